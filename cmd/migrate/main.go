@@ -0,0 +1,179 @@
+// Package main is the migration tool for Bluejay CMS. It applies the schema
+// migrations embedded at build time (db/migrations) to the database named by
+// DB_PATH (or -db), reports the current and pending versions, and rolls
+// migrations back for local development.
+//
+// Usage:
+//
+//	migrate [-db path] [-yes] <command>
+//
+//	status        show the applied version and pending migrations (default)
+//	up            apply every pending migration
+//	down [N|all]  roll back the newest N migrations (default 1); needs -yes
+//	goto V        migrate up or down to version V; going down needs -yes
+//	force V       mark V as applied and clean after repairing a failed migration
+//
+// The server applies pending migrations itself on start unless
+// MIGRATE_ON_START=false; this tool is for deployments that migrate as a
+// separate step and for development.
+package main
+
+import (
+	"flag"    // Command-line flags
+	"fmt"     // Output formatting
+	"io"      // Output destinations
+	"os"      // Exit codes and environment
+	"strconv" // Parsing version and step arguments
+	"strings" // Formatting pending version lists
+
+	"github.com/narendhupati/bluejay-cms/db/migrations"     // Migrations embedded at build time
+	"github.com/narendhupati/bluejay-cms/internal/config"   // DB_PATH from env and CONFIG_FILE
+	"github.com/narendhupati/bluejay-cms/internal/database" // Database connection and migrator
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes one command and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dbPath := flags.String("db", "", "database file (default: DB_PATH, or bluejay.db)")
+	yes := flags.Bool("yes", false, "confirm down migrations, which drop tables and data")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: migrate [-db path] [-yes] status | up | down [N|all] | goto V | force V")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *dbPath == "" {
+		// Only DB_PATH is needed, so the rest of the configuration (such as
+		// SESSION_SECRET) is not validated here
+		lookup, err := config.Lookup(os.Getenv)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		cfg, _ := config.Parse(lookup)
+		*dbPath = cfg.DBPath
+	}
+
+	command, rest := "status", []string(nil)
+	if flags.NArg() > 0 {
+		command, rest = flags.Arg(0), flags.Args()[1:]
+	}
+
+	db, err := database.InitDB(database.Config{Path: *dbPath})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer database.Close(db)
+	m, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	before, err := m.Status()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	switch command {
+	case "status":
+		printStatus(stdout, *dbPath, before)
+		if before.Dirty {
+			return 1
+		}
+		return 0
+
+	case "up":
+		err = m.Up()
+
+	case "down":
+		steps := 1
+		if len(rest) > 0 {
+			if rest[0] == "all" {
+				steps = 0
+			} else if steps, err = strconv.Atoi(rest[0]); err != nil || steps < 1 {
+				fmt.Fprintf(stderr, "down: %q is not a number of migrations or \"all\"\n", rest[0])
+				return 2
+			}
+		}
+		if !*yes {
+			fmt.Fprintln(stderr, "down migrations drop tables and data; run again with -yes (development databases only)")
+			return 2
+		}
+		err = m.Down(steps)
+
+	case "goto", "force":
+		if len(rest) != 1 {
+			fmt.Fprintf(stderr, "%s: expected a version\n", command)
+			return 2
+		}
+		version, perr := strconv.ParseUint(rest[0], 10, 32)
+		if perr != nil {
+			fmt.Fprintf(stderr, "%s: %q is not a version\n", command, rest[0])
+			return 2
+		}
+		if command == "force" {
+			err = m.Force(int(version))
+			break
+		}
+		if uint(version) < before.Current && !*yes {
+			fmt.Fprintln(stderr, "going down drops tables and data; run again with -yes (development databases only)")
+			return 2
+		}
+		err = m.Goto(uint(version))
+
+	default:
+		flags.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	after, err := m.Status()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s: %03d -> %03d\n", command, before.Current, after.Current)
+	printStatus(stdout, *dbPath, after)
+	return 0
+}
+
+// printStatus writes the schema version report.
+func printStatus(w io.Writer, dbPath string, s database.MigrationStatus) {
+	fmt.Fprintf(w, "database: %s\n", dbPath)
+	current := fmt.Sprintf("%03d", s.Current)
+	if s.Current == 0 {
+		current = "none"
+	}
+	if s.Dirty {
+		current += " (dirty: the migration failed part-way; repair the schema, then run: migrate force " + strconv.Itoa(int(s.Current)) + ")"
+	}
+	fmt.Fprintf(w, "current:  %s\n", current)
+	fmt.Fprintf(w, "latest:   %03d\n", s.Latest)
+	if len(s.Pending) == 0 {
+		fmt.Fprintln(w, "pending:  none")
+		return
+	}
+	// A fresh database lists every migration; show the first few
+	var versions []string
+	for i, v := range s.Pending {
+		if i == 10 {
+			versions = append(versions, "...")
+			break
+		}
+		versions = append(versions, fmt.Sprintf("%03d", v))
+	}
+	fmt.Fprintf(w, "pending:  %d (%s)\n", len(s.Pending), strings.Join(versions, ", "))
+}
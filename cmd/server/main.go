@@ -7,20 +7,26 @@ package main
 
 import (
 	// Standard library imports for core functionality
-	"context"   // Used for graceful shutdown with timeout context
-	"log/slog"  // Structured logging throughout the application
-	"net/http"  // HTTP constants and server types
-	"os"        // OS signals for graceful shutdown, environment, and file operations
-	"os/signal" // Signal handling for interrupt/termination signals
-	"time"      // Time utilities for timeouts, rate limiting, and timestamps
-
-	// Third-party Echo web framework and middleware
-	"github.com/labstack/echo/v4"            // High-performance HTTP router and framework
-	"github.com/labstack/echo/v4/middleware" // Built-in Echo middleware (Gzip compression)
+	"context"       // Used for graceful shutdown with timeout context
+	"log/slog"      // Structured logging throughout the application
+	"net/http"      // HTTP constants and server types
+	"os"            // OS signals for graceful shutdown, environment, and file operations
+	"os/signal"     // Signal handling for interrupt/termination signals
+	"path/filepath" // Staging directory for content bundle imports
+	"strings"       // Joining the trusted proxy list
+	"time"          // Time utilities for timeouts, rate limiting, and timestamps
+	_ "time/tzdata" // Embedded zone database, so the site timezone works on hosts without one
+
+	// Third-party Echo web framework
+	"github.com/labstack/echo/v4" // High-performance HTTP router and framework
 
 	// Internal packages - database layer
+	"github.com/narendhupati/bluejay-cms/db/migrations"     // Schema migrations embedded in the binary
 	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Type-safe SQL query code generated by sqlc
+	"github.com/narendhupati/bluejay-cms/internal/config"   // Typed configuration from env and CONFIG_FILE
 	"github.com/narendhupati/bluejay-cms/internal/database" // Database initialization and migrations
+	"github.com/narendhupati/bluejay-cms/internal/doctor"   // Deployment self-check ("doctor" subcommand)
+	"github.com/narendhupati/bluejay-cms/internal/jobs"     // Scheduler for recurring maintenance jobs
 
 	// Internal packages - HTTP handlers (separated by public vs admin access)
 	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"   // Admin panel CRUD handlers
@@ -30,35 +36,58 @@ import (
 	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Custom middleware (auth, logging, security)
 	"github.com/narendhupati/bluejay-cms/internal/services"                    // Business logic services (cache, uploads, etc.)
 	"github.com/narendhupati/bluejay-cms/internal/templates"                   // Template rendering engine wrapper
+	"github.com/narendhupati/bluejay-cms/internal/tracing"                     // OpenTelemetry setup from OTEL_* variables
 )
 
 // main is the application entry point. It performs the following initialization sequence:
-// 1. Sets up structured JSON logging
-// 2. Initializes SQLite database connection and runs migrations
-// 3. Configures session management
-// 4. Sets up Echo web server with middleware stack
-// 5. Initializes business logic services (products, uploads, cache, activity logging)
-// 6. Registers all public and admin route handlers
-// 7. Starts HTTP server on port 28090
-// 8. Waits for OS interrupt signal for graceful shutdown
+//  1. Sets up structured JSON logging and loads the configuration
+//  2. Initializes SQLite database connection and runs migrations
+//  3. Configures session management
+//  4. Sets up Echo web server with middleware stack
+//  5. Initializes business logic services (products, uploads, cache, activity logging)
+//  6. Registers all public and admin route handlers
+//  7. Starts HTTP server on PORT (default 28090), or HTTPS with Let's Encrypt
+//     certificates when TLS_ENABLED or TLS_DOMAINS is set
+//  8. Waits for OS interrupt signal for graceful shutdown
 //
 // The server runs indefinitely until terminated, handling both public website
 // requests and admin panel operations through a single HTTP server instance.
 func main() {
 	// Initialize structured JSON logger for production-ready logging
-	// All logs are written to stdout in JSON format at INFO level and above
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	// All logs are written to stdout in JSON format at INFO level and above;
+	// records logged with a request context carry that request's request_id
+	logger := slog.New(customMiddleware.ContextLogHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
+
+	// Load configuration from environment variables and the optional
+	// CONFIG_FILE (YAML or TOML); see DEPLOYMENT.md, "Configuration".
+	// Every invalid or missing value is reported before anything starts
+	cfg, cfgErr := config.Load(os.Getenv)
+
+	// "bluejay-cms doctor" runs the deployment self-check instead of the server.
+	// It must run before InitDB, which would create a missing database file,
+	// and reports configuration problems itself instead of exiting on them
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		os.Exit(doctor.Run(os.Stdout, doctor.Options{
+			Config:       &cfg,
+			ConfigErr:    cfgErr,
+			Migrations:   migrations.FS,
+			TemplatesDir: "templates",
+		}))
+	}
+	if cfgErr != nil {
+		logger.Error("invalid configuration", "error", cfgErr)
+		os.Exit(1)
+	}
 
 	// Initialize SQLite database connection
-	// Database file "bluejay.db" is created in the current working directory if it doesn't exist
-	dbPath := "bluejay.db"
-	if v := os.Getenv("DB_PATH"); v != "" {
-		dbPath = v
-	}
+	// Database file (DB_PATH, default "bluejay.db") is created if it doesn't exist
+	// DB_AUTOCHECKPOINT=false leaves WAL checkpoints to Litestream or to the
+	// replication job below (see DEPLOYMENT.md, "Continuous Replication")
 	db, err := database.InitDB(database.Config{
-		Path: dbPath,
+		Path:                  cfg.DBPath,
+		DisableAutoCheckpoint: !cfg.DBAutoCheckpoint,
 	})
 	if err != nil {
 		logger.Error("failed to initialize database", "error", err)
@@ -67,47 +96,151 @@ func main() {
 	// Ensure database connection is closed when main exits
 	defer database.Close(db)
 
-	// Run database migrations to ensure schema is up-to-date
-	// Migrations are SQL files in db/migrations directory, executed in order
-	if err := database.RunMigrations(db, "db/migrations"); err != nil {
-		logger.Error("failed to run migrations", "error", err)
+	// Bring the schema up to date with the migrations embedded in this build
+	// (db/migrations). With MIGRATE_ON_START=false they are applied separately
+	// with cmd/migrate, and the server refuses to start on an outdated schema
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		logger.Error("failed to prepare migrations", "error", err)
+		os.Exit(1)
+	}
+	if cfg.MigrateOnStart {
+		if err := migrator.Up(); err != nil {
+			logger.Error("failed to run migrations", "error", err)
+			os.Exit(1)
+		}
+	}
+	schema, err := migrator.Status()
+	if err != nil {
+		logger.Error("failed to read schema version", "error", err)
+		os.Exit(1)
+	}
+	if schema.Dirty || len(schema.Pending) > 0 {
+		logger.Error("database schema is not up to date; run: migrate up", "version", schema.Current, "dirty", schema.Dirty, "pending", len(schema.Pending))
+		os.Exit(1)
+	}
+	logger.Info("database schema", "version", schema.Current)
+
+	// OpenTelemetry tracing, configured by the standard OTEL_* variables (see
+	// DEPLOYMENT.md, "Tracing"); off unless an exporter is set
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.ConfigFromEnv())
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
 		os.Exit(1)
 	}
 
 	// Initialize sqlc-generated query interface for type-safe database operations
 	// All database queries are defined in db/queries/*.sql and compiled to Go code
-	queries := sqlc.New(db)
+	// CountingDB lets the ?debug=templates overlay report per-request query counts
+	// TracingDB records a span per query inside traced requests
+	queries := sqlc.New(database.TracingDB(database.CountingDB(db)))
+
+	// Reporting queries (product and activity exports) run on a separate
+	// read-only pool so long scans do not hold the primary's single connection.
+	// DB_REPORTING_PATH points it at a replica (e.g. one kept by Litestream);
+	// by default it reads the primary file, which WAL mode allows concurrently
+	reportingQueries := queries
+	if replica, err := database.OpenReadOnly(database.Config{Path: cfg.DBReportingPath}); err != nil {
+		logger.Error("read-only reporting pool unavailable, exports use the primary", "path", cfg.DBReportingPath, "error", err)
+	} else {
+		defer database.Close(replica)
+		reportingQueries = sqlc.New(database.TracingDB(database.CountingDB(replica)))
+		adminHandlers.SetReportingQueries(reportingQueries)
+	}
 
 	// Initialize session store with encryption key for secure cookie-based sessions
-	// WARNING: This secret should be replaced with a secure random string in production
-	// The secret must be at least 32 characters for proper AES encryption
-	customMiddleware.InitSessionStore("change-this-secret-in-production-minimum-32-chars")
+	// SESSION_SECRET is required and at least 32 characters (checked by config.Load)
+	// The same secret signs the public recently viewed cookie
+	sessionSecret := cfg.SessionSecret
+	customMiddleware.InitSessionStore(sessionSecret)
 
 	// Create new Echo web framework instance
 	e := echo.New()
 	// Hide the Echo startup banner for cleaner logs
 	e.HideBanner = true
+	// Client addresses (admin IP rules, rate limits, logs) come from the
+	// connection, or from X-Forwarded-For set by a proxy in TRUSTED_PROXIES;
+	// the addresses were checked by config.Load
+	trustedProxies, _ := customMiddleware.ParseIPRules(strings.Join(cfg.TrustedProxies, ","))
+	e.IPExtractor = customMiddleware.ClientIPExtractor(trustedProxies)
 
 	// Configure template renderer for server-side HTML rendering
-	// Templates are loaded from the "templates" directory
+	// Templates are loaded from the "templates" directory and compiled in
+	// parallel; every broken template is logged before exiting
 	// Used by both admin panel and public pages
-	e.Renderer = templates.NewRenderer("templates")
+	renderer, err := templates.LoadRenderer("templates")
+	if err != nil {
+		logger.Error("failed to compile templates", "error", err)
+		os.Exit(1)
+	}
+	stats := renderer.LoadStats()
+	logger.Info("templates compiled", "sets", stats.Sets, "workers", stats.Workers, "duration", stats.Duration)
+	e.Renderer = renderer
+
+	// Static files get content-hashed URLs ({{asset "css/styles.css"}}) so
+	// browsers can cache them for a year; an edited file gets a new URL
+	assets := services.NewAssets("public", "/public")
+	renderer.UseAssets(assets)
+
+	// Admin session limits enforced by SessionTracker (0 disables either):
+	// SESSION_IDLE_TIMEOUT_MINUTES (default 60) signs out sessions with no
+	// requests for that long; SESSION_MAX_LIFETIME_HOURS (default 12) signs out
+	// sessions that long after login, however active
+	sessionTimeouts := customMiddleware.SessionTimeouts{Idle: cfg.SessionIdleTimeout, Absolute: cfg.SessionMaxLifetime}
+
+	// Before routing: admin forms without JavaScript send DELETE/PUT as POST
+	// with a _method field, reaching the routes HTMX calls directly
+	e.Pre(customMiddleware.MethodOverride())
 
 	// Apply middleware stack (executed in order for each request):
-	// 1. Recovery - catches panics and returns 500 errors gracefully
+	// 1. Tracing - server span per request, parent of handler, query and render spans
+	e.Use(customMiddleware.Tracing())
+	// 2. RequestID - assigns or propagates X-Request-ID and adds it to the log context
+	e.Use(customMiddleware.RequestID())
+	// 3. Recovery - catches panics and returns 500 errors gracefully
 	e.Use(customMiddleware.Recovery(logger))
-	// 2. Logging - logs all HTTP requests with method, path, status, and latency
+	// 4. Logging - structured access log: route, status, latency, bytes, user, request ID
 	e.Use(customMiddleware.Logging(logger))
-	// 3. Gzip - compresses responses for faster transfers
-	e.Use(middleware.Gzip())
-	// 4. SecurityHeaders - adds security headers (CSP, X-Frame-Options, etc.)
+	// 5. Compress - Brotli or gzip for HTML, CSS, JavaScript, JSON and XML
+	e.Use(customMiddleware.Compress())
+	// 6. SecurityHeaders - adds security headers (CSP, X-Frame-Options, etc.)
 	e.Use(customMiddleware.SecurityHeaders())
-	// 5. SessionMiddleware - manages user sessions via encrypted cookies
+	// 7. SessionMiddleware - manages user sessions via encrypted cookies
 	e.Use(customMiddleware.SessionMiddleware())
+	// 8. SessionTracker - rejects revoked and timed-out sessions and records last activity
+	e.Use(customMiddleware.SessionTracker(queries, sessionTimeouts))
+	// 9. TemplateDebug - ?debug=templates overlay for signed-in admins
+	e.Use(customMiddleware.TemplateDebug())
+
+	// Errors are rendered through the template engine: branded 404 and error
+	// pages, HTMX fragments and JSON for API routes (see public.ErrorHandler).
+	// Public 404s (path and referrer) are counted first for the broken link
+	// report under Admin > 404s
+	errorHandler := publicHandlers.NewErrorHandler(logger)
+	e.HTTPErrorHandler = customMiddleware.NotFoundTracker(queries, logger, errorHandler.Handle)
 
 	// Serve static files (CSS, JS, images) from the public directory
-	// Accessible at URLs like /public/css/style.css
-	e.Static("/public", "public")
+	// Accessible at URLs like /public/css/styles.css, or with the content
+	// hash the templates add (/public/css/styles.3f9a1c2b0d.css), which is
+	// cached for a year
+	e.GET("/public/*", publicHandlers.NewAssetsHandler(assets).Serve)
+
+	// Theme tokens - light/dark CSS variables from Global Settings, linked by
+	// both the public and admin layouts (registered outside the public group
+	// so admin pages get them without the public middleware)
+	e.GET("/theme-tokens.css", publicHandlers.NewThemeHandler(queries, logger).Tokens)
+
+	// Site timezone - timestamps are stored in UTC and displayed in the zone
+	// configured under Global Settings (UTC until one is set)
+	if settings, err := queries.GetSettings(context.Background()); err == nil {
+		if err := services.SetSiteTimezone(settings.Timezone); err != nil {
+			logger.Warn("invalid site timezone, using UTC", "timezone", settings.Timezone)
+		}
+		// Number and price format of the template functions (en-US, USD until set)
+		if err := services.SetSiteFormat(settings.FormatLocale, settings.Currency); err != nil {
+			logger.Warn("invalid number format, using en-US and USD", "format_locale", settings.FormatLocale, "currency", settings.Currency)
+		}
+	}
 
 	// Initialize business logic services used across multiple handlers
 	// These services provide reusable functionality and maintain separation of concerns
@@ -116,8 +249,8 @@ func main() {
 	productSvc := services.NewProductService(queries)
 
 	// UploadService - manages file uploads, validation, and storage
-	// Files are stored in "public/uploads" directory
-	uploadSvc := services.NewUploadService("public/uploads")
+	// Files are stored in UPLOAD_DIR (default "public/uploads")
+	uploadSvc := services.NewUploadService(cfg.UploadDir)
 
 	// Cache - in-memory cache for frequently accessed data to reduce database queries
 	// Used for settings, categories, and other relatively static content
@@ -131,23 +264,320 @@ func main() {
 	// This global injection allows handlers to log activities without tight coupling
 	adminHandlers.SetActivityLogService(activitySvc)
 
+	// PreviewTokens - signs the expiring ?preview_token= links on admin edit
+	// pages; each link unlocks one draft without a login, for sharing with reviewers
+	previewTokens := services.NewPreviewTokens(sessionSecret, services.DefaultPreviewTTL)
+	adminHandlers.SetPreviewTokens(previewTokens)
+
+	// Mailer - sends form notifications, workflow and export emails and
+	// customer password resets when SMTP_HOST is set (SMTP_PORT,
+	// SMTP_USERNAME, SMTP_PASSWORD, SMTP_FROM); nil disables email
+	var mailer services.Mailer
+	if cfg.SMTP.Host != "" {
+		mailer = services.NewSMTPMailer(services.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		})
+	}
+
+	// DownloadLinks - signs the expiring links that unlock gated product
+	// downloads, handed out by the lead form and the customer account page
+	downloadLinks := services.NewDownloadLinks(sessionSecret, 0)
+
+	// DownloadFiles - keeps the files of gated downloads in GATED_DOWNLOAD_DIR,
+	// outside the public uploads; files gated before an upgrade are moved
+	// at startup
+	downloadFiles := services.NewDownloadFiles(queries, cfg.UploadDir, cfg.GatedDownloadDir)
+	if err := downloadFiles.SyncAll(context.Background()); err != nil {
+		logger.Error("failed to move gated download files", "error", err)
+	}
+
+	// CustomerAccounts - public visitor accounts (/account): sign-in sessions,
+	// password resets and the library of downloads
+	customerAccounts := services.NewCustomerAccounts(db, queries, logger, mailer, cfg.SiteBaseURL)
+
+	// Newsletter - footer sign-ups with double opt-in; confirmed addresses
+	// are synced to the mailing list provider when NEWSLETTER_PROVIDER is set
+	// (NEWSLETTER_API_KEY, NEWSLETTER_LIST_ID)
+	newsletterProvider := services.NewNewsletterProvider(cfg.Newsletter.Provider, cfg.Newsletter.APIKey, cfg.Newsletter.ListID)
+	newsletter := services.NewNewsletter(queries, logger, mailer, newsletterProvider, cfg.SiteBaseURL)
+
+	// Events - the /events listing and event registrations; registrants are
+	// emailed a confirmation with the webinar join link
+	events := services.NewEvents(queries, logger, mailer, cfg.SiteBaseURL)
+
+	// Careers - job postings on /careers and applications; CVs are kept in
+	// APPLICATION_DIR, outside the public uploads, and served to admins only
+	careers := services.NewCareers(queries, services.NewLocalStorage(cfg.ApplicationDir), logger)
+
+	// Docs - versioned product documentation; adds versions as copies of
+	// existing ones and keeps page slugs unique within a version
+	docs := services.NewDocs(db, queries)
+
+	// Partner portal - tokenized links partners use to send logo, description
+	// and tier updates; updates wait for admin approval before going live
+	partnerPortal := services.NewPartnerPortal(db, queries, uploadSvc, logger, mailer, cfg.SiteBaseURL)
+
+	// HTMLSanitizer - cleans rich-text HTML (blog bodies, solution overviews,
+	// case study sections) on save to prevent stored XSS. The default allowlist
+	// covers Trix and Markdown output; comma-separated settings extend it:
+	//   HTML_ALLOW_ELEMENTS   extra elements, e.g. "mark,abbr"
+	//   HTML_ALLOW_ATTRIBUTES element:attribute pairs, e.g. "abbr:title,*:style"
+	//   HTML_IFRAME_HOSTS     hosts allowed in https iframe embeds, e.g. "www.youtube.com"
+	htmlSanitizer := services.NewHTMLSanitizer(services.SanitizerConfig{
+		AllowElements:   cfg.HTMLAllowElements,
+		AllowAttributes: cfg.HTMLAllowAttributes,
+		IframeHosts:     cfg.HTMLIframeHosts,
+	})
+	adminHandlers.SetHTMLSanitizer(htmlSanitizer)
+
+	// SafeHTMLAudit - development aid, enabled with SAFEHTML_AUDIT=true.
+	// Records which template fields pass through safeHTML and whether the
+	// sanitizer would change their values, so fields can be migrated to
+	// sanitize-on-save one at a time. Report: GET /admin/safehtml-audit
+	var safeHTMLAudit *templates.SafeHTMLAudit
+	if cfg.SafeHTMLAudit {
+		safeHTMLAudit = templates.NewSafeHTMLAudit(htmlSanitizer.Sanitize, logger)
+		renderer.AuditSafeHTML(safeHTMLAudit)
+		logger.Warn("safeHTML audit enabled; sanitizes every safeHTML value twice, do not use in production")
+	}
+
+	// Background jobs share a context that is cancelled during graceful shutdown
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Scheduler - recurring maintenance jobs, registered below next to the
+	// services they run and started once all are added. Last runs are kept in
+	// job_runs and shown at GET /admin/jobs, where jobs can be run by hand
+	scheduler := jobs.New(queries, logger, jobs.Config{})
+
+	// ArchiveService - monthly compliance export of activity_log and lead tables
+	// Completed months are written as gzip JSON Lines to ARCHIVE_DIR (append-only),
+	// hash-chained unless ARCHIVE_HASH_CHAIN=false, and live rows older than
+	// ARCHIVE_RETENTION_MONTHS (default 24, 0 = keep forever) are pruned afterwards
+	archiveSvc := services.NewArchiveService(queries, services.NewLocalStorage(cfg.ArchiveDir), logger, services.ArchiveConfig{
+		RetentionMonths: cfg.ArchiveRetentionMonths,
+		HashChain:       cfg.ArchiveHashChain,
+	})
+	scheduler.Add(jobs.Job{
+		Name:        "archive",
+		Description: "Export complete months of activity and lead tables to ARCHIVE_DIR, then prune rows past retention",
+		Schedule:    jobs.MustCron("0 2 * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := archiveSvc.Run(ctx, time.Now())
+			return err
+		},
+	})
+
+	// ActivityRetention - daily pruning of activity_log entries older than
+	// ACTIVITY_LOG_RETENTION_DAYS (default 0 = disabled; ARCHIVE_RETENTION_MONTHS
+	// still applies). Entries are kept until the archive job has exported their month
+	activityRetention := services.NewActivityRetention(queries, logger, cfg.ActivityLogRetentionDays)
+	scheduler.Add(jobs.Job{
+		Name:        "activity-log-pruning",
+		Description: "Delete activity log entries older than ACTIVITY_LOG_RETENTION_DAYS",
+		Schedule:    cronIf(activityRetention.Days() > 0, "15 2 * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := activityRetention.Prune(ctx, time.Now())
+			return err
+		},
+	})
+
+	// TrashPurge - daily permanent deletion of content that has been in the
+	// admin trash for more than TRASH_RETENTION_DAYS (default 30; 0 = never)
+	trashPurge := services.NewTrashPurge(queries, logger, cfg.TrashRetentionDays)
+	scheduler.Add(jobs.Job{
+		Name:        "trash-purge",
+		Description: "Permanently delete content trashed more than TRASH_RETENTION_DAYS ago",
+		Schedule:    cronIf(trashPurge.Days() > 0, "30 2 * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := trashPurge.Purge(ctx, time.Now())
+			return err
+		},
+	})
+
+	// TranslationService - per-entity translation records and coverage tracking
+	// SITE_LOCALES is a comma-separated list; the first entry is the source
+	// language and the rest are translation targets (default "en", no targets)
+	siteLocales := cfg.SiteLocales
+	translationSvc := services.NewTranslationService(queries, logger, siteLocales[0], siteLocales[1:])
+	// SITE_LOCALES seeds the locales table; afterwards languages are managed
+	// under Admin > Languages and the table is authoritative
+	if err := translationSvc.SyncLocales(jobCtx); err != nil {
+		logger.Error("failed to sync locales", "error", err)
+		os.Exit(1)
+	}
+
+	// Inject translation service into public and admin handlers for localized
+	// rendering and the language tabs on edit forms
+	publicHandlers.SetTranslationService(translationSvc)
+	adminHandlers.SetTranslationService(translationSvc)
+
+	// Redirects - rules from changed slugs and Admin > Redirects, served from
+	// memory; edit handlers record a rule whenever a public path changes
+	redirectSvc := services.NewRedirects(queries, logger)
+	if err := redirectSvc.Reload(jobCtx); err != nil {
+		logger.Error("failed to load redirects", "error", err)
+		os.Exit(1)
+	}
+	adminHandlers.SetRedirects(redirectSvc)
+
+	// Webhooks - signed JSON POSTs to the endpoints under Admin > Webhooks when
+	// public content is published, updated or deleted (for static-site
+	// rebuilds or CDN purges); sent by a background worker with retries
+	webhookSvc := services.NewWebhooks(queries, logger)
+	webhookSvc.Start(jobCtx)
+	adminHandlers.SetWebhooks(webhookSvc)
+
+	// EditorAttachments - images uploaded from the Trix editors go to the media
+	// library; ones no content refers to any more (a day after upload) are
+	// removed when content is deleted, and by a daily job
+	editorAttachmentSvc := services.NewEditorAttachments(db, queries, cfg.UploadDir, logger)
+	editorAttachmentSvc.Start(jobCtx)
+	adminHandlers.SetEditorAttachments(editorAttachmentSvc)
+	scheduler.Add(jobs.Job{
+		Name:        "editor-attachment-cleanup",
+		Description: "Remove images uploaded in the editors that no content uses",
+		Schedule:    jobs.MustCron("45 2 * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := editorAttachmentSvc.PruneOrphans(ctx, time.Now())
+			return err
+		},
+	})
+
+	// SEO - per-page metadata overrides from Admin > SEO, served from memory
+	seoSvc := services.NewSEO(queries)
+	if err := seoSvc.Reload(jobCtx); err != nil {
+		logger.Error("failed to load SEO overrides", "error", err)
+		os.Exit(1)
+	}
+
+	// Navigation - active Admin > Navigation menus for the public header and footer, served from memory
+	navSvc := services.NewNavigation(queries)
+	if err := navSvc.Reload(jobCtx); err != nil {
+		logger.Error("failed to load navigation menus", "error", err)
+		os.Exit(1)
+	}
+
+	// Site data - settings and footer links of every public page, served from memory
+	// and reloaded after admin saves (see InvalidateSiteData) or once a minute
+	siteData := services.NewSiteData(customMiddleware.QuerySiteData(queries))
+
+	// Content blocks - Admin > Content Blocks attached to public pages, served from memory
+	blockSvc := services.NewContentBlocks(queries)
+	if err := blockSvc.Reload(jobCtx); err != nil {
+		logger.Error("failed to load content blocks", "error", err)
+		os.Exit(1)
+	}
+
+	// Serve /<locale>/... URLs (e.g., /de/products) by stripping the prefix
+	// before routing; the Locale middleware below picks the language up
+	e.Pre(customMiddleware.LocalePrefix(translationSvc))
+
+	// DBHealth - watchdog that flips the site into read-only recovery mode when
+	// SQLite is locked or corrupt (public pages are served from snapshots, admin
+	// writes are refused) and recovers automatically once the database is healthy.
+	// Mode changes are logged at error level and, if DB_ALERT_WEBHOOK is set,
+	// POSTed there as JSON to alert operators.
+	dbHealth := services.NewDBHealth(db, logger, services.DBHealthConfig{
+		AlertWebhook: cfg.DBAlertWebhook,
+	})
+	dbHealth.Start(jobCtx)
+
+	// Replication - checkpoints the WAL after writes and runs DB_CHECKPOINT_HOOK
+	// (via sh -c) after each checkpoint, for snapshot scripts or replicators.
+	// Runs when a hook is set or DB_AUTOCHECKPOINT=false; checks every
+	// DB_CHECKPOINT_INTERVAL_SECONDS (default 10) in DB_CHECKPOINT_MODE
+	// (PASSIVE by default, which is safe alongside Litestream)
+	if cfg.DBCheckpointHook != "" || !cfg.DBAutoCheckpoint {
+		services.NewReplication(db, cfg.DBPath, logger, services.ReplicationConfig{
+			CheckpointMode: cfg.DBCheckpointMode,
+			Command:        cfg.DBCheckpointHook,
+			Interval:       cfg.DBCheckpointInterval,
+		}).Start(jobCtx)
+	}
+
+	// Analytics - first-party page views for Admin > Analytics, recorded by the
+	// PageViews middleware below and written in batches every few seconds.
+	// Countries come from the CDN header ANALYTICS_COUNTRY_HEADER or the IP
+	// ranges in ANALYTICS_GEOIP_CSV; finished days are rolled up nightly
+	var geoIP *services.GeoIP
+	if cfg.AnalyticsGeoIPCSV != "" {
+		if geoIP, err = services.OpenGeoIP(cfg.AnalyticsGeoIPCSV); err != nil {
+			logger.Warn("GeoIP ranges not loaded, page view countries are unknown", "error", err)
+		} else {
+			logger.Info("GeoIP ranges loaded", "ranges", geoIP.Len())
+		}
+	}
+	analyticsSvc := services.NewAnalytics(db, queries, logger, services.AnalyticsConfig{
+		GeoIP:         geoIP,
+		CountryHeader: cfg.AnalyticsCountryHeader,
+	})
+	analyticsSvc.Start(jobCtx)
+	scheduler.Add(jobs.Job{
+		Name:        "analytics-rollup",
+		Description: "Fold the page views of finished days (UTC) into daily counts",
+		Schedule:    jobs.MustCron("10 0 * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := analyticsSvc.Rollup(ctx, time.Now())
+			return err
+		},
+	})
+
 	// ═══════════════════════════════════════════════════════════════════════════
 	// PUBLIC ROUTES - accessible to all visitors without authentication
 	// ═══════════════════════════════════════════════════════════════════════════
 
 	// Create route group for all public pages (empty prefix means root level)
 	publicGroup := e.Group("")
-	// Load site settings (logo, title, meta tags) into context for every public request
-	// This middleware makes settings available to all public templates
-	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	// Serve saved page snapshots with a read-only banner while the database is
+	// unavailable (registered first so degraded requests never touch the database)
+	publicGroup.Use(customMiddleware.ReadOnlyFallback(dbHealth, appCache))
+	// Send old URLs (changed slugs, manual rules, wildcard patterns) to their new
+	// location with a 301 or 302 before any page is rendered
+	publicGroup.Use(customMiddleware.Redirects(redirectSvc))
+	// Load site settings (logo, title, meta tags) and footer links into context for
+	// every public request, from the in-memory snapshot that admin saves invalidate
+	publicGroup.Use(customMiddleware.SiteDataLoader(siteData))
+	// Resolve the visitor's locale from the URL prefix, ?lang= or the "lang" cookie
+	publicGroup.Use(customMiddleware.Locale(translationSvc))
+	// Hand the active Admin > Navigation menus to the header and footer templates
+	publicGroup.Use(customMiddleware.Navigation(navSvc))
+	// Maintenance mode (Global Settings): visitors get the maintenance page
+	// with 503 and Retry-After; signed-in admins still browse the site
+	publicGroup.Use(customMiddleware.Maintenance(publicHandlers.MaintenancePage))
+	// Admit draft previews carrying a valid ?preview_token= (invalid or expired links get 403)
+	publicGroup.Use(customMiddleware.Preview(previewTokens))
+	// Recognise customers signed in with the bluejay_account cookie
+	publicGroup.Use(customMiddleware.Customer(customerAccounts))
+	// Apply Admin > SEO overrides (title, description, canonical, social image,
+	// noindex) to the page rendered for this path or content item
+	publicGroup.Use(customMiddleware.SEO(seoSvc))
+	// Hand the Admin > Content Blocks attached to this path to the public layout
+	publicGroup.Use(customMiddleware.ContentBlocks(blockSvc))
+	// Count views of public HTML pages for Admin > Analytics (not bots, HTMX
+	// swaps, previews, signed-in admins or Do Not Track visitors)
+	publicGroup.Use(customMiddleware.PageViews(analyticsSvc))
 
 	// Homepage route - displays hero sections, stats, testimonials, and CTAs
 	homeHandler := publicHandlers.NewHomeHandler(queries, logger)
 	publicGroup.GET("/", homeHandler.ShowHomePage)
 
 	// Health check endpoint - returns JSON status for monitoring/load balancers
-	// Used by infrastructure to verify the application is running
+	// Used by infrastructure to verify the application is running; reports 503
+	// "degraded" while the site is in read-only recovery mode
 	publicGroup.GET("/health", func(c echo.Context) error {
+		if st := dbHealth.Status(); st.Degraded {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{
+				"status":   "degraded",
+				"database": st.LastError,
+				"since":    st.Since.Format(time.RFC3339),
+				"time":     time.Now().Format(time.RFC3339),
+			})
+		}
 		return c.JSON(http.StatusOK, map[string]string{
 			"status": "ok",
 			"time":   time.Now().Format(time.RFC3339),
@@ -168,13 +598,54 @@ func main() {
 	// Returns HTML fragment with matching products for dynamic updates
 	publicGroup.GET("/products/search", productsHandler.ProductSearch)
 
+	// GET /products/a-z - alphabetical index of every published product
+	publicGroup.GET("/products/a-z", productsHandler.ProductIndex)
+
 	// GET /products/:category - filtered product list by category slug
 	publicGroup.GET("/products/:category", productsHandler.ProductsByCategory)
 
+	// GET /partials/products/:category - HTMX endpoint for a category's product grid
+	// Pagination links swap just the grid and push the full-page URL to history
+	publicGroup.GET("/partials/products/:category", productsHandler.ProductsCategoryGrid)
+
+	// GET /partials/recently-viewed - HTMX widget on product and category pages
+	// Records ?current= in a signed cookie and lists the visitor's other recent products
+	recentlyViewedHandler := publicHandlers.NewRecentlyViewedHandler(queries, logger, services.NewRecentlyViewedCodec(sessionSecret))
+	publicGroup.GET("/partials/recently-viewed", recentlyViewedHandler.RecentlyViewed)
+
 	// GET /products/:category/:slug - individual product detail page
 	// Shows specs, features, certifications, images, and downloads
 	publicGroup.GET("/products/:category/:slug", productsHandler.ProductDetail)
 
+	// GET /products/:category/:slug/print - printable spec sheet (print layout)
+	publicGroup.GET("/products/:category/:slug/print", productsHandler.ProductPrint)
+
+	// GET /products/:category/:slug/spec-sheet.pdf - branded PDF datasheet, generated
+	// on demand from the product's specs and cached with the product page
+	specSheetHandler := publicHandlers.NewSpecSheetHandler(logger, productSvc, services.NewSpecSheets(cfg.UploadDir), appCache, cfg.SiteBaseURL)
+	publicGroup.GET("/products/:category/:slug/spec-sheet.pdf", specSheetHandler.SpecSheet)
+
+	// Product downloads: every file version is linked through /product-downloads/:id,
+	// which counts the download and redirects to the file. Gated files need a signed
+	// ?token= that visitors get from the lead form, loaded into a modal on the product page.
+	productDownloadsHandler := publicHandlers.NewProductDownloadsHandler(queries, logger, downloadLinks, downloadFiles)
+	publicGroup.GET("/product-downloads/:id", productDownloadsHandler.Download)
+	publicGroup.GET("/product-downloads/:id/form", productDownloadsHandler.LeadForm) // HTMX: lead form of a gated file
+	publicGroup.POST("/product-downloads/:id/lead", productDownloadsHandler.Lead)    // Record the lead, return the signed link
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public JSON API (read-only)
+	// ─────────────────────────────────────────────────────────────────────────
+	// Registered outside publicGroup: responses are JSON, so neither the
+	// settings loader nor the HTML snapshot fallback applies. Lists use
+	// opaque keyset cursors instead of page numbers.
+
+	apiHandler := publicHandlers.NewAPIHandler(queries, logger, productSvc)
+	apiGroup := e.Group("/api/v1")
+
+	// GET /api/v1/products - published products, ?cursor= for the next page
+	apiGroup.GET("/products", apiHandler.Products)
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// Public Solution Routes (Phase 4)
 	// ─────────────────────────────────────────────────────────────────────────
@@ -199,6 +670,16 @@ func main() {
 	// GET /blog - blog post listing with filtering by category, author, and tag
 	publicGroup.GET("/blog", blogHandler.BlogListing)
 
+	// GET /partials/blog - HTMX endpoint for the listing's tabs, grid, and pagination
+	publicGroup.GET("/partials/blog", blogHandler.BlogListingGrid)
+
+	// GET /blog/:year/:month - posts published in one month (e.g. /blog/2024/05)
+	publicGroup.GET("/blog/:year/:month", blogHandler.BlogArchive)
+
+	// GET /blog/tags - tag cloud; /blog/tags/:slug - posts with one tag
+	publicGroup.GET("/blog/tags", blogHandler.TagCloud)
+	publicGroup.GET("/blog/tags/:slug", blogHandler.TagPosts)
+
 	// GET /blog/:slug - individual blog post detail page
 	// Shows rich content, author info, related products, and tags
 	publicGroup.GET("/blog/:slug", blogHandler.BlogPost)
@@ -208,7 +689,7 @@ func main() {
 	// ─────────────────────────────────────────────────────────────────────────
 	// Serves uploaded images, PDFs, and other media files
 	// Accessible at URLs like /uploads/images/product-photo.jpg
-	e.Static("/uploads", "public/uploads")
+	e.Static("/uploads", cfg.UploadDir)
 
 	// ═══════════════════════════════════════════════════════════════════════════
 	// ADMIN ROUTES - authentication and protected admin panel
@@ -219,7 +700,12 @@ func main() {
 	// ─────────────────────────────────────────────────────────────────────────
 	// Login/logout endpoints that don't require existing authentication
 
-	adminAuthGroup := e.Group("/admin")
+	// Every /admin route, the login page included, is refused to addresses
+	// outside the network rules saved under Admin Access (none by default);
+	// ADMIN_IP_BYPASS_TOKEN lets a locked-out administrator back in
+	adminIPFilter := customMiddleware.AdminIPFilter(siteData, cfg.AdminIPBypassToken)
+
+	adminAuthGroup := e.Group("/admin", adminIPFilter)
 	authHandler := adminHandlers.NewAuthHandler(queries, logger)
 
 	// GET /admin/login - displays login form
@@ -235,13 +721,29 @@ func main() {
 	// Protected Admin Routes - require authentication
 	// ─────────────────────────────────────────────────────────────────────────
 	// All routes in this group check for valid session before allowing access
-	// RequireAuth middleware redirects unauthenticated users to /admin/login
-
-	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	// Each route group picks its Authenticator; the admin panel accepts the login
+	// session cookie and redirects unauthenticated users to /admin/login
+
+	adminGroup := e.Group("/admin", adminIPFilter, customMiddleware.Authenticate(customMiddleware.SessionAuthenticator{LoginURL: "/admin/login"}))
+	// Refuse saves with a clear 503 while the database is in read-only recovery mode
+	adminGroup.Use(customMiddleware.ReadOnlyGuard(dbHealth))
+	// Accept each one-time form token once, so double-clicks and re-posted forms
+	// do not create duplicates (admin forms render one with {{formToken}})
+	adminGroup.Use(customMiddleware.NewFormTokens(time.Hour).Middleware())
+	// Guess each admin's own time zone from Accept-Language, for timestamps such
+	// as the activity log that are shown in the viewer's local time
+	adminGroup.Use(customMiddleware.VisitorTimezone(services.TimezoneFromAcceptLanguage))
+	// Reload the public settings and footer snapshot after every admin save
+	adminGroup.Use(customMiddleware.InvalidateSiteData(siteData))
 
 	// Dashboard - main admin panel landing page with stats and recent activity
 	dashboardHandler := adminHandlers.NewDashboardHandler(queries, logger)
-	adminGroup.GET("/dashboard", dashboardHandler.ShowDashboard)
+	adminGroup.GET("/dashboard", dashboardHandler.ShowDashboard)    // Overview with metric cards
+	adminGroup.GET("/dashboard/cards/:card", dashboardHandler.Card) // One card, for HTMX refresh
+
+	// Command palette (Ctrl+K): content and settings matches with edit links
+	adminSearchHandler := adminHandlers.NewAdminSearchHandler(queries, logger)
+	adminGroup.GET("/search", adminSearchHandler.Search)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Master Table CRUD Routes (Phase 2)
@@ -321,6 +823,22 @@ func main() {
 	settingsHandler := adminHandlers.NewSettingsHandler(queries, logger, appCache)
 	adminGroup.GET("/settings", settingsHandler.Edit)
 	adminGroup.POST("/settings", settingsHandler.Update)
+	adminGroup.GET("/settings/export", settingsHandler.Export)
+	adminGroup.GET("/settings/import", settingsHandler.Import)
+	adminGroup.POST("/settings/import", settingsHandler.ImportPreview)
+	adminGroup.POST("/settings/import/apply", settingsHandler.ImportApply)
+
+	// Admin Access - networks the admin panel may be reached from (admins only)
+	adminAccessHandler := adminHandlers.NewAdminAccessHandler(queries, logger)
+	accessGroup := adminGroup.Group("/access", customMiddleware.RequireRole("admin"))
+	accessGroup.GET("", adminAccessHandler.Edit)
+	accessGroup.POST("", adminAccessHandler.Update)
+
+	// HTTPS Domains - host names of the built-in HTTPS server (admins only)
+	httpsDomainsHandler := adminHandlers.NewHTTPSDomainsHandler(queries, logger, cfg.TLSDomains, cfg.TLSEnabled())
+	httpsGroup := adminGroup.Group("/https", customMiddleware.RequireRole("admin"))
+	httpsGroup.GET("", httpsDomainsHandler.Edit)
+	httpsGroup.POST("", httpsDomainsHandler.Update)
 
 	// Page Sections - manage reusable content blocks across pages
 	psHandler := adminHandlers.NewPageSectionsHandler(queries, logger)
@@ -333,13 +851,26 @@ func main() {
 	// ─────────────────────────────────────────────────────────────────────────
 	// Full CRUD interface for products with image uploads and cache invalidation
 
+	// "Save as copy" for products, blog posts, solutions and case studies:
+	// deep-copies the item and its sub-resources as a draft
+	duplicateHandler := adminHandlers.NewDuplicateHandler(services.NewContentDuplicator(db, queries), logger)
+
 	adminProductsHandler := adminHandlers.NewProductsHandler(queries, logger, uploadSvc, appCache)
-	adminGroup.GET("/products", adminProductsHandler.List)          // List all products with search/filter
-	adminGroup.GET("/products/new", adminProductsHandler.New)       // Show product creation form
-	adminGroup.POST("/products", adminProductsHandler.Create)       // Process new product with image upload
-	adminGroup.GET("/products/:id/edit", adminProductsHandler.Edit) // Show edit form with existing data
-	adminGroup.POST("/products/:id", adminProductsHandler.Update)   // Update product, invalidate cache
-	adminGroup.DELETE("/products/:id", adminProductsHandler.Delete) // Delete product (HTMX response)
+	adminGroup.GET("/products", adminProductsHandler.List)                      // List all products with search/filter
+	adminGroup.GET("/products/new", adminProductsHandler.New)                   // Show product creation form
+	adminGroup.POST("/products", adminProductsHandler.Create)                   // Process new product with image upload
+	adminGroup.GET("/products/:id/edit", adminProductsHandler.Edit)             // Show edit form with existing data
+	adminGroup.POST("/products/:id", adminProductsHandler.Update)               // Update product, invalidate cache
+	adminGroup.DELETE("/products/:id", adminProductsHandler.Delete)             // Delete product (HTMX response)
+	adminGroup.PATCH("/products/:id/inline", adminProductsHandler.InlineUpdate) // Publish/feature/order from the list (HTMX row)
+	adminGroup.POST("/products/:id/duplicate", duplicateHandler.Product)        // Save as copy, then open the copy
+	adminGroup.GET("/products/export", adminProductsHandler.Export)             // Download all products as CSV or XLSX (streamed)
+
+	// CSV import: upload, map columns, dry run, then one transaction for all rows
+	productImportHandler := adminHandlers.NewProductImportHandler(services.NewProductImporter(db, queries), logger, appCache)
+	adminGroup.GET("/products/import", productImportHandler.Show)     // Upload step
+	adminGroup.POST("/products/import", productImportHandler.Upload)  // Parse file, render column mapping
+	adminGroup.POST("/products/import/run", productImportHandler.Run) // Validate (dry run) or import
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Product Sub-Entity Routes (Phase 3)
@@ -347,63 +878,108 @@ func main() {
 	// HTMX endpoints for managing product details: specs, features, certs, etc.
 	// These routes return HTML fragments for in-page updates without full reload
 
-	pdHandler := adminHandlers.NewProductDetailsHandler(queries, logger, uploadSvc)
+	pdHandler := adminHandlers.NewProductDetailsHandler(queries, logger, uploadSvc, downloadFiles)
+
+	// Without JavaScript each tab opens as a page of its own, and its forms
+	// post back to it (Post/Redirect/Get; see admin.HTMXFallback)
+	productTab := func(title, tab string) echo.MiddlewareFunc {
+		return adminHandlers.HTMXFallback(adminHandlers.Fallback{Title: title, List: "/admin/products/:id/" + tab, Back: "/admin/products/:id/edit"})
+	}
+	specsPage := productTab("Product Specifications", "specs")
+	featuresPage := productTab("Product Features", "features")
+	certificationsPage := productTab("Product Certifications", "certifications")
+	downloadsPage := productTab("Product Downloads", "downloads")
+	imagesPage := productTab("Product Images", "images")
+	variantsPage := productTab("Product Variants", "variants")
+	relatedPage := productTab("Related Content", "related")
 
 	// Technical Specifications - key/value pairs (e.g., "Weight: 2.5kg")
-	adminGroup.GET("/products/:id/specs", pdHandler.ListSpecs)              // HTMX: render specs list
-	adminGroup.POST("/products/:id/specs", pdHandler.AddSpec)               // HTMX: add new spec
-	adminGroup.DELETE("/products/:id/specs", pdHandler.DeleteSpecs)         // HTMX: bulk delete specs
-	adminGroup.DELETE("/products/:id/specs/:spec_id", pdHandler.DeleteSpec) // HTMX: delete single spec
-	adminGroup.POST("/products/:id/specs/:spec_id", pdHandler.UpdateSpec)   // HTMX: update single spec
+	adminGroup.GET("/products/:id/specs", pdHandler.ListSpecs, specsPage)              // HTMX: render specs list
+	adminGroup.POST("/products/:id/specs", pdHandler.AddSpec, specsPage)               // HTMX: add new spec
+	adminGroup.DELETE("/products/:id/specs", pdHandler.DeleteSpecs, specsPage)         // HTMX: bulk delete specs
+	adminGroup.DELETE("/products/:id/specs/:spec_id", pdHandler.DeleteSpec, specsPage) // HTMX: delete single spec
+	adminGroup.POST("/products/:id/specs/:spec_id", pdHandler.UpdateSpec, specsPage)   // HTMX: update single spec
 
 	// Features - bullet points highlighting product capabilities
-	adminGroup.GET("/products/:id/features", pdHandler.ListFeatures)                 // HTMX: render features list
-	adminGroup.POST("/products/:id/features", pdHandler.AddFeature)                  // HTMX: add new feature
-	adminGroup.DELETE("/products/:id/features", pdHandler.DeleteFeatures)            // HTMX: bulk delete features
-	adminGroup.DELETE("/products/:id/features/:feature_id", pdHandler.DeleteFeature) // HTMX: delete single feature
-	adminGroup.POST("/products/:id/features/:feature_id", pdHandler.UpdateFeature)   // HTMX: update single feature
+	adminGroup.GET("/products/:id/features", pdHandler.ListFeatures, featuresPage)                 // HTMX: render features list
+	adminGroup.POST("/products/:id/features", pdHandler.AddFeature, featuresPage)                  // HTMX: add new feature
+	adminGroup.DELETE("/products/:id/features", pdHandler.DeleteFeatures, featuresPage)            // HTMX: bulk delete features
+	adminGroup.DELETE("/products/:id/features/:feature_id", pdHandler.DeleteFeature, featuresPage) // HTMX: delete single feature
+	adminGroup.POST("/products/:id/features/:feature_id", pdHandler.UpdateFeature, featuresPage)   // HTMX: update single feature
 
 	// Certifications - compliance badges and industry certifications
-	adminGroup.GET("/products/:id/certifications", pdHandler.ListCertifications)              // HTMX: render certs list
-	adminGroup.POST("/products/:id/certifications", pdHandler.AddCertification)               // HTMX: add new cert
-	adminGroup.DELETE("/products/:id/certifications", pdHandler.DeleteCertifications)         // HTMX: bulk delete certs
-	adminGroup.DELETE("/products/:id/certifications/:cert_id", pdHandler.DeleteCertification) // HTMX: delete single cert
-	adminGroup.POST("/products/:id/certifications/:cert_id", pdHandler.UpdateCertification)   // HTMX: update single cert
+	adminGroup.GET("/products/:id/certifications", pdHandler.ListCertifications, certificationsPage)              // HTMX: render certs list
+	adminGroup.POST("/products/:id/certifications", pdHandler.AddCertification, certificationsPage)               // HTMX: add new cert
+	adminGroup.DELETE("/products/:id/certifications", pdHandler.DeleteCertifications, certificationsPage)         // HTMX: bulk delete certs
+	adminGroup.DELETE("/products/:id/certifications/:cert_id", pdHandler.DeleteCertification, certificationsPage) // HTMX: delete single cert
+	adminGroup.POST("/products/:id/certifications/:cert_id", pdHandler.UpdateCertification, certificationsPage)   // HTMX: update single cert
 
 	// Downloads - datasheets, manuals, CAD files
-	adminGroup.GET("/products/:id/downloads", pdHandler.ListDownloads)                  // HTMX: render downloads list
-	adminGroup.POST("/products/:id/downloads", pdHandler.AddDownload)                   // HTMX: upload new file
-	adminGroup.DELETE("/products/:id/downloads/:download_id", pdHandler.DeleteDownload) // HTMX: delete specific file
-	adminGroup.POST("/products/:id/downloads/:download_id", pdHandler.UpdateDownload)   // HTMX: update download metadata
+	adminGroup.GET("/products/:id/downloads", pdHandler.ListDownloads, downloadsPage)                  // HTMX: render downloads list
+	adminGroup.POST("/products/:id/downloads", pdHandler.AddDownload, downloadsPage)                   // HTMX: upload new file
+	adminGroup.DELETE("/products/:id/downloads/:download_id", pdHandler.DeleteDownload, downloadsPage) // HTMX: delete specific file
+	adminGroup.POST("/products/:id/downloads/:download_id", pdHandler.UpdateDownload, downloadsPage)   // HTMX: update download metadata
+	adminGroup.GET("/products/:id/downloads/:download_id/file", pdHandler.DownloadFile)                // open file, gated ones included
 
 	// Product Images - photo gallery for product detail pages
-	adminGroup.GET("/products/:id/images", pdHandler.ListImages)               // HTMX: render image gallery
-	adminGroup.POST("/products/:id/images", pdHandler.AddImage)                // HTMX: upload new image
-	adminGroup.DELETE("/products/:id/images/:image_id", pdHandler.DeleteImage) // HTMX: delete specific image
-	adminGroup.POST("/products/:id/images/:image_id", pdHandler.UpdateImage)   // HTMX: update image metadata
+	adminGroup.GET("/products/:id/images", pdHandler.ListImages, imagesPage)               // HTMX: render image gallery
+	adminGroup.POST("/products/:id/images", pdHandler.AddImage, imagesPage)                // HTMX: upload new image
+	adminGroup.DELETE("/products/:id/images/:image_id", pdHandler.DeleteImage, imagesPage) // HTMX: delete specific image
+	adminGroup.POST("/products/:id/images/:image_id", pdHandler.UpdateImage, imagesPage)   // HTMX: update image metadata
+
+	// Variants - versions with their own SKU, spec overrides and images
+	adminGroup.GET("/products/:id/variants", pdHandler.ListVariants, variantsPage)                                       // HTMX: render variants
+	adminGroup.POST("/products/:id/variants", pdHandler.AddVariant, variantsPage)                                        // HTMX: add new variant
+	adminGroup.POST("/products/:id/variants/:variant_id", pdHandler.UpdateVariant, variantsPage)                         // HTMX: update variant
+	adminGroup.DELETE("/products/:id/variants/:variant_id", pdHandler.DeleteVariant, variantsPage)                       // HTMX: delete variant
+	adminGroup.POST("/products/:id/variants/:variant_id/specs", pdHandler.AddVariantSpec, variantsPage)                  // HTMX: set spec override
+	adminGroup.DELETE("/products/:id/variants/:variant_id/specs/:spec_id", pdHandler.DeleteVariantSpec, variantsPage)    // HTMX: remove spec override
+	adminGroup.POST("/products/:id/variants/:variant_id/images", pdHandler.AddVariantImage, variantsPage)                // HTMX: upload variant image
+	adminGroup.DELETE("/products/:id/variants/:variant_id/images/:image_id", pdHandler.DeleteVariantImage, variantsPage) // HTMX: delete variant image
+	adminGroup.GET("/products/:id/related", pdHandler.ListRelatedContent, relatedPage)                                   // HTMX: render related content pins
+	adminGroup.POST("/products/:id/related", pdHandler.AddRelatedPin, relatedPage)                                       // HTMX: pin case study, post or whitepaper
+	adminGroup.DELETE("/products/:id/related/:pin_id", pdHandler.DeleteRelatedPin, relatedPage)                          // HTMX: remove pin
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Admin Blog Management Routes (Phase 5)
 	// ─────────────────────────────────────────────────────────────────────────
-	// Blog post editor with Trix WYSIWYG, tag management, and product linking
+	// Blog post editor with Trix WYSIWYG or Markdown, tag management, and product linking
 
 	adminBlogPostsHandler := adminHandlers.NewBlogPostsHandler(queries, logger, appCache)
-	adminGroup.GET("/blog/posts", adminBlogPostsHandler.List)          // List posts with filters
-	adminGroup.GET("/blog/posts/new", adminBlogPostsHandler.New)       // Show post editor (Trix)
-	adminGroup.POST("/blog/posts", adminBlogPostsHandler.Create)       // Save new post with tags
-	adminGroup.GET("/blog/posts/:id/edit", adminBlogPostsHandler.Edit) // Edit existing post
-	adminGroup.POST("/blog/posts/:id", adminBlogPostsHandler.Update)   // Update post content
-	adminGroup.DELETE("/blog/posts/:id", adminBlogPostsHandler.Delete) // Delete post (HTMX)
+	adminGroup.GET("/blog/posts", adminBlogPostsHandler.List)                      // List posts with filters
+	adminGroup.GET("/blog/posts/new", adminBlogPostsHandler.New)                   // Show post editor (Trix)
+	adminGroup.POST("/blog/posts", adminBlogPostsHandler.Create)                   // Save new post with tags
+	adminGroup.GET("/blog/posts/:id/edit", adminBlogPostsHandler.Edit)             // Edit existing post
+	adminGroup.POST("/blog/posts/:id", adminBlogPostsHandler.Update)               // Update post content
+	adminGroup.DELETE("/blog/posts/:id", adminBlogPostsHandler.Delete)             // Delete post (HTMX)
+	adminGroup.PATCH("/blog/posts/:id/inline", adminBlogPostsHandler.InlineUpdate) // Publish from the list (HTMX row)
+	adminGroup.POST("/blog/posts/:id/duplicate", duplicateHandler.BlogPost)        // Save as copy, then open the copy
+	// HTMX endpoint: render the Markdown editor's preview pane
+	adminGroup.POST("/blog/posts/markdown-preview", adminBlogPostsHandler.MarkdownPreview)
 	// HTMX endpoint: search products to link in blog post
 	adminGroup.GET("/blog/products/search", adminBlogPostsHandler.SearchProducts)
 
 	// Blog Tags - manage and create tags for blog posts
 	adminBlogTagsHandler := adminHandlers.NewBlogTagsHandler(queries, logger)
-	adminGroup.GET("/blog/tags", adminBlogTagsHandler.List)                      // List all tags
-	adminGroup.POST("/blog/tags", adminBlogTagsHandler.Create)                   // Create new tag
-	adminGroup.GET("/blog/tags/search", adminBlogTagsHandler.Search)             // HTMX: tag autocomplete
-	adminGroup.POST("/blog/tags/quick-create", adminBlogTagsHandler.QuickCreate) // HTMX: inline tag creation
-	adminGroup.DELETE("/blog/tags/:id", adminBlogTagsHandler.Delete)             // Delete tag (HTMX)
+	// Without JavaScript, inline writes return to the page they were sent from
+	backToReferrer := adminHandlers.HTMXFallback(adminHandlers.Fallback{})
+	adminGroup.GET("/blog/tags", adminBlogTagsHandler.List)                                      // List all tags
+	adminGroup.POST("/blog/tags", adminBlogTagsHandler.Create)                                   // Create new tag
+	adminGroup.GET("/blog/tags/search", adminBlogTagsHandler.Search)                             // HTMX: tag autocomplete
+	adminGroup.POST("/blog/tags/quick-create", adminBlogTagsHandler.QuickCreate, backToReferrer) // HTMX: inline tag creation
+	adminGroup.DELETE("/blog/tags/:id", adminBlogTagsHandler.Delete)                             // Delete tag (HTMX)
+
+	// Blog Series - group posts into ordered multi-part articles
+	adminBlogSeriesHandler := adminHandlers.NewBlogSeriesHandler(queries, logger, appCache)
+	seriesEditor := adminHandlers.HTMXFallback(adminHandlers.Fallback{Back: "/admin/blog/series/:id/edit"})
+	adminGroup.GET("/blog/series", adminBlogSeriesHandler.List)                                           // List all series
+	adminGroup.GET("/blog/series/new", adminBlogSeriesHandler.New)                                        // Show creation form
+	adminGroup.POST("/blog/series", adminBlogSeriesHandler.Create)                                        // Process new series
+	adminGroup.GET("/blog/series/:id/edit", adminBlogSeriesHandler.Edit)                                  // Edit series and its posts
+	adminGroup.POST("/blog/series/:id", adminBlogSeriesHandler.Update)                                    // Process updates
+	adminGroup.DELETE("/blog/series/:id", adminBlogSeriesHandler.Delete)                                  // Delete series (HTMX)
+	adminGroup.POST("/blog/series/:id/posts", adminBlogSeriesHandler.AssignPost, seriesEditor)            // Add post / set part number
+	adminGroup.DELETE("/blog/series/:id/posts/:post_id", adminBlogSeriesHandler.RemovePost, seriesEditor) // Remove post from series (HTMX)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Public Whitepaper Routes (Phase 8)
@@ -412,6 +988,8 @@ func main() {
 
 	whitepapersHandler := publicHandlers.NewWhitepapersHandler(queries, logger, appCache)
 	publicGroup.GET("/whitepapers", whitepapersHandler.WhitepapersList)                    // List whitepapers
+	publicGroup.GET("/partials/whitepapers", whitepapersHandler.WhitepapersListGrid)       // HTMX: filter bar and grid only
+	publicGroup.GET("/whitepapers/topics", whitepapersHandler.TopicIndex)                  // Topic index of all whitepapers
 	publicGroup.GET("/whitepapers/:slug", whitepapersHandler.WhitepaperDetail)             // Detail with download form
 	publicGroup.POST("/whitepapers/:slug/download", whitepapersHandler.WhitepaperDownload) // Process form, log download
 
@@ -444,7 +1022,7 @@ func main() {
 	// ─────────────────────────────────────────────────────────────────────────
 	// Full-text search across products, blog posts, solutions, and case studies
 
-	searchHandler := publicHandlers.NewSearchHandler(db, logger)
+	searchHandler := publicHandlers.NewSearchHandler(db, logger, appCache)
 	publicGroup.GET("/search", searchHandler.SearchPage)            // Search results page
 	publicGroup.GET("/search/suggest", searchHandler.SearchSuggest) // HTMX: autocomplete suggestions
 
@@ -455,22 +1033,170 @@ func main() {
 
 	// Base URL used to build absolute links in the sitemap. Configurable via the
 	// SITE_BASE_URL env var (set per-environment); defaults to the production domain.
-	siteBaseURL := "https://newsite.bluejayinnolabs.com"
-	if v := os.Getenv("SITE_BASE_URL"); v != "" {
-		siteBaseURL = v
-	}
+	siteBaseURL := cfg.SiteBaseURL
 	sitemapHandler := publicHandlers.NewSitemapHandler(queries, logger, siteBaseURL)
-	publicGroup.GET("/sitemap.xml", sitemapHandler.Sitemap)  // Dynamic XML sitemap of all public pages
+	publicGroup.GET("/sitemap.xml", sitemapHandler.Sitemap)  // XML sitemap of all public pages, kept by the sitemap job
 	publicGroup.GET("/robots.txt", sitemapHandler.RobotsTxt) // Robots.txt with crawl directives
 
+	// The sitemap is kept in memory between runs of the sitemap job. Every
+	// logged admin change drops the copy, and requests then build it until
+	// the next run, which also picks up changes that only depend on the
+	// clock (scheduled posts, closing job postings, embargoed releases)
+	sitemap := services.NewSitemap(sitemapHandler.Build)
+	publicHandlers.SetSitemap(sitemap)
+	adminHandlers.SetSitemap(sitemap)
+	scheduler.Add(jobs.Job{
+		Name:        "sitemap",
+		Description: "Regenerate /sitemap.xml",
+		Schedule:    jobs.MustCron("*/15 * * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := sitemap.Regenerate(ctx)
+			return err
+		},
+	})
+
+	// ScheduledPublishing - blog posts dated in the future stay hidden until
+	// their publish date; once it passes, this clears the cached blog pages
+	// and the kept sitemap and sends content.published webhooks
+	scheduledPublishing := services.NewScheduledPublishing(queries, appCache, webhookSvc, sitemap, logger)
+	scheduler.Add(jobs.Job{
+		Name:        "scheduled-publishing",
+		Description: "Announce blog posts whose publish date has passed",
+		Schedule:    jobs.Every(time.Minute),
+		Run: func(ctx context.Context) error {
+			_, err := scheduledPublishing.Run(ctx, time.Now())
+			return err
+		},
+	})
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// Public Case Study Routes (Phase 6)
 	// ─────────────────────────────────────────────────────────────────────────
 	// Success stories with metrics, challenges, and associated products
 
 	caseStudiesHandler := publicHandlers.NewCaseStudiesHandler(queries, logger, appCache)
-	publicGroup.GET("/case-studies", caseStudiesHandler.CaseStudiesList)       // List all case studies
-	publicGroup.GET("/case-studies/:slug", caseStudiesHandler.CaseStudyDetail) // Individual case study detail
+	publicGroup.GET("/case-studies", caseStudiesHandler.CaseStudiesList)              // List all case studies
+	publicGroup.GET("/partials/case-studies", caseStudiesHandler.CaseStudiesListGrid) // HTMX: filter bar and grid only
+	publicGroup.GET("/case-studies/:slug", caseStudiesHandler.CaseStudyDetail)        // Individual case study detail
+	publicGroup.GET("/case-studies/:slug/print", caseStudiesHandler.CaseStudyPrint)   // Print layout for printing and PDFs
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Form Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Forms built under Admin > Forms. Submissions are emailed to each form's
+	// notification addresses when SMTP is configured (see mailer above)
+
+	formSvc := services.NewForms(queries, logger, mailer, siteBaseURL)
+	formsHandler := publicHandlers.NewFormsHandler(formSvc, logger, appCache)
+	formLimiter := customMiddleware.NewRateLimiter(10, time.Hour)
+	publicGroup.GET("/forms/:slug", formsHandler.ShowForm)                                     // Form on its own page
+	publicGroup.POST("/forms/:slug/submit", formsHandler.SubmitForm, formLimiter.Middleware()) // HTMX: check and store answers
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Customer Account Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public visitor accounts. Signed-in customers download whitepapers and
+	// gated product files without the lead form and find them again on /account
+
+	accountHandler := publicHandlers.NewAccountHandler(customerAccounts, downloadLinks, logger)
+	accountLimiter := customMiddleware.NewRateLimiter(10, 15*time.Minute)
+	publicGroup.GET("/account", accountHandler.Account)                                         // Library of downloads
+	publicGroup.GET("/account/login", accountHandler.LoginPage)                                 // Sign-in form
+	publicGroup.POST("/account/login", accountHandler.Login, accountLimiter.Middleware())       // Sign in
+	publicGroup.GET("/account/register", accountHandler.RegisterPage)                           // Sign-up form
+	publicGroup.POST("/account/register", accountHandler.Register, accountLimiter.Middleware()) // Email the confirmation link
+	publicGroup.GET("/account/confirm", accountHandler.ConfirmPage)                             // Confirmation link: asks to confirm
+	publicGroup.POST("/account/confirm", accountHandler.Confirm, accountLimiter.Middleware())   // Create the account and sign in
+	publicGroup.POST("/account/logout", accountHandler.Logout)                                  // Sign out
+	publicGroup.GET("/account/forgot", accountHandler.ForgotPage)                               // Password reset request form
+	publicGroup.POST("/account/forgot", accountHandler.Forgot, accountLimiter.Middleware())     // Email a reset link
+	publicGroup.GET("/account/reset", accountHandler.ResetPage)                                 // New password form
+	publicGroup.POST("/account/reset", accountHandler.Reset, accountLimiter.Middleware())       // Set the new password
+	publicGroup.GET("/account/whitepapers/:slug", accountHandler.WhitepaperShortcut)            // HTMX: one-click download, 204 when signed out
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Newsletter Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Footer sign-up form and the confirmation and unsubscribe links of the
+	// newsletter emails
+
+	newsletterHandler := publicHandlers.NewNewsletterHandler(newsletter, logger)
+	newsletterLimiter := customMiddleware.NewRateLimiter(5, 15*time.Minute)
+	publicGroup.POST("/newsletter/subscribe", newsletterHandler.Subscribe, newsletterLimiter.Middleware()) // HTMX: sign up, email the confirmation link
+	publicGroup.GET("/newsletter/confirm", newsletterHandler.Confirm)                                      // Confirmation link from the email
+	publicGroup.GET("/newsletter/unsubscribe", newsletterHandler.UnsubscribePage)                          // Unsubscribe link: asks to confirm
+	publicGroup.POST("/newsletter/unsubscribe", newsletterHandler.Unsubscribe)                             // Unsubscribe (also one-click from mail clients)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Event Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Events and webinars with their registration form and calendar files
+
+	eventsHandler := publicHandlers.NewEventsHandler(queries, events, logger, appCache)
+	eventsLimiter := customMiddleware.NewRateLimiter(5, 15*time.Minute)
+	publicGroup.GET("/events", eventsHandler.List)                                                 // Upcoming and past events
+	publicGroup.GET("/events/:slug", eventsHandler.Detail)                                         // Event page with the registration form
+	publicGroup.POST("/events/:slug/register", eventsHandler.Register, eventsLimiter.Middleware()) // HTMX: register, email the confirmation
+	publicGroup.GET("/events/:slug/calendar.ics", eventsHandler.Calendar)                          // iCalendar file of the event
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Careers Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Open positions with their apply form and Google Jobs structured data
+
+	careersHandler := publicHandlers.NewCareersHandler(queries, careers, logger, appCache, siteBaseURL)
+	careersLimiter := customMiddleware.NewRateLimiter(5, time.Hour)
+	publicGroup.GET("/careers", careersHandler.List)                                            // Open positions
+	publicGroup.GET("/careers/:slug", careersHandler.Detail)                                    // Job posting with the apply form
+	publicGroup.POST("/careers/:slug/apply", careersHandler.Apply, careersLimiter.Middleware()) // HTMX: apply with a CV
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Press Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Newsroom with press releases, "In the news" links and the media kit
+
+	pressHandler := publicHandlers.NewPressHandler(queries, logger, appCache)
+	publicGroup.GET("/press", pressHandler.List)         // Releases, coverage and media kit
+	publicGroup.GET("/press/:slug", pressHandler.Detail) // Press release
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Short Link Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Campaign links: /go/<code> counts the visit and redirects with UTM tags
+
+	shortLinksHandler := publicHandlers.NewShortLinksHandler(queries, logger)
+	publicGroup.GET("/go/:code", shortLinksHandler.Follow) // Count the click, redirect to the destination
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Documentation Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Product manuals: versioned doc sets of nested Markdown pages
+
+	docsHandler := publicHandlers.NewDocsHandler(queries, logger, appCache)
+	publicGroup.GET("/docs", docsHandler.Index)                    // Doc sets grouped by product
+	publicGroup.GET("/docs/:set", docsHandler.Set)                 // Redirect to the current version
+	publicGroup.GET("/docs/:set/:version", docsHandler.Version)    // Contents of a version
+	publicGroup.GET("/docs/:set/:version/:page", docsHandler.Page) // Page with the sidebar tree
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Partner Portal Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Tokenized form for invited partners; pages are not cached or indexed
+
+	partnerPortalHandler := publicHandlers.NewPartnerPortalHandler(partnerPortal, queries, logger)
+	partnerPortalLimiter := customMiddleware.NewRateLimiter(10, time.Hour)
+	partnerPortalAuth := customMiddleware.Authenticate(partnerPortalHandler.Authenticator())
+	publicGroup.GET("/partner-portal/:token", partnerPortalHandler.Page, partnerPortalAuth)                                       // Listing and update form
+	publicGroup.POST("/partner-portal/:token", partnerPortalHandler.Submit, partnerPortalLimiter.Middleware(), partnerPortalAuth) // Send an update for review
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Public Landing Page Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Published campaign pages at /<slug>. The catch-all only receives paths
+	// no other route serves; unknown paths still get the 404 page
+
+	landingPagesHandler := publicHandlers.NewLandingPagesHandler(queries, logger, formSvc, appCache)
+	publicGroup.GET("/*", landingPagesHandler.LandingPage) // Landing page by slug, else 404
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Admin Case Study Management Routes (Phase 6)
@@ -478,18 +1204,98 @@ func main() {
 	// Case study editor with product linking and metrics tracking
 
 	adminCaseStudiesHandler := adminHandlers.NewCaseStudiesHandler(queries, logger, appCache)
-	adminGroup.GET("/case-studies", adminCaseStudiesHandler.List)          // List case studies
-	adminGroup.GET("/case-studies/new", adminCaseStudiesHandler.New)       // Create form
-	adminGroup.POST("/case-studies", adminCaseStudiesHandler.Create)       // Process creation
-	adminGroup.GET("/case-studies/:id/edit", adminCaseStudiesHandler.Edit) // Edit form
-	adminGroup.POST("/case-studies/:id", adminCaseStudiesHandler.Update)   // Process update
-	adminGroup.DELETE("/case-studies/:id", adminCaseStudiesHandler.Delete) // Delete (HTMX)
+	adminGroup.GET("/case-studies", adminCaseStudiesHandler.List)                      // List case studies
+	adminGroup.GET("/case-studies/new", adminCaseStudiesHandler.New)                   // Create form
+	adminGroup.POST("/case-studies", adminCaseStudiesHandler.Create)                   // Process creation
+	adminGroup.GET("/case-studies/:id/edit", adminCaseStudiesHandler.Edit)             // Edit form
+	adminGroup.POST("/case-studies/:id", adminCaseStudiesHandler.Update)               // Process update
+	adminGroup.DELETE("/case-studies/:id", adminCaseStudiesHandler.Delete)             // Delete (HTMX)
+	adminGroup.PATCH("/case-studies/:id/inline", adminCaseStudiesHandler.InlineUpdate) // Publish/order from the list (HTMX row)
+	adminGroup.POST("/case-studies/:id/duplicate", duplicateHandler.CaseStudy)         // Save as copy, then open the copy
 
 	// Sub-entity management via HTMX
-	adminGroup.POST("/case-studies/:id/products", adminCaseStudiesHandler.AddProduct)                 // Link product
-	adminGroup.DELETE("/case-studies/:id/products/:productId", adminCaseStudiesHandler.RemoveProduct) // Unlink product
-	adminGroup.POST("/case-studies/:id/metrics", adminCaseStudiesHandler.AddMetric)                   // Add success metric
-	adminGroup.DELETE("/case-studies/:id/metrics/:metricId", adminCaseStudiesHandler.DeleteMetric)    // Delete metric
+	// Without JavaScript, forms redirect back to the editor, which lists both
+	caseStudyEditor := adminHandlers.HTMXFallback(adminHandlers.Fallback{Back: "/admin/case-studies/:id/edit"})
+	adminGroup.POST("/case-studies/:id/products", adminCaseStudiesHandler.AddProduct, caseStudyEditor)                 // Link product
+	adminGroup.DELETE("/case-studies/:id/products/:productId", adminCaseStudiesHandler.RemoveProduct, caseStudyEditor) // Unlink product
+	adminGroup.POST("/case-studies/:id/metrics", adminCaseStudiesHandler.AddMetric, caseStudyEditor)                   // Add success metric
+	adminGroup.DELETE("/case-studies/:id/metrics/:metricId", adminCaseStudiesHandler.DeleteMetric, caseStudyEditor)    // Delete metric
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Admin Event Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Events and webinars on /events, with their registrations
+
+	adminEventsHandler := adminHandlers.NewEventsHandler(queries, logger, appCache)
+	adminGroup.GET("/events", adminEventsHandler.List)                            // List events
+	adminGroup.GET("/events/new", adminEventsHandler.New)                         // Create form
+	adminGroup.POST("/events", adminEventsHandler.Create)                         // Process creation
+	adminGroup.GET("/events/:id/edit", adminEventsHandler.Edit)                   // Edit form
+	adminGroup.POST("/events/:id", adminEventsHandler.Update)                     // Process update
+	adminGroup.DELETE("/events/:id", adminEventsHandler.Delete)                   // Delete with registrations (HTMX)
+	adminGroup.GET("/events/:id/registrations", adminEventsHandler.Registrations) // Registrations of an event
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Admin Careers Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Job postings on /careers and the inbox of applications
+
+	adminCareersHandler := adminHandlers.NewCareersHandler(queries, careers, logger, appCache)
+	adminGroup.GET("/careers", adminCareersHandler.List)                                             // List job postings
+	adminGroup.GET("/careers/new", adminCareersHandler.New)                                          // Create form
+	adminGroup.POST("/careers", adminCareersHandler.Create)                                          // Process creation
+	adminGroup.GET("/careers/:id/edit", adminCareersHandler.Edit)                                    // Edit form
+	adminGroup.POST("/careers/:id", adminCareersHandler.Update)                                      // Process update
+	adminGroup.DELETE("/careers/:id", adminCareersHandler.Delete)                                    // Delete with applications and CVs (HTMX)
+	adminGroup.GET("/careers/applications", adminCareersHandler.Applications)                        // Application inbox
+	adminGroup.GET("/careers/applications/:id", adminCareersHandler.Application)                     // One application
+	adminGroup.POST("/careers/applications/:id/status", adminCareersHandler.UpdateApplicationStatus) // Set status and notes
+	adminGroup.GET("/careers/applications/:id/cv", adminCareersHandler.DownloadCV)                   // Download the CV
+	adminGroup.DELETE("/careers/applications/:id", adminCareersHandler.DeleteApplication)            // Delete with its CV (HTMX)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Admin Press Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Press releases, "In the news" links and media kit files on /press
+
+	adminPressHandler := adminHandlers.NewPressHandler(queries, logger, appCache, uploadSvc, cfg.UploadDir)
+	adminGroup.GET("/press", adminPressHandler.List)                            // List press releases
+	adminGroup.GET("/press/new", adminPressHandler.New)                         // Create form
+	adminGroup.POST("/press", adminPressHandler.Create)                         // Process creation
+	adminGroup.GET("/press/:id/edit", adminPressHandler.Edit)                   // Edit form
+	adminGroup.POST("/press/:id", adminPressHandler.Update)                     // Process update
+	adminGroup.DELETE("/press/:id", adminPressHandler.Delete)                   // Delete (HTMX)
+	adminGroup.GET("/press/mentions", adminPressHandler.Mentions)               // List "In the news" links
+	adminGroup.GET("/press/mentions/new", adminPressHandler.NewMention)         // Create form
+	adminGroup.POST("/press/mentions", adminPressHandler.CreateMention)         // Process creation
+	adminGroup.GET("/press/mentions/:id/edit", adminPressHandler.EditMention)   // Edit form
+	adminGroup.POST("/press/mentions/:id", adminPressHandler.UpdateMention)     // Process update
+	adminGroup.DELETE("/press/mentions/:id", adminPressHandler.DeleteMention)   // Delete (HTMX)
+	adminGroup.GET("/press/media-kit", adminPressHandler.MediaKit)              // Media kit with the upload form
+	adminGroup.POST("/press/media-kit", adminPressHandler.UploadKitAsset)       // Upload a file
+	adminGroup.DELETE("/press/media-kit/:id", adminPressHandler.DeleteKitAsset) // Delete with its file (HTMX)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Admin Documentation Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Doc sets on /docs, their versions and Markdown pages
+
+	adminDocsHandler := adminHandlers.NewDocsHandler(queries, docs, logger, appCache)
+	adminGroup.GET("/docs", adminDocsHandler.List)                              // List doc sets
+	adminGroup.GET("/docs/new", adminDocsHandler.New)                           // Create form
+	adminGroup.POST("/docs", adminDocsHandler.Create)                           // Process creation with the first version
+	adminGroup.GET("/docs/:id/edit", adminDocsHandler.Edit)                     // Edit form with versions
+	adminGroup.POST("/docs/:id", adminDocsHandler.Update)                       // Process update
+	adminGroup.DELETE("/docs/:id", adminDocsHandler.Delete)                     // Delete with versions and pages (HTMX)
+	adminGroup.POST("/docs/:id/versions", adminDocsHandler.AddVersion)          // Add a version, optionally as a copy
+	adminGroup.GET("/docs/versions/:id", adminDocsHandler.Pages)                // Page tree of a version
+	adminGroup.POST("/docs/versions/:id/current", adminDocsHandler.MakeCurrent) // Make the version current
+	adminGroup.DELETE("/docs/versions/:id", adminDocsHandler.DeleteVersion)     // Delete a version that is not current (HTMX)
+	adminGroup.GET("/docs/versions/:id/pages/new", adminDocsHandler.NewPage)    // Create page form
+	adminGroup.POST("/docs/versions/:id/pages", adminDocsHandler.CreatePage)    // Process page creation
+	adminGroup.GET("/docs/pages/:id/edit", adminDocsHandler.EditPage)           // Edit page form
+	adminGroup.POST("/docs/pages/:id", adminDocsHandler.UpdatePage)             // Process page update
+	adminGroup.DELETE("/docs/pages/:id", adminDocsHandler.DeletePage)           // Delete a page without subpages (HTMX)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Admin Solution Management Routes (Phase 4)
@@ -497,41 +1303,50 @@ func main() {
 	// Solution editor with stats, challenges, products, and CTAs
 
 	adminSolutionsHandler := adminHandlers.NewSolutionsHandler(queries, logger, appCache, uploadSvc)
-	adminGroup.GET("/solutions", adminSolutionsHandler.List)          // List solutions
-	adminGroup.GET("/solutions/new", adminSolutionsHandler.New)       // Create form
-	adminGroup.POST("/solutions", adminSolutionsHandler.Create)       // Process creation
-	adminGroup.GET("/solutions/:id/edit", adminSolutionsHandler.Edit) // Edit form
-	adminGroup.POST("/solutions/:id", adminSolutionsHandler.Update)   // Process update
-	adminGroup.DELETE("/solutions/:id", adminSolutionsHandler.Delete) // Delete (HTMX)
+	adminGroup.GET("/solutions", adminSolutionsHandler.List)               // List solutions
+	adminGroup.GET("/solutions/new", adminSolutionsHandler.New)            // Create form
+	adminGroup.POST("/solutions", adminSolutionsHandler.Create)            // Process creation
+	adminGroup.GET("/solutions/:id/edit", adminSolutionsHandler.Edit)      // Edit form
+	adminGroup.POST("/solutions/:id", adminSolutionsHandler.Update)        // Process update
+	adminGroup.DELETE("/solutions/:id", adminSolutionsHandler.Delete)      // Delete (HTMX)
+	adminGroup.POST("/solutions/:id/duplicate", duplicateHandler.Solution) // Save as copy, then open the copy
 
 	// Detail sub-tabs (HTMX): loaded into #detail-content on the edit form
-	adminGroup.GET("/solutions/:id/challenges-tab", adminSolutionsHandler.ChallengesTab) // Challenges tab partial
-	adminGroup.GET("/solutions/:id/products-tab", adminSolutionsHandler.ProductsTab)     // Products tab partial
-	adminGroup.GET("/solutions/:id/stats-tab", adminSolutionsHandler.StatsTab)           // Stats tab partial
-	adminGroup.GET("/solutions/:id/ctas-tab", adminSolutionsHandler.CTAsTab)             // CTAs tab partial
+	// Without JavaScript each tab opens as a page of its own (see admin.HTMXFallback)
+	solutionTab := func(title, tab string) echo.MiddlewareFunc {
+		return adminHandlers.HTMXFallback(adminHandlers.Fallback{Title: title, List: "/admin/solutions/:id/" + tab + "-tab", Back: "/admin/solutions/:id/edit"})
+	}
+	challengesPage := solutionTab("Solution Challenges", "challenges")
+	solutionProductsPage := solutionTab("Solution Products", "products")
+	statsPage := solutionTab("Solution Stats", "stats")
+	ctasPage := solutionTab("Solution CTAs", "ctas")
+	adminGroup.GET("/solutions/:id/challenges-tab", adminSolutionsHandler.ChallengesTab, challengesPage)   // Challenges tab partial
+	adminGroup.GET("/solutions/:id/products-tab", adminSolutionsHandler.ProductsTab, solutionProductsPage) // Products tab partial
+	adminGroup.GET("/solutions/:id/stats-tab", adminSolutionsHandler.StatsTab, statsPage)                  // Stats tab partial
+	adminGroup.GET("/solutions/:id/ctas-tab", adminSolutionsHandler.CTAsTab, ctasPage)                     // CTAs tab partial
 
 	// Sub-entity management via HTMX
-	adminGroup.POST("/solutions/:id/stats", adminSolutionsHandler.AddStat)                             // Add statistic
-	adminGroup.DELETE("/solutions/:id/stats/:statId", adminSolutionsHandler.DeleteStat)                // Delete stat
-	adminGroup.POST("/solutions/:id/challenges", adminSolutionsHandler.AddChallenge)                   // Add challenge
-	adminGroup.DELETE("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.DeleteChallenge) // Delete challenge
-	adminGroup.POST("/solutions/:id/products", adminSolutionsHandler.AddProduct)                       // Link product
-	adminGroup.DELETE("/solutions/:id/products/:productId", adminSolutionsHandler.RemoveProduct)       // Unlink product
-	adminGroup.POST("/solutions/:id/ctas", adminSolutionsHandler.AddCTA)                               // Add CTA button
-	adminGroup.DELETE("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.DeleteCTA)                   // Delete CTA
+	adminGroup.POST("/solutions/:id/stats", adminSolutionsHandler.AddStat, statsPage)                                  // Add statistic
+	adminGroup.DELETE("/solutions/:id/stats/:statId", adminSolutionsHandler.DeleteStat, statsPage)                     // Delete stat
+	adminGroup.POST("/solutions/:id/challenges", adminSolutionsHandler.AddChallenge, challengesPage)                   // Add challenge
+	adminGroup.DELETE("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.DeleteChallenge, challengesPage) // Delete challenge
+	adminGroup.POST("/solutions/:id/products", adminSolutionsHandler.AddProduct, solutionProductsPage)                 // Link product
+	adminGroup.DELETE("/solutions/:id/products/:productId", adminSolutionsHandler.RemoveProduct, solutionProductsPage) // Unlink product
+	adminGroup.POST("/solutions/:id/ctas", adminSolutionsHandler.AddCTA, ctasPage)                                     // Add CTA button
+	adminGroup.DELETE("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.DeleteCTA, ctasPage)                         // Delete CTA
 
 	// Inline edit of sub-entities via HTMX
-	adminGroup.POST("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.UpdateChallenge) // Edit challenge
-	adminGroup.POST("/solutions/:id/stats/:statId", adminSolutionsHandler.UpdateStat)                // Edit stat
-	adminGroup.POST("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.UpdateCTA)                   // Edit CTA
-	adminGroup.POST("/solutions/:id/products/:productId", adminSolutionsHandler.UpdateProduct)       // Edit product link
+	adminGroup.POST("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.UpdateChallenge, challengesPage) // Edit challenge
+	adminGroup.POST("/solutions/:id/stats/:statId", adminSolutionsHandler.UpdateStat, statsPage)                     // Edit stat
+	adminGroup.POST("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.UpdateCTA, ctasPage)                         // Edit CTA
+	adminGroup.POST("/solutions/:id/products/:productId", adminSolutionsHandler.UpdateProduct, solutionProductsPage) // Edit product link
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Admin Whitepaper Management Routes (Phase 8)
 	// ─────────────────────────────────────────────────────────────────────────
 	// Whitepaper CRUD with PDF upload and download tracking
 
-	adminWhitepapersHandler := adminHandlers.NewWhitepapersHandler(queries, logger, appCache)
+	adminWhitepapersHandler := adminHandlers.NewWhitepapersHandler(queries, logger, appCache, cfg.UploadDir)
 	adminGroup.GET("/whitepapers", adminWhitepapersHandler.List)                    // List whitepapers
 	adminGroup.GET("/whitepapers/new", adminWhitepapersHandler.New)                 // Create form
 	adminGroup.POST("/whitepapers", adminWhitepapersHandler.Create)                 // Upload PDF, process
@@ -588,7 +1403,7 @@ func main() {
 	// ─────────────────────────────────────────────────────────────────────────
 	// Configure meta tags, titles, and descriptions for each major site section
 
-	sectionSettingsHandler := adminHandlers.NewSectionSettingsHandler(queries, logger)
+	sectionSettingsHandler := adminHandlers.NewSectionSettingsHandler(queries, logger, appCache)
 
 	// About section settings - SEO and page-level configuration
 	adminGroup.GET("/about/settings", sectionSettingsHandler.AboutSettings)
@@ -668,12 +1483,21 @@ func main() {
 	adminGroup.POST("/partners/testimonials/:id", adminPartnersHandler.TestimonialUpdate)
 	adminGroup.DELETE("/partners/testimonials/:id", adminPartnersHandler.TestimonialDelete)
 
+	// Partner portal - invite links and the approval queue of partner updates
+	adminPartnerPortalHandler := adminHandlers.NewPartnerPortalHandler(queries, partnerPortal, logger, appCache)
+	adminGroup.GET("/partners/:id/portal", adminPartnerPortalHandler.Portal)                // Invite links and submissions of a partner
+	adminGroup.POST("/partners/:id/invites", adminPartnerPortalHandler.Invite)              // Create (and email) a portal link
+	adminGroup.POST("/partners/invites/:id/revoke", adminPartnerPortalHandler.RevokeInvite) // Disable a portal link
+	adminGroup.GET("/partners/submissions", adminPartnerPortalHandler.Submissions)          // Approval queue
+	adminGroup.GET("/partners/submissions/:id", adminPartnerPortalHandler.Submission)       // Live listing vs proposed changes
+	adminGroup.POST("/partners/submissions/:id/review", adminPartnerPortalHandler.Review)   // Approve (publish) or reject
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// Media Library Routes (Phase 18)
 	// ─────────────────────────────────────────────────────────────────────────
 	// Centralized media management with upload, browsing, and metadata editing
 
-	mediaHandler := adminHandlers.NewMediaHandler(queries, logger, "public/uploads")
+	mediaHandler := adminHandlers.NewMediaHandler(queries, logger, cfg.UploadDir)
 	adminGroup.GET("/media", mediaHandler.List)              // Main media library page
 	adminGroup.POST("/media/upload", mediaHandler.Upload)    // Upload new media file
 	adminGroup.GET("/media/browse", mediaHandler.Browse)     // HTMX: modal browser for image selection
@@ -681,29 +1505,290 @@ func main() {
 	adminGroup.PUT("/media/:id", mediaHandler.UpdateAltText) // Update alt text for accessibility
 	adminGroup.DELETE("/media/:id", mediaHandler.Delete)     // Delete media file (HTMX)
 
+	// Bulk import from a ZIP upload or a folder under MEDIA_IMPORT_DIR
+	// (default data/media-import), run in the background with a status page
+	mediaImportHandler := adminHandlers.NewMediaImportHandler(services.NewMediaImporter(queries, cfg.UploadDir), logger, appCache, cfg.MediaImportDir)
+	adminGroup.GET("/media/import", mediaImportHandler.Show)       // Import form and recent imports
+	adminGroup.POST("/media/import", mediaImportHandler.Start)     // Start an import, redirect to its status
+	adminGroup.GET("/media/import/:id", mediaImportHandler.Status) // Progress (polls) and report
+
+	// Images dropped or pasted into the Trix editors (JSON for the editor)
+	editorAttachmentsHandler := adminHandlers.NewEditorAttachmentsHandler(editorAttachmentSvc, logger)
+	adminGroup.POST("/editor/attachments", editorAttachmentsHandler.Upload)
+
+	// Accessibility report: images without alt text, headings out of order
+	accessibilityHandler := adminHandlers.NewAccessibilityHandler(services.NewAccessibility(queries), logger)
+	adminGroup.GET("/accessibility", accessibilityHandler.Report)
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// Navigation Management Routes (Phase 19)
 	// ─────────────────────────────────────────────────────────────────────────
 	// Visual menu builder with drag-and-drop reordering via HTMX
 
-	navHandler := adminHandlers.NewNavigationHandler(queries, logger)
-	adminGroup.GET("/navigation", navHandler.List)                     // List all menus
-	adminGroup.POST("/navigation", navHandler.Create)                  // Create new menu
-	adminGroup.GET("/navigation/:id", navHandler.Edit)                 // Menu editor interface
-	adminGroup.POST("/navigation/:id/settings", navHandler.UpdateMenu) // Update menu settings
-	adminGroup.POST("/navigation/:id/items", navHandler.AddItem)       // Add menu item (HTMX)
-	adminGroup.POST("/navigation/items/:id", navHandler.UpdateItem)    // Update item (HTMX)
-	adminGroup.DELETE("/navigation/items/:id", navHandler.DeleteItem)  // Delete item (HTMX)
-	adminGroup.DELETE("/navigation/:id", navHandler.DeleteMenu)        // Delete entire menu (HTMX)
-	adminGroup.POST("/navigation/:id/reorder", navHandler.Reorder)     // Reorder items (HTMX drag-drop)
+	navHandler := adminHandlers.NewNavigationHandler(queries, logger, navSvc, appCache)
+	adminGroup.GET("/navigation", navHandler.List)                                    // List all menus
+	adminGroup.POST("/navigation", navHandler.Create)                                 // Create new menu
+	adminGroup.GET("/navigation/:id", navHandler.Edit)                                // Menu editor interface
+	adminGroup.POST("/navigation/:id/settings", navHandler.UpdateMenu)                // Update menu settings
+	adminGroup.POST("/navigation/:id/items", navHandler.AddItem)                      // Add menu item (HTMX)
+	adminGroup.POST("/navigation/items/:id", navHandler.UpdateItem)                   // Update item (HTMX)
+	adminGroup.DELETE("/navigation/items/:id", navHandler.DeleteItem, backToReferrer) // Delete item (HTMX)
+	adminGroup.DELETE("/navigation/:id", navHandler.DeleteMenu)                       // Delete entire menu (HTMX)
+	adminGroup.POST("/navigation/:id/reorder", navHandler.Reorder)                    // Reorder items (HTMX drag-drop)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Content Block Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Reusable blocks and the ordered blocks of each page, by path
+
+	blocksHandler := adminHandlers.NewContentBlocksHandler(queries, logger, blockSvc, appCache)
+	adminGroup.GET("/blocks", blocksHandler.List)                                // Block library and pages with blocks
+	adminGroup.GET("/blocks/new", blocksHandler.New)                             // Add form (?type= preselects the type)
+	adminGroup.POST("/blocks", blocksHandler.Create)                             // Add a block
+	adminGroup.GET("/blocks/pages", blocksHandler.Page)                          // Blocks of one page (?route=)
+	adminGroup.POST("/blocks/pages", blocksHandler.Attach)                       // Add a block to a page
+	adminGroup.POST("/blocks/pages/reorder", blocksHandler.Reorder)              // Save the order (drag and drop)
+	adminGroup.DELETE("/blocks/pages/:id", blocksHandler.Detach, backToReferrer) // Remove a block from a page (HTMX)
+	adminGroup.GET("/blocks/:id/edit", blocksHandler.Edit)                       // Edit form
+	adminGroup.POST("/blocks/:id", blocksHandler.Update)                         // Save a block
+	adminGroup.DELETE("/blocks/:id", blocksHandler.Delete, backToReferrer)       // Remove a block everywhere (HTMX)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Landing Page Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Campaign pages built from sections, with SEO fields, analytics snippets
+	// and publish/unpublish
+
+	adminLandingPagesHandler := adminHandlers.NewLandingPagesHandler(queries, logger, blockSvc, appCache)
+	adminGroup.GET("/landing-pages", adminLandingPagesHandler.List)                                               // Pages with status
+	adminGroup.GET("/landing-pages/new", adminLandingPagesHandler.New)                                            // Add form
+	adminGroup.POST("/landing-pages", adminLandingPagesHandler.Create)                                            // Add a draft page
+	adminGroup.GET("/landing-pages/:id/edit", adminLandingPagesHandler.Edit)                                      // Settings and sections
+	adminGroup.POST("/landing-pages/:id", adminLandingPagesHandler.Update)                                        // Save settings
+	adminGroup.POST("/landing-pages/:id/publish", adminLandingPagesHandler.Publish)                               // Put live
+	adminGroup.POST("/landing-pages/:id/unpublish", adminLandingPagesHandler.Unpublish)                           // Take down
+	adminGroup.DELETE("/landing-pages/:id", adminLandingPagesHandler.Delete, backToReferrer)                      // Remove a page (HTMX)
+	adminGroup.GET("/landing-pages/:id/sections/new", adminLandingPagesHandler.NewSection)                        // Section form (?type=)
+	adminGroup.POST("/landing-pages/:id/sections", adminLandingPagesHandler.CreateSection)                        // Add a section
+	adminGroup.POST("/landing-pages/:id/sections/reorder", adminLandingPagesHandler.ReorderSections)              // Save the order (drag and drop)
+	adminGroup.GET("/landing-pages/:id/sections/:sid/edit", adminLandingPagesHandler.EditSection)                 // Edit a section
+	adminGroup.POST("/landing-pages/:id/sections/:sid", adminLandingPagesHandler.UpdateSection)                   // Save a section
+	adminGroup.DELETE("/landing-pages/:id/sections/:sid", adminLandingPagesHandler.DeleteSection, backToReferrer) // Remove a section (HTMX)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Form Builder Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Custom lead forms: fields with validation rules, success message,
+	// notification addresses and the submissions received
+
+	adminFormsHandler := adminHandlers.NewFormsHandler(queries, logger, appCache)
+	adminGroup.GET("/forms", adminFormsHandler.List)                                                     // Forms with counts
+	adminGroup.GET("/forms/new", adminFormsHandler.New)                                                  // Add form
+	adminGroup.POST("/forms", adminFormsHandler.Create)                                                  // Add a form
+	adminGroup.GET("/forms/:id/edit", adminFormsHandler.Edit)                                            // Settings and fields
+	adminGroup.POST("/forms/:id", adminFormsHandler.Update)                                              // Save settings
+	adminGroup.DELETE("/forms/:id", adminFormsHandler.Delete, backToReferrer)                            // Remove a form (HTMX)
+	adminGroup.GET("/forms/:id/fields/new", adminFormsHandler.NewField)                                  // Field form (?type=)
+	adminGroup.POST("/forms/:id/fields", adminFormsHandler.CreateField)                                  // Add a field
+	adminGroup.POST("/forms/:id/fields/reorder", adminFormsHandler.ReorderFields)                        // Save the order (drag and drop)
+	adminGroup.GET("/forms/:id/fields/:fid/edit", adminFormsHandler.EditField)                           // Edit a field
+	adminGroup.POST("/forms/:id/fields/:fid", adminFormsHandler.UpdateField)                             // Save a field
+	adminGroup.DELETE("/forms/:id/fields/:fid", adminFormsHandler.DeleteField, backToReferrer)           // Remove a field (HTMX)
+	adminGroup.GET("/forms/:id/submissions", adminFormsHandler.Submissions)                              // Answers received, newest first
+	adminGroup.DELETE("/forms/:id/submissions/:sid", adminFormsHandler.DeleteSubmission, backToReferrer) // Remove a submission (HTMX)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Redirect Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Automatic redirects from changed slugs, plus manual rules and wildcards
+
+	redirectsHandler := adminHandlers.NewRedirectsHandler(queries, logger, redirectSvc)
+	adminGroup.GET("/redirects", redirectsHandler.List)                          // Rules with hit counts (?q= filters)
+	adminGroup.POST("/redirects", redirectsHandler.Create)                       // Add a manual rule
+	adminGroup.POST("/redirects/:id", redirectsHandler.Update)                   // Edit a rule
+	adminGroup.DELETE("/redirects/:id", redirectsHandler.Delete, backToReferrer) // Remove a rule (HTMX)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Short Link Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// /go/ campaign links with UTM parameters, click counts and expiry
+
+	adminShortLinksHandler := adminHandlers.NewShortLinksHandler(queries, logger, siteBaseURL)
+	adminGroup.GET("/short-links", adminShortLinksHandler.List)                          // Links with click counts
+	adminGroup.GET("/short-links/new", adminShortLinksHandler.New)                       // Create form
+	adminGroup.POST("/short-links", adminShortLinksHandler.Create)                       // Process creation
+	adminGroup.GET("/short-links/:id/edit", adminShortLinksHandler.Edit)                 // Edit form
+	adminGroup.POST("/short-links/:id", adminShortLinksHandler.Update)                   // Process update
+	adminGroup.DELETE("/short-links/:id", adminShortLinksHandler.Delete, backToReferrer) // Remove a link (HTMX)
+
+	// Webhooks - endpoints notified when public content changes (admins only)
+	webhooksHandler := adminHandlers.NewWebhooksHandler(queries, logger, webhookSvc)
+	webhooksGroup := adminGroup.Group("/webhooks", customMiddleware.RequireRole("admin"))
+	webhooksGroup.GET("", webhooksHandler.List)                          // Endpoints with their last delivery
+	webhooksGroup.POST("", webhooksHandler.Create)                       // Add an endpoint with a new secret
+	webhooksGroup.POST("/:id/toggle", webhooksHandler.Toggle)            // Pause or resume deliveries
+	webhooksGroup.POST("/:id/test", webhooksHandler.Test)                // Send a ping now
+	webhooksGroup.DELETE("/:id", webhooksHandler.Delete, backToReferrer) // Remove an endpoint (HTMX)
+
+	// Content promotion - export a bundle of content and media, import it on
+	// another site with conflicts resolved by slug (admins only)
+	contentBundlesHandler := adminHandlers.NewContentBundlesHandler(
+		services.NewContentBundles(db, cfg.UploadDir, filepath.Join(os.TempDir(), "bluejay-bundles")), logger, appCache)
+	toolsGroup := adminGroup.Group("/tools", customMiddleware.RequireRole("admin"))
+	toolsGroup.GET("/export", contentBundlesHandler.Export)             // Choose content to export
+	toolsGroup.POST("/export", contentBundlesHandler.ExportDownload)    // Download the bundle ZIP
+	toolsGroup.GET("/import", contentBundlesHandler.Import)             // Upload form
+	toolsGroup.POST("/import", contentBundlesHandler.ImportPreview)     // Stage the upload, review conflicts
+	toolsGroup.POST("/import/apply", contentBundlesHandler.ImportApply) // Import with the chosen resolutions
+
+	// SEO overrides for public pages, by path or content item
+	seoHandler := adminHandlers.NewSEOHandler(queries, logger, seoSvc, appCache)
+	adminGroup.GET("/seo", seoHandler.List)                          // Overrides with their pages
+	adminGroup.GET("/seo/new", seoHandler.New)                       // Add form (?route= or ?entity_type=&entity_id=)
+	adminGroup.POST("/seo", seoHandler.Create)                       // Add an override
+	adminGroup.GET("/seo/:id/edit", seoHandler.Edit)                 // Edit form
+	adminGroup.POST("/seo/:id", seoHandler.Update)                   // Save an override
+	adminGroup.DELETE("/seo/:id", seoHandler.Delete, backToReferrer) // Remove an override (HTMX)
+
+	// 404 report: missing public paths, dismissed one at a time or all at once
+	notFoundHandler := adminHandlers.NewNotFoundReportHandler(queries, logger)
+	adminGroup.GET("/404s", notFoundHandler.List)                      // Most requested missing paths with referrers
+	adminGroup.DELETE("/404s", notFoundHandler.Delete, backToReferrer) // Dismiss ?path=, or clear the report (HTMX)
+
+	// First-party analytics: page views, trend and top lists (?days=7|30|90)
+	analyticsHandler := adminHandlers.NewAnalyticsHandler(analyticsSvc, logger)
+	adminGroup.GET("/analytics", analyticsHandler.Dashboard)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Activity Log Routes (Phase 20)
 	// ─────────────────────────────────────────────────────────────────────────
 	// Audit trail of all admin actions for security and accountability
 
-	activityHandler := adminHandlers.NewActivityHandler(queries, logger)
-	adminGroup.GET("/activity", activityHandler.List) // View activity log with filtering
+	activityHandler := adminHandlers.NewActivityHandler(queries, logger, activityRetention)
+	adminGroup.GET("/activity", activityHandler.List)              // View activity log with filtering
+	adminGroup.GET("/activity/export.csv", activityHandler.Export) // Download filtered entries as CSV
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Trash Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Soft-deleted products, blog posts, solutions and case studies
+	// :type is product, blog_post, solution or case_study
+
+	trashHandler := adminHandlers.NewTrashHandler(queries, logger, appCache, trashPurge)
+	adminGroup.GET("/trash", trashHandler.List)                       // Trashed items by type
+	adminGroup.POST("/trash/:type/:id/restore", trashHandler.Restore) // Restore to previous status
+	adminGroup.POST("/trash/:type/:id/delete", trashHandler.Delete)   // Delete permanently
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Publishing Workflow Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Blog posts and products move draft → in review → approved → published.
+	// WORKFLOW_PERMISSIONS overrides which role may make which transition,
+	// e.g. "editor=draft>in_review,in_review>draft;admin=*". State changes are
+	// emailed when SMTP_HOST is set (SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD,
+	// SMTP_FROM); links in the emails use SITE_BASE_URL.
+	// :type is blog_post or product
+
+	var workflowPolicy services.WorkflowPolicy
+	if v := cfg.WorkflowPermissions; v != "" {
+		p, err := services.ParseWorkflowPolicy(v)
+		if err != nil {
+			logger.Error("invalid WORKFLOW_PERMISSIONS", "value", v, "error", err)
+			os.Exit(1)
+		}
+		workflowPolicy = p
+	}
+	adminHandlers.SetWorkflow(services.NewWorkflow(queries, logger, workflowPolicy, mailer, siteBaseURL))
+	// Handlers that write several tables do so in one transaction on db
+	adminHandlers.SetTransactor(database.NewTransactor(db))
+
+	workflowHandler := adminHandlers.NewWorkflowHandler(queries, logger, appCache)
+	reviewPanel := adminHandlers.HTMXFallback(adminHandlers.Fallback{Title: "Review"})
+	adminGroup.GET("/review-queue", workflowHandler.ReviewQueue)                                 // Items waiting for review or publishing
+	adminGroup.GET("/workflow/:type/:id", workflowHandler.Panel, reviewPanel)                    // HTMX: review panel on the edit page
+	adminGroup.POST("/workflow/:type/:id/transition", workflowHandler.Transition, reviewPanel)   // HTMX: move to another state
+	adminGroup.POST("/workflow/:type/:id/reviewer", workflowHandler.AssignReviewer, reviewPanel) // HTMX: assign the reviewer
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Edit Lock Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Product, blog post, case study, solution, whitepaper and landing page
+	// edit forms claim their item while open, with a heartbeat every 30
+	// seconds, and tell a second admin who is already editing it. Locks only
+	// warn; they expire services.EditLockTTL after the last heartbeat.
+	editLocks := services.NewEditLocks(queries, 0)
+	editLocksHandler := adminHandlers.NewEditLocksHandler(editLocks, logger)
+	adminGroup.POST("/locks/:type/:id", editLocksHandler.Heartbeat)       // HTMX: claim or renew, notice when held by someone else
+	adminGroup.POST("/locks/:type/:id/release", editLocksHandler.Release) // Beacon: release when leaving the form
+	scheduler.Add(jobs.Job{
+		Name:        "edit-lock-cleanup",
+		Description: "Delete edit locks whose form stopped sending heartbeats",
+		Schedule:    jobs.MustCron("45 * * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := editLocks.PurgeExpired(ctx, time.Now())
+			return err
+		},
+	})
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Publish Checklist Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Solutions, case studies and whitepapers publish only when their checklist
+	// passes (meta description, hero image, industry/topic, links, alt text).
+	// PUBLISH_OVERRIDE_ROLES lists the roles that may publish anyway with a
+	// reason, comma-separated; the default is "admin".
+	// :type is solutions, case-studies or whitepapers
+
+	adminHandlers.SetPublishChecker(services.NewPublishChecker(queries, cfg.UploadDir, cfg.PublishOverrideRoles))
+
+	publishChecklistHandler := adminHandlers.NewPublishChecklistHandler(logger)
+	adminGroup.POST("/publish-checklist/:type", publishChecklistHandler.Check) // HTMX: re-run with the form's current values
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Share Preview Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Google, X and LinkedIn cards on the product, blog post and whitepaper
+	// edit forms, built from the same metadata as the public page, with
+	// character-count warnings.
+	// :type is products, blog-posts or whitepapers; ?id= names a saved item
+
+	sharePreviewHandler := adminHandlers.NewSharePreviewHandler(queries, logger, seoSvc, siteBaseURL)
+	adminGroup.POST("/share-preview/:type", sharePreviewHandler.Preview) // HTMX: render with the form's current values
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// safeHTML Audit Route (SAFEHTML_AUDIT=true only)
+	// ─────────────────────────────────────────────────────────────────────────
+	// Plain-text report of safeHTML call sites and unsanitized renders
+
+	if safeHTMLAudit != nil {
+		adminGroup.GET("/safehtml-audit", adminHandlers.NewSafeHTMLAuditHandler(safeHTMLAudit).Report)
+	}
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Profile Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// The signed-in user's active sessions with remote logout
+
+	sessionsHandler := adminHandlers.NewSessionsHandler(queries, logger)
+	adminGroup.GET("/profile/sessions", sessionsHandler.List)                        // Signed-in browsers and devices
+	adminGroup.POST("/profile/sessions/:id/revoke", sessionsHandler.Revoke)          // Log out one other session
+	adminGroup.POST("/profile/sessions/revoke-others", sessionsHandler.RevokeOthers) // Log out all but this session
+	adminGroup.POST("/profile/sessions/revoke-all", sessionsHandler.RevokeAll)       // Log out everywhere, including here
+	adminGroup.POST("/session/keepalive", sessionsHandler.KeepAlive)                 // Extend the idle timeout; report time left
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Translation Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Localization workflow: coverage per locale and per-entity translation editor
+
+	translationsHandler := adminHandlers.NewTranslationsHandler(queries, logger, translationSvc, appCache)
+	adminGroup.GET("/translations", translationsHandler.Dashboard)                                   // Coverage dashboard and status list
+	adminGroup.GET("/translations/:type/:id/:locale", translationsHandler.Edit)                      // Side-by-side translation editor
+	adminGroup.POST("/translations/:type/:id/:locale", translationsHandler.Save)                     // Save translated fields (approves machine drafts)
+	adminGroup.POST("/translations/:type/:id/:locale/machine", translationsHandler.MachineTranslate) // Machine-translated draft via configured provider
+	adminGroup.GET("/languages", translationsHandler.Languages)                                      // Site locales
+	adminGroup.POST("/languages", translationsHandler.AddLanguage)                                   // Add a locale
+	adminGroup.POST("/languages/:code", translationsHandler.UpdateLanguage)                          // Rename, reorder, enable or disable a locale
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Admin Contact Management Routes (Phase 8)
@@ -719,6 +1804,23 @@ func main() {
 	adminGroup.POST("/contact/submissions/bulk-mark-read", adminContactHandler.BulkMarkRead)       // Bulk mark read (HTMX)
 	adminGroup.DELETE("/contact/submissions/:id", adminContactHandler.DeleteSubmission)            // Delete submission (HTMX)
 
+	// Leads - submissions grouped by person across contact, RFQ, and whitepaper downloads
+	leadService := services.NewLeadService(queries)
+	leadsHandler := adminHandlers.NewLeadsHandler(leadService, logger)
+	adminGroup.GET("/leads", leadsHandler.List)             // Repeat leads, suggested merges
+	adminGroup.POST("/leads/merge", leadsHandler.Merge)     // Merge one address into another lead
+	adminGroup.POST("/leads/unmerge", leadsHandler.Unmerge) // Split a merged address back out
+
+	// Customer accounts registered on the public site (/account)
+	customersHandler := adminHandlers.NewCustomersHandler(queries, logger)
+	adminGroup.GET("/customers", customersHandler.List)          // Registered customers with library counts
+	adminGroup.DELETE("/customers/:id", customersHandler.Delete) // Delete an account (HTMX)
+
+	// Newsletter subscribers from the footer sign-up form
+	subscribersHandler := adminHandlers.NewSubscribersHandler(queries, newsletter, logger)
+	adminGroup.GET("/subscribers", subscribersHandler.List)          // Subscribers by status, with search
+	adminGroup.DELETE("/subscribers/:id", subscribersHandler.Delete) // Delete a subscriber (HTMX)
+
 	// Office locations - physical office addresses displayed on contact page
 	adminGroup.GET("/contact/offices", adminContactHandler.ListOffices)
 	adminGroup.GET("/contact/offices/new", adminContactHandler.NewOffice)
@@ -727,25 +1829,186 @@ func main() {
 	adminGroup.POST("/contact/offices/:id", adminContactHandler.UpdateOffice)
 	adminGroup.DELETE("/contact/offices/:id", adminContactHandler.DeleteOffice)
 
+	// ─────────────────────────────────────────────────────────────────────────
+	// Export Routes
+	// ─────────────────────────────────────────────────────────────────────────
+	// Products, leads, whitepaper downloads, newsletter subscribers and the
+	// activity log are exported
+	// in the background, one job at a time, reading from the reporting pool.
+	// Files are kept in EXPORT_DIR (default data/exports) and their download
+	// links work for EXPORT_LINK_TTL_HOURS (default 24); the requester is
+	// emailed when the export is ready (with the workflow's SMTP settings).
+
+	exportJobs := services.NewExportJobs(queries, services.NewLocalStorage(cfg.ExportDir), logger, mailer, siteBaseURL, cfg.ExportLinkTTL)
+	exportJobs.Register(services.ExportProducts, services.ProductExportSource(reportingQueries))
+	exportJobs.Register(services.ExportLeads, services.LeadExportSource(leadService))
+	exportJobs.Register(services.ExportWhitepaperDownloads, services.WhitepaperDownloadExportSource(reportingQueries))
+	exportJobs.Register(services.ExportSubscribers, services.NewsletterSubscriberExportSource(reportingQueries, newsletter))
+	exportJobs.Register(services.ExportActivity, activityHandler.ExportSource())
+	exportJobs.Start(jobCtx)
+
+	exportsHandler := adminHandlers.NewExportsHandler(queries, logger, exportJobs)
+	adminGroup.GET("/exports", exportsHandler.List)                  // Recent exports of the signed-in user
+	adminGroup.POST("/exports", exportsHandler.Start)                // Queue an export (kind, format, list filters)
+	adminGroup.GET("/exports/:id", exportsHandler.Status)            // Progress, polled by HTMX until done
+	adminGroup.GET("/exports/:id/download", exportsHandler.Download) // The file, until the link expires
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Backup Routes (admins only)
+	// ─────────────────────────────────────────────────────────────────────────
+	// Archives of the database (copied with SQLite's online backup API) and
+	// UPLOAD_DIR as tar.gz: downloaded directly or kept in BACKUP_DIR (default
+	// data/backups). A backup is also taken every BACKUP_INTERVAL_HOURS
+	// (default 24, 0 = disabled), keeping the newest BACKUP_KEEP (default 7).
+	// A restore first backs up the current state, then migrates the restored
+	// schema and reloads redirects, SEO overrides, the site timezone and
+	// number format and the page cache.
+
+	backups := services.NewBackups(db, cfg.UploadDir, services.NewLocalStorage(cfg.BackupDir), logger, services.BackupConfig{
+		Interval:      cfg.BackupInterval,
+		Keep:          cfg.BackupKeep,
+		SchemaVersion: schema.Latest,
+		AfterRestore: func(ctx context.Context) error {
+			if err := migrator.Up(); err != nil {
+				return err
+			}
+			if err := redirectSvc.Reload(ctx); err != nil {
+				return err
+			}
+			if err := seoSvc.Reload(ctx); err != nil {
+				return err
+			}
+			if err := navSvc.Reload(ctx); err != nil {
+				return err
+			}
+			if err := blockSvc.Reload(ctx); err != nil {
+				return err
+			}
+			if settings, err := queries.GetSettings(ctx); err == nil {
+				services.SetSiteTimezone(settings.Timezone)
+				services.SetSiteFormat(settings.FormatLocale, settings.Currency)
+			}
+			appCache.DeleteByPrefix("")
+			return nil
+		},
+	})
+	scheduler.Add(jobs.Job{
+		Name:        "backup",
+		Description: "Store a scheduled backup in BACKUP_DIR, keeping the newest BACKUP_KEEP",
+		Schedule:    everyIf(cfg.BackupInterval),
+		Run: func(ctx context.Context) error {
+			_, err := backups.Create(ctx, services.BackupScheduled)
+			return err
+		},
+	})
+
+	backupsHandler := adminHandlers.NewBackupsHandler(logger, backups)
+	backupGroup := adminGroup.Group("/maintenance/backup", customMiddleware.RequireRole("admin"))
+	backupGroup.GET("", backupsHandler.List)                         // Stored backups and actions
+	backupGroup.POST("", backupsHandler.Create)                      // Back up now into BACKUP_DIR
+	backupGroup.GET("/download", backupsHandler.Download)            // Stream a new snapshot without storing it
+	backupGroup.POST("/upload", backupsHandler.Upload)               // Store an uploaded archive, then confirm its restore
+	backupGroup.GET("/:name", backupsHandler.DownloadStored)         // A stored backup
+	backupGroup.POST("/:name/delete", backupsHandler.Delete)         // Delete a stored backup
+	backupGroup.GET("/:name/restore", backupsHandler.ConfirmRestore) // Contents and the confirmation form
+	backupGroup.POST("/:name/restore", backupsHandler.Restore)       // Restore after typing RESTORE
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Cache Warmer
+	// ─────────────────────────────────────────────────────────────────────────
+	// Pre-renders product category pages, including paginated pages up to
+	// MaxIndexedCategoryPages, by requesting them in-process every
+	// CACHE_WARM_INTERVAL_MINUTES (default 5, 0 = disabled). Category pages are
+	// cached for 10 minutes, so a shorter interval keeps them warm.
+	cacheWarmer := services.NewCacheWarmer(e, logger, publicHandlers.CategoryPageURLs(queries))
+	// It also re-renders, in the background, every cached public page (product
+	// detail, category, listing, homepage, ...) an admin change invalidates, a
+	// couple of seconds after the change, so the next visitor gets a cached page.
+	publicHandlers.SetCacheWarmer(cacheWarmer)
+	appCache.OnDelete(cacheWarmer.Invalidated)
+	cacheWarmer.Start(jobCtx)
+	scheduler.Add(jobs.Job{
+		Name:        "cache-warm",
+		Description: "Pre-render product category pages into the page cache",
+		Schedule:    everyIf(cfg.CacheWarmInterval),
+		Run: func(ctx context.Context) error {
+			_, err := cacheWarmer.Run(ctx)
+			return err
+		},
+	})
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Link Check
+	// ─────────────────────────────────────────────────────────────────────────
+	// Requests every internal link in rich text and CTA buttons in-process
+	// each night and lists those answering 400 or above, with shortcuts to
+	// edit the link, publish its target or add a redirect. Absolute links to
+	// SITE_BASE_URL count as internal.
+	linkChecker := services.NewLinkChecker(db, queries, e, cfg.SiteBaseURL, logger)
+	scheduler.Add(jobs.Job{
+		Name:        "link-check",
+		Description: "Find internal links to missing or unpublished pages",
+		Schedule:    jobs.MustCron("30 3 * * *"),
+		Run: func(ctx context.Context) error {
+			_, err := linkChecker.Run(ctx)
+			return err
+		},
+	})
+	linkCheckHandler := adminHandlers.NewLinkCheckHandler(linkChecker, scheduler, logger)
+	adminGroup.GET("/link-check", linkCheckHandler.Report)   // Broken links of the last run, polled while a run is in progress
+	adminGroup.POST("/link-check/run", linkCheckHandler.Run) // Run the check now
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Job Routes (admins only)
+	// ─────────────────────────────────────────────────────────────────────────
+	// Every job above with its schedule, next run and last run (duration,
+	// error, trigger). New jobs and runs missed while the server was down run
+	// a minute after startup; disabled jobs can still be run by hand.
+	scheduler.Start(jobCtx)
+
+	jobsHandler := adminHandlers.NewJobsHandler(logger, scheduler)
+	jobsGroup := adminGroup.Group("/jobs", customMiddleware.RequireRole("admin"))
+	jobsGroup.GET("", jobsHandler.List)              // Jobs and their last runs, polled while one is running
+	jobsGroup.POST("/:name/run", jobsHandler.RunNow) // Start a job now
+
 	// ═══════════════════════════════════════════════════════════════════════════
 	// SERVER STARTUP AND GRACEFUL SHUTDOWN
 	// ═══════════════════════════════════════════════════════════════════════════
 
 	// Start HTTP server in a goroutine so it doesn't block signal handling
-	// The server listens on port 28090 for all incoming HTTP requests
-	port := "28090"
-	if v := os.Getenv("PORT"); v != "" {
-		port = v
+	// The server listens on PORT (default 28090) for all incoming HTTP requests,
+	// or with TLS_ENABLED or TLS_DOMAINS set, serves HTTPS itself on
+	// HTTPS_PORT with certificates from Let's Encrypt and redirects HTTP_PORT to it
+	var redirectServer *http.Server
+	if cfg.TLSEnabled() {
+		configureAutoTLS(e, cfg, queries)
+		redirectServer = newRedirectServer(e, cfg)
+		go func() {
+			logger.Info("starting HTTP redirect server", "port", cfg.HTTPPort)
+			if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("redirect server error", "error", err)
+				os.Exit(1)
+			}
+		}()
+		go func() {
+			logger.Info("starting HTTPS server", "port", cfg.HTTPSPort, "fallback_domains", cfg.TLSDomains)
+			if err := e.StartAutoTLS(":" + cfg.HTTPSPort); err != nil && err != http.ErrServerClosed {
+				logger.Error("server error", "error", err)
+				os.Exit(1)
+			}
+		}()
+	} else {
+		port := cfg.Port
+		go func() {
+			logger.Info("starting server", "port", port)
+			// Start returns an error when server stops (normal shutdown or fatal error)
+			if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
+				// Log fatal errors (http.ErrServerClosed is expected during graceful shutdown)
+				logger.Error("server error", "error", err)
+				os.Exit(1)
+			}
+		}()
 	}
-	go func() {
-		logger.Info("starting server", "port", port)
-		// Start returns an error when server stops (normal shutdown or fatal error)
-		if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
-			// Log fatal errors (http.ErrServerClosed is expected during graceful shutdown)
-			logger.Error("server error", "error", err)
-			os.Exit(1)
-		}
-	}()
 
 	// Set up signal handling for graceful shutdown
 	// Create buffered channel to receive OS signals (buffer prevents blocking)
@@ -757,6 +2020,7 @@ func main() {
 
 	// Graceful shutdown sequence begins
 	logger.Info("shutting down server")
+	stopJobs()
 
 	// Create context with 10-second timeout for shutdown operations
 	// This gives active requests time to complete before forced termination
@@ -768,6 +2032,34 @@ func main() {
 	if err := e.Shutdown(ctx); err != nil {
 		logger.Error("server shutdown error", "error", err)
 	}
+	if redirectServer != nil {
+		if err := redirectServer.Shutdown(ctx); err != nil {
+			logger.Error("redirect server shutdown error", "error", err)
+		}
+	}
+
+	// Flush spans still buffered for the exporter
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("tracing shutdown error", "error", err)
+	}
 
 	logger.Info("server stopped")
 }
+
+// cronIf returns the cron schedule expr, or nil so the job only runs by hand
+// when it is disabled in the configuration.
+func cronIf(enabled bool, expr string) jobs.Schedule {
+	if !enabled {
+		return nil
+	}
+	return jobs.MustCron(expr)
+}
+
+// everyIf returns an interval schedule, or nil so the job only runs by hand
+// when the interval is 0 (disabled).
+func everyIf(interval time.Duration) jobs.Schedule {
+	if interval <= 0 {
+		return nil
+	}
+	return jobs.Every(interval)
+}
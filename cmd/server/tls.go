@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net"      // Splitting the port off request hosts
+	"net/http" // Redirect server and status codes
+	"time"     // Timeouts of the redirect server
+
+	"github.com/labstack/echo/v4"       // HTTP router and framework
+	"golang.org/x/crypto/acme/autocert" // Let's Encrypt certificates
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Settings holding the HTTPS host names
+	"github.com/narendhupati/bluejay-cms/internal/config"   // TLS settings
+	"github.com/narendhupati/bluejay-cms/internal/services" // Host policy
+)
+
+// configureAutoTLS sets up Echo's certificate manager, so e.StartAutoTLS
+// serves HTTPS with certificates from Let's Encrypt. Only the host names
+// saved on the HTTPS Domains admin page get certificates, or those of
+// TLS_DOMAINS while none are saved; handshakes for any other name fail
+// before Let's Encrypt is contacted. Certificates and the account key are
+// kept in TLS_CACHE_DIR, so restarts do not request new ones.
+func configureAutoTLS(e *echo.Echo, cfg config.Config, queries sqlc.Querier) {
+	e.AutoTLSManager.Prompt = autocert.AcceptTOS
+	e.AutoTLSManager.HostPolicy = services.TLSHostPolicy(queries, cfg.TLSDomains)
+	e.AutoTLSManager.Cache = autocert.DirCache(cfg.TLSCacheDir)
+	e.AutoTLSManager.Email = cfg.TLSEmail
+}
+
+// newRedirectServer returns the plain HTTP server run next to the HTTPS one.
+// It answers Let's Encrypt's http-01 challenges and redirects every other
+// request to the same URL over HTTPS, on HTTPS_PORT when that is not 443.
+func newRedirectServer(e *echo.Echo, cfg config.Config) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.HTTPPort,
+		Handler:           e.AutoTLSManager.HTTPHandler(httpsRedirect(cfg.HTTPSPort)),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+}
+
+// httpsRedirect returns a handler that permanently redirects requests to
+// https:// on httpsPort. Only GET and HEAD are redirected; other methods get
+// 400, since clients would resend a form body in the clear.
+func httpsRedirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Use HTTPS", http.StatusBadRequest)
+			return
+		}
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS archive_runs_no_delete;
+DROP TRIGGER IF EXISTS archive_runs_no_update;
+DROP INDEX IF EXISTS idx_archive_runs_table_period;
+DROP TABLE IF EXISTS archive_runs;
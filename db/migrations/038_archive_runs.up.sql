@@ -0,0 +1,30 @@
+-- Append-only ledger of monthly compliance archives. Each row records one
+-- exported month of a live table (activity_log, contact_submissions,
+-- whitepaper_downloads): where the compressed export lives in storage, how many
+-- rows it holds, the SHA-256 of the stored bytes, and the hash chain linking it
+-- to the previous archive of the same table.
+CREATE TABLE IF NOT EXISTS archive_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_table TEXT NOT NULL,
+    period TEXT NOT NULL,
+    storage_key TEXT NOT NULL,
+    row_count INTEGER NOT NULL DEFAULT 0,
+    content_hash TEXT NOT NULL,
+    prev_chain_hash TEXT NOT NULL DEFAULT '',
+    chain_hash TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_archive_runs_table_period ON archive_runs(source_table, period);
+
+-- The ledger is immutable: once an archive is recorded it can never be edited
+-- or removed, otherwise the hash chain could be silently rewritten.
+CREATE TRIGGER archive_runs_no_update BEFORE UPDATE ON archive_runs
+BEGIN
+    SELECT RAISE(ABORT, 'archive_runs is append-only');
+END;
+
+CREATE TRIGGER archive_runs_no_delete BEFORE DELETE ON archive_runs
+BEGIN
+    SELECT RAISE(ABORT, 'archive_runs is append-only');
+END;
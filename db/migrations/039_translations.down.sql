@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_translations_locale_status;
+DROP INDEX IF EXISTS idx_translations_entity_locale;
+DROP TABLE IF EXISTS translations;
@@ -0,0 +1,24 @@
+-- Per-entity translations. One row holds the translated fields of a single
+-- entity (product, blog post, solution) for a single locale. fields is a JSON
+-- object keyed by source column name. source_hash fingerprints the source
+-- fields at the time the translation was saved so edits to the source can mark
+-- the translation as outdated.
+--
+-- status values:
+--   missing  - no usable translation yet (not every source field translated)
+--   outdated - complete when saved, but the source content changed since
+--   complete - every non-empty source field has a translation
+CREATE TABLE IF NOT EXISTS translations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity_type TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    fields TEXT NOT NULL DEFAULT '{}',
+    source_hash TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'missing',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_translations_entity_locale ON translations(entity_type, entity_id, locale);
+CREATE INDEX idx_translations_locale_status ON translations(locale, status);
@@ -0,0 +1,5 @@
+-- SQLite cannot drop a column that carries a foreign key constraint, so
+-- blog_posts.series_id and series_order remain but are ignored.
+-- Dropping blog_series clears series_id on every post (ON DELETE SET NULL).
+DROP INDEX IF EXISTS idx_blog_posts_series;
+DROP TABLE IF EXISTS blog_series;
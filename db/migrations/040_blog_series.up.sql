@@ -0,0 +1,15 @@
+-- Blog series group multi-part articles. A post belongs to at most one series;
+-- series_order is its part number within the series (1-based, ascending).
+CREATE TABLE IF NOT EXISTS blog_series (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    description TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE blog_posts ADD COLUMN series_id INTEGER REFERENCES blog_series(id) ON DELETE SET NULL;
+ALTER TABLE blog_posts ADD COLUMN series_order INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_blog_posts_series ON blog_posts(series_id, series_order);
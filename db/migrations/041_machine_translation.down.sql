@@ -0,0 +1,4 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The mt_provider and mt_api_key columns will remain if downgrade is needed.
+-- Unreviewed machine drafts fall back to the pre-existing 'missing' status.
+UPDATE translations SET status = 'missing' WHERE status = 'machine';
@@ -0,0 +1,9 @@
+-- Machine-translation assist. The provider ('deepl', 'google', or '' for
+-- disabled) and its API key are configured under Global Settings.
+--
+-- Drafts produced by the provider are stored in translations with the new
+-- status 'machine': they are never rendered on public pages and stay flagged
+-- until an editor reviews and saves them, which recomputes the status as
+-- 'complete' or 'missing'.
+ALTER TABLE settings ADD COLUMN mt_provider TEXT NOT NULL DEFAULT '';
+ALTER TABLE settings ADD COLUMN mt_api_key TEXT NOT NULL DEFAULT '';
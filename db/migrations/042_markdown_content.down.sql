@@ -0,0 +1,5 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The content_format and body_markdown columns will remain if downgrade is needed.
+-- Rendered HTML stays in body, so Markdown posts keep displaying and open in
+-- the WYSIWYG editor.
+UPDATE blog_posts SET content_format = 'html';
@@ -0,0 +1,6 @@
+-- Markdown authoring mode for blog posts. content_format is 'html' (Trix
+-- WYSIWYG, the default) or 'markdown'. For Markdown posts the source is kept
+-- in body_markdown for editing, and body holds the sanitized HTML rendered on
+-- save, so public pages, feeds, and translations keep reading body unchanged.
+ALTER TABLE blog_posts ADD COLUMN content_format TEXT NOT NULL DEFAULT 'html';
+ALTER TABLE blog_posts ADD COLUMN body_markdown TEXT NOT NULL DEFAULT '';
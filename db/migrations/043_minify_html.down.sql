@@ -0,0 +1,2 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The minify_html column will remain if downgrade is needed.
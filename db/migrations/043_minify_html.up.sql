@@ -0,0 +1,5 @@
+-- Optional HTML minification of public pages. When enabled under Global
+-- Settings, rendered pages have comments and redundant whitespace stripped
+-- before they are cached and served; <pre>, <code>, <textarea>, <script>,
+-- and <style> contents are left untouched.
+ALTER TABLE settings ADD COLUMN minify_html BOOLEAN NOT NULL DEFAULT 0;
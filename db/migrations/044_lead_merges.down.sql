@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_lead_merges_primary;
+DROP TABLE IF EXISTS lead_merges;
@@ -0,0 +1,11 @@
+-- Lead deduplication. The admin Leads view groups contact/RFQ submissions and
+-- whitepaper downloads by normalized email (lowercased, plus-address tag
+-- removed). When an editor merges two people who used different addresses,
+-- the merged-away address is recorded here and its submissions are listed
+-- under the primary address. Source rows are never modified.
+CREATE TABLE IF NOT EXISTS lead_merges (
+    email TEXT PRIMARY KEY,
+    primary_email TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_lead_merges_primary ON lead_merges(primary_email);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_product_categories_parent;
+-- SQLite does not support DROP COLUMN in older versions.
+-- The parent_id column will remain if downgrade is needed.
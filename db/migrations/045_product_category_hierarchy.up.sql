@@ -0,0 +1,7 @@
+-- Product category hierarchy (families -> series -> types). A category with a
+-- NULL parent_id is a top-level family. Depth is limited in the application
+-- (services.MaxCategoryDepth); ON DELETE RESTRICT keeps a category with
+-- subcategories from being deleted.
+ALTER TABLE product_categories ADD COLUMN parent_id INTEGER REFERENCES product_categories(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_product_categories_parent ON product_categories(parent_id);
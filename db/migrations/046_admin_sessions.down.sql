@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_admin_sessions_user;
+DROP TABLE IF EXISTS admin_sessions;
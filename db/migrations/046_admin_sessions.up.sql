@@ -0,0 +1,15 @@
+-- Server-side record of admin login sessions. The session cookie carries the
+-- token; a cookie whose row is gone (revoked, logged out everywhere) is no
+-- longer accepted. last_seen_at and ip_address are refreshed at most once a
+-- minute by middleware.SessionTracker.
+CREATE TABLE IF NOT EXISTS admin_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_sessions_user ON admin_sessions(user_id, last_seen_at DESC);
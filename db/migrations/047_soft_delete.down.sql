@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_products_deleted_at;
+DROP INDEX IF EXISTS idx_blog_posts_deleted_at;
+DROP INDEX IF EXISTS idx_solutions_deleted_at;
+DROP INDEX IF EXISTS idx_case_studies_deleted_at;
+-- SQLite does not support DROP COLUMN in older versions.
+-- The deleted_at columns will remain if downgrade is needed.
@@ -0,0 +1,12 @@
+-- Soft delete for content entities. A non-NULL deleted_at moves the row to
+-- the admin trash: it is hidden from public pages and admin lists until it is
+-- restored, permanently deleted, or purged by services.TrashPurge.
+ALTER TABLE products ADD COLUMN deleted_at DATETIME;
+ALTER TABLE blog_posts ADD COLUMN deleted_at DATETIME;
+ALTER TABLE solutions ADD COLUMN deleted_at DATETIME;
+ALTER TABLE case_studies ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_blog_posts_deleted_at ON blog_posts(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_solutions_deleted_at ON solutions(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_case_studies_deleted_at ON case_studies(deleted_at);
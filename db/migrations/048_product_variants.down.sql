@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_product_variant_images_variant;
+DROP INDEX IF EXISTS idx_product_variant_specs_variant;
+DROP INDEX IF EXISTS idx_product_variants_product;
+DROP TABLE IF EXISTS product_variant_images;
+DROP TABLE IF EXISTS product_variant_specs;
+DROP TABLE IF EXISTS product_variants;
@@ -0,0 +1,37 @@
+-- Product variants: sellable versions of one product (e.g. 24V / 230V, small /
+-- large) that share its page but carry their own SKU. A variant's specs
+-- override the product spec with the same section and label, or add a new row;
+-- its images, when it has any, replace the product gallery.
+CREATE TABLE IF NOT EXISTS product_variants (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS product_variant_specs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    variant_id INTEGER NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    section_name TEXT NOT NULL,
+    spec_key TEXT NOT NULL,
+    spec_value TEXT NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (variant_id, section_name, spec_key)
+);
+
+CREATE TABLE IF NOT EXISTS product_variant_images (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    variant_id INTEGER NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    image_path TEXT NOT NULL,
+    alt_text TEXT NOT NULL DEFAULT '',
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_variants_product ON product_variants(product_id, display_order);
+CREATE INDEX IF NOT EXISTS idx_product_variant_specs_variant ON product_variant_specs(variant_id, display_order);
+CREATE INDEX IF NOT EXISTS idx_product_variant_images_variant ON product_variant_images(variant_id, display_order);
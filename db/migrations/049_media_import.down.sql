@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS media_variants;
+DROP INDEX IF EXISTS idx_media_files_content_hash;
+ALTER TABLE media_files DROP COLUMN content_hash;
@@ -0,0 +1,19 @@
+-- Media import: a SHA-256 of each file's contents, so the same image is not
+-- stored twice, and resized variants of raster images for listings and
+-- thumbnails. Rows from before this migration have an empty hash until the
+-- next import fills it in.
+ALTER TABLE media_files ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_media_files_content_hash ON media_files(content_hash);
+
+CREATE TABLE IF NOT EXISTS media_variants (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    media_file_id INTEGER NOT NULL REFERENCES media_files(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    file_size INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (media_file_id, name)
+);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS related_content_pins_whitepaper_deleted;
+DROP TRIGGER IF EXISTS related_content_pins_blog_post_deleted;
+DROP TRIGGER IF EXISTS related_content_pins_case_study_deleted;
+DROP TABLE IF EXISTS related_content_pins;
@@ -0,0 +1,30 @@
+-- Related content pins: case studies, blog posts and whitepapers an admin
+-- has attached to a product by hand. Pinned items are shown first in the
+-- product page's related content, ahead of the scored recommendations.
+-- content_id is not a foreign key because it points into one of three
+-- tables; the triggers below remove pins when their content is deleted.
+CREATE TABLE IF NOT EXISTS related_content_pins (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    content_type TEXT NOT NULL CHECK (content_type IN ('case_study', 'blog_post', 'whitepaper')),
+    content_id INTEGER NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, content_type, content_id)
+);
+CREATE INDEX IF NOT EXISTS idx_related_content_pins_product ON related_content_pins(product_id);
+
+CREATE TRIGGER related_content_pins_case_study_deleted AFTER DELETE ON case_studies
+BEGIN
+    DELETE FROM related_content_pins WHERE content_type = 'case_study' AND content_id = OLD.id;
+END;
+
+CREATE TRIGGER related_content_pins_blog_post_deleted AFTER DELETE ON blog_posts
+BEGIN
+    DELETE FROM related_content_pins WHERE content_type = 'blog_post' AND content_id = OLD.id;
+END;
+
+CREATE TRIGGER related_content_pins_whitepaper_deleted AFTER DELETE ON whitepapers
+BEGIN
+    DELETE FROM related_content_pins WHERE content_type = 'whitepaper' AND content_id = OLD.id;
+END;
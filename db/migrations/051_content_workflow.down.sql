@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS content_workflow_product_deleted;
+DROP TRIGGER IF EXISTS content_workflow_blog_post_deleted;
+DROP TABLE IF EXISTS content_workflow_history;
+DROP TABLE IF EXISTS content_workflow;
@@ -0,0 +1,42 @@
+-- Publishing workflow: draft -> in review -> approved -> published for blog
+-- posts and products. content_workflow holds the current state and the
+-- assigned reviewer of each item that has entered the workflow; items
+-- without a row are in draft (or published, following their status column).
+-- content_workflow_history records every transition for the review panel.
+-- content_id is not a foreign key because it points into one of several
+-- tables; the triggers below remove rows when their content is deleted.
+CREATE TABLE IF NOT EXISTS content_workflow (
+    content_type TEXT NOT NULL CHECK (content_type IN ('blog_post', 'product')),
+    content_id INTEGER NOT NULL,
+    state TEXT NOT NULL DEFAULT 'draft' CHECK (state IN ('draft', 'in_review', 'approved', 'published')),
+    reviewer_id INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    submitted_by INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (content_type, content_id)
+);
+CREATE INDEX IF NOT EXISTS idx_content_workflow_state ON content_workflow(state, updated_at);
+CREATE INDEX IF NOT EXISTS idx_content_workflow_reviewer ON content_workflow(reviewer_id);
+
+CREATE TABLE IF NOT EXISTS content_workflow_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    content_type TEXT NOT NULL,
+    content_id INTEGER NOT NULL,
+    from_state TEXT NOT NULL,
+    to_state TEXT NOT NULL,
+    user_id INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_content_workflow_history_item ON content_workflow_history(content_type, content_id, created_at);
+
+CREATE TRIGGER content_workflow_blog_post_deleted AFTER DELETE ON blog_posts
+BEGIN
+    DELETE FROM content_workflow WHERE content_type = 'blog_post' AND content_id = OLD.id;
+    DELETE FROM content_workflow_history WHERE content_type = 'blog_post' AND content_id = OLD.id;
+END;
+
+CREATE TRIGGER content_workflow_product_deleted AFTER DELETE ON products
+BEGIN
+    DELETE FROM content_workflow WHERE content_type = 'product' AND content_id = OLD.id;
+    DELETE FROM content_workflow_history WHERE content_type = 'product' AND content_id = OLD.id;
+END;
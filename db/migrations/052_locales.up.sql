@@ -0,0 +1,12 @@
+-- Site locales. The default locale is the language content is written in;
+-- every other enabled locale is a translation target that gets its own
+-- /<code>/ URL prefix and language switcher entry. Rows are seeded from
+-- SITE_LOCALES at startup and managed under Admin > Languages afterwards.
+CREATE TABLE IF NOT EXISTS locales (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT 0,
+    is_enabled BOOLEAN NOT NULL DEFAULT 1,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
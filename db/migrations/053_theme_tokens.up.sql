@@ -0,0 +1,19 @@
+-- Theme tokens for the public site and admin panel. Each mode (light and
+-- dark) has its own brand, surface, text and border colors, configured under
+-- Global Settings and served to both layouts as CSS variables from
+-- /theme-tokens.css. theme_mode picks what visitors see before they choose a
+-- theme with the header toggle: 'light', 'dark', or 'system' to follow the
+-- browser's prefers-color-scheme.
+ALTER TABLE settings ADD COLUMN theme_mode TEXT NOT NULL DEFAULT 'light';
+ALTER TABLE settings ADD COLUMN theme_light_primary TEXT NOT NULL DEFAULT '#0066CC';
+ALTER TABLE settings ADD COLUMN theme_light_primary_hover TEXT NOT NULL DEFAULT '#004499';
+ALTER TABLE settings ADD COLUMN theme_light_background TEXT NOT NULL DEFAULT '#FFFFFF';
+ALTER TABLE settings ADD COLUMN theme_light_surface TEXT NOT NULL DEFAULT '#FFFFFF';
+ALTER TABLE settings ADD COLUMN theme_light_text TEXT NOT NULL DEFAULT '#000000';
+ALTER TABLE settings ADD COLUMN theme_light_border TEXT NOT NULL DEFAULT '#000000';
+ALTER TABLE settings ADD COLUMN theme_dark_primary TEXT NOT NULL DEFAULT '#4D9FFF';
+ALTER TABLE settings ADD COLUMN theme_dark_primary_hover TEXT NOT NULL DEFAULT '#80BAFF';
+ALTER TABLE settings ADD COLUMN theme_dark_background TEXT NOT NULL DEFAULT '#0F1115';
+ALTER TABLE settings ADD COLUMN theme_dark_surface TEXT NOT NULL DEFAULT '#1A1D23';
+ALTER TABLE settings ADD COLUMN theme_dark_text TEXT NOT NULL DEFAULT '#E6E8EB';
+ALTER TABLE settings ADD COLUMN theme_dark_border TEXT NOT NULL DEFAULT '#4A505A';
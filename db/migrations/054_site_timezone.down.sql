@@ -0,0 +1,2 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The timezone column will remain if downgrade is needed.
@@ -0,0 +1,4 @@
+-- Site timezone (an IANA name such as 'Europe/Berlin'), configured under
+-- Global Settings. Timestamps are stored in UTC; templates convert them to
+-- this zone for display, and admin date inputs are read in it.
+ALTER TABLE settings ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
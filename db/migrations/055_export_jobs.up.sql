@@ -0,0 +1,25 @@
+-- Background export jobs. Large exports (products, leads, whitepaper download
+-- analytics, activity log) are queued here and run by services.ExportJobs,
+-- which writes the file to export storage and records where it is. Download
+-- links stop working at expires_at, when the file is deleted and the job is
+-- marked expired. params holds the list filters as a URL query string.
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('csv', 'xlsx')),
+    params TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'done', 'failed', 'expired')),
+    rows_done INTEGER NOT NULL DEFAULT 0,
+    rows_total INTEGER NOT NULL DEFAULT 0,
+    storage_key TEXT NOT NULL DEFAULT '',
+    filename TEXT NOT NULL DEFAULT '',
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    user_id INTEGER REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    finished_at DATETIME,
+    expires_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_export_jobs_status ON export_jobs(status, id);
+CREATE INDEX IF NOT EXISTS idx_export_jobs_user ON export_jobs(user_id, created_at);
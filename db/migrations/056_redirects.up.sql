@@ -0,0 +1,15 @@
+-- URL redirects. Rows are added automatically when an editor changes a slug
+-- (old public path -> new public path) and by hand under Admin > Redirects.
+-- A source_path ending in "*" is a wildcard matching every path with that
+-- prefix; a "*" at the end of target_path is replaced by the matched rest.
+CREATE TABLE IF NOT EXISTS redirects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_path TEXT NOT NULL UNIQUE,
+    target_path TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 301 CHECK (status_code IN (301, 302)),
+    is_automatic BOOLEAN NOT NULL DEFAULT 0,
+    hits INTEGER NOT NULL DEFAULT 0,
+    last_hit_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS not_found_hits;
@@ -0,0 +1,13 @@
+-- Public 404s, one row per (path, referrer) pair, recorded by the
+-- NotFoundTracker error handler and reported under Admin > 404s so broken
+-- inbound links can be fixed with a redirect. referrer is '' for direct
+-- visits and requests without a Referer header.
+CREATE TABLE IF NOT EXISTS not_found_hits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    path TEXT NOT NULL,
+    referrer TEXT NOT NULL DEFAULT '',
+    hits INTEGER NOT NULL DEFAULT 1,
+    first_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (path, referrer)
+);
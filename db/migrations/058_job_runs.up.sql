@@ -0,0 +1,15 @@
+-- Last run of each scheduled job (internal/jobs), one row per job name,
+-- shown under Admin > Jobs. Kept across restarts so interval jobs resume
+-- their schedule and runs missed while the server was down are caught up.
+-- last_error is '' when the last run succeeded; last_trigger is 'schedule'
+-- or 'manual' ("Run now").
+CREATE TABLE IF NOT EXISTS job_runs (
+    name TEXT PRIMARY KEY,
+    last_started_at DATETIME NOT NULL,
+    last_duration_ms INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    last_trigger TEXT NOT NULL DEFAULT 'schedule',
+    last_success_at DATETIME,
+    runs INTEGER NOT NULL DEFAULT 0,
+    failures INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,2 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The maintenance_mode and maintenance_message columns will remain if downgrade is needed.
@@ -0,0 +1,5 @@
+-- Maintenance mode, switched on under Global Settings. While it is on,
+-- public pages answer 503 with a maintenance page showing the message
+-- (blank uses a default text); signed-in admins still see the site.
+ALTER TABLE settings ADD COLUMN maintenance_mode BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE settings ADD COLUMN maintenance_message TEXT NOT NULL DEFAULT '';
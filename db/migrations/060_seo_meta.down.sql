@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_seo_meta_entity;
+DROP INDEX IF EXISTS idx_seo_meta_route;
+DROP TABLE IF EXISTS seo_meta;
@@ -0,0 +1,23 @@
+-- Search and social metadata set under Admin > SEO. A row overrides the
+-- title, description, canonical URL, Open Graph image and robots tag of one
+-- public page, named either by its path (route, e.g. /about) or by the
+-- content it shows (entity_type and entity_id, e.g. product 12), so an
+-- entity override follows the page when its slug changes. Empty fields keep
+-- the page's own value.
+CREATE TABLE IF NOT EXISTS seo_meta (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    route TEXT NOT NULL DEFAULT '',
+    entity_type TEXT NOT NULL DEFAULT '',
+    entity_id INTEGER NOT NULL DEFAULT 0,
+    meta_title TEXT NOT NULL DEFAULT '',
+    meta_description TEXT NOT NULL DEFAULT '',
+    canonical_url TEXT NOT NULL DEFAULT '',
+    og_image TEXT NOT NULL DEFAULT '',
+    noindex BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((route <> '') <> (entity_type <> '' AND entity_id > 0))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seo_meta_route ON seo_meta(route) WHERE route <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seo_meta_entity ON seo_meta(entity_type, entity_id) WHERE entity_type <> '';
@@ -0,0 +1,3 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The navigation_menus.is_active and navigation_items.visibility columns
+-- will remain if downgrade is needed.
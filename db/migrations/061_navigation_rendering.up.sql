@@ -0,0 +1,6 @@
+-- Public rendering of navigation menus. The active menu of each location
+-- (header, footer, footer_legal, mobile) replaces the built-in links of the
+-- site header and footer; activating a menu deactivates the others at its
+-- location. Items can be limited to desktop or mobile layouts.
+ALTER TABLE navigation_menus ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE navigation_items ADD COLUMN visibility TEXT NOT NULL DEFAULT 'all';
@@ -0,0 +1,3 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The navigation_items icon, layout, featured_type and featured_id columns
+-- will remain if downgrade is needed.
@@ -0,0 +1,9 @@
+-- Mega menus for navigation items. An item can show a Material Symbols
+-- icon; a top-level item with layout 'mega' opens a wide panel whose child
+-- items form columns, and any dropdown can feature a product, solution,
+-- blog post or case study as a card (featured_type names the content type
+-- as in seo_meta.entity_type; an empty featured_type means no card).
+ALTER TABLE navigation_items ADD COLUMN icon TEXT NOT NULL DEFAULT '';
+ALTER TABLE navigation_items ADD COLUMN layout TEXT NOT NULL DEFAULT 'list';
+ALTER TABLE navigation_items ADD COLUMN featured_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE navigation_items ADD COLUMN featured_id INTEGER NOT NULL DEFAULT 0;
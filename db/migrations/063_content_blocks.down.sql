@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_page_blocks_block;
+DROP INDEX IF EXISTS idx_page_blocks_route;
+DROP TABLE IF EXISTS page_blocks;
+DROP TABLE IF EXISTS content_blocks;
@@ -0,0 +1,29 @@
+-- Reusable content blocks managed under Admin > Content Blocks. A block is
+-- rich text, a call to action, a stats strip or a logo wall; page_blocks
+-- attaches an ordered list of blocks to any public page by its path, where
+-- they render below the page's own content. Stats and logo wall entries are
+-- stored one per line in items, with fields separated by "|".
+CREATE TABLE IF NOT EXISTS content_blocks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    block_type TEXT NOT NULL CHECK (block_type IN ('rich_text', 'cta', 'stats', 'logo_wall')),
+    heading TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    button_text TEXT NOT NULL DEFAULT '',
+    button_url TEXT NOT NULL DEFAULT '',
+    items TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS page_blocks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    route TEXT NOT NULL,
+    block_id INTEGER NOT NULL REFERENCES content_blocks(id) ON DELETE CASCADE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_page_blocks_route ON page_blocks(route, sort_order);
+CREATE INDEX IF NOT EXISTS idx_page_blocks_block ON page_blocks(block_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_landing_page_sections_page;
+DROP TABLE IF EXISTS landing_page_sections;
+DROP TABLE IF EXISTS landing_pages;
@@ -0,0 +1,40 @@
+-- Campaign landing pages managed under Admin > Landing Pages. A page lives at
+-- /<slug> (the slug may have several segments, e.g. spring/offer), uses the
+-- site layout or a minimal one without navigation, carries its own SEO fields
+-- and analytics snippets, and is built from an ordered list of sections
+-- (hero, text, lead form, testimonials). Library blocks attached to the
+-- page's path through page_blocks render below its sections. Testimonial
+-- entries are stored one per line in items as "quote | name | role".
+CREATE TABLE IF NOT EXISTS landing_pages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    layout TEXT NOT NULL DEFAULT 'default' CHECK (layout IN ('default', 'minimal')),
+    meta_title TEXT NOT NULL DEFAULT '',
+    meta_description TEXT NOT NULL DEFAULT '',
+    og_image TEXT NOT NULL DEFAULT '',
+    noindex BOOLEAN NOT NULL DEFAULT 0,
+    head_snippet TEXT NOT NULL DEFAULT '',
+    body_snippet TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published')),
+    published_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS landing_page_sections (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    landing_page_id INTEGER NOT NULL REFERENCES landing_pages(id) ON DELETE CASCADE,
+    section_type TEXT NOT NULL CHECK (section_type IN ('hero', 'text', 'form', 'testimonials')),
+    heading TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    image TEXT NOT NULL DEFAULT '',
+    button_text TEXT NOT NULL DEFAULT '',
+    button_url TEXT NOT NULL DEFAULT '',
+    items TEXT NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_landing_page_sections_page ON landing_page_sections(landing_page_id, sort_order);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_form_submissions_form;
+DROP INDEX IF EXISTS idx_form_fields_form;
+DROP TABLE IF EXISTS form_submissions;
+DROP TABLE IF EXISTS form_fields;
+DROP TABLE IF EXISTS forms;
@@ -0,0 +1,50 @@
+-- Custom lead-generation forms built under Admin > Forms. A form has an
+-- ordered list of fields with their validation rules, a message shown after
+-- a successful submission and the addresses notified of each submission
+-- (comma separated in notify_emails). Active forms are served at
+-- /forms/<slug> and can be embedded in landing page form sections.
+-- Submissions keep the answers as a JSON array of {name, label, type, value}, so
+-- they survive later edits to the fields.
+CREATE TABLE IF NOT EXISTS forms (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    submit_label TEXT NOT NULL DEFAULT 'Submit',
+    success_message TEXT NOT NULL DEFAULT '',
+    notify_emails TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS form_fields (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    form_id INTEGER NOT NULL REFERENCES forms(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    name TEXT NOT NULL,
+    field_type TEXT NOT NULL CHECK (field_type IN ('text', 'email', 'tel', 'number', 'textarea', 'select', 'checkbox')),
+    placeholder TEXT NOT NULL DEFAULT '',
+    help_text TEXT NOT NULL DEFAULT '',
+    options TEXT NOT NULL DEFAULT '',
+    required BOOLEAN NOT NULL DEFAULT 0,
+    min_length INTEGER NOT NULL DEFAULT 0,
+    max_length INTEGER NOT NULL DEFAULT 0,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (form_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS form_submissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    form_id INTEGER NOT NULL REFERENCES forms(id) ON DELETE CASCADE,
+    data TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_form_fields_form ON form_fields(form_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_form ON form_submissions(form_id, created_at);
@@ -0,0 +1,4 @@
+DROP VIEW IF EXISTS page_view_counts;
+DROP TABLE IF EXISTS page_view_daily;
+DROP INDEX IF EXISTS idx_page_views_viewed_at;
+DROP TABLE IF EXISTS page_views;
@@ -0,0 +1,36 @@
+-- First-party analytics (Admin > Analytics). The PageViews middleware
+-- records one row per public HTML page view, without cookies or visitor
+-- identifiers: the referring site's host ('' for direct visits and links
+-- within the site), the user agent class (bots are not recorded) and the
+-- country the IP address maps to ('' when unknown). The daily
+-- "analytics-rollup" job folds finished days into page_view_daily and
+-- deletes their rows, so page_views only holds recent views.
+CREATE TABLE IF NOT EXISTS page_views (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    path TEXT NOT NULL,
+    referrer TEXT NOT NULL DEFAULT '',
+    ua_class TEXT NOT NULL DEFAULT 'desktop' CHECK (ua_class IN ('desktop', 'mobile', 'tablet')),
+    country TEXT NOT NULL DEFAULT '',
+    viewed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_page_views_viewed_at ON page_views(viewed_at);
+
+-- Views per UTC day (YYYY-MM-DD) and combination of path, referrer, user
+-- agent class and country.
+CREATE TABLE IF NOT EXISTS page_view_daily (
+    day TEXT NOT NULL,
+    path TEXT NOT NULL,
+    referrer TEXT NOT NULL DEFAULT '',
+    ua_class TEXT NOT NULL DEFAULT 'desktop',
+    country TEXT NOT NULL DEFAULT '',
+    views INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, path, referrer, ua_class, country)
+);
+
+-- Rolled-up days and the views not rolled up yet, as one set of counts for
+-- the dashboard queries.
+CREATE VIEW IF NOT EXISTS page_view_counts AS
+SELECT day, path, referrer, ua_class, country, views FROM page_view_daily
+UNION ALL
+SELECT date(viewed_at), path, referrer, ua_class, country, 1 FROM page_views;
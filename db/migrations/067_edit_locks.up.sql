@@ -0,0 +1,12 @@
+-- Soft edit locks. An open edit form claims the lock on its item and renews
+-- it with a heartbeat every 30 seconds; a lock whose heartbeat is older than
+-- the expiry (services.EditLockTTL) is free again. Locks only warn a second
+-- admin that someone else is editing; they never block saving.
+CREATE TABLE IF NOT EXISTS edit_locks (
+    content_type TEXT NOT NULL,
+    content_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES admin_users(id) ON DELETE CASCADE,
+    acquired_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    heartbeat_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (content_type, content_id)
+);
@@ -0,0 +1,2 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The admin_ip_allow and admin_ip_deny columns will remain if downgrade is needed.
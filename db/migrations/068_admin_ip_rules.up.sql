@@ -0,0 +1,6 @@
+-- Network rules for the admin panel, edited under Admin Access. Each column
+-- holds IP addresses or CIDR ranges, one per line. While admin_ip_allow is
+-- not blank, only addresses it matches reach /admin; addresses matching
+-- admin_ip_deny never do.
+ALTER TABLE settings ADD COLUMN admin_ip_allow TEXT NOT NULL DEFAULT '';
+ALTER TABLE settings ADD COLUMN admin_ip_deny TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+-- Outbound webhooks, managed under Admin > Webhooks. Each endpoint receives a
+-- signed JSON POST when public content is published, updated or deleted, so
+-- a static-site build or CDN purge can follow the change. events holds the
+-- subscribed event types, comma separated; blank subscribes to all of them.
+-- The last_* columns describe the most recent delivery attempt.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    last_status INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    last_delivered_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
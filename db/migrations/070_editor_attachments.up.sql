@@ -0,0 +1,9 @@
+-- Images uploaded from the rich text editors (POST /admin/editor/attachments).
+-- They are stored in the media library like any upload; this table marks the
+-- ones the editor added, so they can be removed again once no content refers
+-- to them, e.g. after the post they were pasted into is deleted. Library
+-- files an upload turned out to duplicate are not marked.
+CREATE TABLE IF NOT EXISTS editor_attachments (
+    media_file_id INTEGER PRIMARY KEY REFERENCES media_files(id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+ALTER TABLE homepage_testimonials DROP COLUMN author_image_alt;
+ALTER TABLE homepage_hero DROP COLUMN background_image_alt;
@@ -0,0 +1,5 @@
+-- Alt text for the homepage images that had none: hero backgrounds and
+-- testimonial author photos. The admin forms require it whenever an image is
+-- set, and Admin > Accessibility lists images that still lack it.
+ALTER TABLE homepage_hero ADD COLUMN background_image_alt TEXT NOT NULL DEFAULT '';
+ALTER TABLE homepage_testimonials ADD COLUMN author_image_alt TEXT NOT NULL DEFAULT '';
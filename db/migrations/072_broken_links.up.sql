@@ -0,0 +1,17 @@
+-- Broken internal links found by the link-check job: links in rich text and
+-- call-to-action URLs that answer 404 (or another error) or point at
+-- unpublished content. Each run replaces the rows, so the table always holds
+-- the result of the last run.
+CREATE TABLE IF NOT EXISTS broken_links (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL,                    -- content type the link is in, e.g. 'blog_post', 'hero'
+    owner_id INTEGER NOT NULL,               -- item the link is in
+    parent_id INTEGER NOT NULL DEFAULT 0,    -- landing page of a landing page section, else 0
+    owner_title TEXT NOT NULL,
+    field TEXT NOT NULL,                     -- e.g. 'Body', 'Primary button'
+    url TEXT NOT NULL,                       -- the link as written
+    status INTEGER NOT NULL,                 -- HTTP status the link answered with
+    target_type TEXT NOT NULL DEFAULT '',    -- unpublished content the link points at, e.g. 'solution'
+    target_id INTEGER NOT NULL DEFAULT 0,
+    checked_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DELETE FROM product_images WHERE media_type != 'image';
+ALTER TABLE product_images DROP COLUMN media_url;
+ALTER TABLE product_images DROP COLUMN media_type;
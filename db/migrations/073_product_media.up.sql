@@ -0,0 +1,7 @@
+-- Product galleries hold videos and 3D/CAD files besides images.
+-- media_type is 'image' (image_path is the image), 'video' (media_url is a
+-- YouTube or Vimeo player URL or an uploaded MP4/WebM file) or 'model'
+-- (media_url is an uploaded 3D/CAD file). For videos and models image_path
+-- is an optional poster or preview image, '' when there is none.
+ALTER TABLE product_images ADD COLUMN media_type TEXT NOT NULL DEFAULT 'image' CHECK (media_type IN ('image', 'video', 'model'));
+ALTER TABLE product_images ADD COLUMN media_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- SQLite cannot drop a column that carries a foreign key constraint, so
+-- product_downloads.supersedes_id remains but is ignored.
+DROP TRIGGER IF EXISTS relink_product_download_versions;
+DROP INDEX IF EXISTS idx_product_downloads_supersedes;
+ALTER TABLE product_downloads DROP COLUMN changelog;
@@ -0,0 +1,16 @@
+-- Versions of product downloads. A revised datasheet is uploaded as a new
+-- download that supersedes the previous one; the public page shows the
+-- latest of each chain and lists the older versions below it. Each version
+-- keeps its own download_count.
+ALTER TABLE product_downloads ADD COLUMN changelog TEXT NOT NULL DEFAULT '';
+ALTER TABLE product_downloads ADD COLUMN supersedes_id INTEGER REFERENCES product_downloads(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_product_downloads_supersedes ON product_downloads(supersedes_id);
+
+-- Deleting a version keeps its chain together: the version that superseded
+-- it now supersedes the one it superseded.
+CREATE TRIGGER relink_product_download_versions
+BEFORE DELETE ON product_downloads
+BEGIN
+    UPDATE product_downloads SET supersedes_id = OLD.supersedes_id WHERE supersedes_id = OLD.id;
+END;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_download_leads;
+ALTER TABLE product_downloads DROP COLUMN is_gated;
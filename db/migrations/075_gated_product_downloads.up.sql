@@ -0,0 +1,20 @@
+-- Gated product downloads. A gated file (a detailed manual, say) is only
+-- handed out after the visitor fills in the lead form; each submission is
+-- recorded here like whitepaper_downloads and shows up in the Leads view.
+ALTER TABLE product_downloads ADD COLUMN is_gated INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS product_download_leads (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_download_id INTEGER NOT NULL REFERENCES product_downloads(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL,
+    company TEXT NOT NULL,
+    designation TEXT,
+    marketing_consent INTEGER NOT NULL DEFAULT 0,
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_product_download_leads_download ON product_download_leads(product_download_id);
+CREATE INDEX idx_product_download_leads_email ON product_download_leads(email);
+CREATE INDEX idx_product_download_leads_created ON product_download_leads(created_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS customer_library;
+DROP TABLE IF EXISTS customer_password_resets;
+DROP TABLE IF EXISTS customer_sessions;
+DROP TABLE IF EXISTS customer_accounts;
@@ -0,0 +1,48 @@
+-- Customer accounts for the public site. Returning visitors sign in instead
+-- of re-filling the lead form; whitepapers and gated product files they get
+-- while signed in are kept in their library on /account.
+CREATE TABLE IF NOT EXISTS customer_accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    name TEXT NOT NULL,
+    company TEXT NOT NULL,
+    designation TEXT NOT NULL DEFAULT '',
+    password_hash TEXT NOT NULL,
+    marketing_consent INTEGER NOT NULL DEFAULT 0,
+    last_login_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_customer_accounts_created ON customer_accounts(created_at);
+
+-- One row per signed-in browser, keyed by the random token in the
+-- bluejay_account cookie, like admin_sessions.
+CREATE TABLE IF NOT EXISTS customer_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token TEXT NOT NULL UNIQUE,
+    account_id INTEGER NOT NULL REFERENCES customer_accounts(id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_customer_sessions_account ON customer_sessions(account_id);
+
+-- Password reset links. Only the SHA-256 of the emailed token is stored.
+CREATE TABLE IF NOT EXISTS customer_password_resets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES customer_accounts(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_customer_password_resets_account ON customer_password_resets(account_id);
+
+-- A customer's library: one row per whitepaper or product file, whichever
+-- is set, refreshed each time it is downloaded again.
+CREATE TABLE IF NOT EXISTS customer_library (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER NOT NULL REFERENCES customer_accounts(id) ON DELETE CASCADE,
+    whitepaper_id INTEGER REFERENCES whitepapers(id) ON DELETE CASCADE,
+    product_download_id INTEGER REFERENCES product_downloads(id) ON DELETE CASCADE,
+    downloaded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_customer_library_whitepaper ON customer_library(account_id, whitepaper_id) WHERE whitepaper_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_customer_library_product_download ON customer_library(account_id, product_download_id) WHERE product_download_id IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Newsletter subscribers from the footer form. Addresses stay pending until
+-- the emailed confirmation link is followed (double opt-in); every row keeps
+-- a permanent unsubscribe token for the links in newsletter emails.
+CREATE TABLE IF NOT EXISTS newsletter_subscribers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'unsubscribed')),
+    -- SHA-256 of the outstanding confirmation token; empty once confirmed
+    confirm_token_hash TEXT NOT NULL DEFAULT '',
+    confirm_sent_at DATETIME,
+    unsubscribe_token TEXT NOT NULL UNIQUE,
+    -- Page the form was submitted from
+    source TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    confirmed_at DATETIME,
+    unsubscribed_at DATETIME,
+    -- Last hand-off to the mailing list provider (NEWSLETTER_PROVIDER)
+    provider_synced_at DATETIME,
+    provider_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_newsletter_subscribers_status ON newsletter_subscribers(status, created_at);
+CREATE INDEX idx_newsletter_subscribers_confirm ON newsletter_subscribers(confirm_token_hash) WHERE confirm_token_hash != '';
@@ -0,0 +1,41 @@
+-- Events and webinars with their registrations. Times are stored in UTC and
+-- shown in the site timezone; an event is upcoming until it ends.
+CREATE TABLE IF NOT EXISTS events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    event_type TEXT NOT NULL DEFAULT 'in_person' CHECK (event_type IN ('in_person', 'webinar')),
+    summary TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    -- Venue of in-person events
+    location TEXT NOT NULL DEFAULT '',
+    -- Join link of webinars, only shown to registrants
+    webinar_url TEXT NOT NULL DEFAULT '',
+    registration_open INTEGER NOT NULL DEFAULT 1,
+    -- Maximum registrations; 0 means no limit
+    capacity INTEGER NOT NULL DEFAULT 0,
+    is_published INTEGER NOT NULL DEFAULT 0,
+    meta_description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_events_published_ends ON events(is_published, ends_at);
+
+-- Registrations from the event page form, one per address and event. They
+-- are leads like whitepaper downloads.
+CREATE TABLE IF NOT EXISTS event_registrations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL COLLATE NOCASE,
+    company TEXT NOT NULL,
+    designation TEXT NOT NULL DEFAULT '',
+    marketing_consent INTEGER NOT NULL DEFAULT 0,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id, email)
+);
+CREATE INDEX idx_event_registrations_created ON event_registrations(created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_applications;
+DROP TABLE IF EXISTS job_postings;
@@ -0,0 +1,48 @@
+-- Job postings on /careers and the applications sent with their apply
+-- form. CVs are kept in APPLICATION_DIR, outside the public uploads, and
+-- only the admin can download them.
+CREATE TABLE IF NOT EXISTS job_postings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    department TEXT NOT NULL DEFAULT '',
+    -- City or region of the workplace
+    location TEXT NOT NULL DEFAULT '',
+    -- ISO 3166-1 alpha-2 country code of the workplace, for Google Jobs
+    country TEXT NOT NULL DEFAULT '',
+    employment_type TEXT NOT NULL DEFAULT 'full_time' CHECK (employment_type IN ('full_time', 'part_time', 'contract', 'temporary', 'internship')),
+    remote INTEGER NOT NULL DEFAULT 0,
+    summary TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    -- Closing date (UTC); applications are refused afterwards. NULL keeps
+    -- the posting open.
+    valid_through DATETIME,
+    is_published INTEGER NOT NULL DEFAULT 0,
+    -- First publication, the datePosted of the structured data
+    posted_at DATETIME,
+    meta_description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_job_postings_published ON job_postings(is_published, valid_through);
+
+CREATE TABLE IF NOT EXISTS job_applications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL COLLATE NOCASE,
+    phone TEXT NOT NULL DEFAULT '',
+    cover_letter TEXT NOT NULL DEFAULT '',
+    -- Storage key of the CV in APPLICATION_DIR
+    cv_key TEXT NOT NULL,
+    -- File name as uploaded, for the download
+    cv_filename TEXT NOT NULL,
+    cv_size INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'reviewing', 'interview', 'offer', 'hired', 'rejected')),
+    notes TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_job_applications_status ON job_applications(status, created_at);
+CREATE INDEX idx_job_applications_job ON job_applications(job_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS press_kit_assets;
+DROP TABLE IF EXISTS press_mentions;
+DROP TABLE IF EXISTS press_releases;
@@ -0,0 +1,45 @@
+-- Press newsroom on /press: press releases, the media kit and "In the news"
+-- links to coverage elsewhere. Kept apart from the blog so PR content does
+-- not show up in blog categories, tags or feeds.
+CREATE TABLE IF NOT EXISTS press_releases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    summary TEXT NOT NULL DEFAULT '',
+    -- City of the dateline, e.g. "San Jose, CA"
+    location TEXT NOT NULL DEFAULT '',
+    -- Markdown source of the release
+    body TEXT NOT NULL DEFAULT '',
+    -- Release time in UTC; a published release stays embargoed until then
+    released_at DATETIME NOT NULL,
+    is_published INTEGER NOT NULL DEFAULT 0,
+    meta_description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_press_releases_published ON press_releases(is_published, released_at);
+
+-- Coverage of the company by other outlets, linked from /press.
+CREATE TABLE IF NOT EXISTS press_mentions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    outlet TEXT NOT NULL,
+    headline TEXT NOT NULL,
+    url TEXT NOT NULL,
+    published_on DATETIME NOT NULL,
+    is_published INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_press_mentions_published ON press_mentions(is_published, published_on);
+
+-- Logos, photos and fact sheets journalists can download from /press.
+-- Files are stored under /uploads/press.
+CREATE TABLE IF NOT EXISTS press_kit_assets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    file_path TEXT NOT NULL,
+    file_size INTEGER NOT NULL DEFAULT 0,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS doc_pages_au;
+DROP TRIGGER IF EXISTS doc_pages_ad;
+DROP TRIGGER IF EXISTS doc_pages_ai;
+DROP TABLE IF EXISTS doc_pages_fts;
+DROP TABLE IF EXISTS doc_pages;
+DROP TABLE IF EXISTS doc_versions;
+DROP TABLE IF EXISTS doc_sets;
@@ -0,0 +1,67 @@
+-- Product documentation under /docs. A doc set is one manual, optionally
+-- for a product; each of its versions holds its own tree of Markdown pages,
+-- so the manual of an older release stays online when the next one is
+-- written.
+CREATE TABLE IF NOT EXISTS doc_sets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    product_id INTEGER REFERENCES products(id) ON DELETE SET NULL,
+    description TEXT NOT NULL DEFAULT '',
+    -- Version /docs/<slug> opens; NULL until the first version is added
+    current_version_id INTEGER,
+    is_published INTEGER NOT NULL DEFAULT 0,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_doc_sets_product ON doc_sets(product_id);
+
+CREATE TABLE IF NOT EXISTS doc_versions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    doc_set_id INTEGER NOT NULL REFERENCES doc_sets(id) ON DELETE CASCADE,
+    -- Label and URL segment, e.g. "2.1"
+    version TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (doc_set_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS doc_pages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    doc_version_id INTEGER NOT NULL REFERENCES doc_versions(id) ON DELETE CASCADE,
+    -- NULL for top-level pages. Depth is limited in the application, which
+    -- also refuses to delete pages that have subpages.
+    parent_id INTEGER REFERENCES doc_pages(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    -- Unique within the version: /docs/<set>/<version>/<slug>
+    slug TEXT NOT NULL,
+    body_markdown TEXT NOT NULL DEFAULT '',
+    -- body_markdown rendered on save
+    body_html TEXT NOT NULL DEFAULT '',
+    meta_description TEXT NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_published INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (doc_version_id, slug)
+);
+CREATE INDEX idx_doc_pages_parent ON doc_pages(parent_id);
+
+-- Site search over page titles and Markdown source
+CREATE VIRTUAL TABLE IF NOT EXISTS doc_pages_fts USING fts5(
+    title, body_markdown,
+    content='doc_pages', content_rowid='id'
+);
+
+CREATE TRIGGER doc_pages_ai AFTER INSERT ON doc_pages BEGIN
+    INSERT INTO doc_pages_fts(rowid, title, body_markdown) VALUES (new.id, new.title, new.body_markdown);
+END;
+
+CREATE TRIGGER doc_pages_ad AFTER DELETE ON doc_pages BEGIN
+    INSERT INTO doc_pages_fts(doc_pages_fts, rowid, title, body_markdown) VALUES('delete', old.id, old.title, old.body_markdown);
+END;
+
+CREATE TRIGGER doc_pages_au AFTER UPDATE ON doc_pages BEGIN
+    INSERT INTO doc_pages_fts(doc_pages_fts, rowid, title, body_markdown) VALUES('delete', old.id, old.title, old.body_markdown);
+    INSERT INTO doc_pages_fts(rowid, title, body_markdown) VALUES (new.id, new.title, new.body_markdown);
+END;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS partner_submissions;
+DROP TABLE IF EXISTS partner_invites;
@@ -0,0 +1,40 @@
+-- Partner portal: partners invited by the admin open a tokenized form at
+-- /partner-portal/<token> and send their own logo, description, website
+-- and tier evidence. Submissions wait in an admin queue and only reach
+-- the partners table when approved.
+CREATE TABLE IF NOT EXISTS partner_invites (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    partner_id INTEGER NOT NULL REFERENCES partners(id) ON DELETE CASCADE,
+    -- Address the link was sent to, for the admin's reference
+    email TEXT NOT NULL DEFAULT '',
+    -- SHA-256 of the link token; the token itself is only shown once
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    last_used_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_partner_invites_partner ON partner_invites(partner_id);
+
+CREATE TABLE IF NOT EXISTS partner_submissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    partner_id INTEGER NOT NULL REFERENCES partners(id) ON DELETE CASCADE,
+    invite_id INTEGER REFERENCES partner_invites(id) ON DELETE SET NULL,
+    -- New logo in uploads/partners; empty keeps the current logo
+    logo_url TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    website_url TEXT NOT NULL DEFAULT '',
+    -- Tier the partner asks for; NULL keeps the current tier
+    requested_tier_id INTEGER REFERENCES partner_tiers(id) ON DELETE SET NULL,
+    -- Certifications, revenue or other proof for the requested tier
+    tier_evidence TEXT NOT NULL DEFAULT '',
+    contact_name TEXT NOT NULL DEFAULT '',
+    -- superseded: replaced by a newer submission before review
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'superseded')),
+    review_notes TEXT NOT NULL DEFAULT '',
+    reviewed_at DATETIME,
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_partner_submissions_status ON partner_submissions(status, created_at);
+CREATE INDEX idx_partner_submissions_partner ON partner_submissions(partner_id);
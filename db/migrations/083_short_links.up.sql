@@ -0,0 +1,24 @@
+-- Short links: /go/<code> redirects managed in the admin so marketing can
+-- share campaign links without an external shortener. UTM parameters are
+-- added to the destination on every redirect and each visit is counted.
+CREATE TABLE IF NOT EXISTS short_links (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Lowercase letters, digits, "-" and "_"; the part after /go/
+    code TEXT NOT NULL UNIQUE,
+    -- Site path or http(s) URL the link redirects to
+    destination TEXT NOT NULL,
+    utm_source TEXT NOT NULL DEFAULT '',
+    utm_medium TEXT NOT NULL DEFAULT '',
+    utm_campaign TEXT NOT NULL DEFAULT '',
+    utm_term TEXT NOT NULL DEFAULT '',
+    utm_content TEXT NOT NULL DEFAULT '',
+    -- Where the link is used, for the admin's reference
+    note TEXT NOT NULL DEFAULT '',
+    -- Visits, not counting crawlers
+    clicks INTEGER NOT NULL DEFAULT 0,
+    last_click_at DATETIME,
+    -- After this time the link answers 410 Gone; NULL never expires
+    expires_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- SQLite does not support DROP COLUMN in older versions.
+-- The format_locale and currency columns will remain if downgrade is needed.
@@ -0,0 +1,6 @@
+-- Formatting locale (a BCP 47 tag such as 'de-DE') and default currency
+-- (an ISO 4217 code such as 'EUR'), configured under Global Settings.
+-- Templates format numbers and prices with them. Unlike the translation
+-- locales, they do not change with the visitor's language.
+ALTER TABLE settings ADD COLUMN format_locale TEXT NOT NULL DEFAULT 'en-US';
+ALTER TABLE settings ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
@@ -0,0 +1,4 @@
+-- Host names the built-in HTTPS server obtains Let's Encrypt certificates
+-- for, one per line, configured on the HTTPS Domains admin page. When blank,
+-- the TLS_DOMAINS environment variable is used.
+ALTER TABLE settings ADD COLUMN tls_domains TEXT NOT NULL DEFAULT '';
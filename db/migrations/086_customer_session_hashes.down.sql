@@ -0,0 +1,3 @@
+-- Hashed sessions cannot be turned back into tokens; customers sign in again.
+DELETE FROM customer_sessions;
+ALTER TABLE customer_sessions RENAME COLUMN token_hash TO token;
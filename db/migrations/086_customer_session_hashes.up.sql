@@ -0,0 +1,6 @@
+-- Customer sessions are keyed by the SHA-256 of the bluejay_account cookie
+-- token, like password resets, so the rows cannot be used to sign in.
+-- Sessions stored before held the plain token; they are ended, and their
+-- customers sign in again.
+DELETE FROM customer_sessions;
+ALTER TABLE customer_sessions RENAME COLUMN token TO token_hash;
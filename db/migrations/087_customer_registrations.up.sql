@@ -0,0 +1,18 @@
+-- Sign-ups waiting for their emailed confirmation link. The account is
+-- created only when the link is followed, and the sign-up form answers the
+-- same for new and registered emails, so it does not reveal who has an
+-- account. Only the SHA-256 of the emailed token is stored.
+CREATE TABLE IF NOT EXISTS customer_registrations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token_hash TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL COLLATE NOCASE,
+    name TEXT NOT NULL,
+    company TEXT NOT NULL,
+    designation TEXT NOT NULL DEFAULT '',
+    password_hash TEXT NOT NULL,
+    marketing_consent INTEGER NOT NULL DEFAULT 0,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_customer_registrations_email ON customer_registrations(email);
+CREATE INDEX idx_customer_registrations_expires ON customer_registrations(expires_at);
@@ -0,0 +1,12 @@
+// Package migrations embeds the versioned schema migrations
+// (NNN_name.up.sql / NNN_name.down.sql) into the binaries, so the server
+// and cmd/migrate apply the migrations they were built with without the
+// db/migrations directory being deployed.
+package migrations
+
+import "embed" // Compiles the .sql files into the binary
+
+// FS holds every migration file at its root, for database.NewMigrator.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,64 @@
+-- ====================================================================
+-- ACCESSIBILITY AUDIT QUERIES
+-- ====================================================================
+-- Read-only queries behind Admin > Accessibility (services.Accessibility).
+-- Trashed products, posts, solutions and case studies are left out.
+--
+-- Sources:
+-- - product_images, homepage_hero, homepage_testimonials, media_files:
+--   images and their alt text
+-- - blog_posts, products, solutions, case_studies, content_blocks,
+--   landing_page_sections: rich text (Trix) HTML
+-- ====================================================================
+
+-- name: ListImagesMissingAlt :many
+-- Lists images without alt text: product gallery images, hero backgrounds,
+-- testimonial photos and library images. owner_* is the item the image
+-- belongs to (the media file itself for library images).
+SELECT CAST('product_image' AS TEXT) AS source, p.id AS owner_id, p.name AS owner_title, i.image_path AS image_path
+FROM product_images i
+JOIN products p ON p.id = i.product_id
+WHERE p.deleted_at IS NULL AND TRIM(COALESCE(i.alt_text, '')) = ''
+UNION ALL
+SELECT 'hero', h.id, h.headline, h.background_image
+FROM homepage_hero h
+WHERE COALESCE(h.background_image, '') != '' AND TRIM(h.background_image_alt) = ''
+UNION ALL
+SELECT 'testimonial', t.id, t.author_name, t.author_image
+FROM homepage_testimonials t
+WHERE COALESCE(t.author_image, '') != '' AND TRIM(t.author_image_alt) = ''
+UNION ALL
+SELECT 'media', m.id, m.original_filename, m.file_path
+FROM media_files m
+WHERE m.mime_type LIKE 'image/%' AND TRIM(COALESCE(m.alt_text, '')) = ''
+ORDER BY source, owner_title, owner_id;
+
+-- name: ListRichContent :many
+-- Lists every non-empty rich text field. parent_id is the landing page of a
+-- landing page section and 0 otherwise.
+SELECT CAST('blog_post' AS TEXT) AS source, id AS owner_id, CAST(0 AS INTEGER) AS parent_id, title AS owner_title, CAST('Body' AS TEXT) AS field, body AS html
+FROM blog_posts WHERE deleted_at IS NULL AND body != ''
+UNION ALL
+SELECT 'product', id, 0, name, 'Overview', overview
+FROM products WHERE deleted_at IS NULL AND COALESCE(overview, '') != ''
+UNION ALL
+SELECT 'solution', id, 0, title, 'Overview', overview_content
+FROM solutions WHERE deleted_at IS NULL AND COALESCE(overview_content, '') != ''
+UNION ALL
+SELECT 'case_study', id, 0, title, challenge_title, challenge_content
+FROM case_studies WHERE deleted_at IS NULL AND challenge_content != ''
+UNION ALL
+SELECT 'case_study', id, 0, title, solution_title, solution_content
+FROM case_studies WHERE deleted_at IS NULL AND solution_content != ''
+UNION ALL
+SELECT 'case_study', id, 0, title, outcome_title, outcome_content
+FROM case_studies WHERE deleted_at IS NULL AND outcome_content != ''
+UNION ALL
+SELECT 'content_block', id, 0, name, 'Body', body
+FROM content_blocks WHERE block_type = 'rich_text' AND body != ''
+UNION ALL
+SELECT 'landing_section', s.id, p.id, p.title, 'Text section', s.body
+FROM landing_page_sections s
+JOIN landing_pages p ON p.id = s.landing_page_id
+WHERE s.section_type = 'text' AND s.body != ''
+ORDER BY source, owner_title, owner_id;
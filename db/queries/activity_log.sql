@@ -25,38 +25,70 @@ INSERT INTO activity_log (user_id, action, resource_type, resource_id, resource_
 VALUES (?, ?, ?, ?, ?, ?);
 
 -- name: ListActivityLogs :many
--- sqlc annotation: :many returns slice of activity_log rows
--- Purpose: Retrieves paginated, filtered activity log entries for admin dashboard
+-- sqlc annotation: :many returns slice of activity rows with the acting user
+-- Purpose: Retrieves paginated, filtered activity log entries for the audit
+--          log viewer and its CSV export
 -- Parameters (named parameters using @ prefix):
+--   @filter_user (INTEGER): admin user ID (0 = all users)
+--   @filter_resource_type (TEXT): entity type, e.g. "product" (empty = all)
 --   @filter_action (TEXT): filter by action type (empty string = no filter)
---   @filter_search (TEXT): search term for description field (empty = no filter)
+--   @filter_search (TEXT): search term for description/title (empty = no filter)
+--   @filter_from (TEXT): first day to include, YYYY-MM-DD (empty = no lower bound)
+--   @filter_to (TEXT): last day to include, YYYY-MM-DD (empty = no upper bound)
 --   @page_limit (INTEGER): number of results per page
 --   @page_offset (INTEGER): pagination offset (page_number * page_limit)
--- Return type: slice of activity_log rows
+-- Return type: slice of activity_log rows plus user display name and email
 -- Complex WHERE logic:
---   - CAST to TEXT handles nullable/empty filter parameters safely
---   - Empty string check allows "no filter" without complex null handling
+--   - CAST to TEXT/INTEGER handles empty filter parameters safely
+--   - Empty string / 0 check allows "no filter" without complex null handling
 --   - LIKE with wildcards enables partial text search in descriptions
+--   - created_at is CURRENT_TIMESTAMP text (UTC), so day bounds compare as
+--     strings; the upper bound is exclusive at midnight after @filter_to
+-- LEFT JOIN admin_users: entries of deleted users are still listed
 -- Note: Always ordered by created_at DESC to show newest actions first
-SELECT * FROM activity_log
+SELECT
+    a.id, a.user_id, a.action, a.resource_type, a.resource_id, a.resource_title,
+    a.description, a.created_at,
+    u.display_name AS user_name, u.email AS user_email
+FROM activity_log a
+LEFT JOIN admin_users u ON u.id = a.user_id
 WHERE
+    -- Filter by acting user if provided
+    (CAST(@filter_user AS INTEGER) = 0 OR a.user_id = @filter_user)
+    -- Filter by entity type if provided
+    AND (CAST(@filter_resource_type AS TEXT) = '' OR a.resource_type = @filter_resource_type)
     -- Filter by action type if provided, otherwise include all actions
-    (CAST(@filter_action AS TEXT) = '' OR action = @filter_action)
-    -- Filter by search term in description if provided
-    AND (CAST(@filter_search AS TEXT) = '' OR description LIKE '%' || @filter_search || '%')
-ORDER BY created_at DESC
+    AND (CAST(@filter_action AS TEXT) = '' OR a.action = @filter_action)
+    -- Filter by search term in description or resource title if provided
+    AND (CAST(@filter_search AS TEXT) = '' OR a.description LIKE '%' || @filter_search || '%' OR a.resource_title LIKE '%' || @filter_search || '%')
+    -- Date range (inclusive days)
+    AND (CAST(@filter_from AS TEXT) = '' OR a.created_at >= @filter_from)
+    AND (CAST(@filter_to AS TEXT) = '' OR a.created_at < date(@filter_to, '+1 day'))
+ORDER BY a.created_at DESC, a.id DESC
 LIMIT @page_limit OFFSET @page_offset;
 
 -- name: CountActivityLogs :one
 -- sqlc annotation: :one returns single integer count
 -- Purpose: Counts total activity logs matching filters (for pagination UI)
--- Parameters (named):
---   @filter_action (TEXT): same filter as ListActivityLogs
---   @filter_search (TEXT): same search filter as ListActivityLogs
+-- Parameters (named): same filters as ListActivityLogs
 -- Return type: single integer COUNT(*) value
 -- Note: WHERE clause MUST match ListActivityLogs exactly for accurate pagination
-SELECT COUNT(*) FROM activity_log
+SELECT COUNT(*) FROM activity_log a
 WHERE
     -- Same filter logic as ListActivityLogs for consistency
-    (CAST(@filter_action AS TEXT) = '' OR action = @filter_action)
-    AND (CAST(@filter_search AS TEXT) = '' OR description LIKE '%' || @filter_search || '%');
+    (CAST(@filter_user AS INTEGER) = 0 OR a.user_id = @filter_user)
+    AND (CAST(@filter_resource_type AS TEXT) = '' OR a.resource_type = @filter_resource_type)
+    AND (CAST(@filter_action AS TEXT) = '' OR a.action = @filter_action)
+    AND (CAST(@filter_search AS TEXT) = '' OR a.description LIKE '%' || @filter_search || '%' OR a.resource_title LIKE '%' || @filter_search || '%')
+    AND (CAST(@filter_from AS TEXT) = '' OR a.created_at >= @filter_from)
+    AND (CAST(@filter_to AS TEXT) = '' OR a.created_at < date(@filter_to, '+1 day'));
+
+-- name: ListActivityActions :many
+-- sqlc annotation: :many returns distinct action names
+-- Purpose: Populates the action filter with every action that has been logged
+SELECT DISTINCT action FROM activity_log ORDER BY action;
+
+-- name: ListActivityResourceTypes :many
+-- sqlc annotation: :many returns distinct entity types
+-- Purpose: Populates the entity type filter with every type that has been logged
+SELECT DISTINCT resource_type FROM activity_log ORDER BY resource_type;
@@ -0,0 +1,45 @@
+-- ====================================================================
+-- ADMIN SEARCH QUERIES
+-- ====================================================================
+-- The admin command palette (Ctrl+K) looks up content across every
+-- editable type at once and links each match to its edit page. Settings
+-- screens are matched in Go (handlers/admin/search.go), not here.
+--
+-- Searched entities:
+-- - products (name, SKU, slug)
+-- - blog_posts, solutions, case_studies, whitepapers, landing_pages
+--   (title, slug)
+-- ====================================================================
+
+-- name: SearchAdminContent :many
+-- Finds content whose title, SKU or slug contains the query, titles that
+-- start with it first. Trashed items are left out. Each match has its
+-- content type, row ID, title, detail (SKU or slug) and status (published,
+-- draft or archived).
+-- Parameters (named):
+--   1. query (TEXT): search term, matched case-insensitively
+--   2. result_limit (INTEGER): maximum number of matches
+SELECT content_type, id, title, detail, status FROM (
+    SELECT 'product' AS content_type, id, name AS title, sku AS detail, status, slug
+    FROM products WHERE deleted_at IS NULL
+    UNION ALL
+    SELECT 'blog_post', id, title, slug, status, slug
+    FROM blog_posts WHERE deleted_at IS NULL
+    UNION ALL
+    SELECT 'solution', id, title, slug, CASE WHEN is_published THEN 'published' ELSE 'draft' END, slug
+    FROM solutions WHERE deleted_at IS NULL
+    UNION ALL
+    SELECT 'case_study', id, title, slug, CASE WHEN is_published THEN 'published' ELSE 'draft' END, slug
+    FROM case_studies WHERE deleted_at IS NULL
+    UNION ALL
+    SELECT 'whitepaper', id, title, slug, CASE WHEN is_published THEN 'published' ELSE 'draft' END, slug
+    FROM whitepapers
+    UNION ALL
+    SELECT 'landing_page', id, title, slug, status, slug
+    FROM landing_pages
+) content
+WHERE title LIKE '%' || CAST(@query AS TEXT) || '%'
+    OR detail LIKE '%' || CAST(@query AS TEXT) || '%'
+    OR slug LIKE '%' || CAST(@query AS TEXT) || '%'
+ORDER BY CASE WHEN title LIKE CAST(@query AS TEXT) || '%' THEN 0 ELSE 1 END, title COLLATE NOCASE, id
+LIMIT @result_limit;
@@ -0,0 +1,66 @@
+-- ====================================================================
+-- ADMIN SESSIONS QUERIES
+-- ====================================================================
+-- Server-side tracking of admin login sessions for the "Active Sessions"
+-- page, remote revocation, and "log out everywhere".
+--
+-- Managed entity:
+-- - admin_sessions: One row per signed-in browser, keyed by the random
+--   token stored in the session cookie
+--
+-- Security notes:
+-- - Deleting a row revokes the session; middleware.SessionTracker treats
+--   cookies without a matching row as signed out
+-- - Revocation queries are scoped by user_id so users can only end their
+--   own sessions
+-- ====================================================================
+
+-- name: CreateAdminSession :one
+-- Records a new session at login.
+-- Parameters:
+--   1. token (TEXT): random token stored in the session cookie
+--   2. user_id (INTEGER): signed-in admin user
+--   3. ip_address (TEXT): client IP at login
+--   4. user_agent (TEXT): browser User-Agent at login
+INSERT INTO admin_sessions (token, user_id, ip_address, user_agent)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetAdminSessionByToken :one
+-- Looks up the session for a cookie token. No row means the session was
+-- revoked or never existed.
+SELECT * FROM admin_sessions WHERE token = ? LIMIT 1;
+
+-- name: TouchAdminSession :exec
+-- Refreshes last_seen_at and the latest client IP for a session.
+-- Parameters:
+--   1. ip_address (TEXT): client IP of the current request
+--   2. id (INTEGER): session ID
+UPDATE admin_sessions SET last_seen_at = CURRENT_TIMESTAMP, ip_address = ? WHERE id = ?;
+
+-- name: ListAdminSessionsByUser :many
+-- Lists a user's sessions, most recently active first.
+SELECT * FROM admin_sessions WHERE user_id = ? ORDER BY last_seen_at DESC, id DESC;
+
+-- name: DeleteAdminSession :exec
+-- Revokes one of a user's sessions. Scoped by user_id so users cannot end
+-- other people's sessions.
+-- Parameters:
+--   1. id (INTEGER): session ID
+--   2. user_id (INTEGER): owner of the session
+DELETE FROM admin_sessions WHERE id = ? AND user_id = ?;
+
+-- name: DeleteAdminSessionByToken :exec
+-- Removes the session of the current cookie at logout.
+DELETE FROM admin_sessions WHERE token = ?;
+
+-- name: DeleteAdminSessionsByUser :exec
+-- Revokes every session of a user ("log out everywhere").
+DELETE FROM admin_sessions WHERE user_id = ?;
+
+-- name: DeleteStaleAdminSessions :exec
+-- Removes sessions idle since before the cutoff; their cookies have expired.
+-- Parameters:
+--   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp, typically now minus
+--   the cookie MaxAge
+DELETE FROM admin_sessions WHERE last_seen_at < CAST(@cutoff AS TEXT);
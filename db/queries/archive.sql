@@ -0,0 +1,124 @@
+-- ====================================================================
+-- COMPLIANCE ARCHIVE QUERIES
+-- ====================================================================
+-- This file supports the monthly archival job that exports audit and lead
+-- tables to compressed, hash-chained files and then prunes the live tables
+-- according to the retention policy.
+--
+-- Managed entities:
+-- - archive_runs: append-only ledger of exported months (one row per table/month)
+--
+-- Archived tables:
+-- - activity_log, contact_submissions, whitepaper_downloads
+--
+-- Key concepts:
+-- - period: calendar month in 'YYYY-MM' format
+-- - chain_hash: sha256(prev_chain_hash || content_hash), linking every archive
+--   of a table to all archives that came before it
+-- - Date bounds are passed as 'YYYY-MM-DD HH:MM:SS' text so they compare
+--   correctly against CURRENT_TIMESTAMP values stored by SQLite
+-- ====================================================================
+
+-- ====================================================================
+-- ARCHIVE LEDGER
+-- ====================================================================
+
+-- name: CreateArchiveRun :one
+-- sqlc annotation: :one returns the recorded ledger row
+-- Purpose: Records a completed monthly export in the append-only ledger
+-- Note: UNIQUE(source_table, period) prevents a month being archived twice
+INSERT INTO archive_runs (
+    source_table, period, storage_key, row_count, content_hash, prev_chain_hash, chain_hash
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetLatestArchiveRun :one
+-- sqlc annotation: :one returns the most recent archive of a table
+-- Purpose: Finds where the next export should resume and which chain hash to extend
+-- Returns sql.ErrNoRows when the table has never been archived
+SELECT * FROM archive_runs
+WHERE source_table = ?
+ORDER BY period DESC
+LIMIT 1;
+
+-- name: ListArchiveRunsByTable :many
+-- sqlc annotation: :many returns a table's archives in chain order
+-- Purpose: Walks the hash chain from the first archive to the latest for verification
+SELECT * FROM archive_runs
+WHERE source_table = ?
+ORDER BY period ASC;
+
+-- ====================================================================
+-- ACTIVITY LOG
+-- ====================================================================
+
+-- name: GetOldestActivityLogTime :one
+-- sqlc annotation: :one returns the earliest created_at in activity_log
+-- Purpose: Determines the first month that needs archiving
+SELECT created_at FROM activity_log
+WHERE created_at IS NOT NULL
+ORDER BY created_at ASC
+LIMIT 1;
+
+-- name: ListActivityLogsForArchive :many
+-- sqlc annotation: :many returns every activity_log row in a period
+-- Parameters:
+--   @period_start (TEXT): inclusive lower bound
+--   @period_end (TEXT): exclusive upper bound
+SELECT * FROM activity_log
+WHERE created_at >= CAST(@period_start AS TEXT) AND created_at < CAST(@period_end AS TEXT)
+ORDER BY id ASC;
+
+-- name: DeleteActivityLogsBefore :execrows
+-- sqlc annotation: :execrows returns the number of pruned rows
+-- Purpose: Applies the retention policy once rows have been archived
+DELETE FROM activity_log
+WHERE created_at < CAST(@cutoff AS TEXT);
+
+-- ====================================================================
+-- CONTACT SUBMISSIONS
+-- ====================================================================
+
+-- name: GetOldestContactSubmissionTime :one
+-- sqlc annotation: :one returns the earliest created_at in contact_submissions
+SELECT created_at FROM contact_submissions
+ORDER BY created_at ASC
+LIMIT 1;
+
+-- name: ListContactSubmissionsForArchive :many
+-- sqlc annotation: :many returns every contact submission in a period
+-- Parameters:
+--   @period_start (TEXT): inclusive lower bound
+--   @period_end (TEXT): exclusive upper bound
+SELECT * FROM contact_submissions
+WHERE created_at >= CAST(@period_start AS TEXT) AND created_at < CAST(@period_end AS TEXT)
+ORDER BY id ASC;
+
+-- name: DeleteContactSubmissionsBefore :execrows
+-- sqlc annotation: :execrows returns the number of pruned rows
+DELETE FROM contact_submissions
+WHERE created_at < CAST(@cutoff AS TEXT);
+
+-- ====================================================================
+-- WHITEPAPER DOWNLOADS
+-- ====================================================================
+
+-- name: GetOldestWhitepaperDownloadTime :one
+-- sqlc annotation: :one returns the earliest created_at in whitepaper_downloads
+SELECT created_at FROM whitepaper_downloads
+ORDER BY created_at ASC
+LIMIT 1;
+
+-- name: ListWhitepaperDownloadsForArchive :many
+-- sqlc annotation: :many returns every whitepaper download lead in a period
+-- Parameters:
+--   @period_start (TEXT): inclusive lower bound
+--   @period_end (TEXT): exclusive upper bound
+SELECT * FROM whitepaper_downloads
+WHERE created_at >= CAST(@period_start AS TEXT) AND created_at < CAST(@period_end AS TEXT)
+ORDER BY id ASC;
+
+-- name: DeleteWhitepaperDownloadsBefore :execrows
+-- sqlc annotation: :execrows returns the number of pruned rows
+DELETE FROM whitepaper_downloads
+WHERE created_at < CAST(@cutoff AS TEXT);
@@ -34,7 +34,8 @@
 --   Note: INNER JOINs ensure posts without valid category/author are excluded
 -- WHERE clause:
 --   - status = 'published': only show published posts, hide drafts
---   - published_at IS NOT NULL: additional safety check for scheduled posts
+--   - published_at < datetime('now', '+1 second'): posts dated in the future stay hidden until then
+--     (compared by the second, so a post published just now is listed at once)
 -- ORDER BY published_at DESC: newest posts first (reverse chronological)
 SELECT
     bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
@@ -44,7 +45,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 ORDER BY bp.published_at DESC
 LIMIT ? OFFSET ?;
 
@@ -55,7 +56,7 @@ LIMIT ? OFFSET ?;
 -- Return type: integer count
 -- Note: WHERE clause must match ListPublishedPosts for accurate pagination
 SELECT COUNT(*) FROM blog_posts
-WHERE status = 'published' AND published_at IS NOT NULL;
+WHERE status = 'published' AND deleted_at IS NULL AND published_at < datetime('now', '+1 second');
 
 -- name: ListPublishedPostsByCategory :many
 -- sqlc annotation: :many returns slice of blog post rows
@@ -66,7 +67,7 @@ WHERE status = 'published' AND published_at IS NOT NULL;
 --   3. OFFSET (INTEGER): pagination offset
 -- Return type: slice of denormalized blog post rows
 -- WHERE clause:
---   - bp.status = 'published' AND bp.published_at IS NOT NULL: same as main list
+--   - bp.status = 'published' AND bp.published_at < datetime('now', '+1 second'): same as main list
 --   - bc.slug = ?: filters by category slug (JOIN to blog_categories required)
 -- Note: INNER JOIN ensures only valid category slugs return results
 SELECT
@@ -77,8 +78,8 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published'
-    AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
     AND bc.slug = ?
 ORDER BY bp.published_at DESC
 LIMIT ? OFFSET ?;
@@ -92,10 +93,90 @@ LIMIT ? OFFSET ?;
 -- Note: JOIN required to filter by category slug; WHERE must match ListPublishedPostsByCategory
 SELECT COUNT(*) FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
-WHERE bp.status = 'published'
-    AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
     AND bc.slug = ?;
 
+-- name: ListBlogArchiveDates :many
+-- sqlc annotation: :many returns one publish date per published post
+-- Purpose: Builds the month/year archive widget and archive sitemap entries
+-- Parameters: none
+-- Return type: slice of published_at values
+-- Notes:
+--   - published_at is stored in UTC; the handler buckets the dates into
+--     months of the site timezone, which SQLite cannot convert to
+-- ORDER BY published_at DESC: newest first
+SELECT published_at FROM blog_posts
+WHERE status = 'published' AND deleted_at IS NULL AND published_at < datetime('now', '+1 second')
+ORDER BY published_at DESC;
+
+-- name: ListPublishedPostsByMonth :many
+-- sqlc annotation: :many returns slice of blog post rows
+-- Purpose: Lists published posts for one month archive page (/blog/2024/05)
+-- Parameters (named):
+--   - month_start (TEXT): UTC "2006-01-02 15:04:05" start of the month in the
+--     site timezone
+--   - month_end (TEXT): UTC "2006-01-02 15:04:05" start of the next month
+--   - limit / offset (INTEGER): pagination
+-- Return type: slice of denormalized blog post rows (same columns as ListPublishedPosts)
+SELECT
+    bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
+    bp.category_id, bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
+    bp.author_id, ba.name AS author_name, ba.avatar_url AS author_avatar,
+    bp.reading_time_minutes, bp.published_at, bp.created_at
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+INNER JOIN blog_authors ba ON bp.author_id = ba.id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bp.published_at >= CAST(@month_start AS TEXT) AND bp.published_at < CAST(@month_end AS TEXT)
+ORDER BY bp.published_at DESC
+LIMIT @limit OFFSET @offset;
+
+-- name: CountPublishedPostsByMonth :one
+-- sqlc annotation: :one returns integer count
+-- Purpose: Counts posts in one month archive for pagination
+-- Note: WHERE must match ListPublishedPostsByMonth
+SELECT COUNT(*) FROM blog_posts
+WHERE status = 'published' AND deleted_at IS NULL
+    AND published_at < datetime('now', '+1 second')
+    AND published_at >= CAST(@month_start AS TEXT) AND published_at < CAST(@month_end AS TEXT);
+
+-- name: ListPublishedPostsByTag :many
+-- sqlc annotation: :many returns slice of blog post rows
+-- Purpose: Lists published posts with one tag (tag page, /blog/tags/:slug)
+-- Parameters (positional):
+--   1. tag slug (TEXT): URL-safe tag identifier
+--   2. LIMIT (INTEGER): posts per page
+--   3. OFFSET (INTEGER): pagination offset
+-- Return type: slice of denormalized blog post rows (same columns as ListPublishedPosts)
+SELECT
+    bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
+    bp.category_id, bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
+    bp.author_id, ba.name AS author_name, ba.avatar_url AS author_avatar,
+    bp.reading_time_minutes, bp.published_at, bp.created_at
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+INNER JOIN blog_authors ba ON bp.author_id = ba.id
+INNER JOIN blog_post_tags bpt ON bpt.blog_post_id = bp.id
+INNER JOIN blog_tags bt ON bt.id = bpt.blog_tag_id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bt.slug = ?
+ORDER BY bp.published_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountPublishedPostsByTag :one
+-- sqlc annotation: :one returns integer count
+-- Purpose: Counts posts with one tag for pagination
+-- Note: WHERE must match ListPublishedPostsByTag
+SELECT COUNT(*) FROM blog_posts bp
+INNER JOIN blog_post_tags bpt ON bpt.blog_post_id = bp.id
+INNER JOIN blog_tags bt ON bt.id = bpt.blog_tag_id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bt.slug = ?;
+
 -- name: GetPublishedPostBySlug :one
 -- sqlc annotation: :one returns single blog post row or error if not found
 -- Purpose: Retrieves full published blog post by slug for public post detail page
@@ -109,7 +190,7 @@ WHERE bp.status = 'published'
 --   - SEO fields (meta_title, meta_description, og_image)
 -- WHERE clause:
 --   - bp.slug = ?: exact slug match
---   - bp.status = 'published' AND bp.published_at IS NOT NULL: public posts only
+--   - bp.status = 'published' AND bp.published_at < datetime('now', '+1 second'): public posts only
 SELECT
     bp.id, bp.title, bp.slug, bp.excerpt, bp.body,
     bp.featured_image_url, bp.featured_image_alt,
@@ -121,7 +202,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.slug = ? AND bp.status = 'published' AND bp.published_at IS NOT NULL;
+WHERE bp.slug = ? AND bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second');
 
 -- name: GetPostBySlugIncludeDrafts :one
 -- sqlc annotation: :one returns single blog post row including drafts
@@ -142,7 +223,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.slug = ?;
+WHERE bp.slug = ? AND bp.deleted_at IS NULL;
 
 -- name: GetPostTagsByPostID :many
 -- sqlc annotation: :many returns slice of blog_tags rows
@@ -160,31 +241,34 @@ INNER JOIN blog_post_tags bpt ON bt.id = bpt.blog_tag_id
 WHERE bpt.blog_post_id = ?
 ORDER BY bt.name;
 
--- name: GetRelatedPosts :many
--- sqlc annotation: :many returns slice of related blog posts
--- Purpose: Retrieves posts from same category for "Related Posts" widget
--- Parameters (positional):
---   1. category_id (INTEGER): category to match
---   2. current_post_id (INTEGER): post to exclude (don't show current post as related)
---   3. LIMIT (INTEGER): max number of related posts (typically 3-5)
--- Return type: slice of minimal blog post data (no body content)
--- WHERE clause:
---   - bp.status = 'published' AND bp.published_at IS NOT NULL: public posts only
---   - bp.category_id = ?: same category as current post
---   - bp.id != ?: exclude current post from results
--- ORDER BY bp.published_at DESC: newest related posts first
+-- name: ListRelatedPostCandidates :many
+-- sqlc annotation: :many returns slice of candidate related posts
+-- Purpose: Candidate set for the related posts engine, which scores and ranks them in Go
+-- Parameters (named):
+--   1. post_id (INTEGER): current post; excluded from results and source of the tag set
+--   2. category_id (INTEGER): current post's category
+--   3. candidate_limit (INTEGER): max candidates to score (strongest matches first)
+-- Return type: minimal blog post data plus match signals
+--   - shared_tags: number of tags in common with the current post
+--   - category_id: compared against the current post's category by the scorer
+-- LEFT JOIN + GROUP BY: counts only tags that also belong to the current post
+-- HAVING: a candidate must share the category or at least one tag
 SELECT
     bp.id, bp.title, bp.slug, bp.featured_image_url, bp.featured_image_alt,
-    bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
-    bp.reading_time_minutes, bp.published_at
+    bp.category_id, bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
+    bp.reading_time_minutes, bp.published_at,
+    COUNT(bpt.blog_tag_id) AS shared_tags
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
-WHERE bp.status = 'published'
-    AND bp.published_at IS NOT NULL
-    AND bp.category_id = ?
-    AND bp.id != ?
-ORDER BY bp.published_at DESC
-LIMIT ?;
+LEFT JOIN blog_post_tags bpt ON bpt.blog_post_id = bp.id
+    AND bpt.blog_tag_id IN (SELECT cur.blog_tag_id FROM blog_post_tags cur WHERE cur.blog_post_id = @post_id)
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bp.id != @post_id
+GROUP BY bp.id
+HAVING COUNT(bpt.blog_tag_id) > 0 OR bp.category_id = @category_id
+ORDER BY COUNT(bpt.blog_tag_id) DESC, bp.published_at DESC
+LIMIT @candidate_limit;
 
 -- name: GetFeaturedPost :one
 -- sqlc annotation: :one returns single featured blog post
@@ -201,7 +285,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 ORDER BY bp.published_at DESC
 LIMIT 1;
 
@@ -222,6 +306,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
+WHERE bp.deleted_at IS NULL
 ORDER BY bp.created_at DESC;
 
 -- name: ListBlogPostsAdminFiltered :many
@@ -249,9 +334,9 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE
+WHERE bp.deleted_at IS NULL
     -- Optional status filter: '' = all statuses, otherwise exact match
-    (CASE WHEN @filter_status = '' THEN 1 ELSE bp.status = @filter_status END)
+    AND (CASE WHEN @filter_status = '' THEN 1 ELSE bp.status = @filter_status END)
     -- Optional category filter: 0 = all categories, otherwise exact ID match
     AND (CASE WHEN @filter_category = 0 THEN 1 ELSE bp.category_id = @filter_category END)
     -- Optional author filter: 0 = all authors, otherwise exact ID match
@@ -269,9 +354,9 @@ LIMIT @page_limit OFFSET @page_offset;
 -- Note: WHERE clause MUST exactly match ListBlogPostsAdminFiltered for accuracy
 --       Does NOT include JOINs since we only need count
 SELECT COUNT(*) FROM blog_posts bp
-WHERE
+WHERE bp.deleted_at IS NULL
     -- Exact same filter logic as ListBlogPostsAdminFiltered
-    (CASE WHEN @filter_status = '' THEN 1 ELSE bp.status = @filter_status END)
+    AND (CASE WHEN @filter_status = '' THEN 1 ELSE bp.status = @filter_status END)
     AND (CASE WHEN @filter_category = 0 THEN 1 ELSE bp.category_id = @filter_category END)
     AND (CASE WHEN @filter_author = 0 THEN 1 ELSE bp.author_id = @filter_author END)
     AND (CASE WHEN @filter_search = '' THEN 1 ELSE (bp.title LIKE '%' || @filter_search || '%' OR bp.slug LIKE '%' || @filter_search || '%') END);
@@ -284,6 +369,22 @@ WHERE
 -- Return type: complete blog_posts row with all fields
 SELECT * FROM blog_posts WHERE id = ?;
 
+-- name: GetBlogPostListItem :one
+-- sqlc annotation: :one returns one row of the admin post list
+-- Purpose: Re-renders a list row after an inline edit on /admin/blog/posts
+-- Parameters:
+--   1. id (INTEGER): post primary key
+-- Return type: same columns as ListBlogPostsAdminFiltered; trashed posts are not found
+SELECT
+    bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
+    bp.status, bp.category_id, bc.name AS category_name,
+    bp.author_id, ba.name AS author_name, ba.avatar_url AS author_avatar,
+    bp.reading_time_minutes, bp.published_at, bp.created_at, bp.updated_at
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+INNER JOIN blog_authors ba ON bp.author_id = ba.id
+WHERE bp.id = @id AND bp.deleted_at IS NULL;
+
 -- name: CreateBlogPost :one
 -- sqlc annotation: :one returns the created blog post
 -- Purpose: Creates a new blog post (draft or published)
@@ -334,6 +435,17 @@ UPDATE blog_posts SET
 WHERE id = ?
 RETURNING *;
 
+-- name: SetBlogPostContentFormat :exec
+-- sqlc annotation: :exec returns no data
+-- Purpose: Records the authoring mode of a post after create/update
+-- Parameters (named):
+--   1. content_format (TEXT): 'html' (WYSIWYG) or 'markdown'
+--   2. body_markdown (TEXT): Markdown source ('' for html posts)
+--   3. id (INTEGER): post to update
+-- Note: body already holds the rendered HTML, saved by Create/UpdateBlogPost
+UPDATE blog_posts SET content_format = @content_format, body_markdown = @body_markdown
+WHERE id = @id;
+
 -- name: DeleteBlogPost :exec
 -- sqlc annotation: :exec returns no data
 -- Purpose: Permanently removes a blog post
@@ -399,7 +511,7 @@ SELECT p.id, p.name, p.slug, p.primary_image, p.tagline, pc.slug AS category_slu
 FROM products p
 INNER JOIN blog_post_products bpp ON p.id = bpp.product_id
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE bpp.blog_post_id = ?
+WHERE bpp.blog_post_id = ? AND p.deleted_at IS NULL
 ORDER BY bpp.display_order, p.name;
 
 -- name: AddProductToPost :exec
@@ -432,7 +544,7 @@ DELETE FROM blog_post_products WHERE blog_post_id = ?;
 -- WHERE: status = 'published' ensures only published products can be linked
 -- LIMIT 10: restricts results for autocomplete/typeahead UI
 SELECT id, name, slug, primary_image FROM products
-WHERE status = 'published' AND name LIKE ?
+WHERE status = 'published' AND deleted_at IS NULL AND name LIKE ?
 ORDER BY name LIMIT 10;
 
 -- ====================================================================
@@ -455,6 +567,20 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 ORDER BY bp.published_at DESC
 LIMIT ?;
+
+-- name: ListBlogPostsGoingLive :many
+-- Lists the published posts whose publish date was set ahead of their last
+-- save and fell in (@after, @until], for the scheduled-publishing job. Posts
+-- published by the save itself (publish date within a minute of it) are
+-- left out; the save already announced them.
+-- Parameters:
+--   @after (TEXT): UTC "2006-01-02 15:04:05" end of the previous run
+--   @until (TEXT): UTC "2006-01-02 15:04:05" time of this run
+SELECT id, title, slug FROM blog_posts
+WHERE status = 'published' AND deleted_at IS NULL
+    AND published_at > CAST(@after AS TEXT) AND published_at <= CAST(@until AS TEXT)
+    AND published_at > datetime(updated_at, '+1 minute')
+ORDER BY published_at;
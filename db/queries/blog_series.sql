@@ -0,0 +1,127 @@
+-- ====================================================================
+-- BLOG SERIES QUERIES
+-- ====================================================================
+-- This file manages blog series: named, ordered groups of posts used for
+-- multi-part articles (e.g., "Radiation Safety 101, Part 1 of 4").
+--
+-- Managed entity:
+-- - blog_series: series definitions (name, slug, description)
+--
+-- Note: Membership lives on blog_posts (series_id, series_order); a post
+--       belongs to at most one series
+-- ====================================================================
+
+-- name: ListBlogSeries :many
+-- sqlc annotation: :many returns slice of series with post counts
+-- Purpose: Lists all series for the admin series list
+-- Parameters: none
+-- Return type: series columns plus post_count (all statuses)
+-- LEFT JOIN: series without posts are included with post_count = 0
+SELECT
+    bs.id, bs.name, bs.slug, bs.description, bs.created_at, bs.updated_at,
+    COUNT(bp.id) AS post_count
+FROM blog_series bs
+LEFT JOIN blog_posts bp ON bp.series_id = bs.id AND bp.deleted_at IS NULL
+GROUP BY bs.id
+ORDER BY bs.name;
+
+-- name: GetBlogSeries :one
+-- sqlc annotation: :one returns single series by ID
+-- Purpose: Retrieves specific series for editing
+-- Parameters:
+--   1. id (INTEGER): series primary key
+-- Return type: single blog_series row
+SELECT * FROM blog_series WHERE id = ? LIMIT 1;
+
+-- name: CreateBlogSeries :one
+-- sqlc annotation: :one returns the created series row
+-- Purpose: Creates a new series
+-- Parameters (3 positional):
+--   1. name (TEXT): series display name
+--   2. slug (TEXT): URL-safe identifier (must be unique)
+--   3. description (TEXT): optional summary shown on post pages
+-- Return type: complete inserted row with generated ID and timestamps
+INSERT INTO blog_series (name, slug, description)
+VALUES (?, ?, ?) RETURNING *;
+
+-- name: UpdateBlogSeries :one
+-- sqlc annotation: :one returns the updated series row
+-- Purpose: Updates an existing series
+-- Parameters (4 positional):
+--   1-3. updated field values (name, slug, description)
+--   4. id (INTEGER): which series to update (WHERE clause)
+-- Return type: updated blog_series row
+UPDATE blog_series SET name = ?, slug = ?, description = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ? RETURNING *;
+
+-- name: DeleteBlogSeries :exec
+-- sqlc annotation: :exec returns no data
+-- Purpose: Deletes a series
+-- Parameters:
+--   1. id (INTEGER): series to delete
+-- Note: member posts are kept; ON DELETE SET NULL clears their series_id
+DELETE FROM blog_series WHERE id = ?;
+
+-- name: ListPostsInSeries :many
+-- sqlc annotation: :many returns slice of member posts
+-- Purpose: Lists every post in a series (any status) for the admin series editor
+-- Parameters:
+--   1. series_id (INTEGER): series to list
+-- Return type: minimal post data with part number
+-- ORDER BY series_order, id: part order with a stable tiebreak
+SELECT id, title, slug, status, series_order, published_at
+FROM blog_posts
+WHERE series_id = ? AND deleted_at IS NULL
+ORDER BY series_order, id;
+
+-- name: ListPostsWithoutSeries :many
+-- sqlc annotation: :many returns slice of unassigned posts
+-- Purpose: Options for the "add post to series" picker in the admin series editor
+-- Parameters: none
+-- Return type: id and title of posts not in any series
+SELECT id, title, status
+FROM blog_posts
+WHERE series_id IS NULL AND deleted_at IS NULL
+ORDER BY title;
+
+-- name: AssignPostToSeries :exec
+-- sqlc annotation: :exec returns no data
+-- Purpose: Adds a post to a series (or moves it) at a given part number
+-- Parameters (named):
+--   1. series_id (INTEGER): target series
+--   2. series_order (INTEGER): part number within the series
+--   3. post_id (INTEGER): post to assign
+UPDATE blog_posts SET series_id = @series_id, series_order = @series_order
+WHERE id = @post_id;
+
+-- name: RemovePostFromSeries :exec
+-- sqlc annotation: :exec returns no data
+-- Purpose: Removes a post from its series
+-- Parameters:
+--   1. id (INTEGER): post to detach
+UPDATE blog_posts SET series_id = NULL, series_order = 0 WHERE id = ?;
+
+-- name: GetPublishedPostSeries :one
+-- sqlc annotation: :one returns the series of a post or sql.ErrNoRows
+-- Purpose: Finds the series a public post belongs to, for the series navigation box
+-- Parameters:
+--   1. id (INTEGER): post ID
+-- Return type: single blog_series row
+SELECT bs.*
+FROM blog_series bs
+INNER JOIN blog_posts bp ON bp.series_id = bs.id
+WHERE bp.id = ? LIMIT 1;
+
+-- name: ListPublishedPostsInSeries :many
+-- sqlc annotation: :many returns slice of published member posts
+-- Purpose: Ordered parts of a series for public prev/next navigation
+-- Parameters:
+--   1. series_id (INTEGER): series to list
+-- Return type: minimal post data with part number
+-- WHERE clause: only published posts are linked from public pages
+SELECT id, title, slug, series_order
+FROM blog_posts
+WHERE series_id = ?
+    AND status = 'published' AND deleted_at IS NULL
+    AND published_at < datetime('now', '+1 second')
+ORDER BY series_order, id;
@@ -79,3 +79,21 @@ DELETE FROM blog_tags WHERE id = ?;
 -- Return type: slice of blog_tags (limited to 10 results)
 -- LIMIT 10: restricts results for autocomplete dropdown performance
 SELECT * FROM blog_tags WHERE name LIKE ? ORDER BY name LIMIT 10;
+
+-- name: ListBlogTagCloud :many
+-- sqlc annotation: :many returns one row per tag used by a published post
+-- Purpose: Builds the public tag cloud (/blog/tags) and tag sitemap entries
+-- Parameters: none
+-- Return type: slice of (id, name, slug, post_count) rows
+-- Notes:
+--   - Only published, non-deleted posts are counted, so tags used only by
+--     drafts or trashed posts are left out of the cloud
+--   - post_count drives the font size of each tag in the cloud
+-- ORDER BY name: alphabetical, the usual tag cloud layout
+SELECT bt.id, bt.name, bt.slug, COUNT(*) AS post_count
+FROM blog_tags bt
+INNER JOIN blog_post_tags bpt ON bpt.blog_tag_id = bt.id
+INNER JOIN blog_posts bp ON bp.id = bpt.blog_post_id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
+GROUP BY bt.id, bt.name, bt.slug
+ORDER BY bt.name COLLATE NOCASE ASC;
@@ -0,0 +1,137 @@
+-- ====================================================================
+-- CAREERS QUERIES
+-- ====================================================================
+-- Job postings on /careers and the applications sent with their apply
+-- form.
+--
+-- Managed entities:
+-- - job_postings: One row per job
+-- - job_applications: One row per application, with its CV in storage
+--
+-- Key concepts:
+-- - A posting is open while it is published and its valid_through has
+--   not passed (NULL never closes)
+-- - "Now" is passed in as a UTC "2006-01-02 15:04:05" string so the
+--   comparison matches the stored timestamps
+-- - Deleting a posting deletes its applications (ON DELETE CASCADE); the
+--   caller deletes their CVs
+-- ====================================================================
+
+-- name: CreateJobPosting :one
+-- Creates a job posting.
+-- Parameters (13 positional): every column of job_postings except id and
+-- the timestamps, in table order
+INSERT INTO job_postings (
+    title, slug, department, location, country, employment_type, remote,
+    summary, description, valid_through, is_published, posted_at, meta_description
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateJobPosting :exec
+-- Saves the job posting form.
+-- Parameters: Same as CreateJobPosting, then the posting ID
+UPDATE job_postings
+SET title = ?, slug = ?, department = ?, location = ?, country = ?,
+    employment_type = ?, remote = ?, summary = ?, description = ?,
+    valid_through = ?, is_published = ?, posted_at = ?, meta_description = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetJobPosting :one
+-- Loads a job posting for the admin, published or not.
+SELECT * FROM job_postings WHERE id = ?;
+
+-- name: GetPublishedJobPostingBySlug :one
+-- Loads a published job posting for its public page, open or closed.
+SELECT * FROM job_postings WHERE slug = ? AND is_published = 1;
+
+-- name: DeleteJobPosting :exec
+-- Deletes a job posting with its applications (ON DELETE CASCADE).
+DELETE FROM job_postings WHERE id = ?;
+
+-- name: ListJobPostingsAdmin :many
+-- Lists every job posting for the admin Careers page with its
+-- application counts, newest first.
+SELECT j.id, j.title, j.slug, j.department, j.location, j.employment_type,
+       j.valid_through, j.is_published, j.created_at,
+       (SELECT COUNT(*) FROM job_applications a WHERE a.job_id = j.id) AS application_count,
+       (SELECT COUNT(*) FROM job_applications a WHERE a.job_id = j.id AND a.status = 'new') AS new_count
+FROM job_postings j
+ORDER BY j.created_at DESC, j.id DESC;
+
+-- name: ListOpenJobPostings :many
+-- Lists the open job postings for /careers by department and title.
+-- Parameters:
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+SELECT * FROM job_postings
+WHERE is_published = 1 AND (valid_through IS NULL OR valid_through >= CAST(@now AS TEXT))
+ORDER BY department, title, id;
+
+-- name: ListOpenJobPostingSlugs :many
+-- Lists the open job postings for the sitemap. Closed postings are left
+-- out so search engines drop them.
+-- Parameters:
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+SELECT slug, updated_at FROM job_postings
+WHERE is_published = 1 AND (valid_through IS NULL OR valid_through >= CAST(@now AS TEXT))
+ORDER BY created_at DESC;
+
+-- name: CreateJobApplication :one
+-- Records an application whose CV has been stored.
+-- Parameters (9 positional): job_id, name, email, phone, cover_letter,
+-- cv_key, cv_filename, cv_size, ip_address
+INSERT INTO job_applications (job_id, name, email, phone, cover_letter, cv_key, cv_filename, cv_size, ip_address)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetJobApplication :one
+-- Loads an application for the admin.
+SELECT * FROM job_applications WHERE id = ?;
+
+-- name: ListJobApplications :many
+-- Lists applications for the admin application inbox with their job,
+-- newest first.
+-- Parameters:
+--   @filter_status (TEXT): application status (empty = all)
+--   @filter_job_id (INTEGER): job posting ID (0 = all)
+--   @page_limit (INTEGER): rows per page
+--   @page_offset (INTEGER): rows to skip
+SELECT a.id, a.job_id, a.name, a.email, a.phone, a.cv_filename, a.status, a.created_at,
+       j.title AS job_title
+FROM job_applications a
+JOIN job_postings j ON j.id = a.job_id
+WHERE
+    (CASE WHEN @filter_status = '' THEN 1 ELSE a.status = @filter_status END)
+    AND (CASE WHEN @filter_job_id = 0 THEN 1 ELSE a.job_id = @filter_job_id END)
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountJobApplications :one
+-- Counts the applications ListJobApplications pages through.
+-- Parameters: Same filters as ListJobApplications
+SELECT COUNT(*) FROM job_applications
+WHERE
+    (CASE WHEN @filter_status = '' THEN 1 ELSE status = @filter_status END)
+    AND (CASE WHEN @filter_job_id = 0 THEN 1 ELSE job_id = @filter_job_id END);
+
+-- name: CountJobApplicationsByStatus :many
+-- Returns the number of applications in each status, for the inbox
+-- filter tabs.
+SELECT status, COUNT(*) AS count FROM job_applications GROUP BY status;
+
+-- name: UpdateJobApplicationStatus :exec
+-- Moves an application through the hiring process and saves the
+-- reviewer's notes.
+-- Parameters (3 positional): status, notes, id
+UPDATE job_applications
+SET status = ?, notes = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteJobApplication :exec
+-- Deletes an application; the caller deletes its CV.
+DELETE FROM job_applications WHERE id = ?;
+
+-- name: ListJobApplicationCVKeys :many
+-- Lists the CV storage keys of a job's applications, to delete the files
+-- with the posting.
+SELECT cv_key FROM job_applications WHERE job_id = ?;
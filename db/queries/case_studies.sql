@@ -38,7 +38,7 @@ SELECT
     i.id as industry_id, i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.is_published = 1
+WHERE cs.is_published = 1 AND cs.deleted_at IS NULL
 ORDER BY cs.display_order ASC, cs.created_at DESC;
 
 -- name: ListCaseStudiesByIndustry :many
@@ -56,7 +56,7 @@ SELECT
     i.id as industry_id, i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.is_published = 1 AND cs.industry_id = ?
+WHERE cs.is_published = 1 AND cs.deleted_at IS NULL AND cs.industry_id = ?
 ORDER BY cs.display_order ASC, cs.created_at DESC;
 
 -- name: GetCaseStudyBySlug :one
@@ -82,7 +82,7 @@ SELECT
     i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.slug = ? AND cs.is_published = 1;
+WHERE cs.slug = ? AND cs.is_published = 1 AND cs.deleted_at IS NULL;
 
 -- name: GetCaseStudyBySlugIncludeDrafts :one
 -- sqlc annotation: :one returns case study including drafts
@@ -101,7 +101,7 @@ SELECT
     i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.slug = ?;
+WHERE cs.slug = ? AND cs.deleted_at IS NULL;
 
 -- name: GetCaseStudyProducts :many
 -- sqlc annotation: :many returns products featured in a case study
@@ -123,7 +123,7 @@ SELECT
 FROM case_study_products csp
 INNER JOIN products p ON csp.product_id = p.id
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE csp.case_study_id = ? AND p.status = 'published'
+WHERE csp.case_study_id = ? AND p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY csp.display_order ASC;
 
 -- name: GetCaseStudyMetrics :many
@@ -144,7 +144,7 @@ ORDER BY display_order ASC;
 -- Purpose: Counts published case studies for stats/pagination
 -- Parameters: none
 -- Return type: integer count
-SELECT COUNT(*) FROM case_studies WHERE is_published = 1;
+SELECT COUNT(*) FROM case_studies WHERE is_published = 1 AND deleted_at IS NULL;
 
 -- name: CountCaseStudiesByIndustry :one
 -- sqlc annotation: :one returns count for specific industry
@@ -152,7 +152,7 @@ SELECT COUNT(*) FROM case_studies WHERE is_published = 1;
 -- Parameters:
 --   1. industry_id (INTEGER): industry to count
 -- Return type: integer count
-SELECT COUNT(*) FROM case_studies WHERE is_published = 1 AND industry_id = ?;
+SELECT COUNT(*) FROM case_studies WHERE is_published = 1 AND deleted_at IS NULL AND industry_id = ?;
 
 -- ====================================================================
 -- ADMIN CASE STUDY QUERIES
@@ -170,6 +170,7 @@ SELECT
     i.name as industry_name
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
+WHERE cs.deleted_at IS NULL
 ORDER BY cs.display_order ASC, cs.created_at DESC;
 
 -- name: AdminListCaseStudiesFiltered :many
@@ -194,9 +195,9 @@ SELECT
     i.name as industry_name
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE
+WHERE cs.deleted_at IS NULL
     -- Optional search filter: '' = all, otherwise LIKE match in title or client_name
-    (CASE WHEN @filter_search = '' THEN 1 ELSE (cs.title LIKE '%' || @filter_search || '%' OR cs.client_name LIKE '%' || @filter_search || '%') END)
+    AND (CASE WHEN @filter_search = '' THEN 1 ELSE (cs.title LIKE '%' || @filter_search || '%' OR cs.client_name LIKE '%' || @filter_search || '%') END)
     -- Optional status filter: 'published' = 1, 'draft' = 0, '' = all
     AND (CASE WHEN @filter_status = '' THEN 1
          WHEN @filter_status = 'published' THEN cs.is_published = 1
@@ -212,9 +213,9 @@ LIMIT @page_limit OFFSET @page_offset;
 -- Return type: integer count
 -- Note: WHERE clause MUST match AdminListCaseStudiesFiltered exactly
 SELECT COUNT(*) FROM case_studies cs
-WHERE
+WHERE cs.deleted_at IS NULL
     -- Exact same filter logic as AdminListCaseStudiesFiltered
-    (CASE WHEN @filter_search = '' THEN 1 ELSE (cs.title LIKE '%' || @filter_search || '%' OR cs.client_name LIKE '%' || @filter_search || '%') END)
+    AND (CASE WHEN @filter_search = '' THEN 1 ELSE (cs.title LIKE '%' || @filter_search || '%' OR cs.client_name LIKE '%' || @filter_search || '%') END)
     AND (CASE WHEN @filter_status = '' THEN 1
          WHEN @filter_status = 'published' THEN cs.is_published = 1
          WHEN @filter_status = 'draft' THEN cs.is_published = 0
@@ -228,6 +229,41 @@ WHERE
 -- Return type: complete case_studies row with all fields
 SELECT * FROM case_studies WHERE id = ?;
 
+-- name: AdminGetCaseStudyListItem :one
+-- sqlc annotation: :one returns one row of the admin case study list
+-- Purpose: Re-renders a list row after an inline edit on /admin/case-studies
+-- Parameters:
+--   1. id (INTEGER): case study primary key
+-- Return type: same columns as AdminListCaseStudiesFiltered; trashed case studies are not found
+SELECT
+    cs.id, cs.slug, cs.title, cs.client_name,
+    cs.hero_image_url, cs.is_published, cs.display_order,
+    cs.updated_at,
+    i.name as industry_name
+FROM case_studies cs
+INNER JOIN industries i ON cs.industry_id = i.id
+WHERE cs.id = @id AND cs.deleted_at IS NULL;
+
+-- name: SetCaseStudyPublished :execrows
+-- sqlc annotation: :execrows returns the number of updated rows (0 when missing or trashed)
+-- Purpose: Publishes or unpublishes a case study from the admin list
+-- Parameters (named):
+--   1. is_published (INTEGER): 1 to publish, 0 to unpublish
+--   2. id (INTEGER): case study primary key
+UPDATE case_studies
+SET is_published = @is_published, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND deleted_at IS NULL;
+
+-- name: SetCaseStudyDisplayOrder :execrows
+-- sqlc annotation: :execrows returns the number of updated rows (0 when missing or trashed)
+-- Purpose: Changes where a case study sits in listings, from the admin list
+-- Parameters (named):
+--   1. display_order (INTEGER): sort position, lowest first
+--   2. id (INTEGER): case study primary key
+UPDATE case_studies
+SET display_order = @display_order, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND deleted_at IS NULL;
+
 -- name: AdminCreateCaseStudy :one
 -- sqlc annotation: :one returns the created case study
 -- Purpose: Creates a new case study (draft or published)
@@ -346,7 +382,7 @@ SELECT
     p.name as product_name, p.slug as product_slug
 FROM case_study_products csp
 INNER JOIN products p ON csp.product_id = p.id
-WHERE csp.case_study_id = ?
+WHERE csp.case_study_id = ? AND p.deleted_at IS NULL
 ORDER BY csp.display_order ASC;
 
 -- ====================================================================
@@ -0,0 +1,111 @@
+-- ====================================================================
+-- CONTENT BLOCK QUERIES
+-- ====================================================================
+-- Reusable blocks (rich text, call to action, stats strip, logo wall)
+-- managed under Admin > Content Blocks and the ordered lists of blocks
+-- attached to public pages by path. The ContentBlocks service renders the
+-- attached blocks of active blocks below each page's own content.
+--
+-- Managed entities:
+-- - content_blocks: The block library
+-- - page_blocks: Blocks attached to a page path, in display order
+-- ====================================================================
+
+-- name: ListContentBlocks :many
+-- Lists every block by name with the number of pages it is attached to.
+SELECT cb.id, cb.name, cb.block_type, cb.heading, cb.is_active, cb.updated_at,
+       (SELECT COUNT(*) FROM page_blocks pb WHERE pb.block_id = cb.id) AS page_count
+FROM content_blocks cb
+ORDER BY cb.name, cb.id;
+
+-- name: GetContentBlock :one
+-- Returns one block (sql.ErrNoRows if it does not exist).
+SELECT * FROM content_blocks WHERE id = ?;
+
+-- name: CreateContentBlock :one
+-- Adds a block to the library.
+-- Parameters:
+--   1. name (TEXT): name shown in the admin only
+--   2. block_type (TEXT): rich_text, cta, stats or logo_wall
+--   3. heading (TEXT): heading shown above the block, may be empty
+--   4. body (TEXT): sanitized HTML (rich text) or plain text (call to action)
+--   5. button_text (TEXT): call to action button label
+--   6. button_url (TEXT): call to action button target
+--   7. items (TEXT): stats or logo entries, one per line
+--   8. is_active (BOOLEAN): shown on the pages it is attached to
+INSERT INTO content_blocks (name, block_type, heading, body, button_text, button_url, items, is_active)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateContentBlock :exec
+-- Edits a block; every page it is attached to shows the change.
+-- Parameters: as CreateContentBlock, then 9. id (INTEGER): block ID
+UPDATE content_blocks
+SET name = ?, block_type = ?, heading = ?, body = ?, button_text = ?, button_url = ?,
+    items = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteContentBlock :exec
+-- Removes a block and, through the foreign key, its page attachments.
+DELETE FROM content_blocks WHERE id = ?;
+
+-- name: ListPageBlockRoutes :many
+-- Lists the page paths that have blocks attached, with their block count.
+SELECT route, COUNT(*) AS block_count
+FROM page_blocks
+GROUP BY route
+ORDER BY route;
+
+-- name: ListPageBlocks :many
+-- Lists the blocks attached to a page path in display order, with the
+-- name, type and status of each block, for the page editor.
+SELECT pb.id, pb.route, pb.block_id, pb.sort_order, cb.name, cb.block_type, cb.is_active
+FROM page_blocks pb
+JOIN content_blocks cb ON cb.id = pb.block_id
+WHERE pb.route = ?
+ORDER BY pb.sort_order, pb.id;
+
+-- name: ListActivePageBlocks :many
+-- Lists the active blocks of every page in display order, grouped by path,
+-- for the ContentBlocks service.
+SELECT pb.route, cb.id, cb.block_type, cb.heading, cb.body, cb.button_text, cb.button_url, cb.items
+FROM page_blocks pb
+JOIN content_blocks cb ON cb.id = pb.block_id
+WHERE cb.is_active = 1
+ORDER BY pb.route, pb.sort_order, pb.id;
+
+-- name: AttachPageBlock :one
+-- Attaches a block to a page path. A block may appear on a page more than
+-- once (e.g. a call to action at two positions).
+-- Parameters:
+--   1. route (TEXT): page path starting with /
+--   2. block_id (INTEGER): block to show
+--   3. sort_order (INTEGER): position on the page, 0 first
+INSERT INTO page_blocks (route, block_id, sort_order)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: DetachPageBlock :exec
+-- Removes a block from a page; the block stays in the library.
+DELETE FROM page_blocks WHERE id = ?;
+
+-- name: GetPageBlock :one
+-- Returns one page attachment (sql.ErrNoRows if it does not exist).
+SELECT * FROM page_blocks WHERE id = ?;
+
+-- name: UpdatePageBlockOrder :exec
+-- Moves an attachment to a new position on its page. The route guards
+-- against reordering another page's blocks.
+-- Parameters:
+--   1. sort_order (INTEGER): new position, 0 first
+--   2. id (INTEGER): attachment ID
+--   3. route (TEXT): page path the attachment must belong to
+UPDATE page_blocks SET sort_order = ? WHERE id = ? AND route = ?;
+
+-- name: MovePageBlocks :exec
+-- Moves every block attached to one page path to another, when the page's
+-- path changes (e.g. a landing page gets a new slug).
+-- Parameters (named):
+--   1. new_route (TEXT): new page path
+--   2. old_route (TEXT): old page path
+UPDATE page_blocks SET route = sqlc.arg(new_route) WHERE route = sqlc.arg(old_route);
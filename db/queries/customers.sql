@@ -0,0 +1,199 @@
+-- ====================================================================
+-- CUSTOMER ACCOUNT QUERIES
+-- ====================================================================
+-- Public visitor accounts: sign-up, sign-in sessions, password resets and
+-- the library of whitepapers and product files downloaded while signed in.
+--
+-- Managed entities:
+-- - customer_accounts: One row per registered visitor (email is unique,
+--   case-insensitive)
+-- - customer_sessions: One row per signed-in browser, keyed by the hash of
+--   the random token in the bluejay_account cookie
+-- - customer_password_resets: Outstanding reset links, by token hash
+-- - customer_registrations: Sign-ups waiting for their emailed
+--   confirmation link, by token hash
+-- - customer_library: Whitepapers and product files a customer downloaded
+--
+-- Security notes:
+-- - Session, reset and confirmation tokens are stored hashed; the plain
+--   tokens are only in the cookie and the email
+-- - Deleting an account cascades to its sessions, resets and library
+-- ====================================================================
+
+-- name: CreateCustomerAccount :one
+-- Registers a customer account.
+-- Parameters:
+--   1. email (TEXT): sign-in email, unique ignoring case
+--   2. name (TEXT): full name, used for lead records
+--   3. company (TEXT): company, used for lead records
+--   4. designation (TEXT): role or title, may be empty
+--   5. password_hash (TEXT): bcrypt hash of the password
+--   6. marketing_consent (INTEGER): 1 if the customer opted in
+INSERT INTO customer_accounts (email, name, company, designation, password_hash, marketing_consent)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetCustomerAccountByEmail :one
+-- Looks up an account for sign-in and password resets. Matches ignoring case.
+SELECT * FROM customer_accounts WHERE email = ? LIMIT 1;
+
+-- name: GetCustomerAccountBySession :one
+-- Returns the account signed in with a session cookie token. No row means
+-- the session ended, expired or never existed.
+-- Parameters:
+--   @token_hash (TEXT): hex SHA-256 of the token in the bluejay_account cookie
+--   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp; older sessions
+--   have expired
+SELECT a.* FROM customer_accounts a
+INNER JOIN customer_sessions s ON s.account_id = a.id
+WHERE s.token_hash = @token_hash AND s.created_at >= CAST(@cutoff AS TEXT)
+LIMIT 1;
+
+-- name: UpdateCustomerLastLogin :exec
+-- Records a successful sign-in.
+UPDATE customer_accounts SET last_login_at = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: UpdateCustomerPassword :exec
+-- Replaces an account's password after a reset.
+-- Parameters:
+--   1. password_hash (TEXT): bcrypt hash of the new password
+--   2. id (INTEGER): account ID
+UPDATE customer_accounts SET password_hash = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: ListCustomerAccounts :many
+-- Lists accounts for the admin Customers page, newest first, with the
+-- number of items in each library.
+SELECT a.id, a.email, a.name, a.company, a.designation, a.marketing_consent,
+       a.last_login_at, a.created_at,
+       (SELECT COUNT(*) FROM customer_library l WHERE l.account_id = a.id) AS library_count
+FROM customer_accounts a
+ORDER BY a.created_at DESC, a.id DESC;
+
+-- name: DeleteCustomerAccount :exec
+-- Removes an account with its sessions, resets and library. Leads it
+-- submitted stay in the lead tables.
+DELETE FROM customer_accounts WHERE id = ?;
+
+-- name: CreateCustomerSession :one
+-- Records a new session at sign-in.
+-- Parameters:
+--   1. token_hash (TEXT): hex SHA-256 of the random token stored in the
+--   bluejay_account cookie
+--   2. account_id (INTEGER): signed-in account
+INSERT INTO customer_sessions (token_hash, account_id)
+VALUES (?, ?)
+RETURNING *;
+
+-- name: DeleteCustomerSession :exec
+-- Removes the session of the current cookie at sign-out.
+DELETE FROM customer_sessions WHERE token_hash = ?;
+
+-- name: DeleteCustomerSessionsByAccount :exec
+-- Signs an account out everywhere, after a password reset.
+DELETE FROM customer_sessions WHERE account_id = ?;
+
+-- name: DeleteStaleCustomerSessions :exec
+-- Removes sessions created before the cutoff; their cookies have expired.
+-- Parameters:
+--   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp
+DELETE FROM customer_sessions WHERE created_at < CAST(@cutoff AS TEXT);
+
+-- name: CreateCustomerPasswordReset :exec
+-- Stores a password reset link.
+-- Parameters:
+--   @account_id (INTEGER): account being reset
+--   @token_hash (TEXT): hex SHA-256 of the emailed token
+--   @expires_at (TEXT): UTC "2006-01-02 15:04:05" expiry
+INSERT INTO customer_password_resets (account_id, token_hash, expires_at)
+VALUES (@account_id, @token_hash, CAST(@expires_at AS TEXT));
+
+-- name: GetCustomerPasswordReset :one
+-- Looks up an unexpired reset link by token hash.
+-- Parameters:
+--   @token_hash (TEXT): hex SHA-256 of the token from the link
+--   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+SELECT * FROM customer_password_resets
+WHERE token_hash = @token_hash AND expires_at > CAST(@now AS TEXT)
+LIMIT 1;
+
+-- name: DeleteCustomerPasswordResets :exec
+-- Invalidates every reset link of an account once one has been used.
+DELETE FROM customer_password_resets WHERE account_id = ?;
+
+-- name: CreateCustomerRegistration :exec
+-- Stores a sign-up until its emailed confirmation link is followed.
+-- Parameters:
+--   @token_hash (TEXT): hex SHA-256 of the emailed token
+--   @email (TEXT): sign-in email
+--   @name (TEXT): full name
+--   @company (TEXT): company
+--   @designation (TEXT): role or title, may be empty
+--   @password_hash (TEXT): bcrypt hash of the password
+--   @marketing_consent (INTEGER): 1 if the customer opted in
+--   @expires_at (TEXT): UTC "2006-01-02 15:04:05" expiry
+INSERT INTO customer_registrations (token_hash, email, name, company, designation, password_hash, marketing_consent, expires_at)
+VALUES (@token_hash, @email, @name, @company, @designation, @password_hash, @marketing_consent, CAST(@expires_at AS TEXT));
+
+-- name: GetCustomerRegistration :one
+-- Looks up an unexpired sign-up by confirmation token hash.
+-- Parameters:
+--   @token_hash (TEXT): hex SHA-256 of the token from the link
+--   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+SELECT * FROM customer_registrations
+WHERE token_hash = @token_hash AND expires_at > CAST(@now AS TEXT)
+LIMIT 1;
+
+-- name: DeleteCustomerRegistrations :exec
+-- Invalidates every pending sign-up of an email once one is confirmed.
+-- Matches ignoring case.
+DELETE FROM customer_registrations WHERE email = ?;
+
+-- name: DeleteStaleCustomerRegistrations :exec
+-- Removes sign-ups whose confirmation link has expired.
+-- Parameters:
+--   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+DELETE FROM customer_registrations WHERE expires_at <= CAST(@now AS TEXT);
+
+-- name: AddCustomerLibraryWhitepaper :exec
+-- Adds a whitepaper to a customer's library, or moves it to the top when
+-- it is already there.
+-- Parameters:
+--   1. account_id (INTEGER): signed-in account
+--   2. whitepaper_id (INTEGER): downloaded whitepaper
+INSERT INTO customer_library (account_id, whitepaper_id)
+VALUES (?, ?)
+ON CONFLICT (account_id, whitepaper_id) WHERE whitepaper_id IS NOT NULL
+DO UPDATE SET downloaded_at = CURRENT_TIMESTAMP;
+
+-- name: AddCustomerLibraryProductDownload :exec
+-- Adds a product file to a customer's library, or moves it to the top when
+-- it is already there.
+-- Parameters:
+--   1. account_id (INTEGER): signed-in account
+--   2. product_download_id (INTEGER): downloaded product file
+INSERT INTO customer_library (account_id, product_download_id)
+VALUES (?, ?)
+ON CONFLICT (account_id, product_download_id) WHERE product_download_id IS NOT NULL
+DO UPDATE SET downloaded_at = CURRENT_TIMESTAMP;
+
+-- name: ListCustomerLibraryWhitepapers :many
+-- Lists the published whitepapers in a customer's library, most recently
+-- downloaded first.
+SELECT w.id, w.title, w.slug, w.pdf_file_path, l.downloaded_at
+FROM customer_library l
+INNER JOIN whitepapers w ON w.id = l.whitepaper_id
+WHERE l.account_id = ? AND w.is_published = 1
+ORDER BY l.downloaded_at DESC, l.id DESC;
+
+-- name: ListCustomerLibraryProductDownloads :many
+-- Lists the product files in a customer's library whose product is still
+-- published, most recently downloaded first.
+SELECT d.id, d.title, d.version, d.file_type, d.is_gated,
+       p.name AS product_name, p.slug AS product_slug, pc.slug AS category_slug,
+       l.downloaded_at
+FROM customer_library l
+INNER JOIN product_downloads d ON d.id = l.product_download_id
+INNER JOIN products p ON p.id = d.product_id
+INNER JOIN product_categories pc ON pc.id = p.category_id
+WHERE l.account_id = ? AND p.status = 'published' AND p.deleted_at IS NULL
+ORDER BY l.downloaded_at DESC, l.id DESC;
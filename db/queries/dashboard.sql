@@ -4,11 +4,15 @@
 -- This file contains simple COUNT queries used to populate admin dashboard
 -- widgets showing key metrics and pending items requiring attention.
 --
--- All queries return single integer counts for dashboard cards/badges.
--- These queries help admins quickly see:
+-- The COUNT queries return single integers for dashboard cards/badges;
+-- the list queries feed the HTMX-refreshable cards. They help admins
+-- quickly see:
 -- - Pending contact submissions needing response
 -- - Total partner count
 -- - Draft content needing review/publication
+-- - Published and draft content per type
+-- - Recent leads and the lead trend
+-- - The most downloaded whitepapers
 -- ====================================================================
 
 -- name: CountNewContactSubmissions :one
@@ -33,7 +37,7 @@ SELECT COUNT(*) FROM partners;
 -- Parameters: none
 -- Return type: integer count
 -- Used for: Dashboard alert showing draft products needing review
-SELECT COUNT(*) FROM products WHERE status = 'draft';
+SELECT COUNT(*) FROM products WHERE status = 'draft' AND deleted_at IS NULL;
 
 -- name: CountDraftBlogPosts :one
 -- sqlc annotation: :one returns integer count
@@ -41,4 +45,84 @@ SELECT COUNT(*) FROM products WHERE status = 'draft';
 -- Parameters: none
 -- Return type: integer count
 -- Used for: Dashboard alert showing draft blog posts needing review
-SELECT COUNT(*) FROM blog_posts WHERE status = 'draft';
+SELECT COUNT(*) FROM blog_posts WHERE status = 'draft' AND deleted_at IS NULL;
+
+-- name: CountContentByType :many
+-- Purpose: Published and draft counts per content type for the dashboard's
+-- content card, in a fixed order. Trashed items are left out, and archived
+-- items count as neither.
+-- Return type: one row per type
+SELECT 'product' AS content_type,
+    CAST(COUNT(CASE WHEN status = 'published' THEN 1 END) AS INTEGER) AS published,
+    CAST(COUNT(CASE WHEN status = 'draft' THEN 1 END) AS INTEGER) AS drafts
+FROM products WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'blog_post',
+    CAST(COUNT(CASE WHEN status = 'published' THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN status = 'draft' THEN 1 END) AS INTEGER)
+FROM blog_posts WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'solution',
+    CAST(COUNT(CASE WHEN is_published = 1 THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN is_published = 0 THEN 1 END) AS INTEGER)
+FROM solutions WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'case_study',
+    CAST(COUNT(CASE WHEN is_published = 1 THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN is_published = 0 THEN 1 END) AS INTEGER)
+FROM case_studies WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'whitepaper',
+    CAST(COUNT(CASE WHEN is_published = 1 THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN is_published = 0 THEN 1 END) AS INTEGER)
+FROM whitepapers
+UNION ALL
+SELECT 'landing_page',
+    CAST(COUNT(CASE WHEN status = 'published' THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN status = 'draft' THEN 1 END) AS INTEGER)
+FROM landing_pages;
+
+-- name: ListRecentLeads :many
+-- Purpose: The newest lead submissions (contact form, RFQs and gated
+-- whitepaper downloads) for the dashboard's recent leads card
+-- Parameter: limit (INTEGER), the number of submissions
+-- Return type: source (contact or whitepaper), row ID, contact details, the
+-- inquiry type or whitepaper title as detail, and created_at as
+-- CURRENT_TIMESTAMP text (UTC)
+SELECT * FROM (
+    SELECT 'contact' AS source, id, name, email, company,
+        CAST(COALESCE(inquiry_type, '') AS TEXT) AS detail,
+        CAST(created_at AS TEXT) AS created_at
+    FROM contact_submissions
+    UNION ALL
+    SELECT 'whitepaper', d.id, d.name, d.email, d.company, w.title, CAST(d.created_at AS TEXT)
+    FROM whitepaper_downloads d
+    JOIN whitepapers w ON w.id = d.whitepaper_id
+) leads
+ORDER BY created_at DESC, id DESC
+LIMIT ?1;
+
+-- name: ListLeadTimesSince :many
+-- Purpose: When each lead submission since a moment arrived, for the
+-- dashboard's lead trend (bucketed into days in the site timezone)
+-- Parameter: since (TEXT), a CURRENT_TIMESTAMP-format UTC time
+-- Return type: created_at as text, one row per submission
+SELECT CAST(created_at AS TEXT) AS created_at FROM contact_submissions WHERE created_at >= CAST(@since AS TEXT)
+UNION ALL
+SELECT CAST(created_at AS TEXT) FROM whitepaper_downloads WHERE created_at >= CAST(@since AS TEXT)
+UNION ALL
+SELECT CAST(created_at AS TEXT) FROM product_download_leads WHERE created_at >= CAST(@since AS TEXT)
+UNION ALL
+SELECT CAST(created_at AS TEXT) FROM event_registrations WHERE created_at >= CAST(@since AS TEXT);
+
+-- name: ListTopDownloadedWhitepapers :many
+-- Purpose: The most downloaded whitepapers for the dashboard, with their
+-- downloads over the last 30 days
+-- Parameter: limit (INTEGER), the number of whitepapers
+SELECT w.id, w.title, w.download_count,
+    CAST((SELECT COUNT(*) FROM whitepaper_downloads d
+          WHERE d.whitepaper_id = w.id AND d.created_at >= datetime('now', '-30 days')) AS INTEGER) AS recent_downloads
+FROM whitepapers w
+WHERE w.download_count > 0
+ORDER BY w.download_count DESC, w.title
+LIMIT ?1;
@@ -0,0 +1,180 @@
+-- ====================================================================
+-- DOCUMENTATION QUERIES
+-- ====================================================================
+-- Product manuals under /docs.
+--
+-- Managed entities:
+-- - doc_sets: One row per manual, optionally for a product
+-- - doc_versions: Versions of a manual, each with its own pages
+-- - doc_pages: Markdown pages, nested through parent_id
+--
+-- Key concepts:
+-- - A doc set is public when it is published and has a current version;
+--   /docs/<set> opens that version
+-- - Page slugs are unique within their version; the tree is built in
+--   services.BuildDocTree
+-- - Deleting a set or version deletes its pages (ON DELETE CASCADE)
+-- ====================================================================
+
+-- name: CreateDocSet :one
+-- Creates a doc set without versions.
+-- Parameters (6 positional): name, slug, product_id, description,
+-- is_published, sort_order
+INSERT INTO doc_sets (name, slug, product_id, description, is_published, sort_order)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateDocSet :exec
+-- Saves the doc set form.
+-- Parameters: Same as CreateDocSet, then the set ID
+UPDATE doc_sets
+SET name = ?, slug = ?, product_id = ?, description = ?, is_published = ?,
+    sort_order = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetDocSetCurrentVersion :exec
+-- Makes a version the one /docs/<set> opens.
+-- Parameters (2 positional): current_version_id, id
+UPDATE doc_sets
+SET current_version_id = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetDocSet :one
+-- Loads a doc set for the admin, published or not.
+SELECT * FROM doc_sets WHERE id = ?;
+
+-- name: GetPublishedDocSetBySlug :one
+-- Loads a published doc set for its public pages.
+SELECT * FROM doc_sets WHERE slug = ? AND is_published = 1;
+
+-- name: DeleteDocSet :exec
+-- Deletes a doc set with its versions and pages (ON DELETE CASCADE).
+DELETE FROM doc_sets WHERE id = ?;
+
+-- name: ListDocSetsAdmin :many
+-- Lists every doc set for the admin Docs page with its product, current
+-- version and number of versions. A trashed product is left blank.
+SELECT s.id, s.name, s.slug, s.is_published, s.updated_at,
+       COALESCE(p.name, '') AS product_name,
+       COALESCE(v.version, '') AS current_version,
+       (SELECT COUNT(*) FROM doc_versions dv WHERE dv.doc_set_id = s.id) AS version_count
+FROM doc_sets s
+LEFT JOIN products p ON p.id = s.product_id AND p.deleted_at IS NULL
+LEFT JOIN doc_versions v ON v.id = s.current_version_id
+ORDER BY s.sort_order, s.name, s.id;
+
+-- name: ListPublishedDocSets :many
+-- Lists the public doc sets for /docs, grouped by product (sets without
+-- one, or whose product is trashed, come first) and then by sort order.
+SELECT s.id, s.name, s.slug, s.description, s.product_id,
+       COALESCE(p.name, '') AS product_name,
+       v.version AS current_version
+FROM doc_sets s
+JOIN doc_versions v ON v.id = s.current_version_id
+LEFT JOIN products p ON p.id = s.product_id AND p.deleted_at IS NULL
+WHERE s.is_published = 1
+ORDER BY COALESCE(p.name, ''), s.sort_order, s.name, s.id;
+
+-- name: ListProductDocSets :many
+-- Lists the public doc sets of a product for its detail page.
+SELECT s.name, s.slug, v.version AS current_version
+FROM doc_sets s
+JOIN doc_versions v ON v.id = s.current_version_id
+WHERE s.product_id = ? AND s.is_published = 1
+ORDER BY s.sort_order, s.name, s.id;
+
+-- name: CreateDocVersion :one
+-- Adds a version to a doc set.
+-- Parameters (2 positional): doc_set_id, version
+INSERT INTO doc_versions (doc_set_id, version) VALUES (?, ?)
+RETURNING *;
+
+-- name: GetDocVersion :one
+-- Loads a version for the admin.
+SELECT * FROM doc_versions WHERE id = ?;
+
+-- name: GetDocVersionByLabel :one
+-- Loads a version of a doc set by its label, for /docs/<set>/<version>.
+-- Parameters (2 positional): doc_set_id, version
+SELECT * FROM doc_versions WHERE doc_set_id = ? AND version = ?;
+
+-- name: ListDocVersions :many
+-- Lists the versions of a doc set with their page counts, newest first.
+SELECT v.id, v.doc_set_id, v.version, v.created_at,
+       (SELECT COUNT(*) FROM doc_pages p WHERE p.doc_version_id = v.id) AS page_count
+FROM doc_versions v
+WHERE v.doc_set_id = ?
+ORDER BY v.created_at DESC, v.id DESC;
+
+-- name: DeleteDocVersion :exec
+-- Deletes a version with its pages (ON DELETE CASCADE).
+DELETE FROM doc_versions WHERE id = ?;
+
+-- name: CreateDocPage :one
+-- Creates a documentation page.
+-- Parameters (9 positional): doc_version_id, parent_id, title, slug,
+-- body_markdown, body_html, meta_description, sort_order, is_published
+INSERT INTO doc_pages (
+    doc_version_id, parent_id, title, slug, body_markdown, body_html,
+    meta_description, sort_order, is_published
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateDocPage :exec
+-- Saves the page form. The version of a page never changes.
+-- Parameters (9 positional): parent_id, title, slug, body_markdown,
+-- body_html, meta_description, sort_order, is_published, id
+UPDATE doc_pages
+SET parent_id = ?, title = ?, slug = ?, body_markdown = ?, body_html = ?,
+    meta_description = ?, sort_order = ?, is_published = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetDocPage :one
+-- Loads a page for the admin.
+SELECT * FROM doc_pages WHERE id = ?;
+
+-- name: ListDocPages :many
+-- Lists every page of a version, published or not, in sibling order.
+SELECT * FROM doc_pages
+WHERE doc_version_id = ?
+ORDER BY sort_order, title, id;
+
+-- name: CountChildDocPages :one
+-- Counts the subpages of a page, which must be moved or deleted before it.
+SELECT COUNT(*) FROM doc_pages WHERE parent_id = ?;
+
+-- name: DeleteDocPage :exec
+-- Deletes a page without subpages.
+DELETE FROM doc_pages WHERE id = ?;
+
+-- name: DocPageSlugTaken :one
+-- Reports whether another page of the version uses a slug.
+-- Parameters (named):
+--   1. doc_version_id (INTEGER): version of the page
+--   2. slug (TEXT): candidate slug
+--   3. exclude_id (INTEGER): page being updated, 0 when creating
+SELECT CAST(EXISTS (
+    SELECT 1 FROM doc_pages
+    WHERE doc_version_id = sqlc.arg(doc_version_id) AND slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id)
+) AS INTEGER) AS taken;
+
+-- name: ListDocSitemapPages :many
+-- Lists the published pages of the current versions of published doc
+-- sets for the sitemap. Older versions are left out so search engines
+-- index the current manual, and so are pages below a draft, which the
+-- public tree hides.
+SELECT s.slug AS set_slug, v.version, p.slug, p.updated_at
+FROM doc_pages p
+JOIN doc_versions v ON v.id = p.doc_version_id
+JOIN doc_sets s ON s.current_version_id = v.id
+WHERE s.is_published = 1 AND p.is_published = 1
+  AND NOT EXISTS (
+    WITH RECURSIVE up(id) AS (
+      SELECT p.parent_id
+      UNION ALL
+      SELECT a.parent_id FROM doc_pages a JOIN up ON a.id = up.id
+    )
+    SELECT 1 FROM up JOIN doc_pages a ON a.id = up.id WHERE a.is_published = 0
+  )
+ORDER BY s.sort_order, s.name, p.sort_order, p.id;
@@ -0,0 +1,313 @@
+-- ====================================================================
+-- CONTENT DUPLICATION QUERIES
+-- ====================================================================
+-- "Save as copy" for products, blog posts, solutions and case studies.
+-- Each Duplicate* query copies one row with INSERT ... SELECT, so columns
+-- added later are copied by editing this file only. services.ContentDuplicator
+-- runs them in one transaction and picks the new slug and SKUs.
+--
+-- Copies are unpublished drafts: status, is_published and published_at
+-- are reset, and blog post copies leave their series. Everything else,
+-- including sub-resources and translations, is copied.
+--
+-- Managed entities:
+-- - products + specs, images, features, certifications, downloads,
+--   variants (with their specs and images), related content pins
+-- - blog_posts + tags, related products
+-- - solutions + stats, challenges, products, CTAs
+-- - case_studies + products, metrics
+-- - translations of any of the above
+-- ====================================================================
+
+-- name: CopySlugTaken :one
+-- Reports whether a slug is used by any row of a content type, including
+-- trashed rows (the UNIQUE constraint covers them too).
+-- Parameters (named):
+--   1. content_type (TEXT): 'product', 'blog_post', 'solution' or 'case_study'
+--   2. slug (TEXT): candidate slug
+SELECT CAST(CASE CAST(sqlc.arg(content_type) AS TEXT)
+    WHEN 'product' THEN EXISTS (SELECT 1 FROM products WHERE slug = sqlc.arg(slug))
+    WHEN 'blog_post' THEN EXISTS (SELECT 1 FROM blog_posts WHERE slug = sqlc.arg(slug))
+    WHEN 'solution' THEN EXISTS (SELECT 1 FROM solutions WHERE slug = sqlc.arg(slug))
+    WHEN 'case_study' THEN EXISTS (SELECT 1 FROM case_studies WHERE slug = sqlc.arg(slug))
+    ELSE 1
+END AS INTEGER) AS taken;
+
+-- name: CopySKUTaken :one
+-- Reports whether a SKU is used by a product or a variant.
+-- Parameters (named):
+--   1. sku (TEXT): candidate SKU
+SELECT CAST(EXISTS (SELECT 1 FROM products WHERE sku = sqlc.arg(sku))
+    OR EXISTS (SELECT 1 FROM product_variants WHERE sku = sqlc.arg(sku)) AS INTEGER) AS taken;
+
+-- name: DuplicateProduct :one
+-- Copies a live product as a draft and returns the copy's ID
+-- (sql.ErrNoRows when the source is missing or trashed).
+-- Parameters (named):
+--   1. sku (TEXT): SKU of the copy
+--   2. slug (TEXT): slug of the copy
+--   3. name (TEXT): name of the copy
+--   4. source_id (INTEGER): product to copy
+INSERT INTO products (sku, slug, name, tagline, description, overview, category_id, status,
+    is_featured, featured_order, meta_title, meta_description, primary_image, video_url, og_image)
+SELECT sqlc.arg(sku), sqlc.arg(slug), sqlc.arg(name), tagline, description, overview, category_id, 'draft',
+    is_featured, featured_order, meta_title, meta_description, primary_image, video_url, og_image
+FROM products
+WHERE id = sqlc.arg(source_id) AND deleted_at IS NULL
+RETURNING id;
+
+-- name: DuplicateProductSpecs :exec
+-- Copies a product's specifications to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO product_specs (product_id, section_name, spec_key, spec_value, display_order)
+SELECT sqlc.arg(new_id), section_name, spec_key, spec_value, display_order
+FROM product_specs WHERE product_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductImages :exec
+-- Copies a product's gallery to its copy. Image, video and 3D files are shared.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO product_images (product_id, image_path, alt_text, caption, display_order, is_thumbnail, media_type, media_url)
+SELECT sqlc.arg(new_id), image_path, alt_text, caption, display_order, is_thumbnail, media_type, media_url
+FROM product_images WHERE product_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductFeatures :exec
+-- Copies a product's feature list to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO product_features (product_id, feature_text, display_order)
+SELECT sqlc.arg(new_id), feature_text, display_order
+FROM product_features WHERE product_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductCertifications :exec
+-- Copies a product's certifications to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO product_certifications (product_id, certification_name, certification_code, icon_type, icon_path, display_order)
+SELECT sqlc.arg(new_id), certification_name, certification_code, icon_type, icon_path, display_order
+FROM product_certifications WHERE product_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductDownloads :exec
+-- Copies a product's downloads to its copy, with a fresh download count.
+-- Files are shared.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO product_downloads (product_id, title, description, file_type, file_path, file_size, version, changelog, is_gated, download_count, display_order)
+SELECT sqlc.arg(new_id), title, description, file_type, file_path, file_size, version, changelog, is_gated, 0, display_order
+FROM product_downloads WHERE product_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductDownloadVersions :exec
+-- Links the copied downloads into the same version chains as the
+-- originals. Run after DuplicateProductDownloads, which copies the rows in
+-- ID order, so the n-th copy is the copy of the n-th original.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+WITH source AS (
+    SELECT id, supersedes_id, ROW_NUMBER() OVER (ORDER BY id) AS n
+    FROM product_downloads WHERE product_id = sqlc.arg(source_id)
+), copy AS (
+    SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS n
+    FROM product_downloads WHERE product_id = sqlc.arg(new_id)
+)
+UPDATE product_downloads
+SET supersedes_id = (
+    SELECT previous_copy.id
+    FROM copy
+    JOIN source ON source.n = copy.n
+    JOIN source previous ON previous.id = source.supersedes_id
+    JOIN copy previous_copy ON previous_copy.n = previous.n
+    WHERE copy.id = product_downloads.id
+)
+WHERE product_id = sqlc.arg(new_id);
+
+-- name: DuplicateProductRelatedContent :exec
+-- Copies a product's pinned related content to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO related_content_pins (product_id, content_type, content_id, display_order)
+SELECT sqlc.arg(new_id), content_type, content_id, display_order
+FROM related_content_pins WHERE product_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductVariant :one
+-- Copies one variant to the product's copy and returns the new variant ID.
+-- Parameters (named):
+--   1. new_product_id (INTEGER): the product copy
+--   2. sku (TEXT): SKU of the variant copy
+--   3. source_id (INTEGER): variant to copy
+INSERT INTO product_variants (product_id, sku, name, display_order)
+SELECT sqlc.arg(new_product_id), sqlc.arg(sku), name, display_order
+FROM product_variants WHERE id = sqlc.arg(source_id)
+RETURNING id;
+
+-- name: DuplicateProductVariantSpecs :exec
+-- Copies a variant's spec overrides to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the variant copy
+--   2. source_id (INTEGER): the original variant
+INSERT INTO product_variant_specs (variant_id, section_name, spec_key, spec_value, display_order)
+SELECT sqlc.arg(new_id), section_name, spec_key, spec_value, display_order
+FROM product_variant_specs WHERE variant_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateProductVariantImages :exec
+-- Copies a variant's gallery to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the variant copy
+--   2. source_id (INTEGER): the original variant
+INSERT INTO product_variant_images (variant_id, image_path, alt_text, display_order)
+SELECT sqlc.arg(new_id), image_path, alt_text, display_order
+FROM product_variant_images WHERE variant_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateBlogPost :one
+-- Copies a live blog post as an unscheduled draft outside any series and
+-- returns the copy's ID (sql.ErrNoRows when the source is missing or trashed).
+-- Parameters (named):
+--   1. title (TEXT): title of the copy
+--   2. slug (TEXT): slug of the copy
+--   3. source_id (INTEGER): blog post to copy
+INSERT INTO blog_posts (title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id,
+    meta_description, reading_time_minutes, status, published_at, meta_title, og_image, content_format, body_markdown)
+SELECT sqlc.arg(title), sqlc.arg(slug), excerpt, body, featured_image_url, featured_image_alt, category_id, author_id,
+    meta_description, reading_time_minutes, 'draft', NULL, meta_title, og_image, content_format, body_markdown
+FROM blog_posts
+WHERE id = sqlc.arg(source_id) AND deleted_at IS NULL
+RETURNING id;
+
+-- name: DuplicateBlogPostTags :exec
+-- Copies a blog post's tags to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO blog_post_tags (blog_post_id, blog_tag_id)
+SELECT sqlc.arg(new_id), blog_tag_id
+FROM blog_post_tags WHERE blog_post_id = sqlc.arg(source_id);
+
+-- name: DuplicateBlogPostProducts :exec
+-- Copies a blog post's related products to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO blog_post_products (blog_post_id, product_id, display_order)
+SELECT sqlc.arg(new_id), product_id, display_order
+FROM blog_post_products WHERE blog_post_id = sqlc.arg(source_id);
+
+-- name: DuplicateSolution :one
+-- Copies a live solution as an unpublished one and returns the copy's ID
+-- (sql.ErrNoRows when the source is missing or trashed).
+-- Parameters (named):
+--   1. title (TEXT): title of the copy
+--   2. slug (TEXT): slug of the copy
+--   3. source_id (INTEGER): solution to copy
+INSERT INTO solutions (title, slug, icon, short_description, hero_image_url, hero_title, hero_description,
+    overview_content, meta_description, reference_code, is_published, display_order, meta_title, og_image)
+SELECT sqlc.arg(title), sqlc.arg(slug), icon, short_description, hero_image_url, hero_title, hero_description,
+    overview_content, meta_description, reference_code, 0, display_order, meta_title, og_image
+FROM solutions
+WHERE id = sqlc.arg(source_id) AND deleted_at IS NULL
+RETURNING id;
+
+-- name: DuplicateSolutionStats :exec
+-- Copies a solution's statistics to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO solution_stats (solution_id, value, label, display_order)
+SELECT sqlc.arg(new_id), value, label, display_order
+FROM solution_stats WHERE solution_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateSolutionChallenges :exec
+-- Copies a solution's challenges to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO solution_challenges (solution_id, title, description, icon, display_order)
+SELECT sqlc.arg(new_id), title, description, icon, display_order
+FROM solution_challenges WHERE solution_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateSolutionProducts :exec
+-- Copies a solution's product links to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO solution_products (solution_id, product_id, display_order, is_featured)
+SELECT sqlc.arg(new_id), product_id, display_order, is_featured
+FROM solution_products WHERE solution_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateSolutionCTAs :exec
+-- Copies a solution's call-to-action sections to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO solution_ctas (solution_id, heading, subheading, primary_button_text, primary_button_url,
+    secondary_button_text, secondary_button_url, phone_number, section_name)
+SELECT sqlc.arg(new_id), heading, subheading, primary_button_text, primary_button_url,
+    secondary_button_text, secondary_button_url, phone_number, section_name
+FROM solution_ctas WHERE solution_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateCaseStudy :one
+-- Copies a live case study as an unpublished one and returns the copy's ID
+-- (sql.ErrNoRows when the source is missing or trashed).
+-- Parameters (named):
+--   1. title (TEXT): title of the copy
+--   2. slug (TEXT): slug of the copy
+--   3. source_id (INTEGER): case study to copy
+INSERT INTO case_studies (slug, title, client_name, industry_id, hero_image_url, summary,
+    challenge_title, challenge_content, challenge_bullets, solution_title, solution_content,
+    outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, og_image)
+SELECT sqlc.arg(slug), sqlc.arg(title), client_name, industry_id, hero_image_url, summary,
+    challenge_title, challenge_content, challenge_bullets, solution_title, solution_content,
+    outcome_title, outcome_content, meta_title, meta_description, 0, display_order, og_image
+FROM case_studies
+WHERE id = sqlc.arg(source_id) AND deleted_at IS NULL
+RETURNING id;
+
+-- name: DuplicateCaseStudyProducts :exec
+-- Copies a case study's product links to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO case_study_products (case_study_id, product_id, display_order)
+SELECT sqlc.arg(new_id), product_id, display_order
+FROM case_study_products WHERE case_study_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateCaseStudyMetrics :exec
+-- Copies a case study's metrics to its copy.
+-- Parameters (named):
+--   1. new_id (INTEGER): the copy
+--   2. source_id (INTEGER): the original
+INSERT INTO case_study_metrics (case_study_id, metric_value, metric_label, display_order)
+SELECT sqlc.arg(new_id), metric_value, metric_label, display_order
+FROM case_study_metrics WHERE case_study_id = sqlc.arg(source_id)
+ORDER BY id;
+
+-- name: DuplicateTranslations :exec
+-- Copies an item's translations to its copy. Their source hash no longer
+-- matches once the copy is renamed, so they show as outdated.
+-- Parameters (named):
+--   1. entity_type (TEXT): 'product', 'blog_post' or 'solution'
+--   2. new_id (INTEGER): the copy
+--   3. source_id (INTEGER): the original
+INSERT INTO translations (entity_type, entity_id, locale, fields, source_hash, status)
+SELECT entity_type, sqlc.arg(new_id), locale, fields, source_hash, status
+FROM translations
+WHERE entity_type = sqlc.arg(entity_type) AND entity_id = sqlc.arg(source_id);
@@ -0,0 +1,57 @@
+-- ====================================================================
+-- EDIT LOCK QUERIES
+-- ====================================================================
+-- Soft "currently being edited by" locks on admin edit forms, renewed by
+-- a heartbeat from the open form and read by services.EditLocks.
+--
+-- Managed entity:
+-- - edit_locks: At most one lock per item, keyed by content type and ID
+--
+-- Timestamps are passed in as UTC text in SQLite's CURRENT_TIMESTAMP
+-- format, so expiry comparisons work on the stored values.
+-- ====================================================================
+
+-- name: ClaimEditLock :execrows
+-- Takes the lock on an item for a user, or renews it when they already
+-- hold it. A lock held by someone else is only taken over once its
+-- heartbeat is older than cutoff; otherwise no row is affected.
+-- Parameters:
+--   1. content_type (TEXT): e.g. "product", "blog_post"
+--   2. content_id (INTEGER): ID of the item
+--   3. user_id (INTEGER): admin user claiming the lock
+--   4. now (TEXT): current time
+--   5. cutoff (TEXT): heartbeats before this have expired
+INSERT INTO edit_locks (content_type, content_id, user_id, acquired_at, heartbeat_at)
+VALUES (@content_type, @content_id, @user_id, CAST(@now AS TEXT), CAST(@now AS TEXT))
+ON CONFLICT (content_type, content_id) DO UPDATE SET
+    acquired_at = CASE WHEN edit_locks.user_id = excluded.user_id THEN edit_locks.acquired_at ELSE excluded.acquired_at END,
+    user_id = excluded.user_id,
+    heartbeat_at = excluded.heartbeat_at
+WHERE edit_locks.user_id = excluded.user_id OR edit_locks.heartbeat_at < CAST(@cutoff AS TEXT);
+
+-- name: GetEditLock :one
+-- Returns the live lock on an item with the holder's display name. No row
+-- means nobody is editing it.
+-- Parameters:
+--   1. content_type (TEXT): e.g. "product", "blog_post"
+--   2. content_id (INTEGER): ID of the item
+--   3. cutoff (TEXT): heartbeats before this have expired
+SELECT l.user_id, COALESCE(u.display_name, '') AS user_name, l.acquired_at, l.heartbeat_at
+FROM edit_locks l
+LEFT JOIN admin_users u ON u.id = l.user_id
+WHERE l.content_type = @content_type AND l.content_id = @content_id AND l.heartbeat_at >= CAST(@cutoff AS TEXT);
+
+-- name: ReleaseEditLock :execrows
+-- Drops a user's lock when they leave the edit form. Scoped by user_id so
+-- nobody can release someone else's lock.
+-- Parameters:
+--   1. content_type (TEXT): e.g. "product", "blog_post"
+--   2. content_id (INTEGER): ID of the item
+--   3. user_id (INTEGER): holder of the lock
+DELETE FROM edit_locks WHERE content_type = ? AND content_id = ? AND user_id = ?;
+
+-- name: DeleteExpiredEditLocks :execrows
+-- Removes locks whose heartbeat stopped before cutoff.
+-- Parameters:
+--   1. cutoff (TEXT): heartbeats before this have expired
+DELETE FROM edit_locks WHERE heartbeat_at < CAST(@cutoff AS TEXT);
@@ -0,0 +1,24 @@
+-- ====================================================================
+-- EDITOR ATTACHMENT QUERIES
+-- ====================================================================
+-- Images uploaded from the rich text editors. The files themselves are
+-- media_files rows; services.EditorAttachments removes the ones no content
+-- refers to any more.
+--
+-- Managed entities:
+-- - editor_attachments: Marks a media file as added by an editor upload
+-- ====================================================================
+
+-- name: CreateEditorAttachment :exec
+-- Marks a media file as an editor upload.
+INSERT OR IGNORE INTO editor_attachments (media_file_id) VALUES (?);
+
+-- name: ListEditorAttachmentsBefore :many
+-- Lists the media files uploaded from an editor before cutoff, oldest first.
+-- Parameters:
+--   1. cutoff (TEXT): "2006-01-02 15:04:05" UTC
+SELECT m.id, m.filename, m.original_filename, m.file_path, m.file_size, m.mime_type, m.width, m.height, m.alt_text, m.created_at, m.content_hash
+FROM media_files m
+JOIN editor_attachments a ON a.media_file_id = m.id
+WHERE a.created_at < CAST(@cutoff AS TEXT)
+ORDER BY m.id;
@@ -0,0 +1,100 @@
+-- ====================================================================
+-- EVENT QUERIES
+-- ====================================================================
+-- Events and webinars on /events, and the registrations of their
+-- registration form.
+--
+-- Managed entities:
+-- - events: One row per event (in person or webinar)
+-- - event_registrations: One row per address and event
+--
+-- Key concepts:
+-- - starts_at / ends_at are UTC; an event is upcoming until ends_at
+-- - "Now" is passed in as a UTC "2006-01-02 15:04:05" string so the
+--   comparison matches the stored timestamps
+-- - Registrations are leads, listed with the others on the Leads page
+-- ====================================================================
+
+-- name: CreateEvent :one
+-- Creates an event.
+-- Parameters (13 positional): every column of events except id and the
+-- timestamps, in table order
+INSERT INTO events (
+    title, slug, event_type, summary, description, starts_at, ends_at,
+    location, webinar_url, registration_open, capacity, is_published, meta_description
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateEvent :exec
+-- Saves the event form.
+-- Parameters: Same as CreateEvent, then the event ID
+UPDATE events
+SET title = ?, slug = ?, event_type = ?, summary = ?, description = ?,
+    starts_at = ?, ends_at = ?, location = ?, webinar_url = ?,
+    registration_open = ?, capacity = ?, is_published = ?, meta_description = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetEvent :one
+-- Loads an event for the admin, published or not.
+SELECT * FROM events WHERE id = ?;
+
+-- name: GetPublishedEventBySlug :one
+-- Loads a published event for its public page.
+SELECT * FROM events WHERE slug = ? AND is_published = 1;
+
+-- name: DeleteEvent :exec
+-- Deletes an event with its registrations (ON DELETE CASCADE).
+DELETE FROM events WHERE id = ?;
+
+-- name: ListEventsAdmin :many
+-- Lists every event for the admin Events page with its registration
+-- count, latest start first.
+SELECT e.id, e.title, e.slug, e.event_type, e.starts_at, e.ends_at, e.location,
+       e.registration_open, e.capacity, e.is_published,
+       (SELECT COUNT(*) FROM event_registrations r WHERE r.event_id = e.id) AS registration_count
+FROM events e
+ORDER BY e.starts_at DESC, e.id DESC;
+
+-- name: ListUpcomingEvents :many
+-- Lists published events that have not ended, soonest first.
+-- Parameters:
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+SELECT * FROM events
+WHERE is_published = 1 AND ends_at >= CAST(@now AS TEXT)
+ORDER BY starts_at, id;
+
+-- name: ListPastEvents :many
+-- Lists published events that have ended, most recent first.
+-- Parameters:
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+--   @page_limit (INTEGER): how many to list
+SELECT * FROM events
+WHERE is_published = 1 AND ends_at < CAST(@now AS TEXT)
+ORDER BY starts_at DESC, id DESC
+LIMIT @page_limit;
+
+-- name: ListPublishedEventSlugs :many
+-- Lists every published event for the sitemap.
+SELECT slug, updated_at FROM events
+WHERE is_published = 1
+ORDER BY starts_at DESC;
+
+-- name: CreateEventRegistration :execrows
+-- Registers an address for an event. Registering the same address again
+-- changes nothing and affects no rows.
+-- Parameters (8 positional): event_id, name, email, company, designation,
+-- marketing_consent, ip_address, user_agent
+INSERT INTO event_registrations (event_id, name, email, company, designation, marketing_consent, ip_address, user_agent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (event_id, email) DO NOTHING;
+
+-- name: CountEventRegistrations :one
+-- Counts the registrations of an event, for its capacity.
+SELECT COUNT(*) FROM event_registrations WHERE event_id = ?;
+
+-- name: ListEventRegistrations :many
+-- Lists the registrations of an event for the admin, newest first.
+SELECT * FROM event_registrations
+WHERE event_id = ?
+ORDER BY created_at DESC, id DESC;
@@ -0,0 +1,88 @@
+-- ====================================================================
+-- EXPORT JOB QUERIES
+-- ====================================================================
+-- Queue of background exports run by services.ExportJobs. A job moves
+-- queued -> running -> done (or failed); done jobs become expired when
+-- their file is deleted after the download link's lifetime.
+--
+-- Managed entities:
+-- - export_jobs: One row per requested export, with progress and file
+-- ====================================================================
+
+-- name: CreateExportJob :one
+-- Queues an export.
+-- Parameters:
+--   1. kind (TEXT): export source, e.g. 'products'
+--   2. format (TEXT): 'csv' or 'xlsx'
+--   3. params (TEXT): list filters as a URL query string, may be empty
+--   4. user_id (INTEGER, nullable): user who requested the export
+INSERT INTO export_jobs (kind, format, params, user_id)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetExportJob :one
+-- Returns one job (sql.ErrNoRows if it does not exist).
+SELECT * FROM export_jobs WHERE id = ?;
+
+-- name: ListExportJobs :many
+-- Lists recent jobs, newest first.
+-- Parameters:
+--   @filter_user (INTEGER): requesting user ID (0 = all users)
+--   @page_limit (INTEGER): maximum rows
+SELECT * FROM export_jobs
+WHERE CAST(@filter_user AS INTEGER) = 0 OR user_id = @filter_user
+ORDER BY id DESC
+LIMIT @page_limit;
+
+-- name: ClaimNextExportJob :one
+-- Marks the oldest queued job as running and returns it (sql.ErrNoRows when
+-- the queue is empty).
+UPDATE export_jobs
+SET status = 'running', rows_done = 0, started_at = CURRENT_TIMESTAMP
+WHERE id = (SELECT id FROM export_jobs WHERE status = 'queued' ORDER BY id LIMIT 1)
+RETURNING *;
+
+-- name: RequeueRunningExportJobs :execrows
+-- Puts jobs that were running when the server stopped back in the queue.
+UPDATE export_jobs SET status = 'queued', rows_done = 0 WHERE status = 'running';
+
+-- name: UpdateExportJobProgress :exec
+-- Records how far a running job is.
+-- Parameters:
+--   1. rows_done (INTEGER): rows written so far
+--   2. rows_total (INTEGER): rows expected (0 = unknown)
+--   3. id (INTEGER): job ID
+UPDATE export_jobs SET rows_done = ?, rows_total = ? WHERE id = ?;
+
+-- name: CompleteExportJob :exec
+-- Marks a job done and records its file.
+-- Parameters:
+--   @rows_done (INTEGER): rows written
+--   @storage_key (TEXT): key of the file in export storage
+--   @filename (TEXT): download file name
+--   @size_bytes (INTEGER): file size
+--   @expires_at (TEXT): UTC "2006-01-02 15:04:05" time the download link expires
+--   @id (INTEGER): job ID
+UPDATE export_jobs
+SET status = 'done', rows_done = @rows_done, storage_key = @storage_key, filename = @filename,
+    size_bytes = @size_bytes, finished_at = CURRENT_TIMESTAMP, expires_at = CAST(@expires_at AS TEXT)
+WHERE id = @id;
+
+-- name: FailExportJob :exec
+-- Marks a job failed.
+-- Parameters:
+--   1. error (TEXT): reason shown on the export page
+--   2. id (INTEGER): job ID
+UPDATE export_jobs SET status = 'failed', error = ?, finished_at = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: ListExpiredExportJobs :many
+-- Lists done jobs whose download link expired before the cutoff.
+-- Parameters:
+--   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp, typically now
+SELECT * FROM export_jobs
+WHERE status = 'done' AND expires_at < CAST(@cutoff AS TEXT)
+ORDER BY id;
+
+-- name: ExpireExportJob :exec
+-- Marks a job expired once its file is deleted.
+UPDATE export_jobs SET status = 'expired', storage_key = '' WHERE id = ?;
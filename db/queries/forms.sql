@@ -0,0 +1,149 @@
+-- ====================================================================
+-- FORM BUILDER QUERIES
+-- ====================================================================
+-- Custom lead-generation forms built under Admin > Forms, served at
+-- /forms/<slug> and embeddable in landing page form sections.
+--
+-- Managed entities:
+-- - forms: Form settings, success message and notification addresses
+-- - form_fields: A form's fields and validation rules in display order
+-- - form_submissions: Answers sent through a form
+-- ====================================================================
+
+-- name: ListForms :many
+-- Lists every form by name with its number of fields and submissions.
+SELECT f.id, f.name, f.slug, f.is_active, f.updated_at,
+       (SELECT COUNT(*) FROM form_fields ff WHERE ff.form_id = f.id) AS field_count,
+       (SELECT COUNT(*) FROM form_submissions fs WHERE fs.form_id = f.id) AS submission_count
+FROM forms f
+ORDER BY f.name, f.id;
+
+-- name: ListActiveForms :many
+-- Lists the active forms by name, for the landing page form section.
+SELECT id, name, slug FROM forms WHERE is_active = 1 ORDER BY name, id;
+
+-- name: GetForm :one
+-- Returns one form (sql.ErrNoRows if it does not exist).
+SELECT * FROM forms WHERE id = ?;
+
+-- name: GetFormBySlug :one
+-- Returns the form with a slug, active or not; visitors only see active
+-- forms.
+SELECT * FROM forms WHERE slug = ?;
+
+-- name: CreateForm :one
+-- Adds a form.
+-- Parameters:
+--   1. name (TEXT): form name, shown as its heading
+--   2. slug (TEXT): path below /forms/
+--   3. description (TEXT): introduction shown above the fields, may be empty
+--   4. submit_label (TEXT): submit button label
+--   5. success_message (TEXT): shown after a successful submission
+--   6. notify_emails (TEXT): comma-separated addresses told of each submission
+--   7. is_active (BOOLEAN): whether visitors can see and submit the form
+INSERT INTO forms (name, slug, description, submit_label, success_message, notify_emails, is_active)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateForm :exec
+-- Edits a form's settings.
+-- Parameters: as CreateForm, then 8. id (INTEGER): form ID
+UPDATE forms
+SET name = ?, slug = ?, description = ?, submit_label = ?, success_message = ?, notify_emails = ?,
+    is_active = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteForm :exec
+-- Removes a form and, through the foreign keys, its fields and submissions.
+DELETE FROM forms WHERE id = ?;
+
+-- name: TouchForm :exec
+-- Marks a form as edited after one of its fields changed.
+UPDATE forms SET updated_at = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: ListFormFields :many
+-- Lists a form's fields in display order.
+SELECT * FROM form_fields
+WHERE form_id = ?
+ORDER BY sort_order, id;
+
+-- name: GetFormField :one
+-- Returns one field (sql.ErrNoRows if it does not exist).
+SELECT * FROM form_fields WHERE id = ?;
+
+-- name: CreateFormField :one
+-- Adds a field to a form.
+-- Parameters:
+--   1. form_id (INTEGER): form the field belongs to
+--   2. label (TEXT): label shown to visitors
+--   3. name (TEXT): key the answer is stored under, unique within the form
+--   4. field_type (TEXT): text, email, tel, number, textarea, select or checkbox
+--   5. placeholder (TEXT): hint inside the input, may be empty
+--   6. help_text (TEXT): hint below the input, may be empty
+--   7. options (TEXT): select choices, one per line
+--   8. required (BOOLEAN): whether the field must be answered
+--   9. min_length (INTEGER): fewest characters accepted, 0 for no minimum
+--  10. max_length (INTEGER): most characters accepted, 0 for no maximum
+--  11. sort_order (INTEGER): position on the form, 0 first
+INSERT INTO form_fields (form_id, label, name, field_type, placeholder, help_text, options, required, min_length, max_length, sort_order)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateFormField :exec
+-- Edits a field; its position is left alone.
+-- Parameters:
+--   1-9. label, name, field_type, placeholder, help_text, options, required,
+--        min_length, max_length: as CreateFormField
+--   10. id (INTEGER): field ID
+UPDATE form_fields
+SET label = ?, name = ?, field_type = ?, placeholder = ?, help_text = ?, options = ?,
+    required = ?, min_length = ?, max_length = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteFormField :exec
+-- Removes a field from its form.
+DELETE FROM form_fields WHERE id = ?;
+
+-- name: UpdateFormFieldOrder :exec
+-- Moves a field to a new position. The form ID guards against reordering
+-- another form's fields.
+-- Parameters:
+--   1. sort_order (INTEGER): new position, 0 first
+--   2. id (INTEGER): field ID
+--   3. form_id (INTEGER): form the field must belong to
+UPDATE form_fields SET sort_order = ? WHERE id = ? AND form_id = ?;
+
+-- name: CreateFormSubmission :one
+-- Stores a submission.
+-- Parameters:
+--   1. form_id (INTEGER): form that was submitted
+--   2. data (TEXT): JSON array of {name, label, type, value} answers
+--   3. email (TEXT): first email answer, may be empty
+--   4. ip_address (TEXT): visitor IP address
+--   5. user_agent (TEXT): visitor browser
+INSERT INTO form_submissions (form_id, data, email, ip_address, user_agent)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListFormSubmissions :many
+-- Lists one page of a form's submissions, newest first.
+-- Parameters (named):
+--   1. form_id (INTEGER): form ID
+--   2. limit (INTEGER): page size
+--   3. offset (INTEGER): submissions to skip
+SELECT * FROM form_submissions
+WHERE form_id = sqlc.arg(form_id)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountFormSubmissions :one
+-- Counts a form's submissions, for pagination.
+SELECT COUNT(*) FROM form_submissions WHERE form_id = ?;
+
+-- name: DeleteFormSubmission :exec
+-- Removes a submission. The form ID guards against deleting another form's
+-- submissions.
+-- Parameters (named):
+--   1. id (INTEGER): submission ID
+--   2. form_id (INTEGER): form the submission must belong to
+DELETE FROM form_submissions WHERE id = sqlc.arg(id) AND form_id = sqlc.arg(form_id);
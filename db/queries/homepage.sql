@@ -57,7 +57,7 @@ SELECT * FROM homepage_hero WHERE id = ?;
 
 -- name: CreateHero :one
 -- Purpose: Creates a new hero banner variant
--- Parameters (10 positional):
+-- Parameters (11 positional):
 --   1. headline (TEXT): main hero headline
 --   2. subheadline (TEXT): supporting text
 --   3. badge_text (TEXT): optional badge/announcement text
@@ -66,25 +66,26 @@ SELECT * FROM homepage_hero WHERE id = ?;
 --   6. secondary_cta_text (TEXT): optional secondary button text
 --   7. secondary_cta_url (TEXT): secondary button link
 --   8. background_image (TEXT): hero background/featured image URL
---   9. is_active (BOOLEAN): whether this hero is currently displayed
---   10. display_order (INTEGER): sort position
+--   9. background_image_alt (TEXT): description of the background image
+--   10. is_active (BOOLEAN): whether this hero is currently displayed
+--   11. display_order (INTEGER): sort position
 -- Note: Only one hero should have is_active = 1 at a time
 INSERT INTO homepage_hero (
     headline, subheadline, badge_text,
     primary_cta_text, primary_cta_url,
     secondary_cta_text, secondary_cta_url,
-    background_image, is_active, display_order
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    background_image, background_image_alt, is_active, display_order
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateHero :exec
 -- Purpose: Updates an existing hero banner
--- Parameters (11 positional): same as CreateHero + id (WHERE clause)
+-- Parameters (12 positional): same as CreateHero + id (WHERE clause)
 UPDATE homepage_hero
 SET headline = ?, subheadline = ?, badge_text = ?,
     primary_cta_text = ?, primary_cta_url = ?,
     secondary_cta_text = ?, secondary_cta_url = ?,
-    background_image = ?, is_active = ?, display_order = ?,
+    background_image = ?, background_image_alt = ?, is_active = ?, display_order = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?;
 
@@ -157,27 +158,28 @@ SELECT * FROM homepage_testimonials WHERE id = ?;
 
 -- name: CreateTestimonialHomepage :one
 -- Purpose: Creates a new homepage testimonial
--- Parameters (8 positional):
+-- Parameters (9 positional):
 --   1. quote (TEXT): testimonial text/content
 --   2. author_name (TEXT): customer name
 --   3. author_title (TEXT): job title
 --   4. author_company (TEXT): company/organization name
 --   5. author_image (TEXT): headshot/avatar URL
---   6. rating (INTEGER): star rating (typically 1-5)
---   7. display_order (INTEGER): carousel slide order
---   8. is_active (BOOLEAN): whether to display on homepage
+--   6. author_image_alt (TEXT): description of the headshot
+--   7. rating (INTEGER): star rating (typically 1-5)
+--   8. display_order (INTEGER): carousel slide order
+--   9. is_active (BOOLEAN): whether to display on homepage
 INSERT INTO homepage_testimonials (
     quote, author_name, author_title, author_company,
-    author_image, rating, display_order, is_active
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    author_image, author_image_alt, rating, display_order, is_active
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateTestimonialHomepage :exec
 -- Purpose: Updates an existing homepage testimonial
--- Parameters (9 positional): same as CreateTestimonialHomepage + id
+-- Parameters (10 positional): same as CreateTestimonialHomepage + id
 UPDATE homepage_testimonials
 SET quote = ?, author_name = ?, author_title = ?, author_company = ?,
-    author_image = ?, rating = ?, display_order = ?, is_active = ?
+    author_image = ?, author_image_alt = ?, rating = ?, display_order = ?, is_active = ?
 WHERE id = ?;
 
 -- name: DeleteTestimonialHomepage :exec
@@ -0,0 +1,36 @@
+-- ====================================================================
+-- JOB RUN QUERIES
+-- ====================================================================
+-- Last run of each recurring job scheduled by internal/jobs, for the
+-- Admin > Jobs page and for resuming schedules after a restart.
+--
+-- Managed entities:
+-- - job_runs: One row per job name with its last run and run counts
+-- ====================================================================
+
+-- name: ListJobRuns :many
+-- Lists the last run of every job that has run.
+SELECT * FROM job_runs ORDER BY name;
+
+-- name: RecordJobRun :exec
+-- Records a finished run, counting it and, when it failed, the failure.
+-- Parameters:
+--   @name (TEXT): job name
+--   @started_at (TEXT): UTC "2006-01-02 15:04:05" start time
+--   @duration_ms (INTEGER): run time in milliseconds
+--   @error (TEXT): error message, '' on success
+--   @trigger_kind (TEXT): 'schedule' or 'manual'
+INSERT INTO job_runs (name, last_started_at, last_duration_ms, last_error, last_trigger, last_success_at, runs, failures)
+VALUES (
+    @name, CAST(@started_at AS TEXT), @duration_ms, CAST(@error AS TEXT), @trigger_kind,
+    CASE WHEN CAST(@error AS TEXT) = '' THEN CAST(@started_at AS TEXT) END,
+    1, CASE WHEN CAST(@error AS TEXT) = '' THEN 0 ELSE 1 END
+)
+ON CONFLICT(name) DO UPDATE SET
+    last_started_at = excluded.last_started_at,
+    last_duration_ms = excluded.last_duration_ms,
+    last_error = excluded.last_error,
+    last_trigger = excluded.last_trigger,
+    last_success_at = COALESCE(excluded.last_success_at, job_runs.last_success_at),
+    runs = job_runs.runs + 1,
+    failures = job_runs.failures + excluded.failures;
@@ -0,0 +1,130 @@
+-- ====================================================================
+-- LANDING PAGE QUERIES
+-- ====================================================================
+-- Campaign landing pages managed under Admin > Landing Pages and served
+-- at /<slug> once published. Each page is composed of an ordered list of
+-- sections (hero, text, lead form, testimonials).
+--
+-- Managed entities:
+-- - landing_pages: Page settings, SEO fields, analytics snippets, status
+-- - landing_page_sections: A page's sections in display order
+-- ====================================================================
+
+-- name: ListLandingPages :many
+-- Lists every landing page, most recently edited first, with its number
+-- of sections.
+SELECT lp.id, lp.title, lp.slug, lp.layout, lp.status, lp.published_at, lp.updated_at,
+       (SELECT COUNT(*) FROM landing_page_sections s WHERE s.landing_page_id = lp.id) AS section_count
+FROM landing_pages lp
+ORDER BY lp.updated_at DESC, lp.id DESC;
+
+-- name: ListPublishedLandingPages :many
+-- Lists the published landing pages that search engines may index, for
+-- the sitemap.
+SELECT slug, updated_at
+FROM landing_pages
+WHERE status = 'published' AND noindex = 0
+ORDER BY slug;
+
+-- name: GetLandingPage :one
+-- Returns one landing page (sql.ErrNoRows if it does not exist).
+SELECT * FROM landing_pages WHERE id = ?;
+
+-- name: GetLandingPageBySlug :one
+-- Returns the landing page at a slug, published or not; visitors only see
+-- published pages, drafts are shown with a preview token.
+SELECT * FROM landing_pages WHERE slug = ?;
+
+-- name: CreateLandingPage :one
+-- Adds a landing page as a draft.
+-- Parameters:
+--   1. title (TEXT): page heading and browser title
+--   2. slug (TEXT): path below the site root, without leading slash
+--   3. layout (TEXT): default (site header and footer) or minimal
+--   4. meta_title (TEXT): title for search results, may be empty
+--   5. meta_description (TEXT): description for search results, may be empty
+--   6. og_image (TEXT): social sharing image, may be empty
+--   7. noindex (BOOLEAN): asks search engines not to index the page
+--   8. head_snippet (TEXT): analytics HTML added to <head>
+--   9. body_snippet (TEXT): analytics HTML added before </body>
+INSERT INTO landing_pages (title, slug, layout, meta_title, meta_description, og_image, noindex, head_snippet, body_snippet)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateLandingPage :exec
+-- Edits a landing page's settings; its status is left alone.
+-- Parameters: as CreateLandingPage, then 10. id (INTEGER): page ID
+UPDATE landing_pages
+SET title = ?, slug = ?, layout = ?, meta_title = ?, meta_description = ?, og_image = ?,
+    noindex = ?, head_snippet = ?, body_snippet = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetLandingPageStatus :exec
+-- Publishes or unpublishes a landing page. The first publication date is
+-- kept when a page is taken down and published again.
+-- Parameters (named):
+--   1. status (TEXT): draft or published
+--   2. id (INTEGER): page ID
+UPDATE landing_pages
+SET status = sqlc.arg(status),
+    published_at = CASE WHEN sqlc.arg(status) = 'published' THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteLandingPage :exec
+-- Removes a landing page and, through the foreign key, its sections.
+DELETE FROM landing_pages WHERE id = ?;
+
+-- name: ListLandingPageSections :many
+-- Lists a landing page's sections in display order.
+SELECT * FROM landing_page_sections
+WHERE landing_page_id = ?
+ORDER BY sort_order, id;
+
+-- name: GetLandingPageSection :one
+-- Returns one section (sql.ErrNoRows if it does not exist).
+SELECT * FROM landing_page_sections WHERE id = ?;
+
+-- name: CreateLandingPageSection :one
+-- Adds a section to a landing page.
+-- Parameters:
+--   1. landing_page_id (INTEGER): page the section belongs to
+--   2. section_type (TEXT): hero, text, form or testimonials
+--   3. heading (TEXT): section heading, may be empty
+--   4. body (TEXT): sanitized HTML (text) or plain text (other types)
+--   5. image (TEXT): hero image, may be empty
+--   6. button_text (TEXT): hero button or form submit label
+--   7. button_url (TEXT): hero button target
+--   8. items (TEXT): testimonial entries, one per line
+--   9. sort_order (INTEGER): position on the page, 0 first
+INSERT INTO landing_page_sections (landing_page_id, section_type, heading, body, image, button_text, button_url, items, sort_order)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateLandingPageSection :exec
+-- Edits a section; its type and position are left alone.
+-- Parameters:
+--   1. heading (TEXT), 2. body (TEXT), 3. image (TEXT), 4. button_text (TEXT),
+--   5. button_url (TEXT), 6. items (TEXT): as CreateLandingPageSection
+--   7. id (INTEGER): section ID
+UPDATE landing_page_sections
+SET heading = ?, body = ?, image = ?, button_text = ?, button_url = ?, items = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteLandingPageSection :exec
+-- Removes a section from its page.
+DELETE FROM landing_page_sections WHERE id = ?;
+
+-- name: UpdateLandingPageSectionOrder :exec
+-- Moves a section to a new position. The page ID guards against
+-- reordering another page's sections.
+-- Parameters:
+--   1. sort_order (INTEGER): new position, 0 first
+--   2. id (INTEGER): section ID
+--   3. landing_page_id (INTEGER): page the section must belong to
+UPDATE landing_page_sections SET sort_order = ? WHERE id = ? AND landing_page_id = ?;
+
+-- name: TouchLandingPage :exec
+-- Marks a landing page as edited after one of its sections changed.
+UPDATE landing_pages SET updated_at = CURRENT_TIMESTAMP WHERE id = ?;
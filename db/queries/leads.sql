@@ -0,0 +1,90 @@
+-- ====================================================================
+-- LEAD DEDUPLICATION QUERIES
+-- ====================================================================
+-- This file feeds the admin Leads view, which links submissions from the
+-- same person across the contact form, RFQs, whitepaper downloads, gated
+-- product downloads, and event registrations.
+--
+-- Managed entities:
+-- - lead_merges: manual links from one normalized email to another
+--
+-- Key concepts:
+-- - Grouping and normalization (lowercase, plus-address stripping, company
+--   canonicalization) happen in services.LeadService, not in SQL
+-- - email / primary_email in lead_merges are always normalized addresses
+-- ====================================================================
+
+-- name: ListLeadContactSubmissions :many
+-- sqlc annotation: :many returns every contact and RFQ submission
+-- Purpose: Source rows for lead grouping (contact form and RFQ)
+-- Return type: Submission summary without message body, newest first
+SELECT id, name, email, phone, company, inquiry_type, status, created_at
+FROM contact_submissions
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListLeadWhitepaperDownloads :many
+-- sqlc annotation: :many returns every whitepaper download with its title
+-- Purpose: Source rows for lead grouping (gated whitepaper downloads)
+-- Return type: Download summary with whitepaper title, newest first
+SELECT d.id, d.whitepaper_id, d.name, d.email, d.company, d.designation, d.created_at,
+       w.title AS whitepaper_title
+FROM whitepaper_downloads d
+JOIN whitepapers w ON w.id = d.whitepaper_id
+ORDER BY d.created_at DESC, d.id DESC;
+
+-- name: ListLeadProductDownloads :many
+-- sqlc annotation: :many returns every gated product download lead
+-- Purpose: Source rows for lead grouping (gated product downloads)
+-- Return type: Lead summary with file title and product, newest first
+SELECT l.id, l.product_download_id, l.name, l.email, l.company, l.designation, l.created_at,
+       d.title AS download_title, d.product_id, p.name AS product_name
+FROM product_download_leads l
+JOIN product_downloads d ON d.id = l.product_download_id
+JOIN products p ON p.id = d.product_id
+ORDER BY l.created_at DESC, l.id DESC;
+
+-- name: ListLeadEventRegistrations :many
+-- sqlc annotation: :many returns every event registration
+-- Purpose: Source rows for lead grouping (event and webinar registrations)
+-- Return type: Registration summary with event title, newest first
+SELECT r.id, r.event_id, r.name, r.email, r.company, r.designation, r.created_at,
+       e.title AS event_title
+FROM event_registrations r
+JOIN events e ON e.id = r.event_id
+ORDER BY r.created_at DESC, r.id DESC;
+
+-- name: ListLeadMerges :many
+-- sqlc annotation: :many returns all manual merges
+-- Purpose: Resolve merged addresses to their primary address
+SELECT email, primary_email, created_at
+FROM lead_merges
+ORDER BY email;
+
+-- name: MergeLead :exec
+-- sqlc annotation: :exec performs upsert without returning data
+-- Purpose: Record that email belongs to the same person as primary_email
+-- Parameters (2 positional):
+--   1. email (TEXT): normalized address being merged away
+--   2. primary_email (TEXT): normalized address it is merged into
+-- ON CONFLICT: Re-merging an address moves it to the new primary
+INSERT INTO lead_merges (email, primary_email)
+VALUES (?, ?)
+ON CONFLICT (email) DO UPDATE
+SET primary_email = excluded.primary_email,
+    created_at = CURRENT_TIMESTAMP;
+
+-- name: RepointLeadMerges :exec
+-- sqlc annotation: :exec updates without returning data
+-- Purpose: Keep merges one level deep when a primary is itself merged
+-- Parameters (named):
+--   1. new_primary (TEXT): address the merged leads now belong to
+--   2. old_primary (TEXT): former primary address being merged away
+UPDATE lead_merges
+SET primary_email = @new_primary
+WHERE primary_email = @old_primary;
+
+-- name: UnmergeLead :exec
+-- sqlc annotation: :exec deletes without returning data
+-- Purpose: Split a merged address back into its own lead
+DELETE FROM lead_merges
+WHERE email = ?;
@@ -0,0 +1,90 @@
+-- ====================================================================
+-- LINK CHECK QUERIES
+-- ====================================================================
+-- Queries behind the link-check job and Admin > Link Check
+-- (services.LinkChecker). Rich text comes from ListRichContent
+-- (accessibility.sql); these add the call-to-action URLs, target lookups and
+-- the stored results.
+-- ====================================================================
+
+-- name: ListLinkSources :many
+-- Lists every non-empty call-to-action URL: homepage heroes and CTAs,
+-- solution CTAs, page sections, the header button, content blocks and
+-- landing page sections. parent_id is the landing page of a landing page
+-- section and 0 otherwise.
+SELECT CAST('hero' AS TEXT) AS source, id AS owner_id, CAST(0 AS INTEGER) AS parent_id, headline AS owner_title, CAST('Primary button' AS TEXT) AS field, primary_cta_url AS url
+FROM homepage_hero WHERE primary_cta_url != ''
+UNION ALL
+SELECT 'hero', id, 0, headline, 'Secondary button', secondary_cta_url
+FROM homepage_hero WHERE COALESCE(secondary_cta_url, '') != ''
+UNION ALL
+SELECT 'homepage_cta', id, 0, headline, 'Primary button', primary_cta_url
+FROM homepage_cta WHERE primary_cta_url != ''
+UNION ALL
+SELECT 'homepage_cta', id, 0, headline, 'Secondary button', secondary_cta_url
+FROM homepage_cta WHERE COALESCE(secondary_cta_url, '') != ''
+UNION ALL
+SELECT 'solution_cta', s.id, 0, s.title, c.heading || ': primary button', c.primary_button_url
+FROM solution_ctas c JOIN solutions s ON s.id = c.solution_id
+WHERE s.deleted_at IS NULL AND COALESCE(c.primary_button_url, '') != ''
+UNION ALL
+SELECT 'solution_cta', s.id, 0, s.title, c.heading || ': secondary button', c.secondary_button_url
+FROM solution_ctas c JOIN solutions s ON s.id = c.solution_id
+WHERE s.deleted_at IS NULL AND COALESCE(c.secondary_button_url, '') != ''
+UNION ALL
+SELECT 'page_section', id, 0, page_key || ' / ' || section_key, 'Primary button', primary_button_url
+FROM page_sections WHERE primary_button_url != ''
+UNION ALL
+SELECT 'page_section', id, 0, page_key || ' / ' || section_key, 'Secondary button', secondary_button_url
+FROM page_sections WHERE secondary_button_url != ''
+UNION ALL
+SELECT 'header', id, 0, 'Header', 'Call to action', header_cta_url
+FROM settings WHERE header_cta_enabled = 1 AND header_cta_url != ''
+UNION ALL
+SELECT 'content_block', id, 0, name, 'Button', button_url
+FROM content_blocks WHERE button_url != ''
+UNION ALL
+SELECT 'landing_section', s.id, p.id, p.title, 'Button', s.button_url
+FROM landing_page_sections s
+JOIN landing_pages p ON p.id = s.landing_page_id
+WHERE s.button_url != ''
+ORDER BY source, owner_title, owner_id;
+
+-- name: LinkTargetID :one
+-- Returns the ID of the item a public detail page or landing page path
+-- refers to, whatever its status, or 0 when there is none. Trashed items
+-- count as missing.
+-- Parameters (named):
+--   1. content_type (TEXT): 'product', 'blog_post', 'solution', 'case_study', 'whitepaper' or 'landing_page'
+--   2. slug (TEXT): slug from the link
+SELECT CAST(COALESCE(CASE CAST(sqlc.arg(content_type) AS TEXT)
+    WHEN 'product' THEN (SELECT id FROM products WHERE slug = sqlc.arg(slug) AND deleted_at IS NULL)
+    WHEN 'blog_post' THEN (SELECT id FROM blog_posts WHERE slug = sqlc.arg(slug) AND deleted_at IS NULL)
+    WHEN 'solution' THEN (SELECT id FROM solutions WHERE slug = sqlc.arg(slug) AND deleted_at IS NULL)
+    WHEN 'case_study' THEN (SELECT id FROM case_studies WHERE slug = sqlc.arg(slug) AND deleted_at IS NULL)
+    WHEN 'whitepaper' THEN (SELECT id FROM whitepapers WHERE slug = sqlc.arg(slug))
+    WHEN 'landing_page' THEN (SELECT id FROM landing_pages WHERE slug = sqlc.arg(slug))
+END, 0) AS INTEGER) AS id;
+
+-- name: DeleteBrokenLinks :exec
+-- Clears the results of the previous run.
+DELETE FROM broken_links;
+
+-- name: CreateBrokenLink :exec
+-- Stores one broken link found by a run.
+-- Parameters:
+--   1. source (TEXT): content type the link is in
+--   2. owner_id (INTEGER): item the link is in
+--   3. parent_id (INTEGER): landing page of a landing page section, else 0
+--   4. owner_title (TEXT): title of the item
+--   5. field (TEXT): field the link is in
+--   6. url (TEXT): the link as written
+--   7. status (INTEGER): HTTP status the link answered with
+--   8. target_type (TEXT): content type of the unpublished target, or empty
+--   9. target_id (INTEGER): ID of the unpublished target, or 0
+INSERT INTO broken_links (source, owner_id, parent_id, owner_title, field, url, status, target_type, target_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListBrokenLinks :many
+-- Lists the broken links of the last run, by content type and item.
+SELECT * FROM broken_links ORDER BY source, owner_title, owner_id, field, url;
@@ -136,12 +136,13 @@ SELECT * FROM media_files WHERE file_path = ? LIMIT 1;
 --   $6 (INTEGER) - width: Image width in pixels (NULL for non-images)
 --   $7 (INTEGER) - height: Image height in pixels (NULL for non-images)
 --   $8 (TEXT) - alt_text: Accessibility alt text for images (optional)
+--   $9 (TEXT) - content_hash: Hex SHA-256 of the contents ("" if unknown)
 --
 -- Returns: MediaFile - The newly created media file record with auto-generated ID and timestamps
 --
 -- Note: width/height should be extracted from image files during upload processing
-INSERT INTO media_files (filename, original_filename, file_path, file_size, mime_type, width, height, alt_text)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+INSERT INTO media_files (filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, content_hash)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: UpdateMediaFileAltText :exec
 -- Updates the alt text for an existing media file (accessibility).
@@ -166,3 +167,53 @@ UPDATE media_files SET alt_text = ? WHERE id = ?;
 -- Note: Application code should delete the physical file before calling this query
 -- Caution: May orphan file references in products, solutions, or other content
 DELETE FROM media_files WHERE id = ?;
+
+-- ====================================================================
+-- BULK IMPORT (content hashes and variants)
+-- ====================================================================
+
+-- name: GetMediaFileByHash :one
+-- Retrieves the media file with the given content hash, if any.
+--
+-- Parameters:
+--   $1 (TEXT) - content_hash: Hex SHA-256 of the file contents
+-- Returns: MediaFile - The oldest file with that content, or sql.ErrNoRows
+--
+-- Use case: Skipping files the library already holds during an import
+SELECT * FROM media_files WHERE content_hash = ? AND content_hash != '' ORDER BY id LIMIT 1;
+
+-- name: ListMediaFilesWithoutHash :many
+-- Retrieves media files uploaded before content hashing, so an import can
+-- hash them and detect duplicates of legacy uploads.
+--
+-- Returns: []MediaFile
+SELECT * FROM media_files WHERE content_hash = '' ORDER BY id;
+
+-- name: SetMediaFileHash :exec
+-- Records the content hash of a media file.
+--
+-- Parameters:
+--   $1 (TEXT) - content_hash: Hex SHA-256 of the file contents
+--   $2 (INTEGER) - id: Media file ID
+UPDATE media_files SET content_hash = ? WHERE id = ?;
+
+-- name: CreateMediaVariant :one
+-- Records a resized copy of a media file.
+--
+-- Parameters:
+--   $1 (INTEGER) - media_file_id: Original file
+--   $2 (TEXT) - name: Variant name (e.g., "thumb", "medium")
+--   $3 (TEXT) - file_path: Web path of the resized file
+--   $4, $5 (INTEGER) - width, height: Pixel size of the variant
+--   $6 (INTEGER) - file_size: Size in bytes
+-- Returns: MediaVariant
+INSERT INTO media_variants (media_file_id, name, file_path, width, height, file_size)
+VALUES (?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: ListMediaVariants :many
+-- Retrieves the variants of a media file, smallest first.
+--
+-- Parameters:
+--   $1 (INTEGER) - media_file_id
+-- Returns: []MediaVariant
+SELECT * FROM media_variants WHERE media_file_id = ? ORDER BY width ASC;
@@ -71,6 +71,27 @@ UPDATE navigation_menus SET name = ?, location = ?, updated_at = CURRENT_TIMESTA
 -- Note: Ensure foreign key constraints are configured for cascading deletes
 DELETE FROM navigation_menus WHERE id = ?;
 
+-- name: SetNavigationMenuActive :exec
+-- Switches public rendering of a menu on or off.
+--
+-- Parameters:
+--   $1 (BOOLEAN) - is_active: Whether the menu replaces the built-in links at its location
+--   $2 (INTEGER) - id: Menu ID to update
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Note: Call DeactivateOtherNavigationMenus first, so one menu per location is active
+UPDATE navigation_menus SET is_active = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: DeactivateOtherNavigationMenus :exec
+-- Switches off every other menu at a location before a menu is activated.
+--
+-- Parameters:
+--   $1 (TEXT) - location: Location of the menu being activated
+--   $2 (INTEGER) - id: Menu ID being activated (left unchanged)
+-- Returns: (none) - sqlc annotation :exec returns only row count
+UPDATE navigation_menus SET is_active = 0, updated_at = CURRENT_TIMESTAMP
+WHERE location = ? AND id != ? AND is_active = 1;
+
 -- ====================================================================
 -- NAVIGATION ITEMS
 -- ====================================================================
@@ -132,12 +153,18 @@ VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 --   $6 (BOOLEAN) - is_active: Updated visibility status
 --   $7 (INTEGER) - parent_id: Updated parent (for moving in hierarchy)
 --   $8 (INTEGER) - sort_order: Updated display position
---   $9 (INTEGER) - id: Item ID to update
+--   $9 (TEXT) - visibility: Updated layout visibility ("all", "desktop", "mobile")
+--   $10 (TEXT) - icon: Updated Material Symbols icon name (empty for none)
+--   $11 (TEXT) - layout: Updated dropdown layout ("list", "mega")
+--   $12 (TEXT) - featured_type: Updated featured content type (empty for none)
+--   $13 (INTEGER) - featured_id: Updated featured content ID
+--   $14 (INTEGER) - id: Item ID to update
 -- Returns: (none) - sqlc annotation :exec returns only row count
 --
 -- Use case: Editing menu items, changing hierarchy, reordering
 UPDATE navigation_items
-SET label = ?, link_type = ?, url = ?, page_identifier = ?, open_new_tab = ?, is_active = ?, parent_id = ?, sort_order = ?
+SET label = ?, link_type = ?, url = ?, page_identifier = ?, open_new_tab = ?, is_active = ?, parent_id = ?, sort_order = ?, visibility = ?,
+    icon = ?, layout = ?, featured_type = ?, featured_id = ?
 WHERE id = ?;
 
 -- name: DeleteNavigationItem :exec
@@ -174,3 +201,18 @@ DELETE FROM navigation_items WHERE menu_id = ?;
 -- Use case: Drag-and-drop reordering in admin interface
 -- Note: Application should handle recalculating sort_order for all affected items
 UPDATE navigation_items SET sort_order = ?, parent_id = ? WHERE id = ?;
+
+-- name: ListActiveNavigationItems :many
+-- Retrieves the visible items of every active menu, for rendering on the public site.
+--
+-- Parameters: none
+-- Returns: []ListActiveNavigationItemsRow - Items with their menu's location, in display order
+--
+-- Filtering: active menus (navigation_menus.is_active = 1) and visible items (is_active = 1)
+-- Note: Children of hidden items are returned too; application drops them when building the hierarchy
+SELECT m.location, i.id, i.parent_id, i.label, i.link_type, i.url, i.page_identifier, i.open_new_tab, i.visibility,
+       i.icon, i.layout, i.featured_type, i.featured_id
+FROM navigation_items i
+JOIN navigation_menus m ON m.id = i.menu_id
+WHERE m.is_active = 1 AND i.is_active = 1
+ORDER BY m.location, i.sort_order ASC, i.id ASC;
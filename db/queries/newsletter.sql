@@ -0,0 +1,119 @@
+-- ====================================================================
+-- NEWSLETTER SUBSCRIBER QUERIES
+-- ====================================================================
+-- Addresses signed up with the footer newsletter form.
+--
+-- Managed entities:
+-- - newsletter_subscribers: One row per address (unique, case-insensitive)
+--
+-- Lifecycle:
+-- - pending: signed up, confirmation email sent (double opt-in)
+-- - confirmed: followed the confirmation link; handed to the provider
+-- - unsubscribed: followed an unsubscribe link; signing up again restarts
+--   the confirmation
+--
+-- Security notes:
+-- - Confirmation tokens are stored hashed; the plain token is only in the
+--   email
+-- - Unsubscribe tokens are permanent and stored as is, so exports can
+--   include each subscriber's unsubscribe link
+-- ====================================================================
+
+-- name: CreateNewsletterSubscriber :one
+-- Signs up a new address as pending, with the confirmation just sent.
+-- Parameters:
+--   1. email (TEXT): subscriber address, unique ignoring case
+--   2. confirm_token_hash (TEXT): hex SHA-256 of the emailed token
+--   3. unsubscribe_token (TEXT): random token for unsubscribe links
+--   4. source (TEXT): page the form was submitted from
+--   5. ip_address (TEXT): client IP of the sign-up
+INSERT INTO newsletter_subscribers (email, confirm_token_hash, confirm_sent_at, unsubscribe_token, source, ip_address)
+VALUES (?, ?, CURRENT_TIMESTAMP, ?, ?, ?)
+RETURNING *;
+
+-- name: GetNewsletterSubscriberByEmail :one
+-- Looks up an address at sign-up. Matches ignoring case.
+SELECT * FROM newsletter_subscribers WHERE email = ? LIMIT 1;
+
+-- name: RestartNewsletterConfirmation :exec
+-- Sends a pending or unsubscribed address back to pending with a new
+-- confirmation token, when it signs up again.
+-- Parameters:
+--   1. confirm_token_hash (TEXT): hex SHA-256 of the new token
+--   2. source (TEXT): page the form was submitted from
+--   3. id (INTEGER): subscriber ID
+UPDATE newsletter_subscribers
+SET status = 'pending', confirm_token_hash = ?, confirm_sent_at = CURRENT_TIMESTAMP,
+    unsubscribed_at = NULL, source = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: ConfirmNewsletterSubscriber :one
+-- Confirms the pending address of a confirmation link. No row means the
+-- link was used already, replaced by a newer one, or has expired.
+-- Parameters:
+--   @token_hash (TEXT): hex SHA-256 of the token from the link
+--   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp; links sent
+--   earlier have expired
+UPDATE newsletter_subscribers
+SET status = 'confirmed', confirm_token_hash = '', confirmed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE confirm_token_hash = @token_hash AND status = 'pending'
+  AND confirm_sent_at >= CAST(@cutoff AS TEXT)
+RETURNING *;
+
+-- name: GetNewsletterSubscriber :one
+-- Looks up a subscriber by ID (admin actions).
+SELECT * FROM newsletter_subscribers WHERE id = ? LIMIT 1;
+
+-- name: GetNewsletterSubscriberByUnsubscribeToken :one
+-- Looks up the address of an unsubscribe link.
+SELECT * FROM newsletter_subscribers WHERE unsubscribe_token = ? LIMIT 1;
+
+-- name: UnsubscribeNewsletterSubscriber :exec
+-- Unsubscribes an address. Any outstanding confirmation link stops working.
+UPDATE newsletter_subscribers
+SET status = 'unsubscribed', confirm_token_hash = '', unsubscribed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: RecordNewsletterProviderSync :exec
+-- Records the outcome of handing a subscribe or unsubscribe to the
+-- mailing list provider.
+-- Parameters:
+--   1. provider_error (TEXT): why the provider call failed, empty on success
+--   2. id (INTEGER): subscriber ID
+UPDATE newsletter_subscribers
+SET provider_synced_at = CURRENT_TIMESTAMP, provider_error = ?
+WHERE id = ?;
+
+-- name: ListNewsletterSubscribers :many
+-- Lists subscribers for the admin Subscribers page and the CSV export,
+-- newest first.
+-- Parameters:
+--   @filter_status (TEXT): pending, confirmed or unsubscribed (empty = all)
+--   @filter_search (TEXT): part of the address (empty = all)
+--   @page_limit (INTEGER): rows per page
+--   @page_offset (INTEGER): rows to skip
+SELECT * FROM newsletter_subscribers
+WHERE
+    (CASE WHEN @filter_status = '' THEN 1 ELSE status = @filter_status END)
+    AND (CASE WHEN @filter_search = '' THEN 1 ELSE email LIKE '%' || @filter_search || '%' END)
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountNewsletterSubscribers :one
+-- Counts the subscribers ListNewsletterSubscribers pages through.
+-- Parameters: Same filters as ListNewsletterSubscribers
+SELECT COUNT(*) FROM newsletter_subscribers
+WHERE
+    (CASE WHEN @filter_status = '' THEN 1 ELSE status = @filter_status END)
+    AND (CASE WHEN @filter_search = '' THEN 1 ELSE email LIKE '%' || @filter_search || '%' END);
+
+-- name: CountNewsletterSubscribersByStatus :many
+-- Returns the number of addresses in each status, for the Subscribers page
+-- header.
+SELECT status, COUNT(*) AS count FROM newsletter_subscribers GROUP BY status;
+
+-- name: DeleteNewsletterSubscriber :exec
+-- Removes an address entirely (e.g. on an erasure request).
+DELETE FROM newsletter_subscribers WHERE id = ?;
@@ -0,0 +1,48 @@
+-- ====================================================================
+-- 404 TRACKING QUERIES
+-- ====================================================================
+-- Public requests that ended in 404 Not Found, counted per path and
+-- referrer for the broken link report under Admin > 404s.
+--
+-- Managed entities:
+-- - not_found_hits: One row per (path, referrer) pair, with a hit count
+-- ====================================================================
+
+-- name: RecordNotFound :exec
+-- Counts one 404 for a path, from one referrer.
+-- Parameters:
+--   1. path (TEXT): requested path, without the query string
+--   2. referrer (TEXT): Referer header, empty when absent
+INSERT INTO not_found_hits (path, referrer)
+VALUES (?, ?)
+ON CONFLICT(path, referrer) DO UPDATE SET
+    hits = hits + 1,
+    last_seen_at = CURRENT_TIMESTAMP;
+
+-- name: ListNotFoundPaths :many
+-- Lists missing paths, most requested first, with their total hits, the
+-- number of distinct referring pages and the last time one was requested.
+-- last_seen is text: MAX() loses the DATETIME column type.
+SELECT
+    path,
+    CAST(SUM(hits) AS INTEGER) AS hits,
+    CAST(COUNT(CASE WHEN referrer != '' THEN 1 END) AS INTEGER) AS referrers,
+    CAST(MAX(last_seen_at) AS TEXT) AS last_seen
+FROM not_found_hits
+GROUP BY path
+ORDER BY hits DESC, last_seen DESC
+LIMIT ?;
+
+-- name: ListNotFoundReferrers :many
+-- Lists the pages that linked to missing paths, most hits first.
+SELECT path, referrer, hits FROM not_found_hits
+WHERE referrer != ''
+ORDER BY hits DESC, referrer;
+
+-- name: DeleteNotFoundPath :exec
+-- Dismisses a path from the report, e.g. once a redirect covers it.
+DELETE FROM not_found_hits WHERE path = ?;
+
+-- name: DeleteAllNotFound :exec
+-- Clears the report.
+DELETE FROM not_found_hits;
@@ -0,0 +1,84 @@
+-- ====================================================================
+-- PAGE VIEW QUERIES
+-- ====================================================================
+-- First-party analytics: public page views recorded by the PageViews
+-- middleware and reported under Admin > Analytics.
+--
+-- Managed entities:
+-- - page_views: One row per view, until its day is rolled up
+-- - page_view_daily: Views per day, path, referrer, UA class and country
+-- - page_view_counts: View over both, read by the dashboard
+-- ====================================================================
+
+-- name: InsertPageView :exec
+-- Records one page view.
+-- Parameters:
+--   1. path (TEXT): page path, without the query string
+--   2. referrer (TEXT): referring site's host, empty for direct and internal
+--   3. ua_class (TEXT): desktop, mobile or tablet
+--   4. country (TEXT): ISO 3166-1 alpha-2 code, empty when unknown
+INSERT INTO page_views (path, referrer, ua_class, country)
+VALUES (?, ?, ?, ?);
+
+-- name: RollupPageViews :execrows
+-- Adds the views recorded before cutoff to their day's counts. Run with
+-- DeletePageViewsBefore in one transaction, so no view is counted twice.
+-- Parameters:
+--   1. cutoff (TEXT): start of the first day kept raw, YYYY-MM-DD (UTC)
+INSERT INTO page_view_daily (day, path, referrer, ua_class, country, views)
+SELECT date(viewed_at), path, referrer, ua_class, country, COUNT(*)
+FROM page_views
+WHERE viewed_at < CAST(@cutoff AS TEXT)
+GROUP BY date(viewed_at), path, referrer, ua_class, country
+ON CONFLICT(day, path, referrer, ua_class, country) DO UPDATE SET
+    views = views + excluded.views;
+
+-- name: DeletePageViewsBefore :execrows
+-- Deletes the views recorded before cutoff once they are rolled up.
+DELETE FROM page_views WHERE viewed_at < CAST(@cutoff AS TEXT);
+
+-- name: PageViewTrend :many
+-- Views per day from the first day on, oldest first. Days without views
+-- are missing; the dashboard fills them in.
+SELECT day, CAST(SUM(views) AS INTEGER) AS views
+FROM page_view_counts
+WHERE day >= ?1
+GROUP BY day
+ORDER BY day;
+
+-- name: TopPages :many
+-- The most viewed paths from the first day on.
+SELECT path, CAST(SUM(views) AS INTEGER) AS views
+FROM page_view_counts
+WHERE day >= ?1
+GROUP BY path
+ORDER BY views DESC, path
+LIMIT ?2;
+
+-- name: TopReferrers :many
+-- The referring sites sending the most views from the first day on,
+-- with an empty referrer for direct visits.
+SELECT referrer, CAST(SUM(views) AS INTEGER) AS views
+FROM page_view_counts
+WHERE day >= ?1
+GROUP BY referrer
+ORDER BY views DESC, referrer
+LIMIT ?2;
+
+-- name: TopCountries :many
+-- The countries with the most views from the first day on, with an empty
+-- country for views whose country is unknown.
+SELECT country, CAST(SUM(views) AS INTEGER) AS views
+FROM page_view_counts
+WHERE day >= ?1
+GROUP BY country
+ORDER BY views DESC, country
+LIMIT ?2;
+
+-- name: PageViewsByUAClass :many
+-- Views per user agent class from the first day on.
+SELECT ua_class, CAST(SUM(views) AS INTEGER) AS views
+FROM page_view_counts
+WHERE day >= ?1
+GROUP BY ua_class
+ORDER BY views DESC, ua_class;
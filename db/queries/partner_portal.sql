@@ -0,0 +1,130 @@
+-- ====================================================================
+-- PARTNER PORTAL QUERIES
+-- ====================================================================
+-- Self-service updates from partner organizations.
+--
+-- Managed entities:
+-- - partner_invites: Tokenized links to /partner-portal/<token>
+-- - partner_submissions: Logo, description, website and tier evidence
+--   sent through a link, waiting for admin review
+--
+-- Key concepts:
+-- - Only the SHA-256 of a link token is stored
+-- - A partner has at most one pending submission; a newer one supersedes
+--   it
+-- - Approving copies a submission onto partners (ApplyPartnerSubmission);
+--   nothing a partner sends is public before that
+-- ====================================================================
+
+-- name: CreatePartnerInvite :one
+-- Stores a portal invite for a partner.
+-- Parameters:
+--   @partner_id (INTEGER): partner the link edits
+--   @email (TEXT): address the link was sent to, may be empty
+--   @token_hash (TEXT): hex SHA-256 of the link token
+--   @expires_at (TEXT): UTC "2006-01-02 15:04:05" expiry
+INSERT INTO partner_invites (partner_id, email, token_hash, expires_at)
+VALUES (@partner_id, @email, @token_hash, CAST(@expires_at AS TEXT))
+RETURNING *;
+
+-- name: GetActivePartnerInvite :one
+-- Looks up an unexpired, unrevoked invite by token hash.
+-- Parameters:
+--   @token_hash (TEXT): hex SHA-256 of the token from the link
+--   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+SELECT * FROM partner_invites
+WHERE token_hash = @token_hash AND revoked_at IS NULL AND expires_at > CAST(@now AS TEXT)
+LIMIT 1;
+
+-- name: GetPartnerInvite :one
+-- Loads an invite for the admin.
+SELECT * FROM partner_invites WHERE id = ?;
+
+-- name: ListPartnerInvites :many
+-- Lists the invites of a partner, newest first, for its portal page.
+SELECT * FROM partner_invites
+WHERE partner_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokePartnerInvite :exec
+-- Disables an invite link. Revoking twice keeps the first date.
+UPDATE partner_invites SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = ? AND revoked_at IS NULL;
+
+-- name: TouchPartnerInvite :exec
+-- Records that an invite link was used to send a submission.
+UPDATE partner_invites SET last_used_at = CURRENT_TIMESTAMP WHERE id = ?;
+
+-- name: CreatePartnerSubmission :one
+-- Stores a pending submission from the partner portal.
+-- Parameters (9 positional): partner_id, invite_id, logo_url, description,
+-- website_url, requested_tier_id, tier_evidence, contact_name, ip_address
+INSERT INTO partner_submissions (
+    partner_id, invite_id, logo_url, description, website_url,
+    requested_tier_id, tier_evidence, contact_name, ip_address
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: SupersedePendingPartnerSubmissions :exec
+-- Retires the pending submission of a partner before a newer one is
+-- stored, so the queue holds at most one per partner.
+UPDATE partner_submissions SET status = 'superseded'
+WHERE partner_id = ? AND status = 'pending';
+
+-- name: GetPartnerSubmission :one
+-- Loads a submission for review.
+SELECT * FROM partner_submissions WHERE id = ?;
+
+-- name: GetPendingPartnerSubmission :one
+-- Loads the submission of a partner that awaits review, which the portal
+-- form starts from.
+SELECT * FROM partner_submissions
+WHERE partner_id = ? AND status = 'pending'
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListPartnerSubmissions :many
+-- Lists submissions for the admin queue and a partner's portal page.
+-- Parameters:
+--   @filter_status (TEXT): status to show, '' for all
+--   @filter_partner_id (INTEGER): partner to show, 0 for all
+SELECT s.id, s.partner_id, p.name AS partner_name, s.contact_name, s.status,
+       s.created_at, s.reviewed_at,
+       t.name AS current_tier_name,
+       COALESCE(rt.name, '') AS requested_tier_name
+FROM partner_submissions s
+JOIN partners p ON p.id = s.partner_id
+JOIN partner_tiers t ON t.id = p.tier_id
+LEFT JOIN partner_tiers rt ON rt.id = s.requested_tier_id
+WHERE (CASE WHEN @filter_status = '' THEN 1 ELSE s.status = @filter_status END)
+  AND (CASE WHEN @filter_partner_id = 0 THEN 1 ELSE s.partner_id = @filter_partner_id END)
+ORDER BY s.created_at DESC, s.id DESC;
+
+-- name: CountPendingPartnerSubmissions :one
+-- Counts the submissions awaiting review, for the admin sidebar.
+SELECT COUNT(*) FROM partner_submissions WHERE status = 'pending';
+
+-- name: ReviewPartnerSubmission :exec
+-- Records the decision on a submission.
+-- Parameters (3 positional): status ('approved' or 'rejected'),
+-- review_notes, id
+UPDATE partner_submissions
+SET status = ?, review_notes = ?, reviewed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: ApplyPartnerSubmission :exec
+-- Copies an approved submission onto the live partner. An empty logo keeps
+-- the current one and a NULL tier keeps the current tier.
+-- Parameters:
+--   @logo_url (TEXT): new logo path, '' to keep
+--   @description (TEXT): new description
+--   @website_url (TEXT): new website
+--   @requested_tier_id (INTEGER, nullable): new tier
+--   @id (INTEGER): partner ID
+UPDATE partners
+SET logo_url = CASE WHEN @logo_url = '' THEN logo_url ELSE @logo_url END,
+    description = NULLIF(@description, ''),
+    website_url = NULLIF(@website_url, ''),
+    tier_id = COALESCE(@requested_tier_id, tier_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
@@ -0,0 +1,133 @@
+-- ====================================================================
+-- PRESS QUERIES
+-- ====================================================================
+-- The newsroom on /press: press releases, "In the news" links and the
+-- media kit.
+--
+-- Managed entities:
+-- - press_releases: One row per release, with a Markdown body
+-- - press_mentions: Links to coverage by other outlets
+-- - press_kit_assets: Downloadable logos, photos and fact sheets
+--
+-- Key concepts:
+-- - Press content is separate from the blog and never appears in blog
+--   categories, tags or feeds
+-- - released_at is UTC; a published release is embargoed until then.
+--   "Now" is passed in as a UTC "2006-01-02 15:04:05" string so the
+--   comparison matches the stored timestamps
+-- ====================================================================
+
+-- name: CreatePressRelease :one
+-- Creates a press release.
+-- Parameters (8 positional): every column of press_releases except id and
+-- the timestamps, in table order
+INSERT INTO press_releases (
+    title, slug, summary, location, body, released_at, is_published, meta_description
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdatePressRelease :exec
+-- Saves the press release form.
+-- Parameters: Same as CreatePressRelease, then the release ID
+UPDATE press_releases
+SET title = ?, slug = ?, summary = ?, location = ?, body = ?, released_at = ?,
+    is_published = ?, meta_description = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetPressRelease :one
+-- Loads a press release for the admin, published or not.
+SELECT * FROM press_releases WHERE id = ?;
+
+-- name: GetReleasedPressReleaseBySlug :one
+-- Loads a published press release for its public page once its release
+-- time has passed; embargoed releases are not found.
+-- Parameters:
+--   @slug (TEXT): release slug
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+SELECT * FROM press_releases
+WHERE slug = @slug AND is_published = 1 AND released_at <= CAST(@now AS TEXT);
+
+-- name: DeletePressRelease :exec
+-- Deletes a press release.
+DELETE FROM press_releases WHERE id = ?;
+
+-- name: ListPressReleasesAdmin :many
+-- Lists every press release for the admin Press page, latest release
+-- first.
+SELECT * FROM press_releases
+ORDER BY released_at DESC, id DESC;
+
+-- name: ListReleasedPressReleases :many
+-- Lists published press releases whose release time has passed, latest
+-- first.
+-- Parameters:
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+SELECT * FROM press_releases
+WHERE is_published = 1 AND released_at <= CAST(@now AS TEXT)
+ORDER BY released_at DESC, id DESC;
+
+-- name: ListReleasedPressReleaseSlugs :many
+-- Lists the released press releases for the sitemap.
+-- Parameters:
+--   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+SELECT slug, updated_at FROM press_releases
+WHERE is_published = 1 AND released_at <= CAST(@now AS TEXT)
+ORDER BY released_at DESC;
+
+-- name: CreatePressMention :one
+-- Adds an "In the news" link.
+-- Parameters (5 positional): outlet, headline, url, published_on, is_published
+INSERT INTO press_mentions (outlet, headline, url, published_on, is_published)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdatePressMention :exec
+-- Saves the "In the news" link form.
+-- Parameters: Same as CreatePressMention, then the link ID
+UPDATE press_mentions
+SET outlet = ?, headline = ?, url = ?, published_on = ?, is_published = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetPressMention :one
+-- Loads an "In the news" link for the admin.
+SELECT * FROM press_mentions WHERE id = ?;
+
+-- name: DeletePressMention :exec
+-- Deletes an "In the news" link.
+DELETE FROM press_mentions WHERE id = ?;
+
+-- name: ListPressMentionsAdmin :many
+-- Lists every "In the news" link for the admin, newest first.
+SELECT * FROM press_mentions
+ORDER BY published_on DESC, id DESC;
+
+-- name: ListPublishedPressMentions :many
+-- Lists the newest shown "In the news" links for /press.
+-- Parameters:
+--   1. limit (INTEGER): how many to list
+SELECT * FROM press_mentions
+WHERE is_published = 1
+ORDER BY published_on DESC, id DESC
+LIMIT ?;
+
+-- name: CreatePressKitAsset :one
+-- Adds an uploaded file to the media kit.
+-- Parameters (5 positional): title, description, file_path, file_size,
+-- sort_order
+INSERT INTO press_kit_assets (title, description, file_path, file_size, sort_order)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetPressKitAsset :one
+-- Loads a media kit file.
+SELECT * FROM press_kit_assets WHERE id = ?;
+
+-- name: DeletePressKitAsset :exec
+-- Removes a file from the media kit; the caller deletes the upload.
+DELETE FROM press_kit_assets WHERE id = ?;
+
+-- name: ListPressKitAssets :many
+-- Lists the media kit in display order, for /press and the admin.
+SELECT * FROM press_kit_assets
+ORDER BY sort_order, id;
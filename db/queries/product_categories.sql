@@ -27,6 +27,24 @@
 -- Use case: Category navigation menu, product filters, admin category listing
 SELECT * FROM product_categories ORDER BY sort_order ASC, name ASC;
 
+-- name: ListProductCategoriesWithCounts :many
+-- Retrieves all product categories, in ListProductCategories order, each with
+-- the live count of its published products.
+--
+-- Parameters: none
+-- Returns: []ListProductCategoriesWithCountsRow - Category record and
+--   published_count (products directly in the category, not its children)
+--
+-- Note: One grouped LEFT JOIN instead of CountProductsByCategory per category;
+--   categories without products get 0. The stored product_count column is
+--   not maintained and is not used for this.
+-- Use case: Public product catalog category tree, footer category links
+SELECT sqlc.embed(pc), COUNT(p.id) AS published_count
+FROM product_categories pc
+LEFT JOIN products p ON p.category_id = pc.id AND p.status = 'published' AND p.deleted_at IS NULL
+GROUP BY pc.id
+ORDER BY pc.sort_order ASC, pc.name ASC;
+
 -- name: GetProductCategory :one
 -- Retrieves a single product category by its primary key ID.
 --
@@ -92,3 +110,24 @@ UPDATE product_categories SET name = ?, slug = ?, description = ?, icon = ?, ima
 -- WARNING: Will fail if products reference this category (foreign key constraint)
 -- Note: Reassign or delete products in this category before deletion
 DELETE FROM product_categories WHERE id = ?;
+
+-- name: SetProductCategoryParent :exec
+-- Moves a category under another category, or to the top level.
+--
+-- Parameters:
+--   @parent_id (INTEGER) - Parent category ID (NULL for a top-level family)
+--   @id (INTEGER) - Category ID to move
+-- Returns: (none)
+--
+-- Note: Depth and cycle checks happen in services.ValidateCategoryParent
+UPDATE product_categories SET parent_id = @parent_id, updated_at = CURRENT_TIMESTAMP WHERE id = @id;
+
+-- name: CountChildProductCategories :one
+-- Returns the number of direct subcategories of a category.
+--
+-- Parameters:
+--   $1 (INTEGER) - parent category ID
+-- Returns: INTEGER - Number of child categories
+--
+-- Use case: Blocking deletion of categories that still have subcategories
+SELECT COUNT(*) FROM product_categories WHERE parent_id = ?;
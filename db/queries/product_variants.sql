@@ -0,0 +1,106 @@
+-- ====================================================================
+-- PRODUCT VARIANTS QUERIES
+-- ====================================================================
+-- Variants are versions of a product (voltages, sizes) with their own SKU
+-- shown on the product's page through a variant selector.
+--
+-- Managed entities:
+-- - product_variants: One row per variant, SKU unique across variants
+-- - product_variant_specs: Spec overrides, matched to product specs by
+--   section_name + spec_key
+-- - product_variant_images: Variant gallery, replacing the product gallery
+--
+-- Security notes:
+-- - Variant queries taking a product_id are scoped to it so a variant can
+--   only be edited through the product it belongs to
+-- ====================================================================
+
+-- name: ListProductVariants :many
+-- Lists a product's variants in selector order.
+-- Parameters:
+--   1. product_id (INTEGER): parent product
+SELECT * FROM product_variants
+WHERE product_id = ?
+ORDER BY display_order ASC, id ASC;
+
+-- name: GetProductVariant :one
+-- Fetches a variant of a product by ID.
+-- Parameters:
+--   1. id (INTEGER): variant ID
+--   2. product_id (INTEGER): parent product
+SELECT * FROM product_variants WHERE id = ? AND product_id = ? LIMIT 1;
+
+-- name: GetProductVariantBySKU :one
+-- Fetches a variant of a product by SKU, for ?variant= on the product page.
+-- Parameters:
+--   1. product_id (INTEGER): parent product
+--   2. sku (TEXT): variant SKU
+SELECT * FROM product_variants WHERE product_id = ? AND sku = ? LIMIT 1;
+
+-- name: CreateProductVariant :one
+-- Adds a variant to a product.
+-- Parameters:
+--   1. product_id (INTEGER): parent product
+--   2. sku (TEXT): unique variant SKU
+--   3. name (TEXT): selector label, e.g. "230V AC"
+--   4. display_order (INTEGER): position in the selector
+INSERT INTO product_variants (product_id, sku, name, display_order)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateProductVariant :execrows
+-- Updates a variant's SKU, name and order. Zero rows means the variant does
+-- not belong to the product.
+UPDATE product_variants
+SET sku = ?, name = ?, display_order = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ? AND product_id = ?;
+
+-- name: DeleteProductVariant :exec
+-- Deletes a variant; its spec overrides and images cascade.
+DELETE FROM product_variants WHERE id = ? AND product_id = ?;
+
+-- name: ListProductVariantSpecs :many
+-- Lists a variant's spec overrides in display order.
+SELECT * FROM product_variant_specs
+WHERE variant_id = ?
+ORDER BY display_order ASC, id ASC;
+
+-- name: UpsertProductVariantSpec :one
+-- Sets a variant's value for a section + label, replacing an earlier
+-- override of the same spec.
+-- Parameters:
+--   1. variant_id (INTEGER): variant
+--   2. section_name (TEXT): product spec section to override or extend
+--   3. spec_key (TEXT): spec label within the section
+--   4. spec_value (TEXT): variant value
+--   5. display_order (INTEGER): position when the spec is new to the product
+INSERT INTO product_variant_specs (variant_id, section_name, spec_key, spec_value, display_order)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (variant_id, section_name, spec_key)
+DO UPDATE SET spec_value = excluded.spec_value, display_order = excluded.display_order
+RETURNING *;
+
+-- name: DeleteProductVariantSpec :exec
+-- Removes one spec override, restoring the product's value.
+DELETE FROM product_variant_specs WHERE id = ? AND variant_id = ?;
+
+-- name: ListProductVariantImages :many
+-- Lists a variant's gallery images in display order.
+SELECT * FROM product_variant_images
+WHERE variant_id = ?
+ORDER BY display_order ASC, id ASC;
+
+-- name: CreateProductVariantImage :one
+-- Adds a gallery image to a variant.
+-- Parameters:
+--   1. variant_id (INTEGER): variant
+--   2. image_path (TEXT): stored upload path
+--   3. alt_text (TEXT): accessibility text
+--   4. display_order (INTEGER): position in the gallery
+INSERT INTO product_variant_images (variant_id, image_path, alt_text, display_order)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: DeleteProductVariantImage :exec
+-- Removes one image from a variant's gallery.
+DELETE FROM product_variant_images WHERE id = ? AND variant_id = ?;
@@ -74,7 +74,7 @@ SELECT * FROM products WHERE id = ? LIMIT 1;
 --
 -- Use case: Frontend product detail page, preview mode
 -- Note: Does NOT filter by status; application should check status before displaying
-SELECT * FROM products WHERE slug = ? LIMIT 1;
+SELECT * FROM products WHERE slug = ? AND deleted_at IS NULL LIMIT 1;
 
 -- name: GetProductBySKU :one
 -- Retrieves a single product by its SKU/product code.
@@ -100,9 +100,24 @@ SELECT * FROM products WHERE sku = ? LIMIT 1;
 SELECT p.slug, pc.slug AS category_slug, p.updated_at
 FROM products p
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE p.status = 'published'
+WHERE p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY p.updated_at DESC;
 
+-- name: ListProductsForIndex :many
+-- Retrieves every published product with its category for the A–Z index.
+--
+-- Returns: []ListProductsForIndexRow - name, slug, sku, tagline, category name and slug
+--
+-- Sorting: name COLLATE NOCASE so "acme" and "Acme" file under the same letter;
+-- id breaks ties between products with the same name
+--
+-- Use case: /products/a-z index page (grouped by first letter in Go)
+SELECT p.name, p.slug, p.sku, p.tagline, pc.name AS category_name, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+ORDER BY p.name COLLATE NOCASE ASC, p.id ASC;
+
 -- name: ListProducts :many
 -- Retrieves paginated published products with featured products first.
 --
@@ -121,7 +136,7 @@ ORDER BY p.updated_at DESC;
 --
 -- Use case: Main products catalog page, products listing
 SELECT * FROM products
-WHERE status = 'published'
+WHERE status = 'published' AND deleted_at IS NULL
 ORDER BY
     CASE WHEN is_featured = 1 THEN featured_order ELSE 999999 END ASC,
     published_at DESC
@@ -144,7 +159,7 @@ LIMIT ? OFFSET ?;
 --
 -- Use case: Category-specific product listing pages
 SELECT * FROM products
-WHERE category_id = ? AND status = 'published'
+WHERE category_id = ? AND status = 'published' AND deleted_at IS NULL
 ORDER BY
     CASE WHEN is_featured = 1 THEN featured_order ELSE 999999 END ASC,
     published_at DESC
@@ -172,7 +187,7 @@ LIMIT ? OFFSET ?;
 SELECT p.*, pc.slug AS category_slug
 FROM products p
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE p.is_featured = 1 AND p.status = 'published'
+WHERE p.is_featured = 1 AND p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY p.featured_order ASC
 LIMIT ?;
 
@@ -184,7 +199,7 @@ LIMIT ?;
 --
 -- Filtering: status = 'published' - Only counts public products
 -- Use case: Pagination calculations, site statistics
-SELECT COUNT(*) FROM products WHERE status = 'published';
+SELECT COUNT(*) FROM products WHERE status = 'published' AND deleted_at IS NULL;
 
 -- name: CountProductsByCategory :one
 -- Returns the count of published products in a specific category.
@@ -194,7 +209,7 @@ SELECT COUNT(*) FROM products WHERE status = 'published';
 -- Returns: INTEGER - Number of published products in the category
 --
 -- Use case: Category page pagination, category statistics
-SELECT COUNT(*) FROM products WHERE category_id = ? AND status = 'published';
+SELECT COUNT(*) FROM products WHERE category_id = ? AND status = 'published' AND deleted_at IS NULL;
 
 -- name: SearchProducts :many
 -- Searches published products by name, description, or tagline.
@@ -216,11 +231,36 @@ SELECT COUNT(*) FROM products WHERE category_id = ? AND status = 'published';
 SELECT p.*, pc.slug AS category_slug
 FROM products p
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE p.status = 'published'
+WHERE p.status = 'published' AND p.deleted_at IS NULL
     AND (p.name LIKE ? OR p.description LIKE ? OR p.tagline LIKE ?)
 ORDER BY p.published_at DESC
 LIMIT ? OFFSET ?;
 
+-- name: SearchProductsSorted :many
+-- Searches published products by name, description, or tagline, like
+-- SearchProducts, in the requested sort order.
+--
+-- Parameters:
+--   @pattern (TEXT) - LIKE pattern matched against name, description and tagline
+--   @sort (TEXT) - "name_asc", "name_desc", "newest", "oldest" or "sku"
+--   @page_limit (INTEGER) - Maximum results
+-- Returns: []SearchProductsSortedRow - Matching products with category_slug
+--
+-- Sorting: Same CASE pattern as ListAllProductsInCategoryTree
+SELECT p.*, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+    AND (p.name LIKE @pattern OR p.description LIKE @pattern OR p.tagline LIKE @pattern)
+ORDER BY
+    CASE WHEN @sort = 'name_asc' THEN p.name COLLATE NOCASE END ASC,
+    CASE WHEN @sort = 'name_desc' THEN p.name COLLATE NOCASE END DESC,
+    CASE WHEN @sort = 'newest' THEN COALESCE(p.published_at, p.created_at) END DESC,
+    CASE WHEN @sort = 'oldest' THEN COALESCE(p.published_at, p.created_at) END ASC,
+    CASE WHEN @sort = 'sku' THEN p.sku COLLATE NOCASE END ASC,
+    p.id ASC
+LIMIT @page_limit;
+
 -- name: UpdateProduct :exec
 -- Updates all core fields of an existing product.
 --
@@ -249,6 +289,19 @@ WHERE id = ?;
 -- Alternative: Set status='archived' for soft delete instead
 DELETE FROM products WHERE id = ?;
 
+-- name: SetProductFeatured :execrows
+-- Features or unfeatures a product and sets its homepage position, from the
+-- admin product list.
+--
+-- Parameters:
+--   @is_featured (BOOLEAN) - whether the product is featured
+--   @featured_order (INTEGER) - position among featured products (NULL for none)
+--   @id (INTEGER) - product ID
+-- Returns: number of updated rows (0 when the product is missing or trashed)
+UPDATE products
+SET is_featured = @is_featured, featured_order = @featured_order, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND deleted_at IS NULL;
+
 -- ====================================================================
 -- PRODUCTS - ADMIN QUERIES
 -- ====================================================================
@@ -263,6 +316,7 @@ DELETE FROM products WHERE id = ?;
 -- Use case: Admin product management dashboard
 -- Note: No status filtering - shows published, draft, and archived products
 SELECT * FROM products
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: ListProductsAdminFiltered :many
@@ -287,8 +341,8 @@ ORDER BY created_at DESC;
 -- Sorting: p.created_at DESC - Newest products first
 -- Use case: Admin product listing with filter dropdowns and search bar
 SELECT p.* FROM products p
-WHERE
-    (CASE WHEN @filter_status = '' THEN 1 ELSE p.status = @filter_status END)
+WHERE p.deleted_at IS NULL
+    AND (CASE WHEN @filter_status = '' THEN 1 ELSE p.status = @filter_status END)
     AND (CASE WHEN @filter_category = 0 THEN 1 ELSE p.category_id = @filter_category END)
     AND (CASE WHEN @filter_search = '' THEN 1 ELSE (p.name LIKE '%' || @filter_search || '%' OR p.sku LIKE '%' || @filter_search || '%') END)
 ORDER BY p.created_at DESC
@@ -303,8 +357,8 @@ LIMIT @page_limit OFFSET @page_offset;
 -- Note: Uses identical WHERE clause as ListProductsAdminFiltered for consistent counts
 -- Use case: Calculating total pages for filtered admin product listing
 SELECT COUNT(*) FROM products p
-WHERE
-    (CASE WHEN @filter_status = '' THEN 1 ELSE p.status = @filter_status END)
+WHERE p.deleted_at IS NULL
+    AND (CASE WHEN @filter_status = '' THEN 1 ELSE p.status = @filter_status END)
     AND (CASE WHEN @filter_category = 0 THEN 1 ELSE p.category_id = @filter_category END)
     AND (CASE WHEN @filter_search = '' THEN 1 ELSE (p.name LIKE '%' || @filter_search || '%' OR p.sku LIKE '%' || @filter_search || '%') END);
 
@@ -370,7 +424,7 @@ DELETE FROM product_specs WHERE id = ?;
 -- ====================================================================
 
 -- name: CreateProductImage :one
--- Adds a gallery image to a product.
+-- Adds a gallery image, video or 3D/CAD file to a product.
 --
 -- Parameters:
 --   $1 (INTEGER) - product_id: Foreign key to parent product
@@ -379,13 +433,15 @@ DELETE FROM product_specs WHERE id = ?;
 --   $4 (TEXT) - caption: Optional image caption for display
 --   $5 (INTEGER) - display_order: Position in image gallery
 --   $6 (BOOLEAN) - is_thumbnail: Whether this image is the thumbnail (usually first image)
+--   $7 (TEXT) - media_type: 'image', 'video' or 'model'; '' means 'image'
+--   $8 (TEXT) - media_url: Video player URL or uploaded video/3D file, '' for images
 --
 -- Returns: ProductImage - The newly created image record with auto-generated ID
 --
 -- Use case: Building product image gallery during product creation/editing
 -- Note: Typically only one image should have is_thumbnail=1 per product
-INSERT INTO product_images (product_id, image_path, alt_text, caption, display_order, is_thumbnail)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO product_images (product_id, image_path, alt_text, caption, display_order, is_thumbnail, media_type, media_url)
+VALUES (?, ?, ?, ?, ?, ?, COALESCE(NULLIF(?, ''), 'image'), ?)
 RETURNING *;
 
 -- name: ListProductImages :many
@@ -550,13 +606,16 @@ DELETE FROM product_certifications WHERE id = ?;
 --   $6 (INTEGER) - file_size: File size in bytes
 --   $7 (TEXT) - version: Document/file version (e.g., "v2.1", "Rev A")
 --   $8 (INTEGER) - display_order: Position in downloads list
+--   $9 (TEXT) - changelog: What changed in this version ('' for none)
+--   $10 (INTEGER) - supersedes_id: The previous version of this file (NULL for a new file)
+--   $11 (INTEGER) - is_gated: 1 when the file is only handed out after the lead form
 --
 -- Returns: ProductDownload - The newly created download with auto-generated ID
 --
 -- Use case: Adding technical documents during product creation/editing
 -- Note: download_count initializes to 0 via database schema default
-INSERT INTO product_downloads (product_id, title, description, file_type, file_path, file_size, version, display_order)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO product_downloads (product_id, title, description, file_type, file_path, file_size, version, display_order, changelog, supersedes_id, is_gated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetProductDownload :one
@@ -576,11 +635,11 @@ SELECT * FROM product_downloads WHERE id = ? LIMIT 1;
 --   $1 (INTEGER) - product_id: Product to fetch downloads for
 -- Returns: []ProductDownload - Array of downloads ordered by display_order
 --
--- Sorting: display_order ASC - Downloads appear in admin-configured order
+-- Sorting: display_order ASC, id ASC - Downloads appear in admin-configured order, then as added
 -- Use case: Displaying downloads list on product detail page
 SELECT * FROM product_downloads
 WHERE product_id = ?
-ORDER BY display_order ASC;
+ORDER BY display_order ASC, id ASC;
 
 -- name: IncrementDownloadCount :exec
 -- Increments the download counter for analytics tracking.
@@ -595,6 +654,44 @@ UPDATE product_downloads
 SET download_count = download_count + 1
 WHERE id = ?;
 
+-- name: GetPublishedProductDownload :one
+-- Returns a download when its product is published, with the product's URL
+-- slugs for sending visitors back to the product page.
+--
+-- Parameters:
+--   $1 (INTEGER) - download ID
+-- Returns: GetPublishedProductDownloadRow, or sql.ErrNoRows for a draft,
+-- archived or trashed product
+--
+-- Use case: The public download link, which counts the download and redirects
+-- to the file, and the lead form of gated downloads
+SELECT d.file_path, d.title, d.version, d.is_gated,
+       p.name AS product_name, p.slug AS product_slug, pc.slug AS category_slug
+FROM product_downloads d
+INNER JOIN products p ON p.id = d.product_id
+INNER JOIN product_categories pc ON pc.id = p.category_id
+WHERE d.id = ? AND p.status = 'published' AND p.deleted_at IS NULL;
+
+-- name: CreateProductDownloadLead :one
+-- Records the lead form submitted for a gated product download.
+--
+-- Parameters:
+--   $1 (INTEGER) - product_download_id: Download being requested
+--   $2 (TEXT) - name: Visitor's name
+--   $3 (TEXT) - email: Visitor's email (lead capture)
+--   $4 (TEXT) - company: Visitor's company
+--   $5 (TEXT) - designation: Visitor's job title (optional)
+--   $6 (INTEGER) - marketing_consent: 1 if the visitor opted in to marketing
+--   $7 (TEXT) - ip_address: Visitor's IP address
+--   $8 (TEXT) - user_agent: Visitor's browser user agent
+--
+-- Returns: ProductDownloadLead - The recorded lead
+--
+-- Use case: The gated download form on product pages, before the signed link is issued
+INSERT INTO product_download_leads (product_download_id, name, email, company, designation, marketing_consent, ip_address, user_agent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
 -- name: DeleteProductDownload :exec
 -- Deletes a single product download.
 --
@@ -617,6 +714,30 @@ DELETE FROM product_downloads WHERE id = ?;
 -- WARNING: Deletes ALL downloads for the product; physical files should also be removed
 DELETE FROM product_downloads WHERE product_id = ?;
 
+-- name: GetProductDownloadFileUse :one
+-- Counts the gated and ungated downloads that share one file. Product copies
+-- share their downloads' files, so a file may be used by both.
+--
+-- Parameters:
+--   $1 (TEXT) - file_path: Stored file path (/uploads/downloads/...)
+-- Returns: GetProductDownloadFileUseRow - gated and ungated download counts
+--
+-- Use case: DownloadFiles keeps a file out of the public uploads while only
+-- gated downloads use it
+SELECT CAST(COALESCE(SUM(is_gated = 1), 0) AS INTEGER) AS gated,
+       CAST(COALESCE(SUM(is_gated != 1), 0) AS INTEGER) AS ungated
+FROM product_downloads
+WHERE file_path = ?;
+
+-- name: ListGatedProductDownloadFiles :many
+-- Lists the files of gated downloads, for moving them out of the public
+-- uploads at startup.
+--
+-- Returns: []string - Distinct file paths
+SELECT DISTINCT file_path FROM product_downloads
+WHERE is_gated = 1
+ORDER BY file_path;
+
 -- name: UpdateProductFeature :exec
 UPDATE product_features
 SET feature_text = ?, display_order = ?
@@ -634,10 +755,246 @@ WHERE id = ?;
 
 -- name: UpdateProductDownload :exec
 UPDATE product_downloads
-SET title = ?, description = ?, file_type = ?, version = ?, display_order = ?
+SET title = ?, description = ?, file_type = ?, version = ?, changelog = ?, display_order = ?, is_gated = ?
 WHERE id = ?;
 
 -- name: UpdateProductImage :exec
 UPDATE product_images
 SET alt_text = ?, caption = ?, display_order = ?
 WHERE id = ?;
+
+-- name: ListProductsInCategoryTree :many
+-- Retrieves paginated published products in a category and all of its
+-- subcategories, with each product's own category slug for building URLs.
+--
+-- Parameters:
+--   @category_id (INTEGER) - Root of the category subtree
+--   @limit (INTEGER) - Number of products per page
+--   @offset (INTEGER) - Pagination offset
+-- Returns: []ListProductsInCategoryTreeRow - Products with category_slug
+--
+-- Subtree: the category, its children, and its grandchildren. This covers the
+-- whole hierarchy because depth is capped at services.MaxCategoryDepth (3).
+--
+-- Sorting: Same ordering as ListProductsByCategory (featured first, then by date)
+--
+-- Use case: Family and series pages that roll up products from their children
+SELECT p.*, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = @category_id
+    OR pc.parent_id = @category_id
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = @category_id)
+)
+ORDER BY
+    CASE WHEN p.is_featured = 1 THEN p.featured_order ELSE 999999 END ASC,
+    p.published_at DESC
+LIMIT @limit OFFSET @offset;
+
+-- name: CountProductsInCategoryTree :one
+-- Returns the count of published products in a category and its
+-- subcategories (children and grandchildren, see ListProductsInCategoryTree).
+--
+-- Parameters:
+--   @category_id (INTEGER) - Root of the category subtree
+-- Returns: INTEGER - Number of published products in the subtree
+--
+-- Use case: Rolled-up category page pagination
+SELECT COUNT(*)
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = @category_id
+    OR pc.parent_id = @category_id
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = @category_id)
+);
+
+-- name: ListAllProductsInCategoryTree :many
+-- Retrieves every published product in a category and its subcategories,
+-- in the requested sort order.
+--
+-- Parameters:
+--   @category_id (INTEGER) - Root of the category subtree
+--   @sort (TEXT) - "name_asc", "name_desc", "newest", "oldest" or "sku"
+-- Returns: []ListAllProductsInCategoryTreeRow - Products with category_slug
+--
+-- Sorting: One CASE per sort option; only the matching one yields values,
+-- the others are constant and do not affect the order. The id makes the
+-- order total, so pages never overlap.
+--
+-- Use case: Faceted category pages, which filter in memory and then paginate
+SELECT p.*, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = @category_id
+    OR pc.parent_id = @category_id
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = @category_id)
+)
+ORDER BY
+    CASE WHEN @sort = 'name_asc' THEN p.name COLLATE NOCASE END ASC,
+    CASE WHEN @sort = 'name_desc' THEN p.name COLLATE NOCASE END DESC,
+    CASE WHEN @sort = 'newest' THEN COALESCE(p.published_at, p.created_at) END DESC,
+    CASE WHEN @sort = 'oldest' THEN COALESCE(p.published_at, p.created_at) END ASC,
+    CASE WHEN @sort = 'sku' THEN p.sku COLLATE NOCASE END ASC,
+    p.id ASC;
+
+-- name: ListCategoryTreeSpecFacets :many
+-- Retrieves the spec values of published products in a category subtree,
+-- from which the filter sidebar's spec facets are built.
+--
+-- Parameters:
+--   @category_id (INTEGER) - Root of the category subtree
+-- Returns: []ListCategoryTreeSpecFacetsRow - product_id, section, key and value
+--
+-- Sorting: By section and key in the order they appear on products, then value
+SELECT ps.product_id, ps.section_name, ps.spec_key, ps.spec_value
+FROM product_specs ps
+INNER JOIN products p ON ps.product_id = p.id
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = @category_id
+    OR pc.parent_id = @category_id
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = @category_id)
+)
+ORDER BY ps.display_order ASC, ps.section_name ASC, ps.spec_key ASC, ps.spec_value ASC;
+
+-- name: ListCategoryTreeCertificationFacets :many
+-- Retrieves the certifications of published products in a category subtree
+-- for the certification facet.
+--
+-- Parameters:
+--   @category_id (INTEGER) - Root of the category subtree
+-- Returns: []ListCategoryTreeCertificationFacetsRow - product_id and certification name
+SELECT pcert.product_id, pcert.certification_name
+FROM product_certifications pcert
+INNER JOIN products p ON pcert.product_id = p.id
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = @category_id
+    OR pc.parent_id = @category_id
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = @category_id)
+)
+ORDER BY pcert.certification_name ASC;
+
+-- ====================================================================
+-- PRODUCT EXPORT
+-- ====================================================================
+-- The admin export pages through products by ID so memory stays flat
+-- however many products there are. Trashed products are left out.
+
+-- name: ListProductsForExport :many
+-- Returns the next batch of products after a given ID, with their category.
+--
+-- Parameters:
+--   @after_id (INTEGER) - Last product ID of the previous batch (0 to start)
+--   @batch_size (INTEGER) - Maximum products to return
+-- Returns: []ListProductsForExportRow - Products in ID order with category_slug
+SELECT p.id, p.sku, p.name, p.slug, pc.slug AS category_slug, p.description,
+       p.tagline, p.overview, p.status
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.deleted_at IS NULL AND p.id > @after_id
+ORDER BY p.id ASC
+LIMIT @batch_size;
+
+-- name: ListProductSpecColumnsForExport :many
+-- Returns every distinct spec (section + label) used by a product, one
+-- export column each.
+--
+-- Returns: []ListProductSpecColumnsForExportRow - Sorted by section, then label
+SELECT DISTINCT s.section_name, s.spec_key
+FROM product_specs s
+INNER JOIN products p ON p.id = s.product_id
+WHERE p.deleted_at IS NULL
+ORDER BY s.section_name ASC, s.spec_key ASC;
+
+-- name: ListProductSpecsForExport :many
+-- Returns the specs of the products in an ID range (one export batch).
+--
+-- Parameters:
+--   @first_id (INTEGER) - First product ID of the batch
+--   @last_id (INTEGER) - Last product ID of the batch
+-- Returns: []ListProductSpecsForExportRow
+SELECT product_id, section_name, spec_key, spec_value
+FROM product_specs
+WHERE product_id >= @first_id AND product_id <= @last_id
+ORDER BY product_id ASC, display_order ASC, id ASC;
+
+-- name: ListProductFeaturesForExport :many
+-- Returns the features of the products in an ID range (one export batch).
+--
+-- Parameters:
+--   @first_id (INTEGER) - First product ID of the batch
+--   @last_id (INTEGER) - Last product ID of the batch
+-- Returns: []ListProductFeaturesForExportRow
+SELECT product_id, feature_text
+FROM product_features
+WHERE product_id >= @first_id AND product_id <= @last_id
+ORDER BY product_id ASC, display_order ASC, id ASC;
+
+-- ====================================================================
+-- PUBLIC API (keyset pagination)
+-- ====================================================================
+-- The API pages with a keyset instead of OFFSET: each page starts after the
+-- last row of the previous one, so products published or deleted between
+-- requests never shift rows into (or out of) a page already read. The
+-- product id breaks ties, giving every ordering a strict total order.
+
+-- name: ListPublishedProductsByIDAfter :many
+-- Returns published products with an id greater than @after_id, oldest first.
+--
+-- Parameters:
+--   @category_id (INTEGER) - Only this category (0 for all categories)
+--   @after_id (INTEGER) - id of the last product of the previous page (0 for the first page)
+--   @page_limit (INTEGER) - Maximum rows to return
+-- Returns: []ListPublishedProductsByIDAfterRow
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.primary_image,
+       p.is_featured, p.published_at, p.updated_at, pc.slug AS category_slug, pc.name AS category_name
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+    AND (CASE WHEN @category_id = 0 THEN 1 ELSE p.category_id = @category_id END)
+    AND p.id > @after_id
+ORDER BY p.id ASC
+LIMIT @page_limit;
+
+-- name: ListPublishedProductsByNameAfter :many
+-- Returns published products ordered by name, then id, starting after the
+-- (@after_name, @after_id) key.
+--
+-- Parameters:
+--   @category_id (INTEGER) - Only this category (0 for all categories)
+--   @after_name (TEXT) - Name of the last product of the previous page ("" with @after_id 0 for the first page)
+--   @after_id (INTEGER) - id of the last product of the previous page
+--   @page_limit (INTEGER) - Maximum rows to return
+-- Returns: []ListPublishedProductsByNameAfterRow
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.primary_image,
+       p.is_featured, p.published_at, p.updated_at, pc.slug AS category_slug, pc.name AS category_name
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+    AND (CASE WHEN @category_id = 0 THEN 1 ELSE p.category_id = @category_id END)
+    AND (p.name > @after_name OR (p.name = @after_name AND p.id > @after_id))
+ORDER BY p.name ASC, p.id ASC
+LIMIT @page_limit;
+
+-- ====================================================================
+-- RECENTLY VIEWED
+-- ====================================================================
+
+-- name: GetPublishedProductCard :one
+-- Retrieves the fields of a published product needed for a small product
+-- card, with its category slug for the URL.
+--
+-- Parameters:
+--   $1 (INTEGER) - product ID
+-- Returns: GetPublishedProductCardRow, or sql.ErrNoRows for missing, draft,
+-- archived and trashed products
+--
+-- Use case: The "Recently viewed" widget, whose product IDs come from a cookie
+SELECT p.id, p.slug, p.name, p.tagline, p.primary_image, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.id = ? AND p.status = 'published' AND p.deleted_at IS NULL;
@@ -0,0 +1,18 @@
+-- ====================================================================
+-- PUBLISH CHECKLIST QUERIES
+-- ====================================================================
+
+-- name: LinkTargetPublished :one
+-- Reports whether a public detail page exists for a slug, i.e. whether a
+-- link to it from published content works.
+-- Parameters (named):
+--   1. content_type (TEXT): 'product', 'blog_post', 'solution', 'case_study' or 'whitepaper'
+--   2. slug (TEXT): slug from the link
+SELECT CAST(CASE CAST(sqlc.arg(content_type) AS TEXT)
+    WHEN 'product' THEN EXISTS (SELECT 1 FROM products WHERE slug = sqlc.arg(slug) AND status = 'published' AND deleted_at IS NULL)
+    WHEN 'blog_post' THEN EXISTS (SELECT 1 FROM blog_posts WHERE slug = sqlc.arg(slug) AND status = 'published' AND deleted_at IS NULL)
+    WHEN 'solution' THEN EXISTS (SELECT 1 FROM solutions WHERE slug = sqlc.arg(slug) AND is_published = 1 AND deleted_at IS NULL)
+    WHEN 'case_study' THEN EXISTS (SELECT 1 FROM case_studies WHERE slug = sqlc.arg(slug) AND is_published = 1 AND deleted_at IS NULL)
+    WHEN 'whitepaper' THEN EXISTS (SELECT 1 FROM whitepapers WHERE slug = sqlc.arg(slug) AND is_published = 1)
+    ELSE 0
+END AS INTEGER) AS published;
@@ -0,0 +1,76 @@
+-- ====================================================================
+-- REDIRECT QUERIES
+-- ====================================================================
+-- Redirect rules served by the public Redirects middleware. Automatic
+-- rules are recorded when a slug changes; manual rules and wildcard
+-- patterns are managed under Admin > Redirects.
+--
+-- Managed entities:
+-- - redirects: One rule per source path, with hit statistics
+-- ====================================================================
+
+-- name: ListRedirects :many
+-- Lists every rule, ordered by source path.
+SELECT * FROM redirects ORDER BY source_path;
+
+-- name: GetRedirect :one
+-- Returns one rule (sql.ErrNoRows if it does not exist).
+SELECT * FROM redirects WHERE id = ?;
+
+-- name: CreateRedirect :one
+-- Adds a manual rule.
+-- Parameters:
+--   1. source_path (TEXT): path to redirect, may end in "*"
+--   2. target_path (TEXT): path or absolute URL to redirect to
+--   3. status_code (INTEGER): 301 or 302
+INSERT INTO redirects (source_path, target_path, status_code)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: UpdateRedirect :exec
+-- Edits a rule; an edited automatic rule becomes a manual one.
+-- Parameters:
+--   1. source_path (TEXT): path to redirect, may end in "*"
+--   2. target_path (TEXT): path or absolute URL to redirect to
+--   3. status_code (INTEGER): 301 or 302
+--   4. id (INTEGER): rule ID
+UPDATE redirects
+SET source_path = ?, target_path = ?, status_code = ?, is_automatic = 0, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteRedirect :exec
+-- Removes a rule.
+DELETE FROM redirects WHERE id = ?;
+
+-- name: SaveAutomaticRedirect :exec
+-- Records a permanent redirect from a changed slug's old path, replacing
+-- any rule for that path.
+-- Parameters:
+--   1. source_path (TEXT): old public path
+--   2. target_path (TEXT): new public path
+INSERT INTO redirects (source_path, target_path, status_code, is_automatic)
+VALUES (?, ?, 301, 1)
+ON CONFLICT(source_path) DO UPDATE SET
+    target_path = excluded.target_path,
+    status_code = 301,
+    is_automatic = 1,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteRedirectBySource :exec
+-- Removes the rule for a path that is live again, so it does not shadow
+-- the page now published there.
+DELETE FROM redirects WHERE source_path = ?;
+
+-- name: RetargetRedirects :exec
+-- Points rules that led to a path which has moved at its new path, so
+-- visitors get one redirect instead of a chain.
+-- Parameters:
+--   @new_target (TEXT): new path
+--   @old_target (TEXT): path that moved
+UPDATE redirects
+SET target_path = @new_target, updated_at = CURRENT_TIMESTAMP
+WHERE target_path = @old_target;
+
+-- name: RecordRedirectHit :exec
+-- Counts one use of a rule.
+UPDATE redirects SET hits = hits + 1, last_hit_at = CURRENT_TIMESTAMP WHERE id = ?;
@@ -0,0 +1,177 @@
+-- ====================================================================
+-- RELATED CONTENT QUERIES
+-- ====================================================================
+-- Recommendations of case studies, blog posts and whitepapers for a
+-- product page. The candidate queries return match signals that
+-- services.RelatedContentService weighs in Go; admins can also pin items
+-- to a product by hand.
+--
+-- Managed entity:
+-- - related_content_pins: Manual picks per product, shown before the
+--   scored recommendations
+--
+-- Security notes:
+-- - Pin queries taking a product_id are scoped to it so a pin can only be
+--   removed through the product it belongs to
+-- ====================================================================
+
+-- name: ListRelatedCaseStudyCandidates :many
+-- Candidate case studies for a product's related content.
+-- Parameters (named):
+--   1. product_id (INTEGER): product being viewed
+--   2. category_id (INTEGER): the product's category
+--   3. candidate_limit (INTEGER): max candidates to score (pins and strongest matches first)
+-- Match signals (0 or 1):
+--   - features_product: the case study lists the product
+--   - shares_category: the case study lists another product of the same category
+--   - shares_industry: same industry as a case study that lists the product
+--   - pinned, pin_order: an admin pin for this product
+-- Outer WHERE: a candidate needs a pin or at least one signal
+SELECT * FROM (
+    SELECT
+        cs.id, cs.slug, cs.title, cs.client_name, cs.summary, cs.hero_image_url,
+        i.name AS industry_name, cs.display_order,
+        CAST(EXISTS (
+            SELECT 1 FROM case_study_products csp
+            WHERE csp.case_study_id = cs.id AND csp.product_id = @product_id
+        ) AS INTEGER) AS features_product,
+        CAST(EXISTS (
+            SELECT 1 FROM case_study_products csp
+            INNER JOIN products p ON p.id = csp.product_id
+            WHERE csp.case_study_id = cs.id AND p.category_id = @category_id AND p.id != @product_id
+        ) AS INTEGER) AS shares_category,
+        CAST(cs.industry_id IN (
+            SELECT own.industry_id FROM case_studies own
+            INNER JOIN case_study_products csp ON csp.case_study_id = own.id
+            WHERE csp.product_id = @product_id AND own.is_published = 1 AND own.deleted_at IS NULL
+        ) AS INTEGER) AS shares_industry,
+        CAST(pin.id IS NOT NULL AS INTEGER) AS pinned,
+        CAST(COALESCE(pin.display_order, 0) AS INTEGER) AS pin_order
+    FROM case_studies cs
+    INNER JOIN industries i ON cs.industry_id = i.id
+    LEFT JOIN related_content_pins pin ON pin.product_id = @product_id
+        AND pin.content_type = 'case_study' AND pin.content_id = cs.id
+    WHERE cs.is_published = 1 AND cs.deleted_at IS NULL
+) candidates
+WHERE pinned = 1 OR features_product = 1 OR shares_category = 1 OR shares_industry = 1
+ORDER BY pinned DESC, pin_order ASC, features_product + shares_category + shares_industry DESC, display_order ASC
+LIMIT @candidate_limit;
+
+-- name: ListRelatedBlogPostCandidates :many
+-- Candidate blog posts for a product's related content.
+-- Parameters (named):
+--   1. product_id (INTEGER): product being viewed
+--   2. category_id (INTEGER): the product's category
+--   3. candidate_limit (INTEGER): max candidates to score (pins and strongest matches first)
+-- Match signals:
+--   - mentions_product (0 or 1): the post lists the product as related
+--   - shares_category (0 or 1): the post lists another product of the same category
+--   - shared_tags: tags in common with the posts that list the product
+--   - pinned, pin_order: an admin pin for this product
+-- Outer WHERE: a candidate needs a pin or at least one signal
+SELECT * FROM (
+    SELECT
+        bp.id, bp.slug, bp.title, bp.excerpt, bp.featured_image_url,
+        bc.name AS category_name, bp.published_at,
+        CAST(EXISTS (
+            SELECT 1 FROM blog_post_products bpp
+            WHERE bpp.blog_post_id = bp.id AND bpp.product_id = @product_id
+        ) AS INTEGER) AS mentions_product,
+        CAST(EXISTS (
+            SELECT 1 FROM blog_post_products bpp
+            INNER JOIN products p ON p.id = bpp.product_id
+            WHERE bpp.blog_post_id = bp.id AND p.category_id = @category_id AND p.id != @product_id
+        ) AS INTEGER) AS shares_category,
+        (
+            SELECT COUNT(*) FROM blog_post_tags bpt
+            WHERE bpt.blog_post_id = bp.id AND bpt.blog_tag_id IN (
+                SELECT own.blog_tag_id FROM blog_post_tags own
+                INNER JOIN blog_post_products bpp ON bpp.blog_post_id = own.blog_post_id
+                WHERE bpp.product_id = @product_id AND own.blog_post_id != bp.id
+            )
+        ) AS shared_tags,
+        CAST(pin.id IS NOT NULL AS INTEGER) AS pinned,
+        CAST(COALESCE(pin.display_order, 0) AS INTEGER) AS pin_order
+    FROM blog_posts bp
+    INNER JOIN blog_categories bc ON bp.category_id = bc.id
+    LEFT JOIN related_content_pins pin ON pin.product_id = @product_id
+        AND pin.content_type = 'blog_post' AND pin.content_id = bp.id
+    WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+        AND bp.published_at < datetime('now', '+1 second')
+) candidates
+WHERE pinned = 1 OR mentions_product = 1 OR shares_category = 1 OR shared_tags > 0
+ORDER BY pinned DESC, pin_order ASC, mentions_product + shares_category + shared_tags DESC, published_at DESC
+LIMIT @candidate_limit;
+
+-- name: ListRelatedWhitepaperCandidates :many
+-- Candidate whitepapers for a product's related content. Whitepapers are
+-- not linked to products, so they match through their topic's slug.
+-- Parameters (named):
+--   1. product_id (INTEGER): product being viewed
+--   2. category_id (INTEGER): the product's category
+--   3. candidate_limit (INTEGER): max candidates to score (pins and strongest matches first)
+-- Match signals (0 or 1):
+--   - topic_matches_category: the topic slug equals the product category's slug
+--   - topic_matches_tag: the topic slug equals a tag of a post that lists the product
+--   - pinned, pin_order: an admin pin for this product
+-- Outer WHERE: a candidate needs a pin or at least one signal
+SELECT * FROM (
+    SELECT
+        w.id, w.slug, w.title, w.description, w.published_date,
+        t.name AS topic_name, t.color_hex AS topic_color_hex,
+        CAST(t.slug IN (
+            SELECT pc.slug FROM product_categories pc WHERE pc.id = @category_id
+        ) AS INTEGER) AS topic_matches_category,
+        CAST(t.slug IN (
+            SELECT bt.slug FROM blog_tags bt
+            INNER JOIN blog_post_tags bpt ON bpt.blog_tag_id = bt.id
+            INNER JOIN blog_post_products bpp ON bpp.blog_post_id = bpt.blog_post_id
+            WHERE bpp.product_id = @product_id
+        ) AS INTEGER) AS topic_matches_tag,
+        CAST(pin.id IS NOT NULL AS INTEGER) AS pinned,
+        CAST(COALESCE(pin.display_order, 0) AS INTEGER) AS pin_order
+    FROM whitepapers w
+    INNER JOIN whitepaper_topics t ON w.topic_id = t.id
+    LEFT JOIN related_content_pins pin ON pin.product_id = @product_id
+        AND pin.content_type = 'whitepaper' AND pin.content_id = w.id
+    WHERE w.is_published = 1
+) candidates
+WHERE pinned = 1 OR topic_matches_category = 1 OR topic_matches_tag = 1
+ORDER BY pinned DESC, pin_order ASC, topic_matches_category + topic_matches_tag DESC, published_date DESC
+LIMIT @candidate_limit;
+
+-- name: ListRelatedContentPins :many
+-- Lists a product's pins with the pinned item's title for the admin tab.
+-- The title is empty when the item is in the trash or otherwise missing.
+-- Parameters:
+--   1. product_id (INTEGER): product
+SELECT
+    pin.id, pin.product_id, pin.content_type, pin.content_id, pin.display_order,
+    CAST(COALESCE(
+        CASE pin.content_type
+            WHEN 'case_study' THEN (SELECT cs.title FROM case_studies cs WHERE cs.id = pin.content_id AND cs.deleted_at IS NULL)
+            WHEN 'blog_post' THEN (SELECT bp.title FROM blog_posts bp WHERE bp.id = pin.content_id AND bp.deleted_at IS NULL)
+            WHEN 'whitepaper' THEN (SELECT w.title FROM whitepapers w WHERE w.id = pin.content_id)
+        END, '') AS TEXT) AS title
+FROM related_content_pins pin
+WHERE pin.product_id = ?
+ORDER BY pin.content_type ASC, pin.display_order ASC, pin.id ASC;
+
+-- name: CreateRelatedContentPin :exec
+-- Pins an item to a product. Pinning it again only updates its order.
+-- Parameters:
+--   1. product_id (INTEGER): product
+--   2. content_type (TEXT): 'case_study', 'blog_post' or 'whitepaper'
+--   3. content_id (INTEGER): ID in the content type's table
+--   4. display_order (INTEGER): position among the product's pins of that type
+INSERT INTO related_content_pins (product_id, content_type, content_id, display_order)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (product_id, content_type, content_id)
+DO UPDATE SET display_order = excluded.display_order;
+
+-- name: DeleteRelatedContentPin :exec
+-- Removes a pin from a product.
+-- Parameters:
+--   1. id (INTEGER): pin ID
+--   2. product_id (INTEGER): product the pin must belong to
+DELETE FROM related_content_pins WHERE id = ? AND product_id = ?;
@@ -0,0 +1,45 @@
+-- ====================================================================
+-- SEO METADATA QUERIES
+-- ====================================================================
+-- Per-page search and social metadata managed under Admin > SEO and
+-- applied to public pages by the SEO middleware. A row targets either a
+-- path (route) or a content item (entity_type, entity_id).
+--
+-- Managed entities:
+-- - seo_meta: One override per path or content item
+-- ====================================================================
+
+-- name: ListSEOMeta :many
+-- Lists every override: paths first, then content items by type and ID.
+SELECT * FROM seo_meta ORDER BY entity_type, route, entity_id;
+
+-- name: GetSEOMeta :one
+-- Returns one override (sql.ErrNoRows if it does not exist).
+SELECT * FROM seo_meta WHERE id = ?;
+
+-- name: CreateSEOMeta :one
+-- Adds an override.
+-- Parameters:
+--   1. route (TEXT): page path, empty for a content item
+--   2. entity_type (TEXT): content type, empty for a path
+--   3. entity_id (INTEGER): content ID, or 0 for a path
+--   4. meta_title (TEXT): title, empty to keep the page's own
+--   5. meta_description (TEXT): description, empty to keep the page's own
+--   6. canonical_url (TEXT): canonical path, empty for the page's own path
+--   7. og_image (TEXT): Open Graph / Twitter image, may be empty
+--   8. noindex (BOOLEAN): ask search engines not to index the page
+INSERT INTO seo_meta (route, entity_type, entity_id, meta_title, meta_description, canonical_url, og_image, noindex)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateSEOMeta :exec
+-- Edits an override, including what it targets.
+-- Parameters: as CreateSEOMeta, then 9. id (INTEGER): override ID
+UPDATE seo_meta
+SET route = ?, entity_type = ?, entity_id = ?, meta_title = ?, meta_description = ?,
+    canonical_url = ?, og_image = ?, noindex = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteSEOMeta :exec
+-- Removes an override; the page shows its own metadata again.
+DELETE FROM seo_meta WHERE id = ?;
@@ -248,6 +248,8 @@ WHERE id = 1;
 --   $7-$8: SEO metadata (meta_description, meta_keywords)
 --   $9: google_analytics_id - GA tracking ID
 --   $10-$14: Social media URLs (Facebook, Twitter, LinkedIn, Instagram, YouTube)
+--   $15-$16: Machine-translation assist (provider, API key)
+--   $17: minify_html - Strip comments/whitespace from public pages before caching
 --
 -- Returns: (none) - sqlc annotation :exec returns only row count
 --
@@ -268,5 +270,231 @@ SET site_name = ?,
     social_linkedin = ?,
     social_instagram = ?,
     social_youtube = ?,
+    mt_provider = ?,
+    mt_api_key = ?,
+    minify_html = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: UpdateSiteTimezone :exec
+-- Updates the site timezone used to display timestamps.
+--
+-- Parameters:
+--   $1: timezone - IANA zone name (e.g. "Europe/Berlin"), validated by services.LoadTimezone
+--
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Use case: General tab of the admin global settings page
+UPDATE settings
+SET timezone = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: UpdateSiteFormat :exec
+-- Updates the locale and currency templates format numbers and prices with.
+--
+-- Parameters:
+--   $1: format_locale - BCP 47 tag (e.g. "de-DE"), validated by services.IsFormatLocale
+--   $2: currency - ISO 4217 code (e.g. "EUR"), validated by services.IsCurrency
+--
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Use case: General tab of the admin global settings page
+UPDATE settings
+SET format_locale = ?,
+    currency = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: UpdateMaintenanceMode :exec
+-- Switches maintenance mode and sets the text of the maintenance page.
+--
+-- Parameters:
+--   $1: maintenance_mode - Public pages answer 503 while set (signed-in admins are exempt)
+--   $2: maintenance_message - Shown on the maintenance page; blank uses a default text
+--
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Use case: General tab of the admin global settings page
+UPDATE settings
+SET maintenance_mode = ?,
+    maintenance_message = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: UpdateAdminIPRules :exec
+-- Sets the networks the admin panel may be reached from.
+--
+-- Parameters:
+--   $1: admin_ip_allow - IP addresses or CIDR ranges, one per line; blank allows every address
+--   $2: admin_ip_deny - IP addresses or CIDR ranges, one per line, refused even when allowed
+--
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Use case: Admin Access page (validated by middleware.ParseIPRules)
+UPDATE settings
+SET admin_ip_allow = ?,
+    admin_ip_deny = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: UpdateTLSDomains :exec
+-- Sets the host names the built-in HTTPS server obtains certificates for.
+--
+-- Parameters:
+--   $1: tls_domains - Host names, one per line, validated by services.ParseHostNames; blank uses TLS_DOMAINS
+--
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Use case: HTTPS Domains admin page
+UPDATE settings
+SET tls_domains = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: UpdateThemeSettings :exec
+-- Updates the theme tokens served to the public and admin layouts.
+--
+-- Parameters:
+--   $1: theme_mode - Default theme for new visitors ("light", "dark", "system")
+--   $2-$7: Light mode colors (primary, primary hover, background, surface, text, border)
+--   $8-$13: Dark mode colors (primary, primary hover, background, surface, text, border)
+--
+-- Returns: (none) - sqlc annotation :exec returns only row count
+--
+-- Use case: Theme tab of the admin global settings page
+-- Note: Values are #RRGGBB hex colors, validated by services.ThemeFromSettings
+UPDATE settings
+SET theme_mode = ?,
+    theme_light_primary = ?,
+    theme_light_primary_hover = ?,
+    theme_light_background = ?,
+    theme_light_surface = ?,
+    theme_light_text = ?,
+    theme_light_border = ?,
+    theme_dark_primary = ?,
+    theme_dark_primary_hover = ?,
+    theme_dark_background = ?,
+    theme_dark_surface = ?,
+    theme_dark_text = ?,
+    theme_dark_border = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1;
+
+-- name: ImportSettings :exec
+-- Overwrites every importable setting in one statement (settings JSON import).
+--
+-- Parameters: one per column of the settings row, except id, the timestamps
+-- and mt_api_key (secrets are not part of an export)
+-- Returns: (none)
+--
+-- Use case: Applying a validated settings import (see services.ParseSettingsImport)
+-- Note: Keep in step with the settings table; a column missing here makes the
+-- import fail instead of silently dropping the value
+UPDATE settings
+SET site_name = ?,
+    site_tagline = ?,
+    contact_email = ?,
+    contact_phone = ?,
+    address = ?,
+    footer_text = ?,
+    meta_description = ?,
+    meta_keywords = ?,
+    google_analytics_id = ?,
+    social_linkedin = ?,
+    social_twitter = ?,
+    social_github = ?,
+    social_facebook = ?,
+    social_youtube = ?,
+    social_instagram = ?,
+    business_hours = ?,
+    about_text = ?,
+    show_nav_home = ?,
+    show_nav_about = ?,
+    show_nav_products = ?,
+    show_nav_solutions = ?,
+    show_nav_blog = ?,
+    show_nav_partners = ?,
+    show_nav_contact = ?,
+    show_footer_about = ?,
+    show_footer_socials = ?,
+    show_footer_products = ?,
+    show_footer_solutions = ?,
+    show_footer_resources = ?,
+    show_footer_contact = ?,
+    nav_label_home = ?,
+    nav_label_about = ?,
+    nav_label_products = ?,
+    nav_label_solutions = ?,
+    nav_label_blog = ?,
+    nav_label_partners = ?,
+    nav_label_contact = ?,
+    footer_heading_products = ?,
+    footer_heading_solutions = ?,
+    footer_heading_resources = ?,
+    footer_heading_contact = ?,
+    header_logo_path = ?,
+    header_logo_alt = ?,
+    header_cta_enabled = ?,
+    header_cta_text = ?,
+    header_cta_url = ?,
+    header_cta_style = ?,
+    header_show_phone = ?,
+    header_show_email = ?,
+    header_show_social = ?,
+    header_social_style = ?,
+    show_nav_case_studies = ?,
+    show_nav_whitepapers = ?,
+    nav_label_case_studies = ?,
+    nav_label_whitepapers = ?,
+    footer_columns = ?,
+    footer_bg_style = ?,
+    footer_show_social = ?,
+    footer_social_style = ?,
+    footer_copyright = ?,
+    homepage_show_heroes = ?,
+    homepage_show_stats = ?,
+    homepage_show_testimonials = ?,
+    homepage_show_cta = ?,
+    homepage_max_heroes = ?,
+    homepage_max_stats = ?,
+    homepage_max_testimonials = ?,
+    homepage_hero_autoplay = ?,
+    homepage_hero_interval = ?,
+    about_show_mission = ?,
+    about_show_milestones = ?,
+    about_show_certifications = ?,
+    about_show_team = ?,
+    products_per_page = ?,
+    products_show_categories = ?,
+    products_show_search = ?,
+    products_default_sort = ?,
+    solutions_per_page = ?,
+    solutions_show_industries = ?,
+    solutions_show_search = ?,
+    blog_posts_per_page = ?,
+    blog_show_author = ?,
+    blog_show_date = ?,
+    blog_show_categories = ?,
+    blog_show_tags = ?,
+    blog_show_search = ?,
+    mt_provider = ?,
+    minify_html = ?,
+    theme_mode = ?,
+    theme_light_primary = ?,
+    theme_light_primary_hover = ?,
+    theme_light_background = ?,
+    theme_light_surface = ?,
+    theme_light_text = ?,
+    theme_light_border = ?,
+    theme_dark_primary = ?,
+    theme_dark_primary_hover = ?,
+    theme_dark_background = ?,
+    theme_dark_surface = ?,
+    theme_dark_text = ?,
+    theme_dark_border = ?,
+    timezone = ?,
+    format_locale = ?,
+    currency = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = 1;
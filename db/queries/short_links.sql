@@ -0,0 +1,70 @@
+-- ====================================================================
+-- SHORT LINK QUERIES
+-- ====================================================================
+-- Campaign links served at /go/<code> and managed under Admin > Short
+-- Links.
+--
+-- Managed entities:
+-- - short_links: One link per code, with its UTM parameters, click count
+--   and optional expiry
+--
+-- Key concepts:
+-- - UTM parameters are stored apart from the destination and added to it
+--   on each redirect (services.ShortLinkTarget)
+-- - Expired links stay listed with their counts; /go/ answers 410 Gone
+-- ====================================================================
+
+-- name: ListShortLinks :many
+-- Lists every short link, newest first.
+SELECT * FROM short_links ORDER BY created_at DESC, id DESC;
+
+-- name: GetShortLink :one
+-- Returns one link (sql.ErrNoRows if it does not exist).
+SELECT * FROM short_links WHERE id = ?;
+
+-- name: GetShortLinkByCode :one
+-- Looks up the link served at /go/<code>, expired or not.
+SELECT * FROM short_links WHERE code = ?;
+
+-- name: CreateShortLink :one
+-- Adds a link.
+-- Parameters:
+--   1. code (TEXT): lowercase code after /go/
+--   2. destination (TEXT): site path or http(s) URL
+--   3. utm_source (TEXT): UTM source, empty to leave out
+--   4. utm_medium (TEXT): UTM medium, empty to leave out
+--   5. utm_campaign (TEXT): UTM campaign, empty to leave out
+--   6. utm_term (TEXT): UTM term, empty to leave out
+--   7. utm_content (TEXT): UTM content, empty to leave out
+--   8. note (TEXT): where the link is used
+--   9. expires_at (DATETIME): end of the link, NULL for none
+INSERT INTO short_links (
+    code, destination, utm_source, utm_medium, utm_campaign, utm_term, utm_content, note, expires_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateShortLink :exec
+-- Edits a link; its click count is kept.
+-- Parameters:
+--   1. code (TEXT): lowercase code after /go/
+--   2. destination (TEXT): site path or http(s) URL
+--   3. utm_source (TEXT): UTM source, empty to leave out
+--   4. utm_medium (TEXT): UTM medium, empty to leave out
+--   5. utm_campaign (TEXT): UTM campaign, empty to leave out
+--   6. utm_term (TEXT): UTM term, empty to leave out
+--   7. utm_content (TEXT): UTM content, empty to leave out
+--   8. note (TEXT): where the link is used
+--   9. expires_at (DATETIME): end of the link, NULL for none
+--   10. id (INTEGER): link ID
+UPDATE short_links
+SET code = ?, destination = ?, utm_source = ?, utm_medium = ?, utm_campaign = ?, utm_term = ?, utm_content = ?,
+    note = ?, expires_at = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteShortLink :exec
+-- Removes a link.
+DELETE FROM short_links WHERE id = ?;
+
+-- name: RecordShortLinkClick :exec
+-- Counts one visit through a link.
+UPDATE short_links SET clicks = clicks + 1, last_click_at = CURRENT_TIMESTAMP WHERE id = ?;
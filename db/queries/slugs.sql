@@ -0,0 +1,41 @@
+-- ====================================================================
+-- SLUG QUERIES
+-- ====================================================================
+-- Uniqueness checks for the URL slugs of every entity that has one.
+-- services.SlugService uses them to suffix generated slugs with -2, -3,
+-- ... before they reach the UNIQUE constraint.
+--
+-- Managed entities:
+-- - products, product_categories, solutions, industries, partner_tiers
+-- - blog_posts, blog_categories, blog_tags, blog_authors, blog_series
+-- - case_studies, whitepapers, whitepaper_topics
+-- ====================================================================
+
+-- name: SlugTaken :one
+-- Reports whether a slug is used by another row of a table, including
+-- trashed rows (the UNIQUE constraint covers them too). Unknown tables
+-- report every slug as taken.
+-- Parameters (named):
+--   1. table_name (TEXT): table the slug is for, e.g. 'products'
+--   2. slug (TEXT): candidate slug
+--   3. exclude_id (INTEGER): row being updated, 0 when creating
+SELECT CAST(CASE CAST(sqlc.arg(table_name) AS TEXT)
+    WHEN 'products' THEN EXISTS (SELECT 1 FROM products WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'product_categories' THEN EXISTS (SELECT 1 FROM product_categories WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'solutions' THEN EXISTS (SELECT 1 FROM solutions WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'industries' THEN EXISTS (SELECT 1 FROM industries WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'partner_tiers' THEN EXISTS (SELECT 1 FROM partner_tiers WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'blog_posts' THEN EXISTS (SELECT 1 FROM blog_posts WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'blog_categories' THEN EXISTS (SELECT 1 FROM blog_categories WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'blog_tags' THEN EXISTS (SELECT 1 FROM blog_tags WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'blog_authors' THEN EXISTS (SELECT 1 FROM blog_authors WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'blog_series' THEN EXISTS (SELECT 1 FROM blog_series WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'case_studies' THEN EXISTS (SELECT 1 FROM case_studies WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'whitepapers' THEN EXISTS (SELECT 1 FROM whitepapers WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'whitepaper_topics' THEN EXISTS (SELECT 1 FROM whitepaper_topics WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'events' THEN EXISTS (SELECT 1 FROM events WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'job_postings' THEN EXISTS (SELECT 1 FROM job_postings WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'press_releases' THEN EXISTS (SELECT 1 FROM press_releases WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    WHEN 'doc_sets' THEN EXISTS (SELECT 1 FROM doc_sets WHERE slug = sqlc.arg(slug) AND id != sqlc.arg(exclude_id))
+    ELSE 1
+END AS INTEGER) AS taken;
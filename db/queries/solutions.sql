@@ -40,7 +40,7 @@
 -- Note: Only selects necessary columns for listing (not full content fields)
 SELECT id, title, slug, icon, short_description, display_order, created_at, updated_at
 FROM solutions
-WHERE is_published = 1
+WHERE is_published = 1 AND deleted_at IS NULL
 ORDER BY display_order ASC, title ASC;
 
 -- name: GetSolutionBySlug :one
@@ -56,7 +56,7 @@ ORDER BY display_order ASC, title ASC;
 --
 -- Use case: Public solution detail page
 SELECT * FROM solutions
-WHERE slug = ? AND is_published = 1
+WHERE slug = ? AND is_published = 1 AND deleted_at IS NULL
 LIMIT 1;
 
 -- name: GetSolutionBySlugIncludeDrafts :one
@@ -69,7 +69,7 @@ LIMIT 1;
 -- Use case: Admin preview mode, editing draft solutions
 -- Note: Does NOT filter by is_published, returns draft solutions
 SELECT * FROM solutions
-WHERE slug = ?
+WHERE slug = ? AND deleted_at IS NULL
 LIMIT 1;
 
 -- name: GetSolutionByID :one
@@ -149,7 +149,7 @@ DELETE FROM solutions WHERE id = ?;
 --
 -- Use case: Admin solutions management listing
 -- Note: Returns ALL solutions regardless of is_published status
-SELECT * FROM solutions ORDER BY display_order ASC, title ASC;
+SELECT * FROM solutions WHERE deleted_at IS NULL ORDER BY display_order ASC, title ASC;
 
 -- ====================================================================
 -- SOLUTIONS - ADMIN QUERIES
@@ -177,8 +177,8 @@ SELECT * FROM solutions ORDER BY display_order ASC, title ASC;
 -- Sorting: display_order ASC, title ASC - Custom order then alphabetical
 -- Use case: Admin solutions management with status filter and search bar
 SELECT * FROM solutions
-WHERE
-    (CASE WHEN @filter_status = '' THEN 1 ELSE
+WHERE deleted_at IS NULL
+    AND (CASE WHEN @filter_status = '' THEN 1 ELSE
         (CASE WHEN @filter_status = 'published' THEN is_published = 1
               WHEN @filter_status = 'draft' THEN (is_published = 0 OR is_published IS NULL)
               ELSE 1 END)
@@ -195,8 +195,8 @@ LIMIT @page_limit OFFSET @page_offset;
 --
 -- Note: Uses identical WHERE clause as ListSolutionsAdminFiltered for consistent counts
 SELECT COUNT(*) FROM solutions
-WHERE
-    (CASE WHEN @filter_status = '' THEN 1 ELSE
+WHERE deleted_at IS NULL
+    AND (CASE WHEN @filter_status = '' THEN 1 ELSE
         (CASE WHEN @filter_status = 'published' THEN is_published = 1
               WHEN @filter_status = 'draft' THEN (is_published = 0 OR is_published IS NULL)
               ELSE 1 END)
@@ -354,7 +354,7 @@ SELECT sp.id, sp.solution_id, sp.product_id, sp.display_order, sp.is_featured,
        p.primary_image AS product_image, p.status AS product_status
 FROM solution_products sp
 JOIN products p ON sp.product_id = p.id
-WHERE sp.solution_id = ? AND p.status = 'published'
+WHERE sp.solution_id = ? AND p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY sp.display_order ASC;
 
 -- name: AddProductToSolution :exec
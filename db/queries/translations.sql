@@ -0,0 +1,121 @@
+-- ====================================================================
+-- TRANSLATION QUERIES
+-- ====================================================================
+-- This file manages per-entity translation records used by the content
+-- localization workflow and the translation coverage dashboard.
+--
+-- Managed entities:
+-- - translations: translated fields of one entity for one locale
+--
+-- Key concepts:
+-- - entity_type: 'product', 'blog_post', 'solution', 'page_section'
+-- - fields: JSON object of translated values keyed by source column
+-- - source_hash: fingerprint of the source fields when the translation was saved
+-- - status: 'missing', 'outdated', 'complete', 'machine' (unreviewed machine draft)
+-- ====================================================================
+
+-- name: GetTranslation :one
+-- sqlc annotation: :one returns a single translation record
+-- Purpose: Loads the translation of one entity for one locale (editor + public rendering)
+-- Returns sql.ErrNoRows when the entity has never been translated into the locale
+SELECT * FROM translations
+WHERE entity_type = ? AND entity_id = ? AND locale = ?;
+
+-- name: UpsertTranslation :one
+-- sqlc annotation: :one returns the saved record
+-- Purpose: Creates or replaces the translation of an entity for a locale
+-- Note: The unique (entity_type, entity_id, locale) index drives the upsert
+INSERT INTO translations (entity_type, entity_id, locale, fields, source_hash, status)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (entity_type, entity_id, locale) DO UPDATE SET
+    fields = excluded.fields,
+    source_hash = excluded.source_hash,
+    status = excluded.status,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListTranslationsByLocale :many
+-- sqlc annotation: :many returns every translation for a locale
+-- Purpose: Coverage dashboard computes complete/outdated/missing counts per entity type
+SELECT * FROM translations
+WHERE locale = ?
+ORDER BY entity_type, entity_id;
+
+-- name: MarkTranslationsOutdated :execrows
+-- sqlc annotation: :execrows returns number of translations flagged
+-- Purpose: Flags complete translations whose source content changed since they were saved
+-- Parameters:
+--   @entity_type (TEXT), @entity_id (INTEGER): the edited source entity
+--   @source_hash (TEXT): fingerprint of the current source fields
+UPDATE translations
+SET status = 'outdated', updated_at = CURRENT_TIMESTAMP
+WHERE entity_type = @entity_type AND entity_id = @entity_id
+  AND status = 'complete' AND source_hash != @source_hash;
+
+-- ====================================================================
+-- TRANSLATION SOURCES
+-- ====================================================================
+-- Translatable fields of each supported entity type, used to fingerprint
+-- source content and to show the original text next to the translation.
+
+-- name: ListProductTranslationSources :many
+SELECT id, name, tagline, description FROM products
+WHERE deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListBlogPostTranslationSources :many
+SELECT id, title, excerpt, body FROM blog_posts
+WHERE deleted_at IS NULL
+ORDER BY title;
+
+-- name: ListSolutionTranslationSources :many
+SELECT id, title, short_description, overview_content FROM solutions
+WHERE deleted_at IS NULL
+ORDER BY title;
+
+-- name: ListPageSectionTranslationSources :many
+SELECT id, page_key, section_key, heading, subheading, description, label,
+       primary_button_text, secondary_button_text
+FROM page_sections
+ORDER BY page_key, display_order;
+
+-- ====================================================================
+-- LOCALES
+-- ====================================================================
+-- Languages the site is published in (see migration 052).
+
+-- name: ListLocales :many
+-- sqlc annotation: :many returns every locale, enabled or not
+-- Purpose: Loads the locale list for the translation service and the admin page
+SELECT * FROM locales
+ORDER BY is_default DESC, sort_order, code;
+
+-- name: CreateLocale :exec
+-- sqlc annotation: :exec inserts a locale unless the code already exists
+-- Purpose: Seeds SITE_LOCALES at startup and adds languages from the admin page
+-- Parameters:
+--   @code (TEXT): Locale code (e.g., "de")
+--   @name (TEXT): Language name shown in the switcher (e.g., "Deutsch")
+--   @is_default (BOOLEAN): Whether this is the source-content locale
+--   @sort_order (INTEGER): Position in the switcher
+INSERT OR IGNORE INTO locales (code, name, is_default, sort_order)
+VALUES (@code, @name, @is_default, @sort_order);
+
+-- name: UpdateLocale :execrows
+-- sqlc annotation: :execrows returns 0 when the locale does not exist
+-- Purpose: Renames, reorders, enables or disables a locale
+-- Parameters:
+--   @name (TEXT), @is_enabled (BOOLEAN), @sort_order (INTEGER)
+--   @code (TEXT): Locale to update
+UPDATE locales
+SET name = @name, is_enabled = @is_enabled, sort_order = @sort_order
+WHERE code = @code;
+
+-- name: SetDefaultLocale :exec
+-- sqlc annotation: :exec marks exactly one locale as the default
+-- Purpose: Keeps is_default in step with the configured source language
+-- Parameters:
+--   @code (TEXT): The source-content locale
+UPDATE locales
+SET is_default = (code = @code),
+    is_enabled = CASE WHEN code = @code THEN 1 ELSE is_enabled END;
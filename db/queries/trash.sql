@@ -0,0 +1,163 @@
+-- ====================================================================
+-- TRASH (SOFT DELETE) QUERIES
+-- ====================================================================
+-- Soft delete, restore, and purge for content entities. Deleting a
+-- product, blog post, solution, or case study in the admin sets
+-- deleted_at instead of removing the row; every listing query filters
+-- on deleted_at IS NULL.
+--
+-- Managed entities:
+-- - products, blog_posts, solutions, case_studies (deleted_at column)
+--
+-- Lifecycle:
+-- - Trash*: sets deleted_at (only for rows not already trashed)
+-- - Restore*: clears deleted_at
+-- - ListTrashed*: rows in the trash, most recently deleted first
+-- - DeleteTrashed*: permanently deletes one trashed row
+-- - PurgeTrashed*: permanently deletes rows trashed before a cutoff
+--   (child rows are removed by ON DELETE CASCADE)
+-- ====================================================================
+
+-- name: TrashProduct :execrows
+-- Moves a product to the trash.
+-- Parameters:
+--   1. id (INTEGER): product to trash
+UPDATE products SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: RestoreProduct :execrows
+-- Takes a product out of the trash.
+-- Parameters:
+--   1. id (INTEGER): product to restore
+UPDATE products SET deleted_at = NULL
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedProducts :many
+-- Lists trashed products for the admin trash page.
+SELECT id, name AS title, slug, deleted_at
+FROM products
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: DeleteTrashedProduct :execrows
+-- Permanently deletes a product that is in the trash. Live rows are not
+-- affected, so content must be trashed before it can be destroyed.
+-- Parameters:
+--   1. id (INTEGER): product to delete
+DELETE FROM products
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeTrashedProducts :execrows
+-- Permanently deletes products trashed before the cutoff.
+-- Parameters:
+--   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+DELETE FROM products
+WHERE deleted_at IS NOT NULL AND deleted_at < CAST(@cutoff AS TEXT);
+
+-- name: TrashBlogPost :execrows
+-- Moves a blog post to the trash.
+-- Parameters:
+--   1. id (INTEGER): post to trash
+UPDATE blog_posts SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: RestoreBlogPost :execrows
+-- Takes a blog post out of the trash.
+-- Parameters:
+--   1. id (INTEGER): post to restore
+UPDATE blog_posts SET deleted_at = NULL
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedBlogPosts :many
+-- Lists trashed blog posts for the admin trash page.
+SELECT id, title, slug, deleted_at
+FROM blog_posts
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: DeleteTrashedBlogPost :execrows
+-- Permanently deletes a blog post that is in the trash. Live rows are not
+-- affected, so content must be trashed before it can be destroyed.
+-- Parameters:
+--   1. id (INTEGER): blog post to delete
+DELETE FROM blog_posts
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeTrashedBlogPosts :execrows
+-- Permanently deletes blog posts trashed before the cutoff.
+-- Parameters:
+--   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+DELETE FROM blog_posts
+WHERE deleted_at IS NOT NULL AND deleted_at < CAST(@cutoff AS TEXT);
+
+-- name: TrashSolution :execrows
+-- Moves a solution to the trash.
+-- Parameters:
+--   1. id (INTEGER): solution to trash
+UPDATE solutions SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: RestoreSolution :execrows
+-- Takes a solution out of the trash.
+-- Parameters:
+--   1. id (INTEGER): solution to restore
+UPDATE solutions SET deleted_at = NULL
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedSolutions :many
+-- Lists trashed solutions for the admin trash page.
+SELECT id, title, slug, deleted_at
+FROM solutions
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: DeleteTrashedSolution :execrows
+-- Permanently deletes a solution that is in the trash. Live rows are not
+-- affected, so content must be trashed before it can be destroyed.
+-- Parameters:
+--   1. id (INTEGER): solution to delete
+DELETE FROM solutions
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeTrashedSolutions :execrows
+-- Permanently deletes solutions trashed before the cutoff.
+-- Parameters:
+--   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+DELETE FROM solutions
+WHERE deleted_at IS NOT NULL AND deleted_at < CAST(@cutoff AS TEXT);
+
+-- name: TrashCaseStudy :execrows
+-- Moves a case study to the trash.
+-- Parameters:
+--   1. id (INTEGER): case study to trash
+UPDATE case_studies SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: RestoreCaseStudy :execrows
+-- Takes a case study out of the trash.
+-- Parameters:
+--   1. id (INTEGER): case study to restore
+UPDATE case_studies SET deleted_at = NULL
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: ListTrashedCaseStudies :many
+-- Lists trashed case studies for the admin trash page.
+SELECT id, title, slug, deleted_at
+FROM case_studies
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: DeleteTrashedCaseStudy :execrows
+-- Permanently deletes a case study that is in the trash. Live rows are not
+-- affected, so content must be trashed before it can be destroyed.
+-- Parameters:
+--   1. id (INTEGER): case study to delete
+DELETE FROM case_studies
+WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeTrashedCaseStudies :execrows
+-- Permanently deletes case studies trashed before the cutoff.
+-- Parameters:
+--   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+DELETE FROM case_studies
+WHERE deleted_at IS NOT NULL AND deleted_at < CAST(@cutoff AS TEXT);
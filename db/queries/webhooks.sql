@@ -0,0 +1,52 @@
+-- ====================================================================
+-- WEBHOOK QUERIES
+-- ====================================================================
+-- Outbound webhook endpoints notified by services.Webhooks when public
+-- content changes, managed under Admin > Webhooks.
+--
+-- Managed entities:
+-- - webhooks: One endpoint per row, with the result of its last delivery
+-- ====================================================================
+
+-- name: ListWebhooks :many
+-- Lists every endpoint, oldest first.
+SELECT * FROM webhooks ORDER BY id;
+
+-- name: ListActiveWebhooks :many
+-- Lists the endpoints that receive events, oldest first.
+SELECT * FROM webhooks WHERE is_active = 1 ORDER BY id;
+
+-- name: GetWebhook :one
+-- Returns one endpoint (sql.ErrNoRows if it does not exist).
+SELECT * FROM webhooks WHERE id = ?;
+
+-- name: CreateWebhook :one
+-- Adds an active endpoint.
+-- Parameters:
+--   1. url (TEXT): http(s) URL the events are POSTed to
+--   2. secret (TEXT): HMAC key for the X-Bluejay-Signature header
+--   3. events (TEXT): comma-separated event types, blank for all
+INSERT INTO webhooks (url, secret, events)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: SetWebhookActive :exec
+-- Pauses or resumes deliveries to an endpoint.
+-- Parameters:
+--   1. is_active (BOOLEAN): whether the endpoint receives events
+--   2. id (INTEGER): endpoint ID
+UPDATE webhooks SET is_active = ? WHERE id = ?;
+
+-- name: DeleteWebhook :exec
+-- Removes an endpoint.
+DELETE FROM webhooks WHERE id = ?;
+
+-- name: RecordWebhookDelivery :exec
+-- Stores the outcome of the latest delivery to an endpoint.
+-- Parameters:
+--   1. last_status (INTEGER): HTTP status of the response, 0 when none came
+--   2. last_error (TEXT): why the delivery failed, blank on success
+--   3. id (INTEGER): endpoint ID
+UPDATE webhooks
+SET last_status = ?, last_error = ?, last_delivered_at = CURRENT_TIMESTAMP
+WHERE id = ?;
@@ -0,0 +1,111 @@
+-- ====================================================================
+-- PUBLISHING WORKFLOW QUERIES
+-- ====================================================================
+-- State of blog posts and products in the review workflow
+-- (draft -> in_review -> approved -> published). services.Workflow decides
+-- which transitions are allowed; these queries only store the outcome.
+--
+-- Managed entities:
+-- - content_workflow: Current state, reviewer and submitter per item
+-- - content_workflow_history: One row per transition, with an optional note
+-- ====================================================================
+
+-- name: GetContentWorkflow :one
+-- Returns the workflow row of one item (sql.ErrNoRows if it never entered the workflow).
+-- Parameters:
+--   1. content_type (TEXT): 'blog_post' or 'product'
+--   2. content_id (INTEGER): ID in the content type's table
+SELECT * FROM content_workflow
+WHERE content_type = ? AND content_id = ?;
+
+-- name: SaveContentWorkflow :exec
+-- Creates or replaces the workflow row of one item.
+-- Parameters:
+--   1. content_type (TEXT): 'blog_post' or 'product'
+--   2. content_id (INTEGER): ID in the content type's table
+--   3. state (TEXT): 'draft', 'in_review', 'approved' or 'published'
+--   4. reviewer_id (INTEGER, nullable): assigned reviewer
+--   5. submitted_by (INTEGER, nullable): user who last submitted the item for review
+INSERT INTO content_workflow (content_type, content_id, state, reviewer_id, submitted_by, updated_at)
+VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+ON CONFLICT (content_type, content_id) DO UPDATE SET
+    state = excluded.state,
+    reviewer_id = excluded.reviewer_id,
+    submitted_by = excluded.submitted_by,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: CreateContentWorkflowHistory :exec
+-- Records one transition.
+-- Parameters:
+--   1. content_type (TEXT): 'blog_post' or 'product'
+--   2. content_id (INTEGER): ID in the content type's table
+--   3. from_state (TEXT): state before the transition
+--   4. to_state (TEXT): state after the transition
+--   5. user_id (INTEGER, nullable): user who made the transition
+--   6. note (TEXT): reviewer comment, may be empty
+INSERT INTO content_workflow_history (content_type, content_id, from_state, to_state, user_id, note)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListContentWorkflowHistory :many
+-- Lists the transitions of one item, newest first, with the user's name.
+-- Parameters:
+--   1. content_type (TEXT): 'blog_post' or 'product'
+--   2. content_id (INTEGER): ID in the content type's table
+SELECT
+    h.id, h.from_state, h.to_state, h.note, h.created_at,
+    CAST(COALESCE(u.display_name, '') AS TEXT) AS user_name
+FROM content_workflow_history h
+LEFT JOIN admin_users u ON u.id = h.user_id
+WHERE h.content_type = ? AND h.content_id = ?
+ORDER BY h.created_at DESC, h.id DESC;
+
+-- name: ListReviewQueue :many
+-- Lists items waiting for a reviewer (in_review) or for publishing
+-- (approved), oldest first, with their title and the people involved.
+-- Trashed content is left out (its title comes back empty).
+-- Parameters (named):
+--   1. reviewer_id (INTEGER): only items assigned to this user; 0 for all
+SELECT * FROM (
+    SELECT
+        w.content_type, w.content_id, w.state, w.reviewer_id, w.submitted_by, w.updated_at,
+        CAST(COALESCE(
+            CASE w.content_type
+                WHEN 'blog_post' THEN (SELECT bp.title FROM blog_posts bp WHERE bp.id = w.content_id AND bp.deleted_at IS NULL)
+                WHEN 'product' THEN (SELECT p.name FROM products p WHERE p.id = w.content_id AND p.deleted_at IS NULL)
+            END, '') AS TEXT) AS title,
+        CAST(COALESCE(r.display_name, '') AS TEXT) AS reviewer_name,
+        CAST(COALESCE(s.display_name, '') AS TEXT) AS submitter_name
+    FROM content_workflow w
+    LEFT JOIN admin_users r ON r.id = w.reviewer_id
+    LEFT JOIN admin_users s ON s.id = w.submitted_by
+    WHERE w.state IN ('in_review', 'approved')
+        AND (CAST(@reviewer_id AS INTEGER) = 0 OR w.reviewer_id = @reviewer_id)
+) queue
+WHERE title != ''
+ORDER BY updated_at ASC, content_type ASC, content_id ASC;
+
+-- name: SetBlogPostPublishStatus :execrows
+-- Publishes or unpublishes a blog post when its workflow state changes.
+-- published_at keeps its first value, so republishing does not move the
+-- post to the top of the blog.
+-- Parameters (named):
+--   1. status (TEXT): 'published' or 'draft'
+--   2. published_at (DATETIME): used when the post has never been published
+--   3. id (INTEGER): blog post ID
+UPDATE blog_posts
+SET status = @status,
+    published_at = CASE WHEN @status = 'published' THEN COALESCE(published_at, @published_at) ELSE published_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND deleted_at IS NULL;
+
+-- name: SetProductPublishStatus :execrows
+-- Publishes or unpublishes a product when its workflow state changes.
+-- Parameters (named):
+--   1. status (TEXT): 'published' or 'draft'
+--   2. published_at (DATETIME): used when the product has never been published
+--   3. id (INTEGER): product ID
+UPDATE products
+SET status = @status,
+    published_at = CASE WHEN @status = 'published' THEN COALESCE(published_at, @published_at) ELSE published_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND deleted_at IS NULL;
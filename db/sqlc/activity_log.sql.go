@@ -11,29 +11,40 @@ import (
 )
 
 const countActivityLogs = `-- name: CountActivityLogs :one
-SELECT COUNT(*) FROM activity_log
+SELECT COUNT(*) FROM activity_log a
 WHERE
     -- Same filter logic as ListActivityLogs for consistency
-    (CAST(?1 AS TEXT) = '' OR action = ?1)
-    AND (CAST(?2 AS TEXT) = '' OR description LIKE '%' || ?2 || '%')
+    (CAST(?1 AS INTEGER) = 0 OR a.user_id = ?1)
+    AND (CAST(?2 AS TEXT) = '' OR a.resource_type = ?2)
+    AND (CAST(?3 AS TEXT) = '' OR a.action = ?3)
+    AND (CAST(?4 AS TEXT) = '' OR a.description LIKE '%' || ?4 || '%' OR a.resource_title LIKE '%' || ?4 || '%')
+    AND (CAST(?5 AS TEXT) = '' OR a.created_at >= ?5)
+    AND (CAST(?6 AS TEXT) = '' OR a.created_at < date(?6, '+1 day'))
 `
 
 type CountActivityLogsParams struct {
-	FilterAction string `json:"filter_action"`
-	FilterSearch string `json:"filter_search"`
+	FilterUser         int64  `json:"filter_user"`
+	FilterResourceType string `json:"filter_resource_type"`
+	FilterAction       string `json:"filter_action"`
+	FilterSearch       string `json:"filter_search"`
+	FilterFrom         string `json:"filter_from"`
+	FilterTo           string `json:"filter_to"`
 }
 
 // sqlc annotation: :one returns single integer count
 // Purpose: Counts total activity logs matching filters (for pagination UI)
-// Parameters (named):
-//
-//	@filter_action (TEXT): same filter as ListActivityLogs
-//	@filter_search (TEXT): same search filter as ListActivityLogs
-//
+// Parameters (named): same filters as ListActivityLogs
 // Return type: single integer COUNT(*) value
 // Note: WHERE clause MUST match ListActivityLogs exactly for accurate pagination
 func (q *Queries) CountActivityLogs(ctx context.Context, arg CountActivityLogsParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countActivityLogs, arg.FilterAction, arg.FilterSearch)
+	row := q.db.QueryRowContext(ctx, countActivityLogs,
+		arg.FilterUser,
+		arg.FilterResourceType,
+		arg.FilterAction,
+		arg.FilterSearch,
+		arg.FilterFrom,
+		arg.FilterTo,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -88,44 +99,116 @@ func (q *Queries) CreateActivityLog(ctx context.Context, arg CreateActivityLogPa
 	return err
 }
 
+const listActivityActions = `-- name: ListActivityActions :many
+SELECT DISTINCT action FROM activity_log ORDER BY action
+`
+
+// sqlc annotation: :many returns distinct action names
+// Purpose: Populates the action filter with every action that has been logged
+func (q *Queries) ListActivityActions(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listActivityActions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var action string
+		if err := rows.Scan(&action); err != nil {
+			return nil, err
+		}
+		items = append(items, action)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listActivityLogs = `-- name: ListActivityLogs :many
-SELECT id, user_id, "action", resource_type, resource_id, resource_title, description, created_at FROM activity_log
+SELECT
+    a.id, a.user_id, a.action, a.resource_type, a.resource_id, a.resource_title,
+    a.description, a.created_at,
+    u.display_name AS user_name, u.email AS user_email
+FROM activity_log a
+LEFT JOIN admin_users u ON u.id = a.user_id
 WHERE
+    -- Filter by acting user if provided
+    (CAST(?1 AS INTEGER) = 0 OR a.user_id = ?1)
+    -- Filter by entity type if provided
+    AND (CAST(?2 AS TEXT) = '' OR a.resource_type = ?2)
     -- Filter by action type if provided, otherwise include all actions
-    (CAST(?1 AS TEXT) = '' OR action = ?1)
-    -- Filter by search term in description if provided
-    AND (CAST(?2 AS TEXT) = '' OR description LIKE '%' || ?2 || '%')
-ORDER BY created_at DESC
-LIMIT ?4 OFFSET ?3
+    AND (CAST(?3 AS TEXT) = '' OR a.action = ?3)
+    -- Filter by search term in description or resource title if provided
+    AND (CAST(?4 AS TEXT) = '' OR a.description LIKE '%' || ?4 || '%' OR a.resource_title LIKE '%' || ?4 || '%')
+    -- Date range (inclusive days)
+    AND (CAST(?5 AS TEXT) = '' OR a.created_at >= ?5)
+    AND (CAST(?6 AS TEXT) = '' OR a.created_at < date(?6, '+1 day'))
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT ?8 OFFSET ?7
 `
 
 type ListActivityLogsParams struct {
-	FilterAction string `json:"filter_action"`
-	FilterSearch string `json:"filter_search"`
-	PageOffset   int64  `json:"page_offset"`
-	PageLimit    int64  `json:"page_limit"`
+	FilterUser         int64  `json:"filter_user"`
+	FilterResourceType string `json:"filter_resource_type"`
+	FilterAction       string `json:"filter_action"`
+	FilterSearch       string `json:"filter_search"`
+	FilterFrom         string `json:"filter_from"`
+	FilterTo           string `json:"filter_to"`
+	PageOffset         int64  `json:"page_offset"`
+	PageLimit          int64  `json:"page_limit"`
 }
 
-// sqlc annotation: :many returns slice of activity_log rows
-// Purpose: Retrieves paginated, filtered activity log entries for admin dashboard
+type ListActivityLogsRow struct {
+	ID            int64          `json:"id"`
+	UserID        sql.NullInt64  `json:"user_id"`
+	Action        string         `json:"action"`
+	ResourceType  string         `json:"resource_type"`
+	ResourceID    sql.NullInt64  `json:"resource_id"`
+	ResourceTitle sql.NullString `json:"resource_title"`
+	Description   string         `json:"description"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	UserName      sql.NullString `json:"user_name"`
+	UserEmail     sql.NullString `json:"user_email"`
+}
+
+// sqlc annotation: :many returns slice of activity rows with the acting user
+// Purpose: Retrieves paginated, filtered activity log entries for the audit
+//
+//	log viewer and its CSV export
+//
 // Parameters (named parameters using @ prefix):
 //
+//	@filter_user (INTEGER): admin user ID (0 = all users)
+//	@filter_resource_type (TEXT): entity type, e.g. "product" (empty = all)
 //	@filter_action (TEXT): filter by action type (empty string = no filter)
-//	@filter_search (TEXT): search term for description field (empty = no filter)
+//	@filter_search (TEXT): search term for description/title (empty = no filter)
+//	@filter_from (TEXT): first day to include, YYYY-MM-DD (empty = no lower bound)
+//	@filter_to (TEXT): last day to include, YYYY-MM-DD (empty = no upper bound)
 //	@page_limit (INTEGER): number of results per page
 //	@page_offset (INTEGER): pagination offset (page_number * page_limit)
 //
-// Return type: slice of activity_log rows
+// Return type: slice of activity_log rows plus user display name and email
 // Complex WHERE logic:
-//   - CAST to TEXT handles nullable/empty filter parameters safely
-//   - Empty string check allows "no filter" without complex null handling
+//   - CAST to TEXT/INTEGER handles empty filter parameters safely
+//   - Empty string / 0 check allows "no filter" without complex null handling
 //   - LIKE with wildcards enables partial text search in descriptions
+//   - created_at is CURRENT_TIMESTAMP text (UTC), so day bounds compare as
+//     strings; the upper bound is exclusive at midnight after @filter_to
 //
+// LEFT JOIN admin_users: entries of deleted users are still listed
 // Note: Always ordered by created_at DESC to show newest actions first
-func (q *Queries) ListActivityLogs(ctx context.Context, arg ListActivityLogsParams) ([]ActivityLog, error) {
+func (q *Queries) ListActivityLogs(ctx context.Context, arg ListActivityLogsParams) ([]ListActivityLogsRow, error) {
 	rows, err := q.db.QueryContext(ctx, listActivityLogs,
+		arg.FilterUser,
+		arg.FilterResourceType,
 		arg.FilterAction,
 		arg.FilterSearch,
+		arg.FilterFrom,
+		arg.FilterTo,
 		arg.PageOffset,
 		arg.PageLimit,
 	)
@@ -133,9 +216,9 @@ func (q *Queries) ListActivityLogs(ctx context.Context, arg ListActivityLogsPara
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ActivityLog{}
+	items := []ListActivityLogsRow{}
 	for rows.Next() {
-		var i ActivityLog
+		var i ListActivityLogsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -145,6 +228,8 @@ func (q *Queries) ListActivityLogs(ctx context.Context, arg ListActivityLogsPara
 			&i.ResourceTitle,
 			&i.Description,
 			&i.CreatedAt,
+			&i.UserName,
+			&i.UserEmail,
 		); err != nil {
 			return nil, err
 		}
@@ -158,3 +243,32 @@ func (q *Queries) ListActivityLogs(ctx context.Context, arg ListActivityLogsPara
 	}
 	return items, nil
 }
+
+const listActivityResourceTypes = `-- name: ListActivityResourceTypes :many
+SELECT DISTINCT resource_type FROM activity_log ORDER BY resource_type
+`
+
+// sqlc annotation: :many returns distinct entity types
+// Purpose: Populates the entity type filter with every type that has been logged
+func (q *Queries) ListActivityResourceTypes(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listActivityResourceTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var resourceType string
+		if err := rows.Scan(&resourceType); err != nil {
+			return nil, err
+		}
+		items = append(items, resourceType)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -100,9 +100,9 @@ func (q *Queries) ClearPostTags(ctx context.Context, blogPostID int64) error {
 
 const countBlogPostsAdminFiltered = `-- name: CountBlogPostsAdminFiltered :one
 SELECT COUNT(*) FROM blog_posts bp
-WHERE
+WHERE bp.deleted_at IS NULL
     -- Exact same filter logic as ListBlogPostsAdminFiltered
-    (CASE WHEN ?1 = '' THEN 1 ELSE bp.status = ?1 END)
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE bp.status = ?1 END)
     AND (CASE WHEN ?2 = 0 THEN 1 ELSE bp.category_id = ?2 END)
     AND (CASE WHEN ?3 = 0 THEN 1 ELSE bp.author_id = ?3 END)
     AND (CASE WHEN ?4 = '' THEN 1 ELSE (bp.title LIKE '%' || ?4 || '%' OR bp.slug LIKE '%' || ?4 || '%') END)
@@ -136,7 +136,7 @@ func (q *Queries) CountBlogPostsAdminFiltered(ctx context.Context, arg CountBlog
 
 const countPublishedPosts = `-- name: CountPublishedPosts :one
 SELECT COUNT(*) FROM blog_posts
-WHERE status = 'published' AND published_at IS NOT NULL
+WHERE status = 'published' AND deleted_at IS NULL AND published_at < datetime('now', '+1 second')
 `
 
 // sqlc annotation: :one returns single integer count
@@ -154,8 +154,8 @@ func (q *Queries) CountPublishedPosts(ctx context.Context) (int64, error) {
 const countPublishedPostsByCategory = `-- name: CountPublishedPostsByCategory :one
 SELECT COUNT(*) FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
-WHERE bp.status = 'published'
-    AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
     AND bc.slug = ?
 `
 
@@ -173,6 +173,47 @@ func (q *Queries) CountPublishedPostsByCategory(ctx context.Context, slug string
 	return count, err
 }
 
+const countPublishedPostsByMonth = `-- name: CountPublishedPostsByMonth :one
+SELECT COUNT(*) FROM blog_posts
+WHERE status = 'published' AND deleted_at IS NULL
+    AND published_at < datetime('now', '+1 second')
+    AND published_at >= CAST(?1 AS TEXT) AND published_at < CAST(?2 AS TEXT)
+`
+
+type CountPublishedPostsByMonthParams struct {
+	MonthStart string `json:"month_start"`
+	MonthEnd   string `json:"month_end"`
+}
+
+// sqlc annotation: :one returns integer count
+// Purpose: Counts posts in one month archive for pagination
+// Note: WHERE must match ListPublishedPostsByMonth
+func (q *Queries) CountPublishedPostsByMonth(ctx context.Context, arg CountPublishedPostsByMonthParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublishedPostsByMonth, arg.MonthStart, arg.MonthEnd)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPublishedPostsByTag = `-- name: CountPublishedPostsByTag :one
+SELECT COUNT(*) FROM blog_posts bp
+INNER JOIN blog_post_tags bpt ON bpt.blog_post_id = bp.id
+INNER JOIN blog_tags bt ON bt.id = bpt.blog_tag_id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bt.slug = ?
+`
+
+// sqlc annotation: :one returns integer count
+// Purpose: Counts posts with one tag for pagination
+// Note: WHERE must match ListPublishedPostsByTag
+func (q *Queries) CountPublishedPostsByTag(ctx context.Context, slug string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublishedPostsByTag, slug)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createBlogPost = `-- name: CreateBlogPost :one
 INSERT INTO blog_posts (
     title, slug, excerpt, body, featured_image_url, featured_image_alt,
@@ -180,7 +221,7 @@ INSERT INTO blog_posts (
     status, published_at
 ) VALUES (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
-) RETURNING id, title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id, meta_description, reading_time_minutes, status, published_at, created_at, updated_at, meta_title, og_image
+) RETURNING id, title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id, meta_description, reading_time_minutes, status, published_at, created_at, updated_at, meta_title, og_image, series_id, series_order, content_format, body_markdown, deleted_at
 `
 
 type CreateBlogPostParams struct {
@@ -249,6 +290,11 @@ func (q *Queries) CreateBlogPost(ctx context.Context, arg CreateBlogPostParams)
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.SeriesID,
+		&i.SeriesOrder,
+		&i.ContentFormat,
+		&i.BodyMarkdown,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -272,7 +318,7 @@ func (q *Queries) DeleteBlogPost(ctx context.Context, id int64) error {
 }
 
 const getBlogPost = `-- name: GetBlogPost :one
-SELECT id, title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id, meta_description, reading_time_minutes, status, published_at, created_at, updated_at, meta_title, og_image FROM blog_posts WHERE id = ?
+SELECT id, title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id, meta_description, reading_time_minutes, status, published_at, created_at, updated_at, meta_title, og_image, series_id, series_order, content_format, body_markdown, deleted_at FROM blog_posts WHERE id = ?
 `
 
 // sqlc annotation: :one returns single blog post by ID
@@ -302,6 +348,72 @@ func (q *Queries) GetBlogPost(ctx context.Context, id int64) (BlogPost, error) {
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.SeriesID,
+		&i.SeriesOrder,
+		&i.ContentFormat,
+		&i.BodyMarkdown,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getBlogPostListItem = `-- name: GetBlogPostListItem :one
+SELECT
+    bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
+    bp.status, bp.category_id, bc.name AS category_name,
+    bp.author_id, ba.name AS author_name, ba.avatar_url AS author_avatar,
+    bp.reading_time_minutes, bp.published_at, bp.created_at, bp.updated_at
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+INNER JOIN blog_authors ba ON bp.author_id = ba.id
+WHERE bp.id = ?1 AND bp.deleted_at IS NULL
+`
+
+type GetBlogPostListItemRow struct {
+	ID                 int64          `json:"id"`
+	Title              string         `json:"title"`
+	Slug               string         `json:"slug"`
+	Excerpt            string         `json:"excerpt"`
+	FeaturedImageUrl   sql.NullString `json:"featured_image_url"`
+	FeaturedImageAlt   sql.NullString `json:"featured_image_alt"`
+	Status             string         `json:"status"`
+	CategoryID         int64          `json:"category_id"`
+	CategoryName       string         `json:"category_name"`
+	AuthorID           int64          `json:"author_id"`
+	AuthorName         string         `json:"author_name"`
+	AuthorAvatar       sql.NullString `json:"author_avatar"`
+	ReadingTimeMinutes sql.NullInt64  `json:"reading_time_minutes"`
+	PublishedAt        sql.NullTime   `json:"published_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+// sqlc annotation: :one returns one row of the admin post list
+// Purpose: Re-renders a list row after an inline edit on /admin/blog/posts
+// Parameters:
+//  1. id (INTEGER): post primary key
+//
+// Return type: same columns as ListBlogPostsAdminFiltered; trashed posts are not found
+func (q *Queries) GetBlogPostListItem(ctx context.Context, id int64) (GetBlogPostListItemRow, error) {
+	row := q.db.QueryRowContext(ctx, getBlogPostListItem, id)
+	var i GetBlogPostListItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.Excerpt,
+		&i.FeaturedImageUrl,
+		&i.FeaturedImageAlt,
+		&i.Status,
+		&i.CategoryID,
+		&i.CategoryName,
+		&i.AuthorID,
+		&i.AuthorName,
+		&i.AuthorAvatar,
+		&i.ReadingTimeMinutes,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -315,7 +427,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 ORDER BY bp.published_at DESC
 LIMIT 1
 `
@@ -379,7 +491,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.slug = ?
+WHERE bp.slug = ? AND bp.deleted_at IS NULL
 `
 
 type GetPostBySlugIncludeDraftsRow struct {
@@ -450,7 +562,7 @@ SELECT p.id, p.name, p.slug, p.primary_image, p.tagline, pc.slug AS category_slu
 FROM products p
 INNER JOIN blog_post_products bpp ON p.id = bpp.product_id
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE bpp.blog_post_id = ?
+WHERE bpp.blog_post_id = ? AND p.deleted_at IS NULL
 ORDER BY bpp.display_order, p.name
 `
 
@@ -570,7 +682,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.slug = ? AND bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.slug = ? AND bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 `
 
 type GetPublishedPostBySlugRow struct {
@@ -611,7 +723,7 @@ type GetPublishedPostBySlugRow struct {
 //
 // WHERE clause:
 //   - bp.slug = ?: exact slug match
-//   - bp.status = 'published' AND bp.published_at IS NOT NULL: public posts only
+//   - bp.status = 'published' AND bp.published_at < datetime('now', '+1 second'): public posts only
 func (q *Queries) GetPublishedPostBySlug(ctx context.Context, slug string) (GetPublishedPostBySlugRow, error) {
 	row := q.db.QueryRowContext(ctx, getPublishedPostBySlug, slug)
 	var i GetPublishedPostBySlugRow
@@ -641,88 +753,6 @@ func (q *Queries) GetPublishedPostBySlug(ctx context.Context, slug string) (GetP
 	return i, err
 }
 
-const getRelatedPosts = `-- name: GetRelatedPosts :many
-SELECT
-    bp.id, bp.title, bp.slug, bp.featured_image_url, bp.featured_image_alt,
-    bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
-    bp.reading_time_minutes, bp.published_at
-FROM blog_posts bp
-INNER JOIN blog_categories bc ON bp.category_id = bc.id
-WHERE bp.status = 'published'
-    AND bp.published_at IS NOT NULL
-    AND bp.category_id = ?
-    AND bp.id != ?
-ORDER BY bp.published_at DESC
-LIMIT ?
-`
-
-type GetRelatedPostsParams struct {
-	CategoryID int64 `json:"category_id"`
-	ID         int64 `json:"id"`
-	Limit      int64 `json:"limit"`
-}
-
-type GetRelatedPostsRow struct {
-	ID                 int64          `json:"id"`
-	Title              string         `json:"title"`
-	Slug               string         `json:"slug"`
-	FeaturedImageUrl   sql.NullString `json:"featured_image_url"`
-	FeaturedImageAlt   sql.NullString `json:"featured_image_alt"`
-	CategoryName       string         `json:"category_name"`
-	CategorySlug       string         `json:"category_slug"`
-	CategoryColor      string         `json:"category_color"`
-	ReadingTimeMinutes sql.NullInt64  `json:"reading_time_minutes"`
-	PublishedAt        sql.NullTime   `json:"published_at"`
-}
-
-// sqlc annotation: :many returns slice of related blog posts
-// Purpose: Retrieves posts from same category for "Related Posts" widget
-// Parameters (positional):
-//  1. category_id (INTEGER): category to match
-//  2. current_post_id (INTEGER): post to exclude (don't show current post as related)
-//  3. LIMIT (INTEGER): max number of related posts (typically 3-5)
-//
-// Return type: slice of minimal blog post data (no body content)
-// WHERE clause:
-//   - bp.status = 'published' AND bp.published_at IS NOT NULL: public posts only
-//   - bp.category_id = ?: same category as current post
-//   - bp.id != ?: exclude current post from results
-//
-// ORDER BY bp.published_at DESC: newest related posts first
-func (q *Queries) GetRelatedPosts(ctx context.Context, arg GetRelatedPostsParams) ([]GetRelatedPostsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getRelatedPosts, arg.CategoryID, arg.ID, arg.Limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []GetRelatedPostsRow{}
-	for rows.Next() {
-		var i GetRelatedPostsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Title,
-			&i.Slug,
-			&i.FeaturedImageUrl,
-			&i.FeaturedImageAlt,
-			&i.CategoryName,
-			&i.CategorySlug,
-			&i.CategoryColor,
-			&i.ReadingTimeMinutes,
-			&i.PublishedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
 const listAllBlogPosts = `-- name: ListAllBlogPosts :many
 
 SELECT
@@ -731,6 +761,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
+WHERE bp.deleted_at IS NULL
 ORDER BY bp.created_at DESC
 `
 
@@ -793,6 +824,44 @@ func (q *Queries) ListAllBlogPosts(ctx context.Context) ([]ListAllBlogPostsRow,
 	return items, nil
 }
 
+const listBlogArchiveDates = `-- name: ListBlogArchiveDates :many
+SELECT published_at FROM blog_posts
+WHERE status = 'published' AND deleted_at IS NULL AND published_at < datetime('now', '+1 second')
+ORDER BY published_at DESC
+`
+
+// sqlc annotation: :many returns one publish date per published post
+// Purpose: Builds the month/year archive widget and archive sitemap entries
+// Parameters: none
+// Return type: slice of published_at values
+// Notes:
+//   - published_at is stored in UTC; the handler buckets the dates into
+//     months of the site timezone, which SQLite cannot convert to
+//
+// ORDER BY published_at DESC: newest first
+func (q *Queries) ListBlogArchiveDates(ctx context.Context) ([]sql.NullTime, error) {
+	rows, err := q.db.QueryContext(ctx, listBlogArchiveDates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []sql.NullTime{}
+	for rows.Next() {
+		var published_at sql.NullTime
+		if err := rows.Scan(&published_at); err != nil {
+			return nil, err
+		}
+		items = append(items, published_at)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBlogPostsAdminFiltered = `-- name: ListBlogPostsAdminFiltered :many
 SELECT
     bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
@@ -802,9 +871,9 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE
+WHERE bp.deleted_at IS NULL
     -- Optional status filter: '' = all statuses, otherwise exact match
-    (CASE WHEN ?1 = '' THEN 1 ELSE bp.status = ?1 END)
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE bp.status = ?1 END)
     -- Optional category filter: 0 = all categories, otherwise exact ID match
     AND (CASE WHEN ?2 = 0 THEN 1 ELSE bp.category_id = ?2 END)
     -- Optional author filter: 0 = all authors, otherwise exact ID match
@@ -908,6 +977,56 @@ func (q *Queries) ListBlogPostsAdminFiltered(ctx context.Context, arg ListBlogPo
 	return items, nil
 }
 
+const listBlogPostsGoingLive = `-- name: ListBlogPostsGoingLive :many
+SELECT id, title, slug FROM blog_posts
+WHERE status = 'published' AND deleted_at IS NULL
+    AND published_at > CAST(?1 AS TEXT) AND published_at <= CAST(?2 AS TEXT)
+    AND published_at > datetime(updated_at, '+1 minute')
+ORDER BY published_at
+`
+
+type ListBlogPostsGoingLiveParams struct {
+	After string `json:"after"`
+	Until string `json:"until"`
+}
+
+type ListBlogPostsGoingLiveRow struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// Lists the published posts whose publish date was set ahead of their last
+// save and fell in (@after, @until], for the scheduled-publishing job. Posts
+// published by the save itself (publish date within a minute of it) are
+// left out; the save already announced them.
+// Parameters:
+//
+//	@after (TEXT): UTC "2006-01-02 15:04:05" end of the previous run
+//	@until (TEXT): UTC "2006-01-02 15:04:05" time of this run
+func (q *Queries) ListBlogPostsGoingLive(ctx context.Context, arg ListBlogPostsGoingLiveParams) ([]ListBlogPostsGoingLiveRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBlogPostsGoingLive, arg.After, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBlogPostsGoingLiveRow{}
+	for rows.Next() {
+		var i ListBlogPostsGoingLiveRow
+		if err := rows.Scan(&i.ID, &i.Title, &i.Slug); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLatestPublishedPosts = `-- name: ListLatestPublishedPosts :many
 
 SELECT
@@ -918,7 +1037,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 ORDER BY bp.published_at DESC
 LIMIT ?
 `
@@ -1005,7 +1124,7 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published' AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
 ORDER BY bp.published_at DESC
 LIMIT ? OFFSET ?
 `
@@ -1069,7 +1188,8 @@ type ListPublishedPostsRow struct {
 //
 // WHERE clause:
 //   - status = 'published': only show published posts, hide drafts
-//   - published_at IS NOT NULL: additional safety check for scheduled posts
+//   - published_at < datetime('now', '+1 second'): posts dated in the future stay hidden until then
+//     (compared by the second, so a post published just now is listed at once)
 //
 // ORDER BY published_at DESC: newest posts first (reverse chronological)
 func (q *Queries) ListPublishedPosts(ctx context.Context, arg ListPublishedPostsParams) ([]ListPublishedPostsRow, error) {
@@ -1121,8 +1241,8 @@ SELECT
 FROM blog_posts bp
 INNER JOIN blog_categories bc ON bp.category_id = bc.id
 INNER JOIN blog_authors ba ON bp.author_id = ba.id
-WHERE bp.status = 'published'
-    AND bp.published_at IS NOT NULL
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
     AND bc.slug = ?
 ORDER BY bp.published_at DESC
 LIMIT ? OFFSET ?
@@ -1162,7 +1282,7 @@ type ListPublishedPostsByCategoryRow struct {
 //
 // Return type: slice of denormalized blog post rows
 // WHERE clause:
-//   - bp.status = 'published' AND bp.published_at IS NOT NULL: same as main list
+//   - bp.status = 'published' AND bp.published_at < datetime('now', '+1 second'): same as main list
 //   - bc.slug = ?: filters by category slug (JOIN to blog_categories required)
 //
 // Note: INNER JOIN ensures only valid category slugs return results
@@ -1206,6 +1326,282 @@ func (q *Queries) ListPublishedPostsByCategory(ctx context.Context, arg ListPubl
 	return items, nil
 }
 
+const listPublishedPostsByMonth = `-- name: ListPublishedPostsByMonth :many
+SELECT
+    bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
+    bp.category_id, bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
+    bp.author_id, ba.name AS author_name, ba.avatar_url AS author_avatar,
+    bp.reading_time_minutes, bp.published_at, bp.created_at
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+INNER JOIN blog_authors ba ON bp.author_id = ba.id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bp.published_at >= CAST(?1 AS TEXT) AND bp.published_at < CAST(?2 AS TEXT)
+ORDER BY bp.published_at DESC
+LIMIT ?4 OFFSET ?3
+`
+
+type ListPublishedPostsByMonthParams struct {
+	MonthStart string `json:"month_start"`
+	MonthEnd   string `json:"month_end"`
+	Offset     int64  `json:"offset"`
+	Limit      int64  `json:"limit"`
+}
+
+type ListPublishedPostsByMonthRow struct {
+	ID                 int64          `json:"id"`
+	Title              string         `json:"title"`
+	Slug               string         `json:"slug"`
+	Excerpt            string         `json:"excerpt"`
+	FeaturedImageUrl   sql.NullString `json:"featured_image_url"`
+	FeaturedImageAlt   sql.NullString `json:"featured_image_alt"`
+	CategoryID         int64          `json:"category_id"`
+	CategoryName       string         `json:"category_name"`
+	CategorySlug       string         `json:"category_slug"`
+	CategoryColor      string         `json:"category_color"`
+	AuthorID           int64          `json:"author_id"`
+	AuthorName         string         `json:"author_name"`
+	AuthorAvatar       sql.NullString `json:"author_avatar"`
+	ReadingTimeMinutes sql.NullInt64  `json:"reading_time_minutes"`
+	PublishedAt        sql.NullTime   `json:"published_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+}
+
+// sqlc annotation: :many returns slice of blog post rows
+// Purpose: Lists published posts for one month archive page (/blog/2024/05)
+// Parameters (named):
+//   - month_start (TEXT): UTC "2006-01-02 15:04:05" start of the month in the
+//     site timezone
+//   - month_end (TEXT): UTC "2006-01-02 15:04:05" start of the next month
+//   - limit / offset (INTEGER): pagination
+//
+// Return type: slice of denormalized blog post rows (same columns as ListPublishedPosts)
+func (q *Queries) ListPublishedPostsByMonth(ctx context.Context, arg ListPublishedPostsByMonthParams) ([]ListPublishedPostsByMonthRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedPostsByMonth,
+		arg.MonthStart,
+		arg.MonthEnd,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublishedPostsByMonthRow{}
+	for rows.Next() {
+		var i ListPublishedPostsByMonthRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Excerpt,
+			&i.FeaturedImageUrl,
+			&i.FeaturedImageAlt,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CategorySlug,
+			&i.CategoryColor,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.AuthorAvatar,
+			&i.ReadingTimeMinutes,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedPostsByTag = `-- name: ListPublishedPostsByTag :many
+SELECT
+    bp.id, bp.title, bp.slug, bp.excerpt, bp.featured_image_url, bp.featured_image_alt,
+    bp.category_id, bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
+    bp.author_id, ba.name AS author_name, ba.avatar_url AS author_avatar,
+    bp.reading_time_minutes, bp.published_at, bp.created_at
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+INNER JOIN blog_authors ba ON bp.author_id = ba.id
+INNER JOIN blog_post_tags bpt ON bpt.blog_post_id = bp.id
+INNER JOIN blog_tags bt ON bt.id = bpt.blog_tag_id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bt.slug = ?
+ORDER BY bp.published_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListPublishedPostsByTagParams struct {
+	Slug   string `json:"slug"`
+	Limit  int64  `json:"limit"`
+	Offset int64  `json:"offset"`
+}
+
+type ListPublishedPostsByTagRow struct {
+	ID                 int64          `json:"id"`
+	Title              string         `json:"title"`
+	Slug               string         `json:"slug"`
+	Excerpt            string         `json:"excerpt"`
+	FeaturedImageUrl   sql.NullString `json:"featured_image_url"`
+	FeaturedImageAlt   sql.NullString `json:"featured_image_alt"`
+	CategoryID         int64          `json:"category_id"`
+	CategoryName       string         `json:"category_name"`
+	CategorySlug       string         `json:"category_slug"`
+	CategoryColor      string         `json:"category_color"`
+	AuthorID           int64          `json:"author_id"`
+	AuthorName         string         `json:"author_name"`
+	AuthorAvatar       sql.NullString `json:"author_avatar"`
+	ReadingTimeMinutes sql.NullInt64  `json:"reading_time_minutes"`
+	PublishedAt        sql.NullTime   `json:"published_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+}
+
+// sqlc annotation: :many returns slice of blog post rows
+// Purpose: Lists published posts with one tag (tag page, /blog/tags/:slug)
+// Parameters (positional):
+//  1. tag slug (TEXT): URL-safe tag identifier
+//  2. LIMIT (INTEGER): posts per page
+//  3. OFFSET (INTEGER): pagination offset
+//
+// Return type: slice of denormalized blog post rows (same columns as ListPublishedPosts)
+func (q *Queries) ListPublishedPostsByTag(ctx context.Context, arg ListPublishedPostsByTagParams) ([]ListPublishedPostsByTagRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedPostsByTag, arg.Slug, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublishedPostsByTagRow{}
+	for rows.Next() {
+		var i ListPublishedPostsByTagRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Excerpt,
+			&i.FeaturedImageUrl,
+			&i.FeaturedImageAlt,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CategorySlug,
+			&i.CategoryColor,
+			&i.AuthorID,
+			&i.AuthorName,
+			&i.AuthorAvatar,
+			&i.ReadingTimeMinutes,
+			&i.PublishedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRelatedPostCandidates = `-- name: ListRelatedPostCandidates :many
+SELECT
+    bp.id, bp.title, bp.slug, bp.featured_image_url, bp.featured_image_alt,
+    bp.category_id, bc.name AS category_name, bc.slug AS category_slug, bc.color_hex AS category_color,
+    bp.reading_time_minutes, bp.published_at,
+    COUNT(bpt.blog_tag_id) AS shared_tags
+FROM blog_posts bp
+INNER JOIN blog_categories bc ON bp.category_id = bc.id
+LEFT JOIN blog_post_tags bpt ON bpt.blog_post_id = bp.id
+    AND bpt.blog_tag_id IN (SELECT cur.blog_tag_id FROM blog_post_tags cur WHERE cur.blog_post_id = ?1)
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL
+    AND bp.published_at < datetime('now', '+1 second')
+    AND bp.id != ?1
+GROUP BY bp.id
+HAVING COUNT(bpt.blog_tag_id) > 0 OR bp.category_id = ?2
+ORDER BY COUNT(bpt.blog_tag_id) DESC, bp.published_at DESC
+LIMIT ?3
+`
+
+type ListRelatedPostCandidatesParams struct {
+	PostID         int64 `json:"post_id"`
+	CategoryID     int64 `json:"category_id"`
+	CandidateLimit int64 `json:"candidate_limit"`
+}
+
+type ListRelatedPostCandidatesRow struct {
+	ID                 int64          `json:"id"`
+	Title              string         `json:"title"`
+	Slug               string         `json:"slug"`
+	FeaturedImageUrl   sql.NullString `json:"featured_image_url"`
+	FeaturedImageAlt   sql.NullString `json:"featured_image_alt"`
+	CategoryID         int64          `json:"category_id"`
+	CategoryName       string         `json:"category_name"`
+	CategorySlug       string         `json:"category_slug"`
+	CategoryColor      string         `json:"category_color"`
+	ReadingTimeMinutes sql.NullInt64  `json:"reading_time_minutes"`
+	PublishedAt        sql.NullTime   `json:"published_at"`
+	SharedTags         int64          `json:"shared_tags"`
+}
+
+// sqlc annotation: :many returns slice of candidate related posts
+// Purpose: Candidate set for the related posts engine, which scores and ranks them in Go
+// Parameters (named):
+//  1. post_id (INTEGER): current post; excluded from results and source of the tag set
+//  2. category_id (INTEGER): current post's category
+//  3. candidate_limit (INTEGER): max candidates to score (strongest matches first)
+//
+// Return type: minimal blog post data plus match signals
+//   - shared_tags: number of tags in common with the current post
+//   - category_id: compared against the current post's category by the scorer
+//
+// LEFT JOIN + GROUP BY: counts only tags that also belong to the current post
+// HAVING: a candidate must share the category or at least one tag
+func (q *Queries) ListRelatedPostCandidates(ctx context.Context, arg ListRelatedPostCandidatesParams) ([]ListRelatedPostCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRelatedPostCandidates, arg.PostID, arg.CategoryID, arg.CandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRelatedPostCandidatesRow{}
+	for rows.Next() {
+		var i ListRelatedPostCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.FeaturedImageUrl,
+			&i.FeaturedImageAlt,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CategorySlug,
+			&i.CategoryColor,
+			&i.ReadingTimeMinutes,
+			&i.PublishedAt,
+			&i.SharedTags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const removeTagFromPost = `-- name: RemoveTagFromPost :exec
 DELETE FROM blog_post_tags
 WHERE blog_post_id = ? AND blog_tag_id = ?
@@ -1230,7 +1626,7 @@ func (q *Queries) RemoveTagFromPost(ctx context.Context, arg RemoveTagFromPostPa
 
 const searchPublishedProducts = `-- name: SearchPublishedProducts :many
 SELECT id, name, slug, primary_image FROM products
-WHERE status = 'published' AND name LIKE ?
+WHERE status = 'published' AND deleted_at IS NULL AND name LIKE ?
 ORDER BY name LIMIT 10
 `
 
@@ -1277,6 +1673,30 @@ func (q *Queries) SearchPublishedProducts(ctx context.Context, name string) ([]S
 	return items, nil
 }
 
+const setBlogPostContentFormat = `-- name: SetBlogPostContentFormat :exec
+UPDATE blog_posts SET content_format = ?1, body_markdown = ?2
+WHERE id = ?3
+`
+
+type SetBlogPostContentFormatParams struct {
+	ContentFormat string `json:"content_format"`
+	BodyMarkdown  string `json:"body_markdown"`
+	ID            int64  `json:"id"`
+}
+
+// sqlc annotation: :exec returns no data
+// Purpose: Records the authoring mode of a post after create/update
+// Parameters (named):
+//  1. content_format (TEXT): 'html' (WYSIWYG) or 'markdown'
+//  2. body_markdown (TEXT): Markdown source (” for html posts)
+//  3. id (INTEGER): post to update
+//
+// Note: body already holds the rendered HTML, saved by Create/UpdateBlogPost
+func (q *Queries) SetBlogPostContentFormat(ctx context.Context, arg SetBlogPostContentFormatParams) error {
+	_, err := q.db.ExecContext(ctx, setBlogPostContentFormat, arg.ContentFormat, arg.BodyMarkdown, arg.ID)
+	return err
+}
+
 const updateBlogPost = `-- name: UpdateBlogPost :one
 UPDATE blog_posts SET
     title = ?,
@@ -1293,7 +1713,7 @@ UPDATE blog_posts SET
     published_at = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id, meta_description, reading_time_minutes, status, published_at, created_at, updated_at, meta_title, og_image
+RETURNING id, title, slug, excerpt, body, featured_image_url, featured_image_alt, category_id, author_id, meta_description, reading_time_minutes, status, published_at, created_at, updated_at, meta_title, og_image, series_id, series_order, content_format, body_markdown, deleted_at
 `
 
 type UpdateBlogPostParams struct {
@@ -1356,6 +1776,11 @@ func (q *Queries) UpdateBlogPost(ctx context.Context, arg UpdateBlogPostParams)
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.SeriesID,
+		&i.SeriesOrder,
+		&i.ContentFormat,
+		&i.BodyMarkdown,
+		&i.DeletedAt,
 	)
 	return i, err
 }
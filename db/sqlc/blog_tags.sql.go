@@ -152,6 +152,61 @@ func (q *Queries) ListAllBlogTags(ctx context.Context) ([]BlogTag, error) {
 	return items, nil
 }
 
+const listBlogTagCloud = `-- name: ListBlogTagCloud :many
+SELECT bt.id, bt.name, bt.slug, COUNT(*) AS post_count
+FROM blog_tags bt
+INNER JOIN blog_post_tags bpt ON bpt.blog_tag_id = bt.id
+INNER JOIN blog_posts bp ON bp.id = bpt.blog_post_id
+WHERE bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second')
+GROUP BY bt.id, bt.name, bt.slug
+ORDER BY bt.name COLLATE NOCASE ASC
+`
+
+type ListBlogTagCloudRow struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+}
+
+// sqlc annotation: :many returns one row per tag used by a published post
+// Purpose: Builds the public tag cloud (/blog/tags) and tag sitemap entries
+// Parameters: none
+// Return type: slice of (id, name, slug, post_count) rows
+// Notes:
+//   - Only published, non-deleted posts are counted, so tags used only by
+//     drafts or trashed posts are left out of the cloud
+//   - post_count drives the font size of each tag in the cloud
+//
+// ORDER BY name: alphabetical, the usual tag cloud layout
+func (q *Queries) ListBlogTagCloud(ctx context.Context) ([]ListBlogTagCloudRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBlogTagCloud)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBlogTagCloudRow{}
+	for rows.Next() {
+		var i ListBlogTagCloudRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.PostCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchBlogTags = `-- name: SearchBlogTags :many
 SELECT id, name, slug, created_at FROM blog_tags WHERE name LIKE ? ORDER BY name LIMIT 10
 `
@@ -65,7 +65,7 @@ INSERT INTO case_studies (
     ?, ?,
     ?, ?,
     ?, ?, ?, ?
-) RETURNING id, slug, title, client_name, industry_id, hero_image_url, summary, challenge_title, challenge_content, challenge_bullets, solution_title, solution_content, outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, created_at, updated_at, og_image
+) RETURNING id, slug, title, client_name, industry_id, hero_image_url, summary, challenge_title, challenge_content, challenge_bullets, solution_title, solution_content, outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, created_at, updated_at, og_image, deleted_at
 `
 
 type AdminCreateCaseStudyParams struct {
@@ -153,6 +153,7 @@ func (q *Queries) AdminCreateCaseStudy(ctx context.Context, arg AdminCreateCaseS
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -235,7 +236,7 @@ func (q *Queries) AdminDeleteMetric(ctx context.Context, id int64) error {
 }
 
 const adminGetCaseStudy = `-- name: AdminGetCaseStudy :one
-SELECT id, slug, title, client_name, industry_id, hero_image_url, summary, challenge_title, challenge_content, challenge_bullets, solution_title, solution_content, outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, created_at, updated_at, og_image FROM case_studies WHERE id = ?
+SELECT id, slug, title, client_name, industry_id, hero_image_url, summary, challenge_title, challenge_content, challenge_bullets, solution_title, solution_content, outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, created_at, updated_at, og_image, deleted_at FROM case_studies WHERE id = ?
 `
 
 // sqlc annotation: :one returns single case study by ID for admin editing
@@ -269,6 +270,53 @@ func (q *Queries) AdminGetCaseStudy(ctx context.Context, id int64) (CaseStudy, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.OgImage,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const adminGetCaseStudyListItem = `-- name: AdminGetCaseStudyListItem :one
+SELECT
+    cs.id, cs.slug, cs.title, cs.client_name,
+    cs.hero_image_url, cs.is_published, cs.display_order,
+    cs.updated_at,
+    i.name as industry_name
+FROM case_studies cs
+INNER JOIN industries i ON cs.industry_id = i.id
+WHERE cs.id = ?1 AND cs.deleted_at IS NULL
+`
+
+type AdminGetCaseStudyListItemRow struct {
+	ID           int64          `json:"id"`
+	Slug         string         `json:"slug"`
+	Title        string         `json:"title"`
+	ClientName   string         `json:"client_name"`
+	HeroImageUrl sql.NullString `json:"hero_image_url"`
+	IsPublished  int64          `json:"is_published"`
+	DisplayOrder int64          `json:"display_order"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	IndustryName string         `json:"industry_name"`
+}
+
+// sqlc annotation: :one returns one row of the admin case study list
+// Purpose: Re-renders a list row after an inline edit on /admin/case-studies
+// Parameters:
+//  1. id (INTEGER): case study primary key
+//
+// Return type: same columns as AdminListCaseStudiesFiltered; trashed case studies are not found
+func (q *Queries) AdminGetCaseStudyListItem(ctx context.Context, id int64) (AdminGetCaseStudyListItemRow, error) {
+	row := q.db.QueryRowContext(ctx, adminGetCaseStudyListItem, id)
+	var i AdminGetCaseStudyListItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Title,
+		&i.ClientName,
+		&i.HeroImageUrl,
+		&i.IsPublished,
+		&i.DisplayOrder,
+		&i.UpdatedAt,
+		&i.IndustryName,
 	)
 	return i, err
 }
@@ -281,6 +329,7 @@ SELECT
     i.name as industry_name
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
+WHERE cs.deleted_at IS NULL
 ORDER BY cs.display_order ASC, cs.created_at DESC
 `
 
@@ -343,9 +392,9 @@ SELECT
     i.name as industry_name
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE
+WHERE cs.deleted_at IS NULL
     -- Optional search filter: '' = all, otherwise LIKE match in title or client_name
-    (CASE WHEN ?1 = '' THEN 1 ELSE (cs.title LIKE '%' || ?1 || '%' OR cs.client_name LIKE '%' || ?1 || '%') END)
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE (cs.title LIKE '%' || ?1 || '%' OR cs.client_name LIKE '%' || ?1 || '%') END)
     -- Optional status filter: 'published' = 1, 'draft' = 0, '' = all
     AND (CASE WHEN ?2 = '' THEN 1
          WHEN ?2 = 'published' THEN cs.is_published = 1
@@ -434,7 +483,7 @@ SELECT
     p.name as product_name, p.slug as product_slug
 FROM case_study_products csp
 INNER JOIN products p ON csp.product_id = p.id
-WHERE csp.case_study_id = ?
+WHERE csp.case_study_id = ? AND p.deleted_at IS NULL
 ORDER BY csp.display_order ASC
 `
 
@@ -577,7 +626,7 @@ UPDATE case_studies SET
     display_order = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, slug, title, client_name, industry_id, hero_image_url, summary, challenge_title, challenge_content, challenge_bullets, solution_title, solution_content, outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, created_at, updated_at, og_image
+RETURNING id, slug, title, client_name, industry_id, hero_image_url, summary, challenge_title, challenge_content, challenge_bullets, solution_title, solution_content, outcome_title, outcome_content, meta_title, meta_description, is_published, display_order, created_at, updated_at, og_image, deleted_at
 `
 
 type AdminUpdateCaseStudyParams struct {
@@ -654,6 +703,7 @@ func (q *Queries) AdminUpdateCaseStudy(ctx context.Context, arg AdminUpdateCaseS
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -702,7 +752,7 @@ func (q *Queries) AdminUpdateMetric(ctx context.Context, arg AdminUpdateMetricPa
 }
 
 const countCaseStudies = `-- name: CountCaseStudies :one
-SELECT COUNT(*) FROM case_studies WHERE is_published = 1
+SELECT COUNT(*) FROM case_studies WHERE is_published = 1 AND deleted_at IS NULL
 `
 
 // sqlc annotation: :one returns total published case studies count
@@ -718,9 +768,9 @@ func (q *Queries) CountCaseStudies(ctx context.Context) (int64, error) {
 
 const countCaseStudiesAdminFiltered = `-- name: CountCaseStudiesAdminFiltered :one
 SELECT COUNT(*) FROM case_studies cs
-WHERE
+WHERE cs.deleted_at IS NULL
     -- Exact same filter logic as AdminListCaseStudiesFiltered
-    (CASE WHEN ?1 = '' THEN 1 ELSE (cs.title LIKE '%' || ?1 || '%' OR cs.client_name LIKE '%' || ?1 || '%') END)
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE (cs.title LIKE '%' || ?1 || '%' OR cs.client_name LIKE '%' || ?1 || '%') END)
     AND (CASE WHEN ?2 = '' THEN 1
          WHEN ?2 = 'published' THEN cs.is_published = 1
          WHEN ?2 = 'draft' THEN cs.is_published = 0
@@ -745,7 +795,7 @@ func (q *Queries) CountCaseStudiesAdminFiltered(ctx context.Context, arg CountCa
 }
 
 const countCaseStudiesByIndustry = `-- name: CountCaseStudiesByIndustry :one
-SELECT COUNT(*) FROM case_studies WHERE is_published = 1 AND industry_id = ?
+SELECT COUNT(*) FROM case_studies WHERE is_published = 1 AND deleted_at IS NULL AND industry_id = ?
 `
 
 // sqlc annotation: :one returns count for specific industry
@@ -772,7 +822,7 @@ SELECT
     i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.slug = ? AND cs.is_published = 1
+WHERE cs.slug = ? AND cs.is_published = 1 AND cs.deleted_at IS NULL
 `
 
 type GetCaseStudyBySlugRow struct {
@@ -851,7 +901,7 @@ SELECT
     i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.slug = ?
+WHERE cs.slug = ? AND cs.deleted_at IS NULL
 `
 
 type GetCaseStudyBySlugIncludeDraftsRow struct {
@@ -969,7 +1019,7 @@ SELECT
 FROM case_study_products csp
 INNER JOIN products p ON csp.product_id = p.id
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE csp.case_study_id = ? AND p.status = 'published'
+WHERE csp.case_study_id = ? AND p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY csp.display_order ASC
 `
 
@@ -1037,7 +1087,7 @@ SELECT
     i.id as industry_id, i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.is_published = 1
+WHERE cs.is_published = 1 AND cs.deleted_at IS NULL
 ORDER BY cs.display_order ASC, cs.created_at DESC
 `
 
@@ -1128,7 +1178,7 @@ SELECT
     i.id as industry_id, i.name as industry_name, i.slug as industry_slug
 FROM case_studies cs
 INNER JOIN industries i ON cs.industry_id = i.id
-WHERE cs.is_published = 1 AND cs.industry_id = ?
+WHERE cs.is_published = 1 AND cs.deleted_at IS NULL AND cs.industry_id = ?
 ORDER BY cs.display_order ASC, cs.created_at DESC
 `
 
@@ -1187,3 +1237,51 @@ func (q *Queries) ListCaseStudiesByIndustry(ctx context.Context, industryID int6
 	}
 	return items, nil
 }
+
+const setCaseStudyDisplayOrder = `-- name: SetCaseStudyDisplayOrder :execrows
+UPDATE case_studies
+SET display_order = ?1, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2 AND deleted_at IS NULL
+`
+
+type SetCaseStudyDisplayOrderParams struct {
+	DisplayOrder int64 `json:"display_order"`
+	ID           int64 `json:"id"`
+}
+
+// sqlc annotation: :execrows returns the number of updated rows (0 when missing or trashed)
+// Purpose: Changes where a case study sits in listings, from the admin list
+// Parameters (named):
+//  1. display_order (INTEGER): sort position, lowest first
+//  2. id (INTEGER): case study primary key
+func (q *Queries) SetCaseStudyDisplayOrder(ctx context.Context, arg SetCaseStudyDisplayOrderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setCaseStudyDisplayOrder, arg.DisplayOrder, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setCaseStudyPublished = `-- name: SetCaseStudyPublished :execrows
+UPDATE case_studies
+SET is_published = ?1, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2 AND deleted_at IS NULL
+`
+
+type SetCaseStudyPublishedParams struct {
+	IsPublished int64 `json:"is_published"`
+	ID          int64 `json:"id"`
+}
+
+// sqlc annotation: :execrows returns the number of updated rows (0 when missing or trashed)
+// Purpose: Publishes or unpublishes a case study from the admin list
+// Parameters (named):
+//  1. is_published (INTEGER): 1 to publish, 0 to unpublish
+//  2. id (INTEGER): case study primary key
+func (q *Queries) SetCaseStudyPublished(ctx context.Context, arg SetCaseStudyPublishedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setCaseStudyPublished, arg.IsPublished, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
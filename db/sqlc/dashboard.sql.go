@@ -9,8 +9,77 @@ import (
 	"context"
 )
 
+const countContentByType = `-- name: CountContentByType :many
+SELECT 'product' AS content_type,
+    CAST(COUNT(CASE WHEN status = 'published' THEN 1 END) AS INTEGER) AS published,
+    CAST(COUNT(CASE WHEN status = 'draft' THEN 1 END) AS INTEGER) AS drafts
+FROM products WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'blog_post',
+    CAST(COUNT(CASE WHEN status = 'published' THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN status = 'draft' THEN 1 END) AS INTEGER)
+FROM blog_posts WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'solution',
+    CAST(COUNT(CASE WHEN is_published = 1 THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN is_published = 0 THEN 1 END) AS INTEGER)
+FROM solutions WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'case_study',
+    CAST(COUNT(CASE WHEN is_published = 1 THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN is_published = 0 THEN 1 END) AS INTEGER)
+FROM case_studies WHERE deleted_at IS NULL
+UNION ALL
+SELECT 'whitepaper',
+    CAST(COUNT(CASE WHEN is_published = 1 THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN is_published = 0 THEN 1 END) AS INTEGER)
+FROM whitepapers
+UNION ALL
+SELECT 'landing_page',
+    CAST(COUNT(CASE WHEN status = 'published' THEN 1 END) AS INTEGER),
+    CAST(COUNT(CASE WHEN status = 'draft' THEN 1 END) AS INTEGER)
+FROM landing_pages
+`
+
+type CountContentByTypeRow struct {
+	ContentType string `json:"content_type"`
+	Published   int64  `json:"published"`
+	Drafts      int64  `json:"drafts"`
+}
+
+// Purpose: Published and draft counts per content type for the dashboard's
+// content card, in a fixed order. Trashed items are left out, and archived
+// items count as neither.
+// Return type: one row per type
+func (q *Queries) CountContentByType(ctx context.Context) ([]CountContentByTypeRow, error) {
+	rows, err := q.db.QueryContext(ctx, countContentByType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountContentByTypeRow{}
+	for rows.Next() {
+		var i CountContentByTypeRow
+		if err := rows.Scan(
+			&i.ContentType,
+			&i.Published,
+			&i.Drafts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countDraftBlogPosts = `-- name: CountDraftBlogPosts :one
-SELECT COUNT(*) FROM blog_posts WHERE status = 'draft'
+SELECT COUNT(*) FROM blog_posts WHERE status = 'draft' AND deleted_at IS NULL
 `
 
 // sqlc annotation: :one returns integer count
@@ -26,7 +95,7 @@ func (q *Queries) CountDraftBlogPosts(ctx context.Context) (int64, error) {
 }
 
 const countDraftProducts = `-- name: CountDraftProducts :one
-SELECT COUNT(*) FROM products WHERE status = 'draft'
+SELECT COUNT(*) FROM products WHERE status = 'draft' AND deleted_at IS NULL
 `
 
 // sqlc annotation: :one returns integer count
@@ -85,3 +154,150 @@ func (q *Queries) CountPartners(ctx context.Context) (int64, error) {
 	err := row.Scan(&count)
 	return count, err
 }
+
+const listLeadTimesSince = `-- name: ListLeadTimesSince :many
+SELECT CAST(created_at AS TEXT) AS created_at FROM contact_submissions WHERE created_at >= CAST(?1 AS TEXT)
+UNION ALL
+SELECT CAST(created_at AS TEXT) FROM whitepaper_downloads WHERE created_at >= CAST(?1 AS TEXT)
+UNION ALL
+SELECT CAST(created_at AS TEXT) FROM product_download_leads WHERE created_at >= CAST(?1 AS TEXT)
+UNION ALL
+SELECT CAST(created_at AS TEXT) FROM event_registrations WHERE created_at >= CAST(?1 AS TEXT)
+`
+
+// Purpose: When each lead submission since a moment arrived, for the
+// dashboard's lead trend (bucketed into days in the site timezone)
+// Parameter: since (TEXT), a CURRENT_TIMESTAMP-format UTC time
+// Return type: created_at as text, one row per submission
+func (q *Queries) ListLeadTimesSince(ctx context.Context, since string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listLeadTimesSince, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var created_at string
+		if err := rows.Scan(&created_at); err != nil {
+			return nil, err
+		}
+		items = append(items, created_at)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentLeads = `-- name: ListRecentLeads :many
+SELECT * FROM (
+    SELECT 'contact' AS source, id, name, email, company,
+        CAST(COALESCE(inquiry_type, '') AS TEXT) AS detail,
+        CAST(created_at AS TEXT) AS created_at
+    FROM contact_submissions
+    UNION ALL
+    SELECT 'whitepaper', d.id, d.name, d.email, d.company, w.title, CAST(d.created_at AS TEXT)
+    FROM whitepaper_downloads d
+    JOIN whitepapers w ON w.id = d.whitepaper_id
+) leads
+ORDER BY created_at DESC, id DESC
+LIMIT ?1
+`
+
+type ListRecentLeadsRow struct {
+	Source    string `json:"source"`
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Company   string `json:"company"`
+	Detail    string `json:"detail"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Purpose: The newest lead submissions (contact form, RFQs and gated
+// whitepaper downloads) for the dashboard's recent leads card
+// Parameter: limit (INTEGER), the number of submissions
+// Return type: source (contact or whitepaper), row ID, contact details, the
+// inquiry type or whitepaper title as detail, and created_at as
+// CURRENT_TIMESTAMP text (UTC)
+func (q *Queries) ListRecentLeads(ctx context.Context, limit int64) ([]ListRecentLeadsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecentLeads, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRecentLeadsRow{}
+	for rows.Next() {
+		var i ListRecentLeadsRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Company,
+			&i.Detail,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTopDownloadedWhitepapers = `-- name: ListTopDownloadedWhitepapers :many
+SELECT w.id, w.title, w.download_count,
+    CAST((SELECT COUNT(*) FROM whitepaper_downloads d
+          WHERE d.whitepaper_id = w.id AND d.created_at >= datetime('now', '-30 days')) AS INTEGER) AS recent_downloads
+FROM whitepapers w
+WHERE w.download_count > 0
+ORDER BY w.download_count DESC, w.title
+LIMIT ?1
+`
+
+type ListTopDownloadedWhitepapersRow struct {
+	ID              int64  `json:"id"`
+	Title           string `json:"title"`
+	DownloadCount   int64  `json:"download_count"`
+	RecentDownloads int64  `json:"recent_downloads"`
+}
+
+// Purpose: The most downloaded whitepapers for the dashboard, with their
+// downloads over the last 30 days
+// Parameter: limit (INTEGER), the number of whitepapers
+func (q *Queries) ListTopDownloadedWhitepapers(ctx context.Context, limit int64) ([]ListTopDownloadedWhitepapersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTopDownloadedWhitepapers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTopDownloadedWhitepapersRow{}
+	for rows.Next() {
+		var i ListTopDownloadedWhitepapersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.DownloadCount,
+			&i.RecentDownloads,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -76,26 +76,27 @@ INSERT INTO homepage_hero (
     headline, subheadline, badge_text,
     primary_cta_text, primary_cta_url,
     secondary_cta_text, secondary_cta_url,
-    background_image, is_active, display_order
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at
+    background_image, background_image_alt, is_active, display_order
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at, background_image_alt
 `
 
 type CreateHeroParams struct {
-	Headline         string         `json:"headline"`
-	Subheadline      string         `json:"subheadline"`
-	BadgeText        sql.NullString `json:"badge_text"`
-	PrimaryCtaText   string         `json:"primary_cta_text"`
-	PrimaryCtaUrl    string         `json:"primary_cta_url"`
-	SecondaryCtaText sql.NullString `json:"secondary_cta_text"`
-	SecondaryCtaUrl  sql.NullString `json:"secondary_cta_url"`
-	BackgroundImage  sql.NullString `json:"background_image"`
-	IsActive         int64          `json:"is_active"`
-	DisplayOrder     int64          `json:"display_order"`
+	Headline           string         `json:"headline"`
+	Subheadline        string         `json:"subheadline"`
+	BadgeText          sql.NullString `json:"badge_text"`
+	PrimaryCtaText     string         `json:"primary_cta_text"`
+	PrimaryCtaUrl      string         `json:"primary_cta_url"`
+	SecondaryCtaText   sql.NullString `json:"secondary_cta_text"`
+	SecondaryCtaUrl    sql.NullString `json:"secondary_cta_url"`
+	BackgroundImage    sql.NullString `json:"background_image"`
+	BackgroundImageAlt string         `json:"background_image_alt"`
+	IsActive           int64          `json:"is_active"`
+	DisplayOrder       int64          `json:"display_order"`
 }
 
 // Purpose: Creates a new hero banner variant
-// Parameters (10 positional):
+// Parameters (11 positional):
 //  1. headline (TEXT): main hero headline
 //  2. subheadline (TEXT): supporting text
 //  3. badge_text (TEXT): optional badge/announcement text
@@ -104,8 +105,9 @@ type CreateHeroParams struct {
 //  6. secondary_cta_text (TEXT): optional secondary button text
 //  7. secondary_cta_url (TEXT): secondary button link
 //  8. background_image (TEXT): hero background/featured image URL
-//  9. is_active (BOOLEAN): whether this hero is currently displayed
-//  10. display_order (INTEGER): sort position
+//  9. background_image_alt (TEXT): description of the background image
+//  10. is_active (BOOLEAN): whether this hero is currently displayed
+//  11. display_order (INTEGER): sort position
 //
 // Note: Only one hero should have is_active = 1 at a time
 func (q *Queries) CreateHero(ctx context.Context, arg CreateHeroParams) (HomepageHero, error) {
@@ -118,6 +120,7 @@ func (q *Queries) CreateHero(ctx context.Context, arg CreateHeroParams) (Homepag
 		arg.SecondaryCtaText,
 		arg.SecondaryCtaUrl,
 		arg.BackgroundImage,
+		arg.BackgroundImageAlt,
 		arg.IsActive,
 		arg.DisplayOrder,
 	)
@@ -136,6 +139,7 @@ func (q *Queries) CreateHero(ctx context.Context, arg CreateHeroParams) (Homepag
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackgroundImageAlt,
 	)
 	return i, err
 }
@@ -181,32 +185,34 @@ func (q *Queries) CreateStat(ctx context.Context, arg CreateStatParams) (Homepag
 const createTestimonialHomepage = `-- name: CreateTestimonialHomepage :one
 INSERT INTO homepage_testimonials (
     quote, author_name, author_title, author_company,
-    author_image, rating, display_order, is_active
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at
+    author_image, author_image_alt, rating, display_order, is_active
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at, author_image_alt
 `
 
 type CreateTestimonialHomepageParams struct {
-	Quote         string         `json:"quote"`
-	AuthorName    string         `json:"author_name"`
-	AuthorTitle   sql.NullString `json:"author_title"`
-	AuthorCompany sql.NullString `json:"author_company"`
-	AuthorImage   sql.NullString `json:"author_image"`
-	Rating        int64          `json:"rating"`
-	DisplayOrder  int64          `json:"display_order"`
-	IsActive      int64          `json:"is_active"`
+	Quote          string         `json:"quote"`
+	AuthorName     string         `json:"author_name"`
+	AuthorTitle    sql.NullString `json:"author_title"`
+	AuthorCompany  sql.NullString `json:"author_company"`
+	AuthorImage    sql.NullString `json:"author_image"`
+	AuthorImageAlt string         `json:"author_image_alt"`
+	Rating         int64          `json:"rating"`
+	DisplayOrder   int64          `json:"display_order"`
+	IsActive       int64          `json:"is_active"`
 }
 
 // Purpose: Creates a new homepage testimonial
-// Parameters (8 positional):
+// Parameters (9 positional):
 //  1. quote (TEXT): testimonial text/content
 //  2. author_name (TEXT): customer name
 //  3. author_title (TEXT): job title
 //  4. author_company (TEXT): company/organization name
 //  5. author_image (TEXT): headshot/avatar URL
-//  6. rating (INTEGER): star rating (typically 1-5)
-//  7. display_order (INTEGER): carousel slide order
-//  8. is_active (BOOLEAN): whether to display on homepage
+//  6. author_image_alt (TEXT): description of the headshot
+//  7. rating (INTEGER): star rating (typically 1-5)
+//  8. display_order (INTEGER): carousel slide order
+//  9. is_active (BOOLEAN): whether to display on homepage
 func (q *Queries) CreateTestimonialHomepage(ctx context.Context, arg CreateTestimonialHomepageParams) (HomepageTestimonial, error) {
 	row := q.db.QueryRowContext(ctx, createTestimonialHomepage,
 		arg.Quote,
@@ -214,6 +220,7 @@ func (q *Queries) CreateTestimonialHomepage(ctx context.Context, arg CreateTesti
 		arg.AuthorTitle,
 		arg.AuthorCompany,
 		arg.AuthorImage,
+		arg.AuthorImageAlt,
 		arg.Rating,
 		arg.DisplayOrder,
 		arg.IsActive,
@@ -230,6 +237,7 @@ func (q *Queries) CreateTestimonialHomepage(ctx context.Context, arg CreateTesti
 		&i.DisplayOrder,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.AuthorImageAlt,
 	)
 	return i, err
 }
@@ -311,7 +319,7 @@ func (q *Queries) GetActiveCTA(ctx context.Context) (HomepageCtum, error) {
 const getActiveHero = `-- name: GetActiveHero :one
 
 
-SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at FROM homepage_hero
+SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at, background_image_alt FROM homepage_hero
 WHERE is_active = 1
 ORDER BY display_order ASC
 LIMIT 1
@@ -362,6 +370,7 @@ func (q *Queries) GetActiveHero(ctx context.Context) (HomepageHero, error) {
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackgroundImageAlt,
 	)
 	return i, err
 }
@@ -391,7 +400,7 @@ func (q *Queries) GetCTA(ctx context.Context, id int64) (HomepageCtum, error) {
 }
 
 const getHero = `-- name: GetHero :one
-SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at FROM homepage_hero WHERE id = ?
+SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at, background_image_alt FROM homepage_hero WHERE id = ?
 `
 
 // Purpose: Retrieves specific hero by ID for editing
@@ -412,6 +421,7 @@ func (q *Queries) GetHero(ctx context.Context, id int64) (HomepageHero, error) {
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackgroundImageAlt,
 	)
 	return i, err
 }
@@ -436,7 +446,7 @@ func (q *Queries) GetStat(ctx context.Context, id int64) (HomepageStat, error) {
 }
 
 const getTestimonialHomepage = `-- name: GetTestimonialHomepage :one
-SELECT id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at FROM homepage_testimonials WHERE id = ?
+SELECT id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at, author_image_alt FROM homepage_testimonials WHERE id = ?
 `
 
 // Purpose: Retrieves specific testimonial by ID for editing
@@ -454,12 +464,13 @@ func (q *Queries) GetTestimonialHomepage(ctx context.Context, id int64) (Homepag
 		&i.DisplayOrder,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.AuthorImageAlt,
 	)
 	return i, err
 }
 
 const listActiveHeroes = `-- name: ListActiveHeroes :many
-SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at FROM homepage_hero
+SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at, background_image_alt FROM homepage_hero
 WHERE is_active = 1
 ORDER BY display_order ASC
 LIMIT ?
@@ -494,6 +505,7 @@ func (q *Queries) ListActiveHeroes(ctx context.Context, limit int64) ([]Homepage
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackgroundImageAlt,
 		); err != nil {
 			return nil, err
 		}
@@ -554,7 +566,7 @@ func (q *Queries) ListActiveStats(ctx context.Context) ([]HomepageStat, error) {
 
 const listActiveTestimonialsHomepage = `-- name: ListActiveTestimonialsHomepage :many
 
-SELECT id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at FROM homepage_testimonials
+SELECT id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at, author_image_alt FROM homepage_testimonials
 WHERE is_active = 1
 ORDER BY display_order ASC
 `
@@ -585,6 +597,7 @@ func (q *Queries) ListActiveTestimonialsHomepage(ctx context.Context) ([]Homepag
 			&i.DisplayOrder,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.AuthorImageAlt,
 		); err != nil {
 			return nil, err
 		}
@@ -641,7 +654,7 @@ func (q *Queries) ListAllCTAs(ctx context.Context) ([]HomepageCtum, error) {
 }
 
 const listAllHeroes = `-- name: ListAllHeroes :many
-SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at FROM homepage_hero ORDER BY display_order ASC
+SELECT id, headline, subheadline, badge_text, primary_cta_text, primary_cta_url, secondary_cta_text, secondary_cta_url, background_image, is_active, display_order, created_at, updated_at, background_image_alt FROM homepage_hero ORDER BY display_order ASC
 `
 
 // Purpose: Lists all hero variants (active + inactive) for admin management
@@ -669,6 +682,7 @@ func (q *Queries) ListAllHeroes(ctx context.Context) ([]HomepageHero, error) {
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackgroundImageAlt,
 		); err != nil {
 			return nil, err
 		}
@@ -719,7 +733,7 @@ func (q *Queries) ListAllStats(ctx context.Context) ([]HomepageStat, error) {
 }
 
 const listAllTestimonialsHomepage = `-- name: ListAllTestimonialsHomepage :many
-SELECT id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at FROM homepage_testimonials ORDER BY display_order ASC
+SELECT id, quote, author_name, author_title, author_company, author_image, rating, display_order, is_active, created_at, author_image_alt FROM homepage_testimonials ORDER BY display_order ASC
 `
 
 // Purpose: Lists all testimonials (active + inactive) for admin management
@@ -743,6 +757,7 @@ func (q *Queries) ListAllTestimonialsHomepage(ctx context.Context) ([]HomepageTe
 			&i.DisplayOrder,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.AuthorImageAlt,
 		); err != nil {
 			return nil, err
 		}
@@ -801,27 +816,28 @@ UPDATE homepage_hero
 SET headline = ?, subheadline = ?, badge_text = ?,
     primary_cta_text = ?, primary_cta_url = ?,
     secondary_cta_text = ?, secondary_cta_url = ?,
-    background_image = ?, is_active = ?, display_order = ?,
+    background_image = ?, background_image_alt = ?, is_active = ?, display_order = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
 `
 
 type UpdateHeroParams struct {
-	Headline         string         `json:"headline"`
-	Subheadline      string         `json:"subheadline"`
-	BadgeText        sql.NullString `json:"badge_text"`
-	PrimaryCtaText   string         `json:"primary_cta_text"`
-	PrimaryCtaUrl    string         `json:"primary_cta_url"`
-	SecondaryCtaText sql.NullString `json:"secondary_cta_text"`
-	SecondaryCtaUrl  sql.NullString `json:"secondary_cta_url"`
-	BackgroundImage  sql.NullString `json:"background_image"`
-	IsActive         int64          `json:"is_active"`
-	DisplayOrder     int64          `json:"display_order"`
-	ID               int64          `json:"id"`
+	Headline           string         `json:"headline"`
+	Subheadline        string         `json:"subheadline"`
+	BadgeText          sql.NullString `json:"badge_text"`
+	PrimaryCtaText     string         `json:"primary_cta_text"`
+	PrimaryCtaUrl      string         `json:"primary_cta_url"`
+	SecondaryCtaText   sql.NullString `json:"secondary_cta_text"`
+	SecondaryCtaUrl    sql.NullString `json:"secondary_cta_url"`
+	BackgroundImage    sql.NullString `json:"background_image"`
+	BackgroundImageAlt string         `json:"background_image_alt"`
+	IsActive           int64          `json:"is_active"`
+	DisplayOrder       int64          `json:"display_order"`
+	ID                 int64          `json:"id"`
 }
 
 // Purpose: Updates an existing hero banner
-// Parameters (11 positional): same as CreateHero + id (WHERE clause)
+// Parameters (12 positional): same as CreateHero + id (WHERE clause)
 func (q *Queries) UpdateHero(ctx context.Context, arg UpdateHeroParams) error {
 	_, err := q.db.ExecContext(ctx, updateHero,
 		arg.Headline,
@@ -832,6 +848,7 @@ func (q *Queries) UpdateHero(ctx context.Context, arg UpdateHeroParams) error {
 		arg.SecondaryCtaText,
 		arg.SecondaryCtaUrl,
 		arg.BackgroundImage,
+		arg.BackgroundImageAlt,
 		arg.IsActive,
 		arg.DisplayOrder,
 		arg.ID,
@@ -869,24 +886,25 @@ func (q *Queries) UpdateStat(ctx context.Context, arg UpdateStatParams) error {
 const updateTestimonialHomepage = `-- name: UpdateTestimonialHomepage :exec
 UPDATE homepage_testimonials
 SET quote = ?, author_name = ?, author_title = ?, author_company = ?,
-    author_image = ?, rating = ?, display_order = ?, is_active = ?
+    author_image = ?, author_image_alt = ?, rating = ?, display_order = ?, is_active = ?
 WHERE id = ?
 `
 
 type UpdateTestimonialHomepageParams struct {
-	Quote         string         `json:"quote"`
-	AuthorName    string         `json:"author_name"`
-	AuthorTitle   sql.NullString `json:"author_title"`
-	AuthorCompany sql.NullString `json:"author_company"`
-	AuthorImage   sql.NullString `json:"author_image"`
-	Rating        int64          `json:"rating"`
-	DisplayOrder  int64          `json:"display_order"`
-	IsActive      int64          `json:"is_active"`
-	ID            int64          `json:"id"`
+	Quote          string         `json:"quote"`
+	AuthorName     string         `json:"author_name"`
+	AuthorTitle    sql.NullString `json:"author_title"`
+	AuthorCompany  sql.NullString `json:"author_company"`
+	AuthorImage    sql.NullString `json:"author_image"`
+	AuthorImageAlt string         `json:"author_image_alt"`
+	Rating         int64          `json:"rating"`
+	DisplayOrder   int64          `json:"display_order"`
+	IsActive       int64          `json:"is_active"`
+	ID             int64          `json:"id"`
 }
 
 // Purpose: Updates an existing homepage testimonial
-// Parameters (9 positional): same as CreateTestimonialHomepage + id
+// Parameters (10 positional): same as CreateTestimonialHomepage + id
 func (q *Queries) UpdateTestimonialHomepage(ctx context.Context, arg UpdateTestimonialHomepageParams) error {
 	_, err := q.db.ExecContext(ctx, updateTestimonialHomepage,
 		arg.Quote,
@@ -894,6 +912,7 @@ func (q *Queries) UpdateTestimonialHomepage(ctx context.Context, arg UpdateTesti
 		arg.AuthorTitle,
 		arg.AuthorCompany,
 		arg.AuthorImage,
+		arg.AuthorImageAlt,
 		arg.Rating,
 		arg.DisplayOrder,
 		arg.IsActive,
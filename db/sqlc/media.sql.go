@@ -50,8 +50,8 @@ func (q *Queries) CountMediaFilesSearch(ctx context.Context, search sql.NullStri
 }
 
 const createMediaFile = `-- name: CreateMediaFile :one
-INSERT INTO media_files (filename, original_filename, file_path, file_size, mime_type, width, height, alt_text)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at
+INSERT INTO media_files (filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, content_hash)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash
 `
 
 type CreateMediaFileParams struct {
@@ -63,6 +63,7 @@ type CreateMediaFileParams struct {
 	Width            sql.NullInt64  `json:"width"`
 	Height           sql.NullInt64  `json:"height"`
 	AltText          sql.NullString `json:"alt_text"`
+	ContentHash      string         `json:"content_hash"`
 }
 
 // Inserts a new media file record after successful upload.
@@ -77,6 +78,7 @@ type CreateMediaFileParams struct {
 //	$6 (INTEGER) - width: Image width in pixels (NULL for non-images)
 //	$7 (INTEGER) - height: Image height in pixels (NULL for non-images)
 //	$8 (TEXT) - alt_text: Accessibility alt text for images (optional)
+//	$9 (TEXT) - content_hash: Hex SHA-256 of the contents ("" if unknown)
 //
 // Returns: MediaFile - The newly created media file record with auto-generated ID and timestamps
 //
@@ -91,6 +93,7 @@ func (q *Queries) CreateMediaFile(ctx context.Context, arg CreateMediaFileParams
 		arg.Width,
 		arg.Height,
 		arg.AltText,
+		arg.ContentHash,
 	)
 	var i MediaFile
 	err := row.Scan(
@@ -104,6 +107,55 @@ func (q *Queries) CreateMediaFile(ctx context.Context, arg CreateMediaFileParams
 		&i.Height,
 		&i.AltText,
 		&i.CreatedAt,
+		&i.ContentHash,
+	)
+	return i, err
+}
+
+const createMediaVariant = `-- name: CreateMediaVariant :one
+INSERT INTO media_variants (media_file_id, name, file_path, width, height, file_size)
+VALUES (?, ?, ?, ?, ?, ?) RETURNING id, media_file_id, name, file_path, width, height, file_size, created_at
+`
+
+type CreateMediaVariantParams struct {
+	MediaFileID int64  `json:"media_file_id"`
+	Name        string `json:"name"`
+	FilePath    string `json:"file_path"`
+	Width       int64  `json:"width"`
+	Height      int64  `json:"height"`
+	FileSize    int64  `json:"file_size"`
+}
+
+// Records a resized copy of a media file.
+//
+// Parameters:
+//
+//	$1 (INTEGER) - media_file_id: Original file
+//	$2 (TEXT) - name: Variant name (e.g., "thumb", "medium")
+//	$3 (TEXT) - file_path: Web path of the resized file
+//	$4, $5 (INTEGER) - width, height: Pixel size of the variant
+//	$6 (INTEGER) - file_size: Size in bytes
+//
+// Returns: MediaVariant
+func (q *Queries) CreateMediaVariant(ctx context.Context, arg CreateMediaVariantParams) (MediaVariant, error) {
+	row := q.db.QueryRowContext(ctx, createMediaVariant,
+		arg.MediaFileID,
+		arg.Name,
+		arg.FilePath,
+		arg.Width,
+		arg.Height,
+		arg.FileSize,
+	)
+	var i MediaVariant
+	err := row.Scan(
+		&i.ID,
+		&i.MediaFileID,
+		&i.Name,
+		&i.FilePath,
+		&i.Width,
+		&i.Height,
+		&i.FileSize,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -129,7 +181,7 @@ func (q *Queries) DeleteMediaFile(ctx context.Context, id int64) error {
 }
 
 const getMediaFile = `-- name: GetMediaFile :one
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files WHERE id = ? LIMIT 1
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files WHERE id = ? LIMIT 1
 `
 
 // Retrieves a single media file by its primary key ID.
@@ -155,12 +207,49 @@ func (q *Queries) GetMediaFile(ctx context.Context, id int64) (MediaFile, error)
 		&i.Height,
 		&i.AltText,
 		&i.CreatedAt,
+		&i.ContentHash,
+	)
+	return i, err
+}
+
+const getMediaFileByHash = `-- name: GetMediaFileByHash :one
+
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files WHERE content_hash = ? AND content_hash != '' ORDER BY id LIMIT 1
+`
+
+// ====================================================================
+// BULK IMPORT (content hashes and variants)
+// ====================================================================
+// Retrieves the media file with the given content hash, if any.
+//
+// Parameters:
+//
+//	$1 (TEXT) - content_hash: Hex SHA-256 of the file contents
+//
+// Returns: MediaFile - The oldest file with that content, or sql.ErrNoRows
+//
+// Use case: Skipping files the library already holds during an import
+func (q *Queries) GetMediaFileByHash(ctx context.Context, contentHash string) (MediaFile, error) {
+	row := q.db.QueryRowContext(ctx, getMediaFileByHash, contentHash)
+	var i MediaFile
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.OriginalFilename,
+		&i.FilePath,
+		&i.FileSize,
+		&i.MimeType,
+		&i.Width,
+		&i.Height,
+		&i.AltText,
+		&i.CreatedAt,
+		&i.ContentHash,
 	)
 	return i, err
 }
 
 const getMediaFileByPath = `-- name: GetMediaFileByPath :one
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files WHERE file_path = ? LIMIT 1
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files WHERE file_path = ? LIMIT 1
 `
 
 // Retrieves a single media file by its storage file path.
@@ -187,13 +276,14 @@ func (q *Queries) GetMediaFileByPath(ctx context.Context, filePath string) (Medi
 		&i.Height,
 		&i.AltText,
 		&i.CreatedAt,
+		&i.ContentHash,
 	)
 	return i, err
 }
 
 const listMediaFiles = `-- name: ListMediaFiles :many
 
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?
 `
@@ -244,6 +334,7 @@ func (q *Queries) ListMediaFiles(ctx context.Context, arg ListMediaFilesParams)
 			&i.Height,
 			&i.AltText,
 			&i.CreatedAt,
+			&i.ContentHash,
 		); err != nil {
 			return nil, err
 		}
@@ -259,7 +350,7 @@ func (q *Queries) ListMediaFiles(ctx context.Context, arg ListMediaFilesParams)
 }
 
 const listMediaFilesByName = `-- name: ListMediaFilesByName :many
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files
 ORDER BY filename ASC
 LIMIT ? OFFSET ?
 `
@@ -300,6 +391,7 @@ func (q *Queries) ListMediaFilesByName(ctx context.Context, arg ListMediaFilesBy
 			&i.Height,
 			&i.AltText,
 			&i.CreatedAt,
+			&i.ContentHash,
 		); err != nil {
 			return nil, err
 		}
@@ -315,7 +407,7 @@ func (q *Queries) ListMediaFilesByName(ctx context.Context, arg ListMediaFilesBy
 }
 
 const listMediaFilesBySize = `-- name: ListMediaFilesBySize :many
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files
 ORDER BY file_size DESC
 LIMIT ? OFFSET ?
 `
@@ -356,6 +448,7 @@ func (q *Queries) ListMediaFilesBySize(ctx context.Context, arg ListMediaFilesBy
 			&i.Height,
 			&i.AltText,
 			&i.CreatedAt,
+			&i.ContentHash,
 		); err != nil {
 			return nil, err
 		}
@@ -371,7 +464,7 @@ func (q *Queries) ListMediaFilesBySize(ctx context.Context, arg ListMediaFilesBy
 }
 
 const listMediaFilesOldest = `-- name: ListMediaFilesOldest :many
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files
 ORDER BY created_at ASC
 LIMIT ? OFFSET ?
 `
@@ -412,6 +505,93 @@ func (q *Queries) ListMediaFilesOldest(ctx context.Context, arg ListMediaFilesOl
 			&i.Height,
 			&i.AltText,
 			&i.CreatedAt,
+			&i.ContentHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMediaFilesWithoutHash = `-- name: ListMediaFilesWithoutHash :many
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files WHERE content_hash = '' ORDER BY id
+`
+
+// Retrieves media files uploaded before content hashing, so an import can
+// hash them and detect duplicates of legacy uploads.
+//
+// Returns: []MediaFile
+func (q *Queries) ListMediaFilesWithoutHash(ctx context.Context) ([]MediaFile, error) {
+	rows, err := q.db.QueryContext(ctx, listMediaFilesWithoutHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MediaFile{}
+	for rows.Next() {
+		var i MediaFile
+		if err := rows.Scan(
+			&i.ID,
+			&i.Filename,
+			&i.OriginalFilename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.Width,
+			&i.Height,
+			&i.AltText,
+			&i.CreatedAt,
+			&i.ContentHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMediaVariants = `-- name: ListMediaVariants :many
+SELECT id, media_file_id, name, file_path, width, height, file_size, created_at FROM media_variants WHERE media_file_id = ? ORDER BY width ASC
+`
+
+// Retrieves the variants of a media file, smallest first.
+//
+// Parameters:
+//
+//	$1 (INTEGER) - media_file_id
+//
+// Returns: []MediaVariant
+func (q *Queries) ListMediaVariants(ctx context.Context, mediaFileID int64) ([]MediaVariant, error) {
+	rows, err := q.db.QueryContext(ctx, listMediaVariants, mediaFileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MediaVariant{}
+	for rows.Next() {
+		var i MediaVariant
+		if err := rows.Scan(
+			&i.ID,
+			&i.MediaFileID,
+			&i.Name,
+			&i.FilePath,
+			&i.Width,
+			&i.Height,
+			&i.FileSize,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -427,7 +607,7 @@ func (q *Queries) ListMediaFilesOldest(ctx context.Context, arg ListMediaFilesOl
 }
 
 const searchMediaFiles = `-- name: SearchMediaFiles :many
-SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at FROM media_files
+SELECT id, filename, original_filename, file_path, file_size, mime_type, width, height, alt_text, created_at, content_hash FROM media_files
 WHERE original_filename LIKE '%' || ?1 || '%'
 ORDER BY created_at DESC
 LIMIT ?3 OFFSET ?2
@@ -472,6 +652,7 @@ func (q *Queries) SearchMediaFiles(ctx context.Context, arg SearchMediaFilesPara
 			&i.Height,
 			&i.AltText,
 			&i.CreatedAt,
+			&i.ContentHash,
 		); err != nil {
 			return nil, err
 		}
@@ -486,6 +667,26 @@ func (q *Queries) SearchMediaFiles(ctx context.Context, arg SearchMediaFilesPara
 	return items, nil
 }
 
+const setMediaFileHash = `-- name: SetMediaFileHash :exec
+UPDATE media_files SET content_hash = ? WHERE id = ?
+`
+
+type SetMediaFileHashParams struct {
+	ContentHash string `json:"content_hash"`
+	ID          int64  `json:"id"`
+}
+
+// Records the content hash of a media file.
+//
+// Parameters:
+//
+//	$1 (TEXT) - content_hash: Hex SHA-256 of the file contents
+//	$2 (INTEGER) - id: Media file ID
+func (q *Queries) SetMediaFileHash(ctx context.Context, arg SetMediaFileHashParams) error {
+	_, err := q.db.ExecContext(ctx, setMediaFileHash, arg.ContentHash, arg.ID)
+	return err
+}
+
 const updateMediaFileAltText = `-- name: UpdateMediaFileAltText :exec
 UPDATE media_files SET alt_text = ? WHERE id = ?
 `
@@ -20,6 +20,16 @@ type ActivityLog struct {
 	CreatedAt     sql.NullTime   `json:"created_at"`
 }
 
+type AdminSession struct {
+	ID         int64     `json:"id"`
+	Token      string    `json:"token"`
+	UserID     int64     `json:"user_id"`
+	IpAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
 type AdminUser struct {
 	ID           int64        `json:"id"`
 	Email        string       `json:"email"`
@@ -32,6 +42,18 @@ type AdminUser struct {
 	LastLoginAt  sql.NullTime `json:"last_login_at"`
 }
 
+type ArchiveRun struct {
+	ID            int64     `json:"id"`
+	SourceTable   string    `json:"source_table"`
+	Period        string    `json:"period"`
+	StorageKey    string    `json:"storage_key"`
+	RowCount      int64     `json:"row_count"`
+	ContentHash   string    `json:"content_hash"`
+	PrevChainHash string    `json:"prev_chain_hash"`
+	ChainHash     string    `json:"chain_hash"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
 type BlogAuthor struct {
 	ID          int64          `json:"id"`
 	Name        string         `json:"name"`
@@ -75,6 +97,11 @@ type BlogPost struct {
 	UpdatedAt          time.Time      `json:"updated_at"`
 	MetaTitle          string         `json:"meta_title"`
 	OgImage            string         `json:"og_image"`
+	SeriesID           sql.NullInt64  `json:"series_id"`
+	SeriesOrder        int64          `json:"series_order"`
+	ContentFormat      string         `json:"content_format"`
+	BodyMarkdown       string         `json:"body_markdown"`
+	DeletedAt          sql.NullTime   `json:"deleted_at"`
 }
 
 type BlogPostProduct struct {
@@ -94,6 +121,15 @@ type BlogPostsFt struct {
 	Body    string `json:"body"`
 }
 
+type BlogSeries struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	Slug        string         `json:"slug"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	UpdatedAt   sql.NullTime   `json:"updated_at"`
+}
+
 type BlogTag struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
@@ -101,6 +137,20 @@ type BlogTag struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type BrokenLink struct {
+	ID         int64     `json:"id"`
+	Source     string    `json:"source"`
+	OwnerID    int64     `json:"owner_id"`
+	ParentID   int64     `json:"parent_id"`
+	OwnerTitle string    `json:"owner_title"`
+	Field      string    `json:"field"`
+	Url        string    `json:"url"`
+	Status     int64     `json:"status"`
+	TargetType string    `json:"target_type"`
+	TargetID   int64     `json:"target_id"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
 type CaseStudiesFt struct {
 	Title            string `json:"title"`
 	ClientName       string `json:"client_name"`
@@ -130,6 +180,7 @@ type CaseStudy struct {
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
 	OgImage          string         `json:"og_image"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
 }
 
 type CaseStudyMetric struct {
@@ -188,6 +239,40 @@ type ContactSubmission struct {
 	SubmissionType string         `json:"submission_type"`
 }
 
+type ContentBlock struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	BlockType  string    `json:"block_type"`
+	Heading    string    `json:"heading"`
+	Body       string    `json:"body"`
+	ButtonText string    `json:"button_text"`
+	ButtonUrl  string    `json:"button_url"`
+	Items      string    `json:"items"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ContentWorkflow struct {
+	ContentType string        `json:"content_type"`
+	ContentID   int64         `json:"content_id"`
+	State       string        `json:"state"`
+	ReviewerID  sql.NullInt64 `json:"reviewer_id"`
+	SubmittedBy sql.NullInt64 `json:"submitted_by"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type ContentWorkflowHistory struct {
+	ID          int64         `json:"id"`
+	ContentType string        `json:"content_type"`
+	ContentID   int64         `json:"content_id"`
+	FromState   string        `json:"from_state"`
+	ToState     string        `json:"to_state"`
+	UserID      sql.NullInt64 `json:"user_id"`
+	Note        string        `json:"note"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
 type CoreValue struct {
 	ID           int64     `json:"id"`
 	Title        string    `json:"title"`
@@ -197,6 +282,159 @@ type CoreValue struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type CustomerAccount struct {
+	ID               int64        `json:"id"`
+	Email            string       `json:"email"`
+	Name             string       `json:"name"`
+	Company          string       `json:"company"`
+	Designation      string       `json:"designation"`
+	PasswordHash     string       `json:"password_hash"`
+	MarketingConsent int64        `json:"marketing_consent"`
+	LastLoginAt      sql.NullTime `json:"last_login_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type CustomerLibrary struct {
+	ID                int64         `json:"id"`
+	AccountID         int64         `json:"account_id"`
+	WhitepaperID      sql.NullInt64 `json:"whitepaper_id"`
+	ProductDownloadID sql.NullInt64 `json:"product_download_id"`
+	DownloadedAt      time.Time     `json:"downloaded_at"`
+}
+
+type CustomerPasswordReset struct {
+	ID        int64     `json:"id"`
+	AccountID int64     `json:"account_id"`
+	TokenHash string    `json:"token_hash"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CustomerRegistration struct {
+	ID               int64     `json:"id"`
+	TokenHash        string    `json:"token_hash"`
+	Email            string    `json:"email"`
+	Name             string    `json:"name"`
+	Company          string    `json:"company"`
+	Designation      string    `json:"designation"`
+	PasswordHash     string    `json:"password_hash"`
+	MarketingConsent int64     `json:"marketing_consent"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type CustomerSession struct {
+	ID        int64     `json:"id"`
+	TokenHash string    `json:"token_hash"`
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DocPage struct {
+	ID              int64         `json:"id"`
+	DocVersionID    int64         `json:"doc_version_id"`
+	ParentID        sql.NullInt64 `json:"parent_id"`
+	Title           string        `json:"title"`
+	Slug            string        `json:"slug"`
+	BodyMarkdown    string        `json:"body_markdown"`
+	BodyHtml        string        `json:"body_html"`
+	MetaDescription string        `json:"meta_description"`
+	SortOrder       int64         `json:"sort_order"`
+	IsPublished     int64         `json:"is_published"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+type DocPagesFt struct {
+	Title        string `json:"title"`
+	BodyMarkdown string `json:"body_markdown"`
+}
+
+type DocSet struct {
+	ID               int64         `json:"id"`
+	Name             string        `json:"name"`
+	Slug             string        `json:"slug"`
+	ProductID        sql.NullInt64 `json:"product_id"`
+	Description      string        `json:"description"`
+	CurrentVersionID sql.NullInt64 `json:"current_version_id"`
+	IsPublished      int64         `json:"is_published"`
+	SortOrder        int64         `json:"sort_order"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+type DocVersion struct {
+	ID        int64     `json:"id"`
+	DocSetID  int64     `json:"doc_set_id"`
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EditLock struct {
+	ContentType string    `json:"content_type"`
+	ContentID   int64     `json:"content_id"`
+	UserID      int64     `json:"user_id"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+}
+
+type EditorAttachment struct {
+	MediaFileID int64     `json:"media_file_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Event struct {
+	ID               int64     `json:"id"`
+	Title            string    `json:"title"`
+	Slug             string    `json:"slug"`
+	EventType        string    `json:"event_type"`
+	Summary          string    `json:"summary"`
+	Description      string    `json:"description"`
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	Location         string    `json:"location"`
+	WebinarUrl       string    `json:"webinar_url"`
+	RegistrationOpen int64     `json:"registration_open"`
+	Capacity         int64     `json:"capacity"`
+	IsPublished      int64     `json:"is_published"`
+	MetaDescription  string    `json:"meta_description"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type EventRegistration struct {
+	ID               int64     `json:"id"`
+	EventID          int64     `json:"event_id"`
+	Name             string    `json:"name"`
+	Email            string    `json:"email"`
+	Company          string    `json:"company"`
+	Designation      string    `json:"designation"`
+	MarketingConsent int64     `json:"marketing_consent"`
+	IpAddress        string    `json:"ip_address"`
+	UserAgent        string    `json:"user_agent"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type ExportJob struct {
+	ID         int64         `json:"id"`
+	Kind       string        `json:"kind"`
+	Format     string        `json:"format"`
+	Params     string        `json:"params"`
+	Status     string        `json:"status"`
+	RowsDone   int64         `json:"rows_done"`
+	RowsTotal  int64         `json:"rows_total"`
+	StorageKey string        `json:"storage_key"`
+	Filename   string        `json:"filename"`
+	SizeBytes  int64         `json:"size_bytes"`
+	Error      string        `json:"error"`
+	UserID     sql.NullInt64 `json:"user_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	StartedAt  sql.NullTime  `json:"started_at"`
+	FinishedAt sql.NullTime  `json:"finished_at"`
+	ExpiresAt  sql.NullTime  `json:"expires_at"`
+}
+
 type FooterColumnItem struct {
 	ID          int64  `json:"id"`
 	ColumnIndex int64  `json:"column_index"`
@@ -221,6 +459,46 @@ type FooterLink struct {
 	SortOrder    int64  `json:"sort_order"`
 }
 
+type Form struct {
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	Slug           string    `json:"slug"`
+	Description    string    `json:"description"`
+	SubmitLabel    string    `json:"submit_label"`
+	SuccessMessage string    `json:"success_message"`
+	NotifyEmails   string    `json:"notify_emails"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type FormField struct {
+	ID          int64     `json:"id"`
+	FormID      int64     `json:"form_id"`
+	Label       string    `json:"label"`
+	Name        string    `json:"name"`
+	FieldType   string    `json:"field_type"`
+	Placeholder string    `json:"placeholder"`
+	HelpText    string    `json:"help_text"`
+	Options     string    `json:"options"`
+	Required    bool      `json:"required"`
+	MinLength   int64     `json:"min_length"`
+	MaxLength   int64     `json:"max_length"`
+	SortOrder   int64     `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type FormSubmission struct {
+	ID        int64     `json:"id"`
+	FormID    int64     `json:"form_id"`
+	Data      string    `json:"data"`
+	Email     string    `json:"email"`
+	IpAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type HomepageCtum struct {
 	ID               int64          `json:"id"`
 	Headline         string         `json:"headline"`
@@ -236,19 +514,20 @@ type HomepageCtum struct {
 }
 
 type HomepageHero struct {
-	ID               int64          `json:"id"`
-	Headline         string         `json:"headline"`
-	Subheadline      string         `json:"subheadline"`
-	BadgeText        sql.NullString `json:"badge_text"`
-	PrimaryCtaText   string         `json:"primary_cta_text"`
-	PrimaryCtaUrl    string         `json:"primary_cta_url"`
-	SecondaryCtaText sql.NullString `json:"secondary_cta_text"`
-	SecondaryCtaUrl  sql.NullString `json:"secondary_cta_url"`
-	BackgroundImage  sql.NullString `json:"background_image"`
-	IsActive         int64          `json:"is_active"`
-	DisplayOrder     int64          `json:"display_order"`
-	CreatedAt        time.Time      `json:"created_at"`
-	UpdatedAt        time.Time      `json:"updated_at"`
+	ID                 int64          `json:"id"`
+	Headline           string         `json:"headline"`
+	Subheadline        string         `json:"subheadline"`
+	BadgeText          sql.NullString `json:"badge_text"`
+	PrimaryCtaText     string         `json:"primary_cta_text"`
+	PrimaryCtaUrl      string         `json:"primary_cta_url"`
+	SecondaryCtaText   sql.NullString `json:"secondary_cta_text"`
+	SecondaryCtaUrl    sql.NullString `json:"secondary_cta_url"`
+	BackgroundImage    sql.NullString `json:"background_image"`
+	IsActive           int64          `json:"is_active"`
+	DisplayOrder       int64          `json:"display_order"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	BackgroundImageAlt string         `json:"background_image_alt"`
 }
 
 type HomepageStat struct {
@@ -261,16 +540,17 @@ type HomepageStat struct {
 }
 
 type HomepageTestimonial struct {
-	ID            int64          `json:"id"`
-	Quote         string         `json:"quote"`
-	AuthorName    string         `json:"author_name"`
-	AuthorTitle   sql.NullString `json:"author_title"`
-	AuthorCompany sql.NullString `json:"author_company"`
-	AuthorImage   sql.NullString `json:"author_image"`
-	Rating        int64          `json:"rating"`
-	DisplayOrder  int64          `json:"display_order"`
-	IsActive      int64          `json:"is_active"`
-	CreatedAt     time.Time      `json:"created_at"`
+	ID             int64          `json:"id"`
+	Quote          string         `json:"quote"`
+	AuthorName     string         `json:"author_name"`
+	AuthorTitle    sql.NullString `json:"author_title"`
+	AuthorCompany  sql.NullString `json:"author_company"`
+	AuthorImage    sql.NullString `json:"author_image"`
+	Rating         int64          `json:"rating"`
+	DisplayOrder   int64          `json:"display_order"`
+	IsActive       int64          `json:"is_active"`
+	CreatedAt      time.Time      `json:"created_at"`
+	AuthorImageAlt string         `json:"author_image_alt"`
 }
 
 type Industry struct {
@@ -284,6 +564,100 @@ type Industry struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+type JobApplication struct {
+	ID          int64     `json:"id"`
+	JobID       int64     `json:"job_id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	CoverLetter string    `json:"cover_letter"`
+	CvKey       string    `json:"cv_key"`
+	CvFilename  string    `json:"cv_filename"`
+	CvSize      int64     `json:"cv_size"`
+	Status      string    `json:"status"`
+	Notes       string    `json:"notes"`
+	IpAddress   string    `json:"ip_address"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type JobPosting struct {
+	ID              int64        `json:"id"`
+	Title           string       `json:"title"`
+	Slug            string       `json:"slug"`
+	Department      string       `json:"department"`
+	Location        string       `json:"location"`
+	Country         string       `json:"country"`
+	EmploymentType  string       `json:"employment_type"`
+	Remote          int64        `json:"remote"`
+	Summary         string       `json:"summary"`
+	Description     string       `json:"description"`
+	ValidThrough    sql.NullTime `json:"valid_through"`
+	IsPublished     int64        `json:"is_published"`
+	PostedAt        sql.NullTime `json:"posted_at"`
+	MetaDescription string       `json:"meta_description"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+type JobRun struct {
+	Name           string       `json:"name"`
+	LastStartedAt  time.Time    `json:"last_started_at"`
+	LastDurationMs int64        `json:"last_duration_ms"`
+	LastError      string       `json:"last_error"`
+	LastTrigger    string       `json:"last_trigger"`
+	LastSuccessAt  sql.NullTime `json:"last_success_at"`
+	Runs           int64        `json:"runs"`
+	Failures       int64        `json:"failures"`
+}
+
+type LandingPage struct {
+	ID              int64        `json:"id"`
+	Title           string       `json:"title"`
+	Slug            string       `json:"slug"`
+	Layout          string       `json:"layout"`
+	MetaTitle       string       `json:"meta_title"`
+	MetaDescription string       `json:"meta_description"`
+	OgImage         string       `json:"og_image"`
+	Noindex         bool         `json:"noindex"`
+	HeadSnippet     string       `json:"head_snippet"`
+	BodySnippet     string       `json:"body_snippet"`
+	Status          string       `json:"status"`
+	PublishedAt     sql.NullTime `json:"published_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+type LandingPageSection struct {
+	ID            int64     `json:"id"`
+	LandingPageID int64     `json:"landing_page_id"`
+	SectionType   string    `json:"section_type"`
+	Heading       string    `json:"heading"`
+	Body          string    `json:"body"`
+	Image         string    `json:"image"`
+	ButtonText    string    `json:"button_text"`
+	ButtonUrl     string    `json:"button_url"`
+	Items         string    `json:"items"`
+	SortOrder     int64     `json:"sort_order"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type LeadMerge struct {
+	Email        string    `json:"email"`
+	PrimaryEmail string    `json:"primary_email"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Locale struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	IsDefault bool      `json:"is_default"`
+	IsEnabled bool      `json:"is_enabled"`
+	SortOrder int64     `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type MediaFile struct {
 	ID               int64          `json:"id"`
 	Filename         string         `json:"filename"`
@@ -295,6 +669,18 @@ type MediaFile struct {
 	Height           sql.NullInt64  `json:"height"`
 	AltText          sql.NullString `json:"alt_text"`
 	CreatedAt        sql.NullTime   `json:"created_at"`
+	ContentHash      string         `json:"content_hash"`
+}
+
+type MediaVariant struct {
+	ID          int64     `json:"id"`
+	MediaFileID int64     `json:"media_file_id"`
+	Name        string    `json:"name"`
+	FilePath    string    `json:"file_path"`
+	Width       int64     `json:"width"`
+	Height      int64     `json:"height"`
+	FileSize    int64     `json:"file_size"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type Milestone struct {
@@ -330,6 +716,11 @@ type NavigationItem struct {
 	IsActive       sql.NullInt64  `json:"is_active"`
 	SortOrder      sql.NullInt64  `json:"sort_order"`
 	CreatedAt      sql.NullTime   `json:"created_at"`
+	Visibility     string         `json:"visibility"`
+	Icon           string         `json:"icon"`
+	Layout         string         `json:"layout"`
+	FeaturedType   string         `json:"featured_type"`
+	FeaturedID     int64          `json:"featured_id"`
 }
 
 type NavigationMenu struct {
@@ -338,6 +729,33 @@ type NavigationMenu struct {
 	Location  string       `json:"location"`
 	CreatedAt sql.NullTime `json:"created_at"`
 	UpdatedAt sql.NullTime `json:"updated_at"`
+	IsActive  bool         `json:"is_active"`
+}
+
+type NewsletterSubscriber struct {
+	ID               int64        `json:"id"`
+	Email            string       `json:"email"`
+	Status           string       `json:"status"`
+	ConfirmTokenHash string       `json:"confirm_token_hash"`
+	ConfirmSentAt    sql.NullTime `json:"confirm_sent_at"`
+	UnsubscribeToken string       `json:"unsubscribe_token"`
+	Source           string       `json:"source"`
+	IpAddress        string       `json:"ip_address"`
+	ConfirmedAt      sql.NullTime `json:"confirmed_at"`
+	UnsubscribedAt   sql.NullTime `json:"unsubscribed_at"`
+	ProviderSyncedAt sql.NullTime `json:"provider_synced_at"`
+	ProviderError    string       `json:"provider_error"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type NotFoundHit struct {
+	ID          int64     `json:"id"`
+	Path        string    `json:"path"`
+	Referrer    string    `json:"referrer"`
+	Hits        int64     `json:"hits"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
 }
 
 type OfficeLocation struct {
@@ -358,6 +776,14 @@ type OfficeLocation struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 }
 
+type PageBlock struct {
+	ID        int64     `json:"id"`
+	Route     string    `json:"route"`
+	BlockID   int64     `json:"block_id"`
+	SortOrder int64     `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type PageSection struct {
 	ID                  int64     `json:"id"`
 	PageKey             string    `json:"page_key"`
@@ -376,6 +802,33 @@ type PageSection struct {
 	UpdatedAt           time.Time `json:"updated_at"`
 }
 
+type PageView struct {
+	ID       int64     `json:"id"`
+	Path     string    `json:"path"`
+	Referrer string    `json:"referrer"`
+	UaClass  string    `json:"ua_class"`
+	Country  string    `json:"country"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+type PageViewCount struct {
+	Day      interface{} `json:"day"`
+	Path     string      `json:"path"`
+	Referrer string      `json:"referrer"`
+	UaClass  string      `json:"ua_class"`
+	Country  string      `json:"country"`
+	Views    int64       `json:"views"`
+}
+
+type PageViewDaily struct {
+	Day      string `json:"day"`
+	Path     string `json:"path"`
+	Referrer string `json:"referrer"`
+	UaClass  string `json:"ua_class"`
+	Country  string `json:"country"`
+	Views    int64  `json:"views"`
+}
+
 type Partner struct {
 	ID           int64          `json:"id"`
 	Name         string         `json:"name"`
@@ -391,6 +844,34 @@ type Partner struct {
 	IsFeatured   int64          `json:"is_featured"`
 }
 
+type PartnerInvite struct {
+	ID         int64        `json:"id"`
+	PartnerID  int64        `json:"partner_id"`
+	Email      string       `json:"email"`
+	TokenHash  string       `json:"token_hash"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type PartnerSubmission struct {
+	ID              int64         `json:"id"`
+	PartnerID       int64         `json:"partner_id"`
+	InviteID        sql.NullInt64 `json:"invite_id"`
+	LogoUrl         string        `json:"logo_url"`
+	Description     string        `json:"description"`
+	WebsiteUrl      string        `json:"website_url"`
+	RequestedTierID sql.NullInt64 `json:"requested_tier_id"`
+	TierEvidence    string        `json:"tier_evidence"`
+	ContactName     string        `json:"contact_name"`
+	Status          string        `json:"status"`
+	ReviewNotes     string        `json:"review_notes"`
+	ReviewedAt      sql.NullTime  `json:"reviewed_at"`
+	IpAddress       string        `json:"ip_address"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
 type PartnerTestimonial struct {
 	ID           int64     `json:"id"`
 	PartnerID    int64     `json:"partner_id"`
@@ -412,6 +893,41 @@ type PartnerTier struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+type PressKitAsset struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	FilePath    string    `json:"file_path"`
+	FileSize    int64     `json:"file_size"`
+	SortOrder   int64     `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PressMention struct {
+	ID          int64     `json:"id"`
+	Outlet      string    `json:"outlet"`
+	Headline    string    `json:"headline"`
+	Url         string    `json:"url"`
+	PublishedOn time.Time `json:"published_on"`
+	IsPublished int64     `json:"is_published"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PressRelease struct {
+	ID              int64     `json:"id"`
+	Title           string    `json:"title"`
+	Slug            string    `json:"slug"`
+	Summary         string    `json:"summary"`
+	Location        string    `json:"location"`
+	Body            string    `json:"body"`
+	ReleasedAt      time.Time `json:"released_at"`
+	IsPublished     int64     `json:"is_published"`
+	MetaDescription string    `json:"meta_description"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
 type Product struct {
 	ID              int64          `json:"id"`
 	Sku             string         `json:"sku"`
@@ -432,6 +948,7 @@ type Product struct {
 	UpdatedAt       time.Time      `json:"updated_at"`
 	PublishedAt     sql.NullTime   `json:"published_at"`
 	OgImage         string         `json:"og_image"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
 }
 
 type ProductCategory struct {
@@ -445,6 +962,7 @@ type ProductCategory struct {
 	SortOrder    int64          `json:"sort_order"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
+	ParentID     sql.NullInt64  `json:"parent_id"`
 }
 
 type ProductCertification struct {
@@ -471,6 +989,22 @@ type ProductDownload struct {
 	DisplayOrder  int64          `json:"display_order"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
+	Changelog     string         `json:"changelog"`
+	SupersedesID  sql.NullInt64  `json:"supersedes_id"`
+	IsGated       int64          `json:"is_gated"`
+}
+
+type ProductDownloadLead struct {
+	ID                int64          `json:"id"`
+	ProductDownloadID int64          `json:"product_download_id"`
+	Name              string         `json:"name"`
+	Email             string         `json:"email"`
+	Company           string         `json:"company"`
+	Designation       sql.NullString `json:"designation"`
+	MarketingConsent  int64          `json:"marketing_consent"`
+	IpAddress         sql.NullString `json:"ip_address"`
+	UserAgent         sql.NullString `json:"user_agent"`
+	CreatedAt         time.Time      `json:"created_at"`
 }
 
 type ProductFeature struct {
@@ -490,6 +1024,8 @@ type ProductImage struct {
 	DisplayOrder int64          `json:"display_order"`
 	IsThumbnail  bool           `json:"is_thumbnail"`
 	CreatedAt    time.Time      `json:"created_at"`
+	MediaType    string         `json:"media_type"`
+	MediaUrl     string         `json:"media_url"`
 }
 
 type ProductSpec struct {
@@ -502,12 +1038,76 @@ type ProductSpec struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type ProductVariant struct {
+	ID           int64     `json:"id"`
+	ProductID    int64     `json:"product_id"`
+	Sku          string    `json:"sku"`
+	Name         string    `json:"name"`
+	DisplayOrder int64     `json:"display_order"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ProductVariantImage struct {
+	ID           int64     `json:"id"`
+	VariantID    int64     `json:"variant_id"`
+	ImagePath    string    `json:"image_path"`
+	AltText      string    `json:"alt_text"`
+	DisplayOrder int64     `json:"display_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ProductVariantSpec struct {
+	ID           int64     `json:"id"`
+	VariantID    int64     `json:"variant_id"`
+	SectionName  string    `json:"section_name"`
+	SpecKey      string    `json:"spec_key"`
+	SpecValue    string    `json:"spec_value"`
+	DisplayOrder int64     `json:"display_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type ProductsFt struct {
 	Name        string `json:"name"`
 	Tagline     string `json:"tagline"`
 	Description string `json:"description"`
 }
 
+type Redirect struct {
+	ID          int64        `json:"id"`
+	SourcePath  string       `json:"source_path"`
+	TargetPath  string       `json:"target_path"`
+	StatusCode  int64        `json:"status_code"`
+	IsAutomatic bool         `json:"is_automatic"`
+	Hits        int64        `json:"hits"`
+	LastHitAt   sql.NullTime `json:"last_hit_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type RelatedContentPin struct {
+	ID           int64     `json:"id"`
+	ProductID    int64     `json:"product_id"`
+	ContentType  string    `json:"content_type"`
+	ContentID    int64     `json:"content_id"`
+	DisplayOrder int64     `json:"display_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type SeoMetum struct {
+	ID              int64     `json:"id"`
+	Route           string    `json:"route"`
+	EntityType      string    `json:"entity_type"`
+	EntityID        int64     `json:"entity_id"`
+	MetaTitle       string    `json:"meta_title"`
+	MetaDescription string    `json:"meta_description"`
+	CanonicalUrl    string    `json:"canonical_url"`
+	OgImage         string    `json:"og_image"`
+	Noindex         bool      `json:"noindex"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
 type Setting struct {
 	ID                       int64     `json:"id"`
 	SiteName                 string    `json:"site_name"`
@@ -598,6 +1198,47 @@ type Setting struct {
 	BlogShowCategories       int64     `json:"blog_show_categories"`
 	BlogShowTags             int64     `json:"blog_show_tags"`
 	BlogShowSearch           int64     `json:"blog_show_search"`
+	MtProvider               string    `json:"mt_provider"`
+	MtApiKey                 string    `json:"mt_api_key"`
+	MinifyHtml               bool      `json:"minify_html"`
+	ThemeMode                string    `json:"theme_mode"`
+	ThemeLightPrimary        string    `json:"theme_light_primary"`
+	ThemeLightPrimaryHover   string    `json:"theme_light_primary_hover"`
+	ThemeLightBackground     string    `json:"theme_light_background"`
+	ThemeLightSurface        string    `json:"theme_light_surface"`
+	ThemeLightText           string    `json:"theme_light_text"`
+	ThemeLightBorder         string    `json:"theme_light_border"`
+	ThemeDarkPrimary         string    `json:"theme_dark_primary"`
+	ThemeDarkPrimaryHover    string    `json:"theme_dark_primary_hover"`
+	ThemeDarkBackground      string    `json:"theme_dark_background"`
+	ThemeDarkSurface         string    `json:"theme_dark_surface"`
+	ThemeDarkText            string    `json:"theme_dark_text"`
+	ThemeDarkBorder          string    `json:"theme_dark_border"`
+	Timezone                 string    `json:"timezone"`
+	MaintenanceMode          bool      `json:"maintenance_mode"`
+	MaintenanceMessage       string    `json:"maintenance_message"`
+	AdminIpAllow             string    `json:"admin_ip_allow"`
+	AdminIpDeny              string    `json:"admin_ip_deny"`
+	FormatLocale             string    `json:"format_locale"`
+	Currency                 string    `json:"currency"`
+	TlsDomains               string    `json:"tls_domains"`
+}
+
+type ShortLink struct {
+	ID          int64        `json:"id"`
+	Code        string       `json:"code"`
+	Destination string       `json:"destination"`
+	UtmSource   string       `json:"utm_source"`
+	UtmMedium   string       `json:"utm_medium"`
+	UtmCampaign string       `json:"utm_campaign"`
+	UtmTerm     string       `json:"utm_term"`
+	UtmContent  string       `json:"utm_content"`
+	Note        string       `json:"note"`
+	Clicks      int64        `json:"clicks"`
+	LastClickAt sql.NullTime `json:"last_click_at"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
 }
 
 type Solution struct {
@@ -618,6 +1259,7 @@ type Solution struct {
 	UpdatedAt        sql.NullTime   `json:"updated_at"`
 	MetaTitle        string         `json:"meta_title"`
 	OgImage          string         `json:"og_image"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
 }
 
 type SolutionChallenge struct {
@@ -678,6 +1320,30 @@ type SolutionsListingCtum struct {
 	IsActive            sql.NullBool   `json:"is_active"`
 }
 
+type Translation struct {
+	ID         int64     `json:"id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   int64     `json:"entity_id"`
+	Locale     string    `json:"locale"`
+	Fields     string    `json:"fields"`
+	SourceHash string    `json:"source_hash"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Webhook struct {
+	ID              int64        `json:"id"`
+	Url             string       `json:"url"`
+	Secret          string       `json:"secret"`
+	Events          string       `json:"events"`
+	IsActive        bool         `json:"is_active"`
+	LastStatus      int64        `json:"last_status"`
+	LastError       string       `json:"last_error"`
+	LastDeliveredAt sql.NullTime `json:"last_delivered_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
 type Whitepaper struct {
 	ID              int64          `json:"id"`
 	Title           string         `json:"title"`
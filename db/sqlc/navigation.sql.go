@@ -12,7 +12,7 @@ import (
 
 const createNavigationItem = `-- name: CreateNavigationItem :one
 INSERT INTO navigation_items (menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order, created_at
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order, created_at, visibility, icon, layout, featured_type, featured_id
 `
 
 type CreateNavigationItemParams struct {
@@ -72,12 +72,17 @@ func (q *Queries) CreateNavigationItem(ctx context.Context, arg CreateNavigation
 		&i.IsActive,
 		&i.SortOrder,
 		&i.CreatedAt,
+		&i.Visibility,
+		&i.Icon,
+		&i.Layout,
+		&i.FeaturedType,
+		&i.FeaturedID,
 	)
 	return i, err
 }
 
 const createNavigationMenu = `-- name: CreateNavigationMenu :one
-INSERT INTO navigation_menus (name, location) VALUES (?, ?) RETURNING id, name, location, created_at, updated_at
+INSERT INTO navigation_menus (name, location) VALUES (?, ?) RETURNING id, name, location, created_at, updated_at, is_active
 `
 
 type CreateNavigationMenuParams struct {
@@ -104,10 +109,34 @@ func (q *Queries) CreateNavigationMenu(ctx context.Context, arg CreateNavigation
 		&i.Location,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsActive,
 	)
 	return i, err
 }
 
+const deactivateOtherNavigationMenus = `-- name: DeactivateOtherNavigationMenus :exec
+UPDATE navigation_menus SET is_active = 0, updated_at = CURRENT_TIMESTAMP
+WHERE location = ? AND id != ? AND is_active = 1
+`
+
+type DeactivateOtherNavigationMenusParams struct {
+	Location string `json:"location"`
+	ID       int64  `json:"id"`
+}
+
+// Switches off every other menu at a location before a menu is activated.
+//
+// Parameters:
+//
+//	$1 (TEXT) - location: Location of the menu being activated
+//	$2 (INTEGER) - id: Menu ID being activated (left unchanged)
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+func (q *Queries) DeactivateOtherNavigationMenus(ctx context.Context, arg DeactivateOtherNavigationMenusParams) error {
+	_, err := q.db.ExecContext(ctx, deactivateOtherNavigationMenus, arg.Location, arg.ID)
+	return err
+}
+
 const deleteNavigationItem = `-- name: DeleteNavigationItem :exec
 DELETE FROM navigation_items WHERE id = ?
 `
@@ -166,7 +195,7 @@ func (q *Queries) DeleteNavigationMenu(ctx context.Context, id int64) error {
 }
 
 const getNavigationItem = `-- name: GetNavigationItem :one
-SELECT id, menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order, created_at FROM navigation_items WHERE id = ? LIMIT 1
+SELECT id, menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order, created_at, visibility, icon, layout, featured_type, featured_id FROM navigation_items WHERE id = ? LIMIT 1
 `
 
 // Retrieves a single navigation item by its primary key ID.
@@ -193,12 +222,17 @@ func (q *Queries) GetNavigationItem(ctx context.Context, id int64) (NavigationIt
 		&i.IsActive,
 		&i.SortOrder,
 		&i.CreatedAt,
+		&i.Visibility,
+		&i.Icon,
+		&i.Layout,
+		&i.FeaturedType,
+		&i.FeaturedID,
 	)
 	return i, err
 }
 
 const getNavigationMenu = `-- name: GetNavigationMenu :one
-SELECT id, name, location, created_at, updated_at FROM navigation_menus WHERE id = ? LIMIT 1
+SELECT id, name, location, created_at, updated_at, is_active FROM navigation_menus WHERE id = ? LIMIT 1
 `
 
 // Retrieves a single navigation menu by its primary key ID.
@@ -219,13 +253,83 @@ func (q *Queries) GetNavigationMenu(ctx context.Context, id int64) (NavigationMe
 		&i.Location,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsActive,
 	)
 	return i, err
 }
 
+const listActiveNavigationItems = `-- name: ListActiveNavigationItems :many
+SELECT m.location, i.id, i.parent_id, i.label, i.link_type, i.url, i.page_identifier, i.open_new_tab, i.visibility,
+       i.icon, i.layout, i.featured_type, i.featured_id
+FROM navigation_items i
+JOIN navigation_menus m ON m.id = i.menu_id
+WHERE m.is_active = 1 AND i.is_active = 1
+ORDER BY m.location, i.sort_order ASC, i.id ASC
+`
+
+type ListActiveNavigationItemsRow struct {
+	Location       string         `json:"location"`
+	ID             int64          `json:"id"`
+	ParentID       sql.NullInt64  `json:"parent_id"`
+	Label          string         `json:"label"`
+	LinkType       string         `json:"link_type"`
+	Url            sql.NullString `json:"url"`
+	PageIdentifier sql.NullString `json:"page_identifier"`
+	OpenNewTab     sql.NullInt64  `json:"open_new_tab"`
+	Visibility     string         `json:"visibility"`
+	Icon           string         `json:"icon"`
+	Layout         string         `json:"layout"`
+	FeaturedType   string         `json:"featured_type"`
+	FeaturedID     int64          `json:"featured_id"`
+}
+
+// Retrieves the visible items of every active menu, for rendering on the public site.
+//
+// Parameters: none
+// Returns: []ListActiveNavigationItemsRow - Items with their menu's location, in display order
+//
+// Filtering: active menus (navigation_menus.is_active = 1) and visible items (is_active = 1)
+// Note: Children of hidden items are returned too; application drops them when building the hierarchy
+func (q *Queries) ListActiveNavigationItems(ctx context.Context) ([]ListActiveNavigationItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveNavigationItems)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListActiveNavigationItemsRow{}
+	for rows.Next() {
+		var i ListActiveNavigationItemsRow
+		if err := rows.Scan(
+			&i.Location,
+			&i.ID,
+			&i.ParentID,
+			&i.Label,
+			&i.LinkType,
+			&i.Url,
+			&i.PageIdentifier,
+			&i.OpenNewTab,
+			&i.Visibility,
+			&i.Icon,
+			&i.Layout,
+			&i.FeaturedType,
+			&i.FeaturedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listNavigationItems = `-- name: ListNavigationItems :many
 
-SELECT id, menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order, created_at FROM navigation_items WHERE menu_id = ? ORDER BY sort_order ASC
+SELECT id, menu_id, parent_id, label, link_type, url, page_identifier, open_new_tab, is_active, sort_order, created_at, visibility, icon, layout, featured_type, featured_id FROM navigation_items WHERE menu_id = ? ORDER BY sort_order ASC
 `
 
 // ====================================================================
@@ -263,6 +367,11 @@ func (q *Queries) ListNavigationItems(ctx context.Context, menuID int64) ([]Navi
 			&i.IsActive,
 			&i.SortOrder,
 			&i.CreatedAt,
+			&i.Visibility,
+			&i.Icon,
+			&i.Layout,
+			&i.FeaturedType,
+			&i.FeaturedID,
 		); err != nil {
 			return nil, err
 		}
@@ -280,7 +389,7 @@ func (q *Queries) ListNavigationItems(ctx context.Context, menuID int64) ([]Navi
 const listNavigationMenus = `-- name: ListNavigationMenus :many
 
 
-SELECT id, name, location, created_at, updated_at FROM navigation_menus ORDER BY created_at DESC
+SELECT id, name, location, created_at, updated_at, is_active FROM navigation_menus ORDER BY created_at DESC
 `
 
 // ====================================================================
@@ -323,6 +432,7 @@ func (q *Queries) ListNavigationMenus(ctx context.Context) ([]NavigationMenu, er
 			&i.Location,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsActive,
 		); err != nil {
 			return nil, err
 		}
@@ -337,9 +447,34 @@ func (q *Queries) ListNavigationMenus(ctx context.Context) ([]NavigationMenu, er
 	return items, nil
 }
 
+const setNavigationMenuActive = `-- name: SetNavigationMenuActive :exec
+UPDATE navigation_menus SET is_active = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?
+`
+
+type SetNavigationMenuActiveParams struct {
+	IsActive bool  `json:"is_active"`
+	ID       int64 `json:"id"`
+}
+
+// Switches public rendering of a menu on or off.
+//
+// Parameters:
+//
+//	$1 (BOOLEAN) - is_active: Whether the menu replaces the built-in links at its location
+//	$2 (INTEGER) - id: Menu ID to update
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Note: Call DeactivateOtherNavigationMenus first, so one menu per location is active
+func (q *Queries) SetNavigationMenuActive(ctx context.Context, arg SetNavigationMenuActiveParams) error {
+	_, err := q.db.ExecContext(ctx, setNavigationMenuActive, arg.IsActive, arg.ID)
+	return err
+}
+
 const updateNavigationItem = `-- name: UpdateNavigationItem :exec
 UPDATE navigation_items
-SET label = ?, link_type = ?, url = ?, page_identifier = ?, open_new_tab = ?, is_active = ?, parent_id = ?, sort_order = ?
+SET label = ?, link_type = ?, url = ?, page_identifier = ?, open_new_tab = ?, is_active = ?, parent_id = ?, sort_order = ?, visibility = ?,
+    icon = ?, layout = ?, featured_type = ?, featured_id = ?
 WHERE id = ?
 `
 
@@ -352,6 +487,11 @@ type UpdateNavigationItemParams struct {
 	IsActive       sql.NullInt64  `json:"is_active"`
 	ParentID       sql.NullInt64  `json:"parent_id"`
 	SortOrder      sql.NullInt64  `json:"sort_order"`
+	Visibility     string         `json:"visibility"`
+	Icon           string         `json:"icon"`
+	Layout         string         `json:"layout"`
+	FeaturedType   string         `json:"featured_type"`
+	FeaturedID     int64          `json:"featured_id"`
 	ID             int64          `json:"id"`
 }
 
@@ -367,7 +507,12 @@ type UpdateNavigationItemParams struct {
 //	$6 (BOOLEAN) - is_active: Updated visibility status
 //	$7 (INTEGER) - parent_id: Updated parent (for moving in hierarchy)
 //	$8 (INTEGER) - sort_order: Updated display position
-//	$9 (INTEGER) - id: Item ID to update
+//	$9 (TEXT) - visibility: Updated layout visibility ("all", "desktop", "mobile")
+//	$10 (TEXT) - icon: Updated Material Symbols icon name (empty for none)
+//	$11 (TEXT) - layout: Updated dropdown layout ("list", "mega")
+//	$12 (TEXT) - featured_type: Updated featured content type (empty for none)
+//	$13 (INTEGER) - featured_id: Updated featured content ID
+//	$14 (INTEGER) - id: Item ID to update
 //
 // Returns: (none) - sqlc annotation :exec returns only row count
 //
@@ -382,6 +527,11 @@ func (q *Queries) UpdateNavigationItem(ctx context.Context, arg UpdateNavigation
 		arg.IsActive,
 		arg.ParentID,
 		arg.SortOrder,
+		arg.Visibility,
+		arg.Icon,
+		arg.Layout,
+		arg.FeaturedType,
+		arg.FeaturedID,
 		arg.ID,
 	)
 	return err
@@ -10,9 +10,29 @@ import (
 	"database/sql"
 )
 
+const countChildProductCategories = `-- name: CountChildProductCategories :one
+SELECT COUNT(*) FROM product_categories WHERE parent_id = ?
+`
+
+// Returns the number of direct subcategories of a category.
+//
+// Parameters:
+//
+//	$1 (INTEGER) - parent category ID
+//
+// Returns: INTEGER - Number of child categories
+//
+// Use case: Blocking deletion of categories that still have subcategories
+func (q *Queries) CountChildProductCategories(ctx context.Context, parentID sql.NullInt64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countChildProductCategories, parentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProductCategory = `-- name: CreateProductCategory :one
 INSERT INTO product_categories (name, slug, description, icon, image_url, sort_order)
-VALUES (?, ?, ?, ?, ?, ?) RETURNING id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at
+VALUES (?, ?, ?, ?, ?, ?) RETURNING id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at, parent_id
 `
 
 type CreateProductCategoryParams struct {
@@ -59,6 +79,7 @@ func (q *Queries) CreateProductCategory(ctx context.Context, arg CreateProductCa
 		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -83,7 +104,7 @@ func (q *Queries) DeleteProductCategory(ctx context.Context, id int64) error {
 }
 
 const getProductCategory = `-- name: GetProductCategory :one
-SELECT id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at FROM product_categories WHERE id = ? LIMIT 1
+SELECT id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at, parent_id FROM product_categories WHERE id = ? LIMIT 1
 `
 
 // Retrieves a single product category by its primary key ID.
@@ -109,12 +130,13 @@ func (q *Queries) GetProductCategory(ctx context.Context, id int64) (ProductCate
 		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const getProductCategoryBySlug = `-- name: GetProductCategoryBySlug :one
-SELECT id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at FROM product_categories WHERE slug = ? LIMIT 1
+SELECT id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at, parent_id FROM product_categories WHERE slug = ? LIMIT 1
 `
 
 // Retrieves a single product category by its URL-safe slug identifier.
@@ -141,13 +163,14 @@ func (q *Queries) GetProductCategoryBySlug(ctx context.Context, slug string) (Pr
 		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const listProductCategories = `-- name: ListProductCategories :many
 
-SELECT id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at FROM product_categories ORDER BY sort_order ASC, name ASC
+SELECT id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at, parent_id FROM product_categories ORDER BY sort_order ASC, name ASC
 `
 
 // ====================================================================
@@ -196,6 +219,7 @@ func (q *Queries) ListProductCategories(ctx context.Context) ([]ProductCategory,
 			&i.SortOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
@@ -210,8 +234,95 @@ func (q *Queries) ListProductCategories(ctx context.Context) ([]ProductCategory,
 	return items, nil
 }
 
+const listProductCategoriesWithCounts = `-- name: ListProductCategoriesWithCounts :many
+SELECT pc.id, pc.name, pc.slug, pc.description, pc.icon, pc.image_url, pc.product_count, pc.sort_order, pc.created_at, pc.updated_at, pc.parent_id, COUNT(p.id) AS published_count
+FROM product_categories pc
+LEFT JOIN products p ON p.category_id = pc.id AND p.status = 'published' AND p.deleted_at IS NULL
+GROUP BY pc.id
+ORDER BY pc.sort_order ASC, pc.name ASC
+`
+
+type ListProductCategoriesWithCountsRow struct {
+	ProductCategory ProductCategory `json:"product_category"`
+	PublishedCount  int64           `json:"published_count"`
+}
+
+// Retrieves all product categories, in ListProductCategories order, each with
+// the live count of its published products.
+//
+// Parameters: none
+// Returns: []ListProductCategoriesWithCountsRow - Category record and
+//
+//	published_count (products directly in the category, not its children)
+//
+// Note: One grouped LEFT JOIN instead of CountProductsByCategory per category;
+//
+//	categories without products get 0. The stored product_count column is
+//	not maintained and is not used for this.
+//
+// Use case: Public product catalog category tree, footer category links
+func (q *Queries) ListProductCategoriesWithCounts(ctx context.Context) ([]ListProductCategoriesWithCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductCategoriesWithCounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductCategoriesWithCountsRow{}
+	for rows.Next() {
+		var i ListProductCategoriesWithCountsRow
+		if err := rows.Scan(
+			&i.ProductCategory.ID,
+			&i.ProductCategory.Name,
+			&i.ProductCategory.Slug,
+			&i.ProductCategory.Description,
+			&i.ProductCategory.Icon,
+			&i.ProductCategory.ImageUrl,
+			&i.ProductCategory.ProductCount,
+			&i.ProductCategory.SortOrder,
+			&i.ProductCategory.CreatedAt,
+			&i.ProductCategory.UpdatedAt,
+			&i.ProductCategory.ParentID,
+			&i.PublishedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setProductCategoryParent = `-- name: SetProductCategoryParent :exec
+UPDATE product_categories SET parent_id = ?1, updated_at = CURRENT_TIMESTAMP WHERE id = ?2
+`
+
+type SetProductCategoryParentParams struct {
+	ParentID sql.NullInt64 `json:"parent_id"`
+	ID       int64         `json:"id"`
+}
+
+// Moves a category under another category, or to the top level.
+//
+// Parameters:
+//
+//	@parent_id (INTEGER) - Parent category ID (NULL for a top-level family)
+//	@id (INTEGER) - Category ID to move
+//
+// Returns: (none)
+//
+// Note: Depth and cycle checks happen in services.ValidateCategoryParent
+func (q *Queries) SetProductCategoryParent(ctx context.Context, arg SetProductCategoryParentParams) error {
+	_, err := q.db.ExecContext(ctx, setProductCategoryParent, arg.ParentID, arg.ID)
+	return err
+}
+
 const updateProductCategory = `-- name: UpdateProductCategory :one
-UPDATE product_categories SET name = ?, slug = ?, description = ?, icon = ?, image_url = ?, sort_order = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at
+UPDATE product_categories SET name = ?, slug = ?, description = ?, icon = ?, image_url = ?, sort_order = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING id, name, slug, description, icon, image_url, product_count, sort_order, created_at, updated_at, parent_id
 `
 
 type UpdateProductCategoryParams struct {
@@ -261,6 +372,7 @@ func (q *Queries) UpdateProductCategory(ctx context.Context, arg UpdateProductCa
 		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParentID,
 	)
 	return i, err
 }
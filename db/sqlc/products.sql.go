@@ -12,7 +12,7 @@ import (
 )
 
 const countProducts = `-- name: CountProducts :one
-SELECT COUNT(*) FROM products WHERE status = 'published'
+SELECT COUNT(*) FROM products WHERE status = 'published' AND deleted_at IS NULL
 `
 
 // Returns the total count of published products.
@@ -31,8 +31,8 @@ func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
 
 const countProductsAdminFiltered = `-- name: CountProductsAdminFiltered :one
 SELECT COUNT(*) FROM products p
-WHERE
-    (CASE WHEN ?1 = '' THEN 1 ELSE p.status = ?1 END)
+WHERE p.deleted_at IS NULL
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE p.status = ?1 END)
     AND (CASE WHEN ?2 = 0 THEN 1 ELSE p.category_id = ?2 END)
     AND (CASE WHEN ?3 = '' THEN 1 ELSE (p.name LIKE '%' || ?3 || '%' OR p.sku LIKE '%' || ?3 || '%') END)
 `
@@ -58,7 +58,7 @@ func (q *Queries) CountProductsAdminFiltered(ctx context.Context, arg CountProdu
 }
 
 const countProductsByCategory = `-- name: CountProductsByCategory :one
-SELECT COUNT(*) FROM products WHERE category_id = ? AND status = 'published'
+SELECT COUNT(*) FROM products WHERE category_id = ? AND status = 'published' AND deleted_at IS NULL
 `
 
 // Returns the count of published products in a specific category.
@@ -77,6 +77,34 @@ func (q *Queries) CountProductsByCategory(ctx context.Context, categoryID int64)
 	return count, err
 }
 
+const countProductsInCategoryTree = `-- name: CountProductsInCategoryTree :one
+SELECT COUNT(*)
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = ?1
+    OR pc.parent_id = ?1
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = ?1)
+)
+`
+
+// Returns the count of published products in a category and its
+// subcategories (children and grandchildren, see ListProductsInCategoryTree).
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Root of the category subtree
+//
+// Returns: INTEGER - Number of published products in the subtree
+//
+// Use case: Rolled-up category page pagination
+func (q *Queries) CountProductsInCategoryTree(ctx context.Context, categoryID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProductsInCategoryTree, categoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 
 
@@ -85,7 +113,7 @@ INSERT INTO products (
     category_id, status, is_featured, featured_order,
     meta_title, meta_description, primary_image, video_url, published_at
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image
+RETURNING id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at
 `
 
 type CreateProductParams struct {
@@ -193,6 +221,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.UpdatedAt,
 		&i.PublishedAt,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -256,9 +285,9 @@ func (q *Queries) CreateProductCertification(ctx context.Context, arg CreateProd
 
 const createProductDownload = `-- name: CreateProductDownload :one
 
-INSERT INTO product_downloads (product_id, title, description, file_type, file_path, file_size, version, display_order)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, product_id, title, description, file_type, file_path, file_size, version, download_count, display_order, created_at, updated_at
+INSERT INTO product_downloads (product_id, title, description, file_type, file_path, file_size, version, display_order, changelog, supersedes_id, is_gated)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, product_id, title, description, file_type, file_path, file_size, version, download_count, display_order, created_at, updated_at, changelog, supersedes_id, is_gated
 `
 
 type CreateProductDownloadParams struct {
@@ -270,6 +299,9 @@ type CreateProductDownloadParams struct {
 	FileSize     sql.NullInt64  `json:"file_size"`
 	Version      sql.NullString `json:"version"`
 	DisplayOrder int64          `json:"display_order"`
+	Changelog    string         `json:"changelog"`
+	SupersedesID sql.NullInt64  `json:"supersedes_id"`
+	IsGated      int64          `json:"is_gated"`
 }
 
 // ====================================================================
@@ -287,6 +319,9 @@ type CreateProductDownloadParams struct {
 //	$6 (INTEGER) - file_size: File size in bytes
 //	$7 (TEXT) - version: Document/file version (e.g., "v2.1", "Rev A")
 //	$8 (INTEGER) - display_order: Position in downloads list
+//	$9 (TEXT) - changelog: What changed in this version ('' for none)
+//	$10 (INTEGER) - supersedes_id: The previous version of this file (NULL for a new file)
+//	$11 (INTEGER) - is_gated: 1 when the file is only handed out after the lead form
 //
 // Returns: ProductDownload - The newly created download with auto-generated ID
 //
@@ -302,6 +337,9 @@ func (q *Queries) CreateProductDownload(ctx context.Context, arg CreateProductDo
 		arg.FileSize,
 		arg.Version,
 		arg.DisplayOrder,
+		arg.Changelog,
+		arg.SupersedesID,
+		arg.IsGated,
 	)
 	var i ProductDownload
 	err := row.Scan(
@@ -317,6 +355,69 @@ func (q *Queries) CreateProductDownload(ctx context.Context, arg CreateProductDo
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Changelog,
+		&i.SupersedesID,
+		&i.IsGated,
+	)
+	return i, err
+}
+
+const createProductDownloadLead = `-- name: CreateProductDownloadLead :one
+INSERT INTO product_download_leads (product_download_id, name, email, company, designation, marketing_consent, ip_address, user_agent)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, product_download_id, name, email, company, designation, marketing_consent, ip_address, user_agent, created_at
+`
+
+type CreateProductDownloadLeadParams struct {
+	ProductDownloadID int64          `json:"product_download_id"`
+	Name              string         `json:"name"`
+	Email             string         `json:"email"`
+	Company           string         `json:"company"`
+	Designation       sql.NullString `json:"designation"`
+	MarketingConsent  int64          `json:"marketing_consent"`
+	IpAddress         sql.NullString `json:"ip_address"`
+	UserAgent         sql.NullString `json:"user_agent"`
+}
+
+// Records the lead form submitted for a gated product download.
+//
+// Parameters:
+//
+//	$1 (INTEGER) - product_download_id: Download being requested
+//	$2 (TEXT) - name: Visitor's name
+//	$3 (TEXT) - email: Visitor's email (lead capture)
+//	$4 (TEXT) - company: Visitor's company
+//	$5 (TEXT) - designation: Visitor's job title (optional)
+//	$6 (INTEGER) - marketing_consent: 1 if the visitor opted in to marketing
+//	$7 (TEXT) - ip_address: Visitor's IP address
+//	$8 (TEXT) - user_agent: Visitor's browser user agent
+//
+// Returns: ProductDownloadLead - The recorded lead
+//
+// Use case: The gated download form on product pages, before the signed link is issued
+func (q *Queries) CreateProductDownloadLead(ctx context.Context, arg CreateProductDownloadLeadParams) (ProductDownloadLead, error) {
+	row := q.db.QueryRowContext(ctx, createProductDownloadLead,
+		arg.ProductDownloadID,
+		arg.Name,
+		arg.Email,
+		arg.Company,
+		arg.Designation,
+		arg.MarketingConsent,
+		arg.IpAddress,
+		arg.UserAgent,
+	)
+	var i ProductDownloadLead
+	err := row.Scan(
+		&i.ID,
+		&i.ProductDownloadID,
+		&i.Name,
+		&i.Email,
+		&i.Company,
+		&i.Designation,
+		&i.MarketingConsent,
+		&i.IpAddress,
+		&i.UserAgent,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -364,9 +465,9 @@ func (q *Queries) CreateProductFeature(ctx context.Context, arg CreateProductFea
 
 const createProductImage = `-- name: CreateProductImage :one
 
-INSERT INTO product_images (product_id, image_path, alt_text, caption, display_order, is_thumbnail)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, product_id, image_path, alt_text, caption, display_order, is_thumbnail, created_at
+INSERT INTO product_images (product_id, image_path, alt_text, caption, display_order, is_thumbnail, media_type, media_url)
+VALUES (?, ?, ?, ?, ?, ?, COALESCE(NULLIF(?, ''), 'image'), ?)
+RETURNING id, product_id, image_path, alt_text, caption, display_order, is_thumbnail, created_at, media_type, media_url
 `
 
 type CreateProductImageParams struct {
@@ -376,12 +477,14 @@ type CreateProductImageParams struct {
 	Caption      sql.NullString `json:"caption"`
 	DisplayOrder int64          `json:"display_order"`
 	IsThumbnail  bool           `json:"is_thumbnail"`
+	MediaType    string         `json:"media_type"`
+	MediaUrl     string         `json:"media_url"`
 }
 
 // ====================================================================
 // PRODUCT IMAGES (Gallery Images)
 // ====================================================================
-// Adds a gallery image to a product.
+// Adds a gallery image, video or 3D/CAD file to a product.
 //
 // Parameters:
 //
@@ -391,6 +494,8 @@ type CreateProductImageParams struct {
 //	$4 (TEXT) - caption: Optional image caption for display
 //	$5 (INTEGER) - display_order: Position in image gallery
 //	$6 (BOOLEAN) - is_thumbnail: Whether this image is the thumbnail (usually first image)
+//	$7 (TEXT) - media_type: 'image', 'video' or 'model'; '' means 'image'
+//	$8 (TEXT) - media_url: Video player URL or uploaded video/3D file, '' for images
 //
 // Returns: ProductImage - The newly created image record with auto-generated ID
 //
@@ -404,6 +509,8 @@ func (q *Queries) CreateProductImage(ctx context.Context, arg CreateProductImage
 		arg.Caption,
 		arg.DisplayOrder,
 		arg.IsThumbnail,
+		arg.MediaType,
+		arg.MediaUrl,
 	)
 	var i ProductImage
 	err := row.Scan(
@@ -415,6 +522,8 @@ func (q *Queries) CreateProductImage(ctx context.Context, arg CreateProductImage
 		&i.DisplayOrder,
 		&i.IsThumbnail,
 		&i.CreatedAt,
+		&i.MediaType,
+		&i.MediaUrl,
 	)
 	return i, err
 }
@@ -680,7 +789,7 @@ func (q *Queries) DeleteProductSpecs(ctx context.Context, productID int64) error
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image FROM products WHERE id = ? LIMIT 1
+SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at FROM products WHERE id = ? LIMIT 1
 `
 
 // Retrieves a single product by its primary key ID (all statuses).
@@ -716,12 +825,13 @@ func (q *Queries) GetProduct(ctx context.Context, id int64) (Product, error) {
 		&i.UpdatedAt,
 		&i.PublishedAt,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getProductBySKU = `-- name: GetProductBySKU :one
-SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image FROM products WHERE sku = ? LIMIT 1
+SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at FROM products WHERE sku = ? LIMIT 1
 `
 
 // Retrieves a single product by its SKU/product code.
@@ -757,12 +867,13 @@ func (q *Queries) GetProductBySKU(ctx context.Context, sku string) (Product, err
 		&i.UpdatedAt,
 		&i.PublishedAt,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getProductBySlug = `-- name: GetProductBySlug :one
-SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image FROM products WHERE slug = ? LIMIT 1
+SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at FROM products WHERE slug = ? AND deleted_at IS NULL LIMIT 1
 `
 
 // Retrieves a single product by its URL-safe slug (all statuses).
@@ -798,12 +909,13 @@ func (q *Queries) GetProductBySlug(ctx context.Context, slug string) (Product, e
 		&i.UpdatedAt,
 		&i.PublishedAt,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getProductDownload = `-- name: GetProductDownload :one
-SELECT id, product_id, title, description, file_type, file_path, file_size, version, download_count, display_order, created_at, updated_at FROM product_downloads WHERE id = ? LIMIT 1
+SELECT id, product_id, title, description, file_type, file_path, file_size, version, download_count, display_order, created_at, updated_at, changelog, supersedes_id, is_gated FROM product_downloads WHERE id = ? LIMIT 1
 `
 
 // Retrieves a single product download by its ID.
@@ -831,6 +943,130 @@ func (q *Queries) GetProductDownload(ctx context.Context, id int64) (ProductDown
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Changelog,
+		&i.SupersedesID,
+		&i.IsGated,
+	)
+	return i, err
+}
+
+const getProductDownloadFileUse = `-- name: GetProductDownloadFileUse :one
+SELECT CAST(COALESCE(SUM(is_gated = 1), 0) AS INTEGER) AS gated,
+       CAST(COALESCE(SUM(is_gated != 1), 0) AS INTEGER) AS ungated
+FROM product_downloads
+WHERE file_path = ?
+`
+
+type GetProductDownloadFileUseRow struct {
+	Gated   int64 `json:"gated"`
+	Ungated int64 `json:"ungated"`
+}
+
+// Counts the gated and ungated downloads that share one file. Product copies
+// share their downloads' files, so a file may be used by both.
+//
+// Parameters:
+//
+//	$1 (TEXT) - file_path: Stored file path (/uploads/downloads/...)
+//
+// Returns: GetProductDownloadFileUseRow - gated and ungated download counts
+//
+// Use case: DownloadFiles keeps a file out of the public uploads while only
+// gated downloads use it
+func (q *Queries) GetProductDownloadFileUse(ctx context.Context, filePath string) (GetProductDownloadFileUseRow, error) {
+	row := q.db.QueryRowContext(ctx, getProductDownloadFileUse, filePath)
+	var i GetProductDownloadFileUseRow
+	err := row.Scan(&i.Gated, &i.Ungated)
+	return i, err
+}
+
+const getPublishedProductCard = `-- name: GetPublishedProductCard :one
+
+SELECT p.id, p.slug, p.name, p.tagline, p.primary_image, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.id = ? AND p.status = 'published' AND p.deleted_at IS NULL
+`
+
+type GetPublishedProductCardRow struct {
+	ID           int64          `json:"id"`
+	Slug         string         `json:"slug"`
+	Name         string         `json:"name"`
+	Tagline      sql.NullString `json:"tagline"`
+	PrimaryImage sql.NullString `json:"primary_image"`
+	CategorySlug string         `json:"category_slug"`
+}
+
+// ====================================================================
+// RECENTLY VIEWED
+// ====================================================================
+// Retrieves the fields of a published product needed for a small product
+// card, with its category slug for the URL.
+//
+// Parameters:
+//
+//	$1 (INTEGER) - product ID
+//
+// Returns: GetPublishedProductCardRow, or sql.ErrNoRows for missing, draft,
+// archived and trashed products
+//
+// Use case: The "Recently viewed" widget, whose product IDs come from a cookie
+func (q *Queries) GetPublishedProductCard(ctx context.Context, id int64) (GetPublishedProductCardRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublishedProductCard, id)
+	var i GetPublishedProductCardRow
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Tagline,
+		&i.PrimaryImage,
+		&i.CategorySlug,
+	)
+	return i, err
+}
+
+const getPublishedProductDownload = `-- name: GetPublishedProductDownload :one
+SELECT d.file_path, d.title, d.version, d.is_gated,
+       p.name AS product_name, p.slug AS product_slug, pc.slug AS category_slug
+FROM product_downloads d
+INNER JOIN products p ON p.id = d.product_id
+INNER JOIN product_categories pc ON pc.id = p.category_id
+WHERE d.id = ? AND p.status = 'published' AND p.deleted_at IS NULL
+`
+
+type GetPublishedProductDownloadRow struct {
+	FilePath     string         `json:"file_path"`
+	Title        string         `json:"title"`
+	Version      sql.NullString `json:"version"`
+	IsGated      int64          `json:"is_gated"`
+	ProductName  string         `json:"product_name"`
+	ProductSlug  string         `json:"product_slug"`
+	CategorySlug string         `json:"category_slug"`
+}
+
+// Returns a download when its product is published, with the product's URL
+// slugs for sending visitors back to the product page.
+//
+// Parameters:
+//
+//	$1 (INTEGER) - download ID
+//
+// Returns: GetPublishedProductDownloadRow, or sql.ErrNoRows for a draft,
+// archived or trashed product
+//
+// Use case: The public download link, which counts the download and redirects
+// to the file, and the lead form of gated downloads
+func (q *Queries) GetPublishedProductDownload(ctx context.Context, id int64) (GetPublishedProductDownloadRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublishedProductDownload, id)
+	var i GetPublishedProductDownloadRow
+	err := row.Scan(
+		&i.FilePath,
+		&i.Title,
+		&i.Version,
+		&i.IsGated,
+		&i.ProductName,
+		&i.ProductSlug,
+		&i.CategorySlug,
 	)
 	return i, err
 }
@@ -858,7 +1094,8 @@ func (q *Queries) IncrementDownloadCount(ctx context.Context, id int64) error {
 
 const listAllProductsAdmin = `-- name: ListAllProductsAdmin :many
 
-SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image FROM products
+SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at FROM products
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 `
 
@@ -902,6 +1139,221 @@ func (q *Queries) ListAllProductsAdmin(ctx context.Context) ([]Product, error) {
 			&i.UpdatedAt,
 			&i.PublishedAt,
 			&i.OgImage,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllProductsInCategoryTree = `-- name: ListAllProductsInCategoryTree :many
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, p.deleted_at, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = ?1
+    OR pc.parent_id = ?1
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = ?1)
+)
+ORDER BY
+    CASE WHEN ?2 = 'name_asc' THEN p.name COLLATE NOCASE END ASC,
+    CASE WHEN ?2 = 'name_desc' THEN p.name COLLATE NOCASE END DESC,
+    CASE WHEN ?2 = 'newest' THEN COALESCE(p.published_at, p.created_at) END DESC,
+    CASE WHEN ?2 = 'oldest' THEN COALESCE(p.published_at, p.created_at) END ASC,
+    CASE WHEN ?2 = 'sku' THEN p.sku COLLATE NOCASE END ASC,
+    p.id ASC
+`
+
+type ListAllProductsInCategoryTreeParams struct {
+	CategoryID int64       `json:"category_id"`
+	Sort       interface{} `json:"sort"`
+}
+
+type ListAllProductsInCategoryTreeRow struct {
+	ID              int64          `json:"id"`
+	Sku             string         `json:"sku"`
+	Slug            string         `json:"slug"`
+	Name            string         `json:"name"`
+	Tagline         sql.NullString `json:"tagline"`
+	Description     string         `json:"description"`
+	Overview        sql.NullString `json:"overview"`
+	CategoryID      int64          `json:"category_id"`
+	Status          string         `json:"status"`
+	IsFeatured      bool           `json:"is_featured"`
+	FeaturedOrder   sql.NullInt64  `json:"featured_order"`
+	MetaTitle       sql.NullString `json:"meta_title"`
+	MetaDescription sql.NullString `json:"meta_description"`
+	PrimaryImage    sql.NullString `json:"primary_image"`
+	VideoUrl        sql.NullString `json:"video_url"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	PublishedAt     sql.NullTime   `json:"published_at"`
+	OgImage         string         `json:"og_image"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CategorySlug    string         `json:"category_slug"`
+}
+
+// Retrieves every published product in a category and its subcategories,
+// in the requested sort order.
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Root of the category subtree
+//	@sort (TEXT) - "name_asc", "name_desc", "newest", "oldest" or "sku"
+//
+// Returns: []ListAllProductsInCategoryTreeRow - Products with category_slug
+//
+// Sorting: One CASE per sort option; only the matching one yields values,
+// the others are constant and do not affect the order. The id makes the
+// order total, so pages never overlap.
+//
+// Use case: Faceted category pages, which filter in memory and then paginate
+func (q *Queries) ListAllProductsInCategoryTree(ctx context.Context, arg ListAllProductsInCategoryTreeParams) ([]ListAllProductsInCategoryTreeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllProductsInCategoryTree, arg.CategoryID, arg.Sort)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAllProductsInCategoryTreeRow{}
+	for rows.Next() {
+		var i ListAllProductsInCategoryTreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Slug,
+			&i.Name,
+			&i.Tagline,
+			&i.Description,
+			&i.Overview,
+			&i.CategoryID,
+			&i.Status,
+			&i.IsFeatured,
+			&i.FeaturedOrder,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.PrimaryImage,
+			&i.VideoUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.OgImage,
+			&i.DeletedAt,
+			&i.CategorySlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryTreeCertificationFacets = `-- name: ListCategoryTreeCertificationFacets :many
+SELECT pcert.product_id, pcert.certification_name
+FROM product_certifications pcert
+INNER JOIN products p ON pcert.product_id = p.id
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = ?1
+    OR pc.parent_id = ?1
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = ?1)
+)
+ORDER BY pcert.certification_name ASC
+`
+
+type ListCategoryTreeCertificationFacetsRow struct {
+	ProductID         int64  `json:"product_id"`
+	CertificationName string `json:"certification_name"`
+}
+
+// Retrieves the certifications of published products in a category subtree
+// for the certification facet.
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Root of the category subtree
+//
+// Returns: []ListCategoryTreeCertificationFacetsRow - product_id and certification name
+func (q *Queries) ListCategoryTreeCertificationFacets(ctx context.Context, categoryID int64) ([]ListCategoryTreeCertificationFacetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoryTreeCertificationFacets, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCategoryTreeCertificationFacetsRow{}
+	for rows.Next() {
+		var i ListCategoryTreeCertificationFacetsRow
+		if err := rows.Scan(&i.ProductID, &i.CertificationName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryTreeSpecFacets = `-- name: ListCategoryTreeSpecFacets :many
+SELECT ps.product_id, ps.section_name, ps.spec_key, ps.spec_value
+FROM product_specs ps
+INNER JOIN products p ON ps.product_id = p.id
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = ?1
+    OR pc.parent_id = ?1
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = ?1)
+)
+ORDER BY ps.display_order ASC, ps.section_name ASC, ps.spec_key ASC, ps.spec_value ASC
+`
+
+type ListCategoryTreeSpecFacetsRow struct {
+	ProductID   int64  `json:"product_id"`
+	SectionName string `json:"section_name"`
+	SpecKey     string `json:"spec_key"`
+	SpecValue   string `json:"spec_value"`
+}
+
+// Retrieves the spec values of published products in a category subtree,
+// from which the filter sidebar's spec facets are built.
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Root of the category subtree
+//
+// Returns: []ListCategoryTreeSpecFacetsRow - product_id, section, key and value
+//
+// Sorting: By section and key in the order they appear on products, then value
+func (q *Queries) ListCategoryTreeSpecFacets(ctx context.Context, categoryID int64) ([]ListCategoryTreeSpecFacetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoryTreeSpecFacets, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCategoryTreeSpecFacetsRow{}
+	for rows.Next() {
+		var i ListCategoryTreeSpecFacetsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.SectionName,
+			&i.SpecKey,
+			&i.SpecValue,
 		); err != nil {
 			return nil, err
 		}
@@ -917,10 +1369,10 @@ func (q *Queries) ListAllProductsAdmin(ctx context.Context) ([]Product, error) {
 }
 
 const listFeaturedProducts = `-- name: ListFeaturedProducts :many
-SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, pc.slug AS category_slug
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, p.deleted_at, pc.slug AS category_slug
 FROM products p
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE p.is_featured = 1 AND p.status = 'published'
+WHERE p.is_featured = 1 AND p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY p.featured_order ASC
 LIMIT ?
 `
@@ -945,6 +1397,7 @@ type ListFeaturedProductsRow struct {
 	UpdatedAt       time.Time      `json:"updated_at"`
 	PublishedAt     sql.NullTime   `json:"published_at"`
 	OgImage         string         `json:"og_image"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
 	CategorySlug    string         `json:"category_slug"`
 }
 
@@ -997,6 +1450,7 @@ func (q *Queries) ListFeaturedProducts(ctx context.Context, limit int64) ([]List
 			&i.UpdatedAt,
 			&i.PublishedAt,
 			&i.OgImage,
+			&i.DeletedAt,
 			&i.CategorySlug,
 		); err != nil {
 			return nil, err
@@ -1012,6 +1466,39 @@ func (q *Queries) ListFeaturedProducts(ctx context.Context, limit int64) ([]List
 	return items, nil
 }
 
+const listGatedProductDownloadFiles = `-- name: ListGatedProductDownloadFiles :many
+SELECT DISTINCT file_path FROM product_downloads
+WHERE is_gated = 1
+ORDER BY file_path
+`
+
+// Lists the files of gated downloads, for moving them out of the public
+// uploads at startup.
+//
+// Returns: []string - Distinct file paths
+func (q *Queries) ListGatedProductDownloadFiles(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listGatedProductDownloadFiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var file_path string
+		if err := rows.Scan(&file_path); err != nil {
+			return nil, err
+		}
+		items = append(items, file_path)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductCertifications = `-- name: ListProductCertifications :many
 SELECT id, product_id, certification_name, certification_code, icon_type, icon_path, display_order, created_at FROM product_certifications
 WHERE product_id = ?
@@ -1061,9 +1548,9 @@ func (q *Queries) ListProductCertifications(ctx context.Context, productID int64
 }
 
 const listProductDownloads = `-- name: ListProductDownloads :many
-SELECT id, product_id, title, description, file_type, file_path, file_size, version, download_count, display_order, created_at, updated_at FROM product_downloads
+SELECT id, product_id, title, description, file_type, file_path, file_size, version, download_count, display_order, created_at, updated_at, changelog, supersedes_id, is_gated FROM product_downloads
 WHERE product_id = ?
-ORDER BY display_order ASC
+ORDER BY display_order ASC, id ASC
 `
 
 // Retrieves all downloadable files for a product in display order.
@@ -1074,7 +1561,7 @@ ORDER BY display_order ASC
 //
 // Returns: []ProductDownload - Array of downloads ordered by display_order
 //
-// Sorting: display_order ASC - Downloads appear in admin-configured order
+// Sorting: display_order ASC, id ASC - Downloads appear in admin-configured order, then as added
 // Use case: Displaying downloads list on product detail page
 func (q *Queries) ListProductDownloads(ctx context.Context, productID int64) ([]ProductDownload, error) {
 	rows, err := q.db.QueryContext(ctx, listProductDownloads, productID)
@@ -1098,6 +1585,9 @@ func (q *Queries) ListProductDownloads(ctx context.Context, productID int64) ([]
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Changelog,
+			&i.SupersedesID,
+			&i.IsGated,
 		); err != nil {
 			return nil, err
 		}
@@ -1157,9 +1647,57 @@ func (q *Queries) ListProductFeatures(ctx context.Context, productID int64) ([]P
 	return items, nil
 }
 
-const listProductImages = `-- name: ListProductImages :many
-SELECT id, product_id, image_path, alt_text, caption, display_order, is_thumbnail, created_at FROM product_images
-WHERE product_id = ?
+const listProductFeaturesForExport = `-- name: ListProductFeaturesForExport :many
+SELECT product_id, feature_text
+FROM product_features
+WHERE product_id >= ?1 AND product_id <= ?2
+ORDER BY product_id ASC, display_order ASC, id ASC
+`
+
+type ListProductFeaturesForExportParams struct {
+	FirstID int64 `json:"first_id"`
+	LastID  int64 `json:"last_id"`
+}
+
+type ListProductFeaturesForExportRow struct {
+	ProductID   int64  `json:"product_id"`
+	FeatureText string `json:"feature_text"`
+}
+
+// Returns the features of the products in an ID range (one export batch).
+//
+// Parameters:
+//
+//	@first_id (INTEGER) - First product ID of the batch
+//	@last_id (INTEGER) - Last product ID of the batch
+//
+// Returns: []ListProductFeaturesForExportRow
+func (q *Queries) ListProductFeaturesForExport(ctx context.Context, arg ListProductFeaturesForExportParams) ([]ListProductFeaturesForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductFeaturesForExport, arg.FirstID, arg.LastID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductFeaturesForExportRow{}
+	for rows.Next() {
+		var i ListProductFeaturesForExportRow
+		if err := rows.Scan(&i.ProductID, &i.FeatureText); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductImages = `-- name: ListProductImages :many
+SELECT id, product_id, image_path, alt_text, caption, display_order, is_thumbnail, created_at, media_type, media_url FROM product_images
+WHERE product_id = ?
 ORDER BY display_order ASC
 `
 
@@ -1191,6 +1729,8 @@ func (q *Queries) ListProductImages(ctx context.Context, productID int64) ([]Pro
 			&i.DisplayOrder,
 			&i.IsThumbnail,
 			&i.CreatedAt,
+			&i.MediaType,
+			&i.MediaUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -1205,6 +1745,46 @@ func (q *Queries) ListProductImages(ctx context.Context, productID int64) ([]Pro
 	return items, nil
 }
 
+const listProductSpecColumnsForExport = `-- name: ListProductSpecColumnsForExport :many
+SELECT DISTINCT s.section_name, s.spec_key
+FROM product_specs s
+INNER JOIN products p ON p.id = s.product_id
+WHERE p.deleted_at IS NULL
+ORDER BY s.section_name ASC, s.spec_key ASC
+`
+
+type ListProductSpecColumnsForExportRow struct {
+	SectionName string `json:"section_name"`
+	SpecKey     string `json:"spec_key"`
+}
+
+// Returns every distinct spec (section + label) used by a product, one
+// export column each.
+//
+// Returns: []ListProductSpecColumnsForExportRow - Sorted by section, then label
+func (q *Queries) ListProductSpecColumnsForExport(ctx context.Context) ([]ListProductSpecColumnsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductSpecColumnsForExport)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductSpecColumnsForExportRow{}
+	for rows.Next() {
+		var i ListProductSpecColumnsForExportRow
+		if err := rows.Scan(&i.SectionName, &i.SpecKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductSpecs = `-- name: ListProductSpecs :many
 SELECT id, product_id, section_name, spec_key, spec_value, display_order, created_at FROM product_specs
 WHERE product_id = ?
@@ -1253,9 +1833,64 @@ func (q *Queries) ListProductSpecs(ctx context.Context, productID int64) ([]Prod
 	return items, nil
 }
 
+const listProductSpecsForExport = `-- name: ListProductSpecsForExport :many
+SELECT product_id, section_name, spec_key, spec_value
+FROM product_specs
+WHERE product_id >= ?1 AND product_id <= ?2
+ORDER BY product_id ASC, display_order ASC, id ASC
+`
+
+type ListProductSpecsForExportParams struct {
+	FirstID int64 `json:"first_id"`
+	LastID  int64 `json:"last_id"`
+}
+
+type ListProductSpecsForExportRow struct {
+	ProductID   int64  `json:"product_id"`
+	SectionName string `json:"section_name"`
+	SpecKey     string `json:"spec_key"`
+	SpecValue   string `json:"spec_value"`
+}
+
+// Returns the specs of the products in an ID range (one export batch).
+//
+// Parameters:
+//
+//	@first_id (INTEGER) - First product ID of the batch
+//	@last_id (INTEGER) - Last product ID of the batch
+//
+// Returns: []ListProductSpecsForExportRow
+func (q *Queries) ListProductSpecsForExport(ctx context.Context, arg ListProductSpecsForExportParams) ([]ListProductSpecsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductSpecsForExport, arg.FirstID, arg.LastID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductSpecsForExportRow{}
+	for rows.Next() {
+		var i ListProductSpecsForExportRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.SectionName,
+			&i.SpecKey,
+			&i.SpecValue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProducts = `-- name: ListProducts :many
-SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image FROM products
-WHERE status = 'published'
+SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at FROM products
+WHERE status = 'published' AND deleted_at IS NULL
 ORDER BY
     CASE WHEN is_featured = 1 THEN featured_order ELSE 999999 END ASC,
     published_at DESC
@@ -1314,6 +1949,7 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.UpdatedAt,
 			&i.PublishedAt,
 			&i.OgImage,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1329,9 +1965,9 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 }
 
 const listProductsAdminFiltered = `-- name: ListProductsAdminFiltered :many
-SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image FROM products p
-WHERE
-    (CASE WHEN ?1 = '' THEN 1 ELSE p.status = ?1 END)
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, p.deleted_at FROM products p
+WHERE p.deleted_at IS NULL
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE p.status = ?1 END)
     AND (CASE WHEN ?2 = 0 THEN 1 ELSE p.category_id = ?2 END)
     AND (CASE WHEN ?3 = '' THEN 1 ELSE (p.name LIKE '%' || ?3 || '%' OR p.sku LIKE '%' || ?3 || '%') END)
 ORDER BY p.created_at DESC
@@ -1403,6 +2039,7 @@ func (q *Queries) ListProductsAdminFiltered(ctx context.Context, arg ListProduct
 			&i.UpdatedAt,
 			&i.PublishedAt,
 			&i.OgImage,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1418,8 +2055,8 @@ func (q *Queries) ListProductsAdminFiltered(ctx context.Context, arg ListProduct
 }
 
 const listProductsByCategory = `-- name: ListProductsByCategory :many
-SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image FROM products
-WHERE category_id = ? AND status = 'published'
+SELECT id, sku, slug, name, tagline, description, overview, category_id, status, is_featured, featured_order, meta_title, meta_description, primary_image, video_url, created_at, updated_at, published_at, og_image, deleted_at FROM products
+WHERE category_id = ? AND status = 'published' AND deleted_at IS NULL
 ORDER BY
     CASE WHEN is_featured = 1 THEN featured_order ELSE 999999 END ASC,
     published_at DESC
@@ -1478,6 +2115,136 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 			&i.UpdatedAt,
 			&i.PublishedAt,
 			&i.OgImage,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsForExport = `-- name: ListProductsForExport :many
+
+SELECT p.id, p.sku, p.name, p.slug, pc.slug AS category_slug, p.description,
+       p.tagline, p.overview, p.status
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.deleted_at IS NULL AND p.id > ?1
+ORDER BY p.id ASC
+LIMIT ?2
+`
+
+type ListProductsForExportParams struct {
+	AfterID   int64 `json:"after_id"`
+	BatchSize int64 `json:"batch_size"`
+}
+
+type ListProductsForExportRow struct {
+	ID           int64          `json:"id"`
+	Sku          string         `json:"sku"`
+	Name         string         `json:"name"`
+	Slug         string         `json:"slug"`
+	CategorySlug string         `json:"category_slug"`
+	Description  string         `json:"description"`
+	Tagline      sql.NullString `json:"tagline"`
+	Overview     sql.NullString `json:"overview"`
+	Status       string         `json:"status"`
+}
+
+// ====================================================================
+// PRODUCT EXPORT
+// ====================================================================
+// The admin export pages through products by ID so memory stays flat
+// however many products there are. Trashed products are left out.
+// Returns the next batch of products after a given ID, with their category.
+//
+// Parameters:
+//
+//	@after_id (INTEGER) - Last product ID of the previous batch (0 to start)
+//	@batch_size (INTEGER) - Maximum products to return
+//
+// Returns: []ListProductsForExportRow - Products in ID order with category_slug
+func (q *Queries) ListProductsForExport(ctx context.Context, arg ListProductsForExportParams) ([]ListProductsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsForExport, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsForExportRow{}
+	for rows.Next() {
+		var i ListProductsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.Slug,
+			&i.CategorySlug,
+			&i.Description,
+			&i.Tagline,
+			&i.Overview,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsForIndex = `-- name: ListProductsForIndex :many
+SELECT p.name, p.slug, p.sku, p.tagline, pc.name AS category_name, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+ORDER BY p.name COLLATE NOCASE ASC, p.id ASC
+`
+
+type ListProductsForIndexRow struct {
+	Name         string         `json:"name"`
+	Slug         string         `json:"slug"`
+	Sku          string         `json:"sku"`
+	Tagline      sql.NullString `json:"tagline"`
+	CategoryName string         `json:"category_name"`
+	CategorySlug string         `json:"category_slug"`
+}
+
+// Retrieves every published product with its category for the A–Z index.
+//
+// Returns: []ListProductsForIndexRow - name, slug, sku, tagline, category name and slug
+//
+// Sorting: name COLLATE NOCASE so "acme" and "Acme" file under the same letter;
+// id breaks ties between products with the same name
+//
+// Use case: /products/a-z index page (grouped by first letter in Go)
+func (q *Queries) ListProductsForIndex(ctx context.Context) ([]ListProductsForIndexRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsForIndex)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsForIndexRow{}
+	for rows.Next() {
+		var i ListProductsForIndexRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.Slug,
+			&i.Sku,
+			&i.Tagline,
+			&i.CategoryName,
+			&i.CategorySlug,
 		); err != nil {
 			return nil, err
 		}
@@ -1497,7 +2264,7 @@ const listProductsForSitemap = `-- name: ListProductsForSitemap :many
 SELECT p.slug, pc.slug AS category_slug, p.updated_at
 FROM products p
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE p.status = 'published'
+WHERE p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY p.updated_at DESC
 `
 
@@ -1538,11 +2305,290 @@ func (q *Queries) ListProductsForSitemap(ctx context.Context) ([]ListProductsFor
 	return items, nil
 }
 
+const listProductsInCategoryTree = `-- name: ListProductsInCategoryTree :many
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, p.deleted_at, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL AND (
+    pc.id = ?1
+    OR pc.parent_id = ?1
+    OR pc.parent_id IN (SELECT c.id FROM product_categories c WHERE c.parent_id = ?1)
+)
+ORDER BY
+    CASE WHEN p.is_featured = 1 THEN p.featured_order ELSE 999999 END ASC,
+    p.published_at DESC
+LIMIT ?3 OFFSET ?2
+`
+
+type ListProductsInCategoryTreeParams struct {
+	CategoryID int64 `json:"category_id"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type ListProductsInCategoryTreeRow struct {
+	ID              int64          `json:"id"`
+	Sku             string         `json:"sku"`
+	Slug            string         `json:"slug"`
+	Name            string         `json:"name"`
+	Tagline         sql.NullString `json:"tagline"`
+	Description     string         `json:"description"`
+	Overview        sql.NullString `json:"overview"`
+	CategoryID      int64          `json:"category_id"`
+	Status          string         `json:"status"`
+	IsFeatured      bool           `json:"is_featured"`
+	FeaturedOrder   sql.NullInt64  `json:"featured_order"`
+	MetaTitle       sql.NullString `json:"meta_title"`
+	MetaDescription sql.NullString `json:"meta_description"`
+	PrimaryImage    sql.NullString `json:"primary_image"`
+	VideoUrl        sql.NullString `json:"video_url"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	PublishedAt     sql.NullTime   `json:"published_at"`
+	OgImage         string         `json:"og_image"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CategorySlug    string         `json:"category_slug"`
+}
+
+// Retrieves paginated published products in a category and all of its
+// subcategories, with each product's own category slug for building URLs.
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Root of the category subtree
+//	@limit (INTEGER) - Number of products per page
+//	@offset (INTEGER) - Pagination offset
+//
+// Returns: []ListProductsInCategoryTreeRow - Products with category_slug
+//
+// Subtree: the category, its children, and its grandchildren. This covers the
+// whole hierarchy because depth is capped at services.MaxCategoryDepth (3).
+//
+// Sorting: Same ordering as ListProductsByCategory (featured first, then by date)
+//
+// Use case: Family and series pages that roll up products from their children
+func (q *Queries) ListProductsInCategoryTree(ctx context.Context, arg ListProductsInCategoryTreeParams) ([]ListProductsInCategoryTreeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsInCategoryTree, arg.CategoryID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsInCategoryTreeRow{}
+	for rows.Next() {
+		var i ListProductsInCategoryTreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Slug,
+			&i.Name,
+			&i.Tagline,
+			&i.Description,
+			&i.Overview,
+			&i.CategoryID,
+			&i.Status,
+			&i.IsFeatured,
+			&i.FeaturedOrder,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.PrimaryImage,
+			&i.VideoUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.OgImage,
+			&i.DeletedAt,
+			&i.CategorySlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedProductsByIDAfter = `-- name: ListPublishedProductsByIDAfter :many
+
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.primary_image,
+       p.is_featured, p.published_at, p.updated_at, pc.slug AS category_slug, pc.name AS category_name
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+    AND (CASE WHEN ?1 = 0 THEN 1 ELSE p.category_id = ?1 END)
+    AND p.id > ?2
+ORDER BY p.id ASC
+LIMIT ?3
+`
+
+type ListPublishedProductsByIDAfterParams struct {
+	CategoryID interface{} `json:"category_id"`
+	AfterID    int64       `json:"after_id"`
+	PageLimit  int64       `json:"page_limit"`
+}
+
+type ListPublishedProductsByIDAfterRow struct {
+	ID           int64          `json:"id"`
+	Sku          string         `json:"sku"`
+	Slug         string         `json:"slug"`
+	Name         string         `json:"name"`
+	Tagline      sql.NullString `json:"tagline"`
+	Description  string         `json:"description"`
+	PrimaryImage sql.NullString `json:"primary_image"`
+	IsFeatured   bool           `json:"is_featured"`
+	PublishedAt  sql.NullTime   `json:"published_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	CategorySlug string         `json:"category_slug"`
+	CategoryName string         `json:"category_name"`
+}
+
+// ====================================================================
+// PUBLIC API (keyset pagination)
+// ====================================================================
+// The API pages with a keyset instead of OFFSET: each page starts after the
+// last row of the previous one, so products published or deleted between
+// requests never shift rows into (or out of) a page already read. The
+// product id breaks ties, giving every ordering a strict total order.
+// Returns published products with an id greater than @after_id, oldest first.
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Only this category (0 for all categories)
+//	@after_id (INTEGER) - id of the last product of the previous page (0 for the first page)
+//	@page_limit (INTEGER) - Maximum rows to return
+//
+// Returns: []ListPublishedProductsByIDAfterRow
+func (q *Queries) ListPublishedProductsByIDAfter(ctx context.Context, arg ListPublishedProductsByIDAfterParams) ([]ListPublishedProductsByIDAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedProductsByIDAfter, arg.CategoryID, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublishedProductsByIDAfterRow{}
+	for rows.Next() {
+		var i ListPublishedProductsByIDAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Slug,
+			&i.Name,
+			&i.Tagline,
+			&i.Description,
+			&i.PrimaryImage,
+			&i.IsFeatured,
+			&i.PublishedAt,
+			&i.UpdatedAt,
+			&i.CategorySlug,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedProductsByNameAfter = `-- name: ListPublishedProductsByNameAfter :many
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.primary_image,
+       p.is_featured, p.published_at, p.updated_at, pc.slug AS category_slug, pc.name AS category_name
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+    AND (CASE WHEN ?1 = 0 THEN 1 ELSE p.category_id = ?1 END)
+    AND (p.name > ?2 OR (p.name = ?2 AND p.id > ?3))
+ORDER BY p.name ASC, p.id ASC
+LIMIT ?4
+`
+
+type ListPublishedProductsByNameAfterParams struct {
+	CategoryID interface{} `json:"category_id"`
+	AfterName  string      `json:"after_name"`
+	AfterID    int64       `json:"after_id"`
+	PageLimit  int64       `json:"page_limit"`
+}
+
+type ListPublishedProductsByNameAfterRow struct {
+	ID           int64          `json:"id"`
+	Sku          string         `json:"sku"`
+	Slug         string         `json:"slug"`
+	Name         string         `json:"name"`
+	Tagline      sql.NullString `json:"tagline"`
+	Description  string         `json:"description"`
+	PrimaryImage sql.NullString `json:"primary_image"`
+	IsFeatured   bool           `json:"is_featured"`
+	PublishedAt  sql.NullTime   `json:"published_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	CategorySlug string         `json:"category_slug"`
+	CategoryName string         `json:"category_name"`
+}
+
+// Returns published products ordered by name, then id, starting after the
+// (@after_name, @after_id) key.
+//
+// Parameters:
+//
+//	@category_id (INTEGER) - Only this category (0 for all categories)
+//	@after_name (TEXT) - Name of the last product of the previous page ("" with @after_id 0 for the first page)
+//	@after_id (INTEGER) - id of the last product of the previous page
+//	@page_limit (INTEGER) - Maximum rows to return
+//
+// Returns: []ListPublishedProductsByNameAfterRow
+func (q *Queries) ListPublishedProductsByNameAfter(ctx context.Context, arg ListPublishedProductsByNameAfterParams) ([]ListPublishedProductsByNameAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedProductsByNameAfter,
+		arg.CategoryID,
+		arg.AfterName,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublishedProductsByNameAfterRow{}
+	for rows.Next() {
+		var i ListPublishedProductsByNameAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Slug,
+			&i.Name,
+			&i.Tagline,
+			&i.Description,
+			&i.PrimaryImage,
+			&i.IsFeatured,
+			&i.PublishedAt,
+			&i.UpdatedAt,
+			&i.CategorySlug,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchProducts = `-- name: SearchProducts :many
-SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, pc.slug AS category_slug
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, p.deleted_at, pc.slug AS category_slug
 FROM products p
 INNER JOIN product_categories pc ON p.category_id = pc.id
-WHERE p.status = 'published'
+WHERE p.status = 'published' AND p.deleted_at IS NULL
     AND (p.name LIKE ? OR p.description LIKE ? OR p.tagline LIKE ?)
 ORDER BY p.published_at DESC
 LIMIT ? OFFSET ?
@@ -1576,6 +2622,7 @@ type SearchProductsRow struct {
 	UpdatedAt       time.Time      `json:"updated_at"`
 	PublishedAt     sql.NullTime   `json:"published_at"`
 	OgImage         string         `json:"og_image"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
 	CategorySlug    string         `json:"category_slug"`
 }
 
@@ -1632,6 +2679,110 @@ func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams)
 			&i.UpdatedAt,
 			&i.PublishedAt,
 			&i.OgImage,
+			&i.DeletedAt,
+			&i.CategorySlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProductsSorted = `-- name: SearchProductsSorted :many
+SELECT p.id, p.sku, p.slug, p.name, p.tagline, p.description, p.overview, p.category_id, p.status, p.is_featured, p.featured_order, p.meta_title, p.meta_description, p.primary_image, p.video_url, p.created_at, p.updated_at, p.published_at, p.og_image, p.deleted_at, pc.slug AS category_slug
+FROM products p
+INNER JOIN product_categories pc ON p.category_id = pc.id
+WHERE p.status = 'published' AND p.deleted_at IS NULL
+    AND (p.name LIKE ?1 OR p.description LIKE ?1 OR p.tagline LIKE ?1)
+ORDER BY
+    CASE WHEN ?2 = 'name_asc' THEN p.name COLLATE NOCASE END ASC,
+    CASE WHEN ?2 = 'name_desc' THEN p.name COLLATE NOCASE END DESC,
+    CASE WHEN ?2 = 'newest' THEN COALESCE(p.published_at, p.created_at) END DESC,
+    CASE WHEN ?2 = 'oldest' THEN COALESCE(p.published_at, p.created_at) END ASC,
+    CASE WHEN ?2 = 'sku' THEN p.sku COLLATE NOCASE END ASC,
+    p.id ASC
+LIMIT ?3
+`
+
+type SearchProductsSortedParams struct {
+	Pattern   string      `json:"pattern"`
+	Sort      interface{} `json:"sort"`
+	PageLimit int64       `json:"page_limit"`
+}
+
+type SearchProductsSortedRow struct {
+	ID              int64          `json:"id"`
+	Sku             string         `json:"sku"`
+	Slug            string         `json:"slug"`
+	Name            string         `json:"name"`
+	Tagline         sql.NullString `json:"tagline"`
+	Description     string         `json:"description"`
+	Overview        sql.NullString `json:"overview"`
+	CategoryID      int64          `json:"category_id"`
+	Status          string         `json:"status"`
+	IsFeatured      bool           `json:"is_featured"`
+	FeaturedOrder   sql.NullInt64  `json:"featured_order"`
+	MetaTitle       sql.NullString `json:"meta_title"`
+	MetaDescription sql.NullString `json:"meta_description"`
+	PrimaryImage    sql.NullString `json:"primary_image"`
+	VideoUrl        sql.NullString `json:"video_url"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	PublishedAt     sql.NullTime   `json:"published_at"`
+	OgImage         string         `json:"og_image"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CategorySlug    string         `json:"category_slug"`
+}
+
+// Searches published products by name, description, or tagline, like
+// SearchProducts, in the requested sort order.
+//
+// Parameters:
+//
+//	@pattern (TEXT) - LIKE pattern matched against name, description and tagline
+//	@sort (TEXT) - "name_asc", "name_desc", "newest", "oldest" or "sku"
+//	@page_limit (INTEGER) - Maximum results
+//
+// Returns: []SearchProductsSortedRow - Matching products with category_slug
+//
+// Sorting: Same CASE pattern as ListAllProductsInCategoryTree
+func (q *Queries) SearchProductsSorted(ctx context.Context, arg SearchProductsSortedParams) ([]SearchProductsSortedRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchProductsSorted, arg.Pattern, arg.Sort, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductsSortedRow{}
+	for rows.Next() {
+		var i SearchProductsSortedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Slug,
+			&i.Name,
+			&i.Tagline,
+			&i.Description,
+			&i.Overview,
+			&i.CategoryID,
+			&i.Status,
+			&i.IsFeatured,
+			&i.FeaturedOrder,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.PrimaryImage,
+			&i.VideoUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.OgImage,
+			&i.DeletedAt,
 			&i.CategorySlug,
 		); err != nil {
 			return nil, err
@@ -1647,6 +2798,36 @@ func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams)
 	return items, nil
 }
 
+const setProductFeatured = `-- name: SetProductFeatured :execrows
+UPDATE products
+SET is_featured = ?1, featured_order = ?2, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?3 AND deleted_at IS NULL
+`
+
+type SetProductFeaturedParams struct {
+	IsFeatured    bool          `json:"is_featured"`
+	FeaturedOrder sql.NullInt64 `json:"featured_order"`
+	ID            int64         `json:"id"`
+}
+
+// Features or unfeatures a product and sets its homepage position, from the
+// admin product list.
+//
+// Parameters:
+//
+//	@is_featured (BOOLEAN) - whether the product is featured
+//	@featured_order (INTEGER) - position among featured products (NULL for none)
+//	@id (INTEGER) - product ID
+//
+// Returns: number of updated rows (0 when the product is missing or trashed)
+func (q *Queries) SetProductFeatured(ctx context.Context, arg SetProductFeaturedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setProductFeatured, arg.IsFeatured, arg.FeaturedOrder, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateProduct = `-- name: UpdateProduct :exec
 UPDATE products
 SET sku = ?, slug = ?, name = ?, tagline = ?, description = ?, overview = ?,
@@ -1736,7 +2917,7 @@ func (q *Queries) UpdateProductCertification(ctx context.Context, arg UpdateProd
 
 const updateProductDownload = `-- name: UpdateProductDownload :exec
 UPDATE product_downloads
-SET title = ?, description = ?, file_type = ?, version = ?, display_order = ?
+SET title = ?, description = ?, file_type = ?, version = ?, changelog = ?, display_order = ?, is_gated = ?
 WHERE id = ?
 `
 
@@ -1745,7 +2926,9 @@ type UpdateProductDownloadParams struct {
 	Description  sql.NullString `json:"description"`
 	FileType     string         `json:"file_type"`
 	Version      sql.NullString `json:"version"`
+	Changelog    string         `json:"changelog"`
 	DisplayOrder int64          `json:"display_order"`
+	IsGated      int64          `json:"is_gated"`
 	ID           int64          `json:"id"`
 }
 
@@ -1755,7 +2938,9 @@ func (q *Queries) UpdateProductDownload(ctx context.Context, arg UpdateProductDo
 		arg.Description,
 		arg.FileType,
 		arg.Version,
+		arg.Changelog,
 		arg.DisplayOrder,
+		arg.IsGated,
 		arg.ID,
 	)
 	return err
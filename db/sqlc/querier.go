@@ -7,9 +7,22 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	// Adds a product file to a customer's library, or moves it to the top when
+	// it is already there.
+	// Parameters:
+	//   1. account_id (INTEGER): signed-in account
+	//   2. product_download_id (INTEGER): downloaded product file
+	AddCustomerLibraryProductDownload(ctx context.Context, arg AddCustomerLibraryProductDownloadParams) error
+	// Adds a whitepaper to a customer's library, or moves it to the top when
+	// it is already there.
+	// Parameters:
+	//   1. account_id (INTEGER): signed-in account
+	//   2. whitepaper_id (INTEGER): downloaded whitepaper
+	AddCustomerLibraryWhitepaper(ctx context.Context, arg AddCustomerLibraryWhitepaperParams) error
 	// sqlc annotation: :exec returns no data
 	// Purpose: Associates a product with a blog post with specific display order
 	// Parameters:
@@ -110,6 +123,13 @@ type Querier interface {
 	//   1. id (INTEGER): case study primary key
 	// Return type: complete case_studies row with all fields
 	AdminGetCaseStudy(ctx context.Context, id int64) (CaseStudy, error)
+	// sqlc annotation: :one returns one row of the admin case study list
+	// Purpose: Re-renders a list row after an inline edit on /admin/case-studies
+	// Parameters:
+	//  1. id (INTEGER): case study primary key
+	//
+	// Return type: same columns as AdminListCaseStudiesFiltered; trashed case studies are not found
+	AdminGetCaseStudyListItem(ctx context.Context, id int64) (AdminGetCaseStudyListItemRow, error)
 	// ====================================================================
 	// ADMIN CASE STUDY QUERIES
 	// ====================================================================
@@ -171,7 +191,43 @@ type Querier interface {
 	//   4. id (INTEGER): which metric to update (WHERE clause)
 	// Return type: updated case_study_metrics row
 	AdminUpdateMetric(ctx context.Context, arg AdminUpdateMetricParams) (CaseStudyMetric, error)
+	// Copies an approved submission onto the live partner. An empty logo keeps
+	// the current one and a NULL tier keeps the current tier.
+	// Parameters:
+	//   @logo_url (TEXT): new logo path, '' to keep
+	//   @description (TEXT): new description
+	//   @website_url (TEXT): new website
+	//   @requested_tier_id (INTEGER, nullable): new tier
+	//   @id (INTEGER): partner ID
+	ApplyPartnerSubmission(ctx context.Context, arg ApplyPartnerSubmissionParams) error
+	// sqlc annotation: :exec returns no data
+	// Purpose: Adds a post to a series (or moves it) at a given part number
+	// Parameters (named):
+	//   1. series_id (INTEGER): target series
+	//   2. series_order (INTEGER): part number within the series
+	//   3. post_id (INTEGER): post to assign
+	AssignPostToSeries(ctx context.Context, arg AssignPostToSeriesParams) error
+	// Attaches a block to a page path. A block may appear on a page more than
+	// once (e.g. a call to action at two positions).
+	// Parameters:
+	//  1. route (TEXT): page path starting with /
+	//  2. block_id (INTEGER): block to show
+	//  3. sort_order (INTEGER): position on the page, 0 first
+	AttachPageBlock(ctx context.Context, arg AttachPageBlockParams) (PageBlock, error)
 	BulkMarkContactSubmissionsRead(ctx context.Context) error
+	// Takes the lock on an item for a user, or renews it when they already
+	// hold it. A lock held by someone else is only taken over once its
+	// heartbeat is older than cutoff; otherwise no row is affected.
+	// Parameters:
+	//  1. content_type (TEXT): e.g. "product", "blog_post"
+	//  2. content_id (INTEGER): ID of the item
+	//  3. user_id (INTEGER): admin user claiming the lock
+	//  4. now (TEXT): current time
+	//  5. cutoff (TEXT): heartbeats before this have expired
+	ClaimEditLock(ctx context.Context, arg ClaimEditLockParams) (int64, error)
+	// Marks the oldest queued job as running and returns it (sql.ErrNoRows when
+	// the queue is empty).
+	ClaimNextExportJob(ctx context.Context) (ExportJob, error)
 	// sqlc annotation: :exec returns no data
 	// Purpose: Removes all product associations from a post
 	// Parameters:
@@ -185,11 +241,35 @@ type Querier interface {
 	// Return type: none
 	// Note: Typically used when updating post tags (clear then re-add)
 	ClearPostTags(ctx context.Context, blogPostID int64) error
+	// Marks a job done and records its file.
+	// Parameters:
+	//   @rows_done (INTEGER): rows written
+	//   @storage_key (TEXT): key of the file in export storage
+	//   @filename (TEXT): download file name
+	//   @size_bytes (INTEGER): file size
+	//   @expires_at (TEXT): UTC "2006-01-02 15:04:05" time the download link expires
+	//   @id (INTEGER): job ID
+	CompleteExportJob(ctx context.Context, arg CompleteExportJobParams) error
+	// Confirms the pending address of a confirmation link. No row means the
+	// link was used already, replaced by a newer one, or has expired.
+	// Parameters:
+	//   @token_hash (TEXT): hex SHA-256 of the token from the link
+	//   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp; links sent
+	//   earlier have expired
+	ConfirmNewsletterSubscriber(ctx context.Context, arg ConfirmNewsletterSubscriberParams) (NewsletterSubscriber, error)
+	// Reports whether a SKU is used by a product or a variant.
+	// Parameters (named):
+	//  1. sku (TEXT): candidate SKU
+	CopySKUTaken(ctx context.Context, sku string) (int64, error)
+	// Reports whether a slug is used by any row of a content type, including
+	// trashed rows (the UNIQUE constraint covers them too).
+	// Parameters (named):
+	//  1. content_type (TEXT): 'product', 'blog_post', 'solution' or 'case_study'
+	//  2. slug (TEXT): candidate slug
+	CopySlugTaken(ctx context.Context, arg CopySlugTakenParams) (int64, error)
 	// sqlc annotation: :one returns single integer count
 	// Purpose: Counts total activity logs matching filters (for pagination UI)
-	// Parameters (named):
-	//   @filter_action (TEXT): same filter as ListActivityLogs
-	//   @filter_search (TEXT): same search filter as ListActivityLogs
+	// Parameters (named): same filters as ListActivityLogs
 	// Return type: single integer COUNT(*) value
 	// Note: WHERE clause MUST match ListActivityLogs exactly for accurate pagination
 	CountActivityLogs(ctx context.Context, arg CountActivityLogsParams) (int64, error)
@@ -217,11 +297,26 @@ type Querier interface {
 	//   1. industry_id (INTEGER): industry to count
 	// Return type: integer count
 	CountCaseStudiesByIndustry(ctx context.Context, industryID int64) (int64, error)
+	// Counts the subpages of a page, which must be moved or deleted before it.
+	CountChildDocPages(ctx context.Context, parentID sql.NullInt64) (int64, error)
+	// Returns the number of direct subcategories of a category.
+	//
+	// Parameters:
+	//   $1 (INTEGER) - parent category ID
+	// Returns: INTEGER - Number of child categories
+	//
+	// Use case: Blocking deletion of categories that still have subcategories
+	CountChildProductCategories(ctx context.Context, parentID sql.NullInt64) (int64, error)
 	CountContactSubmissions(ctx context.Context) (int64, error)
 	CountContactSubmissionsByStatus(ctx context.Context, status string) (int64, error)
 	CountContactSubmissionsByStatusAndType(ctx context.Context, arg CountContactSubmissionsByStatusAndTypeParams) (int64, error)
 	CountContactSubmissionsByType(ctx context.Context, submissionType string) (int64, error)
 	CountContactSubmissionsSearch(ctx context.Context, arg CountContactSubmissionsSearchParams) (int64, error)
+	// Purpose: Published and draft counts per content type for the dashboard's
+	// content card, in a fixed order. Trashed items are left out, and archived
+	// items count as neither.
+	// Return type: one row per type
+	CountContentByType(ctx context.Context) ([]CountContentByTypeRow, error)
 	// sqlc annotation: :one returns integer count
 	// Purpose: Counts unpublished blog posts (status = 'draft')
 	// Parameters: none
@@ -234,6 +329,16 @@ type Querier interface {
 	// Return type: integer count
 	// Used for: Dashboard alert showing draft products needing review
 	CountDraftProducts(ctx context.Context) (int64, error)
+	// Counts the registrations of an event, for its capacity.
+	CountEventRegistrations(ctx context.Context, eventID int64) (int64, error)
+	// Counts a form's submissions, for pagination.
+	CountFormSubmissions(ctx context.Context, formID int64) (int64, error)
+	// Counts the applications ListJobApplications pages through.
+	// Parameters: Same filters as ListJobApplications
+	CountJobApplications(ctx context.Context, arg CountJobApplicationsParams) (int64, error)
+	// Returns the number of applications in each status, for the inbox
+	// filter tabs.
+	CountJobApplicationsByStatus(ctx context.Context) ([]CountJobApplicationsByStatusRow, error)
 	// Returns the total count of all media files.
 	//
 	// Parameters: none
@@ -268,12 +373,20 @@ type Querier interface {
 	// Return type: integer count
 	// Used for: Dashboard alert badge showing pending inquiries
 	CountNewContactSubmissions(ctx context.Context) (int64, error)
+	// Counts the subscribers ListNewsletterSubscribers pages through.
+	// Parameters: Same filters as ListNewsletterSubscribers
+	CountNewsletterSubscribers(ctx context.Context, arg CountNewsletterSubscribersParams) (int64, error)
+	// Returns the number of addresses in each status, for the Subscribers page
+	// header.
+	CountNewsletterSubscribersByStatus(ctx context.Context) ([]CountNewsletterSubscribersByStatusRow, error)
 	// sqlc annotation: :one returns integer count
 	// Purpose: Counts total partners in the system
 	// Parameters: none
 	// Return type: integer count
 	// Used for: Dashboard "Total Partners" statistic card
 	CountPartners(ctx context.Context) (int64, error)
+	// Counts the submissions awaiting review, for the admin sidebar.
+	CountPendingPartnerSubmissions(ctx context.Context) (int64, error)
 	// Returns the total count of published products.
 	//
 	// Parameters: none
@@ -298,6 +411,15 @@ type Querier interface {
 	//
 	// Use case: Category page pagination, category statistics
 	CountProductsByCategory(ctx context.Context, categoryID int64) (int64, error)
+	// Returns the count of published products in a category and its
+	// subcategories (children and grandchildren, see ListProductsInCategoryTree).
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Root of the category subtree
+	// Returns: INTEGER - Number of published products in the subtree
+	//
+	// Use case: Rolled-up category page pagination
+	CountProductsInCategoryTree(ctx context.Context, categoryID int64) (int64, error)
 	// sqlc annotation: :one returns single integer count
 	// Purpose: Counts total published posts for pagination calculations
 	// Parameters: none
@@ -311,6 +433,14 @@ type Querier interface {
 	// Return type: integer count
 	// Note: JOIN required to filter by category slug; WHERE must match ListPublishedPostsByCategory
 	CountPublishedPostsByCategory(ctx context.Context, slug string) (int64, error)
+	// sqlc annotation: :one returns integer count
+	// Purpose: Counts posts in one month archive for pagination
+	// Note: WHERE must match ListPublishedPostsByMonth
+	CountPublishedPostsByMonth(ctx context.Context, arg CountPublishedPostsByMonthParams) (int64, error)
+	// sqlc annotation: :one returns integer count
+	// Purpose: Counts posts with one tag for pagination
+	// Note: WHERE must match ListPublishedPostsByTag
+	CountPublishedPostsByTag(ctx context.Context, slug string) (int64, error)
 	// Returns the total count of published whitepapers.
 	//
 	// Parameters: none
@@ -376,6 +506,29 @@ type Querier interface {
 	// Return type: none (exec only returns error status)
 	// Note: created_at timestamp is auto-generated by database
 	CreateActivityLog(ctx context.Context, arg CreateActivityLogParams) error
+	// ====================================================================
+	// ADMIN SESSIONS QUERIES
+	// ====================================================================
+	// Server-side tracking of admin login sessions for the "Active Sessions"
+	// page, remote revocation, and "log out everywhere".
+	//
+	// Managed entity:
+	// - admin_sessions: One row per signed-in browser, keyed by the random
+	//   token stored in the session cookie
+	//
+	// Security notes:
+	// - Deleting a row revokes the session; middleware.SessionTracker treats
+	//   cookies without a matching row as signed out
+	// - Revocation queries are scoped by user_id so users can only end their
+	//   own sessions
+	// ====================================================================
+	// Records a new session at login.
+	// Parameters:
+	//   1. token (TEXT): random token stored in the session cookie
+	//   2. user_id (INTEGER): signed-in admin user
+	//   3. ip_address (TEXT): client IP at login
+	//   4. user_agent (TEXT): browser User-Agent at login
+	CreateAdminSession(ctx context.Context, arg CreateAdminSessionParams) (AdminSession, error)
 	// sqlc annotation: :one returns the newly created user (partial)
 	// Purpose: Creates a new admin user account
 	// Parameters (4 positional):
@@ -388,6 +541,33 @@ type Querier interface {
 	//       is_active defaults to 1 (true) via schema default
 	//       UNIQUE constraint on email enforced at database level
 	CreateAdminUser(ctx context.Context, arg CreateAdminUserParams) (CreateAdminUserRow, error)
+	// ====================================================================
+	// COMPLIANCE ARCHIVE QUERIES
+	// ====================================================================
+	// This file supports the monthly archival job that exports audit and lead
+	// tables to compressed, hash-chained files and then prunes the live tables
+	// according to the retention policy.
+	//
+	// Managed entities:
+	// - archive_runs: append-only ledger of exported months (one row per table/month)
+	//
+	// Archived tables:
+	// - activity_log, contact_submissions, whitepaper_downloads
+	//
+	// Key concepts:
+	// - period: calendar month in 'YYYY-MM' format
+	// - chain_hash: sha256(prev_chain_hash || content_hash), linking every archive
+	//   of a table to all archives that came before it
+	// - Date bounds are passed as 'YYYY-MM-DD HH:MM:SS' text so they compare
+	//   correctly against CURRENT_TIMESTAMP values stored by SQLite
+	// ====================================================================
+	// ====================================================================
+	// ARCHIVE LEDGER
+	// ====================================================================
+	// sqlc annotation: :one returns the recorded ledger row
+	// Purpose: Records a completed monthly export in the append-only ledger
+	// Note: UNIQUE(source_table, period) prevents a month being archived twice
+	CreateArchiveRun(ctx context.Context, arg CreateArchiveRunParams) (ArchiveRun, error)
 	// sqlc annotation: :one returns the created author row
 	// Purpose: Creates a new blog author profile
 	// Parameters (8 positional):
@@ -428,6 +608,14 @@ type Querier interface {
 	//   12. published_at (TIMESTAMP): publication datetime (NULL for drafts)
 	// Return type: complete inserted row with ID and timestamps
 	CreateBlogPost(ctx context.Context, arg CreateBlogPostParams) (BlogPost, error)
+	// sqlc annotation: :one returns the created series row
+	// Purpose: Creates a new series
+	// Parameters (3 positional):
+	//   1. name (TEXT): series display name
+	//   2. slug (TEXT): URL-safe identifier (must be unique)
+	//   3. description (TEXT): optional summary shown on post pages
+	// Return type: complete inserted row with generated ID and timestamps
+	CreateBlogSeries(ctx context.Context, arg CreateBlogSeriesParams) (BlogSeries, error)
 	// sqlc annotation: :one returns the created tag
 	// Purpose: Creates a new blog tag
 	// Parameters (2 positional):
@@ -435,6 +623,18 @@ type Querier interface {
 	//   2. slug (TEXT): URL-safe identifier (must be unique)
 	// Return type: complete inserted row with ID
 	CreateBlogTag(ctx context.Context, arg CreateBlogTagParams) (BlogTag, error)
+	// Stores one broken link found by a run.
+	// Parameters:
+	//  1. source (TEXT): content type the link is in
+	//  2. owner_id (INTEGER): item the link is in
+	//  3. parent_id (INTEGER): landing page of a landing page section, else 0
+	//  4. owner_title (TEXT): title of the item
+	//  5. field (TEXT): field the link is in
+	//  6. url (TEXT): the link as written
+	//  7. status (INTEGER): HTTP status the link answered with
+	//  8. target_type (TEXT): content type of the unpublished target, or empty
+	//  9. target_id (INTEGER): ID of the unpublished target, or 0
+	CreateBrokenLink(ctx context.Context, arg CreateBrokenLinkParams) error
 	// Purpose: Creates a new CTA block variant
 	// Parameters (8 positional):
 	//   1. headline (TEXT): CTA section headline
@@ -490,6 +690,26 @@ type Querier interface {
 	// Return type: id and created_at only (minimal response)
 	// Note: status defaults to 'new' via schema default
 	CreateContactSubmission(ctx context.Context, arg CreateContactSubmissionParams) (CreateContactSubmissionRow, error)
+	// Adds a block to the library.
+	// Parameters:
+	//  1. name (TEXT): name shown in the admin only
+	//  2. block_type (TEXT): rich_text, cta, stats or logo_wall
+	//  3. heading (TEXT): heading shown above the block, may be empty
+	//  4. body (TEXT): sanitized HTML (rich text) or plain text (call to action)
+	//  5. button_text (TEXT): call to action button label
+	//  6. button_url (TEXT): call to action button target
+	//  7. items (TEXT): stats or logo entries, one per line
+	//  8. is_active (BOOLEAN): shown on the pages it is attached to
+	CreateContentBlock(ctx context.Context, arg CreateContentBlockParams) (ContentBlock, error)
+	// Records one transition.
+	// Parameters:
+	//  1. content_type (TEXT): 'blog_post' or 'product'
+	//  2. content_id (INTEGER): ID in the content type's table
+	//  3. from_state (TEXT): state before the transition
+	//  4. to_state (TEXT): state after the transition
+	//  5. user_id (INTEGER, nullable): user who made the transition
+	//  6. note (TEXT): reviewer comment, may be empty
+	CreateContentWorkflowHistory(ctx context.Context, arg CreateContentWorkflowHistoryParams) error
 	// sqlc annotation: :one returns the created row
 	// Purpose: Creates a new core value entry
 	// Parameters (4 positional):
@@ -499,6 +719,86 @@ type Querier interface {
 	//   4. display_order (INTEGER): sort position in list
 	// Return type: complete inserted row with generated ID
 	CreateCoreValue(ctx context.Context, arg CreateCoreValueParams) (CoreValue, error)
+	// ====================================================================
+	// CUSTOMER ACCOUNT QUERIES
+	// ====================================================================
+	// Public visitor accounts: sign-up, sign-in sessions, password resets and
+	// the library of whitepapers and product files downloaded while signed in.
+	//
+	// Managed entities:
+	// - customer_accounts: One row per registered visitor (email is unique,
+	//   case-insensitive)
+	// - customer_sessions: One row per signed-in browser, keyed by the hash of
+	//   the random token in the bluejay_account cookie
+	// - customer_password_resets: Outstanding reset links, by token hash
+	// - customer_library: Whitepapers and product files a customer downloaded
+	//
+	// Security notes:
+	// - Session and reset tokens are stored hashed; the plain tokens are only
+	//   in the cookie and the email
+	// - Deleting an account cascades to its sessions, resets and library
+	// ====================================================================
+	// Registers a customer account.
+	// Parameters:
+	//   1. email (TEXT): sign-in email, unique ignoring case
+	//   2. name (TEXT): full name, used for lead records
+	//   3. company (TEXT): company, used for lead records
+	//   4. designation (TEXT): role or title, may be empty
+	//   5. password_hash (TEXT): bcrypt hash of the password
+	//   6. marketing_consent (INTEGER): 1 if the customer opted in
+	CreateCustomerAccount(ctx context.Context, arg CreateCustomerAccountParams) (CustomerAccount, error)
+	// Stores a password reset link.
+	// Parameters:
+	//   @account_id (INTEGER): account being reset
+	//   @token_hash (TEXT): hex SHA-256 of the emailed token
+	//   @expires_at (TEXT): UTC "2006-01-02 15:04:05" expiry
+	CreateCustomerPasswordReset(ctx context.Context, arg CreateCustomerPasswordResetParams) error
+	// Stores a sign-up until its emailed confirmation link is followed.
+	// Parameters:
+	//   @token_hash (TEXT): hex SHA-256 of the emailed token
+	//   @email (TEXT): sign-in email
+	//   @name (TEXT): full name
+	//   @company (TEXT): company
+	//   @designation (TEXT): role or title, may be empty
+	//   @password_hash (TEXT): bcrypt hash of the password
+	//   @marketing_consent (INTEGER): 1 if the customer opted in
+	//   @expires_at (TEXT): UTC "2006-01-02 15:04:05" expiry
+	CreateCustomerRegistration(ctx context.Context, arg CreateCustomerRegistrationParams) error
+	// Records a new session at sign-in.
+	// Parameters:
+	//   1. token_hash (TEXT): hex SHA-256 of the random token stored in the
+	//   bluejay_account cookie
+	//   2. account_id (INTEGER): signed-in account
+	CreateCustomerSession(ctx context.Context, arg CreateCustomerSessionParams) (CustomerSession, error)
+	// Creates a documentation page.
+	// Parameters (9 positional): doc_version_id, parent_id, title, slug,
+	// body_markdown, body_html, meta_description, sort_order, is_published
+	CreateDocPage(ctx context.Context, arg CreateDocPageParams) (DocPage, error)
+	// Creates a doc set without versions.
+	// Parameters (6 positional): name, slug, product_id, description,
+	// is_published, sort_order
+	CreateDocSet(ctx context.Context, arg CreateDocSetParams) (DocSet, error)
+	// Adds a version to a doc set.
+	// Parameters (2 positional): doc_set_id, version
+	CreateDocVersion(ctx context.Context, arg CreateDocVersionParams) (DocVersion, error)
+	// Marks a media file as an editor upload.
+	CreateEditorAttachment(ctx context.Context, mediaFileID int64) error
+	// Creates an event.
+	// Parameters (13 positional): every column of events except id and the
+	// timestamps, in table order
+	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
+	// Registers an address for an event. Registering the same address again
+	// changes nothing and affects no rows.
+	// Parameters (8 positional): event_id, name, email, company, designation,
+	// marketing_consent, ip_address, user_agent
+	CreateEventRegistration(ctx context.Context, arg CreateEventRegistrationParams) (int64, error)
+	// Queues an export.
+	// Parameters:
+	//  1. kind (TEXT): export source, e.g. 'products'
+	//  2. format (TEXT): 'csv' or 'xlsx'
+	//  3. params (TEXT): list filters as a URL query string, may be empty
+	//  4. user_id (INTEGER, nullable): user who requested the export
+	CreateExportJob(ctx context.Context, arg CreateExportJobParams) (ExportJob, error)
 	// Purpose: Creates new content block in footer column
 	// Parameters: column_index, type, heading, content, sort_order
 	// type examples: 'text', 'links', 'newsletter'
@@ -509,6 +809,38 @@ type Querier interface {
 	// Purpose: Creates a new link within a footer column item
 	// Parameters: column_item_id (parent), label (link text), url, sort_order
 	CreateFooterLink(ctx context.Context, arg CreateFooterLinkParams) (FooterLink, error)
+	// Adds a form.
+	// Parameters:
+	//  1. name (TEXT): form name, shown as its heading
+	//  2. slug (TEXT): path below /forms/
+	//  3. description (TEXT): introduction shown above the fields, may be empty
+	//  4. submit_label (TEXT): submit button label
+	//  5. success_message (TEXT): shown after a successful submission
+	//  6. notify_emails (TEXT): comma-separated addresses told of each submission
+	//  7. is_active (BOOLEAN): whether visitors can see and submit the form
+	CreateForm(ctx context.Context, arg CreateFormParams) (Form, error)
+	// Adds a field to a form.
+	// Parameters:
+	//  1. form_id (INTEGER): form the field belongs to
+	//  2. label (TEXT): label shown to visitors
+	//  3. name (TEXT): key the answer is stored under, unique within the form
+	//  4. field_type (TEXT): text, email, tel, number, textarea, select or checkbox
+	//  5. placeholder (TEXT): hint inside the input, may be empty
+	//  6. help_text (TEXT): hint below the input, may be empty
+	//  7. options (TEXT): select choices, one per line
+	//  8. required (BOOLEAN): whether the field must be answered
+	//  9. min_length (INTEGER): fewest characters accepted, 0 for no minimum
+	//  10. max_length (INTEGER): most characters accepted, 0 for no maximum
+	//  11. sort_order (INTEGER): position on the form, 0 first
+	CreateFormField(ctx context.Context, arg CreateFormFieldParams) (FormField, error)
+	// Stores a submission.
+	// Parameters:
+	//  1. form_id (INTEGER): form that was submitted
+	//  2. data (TEXT): JSON array of {name, label, value} answers
+	//  3. email (TEXT): first email answer, may be empty
+	//  4. ip_address (TEXT): visitor IP address
+	//  5. user_agent (TEXT): visitor browser
+	CreateFormSubmission(ctx context.Context, arg CreateFormSubmissionParams) (FormSubmission, error)
 	// Purpose: Creates a new hero banner variant
 	// Parameters (10 positional):
 	//   1. headline (TEXT): main hero headline
@@ -536,6 +868,47 @@ type Querier interface {
 	//
 	// Note: RETURNING * returns all columns including auto-generated created_at, updated_at
 	CreateIndustry(ctx context.Context, arg CreateIndustryParams) (Industry, error)
+	// Records an application whose CV has been stored.
+	// Parameters (9 positional): job_id, name, email, phone, cover_letter,
+	// cv_key, cv_filename, cv_size, ip_address
+	CreateJobApplication(ctx context.Context, arg CreateJobApplicationParams) (JobApplication, error)
+	// Creates a job posting.
+	// Parameters (13 positional): every column of job_postings except id and
+	// the timestamps, in table order
+	CreateJobPosting(ctx context.Context, arg CreateJobPostingParams) (JobPosting, error)
+	// Adds a landing page as a draft.
+	// Parameters:
+	//  1. title (TEXT): page heading and browser title
+	//  2. slug (TEXT): path below the site root, without leading slash
+	//  3. layout (TEXT): default (site header and footer) or minimal
+	//  4. meta_title (TEXT): title for search results, may be empty
+	//  5. meta_description (TEXT): description for search results, may be empty
+	//  6. og_image (TEXT): social sharing image, may be empty
+	//  7. noindex (BOOLEAN): asks search engines not to index the page
+	//  8. head_snippet (TEXT): analytics HTML added to <head>
+	//  9. body_snippet (TEXT): analytics HTML added before </body>
+	CreateLandingPage(ctx context.Context, arg CreateLandingPageParams) (LandingPage, error)
+	// Adds a section to a landing page.
+	// Parameters:
+	//  1. landing_page_id (INTEGER): page the section belongs to
+	//  2. section_type (TEXT): hero, text, form or testimonials
+	//  3. heading (TEXT): section heading, may be empty
+	//  4. body (TEXT): sanitized HTML (text) or plain text (other types)
+	//  5. image (TEXT): hero image, may be empty
+	//  6. button_text (TEXT): hero button or form submit label
+	//  7. button_url (TEXT): hero button target
+	//  8. items (TEXT): testimonial entries, one per line
+	//  9. sort_order (INTEGER): position on the page, 0 first
+	CreateLandingPageSection(ctx context.Context, arg CreateLandingPageSectionParams) (LandingPageSection, error)
+	// sqlc annotation: :exec inserts a locale unless the code already exists
+	// Purpose: Seeds SITE_LOCALES at startup and adds languages from the admin page
+	// Parameters:
+	//
+	//	@code (TEXT): Locale code (e.g., "de")
+	//	@name (TEXT): Language name shown in the switcher (e.g., "Deutsch")
+	//	@is_default (BOOLEAN): Whether this is the source-content locale
+	//	@sort_order (INTEGER): Position in the switcher
+	CreateLocale(ctx context.Context, arg CreateLocaleParams) error
 	// Inserts a new media file record after successful upload.
 	//
 	// Parameters:
@@ -547,11 +920,22 @@ type Querier interface {
 	//   $6 (INTEGER) - width: Image width in pixels (NULL for non-images)
 	//   $7 (INTEGER) - height: Image height in pixels (NULL for non-images)
 	//   $8 (TEXT) - alt_text: Accessibility alt text for images (optional)
+	//   $9 (TEXT) - content_hash: Hex SHA-256 of the contents ("" if unknown)
 	//
 	// Returns: MediaFile - The newly created media file record with auto-generated ID and timestamps
 	//
 	// Note: width/height should be extracted from image files during upload processing
 	CreateMediaFile(ctx context.Context, arg CreateMediaFileParams) (MediaFile, error)
+	// Records a resized copy of a media file.
+	//
+	// Parameters:
+	//   $1 (INTEGER) - media_file_id: Original file
+	//   $2 (TEXT) - name: Variant name (e.g., "thumb", "medium")
+	//   $3 (TEXT) - file_path: Web path of the resized file
+	//   $4, $5 (INTEGER) - width, height: Pixel size of the variant
+	//   $6 (INTEGER) - file_size: Size in bytes
+	// Returns: MediaVariant
+	CreateMediaVariant(ctx context.Context, arg CreateMediaVariantParams) (MediaVariant, error)
 	// sqlc annotation: :one returns created milestone
 	// Purpose: Creates a new milestone entry for company timeline
 	// Parameters (5 positional):
@@ -591,6 +975,14 @@ type Querier interface {
 	//
 	// Use case: Setting up a new menu location (header, footer, sidebar, etc.)
 	CreateNavigationMenu(ctx context.Context, arg CreateNavigationMenuParams) (NavigationMenu, error)
+	// Signs up a new address as pending, with the confirmation just sent.
+	// Parameters:
+	//   1. email (TEXT): subscriber address, unique ignoring case
+	//   2. confirm_token_hash (TEXT): hex SHA-256 of the emailed token
+	//   3. unsubscribe_token (TEXT): random token for unsubscribe links
+	//   4. source (TEXT): page the form was submitted from
+	//   5. ip_address (TEXT): client IP of the sign-up
+	CreateNewsletterSubscriber(ctx context.Context, arg CreateNewsletterSubscriberParams) (NewsletterSubscriber, error)
 	CreateOfficeLocation(ctx context.Context, arg CreateOfficeLocationParams) (CreateOfficeLocationRow, error)
 	// Creates a new partner record.
 	//
@@ -607,6 +999,17 @@ type Querier interface {
 	//
 	// Note: is_active defaults to 1 (true) via database schema, is_featured defaults to 0
 	CreatePartner(ctx context.Context, arg CreatePartnerParams) (Partner, error)
+	// Stores a portal invite for a partner.
+	// Parameters:
+	//   @partner_id (INTEGER): partner the link edits
+	//   @email (TEXT): address the link was sent to, may be empty
+	//   @token_hash (TEXT): hex SHA-256 of the link token
+	//   @expires_at (TEXT): UTC "2006-01-02 15:04:05" expiry
+	CreatePartnerInvite(ctx context.Context, arg CreatePartnerInviteParams) (PartnerInvite, error)
+	// Stores a pending submission from the partner portal.
+	// Parameters (9 positional): partner_id, invite_id, logo_url, description,
+	// website_url, requested_tier_id, tier_evidence, contact_name, ip_address
+	CreatePartnerSubmission(ctx context.Context, arg CreatePartnerSubmissionParams) (PartnerSubmission, error)
 	// Creates a new partner tier category.
 	//
 	// Parameters:
@@ -619,6 +1022,17 @@ type Querier interface {
 	//
 	// Note: RETURNING * includes auto-generated created_at, updated_at timestamps
 	CreatePartnerTier(ctx context.Context, arg CreatePartnerTierParams) (PartnerTier, error)
+	// Adds an uploaded file to the media kit.
+	// Parameters (5 positional): title, description, file_path, file_size,
+	// sort_order
+	CreatePressKitAsset(ctx context.Context, arg CreatePressKitAssetParams) (PressKitAsset, error)
+	// Adds an "In the news" link.
+	// Parameters (5 positional): outlet, headline, url, published_on, is_published
+	CreatePressMention(ctx context.Context, arg CreatePressMentionParams) (PressMention, error)
+	// Creates a press release.
+	// Parameters (8 positional): every column of press_releases except id and
+	// the timestamps, in table order
+	CreatePressRelease(ctx context.Context, arg CreatePressReleaseParams) (PressRelease, error)
 	// ====================================================================
 	// PRODUCTS QUERY FILE
 	// ====================================================================
@@ -712,12 +1126,31 @@ type Querier interface {
 	//   $6 (INTEGER) - file_size: File size in bytes
 	//   $7 (TEXT) - version: Document/file version (e.g., "v2.1", "Rev A")
 	//   $8 (INTEGER) - display_order: Position in downloads list
+	//   $9 (TEXT) - changelog: What changed in this version ('' for none)
+	//   $10 (INTEGER) - supersedes_id: The previous version of this file (NULL for a new file)
+	//   $11 (INTEGER) - is_gated: 1 when the file is only handed out after the lead form
 	//
 	// Returns: ProductDownload - The newly created download with auto-generated ID
 	//
 	// Use case: Adding technical documents during product creation/editing
 	// Note: download_count initializes to 0 via database schema default
 	CreateProductDownload(ctx context.Context, arg CreateProductDownloadParams) (ProductDownload, error)
+	// Records the lead form submitted for a gated product download.
+	//
+	// Parameters:
+	//   $1 (INTEGER) - product_download_id: Download being requested
+	//   $2 (TEXT) - name: Visitor's name
+	//   $3 (TEXT) - email: Visitor's email (lead capture)
+	//   $4 (TEXT) - company: Visitor's company
+	//   $5 (TEXT) - designation: Visitor's job title (optional)
+	//   $6 (INTEGER) - marketing_consent: 1 if the visitor opted in to marketing
+	//   $7 (TEXT) - ip_address: Visitor's IP address
+	//   $8 (TEXT) - user_agent: Visitor's browser user agent
+	//
+	// Returns: ProductDownloadLead - The recorded lead
+	//
+	// Use case: The gated download form on product pages, before the signed link is issued
+	CreateProductDownloadLead(ctx context.Context, arg CreateProductDownloadLeadParams) (ProductDownloadLead, error)
 	// ====================================================================
 	// PRODUCT FEATURES (Bullet-Point Feature Lists)
 	// ====================================================================
@@ -736,7 +1169,7 @@ type Querier interface {
 	// ====================================================================
 	// PRODUCT IMAGES (Gallery Images)
 	// ====================================================================
-	// Adds a gallery image to a product.
+	// Adds a gallery image, video or 3D/CAD file to a product.
 	//
 	// Parameters:
 	//   $1 (INTEGER) - product_id: Foreign key to parent product
@@ -745,6 +1178,8 @@ type Querier interface {
 	//   $4 (TEXT) - caption: Optional image caption for display
 	//   $5 (INTEGER) - display_order: Position in image gallery
 	//   $6 (BOOLEAN) - is_thumbnail: Whether this image is the thumbnail (usually first image)
+	//   $7 (TEXT) - media_type: 'image', 'video' or 'model'; '' means 'image'
+	//   $8 (TEXT) - media_url: Video player URL or uploaded video/3D file, '' for images
 	//
 	// Returns: ProductImage - The newly created image record with auto-generated ID
 	//
@@ -768,6 +1203,56 @@ type Querier interface {
 	// Use case: Adding technical specifications during product creation/editing
 	// Note: Specs can be grouped by section_name for tabbed or sectioned display
 	CreateProductSpec(ctx context.Context, arg CreateProductSpecParams) (ProductSpec, error)
+	// Adds a variant to a product.
+	// Parameters:
+	//   1. product_id (INTEGER): parent product
+	//   2. sku (TEXT): unique variant SKU
+	//   3. name (TEXT): selector label, e.g. "230V AC"
+	//   4. display_order (INTEGER): position in the selector
+	CreateProductVariant(ctx context.Context, arg CreateProductVariantParams) (ProductVariant, error)
+	// Adds a gallery image to a variant.
+	// Parameters:
+	//   1. variant_id (INTEGER): variant
+	//   2. image_path (TEXT): stored upload path
+	//   3. alt_text (TEXT): accessibility text
+	//   4. display_order (INTEGER): position in the gallery
+	CreateProductVariantImage(ctx context.Context, arg CreateProductVariantImageParams) (ProductVariantImage, error)
+	// Adds a manual rule.
+	// Parameters:
+	//   1. source_path (TEXT): path to redirect, may end in "*"
+	//   2. target_path (TEXT): path or absolute URL to redirect to
+	//   3. status_code (INTEGER): 301 or 302
+	CreateRedirect(ctx context.Context, arg CreateRedirectParams) (Redirect, error)
+	// Pins an item to a product. Pinning it again only updates its order.
+	// Parameters:
+	//   1. product_id (INTEGER): product
+	//   2. content_type (TEXT): 'case_study', 'blog_post' or 'whitepaper'
+	//   3. content_id (INTEGER): ID in the content type's table
+	//   4. display_order (INTEGER): position among the product's pins of that type
+	CreateRelatedContentPin(ctx context.Context, arg CreateRelatedContentPinParams) error
+	// Adds an override.
+	// Parameters:
+	//  1. route (TEXT): page path, empty for a content item
+	//  2. entity_type (TEXT): content type, empty for a path
+	//  3. entity_id (INTEGER): content ID, or 0 for a path
+	//  4. meta_title (TEXT): title, empty to keep the page's own
+	//  5. meta_description (TEXT): description, empty to keep the page's own
+	//  6. canonical_url (TEXT): canonical path, empty for the page's own path
+	//  7. og_image (TEXT): Open Graph / Twitter image, may be empty
+	//  8. noindex (BOOLEAN): ask search engines not to index the page
+	CreateSEOMeta(ctx context.Context, arg CreateSEOMetaParams) (SeoMetum, error)
+	// Adds a link.
+	// Parameters:
+	//  1. code (TEXT): lowercase code after /go/
+	//  2. destination (TEXT): site path or http(s) URL
+	//  3. utm_source (TEXT): UTM source, empty to leave out
+	//  4. utm_medium (TEXT): UTM medium, empty to leave out
+	//  5. utm_campaign (TEXT): UTM campaign, empty to leave out
+	//  6. utm_term (TEXT): UTM term, empty to leave out
+	//  7. utm_content (TEXT): UTM content, empty to leave out
+	//  8. note (TEXT): where the link is used
+	//  9. expires_at (DATETIME): end of the link, NULL for none
+	CreateShortLink(ctx context.Context, arg CreateShortLinkParams) (ShortLink, error)
 	// Creates a new solution record.
 	//
 	// Parameters:
@@ -874,6 +1359,12 @@ type Querier interface {
 	//   7. display_order (INTEGER): carousel slide order
 	//   8. is_active (BOOLEAN): whether to display on homepage
 	CreateTestimonialHomepage(ctx context.Context, arg CreateTestimonialHomepageParams) (HomepageTestimonial, error)
+	// Adds an active endpoint.
+	// Parameters:
+	//   1. url (TEXT): http(s) URL the events are POSTed to
+	//   2. secret (TEXT): HMAC key for the X-Bluejay-Signature header
+	//   3. events (TEXT): comma-separated event types, blank for all
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
 	// Creates a new whitepaper record.
 	//
 	// Parameters:
@@ -935,9 +1426,33 @@ type Querier interface {
 	//
 	// Note: color_hex is used for visual differentiation in topic badges and cards
 	CreateWhitepaperTopic(ctx context.Context, arg CreateWhitepaperTopicParams) (WhitepaperTopic, error)
+	// Switches off every other menu at a location before a menu is activated.
+	//
+	// Parameters:
+	//
+	//	$1 (TEXT) - location: Location of the menu being activated
+	//	$2 (INTEGER) - id: Menu ID being activated (left unchanged)
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	DeactivateOtherNavigationMenus(ctx context.Context, arg DeactivateOtherNavigationMenusParams) error
+	// sqlc annotation: :execrows returns the number of pruned rows
+	// Purpose: Applies the retention policy once rows have been archived
+	DeleteActivityLogsBefore(ctx context.Context, cutoff string) (int64, error)
+	// Revokes one of a user's sessions. Scoped by user_id so users cannot end
+	// other people's sessions.
+	// Parameters:
+	//   1. id (INTEGER): session ID
+	//   2. user_id (INTEGER): owner of the session
+	DeleteAdminSession(ctx context.Context, arg DeleteAdminSessionParams) error
+	// Removes the session of the current cookie at logout.
+	DeleteAdminSessionByToken(ctx context.Context, token string) error
+	// Revokes every session of a user ("log out everywhere").
+	DeleteAdminSessionsByUser(ctx context.Context, userID int64) error
 	// Purpose: Removes all legal links (used when rebuilding legal link set)
 	// Note: No WHERE clause - deletes entire table contents
 	DeleteAllFooterLegalLinks(ctx context.Context) error
+	// Clears the report.
+	DeleteAllNotFound(ctx context.Context) error
 	// sqlc annotation: :exec returns no data, only error or success
 	// Purpose: Permanently removes a blog author
 	// Parameters:
@@ -963,6 +1478,12 @@ type Querier interface {
 	//       if foreign keys are configured with ON DELETE CASCADE
 	DeleteBlogPost(ctx context.Context, id int64) error
 	// sqlc annotation: :exec returns no data
+	// Purpose: Deletes a series
+	// Parameters:
+	//   1. id (INTEGER): series to delete
+	// Note: member posts are kept; ON DELETE SET NULL clears their series_id
+	DeleteBlogSeries(ctx context.Context, id int64) error
+	// sqlc annotation: :exec returns no data
 	// Purpose: Permanently removes a blog tag
 	// Parameters:
 	//   1. id (INTEGER): tag to delete
@@ -970,6 +1491,8 @@ type Querier interface {
 	// Note: May fail if tag is still associated with posts (foreign key constraint)
 	//       Consider removing tag associations first or using ON DELETE CASCADE
 	DeleteBlogTag(ctx context.Context, id int64) error
+	// Clears the results of the previous run.
+	DeleteBrokenLinks(ctx context.Context) error
 	// Purpose: Removes a CTA block variant
 	DeleteCTA(ctx context.Context, id int64) error
 	// sqlc annotation: :exec returns no data
@@ -979,12 +1502,40 @@ type Querier interface {
 	// Return type: none
 	DeleteCertification(ctx context.Context, id int64) error
 	DeleteContactSubmission(ctx context.Context, id int64) error
+	// sqlc annotation: :execrows returns the number of pruned rows
+	DeleteContactSubmissionsBefore(ctx context.Context, cutoff string) (int64, error)
+	// Removes a block and, through the foreign key, its page attachments.
+	DeleteContentBlock(ctx context.Context, id int64) error
 	// sqlc annotation: :exec returns no data, only error or nil
 	// Purpose: Permanently removes a core value entry
 	// Parameters:
 	//   1. id (INTEGER): core value to delete
 	// Return type: none (exec returns only error status)
 	DeleteCoreValue(ctx context.Context, id int64) error
+	// Removes an account with its sessions, resets and library. Leads it
+	// submitted stay in the lead tables.
+	DeleteCustomerAccount(ctx context.Context, id int64) error
+	// Invalidates every reset link of an account once one has been used.
+	DeleteCustomerPasswordResets(ctx context.Context, accountID int64) error
+	// Invalidates every pending sign-up of an email once one is confirmed.
+	// Matches ignoring case.
+	DeleteCustomerRegistrations(ctx context.Context, email string) error
+	// Removes the session of the current cookie at sign-out.
+	DeleteCustomerSession(ctx context.Context, tokenHash string) error
+	// Signs an account out everywhere, after a password reset.
+	DeleteCustomerSessionsByAccount(ctx context.Context, accountID int64) error
+	// Deletes a page without subpages.
+	DeleteDocPage(ctx context.Context, id int64) error
+	// Deletes a doc set with its versions and pages (ON DELETE CASCADE).
+	DeleteDocSet(ctx context.Context, id int64) error
+	// Deletes a version with its pages (ON DELETE CASCADE).
+	DeleteDocVersion(ctx context.Context, id int64) error
+	// Deletes an event with its registrations (ON DELETE CASCADE).
+	DeleteEvent(ctx context.Context, id int64) error
+	// Removes locks whose heartbeat stopped before cutoff.
+	// Parameters:
+	//  1. cutoff (TEXT): heartbeats before this have expired
+	DeleteExpiredEditLocks(ctx context.Context, cutoff string) (int64, error)
 	// Purpose: Removes specific footer column item
 	DeleteFooterColumnItem(ctx context.Context, id int64) error
 	// Purpose: Bulk delete all items in columns >= specified index
@@ -997,6 +1548,16 @@ type Querier interface {
 	// Parameters:
 	//   1. column_item_id (INTEGER): delete all links for this column item
 	DeleteFooterLinksByColumnItem(ctx context.Context, columnItemID int64) error
+	// Removes a form and, through the foreign keys, its fields and submissions.
+	DeleteForm(ctx context.Context, id int64) error
+	// Removes a field from its form.
+	DeleteFormField(ctx context.Context, id int64) error
+	// Removes a submission. The form ID guards against deleting another form's
+	// submissions.
+	// Parameters (named):
+	//  1. id (INTEGER): submission ID
+	//  2. form_id (INTEGER): form the submission must belong to
+	DeleteFormSubmission(ctx context.Context, arg DeleteFormSubmissionParams) error
 	// Purpose: Removes a hero banner variant
 	DeleteHero(ctx context.Context, id int64) error
 	// Permanently deletes an industry record.
@@ -1008,6 +1569,14 @@ type Querier interface {
 	// WARNING: This is a hard delete. Consider adding soft delete (is_active flag) for production.
 	// Note: May fail if foreign key constraints exist (e.g., solutions referencing this industry)
 	DeleteIndustry(ctx context.Context, id int64) error
+	// Deletes an application; the caller deletes its CV.
+	DeleteJobApplication(ctx context.Context, id int64) error
+	// Deletes a job posting with its applications (ON DELETE CASCADE).
+	DeleteJobPosting(ctx context.Context, id int64) error
+	// Removes a landing page and, through the foreign key, its sections.
+	DeleteLandingPage(ctx context.Context, id int64) error
+	// Removes a section from its page.
+	DeleteLandingPageSection(ctx context.Context, id int64) error
 	// Permanently deletes a media file record from the database.
 	//
 	// Parameters:
@@ -1051,7 +1620,13 @@ type Querier interface {
 	// WARNING: This is a hard delete. Should cascade delete all navigation_items in this menu
 	// Note: Ensure foreign key constraints are configured for cascading deletes
 	DeleteNavigationMenu(ctx context.Context, id int64) error
+	// Removes an address entirely (e.g. on an erasure request).
+	DeleteNewsletterSubscriber(ctx context.Context, id int64) error
+	// Dismisses a path from the report, e.g. once a redirect covers it.
+	DeleteNotFoundPath(ctx context.Context, path string) error
 	DeleteOfficeLocation(ctx context.Context, id int64) error
+	// Deletes the views recorded before cutoff once they are rolled up.
+	DeletePageViewsBefore(ctx context.Context, cutoff string) (int64, error)
 	// Permanently deletes a partner record.
 	//
 	// Parameters:
@@ -1070,6 +1645,12 @@ type Querier interface {
 	// WARNING: Will fail if partners reference this tier (foreign key constraint)
 	// Note: Consider archiving or reassigning partners before deletion
 	DeletePartnerTier(ctx context.Context, id int64) error
+	// Removes a file from the media kit; the caller deletes the upload.
+	DeletePressKitAsset(ctx context.Context, id int64) error
+	// Deletes an "In the news" link.
+	DeletePressMention(ctx context.Context, id int64) error
+	// Deletes a press release.
+	DeletePressRelease(ctx context.Context, id int64) error
 	// Permanently deletes a product record.
 	//
 	// Parameters:
@@ -1176,6 +1757,26 @@ type Querier interface {
 	// Use case: Clearing all specs before re-importing or rebuilding spec list
 	// WARNING: Deletes ALL specs for the product in one operation
 	DeleteProductSpecs(ctx context.Context, productID int64) error
+	// Deletes a variant; its spec overrides and images cascade.
+	DeleteProductVariant(ctx context.Context, arg DeleteProductVariantParams) error
+	// Removes one image from a variant's gallery.
+	DeleteProductVariantImage(ctx context.Context, arg DeleteProductVariantImageParams) error
+	// Removes one spec override, restoring the product's value.
+	DeleteProductVariantSpec(ctx context.Context, arg DeleteProductVariantSpecParams) error
+	// Removes a rule.
+	DeleteRedirect(ctx context.Context, id int64) error
+	// Removes the rule for a path that is live again, so it does not shadow
+	// the page now published there.
+	DeleteRedirectBySource(ctx context.Context, sourcePath string) error
+	// Removes a pin from a product.
+	// Parameters:
+	//   1. id (INTEGER): pin ID
+	//   2. product_id (INTEGER): product the pin must belong to
+	DeleteRelatedContentPin(ctx context.Context, arg DeleteRelatedContentPinParams) error
+	// Removes an override; the page shows its own metadata again.
+	DeleteSEOMeta(ctx context.Context, id int64) error
+	// Removes a link.
+	DeleteShortLink(ctx context.Context, id int64) error
 	// Permanently deletes a solution.
 	//
 	// Parameters:
@@ -1235,6 +1836,19 @@ type Querier interface {
 	//
 	// Use case: Clearing stats before rebuilding or deleting solution
 	DeleteSolutionStatsBySolutionID(ctx context.Context, solutionID int64) error
+	// Removes sessions idle since before the cutoff; their cookies have expired.
+	// Parameters:
+	//   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp, typically now minus
+	//   the cookie MaxAge
+	DeleteStaleAdminSessions(ctx context.Context, cutoff string) error
+	// Removes sign-ups whose confirmation link has expired.
+	// Parameters:
+	//   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+	DeleteStaleCustomerRegistrations(ctx context.Context, now string) error
+	// Removes sessions created before the cutoff; their cookies have expired.
+	// Parameters:
+	//   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp
+	DeleteStaleCustomerSessions(ctx context.Context, cutoff string) error
 	// Purpose: Removes a homepage statistic
 	DeleteStat(ctx context.Context, id int64) error
 	// Permanently deletes a partner testimonial.
@@ -1247,6 +1861,28 @@ type Querier interface {
 	DeleteTestimonial(ctx context.Context, id int64) error
 	// Purpose: Removes a homepage testimonial
 	DeleteTestimonialHomepage(ctx context.Context, id int64) error
+	// Permanently deletes a blog post that is in the trash. Live rows are not
+	// affected, so content must be trashed before it can be destroyed.
+	// Parameters:
+	//   1. id (INTEGER): blog post to delete
+	DeleteTrashedBlogPost(ctx context.Context, id int64) (int64, error)
+	// Permanently deletes a case study that is in the trash. Live rows are not
+	// affected, so content must be trashed before it can be destroyed.
+	// Parameters:
+	//   1. id (INTEGER): case study to delete
+	DeleteTrashedCaseStudy(ctx context.Context, id int64) (int64, error)
+	// Permanently deletes a product that is in the trash. Live rows are not
+	// affected, so content must be trashed before it can be destroyed.
+	// Parameters:
+	//   1. id (INTEGER): product to delete
+	DeleteTrashedProduct(ctx context.Context, id int64) (int64, error)
+	// Permanently deletes a solution that is in the trash. Live rows are not
+	// affected, so content must be trashed before it can be destroyed.
+	// Parameters:
+	//   1. id (INTEGER): solution to delete
+	DeleteTrashedSolution(ctx context.Context, id int64) (int64, error)
+	// Removes an endpoint.
+	DeleteWebhook(ctx context.Context, id int64) error
 	// Permanently deletes a whitepaper.
 	//
 	// Parameters:
@@ -1256,6 +1892,8 @@ type Querier interface {
 	// WARNING: Should cascade delete related records (learning points, downloads)
 	// Note: Physical PDF file should be deleted separately by application code
 	DeleteWhitepaper(ctx context.Context, id int64) error
+	// sqlc annotation: :execrows returns the number of pruned rows
+	DeleteWhitepaperDownloadsBefore(ctx context.Context, cutoff string) (int64, error)
 	// Deletes all learning points for a whitepaper (bulk delete).
 	//
 	// Parameters:
@@ -1273,6 +1911,151 @@ type Querier interface {
 	// WARNING: Will fail if whitepapers reference this topic (foreign key constraint)
 	// Note: Reassign or delete whitepapers in this topic before deletion
 	DeleteWhitepaperTopic(ctx context.Context, id int64) error
+	// Removes a block from a page; the block stays in the library.
+	DetachPageBlock(ctx context.Context, id int64) error
+	// Reports whether another page of the version uses a slug.
+	// Parameters (named):
+	//  1. doc_version_id (INTEGER): version of the page
+	//  2. slug (TEXT): candidate slug
+	//  3. exclude_id (INTEGER): page being updated, 0 when creating
+	DocPageSlugTaken(ctx context.Context, arg DocPageSlugTakenParams) (int64, error)
+	// Copies a live blog post as an unscheduled draft outside any series and
+	// returns the copy's ID (sql.ErrNoRows when the source is missing or trashed).
+	// Parameters (named):
+	//  1. title (TEXT): title of the copy
+	//  2. slug (TEXT): slug of the copy
+	//  3. source_id (INTEGER): blog post to copy
+	DuplicateBlogPost(ctx context.Context, arg DuplicateBlogPostParams) (int64, error)
+	// Copies a blog post's related products to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateBlogPostProducts(ctx context.Context, arg DuplicateBlogPostProductsParams) error
+	// Copies a blog post's tags to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateBlogPostTags(ctx context.Context, arg DuplicateBlogPostTagsParams) error
+	// Copies a live case study as an unpublished one and returns the copy's ID
+	// (sql.ErrNoRows when the source is missing or trashed).
+	// Parameters (named):
+	//  1. title (TEXT): title of the copy
+	//  2. slug (TEXT): slug of the copy
+	//  3. source_id (INTEGER): case study to copy
+	DuplicateCaseStudy(ctx context.Context, arg DuplicateCaseStudyParams) (int64, error)
+	// Copies a case study's metrics to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateCaseStudyMetrics(ctx context.Context, arg DuplicateCaseStudyMetricsParams) error
+	// Copies a case study's product links to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateCaseStudyProducts(ctx context.Context, arg DuplicateCaseStudyProductsParams) error
+	// Copies a live product as a draft and returns the copy's ID
+	// (sql.ErrNoRows when the source is missing or trashed).
+	// Parameters (named):
+	//  1. sku (TEXT): SKU of the copy
+	//  2. slug (TEXT): slug of the copy
+	//  3. name (TEXT): name of the copy
+	//  4. source_id (INTEGER): product to copy
+	DuplicateProduct(ctx context.Context, arg DuplicateProductParams) (int64, error)
+	// Copies a product's certifications to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductCertifications(ctx context.Context, arg DuplicateProductCertificationsParams) error
+	// Links the copied downloads into the same version chains as the
+	// originals. Run after DuplicateProductDownloads, which copies the rows in
+	// ID order, so the n-th copy is the copy of the n-th original.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductDownloadVersions(ctx context.Context, arg DuplicateProductDownloadVersionsParams) error
+	// Copies a product's downloads to its copy, with a fresh download count.
+	// Files are shared.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductDownloads(ctx context.Context, arg DuplicateProductDownloadsParams) error
+	// Copies a product's feature list to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductFeatures(ctx context.Context, arg DuplicateProductFeaturesParams) error
+	// Copies a product's gallery to its copy. Image, video and 3D files are shared.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductImages(ctx context.Context, arg DuplicateProductImagesParams) error
+	// Copies a product's pinned related content to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductRelatedContent(ctx context.Context, arg DuplicateProductRelatedContentParams) error
+	// Copies a product's specifications to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateProductSpecs(ctx context.Context, arg DuplicateProductSpecsParams) error
+	// Copies one variant to the product's copy and returns the new variant ID.
+	// Parameters (named):
+	//  1. new_product_id (INTEGER): the product copy
+	//  2. sku (TEXT): SKU of the variant copy
+	//  3. source_id (INTEGER): variant to copy
+	DuplicateProductVariant(ctx context.Context, arg DuplicateProductVariantParams) (int64, error)
+	// Copies a variant's gallery to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the variant copy
+	//  2. source_id (INTEGER): the original variant
+	DuplicateProductVariantImages(ctx context.Context, arg DuplicateProductVariantImagesParams) error
+	// Copies a variant's spec overrides to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the variant copy
+	//  2. source_id (INTEGER): the original variant
+	DuplicateProductVariantSpecs(ctx context.Context, arg DuplicateProductVariantSpecsParams) error
+	// Copies a live solution as an unpublished one and returns the copy's ID
+	// (sql.ErrNoRows when the source is missing or trashed).
+	// Parameters (named):
+	//  1. title (TEXT): title of the copy
+	//  2. slug (TEXT): slug of the copy
+	//  3. source_id (INTEGER): solution to copy
+	DuplicateSolution(ctx context.Context, arg DuplicateSolutionParams) (int64, error)
+	// Copies a solution's call-to-action sections to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateSolutionCTAs(ctx context.Context, arg DuplicateSolutionCTAsParams) error
+	// Copies a solution's challenges to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateSolutionChallenges(ctx context.Context, arg DuplicateSolutionChallengesParams) error
+	// Copies a solution's product links to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateSolutionProducts(ctx context.Context, arg DuplicateSolutionProductsParams) error
+	// Copies a solution's statistics to its copy.
+	// Parameters (named):
+	//  1. new_id (INTEGER): the copy
+	//  2. source_id (INTEGER): the original
+	DuplicateSolutionStats(ctx context.Context, arg DuplicateSolutionStatsParams) error
+	// Copies an item's translations to its copy. Their source hash no longer
+	// matches once the copy is renamed, so they show as outdated.
+	// Parameters (named):
+	//  1. entity_type (TEXT): 'product', 'blog_post' or 'solution'
+	//  2. new_id (INTEGER): the copy
+	//  3. source_id (INTEGER): the original
+	DuplicateTranslations(ctx context.Context, arg DuplicateTranslationsParams) error
+	// Marks a job expired once its file is deleted.
+	ExpireExportJob(ctx context.Context, id int64) error
+	// Marks a job failed.
+	// Parameters:
+	//  1. error (TEXT): reason shown on the export page
+	//  2. id (INTEGER): job ID
+	FailExportJob(ctx context.Context, arg FailExportJobParams) error
 	// ====================================================================
 	// HOMEPAGE CALL-TO-ACTION (CTA)
 	// ====================================================================
@@ -1321,6 +2104,11 @@ type Querier interface {
 	//   - Secondary: display_order ASC (custom sort order)
 	//   - Tertiary: id ASC (stable fallback)
 	GetActiveOfficeLocations(ctx context.Context) ([]GetActiveOfficeLocationsRow, error)
+	// Looks up an unexpired, unrevoked invite by token hash.
+	// Parameters:
+	//   @token_hash (TEXT): hex SHA-256 of the token from the link
+	//   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+	GetActivePartnerInvite(ctx context.Context, arg GetActivePartnerInviteParams) (PartnerInvite, error)
 	// ====================================================================
 	// SOLUTIONS LISTING CTA (Singleton for Solutions Index Page)
 	// ====================================================================
@@ -1333,6 +2121,9 @@ type Querier interface {
 	// Filtering: is_active = 1 - Only the currently active CTA
 	// Note: Typically only one CTA should be active at a time
 	GetActiveSolutionsListingCTA(ctx context.Context) (SolutionsListingCtum, error)
+	// Looks up the session for a cookie token. No row means the session was
+	// revoked or never existed.
+	GetAdminSessionByToken(ctx context.Context, token string) (AdminSession, error)
 	// ====================================================================
 	// ADMIN USERS QUERIES
 	// ====================================================================
@@ -1390,6 +2181,19 @@ type Querier interface {
 	//   1. id (INTEGER): post primary key
 	// Return type: complete blog_posts row with all fields
 	GetBlogPost(ctx context.Context, id int64) (BlogPost, error)
+	// sqlc annotation: :one returns one row of the admin post list
+	// Purpose: Re-renders a list row after an inline edit on /admin/blog/posts
+	// Parameters:
+	//  1. id (INTEGER): post primary key
+	//
+	// Return type: same columns as ListBlogPostsAdminFiltered; trashed posts are not found
+	GetBlogPostListItem(ctx context.Context, id int64) (GetBlogPostListItemRow, error)
+	// sqlc annotation: :one returns single series by ID
+	// Purpose: Retrieves specific series for editing
+	// Parameters:
+	//   1. id (INTEGER): series primary key
+	// Return type: single blog_series row
+	GetBlogSeries(ctx context.Context, id int64) (BlogSeries, error)
 	// sqlc annotation: :one returns single blog tag by ID
 	// Purpose: Retrieves specific tag for editing
 	// Parameters:
@@ -1477,12 +2281,58 @@ type Querier interface {
 	// Note: Uses ORDER BY id DESC to get the latest entry (highest ID)
 	GetCompanyOverview(ctx context.Context) (CompanyOverview, error)
 	GetContactSubmissionByID(ctx context.Context, id int64) (GetContactSubmissionByIDRow, error)
+	// Returns one block (sql.ErrNoRows if it does not exist).
+	GetContentBlock(ctx context.Context, id int64) (ContentBlock, error)
+	// Returns the workflow row of one item (sql.ErrNoRows if it never entered the workflow).
+	// Parameters:
+	//  1. content_type (TEXT): 'blog_post' or 'product'
+	//  2. content_id (INTEGER): ID in the content type's table
+	GetContentWorkflow(ctx context.Context, arg GetContentWorkflowParams) (ContentWorkflow, error)
 	// sqlc annotation: :one returns single row or error
 	// Purpose: Retrieves a specific core value by ID for editing
 	// Parameters:
 	//   1. id (INTEGER): core value primary key
 	// Return type: single core_values row
 	GetCoreValue(ctx context.Context, id int64) (CoreValue, error)
+	// Looks up an account for sign-in and password resets. Matches ignoring case.
+	GetCustomerAccountByEmail(ctx context.Context, email string) (CustomerAccount, error)
+	// Returns the account signed in with a session cookie token. No row means
+	// the session ended, expired or never existed.
+	// Parameters:
+	//   @token_hash (TEXT): hex SHA-256 of the token in the bluejay_account cookie
+	//   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp; older sessions
+	//   have expired
+	GetCustomerAccountBySession(ctx context.Context, arg GetCustomerAccountBySessionParams) (CustomerAccount, error)
+	// Looks up an unexpired reset link by token hash.
+	// Parameters:
+	//   @token_hash (TEXT): hex SHA-256 of the token from the link
+	//   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+	GetCustomerPasswordReset(ctx context.Context, arg GetCustomerPasswordResetParams) (CustomerPasswordReset, error)
+	// Looks up an unexpired sign-up by confirmation token hash.
+	// Parameters:
+	//   @token_hash (TEXT): hex SHA-256 of the token from the link
+	//   @now (TEXT): current UTC "2006-01-02 15:04:05" timestamp
+	GetCustomerRegistration(ctx context.Context, arg GetCustomerRegistrationParams) (CustomerRegistration, error)
+	// Loads a page for the admin.
+	GetDocPage(ctx context.Context, id int64) (DocPage, error)
+	// Loads a doc set for the admin, published or not.
+	GetDocSet(ctx context.Context, id int64) (DocSet, error)
+	// Loads a version for the admin.
+	GetDocVersion(ctx context.Context, id int64) (DocVersion, error)
+	// Loads a version of a doc set by its label, for /docs/<set>/<version>.
+	// Parameters (2 positional): doc_set_id, version
+	GetDocVersionByLabel(ctx context.Context, arg GetDocVersionByLabelParams) (DocVersion, error)
+	// Returns the live lock on an item with the holder's display name. No row
+	// means nobody is editing it.
+	// Parameters:
+	//  1. content_type (TEXT): e.g. "product", "blog_post"
+	//  2. content_id (INTEGER): ID of the item
+	//  3. cutoff (TEXT): heartbeats before this have expired
+	GetEditLock(ctx context.Context, arg GetEditLockParams) (GetEditLockRow, error)
+	// Loads an event for the admin, published or not.
+	GetEvent(ctx context.Context, id int64) (Event, error)
+	// Returns one job (sql.ErrNoRows if it does not exist).
+	GetExportJob(ctx context.Context, id int64) (ExportJob, error)
 	// sqlc annotation: :one returns single featured blog post
 	// Purpose: Retrieves most recent published post for homepage/featured display
 	// Parameters: none
@@ -1492,6 +2342,13 @@ type Querier interface {
 	GetFeaturedPost(ctx context.Context) (GetFeaturedPostRow, error)
 	// Purpose: Retrieves specific footer column item for editing
 	GetFooterColumnItem(ctx context.Context, id int64) (FooterColumnItem, error)
+	// Returns one form (sql.ErrNoRows if it does not exist).
+	GetForm(ctx context.Context, id int64) (Form, error)
+	// Returns the form with a slug, active or not; visitors only see active
+	// forms.
+	GetFormBySlug(ctx context.Context, slug string) (Form, error)
+	// Returns one field (sql.ErrNoRows if it does not exist).
+	GetFormField(ctx context.Context, id int64) (FormField, error)
 	// Purpose: Retrieves specific hero by ID for editing
 	GetHero(ctx context.Context, id int64) (HomepageHero, error)
 	// Retrieves a single industry by its primary key ID.
@@ -1511,6 +2368,21 @@ type Querier interface {
 	// Use case: Frontend routing, displaying industry-specific content
 	// Note: Slugs should be unique (enforced by database constraint)
 	GetIndustryBySlug(ctx context.Context, slug string) (Industry, error)
+	// Loads an application for the admin.
+	GetJobApplication(ctx context.Context, id int64) (JobApplication, error)
+	// Loads a job posting for the admin, published or not.
+	GetJobPosting(ctx context.Context, id int64) (JobPosting, error)
+	// Returns one landing page (sql.ErrNoRows if it does not exist).
+	GetLandingPage(ctx context.Context, id int64) (LandingPage, error)
+	// Returns the landing page at a slug, published or not; visitors only see
+	// published pages, drafts are shown with a preview token.
+	GetLandingPageBySlug(ctx context.Context, slug string) (LandingPage, error)
+	// Returns one section (sql.ErrNoRows if it does not exist).
+	GetLandingPageSection(ctx context.Context, id int64) (LandingPageSection, error)
+	// sqlc annotation: :one returns the most recent archive of a table
+	// Purpose: Finds where the next export should resume and which chain hash to extend
+	// Returns sql.ErrNoRows when the table has never been archived
+	GetLatestArchiveRun(ctx context.Context, sourceTable string) (ArchiveRun, error)
 	// Retrieves a single media file by its primary key ID.
 	//
 	// Parameters:
@@ -1519,6 +2391,17 @@ type Querier interface {
 	//
 	// Use case: Displaying file details, embedding in content
 	GetMediaFile(ctx context.Context, id int64) (MediaFile, error)
+	// ====================================================================
+	// BULK IMPORT (content hashes and variants)
+	// ====================================================================
+	// Retrieves the media file with the given content hash, if any.
+	//
+	// Parameters:
+	//   $1 (TEXT) - content_hash: Hex SHA-256 of the file contents
+	// Returns: MediaFile - The oldest file with that content, or sql.ErrNoRows
+	//
+	// Use case: Skipping files the library already holds during an import
+	GetMediaFileByHash(ctx context.Context, contentHash string) (MediaFile, error)
 	// Retrieves a single media file by its storage file path.
 	//
 	// Parameters:
@@ -1559,6 +2442,12 @@ type Querier interface {
 	//
 	// Use case: Editing a specific menu, fetching menu details
 	GetNavigationMenu(ctx context.Context, id int64) (NavigationMenu, error)
+	// Looks up a subscriber by ID (admin actions).
+	GetNewsletterSubscriber(ctx context.Context, id int64) (NewsletterSubscriber, error)
+	// Looks up an address at sign-up. Matches ignoring case.
+	GetNewsletterSubscriberByEmail(ctx context.Context, email string) (NewsletterSubscriber, error)
+	// Looks up the address of an unsubscribe link.
+	GetNewsletterSubscriberByUnsubscribeToken(ctx context.Context, unsubscribeToken string) (NewsletterSubscriber, error)
 	// Purpose: Gets ID of submission created BEFORE current one (for "next" navigation button)
 	// Parameters:
 	//   1. current_id (INTEGER): current submission ID
@@ -1567,6 +2456,24 @@ type Querier interface {
 	GetNextSubmissionID(ctx context.Context, id int64) (int64, error)
 	GetOfficeLocationByID(ctx context.Context, id int64) (GetOfficeLocationByIDRow, error)
 	// ====================================================================
+	// ACTIVITY LOG
+	// ====================================================================
+	// sqlc annotation: :one returns the earliest created_at in activity_log
+	// Purpose: Determines the first month that needs archiving
+	GetOldestActivityLogTime(ctx context.Context) (sql.NullTime, error)
+	// ====================================================================
+	// CONTACT SUBMISSIONS
+	// ====================================================================
+	// sqlc annotation: :one returns the earliest created_at in contact_submissions
+	GetOldestContactSubmissionTime(ctx context.Context) (time.Time, error)
+	// ====================================================================
+	// WHITEPAPER DOWNLOADS
+	// ====================================================================
+	// sqlc annotation: :one returns the earliest created_at in whitepaper_downloads
+	GetOldestWhitepaperDownloadTime(ctx context.Context) (time.Time, error)
+	// Returns one page attachment (sql.ErrNoRows if it does not exist).
+	GetPageBlock(ctx context.Context, id int64) (PageBlock, error)
+	// ====================================================================
 	// PAGE SECTIONS QUERY FILE
 	// ====================================================================
 	// This file contains SQL queries for managing configurable page sections
@@ -1620,6 +2527,10 @@ type Querier interface {
 	// Use case: Partner detail view, editing partner with tier context
 	// Note: Does NOT filter by is_active, returns inactive partners for admin use
 	GetPartner(ctx context.Context, id int64) (GetPartnerRow, error)
+	// Loads an invite for the admin.
+	GetPartnerInvite(ctx context.Context, id int64) (PartnerInvite, error)
+	// Loads a submission for review.
+	GetPartnerSubmission(ctx context.Context, id int64) (PartnerSubmission, error)
 	// Retrieves a single partner tier by its primary key ID.
 	//
 	// Parameters:
@@ -1637,6 +2548,9 @@ type Querier interface {
 	// Use case: Frontend routing, filtering partners by tier via URL parameter
 	// Note: Slugs should be unique (enforced by database constraint)
 	GetPartnerTierBySlug(ctx context.Context, slug string) (PartnerTier, error)
+	// Loads the submission of a partner that awaits review, which the portal
+	// form starts from.
+	GetPendingPartnerSubmission(ctx context.Context, partnerID int64) (PartnerSubmission, error)
 	// sqlc annotation: :one returns single blog post row including drafts
 	// Purpose: Retrieves blog post for admin preview (allows viewing draft posts)
 	// Parameters:
@@ -1669,6 +2583,12 @@ type Querier interface {
 	//   - INNER JOIN ensures only tags actually linked to the post are returned
 	// ORDER BY bt.name: alphabetical tag display
 	GetPostTagsByPostID(ctx context.Context, blogPostID int64) ([]GetPostTagsByPostIDRow, error)
+	// Loads a media kit file.
+	GetPressKitAsset(ctx context.Context, id int64) (PressKitAsset, error)
+	// Loads an "In the news" link for the admin.
+	GetPressMention(ctx context.Context, id int64) (PressMention, error)
+	// Loads a press release for the admin, published or not.
+	GetPressRelease(ctx context.Context, id int64) (PressRelease, error)
 	// Purpose: Gets ID of submission created AFTER current one (for "previous" navigation button)
 	// Parameters:
 	//   1. current_id (INTEGER): current submission ID
@@ -1728,6 +2648,32 @@ type Querier interface {
 	//
 	// Use case: Fetching download metadata before serving file, tracking analytics
 	GetProductDownload(ctx context.Context, id int64) (ProductDownload, error)
+	// Counts the gated and ungated downloads that share one file. Product copies
+	// share their downloads' files, so a file may be used by both.
+	//
+	// Parameters:
+	//   $1 (TEXT) - file_path: Stored file path (/uploads/downloads/...)
+	// Returns: GetProductDownloadFileUseRow - gated and ungated download counts
+	//
+	// Use case: DownloadFiles keeps a file out of the public uploads while only
+	// gated downloads use it
+	GetProductDownloadFileUse(ctx context.Context, filePath string) (GetProductDownloadFileUseRow, error)
+	// Fetches a variant of a product by ID.
+	// Parameters:
+	//   1. id (INTEGER): variant ID
+	//   2. product_id (INTEGER): parent product
+	GetProductVariant(ctx context.Context, arg GetProductVariantParams) (ProductVariant, error)
+	// Fetches a variant of a product by SKU, for ?variant= on the product page.
+	// Parameters:
+	//   1. product_id (INTEGER): parent product
+	//   2. sku (TEXT): variant SKU
+	GetProductVariantBySKU(ctx context.Context, arg GetProductVariantBySKUParams) (ProductVariant, error)
+	// Loads a published doc set for its public pages.
+	GetPublishedDocSetBySlug(ctx context.Context, slug string) (DocSet, error)
+	// Loads a published event for its public page.
+	GetPublishedEventBySlug(ctx context.Context, slug string) (Event, error)
+	// Loads a published job posting for its public page, open or closed.
+	GetPublishedJobPostingBySlug(ctx context.Context, slug string) (JobPosting, error)
 	// sqlc annotation: :one returns single blog post row or error if not found
 	// Purpose: Retrieves full published blog post by slug for public post detail page
 	// Parameters:
@@ -1740,21 +2686,40 @@ type Querier interface {
 	//   - SEO fields (meta_title, meta_description, og_image)
 	// WHERE clause:
 	//   - bp.slug = ?: exact slug match
-	//   - bp.status = 'published' AND bp.published_at IS NOT NULL: public posts only
+	//   - bp.status = 'published' AND bp.published_at < datetime('now', '+1 second'): public posts only
 	GetPublishedPostBySlug(ctx context.Context, slug string) (GetPublishedPostBySlugRow, error)
-	// sqlc annotation: :many returns slice of related blog posts
-	// Purpose: Retrieves posts from same category for "Related Posts" widget
-	// Parameters (positional):
-	//   1. category_id (INTEGER): category to match
-	//   2. current_post_id (INTEGER): post to exclude (don't show current post as related)
-	//   3. LIMIT (INTEGER): max number of related posts (typically 3-5)
-	// Return type: slice of minimal blog post data (no body content)
-	// WHERE clause:
-	//   - bp.status = 'published' AND bp.published_at IS NOT NULL: public posts only
-	//   - bp.category_id = ?: same category as current post
-	//   - bp.id != ?: exclude current post from results
-	// ORDER BY bp.published_at DESC: newest related posts first
-	GetRelatedPosts(ctx context.Context, arg GetRelatedPostsParams) ([]GetRelatedPostsRow, error)
+	// sqlc annotation: :one returns the series of a post or sql.ErrNoRows
+	// Purpose: Finds the series a public post belongs to, for the series navigation box
+	// Parameters:
+	//   1. id (INTEGER): post ID
+	// Return type: single blog_series row
+	GetPublishedPostSeries(ctx context.Context, id int64) (BlogSeries, error)
+	// ====================================================================
+	// RECENTLY VIEWED
+	// ====================================================================
+	// Retrieves the fields of a published product needed for a small product
+	// card, with its category slug for the URL.
+	//
+	// Parameters:
+	//   $1 (INTEGER) - product ID
+	// Returns: GetPublishedProductCardRow, or sql.ErrNoRows for missing, draft,
+	// archived and trashed products
+	//
+	// Use case: The "Recently viewed" widget, whose product IDs come from a cookie
+	GetPublishedProductCard(ctx context.Context, id int64) (GetPublishedProductCardRow, error)
+	// Returns a download when its product is published, with the product's URL
+	// slugs for sending visitors back to the product page.
+	//
+	// Parameters:
+	//   $1 (INTEGER) - download ID
+	// Returns: GetPublishedProductDownloadRow, or sql.ErrNoRows for a draft,
+	// archived or trashed product
+	//
+	// Use case: The public download link, which counts the download and redirects
+	// to the file, and the lead form of gated downloads
+	GetPublishedProductDownload(ctx context.Context, id int64) (GetPublishedProductDownloadRow, error)
+	// Returns one rule (sql.ErrNoRows if it does not exist).
+	GetRedirect(ctx context.Context, id int64) (Redirect, error)
 	// Retrieves up to 3 related published whitepapers from the same topic.
 	//
 	// Parameters:
@@ -1772,6 +2737,14 @@ type Querier interface {
 	//
 	// Use case: "Related Whitepapers" section on whitepaper detail page
 	GetRelatedWhitepapers(ctx context.Context, arg GetRelatedWhitepapersParams) ([]GetRelatedWhitepapersRow, error)
+	// Loads a published press release for its public page once its release
+	// time has passed; embargoed releases are not found.
+	// Parameters:
+	//   @slug (TEXT): release slug
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	GetReleasedPressReleaseBySlug(ctx context.Context, arg GetReleasedPressReleaseBySlugParams) (PressRelease, error)
+	// Returns one override (sql.ErrNoRows if it does not exist).
+	GetSEOMeta(ctx context.Context, id int64) (SeoMetum, error)
 	// ====================================================================
 	// SETTINGS QUERY FILE
 	// ====================================================================
@@ -1796,6 +2769,10 @@ type Querier interface {
 	// Note: Always targets id=1; settings table should only contain one row
 	// Use case: Loading site configuration on application startup, template rendering
 	GetSettings(ctx context.Context) (Setting, error)
+	// Returns one link (sql.ErrNoRows if it does not exist).
+	GetShortLink(ctx context.Context, id int64) (ShortLink, error)
+	// Looks up the link served at /go/<code>, expired or not.
+	GetShortLinkByCode(ctx context.Context, code string) (ShortLink, error)
 	// Retrieves a single solution by its primary key ID (any status).
 	//
 	// Parameters:
@@ -1899,6 +2876,27 @@ type Querier interface {
 	GetTestimonial(ctx context.Context, id int64) (GetTestimonialRow, error)
 	// Purpose: Retrieves specific testimonial by ID for editing
 	GetTestimonialHomepage(ctx context.Context, id int64) (HomepageTestimonial, error)
+	// ====================================================================
+	// TRANSLATION QUERIES
+	// ====================================================================
+	// This file manages per-entity translation records used by the content
+	// localization workflow and the translation coverage dashboard.
+	//
+	// Managed entities:
+	// - translations: translated fields of one entity for one locale
+	//
+	// Key concepts:
+	// - entity_type: 'product', 'blog_post', 'solution'
+	// - fields: JSON object of translated values keyed by source column
+	// - source_hash: fingerprint of the source fields when the translation was saved
+	// - status: 'missing', 'outdated', 'complete'
+	// ====================================================================
+	// sqlc annotation: :one returns a single translation record
+	// Purpose: Loads the translation of one entity for one locale (editor + public rendering)
+	// Returns sql.ErrNoRows when the entity has never been translated into the locale
+	GetTranslation(ctx context.Context, arg GetTranslationParams) (Translation, error)
+	// Returns one endpoint (sql.ErrNoRows if it does not exist).
+	GetWebhook(ctx context.Context, id int64) (Webhook, error)
 	// Retrieves a single whitepaper by its primary key ID (any status).
 	//
 	// Parameters:
@@ -1961,6 +2959,16 @@ type Querier interface {
 	// Use case: Frontend topic page routing, filtering whitepapers by topic URL
 	// Note: Slugs should be unique (enforced by database constraint)
 	GetWhitepaperTopicBySlug(ctx context.Context, slug string) (WhitepaperTopic, error)
+	// Overwrites every importable setting in one statement (settings JSON import).
+	//
+	// Parameters: one per column of the settings row, except id, the timestamps
+	// and mt_api_key (secrets are not part of an export)
+	// Returns: (none)
+	//
+	// Use case: Applying a validated settings import (see services.ParseSettingsImport)
+	// Note: Keep in step with the settings table; a column missing here makes the
+	// import fail instead of silently dropping the value
+	ImportSettings(ctx context.Context, arg ImportSettingsParams) error
 	// Increments the download counter for analytics tracking.
 	//
 	// Parameters:
@@ -1979,6 +2987,28 @@ type Querier interface {
 	// Use case: Tracking download popularity metrics
 	// Note: Uses download_count + 1 for atomic increment without race conditions
 	IncrementWhitepaperDownloadCount(ctx context.Context, id int64) error
+	// Records one page view.
+	// Parameters:
+	//  1. path (TEXT): page path, without the query string
+	//  2. referrer (TEXT): referring site's host, empty for direct and internal
+	//  3. ua_class (TEXT): desktop, mobile or tablet
+	//  4. country (TEXT): ISO 3166-1 alpha-2 code, empty when unknown
+	InsertPageView(ctx context.Context, arg InsertPageViewParams) error
+	// Returns the ID of the item a public detail page or landing page path
+	// refers to, whatever its status, or 0 when there is none. Trashed items
+	// count as missing.
+	// Parameters (named):
+	//  1. content_type (TEXT): 'product', 'blog_post', 'solution', 'case_study', 'whitepaper' or 'landing_page'
+	//  2. slug (TEXT): slug from the link
+	LinkTargetID(ctx context.Context, arg LinkTargetIDParams) (int64, error)
+	// Reports whether a public detail page exists for a slug, i.e. whether a
+	// link to it from published content works.
+	// Parameters (named):
+	//  1. content_type (TEXT): 'product', 'blog_post', 'solution', 'case_study' or 'whitepaper'
+	//  2. slug (TEXT): slug from the link
+	LinkTargetPublished(ctx context.Context, arg LinkTargetPublishedParams) (int64, error)
+	// Lists the active forms by name, for the landing page form section.
+	ListActiveForms(ctx context.Context) ([]ListActiveFormsRow, error)
 	// Purpose: Returns the active heroes that make up the public homepage carousel.
 	// Every active hero becomes a rotating slide (image + message + CTAs), ordered by
 	// display_order. The limit is the homepage_max_heroes setting, capping how many
@@ -1986,6 +3016,17 @@ type Querier interface {
 	// Parameters:
 	//   1. limit (INTEGER): maximum number of slides (homepage_max_heroes)
 	ListActiveHeroes(ctx context.Context, limit int64) ([]HomepageHero, error)
+	// Retrieves the visible items of every active menu, for rendering on the public site.
+	//
+	// Parameters: none
+	// Returns: []ListActiveNavigationItemsRow - Items with their menu's location, in display order
+	//
+	// Filtering: active menus (navigation_menus.is_active = 1) and visible items (is_active = 1)
+	// Note: Children of hidden items are returned too; application drops them when building the hierarchy
+	ListActiveNavigationItems(ctx context.Context) ([]ListActiveNavigationItemsRow, error)
+	// Lists the active blocks of every page in display order, grouped by path,
+	// for the ContentBlocks service.
+	ListActivePageBlocks(ctx context.Context) ([]ListActivePageBlocksRow, error)
 	// ====================================================================
 	// HOMEPAGE STATS / METRICS
 	// ====================================================================
@@ -2018,20 +3059,43 @@ type Querier interface {
 	// WHERE: is_active = 1 (allows managing testimonial rotation)
 	// ORDER BY display_order: custom sequence for carousel slides
 	ListActiveTestimonialsHomepage(ctx context.Context) ([]HomepageTestimonial, error)
-	// sqlc annotation: :many returns slice of activity_log rows
-	// Purpose: Retrieves paginated, filtered activity log entries for admin dashboard
+	// Lists the endpoints that receive events, oldest first.
+	ListActiveWebhooks(ctx context.Context) ([]Webhook, error)
+	// sqlc annotation: :many returns distinct action names
+	// Purpose: Populates the action filter with every action that has been logged
+	ListActivityActions(ctx context.Context) ([]string, error)
+	// sqlc annotation: :many returns slice of activity rows with the acting user
+	// Purpose: Retrieves paginated, filtered activity log entries for the audit
+	//          log viewer and its CSV export
 	// Parameters (named parameters using @ prefix):
+	//   @filter_user (INTEGER): admin user ID (0 = all users)
+	//   @filter_resource_type (TEXT): entity type, e.g. "product" (empty = all)
 	//   @filter_action (TEXT): filter by action type (empty string = no filter)
-	//   @filter_search (TEXT): search term for description field (empty = no filter)
+	//   @filter_search (TEXT): search term for description/title (empty = no filter)
+	//   @filter_from (TEXT): first day to include, YYYY-MM-DD (empty = no lower bound)
+	//   @filter_to (TEXT): last day to include, YYYY-MM-DD (empty = no upper bound)
 	//   @page_limit (INTEGER): number of results per page
 	//   @page_offset (INTEGER): pagination offset (page_number * page_limit)
-	// Return type: slice of activity_log rows
+	// Return type: slice of activity_log rows plus user display name and email
 	// Complex WHERE logic:
-	//   - CAST to TEXT handles nullable/empty filter parameters safely
-	//   - Empty string check allows "no filter" without complex null handling
+	//   - CAST to TEXT/INTEGER handles empty filter parameters safely
+	//   - Empty string / 0 check allows "no filter" without complex null handling
 	//   - LIKE with wildcards enables partial text search in descriptions
+	//   - created_at is CURRENT_TIMESTAMP text (UTC), so day bounds compare as
+	//     strings; the upper bound is exclusive at midnight after @filter_to
+	// LEFT JOIN admin_users: entries of deleted users are still listed
 	// Note: Always ordered by created_at DESC to show newest actions first
-	ListActivityLogs(ctx context.Context, arg ListActivityLogsParams) ([]ActivityLog, error)
+	ListActivityLogs(ctx context.Context, arg ListActivityLogsParams) ([]ListActivityLogsRow, error)
+	// sqlc annotation: :many returns every activity_log row in a period
+	// Parameters:
+	//   @period_start (TEXT): inclusive lower bound
+	//   @period_end (TEXT): exclusive upper bound
+	ListActivityLogsForArchive(ctx context.Context, arg ListActivityLogsForArchiveParams) ([]ActivityLog, error)
+	// sqlc annotation: :many returns distinct entity types
+	// Purpose: Populates the entity type filter with every type that has been logged
+	ListActivityResourceTypes(ctx context.Context) ([]string, error)
+	// Lists a user's sessions, most recently active first.
+	ListAdminSessionsByUser(ctx context.Context, userID int64) ([]AdminSession, error)
 	// sqlc annotation: :many returns slice of admin_users rows
 	// Purpose: Lists all admin users for management dashboard
 	// Parameters: none
@@ -2126,6 +3190,20 @@ type Querier interface {
 	// Use case: Admin product management dashboard
 	// Note: No status filtering - shows published, draft, and archived products
 	ListAllProductsAdmin(ctx context.Context) ([]Product, error)
+	// Retrieves every published product in a category and its subcategories,
+	// in the requested sort order.
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Root of the category subtree
+	//   @sort (TEXT) - "name_asc", "name_desc", "newest", "oldest" or "sku"
+	// Returns: []ListAllProductsInCategoryTreeRow - Products with category_slug
+	//
+	// Sorting: One CASE per sort option; only the matching one yields values,
+	// the others are constant and do not affect the order. The id makes the
+	// order total, so pages never overlap.
+	//
+	// Use case: Faceted category pages, which filter in memory and then paginate
+	ListAllProductsInCategoryTree(ctx context.Context, arg ListAllProductsInCategoryTreeParams) ([]ListAllProductsInCategoryTreeRow, error)
 	// Retrieves all solution page features (active and inactive) for admin.
 	//
 	// Parameters: none
@@ -2165,6 +3243,18 @@ type Querier interface {
 	// Note: Returns ALL whitepapers regardless of is_published status
 	// Note: Selects subset of columns optimized for admin listing (not full content)
 	ListAllWhitepapers(ctx context.Context) ([]ListAllWhitepapersRow, error)
+	// sqlc annotation: :many returns a table's archives in chain order
+	// Purpose: Walks the hash chain from the first archive to the latest for verification
+	ListArchiveRunsByTable(ctx context.Context, sourceTable string) ([]ArchiveRun, error)
+	// sqlc annotation: :many returns one publish date per published post
+	// Purpose: Builds the month/year archive widget and archive sitemap entries
+	// Parameters: none
+	// Return type: slice of published_at values
+	// Notes:
+	//   - published_at is stored in UTC; the handler buckets the dates into
+	//     months of the site timezone, which SQLite cannot convert to
+	// ORDER BY published_at DESC: newest first
+	ListBlogArchiveDates(ctx context.Context) ([]sql.NullTime, error)
 	// ====================================================================
 	// BLOG AUTHORS QUERIES
 	// ====================================================================
@@ -2204,6 +3294,7 @@ type Querier interface {
 	//   - Primary sort: sort_order ASC (custom display order)
 	//   - Secondary sort: name ASC (alphabetical fallback)
 	ListBlogCategories(ctx context.Context) ([]BlogCategory, error)
+	ListBlogPostTranslationSources(ctx context.Context) ([]ListBlogPostTranslationSourcesRow, error)
 	// sqlc annotation: :many returns filtered/paginated blog posts for admin table
 	// Purpose: Advanced admin list with multiple filter options and pagination
 	// Parameters (named using @ prefix):
@@ -2221,6 +3312,44 @@ type Querier interface {
 	//   - filter_category/filter_author use 0 as "no filter" sentinel value
 	//   - filter_search uses LIKE for partial matching in title OR slug
 	ListBlogPostsAdminFiltered(ctx context.Context, arg ListBlogPostsAdminFilteredParams) ([]ListBlogPostsAdminFilteredRow, error)
+	// Lists the published posts whose publish date was set ahead of their last
+	// save and fell in (@after, @until], for the scheduled-publishing job. Posts
+	// published by the save itself (publish date within a minute of it) are
+	// left out; the save already announced them.
+	// Parameters:
+	//   @after (TEXT): UTC "2006-01-02 15:04:05" end of the previous run
+	//   @until (TEXT): UTC "2006-01-02 15:04:05" time of this run
+	ListBlogPostsGoingLive(ctx context.Context, arg ListBlogPostsGoingLiveParams) ([]ListBlogPostsGoingLiveRow, error)
+	// ====================================================================
+	// BLOG SERIES QUERIES
+	// ====================================================================
+	// This file manages blog series: named, ordered groups of posts used for
+	// multi-part articles (e.g., "Radiation Safety 101, Part 1 of 4").
+	//
+	// Managed entity:
+	// - blog_series: series definitions (name, slug, description)
+	//
+	// Note: Membership lives on blog_posts (series_id, series_order); a post
+	//       belongs to at most one series
+	// ====================================================================
+	// sqlc annotation: :many returns slice of series with post counts
+	// Purpose: Lists all series for the admin series list
+	// Parameters: none
+	// Return type: series columns plus post_count (all statuses)
+	// LEFT JOIN: series without posts are included with post_count = 0
+	ListBlogSeries(ctx context.Context) ([]ListBlogSeriesRow, error)
+	// sqlc annotation: :many returns one row per tag used by a published post
+	// Purpose: Builds the public tag cloud (/blog/tags) and tag sitemap entries
+	// Parameters: none
+	// Return type: slice of (id, name, slug, post_count) rows
+	// Notes:
+	//   - Only published, non-deleted posts are counted, so tags used only by
+	//     drafts or trashed posts are left out of the cloud
+	//   - post_count drives the font size of each tag in the cloud
+	// ORDER BY name: alphabetical, the usual tag cloud layout
+	ListBlogTagCloud(ctx context.Context) ([]ListBlogTagCloudRow, error)
+	// Lists the broken links of the last run, by content type and item.
+	ListBrokenLinks(ctx context.Context) ([]BrokenLink, error)
 	// ====================================================================
 	// CASE STUDIES QUERIES
 	// ====================================================================
@@ -2262,6 +3391,22 @@ type Querier interface {
 	//   - is_published = 1: published only
 	//   - industry_id = ?: filter to specific industry
 	ListCaseStudiesByIndustry(ctx context.Context, industryID int64) ([]ListCaseStudiesByIndustryRow, error)
+	// Retrieves the certifications of published products in a category subtree
+	// for the certification facet.
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Root of the category subtree
+	// Returns: []ListCategoryTreeCertificationFacetsRow - product_id and certification name
+	ListCategoryTreeCertificationFacets(ctx context.Context, categoryID int64) ([]ListCategoryTreeCertificationFacetsRow, error)
+	// Retrieves the spec values of published products in a category subtree,
+	// from which the filter sidebar's spec facets are built.
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Root of the category subtree
+	// Returns: []ListCategoryTreeSpecFacetsRow - product_id, section, key and value
+	//
+	// Sorting: By section and key in the order they appear on products, then value
+	ListCategoryTreeSpecFacets(ctx context.Context, categoryID int64) ([]ListCategoryTreeSpecFacetsRow, error)
 	// ====================================================================
 	// CERTIFICATIONS & CREDENTIALS
 	// ====================================================================
@@ -2295,6 +3440,18 @@ type Querier interface {
 	ListContactSubmissionsByStatus(ctx context.Context, arg ListContactSubmissionsByStatusParams) ([]ListContactSubmissionsByStatusRow, error)
 	ListContactSubmissionsByStatusAndType(ctx context.Context, arg ListContactSubmissionsByStatusAndTypeParams) ([]ListContactSubmissionsByStatusAndTypeRow, error)
 	ListContactSubmissionsByType(ctx context.Context, arg ListContactSubmissionsByTypeParams) ([]ListContactSubmissionsByTypeRow, error)
+	// sqlc annotation: :many returns every contact submission in a period
+	// Parameters:
+	//   @period_start (TEXT): inclusive lower bound
+	//   @period_end (TEXT): exclusive upper bound
+	ListContactSubmissionsForArchive(ctx context.Context, arg ListContactSubmissionsForArchiveParams) ([]ContactSubmission, error)
+	// Lists every block by name with the number of pages it is attached to.
+	ListContentBlocks(ctx context.Context) ([]ListContentBlocksRow, error)
+	// Lists the transitions of one item, newest first, with the user's name.
+	// Parameters:
+	//  1. content_type (TEXT): 'blog_post' or 'product'
+	//  2. content_id (INTEGER): ID in the content type's table
+	ListContentWorkflowHistory(ctx context.Context, arg ListContentWorkflowHistoryParams) ([]ListContentWorkflowHistoryRow, error)
 	// ====================================================================
 	// CORE VALUES
 	// ====================================================================
@@ -2304,6 +3461,44 @@ type Querier interface {
 	// Return type: slice of core_values rows
 	// Note: ORDER BY display_order ensures consistent presentation order
 	ListCoreValues(ctx context.Context) ([]CoreValue, error)
+	// Lists accounts for the admin Customers page, newest first, with the
+	// number of items in each library.
+	ListCustomerAccounts(ctx context.Context) ([]ListCustomerAccountsRow, error)
+	// Lists the product files in a customer's library whose product is still
+	// published, most recently downloaded first.
+	ListCustomerLibraryProductDownloads(ctx context.Context, accountID int64) ([]ListCustomerLibraryProductDownloadsRow, error)
+	// Lists the published whitepapers in a customer's library, most recently
+	// downloaded first.
+	ListCustomerLibraryWhitepapers(ctx context.Context, accountID int64) ([]ListCustomerLibraryWhitepapersRow, error)
+	// Lists every page of a version, published or not, in sibling order.
+	ListDocPages(ctx context.Context, docVersionID int64) ([]DocPage, error)
+	// Lists every doc set for the admin Docs page with its product, current
+	// version and number of versions. A trashed product is left blank.
+	ListDocSetsAdmin(ctx context.Context) ([]ListDocSetsAdminRow, error)
+	// Lists the published pages of the current versions of published doc
+	// sets for the sitemap. Older versions are left out so search engines
+	// index the current manual.
+	ListDocSitemapPages(ctx context.Context) ([]ListDocSitemapPagesRow, error)
+	// Lists the versions of a doc set with their page counts, newest first.
+	ListDocVersions(ctx context.Context, docSetID int64) ([]ListDocVersionsRow, error)
+	// Lists the media files uploaded from an editor before cutoff, oldest first.
+	// Parameters:
+	//   1. cutoff (TEXT): "2006-01-02 15:04:05" UTC
+	ListEditorAttachmentsBefore(ctx context.Context, cutoff string) ([]MediaFile, error)
+	// Lists the registrations of an event for the admin, newest first.
+	ListEventRegistrations(ctx context.Context, eventID int64) ([]EventRegistration, error)
+	// Lists every event for the admin Events page with its registration
+	// count, latest start first.
+	ListEventsAdmin(ctx context.Context) ([]ListEventsAdminRow, error)
+	// Lists done jobs whose download link expired before the cutoff.
+	// Parameters:
+	//   @cutoff (TEXT): UTC "2006-01-02 15:04:05" timestamp, typically now
+	ListExpiredExportJobs(ctx context.Context, cutoff string) ([]ExportJob, error)
+	// Lists recent jobs, newest first.
+	// Parameters:
+	//   @filter_user (INTEGER): requesting user ID (0 = all users)
+	//   @page_limit (INTEGER): maximum rows
+	ListExportJobs(ctx context.Context, arg ListExportJobsParams) ([]ExportJob, error)
 	// Retrieves a limited number of featured active partners.
 	//
 	// Parameters:
@@ -2361,6 +3556,25 @@ type Querier interface {
 	//   1. column_item_id (INTEGER): parent column item
 	// Use case: Getting links for a "Quick Links" or "Resources" column block
 	ListFooterLinks(ctx context.Context, columnItemID int64) ([]FooterLink, error)
+	// Lists a form's fields in display order.
+	ListFormFields(ctx context.Context, formID int64) ([]FormField, error)
+	// Lists one page of a form's submissions, newest first.
+	// Parameters (named):
+	//  1. form_id (INTEGER): form ID
+	//  2. limit (INTEGER): page size
+	//  3. offset (INTEGER): submissions to skip
+	ListFormSubmissions(ctx context.Context, arg ListFormSubmissionsParams) ([]FormSubmission, error)
+	// Lists every form by name with its number of fields and submissions.
+	ListForms(ctx context.Context) ([]ListFormsRow, error)
+	// Lists the files of gated downloads, for moving them out of the public
+	// uploads at startup.
+	//
+	// Returns: []string - Distinct file paths
+	ListGatedProductDownloadFiles(ctx context.Context) ([]string, error)
+	// Lists images without alt text: product gallery images, hero backgrounds,
+	// testimonial photos and library images. owner_* is the item the image
+	// belongs to (the media file itself for library images).
+	ListImagesMissingAlt(ctx context.Context) ([]ListImagesMissingAltRow, error)
 	// ====================================================================
 	// INDUSTRIES QUERY FILE
 	// ====================================================================
@@ -2382,6 +3596,27 @@ type Querier interface {
 	//
 	// Use case: Display industries in navigation, filters, or admin listing
 	ListIndustries(ctx context.Context) ([]Industry, error)
+	// Lists the CV storage keys of a job's applications, to delete the files
+	// with the posting.
+	ListJobApplicationCVKeys(ctx context.Context, jobID int64) ([]string, error)
+	// Lists applications for the admin application inbox with their job,
+	// newest first.
+	// Parameters:
+	//   @filter_status (TEXT): application status (empty = all)
+	//   @filter_job_id (INTEGER): job posting ID (0 = all)
+	//   @page_limit (INTEGER): rows per page
+	//   @page_offset (INTEGER): rows to skip
+	ListJobApplications(ctx context.Context, arg ListJobApplicationsParams) ([]ListJobApplicationsRow, error)
+	// Lists every job posting for the admin Careers page with its
+	// application counts, newest first.
+	ListJobPostingsAdmin(ctx context.Context) ([]ListJobPostingsAdminRow, error)
+	// Lists the last run of every job that has run.
+	ListJobRuns(ctx context.Context) ([]JobRun, error)
+	// Lists a landing page's sections in display order.
+	ListLandingPageSections(ctx context.Context, landingPageID int64) ([]LandingPageSection, error)
+	// Lists every landing page, most recently edited first, with its number
+	// of sections.
+	ListLandingPages(ctx context.Context) ([]ListLandingPagesRow, error)
 	// ====================================================================
 	// UTILITY QUERIES
 	// ====================================================================
@@ -2394,6 +3629,52 @@ type Querier interface {
 	//       Used for "Recent Posts" widgets with fixed count
 	ListLatestPublishedPosts(ctx context.Context, limit int64) ([]ListLatestPublishedPostsRow, error)
 	// ====================================================================
+	// LEAD DEDUPLICATION QUERIES
+	// ====================================================================
+	// This file feeds the admin Leads view, which links submissions from the
+	// same person across the contact form, RFQs, and whitepaper downloads.
+	//
+	// Managed entities:
+	// - lead_merges: manual links from one normalized email to another
+	//
+	// Key concepts:
+	// - Grouping and normalization (lowercase, plus-address stripping, company
+	//   canonicalization) happen in services.LeadService, not in SQL
+	// - email / primary_email in lead_merges are always normalized addresses
+	// ====================================================================
+	// sqlc annotation: :many returns every contact and RFQ submission
+	// Purpose: Source rows for lead grouping (contact form and RFQ)
+	// Return type: Submission summary without message body, newest first
+	ListLeadContactSubmissions(ctx context.Context) ([]ListLeadContactSubmissionsRow, error)
+	// sqlc annotation: :many returns every event registration
+	// Purpose: Source rows for lead grouping (event and webinar registrations)
+	// Return type: Registration summary with event title, newest first
+	ListLeadEventRegistrations(ctx context.Context) ([]ListLeadEventRegistrationsRow, error)
+	// sqlc annotation: :many returns all manual merges
+	// Purpose: Resolve merged addresses to their primary address
+	ListLeadMerges(ctx context.Context) ([]LeadMerge, error)
+	// sqlc annotation: :many returns every gated product download lead
+	// Purpose: Source rows for lead grouping (gated product downloads)
+	// Return type: Lead summary with file title and product, newest first
+	ListLeadProductDownloads(ctx context.Context) ([]ListLeadProductDownloadsRow, error)
+	// Purpose: When each lead submission since a moment arrived, for the
+	// dashboard's lead trend (bucketed into days in the site timezone)
+	// Parameter: since (TEXT), a CURRENT_TIMESTAMP-format UTC time
+	// Return type: created_at as text, one row per submission
+	ListLeadTimesSince(ctx context.Context, since string) ([]string, error)
+	// sqlc annotation: :many returns every whitepaper download with its title
+	// Purpose: Source rows for lead grouping (gated whitepaper downloads)
+	// Return type: Download summary with whitepaper title, newest first
+	ListLeadWhitepaperDownloads(ctx context.Context) ([]ListLeadWhitepaperDownloadsRow, error)
+	// Lists every non-empty call-to-action URL: homepage heroes and CTAs,
+	// solution CTAs, page sections, the header button, content blocks and
+	// landing page sections. parent_id is the landing page of a landing page
+	// section and 0 otherwise.
+	ListLinkSources(ctx context.Context) ([]ListLinkSourcesRow, error)
+	// sqlc annotation: :many returns every locale, enabled or not
+	// Purpose: Loads the locale list for the translation service and the admin page
+	ListLocales(ctx context.Context) ([]Locale, error)
+	// ====================================================================
 	// MEDIA FILES QUERY FILE
 	// ====================================================================
 	// This file contains all SQL queries for managing uploaded media files
@@ -2443,6 +3724,17 @@ type Querier interface {
 	// Sorting: created_at ASC - Oldest files appear first
 	// Use case: Identifying old/unused files for archival or cleanup
 	ListMediaFilesOldest(ctx context.Context, arg ListMediaFilesOldestParams) ([]MediaFile, error)
+	// Retrieves media files uploaded before content hashing, so an import can
+	// hash them and detect duplicates of legacy uploads.
+	//
+	// Returns: []MediaFile
+	ListMediaFilesWithoutHash(ctx context.Context) ([]MediaFile, error)
+	// Retrieves the variants of a media file, smallest first.
+	//
+	// Parameters:
+	//   $1 (INTEGER) - media_file_id
+	// Returns: []MediaVariant
+	ListMediaVariants(ctx context.Context, mediaFileID int64) ([]MediaVariant, error)
 	// ====================================================================
 	// MILESTONES / COMPANY TIMELINE
 	// ====================================================================
@@ -2490,6 +3782,35 @@ type Querier interface {
 	//
 	// Use case: Admin panel menu management, displaying available menus
 	ListNavigationMenus(ctx context.Context) ([]NavigationMenu, error)
+	// Lists subscribers for the admin Subscribers page and the CSV export,
+	// newest first.
+	// Parameters:
+	//   @filter_status (TEXT): pending, confirmed or unsubscribed (empty = all)
+	//   @filter_search (TEXT): part of the address (empty = all)
+	//   @page_limit (INTEGER): rows per page
+	//   @page_offset (INTEGER): rows to skip
+	ListNewsletterSubscribers(ctx context.Context, arg ListNewsletterSubscribersParams) ([]NewsletterSubscriber, error)
+	// Lists missing paths, most requested first, with their total hits, the
+	// number of distinct referring pages and the last time one was requested.
+	// last_seen is text: MAX() loses the DATETIME column type.
+	ListNotFoundPaths(ctx context.Context, limit int64) ([]ListNotFoundPathsRow, error)
+	// Lists the pages that linked to missing paths, most hits first.
+	ListNotFoundReferrers(ctx context.Context) ([]ListNotFoundReferrersRow, error)
+	// Lists the open job postings for the sitemap. Closed postings are left
+	// out so search engines drop them.
+	// Parameters:
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	ListOpenJobPostingSlugs(ctx context.Context, now string) ([]ListOpenJobPostingSlugsRow, error)
+	// Lists the open job postings for /careers by department and title.
+	// Parameters:
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	ListOpenJobPostings(ctx context.Context, now string) ([]JobPosting, error)
+	// Lists the page paths that have blocks attached, with their block count.
+	ListPageBlockRoutes(ctx context.Context) ([]ListPageBlockRoutesRow, error)
+	// Lists the blocks attached to a page path in display order, with the
+	// name, type and status of each block, for the page editor.
+	ListPageBlocks(ctx context.Context, route string) ([]ListPageBlocksRow, error)
+	ListPageSectionTranslationSources(ctx context.Context) ([]ListPageSectionTranslationSourcesRow, error)
 	// Retrieves all active sections for a specific page in display order.
 	//
 	// Parameters:
@@ -2501,6 +3822,13 @@ type Querier interface {
 	//
 	// Use case: Rendering all sections for a page, building page content dynamically
 	ListPageSections(ctx context.Context, pageKey string) ([]PageSection, error)
+	// Lists the invites of a partner, newest first, for its portal page.
+	ListPartnerInvites(ctx context.Context, partnerID int64) ([]PartnerInvite, error)
+	// Lists submissions for the admin queue and a partner's portal page.
+	// Parameters:
+	//   @filter_status (TEXT): status to show, '' for all
+	//   @filter_partner_id (INTEGER): partner to show, 0 for all
+	ListPartnerSubmissions(ctx context.Context, arg ListPartnerSubmissionsParams) ([]ListPartnerSubmissionsRow, error)
 	// ====================================================================
 	// PARTNER TIERS QUERY FILE
 	// ====================================================================
@@ -2581,6 +3909,30 @@ type Querier interface {
 	//
 	// Use case: Displaying partners filtered by tier (e.g., "Show all Platinum Partners")
 	ListPartnersByTierID(ctx context.Context, tierID int64) ([]Partner, error)
+	// Lists published events that have ended, most recent first.
+	// Parameters:
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	//   @page_limit (INTEGER): how many to list
+	ListPastEvents(ctx context.Context, arg ListPastEventsParams) ([]Event, error)
+	// sqlc annotation: :many returns slice of member posts
+	// Purpose: Lists every post in a series (any status) for the admin series editor
+	// Parameters:
+	//   1. series_id (INTEGER): series to list
+	// Return type: minimal post data with part number
+	// ORDER BY series_order, id: part order with a stable tiebreak
+	ListPostsInSeries(ctx context.Context, seriesID sql.NullInt64) ([]ListPostsInSeriesRow, error)
+	// sqlc annotation: :many returns slice of unassigned posts
+	// Purpose: Options for the "add post to series" picker in the admin series editor
+	// Parameters: none
+	// Return type: id and title of posts not in any series
+	ListPostsWithoutSeries(ctx context.Context) ([]ListPostsWithoutSeriesRow, error)
+	// Lists the media kit in display order, for /press and the admin.
+	ListPressKitAssets(ctx context.Context) ([]PressKitAsset, error)
+	// Lists every "In the news" link for the admin, newest first.
+	ListPressMentionsAdmin(ctx context.Context) ([]PressMention, error)
+	// Lists every press release for the admin Press page, latest release
+	// first.
+	ListPressReleasesAdmin(ctx context.Context) ([]PressRelease, error)
 	// ====================================================================
 	// PRODUCT CATEGORIES QUERY FILE
 	// ====================================================================
@@ -2607,6 +3959,18 @@ type Querier interface {
 	//
 	// Use case: Category navigation menu, product filters, admin category listing
 	ListProductCategories(ctx context.Context) ([]ProductCategory, error)
+	// Retrieves all product categories, in ListProductCategories order, each with
+	// the live count of its published products.
+	//
+	// Parameters: none
+	// Returns: []ListProductCategoriesWithCountsRow - Category record and
+	//   published_count (products directly in the category, not its children)
+	//
+	// Note: One grouped LEFT JOIN instead of CountProductsByCategory per category;
+	//   categories without products get 0. The stored product_count column is
+	//   not maintained and is not used for this.
+	// Use case: Public product catalog category tree, footer category links
+	ListProductCategoriesWithCounts(ctx context.Context) ([]ListProductCategoriesWithCountsRow, error)
 	// Retrieves all certifications for a product in display order.
 	//
 	// Parameters:
@@ -2616,13 +3980,15 @@ type Querier interface {
 	// Sorting: display_order ASC - Certifications appear in admin-configured order
 	// Use case: Displaying compliance badges on product detail page
 	ListProductCertifications(ctx context.Context, productID int64) ([]ProductCertification, error)
+	// Lists the public doc sets of a product for its detail page.
+	ListProductDocSets(ctx context.Context, productID sql.NullInt64) ([]ListProductDocSetsRow, error)
 	// Retrieves all downloadable files for a product in display order.
 	//
 	// Parameters:
 	//   $1 (INTEGER) - product_id: Product to fetch downloads for
 	// Returns: []ProductDownload - Array of downloads ordered by display_order
 	//
-	// Sorting: display_order ASC - Downloads appear in admin-configured order
+	// Sorting: display_order ASC, id ASC - Downloads appear in admin-configured order, then as added
 	// Use case: Displaying downloads list on product detail page
 	ListProductDownloads(ctx context.Context, productID int64) ([]ProductDownload, error)
 	// Retrieves all features for a product in display order.
@@ -2634,6 +4000,13 @@ type Querier interface {
 	// Sorting: display_order ASC - Features appear in admin-configured order
 	// Use case: Displaying key features list on product detail page
 	ListProductFeatures(ctx context.Context, productID int64) ([]ProductFeature, error)
+	// Returns the features of the products in an ID range (one export batch).
+	//
+	// Parameters:
+	//   @first_id (INTEGER) - First product ID of the batch
+	//   @last_id (INTEGER) - Last product ID of the batch
+	// Returns: []ListProductFeaturesForExportRow
+	ListProductFeaturesForExport(ctx context.Context, arg ListProductFeaturesForExportParams) ([]ListProductFeaturesForExportRow, error)
 	// Retrieves all gallery images for a product in display order.
 	//
 	// Parameters:
@@ -2643,6 +4016,11 @@ type Querier interface {
 	// Sorting: display_order ASC - Images appear in admin-configured order
 	// Use case: Rendering product image gallery, lightbox, thumbnails
 	ListProductImages(ctx context.Context, productID int64) ([]ProductImage, error)
+	// Returns every distinct spec (section + label) used by a product, one
+	// export column each.
+	//
+	// Returns: []ListProductSpecColumnsForExportRow - Sorted by section, then label
+	ListProductSpecColumnsForExport(ctx context.Context) ([]ListProductSpecColumnsForExportRow, error)
 	// Retrieves all technical specifications for a product in display order.
 	//
 	// Parameters:
@@ -2653,6 +4031,43 @@ type Querier interface {
 	// Use case: Displaying specs table on product detail page
 	// Note: Application code should group by section_name for organized display
 	ListProductSpecs(ctx context.Context, productID int64) ([]ProductSpec, error)
+	// Returns the specs of the products in an ID range (one export batch).
+	//
+	// Parameters:
+	//   @first_id (INTEGER) - First product ID of the batch
+	//   @last_id (INTEGER) - Last product ID of the batch
+	// Returns: []ListProductSpecsForExportRow
+	ListProductSpecsForExport(ctx context.Context, arg ListProductSpecsForExportParams) ([]ListProductSpecsForExportRow, error)
+	// ====================================================================
+	// TRANSLATION SOURCES
+	// ====================================================================
+	// Translatable fields of each supported entity type, used to fingerprint
+	// source content and to show the original text next to the translation.
+	ListProductTranslationSources(ctx context.Context) ([]ListProductTranslationSourcesRow, error)
+	// Lists a variant's gallery images in display order.
+	ListProductVariantImages(ctx context.Context, variantID int64) ([]ProductVariantImage, error)
+	// Lists a variant's spec overrides in display order.
+	ListProductVariantSpecs(ctx context.Context, variantID int64) ([]ProductVariantSpec, error)
+	// ====================================================================
+	// PRODUCT VARIANTS QUERIES
+	// ====================================================================
+	// Variants are versions of a product (voltages, sizes) with their own SKU
+	// shown on the product's page through a variant selector.
+	//
+	// Managed entities:
+	// - product_variants: One row per variant, SKU unique across variants
+	// - product_variant_specs: Spec overrides, matched to product specs by
+	//   section_name + spec_key
+	// - product_variant_images: Variant gallery, replacing the product gallery
+	//
+	// Security notes:
+	// - Variant queries taking a product_id are scoped to it so a variant can
+	//   only be edited through the product it belongs to
+	// ====================================================================
+	// Lists a product's variants in selector order.
+	// Parameters:
+	//   1. product_id (INTEGER): parent product
+	ListProductVariants(ctx context.Context, productID int64) ([]ProductVariant, error)
 	// Retrieves paginated published products with featured products first.
 	//
 	// Parameters:
@@ -2708,6 +4123,27 @@ type Querier interface {
 	// Use case: Category-specific product listing pages
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]Product, error)
 	// ====================================================================
+	// PRODUCT EXPORT
+	// ====================================================================
+	// The admin export pages through products by ID so memory stays flat
+	// however many products there are. Trashed products are left out.
+	// Returns the next batch of products after a given ID, with their category.
+	//
+	// Parameters:
+	//   @after_id (INTEGER) - Last product ID of the previous batch (0 to start)
+	//   @batch_size (INTEGER) - Maximum products to return
+	// Returns: []ListProductsForExportRow - Products in ID order with category_slug
+	ListProductsForExport(ctx context.Context, arg ListProductsForExportParams) ([]ListProductsForExportRow, error)
+	// Retrieves every published product with its category for the A–Z index.
+	//
+	// Returns: []ListProductsForIndexRow - name, slug, sku, tagline, category name and slug
+	//
+	// Sorting: name COLLATE NOCASE so "acme" and "Acme" file under the same letter;
+	// id breaks ties between products with the same name
+	//
+	// Use case: /products/a-z index page (grouped by first letter in Go)
+	ListProductsForIndex(ctx context.Context) ([]ListProductsForIndexRow, error)
+	// ====================================================================
 	// PRODUCTS - PUBLIC LISTING QUERIES
 	// ====================================================================
 	// Retrieves all published products with category slug for sitemap generation.
@@ -2716,6 +4152,30 @@ type Querier interface {
 	//
 	// Use case: Generating sitemap.xml with product detail page URLs
 	ListProductsForSitemap(ctx context.Context) ([]ListProductsForSitemapRow, error)
+	// Retrieves paginated published products in a category and all of its
+	// subcategories, with each product's own category slug for building URLs.
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Root of the category subtree
+	//   @limit (INTEGER) - Number of products per page
+	//   @offset (INTEGER) - Pagination offset
+	// Returns: []ListProductsInCategoryTreeRow - Products with category_slug
+	//
+	// Subtree: the category, its children, and its grandchildren. This covers the
+	// whole hierarchy because depth is capped at services.MaxCategoryDepth (3).
+	//
+	// Sorting: Same ordering as ListProductsByCategory (featured first, then by date)
+	//
+	// Use case: Family and series pages that roll up products from their children
+	ListProductsInCategoryTree(ctx context.Context, arg ListProductsInCategoryTreeParams) ([]ListProductsInCategoryTreeRow, error)
+	// Lists the public doc sets for /docs, grouped by product (sets without
+	// one, or whose product is trashed, come first) and then by sort order.
+	ListPublishedDocSets(ctx context.Context) ([]ListPublishedDocSetsRow, error)
+	// Lists every published event for the sitemap.
+	ListPublishedEventSlugs(ctx context.Context) ([]ListPublishedEventSlugsRow, error)
+	// Lists the published landing pages that search engines may index, for
+	// the sitemap.
+	ListPublishedLandingPages(ctx context.Context) ([]ListPublishedLandingPagesRow, error)
 	// ====================================================================
 	// BLOG POSTS QUERIES
 	// ====================================================================
@@ -2749,7 +4209,8 @@ type Querier interface {
 	//   Note: INNER JOINs ensure posts without valid category/author are excluded
 	// WHERE clause:
 	//   - status = 'published': only show published posts, hide drafts
-	//   - published_at IS NOT NULL: additional safety check for scheduled posts
+	//   - published_at < datetime('now', '+1 second'): posts dated in the future stay hidden until then
+	//     (compared by the second, so a post published just now is listed at once)
 	// ORDER BY published_at DESC: newest posts first (reverse chronological)
 	ListPublishedPosts(ctx context.Context, arg ListPublishedPostsParams) ([]ListPublishedPostsRow, error)
 	// sqlc annotation: :many returns slice of blog post rows
@@ -2760,10 +4221,63 @@ type Querier interface {
 	//   3. OFFSET (INTEGER): pagination offset
 	// Return type: slice of denormalized blog post rows
 	// WHERE clause:
-	//   - bp.status = 'published' AND bp.published_at IS NOT NULL: same as main list
+	//   - bp.status = 'published' AND bp.published_at < datetime('now', '+1 second'): same as main list
 	//   - bc.slug = ?: filters by category slug (JOIN to blog_categories required)
 	// Note: INNER JOIN ensures only valid category slugs return results
 	ListPublishedPostsByCategory(ctx context.Context, arg ListPublishedPostsByCategoryParams) ([]ListPublishedPostsByCategoryRow, error)
+	// sqlc annotation: :many returns slice of blog post rows
+	// Purpose: Lists published posts for one month archive page (/blog/2024/05)
+	// Parameters (named):
+	//   - month_start (TEXT): UTC "2006-01-02 15:04:05" start of the month in the
+	//     site timezone
+	//   - month_end (TEXT): UTC "2006-01-02 15:04:05" start of the next month
+	//   - limit / offset (INTEGER): pagination
+	// Return type: slice of denormalized blog post rows (same columns as ListPublishedPosts)
+	ListPublishedPostsByMonth(ctx context.Context, arg ListPublishedPostsByMonthParams) ([]ListPublishedPostsByMonthRow, error)
+	// sqlc annotation: :many returns slice of blog post rows
+	// Purpose: Lists published posts with one tag (tag page, /blog/tags/:slug)
+	// Parameters (positional):
+	//   1. tag slug (TEXT): URL-safe tag identifier
+	//   2. LIMIT (INTEGER): posts per page
+	//   3. OFFSET (INTEGER): pagination offset
+	// Return type: slice of denormalized blog post rows (same columns as ListPublishedPosts)
+	ListPublishedPostsByTag(ctx context.Context, arg ListPublishedPostsByTagParams) ([]ListPublishedPostsByTagRow, error)
+	// sqlc annotation: :many returns slice of published member posts
+	// Purpose: Ordered parts of a series for public prev/next navigation
+	// Parameters:
+	//   1. series_id (INTEGER): series to list
+	// Return type: minimal post data with part number
+	// WHERE clause: only published posts are linked from public pages
+	ListPublishedPostsInSeries(ctx context.Context, seriesID sql.NullInt64) ([]ListPublishedPostsInSeriesRow, error)
+	// Lists the newest shown "In the news" links for /press.
+	// Parameters:
+	//   1. limit (INTEGER): how many to list
+	ListPublishedPressMentions(ctx context.Context, limit int64) ([]PressMention, error)
+	// ====================================================================
+	// PUBLIC API (keyset pagination)
+	// ====================================================================
+	// The API pages with a keyset instead of OFFSET: each page starts after the
+	// last row of the previous one, so products published or deleted between
+	// requests never shift rows into (or out of) a page already read. The
+	// product id breaks ties, giving every ordering a strict total order.
+	// Returns published products with an id greater than @after_id, oldest first.
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Only this category (0 for all categories)
+	//   @after_id (INTEGER) - id of the last product of the previous page (0 for the first page)
+	//   @page_limit (INTEGER) - Maximum rows to return
+	// Returns: []ListPublishedProductsByIDAfterRow
+	ListPublishedProductsByIDAfter(ctx context.Context, arg ListPublishedProductsByIDAfterParams) ([]ListPublishedProductsByIDAfterRow, error)
+	// Returns published products ordered by name, then id, starting after the
+	// (@after_name, @after_id) key.
+	//
+	// Parameters:
+	//   @category_id (INTEGER) - Only this category (0 for all categories)
+	//   @after_name (TEXT) - Name of the last product of the previous page ("" with @after_id 0 for the first page)
+	//   @after_id (INTEGER) - id of the last product of the previous page
+	//   @page_limit (INTEGER) - Maximum rows to return
+	// Returns: []ListPublishedProductsByNameAfterRow
+	ListPublishedProductsByNameAfter(ctx context.Context, arg ListPublishedProductsByNameAfterParams) ([]ListPublishedProductsByNameAfterRow, error)
 	// ====================================================================
 	// SOLUTIONS QUERY FILE
 	// ====================================================================
@@ -2858,6 +4372,106 @@ type Querier interface {
 	// Sorting: Same as ListPublishedWhitepapers (newest first)
 	// Use case: Topic-specific whitepaper listing pages
 	ListPublishedWhitepapersByTopic(ctx context.Context, topicID int64) ([]ListPublishedWhitepapersByTopicRow, error)
+	// Purpose: The newest lead submissions (contact form, RFQs and gated
+	// whitepaper downloads) for the dashboard's recent leads card
+	// Parameter: limit (INTEGER), the number of submissions
+	// Return type: source (contact or whitepaper), row ID, contact details, the
+	// inquiry type or whitepaper title as detail, and created_at as
+	// CURRENT_TIMESTAMP text (UTC)
+	ListRecentLeads(ctx context.Context, limit int64) ([]ListRecentLeadsRow, error)
+	// Lists every rule, ordered by source path.
+	ListRedirects(ctx context.Context) ([]Redirect, error)
+	// Candidate blog posts for a product's related content.
+	// Parameters (named):
+	//   1. product_id (INTEGER): product being viewed
+	//   2. category_id (INTEGER): the product's category
+	//   3. candidate_limit (INTEGER): max candidates to score (pins and strongest matches first)
+	// Match signals:
+	//   - mentions_product (0 or 1): the post lists the product as related
+	//   - shares_category (0 or 1): the post lists another product of the same category
+	//   - shared_tags: tags in common with the posts that list the product
+	//   - pinned, pin_order: an admin pin for this product
+	// Outer WHERE: a candidate needs a pin or at least one signal
+	ListRelatedBlogPostCandidates(ctx context.Context, arg ListRelatedBlogPostCandidatesParams) ([]ListRelatedBlogPostCandidatesRow, error)
+	// ====================================================================
+	// RELATED CONTENT QUERIES
+	// ====================================================================
+	// Recommendations of case studies, blog posts and whitepapers for a
+	// product page. The candidate queries return match signals that
+	// services.RelatedContentService weighs in Go; admins can also pin items
+	// to a product by hand.
+	//
+	// Managed entity:
+	// - related_content_pins: Manual picks per product, shown before the
+	//   scored recommendations
+	//
+	// Security notes:
+	// - Pin queries taking a product_id are scoped to it so a pin can only be
+	//   removed through the product it belongs to
+	// ====================================================================
+	// Candidate case studies for a product's related content.
+	// Parameters (named):
+	//   1. product_id (INTEGER): product being viewed
+	//   2. category_id (INTEGER): the product's category
+	//   3. candidate_limit (INTEGER): max candidates to score (pins and strongest matches first)
+	// Match signals (0 or 1):
+	//   - features_product: the case study lists the product
+	//   - shares_category: the case study lists another product of the same category
+	//   - shares_industry: same industry as a case study that lists the product
+	//   - pinned, pin_order: an admin pin for this product
+	// Outer WHERE: a candidate needs a pin or at least one signal
+	ListRelatedCaseStudyCandidates(ctx context.Context, arg ListRelatedCaseStudyCandidatesParams) ([]ListRelatedCaseStudyCandidatesRow, error)
+	// Lists a product's pins with the pinned item's title for the admin tab.
+	// The title is empty when the item is in the trash or otherwise missing.
+	// Parameters:
+	//   1. product_id (INTEGER): product
+	ListRelatedContentPins(ctx context.Context, productID int64) ([]ListRelatedContentPinsRow, error)
+	// sqlc annotation: :many returns slice of candidate related posts
+	// Purpose: Candidate set for the related posts engine, which scores and ranks them in Go
+	// Parameters (named):
+	//   1. post_id (INTEGER): current post; excluded from results and source of the tag set
+	//   2. category_id (INTEGER): current post's category
+	//   3. candidate_limit (INTEGER): max candidates to score (strongest matches first)
+	// Return type: minimal blog post data plus match signals
+	//   - shared_tags: number of tags in common with the current post
+	//   - category_id: compared against the current post's category by the scorer
+	// LEFT JOIN + GROUP BY: counts only tags that also belong to the current post
+	// HAVING: a candidate must share the category or at least one tag
+	ListRelatedPostCandidates(ctx context.Context, arg ListRelatedPostCandidatesParams) ([]ListRelatedPostCandidatesRow, error)
+	// Candidate whitepapers for a product's related content. Whitepapers are
+	// not linked to products, so they match through their topic's slug.
+	// Parameters (named):
+	//   1. product_id (INTEGER): product being viewed
+	//   2. category_id (INTEGER): the product's category
+	//   3. candidate_limit (INTEGER): max candidates to score (pins and strongest matches first)
+	// Match signals (0 or 1):
+	//   - topic_matches_category: the topic slug equals the product category's slug
+	//   - topic_matches_tag: the topic slug equals a tag of a post that lists the product
+	//   - pinned, pin_order: an admin pin for this product
+	// Outer WHERE: a candidate needs a pin or at least one signal
+	ListRelatedWhitepaperCandidates(ctx context.Context, arg ListRelatedWhitepaperCandidatesParams) ([]ListRelatedWhitepaperCandidatesRow, error)
+	// Lists the released press releases for the sitemap.
+	// Parameters:
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	ListReleasedPressReleaseSlugs(ctx context.Context, now string) ([]ListReleasedPressReleaseSlugsRow, error)
+	// Lists published press releases whose release time has passed, latest
+	// first.
+	// Parameters:
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	ListReleasedPressReleases(ctx context.Context, now string) ([]PressRelease, error)
+	// Lists items waiting for a reviewer (in_review) or for publishing
+	// (approved), oldest first, with their title and the people involved.
+	// Trashed content is left out (its title comes back empty).
+	// Parameters (named):
+	//  1. reviewer_id (INTEGER): only items assigned to this user; 0 for all
+	ListReviewQueue(ctx context.Context, reviewerID int64) ([]ListReviewQueueRow, error)
+	// Lists every non-empty rich text field. parent_id is the landing page of a
+	// landing page section and 0 otherwise.
+	ListRichContent(ctx context.Context) ([]ListRichContentRow, error)
+	// Lists every override: paths first, then content items by type and ID.
+	ListSEOMeta(ctx context.Context) ([]SeoMetum, error)
+	// Lists every short link, newest first.
+	ListShortLinks(ctx context.Context) ([]ShortLink, error)
 	// ====================================================================
 	// SOLUTION PAGE FEATURES ("Why Choose BlueJay" Section)
 	// ====================================================================
@@ -2871,6 +4485,7 @@ type Querier interface {
 	// Sorting: display_order ASC - Features in configured order
 	// Use case: Displaying "Why Choose BlueJay" section on solution pages
 	ListSolutionPageFeatures(ctx context.Context) ([]SolutionPageFeature, error)
+	ListSolutionTranslationSources(ctx context.Context) ([]ListSolutionTranslationSourcesRow, error)
 	// ====================================================================
 	// SOLUTIONS - ADMIN QUERIES
 	// ====================================================================
@@ -2895,6 +4510,27 @@ type Querier interface {
 	// Sorting: display_order ASC, title ASC - Custom order then alphabetical
 	// Use case: Admin solutions management with status filter and search bar
 	ListSolutionsAdminFiltered(ctx context.Context, arg ListSolutionsAdminFilteredParams) ([]Solution, error)
+	// Purpose: The most downloaded whitepapers for the dashboard, with their
+	// downloads over the last 30 days
+	// Parameter: limit (INTEGER), the number of whitepapers
+	ListTopDownloadedWhitepapers(ctx context.Context, limit int64) ([]ListTopDownloadedWhitepapersRow, error)
+	// sqlc annotation: :many returns every translation for a locale
+	// Purpose: Coverage dashboard computes complete/outdated/missing counts per entity type
+	ListTranslationsByLocale(ctx context.Context, locale string) ([]Translation, error)
+	// Lists trashed blog posts for the admin trash page.
+	ListTrashedBlogPosts(ctx context.Context) ([]ListTrashedBlogPostsRow, error)
+	// Lists trashed case studies for the admin trash page.
+	ListTrashedCaseStudies(ctx context.Context) ([]ListTrashedCaseStudiesRow, error)
+	// Lists trashed products for the admin trash page.
+	ListTrashedProducts(ctx context.Context) ([]ListTrashedProductsRow, error)
+	// Lists trashed solutions for the admin trash page.
+	ListTrashedSolutions(ctx context.Context) ([]ListTrashedSolutionsRow, error)
+	// Lists published events that have not ended, soonest first.
+	// Parameters:
+	//   @now (TEXT): current UTC time, "2006-01-02 15:04:05"
+	ListUpcomingEvents(ctx context.Context, now string) ([]Event, error)
+	// Lists every endpoint, oldest first.
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
 	// Retrieves paginated whitepaper download records (all whitepapers).
 	//
 	// Parameters:
@@ -2938,6 +4574,11 @@ type Querier interface {
 	// Sorting: wd.created_at DESC - Newest downloads first
 	// Use case: Admin lead management, download analytics, CRM export
 	ListWhitepaperDownloadsFiltered(ctx context.Context, arg ListWhitepaperDownloadsFilteredParams) ([]ListWhitepaperDownloadsFilteredRow, error)
+	// sqlc annotation: :many returns every whitepaper download lead in a period
+	// Parameters:
+	//   @period_start (TEXT): inclusive lower bound
+	//   @period_end (TEXT): exclusive upper bound
+	ListWhitepaperDownloadsForArchive(ctx context.Context, arg ListWhitepaperDownloadsForArchiveParams) ([]WhitepaperDownload, error)
 	// ====================================================================
 	// WHITEPAPER TOPICS QUERY FILE
 	// ====================================================================
@@ -2997,6 +4638,88 @@ type Querier interface {
 	// Sorting: w.created_at DESC - Newest whitepapers first
 	// Use case: Admin whitepapers listing with search, topic dropdown, and status filter
 	ListWhitepapersAdminFiltered(ctx context.Context, arg ListWhitepapersAdminFilteredParams) ([]ListWhitepapersAdminFilteredRow, error)
+	// sqlc annotation: :execrows returns number of translations flagged
+	// Purpose: Flags complete translations whose source content changed since they were saved
+	// Parameters:
+	//   @entity_type (TEXT), @entity_id (INTEGER): the edited source entity
+	//   @source_hash (TEXT): fingerprint of the current source fields
+	MarkTranslationsOutdated(ctx context.Context, arg MarkTranslationsOutdatedParams) (int64, error)
+	// sqlc annotation: :exec performs upsert without returning data
+	// Purpose: Record that email belongs to the same person as primary_email
+	// Parameters (2 positional):
+	//   1. email (TEXT): normalized address being merged away
+	//   2. primary_email (TEXT): normalized address it is merged into
+	// ON CONFLICT: Re-merging an address moves it to the new primary
+	MergeLead(ctx context.Context, arg MergeLeadParams) error
+	// Moves every block attached to one page path to another, when the page's
+	// path changes (e.g. a landing page gets a new slug).
+	// Parameters (named):
+	//  1. new_route (TEXT): new page path
+	//  2. old_route (TEXT): old page path
+	MovePageBlocks(ctx context.Context, arg MovePageBlocksParams) error
+	// Views per day from the first day on, oldest first. Days without views
+	// are missing; the dashboard fills them in.
+	PageViewTrend(ctx context.Context, day string) ([]PageViewTrendRow, error)
+	// Views per user agent class from the first day on.
+	PageViewsByUAClass(ctx context.Context, day string) ([]PageViewsByUAClassRow, error)
+	// Permanently deletes blog posts trashed before the cutoff.
+	// Parameters:
+	//   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+	PurgeTrashedBlogPosts(ctx context.Context, cutoff string) (int64, error)
+	// Permanently deletes case studies trashed before the cutoff.
+	// Parameters:
+	//   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+	PurgeTrashedCaseStudies(ctx context.Context, cutoff string) (int64, error)
+	// Permanently deletes products trashed before the cutoff.
+	// Parameters:
+	//   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+	PurgeTrashedProducts(ctx context.Context, cutoff string) (int64, error)
+	// Permanently deletes solutions trashed before the cutoff.
+	// Parameters:
+	//   1. cutoff (TEXT): "YYYY-MM-DD HH:MM:SS" (UTC)
+	PurgeTrashedSolutions(ctx context.Context, cutoff string) (int64, error)
+	// Records a finished run, counting it and, when it failed, the failure.
+	// Parameters:
+	//
+	//	@name (TEXT): job name
+	//	@started_at (TEXT): UTC "2006-01-02 15:04:05" start time
+	//	@duration_ms (INTEGER): run time in milliseconds
+	//	@error (TEXT): error message, '' on success
+	//	@trigger_kind (TEXT): 'schedule' or 'manual'
+	RecordJobRun(ctx context.Context, arg RecordJobRunParams) error
+	// Records the outcome of handing a subscribe or unsubscribe to the
+	// mailing list provider.
+	// Parameters:
+	//   1. provider_error (TEXT): why the provider call failed, empty on success
+	//   2. id (INTEGER): subscriber ID
+	RecordNewsletterProviderSync(ctx context.Context, arg RecordNewsletterProviderSyncParams) error
+	// Counts one 404 for a path, from one referrer.
+	// Parameters:
+	//   1. path (TEXT): requested path, without the query string
+	//   2. referrer (TEXT): Referer header, empty when absent
+	RecordNotFound(ctx context.Context, arg RecordNotFoundParams) error
+	// Counts one use of a rule.
+	RecordRedirectHit(ctx context.Context, id int64) error
+	// Counts one visit through a link.
+	RecordShortLinkClick(ctx context.Context, id int64) error
+	// Stores the outcome of the latest delivery to an endpoint.
+	// Parameters:
+	//   1. last_status (INTEGER): HTTP status of the response, 0 when none came
+	//   2. last_error (TEXT): why the delivery failed, blank on success
+	//   3. id (INTEGER): endpoint ID
+	RecordWebhookDelivery(ctx context.Context, arg RecordWebhookDeliveryParams) error
+	// Drops a user's lock when they leave the edit form. Scoped by user_id so
+	// nobody can release someone else's lock.
+	// Parameters:
+	//  1. content_type (TEXT): e.g. "product", "blog_post"
+	//  2. content_id (INTEGER): ID of the item
+	//  3. user_id (INTEGER): holder of the lock
+	ReleaseEditLock(ctx context.Context, arg ReleaseEditLockParams) (int64, error)
+	// sqlc annotation: :exec returns no data
+	// Purpose: Removes a post from its series
+	// Parameters:
+	//   1. id (INTEGER): post to detach
+	RemovePostFromSeries(ctx context.Context, id int64) error
 	// Removes a product association from a solution.
 	//
 	// Parameters:
@@ -3011,6 +4734,76 @@ type Querier interface {
 	//   2. blog_tag_id (INTEGER)
 	// Return type: none
 	RemoveTagFromPost(ctx context.Context, arg RemoveTagFromPostParams) error
+	// sqlc annotation: :exec updates without returning data
+	// Purpose: Keep merges one level deep when a primary is itself merged
+	// Parameters (named):
+	//   1. new_primary (TEXT): address the merged leads now belong to
+	//   2. old_primary (TEXT): former primary address being merged away
+	RepointLeadMerges(ctx context.Context, arg RepointLeadMergesParams) error
+	// Puts jobs that were running when the server stopped back in the queue.
+	RequeueRunningExportJobs(ctx context.Context) (int64, error)
+	// Sends a pending or unsubscribed address back to pending with a new
+	// confirmation token, when it signs up again.
+	// Parameters:
+	//   1. confirm_token_hash (TEXT): hex SHA-256 of the new token
+	//   2. source (TEXT): page the form was submitted from
+	//   3. id (INTEGER): subscriber ID
+	RestartNewsletterConfirmation(ctx context.Context, arg RestartNewsletterConfirmationParams) error
+	// Takes a blog post out of the trash.
+	// Parameters:
+	//   1. id (INTEGER): post to restore
+	RestoreBlogPost(ctx context.Context, id int64) (int64, error)
+	// Takes a case study out of the trash.
+	// Parameters:
+	//   1. id (INTEGER): case study to restore
+	RestoreCaseStudy(ctx context.Context, id int64) (int64, error)
+	// Takes a product out of the trash.
+	// Parameters:
+	//   1. id (INTEGER): product to restore
+	RestoreProduct(ctx context.Context, id int64) (int64, error)
+	// Takes a solution out of the trash.
+	// Parameters:
+	//   1. id (INTEGER): solution to restore
+	RestoreSolution(ctx context.Context, id int64) (int64, error)
+	// Points rules that led to a path which has moved at its new path, so
+	// visitors get one redirect instead of a chain.
+	// Parameters:
+	//   @new_target (TEXT): new path
+	//   @old_target (TEXT): path that moved
+	RetargetRedirects(ctx context.Context, arg RetargetRedirectsParams) error
+	// Records the decision on a submission.
+	// Parameters (3 positional): status ('approved' or 'rejected'),
+	// review_notes, id
+	ReviewPartnerSubmission(ctx context.Context, arg ReviewPartnerSubmissionParams) error
+	// Disables an invite link. Revoking twice keeps the first date.
+	RevokePartnerInvite(ctx context.Context, id int64) error
+	// Adds the views recorded before cutoff to their day's counts. Run with
+	// DeletePageViewsBefore in one transaction, so no view is counted twice.
+	// Parameters:
+	//  1. cutoff (TEXT): start of the first day kept raw, YYYY-MM-DD (UTC)
+	RollupPageViews(ctx context.Context, cutoff string) (int64, error)
+	// Records a permanent redirect from a changed slug's old path, replacing
+	// any rule for that path.
+	// Parameters:
+	//   1. source_path (TEXT): old public path
+	//   2. target_path (TEXT): new public path
+	SaveAutomaticRedirect(ctx context.Context, arg SaveAutomaticRedirectParams) error
+	// Creates or replaces the workflow row of one item.
+	// Parameters:
+	//  1. content_type (TEXT): 'blog_post' or 'product'
+	//  2. content_id (INTEGER): ID in the content type's table
+	//  3. state (TEXT): 'draft', 'in_review', 'approved' or 'published'
+	//  4. reviewer_id (INTEGER, nullable): assigned reviewer
+	//  5. submitted_by (INTEGER, nullable): user who last submitted the item for review
+	SaveContentWorkflow(ctx context.Context, arg SaveContentWorkflowParams) error
+	// Finds content whose title, SKU or slug contains the query, titles that
+	// start with it first. Trashed items are left out. Each match has its
+	// content type, row ID, title, detail (SKU or slug) and status (published,
+	// draft or archived).
+	// Parameters (named):
+	//  1. query (TEXT): search term, matched case-insensitively
+	//  2. result_limit (INTEGER): maximum number of matches
+	SearchAdminContent(ctx context.Context, arg SearchAdminContentParams) ([]SearchAdminContentRow, error)
 	// sqlc annotation: :many returns filtered tags for autocomplete
 	// Purpose: Searches tags by partial name match (for typeahead/autocomplete UI)
 	// Parameters:
@@ -3055,6 +4848,17 @@ type Querier interface {
 	// Performance: May be slow without full-text search index on large datasets
 	// Sorting: published_at DESC - Newest matching products first
 	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error)
+	// Searches published products by name, description, or tagline, like
+	// SearchProducts, in the requested sort order.
+	//
+	// Parameters:
+	//   @pattern (TEXT) - LIKE pattern matched against name, description and tagline
+	//   @sort (TEXT) - "name_asc", "name_desc", "newest", "oldest" or "sku"
+	//   @page_limit (INTEGER) - Maximum results
+	// Returns: []SearchProductsSortedRow - Matching products with category_slug
+	//
+	// Sorting: Same CASE pattern as ListAllProductsInCategoryTree
+	SearchProductsSorted(ctx context.Context, arg SearchProductsSortedParams) ([]SearchProductsSortedRow, error)
 	// sqlc annotation: :many returns slice of products for search autocomplete
 	// Purpose: Searches published products by name for adding to blog posts
 	// Parameters:
@@ -3063,7 +4867,167 @@ type Querier interface {
 	// WHERE: status = 'published' ensures only published products can be linked
 	// LIMIT 10: restricts results for autocomplete/typeahead UI
 	SearchPublishedProducts(ctx context.Context, name string) ([]SearchPublishedProductsRow, error)
+	// sqlc annotation: :exec returns no data
+	// Purpose: Records the authoring mode of a post after create/update
+	// Parameters (named):
+	//   1. content_format (TEXT): 'html' (WYSIWYG) or 'markdown'
+	//   2. body_markdown (TEXT): Markdown source ('' for html posts)
+	//   3. id (INTEGER): post to update
+	// Note: body already holds the rendered HTML, saved by Create/UpdateBlogPost
+	SetBlogPostContentFormat(ctx context.Context, arg SetBlogPostContentFormatParams) error
+	// Publishes or unpublishes a blog post when its workflow state changes.
+	// published_at keeps its first value, so republishing does not move the
+	// post to the top of the blog.
+	// Parameters (named):
+	//  1. status (TEXT): 'published' or 'draft'
+	//  2. published_at (DATETIME): used when the post has never been published
+	//  3. id (INTEGER): blog post ID
+	SetBlogPostPublishStatus(ctx context.Context, arg SetBlogPostPublishStatusParams) (int64, error)
+	// sqlc annotation: :execrows returns the number of updated rows (0 when missing or trashed)
+	// Purpose: Changes where a case study sits in listings, from the admin list
+	// Parameters (named):
+	//  1. display_order (INTEGER): sort position, lowest first
+	//  2. id (INTEGER): case study primary key
+	SetCaseStudyDisplayOrder(ctx context.Context, arg SetCaseStudyDisplayOrderParams) (int64, error)
+	// sqlc annotation: :execrows returns the number of updated rows (0 when missing or trashed)
+	// Purpose: Publishes or unpublishes a case study from the admin list
+	// Parameters (named):
+	//  1. is_published (INTEGER): 1 to publish, 0 to unpublish
+	//  2. id (INTEGER): case study primary key
+	SetCaseStudyPublished(ctx context.Context, arg SetCaseStudyPublishedParams) (int64, error)
+	// sqlc annotation: :exec marks exactly one locale as the default
+	// Purpose: Keeps is_default in step with the configured source language
+	// Parameters:
+	//
+	//	@code (TEXT): The source-content locale
+	SetDefaultLocale(ctx context.Context, code string) error
+	// Makes a version the one /docs/<set> opens.
+	// Parameters (2 positional): current_version_id, id
+	SetDocSetCurrentVersion(ctx context.Context, arg SetDocSetCurrentVersionParams) error
+	// Publishes or unpublishes a landing page. The first publication date is
+	// kept when a page is taken down and published again.
+	// Parameters (named):
+	//  1. status (TEXT): draft or published
+	//  2. id (INTEGER): page ID
+	SetLandingPageStatus(ctx context.Context, arg SetLandingPageStatusParams) error
+	// Records the content hash of a media file.
+	//
+	// Parameters:
+	//   $1 (TEXT) - content_hash: Hex SHA-256 of the file contents
+	//   $2 (INTEGER) - id: Media file ID
+	SetMediaFileHash(ctx context.Context, arg SetMediaFileHashParams) error
+	// Switches public rendering of a menu on or off.
+	//
+	// Parameters:
+	//
+	//	$1 (BOOLEAN) - is_active: Whether the menu replaces the built-in links at its location
+	//	$2 (INTEGER) - id: Menu ID to update
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Note: Call DeactivateOtherNavigationMenus first, so one menu per location is active
+	SetNavigationMenuActive(ctx context.Context, arg SetNavigationMenuActiveParams) error
+	// Moves a category under another category, or to the top level.
+	//
+	// Parameters:
+	//   @parent_id (INTEGER) - Parent category ID (NULL for a top-level family)
+	//   @id (INTEGER) - Category ID to move
+	// Returns: (none)
+	//
+	// Note: Depth and cycle checks happen in services.ValidateCategoryParent
+	SetProductCategoryParent(ctx context.Context, arg SetProductCategoryParentParams) error
+	// Features or unfeatures a product and sets its homepage position, from the
+	// admin product list.
+	//
+	// Parameters:
+	//
+	//	@is_featured (BOOLEAN) - whether the product is featured
+	//	@featured_order (INTEGER) - position among featured products (NULL for none)
+	//	@id (INTEGER) - product ID
+	//
+	// Returns: number of updated rows (0 when the product is missing or trashed)
+	SetProductFeatured(ctx context.Context, arg SetProductFeaturedParams) (int64, error)
+	// Publishes or unpublishes a product when its workflow state changes.
+	// Parameters (named):
+	//  1. status (TEXT): 'published' or 'draft'
+	//  2. published_at (DATETIME): used when the product has never been published
+	//  3. id (INTEGER): product ID
+	SetProductPublishStatus(ctx context.Context, arg SetProductPublishStatusParams) (int64, error)
+	// Pauses or resumes deliveries to an endpoint.
+	// Parameters:
+	//   1. is_active (BOOLEAN): whether the endpoint receives events
+	//   2. id (INTEGER): endpoint ID
+	SetWebhookActive(ctx context.Context, arg SetWebhookActiveParams) error
+	// Reports whether a slug is used by another row of a table, including
+	// trashed rows (the UNIQUE constraint covers them too). Unknown tables
+	// report every slug as taken.
+	// Parameters (named):
+	//   1. table_name (TEXT): table the slug is for, e.g. 'products'
+	//   2. slug (TEXT): candidate slug
+	//   3. exclude_id (INTEGER): row being updated, 0 when creating
+	SlugTaken(ctx context.Context, arg SlugTakenParams) (int64, error)
+	// Retires the pending submission of a partner before a newer one is
+	// stored, so the queue holds at most one per partner.
+	SupersedePendingPartnerSubmissions(ctx context.Context, partnerID int64) error
+	// The countries with the most views from the first day on, including ”
+	// for views whose country is unknown.
+	TopCountries(ctx context.Context, arg TopCountriesParams) ([]TopCountriesRow, error)
+	// The most viewed paths from the first day on.
+	TopPages(ctx context.Context, arg TopPagesParams) ([]TopPagesRow, error)
+	// The referring sites sending the most views from the first day on,
+	// including ” for direct visits.
+	TopReferrers(ctx context.Context, arg TopReferrersParams) ([]TopReferrersRow, error)
+	// Refreshes last_seen_at and the latest client IP for a session.
+	// Parameters:
+	//   1. ip_address (TEXT): client IP of the current request
+	//   2. id (INTEGER): session ID
+	TouchAdminSession(ctx context.Context, arg TouchAdminSessionParams) error
+	// Marks a form as edited after one of its fields changed.
+	TouchForm(ctx context.Context, id int64) error
+	// Marks a landing page as edited after one of its sections changed.
+	TouchLandingPage(ctx context.Context, id int64) error
+	// Records that an invite link was used to send a submission.
+	TouchPartnerInvite(ctx context.Context, id int64) error
+	// Moves a blog post to the trash.
+	// Parameters:
+	//   1. id (INTEGER): post to trash
+	TrashBlogPost(ctx context.Context, id int64) (int64, error)
+	// Moves a case study to the trash.
+	// Parameters:
+	//   1. id (INTEGER): case study to trash
+	TrashCaseStudy(ctx context.Context, id int64) (int64, error)
+	// ====================================================================
+	// TRASH (SOFT DELETE) QUERIES
+	// ====================================================================
+	// Soft delete, restore, and purge for content entities. Deleting a
+	// product, blog post, solution, or case study in the admin sets
+	// deleted_at instead of removing the row; every listing query filters
+	// on deleted_at IS NULL.
+	//
+	// Managed entities:
+	// - products, blog_posts, solutions, case_studies (deleted_at column)
+	//
+	// Lifecycle:
+	// - Trash*: sets deleted_at (only for rows not already trashed)
+	// - Restore*: clears deleted_at
+	// - ListTrashed*: rows in the trash, most recently deleted first
+	// - PurgeTrashed*: permanently deletes rows trashed before a cutoff
+	//   (child rows are removed by ON DELETE CASCADE)
+	// ====================================================================
+	// Moves a product to the trash.
+	// Parameters:
+	//   1. id (INTEGER): product to trash
+	TrashProduct(ctx context.Context, id int64) (int64, error)
+	// Moves a solution to the trash.
+	// Parameters:
+	//   1. id (INTEGER): solution to trash
+	TrashSolution(ctx context.Context, id int64) (int64, error)
+	// sqlc annotation: :exec deletes without returning data
+	// Purpose: Split a merged address back into its own lead
+	UnmergeLead(ctx context.Context, email string) error
 	UnsetPrimaryOfficeLocations(ctx context.Context) error
+	// Unsubscribes an address. Any outstanding confirmation link stops working.
+	UnsubscribeNewsletterSubscriber(ctx context.Context, id int64) error
 	// Updates About page section visibility toggles.
 	//
 	// Parameters:
@@ -3074,6 +5038,16 @@ type Querier interface {
 	// Use case: Admin About page configuration
 	// Note: Controls which About page sections are displayed
 	UpdateAboutSettings(ctx context.Context, arg UpdateAboutSettingsParams) error
+	// Sets the networks the admin panel may be reached from.
+	//
+	// Parameters:
+	//   $1: admin_ip_allow - IP addresses or CIDR ranges, one per line; blank allows every address
+	//   $2: admin_ip_deny - IP addresses or CIDR ranges, one per line, refused even when allowed
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Use case: Admin Access page (validated by middleware.ParseIPRules)
+	UpdateAdminIPRules(ctx context.Context, arg UpdateAdminIPRulesParams) error
 	// sqlc annotation: :one returns the updated author row
 	// Purpose: Updates an existing blog author profile
 	// Parameters (9 positional):
@@ -3098,6 +5072,13 @@ type Querier interface {
 	// Return type: updated blog_posts row
 	// Note: updated_at explicitly set to CURRENT_TIMESTAMP
 	UpdateBlogPost(ctx context.Context, arg UpdateBlogPostParams) (BlogPost, error)
+	// sqlc annotation: :one returns the updated series row
+	// Purpose: Updates an existing series
+	// Parameters (4 positional):
+	//   1-3. updated field values (name, slug, description)
+	//   4. id (INTEGER): which series to update (WHERE clause)
+	// Return type: updated blog_series row
+	UpdateBlogSeries(ctx context.Context, arg UpdateBlogSeriesParams) (BlogSeries, error)
 	// Updates Blog page display and metadata settings.
 	//
 	// Parameters:
@@ -3129,6 +5110,9 @@ type Querier interface {
 	// Return type: updated certification row
 	UpdateCertification(ctx context.Context, arg UpdateCertificationParams) (Certification, error)
 	UpdateContactSubmissionStatus(ctx context.Context, arg UpdateContactSubmissionStatusParams) error
+	// Edits a block; every page it is attached to shows the change.
+	// Parameters: as CreateContentBlock, then 9. id (INTEGER): block ID
+	UpdateContentBlock(ctx context.Context, arg UpdateContentBlockParams) error
 	// sqlc annotation: :one returns the updated row
 	// Purpose: Updates an existing core value
 	// Parameters (5 positional):
@@ -3139,6 +5123,29 @@ type Querier interface {
 	//   5. id (INTEGER): which core value to update (WHERE clause)
 	// Return type: updated row with new values
 	UpdateCoreValue(ctx context.Context, arg UpdateCoreValueParams) (CoreValue, error)
+	// Records a successful sign-in.
+	UpdateCustomerLastLogin(ctx context.Context, id int64) error
+	// Replaces an account's password after a reset.
+	// Parameters:
+	//   1. password_hash (TEXT): bcrypt hash of the new password
+	//   2. id (INTEGER): account ID
+	UpdateCustomerPassword(ctx context.Context, arg UpdateCustomerPasswordParams) error
+	// Saves the page form. The version of a page never changes.
+	// Parameters (9 positional): parent_id, title, slug, body_markdown,
+	// body_html, meta_description, sort_order, is_published, id
+	UpdateDocPage(ctx context.Context, arg UpdateDocPageParams) error
+	// Saves the doc set form.
+	// Parameters: Same as CreateDocSet, then the set ID
+	UpdateDocSet(ctx context.Context, arg UpdateDocSetParams) error
+	// Saves the event form.
+	// Parameters: Same as CreateEvent, then the event ID
+	UpdateEvent(ctx context.Context, arg UpdateEventParams) error
+	// Records how far a running job is.
+	// Parameters:
+	//  1. rows_done (INTEGER): rows written so far
+	//  2. rows_total (INTEGER): rows expected (0 = unknown)
+	//  3. id (INTEGER): job ID
+	UpdateExportJobProgress(ctx context.Context, arg UpdateExportJobProgressParams) error
 	// Purpose: Updates existing footer column item
 	UpdateFooterColumnItem(ctx context.Context, arg UpdateFooterColumnItemParams) error
 	// ====================================================================
@@ -3175,6 +5182,23 @@ type Querier interface {
 	// Return type: none
 	// Note: Always updates row with id=1 (single settings row pattern)
 	UpdateFooterSettings(ctx context.Context, arg UpdateFooterSettingsParams) error
+	// Edits a form's settings.
+	// Parameters: as CreateForm, then 8. id (INTEGER): form ID
+	UpdateForm(ctx context.Context, arg UpdateFormParams) error
+	// Edits a field; its position is left alone.
+	// Parameters:
+	//
+	//	1-9. label, name, field_type, placeholder, help_text, options, required,
+	//	     min_length, max_length: as CreateFormField
+	//	10. id (INTEGER): field ID
+	UpdateFormField(ctx context.Context, arg UpdateFormFieldParams) error
+	// Moves a field to a new position. The form ID guards against reordering
+	// another form's fields.
+	// Parameters:
+	//  1. sort_order (INTEGER): new position, 0 first
+	//  2. id (INTEGER): field ID
+	//  3. form_id (INTEGER): form the field must belong to
+	UpdateFormFieldOrder(ctx context.Context, arg UpdateFormFieldOrderParams) error
 	// Updates site-wide global settings (identity, contact, SEO, social).
 	//
 	// Parameters:
@@ -3183,6 +5207,8 @@ type Querier interface {
 	//   $7-$8: SEO metadata (meta_description, meta_keywords)
 	//   $9: google_analytics_id - GA tracking ID
 	//   $10-$14: Social media URLs (Facebook, Twitter, LinkedIn, Instagram, YouTube)
+	//   $15-$16: Machine-translation assist (provider, API key)
+	//   $17: minify_html - Strip comments/whitespace from public pages before caching
 	//
 	// Returns: (none) - sqlc annotation :exec returns only row count
 	//
@@ -3235,6 +5261,29 @@ type Querier interface {
 	//
 	// Note: updated_at is automatically set to CURRENT_TIMESTAMP to track last modification
 	UpdateIndustry(ctx context.Context, arg UpdateIndustryParams) (Industry, error)
+	// Moves an application through the hiring process and saves the
+	// reviewer's notes.
+	// Parameters (3 positional): status, notes, id
+	UpdateJobApplicationStatus(ctx context.Context, arg UpdateJobApplicationStatusParams) error
+	// Saves the job posting form.
+	// Parameters: Same as CreateJobPosting, then the posting ID
+	UpdateJobPosting(ctx context.Context, arg UpdateJobPostingParams) error
+	// Edits a landing page's settings; its status is left alone.
+	// Parameters: as CreateLandingPage, then 10. id (INTEGER): page ID
+	UpdateLandingPage(ctx context.Context, arg UpdateLandingPageParams) error
+	// Edits a section; its type and position are left alone.
+	// Parameters:
+	//  1. heading (TEXT), 2. body (TEXT), 3. image (TEXT), 4. button_text (TEXT),
+	//  5. button_url (TEXT), 6. items (TEXT): as CreateLandingPageSection
+	//  7. id (INTEGER): section ID
+	UpdateLandingPageSection(ctx context.Context, arg UpdateLandingPageSectionParams) error
+	// Moves a section to a new position. The page ID guards against
+	// reordering another page's sections.
+	// Parameters:
+	//  1. sort_order (INTEGER): new position, 0 first
+	//  2. id (INTEGER): section ID
+	//  3. landing_page_id (INTEGER): page the section must belong to
+	UpdateLandingPageSectionOrder(ctx context.Context, arg UpdateLandingPageSectionOrderParams) error
 	// sqlc annotation: :exec returns no data, only error or success
 	// Purpose: Updates last_login_at timestamp after successful authentication
 	// Parameters:
@@ -3243,6 +5292,23 @@ type Querier interface {
 	// Note: CURRENT_TIMESTAMP uses database server time (UTC in SQLite)
 	//       updated_at also refreshed to track any account modifications
 	UpdateLastLogin(ctx context.Context, id int64) error
+	// sqlc annotation: :execrows returns 0 when the locale does not exist
+	// Purpose: Renames, reorders, enables or disables a locale
+	// Parameters:
+	//
+	//	@name (TEXT), @is_enabled (BOOLEAN), @sort_order (INTEGER)
+	//	@code (TEXT): Locale to update
+	UpdateLocale(ctx context.Context, arg UpdateLocaleParams) (int64, error)
+	// Switches maintenance mode and sets the text of the maintenance page.
+	//
+	// Parameters:
+	//   $1: maintenance_mode - Public pages answer 503 while set (signed-in admins are exempt)
+	//   $2: maintenance_message - Shown on the maintenance page; blank uses a default text
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Use case: General tab of the admin global settings page
+	UpdateMaintenanceMode(ctx context.Context, arg UpdateMaintenanceModeParams) error
 	// Updates the alt text for an existing media file (accessibility).
 	//
 	// Parameters:
@@ -3271,7 +5337,12 @@ type Querier interface {
 	//   $6 (BOOLEAN) - is_active: Updated visibility status
 	//   $7 (INTEGER) - parent_id: Updated parent (for moving in hierarchy)
 	//   $8 (INTEGER) - sort_order: Updated display position
-	//   $9 (INTEGER) - id: Item ID to update
+	//   $9 (TEXT) - visibility: Updated layout visibility ("all", "desktop", "mobile")
+	//   $10 (TEXT) - icon: Updated Material Symbols icon name (empty for none)
+	//   $11 (TEXT) - layout: Updated dropdown layout ("list", "mega")
+	//   $12 (TEXT) - featured_type: Updated featured content type (empty for none)
+	//   $13 (INTEGER) - featured_id: Updated featured content ID
+	//   $14 (INTEGER) - id: Item ID to update
 	// Returns: (none) - sqlc annotation :exec returns only row count
 	//
 	// Use case: Editing menu items, changing hierarchy, reordering
@@ -3298,6 +5369,13 @@ type Querier interface {
 	// Note: updated_at is automatically set to CURRENT_TIMESTAMP
 	UpdateNavigationMenu(ctx context.Context, arg UpdateNavigationMenuParams) error
 	UpdateOfficeLocation(ctx context.Context, arg UpdateOfficeLocationParams) error
+	// Moves an attachment to a new position on its page. The route guards
+	// against reordering another page's blocks.
+	// Parameters:
+	//  1. sort_order (INTEGER): new position, 0 first
+	//  2. id (INTEGER): attachment ID
+	//  3. route (TEXT): page path the attachment must belong to
+	UpdatePageBlockOrder(ctx context.Context, arg UpdatePageBlockOrderParams) error
 	// Updates the content fields of an existing page section.
 	//
 	// Parameters:
@@ -3351,6 +5429,12 @@ type Querier interface {
 	//
 	// Note: updated_at is automatically set to CURRENT_TIMESTAMP
 	UpdatePartnerTier(ctx context.Context, arg UpdatePartnerTierParams) (PartnerTier, error)
+	// Saves the "In the news" link form.
+	// Parameters: Same as CreatePressMention, then the link ID
+	UpdatePressMention(ctx context.Context, arg UpdatePressMentionParams) error
+	// Saves the press release form.
+	// Parameters: Same as CreatePressRelease, then the release ID
+	UpdatePressRelease(ctx context.Context, arg UpdatePressReleaseParams) error
 	// Updates all core fields of an existing product.
 	//
 	// Parameters:
@@ -3381,6 +5465,9 @@ type Querier interface {
 	UpdateProductFeature(ctx context.Context, arg UpdateProductFeatureParams) error
 	UpdateProductImage(ctx context.Context, arg UpdateProductImageParams) error
 	UpdateProductSpec(ctx context.Context, arg UpdateProductSpecParams) error
+	// Updates a variant's SKU, name and order. Zero rows means the variant does
+	// not belong to the product.
+	UpdateProductVariant(ctx context.Context, arg UpdateProductVariantParams) (int64, error)
 	// Updates Products page display and filter settings.
 	//
 	// Parameters:
@@ -3393,6 +5480,16 @@ type Querier interface {
 	//
 	// Use case: Admin Products page configuration
 	UpdateProductsSettings(ctx context.Context, arg UpdateProductsSettingsParams) error
+	// Edits a rule; an edited automatic rule becomes a manual one.
+	// Parameters:
+	//   1. source_path (TEXT): path to redirect, may end in "*"
+	//   2. target_path (TEXT): path or absolute URL to redirect to
+	//   3. status_code (INTEGER): 301 or 302
+	//   4. id (INTEGER): rule ID
+	UpdateRedirect(ctx context.Context, arg UpdateRedirectParams) error
+	// Edits an override, including what it targets.
+	// Parameters: as CreateSEOMeta, then 9. id (INTEGER): override ID
+	UpdateSEOMeta(ctx context.Context, arg UpdateSEOMetaParams) error
 	// Updates the global settings record with comprehensive site configuration.
 	//
 	// Parameters (47 total):
@@ -3411,6 +5508,38 @@ type Querier interface {
 	// Use case: Comprehensive settings update from admin settings page (legacy query)
 	// Recommendation: Use specific Update*Settings queries for better maintainability
 	UpdateSettings(ctx context.Context, arg UpdateSettingsParams) error
+	// Edits a link; its click count is kept.
+	// Parameters:
+	//  1. code (TEXT): lowercase code after /go/
+	//  2. destination (TEXT): site path or http(s) URL
+	//  3. utm_source (TEXT): UTM source, empty to leave out
+	//  4. utm_medium (TEXT): UTM medium, empty to leave out
+	//  5. utm_campaign (TEXT): UTM campaign, empty to leave out
+	//  6. utm_term (TEXT): UTM term, empty to leave out
+	//  7. utm_content (TEXT): UTM content, empty to leave out
+	//  8. note (TEXT): where the link is used
+	//  9. expires_at (DATETIME): end of the link, NULL for none
+	//  10. id (INTEGER): link ID
+	UpdateShortLink(ctx context.Context, arg UpdateShortLinkParams) error
+	// Updates the locale and currency templates format numbers and prices with.
+	//
+	// Parameters:
+	//   $1: format_locale - BCP 47 tag (e.g. "de-DE"), validated by services.IsFormatLocale
+	//   $2: currency - ISO 4217 code (e.g. "EUR"), validated by services.IsCurrency
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Use case: General tab of the admin global settings page
+	UpdateSiteFormat(ctx context.Context, arg UpdateSiteFormatParams) error
+	// Updates the site timezone used to display timestamps.
+	//
+	// Parameters:
+	//   $1: timezone - IANA zone name (e.g. "Europe/Berlin"), validated by services.LoadTimezone
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Use case: General tab of the admin global settings page
+	UpdateSiteTimezone(ctx context.Context, timezone string) error
 	// Updates an existing solution's core fields.
 	//
 	// Parameters: Same as CreateSolution ($1-$12), plus:
@@ -3483,6 +5612,15 @@ type Querier interface {
 	// Purpose: Updates an existing homepage statistic
 	// Parameters (5 positional): same as CreateStat + id
 	UpdateStat(ctx context.Context, arg UpdateStatParams) error
+	// Sets the host names the built-in HTTPS server obtains certificates for.
+	//
+	// Parameters:
+	//   $1: tls_domains - Host names, one per line, validated by services.ParseHostNames; blank uses TLS_DOMAINS
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Use case: HTTPS Domains admin page
+	UpdateTLSDomains(ctx context.Context, tlsDomains string) error
 	// Updates an existing partner testimonial.
 	//
 	// Parameters:
@@ -3499,6 +5637,18 @@ type Querier interface {
 	// Purpose: Updates an existing homepage testimonial
 	// Parameters (9 positional): same as CreateTestimonialHomepage + id
 	UpdateTestimonialHomepage(ctx context.Context, arg UpdateTestimonialHomepageParams) error
+	// Updates the theme tokens served to the public and admin layouts.
+	//
+	// Parameters:
+	//   $1: theme_mode - Default theme for new visitors ("light", "dark", "system")
+	//   $2-$7: Light mode colors (primary, primary hover, background, surface, text, border)
+	//   $8-$13: Dark mode colors (primary, primary hover, background, surface, text, border)
+	//
+	// Returns: (none) - sqlc annotation :exec returns only row count
+	//
+	// Use case: Theme tab of the admin global settings page
+	// Note: Values are #RRGGBB hex colors, validated by services.ThemeFromSettings
+	UpdateThemeSettings(ctx context.Context, arg UpdateThemeSettingsParams) error
 	// Updates an existing whitepaper's core fields.
 	//
 	// Parameters: Same as CreateWhitepaper ($1-$12), plus:
@@ -3546,6 +5696,19 @@ type Querier interface {
 	//   6. values_icon (TEXT): icon identifier for values
 	// Return type: complete inserted row
 	UpsertMissionVisionValues(ctx context.Context, arg UpsertMissionVisionValuesParams) (MissionVisionValue, error)
+	// Sets a variant's value for a section + label, replacing an earlier
+	// override of the same spec.
+	// Parameters:
+	//   1. variant_id (INTEGER): variant
+	//   2. section_name (TEXT): product spec section to override or extend
+	//   3. spec_key (TEXT): spec label within the section
+	//   4. spec_value (TEXT): variant value
+	//   5. display_order (INTEGER): position when the spec is new to the product
+	UpsertProductVariantSpec(ctx context.Context, arg UpsertProductVariantSpecParams) (ProductVariantSpec, error)
+	// sqlc annotation: :one returns the saved record
+	// Purpose: Creates or replaces the translation of an entity for a locale
+	// Note: The unique (entity_type, entity_id, locale) index drives the upsert
+	UpsertTranslation(ctx context.Context, arg UpsertTranslationParams) (Translation, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -413,6 +413,26 @@ func TestProductCRUD(t *testing.T) {
 		t.Errorf("expected count 1, got %d", count)
 	}
 
+	// Categories with counts: the same count, for every category in one query
+	withCounts, err := queries.ListProductCategoriesWithCounts(ctx)
+	if err != nil {
+		t.Fatalf("ListCategoriesWithCounts: %v", err)
+	}
+	found := false
+	for _, row := range withCounts {
+		if row.ProductCategory.ID == cat.ID {
+			found = true
+			if row.PublishedCount != 1 {
+				t.Errorf("expected published count 1, got %d", row.PublishedCount)
+			}
+		} else if row.PublishedCount != 0 {
+			t.Errorf("expected no products in category %q, got %d", row.ProductCategory.Slug, row.PublishedCount)
+		}
+	}
+	if !found {
+		t.Error("category missing from ListProductCategoriesWithCounts")
+	}
+
 	// Admin list (all statuses)
 	allProducts, err := queries.ListAllProductsAdmin(ctx)
 	if err != nil {
@@ -11,7 +11,7 @@ import (
 
 const getSettings = `-- name: GetSettings :one
 
-SELECT id, site_name, site_tagline, contact_email, contact_phone, address, footer_text, meta_description, meta_keywords, google_analytics_id, social_linkedin, social_twitter, social_github, created_at, updated_at, social_facebook, social_youtube, social_instagram, business_hours, about_text, show_nav_home, show_nav_about, show_nav_products, show_nav_solutions, show_nav_blog, show_nav_partners, show_nav_contact, show_footer_about, show_footer_socials, show_footer_products, show_footer_solutions, show_footer_resources, show_footer_contact, nav_label_home, nav_label_about, nav_label_products, nav_label_solutions, nav_label_blog, nav_label_partners, nav_label_contact, footer_heading_products, footer_heading_solutions, footer_heading_resources, footer_heading_contact, header_logo_path, header_logo_alt, header_cta_enabled, header_cta_text, header_cta_url, header_cta_style, header_show_phone, header_show_email, header_show_social, header_social_style, show_nav_case_studies, show_nav_whitepapers, nav_label_case_studies, nav_label_whitepapers, footer_columns, footer_bg_style, footer_show_social, footer_social_style, footer_copyright, homepage_show_heroes, homepage_show_stats, homepage_show_testimonials, homepage_show_cta, homepage_max_heroes, homepage_max_stats, homepage_max_testimonials, homepage_hero_autoplay, homepage_hero_interval, about_show_mission, about_show_milestones, about_show_certifications, about_show_team, products_per_page, products_show_categories, products_show_search, products_default_sort, solutions_per_page, solutions_show_industries, solutions_show_search, blog_posts_per_page, blog_show_author, blog_show_date, blog_show_categories, blog_show_tags, blog_show_search FROM settings WHERE id = 1 LIMIT 1
+SELECT id, site_name, site_tagline, contact_email, contact_phone, address, footer_text, meta_description, meta_keywords, google_analytics_id, social_linkedin, social_twitter, social_github, created_at, updated_at, social_facebook, social_youtube, social_instagram, business_hours, about_text, show_nav_home, show_nav_about, show_nav_products, show_nav_solutions, show_nav_blog, show_nav_partners, show_nav_contact, show_footer_about, show_footer_socials, show_footer_products, show_footer_solutions, show_footer_resources, show_footer_contact, nav_label_home, nav_label_about, nav_label_products, nav_label_solutions, nav_label_blog, nav_label_partners, nav_label_contact, footer_heading_products, footer_heading_solutions, footer_heading_resources, footer_heading_contact, header_logo_path, header_logo_alt, header_cta_enabled, header_cta_text, header_cta_url, header_cta_style, header_show_phone, header_show_email, header_show_social, header_social_style, show_nav_case_studies, show_nav_whitepapers, nav_label_case_studies, nav_label_whitepapers, footer_columns, footer_bg_style, footer_show_social, footer_social_style, footer_copyright, homepage_show_heroes, homepage_show_stats, homepage_show_testimonials, homepage_show_cta, homepage_max_heroes, homepage_max_stats, homepage_max_testimonials, homepage_hero_autoplay, homepage_hero_interval, about_show_mission, about_show_milestones, about_show_certifications, about_show_team, products_per_page, products_show_categories, products_show_search, products_default_sort, solutions_per_page, solutions_show_industries, solutions_show_search, blog_posts_per_page, blog_show_author, blog_show_date, blog_show_categories, blog_show_tags, blog_show_search, mt_provider, mt_api_key, minify_html, theme_mode, theme_light_primary, theme_light_primary_hover, theme_light_background, theme_light_surface, theme_light_text, theme_light_border, theme_dark_primary, theme_dark_primary_hover, theme_dark_background, theme_dark_surface, theme_dark_text, theme_dark_border, timezone, maintenance_mode, maintenance_message, admin_ip_allow, admin_ip_deny, format_locale, currency, tls_domains FROM settings WHERE id = 1 LIMIT 1
 `
 
 // ====================================================================
@@ -130,10 +130,370 @@ func (q *Queries) GetSettings(ctx context.Context) (Setting, error) {
 		&i.BlogShowCategories,
 		&i.BlogShowTags,
 		&i.BlogShowSearch,
+		&i.MtProvider,
+		&i.MtApiKey,
+		&i.MinifyHtml,
+		&i.ThemeMode,
+		&i.ThemeLightPrimary,
+		&i.ThemeLightPrimaryHover,
+		&i.ThemeLightBackground,
+		&i.ThemeLightSurface,
+		&i.ThemeLightText,
+		&i.ThemeLightBorder,
+		&i.ThemeDarkPrimary,
+		&i.ThemeDarkPrimaryHover,
+		&i.ThemeDarkBackground,
+		&i.ThemeDarkSurface,
+		&i.ThemeDarkText,
+		&i.ThemeDarkBorder,
+		&i.Timezone,
+		&i.MaintenanceMode,
+		&i.MaintenanceMessage,
+		&i.AdminIpAllow,
+		&i.AdminIpDeny,
+		&i.FormatLocale,
+		&i.Currency,
+		&i.TlsDomains,
 	)
 	return i, err
 }
 
+const importSettings = `-- name: ImportSettings :exec
+UPDATE settings
+SET site_name = ?,
+    site_tagline = ?,
+    contact_email = ?,
+    contact_phone = ?,
+    address = ?,
+    footer_text = ?,
+    meta_description = ?,
+    meta_keywords = ?,
+    google_analytics_id = ?,
+    social_linkedin = ?,
+    social_twitter = ?,
+    social_github = ?,
+    social_facebook = ?,
+    social_youtube = ?,
+    social_instagram = ?,
+    business_hours = ?,
+    about_text = ?,
+    show_nav_home = ?,
+    show_nav_about = ?,
+    show_nav_products = ?,
+    show_nav_solutions = ?,
+    show_nav_blog = ?,
+    show_nav_partners = ?,
+    show_nav_contact = ?,
+    show_footer_about = ?,
+    show_footer_socials = ?,
+    show_footer_products = ?,
+    show_footer_solutions = ?,
+    show_footer_resources = ?,
+    show_footer_contact = ?,
+    nav_label_home = ?,
+    nav_label_about = ?,
+    nav_label_products = ?,
+    nav_label_solutions = ?,
+    nav_label_blog = ?,
+    nav_label_partners = ?,
+    nav_label_contact = ?,
+    footer_heading_products = ?,
+    footer_heading_solutions = ?,
+    footer_heading_resources = ?,
+    footer_heading_contact = ?,
+    header_logo_path = ?,
+    header_logo_alt = ?,
+    header_cta_enabled = ?,
+    header_cta_text = ?,
+    header_cta_url = ?,
+    header_cta_style = ?,
+    header_show_phone = ?,
+    header_show_email = ?,
+    header_show_social = ?,
+    header_social_style = ?,
+    show_nav_case_studies = ?,
+    show_nav_whitepapers = ?,
+    nav_label_case_studies = ?,
+    nav_label_whitepapers = ?,
+    footer_columns = ?,
+    footer_bg_style = ?,
+    footer_show_social = ?,
+    footer_social_style = ?,
+    footer_copyright = ?,
+    homepage_show_heroes = ?,
+    homepage_show_stats = ?,
+    homepage_show_testimonials = ?,
+    homepage_show_cta = ?,
+    homepage_max_heroes = ?,
+    homepage_max_stats = ?,
+    homepage_max_testimonials = ?,
+    homepage_hero_autoplay = ?,
+    homepage_hero_interval = ?,
+    about_show_mission = ?,
+    about_show_milestones = ?,
+    about_show_certifications = ?,
+    about_show_team = ?,
+    products_per_page = ?,
+    products_show_categories = ?,
+    products_show_search = ?,
+    products_default_sort = ?,
+    solutions_per_page = ?,
+    solutions_show_industries = ?,
+    solutions_show_search = ?,
+    blog_posts_per_page = ?,
+    blog_show_author = ?,
+    blog_show_date = ?,
+    blog_show_categories = ?,
+    blog_show_tags = ?,
+    blog_show_search = ?,
+    mt_provider = ?,
+    minify_html = ?,
+    theme_mode = ?,
+    theme_light_primary = ?,
+    theme_light_primary_hover = ?,
+    theme_light_background = ?,
+    theme_light_surface = ?,
+    theme_light_text = ?,
+    theme_light_border = ?,
+    theme_dark_primary = ?,
+    theme_dark_primary_hover = ?,
+    theme_dark_background = ?,
+    theme_dark_surface = ?,
+    theme_dark_text = ?,
+    theme_dark_border = ?,
+    timezone = ?,
+    format_locale = ?,
+    currency = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+type ImportSettingsParams struct {
+	SiteName                 string `json:"site_name"`
+	SiteTagline              string `json:"site_tagline"`
+	ContactEmail             string `json:"contact_email"`
+	ContactPhone             string `json:"contact_phone"`
+	Address                  string `json:"address"`
+	FooterText               string `json:"footer_text"`
+	MetaDescription          string `json:"meta_description"`
+	MetaKeywords             string `json:"meta_keywords"`
+	GoogleAnalyticsID        string `json:"google_analytics_id"`
+	SocialLinkedin           string `json:"social_linkedin"`
+	SocialTwitter            string `json:"social_twitter"`
+	SocialGithub             string `json:"social_github"`
+	SocialFacebook           string `json:"social_facebook"`
+	SocialYoutube            string `json:"social_youtube"`
+	SocialInstagram          string `json:"social_instagram"`
+	BusinessHours            string `json:"business_hours"`
+	AboutText                string `json:"about_text"`
+	ShowNavHome              bool   `json:"show_nav_home"`
+	ShowNavAbout             bool   `json:"show_nav_about"`
+	ShowNavProducts          bool   `json:"show_nav_products"`
+	ShowNavSolutions         bool   `json:"show_nav_solutions"`
+	ShowNavBlog              bool   `json:"show_nav_blog"`
+	ShowNavPartners          bool   `json:"show_nav_partners"`
+	ShowNavContact           bool   `json:"show_nav_contact"`
+	ShowFooterAbout          bool   `json:"show_footer_about"`
+	ShowFooterSocials        bool   `json:"show_footer_socials"`
+	ShowFooterProducts       bool   `json:"show_footer_products"`
+	ShowFooterSolutions      bool   `json:"show_footer_solutions"`
+	ShowFooterResources      bool   `json:"show_footer_resources"`
+	ShowFooterContact        bool   `json:"show_footer_contact"`
+	NavLabelHome             string `json:"nav_label_home"`
+	NavLabelAbout            string `json:"nav_label_about"`
+	NavLabelProducts         string `json:"nav_label_products"`
+	NavLabelSolutions        string `json:"nav_label_solutions"`
+	NavLabelBlog             string `json:"nav_label_blog"`
+	NavLabelPartners         string `json:"nav_label_partners"`
+	NavLabelContact          string `json:"nav_label_contact"`
+	FooterHeadingProducts    string `json:"footer_heading_products"`
+	FooterHeadingSolutions   string `json:"footer_heading_solutions"`
+	FooterHeadingResources   string `json:"footer_heading_resources"`
+	FooterHeadingContact     string `json:"footer_heading_contact"`
+	HeaderLogoPath           string `json:"header_logo_path"`
+	HeaderLogoAlt            string `json:"header_logo_alt"`
+	HeaderCtaEnabled         bool   `json:"header_cta_enabled"`
+	HeaderCtaText            string `json:"header_cta_text"`
+	HeaderCtaUrl             string `json:"header_cta_url"`
+	HeaderCtaStyle           string `json:"header_cta_style"`
+	HeaderShowPhone          bool   `json:"header_show_phone"`
+	HeaderShowEmail          bool   `json:"header_show_email"`
+	HeaderShowSocial         bool   `json:"header_show_social"`
+	HeaderSocialStyle        string `json:"header_social_style"`
+	ShowNavCaseStudies       bool   `json:"show_nav_case_studies"`
+	ShowNavWhitepapers       bool   `json:"show_nav_whitepapers"`
+	NavLabelCaseStudies      string `json:"nav_label_case_studies"`
+	NavLabelWhitepapers      string `json:"nav_label_whitepapers"`
+	FooterColumns            int64  `json:"footer_columns"`
+	FooterBgStyle            string `json:"footer_bg_style"`
+	FooterShowSocial         int64  `json:"footer_show_social"`
+	FooterSocialStyle        string `json:"footer_social_style"`
+	FooterCopyright          string `json:"footer_copyright"`
+	HomepageShowHeroes       int64  `json:"homepage_show_heroes"`
+	HomepageShowStats        int64  `json:"homepage_show_stats"`
+	HomepageShowTestimonials int64  `json:"homepage_show_testimonials"`
+	HomepageShowCta          int64  `json:"homepage_show_cta"`
+	HomepageMaxHeroes        int64  `json:"homepage_max_heroes"`
+	HomepageMaxStats         int64  `json:"homepage_max_stats"`
+	HomepageMaxTestimonials  int64  `json:"homepage_max_testimonials"`
+	HomepageHeroAutoplay     int64  `json:"homepage_hero_autoplay"`
+	HomepageHeroInterval     int64  `json:"homepage_hero_interval"`
+	AboutShowMission         int64  `json:"about_show_mission"`
+	AboutShowMilestones      int64  `json:"about_show_milestones"`
+	AboutShowCertifications  int64  `json:"about_show_certifications"`
+	AboutShowTeam            int64  `json:"about_show_team"`
+	ProductsPerPage          int64  `json:"products_per_page"`
+	ProductsShowCategories   int64  `json:"products_show_categories"`
+	ProductsShowSearch       int64  `json:"products_show_search"`
+	ProductsDefaultSort      string `json:"products_default_sort"`
+	SolutionsPerPage         int64  `json:"solutions_per_page"`
+	SolutionsShowIndustries  int64  `json:"solutions_show_industries"`
+	SolutionsShowSearch      int64  `json:"solutions_show_search"`
+	BlogPostsPerPage         int64  `json:"blog_posts_per_page"`
+	BlogShowAuthor           int64  `json:"blog_show_author"`
+	BlogShowDate             int64  `json:"blog_show_date"`
+	BlogShowCategories       int64  `json:"blog_show_categories"`
+	BlogShowTags             int64  `json:"blog_show_tags"`
+	BlogShowSearch           int64  `json:"blog_show_search"`
+	MtProvider               string `json:"mt_provider"`
+	MinifyHtml               bool   `json:"minify_html"`
+	ThemeMode                string `json:"theme_mode"`
+	ThemeLightPrimary        string `json:"theme_light_primary"`
+	ThemeLightPrimaryHover   string `json:"theme_light_primary_hover"`
+	ThemeLightBackground     string `json:"theme_light_background"`
+	ThemeLightSurface        string `json:"theme_light_surface"`
+	ThemeLightText           string `json:"theme_light_text"`
+	ThemeLightBorder         string `json:"theme_light_border"`
+	ThemeDarkPrimary         string `json:"theme_dark_primary"`
+	ThemeDarkPrimaryHover    string `json:"theme_dark_primary_hover"`
+	ThemeDarkBackground      string `json:"theme_dark_background"`
+	ThemeDarkSurface         string `json:"theme_dark_surface"`
+	ThemeDarkText            string `json:"theme_dark_text"`
+	ThemeDarkBorder          string `json:"theme_dark_border"`
+	Timezone                 string `json:"timezone"`
+	FormatLocale             string `json:"format_locale"`
+	Currency                 string `json:"currency"`
+}
+
+// Overwrites every importable setting in one statement (settings JSON import).
+//
+// Parameters: one per column of the settings row, except id, the timestamps
+// and mt_api_key (secrets are not part of an export)
+// Returns: (none)
+//
+// Use case: Applying a validated settings import (see services.ParseSettingsImport)
+// Note: Keep in step with the settings table; a column missing here makes the
+// import fail instead of silently dropping the value
+func (q *Queries) ImportSettings(ctx context.Context, arg ImportSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, importSettings,
+		arg.SiteName,
+		arg.SiteTagline,
+		arg.ContactEmail,
+		arg.ContactPhone,
+		arg.Address,
+		arg.FooterText,
+		arg.MetaDescription,
+		arg.MetaKeywords,
+		arg.GoogleAnalyticsID,
+		arg.SocialLinkedin,
+		arg.SocialTwitter,
+		arg.SocialGithub,
+		arg.SocialFacebook,
+		arg.SocialYoutube,
+		arg.SocialInstagram,
+		arg.BusinessHours,
+		arg.AboutText,
+		arg.ShowNavHome,
+		arg.ShowNavAbout,
+		arg.ShowNavProducts,
+		arg.ShowNavSolutions,
+		arg.ShowNavBlog,
+		arg.ShowNavPartners,
+		arg.ShowNavContact,
+		arg.ShowFooterAbout,
+		arg.ShowFooterSocials,
+		arg.ShowFooterProducts,
+		arg.ShowFooterSolutions,
+		arg.ShowFooterResources,
+		arg.ShowFooterContact,
+		arg.NavLabelHome,
+		arg.NavLabelAbout,
+		arg.NavLabelProducts,
+		arg.NavLabelSolutions,
+		arg.NavLabelBlog,
+		arg.NavLabelPartners,
+		arg.NavLabelContact,
+		arg.FooterHeadingProducts,
+		arg.FooterHeadingSolutions,
+		arg.FooterHeadingResources,
+		arg.FooterHeadingContact,
+		arg.HeaderLogoPath,
+		arg.HeaderLogoAlt,
+		arg.HeaderCtaEnabled,
+		arg.HeaderCtaText,
+		arg.HeaderCtaUrl,
+		arg.HeaderCtaStyle,
+		arg.HeaderShowPhone,
+		arg.HeaderShowEmail,
+		arg.HeaderShowSocial,
+		arg.HeaderSocialStyle,
+		arg.ShowNavCaseStudies,
+		arg.ShowNavWhitepapers,
+		arg.NavLabelCaseStudies,
+		arg.NavLabelWhitepapers,
+		arg.FooterColumns,
+		arg.FooterBgStyle,
+		arg.FooterShowSocial,
+		arg.FooterSocialStyle,
+		arg.FooterCopyright,
+		arg.HomepageShowHeroes,
+		arg.HomepageShowStats,
+		arg.HomepageShowTestimonials,
+		arg.HomepageShowCta,
+		arg.HomepageMaxHeroes,
+		arg.HomepageMaxStats,
+		arg.HomepageMaxTestimonials,
+		arg.HomepageHeroAutoplay,
+		arg.HomepageHeroInterval,
+		arg.AboutShowMission,
+		arg.AboutShowMilestones,
+		arg.AboutShowCertifications,
+		arg.AboutShowTeam,
+		arg.ProductsPerPage,
+		arg.ProductsShowCategories,
+		arg.ProductsShowSearch,
+		arg.ProductsDefaultSort,
+		arg.SolutionsPerPage,
+		arg.SolutionsShowIndustries,
+		arg.SolutionsShowSearch,
+		arg.BlogPostsPerPage,
+		arg.BlogShowAuthor,
+		arg.BlogShowDate,
+		arg.BlogShowCategories,
+		arg.BlogShowTags,
+		arg.BlogShowSearch,
+		arg.MtProvider,
+		arg.MinifyHtml,
+		arg.ThemeMode,
+		arg.ThemeLightPrimary,
+		arg.ThemeLightPrimaryHover,
+		arg.ThemeLightBackground,
+		arg.ThemeLightSurface,
+		arg.ThemeLightText,
+		arg.ThemeLightBorder,
+		arg.ThemeDarkPrimary,
+		arg.ThemeDarkPrimaryHover,
+		arg.ThemeDarkBackground,
+		arg.ThemeDarkSurface,
+		arg.ThemeDarkText,
+		arg.ThemeDarkBorder,
+		arg.Timezone,
+		arg.FormatLocale,
+		arg.Currency,
+	)
+	return err
+}
+
 const updateAboutSettings = `-- name: UpdateAboutSettings :exec
 UPDATE settings
 SET about_show_mission = ?,
@@ -232,6 +592,9 @@ SET site_name = ?,
     social_linkedin = ?,
     social_instagram = ?,
     social_youtube = ?,
+    mt_provider = ?,
+    mt_api_key = ?,
+    minify_html = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = 1
 `
@@ -251,6 +614,9 @@ type UpdateGlobalSettingsParams struct {
 	SocialLinkedin    string `json:"social_linkedin"`
 	SocialInstagram   string `json:"social_instagram"`
 	SocialYoutube     string `json:"social_youtube"`
+	MtProvider        string `json:"mt_provider"`
+	MtApiKey          string `json:"mt_api_key"`
+	MinifyHtml        bool   `json:"minify_html"`
 }
 
 // Updates site-wide global settings (identity, contact, SEO, social).
@@ -262,6 +628,8 @@ type UpdateGlobalSettingsParams struct {
 //	$7-$8: SEO metadata (meta_description, meta_keywords)
 //	$9: google_analytics_id - GA tracking ID
 //	$10-$14: Social media URLs (Facebook, Twitter, LinkedIn, Instagram, YouTube)
+//	$15-$16: Machine-translation assist (provider, API key)
+//	$17: minify_html - Strip comments/whitespace from public pages before caching
 //
 // Returns: (none) - sqlc annotation :exec returns only row count
 //
@@ -283,6 +651,9 @@ func (q *Queries) UpdateGlobalSettings(ctx context.Context, arg UpdateGlobalSett
 		arg.SocialLinkedin,
 		arg.SocialInstagram,
 		arg.SocialYoutube,
+		arg.MtProvider,
+		arg.MtApiKey,
+		arg.MinifyHtml,
 	)
 	return err
 }
@@ -452,6 +823,62 @@ func (q *Queries) UpdateHomepageSettings(ctx context.Context, arg UpdateHomepage
 	return err
 }
 
+const updateAdminIPRules = `-- name: UpdateAdminIPRules :exec
+UPDATE settings
+SET admin_ip_allow = ?,
+    admin_ip_deny = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+type UpdateAdminIPRulesParams struct {
+	AdminIpAllow string `json:"admin_ip_allow"`
+	AdminIpDeny  string `json:"admin_ip_deny"`
+}
+
+// Sets the networks the admin panel may be reached from.
+//
+// Parameters:
+//
+//	$1: admin_ip_allow - IP addresses or CIDR ranges, one per line; blank allows every address
+//	$2: admin_ip_deny - IP addresses or CIDR ranges, one per line, refused even when allowed
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Use case: Admin Access page (validated by middleware.ParseIPRules)
+func (q *Queries) UpdateAdminIPRules(ctx context.Context, arg UpdateAdminIPRulesParams) error {
+	_, err := q.db.ExecContext(ctx, updateAdminIPRules, arg.AdminIpAllow, arg.AdminIpDeny)
+	return err
+}
+
+const updateMaintenanceMode = `-- name: UpdateMaintenanceMode :exec
+UPDATE settings
+SET maintenance_mode = ?,
+    maintenance_message = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+type UpdateMaintenanceModeParams struct {
+	MaintenanceMode    bool   `json:"maintenance_mode"`
+	MaintenanceMessage string `json:"maintenance_message"`
+}
+
+// Switches maintenance mode and sets the text of the maintenance page.
+//
+// Parameters:
+//
+//	$1: maintenance_mode - Public pages answer 503 while set (signed-in admins are exempt)
+//	$2: maintenance_message - Shown on the maintenance page; blank uses a default text
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Use case: General tab of the admin global settings page
+func (q *Queries) UpdateMaintenanceMode(ctx context.Context, arg UpdateMaintenanceModeParams) error {
+	_, err := q.db.ExecContext(ctx, updateMaintenanceMode, arg.MaintenanceMode, arg.MaintenanceMessage)
+	return err
+}
+
 const updateProductsSettings = `-- name: UpdateProductsSettings :exec
 UPDATE settings
 SET products_per_page = ?,
@@ -647,6 +1074,55 @@ func (q *Queries) UpdateSettings(ctx context.Context, arg UpdateSettingsParams)
 	return err
 }
 
+const updateSiteFormat = `-- name: UpdateSiteFormat :exec
+UPDATE settings
+SET format_locale = ?,
+    currency = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+type UpdateSiteFormatParams struct {
+	FormatLocale string `json:"format_locale"`
+	Currency     string `json:"currency"`
+}
+
+// Updates the locale and currency templates format numbers and prices with.
+//
+// Parameters:
+//
+//	$1: format_locale - BCP 47 tag (e.g. "de-DE"), validated by services.IsFormatLocale
+//	$2: currency - ISO 4217 code (e.g. "EUR"), validated by services.IsCurrency
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Use case: General tab of the admin global settings page
+func (q *Queries) UpdateSiteFormat(ctx context.Context, arg UpdateSiteFormatParams) error {
+	_, err := q.db.ExecContext(ctx, updateSiteFormat, arg.FormatLocale, arg.Currency)
+	return err
+}
+
+const updateSiteTimezone = `-- name: UpdateSiteTimezone :exec
+UPDATE settings
+SET timezone = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+// Updates the site timezone used to display timestamps.
+//
+// Parameters:
+//
+//	$1: timezone - IANA zone name (e.g. "Europe/Berlin"), validated by services.LoadTimezone
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Use case: General tab of the admin global settings page
+func (q *Queries) UpdateSiteTimezone(ctx context.Context, timezone string) error {
+	_, err := q.db.ExecContext(ctx, updateSiteTimezone, timezone)
+	return err
+}
+
 const updateSolutionsSettings = `-- name: UpdateSolutionsSettings :exec
 UPDATE settings
 SET solutions_per_page = ?,
@@ -677,3 +1153,90 @@ func (q *Queries) UpdateSolutionsSettings(ctx context.Context, arg UpdateSolutio
 	_, err := q.db.ExecContext(ctx, updateSolutionsSettings, arg.SolutionsPerPage, arg.SolutionsShowIndustries, arg.SolutionsShowSearch)
 	return err
 }
+
+const updateTLSDomains = `-- name: UpdateTLSDomains :exec
+UPDATE settings
+SET tls_domains = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+// Sets the host names the built-in HTTPS server obtains certificates for.
+//
+// Parameters:
+//
+//	$1: tls_domains - Host names, one per line, validated by services.ParseHostNames; blank uses TLS_DOMAINS
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Use case: HTTPS Domains admin page
+func (q *Queries) UpdateTLSDomains(ctx context.Context, tlsDomains string) error {
+	_, err := q.db.ExecContext(ctx, updateTLSDomains, tlsDomains)
+	return err
+}
+
+const updateThemeSettings = `-- name: UpdateThemeSettings :exec
+UPDATE settings
+SET theme_mode = ?,
+    theme_light_primary = ?,
+    theme_light_primary_hover = ?,
+    theme_light_background = ?,
+    theme_light_surface = ?,
+    theme_light_text = ?,
+    theme_light_border = ?,
+    theme_dark_primary = ?,
+    theme_dark_primary_hover = ?,
+    theme_dark_background = ?,
+    theme_dark_surface = ?,
+    theme_dark_text = ?,
+    theme_dark_border = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = 1
+`
+
+type UpdateThemeSettingsParams struct {
+	ThemeMode              string `json:"theme_mode"`
+	ThemeLightPrimary      string `json:"theme_light_primary"`
+	ThemeLightPrimaryHover string `json:"theme_light_primary_hover"`
+	ThemeLightBackground   string `json:"theme_light_background"`
+	ThemeLightSurface      string `json:"theme_light_surface"`
+	ThemeLightText         string `json:"theme_light_text"`
+	ThemeLightBorder       string `json:"theme_light_border"`
+	ThemeDarkPrimary       string `json:"theme_dark_primary"`
+	ThemeDarkPrimaryHover  string `json:"theme_dark_primary_hover"`
+	ThemeDarkBackground    string `json:"theme_dark_background"`
+	ThemeDarkSurface       string `json:"theme_dark_surface"`
+	ThemeDarkText          string `json:"theme_dark_text"`
+	ThemeDarkBorder        string `json:"theme_dark_border"`
+}
+
+// Updates the theme tokens served to the public and admin layouts.
+//
+// Parameters:
+//
+//	$1: theme_mode - Default theme for new visitors ("light", "dark", "system")
+//	$2-$7: Light mode colors (primary, primary hover, background, surface, text, border)
+//	$8-$13: Dark mode colors (primary, primary hover, background, surface, text, border)
+//
+// Returns: (none) - sqlc annotation :exec returns only row count
+//
+// Use case: Theme tab of the admin global settings page
+// Note: Values are #RRGGBB hex colors, validated by services.ThemeFromSettings
+func (q *Queries) UpdateThemeSettings(ctx context.Context, arg UpdateThemeSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, updateThemeSettings,
+		arg.ThemeMode,
+		arg.ThemeLightPrimary,
+		arg.ThemeLightPrimaryHover,
+		arg.ThemeLightBackground,
+		arg.ThemeLightSurface,
+		arg.ThemeLightText,
+		arg.ThemeLightBorder,
+		arg.ThemeDarkPrimary,
+		arg.ThemeDarkPrimaryHover,
+		arg.ThemeDarkBackground,
+		arg.ThemeDarkSurface,
+		arg.ThemeDarkText,
+		arg.ThemeDarkBorder,
+	)
+	return err
+}
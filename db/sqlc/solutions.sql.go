@@ -48,8 +48,8 @@ func (q *Queries) AddProductToSolution(ctx context.Context, arg AddProductToSolu
 
 const countSolutionsAdminFiltered = `-- name: CountSolutionsAdminFiltered :one
 SELECT COUNT(*) FROM solutions
-WHERE
-    (CASE WHEN ?1 = '' THEN 1 ELSE
+WHERE deleted_at IS NULL
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE
         (CASE WHEN ?1 = 'published' THEN is_published = 1
               WHEN ?1 = 'draft' THEN (is_published = 0 OR is_published IS NULL)
               ELSE 1 END)
@@ -81,7 +81,7 @@ INSERT INTO solutions (
     hero_description, overview_content, meta_description, reference_code,
     is_published, display_order
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image
+RETURNING id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image, deleted_at
 `
 
 type CreateSolutionParams struct {
@@ -153,6 +153,7 @@ func (q *Queries) CreateSolution(ctx context.Context, arg CreateSolutionParams)
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -591,7 +592,7 @@ func (q *Queries) GetActiveSolutionsListingCTA(ctx context.Context) (SolutionsLi
 }
 
 const getSolutionByID = `-- name: GetSolutionByID :one
-SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image FROM solutions WHERE id = ?
+SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image, deleted_at FROM solutions WHERE id = ?
 `
 
 // Retrieves a single solution by its primary key ID (any status).
@@ -625,13 +626,14 @@ func (q *Queries) GetSolutionByID(ctx context.Context, id int64) (Solution, erro
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getSolutionBySlug = `-- name: GetSolutionBySlug :one
-SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image FROM solutions
-WHERE slug = ? AND is_published = 1
+SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image, deleted_at FROM solutions
+WHERE slug = ? AND is_published = 1 AND deleted_at IS NULL
 LIMIT 1
 `
 
@@ -669,13 +671,14 @@ func (q *Queries) GetSolutionBySlug(ctx context.Context, slug string) (Solution,
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getSolutionBySlugIncludeDrafts = `-- name: GetSolutionBySlugIncludeDrafts :one
-SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image FROM solutions
-WHERE slug = ?
+SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image, deleted_at FROM solutions
+WHERE slug = ? AND deleted_at IS NULL
 LIMIT 1
 `
 
@@ -710,6 +713,7 @@ func (q *Queries) GetSolutionBySlugIncludeDrafts(ctx context.Context, slug strin
 		&i.UpdatedAt,
 		&i.MetaTitle,
 		&i.OgImage,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -826,7 +830,7 @@ SELECT sp.id, sp.solution_id, sp.product_id, sp.display_order, sp.is_featured,
        p.primary_image AS product_image, p.status AS product_status
 FROM solution_products sp
 JOIN products p ON sp.product_id = p.id
-WHERE sp.solution_id = ? AND p.status = 'published'
+WHERE sp.solution_id = ? AND p.status = 'published' AND p.deleted_at IS NULL
 ORDER BY sp.display_order ASC
 `
 
@@ -992,7 +996,7 @@ func (q *Queries) ListAllSolutionPageFeatures(ctx context.Context) ([]SolutionPa
 }
 
 const listAllSolutions = `-- name: ListAllSolutions :many
-SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image FROM solutions ORDER BY display_order ASC, title ASC
+SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image, deleted_at FROM solutions WHERE deleted_at IS NULL ORDER BY display_order ASC, title ASC
 `
 
 // Retrieves all solutions (published and draft) for admin dashboard.
@@ -1033,6 +1037,7 @@ func (q *Queries) ListAllSolutions(ctx context.Context) ([]Solution, error) {
 			&i.UpdatedAt,
 			&i.MetaTitle,
 			&i.OgImage,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1052,7 +1057,7 @@ const listPublishedSolutions = `-- name: ListPublishedSolutions :many
 
 SELECT id, title, slug, icon, short_description, display_order, created_at, updated_at
 FROM solutions
-WHERE is_published = 1
+WHERE is_published = 1 AND deleted_at IS NULL
 ORDER BY display_order ASC, title ASC
 `
 
@@ -1188,9 +1193,9 @@ func (q *Queries) ListSolutionPageFeatures(ctx context.Context) ([]SolutionPageF
 
 const listSolutionsAdminFiltered = `-- name: ListSolutionsAdminFiltered :many
 
-SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image FROM solutions
-WHERE
-    (CASE WHEN ?1 = '' THEN 1 ELSE
+SELECT id, title, slug, icon, short_description, hero_image_url, hero_title, hero_description, overview_content, meta_description, reference_code, is_published, display_order, created_at, updated_at, meta_title, og_image, deleted_at FROM solutions
+WHERE deleted_at IS NULL
+    AND (CASE WHEN ?1 = '' THEN 1 ELSE
         (CASE WHEN ?1 = 'published' THEN is_published = 1
               WHEN ?1 = 'draft' THEN (is_published = 0 OR is_published IS NULL)
               ELSE 1 END)
@@ -1264,6 +1269,7 @@ func (q *Queries) ListSolutionsAdminFiltered(ctx context.Context, arg ListSoluti
 			&i.UpdatedAt,
 			&i.MetaTitle,
 			&i.OgImage,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -4,7 +4,8 @@
 #
 # What it does:
 #   1. Cross-compiles a static linux/amd64 binary (pure Go, no CGO).
-#   2. Syncs the binary + templates/ + db/migrations/ + public/ (CSS/JS) to the server.
+#   2. Syncs the binary + templates/ + public/ (CSS/JS) to the server. Migrations
+#      are embedded in the binary and applied when the service restarts.
 #      -> It NEVER touches the production database (bluejay.db) or user-uploaded
 #         files (public/uploads/), so your live content and images are preserved.
 #   3. Restarts the systemd service and runs health checks.
@@ -71,9 +72,6 @@ say "Uploading binary"
 say "Syncing templates/"
 "${RSYNC[@]}" --delete templates/ "$SSH_TARGET:$REMOTE_DIR/templates/" || die "templates sync failed"
 
-say "Syncing db/migrations/"
-"${RSYNC[@]}" --delete db/migrations/ "$SSH_TARGET:$REMOTE_DIR/db/migrations/" || die "migrations sync failed"
-
 say "Syncing public/ (excluding uploads/)"
 "${RSYNC[@]}" --delete --exclude 'uploads/' public/ "$SSH_TARGET:$REMOTE_DIR/public/" || die "public sync failed"
 
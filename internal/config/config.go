@@ -0,0 +1,495 @@
+// Package config loads the server configuration. Every setting has an
+// environment variable (DB_PATH, SESSION_SECRET, ...) and may also be given
+// in an optional YAML or TOML file named by CONFIG_FILE, using the variable
+// name in lower case as the key (db_path, session_secret, ...). Environment
+// variables override the file, so secrets can stay out of it. Load parses
+// and validates everything up front and reports every problem at once, so a
+// bad deployment fails at startup instead of halfway through a request.
+//
+// The OpenTelemetry OTEL_* variables are read by the tracing package and the
+// OpenTelemetry SDK directly and are not part of Config.
+package config
+
+import (
+	"errors"        // Collecting validation problems
+	"fmt"           // Error messages
+	"net/netip"     // Validating trusted proxy addresses
+	"net/url"       // Validating URL settings
+	"os"            // Reading the config file and checking directories
+	"path/filepath" // Locating the database directory
+	"sort"          // Stable order for unknown-key errors
+	"strconv"       // Parsing numeric and boolean settings
+	"strings"       // Splitting list-valued settings
+	"time"          // Duration settings
+
+	"github.com/BurntSushi/toml" // TOML config files
+	"gopkg.in/yaml.v3"           // YAML config files
+)
+
+// MinSessionSecretLen is the shortest SESSION_SECRET accepted. The secret
+// keys the session cookie store and signs preview links and the recently
+// viewed cookie.
+const MinSessionSecretLen = 32
+
+// SMTP holds the outgoing mail settings; mail is disabled when Host is empty.
+type SMTP struct {
+	Host     string // SMTP_HOST
+	Port     string // SMTP_PORT (the mailer defaults to 587)
+	Username string // SMTP_USERNAME
+	Password string // SMTP_PASSWORD
+	From     string // SMTP_FROM
+}
+
+// Newsletter holds the mailing list provider confirmed newsletter
+// subscribers are handed to; subscribers are only kept in the database when
+// Provider is empty.
+type Newsletter struct {
+	Provider string // NEWSLETTER_PROVIDER, "mailchimp" or empty
+	APIKey   string // NEWSLETTER_API_KEY, e.g. a Mailchimp key ending in -us6
+	ListID   string // NEWSLETTER_LIST_ID, the provider's list (Mailchimp audience) ID
+}
+
+// Config is the typed server configuration. Zero values are never left for
+// the caller to interpret: Load fills in the documented defaults.
+type Config struct {
+	// Server
+	Port        string   // PORT, default "28090"
+	SiteBaseURL string   // SITE_BASE_URL, used for absolute links in the sitemap and emails
+	SiteLocales []string // SITE_LOCALES, source language first, default ["en"]
+
+	// Built-in HTTPS, enabled by TLS_ENABLED or TLS_DOMAINS (see TLSEnabled)
+	TLS         bool     // TLS_ENABLED, serve HTTPS for the host names saved on the HTTPS Domains admin page
+	TLSDomains  []string // TLS_DOMAINS, hosts Let's Encrypt certificates are obtained for while none are saved
+	TLSEmail    string   // TLS_EMAIL, contact address for the Let's Encrypt account
+	TLSCacheDir string   // TLS_CACHE_DIR, default "data/certs"
+	HTTPSPort   string   // HTTPS_PORT, default "443"
+	HTTPPort    string   // HTTP_PORT, default "80": certificate challenges and redirects to HTTPS
+
+	// Client addresses: X-Forwarded-For is only believed from these proxies
+	TrustedProxies []string // TRUSTED_PROXIES, addresses or CIDR ranges, default loopback (Caddy on the same host)
+
+	// Database
+	DBPath               string        // DB_PATH, default "bluejay.db"
+	DBReportingPath      string        // DB_REPORTING_PATH, default DBPath
+	DBAutoCheckpoint     bool          // DB_AUTOCHECKPOINT, default true
+	DBCheckpointHook     string        // DB_CHECKPOINT_HOOK, run via sh -c after checkpoints
+	DBCheckpointMode     string        // DB_CHECKPOINT_MODE, default PASSIVE (set by the replication job)
+	DBCheckpointInterval time.Duration // DB_CHECKPOINT_INTERVAL_SECONDS, default 10s
+	DBAlertWebhook       string        // DB_ALERT_WEBHOOK, POSTed on read-only mode changes
+	MigrateOnStart       bool          // MIGRATE_ON_START, default true; false requires cmd/migrate up
+
+	// Sessions
+	SessionSecret      string        // SESSION_SECRET, required, at least MinSessionSecretLen bytes
+	SessionIdleTimeout time.Duration // SESSION_IDLE_TIMEOUT_MINUTES, default 60m, 0 disables
+	SessionMaxLifetime time.Duration // SESSION_MAX_LIFETIME_HOURS, default 12h, 0 disables
+	AdminIPBypassToken string        // ADMIN_IP_BYPASS_TOKEN, lets /admin?admin_bypass=<token> past the admin IP rules
+
+	// Files
+	UploadDir        string // UPLOAD_DIR, served at /uploads, default "public/uploads"
+	MediaImportDir   string // MEDIA_IMPORT_DIR, default "data/media-import"
+	ArchiveDir       string // ARCHIVE_DIR, default "data/archives"
+	ExportDir        string // EXPORT_DIR, default "data/exports"
+	BackupDir        string // BACKUP_DIR, default "data/backups"
+	ApplicationDir   string // APPLICATION_DIR, job application CVs, default "data/applications"
+	GatedDownloadDir string // GATED_DOWNLOAD_DIR, files of gated product downloads, default "data/gated-downloads"
+
+	// Retention and background jobs
+	ArchiveRetentionMonths   int           // ARCHIVE_RETENTION_MONTHS, default 24, 0 keeps rows forever
+	ArchiveHashChain         bool          // ARCHIVE_HASH_CHAIN, default true
+	ActivityLogRetentionDays int           // ACTIVITY_LOG_RETENTION_DAYS, default 0 (disabled)
+	TrashRetentionDays       int           // TRASH_RETENTION_DAYS, default 30, 0 never purges
+	ExportLinkTTL            time.Duration // EXPORT_LINK_TTL_HOURS, default 24h
+	CacheWarmInterval        time.Duration // CACHE_WARM_INTERVAL_MINUTES, default 5m, 0 disables
+	BackupInterval           time.Duration // BACKUP_INTERVAL_HOURS, default 24h, 0 disables scheduled backups
+	BackupKeep               int           // BACKUP_KEEP, scheduled backups kept, default 7
+
+	// Content
+	HTMLAllowElements    []string // HTML_ALLOW_ELEMENTS, extra sanitizer elements
+	HTMLAllowAttributes  []string // HTML_ALLOW_ATTRIBUTES, extra element:attribute pairs
+	HTMLIframeHosts      []string // HTML_IFRAME_HOSTS, hosts allowed in iframe embeds
+	SafeHTMLAudit        bool     // SAFEHTML_AUDIT, development aid, default false
+	WorkflowPermissions  string   // WORKFLOW_PERMISSIONS, parsed by services.ParseWorkflowPolicy
+	PublishOverrideRoles []string // PUBLISH_OVERRIDE_ROLES, default ["admin"]
+
+	// Analytics
+	AnalyticsGeoIPCSV      string // ANALYTICS_GEOIP_CSV, IP range to country CSV, default none
+	AnalyticsCountryHeader string // ANALYTICS_COUNTRY_HEADER, CDN country header such as CF-IPCountry
+
+	SMTP       SMTP
+	Newsletter Newsletter
+}
+
+// keys lists every setting read by Load and by the doctor command (which
+// also checks REDIS_URL). Config files may only use these, in lower case,
+// which catches typos that would otherwise be ignored.
+var keys = []string{
+	"PORT", "SITE_BASE_URL", "SITE_LOCALES",
+	"TLS_ENABLED", "TLS_DOMAINS", "TLS_EMAIL", "TLS_CACHE_DIR", "HTTPS_PORT", "HTTP_PORT", "TRUSTED_PROXIES",
+	"DB_PATH", "DB_REPORTING_PATH", "DB_AUTOCHECKPOINT", "DB_CHECKPOINT_HOOK",
+	"DB_CHECKPOINT_MODE", "DB_CHECKPOINT_INTERVAL_SECONDS", "DB_ALERT_WEBHOOK", "MIGRATE_ON_START",
+	"SESSION_SECRET", "SESSION_IDLE_TIMEOUT_MINUTES", "SESSION_MAX_LIFETIME_HOURS", "ADMIN_IP_BYPASS_TOKEN",
+	"UPLOAD_DIR", "MEDIA_IMPORT_DIR", "ARCHIVE_DIR", "EXPORT_DIR", "BACKUP_DIR", "APPLICATION_DIR", "GATED_DOWNLOAD_DIR",
+	"ARCHIVE_RETENTION_MONTHS", "ARCHIVE_HASH_CHAIN", "ACTIVITY_LOG_RETENTION_DAYS",
+	"TRASH_RETENTION_DAYS", "EXPORT_LINK_TTL_HOURS", "CACHE_WARM_INTERVAL_MINUTES",
+	"BACKUP_INTERVAL_HOURS", "BACKUP_KEEP",
+	"HTML_ALLOW_ELEMENTS", "HTML_ALLOW_ATTRIBUTES", "HTML_IFRAME_HOSTS", "SAFEHTML_AUDIT",
+	"WORKFLOW_PERMISSIONS", "PUBLISH_OVERRIDE_ROLES",
+	"ANALYTICS_GEOIP_CSV", "ANALYTICS_COUNTRY_HEADER",
+	"SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD", "SMTP_FROM",
+	"NEWSLETTER_PROVIDER", "NEWSLETTER_API_KEY", "NEWSLETTER_LIST_ID",
+	"REDIS_URL",
+}
+
+// Load reads the configuration from the environment and, when CONFIG_FILE is
+// set, from that file, then validates it. The returned error lists every
+// invalid or missing value, one per line.
+//
+// Parameters:
+//   - getenv: Environment lookup, normally os.Getenv (tests pass a map)
+//
+// Returns:
+//   - Config: The configuration, with defaults for unset values. It is
+//     returned even with an error (without the file's values when the file
+//     cannot be read), so the doctor command can still check paths
+//   - error: Unreadable config file, or the joined validation errors
+//
+// Example usage:
+//
+//	cfg, err := config.Load(os.Getenv)
+//	if err != nil {
+//	    logger.Error("invalid configuration", "error", err)
+//	    os.Exit(1)
+//	}
+func Load(getenv func(string) string) (Config, error) {
+	lookup, err := Lookup(getenv)
+	if err != nil {
+		cfg, _ := Parse(getenv)
+		return cfg, err
+	}
+	cfg, err := Parse(lookup)
+	return cfg, errors.Join(err, cfg.Validate())
+}
+
+// Lookup returns a lookup function that resolves a setting from getenv and
+// falls back to the file named by CONFIG_FILE. The doctor command uses it to
+// check the same values the server will see.
+func Lookup(getenv func(string) string) (func(string) string, error) {
+	path := getenv("CONFIG_FILE")
+	if path == "" {
+		return getenv, nil
+	}
+	file, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return func(key string) string {
+		if v := getenv(key); v != "" {
+			return v
+		}
+		return file[key]
+	}, nil
+}
+
+// readFile parses a YAML (.yaml, .yml) or TOML (.toml) file of top-level
+// settings into environment-style values: keys upper-cased, lists joined
+// with commas, numbers and booleans formatted as text.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	known := map[string]bool{}
+	for _, k := range keys {
+		known[k] = true
+	}
+	values := map[string]string{}
+	var unknown []string
+	for k, v := range raw {
+		key := strings.ToUpper(k)
+		if !known[key] {
+			unknown = append(unknown, k)
+			continue
+		}
+		s, err := fileValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %s: %w", path, k, err)
+		}
+		values[key] = s
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("config file %s: unknown settings: %s", path, strings.Join(unknown, ", "))
+	}
+	return values, nil
+}
+
+// fileValue formats a scalar or a list of scalars from a config file.
+func fileValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool, int, int64, float64, uint64:
+		return fmt.Sprint(v), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("expected a value or a list, got %T", v)
+	}
+}
+
+// parser converts settings and collects the values that do not parse.
+type parser struct {
+	lookup func(string) string
+	errs   []error
+}
+
+func (p *parser) str(key, def string) string {
+	if v := p.lookup(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func (p *parser) list(key string, def []string) []string {
+	v := p.lookup(key)
+	if v == "" {
+		return def
+	}
+	items := strings.Split(v, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+func (p *parser) boolean(key string, def bool) bool {
+	v := p.lookup(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s=%q is not true or false", key, v))
+		return def
+	}
+	return b
+}
+
+// count parses a non-negative integer, or a positive one when min is 1.
+func (p *parser) count(key string, def, min int) int {
+	v := p.lookup(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min {
+		kind := "a non-negative integer"
+		if min > 0 {
+			kind = "a positive integer"
+		}
+		p.errs = append(p.errs, fmt.Errorf("%s=%q is not %s", key, v, kind))
+		return def
+	}
+	return n
+}
+
+func (p *parser) duration(key string, def time.Duration, unit time.Duration, min int) time.Duration {
+	return time.Duration(p.count(key, int(def/unit), min)) * unit
+}
+
+// Parse reads every setting through lookup and fills in defaults. It reports
+// values that do not parse (a non-numeric retention, say) but does not check
+// required values; see Validate.
+func Parse(lookup func(string) string) (Config, error) {
+	p := &parser{lookup: lookup}
+	cfg := Config{
+		Port:        p.str("PORT", "28090"),
+		SiteBaseURL: p.str("SITE_BASE_URL", "https://newsite.bluejayinnolabs.com"),
+		SiteLocales: p.list("SITE_LOCALES", []string{"en"}),
+
+		TLS:         p.boolean("TLS_ENABLED", false),
+		TLSDomains:  p.list("TLS_DOMAINS", nil),
+		TLSEmail:    p.str("TLS_EMAIL", ""),
+		TLSCacheDir: p.str("TLS_CACHE_DIR", "data/certs"),
+		HTTPSPort:   p.str("HTTPS_PORT", "443"),
+		HTTPPort:    p.str("HTTP_PORT", "80"),
+
+		TrustedProxies: p.list("TRUSTED_PROXIES", []string{"127.0.0.1", "::1"}),
+
+		DBPath:               p.str("DB_PATH", "bluejay.db"),
+		DBAutoCheckpoint:     p.boolean("DB_AUTOCHECKPOINT", true),
+		DBCheckpointHook:     p.str("DB_CHECKPOINT_HOOK", ""),
+		DBCheckpointMode:     p.str("DB_CHECKPOINT_MODE", ""),
+		DBCheckpointInterval: p.duration("DB_CHECKPOINT_INTERVAL_SECONDS", 10*time.Second, time.Second, 1),
+		DBAlertWebhook:       p.str("DB_ALERT_WEBHOOK", ""),
+		MigrateOnStart:       p.boolean("MIGRATE_ON_START", true),
+
+		SessionSecret:      p.str("SESSION_SECRET", ""),
+		SessionIdleTimeout: p.duration("SESSION_IDLE_TIMEOUT_MINUTES", 60*time.Minute, time.Minute, 0),
+		SessionMaxLifetime: p.duration("SESSION_MAX_LIFETIME_HOURS", 12*time.Hour, time.Hour, 0),
+		AdminIPBypassToken: p.str("ADMIN_IP_BYPASS_TOKEN", ""),
+
+		UploadDir:        p.str("UPLOAD_DIR", "public/uploads"),
+		MediaImportDir:   p.str("MEDIA_IMPORT_DIR", "data/media-import"),
+		ArchiveDir:       p.str("ARCHIVE_DIR", "data/archives"),
+		ExportDir:        p.str("EXPORT_DIR", "data/exports"),
+		BackupDir:        p.str("BACKUP_DIR", "data/backups"),
+		ApplicationDir:   p.str("APPLICATION_DIR", "data/applications"),
+		GatedDownloadDir: p.str("GATED_DOWNLOAD_DIR", "data/gated-downloads"),
+
+		ArchiveRetentionMonths:   p.count("ARCHIVE_RETENTION_MONTHS", 24, 0),
+		ArchiveHashChain:         p.boolean("ARCHIVE_HASH_CHAIN", true),
+		ActivityLogRetentionDays: p.count("ACTIVITY_LOG_RETENTION_DAYS", 0, 0),
+		TrashRetentionDays:       p.count("TRASH_RETENTION_DAYS", 30, 0),
+		ExportLinkTTL:            p.duration("EXPORT_LINK_TTL_HOURS", 24*time.Hour, time.Hour, 1),
+		CacheWarmInterval:        p.duration("CACHE_WARM_INTERVAL_MINUTES", 5*time.Minute, time.Minute, 0),
+		BackupInterval:           p.duration("BACKUP_INTERVAL_HOURS", 24*time.Hour, time.Hour, 0),
+		BackupKeep:               p.count("BACKUP_KEEP", 7, 1),
+
+		HTMLAllowElements:    p.list("HTML_ALLOW_ELEMENTS", nil),
+		HTMLAllowAttributes:  p.list("HTML_ALLOW_ATTRIBUTES", nil),
+		HTMLIframeHosts:      p.list("HTML_IFRAME_HOSTS", nil),
+		SafeHTMLAudit:        p.boolean("SAFEHTML_AUDIT", false),
+		WorkflowPermissions:  p.str("WORKFLOW_PERMISSIONS", ""),
+		PublishOverrideRoles: p.list("PUBLISH_OVERRIDE_ROLES", []string{"admin"}),
+
+		AnalyticsGeoIPCSV:      p.str("ANALYTICS_GEOIP_CSV", ""),
+		AnalyticsCountryHeader: p.str("ANALYTICS_COUNTRY_HEADER", ""),
+
+		SMTP: SMTP{
+			Host:     p.str("SMTP_HOST", ""),
+			Port:     p.str("SMTP_PORT", ""),
+			Username: p.str("SMTP_USERNAME", ""),
+			Password: p.str("SMTP_PASSWORD", ""),
+			From:     p.str("SMTP_FROM", ""),
+		},
+		Newsletter: Newsletter{
+			Provider: strings.ToLower(p.str("NEWSLETTER_PROVIDER", "")),
+			APIKey:   p.str("NEWSLETTER_API_KEY", ""),
+			ListID:   p.str("NEWSLETTER_LIST_ID", ""),
+		},
+	}
+	cfg.DBReportingPath = p.str("DB_REPORTING_PATH", cfg.DBPath)
+	return cfg, errors.Join(p.errs...)
+}
+
+// TLSEnabled reports whether the server serves HTTPS itself, with
+// certificates from Let's Encrypt, instead of plain HTTP on PORT. Setting
+// TLS_DOMAINS implies it.
+func (c Config) TLSEnabled() bool {
+	return c.TLS || len(c.TLSDomains) > 0
+}
+
+// Validate checks the values the server cannot start without: a strong
+// session secret, a database path in an existing directory, a usable upload
+// directory, a valid port, and well-formed locales and URLs.
+func (c Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if n, err := strconv.Atoi(c.Port); err != nil || n < 1 || n > 65535 {
+		add("PORT=%q is not a port between 1 and 65535", c.Port)
+	}
+	if c.TLSEnabled() {
+		for _, d := range c.TLSDomains {
+			if d == "" || strings.ContainsAny(d, ":/ ") || !strings.Contains(d, ".") {
+				add("TLS_DOMAINS contains %q; list host names such as example.com, separated by commas", d)
+			}
+		}
+		for key, port := range map[string]string{"HTTPS_PORT": c.HTTPSPort, "HTTP_PORT": c.HTTPPort} {
+			if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+				add("%s=%q is not a port between 1 and 65535", key, port)
+			}
+		}
+		if c.HTTPSPort == c.HTTPPort {
+			add("HTTPS_PORT and HTTP_PORT are both %s", c.HTTPSPort)
+		}
+		if c.TLSEmail != "" && !strings.Contains(c.TLSEmail, "@") {
+			add("TLS_EMAIL=%q is not an email address", c.TLSEmail)
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			add("TRUSTED_PROXIES contains %q; list IP addresses or CIDR ranges, separated by commas", proxy)
+		}
+	}
+	if u, err := url.Parse(c.SiteBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("SITE_BASE_URL=%q is not an http(s) URL", c.SiteBaseURL)
+	}
+	for _, l := range c.SiteLocales {
+		if l == "" {
+			add("SITE_LOCALES contains an empty locale; list locales separated by commas, source language first")
+			break
+		}
+	}
+
+	if strings.TrimSpace(c.SessionSecret) == "" {
+		add("SESSION_SECRET is required; generate one with: openssl rand -base64 48")
+	} else if len(c.SessionSecret) < MinSessionSecretLen {
+		add("SESSION_SECRET must be at least %d characters, got %d", MinSessionSecretLen, len(c.SessionSecret))
+	}
+	if c.AdminIPBypassToken != "" && len(c.AdminIPBypassToken) < MinSessionSecretLen {
+		add("ADMIN_IP_BYPASS_TOKEN must be at least %d characters, got %d; generate one with: openssl rand -hex 24", MinSessionSecretLen, len(c.AdminIPBypassToken))
+	}
+
+	if info, err := os.Stat(filepath.Dir(c.DBPath)); err != nil || !info.IsDir() {
+		add("DB_PATH=%q: directory %s does not exist", c.DBPath, filepath.Dir(c.DBPath))
+	} else if info, err := os.Stat(c.DBPath); err == nil && info.IsDir() {
+		add("DB_PATH=%q is a directory, not a database file", c.DBPath)
+	}
+	if info, err := os.Stat(c.UploadDir); err != nil {
+		add("UPLOAD_DIR=%q does not exist", c.UploadDir)
+	} else if !info.IsDir() {
+		add("UPLOAD_DIR=%q is not a directory", c.UploadDir)
+	}
+
+	if c.AnalyticsGeoIPCSV != "" {
+		if info, err := os.Stat(c.AnalyticsGeoIPCSV); err != nil || info.IsDir() {
+			add("ANALYTICS_GEOIP_CSV=%q is not a file", c.AnalyticsGeoIPCSV)
+		}
+	}
+	switch c.Newsletter.Provider {
+	case "":
+	case "mailchimp":
+		if i := strings.LastIndex(c.Newsletter.APIKey, "-"); i <= 0 || i == len(c.Newsletter.APIKey)-1 {
+			add("NEWSLETTER_API_KEY must be a Mailchimp API key ending in its data center, e.g. -us6")
+		}
+		if c.Newsletter.ListID == "" {
+			add("NEWSLETTER_LIST_ID is required with NEWSLETTER_PROVIDER=mailchimp; it is the audience ID under Audience > Settings")
+		}
+	default:
+		add("NEWSLETTER_PROVIDER=%q is not supported; use mailchimp or leave it empty", c.Newsletter.Provider)
+	}
+	if c.DBAlertWebhook != "" {
+		if u, err := url.Parse(c.DBAlertWebhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("DB_ALERT_WEBHOOK is not an http(s) URL")
+		}
+	}
+	return errors.Join(errs...)
+}
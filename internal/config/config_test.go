@@ -0,0 +1,173 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/config"
+)
+
+// env returns a getenv over vars with valid required values for a temp dir.
+func env(t *testing.T, vars map[string]string) func(string) string {
+	t.Helper()
+	dir := t.TempDir()
+	all := map[string]string{
+		"SESSION_SECRET": strings.Repeat("s", config.MinSessionSecretLen),
+		"DB_PATH":        filepath.Join(dir, "bluejay.db"),
+		"UPLOAD_DIR":     dir,
+	}
+	for k, v := range vars {
+		all[k] = v
+	}
+	return func(key string) string { return all[key] }
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := config.Load(env(t, nil))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Port != "28090" || cfg.DBReportingPath != cfg.DBPath || !cfg.DBAutoCheckpoint || !cfg.ArchiveHashChain {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+	if cfg.SessionIdleTimeout != time.Hour || cfg.SessionMaxLifetime != 12*time.Hour || cfg.ExportLinkTTL != 24*time.Hour {
+		t.Errorf("unexpected duration defaults: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.TrustedProxies, []string{"127.0.0.1", "::1"}) {
+		t.Errorf("unexpected trusted proxies: %v", cfg.TrustedProxies)
+	}
+	if !reflect.DeepEqual(cfg.SiteLocales, []string{"en"}) || !reflect.DeepEqual(cfg.PublishOverrideRoles, []string{"admin"}) {
+		t.Errorf("unexpected list defaults: %v %v", cfg.SiteLocales, cfg.PublishOverrideRoles)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	_, err := config.Load(env(t, map[string]string{
+		"SESSION_SECRET":           "too-short",
+		"DB_PATH":                  "/does/not/exist/bluejay.db",
+		"UPLOAD_DIR":               "/does/not/exist",
+		"PORT":                     "http",
+		"ARCHIVE_RETENTION_MONTHS": "-1",
+		"DB_AUTOCHECKPOINT":        "sometimes",
+		"SITE_LOCALES":             "en,,de",
+		"ADMIN_IP_BYPASS_TOKEN":    "letmein",
+		"TRUSTED_PROXIES":          "127.0.0.1, caddy",
+	}))
+	if err == nil {
+		t.Fatal("Load accepted an invalid configuration")
+	}
+	for _, want := range []string{"SESSION_SECRET", "DB_PATH", "UPLOAD_DIR", "PORT", "ARCHIVE_RETENTION_MONTHS", "DB_AUTOCHECKPOINT", "SITE_LOCALES", "ADMIN_IP_BYPASS_TOKEN", "TRUSTED_PROXIES"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+
+	if _, err := config.Load(env(t, map[string]string{"SESSION_SECRET": ""})); err == nil || !strings.Contains(err.Error(), "SESSION_SECRET is required") {
+		t.Errorf("missing secret: %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"bluejay.yaml": "port: 8080\nsite_locales: [en, de]\ntrash_retention_days: 7\nsafehtml_audit: true\nsmtp_host: mail.example.com\n",
+		"bluejay.toml": "port = 8080\nsite_locales = [\"en\", \"de\"]\ntrash_retention_days = 7\nsafehtml_audit = true\nsmtp_host = \"mail.example.com\"\n",
+	}
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(body), 0o600)
+
+		// The environment overrides the file
+		cfg, err := config.Load(env(t, map[string]string{"CONFIG_FILE": path, "TRASH_RETENTION_DAYS": "3"}))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if cfg.Port != "8080" || !reflect.DeepEqual(cfg.SiteLocales, []string{"en", "de"}) || !cfg.SafeHTMLAudit || cfg.SMTP.Host != "mail.example.com" {
+			t.Errorf("%s: file values not applied: %+v", name, cfg)
+		}
+		if cfg.TrashRetentionDays != 3 {
+			t.Errorf("%s: TrashRetentionDays = %d, want the environment's 3", name, cfg.TrashRetentionDays)
+		}
+	}
+
+	typo := filepath.Join(dir, "typo.yaml")
+	os.WriteFile(typo, []byte("db_pth: x.db\n"), 0o600)
+	if _, err := config.Load(env(t, map[string]string{"CONFIG_FILE": typo})); err == nil || !strings.Contains(err.Error(), "db_pth") {
+		t.Errorf("unknown key: %v", err)
+	}
+
+	ini := filepath.Join(dir, "bluejay.ini")
+	os.WriteFile(ini, []byte("port=1\n"), 0o600)
+	cfg, err := config.Load(env(t, map[string]string{"CONFIG_FILE": ini}))
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("ini file: %v", err)
+	}
+	if cfg.Port != "28090" {
+		t.Errorf("config with an unreadable file = %+v, want defaults", cfg)
+	}
+}
+
+func TestLoadTLS(t *testing.T) {
+	cfg, err := config.Load(env(t, nil))
+	if err != nil || cfg.TLSEnabled() {
+		t.Fatalf("TLS enabled by default: %v", err)
+	}
+
+	cfg, err = config.Load(env(t, map[string]string{"TLS_DOMAINS": "example.com, www.example.com", "TLS_EMAIL": "ops@example.com"}))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.TLSEnabled() || !reflect.DeepEqual(cfg.TLSDomains, []string{"example.com", "www.example.com"}) {
+		t.Errorf("TLSDomains = %v", cfg.TLSDomains)
+	}
+	if cfg.HTTPSPort != "443" || cfg.HTTPPort != "80" || cfg.TLSCacheDir != "data/certs" {
+		t.Errorf("unexpected TLS defaults: %+v", cfg)
+	}
+
+	// The host names may come from the admin alone
+	cfg, err = config.Load(env(t, map[string]string{"TLS_ENABLED": "true"}))
+	if err != nil || !cfg.TLSEnabled() || len(cfg.TLSDomains) != 0 {
+		t.Errorf("TLS_ENABLED without TLS_DOMAINS: enabled=%v domains=%v err=%v", cfg.TLSEnabled(), cfg.TLSDomains, err)
+	}
+
+	_, err = config.Load(env(t, map[string]string{
+		"TLS_DOMAINS": "https://example.com",
+		"TLS_EMAIL":   "ops",
+		"HTTPS_PORT":  "8443",
+		"HTTP_PORT":   "8443",
+	}))
+	if err == nil {
+		t.Fatal("Load accepted an invalid TLS configuration")
+	}
+	for _, want := range []string{"TLS_DOMAINS", "TLS_EMAIL", "HTTPS_PORT and HTTP_PORT"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadNewsletter(t *testing.T) {
+	cfg, err := config.Load(env(t, map[string]string{
+		"NEWSLETTER_PROVIDER": "Mailchimp", "NEWSLETTER_API_KEY": "abc123-us6", "NEWSLETTER_LIST_ID": "a1b2c3",
+	}))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Newsletter.Provider != "mailchimp" || cfg.Newsletter.ListID != "a1b2c3" {
+		t.Errorf("Newsletter = %+v", cfg.Newsletter)
+	}
+
+	_, err = config.Load(env(t, map[string]string{"NEWSLETTER_PROVIDER": "mailchimp", "NEWSLETTER_API_KEY": "abc123"}))
+	for _, want := range []string{"NEWSLETTER_API_KEY", "NEWSLETTER_LIST_ID"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s: %v", want, err)
+		}
+	}
+	if _, err := config.Load(env(t, map[string]string{"NEWSLETTER_PROVIDER": "sendgrid"})); err == nil || !strings.Contains(err.Error(), "NEWSLETTER_PROVIDER") {
+		t.Errorf("unknown provider: %v", err)
+	}
+}
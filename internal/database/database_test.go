@@ -1,11 +1,15 @@
 package database_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
 
+	"github.com/narendhupati/bluejay-cms/db/migrations"
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
 	"github.com/narendhupati/bluejay-cms/internal/database"
 )
 
@@ -105,3 +109,221 @@ func findMigrationsDir(t *testing.T) string {
 	t.Skip("could not find migrations directory")
 	return ""
 }
+
+func TestCountingDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "count.db")
+	db, err := database.InitDB(database.Config{Path: dbPath})
+	if err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	defer database.Close(db)
+
+	counting := database.CountingDB(db)
+	ctx, count := database.WithQueryCounter(context.Background())
+
+	if _, err := counting.ExecContext(ctx, "CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	var n int
+	if err := counting.QueryRowContext(ctx, "SELECT COUNT(*) FROM t").Scan(&n); err != nil {
+		t.Fatalf("query row: %v", err)
+	}
+	// Queries without a counter in the context are not counted
+	if _, err := counting.ExecContext(context.Background(), "INSERT INTO t (id) VALUES (1)"); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	if got := count.Load(); got != 2 {
+		t.Errorf("expected 2 counted queries, got %d", got)
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	db, err := database.InitDB(database.Config{Path: filepath.Join(t.TempDir(), "tx.db")})
+	if err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	defer database.Close(db)
+	if err := database.RunMigrations(db, findMigrationsDir(t)); err != nil {
+		t.Fatalf("RunMigrations failed: %v", err)
+	}
+	ctx := context.Background()
+	count := func() int {
+		var n int
+		if err := db.QueryRow("SELECT COUNT(*) FROM blog_tags").Scan(&n); err != nil {
+			t.Fatalf("count tags: %v", err)
+		}
+		return n
+	}
+	before := count()
+
+	// An error rolls back the writes made before it
+	failed := errors.New("second write failed")
+	err = database.WithTx(ctx, db, func(q *sqlc.Queries) error {
+		if _, err := q.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Rolled back", Slug: "rolled-back"}); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected fn's error, got %v", err)
+	}
+	if got := count(); got != before {
+		t.Errorf("expected rollback to keep %d tags, got %d", before, got)
+	}
+
+	err = database.WithTx(ctx, db, func(q *sqlc.Queries) error {
+		if _, err := q.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "One", Slug: "one"}); err != nil {
+			return err
+		}
+		_, err := q.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Two", Slug: "two"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+	if got := count(); got != before+2 {
+		t.Errorf("expected %d tags after commit, got %d", before+2, got)
+	}
+}
+
+func TestWithDBTx(t *testing.T) {
+	db, err := database.InitDB(database.Config{Path: filepath.Join(t.TempDir(), "dbtx.db")})
+	if err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	defer database.Close(db)
+	ctx := context.Background()
+	if _, err := db.Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	failed := errors.New("import failed")
+	err = database.WithDBTx(ctx, db, func(tx database.DBTX) error {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO t (id) VALUES (1)"); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected fn's error, got %v", err)
+	}
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM t").Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected rollback to leave no rows, got %d", n)
+	}
+}
+
+func TestInitDB_Pragmas(t *testing.T) {
+	db, err := database.InitDB(database.Config{Path: filepath.Join(t.TempDir(), "pragmas.db"), DisableAutoCheckpoint: true})
+	if err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	defer database.Close(db)
+
+	for pragma, want := range map[string]string{
+		"journal_mode":       "wal",
+		"busy_timeout":       "5000",
+		"foreign_keys":       "1",
+		"synchronous":        "1",
+		"wal_autocheckpoint": "0",
+	} {
+		var got string
+		if err := db.QueryRow("PRAGMA " + pragma).Scan(&got); err != nil {
+			t.Fatalf("PRAGMA %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("PRAGMA %s = %s, want %s", pragma, got, want)
+		}
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "replica.db")
+	db, err := database.InitDB(database.Config{Path: dbPath})
+	if err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	defer database.Close(db)
+	if _, err := db.Exec("CREATE TABLE t (id INTEGER); INSERT INTO t (id) VALUES (1)"); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	replica, err := database.OpenReadOnly(database.Config{Path: dbPath})
+	if err != nil {
+		t.Fatalf("OpenReadOnly failed: %v", err)
+	}
+	defer database.Close(replica)
+
+	// An open read transaction on the replica does not block the primary's writes
+	tx, err := replica.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	var n int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM t").Scan(&n); err != nil || n != 1 {
+		t.Fatalf("replica read: n=%d err=%v", n, err)
+	}
+	if _, err := db.Exec("INSERT INTO t (id) VALUES (2)"); err != nil {
+		t.Errorf("primary write during a replica read: %v", err)
+	}
+	tx.Rollback()
+
+	if _, err := replica.Exec("INSERT INTO t (id) VALUES (3)"); err == nil {
+		t.Error("expected writes through the read-only pool to fail")
+	}
+	if err := replica.QueryRow("SELECT COUNT(*) FROM t").Scan(&n); err != nil || n != 2 {
+		t.Errorf("replica sees committed rows: n=%d err=%v", n, err)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	db, err := database.InitDB(database.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	defer database.Close(db)
+
+	m, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Current != 0 || status.Latest == 0 || len(status.Pending) != int(status.Latest) {
+		t.Fatalf("fresh database status = %+v", status)
+	}
+	latest := status.Latest
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatalf("second Up failed: %v", err)
+	}
+	if status, _ = m.Status(); status.Current != latest || len(status.Pending) != 0 || status.Dirty {
+		t.Fatalf("after Up status = %+v", status)
+	}
+
+	// Every down migration runs cleanly, and the schema can be rebuilt
+	if err := m.Down(2); err != nil {
+		t.Fatalf("Down(2) failed: %v", err)
+	}
+	if status, _ = m.Status(); status.Current != latest-2 || len(status.Pending) != 2 {
+		t.Fatalf("after Down(2) status = %+v", status)
+	}
+	if err := m.Down(0); err != nil {
+		t.Fatalf("Down(all) failed: %v", err)
+	}
+	if err := m.Goto(latest); err != nil {
+		t.Fatalf("Goto(%d) failed: %v", latest, err)
+	}
+	if status, _ = m.Status(); status.Current != latest {
+		t.Fatalf("after Goto status = %+v", status)
+	}
+}
@@ -2,7 +2,9 @@ package database
 
 import (
 	"database/sql" // Standard library SQL interface for database operations
+	"errors"       // Recognizing migrate's sentinel errors
 	"fmt"          // String formatting for error messages and file path construction
+	"io/fs"        // Migration sources: the embedded db/migrations or a directory
 
 	// golang-migrate/migrate/v4 is the main migration engine that orchestrates
 	// the execution of migration files in order, tracks which migrations have
@@ -18,6 +20,14 @@ import (
 	// from the filesystem. Imported with blank identifier to register the
 	// "file://" source driver with the migration engine.
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	// golang-migrate/migrate/v4/source is the source driver interface, used
+	// to list the versions a source contains.
+	"github.com/golang-migrate/migrate/v4/source"
+
+	// golang-migrate/migrate/v4/source/iofs reads migrations from an fs.FS,
+	// such as the migrations embedded in the binary (db/migrations.FS).
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
 // RunMigrations executes all pending database migrations in order, bringing
@@ -175,3 +185,122 @@ func RollbackMigration(db *sql.DB, migrationsPath string) error {
 
 	return nil
 }
+
+// Migrator applies the migrations in an fs.FS (normally the embedded
+// db/migrations.FS) to a database and reports its schema version. It backs
+// migrate-on-start in the server, the doctor's schema check, and the
+// cmd/migrate tool.
+type Migrator struct {
+	m      *migrate.Migrate // golang-migrate engine over the SQLite driver
+	source source.Driver    // Migration files, for listing versions
+}
+
+// MigrationStatus describes a database's schema version relative to the
+// migrations a build ships with.
+type MigrationStatus struct {
+	Current uint   // Applied version; 0 when no migration has run
+	Dirty   bool   // The Current migration failed part-way and needs repair
+	Latest  uint   // Newest version in the source
+	Pending []uint // Versions newer than Current, in order
+}
+
+// NewMigrator prepares migrations from migrations (files named
+// {version}_{description}.up.sql and .down.sql at its root) for db.
+//
+// The migrator must not be closed: golang-migrate would close db with it.
+//
+// Parameters:
+//   - db: Active database connection (must be already initialized)
+//   - migrations: Migration files, e.g. migrations.FS or os.DirFS("db/migrations")
+//
+// Returns:
+//   - *Migrator: Migrator for db
+//   - error: If the migration files or the schema_migrations table cannot be read
+//
+// Example usage:
+//
+//	m, err := database.NewMigrator(db, migrations.FS)
+//	if err != nil {
+//	    return err
+//	}
+//	status, err := m.Status()
+func NewMigrator(db *sql.DB, migrations fs.FS) (*Migrator, error) {
+	src, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	driver, err := sqlite.WithInstance(db, &sqlite.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration instance: %w", err)
+	}
+	return &Migrator{m: m, source: src}, nil
+}
+
+// Status reports the applied version and the migrations still pending.
+func (m *Migrator) Status() (MigrationStatus, error) {
+	var status MigrationStatus
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return status, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	status.Current, status.Dirty = version, dirty
+
+	v, err := m.source.First()
+	for err == nil {
+		status.Latest = v
+		if v > status.Current {
+			status.Pending = append(status.Pending, v)
+		}
+		v, err = m.source.Next(v)
+	}
+	if !errors.Is(err, fs.ErrNotExist) { // past the last migration
+		return status, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return status, nil
+}
+
+// Up applies every pending migration. It is a no-op when the schema is
+// current.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the newest steps migrations, or all of them when steps is
+// 0. Down migrations drop tables and data; they are meant for local
+// development, never for production databases.
+func (m *Migrator) Down(steps int) error {
+	var err error
+	if steps > 0 {
+		err = m.m.Steps(-steps)
+	} else {
+		err = m.m.Down()
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+	return nil
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(version uint) error {
+	if err := m.m.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate to %d: %w", version, err)
+	}
+	return nil
+}
+
+// Force records version as applied and clean without running anything. Use
+// it after repairing a dirty migration by hand.
+func (m *Migrator) Force(version int) error {
+	if err := m.m.Force(version); err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
+	}
+	return nil
+}
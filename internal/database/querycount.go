@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"      // Per-request counters travel in the query context
+	"database/sql" // Wrapped connection and result types
+	"sync/atomic"  // Counters may be shared by concurrent queries of one request
+)
+
+// DBTX is the subset of *sql.DB used by the sqlc-generated queries.
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+// queryCounterKey is the context key holding a request's *atomic.Int64 counter.
+type queryCounterKey struct{}
+
+// WithQueryCounter returns a context whose queries are counted when they run
+// through a CountingDB, along with the counter itself.
+//
+// Example usage:
+//
+//	ctx, count := database.WithQueryCounter(req.Context())
+//	c.SetRequest(req.WithContext(ctx))
+//	// ... handle the request ...
+//	slog.Debug("queries", "count", count.Load())
+func WithQueryCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := new(atomic.Int64)
+	return context.WithValue(ctx, queryCounterKey{}, counter), counter
+}
+
+// countingDB increments the context's query counter before each statement.
+type countingDB struct {
+	db DBTX
+}
+
+// CountingDB wraps db so that queries issued with a context from
+// WithQueryCounter are counted. Contexts without a counter cost one context
+// lookup per query and are otherwise unaffected.
+//
+// Parameters:
+//   - db: Connection passed to sqlc.New (typically the *sql.DB from InitDB)
+//
+// Returns:
+//   - DBTX: Wrapped connection for sqlc.New
+func CountingDB(db DBTX) DBTX {
+	return &countingDB{db: db}
+}
+
+func count(ctx context.Context) {
+	if counter, ok := ctx.Value(queryCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(1)
+	}
+}
+
+func (d *countingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	count(ctx)
+	return d.db.ExecContext(ctx, query, args...)
+}
+
+func (d *countingDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.db.PrepareContext(ctx, query)
+}
+
+func (d *countingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	count(ctx)
+	return d.db.QueryContext(ctx, query, args...)
+}
+
+func (d *countingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	count(ctx)
+	return d.db.QueryRowContext(ctx, query, args...)
+}
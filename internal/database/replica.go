@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql" // Standard library SQL interface for the read-only pool
+	"fmt"          // Error wrapping
+)
+
+// readOnlyConns is the size of a read-only pool. Readers never take the write
+// lock, so several can run at once alongside the single writer.
+const readOnlyConns = 4
+
+// OpenReadOnly opens a read-only connection pool for reporting queries
+// (exports, analytics) so long scans do not hold the primary's only
+// connection and delay page requests and admin saves.
+//
+// cfg.Path may be the primary database itself, which in WAL mode serves
+// readers from a consistent snapshot while the primary keeps writing, or a
+// replica restored by Litestream ("litestream restore" or a read replica
+// kept in sync on a reporting host). Connections are opened with mode=ro and
+// query_only, so a stray write fails instead of touching the file.
+//
+// Parameters:
+//   - cfg: Configuration containing the database or replica file path
+//     (DisableAutoCheckpoint is ignored)
+//
+// Returns:
+//   - *sql.DB: Read-only pool; pass it to sqlc.New for reporting queries
+//   - error: Any error opening or pinging the database
+//
+// Example usage:
+//
+//	replica, err := OpenReadOnly(Config{Path: "./data/cms.db"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer Close(replica)
+//	reports := sqlc.New(replica)
+func OpenReadOnly(cfg Config) (*sql.DB, error) {
+	dsn := "file:" + cfg.Path + "?mode=ro&_pragma=busy_timeout(5000)&_pragma=query_only(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open read-only database: %w", err)
+	}
+	db.SetMaxOpenConns(readOnlyConns)
+	db.SetMaxIdleConns(readOnlyConns)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping read-only database: %w", err)
+	}
+	return db, nil
+}
@@ -21,13 +21,28 @@ import (
 )
 
 // Config holds the configuration parameters for database initialization.
-// Currently only contains the file path, but can be extended with additional
-// settings like connection pool sizes, timeouts, or pragma configurations.
 type Config struct {
 	// Path is the filesystem path to the SQLite database file.
 	// If the file doesn't exist, SQLite will create it automatically.
 	// Use ":memory:" for an in-memory database (useful for testing).
 	Path string
+
+	// DisableAutoCheckpoint turns off SQLite's automatic WAL checkpoints
+	// (wal_autocheckpoint=0) so an external replicator such as Litestream,
+	// or the checkpoints of services.Replication, decide when the WAL is
+	// copied back into the database file.
+	DisableAutoCheckpoint bool
+}
+
+// DSN returns the modernc.org/sqlite data source name for cfg. Pragmas are
+// passed as _pragma=name(value) so the driver applies them to every
+// connection it opens, not only the first.
+func (cfg Config) DSN() string {
+	dsn := cfg.Path + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)&_pragma=synchronous(NORMAL)&_pragma=cache_size(-8000)"
+	if cfg.DisableAutoCheckpoint {
+		dsn += "&_pragma=wal_autocheckpoint(0)"
+	}
+	return dsn
 }
 
 // InitDB initializes and configures a SQLite database connection with
@@ -38,7 +53,7 @@ type Config struct {
 //   - Foreign key constraints enabled for referential integrity
 //   - Busy timeout of 5 seconds to handle concurrent access
 //   - NORMAL synchronous mode for balanced safety and performance
-//   - Cache size of 2000 pages (typically ~8MB) for better query performance
+//   - Cache size of ~8MB for better query performance
 //   - Single connection pool to respect SQLite's single-writer limitation
 //
 // Parameters:
@@ -56,29 +71,33 @@ type Config struct {
 //	}
 //	defer Close(db)
 func InitDB(cfg Config) (*sql.DB, error) {
-	// Construct the Data Source Name (DSN) with SQLite-specific pragmas.
-	// These pragmas are connection-level settings that configure SQLite's behavior:
+	// Construct the Data Source Name (DSN) with SQLite-specific pragmas (see
+	// Config.DSN). These pragmas are connection-level settings that configure
+	// SQLite's behavior:
 	//
-	// _journal_mode=WAL: Enables Write-Ahead Logging mode, which allows
+	// journal_mode(WAL): Enables Write-Ahead Logging mode, which allows
 	//   readers and writers to operate concurrently. WAL is recommended for
-	//   web applications with moderate write activity.
+	//   web applications with moderate write activity, lets OpenReadOnly
+	//   pools read while the primary writes, and is required by Litestream.
 	//
-	// _busy_timeout=5000: Sets a 5-second timeout when the database is locked.
+	// busy_timeout(5000): Sets a 5-second timeout when the database is locked.
 	//   SQLite will retry the operation for up to 5 seconds before returning
 	//   a SQLITE_BUSY error. This helps handle concurrent access gracefully.
 	//
-	// _foreign_keys=on: Enables foreign key constraint checking. SQLite
+	// foreign_keys(1): Enables foreign key constraint checking. SQLite
 	//   doesn't enable this by default for backward compatibility, but it's
 	//   essential for maintaining referential integrity.
 	//
-	// _synchronous=NORMAL: Balances durability and performance. FULL would
+	// synchronous(NORMAL): Balances durability and performance. FULL would
 	//   be safer but slower; NORMAL is sufficient for most applications when
 	//   combined with WAL mode.
 	//
-	// _cache_size=2000: Sets the page cache to 2000 pages. With SQLite's
-	//   default 4KB page size, this provides ~8MB of cache, improving query
-	//   performance for frequently accessed data.
-	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on&_synchronous=NORMAL&_cache_size=2000", cfg.Path)
+	// cache_size(-8000): Sets the page cache to ~8MB (negative values are in
+	//   KiB), improving query performance for frequently accessed data.
+	//
+	// wal_autocheckpoint(0): Only with cfg.DisableAutoCheckpoint, for
+	//   deployments where a replicator owns checkpointing.
+	dsn := cfg.DSN()
 
 	// Open the database connection using the "sqlite" driver registered by
 	// modernc.org/sqlite. This doesn't actually connect yet, just creates
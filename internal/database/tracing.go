@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"      // Spans travel in the query context
+	"database/sql" // Wrapped connection and result types
+	"strings"      // Reading the sqlc query name
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0" // Standard database attribute keys
+	"go.opentelemetry.io/otel/trace"                   // Parent span lookup
+
+	"github.com/narendhupati/bluejay-cms/internal/tracing" // App tracer and error recording
+)
+
+// tracingDB records a span for each statement run inside a traced request.
+type tracingDB struct {
+	db DBTX
+}
+
+// TracingDB wraps db so that each statement issued with a context carrying a
+// span (a traced request, see middleware.Tracing) gets a child span named
+// after its sqlc query, e.g. "GetProductBySlug". Statements without a parent
+// span, such as background jobs, are not traced, so tracing costs one
+// context lookup per query when it is off.
+//
+// Spans cover executing the statement, not scanning the rows. The SQL text
+// is recorded as db.query.text; sqlc queries are parameterized, so it holds
+// no values.
+//
+// Parameters:
+//   - db: Connection passed to sqlc.New (typically the *sql.DB from InitDB)
+//
+// Returns:
+//   - DBTX: Wrapped connection for sqlc.New
+//
+// Example usage:
+//
+//	queries := sqlc.New(database.TracingDB(database.CountingDB(db)))
+func TracingDB(db DBTX) DBTX {
+	return &tracingDB{db: db}
+}
+
+// startQuery starts the span for query, or returns a nil span when ctx is
+// not part of a trace.
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+	name := queryName(query)
+	return tracing.Start(ctx, name,
+		semconv.DBSystemNameSQLite,
+		semconv.DBOperationName(name),
+		semconv.DBQueryText(query),
+	)
+}
+
+// queryName returns the sqlc name from the "-- name: GetProduct :one" line
+// that starts every generated query, or "query" for other statements.
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "query"
+	}
+	if i := strings.IndexAny(rest, " \n"); i > 0 {
+		return rest[:i]
+	}
+	return "query"
+}
+
+// endQuery records err and ends span, if the statement was traced.
+func endQuery(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	tracing.RecordError(span, err)
+	span.End()
+}
+
+func (d *tracingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+	res, err := d.db.ExecContext(ctx, query, args...)
+	endQuery(span, err)
+	return res, err
+}
+
+func (d *tracingDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.db.PrepareContext(ctx, query)
+}
+
+func (d *tracingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+// QueryRowContext cannot see the error, which is reported by Scan; a
+// missing row is not a failure anyway.
+func (d *tracingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+	row := d.db.QueryRowContext(ctx, query, args...)
+	endQuery(span, nil)
+	return row
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"      // Transaction lifetime
+	"database/sql" // Connection and transaction types
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Queries bound to the transaction
+)
+
+// WithTx runs fn with queries bound to a new transaction on db, and commits
+// when fn returns nil. When fn returns an error or panics, the transaction is
+// rolled back and none of its writes are kept. Queries inside the transaction
+// are counted and traced like those of the server's shared Queries.
+//
+// InitDB limits db to one connection, so fn must only use the Queries it is
+// given: a query through any other Queries waits for the transaction, which
+// is waiting for fn.
+//
+// Example usage:
+//
+//	err := database.WithTx(ctx, db, func(q *sqlc.Queries) error {
+//		wp, err := q.CreateWhitepaper(ctx, params)
+//		if err != nil {
+//			return err
+//		}
+//		_, err = q.CreateWhitepaperLearningPoint(ctx, sqlc.CreateWhitepaperLearningPointParams{WhitepaperID: wp.ID, ...})
+//		return err
+//	})
+func WithTx(ctx context.Context, db *sql.DB, fn func(*sqlc.Queries) error) error {
+	return WithDBTx(ctx, db, func(tx DBTX) error {
+		return fn(sqlc.New(tx))
+	})
+}
+
+// WithDBTx is WithTx for code that builds its own SQL, such as the content
+// bundle importer: fn gets the transaction itself, counted and traced, instead
+// of Queries bound to it.
+func WithDBTx(ctx context.Context, db *sql.DB, fn func(DBTX) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op after Commit
+	if err := fn(TracingDB(CountingDB(tx))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Transactor runs fn in one transaction. Handlers depend on it rather than
+// on *sql.DB, so that their unit tests can run fn on a mock sqlc.Querier.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(sqlc.Querier) error) error
+}
+
+// NewTransactor returns the Transactor that opens its transactions on db
+// with WithTx.
+func NewTransactor(db *sql.DB) Transactor {
+	return dbTransactor{db: db}
+}
+
+// dbTransactor is the Transactor of a *sql.DB.
+type dbTransactor struct {
+	db *sql.DB
+}
+
+// WithTx implements Transactor.
+func (t dbTransactor) WithTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return WithTx(ctx, t.db, func(q *sqlc.Queries) error { return fn(q) })
+}
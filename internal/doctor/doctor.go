@@ -0,0 +1,383 @@
+// Package doctor implements the "bluejay-cms doctor" startup self-check.
+// It validates the deployment environment (configuration, database schema,
+// templates, writable directories, external services, and the system clock)
+// without starting the server or changing anything on disk, and prints an
+// actionable fix for every problem it finds. Run it before a deploy or after
+// moving the application to a new host.
+package doctor
+
+import (
+	// Standard library imports
+	"database/sql" // Read-only inspection of the database file
+	"fmt"          // Report formatting
+	"io"           // Report output destination
+	"io/fs"        // Migration files (embedded in the server)
+	"net"          // TCP reachability checks for Redis and SMTP
+	"net/url"      // Parsing webhook and Redis URLs
+	"os"           // File checks and environment lookups
+	"sort"         // Ordering migration versions
+	"strconv"      // Parsing numeric settings and migration versions
+	"strings"      // Splitting list-valued settings
+	"time"         // Dial timeouts and clock checks
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/config"    // Loaded configuration and CONFIG_FILE lookup
+	"github.com/narendhupati/bluejay-cms/internal/templates" // Template parsing
+
+	// SQLite driver registration for the read-only database connection
+	_ "modernc.org/sqlite"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	StatusOK   Status = "OK"   // Check passed
+	StatusWarn Status = "WARN" // Works, but likely to cause trouble
+	StatusFail Status = "FAIL" // The server will not start or will misbehave
+	StatusSkip Status = "SKIP" // Not applicable to this configuration
+)
+
+// Result is the outcome of one check with an optional remedy.
+type Result struct {
+	Name    string // Check name shown in the report (e.g. "database schema")
+	Status  Status // OK, WARN, FAIL, or SKIP
+	Message string // What was found
+	Fix     string // Suggested remedy; empty when nothing needs doing
+}
+
+// Options describes the deployment being checked. Config and ConfigErr are
+// what config.Load returned to the server; Getenv and Now may be overridden
+// in tests.
+type Options struct {
+	Config       *config.Config // Loaded configuration; loaded from Getenv when nil
+	ConfigErr    error          // Error returned with Config by config.Load
+	Migrations   fs.FS          // NNN_name.up.sql files (the embedded db/migrations in the server)
+	TemplatesDir string         // Root of the html/template tree
+
+	Getenv      func(string) string // Environment lookup for REDIS_URL; defaults to os.Getenv (CONFIG_FILE is read through it)
+	Now         func() time.Time    // Clock; defaults to time.Now
+	DialTimeout time.Duration       // Timeout for Redis/SMTP dials; defaults to 3s
+}
+
+// Run executes every check, writes a report to w, and returns the process exit
+// code: 1 if any check failed, 0 otherwise.
+//
+// Parameters:
+//   - w: Report destination (usually os.Stdout)
+//   - opts: Deployment paths and overrides
+//
+// Returns:
+//   - int: Exit code for os.Exit
+func Run(w io.Writer, opts Options) int {
+	results := Check(opts)
+
+	fmt.Fprintln(w, "Bluejay CMS doctor")
+	fmt.Fprintln(w)
+	failed, warned := 0, 0
+	for _, r := range results {
+		fmt.Fprintf(w, "[%-4s] %-18s %s\n", r.Status, r.Name, r.Message)
+		if r.Fix != "" {
+			fmt.Fprintf(w, "       %-18s fix: %s\n", "", r.Fix)
+		}
+		switch r.Status {
+		case StatusFail:
+			failed++
+		case StatusWarn:
+			warned++
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d checks, %d failed, %d warnings\n", len(results), failed, warned)
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Check executes every check and returns the results in report order.
+func Check(opts Options) []Result {
+	if opts.Getenv == nil {
+		opts.Getenv = os.Getenv
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 3 * time.Second
+	}
+
+	if opts.Config == nil {
+		cfg, err := config.Load(opts.Getenv)
+		opts.Config, opts.ConfigErr = &cfg, err
+	}
+	// REDIS_URL is not part of the configuration; it may still come from
+	// CONFIG_FILE, whose errors are already in ConfigErr
+	if lookup, err := config.Lookup(opts.Getenv); err == nil {
+		opts.Getenv = lookup
+	}
+	results := checkConfig(opts.ConfigErr)
+
+	// The database is opened once and shared by the schema and clock checks
+	db, dbResult := openDB(opts.Config.DBPath)
+	if db != nil {
+		defer db.Close()
+	}
+	if dbResult != nil {
+		results = append(results, *dbResult)
+	} else {
+		results = append(results, checkSchema(db, opts.Migrations))
+	}
+
+	results = append(results,
+		checkTemplates(opts.TemplatesDir),
+		checkWritable("upload dir", opts.Config.UploadDir),
+		checkWritable("archive dir", opts.Config.ArchiveDir),
+		checkCache(opts),
+		checkTLS(*opts.Config),
+		checkSMTP(*opts.Config, opts.DialTimeout),
+		checkClock(db, opts.Now()),
+	)
+	return results
+}
+
+// checkConfig reports the problems config.Load found, one result each, so
+// the doctor fails on exactly what would stop the server from starting.
+func checkConfig(err error) []Result {
+	if err == nil {
+		return []Result{{Name: "config", Status: StatusOK, Message: "configuration is valid"}}
+	}
+	var problems []Result
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fix := `fix the environment variable or CONFIG_FILE setting; see DEPLOYMENT.md, "Configuration"`
+		if strings.HasPrefix(line, "config file") {
+			fix = "fix the file named by CONFIG_FILE (YAML or TOML, lower-case setting names), or unset CONFIG_FILE"
+		}
+		problems = append(problems, Result{"config", StatusFail, line, fix})
+	}
+	return problems
+}
+
+// openDB opens the database file read-only. A missing file is only a warning
+// because the server creates it on first start. Returns a result instead of a
+// handle when the database cannot be inspected.
+func openDB(path string) (*sql.DB, *Result) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, &Result{"database schema", StatusWarn,
+			fmt.Sprintf("%s does not exist yet", path),
+			"it is created and migrated on first start; check DB_PATH if you expected an existing database"}
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_busy_timeout=5000")
+	if err == nil {
+		err = db.Ping()
+	}
+	if err != nil {
+		if db != nil {
+			db.Close()
+		}
+		return nil, &Result{"database schema", StatusFail,
+			fmt.Sprintf("cannot open %s: %v", path, err),
+			"check the file permissions and that DB_PATH points to a SQLite database"}
+	}
+	return db, nil
+}
+
+// latestMigration returns the highest version among the *.up.sql files in dir.
+func latestMigration(migrations fs.FS) (uint, error) {
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+	var versions []int
+	for _, f := range files {
+		prefix, _, _ := strings.Cut(f, "_")
+		if n, err := strconv.Atoi(prefix); err == nil {
+			versions = append(versions, n)
+		}
+	}
+	if len(versions) == 0 {
+		return 0, fmt.Errorf("no migrations found")
+	}
+	sort.Ints(versions)
+	return uint(versions[len(versions)-1]), nil
+}
+
+// checkSchema compares the database's golang-migrate version with the newest
+// migration file. Pending migrations are applied automatically on start, so
+// they are a warning; a dirty version or a database ahead of the code is not.
+func checkSchema(db *sql.DB, migrations fs.FS) Result {
+	const name = "database schema"
+
+	if err := db.QueryRow("PRAGMA quick_check").Scan(new(string)); err != nil {
+		return Result{name, StatusFail, fmt.Sprintf("integrity check failed: %v", err),
+			"restore the database from a backup"}
+	}
+
+	latest, err := latestMigration(migrations)
+	if err != nil {
+		return Result{name, StatusFail, err.Error(),
+			"rebuild the binary from a checkout that includes db/migrations"}
+	}
+
+	var version uint
+	var dirty bool
+	err = db.QueryRow("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err == sql.ErrNoRows || (err != nil && strings.Contains(err.Error(), "no such table")) {
+		return Result{name, StatusWarn, fmt.Sprintf("no migrations applied (latest is %03d)", latest),
+			"migrations run automatically when the server starts (or with: migrate up when MIGRATE_ON_START=false)"}
+	}
+	if err != nil {
+		return Result{name, StatusFail, fmt.Sprintf("cannot read schema_migrations: %v", err),
+			"check that DB_PATH points to a Bluejay CMS database"}
+	}
+
+	switch {
+	case dirty:
+		return Result{name, StatusFail, fmt.Sprintf("migration %03d failed part-way (dirty)", version),
+			fmt.Sprintf("repair the schema by hand or restore a backup, then run: migrate force %d", version)}
+	case version > latest:
+		return Result{name, StatusFail, fmt.Sprintf("database is at %03d but this build only knows up to %03d", version, latest),
+			"deploy the matching (newer) build, or restore a database backup taken before the upgrade"}
+	case version < latest:
+		return Result{name, StatusWarn, fmt.Sprintf("at %03d, %d migration(s) pending up to %03d", version, latest-version, latest),
+			"pending migrations run automatically on start (or with: migrate up when MIGRATE_ON_START=false); take a backup first"}
+	}
+	return Result{Name: name, Status: StatusOK, Message: fmt.Sprintf("up to date at %03d", version)}
+}
+
+// checkTemplates parses every template the same way the server does and
+// reports all broken templates at once.
+func checkTemplates(dir string) Result {
+	const name = "templates"
+	r, err := templates.LoadRenderer(dir)
+	if err != nil {
+		return Result{name, StatusFail, err.Error(),
+			"fix the template errors above; the server will refuse to start until they parse"}
+	}
+	return Result{Name: name, Status: StatusOK, Message: fmt.Sprintf("all %d template sets parse", r.LoadStats().Sets)}
+}
+
+// checkWritable verifies the server can create files in dir by writing and
+// removing a temporary file.
+func checkWritable(name, dir string) Result {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return Result{name, StatusWarn, fmt.Sprintf("%s does not exist", dir),
+			fmt.Sprintf("mkdir -p %s (it is created on first write, but only if the parent is writable)", dir)}
+	}
+	if err != nil {
+		return Result{name, StatusFail, err.Error(), fmt.Sprintf("check the permissions of %s", dir)}
+	}
+	if !info.IsDir() {
+		return Result{name, StatusFail, fmt.Sprintf("%s is not a directory", dir),
+			fmt.Sprintf("remove or rename %s", dir)}
+	}
+
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return Result{name, StatusFail, fmt.Sprintf("%s is not writable: %v", dir, err),
+			fmt.Sprintf("chown the directory to the service user or chmod u+w %s", dir)}
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return Result{Name: name, Status: StatusOK, Message: fmt.Sprintf("%s is writable", dir)}
+}
+
+// dial reports whether a TCP connection to addr can be opened.
+func dial(addr string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// checkCache reports the page cache backend. The built-in cache is in-memory;
+// when REDIS_URL is set the Redis server must be reachable.
+func checkCache(opts Options) Result {
+	const name = "cache"
+	v := opts.Getenv("REDIS_URL")
+	if v == "" {
+		return Result{Name: name, Status: StatusOK, Message: "in-memory cache (no external service)"}
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.Host == "" {
+		return Result{name, StatusFail, "REDIS_URL is not a valid URL",
+			`use the form redis://[:password@]host:6379/0`}
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if err := dial(addr, opts.DialTimeout); err != nil {
+		return Result{name, StatusFail, fmt.Sprintf("cannot reach Redis at %s: %v", addr, err),
+			"start Redis or fix REDIS_URL; check firewalls between this host and Redis"}
+	}
+	return Result{Name: name, Status: StatusOK, Message: fmt.Sprintf("Redis reachable at %s", addr)}
+}
+
+// checkTLS verifies the certificate cache is writable when the server serves
+// HTTPS itself (TLS_ENABLED or TLS_DOMAINS set). Without it every restart
+// requests new certificates, and Let's Encrypt's rate limits soon refuse them.
+func checkTLS(cfg config.Config) Result {
+	const name = "tls cache dir"
+	if !cfg.TLSEnabled() {
+		return Result{Name: name, Status: StatusSkip, Message: "TLS_ENABLED and TLS_DOMAINS not set, HTTPS left to a reverse proxy"}
+	}
+	return checkWritable(name, cfg.TLSCacheDir)
+}
+
+// checkSMTP verifies the mail server is reachable when SMTP_HOST is set.
+func checkSMTP(cfg config.Config, timeout time.Duration) Result {
+	const name = "smtp"
+	if cfg.SMTP.Host == "" {
+		return Result{Name: name, Status: StatusSkip, Message: "SMTP_HOST not set, outgoing mail disabled"}
+	}
+	port := cfg.SMTP.Port
+	if port == "" {
+		port = "587"
+	}
+	addr := net.JoinHostPort(cfg.SMTP.Host, port)
+	if err := dial(addr, timeout); err != nil {
+		return Result{name, StatusFail, fmt.Sprintf("cannot reach %s: %v", addr, err),
+			"check SMTP_HOST/SMTP_PORT and that outbound SMTP is not blocked by the host or provider"}
+	}
+	return Result{Name: name, Status: StatusOK, Message: fmt.Sprintf("%s reachable", addr)}
+}
+
+// timestampLayouts are the formats SQLite DATETIME values come back in.
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05Z"}
+
+// checkClock catches a badly wrong system clock, which breaks session expiry,
+// scheduled jobs, future-dated blog posts, and monthly archives. The newest activity log entry
+// is used as a lower bound: the clock must not be behind it.
+func checkClock(db *sql.DB, now time.Time) Result {
+	const name = "clock"
+	fix := "enable time synchronisation (e.g. timedatectl set-ntp true) and restart the server"
+
+	if now.Year() < 2024 {
+		return Result{name, StatusFail, fmt.Sprintf("system time is %s", now.UTC().Format(time.RFC3339)), fix}
+	}
+	if db == nil {
+		return Result{Name: name, Status: StatusOK, Message: now.UTC().Format(time.RFC3339)}
+	}
+
+	var latest sql.NullString
+	if err := db.QueryRow("SELECT MAX(created_at) FROM activity_log").Scan(&latest); err != nil || !latest.Valid {
+		return Result{Name: name, Status: StatusOK, Message: now.UTC().Format(time.RFC3339)}
+	}
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, latest.String)
+		if err != nil {
+			continue
+		}
+		if t.Sub(now) > 5*time.Minute {
+			return Result{name, StatusFail,
+				fmt.Sprintf("system time %s is behind the newest activity log entry (%s)", now.UTC().Format(time.RFC3339), t.UTC().Format(time.RFC3339)),
+				fix}
+		}
+		break
+	}
+	return Result{Name: name, Status: StatusOK, Message: now.UTC().Format(time.RFC3339)}
+}
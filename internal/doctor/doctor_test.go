@@ -0,0 +1,268 @@
+package doctor_test
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/migrations"
+	"github.com/narendhupati/bluejay-cms/internal/config"
+	"github.com/narendhupati/bluejay-cms/internal/database"
+	"github.com/narendhupati/bluejay-cms/internal/doctor"
+)
+
+// migratedDB creates a fully migrated database in a temp dir.
+func migratedDB(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "doctor.db")
+	db, err := database.InitDB(database.Config{Path: path})
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	defer db.Close()
+	if err := database.RunMigrations(db, "../../db/migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	return path
+}
+
+func options(t *testing.T, dbPath string, env map[string]string) doctor.Options {
+	t.Helper()
+	env = maps.Clone(env)
+	if env == nil {
+		env = map[string]string{}
+	}
+	defaults := map[string]string{
+		"SESSION_SECRET": strings.Repeat("s", 32),
+		"DB_PATH":        dbPath,
+		"UPLOAD_DIR":     t.TempDir(),
+		"ARCHIVE_DIR":    t.TempDir(),
+	}
+	for k, v := range defaults {
+		if _, ok := env[k]; !ok {
+			env[k] = v
+		}
+	}
+	return doctor.Options{
+		Migrations:   migrations.FS,
+		TemplatesDir: "../../templates",
+		Getenv:       func(k string) string { return env[k] },
+	}
+}
+
+func find(t *testing.T, results []doctor.Result, name string) doctor.Result {
+	t.Helper()
+	for _, r := range results {
+		if r.Name == name {
+			return r
+		}
+	}
+	t.Fatalf("no %q result in %+v", name, results)
+	return doctor.Result{}
+}
+
+func TestDoctorHealthyDeployment(t *testing.T) {
+	var out bytes.Buffer
+	code := doctor.Run(&out, options(t, migratedDB(t), nil))
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0\n%s", code, out.String())
+	}
+	if strings.Contains(out.String(), "FAIL") || strings.Contains(out.String(), "WARN") {
+		t.Errorf("unexpected problems:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "up to date at") {
+		t.Errorf("schema version not reported:\n%s", out.String())
+	}
+}
+
+func TestDoctorReportsProblems(t *testing.T) {
+	opts := options(t, migratedDB(t), map[string]string{
+		"ARCHIVE_RETENTION_MONTHS": "two",
+		"DB_ALERT_WEBHOOK":         "hooks.example.com",
+		"SMTP_HOST":                "127.0.0.1",
+		"SMTP_PORT":                "1",
+		"UPLOAD_DIR":               filepath.Join(t.TempDir(), "missing"),
+	})
+	opts.Now = func() time.Time { return time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC) }
+	opts.DialTimeout = time.Second
+
+	results := doctor.Check(opts)
+
+	config := 0
+	for _, r := range results {
+		if r.Name == "config" {
+			config++
+			if r.Status != doctor.StatusFail || r.Fix == "" {
+				t.Errorf("config result = %+v, want FAIL with a fix", r)
+			}
+		}
+	}
+	if config != 3 {
+		t.Errorf("got %d config problems, want 3", config)
+	}
+	if r := find(t, results, "upload dir"); r.Status != doctor.StatusWarn {
+		t.Errorf("upload dir = %+v, want WARN", r)
+	}
+	if r := find(t, results, "smtp"); r.Status != doctor.StatusFail {
+		t.Errorf("smtp = %+v, want FAIL", r)
+	}
+	if r := find(t, results, "clock"); r.Status != doctor.StatusFail {
+		t.Errorf("clock = %+v, want FAIL", r)
+	}
+
+	var out bytes.Buffer
+	if code := doctor.Run(&out, opts); code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+}
+
+func TestDoctorTLSCacheDir(t *testing.T) {
+	dbPath := migratedDB(t)
+	if r := find(t, doctor.Check(options(t, dbPath, nil)), "tls cache dir"); r.Status != doctor.StatusSkip {
+		t.Errorf("without TLS_DOMAINS = %+v, want SKIP", r)
+	}
+	env := map[string]string{"TLS_DOMAINS": "example.com", "TLS_CACHE_DIR": t.TempDir()}
+	if r := find(t, doctor.Check(options(t, dbPath, env)), "tls cache dir"); r.Status != doctor.StatusOK {
+		t.Errorf("writable cache dir = %+v, want OK", r)
+	}
+	env["TLS_CACHE_DIR"] = filepath.Join(t.TempDir(), "missing")
+	if r := find(t, doctor.Check(options(t, dbPath, env)), "tls cache dir"); r.Status != doctor.StatusWarn {
+		t.Errorf("missing cache dir = %+v, want WARN", r)
+	}
+	env = map[string]string{"TLS_ENABLED": "true", "TLS_CACHE_DIR": filepath.Join(t.TempDir(), "missing")}
+	if r := find(t, doctor.Check(options(t, dbPath, env)), "tls cache dir"); r.Status != doctor.StatusWarn {
+		t.Errorf("TLS_ENABLED with a missing cache dir = %+v, want WARN", r)
+	}
+}
+
+func TestDoctorSchemaStates(t *testing.T) {
+	t.Run("missing database", func(t *testing.T) {
+		r := find(t, doctor.Check(options(t, filepath.Join(t.TempDir(), "none.db"), nil)), "database schema")
+		if r.Status != doctor.StatusWarn {
+			t.Errorf("got %+v, want WARN", r)
+		}
+	})
+
+	t.Run("pending migrations", func(t *testing.T) {
+		path := migratedDB(t)
+		opts := options(t, path, nil)
+		// Pretend a newer migration ships with this build
+		dir := t.TempDir()
+		files, _ := filepath.Glob("../../db/migrations/*.sql")
+		for _, f := range files {
+			b, _ := os.ReadFile(f)
+			os.WriteFile(filepath.Join(dir, filepath.Base(f)), b, 0644)
+		}
+		os.WriteFile(filepath.Join(dir, "999_future.up.sql"), []byte("SELECT 1;"), 0644)
+		opts.Migrations = os.DirFS(dir)
+
+		r := find(t, doctor.Check(opts), "database schema")
+		if r.Status != doctor.StatusWarn || !strings.Contains(r.Message, "pending") {
+			t.Errorf("got %+v, want pending WARN", r)
+		}
+	})
+
+	t.Run("dirty migration", func(t *testing.T) {
+		path := migratedDB(t)
+		db, err := database.InitDB(database.Config{Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.Exec("UPDATE schema_migrations SET dirty = 1")
+		db.Close()
+
+		r := find(t, doctor.Check(options(t, path, nil)), "database schema")
+		if r.Status != doctor.StatusFail || !strings.Contains(r.Fix, "force") {
+			t.Errorf("got %+v, want dirty FAIL with force fix", r)
+		}
+	})
+}
+
+func TestDoctorTemplateParseError(t *testing.T) {
+	r := find(t, doctor.Check(options(t, migratedDB(t), nil)), "templates")
+	if r.Status != doctor.StatusOK {
+		t.Fatalf("templates = %+v, want OK", r)
+	}
+
+	opts := options(t, migratedDB(t), nil)
+	opts.TemplatesDir = t.TempDir()
+	r = find(t, doctor.Check(opts), "templates")
+	if r.Status != doctor.StatusFail {
+		t.Errorf("templates with empty dir = %+v, want FAIL", r)
+	}
+
+	// Every broken template is reported, not just the first
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS("../../templates")); err != nil {
+		t.Fatalf("copy templates: %v", err)
+	}
+	for _, page := range []string{"admin/pages/login.html", "public/pages/contact.html"} {
+		if err := os.WriteFile(filepath.Join(dir, page), []byte(`{{define "content"}}{{if}}{{end}}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts.TemplatesDir = dir
+	r = find(t, doctor.Check(opts), "templates")
+	if r.Status != doctor.StatusFail || !strings.Contains(r.Message, "admin/pages/login.html") || !strings.Contains(r.Message, "public/pages/contact.html") {
+		t.Errorf("templates with two broken pages = %+v, want FAIL naming both", r)
+	}
+}
+
+func TestDoctorUsesLoadedConfig(t *testing.T) {
+	opts := options(t, migratedDB(t), nil)
+	cfg, err := config.Load(opts.Getenv)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	cfg.TLSDomains = []string{"example.com"}
+	cfg.TLSCacheDir = filepath.Join(t.TempDir(), "missing")
+	opts.Config = &cfg
+	opts.ConfigErr = errors.Join(errors.New("PORT=\"x\" is not a port between 1 and 65535"), errors.New("SITE_BASE_URL=\"\" is not an http(s) URL"))
+	opts.Getenv = func(string) string { return "" }
+
+	results := doctor.Check(opts)
+	var messages []string
+	for _, r := range results {
+		if r.Name == "config" {
+			messages = append(messages, r.Message)
+		}
+	}
+	if len(messages) != 2 || !strings.HasPrefix(messages[0], "PORT=") || !strings.HasPrefix(messages[1], "SITE_BASE_URL=") {
+		t.Errorf("config results = %q, want the two load errors", messages)
+	}
+	if r := find(t, results, "tls cache dir"); r.Status != doctor.StatusWarn {
+		t.Errorf("tls cache dir = %+v, want WARN from the loaded TLS settings", r)
+	}
+	if r := find(t, results, "database schema"); r.Status != doctor.StatusOK {
+		t.Errorf("database schema = %+v, want the loaded DB_PATH checked", r)
+	}
+}
+
+func TestDoctorConfigFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "bluejay.yaml")
+	os.WriteFile(file, []byte("session_secret: short\narchive_retention_months: two\n"), 0o600)
+	opts := options(t, migratedDB(t), map[string]string{"CONFIG_FILE": file, "SESSION_SECRET": ""})
+
+	var messages []string
+	for _, r := range doctor.Check(opts) {
+		if r.Name == "config" {
+			messages = append(messages, r.Message)
+		}
+	}
+	got := strings.Join(messages, "\n")
+	if !strings.Contains(got, "SESSION_SECRET") || !strings.Contains(got, "ARCHIVE_RETENTION_MONTHS") {
+		t.Errorf("config file values not checked, got:\n%s", got)
+	}
+
+	opts.Getenv = func(k string) string {
+		return map[string]string{"CONFIG_FILE": filepath.Join(t.TempDir(), "missing.toml")}[k]
+	}
+	if r := find(t, doctor.Check(opts), "config"); r.Status != doctor.StatusFail || !strings.Contains(r.Message, "missing.toml") {
+		t.Errorf("unreadable config file = %+v, want FAIL", r)
+	}
+}
@@ -73,8 +73,8 @@ func TestWhitepaperTopicsCreate_E2E(t *testing.T) {
 	if items[0].Name != "AI & Machine Learning" {
 		t.Errorf("expected 'AI & Machine Learning', got %q", items[0].Name)
 	}
-	if items[0].Slug != "ai--machine-learning" {
-		t.Errorf("expected slug 'ai--machine-learning', got %q", items[0].Slug)
+	if items[0].Slug != "ai-machine-learning" {
+		t.Errorf("expected slug 'ai-machine-learning', got %q", items[0].Slug)
 	}
 	if items[0].ColorHex != "#00FF00" {
 		t.Errorf("expected color '#00FF00', got %q", items[0].ColorHex)
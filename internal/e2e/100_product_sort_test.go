@@ -0,0 +1,122 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestProductSort_E2E orders category pages and product search with ?sort=
+// and the products_default_sort setting.
+func TestProductSort_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	now := time.Now()
+	// name, SKU, age
+	for _, p := range []struct {
+		name, sku string
+		age       time.Duration
+	}{
+		{"Bravo Probe", "Z-1", 3 * time.Hour},
+		{"alpha Probe", "M-1", 1 * time.Hour},
+		{"Charlie Probe", "A-1", 2 * time.Hour},
+	} {
+		queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: p.sku, Slug: strings.ToLower(p.sku), Name: p.name, Description: "d", CategoryID: cat.ID, Status: "published",
+			PublishedAt: sql.NullTime{Time: now.Add(-p.age), Valid: true},
+		})
+	}
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	cache := services.NewCache()
+	h := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), cache)
+	e.GET("/products/search", h.ProductSearch, appmw.SettingsLoader(queries))
+	e.GET("/products/:category", h.ProductsByCategory, appmw.SettingsLoader(queries))
+	e.GET("/partials/products/:category", h.ProductsCategoryGrid, appmw.SettingsLoader(queries))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	// order returns the first letters of the product names in page order
+	order := func(path string) string {
+		rec := get(path)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", path, rec.Code)
+		}
+		body := rec.Body.String()
+		pos := map[string]int{}
+		for _, name := range []string{"alpha Probe", "Bravo Probe", "Charlie Probe"} {
+			pos[name] = strings.Index(body, name)
+			if pos[name] < 0 {
+				t.Fatalf("%s: %s missing", path, name)
+			}
+		}
+		out := ""
+		for len(pos) > 0 {
+			first := ""
+			for name, i := range pos {
+				if first == "" || i < pos[first] {
+					first = name
+				}
+			}
+			out += strings.ToUpper(first[:1])
+			delete(pos, first)
+		}
+		return out
+	}
+
+	for path, want := range map[string]string{
+		"/partials/products/sensors":                "ABC", // Site default: name_asc, case-insensitive
+		"/partials/products/sensors?sort=name_desc": "CBA",
+		"/partials/products/sensors?sort=newest":    "ACB",
+		"/partials/products/sensors?sort=oldest":    "BCA",
+		"/partials/products/sensors?sort=sku":       "CAB",
+		"/products/search?q=probe&sort=sku":         "CAB",
+		"/products/search?q=probe&sort=name_desc":   "CBA",
+	} {
+		if got := order(path); got != want {
+			t.Errorf("%s: order %s, want %s", path, got, want)
+		}
+	}
+
+	grid := get("/partials/products/sensors?sort=newest").Body.String()
+	if !strings.Contains(grid, `<option value="newest" selected>`) {
+		t.Error("sort dropdown should show the selected order")
+	}
+	if rec := get("/products/sensors?sort=price"); rec.Code != http.StatusFound || rec.Header().Get("Location") != "/products/sensors" {
+		t.Errorf("unknown sort: got %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// The default order comes from the setting (the settings save purges pages)
+	if err := queries.UpdateProductsSettings(ctx, sqlc.UpdateProductsSettingsParams{ProductsPerPage: 12, ProductsDefaultSort: services.SortOldest}); err != nil {
+		t.Fatal(err)
+	}
+	cache.DeleteByPrefix("page:")
+	if got := order("/partials/products/sensors"); got != "BCA" {
+		t.Errorf("default order with oldest setting: %s", got)
+	}
+	if got := order("/products/search?q=probe"); got != "BCA" {
+		t.Errorf("search default order with oldest setting: %s", got)
+	}
+}
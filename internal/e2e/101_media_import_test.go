@@ -0,0 +1,113 @@
+package e2e_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestMediaImport_E2E imports a ZIP through the admin form, follows the
+// status page until the report is ready, and checks server folder limits.
+func TestMediaImport_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+
+	importDir := t.TempDir()
+	os.MkdirAll(filepath.Join(importDir, "catalog"), 0755)
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 400, 200)))
+	os.WriteFile(filepath.Join(importDir, "catalog", "banner.png"), img.Bytes(), 0644)
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	h := adminHandlers.NewMediaImportHandler(services.NewMediaImporter(queries, t.TempDir()), testLogger, services.NewCache(), importDir)
+	e.GET("/admin/media/import", h.Show)
+	e.POST("/admin/media/import", h.Start)
+	e.GET("/admin/media/import/:id", h.Status)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	// finish follows a start redirect and polls until the report is shown
+	finish := func(rec *httptest.ResponseRecorder) string {
+		t.Helper()
+		loc := rec.Header().Get("Location")
+		if rec.Code != http.StatusSeeOther || !strings.HasPrefix(loc, "/admin/media/import/") {
+			t.Fatalf("start: status %d to %q: %s", rec.Code, loc, rec.Body.String())
+		}
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+			body := serve(httptest.NewRequest(http.MethodGet, loc, nil)).Body.String()
+			if !strings.Contains(body, `hx-trigger="every 2s"`) {
+				return body
+			}
+		}
+		t.Fatal("import did not finish")
+		return ""
+	}
+
+	if body := serve(httptest.NewRequest(http.MethodGet, "/admin/media/import", nil)).Body.String(); !strings.Contains(body, `<option value="catalog">`) {
+		t.Error("form should list the folders under the import folder")
+	}
+
+	// ZIP upload
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, _ := zw.Create("banners/banner.png")
+	w.Write(img.Bytes())
+	w, _ = zw.Create("readme.txt")
+	w.Write([]byte("notes"))
+	zw.Close()
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	mw.WriteField("source", "zip")
+	part, _ := mw.CreateFormFile("archive", "banners.zip")
+	part.Write(archive.Bytes())
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/admin/media/import", &form)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	body := finish(serve(req))
+	if !strings.Contains(body, "1 added, 0 duplicates, 1 skipped, 0 failed") || !strings.Contains(body, "1 resized variants") {
+		t.Errorf("zip report missing counts:\n%s", body)
+	}
+
+	// The same image from the server folder is a duplicate
+	postDir := func(dir string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/media/import", strings.NewReader(url.Values{"source": {"dir"}, "dir": {dir}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(req)
+	}
+	if body := finish(postDir("catalog")); !strings.Contains(body, "0 added, 1 duplicates") || !strings.Contains(body, "same as banners/banner.png") {
+		t.Errorf("folder report should show the duplicate:\n%s", body)
+	}
+	for _, dir := range []string{"../", "/etc", "missing"} {
+		if rec := postDir(dir); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `role="alert"`) {
+			t.Errorf("dir %q: status %d, want the form with an error", dir, rec.Code)
+		}
+	}
+
+	if rec := serve(httptest.NewRequest(http.MethodGet, "/admin/media/import/nope", nil)); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown import: status %d", rec.Code)
+	}
+	if body := serve(httptest.NewRequest(http.MethodGet, "/admin/media/import", nil)).Body.String(); !strings.Contains(body, "banners.zip") {
+		t.Error("recent imports should list the ZIP import")
+	}
+}
@@ -0,0 +1,110 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestRecentlyViewed_E2E browses products with a cookie jar and checks the
+// widget lists the other viewed products, most recent first, and that
+// product and category pages load it.
+func TestRecentlyViewed_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	ids := map[string]int64{}
+	for _, p := range []struct{ sku, name, status string }{
+		{"A-1", "Alpha Probe", "published"},
+		{"B-1", "Bravo Probe", "published"},
+		{"C-1", "Charlie Probe", "published"},
+		{"D-1", "Draft Probe", "draft"},
+	} {
+		prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: p.sku, Slug: strings.ToLower(p.sku), Name: p.name, Description: "d", CategoryID: cat.ID, Status: p.status})
+		ids[p.sku] = prod.ID
+	}
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), services.NewCache())
+	recent := publicHandlers.NewRecentlyViewedHandler(queries, testLogger, services.NewRecentlyViewedCodec("test-secret"))
+	e.GET("/products/:category", products.ProductsByCategory, appmw.SettingsLoader(queries))
+	e.GET("/products/:category/:slug", products.ProductDetail, appmw.SettingsLoader(queries))
+	e.GET("/partials/recently-viewed", recent.RecentlyViewed)
+
+	var cookie *http.Cookie
+	// view requests the widget as a page would, keeping the cookie like a browser
+	view := func(query string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/partials/recently-viewed"+query, nil)
+		req.Header.Set("HX-Request", "true")
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", query, rec.Code)
+		}
+		if rec.Header().Get("Cache-Control") != "private, no-store" {
+			t.Errorf("widget should not be cached, got %q", rec.Header().Get("Cache-Control"))
+		}
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "recently_viewed" {
+				cookie = c
+			}
+		}
+		return rec.Body.String()
+	}
+	current := func(sku string) string { return fmt.Sprintf("?current=%d", ids[sku]) }
+
+	if body := strings.TrimSpace(view(current("A-1"))); body != "" {
+		t.Errorf("first product view should show nothing, got %q", body)
+	}
+	view(current("B-1"))
+	view(current("D-1")) // drafts are not recorded
+	body := view(current("C-1"))
+	if !strings.Contains(body, "Recently Viewed") || strings.Contains(body, "Charlie Probe") || strings.Contains(body, "Draft Probe") {
+		t.Errorf("widget on Charlie's page:\n%s", body)
+	}
+	if b, a := strings.Index(body, "Bravo Probe"), strings.Index(body, "Alpha Probe"); b < 0 || a < 0 || b > a {
+		t.Errorf("want Bravo then Alpha, got positions %d, %d", b, a)
+	}
+	if !strings.Contains(body, `href="/products/sensors/a-1"`) {
+		t.Error("cards should link to the product pages")
+	}
+
+	// Category pages list everything; a tampered cookie is ignored
+	if body := view(""); !strings.Contains(body, "Charlie Probe") {
+		t.Error("category widget should include the last viewed product")
+	}
+	cookie.Value = "99-98" + cookie.Value[strings.Index(cookie.Value, "."):]
+	if body := strings.TrimSpace(view("")); body != "" {
+		t.Errorf("tampered cookie should be ignored, got %q", body)
+	}
+
+	for path, want := range map[string]string{
+		"/products/sensors":     `hx-get="/partials/recently-viewed"`,
+		"/products/sensors/a-1": `hx-get="/partials/recently-viewed?current=`,
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("%s should load the widget (status %d)", path, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestContentIndexPages checks the A–Z product index, the blog tag cloud and
+// tag pages, the whitepaper topic index, their caching and sitemap entries,
+// rendering with the REAL templates.
+func TestContentIndexPages(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	productsHandler := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products/a-z", productsHandler.ProductIndex)
+	publicGroup.GET("/products/:category", productsHandler.ProductsByCategory)
+	blogHandler := publicHandlers.NewBlogHandler(queries, logger, appCache)
+	publicGroup.GET("/blog/tags", blogHandler.TagCloud)
+	publicGroup.GET("/blog/tags/:slug", blogHandler.TagPosts)
+	publicGroup.GET("/blog/:slug", blogHandler.BlogPost)
+	whitepapersHandler := publicHandlers.NewWhitepapersHandler(queries, logger, appCache)
+	publicGroup.GET("/whitepapers/topics", whitepapersHandler.TopicIndex)
+	e.GET("/sitemap.xml", publicHandlers.NewSitemapHandler(queries, logger, "https://example.com").Sitemap)
+
+	get := func(path string, wantStatus int) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	// Products: two under A, one under #, one draft
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	for _, p := range []struct{ sku, slug, name, status string }{
+		{"A-1", "apex-probe", "Apex Probe", "published"},
+		{"A-2", "anchor-node", "anchor node", "published"},
+		{"N-1", "9-port-hub", "9-Port Hub", "published"},
+		{"D-1", "draft-device", "Draft Device", "draft"},
+	} {
+		if _, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: p.sku, Slug: p.slug, Name: p.name, Description: "d", CategoryID: cat.ID, Status: p.status,
+		}); err != nil {
+			t.Fatalf("create product %s: %v", p.slug, err)
+		}
+	}
+
+	az := get("/products/a-z", http.StatusOK)
+	for _, want := range []string{
+		`id="letter-A"`, `id="letter-#"`, `href="#letter-A"`,
+		`href="/products/sensors/apex-probe"`, `href="/products/sensors/anchor-node"`, `href="/products/sensors/9-port-hub"`,
+		"3 products",
+	} {
+		if !strings.Contains(az, want) {
+			t.Errorf("A–Z index: expected %q", want)
+		}
+	}
+	if strings.Contains(az, "draft-device") {
+		t.Error("A–Z index must not list draft products")
+	}
+	if strings.Contains(az, `href="#letter-B"`) {
+		t.Error("letters without products must not link")
+	}
+	if strings.Index(az, "anchor node") > strings.Index(az, "Apex Probe") {
+		t.Error("A–Z index should sort names case-insensitively")
+	}
+
+	// Blog: "edge" on two published posts, "legacy" only on a draft
+	blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1})
+	edge, _ := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Edge", Slug: "edge"})
+	iot, _ := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "IoT", Slug: "iot"})
+	legacy, _ := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Legacy", Slug: "legacy"})
+	newPost := func(slug, status string, tags ...sqlc.BlogTag) {
+		t.Helper()
+		post, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: strings.ToUpper(slug), Slug: slug, Excerpt: "Excerpt", Body: "<p>Body</p>",
+			CategoryID: blogCat.ID, AuthorID: author.ID, Status: status,
+			PublishedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+		for _, tag := range tags {
+			queries.AddTagToPost(ctx, sqlc.AddTagToPostParams{BlogPostID: post.ID, BlogTagID: tag.ID})
+		}
+	}
+	newPost("edge-launch", "published", edge, iot)
+	newPost("edge-recap", "published", edge)
+	newPost("old-draft", "draft", legacy)
+
+	cloud := get("/blog/tags", http.StatusOK)
+	for _, want := range []string{`href="/blog/tags/edge"`, `href="/blog/tags/iot"`, `title="2 posts"`, `title="1 post"`} {
+		if !strings.Contains(cloud, want) {
+			t.Errorf("tag cloud: expected %q", want)
+		}
+	}
+	if strings.Contains(cloud, "/blog/tags/legacy") {
+		t.Error("tag cloud must skip tags used only by drafts")
+	}
+
+	tagPage := get("/blog/tags/edge", http.StatusOK)
+	for _, want := range []string{`href="/blog/edge-launch"`, `href="/blog/edge-recap"`, "2 posts tagged Edge"} {
+		if !strings.Contains(tagPage, want) {
+			t.Errorf("tag page: expected %q", want)
+		}
+	}
+	get("/blog/tags/legacy", http.StatusNotFound)
+	get("/blog/tags/missing", http.StatusNotFound)
+
+	// Post pages link their tags to the tag pages
+	if post := get("/blog/edge-launch", http.StatusOK); !strings.Contains(post, `href="/blog/tags/iot"`) {
+		t.Error("blog post should link its tags to /blog/tags/:slug")
+	}
+
+	// Whitepapers: one topic with a published paper, one with only a draft
+	security, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "Security", Slug: "security", ColorHex: "#000000", Icon: "shield"})
+	energy, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "Energy", Slug: "energy", ColorHex: "#000000", Icon: "bolt"})
+	newWhitepaper := func(slug string, topic sqlc.WhitepaperTopic, published int64) {
+		t.Helper()
+		if _, err := queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+			Title: strings.ToUpper(slug), Slug: slug, Description: "d", TopicID: topic.ID,
+			PdfFilePath: "/files/" + slug + ".pdf", FileSizeBytes: 1024, PublishedDate: "2026-01-01",
+			IsPublished: published, CoverColorFrom: "#111111", CoverColorTo: "#222222",
+		}); err != nil {
+			t.Fatalf("create whitepaper %s: %v", slug, err)
+		}
+	}
+	newWhitepaper("zero-trust", security, 1)
+	newWhitepaper("grid-draft", energy, 0)
+
+	topics := get("/whitepapers/topics", http.StatusOK)
+	for _, want := range []string{`id="topic-security"`, `href="/whitepapers/zero-trust"`, `href="/whitepapers?topic=`} {
+		if !strings.Contains(topics, want) {
+			t.Errorf("topic index: expected %q", want)
+		}
+	}
+	if strings.Contains(topics, `id="topic-energy"`) || strings.Contains(topics, "grid-draft") {
+		t.Error("topic index must skip topics without published whitepapers")
+	}
+
+	// Index pages are cached and cleared with their section's prefix
+	for _, key := range []string{"page:products:index", "page:blog:tags", "page:blog:tag:edge:page:1", "page:whitepapers:topics"} {
+		if _, ok := appCache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+	appCache.DeleteByPrefix("page:products")
+	if _, ok := appCache.Get("page:products:index"); ok {
+		t.Error("expected admin product invalidation to clear the A–Z index")
+	}
+
+	sitemap := get("/sitemap.xml", http.StatusOK)
+	for _, want := range []string{
+		"<loc>https://example.com/products/a-z</loc>",
+		"<loc>https://example.com/blog/tags</loc>",
+		"<loc>https://example.com/blog/tags/edge</loc>",
+	} {
+		if !strings.Contains(sitemap, want) {
+			t.Errorf("sitemap: expected %q", want)
+		}
+	}
+	if strings.Contains(sitemap, "/blog/tags/legacy") {
+		t.Error("sitemap must skip tags used only by drafts")
+	}
+}
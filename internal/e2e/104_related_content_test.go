@@ -0,0 +1,148 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestRelatedContent_E2E checks the Related tab of the product editor and the
+// related resources section it feeds on the public product page, rendering
+// with the REAL templates.
+func TestRelatedContent_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	ctx := context.Background()
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i", SortOrder: 1,
+	})
+	product, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "SEN-1", Slug: "sensor-one", Name: "Sensor One", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	utilities, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Utilities", Slug: "utilities", Icon: "bolt", Description: "d"})
+	ports, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Ports", Slug: "ports", Icon: "anchor", Description: "d"})
+	newCaseStudy := func(slug, title string, industry sqlc.Industry) sqlc.CaseStudy {
+		cs, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+			Slug: slug, Title: title, ClientName: "Client", IndustryID: industry.ID, Summary: "Summary of " + title,
+			ChallengeContent: "c", SolutionContent: "s", OutcomeContent: "o", IsPublished: 1,
+		})
+		if err != nil {
+			t.Fatalf("create case study %s: %v", slug, err)
+		}
+		return cs
+	}
+	featured := newCaseStudy("grid-rollout", "Grid Rollout", utilities)
+	queries.AdminAddCaseStudyProduct(ctx, sqlc.AdminAddCaseStudyProductParams{CaseStudyID: featured.ID, ProductID: product.ID})
+	unrelated := newCaseStudy("port-automation", "Port Automation", ports)
+	topic, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "Sensors", Slug: "sensors", ColorHex: "#000000", Icon: "i"})
+	queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+		Title: "Sensor Calibration Guide", Slug: "sensor-calibration", Description: "d", TopicID: topic.ID,
+		PdfFilePath: "/f.pdf", FileSizeBytes: 1, PublishedDate: "2026-01-01", IsPublished: 1,
+		CoverColorFrom: "#111111", CoverColorTo: "#222222",
+	})
+
+	send := func(method, path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	base := fmt.Sprintf("/admin/products/%d/related", product.ID)
+
+	rec := send(http.MethodGet, base, nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), fmt.Sprintf(`value="case_study:%d"`, unrelated.ID)) {
+		t.Fatalf("related tab: got %d, expected the pin picker to list case studies", rec.Code)
+	}
+
+	// Unknown types are rejected with a message
+	rec = send(http.MethodPost, base, url.Values{"content": {"solution:1"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Choose a case study") {
+		t.Errorf("invalid pin: got %d, expected an inline error", rec.Code)
+	}
+
+	rec = send(http.MethodPost, base, url.Values{"content": {fmt.Sprintf("case_study:%d", unrelated.ID)}, "display_order": {"1"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Port Automation") {
+		t.Fatalf("pin case study: got %d", rec.Code)
+	}
+	pins, _ := queries.ListRelatedContentPins(ctx, product.ID)
+	if len(pins) != 1 || pins[0].ContentID != unrelated.ID || pins[0].DisplayOrder != 1 {
+		t.Fatalf("expected one pin for the unrelated case study, got %+v", pins)
+	}
+
+	// Public product page: the pinned case study precedes the featured one,
+	// and the whitepaper matches through its topic
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+	pub := echo.New()
+	pub.Renderer = templates.NewRenderer("templates")
+	publicGroup := pub.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	productsHandler := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products/:category/:slug", productsHandler.ProductDetail)
+
+	get := func(path string) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		pub.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d", path, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	page := get("/products/sensors/sensor-one")
+	for _, want := range []string{`id="related-content"`, `href="/case-studies/grid-rollout"`, `href="/whitepapers/sensor-calibration"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("product page: expected %q", want)
+		}
+	}
+	pinnedAt := strings.Index(page, `href="/case-studies/port-automation"`)
+	if pinnedAt < 0 || pinnedAt > strings.Index(page, `href="/case-studies/grid-rollout"`) {
+		t.Error("the pinned case study should be listed first")
+	}
+
+	// Unpinning removes it once the product's cached pages are cleared
+	rec = send(http.MethodDelete, fmt.Sprintf("%s/%d", base, pins[0].ID), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unpin: got %d", rec.Code)
+	}
+	if pins, _ := queries.ListRelatedContentPins(ctx, product.ID); len(pins) != 0 {
+		t.Errorf("expected no pins after unpinning, got %d", len(pins))
+	}
+	appCache.DeleteByPrefix("page:products")
+	if page := get("/products/sensors/sensor-one"); strings.Contains(page, "port-automation") {
+		t.Error("unpinned, unrelated case study should no longer be recommended")
+	}
+
+	// Pins are scoped to their product
+	other, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "SEN-2", Slug: "sensor-two", Name: "Sensor Two", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	send(http.MethodPost, base, url.Values{"content": {fmt.Sprintf("case_study:%d", unrelated.ID)}})
+	pins, _ = queries.ListRelatedContentPins(ctx, product.ID)
+	send(http.MethodDelete, fmt.Sprintf("/admin/products/%d/related/%d", other.ID, pins[0].ID), nil)
+	if pins, _ := queries.ListRelatedContentPins(ctx, product.ID); len(pins) != 1 {
+		t.Error("a pin must not be removable through another product")
+	}
+}
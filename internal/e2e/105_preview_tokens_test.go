@@ -0,0 +1,87 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+// TestPreviewTokens_E2E checks that drafts are only reachable through a
+// signed, unexpired preview link issued for that exact item.
+func TestPreviewTokens_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i", SortOrder: 1,
+	})
+	draft, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "SEN-1", Slug: "sensor-draft", Name: "Sensor Draft", Description: "d", CategoryID: cat.ID, Status: "draft",
+	})
+	if err != nil {
+		t.Fatalf("create draft product: %v", err)
+	}
+	other, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "SEN-2", Slug: "sensor-other", Name: "Sensor Other", Description: "d", CategoryID: cat.ID, Status: "draft",
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	const page = "/products/sensors/sensor-draft"
+	token := testPreviewTokens.Issue(services.PreviewProduct, draft.ID)
+
+	if rec := get(page); rec.Code != http.StatusNotFound {
+		t.Errorf("unpublished product without a token: expected 404, got %d", rec.Code)
+	}
+	if rec := get(page + "?preview=1"); rec.Code != http.StatusNotFound {
+		t.Errorf("?preview=1: expected 404, got %d", rec.Code)
+	}
+
+	rec := get(page + "?preview_token=" + token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("valid token: expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, "no-store") {
+		t.Errorf("preview Cache-Control = %q, want no-store", got)
+	}
+	if got := rec.Header().Get("X-Robots-Tag"); !strings.Contains(got, "noindex") {
+		t.Errorf("preview X-Robots-Tag = %q, want noindex", got)
+	}
+
+	// Tampered and expired links are refused outright
+	tampered := strings.Replace(token, "product.", "solution.", 1)
+	expired := services.NewPreviewTokens("e2e-test-secret-at-least-32-characters-long", time.Millisecond).Issue(services.PreviewProduct, draft.ID)
+	for name, bad := range map[string]string{"tampered": tampered, "expired": expired, "garbage": "nope"} {
+		if rec := get(page + "?preview_token=" + bad); rec.Code != http.StatusForbidden {
+			t.Errorf("%s token: expected 403, got %d", name, rec.Code)
+		}
+	}
+
+	// A token unlocks only the item and content type it was issued for
+	if rec := get("/products/sensors/sensor-other?preview_token=" + token); rec.Code != http.StatusNotFound {
+		t.Errorf("token for another product: expected 404, got %d", rec.Code)
+	}
+	postToken := testPreviewTokens.Issue(services.PreviewBlogPost, other.ID)
+	if rec := get("/products/sensors/sensor-other?preview_token=" + postToken); rec.Code != http.StatusNotFound {
+		t.Errorf("blog post token on a product page: expected 404, got %d", rec.Code)
+	}
+
+	// Published products are unaffected
+	queries.UpdateProduct(ctx, sqlc.UpdateProductParams{
+		ID: draft.ID, Sku: draft.Sku, Slug: draft.Slug, Name: draft.Name, Description: draft.Description, CategoryID: cat.ID, Status: "published",
+	})
+	if rec := get(page); rec.Code != http.StatusOK {
+		t.Errorf("published product: expected 200, got %d", rec.Code)
+	}
+}
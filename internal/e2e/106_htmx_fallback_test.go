@@ -0,0 +1,109 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestHTMXFallback_E2E checks that the HTMX sub-resource editors still work
+// from plain links and forms: writes redirect, _method reaches DELETE routes,
+// tabs render as pages, and validation errors are shown instead of skipped.
+func TestHTMXFallback_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Drives", Slug: "drives", Description: "d", Icon: "i", SortOrder: 1,
+	})
+	product, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "DRV-1", Slug: "drive-1", Name: "Drive 1", Description: "d", CategoryID: cat.ID, Status: "draft",
+	})
+
+	send := func(method, path string, form url.Values, htmx bool, referer string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		if referer != "" {
+			req.Header.Set("Referer", referer)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	specs := fmt.Sprintf("/admin/products/%d/specs", product.ID)
+	spec := url.Values{"section_name": {"Power"}, "spec_key": {"Voltage"}, "spec_value": {"220V"}}
+
+	// HTMX still gets the fragment
+	if rec := send(http.MethodPost, specs, spec, true, ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Voltage") {
+		t.Fatalf("htmx add spec: got %d", rec.Code)
+	}
+
+	// A plain form is redirected to the specs page
+	rec := send(http.MethodPost, specs, spec, false, "")
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != specs {
+		t.Errorf("plain add spec: got %d to %q, want 303 to %s", rec.Code, rec.Header().Get("Location"), specs)
+	}
+	if list, _ := queries.ListProductSpecs(ctx, product.ID); len(list) != 2 {
+		t.Fatalf("expected 2 specs, got %d", len(list))
+	}
+
+	// The tab opens as a page
+	if rec := send(http.MethodGet, specs, nil, false, ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "stub") {
+		t.Errorf("plain specs tab: got %d, expected the fallback page", rec.Code)
+	}
+
+	// _method=DELETE reaches the DELETE route
+	rec = send(http.MethodPost, specs, url.Values{"_method": {"DELETE"}}, false, "")
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("_method delete specs: got %d, want 303", rec.Code)
+	}
+	if list, _ := queries.ListProductSpecs(ctx, product.ID); len(list) != 0 {
+		t.Errorf("expected specs to be deleted, got %d", len(list))
+	}
+
+	// A validation error is shown, not redirected past
+	variants := fmt.Sprintf("/admin/products/%d/variants", product.ID)
+	if rec := send(http.MethodPost, variants, url.Values{"sku": {"DRV-1"}, "name": {"Dup"}}, false, ""); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("plain duplicate variant SKU: got %d, want 422", rec.Code)
+	}
+
+	// Routes without a list page go back where the form was
+	rec = send(http.MethodPost, "/admin/blog/tags/quick-create", url.Values{"name": {"Edge"}}, false, "http://example.com/admin/blog/posts/3/edit?tab=tags")
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/blog/posts/3/edit?tab=tags" {
+		t.Errorf("plain quick-create: got %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+	rec = send(http.MethodPost, "/admin/blog/tags/quick-create", url.Values{"name": {"Offsite"}}, false, "https://evil.example/admin-phish")
+	if loc := rec.Header().Get("Location"); loc != "/admin" {
+		t.Errorf("offsite referer: redirected to %q, want /admin", loc)
+	}
+
+	ind, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Tech", Slug: "tech", Description: "d", Icon: "i", SortOrder: 1})
+	cs, _ := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+		Slug: "cs", Title: "CS", ClientName: "C", IndustryID: ind.ID, Summary: "s",
+		ChallengeTitle: "ch", ChallengeContent: "cc", SolutionTitle: "st", SolutionContent: "sc", OutcomeTitle: "ot", OutcomeContent: "oc",
+	})
+	metric, _ := queries.AdminCreateMetric(ctx, sqlc.AdminCreateMetricParams{CaseStudyID: cs.ID, MetricValue: "30%", MetricLabel: "Efficiency"})
+
+	rec = send(http.MethodPost, fmt.Sprintf("/admin/case-studies/%d/metrics/%d", cs.ID, metric.ID), url.Values{"_method": {"DELETE"}}, false, "")
+	if want := fmt.Sprintf("/admin/case-studies/%d/edit", cs.ID); rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != want {
+		t.Errorf("_method delete metric: got %d to %q, want 303 to %s", rec.Code, rec.Header().Get("Location"), want)
+	}
+	if metrics, _ := queries.AdminListMetrics(ctx, cs.ID); len(metrics) != 0 {
+		t.Errorf("expected the metric to be deleted, got %d", len(metrics))
+	}
+}
@@ -0,0 +1,171 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestPublishingWorkflow_E2E walks a blog post from an editor's draft through
+// review and approval to publication, and checks editors cannot publish from
+// the edit form.
+func TestPublishingWorkflow_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("editorpassword"), bcrypt.DefaultCost)
+	editor, err := queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{
+		Email: "editor@test.com", PasswordHash: string(hash), DisplayName: "Test Editor", Role: "editor",
+	})
+	if err != nil {
+		t.Fatalf("create editor: %v", err)
+	}
+	editorCookie := func() *http.Cookie {
+		req := httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(url.Values{
+			"email": {"editor@test.com"}, "password": {"editorpassword"},
+		}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "bluejay_session" {
+				return c
+			}
+		}
+		t.Fatal("no session cookie after editor login")
+		return nil
+	}()
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{
+		Name: "Tech", Slug: "tech", ColorHex: "#000000", SortOrder: 1,
+	})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{
+		Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1,
+	})
+
+	post := func(cookie *http.Cookie, path string, form url.Values, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	get := func(cookie *http.Cookie, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	status := func(id int64) string {
+		t.Helper()
+		p, err := queries.GetBlogPost(ctx, id)
+		if err != nil {
+			t.Fatalf("get post: %v", err)
+		}
+		return p.Status
+	}
+
+	// Step 1: An editor choosing "published" on the form still saves a draft
+	rec := post(editorCookie, "/admin/blog/posts", url.Values{
+		"title": {"Launch Notes"}, "slug": {"launch-notes"}, "excerpt": {"e"}, "body": {"b"},
+		"category_id": {fmt.Sprint(cat.ID)}, "author_id": {fmt.Sprint(author.ID)}, "status": {"published"},
+	}, false)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("editor create: expected 303, got %d", rec.Code)
+	}
+	posts, _ := queries.ListBlogPostsAdminFiltered(ctx, sqlc.ListBlogPostsAdminFilteredParams{
+		FilterStatus: "", FilterCategory: int64(0), FilterAuthor: int64(0), FilterSearch: "", PageLimit: 15,
+	})
+	if len(posts) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(posts))
+	}
+	id := posts[0].ID
+	if got := status(id); got != "draft" {
+		t.Fatalf("editor-created post status = %q, want draft", got)
+	}
+	panel := fmt.Sprintf("/admin/workflow/blog_post/%d", id)
+
+	// Step 2: The editor cannot publish from the panel either, but can submit
+	if rec := post(editorCookie, panel+"/transition", url.Values{"to": {"published"}}, true); rec.Code != http.StatusOK {
+		t.Fatalf("editor publish: expected the panel with an error, got %d", rec.Code)
+	}
+	if got := status(id); got != "draft" {
+		t.Fatalf("editor publish changed status to %q", got)
+	}
+	if rec := post(editorCookie, panel+"/reviewer", url.Values{"reviewer_id": {"1"}}, true); rec.Code != http.StatusOK {
+		t.Fatalf("assign reviewer: expected 200, got %d", rec.Code)
+	}
+	if rec := post(editorCookie, panel+"/transition", url.Values{"to": {"in_review"}}, true); rec.Code != http.StatusOK {
+		t.Fatalf("submit: expected 200, got %d", rec.Code)
+	}
+	queue, _ := queries.ListReviewQueue(ctx, 1)
+	if len(queue) != 1 || queue[0].State != "in_review" || queue[0].SubmittedBy.Int64 != editor.ID || queue[0].Title != "Launch Notes" {
+		t.Fatalf("review queue for the reviewer = %+v", queue)
+	}
+	if rec := get(adminCookie, "/admin/review-queue?mine=1"); rec.Code != http.StatusOK {
+		t.Errorf("review queue: expected 200, got %d", rec.Code)
+	}
+
+	// Step 3: The admin approves and publishes; the post goes live
+	if rec := post(adminCookie, panel+"/transition", url.Values{"to": {"approved"}, "note": {"Looks good"}}, true); rec.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d", rec.Code)
+	}
+	if got := status(id); got != "draft" {
+		t.Errorf("approved post status = %q, want draft until published", got)
+	}
+	// Without HTMX the panel form redirects back (Post/Redirect/Get)
+	rec = post(adminCookie, panel+"/transition", url.Values{"to": {"published"}}, false)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("publish without HTMX: expected 303, got %d", rec.Code)
+	}
+	p, _ := queries.GetBlogPost(ctx, id)
+	if p.Status != "published" || !p.PublishedAt.Valid {
+		t.Errorf("published post = status %q, published_at %v", p.Status, p.PublishedAt)
+	}
+	if queue, _ := queries.ListReviewQueue(ctx, 0); len(queue) != 0 {
+		t.Errorf("published post should leave the queue, got %d items", len(queue))
+	}
+	history, _ := queries.ListContentWorkflowHistory(ctx, sqlc.ListContentWorkflowHistoryParams{ContentType: "blog_post", ContentID: id})
+	if len(history) != 3 || history[1].Note != "Looks good" {
+		t.Errorf("history = %+v", history)
+	}
+
+	// Step 4: Editors cannot unpublish from the form
+	rec = post(editorCookie, fmt.Sprintf("/admin/blog/posts/%d", id), url.Values{
+		"title": {"Launch Notes"}, "slug": {"launch-notes"}, "excerpt": {"e"}, "body": {"b"},
+		"category_id": {fmt.Sprint(cat.ID)}, "author_id": {fmt.Sprint(author.ID)}, "status": {"draft"},
+	}, false)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("editor update: expected 303, got %d", rec.Code)
+	}
+	if got := status(id); got != "published" {
+		t.Errorf("editor unpublished the post from the form: status %q", got)
+	}
+
+	// Unknown types and items are not found
+	for _, path := range []string{"/admin/workflow/page/1", fmt.Sprintf("/admin/workflow/product/%d", id+100)} {
+		if rec := get(adminCookie, path); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s: expected 404, got %d", path, rec.Code)
+		}
+	}
+}
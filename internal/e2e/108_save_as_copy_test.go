@@ -0,0 +1,70 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestSaveAsCopy_E2E duplicates a product and a case study from their editors
+// and checks the copy's editor is opened.
+func TestSaveAsCopy_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	post := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "SX-1", Slug: "sensor-x", Name: "Sensor X", Description: "d", CategoryID: cat.ID, Status: "published"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: product.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V"})
+
+	rec := post(fmt.Sprintf("/admin/products/%d/duplicate", product.ID))
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("duplicate product: status %d, body %s", rec.Code, rec.Body.String())
+	}
+	cp, err := queries.GetProductBySlug(ctx, "sensor-x-copy")
+	if err != nil {
+		t.Fatalf("copy not found: %v", err)
+	}
+	if want := fmt.Sprintf("/admin/products/%d/edit", cp.ID); rec.Header().Get("Location") != want {
+		t.Errorf("Location = %q, want %q", rec.Header().Get("Location"), want)
+	}
+	if specs, _ := queries.ListProductSpecs(ctx, cp.ID); len(specs) != 1 {
+		t.Errorf("copied specs = %d, want 1", len(specs))
+	}
+
+	industry, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Energy", Slug: "energy", Icon: "bolt", Description: "d"})
+	cs, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+		Slug: "grid-upgrade", Title: "Grid upgrade", ClientName: "Client", IndustryID: industry.ID, Summary: "s",
+		ChallengeContent: "c", SolutionContent: "s", OutcomeContent: "o", IsPublished: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = post(fmt.Sprintf("/admin/case-studies/%d/duplicate", cs.ID))
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("duplicate case study: status %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := post("/admin/products/9999/duplicate"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown product: status %d, want 404", rec.Code)
+	}
+}
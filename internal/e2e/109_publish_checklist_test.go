@@ -0,0 +1,130 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestPublishChecklist_E2E publishes a solution that fails the checklist:
+// it stays a draft until an admin overrides the checklist with a reason.
+func TestPublishChecklist_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("editorpassword"), bcrypt.DefaultCost)
+	if _, err := queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{
+		Email: "editor@test.com", PasswordHash: string(hash), DisplayName: "Test Editor", Role: "editor",
+	}); err != nil {
+		t.Fatalf("create editor: %v", err)
+	}
+	editorCookie := func() *http.Cookie {
+		req := httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(url.Values{
+			"email": {"editor@test.com"}, "password": {"editorpassword"},
+		}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "bluejay_session" {
+				return c
+			}
+		}
+		t.Fatal("no session cookie after editor login")
+		return nil
+	}()
+
+	post := func(cookie *http.Cookie, path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	published := func(id int64) bool {
+		t.Helper()
+		s, err := queries.GetSolutionByID(ctx, id)
+		if err != nil {
+			t.Fatalf("get solution: %v", err)
+		}
+		return s.IsPublished.Bool
+	}
+
+	sol, err := queries.CreateSolution(ctx, sqlc.CreateSolutionParams{Title: "Grid", Slug: "grid", Icon: "bolt", ShortDescription: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := fmt.Sprintf("/admin/solutions/%d", sol.ID)
+	edit := path + "/edit"
+	form := func(extra url.Values) url.Values {
+		v := url.Values{"title": {"Grid"}, "slug": {"grid"}, "icon": {"bolt"}, "short_description": {"s"}, "is_published": {"1"}}
+		for k, vals := range extra {
+			v[k] = vals
+		}
+		return v
+	}
+
+	// Step 1: No meta description or hero image - saved, but still a draft
+	rec := post(adminCookie, path, form(nil))
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != edit+"?publish=blocked" {
+		t.Fatalf("failing publish: got %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if published(sol.ID) {
+		t.Fatal("solution published despite failed checks")
+	}
+
+	// Step 2: Overrides need a reason, and a role that may override
+	rec = post(adminCookie, path, form(url.Values{"publish_override": {"1"}}))
+	if rec.Header().Get("Location") != edit+"?publish=reason" || published(sol.ID) {
+		t.Fatalf("override without reason: got %q", rec.Header().Get("Location"))
+	}
+	rec = post(editorCookie, path, form(url.Values{"publish_override": {"1"}, "publish_override_reason": {"Launch day"}}))
+	if rec.Header().Get("Location") != edit+"?publish=blocked" || published(sol.ID) {
+		t.Fatalf("editor override: got %q", rec.Header().Get("Location"))
+	}
+
+	// Step 3: The admin overrides with a reason; the reason is logged
+	rec = post(adminCookie, path, form(url.Values{"publish_override": {"1"}, "publish_override_reason": {"Launch day, hero image follows"}}))
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/solutions" {
+		t.Fatalf("admin override: got %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if !published(sol.ID) {
+		t.Fatal("override did not publish")
+	}
+	logs, _ := queries.ListActivityLogs(ctx, sqlc.ListActivityLogsParams{FilterAction: "published", PageLimit: 10})
+	if len(logs) != 1 || !strings.Contains(logs[0].Description, "Launch day, hero image follows") || !strings.Contains(logs[0].Description, "Hero image set") {
+		t.Fatalf("override log = %+v", logs)
+	}
+
+	// A complete solution publishes directly
+	rec = post(adminCookie, "/admin/solutions", url.Values{
+		"title": {"Metering"}, "icon": {"bolt"}, "short_description": {"s"}, "is_published": {"1"},
+		"meta_description": {"Smart metering"}, "hero_image_url": {"https://cdn.example.com/hero.jpg"},
+	})
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/solutions" {
+		t.Fatalf("complete create: got %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// The checklist re-runs with the form's current values
+	if rec := post(editorCookie, "/admin/publish-checklist/solutions", form(nil)); rec.Code != http.StatusOK {
+		t.Errorf("re-check: expected 200, got %d", rec.Code)
+	}
+	if rec := post(editorCookie, "/admin/publish-checklist/products", form(nil)); rec.Code != http.StatusNotFound {
+		t.Errorf("re-check of an unsupported type: expected 404, got %d", rec.Code)
+	}
+}
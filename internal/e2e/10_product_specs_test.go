@@ -91,6 +91,7 @@ func TestProductSpecsAdd_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -156,6 +157,7 @@ func TestProductSpecsDeleteAll_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/products/%d/specs", product.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -262,6 +264,7 @@ func TestProductSpecsEditForm_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/products/%d/specs?edit=%d", product.ID, spec.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -303,6 +306,7 @@ func TestProductSpecsUpdate_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
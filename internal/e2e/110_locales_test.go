@@ -0,0 +1,159 @@
+package e2e_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestLocales_E2E covers multi-language sites with the REAL renderer: locales
+// are managed under Admin > Languages, page sections are translated from the
+// language tabs, and public pages are served under /<locale>/ prefixes with a
+// language switcher.
+func TestLocales_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+
+	appCache := services.NewCache()
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, logger))
+	translationSvc := services.NewTranslationService(queries, logger, "en", []string{"de"})
+	if err := translationSvc.SyncLocales(ctx); err != nil {
+		t.Fatalf("sync locales: %v", err)
+	}
+	publicHandlers.SetTranslationService(translationSvc)
+	adminHandlers.SetTranslationService(translationSvc)
+	t.Cleanup(func() {
+		publicHandlers.SetTranslationService(nil)
+		adminHandlers.SetTranslationService(nil)
+	})
+	e.Pre(customMiddleware.LocalePrefix(translationSvc))
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Locale(translationSvc))
+	productsHandler := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products", productsHandler.ProductsList)
+
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	psHandler := adminHandlers.NewPageSectionsHandler(queries, logger)
+	adminGroup.GET("/page-sections/:id/edit", psHandler.Edit)
+	translationsHandler := adminHandlers.NewTranslationsHandler(queries, logger, translationSvc, appCache)
+	adminGroup.GET("/translations/:type/:id/:locale", translationsHandler.Edit)
+	adminGroup.POST("/translations/:type/:id/:locale", translationsHandler.Save)
+	adminGroup.GET("/languages", translationsHandler.Languages)
+	adminGroup.POST("/languages", translationsHandler.AddLanguage)
+	adminGroup.POST("/languages/:code", translationsHandler.UpdateLanguage)
+
+	cookie := loginTabsAdmin(t, e, queries)
+	do := func(method, path string, form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		if form != nil {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Step 1: SITE_LOCALES seeded the table; admins add and validate languages
+	if rec := do(http.MethodPost, "/admin/languages", url.Values{"code": {"fr"}}, cookie); rec.Code != http.StatusSeeOther {
+		t.Fatalf("add language: got %d", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/admin/languages", url.Values{"code": {"french"}}, cookie); !strings.Contains(rec.Header().Get("Location"), "error=") {
+		t.Errorf("invalid code: redirected to %q, want an error", rec.Header().Get("Location"))
+	}
+	if rec := do(http.MethodPost, "/admin/languages/en", url.Values{"name": {"English"}}, cookie); !strings.Contains(rec.Header().Get("Location"), "error=") {
+		t.Errorf("disabling the default: redirected to %q, want an error", rec.Header().Get("Location"))
+	}
+	rec := do(http.MethodGet, "/admin/languages", nil, cookie)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Deutsch") || !strings.Contains(rec.Body.String(), "Français") {
+		t.Fatalf("languages page: got %d", rec.Code)
+	}
+
+	// Step 2: The page section edit form links to each language's editor
+	hero, err := queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "products", SectionKey: "hero"})
+	if err != nil {
+		t.Fatalf("products hero: %v", err)
+	}
+	id := strconv.FormatInt(hero.ID, 10)
+	rec = do(http.MethodGet, "/admin/page-sections/"+id+"/edit", nil, cookie)
+	if !strings.Contains(rec.Body.String(), `href="/admin/translations/page_section/`+id+`/de"`) {
+		t.Error("page section form should have a Deutsch tab")
+	}
+
+	// Step 3: Translate the hero into German
+	form := url.Values{"heading": {"Unsere Produkte"}}
+	for _, f := range []string{"subheading", "description", "label", "primary_button_text", "secondary_button_text"} {
+		form.Set(f, "de "+f)
+	}
+	if rec := do(http.MethodPost, "/admin/translations/page_section/"+id+"/de", form, cookie); rec.Code != http.StatusSeeOther {
+		t.Fatalf("save translation: got %d", rec.Code)
+	}
+
+	// Step 4: /de/ serves the translation and remembers the choice
+	rec = do(http.MethodGet, "/de/products", nil)
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Unsere Produkte") || !strings.Contains(body, `lang="de"`) {
+		t.Fatalf("/de/products: got %d, want the German hero", rec.Code)
+	}
+	if !strings.Contains(body, `href="/fr/products"`) || !strings.Contains(body, `hreflang="en" href="https://bluejaylabs.com/en/products"`) {
+		t.Error("expected switcher links and hreflang alternates")
+	}
+	var langCookie *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "lang" {
+			langCookie = c
+		}
+	}
+	if langCookie == nil || langCookie.Value != "de" {
+		t.Fatalf("lang cookie = %v, want de", langCookie)
+	}
+	if body := do(http.MethodGet, "/products", nil, langCookie).Body.String(); !strings.Contains(body, "Unsere Produkte") {
+		t.Error("unprefixed links should keep the remembered language")
+	}
+	if body := do(http.MethodGet, "/products", nil).Body.String(); strings.Contains(body, "Unsere Produkte") {
+		t.Error("the default locale should render the source heading")
+	}
+	if body := do(http.MethodGet, "/fr/products", nil).Body.String(); !strings.Contains(body, hero.Heading) {
+		t.Error("an untranslated locale should fall back to the source heading")
+	}
+
+	// Step 5: Disabled languages lose their prefix
+	if rec := do(http.MethodPost, "/admin/languages/de", url.Values{"name": {"Deutsch"}, "sort_order": {"1"}}, cookie); rec.Code != http.StatusSeeOther {
+		t.Fatalf("disable de: got %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/de/products", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("/de/products after disabling de: got %d, want 404", rec.Code)
+	}
+}
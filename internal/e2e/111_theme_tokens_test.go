@@ -0,0 +1,151 @@
+package e2e_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestThemeTokens_E2E covers dark mode with the REAL renderer: theme colors
+// are edited on the Theme tab of Global Settings (with a preview of both
+// modes), served as CSS variables from /theme-tokens.css, and linked with
+// the visitor toggle from the public and admin layouts.
+func TestThemeTokens_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+
+	appCache := services.NewCache()
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, logger))
+	e.GET("/theme-tokens.css", publicHandlers.NewThemeHandler(queries, logger).Tokens)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	productsHandler := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products", productsHandler.ProductsList)
+
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	settingsHandler := adminHandlers.NewSettingsHandler(queries, logger, appCache)
+	adminGroup.GET("/settings", settingsHandler.Edit)
+	adminGroup.POST("/settings", settingsHandler.Update)
+
+	cookie := loginTabsAdmin(t, e, queries)
+	get := func(path string, header ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if len(header) == 2 {
+			req.Header.Set(header[0], header[1])
+		}
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Defaults: light mode, brand colors the templates were designed with
+	rec := get("/theme-tokens.css")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), "text/css") {
+		t.Fatalf("tokens: %d %q", rec.Code, rec.Header().Get(echo.HeaderContentType))
+	}
+	if !strings.Contains(rec.Body.String(), "--color-primary: #0066CC;") {
+		t.Error("default light primary missing")
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" || rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("tokens should revalidate by ETag: etag=%q cache-control=%q", etag, rec.Header().Get("Cache-Control"))
+	}
+	if rec := get("/theme-tokens.css", "If-None-Match", etag); rec.Code != http.StatusNotModified {
+		t.Errorf("matching ETag: expected 304, got %d", rec.Code)
+	}
+
+	// The Theme tab shows the color inputs and a preview of each mode
+	rec = get("/admin/settings?tab=theme")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("settings page: %d %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`id="tab-theme"`,
+		`name="theme_mode"`,
+		`name="theme_dark_surface" value="#1A1D23"`,
+		`data-theme="light" class="theme-preview`,
+		`data-theme="dark" class="theme-preview`,
+		`href="/?theme=dark"`,
+		`href="/theme-tokens.css"`,
+		`data-theme-toggle`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("settings page missing %q", want)
+		}
+	}
+
+	// Save: valid colors are stored upper-cased, malformed ones keep the saved value
+	form := url.Values{
+		"active_tab":          {"theme"},
+		"site_name":           {"BlueJay"},
+		"theme_mode":          {"dark"},
+		"theme_light_primary": {"#ff6600"},
+		"theme_dark_text":     {"white"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/admin/settings", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get("Location"), "tab=theme") {
+		t.Fatalf("save: %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	settings, _ := queries.GetSettings(ctx)
+	if settings.ThemeMode != "dark" || settings.ThemeLightPrimary != "#FF6600" {
+		t.Errorf("theme not saved: mode=%q primary=%q", settings.ThemeMode, settings.ThemeLightPrimary)
+	}
+	if settings.ThemeDarkText != "#E6E8EB" {
+		t.Errorf("malformed color should keep the saved value, got %q", settings.ThemeDarkText)
+	}
+
+	// The stylesheet follows the settings: new ETag, dark by default
+	rec = get("/theme-tokens.css", "If-None-Match", etag)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("changed theme: expected 200, got %d", rec.Code)
+	}
+	css := rec.Body.String()
+	if !strings.Contains(css, "--color-primary: #FF6600;") || !strings.Contains(css, ":root:not([data-theme]) .bg-white") {
+		t.Error("stylesheet should use the saved primary and default to dark mode")
+	}
+
+	// Public pages link the tokens and the visitor toggle
+	rec = get("/products")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("products: %d", rec.Code)
+	}
+	body = rec.Body.String()
+	for _, want := range []string{`href="/theme-tokens.css"`, `src="/public/js/theme.js"`, `data-theme-toggle`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("public page missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestSiteTimezone_E2E covers the site timezone: it is set under Global
+// Settings, admin date inputs are read in it and stored as UTC, and
+// timestamps are displayed in it (or in the admin's own zone where noted).
+func TestSiteTimezone_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	t.Cleanup(func() { services.SetSiteTimezone(services.DefaultTimezone) })
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if settings, _ := queries.GetSettings(ctx); settings.Timezone != "UTC" {
+		t.Fatalf("default timezone = %q, want UTC", settings.Timezone)
+	}
+
+	// Saving a valid zone stores it and applies it immediately
+	if rec := post("/admin/settings", url.Values{"site_name": {"BlueJay"}, "timezone": {"Asia/Tokyo"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("save settings: %d", rec.Code)
+	}
+	settings, _ := queries.GetSettings(ctx)
+	if settings.Timezone != "Asia/Tokyo" || services.SiteLocation().String() != "Asia/Tokyo" {
+		t.Fatalf("timezone not applied: saved=%q active=%q", settings.Timezone, services.SiteLocation())
+	}
+
+	// Unknown names keep the saved zone
+	post("/admin/settings", url.Values{"site_name": {"BlueJay"}, "timezone": {"Mars/Olympus"}})
+	if settings, _ := queries.GetSettings(ctx); settings.Timezone != "Asia/Tokyo" {
+		t.Errorf("invalid zone should keep the saved value, got %q", settings.Timezone)
+	}
+
+	// A publish date entered as 09:30 Tokyo time is stored as 00:30 UTC
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{
+		Name: "Tech", Slug: "tech", ColorHex: "#000000", SortOrder: 1,
+	})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{
+		Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1,
+	})
+	rec := post("/admin/blog/posts", url.Values{
+		"title":        {"Tokyo Launch"},
+		"slug":         {"tokyo-launch"},
+		"excerpt":      {"Excerpt"},
+		"body":         {"Body"},
+		"category_id":  {fmt.Sprintf("%d", cat.ID)},
+		"author_id":    {fmt.Sprintf("%d", author.ID)},
+		"status":       {"published"},
+		"published_at": {"2026-03-01T09:30"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create post: %d %s", rec.Code, rec.Body.String())
+	}
+	stored, err := queries.GetPublishedPostBySlug(ctx, "tokyo-launch")
+	if err != nil {
+		t.Fatalf("post not created: %v", err)
+	}
+	if want := time.Date(2026, 3, 1, 0, 30, 0, 0, time.UTC); !stored.PublishedAt.Time.Equal(want) {
+		t.Errorf("published_at = %v, want %v", stored.PublishedAt.Time, want)
+	}
+
+	// The activity log shows the admin's own zone, with the site time on hover
+	logs := []sqlc.ListActivityLogsRow{{
+		Action:    "updated",
+		CreatedAt: sql.NullTime{Time: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), Valid: true},
+	}}
+	render := func(visitorZone string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := templates.NewRenderer("templates").Render(&buf, "admin/pages/activity_log.html", map[string]interface{}{
+			"Title": "Activity Log", "Logs": logs, "VisitorTimezone": visitorZone,
+			"Filters": map[string]interface{}{"User": int64(0), "ResourceType": "", "Action": "", "Search": "", "From": "", "To": ""},
+			"Page":    1, "TotalPages": 1, "Pages": []int{1}, "Total": int64(1), "ShowFrom": int64(1), "ShowTo": int64(1),
+		}, nil); err != nil {
+			t.Fatalf("render activity log: %v", err)
+		}
+		return buf.String()
+	}
+	html := render("Europe/Berlin")
+	for _, want := range []string{`datetime="2026-03-01T12:00:00Z"`, `title="Site time: Mar 01, 2026 9:00 PM JST"`, ">Mar 01, 2026 1:00 PM CET</time>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("activity log (Berlin visitor): expected %q", want)
+		}
+	}
+	if html := render(""); !strings.Contains(html, ">Mar 01, 2026 9:00 PM JST</time>") {
+		t.Error("activity log without a visitor zone should use the site zone")
+	}
+}
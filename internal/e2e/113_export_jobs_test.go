@@ -0,0 +1,140 @@
+package e2e_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestExportJobs_E2E queues a product export from the admin, runs it on the
+// queue, follows the status page to the download link and checks that links
+// are private to their requester and stop working when they expire.
+func TestExportJobs_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, logger))
+	jobs := services.NewExportJobs(queries, services.NewLocalStorage(t.TempDir()), logger, nil, "http://localhost", time.Hour)
+	jobs.Register(services.ExportProducts, services.ProductExportSource(queries))
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	h := adminHandlers.NewExportsHandler(queries, logger, jobs)
+	adminGroup.GET("/exports", h.List)
+	adminGroup.POST("/exports", h.Start)
+	adminGroup.GET("/exports/:id", h.Status)
+	adminGroup.GET("/exports/:id/download", h.Download)
+
+	cookie := loginTabsAdmin(t, e, queries)
+	do := func(c *http.Cookie, method, path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		if form != nil {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		req.AddCookie(c)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cameras", Slug: "cameras", Description: "desc"})
+	if _, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "CAM-1", Slug: "cam-1", Name: "Camera One", Description: "desc",
+		CategoryID: cat.ID, Status: "published", IsFeatured: false,
+	}); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+
+	if rec := do(cookie, http.MethodPost, "/admin/exports", url.Values{"kind": {"nope"}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown export kind: %d", rec.Code)
+	}
+
+	rec := do(cookie, http.MethodPost, "/admin/exports", url.Values{"kind": {"products"}, "format": {"csv"}})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("start export: %d %s", rec.Code, rec.Body.String())
+	}
+	statusURL := rec.Header().Get("Location")
+
+	// Until the worker picks it up the page polls and has no download link
+	body := do(cookie, http.MethodGet, statusURL, nil).Body.String()
+	if !strings.Contains(body, `hx-trigger="every 2s"`) || strings.Contains(body, "/download") {
+		t.Error("queued export should poll without a download link")
+	}
+	if rec := do(cookie, http.MethodGet, statusURL+"/download", nil); rec.Code != http.StatusConflict {
+		t.Errorf("download before finishing: %d", rec.Code)
+	}
+
+	if ran, err := jobs.RunNext(ctx); !ran || err != nil {
+		t.Fatalf("RunNext = %v, %v", ran, err)
+	}
+	body = do(cookie, http.MethodGet, statusURL, nil).Body.String()
+	if strings.Contains(body, `hx-trigger="every 2s"`) || !strings.Contains(body, statusURL+"/download") {
+		t.Error("finished export should stop polling and link the download")
+	}
+	if body := do(cookie, http.MethodGet, "/admin/exports", nil).Body.String(); !strings.Contains(body, "Ready, 1 rows") {
+		t.Error("exports list should show the finished export")
+	}
+
+	rec = do(cookie, http.MethodGet, statusURL+"/download", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("download: %d", rec.Code)
+	}
+	if cd := rec.Header().Get(echo.HeaderContentDisposition); !strings.Contains(cd, `filename="products-`) {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	if !strings.Contains(rec.Body.String(), "CAM-1") {
+		t.Error("download should contain the product")
+	}
+
+	// Another non-admin user cannot see the export
+	hash, _ := bcrypt.GenerateFromPassword([]byte("editorpassword"), bcrypt.DefaultCost)
+	queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{Email: "editor@test.com", PasswordHash: string(hash), DisplayName: "Editor", Role: "editor"})
+	login := httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(url.Values{"email": {"editor@test.com"}, "password": {"editorpassword"}}.Encode()))
+	login.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	loginRec := httptest.NewRecorder()
+	e.ServeHTTP(loginRec, login)
+	var editor *http.Cookie
+	for _, c := range loginRec.Result().Cookies() {
+		if c.Name == "bluejay_session" {
+			editor = c
+		}
+	}
+	if editor == nil {
+		t.Fatal("no editor session cookie")
+	}
+	if rec := do(editor, http.MethodGet, statusURL+"/download", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("other user's download: %d, want 404", rec.Code)
+	}
+
+	// Once the link expires the file is purged and the download is gone
+	if n, _ := jobs.PurgeExpired(ctx, time.Now().Add(2*time.Hour)); n != 1 {
+		t.Fatalf("expected 1 purged export, got %d", n)
+	}
+	if rec := do(cookie, http.MethodGet, statusURL+"/download", nil); rec.Code != http.StatusGone {
+		t.Errorf("expired download: %d, want 410", rec.Code)
+	}
+}
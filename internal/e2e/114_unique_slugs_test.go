@@ -0,0 +1,105 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestUniqueSlugs_E2E checks that admin create and update handlers store
+// transliterated slugs and suffix them instead of failing on duplicates.
+func TestUniqueSlugs_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Product categories: names that slugify alike get -2
+	for _, name := range []string{"Capteurs Thermiques Élevés", "Capteurs thermiques (élevés)"} {
+		if rec := post("/admin/product-categories", url.Values{
+			"name": {name}, "description": {"d"}, "icon": {"i"}, "sort_order": {"1"},
+		}); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create category %q: %d %s", name, rec.Code, rec.Body.String())
+		}
+	}
+	for _, slug := range []string{"capteurs-thermiques-eleves", "capteurs-thermiques-eleves-2"} {
+		if _, err := queries.GetProductCategoryBySlug(ctx, slug); err != nil {
+			t.Errorf("expected category slug %q: %v", slug, err)
+		}
+	}
+
+	// Products: renaming onto another product's name suffixes, re-saving keeps the slug
+	cat, _ := queries.GetProductCategoryBySlug(ctx, "capteurs-thermiques-eleves")
+	create := func(sku, name string) {
+		t.Helper()
+		if rec := post("/admin/products", url.Values{
+			"sku": {sku}, "name": {name}, "description": {"d"},
+			"category_id": {fmt.Sprintf("%d", cat.ID)}, "status": {"draft"},
+		}); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create product %s: %d %s", sku, rec.Code, rec.Body.String())
+		}
+	}
+	create("S-1", "Sensor Pro")
+	create("S-2", "Sensor Lite")
+	lite, err := queries.GetProductBySKU(ctx, "S-2")
+	if err != nil || lite.Slug != "sensor-lite" {
+		t.Fatalf("second product slug = %q (%v)", lite.Slug, err)
+	}
+	update := func(name string) string {
+		t.Helper()
+		if rec := post(fmt.Sprintf("/admin/products/%d", lite.ID), url.Values{
+			"sku": {"S-2"}, "name": {name}, "description": {"d"},
+			"category_id": {fmt.Sprintf("%d", cat.ID)}, "status": {"draft"},
+		}); rec.Code != http.StatusSeeOther {
+			t.Fatalf("update product: %d %s", rec.Code, rec.Body.String())
+		}
+		p, _ := queries.GetProductBySKU(ctx, "S-2")
+		return p.Slug
+	}
+	if got := update("Sensor Pro"); got != "sensor-pro-2" {
+		t.Errorf("renamed product slug = %q, want sensor-pro-2", got)
+	}
+	if got := update("Sensor Pro"); got != "sensor-pro-2" {
+		t.Errorf("re-saved product slug = %q, want sensor-pro-2", got)
+	}
+
+	// Blog posts: a submitted slug is normalized and made unique too
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "A", Slug: "a", Title: "W", SortOrder: 1})
+	category, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "C", Slug: "c", ColorHex: "#000000", SortOrder: 1})
+	for _, slug := range []string{"Über Uns!", "uber-uns"} {
+		if rec := post("/admin/blog/posts", url.Values{
+			"title": {"Post"}, "slug": {slug}, "excerpt": {"e"}, "body": {"b"}, "status": {"draft"},
+			"category_id": {fmt.Sprintf("%d", category.ID)}, "author_id": {fmt.Sprintf("%d", author.ID)},
+		}); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create post %q: %d %s", slug, rec.Code, rec.Body.String())
+		}
+	}
+	var slugs []string
+	for _, slug := range []string{"uber-uns", "uber-uns-2"} {
+		if _, err := queries.GetPostBySlugIncludeDrafts(ctx, slug); err == nil {
+			slugs = append(slugs, slug)
+		}
+	}
+	if len(slugs) != 2 {
+		t.Errorf("blog post slugs = %v, want uber-uns and uber-uns-2", slugs)
+	}
+}
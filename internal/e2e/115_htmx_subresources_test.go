@@ -0,0 +1,397 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// htmxFlows is an admin app with the REAL template renderer and the HTMX
+// sub-resource endpoints, so tests can assert the partial each request swaps
+// into the page. The shared setupApp uses a stub renderer.
+type htmxFlows struct {
+	t       *testing.T
+	e       *echo.Echo
+	queries *sqlc.Queries
+	cache   *services.Cache
+	cookie  *http.Cookie
+}
+
+func setupHTMXFlows(t *testing.T) (*htmxFlows, func()) {
+	t.Helper()
+
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, logger))
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	admin := e.Group("/admin", customMiddleware.RequireAuth())
+
+	cache := services.NewCache()
+	solutions := adminHandlers.NewSolutionsHandler(queries, logger, cache, services.NewUploadService(t.TempDir()))
+	admin.GET("/solutions/:id/stats-tab", solutions.StatsTab)
+	admin.POST("/solutions/:id/stats", solutions.AddStat)
+	admin.POST("/solutions/:id/stats/:statId", solutions.UpdateStat)
+	admin.DELETE("/solutions/:id/stats/:statId", solutions.DeleteStat)
+	admin.POST("/solutions/:id/challenges", solutions.AddChallenge)
+	admin.POST("/solutions/:id/challenges/:challengeId", solutions.UpdateChallenge)
+	admin.DELETE("/solutions/:id/challenges/:challengeId", solutions.DeleteChallenge)
+	admin.POST("/solutions/:id/products", solutions.AddProduct)
+	admin.POST("/solutions/:id/products/:productId", solutions.UpdateProduct)
+	admin.DELETE("/solutions/:id/products/:productId", solutions.RemoveProduct)
+	admin.POST("/solutions/:id/ctas", solutions.AddCTA)
+	admin.POST("/solutions/:id/ctas/:ctaId", solutions.UpdateCTA)
+	admin.DELETE("/solutions/:id/ctas/:ctaId", solutions.DeleteCTA)
+
+	caseStudies := adminHandlers.NewCaseStudiesHandler(queries, logger, cache)
+	admin.POST("/case-studies/:id/products", caseStudies.AddProduct)
+	admin.DELETE("/case-studies/:id/products/:productId", caseStudies.RemoveProduct)
+	admin.POST("/case-studies/:id/metrics", caseStudies.AddMetric)
+	admin.DELETE("/case-studies/:id/metrics/:metricId", caseStudies.DeleteMetric)
+
+	tags := adminHandlers.NewBlogTagsHandler(queries, logger)
+	admin.GET("/blog/tags/search", tags.Search)
+	admin.POST("/blog/tags/quick-create", tags.QuickCreate)
+
+	media := adminHandlers.NewMediaHandler(queries, logger, t.TempDir())
+	admin.POST("/media/upload", media.Upload)
+	admin.GET("/media/browse", media.Browse)
+	admin.PUT("/media/:id", media.UpdateAltText)
+
+	f := &htmxFlows{t: t, e: e, queries: queries, cache: cache}
+	f.cookie = loginTabsAdmin(t, e, queries)
+	return f, cleanup
+}
+
+// do sends an HTMX request (HX-Request: true) as the logged-in admin.
+func (f *htmxFlows) do(method, path string, form url.Values) *httptest.ResponseRecorder {
+	f.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	if form != nil {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	}
+	req.Header.Set("HX-Request", "true")
+	req.AddCookie(f.cookie)
+	rec := httptest.NewRecorder()
+	f.e.ServeHTTP(rec, req)
+	return rec
+}
+
+// partial sends an HTMX request that must answer 200 with a fragment, not a
+// full page, and returns the fragment.
+func (f *htmxFlows) partial(method, path string, form url.Values) string {
+	f.t.Helper()
+	rec := f.do(method, path, form)
+	if rec.Code != http.StatusOK {
+		f.t.Fatalf("%s %s: %d %s", method, path, rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "<html") {
+		f.t.Fatalf("%s %s: expected a partial, got a full page", method, path)
+	}
+	return body
+}
+
+// expectInvalidates runs action and checks that it drops the cached public
+// page key (a key under the prefix the public pages are cached with), stored
+// with the cache tags its public handler gives it.
+func (f *htmxFlows) expectInvalidates(key string, action func(), tags ...string) {
+	f.t.Helper()
+	f.cache.Set(key, "stale", 300)
+	f.cache.Tag(key, tags...)
+	action()
+	if _, ok := f.cache.Get(key); ok {
+		f.t.Errorf("expected %s to be invalidated", key)
+	}
+}
+
+func contains(t *testing.T, what, body string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(body, want) {
+			t.Errorf("%s: expected %q", what, want)
+		}
+	}
+}
+
+func TestHTMXSolutionSubResources_E2E(t *testing.T) {
+	f, cleanup := setupHTMXFlows(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sol, _ := f.queries.CreateSolution(ctx, sqlc.CreateSolutionParams{Title: "Edge", Slug: "edge", ShortDescription: "d"})
+	base := fmt.Sprintf("/admin/solutions/%d", sol.ID)
+	const cacheKey = "page:solutions:edge"
+	solutionTag := services.SolutionTag(sol.ID)
+
+	t.Run("stats", func(t *testing.T) {
+		var body string
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, base+"/stats", url.Values{"value": {"99%"}, "label": {"Uptime"}, "display_order": {"1"}})
+		}, solutionTag)
+		stats, _ := f.queries.GetSolutionStats(ctx, sol.ID)
+		if len(stats) != 1 {
+			t.Fatalf("expected 1 stat, got %d", len(stats))
+		}
+		statURL := fmt.Sprintf("%s/stats/%d", base, stats[0].ID)
+		contains(t, "add stat", body, `id="stats-section"`, "99%", "Uptime", `hx-delete="`+statURL+`"`)
+
+		// The edit link swaps in an inline form posting to the stat
+		contains(t, "edit stat", f.partial(http.MethodGet, fmt.Sprintf("%s/stats-tab?edit=%d", base, stats[0].ID), nil),
+			`hx-post="`+statURL+`"`)
+
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, statURL, url.Values{"value": {"98%"}, "label": {"Availability"}, "display_order": {"2"}})
+		}, solutionTag)
+		contains(t, "update stat", body, "98%", "Availability")
+		if strings.Contains(body, "Uptime") {
+			t.Error("update stat: old label still rendered")
+		}
+
+		f.expectInvalidates(cacheKey, func() {
+			if rec := f.do(http.MethodDelete, statURL, nil); rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+				t.Errorf("delete stat: %d %q, want an empty 200 so HTMX removes the row", rec.Code, rec.Body.String())
+			}
+		}, solutionTag)
+		if stats, _ := f.queries.GetSolutionStats(ctx, sol.ID); len(stats) != 0 {
+			t.Errorf("expected the stat to be deleted, got %d", len(stats))
+		}
+	})
+
+	t.Run("challenges", func(t *testing.T) {
+		var body string
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, base+"/challenges", url.Values{"title": {"Slow Lines"}, "description": {"Manual checks"}, "icon": {"speed"}})
+		}, solutionTag)
+		challenges, _ := f.queries.GetSolutionChallenges(ctx, sol.ID)
+		if len(challenges) != 1 {
+			t.Fatalf("expected 1 challenge, got %d", len(challenges))
+		}
+		challengeURL := fmt.Sprintf("%s/challenges/%d", base, challenges[0].ID)
+		contains(t, "add challenge", body, "Slow Lines", `hx-delete="`+challengeURL+`"`)
+
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, challengeURL, url.Values{"title": {"Fast Lines"}, "description": {"Automated"}, "icon": {"bolt"}})
+		}, solutionTag)
+		contains(t, "update challenge", body, "Fast Lines")
+		if challenges, _ := f.queries.GetSolutionChallenges(ctx, sol.ID); challenges[0].Description != "Automated" {
+			t.Errorf("challenge description = %q", challenges[0].Description)
+		}
+
+		f.expectInvalidates(cacheKey, func() { f.do(http.MethodDelete, challengeURL, nil) }, solutionTag)
+		if challenges, _ := f.queries.GetSolutionChallenges(ctx, sol.ID); len(challenges) != 0 {
+			t.Errorf("expected the challenge to be deleted, got %d", len(challenges))
+		}
+	})
+
+	t.Run("products", func(t *testing.T) {
+		cat, _ := f.queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+		product, _ := f.queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: "SN-1", Slug: "sn-1", Name: "Thermal Sensor", Description: "d", CategoryID: cat.ID, Status: "published",
+		})
+		productURL := fmt.Sprintf("%s/products/%d", base, product.ID)
+
+		var body string
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, base+"/products", url.Values{"product_id": {fmt.Sprint(product.ID)}, "display_order": {"1"}})
+		}, solutionTag)
+		contains(t, "add product", body, `id="products-section"`, "Thermal Sensor", `hx-delete="`+productURL+`"`)
+
+		f.expectInvalidates(cacheKey, func() {
+			f.partial(http.MethodPost, productURL, url.Values{"display_order": {"3"}, "is_featured": {"on"}})
+		}, solutionTag)
+		linked, _ := f.queries.GetSolutionProducts(ctx, sol.ID)
+		if len(linked) != 1 || !linked[0].IsFeatured.Bool || linked[0].DisplayOrder.Int64 != 3 {
+			t.Errorf("unexpected solution product after update: %+v", linked)
+		}
+
+		f.expectInvalidates(cacheKey, func() { f.do(http.MethodDelete, productURL, nil) }, solutionTag)
+		if linked, _ := f.queries.GetSolutionProducts(ctx, sol.ID); len(linked) != 0 {
+			t.Errorf("expected the product to be unlinked, got %d", len(linked))
+		}
+	})
+
+	t.Run("ctas", func(t *testing.T) {
+		var body string
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, base+"/ctas", url.Values{
+				"heading": {"Book a Demo"}, "primary_button_text": {"Contact"}, "primary_button_url": {"/contact"}, "section_name": {"main"},
+			})
+		}, solutionTag)
+		ctas, _ := f.queries.GetSolutionCTAs(ctx, sol.ID)
+		if len(ctas) != 1 {
+			t.Fatalf("expected 1 CTA, got %d", len(ctas))
+		}
+		ctaURL := fmt.Sprintf("%s/ctas/%d", base, ctas[0].ID)
+		contains(t, "add CTA", body, "Book a Demo", `hx-delete="`+ctaURL+`"`)
+
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, ctaURL, url.Values{"heading": {"Talk to Sales"}, "section_name": {"main"}})
+		}, solutionTag)
+		contains(t, "update CTA", body, "Talk to Sales")
+		if ctas, _ := f.queries.GetSolutionCTAs(ctx, sol.ID); ctas[0].PrimaryButtonText.Valid {
+			t.Error("clearing the button text should store NULL")
+		}
+
+		f.expectInvalidates(cacheKey, func() { f.do(http.MethodDelete, ctaURL, nil) }, solutionTag)
+		if ctas, _ := f.queries.GetSolutionCTAs(ctx, sol.ID); len(ctas) != 0 {
+			t.Errorf("expected the CTA to be deleted, got %d", len(ctas))
+		}
+	})
+}
+
+func TestHTMXCaseStudySubResources_E2E(t *testing.T) {
+	f, cleanup := setupHTMXFlows(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ind, _ := f.queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Energy", Slug: "energy", Description: "d", Icon: "i", SortOrder: 1})
+	cs, _ := f.queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+		Slug: "grid", Title: "Grid Upgrade", ClientName: "GridCo", IndustryID: ind.ID, Summary: "s",
+		ChallengeTitle: "ct", ChallengeContent: "cc", SolutionTitle: "st", SolutionContent: "sc",
+		OutcomeTitle: "ot", OutcomeContent: "oc",
+	})
+	base := fmt.Sprintf("/admin/case-studies/%d", cs.ID)
+	const cacheKey = "page:case-studies:grid"
+
+	t.Run("metrics", func(t *testing.T) {
+		var body string
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, base+"/metrics", url.Values{"metric_value": {"40%"}, "metric_label": {"Cost Reduction"}, "display_order": {"1"}})
+		})
+		metrics, _ := f.queries.AdminListMetrics(ctx, cs.ID)
+		if len(metrics) != 1 {
+			t.Fatalf("expected 1 metric, got %d", len(metrics))
+		}
+		metricURL := fmt.Sprintf("%s/metrics/%d", base, metrics[0].ID)
+		contains(t, "add metric", body, "40%", "Cost Reduction", `hx-delete="`+metricURL+`"`)
+
+		f.expectInvalidates(cacheKey, func() {
+			if rec := f.do(http.MethodDelete, metricURL, nil); rec.Code != http.StatusNoContent {
+				t.Errorf("delete metric: %d, want 204", rec.Code)
+			}
+		})
+		if metrics, _ := f.queries.AdminListMetrics(ctx, cs.ID); len(metrics) != 0 {
+			t.Errorf("expected the metric to be deleted, got %d", len(metrics))
+		}
+	})
+
+	t.Run("products", func(t *testing.T) {
+		cat, _ := f.queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Meters", Slug: "meters", Description: "d", Icon: "i"})
+		product, _ := f.queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: "MT-1", Slug: "mt-1", Name: "Smart Meter", Description: "d", CategoryID: cat.ID, Status: "published",
+		})
+		productURL := fmt.Sprintf("%s/products/%d", base, product.ID)
+
+		var body string
+		f.expectInvalidates(cacheKey, func() {
+			body = f.partial(http.MethodPost, base+"/products", url.Values{"product_id": {fmt.Sprint(product.ID)}, "display_order": {"1"}})
+		})
+		contains(t, "add product", body, "Smart Meter", `hx-delete="`+productURL+`"`)
+
+		f.expectInvalidates(cacheKey, func() {
+			if rec := f.do(http.MethodDelete, productURL, nil); rec.Code != http.StatusNoContent {
+				t.Errorf("remove product: %d, want 204", rec.Code)
+			}
+		})
+		if linked, _ := f.queries.AdminListCaseStudyProducts(ctx, cs.ID); len(linked) != 0 {
+			t.Errorf("expected the product to be unlinked, got %d", len(linked))
+		}
+	})
+}
+
+func TestHTMXBlogTagChips_E2E(t *testing.T) {
+	f, cleanup := setupHTMXFlows(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	existing, _ := f.queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Robotics", Slug: "robotics"})
+
+	// Suggestions list matching tags plus a "create" option for the query
+	body := f.partial(http.MethodGet, "/admin/blog/tags/search?_tag_search=robo", nil)
+	contains(t, "tag search", body, fmt.Sprintf("addTag( %d , 'Robotics')", existing.ID), "Robotics", `+ Create tag "robo"`, `hx-post="/admin/blog/tags/quick-create"`)
+	contains(t, "empty tag search", f.partial(http.MethodGet, "/admin/blog/tags/search?_tag_search=", nil), "Type to search...")
+
+	// Quick-create returns a chip carrying the new tag's ID for the post form
+	body = f.partial(http.MethodPost, "/admin/blog/tags/quick-create", url.Values{"name": {"  Edge AI  "}})
+	tag, err := f.queries.GetBlogTagBySlug(ctx, "edge-ai")
+	if err != nil || tag.Name != "Edge AI" {
+		t.Fatalf("quick-created tag not stored: %+v %v", tag, err)
+	}
+	contains(t, "tag chip", body, "Edge AI", fmt.Sprintf(`name="tag_ids" value="%d"`, tag.ID))
+
+	if rec := f.do(http.MethodPost, "/admin/blog/tags/quick-create", url.Values{"name": {"   "}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("blank quick-create: %d, want 400", rec.Code)
+	}
+}
+
+func TestHTMXMediaPicker_E2E(t *testing.T) {
+	f, cleanup := setupHTMXFlows(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	contains(t, "empty picker", f.partial(http.MethodGet, "/admin/media/browse", nil), "No media files found.")
+
+	// Upload as the media library does, then pick the file
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, _ := w.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="files"; filename="plant floor.png"`},
+		"Content-Type":        {"image/png"},
+	})
+	part.Write([]byte("\x89PNG\r\n\x1a\nnot really an image"))
+	w.WriteField("alt_text", "Plant floor")
+	w.Close()
+	req := httptest.NewRequest(http.MethodPost, "/admin/media/upload", &buf)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	req.AddCookie(f.cookie)
+	rec := httptest.NewRecorder()
+	f.e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("upload: %d %s", rec.Code, rec.Body.String())
+	}
+	files, _ := f.queries.ListMediaFiles(ctx, sqlc.ListMediaFilesParams{Limit: 10})
+	if len(files) != 1 {
+		t.Fatalf("expected 1 media file, got %d", len(files))
+	}
+	file := files[0]
+
+	body := f.partial(http.MethodGet, "/admin/media/browse", nil)
+	contains(t, "picker", body, "plant floor.png", fmt.Sprintf("selectMediaFile('%s', 'plant floor.png')", strings.ReplaceAll(file.FilePath, "/", `\/`)))
+
+	// Alt text edited in the library shows up in the picker
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/admin/media/%d", file.ID), strings.NewReader(`{"alt_text":"Factory floor"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(f.cookie)
+	rec = httptest.NewRecorder()
+	f.e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update alt text: %d %s", rec.Code, rec.Body.String())
+	}
+	body = f.partial(http.MethodGet, "/admin/media/browse?search=plant", nil)
+	contains(t, "picker after alt text", body, `alt="Factory floor"`, `value="plant"`)
+	contains(t, "picker search miss", f.partial(http.MethodGet, "/admin/media/browse?search=nothing-matches", nil), "No media files found.")
+}
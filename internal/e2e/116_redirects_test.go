@@ -0,0 +1,132 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TestRedirects_E2E renames a product and its category in the admin and
+// checks that the old public URLs answer 301 to the new ones, then manages
+// manual and wildcard rules under /admin/redirects.
+func TestRedirects_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	expectRedirect := func(path, location string) {
+		t.Helper()
+		rec := get(path)
+		if rec.Code != http.StatusMovedPermanently || rec.Header().Get(echo.HeaderLocation) != location {
+			t.Errorf("GET %s: %d %q, want 301 %q", path, rec.Code, rec.Header().Get(echo.HeaderLocation), location)
+		}
+	}
+
+	if rec := post("/admin/product-categories", url.Values{"name": {"Sensors"}, "description": {"d"}, "icon": {"i"}, "sort_order": {"1"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("create category: %d", rec.Code)
+	}
+	cat, _ := queries.GetProductCategoryBySlug(ctx, "sensors")
+	product := func(name string) url.Values {
+		return url.Values{"sku": {"TS-1"}, "name": {name}, "description": {"d"}, "category_id": {fmt.Sprint(cat.ID)}, "status": {"published"}}
+	}
+	if rec := post("/admin/products", product("Temp Sensor")); rec.Code != http.StatusSeeOther {
+		t.Fatalf("create product: %d", rec.Code)
+	}
+	p, _ := queries.GetProductBySKU(ctx, "TS-1")
+	if rec := get("/products/sensors/temp-sensor"); rec.Code != http.StatusOK {
+		t.Fatalf("product page: %d", rec.Code)
+	}
+
+	// Renaming the product redirects its old URL, keeping the query string
+	if rec := post(fmt.Sprintf("/admin/products/%d", p.ID), product("Thermo Sensor")); rec.Code != http.StatusSeeOther {
+		t.Fatalf("rename product: %d", rec.Code)
+	}
+	expectRedirect("/products/sensors/temp-sensor", "/products/sensors/thermo-sensor")
+	expectRedirect("/products/sensors/temp-sensor?variant=X", "/products/sensors/thermo-sensor?variant=X")
+	if rec := get("/products/sensors/thermo-sensor"); rec.Code != http.StatusOK {
+		t.Errorf("new product URL: %d", rec.Code)
+	}
+
+	// Renaming the category moves the category page and every product in it,
+	// and the product's older URL goes straight to the newest one
+	if rec := post(fmt.Sprintf("/admin/product-categories/%d", cat.ID), url.Values{"name": {"Probes"}, "description": {"d"}, "icon": {"i"}, "sort_order": {"1"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("rename category: %d", rec.Code)
+	}
+	expectRedirect("/products/sensors", "/products/probes")
+	expectRedirect("/products/sensors/thermo-sensor", "/products/probes/thermo-sensor")
+	expectRedirect("/products/sensors/temp-sensor", "/products/probes/thermo-sensor")
+
+	// Renaming the product back makes its old slug live again
+	if rec := post(fmt.Sprintf("/admin/products/%d", p.ID), product("Temp Sensor")); rec.Code != http.StatusSeeOther {
+		t.Fatalf("rename product back: %d", rec.Code)
+	}
+	if rec := get("/products/probes/temp-sensor"); rec.Code != http.StatusOK {
+		t.Errorf("renamed-back product URL: %d", rec.Code)
+	}
+	expectRedirect("/products/probes/thermo-sensor", "/products/probes/temp-sensor")
+
+	// Manual and wildcard rules
+	if rec := post("/admin/redirects", url.Values{"source_path": {"/docs/*"}, "target_path": {"/whitepapers/*"}, "status_code": {"301"}}); rec.Code != http.StatusSeeOther || rec.Header().Get(echo.HeaderLocation) != "/admin/redirects" {
+		t.Fatalf("create wildcard rule: %d %s", rec.Code, rec.Header().Get(echo.HeaderLocation))
+	}
+	expectRedirect("/docs/iot-guide", "/whitepapers/iot-guide")
+
+	for _, bad := range []url.Values{
+		{"source_path": {"/admin/products"}, "target_path": {"/products"}},
+		{"source_path": {"/loop"}, "target_path": {"/loop/"}},
+		{"source_path": {"/docs/*"}, "target_path": {"/resources"}},
+	} {
+		rec := post("/admin/redirects", bad)
+		if loc := rec.Header().Get(echo.HeaderLocation); rec.Code != http.StatusSeeOther || !strings.Contains(loc, "error=") {
+			t.Errorf("invalid rule %v: %d %s", bad, rec.Code, loc)
+		}
+	}
+
+	rules, _ := queries.ListRedirects(ctx)
+	var wildcardID int64
+	for _, r := range rules {
+		if r.SourcePath == "/docs/*" {
+			wildcardID = r.ID
+		}
+	}
+	if rec := post(fmt.Sprintf("/admin/redirects/%d", wildcardID), url.Values{"source_path": {"/docs/*"}, "target_path": {"/case-studies/*"}, "status_code": {"302"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("update rule: %d", rec.Code)
+	}
+	if rec := get("/docs/x"); rec.Code != http.StatusFound || rec.Header().Get(echo.HeaderLocation) != "/case-studies/x" {
+		t.Errorf("updated rule: %d %s", rec.Code, rec.Header().Get(echo.HeaderLocation))
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/redirects/%d", wildcardID), nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("delete rule: %d", rec.Code)
+	}
+	if rec := get("/docs/x"); rec.Code == http.StatusFound {
+		t.Error("deleted rule still redirects")
+	}
+}
@@ -0,0 +1,122 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestNotFoundReport_E2E requests missing public pages, checks they are
+// counted per path and referrer on /admin/404s, and that redirecting or
+// dismissing a path removes it from the report.
+func TestNotFoundReport_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+	visit := func(method, path, referrer string) int {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		if referrer != "" {
+			req.Header.Set("Referer", referrer)
+		}
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for _, v := range []struct{ path, referrer string }{
+		{"/old-brochure?utm=mail", "https://partner.example/links"},
+		{"/old-brochure", "https://partner.example/links"},
+		{"/old-brochure", ""},
+		{"/products/sensors/gone", "https://news.example/review"},
+	} {
+		if code := visit(http.MethodGet, v.path, v.referrer); code != http.StatusNotFound {
+			t.Fatalf("GET %s: %d, want 404", v.path, code)
+		}
+	}
+	// Not recorded: form posts, admin pages and pages that exist
+	visit(http.MethodPost, "/posted", "")
+	visit(http.MethodGet, "/admin/nope", "")
+	visit(http.MethodGet, "/", "")
+
+	paths, err := queries.ListNotFoundPaths(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListNotFoundPaths: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("recorded paths = %+v, want /old-brochure and /products/sensors/gone", paths)
+	}
+	if p := paths[0]; p.Path != "/old-brochure" || p.Hits != 3 || p.Referrers != 1 || p.LastSeen == "" {
+		t.Errorf("top path = %+v, want /old-brochure with 3 hits from 1 referrer", p)
+	}
+
+	// The report lists paths with their referrers and a redirect shortcut
+	r := echo.New()
+	r.Renderer = templates.NewRenderer("templates")
+	r.GET("/admin/404s", adminHandlers.NewNotFoundReportHandler(queries, testLogger).List)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/404s", nil))
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("report: %d", rec.Code)
+	}
+	for _, want := range []string{"/old-brochure", "https://partner.example/links", "/products/sensors/gone", `href="/admin/redirects?source=%2fold-brochure"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("report is missing %q", want)
+		}
+	}
+
+	// Redirecting a path takes it off the report
+	form := url.Values{"source_path": {"/old-brochure"}, "target_path": {"/whitepapers"}, "status_code": {"301"}}
+	req := httptest.NewRequest(http.MethodPost, "/admin/redirects", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.AddCookie(cookie)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	if code := visit(http.MethodGet, "/old-brochure", "https://partner.example/links"); code != http.StatusMovedPermanently {
+		t.Errorf("redirected path: %d, want 301", code)
+	}
+	paths, _ = queries.ListNotFoundPaths(ctx, 10)
+	if len(paths) != 1 || paths[0].Path != "/products/sensors/gone" {
+		t.Errorf("after redirect, report = %+v", paths)
+	}
+
+	// Dismissing one path, then clearing the report
+	req = httptest.NewRequest(http.MethodDelete, "/admin/404s?path="+url.QueryEscape("/products/sensors/gone"), nil)
+	req.Header.Set("HX-Request", "true")
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("dismiss: %d", rec.Code)
+	}
+	if paths, _ = queries.ListNotFoundPaths(ctx, 10); len(paths) != 0 {
+		t.Errorf("after dismiss, report = %+v", paths)
+	}
+
+	visit(http.MethodGet, "/missing-a", "")
+	visit(http.MethodGet, "/missing-b", "")
+	req = httptest.NewRequest(http.MethodPost, "/admin/404s", strings.NewReader("_method=DELETE"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.Header.Set("Referer", "/admin/404s")
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("clear without HTMX: %d", rec.Code)
+	}
+	if paths, _ = queries.ListNotFoundPaths(ctx, 10); len(paths) != 0 {
+		t.Errorf("after clear, report = %+v", paths)
+	}
+}
@@ -0,0 +1,98 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestErrorPages_E2E renders errors through the real templates: branded 404
+// and 500 pages inside the public layout, HTMX fragments and JSON for API
+// routes.
+func TestErrorPages_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.HTTPErrorHandler = publicHandlers.NewErrorHandler(testLogger).Handle
+	e.Use(appmw.Recovery(testLogger))
+	public := e.Group("")
+	public.Use(appmw.SettingsLoader(queries))
+	public.GET("/products/:category", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
+	})
+	public.GET("/boom", func(c echo.Context) error { panic("secret database detail") })
+	public.GET("/bad", func(c echo.Context) error { return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID") })
+	e.GET("/api/v1/products", func(c echo.Context) error { return echo.ErrNotFound })
+
+	do := func(method, path string, header map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	cases := []struct {
+		name, method, path string
+		header             map[string]string
+		code               int
+		contains, excludes []string
+	}{
+		{name: "unmatched path", method: http.MethodGet, path: "/no-such-page", code: http.StatusNotFound,
+			contains: []string{"<html", "Page Not Found", "does not exist or has moved", `action="/search"`}},
+		{name: "handler 404", method: http.MethodGet, path: "/products/nope", code: http.StatusNotFound,
+			contains: []string{"<html", "Page Not Found", "Category not found"}},
+		{name: "panic", method: http.MethodGet, path: "/boom", code: http.StatusInternalServerError,
+			contains: []string{"<html", "Internal Server Error", "Something went wrong"}, excludes: []string{"secret database detail"}},
+		{name: "bad request", method: http.MethodGet, path: "/bad", code: http.StatusBadRequest,
+			contains: []string{"<html", "Bad Request", "Invalid ID"}},
+		{name: "htmx fragment", method: http.MethodGet, path: "/bad", header: map[string]string{"HX-Request": "true"}, code: http.StatusBadRequest,
+			contains: []string{`role="alert"`, "Invalid ID"}, excludes: []string{"<html"}},
+		{name: "admin 404", method: http.MethodGet, path: "/admin/nope", code: http.StatusNotFound,
+			contains: []string{"Back to Dashboard"}},
+		{name: "head", method: http.MethodHead, path: "/no-such-page", code: http.StatusNotFound,
+			excludes: []string{"<html"}},
+	}
+	for _, tc := range cases {
+		rec := do(tc.method, tc.path, tc.header)
+		if rec.Code != tc.code {
+			t.Errorf("%s: status %d, want %d", tc.name, rec.Code, tc.code)
+		}
+		body := rec.Body.String()
+		for _, want := range tc.contains {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s: body is missing %q", tc.name, want)
+			}
+		}
+		for _, unwanted := range tc.excludes {
+			if strings.Contains(body, unwanted) {
+				t.Errorf("%s: body contains %q", tc.name, unwanted)
+			}
+		}
+	}
+
+	// API routes and JSON clients get {"error": "..."}
+	for _, rec := range []*httptest.ResponseRecorder{
+		do(http.MethodGet, "/api/v1/products", nil),
+		do(http.MethodGet, "/no-such-page", map[string]string{"Accept": "application/json"}),
+	} {
+		var body map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || rec.Code != http.StatusNotFound || body["error"] == "" {
+			t.Errorf("JSON error: %d %q (%v)", rec.Code, rec.Body.String(), err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/database"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestTracing_E2E loads a product page with tracing on and checks that the
+// request, ProductService, sqlc query and template render spans form one
+// trace under the caller's traceparent.
+func TestTracing_E2E(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	queries := sqlc.New(database.TracingDB(db))
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "TS-1", Slug: "temp-sensor", Name: "Temp Sensor", Description: "d", CategoryID: cat.ID, Status: "published"})
+	if n := len(recorder.Ended()); n != 0 {
+		t.Fatalf("queries outside a request recorded %d spans", n)
+	}
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(appmw.Tracing())
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), services.NewCache())
+	e.GET("/products/:category/:slug", products.ProductDetail, appmw.SettingsLoader(queries))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/products/sensors/temp-sensor", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("product page: %d", rec.Code)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		if s.SpanContext().TraceID().String() != traceID {
+			t.Errorf("span %q is outside the caller's trace", s.Name())
+		}
+		if _, seen := spans[s.Name()]; !seen {
+			spans[s.Name()] = s
+		}
+	}
+	server, ok := spans["GET /products/:category/:slug"]
+	if !ok {
+		t.Fatalf("no server span; got %v", spanNames(spans))
+	}
+	// child -> expected parent
+	for child, parent := range map[string]string{
+		"ProductService.GetProductDetail":         server.Name(),
+		"GetProductBySlug":                        "ProductService.GetProductDetail",
+		"render public/pages/product_detail.html": server.Name(),
+	} {
+		c, ok := spans[child]
+		if !ok {
+			t.Errorf("no %q span; got %v", child, spanNames(spans))
+			continue
+		}
+		if c.Parent().SpanID() != spans[parent].SpanContext().SpanID() {
+			t.Errorf("%q is not a child of %q", child, parent)
+		}
+	}
+
+	var status int64
+	for _, a := range server.Attributes() {
+		if a.Key == "http.response.status_code" {
+			status = a.Value.AsInt64()
+		}
+	}
+	if status != http.StatusOK {
+		t.Errorf("server span status code = %d", status)
+	}
+}
+
+func spanNames(spans map[string]sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for name := range spans {
+		names = append(names, name)
+	}
+	return names
+}
@@ -87,6 +87,7 @@ func TestProductFeaturesAdd_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -148,6 +149,7 @@ func TestProductFeaturesDeleteAll_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/products/%d/features", product.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -181,6 +183,7 @@ func TestProductFeaturesEditForm_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/products/%d/features?edit=%d", product.ID, feat.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -223,6 +226,7 @@ func TestProductFeaturesUpdate_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -0,0 +1,148 @@
+package e2e_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestBackups_E2E downloads a snapshot, stores a backup, and restores it
+// after the typed confirmation; editors cannot reach the backup routes.
+func TestBackups_E2E(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	uploads := t.TempDir()
+	os.WriteFile(filepath.Join(uploads, "logo.png"), []byte("png"), 0644)
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	backups := services.NewBackups(db, uploads, services.NewLocalStorage(t.TempDir()), testLogger, services.BackupConfig{})
+	h := adminHandlers.NewBackupsHandler(testLogger, backups)
+	backupGroup := adminGroup.Group("/maintenance/backup", customMiddleware.RequireRole("admin"))
+	backupGroup.GET("", h.List)
+	backupGroup.POST("", h.Create)
+	backupGroup.GET("/download", h.Download)
+	backupGroup.GET("/:name/restore", h.ConfirmRestore)
+	backupGroup.POST("/:name/restore", h.Restore)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	do := func(req *http.Request, c *http.Cookie) *httptest.ResponseRecorder {
+		req.AddCookie(c)
+		if req.Method == http.MethodPost {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Download: a tar.gz with the manifest, the database and the uploads
+	rec := do(httptest.NewRequest(http.MethodGet, "/admin/maintenance/backup/download", nil), cookie)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get(echo.HeaderContentDisposition), ".tar.gz") {
+		t.Fatalf("download: got %d %q", rec.Code, rec.Header().Get(echo.HeaderContentDisposition))
+	}
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("download is not gzip: %v", err)
+	}
+	var names []string
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+	}
+	if strings.Join(names, ",") != "manifest.json,database.sqlite,uploads/logo.png" {
+		t.Errorf("archive entries = %v", names)
+	}
+
+	// Store a backup, change the content, and restore
+	rec = do(httptest.NewRequest(http.MethodPost, "/admin/maintenance/backup", nil), cookie)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: got %d", rec.Code)
+	}
+	loc, _ := url.Parse(rec.Header().Get(echo.HeaderLocation))
+	name := loc.Query().Get("created")
+	if rec = do(httptest.NewRequest(http.MethodGet, "/admin/maintenance/backup", nil), cookie); !strings.Contains(rec.Body.String(), name) {
+		t.Errorf("backup %s not listed", name)
+	}
+	if _, err := db.Exec(`INSERT INTO product_categories (name, slug, description, icon) VALUES ('After', 'after', 'd', 'i')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	rec = do(httptest.NewRequest(http.MethodGet, "/admin/maintenance/backup/"+name+"/restore", nil), cookie)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Type RESTORE to confirm") {
+		t.Fatalf("confirm page: got %d", rec.Code)
+	}
+	restore := func(confirm string) *httptest.ResponseRecorder {
+		return do(httptest.NewRequest(http.MethodPost, "/admin/maintenance/backup/"+name+"/restore",
+			strings.NewReader(url.Values{"confirm": {confirm}}.Encode())), cookie)
+	}
+	if rec = restore("restore"); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("wrong confirmation: got %d", rec.Code)
+	}
+	var n int
+	db.QueryRow(`SELECT COUNT(*) FROM product_categories WHERE slug = 'after'`).Scan(&n)
+	if n != 1 {
+		t.Fatal("an unconfirmed restore changed the database")
+	}
+	if rec = restore("RESTORE"); rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get(echo.HeaderLocation), "restored=") {
+		t.Fatalf("restore: got %d %q", rec.Code, rec.Header().Get(echo.HeaderLocation))
+	}
+	db.QueryRow(`SELECT COUNT(*) FROM product_categories WHERE slug = 'after'`).Scan(&n)
+	if n != 0 {
+		t.Error("restore did not roll the database back")
+	}
+	if list, _ := backups.List(ctx); len(list) != 2 || list[0].Kind != services.BackupPreRestore {
+		t.Errorf("backups after restore = %+v, want the pre-restore backup first", list)
+	}
+
+	// Editors are refused
+	hash, _ := bcrypt.GenerateFromPassword([]byte("testpassword"), bcrypt.DefaultCost)
+	if _, err := queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{Email: "editor@test.com", PasswordHash: string(hash), DisplayName: "Editor", Role: "editor"}); err != nil {
+		t.Fatalf("create editor: %v", err)
+	}
+	login := httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(url.Values{"email": {"editor@test.com"}, "password": {"testpassword"}}.Encode()))
+	login.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, login)
+	var editor *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "bluejay_session" {
+			editor = c
+		}
+	}
+	if editor == nil {
+		t.Fatal("editor login failed")
+	}
+	if rec = do(httptest.NewRequest(http.MethodGet, "/admin/maintenance/backup/download", nil), editor); rec.Code != http.StatusForbidden {
+		t.Errorf("editor download: got %d, want 403", rec.Code)
+	}
+}
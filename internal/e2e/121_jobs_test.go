@@ -0,0 +1,90 @@
+package e2e_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/jobs"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestJobs_E2E lists the registered jobs and runs one by hand; the run's
+// error and trigger appear on the page and in job_runs.
+func TestJobs_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler := jobs.New(queries, testLogger, jobs.Config{StartupDelay: time.Hour})
+	scheduler.Add(jobs.Job{Name: "trash-purge", Description: "Purge the trash", Schedule: jobs.MustCron("30 2 * * *"),
+		Run: func(context.Context) error { return nil }})
+	scheduler.Add(jobs.Job{Name: "cache-warm", Description: "Warm the cache",
+		Run: func(context.Context) error { return errors.New("listing pages failed") }})
+	scheduler.Start(ctx)
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	h := adminHandlers.NewJobsHandler(testLogger, scheduler)
+	jobsGroup := adminGroup.Group("/jobs", customMiddleware.RequireRole("admin"))
+	jobsGroup.GET("", h.List)
+	jobsGroup.POST("/:name/run", h.RunNow)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodGet, "/admin/jobs")
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "trash-purge") || !strings.Contains(body, "30 2 * * *") || !strings.Contains(body, "disabled") {
+		t.Fatalf("jobs page: got %d", rec.Code)
+	}
+
+	rec = do(http.MethodPost, "/admin/jobs/cache-warm/run")
+	if rec.Code != http.StatusSeeOther || rec.Header().Get(echo.HeaderLocation) != "/admin/jobs?started=cache-warm" {
+		t.Fatalf("run now: got %d %q", rec.Code, rec.Header().Get(echo.HeaderLocation))
+	}
+	if rec = do(http.MethodPost, "/admin/jobs/missing/run"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown job: got %d, want 404", rec.Code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runs, _ := queries.ListJobRuns(ctx)
+		if len(runs) == 1 {
+			if runs[0].Name != "cache-warm" || runs[0].LastError != "listing pages failed" || runs[0].LastTrigger != jobs.TriggerManual {
+				t.Errorf("job run = %+v", runs[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("manual run was not recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if body = do(http.MethodGet, "/admin/jobs").Body.String(); !strings.Contains(body, "listing pages failed") || !strings.Contains(body, "(manual)") {
+		t.Error("last run error not shown")
+	}
+}
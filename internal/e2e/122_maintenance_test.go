@@ -0,0 +1,94 @@
+package e2e_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestMaintenanceMode_E2E switches maintenance mode on in Global Settings:
+// visitors get the branded 503 page with Retry-After while the signed-in
+// admin still sees the site, and switching it off reopens the site.
+func TestMaintenanceMode_E2E(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.HTTPErrorHandler = publicHandlers.NewErrorHandler(testLogger).Handle
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	settingsHandler := adminHandlers.NewSettingsHandler(queries, testLogger, nil)
+	adminGroup.POST("/settings", settingsHandler.Update)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Maintenance(publicHandlers.MaintenancePage))
+	publicGroup.GET("/about", func(c echo.Context) error { return c.String(http.StatusOK, "about page") })
+	publicGroup.POST("/contact/submit", func(c echo.Context) error { return c.String(http.StatusOK, "sent") })
+	cookie := loginTabsAdmin(t, e, queries)
+
+	get := func(method, path string, c *http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if c != nil {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	saveSettings := func(form url.Values) {
+		t.Helper()
+		form.Set("site_name", "BlueJay Labs")
+		req := httptest.NewRequest(http.MethodPost, "/admin/settings", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("save settings: got %d", rec.Code)
+		}
+	}
+
+	saveSettings(url.Values{"maintenance_mode": {"on"}, "maintenance_message": {"Back at 18:00 UTC."}})
+
+	rec := get(http.MethodGet, "/about", nil)
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("visitor: got %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Down for Maintenance") || !strings.Contains(body, "Back at 18:00 UTC.") || !strings.Contains(body, "BlueJay Labs") {
+		t.Error("maintenance page is missing the title, message or site name")
+	}
+	if rec = get(http.MethodPost, "/contact/submit", nil); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("visitor form post: got %d, want 503", rec.Code)
+	}
+	if rec = get(http.MethodGet, "/about", cookie); rec.Code != http.StatusOK || rec.Body.String() != "about page" {
+		t.Errorf("admin: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	var n int
+	db.QueryRow(`SELECT COUNT(*) FROM activity_log WHERE description = 'Enabled maintenance mode'`).Scan(&n)
+	if n != 1 {
+		t.Errorf("maintenance switch logged %d times, want 1", n)
+	}
+
+	saveSettings(url.Values{})
+	if rec = get(http.MethodGet, "/about", nil); rec.Code != http.StatusOK {
+		t.Errorf("after switching off: got %d", rec.Code)
+	}
+}
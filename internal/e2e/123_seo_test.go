@@ -0,0 +1,148 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSEOOverrides_E2E adds overrides under /admin/seo for a blog post (by
+// content item) and a page path, and checks with the REAL renderer that the
+// public pages' head carries the new title, description, canonical, social
+// and robots tags, and that cached pages are refreshed.
+func TestSEOOverrides_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	seoSvc := services.NewSEO(queries)
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	seoHandler := adminHandlers.NewSEOHandler(queries, testLogger, seoSvc, appCache)
+	adminGroup.GET("/seo", seoHandler.List)
+	adminGroup.GET("/seo/new", seoHandler.New)
+	adminGroup.POST("/seo", seoHandler.Create)
+	adminGroup.GET("/seo/:id/edit", seoHandler.Edit)
+	adminGroup.POST("/seo/:id", seoHandler.Update)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.SEO(seoSvc))
+	blogHandler := publicHandlers.NewBlogHandler(queries, testLogger, appCache)
+	publicGroup.GET("/blog/:slug", blogHandler.BlogPost)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1})
+	newPost := func(title, slug string) sqlc.BlogPost {
+		p, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: title, Slug: slug, Excerpt: "Excerpt", Body: "<p>Body</p>",
+			CategoryID: cat.ID, AuthorID: author.ID, Status: "published",
+			PublishedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+		return p
+	}
+	launch := newPost("Launch Notes", "launch-notes")
+	newPost("Old Notes", "old-notes")
+
+	get := func(path string) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: %d", path, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Cached before any override exists
+	if body := get("/blog/launch-notes"); strings.Contains(body, "Launch Day Recap") {
+		t.Fatal("override title shown before it was added")
+	}
+
+	rec := post("/admin/seo", url.Values{
+		"entity_type": {services.PreviewBlogPost}, "entity_id": {fmt.Sprint(launch.ID)},
+		"meta_title": {"Launch Day Recap"}, "meta_description": {"Everything we shipped."},
+		"og_image": {"/uploads/launch.png"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create entity override: %d %s", rec.Code, rec.Body.String())
+	}
+	rec = post("/admin/seo", url.Values{"route": {"/blog/old-notes/"}, "canonical_url": {"/blog/launch-notes"}, "noindex": {"on"}})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create path override: %d %s", rec.Code, rec.Body.String())
+	}
+
+	body := get("/blog/launch-notes")
+	for _, want := range []string{
+		"<title>Launch Day Recap</title>",
+		`<meta name="description" content="Everything we shipped.">`,
+		`<meta property="og:title" content="Launch Day Recap">`,
+		`<meta name="twitter:image" content="/uploads/launch.png">`,
+		`<link rel="canonical" href="https://bluejaylabs.com/blog/launch-notes">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("launch-notes page is missing %s", want)
+		}
+	}
+	if strings.Contains(body, "noindex") {
+		t.Error("launch-notes page is noindex")
+	}
+	body = get("/blog/old-notes")
+	if !strings.Contains(body, `<meta name="robots" content="noindex">`) || !strings.Contains(body, `<link rel="canonical" href="https://bluejaylabs.com/blog/launch-notes">`) {
+		t.Error("old-notes page is missing the noindex or canonical override")
+	}
+
+	// Duplicates, missing content and empty overrides are refused on the form
+	for _, form := range []url.Values{
+		{"route": {"/blog/old-notes"}, "meta_title": {"Again"}},
+		{"entity_type": {services.PreviewBlogPost}, "entity_id": {"9999"}, "meta_title": {"Missing"}},
+		{"route": {"/about"}},
+	} {
+		if rec := post("/admin/seo", form); rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("create %v: got %d, want 422", form, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/seo", nil)
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "Blog post: Launch Notes") || !strings.Contains(body, "/blog/old-notes") {
+		t.Errorf("SEO list: got %d", rec.Code)
+	}
+}
@@ -0,0 +1,163 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestNavigationMenus_E2E builds header and footer-legal menus in the menu
+// editor and checks with the REAL renderer that activating them replaces the
+// built-in header links on a cached public page, with nested dropdown items
+// and external links opening in a new tab, and that deactivating the header
+// menu brings the built-in links back.
+func TestNavigationMenus_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	navSvc := services.NewNavigation(queries)
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	navHandler := adminHandlers.NewNavigationHandler(queries, testLogger, navSvc, appCache)
+	adminGroup.GET("/navigation/:id", navHandler.Edit)
+	adminGroup.POST("/navigation/:id/settings", navHandler.UpdateMenu)
+	adminGroup.POST("/navigation/:id/items", navHandler.AddItem)
+	adminGroup.POST("/navigation/items/:id", navHandler.UpdateItem)
+	adminGroup.POST("/navigation/:id/reorder", navHandler.Reorder)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Navigation(navSvc))
+	blogHandler := publicHandlers.NewBlogHandler(queries, testLogger, appCache)
+	publicGroup.GET("/blog/:slug", blogHandler.BlogPost)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1})
+	if _, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Launch Notes", Slug: "launch-notes", Excerpt: "Excerpt", Body: "<p>Body</p>",
+		CategoryID: cat.ID, AuthorID: author.ID, Status: "published",
+		PublishedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}); err != nil {
+		t.Fatalf("create post: %v", err)
+	}
+
+	get := func(path string) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: %d %s", path, rec.Code, rec.Body.String())
+		}
+		return rec.Body.String()
+	}
+	send := func(method, path, contentType, body string) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther && rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: got %d", method, path, rec.Code)
+		}
+	}
+	post := func(path string, form url.Values) {
+		t.Helper()
+		send(http.MethodPost, path, echo.MIMEApplicationForm, form.Encode())
+	}
+	itemID := func(menuID int64, label string) int64 {
+		t.Helper()
+		items, _ := queries.ListNavigationItems(ctx, menuID)
+		for _, it := range items {
+			if it.Label == label {
+				return it.ID
+			}
+		}
+		t.Fatalf("menu %d has no item %q", menuID, label)
+		return 0
+	}
+
+	header, _ := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: "Main", Location: "header"})
+	legal, _ := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: "Legal", Location: "footer_legal"})
+	headerItems := fmt.Sprintf("/admin/navigation/%d/items", header.ID)
+	post(headerItems, url.Values{"link_type": {"page"}, "page_identifier": {"Case Studies"}})
+	post(headerItems, url.Values{"link_type": {"dropdown"}, "label": {"Developers"}})
+	post(headerItems, url.Values{"link_type": {"custom"}, "label": {"API Docs"}, "url": {"https://docs.example.com"}})
+	post(headerItems, url.Values{"link_type": {"custom"}, "label": {"Status"}, "url": {"/status"}})
+	post(fmt.Sprintf("/admin/navigation/items/%d", itemID(header.ID, "Status")), url.Values{
+		"label": {"Status"}, "link_type": {"custom"}, "url": {"/status"}, "is_active": {"on"}, "visibility": {"mobile"},
+	})
+	send(http.MethodPost, fmt.Sprintf("/admin/navigation/%d/reorder", header.ID), echo.MIMEApplicationJSON, fmt.Sprintf(
+		`[{"id":%d,"order":0},{"id":%d,"order":1},{"id":%d,"parent_id":%d,"order":0},{"id":%d,"order":2}]`,
+		itemID(header.ID, "Case Studies"), itemID(header.ID, "Developers"),
+		itemID(header.ID, "API Docs"), itemID(header.ID, "Developers"), itemID(header.ID, "Status")))
+	post(fmt.Sprintf("/admin/navigation/%d/items", legal.ID), url.Values{"link_type": {"custom"}, "label": {"Privacy Policy"}, "url": {"/privacy"}})
+
+	// Inactive menus leave the built-in links in place (and the page is cached)
+	if body := get("/blog/launch-notes"); strings.Contains(body, `href="/case-studies"`) || strings.Contains(body, "Privacy Policy") {
+		t.Fatal("inactive menus were rendered")
+	}
+
+	post(fmt.Sprintf("/admin/navigation/%d/settings", header.ID), url.Values{"name": {"Main"}, "location": {"header"}, "is_active": {"on"}})
+	post(fmt.Sprintf("/admin/navigation/%d/settings", legal.ID), url.Values{"name": {"Legal"}, "location": {"footer_legal"}, "is_active": {"on"}})
+
+	body := get("/blog/launch-notes")
+	for _, want := range []string{
+		`href="/case-studies"`,
+		`transition-colors">Developers<span class="material-symbols-outlined text-base" aria-hidden="true">expand_more</span></button>`,
+		`href="https://docs.example.com" target="_blank" rel="noopener"`,
+		`href="/privacy"`,
+		`<details class="md:hidden">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page with active menus is missing %s", want)
+		}
+	}
+	desktopNav := body[strings.Index(body, `<nav class="hidden md:flex`):strings.Index(body, `<details class="md:hidden">`)]
+	if strings.Contains(desktopNav, "/status") {
+		t.Error("mobile-only item rendered in the desktop header")
+	}
+	if !strings.Contains(body[strings.Index(body, `<details class="md:hidden">`):], `href="/status"`) {
+		t.Error("mobile-only item missing from the mobile menu")
+	}
+
+	// A second header menu takes over and switches the first one off
+	second, _ := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: "Campaign", Location: "header"})
+	post(fmt.Sprintf("/admin/navigation/%d/settings", second.ID), url.Values{"name": {"Campaign"}, "location": {"header"}, "is_active": {"on"}})
+	if m, _ := queries.GetNavigationMenu(ctx, header.ID); m.IsActive {
+		t.Error("activating a second header menu left the first one active")
+	}
+	if body := get("/blog/launch-notes"); strings.Contains(body, `href="/case-studies"`) {
+		t.Error("the replaced header menu is still rendered")
+	}
+
+	post(fmt.Sprintf("/admin/navigation/%d/settings", second.ID), url.Values{"name": {"Campaign"}, "location": {"header"}})
+	if body := get("/blog/launch-notes"); !strings.Contains(body, "Privacy Policy") || strings.Contains(body, `<details class="md:hidden">`) {
+		t.Error("after deactivating the header menu: want built-in header links and the legal menu")
+	}
+}
@@ -0,0 +1,150 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestNavigationMegaMenu_E2E turns a header dropdown into a mega panel in the
+// menu editor, with icon columns, a third level of items and a featured
+// product, and checks the REAL rendered header. It also checks that the
+// editor refuses to nest an item under its own descendant.
+func TestNavigationMegaMenu_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	navSvc := services.NewNavigation(queries)
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	navHandler := adminHandlers.NewNavigationHandler(queries, testLogger, navSvc, appCache)
+	adminGroup.GET("/navigation/:id", navHandler.Edit)
+	adminGroup.POST("/navigation/:id/settings", navHandler.UpdateMenu)
+	adminGroup.POST("/navigation/:id/items", navHandler.AddItem)
+	adminGroup.POST("/navigation/items/:id", navHandler.UpdateItem)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Navigation(navSvc))
+	productsHandler := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products", productsHandler.ProductsList)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i", SortOrder: 1})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "TS-1", Slug: "thermal-sensor", Name: "Thermal Sensor", Tagline: sql.NullString{String: "Reads heat", Valid: true},
+		Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+
+	post := func(path string, form url.Values) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	menu, _ := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: "Main", Location: "header"})
+	itemID := func(label string) int64 {
+		t.Helper()
+		items, _ := queries.ListNavigationItems(ctx, menu.ID)
+		for _, it := range items {
+			if it.Label == label {
+				return it.ID
+			}
+		}
+		t.Fatalf("menu has no item %q", label)
+		return 0
+	}
+	add := func(form url.Values) {
+		t.Helper()
+		if code := post(fmt.Sprintf("/admin/navigation/%d/items", menu.ID), form); code != http.StatusSeeOther && code != http.StatusOK {
+			t.Fatalf("add item %v: got %d", form, code)
+		}
+	}
+	update := func(label string, form url.Values) int {
+		t.Helper()
+		form.Set("label", label)
+		form.Set("is_active", "on")
+		return post(fmt.Sprintf("/admin/navigation/items/%d", itemID(label)), form)
+	}
+
+	add(url.Values{"link_type": {"dropdown"}, "label": {"Products"}})
+	add(url.Values{"link_type": {"custom"}, "label": {"Sensing"}, "url": {"/products/sensors"}})
+	add(url.Values{"link_type": {"custom"}, "label": {"Thermal"}, "url": {"/products/sensors/thermal"}})
+	for _, step := range []struct {
+		label string
+		form  url.Values
+	}{
+		{"Products", url.Values{"link_type": {"dropdown"}, "layout": {"mega"}, "featured_type": {"product"}, "featured_id": {fmt.Sprint(product.ID)}}},
+		{"Sensing", url.Values{"link_type": {"custom"}, "url": {"/products/sensors"}, "icon": {"sensors"}, "parent_id": {fmt.Sprint(itemID("Products"))}}},
+		{"Thermal", url.Values{"link_type": {"custom"}, "url": {"/products/sensors/thermal"}, "icon": {"<b>"}, "parent_id": {fmt.Sprint(itemID("Sensing"))}}},
+	} {
+		if code := update(step.label, step.form); code != http.StatusSeeOther && code != http.StatusOK {
+			t.Fatalf("update %s: got %d", step.label, code)
+		}
+	}
+
+	// Products is an ancestor of Thermal, so it cannot move under it
+	if code := update("Products", url.Values{"link_type": {"dropdown"}, "layout": {"mega"}, "parent_id": {fmt.Sprint(itemID("Thermal"))}}); code != http.StatusBadRequest {
+		t.Errorf("parent cycle: got %d, want 400", code)
+	}
+	if it, _ := queries.GetNavigationItem(ctx, itemID("Thermal")); it.Icon != "" {
+		t.Errorf("invalid icon stored as %q", it.Icon)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/navigation/%d", menu.ID), nil)
+	req.AddCookie(cookie)
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Mega") {
+		t.Errorf("menu editor: %d, want the Mega badge", rec.Code)
+	}
+
+	post(fmt.Sprintf("/admin/navigation/%d/settings", menu.ID), url.Values{"name": {"Main"}, "location": {"header"}, "is_active": {"on"}})
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /products: %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`class="absolute left-0 right-0 top-full`,
+		`aria-hidden="true">sensors</span>Sensing</a>`,
+		`href="/products/sensors/thermal"`,
+		`href="/products/sensors/thermal-sensor"`,
+		"Thermal Sensor",
+		"Reads heat",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("mega menu is missing %s", want)
+		}
+	}
+}
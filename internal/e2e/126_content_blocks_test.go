@@ -0,0 +1,152 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestContentBlocks_E2E creates blocks in the block library, attaches them
+// to the products page, reorders and removes them, and checks with the REAL
+// renderer that the cached public page follows every change.
+func TestContentBlocks_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	blockSvc := services.NewContentBlocks(queries)
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	blocksHandler := adminHandlers.NewContentBlocksHandler(queries, testLogger, blockSvc, appCache)
+	adminGroup.GET("/blocks", blocksHandler.List)
+	adminGroup.GET("/blocks/new", blocksHandler.New)
+	adminGroup.POST("/blocks", blocksHandler.Create)
+	adminGroup.GET("/blocks/pages", blocksHandler.Page)
+	adminGroup.POST("/blocks/pages", blocksHandler.Attach)
+	adminGroup.POST("/blocks/pages/reorder", blocksHandler.Reorder)
+	adminGroup.DELETE("/blocks/pages/:id", blocksHandler.Detach)
+	adminGroup.GET("/blocks/:id/edit", blocksHandler.Edit)
+	adminGroup.POST("/blocks/:id", blocksHandler.Update)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.ContentBlocks(blockSvc))
+	productsHandler := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products", productsHandler.ProductsList)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	send := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		return send(http.MethodPost, path, echo.MIMEApplicationForm, form.Encode())
+	}
+	page := func() string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /products: %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	if rec := post("/admin/blocks", url.Values{"name": {"Broken"}, "block_type": {"stats"}, "items": {"500+"}, "is_active": {"on"}}); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "value | label") {
+		t.Errorf("invalid stats block: %d", rec.Code)
+	}
+	for _, form := range []url.Values{
+		{"name": {"Intro"}, "block_type": {"rich_text"}, "heading": {"Why BlueJay"}, "rich_body": {`<p>Built to last</p><script>alert(1)</script>`}, "is_active": {"on"}},
+		{"name": {"Numbers"}, "block_type": {"stats"}, "items": {"500+ | Customers\n12 | Countries"}, "is_active": {"on"}},
+	} {
+		if rec := post("/admin/blocks", form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create %s: %d %s", form.Get("name"), rec.Code, rec.Body.String())
+		}
+	}
+	library, _ := queries.ListContentBlocks(ctx)
+	if len(library) != 2 {
+		t.Fatalf("library = %+v", library)
+	}
+	intro, numbers := library[0], library[1]
+
+	page() // Cache the page without blocks
+	for _, id := range []int64{intro.ID, numbers.ID} {
+		if rec := post("/admin/blocks/pages", url.Values{"route": {"/products/"}, "block_id": {fmt.Sprint(id)}}); rec.Code != http.StatusSeeOther {
+			t.Fatalf("attach %d: %d", id, rec.Code)
+		}
+	}
+	body := page()
+	if !strings.Contains(body, "Built to last") || strings.Contains(body, "alert(1)") {
+		t.Error("rich text block missing or unsanitized")
+	}
+	if i, j := strings.Index(body, "content-block-rich-text"), strings.Index(body, "content-block-stats"); i < 0 || j < i {
+		t.Errorf("blocks out of order: rich text at %d, stats at %d", i, j)
+	}
+
+	attached, _ := queries.ListPageBlocks(ctx, "/products")
+	if rec := send(http.MethodGet, "/admin/blocks/pages?route=/products", "", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `data-id="`+fmt.Sprint(attached[1].ID)+`"`) {
+		t.Errorf("page editor: %d", rec.Code)
+	}
+	rec := send(http.MethodPost, "/admin/blocks/pages/reorder?route=/products", echo.MIMEApplicationJSON,
+		fmt.Sprintf(`[{"id":%d,"order":0},{"id":%d,"order":1}]`, attached[1].ID, attached[0].ID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reorder: %d", rec.Code)
+	}
+	body = page()
+	if i, j := strings.Index(body, "content-block-stats"), strings.Index(body, "content-block-rich-text"); i < 0 || j < i {
+		t.Errorf("after reorder: stats at %d, rich text at %d", i, j)
+	}
+	if !strings.Contains(body, "500&#43;") || !strings.Contains(body, "Countries") {
+		t.Error("stats strip figures missing")
+	}
+
+	// Hiding a block keeps it attached but takes it off the page
+	if rec := post(fmt.Sprintf("/admin/blocks/%d", numbers.ID), url.Values{"name": {"Numbers"}, "block_type": {"stats"}, "items": {"500+ | Customers"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("update: %d", rec.Code)
+	}
+	if body := page(); strings.Contains(body, "content-block-stats") {
+		t.Error("hidden block still rendered")
+	}
+
+	if rec := send(http.MethodDelete, fmt.Sprintf("/admin/blocks/pages/%d", attached[0].ID), "", ""); rec.Code != http.StatusOK {
+		t.Fatalf("detach: %d", rec.Code)
+	}
+	if body := page(); strings.Contains(body, "content-blocks") {
+		t.Error("blocks rendered after the last visible one was removed")
+	}
+	for path, want := range map[string]string{
+		"/admin/blocks":                                "Stats strip",
+		"/admin/blocks/new?type=cta":                   `<option value="cta" selected>`,
+		fmt.Sprintf("/admin/blocks/%d/edit", intro.ID): "Built to last",
+	} {
+		if rec := send(http.MethodGet, path, "", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("GET %s: %d, want %s", path, rec.Code, want)
+		}
+	}
+}
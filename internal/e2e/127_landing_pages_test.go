@@ -0,0 +1,216 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestLandingPages_E2E builds a campaign page in the admin, previews it as a
+// draft, publishes it, reorders its sections, moves it to a new slug with
+// the minimal layout and takes it down again, checking the public page
+// rendered by the REAL templates after each step.
+func TestLandingPages_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	blockSvc := services.NewContentBlocks(queries)
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	adminHandlers.SetPreviewTokens(testPreviewTokens)
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	landing := adminHandlers.NewLandingPagesHandler(queries, testLogger, blockSvc, appCache)
+	adminGroup.GET("/landing-pages", landing.List)
+	adminGroup.GET("/landing-pages/new", landing.New)
+	adminGroup.POST("/landing-pages", landing.Create)
+	adminGroup.GET("/landing-pages/:id/edit", landing.Edit)
+	adminGroup.POST("/landing-pages/:id", landing.Update)
+	adminGroup.POST("/landing-pages/:id/publish", landing.Publish)
+	adminGroup.POST("/landing-pages/:id/unpublish", landing.Unpublish)
+	adminGroup.GET("/landing-pages/:id/sections/new", landing.NewSection)
+	adminGroup.POST("/landing-pages/:id/sections", landing.CreateSection)
+	adminGroup.POST("/landing-pages/:id/sections/reorder", landing.ReorderSections)
+	adminGroup.GET("/landing-pages/:id/sections/:sid/edit", landing.EditSection)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Preview(testPreviewTokens))
+	publicGroup.Use(customMiddleware.ContentBlocks(blockSvc))
+	productsHandler := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products", productsHandler.ProductsList)
+	publicGroup.GET("/sitemap.xml", publicHandlers.NewSitemapHandler(queries, testLogger, "https://example.com").Sitemap)
+	publicGroup.GET("/*", publicHandlers.NewLandingPagesHandler(queries, testLogger, services.NewForms(queries, testLogger, nil, ""), appCache).LandingPage)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	send := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		return send(http.MethodPost, path, echo.MIMEApplicationForm, form.Encode())
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	settings := url.Values{
+		"title": {"Spring Offer"}, "layout": {"default"},
+		"meta_description": {"Twenty percent off sensors"},
+		"head_snippet":     {`<script>window.campaign="spring"</script>`},
+	}
+	settings.Set("slug", "products/spring")
+	if rec := post("/admin/landing-pages", settings); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "already uses") {
+		t.Errorf("reserved slug: %d", rec.Code)
+	}
+	settings.Set("slug", "")
+	rec := post("/admin/landing-pages", settings)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: %d %s", rec.Code, rec.Body.String())
+	}
+	page, err := queries.GetLandingPageBySlug(ctx, "spring-offer")
+	if err != nil || page.Status != services.LandingDraft {
+		t.Fatalf("created page: %+v, %v", page, err)
+	}
+	edit := fmt.Sprintf("/admin/landing-pages/%d", page.ID)
+	if loc := rec.Header().Get("Location"); loc != edit+"/edit" {
+		t.Errorf("create redirects to %q", loc)
+	}
+	if rec := post("/admin/landing-pages", settings); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "already uses this slug") {
+		t.Errorf("duplicate slug: %d", rec.Code)
+	}
+
+	if rec := post(edit+"/sections", url.Values{"section_type": {"testimonials"}, "items": {"No name"}}); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "quote | name | role") {
+		t.Errorf("invalid testimonials: %d", rec.Code)
+	}
+	for _, form := range []url.Values{
+		{"section_type": {"hero"}, "heading": {"Sensors for spring"}, "body": {"Limited offer"}, "button_text": {"Claim it"}, "button_url": {"#form"}},
+		{"section_type": {"text"}, "rich_body": {`<p>Fine print</p><script>alert(1)</script>`}},
+		{"section_type": {"form"}, "heading": {"Get the quote"}, "button_text": {"Request quote"}},
+		{"section_type": {"testimonials"}, "items": {"Setup took an afternoon. | Ana Ruiz | CTO, Acme"}},
+	} {
+		if rec := post(edit+"/sections", form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("add %s section: %d %s", form.Get("section_type"), rec.Code, rec.Body.String())
+		}
+	}
+	admin := send(http.MethodGet, edit+"/edit", "", "")
+	if admin.Code != http.StatusOK || !strings.Contains(admin.Body.String(), "Lead form") || !strings.Contains(admin.Body.String(), "preview_token=") {
+		t.Errorf("edit page: %d", admin.Code)
+	}
+
+	// Drafts are hidden from visitors but open with a preview token,
+	// without the analytics snippet
+	if rec := get("/spring-offer"); rec.Code != http.StatusNotFound {
+		t.Errorf("draft: expected 404, got %d", rec.Code)
+	}
+	rec = get("/spring-offer?preview_token=" + url.QueryEscape(testPreviewTokens.Issue(services.PreviewLanding, page.ID)))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Preview Mode") || strings.Contains(rec.Body.String(), "window.campaign") {
+		t.Errorf("preview: %d", rec.Code)
+	}
+
+	if rec := post(edit+"/publish", nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("publish: %d", rec.Code)
+	}
+	rec = get("/spring-offer/")
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("published: %d", rec.Code)
+	}
+	for _, want := range []string{
+		"Sensors for spring", `href="#form"`, "Fine print", `value="landing:spring-offer"`, "Request quote",
+		"Ana Ruiz", "CTO, Acme", `content="Twenty percent off sensors"`, `<script>window.campaign="spring"</script>`,
+		"search-modal",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("published page lacks %q", want)
+		}
+	}
+	if strings.Contains(body, "alert(1)") {
+		t.Error("text section kept a script")
+	}
+	if strings.Index(body, "Sensors for spring") > strings.Index(body, "Ana Ruiz") {
+		t.Error("hero should come before the testimonials")
+	}
+	if rec := get("/sitemap.xml"); !strings.Contains(rec.Body.String(), "https://example.com/spring-offer") {
+		t.Error("sitemap lacks the published page")
+	}
+
+	// Drag the testimonials to the top; the cached page follows
+	sections, _ := queries.ListLandingPageSections(ctx, page.ID)
+	order := fmt.Sprintf(`[{"id":%d,"order":0},{"id":%d,"order":1},{"id":%d,"order":2},{"id":%d,"order":3}]`, sections[3].ID, sections[0].ID, sections[1].ID, sections[2].ID)
+	if rec := send(http.MethodPost, edit+"/sections/reorder", echo.MIMEApplicationJSON, order); rec.Code != http.StatusOK {
+		t.Fatalf("reorder: %d", rec.Code)
+	}
+	if body := get("/spring-offer").Body.String(); strings.Index(body, "Ana Ruiz") > strings.Index(body, "Sensors for spring") {
+		t.Error("testimonials should come first after reordering")
+	}
+
+	// A new slug moves the page and the library blocks attached to it
+	block, _ := queries.CreateContentBlock(ctx, sqlc.CreateContentBlockParams{Name: "Numbers", BlockType: services.BlockStats, Items: "500 | Customers", IsActive: true})
+	queries.AttachPageBlock(ctx, sqlc.AttachPageBlockParams{Route: "/spring-offer", BlockID: block.ID})
+	settings.Set("slug", "Campaigns/Spring")
+	settings.Set("layout", "minimal")
+	settings.Set("noindex", "on")
+	if rec := post(edit, settings); rec.Code != http.StatusSeeOther {
+		t.Fatalf("update: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := get("/spring-offer"); rec.Code != http.StatusNotFound {
+		t.Errorf("old slug: expected 404, got %d", rec.Code)
+	}
+	rec = get("/campaigns/spring")
+	body = rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "content-block-stats") || !strings.Contains(body, `name="robots" content="noindex"`) {
+		t.Errorf("moved page: %d", rec.Code)
+	}
+	if strings.Contains(body, "search-modal") {
+		t.Error("minimal layout should not show the site navigation")
+	}
+	if rec := get("/sitemap.xml"); strings.Contains(rec.Body.String(), "campaigns/spring") {
+		t.Error("noindex page listed in the sitemap")
+	}
+
+	if rec := post(edit+"/unpublish", nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("unpublish: %d", rec.Code)
+	}
+	if rec := get("/campaigns/spring"); rec.Code != http.StatusNotFound {
+		t.Errorf("unpublished: expected 404, got %d", rec.Code)
+	}
+	if rec := get("/no-such-page"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown path: expected 404, got %d", rec.Code)
+	}
+	if rec := get("/products"); rec.Code != http.StatusOK {
+		t.Errorf("built-in routes still win: %d", rec.Code)
+	}
+	if rec := send(http.MethodGet, "/admin/landing-pages", "", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "/campaigns/spring") {
+		t.Errorf("admin list: %d", rec.Code)
+	}
+}
@@ -0,0 +1,204 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestFormBuilder_E2E builds a lead form in the admin, submits it on its own
+// page and embedded in a landing page, and reads the submissions back,
+// rendering the REAL templates throughout.
+func TestFormBuilder_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	formSvc := services.NewForms(queries, testLogger, nil, "")
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	forms := adminHandlers.NewFormsHandler(queries, testLogger, appCache)
+	adminGroup.GET("/forms", forms.List)
+	adminGroup.POST("/forms", forms.Create)
+	adminGroup.GET("/forms/:id/edit", forms.Edit)
+	adminGroup.POST("/forms/:id", forms.Update)
+	adminGroup.GET("/forms/:id/fields/new", forms.NewField)
+	adminGroup.POST("/forms/:id/fields", forms.CreateField)
+	adminGroup.POST("/forms/:id/fields/reorder", forms.ReorderFields)
+	adminGroup.GET("/forms/:id/submissions", forms.Submissions)
+	adminGroup.DELETE("/forms/:id/submissions/:sid", forms.DeleteSubmission)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	formsHandler := publicHandlers.NewFormsHandler(formSvc, testLogger, appCache)
+	publicGroup.GET("/forms/:slug", formsHandler.ShowForm)
+	publicGroup.POST("/forms/:slug/submit", formsHandler.SubmitForm)
+	publicGroup.GET("/*", publicHandlers.NewLandingPagesHandler(queries, testLogger, formSvc, appCache).LandingPage)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	send := func(method, path, contentType, body string, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		return send(http.MethodPost, path, echo.MIMEApplicationForm, form.Encode(), false)
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		return send(http.MethodGet, path, "", "", false)
+	}
+
+	settings := url.Values{
+		"name": {"Demo Request"}, "submit_label": {"Book a demo"}, "is_active": {"on"},
+		"success_message": {"Thanks, we will call you."}, "notify_emails": {"sales@example"},
+	}
+	if rec := post("/admin/forms", settings); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "separated by commas") {
+		t.Errorf("invalid notification address: %d", rec.Code)
+	}
+	settings.Set("notify_emails", "sales@example.com")
+	rec := post("/admin/forms", settings)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: %d %s", rec.Code, rec.Body.String())
+	}
+	stored, err := queries.GetFormBySlug(ctx, "demo-request")
+	if err != nil {
+		t.Fatalf("created form: %v", err)
+	}
+	base := fmt.Sprintf("/admin/forms/%d", stored.ID)
+
+	for _, field := range []url.Values{
+		{"label": {"Your name"}, "name": {"name"}, "field_type": {"text"}, "required": {"on"}, "min_length": {"2"}},
+		{"label": {"Work email"}, "field_type": {"email"}, "required": {"on"}},
+		{"label": {"Team size"}, "field_type": {"select"}, "options": {"1-10\n11-50\n51+"}},
+		{"label": {"I agree to be contacted"}, "name": {"consent"}, "field_type": {"checkbox"}, "required": {"on"}},
+	} {
+		if rec := post(base+"/fields", field); rec.Code != http.StatusSeeOther {
+			t.Fatalf("add %s: %d %s", field.Get("label"), rec.Code, rec.Body.String())
+		}
+	}
+	if rec := post(base+"/fields", url.Values{"label": {"Budget"}, "field_type": {"select"}}); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "at least one option") {
+		t.Errorf("dropdown without options: %d", rec.Code)
+	}
+	if rec := post(base+"/fields", url.Values{"label": {"Name"}, "field_type": {"text"}}); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "already uses this key") {
+		t.Errorf("duplicate key: %d", rec.Code)
+	}
+	if rec := get(base + "/edit"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "work_email") || !strings.Contains(rec.Body.String(), "Dropdown") {
+		t.Errorf("edit page: %d", rec.Code)
+	}
+
+	// Put the email field first
+	fields, _ := queries.ListFormFields(ctx, stored.ID)
+	order := fmt.Sprintf(`[{"id":%d,"order":0},{"id":%d,"order":1}]`, fields[1].ID, fields[0].ID)
+	if rec := send(http.MethodPost, base+"/fields/reorder", echo.MIMEApplicationJSON, order, false); rec.Code != http.StatusOK {
+		t.Fatalf("reorder: %d", rec.Code)
+	}
+
+	rec = get("/forms/demo-request")
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `hx-post="/forms/demo-request/submit"`) || !strings.Contains(body, "Book a demo") {
+		t.Fatalf("form page: %d", rec.Code)
+	}
+	if strings.Index(body, "Work email") > strings.Index(body, "Your name") {
+		t.Error("fields should follow the saved order")
+	}
+
+	// Refused answers come back in the form with their errors
+	answers := url.Values{"name": {"A"}, "work_email": {"ana@example.com"}, "team_size": {"1-10"}}
+	rec = send(http.MethodPost, "/forms/demo-request/submit", echo.MIMEApplicationForm, answers.Encode(), true)
+	body = rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Enter at least 2 characters.") || !strings.Contains(body, "This field is required.") || !strings.Contains(body, `value="ana@example.com"`) {
+		t.Errorf("refused answers: %d %s", rec.Code, body)
+	}
+	if strings.Contains(body, "<html") {
+		t.Error("HTMX submissions should get the form alone")
+	}
+	if n, _ := queries.CountFormSubmissions(ctx, stored.ID); n != 0 {
+		t.Errorf("refused answers stored: %d", n)
+	}
+
+	answers.Set("name", "Ana Ruiz")
+	answers.Set("consent", "on")
+	rec = send(http.MethodPost, "/forms/demo-request/submit", echo.MIMEApplicationForm, answers.Encode(), true)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Thanks, we will call you.") || strings.Contains(rec.Body.String(), "<form") {
+		t.Errorf("accepted answers: %d %s", rec.Code, rec.Body.String())
+	}
+	// Without HTMX the whole page comes back
+	answers.Set("name", "Bo Chen")
+	answers.Set("work_email", "bo@example.com")
+	if rec := post("/forms/demo-request/submit", answers); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<html") || !strings.Contains(rec.Body.String(), "Thanks, we will call you.") {
+		t.Errorf("submission without HTMX: %d", rec.Code)
+	}
+
+	rec = get(base + "/submissions")
+	body = rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "2 submissions") || !strings.Contains(body, "Ana Ruiz") || !strings.Contains(body, "mailto:bo@example.com") || !strings.Contains(body, "I agree to be contacted") {
+		t.Errorf("submissions page: %d", rec.Code)
+	}
+	if strings.Index(body, "Bo Chen") > strings.Index(body, "Ana Ruiz") {
+		t.Error("newest submission should come first")
+	}
+	subs, _ := queries.ListFormSubmissions(ctx, sqlc.ListFormSubmissionsParams{FormID: stored.ID, Limit: 10})
+	if rec := send(http.MethodDelete, fmt.Sprintf("%s/submissions/%d", base, subs[0].ID), "", "", true); rec.Code != http.StatusOK {
+		t.Errorf("delete submission: %d", rec.Code)
+	}
+	if n, _ := queries.CountFormSubmissions(ctx, stored.ID); n != 1 {
+		t.Errorf("submissions after delete: %d", n)
+	}
+	if rec := get("/admin/forms"); !strings.Contains(rec.Body.String(), "/forms/demo-request") {
+		t.Error("form list lacks the form")
+	}
+
+	// Embedded in a landing page's form section
+	page, _ := queries.CreateLandingPage(ctx, sqlc.CreateLandingPageParams{Title: "Demo Week", Slug: "demo-week", Layout: services.LandingLayoutDefault})
+	queries.CreateLandingPageSection(ctx, sqlc.CreateLandingPageSectionParams{LandingPageID: page.ID, SectionType: services.SectionForm, Heading: "Book now", Items: "demo-request"})
+	queries.SetLandingPageStatus(ctx, sqlc.SetLandingPageStatusParams{Status: services.LandingPublished, ID: page.ID})
+	if body := get("/demo-week").Body.String(); !strings.Contains(body, `id="custom-form-demo-request"`) || strings.Contains(body, "landing:demo-week") {
+		t.Error("landing page should embed the custom form")
+	}
+
+	// Deactivating takes the form down and the landing page falls back to
+	// its built-in lead form
+	settings.Del("is_active")
+	if rec := post(base, settings); rec.Code != http.StatusSeeOther {
+		t.Fatalf("deactivate: %d", rec.Code)
+	}
+	if rec := get("/forms/demo-request"); rec.Code != http.StatusNotFound {
+		t.Errorf("inactive form page: %d", rec.Code)
+	}
+	if rec := post("/forms/demo-request/submit", answers); rec.Code != http.StatusNotFound {
+		t.Errorf("inactive form submit: %d", rec.Code)
+	}
+	if body := get("/demo-week").Body.String(); strings.Contains(body, "custom-form-demo-request") || !strings.Contains(body, "landing:demo-week") {
+		t.Error("landing page should fall back to the built-in form")
+	}
+}
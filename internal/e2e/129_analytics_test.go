@@ -0,0 +1,131 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestAnalytics_E2E browses the public site as visitors, bots, HTMX swaps
+// and a signed-in admin, and checks which views reach the Admin > Analytics
+// dashboard rendered by the REAL templates, before and after the daily
+// rollup.
+func TestAnalytics_E2E(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	appCache := services.NewCache()
+	analytics := services.NewAnalytics(db, queries, testLogger, services.AnalyticsConfig{CountryHeader: "CF-IPCountry"})
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	adminGroup.GET("/analytics", adminHandlers.NewAnalyticsHandler(analytics, testLogger).Dashboard)
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.PageViews(analytics))
+	productsHandler := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products", productsHandler.ProductsList)
+	publicGroup.GET("/*", publicHandlers.NewLandingPagesHandler(queries, testLogger, services.NewForms(queries, testLogger, nil, ""), appCache).LandingPage)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	page, _ := queries.CreateLandingPage(ctx, sqlc.CreateLandingPageParams{Title: "Spring Launch", Slug: "spring-launch", Layout: services.LandingLayoutDefault})
+	queries.SetLandingPageStatus(ctx, sqlc.SetLandingPageStatusParams{Status: services.LandingPublished, ID: page.ID})
+
+	const (
+		iphone = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+		chrome = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36"
+	)
+	visit := func(path, ua string, header map[string]string, signedIn bool) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("User-Agent", ua)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		if signedIn {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	visit("/spring-launch", iphone, map[string]string{"Referer": "https://www.google.com/", "CF-IPCountry": "de"}, false)
+	visit("/spring-launch", chrome, map[string]string{"Referer": "https://news.ycombinator.com/item?id=1"}, false)
+	visit("/products", chrome, map[string]string{"Referer": "http://example.com/spring-launch"}, false)
+	// Not counted
+	visit("/spring-launch", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", nil, false)
+	visit("/spring-launch", chrome, map[string]string{"DNT": "1"}, false)
+	visit("/spring-launch", chrome, map[string]string{"HX-Request": "true"}, false)
+	visit("/spring-launch", chrome, nil, true)
+	if code := visit("/no-such-page", chrome, nil, false); code != http.StatusNotFound {
+		t.Fatalf("missing page: %d", code)
+	}
+	if n, err := analytics.Flush(ctx); err != nil || n != 3 {
+		t.Fatalf("Flush = %d, %v; want 3 views", n, err)
+	}
+
+	dashboard := func(query string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/admin/analytics"+query, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("dashboard%s: %d", query, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	body := dashboard("")
+	for _, want := range []string{
+		`id="analytics-total">3<`, `id="analytics-today">3<`, "Views, last 30 days",
+		`href="/spring-launch"`, "google.com", "news.ycombinator.com", "Direct or internal",
+		">DE<", "Unknown", "desktop", "mobile",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("dashboard lacks %q", want)
+		}
+	}
+	if strings.Index(body, `href="/spring-launch"`) > strings.Index(body, `href="/products"`) {
+		t.Error("the most viewed page should be listed first")
+	}
+	if n := strings.Count(body, `class="analytics-day `); n != 30 {
+		t.Errorf("trend has %d days, want 30", n)
+	}
+	if !strings.Contains(body, `"views":3}]`) {
+		t.Error("trend JSON should end with today's 3 views")
+	}
+	if n := strings.Count(dashboard("?days=7"), `class="analytics-day `); n != 7 {
+		t.Errorf("7 day trend has %d days", n)
+	}
+	if !strings.Contains(dashboard("?days=12"), "Views, last 30 days") {
+		t.Error("an unknown range should fall back to 30 days")
+	}
+
+	// Rolled up as if the day were over, the counts stay the same
+	if n, err := analytics.Rollup(ctx, time.Now().Add(24*time.Hour)); err != nil || n != 3 {
+		t.Fatalf("Rollup = %d, %v", n, err)
+	}
+	if body := dashboard(""); !strings.Contains(body, `id="analytics-total">3<`) || !strings.Contains(body, "news.ycombinator.com") {
+		t.Error("rolled up views should still be reported")
+	}
+}
@@ -94,6 +94,7 @@ func TestProductCertificationsAdd_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -147,6 +148,7 @@ func TestProductCertificationsAddWithoutOptionalFields_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -211,6 +213,7 @@ func TestProductCertificationsDeleteAll_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/products/%d/certifications", product.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -245,6 +248,7 @@ func TestProductCertificationsEditForm_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/products/%d/certifications?edit=%d", product.ID, cert.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -287,6 +291,7 @@ func TestProductCertificationsUpdate_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -0,0 +1,137 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestDashboardCards_E2E fills the site with content, leads, downloads, a
+// post in review and activity, and checks the dashboard cards rendered by
+// the REAL templates, on the page and one at a time through the HTMX
+// refresh route.
+func TestDashboardCards_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	dashboard := adminHandlers.NewDashboardHandler(queries, testLogger)
+	adminGroup.GET("/dashboard", dashboard.ShowDashboard)
+	adminGroup.GET("/dashboard/cards/:card", dashboard.Card)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+
+	t.Run("empty site", func(t *testing.T) {
+		code, body := get("/admin/dashboard")
+		if code != http.StatusOK {
+			t.Fatalf("dashboard: %d", code)
+		}
+		for _, card := range []string{"content", "review", "leads", "lead-trend", "whitepapers", "activity"} {
+			if !strings.Contains(body, `id="dashboard-card-`+card+`"`) {
+				t.Errorf("dashboard lacks the %s card", card)
+			}
+		}
+		for _, want := range []string{"Nothing is waiting for review.", "No leads yet.", "No downloads yet."} {
+			if !strings.Contains(body, want) {
+				t.Errorf("empty dashboard lacks %q", want)
+			}
+		}
+		if strings.Contains(body, "coming soon") {
+			t.Error("dashboard still shows the activity placeholder")
+		}
+	})
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cat", Slug: "cat", Description: "d", Icon: "i"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "P1", Slug: "p1", Name: "Live Product", Description: "d", CategoryID: cat.ID, Status: "published"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "P2", Slug: "p2", Name: "Draft Product", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "P3", Slug: "p3", Name: "Other Draft", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	review, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "P4", Slug: "p4", Name: "Reviewed Gadget", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	queries.SaveContentWorkflow(ctx, sqlc.SaveContentWorkflowParams{ContentType: "product", ContentID: review.ID, State: services.WorkflowInReview})
+
+	contact, _ := queries.CreateContactSubmission(ctx, sqlc.CreateContactSubmissionParams{
+		Name: "Ada Buyer", Email: "ada@example.com", Company: "Acme", Message: "Quote please",
+		InquiryType: sql.NullString{String: "sales", Valid: true},
+	})
+	topic, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "IoT", Slug: "iot", ColorHex: "#0000FF", Icon: "i"})
+	paper, _ := queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+		Title: "Edge Computing Guide", Slug: "edge", Description: "d", TopicID: topic.ID,
+		PdfFilePath: "/x.pdf", PublishedDate: "2026-01-01", IsPublished: 1,
+	})
+	queries.CreateWhitepaperDownload(ctx, sqlc.CreateWhitepaperDownloadParams{WhitepaperID: paper.ID, Name: "Bob Reader", Email: "bob@example.com", Company: "Globex"})
+	queries.IncrementWhitepaperDownloadCount(ctx, paper.ID)
+	queries.CreateActivityLog(ctx, sqlc.CreateActivityLogParams{Action: "created", ResourceType: "product", Description: "Created product Live Product"})
+
+	t.Run("filled site", func(t *testing.T) {
+		code, body := get("/admin/dashboard")
+		if code != http.StatusOK {
+			t.Fatalf("dashboard: %d", code)
+		}
+		for _, want := range []string{
+			"3 drafts",           // content card: products
+			"Reviewed Gadget",    // review card
+			"Ada Buyer", "1 new", // leads card
+			"Downloaded Edge Computing Guide", // whitepaper lead
+			`id="dashboard-lead-total">2<`,    // lead trend: both leads arrived today
+			"(1 in 30 days)",                  // whitepapers card
+			"Created product Live Product",    // activity card
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("dashboard lacks %q", want)
+			}
+		}
+		if n := strings.Count(body, `class="dashboard-trend-day `); n != 7 {
+			t.Errorf("lead trend has %d days, want 7", n)
+		}
+		if !strings.Contains(body, "/admin/contact/submissions/"+strconv.FormatInt(contact.ID, 10)) {
+			t.Error("contact lead does not link to its submission")
+		}
+	})
+
+	t.Run("single card refresh", func(t *testing.T) {
+		code, body := get("/admin/dashboard/cards/leads")
+		if code != http.StatusOK {
+			t.Fatalf("leads card: %d", code)
+		}
+		if !strings.HasPrefix(strings.TrimSpace(body), `<div id="dashboard-card-leads"`) || !strings.Contains(body, `hx-trigger="every 60s"`) {
+			t.Errorf("leads card is not a standalone refreshing card:\n%s", body)
+		}
+		if !strings.Contains(body, `"X-Background-Request": "true"`) {
+			t.Error("card poll is not marked as a background request, so it keeps the session alive")
+		}
+		if strings.Contains(body, "dashboard-card-content") || strings.Contains(body, "<html") {
+			t.Error("card refresh renders more than the card")
+		}
+		if code, _ := get("/admin/dashboard/cards/nope"); code != http.StatusNotFound {
+			t.Errorf("unknown card: %d, want 404", code)
+		}
+	})
+}
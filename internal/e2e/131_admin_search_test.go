@@ -0,0 +1,123 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestAdminSearch_E2E searches the command palette by title, SKU and slug
+// across content types and for settings screens, with the REAL templates,
+// and checks that the palette is on admin pages.
+func TestAdminSearch_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	adminGroup.GET("/dashboard", adminHandlers.NewDashboardHandler(queries, testLogger).ShowDashboard)
+	adminGroup.GET("/search", adminHandlers.NewAdminSearchHandler(queries, testLogger).Search)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	search := func(q string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/admin/search?q="+url.QueryEscape(q), nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("search %q: %d", q, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cat", Slug: "cat", Description: "d", Icon: "i"})
+	gateway, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-9000", Slug: "edge-gateway", Name: "Edge Gateway", Description: "d", CategoryID: cat.ID, Status: "published"})
+	trashed, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1000", Slug: "old-gateway", Name: "Old Gateway", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	queries.TrashProduct(ctx, trashed.ID)
+	blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1})
+	post, _ := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Why the edge matters", Slug: "edge-computing-explained", Excerpt: "e", Body: "b",
+		CategoryID: blogCat.ID, AuthorID: author.ID, Status: "draft",
+	})
+	page, _ := queries.CreateLandingPage(ctx, sqlc.CreateLandingPageParams{Title: "Spring Launch", Slug: "spring-launch", Layout: services.LandingLayoutDefault})
+
+	t.Run("title across types", func(t *testing.T) {
+		body := search("edge")
+		for _, want := range []string{
+			fmt.Sprintf(`href="/admin/products/%d/edit"`, gateway.ID),
+			fmt.Sprintf(`href="/admin/blog/posts/%d/edit"`, post.ID),
+			"Edge Gateway", "Why the edge matters", ">Product<", ">Blog post<",
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("search edge lacks %q", want)
+			}
+		}
+		// Titles starting with the query come first
+		if strings.Index(body, "Edge Gateway") > strings.Index(body, "Why the edge matters") {
+			t.Error("prefix match is not ranked first")
+		}
+	})
+
+	t.Run("SKU and slug", func(t *testing.T) {
+		if body := search("gw-9"); !strings.Contains(body, "Edge Gateway") || !strings.Contains(body, "GW-9000") {
+			t.Errorf("SKU search misses the product:\n%s", body)
+		}
+		if body := search("spring-launch"); !strings.Contains(body, fmt.Sprintf(`href="/admin/landing-pages/%d/edit"`, page.ID)) {
+			t.Errorf("slug search misses the landing page:\n%s", body)
+		}
+		if body := search("gw-1000"); strings.Contains(body, "Old Gateway") || !strings.Contains(body, "No content or settings match") {
+			t.Errorf("trashed product is found:\n%s", body)
+		}
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		body := search("timezone")
+		if !strings.Contains(body, `href="/admin/settings?tab=general"`) || !strings.Contains(body, ">Settings<") {
+			t.Errorf("settings search misses the General tab:\n%s", body)
+		}
+		if body := search("blog settings"); !strings.Contains(body, `href="/admin/blog/settings"`) {
+			t.Errorf("multi-word settings search misses Blog Settings:\n%s", body)
+		}
+	})
+
+	t.Run("empty query shows the hint", func(t *testing.T) {
+		if body := search("  "); !strings.Contains(body, "Type a title, SKU, slug or settings name") {
+			t.Errorf("empty query:\n%s", body)
+		}
+	})
+
+	t.Run("palette on admin pages", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		for _, want := range []string{`id="command-palette"`, `hx-get="/admin/search"`, "/public/js/command-palette.js"} {
+			if !strings.Contains(rec.Body.String(), want) {
+				t.Errorf("dashboard lacks %q", want)
+			}
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestListInlineEdit_E2E publishes, features and reorders items from the
+// product, blog post and case study lists through the HTMX PATCH endpoints,
+// with the REAL templates, and checks the returned rows and the database.
+func TestListInlineEdit_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	adminHandlers.SetWorkflow(services.NewWorkflow(queries, testLogger, nil, nil, "http://localhost"))
+	adminHandlers.SetPublishChecker(services.NewPublishChecker(queries, t.TempDir(), []string{"admin"}))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	cache := services.NewCache()
+	products := adminHandlers.NewProductsHandler(queries, testLogger, nil, cache)
+	posts := adminHandlers.NewBlogPostsHandler(queries, testLogger, cache)
+	caseStudies := adminHandlers.NewCaseStudiesHandler(queries, testLogger, cache)
+	adminGroup.GET("/products", products.List)
+	adminGroup.PATCH("/products/:id/inline", products.InlineUpdate)
+	adminGroup.GET("/blog/posts", posts.List)
+	adminGroup.PATCH("/blog/posts/:id/inline", posts.InlineUpdate)
+	adminGroup.GET("/case-studies", caseStudies.List)
+	adminGroup.PATCH("/case-studies/:id/inline", caseStudies.InlineUpdate)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	patch := func(path string, form url.Values) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+	get := func(path string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: %d", path, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	isRow := func(t *testing.T, body, id string) {
+		t.Helper()
+		if !strings.HasPrefix(strings.TrimSpace(body), `<tr id="`+id+`"`) || strings.Contains(body, "<html") {
+			t.Errorf("response is not the %s row:\n%s", id, body)
+		}
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	product, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	productPath := fmt.Sprintf("/admin/products/%d/inline", product.ID)
+
+	t.Run("product list rows", func(t *testing.T) {
+		body := get("/admin/products")
+		for _, want := range []string{fmt.Sprintf(`id="product-row-%d"`, product.ID), `hx-patch="` + productPath + `"`, "Gateways"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("product list lacks %q", want)
+			}
+		}
+	})
+
+	t.Run("publish product", func(t *testing.T) {
+		code, body := patch(productPath, url.Values{"published": {"1"}})
+		if code != http.StatusOK {
+			t.Fatalf("publish: %d", code)
+		}
+		isRow(t, body, fmt.Sprintf("product-row-%d", product.ID))
+		if !strings.Contains(body, ">Published</button>") || !strings.Contains(body, "Gateways") {
+			t.Errorf("row does not show the published product:\n%s", body)
+		}
+		p, _ := queries.GetProduct(ctx, product.ID)
+		if p.Status != "published" || !p.PublishedAt.Valid {
+			t.Errorf("product status %q, published_at %v", p.Status, p.PublishedAt)
+		}
+		wf, err := queries.GetContentWorkflow(ctx, sqlc.GetContentWorkflowParams{ContentType: "product", ContentID: product.ID})
+		if err != nil || wf.State != services.WorkflowPublished {
+			t.Errorf("workflow state %q (%v), want published", wf.State, err)
+		}
+	})
+
+	t.Run("feature and order product", func(t *testing.T) {
+		code, body := patch(productPath, url.Values{"featured": {"1"}})
+		if code != http.StatusOK || !strings.Contains(body, `name="featured_order"`) {
+			t.Fatalf("feature: %d\n%s", code, body)
+		}
+		if _, body = patch(productPath, url.Values{"featured_order": {"3"}}); !strings.Contains(body, `value="3"`) {
+			t.Errorf("row does not show the featured order:\n%s", body)
+		}
+		p, _ := queries.GetProduct(ctx, product.ID)
+		if !p.IsFeatured || p.FeaturedOrder.Int64 != 3 || p.Status != "published" {
+			t.Errorf("product featured %v order %v status %q", p.IsFeatured, p.FeaturedOrder, p.Status)
+		}
+
+		if _, body = patch(productPath, url.Values{"featured_order": {"first"}}); !strings.Contains(body, "Featured order must be a whole number.") {
+			t.Errorf("bad order has no notice:\n%s", body)
+		}
+		if p, _ = queries.GetProduct(ctx, product.ID); p.FeaturedOrder.Int64 != 3 {
+			t.Errorf("bad order changed the order to %v", p.FeaturedOrder)
+		}
+
+		patch(productPath, url.Values{"featured": {"0"}})
+		if p, _ = queries.GetProduct(ctx, product.ID); p.IsFeatured {
+			t.Error("product is still featured")
+		}
+	})
+
+	t.Run("trashed product", func(t *testing.T) {
+		trashed, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-2", Slug: "gw-2", Name: "Old", Description: "d", CategoryID: cat.ID, Status: "draft"})
+		queries.TrashProduct(ctx, trashed.ID)
+		if code, _ := patch(fmt.Sprintf("/admin/products/%d/inline", trashed.ID), url.Values{"published": {"1"}}); code != http.StatusNotFound {
+			t.Errorf("trashed product: %d, want 404", code)
+		}
+	})
+
+	blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1})
+	post, _ := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Launch notes", Slug: "launch-notes", Excerpt: "e", Body: "b",
+		CategoryID: blogCat.ID, AuthorID: author.ID, Status: "published",
+	})
+
+	t.Run("unpublish blog post", func(t *testing.T) {
+		if body := get("/admin/blog/posts"); !strings.Contains(body, fmt.Sprintf(`hx-patch="/admin/blog/posts/%d/inline"`, post.ID)) {
+			t.Error("post list has no inline publish button")
+		}
+		code, body := patch(fmt.Sprintf("/admin/blog/posts/%d/inline", post.ID), url.Values{"published": {"0"}})
+		if code != http.StatusOK {
+			t.Fatalf("unpublish: %d", code)
+		}
+		isRow(t, body, fmt.Sprintf("blog-post-row-%d", post.ID))
+		if !strings.Contains(body, ">Draft</button>") || !strings.Contains(body, "News") {
+			t.Errorf("row does not show the draft post:\n%s", body)
+		}
+		if p, _ := queries.GetBlogPost(ctx, post.ID); p.Status != "draft" {
+			t.Errorf("post status %q, want draft", p.Status)
+		}
+	})
+
+	industry, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Retail", Slug: "retail", Icon: "i", Description: "d", SortOrder: 1})
+	cs, _ := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+		Slug: "acme", Title: "Acme rollout", ClientName: "Acme", IndustryID: industry.ID,
+		Summary: "s", ChallengeTitle: "c", ChallengeContent: "c", SolutionTitle: "s", SolutionContent: "s",
+		OutcomeTitle: "o", OutcomeContent: "o", DisplayOrder: 5,
+	})
+	csPath := fmt.Sprintf("/admin/case-studies/%d/inline", cs.ID)
+
+	t.Run("case study publish needs the checklist", func(t *testing.T) {
+		code, body := patch(csPath, url.Values{"published": {"1"}})
+		if code != http.StatusOK {
+			t.Fatalf("publish: %d", code)
+		}
+		isRow(t, body, fmt.Sprintf("case-study-row-%d", cs.ID))
+		if !strings.Contains(body, "fails the publish checklist") || !strings.Contains(body, fmt.Sprintf(`href="/admin/case-studies/%d/edit"`, cs.ID)) {
+			t.Errorf("row has no checklist notice:\n%s", body)
+		}
+		if got, _ := queries.AdminGetCaseStudy(ctx, cs.ID); got.IsPublished != 0 {
+			t.Error("case study failing the checklist was published")
+		}
+	})
+
+	t.Run("case study order and unpublish", func(t *testing.T) {
+		if body := get("/admin/case-studies"); !strings.Contains(body, `name="display_order" value="5"`) {
+			t.Error("case study list has no display order input")
+		}
+		if _, body := patch(csPath, url.Values{"display_order": {"2"}}); !strings.Contains(body, `value="2"`) {
+			t.Errorf("row does not show the new order:\n%s", body)
+		}
+		queries.SetCaseStudyPublished(ctx, sqlc.SetCaseStudyPublishedParams{IsPublished: 1, ID: cs.ID})
+		if _, body := patch(csPath, url.Values{"published": {"0"}}); !strings.Contains(body, ">Draft</button>") {
+			t.Errorf("row does not show the unpublished case study:\n%s", body)
+		}
+		got, _ := queries.AdminGetCaseStudy(ctx, cs.ID)
+		if got.DisplayOrder != 2 || got.IsPublished != 0 {
+			t.Errorf("case study order %d published %d", got.DisplayOrder, got.IsPublished)
+		}
+	})
+}
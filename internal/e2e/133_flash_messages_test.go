@@ -0,0 +1,135 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestFlashMessages_E2E saves a settings form and checks, with the REAL
+// templates, that the admin layout shows its flash exactly once, and that an
+// HTMX inline edit reports back through an HX-Trigger toast instead.
+func TestFlashMessages_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	adminHandlers.SetWorkflow(services.NewWorkflow(queries, testLogger, nil, nil, "http://localhost"))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	cache := services.NewCache()
+	sectionSettings := adminHandlers.NewSectionSettingsHandler(queries, testLogger, cache)
+	posts := adminHandlers.NewBlogPostsHandler(queries, testLogger, cache)
+	adminGroup.GET("/blog/settings", sectionSettings.BlogSettings)
+	adminGroup.POST("/blog/settings", sectionSettings.UpdateBlogSettings)
+	adminGroup.PATCH("/blog/posts/:id/inline", posts.InlineUpdate)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	// send makes a request with the current session cookie and keeps any
+	// cookie the response sets, like a browser would.
+	send := func(req *http.Request) *httptest.ResponseRecorder {
+		t.Helper()
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "bluejay_session" {
+				cookie = c
+			}
+		}
+		return rec
+	}
+	settingsPage := func() string {
+		t.Helper()
+		rec := send(httptest.NewRequest(http.MethodGet, "/admin/blog/settings", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET settings: %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	t.Run("layout has the notification region", func(t *testing.T) {
+		body := settingsPage()
+		for _, want := range []string{`id="admin-toasts"`, `aria-label="Notifications"`, "/public/js/admin-toasts.js"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("admin page lacks %q", want)
+			}
+		}
+		if strings.Contains(body, "admin-toast-success") {
+			t.Error("page shows a flash before anything was saved")
+		}
+	})
+
+	t.Run("flash after save is shown once", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/blog/settings", strings.NewReader(url.Values{
+			"blog_posts_per_page": {"12"},
+			"blog_show_author":    {"on"},
+		}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := send(req)
+		if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/blog/settings" {
+			t.Fatalf("save: %d to %q", rec.Code, rec.Header().Get("Location"))
+		}
+
+		body := settingsPage()
+		if !strings.Contains(body, "admin-toast-success") || !strings.Contains(body, "Blog settings saved.") {
+			t.Errorf("settings page does not show the flash")
+		}
+		if !strings.Contains(body, `aria-label="Dismiss notification"`) {
+			t.Error("flash has no dismiss button")
+		}
+		if body = settingsPage(); strings.Contains(body, "Blog settings saved.") {
+			t.Error("flash shown again on the next page")
+		}
+	})
+
+	t.Run("HTMX edit raises a toast", func(t *testing.T) {
+		blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+		author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1})
+		post, _ := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: "Launch notes", Slug: "launch-notes", Excerpt: "e", Body: "b",
+			CategoryID: blogCat.ID, AuthorID: author.ID, Status: "published",
+		})
+
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/admin/blog/posts/%d/inline", post.ID), strings.NewReader("published=0"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set("HX-Request", "true")
+		rec := send(req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("inline edit: %d", rec.Code)
+		}
+		var trigger map[string]struct {
+			Messages []struct{ Level, Message string }
+		}
+		if err := json.Unmarshal([]byte(rec.Header().Get("HX-Trigger")), &trigger); err != nil {
+			t.Fatalf("HX-Trigger %q: %v", rec.Header().Get("HX-Trigger"), err)
+		}
+		msgs := trigger[customMiddleware.ToastEvent].Messages
+		if len(msgs) != 1 || msgs[0].Level != customMiddleware.FlashSuccess || msgs[0].Message != "Saved 'Launch notes'." {
+			t.Errorf("toasts = %+v", msgs)
+		}
+		if strings.Contains(settingsPage(), "Launch notes") {
+			t.Error("HTMX toast was also queued as a flash")
+		}
+	})
+}
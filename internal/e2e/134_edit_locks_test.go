@@ -0,0 +1,131 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestEditLocks_E2E opens the same product as two admins, with the REAL
+// templates, and checks that the second one is told who is editing it until
+// the first one leaves the form.
+func TestEditLocks_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	products := adminHandlers.NewProductsHandler(queries, testLogger, nil, services.NewCache())
+	locks := adminHandlers.NewEditLocksHandler(services.NewEditLocks(queries, 0), testLogger)
+	adminGroup.GET("/products/:id/edit", products.Edit)
+	adminGroup.POST("/locks/:type/:id", locks.Heartbeat)
+	adminGroup.POST("/locks/:type/:id/release", locks.Release)
+
+	first := loginTabsAdmin(t, e, queries)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secondpassword"), bcrypt.DefaultCost)
+	queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{Email: "editor@test.com", PasswordHash: string(hash), DisplayName: "Second Editor", Role: "editor"})
+	req := httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(url.Values{
+		"email":    {"editor@test.com"},
+		"password": {"secondpassword"},
+	}.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	var second *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "bluejay_session" {
+			second = c
+		}
+	}
+	if second == nil {
+		t.Fatal("no session cookie for the second admin")
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	product, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	lockPath := fmt.Sprintf("/admin/locks/product/%d", product.ID)
+
+	post := func(cookie *http.Cookie, path string) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.String()
+	}
+
+	t.Run("edit form sends heartbeats", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/products/%d/edit", product.ID), nil)
+		req.AddCookie(first)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("edit page: %d", rec.Code)
+		}
+		body := rec.Body.String()
+		for _, want := range []string{`id="edit-lock"`, `hx-post="` + lockPath + `"`, `hx-trigger="load, every 30s"`, `data-release="` + lockPath + `/release"`, "/public/js/admin-edit-lock.js"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("edit page lacks %q", want)
+			}
+		}
+	})
+
+	t.Run("second admin is warned", func(t *testing.T) {
+		if code, body := post(first, lockPath); code != http.StatusOK || strings.Contains(body, "edit-lock-notice") {
+			t.Fatalf("first admin heartbeat: %d\n%s", code, body)
+		}
+		code, body := post(second, lockPath)
+		if code != http.StatusOK || !strings.Contains(body, "edit-lock-notice") || !strings.Contains(body, "Test Admin") || !strings.Contains(body, "currently editing this product") {
+			t.Errorf("second admin heartbeat: %d\n%s", code, body)
+		}
+		if _, body = post(first, lockPath); strings.Contains(body, "edit-lock-notice") {
+			t.Error("holder is warned about their own lock")
+		}
+	})
+
+	t.Run("lock passes on after release", func(t *testing.T) {
+		if code, _ := post(second, lockPath+"/release"); code != http.StatusNoContent {
+			t.Errorf("release by non-holder: %d", code)
+		}
+		if _, body := post(second, lockPath); !strings.Contains(body, "Test Admin") {
+			t.Error("non-holder released the lock")
+		}
+		if code, _ := post(first, lockPath+"/release"); code != http.StatusNoContent {
+			t.Fatalf("release: %d", code)
+		}
+		if _, body := post(second, lockPath); strings.Contains(body, "edit-lock-notice") {
+			t.Errorf("second admin still warned after release:\n%s", body)
+		}
+		if _, body := post(first, lockPath); !strings.Contains(body, "Second Editor") {
+			t.Errorf("first admin not warned about the new holder:\n%s", body)
+		}
+	})
+
+	t.Run("unknown content type", func(t *testing.T) {
+		if code, _ := post(first, "/admin/locks/settings/1"); code != http.StatusNotFound {
+			t.Errorf("unknown type: %d, want 404", code)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// A blog post whose product link fails to save is not created without it.
+func TestAdminTransactions_BlogPostRolledBack(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{
+		Name: "Tech", Slug: "tech", ColorHex: "#000000", SortOrder: 1,
+	})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{
+		Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1,
+	})
+
+	formData := url.Values{
+		"title":       {"Half Saved"},
+		"slug":        {"half-saved"},
+		"excerpt":     {"Test excerpt"},
+		"body":        {"Test body"},
+		"category_id": {fmt.Sprintf("%d", cat.ID)},
+		"author_id":   {fmt.Sprintf("%d", author.ID)},
+		"status":      {"draft"},
+		"product_ids": {"9999"}, // No such product
+	}
+	req := httptest.NewRequest(http.MethodPost, "/admin/blog/posts", strings.NewReader(formData.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	n, _ := queries.CountBlogPostsAdminFiltered(ctx, sqlc.CountBlogPostsAdminFilteredParams{
+		FilterStatus: "", FilterCategory: int64(0), FilterAuthor: int64(0), FilterSearch: "",
+	})
+	if n != 0 {
+		t.Errorf("expected the post to be rolled back, got %d posts", n)
+	}
+}
@@ -0,0 +1,116 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestCacheTags_ExactInvalidation checks that editing a product or a
+// solution's stats removes only the cached pages showing it.
+func TestCacheTags_ExactInvalidation(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sensors, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	meters, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Meters", Slug: "meters", Description: "d", Icon: "i"})
+	newProduct := func(slug string, categoryID int64) sqlc.Product {
+		p, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: slug, Slug: slug, Name: slug, Description: "d", CategoryID: categoryID, Status: "published"})
+		if err != nil {
+			t.Fatalf("create product %s: %v", slug, err)
+		}
+		return p
+	}
+	alpha := newProduct("alpha", sensors.ID)
+	newProduct("beta", sensors.ID)
+	newProduct("gauge", meters.ID)
+
+	edge, _ := queries.CreateSolution(ctx, sqlc.CreateSolutionParams{Title: "Edge", Slug: "edge", ShortDescription: "d", IsPublished: sql.NullBool{Bool: true, Valid: true}})
+	queries.CreateSolution(ctx, sqlc.CreateSolutionParams{Title: "Cloud", Slug: "cloud", ShortDescription: "d", IsPublished: sql.NullBool{Bool: true, Valid: true}})
+	queries.AddProductToSolution(ctx, sqlc.AddProductToSolutionParams{SolutionID: edge.ID, ProductID: alpha.ID})
+
+	e := echo.New()
+	e.Renderer = &stubRenderer{}
+	appCache := services.NewCache()
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), appCache)
+	solutions := publicHandlers.NewSolutionsHandler(queries, testLogger, appCache)
+	e.GET("/products", products.ProductsList)
+	e.GET("/products/:category", products.ProductsByCategory)
+	e.GET("/products/:category/:slug", products.ProductDetail)
+	e.GET("/solutions", solutions.SolutionsList)
+	e.GET("/solutions/:slug", solutions.SolutionDetail)
+	adminProducts := adminHandlers.NewProductsHandler(queries, testLogger, nil, appCache)
+	adminSolutions := adminHandlers.NewSolutionsHandler(queries, testLogger, appCache, nil)
+	e.POST("/admin/products/:id", adminProducts.Update)
+	e.POST("/admin/solutions/:id/stats", adminSolutions.AddStat)
+
+	serve := func(method, target string, form url.Values) int {
+		req := httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+		if form != nil {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	alphaPath := "/products/sensors/alpha"
+	warm := func() {
+		for _, path := range []string{
+			"/products", "/products/sensors", "/products/meters",
+			alphaPath, "/products/sensors/beta", "/products/meters/gauge",
+			"/solutions", "/solutions/edge", "/solutions/cloud",
+		} {
+			if code := serve(http.MethodGet, path, nil); code != http.StatusOK {
+				t.Fatalf("GET %s: %d", path, code)
+			}
+		}
+	}
+	var deleted []string
+	appCache.OnDelete(func(keys []string) { deleted = append(deleted, keys...) })
+	expectDeleted := func(action string, want ...string) {
+		t.Helper()
+		sort.Strings(deleted)
+		sort.Strings(want)
+		if got := strings.Join(deleted, ","); got != strings.Join(want, ",") {
+			t.Errorf("%s removed %s, want %s", action, got, strings.Join(want, ","))
+		}
+		deleted = nil
+	}
+
+	warm()
+	form := url.Values{"sku": {"alpha"}, "name": {"alpha"}, "description": {"new"}, "category_id": {fmt.Sprint(sensors.ID)}, "status": {"published"}}
+	if code := serve(http.MethodPost, fmt.Sprintf("/admin/products/%d", alpha.ID), form); code != http.StatusSeeOther {
+		t.Fatalf("update product: %d", code)
+	}
+	expectDeleted("product edit", "page:products", "page:products:sensors", "page:products:sensors:alpha", "page:solutions:edge")
+
+	// Moving the product also removes its new category's page
+	warm()
+	form.Set("category_id", fmt.Sprint(meters.ID))
+	if code := serve(http.MethodPost, fmt.Sprintf("/admin/products/%d", alpha.ID), form); code != http.StatusSeeOther {
+		t.Fatalf("move product: %d", code)
+	}
+	expectDeleted("product move", "page:products", "page:products:sensors", "page:products:meters", "page:products:sensors:alpha", "page:solutions:edge")
+
+	alphaPath = "/products/meters/alpha"
+	warm()
+	if code := serve(http.MethodPost, fmt.Sprintf("/admin/solutions/%d/stats", edge.ID), url.Values{"value": {"99%"}, "label": {"Uptime"}}); code != http.StatusOK {
+		t.Fatalf("add stat: %d", code)
+	}
+	expectDeleted("solution stat", "page:solutions:edge")
+}
@@ -0,0 +1,73 @@
+package e2e_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestStaticAssets_HashedURLs checks that pages link to content-hashed
+// stylesheets and scripts, that those are cached for a year and compressed,
+// and that plain names are revalidated.
+func TestStaticAssets_HashedURLs(t *testing.T) {
+	assets := services.NewAssets("public", "/public")
+	renderer := templates.NewRenderer("templates")
+	renderer.UseAssets(assets)
+
+	e := echo.New()
+	e.Use(customMiddleware.Compress())
+	e.Renderer = renderer
+	e.GET("/public/*", publicHandlers.NewAssetsHandler(assets).Serve)
+
+	var page bytes.Buffer
+	if err := renderer.Render(&page, "public/pages/products_index.html", map[string]interface{}{"Title": "Products A–Z"}, e.NewContext(httptest.NewRequest(http.MethodGet, "/products/a-z", nil), httptest.NewRecorder())); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	stylesheet := regexp.MustCompile(`href="(/public/css/styles\.[0-9a-f]{10}\.css)"`).FindStringSubmatch(page.String())
+	if stylesheet == nil {
+		t.Fatal("page does not link to the hashed stylesheet")
+	}
+	if !regexp.MustCompile(`src="/public/js/vendor/htmx\.min\.[0-9a-f]{10}\.js"`).MatchString(page.String()) {
+		t.Error("page does not link to the hashed htmx script")
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", "gzip, br")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(stylesheet[1])
+	if rec.Code != http.StatusOK {
+		t.Fatalf("hashed stylesheet: %d", rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=31536000, immutable" {
+		t.Errorf("hashed stylesheet Cache-Control = %q", got)
+	}
+	if got := rec.Header().Get("Content-Encoding"); got != "br" {
+		t.Errorf("hashed stylesheet Content-Encoding = %q", got)
+	}
+
+	rec = get("/public/css/styles.css")
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("plain stylesheet: %d, Cache-Control %q", rec.Code, rec.Header().Get("Cache-Control"))
+	}
+	rec = get("/public/css/styles.0123456789.css")
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("outdated hash: %d, Cache-Control %q", rec.Code, rec.Header().Get("Cache-Control"))
+	}
+	if rec := get("/public/css/missing.css"); rec.Code != http.StatusNotFound {
+		t.Errorf("missing file: %d", rec.Code)
+	}
+}
@@ -0,0 +1,115 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestAdminIPRules_E2E saves admin network rules under Admin Access: rules
+// that would lock out the admin saving them are refused, saved rules keep
+// other networks away from the login page, and the bypass token lets a
+// locked-out admin back in to fix them.
+func TestAdminIPRules_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	const token = "e2e-bypass-token-at-least-32-characters"
+
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.Renderer = templates.NewRenderer("templates")
+	e.HTTPErrorHandler = publicHandlers.NewErrorHandler(testLogger).Handle
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	ipFilter := customMiddleware.AdminIPFilter(customMiddleware.QuerySiteData(queries), token)
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.Group("/admin", ipFilter).POST("/login", authHandler.LoginSubmit)
+	e.Group("/admin", ipFilter).GET("/login", authHandler.ShowLoginPage)
+	adminGroup := e.Group("/admin", ipFilter, customMiddleware.RequireAuth())
+	access := adminHandlers.NewAdminAccessHandler(queries, testLogger)
+	adminGroup.GET("/access", access.Edit, customMiddleware.RequireRole("admin"))
+	adminGroup.POST("/access", access.Update, customMiddleware.RequireRole("admin"))
+
+	// httptest requests come from 192.0.2.1, the "office" here
+	session := loginTabsAdmin(t, e, queries)
+	serve := func(method, target, remote string, form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		var body *strings.Reader
+		if form != nil {
+			body = strings.NewReader(form.Encode())
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(method, target, body)
+		if form != nil {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		req.RemoteAddr = remote + ":40000"
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	const office, vpn, elsewhere = "192.0.2.1", "10.8.1.2", "198.51.100.1"
+
+	rec := serve(http.MethodPost, "/admin/access", office, url.Values{"allow": {"10.8.0.0/16"}}, session)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "lock you out") {
+		t.Fatalf("self-locking rules: %d, want 422 with a warning", rec.Code)
+	}
+	rec = serve(http.MethodPost, "/admin/access", office, url.Values{"allow": {"192.0.2.0/24\nintranet"}}, session)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "intranet") {
+		t.Fatalf("invalid rule: %d, want 422 naming the entry", rec.Code)
+	}
+	if s, _ := queries.GetSettings(context.Background()); s.AdminIpAllow != "" {
+		t.Fatalf("refused rules were saved: %q", s.AdminIpAllow)
+	}
+
+	rec = serve(http.MethodPost, "/admin/access", office, url.Values{"allow": {" 192.0.2.0/24, 10.8.0.0/16 \r\n"}, "deny": {"10.8.9.0/24"}}, session)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("save rules: %d", rec.Code)
+	}
+	if s, _ := queries.GetSettings(context.Background()); s.AdminIpAllow != "192.0.2.0/24\n10.8.0.0/16" || s.AdminIpDeny != "10.8.9.0/24" {
+		t.Errorf("saved rules = %q / %q", s.AdminIpAllow, s.AdminIpDeny)
+	}
+
+	for remote, want := range map[string]int{office: http.StatusOK, vpn: http.StatusOK, "10.8.9.4": http.StatusForbidden, elsewhere: http.StatusForbidden} {
+		if rec := serve(http.MethodGet, "/admin/login", remote, nil); rec.Code != want {
+			t.Errorf("login page from %s: %d, want %d", remote, rec.Code, want)
+		}
+	}
+	if rec := serve(http.MethodGet, "/admin/access", elsewhere, nil, session); rec.Code != http.StatusForbidden {
+		t.Errorf("signed-in admin from outside: %d, want 403", rec.Code)
+	}
+
+	// The bypass token admits the browser and allows saving rules that do
+	// not include its own address
+	rec = serve(http.MethodGet, "/admin/login?admin_bypass="+token, elsewhere, nil)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/login" {
+		t.Fatalf("bypass: %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	bypass := rec.Result().Cookies()[0]
+	rec = serve(http.MethodGet, "/admin/access", elsewhere, nil, session, bypass)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "emergency bypass token") {
+		t.Fatalf("access page with bypass: %d", rec.Code)
+	}
+	if rec := serve(http.MethodPost, "/admin/access", elsewhere, url.Values{"allow": {"10.8.0.0/16"}}, session, bypass); rec.Code != http.StatusSeeOther {
+		t.Fatalf("save with bypass: %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/admin/login", office, nil); rec.Code != http.StatusForbidden {
+		t.Errorf("office after rule change: %d, want 403", rec.Code)
+	}
+}
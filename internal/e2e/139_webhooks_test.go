@@ -0,0 +1,189 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestWebhooks_E2E registers an endpoint under Admin > Webhooks, then
+// publishes and trashes a product and checks the endpoint receives signed
+// content.published and content.deleted events, and that a paused endpoint
+// receives nothing.
+func TestWebhooks_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type delivery struct {
+		header http.Header
+		body   []byte
+	}
+	received := make(chan delivery, 8)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- delivery{r.Header.Clone(), body}
+	}))
+	defer endpoint.Close()
+
+	webhookSvc := services.NewWebhooks(queries, testLogger)
+	webhookSvc.Start(ctx)
+	adminHandlers.SetWebhooks(webhookSvc)
+	t.Cleanup(func() { adminHandlers.SetWebhooks(nil) })
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	adminHandlers.SetWorkflow(services.NewWorkflow(queries, testLogger, nil, nil, "http://localhost"))
+	adminHandlers.SetPublishChecker(services.NewPublishChecker(queries, t.TempDir(), []string{"admin"}))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	products := adminHandlers.NewProductsHandler(queries, testLogger, nil, services.NewCache())
+	adminGroup.PATCH("/products/:id/inline", products.InlineUpdate)
+	adminGroup.DELETE("/products/:id", products.Delete)
+	hooks := adminHandlers.NewWebhooksHandler(queries, testLogger, webhookSvc)
+	webhooksGroup := adminGroup.Group("/webhooks", customMiddleware.RequireRole("admin"))
+	webhooksGroup.GET("", hooks.List)
+	webhooksGroup.POST("", hooks.Create)
+	webhooksGroup.POST("/:id/toggle", hooks.Toggle)
+	webhooksGroup.POST("/:id/test", hooks.Test)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	serve := func(method, path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	next := func(t *testing.T) (delivery, services.WebhookEvent) {
+		t.Helper()
+		select {
+		case d := <-received:
+			var event services.WebhookEvent
+			if err := json.Unmarshal(d.body, &event); err != nil {
+				t.Fatalf("payload: %v\n%s", err, d.body)
+			}
+			return d, event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no webhook delivered")
+		}
+		return delivery{}, services.WebhookEvent{}
+	}
+
+	if rec := serve(http.MethodPost, "/admin/webhooks", url.Values{"url": {"ftp://example.com"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("invalid URL: %d", rec.Code)
+	}
+	if rec := serve(http.MethodPost, "/admin/webhooks", url.Values{"url": {endpoint.URL}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("add webhook: %d", rec.Code)
+	}
+	list, _ := queries.ListWebhooks(ctx)
+	if len(list) != 1 || list[0].Url != endpoint.URL || list[0].Events != "" || !strings.HasPrefix(list[0].Secret, "whsec_") {
+		t.Fatalf("webhooks after adding: %+v", list)
+	}
+	hook := list[0]
+	if body := serve(http.MethodGet, "/admin/webhooks", nil).Body.String(); !strings.Contains(body, endpoint.URL) || !strings.Contains(body, hook.Secret) {
+		t.Error("webhook list does not show the endpoint and its secret")
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	product, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway", Description: "d", CategoryID: cat.ID, Status: "draft"})
+
+	t.Run("publish sends a signed content.published", func(t *testing.T) {
+		if rec := serve(http.MethodPatch, fmt.Sprintf("/admin/products/%d/inline", product.ID), url.Values{"published": {"1"}}); rec.Code != http.StatusOK {
+			t.Fatalf("publish: %d", rec.Code)
+		}
+		d, event := next(t)
+		if event.Type != services.WebhookContentPublished || event.Content == nil || event.Content.Type != "product" || event.Content.ID != product.ID {
+			t.Fatalf("event %+v, want content.published for product %d", event, product.ID)
+		}
+		if d.header.Get("X-Bluejay-Event") != services.WebhookContentPublished || d.header.Get("X-Bluejay-Delivery") != event.ID {
+			t.Errorf("headers %v", d.header)
+		}
+		ts, _ := strconv.ParseInt(d.header.Get("X-Bluejay-Timestamp"), 10, 64)
+		if got := d.header.Get("X-Bluejay-Signature"); got != services.SignWebhook(hook.Secret, ts, d.body) {
+			t.Errorf("signature %q does not match the body", got)
+		}
+	})
+
+	t.Run("trash sends content.deleted", func(t *testing.T) {
+		if rec := serve(http.MethodDelete, fmt.Sprintf("/admin/products/%d", product.ID), nil); rec.Code >= 400 {
+			t.Fatalf("trash: %d", rec.Code)
+		}
+		_, event := next(t)
+		if event.Type != services.WebhookContentDeleted || event.Action != "trashed" || event.Content.ID != product.ID {
+			t.Fatalf("event %+v, want content.deleted (trashed)", event)
+		}
+		// The delivery is recorded after the endpoint answers
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			got, _ := queries.GetWebhook(ctx, hook.ID)
+			if got.LastStatus == http.StatusOK && got.LastDeliveredAt.Valid {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("delivery not recorded: %+v", got)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("test button pings", func(t *testing.T) {
+		if rec := serve(http.MethodPost, fmt.Sprintf("/admin/webhooks/%d/test", hook.ID), nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("test: %d", rec.Code)
+		}
+		if _, event := next(t); event.Type != services.WebhookPing || event.Content != nil {
+			t.Errorf("event %+v, want ping", event)
+		}
+	})
+
+	t.Run("paused endpoint receives nothing", func(t *testing.T) {
+		if rec := serve(http.MethodPost, fmt.Sprintf("/admin/webhooks/%d/toggle", hook.ID), nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("pause: %d", rec.Code)
+		}
+		if got, _ := queries.GetWebhook(ctx, hook.ID); got.IsActive {
+			t.Fatal("webhook still active after pausing")
+		}
+		// Publishing another product is delivered to active endpoints only;
+		// a second, active endpoint proves the event was dispatched
+		other := make(chan struct{}, 1)
+		active := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { other <- struct{}{} }))
+		defer active.Close()
+		queries.CreateWebhook(ctx, sqlc.CreateWebhookParams{Url: active.URL, Secret: "s", Events: services.WebhookContentPublished})
+		second, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-2", Slug: "gw-2", Name: "Core Gateway", Description: "d", CategoryID: cat.ID, Status: "draft"})
+		serve(http.MethodPatch, fmt.Sprintf("/admin/products/%d/inline", second.ID), url.Values{"published": {"1"}})
+		select {
+		case <-other:
+		case <-time.After(5 * time.Second):
+			t.Fatal("active endpoint received nothing")
+		}
+		select {
+		case d := <-received:
+			t.Errorf("paused endpoint received %s", d.body)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
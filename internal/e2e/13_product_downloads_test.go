@@ -67,6 +67,7 @@ func TestProductDownloads_AddDownload(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/products/%d/downloads", prod.ID), strings.NewReader(body.String()))
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -103,6 +104,7 @@ func TestProductDownloads_DeleteDownload(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/products/%d/downloads/%d", prod.ID, dl.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -223,6 +225,7 @@ func TestProductDownloadsEditForm_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/products/%d/downloads?edit=%d", product.ID, dl.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -266,6 +269,7 @@ func TestProductDownloadsUpdate_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
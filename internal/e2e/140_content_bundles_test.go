@@ -0,0 +1,121 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestContentBundles_E2E exports a product from a staging site under
+// Admin > Tools, imports the bundle on a production site that has an older
+// version of the product, chooses to overwrite it, and checks the product
+// and the activity log.
+func TestContentBundles_E2E(t *testing.T) {
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	// site builds an admin app with the tools routes on its own database
+	site := func(t *testing.T) (*echo.Echo, *sqlc.Queries, *http.Cookie) {
+		db, queries, cleanup := testutil.SetupTestDB(t)
+		t.Cleanup(cleanup)
+		e := echo.New()
+		e.Renderer = templates.NewRenderer("templates")
+		e.Use(customMiddleware.SessionMiddleware())
+		authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+		e.POST("/admin/login", authHandler.LoginSubmit)
+		h := adminHandlers.NewContentBundlesHandler(services.NewContentBundles(db, t.TempDir(), t.TempDir()), testLogger, services.NewCache())
+		tools := e.Group("/admin/tools", customMiddleware.RequireAuth(), customMiddleware.RequireRole("admin"))
+		tools.GET("/export", h.Export)
+		tools.POST("/export", h.ExportDownload)
+		tools.GET("/import", h.Import)
+		tools.POST("/import", h.ImportPreview)
+		tools.POST("/import/apply", h.ImportApply)
+		return e, queries, loginTabsAdmin(t, e, queries)
+	}
+	serve := func(e *echo.Echo, cookie *http.Cookie, req *http.Request) *httptest.ResponseRecorder {
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	postForm := func(e *echo.Echo, cookie *http.Cookie, path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		return serve(e, cookie, req)
+	}
+
+	staging, stagingQueries, stagingCookie := site(t)
+	production, prodQueries, prodCookie := site(t)
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(prodQueries, testLogger))
+
+	cat, _ := stagingQueries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	product, _ := stagingQueries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway v2", Description: "new copy", CategoryID: cat.ID, Status: "published"})
+	prodCat, _ := prodQueries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	prodQueries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway", Description: "old copy", CategoryID: prodCat.ID, Status: "published"})
+
+	// Export on staging
+	page := serve(staging, stagingCookie, httptest.NewRequest(http.MethodGet, "/admin/tools/export", nil)).Body.String()
+	if !strings.Contains(page, fmt.Sprintf(`value="product:%d"`, product.ID)) {
+		t.Fatal("export page does not offer the product")
+	}
+	rec := postForm(staging, stagingCookie, "/admin/tools/export", url.Values{"items": {fmt.Sprintf("product:%d", product.ID)}})
+	if rec.Code != http.StatusOK || rec.Header().Get(echo.HeaderContentType) != "application/zip" || !strings.Contains(rec.Header().Get(echo.HeaderContentDisposition), "content-") {
+		t.Fatalf("export: %d %v", rec.Code, rec.Header())
+	}
+	bundle := rec.Body.Bytes()
+
+	// Preview on production
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "staging.zip")
+	fw.Write(bundle)
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/admin/tools/import", &body)
+	req.Header.Set(echo.HeaderContentType, mw.FormDataContentType())
+	preview := serve(production, prodCookie, req).Body.String()
+	resolution := regexp.MustCompile(`name="(resolution_\d+)"`).FindStringSubmatch(preview)
+	token := regexp.MustCompile(`name="token" value="([0-9a-f]+)"`).FindStringSubmatch(preview)
+	if resolution == nil || token == nil || !strings.Contains(preview, "Edge Gateway v2") {
+		t.Fatalf("preview should offer a choice for the existing product:\n%s", preview)
+	}
+	if p, _ := prodQueries.GetProductBySlug(ctx, "gw-1"); p.Name != "Edge Gateway" {
+		t.Fatal("preview changed the product")
+	}
+
+	// Apply with overwrite
+	apply := url.Values{"token": {token[1]}, "file_name": {"staging.zip"}, resolution[1]: {services.BundleOverwrite}}
+	if rec := postForm(production, prodCookie, "/admin/tools/import/apply", apply); rec.Code != http.StatusSeeOther {
+		t.Fatalf("apply: %d %s", rec.Code, rec.Body.String())
+	}
+	got, _ := prodQueries.GetProductBySlug(ctx, "gw-1")
+	if got.Name != "Edge Gateway v2" || got.Description != "new copy" || got.CategoryID != prodCat.ID {
+		t.Errorf("product after import: %+v", got)
+	}
+	if n, _ := prodQueries.CountActivityLogs(ctx, sqlc.CountActivityLogsParams{FilterSearch: "Overwrote product 'Edge Gateway v2' from staging.zip"}); n != 1 {
+		t.Errorf("%d activity entries for the overwrite, want 1", n)
+	}
+
+	// The staged upload is gone once applied
+	if rec := postForm(production, prodCookie, "/admin/tools/import/apply", apply); rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/tools/import" {
+		t.Errorf("second apply: %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if products, _ := prodQueries.CountProducts(ctx); products != 1 {
+		t.Errorf("%d products after applying twice, want 1", products)
+	}
+}
@@ -0,0 +1,124 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestEditorAttachments_E2E uploads an image the way the Trix editor does,
+// uses it in a blog post, and checks that permanently deleting the post
+// from the trash removes the attachment from the media library.
+func TestEditorAttachments_E2E(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uploadDir := t.TempDir()
+	attachments := services.NewEditorAttachments(db, queries, uploadDir, testLogger)
+	attachments.Start(ctx)
+	adminHandlers.SetEditorAttachments(attachments)
+	defer adminHandlers.SetEditorAttachments(nil)
+
+	e := echo.New()
+	h := adminHandlers.NewEditorAttachmentsHandler(attachments, testLogger)
+	e.POST("/admin/editor/attachments", h.Upload)
+	trash := adminHandlers.NewTrashHandler(queries, testLogger, services.NewCache(), services.NewTrashPurge(queries, testLogger, 30))
+	e.POST("/admin/trash/:type/:id/delete", trash.Delete)
+
+	upload := func(name string, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("file", name)
+		fw.Write(data)
+		mw.Close()
+		req := httptest.NewRequest(http.MethodPost, "/admin/editor/attachments", &body)
+		req.Header.Set(echo.HeaderContentType, mw.FormDataContentType())
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 64, 32)))
+	rec := upload("chart.png", img.Bytes())
+	var got struct {
+		URL    string `json:"url"`
+		Href   string `json:"href"`
+		ID     int64  `json:"id"`
+		Width  int64  `json:"width"`
+		Height int64  `json:"height"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); rec.Code != http.StatusOK || err != nil {
+		t.Fatalf("upload: %d %s", rec.Code, rec.Body.String())
+	}
+	if got.URL == "" || got.Href != got.URL || got.Width != 64 || got.Height != 32 {
+		t.Errorf("upload response %+v", got)
+	}
+	file := filepath.Join(uploadDir, filepath.FromSlash(got.URL[len("/uploads/"):]))
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("uploaded file not on disk: %v", err)
+	}
+
+	if rec := upload("notes.txt", []byte("hello")); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("text upload: %d %s, want 422", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/editor/attachments", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("upload without a file: %d, want 400", rec.Code)
+	}
+
+	// A trashed post that uses the image, uploaded two days ago
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Ada", Slug: "ada", Title: "Editor"})
+	post, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Chart", Slug: "chart", Excerpt: "e", Body: fmt.Sprintf(`<figure><img src="%s"></figure>`, got.URL),
+		CategoryID: cat.ID, AuthorID: author.ID, Status: "draft",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Exec(`UPDATE blog_posts SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, post.ID)
+	db.Exec(`UPDATE editor_attachments SET created_at = datetime('now', '-2 days')`)
+
+	// Still used while the post is in the trash
+	if n, err := attachments.PruneOrphans(ctx, time.Now()); err != nil || n != 0 {
+		t.Fatalf("PruneOrphans with the post in the trash = %d, %v", n, err)
+	}
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/trash/blog_post/%d/delete", post.ID), nil))
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("delete: %d %s", rec.Code, rec.Body.String())
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("attachment still on disk after deleting the post")
+		}
+	}
+	if _, err := queries.GetMediaFile(ctx, got.ID); err == nil {
+		t.Error("attachment still in the media library")
+	}
+}
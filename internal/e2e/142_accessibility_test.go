@@ -0,0 +1,150 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestAccessibility_E2E checks that heroes, product images and media uploads
+// are refused without alt text, and that the report under
+// /admin/accessibility lists what is left to fix until it is fixed.
+func TestAccessibility_E2E(t *testing.T) {
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	e.POST("/admin/login", adminHandlers.NewAuthHandler(queries, testLogger).LoginSubmit)
+	admin := e.Group("/admin", customMiddleware.RequireAuth())
+	admin.GET("/accessibility", adminHandlers.NewAccessibilityHandler(services.NewAccessibility(queries), testLogger).Report)
+	admin.POST("/homepage/heroes", adminHandlers.NewHomepageHandler(queries, testLogger).HeroCreate)
+	details := adminHandlers.NewProductDetailsHandler(queries, testLogger, services.NewUploadService(t.TempDir()), nil)
+	admin.POST("/products/:id/images", details.AddImage)
+	admin.POST("/media/upload", adminHandlers.NewMediaHandler(queries, testLogger, t.TempDir()).Upload)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	postForm := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		return serve(req)
+	}
+	postFile := func(path, field, name string, fields map[string]string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile(field, name)
+		fw.Write([]byte("\x89PNG\r\n\x1a\nnot really an image"))
+		for k, v := range fields {
+			mw.WriteField(k, v)
+		}
+		mw.Close()
+		req := httptest.NewRequest(http.MethodPost, path, &body)
+		req.Header.Set(echo.HeaderContentType, mw.FormDataContentType())
+		req.Header.Set("HX-Request", "true")
+		return serve(req)
+	}
+	report := func() string {
+		rec := serve(httptest.NewRequest(http.MethodGet, "/admin/accessibility", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("report: %d %s", rec.Code, rec.Body.String())
+		}
+		return rec.Body.String()
+	}
+
+	// A hero background without alt text shows the form again
+	hero := url.Values{
+		"headline": {"Built for the plant floor"}, "subheadline": {"s"},
+		"primary_cta_text": {"Go"}, "primary_cta_url": {"/"},
+		"background_image": {"/uploads/hero.jpg"}, "is_active": {"on"},
+	}
+	rec := postForm("/admin/homepage/heroes", hero)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "Describe the background image in its alt text") ||
+		!strings.Contains(rec.Body.String(), `value="Built for the plant floor"`) {
+		t.Fatalf("hero without alt text: %d", rec.Code)
+	}
+	if heroes, _ := queries.ListAllHeroes(ctx); len(heroes) != 0 {
+		t.Fatalf("hero saved without alt text: %+v", heroes)
+	}
+	hero.Set("background_image_alt", "Technicians at a packaging line")
+	if rec := postForm("/admin/homepage/heroes", hero); rec.Code != http.StatusSeeOther {
+		t.Fatalf("hero with alt text: %d %s", rec.Code, rec.Body.String())
+	}
+	heroes, _ := queries.ListAllHeroes(ctx)
+	if len(heroes) != 1 || heroes[0].BackgroundImageAlt != "Technicians at a packaging line" {
+		t.Fatalf("heroes = %+v", heroes)
+	}
+
+	// Product images and media uploads need alt text too
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Scanners", Slug: "scanners", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "SC-1", Slug: "sc-1", Name: "Handheld Scanner", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	imagesURL := fmt.Sprintf("/admin/products/%d/images", prod.ID)
+	rec = postFile(imagesURL, "image", "front.png", map[string]string{"alt_text": " "})
+	if !strings.Contains(rec.Header().Get("HX-Trigger"), "Describe the image in its alt text") {
+		t.Errorf("product image without alt text: %d %v", rec.Code, rec.Header())
+	}
+	if images, _ := queries.ListProductImages(ctx, prod.ID); len(images) != 0 {
+		t.Fatalf("product image saved without alt text: %+v", images)
+	}
+	if rec := postFile(imagesURL, "image", "front.png", map[string]string{"alt_text": "Scanner, front"}); rec.Code != http.StatusOK {
+		t.Fatalf("product image with alt text: %d %s", rec.Code, rec.Body.String())
+	}
+	rec = postFile("/admin/media/upload", "files", "logo.png", nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "logo.png") {
+		t.Errorf("media upload without alt text: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := postFile("/admin/media/upload", "files", "logo.png", map[string]string{"alt_text": "Company logo"}); rec.Code != http.StatusOK {
+		t.Fatalf("media upload with alt text: %d %s", rec.Code, rec.Body.String())
+	}
+
+	page := report()
+	if !strings.Contains(page, "Every image has alt text.") || !strings.Contains(page, "All headings are in order.") {
+		t.Fatal("report lists problems for content with alt text")
+	}
+
+	// Content saved before alt text was required shows up until it is fixed
+	queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: prod.ID, ImagePath: "/uploads/products/back.png"})
+	blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Ada", Slug: "ada", Title: "Editor"})
+	post, _ := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Scanning tips", Slug: "scanning-tips", Excerpt: "e",
+		Body:       `<h2>Setup</h2><h4>Pairing</h4><img src="/uploads/media/pairing.png">`,
+		CategoryID: blogCat.ID, AuthorID: author.ID, Status: "draft",
+	})
+	page = report()
+	for _, want := range []string{
+		"/uploads/products/back.png", fmt.Sprintf(`href="/admin/products/%d/images"`, prod.ID),
+		"/uploads/media/pairing.png", "h4 follows h2", fmt.Sprintf(`href="/admin/blog/posts/%d/edit"`, post.ID),
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("report does not contain %q", want)
+		}
+	}
+	if strings.Count(page, `class="alt-issue`) != 2 || strings.Count(page, `class="heading-issue`) != 1 {
+		t.Errorf("report lists %d images and %d heading problems, want 2 and 1",
+			strings.Count(page, `class="alt-issue`), strings.Count(page, `class="heading-issue`))
+	}
+}
@@ -0,0 +1,102 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/jobs"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestLinkCheck_E2E runs the link check from /admin/link-check and checks
+// that the report lists the CTA pointing at a missing page with its fix
+// shortcuts, and that the checker's requests stay out of the 404 report.
+func TestLinkCheck_E2E(t *testing.T) {
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	db.Exec(`DELETE FROM page_sections`)
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.HTTPErrorHandler = customMiddleware.NotFoundTracker(queries, testLogger, e.DefaultHTTPErrorHandler)
+	e.Use(customMiddleware.SessionMiddleware())
+	e.GET("/about", func(c echo.Context) error { return c.String(http.StatusOK, "About us") })
+	e.POST("/admin/login", adminHandlers.NewAuthHandler(queries, testLogger).LoginSubmit)
+
+	checker := services.NewLinkChecker(db, queries, e, "https://www.example.com", testLogger)
+	scheduler := jobs.New(queries, testLogger, jobs.Config{})
+	scheduler.Add(jobs.Job{Name: "link-check", Description: "Find broken links", Run: func(ctx context.Context) error {
+		_, err := checker.Run(ctx)
+		return err
+	}})
+	handler := adminHandlers.NewLinkCheckHandler(checker, scheduler, testLogger)
+	admin := e.Group("/admin", customMiddleware.RequireAuth())
+	admin.GET("/link-check", handler.Report)
+	admin.POST("/link-check/run", handler.Run)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	hero, _ := queries.CreateHero(ctx, sqlc.CreateHeroParams{
+		Headline: "Welcome", PrimaryCtaText: "Learn more", PrimaryCtaUrl: "/about",
+		SecondaryCtaText: sql.NullString{String: "Brochure", Valid: true},
+		SecondaryCtaUrl:  sql.NullString{String: "https://www.example.com/brochure", Valid: true}, IsActive: 1,
+	})
+
+	page := serve(http.MethodGet, "/admin/link-check").Body.String()
+	if !strings.Contains(page, "Not checked yet.") || !strings.Contains(page, "No broken links found.") {
+		t.Fatal("report before the first run")
+	}
+
+	if rec := serve(http.MethodPost, "/admin/link-check/run"); rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/link-check" {
+		t.Fatalf("run: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		if s := scheduler.Jobs()[0]; !s.Running && !s.LastStart.IsZero() {
+			if s.LastError != "" {
+				t.Fatalf("link check failed: %s", s.LastError)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("link check did not finish")
+		}
+	}
+
+	page = serve(http.MethodGet, "/admin/link-check").Body.String()
+	for _, want := range []string{
+		"https://www.example.com/brochure", "Page not found", "Secondary button",
+		fmt.Sprintf(`href="/admin/homepage/heroes/%d/edit"`, hero.ID), `href="/admin/redirects?source=%2Fbrochure"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("report does not contain %q", want)
+		}
+	}
+	if n := strings.Count(page, `class="broken-link`); n != 1 {
+		t.Errorf("report lists %d broken links, want 1", n)
+	}
+	if paths, _ := queries.ListNotFoundPaths(ctx, 10); len(paths) != 0 {
+		t.Errorf("link checker requests recorded as 404s: %+v", paths)
+	}
+}
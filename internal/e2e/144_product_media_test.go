@@ -0,0 +1,141 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestProductMedia_E2E adds a YouTube video, an uploaded video and a 3D/CAD
+// file to a product gallery, checks the admin validation messages, and that
+// the public product page renders each with its own thumbnail and data.
+func TestProductMedia_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "MED-1", Slug: "flow-sensor", Name: "Flow Sensor", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	imagesURL := fmt.Sprintf("/admin/products/%d/images", prod.ID)
+
+	add := func(fields map[string]string, files map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := &strings.Builder{}
+		w := multipart.NewWriter(body)
+		for k, v := range fields {
+			w.WriteField(k, v)
+		}
+		for field, name := range files {
+			part, _ := w.CreateFormFile(field, name)
+			part.Write([]byte("file content"))
+		}
+		w.Close()
+		req := httptest.NewRequest(http.MethodPost, imagesURL, strings.NewReader(body.String()))
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Rejected: a link that is not a YouTube or Vimeo video, a video without
+	// a title, and a 3D/CAD entry without its file
+	for _, tc := range []struct {
+		fields map[string]string
+		files  map[string]string
+		want   string
+	}{
+		{map[string]string{"media_type": "video", "video_url": "https://example.com/watch", "alt_text": "Demo"}, nil, "Enter a YouTube or Vimeo video link"},
+		{map[string]string{"media_type": "video", "video_url": "https://youtu.be/dQw4w9WgXcQ"}, nil, "Give the video a title"},
+		{map[string]string{"media_type": "model", "alt_text": "Housing"}, nil, "Choose the 3D/CAD file"},
+	} {
+		rec := add(tc.fields, tc.files)
+		if !strings.Contains(rec.Header().Get("HX-Trigger"), tc.want) {
+			t.Errorf("%v: HX-Trigger = %q, want %q", tc.fields, rec.Header().Get("HX-Trigger"), tc.want)
+		}
+	}
+	if rec := add(map[string]string{"media_type": "model", "alt_text": "Installer"}, map[string]string{"model": "setup.exe"}); rec.Code != http.StatusBadRequest {
+		t.Errorf("3D/CAD upload of an executable: %d, want 400", rec.Code)
+	}
+	if media, _ := queries.ListProductImages(ctx, prod.ID); len(media) != 0 {
+		t.Fatalf("rejected media saved: %+v", media)
+	}
+
+	// Accepted: an image, a YouTube link, an uploaded video with a poster and a STEP file
+	for _, tc := range []struct {
+		fields map[string]string
+		files  map[string]string
+	}{
+		{map[string]string{"alt_text": "Sensor, front", "display_order": "1"}, map[string]string{"image": "front.png"}},
+		{map[string]string{"media_type": "video", "video_url": "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "alt_text": "Installation walkthrough", "display_order": "2"}, nil},
+		{map[string]string{"media_type": "video", "alt_text": "Calibration", "display_order": "3"}, map[string]string{"video": "calibration.mp4", "image": "poster.jpg"}},
+		{map[string]string{"media_type": "model", "alt_text": "Sensor housing", "display_order": "4", "is_thumbnail": "1"}, map[string]string{"model": "housing.step"}},
+	} {
+		if rec := add(tc.fields, tc.files); rec.Code != http.StatusOK || rec.Header().Get("HX-Trigger") != "" {
+			t.Fatalf("%v: %d %s", tc.fields, rec.Code, rec.Header().Get("HX-Trigger"))
+		}
+	}
+	media, _ := queries.ListProductImages(ctx, prod.ID)
+	if len(media) != 4 {
+		t.Fatalf("gallery = %+v", media)
+	}
+	if m := media[1]; m.MediaType != "video" || m.MediaUrl != "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" || m.ImagePath != "" {
+		t.Errorf("YouTube video = %+v", m)
+	}
+	if m := media[2]; m.MediaType != "video" || !strings.HasPrefix(m.MediaUrl, "/uploads/products/") || !strings.HasPrefix(m.ImagePath, "/uploads/products/") {
+		t.Errorf("uploaded video = %+v", m)
+	}
+	if m := media[3]; m.MediaType != "model" || !strings.HasPrefix(m.MediaUrl, "/uploads/models/") || m.IsThumbnail {
+		t.Errorf("3D/CAD file = %+v", m)
+	}
+
+	// setupApp renders a stub page, so the product page is served by the
+	// real templates here
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	site.Use(customMiddleware.SecurityHeaders())
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), services.NewCache())
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/sensors/flow-sensor", nil)
+	rec := httptest.NewRecorder()
+	site.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("product page: %d", rec.Code)
+	}
+	page := rec.Body.String()
+	for _, want := range []string{
+		`data-kind="video" data-src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"`,
+		`data-title="Installation walkthrough"`,
+		fmt.Sprintf(`data-kind="video" data-src="%s" data-poster="%s"`, media[2].MediaUrl, media[2].ImagePath),
+		fmt.Sprintf(`data-kind="model" data-src="%s"`, media[3].MediaUrl),
+		`aria-label="3D/CAD file: Sensor housing"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("product page does not contain %q", want)
+		}
+	}
+	if csp := rec.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "https://www.youtube-nocookie.com") {
+		t.Errorf("CSP does not allow the YouTube player: %s", csp)
+	}
+}
@@ -0,0 +1,168 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestProductDownloadVersions_E2E uploads a datasheet and two revisions of
+// it, checks that the product page links the newest one and lists the older
+// ones, that each version counts its own downloads, and that deleting a
+// version keeps the others chained.
+func TestProductDownloadVersions_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "VER-1", Slug: "level-sensor", Name: "Level Sensor", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	downloadsURL := fmt.Sprintf("/admin/products/%d/downloads", prod.ID)
+
+	add := func(fields map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := &strings.Builder{}
+		w := multipart.NewWriter(body)
+		part, _ := w.CreateFormFile("file", "datasheet.pdf")
+		part.Write([]byte("pdf"))
+		for k, v := range fields {
+			w.WriteField(k, v)
+		}
+		w.Close()
+		req := httptest.NewRequest(http.MethodPost, downloadsURL, strings.NewReader(body.String()))
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("add %v: %d", fields, rec.Code)
+		}
+		return rec
+	}
+	latest := func() sqlc.ProductDownload {
+		t.Helper()
+		downloads, _ := queries.ListProductDownloads(ctx, prod.ID)
+		return downloads[len(downloads)-1]
+	}
+
+	add(map[string]string{"title": "Datasheet", "file_type": "pdf", "version": "1.0", "display_order": "2"})
+	v1 := latest()
+	add(map[string]string{"title": "Installation Guide", "file_type": "pdf", "display_order": "1"})
+	add(map[string]string{"title": "Datasheet", "file_type": "pdf", "version": "1.1", "changelog": "Corrected the wiring diagram", "supersedes_id": fmt.Sprint(v1.ID)})
+	v2 := latest()
+	if v2.SupersedesID.Int64 != v1.ID || v2.DisplayOrder != 2 || v2.Changelog != "Corrected the wiring diagram" {
+		t.Fatalf("new version = %+v, want it to supersede v1 in its place", v2)
+	}
+
+	// Only the current version of a file of this product can be superseded
+	rec := add(map[string]string{"title": "Datasheet", "version": "1.2", "supersedes_id": fmt.Sprint(v1.ID)})
+	if !strings.Contains(rec.Header().Get("HX-Trigger"), "current version") {
+		t.Errorf("superseding an older version: HX-Trigger = %q", rec.Header().Get("HX-Trigger"))
+	}
+	if downloads, _ := queries.ListProductDownloads(ctx, prod.ID); len(downloads) != 3 {
+		t.Fatalf("rejected version saved: %d downloads", len(downloads))
+	}
+	add(map[string]string{"title": "Datasheet", "file_type": "pdf", "version": "2.0", "changelog": "New housing", "supersedes_id": fmt.Sprint(v2.ID)})
+	v3 := latest()
+
+	// The admin list shows the chain with per-version counts and the add form
+	// offers only current versions
+	req := httptest.NewRequest(http.MethodGet, downloadsURL+fmt.Sprintf("?supersedes=%d", v3.ID), nil)
+	req.Header.Set("HX-Request", "true")
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	admin := rec.Body.String()
+	for _, want := range []string{"Current &middot; 3 versions", "Older version", fmt.Sprintf(`<option value="%d" selected>Datasheet (v2.0)</option>`, v3.ID)} {
+		if !strings.Contains(admin, want) {
+			t.Errorf("admin list does not contain %q", want)
+		}
+	}
+	if strings.Contains(admin, fmt.Sprintf(`<option value="%d"`, v1.ID)) {
+		t.Error("an older version is offered in the add form")
+	}
+
+	// The product page links the newest version and lists the older ones
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), services.NewCache())
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+	rec = httptest.NewRecorder()
+	site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/sensors/level-sensor", nil))
+	page := rec.Body.String()
+	for _, want := range []string{
+		fmt.Sprintf(`href="/product-downloads/%d"`, v3.ID), "New housing", "Older versions (2)",
+		fmt.Sprintf(`href="/product-downloads/%d" class="font-bold underline hover:text-[#0066CC]" download>v1.1</a>`, v2.ID),
+		"Corrected the wiring diagram",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("product page does not contain %q", want)
+		}
+	}
+	if strings.Index(page, "Installation Guide") > strings.Index(page, "New housing") {
+		t.Error("the new version did not keep the datasheet's place after the installation guide")
+	}
+
+	// Each version counts its own downloads; crawlers are not counted
+	download := func(id int64, ua string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/product-downloads/%d", id), nil)
+		req.Header.Set("User-Agent", ua)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	browser := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36"
+	for range 2 {
+		download(v3.ID, browser)
+	}
+	if rec := download(v1.ID, browser); rec.Code != http.StatusFound || rec.Header().Get("Location") != v1.FilePath {
+		t.Errorf("download v1: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	download(v1.ID, "Googlebot/2.1")
+	counts := map[int64]int64{}
+	downloads, _ := queries.ListProductDownloads(ctx, prod.ID)
+	for _, d := range downloads {
+		counts[d.ID] = d.DownloadCount
+	}
+	if counts[v1.ID] != 1 || counts[v2.ID] != 0 || counts[v3.ID] != 2 {
+		t.Errorf("download counts = %v", counts)
+	}
+	if rec := download(9999, browser); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown download: %d", rec.Code)
+	}
+
+	// Deleting the middle version links the newest to the oldest
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", downloadsURL, v2.ID), nil)
+	req.Header.Set("HX-Request", "true")
+	req.AddCookie(cookie)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	if d, err := queries.GetProductDownload(ctx, v3.ID); err != nil || d.SupersedesID.Int64 != v1.ID {
+		t.Errorf("after deleting v1.1, v2.0 supersedes %+v (%v), want v1.0", d.SupersedesID, err)
+	}
+
+	// Downloads of trashed products are not served
+	queries.TrashProduct(ctx, prod.ID)
+	if rec := download(v3.ID, browser); rec.Code != http.StatusNotFound {
+		t.Errorf("download of a trashed product: %d", rec.Code)
+	}
+}
@@ -0,0 +1,215 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestGatedProductDownloads_E2E gates a product manual, checks that the
+// product page opens the lead form instead of linking the file, that the
+// file is only served with the signed link the form returns and not from
+// the public uploads, and that the submission shows up as a lead.
+func TestGatedProductDownloads_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "GATE-1", Slug: "level-sensor", Name: "Level Sensor", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	downloadsURL := fmt.Sprintf("/admin/products/%d/downloads", prod.ID)
+
+	admin := func(method, target string, fields map[string]string) string {
+		t.Helper()
+		var req *http.Request
+		if method == http.MethodPost {
+			body := &strings.Builder{}
+			w := multipart.NewWriter(body)
+			part, _ := w.CreateFormFile("file", strings.ReplaceAll(strings.ToLower(fields["title"]), " ", "-")+".pdf")
+			part.Write([]byte("pdf"))
+			for k, v := range fields {
+				w.WriteField(k, v)
+			}
+			w.Close()
+			req = httptest.NewRequest(method, target, strings.NewReader(body.String()))
+			req.Header.Set("Content-Type", w.FormDataContentType())
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: %d", method, target, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	admin(http.MethodPost, downloadsURL, map[string]string{"title": "Datasheet", "file_type": "pdf"})
+	list := admin(http.MethodPost, downloadsURL, map[string]string{"title": "Installation Manual", "file_type": "pdf", "version": "3", "is_gated": "1"})
+	downloads, _ := queries.ListProductDownloads(ctx, prod.ID)
+	if len(downloads) != 2 || downloads[0].IsGated != 0 || downloads[1].IsGated != 1 {
+		t.Fatalf("downloads = %+v, want the manual gated", downloads)
+	}
+	open, manual := downloads[0], downloads[1]
+	if strings.Count(list, ">Gated</span>") != 1 {
+		t.Error("the admin list does not badge the gated manual")
+	}
+	// A new version of a gated file starts out gated
+	if form := admin(http.MethodGet, fmt.Sprintf("%s?supersedes=%d", downloadsURL, manual.ID), nil); !strings.Contains(form, `name="is_gated" value="1" class="border-2 border-black" checked>`) {
+		t.Error("the add form does not gate a new version of the gated manual")
+	}
+
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), services.NewCache())
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+	files := publicHandlers.NewProductDownloadsHandler(queries, testLogger, testDownloadLinks, nil)
+	publicGroup.GET("/product-downloads/:id/form", files.LeadForm)
+	publicGroup.POST("/product-downloads/:id/lead", files.Lead)
+	whitepapers := publicHandlers.NewWhitepapersHandler(queries, testLogger, services.NewCache())
+	publicGroup.GET("/whitepapers/:slug", whitepapers.WhitepaperDetail)
+	browser := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36"
+	get := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("User-Agent", browser)
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, req)
+		return rec
+	}
+	// setupApp's app has the files the admin uploaded
+	download := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("User-Agent", browser)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The product page opens the form for the manual and links the datasheet
+	page := get("/products/sensors/level-sensor").Body.String()
+	for _, want := range []string{
+		fmt.Sprintf(`hx-get="/product-downloads/%d/form" hx-target="#download-lead-modal"`, manual.ID),
+		fmt.Sprintf(`href="/product-downloads/%d"`, open.ID),
+		`<div id="download-lead-modal"></div>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("product page does not contain %q", want)
+		}
+	}
+	if strings.Contains(page, fmt.Sprintf(`href="/product-downloads/%d"`, manual.ID)) || strings.Contains(page, manual.FilePath) {
+		t.Error("product page links the gated manual directly")
+	}
+
+	// Without a valid signed link the manual is not served
+	productDownloads := "/products/sensors/level-sensor#downloads"
+	for _, target := range []string{
+		fmt.Sprintf("/product-downloads/%d", manual.ID),
+		fmt.Sprintf("/product-downloads/%d?token=forged", manual.ID),
+		fmt.Sprintf("/product-downloads/%d?token=%s", manual.ID, url.QueryEscape(testDownloadLinks.Issue(open.ID))),
+	} {
+		if rec := download(target); rec.Code != http.StatusFound || rec.Header().Get("Location") != productDownloads {
+			t.Errorf("GET %s: %d %s, want a redirect to the product's downloads", target, rec.Code, rec.Header().Get("Location"))
+		}
+	}
+	// Only the datasheet is in the public uploads
+	if rec := download(open.FilePath); rec.Code != http.StatusOK || rec.Body.String() != "pdf" {
+		t.Errorf("GET %s: %d, want the datasheet", open.FilePath, rec.Code)
+	}
+	if rec := download(manual.FilePath); rec.Code != http.StatusNotFound {
+		t.Errorf("GET %s: %d, want the gated manual not found", manual.FilePath, rec.Code)
+	}
+	if rec := admin(http.MethodGet, fmt.Sprintf("%s/%d/file", downloadsURL, manual.ID), nil); rec != "pdf" {
+		t.Errorf("admin file of the gated manual = %q", rec)
+	}
+	if rec := get(fmt.Sprintf("/product-downloads/%d/form", open.ID)); rec.Code != http.StatusNotFound {
+		t.Errorf("form of an ungated file: %d", rec.Code)
+	}
+	if form := get(fmt.Sprintf("/product-downloads/%d/form", manual.ID)).Body.String(); !strings.Contains(form, fmt.Sprintf(`hx-post="/product-downloads/%d/lead"`, manual.ID)) || !strings.Contains(form, `name="company" required`) {
+		t.Errorf("lead form = %s", form)
+	}
+
+	lead := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/product-downloads/%d/lead", manual.ID), strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("User-Agent", browser)
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := lead(url.Values{"name": {"Jane Doe"}, "email": {"jane@acme.com"}}); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "required") {
+		t.Errorf("lead without company: %d %s", rec.Code, rec.Body.String())
+	}
+	rec := lead(url.Values{"name": {"Jane Doe"}, "email": {"Jane@Acme.com"}, "company": {"Acme"}, "marketing_consent": {"true"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("lead: %d", rec.Code)
+	}
+	m := regexp.MustCompile(`href="(/product-downloads/\d+\?token=[^"]+)"`).FindStringSubmatch(rec.Body.String())
+	if m == nil {
+		t.Fatalf("lead response has no download link: %s", rec.Body.String())
+	}
+	link := strings.ReplaceAll(m[1], "&amp;", "&")
+	if d, _ := queries.GetProductDownload(ctx, manual.ID); d.DownloadCount != 0 {
+		t.Errorf("the lead form counted a download: %d", d.DownloadCount)
+	}
+
+	// The signed link serves the manual and counts the download
+	if rec := download(link); rec.Code != http.StatusOK || rec.Body.String() != "pdf" ||
+		rec.Header().Get("Content-Disposition") != `attachment; filename=`+path.Base(manual.FilePath) {
+		t.Errorf("signed link: %d %s %q", rec.Code, rec.Header().Get("Content-Disposition"), rec.Body.String())
+	}
+	if d, _ := queries.GetProductDownload(ctx, manual.ID); d.DownloadCount != 1 {
+		t.Errorf("download count = %d, want 1", d.DownloadCount)
+	}
+
+	// The submission is a lead
+	leads, err := services.NewLeadService(queries).Leads(ctx)
+	if err != nil {
+		t.Fatalf("Leads: %v", err)
+	}
+	if len(leads) != 1 || leads[0].ProductFiles != 1 || leads[0].Submissions[0].Source != services.LeadSourceProductDownload ||
+		leads[0].Submissions[0].Detail != "Level Sensor: Installation Manual" {
+		t.Errorf("leads = %+v", leads)
+	}
+
+	// Ungating the manual links it directly again
+	admin(http.MethodPost, fmt.Sprintf("%s/%d", downloadsURL, manual.ID), map[string]string{"title": "Installation Manual", "file_type": "pdf", "version": "3"})
+	if rec := download(fmt.Sprintf("/product-downloads/%d", manual.ID)); rec.Code != http.StatusFound || rec.Header().Get("Location") != manual.FilePath {
+		t.Errorf("ungated manual: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	if rec := download(manual.FilePath); rec.Code != http.StatusOK || rec.Body.String() != "pdf" {
+		t.Errorf("GET %s after ungating: %d", manual.FilePath, rec.Code)
+	}
+
+	// The whitepaper form keeps the lead fields it now shares
+	topic, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "Topic", Slug: "topic", ColorHex: "#000000"})
+	queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+		Title: "Sensor Guide", Slug: "sensor-guide", Description: "d", TopicID: topic.ID,
+		PdfFilePath: "/x.pdf", PublishedDate: "2025-01-01", IsPublished: 1,
+	})
+	if page := get("/whitepapers/sensor-guide").Body.String(); !strings.Contains(page, `name="designation"`) || !strings.Contains(page, `hx-post="/whitepapers/sensor-guide/download"`) {
+		t.Error("whitepaper page lost its download form fields")
+	}
+}
@@ -0,0 +1,286 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// healthyDB is a database health check that always reports a working
+// database, so ReadOnlyFallback only records snapshots.
+type healthyDB struct{}
+
+func (healthyDB) Degraded() bool                  { return false }
+func (healthyDB) Verify(ctx context.Context) bool { return true }
+
+// TestCustomerAccounts_E2E registers a customer account with the emailed
+// confirmation link, downloads a whitepaper and a gated product file
+// without filling in the lead form, finds both on /account, signs out and
+// back in, and checks the admin Customers page.
+func TestCustomerAccounts_E2E(t *testing.T) {
+	e, db, queries, cleanup := setupAppDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+
+	topic, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "Topic", Slug: "topic", ColorHex: "#000000"})
+	queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+		Title: "Sensor Guide", Slug: "sensor-guide", Description: "d", TopicID: topic.ID,
+		PdfFilePath: "uploads/sensor-guide.pdf", PublishedDate: "2025-01-01", IsPublished: 1,
+	})
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "ACC-1", Slug: "level-sensor", Name: "Level Sensor", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	manual, err := queries.CreateProductDownload(ctx, sqlc.CreateProductDownloadParams{
+		ProductID: prod.ID, Title: "Installation Manual", FileType: "pdf", FilePath: "/uploads/manual.pdf",
+		Version: sql.NullString{String: "3", Valid: true}, IsGated: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateProductDownload: %v", err)
+	}
+
+	mailer := &newsletterMailer{sent: make(chan string, 2)}
+	accounts := services.NewCustomerAccounts(db, queries, testLogger, mailer, "https://example.com")
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	snapshots := services.NewCache()
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.ReadOnlyFallback(healthyDB{}, snapshots))
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Customer(accounts))
+	account := publicHandlers.NewAccountHandler(accounts, testDownloadLinks, testLogger)
+	publicGroup.GET("/account", account.Account)
+	publicGroup.GET("/account/login", account.LoginPage)
+	publicGroup.POST("/account/login", account.Login)
+	publicGroup.GET("/account/register", account.RegisterPage)
+	publicGroup.POST("/account/register", account.Register)
+	publicGroup.GET("/account/confirm", account.ConfirmPage)
+	publicGroup.POST("/account/confirm", account.Confirm)
+	publicGroup.POST("/account/logout", account.Logout)
+	publicGroup.GET("/account/whitepapers/:slug", account.WhitepaperShortcut)
+	whitepapers := publicHandlers.NewWhitepapersHandler(queries, testLogger, services.NewCache())
+	publicGroup.GET("/whitepapers/:slug", whitepapers.WhitepaperDetail)
+	publicGroup.POST("/whitepapers/:slug/download", whitepapers.WhitepaperDownload)
+	gatedDir := t.TempDir()
+	os.WriteFile(filepath.Join(gatedDir, "manual.pdf"), []byte("pdf"), 0o644)
+	files := publicHandlers.NewProductDownloadsHandler(queries, testLogger, testDownloadLinks, services.NewDownloadFiles(queries, t.TempDir(), gatedDir))
+	publicGroup.GET("/product-downloads/:id", files.Download)
+	publicGroup.GET("/product-downloads/:id/form", files.LeadForm)
+
+	var session *http.Cookie
+	do := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36")
+		if session != nil {
+			req.AddCookie(session)
+		}
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, req)
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == customMiddleware.CustomerCookie {
+				session = c
+				if c.MaxAge < 0 {
+					session = nil
+				}
+			}
+		}
+		return rec
+	}
+
+	// Signed out: /account asks to sign in, whitepapers keep the lead form
+	if rec := do(http.MethodGet, "/account", nil); rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/account/login?next=/account" {
+		t.Errorf("signed-out /account: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	if rec := do(http.MethodGet, "/account/whitepapers/sensor-guide", nil); rec.Code != http.StatusNoContent {
+		t.Errorf("signed-out whitepaper shortcut: %d", rec.Code)
+	}
+	if page := do(http.MethodGet, "/whitepapers/sensor-guide", nil).Body.String(); !strings.Contains(page, `hx-get="/account/whitepapers/sensor-guide" hx-trigger="load"`) {
+		t.Error("whitepaper page does not load the signed-in shortcut")
+	}
+
+	// Registration refuses short passwords and keeps the values entered
+	rec := do(http.MethodPost, "/account/register", url.Values{"name": {"Jane Doe"}, "email": {"jane@acme.com"}, "company": {"Acme"}, "password": {"short"}})
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "at least 8 characters") || !strings.Contains(body, `value="Acme"`) || session != nil {
+		t.Errorf("short password: %d, signed in %v", rec.Code, session != nil)
+	}
+	// bcrypt cannot hash more than 72 bytes; the form says so instead of failing
+	rec = do(http.MethodPost, "/account/register", url.Values{"name": {"Jane Doe"}, "email": {"jane@acme.com"}, "company": {"Acme"}, "password": {strings.Repeat("a", 73)}})
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "at most 72 characters") || session != nil {
+		t.Errorf("long password: %d, signed in %v", rec.Code, session != nil)
+	}
+	nextMail := func() string {
+		t.Helper()
+		select {
+		case body := <-mailer.sent:
+			return body
+		case <-time.After(5 * time.Second):
+			t.Fatal("no email sent")
+			return ""
+		}
+	}
+	janeForm := url.Values{
+		"name": {"Jane Doe"}, "email": {"jane@acme.com"}, "company": {"Acme"}, "designation": {"Engineer"},
+		"password": {"correct-horse"}, "next": {"/whitepapers/sensor-guide"},
+	}
+	rec = do(http.MethodPost, "/account/register", janeForm)
+	signUpPage := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(signUpPage, "FOR A LINK TO CONFIRM YOUR ADDRESS") || session != nil {
+		t.Fatalf("register: %d, signed in %v", rec.Code, session != nil)
+	}
+
+	// The emailed link asks to confirm; only the POST creates the account
+	m := regexp.MustCompile(`https://example\.com(/account/confirm\?token=([0-9a-f]+))`).FindStringSubmatch(nextMail())
+	if m == nil {
+		t.Fatal("confirmation email has no link")
+	}
+	if page := do(http.MethodGet, m[1], nil).Body.String(); !strings.Contains(page, `action="/account/confirm"`) || session != nil {
+		t.Errorf("confirmation page signed in %v or lacks its form", session != nil)
+	}
+	if customers, _ := queries.ListCustomerAccounts(ctx); len(customers) != 0 {
+		t.Error("following the link created the account")
+	}
+	rec = do(http.MethodPost, "/account/confirm", url.Values{"token": {m[2]}})
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/account" || session == nil {
+		t.Fatalf("confirm: %d %s, signed in %v", rec.Code, rec.Header().Get("Location"), session != nil)
+	}
+	if !session.HttpOnly || session.Value == "" {
+		t.Errorf("session cookie = %+v", session)
+	}
+	if page := do(http.MethodGet, m[1], nil).Body.String(); !strings.Contains(page, "INVALID OR HAS EXPIRED") {
+		t.Error("the confirmation link still works after use")
+	}
+
+	// Signing up again with the registered email looks the same, and the
+	// owner is emailed instead
+	signedIn := session
+	session = nil
+	rec = do(http.MethodPost, "/account/register", janeForm)
+	if rec.Code != http.StatusOK || rec.Body.String() != signUpPage || session != nil {
+		t.Errorf("registered email: %d, signed in %v, same page %v", rec.Code, session != nil, rec.Body.String() == signUpPage)
+	}
+	if mail := nextMail(); !strings.Contains(mail, "already has one") {
+		t.Errorf("registered email was sent %s", mail)
+	}
+	session = signedIn
+
+	// One-click whitepaper download, recorded as a lead from the account
+	if body := do(http.MethodGet, "/account/whitepapers/sensor-guide", nil).Body.String(); !strings.Contains(body, `hx-post="/whitepapers/sensor-guide/download"`) || !strings.Contains(body, "jane@acme.com") {
+		t.Errorf("whitepaper shortcut = %s", body)
+	}
+	if rec := do(http.MethodPost, "/whitepapers/sensor-guide/download", url.Values{}); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "/uploads/sensor-guide.pdf") {
+		t.Errorf("one-click whitepaper download: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// The gated manual's form is skipped: the modal holds the signed link
+	form := do(http.MethodGet, fmt.Sprintf("/product-downloads/%d/form", manual.ID), nil).Body.String()
+	if strings.Contains(form, `name="company"`) || !strings.Contains(form, "download-lead-modal") {
+		t.Errorf("signed-in lead form = %s", form)
+	}
+	m = regexp.MustCompile(`href="(/product-downloads/\d+\?token=[^"]+)"`).FindStringSubmatch(form)
+	if m == nil {
+		t.Fatalf("signed-in lead form has no download link: %s", form)
+	}
+	if rec := do(http.MethodGet, strings.ReplaceAll(m[1], "&amp;", "&"), nil); rec.Code != http.StatusOK || rec.Body.String() != "pdf" {
+		t.Errorf("signed link: %d %q", rec.Code, rec.Body.String())
+	}
+
+	leads, err := services.NewLeadService(queries).Leads(ctx)
+	if err != nil {
+		t.Fatalf("Leads: %v", err)
+	}
+	if len(leads) != 1 || leads[0].Downloads != 1 || leads[0].ProductFiles != 1 || leads[0].Company != "Acme" {
+		t.Errorf("leads = %+v, want Jane's two downloads", leads)
+	}
+
+	// Both are in the library, the gated file with a fresh signed link
+	page := do(http.MethodGet, "/account", nil).Body.String()
+	for _, want := range []string{"Sensor Guide", `href="/uploads/sensor-guide.pdf"`, "Installation Manual", fmt.Sprintf(`href="/product-downloads/%d?token=`, manual.ID)} {
+		if !strings.Contains(page, want) {
+			t.Errorf("/account does not contain %q", want)
+		}
+	}
+
+	// The library is never kept as a read-only snapshot for other visitors
+	if _, ok := snapshots.Get("stale:|/account"); ok {
+		t.Error("/account was snapshotted for read-only mode")
+	}
+
+	// Signing out ends the session; signing in again restores the library
+	stale := session
+	if rec := do(http.MethodPost, "/account/logout", url.Values{}); rec.Code != http.StatusSeeOther || session != nil {
+		t.Fatalf("logout: %d, signed in %v", rec.Code, session != nil)
+	}
+	session = stale
+	if rec := do(http.MethodGet, "/account", nil); rec.Code != http.StatusSeeOther {
+		t.Errorf("ended session still signed in: %d", rec.Code)
+	}
+	session = nil
+	if rec := do(http.MethodPost, "/account/login", url.Values{"email": {"jane@acme.com"}, "password": {"wrong-password"}}); !strings.Contains(rec.Body.String(), "incorrect email or password") || session != nil {
+		t.Errorf("wrong password: %d, signed in %v", rec.Code, session != nil)
+	}
+	rec = do(http.MethodPost, "/account/login", url.Values{"email": {"JANE@acme.com"}, "password": {"correct-horse"}, "next": {"//evil.example"}})
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/account" || session == nil {
+		t.Fatalf("login: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	if page := do(http.MethodGet, "/account", nil).Body.String(); !strings.Contains(page, "Installation Manual") {
+		t.Error("library lost after signing in again")
+	}
+
+	// The admin sees the account with its library and can delete it
+	admin := func(method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	e.Renderer = site.Renderer
+	rec = admin(http.MethodGet, "/admin/customers")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin customers: %d", rec.Code)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "jane@acme.com") || !strings.Contains(body, "Engineer") {
+		t.Error("the admin Customers page does not list Jane")
+	}
+	customers, _ := queries.ListCustomerAccounts(ctx)
+	if len(customers) != 1 || customers[0].LibraryCount != 2 || !customers[0].LastLoginAt.Valid || customers[0].Designation != "Engineer" {
+		t.Fatalf("customers = %+v", customers)
+	}
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/customers/%d", customers[0].ID)); rec.Code != http.StatusOK {
+		t.Fatalf("delete customer: %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/account", nil); rec.Code != http.StatusSeeOther {
+		t.Errorf("deleted account still signed in: %d", rec.Code)
+	}
+	if leads, _ := services.NewLeadService(queries).Leads(ctx); len(leads) != 1 {
+		t.Error("deleting the account removed its leads")
+	}
+}
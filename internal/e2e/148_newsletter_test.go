@@ -0,0 +1,172 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// newsletterMailer hands the bodies of sent emails to the test.
+type newsletterMailer struct {
+	sent chan string
+}
+
+func (m *newsletterMailer) Send(ctx context.Context, to []string, subject, body string) error {
+	m.sent <- body
+	return nil
+}
+
+// TestNewsletter_E2E signs up with the footer form, follows the emailed
+// confirmation link, unsubscribes with the link from the email and checks
+// the admin Subscribers page.
+func TestNewsletter_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+
+	mailer := &newsletterMailer{sent: make(chan string, 4)}
+	newsletter := services.NewNewsletter(queries, testLogger, mailer, nil, "https://example.com")
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	handler := publicHandlers.NewNewsletterHandler(newsletter, testLogger)
+	publicGroup.POST("/newsletter/subscribe", handler.Subscribe)
+	publicGroup.GET("/newsletter/confirm", handler.Confirm)
+	publicGroup.GET("/newsletter/unsubscribe", handler.UnsubscribePage)
+	publicGroup.POST("/newsletter/unsubscribe", handler.Unsubscribe)
+
+	do := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("HX-Request", "true")
+			req.Header.Set("HX-Current-URL", "https://example.com/products/sensors")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, req)
+		return rec
+	}
+	nextMail := func() string {
+		t.Helper()
+		select {
+		case body := <-mailer.sent:
+			return body
+		case <-time.After(5 * time.Second):
+			t.Fatal("no confirmation email sent")
+			return ""
+		}
+	}
+
+	// The footer carries the sign-up form; invalid addresses get a message
+	// under the form
+	page := do(http.MethodGet, "/newsletter/confirm?token=bogus", nil)
+	if body := page.Body.String(); !strings.Contains(body, `hx-post="/newsletter/subscribe"`) || !strings.Contains(body, "THIS LINK IS INVALID") {
+		t.Error("invalid confirmation link page lacks the message or the footer form")
+	}
+	if cc := page.Header().Get("Cache-Control"); cc != "private, no-store" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+	rec := do(http.MethodPost, "/newsletter/subscribe", url.Values{"email": {"not-an-address"}})
+	if rec.Header().Get("HX-Retarget") != "#newsletter-status" || !strings.Contains(rec.Body.String(), "valid email") {
+		t.Errorf("invalid address: %q %q", rec.Header().Get("HX-Retarget"), rec.Body.String())
+	}
+
+	// Signing up leaves the address pending until the emailed link is followed
+	rec = do(http.MethodPost, "/newsletter/subscribe", url.Values{"email": {"Jane@Acme.com"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "confirmation link") {
+		t.Fatalf("subscribe: %d %s", rec.Code, rec.Body.String())
+	}
+	mail := nextMail()
+	sub, err := queries.GetNewsletterSubscriberByEmail(ctx, "jane@acme.com")
+	if err != nil || sub.Status != services.NewsletterPending || sub.Source != "/products/sensors" {
+		t.Fatalf("pending subscriber = %+v, %v", sub, err)
+	}
+	confirm := regexp.MustCompile(`https://example\.com(/newsletter/confirm\?token=[\w-]+)`).FindStringSubmatch(mail)
+	unsubscribe := regexp.MustCompile(`https://example\.com(/newsletter/unsubscribe\?token=[\w-]+)`).FindStringSubmatch(mail)
+	if confirm == nil || unsubscribe == nil {
+		t.Fatalf("confirmation email lacks its links:\n%s", mail)
+	}
+
+	if body := do(http.MethodGet, confirm[1], nil).Body.String(); !strings.Contains(body, "THANKS FOR CONFIRMING") {
+		t.Error("confirmation link did not confirm")
+	}
+	if body := do(http.MethodGet, confirm[1], nil).Body.String(); !strings.Contains(body, "THIS LINK IS INVALID") {
+		t.Error("confirmation link works twice")
+	}
+	if sub, _ = queries.GetNewsletterSubscriberByEmail(ctx, "jane@acme.com"); sub.Status != services.NewsletterConfirmed || !sub.ConfirmedAt.Valid {
+		t.Fatalf("confirmed subscriber = %+v", sub)
+	}
+
+	// Signing up again once confirmed sends nothing
+	do(http.MethodPost, "/newsletter/subscribe", url.Values{"email": {"jane@acme.com"}})
+	select {
+	case <-mailer.sent:
+		t.Error("confirmed address was emailed again")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The unsubscribe link asks first; the POST unsubscribes
+	if body := do(http.MethodGet, unsubscribe[1], nil).Body.String(); !strings.Contains(body, "STOP SENDING THE NEWSLETTER") {
+		t.Error("unsubscribe link does not ask to confirm")
+	}
+	if sub, _ = queries.GetNewsletterSubscriberByEmail(ctx, "jane@acme.com"); sub.Status != services.NewsletterConfirmed {
+		t.Error("following the unsubscribe link unsubscribed")
+	}
+	token := strings.TrimPrefix(unsubscribe[1], "/newsletter/unsubscribe?token=")
+	if body := do(http.MethodPost, "/newsletter/unsubscribe", url.Values{"token": {token}}).Body.String(); !strings.Contains(body, "IS UNSUBSCRIBED") {
+		t.Error("unsubscribe did not confirm")
+	}
+	if sub, _ = queries.GetNewsletterSubscriberByEmail(ctx, "jane@acme.com"); sub.Status != services.NewsletterUnsubscribed || !sub.UnsubscribedAt.Valid {
+		t.Fatalf("unsubscribed subscriber = %+v", sub)
+	}
+
+	// The admin filters subscribers by status and deletes them
+	queries.CreateNewsletterSubscriber(ctx, sqlc.CreateNewsletterSubscriberParams{Email: "pending@acme.com", UnsubscribeToken: "pending-token"})
+	admin := func(method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	e.Renderer = site.Renderer
+	rec = admin(http.MethodGet, "/admin/subscribers")
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "Jane@Acme.com") || !strings.Contains(body, "pending@acme.com") || !strings.Contains(body, "/products/sensors") {
+		t.Fatalf("admin subscribers: %d", rec.Code)
+	}
+	if body := admin(http.MethodGet, "/admin/subscribers?status=pending").Body.String(); strings.Contains(body, "Jane@Acme.com") || !strings.Contains(body, "pending@acme.com") {
+		t.Error("status filter does not apply")
+	}
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/subscribers/%d", sub.ID)); rec.Code != http.StatusOK {
+		t.Fatalf("delete subscriber: %d", rec.Code)
+	}
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/subscribers/%d", sub.ID)); rec.Code != http.StatusNotFound {
+		t.Errorf("delete missing subscriber: %d", rec.Code)
+	}
+	if _, err := queries.GetNewsletterSubscriberByEmail(ctx, "jane@acme.com"); err == nil {
+		t.Error("deleted subscriber still stored")
+	}
+}
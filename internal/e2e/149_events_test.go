@@ -0,0 +1,191 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestEvents_E2E creates events in the admin, lists them on /events,
+// registers for a webinar, downloads its calendar file and finds the
+// registration among the leads.
+func TestEvents_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+
+	mailer := &newsletterMailer{sent: make(chan string, 4)}
+	events := services.NewEvents(queries, testLogger, mailer, "https://example.com")
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	e.Renderer = site.Renderer
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	handler := publicHandlers.NewEventsHandler(queries, events, testLogger, services.NewCache())
+	publicGroup.GET("/events", handler.List)
+	publicGroup.GET("/events/:slug", handler.Detail)
+	publicGroup.POST("/events/:slug/register", handler.Register)
+	publicGroup.GET("/events/:slug/calendar.ics", handler.Calendar)
+
+	admin := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	visit := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("HX-Request", "true")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, req)
+		return rec
+	}
+	at := func(d time.Duration) string {
+		return time.Now().UTC().Add(d).Format("2006-01-02T15:04")
+	}
+
+	// The admin form checks its input
+	rec := admin(http.MethodPost, "/admin/events", url.Values{
+		"title": {"Sensor Webinar"}, "event_type": {"webinar"},
+		"starts_at": {at(48 * time.Hour)}, "ends_at": {at(47 * time.Hour)},
+		"webinar_url": {"https://meet.example.com/sensors"},
+	})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "must end after it starts") {
+		t.Fatalf("event ending before it starts: %d", rec.Code)
+	}
+
+	for _, form := range []url.Values{
+		{"title": {"Sensor Webinar"}, "event_type": {"webinar"}, "summary": {"Live Q&A on sensors"},
+			"starts_at": {at(48 * time.Hour)}, "ends_at": {at(49 * time.Hour)}, "webinar_url": {"https://meet.example.com/sensors"},
+			"capacity": {"1"}, "registration_open": {"on"}, "is_published": {"on"}},
+		{"title": {"Hannover Messe"}, "event_type": {"in_person"}, "location": {"Hall 4, Hannover"},
+			"starts_at": {at(-72 * time.Hour)}, "ends_at": {at(-70 * time.Hour)}, "registration_open": {"on"}, "is_published": {"on"}},
+		{"title": {"Secret Summit"}, "event_type": {"in_person"}, "location": {"Berlin"},
+			"starts_at": {at(24 * time.Hour)}, "ends_at": {at(26 * time.Hour)}, "registration_open": {"on"}},
+	} {
+		if rec := admin(http.MethodPost, "/admin/events", form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create %s: %d %s", form.Get("title"), rec.Code, rec.Body.String())
+		}
+	}
+	rec = admin(http.MethodGet, "/admin/events", nil)
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "Sensor Webinar") || !strings.Contains(body, "Secret Summit") {
+		t.Fatalf("admin events: %d", rec.Code)
+	}
+
+	// /events splits upcoming from past and leaves drafts out
+	body := visit(http.MethodGet, "/events", nil).Body.String()
+	upcoming, past := strings.Index(body, "Sensor Webinar"), strings.Index(body, "Hannover Messe")
+	if upcoming < 0 || past < 0 || !(upcoming < strings.Index(body, "Past Events") && strings.Index(body, "Past Events") < past) {
+		t.Error("events are not split into upcoming and past")
+	}
+	if strings.Contains(body, "Secret Summit") {
+		t.Error("draft event listed")
+	}
+
+	// The join link is for registrants only
+	body = visit(http.MethodGet, "/events/sensor-webinar", nil).Body.String()
+	if !strings.Contains(body, `hx-post="/events/sensor-webinar/register"`) || strings.Contains(body, "meet.example.com") {
+		t.Error("event page lacks the registration form or shows the join link")
+	}
+	if rec := visit(http.MethodGet, "/events/secret-summit", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("draft event page: %d", rec.Code)
+	}
+
+	rec = visit(http.MethodPost, "/events/sensor-webinar/register", url.Values{"name": {"Jane"}, "email": {"jane@acme.com"}})
+	if rec.Header().Get("HX-Retarget") != "#event-registration-status" {
+		t.Error("missing company was not reported under the form")
+	}
+	rec = visit(http.MethodPost, "/events/sensor-webinar/register", url.Values{"name": {"Jane"}, "email": {"jane@acme.com"}, "company": {"Acme"}})
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "https://meet.example.com/sensors") {
+		t.Fatalf("register: %d %s", rec.Code, body)
+	}
+	select {
+	case mail := <-mailer.sent:
+		if !strings.Contains(mail, "https://meet.example.com/sensors") || !strings.Contains(mail, "https://example.com/events/sensor-webinar/calendar.ics") {
+			t.Errorf("confirmation email:\n%s", mail)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no confirmation email sent")
+	}
+
+	// The only place is taken
+	rec = visit(http.MethodPost, "/events/sensor-webinar/register", url.Values{"name": {"John"}, "email": {"john@acme.com"}, "company": {"Acme"}})
+	if !strings.Contains(rec.Body.String(), "fully booked") {
+		t.Errorf("second registration: %s", rec.Body.String())
+	}
+	if body := visit(http.MethodGet, "/events/sensor-webinar", nil).Body.String(); !strings.Contains(body, "Fully Booked") {
+		t.Error("full event still offers the form")
+	}
+	if body := visit(http.MethodGet, "/events/hannover-messe", nil).Body.String(); !strings.Contains(body, "This Event Has Ended") {
+		t.Error("past event still offers the form")
+	}
+	rec = visit(http.MethodPost, "/events/hannover-messe/register", url.Values{"name": {"John"}, "email": {"john@acme.com"}, "company": {"Acme"}})
+	if !strings.Contains(rec.Body.String(), "closed") {
+		t.Errorf("registration for a past event: %s", rec.Body.String())
+	}
+
+	rec = visit(http.MethodGet, "/events/sensor-webinar/calendar.ics", nil)
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") || !strings.Contains(rec.Header().Get("Content-Disposition"), "sensor-webinar.ics") {
+		t.Errorf("calendar headers: %q %q", ct, rec.Header().Get("Content-Disposition"))
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "BEGIN:VEVENT\r\n") || !strings.Contains(body, "SUMMARY:Sensor Webinar\r\n") {
+		t.Errorf("calendar file:\n%s", body)
+	}
+
+	// Registrations are leads, and listed with the event
+	leads, err := services.NewLeadService(queries).Leads(ctx)
+	if err != nil || len(leads) != 1 || leads[0].Events != 1 {
+		t.Fatalf("leads = %+v, %v", leads, err)
+	}
+	event, err := queries.GetPublishedEventBySlug(ctx, "sensor-webinar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := admin(http.MethodGet, fmt.Sprintf("/admin/events/%d/registrations", event.ID), nil).Body.String(); !strings.Contains(body, "jane@acme.com") {
+		t.Error("admin registrations lack the registrant")
+	}
+
+	sitemap := httptest.NewRecorder()
+	e.ServeHTTP(sitemap, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+	if body := sitemap.Body.String(); !strings.Contains(body, "/events/sensor-webinar") || strings.Contains(body, "/events/secret-summit") {
+		t.Error("sitemap does not list the published events only")
+	}
+
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/events/%d", event.ID), nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete event: %d", rec.Code)
+	}
+	if count, _ := queries.CountEventRegistrations(ctx, event.ID); count != 0 {
+		t.Errorf("registrations left after deleting the event: %d", count)
+	}
+}
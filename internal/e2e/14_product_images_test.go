@@ -66,6 +66,7 @@ func TestProductImages_AddImage(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/products/%d/images", prod.ID), strings.NewReader(body.String()))
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -102,6 +103,7 @@ func TestProductImages_DeleteImage(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/products/%d/images/%d", prod.ID, img.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -220,12 +222,14 @@ func TestProductImages_OptionalFields(t *testing.T) {
 	writer := multipart.NewWriter(body)
 	part, _ := writer.CreateFormFile("image", "product.png")
 	part.Write([]byte("fake image content"))
+	writer.WriteField("alt_text", "Product")
 	writer.WriteField("display_order", "1")
 	writer.Close()
 
 	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/products/%d/images", prod.ID), strings.NewReader(body.String()))
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -237,8 +241,8 @@ func TestProductImages_OptionalFields(t *testing.T) {
 	if len(images) != 1 {
 		t.Fatalf("expected 1 image, got %d", len(images))
 	}
-	if images[0].AltText.Valid {
-		t.Error("expected alt_text to be null when not provided")
+	if images[0].AltText.String != "Product" {
+		t.Errorf("expected alt_text 'Product', got %q", images[0].AltText.String)
 	}
 	if images[0].Caption.Valid {
 		t.Error("expected caption to be null when not provided")
@@ -265,6 +269,7 @@ func TestProductImagesEditForm_E2E(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/products/%d/images?edit=%d", product.ID, img.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -305,6 +310,7 @@ func TestProductImagesUpdate_E2E(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
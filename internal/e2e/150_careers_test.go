@@ -0,0 +1,201 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestCareers_E2E creates job postings in the admin, lists the open ones
+// on /careers with their structured data, applies with a CV and works the
+// application in the admin inbox.
+func TestCareers_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+
+	careers := services.NewCareers(queries, services.NewLocalStorage(t.TempDir()), testLogger)
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	e.Renderer = site.Renderer
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	handler := publicHandlers.NewCareersHandler(queries, careers, testLogger, services.NewCache(), "https://example.com")
+	publicGroup.GET("/careers", handler.List)
+	publicGroup.GET("/careers/:slug", handler.Detail)
+	publicGroup.POST("/careers/:slug/apply", handler.Apply)
+
+	admin := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	visit := func(target string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	apply := func(slug string, fields map[string]string, cvName, cv string) *httptest.ResponseRecorder {
+		t.Helper()
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		for k, v := range fields {
+			w.WriteField(k, v)
+		}
+		if cvName != "" {
+			part, _ := w.CreateFormFile("cv", cvName)
+			part.Write([]byte(cv))
+		}
+		w.Close()
+		req := httptest.NewRequest(http.MethodPost, "/careers/"+slug+"/apply", &body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req) // shares CV storage with the admin routes
+		return rec
+	}
+
+	// The admin form checks its input
+	rec := admin(http.MethodPost, "/admin/careers", url.Values{
+		"title": {"Firmware Engineer"}, "employment_type": {"full_time"},
+		"description": {"Write firmware."}, "is_published": {"on"},
+	})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "mark it remote") {
+		t.Fatalf("posting without a location: %d", rec.Code)
+	}
+
+	for _, form := range []url.Values{
+		{"title": {"Firmware Engineer"}, "department": {"Engineering"}, "employment_type": {"full_time"},
+			"location": {"Hyderabad"}, "country": {"in"}, "remote": {"on"}, "summary": {"Build our sensor firmware"},
+			"description": {"Write firmware.\n\nShip products."}, "is_published": {"on"},
+			"valid_through": {time.Now().AddDate(0, 1, 0).Format("2006-01-02")}},
+		{"title": {"Sales Intern"}, "employment_type": {"internship"}, "location": {"Pune"},
+			"description": {"Help the sales team."}, "is_published": {"on"},
+			"valid_through": {time.Now().AddDate(0, 0, -3).Format("2006-01-02")}},
+		{"title": {"Secret Role"}, "employment_type": {"contract"}, "location": {"Berlin"},
+			"description": {"Not yet announced."}},
+	} {
+		if rec := admin(http.MethodPost, "/admin/careers", form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create %s: %d %s", form.Get("title"), rec.Code, rec.Body.String())
+		}
+	}
+	rec = admin(http.MethodGet, "/admin/careers", nil)
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "Firmware Engineer") || !strings.Contains(body, "Secret Role") {
+		t.Fatalf("admin careers: %d", rec.Code)
+	}
+
+	// /careers lists open postings only
+	body := visit("/careers").Body.String()
+	if !strings.Contains(body, "Firmware Engineer") || strings.Contains(body, "Sales Intern") || strings.Contains(body, "Secret Role") {
+		t.Error("careers page does not list the open postings only")
+	}
+
+	// Open postings carry the apply form and their Google Jobs data
+	body = visit("/careers/firmware-engineer").Body.String()
+	if !strings.Contains(body, `hx-post="/careers/firmware-engineer/apply"`) || !strings.Contains(body, `hx-encoding="multipart/form-data"`) {
+		t.Error("posting lacks the apply form")
+	}
+	if !strings.Contains(body, `<script type="application/ld+json">`) || !strings.Contains(body, `"@type":"JobPosting"`) ||
+		!strings.Contains(body, `"jobLocationType":"TELECOMMUTE"`) || !strings.Contains(body, `"url":"https://example.com/careers/firmware-engineer"`) {
+		t.Errorf("posting lacks its JobPosting structured data")
+	}
+	body = visit("/careers/sales-intern").Body.String()
+	if !strings.Contains(body, "Position Closed") || strings.Contains(body, "application/ld+json") || strings.Contains(body, "/apply") {
+		t.Error("closed posting still offers the form or its structured data")
+	}
+	if rec := visit("/careers/secret-role"); rec.Code != http.StatusNotFound {
+		t.Errorf("draft posting page: %d", rec.Code)
+	}
+
+	// Applying checks the CV and stores it
+	rec = apply("firmware-engineer", map[string]string{"name": "Jane", "email": "jane@example.com"}, "", "")
+	if rec.Header().Get("HX-Retarget") != "#job-application-status" || !strings.Contains(rec.Body.String(), "Attach your CV") {
+		t.Errorf("application without a CV: %q", rec.Body.String())
+	}
+	rec = apply("firmware-engineer", map[string]string{"name": "Jane", "email": "jane@example.com"}, "cv.exe", "MZ")
+	if !strings.Contains(rec.Body.String(), "PDF, Word") {
+		t.Errorf("application with an executable: %q", rec.Body.String())
+	}
+	rec = apply("sales-intern", map[string]string{"name": "Jane", "email": "jane@example.com"}, "cv.pdf", "%PDF")
+	if !strings.Contains(rec.Body.String(), "no longer open") {
+		t.Errorf("application for a closed posting: %q", rec.Body.String())
+	}
+	rec = apply("firmware-engineer", map[string]string{"name": "Jane", "email": "jane@example.com", "cover_letter": "I love sensors."}, "jane-cv.pdf", "%PDF-1.7 jane")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Application Received") {
+		t.Fatalf("apply: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// The inbox lists the application, and only admins get the CV
+	rec = admin(http.MethodGet, "/admin/careers/applications?status=new", nil)
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "jane@example.com") || !strings.Contains(body, "Firmware Engineer") {
+		t.Fatalf("application inbox: %d", rec.Code)
+	}
+	apps, err := queries.ListJobApplications(ctx, sqlc.ListJobApplicationsParams{FilterStatus: "", FilterJobID: 0, PageLimit: 10})
+	if err != nil || len(apps) != 1 {
+		t.Fatalf("applications = %+v, %v", apps, err)
+	}
+	app := apps[0]
+	rec = admin(http.MethodGet, fmt.Sprintf("/admin/careers/applications/%d/cv", app.ID), nil)
+	if rec.Code != http.StatusOK || rec.Body.String() != "%PDF-1.7 jane" || !strings.Contains(rec.Header().Get("Content-Disposition"), "jane-cv.pdf") {
+		t.Errorf("CV download: %d %q", rec.Code, rec.Header().Get("Content-Disposition"))
+	}
+	cvReq := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/careers/applications/%d/cv", app.ID), nil)
+	cvRec := httptest.NewRecorder()
+	e.ServeHTTP(cvRec, cvReq)
+	if cvRec.Code == http.StatusOK {
+		t.Error("CV downloaded without signing in")
+	}
+
+	rec = admin(http.MethodPost, fmt.Sprintf("/admin/careers/applications/%d/status", app.ID), url.Values{"status": {"interview"}, "notes": {"Strong firmware background"}})
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != fmt.Sprintf("/admin/careers/applications/%d", app.ID) {
+		t.Fatalf("status update: %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if body := admin(http.MethodGet, fmt.Sprintf("/admin/careers/applications/%d", app.ID), nil).Body.String(); !strings.Contains(body, "Strong firmware background") || !strings.Contains(body, "I love sensors.") {
+		t.Error("application page lacks the notes or cover letter")
+	}
+	if body := admin(http.MethodGet, "/admin/careers/applications?status=new", nil).Body.String(); strings.Contains(body, "jane@example.com") {
+		t.Error("interviewed application still filed as new")
+	}
+
+	sitemap := httptest.NewRecorder()
+	e.ServeHTTP(sitemap, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+	if body := sitemap.Body.String(); !strings.Contains(body, "/careers/firmware-engineer") || strings.Contains(body, "/careers/sales-intern") || strings.Contains(body, "/careers/secret-role") {
+		t.Error("sitemap does not list the open postings only")
+	}
+
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/careers/%d", app.JobID), nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete posting: %d", rec.Code)
+	}
+	if _, err := queries.GetJobApplication(ctx, app.ID); err == nil {
+		t.Error("application left after deleting the posting")
+	}
+}
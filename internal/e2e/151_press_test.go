@@ -0,0 +1,178 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestPress_E2E creates press releases, coverage links and a media kit file
+// in the admin and checks that /press lists them while holding back drafts
+// and embargoed releases.
+func TestPress_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+
+	site := echo.New()
+	site.Renderer = templates.NewRenderer("templates")
+	e.Renderer = site.Renderer
+	publicGroup := site.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	handler := publicHandlers.NewPressHandler(queries, testLogger, services.NewCache())
+	publicGroup.GET("/press", handler.List)
+	publicGroup.GET("/press/:slug", handler.Detail)
+
+	admin := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	visit := func(target string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	at := func(d time.Duration) string {
+		return time.Now().UTC().Add(d).Format("2006-01-02T15:04")
+	}
+
+	// The admin form checks its input
+	rec := admin(http.MethodPost, "/admin/press", url.Values{"title": {"Launch"}, "released_at": {at(-time.Hour)}})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "Write the text of the press release.") {
+		t.Fatalf("release without a body: %d", rec.Code)
+	}
+
+	for _, form := range []url.Values{
+		{"title": {"BlueJay Launches TS 100"}, "location": {"San Jose, CA"}, "summary": {"A rugged thermal sensor."},
+			"body": {"BlueJay today **announced** the TS 100."}, "released_at": {at(-time.Hour)}, "is_published": {"on"}},
+		{"title": {"Embargoed Results"}, "body": {"Results."}, "released_at": {at(24 * time.Hour)}, "is_published": {"on"}},
+		{"title": {"Draft Release"}, "body": {"Draft."}, "released_at": {at(-time.Hour)}},
+	} {
+		if rec := admin(http.MethodPost, "/admin/press", form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create release %q: %d %s", form.Get("title"), rec.Code, rec.Body.String())
+		}
+	}
+	releases, err := queries.ListPressReleasesAdmin(ctx)
+	if err != nil || len(releases) != 3 {
+		t.Fatalf("releases: %v %d", err, len(releases))
+	}
+	if body := admin(http.MethodGet, "/admin/press", nil).Body.String(); !strings.Contains(body, ">Embargoed</span>") || !strings.Contains(body, ">Draft</span>") {
+		t.Error("admin list does not show the release states")
+	}
+
+	// Coverage links need a full URL
+	rec = admin(http.MethodPost, "/admin/press/mentions", url.Values{
+		"outlet": {"IEEE Spectrum"}, "headline": {"Sensors get tough"}, "url": {"spectrum.example.com"}, "published_on": {"2026-03-01"},
+	})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("mention with a bad URL: %d", rec.Code)
+	}
+	rec = admin(http.MethodPost, "/admin/press/mentions", url.Values{
+		"outlet": {"IEEE Spectrum"}, "headline": {"Sensors get tough"}, "url": {"https://spectrum.example.com/tough"},
+		"published_on": {"2026-03-01"}, "is_published": {"on"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create mention: %d", rec.Code)
+	}
+
+	// Media kit uploads are limited to images, documents and archives
+	upload := func(name string) *httptest.ResponseRecorder {
+		t.Helper()
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		part, _ := w.CreateFormFile("file", name)
+		part.Write([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"))
+		w.WriteField("description", "Logo for light backgrounds")
+		w.Close()
+		req := httptest.NewRequest(http.MethodPost, "/admin/press/media-kit", &body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := upload("logo.exe"); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("upload of an .exe: %d", rec.Code)
+	}
+	if rec := upload("BlueJay Logo.svg"); rec.Code != http.StatusSeeOther {
+		t.Fatalf("upload logo: %d %s", rec.Code, rec.Body.String())
+	}
+	assets, _ := queries.ListPressKitAssets(ctx)
+	if len(assets) != 1 || assets[0].Title != "BlueJay Logo" || !strings.HasPrefix(assets[0].FilePath, "/uploads/press/") {
+		t.Fatalf("media kit: %+v", assets)
+	}
+
+	// /press lists what is out, coverage and the media kit
+	rec = visit("/press")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/press: %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"BlueJay Launches TS 100", "San Jose, CA", "Sensors get tough", "https://spectrum.example.com/tough", "BlueJay Logo", "SVG"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/press lacks %q", want)
+		}
+	}
+	for _, hidden := range []string{"Embargoed Results", "Draft Release"} {
+		if strings.Contains(body, hidden) {
+			t.Errorf("/press shows %q", hidden)
+		}
+	}
+
+	rec = visit("/press/bluejay-launches-ts-100")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<strong>announced</strong>") {
+		t.Fatalf("release page: %d", rec.Code)
+	}
+	for _, slug := range []string{"embargoed-results", "draft-release"} {
+		if rec := visit("/press/" + slug); rec.Code != http.StatusNotFound {
+			t.Errorf("/press/%s: expected 404, got %d", slug, rec.Code)
+		}
+	}
+
+	// Deleting removes the rows
+	mentions, _ := queries.ListPressMentionsAdmin(ctx)
+	for _, target := range []string{
+		"/admin/press/" + strconv.FormatInt(releases[0].ID, 10),
+		"/admin/press/mentions/" + strconv.FormatInt(mentions[0].ID, 10),
+		"/admin/press/media-kit/" + strconv.FormatInt(assets[0].ID, 10),
+	} {
+		if rec := admin(http.MethodDelete, target, nil); rec.Code != http.StatusOK {
+			t.Errorf("DELETE %s: %d", target, rec.Code)
+		}
+	}
+	if releases, _ := queries.ListPressReleasesAdmin(ctx); len(releases) != 2 {
+		t.Errorf("%d releases after delete", len(releases))
+	}
+	if assets, _ := queries.ListPressKitAssets(ctx); len(assets) != 0 {
+		t.Error("media kit file not removed")
+	}
+}
@@ -0,0 +1,164 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestDocs_E2E builds a doc set with nested pages in the admin, reads it
+// on /docs, adds a second version as a copy and checks that site search
+// and the sitemap follow the current version.
+func TestDocs_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+
+	// The /docs routes of setupApp share the page cache with the admin, so
+	// public pages show admin changes straight away
+	e.Renderer = templates.NewRenderer("templates")
+
+	admin := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	visit := func(target string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	// The doc set and its first version
+	rec := admin(http.MethodPost, "/admin/docs", url.Values{"name": {"Gateway Manual"}, "version": {"1 beta"}, "is_published": {"on"}})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "first version") {
+		t.Fatalf("set with a bad version label: %d", rec.Code)
+	}
+	rec = admin(http.MethodPost, "/admin/docs", url.Values{"name": {"Gateway Manual"}, "version": {"1.0"}, "description": {"Set up the gateway."}, "is_published": {"on"}})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create set: %d %s", rec.Code, rec.Body.String())
+	}
+	set, err := queries.GetPublishedDocSetBySlug(ctx, "gateway-manual")
+	if err != nil || !set.CurrentVersionID.Valid {
+		t.Fatalf("set = %+v, %v", set, err)
+	}
+	v1 := set.CurrentVersionID.Int64
+
+	// Nested pages; a draft hides its subpages
+	page := func(versionID int64, form url.Values) sqlc.DocPage {
+		t.Helper()
+		if rec := admin(http.MethodPost, fmt.Sprintf("/admin/docs/versions/%d/pages", versionID), form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create page %s: %d %s", form.Get("title"), rec.Code, rec.Body.String())
+		}
+		pages, _ := queries.ListDocPages(ctx, versionID)
+		return pages[len(pages)-1]
+	}
+	install := page(v1, url.Values{"title": {"Installation"}, "body_markdown": {"Mount the **gateway** on a DIN rail."}, "sort_order": {"1"}, "is_published": {"on"}})
+	wiring := page(v1, url.Values{"title": {"Wiring"}, "parent_id": {fmt.Sprint(install.ID)}, "body_markdown": {"Connect the quokka terminal."}, "is_published": {"on"}})
+	draft := page(v1, url.Values{"title": {"Firmware"}, "sort_order": {"2"}, "body_markdown": {"Not ready."}})
+	page(v1, url.Values{"title": {"Flashing"}, "parent_id": {fmt.Sprint(draft.ID)}, "body_markdown": {"Flash the quokka image."}, "is_published": {"on"}})
+
+	rec = admin(http.MethodPost, fmt.Sprintf("/admin/docs/pages/%d", install.ID), url.Values{"title": {"Installation"}, "slug": {"installation"}, "parent_id": {fmt.Sprint(wiring.ID)}, "is_published": {"on"}})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "under itself") {
+		t.Errorf("page moved below its subpage: %d", rec.Code)
+	}
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/docs/pages/%d", install.ID), nil); rec.Code != http.StatusConflict {
+		t.Errorf("delete page with subpages: %d", rec.Code)
+	}
+	if body := admin(http.MethodGet, fmt.Sprintf("/admin/docs/versions/%d", v1), nil).Body.String(); !strings.Contains(body, "Wiring") || !strings.Contains(body, "Flashing") {
+		t.Error("admin page tree lacks pages")
+	}
+	if rec := admin(http.MethodGet, fmt.Sprintf("/admin/docs/versions/%d/pages/new?parent=%d", v1, install.ID), nil); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "— Wiring") {
+		t.Errorf("new page form: %d", rec.Code)
+	}
+
+	// Public docs
+	if body := visit("/docs").Body.String(); !strings.Contains(body, "Gateway Manual") || !strings.Contains(body, "Set up the gateway.") {
+		t.Error("docs index lacks the set")
+	}
+	if rec := visit("/docs/gateway-manual"); rec.Code != http.StatusFound || rec.Header().Get("Location") != "/docs/gateway-manual/1.0" {
+		t.Errorf("set redirect: %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+	rec = visit("/docs/gateway-manual/1.0/wiring")
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "Connect the quokka terminal.") || !strings.Contains(body, `href="/docs/gateway-manual/1.0/installation"`) {
+		t.Fatalf("wiring page: %d", rec.Code)
+	}
+	if strings.Contains(body, "Firmware") || strings.Contains(body, "Flashing") {
+		t.Error("sidebar lists a draft page or its subpage")
+	}
+	if rec := visit("/docs/gateway-manual/1.0/flashing"); rec.Code != http.StatusNotFound {
+		t.Errorf("page below a draft: %d", rec.Code)
+	}
+	if body := visit("/docs/gateway-manual/1.0/installation").Body.String(); !strings.Contains(body, "<strong>gateway</strong>") {
+		t.Error("Markdown not rendered")
+	}
+
+	// A second version starts as a copy and becomes current
+	if rec := admin(http.MethodPost, fmt.Sprintf("/admin/docs/%d/versions", set.ID), url.Values{"version": {"2.0"}, "copy_from": {fmt.Sprint(v1)}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("add version: %d", rec.Code)
+	}
+	if rec := admin(http.MethodPost, fmt.Sprintf("/admin/docs/%d/versions", set.ID), url.Values{"version": {"2.0"}}); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "already has a version 2.0") {
+		t.Errorf("duplicate version: %d, want the form again with the error", rec.Code)
+	}
+	v2, err := queries.GetDocVersionByLabel(ctx, sqlc.GetDocVersionByLabelParams{DocSetID: set.ID, Version: "2.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := admin(http.MethodGet, fmt.Sprintf("/admin/docs/%d/edit", set.ID), nil).Body.String(); !strings.Contains(body, "Make Current") || !strings.Contains(body, "Copy of 2.0") {
+		t.Error("set form lacks the versions")
+	}
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/docs/versions/%d", v1), nil); rec.Code != http.StatusConflict {
+		t.Errorf("delete current version: %d", rec.Code)
+	}
+	if rec := admin(http.MethodPost, fmt.Sprintf("/admin/docs/versions/%d/current", v2.ID), nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("make current: %d", rec.Code)
+	}
+	if rec := visit("/docs/gateway-manual"); rec.Header().Get("Location") != "/docs/gateway-manual/2.0" {
+		t.Errorf("set redirect after the switch: %s", rec.Header().Get("Location"))
+	}
+	if body := visit("/docs/gateway-manual/1.0/wiring").Body.String(); !strings.Contains(body, "/docs/gateway-manual/2.0") || !strings.Contains(body, "Connect the quokka terminal.") {
+		t.Error("old version page does not point to the current version")
+	}
+
+	// Search and the sitemap cover published pages of the current version
+	search := httptest.NewRecorder()
+	e.ServeHTTP(search, httptest.NewRequest(http.MethodGet, "/search?q=quokka", nil))
+	if body := search.Body.String(); !strings.Contains(body, "/docs/gateway-manual/2.0/wiring") || strings.Contains(body, "/1.0/") || strings.Contains(body, "flashing") {
+		t.Errorf("search results: %s", body)
+	}
+	sitemap := httptest.NewRecorder()
+	e.ServeHTTP(sitemap, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+	if body := sitemap.Body.String(); !strings.Contains(body, "/docs/gateway-manual/2.0/installation") || strings.Contains(body, "/docs/gateway-manual/1.0/") || strings.Contains(body, "flashing") {
+		t.Error("sitemap does not list the current version's published pages only")
+	}
+
+	// Old versions can go once they are not current
+	if rec := admin(http.MethodDelete, fmt.Sprintf("/admin/docs/versions/%d", v1), nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete old version: %d", rec.Code)
+	}
+	if rec := visit("/docs/gateway-manual/1.0/wiring"); rec.Code != http.StatusNotFound {
+		t.Errorf("deleted version still served: %d", rec.Code)
+	}
+}
@@ -0,0 +1,171 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestPartnerPortal_E2E creates a portal link in the admin, sends an
+// update through it with a new logo and tier, approves it in the queue
+// and checks that /partners shows the change and the revoked link stops
+// working.
+func TestPartnerPortal_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+	e.Renderer = templates.NewRenderer("templates")
+
+	silver, _ := queries.CreatePartnerTier(ctx, sqlc.CreatePartnerTierParams{Name: "Silver Partners", Slug: "silver", SortOrder: 2})
+	gold, _ := queries.CreatePartnerTier(ctx, sqlc.CreatePartnerTierParams{Name: "Gold Partners", Slug: "gold", SortOrder: 1})
+	partner, err := queries.CreatePartner(ctx, sqlc.CreatePartnerParams{Name: "Acme Sensors", TierID: silver.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+		}
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	visit := func(target string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	submit := func(path string, fields map[string]string, logo []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		for k, v := range fields {
+			w.WriteField(k, v)
+		}
+		if logo != nil {
+			part, _ := w.CreateFormFile("logo", "acme.png")
+			part.Write(logo)
+		}
+		w.Close()
+		req := httptest.NewRequest(http.MethodPost, path, &body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The admin creates a link, shown once
+	portalPage := fmt.Sprintf("/admin/partners/%d/portal", partner.ID)
+	rec := admin(http.MethodPost, fmt.Sprintf("/admin/partners/%d/invites", partner.ID), url.Values{"email": {"not-an-email"}})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("invite with a bad email: %d", rec.Code)
+	}
+	rec = admin(http.MethodPost, fmt.Sprintf("/admin/partners/%d/invites", partner.ID), url.Values{"email": {""}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("invite: %d %s", rec.Code, rec.Body.String())
+	}
+	m := regexp.MustCompile(`value="https://example\.com(/partner-portal/[0-9a-f]+)"`).FindStringSubmatch(rec.Body.String())
+	if m == nil {
+		t.Fatal("invite link not shown")
+	}
+	link := m[1]
+	if strings.Contains(admin(http.MethodGet, portalPage, nil).Body.String(), link) {
+		t.Error("portal page shows the link again")
+	}
+
+	// The partner opens the link; the page is private and not counted
+	if rec := visit("/partner-portal/0123abcd"); !strings.Contains(rec.Body.String(), "LINK IS INVALID") {
+		t.Errorf("unknown link: %d", rec.Code)
+	}
+	rec = visit(link)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Acme Sensors") {
+		t.Fatalf("portal page: %d", rec.Code)
+	}
+	if rec.Header().Get("Cache-Control") != "private, no-store" || rec.Header().Get("Referrer-Policy") != "no-referrer" {
+		t.Errorf("portal headers = %v", rec.Header())
+	}
+
+	// A tier change needs evidence; the form keeps its input
+	fields := map[string]string{
+		"contact_name": "Ann Lee",
+		"description":  "Industrial quokka sensors since 1999.",
+		"website_url":  "https://acme.example",
+		"tier_id":      fmt.Sprint(gold.ID),
+	}
+	rec = submit(link, fields, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "quokka sensors") {
+		t.Fatalf("tier change without evidence: %d", rec.Code)
+	}
+	fields["tier_evidence"] = "40 joint deployments"
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+	if rec := submit(link, fields, png); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "WAITING FOR REVIEW") {
+		t.Fatalf("submit: %d %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(visit(link).Body.String(), "SENT EARLIER") {
+		t.Error("portal does not mention the pending update")
+	}
+	if strings.Contains(visit("/partners").Body.String(), "/uploads/partners/") {
+		t.Error("update public before approval")
+	}
+
+	// The queue lists it; approval publishes it
+	pending, err := queries.GetPendingPartnerSubmission(ctx, partner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := admin(http.MethodGet, "/admin/partners/submissions", nil).Body.String(); !strings.Contains(body, "Acme Sensors") || !strings.Contains(body, "Gold Partners") {
+		t.Error("queue does not list the submission")
+	}
+	if body := admin(http.MethodGet, fmt.Sprintf("/admin/partners/submissions/%d", pending.ID), nil).Body.String(); !strings.Contains(body, "40 joint deployments") {
+		t.Error("detail does not show the tier evidence")
+	}
+	review := fmt.Sprintf("/admin/partners/submissions/%d/review", pending.ID)
+	if rec := admin(http.MethodPost, review, url.Values{"action": {"approve"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("approve: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := admin(http.MethodPost, review, url.Values{"action": {"reject"}}); rec.Header().Get("Location") != fmt.Sprintf("/admin/partners/submissions/%d", pending.ID) || !strings.Contains(rec.Header().Get("HX-Trigger"), "already been reviewed") {
+		t.Error("second review not refused")
+	}
+	p, _ := queries.GetPartner(ctx, partner.ID)
+	if p.TierID != gold.ID || p.Description.String != "Industrial quokka sensors since 1999." || p.LogoUrl != (sql.NullString{String: pending.LogoUrl, Valid: true}) {
+		t.Errorf("approved partner = %+v", p)
+	}
+	if !strings.Contains(visit("/partners").Body.String(), pending.LogoUrl) {
+		t.Error("/partners does not show the new logo")
+	}
+
+	// A revoked link stops working
+	invites, _ := queries.ListPartnerInvites(ctx, partner.ID)
+	if rec := admin(http.MethodPost, fmt.Sprintf("/admin/partners/invites/%d/revoke", invites[0].ID), url.Values{}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("revoke: %d", rec.Code)
+	}
+	if !strings.Contains(admin(http.MethodGet, portalPage, nil).Body.String(), "Revoked") {
+		t.Error("portal page does not show the revoked link")
+	}
+	if !strings.Contains(visit(link).Body.String(), "LINK IS INVALID") {
+		t.Error("revoked link still opens the form")
+	}
+}
@@ -0,0 +1,109 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSpecSheet_E2E downloads the PDF datasheet of a product and its
+// variant, checks that it is cached until the product changes and that
+// unpublished products have none.
+func TestSpecSheet_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+	productSvc := services.NewProductService(queries)
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, logger, productSvc, appCache)
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+	sheets := publicHandlers.NewSpecSheetHandler(logger, productSvc, services.NewSpecSheets(t.TempDir()), appCache, "https://example.com/")
+	publicGroup.GET("/products/:category/:slug/spec-sheet.pdf", sheets.SpecSheet)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "sensors", SortOrder: 1,
+	})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "TS-100", Slug: "ts-100", Name: "TS 100", Description: "Thermal sensor", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: product.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V DC"})
+	variant, _ := queries.CreateProductVariant(ctx, sqlc.CreateProductVariantParams{ProductID: product.ID, Sku: "TS-100-HV", Name: "230V AC"})
+	queries.UpsertProductVariantSpec(ctx, sqlc.UpsertProductVariantSpecParams{VariantID: variant.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "230V AC"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "TS-200", Slug: "ts-200", Name: "TS 200", Description: "Draft", CategoryID: cat.ID, Status: "draft",
+	})
+
+	get := func(path string, wantStatus int) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec
+	}
+
+	rec := get("/products/sensors/ts-100/spec-sheet.pdf", http.StatusOK)
+	if ct := rec.Header().Get(echo.HeaderContentType); ct != "application/pdf" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cd := rec.Header().Get(echo.HeaderContentDisposition); cd != `inline; filename="ts-100-spec-sheet.pdf"` {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	if rec.Header().Get("X-Robots-Tag") != "noindex" {
+		t.Error("datasheet is indexable")
+	}
+	pdf := rec.Body.Bytes()
+	for _, want := range []string{"%PDF-1.4", "(TS 100) Tj", "(24V DC) Tj", "(https://example.com/products/sensors/ts-100) Tj"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("datasheet lacks %q", want)
+		}
+	}
+	hv := get("/products/sensors/ts-100/spec-sheet.pdf?variant=TS-100-HV", http.StatusOK).Body.Bytes()
+	if !bytes.Contains(hv, []byte("(SKU TS-100-HV) Tj")) || !bytes.Contains(hv, []byte("(230V AC) Tj")) {
+		t.Error("variant datasheet lacks the variant's SKU and specs")
+	}
+
+	// Cached until the product's pages are invalidated
+	queries.UpdateProduct(ctx, sqlc.UpdateProductParams{Sku: "TS-100", Slug: "ts-100", Name: "TS 100 Mk2", Description: "Thermal sensor", CategoryID: cat.ID, Status: "published", ID: product.ID})
+	if !bytes.Equal(get("/products/sensors/ts-100/spec-sheet.pdf", http.StatusOK).Body.Bytes(), pdf) {
+		t.Error("datasheet not served from the cache")
+	}
+	appCache.DeleteTagged(services.ProductTag(product.ID))
+	if !bytes.Contains(get("/products/sensors/ts-100/spec-sheet.pdf", http.StatusOK).Body.Bytes(), []byte("(TS 100 Mk2) Tj")) {
+		t.Error("datasheet not regenerated after the product changed")
+	}
+
+	// Only published products in their own category have one
+	get("/products/sensors/ts-200/spec-sheet.pdf", http.StatusNotFound)
+	get("/products/other/ts-100/spec-sheet.pdf", http.StatusNotFound)
+	get("/products/sensors/ts-100/spec-sheet.pdf?variant=NOPE", http.StatusNotFound)
+
+	// The product page links to it
+	if body := get("/products/sensors/ts-100", http.StatusOK).Body.String(); !strings.Contains(body, `href="/products/sensors/ts-100/spec-sheet.pdf"`) {
+		t.Error("product page does not link to the datasheet")
+	}
+}
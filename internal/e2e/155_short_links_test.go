@@ -0,0 +1,132 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestShortLinks_E2E creates a campaign link in the admin, follows it as a
+// visitor and a crawler, and checks the UTM tagging, click count, expiry
+// and deletion.
+func TestShortLinks_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	adminCookie := loginAndGetCookie(t, e)
+	e.Renderer = templates.NewRenderer("templates")
+
+	admin := func(method, target string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if form != nil {
+			req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, target, nil)
+			req.Header.Set("HX-Request", "true")
+		}
+		req.AddCookie(adminCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	follow := func(code, userAgent string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/go/"+code, nil)
+		req.Header.Set("User-Agent", userAgent)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	const browser = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36"
+
+	// Invalid input is reported on the form
+	rec := admin(http.MethodPost, "/admin/short-links", url.Values{"code": {"fair"}, "destination": {"/"}, "utm_campaign": {"spring"}})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "Enter a UTM source") {
+		t.Fatalf("campaign without source: %d", rec.Code)
+	}
+
+	rec = admin(http.MethodPost, "/admin/short-links", url.Values{
+		"code": {"Fair26"}, "destination": {"/products?ref=flyer#specs"}, "note": {"Trade fair flyer"},
+		"utm_source": {"expo"}, "utm_medium": {"print"}, "utm_campaign": {"spring 26"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: expected 303, got %d: %s", rec.Code, rec.Body.String())
+	}
+	link, err := queries.GetShortLinkByCode(ctx, "fair26")
+	if err != nil {
+		t.Fatalf("link not saved with a lowercase code: %v", err)
+	}
+	if rec := admin(http.MethodPost, "/admin/short-links", url.Values{"code": {"fair26"}, "destination": {"/about"}}); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("duplicate code: expected 422, got %d", rec.Code)
+	}
+
+	// Visitors are counted and sent on with the UTM parameters
+	rec = follow("FAIR26", browser)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("follow: expected 302, got %d", rec.Code)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/products?ref=flyer&utm_campaign=spring+26&utm_medium=print&utm_source=expo#specs" {
+		t.Errorf("Location = %q", loc)
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Error("redirect may be cached, hiding clicks")
+	}
+	if rec := follow("fair26", "Googlebot/2.1 (+http://www.google.com/bot.html)"); rec.Code != http.StatusFound {
+		t.Errorf("crawler: expected 302, got %d", rec.Code)
+	}
+	link, _ = queries.GetShortLink(ctx, link.ID)
+	if link.Clicks != 1 || !link.LastClickAt.Valid {
+		t.Errorf("clicks = %d, want 1 (crawlers not counted)", link.Clicks)
+	}
+	if body := admin(http.MethodGet, "/admin/short-links", nil).Body.String(); !strings.Contains(body, "https://example.com/go/fair26") || !strings.Contains(body, "Trade fair flyer") {
+		t.Error("list lacks the link's URL or note")
+	}
+
+	// A link saved without a code gets one
+	admin(http.MethodPost, "/admin/short-links", url.Values{"destination": {"https://example.org/webinar"}})
+	links, _ := queries.ListShortLinks(ctx)
+	if len(links) != 2 || len(links[0].Code) != 7 {
+		t.Fatalf("generated link: %+v", links)
+	}
+	if loc := follow(links[0].Code, browser).Header().Get("Location"); loc != "https://example.org/webinar" {
+		t.Errorf("generated link redirects to %q", loc)
+	}
+
+	// Expired links answer 410 and are not counted; the count survives edits
+	yesterday := time.Now().Add(-48 * time.Hour).Format("2006-01-02")
+	rec = admin(http.MethodPost, "/admin/short-links/"+strconv.FormatInt(link.ID, 10), url.Values{
+		"code": {"fair26"}, "destination": {"/products"}, "utm_source": {"expo"}, "expires_at": {yesterday},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("update: expected 303, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := follow("fair26", browser); rec.Code != http.StatusGone || !strings.Contains(rec.Body.String(), "This link has expired.") {
+		t.Errorf("expired link: %d", rec.Code)
+	}
+	link, _ = queries.GetShortLink(ctx, link.ID)
+	if link.Clicks != 1 || link.Destination != "/products" {
+		t.Errorf("after update: clicks %d, destination %q", link.Clicks, link.Destination)
+	}
+	if !strings.Contains(admin(http.MethodGet, "/admin/short-links", nil).Body.String(), ">Expired</span>") {
+		t.Error("list does not mark the expired link")
+	}
+
+	// Deleted and unknown codes are not found
+	if rec := admin(http.MethodDelete, "/admin/short-links/"+strconv.FormatInt(link.ID, 10), nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d", rec.Code)
+	}
+	if rec := follow("fair26", browser); rec.Code != http.StatusNotFound {
+		t.Errorf("deleted link: expected 404, got %d", rec.Code)
+	}
+}
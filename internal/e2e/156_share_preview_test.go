@@ -0,0 +1,100 @@
+package e2e_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSharePreview_E2E renders the share preview of edit forms and checks
+// that it resolves the title, description, image and URL like the public
+// page, including Admin > SEO overrides, and warns about long or missing
+// metadata.
+func TestSharePreview_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	seo := services.NewSEO(queries)
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	h := adminHandlers.NewSharePreviewHandler(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), seo, "https://example.com")
+	e.POST("/admin/share-preview/:type", h.Preview)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "sensors", SortOrder: 1,
+	})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "TS-100", Slug: "ts-100", Name: "TS 100", Description: "Thermal sensor", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []sqlc.CreateSEOMetaParams{
+		{EntityType: services.PreviewProduct, EntityID: product.ID, MetaTitle: "Rugged thermal sensor", OgImage: "/uploads/ts-100.jpg"},
+		{Route: "/whitepapers/guide", MetaDescription: "A guide from the override."},
+	} {
+		if _, err := queries.CreateSEOMeta(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	seo.Reload(ctx)
+
+	preview := func(target string, form url.Values, wantStatus int) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != wantStatus {
+			t.Fatalf("POST %s: expected %d, got %d", target, wantStatus, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	// A saved product takes its item override; the site description fills in
+	body := preview("/admin/share-preview/products?id="+strconv.FormatInt(product.ID, 10), url.Values{
+		"name": {"TS 100"}, "slug": {"ts-100"}, "category_id": {strconv.FormatInt(cat.ID, 10)},
+	}, http.StatusOK)
+	for _, want := range []string{
+		"Rugged thermal sensor", `src="https://example.com/uploads/ts-100.jpg"`, "https://example.com/products/sensors/ts-100",
+		"No meta description", `hx-post="/admin/share-preview/products?id=` + strconv.FormatInt(product.ID, 10) + `"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("product preview lacks %q", want)
+		}
+	}
+
+	// A new post shows its own title with the site name, cut where too long
+	title := strings.Repeat("Sensor ", 10)
+	body = preview("/admin/share-preview/blog-posts", url.Values{"title": {title}, "slug": {"sensors"}, "meta_description": {"Short."}}, http.StatusOK)
+	if !strings.Contains(body, "The title is 95 characters; Google shows about 60.") || !strings.Contains(body, "No image: X shows a plain link.") {
+		t.Error("blog post preview lacks its warnings")
+	}
+	if !strings.Contains(body, `class="text-xs text-red-600 font-bold">Title 95/60`) {
+		t.Error("long title not flagged")
+	}
+
+	// Path overrides apply to new items too
+	body = preview("/admin/share-preview/whitepapers", url.Values{"title": {"Guide"}, "slug": {"guide"}}, http.StatusOK)
+	if !strings.Contains(body, "A guide from the override.") || !strings.Contains(body, "Guide - BlueJay Innovative Labs") {
+		t.Error("whitepaper preview ignores the path override")
+	}
+
+	preview("/admin/share-preview/events", url.Values{}, http.StatusNotFound)
+}
@@ -0,0 +1,63 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestSiteFormat_E2E covers the number format of Global Settings: the
+// formatting locale and currency are saved together, applied to the
+// template functions at once, and kept when either is invalid.
+func TestSiteFormat_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	t.Cleanup(func() { services.SetSiteFormat(services.DefaultFormatLocale, services.DefaultCurrency) })
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+	do := func(method, path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if settings, _ := queries.GetSettings(ctx); settings.FormatLocale != "en-US" || settings.Currency != "USD" {
+		t.Fatalf("default format = %q %q, want en-US USD", settings.FormatLocale, settings.Currency)
+	}
+
+	// Codes are saved upper case and applied immediately
+	form := url.Values{"site_name": {"BlueJay"}, "timezone": {"UTC"}, "format_locale": {"de-DE"}, "currency": {"eur"}}
+	if rec := do(http.MethodPost, "/admin/settings", form); rec.Code != http.StatusSeeOther {
+		t.Fatalf("save settings: %d", rec.Code)
+	}
+	settings, _ := queries.GetSettings(ctx)
+	if settings.FormatLocale != "de-DE" || settings.Currency != "EUR" || services.SiteFormat().Locale.String() != "de-DE" {
+		t.Fatalf("format not applied: saved=%q %q active=%v", settings.FormatLocale, settings.Currency, services.SiteFormat().Locale)
+	}
+	e.Renderer = templates.NewRenderer("templates")
+	if body := do(http.MethodGet, "/admin/settings", nil).Body.String(); !strings.Contains(body, "Example: 1.234,50\u00a0€ · 12,3k") {
+		t.Error("settings page does not show the format example")
+	}
+
+	// An unknown currency keeps both saved values
+	form.Set("format_locale", "fr-FR")
+	form.Set("currency", "XYZ")
+	do(http.MethodPost, "/admin/settings", form)
+	if settings, _ := queries.GetSettings(ctx); settings.FormatLocale != "de-DE" || settings.Currency != "EUR" {
+		t.Errorf("invalid format should keep the saved values, got %q %q", settings.FormatLocale, settings.Currency)
+	}
+}
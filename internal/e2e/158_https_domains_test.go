@@ -0,0 +1,87 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestHTTPSDomains_E2E saves the host names of the built-in HTTPS server on
+// the HTTPS Domains page: invalid names are refused, saved names replace
+// TLS_DOMAINS in the certificate host policy, and an empty list falls back
+// to it again.
+func TestHTTPSDomains_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	https := adminHandlers.NewHTTPSDomainsHandler(queries, testLogger, []string{"env.example.com"}, true)
+	adminGroup.GET("/https", https.Edit, customMiddleware.RequireRole("admin"))
+	adminGroup.POST("/https", https.Update, customMiddleware.RequireRole("admin"))
+
+	session := loginTabsAdmin(t, e, queries)
+	serve := func(method string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, "/admin/https", strings.NewReader(form.Encode()))
+		if form != nil {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		req.AddCookie(session)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	policy := services.TLSHostPolicy(queries, []string{"env.example.com"})
+
+	if body := serve(http.MethodGet, nil).Body.String(); !strings.Contains(body, "env.example.com") {
+		t.Error("page does not show the TLS_DOMAINS fallback")
+	}
+
+	rec := serve(http.MethodPost, url.Values{"domains": {"example.com\nhttps://www.example.com"}})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "not a host name") {
+		t.Errorf("invalid host name: %d, want the form again with the error", rec.Code)
+	}
+	if err := policy(ctx, "env.example.com"); err != nil {
+		t.Errorf("refused form changed the host policy: %v", err)
+	}
+
+	if rec := serve(http.MethodPost, url.Values{"domains": {" Example.com, www.example.com \r\n"}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("save: %d", rec.Code)
+	}
+	settings, _ := queries.GetSettings(ctx)
+	if settings.TlsDomains != "example.com\nwww.example.com" {
+		t.Errorf("saved host names = %q", settings.TlsDomains)
+	}
+	if err := policy(ctx, "www.example.com"); err != nil {
+		t.Errorf("saved host refused: %v", err)
+	}
+	if err := policy(ctx, "env.example.com"); err == nil {
+		t.Error("TLS_DOMAINS still allowed after saving host names")
+	}
+
+	if rec := serve(http.MethodPost, url.Values{"domains": {""}}); rec.Code != http.StatusSeeOther {
+		t.Fatalf("clear: %d", rec.Code)
+	}
+	if err := policy(ctx, "env.example.com"); err != nil {
+		t.Errorf("TLS_DOMAINS not used after clearing the list: %v", err)
+	}
+}
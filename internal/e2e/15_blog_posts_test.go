@@ -2,7 +2,6 @@ package e2e_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -145,9 +144,12 @@ func TestBlogPosts_Delete(t *testing.T) {
 		t.Errorf("expected 200, got %d", rec.Code)
 	}
 
-	_, err := queries.GetBlogPost(ctx, post.ID)
-	if err != sql.ErrNoRows {
-		t.Errorf("expected ErrNoRows after delete, got %v", err)
+	trashed, err := queries.GetBlogPost(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("expected the post to be kept in the trash: %v", err)
+	}
+	if !trashed.DeletedAt.Valid {
+		t.Error("expected deleted_at to be set")
 	}
 }
 
@@ -204,9 +204,24 @@ func TestBlogPostTags_DeletePostClearsTags(t *testing.T) {
 		t.Errorf("expected 200, got %d", rec.Code)
 	}
 
+	// Trashed posts keep their tags so a restore brings them back
 	postTags, _ := queries.GetPostTagsByPostID(ctx, post.ID)
+	if len(postTags) != 1 {
+		t.Errorf("expected 1 tag after moving the post to the trash, got %d", len(postTags))
+	}
+
+	// Deleting the post permanently clears them
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/trash/blog_post/%d/delete", post.ID), nil)
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("expected 303, got %d", rec.Code)
+	}
+
+	postTags, _ = queries.GetPostTagsByPostID(ctx, post.ID)
 	if len(postTags) != 0 {
-		t.Errorf("expected 0 tags after post deletion, got %d", len(postTags))
+		t.Errorf("expected 0 tags after permanent deletion, got %d", len(postTags))
 	}
 }
 
@@ -245,6 +260,7 @@ func TestBlogPostTags_QuickCreateFromPostForm(t *testing.T) {
 	}.Encode()))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
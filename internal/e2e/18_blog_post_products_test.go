@@ -237,9 +237,24 @@ func TestBlogPostProducts_DeletePostClearsProducts(t *testing.T) {
 		t.Errorf("expected 200, got %d", rec.Code)
 	}
 
+	// Trashed posts keep their products so a restore brings them back
 	postProducts, _ := queries.GetPostProductsByPostID(ctx, post.ID)
+	if len(postProducts) != 1 {
+		t.Errorf("expected 1 product after moving the post to the trash, got %d", len(postProducts))
+	}
+
+	// Deleting the post permanently clears them
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/trash/blog_post/%d/delete", post.ID), nil)
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("expected 303, got %d", rec.Code)
+	}
+
+	postProducts, _ = queries.GetPostProductsByPostID(ctx, post.ID)
 	if len(postProducts) != 0 {
-		t.Errorf("expected 0 products after post deletion, got %d", len(postProducts))
+		t.Errorf("expected 0 products after permanent deletion, got %d", len(postProducts))
 	}
 }
 
@@ -2,7 +2,6 @@ package e2e_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -136,8 +135,11 @@ func TestSolutionDelete(t *testing.T) {
 		t.Errorf("expected 204, got %d", rec.Code)
 	}
 
-	_, err := queries.GetSolutionByID(ctx, sol.ID)
-	if err != sql.ErrNoRows {
-		t.Errorf("expected ErrNoRows after delete, got %v", err)
+	trashed, err := queries.GetSolutionByID(ctx, sol.ID)
+	if err != nil {
+		t.Fatalf("expected the solution to be kept in the trash: %v", err)
+	}
+	if !trashed.DeletedAt.Valid {
+		t.Error("expected deleted_at to be set")
 	}
 }
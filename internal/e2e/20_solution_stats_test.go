@@ -33,6 +33,7 @@ func TestSolutionStatsAdd(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -74,6 +75,7 @@ func TestSolutionStatsDelete(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/solutions/%d/stats/%d", sol.ID, stat.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -113,6 +115,7 @@ func TestSolutionStatsMultiple(t *testing.T) {
 		}.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
 		rec := httptest.NewRecorder()
 		e.ServeHTTP(rec, req)
 
@@ -34,6 +34,7 @@ func TestSolutionChallengesAdd(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -72,6 +73,7 @@ func TestSolutionChallengesDelete(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/solutions/%d/challenges/%d", sol.ID, challenge.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -114,6 +116,7 @@ func TestSolutionChallengesMultiple(t *testing.T) {
 		}.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
 		rec := httptest.NewRecorder()
 		e.ServeHTTP(rec, req)
 
@@ -38,6 +38,7 @@ func TestSolutionCTAsAdd(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -79,6 +80,7 @@ func TestSolutionCTAsDelete(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/solutions/%d/ctas/%d", sol.ID, cta.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -118,6 +120,7 @@ func TestSolutionCTAsMultiple(t *testing.T) {
 		}.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
 		rec := httptest.NewRecorder()
 		e.ServeHTTP(rec, req)
 
@@ -152,6 +155,7 @@ func TestSolutionCTAsOptionalFields(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
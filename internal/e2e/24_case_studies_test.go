@@ -2,7 +2,6 @@ package e2e_test
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -187,8 +186,11 @@ func TestCaseStudyDelete(t *testing.T) {
 		t.Errorf("expected 204, got %d", rec.Code)
 	}
 
-	_, err := queries.AdminGetCaseStudy(ctx, cs.ID)
-	if err != sql.ErrNoRows {
-		t.Errorf("expected ErrNoRows after delete, got %v", err)
+	trashed, err := queries.AdminGetCaseStudy(ctx, cs.ID)
+	if err != nil {
+		t.Fatalf("expected the case study to be kept in the trash: %v", err)
+	}
+	if !trashed.DeletedAt.Valid {
+		t.Error("expected deleted_at to be set")
 	}
 }
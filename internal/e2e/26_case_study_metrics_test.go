@@ -43,6 +43,7 @@ func TestCaseStudyMetricsAdd(t *testing.T) {
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -94,6 +95,7 @@ func TestCaseStudyMetricsDelete(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/case-studies/%d/metrics/%d", cs.ID, metric.ID), nil)
 	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
 	rec := httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
@@ -145,6 +147,7 @@ func TestCaseStudyMetricsMultiple(t *testing.T) {
 		}.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
 		rec := httptest.NewRecorder()
 		e.ServeHTTP(rec, req)
 
@@ -193,6 +196,7 @@ func TestCaseStudyMetricsVariousFormats(t *testing.T) {
 		}.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
 		rec := httptest.NewRecorder()
 		e.ServeHTTP(rec, req)
 
@@ -19,16 +19,17 @@ func TestHomepageHeroesCRUD_E2E(t *testing.T) {
 	cookie := loginAndGetCookie(t, e)
 
 	req := httptest.NewRequest(http.MethodPost, "/admin/homepage/heroes", strings.NewReader(url.Values{
-		"headline":           {"Test Hero"},
-		"subheadline":        {"Test Subheadline"},
-		"badge_text":         {"New"},
-		"primary_cta_text":   {"Learn More"},
-		"primary_cta_url":    {"/about"},
-		"secondary_cta_text": {"Contact Us"},
-		"secondary_cta_url":  {"/contact"},
-		"background_image":   {"/images/hero.jpg"},
-		"is_active":          {"on"},
-		"display_order":      {"1"},
+		"headline":             {"Test Hero"},
+		"subheadline":          {"Test Subheadline"},
+		"badge_text":           {"New"},
+		"primary_cta_text":     {"Learn More"},
+		"primary_cta_url":      {"/about"},
+		"secondary_cta_text":   {"Contact Us"},
+		"secondary_cta_url":    {"/contact"},
+		"background_image":     {"/images/hero.jpg"},
+		"background_image_alt": {"Engineers on the factory floor"},
+		"is_active":            {"on"},
+		"display_order":        {"1"},
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
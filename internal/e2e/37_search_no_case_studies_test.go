@@ -84,7 +84,7 @@ func TestSearch_OmitsCaseStudies(t *testing.T) {
 	e := echo.New()
 	e.HideBanner = true
 	e.Renderer = templates.NewRenderer("templates")
-	searchHandler := publicHandlers.NewSearchHandler(db, logger)
+	searchHandler := publicHandlers.NewSearchHandler(db, logger, nil)
 	pub := e.Group("", customMiddleware.SettingsLoader(queries))
 	pub.GET("/search", searchHandler.SearchPage)
 
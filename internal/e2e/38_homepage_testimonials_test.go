@@ -19,14 +19,15 @@ func TestHomepageTestimonialsCRUD_E2E(t *testing.T) {
 	cookie := loginAndGetCookie(t, e)
 
 	req := httptest.NewRequest(http.MethodPost, "/admin/homepage/testimonials", strings.NewReader(url.Values{
-		"quote":          {"Great product and service!"},
-		"author_name":    {"John Doe"},
-		"author_title":   {"CTO"},
-		"author_company": {"Tech Corp"},
-		"author_image":   {"/images/john.jpg"},
-		"rating":         {"5"},
-		"display_order":  {"1"},
-		"is_active":      {"on"},
+		"quote":            {"Great product and service!"},
+		"author_name":      {"John Doe"},
+		"author_title":     {"CTO"},
+		"author_company":   {"Tech Corp"},
+		"author_image":     {"/images/john.jpg"},
+		"author_image_alt": {"Portrait of John Doe"},
+		"rating":           {"5"},
+		"display_order":    {"1"},
+		"is_active":        {"on"},
 	}.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.AddCookie(cookie)
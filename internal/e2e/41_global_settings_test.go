@@ -44,8 +44,11 @@ func TestGlobalSettingsUpdate_E2E(t *testing.T) {
 	}
 
 	loc := rec.Header().Get("Location")
-	if !strings.Contains(loc, "saved=1") {
-		t.Errorf("expected redirect with saved=1, got %q", loc)
+	if strings.Contains(loc, "saved=") {
+		t.Errorf("expected no saved flag in the redirect, got %q", loc)
+	}
+	if len(rec.Result().Cookies()) == 0 {
+		t.Error("expected the success flash in the session cookie")
 	}
 	if !strings.Contains(loc, "tab=general") {
 		t.Errorf("expected redirect with tab=general, got %q", loc)
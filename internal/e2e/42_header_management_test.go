@@ -57,8 +57,11 @@ func TestHeaderSettingsUpdate_E2E(t *testing.T) {
 	}
 
 	loc := rec.Header().Get("Location")
-	if !strings.Contains(loc, "saved=1") {
-		t.Errorf("expected redirect with saved=1, got %q", loc)
+	if loc != "/admin/header" {
+		t.Errorf("expected redirect to /admin/header, got %q", loc)
+	}
+	if len(rec.Result().Cookies()) == 0 {
+		t.Error("expected the success flash in the session cookie")
 	}
 
 	settings, _ := queries.GetSettings(ctx)
@@ -69,6 +69,7 @@ func TestMediaUpload(t *testing.T) {
 		writer := multipart.NewWriter(body)
 		part, _ := writer.CreateFormFile("files", "test.jpg")
 		io.WriteString(part, "fake image data")
+		writer.WriteField("alt_text", "Test image")
 		writer.Close()
 
 		req := httptest.NewRequest(http.MethodPost, "/admin/media/upload", body)
@@ -182,6 +182,7 @@ func TestNavigationDeleteItem(t *testing.T) {
 	t.Run("delete navigation item", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/navigation/items/%d", item.ID), nil)
 		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
 		rec := httptest.NewRecorder()
 		app.ServeHTTP(rec, req)
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
 )
 
 func TestPublicSolutionDetail(t *testing.T) {
@@ -71,7 +72,7 @@ func TestPublicSolutionDetail_PreviewMode(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	_, err := queries.CreateSolution(ctx, sqlc.CreateSolutionParams{
+	solution, err := queries.CreateSolution(ctx, sqlc.CreateSolutionParams{
 		Title:            "Draft Solution",
 		Slug:             "draft-solution",
 		ShortDescription: "Draft only",
@@ -97,7 +98,7 @@ func TestPublicSolutionDetail_PreviewMode(t *testing.T) {
 		t.Errorf("draft without preview should return 404, got %d", rec.Code)
 	}
 
-	// Preview mode requires authentication + ?preview=true
+	// ?preview=true no longer reveals drafts, even to a logged-in admin
 	createTestAdmin(t, queries)
 	cookie := loginAndGetCookie(t, e)
 
@@ -106,6 +107,15 @@ func TestPublicSolutionDetail_PreviewMode(t *testing.T) {
 	rec = httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("?preview=true should not show drafts, got %d", rec.Code)
+	}
+
+	// A signed preview link shows the draft without logging in
+	req = httptest.NewRequest(http.MethodGet, "/solutions/draft-solution?preview_token="+testPreviewTokens.Issue(services.PreviewSolution, solution.ID), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
 	if rec.Code == http.StatusNotFound {
 		t.Error("preview mode should show draft solutions")
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
 )
 
 func TestPublicBlogPost(t *testing.T) {
@@ -222,7 +223,7 @@ func TestPublicBlogPost_PreviewMode(t *testing.T) {
 		SortOrder:   0,
 	})
 
-	_, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+	post, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
 		Title:      "Draft Post",
 		Slug:       "draft-post",
 		Excerpt:    "Draft excerpt",
@@ -243,7 +244,7 @@ func TestPublicBlogPost_PreviewMode(t *testing.T) {
 		t.Errorf("draft post should return 404, got %d", rec.Code)
 	}
 
-	// Preview mode requires authentication + ?preview=true
+	// ?preview=true no longer reveals drafts, even to a logged-in admin
 	createTestAdmin(t, queries)
 	cookie := loginAndGetCookie(t, e)
 
@@ -252,6 +253,15 @@ func TestPublicBlogPost_PreviewMode(t *testing.T) {
 	rec = httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("?preview=true should not show drafts, got %d", rec.Code)
+	}
+
+	// A signed preview link shows the draft without logging in
+	req = httptest.NewRequest(http.MethodGet, "/blog/draft-post?preview_token="+testPreviewTokens.Issue(services.PreviewBlogPost, post.ID), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
 	if rec.Code == http.StatusNotFound {
 		t.Error("preview mode should show draft posts")
 	}
@@ -464,7 +464,7 @@ func TestPublicCaseStudyDetail_PreviewMode(t *testing.T) {
 		Slug: "technology",
 	})
 
-	_, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+	cs, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
 		Slug:             "draft-case-study",
 		Title:            "Draft Case Study",
 		ClientName:       "Tech Startup",
@@ -495,7 +495,7 @@ func TestPublicCaseStudyDetail_PreviewMode(t *testing.T) {
 		t.Errorf("draft should return 404, got %d", rec.Code)
 	}
 
-	// Preview mode requires authentication + ?preview=true
+	// ?preview=true no longer reveals drafts, even to a logged-in admin
 	createTestAdmin(t, queries)
 	cookie := loginAndGetCookie(t, e)
 
@@ -504,6 +504,15 @@ func TestPublicCaseStudyDetail_PreviewMode(t *testing.T) {
 	rec = httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("?preview=true should not show drafts, got %d", rec.Code)
+	}
+
+	// A signed preview link shows the draft without logging in
+	req = httptest.NewRequest(http.MethodGet, "/case-studies/draft-case-study?preview_token="+testPreviewTokens.Issue(services.PreviewCaseStudy, cs.ID), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
 	if rec.Code == http.StatusNotFound {
 		t.Error("preview mode should show draft case studies")
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
 )
 
 func TestPublicWhitepapersList(t *testing.T) {
@@ -201,7 +202,7 @@ func TestPublicWhitepaperDetail_PreviewMode(t *testing.T) {
 		Slug: "draft-topic",
 	})
 
-	_, err := queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+	wp, err := queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
 		Title:          "Draft Whitepaper",
 		Slug:           "draft-whitepaper",
 		Description:    "Draft description",
@@ -227,7 +228,7 @@ func TestPublicWhitepaperDetail_PreviewMode(t *testing.T) {
 		t.Errorf("draft should return 404, got %d", rec.Code)
 	}
 
-	// Preview mode requires authentication + ?preview=true
+	// ?preview=true no longer reveals drafts, even to a logged-in admin
 	createTestAdmin(t, queries)
 	cookie := loginAndGetCookie(t, e)
 
@@ -236,6 +237,15 @@ func TestPublicWhitepaperDetail_PreviewMode(t *testing.T) {
 	rec = httptest.NewRecorder()
 	e.ServeHTTP(rec, req)
 
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("?preview=true should not show drafts, got %d", rec.Code)
+	}
+
+	// A signed preview link shows the draft without logging in
+	req = httptest.NewRequest(http.MethodGet, "/whitepapers/draft-whitepaper?preview_token="+testPreviewTokens.Issue(services.PreviewWhitepaper, wp.ID), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
 	if rec.Code == http.StatusNotFound {
 		t.Error("preview mode should show draft whitepapers")
 	}
@@ -0,0 +1,191 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestTranslationWorkflow covers the localization workflow end to end with the
+// REAL renderer: the dashboard reports a missing translation, saving every field
+// marks it complete, and the public product page renders the translation for
+// ?lang=de while the default locale keeps the source text.
+func TestTranslationWorkflow(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SecurityHeaders())
+	e.Use(customMiddleware.SessionMiddleware())
+
+	appCache := services.NewCache()
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, logger))
+	translationSvc := services.NewTranslationService(queries, logger, "en", []string{"de"})
+	publicHandlers.SetTranslationService(translationSvc)
+	t.Cleanup(func() { publicHandlers.SetTranslationService(nil) })
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	publicGroup.Use(customMiddleware.Locale(translationSvc))
+	productsHandler := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products/:category/:slug", productsHandler.ProductDetail)
+
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.GET("/admin/login", authHandler.ShowLoginPage)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	translationsHandler := adminHandlers.NewTranslationsHandler(queries, logger, translationSvc, appCache)
+	adminGroup.GET("/translations", translationsHandler.Dashboard)
+	adminGroup.GET("/translations/:type/:id/:locale", translationsHandler.Edit)
+	adminGroup.POST("/translations/:type/:id/:locale", translationsHandler.Save)
+	adminGroup.POST("/translations/:type/:id/:locale/machine", translationsHandler.MachineTranslate)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Detectors", Slug: "detectors", Description: "Detection equipment", Icon: "radar", SortOrder: 1,
+	})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku:         "DET-001",
+		Slug:        "alpha-detector",
+		Name:        "Alpha Detector",
+		Description: "High-precision alpha particle detector",
+		CategoryID:  cat.ID,
+		Status:      "published",
+		Tagline:     sql.NullString{String: "Precision Detection", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+
+	cookie := loginTabsAdmin(t, e, queries)
+	get := func(path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("dashboard lists untranslated product as missing", func(t *testing.T) {
+		rec := get("/admin/translations?locale=de&type=product", cookie)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "Alpha Detector") || !strings.Contains(body, "Missing") {
+			t.Errorf("expected Alpha Detector listed as Missing")
+		}
+		if !strings.Contains(body, `data-path="/admin/translations"`) {
+			t.Errorf("expected Translations link in the admin sidebar")
+		}
+	})
+
+	t.Run("unknown type or locale returns 404", func(t *testing.T) {
+		if rec := get("/admin/translations/widget/1/de", cookie); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for unknown type, got %d", rec.Code)
+		}
+		if rec := get("/admin/translations/product/1/xx", cookie); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for unsupported locale, got %d", rec.Code)
+		}
+	})
+
+	editPath := "/admin/translations/product/" + strconv.FormatInt(product.ID, 10) + "/de"
+
+	t.Run("edit form shows source fields", func(t *testing.T) {
+		rec := get(editPath, cookie)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), "Precision Detection") {
+			t.Errorf("expected source tagline in editor")
+		}
+	})
+
+	t.Run("machine translate requires a configured provider", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, editPath+"/machine", nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 without a provider, got %d", rec.Code)
+		}
+		if strings.Contains(get(editPath, cookie).Body.String(), "Machine Translate") {
+			t.Errorf("machine translate action should be hidden when not configured")
+		}
+	})
+
+	t.Run("public page renders source before translation", func(t *testing.T) {
+		rec := get("/products/detectors/alpha-detector?lang=de")
+		if !strings.Contains(rec.Body.String(), "Alpha Detector") {
+			t.Errorf("expected source name while translation is missing")
+		}
+	})
+
+	t.Run("saving all fields marks translation complete", func(t *testing.T) {
+		form := url.Values{
+			"name":        {"Alpha-Detektor"},
+			"tagline":     {"Präzise Erkennung"},
+			"description": {"Hochpräziser Alphateilchen-Detektor"},
+		}
+		req := httptest.NewRequest(http.MethodPost, editPath, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", rec.Code)
+		}
+
+		row, err := queries.GetTranslation(ctx, sqlc.GetTranslationParams{EntityType: "product", EntityID: product.ID, Locale: "de"})
+		if err != nil {
+			t.Fatalf("GetTranslation: %v", err)
+		}
+		if row.Status != services.TranslationComplete {
+			t.Errorf("expected status complete, got %q", row.Status)
+		}
+	})
+
+	t.Run("public page renders translation for requested locale", func(t *testing.T) {
+		rec := get("/products/detectors/alpha-detector?lang=de")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "Alpha-Detektor") {
+			t.Errorf("expected translated product name")
+		}
+		if !strings.Contains(body, `lang="de"`) {
+			t.Errorf("expected <html lang=\"de\">")
+		}
+
+		rec = get("/products/detectors/alpha-detector")
+		if strings.Contains(rec.Body.String(), "Alpha-Detektor") {
+			t.Errorf("default locale should render the source name")
+		}
+	})
+}
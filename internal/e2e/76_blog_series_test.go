@@ -0,0 +1,173 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestBlogSeriesWorkflow creates a series in the admin, assigns three posts to
+// it (out of order) and checks with the REAL renderer that each public post page
+// shows its part number and the correct prev/next links.
+func TestBlogSeriesWorkflow(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SecurityHeaders())
+	e.Use(customMiddleware.SessionMiddleware())
+
+	appCache := services.NewCache()
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, logger))
+
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	blogHandler := publicHandlers.NewBlogHandler(queries, logger, appCache)
+	publicGroup.GET("/blog/:slug", blogHandler.BlogPost)
+
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.GET("/admin/login", authHandler.ShowLoginPage)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	seriesHandler := adminHandlers.NewBlogSeriesHandler(queries, logger, appCache)
+	adminGroup.GET("/blog/series", seriesHandler.List)
+	adminGroup.POST("/blog/series", seriesHandler.Create)
+	adminGroup.GET("/blog/series/:id/edit", seriesHandler.Edit)
+	adminGroup.POST("/blog/series/:id/posts", seriesHandler.AssignPost)
+	adminGroup.DELETE("/blog/series/:id/posts/:post_id", seriesHandler.RemovePost)
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{
+		Name: "Safety", Slug: "safety", ColorHex: "#000000", SortOrder: 1,
+	})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{
+		Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1,
+	})
+	newPost := func(title, slug string) sqlc.BlogPost {
+		p, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: title, Slug: slug, Excerpt: "Excerpt", Body: "<p>Body</p>",
+			CategoryID: cat.ID, AuthorID: author.ID, Status: "published",
+			PublishedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+		return p
+	}
+	intro := newPost("Shielding Basics", "shielding-basics")
+	middle := newPost("Dose Calculations", "dose-calculations")
+	final := newPost("Field Monitoring", "field-monitoring")
+
+	cookie := loginTabsAdmin(t, e, queries)
+	do := func(method, path string, form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		var body *strings.Reader
+		if form != nil {
+			body = strings.NewReader(form.Encode())
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(method, path, body)
+		if form != nil {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		}
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/admin/blog/series", url.Values{"name": {"Radiation Safety 101"}}, cookie)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create series: expected 303, got %d", rec.Code)
+	}
+	editPath := rec.Header().Get("Location")
+	seriesPath := strings.TrimSuffix(editPath, "/edit")
+
+	// Assign out of order to prove part numbers, not insertion order, drive navigation
+	for _, a := range []struct {
+		post sqlc.BlogPost
+		part string
+	}{{final, "3"}, {intro, "1"}, {middle, "2"}} {
+		rec := do(http.MethodPost, seriesPath+"/posts", url.Values{
+			"post_id": {strconv.FormatInt(a.post.ID, 10)}, "series_order": {a.part},
+		}, cookie)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("assign %s: expected 303, got %d", a.post.Slug, rec.Code)
+		}
+	}
+
+	t.Run("admin editor lists parts in order", func(t *testing.T) {
+		rec := do(http.MethodGet, editPath, nil, cookie)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+		}
+		body := rec.Body.String()
+		i, j, k := strings.Index(body, "Shielding Basics"), strings.Index(body, "Dose Calculations"), strings.Index(body, "Field Monitoring")
+		if i < 0 || !(i < j && j < k) {
+			t.Errorf("expected posts listed in part order, got indexes %d, %d, %d", i, j, k)
+		}
+	})
+
+	t.Run("middle part links both ways", func(t *testing.T) {
+		rec := do(http.MethodGet, "/blog/dose-calculations", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "Part 2 of 3") {
+			t.Errorf("expected 'Part 2 of 3'")
+		}
+		if !strings.Contains(body, `href="/blog/shielding-basics"`) || !strings.Contains(body, `href="/blog/field-monitoring"`) {
+			t.Errorf("expected prev and next links")
+		}
+	})
+
+	t.Run("first part has no previous link", func(t *testing.T) {
+		body := do(http.MethodGet, "/blog/shielding-basics", nil).Body.String()
+		if !strings.Contains(body, "Part 1 of 3") {
+			t.Errorf("expected 'Part 1 of 3'")
+		}
+		if strings.Contains(body, "arrow_back") {
+			t.Errorf("first part should not link to a previous part")
+		}
+	})
+
+	t.Run("removed post leaves the series", func(t *testing.T) {
+		rec := do(http.MethodDelete, seriesPath+"/posts/"+strconv.FormatInt(final.ID, 10), nil, cookie)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		if body := do(http.MethodGet, "/blog/field-monitoring", nil).Body.String(); strings.Contains(body, "Radiation Safety 101") {
+			t.Errorf("removed post should not show the series box")
+		}
+		if body := do(http.MethodGet, "/blog/dose-calculations", nil).Body.String(); !strings.Contains(body, "Part 2 of 2") {
+			t.Errorf("expected series to shrink to 2 parts after removal")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+func TestBlogPosts_MarkdownAuthoring(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{
+		Name: "Engineering", Slug: "engineering", ColorHex: "#000000", SortOrder: 1,
+	})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{
+		Name: "Dev", Slug: "dev", Title: "Engineer", SortOrder: 1,
+	})
+
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	source := "## Wiring\n\nUse **shielded** cable.\n\n<script>alert(1)</script>\n"
+	form := url.Values{
+		"title":          {"Wiring Guide"},
+		"slug":           {"wiring-guide"},
+		"excerpt":        {"How to wire it"},
+		"body":           {"<p>ignored trix content</p>"},
+		"content_format": {"markdown"},
+		"body_markdown":  {source},
+		"category_id":    {fmt.Sprintf("%d", cat.ID)},
+		"author_id":      {fmt.Sprintf("%d", author.ID)},
+		"status":         {"draft"},
+	}
+	if rec := post("/admin/blog/posts", form); rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: expected 303, got %d", rec.Code)
+	}
+
+	posts, _ := queries.ListBlogPostsAdminFiltered(ctx, sqlc.ListBlogPostsAdminFilteredParams{
+		FilterStatus: "", FilterCategory: int64(0), FilterAuthor: int64(0), FilterSearch: "",
+		PageLimit: 15, PageOffset: 0,
+	})
+	if len(posts) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(posts))
+	}
+	saved, err := queries.GetBlogPost(ctx, posts[0].ID)
+	if err != nil {
+		t.Fatalf("GetBlogPost: %v", err)
+	}
+
+	t.Run("markdown is rendered and sanitized on save", func(t *testing.T) {
+		if saved.ContentFormat != "markdown" || saved.BodyMarkdown != source {
+			t.Errorf("format/source not stored: %q %q", saved.ContentFormat, saved.BodyMarkdown)
+		}
+		if !strings.Contains(saved.Body, "<strong>shielded</strong>") || strings.Contains(saved.Body, "ignored trix") {
+			t.Errorf("body should hold rendered markdown, got %q", saved.Body)
+		}
+		if strings.Contains(saved.Body, "<script") {
+			t.Errorf("raw HTML must be stripped, got %q", saved.Body)
+		}
+	})
+
+	t.Run("switching back to rich text", func(t *testing.T) {
+		form.Set("content_format", "html")
+		form.Set("body", "<p>Now in Trix</p>")
+		if rec := post(fmt.Sprintf("/admin/blog/posts/%d", saved.ID), form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("update: expected 303, got %d", rec.Code)
+		}
+		updated, _ := queries.GetBlogPost(ctx, saved.ID)
+		if updated.ContentFormat != "html" || updated.BodyMarkdown != "" || updated.Body != "<p>Now in Trix</p>" {
+			t.Errorf("unexpected post after switching: %q %q %q", updated.ContentFormat, updated.BodyMarkdown, updated.Body)
+		}
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		rec := post("/admin/blog/posts/markdown-preview", url.Values{"body_markdown": {"# Hello\n\n<iframe src=x></iframe>"}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), `<h1 id="hello">Hello</h1>`) || strings.Contains(rec.Body.String(), "<iframe") {
+			t.Errorf("unexpected preview %q", rec.Body.String())
+		}
+	})
+}
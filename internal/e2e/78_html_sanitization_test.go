@@ -0,0 +1,113 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+const xssPayload = `<p>Safe <strong>text</strong></p><script>alert(1)</script><img src="/x.png" onerror="alert(2)">`
+
+// assertSanitized fails when stored rich text still contains the payload's
+// script or event handler, or lost its legitimate formatting.
+func assertSanitized(t *testing.T, field, html string) {
+	t.Helper()
+	if strings.Contains(html, "<script") || strings.Contains(html, "onerror") {
+		t.Errorf("%s was stored unsanitized: %q", field, html)
+	}
+	if !strings.Contains(html, "<strong>text</strong>") {
+		t.Errorf("%s lost its formatting: %q", field, html)
+	}
+}
+
+func TestRichTextSanitizedOnSave(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	post := func(path string, form url.Values) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("POST %s: expected 303, got %d", path, rec.Code)
+		}
+	}
+
+	t.Run("blog post body", func(t *testing.T) {
+		cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1})
+		author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "A", Slug: "a", Title: "W", SortOrder: 1})
+		post("/admin/blog/posts", url.Values{
+			"title":       {"XSS Post"},
+			"excerpt":     {"e"},
+			"body":        {xssPayload},
+			"category_id": {fmt.Sprintf("%d", cat.ID)},
+			"author_id":   {fmt.Sprintf("%d", author.ID)},
+			"status":      {"draft"},
+		})
+		posts, _ := queries.ListBlogPostsAdminFiltered(ctx, sqlc.ListBlogPostsAdminFilteredParams{
+			FilterStatus: "", FilterCategory: int64(0), FilterAuthor: int64(0), FilterSearch: "",
+			PageLimit: 15, PageOffset: 0,
+		})
+		if len(posts) != 1 {
+			t.Fatalf("expected 1 post, got %d", len(posts))
+		}
+		saved, _ := queries.GetBlogPost(ctx, posts[0].ID)
+		assertSanitized(t, "body", saved.Body)
+	})
+
+	t.Run("solution overview", func(t *testing.T) {
+		post("/admin/solutions", url.Values{
+			"title":             {"Secure Solution"},
+			"icon":              {"shield"},
+			"short_description": {"d"},
+			"overview_content":  {xssPayload},
+		})
+		solutions, _ := queries.ListSolutionsAdminFiltered(ctx, sqlc.ListSolutionsAdminFilteredParams{
+			FilterStatus: "", FilterSearch: "", PageLimit: 100, PageOffset: 0,
+		})
+		if len(solutions) != 1 {
+			t.Fatalf("expected 1 solution, got %d", len(solutions))
+		}
+		saved, _ := queries.GetSolutionByID(ctx, solutions[0].ID)
+		assertSanitized(t, "overview_content", saved.OverviewContent.String)
+	})
+
+	t.Run("case study sections", func(t *testing.T) {
+		ind, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Tech", Slug: "tech", Description: "d", Icon: "chip", SortOrder: 1})
+		post("/admin/case-studies", url.Values{
+			"title":             {"Secure Story"},
+			"client_name":       {"Corp"},
+			"industry_id":       {fmt.Sprintf("%d", ind.ID)},
+			"summary":           {"s"},
+			"challenge_title":   {"c"},
+			"challenge_content": {xssPayload},
+			"solution_title":    {"s"},
+			"solution_content":  {xssPayload},
+			"outcome_title":     {"o"},
+			"outcome_content":   {xssPayload},
+		})
+		list, _ := queries.AdminListCaseStudiesFiltered(ctx, sqlc.AdminListCaseStudiesFilteredParams{
+			FilterSearch: "", FilterStatus: "", PageLimit: 100, PageOffset: 0,
+		})
+		if len(list) != 1 {
+			t.Fatalf("expected 1 case study, got %d", len(list))
+		}
+		saved, _ := queries.AdminGetCaseStudy(ctx, list[0].ID)
+		assertSanitized(t, "challenge_content", saved.ChallengeContent)
+		assertSanitized(t, "solution_content", saved.SolutionContent)
+		assertSanitized(t, "outcome_content", saved.OutcomeContent)
+	})
+}
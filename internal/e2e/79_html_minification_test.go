@@ -0,0 +1,117 @@
+package e2e_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestMinifyHTMLSetting verifies that enabling "Minify HTML output" in Global
+// Settings shrinks rendered public pages (with the real templates) while the
+// page content is unchanged, and that the saved page cache is rebuilt minified.
+func TestMinifyHTMLSetting(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	if err := queries.UpdateGlobalSettings(ctx, sqlc.UpdateGlobalSettingsParams{
+		SiteName:     "Minify Site",
+		ContactPhone: "555-MINIFY",
+		ContactEmail: "info@example.com",
+	}); err != nil {
+		t.Fatalf("seed settings: %v", err)
+	}
+
+	appCache := services.NewCache()
+	e := echo.New()
+	e.HideBanner = true
+	e.Renderer = templates.NewRenderer("templates")
+
+	contactHandler := publicHandlers.NewContactHandler(queries, logger, appCache)
+	e.GET("/contact", contactHandler.ShowContactPage, appmw.SettingsLoader(queries))
+	settingsHandler := adminHandlers.NewSettingsHandler(queries, logger, appCache)
+	e.POST("/admin/settings", settingsHandler.Update)
+
+	getContact := func() string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/contact", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /contact: expected 200, got %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+	saveSettings := func(minify bool) {
+		t.Helper()
+		form := url.Values{}
+		form.Set("site_name", "Minify Site")
+		form.Set("contact_phone", "555-MINIFY")
+		form.Set("contact_email", "info@example.com")
+		form.Set("active_tab", "performance")
+		if minify {
+			form.Set("minify_html", "on")
+		}
+		req := httptest.NewRequest(http.MethodPost, "/admin/settings", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("POST /admin/settings: expected 303, got %d", rec.Code)
+		}
+		if loc := rec.Header().Get("Location"); !strings.Contains(loc, "tab=performance") {
+			t.Errorf("expected redirect to keep the performance tab, got %q", loc)
+		}
+	}
+
+	plain := getContact()
+	plainLines := strings.Count(plain, "\n")
+	if plainLines < 100 {
+		t.Fatalf("precondition failed: expected an indented multi-line page, got %d lines", plainLines)
+	}
+
+	saveSettings(true)
+	settings, err := queries.GetSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetSettings: %v", err)
+	}
+	if !settings.MinifyHtml {
+		t.Fatal("expected minify_html to be saved as enabled")
+	}
+
+	minified := getContact()
+	if len(minified) >= len(plain) {
+		t.Errorf("expected minified page to be smaller: %d >= %d bytes", len(minified), len(plain))
+	}
+	// Only <script>/<style> bodies keep their line breaks
+	if lines := strings.Count(minified, "\n"); lines > plainLines/2 {
+		t.Errorf("expected inter-tag whitespace to be stripped: %d of %d lines remain", lines, plainLines)
+	}
+	if !strings.Contains(minified, "555-MINIFY") || !strings.Contains(minified, "</html>") {
+		t.Error("expected minified page to keep its content")
+	}
+	if again := getContact(); again != minified {
+		t.Error("expected the cached page to be served minified")
+	}
+
+	saveSettings(false)
+	if restored := getContact(); restored != plain {
+		t.Error("expected disabling minification to restore the original output")
+	}
+}
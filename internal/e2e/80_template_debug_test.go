@@ -0,0 +1,102 @@
+package e2e_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/database"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestTemplateDebugOverlay verifies the ?debug=templates overlay with the real
+// renderer: admins see the template, data keys, cache key/TTL, and query count;
+// HTMX requests get a comment block; anonymous visitors and the page cache
+// never see the report.
+func TestTemplateDebugOverlay(t *testing.T) {
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	queries := sqlc.New(database.CountingDB(db))
+	createTestAdmin(t, queries)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appmw.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(appmw.SessionMiddleware())
+	e.Use(appmw.TemplateDebug())
+
+	authHandler := adminHandlers.NewAuthHandler(queries, logger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	appCache := services.NewCache()
+	contactHandler := publicHandlers.NewContactHandler(queries, logger, appCache)
+	e.GET("/contact", contactHandler.ShowContactPage, appmw.SettingsLoader(queries))
+
+	get := func(target string, cookie *http.Cookie, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d", target, rec.Code)
+		}
+		return rec
+	}
+
+	// Anonymous visitors cannot enable the overlay; this also primes the cache
+	if body := get("/contact?debug=templates", nil, false).Body.String(); strings.Contains(body, "template-debug") {
+		t.Fatal("expected no debug overlay for anonymous visitors")
+	}
+
+	cookie := loginAndGetCookie(t, e)
+	rec := get("/contact?debug=templates", cookie, false)
+	body := rec.Body.String()
+	for _, want := range []string{
+		`id="template-debug"`,
+		"public/pages/contact.html",
+		"Settings (sqlc.Setting)",
+		"page:contact (TTL 3600s)",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected overlay to contain %q", want)
+		}
+	}
+	if strings.Contains(body, "&middot; 0 queries") {
+		t.Error("expected a fresh render (cache bypassed) with counted queries")
+	}
+	if i, j := strings.Index(body, "template-debug"), strings.LastIndex(body, "</body>"); i < 0 || i > j {
+		t.Error("expected the overlay inside <body>")
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "no-store" {
+		t.Errorf("expected Cache-Control no-store, got %q", cc)
+	}
+
+	// HTMX requests get the report as an HTML comment
+	if body := get("/contact?debug=templates", cookie, true).Body.String(); !strings.Contains(body, "<!-- debug=templates") || strings.Contains(body, `id="template-debug"`) {
+		t.Error("expected an HTML comment report for HTMX requests")
+	}
+
+	// The cached page must never include the overlay
+	if body := get("/contact", nil, false).Body.String(); strings.Contains(body, "template-debug") || strings.Contains(body, "debug=templates") {
+		t.Error("expected the cached page to be free of debug output")
+	}
+}
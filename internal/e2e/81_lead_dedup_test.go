@@ -0,0 +1,154 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestLeadDeduplication verifies that submissions from the same person across
+// the contact form, RFQs, and whitepaper downloads are grouped by normalized
+// email, and that the merge and unmerge actions link and split leads.
+func TestLeadDeduplication(t *testing.T) {
+	app, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, app)
+
+	contact := func(name, email, company, inquiry string) {
+		t.Helper()
+		if _, err := queries.CreateContactSubmission(ctx, sqlc.CreateContactSubmissionParams{
+			Name: name, Email: email, Phone: "555", Company: company, Message: "Hello",
+			InquiryType: sql.NullString{String: inquiry, Valid: true},
+		}); err != nil {
+			t.Fatalf("create submission: %v", err)
+		}
+	}
+	contact("Jane Doe", "Jane.Doe@Acme.com", "Acme Inc.", "contact")
+	contact("Jane Doe", "jane.doe+rfq@acme.com", "ACME", "rfq")
+	contact("Jane Doe", "jdoe@gmail.com", "Acme Ltd", "contact")
+	contact("Bob Smith", "bob@example.com", "Example", "contact")
+
+	topic, err := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: "Topic", Slug: "topic", ColorHex: "#000000"})
+	if err != nil {
+		t.Fatalf("create topic: %v", err)
+	}
+	wp, err := queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+		Title: "Sensor Guide", Slug: "sensor-guide", Description: "d", TopicID: topic.ID,
+		PdfFilePath: "/x.pdf", PublishedDate: "2025-01-01", IsPublished: 1,
+	})
+	if err != nil {
+		t.Fatalf("create whitepaper: %v", err)
+	}
+	if _, err := queries.CreateWhitepaperDownload(ctx, sqlc.CreateWhitepaperDownloadParams{
+		WhitepaperID: wp.ID, Name: "Jane Doe", Email: "JANE.DOE@acme.com", Company: "Acme",
+	}); err != nil {
+		t.Fatalf("create download: %v", err)
+	}
+
+	leadSvc := services.NewLeadService(queries)
+	leadByKey := func() map[string]*services.Lead {
+		t.Helper()
+		leads, err := leadSvc.Leads(ctx)
+		if err != nil {
+			t.Fatalf("Leads: %v", err)
+		}
+		m := map[string]*services.Lead{}
+		for _, l := range leads {
+			m[l.Key] = l
+		}
+		return m
+	}
+
+	leads := leadByKey()
+	jane := leads["jane.doe@acme.com"]
+	if jane == nil || jane.Contacts != 1 || jane.RFQs != 1 || jane.Downloads != 1 {
+		t.Fatalf("expected jane.doe@acme.com to group a contact, an RFQ, and a download; got %+v", jane)
+	}
+	if len(leads) != 3 {
+		t.Fatalf("expected 3 leads before merging, got %d", len(leads))
+	}
+
+	post := func(path string, form url.Values) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("POST %s: expected 303, got %d", path, rec.Code)
+		}
+		return rec.Header().Get("Location")
+	}
+
+	// Merging a lead into itself is rejected
+	if loc := post("/admin/leads/merge", url.Values{"primary_email": {"jane.doe@acme.com"}, "duplicate_email": {"Jane.Doe+x@acme.com"}}); loc != "/admin/leads?error=invalid" {
+		t.Errorf("expected invalid merge redirect, got %q", loc)
+	}
+
+	if loc := post("/admin/leads/merge", url.Values{"primary_email": {"jane.doe@acme.com"}, "duplicate_email": {"JDoe@gmail.com"}}); loc != "/admin/leads?merged=1" {
+		t.Errorf("expected merged redirect, got %q", loc)
+	}
+	leads = leadByKey()
+	if len(leads) != 2 || leads["jane.doe@acme.com"].Contacts != 2 || len(leads["jane.doe@acme.com"].MergedEmails) != 1 {
+		t.Fatalf("expected gmail address folded into jane.doe@acme.com, got %+v", leads["jane.doe@acme.com"])
+	}
+
+	// Merging the primary elsewhere carries its merged addresses along
+	post("/admin/leads/merge", url.Values{"primary_email": {"bob@example.com"}, "duplicate_email": {"jane.doe@acme.com"}})
+	merges, err := queries.ListLeadMerges(ctx)
+	if err != nil {
+		t.Fatalf("ListLeadMerges: %v", err)
+	}
+	for _, m := range merges {
+		if m.PrimaryEmail != "bob@example.com" {
+			t.Errorf("expected %s to point at bob@example.com, got %s", m.Email, m.PrimaryEmail)
+		}
+	}
+	post("/admin/leads/unmerge", url.Values{"email": {"jane.doe@acme.com"}})
+	post("/admin/leads/unmerge", url.Values{"email": {"jdoe@gmail.com"}})
+	if leads = leadByKey(); len(leads) != 3 {
+		t.Errorf("expected 3 leads after splitting, got %d", len(leads))
+	}
+
+	for _, target := range []string{"/admin/leads", "/admin/leads?view=all&q=acme"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s: expected 200, got %d", target, rec.Code)
+		}
+	}
+
+	// Render the real template with a suggestion and a merged lead
+	all, _ := leadSvc.Leads(ctx)
+	var buf bytes.Buffer
+	err = templates.NewRenderer("templates").Render(&buf, "admin/pages/leads_list.html", map[string]interface{}{
+		"Title": "Leads", "Leads": all, "Suggestions": services.Suggestions(all),
+		"Total": len(all), "Duplicates": 1, "View": "all", "Search": "",
+	}, nil)
+	if err != nil {
+		t.Fatalf("render leads_list: %v", err)
+	}
+	page := buf.String()
+	for _, want := range []string{"jane.doe&#43;rfq@acme.com", "Sensor Guide", "Possible Duplicates", `value="jdoe@gmail.com"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected leads page to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestProductCategoryHierarchy verifies nested category management in the
+// admin (parent selection, depth and cycle checks, delete protection) and the
+// public family → series → type pages with breadcrumbs and rolled-up products.
+func TestProductCategoryHierarchy(t *testing.T) {
+	app, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, app)
+
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+	create := func(name string, parent int64) int64 {
+		t.Helper()
+		form := url.Values{"name": {name}, "description": {name + " range"}, "icon": {"devices"}}
+		if parent != 0 {
+			form.Set("parent_id", strconv.FormatInt(parent, 10))
+		}
+		if rec := post("/admin/product-categories", form); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create %s: expected 303, got %d", name, rec.Code)
+		}
+		cat, err := queries.GetProductCategoryBySlug(ctx, makeTestSlug(name))
+		if err != nil {
+			t.Fatalf("load %s: %v", name, err)
+		}
+		return cat.ID
+	}
+
+	computers := create("Computers", 0)
+	laptops := create("Laptops", computers)
+	rugged := create("Rugged", laptops)
+
+	if cat, _ := queries.GetProductCategory(ctx, rugged); cat.ParentID.Int64 != laptops {
+		t.Fatalf("expected Rugged under Laptops, got parent %v", cat.ParentID)
+	}
+
+	// A fourth level and a cycle are both rejected with the form re-rendered
+	if rec := post("/admin/product-categories", url.Values{"name": {"Too Deep"}, "parent_id": {strconv.FormatInt(rugged, 10)}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("fourth level: expected 400, got %d", rec.Code)
+	}
+	if rec := post("/admin/product-categories/"+strconv.FormatInt(computers, 10), url.Values{"name": {"Computers"}, "parent_id": {strconv.FormatInt(rugged, 10)}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("cycle: expected 400, got %d", rec.Code)
+	}
+	if cat, _ := queries.GetProductCategory(ctx, computers); cat.ParentID.Valid {
+		t.Error("rejected update must not change the parent")
+	}
+
+	for _, p := range []struct {
+		slug string
+		cat  int64
+	}{{"field-laptop", laptops}, {"rugged-tab", rugged}} {
+		if _, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: strings.ToUpper(p.slug), Slug: p.slug, Name: p.slug, Description: "d", CategoryID: p.cat, Status: "published",
+		}); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+	if n, _ := queries.CountProductsInCategoryTree(ctx, computers); n != 2 {
+		t.Errorf("expected 2 products rolled up into Computers, got %d", n)
+	}
+
+	// Categories with subcategories cannot be deleted
+	req := httptest.NewRequest(http.MethodDelete, "/admin/product-categories/"+strconv.FormatInt(laptops, 10), nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "subcategories") {
+		t.Errorf("delete parent: expected 409 about subcategories, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/product-categories", nil)
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET list: expected 200, got %d", rec.Code)
+	}
+
+	// Admin list and form with the real templates
+	all, _ := queries.ListProductCategories(ctx)
+	tree := services.BuildCategoryTree(all, nil)
+	renderer := templates.NewRenderer("templates")
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, "admin/pages/product_categories_list.html", map[string]interface{}{
+		"Title": "Product Categories", "Items": tree.Flatten(), "MaxDepth": services.MaxCategoryDepth,
+	}, nil); err != nil {
+		t.Fatalf("render list: %v", err)
+	}
+	if !strings.Contains(buf.String(), "/admin/product-categories/new?parent="+strconv.FormatInt(laptops, 10)) ||
+		strings.Contains(buf.String(), "/admin/product-categories/new?parent="+strconv.FormatInt(rugged, 10)) {
+		t.Error("list: expected Add Sub links only below the maximum depth")
+	}
+	buf.Reset()
+	item, _ := queries.GetProductCategory(ctx, laptops)
+	if err := renderer.Render(&buf, "admin/pages/product_categories_form.html", map[string]interface{}{
+		"Title": "Edit", "FormAction": "/x", "Item": item, "ParentOptions": tree.Options(laptops, services.MaxCategoryDepth), "Error": "",
+	}, nil); err != nil {
+		t.Fatalf("render form: %v", err)
+	}
+	if !strings.Contains(buf.String(), `value="`+strconv.FormatInt(computers, 10)+`" selected`) || strings.Contains(buf.String(), ">— — Rugged<") {
+		t.Error("form: expected current parent selected and own subcategories excluded")
+	}
+
+	// Public pages with the real templates
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	e := echo.New()
+	e.Renderer = renderer
+	products := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), services.NewCache())
+	e.GET("/products", products.ProductsList, appmw.SettingsLoader(queries))
+	e.GET("/products/:category", products.ProductsByCategory, appmw.SettingsLoader(queries))
+	e.GET("/products/:category/:slug", products.ProductDetail, appmw.SettingsLoader(queries))
+
+	get := func(path string, wantStatus int) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec
+	}
+
+	// Subcategories redirect to their nested URL
+	if loc := get("/products/rugged?page=2", http.StatusMovedPermanently).Header().Get("Location"); loc != "/products/laptops/rugged?page=2" {
+		t.Errorf("expected redirect to nested URL, got %q", loc)
+	}
+
+	list := get("/products", http.StatusOK).Body.String()
+	if !strings.Contains(list, `href="/products/computers"`) || !strings.Contains(list, "2 Products") {
+		t.Error("expected /products to list Computers with products rolled up from its subcategories")
+	}
+
+	family := get("/products/computers", http.StatusOK).Body.String()
+	for _, want := range []string{`href="/products/computers/laptops"`, `href="/products/laptops/field-laptop"`, `href="/products/rugged/rugged-tab"`} {
+		if !strings.Contains(family, want) {
+			t.Errorf("family page: expected %q", want)
+		}
+	}
+
+	series := get("/products/laptops/rugged", http.StatusOK).Body.String()
+	for _, want := range []string{`href="/products/computers"`, `href="/products/computers/laptops"`, `href="/products/rugged/rugged-tab"`} {
+		if !strings.Contains(series, want) {
+			t.Errorf("type page: expected breadcrumb or product link %q", want)
+		}
+	}
+	if strings.Contains(series, "field-laptop") {
+		t.Error("type page must not include products from sibling categories")
+	}
+
+	detail := get("/products/rugged/rugged-tab", http.StatusOK).Body.String()
+	if !strings.Contains(detail, `href="/products/laptops/rugged"`) {
+		t.Error("product detail: expected breadcrumb through the full category path")
+	}
+	get("/products/computers/rugged", http.StatusNotFound)
+}
+
+// makeTestSlug mirrors the admin slug rule for simple names.
+func makeTestSlug(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "-")
+}
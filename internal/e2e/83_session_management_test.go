@@ -0,0 +1,123 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestSessionManagement verifies that logins are tracked in admin_sessions
+// and that sessions can be ended remotely from the Active Sessions page.
+func TestSessionManagement(t *testing.T) {
+	app, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	admin, err := queries.GetAdminUserByEmail(ctx, "admin@test.com")
+	if err != nil {
+		t.Fatalf("load admin: %v", err)
+	}
+
+	do := func(method, path string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+	signedIn := func(cookie *http.Cookie) bool {
+		t.Helper()
+		return do(http.MethodGet, "/admin/dashboard", cookie).Code == http.StatusOK
+	}
+
+	laptop := loginAndGetCookie(t, app)
+	phone := loginAndGetCookie(t, app)
+
+	rows, err := queries.ListAdminSessionsByUser(ctx, admin.ID)
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("expected 2 tracked sessions, got %d (%v)", len(rows), err)
+	}
+
+	rec := do(http.MethodGet, "/admin/profile/sessions", laptop)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET sessions: expected 200, got %d", rec.Code)
+	}
+
+	// Revoke the phone's session from the laptop
+	phoneID := rows[0].ID // the phone logged in last
+	rec = do(http.MethodPost, "/admin/profile/sessions/"+strconv.FormatInt(phoneID, 10)+"/revoke", laptop)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("revoke: expected 303, got %d", rec.Code)
+	}
+	if signedIn(phone) {
+		t.Error("revoked session should be signed out")
+	}
+	if !signedIn(laptop) {
+		t.Error("revoking another session must not sign out the current one")
+	}
+
+	// Log out other sessions keeps only the current one
+	tablet := loginAndGetCookie(t, app)
+	if rec := do(http.MethodPost, "/admin/profile/sessions/revoke-others", laptop); rec.Code != http.StatusSeeOther {
+		t.Fatalf("revoke-others: expected 303, got %d", rec.Code)
+	}
+	if signedIn(tablet) || !signedIn(laptop) {
+		t.Error("revoke-others should sign out every session except the current one")
+	}
+
+	// Log out everywhere ends the current session too
+	desktop := loginAndGetCookie(t, app)
+	rec = do(http.MethodPost, "/admin/profile/sessions/revoke-all", laptop)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/login" {
+		t.Fatalf("revoke-all: expected 303 to /admin/login, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if signedIn(laptop) || signedIn(desktop) {
+		t.Error("log out everywhere should sign out all sessions")
+	}
+	if rows, _ := queries.ListAdminSessionsByUser(ctx, admin.ID); len(rows) != 0 {
+		t.Errorf("expected no sessions left, got %d", len(rows))
+	}
+
+	// Logging out removes the session row
+	cookie := loginAndGetCookie(t, app)
+	do(http.MethodPost, "/admin/logout", cookie)
+	if rows, _ := queries.ListAdminSessionsByUser(ctx, admin.ID); len(rows) != 0 {
+		t.Errorf("logout should delete its session row, %d left", len(rows))
+	}
+
+	// Real template: current session is marked, others get a revoke button
+	loginAndGetCookie(t, app)
+	loginAndGetCookie(t, app)
+	rows, _ = queries.ListAdminSessionsByUser(ctx, admin.ID)
+	type view struct {
+		sqlc.AdminSession
+		Device  string
+		Current bool
+	}
+	var views []view
+	for i, row := range rows {
+		views = append(views, view{AdminSession: row, Device: "Firefox on Linux", Current: i == 0})
+	}
+	var buf bytes.Buffer
+	if err := templates.NewRenderer("templates").Render(&buf, "admin/pages/profile_sessions.html", map[string]interface{}{
+		"Title": "Active Sessions", "Sessions": views, "DisplayName": "Test Admin", "Revoked": true,
+	}, nil); err != nil {
+		t.Fatalf("render sessions page: %v", err)
+	}
+	html := buf.String()
+	if !strings.Contains(html, "This device") || !strings.Contains(html, "/admin/profile/sessions/"+strconv.FormatInt(rows[1].ID, 10)+"/revoke") {
+		t.Error("sessions page: expected current-session badge and revoke form for the other session")
+	}
+	if strings.Contains(html, "/admin/profile/sessions/"+strconv.FormatInt(rows[0].ID, 10)+"/revoke") {
+		t.Error("sessions page: current session must not offer a revoke button")
+	}
+}
@@ -0,0 +1,134 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestBlogMonthArchives checks the /blog/:year/:month pages, the month/year
+// widget on the blog listing, per-month caching, and archive sitemap entries,
+// rendering with the REAL templates.
+func TestBlogMonthArchives(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	blogHandler := publicHandlers.NewBlogHandler(queries, logger, appCache)
+	publicGroup.GET("/blog", blogHandler.BlogListing)
+	publicGroup.GET("/blog/:year/:month", blogHandler.BlogArchive)
+	publicGroup.GET("/blog/:slug", blogHandler.BlogPost)
+	e.GET("/sitemap.xml", publicHandlers.NewSitemapHandler(queries, logger, "https://example.com").Sitemap)
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{
+		Name: "News", Slug: "news", ColorHex: "#000000", SortOrder: 1,
+	})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{
+		Name: "Author", Slug: "author", Title: "Writer", SortOrder: 1,
+	})
+	newPost := func(slug, status string, published time.Time) {
+		t.Helper()
+		if _, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: strings.ToUpper(slug), Slug: slug, Excerpt: "Excerpt", Body: "<p>Body</p>",
+			CategoryID: cat.ID, AuthorID: author.ID, Status: status,
+			PublishedAt: sql.NullTime{Time: published, Valid: true},
+		}); err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+	}
+	newPost("may-launch", "published", time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC))
+	newPost("may-recap", "published", time.Date(2024, 5, 28, 17, 30, 0, 0, time.UTC))
+	newPost("may-draft", "draft", time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC))
+	newPost("winter-notes", "published", time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC))
+
+	get := func(path string, wantStatus int) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	may := get("/blog/2024/05", http.StatusOK)
+	for _, want := range []string{"May 2024", `href="/blog/may-launch"`, `href="/blog/may-recap"`, "2 posts published"} {
+		if !strings.Contains(may, want) {
+			t.Errorf("May archive: expected %q", want)
+		}
+	}
+	if strings.Contains(may, "may-draft") || strings.Contains(may, `href="/blog/winter-notes"`) {
+		t.Error("May archive must list only published posts from May 2024")
+	}
+
+	// Canonical URLs only; empty months are not found
+	get("/blog/2024/5", http.StatusNotFound)
+	get("/blog/2024/13", http.StatusNotFound)
+	get("/blog/2024/06", http.StatusNotFound)
+
+	// Widget on the listing links every month, grouped by year
+	listing := get("/blog", http.StatusOK)
+	for _, want := range []string{`href="/blog/2024/05"`, `href="/blog/2023/01"`, "2024 (2)", "2023 (1)"} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("listing widget: expected %q", want)
+		}
+	}
+
+	// Each month is cached under its own key and cleared with the blog prefix
+	if _, ok := appCache.Get("page:blog:archive:2024-05:page:1"); !ok {
+		t.Error("expected the May 2024 archive to be cached")
+	}
+	appCache.DeleteByPrefix("page:blog")
+	if _, ok := appCache.Get("page:blog:archive:2024-05:page:1"); ok {
+		t.Error("expected admin blog invalidation to clear archive pages")
+	}
+
+	sitemap := get("/sitemap.xml", http.StatusOK)
+	for _, want := range []string{
+		"<loc>https://example.com/blog/2024/05</loc>\n    <lastmod>2024-05-28</lastmod>",
+		"<loc>https://example.com/blog/2023/01</loc>",
+	} {
+		if !strings.Contains(sitemap, want) {
+			t.Errorf("sitemap: expected %q", want)
+		}
+	}
+
+	// Months follow the site timezone: 1 March 02:00 in Kolkata is still
+	// February in UTC, but is filed (and dated) under March
+	if err := services.SetSiteTimezone("Asia/Kolkata"); err != nil {
+		t.Fatalf("SetSiteTimezone: %v", err)
+	}
+	t.Cleanup(func() { services.SetSiteTimezone(services.DefaultTimezone) })
+	newPost("march-opening", "published", time.Date(2024, 2, 29, 20, 30, 0, 0, time.UTC))
+	appCache.DeleteByPrefix("page:blog")
+	march := get("/blog/2024/03", http.StatusOK)
+	if !strings.Contains(march, `href="/blog/march-opening"`) {
+		t.Error("March archive: expected the post published 1 March site time")
+	}
+	get("/blog/2024/02", http.StatusNotFound)
+	if listing := get("/blog", http.StatusOK); !strings.Contains(listing, `href="/blog/2024/03"`) || strings.Contains(listing, `href="/blog/2024/02"`) {
+		t.Error("listing widget: expected the post under March, not February")
+	}
+}
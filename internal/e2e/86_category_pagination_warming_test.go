@@ -0,0 +1,119 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestCategoryPaginationSitemapAndWarming checks that paginated category pages
+// are cached per page with rel=prev/next links, listed in the sitemap up to
+// the page cap, and pre-rendered by the cache warmer.
+func TestCategoryPaginationSitemapAndWarming(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products/:category", products.ProductsByCategory)
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+	e.GET("/sitemap.xml", publicHandlers.NewSitemapHandler(queries, logger, "https://example.com").Sitemap)
+
+	cat, err := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "sensors",
+	})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	// 13 pages of products: more than the sitemap/warming cap of 10
+	for i := 1; i <= 12*12+1; i++ {
+		if _, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("S-%03d", i), Slug: fmt.Sprintf("sensor-%03d", i), Name: fmt.Sprintf("Sensor %03d", i),
+			Description: "d", CategoryID: cat.ID, Status: "published",
+		}); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+
+	get := func(path string, wantStatus int) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	// Each page is cached under its own key and links to its neighbours
+	page1 := get("/products/sensors", http.StatusOK)
+	page2 := get("/products/sensors?page=2", http.StatusOK)
+	if page1 == page2 {
+		t.Fatal("page 2 must not be served from page 1's cache entry")
+	}
+	for _, want := range []string{
+		`<link rel="prev" href="https://bluejaylabs.com/products/sensors">`,
+		`<link rel="next" href="https://bluejaylabs.com/products/sensors?page=3">`,
+		`<link rel="canonical" href="https://bluejaylabs.com/products/sensors?page=2">`,
+	} {
+		if !strings.Contains(page2, want) {
+			t.Errorf("page 2: expected %q", want)
+		}
+	}
+	if strings.Contains(page1, `rel="prev"`) {
+		t.Error("page 1 must not have a previous page")
+	}
+	if !strings.Contains(get("/products/sensors?page=13", http.StatusOK), "Page 13 of 13") {
+		t.Error("expected the last page to render")
+	}
+	get("/products/sensors?page=14", http.StatusNotFound)
+
+	sitemap := get("/sitemap.xml", http.StatusOK)
+	for _, want := range []string{"<loc>https://example.com/products/sensors</loc>", "<loc>https://example.com/products/sensors?page=10</loc>"} {
+		if !strings.Contains(sitemap, want) {
+			t.Errorf("sitemap: expected %q", want)
+		}
+	}
+	if strings.Contains(sitemap, "sensors?page=11") {
+		t.Error("sitemap: paginated URLs must stop at MaxIndexedCategoryPages")
+	}
+
+	// Warmer renders every indexed page into the cache
+	appCache.DeleteByPrefix("page:products")
+	warmed, err := services.NewCacheWarmer(e, logger, publicHandlers.CategoryPageURLs(queries)).Run(ctx)
+	if err != nil {
+		t.Fatalf("warm: %v", err)
+	}
+	if warmed != publicHandlers.MaxIndexedCategoryPages {
+		t.Errorf("warmed %d pages, want %d", warmed, publicHandlers.MaxIndexedCategoryPages)
+	}
+	for _, key := range []string{"page:products:sensors", "page:products:sensors:page:10"} {
+		if _, ok := appCache.Get(key); !ok {
+			t.Errorf("expected %s to be warmed", key)
+		}
+	}
+	if _, ok := appCache.Get("page:products:sensors:page:11"); ok {
+		t.Error("pages past the cap must not be warmed")
+	}
+}
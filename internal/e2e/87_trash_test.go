@@ -0,0 +1,121 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestTrash verifies that deleting content moves it to the trash, hides it
+// from listings, and that it can be restored or deleted permanently.
+func TestTrash(t *testing.T) {
+	app, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, app)
+	do := func(method, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cat", Slug: "cat", Description: "d", Icon: "i"})
+	prod, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "TR-1", Slug: "trashed-product", Name: "Trashed Product", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	sol, err := queries.CreateSolution(ctx, sqlc.CreateSolutionParams{
+		Title: "Trashed Solution", Slug: "trashed-solution", Icon: "i", ShortDescription: "s",
+		IsPublished: sql.NullBool{Bool: true, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("create solution: %v", err)
+	}
+
+	// Deleting moves items to the trash and hides them everywhere
+	if rec := do(http.MethodDelete, fmt.Sprintf("/admin/products/%d", prod.ID)); rec.Code != http.StatusOK {
+		t.Fatalf("delete product: expected 200, got %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, fmt.Sprintf("/admin/solutions/%d", sol.ID)); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete solution: expected 204, got %d", rec.Code)
+	}
+	if _, err := queries.GetProductBySlug(ctx, "trashed-product"); err != sql.ErrNoRows {
+		t.Errorf("trashed product should not be found by slug, got %v", err)
+	}
+	if n, _ := queries.CountProducts(ctx); n != 0 {
+		t.Errorf("trashed product should not be counted, got %d", n)
+	}
+	if n, _ := queries.CountProductsAdminFiltered(ctx, sqlc.CountProductsAdminFilteredParams{FilterStatus: "", FilterCategory: 0, FilterSearch: ""}); n != 0 {
+		t.Errorf("trashed product should not be listed in the admin, got %d", n)
+	}
+	if list, _ := queries.ListPublishedSolutions(ctx); len(list) != 0 {
+		t.Errorf("trashed solution should not be listed, got %d", len(list))
+	}
+
+	if rec := do(http.MethodGet, "/admin/trash"); rec.Code != http.StatusOK {
+		t.Fatalf("GET /admin/trash: expected 200, got %d", rec.Code)
+	}
+	trashed, _ := queries.ListTrashedProducts(ctx)
+	if len(trashed) != 1 || trashed[0].ID != prod.ID || !trashed[0].DeletedAt.Valid {
+		t.Fatalf("expected the product in the trash, got %+v", trashed)
+	}
+
+	// Restore brings the product back with its previous status
+	rec := do(http.MethodPost, fmt.Sprintf("/admin/trash/product/%d/restore", prod.ID))
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/trash?restored=1" {
+		t.Fatalf("restore: expected 303 to /admin/trash?restored=1, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if p, err := queries.GetProductBySlug(ctx, "trashed-product"); err != nil || p.Status != "published" {
+		t.Errorf("restored product should be published again (%v)", err)
+	}
+
+	// Only trashed items can be restored or deleted permanently
+	if rec := do(http.MethodPost, fmt.Sprintf("/admin/trash/product/%d/delete", prod.ID)); rec.Code != http.StatusNotFound {
+		t.Errorf("deleting a live product from the trash: expected 404, got %d", rec.Code)
+	}
+	if rec := do(http.MethodPost, fmt.Sprintf("/admin/trash/widget/%d/delete", prod.ID)); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown type: expected 404, got %d", rec.Code)
+	}
+
+	// Permanent delete removes the row
+	if rec := do(http.MethodPost, fmt.Sprintf("/admin/trash/solution/%d/delete", sol.ID)); rec.Code != http.StatusSeeOther {
+		t.Fatalf("permanent delete: expected 303, got %d", rec.Code)
+	}
+	if _, err := queries.GetSolutionByID(ctx, sol.ID); err != sql.ErrNoRows {
+		t.Errorf("expected the solution to be gone, got %v", err)
+	}
+
+	// Real template: items are grouped by type with restore and delete forms
+	var buf bytes.Buffer
+	if err := templates.NewRenderer("templates").Render(&buf, "admin/pages/trash.html", map[string]interface{}{
+		"Title": "Trash",
+		"Sections": []map[string]interface{}{{
+			"Type": "product", "Label": "Products",
+			"Items": []sqlc.ListTrashedProductsRow{{ID: 7, Title: "Old Sensor", Slug: "old-sensor"}},
+		}},
+		"Total": 1, "RetentionDays": 30,
+	}, nil); err != nil {
+		t.Fatalf("render trash page: %v", err)
+	}
+	html := buf.String()
+	for _, want := range []string{"Old Sensor", "/admin/trash/product/7/restore", "/admin/trash/product/7/delete", "30 days"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("trash page: expected %q", want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestListingGridPartials checks that the /partials/* endpoints return only
+// the listing grid, push the full-page URL to history, and are cached apart
+// from the pages that link to them.
+func TestListingGridPartials(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products/:category", products.ProductsByCategory)
+	publicGroup.GET("/partials/products/:category", products.ProductsCategoryGrid)
+	blog := publicHandlers.NewBlogHandler(queries, logger, appCache)
+	publicGroup.GET("/blog", blog.BlogListing)
+	publicGroup.GET("/partials/blog", blog.BlogListingGrid)
+	caseStudies := publicHandlers.NewCaseStudiesHandler(queries, logger, appCache)
+	publicGroup.GET("/case-studies", caseStudies.CaseStudiesList)
+	publicGroup.GET("/partials/case-studies", caseStudies.CaseStudiesListGrid)
+	whitepapers := publicHandlers.NewWhitepapersHandler(queries, logger, appCache)
+	publicGroup.GET("/whitepapers", whitepapers.WhitepapersList)
+	publicGroup.GET("/partials/whitepapers", whitepapers.WhitepapersListGrid)
+
+	cat, err := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "sensors",
+	})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	// Two pages of products
+	for i := 1; i <= 13; i++ {
+		if _, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("S-%03d", i), Slug: fmt.Sprintf("sensor-%03d", i), Name: fmt.Sprintf("Sensor %03d", i),
+			Description: "d", CategoryID: cat.ID, Status: "published",
+		}); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+
+	get := func(path string, wantStatus int) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec
+	}
+
+	// Full pages link to the partial endpoints and keep plain hrefs
+	page := get("/products/sensors", http.StatusOK).Body.String()
+	for _, want := range []string{`id="product-grid"`, `href="/products/sensors?page=2"`, `hx-get="/partials/products/sensors?page=2"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("category page: expected %q", want)
+		}
+	}
+
+	grids := []struct {
+		path, push, id string
+	}{
+		{"/partials/products/sensors?page=2", "/products/sensors?page=2", `id="product-grid"`},
+		{"/partials/blog?category=news", "/blog?category=news", `id="blog-grid"`},
+		{"/partials/case-studies?industry=1", "/case-studies?industry=1", `id="case-studies-grid"`},
+		{"/partials/whitepapers", "/whitepapers", `id="whitepapers-grid"`},
+	}
+	for _, g := range grids {
+		rec := get(g.path, http.StatusOK)
+		body := rec.Body.String()
+		if !strings.Contains(body, g.id) {
+			t.Errorf("%s: expected %s", g.path, g.id)
+		}
+		if strings.Contains(body, "<html") || strings.Contains(body, "<header") {
+			t.Errorf("%s: fragment must not include the layout", g.path)
+		}
+		if got := rec.Header().Get("HX-Push-Url"); got != g.push {
+			t.Errorf("%s: HX-Push-Url = %q, want %q", g.path, got, g.push)
+		}
+	}
+	if body := get("/partials/products/sensors?page=2", http.StatusOK).Body.String(); !strings.Contains(body, "Page 2 of 2") {
+		t.Error("cached product grid: expected page 2")
+	}
+	get("/partials/products/missing", http.StatusNotFound)
+
+	// Fragments and pages are cached under separate keys
+	for _, path := range []string{"/blog?category=news", "/case-studies?industry=1", "/whitepapers"} {
+		if body := get(path, http.StatusOK).Body.String(); !strings.Contains(body, "<html") {
+			t.Errorf("%s: expected the full page after its fragment was cached", path)
+		}
+	}
+	if _, ok := appCache.Get("page:whitepapers" + ":grid"); !ok {
+		t.Error("expected the whitepapers fragment to be cached")
+	}
+}
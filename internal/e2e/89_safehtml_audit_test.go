@@ -0,0 +1,100 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSafeHTMLAudit checks that the audit attributes safeHTML values to their
+// template fields, flags values the sanitizer would change, and lists call
+// sites that have not rendered yet.
+func TestSafeHTMLAudit(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	renderer := templates.NewRenderer("templates")
+	audit := templates.NewSafeHTMLAudit(services.NewHTMLSanitizer(services.SanitizerConfig{}).Sanitize, logger)
+	renderer.AuditSafeHTML(audit)
+
+	e := echo.New()
+	e.Renderer = renderer
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	caseStudies := publicHandlers.NewCaseStudiesHandler(queries, logger, services.NewCache())
+	publicGroup.GET("/case-studies/:slug", caseStudies.CaseStudyDetail)
+	e.GET("/admin/safehtml-audit", adminHandlers.NewSafeHTMLAuditHandler(audit).Report)
+
+	ind, err := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Tech", Slug: "tech", Description: "d", Icon: "chip", SortOrder: 1})
+	if err != nil {
+		t.Fatalf("create industry: %v", err)
+	}
+	if _, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+		Slug: "acme", Title: "Acme", ClientName: "Acme", IndustryID: ind.ID, IsPublished: 1,
+		Summary: "s", ChallengeTitle: "ch", SolutionTitle: "st", OutcomeTitle: "ot",
+		ChallengeContent: `<p onclick="steal()">Legacy challenge</p>`,
+		SolutionContent:  "<p>Clean solution</p>",
+		OutcomeContent:   "<p>Clean outcome</p>",
+	}); err != nil {
+		t.Fatalf("create case study: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/case-studies/acme", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("case study: expected 200, got %d", rec.Code)
+	}
+	// Auditing never changes the output
+	if !strings.Contains(rec.Body.String(), `<p onclick="steal()">Legacy challenge</p>`) {
+		t.Error("expected safeHTML output to be unchanged by the audit")
+	}
+
+	found := map[string]templates.SafeHTMLField{}
+	for _, f := range audit.Fields() {
+		found[f.Template+" "+f.Field] = f
+	}
+	const page = "public/pages/case_study_detail.html "
+	if f := found[page+".CaseStudy.ChallengeContent"]; f.Renders != 1 || f.Unsanitized != 1 {
+		t.Errorf("challenge: got %+v, want 1 render, 1 unsanitized", f)
+	}
+	if f := found[page+".CaseStudy.SolutionContent"]; f.Renders != 1 || f.Unsanitized != 0 {
+		t.Errorf("solution: got %+v, want 1 render, 0 unsanitized", f)
+	}
+	if f, ok := found["public/pages/blog_post.html .Post.Body"]; !ok || f.Renders != 0 {
+		t.Errorf("blog body: got %+v (listed %v), want an unrendered call site", f, ok)
+	}
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/safehtml-audit", nil))
+	report := rec.Body.String()
+	lines := strings.Split(report, "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[3], "MIGRATE") || !strings.Contains(lines[3], ".CaseStudy.ChallengeContent") {
+		t.Errorf("expected the unsanitized field first in the report:\n%s", report)
+	}
+	if !strings.Contains(report, "UNSEEN") {
+		t.Errorf("expected unrendered call sites in the report:\n%s", report)
+	}
+
+	// WriteReport output matches the endpoint
+	var buf bytes.Buffer
+	if err := audit.WriteReport(&buf); err != nil || buf.String() != report {
+		t.Errorf("WriteReport: err=%v, output differs from endpoint", err)
+	}
+}
@@ -0,0 +1,172 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestProductVariantsAdmin_E2E checks the Variants tab endpoints: creating a
+// variant, rejecting SKUs already in use, setting spec overrides, and keeping
+// variants scoped to their product.
+func TestProductVariantsAdmin_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	ctx := context.Background()
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Drives", Slug: "drives", Description: "d", Icon: "bolt", SortOrder: 1,
+	})
+	product, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "DRV-100", Slug: "drive-100", Name: "Drive 100", Description: "d", CategoryID: cat.ID, Status: "draft",
+	})
+	other, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "DRV-200", Slug: "drive-200", Name: "Drive 200", Description: "d", CategoryID: cat.ID, Status: "draft",
+	})
+
+	send := func(method, path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	base := fmt.Sprintf("/admin/products/%d/variants", product.ID)
+
+	rec := send(http.MethodPost, base, url.Values{"sku": {"DRV-100-230"}, "name": {"230V AC"}, "display_order": {"1"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "DRV-100-230") {
+		t.Fatalf("add variant: got %d", rec.Code)
+	}
+	variants, _ := queries.ListProductVariants(ctx, product.ID)
+	if len(variants) != 1 || variants[0].Name != "230V AC" {
+		t.Fatalf("expected one 230V AC variant, got %+v", variants)
+	}
+	v := variants[0]
+
+	// SKUs of products and other variants are rejected with a message
+	for _, sku := range []string{"DRV-200", "DRV-100-230"} {
+		rec = send(http.MethodPost, base, url.Values{"sku": {sku}, "name": {"Dup"}})
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "already used") {
+			t.Errorf("duplicate SKU %s: got %d, expected an inline error", sku, rec.Code)
+		}
+	}
+	if variants, _ := queries.ListProductVariants(ctx, product.ID); len(variants) != 1 {
+		t.Errorf("expected duplicates to be rejected, got %d variants", len(variants))
+	}
+
+	// Saving the same override twice replaces it
+	specPath := fmt.Sprintf("%s/%d/specs", base, v.ID)
+	send(http.MethodPost, specPath, url.Values{"section_name": {"Electrical"}, "spec_key": {"Voltage"}, "spec_value": {"220V"}})
+	send(http.MethodPost, specPath, url.Values{"section_name": {"Electrical"}, "spec_key": {"Voltage"}, "spec_value": {"230V"}})
+	specs, _ := queries.ListProductVariantSpecs(ctx, v.ID)
+	if len(specs) != 1 || specs[0].SpecValue != "230V" {
+		t.Errorf("expected one 230V override, got %+v", specs)
+	}
+
+	// A variant cannot be reached through another product
+	otherPath := fmt.Sprintf("/admin/products/%d/variants/%d/specs", other.ID, v.ID)
+	if rec = send(http.MethodPost, otherPath, url.Values{"section_name": {"X"}, "spec_key": {"Y"}, "spec_value": {"Z"}}); rec.Code != http.StatusNotFound {
+		t.Errorf("cross-product override: expected 404, got %d", rec.Code)
+	}
+	if rec = send(http.MethodPost, fmt.Sprintf("/admin/products/%d/variants/%d", other.ID, v.ID), url.Values{"sku": {"NEW"}, "name": {"n"}}); rec.Code != http.StatusNotFound {
+		t.Errorf("cross-product update: expected 404, got %d", rec.Code)
+	}
+
+	// Deleting the variant removes its overrides
+	if rec = send(http.MethodDelete, fmt.Sprintf("%s/%d", base, v.ID), nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete variant: got %d", rec.Code)
+	}
+	if specs, _ := queries.ListProductVariantSpecs(ctx, v.ID); len(specs) != 0 {
+		t.Errorf("expected overrides to cascade, got %d", len(specs))
+	}
+}
+
+// TestProductVariantSelector_E2E checks that ?variant= on the product page
+// switches the SKU, specs and gallery, and that unknown variants 404.
+func TestProductVariantSelector_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), services.NewCache())
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Drives", Slug: "drives", Description: "d", Icon: "bolt", SortOrder: 1,
+	})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "DRV-100", Slug: "drive-100", Name: "Drive 100", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: product.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V DC"})
+	queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: product.ID, ImagePath: "/uploads/base.jpg"})
+	variant, err := queries.CreateProductVariant(ctx, sqlc.CreateProductVariantParams{ProductID: product.ID, Sku: "DRV-100-230", Name: "230V AC"})
+	if err != nil {
+		t.Fatalf("create variant: %v", err)
+	}
+	queries.UpsertProductVariantSpec(ctx, sqlc.UpsertProductVariantSpecParams{VariantID: variant.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "230V AC"})
+	queries.CreateProductVariantImage(ctx, sqlc.CreateProductVariantImageParams{VariantID: variant.ID, ImagePath: "/uploads/variant.jpg"})
+
+	get := func(path string, wantStatus int) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	page := get("/products/drives/drive-100", http.StatusOK)
+	for _, want := range []string{"DRV-100", "24V DC", "/uploads/base.jpg", `href="/products/drives/drive-100?variant=DRV-100-230"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("base page: expected %q", want)
+		}
+	}
+
+	page = get("/products/drives/drive-100?variant=DRV-100-230", http.StatusOK)
+	for _, want := range []string{"DRV-100-230", "230V AC", "/uploads/variant.jpg"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("variant page: expected %q", want)
+		}
+	}
+	for _, unwanted := range []string{"24V DC", "/uploads/base.jpg"} {
+		if strings.Contains(page, unwanted) {
+			t.Errorf("variant page: unexpected %q", unwanted)
+		}
+	}
+
+	// The base page is cached separately from the variant
+	if page = get("/products/drives/drive-100", http.StatusOK); !strings.Contains(page, "24V DC") {
+		t.Error("base page after variant: expected the product specs")
+	}
+	get("/products/drives/drive-100?variant=NOPE", http.StatusNotFound)
+}
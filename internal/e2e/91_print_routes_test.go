@@ -0,0 +1,120 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestPrintRoutes checks that the /print routes render products and case
+// studies in the print layout, without site chrome or scripts, and are kept
+// out of search indexes.
+func TestPrintRoutes(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	appCache := services.NewCache()
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	publicGroup := e.Group("")
+	publicGroup.Use(customMiddleware.SettingsLoader(queries))
+	products := publicHandlers.NewProductsHandler(queries, logger, services.NewProductService(queries), appCache)
+	publicGroup.GET("/products/:category/:slug", products.ProductDetail)
+	publicGroup.GET("/products/:category/:slug/print", products.ProductPrint)
+	caseStudies := publicHandlers.NewCaseStudiesHandler(queries, logger, appCache)
+	publicGroup.GET("/case-studies/:slug", caseStudies.CaseStudyDetail)
+	publicGroup.GET("/case-studies/:slug/print", caseStudies.CaseStudyPrint)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Sensors", Slug: "sensors", Description: "d", Icon: "sensors", SortOrder: 1,
+	})
+	sub, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Thermal", Slug: "thermal", Description: "d", Icon: "sensors",
+	})
+	queries.SetProductCategoryParent(ctx, sqlc.SetProductCategoryParentParams{ParentID: sql.NullInt64{Int64: cat.ID, Valid: true}, ID: sub.ID})
+	product, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "TS-100", Slug: "ts-100", Name: "TS 100", Description: "Thermal sensor", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: product.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V DC"})
+	variant, _ := queries.CreateProductVariant(ctx, sqlc.CreateProductVariantParams{ProductID: product.ID, Sku: "TS-100-HV", Name: "230V AC"})
+	queries.UpsertProductVariantSpec(ctx, sqlc.UpsertProductVariantSpecParams{VariantID: variant.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "230V AC"})
+
+	ind, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Energy", Slug: "energy", Description: "d", Icon: "bolt", SortOrder: 1})
+	if _, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+		Slug: "grid-co", Title: "Grid Co", ClientName: "Grid Co", IndustryID: ind.ID, IsPublished: 1,
+		Summary: "s", ChallengeTitle: "ch", SolutionTitle: "st", OutcomeTitle: "ot",
+		ChallengeContent: "<p>Outages</p>", SolutionContent: "<p>Sensors</p>", OutcomeContent: "<p>Uptime</p>",
+	}); err != nil {
+		t.Fatalf("create case study: %v", err)
+	}
+
+	get := func(path string, wantStatus int) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s: expected %d, got %d", path, wantStatus, rec.Code)
+		}
+		return rec
+	}
+
+	pages := []struct {
+		path      string
+		canonical string
+		want      []string
+	}{
+		{"/products/sensors/ts-100/print", "/products/sensors/ts-100", []string{"TS-100", "<th>Voltage</th><td>24V DC</td>"}},
+		{"/products/sensors/ts-100/print?variant=TS-100-HV", "/products/sensors/ts-100", []string{"TS-100-HV", "<td>230V AC</td>"}},
+		{"/case-studies/grid-co/print", "/case-studies/grid-co", []string{"Grid Co", "<p>Outages</p>", "<p>Uptime</p>"}},
+	}
+	for _, p := range pages {
+		rec := get(p.path, http.StatusOK)
+		body := rec.Body.String()
+		for _, want := range append(p.want, `href="/public/css/print.css"`, `href="https://bluejaylabs.com`+p.canonical+`"`) {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s: expected %q", p.path, want)
+			}
+		}
+		for _, unwanted := range []string{"<header", "cdn.tailwindcss.com", "htmx.min.js"} {
+			if strings.Contains(body, unwanted) {
+				t.Errorf("%s: print layout must not include %q", p.path, unwanted)
+			}
+		}
+		if got := rec.Header().Get("X-Robots-Tag"); got != "noindex" {
+			t.Errorf("%s: X-Robots-Tag = %q, want noindex", p.path, got)
+		}
+	}
+
+	// Regular pages link to their print version and keep the site layout
+	for path, link := range map[string]string{
+		"/products/sensors/ts-100": `href="/products/sensors/ts-100/print"`,
+		"/case-studies/grid-co":    `href="/case-studies/grid-co/print"`,
+	} {
+		body := get(path, http.StatusOK).Body.String()
+		if !strings.Contains(body, link) || !strings.Contains(body, "<header") {
+			t.Errorf("%s: expected the site layout with a print link", path)
+		}
+	}
+
+	// Subcategory pages have no print version
+	get("/products/sensors/thermal/print", http.StatusNotFound)
+}
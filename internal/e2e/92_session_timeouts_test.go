@@ -0,0 +1,124 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSessionTimeouts_E2E checks that SessionTracker signs out sessions past
+// the idle timeout or the absolute lifetime, that the login redirect says
+// why, that the keep-alive endpoint reports the remaining time, and that
+// background polls do not keep an idle session alive.
+func TestSessionTimeouts_E2E(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+
+	e := echo.New()
+	e.Use(customMiddleware.SessionMiddleware())
+	e.Use(customMiddleware.SessionTracker(queries, customMiddleware.SessionTimeouts{Idle: 30 * time.Minute, Absolute: 8 * time.Hour}))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	adminGroup.GET("/dashboard", func(c echo.Context) error { return c.String(http.StatusOK, "dashboard") })
+	adminGroup.POST("/session/keepalive", adminHandlers.NewSessionsHandler(queries, testLogger).KeepAlive)
+
+	createTestAdmin(t, queries)
+
+	send := func(method, path string, cookie *http.Cookie, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		req.AddCookie(cookie)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// An active session reports its deadlines
+	cookie := loginAndGetCookie(t, e)
+	rec := send(http.MethodPost, "/admin/session/keepalive", cookie, false)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("keepalive: expected 200, got %d", rec.Code)
+	}
+	var left struct {
+		IdleRemaining     int `json:"idle_remaining"`
+		AbsoluteRemaining int `json:"absolute_remaining"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &left); err != nil {
+		t.Fatalf("keepalive: %v", err)
+	}
+	if left.IdleRemaining < 29*60 || left.IdleRemaining > 30*60 {
+		t.Errorf("idle_remaining = %d, want about 1800", left.IdleRemaining)
+	}
+	if left.AbsoluteRemaining < 7*3600 || left.AbsoluteRemaining > 8*3600 {
+		t.Errorf("absolute_remaining = %d, want about 28800", left.AbsoluteRemaining)
+	}
+
+	// A background poll is accepted but leaves last_seen_at alone
+	if _, err := db.ExecContext(ctx, "UPDATE admin_sessions SET last_seen_at = datetime('now', '-20 minutes')"); err != nil {
+		t.Fatalf("age sessions: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil)
+	req.AddCookie(cookie)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set(customMiddleware.BackgroundRequestHeader, "true")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("background poll: expected 200, got %d", rec.Code)
+	}
+	var idle float64
+	if err := db.QueryRowContext(ctx, "SELECT (julianday('now') - julianday(last_seen_at)) * 1440 FROM admin_sessions").Scan(&idle); err != nil {
+		t.Fatalf("read last_seen_at: %v", err)
+	}
+	if idle < 19 {
+		t.Errorf("background poll refreshed last_seen_at: idle for %.1f minutes, want about 20", idle)
+	}
+
+	expiries := []struct {
+		name   string
+		column string
+		age    string
+		htmx   bool
+	}{
+		{"idle", "last_seen_at", "-31 minutes", false},
+		{"absolute", "created_at", "-9 hours", true},
+	}
+	for _, exp := range expiries {
+		cookie := loginAndGetCookie(t, e)
+		if rec := send(http.MethodGet, "/admin/dashboard", cookie, false); rec.Code != http.StatusOK {
+			t.Fatalf("%s: fresh session: expected 200, got %d", exp.name, rec.Code)
+		}
+		if _, err := db.ExecContext(ctx, "UPDATE admin_sessions SET "+exp.column+" = datetime('now', ?)", exp.age); err != nil {
+			t.Fatalf("%s: age sessions: %v", exp.name, err)
+		}
+
+		rec := send(http.MethodGet, "/admin/dashboard", cookie, exp.htmx)
+		location := rec.Header().Get("Location")
+		if exp.htmx {
+			location = rec.Header().Get("HX-Redirect")
+		}
+		if location != "/admin/login?error=session_expired" {
+			t.Errorf("%s: expected redirect to the expired login page, got %d %q", exp.name, rec.Code, location)
+		}
+
+		// The session row is gone, so the cookie stays signed out
+		if rec := send(http.MethodGet, "/admin/dashboard", cookie, false); rec.Header().Get("Location") != "/admin/login" {
+			t.Errorf("%s: expected the expired session to stay signed out, got %d", exp.name, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestProductImport_E2E walks the import wizard with the real templates:
+// upload, suggested mapping, a dry run that writes nothing, then the import.
+func TestProductImport_E2E(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	h := adminHandlers.NewProductImportHandler(services.NewProductImporter(db, queries), testLogger, services.NewCache())
+	e.GET("/admin/products/import", h.Show)
+	e.POST("/admin/products/import", h.Upload)
+	e.POST("/admin/products/import/run", h.Run)
+
+	queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	csvData := "SKU,Name,Category,Description,Weight\nTS-1,Temp Sensor,sensors,Measures heat,120 g\n"
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/products/import", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `name="file"`) {
+		t.Fatalf("upload step: got %d", rec.Code)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "products.csv")
+	fw.Write([]byte(csvData))
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	page := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(page, "2. Map Columns") {
+		t.Fatalf("mapping step: got %d", rec.Code)
+	}
+	if !strings.Contains(page, `<option value="sku" selected>`) || !strings.Contains(page, "120 g") {
+		t.Error("mapping step: expected suggested fields and sample values")
+	}
+
+	run := func(action string) string {
+		t.Helper()
+		form := url.Values{
+			"csv":       {csvData},
+			"file_name": {"products.csv"},
+			"map":       {"sku", "name", "category", "description", "spec"},
+			"action":    {action},
+		}
+		req := httptest.NewRequest(http.MethodPost, "/admin/products/import/run", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: got %d", action, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	if page = run("dry_run"); !strings.Contains(page, "Dry run passed") {
+		t.Error("dry run: expected a passing report")
+	}
+	if _, err := queries.GetProductBySKU(ctx, "TS-1"); err == nil {
+		t.Fatal("dry run must not create products")
+	}
+
+	if page = run("import"); !strings.Contains(page, "Imported 1 products") {
+		t.Error("import: expected a success report")
+	}
+	p, err := queries.GetProductBySKU(ctx, "TS-1")
+	if err != nil {
+		t.Fatalf("imported product: %v", err)
+	}
+	if specs, _ := queries.ListProductSpecs(ctx, p.ID); len(specs) != 1 || specs[0].SpecKey != "Weight" {
+		t.Errorf("specs = %+v", specs)
+	}
+
+	// Importing the same file again reports the existing SKU
+	if page = run("import"); !strings.Contains(page, "SKU TS-1 already exists") {
+		t.Error("re-import: expected the duplicate SKU in the report")
+	}
+}
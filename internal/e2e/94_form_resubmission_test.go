@@ -0,0 +1,97 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+)
+
+// TestFormResubmission_E2E checks that posting the same create form twice
+// (a refresh after POST, or a double-click) creates one homepage stat, and
+// that the repeat lands on the same page as the first submission.
+func TestFormResubmission_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	form := url.Values{
+		"stat_value": {"500+"},
+		"stat_label": {"Projects"},
+		"is_active":  {"on"},
+		"form_token": {"0123456789abcdef0123456789abcdef"},
+	}
+	for i := 1; i <= 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/admin/homepage/stats", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/homepage/stats" {
+			t.Errorf("submission %d: expected redirect to the stats list, got %d %q", i, rec.Code, rec.Header().Get("Location"))
+		}
+	}
+
+	stats, err := queries.ListAllStats(context.Background())
+	if err != nil {
+		t.Fatalf("list stats: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Errorf("expected 1 stat, got %d", len(stats))
+	}
+
+	// A new rendering of the form has a new token and creates another stat
+	form.Set("form_token", "fedcba9876543210fedcba9876543210")
+	req := httptest.NewRequest(http.MethodPost, "/admin/homepage/stats", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	if stats, _ := queries.ListAllStats(context.Background()); len(stats) != 2 {
+		t.Errorf("expected 2 stats after a new form, got %d", len(stats))
+	}
+}
+
+// TestFormResubmission_RenderedToken_E2E checks that the form page renders
+// its own token, so a browser without JavaScript is protected too.
+func TestFormResubmission_RenderedToken_E2E(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	e.Renderer = templates.NewRenderer("templates")
+
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	newForm := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/admin/homepage/stats/new", nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		m := regexp.MustCompile(`name="form_token" value="([0-9a-f]{32})"`).FindStringSubmatch(rec.Body.String())
+		if m == nil {
+			t.Fatalf("the stat form has no form token: %d %s", rec.Code, rec.Body.String())
+		}
+		return m[1]
+	}
+	token := newForm()
+	if newForm() == token {
+		t.Error("two renderings of the form share a token")
+	}
+
+	form := url.Values{"stat_value": {"500+"}, "stat_label": {"Projects"}, "is_active": {"on"}, "form_token": {token}}
+	for i := 1; i <= 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/admin/homepage/stats", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if stats, _ := queries.ListAllStats(context.Background()); len(stats) != 1 {
+		t.Errorf("expected 1 stat from the re-posted form, got %d", len(stats))
+	}
+}
@@ -0,0 +1,62 @@
+package e2e_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestProductExport_Downloads checks both export formats through the admin
+// route, including the formula guard on CSV cells.
+func TestProductExport_Downloads(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+	createTestAdmin(t, queries)
+	cookie := loginAndGetCookie(t, e)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	p, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "TS-1", Slug: "ts-1", Name: "=Temp Sensor", Description: "d", CategoryID: cat.ID, Status: "published"})
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "Physical", SpecKey: "Weight", SpecValue: "120 g"})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/admin/products/export")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("csv: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Header().Get("Content-Disposition"), `.csv"`) {
+		t.Errorf("csv: disposition %q", rec.Header().Get("Content-Disposition"))
+	}
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "features,Physical: Weight") {
+		t.Fatalf("csv: body %q", rec.Body.String())
+	}
+	if lines[1] != "TS-1,'=Temp Sensor,ts-1,sensors,d,,,published,,120 g" {
+		t.Errorf("csv: row %q", lines[1])
+	}
+
+	rec = get("/admin/products/export?format=xlsx")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Type"), "spreadsheetml") {
+		t.Fatalf("xlsx: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if _, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len())); err != nil {
+		t.Errorf("xlsx: not a zip: %v", err)
+	}
+
+	if rec = get("/admin/products/export?format=pdf"); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown format: got %d", rec.Code)
+	}
+}
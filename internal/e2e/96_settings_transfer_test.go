@@ -0,0 +1,134 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSettingsTransfer_E2E exports the settings, edits the file as an
+// operator would for another environment, previews the diff and applies it.
+func TestSettingsTransfer_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
+	ctx := context.Background()
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	e.Use(customMiddleware.SessionMiddleware())
+	adminHandlers.SetActivityLogService(services.NewActivityLogService(queries, testLogger))
+	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
+	e.POST("/admin/login", authHandler.LoginSubmit)
+	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	h := adminHandlers.NewSettingsHandler(queries, testLogger, services.NewCache())
+	adminGroup.GET("/settings/export", h.Export)
+	adminGroup.GET("/settings/import", h.Import)
+	adminGroup.POST("/settings/import", h.ImportPreview)
+	adminGroup.POST("/settings/import/apply", h.ImportApply)
+	cookie := loginTabsAdmin(t, e, queries)
+
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	upload := func(data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("file", "staging.json")
+		fw.Write(data)
+		mw.Close()
+		req := httptest.NewRequest(http.MethodPost, "/admin/settings/import", &body)
+		req.Header.Set(echo.HeaderContentType, mw.FormDataContentType())
+		return do(req)
+	}
+
+	rec := do(httptest.NewRequest(http.MethodGet, "/admin/settings/export", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get(echo.HeaderContentDisposition), ".json") {
+		t.Fatalf("export: got %d %q", rec.Code, rec.Header().Get(echo.HeaderContentDisposition))
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("export is not JSON: %v", err)
+	}
+	settings := doc["settings"].(map[string]interface{})
+	settings["site_name"] = "Bluejay Staging"
+	settings["footer_columns"] = 3
+	edited, _ := json.Marshal(doc)
+
+	// Preview shows the diff and writes nothing
+	rec = upload(edited)
+	page := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(page, "changes 2 settings") || !strings.Contains(page, "Bluejay Staging") {
+		t.Fatalf("preview: got %d\n%s", rec.Code, page)
+	}
+	if s, _ := queries.GetSettings(ctx); s.SiteName == "Bluejay Staging" {
+		t.Fatal("preview must not write settings")
+	}
+
+	// Apply with the values carried by the preview form
+	form := url.Values{}
+	for _, m := range regexp.MustCompile(`name="(file_name|settings_version)" value="([^"]*)"`).FindAllStringSubmatch(page, -1) {
+		form.Set(m[1], html.UnescapeString(m[2]))
+	}
+	m := regexp.MustCompile(`(?s)<textarea name="document" hidden>(.*?)</textarea>`).FindStringSubmatch(page)
+	if m == nil {
+		t.Fatal("preview: no document field")
+	}
+	form.Set("document", html.UnescapeString(m[1]))
+	req := httptest.NewRequest(http.MethodPost, "/admin/settings/import/apply", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec = do(req)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("apply: got %d\n%s", rec.Code, rec.Body.String())
+	}
+	s, _ := queries.GetSettings(ctx)
+	if s.SiteName != "Bluejay Staging" || s.FooterColumns != 3 {
+		t.Errorf("apply: site_name=%q footer_columns=%d", s.SiteName, s.FooterColumns)
+	}
+
+	logs, _ := queries.ListActivityLogs(ctx, sqlc.ListActivityLogsParams{FilterResourceType: "settings", PageLimit: 20})
+	var changes []string
+	for _, l := range logs {
+		if strings.HasPrefix(l.Description, "Imported ") {
+			changes = append(changes, l.Description)
+		}
+	}
+	if len(changes) != 2 || !strings.Contains(strings.Join(changes, "\n"), `Imported site_name from staging.json: "`) {
+		t.Errorf("activity log: %q", changes)
+	}
+
+	// A stale preview is shown again rather than applied
+	form.Set("settings_version", "2000-01-01T00:00:00Z")
+	req = httptest.NewRequest(http.MethodPost, "/admin/settings/import/apply", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	if rec = do(req); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "changed since this preview") {
+		t.Errorf("stale apply: got %d", rec.Code)
+	}
+
+	// Invalid files list every problem
+	rec = upload([]byte(`{"format":"bluejay-settings","version":1,"settings":{"footer_columns":7,"mt_api_key":"k"}}`))
+	page = rec.Body.String()
+	if !strings.Contains(page, "cannot be imported. Nothing was changed.") || !strings.Contains(page, "footer_columns: must be between 2 and 4") || !strings.Contains(page, "mt_api_key: cannot be imported") {
+		t.Errorf("invalid file: expected validation errors\n%s", page)
+	}
+}
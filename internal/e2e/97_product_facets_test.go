@@ -0,0 +1,113 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	appmw "github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestProductFacets_E2E filters a category page with the real templates:
+// sidebar facets, filtered pagination over HTMX, and clean-up of unknown
+// filters.
+func TestProductFacets_E2E(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	// 14 products at 24 V (two pages when filtered), 3 at 5 V
+	for i := 0; i < 17; i++ {
+		supply := "24 V"
+		if i >= 14 {
+			supply = "5 V"
+		}
+		p, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("S-%02d", i), Slug: fmt.Sprintf("sensor-%02d", i), Name: fmt.Sprintf("Sensor %02d", i), Description: "d", CategoryID: cat.ID, Status: "published",
+		})
+		queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "Electrical", SpecKey: "Supply", SpecValue: supply})
+	}
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	cache := services.NewCache()
+	h := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), cache)
+	e.GET("/products/:category", h.ProductsByCategory, appmw.SettingsLoader(queries))
+	e.GET("/partials/products/:category", h.ProductsCategoryGrid)
+
+	get := func(path string, hx bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if hx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/products/sensors", false)
+	page := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("category page: got %d", rec.Code)
+	}
+	for _, want := range []string{
+		`hx-get="/partials/products/sensors" hx-trigger="change"`,
+		`name="spec_electrical-supply" value="5 V"`,
+		`name="spec_electrical-supply" value="24 V"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("category page: expected %q", want)
+		}
+	}
+
+	// The sidebar submits to the grid fragment; pagination keeps the filter
+	rec = get("/partials/products/sensors?spec_electrical-supply=24+V", true)
+	grid := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(grid, "14 matching products") {
+		t.Fatalf("filtered grid: got %d", rec.Code)
+	}
+	if push := rec.Header().Get("HX-Push-Url"); push != "/products/sensors?spec_electrical-supply=24+V" {
+		t.Errorf("HX-Push-Url = %q", push)
+	}
+	if !strings.Contains(grid, `hx-get="/partials/products/sensors?page=2&amp;spec_electrical-supply=24&#43;V"`) ||
+		!strings.Contains(grid, `href="/products/sensors?page=2&amp;spec_electrical-supply=24&#43;V"`) {
+		t.Error("filtered grid: next page links must carry the filter")
+	}
+	if !strings.Contains(grid, "checked") || strings.Contains(grid, "Sensor 15") {
+		t.Error("filtered grid: expected the selected value and only 24 V products")
+	}
+
+	rec = get("/products/sensors?spec_electrical-supply=5+V", false)
+	page = rec.Body.String()
+	if !strings.Contains(page, "Sensor 15") || strings.Contains(page, "Sensor 01") || strings.Contains(page, `rel="next"`) {
+		t.Error("5 V page: expected only 5 V products on one page")
+	}
+	if !strings.Contains(page, `<link rel="canonical" href="https://bluejaylabs.com/products/sensors">`) {
+		t.Error("filtered pages should be canonicalised to the unfiltered category")
+	}
+
+	// Filter and page combinations are cached separately
+	if _, ok := cache.Get("page:products:sensors:grid:f:spec_electrical-supply=24+V"); !ok {
+		t.Error("expected the filtered grid to be cached under its own key")
+	}
+
+	// Unknown filters are dropped by a redirect, and not cached
+	rec = get("/products/sensors?spec_electrical-supply=24+V&spec_electrical-supply=99+V&cert=XX", false)
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/products/sensors?spec_electrical-supply=24+V" {
+		t.Errorf("unknown filter: got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if _, ok := cache.Get("page:products:sensors:f:cert=XX&spec_electrical-supply=24+V&spec_electrical-supply=99+V"); ok {
+		t.Error("unknown filters must not be cached")
+	}
+}
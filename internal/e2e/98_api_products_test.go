@@ -0,0 +1,88 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// TestAPIProducts_CursorPagination pages through /api/v1/products with
+// next_cursor and checks filtering and parameter errors.
+func TestAPIProducts_CursorPagination(t *testing.T) {
+	e, queries, cleanup := setupApp(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sensors, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	other, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Other", Slug: "other", Description: "d", Icon: "i"})
+	for i := 0; i < 5; i++ {
+		queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: fmt.Sprintf("S-%d", i), Slug: fmt.Sprintf("s-%d", i), Name: fmt.Sprintf("Sensor %d", i), Description: "d", CategoryID: sensors.ID, Status: "published"})
+	}
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "D-1", Slug: "d-1", Name: "Draft", Description: "d", CategoryID: sensors.ID, Status: "draft"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "O-1", Slug: "o-1", Name: "Other", Description: "d", CategoryID: other.ID, Status: "published"})
+
+	type listResp struct {
+		Data []struct {
+			SKU string `json:"sku"`
+			URL string `json:"url"`
+		} `json:"data"`
+		NextCursor string `json:"next_cursor"`
+		HasMore    bool   `json:"has_more"`
+		Error      string `json:"error"`
+	}
+	get := func(target string) (int, listResp) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		var body listResp
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: invalid JSON %q", target, rec.Body.String())
+		}
+		return rec.Code, body
+	}
+
+	var skus []string
+	target := "/api/v1/products?category=sensors&limit=2"
+	for pages := 1; ; pages++ {
+		code, body := get(target)
+		if code != http.StatusOK {
+			t.Fatalf("%s: status %d", target, code)
+		}
+		for _, p := range body.Data {
+			skus = append(skus, p.SKU)
+		}
+		if !body.HasMore {
+			if pages != 3 || body.NextCursor != "" {
+				t.Errorf("last page: pages=%d next_cursor=%q", pages, body.NextCursor)
+			}
+			break
+		}
+		target = "/api/v1/products?category=sensors&limit=2&cursor=" + body.NextCursor
+	}
+	if fmt.Sprint(skus) != "[S-0 S-1 S-2 S-3 S-4]" {
+		t.Errorf("skus = %v", skus)
+	}
+
+	_, all := get("/api/v1/products")
+	if len(all.Data) != 6 || all.Data[0].URL != "/products/sensors/s-0" {
+		t.Errorf("unfiltered list = %+v", all.Data)
+	}
+
+	_, first := get("/api/v1/products?limit=1")
+	for target, want := range map[string]int{
+		"/api/v1/products?sort=price":                           http.StatusBadRequest,
+		"/api/v1/products?limit=500":                            http.StatusBadRequest,
+		"/api/v1/products?cursor=garbage":                       http.StatusBadRequest,
+		"/api/v1/products?sort=name&cursor=" + first.NextCursor: http.StatusBadRequest,
+		"/api/v1/products?category=missing":                     http.StatusNotFound,
+	} {
+		if code, body := get(target); code != want || body.Error == "" {
+			t.Errorf("%s: status %d error %q, want %d", target, code, body.Error, want)
+		}
+	}
+}
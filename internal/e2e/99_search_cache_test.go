@@ -0,0 +1,85 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/templates"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestSearchCache_NormalizedQueries checks that live and site search reuse
+// results for queries differing only in case and spacing, that a "page:"
+// purge drops them, and that very short live queries do not search.
+func TestSearchCache_NormalizedQueries(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "T-1", Slug: "temp-one", Name: "Temp Sensor One", Description: "d", CategoryID: cat.ID, Status: "published"})
+
+	e := echo.New()
+	e.Renderer = templates.NewRenderer("templates")
+	appCache := services.NewCache()
+	products := publicHandlers.NewProductsHandler(queries, testLogger, services.NewProductService(queries), appCache)
+	search := publicHandlers.NewSearchHandler(db, testLogger, appCache)
+	e.GET("/products/search", products.ProductSearch)
+	e.GET("/search/suggest", search.SearchSuggest)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("HX-Request", "true")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", target, rec.Code)
+		}
+		return rec
+	}
+
+	for _, path := range []string{"/products/search?q=", "/search/suggest?q="} {
+		if rec := get(path + "Temp+Sensor"); !strings.Contains(rec.Body.String(), "Temp Sensor One") {
+			t.Fatalf("%s: first search missed the product", path)
+		} else if !strings.Contains(rec.Header().Get("Cache-Control"), "max-age=") {
+			t.Errorf("%s: live results should be cacheable, got %q", path, rec.Header().Get("Cache-Control"))
+		}
+	}
+
+	// A product added now is not seen by a normalized repeat of the query
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "T-2", Slug: "temp-two", Name: "Temp Sensor Two", Description: "d", CategoryID: cat.ID, Status: "published"})
+	for _, path := range []string{"/products/search?q=", "/search/suggest?q="} {
+		if body := get(path + "++tEMP+++sensor+").Body.String(); !strings.Contains(body, "Temp Sensor One") || strings.Contains(body, "Temp Sensor Two") {
+			t.Errorf("%s: normalized repeat should be served from the cache", path)
+		}
+	}
+
+	// Content saves purge "page:", which includes search results
+	appCache.DeleteByPrefix("page:")
+	for _, path := range []string{"/products/search?q=", "/search/suggest?q="} {
+		if body := get(path + "temp+sensor").Body.String(); !strings.Contains(body, "Temp Sensor Two") {
+			t.Errorf("%s: results should be fresh after a purge", path)
+		}
+	}
+
+	// Letters SQLite's LIKE does not fold keep their case
+	queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "E-1", Slug: "elan", Name: "Élan Probe", Description: "d", CategoryID: cat.ID, Status: "published"})
+	for _, path := range []string{"/products/search?q=", "/search/suggest?q="} {
+		if body := get(path + "%C3%89LAN").Body.String(); !strings.Contains(body, "Élan Probe") {
+			t.Errorf("%s: search for \"ÉLAN\" missed \"Élan Probe\"", path)
+		}
+	}
+
+	for _, path := range []string{"/products/search?q=+t+", "/search/suggest?q=t"} {
+		if body := strings.TrimSpace(get(path).Body.String()); body != "" {
+			t.Errorf("%s: one-character live query returned %q", path, body)
+		}
+	}
+}
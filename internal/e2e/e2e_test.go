@@ -35,6 +35,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/database"
 	adminHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/admin"
 	publicHandlers "github.com/narendhupati/bluejay-cms/internal/handlers/public"
 	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware"
@@ -88,6 +89,12 @@ func (r *stubRenderer) Render(w io.Writer, name string, data interface{}, c echo
 	return err
 }
 
+// testPreviewTokens issues the ?preview_token= links accepted by setupApp's app.
+var testPreviewTokens = services.NewPreviewTokens("e2e-test-secret-at-least-32-characters-long", time.Hour)
+
+// testDownloadLinks issues the ?token= links to gated downloads accepted by setupApp's app.
+var testDownloadLinks = services.NewDownloadLinks("e2e-test-secret-at-least-32-characters-long", time.Hour)
+
 // setupApp creates and configures a complete Echo application instance for e2e testing.
 //
 // This function mirrors the production application setup from cmd/server/main.go but
@@ -120,6 +127,14 @@ func (r *stubRenderer) Render(w io.Writer, name string, data interface{}, c echo
 //	}
 func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	t.Helper()
+	e, _, queries, cleanup := setupAppDB(t)
+	return e, queries, cleanup
+}
+
+// setupAppDB is setupApp that also returns the database, for tests that
+// build services of their own next to the application.
+func setupAppDB(t *testing.T) (*echo.Echo, *sql.DB, *sqlc.Queries, func()) {
+	t.Helper()
 
 	db, queries, cleanup := testutil.SetupTestDB(t)
 	customMiddleware.InitSessionStore("e2e-test-secret-at-least-32-characters-long")
@@ -129,13 +144,27 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	e.Renderer = &stubRenderer{}
 	e.Use(customMiddleware.SecurityHeaders())
 	e.Use(customMiddleware.SessionMiddleware())
+	e.Use(customMiddleware.SessionTracker(queries, customMiddleware.SessionTimeouts{Idle: time.Hour, Absolute: 12 * time.Hour}))
+	e.Use(customMiddleware.TemplateDebug())
+	e.Pre(customMiddleware.MethodOverride())
 
 	// Services
 	productSvc := services.NewProductService(queries)
-	uploadSvc := services.NewUploadService(t.TempDir())
+	uploadDir := t.TempDir()
+	uploadSvc := services.NewUploadService(uploadDir)
+	downloadFiles := services.NewDownloadFiles(queries, uploadDir, t.TempDir())
 	appCache := services.NewCache()
 	activitySvc := services.NewActivityLogService(queries, testLogger)
 	adminHandlers.SetActivityLogService(activitySvc)
+	adminHandlers.SetPreviewTokens(testPreviewTokens)
+	adminHandlers.SetWorkflow(services.NewWorkflow(queries, testLogger, nil, nil, "http://localhost"))
+	adminHandlers.SetTransactor(database.NewTransactor(db))
+	adminHandlers.SetPublishChecker(services.NewPublishChecker(queries, t.TempDir(), []string{"admin"}))
+	e.Use(customMiddleware.Preview(testPreviewTokens))
+	redirectSvc := services.NewRedirects(queries, testLogger)
+	adminHandlers.SetRedirects(redirectSvc)
+	e.Use(customMiddleware.Redirects(redirectSvc))
+	e.HTTPErrorHandler = customMiddleware.NotFoundTracker(queries, testLogger, publicHandlers.NewErrorHandler(testLogger).Handle)
 
 	// Public routes
 	homeHandler := publicHandlers.NewHomeHandler(queries, testLogger)
@@ -151,8 +180,20 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	productsHandler := publicHandlers.NewProductsHandler(queries, testLogger, productSvc, appCache)
 	e.GET("/products", productsHandler.ProductsList)
 	e.GET("/products/search", productsHandler.ProductSearch)
+	e.GET("/products/a-z", productsHandler.ProductIndex)
 	e.GET("/products/:category", productsHandler.ProductsByCategory)
+	e.GET("/partials/products/:category", productsHandler.ProductsCategoryGrid)
 	e.GET("/products/:category/:slug", productsHandler.ProductDetail)
+	e.GET("/products/:category/:slug/print", productsHandler.ProductPrint)
+	productDownloadsHandler := publicHandlers.NewProductDownloadsHandler(queries, testLogger, testDownloadLinks, downloadFiles)
+	e.GET("/product-downloads/:id", productDownloadsHandler.Download)
+	e.Static("/uploads", uploadDir)
+	e.GET("/product-downloads/:id/form", productDownloadsHandler.LeadForm)
+	e.POST("/product-downloads/:id/lead", productDownloadsHandler.Lead)
+	recentlyViewedHandler := publicHandlers.NewRecentlyViewedHandler(queries, testLogger, services.NewRecentlyViewedCodec("test-secret"))
+	e.GET("/partials/recently-viewed", recentlyViewedHandler.RecentlyViewed)
+	apiHandler := publicHandlers.NewAPIHandler(queries, testLogger, productSvc)
+	e.GET("/api/v1/products", apiHandler.Products)
 
 	solutionsHandler := publicHandlers.NewSolutionsHandler(queries, testLogger, appCache)
 	e.GET("/solutions", solutionsHandler.SolutionsList)
@@ -160,10 +201,16 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 
 	blogHandler := publicHandlers.NewBlogHandler(queries, testLogger, appCache)
 	e.GET("/blog", blogHandler.BlogListing)
+	e.GET("/partials/blog", blogHandler.BlogListingGrid)
+	e.GET("/blog/:year/:month", blogHandler.BlogArchive)
+	e.GET("/blog/tags", blogHandler.TagCloud)
+	e.GET("/blog/tags/:slug", blogHandler.TagPosts)
 	e.GET("/blog/:slug", blogHandler.BlogPost)
 
 	whitepapersHandler := publicHandlers.NewWhitepapersHandler(queries, testLogger, appCache)
 	e.GET("/whitepapers", whitepapersHandler.WhitepapersList)
+	e.GET("/partials/whitepapers", whitepapersHandler.WhitepapersListGrid)
+	e.GET("/whitepapers/topics", whitepapersHandler.TopicIndex)
 	e.GET("/whitepapers/:slug", whitepapersHandler.WhitepaperDetail)
 	e.POST("/whitepapers/:slug/download", whitepapersHandler.WhitepaperDownload)
 
@@ -178,17 +225,51 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	partnersPageHandler := publicHandlers.NewPartnersHandler(queries, testLogger, appCache)
 	e.GET("/partners", partnersPageHandler.PartnersPage)
 
-	searchHandler := publicHandlers.NewSearchHandler(db, testLogger)
+	searchHandler := publicHandlers.NewSearchHandler(db, testLogger, appCache)
 	e.GET("/search", searchHandler.SearchPage)
 	e.GET("/search/suggest", searchHandler.SearchSuggest)
 
 	sitemapHandler := publicHandlers.NewSitemapHandler(queries, testLogger, "https://bluejaylabs.com")
 	e.GET("/sitemap.xml", sitemapHandler.Sitemap)
 	e.GET("/robots.txt", sitemapHandler.RobotsTxt)
+	e.GET("/theme-tokens.css", publicHandlers.NewThemeHandler(queries, testLogger).Tokens)
 
 	caseStudiesHandler := publicHandlers.NewCaseStudiesHandler(queries, testLogger, appCache)
 	e.GET("/case-studies", caseStudiesHandler.CaseStudiesList)
+	e.GET("/partials/case-studies", caseStudiesHandler.CaseStudiesListGrid)
 	e.GET("/case-studies/:slug", caseStudiesHandler.CaseStudyDetail)
+	e.GET("/case-studies/:slug/print", caseStudiesHandler.CaseStudyPrint)
+
+	eventsHandler := publicHandlers.NewEventsHandler(queries, services.NewEvents(queries, testLogger, nil, "https://example.com"), testLogger, appCache)
+	e.GET("/events", eventsHandler.List)
+	e.GET("/events/:slug", eventsHandler.Detail)
+	e.POST("/events/:slug/register", eventsHandler.Register)
+	e.GET("/events/:slug/calendar.ics", eventsHandler.Calendar)
+
+	careers := services.NewCareers(queries, services.NewLocalStorage(t.TempDir()), testLogger)
+	careersHandler := publicHandlers.NewCareersHandler(queries, careers, testLogger, appCache, "https://example.com")
+	e.GET("/careers", careersHandler.List)
+	e.GET("/careers/:slug", careersHandler.Detail)
+	e.POST("/careers/:slug/apply", careersHandler.Apply)
+
+	pressHandler := publicHandlers.NewPressHandler(queries, testLogger, appCache)
+	e.GET("/press", pressHandler.List)
+	e.GET("/press/:slug", pressHandler.Detail)
+
+	shortLinksHandler := publicHandlers.NewShortLinksHandler(queries, testLogger)
+	e.GET("/go/:code", shortLinksHandler.Follow)
+
+	docs := services.NewDocs(db, queries)
+	docsHandler := publicHandlers.NewDocsHandler(queries, testLogger, appCache)
+	e.GET("/docs", docsHandler.Index)
+	e.GET("/docs/:set", docsHandler.Set)
+	e.GET("/docs/:set/:version", docsHandler.Version)
+	e.GET("/docs/:set/:version/:page", docsHandler.Page)
+	partnerPortal := services.NewPartnerPortal(db, queries, uploadSvc, testLogger, nil, "https://example.com")
+	partnerPortalHandler := publicHandlers.NewPartnerPortalHandler(partnerPortal, queries, testLogger)
+	partnerPortalAuth := customMiddleware.Authenticate(partnerPortalHandler.Authenticator())
+	e.GET("/partner-portal/:token", partnerPortalHandler.Page, partnerPortalAuth)
+	e.POST("/partner-portal/:token", partnerPortalHandler.Submit, partnerPortalAuth)
 
 	// Admin auth routes
 	authHandler := adminHandlers.NewAuthHandler(queries, testLogger)
@@ -198,9 +279,35 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 
 	// Admin protected routes
 	adminGroup := e.Group("/admin", customMiddleware.RequireAuth())
+	adminGroup.Use(customMiddleware.NewFormTokens(time.Hour).Middleware())
+	adminGroup.Use(customMiddleware.VisitorTimezone(services.TimezoneFromAcceptLanguage))
+
+	// No-script fallbacks for the HTMX sub-resource routes, as in main.go
+	productTab := func(title, tab string) echo.MiddlewareFunc {
+		return adminHandlers.HTMXFallback(adminHandlers.Fallback{Title: title, List: "/admin/products/:id/" + tab, Back: "/admin/products/:id/edit"})
+	}
+	specsPage := productTab("Product Specifications", "specs")
+	featuresPage := productTab("Product Features", "features")
+	certificationsPage := productTab("Product Certifications", "certifications")
+	downloadsPage := productTab("Product Downloads", "downloads")
+	imagesPage := productTab("Product Images", "images")
+	variantsPage := productTab("Product Variants", "variants")
+	relatedPage := productTab("Related Content", "related")
+	solutionTab := func(title, tab string) echo.MiddlewareFunc {
+		return adminHandlers.HTMXFallback(adminHandlers.Fallback{Title: title, List: "/admin/solutions/:id/" + tab + "-tab", Back: "/admin/solutions/:id/edit"})
+	}
+	challengesPage := solutionTab("Solution Challenges", "challenges")
+	solutionProductsPage := solutionTab("Solution Products", "products")
+	statsPage := solutionTab("Solution Stats", "stats")
+	ctasPage := solutionTab("Solution CTAs", "ctas")
+	caseStudyEditor := adminHandlers.HTMXFallback(adminHandlers.Fallback{Back: "/admin/case-studies/:id/edit"})
+	seriesEditor := adminHandlers.HTMXFallback(adminHandlers.Fallback{Back: "/admin/blog/series/:id/edit"})
+	backToReferrer := adminHandlers.HTMXFallback(adminHandlers.Fallback{})
 
 	dashHandler := adminHandlers.NewDashboardHandler(queries, testLogger)
 	adminGroup.GET("/dashboard", dashHandler.ShowDashboard)
+	adminGroup.GET("/dashboard/cards/:card", dashHandler.Card)
+	adminGroup.GET("/search", adminHandlers.NewAdminSearchHandler(queries, testLogger).Search)
 
 	// Product categories
 	pcHandler := adminHandlers.NewProductCategoriesHandler(queries, testLogger)
@@ -268,6 +375,10 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	settingsHandler := adminHandlers.NewSettingsHandler(queries, testLogger, appCache)
 	adminGroup.GET("/settings", settingsHandler.Edit)
 	adminGroup.POST("/settings", settingsHandler.Update)
+	adminGroup.GET("/settings/export", settingsHandler.Export)
+	adminGroup.GET("/settings/import", settingsHandler.Import)
+	adminGroup.POST("/settings/import", settingsHandler.ImportPreview)
+	adminGroup.POST("/settings/import/apply", settingsHandler.ImportApply)
 
 	// Page sections
 	psHandler := adminHandlers.NewPageSectionsHandler(queries, testLogger)
@@ -275,6 +386,9 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.GET("/page-sections/:id/edit", psHandler.Edit)
 	adminGroup.POST("/page-sections/:id", psHandler.Update)
 
+	// Save as copy
+	duplicateHandler := adminHandlers.NewDuplicateHandler(services.NewContentDuplicator(db, queries), testLogger)
+
 	// Products
 	adminProductsHandler := adminHandlers.NewProductsHandler(queries, testLogger, uploadSvc, appCache)
 	adminGroup.GET("/products", adminProductsHandler.List)
@@ -283,32 +397,51 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.GET("/products/:id/edit", adminProductsHandler.Edit)
 	adminGroup.POST("/products/:id", adminProductsHandler.Update)
 	adminGroup.DELETE("/products/:id", adminProductsHandler.Delete)
+	adminGroup.PATCH("/products/:id/inline", adminProductsHandler.InlineUpdate)
+	adminGroup.POST("/products/:id/duplicate", duplicateHandler.Product)
+	adminGroup.GET("/products/export", adminProductsHandler.Export)
+	productImportHandler := adminHandlers.NewProductImportHandler(services.NewProductImporter(db, queries), testLogger, appCache)
+	adminGroup.GET("/products/import", productImportHandler.Show)
+	adminGroup.POST("/products/import", productImportHandler.Upload)
+	adminGroup.POST("/products/import/run", productImportHandler.Run)
 
 	// Product details (specs, features, certs, downloads, images)
-	pdHandler := adminHandlers.NewProductDetailsHandler(queries, testLogger, uploadSvc)
-	adminGroup.GET("/products/:id/specs", pdHandler.ListSpecs)
-	adminGroup.POST("/products/:id/specs", pdHandler.AddSpec)
-	adminGroup.DELETE("/products/:id/specs", pdHandler.DeleteSpecs)
-	adminGroup.DELETE("/products/:id/specs/:spec_id", pdHandler.DeleteSpec)
-	adminGroup.POST("/products/:id/specs/:spec_id", pdHandler.UpdateSpec)
-	adminGroup.GET("/products/:id/features", pdHandler.ListFeatures)
-	adminGroup.POST("/products/:id/features", pdHandler.AddFeature)
-	adminGroup.DELETE("/products/:id/features", pdHandler.DeleteFeatures)
-	adminGroup.DELETE("/products/:id/features/:feature_id", pdHandler.DeleteFeature)
-	adminGroup.POST("/products/:id/features/:feature_id", pdHandler.UpdateFeature)
-	adminGroup.GET("/products/:id/certifications", pdHandler.ListCertifications)
-	adminGroup.POST("/products/:id/certifications", pdHandler.AddCertification)
-	adminGroup.DELETE("/products/:id/certifications", pdHandler.DeleteCertifications)
-	adminGroup.DELETE("/products/:id/certifications/:cert_id", pdHandler.DeleteCertification)
-	adminGroup.POST("/products/:id/certifications/:cert_id", pdHandler.UpdateCertification)
-	adminGroup.GET("/products/:id/downloads", pdHandler.ListDownloads)
-	adminGroup.POST("/products/:id/downloads", pdHandler.AddDownload)
-	adminGroup.DELETE("/products/:id/downloads/:download_id", pdHandler.DeleteDownload)
-	adminGroup.POST("/products/:id/downloads/:download_id", pdHandler.UpdateDownload)
-	adminGroup.GET("/products/:id/images", pdHandler.ListImages)
-	adminGroup.POST("/products/:id/images", pdHandler.AddImage)
-	adminGroup.DELETE("/products/:id/images/:image_id", pdHandler.DeleteImage)
-	adminGroup.POST("/products/:id/images/:image_id", pdHandler.UpdateImage)
+	pdHandler := adminHandlers.NewProductDetailsHandler(queries, testLogger, uploadSvc, downloadFiles)
+	adminGroup.GET("/products/:id/specs", pdHandler.ListSpecs, specsPage)
+	adminGroup.POST("/products/:id/specs", pdHandler.AddSpec, specsPage)
+	adminGroup.DELETE("/products/:id/specs", pdHandler.DeleteSpecs, specsPage)
+	adminGroup.DELETE("/products/:id/specs/:spec_id", pdHandler.DeleteSpec, specsPage)
+	adminGroup.POST("/products/:id/specs/:spec_id", pdHandler.UpdateSpec, specsPage)
+	adminGroup.GET("/products/:id/features", pdHandler.ListFeatures, featuresPage)
+	adminGroup.POST("/products/:id/features", pdHandler.AddFeature, featuresPage)
+	adminGroup.DELETE("/products/:id/features", pdHandler.DeleteFeatures, featuresPage)
+	adminGroup.DELETE("/products/:id/features/:feature_id", pdHandler.DeleteFeature, featuresPage)
+	adminGroup.POST("/products/:id/features/:feature_id", pdHandler.UpdateFeature, featuresPage)
+	adminGroup.GET("/products/:id/certifications", pdHandler.ListCertifications, certificationsPage)
+	adminGroup.POST("/products/:id/certifications", pdHandler.AddCertification, certificationsPage)
+	adminGroup.DELETE("/products/:id/certifications", pdHandler.DeleteCertifications, certificationsPage)
+	adminGroup.DELETE("/products/:id/certifications/:cert_id", pdHandler.DeleteCertification, certificationsPage)
+	adminGroup.POST("/products/:id/certifications/:cert_id", pdHandler.UpdateCertification, certificationsPage)
+	adminGroup.GET("/products/:id/downloads", pdHandler.ListDownloads, downloadsPage)
+	adminGroup.POST("/products/:id/downloads", pdHandler.AddDownload, downloadsPage)
+	adminGroup.DELETE("/products/:id/downloads/:download_id", pdHandler.DeleteDownload, downloadsPage)
+	adminGroup.POST("/products/:id/downloads/:download_id", pdHandler.UpdateDownload, downloadsPage)
+	adminGroup.GET("/products/:id/downloads/:download_id/file", pdHandler.DownloadFile)
+	adminGroup.GET("/products/:id/images", pdHandler.ListImages, imagesPage)
+	adminGroup.POST("/products/:id/images", pdHandler.AddImage, imagesPage)
+	adminGroup.DELETE("/products/:id/images/:image_id", pdHandler.DeleteImage, imagesPage)
+	adminGroup.POST("/products/:id/images/:image_id", pdHandler.UpdateImage, imagesPage)
+	adminGroup.GET("/products/:id/variants", pdHandler.ListVariants, variantsPage)
+	adminGroup.POST("/products/:id/variants", pdHandler.AddVariant, variantsPage)
+	adminGroup.POST("/products/:id/variants/:variant_id", pdHandler.UpdateVariant, variantsPage)
+	adminGroup.DELETE("/products/:id/variants/:variant_id", pdHandler.DeleteVariant, variantsPage)
+	adminGroup.POST("/products/:id/variants/:variant_id/specs", pdHandler.AddVariantSpec, variantsPage)
+	adminGroup.DELETE("/products/:id/variants/:variant_id/specs/:spec_id", pdHandler.DeleteVariantSpec, variantsPage)
+	adminGroup.POST("/products/:id/variants/:variant_id/images", pdHandler.AddVariantImage, variantsPage)
+	adminGroup.DELETE("/products/:id/variants/:variant_id/images/:image_id", pdHandler.DeleteVariantImage, variantsPage)
+	adminGroup.GET("/products/:id/related", pdHandler.ListRelatedContent, relatedPage)
+	adminGroup.POST("/products/:id/related", pdHandler.AddRelatedPin, relatedPage)
+	adminGroup.DELETE("/products/:id/related/:pin_id", pdHandler.DeleteRelatedPin, relatedPage)
 
 	// Blog posts
 	adminBlogPostsHandler := adminHandlers.NewBlogPostsHandler(queries, testLogger, appCache)
@@ -317,7 +450,10 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.POST("/blog/posts", adminBlogPostsHandler.Create)
 	adminGroup.GET("/blog/posts/:id/edit", adminBlogPostsHandler.Edit)
 	adminGroup.POST("/blog/posts/:id", adminBlogPostsHandler.Update)
+	adminGroup.POST("/blog/posts/markdown-preview", adminBlogPostsHandler.MarkdownPreview)
 	adminGroup.DELETE("/blog/posts/:id", adminBlogPostsHandler.Delete)
+	adminGroup.PATCH("/blog/posts/:id/inline", adminBlogPostsHandler.InlineUpdate)
+	adminGroup.POST("/blog/posts/:id/duplicate", duplicateHandler.BlogPost)
 	adminGroup.GET("/blog/products/search", adminBlogPostsHandler.SearchProducts)
 
 	// Blog tags
@@ -325,36 +461,47 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.GET("/blog/tags", adminBlogTagsHandler.List)
 	adminGroup.POST("/blog/tags", adminBlogTagsHandler.Create)
 	adminGroup.GET("/blog/tags/search", adminBlogTagsHandler.Search)
-	adminGroup.POST("/blog/tags/quick-create", adminBlogTagsHandler.QuickCreate)
+	adminGroup.POST("/blog/tags/quick-create", adminBlogTagsHandler.QuickCreate, backToReferrer)
 	adminGroup.DELETE("/blog/tags/:id", adminBlogTagsHandler.Delete)
 
+	adminBlogSeriesHandler := adminHandlers.NewBlogSeriesHandler(queries, testLogger, appCache)
+	adminGroup.GET("/blog/series", adminBlogSeriesHandler.List)
+	adminGroup.GET("/blog/series/new", adminBlogSeriesHandler.New)
+	adminGroup.POST("/blog/series", adminBlogSeriesHandler.Create)
+	adminGroup.GET("/blog/series/:id/edit", adminBlogSeriesHandler.Edit)
+	adminGroup.POST("/blog/series/:id", adminBlogSeriesHandler.Update)
+	adminGroup.DELETE("/blog/series/:id", adminBlogSeriesHandler.Delete)
+	adminGroup.POST("/blog/series/:id/posts", adminBlogSeriesHandler.AssignPost, seriesEditor)
+	adminGroup.DELETE("/blog/series/:id/posts/:post_id", adminBlogSeriesHandler.RemovePost, seriesEditor)
+
 	// Solutions
 	adminSolutionsHandler := adminHandlers.NewSolutionsHandler(queries, testLogger, appCache, uploadSvc)
 	adminGroup.GET("/solutions", adminSolutionsHandler.List)
 	adminGroup.GET("/solutions/new", adminSolutionsHandler.New)
 	adminGroup.POST("/solutions", adminSolutionsHandler.Create)
 	adminGroup.GET("/solutions/:id/edit", adminSolutionsHandler.Edit)
-	adminGroup.GET("/solutions/:id/challenges-tab", adminSolutionsHandler.ChallengesTab)
-	adminGroup.GET("/solutions/:id/products-tab", adminSolutionsHandler.ProductsTab)
-	adminGroup.GET("/solutions/:id/stats-tab", adminSolutionsHandler.StatsTab)
-	adminGroup.GET("/solutions/:id/ctas-tab", adminSolutionsHandler.CTAsTab)
+	adminGroup.GET("/solutions/:id/challenges-tab", adminSolutionsHandler.ChallengesTab, challengesPage)
+	adminGroup.GET("/solutions/:id/products-tab", adminSolutionsHandler.ProductsTab, solutionProductsPage)
+	adminGroup.GET("/solutions/:id/stats-tab", adminSolutionsHandler.StatsTab, statsPage)
+	adminGroup.GET("/solutions/:id/ctas-tab", adminSolutionsHandler.CTAsTab, ctasPage)
 	adminGroup.POST("/solutions/:id", adminSolutionsHandler.Update)
 	adminGroup.DELETE("/solutions/:id", adminSolutionsHandler.Delete)
-	adminGroup.POST("/solutions/:id/stats", adminSolutionsHandler.AddStat)
-	adminGroup.DELETE("/solutions/:id/stats/:statId", adminSolutionsHandler.DeleteStat)
-	adminGroup.POST("/solutions/:id/challenges", adminSolutionsHandler.AddChallenge)
-	adminGroup.DELETE("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.DeleteChallenge)
-	adminGroup.POST("/solutions/:id/products", adminSolutionsHandler.AddProduct)
-	adminGroup.DELETE("/solutions/:id/products/:productId", adminSolutionsHandler.RemoveProduct)
-	adminGroup.POST("/solutions/:id/ctas", adminSolutionsHandler.AddCTA)
-	adminGroup.DELETE("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.DeleteCTA)
-	adminGroup.POST("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.UpdateChallenge)
-	adminGroup.POST("/solutions/:id/stats/:statId", adminSolutionsHandler.UpdateStat)
-	adminGroup.POST("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.UpdateCTA)
-	adminGroup.POST("/solutions/:id/products/:productId", adminSolutionsHandler.UpdateProduct)
+	adminGroup.POST("/solutions/:id/duplicate", duplicateHandler.Solution)
+	adminGroup.POST("/solutions/:id/stats", adminSolutionsHandler.AddStat, statsPage)
+	adminGroup.DELETE("/solutions/:id/stats/:statId", adminSolutionsHandler.DeleteStat, statsPage)
+	adminGroup.POST("/solutions/:id/challenges", adminSolutionsHandler.AddChallenge, challengesPage)
+	adminGroup.DELETE("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.DeleteChallenge, challengesPage)
+	adminGroup.POST("/solutions/:id/products", adminSolutionsHandler.AddProduct, solutionProductsPage)
+	adminGroup.DELETE("/solutions/:id/products/:productId", adminSolutionsHandler.RemoveProduct, solutionProductsPage)
+	adminGroup.POST("/solutions/:id/ctas", adminSolutionsHandler.AddCTA, ctasPage)
+	adminGroup.DELETE("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.DeleteCTA, ctasPage)
+	adminGroup.POST("/solutions/:id/challenges/:challengeId", adminSolutionsHandler.UpdateChallenge, challengesPage)
+	adminGroup.POST("/solutions/:id/stats/:statId", adminSolutionsHandler.UpdateStat, statsPage)
+	adminGroup.POST("/solutions/:id/ctas/:ctaId", adminSolutionsHandler.UpdateCTA, ctasPage)
+	adminGroup.POST("/solutions/:id/products/:productId", adminSolutionsHandler.UpdateProduct, solutionProductsPage)
 
 	// Whitepapers admin
-	adminWhitepapersHandler := adminHandlers.NewWhitepapersHandler(queries, testLogger, appCache)
+	adminWhitepapersHandler := adminHandlers.NewWhitepapersHandler(queries, testLogger, appCache, "public/uploads")
 	adminGroup.GET("/whitepapers", adminWhitepapersHandler.List)
 	adminGroup.GET("/whitepapers/new", adminWhitepapersHandler.New)
 	adminGroup.POST("/whitepapers", adminWhitepapersHandler.Create)
@@ -393,7 +540,7 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.POST("/homepage/settings", homepageAdminHandler.UpdateSettings)
 
 	// Section settings
-	sectionSettingsHandler := adminHandlers.NewSectionSettingsHandler(queries, testLogger)
+	sectionSettingsHandler := adminHandlers.NewSectionSettingsHandler(queries, testLogger, appCache)
 	adminGroup.GET("/about/settings", sectionSettingsHandler.AboutSettings)
 	adminGroup.POST("/about/settings", sectionSettingsHandler.UpdateAboutSettings)
 	adminGroup.GET("/products/settings", sectionSettingsHandler.ProductsSettings)
@@ -442,6 +589,13 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.GET("/partners/testimonials/:id/edit", adminPartnersHandler.TestimonialEdit)
 	adminGroup.POST("/partners/testimonials/:id", adminPartnersHandler.TestimonialUpdate)
 	adminGroup.DELETE("/partners/testimonials/:id", adminPartnersHandler.TestimonialDelete)
+	adminPartnerPortalHandler := adminHandlers.NewPartnerPortalHandler(queries, partnerPortal, testLogger, appCache)
+	adminGroup.GET("/partners/:id/portal", adminPartnerPortalHandler.Portal)
+	adminGroup.POST("/partners/:id/invites", adminPartnerPortalHandler.Invite)
+	adminGroup.POST("/partners/invites/:id/revoke", adminPartnerPortalHandler.RevokeInvite)
+	adminGroup.GET("/partners/submissions", adminPartnerPortalHandler.Submissions)
+	adminGroup.GET("/partners/submissions/:id", adminPartnerPortalHandler.Submission)
+	adminGroup.POST("/partners/submissions/:id/review", adminPartnerPortalHandler.Review)
 
 	// Media library
 	mediaHandler := adminHandlers.NewMediaHandler(queries, testLogger, t.TempDir())
@@ -451,22 +605,56 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.GET("/media/:id", mediaHandler.GetFile)
 	adminGroup.PUT("/media/:id", mediaHandler.UpdateAltText)
 	adminGroup.DELETE("/media/:id", mediaHandler.Delete)
+	mediaImportHandler := adminHandlers.NewMediaImportHandler(services.NewMediaImporter(queries, t.TempDir()), testLogger, appCache, t.TempDir())
+	adminGroup.GET("/media/import", mediaImportHandler.Show)
+	adminGroup.POST("/media/import", mediaImportHandler.Start)
+	adminGroup.GET("/media/import/:id", mediaImportHandler.Status)
 
 	// Navigation
-	navHandler := adminHandlers.NewNavigationHandler(queries, testLogger)
+	navHandler := adminHandlers.NewNavigationHandler(queries, testLogger, nil, nil)
 	adminGroup.GET("/navigation", navHandler.List)
 	adminGroup.POST("/navigation", navHandler.Create)
 	adminGroup.GET("/navigation/:id", navHandler.Edit)
 	adminGroup.POST("/navigation/:id/settings", navHandler.UpdateMenu)
 	adminGroup.POST("/navigation/:id/items", navHandler.AddItem)
 	adminGroup.POST("/navigation/items/:id", navHandler.UpdateItem)
-	adminGroup.DELETE("/navigation/items/:id", navHandler.DeleteItem)
+	adminGroup.DELETE("/navigation/items/:id", navHandler.DeleteItem, backToReferrer)
 	adminGroup.DELETE("/navigation/:id", navHandler.DeleteMenu)
 	adminGroup.POST("/navigation/:id/reorder", navHandler.Reorder)
 
 	// Activity log
-	activityHandler := adminHandlers.NewActivityHandler(queries, testLogger)
+	activityHandler := adminHandlers.NewActivityHandler(queries, testLogger, nil)
 	adminGroup.GET("/activity", activityHandler.List)
+	adminGroup.GET("/activity/export.csv", activityHandler.Export)
+
+	trashHandler := adminHandlers.NewTrashHandler(queries, testLogger, appCache, nil)
+	adminGroup.GET("/trash", trashHandler.List)
+	adminGroup.POST("/trash/:type/:id/restore", trashHandler.Restore)
+	adminGroup.POST("/trash/:type/:id/delete", trashHandler.Delete)
+
+	// Publishing workflow
+	workflowHandler := adminHandlers.NewWorkflowHandler(queries, testLogger, appCache)
+	reviewPanel := adminHandlers.HTMXFallback(adminHandlers.Fallback{Title: "Review"})
+	adminGroup.GET("/review-queue", workflowHandler.ReviewQueue)
+	adminGroup.GET("/workflow/:type/:id", workflowHandler.Panel, reviewPanel)
+	adminGroup.POST("/workflow/:type/:id/transition", workflowHandler.Transition, reviewPanel)
+	adminGroup.POST("/workflow/:type/:id/reviewer", workflowHandler.AssignReviewer, reviewPanel)
+
+	editLocksHandler := adminHandlers.NewEditLocksHandler(services.NewEditLocks(queries, 0), testLogger)
+	adminGroup.POST("/locks/:type/:id", editLocksHandler.Heartbeat)
+	adminGroup.POST("/locks/:type/:id/release", editLocksHandler.Release)
+
+	// Publish checklist
+	publishChecklistHandler := adminHandlers.NewPublishChecklistHandler(testLogger)
+	adminGroup.POST("/publish-checklist/:type", publishChecklistHandler.Check)
+
+	// Profile
+	sessionsHandler := adminHandlers.NewSessionsHandler(queries, testLogger)
+	adminGroup.GET("/profile/sessions", sessionsHandler.List)
+	adminGroup.POST("/profile/sessions/:id/revoke", sessionsHandler.Revoke)
+	adminGroup.POST("/profile/sessions/revoke-others", sessionsHandler.RevokeOthers)
+	adminGroup.POST("/profile/sessions/revoke-all", sessionsHandler.RevokeAll)
+	adminGroup.POST("/session/keepalive", sessionsHandler.KeepAlive)
 
 	// Contact admin
 	adminContactHandler := adminHandlers.NewAdminContactHandler(queries, testLogger, appCache)
@@ -475,6 +663,18 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.POST("/contact/submissions/:id/status", adminContactHandler.UpdateSubmissionStatus)
 	adminGroup.POST("/contact/submissions/bulk-mark-read", adminContactHandler.BulkMarkRead)
 	adminGroup.DELETE("/contact/submissions/:id", adminContactHandler.DeleteSubmission)
+
+	// Leads
+	leadsHandler := adminHandlers.NewLeadsHandler(services.NewLeadService(queries), testLogger)
+	adminGroup.GET("/leads", leadsHandler.List)
+	adminGroup.POST("/leads/merge", leadsHandler.Merge)
+	adminGroup.POST("/leads/unmerge", leadsHandler.Unmerge)
+	customersHandler := adminHandlers.NewCustomersHandler(queries, testLogger)
+	adminGroup.GET("/customers", customersHandler.List)
+	adminGroup.DELETE("/customers/:id", customersHandler.Delete)
+	subscribersHandler := adminHandlers.NewSubscribersHandler(queries, services.NewNewsletter(queries, testLogger, nil, nil, "https://example.com"), testLogger)
+	adminGroup.GET("/subscribers", subscribersHandler.List)
+	adminGroup.DELETE("/subscribers/:id", subscribersHandler.Delete)
 	adminGroup.GET("/contact/offices", adminContactHandler.ListOffices)
 	adminGroup.GET("/contact/offices/new", adminContactHandler.NewOffice)
 	adminGroup.POST("/contact/offices", adminContactHandler.CreateOffice)
@@ -490,12 +690,99 @@ func setupApp(t *testing.T) (*echo.Echo, *sqlc.Queries, func()) {
 	adminGroup.GET("/case-studies/:id/edit", adminCaseStudiesHandler.Edit)
 	adminGroup.POST("/case-studies/:id", adminCaseStudiesHandler.Update)
 	adminGroup.DELETE("/case-studies/:id", adminCaseStudiesHandler.Delete)
-	adminGroup.POST("/case-studies/:id/products", adminCaseStudiesHandler.AddProduct)
-	adminGroup.DELETE("/case-studies/:id/products/:productId", adminCaseStudiesHandler.RemoveProduct)
-	adminGroup.POST("/case-studies/:id/metrics", adminCaseStudiesHandler.AddMetric)
-	adminGroup.DELETE("/case-studies/:id/metrics/:metricId", adminCaseStudiesHandler.DeleteMetric)
-
-	return e, queries, cleanup
+	adminGroup.PATCH("/case-studies/:id/inline", adminCaseStudiesHandler.InlineUpdate)
+	adminGroup.POST("/case-studies/:id/duplicate", duplicateHandler.CaseStudy)
+	adminGroup.POST("/case-studies/:id/products", adminCaseStudiesHandler.AddProduct, caseStudyEditor)
+	adminGroup.DELETE("/case-studies/:id/products/:productId", adminCaseStudiesHandler.RemoveProduct, caseStudyEditor)
+	adminGroup.POST("/case-studies/:id/metrics", adminCaseStudiesHandler.AddMetric, caseStudyEditor)
+	adminGroup.DELETE("/case-studies/:id/metrics/:metricId", adminCaseStudiesHandler.DeleteMetric, caseStudyEditor)
+
+	// Events admin
+	adminEventsHandler := adminHandlers.NewEventsHandler(queries, testLogger, appCache)
+	adminGroup.GET("/events", adminEventsHandler.List)
+	adminGroup.GET("/events/new", adminEventsHandler.New)
+	adminGroup.POST("/events", adminEventsHandler.Create)
+	adminGroup.GET("/events/:id/edit", adminEventsHandler.Edit)
+	adminGroup.POST("/events/:id", adminEventsHandler.Update)
+	adminGroup.DELETE("/events/:id", adminEventsHandler.Delete)
+	adminGroup.GET("/events/:id/registrations", adminEventsHandler.Registrations)
+
+	// Careers admin
+	adminCareersHandler := adminHandlers.NewCareersHandler(queries, careers, testLogger, appCache)
+	adminGroup.GET("/careers", adminCareersHandler.List)
+	adminGroup.GET("/careers/new", adminCareersHandler.New)
+	adminGroup.POST("/careers", adminCareersHandler.Create)
+	adminGroup.GET("/careers/:id/edit", adminCareersHandler.Edit)
+	adminGroup.POST("/careers/:id", adminCareersHandler.Update)
+	adminGroup.DELETE("/careers/:id", adminCareersHandler.Delete)
+	adminGroup.GET("/careers/applications", adminCareersHandler.Applications)
+	adminGroup.GET("/careers/applications/:id", adminCareersHandler.Application)
+	adminGroup.POST("/careers/applications/:id/status", adminCareersHandler.UpdateApplicationStatus)
+	adminGroup.GET("/careers/applications/:id/cv", adminCareersHandler.DownloadCV)
+	adminGroup.DELETE("/careers/applications/:id", adminCareersHandler.DeleteApplication)
+
+	// Press admin
+	adminPressHandler := adminHandlers.NewPressHandler(queries, testLogger, appCache, uploadSvc, uploadDir)
+	adminGroup.GET("/press", adminPressHandler.List)
+	adminGroup.GET("/press/new", adminPressHandler.New)
+	adminGroup.POST("/press", adminPressHandler.Create)
+	adminGroup.GET("/press/:id/edit", adminPressHandler.Edit)
+	adminGroup.POST("/press/:id", adminPressHandler.Update)
+	adminGroup.DELETE("/press/:id", adminPressHandler.Delete)
+	adminGroup.GET("/press/mentions", adminPressHandler.Mentions)
+	adminGroup.GET("/press/mentions/new", adminPressHandler.NewMention)
+	adminGroup.POST("/press/mentions", adminPressHandler.CreateMention)
+	adminGroup.GET("/press/mentions/:id/edit", adminPressHandler.EditMention)
+	adminGroup.POST("/press/mentions/:id", adminPressHandler.UpdateMention)
+	adminGroup.DELETE("/press/mentions/:id", adminPressHandler.DeleteMention)
+	adminGroup.GET("/press/media-kit", adminPressHandler.MediaKit)
+	adminGroup.POST("/press/media-kit", adminPressHandler.UploadKitAsset)
+	adminGroup.DELETE("/press/media-kit/:id", adminPressHandler.DeleteKitAsset)
+
+	// Docs admin
+	adminDocsHandler := adminHandlers.NewDocsHandler(queries, docs, testLogger, appCache)
+	adminGroup.GET("/docs", adminDocsHandler.List)
+	adminGroup.GET("/docs/new", adminDocsHandler.New)
+	adminGroup.POST("/docs", adminDocsHandler.Create)
+	adminGroup.GET("/docs/:id/edit", adminDocsHandler.Edit)
+	adminGroup.POST("/docs/:id", adminDocsHandler.Update)
+	adminGroup.DELETE("/docs/:id", adminDocsHandler.Delete)
+	adminGroup.POST("/docs/:id/versions", adminDocsHandler.AddVersion)
+	adminGroup.GET("/docs/versions/:id", adminDocsHandler.Pages)
+	adminGroup.POST("/docs/versions/:id/current", adminDocsHandler.MakeCurrent)
+	adminGroup.DELETE("/docs/versions/:id", adminDocsHandler.DeleteVersion)
+	adminGroup.GET("/docs/versions/:id/pages/new", adminDocsHandler.NewPage)
+	adminGroup.POST("/docs/versions/:id/pages", adminDocsHandler.CreatePage)
+	adminGroup.GET("/docs/pages/:id/edit", adminDocsHandler.EditPage)
+	adminGroup.POST("/docs/pages/:id", adminDocsHandler.UpdatePage)
+	adminGroup.DELETE("/docs/pages/:id", adminDocsHandler.DeletePage)
+
+	// Redirects
+	redirectsHandler := adminHandlers.NewRedirectsHandler(queries, testLogger, redirectSvc)
+	adminGroup.GET("/redirects", redirectsHandler.List)
+	adminGroup.POST("/redirects", redirectsHandler.Create)
+	adminGroup.POST("/redirects/:id", redirectsHandler.Update)
+	adminGroup.DELETE("/redirects/:id", redirectsHandler.Delete, backToReferrer)
+
+	// Short links
+	adminShortLinksHandler := adminHandlers.NewShortLinksHandler(queries, testLogger, "https://example.com")
+	adminGroup.GET("/short-links", adminShortLinksHandler.List)
+	adminGroup.GET("/short-links/new", adminShortLinksHandler.New)
+	adminGroup.POST("/short-links", adminShortLinksHandler.Create)
+	adminGroup.GET("/short-links/:id/edit", adminShortLinksHandler.Edit)
+	adminGroup.POST("/short-links/:id", adminShortLinksHandler.Update)
+	adminGroup.DELETE("/short-links/:id", adminShortLinksHandler.Delete, backToReferrer)
+
+	// 404 report
+	notFoundHandler := adminHandlers.NewNotFoundReportHandler(queries, testLogger)
+	adminGroup.GET("/404s", notFoundHandler.List)
+	adminGroup.DELETE("/404s", notFoundHandler.Delete, backToReferrer)
+
+	return e, db, queries, func() {
+		// Tests that build their own router must not open transactions on a closed db
+		adminHandlers.SetTransactor(nil)
+		cleanup()
+	}
 }
 
 // createTestAdmin creates a test admin user in the database for authentication tests.
@@ -947,11 +1234,10 @@ func TestLogout_E2E(t *testing.T) {
 // This test ensures:
 //   - Authenticated admin users can delete products via DELETE request
 //   - Successful deletion returns HTTP 200 OK
-//   - The product is removed from the database
-//   - Attempting to fetch the deleted product returns sql.ErrNoRows
+//   - The product is moved to the trash (deleted_at set) rather than removed
 //
 // The test creates a test product, deletes it via the admin endpoint, then verifies
-// the product no longer exists in the database.
+// the product is still in the database with deleted_at set.
 func TestAdminProductDelete_E2E(t *testing.T) {
 	// Set up application and authenticate
 	e, queries, cleanup := setupApp(t)
@@ -991,10 +1277,12 @@ func TestAdminProductDelete_E2E(t *testing.T) {
 		t.Errorf("expected 200, got %d", rec.Code)
 	}
 
-	// Step 2: Verify the product was removed from the database
-	// Attempting to fetch it should return sql.ErrNoRows
-	_, err := queries.GetProduct(ctx, prod.ID)
-	if err != sql.ErrNoRows {
-		t.Errorf("expected ErrNoRows after delete, got %v", err)
+	// Step 2: Verify the product was moved to the trash, not removed
+	trashed, err := queries.GetProduct(ctx, prod.ID)
+	if err != nil {
+		t.Fatalf("expected the product to be kept in the trash: %v", err)
+	}
+	if !trashed.DeletedAt.Valid {
+		t.Error("expected deleted_at to be set")
 	}
 }
@@ -22,9 +22,9 @@ import (
 // It handles CRUD operations for company overview, mission/vision/values,
 // core values, milestones, and certifications.
 type AboutHandler struct {
-	queries *sqlc.Queries    // Database query interface generated by sqlc
-	logger  *slog.Logger     // Structured logger for error and event logging
-	cache   *services.Cache  // Cache service for invalidating page-level caches
+	queries sqlc.Querier    // Database query interface generated by sqlc
+	logger  *slog.Logger    // Structured logger for error and event logging
+	cache   *services.Cache // Cache service for invalidating page-level caches
 }
 
 // NewAboutHandler creates a new AboutHandler instance with required dependencies.
@@ -32,7 +32,7 @@ type AboutHandler struct {
 //   - queries: sqlc-generated database query interface
 //   - logger: structured logger for error and event tracking
 //   - cache: cache service for page invalidation
-func NewAboutHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *AboutHandler {
+func NewAboutHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *AboutHandler {
 	return &AboutHandler{queries: queries, logger: logger, cache: cache}
 }
 
@@ -81,7 +81,7 @@ func (h *AboutHandler) OverviewUpdate(c echo.Context) error {
 		CompanyImageUrl:      sql.NullString{String: c.FormValue("company_image_url"), Valid: c.FormValue("company_image_url") != ""},
 	})
 	if err != nil {
-		h.logger.Error("failed to upsert company overview", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to upsert company overview", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -138,7 +138,7 @@ func (h *AboutHandler) MVVUpdate(c echo.Context) error {
 		ValuesIcon:    c.FormValue("values_icon"),
 	})
 	if err != nil {
-		h.logger.Error("failed to upsert mvv", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to upsert mvv", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -169,7 +169,7 @@ func (h *AboutHandler) CoreValuesList(c echo.Context) error {
 	// Fetch all core values from database, ordered by display_order
 	items, err := h.queries.ListCoreValues(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list core values", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list core values", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -218,7 +218,7 @@ func (h *AboutHandler) CoreValueCreate(c echo.Context) error {
 		DisplayOrder: order,                       // Controls sort order on frontend
 	})
 	if err != nil {
-		h.logger.Error("failed to create core value", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create core value", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -283,7 +283,7 @@ func (h *AboutHandler) CoreValueUpdate(c echo.Context) error {
 		ID:           id, // WHERE clause identifier
 	})
 	if err != nil {
-		h.logger.Error("failed to update core value", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update core value", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -312,7 +312,7 @@ func (h *AboutHandler) CoreValueDelete(c echo.Context) error {
 
 	// Delete the core value from database
 	if err := h.queries.DeleteCoreValue(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete core value", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete core value", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -343,7 +343,7 @@ func (h *AboutHandler) MilestonesList(c echo.Context) error {
 	// Fetch all milestones from database, ordered by display_order
 	items, err := h.queries.ListMilestones(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list milestones", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list milestones", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -396,7 +396,7 @@ func (h *AboutHandler) MilestoneCreate(c echo.Context) error {
 		DisplayOrder: order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create milestone", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create milestone", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -449,7 +449,7 @@ func (h *AboutHandler) MilestoneUpdate(c echo.Context) error {
 		ID:           id,
 	})
 	if err != nil {
-		h.logger.Error("failed to update milestone", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update milestone", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -466,7 +466,7 @@ func (h *AboutHandler) MilestoneUpdate(c echo.Context) error {
 func (h *AboutHandler) MilestoneDelete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err := h.queries.DeleteMilestone(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete milestone", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete milestone", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -487,7 +487,7 @@ func (h *AboutHandler) MilestoneDelete(c echo.Context) error {
 func (h *AboutHandler) CertificationsList(c echo.Context) error {
 	items, err := h.queries.ListCertifications(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list certifications", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list certifications", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	return c.Render(http.StatusOK, "admin/pages/certifications_list.html", map[string]interface{}{
@@ -529,7 +529,7 @@ func (h *AboutHandler) CertificationCreate(c echo.Context) error {
 		DisplayOrder: order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create certification", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create certification", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -575,7 +575,7 @@ func (h *AboutHandler) CertificationUpdate(c echo.Context) error {
 		ID:           id,
 	})
 	if err != nil {
-		h.logger.Error("failed to update certification", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update certification", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -592,7 +592,7 @@ func (h *AboutHandler) CertificationUpdate(c echo.Context) error {
 func (h *AboutHandler) CertificationDelete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err := h.queries.DeleteCertification(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete certification", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete certification", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
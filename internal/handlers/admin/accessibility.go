@@ -0,0 +1,54 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the accessibility report and the alt text rule the
+// image forms share.
+package admin
+
+import (
+	// Standard library imports
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes
+	"strings"  // Blank alt text
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Accessibility audit
+)
+
+// altTextRequired returns the error shown when an image is set without alt
+// text, or "" when the form is fine. what names the image in the message,
+// e.g. "background image".
+func altTextRequired(hasImage bool, alt, what string) string {
+	if !hasImage || strings.TrimSpace(alt) != "" {
+		return ""
+	}
+	return "Describe the " + what + " in its alt text; screen readers read it out instead of the image."
+}
+
+// AccessibilityHandler serves /admin/accessibility.
+type AccessibilityHandler struct {
+	audit  *services.Accessibility // Finds the problems
+	logger *slog.Logger            // Structured logger for error tracking
+}
+
+// NewAccessibilityHandler creates a new AccessibilityHandler instance.
+func NewAccessibilityHandler(audit *services.Accessibility, logger *slog.Logger) *AccessibilityHandler {
+	return &AccessibilityHandler{audit: audit, logger: logger}
+}
+
+// Report handles GET /admin/accessibility
+// Lists the images without alt text and the rich text with headings out of
+// order, each with a link to the page that fixes it.
+// Template: admin/pages/accessibility.html (full page)
+func (h *AccessibilityHandler) Report(c echo.Context) error {
+	report, err := h.audit.Audit(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to audit accessibility", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/accessibility.html", map[string]interface{}{
+		"Title":  "Accessibility",
+		"Report": report,
+	})
+}
@@ -1,10 +1,23 @@
 // Package admin provides HTTP handlers for the admin panel.
-// This file contains handlers for the activity log viewing functionality.
+// This file contains handlers for the activity log viewing functionality,
+// including filtering by user, entity type, action and date range, and CSV export.
 package admin
 
 import (
 	// Standard library imports
 
+	// context: Background export source queries
+	"context"
+
+	// encoding/csv: Writes the CSV export
+	"encoding/csv"
+
+	// fmt: Export file name and CSV cell formatting
+	"fmt"
+
+	// html/template: template.URL keeps the filter query string intact in links
+	"html/template"
+
 	// log/slog: Structured logging for error and debug messages
 	"log/slog"
 
@@ -14,9 +27,15 @@ import (
 	// net/http: HTTP status codes and standard HTTP types
 	"net/http"
 
+	// net/url: Rebuilds the filter query string for pagination and export links
+	"net/url"
+
 	// strconv: String conversion utilities, used for parsing query parameters
 	"strconv"
 
+	// time: Date filter validation and export timestamps
+	"time"
+
 	// Third-party imports
 
 	// echo/v4: Web framework providing routing, context, and HTTP handling
@@ -24,21 +43,34 @@ import (
 
 	// sqlc: Generated database query client for type-safe SQL operations
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+
+	// services: Activity log retention settings and the background export source
+	"github.com/narendhupati/bluejay-cms/internal/services"
 )
 
 // activityPerPage defines the number of activity log entries displayed per page.
 // This constant controls pagination throughout the activity log interface.
 const activityPerPage = 50
 
+// activityExportBatch is how many entries exports read per query, so
+// large exports are streamed instead of loaded into memory at once.
+const activityExportBatch = 500
+
+// activityDateLayout is the format of the from/to date filters (HTML date inputs).
+const activityDateLayout = "2006-01-02"
+
 // ActivityHandler handles HTTP requests for viewing and filtering activity logs.
 // It provides read-only access to the audit trail, allowing administrators to
 // review all actions taken within the CMS.
 type ActivityHandler struct {
 	// queries provides type-safe database access via sqlc-generated methods
-	queries *sqlc.Queries
+	queries sqlc.Querier
 
 	// logger outputs structured error and debug messages
 	logger *slog.Logger
+
+	// retention is the pruning job, used to show the retention window (may be nil)
+	retention *services.ActivityRetention
 }
 
 // NewActivityHandler creates a new ActivityHandler with the provided dependencies.
@@ -48,11 +80,92 @@ type ActivityHandler struct {
 // Parameters:
 //   - queries: sqlc.Queries instance for database operations
 //   - logger: slog.Logger for structured logging
+//   - retention: Activity log pruning job (nil when not configured)
 //
 // Returns:
 //   - *ActivityHandler: Initialized handler ready to process HTTP requests
-func NewActivityHandler(queries *sqlc.Queries, logger *slog.Logger) *ActivityHandler {
-	return &ActivityHandler{queries: queries, logger: logger}
+func NewActivityHandler(queries sqlc.Querier, logger *slog.Logger, retention *services.ActivityRetention) *ActivityHandler {
+	return &ActivityHandler{queries: queries, logger: logger, retention: retention}
+}
+
+// activityFilters holds the filter query parameters shared by List and Export.
+type activityFilters struct {
+	User         int64  // Acting admin user ID (0 = all)
+	ResourceType string // Entity type, e.g. "product"
+	Action       string // Action, e.g. "deleted"
+	Search       string // Text in the description or resource title
+	From         string // First day, YYYY-MM-DD
+	To           string // Last day, YYYY-MM-DD
+}
+
+// parseActivityFilters reads the filters from the query string. Invalid user
+// IDs and dates are ignored rather than rejected, like invalid page numbers.
+func parseActivityFilters(c echo.Context) activityFilters {
+	return activityFiltersFromQuery(c.QueryParams())
+}
+
+// activityFiltersFromQuery reads the filters from query values, as stored
+// with background export jobs.
+func activityFiltersFromQuery(q url.Values) activityFilters {
+	f := activityFilters{
+		ResourceType: q.Get("type"),
+		Action:       q.Get("action"),
+		Search:       q.Get("search"),
+	}
+	f.User, _ = strconv.ParseInt(q.Get("user"), 10, 64)
+	if _, err := time.Parse(activityDateLayout, q.Get("from")); err == nil {
+		f.From = q.Get("from")
+	}
+	if _, err := time.Parse(activityDateLayout, q.Get("to")); err == nil {
+		f.To = q.Get("to")
+	}
+	return f
+}
+
+// Active reports whether any filter is set.
+func (f activityFilters) Active() bool {
+	return f.User != 0 || f.ResourceType != "" || f.Action != "" || f.Search != "" || f.From != "" || f.To != ""
+}
+
+// Query encodes the active filters as a query string (without "?"). It is a
+// template.URL so the "&" separators survive escaping inside href attributes.
+func (f activityFilters) Query() template.URL {
+	v := url.Values{}
+	if f.User != 0 {
+		v.Set("user", strconv.FormatInt(f.User, 10))
+	}
+	for key, val := range map[string]string{"type": f.ResourceType, "action": f.Action, "search": f.Search, "from": f.From, "to": f.To} {
+		if val != "" {
+			v.Set(key, val)
+		}
+	}
+	return template.URL(v.Encode())
+}
+
+// countParams returns the CountActivityLogs parameters.
+func (f activityFilters) countParams() sqlc.CountActivityLogsParams {
+	return sqlc.CountActivityLogsParams{
+		FilterUser:         f.User,
+		FilterResourceType: f.ResourceType,
+		FilterAction:       f.Action,
+		FilterSearch:       f.Search,
+		FilterFrom:         f.From,
+		FilterTo:           f.To,
+	}
+}
+
+// listParams returns the ListActivityLogs parameters for one page.
+func (f activityFilters) listParams(limit, offset int64) sqlc.ListActivityLogsParams {
+	return sqlc.ListActivityLogsParams{
+		FilterUser:         f.User,
+		FilterResourceType: f.ResourceType,
+		FilterAction:       f.Action,
+		FilterSearch:       f.Search,
+		FilterFrom:         f.From,
+		FilterTo:           f.To,
+		PageLimit:          limit,
+		PageOffset:         offset,
+	}
 }
 
 // List displays a paginated, filterable view of all activity logs in the system.
@@ -63,8 +176,11 @@ func NewActivityHandler(queries *sqlc.Queries, logger *slog.Logger) *ActivityHan
 // Template: admin/pages/activity_log.html (uses admin-layout.html as base)
 //
 // Query Parameters:
-//   - action: Filter by specific action type (e.g., "create", "update", "delete")
-//   - search: Free-text search across user_email, resource_title, and description
+//   - user: Filter by acting admin user ID
+//   - type: Filter by entity type (e.g., "product", "blog_post")
+//   - action: Filter by specific action type (e.g., "created", "updated", "deleted")
+//   - search: Free-text search across resource_title and description
+//   - from / to: Inclusive date range, YYYY-MM-DD (UTC)
 //   - page: Current page number (defaults to 1 if missing or invalid)
 //
 // Behavior:
@@ -82,10 +198,12 @@ func NewActivityHandler(queries *sqlc.Queries, logger *slog.Logger) *ActivityHan
 //
 // Template Data:
 //   - Title: Page title ("Activity Log")
-//   - Logs: Array of activity log entries for current page
-//   - Action: Current action filter value (for preserving filter state)
-//   - Search: Current search term (for preserving filter state)
+//   - Logs: Array of activity log entries for current page, with user name/email
+//   - Filters: Current filter values (for preserving filter state)
+//   - FilterQuery: Encoded filters for pagination links
+//   - Users, Actions, ResourceTypes: Options for the filter dropdowns
 //   - HasFilters: Boolean indicating if any filters are active (for UI state)
+//   - RetentionDays: Pruning window in days (0 = entries are kept)
 //   - Page: Current page number (1-indexed)
 //   - TotalPages: Total number of pages based on filtered results
 //   - Pages: Array of page numbers for pagination UI
@@ -101,11 +219,7 @@ func (h *ActivityHandler) List(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	// Parse query parameters for filtering and pagination
-	// action: filters by specific action types (create/update/delete/publish/unpublish)
-	action := c.QueryParam("action")
-
-	// search: performs full-text search across user_email, resource_title, and description
-	search := c.QueryParam("search")
+	filters := parseActivityFilters(c)
 
 	// page: current page number, defaults to 1 if missing or invalid
 	pageStr := c.QueryParam("page")
@@ -123,27 +237,19 @@ func (h *ActivityHandler) List(c echo.Context) error {
 
 	// Query the database for activity logs matching the current filters
 	// ListActivityLogs performs filtering, ordering, and pagination in a single query
-	logs, err := h.queries.ListActivityLogs(ctx, sqlc.ListActivityLogsParams{
-		FilterAction: action,        // Filter by action type (empty string = no filter)
-		FilterSearch: search,        // Filter by search term (empty string = no filter)
-		PageLimit:    activityPerPage, // Always fetch exactly 50 rows
-		PageOffset:   offset,         // Skip rows from previous pages
-	})
+	logs, err := h.queries.ListActivityLogs(ctx, filters.listParams(activityPerPage, offset))
 	if err != nil {
 		// Log database errors with structured context for debugging
-		h.logger.Error("failed to list activity logs", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list activity logs", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Get the total count of matching logs for pagination calculation
 	// This query respects the same filters but doesn't apply LIMIT/OFFSET
-	total, err := h.queries.CountActivityLogs(ctx, sqlc.CountActivityLogsParams{
-		FilterAction: action,
-		FilterSearch: search,
-	})
+	total, err := h.queries.CountActivityLogs(ctx, filters.countParams())
 	if err != nil {
 		// Log database errors with structured context for debugging
-		h.logger.Error("failed to count activity logs", "error", err)
+		h.logger.ErrorContext(ctx, "failed to count activity logs", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -175,24 +281,168 @@ func (h *ActivityHandler) List(c echo.Context) error {
 		showFrom = 0
 	}
 
-	// Determine if any filters are active (used to show/hide "Clear Filters" button)
-	hasFilters := action != "" || search != ""
+	// Options for the filter dropdowns (errors leave a dropdown empty)
+	users, _ := h.queries.ListAdminUsers(ctx)
+	actions, _ := h.queries.ListActivityActions(ctx)
+	resourceTypes, _ := h.queries.ListActivityResourceTypes(ctx)
 
 	// Render the full activity log page template
 	// This is NOT an HTMX fragment — it includes the full admin layout with sidebar
 	// Template path: templates/admin/pages/activity_log.html
 	// Base layout: templates/admin/layouts/admin-layout.html
 	return c.Render(http.StatusOK, "admin/pages/activity_log.html", map[string]interface{}{
-		"Title":      "Activity Log",     // Browser title and page heading
-		"Logs":       logs,                // Array of activity log entries for current page
-		"Action":     action,              // Current action filter (preserves form state)
-		"Search":     search,              // Current search term (preserves form state)
-		"HasFilters": hasFilters,          // Whether any filters are active (UI visibility)
-		"Page":       page,                // Current page number (for pagination state)
-		"TotalPages": totalPages,          // Total pages (for pagination limits)
-		"Pages":      pages,               // Page number array (for pagination UI)
-		"Total":      total,               // Total filtered results (for "X total entries" display)
-		"ShowFrom":   showFrom,            // First entry number on page (for "Showing X-Y" display)
-		"ShowTo":     showTo,              // Last entry number on page (for "Showing X-Y" display)
+		"Title":         "Activity Log",     // Browser title and page heading
+		"Logs":          logs,               // Array of activity log entries for current page
+		"Filters":       filters,            // Current filters (preserves form state)
+		"FilterQuery":   filters.Query(),    // Encoded filters for pagination links
+		"Users":         users,              // User filter options
+		"Actions":       actions,            // Action filter options
+		"ResourceTypes": resourceTypes,      // Entity type filter options
+		"HasFilters":    filters.Active(),   // Whether any filters are active (UI visibility)
+		"RetentionDays": h.retention.Days(), // Pruning window shown under the header
+		"Page":          page,               // Current page number (for pagination state)
+		"TotalPages":    totalPages,         // Total pages (for pagination limits)
+		"Pages":         pages,              // Page number array (for pagination UI)
+		"Total":         total,              // Total filtered results (for "X total entries" display)
+		"ShowFrom":      showFrom,           // First entry number on page (for "Showing X-Y" display)
+		"ShowTo":        showTo,             // Last entry number on page (for "Showing X-Y" display)
 	})
 }
+
+// Export streams the filtered activity log as a CSV download.
+//
+// HTTP Method: GET
+// Route: /admin/activity/export.csv
+// Returns: text/csv attachment (activity-log-YYYYMMDD-HHMMSS.csv)
+//
+// Query Parameters:
+//   - Same filters as List (user, type, action, search, from, to); page is ignored
+//
+// Behavior:
+//   - Entries are read in batches of activityExportBatch and written as they are
+//     read, newest first, so exports of the whole log do not load it into memory
+//   - Columns: id, timestamp (UTC), user_id, user_email, user_name, action,
+//     resource_type, resource_id, resource_title, description
+//   - The export itself is recorded in the activity log
+//
+// Error Handling:
+//   - A failure before the first row returns HTTP 500; once streaming has
+//     started the error is logged and the download is cut short
+func (h *ActivityHandler) Export(c echo.Context) error {
+	ctx := c.Request().Context()
+	filters := parseActivityFilters(c)
+
+	// Read from the reporting pool so a large export does not block saves
+	reports := reporting(h.queries)
+	first, err := reports.ListActivityLogs(ctx, filters.listParams(activityExportBatch, 0))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to export activity logs", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "exported", "activity_log", 0, "", "Exported activity log as CSV")
+
+	filename := fmt.Sprintf("activity-log-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	_ = w.Write(activityExportHeader)
+
+	batch := first
+	for offset := int64(0); ; {
+		for _, l := range batch {
+			row := activityExportRow(l)
+			for i := range row {
+				row[i] = csvSafe(row[i])
+			}
+			_ = w.Write(row)
+		}
+		w.Flush()
+		if len(batch) < activityExportBatch {
+			break
+		}
+		offset += activityExportBatch
+		if batch, err = reports.ListActivityLogs(ctx, filters.listParams(activityExportBatch, offset)); err != nil {
+			h.logger.ErrorContext(ctx, "activity export interrupted", "offset", offset, "error", err)
+			break
+		}
+	}
+	return w.Error()
+}
+
+// activityExportHeader is the first row of activity exports.
+var activityExportHeader = []string{"id", "timestamp", "user_id", "user_email", "user_name", "action", "resource_type", "resource_id", "resource_title", "description"}
+
+// activityExportRow returns the export cells of one entry, before CSV
+// formula escaping.
+func activityExportRow(l sqlc.ListActivityLogsRow) []string {
+	return []string{
+		strconv.FormatInt(l.ID, 10),
+		csvTime(l.CreatedAt.Time, l.CreatedAt.Valid),
+		csvInt(l.UserID.Int64, l.UserID.Valid),
+		l.UserEmail.String,
+		l.UserName.String,
+		l.Action,
+		l.ResourceType,
+		csvInt(l.ResourceID.Int64, l.ResourceID.Valid),
+		l.ResourceTitle.String,
+		l.Description,
+	}
+}
+
+// ExportSource returns the activity log as a background export (see
+// services.ExportJobs), with the filters of List as job params. Entries are
+// read from the reporting pool in batches, newest first.
+func (h *ActivityHandler) ExportSource() services.ExportSource {
+	return services.ExportSource{
+		Label:   "Activity Log",
+		Formats: []string{services.ExportCSV, services.ExportXLSX},
+		Count: func(ctx context.Context, params url.Values) (int64, error) {
+			return reporting(h.queries).CountActivityLogs(ctx, activityFiltersFromQuery(params).countParams())
+		},
+		Rows: func(ctx context.Context, params url.Values, write func([]string) error) error {
+			filters := activityFiltersFromQuery(params)
+			if err := write(activityExportHeader); err != nil {
+				return err
+			}
+			for offset := int64(0); ; offset += activityExportBatch {
+				batch, err := reporting(h.queries).ListActivityLogs(ctx, filters.listParams(activityExportBatch, offset))
+				if err != nil {
+					return err
+				}
+				for _, l := range batch {
+					if err := write(activityExportRow(l)); err != nil {
+						return err
+					}
+				}
+				if len(batch) < activityExportBatch {
+					return nil
+				}
+			}
+		},
+	}
+}
+
+// csvTime formats an optional timestamp for the CSV export.
+func csvTime(t time.Time, valid bool) string {
+	if !valid {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// csvInt formats an optional integer for the CSV export.
+func csvInt(n int64, valid bool) string {
+	if !valid {
+		return ""
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// csvSafe neutralizes spreadsheet formulas: cells starting with =, +, - or @
+// are prefixed with a quote so they open as text (CSV injection).
+func csvSafe(s string) string {
+	return services.CSVSafe(s)
+}
@@ -181,4 +181,14 @@ func logActivity(c echo.Context, action, resourceType string, resourceID int64,
 	}
 	// If activityLog is nil, function returns here without logging
 	// This is intentional — activity logging is non-critical infrastructure
+
+	// Changes to public content are also sent to the registered webhooks
+	// (content.published, content.updated, content.deleted)
+	notifyWebhooks(c, action, resourceType, resourceID, resourceTitle)
+
+	// The kept sitemap may no longer list the changed content
+	refreshSitemap()
+
+	// Deleting content may leave images attached in its editors unused
+	cleanUpAttachments(action)
 }
@@ -0,0 +1,106 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the Admin Access page, which limits the networks the
+// admin panel can be reached from.
+package admin
+
+import (
+	"log/slog" // Structured logging
+	"net/http" // HTTP status codes
+	"strings"  // Normalizing the rule text
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Rule parsing, flash messages
+)
+
+// AdminAccessHandler handles the admin network rules at /admin/access.
+// The rules are enforced by middleware.AdminIPFilter on every /admin route.
+type AdminAccessHandler struct {
+	queries sqlc.Querier // Database queries generated by sqlc
+	logger  *slog.Logger // Structured logger for error reporting
+}
+
+// NewAdminAccessHandler creates a new AdminAccessHandler instance.
+func NewAdminAccessHandler(queries sqlc.Querier, logger *slog.Logger) *AdminAccessHandler {
+	return &AdminAccessHandler{queries: queries, logger: logger}
+}
+
+// Edit handles GET /admin/access
+// Shows the allowed and denied networks with the viewer's own address.
+// Template: admin/pages/admin_access.html (full page)
+func (h *AdminAccessHandler) Edit(c echo.Context) error {
+	settings, err := h.queries.GetSettings(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.render(c, http.StatusOK, settings.AdminIpAllow, settings.AdminIpDeny, "")
+}
+
+// Update handles POST /admin/access
+// Saves the allow and deny form fields (IP addresses or CIDR ranges, one
+// per line). Invalid entries, and rules that would refuse the address the
+// form is submitted from, are reported on the page and nothing is saved;
+// an admin who came in with the emergency bypass token may save rules that
+// exclude their own address.
+func (h *AdminAccessHandler) Update(c echo.Context) error {
+	allowText, denyText := normalizeIPRules(c.FormValue("allow")), normalizeIPRules(c.FormValue("deny"))
+	allow, err := customMiddleware.ParseIPRules(allowText)
+	if err != nil {
+		return h.render(c, http.StatusUnprocessableEntity, allowText, denyText, "Allowed networks: "+err.Error())
+	}
+	deny, err := customMiddleware.ParseIPRules(denyText)
+	if err != nil {
+		return h.render(c, http.StatusUnprocessableEntity, allowText, denyText, "Denied networks: "+err.Error())
+	}
+	if !customMiddleware.IPBypassed(c) && !customMiddleware.IPAllowed(c.RealIP(), allow, deny) {
+		return h.render(c, http.StatusUnprocessableEntity, allowText, denyText,
+			"These rules would lock you out: your address "+c.RealIP()+" would not be allowed. Allow it, or save from an address the rules allow.")
+	}
+
+	if err := h.queries.UpdateAdminIPRules(c.Request().Context(), sqlc.UpdateAdminIPRulesParams{
+		AdminIpAllow: allowText,
+		AdminIpDeny:  denyText,
+	}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update admin IP rules", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "updated", "admin_access", 0, "", "Updated admin network rules (%d allowed, %d denied)", len(allow), len(deny))
+
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Admin access rules saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/access")
+}
+
+// render shows the Admin Access page with the given rule text and error.
+func (h *AdminAccessHandler) render(c echo.Context, status int, allow, deny, errMsg string) error {
+	return c.Render(status, "admin/pages/admin_access.html", map[string]interface{}{
+		"Title":    "Admin Access",
+		"Allow":    allow,
+		"Deny":     deny,
+		"ClientIP": c.RealIP(),
+		"Allowed":  ipRulesAllow(c.RealIP(), allow, deny),
+		"Bypassed": customMiddleware.IPBypassed(c),
+		"Error":    errMsg,
+	})
+}
+
+// ipRulesAllow reports whether the saved rule text admits ip; text that
+// does not parse admits nobody.
+func ipRulesAllow(ip, allowText, denyText string) bool {
+	allow, errAllow := customMiddleware.ParseIPRules(allowText)
+	deny, errDeny := customMiddleware.ParseIPRules(denyText)
+	return errAllow == nil && errDeny == nil && customMiddleware.IPAllowed(ip, allow, deny)
+}
+
+// normalizeIPRules puts each entry of rule text on its own line, trimmed,
+// without blank lines.
+func normalizeIPRules(text string) string {
+	var entries []string
+	for _, entry := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' || r == ',' }) {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return strings.Join(entries, "\n")
+}
@@ -0,0 +1,54 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the first-party analytics dashboard: page views,
+// their trend, top pages, referrers, countries and device classes.
+package admin
+
+import (
+	"log/slog" // Structured logging
+	"net/http" // HTTP status codes
+	"slices"   // Range validation
+	"strconv"  // Parsing ?days=
+	"time"     // Report end date
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page view reports
+)
+
+// analyticsDefaultDays is the range shown without ?days=.
+const analyticsDefaultDays = 30
+
+// AnalyticsHandler handles the dashboard at /admin/analytics.
+type AnalyticsHandler struct {
+	analytics *services.Analytics // Page view reports
+	logger    *slog.Logger        // Structured logger for error reporting
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler instance.
+func NewAnalyticsHandler(analytics *services.Analytics, logger *slog.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{analytics: analytics, logger: logger}
+}
+
+// Dashboard handles GET /admin/analytics
+// Shows the page views of the last ?days= days (7, 30 or 90; default 30)
+// ending today (UTC): totals, a bar per day, the most viewed pages and the
+// referrers, countries and device classes of the views. The daily counts are
+// also embedded as JSON (#analytics-trend) for charting.
+// Template: admin/pages/analytics.html (full page)
+func (h *AnalyticsHandler) Dashboard(c echo.Context) error {
+	ctx := c.Request().Context()
+	days, err := strconv.Atoi(c.QueryParam("days"))
+	if err != nil || !slices.Contains(services.AnalyticsRanges, days) {
+		days = analyticsDefaultDays
+	}
+	report, err := h.analytics.Report(ctx, days, time.Now())
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to build analytics report", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/analytics.html", map[string]interface{}{
+		"Title":  "Analytics",
+		"Report": report,
+		"Ranges": services.AnalyticsRanges,
+	})
+}
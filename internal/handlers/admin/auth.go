@@ -4,6 +4,7 @@ import (
 	// Standard library imports
 	"log/slog"  // Structured logging for authentication events and security auditing
 	"net/http"  // HTTP status codes and request/response handling
+	"time"      // Cutoff for pruning expired session records
 
 	// Third-party framework
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context management
@@ -20,13 +21,13 @@ import (
 // Manages user login, logout, and session lifecycle for admin panel access.
 // All authentication events are logged for security auditing.
 type AuthHandler struct {
-	queries *sqlc.Queries // Database query interface for user credential verification
-	logger  *slog.Logger  // Structured logger for security event tracking
+	queries sqlc.Querier // Database query interface for user credential verification
+	logger  *slog.Logger // Structured logger for security event tracking
 }
 
 // NewAuthHandler creates and initializes a new AuthHandler instance.
 // Dependencies are injected to support database access and security logging.
-func NewAuthHandler(queries *sqlc.Queries, logger *slog.Logger) *AuthHandler {
+func NewAuthHandler(queries sqlc.Querier, logger *slog.Logger) *AuthHandler {
 	return &AuthHandler{
 		queries: queries,
 		logger:  logger,
@@ -76,9 +77,10 @@ func (h *AuthHandler) ShowLoginPage(c echo.Context) error {
 // 2. Query database for user with matching email
 // 3. Verify password using bcrypt hash comparison
 // 4. Update user's last_login timestamp in database
-// 5. Create authenticated session with user data
-// 6. Log successful authentication event
-// 7. Redirect to admin dashboard
+// 5. Record the session in admin_sessions (IP, user agent) and prune expired ones
+// 6. Create authenticated session with user data and the session token
+// 7. Log successful authentication event
+// 8. Redirect to admin dashboard
 //
 // Security Features:
 // - Passwords are verified using bcrypt (constant-time comparison)
@@ -105,7 +107,7 @@ func (h *AuthHandler) LoginSubmit(c echo.Context) error {
 	user, err := h.queries.GetAdminUserByEmail(c.Request().Context(), email)
 	if err != nil {
 		// User not found - log for security monitoring but show generic error
-		h.logger.Warn("login attempt failed", "email", email, "error", "user_not_found")
+		h.logger.WarnContext(c.Request().Context(), "login attempt failed", "email", email, "error", "user_not_found")
 		return c.Redirect(http.StatusSeeOther, "/admin/login?error=invalid_credentials")
 	}
 
@@ -113,14 +115,36 @@ func (h *AuthHandler) LoginSubmit(c echo.Context) error {
 	// CompareHashAndPassword prevents timing attacks
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		// Password mismatch - log for security monitoring but show generic error
-		h.logger.Warn("login attempt failed", "email", email, "error", "invalid_password")
+		h.logger.WarnContext(c.Request().Context(), "login attempt failed", "email", email, "error", "invalid_password")
 		return c.Redirect(http.StatusSeeOther, "/admin/login?error=invalid_credentials")
 	}
 
 	// Update user's last_login timestamp for activity tracking
 	// Non-critical operation: log error but don't fail authentication
 	if err := h.queries.UpdateLastLogin(c.Request().Context(), user.ID); err != nil {
-		h.logger.Error("failed to update last login", "user_id", user.ID, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update last login", "user_id", user.ID, "error", err)
+	}
+
+	// Record the session server-side so it can be listed and revoked later
+	// (see /admin/profile/sessions); the cookie only carries the token
+	token, err := customMiddleware.NewSessionToken()
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate session token", "error", err)
+		return c.Redirect(http.StatusSeeOther, "/admin/login?error=session_error")
+	}
+	if _, err := h.queries.CreateAdminSession(c.Request().Context(), sqlc.CreateAdminSessionParams{
+		Token:     token,
+		UserID:    user.ID,
+		IpAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to record session", "user_id", user.ID, "error", err)
+		return c.Redirect(http.StatusSeeOther, "/admin/login?error=session_error")
+	}
+	// Sessions idle longer than the cookie lifetime can never be used again
+	cutoff := time.Now().UTC().Add(-customMiddleware.SessionMaxAge()).Format("2006-01-02 15:04:05")
+	if err := h.queries.DeleteStaleAdminSessions(c.Request().Context(), cutoff); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to prune expired sessions", "error", err)
 	}
 
 	// Retrieve session and populate with authenticated user data
@@ -129,15 +153,16 @@ func (h *AuthHandler) LoginSubmit(c echo.Context) error {
 	sess.Email = user.Email
 	sess.DisplayName = user.DisplayName
 	sess.Role = user.Role
+	sess.Token = token
 
 	// Persist session to cookie (server-side session storage)
 	if err := sess.Save(c.Request(), c.Response()); err != nil {
-		h.logger.Error("failed to save session", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to save session", "error", err)
 		return c.Redirect(http.StatusSeeOther, "/admin/login?error=session_error")
 	}
 
 	// Log successful authentication for security audit trail
-	h.logger.Info("user logged in", "user_id", user.ID, "email", user.Email)
+	h.logger.InfoContext(c.Request().Context(), "user logged in", "user_id", user.ID, "email", user.Email)
 	// Log activity to activity_log table for admin dashboard
 	logActivity(c, "login", "system", user.ID, user.DisplayName, "User '%s' logged in", user.DisplayName)
 
@@ -152,8 +177,8 @@ func (h *AuthHandler) LoginSubmit(c echo.Context) error {
 // HTMX: Not used - standard redirect flow
 //
 // Logout Process:
-// 1. Retrieve current session from context
-// 2. Clear all session data (UserID, Email, DisplayName, Role)
+// 1. Retrieve current session from context and delete its admin_sessions record
+// 2. Clear all session data (UserID, Email, DisplayName, Role, Token)
 // 3. Set session MaxAge to -1 (instructs browser to delete cookie)
 // 4. Save session changes to persist deletion
 // 5. Log logout event for audit trail
@@ -170,22 +195,30 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 	// Retrieve existing session from context
 	sess := c.Get("session").(*customMiddleware.Session)
 
+	// Remove the server-side record so the cookie cannot be replayed
+	if sess.Token != "" {
+		if err := h.queries.DeleteAdminSessionByToken(c.Request().Context(), sess.Token); err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to delete session record", "error", err)
+		}
+	}
+
 	// Clear all user data from session
 	sess.UserID = 0
 	sess.Email = ""
 	sess.DisplayName = ""
 	sess.Role = ""
+	sess.Token = ""
 	// Set MaxAge to -1 to instruct browser to delete the session cookie
 	sess.Options.MaxAge = -1
 
 	// Persist session destruction (saves empty session and triggers cookie deletion)
 	if err := sess.Save(c.Request(), c.Response()); err != nil {
 		// Log error but continue logout process (fail-safe approach)
-		h.logger.Error("failed to destroy session", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to destroy session", "error", err)
 	}
 
 	// Log logout event for security audit trail
-	h.logger.Info("user logged out")
+	h.logger.InfoContext(c.Request().Context(), "user logged out")
 	// Log activity to activity_log table (user_id=0 since session is cleared)
 	logActivity(c, "logout", "system", 0, "", "User logged out")
 
@@ -0,0 +1,236 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains database and uploads backups: download a snapshot, keep
+// backups in the configured storage, and restore one after confirmation.
+package admin
+
+import (
+	// Standard library imports
+	"errors"   // Matching backup errors
+	"fmt"      // Download headers
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"time"     // Snapshot file names
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Backup service
+)
+
+// restoreConfirmation must be typed to confirm a restore.
+const restoreConfirmation = "RESTORE"
+
+// BackupsHandler serves /admin/maintenance/backup. Backups are archives of
+// the database and the uploads directory made by services.Backups; the
+// routes are limited to admins.
+type BackupsHandler struct {
+	logger  *slog.Logger      // Structured logger for error tracking
+	backups *services.Backups // Backup service and storage
+}
+
+// NewBackupsHandler constructs a new BackupsHandler.
+func NewBackupsHandler(logger *slog.Logger, backups *services.Backups) *BackupsHandler {
+	return &BackupsHandler{logger: logger, backups: backups}
+}
+
+// List handles GET /admin/maintenance/backup
+// Lists the stored backups with download, restore and delete actions.
+// Template: admin/pages/backups.html (full page)
+//
+// Query parameters (set by the redirects after each action):
+//   - created, deleted, restored: Name of the affected backup
+//   - safety: Name of the pre-restore backup
+func (h *BackupsHandler) List(c echo.Context) error {
+	return h.renderList(c, http.StatusOK, "")
+}
+
+// Download handles GET /admin/maintenance/backup/download
+// Streams a new backup straight to the browser without storing it.
+func (h *BackupsHandler) Download(c echo.Context) error {
+	ctx := c.Request().Context()
+	res := c.Response()
+	filename := fmt.Sprintf("bluejay-backup-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	res.Header().Set(echo.HeaderContentType, "application/gzip")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	res.Header().Set("Cache-Control", "private, no-store")
+
+	_, err := h.backups.WriteSnapshot(ctx, res)
+	switch {
+	case errors.Is(err, services.ErrBackupBusy):
+		res.Header().Del(echo.HeaderContentDisposition)
+		return h.renderList(c, http.StatusConflict, "Another backup or restore is running. Try again when it has finished.")
+	case err != nil && !res.Committed:
+		h.logger.ErrorContext(ctx, "failed to create backup", "error", err)
+		res.Header().Del(echo.HeaderContentDisposition)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	case err != nil:
+		// Part of the archive was sent; the browser reports a failed download
+		h.logger.ErrorContext(ctx, "backup download interrupted", "error", err)
+		return nil
+	}
+	logActivity(c, "downloaded", "backup", 0, filename, "Downloaded backup %s", filename)
+	return nil
+}
+
+// Create handles POST /admin/maintenance/backup
+// Takes a backup and keeps it in the backup storage.
+func (h *BackupsHandler) Create(c echo.Context) error {
+	backup, err := h.backups.Create(c.Request().Context(), services.BackupManual)
+	if errors.Is(err, services.ErrBackupBusy) {
+		return h.renderList(c, http.StatusConflict, "Another backup or restore is running. Try again when it has finished.")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create backup", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "backup", 0, backup.Name, "Created backup %s", backup.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/maintenance/backup?created="+backup.Name)
+}
+
+// Upload handles POST /admin/maintenance/backup/upload
+// Stores a backup downloaded from this or another installation and asks
+// for confirmation to restore it.
+//
+// Form fields:
+//   - file: tar.gz archive from Download
+func (h *BackupsHandler) Upload(c echo.Context) error {
+	ctx := c.Request().Context()
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return h.renderList(c, http.StatusBadRequest, "Choose a backup file to upload.")
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to open backup upload", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer f.Close()
+
+	backup, err := h.backups.Import(ctx, f)
+	if errors.Is(err, services.ErrBackupInvalid) {
+		return h.renderList(c, http.StatusUnprocessableEntity, fileHeader.Filename+" is not a backup archive.")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to store backup upload", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "uploaded", "backup", 0, backup.Name, "Uploaded backup %s as %s", fileHeader.Filename, backup.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/maintenance/backup/"+backup.Name+"/restore")
+}
+
+// DownloadStored handles GET /admin/maintenance/backup/:name
+// Downloads a stored backup.
+func (h *BackupsHandler) DownloadStored(c echo.Context) error {
+	name := c.Param("name")
+	f, err := h.backups.Open(c.Request().Context(), name)
+	if errors.Is(err, services.ErrBackupNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Backup not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to open backup", "name", name, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer f.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+	res.Header().Set("Cache-Control", "private, no-store")
+	logActivity(c, "downloaded", "backup", 0, name, "Downloaded backup %s", name)
+	return c.Stream(http.StatusOK, "application/gzip", f)
+}
+
+// Delete handles POST /admin/maintenance/backup/:name/delete
+func (h *BackupsHandler) Delete(c echo.Context) error {
+	name := c.Param("name")
+	err := h.backups.Delete(c.Request().Context(), name)
+	if errors.Is(err, services.ErrBackupNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Backup not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete backup", "name", name, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "deleted", "backup", 0, name, "Deleted backup %s", name)
+	return c.Redirect(http.StatusSeeOther, "/admin/maintenance/backup?deleted="+name)
+}
+
+// ConfirmRestore handles GET /admin/maintenance/backup/:name/restore
+// Shows what the backup contains and asks for the confirmation word.
+// Template: admin/pages/backups.html (full page, confirmation step)
+func (h *BackupsHandler) ConfirmRestore(c echo.Context) error {
+	return h.renderConfirm(c, http.StatusOK, c.Param("name"), "")
+}
+
+// Restore handles POST /admin/maintenance/backup/:name/restore
+// Restores the database and uploads from the backup once the confirmation
+// word has been typed. The current state is backed up first.
+//
+// Form fields:
+//   - confirm: Must be "RESTORE"
+func (h *BackupsHandler) Restore(c echo.Context) error {
+	ctx := c.Request().Context()
+	name := c.Param("name")
+	if c.FormValue("confirm") != restoreConfirmation {
+		return h.renderConfirm(c, http.StatusUnprocessableEntity, name, "Type "+restoreConfirmation+" to confirm the restore.")
+	}
+
+	safety, err := h.backups.Restore(ctx, name)
+	switch {
+	case errors.Is(err, services.ErrBackupNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Backup not found")
+	case errors.Is(err, services.ErrBackupBusy):
+		return h.renderConfirm(c, http.StatusConflict, name, "Another backup or restore is running. Try again when it has finished.")
+	case errors.Is(err, services.ErrBackupSchema):
+		return h.renderConfirm(c, http.StatusUnprocessableEntity, name, "This backup was made by a newer version of the CMS. Upgrade before restoring it.")
+	case errors.Is(err, services.ErrBackupInvalid):
+		return h.renderConfirm(c, http.StatusUnprocessableEntity, name, "The backup cannot be restored: "+err.Error()+". Nothing was changed.")
+	case err != nil:
+		h.logger.ErrorContext(ctx, "failed to restore backup", "name", name, "pre_restore_backup", safety.Name, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "restored", "backup", 0, name, "Restored backup %s (previous state saved as %s)", name, safety.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/maintenance/backup?restored="+name+"&safety="+safety.Name)
+}
+
+// renderList renders the backups page with an optional error.
+func (h *BackupsHandler) renderList(c echo.Context, status int, errMsg string) error {
+	backups, err := h.backups.List(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list backups", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(status, "admin/pages/backups.html", map[string]interface{}{
+		"Title":         "Backups",
+		"Backups":       backups,
+		"IntervalHours": int(h.backups.Interval().Hours()),
+		"Keep":          h.backups.Keep(),
+		"Error":         errMsg,
+		"Created":       c.QueryParam("created"),
+		"Deleted":       c.QueryParam("deleted"),
+		"Restored":      c.QueryParam("restored"),
+		"Safety":        c.QueryParam("safety"),
+	})
+}
+
+// renderConfirm renders the restore confirmation for the named backup.
+func (h *BackupsHandler) renderConfirm(c echo.Context, status int, name, errMsg string) error {
+	manifest, err := h.backups.Inspect(c.Request().Context(), name)
+	if errors.Is(err, services.ErrBackupNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Backup not found")
+	}
+	if errors.Is(err, services.ErrBackupInvalid) {
+		return h.renderList(c, http.StatusUnprocessableEntity, name+" is not a backup archive.")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to read backup", "name", name, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(status, "admin/pages/backups.html", map[string]interface{}{
+		"Title":        "Restore Backup",
+		"Name":         name,
+		"Manifest":     manifest,
+		"Confirmation": restoreConfirmation,
+		"Error":        errMsg,
+	})
+}
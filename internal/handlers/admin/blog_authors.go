@@ -11,18 +11,19 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal application imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Unique slug generation
 )
 
 // BlogAuthorsHandler manages all HTTP handlers for blog author CRUD operations.
 // Handles listing, creating, editing, updating, and deleting blog authors.
 type BlogAuthorsHandler struct {
-	queries *sqlc.Queries // Database query interface generated by sqlc
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error tracking
 }
 
 // NewBlogAuthorsHandler constructs a new BlogAuthorsHandler with required dependencies.
-func NewBlogAuthorsHandler(queries *sqlc.Queries, logger *slog.Logger) *BlogAuthorsHandler {
+func NewBlogAuthorsHandler(queries sqlc.Querier, logger *slog.Logger) *BlogAuthorsHandler {
 	return &BlogAuthorsHandler{queries: queries, logger: logger}
 }
 
@@ -34,7 +35,7 @@ func (h *BlogAuthorsHandler) List(c echo.Context) error {
 	// Fetch all blog authors from the database
 	items, err := h.queries.ListBlogAuthors(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list blog authors", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list blog authors", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -69,11 +70,17 @@ func (h *BlogAuthorsHandler) Create(c echo.Context) error {
 	linkedinUrl := c.FormValue("linkedin_url")
 	email := c.FormValue("email")
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogAuthors, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog author slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Insert the blog author into the database
-	// Slug is auto-generated from name using makeSlug utility function
-	_, err := h.queries.CreateBlogAuthor(c.Request().Context(), sqlc.CreateBlogAuthorParams{
+	// Slug is auto-generated from name using uniqueSlug
+	_, err = h.queries.CreateBlogAuthor(c.Request().Context(), sqlc.CreateBlogAuthorParams{
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")), // Auto-generate URL-friendly slug
+		Slug:        slug,                          // Auto-generate URL-friendly slug
 		Title:       c.FormValue("title"),
 		Bio:         sql.NullString{String: bio, Valid: bio != ""},                     // NULL if empty
 		AvatarUrl:   sql.NullString{String: avatarUrl, Valid: avatarUrl != ""},         // NULL if empty
@@ -82,7 +89,7 @@ func (h *BlogAuthorsHandler) Create(c echo.Context) error {
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to create blog author", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create blog author", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -126,12 +133,18 @@ func (h *BlogAuthorsHandler) Update(c echo.Context) error {
 	linkedinUrl := c.FormValue("linkedin_url")
 	email := c.FormValue("email")
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogAuthors, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog author slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Update the blog author in the database
 	// Slug is regenerated from name on each update
-	_, err := h.queries.UpdateBlogAuthor(c.Request().Context(), sqlc.UpdateBlogAuthorParams{
+	_, err = h.queries.UpdateBlogAuthor(c.Request().Context(), sqlc.UpdateBlogAuthorParams{
 		ID:          id,
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")), // Regenerate slug from updated name
+		Slug:        slug,                          // Regenerate slug from updated name
 		Title:       c.FormValue("title"),
 		Bio:         sql.NullString{String: bio, Valid: bio != ""},                     // NULL if empty
 		AvatarUrl:   sql.NullString{String: avatarUrl, Valid: avatarUrl != ""},         // NULL if empty
@@ -140,7 +153,7 @@ func (h *BlogAuthorsHandler) Update(c echo.Context) error {
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to update blog author", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update blog author", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -159,7 +172,7 @@ func (h *BlogAuthorsHandler) Delete(c echo.Context) error {
 	// Attempt to delete the author
 	// Will fail if author is referenced by blog posts due to foreign key constraints
 	if err := h.queries.DeleteBlogAuthor(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete blog author", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete blog author", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -11,18 +11,19 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal application imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Unique slug generation
 )
 
 // BlogCategoriesHandler manages all HTTP handlers for blog category CRUD operations.
 // Categories are used to organize blog posts and can have custom colors for UI theming.
 type BlogCategoriesHandler struct {
-	queries *sqlc.Queries // Database query interface generated by sqlc
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error tracking
 }
 
 // NewBlogCategoriesHandler constructs a new BlogCategoriesHandler with required dependencies.
-func NewBlogCategoriesHandler(queries *sqlc.Queries, logger *slog.Logger) *BlogCategoriesHandler {
+func NewBlogCategoriesHandler(queries sqlc.Querier, logger *slog.Logger) *BlogCategoriesHandler {
 	return &BlogCategoriesHandler{queries: queries, logger: logger}
 }
 
@@ -34,7 +35,7 @@ func (h *BlogCategoriesHandler) List(c echo.Context) error {
 	// Fetch all blog categories from the database
 	items, err := h.queries.ListBlogCategories(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list blog categories", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list blog categories", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -66,17 +67,23 @@ func (h *BlogCategoriesHandler) Create(c echo.Context) error {
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 	desc := c.FormValue("description")
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogCategories, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog category slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Insert the blog category into the database
 	// Slug is auto-generated from name, color_hex is a hex color code (e.g., "#FF6B35")
-	_, err := h.queries.CreateBlogCategory(c.Request().Context(), sqlc.CreateBlogCategoryParams{
+	_, err = h.queries.CreateBlogCategory(c.Request().Context(), sqlc.CreateBlogCategoryParams{
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),           // Auto-generate URL-friendly slug
+		Slug:        slug,                                    // Auto-generate URL-friendly slug
 		ColorHex:    c.FormValue("color_hex"),                // Hex color for category theming (e.g., "#FF6B35")
 		Description: sql.NullString{String: desc, Valid: desc != ""}, // NULL if empty
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to create blog category", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create blog category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -117,18 +124,24 @@ func (h *BlogCategoriesHandler) Update(c echo.Context) error {
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 	desc := c.FormValue("description")
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogCategories, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog category slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Update the blog category in the database
 	// Slug is regenerated from name on each update
-	_, err := h.queries.UpdateBlogCategory(c.Request().Context(), sqlc.UpdateBlogCategoryParams{
+	_, err = h.queries.UpdateBlogCategory(c.Request().Context(), sqlc.UpdateBlogCategoryParams{
 		ID:          id,
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),           // Regenerate slug from updated name
+		Slug:        slug,                                    // Regenerate slug from updated name
 		ColorHex:    c.FormValue("color_hex"),                // Update color hex for category theming
 		Description: sql.NullString{String: desc, Valid: desc != ""}, // NULL if empty
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to update blog category", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update blog category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -147,7 +160,7 @@ func (h *BlogCategoriesHandler) Delete(c echo.Context) error {
 	// Attempt to delete the category
 	// Will fail if category is referenced by blog posts due to foreign key constraints
 	if err := h.queries.DeleteBlogCategory(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete blog category", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete blog category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
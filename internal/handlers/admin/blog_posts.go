@@ -16,19 +16,19 @@ import (
 
 	// Internal application imports
 	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries via sqlc
-	"github.com/narendhupati/bluejay-cms/internal/services" // Cache service for invalidating blog pages
+	"github.com/narendhupati/bluejay-cms/internal/services" // Cache invalidation and site-timezone date parsing
 )
 
 // BlogPostsHandler manages all HTTP handlers for blog post CRUD operations.
 // Handles listing with filters/pagination, create/edit forms, and product associations.
 type BlogPostsHandler struct {
-	queries *sqlc.Queries   // Database query interface generated by sqlc
+	queries sqlc.Querier    // Database query interface generated by sqlc
 	logger  *slog.Logger    // Structured logger for error tracking
 	cache   *services.Cache // Cache service for invalidating blog-related pages
 }
 
 // NewBlogPostsHandler constructs a new BlogPostsHandler with required dependencies.
-func NewBlogPostsHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *BlogPostsHandler {
+func NewBlogPostsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *BlogPostsHandler {
 	return &BlogPostsHandler{queries: queries, logger: logger, cache: cache}
 }
 
@@ -81,7 +81,7 @@ func (h *BlogPostsHandler) List(c echo.Context) error {
 		PageOffset:     offset,
 	})
 	if err != nil {
-		h.logger.Error("failed to list blog posts", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list blog posts", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -93,7 +93,7 @@ func (h *BlogPostsHandler) List(c echo.Context) error {
 		FilterSearch:   search,
 	})
 	if err != nil {
-		h.logger.Error("failed to count blog posts", "error", err)
+		h.logger.ErrorContext(ctx, "failed to count blog posts", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -123,9 +123,14 @@ func (h *BlogPostsHandler) List(c echo.Context) error {
 	// Determine if any filters are active (used for "Clear Filters" button visibility)
 	hasFilters := search != "" || status != "" || categoryStr != "" || authorStr != ""
 
+	rows := make([]blogPostRow, len(posts))
+	for i, p := range posts {
+		rows[i] = blogPostRow{ListBlogPostsAdminFilteredRow: p}
+	}
+
 	return c.Render(http.StatusOK, "admin/pages/blog_posts_list.html", map[string]interface{}{
 		"Title":      "Manage Blog Posts",
-		"Posts":      posts,
+		"Posts":      rows,
 		"Categories": categories,
 		"Authors":    authors,
 		"Search":     search,
@@ -161,6 +166,7 @@ func (h *BlogPostsHandler) New(c echo.Context) error {
 		"AllTags":      tags,
 		"PostTags":     nil, // No tags selected yet
 		"PostProducts": nil, // No products associated yet
+		"CanPublish":   canPublishFromForm(c, "blog_post", 0, ""),
 	})
 }
 
@@ -176,6 +182,77 @@ func calculateReadingTime(body string) int64 {
 	return int64(minutes)
 }
 
+// postBody reads the post content from the form according to the selected
+// authoring mode. WYSIWYG posts submit HTML in "body"; Markdown posts submit
+// source in "body_markdown", which is rendered to HTML for body. Either way
+// the HTML passes through the rich-text sanitizer before it is stored.
+//
+// Returns:
+//   - format: services.ContentFormatHTML or services.ContentFormatMarkdown
+//   - body: HTML to store in blog_posts.body
+//   - source: Markdown source to store in blog_posts.body_markdown ("" for HTML posts)
+func postBody(c echo.Context) (format, body, source string, err error) {
+	if c.FormValue("content_format") != services.ContentFormatMarkdown {
+		return services.ContentFormatHTML, sanitizeHTML(c.FormValue("body")), "", nil
+	}
+	source = c.FormValue("body_markdown")
+	body, err = services.RenderMarkdown(source)
+	return services.ContentFormatMarkdown, sanitizeHTML(body), source, err
+}
+
+// submittedTagIDs returns the tag IDs chosen on the post form. The tag chips
+// need JavaScript to add or remove tags; without it the form submits the
+// <noscript> checkboxes instead (marked by the tags_noscript field), which
+// then replace the chips' values.
+func submittedTagIDs(c echo.Context) []string {
+	form := c.Request().Form
+	if form.Has("tags_noscript") {
+		return form["noscript_tag_ids"]
+	}
+	return form["tag_ids"]
+}
+
+// savePostDetails stores a post's content format and adds the tags and
+// products chosen on the form. Product display order follows the form order.
+// Create and Update run it in the post's transaction.
+func savePostDetails(c echo.Context, q sqlc.Querier, postID int64, format, source string) error {
+	ctx := c.Request().Context()
+	if err := q.SetBlogPostContentFormat(ctx, sqlc.SetBlogPostContentFormatParams{
+		ID:            postID,
+		ContentFormat: format,
+		BodyMarkdown:  source,
+	}); err != nil {
+		return err
+	}
+
+	// Each tag ID creates a row in the blog_post_tags junction table
+	for _, tagIDStr := range submittedTagIDs(c) {
+		tagID, _ := strconv.ParseInt(tagIDStr, 10, 64)
+		if tagID > 0 {
+			if err := q.AddTagToPost(ctx, sqlc.AddTagToPostParams{
+				BlogPostID: postID,
+				BlogTagID:  tagID,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, pidStr := range c.Request().Form["product_ids"] {
+		pid, _ := strconv.ParseInt(pidStr, 10, 64)
+		if pid > 0 {
+			if err := q.AddProductToPost(ctx, sqlc.AddProductToPostParams{
+				BlogPostID:   postID,
+				ProductID:    pid,
+				DisplayOrder: sql.NullInt64{Int64: int64(i), Valid: true},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Create handles POST /admin/blog/posts
 // Processes the blog post creation form submission.
 // Handles tag associations, product associations, and automatic slug/reading time generation.
@@ -185,13 +262,19 @@ func (h *BlogPostsHandler) Create(c echo.Context) error {
 
 	// Extract basic post fields from form
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title) // Auto-generate slug from title if not provided
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogPosts, c.FormValue("slug"), title, 0)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate blog post slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Extract body and calculate reading time if not manually set
-	body := c.FormValue("body")
+	// Extract body (rendering Markdown posts) and calculate reading time if not manually set
+	format, body, source, err := postBody(c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to render markdown", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
 	readingTime, _ := strconv.ParseInt(c.FormValue("reading_time_minutes"), 10, 64)
 	if readingTime == 0 {
 		readingTime = calculateReadingTime(body) // Auto-calculate based on word count
@@ -206,70 +289,56 @@ func (h *BlogPostsHandler) Create(c echo.Context) error {
 	featuredAlt := c.FormValue("featured_image_alt")
 	metaDesc := c.FormValue("meta_description")
 	excerpt := c.FormValue("excerpt")
-	status := c.FormValue("status")
+	// The workflow may keep new posts in draft until they are reviewed
+	status := formStatus(c, "blog_post", 0, "", c.FormValue("status"))
 
 	// Set published_at timestamp only for published posts
 	// If status is "published", parse the provided datetime or default to now
 	var publishedAt sql.NullTime
 	if status == "published" {
 		pubStr := c.FormValue("published_at")
-		if t, err := time.Parse("2006-01-02T15:04", pubStr); err == nil {
+		if t, err := services.ParseSiteTime("2006-01-02T15:04", pubStr); err == nil { // Entered in the site timezone
 			publishedAt = sql.NullTime{Time: t, Valid: true}
 		} else {
-			publishedAt = sql.NullTime{Time: time.Now(), Valid: true} // Default to current time
+			publishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true} // Default to current time
 		}
 	}
 
-	// Insert the blog post into the database
-	post, err := h.queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
-		Title:              title,
-		Slug:               slug,
-		Excerpt:            excerpt,
-		Body:               body,
-		FeaturedImageUrl:   sql.NullString{String: featuredURL, Valid: featuredURL != ""},
-		FeaturedImageAlt:   sql.NullString{String: featuredAlt, Valid: featuredAlt != ""},
-		CategoryID:         categoryID,
-		AuthorID:           authorID,
-		MetaDescription:    sql.NullString{String: metaDesc, Valid: metaDesc != ""},
-		ReadingTimeMinutes: sql.NullInt64{Int64: readingTime, Valid: readingTime > 0},
-		Status:             status,
-		PublishedAt:        publishedAt,
+	// Insert the blog post into the database, together with its content
+	// format, tags and products, or nothing at all
+	var post sqlc.BlogPost
+	err = withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		var err error
+		post, err = q.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title:              title,
+			Slug:               slug,
+			Excerpt:            excerpt,
+			Body:               body,
+			FeaturedImageUrl:   sql.NullString{String: featuredURL, Valid: featuredURL != ""},
+			FeaturedImageAlt:   sql.NullString{String: featuredAlt, Valid: featuredAlt != ""},
+			CategoryID:         categoryID,
+			AuthorID:           authorID,
+			MetaDescription:    sql.NullString{String: metaDesc, Valid: metaDesc != ""},
+			ReadingTimeMinutes: sql.NullInt64{Int64: readingTime, Valid: readingTime > 0},
+			Status:             status,
+			PublishedAt:        publishedAt,
+		})
+
+		if err != nil {
+			return err
+		}
+		return savePostDetails(c, q, post.ID, format, source)
 	})
 	if err != nil {
-		h.logger.Error("failed to create blog post", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create blog post", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
-
-	// Handle tag associations - form returns tag_ids[] array
-	// Each tag ID creates a row in the blog_post_tags junction table
-	tagIDs := c.Request().Form["tag_ids"]
-	for _, tagIDStr := range tagIDs {
-		tagID, _ := strconv.ParseInt(tagIDStr, 10, 64)
-		if tagID > 0 {
-			h.queries.AddTagToPost(ctx, sqlc.AddTagToPostParams{
-				BlogPostID: post.ID,
-				BlogTagID:  tagID,
-			})
-		}
-	}
-
-	// Handle product associations - form returns product_ids[] array
-	// Display order is determined by the order in the form submission
-	productIDs := c.Request().Form["product_ids"]
-	for i, pidStr := range productIDs {
-		pid, _ := strconv.ParseInt(pidStr, 10, 64)
-		if pid > 0 {
-			h.queries.AddProductToPost(ctx, sqlc.AddProductToPostParams{
-				BlogPostID:   post.ID,
-				ProductID:    pid,
-				DisplayOrder: sql.NullInt64{Int64: int64(i), Valid: true},
-			})
-		}
-	}
+	recordFormStatus(c, h.logger, "blog_post", post.ID, title, fmt.Sprintf("/admin/blog/posts/%d/edit", post.ID), "", status)
+	recordSlugChange(c, h.logger, "", "/blog/"+slug)
 
 	// Invalidate all blog-related cache entries since new content was created
 	h.cache.DeleteByPrefix("page:blog")
-	logActivity(c, "created", "blog_post", 0, title, "Created blog_post '%s'", title)
+	logActivity(c, "created", "blog_post", post.ID, title, "Created blog_post '%s'", title)
 	return c.Redirect(http.StatusSeeOther, "/admin/blog/posts")
 }
 
@@ -305,6 +374,10 @@ func (h *BlogPostsHandler) Edit(c echo.Context) error {
 		"AllTags":      allTags,
 		"PostTags":     postTags,     // Currently selected tags
 		"PostProducts": postProducts, // Currently associated products
+		"PreviewURL":   previewURL("/blog/"+post.Slug, services.PreviewBlogPost, post.ID),
+		"CanPublish":   canPublishFromForm(c, "blog_post", id, post.Status),
+		"Workflow":     workflow != nil, // Show the review panel
+		"LanguageTabs": languageTabs(c, "blog_post", id, ""),
 	})
 }
 
@@ -325,12 +398,18 @@ func (h *BlogPostsHandler) Update(c echo.Context) error {
 
 	// Extract and process form values (same as Create handler)
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title) // Auto-generate slug if not provided
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogPosts, c.FormValue("slug"), title, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate blog post slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	body := c.FormValue("body")
+	format, body, source, err := postBody(c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to render markdown", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
 	readingTime, _ := strconv.ParseInt(c.FormValue("reading_time_minutes"), 10, 64)
 	if readingTime == 0 {
 		readingTime = calculateReadingTime(body) // Auto-calculate if not manually set
@@ -343,69 +422,56 @@ func (h *BlogPostsHandler) Update(c echo.Context) error {
 	featuredAlt := c.FormValue("featured_image_alt")
 	metaDesc := c.FormValue("meta_description")
 	excerpt := c.FormValue("excerpt")
-	status := c.FormValue("status")
+	status := formStatus(c, "blog_post", id, existing.Status, c.FormValue("status"))
 
 	// Preserve existing published_at unless transitioning from draft/scheduled to published
 	// Only set published_at when first publishing the post
 	publishedAt := existing.PublishedAt
 	if status == "published" && !existing.PublishedAt.Valid {
 		pubStr := c.FormValue("published_at")
-		if t, err := time.Parse("2006-01-02T15:04", pubStr); err == nil {
+		if t, err := services.ParseSiteTime("2006-01-02T15:04", pubStr); err == nil { // Entered in the site timezone
 			publishedAt = sql.NullTime{Time: t, Valid: true}
 		} else {
-			publishedAt = sql.NullTime{Time: time.Now(), Valid: true} // Default to now
+			publishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true} // Default to now
 		}
 	}
 
-	// Update the blog post in the database
-	_, err = h.queries.UpdateBlogPost(ctx, sqlc.UpdateBlogPostParams{
-		ID:                 id,
-		Title:              title,
-		Slug:               slug,
-		Excerpt:            excerpt,
-		Body:               body,
-		FeaturedImageUrl:   sql.NullString{String: featuredURL, Valid: featuredURL != ""},
-		FeaturedImageAlt:   sql.NullString{String: featuredAlt, Valid: featuredAlt != ""},
-		CategoryID:         categoryID,
-		AuthorID:           authorID,
-		MetaDescription:    sql.NullString{String: metaDesc, Valid: metaDesc != ""},
-		ReadingTimeMinutes: sql.NullInt64{Int64: readingTime, Valid: readingTime > 0},
-		Status:             status,
-		PublishedAt:        publishedAt,
+	// Update the blog post in the database, together with its content
+	// format, tags and products, or nothing at all
+	err = withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		if _, err := q.UpdateBlogPost(ctx, sqlc.UpdateBlogPostParams{
+			ID:                 id,
+			Title:              title,
+			Slug:               slug,
+			Excerpt:            excerpt,
+			Body:               body,
+			FeaturedImageUrl:   sql.NullString{String: featuredURL, Valid: featuredURL != ""},
+			FeaturedImageAlt:   sql.NullString{String: featuredAlt, Valid: featuredAlt != ""},
+			CategoryID:         categoryID,
+			AuthorID:           authorID,
+			MetaDescription:    sql.NullString{String: metaDesc, Valid: metaDesc != ""},
+			ReadingTimeMinutes: sql.NullInt64{Int64: readingTime, Valid: readingTime > 0},
+			Status:             status,
+			PublishedAt:        publishedAt,
+		}); err != nil {
+			return err
+		}
+		// Tags and products use a "clear and re-add" strategy, which handles
+		// additions, removals and the new product order in one pass
+		if err := q.ClearPostTags(ctx, id); err != nil {
+			return err
+		}
+		if err := q.ClearPostProducts(ctx, id); err != nil {
+			return err
+		}
+		return savePostDetails(c, q, id, format, source)
 	})
 	if err != nil {
-		h.logger.Error("failed to update blog post", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update blog post", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
-
-	// Update tag associations using "clear and re-add" strategy
-	// This handles both newly added tags and removed tags in one operation
-	h.queries.ClearPostTags(ctx, id)
-	tagIDs := c.Request().Form["tag_ids"]
-	for _, tagIDStr := range tagIDs {
-		tagID, _ := strconv.ParseInt(tagIDStr, 10, 64)
-		if tagID > 0 {
-			h.queries.AddTagToPost(ctx, sqlc.AddTagToPostParams{
-				BlogPostID: id,
-				BlogTagID:  tagID,
-			})
-		}
-	}
-
-	// Update product associations using "clear and re-add" strategy
-	// Display order is recalculated based on new form submission order
-	h.queries.ClearPostProducts(ctx, id)
-	productIDs := c.Request().Form["product_ids"]
-	for i, pidStr := range productIDs {
-		pid, _ := strconv.ParseInt(pidStr, 10, 64)
-		if pid > 0 {
-			h.queries.AddProductToPost(ctx, sqlc.AddProductToPostParams{
-				BlogPostID:   id,
-				ProductID:    pid,
-				DisplayOrder: sql.NullInt64{Int64: int64(i), Valid: true},
-			})
-		}
-	}
+	recordFormStatus(c, h.logger, "blog_post", id, title, fmt.Sprintf("/admin/blog/posts/%d/edit", id), existing.Status, status)
+	recordSlugChange(c, h.logger, "/blog/"+existing.Slug, "/blog/"+slug)
 
 	// Invalidate all blog-related cache entries since content was modified
 	h.cache.DeleteByPrefix("page:blog")
@@ -413,26 +479,37 @@ func (h *BlogPostsHandler) Update(c echo.Context) error {
 	return c.Redirect(http.StatusSeeOther, "/admin/blog/posts")
 }
 
+// MarkdownPreview handles POST /admin/blog/posts/markdown-preview
+// Renders the submitted "body_markdown" exactly as it will be stored on save.
+// HTMX endpoint: returns an HTML fragment for the editor's preview pane.
+func (h *BlogPostsHandler) MarkdownPreview(c echo.Context) error {
+	html, err := services.RenderMarkdown(c.FormValue("body_markdown"))
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to render markdown", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if strings.TrimSpace(html) == "" {
+		return c.HTML(http.StatusOK, `<p class="text-sm text-gray-500">Nothing to preview yet.</p>`)
+	}
+	return c.HTML(http.StatusOK, html)
+}
+
 // Delete handles DELETE /admin/blog/posts/:id
-// Deletes a blog post and all associated tags and products.
+// Moves a blog post to the trash. Its tags and linked products are kept so
+// a restored post comes back unchanged.
 // HTMX behavior: Returns 200 OK with no content, triggering client-side row removal.
-// Must clear associations first due to foreign key constraints.
 func (h *BlogPostsHandler) Delete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 
-	// Clear associations first to avoid foreign key constraint violations
-	h.queries.ClearPostProducts(c.Request().Context(), id)
-	h.queries.ClearPostTags(c.Request().Context(), id)
-
-	// Delete the blog post itself
-	if err := h.queries.DeleteBlogPost(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete blog post", "error", err)
+	// Move the blog post to the trash
+	if _, err := h.queries.TrashBlogPost(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete blog post", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Invalidate blog cache since content was removed
 	h.cache.DeleteByPrefix("page:blog")
-	logActivity(c, "deleted", "blog_post", id, "", "Deleted blog_post #%d", id)
+	logActivity(c, "trashed", "blog_post", id, "", "Moved blog_post #%d to trash", id)
 
 	// Return empty 200 OK response for HTMX to process
 	return c.NoContent(http.StatusOK)
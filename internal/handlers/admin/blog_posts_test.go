@@ -142,7 +142,7 @@ func TestListPublishedPostsByCategory(t *testing.T) {
 	}
 }
 
-func TestGetRelatedPosts_ExcludesCurrentPost(t *testing.T) {
+func TestListRelatedPostCandidates_ExcludesCurrentPost(t *testing.T) {
 	_, queries, cleanup := testutil.SetupTestDB(t)
 	defer cleanup()
 
@@ -152,10 +152,10 @@ func TestGetRelatedPosts_ExcludesCurrentPost(t *testing.T) {
 	post1 := createTestPost(t, queries, cat, author, "Post 1", "post-1", "published", sql.NullTime{Time: time.Now(), Valid: true})
 	createTestPost(t, queries, cat, author, "Post 2", "post-2", "published", sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true})
 
-	related, err := queries.GetRelatedPosts(context.Background(), sqlc.GetRelatedPostsParams{
-		CategoryID: cat.ID,
-		ID:         post1.ID,
-		Limit:      3,
+	related, err := queries.ListRelatedPostCandidates(context.Background(), sqlc.ListRelatedPostCandidatesParams{
+		PostID:         post1.ID,
+		CategoryID:     cat.ID,
+		CandidateLimit: 3,
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
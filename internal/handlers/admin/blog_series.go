@@ -0,0 +1,207 @@
+package admin
+
+import (
+	// Standard library imports for data handling and HTTP operations
+	"database/sql" // Handles SQL NULL types for optional description field
+	"log/slog"     // Structured logging for error tracking
+	"net/http"     // HTTP status codes and error responses
+	"strconv"      // String to integer conversions for IDs and part numbers
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Cache service for invalidating blog pages
+)
+
+// BlogSeriesHandler manages blog series: named, ordered groups of posts used for
+// multi-part articles. Besides series CRUD it assigns posts to a series with a
+// part number, which drives prev/next navigation on public post pages.
+type BlogSeriesHandler struct {
+	queries sqlc.Querier    // Database query interface generated by sqlc
+	logger  *slog.Logger    // Structured logger for error tracking
+	cache   *services.Cache // Cache service for invalidating blog post pages
+}
+
+// NewBlogSeriesHandler constructs a new BlogSeriesHandler with required dependencies.
+func NewBlogSeriesHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *BlogSeriesHandler {
+	return &BlogSeriesHandler{queries: queries, logger: logger, cache: cache}
+}
+
+// List handles GET /admin/blog/series
+// Renders all series with their post counts.
+// Template: admin/pages/blog_series_list.html (full page)
+func (h *BlogSeriesHandler) List(c echo.Context) error {
+	items, err := h.queries.ListBlogSeries(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list blog series", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/blog_series_list.html", map[string]interface{}{
+		"Title": "Blog Series",
+		"Items": items,
+	})
+}
+
+// New handles GET /admin/blog/series/new
+// Renders the create series form with empty fields.
+// Template: admin/pages/blog_series_form.html (full page)
+func (h *BlogSeriesHandler) New(c echo.Context) error {
+	return c.Render(http.StatusOK, "admin/pages/blog_series_form.html", map[string]interface{}{
+		"Title":      "New Blog Series",
+		"FormAction": "/admin/blog/series",
+		"Item":       nil, // No existing series data
+	})
+}
+
+// Create handles POST /admin/blog/series
+// Creates a series and redirects to its edit page so posts can be assigned.
+func (h *BlogSeriesHandler) Create(c echo.Context) error {
+	desc := c.FormValue("description")
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogSeries, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog series slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	item, err := h.queries.CreateBlogSeries(c.Request().Context(), sqlc.CreateBlogSeriesParams{
+		Name:        c.FormValue("name"),
+		Slug:        slug,                                            // Auto-generate URL-friendly slug
+		Description: sql.NullString{String: desc, Valid: desc != ""}, // NULL if empty
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create blog series", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "created", "blog_series", item.ID, item.Name, "Created blog_series '%s'", item.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/blog/series/"+strconv.FormatInt(item.ID, 10)+"/edit")
+}
+
+// Edit handles GET /admin/blog/series/:id/edit
+// Renders the series form together with its ordered member posts and a picker
+// for adding unassigned posts.
+// Template: admin/pages/blog_series_form.html (full page)
+// Returns 404 if series ID does not exist.
+func (h *BlogSeriesHandler) Edit(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	item, err := h.queries.GetBlogSeries(ctx, id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Series not found")
+	}
+
+	posts, err := h.queries.ListPostsInSeries(ctx, sql.NullInt64{Int64: id, Valid: true})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list series posts", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	available, err := h.queries.ListPostsWithoutSeries(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list unassigned posts", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/blog_series_form.html", map[string]interface{}{
+		"Title":          "Edit Blog Series",
+		"FormAction":     "/admin/blog/series/" + c.Param("id"),
+		"Item":           item,
+		"Posts":          posts,
+		"AvailablePosts": available,
+		"NextPart":       len(posts) + 1, // Default part number for the next post added
+	})
+}
+
+// Update handles POST /admin/blog/series/:id
+// Updates name and description; the slug is regenerated from the name.
+func (h *BlogSeriesHandler) Update(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	desc := c.FormValue("description")
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogSeries, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog series slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	_, err = h.queries.UpdateBlogSeries(c.Request().Context(), sqlc.UpdateBlogSeriesParams{
+		ID:          id,
+		Name:        c.FormValue("name"),
+		Slug:        slug,
+		Description: sql.NullString{String: desc, Valid: desc != ""},
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update blog series", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// Series name appears on every member post page
+	h.cache.DeleteByPrefix("page:blog")
+
+	logActivity(c, "updated", "blog_series", id, c.FormValue("name"), "Updated blog_series '%s'", c.FormValue("name"))
+	return c.Redirect(http.StatusSeeOther, "/admin/blog/series")
+}
+
+// Delete handles DELETE /admin/blog/series/:id
+// Deletes a series. Member posts are kept and simply leave the series.
+// HTMX behavior: Returns 200 OK with no content, triggering client-side row removal.
+func (h *BlogSeriesHandler) Delete(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	if err := h.queries.DeleteBlogSeries(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete blog series", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.cache.DeleteByPrefix("page:blog")
+
+	logActivity(c, "deleted", "blog_series", id, "", "Deleted blog_series #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// AssignPost handles POST /admin/blog/series/:id/posts
+// Adds a post to the series (or updates its part number when it is already a
+// member). Form fields: post_id, series_order.
+// Redirects back to the series edit page.
+func (h *BlogSeriesHandler) AssignPost(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	postID, err := strconv.ParseInt(c.FormValue("post_id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid post")
+	}
+	order, _ := strconv.ParseInt(c.FormValue("series_order"), 10, 64)
+
+	err = h.queries.AssignPostToSeries(c.Request().Context(), sqlc.AssignPostToSeriesParams{
+		SeriesID:    sql.NullInt64{Int64: id, Valid: true},
+		SeriesOrder: order,
+		PostID:      postID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to assign post to series", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.cache.DeleteByPrefix("page:blog")
+
+	logActivity(c, "updated", "blog_series", id, "", "Set post #%d as part %d of blog_series #%d", postID, order, id)
+	return c.Redirect(http.StatusSeeOther, "/admin/blog/series/"+c.Param("id")+"/edit")
+}
+
+// RemovePost handles DELETE /admin/blog/series/:id/posts/:post_id
+// Removes a post from the series without deleting it.
+// HTMX behavior: Returns 200 OK with no content, triggering client-side row removal.
+func (h *BlogSeriesHandler) RemovePost(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	postID, _ := strconv.ParseInt(c.Param("post_id"), 10, 64)
+
+	if err := h.queries.RemovePostFromSeries(c.Request().Context(), postID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to remove post from series", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.cache.DeleteByPrefix("page:blog")
+
+	logActivity(c, "updated", "blog_series", id, "", "Removed post #%d from blog_series #%d", postID, id)
+	return c.NoContent(http.StatusOK)
+}
@@ -11,19 +11,20 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal application imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Unique slug generation
 )
 
 // BlogTagsHandler manages all HTTP handlers for blog tag CRUD operations.
 // Tags are simpler than categories - they only have name and slug fields.
 // Tags support HTMX-powered search/autocomplete and quick-create functionality.
 type BlogTagsHandler struct {
-	queries *sqlc.Queries // Database query interface generated by sqlc
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error tracking
 }
 
 // NewBlogTagsHandler constructs a new BlogTagsHandler with required dependencies.
-func NewBlogTagsHandler(queries *sqlc.Queries, logger *slog.Logger) *BlogTagsHandler {
+func NewBlogTagsHandler(queries sqlc.Querier, logger *slog.Logger) *BlogTagsHandler {
 	return &BlogTagsHandler{queries: queries, logger: logger}
 }
 
@@ -35,7 +36,7 @@ func (h *BlogTagsHandler) List(c echo.Context) error {
 	// Fetch all blog tags from the database
 	tags, err := h.queries.ListAllBlogTags(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list blog tags", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list blog tags", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -53,14 +54,20 @@ func (h *BlogTagsHandler) List(c echo.Context) error {
 func (h *BlogTagsHandler) Create(c echo.Context) error {
 	name := c.FormValue("name")
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogTags, "", name, 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog tag slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Insert the blog tag into the database
-	// Slug is auto-generated from name using makeSlug utility function
-	_, err := h.queries.CreateBlogTag(c.Request().Context(), sqlc.CreateBlogTagParams{
+	// Slug is auto-generated from name using uniqueSlug
+	_, err = h.queries.CreateBlogTag(c.Request().Context(), sqlc.CreateBlogTagParams{
 		Name: name,
-		Slug: makeSlug(name), // Auto-generate URL-friendly slug
+		Slug: slug,           // Auto-generate URL-friendly slug
 	})
 	if err != nil {
-		h.logger.Error("failed to create blog tag", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create blog tag", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -109,13 +116,19 @@ func (h *BlogTagsHandler) QuickCreate(c echo.Context) error {
 		return c.NoContent(http.StatusBadRequest)
 	}
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugBlogTags, "", name, 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate blog tag slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Insert the new tag into the database
 	tag, err := h.queries.CreateBlogTag(c.Request().Context(), sqlc.CreateBlogTagParams{
 		Name: name,
-		Slug: makeSlug(name), // Auto-generate slug from name
+		Slug: slug,           // Auto-generate slug from name
 	})
 	if err != nil {
-		h.logger.Error("failed to quick-create blog tag", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to quick-create blog tag", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -140,7 +153,7 @@ func (h *BlogTagsHandler) Delete(c echo.Context) error {
 	// Attempt to delete the tag
 	// Junction table entries (blog_post_tags) are automatically deleted via cascade
 	if err := h.queries.DeleteBlogTag(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete blog tag", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete blog tag", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
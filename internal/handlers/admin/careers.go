@@ -0,0 +1,447 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the job postings listed on /careers and the inbox of
+// applications sent with their apply forms.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows detection and closing dates
+	"errors"       // Error inspection
+	"io"           // Streaming CVs
+	"log/slog"     // Structured logging
+	"math"         // Page count calculation
+	"mime"         // CV download file names
+	"net/http"     // HTTP status codes
+	"slices"       // Employment type and status validation
+	"strconv"      // Parsing IDs and pages
+	"strings"      // Trimming form values
+	"time"         // Closing dates and first publication
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Careers service, slugs, site timezone and page cache
+)
+
+// applicationsPerPage is the page size of the application inbox.
+const applicationsPerPage = 50
+
+// CareersHandler handles job postings at /admin/careers and the
+// applications received for them.
+type CareersHandler struct {
+	queries sqlc.Querier      // Database queries generated by sqlc
+	careers *services.Careers // Deletes postings and applications with their CVs, serves CVs
+	logger  *slog.Logger      // Structured logger for error reporting
+	cache   *services.Cache   // Public page cache, cleared after each change
+}
+
+// NewCareersHandler creates a new CareersHandler instance.
+func NewCareersHandler(queries sqlc.Querier, careers *services.Careers, logger *slog.Logger, cache *services.Cache) *CareersHandler {
+	return &CareersHandler{queries: queries, careers: careers, logger: logger, cache: cache}
+}
+
+// List handles GET /admin/careers
+// Lists every job posting, newest first, with its application counts.
+// Template: admin/pages/careers_list.html (full page)
+func (h *CareersHandler) List(c echo.Context) error {
+	jobs, err := h.queries.ListJobPostingsAdmin(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list job postings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/careers_list.html", map[string]interface{}{
+		"Title":      "Careers",
+		"Jobs":       jobs,
+		"TypeLabels": services.JobEmploymentTypeLabels,
+	})
+}
+
+// New handles GET /admin/careers/new
+// Template: admin/pages/careers_form.html (full page)
+func (h *CareersHandler) New(c echo.Context) error {
+	return h.renderForm(c, http.StatusOK, sqlc.JobPosting{EmploymentType: services.JobFullTime}, "")
+}
+
+// Create handles POST /admin/careers
+// Adds a job posting and returns to the list. Invalid input is reported on
+// the form.
+func (h *CareersHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	job, msg := h.form(c, 0)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, job, msg)
+	}
+	if job.IsPublished == 1 {
+		job.PostedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+	created, err := h.queries.CreateJobPosting(ctx, sqlc.CreateJobPostingParams{
+		Title:           job.Title,
+		Slug:            job.Slug,
+		Department:      job.Department,
+		Location:        job.Location,
+		Country:         job.Country,
+		EmploymentType:  job.EmploymentType,
+		Remote:          job.Remote,
+		Summary:         job.Summary,
+		Description:     job.Description,
+		ValidThrough:    job.ValidThrough,
+		IsPublished:     job.IsPublished,
+		PostedAt:        job.PostedAt,
+		MetaDescription: job.MetaDescription,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create job posting", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, "", "/careers/"+created.Slug)
+	h.changed()
+	logActivity(c, "created", "job_posting", created.ID, created.Title, "Created job posting %q", created.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/careers")
+}
+
+// Edit handles GET /admin/careers/:id/edit
+// Template: admin/pages/careers_form.html (full page)
+func (h *CareersHandler) Edit(c echo.Context) error {
+	job, err := h.job(c)
+	if err != nil {
+		return err
+	}
+	return h.renderForm(c, http.StatusOK, job, "")
+}
+
+// Update handles POST /admin/careers/:id
+// Saves the job posting form. A changed slug leaves a redirect from the old
+// page. The posting date is set the first time the posting is published.
+func (h *CareersHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, err := h.job(c)
+	if err != nil {
+		return err
+	}
+	job, msg := h.form(c, existing.ID)
+	job.ID = existing.ID
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, job, msg)
+	}
+	job.PostedAt = existing.PostedAt
+	if job.IsPublished == 1 && !job.PostedAt.Valid {
+		job.PostedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+	err = h.queries.UpdateJobPosting(ctx, sqlc.UpdateJobPostingParams{
+		Title:           job.Title,
+		Slug:            job.Slug,
+		Department:      job.Department,
+		Location:        job.Location,
+		Country:         job.Country,
+		EmploymentType:  job.EmploymentType,
+		Remote:          job.Remote,
+		Summary:         job.Summary,
+		Description:     job.Description,
+		ValidThrough:    job.ValidThrough,
+		IsPublished:     job.IsPublished,
+		PostedAt:        job.PostedAt,
+		MetaDescription: job.MetaDescription,
+		ID:              existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update job posting", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, "/careers/"+existing.Slug, "/careers/"+job.Slug)
+	h.changed()
+	logActivity(c, "updated", "job_posting", existing.ID, job.Title, "Updated job posting %q", job.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/careers")
+}
+
+// Delete handles DELETE /admin/careers/:id
+// Removes a job posting with its applications and their CVs. HTMX: returns
+// an empty 200 response and the row is removed.
+func (h *CareersHandler) Delete(c echo.Context) error {
+	job, err := h.job(c)
+	if err != nil {
+		return err
+	}
+	if err := h.careers.DeleteJob(c.Request().Context(), job); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete job posting", "error", err, "id", job.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "deleted", "job_posting", job.ID, job.Title, "Deleted job posting %q", job.Title)
+	return c.NoContent(http.StatusOK)
+}
+
+// Applications handles GET /admin/careers/applications
+// Renders the application inbox, newest first, 50 per page, with the
+// number of applications in each status.
+// Template: admin/pages/job_applications_list.html (full page)
+//
+// Query parameters:
+//   - status: Application status (default: all)
+//   - job: Job posting ID (default: all)
+//   - page: Page number (default 1)
+func (h *CareersHandler) Applications(c echo.Context) error {
+	ctx := c.Request().Context()
+	status := c.QueryParam("status")
+	if !slices.Contains(services.JobApplicationStatuses, status) {
+		status = ""
+	}
+	jobID, _ := strconv.ParseInt(c.QueryParam("job"), 10, 64)
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	applications, err := h.queries.ListJobApplications(ctx, sqlc.ListJobApplicationsParams{
+		FilterStatus: status,
+		FilterJobID:  jobID,
+		PageLimit:    applicationsPerPage,
+		PageOffset:   int64(page-1) * applicationsPerPage,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load job applications", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	total, err := h.queries.CountJobApplications(ctx, sqlc.CountJobApplicationsParams{FilterStatus: status, FilterJobID: jobID})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count job applications", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	byStatus, err := h.queries.CountJobApplicationsByStatus(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count job applications by status", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	counts := map[string]int64{}
+	for _, row := range byStatus {
+		counts[row.Status] = row.Count
+	}
+	jobs, err := h.queries.ListJobPostingsAdmin(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list job postings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(applicationsPerPage)))
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	var pages []int
+	for i := 1; i <= totalPages; i++ {
+		pages = append(pages, i)
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/job_applications_list.html", map[string]interface{}{
+		"Title":        "Applications",
+		"Applications": applications,
+		"Counts":       counts,
+		"Statuses":     services.JobApplicationStatuses,
+		"Status":       status,
+		"Jobs":         jobs,
+		"JobID":        jobID,
+		"Total":        total,
+		"Page":         page,
+		"TotalPages":   totalPages,
+		"Pages":        pages,
+	})
+}
+
+// Application handles GET /admin/careers/applications/:id
+// Shows an application with its cover letter, a link to the CV and the
+// status and notes form.
+// Template: admin/pages/job_application_detail.html (full page)
+func (h *CareersHandler) Application(c echo.Context) error {
+	app, err := h.application(c)
+	if err != nil {
+		return err
+	}
+	job, err := h.queries.GetJobPosting(c.Request().Context(), app.JobID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load job posting", "error", err, "id", app.JobID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/job_application_detail.html", map[string]interface{}{
+		"Title":       "Application: " + app.Name,
+		"Application": app,
+		"Job":         job,
+		"Statuses":    services.JobApplicationStatuses,
+	})
+}
+
+// UpdateApplicationStatus handles POST /admin/careers/applications/:id/status
+// Saves the status and notes of an application and returns to it.
+//
+// Form Fields: status, notes
+func (h *CareersHandler) UpdateApplicationStatus(c echo.Context) error {
+	app, err := h.application(c)
+	if err != nil {
+		return err
+	}
+	status := c.FormValue("status")
+	if !slices.Contains(services.JobApplicationStatuses, status) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status")
+	}
+	err = h.queries.UpdateJobApplicationStatus(c.Request().Context(), sqlc.UpdateJobApplicationStatusParams{
+		Status: status,
+		Notes:  strings.TrimSpace(c.FormValue("notes")),
+		ID:     app.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update job application", "error", err, "id", app.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "updated", "job_application", app.ID, app.Name, "Moved application #%d to %s", app.ID, status)
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Application updated.")
+	return c.Redirect(http.StatusSeeOther, "/admin/careers/applications/"+strconv.FormatInt(app.ID, 10))
+}
+
+// DownloadCV handles GET /admin/careers/applications/:id/cv
+// Sends the CV of an application as an attachment, under the file name it
+// was uploaded with.
+func (h *CareersHandler) DownloadCV(c echo.Context) error {
+	app, err := h.application(c)
+	if err != nil {
+		return err
+	}
+	f, err := h.careers.OpenCV(c.Request().Context(), app)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to open CV", "error", err, "id", app.ID)
+		return echo.NewHTTPError(http.StatusNotFound, "CV not found")
+	}
+	defer f.Close()
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": app.CvFilename}))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	c.Response().Header().Set(echo.HeaderContentType, "application/octet-stream")
+	c.Response().WriteHeader(http.StatusOK)
+	_, err = io.Copy(c.Response(), f)
+	return err
+}
+
+// DeleteApplication handles DELETE /admin/careers/applications/:id
+// Removes an application and its CV, e.g. on an erasure request. HTMX:
+// returns an empty 200 response and the row is removed.
+func (h *CareersHandler) DeleteApplication(c echo.Context) error {
+	app, err := h.application(c)
+	if err != nil {
+		return err
+	}
+	if err := h.careers.DeleteApplication(c.Request().Context(), app); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete job application", "error", err, "id", app.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "deleted", "job_application", app.ID, app.Name, "Deleted application #%d", app.ID)
+	return c.NoContent(http.StatusOK)
+}
+
+// job loads the job posting named by the :id parameter, returning an HTTP
+// error for a bad or unknown ID.
+func (h *CareersHandler) job(c echo.Context) (sqlc.JobPosting, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.JobPosting{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	job, err := h.queries.GetJobPosting(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return job, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load job posting", "error", err, "id", id)
+		return job, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return job, nil
+}
+
+// application loads the application named by the :id parameter,
+// returning an HTTP error for a bad or unknown ID.
+func (h *CareersHandler) application(c echo.Context) (sqlc.JobApplication, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.JobApplication{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	app, err := h.queries.GetJobApplication(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return app, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load job application", "error", err, "id", id)
+		return app, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return app, nil
+}
+
+// changed drops the cached /careers pages, which were rendered before the
+// change.
+func (h *CareersHandler) changed() {
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:careers")
+	}
+}
+
+// form reads and validates the job posting form for posting id (0 for a
+// new one). The closing date is entered in the site timezone and applies
+// until the end of that day. It returns a message for the form on invalid
+// input.
+func (h *CareersHandler) form(c echo.Context, id int64) (sqlc.JobPosting, string) {
+	job := sqlc.JobPosting{
+		Title:           strings.TrimSpace(c.FormValue("title")),
+		Department:      strings.TrimSpace(c.FormValue("department")),
+		Location:        strings.TrimSpace(c.FormValue("location")),
+		Country:         strings.ToUpper(strings.TrimSpace(c.FormValue("country"))),
+		EmploymentType:  c.FormValue("employment_type"),
+		Remote:          checkboxValue(c.FormValue("remote")),
+		Summary:         strings.TrimSpace(c.FormValue("summary")),
+		Description:     strings.TrimSpace(c.FormValue("description")),
+		MetaDescription: strings.TrimSpace(c.FormValue("meta_description")),
+		Slug:            strings.TrimSpace(c.FormValue("slug")),
+		IsPublished:     checkboxValue(c.FormValue("is_published")),
+	}
+	var dateErr error
+	if v := strings.TrimSpace(c.FormValue("valid_through")); v != "" {
+		var day time.Time
+		day, dateErr = services.ParseSiteTime("2006-01-02", v)
+		job.ValidThrough = sql.NullTime{Time: day.Add(24*time.Hour - time.Second), Valid: dateErr == nil}
+	}
+
+	switch {
+	case job.Title == "":
+		return job, "Give the position a title."
+	case !slices.Contains(services.JobEmploymentTypes, job.EmploymentType):
+		return job, "Choose the employment type."
+	case job.Location == "" && job.Remote != 1:
+		return job, "Enter where the position is based, or mark it remote."
+	case job.Country != "" && !isCountryCode(job.Country):
+		return job, "Enter the country as a two-letter code, such as IN or US."
+	case job.Description == "":
+		return job, "Describe the position."
+	case dateErr != nil:
+		return job, "Enter the closing date as a date."
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugJobPostings, job.Slug, job.Title, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate job posting slug", "error", err)
+		return job, "Could not find a free address for this position; enter another slug."
+	}
+	job.Slug = slug
+	return job, ""
+}
+
+// isCountryCode reports whether s looks like an ISO 3166-1 alpha-2 code.
+func isCountryCode(s string) bool {
+	return len(s) == 2 && s[0] >= 'A' && s[0] <= 'Z' && s[1] >= 'A' && s[1] <= 'Z'
+}
+
+// renderForm shows the add or edit form for job (ID 0 for a new one).
+func (h *CareersHandler) renderForm(c echo.Context, status int, job sqlc.JobPosting, errMsg string) error {
+	title, action := "New Job Posting", "/admin/careers"
+	if job.ID != 0 {
+		title, action = "Edit Job Posting", "/admin/careers/"+strconv.FormatInt(job.ID, 10)
+	}
+	return c.Render(status, "admin/pages/careers_form.html", map[string]interface{}{
+		"Title":           title,
+		"Item":            job,
+		"FormAction":      action,
+		"EmploymentTypes": services.JobEmploymentTypes,
+		"TypeLabels":      services.JobEmploymentTypeLabels,
+		"Error":           errMsg,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	// Standard library imports
 	"database/sql"   // Used for nullable SQL types (NullString, NullInt64)
 	"encoding/json"  // JSON marshaling for storing bullet arrays in database
+	"fmt"            // Editor path after a save that did not publish
 	"log/slog"       // Structured logging for error tracking and debugging
 	"math"           // Used for math.Ceil to calculate total pages from item count
 	"net/http"       // HTTP status codes for responses
@@ -26,7 +27,7 @@ const caseStudiesPerPage = 15
 // CaseStudiesHandler handles all HTTP requests for case studies management in the admin panel.
 // It manages CRUD operations for case studies and their related resources (products, metrics).
 type CaseStudiesHandler struct {
-	queries *sqlc.Queries   // Database query interface generated by sqlc
+	queries sqlc.Querier    // Database query interface generated by sqlc
 	logger  *slog.Logger    // Structured logger for error tracking
 	cache   *services.Cache // Cache service for invalidating page cache after updates
 }
@@ -38,7 +39,7 @@ type CaseStudiesHandler struct {
 //   - cache: cache service for cache invalidation
 //
 // Returns a fully initialized CaseStudiesHandler ready to handle HTTP requests.
-func NewCaseStudiesHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *CaseStudiesHandler {
+func NewCaseStudiesHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *CaseStudiesHandler {
 	return &CaseStudiesHandler{
 		queries: queries,
 		logger:  logger,
@@ -80,7 +81,7 @@ func (h *CaseStudiesHandler) List(c echo.Context) error {
 		PageOffset:   offset,
 	})
 	if err != nil {
-		h.logger.Error("Failed to list case studies", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to list case studies", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load case studies")
 	}
 
@@ -89,7 +90,7 @@ func (h *CaseStudiesHandler) List(c echo.Context) error {
 		FilterStatus: status,
 	})
 	if err != nil {
-		h.logger.Error("Failed to count case studies", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to count case studies", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to count case studies")
 	}
 
@@ -111,9 +112,14 @@ func (h *CaseStudiesHandler) List(c echo.Context) error {
 
 	hasFilters := search != "" || status != ""
 
+	rows := make([]caseStudyRow, len(caseStudies))
+	for i, cs := range caseStudies {
+		rows[i] = caseStudyRow{AdminListCaseStudiesFilteredRow: cs}
+	}
+
 	return c.Render(http.StatusOK, "admin/pages/case_studies_list.html", map[string]interface{}{
 		"Title":       "Case Studies",
-		"CaseStudies": caseStudies,
+		"CaseStudies": rows,
 		"Search":      search,
 		"Status":      status,
 		"HasFilters":  hasFilters,
@@ -139,7 +145,7 @@ func (h *CaseStudiesHandler) List(c echo.Context) error {
 func (h *CaseStudiesHandler) New(c echo.Context) error {
 	industries, err := h.queries.ListIndustries(c.Request().Context())
 	if err != nil {
-		h.logger.Error("Failed to list industries", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to list industries", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load industries")
 	}
 
@@ -182,23 +188,27 @@ func (h *CaseStudiesHandler) New(c echo.Context) error {
 //   - Auto-generates slug from title if not provided
 //   - Converts comma-separated challenge_bullets to JSON array for database storage
 //   - Trims whitespace from individual bullet points
+//   - Publishing runs the publish checklist; a case study that fails is saved
+//     unpublished and its edit page opens with the failed checks
 //   - Invalidates "page:case-studies" cache entries after creation
 func (h *CaseStudiesHandler) Create(c echo.Context) error {
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title)
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugCaseStudies, c.FormValue("slug"), title, 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to generate case study slug", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to create case study")
 	}
 
 	clientName := c.FormValue("client_name")
 	summary := c.FormValue("summary")
 	heroImageUrl := c.FormValue("hero_image_url")
 	challengeTitle := c.FormValue("challenge_title")
-	challengeContent := c.FormValue("challenge_content")
+	challengeContent := sanitizeHTML(c.FormValue("challenge_content"))
 	solutionTitle := c.FormValue("solution_title")
-	solutionContent := c.FormValue("solution_content")
+	solutionContent := sanitizeHTML(c.FormValue("solution_content"))
 	outcomeTitle := c.FormValue("outcome_title")
-	outcomeContent := c.FormValue("outcome_content")
+	outcomeContent := sanitizeHTML(c.FormValue("outcome_content"))
 	metaTitle := c.FormValue("meta_title")
 	metaDescription := c.FormValue("meta_description")
 
@@ -221,6 +231,21 @@ func (h *CaseStudiesHandler) Create(c echo.Context) error {
 		isPublished = 1
 	}
 
+	// Publishing needs the publish checklist to pass; otherwise save it unpublished
+	var gate publishGate
+	if isPublished == 1 {
+		var err error
+		gate, err = checkPublish(c, caseStudyCandidate(metaDescription, heroImageUrl, industryID,
+			summary, challengeContent, solutionContent, outcomeContent))
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+			return c.String(http.StatusInternalServerError, "Failed to create case study")
+		}
+		if !gate.allowed() {
+			isPublished = 0
+		}
+	}
+
 	// Convert challenge_bullets comma-separated to JSON array
 	challengeBulletsRaw := c.FormValue("challenge_bullets")
 	challengeBulletsJSON := sql.NullString{}
@@ -261,14 +286,20 @@ func (h *CaseStudiesHandler) Create(c echo.Context) error {
 		DisplayOrder:      displayOrder,
 	}
 
-	_, err := h.queries.AdminCreateCaseStudy(c.Request().Context(), params)
+	caseStudy, err := h.queries.AdminCreateCaseStudy(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create case study", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create case study", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to create case study")
 	}
+	recordSlugChange(c, h.logger, "", "/case-studies/"+slug)
 
 	h.cache.DeleteByPrefix("page:case-studies")
 	logActivity(c, "created", "case_study", 0, c.FormValue("title"), "Created Case Study '%s'", c.FormValue("title"))
+	gate.logOverride(c, "case_study", caseStudy.ID, title)
+	if !gate.allowed() {
+		// Show the failed checks on the new case study's edit page
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/case-studies/%d/edit?publish=%s", caseStudy.ID, gate.notice))
+	}
 	return c.Redirect(http.StatusSeeOther, "/admin/case-studies")
 }
 
@@ -298,34 +329,41 @@ func (h *CaseStudiesHandler) Edit(c echo.Context) error {
 
 	caseStudy, err := h.queries.AdminGetCaseStudy(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get case study", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get case study", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load case study")
 	}
 
 	industries, err := h.queries.ListIndustries(c.Request().Context())
 	if err != nil {
-		h.logger.Error("Failed to list industries", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to list industries", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load industries")
 	}
 
 	caseStudyProducts, err := h.queries.AdminListCaseStudyProducts(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get case study products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get case study products", "error", err)
 		caseStudyProducts = []sqlc.AdminListCaseStudyProductsRow{}
 	}
 
 	metrics, err := h.queries.AdminListMetrics(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get case study metrics", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get case study metrics", "error", err)
 		metrics = []sqlc.AdminListMetricsRow{}
 	}
 
 	allProducts, err := h.queries.ListProducts(c.Request().Context(), sqlc.ListProductsParams{Limit: 1000, Offset: 0})
 	if err != nil {
-		h.logger.Error("Failed to list all products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to list all products", "error", err)
 		allProducts = []sqlc.Product{}
 	}
 
+	checklist, err := publishChecklist(c, "case-studies", caseStudyCandidate(caseStudy.MetaDescription.String,
+		caseStudy.HeroImageUrl.String, caseStudy.IndustryID, caseStudy.Summary,
+		caseStudy.ChallengeContent, caseStudy.SolutionContent, caseStudy.OutcomeContent))
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+	}
+
 	return c.Render(http.StatusOK, "admin/pages/case_studies_form.html", map[string]interface{}{
 		"Title":       "Edit Case Study",
 		"FormAction":  "/admin/case-studies/" + c.Param("id"),
@@ -335,6 +373,8 @@ func (h *CaseStudiesHandler) Edit(c echo.Context) error {
 		"Metrics":     metrics,
 		"AllProducts": allProducts,
 		"IsNew":       false,
+		"PreviewURL":  previewURL("/case-studies/"+caseStudy.Slug, services.PreviewCaseStudy, caseStudy.ID),
+		"Checklist":   checklist,
 	})
 }
 
@@ -354,6 +394,7 @@ func (h *CaseStudiesHandler) Edit(c echo.Context) error {
 //   - Updates only the case study base record (not related resources)
 //   - Related resources (products, metrics) updated via separate endpoints
 //   - Processes challenge_bullets same as Create (comma-separated to JSON)
+//   - Publishing an unpublished case study runs the publish checklist, as in Create
 //   - Invalidates "page:case-studies" cache entries
 func (h *CaseStudiesHandler) Update(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -362,20 +403,22 @@ func (h *CaseStudiesHandler) Update(c echo.Context) error {
 	}
 
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title)
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugCaseStudies, c.FormValue("slug"), title, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to generate case study slug", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to update case study")
 	}
 
 	clientName := c.FormValue("client_name")
 	summary := c.FormValue("summary")
 	heroImageUrl := c.FormValue("hero_image_url")
 	challengeTitle := c.FormValue("challenge_title")
-	challengeContent := c.FormValue("challenge_content")
+	challengeContent := sanitizeHTML(c.FormValue("challenge_content"))
 	solutionTitle := c.FormValue("solution_title")
-	solutionContent := c.FormValue("solution_content")
+	solutionContent := sanitizeHTML(c.FormValue("solution_content"))
 	outcomeTitle := c.FormValue("outcome_title")
-	outcomeContent := c.FormValue("outcome_content")
+	outcomeContent := sanitizeHTML(c.FormValue("outcome_content"))
 	metaTitle := c.FormValue("meta_title")
 	metaDescription := c.FormValue("meta_description")
 
@@ -398,6 +441,26 @@ func (h *CaseStudiesHandler) Update(c echo.Context) error {
 		isPublished = 1
 	}
 
+	existing, err := h.queries.AdminGetCaseStudy(c.Request().Context(), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get case study", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to update case study")
+	}
+
+	// Publishing an unpublished case study needs the publish checklist to pass
+	var gate publishGate
+	if isPublished == 1 && existing.IsPublished == 0 {
+		gate, err = checkPublish(c, caseStudyCandidate(metaDescription, heroImageUrl, industryID,
+			summary, challengeContent, solutionContent, outcomeContent))
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+			return c.String(http.StatusInternalServerError, "Failed to update case study")
+		}
+		if !gate.allowed() {
+			isPublished = 0
+		}
+	}
+
 	// Convert challenge_bullets comma-separated to JSON array
 	challengeBulletsRaw := c.FormValue("challenge_bullets")
 	challengeBulletsJSON := sql.NullString{}
@@ -441,12 +504,17 @@ func (h *CaseStudiesHandler) Update(c echo.Context) error {
 
 	_, err = h.queries.AdminUpdateCaseStudy(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to update case study", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update case study", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update case study")
 	}
+	recordSlugChange(c, h.logger, "/case-studies/"+existing.Slug, "/case-studies/"+slug)
 
 	h.cache.DeleteByPrefix("page:case-studies")
 	logActivity(c, "updated", "case_study", id, c.FormValue("title"), "Updated Case Study '%s'", c.FormValue("title"))
+	gate.logOverride(c, "case_study", id, title)
+	if !gate.allowed() {
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/case-studies/%d/edit?publish=%s", id, gate.notice))
+	}
 	return c.Redirect(http.StatusSeeOther, "/admin/case-studies")
 }
 
@@ -461,7 +529,8 @@ func (h *CaseStudiesHandler) Update(c echo.Context) error {
 //   - id: Case study ID (int64)
 //
 // Business Logic:
-//   - Deletes case study and all related resources (cascade delete handled by DB)
+//   - Moves the case study to the trash; related resources are kept until it
+//     is deleted permanently (cascade delete handled by DB)
 //   - Invalidates "page:case-studies" cache entries
 //   - Returns 204 No Content on success (HTMX removes element from DOM)
 func (h *CaseStudiesHandler) Delete(c echo.Context) error {
@@ -470,14 +539,14 @@ func (h *CaseStudiesHandler) Delete(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "Invalid case study ID")
 	}
 
-	err = h.queries.AdminDeleteCaseStudy(c.Request().Context(), id)
+	_, err = h.queries.TrashCaseStudy(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to delete case study", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete case study", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete case study")
 	}
 
 	h.cache.DeleteByPrefix("page:case-studies")
-	logActivity(c, "deleted", "case_study", id, "", "Deleted Case Study #%d", id)
+	logActivity(c, "trashed", "case_study", id, "", "Moved Case Study #%d to trash", id)
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -528,7 +597,7 @@ func (h *CaseStudiesHandler) AddProduct(c echo.Context) error {
 
 	_, err = h.queries.AdminAddCaseStudyProduct(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to add product to case study", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to add product to case study", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to add product")
 	}
 
@@ -536,13 +605,14 @@ func (h *CaseStudiesHandler) AddProduct(c echo.Context) error {
 
 	caseStudyProducts, err := h.queries.AdminListCaseStudyProducts(c.Request().Context(), caseStudyID)
 	if err != nil {
-		h.logger.Error("Failed to get case study products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get case study products", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load products")
 	}
 
 	logActivity(c, "updated", "case_study", caseStudyID, "", "Updated Case Study #%d sub-resources", caseStudyID)
 	return c.Render(http.StatusOK, "admin/partials/case_study_products.html", map[string]interface{}{
-		"Products": caseStudyProducts,
+		"CaseStudyID": caseStudyID,
+		"Products":    caseStudyProducts,
 	})
 }
 
@@ -582,7 +652,7 @@ func (h *CaseStudiesHandler) RemoveProduct(c echo.Context) error {
 
 	err = h.queries.AdminRemoveCaseStudyProduct(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to remove product from case study", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to remove product from case study", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to remove product")
 	}
 
@@ -638,7 +708,7 @@ func (h *CaseStudiesHandler) AddMetric(c echo.Context) error {
 
 	_, err = h.queries.AdminCreateMetric(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create metric", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create metric", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to add metric")
 	}
 
@@ -646,13 +716,14 @@ func (h *CaseStudiesHandler) AddMetric(c echo.Context) error {
 
 	metrics, err := h.queries.AdminListMetrics(c.Request().Context(), caseStudyID)
 	if err != nil {
-		h.logger.Error("Failed to get case study metrics", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get case study metrics", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load metrics")
 	}
 
 	logActivity(c, "updated", "case_study", caseStudyID, "", "Updated Case Study #%d sub-resources", caseStudyID)
 	return c.Render(http.StatusOK, "admin/partials/case_study_metrics.html", map[string]interface{}{
-		"Metrics": metrics,
+		"CaseStudyID": caseStudyID,
+		"Metrics":     metrics,
 	})
 }
 
@@ -677,7 +748,7 @@ func (h *CaseStudiesHandler) DeleteMetric(c echo.Context) error {
 
 	err = h.queries.AdminDeleteMetric(c.Request().Context(), metricID)
 	if err != nil {
-		h.logger.Error("Failed to delete metric", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete metric", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete metric")
 	}
 
@@ -23,9 +23,9 @@ import (
 // and office locations. It handles viewing, filtering, status updates, and deletion
 // of contact submissions, as well as CRUD operations for office locations.
 type AdminContactHandler struct {
-	queries *sqlc.Queries    // Database query interface generated by sqlc
-	logger  *slog.Logger     // Structured logger for error and event logging
-	cache   *services.Cache  // Cache service for invalidating page-level caches
+	queries sqlc.Querier    // Database query interface generated by sqlc
+	logger  *slog.Logger    // Structured logger for error and event logging
+	cache   *services.Cache // Cache service for invalidating page-level caches
 }
 
 // NewAdminContactHandler creates a new AdminContactHandler instance with required dependencies.
@@ -33,7 +33,7 @@ type AdminContactHandler struct {
 //   - queries: sqlc-generated database query interface
 //   - logger: structured logger for error and event tracking
 //   - cache: cache service for page invalidation
-func NewAdminContactHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *AdminContactHandler {
+func NewAdminContactHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *AdminContactHandler {
 	return &AdminContactHandler{
 		queries: queries,
 		logger:  logger,
@@ -105,7 +105,7 @@ func (h *AdminContactHandler) ListSubmissions(c echo.Context) error {
 			Offset:  offset,
 		})
 		if err != nil {
-			h.logger.Error("Failed to search contact submissions", "error", err)
+			h.logger.ErrorContext(ctx, "Failed to search contact submissions", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to load submissions")
 		}
 
@@ -129,7 +129,7 @@ func (h *AdminContactHandler) ListSubmissions(c echo.Context) error {
 			Status: status, SubmissionType: submissionType, Limit: perPage, Offset: offset,
 		})
 		if err != nil {
-			h.logger.Error("Failed to list contact submissions", "error", err)
+			h.logger.ErrorContext(ctx, "Failed to list contact submissions", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to load submissions")
 		}
 		for _, item := range items {
@@ -149,7 +149,7 @@ func (h *AdminContactHandler) ListSubmissions(c echo.Context) error {
 			Status: status, Limit: perPage, Offset: offset,
 		})
 		if err != nil {
-			h.logger.Error("Failed to list contact submissions", "error", err)
+			h.logger.ErrorContext(ctx, "Failed to list contact submissions", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to load submissions")
 		}
 		for _, item := range items {
@@ -167,7 +167,7 @@ func (h *AdminContactHandler) ListSubmissions(c echo.Context) error {
 			SubmissionType: submissionType, Limit: perPage, Offset: offset,
 		})
 		if err != nil {
-			h.logger.Error("Failed to list contact submissions", "error", err)
+			h.logger.ErrorContext(ctx, "Failed to list contact submissions", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to load submissions")
 		}
 		for _, item := range items {
@@ -185,7 +185,7 @@ func (h *AdminContactHandler) ListSubmissions(c echo.Context) error {
 			Limit: perPage, Offset: offset,
 		})
 		if err != nil {
-			h.logger.Error("Failed to list contact submissions", "error", err)
+			h.logger.ErrorContext(ctx, "Failed to list contact submissions", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to load submissions")
 		}
 		for _, item := range items {
@@ -253,7 +253,7 @@ func (h *AdminContactHandler) ViewSubmission(c echo.Context) error {
 	// Fetch the full submission record from database
 	submission, err := h.queries.GetContactSubmissionByID(ctx, id)
 	if err != nil {
-		h.logger.Error("Failed to get contact submission", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to get contact submission", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load submission")
 	}
 
@@ -303,7 +303,7 @@ func (h *AdminContactHandler) UpdateSubmissionStatus(c echo.Context) error {
 		ID:     id,
 	})
 	if err != nil {
-		h.logger.Error("Failed to update submission status", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update submission status", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update status")
 	}
 
@@ -326,7 +326,7 @@ func (h *AdminContactHandler) BulkMarkRead(c echo.Context) error {
 	// Update all submissions with status="new" to status="reviewed"
 	err := h.queries.BulkMarkContactSubmissionsRead(c.Request().Context())
 	if err != nil {
-		h.logger.Error("Failed to bulk mark submissions as read", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to bulk mark submissions as read", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update")
 	}
 
@@ -350,7 +350,7 @@ func (h *AdminContactHandler) DeleteSubmission(c echo.Context) error {
 	// Permanently delete the submission from database
 	err = h.queries.DeleteContactSubmission(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to delete contact submission", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete contact submission", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete submission")
 	}
 
@@ -374,7 +374,7 @@ func (h *AdminContactHandler) ListOffices(c echo.Context) error {
 	// Fetch all office locations from database, ordered by display_order
 	offices, err := h.queries.ListAllOfficeLocations(c.Request().Context())
 	if err != nil {
-		h.logger.Error("Failed to list office locations", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to list office locations", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load offices")
 	}
 
@@ -446,7 +446,7 @@ func (h *AdminContactHandler) CreateOffice(c echo.Context) error {
 	if isPrimary == 1 {
 		err := h.queries.UnsetPrimaryOfficeLocations(c.Request().Context())
 		if err != nil {
-			h.logger.Error("Failed to unset primary office locations", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to unset primary office locations", "error", err)
 		}
 	}
 
@@ -470,7 +470,7 @@ func (h *AdminContactHandler) CreateOffice(c echo.Context) error {
 	// Insert the new office location into database
 	_, err := h.queries.CreateOfficeLocation(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create office location", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create office location", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to create office")
 	}
 
@@ -499,7 +499,7 @@ func (h *AdminContactHandler) EditOffice(c echo.Context) error {
 	// Fetch the existing office location from database
 	office, err := h.queries.GetOfficeLocationByID(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get office location", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get office location", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load office")
 	}
 
@@ -560,7 +560,7 @@ func (h *AdminContactHandler) UpdateOffice(c echo.Context) error {
 	if isPrimary == 1 {
 		err := h.queries.UnsetPrimaryOfficeLocations(c.Request().Context())
 		if err != nil {
-			h.logger.Error("Failed to unset primary office locations", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to unset primary office locations", "error", err)
 		}
 	}
 
@@ -584,7 +584,7 @@ func (h *AdminContactHandler) UpdateOffice(c echo.Context) error {
 	// Update the existing office location in database
 	err = h.queries.UpdateOfficeLocation(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to update office location", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update office location", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update office")
 	}
 
@@ -613,7 +613,7 @@ func (h *AdminContactHandler) DeleteOffice(c echo.Context) error {
 	// Permanently delete the office location from database
 	err = h.queries.DeleteOfficeLocation(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to delete office location", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete office location", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete office")
 	}
 
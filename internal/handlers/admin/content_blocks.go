@@ -0,0 +1,354 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the content block manager: a library of reusable
+// blocks (rich text, calls to action, stats strips, logo walls) and the
+// ordered lists of blocks attached to public pages.
+package admin
+
+import (
+	"database/sql"  // sql.ErrNoRows detection
+	"encoding/json" // Reorder request body
+	"errors"        // Error inspection
+	"log/slog"      // Structured logging
+	"net/http"      // HTTP status codes
+	"net/url"       // Escaping the page path in redirects
+	"strconv"       // Parsing IDs
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Content blocks and page cache
+)
+
+// contentBlockRow is one block on the list page with its type's name.
+type contentBlockRow struct {
+	sqlc.ListContentBlocksRow
+	TypeLabel string // e.g. "Stats strip"
+}
+
+// ContentBlocksHandler handles the content block manager at /admin/blocks.
+type ContentBlocksHandler struct {
+	queries sqlc.Querier            // Database queries generated by sqlc
+	logger  *slog.Logger            // Structured logger for error reporting
+	blocks  *services.ContentBlocks // In-memory page blocks reloaded after each change
+	cache   *services.Cache         // Public page cache, cleared after each change
+}
+
+// NewContentBlocksHandler creates a new ContentBlocksHandler instance.
+func NewContentBlocksHandler(queries sqlc.Querier, logger *slog.Logger, blocks *services.ContentBlocks, cache *services.Cache) *ContentBlocksHandler {
+	return &ContentBlocksHandler{queries: queries, logger: logger, blocks: blocks, cache: cache}
+}
+
+// List handles GET /admin/blocks
+// Lists the block library and the pages that have blocks attached.
+// Template: admin/pages/content_blocks.html (full page)
+func (h *ContentBlocksHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+	blocks, err := h.queries.ListContentBlocks(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list content blocks", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	pages, err := h.queries.ListPageBlockRoutes(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list pages with blocks", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	rows := make([]contentBlockRow, 0, len(blocks))
+	for _, b := range blocks {
+		rows = append(rows, contentBlockRow{ListContentBlocksRow: b, TypeLabel: services.ContentBlockLabel(b.BlockType)})
+	}
+	return c.Render(http.StatusOK, "admin/pages/content_blocks.html", map[string]interface{}{
+		"Title":  "Content Blocks",
+		"Blocks": rows,
+		"Pages":  pages,
+	})
+}
+
+// New handles GET /admin/blocks/new
+// Shows an empty form; ?type= preselects the block type.
+// Template: admin/pages/content_block_form.html (full page)
+func (h *ContentBlocksHandler) New(c echo.Context) error {
+	blockType := c.QueryParam("type")
+	if services.ContentBlockLabel(blockType) == blockType {
+		blockType = services.BlockRichText
+	}
+	return h.renderForm(c, http.StatusOK, sqlc.ContentBlock{BlockType: blockType, IsActive: true}, "")
+}
+
+// Create handles POST /admin/blocks
+// Adds a block to the library. Invalid input is reported on the form.
+func (h *ContentBlocksHandler) Create(c echo.Context) error {
+	params, msg := h.form(c)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, contentBlockFrom(params, 0), msg)
+	}
+	block, err := h.queries.CreateContentBlock(c.Request().Context(), params)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create content block", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "content_block", block.ID, block.Name, "Created content block %q", block.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/blocks")
+}
+
+// Edit handles GET /admin/blocks/:id/edit
+// Template: admin/pages/content_block_form.html (full page)
+func (h *ContentBlocksHandler) Edit(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	block, err := h.queries.GetContentBlock(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load content block", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.renderForm(c, http.StatusOK, block, "")
+}
+
+// Update handles POST /admin/blocks/:id
+// Saves a block; every page it is attached to shows the change.
+func (h *ContentBlocksHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if _, err := h.queries.GetContentBlock(c.Request().Context(), id); errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	params, msg := h.form(c)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, contentBlockFrom(params, id), msg)
+	}
+	err = h.queries.UpdateContentBlock(c.Request().Context(), sqlc.UpdateContentBlockParams{
+		Name:       params.Name,
+		BlockType:  params.BlockType,
+		Heading:    params.Heading,
+		Body:       params.Body,
+		ButtonText: params.ButtonText,
+		ButtonUrl:  params.ButtonUrl,
+		Items:      params.Items,
+		IsActive:   params.IsActive,
+		ID:         id,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update content block", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	logActivity(c, "updated", "content_block", id, params.Name, "Updated content block %q", params.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/blocks")
+}
+
+// Delete handles DELETE /admin/blocks/:id
+// Removes a block from the library and from every page it is attached to.
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *ContentBlocksHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteContentBlock(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete content block", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	logActivity(c, "deleted", "content_block", id, "", "Deleted content block #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// Page handles GET /admin/blocks/pages?route=
+// Shows the blocks attached to the page at route in display order, for
+// reordering by drag and drop, removing and adding blocks.
+// Template: admin/pages/content_block_page.html (full page)
+func (h *ContentBlocksHandler) Page(c echo.Context) error {
+	route, err := services.NormalizeBlockRoute(c.QueryParam("route"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Enter a page path starting with /, e.g. /about.")
+	}
+	return h.renderPage(c, http.StatusOK, route, "")
+}
+
+// Attach handles POST /admin/blocks/pages
+// Adds a block from the library to the end of a page (form fields route
+// and block_id).
+func (h *ContentBlocksHandler) Attach(c echo.Context) error {
+	ctx := c.Request().Context()
+	route, err := services.NormalizeBlockRoute(c.FormValue("route"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Enter a page path starting with /, e.g. /about.")
+	}
+	blockID, _ := strconv.ParseInt(c.FormValue("block_id"), 10, 64)
+	block, err := h.queries.GetContentBlock(ctx, blockID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return h.renderPage(c, http.StatusUnprocessableEntity, route, "Choose a block to add.")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load content block", "error", err, "id", blockID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	attached, err := h.queries.ListPageBlocks(ctx, route)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list page blocks", "error", err, "route", route)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	_, err = h.queries.AttachPageBlock(ctx, sqlc.AttachPageBlockParams{Route: route, BlockID: block.ID, SortOrder: int64(len(attached))})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to attach content block", "error", err, "route", route, "id", block.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	logActivity(c, "updated", "content_block", block.ID, block.Name, "Added content block %q to %s", block.Name, route)
+	return c.Redirect(http.StatusSeeOther, "/admin/blocks/pages?route="+url.QueryEscape(route))
+}
+
+// Detach handles DELETE /admin/blocks/pages/:id
+// Removes a block from a page; the block stays in the library.
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *ContentBlocksHandler) Detach(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	pb, err := h.queries.GetPageBlock(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err == nil {
+		err = h.queries.DetachPageBlock(c.Request().Context(), id)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to detach content block", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	logActivity(c, "updated", "content_block", pb.BlockID, "", "Removed content block #%d from %s", pb.BlockID, pb.Route)
+	return c.NoContent(http.StatusOK)
+}
+
+// Reorder handles POST /admin/blocks/pages/reorder?route=
+// Saves the order of a page's blocks after a drag and drop. The JSON body
+// is an array of {"id": attachment ID, "order": position}; attachments of
+// other pages are left alone.
+func (h *ContentBlocksHandler) Reorder(c echo.Context) error {
+	ctx := c.Request().Context()
+	route, err := services.NormalizeBlockRoute(c.QueryParam("route"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid page path")
+	}
+	var items []struct {
+		ID    int64 `json:"id"`
+		Order int64 `json:"order"`
+	}
+	if err := json.NewDecoder(c.Request().Body).Decode(&items); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid JSON")
+	}
+	for _, item := range items {
+		err := h.queries.UpdatePageBlockOrder(ctx, sqlc.UpdatePageBlockOrderParams{SortOrder: item.Order, ID: item.ID, Route: route})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to reorder page block", "error", err, "id", item.ID)
+		}
+	}
+	h.changed(c)
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// changed reloads the blocks served to visitors and drops cached pages,
+// which were rendered with the old blocks.
+func (h *ContentBlocksHandler) changed(c echo.Context) {
+	if h.blocks != nil {
+		if err := h.blocks.Reload(c.Request().Context()); err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to reload content blocks", "error", err)
+		}
+	}
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
+}
+
+// form reads and validates the block form. Rich text is sanitized before it
+// is validated. It returns a message for the form on invalid input.
+func (h *ContentBlocksHandler) form(c echo.Context) (sqlc.CreateContentBlockParams, string) {
+	params := sqlc.CreateContentBlockParams{
+		Name:       c.FormValue("name"),
+		BlockType:  c.FormValue("block_type"),
+		Heading:    c.FormValue("heading"),
+		Body:       c.FormValue("body"),
+		ButtonText: c.FormValue("button_text"),
+		ButtonUrl:  c.FormValue("button_url"),
+		Items:      c.FormValue("items"),
+		IsActive:   c.FormValue("is_active") == "on",
+	}
+	if params.BlockType == services.BlockRichText {
+		params.Body = sanitizeHTML(c.FormValue("rich_body"))
+	}
+	params, err := services.ValidateContentBlock(params)
+	if err != nil {
+		return params, contentBlockErrorMessages[err]
+	}
+	return params, ""
+}
+
+// contentBlockErrorMessages explains block validation errors on the form.
+var contentBlockErrorMessages = map[error]string{
+	services.ErrBlockName:   "Give the block a name, so you can find it when adding it to pages.",
+	services.ErrBlockType:   "Choose a block type.",
+	services.ErrBlockEmpty:  "The block is empty: fill in its text, or at least one stat or logo line.",
+	services.ErrBlockButton: "A button needs both a label and a link: a path starting with / or a full http(s):// URL.",
+	services.ErrBlockItems:  "Check the lines: stats need \"value | label\", logos \"image | alt text | link\" with an image path or URL.",
+}
+
+// renderForm shows the add or edit form for block (ID 0 for a new one).
+func (h *ContentBlocksHandler) renderForm(c echo.Context, status int, block sqlc.ContentBlock, errMsg string) error {
+	title, action := "New Content Block", "/admin/blocks"
+	if block.ID != 0 {
+		title, action = "Edit Content Block", "/admin/blocks/"+strconv.FormatInt(block.ID, 10)
+	}
+	return c.Render(status, "admin/pages/content_block_form.html", map[string]interface{}{
+		"Title":      title,
+		"Item":       block,
+		"FormAction": action,
+		"BlockTypes": services.ContentBlockTypes,
+		"Error":      errMsg,
+	})
+}
+
+// renderPage shows the blocks attached to the page at route.
+func (h *ContentBlocksHandler) renderPage(c echo.Context, status int, route, errMsg string) error {
+	ctx := c.Request().Context()
+	attached, err := h.queries.ListPageBlocks(ctx, route)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list page blocks", "error", err, "route", route)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	library, err := h.queries.ListContentBlocks(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list content blocks", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(status, "admin/pages/content_block_page.html", map[string]interface{}{
+		"Title":    "Blocks on " + route,
+		"Route":    route,
+		"Attached": attached,
+		"Library":  library,
+		"Error":    errMsg,
+	})
+}
+
+// contentBlockFrom turns submitted form values back into a row for the form.
+func contentBlockFrom(p sqlc.CreateContentBlockParams, id int64) sqlc.ContentBlock {
+	return sqlc.ContentBlock{
+		ID:         id,
+		Name:       p.Name,
+		BlockType:  p.BlockType,
+		Heading:    p.Heading,
+		Body:       p.Body,
+		ButtonText: p.ButtonText,
+		ButtonUrl:  p.ButtonUrl,
+		Items:      p.Items,
+		IsActive:   p.IsActive,
+	}
+}
@@ -0,0 +1,212 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the content bundle export and import under
+// /admin/tools, used to promote content written on staging to production.
+package admin
+
+import (
+	// Standard library imports
+	"errors"   // Bundle format errors
+	"fmt"      // Download file name and summary
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes
+	"strconv"  // Parsing selections and resolutions
+	"strings"  // Parsing "kind:id" selections
+	"time"     // Export timestamp
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Content bundles and cache
+)
+
+// maxContentBundleSize limits uploaded bundles to 200 MB, like media imports.
+const maxContentBundleSize = 200 << 20
+
+// ContentBundlesHandler serves /admin/tools/export and /admin/tools/import.
+type ContentBundlesHandler struct {
+	bundles *services.ContentBundles // Builds, stages and applies bundles
+	logger  *slog.Logger             // Structured logger for error tracking
+	cache   *services.Cache          // Cleared after an import so pages show the new content
+}
+
+// NewContentBundlesHandler creates a new ContentBundlesHandler instance.
+func NewContentBundlesHandler(bundles *services.ContentBundles, logger *slog.Logger, cache *services.Cache) *ContentBundlesHandler {
+	return &ContentBundlesHandler{bundles: bundles, logger: logger, cache: cache}
+}
+
+// Export handles GET /admin/tools/export
+// Lists the content that can be exported, by type, with checkboxes.
+// Template: admin/pages/content_export.html (full page)
+func (h *ContentBundlesHandler) Export(c echo.Context) error {
+	candidates, err := h.bundles.Candidates(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list exportable content", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/content_export.html", map[string]interface{}{
+		"Title":      "Export Content",
+		"Candidates": candidates,
+	})
+}
+
+// ExportDownload handles POST /admin/tools/export
+// Downloads the checked items, the categories, authors and tags they refer
+// to, and the uploaded files they use, as a ZIP bundle.
+//
+// Form fields:
+//   - items: "<kind>:<id>" per checked item
+func (h *ContentBundlesHandler) ExportDownload(c echo.Context) error {
+	form, _ := c.FormParams()
+	var selection []services.BundleSelection
+	for _, v := range form["items"] {
+		kind, rawID, ok := strings.Cut(v, ":")
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if !ok || err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid selection")
+		}
+		selection = append(selection, services.BundleSelection{Kind: kind, ID: id})
+	}
+	if len(selection) == 0 {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, "Select the content to export.")
+		return c.Redirect(http.StatusSeeOther, "/admin/tools/export")
+	}
+
+	now := time.Now()
+	bundle, err := h.bundles.Export(c.Request().Context(), selection, now)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to export content", "error", err)
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, "The selection could not be exported: "+err.Error())
+		return c.Redirect(http.StatusSeeOther, "/admin/tools/export")
+	}
+
+	filename := fmt.Sprintf("content-%s.zip", now.UTC().Format("20060102-150405"))
+	c.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+	logActivity(c, "exported", "content_bundle", 0, filename, "Exported %d items and %d files as %s", len(bundle.Items), len(bundle.Media), filename)
+	if err := h.bundles.WriteBundle(c.Response(), bundle); err != nil {
+		// Headers are sent; the download ends up truncated
+		h.logger.ErrorContext(c.Request().Context(), "failed to write content bundle", "error", err)
+	}
+	return nil
+}
+
+// Import handles GET /admin/tools/import
+// Renders the upload step.
+// Template: admin/pages/content_import.html (full page)
+func (h *ContentBundlesHandler) Import(c echo.Context) error {
+	return h.renderImport(c, map[string]interface{}{})
+}
+
+// ImportPreview handles POST /admin/tools/import
+// Stores the uploaded bundle and renders what importing it would do: which
+// items are new and which slugs are already taken, with a choice per
+// conflict. Nothing is written to the content yet.
+//
+// Form fields:
+//   - file: ZIP file from ExportDownload
+func (h *ContentBundlesHandler) ImportPreview(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return h.renderImport(c, map[string]interface{}{"UploadError": "Choose a content bundle to import."})
+	}
+	if fileHeader.Size > maxContentBundleSize {
+		return h.renderImport(c, map[string]interface{}{"UploadError": "The file is larger than 200 MB."})
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to open content bundle", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer f.Close()
+
+	staged, err := h.bundles.Stage(f)
+	if errors.Is(err, services.ErrBundleFormat) {
+		return h.renderImport(c, map[string]interface{}{"UploadError": fileHeader.Filename + " is not a content bundle exported by this version of the CMS."})
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to stage content bundle", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer staged.Close()
+
+	plan, err := h.bundles.Plan(c.Request().Context(), staged.Bundle)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to plan content import", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.renderImport(c, map[string]interface{}{
+		"Plan":     plan,
+		"Token":    staged.Token,
+		"FileName": fileHeader.Filename,
+	})
+}
+
+// ImportApply handles POST /admin/tools/import/apply
+// Imports the previewed bundle in one transaction, resolving each conflict
+// as chosen, and logs every item written. A conflict without a choice is
+// skipped.
+//
+// Form fields:
+//   - token: Staged bundle from ImportPreview
+//   - file_name: Name of the uploaded file, for the activity log
+//   - resolution_<index>: "skip", "overwrite" or "copy" per conflicting item
+func (h *ContentBundlesHandler) ImportApply(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, fileName := c.FormValue("token"), c.FormValue("file_name")
+	staged, err := h.bundles.Open(token)
+	if errors.Is(err, services.ErrBundleExpired) || errors.Is(err, services.ErrBundleFormat) {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, services.ErrBundleExpired.Error()+".")
+		return c.Redirect(http.StatusSeeOther, "/admin/tools/import")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to open staged content bundle", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer staged.Close()
+
+	form, _ := c.FormParams()
+	resolutions := map[int]string{}
+	for key, values := range form {
+		if i, err := strconv.Atoi(strings.TrimPrefix(key, "resolution_")); err == nil && strings.HasPrefix(key, "resolution_") && len(values) > 0 {
+			resolutions[i] = values[0]
+		}
+	}
+
+	report, err := h.bundles.Apply(ctx, staged, resolutions)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to import content bundle", "error", err)
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, "Nothing was imported: "+err.Error())
+		return c.Redirect(http.StatusSeeOther, "/admin/tools/import")
+	}
+	h.bundles.Discard(token)
+
+	// Imported content can appear on any listing page
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
+	for _, item := range report.Items {
+		switch item.Action {
+		case "created", "copied":
+			logActivity(c, "created", item.Kind, item.ID, item.Title, "Imported %s '%s' from %s", strings.ToLower(item.Label), item.Title, fileName)
+		case "updated":
+			logActivity(c, "updated", item.Kind, item.ID, item.Title, "Overwrote %s '%s' from %s", strings.ToLower(item.Label), item.Title, fileName)
+		}
+	}
+
+	summary := fmt.Sprintf("Imported %s: %d created, %d overwritten, %d copied, %d skipped, %d files added.",
+		fileName, report.Count("created"), report.Count("updated"), report.Count("copied"), report.Count("skipped"), report.MediaWritten)
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, summary)
+	for _, w := range report.Warnings {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, w)
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/tools/import")
+}
+
+// renderImport renders the content import page.
+func (h *ContentBundlesHandler) renderImport(c echo.Context, data map[string]interface{}) error {
+	data["Title"] = "Import Content"
+	return c.Render(http.StatusOK, "admin/pages/content_import.html", data)
+}
@@ -0,0 +1,81 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains handlers for the registered customer accounts view.
+package admin
+
+import (
+	// Standard library imports
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"strconv"  // Account ID parsing
+	"strings"  // Case-insensitive search
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database queries
+)
+
+// CustomersHandler lists the visitors who registered a customer account on
+// the public site, with what they downloaded while signed in, and removes
+// accounts on request.
+type CustomersHandler struct {
+	queries sqlc.Querier // Database query interface for customer accounts
+	logger  *slog.Logger // Structured logger for error tracking
+}
+
+// NewCustomersHandler constructs a new CustomersHandler with required dependencies.
+func NewCustomersHandler(queries sqlc.Querier, logger *slog.Logger) *CustomersHandler {
+	return &CustomersHandler{queries: queries, logger: logger}
+}
+
+// List handles GET /admin/customers
+// Renders every customer account, newest first.
+// Template: admin/pages/customers_list.html (full page)
+//
+// Query parameters:
+//   - q: Filters by name, email, or company (case-insensitive)
+func (h *CustomersHandler) List(c echo.Context) error {
+	accounts, err := h.queries.ListCustomerAccounts(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load customer accounts", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	search := strings.TrimSpace(c.QueryParam("q"))
+	needle := strings.ToLower(search)
+	shown := accounts
+	if needle != "" {
+		shown = nil
+		for _, a := range accounts {
+			if strings.Contains(strings.ToLower(a.Name), needle) ||
+				strings.Contains(strings.ToLower(a.Email), needle) ||
+				strings.Contains(strings.ToLower(a.Company), needle) {
+				shown = append(shown, a)
+			}
+		}
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/customers_list.html", map[string]interface{}{
+		"Title":     "Customers",
+		"Customers": shown,
+		"Total":     len(accounts),
+		"Search":    search,
+	})
+}
+
+// Delete handles DELETE /admin/customers/:id
+// Removes an account with its sessions and library, signing the customer
+// out. Leads the customer submitted stay under Leads.
+func (h *CustomersHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid customer ID")
+	}
+	if err := h.queries.DeleteCustomerAccount(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete customer account", "error", err, "id", id)
+		return c.String(http.StatusInternalServerError, "Failed to delete customer")
+	}
+	logActivity(c, "deleted", "customer_account", id, "", "Deleted Customer Account #%d", id)
+	return c.NoContent(http.StatusOK)
+}
@@ -2,28 +2,33 @@ package admin
 
 import (
 	// Standard library imports
+	"context"  // Card loader signatures
+	"fmt"      // Editor and submission paths
 	"log/slog" // Structured logging for error tracking and debugging
 	"net/http" // HTTP status codes and request/response handling
+	"strings"  // Stored timestamp parsing
+	"time"     // Lead trend days
 
 	// Third-party framework
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context management
 
 	// Internal dependencies
-	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries and models
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries and models
 	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Session management and authentication middleware
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Workflow states and the site timezone
 )
 
 // DashboardHandler handles all dashboard-related HTTP requests.
 // Responsible for aggregating statistics from multiple content sections
-// and rendering the admin dashboard overview page.
+// and rendering the admin dashboard overview page and its cards.
 type DashboardHandler struct {
-	queries *sqlc.Queries // Database query interface for fetching dashboard statistics
-	logger  *slog.Logger  // Structured logger for error and activity logging
+	queries sqlc.Querier // Database query interface for fetching dashboard statistics
+	logger  *slog.Logger // Structured logger for error and activity logging
 }
 
 // NewDashboardHandler creates and initializes a new DashboardHandler instance.
 // Dependencies are injected to support database access and logging.
-func NewDashboardHandler(queries *sqlc.Queries, logger *slog.Logger) *DashboardHandler {
+func NewDashboardHandler(queries sqlc.Querier, logger *slog.Logger) *DashboardHandler {
 	return &DashboardHandler{
 		queries: queries,
 		logger:  logger,
@@ -31,23 +36,104 @@ func NewDashboardHandler(queries *sqlc.Queries, logger *slog.Logger) *DashboardH
 }
 
 // DashboardData contains all data needed to render the admin dashboard page.
-// Includes user session information and aggregated statistics from all content sections.
+// Includes user session information and the metric cards.
 // This struct is passed to the template renderer for display.
 type DashboardData struct {
-	Title                  string // Page title ("Dashboard")
-	ActiveNav              string // Active navigation item identifier ("dashboard")
-	DisplayName            string // Current user's display name from session
-	Email                  string // Current user's email from session
-	Role                   string // Current user's role (admin, editor, etc.) from session
-	PublishedProducts      int64  // Count of published products
-	PublishedBlogPosts     int64  // Count of published blog posts
-	ContactSubmissions     int64  // Total count of contact form submissions
-	NewContactSubmissions  int64  // Count of unread/new contact submissions
-	TotalPartners          int64  // Count of partner organizations
-	DraftProducts          int64  // Count of unpublished/draft products
-	DraftBlogPosts         int64  // Count of unpublished/draft blog posts
+	Title       string                   // Page title ("Dashboard")
+	ActiveNav   string                   // Active navigation item identifier ("dashboard")
+	DisplayName string                   // Current user's display name from session
+	Email       string                   // Current user's email from session
+	Role        string                   // Current user's role (admin, editor, etc.) from session
+	Cards       []DashboardCard          // Metric cards, in display order
+	Flashes     []customMiddleware.Flash // Flash messages for the admin layout
 }
 
+// DashboardCard is one HTMX-refreshable card on the dashboard. Each card
+// reloads itself from GET /admin/dashboard/cards/:card.
+type DashboardCard struct {
+	Name      string      // URL name, e.g. "leads"
+	Title     string      // Card heading
+	Icon      string      // Material Symbols icon name
+	Link      string      // "View all" target, empty for none
+	LinkLabel string      // "View all" text
+	Wide      bool        // Spans two grid columns
+	Error     string      // Shown instead of the data when loading failed
+	Data      interface{} // Card-specific data, see the dashboard* types below
+}
+
+// dashboardCardSpec describes how one card is titled and loaded.
+type dashboardCardSpec struct {
+	name, title, icon, link, linkLabel string
+	wide                               bool
+	load                               func(h *DashboardHandler, ctx context.Context) (interface{}, error)
+}
+
+// dashboardCards lists the cards in display order.
+var dashboardCards = []dashboardCardSpec{
+	{"content", "Content", "inventory_2", "", "", true, (*DashboardHandler).contentCard},
+	{"review", "Awaiting Review", "rate_review", "/admin/review-queue", "Review queue", false, (*DashboardHandler).reviewCard},
+	{"leads", "Recent Leads", "mail", "/admin/contact/submissions", "All submissions", false, (*DashboardHandler).leadsCard},
+	{"lead-trend", "Leads, Last 7 Days", "trending_up", "/admin/leads", "Leads", false, (*DashboardHandler).leadTrendCard},
+	{"whitepapers", "Top Whitepapers", "description", "/admin/whitepapers", "All whitepapers", false, (*DashboardHandler).whitepapersCard},
+	{"activity", "Recent Activity", "history", "/admin/activity", "Activity log", true, (*DashboardHandler).activityCard},
+}
+
+// dashboardContentTypes labels the content types counted by
+// CountContentByType and links them to their admin lists.
+var dashboardContentTypes = map[string]struct{ label, link string }{
+	"product":      {"Products", "/admin/products"},
+	"blog_post":    {"Blog posts", "/admin/blog/posts"},
+	"solution":     {"Solutions", "/admin/solutions"},
+	"case_study":   {"Case studies", "/admin/case-studies"},
+	"whitepaper":   {"Whitepapers", "/admin/whitepapers"},
+	"landing_page": {"Landing pages", "/admin/landing-pages"},
+}
+
+// dashboardContent is one row of the content card.
+type dashboardContent struct {
+	Label     string // e.g. "Blog posts"
+	Link      string // Admin list
+	Published int64  // Published items
+	Drafts    int64  // Draft items
+}
+
+// dashboardReview is the data of the awaiting review card.
+type dashboardReview struct {
+	Items []reviewQueueItem // Oldest first, at most dashboardListLimit
+	Total int               // All items in review
+}
+
+// dashboardLead is one row of the recent leads card.
+type dashboardLead struct {
+	sqlc.ListRecentLeadsRow
+	At  time.Time // Submission time, zero when unreadable
+	URL string    // Where the lead is handled
+}
+
+// dashboardLeads is the data of the recent leads card.
+type dashboardLeads struct {
+	Items []dashboardLead // Newest first
+	New   int64           // Unread contact submissions
+}
+
+// dashboardTrendDay is one bar of the lead trend card.
+type dashboardTrendDay struct {
+	Day     time.Time // Midnight in the site timezone
+	Leads   int       // Submissions that day
+	Percent int       // Bar height relative to the busiest day
+}
+
+// dashboardLeadTrend is the data of the lead trend card.
+type dashboardLeadTrend struct {
+	Days  []dashboardTrendDay // Oldest first, ending today
+	Total int                 // Submissions over all days
+}
+
+const (
+	dashboardListLimit = 8 // Rows in the review, leads and activity cards
+	dashboardTrendDays = 7 // Days in the lead trend card
+)
+
 // ShowDashboard renders the admin dashboard overview page.
 //
 // HTTP Method: GET
@@ -55,10 +141,9 @@ type DashboardData struct {
 // Template: templates/admin/pages/dashboard.html (with admin-layout wrapper)
 // HTMX: Returns full HTML page (not a fragment)
 //
-// Aggregates statistics from multiple database tables (products, blog posts,
-// contact submissions, partners) and displays them in a single dashboard view.
-// Uses graceful degradation: if any count query fails, it logs the error but
-// continues rendering the page with zero values for failed queries.
+// Loads every card in dashboardCards. Uses graceful degradation: a card
+// whose queries fail logs the error and shows it in place of its data, and
+// the rest of the page renders as usual.
 //
 // Authentication: Requires valid session (enforced by middleware)
 // Session data: Retrieves user DisplayName, Email, and Role for display
@@ -74,63 +159,171 @@ func (h *DashboardHandler) ShowDashboard(c echo.Context) error {
 		DisplayName: sess.DisplayName,
 		Email:       sess.Email,
 		Role:        sess.Role,
+		Flashes:     customMiddleware.FlashesFrom(c),
+	}
+	for _, spec := range dashboardCards {
+		data.Cards = append(data.Cards, h.card(ctx, spec))
 	}
 
-	// Fetch all statistics counts from database
-	// Strategy: Log errors but don't fail the entire page if one query fails
-	// This ensures the dashboard remains accessible even with partial data
+	// Render the dashboard template with admin layout wrapper
+	// Template path: templates/admin/pages/dashboard.html
+	// Layout: Uses {{template "admin-layout" .}} for consistent admin UI
+	return c.Render(http.StatusOK, "admin/pages/dashboard.html", data)
+}
+
+// Card handles GET /admin/dashboard/cards/:card
+// Reloads one dashboard card; the cards poll it every minute and on their
+// refresh button.
+//
+// Template: admin/partials/dashboard_card.html (the card, swapped as outerHTML)
+// Unknown cards are not found.
+func (h *DashboardHandler) Card(c echo.Context) error {
+	for _, spec := range dashboardCards {
+		if spec.name == c.Param("card") {
+			return c.Render(http.StatusOK, "admin/partials/dashboard_card.html", h.card(c.Request().Context(), spec))
+		}
+	}
+	return echo.NewHTTPError(http.StatusNotFound, "Card not found")
+}
 
-	// Count published products (status = 'published')
-	if count, err := h.queries.CountProducts(ctx); err == nil {
-		data.PublishedProducts = count
-	} else {
-		h.logger.Error("dashboard: count products", "error", err)
+// card loads the card described by spec.
+func (h *DashboardHandler) card(ctx context.Context, spec dashboardCardSpec) DashboardCard {
+	card := DashboardCard{
+		Name: spec.name, Title: spec.title, Icon: spec.icon,
+		Link: spec.link, LinkLabel: spec.linkLabel, Wide: spec.wide,
+	}
+	data, err := spec.load(h, ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "dashboard: load card", "card", spec.name, "error", err)
+		card.Error = "This card could not be loaded."
+		return card
 	}
+	card.Data = data
+	return card
+}
 
-	// Count published blog posts (status = 'published')
-	if count, err := h.queries.CountPublishedPosts(ctx); err == nil {
-		data.PublishedBlogPosts = count
-	} else {
-		h.logger.Error("dashboard: count blog posts", "error", err)
+// contentCard returns published and draft counts per content type.
+func (h *DashboardHandler) contentCard(ctx context.Context) (interface{}, error) {
+	rows, err := h.queries.CountContentByType(ctx)
+	if err != nil {
+		return nil, err
 	}
+	content := make([]dashboardContent, 0, len(rows))
+	for _, r := range rows {
+		t := dashboardContentTypes[r.ContentType]
+		content = append(content, dashboardContent{Label: t.label, Link: t.link, Published: r.Published, Drafts: r.Drafts})
+	}
+	return content, nil
+}
 
-	// Count all contact form submissions (total submissions ever received)
-	if count, err := h.queries.CountContactSubmissions(ctx); err == nil {
-		data.ContactSubmissions = count
-	} else {
-		h.logger.Error("dashboard: count contact submissions", "error", err)
+// reviewCard returns the content submitted for review, oldest first.
+// Approved items waiting to be published stay on the review queue page.
+func (h *DashboardHandler) reviewCard(ctx context.Context) (interface{}, error) {
+	rows, err := h.queries.ListReviewQueue(ctx, 0)
+	if err != nil {
+		return nil, err
 	}
+	types := workflowTypes(h.queries)
+	var review dashboardReview
+	for _, r := range rows {
+		if r.State != services.WorkflowInReview {
+			continue
+		}
+		review.Total++
+		if len(review.Items) < dashboardListLimit {
+			review.Items = append(review.Items, reviewQueueItem{
+				ListReviewQueueRow: r,
+				TypeLabel:          types[r.ContentType].label,
+				StateLabel:         services.WorkflowStateLabel(r.State),
+				EditURL:            fmt.Sprintf(types[r.ContentType].editPath, r.ContentID),
+			})
+		}
+	}
+	return review, nil
+}
 
-	// Count new/unread contact submissions (status = 'new')
-	if count, err := h.queries.CountNewContactSubmissions(ctx); err == nil {
-		data.NewContactSubmissions = count
-	} else {
-		h.logger.Error("dashboard: count new contact submissions", "error", err)
+// leadsCard returns the newest contact submissions and whitepaper downloads.
+func (h *DashboardHandler) leadsCard(ctx context.Context) (interface{}, error) {
+	rows, err := h.queries.ListRecentLeads(ctx, dashboardListLimit)
+	if err != nil {
+		return nil, err
 	}
+	var leads dashboardLeads
+	if leads.New, err = h.queries.CountNewContactSubmissions(ctx); err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		lead := dashboardLead{ListRecentLeadsRow: r, At: parseStoredTime(r.CreatedAt), URL: "/admin/leads"}
+		if r.Source == "contact" {
+			lead.URL = fmt.Sprintf("/admin/contact/submissions/%d", r.ID)
+		}
+		leads.Items = append(leads.Items, lead)
+	}
+	return leads, nil
+}
 
-	// Count total partner organizations
-	if count, err := h.queries.CountPartners(ctx); err == nil {
-		data.TotalPartners = count
-	} else {
-		h.logger.Error("dashboard: count partners", "error", err)
+// leadTrendCard returns the leads per day over the last dashboardTrendDays
+// days, counted in the site timezone.
+func (h *DashboardHandler) leadTrendCard(ctx context.Context) (interface{}, error) {
+	now := services.InSiteTimezone(time.Now())
+	first := time.Date(now.Year(), now.Month(), now.Day()-(dashboardTrendDays-1), 0, 0, 0, 0, now.Location())
+	times, err := h.queries.ListLeadTimesSince(ctx, first.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
 	}
 
-	// Count draft products (status = 'draft')
-	if count, err := h.queries.CountDraftProducts(ctx); err == nil {
-		data.DraftProducts = count
-	} else {
-		h.logger.Error("dashboard: count draft products", "error", err)
+	trend := dashboardLeadTrend{Days: make([]dashboardTrendDay, dashboardTrendDays)}
+	for i := range trend.Days {
+		trend.Days[i].Day = first.AddDate(0, 0, i)
+	}
+	for _, s := range times {
+		at := parseStoredTime(s)
+		if at.IsZero() {
+			continue
+		}
+		at = services.InSiteTimezone(at)
+		day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+		for i := range trend.Days {
+			if trend.Days[i].Day.Equal(day) {
+				trend.Days[i].Leads++
+				trend.Total++
+			}
+		}
 	}
 
-	// Count draft blog posts (status = 'draft')
-	if count, err := h.queries.CountDraftBlogPosts(ctx); err == nil {
-		data.DraftBlogPosts = count
-	} else {
-		h.logger.Error("dashboard: count draft blog posts", "error", err)
+	most := 0
+	for _, d := range trend.Days {
+		most = max(most, d.Leads)
+	}
+	if most > 0 {
+		for i := range trend.Days {
+			trend.Days[i].Percent = trend.Days[i].Leads * 100 / most
+		}
 	}
+	return trend, nil
+}
 
-	// Render the dashboard template with admin layout wrapper
-	// Template path: templates/admin/pages/dashboard.html
-	// Layout: Uses {{template "admin-layout" .}} for consistent admin UI
-	return c.Render(http.StatusOK, "admin/pages/dashboard.html", data)
+// whitepapersCard returns the five most downloaded whitepapers.
+func (h *DashboardHandler) whitepapersCard(ctx context.Context) (interface{}, error) {
+	return h.queries.ListTopDownloadedWhitepapers(ctx, 5)
+}
+
+// activityCard returns the newest activity log entries.
+func (h *DashboardHandler) activityCard(ctx context.Context) (interface{}, error) {
+	return h.queries.ListActivityLogs(ctx, sqlc.ListActivityLogsParams{PageLimit: dashboardListLimit})
+}
+
+// parseStoredTime parses a UTC timestamp read back as text, in
+// CURRENT_TIMESTAMP format or as written by the SQLite driver for a
+// time.Time. It returns the zero time for anything else.
+func parseStoredTime(s string) time.Time {
+	s = strings.Replace(s, "T", " ", 1)
+	if len(s) < len("2006-01-02 15:04:05") {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02 15:04:05", s[:len("2006-01-02 15:04:05")])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
 }
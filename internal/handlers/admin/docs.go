@@ -0,0 +1,591 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the documentation under /docs: doc sets, their
+// versions and the Markdown pages of each version.
+package admin
+
+import (
+	"context"      // Loading page trees
+	"database/sql" // sql.ErrNoRows detection and nullable references
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing IDs and sort orders
+	"strings"      // Trimming form values
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Docs service, page trees, Markdown and page cache
+)
+
+// DocsHandler handles doc sets at /admin/docs, their versions and pages.
+type DocsHandler struct {
+	queries sqlc.Querier    // Database queries generated by sqlc
+	docs    *services.Docs  // Adds versions and picks page slugs
+	logger  *slog.Logger    // Structured logger for error reporting
+	cache   *services.Cache // Public page cache, cleared after each change
+}
+
+// NewDocsHandler creates a new DocsHandler instance.
+func NewDocsHandler(queries sqlc.Querier, docs *services.Docs, logger *slog.Logger, cache *services.Cache) *DocsHandler {
+	return &DocsHandler{queries: queries, docs: docs, logger: logger, cache: cache}
+}
+
+// List handles GET /admin/docs
+// Lists every doc set with its product and current version.
+// Template: admin/pages/docs_list.html (full page)
+func (h *DocsHandler) List(c echo.Context) error {
+	sets, err := h.queries.ListDocSetsAdmin(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list doc sets", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/docs_list.html", map[string]interface{}{
+		"Title": "Documentation",
+		"Sets":  sets,
+	})
+}
+
+// New handles GET /admin/docs/new
+// Template: admin/pages/docs_form.html (full page)
+func (h *DocsHandler) New(c echo.Context) error {
+	return h.renderSetForm(c, http.StatusOK, sqlc.DocSet{}, "", "")
+}
+
+// Create handles POST /admin/docs
+// Adds a doc set with its first version and opens it for editing. Invalid
+// input is reported on the form.
+//
+// Form Fields: name, slug, product_id, description, sort_order,
+// is_published, version (first version label)
+func (h *DocsHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	set, msg := h.setForm(c, 0)
+	label := strings.TrimSpace(c.FormValue("version"))
+	if msg == "" && !services.ValidDocVersion(label) {
+		msg = "Enter the first version, such as 1.0, using letters, digits, dots and hyphens."
+	}
+	if msg != "" {
+		return h.renderSetForm(c, http.StatusUnprocessableEntity, set, label, msg)
+	}
+	created, err := h.queries.CreateDocSet(ctx, sqlc.CreateDocSetParams{
+		Name:        set.Name,
+		Slug:        set.Slug,
+		ProductID:   set.ProductID,
+		Description: set.Description,
+		IsPublished: set.IsPublished,
+		SortOrder:   set.SortOrder,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create doc set", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if _, err := h.docs.AddVersion(ctx, created, label, 0); err != nil {
+		h.logger.ErrorContext(ctx, "failed to add first doc version", "error", err, "id", created.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "created", "doc_set", created.ID, created.Name, "Created doc set %q", created.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/docs/"+strconv.FormatInt(created.ID, 10)+"/edit")
+}
+
+// Edit handles GET /admin/docs/:id/edit
+// Shows the doc set form with its versions.
+// Template: admin/pages/docs_form.html (full page)
+func (h *DocsHandler) Edit(c echo.Context) error {
+	set, err := h.set(c)
+	if err != nil {
+		return err
+	}
+	return h.renderSetForm(c, http.StatusOK, set, "", "")
+}
+
+// Update handles POST /admin/docs/:id
+// Saves the doc set form. A changed slug redirects the old /docs/<slug>.
+func (h *DocsHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, err := h.set(c)
+	if err != nil {
+		return err
+	}
+	set, msg := h.setForm(c, existing.ID)
+	set.ID = existing.ID
+	set.CurrentVersionID = existing.CurrentVersionID
+	if msg != "" {
+		return h.renderSetForm(c, http.StatusUnprocessableEntity, set, "", msg)
+	}
+	err = h.queries.UpdateDocSet(ctx, sqlc.UpdateDocSetParams{
+		Name:        set.Name,
+		Slug:        set.Slug,
+		ProductID:   set.ProductID,
+		Description: set.Description,
+		IsPublished: set.IsPublished,
+		SortOrder:   set.SortOrder,
+		ID:          existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update doc set", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, services.DocSetPath(existing.Slug), services.DocSetPath(set.Slug))
+	h.changed(existing.ProductID, set.ProductID)
+	logActivity(c, "updated", "doc_set", existing.ID, set.Name, "Updated doc set %q", set.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/docs")
+}
+
+// Delete handles DELETE /admin/docs/:id
+// Removes a doc set with all of its versions and pages. HTMX: returns an
+// empty 200 response and the row is removed.
+func (h *DocsHandler) Delete(c echo.Context) error {
+	set, err := h.set(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeleteDocSet(c.Request().Context(), set.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete doc set", "error", err, "id", set.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "deleted", "doc_set", set.ID, set.Name, "Deleted doc set %q", set.Name)
+	return c.NoContent(http.StatusOK)
+}
+
+// AddVersion handles POST /admin/docs/:id/versions
+// Adds a version to the doc set, starting as a copy of the pages of
+// another version or empty, and returns to the set. An invalid or taken
+// label shows the set form again with the error.
+//
+// Form Fields: version, copy_from (version ID, empty to start empty)
+func (h *DocsHandler) AddVersion(c echo.Context) error {
+	set, err := h.set(c)
+	if err != nil {
+		return err
+	}
+	label := strings.TrimSpace(c.FormValue("version"))
+	copyFrom, _ := strconv.ParseInt(c.FormValue("copy_from"), 10, 64)
+	back := "/admin/docs/" + strconv.FormatInt(set.ID, 10) + "/edit"
+
+	version, err := h.docs.AddVersion(c.Request().Context(), set, label, copyFrom)
+	switch {
+	case errors.Is(err, services.ErrDocVersionLabel):
+		return h.renderSetForm(c, http.StatusUnprocessableEntity, set, "", "Version labels are letters, digits, dots, hyphens and underscores, such as 2.1.")
+	case err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed"):
+		return h.renderSetForm(c, http.StatusUnprocessableEntity, set, "", "This doc set already has a version "+label+".")
+	case err != nil:
+		h.logger.ErrorContext(c.Request().Context(), "failed to add doc version", "error", err, "id", set.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "created", "doc_version", version.ID, set.Name+" "+label, "Added version %s to doc set %q", label, set.Name)
+	return c.Redirect(http.StatusSeeOther, back)
+}
+
+// MakeCurrent handles POST /admin/docs/versions/:id/current
+// Makes a version the one /docs/<set> opens and the sitemap and site
+// search list.
+func (h *DocsHandler) MakeCurrent(c echo.Context) error {
+	version, set, err := h.version(c)
+	if err != nil {
+		return err
+	}
+	err = h.queries.SetDocSetCurrentVersion(c.Request().Context(), sqlc.SetDocSetCurrentVersionParams{
+		CurrentVersionID: sql.NullInt64{Int64: version.ID, Valid: true},
+		ID:               set.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to set current doc version", "error", err, "id", version.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "updated", "doc_set", set.ID, set.Name, "Made version %s current for doc set %q", version.Version, set.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/docs/"+strconv.FormatInt(set.ID, 10)+"/edit")
+}
+
+// DeleteVersion handles DELETE /admin/docs/versions/:id
+// Removes a version with its pages. The current version cannot be deleted;
+// make another version current first. HTMX: returns an empty 200 response
+// and the row is removed.
+func (h *DocsHandler) DeleteVersion(c echo.Context) error {
+	version, set, err := h.version(c)
+	if err != nil {
+		return err
+	}
+	if set.CurrentVersionID.Int64 == version.ID {
+		return c.String(http.StatusConflict, "Cannot delete the current version. Make another version current first.")
+	}
+	if err := h.queries.DeleteDocVersion(c.Request().Context(), version.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete doc version", "error", err, "id", version.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "deleted", "doc_version", version.ID, set.Name+" "+version.Version, "Deleted version %s of doc set %q", version.Version, set.Name)
+	return c.NoContent(http.StatusOK)
+}
+
+// Pages handles GET /admin/docs/versions/:id
+// Lists the pages of a version as a tree.
+// Template: admin/pages/doc_pages_list.html (full page)
+func (h *DocsHandler) Pages(c echo.Context) error {
+	version, set, err := h.version(c)
+	if err != nil {
+		return err
+	}
+	tree, err := h.tree(c.Request().Context(), set, version)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list doc pages", "error", err, "id", version.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/doc_pages_list.html", map[string]interface{}{
+		"Title":     set.Name + " " + version.Version,
+		"Set":       set,
+		"Version":   version,
+		"IsCurrent": set.CurrentVersionID.Int64 == version.ID,
+		"Tree":      tree,
+		"MaxDepth":  services.MaxDocDepth,
+	})
+}
+
+// NewPage handles GET /admin/docs/versions/:id/pages/new
+// Template: admin/pages/doc_page_form.html (full page)
+//
+// Query parameters:
+//   - parent: Page to add the new page under (default: top level)
+func (h *DocsHandler) NewPage(c echo.Context) error {
+	version, set, err := h.version(c)
+	if err != nil {
+		return err
+	}
+	page := sqlc.DocPage{DocVersionID: version.ID, IsPublished: 1}
+	if parent, _ := strconv.ParseInt(c.QueryParam("parent"), 10, 64); parent != 0 {
+		page.ParentID = sql.NullInt64{Int64: parent, Valid: true}
+	}
+	return h.renderPageForm(c, http.StatusOK, set, version, page, "")
+}
+
+// CreatePage handles POST /admin/docs/versions/:id/pages
+// Adds a page to the version and returns to its page tree. Invalid input
+// is reported on the form.
+func (h *DocsHandler) CreatePage(c echo.Context) error {
+	ctx := c.Request().Context()
+	version, set, err := h.version(c)
+	if err != nil {
+		return err
+	}
+	page, msg := h.pageForm(c, set, version, 0)
+	if msg != "" {
+		return h.renderPageForm(c, http.StatusUnprocessableEntity, set, version, page, msg)
+	}
+	created, err := h.queries.CreateDocPage(ctx, sqlc.CreateDocPageParams{
+		DocVersionID:    version.ID,
+		ParentID:        page.ParentID,
+		Title:           page.Title,
+		Slug:            page.Slug,
+		BodyMarkdown:    page.BodyMarkdown,
+		BodyHtml:        page.BodyHtml,
+		MetaDescription: page.MetaDescription,
+		SortOrder:       page.SortOrder,
+		IsPublished:     page.IsPublished,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create doc page", "error", err, "version", version.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "created", "doc_page", created.ID, created.Title, "Created doc page %q in %s %s", created.Title, set.Name, version.Version)
+	return c.Redirect(http.StatusSeeOther, "/admin/docs/versions/"+strconv.FormatInt(version.ID, 10))
+}
+
+// EditPage handles GET /admin/docs/pages/:id/edit
+// Template: admin/pages/doc_page_form.html (full page)
+func (h *DocsHandler) EditPage(c echo.Context) error {
+	page, version, set, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	return h.renderPageForm(c, http.StatusOK, set, version, page, "")
+}
+
+// UpdatePage handles POST /admin/docs/pages/:id
+// Saves the page form. A changed slug redirects the old page.
+func (h *DocsHandler) UpdatePage(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, version, set, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	page, msg := h.pageForm(c, set, version, existing.ID)
+	page.ID = existing.ID
+	if msg != "" {
+		return h.renderPageForm(c, http.StatusUnprocessableEntity, set, version, page, msg)
+	}
+	err = h.queries.UpdateDocPage(ctx, sqlc.UpdateDocPageParams{
+		ParentID:        page.ParentID,
+		Title:           page.Title,
+		Slug:            page.Slug,
+		BodyMarkdown:    page.BodyMarkdown,
+		BodyHtml:        page.BodyHtml,
+		MetaDescription: page.MetaDescription,
+		SortOrder:       page.SortOrder,
+		IsPublished:     page.IsPublished,
+		ID:              existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update doc page", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger,
+		services.DocPagePath(set.Slug, version.Version, existing.Slug),
+		services.DocPagePath(set.Slug, version.Version, page.Slug))
+	h.changed(set.ProductID)
+	logActivity(c, "updated", "doc_page", existing.ID, page.Title, "Updated doc page %q in %s %s", page.Title, set.Name, version.Version)
+	return c.Redirect(http.StatusSeeOther, "/admin/docs/versions/"+strconv.FormatInt(version.ID, 10))
+}
+
+// DeletePage handles DELETE /admin/docs/pages/:id
+// Removes a page without subpages. HTMX: returns an empty 200 response and
+// the row is removed.
+func (h *DocsHandler) DeletePage(c echo.Context) error {
+	page, _, set, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	children, err := h.queries.CountChildDocPages(c.Request().Context(), sql.NullInt64{Int64: page.ID, Valid: true})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to count doc subpages", "error", err, "id", page.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if children > 0 {
+		return c.String(http.StatusConflict, "Cannot delete this page because it has subpages. Please move or delete its subpages first.")
+	}
+	if err := h.queries.DeleteDocPage(c.Request().Context(), page.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete doc page", "error", err, "id", page.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(set.ProductID)
+	logActivity(c, "deleted", "doc_page", page.ID, page.Title, "Deleted doc page %q", page.Title)
+	return c.NoContent(http.StatusOK)
+}
+
+// set loads the doc set named by the :id parameter, returning an HTTP
+// error for a bad or unknown ID.
+func (h *DocsHandler) set(c echo.Context) (sqlc.DocSet, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.DocSet{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	return h.loadSet(c, id)
+}
+
+// loadSet loads doc set id, returning an HTTP error when it is unknown.
+func (h *DocsHandler) loadSet(c echo.Context, id int64) (sqlc.DocSet, error) {
+	set, err := h.queries.GetDocSet(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return set, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load doc set", "error", err, "id", id)
+		return set, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return set, nil
+}
+
+// version loads the version named by the :id parameter with its doc set.
+func (h *DocsHandler) version(c echo.Context) (sqlc.DocVersion, sqlc.DocSet, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.DocVersion{}, sqlc.DocSet{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	return h.loadVersion(c, id)
+}
+
+// loadVersion loads version id with its doc set, returning an HTTP error
+// when it is unknown.
+func (h *DocsHandler) loadVersion(c echo.Context, id int64) (sqlc.DocVersion, sqlc.DocSet, error) {
+	version, err := h.queries.GetDocVersion(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return version, sqlc.DocSet{}, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load doc version", "error", err, "id", id)
+		return version, sqlc.DocSet{}, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	set, err := h.loadSet(c, version.DocSetID)
+	return version, set, err
+}
+
+// page loads the page named by the :id parameter with its version and
+// doc set.
+func (h *DocsHandler) page(c echo.Context) (sqlc.DocPage, sqlc.DocVersion, sqlc.DocSet, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.DocPage{}, sqlc.DocVersion{}, sqlc.DocSet{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	page, err := h.queries.GetDocPage(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return page, sqlc.DocVersion{}, sqlc.DocSet{}, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load doc page", "error", err, "id", id)
+		return page, sqlc.DocVersion{}, sqlc.DocSet{}, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	version, set, err := h.loadVersion(c, page.DocVersionID)
+	return page, version, set, err
+}
+
+// tree loads every page of a version, published or not, as a tree.
+func (h *DocsHandler) tree(ctx context.Context, set sqlc.DocSet, version sqlc.DocVersion) (*services.DocTree, error) {
+	pages, err := h.queries.ListDocPages(ctx, version.ID)
+	if err != nil {
+		return nil, err
+	}
+	return services.BuildDocTree(pages, services.DocVersionPath(set.Slug, version.Version), false), nil
+}
+
+// changed drops the cached /docs pages, and the product pages that link to
+// the documentation of productIDs.
+func (h *DocsHandler) changed(productIDs ...sql.NullInt64) {
+	if h.cache == nil {
+		return
+	}
+	h.cache.DeleteByPrefix("page:docs")
+	for _, id := range productIDs {
+		if id.Valid {
+			h.cache.DeleteTagged(services.ProductTag(id.Int64))
+		}
+	}
+}
+
+// setForm reads and validates the doc set form for set id (0 for a new
+// one). It returns a message for the form on invalid input.
+func (h *DocsHandler) setForm(c echo.Context, id int64) (sqlc.DocSet, string) {
+	set := sqlc.DocSet{
+		Name:        strings.TrimSpace(c.FormValue("name")),
+		Slug:        strings.TrimSpace(c.FormValue("slug")),
+		Description: strings.TrimSpace(c.FormValue("description")),
+		IsPublished: checkboxValue(c.FormValue("is_published")),
+	}
+	set.SortOrder, _ = strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
+	if productID, _ := strconv.ParseInt(c.FormValue("product_id"), 10, 64); productID != 0 {
+		set.ProductID = sql.NullInt64{Int64: productID, Valid: true}
+	}
+	if set.Name == "" {
+		return set, "Give the documentation a name."
+	}
+	slug, err := uniqueSlug(c, h.queries, services.SlugDocSets, set.Slug, set.Name, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate doc set slug", "error", err)
+		return set, "Could not find a free address for this documentation; enter another slug."
+	}
+	set.Slug = slug
+	return set, ""
+}
+
+// pageForm reads and validates the page form for page id (0 for a new one)
+// of version, and renders its Markdown. It returns a message for the form
+// on invalid input.
+func (h *DocsHandler) pageForm(c echo.Context, set sqlc.DocSet, version sqlc.DocVersion, id int64) (sqlc.DocPage, string) {
+	ctx := c.Request().Context()
+	page := sqlc.DocPage{
+		DocVersionID:    version.ID,
+		Title:           strings.TrimSpace(c.FormValue("title")),
+		Slug:            strings.TrimSpace(c.FormValue("slug")),
+		BodyMarkdown:    c.FormValue("body_markdown"),
+		MetaDescription: strings.TrimSpace(c.FormValue("meta_description")),
+		IsPublished:     checkboxValue(c.FormValue("is_published")),
+	}
+	page.SortOrder, _ = strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
+	parentID, _ := strconv.ParseInt(c.FormValue("parent_id"), 10, 64)
+	if parentID != 0 {
+		page.ParentID = sql.NullInt64{Int64: parentID, Valid: true}
+	}
+	if page.Title == "" {
+		return page, "Give the page a title."
+	}
+
+	tree, err := h.tree(ctx, set, version)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list doc pages", "error", err, "version", version.ID)
+		return page, "Could not load the pages of this version; try again."
+	}
+	switch err := tree.ValidateParent(id, parentID); {
+	case errors.Is(err, services.ErrDocPageCycle):
+		return page, "A page cannot be placed under itself or one of its own subpages."
+	case errors.Is(err, services.ErrDocPageTooDeep):
+		return page, "Pages can be nested at most 4 levels deep. Choose a higher parent or move the subpages first."
+	case err != nil:
+		return page, "The selected parent page no longer exists."
+	}
+
+	html, err := services.RenderMarkdown(page.BodyMarkdown)
+	if err != nil {
+		return page, "The Markdown could not be rendered."
+	}
+	page.BodyHtml = html
+	text := page.Slug
+	if text == "" {
+		text = page.Title
+	}
+	slug, err := h.docs.UniquePageSlug(ctx, version.ID, text, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate doc page slug", "error", err)
+		return page, "Could not find a free address for this page; enter another slug."
+	}
+	page.Slug = slug
+	return page, ""
+}
+
+// renderSetForm shows the add or edit form for set (ID 0 for a new one);
+// existing sets list their versions below it.
+func (h *DocsHandler) renderSetForm(c echo.Context, status int, set sqlc.DocSet, firstVersion, errMsg string) error {
+	ctx := c.Request().Context()
+	products, err := h.queries.ListAllProductsAdmin(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list products", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	title, action := "New Documentation", "/admin/docs"
+	var versions []sqlc.ListDocVersionsRow
+	if set.ID != 0 {
+		title, action = "Edit Documentation", "/admin/docs/"+strconv.FormatInt(set.ID, 10)
+		if versions, err = h.queries.ListDocVersions(ctx, set.ID); err != nil {
+			h.logger.ErrorContext(ctx, "failed to list doc versions", "error", err, "id", set.ID)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+	if firstVersion == "" {
+		firstVersion = "1.0"
+	}
+	return c.Render(status, "admin/pages/docs_form.html", map[string]interface{}{
+		"Title":        title,
+		"Item":         set,
+		"FormAction":   action,
+		"Products":     products,
+		"Versions":     versions,
+		"FirstVersion": firstVersion,
+		"Error":        errMsg,
+	})
+}
+
+// renderPageForm shows the add or edit form for page (ID 0 for a new one)
+// of version.
+func (h *DocsHandler) renderPageForm(c echo.Context, status int, set sqlc.DocSet, version sqlc.DocVersion, page sqlc.DocPage, errMsg string) error {
+	tree, err := h.tree(c.Request().Context(), set, version)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list doc pages", "error", err, "version", version.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	title, action := "New Page", "/admin/docs/versions/"+strconv.FormatInt(version.ID, 10)+"/pages"
+	if page.ID != 0 {
+		title, action = "Edit Page", "/admin/docs/pages/"+strconv.FormatInt(page.ID, 10)
+	}
+	return c.Render(status, "admin/pages/doc_page_form.html", map[string]interface{}{
+		"Title":         title,
+		"Item":          page,
+		"Set":           set,
+		"Version":       version,
+		"FormAction":    action,
+		"ParentOptions": tree.Options(page.ID),
+		"PublicURL":     services.DocPagePath(set.Slug, version.Version, page.Slug),
+		"Error":         errMsg,
+	})
+}
@@ -0,0 +1,80 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the "Save as copy" actions of the product, blog post,
+// solution and case study editors.
+package admin
+
+import (
+	// Standard library imports
+	"database/sql" // Missing or trashed originals
+	"errors"       // sql.ErrNoRows detection
+	"fmt"          // Editor paths of the copies
+	"log/slog"     // Structured logging for error tracking
+	"net/http"     // HTTP status codes and redirects
+	"strconv"      // Item ID parsing from the URL
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Content duplicator
+)
+
+// duplicateEditPaths are the editors a copy opens in, keyed by content type.
+var duplicateEditPaths = map[string]string{
+	services.DuplicateProduct:   "/admin/products/%d/edit",
+	services.DuplicateBlogPost:  "/admin/blog/posts/%d/edit",
+	services.DuplicateSolution:  "/admin/solutions/%d/edit",
+	services.DuplicateCaseStudy: "/admin/case-studies/%d/edit",
+}
+
+// DuplicateHandler copies products, blog posts, solutions and case studies,
+// with all of their sub-resources, as drafts.
+type DuplicateHandler struct {
+	duplicator *services.ContentDuplicator // Copies items in one transaction
+	logger     *slog.Logger                // Structured logger for error tracking
+}
+
+// NewDuplicateHandler constructs a new DuplicateHandler with required dependencies.
+func NewDuplicateHandler(duplicator *services.ContentDuplicator, logger *slog.Logger) *DuplicateHandler {
+	return &DuplicateHandler{duplicator: duplicator, logger: logger}
+}
+
+// Product handles POST /admin/products/:id/duplicate
+func (h *DuplicateHandler) Product(c echo.Context) error {
+	return h.duplicate(c, services.DuplicateProduct)
+}
+
+// BlogPost handles POST /admin/blog/posts/:id/duplicate
+func (h *DuplicateHandler) BlogPost(c echo.Context) error {
+	return h.duplicate(c, services.DuplicateBlogPost)
+}
+
+// Solution handles POST /admin/solutions/:id/duplicate
+func (h *DuplicateHandler) Solution(c echo.Context) error {
+	return h.duplicate(c, services.DuplicateSolution)
+}
+
+// CaseStudy handles POST /admin/case-studies/:id/duplicate
+func (h *DuplicateHandler) CaseStudy(c echo.Context) error {
+	return h.duplicate(c, services.DuplicateCaseStudy)
+}
+
+// duplicate copies the item in :id and opens the copy's editor. The copy is
+// made from the saved item; unsaved changes on the page are not included.
+func (h *DuplicateHandler) duplicate(c echo.Context, kind string) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	newID, title, err := h.duplicator.Duplicate(c.Request().Context(), kind, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to duplicate content", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create the copy")
+	}
+
+	logActivity(c, "created", kind, newID, title, "Created %s '%s' as a copy of #%d", kind, title, id)
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf(duplicateEditPaths[kind], newID))
+}
@@ -0,0 +1,93 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the soft edit locks: the heartbeat an open edit form
+// sends to claim its item, and the notice shown when someone else has the
+// same item open.
+package admin
+
+import (
+	// Standard library imports
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"strconv"  // ID parsing
+	"time"     // Heartbeat time
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Edit lock store
+)
+
+// editLockLabels are the content types whose edit forms take a lock, with
+// the name used in the notice ("is currently editing this case study").
+var editLockLabels = map[string]string{
+	"product":      "product",
+	"blog_post":    "blog post",
+	"case_study":   "case study",
+	"solution":     "solution",
+	"whitepaper":   "whitepaper",
+	"landing_page": "landing page",
+}
+
+// EditLocksHandler serves the edit lock heartbeat of the edit forms.
+type EditLocksHandler struct {
+	locks  *services.EditLocks // Lock store
+	logger *slog.Logger        // Structured logger for error tracking
+}
+
+// NewEditLocksHandler constructs a new EditLocksHandler with required dependencies.
+func NewEditLocksHandler(locks *services.EditLocks, logger *slog.Logger) *EditLocksHandler {
+	return &EditLocksHandler{locks: locks, logger: logger}
+}
+
+// target resolves :type and :id to a lockable item.
+func (h *EditLocksHandler) target(c echo.Context) (string, int64, error) {
+	kind := c.Param("type")
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if _, ok := editLockLabels[kind]; !ok || err != nil || id <= 0 {
+		return "", 0, echo.NewHTTPError(http.StatusNotFound)
+	}
+	return kind, id, nil
+}
+
+// Heartbeat handles POST /admin/locks/:type/:id
+// Claims or renews the current user's lock on the item. The edit form posts
+// here when it loads and every 30 seconds while it stays open; when another
+// admin holds the lock, the response tells who and since when. Once their
+// lock is released or expires, the next heartbeat claims it and the notice
+// goes away.
+// Template: admin/partials/edit_lock.html (HTMX fragment, empty while the
+// user holds the lock)
+func (h *EditLocksHandler) Heartbeat(c echo.Context) error {
+	kind, id, err := h.target(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	lock, held, err := h.locks.Acquire(ctx, kind, id, getUserID(c), time.Now())
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to claim edit lock", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/partials/edit_lock.html", map[string]interface{}{
+		"Held":  held,
+		"Lock":  lock,
+		"Label": editLockLabels[kind],
+	})
+}
+
+// Release handles POST /admin/locks/:type/:id/release
+// Drops the current user's lock when they leave the edit form. The form
+// sends it with navigator.sendBeacon, so the response is empty.
+func (h *EditLocksHandler) Release(c echo.Context) error {
+	kind, id, err := h.target(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if err := h.locks.Release(ctx, kind, id, getUserID(c)); err != nil {
+		h.logger.ErrorContext(ctx, "failed to release edit lock", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
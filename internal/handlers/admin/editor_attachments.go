@@ -0,0 +1,93 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the upload endpoint for images attached in the rich
+// text (Trix) editors, and the hook that cleans up attachments left behind
+// by deleted content.
+package admin
+
+import (
+	// Standard library imports
+	"errors"   // Rejected uploads
+	"io"       // Upload contents
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Attachment storage and cleanup
+)
+
+// editorAttachments removes editor uploads that no content refers to. Like
+// webhooks it is set once at startup and notified through logActivity; when
+// it is nil nothing is cleaned up.
+var editorAttachments *services.EditorAttachments
+
+// SetEditorAttachments sets the package-level editor attachment service.
+//
+// Example:
+//
+//	admin.SetEditorAttachments(services.NewEditorAttachments(db, queries, cfg.UploadDir, logger))
+func SetEditorAttachments(svc *services.EditorAttachments) {
+	editorAttachments = svc
+}
+
+// cleanUpAttachments starts a cleanup of orphaned editor attachments after
+// something was permanently deleted.
+func cleanUpAttachments(action string) {
+	if editorAttachments != nil && action == "deleted" {
+		editorAttachments.ContentDeleted()
+	}
+}
+
+// EditorAttachmentsHandler handles POST /admin/editor/attachments.
+type EditorAttachmentsHandler struct {
+	attachments *services.EditorAttachments // Stores the uploads
+	logger      *slog.Logger                // Structured logger for error tracking
+}
+
+// NewEditorAttachmentsHandler creates a new EditorAttachmentsHandler instance.
+func NewEditorAttachmentsHandler(attachments *services.EditorAttachments, logger *slog.Logger) *EditorAttachmentsHandler {
+	return &EditorAttachmentsHandler{attachments: attachments, logger: logger}
+}
+
+// Upload handles POST /admin/editor/attachments
+// Stores an image dropped or pasted into a Trix editor in the media library
+// (max 10 MB; JPEG, PNG, GIF or WebP) and returns the attributes the editor
+// sets on the attachment. Uploading an image the library already has returns
+// that file.
+//
+// Form fields:
+//   - file: The image
+//
+// Response JSON:
+//
+//	{"url": "/uploads/media/…", "href": "/uploads/media/…", "id": 12, "width": 800, "height": 600}
+//
+// Errors are returned as {"error": "..."} with 400 (no file) or 422 (not
+// an accepted image).
+func (h *EditorAttachmentsHandler) Upload(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "No file provided"})
+	}
+	media, err := h.attachments.Store(c.Request().Context(), file.Filename, file.Size, func() (io.ReadCloser, error) {
+		return file.Open()
+	})
+	if errors.Is(err, services.ErrEditorAttachment) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to store editor attachment", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save the image"})
+	}
+
+	logActivity(c, "created", "media", media.ID, media.OriginalFilename, "Attached image %s in the editor", media.OriginalFilename)
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"url":    media.FilePath,
+		"href":   media.FilePath,
+		"id":     media.ID,
+		"width":  media.Width.Int64,
+		"height": media.Height.Int64,
+	})
+}
@@ -0,0 +1,279 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the events and webinars listed on /events, with the
+// registrations received through their public registration forms.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"net/url"      // Webinar link validation
+	"slices"       // Event type validation
+	"strconv"      // Parsing IDs and the capacity
+	"strings"      // Trimming form values
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Event types, slugs, site timezone and page cache
+)
+
+// eventTimeLayout is the value format of the datetime-local inputs.
+const eventTimeLayout = "2006-01-02T15:04"
+
+// EventsHandler handles events and webinars at /admin/events.
+type EventsHandler struct {
+	queries sqlc.Querier    // Database queries generated by sqlc
+	logger  *slog.Logger    // Structured logger for error reporting
+	cache   *services.Cache // Public page cache, cleared after each change
+}
+
+// NewEventsHandler creates a new EventsHandler instance.
+func NewEventsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *EventsHandler {
+	return &EventsHandler{queries: queries, logger: logger, cache: cache}
+}
+
+// List handles GET /admin/events
+// Lists every event, latest start first, with its registration count.
+// Template: admin/pages/events_list.html (full page)
+func (h *EventsHandler) List(c echo.Context) error {
+	events, err := h.queries.ListEventsAdmin(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list events", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/events_list.html", map[string]interface{}{
+		"Title":  "Events",
+		"Events": events,
+	})
+}
+
+// New handles GET /admin/events/new
+// Template: admin/pages/events_form.html (full page)
+func (h *EventsHandler) New(c echo.Context) error {
+	return h.renderForm(c, http.StatusOK, sqlc.Event{EventType: services.EventInPerson, RegistrationOpen: 1}, "")
+}
+
+// Create handles POST /admin/events
+// Adds an event and returns to the list. Invalid input is reported on the
+// form.
+func (h *EventsHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	event, msg := h.form(c, 0)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, event, msg)
+	}
+	created, err := h.queries.CreateEvent(ctx, sqlc.CreateEventParams{
+		Title:            event.Title,
+		Slug:             event.Slug,
+		EventType:        event.EventType,
+		Summary:          event.Summary,
+		Description:      event.Description,
+		StartsAt:         event.StartsAt,
+		EndsAt:           event.EndsAt,
+		Location:         event.Location,
+		WebinarUrl:       event.WebinarUrl,
+		RegistrationOpen: event.RegistrationOpen,
+		Capacity:         event.Capacity,
+		IsPublished:      event.IsPublished,
+		MetaDescription:  event.MetaDescription,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create event", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, "", "/events/"+created.Slug)
+	h.changed()
+	logActivity(c, "created", "event", created.ID, created.Title, "Created event %q", created.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/events")
+}
+
+// Edit handles GET /admin/events/:id/edit
+// Template: admin/pages/events_form.html (full page)
+func (h *EventsHandler) Edit(c echo.Context) error {
+	event, err := h.event(c)
+	if err != nil {
+		return err
+	}
+	return h.renderForm(c, http.StatusOK, event, "")
+}
+
+// Update handles POST /admin/events/:id
+// Saves the event form. A changed slug leaves a redirect from the old
+// page.
+func (h *EventsHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, err := h.event(c)
+	if err != nil {
+		return err
+	}
+	event, msg := h.form(c, existing.ID)
+	event.ID = existing.ID
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, event, msg)
+	}
+	err = h.queries.UpdateEvent(ctx, sqlc.UpdateEventParams{
+		Title:            event.Title,
+		Slug:             event.Slug,
+		EventType:        event.EventType,
+		Summary:          event.Summary,
+		Description:      event.Description,
+		StartsAt:         event.StartsAt,
+		EndsAt:           event.EndsAt,
+		Location:         event.Location,
+		WebinarUrl:       event.WebinarUrl,
+		RegistrationOpen: event.RegistrationOpen,
+		Capacity:         event.Capacity,
+		IsPublished:      event.IsPublished,
+		MetaDescription:  event.MetaDescription,
+		ID:               existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update event", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, "/events/"+existing.Slug, "/events/"+event.Slug)
+	h.changed()
+	logActivity(c, "updated", "event", existing.ID, event.Title, "Updated event %q", event.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/events")
+}
+
+// Delete handles DELETE /admin/events/:id
+// Removes an event with its registrations. HTMX: returns an empty 200
+// response and the row is removed.
+func (h *EventsHandler) Delete(c echo.Context) error {
+	event, err := h.event(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeleteEvent(c.Request().Context(), event.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete event", "error", err, "id", event.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "deleted", "event", event.ID, event.Title, "Deleted event %q", event.Title)
+	return c.NoContent(http.StatusOK)
+}
+
+// Registrations handles GET /admin/events/:id/registrations
+// Lists the registrations of an event, newest first. They also appear as
+// leads on the Leads page.
+// Template: admin/pages/event_registrations.html (full page)
+func (h *EventsHandler) Registrations(c echo.Context) error {
+	event, err := h.event(c)
+	if err != nil {
+		return err
+	}
+	registrations, err := h.queries.ListEventRegistrations(c.Request().Context(), event.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list event registrations", "error", err, "id", event.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/event_registrations.html", map[string]interface{}{
+		"Title":         "Registrations: " + event.Title,
+		"Event":         event,
+		"Registrations": registrations,
+	})
+}
+
+// event loads the event named by the :id parameter, returning an HTTP
+// error for a bad or unknown ID.
+func (h *EventsHandler) event(c echo.Context) (sqlc.Event, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.Event{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	event, err := h.queries.GetEvent(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return event, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load event", "error", err, "id", id)
+		return event, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return event, nil
+}
+
+// changed drops the cached /events pages, which were rendered before the
+// change.
+func (h *EventsHandler) changed() {
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:events")
+	}
+}
+
+// form reads and validates the event form for event id (0 for a new one).
+// Times are entered in the site timezone and returned in UTC. It returns a
+// message for the form on invalid input.
+func (h *EventsHandler) form(c echo.Context, id int64) (sqlc.Event, string) {
+	event := sqlc.Event{
+		Title:            strings.TrimSpace(c.FormValue("title")),
+		EventType:        c.FormValue("event_type"),
+		Summary:          strings.TrimSpace(c.FormValue("summary")),
+		Description:      strings.TrimSpace(c.FormValue("description")),
+		Location:         strings.TrimSpace(c.FormValue("location")),
+		WebinarUrl:       strings.TrimSpace(c.FormValue("webinar_url")),
+		MetaDescription:  strings.TrimSpace(c.FormValue("meta_description")),
+		Slug:             strings.TrimSpace(c.FormValue("slug")),
+		RegistrationOpen: checkboxValue(c.FormValue("registration_open")),
+		IsPublished:      checkboxValue(c.FormValue("is_published")),
+	}
+	event.Capacity, _ = strconv.ParseInt(strings.TrimSpace(c.FormValue("capacity")), 10, 64)
+	starts, startErr := services.ParseSiteTime(eventTimeLayout, c.FormValue("starts_at"))
+	ends, endErr := services.ParseSiteTime(eventTimeLayout, c.FormValue("ends_at"))
+	event.StartsAt, event.EndsAt = starts, ends
+
+	switch {
+	case event.Title == "":
+		return event, "Give the event a title."
+	case !slices.Contains(services.EventTypes, event.EventType):
+		return event, "Choose whether the event is in person or a webinar."
+	case startErr != nil || endErr != nil:
+		return event, "Enter when the event starts and ends."
+	case !ends.After(starts):
+		return event, "The event must end after it starts."
+	case event.EventType == services.EventInPerson && event.Location == "":
+		return event, "Enter where the event takes place."
+	case event.EventType == services.EventWebinar && !isWebURL(event.WebinarUrl):
+		return event, "Enter the webinar's join link, a full http(s):// URL."
+	case event.Capacity < 0:
+		return event, "The capacity cannot be negative; use 0 for no limit."
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugEvents, event.Slug, event.Title, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate event slug", "error", err)
+		return event, "Could not find a free address for this event; enter another slug."
+	}
+	event.Slug = slug
+	return event, ""
+}
+
+// isWebURL reports whether s is an absolute http or https URL.
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// checkboxValue converts a checkbox form value to the 0/1 columns.
+func checkboxValue(v string) int64 {
+	if v == "on" || v == "1" || v == "true" {
+		return 1
+	}
+	return 0
+}
+
+// renderForm shows the add or edit form for event (ID 0 for a new one).
+func (h *EventsHandler) renderForm(c echo.Context, status int, event sqlc.Event, errMsg string) error {
+	title, action := "New Event", "/admin/events"
+	if event.ID != 0 {
+		title, action = "Edit Event", "/admin/events/"+strconv.FormatInt(event.ID, 10)
+	}
+	return c.Render(status, "admin/pages/events_form.html", map[string]interface{}{
+		"Title":      title,
+		"Item":       event,
+		"FormAction": action,
+		"Error":      errMsg,
+	})
+}
@@ -0,0 +1,195 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the background exports: queue an export of products,
+// leads, whitepaper downloads or the activity log, follow its progress, and
+// download the file while its link is valid.
+package admin
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for unknown jobs
+	"errors"       // Matching export errors
+	"fmt"          // Download headers and activity descriptions
+	"log/slog"     // Structured logging for error tracking
+	"net/http"     // HTTP status codes and error responses
+	"net/url"      // Filters passed on to the export
+	"strconv"      // Parsing job IDs
+	"time"         // Expiry checks
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Generated SQL queries via sqlc
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Form token field name
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Export queue
+)
+
+// exportsListLimit is how many recent exports the exports page lists.
+const exportsListLimit = 25
+
+// exportContentTypes are the download content types per format.
+var exportContentTypes = map[string]string{
+	services.ExportCSV:  "text/csv; charset=utf-8",
+	services.ExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportJobView is an export job with what the template shows about it.
+type exportJobView struct {
+	sqlc.ExportJob
+	Label   string // Source label, e.g. "Products"
+	Percent int    // Progress 0-100, or -1 when the total is unknown
+	Expired bool   // Download link no longer works
+}
+
+// ExportsHandler serves /admin/exports. Exports run in the background on
+// services.ExportJobs; users see and download their own exports, admins
+// can open any export by its link.
+type ExportsHandler struct {
+	queries sqlc.Querier         // Export job records
+	logger  *slog.Logger         // Structured logger for error tracking
+	jobs    *services.ExportJobs // Export queue and file storage
+}
+
+// NewExportsHandler constructs a new ExportsHandler.
+func NewExportsHandler(queries sqlc.Querier, logger *slog.Logger, jobs *services.ExportJobs) *ExportsHandler {
+	return &ExportsHandler{queries: queries, logger: logger, jobs: jobs}
+}
+
+// List handles GET /admin/exports
+// Lists the current user's recent exports with their status and links.
+// Template: admin/pages/exports.html (full page)
+func (h *ExportsHandler) List(c echo.Context) error {
+	jobs, err := h.queries.ListExportJobs(c.Request().Context(), sqlc.ListExportJobsParams{
+		FilterUser: getUserID(c),
+		PageLimit:  exportsListLimit,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list exports", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	views := make([]exportJobView, len(jobs))
+	for i, job := range jobs {
+		views[i] = h.view(job)
+	}
+	return c.Render(http.StatusOK, "admin/pages/exports.html", map[string]interface{}{
+		"Title":    "Exports",
+		"Jobs":     views,
+		"TTLHours": int(h.jobs.TTL().Hours()),
+	})
+}
+
+// Start handles POST /admin/exports
+// Queues an export and redirects to its status page (303).
+//
+// Form fields:
+//   - kind: products, leads, whitepaper_downloads, subscribers or activity
+//   - format: csv or xlsx (default: the export's first format)
+//   - any other field: list filters, passed to the export as is (e.g. the
+//     activity log's type and from, or whitepaper and date_from)
+func (h *ExportsHandler) Start(c echo.Context) error {
+	form, err := c.FormParams()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form")
+	}
+	kind, format := form.Get("kind"), form.Get("format")
+	params := url.Values{}
+	for key, values := range form {
+		if key != "kind" && key != "format" && key != customMiddleware.FormTokenField {
+			params[key] = values
+		}
+	}
+
+	job, err := h.jobs.Enqueue(c.Request().Context(), kind, format, params, getUserID(c))
+	switch {
+	case errors.Is(err, services.ErrUnknownExport), errors.Is(err, services.ErrExportFormat):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case err != nil:
+		h.logger.ErrorContext(c.Request().Context(), "failed to queue export", "kind", kind, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	src, _ := h.jobs.Source(kind)
+	logActivity(c, "exported", "export", job.ID, src.Label, "Requested %s export as %s", src.Label, job.Format)
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/exports/%d", job.ID))
+}
+
+// Status handles GET /admin/exports/:id
+// Renders the progress of an export and, once done, its download link. While
+// the export is queued or running the page polls itself every two seconds
+// (HTMX).
+// Template: admin/pages/exports.html (full page)
+func (h *ExportsHandler) Status(c echo.Context) error {
+	job, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	return c.Render(http.StatusOK, "admin/pages/exports.html", map[string]interface{}{
+		"Title":    "Exports",
+		"Job":      h.view(job),
+		"TTLHours": int(h.jobs.TTL().Hours()),
+	})
+}
+
+// Download handles GET /admin/exports/:id/download
+// Sends the file of a finished export. Expired links get 410 Gone and
+// unfinished exports 409 Conflict.
+func (h *ExportsHandler) Download(c echo.Context) error {
+	job, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	f, err := h.jobs.Open(c.Request().Context(), job, time.Now())
+	switch {
+	case errors.Is(err, services.ErrExportExpired):
+		return echo.NewHTTPError(http.StatusGone, "This download link has expired. Start the export again.")
+	case errors.Is(err, services.ErrExportNotReady):
+		return echo.NewHTTPError(http.StatusConflict, "The export is not finished yet.")
+	case err != nil:
+		h.logger.ErrorContext(c.Request().Context(), "failed to open export file", "job", job.ID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer f.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", job.Filename))
+	res.Header().Set("Cache-Control", "private, no-store")
+	return c.Stream(http.StatusOK, exportContentTypes[job.Format], f)
+}
+
+// load returns the job named by :id. Other users' jobs are only visible to
+// admins; everyone else gets 404, as for jobs that do not exist.
+func (h *ExportsHandler) load(c echo.Context) (sqlc.ExportJob, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.ExportJob{}, echo.NewHTTPError(http.StatusNotFound, "Export not found")
+	}
+	job, err := h.queries.GetExportJob(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.ExportJob{}, echo.NewHTTPError(http.StatusNotFound, "Export not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load export", "job", id, "error", err)
+		return sqlc.ExportJob{}, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if job.UserID.Int64 != getUserID(c) && getSessionRole(c) != "admin" {
+		return sqlc.ExportJob{}, echo.NewHTTPError(http.StatusNotFound, "Export not found")
+	}
+	return job, nil
+}
+
+// view adds the source label, progress and expiry to a job.
+func (h *ExportsHandler) view(job sqlc.ExportJob) exportJobView {
+	v := exportJobView{ExportJob: job, Label: job.Kind, Percent: -1}
+	if src, ok := h.jobs.Source(job.Kind); ok {
+		v.Label = src.Label
+	}
+	switch {
+	case job.Status == services.ExportDone:
+		v.Percent = 100
+	case job.RowsTotal > 0:
+		v.Percent = int(min(job.RowsDone*100/job.RowsTotal, 99))
+	}
+	v.Expired = job.Status == services.ExportExpired ||
+		job.Status == services.ExportDone && job.ExpiresAt.Valid && !time.Now().Before(job.ExpiresAt.Time)
+	return v
+}
@@ -14,15 +14,16 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Database query layer generated by sqlc
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Database query layer generated by sqlc
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
 )
 
 // FooterHandler manages footer configuration for the website.
 // It provides endpoints to view and update footer settings including column layout,
 // footer links, legal links, social media visibility, and copyright information.
 type FooterHandler struct {
-	queries *sqlc.Queries // Database query interface for footer operations
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface for footer operations
+	logger  *slog.Logger // Structured logger for error tracking
 }
 
 // FooterColumnData is a template-friendly struct for each footer column.
@@ -43,7 +44,7 @@ type FooterColumnData struct {
 //   - logger: Structured logger for error and activity logging
 //
 // Returns a fully initialized FooterHandler ready to handle HTTP requests.
-func NewFooterHandler(queries *sqlc.Queries, logger *slog.Logger) *FooterHandler {
+func NewFooterHandler(queries sqlc.Querier, logger *slog.Logger) *FooterHandler {
 	return &FooterHandler{queries: queries, logger: logger}
 }
 
@@ -65,7 +66,6 @@ func NewFooterHandler(queries *sqlc.Queries, logger *slog.Logger) *FooterHandler
 // making it easy to render a consistent form interface regardless of how many columns are active.
 //
 // Query Parameters:
-//   - saved: Set to "1" to display a success message after form submission
 //
 // Returns:
 //   - 200 OK with rendered HTML form on success
@@ -76,28 +76,28 @@ func (h *FooterHandler) Edit(c echo.Context) error {
 	// Retrieve global footer settings (columns count, background style, etc.)
 	settings, err := h.queries.GetSettings(ctx)
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Retrieve all footer column items (metadata for each column)
 	columnItems, err := h.queries.ListFooterColumnItems(ctx)
 	if err != nil {
-		h.logger.Error("failed to load footer column items", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load footer column items", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Retrieve all footer links across all columns
 	allLinks, err := h.queries.ListAllFooterLinks(ctx)
 	if err != nil {
-		h.logger.Error("failed to load footer links", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load footer links", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Retrieve legal links (displayed separately in footer bottom)
 	legalLinks, err := h.queries.ListFooterLegalLinks(ctx)
 	if err != nil {
-		h.logger.Error("failed to load footer legal links", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load footer legal links", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -138,13 +138,11 @@ func (h *FooterHandler) Edit(c echo.Context) error {
 	}
 
 	// Check if this is a redirect after successful save operation
-	saved := c.QueryParam("saved") == "1"
 
 	// Render the footer configuration form with all organized data
 	return c.Render(http.StatusOK, "admin/pages/footer_form.html", map[string]interface{}{
 		"Title":      "Footer Management",
 		"Settings":   settings,    // Global footer settings
-		"Saved":      saved,       // Success flag to show confirmation message
 		"ColumnData": columnData,  // Organized column data (always 4 slots)
 		"LegalLinks": legalLinks,  // Legal/compliance links for footer bottom
 	})
@@ -165,7 +163,7 @@ func (h *FooterHandler) Edit(c echo.Context) error {
 //   5. Deleting and recreating legal links
 //
 // The delete-and-recreate approach simplifies handling of dynamic form arrays and ensures
-// data consistency without complex diff logic.
+// data consistency without complex diff logic. All steps run in one transaction.
 //
 // Form Fields:
 //   - footer_columns: Number of columns to display (2-4, defaults to 4)
@@ -182,7 +180,7 @@ func (h *FooterHandler) Edit(c echo.Context) error {
 //   - legal_link_url[]: Array of legal link URLs
 //
 // Returns:
-//   - 303 See Other redirect to /admin/footer?saved=1 on success
+//   - 303 See Other redirect to /admin/footer on success
 //   - 500 Internal Server Error if any database operation fails
 func (h *FooterHandler) Update(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -199,110 +197,121 @@ func (h *FooterHandler) Update(c echo.Context) error {
 		footerShowSocial = 1
 	}
 
-	// Update global footer settings in database
-	err := h.queries.UpdateFooterSettings(ctx, sqlc.UpdateFooterSettingsParams{
-		FooterColumns:     footerColumns,
-		FooterBgStyle:     c.FormValue("footer_bg_style"),
-		FooterShowSocial:  footerShowSocial,
-		FooterSocialStyle: c.FormValue("footer_social_style"),
-		FooterCopyright:   c.FormValue("footer_copyright"),
-	})
-	if err != nil {
-		h.logger.Error("failed to update footer settings", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError)
-	}
-
-	// Delete all existing column items and their associated links
-	// This approach is simpler than updating existing items and handling additions/deletions
-	existingItems, err := h.queries.ListFooterColumnItems(ctx)
-	if err != nil {
-		h.logger.Error("failed to list existing column items", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError)
-	}
-	for _, item := range existingItems {
-		// Delete links first (foreign key constraint)
-		_ = h.queries.DeleteFooterLinksByColumnItem(ctx, item.ID)
-		// Then delete the column item itself
-		_ = h.queries.DeleteFooterColumnItem(ctx, item.ID)
-	}
-
-	// Create new column items based on form data
-	// Only create columns up to the specified footerColumns count
-	for i := int64(0); i < footerColumns; i++ {
-		// Form fields are namespaced with column index (e.g., "col_0_type", "col_1_heading")
-		prefix := fmt.Sprintf("col_%d_", i)
-		colType := c.FormValue(prefix + "type")
-		if colType == "" {
-			colType = "links" // Default to links type if not specified
+	// Settings, columns, links and legal links are replaced together, so a
+	// failed write leaves the previous footer in place instead of a partial one
+	err := withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		// Update global footer settings in database
+		err := q.UpdateFooterSettings(ctx, sqlc.UpdateFooterSettingsParams{
+			FooterColumns:     footerColumns,
+			FooterBgStyle:     c.FormValue("footer_bg_style"),
+			FooterShowSocial:  footerShowSocial,
+			FooterSocialStyle: c.FormValue("footer_social_style"),
+			FooterCopyright:   c.FormValue("footer_copyright"),
+		})
+		if err != nil {
+			return err
 		}
 
-		heading := c.FormValue(prefix + "heading")
-		content := c.FormValue(prefix + "content") // Only used if type is "text"
-
-		// Create the column item record
-		colItem, err := h.queries.CreateFooterColumnItem(ctx, sqlc.CreateFooterColumnItemParams{
-			ColumnIndex: i,       // Position in footer layout (0-3)
-			Type:        colType, // "links" or "text"
-			Heading:     heading,
-			Content:     content,
-			SortOrder:   i, // Same as column index for consistent ordering
-		})
+		// Delete all existing column items and their associated links
+		// This approach is simpler than updating existing items and handling additions/deletions
+		existingItems, err := q.ListFooterColumnItems(ctx)
 		if err != nil {
-			h.logger.Error("failed to create footer column item", "error", err)
-			return echo.NewHTTPError(http.StatusInternalServerError)
+			return err
+		}
+		for _, item := range existingItems {
+			// Delete links first (foreign key constraint)
+			if err := q.DeleteFooterLinksByColumnItem(ctx, item.ID); err != nil {
+				return err
+			}
+			// Then delete the column item itself
+			if err := q.DeleteFooterColumnItem(ctx, item.ID); err != nil {
+				return err
+			}
 		}
 
-		// If column type is "links", create link records from form arrays
-		// Links are submitted as parallel arrays: link_label[] and link_url[]
-		if colType == "links" {
-			labels := c.Request().Form[prefix+"link_label[]"]
-			urls := c.Request().Form[prefix+"link_url[]"]
-			// Iterate through both arrays simultaneously
-			for j := 0; j < len(labels) && j < len(urls); j++ {
-				// Skip empty entries (user may have added but not filled in a link row)
-				if labels[j] == "" && urls[j] == "" {
-					continue
-				}
-				_, err := h.queries.CreateFooterLink(ctx, sqlc.CreateFooterLinkParams{
-					ColumnItemID: colItem.ID, // Associate with parent column
-					Label:        labels[j],
-					Url:          urls[j],
-					SortOrder:    int64(j), // Preserve order from form
-				})
-				if err != nil {
-					h.logger.Error("failed to create footer link", "error", err)
-					// Continue processing other links even if one fails
+		// Create new column items based on form data
+		// Only create columns up to the specified footerColumns count
+		for i := int64(0); i < footerColumns; i++ {
+			// Form fields are namespaced with column index (e.g., "col_0_type", "col_1_heading")
+			prefix := fmt.Sprintf("col_%d_", i)
+			colType := c.FormValue(prefix + "type")
+			if colType == "" {
+				colType = "links" // Default to links type if not specified
+			}
+
+			heading := c.FormValue(prefix + "heading")
+			content := c.FormValue(prefix + "content") // Only used if type is "text"
+
+			// Create the column item record
+			colItem, err := q.CreateFooterColumnItem(ctx, sqlc.CreateFooterColumnItemParams{
+				ColumnIndex: i,       // Position in footer layout (0-3)
+				Type:        colType, // "links" or "text"
+				Heading:     heading,
+				Content:     content,
+				SortOrder:   i, // Same as column index for consistent ordering
+			})
+			if err != nil {
+				return err
+			}
+
+			// If column type is "links", create link records from form arrays
+			// Links are submitted as parallel arrays: link_label[] and link_url[]
+			if colType == "links" {
+				labels := c.Request().Form[prefix+"link_label[]"]
+				urls := c.Request().Form[prefix+"link_url[]"]
+				// Iterate through both arrays simultaneously
+				for j := 0; j < len(labels) && j < len(urls); j++ {
+					// Skip empty entries (user may have added but not filled in a link row)
+					if labels[j] == "" && urls[j] == "" {
+						continue
+					}
+					_, err := q.CreateFooterLink(ctx, sqlc.CreateFooterLinkParams{
+						ColumnItemID: colItem.ID, // Associate with parent column
+						Label:        labels[j],
+						Url:          urls[j],
+						SortOrder:    int64(j), // Preserve order from form
+					})
+					if err != nil {
+						return err
+					}
 				}
 			}
 		}
-	}
-
-	// Update legal links section (privacy policy, terms, etc.)
-	// Delete all existing legal links first
-	_ = h.queries.DeleteAllFooterLegalLinks(ctx)
 
-	// Create new legal links from form arrays
-	legalLabels := c.Request().Form["legal_link_label[]"]
-	legalUrls := c.Request().Form["legal_link_url[]"]
-	for i := 0; i < len(legalLabels) && i < len(legalUrls); i++ {
-		// Skip empty entries
-		if legalLabels[i] == "" && legalUrls[i] == "" {
-			continue
+		// Update legal links section (privacy policy, terms, etc.)
+		// Delete all existing legal links first
+		if err := q.DeleteAllFooterLegalLinks(ctx); err != nil {
+			return err
 		}
-		_, err := h.queries.CreateFooterLegalLink(ctx, sqlc.CreateFooterLegalLinkParams{
-			Label:     legalLabels[i],
-			Url:       legalUrls[i],
-			SortOrder: int64(i), // Preserve order from form
-		})
-		if err != nil {
-			h.logger.Error("failed to create legal link", "error", err)
-			// Continue processing other links even if one fails
+
+		// Create new legal links from form arrays
+		legalLabels := c.Request().Form["legal_link_label[]"]
+		legalUrls := c.Request().Form["legal_link_url[]"]
+		for i := 0; i < len(legalLabels) && i < len(legalUrls); i++ {
+			// Skip empty entries
+			if legalLabels[i] == "" && legalUrls[i] == "" {
+				continue
+			}
+			_, err := q.CreateFooterLegalLink(ctx, sqlc.CreateFooterLegalLinkParams{
+				Label:     legalLabels[i],
+				Url:       legalUrls[i],
+				SortOrder: int64(i), // Preserve order from form
+			})
+			if err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to save footer", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log the update activity for audit trail
 	logActivity(c, "updated", "footer", 0, "", "Updated Footer Settings")
 
-	// Redirect back to the edit form with saved parameter to show success message
-	return c.Redirect(http.StatusSeeOther, "/admin/footer?saved=1")
+	// Redirect back; the page shows the flash message
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Footer settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/footer")
 }
@@ -0,0 +1,545 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the form builder: custom lead forms with an ordered
+// list of fields and their validation rules, a success message, the
+// addresses notified of each submission, and the submissions received.
+package admin
+
+import (
+	"database/sql"  // sql.ErrNoRows detection
+	"encoding/json" // Reorder request body
+	"errors"        // Error inspection
+	"log/slog"      // Structured logging
+	"net/http"      // HTTP status codes
+	"strconv"       // Parsing IDs and lengths
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/models"   // Stored submission answers
+	"github.com/narendhupati/bluejay-cms/internal/services" // Validation and page cache
+)
+
+// formFieldRow is one field on the form settings page with its type's name.
+type formFieldRow struct {
+	sqlc.FormField
+	TypeLabel string // e.g. "Dropdown"
+}
+
+// formSubmissionRow is one submission with its decoded answers.
+type formSubmissionRow struct {
+	sqlc.FormSubmission
+	Answers []models.FormAnswer
+}
+
+// formSubmissionsPerPage is the page size of the submissions list.
+const formSubmissionsPerPage = 25
+
+// FormsHandler handles the form builder at /admin/forms.
+type FormsHandler struct {
+	queries sqlc.Querier    // Database queries generated by sqlc
+	logger  *slog.Logger    // Structured logger for error reporting
+	cache   *services.Cache // Public page cache, cleared after each change
+}
+
+// NewFormsHandler creates a new FormsHandler instance.
+func NewFormsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *FormsHandler {
+	return &FormsHandler{queries: queries, logger: logger, cache: cache}
+}
+
+// List handles GET /admin/forms
+// Lists every form with its number of fields and submissions.
+// Template: admin/pages/forms.html (full page)
+func (h *FormsHandler) List(c echo.Context) error {
+	forms, err := h.queries.ListForms(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list forms", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/forms.html", map[string]interface{}{
+		"Title": "Forms",
+		"Forms": forms,
+	})
+}
+
+// New handles GET /admin/forms/new
+// Template: admin/pages/form_form.html (full page)
+func (h *FormsHandler) New(c echo.Context) error {
+	return h.renderForm(c, http.StatusOK, sqlc.Form{IsActive: true}, "")
+}
+
+// Create handles POST /admin/forms
+// Adds a form and opens it to add fields. Invalid input is reported on the
+// form.
+func (h *FormsHandler) Create(c echo.Context) error {
+	params, msg := h.form(c)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, formFrom(params, sqlc.Form{}), msg)
+	}
+	form, err := h.queries.CreateForm(c.Request().Context(), params)
+	if isUniqueViolation(err) {
+		return h.renderForm(c, http.StatusUnprocessableEntity, formFrom(params, sqlc.Form{}), formSlugTaken)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create form", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "form", form.ID, form.Name, "Created form %q", form.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/forms/"+strconv.FormatInt(form.ID, 10)+"/edit")
+}
+
+// Edit handles GET /admin/forms/:id/edit
+// Shows the form settings with its fields for adding, editing and
+// reordering by drag and drop.
+// Template: admin/pages/form_form.html (full page)
+func (h *FormsHandler) Edit(c echo.Context) error {
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	return h.renderForm(c, http.StatusOK, form, "")
+}
+
+// Update handles POST /admin/forms/:id
+// Saves the form settings. Landing pages embedding the form by its old
+// slug fall back to their built-in lead form.
+func (h *FormsHandler) Update(c echo.Context) error {
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	params, msg := h.form(c)
+	if msg == "" {
+		err = h.queries.UpdateForm(c.Request().Context(), sqlc.UpdateFormParams{
+			Name:           params.Name,
+			Slug:           params.Slug,
+			Description:    params.Description,
+			SubmitLabel:    params.SubmitLabel,
+			SuccessMessage: params.SuccessMessage,
+			NotifyEmails:   params.NotifyEmails,
+			IsActive:       params.IsActive,
+			ID:             form.ID,
+		})
+		if isUniqueViolation(err) {
+			msg = formSlugTaken
+		}
+	}
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, formFrom(params, form), msg)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update form", "error", err, "id", form.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "updated", "form", form.ID, params.Name, "Updated form %q", params.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/forms/"+strconv.FormatInt(form.ID, 10)+"/edit")
+}
+
+// Delete handles DELETE /admin/forms/:id
+// Removes a form with its fields and submissions. HTMX: returns an empty
+// 200 response and the row is removed.
+func (h *FormsHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteForm(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete form", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "deleted", "form", id, "", "Deleted form #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// NewField handles GET /admin/forms/:id/fields/new?type=
+// Template: admin/pages/form_field_form.html (full page)
+func (h *FormsHandler) NewField(c echo.Context) error {
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	fieldType := c.QueryParam("type")
+	if services.FormFieldLabel(fieldType) == fieldType {
+		fieldType = services.FieldText
+	}
+	return h.renderFieldForm(c, http.StatusOK, form, sqlc.FormField{FieldType: fieldType}, "")
+}
+
+// CreateField handles POST /admin/forms/:id/fields
+// Adds a field at the end of the form.
+func (h *FormsHandler) CreateField(c echo.Context) error {
+	ctx := c.Request().Context()
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	params, msg := h.fieldForm(c)
+	params.FormID = form.ID
+	if msg != "" {
+		return h.renderFieldForm(c, http.StatusUnprocessableEntity, form, formFieldFrom(params, 0), msg)
+	}
+	fields, err := h.queries.ListFormFields(ctx, form.ID)
+	if err == nil {
+		params.SortOrder = int64(len(fields))
+		_, err = h.queries.CreateFormField(ctx, params)
+	}
+	if isUniqueViolation(err) {
+		return h.renderFieldForm(c, http.StatusUnprocessableEntity, form, formFieldFrom(params, 0), formFieldNameTaken)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create form field", "error", err, "id", form.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.fieldsChanged(c, form.ID)
+	logActivity(c, "updated", "form", form.ID, form.Name, "Added the field %q to form %q", params.Label, form.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/forms/"+strconv.FormatInt(form.ID, 10)+"/edit")
+}
+
+// EditField handles GET /admin/forms/:id/fields/:fid/edit
+// Template: admin/pages/form_field_form.html (full page)
+func (h *FormsHandler) EditField(c echo.Context) error {
+	form, field, err := h.field(c)
+	if err != nil {
+		return err
+	}
+	return h.renderFieldForm(c, http.StatusOK, form, field, "")
+}
+
+// UpdateField handles POST /admin/forms/:id/fields/:fid
+// Saves a field; its position stays as it is. Stored submissions keep the
+// label the field had when they were sent.
+func (h *FormsHandler) UpdateField(c echo.Context) error {
+	form, field, err := h.field(c)
+	if err != nil {
+		return err
+	}
+	params, msg := h.fieldForm(c)
+	if msg == "" {
+		err = h.queries.UpdateFormField(c.Request().Context(), sqlc.UpdateFormFieldParams{
+			Label:       params.Label,
+			Name:        params.Name,
+			FieldType:   params.FieldType,
+			Placeholder: params.Placeholder,
+			HelpText:    params.HelpText,
+			Options:     params.Options,
+			Required:    params.Required,
+			MinLength:   params.MinLength,
+			MaxLength:   params.MaxLength,
+			ID:          field.ID,
+		})
+		if isUniqueViolation(err) {
+			msg = formFieldNameTaken
+		}
+	}
+	if msg != "" {
+		params.FormID = form.ID
+		return h.renderFieldForm(c, http.StatusUnprocessableEntity, form, formFieldFrom(params, field.ID), msg)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update form field", "error", err, "id", field.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.fieldsChanged(c, form.ID)
+	logActivity(c, "updated", "form", form.ID, form.Name, "Updated the field %q of form %q", params.Label, form.Name)
+	return c.Redirect(http.StatusSeeOther, "/admin/forms/"+strconv.FormatInt(form.ID, 10)+"/edit")
+}
+
+// DeleteField handles DELETE /admin/forms/:id/fields/:fid
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *FormsHandler) DeleteField(c echo.Context) error {
+	form, field, err := h.field(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeleteFormField(c.Request().Context(), field.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete form field", "error", err, "id", field.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.fieldsChanged(c, form.ID)
+	logActivity(c, "updated", "form", form.ID, form.Name, "Removed the field %q from form %q", field.Label, form.Name)
+	return c.NoContent(http.StatusOK)
+}
+
+// ReorderFields handles POST /admin/forms/:id/fields/reorder
+// Saves the order of a form's fields after a drag and drop. The JSON body
+// is an array of {"id": field ID, "order": position}; fields of other forms
+// are left alone.
+func (h *FormsHandler) ReorderFields(c echo.Context) error {
+	ctx := c.Request().Context()
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	var items []struct {
+		ID    int64 `json:"id"`
+		Order int64 `json:"order"`
+	}
+	if err := json.NewDecoder(c.Request().Body).Decode(&items); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid JSON")
+	}
+	for _, item := range items {
+		err := h.queries.UpdateFormFieldOrder(ctx, sqlc.UpdateFormFieldOrderParams{SortOrder: item.Order, ID: item.ID, FormID: form.ID})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to reorder form field", "error", err, "id", item.ID)
+		}
+	}
+	h.fieldsChanged(c, form.ID)
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Submissions handles GET /admin/forms/:id/submissions
+// Lists a form's submissions with their answers, newest first, 25 to a
+// page (?page=).
+// Template: admin/pages/form_submissions.html (full page)
+func (h *FormsHandler) Submissions(c echo.Context) error {
+	ctx := c.Request().Context()
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	page := int64(1)
+	if v, err := strconv.ParseInt(c.QueryParam("page"), 10, 64); err == nil && v > 1 {
+		page = v
+	}
+	total, err := h.queries.CountFormSubmissions(ctx, form.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count form submissions", "error", err, "id", form.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	subs, err := h.queries.ListFormSubmissions(ctx, sqlc.ListFormSubmissionsParams{
+		FormID: form.ID,
+		Limit:  formSubmissionsPerPage,
+		Offset: (page - 1) * formSubmissionsPerPage,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list form submissions", "error", err, "id", form.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	rows := make([]formSubmissionRow, len(subs))
+	for i, s := range subs {
+		rows[i] = formSubmissionRow{FormSubmission: s, Answers: services.DecodeFormAnswers(s.Data)}
+	}
+	totalPages := (total + formSubmissionsPerPage - 1) / formSubmissionsPerPage
+	return c.Render(http.StatusOK, "admin/pages/form_submissions.html", map[string]interface{}{
+		"Title":       "Submissions: " + form.Name,
+		"Form":        form,
+		"Submissions": rows,
+		"Total":       total,
+		"Page":        page,
+		"TotalPages":  totalPages,
+		"HasPrev":     page > 1,
+		"HasNext":     page < totalPages,
+		"PrevPage":    page - 1,
+		"NextPage":    page + 1,
+	})
+}
+
+// DeleteSubmission handles DELETE /admin/forms/:id/submissions/:sid
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *FormsHandler) DeleteSubmission(c echo.Context) error {
+	form, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	sid, err := strconv.ParseInt(c.Param("sid"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteFormSubmission(c.Request().Context(), sqlc.DeleteFormSubmissionParams{ID: sid, FormID: form.ID}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete form submission", "error", err, "id", sid)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "deleted", "form", form.ID, form.Name, "Deleted submission #%d of form %q", sid, form.Name)
+	return c.NoContent(http.StatusOK)
+}
+
+// load returns the form named by the :id parameter, or an HTTP error for a
+// bad or unknown ID.
+func (h *FormsHandler) load(c echo.Context) (sqlc.Form, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.Form{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	form, err := h.queries.GetForm(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return form, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load form", "error", err, "id", id)
+		return form, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return form, nil
+}
+
+// field loads the form and the field named by the :id and :fid parameters;
+// a field of another form is not found.
+func (h *FormsHandler) field(c echo.Context) (sqlc.Form, sqlc.FormField, error) {
+	form, err := h.load(c)
+	if err != nil {
+		return form, sqlc.FormField{}, err
+	}
+	fid, err := strconv.ParseInt(c.Param("fid"), 10, 64)
+	if err != nil {
+		return form, sqlc.FormField{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	field, err := h.queries.GetFormField(c.Request().Context(), fid)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && field.FormID != form.ID {
+		return form, field, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load form field", "error", err, "id", fid)
+		return form, field, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return form, field, nil
+}
+
+// fieldsChanged marks the form as edited after a field change and drops the
+// cached pages showing it.
+func (h *FormsHandler) fieldsChanged(c echo.Context, id int64) {
+	if err := h.queries.TouchForm(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to touch form", "error", err, "id", id)
+	}
+	h.changed()
+}
+
+// changed drops the cached form pages and the landing pages, which may
+// embed a form.
+func (h *FormsHandler) changed() {
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:form:")
+		h.cache.DeleteByPrefix("page:landing:")
+	}
+}
+
+// form reads and validates the form settings. It returns a message for the
+// form on invalid input.
+func (h *FormsHandler) form(c echo.Context) (sqlc.CreateFormParams, string) {
+	params, err := services.ValidateForm(sqlc.CreateFormParams{
+		Name:           c.FormValue("name"),
+		Slug:           c.FormValue("slug"),
+		Description:    c.FormValue("description"),
+		SubmitLabel:    c.FormValue("submit_label"),
+		SuccessMessage: c.FormValue("success_message"),
+		NotifyEmails:   c.FormValue("notify_emails"),
+		IsActive:       c.FormValue("is_active") == "on",
+	})
+	if err != nil {
+		return params, formErrorMessages[err]
+	}
+	return params, ""
+}
+
+// fieldForm reads and validates a field. Lengths that are not numbers count
+// as no limit. It returns a message for the form on invalid input.
+func (h *FormsHandler) fieldForm(c echo.Context) (sqlc.CreateFormFieldParams, string) {
+	minLength, _ := strconv.ParseInt(c.FormValue("min_length"), 10, 64)
+	maxLength, _ := strconv.ParseInt(c.FormValue("max_length"), 10, 64)
+	params, err := services.ValidateFormField(sqlc.CreateFormFieldParams{
+		Label:       c.FormValue("label"),
+		Name:        c.FormValue("name"),
+		FieldType:   c.FormValue("field_type"),
+		Placeholder: c.FormValue("placeholder"),
+		HelpText:    c.FormValue("help_text"),
+		Options:     c.FormValue("options"),
+		Required:    c.FormValue("required") == "on",
+		MinLength:   minLength,
+		MaxLength:   maxLength,
+	})
+	if err != nil {
+		return params, formErrorMessages[err]
+	}
+	return params, ""
+}
+
+// Messages for a slug or field key that is already taken.
+const (
+	formSlugTaken      = "Another form already uses this slug."
+	formFieldNameTaken = "Another field of this form already uses this key."
+)
+
+// formErrorMessages explains validation errors on the forms.
+var formErrorMessages = map[error]string{
+	services.ErrFormName:     "Give the form a name.",
+	services.ErrFormSlug:     "The slug needs letters or digits, e.g. demo-request.",
+	services.ErrFormEmails:   "Notification addresses must be email addresses such as sales@example.com, separated by commas.",
+	services.ErrFieldLabel:   "Give the field a label.",
+	services.ErrFieldName:    "The key needs letters or digits, e.g. company_size.",
+	services.ErrFieldType:    "Choose a field type.",
+	services.ErrFieldOptions: "A dropdown needs at least one option, one per line.",
+	services.ErrFieldLength:  "Lengths cannot be negative, and the minimum cannot exceed the maximum.",
+}
+
+// renderForm shows the add or edit form for form (ID 0 for a new one) with
+// its fields.
+func (h *FormsHandler) renderForm(c echo.Context, status int, form sqlc.Form, errMsg string) error {
+	title, action := "New Form", "/admin/forms"
+	var rows []formFieldRow
+	if form.ID != 0 {
+		title, action = "Edit Form", "/admin/forms/"+strconv.FormatInt(form.ID, 10)
+		fields, err := h.queries.ListFormFields(c.Request().Context(), form.ID)
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to list form fields", "error", err, "id", form.ID)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		for _, f := range fields {
+			rows = append(rows, formFieldRow{FormField: f, TypeLabel: services.FormFieldLabel(f.FieldType)})
+		}
+	}
+	return c.Render(status, "admin/pages/form_form.html", map[string]interface{}{
+		"Title":      title,
+		"Item":       form,
+		"FormAction": action,
+		"FieldTypes": services.FormFieldTypes,
+		"Fields":     rows,
+		"Error":      errMsg,
+	})
+}
+
+// renderFieldForm shows the add or edit form for a field of form (ID 0 for
+// a new one).
+func (h *FormsHandler) renderFieldForm(c echo.Context, status int, form sqlc.Form, field sqlc.FormField, errMsg string) error {
+	base := "/admin/forms/" + strconv.FormatInt(form.ID, 10)
+	title, action := "New Field", base+"/fields"
+	if field.ID != 0 {
+		title, action = "Edit Field", base+"/fields/"+strconv.FormatInt(field.ID, 10)
+	}
+	return c.Render(status, "admin/pages/form_field_form.html", map[string]interface{}{
+		"Title":      title,
+		"Form":       form,
+		"Item":       field,
+		"FormAction": action,
+		"FieldTypes": services.FormFieldTypes,
+		"Error":      errMsg,
+	})
+}
+
+// formFrom turns submitted settings back into a row for the form, keeping
+// the ID of form.
+func formFrom(p sqlc.CreateFormParams, form sqlc.Form) sqlc.Form {
+	form.Name = p.Name
+	form.Slug = p.Slug
+	form.Description = p.Description
+	form.SubmitLabel = p.SubmitLabel
+	form.SuccessMessage = p.SuccessMessage
+	form.NotifyEmails = p.NotifyEmails
+	form.IsActive = p.IsActive
+	return form
+}
+
+// formFieldFrom turns a submitted field back into a row for the form.
+func formFieldFrom(p sqlc.CreateFormFieldParams, id int64) sqlc.FormField {
+	return sqlc.FormField{
+		ID:          id,
+		FormID:      p.FormID,
+		Label:       p.Label,
+		Name:        p.Name,
+		FieldType:   p.FieldType,
+		Placeholder: p.Placeholder,
+		HelpText:    p.HelpText,
+		Options:     p.Options,
+		Required:    p.Required,
+		MinLength:   p.MinLength,
+		MaxLength:   p.MaxLength,
+	}
+}
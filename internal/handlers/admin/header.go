@@ -15,15 +15,16 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Database query layer generated by sqlc
-	"github.com/narendhupati/bluejay-cms/internal/services" // Shared services (file uploads, etc.)
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Database query layer generated by sqlc
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Shared services (file uploads, etc.)
 )
 
 // HeaderHandler manages header configuration for the website.
 // It provides endpoints to view and update header settings including logo,
 // CTA buttons, contact information visibility, and navigation menu items.
 type HeaderHandler struct {
-	queries   *sqlc.Queries           // Database query interface for settings operations
+	queries   sqlc.Querier            // Database query interface for settings operations
 	logger    *slog.Logger            // Structured logger for error tracking
 	uploadSvc *services.UploadService // Handles logo file uploads to disk
 	cache     *services.Cache         // Cache service for invalidating page-level caches
@@ -39,7 +40,7 @@ type HeaderHandler struct {
 //   - cache: Cache service used to invalidate rendered public pages on update
 //
 // Returns a fully initialized HeaderHandler ready to handle HTTP requests.
-func NewHeaderHandler(queries *sqlc.Queries, logger *slog.Logger, uploadSvc *services.UploadService, cache *services.Cache) *HeaderHandler {
+func NewHeaderHandler(queries sqlc.Querier, logger *slog.Logger, uploadSvc *services.UploadService, cache *services.Cache) *HeaderHandler {
 	return &HeaderHandler{queries: queries, logger: logger, uploadSvc: uploadSvc, cache: cache}
 }
 
@@ -58,7 +59,6 @@ func NewHeaderHandler(queries *sqlc.Queries, logger *slog.Logger, uploadSvc *ser
 //   - Navigation menu item visibility and labels
 //
 // Query Parameters:
-//   - saved: Set to "1" to display a success message after form submission
 //
 // Returns:
 //   - 200 OK with rendered HTML form on success
@@ -67,18 +67,16 @@ func (h *HeaderHandler) Edit(c echo.Context) error {
 	// Retrieve current header settings from database
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Check if this is a redirect after successful save operation
-	saved := c.QueryParam("saved") == "1"
 
 	// Render the header configuration form with current settings
 	return c.Render(http.StatusOK, "admin/pages/header_form.html", map[string]interface{}{
 		"Title":    "Header Management",
 		"Settings": settings, // Current header configuration data
-		"Saved":    saved,    // Success flag to show confirmation message
 	})
 }
 
@@ -105,10 +103,10 @@ func (h *HeaderHandler) Edit(c echo.Context) error {
 //   - nav_label_*: Custom labels for each navigation section
 //
 // Note: Checkbox values are converted from "on" string to boolean.
-// After successful update, logs the activity and redirects back to the edit form with saved=1.
+// After successful update, logs the activity and redirects back to the edit form with a success flash message.
 //
 // Returns:
-//   - 303 See Other redirect to /admin/header?saved=1 on success
+//   - 303 See Other redirect to /admin/header on success
 //   - 500 Internal Server Error if database update fails
 func (h *HeaderHandler) Update(c echo.Context) error {
 	// Resolve the logo path. The text field provides a fallback (paste/clear a path),
@@ -118,7 +116,7 @@ func (h *HeaderHandler) Update(c echo.Context) error {
 	if fileHeader, err := c.FormFile("header_logo_file"); err == nil && fileHeader != nil {
 		path, uploadErr := h.uploadSvc.UploadLogo(fileHeader)
 		if uploadErr != nil {
-			h.logger.Error("failed to upload logo", "error", uploadErr)
+			h.logger.ErrorContext(c.Request().Context(), "failed to upload logo", "error", uploadErr)
 
 			// Re-render the form with a friendly, in-form error instead of dumping
 			// the admin out to a raw JSON error page. Name the offending extension
@@ -131,7 +129,7 @@ func (h *HeaderHandler) Update(c echo.Context) error {
 
 			settings, getErr := h.queries.GetSettings(c.Request().Context())
 			if getErr != nil {
-				h.logger.Error("failed to load settings", "error", getErr)
+				h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", getErr)
 				return echo.NewHTTPError(http.StatusInternalServerError)
 			}
 
@@ -185,7 +183,7 @@ func (h *HeaderHandler) Update(c echo.Context) error {
 	})
 
 	if err != nil {
-		h.logger.Error("failed to update header settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update header settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -199,6 +197,7 @@ func (h *HeaderHandler) Update(c echo.Context) error {
 	// Log the update activity for audit trail (uses helper function from activity.go)
 	logActivity(c, "updated", "header", 0, "", "Updated Header Settings")
 
-	// Redirect back to the edit form with saved parameter to show success message
-	return c.Redirect(http.StatusSeeOther, "/admin/header?saved=1")
+	// Redirect back; the page shows the flash message
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Header settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/header")
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context
 
 	// Internal imports
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
 	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated sqlc queries for database operations
 )
 
@@ -23,15 +24,15 @@ import (
 // and global homepage settings. Note: This handler does not use a cache service
 // since homepage content changes are immediate.
 type HomepageHandler struct {
-	queries *sqlc.Queries // Database query interface generated by sqlc
-	logger  *slog.Logger  // Structured logger for error and event logging
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error and event logging
 }
 
 // NewHomepageHandler creates a new HomepageHandler instance with required dependencies.
 // Parameters:
 //   - queries: sqlc-generated database query interface
 //   - logger: structured logger for error and event tracking
-func NewHomepageHandler(queries *sqlc.Queries, logger *slog.Logger) *HomepageHandler {
+func NewHomepageHandler(queries sqlc.Querier, logger *slog.Logger) *HomepageHandler {
 	return &HomepageHandler{queries: queries, logger: logger}
 }
 
@@ -52,7 +53,7 @@ func (h *HomepageHandler) HeroesList(c echo.Context) error {
 	// Fetch all heroes from database, ordered by display_order
 	items, err := h.queries.ListAllHeroes(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list heroes", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list heroes", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -86,6 +87,11 @@ func (h *HomepageHandler) HeroNew(c echo.Context) error {
 // primary and optional secondary CTAs, optional background image, is_active flag,
 // and display order for carousel sequencing.
 func (h *HomepageHandler) HeroCreate(c echo.Context) error {
+	// A background image needs alt text; otherwise the form is shown again
+	if msg := altTextRequired(c.FormValue("background_image") != "", c.FormValue("background_image_alt"), "background image"); msg != "" {
+		return h.heroForm(c, "New Hero", heroFromForm(c, 0), msg)
+	}
+
 	// Parse display_order and is_active checkbox from form
 	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
 	var isActive int64
@@ -95,19 +101,20 @@ func (h *HomepageHandler) HeroCreate(c echo.Context) error {
 	// Create new hero record with form data
 	// Optional fields (badge, secondary CTA, background image) use sql.NullString
 	_, err := h.queries.CreateHero(c.Request().Context(), sqlc.CreateHeroParams{
-		Headline:         c.FormValue("headline"),         // Main heading text
-		Subheadline:      c.FormValue("subheadline"),      // Supporting text
-		BadgeText:        sql.NullString{String: c.FormValue("badge_text"), Valid: c.FormValue("badge_text") != ""},       // Optional badge/label
-		PrimaryCtaText:   c.FormValue("primary_cta_text"), // Primary button text (required)
-		PrimaryCtaUrl:    c.FormValue("primary_cta_url"),  // Primary button URL (required)
-		SecondaryCtaText: sql.NullString{String: c.FormValue("secondary_cta_text"), Valid: c.FormValue("secondary_cta_text") != ""}, // Optional secondary button text
-		SecondaryCtaUrl:  sql.NullString{String: c.FormValue("secondary_cta_url"), Valid: c.FormValue("secondary_cta_url") != ""},   // Optional secondary button URL
-		BackgroundImage:  sql.NullString{String: c.FormValue("background_image"), Valid: c.FormValue("background_image") != ""},     // Optional hero background image URL
-		IsActive:         isActive,
-		DisplayOrder:     displayOrder, // Controls carousel order
+		Headline:           c.FormValue("headline"),         // Main heading text
+		Subheadline:        c.FormValue("subheadline"),      // Supporting text
+		BadgeText:          sql.NullString{String: c.FormValue("badge_text"), Valid: c.FormValue("badge_text") != ""},       // Optional badge/label
+		PrimaryCtaText:     c.FormValue("primary_cta_text"), // Primary button text (required)
+		PrimaryCtaUrl:      c.FormValue("primary_cta_url"),  // Primary button URL (required)
+		SecondaryCtaText:   sql.NullString{String: c.FormValue("secondary_cta_text"), Valid: c.FormValue("secondary_cta_text") != ""}, // Optional secondary button text
+		SecondaryCtaUrl:    sql.NullString{String: c.FormValue("secondary_cta_url"), Valid: c.FormValue("secondary_cta_url") != ""},   // Optional secondary button URL
+		BackgroundImage:    sql.NullString{String: c.FormValue("background_image"), Valid: c.FormValue("background_image") != ""},     // Optional hero background image URL
+		BackgroundImageAlt: strings.TrimSpace(c.FormValue("background_image_alt")), // Required with a background image
+		IsActive:           isActive,
+		DisplayOrder:       displayOrder, // Controls carousel order
 	})
 	if err != nil {
-		h.logger.Error("failed to create hero", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create hero", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -124,7 +131,6 @@ func (h *HomepageHandler) HeroCreate(c echo.Context) error {
 // Template: admin/pages/homepage_hero_form.html (full page)
 // HTMX: Not used - returns full page render
 //
-// Query params: saved=1 (shows success message after update)
 func (h *HomepageHandler) HeroEdit(c echo.Context) error {
 	// Parse hero ID from URL parameter
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -132,32 +138,32 @@ func (h *HomepageHandler) HeroEdit(c echo.Context) error {
 	// Fetch the existing hero from database
 	item, err := h.queries.GetHero(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to get hero", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to get hero", "error", err)
 		return echo.NewHTTPError(http.StatusNotFound)
 	}
 
-	// Check for "saved" query parameter to show success message
-	saved := c.QueryParam("saved") == "1"
 
 	// Render the form template with existing hero data
 	return c.Render(http.StatusOK, "admin/pages/homepage_hero_form.html", map[string]interface{}{
 		"Title": "Edit Hero",
 		"Item":  item,
-		"Saved": saved, // Template shows success message if true
 	})
 }
 
 // HeroUpdate processes homepage hero update form submissions.
 // HTTP Method: POST
 // Route: /admin/homepage/heroes/:id
-// Template: None - redirects to GET /admin/homepage/heroes/:id/edit?saved=1
+// Template: None - redirects to GET /admin/homepage/heroes/:id/edit
 // HTMX: Not used - standard form submission with redirect
 //
-// After successful update, redirects back to the edit form with saved=1 query param
+// After successful update, redirects back to the edit form with a success flash message
 // to show a success message while keeping the user on the edit page.
 func (h *HomepageHandler) HeroUpdate(c echo.Context) error {
 	// Parse hero ID and form values
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	if msg := altTextRequired(c.FormValue("background_image") != "", c.FormValue("background_image_alt"), "background image"); msg != "" {
+		return h.heroForm(c, "Edit Hero", heroFromForm(c, id), msg)
+	}
 	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
 	var isActive int64
 	if c.FormValue("is_active") == "on" {
@@ -166,28 +172,65 @@ func (h *HomepageHandler) HeroUpdate(c echo.Context) error {
 
 	// Update the existing hero record with form data
 	err := h.queries.UpdateHero(c.Request().Context(), sqlc.UpdateHeroParams{
-		ID:               id,
-		Headline:         c.FormValue("headline"),
-		Subheadline:      c.FormValue("subheadline"),
-		BadgeText:        sql.NullString{String: c.FormValue("badge_text"), Valid: c.FormValue("badge_text") != ""},
-		PrimaryCtaText:   c.FormValue("primary_cta_text"),
-		PrimaryCtaUrl:    c.FormValue("primary_cta_url"),
-		SecondaryCtaText: sql.NullString{String: c.FormValue("secondary_cta_text"), Valid: c.FormValue("secondary_cta_text") != ""},
-		SecondaryCtaUrl:  sql.NullString{String: c.FormValue("secondary_cta_url"), Valid: c.FormValue("secondary_cta_url") != ""},
-		BackgroundImage:  sql.NullString{String: c.FormValue("background_image"), Valid: c.FormValue("background_image") != ""},
-		IsActive:         isActive,
-		DisplayOrder:     displayOrder,
+		ID:                 id,
+		Headline:           c.FormValue("headline"),
+		Subheadline:        c.FormValue("subheadline"),
+		BadgeText:          sql.NullString{String: c.FormValue("badge_text"), Valid: c.FormValue("badge_text") != ""},
+		PrimaryCtaText:     c.FormValue("primary_cta_text"),
+		PrimaryCtaUrl:      c.FormValue("primary_cta_url"),
+		SecondaryCtaText:   sql.NullString{String: c.FormValue("secondary_cta_text"), Valid: c.FormValue("secondary_cta_text") != ""},
+		SecondaryCtaUrl:    sql.NullString{String: c.FormValue("secondary_cta_url"), Valid: c.FormValue("secondary_cta_url") != ""},
+		BackgroundImage:    sql.NullString{String: c.FormValue("background_image"), Valid: c.FormValue("background_image") != ""},
+		BackgroundImageAlt: strings.TrimSpace(c.FormValue("background_image_alt")),
+		IsActive:           isActive,
+		DisplayOrder:       displayOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to update hero", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update hero", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log the update activity
 	logActivity(c, "updated", "hero", id, c.FormValue("headline"), "Updated Hero '%s'", c.FormValue("headline"))
 
-	// Redirect back to edit form with saved=1 to show success message
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/heroes/%d/edit?saved=1", id))
+	// Redirect back; the page shows the flash message
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Hero saved.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/heroes/%d/edit", id))
+}
+
+// heroFromForm returns the submitted hero form as a hero with the given ID,
+// to show the form again after a validation error.
+func heroFromForm(c echo.Context, id int64) sqlc.HomepageHero {
+	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+	optional := func(name string) sql.NullString {
+		return sql.NullString{String: c.FormValue(name), Valid: c.FormValue(name) != ""}
+	}
+	hero := sqlc.HomepageHero{
+		ID:                 id,
+		Headline:           c.FormValue("headline"),
+		Subheadline:        c.FormValue("subheadline"),
+		BadgeText:          optional("badge_text"),
+		PrimaryCtaText:     c.FormValue("primary_cta_text"),
+		PrimaryCtaUrl:      c.FormValue("primary_cta_url"),
+		SecondaryCtaText:   optional("secondary_cta_text"),
+		SecondaryCtaUrl:    optional("secondary_cta_url"),
+		BackgroundImage:    optional("background_image"),
+		BackgroundImageAlt: c.FormValue("background_image_alt"),
+		DisplayOrder:       displayOrder,
+	}
+	if c.FormValue("is_active") == "on" {
+		hero.IsActive = 1
+	}
+	return hero
+}
+
+// heroForm shows the hero form again with a validation error.
+func (h *HomepageHandler) heroForm(c echo.Context, title string, item sqlc.HomepageHero, errMsg string) error {
+	return c.Render(http.StatusUnprocessableEntity, "admin/pages/homepage_hero_form.html", map[string]interface{}{
+		"Title": title,
+		"Item":  item,
+		"Error": errMsg,
+	})
 }
 
 // HeroDelete handles deletion of a homepage hero.
@@ -202,7 +245,7 @@ func (h *HomepageHandler) HeroDelete(c echo.Context) error {
 	// Delete the hero from database
 	err := h.queries.DeleteHero(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to delete hero", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete hero", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -227,7 +270,7 @@ func (h *HomepageHandler) StatsList(c echo.Context) error {
 	// Fetch all stats from database, ordered by display_order
 	items, err := h.queries.ListAllStats(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list stats", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list stats", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	return c.Render(http.StatusOK, "admin/pages/homepage_stats_list.html", map[string]interface{}{
@@ -262,7 +305,7 @@ func (h *HomepageHandler) StatCreate(c echo.Context) error {
 		IsActive:     isActive,
 	})
 	if err != nil {
-		h.logger.Error("failed to create stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create stat", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "created", "stat", 0, c.FormValue("stat_label"), "Created Stat '%s'", c.FormValue("stat_label"))
@@ -270,25 +313,23 @@ func (h *HomepageHandler) StatCreate(c echo.Context) error {
 }
 
 // StatEdit displays the form for editing an existing stat.
-// Route pattern follows: GET /admin/homepage/stats/:id/edit?saved=1
+// Route pattern follows: GET /admin/homepage/stats/:id/edit
 func (h *HomepageHandler) StatEdit(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	item, err := h.queries.GetStat(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to get stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to get stat", "error", err)
 		return echo.NewHTTPError(http.StatusNotFound)
 	}
-	saved := c.QueryParam("saved") == "1"
 	return c.Render(http.StatusOK, "admin/pages/homepage_stat_form.html", map[string]interface{}{
 		"Title": "Edit Stat",
 		"Item":  item,
-		"Saved": saved,
 	})
 }
 
 // StatUpdate processes stat update form submissions.
 // Route pattern follows: POST /admin/homepage/stats/:id
-// Redirects to edit form with saved=1 after successful update.
+// Redirects to edit form with a success flash message after successful update.
 func (h *HomepageHandler) StatUpdate(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
@@ -304,11 +345,12 @@ func (h *HomepageHandler) StatUpdate(c echo.Context) error {
 		IsActive:     isActive,
 	})
 	if err != nil {
-		h.logger.Error("failed to update stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update stat", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "updated", "stat", id, c.FormValue("stat_label"), "Updated Stat '%s'", c.FormValue("stat_label"))
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/stats/%d/edit?saved=1", id))
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Stat saved.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/stats/%d/edit", id))
 }
 
 // StatDelete handles deletion of a stat.
@@ -318,7 +360,7 @@ func (h *HomepageHandler) StatDelete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	err := h.queries.DeleteStat(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to delete stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete stat", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "deleted", "stat", id, "", "Deleted Stat #%d", id)
@@ -335,7 +377,7 @@ func (h *HomepageHandler) StatDelete(c echo.Context) error {
 // - New: GET /admin/homepage/testimonials/new - empty form
 // - Create: POST /admin/homepage/testimonials - creates and redirects to list
 // - Edit: GET /admin/homepage/testimonials/:id/edit - form with saved query param
-// - Update: POST /admin/homepage/testimonials/:id - updates and redirects to edit?saved=1
+// - Update: POST /admin/homepage/testimonials/:id - updates and redirects to edit with a success flash message
 // - Delete: DELETE /admin/homepage/testimonials/:id - HTMX delete with no content response
 
 // TestimonialsList displays all homepage testimonials in a list view.
@@ -346,7 +388,7 @@ func (h *HomepageHandler) StatDelete(c echo.Context) error {
 func (h *HomepageHandler) TestimonialsList(c echo.Context) error {
 	items, err := h.queries.ListAllTestimonialsHomepage(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list testimonials", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list testimonials", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	return c.Render(http.StatusOK, "admin/pages/homepage_testimonials_list.html", map[string]interface{}{
@@ -363,6 +405,9 @@ func (h *HomepageHandler) TestimonialNew(c echo.Context) error {
 }
 
 func (h *HomepageHandler) TestimonialCreate(c echo.Context) error {
+	if msg := altTextRequired(c.FormValue("author_image") != "", c.FormValue("author_image_alt"), "author image"); msg != "" {
+		return h.testimonialForm(c, "New Testimonial", testimonialFromForm(c, 0), msg)
+	}
 	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
 	rating, _ := strconv.ParseInt(c.FormValue("rating"), 10, 64)
 	var isActive int64
@@ -370,17 +415,18 @@ func (h *HomepageHandler) TestimonialCreate(c echo.Context) error {
 		isActive = 1
 	}
 	_, err := h.queries.CreateTestimonialHomepage(c.Request().Context(), sqlc.CreateTestimonialHomepageParams{
-		Quote:         c.FormValue("quote"),
-		AuthorName:    c.FormValue("author_name"),
-		AuthorTitle:   sql.NullString{String: c.FormValue("author_title"), Valid: c.FormValue("author_title") != ""},
-		AuthorCompany: sql.NullString{String: c.FormValue("author_company"), Valid: c.FormValue("author_company") != ""},
-		AuthorImage:   sql.NullString{String: c.FormValue("author_image"), Valid: c.FormValue("author_image") != ""},
-		Rating:        rating,
-		DisplayOrder:  displayOrder,
-		IsActive:      isActive,
+		Quote:          c.FormValue("quote"),
+		AuthorName:     c.FormValue("author_name"),
+		AuthorTitle:    sql.NullString{String: c.FormValue("author_title"), Valid: c.FormValue("author_title") != ""},
+		AuthorCompany:  sql.NullString{String: c.FormValue("author_company"), Valid: c.FormValue("author_company") != ""},
+		AuthorImage:    sql.NullString{String: c.FormValue("author_image"), Valid: c.FormValue("author_image") != ""},
+		AuthorImageAlt: strings.TrimSpace(c.FormValue("author_image_alt")),
+		Rating:         rating,
+		DisplayOrder:   displayOrder,
+		IsActive:       isActive,
 	})
 	if err != nil {
-		h.logger.Error("failed to create testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "created", "testimonial", 0, c.FormValue("author_name"), "Created Testimonial by '%s'", c.FormValue("author_name"))
@@ -391,19 +437,20 @@ func (h *HomepageHandler) TestimonialEdit(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	item, err := h.queries.GetTestimonialHomepage(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to get testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to get testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusNotFound)
 	}
-	saved := c.QueryParam("saved") == "1"
 	return c.Render(http.StatusOK, "admin/pages/homepage_testimonial_form.html", map[string]interface{}{
 		"Title": "Edit Testimonial",
 		"Item":  item,
-		"Saved": saved,
 	})
 }
 
 func (h *HomepageHandler) TestimonialUpdate(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	if msg := altTextRequired(c.FormValue("author_image") != "", c.FormValue("author_image_alt"), "author image"); msg != "" {
+		return h.testimonialForm(c, "Edit Testimonial", testimonialFromForm(c, id), msg)
+	}
 	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
 	rating, _ := strconv.ParseInt(c.FormValue("rating"), 10, 64)
 	var isActive int64
@@ -411,29 +458,66 @@ func (h *HomepageHandler) TestimonialUpdate(c echo.Context) error {
 		isActive = 1
 	}
 	err := h.queries.UpdateTestimonialHomepage(c.Request().Context(), sqlc.UpdateTestimonialHomepageParams{
-		ID:            id,
-		Quote:         c.FormValue("quote"),
-		AuthorName:    c.FormValue("author_name"),
-		AuthorTitle:   sql.NullString{String: c.FormValue("author_title"), Valid: c.FormValue("author_title") != ""},
-		AuthorCompany: sql.NullString{String: c.FormValue("author_company"), Valid: c.FormValue("author_company") != ""},
-		AuthorImage:   sql.NullString{String: c.FormValue("author_image"), Valid: c.FormValue("author_image") != ""},
-		Rating:        rating,
-		DisplayOrder:  displayOrder,
-		IsActive:      isActive,
+		ID:             id,
+		Quote:          c.FormValue("quote"),
+		AuthorName:     c.FormValue("author_name"),
+		AuthorTitle:    sql.NullString{String: c.FormValue("author_title"), Valid: c.FormValue("author_title") != ""},
+		AuthorCompany:  sql.NullString{String: c.FormValue("author_company"), Valid: c.FormValue("author_company") != ""},
+		AuthorImage:    sql.NullString{String: c.FormValue("author_image"), Valid: c.FormValue("author_image") != ""},
+		AuthorImageAlt: strings.TrimSpace(c.FormValue("author_image_alt")),
+		Rating:         rating,
+		DisplayOrder:   displayOrder,
+		IsActive:       isActive,
 	})
 	if err != nil {
-		h.logger.Error("failed to update testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "updated", "testimonial", id, c.FormValue("author_name"), "Updated Testimonial by '%s'", c.FormValue("author_name"))
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/testimonials/%d/edit?saved=1", id))
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Testimonial saved.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/testimonials/%d/edit", id))
+}
+
+// testimonialFromForm returns the submitted testimonial form as a
+// testimonial with the given ID, to show the form again after a validation
+// error.
+func testimonialFromForm(c echo.Context, id int64) sqlc.HomepageTestimonial {
+	displayOrder, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+	rating, _ := strconv.ParseInt(c.FormValue("rating"), 10, 64)
+	optional := func(name string) sql.NullString {
+		return sql.NullString{String: c.FormValue(name), Valid: c.FormValue(name) != ""}
+	}
+	t := sqlc.HomepageTestimonial{
+		ID:             id,
+		Quote:          c.FormValue("quote"),
+		AuthorName:     c.FormValue("author_name"),
+		AuthorTitle:    optional("author_title"),
+		AuthorCompany:  optional("author_company"),
+		AuthorImage:    optional("author_image"),
+		AuthorImageAlt: c.FormValue("author_image_alt"),
+		Rating:         rating,
+		DisplayOrder:   displayOrder,
+	}
+	if c.FormValue("is_active") == "on" {
+		t.IsActive = 1
+	}
+	return t
+}
+
+// testimonialForm shows the testimonial form again with a validation error.
+func (h *HomepageHandler) testimonialForm(c echo.Context, title string, item sqlc.HomepageTestimonial, errMsg string) error {
+	return c.Render(http.StatusUnprocessableEntity, "admin/pages/homepage_testimonial_form.html", map[string]interface{}{
+		"Title": title,
+		"Item":  item,
+		"Error": errMsg,
+	})
 }
 
 func (h *HomepageHandler) TestimonialDelete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	err := h.queries.DeleteTestimonialHomepage(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to delete testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "deleted", "testimonial", id, "", "Deleted Testimonial #%d", id)
@@ -450,7 +534,7 @@ func (h *HomepageHandler) TestimonialDelete(c echo.Context) error {
 // - New: GET /admin/homepage/cta/new - empty form
 // - Create: POST /admin/homepage/cta - creates and redirects to list
 // - Edit: GET /admin/homepage/cta/:id/edit - form with saved query param
-// - Update: POST /admin/homepage/cta/:id - updates and redirects to edit?saved=1
+// - Update: POST /admin/homepage/cta/:id - updates and redirects to edit with a success flash message
 // - Delete: DELETE /admin/homepage/cta/:id - HTMX delete with no content response
 
 // CTAList displays all homepage CTAs in a list view.
@@ -461,7 +545,7 @@ func (h *HomepageHandler) TestimonialDelete(c echo.Context) error {
 func (h *HomepageHandler) CTAList(c echo.Context) error {
 	items, err := h.queries.ListAllCTAs(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list CTAs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list CTAs", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	return c.Render(http.StatusOK, "admin/pages/homepage_cta_list.html", map[string]interface{}{
@@ -493,7 +577,7 @@ func (h *HomepageHandler) CTACreate(c echo.Context) error {
 		IsActive:         isActive,
 	})
 	if err != nil {
-		h.logger.Error("failed to create CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create CTA", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "created", "cta", 0, c.FormValue("headline"), "Created CTA '%s'", c.FormValue("headline"))
@@ -504,14 +588,12 @@ func (h *HomepageHandler) CTAEdit(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	item, err := h.queries.GetCTA(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to get CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to get CTA", "error", err)
 		return echo.NewHTTPError(http.StatusNotFound)
 	}
-	saved := c.QueryParam("saved") == "1"
 	return c.Render(http.StatusOK, "admin/pages/homepage_cta_form.html", map[string]interface{}{
 		"Title": "Edit CTA",
 		"Item":  item,
-		"Saved": saved,
 	})
 }
 
@@ -533,18 +615,19 @@ func (h *HomepageHandler) CTAUpdate(c echo.Context) error {
 		IsActive:         isActive,
 	})
 	if err != nil {
-		h.logger.Error("failed to update CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update CTA", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "updated", "cta", id, c.FormValue("headline"), "Updated CTA '%s'", c.FormValue("headline"))
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/cta/%d/edit?saved=1", id))
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Call to action saved.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/homepage/cta/%d/edit", id))
 }
 
 func (h *HomepageHandler) CTADelete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	err := h.queries.DeleteCTA(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to delete CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete CTA", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "deleted", "cta", id, "", "Deleted CTA #%d", id)
@@ -562,31 +645,27 @@ func (h *HomepageHandler) CTADelete(c echo.Context) error {
 // Template: admin/pages/homepage_settings.html (full page)
 // HTMX: Not used - returns full page render
 //
-// Query params: saved=1 (shows success message after update)
 // This is a singleton record - only one settings record exists in the database.
 func (h *HomepageHandler) Settings(c echo.Context) error {
 	// Fetch the single homepage settings record from database
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Check for "saved" query parameter to show success message
-	saved := c.QueryParam("saved") == "1"
 
 	// Render the settings form template
 	return c.Render(http.StatusOK, "admin/pages/homepage_settings.html", map[string]interface{}{
 		"Title":    "Homepage Settings",
 		"Settings": settings,
-		"Saved":    saved, // Template shows success message if true
 	})
 }
 
 // UpdateSettings processes homepage settings form submissions.
 // HTTP Method: POST
 // Route: /admin/homepage/settings
-// Template: None - redirects to GET /admin/homepage/settings?saved=1
+// Template: None - redirects to GET /admin/homepage/settings
 // HTMX: Not used - standard form submission with redirect
 //
 // This handler updates the homepage settings singleton record, controlling:
@@ -629,13 +708,14 @@ func (h *HomepageHandler) UpdateSettings(c echo.Context) error {
 		HomepageHeroInterval:     parseIntField("homepage_hero_interval", 5),      // Autoplay interval in seconds (default: 5)
 	})
 	if err != nil {
-		h.logger.Error("failed to update homepage settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update homepage settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log the settings update activity
 	logActivity(c, "updated", "homepage_settings", 0, "", "Updated Homepage Settings")
 
-	// Redirect back to settings page with saved=1 to show success message
-	return c.Redirect(http.StatusSeeOther, "/admin/homepage/settings?saved=1")
+	// Redirect back; the page shows the flash message
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Homepage settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/homepage/settings")
 }
@@ -0,0 +1,157 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file keeps the HTMX sub-resource editors (specs, variants, solution
+// tabs, case study metrics, tag chips...) usable when JavaScript is disabled
+// or blocked.
+package admin
+
+import (
+	"bytes"         // Buffering the handler's fragment
+	"html/template" // Embedding the fragment in the fallback page
+	"net/http"      // HTTP status codes and the captured ResponseWriter
+	"net/url"       // Escaping route params and reading the Referer
+	"strings"       // Route pattern expansion
+
+	"github.com/labstack/echo/v4"                                              // Echo web framework for routing and context
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // IsHTMX
+)
+
+// Fallback says where a browser without HTMX goes for one sub-resource
+// route. Patterns are route paths; their :params are filled from the request.
+type Fallback struct {
+	Title string // Heading of the standalone page rendered for GET routes, e.g. "Product Specifications"
+	List  string // Page listing the sub-resource, e.g. "/admin/products/:id/specs"
+	Back  string // Parent editor, e.g. "/admin/products/:id/edit"
+}
+
+// fallbackFormErrorKey marks a response that re-rendered its form with a
+// validation error (see showFallbackForm).
+const fallbackFormErrorKey = "htmx_fallback_form_error"
+
+// HTMXFallback returns route middleware for endpoints that answer HTMX with
+// an HTML fragment. HTMX requests (HX-Request: true) are untouched. Other
+// requests come from plain links and forms, and would otherwise land on a
+// bare fragment:
+//   - GET renders the fragment inside the admin layout (titled f.Title, with
+//     a link back to f.Back), so tabs loaded by hx-get can be opened directly
+//   - POST, PUT and DELETE follow Post/Redirect/Get: the fragment is dropped
+//     and the browser is sent (303) to f.List, else f.Back, else the admin
+//     page it came from. A form re-rendered with a validation error is shown
+//     as a page instead (422), and handlers that already redirect keep their
+//     redirect
+//   - Error responses pass through unchanged
+//
+// Forms reach DELETE and PUT routes with the _method field (see
+// middleware.MethodOverride).
+//
+// Example usage:
+//
+//	specs := admin.HTMXFallback(admin.Fallback{Title: "Specifications", List: "/admin/products/:id/specs", Back: "/admin/products/:id/edit"})
+//	adminGroup.GET("/products/:id/specs", pdHandler.ListSpecs, specs)
+//	adminGroup.POST("/products/:id/specs", pdHandler.AddSpec, specs)
+func HTMXFallback(f Fallback) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if customMiddleware.IsHTMX(c) {
+				return next(c)
+			}
+
+			orig := c.Response()
+			buf := &fragmentWriter{header: make(http.Header)}
+			c.SetResponse(echo.NewResponse(buf, c.Echo()))
+			err := next(c)
+			status := c.Response().Status
+			c.SetResponse(orig)
+			if err != nil {
+				return err
+			}
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			formError, _ := c.Get(fallbackFormErrorKey).(bool)
+			switch {
+			case status >= http.StatusMultipleChoices && status < http.StatusBadRequest:
+				return c.Redirect(status, buf.header.Get(echo.HeaderLocation))
+			case status >= http.StatusBadRequest:
+				return buf.replay(orig, status)
+			case formError:
+				return f.render(c, http.StatusUnprocessableEntity, buf)
+			case c.Request().Method == http.MethodGet:
+				return f.render(c, http.StatusOK, buf)
+			default:
+				return c.Redirect(http.StatusSeeOther, f.redirectTarget(c))
+			}
+		}
+	}
+}
+
+// showFallbackForm marks the response as a form re-rendered with a
+// validation error, so HTMXFallback shows it to browsers without HTMX instead
+// of redirecting past the message.
+func showFallbackForm(c echo.Context) {
+	c.Set(fallbackFormErrorKey, true)
+}
+
+// render shows the captured fragment inside the admin layout.
+func (f Fallback) render(c echo.Context, status int, buf *fragmentWriter) error {
+	back := fillRoute(c, f.Back)
+	if back == "" {
+		back = fillRoute(c, f.List)
+	}
+	return c.Render(status, "admin/pages/htmx_fallback.html", map[string]interface{}{
+		"Title":   f.Title,
+		"BackURL": back,
+		// The fragment is the output of our own templates, already escaped
+		"Content": template.HTML(buf.body.String()),
+	})
+}
+
+// redirectTarget picks where a completed write sends the browser.
+func (f Fallback) redirectTarget(c echo.Context) string {
+	if f.List != "" {
+		return fillRoute(c, f.List)
+	}
+	if f.Back != "" {
+		return fillRoute(c, f.Back)
+	}
+	// Only the path of the Referer is used, so the redirect stays on this site
+	if ref, err := url.Parse(c.Request().Referer()); err == nil && strings.HasPrefix(ref.Path, "/admin/") {
+		if ref.RawQuery != "" {
+			return ref.Path + "?" + ref.RawQuery
+		}
+		return ref.Path
+	}
+	return "/admin"
+}
+
+// fillRoute replaces the :params of a route pattern with the request's values.
+func fillRoute(c echo.Context, pattern string) string {
+	parts := strings.Split(pattern, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") {
+			parts[i] = url.PathEscape(c.Param(p[1:]))
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// fragmentWriter captures a handler's response so HTMXFallback can decide
+// what the browser gets.
+type fragmentWriter struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *fragmentWriter) Header() http.Header         { return w.header }
+func (w *fragmentWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+func (w *fragmentWriter) WriteHeader(int)             {}
+
+// replay sends the captured response unchanged.
+func (w *fragmentWriter) replay(res *echo.Response, status int) error {
+	for k, v := range w.header {
+		res.Header()[k] = v
+	}
+	res.WriteHeader(status)
+	_, err := res.Write(w.body.Bytes())
+	return err
+}
@@ -0,0 +1,76 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the HTTPS Domains page, which lists the host names the
+// built-in HTTPS server obtains certificates for.
+package admin
+
+import (
+	"log/slog" // Structured logging
+	"net/http" // HTTP status codes
+	"strings"  // Joining the host names
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Host name parsing
+)
+
+// HTTPSDomainsHandler handles the HTTPS host names at /admin/https. They are
+// read by services.TLSHostPolicy when the server runs with TLS_ENABLED or
+// TLS_DOMAINS; TLS_DOMAINS applies while none are saved.
+type HTTPSDomainsHandler struct {
+	queries  sqlc.Querier // Database queries generated by sqlc
+	logger   *slog.Logger // Structured logger for error reporting
+	fallback []string     // TLS_DOMAINS, shown while no host names are saved
+	enabled  bool         // Whether the server serves HTTPS itself
+}
+
+// NewHTTPSDomainsHandler creates a new HTTPSDomainsHandler instance.
+func NewHTTPSDomainsHandler(queries sqlc.Querier, logger *slog.Logger, fallback []string, enabled bool) *HTTPSDomainsHandler {
+	return &HTTPSDomainsHandler{queries: queries, logger: logger, fallback: fallback, enabled: enabled}
+}
+
+// Edit handles GET /admin/https
+// Shows the saved host names and the TLS_DOMAINS fallback.
+// Template: admin/pages/https_domains.html (full page)
+func (h *HTTPSDomainsHandler) Edit(c echo.Context) error {
+	settings, err := h.queries.GetSettings(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.render(c, http.StatusOK, settings.TlsDomains, "")
+}
+
+// Update handles POST /admin/https
+// Saves the domains form field (host names, one per line or separated by
+// commas). An invalid entry is reported on the page and nothing is saved;
+// an empty list falls back to TLS_DOMAINS. New host names get certificates
+// on their first HTTPS request, without a restart.
+func (h *HTTPSDomainsHandler) Update(c echo.Context) error {
+	hosts, err := services.ParseHostNames(c.FormValue("domains"))
+	if err != nil {
+		return h.render(c, http.StatusUnprocessableEntity, c.FormValue("domains"), err.Error())
+	}
+	text := strings.Join(hosts, "\n")
+
+	if err := h.queries.UpdateTLSDomains(c.Request().Context(), text); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update HTTPS domains", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "updated", "https_domains", 0, "", "Updated HTTPS domains (%d host names)", len(hosts))
+
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "HTTPS domains saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/https")
+}
+
+// render shows the HTTPS Domains page with the given host names and error.
+func (h *HTTPSDomainsHandler) render(c echo.Context, status int, domains, errMsg string) error {
+	return c.Render(status, "admin/pages/https_domains.html", map[string]interface{}{
+		"Title":    "HTTPS Domains",
+		"Domains":  domains,
+		"Fallback": strings.Join(h.fallback, ", "),
+		"Enabled":  h.enabled,
+		"Error":    errMsg,
+	})
+}
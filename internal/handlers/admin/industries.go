@@ -12,7 +12,8 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database query methods
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database query methods
+	"github.com/narendhupati/bluejay-cms/internal/services" // Unique slug generation
 )
 
 // IndustriesHandler manages HTTP requests for industry CRUD operations.
@@ -20,14 +21,14 @@ import (
 // partners, or other entities (e.g., "Healthcare", "Finance", "Manufacturing").
 // It depends on database queries and structured logging.
 type IndustriesHandler struct {
-	queries *sqlc.Queries  // Database query interface generated by sqlc
-	logger  *slog.Logger   // Structured logger for error tracking and debugging
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error tracking and debugging
 }
 
 // NewIndustriesHandler constructs a new IndustriesHandler with required dependencies.
 // This constructor is called during application initialization to wire up the handler
 // with database access and logging capabilities.
-func NewIndustriesHandler(queries *sqlc.Queries, logger *slog.Logger) *IndustriesHandler {
+func NewIndustriesHandler(queries sqlc.Querier, logger *slog.Logger) *IndustriesHandler {
 	return &IndustriesHandler{queries: queries, logger: logger}
 }
 
@@ -46,7 +47,7 @@ func (h *IndustriesHandler) List(c echo.Context) error {
 	// Fetch all industries from the database (ordered by sort_order)
 	items, err := h.queries.ListIndustries(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list industries", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list industries", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -92,7 +93,7 @@ func (h *IndustriesHandler) New(c echo.Context) error {
 //
 // Business Logic:
 //   - Converts form string values to appropriate types (int64 for sort_order)
-//   - Auto-generates slug from name using makeSlug helper function
+//   - Auto-generates slug from name using uniqueSlug
 //   - Slug is used for URL-friendly industry identification
 //   - Logs admin activity for audit trail
 //   - Redirects to industries list on success (303 See Other)
@@ -100,16 +101,22 @@ func (h *IndustriesHandler) Create(c echo.Context) error {
 	// Convert sort_order from string to int64
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugIndustries, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate industry slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Create new industry with form data
-	_, err := h.queries.CreateIndustry(c.Request().Context(), sqlc.CreateIndustryParams{
+	_, err = h.queries.CreateIndustry(c.Request().Context(), sqlc.CreateIndustryParams{
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),  // Auto-generate URL-friendly slug from name
+		Slug:        slug,                           // Auto-generate URL-friendly slug from name
 		Icon:        c.FormValue("icon"),
 		Description: c.FormValue("description"),
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to create industry", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create industry", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -172,7 +179,7 @@ func (h *IndustriesHandler) Edit(c echo.Context) error {
 //
 // Business Logic:
 //   - Converts form string values to appropriate types (int64 for sort_order)
-//   - Auto-generates slug from name using makeSlug helper function (slug updates with name)
+//   - Auto-generates slug from name using uniqueSlug (slug updates with name)
 //   - Logs admin activity for audit trail
 //   - Redirects to industries list on success (303 See Other)
 func (h *IndustriesHandler) Update(c echo.Context) error {
@@ -182,17 +189,23 @@ func (h *IndustriesHandler) Update(c echo.Context) error {
 	// Convert sort_order from string to int64
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugIndustries, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate industry slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Update industry with form data
-	_, err := h.queries.UpdateIndustry(c.Request().Context(), sqlc.UpdateIndustryParams{
+	_, err = h.queries.UpdateIndustry(c.Request().Context(), sqlc.UpdateIndustryParams{
 		ID:          id,
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),  // Regenerate slug from updated name
+		Slug:        slug,                           // Regenerate slug from updated name
 		Icon:        c.FormValue("icon"),
 		Description: c.FormValue("description"),
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to update industry", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update industry", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -225,7 +238,7 @@ func (h *IndustriesHandler) Delete(c echo.Context) error {
 	// Delete industry from database (hard delete)
 	// This will fail if any content references this industry due to foreign key constraints
 	if err := h.queries.DeleteIndustry(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete industry", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete industry", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -0,0 +1,90 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the scheduled jobs page: each recurring job's schedule
+// and last run, and a button to run it now.
+package admin
+
+import (
+	// Standard library imports
+	"errors"   // Matching scheduler errors
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"net/url"  // Escaping job names in redirects
+	"time"     // Rounding run durations
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/jobs" // Job scheduler
+)
+
+// JobsHandler serves /admin/jobs. The jobs are registered with the
+// scheduler in main; the routes are limited to admins.
+type JobsHandler struct {
+	logger    *slog.Logger    // Structured logger for error tracking
+	scheduler *jobs.Scheduler // Registered jobs and their last runs
+}
+
+// NewJobsHandler constructs a new JobsHandler.
+func NewJobsHandler(logger *slog.Logger, scheduler *jobs.Scheduler) *JobsHandler {
+	return &JobsHandler{logger: logger, scheduler: scheduler}
+}
+
+// jobRow is a job on the jobs page.
+type jobRow struct {
+	jobs.Status
+	Duration string // Rounded length of the last run
+}
+
+// List handles GET /admin/jobs
+// Lists every job with its schedule, next run and last run. While a job is
+// running the table polls itself until the run has finished.
+// Template: admin/pages/jobs.html (full page)
+//
+// Query parameters (set by the redirect after RunNow):
+//   - started: Name of the job that was started
+func (h *JobsHandler) List(c echo.Context) error {
+	return h.renderList(c, http.StatusOK, "")
+}
+
+// RunNow handles POST /admin/jobs/:name/run
+// Starts the job in the background and returns to the list.
+//
+// Path parameters:
+//   - name: Job name
+func (h *JobsHandler) RunNow(c echo.Context) error {
+	name := c.Param("name")
+	err := h.scheduler.RunNow(name)
+	switch {
+	case errors.Is(err, jobs.ErrUnknownJob):
+		return echo.NewHTTPError(http.StatusNotFound, "Job not found")
+	case errors.Is(err, jobs.ErrJobRunning):
+		return h.renderList(c, http.StatusConflict, name+" is already running.")
+	case err != nil:
+		h.logger.ErrorContext(c.Request().Context(), "failed to start job", "job", name, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "started", "job", 0, name, "Started job %s", name)
+	return c.Redirect(http.StatusSeeOther, "/admin/jobs?started="+url.QueryEscape(name))
+}
+
+// renderList renders the jobs page with an optional error banner.
+func (h *JobsHandler) renderList(c echo.Context, status int, errMsg string) error {
+	statuses := h.scheduler.Jobs()
+	rows := make([]jobRow, len(statuses))
+	running := false
+	for i, s := range statuses {
+		rows[i] = jobRow{Status: s}
+		if !s.LastStart.IsZero() {
+			rows[i].Duration = s.LastDuration.Round(time.Millisecond).String()
+		}
+		running = running || s.Running
+	}
+	return c.Render(status, "admin/pages/jobs.html", map[string]interface{}{
+		"Title":   "Scheduled Jobs",
+		"Jobs":    rows,
+		"Running": running,
+		"Error":   errMsg,
+		"Started": c.QueryParam("started"),
+	})
+}
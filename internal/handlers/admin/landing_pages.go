@@ -0,0 +1,512 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the landing page builder: campaign pages at arbitrary
+// slugs with a layout, SEO fields, analytics snippets, publish status and an
+// ordered list of sections (hero, text, lead form, testimonials).
+package admin
+
+import (
+	"database/sql"  // sql.ErrNoRows detection
+	"encoding/json" // Reorder request body
+	"errors"        // Error inspection
+	"log/slog"      // Structured logging
+	"net/http"      // HTTP status codes
+	"strconv"       // Parsing IDs
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Validation, content blocks and page cache
+)
+
+// landingSectionRow is one section on the page form with its type's name.
+type landingSectionRow struct {
+	sqlc.LandingPageSection
+	TypeLabel string // e.g. "Lead form"
+}
+
+// LandingPagesHandler handles the landing page builder at /admin/landing-pages.
+type LandingPagesHandler struct {
+	queries sqlc.Querier            // Database queries generated by sqlc
+	logger  *slog.Logger            // Structured logger for error reporting
+	blocks  *services.ContentBlocks // Page blocks, reloaded when a page's slug changes
+	cache   *services.Cache         // Public page cache, cleared after each change
+}
+
+// NewLandingPagesHandler creates a new LandingPagesHandler instance.
+func NewLandingPagesHandler(queries sqlc.Querier, logger *slog.Logger, blocks *services.ContentBlocks, cache *services.Cache) *LandingPagesHandler {
+	return &LandingPagesHandler{queries: queries, logger: logger, blocks: blocks, cache: cache}
+}
+
+// List handles GET /admin/landing-pages
+// Lists every landing page with its status and number of sections.
+// Template: admin/pages/landing_pages.html (full page)
+func (h *LandingPagesHandler) List(c echo.Context) error {
+	pages, err := h.queries.ListLandingPages(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list landing pages", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/landing_pages.html", map[string]interface{}{
+		"Title": "Landing Pages",
+		"Pages": pages,
+	})
+}
+
+// New handles GET /admin/landing-pages/new
+// Template: admin/pages/landing_page_form.html (full page)
+func (h *LandingPagesHandler) New(c echo.Context) error {
+	return h.renderForm(c, http.StatusOK, sqlc.LandingPage{Layout: services.LandingLayoutDefault}, "")
+}
+
+// Create handles POST /admin/landing-pages
+// Adds a draft landing page and opens it to add sections. Invalid input is
+// reported on the form.
+func (h *LandingPagesHandler) Create(c echo.Context) error {
+	params, msg := h.form(c)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, landingPageFrom(params, sqlc.LandingPage{}), msg)
+	}
+	page, err := h.queries.CreateLandingPage(c.Request().Context(), params)
+	if isUniqueViolation(err) {
+		return h.renderForm(c, http.StatusUnprocessableEntity, landingPageFrom(params, sqlc.LandingPage{}), landingSlugTaken)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create landing page", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "landing_page", page.ID, page.Title, "Created landing page %q at /%s", page.Title, page.Slug)
+	return c.Redirect(http.StatusSeeOther, "/admin/landing-pages/"+strconv.FormatInt(page.ID, 10)+"/edit")
+}
+
+// Edit handles GET /admin/landing-pages/:id/edit
+// Shows the page settings with its sections for adding, editing and
+// reordering by drag and drop.
+// Template: admin/pages/landing_page_form.html (full page)
+func (h *LandingPagesHandler) Edit(c echo.Context) error {
+	page, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	return h.renderForm(c, http.StatusOK, page, "")
+}
+
+// Update handles POST /admin/landing-pages/:id
+// Saves the page settings. When the slug changes, the library blocks
+// attached to the old path move with the page.
+func (h *LandingPagesHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	page, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	params, msg := h.form(c)
+	if msg == "" {
+		err = h.queries.UpdateLandingPage(ctx, sqlc.UpdateLandingPageParams{
+			Title:           params.Title,
+			Slug:            params.Slug,
+			Layout:          params.Layout,
+			MetaTitle:       params.MetaTitle,
+			MetaDescription: params.MetaDescription,
+			OgImage:         params.OgImage,
+			Noindex:         params.Noindex,
+			HeadSnippet:     params.HeadSnippet,
+			BodySnippet:     params.BodySnippet,
+			ID:              page.ID,
+		})
+		if isUniqueViolation(err) {
+			msg = landingSlugTaken
+		}
+	}
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, landingPageFrom(params, page), msg)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update landing page", "error", err, "id", page.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if params.Slug != page.Slug {
+		err := h.queries.MovePageBlocks(ctx, sqlc.MovePageBlocksParams{NewRoute: "/" + params.Slug, OldRoute: "/" + page.Slug})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to move landing page blocks", "error", err, "id", page.ID)
+		} else if h.blocks != nil {
+			if err := h.blocks.Reload(ctx); err != nil {
+				h.logger.ErrorContext(ctx, "failed to reload content blocks", "error", err)
+			}
+		}
+	}
+	h.changed()
+	logActivity(c, "updated", "landing_page", page.ID, params.Title, "Updated landing page %q", params.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/landing-pages/"+strconv.FormatInt(page.ID, 10)+"/edit")
+}
+
+// Publish handles POST /admin/landing-pages/:id/publish
+// Puts the page live at /<slug>.
+func (h *LandingPagesHandler) Publish(c echo.Context) error {
+	return h.setStatus(c, services.LandingPublished)
+}
+
+// Unpublish handles POST /admin/landing-pages/:id/unpublish
+// Takes the page down; visitors get the 404 page again.
+func (h *LandingPagesHandler) Unpublish(c echo.Context) error {
+	return h.setStatus(c, services.LandingDraft)
+}
+
+// Delete handles DELETE /admin/landing-pages/:id
+// Removes a page and its sections. HTMX: returns an empty 200 response and
+// the row is removed.
+func (h *LandingPagesHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteLandingPage(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete landing page", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "deleted", "landing_page", id, "", "Deleted landing page #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// NewSection handles GET /admin/landing-pages/:id/sections/new?type=
+// Template: admin/pages/landing_section_form.html (full page)
+func (h *LandingPagesHandler) NewSection(c echo.Context) error {
+	page, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	sectionType := c.QueryParam("type")
+	if services.LandingSectionLabel(sectionType) == sectionType {
+		sectionType = services.SectionHero
+	}
+	return h.renderSectionForm(c, http.StatusOK, page, sqlc.LandingPageSection{SectionType: sectionType}, "")
+}
+
+// CreateSection handles POST /admin/landing-pages/:id/sections
+// Adds a section at the end of the page.
+func (h *LandingPagesHandler) CreateSection(c echo.Context) error {
+	ctx := c.Request().Context()
+	page, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	params, msg := h.sectionForm(c, c.FormValue("section_type"))
+	params.LandingPageID = page.ID
+	if msg != "" {
+		return h.renderSectionForm(c, http.StatusUnprocessableEntity, page, landingSectionFrom(params, 0), msg)
+	}
+	sections, err := h.queries.ListLandingPageSections(ctx, page.ID)
+	if err == nil {
+		params.SortOrder = int64(len(sections))
+		_, err = h.queries.CreateLandingPageSection(ctx, params)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create landing page section", "error", err, "id", page.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.sectionsChanged(c, page.ID)
+	logActivity(c, "updated", "landing_page", page.ID, page.Title, "Added a %s section to landing page %q", services.LandingSectionLabel(params.SectionType), page.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/landing-pages/"+strconv.FormatInt(page.ID, 10)+"/edit")
+}
+
+// EditSection handles GET /admin/landing-pages/:id/sections/:sid/edit
+// Template: admin/pages/landing_section_form.html (full page)
+func (h *LandingPagesHandler) EditSection(c echo.Context) error {
+	page, section, err := h.section(c)
+	if err != nil {
+		return err
+	}
+	return h.renderSectionForm(c, http.StatusOK, page, section, "")
+}
+
+// UpdateSection handles POST /admin/landing-pages/:id/sections/:sid
+// Saves a section; its type and position stay as they are.
+func (h *LandingPagesHandler) UpdateSection(c echo.Context) error {
+	page, section, err := h.section(c)
+	if err != nil {
+		return err
+	}
+	params, msg := h.sectionForm(c, section.SectionType)
+	if msg != "" {
+		return h.renderSectionForm(c, http.StatusUnprocessableEntity, page, landingSectionFrom(params, section.ID), msg)
+	}
+	err = h.queries.UpdateLandingPageSection(c.Request().Context(), sqlc.UpdateLandingPageSectionParams{
+		Heading:    params.Heading,
+		Body:       params.Body,
+		Image:      params.Image,
+		ButtonText: params.ButtonText,
+		ButtonUrl:  params.ButtonUrl,
+		Items:      params.Items,
+		ID:         section.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update landing page section", "error", err, "id", section.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.sectionsChanged(c, page.ID)
+	logActivity(c, "updated", "landing_page", page.ID, page.Title, "Updated a %s section of landing page %q", services.LandingSectionLabel(section.SectionType), page.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/landing-pages/"+strconv.FormatInt(page.ID, 10)+"/edit")
+}
+
+// DeleteSection handles DELETE /admin/landing-pages/:id/sections/:sid
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *LandingPagesHandler) DeleteSection(c echo.Context) error {
+	page, section, err := h.section(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeleteLandingPageSection(c.Request().Context(), section.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete landing page section", "error", err, "id", section.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.sectionsChanged(c, page.ID)
+	logActivity(c, "updated", "landing_page", page.ID, page.Title, "Removed a %s section from landing page %q", services.LandingSectionLabel(section.SectionType), page.Title)
+	return c.NoContent(http.StatusOK)
+}
+
+// ReorderSections handles POST /admin/landing-pages/:id/sections/reorder
+// Saves the order of a page's sections after a drag and drop. The JSON body
+// is an array of {"id": section ID, "order": position}; sections of other
+// pages are left alone.
+func (h *LandingPagesHandler) ReorderSections(c echo.Context) error {
+	ctx := c.Request().Context()
+	page, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	var items []struct {
+		ID    int64 `json:"id"`
+		Order int64 `json:"order"`
+	}
+	if err := json.NewDecoder(c.Request().Body).Decode(&items); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid JSON")
+	}
+	for _, item := range items {
+		err := h.queries.UpdateLandingPageSectionOrder(ctx, sqlc.UpdateLandingPageSectionOrderParams{SortOrder: item.Order, ID: item.ID, LandingPageID: page.ID})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to reorder landing page section", "error", err, "id", item.ID)
+		}
+	}
+	h.sectionsChanged(c, page.ID)
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// page loads the landing page named by the :id parameter, returning an
+// HTTP error for a bad or unknown ID.
+func (h *LandingPagesHandler) page(c echo.Context) (sqlc.LandingPage, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.LandingPage{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	page, err := h.queries.GetLandingPage(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return page, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load landing page", "error", err, "id", id)
+		return page, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return page, nil
+}
+
+// section loads the page and the section named by the :id and :sid
+// parameters; a section of another page is not found.
+func (h *LandingPagesHandler) section(c echo.Context) (sqlc.LandingPage, sqlc.LandingPageSection, error) {
+	page, err := h.page(c)
+	if err != nil {
+		return page, sqlc.LandingPageSection{}, err
+	}
+	sid, err := strconv.ParseInt(c.Param("sid"), 10, 64)
+	if err != nil {
+		return page, sqlc.LandingPageSection{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	section, err := h.queries.GetLandingPageSection(c.Request().Context(), sid)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && section.LandingPageID != page.ID {
+		return page, section, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load landing page section", "error", err, "id", sid)
+		return page, section, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return page, section, nil
+}
+
+// setStatus publishes or unpublishes the page named by the :id parameter.
+func (h *LandingPagesHandler) setStatus(c echo.Context, status string) error {
+	page, err := h.page(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.SetLandingPageStatus(c.Request().Context(), sqlc.SetLandingPageStatusParams{Status: status, ID: page.ID}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to change landing page status", "error", err, "id", page.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	if status == services.LandingPublished {
+		logActivity(c, "published", "landing_page", page.ID, page.Title, "Published landing page %q at /%s", page.Title, page.Slug)
+	} else {
+		logActivity(c, "updated", "landing_page", page.ID, page.Title, "Unpublished landing page %q", page.Title)
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/landing-pages/"+strconv.FormatInt(page.ID, 10)+"/edit")
+}
+
+// sectionsChanged marks the page as edited after a section change and drops
+// the cached landing pages.
+func (h *LandingPagesHandler) sectionsChanged(c echo.Context, id int64) {
+	if err := h.queries.TouchLandingPage(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to touch landing page", "error", err, "id", id)
+	}
+	h.changed()
+}
+
+// changed drops the cached landing pages, which were rendered before the
+// change (or under a slug the page no longer has).
+func (h *LandingPagesHandler) changed() {
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:landing:")
+	}
+}
+
+// form reads and validates the page settings form. It returns a message
+// for the form on invalid input.
+func (h *LandingPagesHandler) form(c echo.Context) (sqlc.CreateLandingPageParams, string) {
+	params, err := services.ValidateLandingPage(sqlc.CreateLandingPageParams{
+		Title:           c.FormValue("title"),
+		Slug:            c.FormValue("slug"),
+		Layout:          c.FormValue("layout"),
+		MetaTitle:       c.FormValue("meta_title"),
+		MetaDescription: c.FormValue("meta_description"),
+		OgImage:         c.FormValue("og_image"),
+		Noindex:         c.FormValue("noindex") == "on",
+		HeadSnippet:     c.FormValue("head_snippet"),
+		BodySnippet:     c.FormValue("body_snippet"),
+	})
+	if err != nil {
+		return params, landingErrorMessages[err]
+	}
+	return params, ""
+}
+
+// sectionForm reads and validates a section of sectionType. Text is
+// sanitized before it is validated. It returns a message for the form on
+// invalid input.
+func (h *LandingPagesHandler) sectionForm(c echo.Context, sectionType string) (sqlc.CreateLandingPageSectionParams, string) {
+	params := sqlc.CreateLandingPageSectionParams{
+		SectionType: sectionType,
+		Heading:     c.FormValue("heading"),
+		Body:        c.FormValue("body"),
+		Image:       c.FormValue("image"),
+		ButtonText:  c.FormValue("button_text"),
+		ButtonUrl:   c.FormValue("button_url"),
+		Items:       c.FormValue("items"),
+	}
+	if sectionType == services.SectionText {
+		params.Body = sanitizeHTML(c.FormValue("rich_body"))
+	}
+	params, err := services.ValidateLandingSection(params)
+	if err != nil {
+		return params, landingErrorMessages[err]
+	}
+	return params, ""
+}
+
+// landingSlugTaken explains a slug used by another landing page.
+const landingSlugTaken = "Another landing page already uses this slug."
+
+// landingErrorMessages explains validation errors on the forms.
+var landingErrorMessages = map[error]string{
+	services.ErrLandingTitle:    "Give the page a title.",
+	services.ErrLandingSlug:     "The slug needs letters or digits, e.g. spring-offer or campaigns/spring-offer.",
+	services.ErrLandingReserved: "The slug starts with a path the site already uses (such as products or blog). Choose another one.",
+	services.ErrLandingLayout:   "Choose a layout.",
+	services.ErrLandingImage:    "Images must be a path starting with / or a full http(s):// URL.",
+	services.ErrSectionType:     "Choose a section type.",
+	services.ErrSectionEmpty:    "The section is empty: a hero needs a headline, text needs a body and testimonials at least one quote.",
+	services.ErrSectionButton:   "A button needs both a label and a link: a path starting with /, an anchor such as #form, or a full http(s):// URL.",
+	services.ErrSectionItems:    "Check the testimonials: one per line as \"quote | name | role\"; the role is optional.",
+}
+
+// renderForm shows the add or edit form for page (ID 0 for a new one) with
+// its sections.
+func (h *LandingPagesHandler) renderForm(c echo.Context, status int, page sqlc.LandingPage, errMsg string) error {
+	title, action := "New Landing Page", "/admin/landing-pages"
+	var rows []landingSectionRow
+	preview := ""
+	if page.ID != 0 {
+		title, action = "Edit Landing Page", "/admin/landing-pages/"+strconv.FormatInt(page.ID, 10)
+		sections, err := h.queries.ListLandingPageSections(c.Request().Context(), page.ID)
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to list landing page sections", "error", err, "id", page.ID)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		for _, s := range sections {
+			rows = append(rows, landingSectionRow{LandingPageSection: s, TypeLabel: services.LandingSectionLabel(s.SectionType)})
+		}
+		preview = previewURL("/"+page.Slug, services.PreviewLanding, page.ID)
+	}
+	return c.Render(status, "admin/pages/landing_page_form.html", map[string]interface{}{
+		"Title":        title,
+		"Item":         page,
+		"FormAction":   action,
+		"Layouts":      services.LandingLayouts,
+		"SectionTypes": services.LandingSectionTypes,
+		"Sections":     rows,
+		"PreviewURL":   preview,
+		"Error":        errMsg,
+	})
+}
+
+// renderSectionForm shows the add or edit form for a section of page (ID 0
+// for a new one). Form sections offer the active custom forms.
+func (h *LandingPagesHandler) renderSectionForm(c echo.Context, status int, page sqlc.LandingPage, section sqlc.LandingPageSection, errMsg string) error {
+	base := "/admin/landing-pages/" + strconv.FormatInt(page.ID, 10)
+	title, action := "New "+services.LandingSectionLabel(section.SectionType)+" Section", base+"/sections"
+	if section.ID != 0 {
+		title, action = "Edit "+services.LandingSectionLabel(section.SectionType)+" Section", base+"/sections/"+strconv.FormatInt(section.ID, 10)
+	}
+	var forms []sqlc.ListActiveFormsRow
+	if section.SectionType == services.SectionForm {
+		var err error
+		if forms, err = h.queries.ListActiveForms(c.Request().Context()); err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to list forms", "error", err)
+		}
+	}
+	return c.Render(status, "admin/pages/landing_section_form.html", map[string]interface{}{
+		"Title":      title,
+		"Page":       page,
+		"Item":       section,
+		"FormAction": action,
+		"Forms":      forms,
+		"Error":      errMsg,
+	})
+}
+
+// landingPageFrom turns submitted settings back into a row for the form,
+// keeping the stored status of page.
+func landingPageFrom(p sqlc.CreateLandingPageParams, page sqlc.LandingPage) sqlc.LandingPage {
+	page.Title = p.Title
+	page.Slug = p.Slug
+	page.Layout = p.Layout
+	page.MetaTitle = p.MetaTitle
+	page.MetaDescription = p.MetaDescription
+	page.OgImage = p.OgImage
+	page.Noindex = p.Noindex
+	page.HeadSnippet = p.HeadSnippet
+	page.BodySnippet = p.BodySnippet
+	return page
+}
+
+// landingSectionFrom turns a submitted section back into a row for the form.
+func landingSectionFrom(p sqlc.CreateLandingPageSectionParams, id int64) sqlc.LandingPageSection {
+	return sqlc.LandingPageSection{
+		ID:            id,
+		LandingPageID: p.LandingPageID,
+		SectionType:   p.SectionType,
+		Heading:       p.Heading,
+		Body:          p.Body,
+		Image:         p.Image,
+		ButtonText:    p.ButtonText,
+		ButtonUrl:     p.ButtonUrl,
+		Items:         p.Items,
+	}
+}
@@ -0,0 +1,134 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains handlers for the lead deduplication view.
+package admin
+
+import (
+	// Standard library imports
+	"errors"   // Merge validation error detection
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"strings"  // Case-insensitive lead search
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Lead grouping, normalization, and merges
+)
+
+// LeadsHandler shows contact submissions, RFQs, and whitepaper downloads
+// grouped by person, and lets editors merge people who used several addresses.
+type LeadsHandler struct {
+	leads  *services.LeadService // Lead grouping and merge records
+	logger *slog.Logger          // Structured logger for error tracking
+}
+
+// NewLeadsHandler constructs a new LeadsHandler with required dependencies.
+func NewLeadsHandler(leads *services.LeadService, logger *slog.Logger) *LeadsHandler {
+	return &LeadsHandler{leads: leads, logger: logger}
+}
+
+// List handles GET /admin/leads
+// Renders every lead with its submissions across sources, plus suggested
+// merges for different addresses with the same name and company.
+// Template: admin/pages/leads_list.html (full page)
+//
+// Query parameters:
+//   - view: "duplicates" (default) shows only leads with several submissions
+//     or addresses; "all" shows every lead
+//   - q: Filters by name, email, or company (case-insensitive)
+//   - merged / unmerged / error: Flash flags set by Merge and Unmerge redirects
+func (h *LeadsHandler) List(c echo.Context) error {
+	leads, err := h.leads.Leads(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load leads", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	view := c.QueryParam("view")
+	if view != "all" {
+		view = "duplicates"
+	}
+	search := strings.TrimSpace(c.QueryParam("q"))
+	needle := strings.ToLower(search)
+
+	duplicates := 0
+	var shown []*services.Lead
+	for _, lead := range leads {
+		if lead.IsDuplicate() {
+			duplicates++
+		}
+		if view == "duplicates" && !lead.IsDuplicate() {
+			continue
+		}
+		if needle != "" && !leadMatches(lead, needle) {
+			continue
+		}
+		shown = append(shown, lead)
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/leads_list.html", map[string]interface{}{
+		"Title":       "Leads",
+		"Leads":       shown,
+		"Suggestions": services.Suggestions(leads),
+		"Total":       len(leads),
+		"Duplicates":  duplicates,
+		"View":        view,
+		"Search":      search,
+		"Merged":      c.QueryParam("merged") == "1",
+		"Unmerged":    c.QueryParam("unmerged") == "1",
+		"Error":       c.QueryParam("error"),
+	})
+}
+
+// leadMatches reports whether any name, address, or company of the lead
+// contains needle (already lowercased).
+func leadMatches(lead *services.Lead, needle string) bool {
+	for _, sub := range lead.Submissions {
+		if strings.Contains(strings.ToLower(sub.Name), needle) ||
+			strings.Contains(strings.ToLower(sub.Email), needle) ||
+			strings.Contains(strings.ToLower(sub.Company), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge handles POST /admin/leads/merge
+// Folds the lead of duplicate_email into the lead of primary_email. Source
+// submissions are not modified; the link is recorded in lead_merges.
+//
+// Form fields:
+//   - primary_email: Address of the lead to keep
+//   - duplicate_email: Address of the lead to merge into it
+func (h *LeadsHandler) Merge(c echo.Context) error {
+	primary := c.FormValue("primary_email")
+	duplicate := c.FormValue("duplicate_email")
+
+	if err := h.leads.Merge(c.Request().Context(), primary, duplicate); err != nil {
+		if errors.Is(err, services.ErrInvalidLeadMerge) {
+			return c.Redirect(http.StatusSeeOther, "/admin/leads?error=invalid")
+		}
+		h.logger.ErrorContext(c.Request().Context(), "failed to merge leads", "primary", primary, "duplicate", duplicate, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "merged", "lead", 0, services.NormalizeEmail(primary), "Merged lead %s into %s", services.NormalizeEmail(duplicate), services.NormalizeEmail(primary))
+	return c.Redirect(http.StatusSeeOther, "/admin/leads?merged=1")
+}
+
+// Unmerge handles POST /admin/leads/unmerge
+// Splits a merged address back into its own lead.
+//
+// Form fields:
+//   - email: Merged address to split off
+func (h *LeadsHandler) Unmerge(c echo.Context) error {
+	email := c.FormValue("email")
+	if err := h.leads.Unmerge(c.Request().Context(), email); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to unmerge lead", "email", email, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "unmerged", "lead", 0, services.NormalizeEmail(email), "Split lead %s back out", services.NormalizeEmail(email))
+	return c.Redirect(http.StatusSeeOther, "/admin/leads?unmerged=1")
+}
@@ -0,0 +1,79 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the broken internal link report and the button that
+// runs the link check.
+package admin
+
+import (
+	// Standard library imports
+	"errors"   // Matching scheduler errors
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/jobs"     // Job scheduler
+	"github.com/narendhupati/bluejay-cms/internal/services" // Link checker
+)
+
+// linkCheckJob is the name the link check job is registered under in main.
+const linkCheckJob = "link-check"
+
+// LinkCheckHandler serves /admin/link-check. The check itself runs as the
+// link-check job, so it is never started twice at once.
+type LinkCheckHandler struct {
+	checker   *services.LinkChecker // Results of the last run
+	scheduler *jobs.Scheduler       // Runs the check and reports its last run
+	logger    *slog.Logger          // Structured logger for error tracking
+}
+
+// NewLinkCheckHandler creates a new LinkCheckHandler instance.
+func NewLinkCheckHandler(checker *services.LinkChecker, scheduler *jobs.Scheduler, logger *slog.Logger) *LinkCheckHandler {
+	return &LinkCheckHandler{checker: checker, scheduler: scheduler, logger: logger}
+}
+
+// Report handles GET /admin/link-check
+// Lists the broken internal links found by the last run, each with a link
+// to edit it, to publish its target or to add a redirect for it. While a run
+// is in progress the page polls itself until it has finished.
+// Template: admin/pages/link_check.html (full page)
+func (h *LinkCheckHandler) Report(c echo.Context) error {
+	return h.render(c, http.StatusOK, "")
+}
+
+// Run handles POST /admin/link-check/run
+// Starts the link check in the background and returns to the report.
+func (h *LinkCheckHandler) Run(c echo.Context) error {
+	err := h.scheduler.RunNow(linkCheckJob)
+	switch {
+	case errors.Is(err, jobs.ErrJobRunning):
+		return h.render(c, http.StatusConflict, "The link check is already running.")
+	case err != nil:
+		h.logger.ErrorContext(c.Request().Context(), "failed to start link check", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "started", "job", 0, linkCheckJob, "Started job %s", linkCheckJob)
+	return c.Redirect(http.StatusSeeOther, "/admin/link-check")
+}
+
+// render renders the report with an optional error banner.
+func (h *LinkCheckHandler) render(c echo.Context, status int, errMsg string) error {
+	links, err := h.checker.Report(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list broken links", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	var job jobs.Status
+	for _, s := range h.scheduler.Jobs() {
+		if s.Name == linkCheckJob {
+			job = s
+		}
+	}
+	return c.Render(status, "admin/pages/link_check.html", map[string]interface{}{
+		"Title": "Link Check",
+		"Links": links,
+		"Job":   job,
+		"Error": errMsg,
+	})
+}
@@ -0,0 +1,287 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the inline edits on the product, blog post and case
+// study lists: publishing, featuring and ordering an item from its row
+// without opening the edit form.
+package admin
+
+import (
+	// Standard library imports
+	"database/sql" // Nullable featured order
+	"errors"       // Telling missing items apart from query failures
+	"fmt"          // Editor paths
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing IDs and order values
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Toasts for HTMX responses
+)
+
+// productRow is one row of the admin product list.
+type productRow struct {
+	sqlc.Product
+	Category string // Category name
+	Notice   string // Why the last inline edit was not applied
+}
+
+// blogPostRow is one row of the admin blog post list.
+type blogPostRow struct {
+	sqlc.ListBlogPostsAdminFilteredRow
+	Notice string // Why the last inline edit was not applied
+}
+
+// caseStudyRow is one row of the admin case study list.
+type caseStudyRow struct {
+	sqlc.AdminListCaseStudiesFilteredRow
+	Notice string // Why the last inline edit was not applied
+}
+
+// inlinePublish reads the "published" field of an inline edit: whether the
+// item should be published, and whether the field was sent at all.
+func inlinePublish(c echo.Context) (publish, ok bool) {
+	switch c.FormValue("published") {
+	case "1":
+		return true, true
+	case "0":
+		return false, true
+	}
+	return false, false
+}
+
+// inlineToast reports an inline edit in a toast: the notice when part of it
+// was refused, otherwise that the item was saved.
+func inlineToast(c echo.Context, notice string, saved bool, title string) {
+	switch {
+	case notice != "":
+		customMiddleware.AddFlash(c, customMiddleware.FlashWarning, notice)
+	case saved:
+		customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, fmt.Sprintf("Saved '%s'.", title))
+	}
+}
+
+// InlineUpdate handles PATCH /admin/products/:id/inline
+// Applies a small change from a row of the product list and returns the row.
+// Publishing goes through the workflow like the edit form; users it does not
+// allow keep the current status and see a notice on the row.
+//
+// Form fields (each optional):
+//   - published: "1" to publish, "0" to unpublish
+//   - featured: "1" to feature, "0" to unfeature
+//   - featured_order: Position among featured products; empty clears it
+//
+// HTMX: returns admin/partials/product_row.html, swapped over the row, and a
+// toast in the HX-Trigger header
+func (h *ProductsHandler) InlineUpdate(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	product, err := h.queries.GetProduct(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && product.DeletedAt.Valid) {
+		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get product", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	var notice string
+	var saved bool
+	if publish, ok := inlinePublish(c); ok && publish != (product.Status == "published") {
+		status := "draft"
+		if publish {
+			status = "published"
+		}
+		if !canPublishFromForm(c, "product", id, product.Status) {
+			notice = "You cannot change whether this product is published. Use the review panel on its edit page."
+		} else {
+			if _, err := h.queries.SetProductPublishStatus(ctx, sqlc.SetProductPublishStatusParams{
+				Status: status, PublishedAt: nullTimeNow(), ID: id,
+			}); err != nil {
+				h.logger.ErrorContext(ctx, "failed to set product status", "error", err, "id", id)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+			recordFormStatus(c, h.logger, "product", id, product.Name, fmt.Sprintf("/admin/products/%d/edit", id), product.Status, status)
+			logActivity(c, "updated", "product", id, product.Name, "Changed Product '%s' to %s from the list", product.Name, status)
+			saved = true
+		}
+	}
+
+	featured, order := product.IsFeatured, product.FeaturedOrder
+	switch c.FormValue("featured") {
+	case "1":
+		featured = true
+	case "0":
+		featured = false
+	}
+	if _, ok := c.Request().Form["featured_order"]; ok {
+		if v := c.FormValue("featured_order"); v == "" {
+			order = sql.NullInt64{}
+		} else if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+			order = sql.NullInt64{Int64: n, Valid: true}
+		} else {
+			notice = "Featured order must be a whole number."
+		}
+	}
+	if featured != product.IsFeatured || order != product.FeaturedOrder {
+		if _, err := h.queries.SetProductFeatured(ctx, sqlc.SetProductFeaturedParams{
+			IsFeatured: featured, FeaturedOrder: order, ID: id,
+		}); err != nil {
+			h.logger.ErrorContext(ctx, "failed to set product featured flag", "error", err, "id", id)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		logActivity(c, "updated", "product", id, product.Name, "Changed featuring of Product '%s' from the list", product.Name)
+		saved = true
+	}
+
+	invalidateProductPages(h.cache, id, product.CategoryID)
+
+	row := productRow{Notice: notice}
+	if row.Product, err = h.queries.GetProduct(ctx, id); err != nil {
+		h.logger.ErrorContext(ctx, "failed to reload product", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if category, err := h.queries.GetProductCategory(ctx, row.CategoryID); err == nil {
+		row.Category = category.Name
+	}
+	inlineToast(c, notice, saved, row.Name)
+	return c.Render(http.StatusOK, "admin/partials/product_row.html", row)
+}
+
+// InlineUpdate handles PATCH /admin/blog/posts/:id/inline
+// Publishes or unpublishes a post from its row of the post list and returns
+// the row. Posts have no featured flag or order. Publishing goes through the
+// workflow like the edit form.
+//
+// Form fields:
+//   - published: "1" to publish, "0" to unpublish
+//
+// HTMX: returns admin/partials/blog_post_row.html, swapped over the row, and a
+// toast in the HX-Trigger header
+func (h *BlogPostsHandler) InlineUpdate(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	item, err := h.queries.GetBlogPostListItem(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get blog post", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	var notice string
+	var saved bool
+	if publish, ok := inlinePublish(c); ok && publish != (item.Status == "published") {
+		status := "draft"
+		if publish {
+			status = "published"
+		}
+		if !canPublishFromForm(c, "blog_post", id, item.Status) {
+			notice = "You cannot change whether this post is published. Use the review panel on its edit page."
+		} else {
+			if _, err := h.queries.SetBlogPostPublishStatus(ctx, sqlc.SetBlogPostPublishStatusParams{
+				Status: status, PublishedAt: nullTimeNow(), ID: id,
+			}); err != nil {
+				h.logger.ErrorContext(ctx, "failed to set blog post status", "error", err, "id", id)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+			recordFormStatus(c, h.logger, "blog_post", id, item.Title, fmt.Sprintf("/admin/blog/posts/%d/edit", id), item.Status, status)
+			h.cache.DeleteByPrefix("page:blog")
+			logActivity(c, "updated", "blog_post", id, item.Title, "Changed blog_post '%s' to %s from the list", item.Title, status)
+			saved = true
+			if item, err = h.queries.GetBlogPostListItem(ctx, id); err != nil {
+				h.logger.ErrorContext(ctx, "failed to reload blog post", "error", err, "id", id)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+		}
+	}
+
+	inlineToast(c, notice, saved, item.Title)
+	return c.Render(http.StatusOK, "admin/partials/blog_post_row.html", blogPostRow{
+		ListBlogPostsAdminFilteredRow: sqlc.ListBlogPostsAdminFilteredRow(item),
+		Notice:                        notice,
+	})
+}
+
+// InlineUpdate handles PATCH /admin/case-studies/:id/inline
+// Publishes, unpublishes or reorders a case study from its row of the case
+// study list and returns the row. Case studies have no featured flag.
+// Publishing needs the publish checklist to pass; there is no override from
+// the list, so a case study that fails stays unpublished and the row links
+// to its edit page.
+//
+// Form fields (each optional):
+//   - published: "1" to publish, "0" to unpublish
+//   - display_order: Sort position, lowest first
+//
+// HTMX: returns admin/partials/case_study_row.html, swapped over the row, and
+// a toast in the HX-Trigger header
+func (h *CaseStudiesHandler) InlineUpdate(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	cs, err := h.queries.AdminGetCaseStudy(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && cs.DeletedAt.Valid) {
+		return echo.NewHTTPError(http.StatusNotFound, "Case study not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get case study", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	var notice string
+	var saved bool
+	if publish, ok := inlinePublish(c); ok && publish != (cs.IsPublished == 1) {
+		isPublished, status := int64(0), "draft"
+		if publish {
+			isPublished, status = 1, "published"
+			gate, err := checkPublish(c, caseStudyCandidate(cs.MetaDescription.String, cs.HeroImageUrl.String, cs.IndustryID,
+				cs.Summary, cs.ChallengeContent, cs.SolutionContent, cs.OutcomeContent))
+			if err != nil {
+				h.logger.ErrorContext(ctx, "failed to run publish checklist", "error", err, "id", id)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+			if !gate.allowed() {
+				notice = "This case study fails the publish checklist."
+			}
+		}
+		if notice == "" {
+			if _, err := h.queries.SetCaseStudyPublished(ctx, sqlc.SetCaseStudyPublishedParams{IsPublished: isPublished, ID: id}); err != nil {
+				h.logger.ErrorContext(ctx, "failed to set case study published", "error", err, "id", id)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+			logActivity(c, "updated", "case_study", id, cs.Title, "Changed Case Study '%s' to %s from the list", cs.Title, status)
+			saved = true
+		}
+	}
+
+	if v, ok := c.Request().Form["display_order"]; ok {
+		if n, err := strconv.ParseInt(v[0], 10, 64); err != nil {
+			notice = "Display order must be a whole number."
+		} else if n != cs.DisplayOrder {
+			if _, err := h.queries.SetCaseStudyDisplayOrder(ctx, sqlc.SetCaseStudyDisplayOrderParams{DisplayOrder: n, ID: id}); err != nil {
+				h.logger.ErrorContext(ctx, "failed to set case study display order", "error", err, "id", id)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+			logActivity(c, "updated", "case_study", id, cs.Title, "Moved Case Study '%s' to position %d from the list", cs.Title, n)
+			saved = true
+		}
+	}
+
+	h.cache.DeleteByPrefix("page:case-studies")
+
+	item, err := h.queries.AdminGetCaseStudyListItem(ctx, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to reload case study", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	inlineToast(c, notice, saved, item.Title)
+	return c.Render(http.StatusOK, "admin/partials/case_study_row.html", caseStudyRow{
+		AdminListCaseStudiesFilteredRow: sqlc.AdminListCaseStudiesFilteredRow(item),
+		Notice:                          notice,
+	})
+}
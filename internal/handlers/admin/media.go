@@ -5,7 +5,9 @@ package admin
 
 import (
 	// Standard library imports
+	"crypto/sha256"   // Content hashes for duplicate detection by imports
 	"database/sql"    // SQL null types for optional database fields
+	"encoding/hex"    // Hex encoding of content hashes
 	"encoding/json"   // JSON encoding for API responses
 	"fmt"             // String formatting for file naming and messages
 	"image"           // Image configuration decoding for dimensions
@@ -34,9 +36,9 @@ import (
 // It handles file uploads, storage, metadata tracking, search, pagination, and deletion.
 // Supports multiple file types with validation, dimension detection, and alt text management.
 type MediaHandler struct {
-	queries   *sqlc.Queries // Database query interface for media operations
-	logger    *slog.Logger  // Structured logger for error tracking
-	uploadDir string        // Base directory for file storage (e.g., "public/uploads")
+	queries   sqlc.Querier // Database query interface for media operations
+	logger    *slog.Logger // Structured logger for error tracking
+	uploadDir string       // Base directory for file storage (e.g., "public/uploads")
 }
 
 // NewMediaHandler creates and initializes a new MediaHandler instance.
@@ -48,7 +50,7 @@ type MediaHandler struct {
 //   - uploadDir: Base directory for file storage (must be writable)
 //
 // Returns a fully initialized MediaHandler ready to handle HTTP requests.
-func NewMediaHandler(queries *sqlc.Queries, logger *slog.Logger, uploadDir string) *MediaHandler {
+func NewMediaHandler(queries sqlc.Querier, logger *slog.Logger, uploadDir string) *MediaHandler {
 	return &MediaHandler{
 		queries:   queries,
 		logger:    logger,
@@ -115,7 +117,7 @@ func (h *MediaHandler) List(c echo.Context) error {
 			PageOffset: offset,
 		})
 		if err != nil {
-			h.logger.Error("failed to search media files", "error", err)
+			h.logger.ErrorContext(ctx, "failed to search media files", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to search media files")
 		}
 		// Get total count for search results (for pagination)
@@ -145,7 +147,7 @@ func (h *MediaHandler) List(c echo.Context) error {
 			})
 		}
 		if err != nil {
-			h.logger.Error("failed to list media files", "error", err)
+			h.logger.ErrorContext(ctx, "failed to list media files", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to list media files")
 		}
 		// Get total count of all files (for pagination)
@@ -153,7 +155,7 @@ func (h *MediaHandler) List(c echo.Context) error {
 	}
 
 	if err != nil {
-		h.logger.Error("failed to count media files", "error", err)
+		h.logger.ErrorContext(ctx, "failed to count media files", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to count media files")
 	}
 
@@ -203,10 +205,12 @@ func (h *MediaHandler) List(c echo.Context) error {
 //
 // Form Data:
 //   - files: Multiple files (using standard multipart/form-data)
+//   - alt_text: One value per file, in the same order; required for images
 //
 // Returns:
 //   - 200 OK with JSON list of uploaded files on success
 //   - 400 Bad Request if no files provided or form data is invalid
+//   - 422 Unprocessable Entity if an image has no alt text; nothing is uploaded
 //   - 500 Internal Server Error if upload directory creation fails
 //
 // Response JSON:
@@ -233,6 +237,26 @@ func (h *MediaHandler) Upload(c echo.Context) error {
 		".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".svg": true, ".pdf": true, ".webp": true,
 	}
 
+	// Every image needs alt text (PDFs have none); reject the whole upload
+	// rather than store some files without it
+	altTexts := form.Value["alt_text"]
+	alt := func(i int) string {
+		if i < len(altTexts) {
+			return strings.TrimSpace(altTexts[i])
+		}
+		return ""
+	}
+	var missingAlt []string
+	for i, file := range formFiles {
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		if allowedTypes[ext] && ext != ".pdf" && alt(i) == "" {
+			missingAlt = append(missingAlt, file.Filename)
+		}
+	}
+	if len(missingAlt) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Add alt text for " + strings.Join(missingAlt, ", ")})
+	}
+
 	// Ensure upload directory exists (e.g., "public/uploads/media")
 	mediaDir := filepath.Join(h.uploadDir, "media")
 	if err := os.MkdirAll(mediaDir, 0755); err != nil {
@@ -243,7 +267,7 @@ func (h *MediaHandler) Upload(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	// Process each uploaded file
-	for _, file := range formFiles {
+	for i, file := range formFiles {
 		// Validate file extension
 		ext := strings.ToLower(filepath.Ext(file.Filename))
 		if !allowedTypes[ext] {
@@ -274,7 +298,7 @@ func (h *MediaHandler) Upload(c echo.Context) error {
 		// Open uploaded file for reading
 		src, err := file.Open()
 		if err != nil {
-			h.logger.Error("failed to open uploaded file", "error", err)
+			h.logger.ErrorContext(ctx, "failed to open uploaded file", "error", err)
 			continue // Skip this file, continue with others
 		}
 
@@ -282,15 +306,17 @@ func (h *MediaHandler) Upload(c echo.Context) error {
 		dst, err := os.Create(dstPath)
 		if err != nil {
 			src.Close()
-			h.logger.Error("failed to create destination file", "error", err)
+			h.logger.ErrorContext(ctx, "failed to create destination file", "error", err)
 			continue // Skip this file, continue with others
 		}
 
-		// Copy file contents from upload to destination
-		if _, err = io.Copy(dst, src); err != nil {
+		// Copy file contents from upload to destination, hashing them on the way
+		// so bulk imports can recognise the file as a duplicate
+		hash := sha256.New()
+		if _, err = io.Copy(io.MultiWriter(dst, hash), src); err != nil {
 			src.Close()
 			dst.Close()
-			h.logger.Error("failed to copy file", "error", err)
+			h.logger.ErrorContext(ctx, "failed to copy file", "error", err)
 			continue // Skip this file, continue with others
 		}
 		src.Close()
@@ -305,10 +331,11 @@ func (h *MediaHandler) Upload(c echo.Context) error {
 			MimeType:         mimeType,           // MIME type (e.g., "image/jpeg")
 			Width:            sql.NullInt64{Int64: int64(width), Valid: width > 0},   // Image width (null for non-images)
 			Height:           sql.NullInt64{Int64: int64(height), Valid: height > 0}, // Image height (null for non-images)
-			AltText:          sql.NullString{String: "", Valid: true},                // Empty alt text (can be edited later)
+			AltText:          sql.NullString{String: alt(i), Valid: true},            // Alt text from the upload form (empty for PDFs)
+			ContentHash:      hex.EncodeToString(hash.Sum(nil)),                      // SHA-256 of the contents
 		})
 		if err != nil {
-			h.logger.Error("failed to save media file record", "error", err)
+			h.logger.ErrorContext(ctx, "failed to save media file record", "error", err)
 			continue // Skip this file, continue with others
 		}
 
@@ -453,6 +480,13 @@ func (h *MediaHandler) Delete(c echo.Context) error {
 	fsPath := filepath.Join(h.uploadDir, strings.TrimPrefix(file.FilePath, "/uploads/"))
 	os.Remove(fsPath) // Ignore errors (file may already be deleted)
 
+	// Resized variants from bulk imports; their rows go with the file's
+	if variants, err := h.queries.ListMediaVariants(c.Request().Context(), id); err == nil {
+		for _, v := range variants {
+			os.Remove(filepath.Join(h.uploadDir, strings.TrimPrefix(v.FilePath, "/uploads/")))
+		}
+	}
+
 	// Delete database record
 	if err := h.queries.DeleteMediaFile(c.Request().Context(), id); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete file"})
@@ -526,7 +560,7 @@ func (h *MediaHandler) Browse(c echo.Context) error {
 	}
 
 	if err != nil {
-		h.logger.Error("failed to browse media files", "error", err)
+		h.logger.ErrorContext(ctx, "failed to browse media files", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load media files")
 	}
 
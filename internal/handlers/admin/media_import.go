@@ -0,0 +1,353 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the bulk media import: add every file in a ZIP upload or
+// a server-side folder to the media library in the background, with
+// de-duplication by content and resized variants, then report per file.
+package admin
+
+import (
+	// Standard library imports
+	"archive/zip"    // Opening uploaded archives
+	"context"        // Background context for running imports
+	"crypto/rand"    // Job IDs
+	"encoding/hex"   // Job ID encoding
+	"errors"         // Folder errors shown on the form
+	"io"             // Copying the upload to a temporary file
+	"log/slog"       // Structured logging for error tracking
+	"mime/multipart" // Uploaded archive
+	"net/http"       // HTTP status codes and error responses
+	"os"             // Temporary files and folder listing
+	"path/filepath"  // Resolving folders under the import root
+	"sort"           // Listing recent jobs newest first
+	"strings"        // Path checks
+	"sync"           // Guarding the job table
+	"time"           // Job timestamps
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Media importer and cache
+)
+
+// maxMediaImportArchiveSize limits uploaded ZIP files to 200 MB.
+const maxMediaImportArchiveSize = 200 << 20
+
+// maxMediaImportJobs is how many finished imports are kept for their reports.
+const maxMediaImportJobs = 20
+
+// mediaImportJob is one import, running or finished. Fields are guarded by
+// MediaImportHandler.mu; templates get copies.
+type mediaImportJob struct {
+	ID      string                      // Random ID used in the status URL
+	Source  string                      // Archive or folder name, for display
+	Total   int                         // Files found in the source
+	Done    int                         // Files processed so far
+	Running bool                        // False once the report is final
+	Error   string                      // Why the import stopped early, if it did
+	Report  *services.MediaImportReport // Set when the import finishes
+	Started time.Time
+}
+
+// MediaImportHandler serves the bulk media import under /admin/media/import.
+// Imports run one at a time in the background, since an archive of large
+// images takes longer than a request should; the status page polls until the
+// report is ready. Jobs live in memory and are lost on restart, which only
+// loses the report: imported files are already in the library.
+type MediaImportHandler struct {
+	importer  *services.MediaImporter // Stores files, hashes and variants
+	logger    *slog.Logger            // Structured logger for error tracking
+	cache     *services.Cache         // Cleared after an import so pages see new media
+	importDir string                  // Root of the server folders that may be imported
+
+	mu   sync.Mutex
+	jobs map[string]*mediaImportJob
+}
+
+// NewMediaImportHandler constructs a new MediaImportHandler. Server-side
+// imports may only read folders under importDir.
+func NewMediaImportHandler(importer *services.MediaImporter, logger *slog.Logger, cache *services.Cache, importDir string) *MediaImportHandler {
+	return &MediaImportHandler{
+		importer:  importer,
+		logger:    logger,
+		cache:     cache,
+		importDir: importDir,
+		jobs:      map[string]*mediaImportJob{},
+	}
+}
+
+// Show handles GET /admin/media/import
+// Renders the import form with the folders available for a server import and
+// the recent imports.
+// Template: admin/pages/media_import.html (full page)
+func (h *MediaImportHandler) Show(c echo.Context) error {
+	return h.renderForm(c, "")
+}
+
+// Start handles POST /admin/media/import
+// Starts an import and redirects to its status page (303). Invalid input
+// re-renders the form with the error.
+//
+// Form fields:
+//   - source: "zip" (upload) or "dir" (server folder)
+//   - archive: ZIP file, for source=zip
+//   - dir: Folder under the import root, for source=dir ("." for the root)
+func (h *MediaImportHandler) Start(c echo.Context) error {
+	// Checked again by addJob; this only saves reading the upload
+	if h.running() {
+		return h.renderForm(c, "An import is already running. Wait for it to finish.")
+	}
+
+	var (
+		files   []services.MediaImportFile
+		source  string
+		cleanup = func() {}
+	)
+	switch c.FormValue("source") {
+	case "zip":
+		fileHeader, err := c.FormFile("archive")
+		if err != nil {
+			return h.renderForm(c, "Choose a ZIP file to import.")
+		}
+		if fileHeader.Size > maxMediaImportArchiveSize {
+			return h.renderForm(c, "The archive is larger than 200 MB. Split it, or copy it to the server and import the folder.")
+		}
+		zr, closeZip, err := openMediaArchive(fileHeader)
+		if err != nil {
+			return h.renderForm(c, "The file is not a valid ZIP archive.")
+		}
+		files, source, cleanup = services.ZipMediaFiles(zr), fileHeader.Filename, closeZip
+	case "dir":
+		dir, err := h.resolveDir(c.FormValue("dir"))
+		if err != nil {
+			return h.renderForm(c, err.Error())
+		}
+		if files, err = services.DirMediaFiles(dir); err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to list media import folder", "dir", dir, "error", err)
+			return h.renderForm(c, "The folder could not be read.")
+		}
+		source = c.FormValue("dir")
+	default:
+		return h.renderForm(c, "Choose a ZIP file or a server folder.")
+	}
+	if len(files) == 0 {
+		cleanup()
+		return h.renderForm(c, "There are no files to import in "+source+".")
+	}
+
+	job := &mediaImportJob{ID: newMediaImportJobID(), Source: source, Total: len(files), Running: true, Started: time.Now()}
+	if !h.addJob(job) {
+		cleanup()
+		return h.renderForm(c, "An import is already running. Wait for it to finish.")
+	}
+
+	// The request context ends with the redirect, so the import runs on its own
+	userID := getUserID(c)
+	go func() {
+		defer cleanup()
+		report, err := h.importer.Import(context.Background(), files, func(done int) {
+			h.mu.Lock()
+			job.Done = done
+			h.mu.Unlock()
+		})
+		h.finish(job, userID, report, err)
+	}()
+
+	return c.Redirect(http.StatusSeeOther, "/admin/media/import/"+job.ID)
+}
+
+// Status handles GET /admin/media/import/:id
+// Renders the progress of an import and, once finished, its report. While
+// the import runs the page polls itself every two seconds (HTMX).
+// Template: admin/pages/media_import.html (full page)
+func (h *MediaImportHandler) Status(c echo.Context) error {
+	h.mu.Lock()
+	job, ok := h.jobs[c.Param("id")]
+	var snapshot mediaImportJob
+	if ok {
+		snapshot = *job
+	}
+	h.mu.Unlock()
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Import not found")
+	}
+	return c.Render(http.StatusOK, "admin/pages/media_import.html", map[string]interface{}{
+		"Title": "Import Media",
+		"Job":   snapshot,
+	})
+}
+
+// finish records the outcome of a job, logs it and clears cached pages.
+func (h *MediaImportHandler) finish(job *mediaImportJob, userID int64, report *services.MediaImportReport, err error) {
+	h.mu.Lock()
+	job.Running = false
+	job.Report = report
+	if err != nil {
+		job.Error = err.Error()
+	}
+	h.mu.Unlock()
+
+	if err != nil {
+		h.logger.Error("media import stopped", "source", job.Source, "error", err)
+	}
+	if report == nil {
+		return
+	}
+	if report.Imported > 0 && h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
+	if activityLog != nil {
+		activityLog.LogF(context.Background(), userID, "created", "media", 0, "",
+			"Imported media from %s: %d added, %d duplicates, %d skipped, %d failed",
+			job.Source, report.Imported, report.Duplicates, report.Skipped, report.Failed)
+	}
+}
+
+// openMediaArchive copies an uploaded archive to a temporary file, since ZIP
+// reading needs random access, and opens it. The returned func closes and
+// removes the file.
+func openMediaArchive(fileHeader *multipart.FileHeader) (*zip.Reader, func(), error) {
+	src, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp("", "media-import-*.zip")
+	if err != nil {
+		return nil, nil, err
+	}
+	remove := func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+	size, err := io.Copy(tmp, src)
+	if err != nil {
+		remove()
+		return nil, nil, err
+	}
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		remove()
+		return nil, nil, err
+	}
+	return zr, remove, nil
+}
+
+// resolveDir returns the server path of a folder under the import root,
+// rejecting paths that leave it. Errors are shown on the form as is.
+func (h *MediaImportHandler) resolveDir(name string) (string, error) {
+	if h.importDir == "" {
+		return "", errors.New("Server folder imports are not configured.")
+	}
+	if name == "" {
+		return "", errors.New("Choose a folder to import.")
+	}
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("The folder must be inside the import folder.")
+	}
+	dir := filepath.Join(h.importDir, rel)
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return "", errors.New("The folder " + name + " does not exist.")
+	}
+	return dir, nil
+}
+
+// running reports whether an import is in progress.
+func (h *MediaImportHandler) running() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.runningLocked()
+}
+
+// runningLocked is running for callers that hold h.mu.
+func (h *MediaImportHandler) runningLocked() bool {
+	for _, job := range h.jobs {
+		if job.Running {
+			return true
+		}
+	}
+	return false
+}
+
+// addJob registers job unless an import is already running, in one critical
+// section so two requests cannot both start one: the importer's duplicate
+// check is not atomic with its insert.
+func (h *MediaImportHandler) addJob(job *mediaImportJob) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.runningLocked() {
+		return false
+	}
+	h.jobs[job.ID] = job
+	h.pruneJobs()
+	return true
+}
+
+// recentJobs returns copies of the jobs, newest first.
+func (h *MediaImportHandler) recentJobs() []mediaImportJob {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	jobs := make([]mediaImportJob, 0, len(h.jobs))
+	for _, job := range h.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Started.After(jobs[j].Started) })
+	return jobs
+}
+
+// pruneJobs drops the oldest finished jobs beyond maxMediaImportJobs.
+// Callers hold h.mu.
+func (h *MediaImportHandler) pruneJobs() {
+	for len(h.jobs) > maxMediaImportJobs {
+		var oldest *mediaImportJob
+		for _, job := range h.jobs {
+			if !job.Running && (oldest == nil || job.Started.Before(oldest.Started)) {
+				oldest = job
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		delete(h.jobs, oldest.ID)
+	}
+}
+
+// importFolders lists the folders directly under the import root, plus "."
+// for the root itself when it exists.
+func (h *MediaImportHandler) importFolders() []string {
+	if h.importDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(h.importDir)
+	if err != nil {
+		return nil
+	}
+	folders := []string{"."}
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			folders = append(folders, e.Name())
+		}
+	}
+	return folders
+}
+
+// renderForm renders the import form, with an error message if set.
+func (h *MediaImportHandler) renderForm(c echo.Context, errMsg string) error {
+	return c.Render(http.StatusOK, "admin/pages/media_import.html", map[string]interface{}{
+		"Title":       "Import Media",
+		"UploadError": errMsg,
+		"ImportDir":   h.importDir,
+		"Folders":     h.importFolders(),
+		"Jobs":        h.recentJobs(),
+		"Running":     h.running(),
+	})
+}
+
+// newMediaImportJobID returns a random 16-character job ID.
+func newMediaImportJobID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -5,8 +5,10 @@ package admin
 
 import (
 	// Standard library imports
+	"context"         // Request context for parent validation
 	"database/sql"    // SQL null types for optional database fields
 	"encoding/json"   // JSON encoding/decoding for AJAX requests
+	"errors"          // Parent validation errors
 	"fmt"             // String formatting for dynamic URLs and messages
 	"log/slog"        // Structured logging for error and debug output
 	"net/http"        // HTTP status codes and request/response handling
@@ -16,15 +18,19 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Database query layer generated by sqlc
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Database query layer generated by sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page identifier slugs, public menus and page cache
 )
 
 // NavigationHandler manages navigation menus and menu items for the website.
 // It supports multiple menus (header, footer, sidebar), hierarchical menu structures
 // with parent-child relationships, drag-and-drop reordering, and various link types.
 type NavigationHandler struct {
-	queries *sqlc.Queries // Database query interface for navigation operations
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier         // Database query interface for navigation operations
+	logger  *slog.Logger         // Structured logger for error tracking
+	nav     *services.Navigation // Active menus served to visitors, reloaded after each change
+	cache   *services.Cache      // Public page cache, cleared after each change
 }
 
 // NewNavigationHandler creates and initializes a new NavigationHandler instance.
@@ -33,18 +39,63 @@ type NavigationHandler struct {
 // Parameters:
 //   - queries: Database query layer for executing navigation operations
 //   - logger: Structured logger for error and activity logging
+//   - nav: Active menus rendered in the public header and footer
+//   - cache: Public page cache holding pages rendered with the old menus (may be nil)
 //
 // Returns a fully initialized NavigationHandler ready to handle HTTP requests.
-func NewNavigationHandler(queries *sqlc.Queries, logger *slog.Logger) *NavigationHandler {
-	return &NavigationHandler{queries: queries, logger: logger}
+func NewNavigationHandler(queries sqlc.Querier, logger *slog.Logger, nav *services.Navigation, cache *services.Cache) *NavigationHandler {
+	return &NavigationHandler{queries: queries, logger: logger, nav: nav, cache: cache}
+}
+
+// changed reloads the menus served to visitors and drops cached pages,
+// whose header and footer show the old menus.
+func (h *NavigationHandler) changed(c echo.Context) {
+	if h.nav != nil {
+		if err := h.nav.Reload(c.Request().Context()); err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to reload navigation menus", "error", err)
+		}
+	}
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
 }
 
 // NavigationItemView is a template-friendly struct that includes child navigation items.
 // It embeds the base NavigationItem and adds a Children slice to represent hierarchical
-// menu structures (e.g., dropdown menus with parent and child items).
+// menu structures (e.g., dropdown menus with parent and child items, nested to any depth).
 type NavigationItemView struct {
-	sqlc.NavigationItem               // Embedded base navigation item (ID, Label, URL, etc.)
-	Children            []sqlc.NavigationItem // Child items for dropdown/nested menus
+	sqlc.NavigationItem                      // Embedded base navigation item (ID, Label, URL, etc.)
+	Children            []NavigationItemView // Child items for dropdown/nested menus
+}
+
+// navigationTree nests a menu's flat item list under their parents, keeping
+// the list order at each level. Items caught in a parent cycle are left out.
+func navigationTree(items []sqlc.NavigationItem) []NavigationItemView {
+	childrenMap := make(map[int64][]sqlc.NavigationItem)
+	for _, item := range items {
+		if item.ParentID.Valid {
+			childrenMap[item.ParentID.Int64] = append(childrenMap[item.ParentID.Int64], item)
+		}
+	}
+	visited := make(map[int64]bool)
+	var build func(item sqlc.NavigationItem) NavigationItemView
+	build = func(item sqlc.NavigationItem) NavigationItemView {
+		visited[item.ID] = true
+		view := NavigationItemView{NavigationItem: item}
+		for _, child := range childrenMap[item.ID] {
+			if !visited[child.ID] {
+				view.Children = append(view.Children, build(child))
+			}
+		}
+		return view
+	}
+	topLevel := []NavigationItemView{}
+	for _, item := range items {
+		if !item.ParentID.Valid {
+			topLevel = append(topLevel, build(item))
+		}
+	}
+	return topLevel
 }
 
 // List renders the navigation menus overview page showing all available menus.
@@ -70,7 +121,7 @@ func (h *NavigationHandler) List(c echo.Context) error {
 	// Retrieve all navigation menus from the database
 	menus, err := h.queries.ListNavigationMenus(ctx)
 	if err != nil {
-		h.logger.Error("failed to list navigation menus", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list navigation menus", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -114,7 +165,6 @@ func (h *NavigationHandler) List(c echo.Context) error {
 //   - id: Navigation menu ID to edit
 //
 // Query Parameters:
-//   - saved: Set to "1" to display a success message
 //
 // Returns:
 //   - 200 OK with rendered HTML editor on success
@@ -133,41 +183,21 @@ func (h *NavigationHandler) Edit(c echo.Context) error {
 	// Retrieve menu record
 	menu, err := h.queries.GetNavigationMenu(ctx, id)
 	if err != nil {
-		h.logger.Error("failed to get navigation menu", "error", err)
+		h.logger.ErrorContext(ctx, "failed to get navigation menu", "error", err)
 		return echo.NewHTTPError(http.StatusNotFound)
 	}
 
 	// Retrieve all items belonging to this menu
 	items, err := h.queries.ListNavigationItems(ctx, id)
 	if err != nil {
-		h.logger.Error("failed to list navigation items", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list navigation items", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Build hierarchical tree structure from flat item list
-	// Step 1: Create a map of parent items to their children
-	topLevel := []NavigationItemView{}
-	childrenMap := make(map[int64][]sqlc.NavigationItem)
-	for _, item := range items {
-		if item.ParentID.Valid {
-			// Item has a parent, add it to children map
-			childrenMap[item.ParentID.Int64] = append(childrenMap[item.ParentID.Int64], item)
-		}
-	}
-
-	// Step 2: Build top-level items with their children attached
-	for _, item := range items {
-		if !item.ParentID.Valid {
-			// Top-level item (no parent), attach any children from map
-			topLevel = append(topLevel, NavigationItemView{
-				NavigationItem: item,
-				Children:       childrenMap[item.ID], // May be nil if no children
-			})
-		}
-	}
+	topLevel := navigationTree(items)
 
 	// Check if this is a redirect after successful save operation
-	saved := c.QueryParam("saved") == "1"
 
 	// Predefined page options for quick link creation (internal CMS pages)
 	pageOptions := []string{
@@ -177,12 +207,13 @@ func (h *NavigationHandler) Edit(c echo.Context) error {
 
 	// Render the navigation editor with organized data
 	return c.Render(http.StatusOK, "admin/pages/navigation_editor.html", map[string]interface{}{
-		"Title":       fmt.Sprintf("Edit Menu: %s", menu.Name),
-		"Menu":        menu,        // Menu metadata
-		"Items":       topLevel,    // Hierarchical items for tree rendering
-		"AllItems":    items,       // Flat list of all items (for dropdown parent selection)
-		"Saved":       saved,       // Success flag
-		"PageOptions": pageOptions, // Predefined internal page options
+		"Title":        fmt.Sprintf("Edit Menu: %s", menu.Name),
+		"Menu":         menu,                            // Menu metadata
+		"Items":        topLevel,                        // Hierarchical items for tree rendering
+		"AllItems":     items,                           // Flat list of all items (for dropdown parent selection)
+		"PageOptions":  pageOptions,                     // Predefined internal page options
+		"Visibilities": services.NavigationVisibilities, // Layout visibility options for items
+		"Featured":     services.NavFeaturedTypes,       // Content types a dropdown can feature
 	})
 }
 
@@ -201,7 +232,7 @@ func (h *NavigationHandler) Edit(c echo.Context) error {
 //   - location: Menu location - "header", "footer", or "sidebar" (defaults to "header")
 //
 // Returns:
-//   - 303 See Other redirect to /admin/navigation/:id?saved=1 on success
+//   - 303 See Other redirect to /admin/navigation/:id on success
 //   - 500 Internal Server Error if database operation fails
 func (h *NavigationHandler) Create(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -223,7 +254,7 @@ func (h *NavigationHandler) Create(c echo.Context) error {
 		Location: location,
 	})
 	if err != nil {
-		h.logger.Error("failed to create navigation menu", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create navigation menu", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -231,7 +262,8 @@ func (h *NavigationHandler) Create(c echo.Context) error {
 	logActivity(c, "created", "navigation", 0, c.FormValue("name"), "Created Navigation Menu '%s'", c.FormValue("name"))
 
 	// Redirect to the menu editor for the newly created menu
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d?saved=1", menu.ID))
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Menu created.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d", menu.ID))
 }
 
 // DeleteMenu handles deletion of a navigation menu and all its items.
@@ -261,19 +293,22 @@ func (h *NavigationHandler) DeleteMenu(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
 
-	// Delete all items belonging to this menu first (cascading delete)
-	// This prevents foreign key constraint violations
-	if err := h.queries.DeleteNavigationItemsByMenu(ctx, id); err != nil {
-		h.logger.Error("failed to delete navigation items", "error", err)
-		// Continue to attempt menu deletion even if items fail
-	}
-
-	// Delete the menu record itself
-	if err := h.queries.DeleteNavigationMenu(ctx, id); err != nil {
-		h.logger.Error("failed to delete navigation menu", "error", err)
+	// Delete all items belonging to this menu first (cascading delete), then
+	// the menu record itself, in one transaction so that a failure leaves
+	// both in place
+	err = withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		if err := q.DeleteNavigationItemsByMenu(ctx, id); err != nil {
+			return err
+		}
+		return q.DeleteNavigationMenu(ctx, id)
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to delete navigation menu", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	h.changed(c)
+
 	// Log the deletion activity for audit trail
 	logActivity(c, "deleted", "navigation", id, "", "Deleted Navigation Menu #%d", id)
 
@@ -307,7 +342,7 @@ func (h *NavigationHandler) DeleteMenu(c echo.Context) error {
 //   - page_identifier: Page name for "page" type links (e.g., "Products", "About")
 //
 // Returns:
-//   - 303 See Other redirect to /admin/navigation/:id?saved=1 on success
+//   - 303 See Other redirect to /admin/navigation/:id on success
 //   - 400 Bad Request if menu ID is invalid
 //   - 500 Internal Server Error if database operation fails
 func (h *NavigationHandler) AddItem(c echo.Context) error {
@@ -341,7 +376,7 @@ func (h *NavigationHandler) AddItem(c echo.Context) error {
 	// For "page" type links, auto-generate URL from page identifier
 	// This ensures consistent internal linking (e.g., "Products" -> "/products")
 	if linkType == "page" && pageIdentifier != "" && url == "" {
-		url = "/" + slugifyNav(pageIdentifier) // Convert "Case Studies" to "/case-studies"
+		url = "/" + services.Slugify(pageIdentifier) // Convert "Case Studies" to "/case-studies"
 		label = pageIdentifier                 // Use page name as label if not provided
 	}
 
@@ -357,15 +392,18 @@ func (h *NavigationHandler) AddItem(c echo.Context) error {
 		SortOrder:      sql.NullInt64{Int64: nextSort, Valid: true},                       // Append to end
 	})
 	if err != nil {
-		h.logger.Error("failed to create navigation item", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create navigation item", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	h.changed(c)
+
 	// Log the update activity for audit trail
 	logActivity(c, "updated", "navigation", 0, "", "Updated Navigation Items")
 
 	// Redirect back to the menu editor
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d?saved=1", menuID))
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Menu item added.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d", menuID))
 }
 
 // UpdateItem handles updates to an existing navigation menu item.
@@ -375,8 +413,9 @@ func (h *NavigationHandler) AddItem(c echo.Context) error {
 // HTMX: Not used for this endpoint (standard form POST)
 // Template: None (redirects to Edit handler)
 //
-// This handler updates all editable fields of a menu item except ParentID and SortOrder,
-// which are managed separately through the drag-and-drop reorder functionality.
+// This handler updates all editable fields of a menu item except SortOrder, which is
+// managed separately through the drag-and-drop reorder functionality. Moving an item
+// under one of its own descendants is rejected.
 //
 // URL Parameters:
 //   - id: Navigation item ID to update
@@ -388,10 +427,16 @@ func (h *NavigationHandler) AddItem(c echo.Context) error {
 //   - page_identifier: Internal page identifier for "page" type links
 //   - open_new_tab: Checkbox - whether to open link in new tab
 //   - is_active: Checkbox - whether item is visible in menu
+//   - visibility: Layouts showing the item - "all", "desktop", or "mobile"
+//   - icon: Material Symbols icon name (invalid names are dropped)
+//   - layout: Dropdown layout - "list" or "mega" (top-level items)
+//   - featured_type, featured_id: Content featured as a card in the dropdown
+//   - parent_id: New parent item in the same menu (empty for top level); when
+//     absent the parent is kept
 //
 // Returns:
-//   - 303 See Other redirect to /admin/navigation/:menu_id?saved=1 on success
-//   - 400 Bad Request if item ID is invalid
+//   - 303 See Other redirect to /admin/navigation/:menu_id on success
+//   - 400 Bad Request if item ID or parent is invalid
 //   - 404 Not Found if item doesn't exist
 //   - 500 Internal Server Error if database operation fails
 func (h *NavigationHandler) UpdateItem(c echo.Context) error {
@@ -427,9 +472,30 @@ func (h *NavigationHandler) UpdateItem(c echo.Context) error {
 		isActiveInt = 1
 	}
 
+	// Move the item when the form names a parent; the editor always sends one
+	parentID := item.ParentID
+	if form, _ := c.FormParams(); form != nil {
+		if values, ok := form["parent_id"]; ok {
+			if parentID, err = h.parentFor(ctx, item, values[0]); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid parent item")
+			}
+		}
+	}
+
+	// Featured content needs a known type and ID, else the card is cleared
+	featuredType := c.FormValue("featured_type")
+	featuredID, _ := strconv.ParseInt(c.FormValue("featured_id"), 10, 64)
+	if !services.IsNavFeaturedType(featuredType) || featuredID <= 0 {
+		featuredType, featuredID = "", 0
+	}
+	layout := services.NavLayoutList
+	if c.FormValue("layout") == services.NavLayoutMega {
+		layout = services.NavLayoutMega
+	}
+
 	// Update the navigation item
-	// Note: ParentID and SortOrder are preserved from existing item
-	// These are managed separately through the Reorder endpoint
+	// Note: SortOrder is preserved from existing item
+	// It is managed separately through the Reorder endpoint
 	err = h.queries.UpdateNavigationItem(ctx, sqlc.UpdateNavigationItemParams{
 		ID:             itemID,
 		Label:          label,
@@ -438,19 +504,58 @@ func (h *NavigationHandler) UpdateItem(c echo.Context) error {
 		PageIdentifier: sql.NullString{String: pageIdentifier, Valid: pageIdentifier != ""},
 		OpenNewTab:     sql.NullInt64{Int64: openNewTabInt, Valid: true},
 		IsActive:       sql.NullInt64{Int64: isActiveInt, Valid: true},
-		ParentID:       item.ParentID,  // Preserve existing parent relationship
+		ParentID:       parentID,
 		SortOrder:      item.SortOrder, // Preserve existing sort position
+		Visibility:     services.NormalizeNavVisibility(c.FormValue("visibility")),
+		Icon:           services.NormalizeNavIcon(c.FormValue("icon")),
+		Layout:         layout,
+		FeaturedType:   featuredType,
+		FeaturedID:     featuredID,
 	})
 	if err != nil {
-		h.logger.Error("failed to update navigation item", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update navigation item", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	h.changed(c)
+
 	// Log the update activity for audit trail
 	logActivity(c, "updated", "navigation", 0, "", "Updated Navigation Items")
 
 	// Redirect back to the menu editor
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d?saved=1", item.MenuID))
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Menu item saved.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d", item.MenuID))
+}
+
+// parentFor resolves the parent_id submitted for item: empty means top level,
+// otherwise it must be another item of the same menu that is not nested
+// under item, which would make the menu a cycle.
+func (h *NavigationHandler) parentFor(ctx context.Context, item sqlc.NavigationItem, value string) (sql.NullInt64, error) {
+	if value == "" {
+		return sql.NullInt64{}, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	items, err := h.queries.ListNavigationItems(ctx, item.MenuID)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	parents := make(map[int64]sql.NullInt64, len(items))
+	for _, it := range items {
+		parents[it.ID] = it.ParentID
+	}
+	if _, ok := parents[id]; !ok {
+		return sql.NullInt64{}, errors.New("parent is not in this menu")
+	}
+	// Walk up from the new parent; reaching item means it would nest in itself
+	for cur, steps := (sql.NullInt64{Int64: id, Valid: true}), 0; cur.Valid && steps <= len(items); cur, steps = parents[cur.Int64], steps+1 {
+		if cur.Int64 == item.ID {
+			return sql.NullInt64{}, errors.New("parent is nested under the item")
+		}
+	}
+	return sql.NullInt64{Int64: id, Valid: true}, nil
 }
 
 // DeleteItem handles deletion of a navigation menu item.
@@ -484,10 +589,12 @@ func (h *NavigationHandler) DeleteItem(c echo.Context) error {
 	// Delete the navigation item
 	// Note: This does not cascade to children (they would become orphaned)
 	if err := h.queries.DeleteNavigationItem(ctx, itemID); err != nil {
-		h.logger.Error("failed to delete navigation item", "error", err)
+		h.logger.ErrorContext(ctx, "failed to delete navigation item", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	h.changed(c)
+
 	// Log the update activity for audit trail
 	logActivity(c, "updated", "navigation", 0, "", "Updated Navigation Items")
 
@@ -555,10 +662,12 @@ func (h *NavigationHandler) Reorder(c echo.Context) error {
 		if err != nil {
 			// Log error but continue processing other items
 			// This prevents one failure from blocking all reordering
-			h.logger.Error("failed to reorder navigation item", "error", err, "id", item.ID)
+			h.logger.ErrorContext(ctx, "failed to reorder navigation item", "error", err, "id", item.ID)
 		}
 	}
 
+	h.changed(c)
+
 	// Return success response
 	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
@@ -571,16 +680,19 @@ func (h *NavigationHandler) Reorder(c echo.Context) error {
 // Template: None (redirects to Edit handler)
 //
 // This handler updates the menu's name and location without affecting its items.
+// An active menu replaces the built-in links at its location on the public site;
+// activating it switches off any other active menu at the same location.
 //
 // URL Parameters:
 //   - id: Navigation menu ID to update
 //
 // Form Fields:
 //   - name: Menu name
-//   - location: Menu location - "header", "footer", or "sidebar"
+//   - location: Menu location - "header", "footer", "footer_legal", or "mobile"
+//   - is_active: Checkbox - whether the menu is rendered on the public site
 //
 // Returns:
-//   - 303 See Other redirect to /admin/navigation/:id?saved=1 on success
+//   - 303 See Other redirect to /admin/navigation/:id on success
 //   - 400 Bad Request if ID is invalid
 //   - 500 Internal Server Error if database operation fails
 func (h *NavigationHandler) UpdateMenu(c echo.Context) error {
@@ -596,52 +708,37 @@ func (h *NavigationHandler) UpdateMenu(c echo.Context) error {
 	name := c.FormValue("name")
 	location := c.FormValue("location")
 
-	// Update menu metadata
-	err = h.queries.UpdateNavigationMenu(ctx, sqlc.UpdateNavigationMenuParams{
-		ID:       id,
-		Name:     name,
-		Location: location,
+	// Update menu metadata and switch public rendering on or off, keeping one
+	// active menu per location. Both happen in one transaction, so a failed
+	// write cannot leave a location with two active menus or none
+	isActive := c.FormValue("is_active") == "on"
+	err = withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		err := q.UpdateNavigationMenu(ctx, sqlc.UpdateNavigationMenuParams{
+			ID:       id,
+			Name:     name,
+			Location: location,
+		})
+		if err != nil {
+			return err
+		}
+		if isActive {
+			err := q.DeactivateOtherNavigationMenus(ctx, sqlc.DeactivateOtherNavigationMenusParams{Location: location, ID: id})
+			if err != nil {
+				return err
+			}
+		}
+		return q.SetNavigationMenuActive(ctx, sqlc.SetNavigationMenuActiveParams{IsActive: isActive, ID: id})
 	})
 	if err != nil {
-		h.logger.Error("failed to update navigation menu", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update navigation menu", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	h.changed(c)
 
 	// Log the update activity for audit trail
 	logActivity(c, "updated", "navigation", id, c.FormValue("name"), "Updated Navigation Menu '%s'", c.FormValue("name"))
 
 	// Redirect back to the menu editor
-	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d?saved=1", id))
-}
-
-// slugifyNav converts a human-readable page identifier into a URL-safe slug.
-// It performs the following transformations:
-//   - Converts uppercase letters to lowercase
-//   - Converts spaces to hyphens
-//   - Preserves alphanumeric characters and hyphens
-//   - Removes all other special characters
-//
-// Examples:
-//   - "Case Studies" -> "case-studies"
-//   - "About Us" -> "about-us"
-//   - "Products & Services" -> "products--services"
-//
-// This function is used when creating navigation items of type "page" to automatically
-// generate consistent internal URLs from page identifiers.
-func slugifyNav(s string) string {
-	result := ""
-	for _, c := range s {
-		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' {
-			// Keep lowercase letters, numbers, and hyphens as-is
-			result += string(c)
-		} else if c >= 'A' && c <= 'Z' {
-			// Convert uppercase to lowercase (ASCII: A=65, a=97, difference=32)
-			result += string(c + 32)
-		} else if c == ' ' {
-			// Convert spaces to hyphens
-			result += "-"
-		}
-		// All other characters are silently dropped
-	}
-	return result
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Menu saved.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/navigation/%d", id))
 }
@@ -0,0 +1,108 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the 404 report: public paths that were not found, with
+// the pages linking to them and shortcuts to redirect them.
+package admin
+
+import (
+	"log/slog" // Structured logging
+	"net/http" // HTTP status codes
+	"time"     // Parsing last-seen timestamps
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database queries
+)
+
+const (
+	// notFoundReportLimit caps the paths shown, most requested first.
+	notFoundReportLimit = 200
+
+	// notFoundTopReferrers is how many referring pages are listed per path.
+	notFoundTopReferrers = 5
+)
+
+// NotFoundPath is one row of the 404 report.
+type NotFoundPath struct {
+	Path      string                          // Requested path
+	Hits      int64                           // 404s across all referrers
+	LastSeen  time.Time                       // Most recent 404 (UTC)
+	Referrers int64                           // Distinct referring pages
+	Top       []sqlc.ListNotFoundReferrersRow // Referring pages with the most hits
+}
+
+// NotFoundReportHandler handles the broken link report at /admin/404s.
+type NotFoundReportHandler struct {
+	queries sqlc.Querier // Database queries generated by sqlc
+	logger  *slog.Logger // Structured logger for error reporting
+}
+
+// NewNotFoundReportHandler creates a new NotFoundReportHandler instance.
+func NewNotFoundReportHandler(queries sqlc.Querier, logger *slog.Logger) *NotFoundReportHandler {
+	return &NotFoundReportHandler{queries: queries, logger: logger}
+}
+
+// List handles GET /admin/404s
+// Lists the most requested missing paths with their hit counts and top
+// referrers, each with a link that opens the redirect manager with the path
+// filled in.
+// Template: admin/pages/not_found.html (full page)
+func (h *NotFoundReportHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+	rows, err := h.queries.ListNotFoundPaths(ctx, notFoundReportLimit)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list 404s", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	refs, err := h.queries.ListNotFoundReferrers(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list 404 referrers", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	top := make(map[string][]sqlc.ListNotFoundReferrersRow)
+	for _, r := range refs {
+		if len(top[r.Path]) < notFoundTopReferrers {
+			top[r.Path] = append(top[r.Path], r)
+		}
+	}
+
+	paths := make([]NotFoundPath, 0, len(rows))
+	for _, r := range rows {
+		// CURRENT_TIMESTAMP format, in UTC
+		lastSeen, _ := time.Parse("2006-01-02 15:04:05", r.LastSeen)
+		paths = append(paths, NotFoundPath{
+			Path:      r.Path,
+			Hits:      r.Hits,
+			LastSeen:  lastSeen,
+			Referrers: r.Referrers,
+			Top:       top[r.Path],
+		})
+	}
+	return c.Render(http.StatusOK, "admin/pages/not_found.html", map[string]interface{}{
+		"Title": "404s",
+		"Paths": paths,
+		"Limit": notFoundReportLimit,
+	})
+}
+
+// Delete handles DELETE /admin/404s
+// Dismisses one path (?path=) from the report, or clears the whole report
+// when no path is given. A dismissed path reappears if it is requested again.
+// HTMX: returns an empty 200 response and the row (or every row) is removed.
+func (h *NotFoundReportHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+	path := c.QueryParam("path")
+	if path == "" {
+		if err := h.queries.DeleteAllNotFound(ctx); err != nil {
+			h.logger.ErrorContext(ctx, "failed to clear 404s", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		logActivity(c, "deleted", "not_found", 0, "", "Cleared the 404 report")
+		return c.NoContent(http.StatusOK)
+	}
+	if err := h.queries.DeleteNotFoundPath(ctx, path); err != nil {
+		h.logger.ErrorContext(ctx, "failed to dismiss 404", "error", err, "path", path)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "deleted", "not_found", 0, path, "Dismissed 404s for %s", path)
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,35 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file removes the cached public pages that show an edited product or
+// solution.
+package admin
+
+import (
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page cache and its tags
+)
+
+// invalidateProductPages removes the cached public pages that show the
+// product with id: its detail pages, the solution pages linking to it, the
+// products overview and A–Z index, and the pages of categoryIDs (its old and
+// new category when it moved) and the categories above them. Other products'
+// pages stay cached.
+func invalidateProductPages(cache *services.Cache, id int64, categoryIDs ...int64) {
+	tags := []string{services.ProductTag(id), services.ProductCatalogTag}
+	for _, categoryID := range categoryIDs {
+		tags = append(tags, services.ProductCategoryTag(categoryID))
+	}
+	cache.DeleteTagged(tags...)
+}
+
+// invalidateSolutionPages removes the cached page of the solution with id.
+// With listed, the pages listing every solution (the listing and the other
+// solution pages) are removed too: set it when the title, card fields, order
+// or published state may have changed, and leave it unset for edits to the
+// solution's stats, challenges, products and CTAs, which only its own page
+// shows.
+func invalidateSolutionPages(cache *services.Cache, id int64, listed bool) {
+	tags := []string{services.SolutionTag(id)}
+	if listed {
+		tags = append(tags, services.SolutionCatalogTag)
+	}
+	cache.DeleteTagged(tags...)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
 
 	// Internal imports
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
 	"github.com/narendhupati/bluejay-cms/db/sqlc" // Database query layer generated by sqlc
 )
 
@@ -27,8 +28,8 @@ import (
 // Sections are identified by PageKey (e.g., "home_hero", "about_team") and can be
 // activated/deactivated without deletion.
 type PageSectionsHandler struct {
-	queries *sqlc.Queries // Database query interface for page sections operations
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface for page sections operations
+	logger  *slog.Logger // Structured logger for error tracking
 }
 
 // NewPageSectionsHandler creates and initializes a new PageSectionsHandler instance.
@@ -39,7 +40,7 @@ type PageSectionsHandler struct {
 //   - logger: Structured logger for error and activity logging
 //
 // Returns a fully initialized PageSectionsHandler ready to handle HTTP requests.
-func NewPageSectionsHandler(queries *sqlc.Queries, logger *slog.Logger) *PageSectionsHandler {
+func NewPageSectionsHandler(queries sqlc.Querier, logger *slog.Logger) *PageSectionsHandler {
 	return &PageSectionsHandler{queries: queries, logger: logger}
 }
 
@@ -64,7 +65,7 @@ func (h *PageSectionsHandler) List(c echo.Context) error {
 	// Retrieve all page sections from database
 	sections, err := h.queries.ListAllPageSections(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load page sections", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load page sections", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -104,7 +105,6 @@ func (h *PageSectionsHandler) List(c echo.Context) error {
 //   - id: Page section ID to edit
 //
 // Query Parameters:
-//   - saved: Set to "1" to display a success message after form submission
 //
 // Returns:
 //   - 200 OK with rendered HTML form on success
@@ -120,18 +120,17 @@ func (h *PageSectionsHandler) Edit(c echo.Context) error {
 	// Retrieve section record from database
 	section, err := h.queries.GetPageSectionByID(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to load page section", "error", err, "id", id)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load page section", "error", err, "id", id)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Check if this is a redirect after successful save operation
-	saved := c.QueryParam("saved") == "1"
 
 	// Render the section editor form with current section data
 	return c.Render(http.StatusOK, "admin/pages/page_sections_form.html", map[string]interface{}{
-		"Title":   "Edit Page Section",
-		"Section": section, // Current section data for form population
-		"Saved":   saved,   // Success flag to show confirmation message
+		"Title":        "Edit Page Section",
+		"Section":      section,                                 // Current section data for form population
+		"LanguageTabs": languageTabs(c, "page_section", id, ""), // Links to the translation editors
 	})
 }
 
@@ -160,10 +159,10 @@ func (h *PageSectionsHandler) Edit(c echo.Context) error {
 //   - is_active: Checkbox - whether section is visible on the website
 //
 // Note: The checkbox is_active converts from "on" string to boolean.
-// After successful update, redirects back to the edit form with saved=1.
+// After successful update, redirects back to the edit form with a success flash message.
 //
 // Returns:
-//   - 303 See Other redirect to /admin/page-sections/:id/edit?saved=1 on success
+//   - 303 See Other redirect to /admin/page-sections/:id/edit on success
 //   - 400 Bad Request if ID is invalid
 //   - 500 Internal Server Error if database update fails
 func (h *PageSectionsHandler) Update(c echo.Context) error {
@@ -189,10 +188,11 @@ func (h *PageSectionsHandler) Update(c echo.Context) error {
 	})
 
 	if err != nil {
-		h.logger.Error("failed to update page section", "error", err, "id", id)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update page section", "error", err, "id", id)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Redirect back to the edit form with saved parameter to show success message
-	return c.Redirect(http.StatusSeeOther, "/admin/page-sections/"+c.Param("id")+"/edit?saved=1")
+	// Redirect back; the page shows the flash message
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Section saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/page-sections/"+c.Param("id")+"/edit")
 }
@@ -0,0 +1,258 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the partner portal: invite links that let a partner
+// send updates to its own listing, and the approval queue those updates
+// wait in before they go live.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"slices"       // Status filter validation
+	"strconv"      // Parsing IDs
+	"time"         // Marking expired invites
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Partner portal service and page cache
+)
+
+// PartnerPortalHandler handles partner invites at
+// /admin/partners/:id/portal and the submission queue at
+// /admin/partners/submissions.
+type PartnerPortalHandler struct {
+	queries sqlc.Querier            // Database queries generated by sqlc
+	portal  *services.PartnerPortal // Invites and reviews
+	logger  *slog.Logger            // Structured logger for error reporting
+	cache   *services.Cache         // Public page cache, cleared after approvals
+}
+
+// NewPartnerPortalHandler creates a new PartnerPortalHandler instance.
+func NewPartnerPortalHandler(queries sqlc.Querier, portal *services.PartnerPortal, logger *slog.Logger, cache *services.Cache) *PartnerPortalHandler {
+	return &PartnerPortalHandler{queries: queries, portal: portal, logger: logger, cache: cache}
+}
+
+// Portal handles GET /admin/partners/:id/portal
+// Shows the invite form, the partner's invite links and its submissions.
+// Template: admin/pages/partner_portal.html (full page)
+func (h *PartnerPortalHandler) Portal(c echo.Context) error {
+	partner, err := h.partner(c)
+	if err != nil {
+		return err
+	}
+	return h.renderPortal(c, http.StatusOK, partner, map[string]interface{}{})
+}
+
+// Invite handles POST /admin/partners/:id/invites
+// Creates a portal link and emails it when an address is given. The page
+// shows the link once; only its hash is stored.
+//
+// Form Fields: email (optional)
+// Template: admin/pages/partner_portal.html (full page)
+func (h *PartnerPortalHandler) Invite(c echo.Context) error {
+	partner, err := h.partner(c)
+	if err != nil {
+		return err
+	}
+	email := c.FormValue("email")
+	link, err := h.portal.Invite(c.Request().Context(), partner, email)
+	if errors.Is(err, services.ErrPartnerInviteEmail) {
+		return h.renderPortal(c, http.StatusUnprocessableEntity, partner, map[string]interface{}{
+			"Error": "Enter a valid email address or leave it empty.",
+			"Email": email,
+		})
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create partner invite", "error", err, "partner", partner.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "partner_invite", partner.ID, partner.Name, "Created a portal link for partner %s", partner.Name)
+	return h.renderPortal(c, http.StatusOK, partner, map[string]interface{}{
+		"InviteLink": link,
+		"Emailed":    email != "",
+	})
+}
+
+// RevokeInvite handles POST /admin/partners/invites/:id/revoke
+// Disables a portal link and returns to the partner's portal page.
+// Submissions already sent through it stay in the queue.
+func (h *PartnerPortalHandler) RevokeInvite(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	invite, err := h.queries.GetPartnerInvite(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load partner invite", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if err := h.queries.RevokePartnerInvite(c.Request().Context(), invite.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to revoke partner invite", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "updated", "partner_invite", invite.PartnerID, "", "Revoked portal link #%d", invite.ID)
+	return c.Redirect(http.StatusSeeOther, "/admin/partners/"+strconv.FormatInt(invite.PartnerID, 10)+"/portal")
+}
+
+// Submissions handles GET /admin/partners/submissions
+// Lists partner submissions, the pending ones by default.
+//
+// Query Parameters:
+//   - status: pending (default), approved, rejected, superseded or "all"
+//
+// Template: admin/pages/partner_submissions_list.html (full page)
+func (h *PartnerPortalHandler) Submissions(c echo.Context) error {
+	status := c.QueryParam("status")
+	filter := status
+	switch {
+	case status == "":
+		status, filter = services.PartnerSubmissionPending, services.PartnerSubmissionPending
+	case status == "all":
+		filter = ""
+	case !slices.Contains(services.PartnerSubmissionStatuses, status):
+		status, filter = "all", ""
+	}
+	submissions, err := h.queries.ListPartnerSubmissions(c.Request().Context(), sqlc.ListPartnerSubmissionsParams{FilterStatus: filter})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list partner submissions", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/partner_submissions_list.html", map[string]interface{}{
+		"Title":       "Partner Submissions",
+		"Submissions": submissions,
+		"Statuses":    services.PartnerSubmissionStatuses,
+		"Status":      status,
+	})
+}
+
+// Submission handles GET /admin/partners/submissions/:id
+// Shows the partner's live listing next to the proposed changes, with the
+// approve and reject form while the submission is pending.
+// Template: admin/pages/partner_submission_detail.html (full page)
+func (h *PartnerPortalHandler) Submission(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	sub, err := h.queries.GetPartnerSubmission(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load partner submission", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	partner, err := h.queries.GetPartner(ctx, sub.PartnerID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load partner", "error", err, "id", sub.PartnerID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	var requestedTier string
+	if sub.RequestedTierID.Valid {
+		if tier, err := h.queries.GetPartnerTier(ctx, sub.RequestedTierID.Int64); err == nil {
+			requestedTier = tier.Name
+		}
+	}
+	var inviteEmail string
+	if sub.InviteID.Valid {
+		if invite, err := h.queries.GetPartnerInvite(ctx, sub.InviteID.Int64); err == nil {
+			inviteEmail = invite.Email
+		}
+	}
+	return c.Render(http.StatusOK, "admin/pages/partner_submission_detail.html", map[string]interface{}{
+		"Title":         "Submission: " + partner.Name,
+		"Submission":    sub,
+		"Partner":       partner,
+		"RequestedTier": requestedTier,
+		"InviteEmail":   inviteEmail,
+	})
+}
+
+// Review handles POST /admin/partners/submissions/:id/review
+// Approves a pending submission, which updates the live partner listing,
+// or rejects it. Returns to the queue.
+//
+// Form Fields: action ("approve" or "reject"), notes
+func (h *PartnerPortalHandler) Review(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	var sub sqlc.PartnerSubmission
+	action := c.FormValue("action")
+	switch action {
+	case "approve":
+		sub, err = h.portal.Approve(ctx, id, c.FormValue("notes"))
+	case "reject":
+		sub, err = h.portal.Reject(ctx, id, c.FormValue("notes"))
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid action")
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return echo.NewHTTPError(http.StatusNotFound)
+	case errors.Is(err, services.ErrPartnerSubmissionReviewed):
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, "This submission has already been reviewed.")
+		return c.Redirect(http.StatusSeeOther, "/admin/partners/submissions/"+strconv.FormatInt(id, 10))
+	case err != nil:
+		h.logger.ErrorContext(ctx, "failed to review partner submission", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if action == "approve" {
+		h.cache.DeleteByPrefix("page:partners")
+		logActivity(c, "updated", "partner", sub.PartnerID, "", "Approved partner submission #%d", sub.ID)
+	} else {
+		logActivity(c, "updated", "partner_submission", sub.ID, "", "Rejected partner submission #%d", sub.ID)
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/partners/submissions")
+}
+
+// partner loads the partner named by the :id parameter, returning an HTTP
+// error for a bad or unknown ID.
+func (h *PartnerPortalHandler) partner(c echo.Context) (sqlc.GetPartnerRow, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.GetPartnerRow{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	partner, err := h.queries.GetPartner(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return partner, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load partner", "error", err, "id", id)
+		return partner, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return partner, nil
+}
+
+// renderPortal renders a partner's portal page with its invites and
+// submissions, adding them to data.
+func (h *PartnerPortalHandler) renderPortal(c echo.Context, status int, partner sqlc.GetPartnerRow, data map[string]interface{}) error {
+	ctx := c.Request().Context()
+	invites, err := h.queries.ListPartnerInvites(ctx, partner.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list partner invites", "error", err, "partner", partner.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	submissions, err := h.queries.ListPartnerSubmissions(ctx, sqlc.ListPartnerSubmissionsParams{FilterPartnerID: partner.ID})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list partner submissions", "error", err, "partner", partner.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	data["Title"] = "Partner Portal: " + partner.Name
+	data["Partner"] = partner
+	data["Invites"] = invites
+	data["Submissions"] = submissions
+	data["InviteDays"] = int(services.PartnerInviteTTL.Hours() / 24)
+	data["Now"] = time.Now().UTC()
+	return c.Render(status, "admin/pages/partner_portal.html", data)
+}
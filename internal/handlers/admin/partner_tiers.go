@@ -12,21 +12,22 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database query methods
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database query methods
+	"github.com/narendhupati/bluejay-cms/internal/services" // Unique slug generation
 )
 
 // PartnerTiersHandler manages HTTP requests for partner tier CRUD operations.
 // Partner tiers are used to categorize partners into levels/categories (e.g., "Platinum", "Gold").
 // It depends on database queries and structured logging.
 type PartnerTiersHandler struct {
-	queries *sqlc.Queries  // Database query interface generated by sqlc
-	logger  *slog.Logger   // Structured logger for error tracking and debugging
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error tracking and debugging
 }
 
 // NewPartnerTiersHandler constructs a new PartnerTiersHandler with required dependencies.
 // This constructor is called during application initialization to wire up the handler
 // with database access and logging capabilities.
-func NewPartnerTiersHandler(queries *sqlc.Queries, logger *slog.Logger) *PartnerTiersHandler {
+func NewPartnerTiersHandler(queries sqlc.Querier, logger *slog.Logger) *PartnerTiersHandler {
 	return &PartnerTiersHandler{queries: queries, logger: logger}
 }
 
@@ -45,7 +46,7 @@ func (h *PartnerTiersHandler) List(c echo.Context) error {
 	// Fetch all partner tiers from the database (ordered by sort_order)
 	items, err := h.queries.ListPartnerTiers(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list partner tiers", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list partner tiers", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -90,7 +91,7 @@ func (h *PartnerTiersHandler) New(c echo.Context) error {
 //
 // Business Logic:
 //   - Converts form string values to appropriate types (int64 for sort_order)
-//   - Auto-generates slug from name using makeSlug helper function
+//   - Auto-generates slug from name using uniqueSlug
 //   - Slug is used for URL-friendly tier identification
 //   - Logs admin activity for audit trail
 //   - Redirects to partner tiers list on success (303 See Other)
@@ -98,15 +99,21 @@ func (h *PartnerTiersHandler) Create(c echo.Context) error {
 	// Convert sort_order from string to int64
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugPartnerTiers, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate partner tier slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Create new partner tier with form data
-	_, err := h.queries.CreatePartnerTier(c.Request().Context(), sqlc.CreatePartnerTierParams{
+	_, err = h.queries.CreatePartnerTier(c.Request().Context(), sqlc.CreatePartnerTierParams{
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),  // Auto-generate URL-friendly slug from name
+		Slug:        slug,                           // Auto-generate URL-friendly slug from name
 		Description: c.FormValue("description"),
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to create partner tier", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create partner tier", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -168,7 +175,7 @@ func (h *PartnerTiersHandler) Edit(c echo.Context) error {
 //
 // Business Logic:
 //   - Converts form string values to appropriate types (int64 for sort_order)
-//   - Auto-generates slug from name using makeSlug helper function (slug updates with name)
+//   - Auto-generates slug from name using uniqueSlug (slug updates with name)
 //   - Logs admin activity for audit trail
 //   - Redirects to partner tiers list on success (303 See Other)
 func (h *PartnerTiersHandler) Update(c echo.Context) error {
@@ -178,16 +185,22 @@ func (h *PartnerTiersHandler) Update(c echo.Context) error {
 	// Convert sort_order from string to int64
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 
+	slug, err := uniqueSlug(c, h.queries, services.SlugPartnerTiers, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate partner tier slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Update partner tier with form data
-	_, err := h.queries.UpdatePartnerTier(c.Request().Context(), sqlc.UpdatePartnerTierParams{
+	_, err = h.queries.UpdatePartnerTier(c.Request().Context(), sqlc.UpdatePartnerTierParams{
 		ID:          id,
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),  // Regenerate slug from updated name
+		Slug:        slug,                           // Regenerate slug from updated name
 		Description: c.FormValue("description"),
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to update partner tier", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update partner tier", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -220,7 +233,7 @@ func (h *PartnerTiersHandler) Delete(c echo.Context) error {
 	// Delete partner tier from database (hard delete)
 	// This will fail if any partners reference this tier due to foreign key constraints
 	if err := h.queries.DeletePartnerTier(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete partner tier", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete partner tier", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
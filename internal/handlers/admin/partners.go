@@ -22,15 +22,15 @@ import (
 // It depends on database queries, structured logging, and a cache service for
 // invalidating cached partner page content when data changes.
 type PartnersHandler struct {
-	queries *sqlc.Queries    // Database query interface generated by sqlc
-	logger  *slog.Logger     // Structured logger for error tracking and debugging
-	cache   *services.Cache  // Cache service to invalidate "page:partners" entries on mutations
+	queries sqlc.Querier    // Database query interface generated by sqlc
+	logger  *slog.Logger    // Structured logger for error tracking and debugging
+	cache   *services.Cache // Cache service to invalidate "page:partners" entries on mutations
 }
 
 // NewPartnersHandler constructs a new PartnersHandler with required dependencies.
 // This constructor is called during application initialization to wire up the handler
 // with database access, logging, and caching capabilities.
-func NewPartnersHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *PartnersHandler {
+func NewPartnersHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *PartnersHandler {
 	return &PartnersHandler{queries: queries, logger: logger, cache: cache}
 }
 
@@ -57,11 +57,13 @@ func (h *PartnersHandler) List(c echo.Context) error {
 	// Fetch all partners from the database (includes tier name via JOIN)
 	partners, err := h.queries.ListAllPartners(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list partners", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list partners", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	// Load partner tiers for the filter dropdown (errors ignored - non-critical)
 	tiers, _ := h.queries.ListPartnerTiers(c.Request().Context())
+	// Count portal submissions awaiting review for the Submissions button (errors ignored - non-critical)
+	pending, _ := h.queries.CountPendingPartnerSubmissions(c.Request().Context())
 
 	// Extract filter parameters from query string
 	search := strings.TrimSpace(c.QueryParam("search"))
@@ -106,6 +108,7 @@ func (h *PartnersHandler) List(c echo.Context) error {
 		"TierFilter": tierFilterID,     // Preserve tier filter in UI
 		"Status":     status,           // Preserve status filter in UI
 		"HasFilters": hasFilters,       // Flag to show "clear filters" button
+		"Pending":    pending,          // Portal submissions awaiting review
 	})
 }
 
@@ -170,7 +173,7 @@ func (h *PartnersHandler) Create(c echo.Context) error {
 		DisplayOrder: order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create partner", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create partner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -279,7 +282,7 @@ func (h *PartnersHandler) Update(c echo.Context) error {
 		ID:           id,
 	})
 	if err != nil {
-		h.logger.Error("failed to update partner", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update partner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -315,7 +318,7 @@ func (h *PartnersHandler) Delete(c echo.Context) error {
 
 	// Delete partner from database (hard delete)
 	if err := h.queries.DeletePartner(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete partner", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete partner", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -350,7 +353,7 @@ func (h *PartnersHandler) TestimonialsList(c echo.Context) error {
 	// Fetch all testimonials from the database (includes partner name via JOIN)
 	items, err := h.queries.ListActiveTestimonials(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list testimonials", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list testimonials", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -418,7 +421,7 @@ func (h *PartnersHandler) TestimonialCreate(c echo.Context) error {
 		DisplayOrder: order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -521,7 +524,7 @@ func (h *PartnersHandler) TestimonialUpdate(c echo.Context) error {
 		ID:           id,
 	})
 	if err != nil {
-		h.logger.Error("failed to update testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -556,7 +559,7 @@ func (h *PartnersHandler) TestimonialDelete(c echo.Context) error {
 
 	// Delete testimonial from database (hard delete)
 	if err := h.queries.DeleteTestimonial(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete testimonial", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete testimonial", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
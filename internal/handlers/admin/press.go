@@ -0,0 +1,483 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the press newsroom on /press: press releases, the
+// "In the news" links to coverage elsewhere and the media kit downloads.
+package admin
+
+import (
+	"database/sql"  // sql.ErrNoRows detection
+	"errors"        // Error inspection
+	"log/slog"      // Structured logging
+	"net/http"      // HTTP status codes
+	"os"            // Removing media kit files
+	"path/filepath" // Media kit file paths
+	"strconv"       // Parsing IDs and the sort order
+	"strings"       // Trimming form values
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Slugs, uploads, site timezone and page cache
+)
+
+// pressReleaseTimeLayout is the value format of the release time input.
+const pressReleaseTimeLayout = "2006-01-02T15:04"
+
+// pressMentionDateLayout is the value format of the "In the news" date input.
+const pressMentionDateLayout = "2006-01-02"
+
+// PressHandler handles the press newsroom at /admin/press.
+type PressHandler struct {
+	queries   sqlc.Querier            // Database queries generated by sqlc
+	logger    *slog.Logger            // Structured logger for error reporting
+	cache     *services.Cache         // Public page cache, cleared after each change
+	uploads   *services.UploadService // Stores media kit files
+	uploadDir string                  // Upload root, for deleting media kit files
+}
+
+// NewPressHandler creates a new PressHandler instance.
+func NewPressHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache, uploads *services.UploadService, uploadDir string) *PressHandler {
+	return &PressHandler{queries: queries, logger: logger, cache: cache, uploads: uploads, uploadDir: uploadDir}
+}
+
+// List handles GET /admin/press
+// Lists every press release, latest release first.
+// Template: admin/pages/press_list.html (full page)
+func (h *PressHandler) List(c echo.Context) error {
+	releases, err := h.queries.ListPressReleasesAdmin(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list press releases", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/press_list.html", map[string]interface{}{
+		"Title":    "Press Releases",
+		"Section":  "releases",
+		"Releases": releases,
+	})
+}
+
+// New handles GET /admin/press/new
+// Template: admin/pages/press_form.html (full page)
+func (h *PressHandler) New(c echo.Context) error {
+	return h.renderForm(c, http.StatusOK, sqlc.PressRelease{}, "")
+}
+
+// Create handles POST /admin/press
+// Adds a press release and returns to the list. Invalid input is reported
+// on the form.
+func (h *PressHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	release, msg := h.form(c, 0)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, release, msg)
+	}
+	created, err := h.queries.CreatePressRelease(ctx, sqlc.CreatePressReleaseParams{
+		Title:           release.Title,
+		Slug:            release.Slug,
+		Summary:         release.Summary,
+		Location:        release.Location,
+		Body:            release.Body,
+		ReleasedAt:      release.ReleasedAt,
+		IsPublished:     release.IsPublished,
+		MetaDescription: release.MetaDescription,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create press release", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, "", services.PressReleasePath(created))
+	h.changed()
+	logActivity(c, "created", "press_release", created.ID, created.Title, "Created press release %q", created.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/press")
+}
+
+// Edit handles GET /admin/press/:id/edit
+// Template: admin/pages/press_form.html (full page)
+func (h *PressHandler) Edit(c echo.Context) error {
+	release, err := h.release(c)
+	if err != nil {
+		return err
+	}
+	return h.renderForm(c, http.StatusOK, release, "")
+}
+
+// Update handles POST /admin/press/:id
+// Saves the press release form. A changed slug leaves a redirect from the
+// old page.
+func (h *PressHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, err := h.release(c)
+	if err != nil {
+		return err
+	}
+	release, msg := h.form(c, existing.ID)
+	release.ID = existing.ID
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, release, msg)
+	}
+	err = h.queries.UpdatePressRelease(ctx, sqlc.UpdatePressReleaseParams{
+		Title:           release.Title,
+		Slug:            release.Slug,
+		Summary:         release.Summary,
+		Location:        release.Location,
+		Body:            release.Body,
+		ReleasedAt:      release.ReleasedAt,
+		IsPublished:     release.IsPublished,
+		MetaDescription: release.MetaDescription,
+		ID:              existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update press release", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	recordSlugChange(c, h.logger, services.PressReleasePath(existing), services.PressReleasePath(release))
+	h.changed()
+	logActivity(c, "updated", "press_release", existing.ID, release.Title, "Updated press release %q", release.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/press")
+}
+
+// Delete handles DELETE /admin/press/:id
+// Removes a press release. HTMX: returns an empty 200 response and the row
+// is removed.
+func (h *PressHandler) Delete(c echo.Context) error {
+	release, err := h.release(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeletePressRelease(c.Request().Context(), release.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete press release", "error", err, "id", release.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "deleted", "press_release", release.ID, release.Title, "Deleted press release %q", release.Title)
+	return c.NoContent(http.StatusOK)
+}
+
+// Mentions handles GET /admin/press/mentions
+// Lists the "In the news" links, newest first.
+// Template: admin/pages/press_mentions_list.html (full page)
+func (h *PressHandler) Mentions(c echo.Context) error {
+	mentions, err := h.queries.ListPressMentionsAdmin(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list press mentions", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/press_mentions_list.html", map[string]interface{}{
+		"Title":    "In the News",
+		"Section":  "mentions",
+		"Mentions": mentions,
+	})
+}
+
+// NewMention handles GET /admin/press/mentions/new
+// Template: admin/pages/press_mention_form.html (full page)
+func (h *PressHandler) NewMention(c echo.Context) error {
+	return h.renderMentionForm(c, http.StatusOK, sqlc.PressMention{IsPublished: 1}, "")
+}
+
+// CreateMention handles POST /admin/press/mentions
+// Adds an "In the news" link and returns to the list.
+func (h *PressHandler) CreateMention(c echo.Context) error {
+	ctx := c.Request().Context()
+	mention, msg := h.mentionForm(c)
+	if msg != "" {
+		return h.renderMentionForm(c, http.StatusUnprocessableEntity, mention, msg)
+	}
+	created, err := h.queries.CreatePressMention(ctx, sqlc.CreatePressMentionParams{
+		Outlet:      mention.Outlet,
+		Headline:    mention.Headline,
+		Url:         mention.Url,
+		PublishedOn: mention.PublishedOn,
+		IsPublished: mention.IsPublished,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create press mention", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "created", "press_mention", created.ID, created.Headline, "Added %s coverage %q", created.Outlet, created.Headline)
+	return c.Redirect(http.StatusSeeOther, "/admin/press/mentions")
+}
+
+// EditMention handles GET /admin/press/mentions/:id/edit
+// Template: admin/pages/press_mention_form.html (full page)
+func (h *PressHandler) EditMention(c echo.Context) error {
+	mention, err := h.mention(c)
+	if err != nil {
+		return err
+	}
+	return h.renderMentionForm(c, http.StatusOK, mention, "")
+}
+
+// UpdateMention handles POST /admin/press/mentions/:id
+// Saves the "In the news" link form.
+func (h *PressHandler) UpdateMention(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, err := h.mention(c)
+	if err != nil {
+		return err
+	}
+	mention, msg := h.mentionForm(c)
+	mention.ID = existing.ID
+	if msg != "" {
+		return h.renderMentionForm(c, http.StatusUnprocessableEntity, mention, msg)
+	}
+	err = h.queries.UpdatePressMention(ctx, sqlc.UpdatePressMentionParams{
+		Outlet:      mention.Outlet,
+		Headline:    mention.Headline,
+		Url:         mention.Url,
+		PublishedOn: mention.PublishedOn,
+		IsPublished: mention.IsPublished,
+		ID:          existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update press mention", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "updated", "press_mention", existing.ID, mention.Headline, "Updated %s coverage %q", mention.Outlet, mention.Headline)
+	return c.Redirect(http.StatusSeeOther, "/admin/press/mentions")
+}
+
+// DeleteMention handles DELETE /admin/press/mentions/:id
+// Removes an "In the news" link. HTMX: returns an empty 200 response and
+// the row is removed.
+func (h *PressHandler) DeleteMention(c echo.Context) error {
+	mention, err := h.mention(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeletePressMention(c.Request().Context(), mention.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete press mention", "error", err, "id", mention.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "deleted", "press_mention", mention.ID, mention.Headline, "Deleted %s coverage %q", mention.Outlet, mention.Headline)
+	return c.NoContent(http.StatusOK)
+}
+
+// MediaKit handles GET /admin/press/media-kit
+// Lists the media kit files in display order with the upload form.
+// Template: admin/pages/press_kit.html (full page)
+func (h *PressHandler) MediaKit(c echo.Context) error {
+	return h.renderMediaKit(c, http.StatusOK, "")
+}
+
+// UploadKitAsset handles POST /admin/press/media-kit
+// Adds an uploaded file to the media kit. A missing title is taken from
+// the file name.
+//
+// Form Fields: file (required), title, description, sort_order
+func (h *PressHandler) UploadKitAsset(c echo.Context) error {
+	ctx := c.Request().Context()
+	file, err := c.FormFile("file")
+	if err != nil {
+		return h.renderMediaKit(c, http.StatusUnprocessableEntity, "Choose a file to upload.")
+	}
+	path, err := h.uploads.UploadPressKitAsset(file)
+	if err != nil {
+		return h.renderMediaKit(c, http.StatusUnprocessableEntity, "Upload failed: "+err.Error())
+	}
+	title := strings.TrimSpace(c.FormValue("title"))
+	if title == "" {
+		title = strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))
+	}
+	order, _ := strconv.ParseInt(strings.TrimSpace(c.FormValue("sort_order")), 10, 64)
+	asset, err := h.queries.CreatePressKitAsset(ctx, sqlc.CreatePressKitAssetParams{
+		Title:       title,
+		Description: strings.TrimSpace(c.FormValue("description")),
+		FilePath:    path,
+		FileSize:    file.Size,
+		SortOrder:   order,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create press kit asset", "error", err)
+		h.removeUpload(path)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed()
+	logActivity(c, "created", "press_kit_asset", asset.ID, asset.Title, "Added %q to the media kit", asset.Title)
+	return c.Redirect(http.StatusSeeOther, "/admin/press/media-kit")
+}
+
+// DeleteKitAsset handles DELETE /admin/press/media-kit/:id
+// Removes a file from the media kit and deletes the upload. HTMX: returns
+// an empty 200 response and the row is removed.
+func (h *PressHandler) DeleteKitAsset(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	asset, err := h.queries.GetPressKitAsset(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load press kit asset", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if err := h.queries.DeletePressKitAsset(ctx, asset.ID); err != nil {
+		h.logger.ErrorContext(ctx, "failed to delete press kit asset", "error", err, "id", asset.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.removeUpload(asset.FilePath)
+	h.changed()
+	logActivity(c, "deleted", "press_kit_asset", asset.ID, asset.Title, "Removed %q from the media kit", asset.Title)
+	return c.NoContent(http.StatusOK)
+}
+
+// release loads the press release named by the :id parameter, returning
+// an HTTP error for a bad or unknown ID.
+func (h *PressHandler) release(c echo.Context) (sqlc.PressRelease, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.PressRelease{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	release, err := h.queries.GetPressRelease(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return release, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load press release", "error", err, "id", id)
+		return release, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return release, nil
+}
+
+// mention loads the "In the news" link named by the :id parameter,
+// returning an HTTP error for a bad or unknown ID.
+func (h *PressHandler) mention(c echo.Context) (sqlc.PressMention, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.PressMention{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	mention, err := h.queries.GetPressMention(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return mention, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load press mention", "error", err, "id", id)
+		return mention, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return mention, nil
+}
+
+// changed drops the cached /press pages, which were rendered before the
+// change.
+func (h *PressHandler) changed() {
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:press")
+	}
+}
+
+// removeUpload deletes a media kit file given its public path. Errors are
+// ignored: the file may already be gone.
+func (h *PressHandler) removeUpload(path string) {
+	if strings.HasPrefix(path, "/uploads/press/") {
+		os.Remove(filepath.Join(h.uploadDir, strings.TrimPrefix(path, "/uploads/")))
+	}
+}
+
+// form reads and validates the press release form for release id (0 for
+// a new one). The release time is entered in the site timezone and
+// returned in UTC. It returns a message for the form on invalid input.
+func (h *PressHandler) form(c echo.Context, id int64) (sqlc.PressRelease, string) {
+	release := sqlc.PressRelease{
+		Title:           strings.TrimSpace(c.FormValue("title")),
+		Slug:            strings.TrimSpace(c.FormValue("slug")),
+		Summary:         strings.TrimSpace(c.FormValue("summary")),
+		Location:        strings.TrimSpace(c.FormValue("location")),
+		Body:            strings.TrimSpace(c.FormValue("body")),
+		MetaDescription: strings.TrimSpace(c.FormValue("meta_description")),
+		IsPublished:     checkboxValue(c.FormValue("is_published")),
+	}
+	released, timeErr := services.ParseSiteTime(pressReleaseTimeLayout, c.FormValue("released_at"))
+	release.ReleasedAt = released
+
+	switch {
+	case release.Title == "":
+		return release, "Give the press release a title."
+	case timeErr != nil:
+		return release, "Enter when the press release goes out."
+	case release.Body == "":
+		return release, "Write the text of the press release."
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugPressReleases, release.Slug, release.Title, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate press release slug", "error", err)
+		return release, "Could not find a free address for this press release; enter another slug."
+	}
+	release.Slug = slug
+	return release, ""
+}
+
+// mentionForm reads and validates the "In the news" link form. It returns
+// a message for the form on invalid input.
+func (h *PressHandler) mentionForm(c echo.Context) (sqlc.PressMention, string) {
+	mention := sqlc.PressMention{
+		Outlet:      strings.TrimSpace(c.FormValue("outlet")),
+		Headline:    strings.TrimSpace(c.FormValue("headline")),
+		Url:         strings.TrimSpace(c.FormValue("url")),
+		IsPublished: checkboxValue(c.FormValue("is_published")),
+	}
+	published, dateErr := services.ParseSiteTime(pressMentionDateLayout, c.FormValue("published_on"))
+	mention.PublishedOn = published
+
+	switch {
+	case mention.Outlet == "":
+		return mention, "Enter the outlet that covered you."
+	case mention.Headline == "":
+		return mention, "Enter the headline of the article."
+	case !isWebURL(mention.Url):
+		return mention, "Enter the article's address, a full http(s):// URL."
+	case dateErr != nil:
+		return mention, "Enter the date the article appeared."
+	}
+	return mention, ""
+}
+
+// renderForm shows the add or edit form for release (ID 0 for a new one).
+func (h *PressHandler) renderForm(c echo.Context, status int, release sqlc.PressRelease, errMsg string) error {
+	title, action := "New Press Release", "/admin/press"
+	if release.ID != 0 {
+		title, action = "Edit Press Release", "/admin/press/"+strconv.FormatInt(release.ID, 10)
+	}
+	return c.Render(status, "admin/pages/press_form.html", map[string]interface{}{
+		"Title":      title,
+		"Item":       release,
+		"FormAction": action,
+		"Error":      errMsg,
+	})
+}
+
+// renderMentionForm shows the add or edit form for mention (ID 0 for a
+// new one).
+func (h *PressHandler) renderMentionForm(c echo.Context, status int, mention sqlc.PressMention, errMsg string) error {
+	title, action := "New Coverage Link", "/admin/press/mentions"
+	if mention.ID != 0 {
+		title, action = "Edit Coverage Link", "/admin/press/mentions/"+strconv.FormatInt(mention.ID, 10)
+	}
+	return c.Render(status, "admin/pages/press_mention_form.html", map[string]interface{}{
+		"Title":      title,
+		"Item":       mention,
+		"FormAction": action,
+		"Error":      errMsg,
+	})
+}
+
+// renderMediaKit shows the media kit files with the upload form.
+func (h *PressHandler) renderMediaKit(c echo.Context, status int, errMsg string) error {
+	assets, err := h.queries.ListPressKitAssets(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list press kit assets", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(status, "admin/pages/press_kit.html", map[string]interface{}{
+		"Title":      "Media Kit",
+		"Section":    "kit",
+		"Assets":     assets,
+		"Extensions": strings.Join(services.PressKitExtensions, ","),
+		"Error":      errMsg,
+	})
+}
@@ -0,0 +1,33 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file builds the signed preview links shown on content edit pages.
+package admin
+
+import (
+	"net/url" // Query escaping for the token
+
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Preview token query parameter name
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // PreviewTokens issuer
+)
+
+// previewTokens issues the tokens behind the "Preview" buttons. Like
+// activityLog it is set once at startup so edit handlers need no extra
+// constructor argument; when it is nil the buttons are hidden.
+var previewTokens *services.PreviewTokens
+
+// SetPreviewTokens sets the package-level preview token issuer.
+//
+// Example:
+//
+//	admin.SetPreviewTokens(services.NewPreviewTokens(sessionSecret, services.DefaultPreviewTTL))
+func SetPreviewTokens(tokens *services.PreviewTokens) {
+	previewTokens = tokens
+}
+
+// previewURL returns the public path with a fresh preview token for the
+// contentType item id, or "" when preview tokens are not configured.
+func previewURL(path, contentType string, id int64) string {
+	if previewTokens == nil {
+		return ""
+	}
+	return path + "?" + customMiddleware.PreviewTokenParam + "=" + url.QueryEscape(previewTokens.Issue(contentType, id))
+}
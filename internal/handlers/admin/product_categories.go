@@ -5,73 +5,105 @@
 package admin
 
 import (
+	"context"      // Request context for loading the category tree
 	"database/sql" // Used for nullable database types (sql.NullString)
+	"errors"       // Parent validation error detection
 	"log/slog"     // Structured logging for error messages
 	"net/http"     // HTTP status codes
-	"regexp"       // Regular expressions for slug generation
 	"strconv"      // String to integer conversion for form values and URL parameters
-	"strings"      // String manipulation for slug generation
+	"strings"      // Detecting an empty submitted slug
 
-	"github.com/labstack/echo/v4"                 // Echo web framework for routing and context
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database queries
+	"github.com/labstack/echo/v4"                           // Echo web framework for routing and context
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Category hierarchy and depth rules
 )
 
-// slugRegexp is a compiled regular expression that matches any character
-// that is NOT a lowercase letter, digit, or hyphen. Used for cleaning slugs.
-var slugRegexp = regexp.MustCompile(`[^a-z0-9-]+`)
-
-// makeSlug converts a string into a URL-friendly slug format.
-// It performs the following transformations:
-//   1. Converts to lowercase
-//   2. Trims whitespace from ends
-//   3. Replaces spaces with hyphens
-//   4. Removes all characters except a-z, 0-9, and hyphens
-//
-// Example: "Product Category Name" -> "product-category-name"
-//
-// This function is used to generate slugs from category and product names
-// for use in URLs throughout the frontend.
-func makeSlug(s string) string {
-	s = strings.ToLower(strings.TrimSpace(s))
-	s = strings.ReplaceAll(s, " ", "-")
-	s = slugRegexp.ReplaceAllString(s, "")
-	return s
+// uniqueSlug returns the slug to store for a row of table: the submitted
+// slug, or the name when none was submitted, normalized by services.Slugify
+// and suffixed -2, -3, ... when another row of table already uses it.
+//
+// Parameters:
+//   - table: One of the services.Slug* table constants
+//   - slug: Slug field of the form ("" when the form has none or it was left empty)
+//   - name: Name or title the slug is generated from
+//   - id: Row being updated; 0 when creating
+func uniqueSlug(c echo.Context, queries sqlc.Querier, table, slug, name string, id int64) (string, error) {
+	if strings.TrimSpace(slug) == "" {
+		slug = name
+	}
+	return services.NewSlugService(queries).Unique(c.Request().Context(), table, slug, id)
 }
 
 // ProductCategoriesHandler handles HTTP requests for product category management.
 // Categories are used to organize products and provide navigation/filtering functionality.
 // All handlers return full HTML pages (not fragments) except for delete operations.
 type ProductCategoriesHandler struct {
-	queries *sqlc.Queries // Database queries generated by sqlc
-	logger  *slog.Logger  // Structured logger for error reporting
+	queries sqlc.Querier // Database queries generated by sqlc
+	logger  *slog.Logger // Structured logger for error reporting
 }
 
 // NewProductCategoriesHandler creates and returns a new ProductCategoriesHandler instance.
 // This constructor is typically called during application initialization when wiring up handlers.
-func NewProductCategoriesHandler(queries *sqlc.Queries, logger *slog.Logger) *ProductCategoriesHandler {
+func NewProductCategoriesHandler(queries sqlc.Querier, logger *slog.Logger) *ProductCategoriesHandler {
 	return &ProductCategoriesHandler{queries: queries, logger: logger}
 }
 
+// categoryTree loads every product category linked into its hierarchy.
+func (h *ProductCategoriesHandler) categoryTree(ctx context.Context) (*services.CategoryTree, error) {
+	categories, err := h.queries.ListProductCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return services.BuildCategoryTree(categories, nil), nil
+}
+
+// parentErrorMessage turns a services.CategoryTree.ValidateParent error into
+// form feedback for the editor.
+func parentErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, services.ErrCategoryCycle):
+		return "A category cannot be placed under itself or one of its own subcategories."
+	case errors.Is(err, services.ErrCategoryTooDeep):
+		return "Categories can be nested at most 3 levels deep (family → series → type). Choose a higher parent or move the subcategories first."
+	default:
+		return "The selected parent category no longer exists."
+	}
+}
+
+// renderForm renders the category form with parent options that exclude the
+// category itself and its subcategories.
+func (h *ProductCategoriesHandler) renderForm(c echo.Context, status int, tree *services.CategoryTree, title, action string, item interface{}, id int64, formErr string) error {
+	return c.Render(status, "admin/pages/product_categories_form.html", map[string]interface{}{
+		"Title":         title,
+		"FormAction":    action,
+		"Item":          item,
+		"ParentOptions": tree.Options(id, services.MaxCategoryDepth), // Only parents that leave room for a child level
+		"Error":         formErr,
+	})
+}
+
 // List handles GET requests to /admin/product-categories
-// Renders the product categories list page showing all categories.
+// Renders the product categories list page showing all categories, with
+// subcategories indented beneath their parents.
 //
 // Template: admin/pages/product_categories_list.html (full page render)
 // HTMX: This endpoint returns a full HTML page, not a fragment
 //
 // The list displays category name, slug, icon, sort order, and action buttons.
-// Categories are typically ordered by sort_order field.
+// Siblings are ordered by sort_order field.
 func (h *ProductCategoriesHandler) List(c echo.Context) error {
-	// Fetch all product categories from database
-	items, err := h.queries.ListProductCategories(c.Request().Context())
+	// Fetch all product categories and link them into the hierarchy
+	tree, err := h.categoryTree(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list product categories", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list product categories", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Render the category list page
+	// Render the category list page in depth-first order
 	return c.Render(http.StatusOK, "admin/pages/product_categories_list.html", map[string]interface{}{
-		"Title": "Product Categories",
-		"Items": items,
+		"Title":    "Product Categories",
+		"Items":    tree.Flatten(),
+		"MaxDepth": services.MaxCategoryDepth,
 	})
 }
 
@@ -81,14 +113,36 @@ func (h *ProductCategoriesHandler) List(c echo.Context) error {
 // Template: admin/pages/product_categories_form.html (full page render)
 // HTMX: This endpoint returns a full HTML page, not a fragment
 //
-// The form includes fields for name, description, icon, image URL, and sort order.
+// The form includes fields for name, parent category, description, icon,
+// image URL, and sort order. ?parent=<id> preselects the parent category.
 func (h *ProductCategoriesHandler) New(c echo.Context) error {
-	// Render the form template with no existing item (Item: nil indicates new category)
-	return c.Render(http.StatusOK, "admin/pages/product_categories_form.html", map[string]interface{}{
-		"Title":      "New Product Category",
-		"FormAction": "/admin/product-categories", // POST to this endpoint for creation
-		"Item":       nil,                         // No existing category data
-	})
+	tree, err := h.categoryTree(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list product categories", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// A nil item indicates a new category; a parent-only item preselects ?parent
+	var item interface{}
+	if parentID, _ := strconv.ParseInt(c.QueryParam("parent"), 10, 64); parentID > 0 {
+		item = sqlc.ProductCategory{ParentID: sql.NullInt64{Int64: parentID, Valid: true}}
+	}
+	return h.renderForm(c, http.StatusOK, tree, "New Product Category", "/admin/product-categories", item, 0, "")
+}
+
+// categoryFromForm reads the category form fields into a ProductCategory,
+// used to re-render the form after a validation error.
+func categoryFromForm(c echo.Context, id, parentID, sortOrder int64) sqlc.ProductCategory {
+	imageUrl := c.FormValue("image_url")
+	return sqlc.ProductCategory{
+		ID:          id,
+		Name:        c.FormValue("name"),
+		Description: c.FormValue("description"),
+		Icon:        c.FormValue("icon"),
+		ImageUrl:    sql.NullString{String: imageUrl, Valid: imageUrl != ""},
+		SortOrder:   sortOrder,
+		ParentID:    sql.NullInt64{Int64: parentID, Valid: parentID > 0},
+	}
 }
 
 // Create handles POST requests to /admin/product-categories
@@ -96,36 +150,78 @@ func (h *ProductCategoriesHandler) New(c echo.Context) error {
 //
 // Form Fields:
 //   - name: Required category name
+//   - parent_id: Parent category ID (empty for a top-level family)
 //   - description: Category description text
 //   - icon: Icon identifier or class name (e.g., "fa-box", "icon-electronics")
 //   - image_url: Optional category image/banner URL
 //   - sort_order: Display order (lower numbers appear first)
 //
 // On Success: Redirects to /admin/product-categories (HTTP 303 See Other)
+// On Invalid Parent: Re-renders the form with an error (HTTP 400)
 // On Error: Returns HTTP error with appropriate status code
 //
 // Side Effects:
-//   - Auto-generates slug from name using makeSlug helper
+//   - Auto-generates a unique slug from name using uniqueSlug
 //   - Logs activity to audit trail
 func (h *ProductCategoriesHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
 	// Parse numeric and optional form values
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
+	parentID, _ := strconv.ParseInt(c.FormValue("parent_id"), 10, 64)
 	imageUrl := c.FormValue("image_url")
 
-	// Insert new category into database
-	_, err := h.queries.CreateProductCategory(c.Request().Context(), sqlc.CreateProductCategoryParams{
-		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")), // Auto-generate URL-friendly slug from name
-		Description: c.FormValue("description"),
-		Icon:        c.FormValue("icon"),
-		ImageUrl:    sql.NullString{String: imageUrl, Valid: imageUrl != ""}, // Only store if provided
-		SortOrder:   sortOrder,
+	// Check the parent exists and leaves room for another level
+	tree, err := h.categoryTree(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list product categories", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if err := tree.ValidateParent(0, parentID); err != nil {
+		return h.renderForm(c, http.StatusBadRequest, tree, "New Product Category", "/admin/product-categories",
+			categoryFromForm(c, 0, parentID, sortOrder), 0, parentErrorMessage(err))
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugProductCategories, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate product category slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// Insert new category into database, with its parent in the same
+	// transaction so a failure cannot leave it at the top level
+	var created sqlc.ProductCategory
+	err = withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		var err error
+		created, err = q.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+			Name:        c.FormValue("name"),
+			Slug:        slug, // Unique URL-friendly slug generated from name
+			Description: c.FormValue("description"),
+			Icon:        c.FormValue("icon"),
+			ImageUrl:    sql.NullString{String: imageUrl, Valid: imageUrl != ""}, // Only store if provided
+			SortOrder:   sortOrder,
+		})
+		if err != nil || parentID == 0 {
+			return err
+		}
+		return q.SetProductCategoryParent(ctx, sqlc.SetProductCategoryParentParams{
+			ParentID: sql.NullInt64{Int64: parentID, Valid: true},
+			ID:       created.ID,
+		})
 	})
 	if err != nil {
-		h.logger.Error("failed to create product category", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create product category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	// No redirect left by an earlier category may shadow the new one's pages
+	if tree, err := h.categoryTree(ctx); err == nil {
+		if node := tree.ByID(created.ID); node != nil {
+			recordSlugChange(c, h.logger, "", node.URL())
+		}
+	}
+	recordSlugChange(c, h.logger, "", "/products/"+slug+"/*")
+
 	// Log this action to the admin activity log for audit trail
 	logActivity(c, "created", "product_category", 0, c.FormValue("name"), "Created Product Category '%s'", c.FormValue("name"))
 
@@ -153,12 +249,14 @@ func (h *ProductCategoriesHandler) Edit(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
 	}
 
-	// Render the form with existing category data
-	return c.Render(http.StatusOK, "admin/pages/product_categories_form.html", map[string]interface{}{
-		"Title":      "Edit Product Category",
-		"FormAction": "/admin/product-categories/" + c.Param("id"), // POST to this URL for update
-		"Item":       item,                                         // Pre-fill form with existing data
-	})
+	tree, err := h.categoryTree(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list product categories", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// Render the form with existing category data; POST to this URL for update
+	return h.renderForm(c, http.StatusOK, tree, "Edit Product Category", "/admin/product-categories/"+c.Param("id"), item, id, "")
 }
 
 // Update handles POST requests to /admin/product-categories/:id
@@ -170,6 +268,9 @@ func (h *ProductCategoriesHandler) Edit(c echo.Context) error {
 // Form Fields: Same as Create handler (see Create documentation)
 //
 // On Success: Redirects to /admin/product-categories (HTTP 303 See Other)
+// On Invalid Parent: Re-renders the form with an error (HTTP 400) when the
+// parent is the category itself, one of its subcategories, or too deep to
+// hold the category's subtree
 // On Error: Returns HTTP error with appropriate status code
 //
 // Special Handling:
@@ -179,26 +280,67 @@ func (h *ProductCategoriesHandler) Edit(c echo.Context) error {
 //   - Logs activity to audit trail
 func (h *ProductCategoriesHandler) Update(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	ctx := c.Request().Context()
 
 	// Parse form values
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
+	parentID, _ := strconv.ParseInt(c.FormValue("parent_id"), 10, 64)
 	imageUrl := c.FormValue("image_url")
 
-	// Update the category record with new values
-	_, err := h.queries.UpdateProductCategory(c.Request().Context(), sqlc.UpdateProductCategoryParams{
-		ID:          id,
-		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")), // Regenerate slug in case name changed
-		Description: c.FormValue("description"),
-		Icon:        c.FormValue("icon"),
-		ImageUrl:    sql.NullString{String: imageUrl, Valid: imageUrl != ""}, // Only store if provided
-		SortOrder:   sortOrder,
+	// Reject cycles and moves that would nest the subtree too deep
+	tree, err := h.categoryTree(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list product categories", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if err := tree.ValidateParent(id, parentID); err != nil {
+		return h.renderForm(c, http.StatusBadRequest, tree, "Edit Product Category", "/admin/product-categories/"+c.Param("id"),
+			categoryFromForm(c, id, parentID, sortOrder), id, parentErrorMessage(err))
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugProductCategories, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate product category slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// Update the category record with new values and its parent together
+	err = withTx(ctx, h.queries, func(q sqlc.Querier) error {
+		_, err := q.UpdateProductCategory(ctx, sqlc.UpdateProductCategoryParams{
+			ID:          id,
+			Name:        c.FormValue("name"),
+			Slug:        slug, // Regenerated in case name changed
+			Description: c.FormValue("description"),
+			Icon:        c.FormValue("icon"),
+			ImageUrl:    sql.NullString{String: imageUrl, Valid: imageUrl != ""}, // Only store if provided
+			SortOrder:   sortOrder,
+		})
+		if err != nil {
+			return err
+		}
+		return q.SetProductCategoryParent(ctx, sqlc.SetProductCategoryParentParams{
+			ParentID: sql.NullInt64{Int64: parentID, Valid: parentID > 0},
+			ID:       id,
+		})
 	})
 	if err != nil {
-		h.logger.Error("failed to update product category", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update product category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	// Old links follow the category page, and the pages of its products and
+	// subcategories, to the new slug or parent
+	if node := tree.ByID(id); node != nil {
+		if newTree, err := h.categoryTree(ctx); err == nil {
+			if moved := newTree.ByID(id); moved != nil {
+				recordSlugChange(c, h.logger, node.URL(), moved.URL())
+			}
+		}
+		if node.Category.Slug != slug {
+			recordSlugChange(c, h.logger, "/products/"+node.Category.Slug+"/*", "/products/"+slug+"/*")
+		}
+	}
+
 	// Log update to audit trail
 	logActivity(c, "updated", "product_category", id, c.FormValue("name"), "Updated Product Category '%s'", c.FormValue("name"))
 
@@ -215,21 +357,32 @@ func (h *ProductCategoriesHandler) Update(c echo.Context) error {
 // HTMX: Returns either an empty response (HTTP 204 No Content) on success,
 // or a text error message (HTTP 409 Conflict) if deletion is blocked by foreign key.
 //
-// Important: Categories cannot be deleted if they still have products assigned to them.
-// The database foreign key constraint will prevent deletion and return a helpful error message.
+// Important: Categories cannot be deleted if they still have subcategories or products
+// assigned to them. Subcategories are checked first; the database foreign key constraint
+// prevents deletion while products remain. Both cases return a helpful error message.
 //
 // Side Effects:
 //   - Logs deletion to audit trail (only if successful)
 func (h *ProductCategoriesHandler) Delete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 
+	// Subcategories must be moved or deleted first
+	children, err := h.queries.CountChildProductCategories(c.Request().Context(), sql.NullInt64{Int64: id, Valid: true})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to count subcategories", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if children > 0 {
+		return c.String(http.StatusConflict, "Cannot delete this category because it has subcategories. Please move or delete its subcategories first.")
+	}
+
 	// Attempt to delete the category from database
 	if err := h.queries.DeleteProductCategory(c.Request().Context(), id); err != nil {
 		// Check if deletion failed due to foreign key constraint (products still assigned)
 		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
 			return c.String(http.StatusConflict, "Cannot delete this category because it still has products assigned to it. Please reassign or remove those products first.")
 		}
-		h.logger.Error("failed to delete product category", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete product category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
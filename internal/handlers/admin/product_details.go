@@ -5,37 +5,45 @@
 package admin
 
 import (
+	"context"       // Request context for the download version lookup
 	"database/sql"  // Used for nullable database types (sql.NullString, sql.NullInt64)
 	"fmt"           // Used for error formatting and string operations
 	"html/template" // Used for rendering partial HTML templates
+	"io"            // Streaming gated download files
 	"log/slog"      // Structured logging for error messages
+	"mime"          // File name of streamed download files
 	"net/http"      // HTTP status codes and content type headers
+	"path"          // File name of streamed download files
 	"path/filepath" // Used for constructing template file paths
 	"strconv"       // String to integer conversion for URL params and form values
+	"strings"       // Trimming alt text
 
-	"github.com/labstack/echo/v4"                           // Echo web framework for routing and context
-	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
-	"github.com/narendhupati/bluejay-cms/internal/services" // Upload service for file and image handling
+	"github.com/labstack/echo/v4"                                              // Echo web framework for routing and context
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages and form tokens
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Upload service for file and image handling
 )
 
 // ProductDetailsHandler handles HTTP requests for managing product details and related entities.
 // This includes specs, features, certifications, downloads, and images. All handlers return
 // HTML fragments suitable for HTMX swapping rather than full page renders.
 type ProductDetailsHandler struct {
-	queries   *sqlc.Queries                 // Database queries generated by sqlc
+	queries   sqlc.Querier                  // Database queries generated by sqlc
 	logger    *slog.Logger                  // Structured logger for error reporting
 	uploadSvc *services.UploadService       // Service for handling file and image uploads
+	files     *services.DownloadFiles       // Moves gated download files out of the public uploads
 	partials  map[string]*template.Template // Pre-parsed partial templates for performance
 }
 
 // NewProductDetailsHandler creates and returns a new ProductDetailsHandler instance.
 // It pre-loads all partial templates during initialization for better performance.
 // This constructor is typically called during application initialization.
-func NewProductDetailsHandler(queries *sqlc.Queries, logger *slog.Logger, uploadSvc *services.UploadService) *ProductDetailsHandler {
+func NewProductDetailsHandler(queries sqlc.Querier, logger *slog.Logger, uploadSvc *services.UploadService, files *services.DownloadFiles) *ProductDetailsHandler {
 	h := &ProductDetailsHandler{
 		queries:   queries,
 		logger:    logger,
 		uploadSvc: uploadSvc,
+		files:     files,
 		partials:  make(map[string]*template.Template),
 	}
 	// Pre-parse all partial templates at initialization
@@ -50,17 +58,19 @@ func (h *ProductDetailsHandler) loadPartials() {
 	basePath := "templates"
 	// List of partial template names (without .html extension)
 	names := []string{
-		"product_specs",          // Specification table partial
-		"product_features",       // Features list partial
-		"product_certifications", // Certifications grid partial
-		"product_downloads",      // Downloads table partial
-		"product_images",         // Image gallery partial
+		"product_specs",           // Specification table partial
+		"product_features",        // Features list partial
+		"product_certifications",  // Certifications grid partial
+		"product_downloads",       // Downloads table partial
+		"product_images",          // Image gallery partial
+		"product_variants",        // Variants with spec overrides and images
+		"product_related_content", // Pinned case studies, blog posts and whitepapers
 	}
 	// Parse each partial template and store in map
 	for _, name := range names {
-		h.partials[name] = template.Must(template.ParseFiles(
-			filepath.Join(basePath, "admin/partials", name+".html"),
-		))
+		h.partials[name] = template.Must(template.New(name + ".html").
+			Funcs(template.FuncMap{"formToken": customMiddleware.NewFormToken}).
+			ParseFiles(filepath.Join(basePath, "admin/partials", name+".html")))
 	}
 }
 
@@ -100,7 +110,7 @@ func (h *ProductDetailsHandler) ListSpecs(c echo.Context) error {
 	// Fetch all specifications for this product
 	specs, err := h.queries.ListProductSpecs(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to list specs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list specs", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -140,7 +150,7 @@ func (h *ProductDetailsHandler) AddSpec(c echo.Context) error {
 		DisplayOrder: order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create spec", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create spec", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -164,7 +174,7 @@ func (h *ProductDetailsHandler) DeleteSpecs(c echo.Context) error {
 
 	// Delete all specs for this product
 	if err := h.queries.DeleteProductSpecs(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete specs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete specs", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -182,7 +192,7 @@ func (h *ProductDetailsHandler) DeleteSpec(c echo.Context) error {
 	specID, _ := strconv.ParseInt(c.Param("spec_id"), 10, 64)
 
 	if err := h.queries.DeleteProductSpec(c.Request().Context(), specID); err != nil {
-		h.logger.Error("failed to delete spec", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete spec", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -205,7 +215,7 @@ func (h *ProductDetailsHandler) UpdateSpec(c echo.Context) error {
 		DisplayOrder: order,
 		ID:           specID,
 	}); err != nil {
-		h.logger.Error("failed to update spec", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update spec", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -231,7 +241,7 @@ func (h *ProductDetailsHandler) ListFeatures(c echo.Context) error {
 	// Fetch all features for this product
 	features, err := h.queries.ListProductFeatures(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to list features", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list features", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -266,7 +276,7 @@ func (h *ProductDetailsHandler) AddFeature(c echo.Context) error {
 		DisplayOrder: order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create feature", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create feature", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -290,7 +300,7 @@ func (h *ProductDetailsHandler) DeleteFeatures(c echo.Context) error {
 
 	// Delete all features for this product
 	if err := h.queries.DeleteProductFeatures(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete features", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete features", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -308,7 +318,7 @@ func (h *ProductDetailsHandler) DeleteFeature(c echo.Context) error {
 	featureID, _ := strconv.ParseInt(c.Param("feature_id"), 10, 64)
 
 	if err := h.queries.DeleteProductFeature(c.Request().Context(), featureID); err != nil {
-		h.logger.Error("failed to delete feature", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete feature", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -329,7 +339,7 @@ func (h *ProductDetailsHandler) UpdateFeature(c echo.Context) error {
 		DisplayOrder: order,
 		ID:           featureID,
 	}); err != nil {
-		h.logger.Error("failed to update feature", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update feature", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -355,7 +365,7 @@ func (h *ProductDetailsHandler) ListCertifications(c echo.Context) error {
 	// Fetch all certifications for this product
 	certs, err := h.queries.ListProductCertifications(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to list certifications", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list certifications", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -401,7 +411,7 @@ func (h *ProductDetailsHandler) AddCertification(c echo.Context) error {
 		DisplayOrder:      order,
 	})
 	if err != nil {
-		h.logger.Error("failed to create certification", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create certification", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -425,7 +435,7 @@ func (h *ProductDetailsHandler) DeleteCertifications(c echo.Context) error {
 
 	// Delete all certifications for this product
 	if err := h.queries.DeleteProductCertifications(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete certifications", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete certifications", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -443,7 +453,7 @@ func (h *ProductDetailsHandler) DeleteCertification(c echo.Context) error {
 	certID, _ := strconv.ParseInt(c.Param("cert_id"), 10, 64)
 
 	if err := h.queries.DeleteProductCertification(c.Request().Context(), certID); err != nil {
-		h.logger.Error("failed to delete certification", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete certification", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -471,7 +481,7 @@ func (h *ProductDetailsHandler) UpdateCertification(c echo.Context) error {
 		DisplayOrder:      order,
 		ID:                certID,
 	}); err != nil {
-		h.logger.Error("failed to update certification", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update certification", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -481,6 +491,8 @@ func (h *ProductDetailsHandler) UpdateCertification(c echo.Context) error {
 
 // --- Product Downloads Section ---
 // Downloads are files like manuals, datasheets, CAD models, etc.
+// A revised file is added as a new version that supersedes the current one;
+// the versions of a file are listed together, newest first.
 
 // ListDownloads handles GET requests to /admin/products/:id/downloads
 // Returns the downloads table as an HTML fragment for HTMX swap.
@@ -488,27 +500,73 @@ func (h *ProductDetailsHandler) UpdateCertification(c echo.Context) error {
 // URL Parameters:
 //   - id: Product ID
 //
+// Query Parameters:
+//   - edit: Download whose metadata form is open
+//   - supersedes: Download preselected in the add form's "New version of"
+//
 // Template: admin/partials/product_downloads.html (partial fragment)
 // HTMX: Returns HTML fragment that replaces the downloads container
 func (h *ProductDetailsHandler) ListDownloads(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	editingID, _ := strconv.ParseInt(c.QueryParam("edit"), 10, 64)
+	supersedesID, _ := strconv.ParseInt(c.QueryParam("supersedes"), 10, 64)
 
 	// Fetch all downloadable files for this product
 	downloads, err := h.queries.ListProductDownloads(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to list downloads", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list downloads", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	// List each file's current version followed by its older versions
+	var rows []downloadRow
+	var current []sqlc.ProductDownload
+	supersedesGated := false
+	for _, group := range services.GroupDownloadVersions(downloads) {
+		rows = append(rows, downloadRow{ProductDownload: group.Latest, Versions: len(group.Older) + 1})
+		for _, older := range group.Older {
+			rows = append(rows, downloadRow{ProductDownload: older, Superseded: true})
+		}
+		current = append(current, group.Latest)
+		if group.Latest.ID == supersedesID && group.Latest.IsGated == 1 {
+			supersedesGated = true
+		}
+	}
+
 	// Render the downloads partial template
 	return h.renderPartial(c, "product_downloads", map[string]interface{}{
-		"ProductID": id,
-		"Downloads": downloads,
-		"EditingID": editingID,
+		"ProductID":    id,
+		"Downloads":    rows,
+		"Current":      current, // Files a new version can be added to
+		"EditingID":    editingID,
+		"SupersedesID": supersedesID,
+		"GateNew":      supersedesGated, // A new version of a gated file starts out gated
 	})
 }
 
+// downloadRow is one line of the admin downloads list.
+type downloadRow struct {
+	sqlc.ProductDownload
+	Superseded bool // An older version, listed under the current one
+	Versions   int  // Versions of the file, on the current version's line
+}
+
+// currentDownload returns the download of product that supersedesID names
+// when it is the current version of its file, the only version a new
+// version may supersede.
+func (h *ProductDetailsHandler) currentDownload(ctx context.Context, productID, supersedesID int64) (sqlc.ProductDownload, bool, error) {
+	downloads, err := h.queries.ListProductDownloads(ctx, productID)
+	if err != nil {
+		return sqlc.ProductDownload{}, false, err
+	}
+	for _, group := range services.GroupDownloadVersions(downloads) {
+		if group.Latest.ID == supersedesID {
+			return group.Latest, true, nil
+		}
+	}
+	return sqlc.ProductDownload{}, false, nil
+}
+
 // AddDownload handles POST requests to /admin/products/:id/downloads
 // Uploads a file and creates a new download record, then returns the updated downloads table.
 //
@@ -521,12 +579,18 @@ func (h *ProductDetailsHandler) ListDownloads(c echo.Context) error {
 //   - description: Optional description text
 //   - file_type: Optional manual file type (auto-detected from extension if not provided)
 //   - version: Optional version number
+//   - changelog: Optional summary of what changed in this version
+//   - supersedes_id: Optional current download this file is a new version of;
+//     the new version takes its place in the list
+//   - is_gated: Checkbox (value "1" if checked) - visitors get the file only
+//     after the lead form
 //   - display_order: Sort order for display
 //
 // HTMX: Returns updated downloads table fragment after successful upload
 //
 // Side Effects:
 //   - Uploads file to disk/storage via UploadService
+//   - Moves the file of a gated download out of the public uploads
 //   - Stores file metadata including size and type
 func (h *ProductDetailsHandler) AddDownload(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -539,10 +603,25 @@ func (h *ProductDetailsHandler) AddDownload(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "File is required")
 	}
 
+	// A new version replaces the current version of a file of this product
+	supersedesID, _ := strconv.ParseInt(c.FormValue("supersedes_id"), 10, 64)
+	if supersedesID != 0 {
+		previous, ok, err := h.currentDownload(ctx, id, supersedesID)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to list downloads", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		if !ok {
+			customMiddleware.AddFlash(c, customMiddleware.FlashError, "A new version can only replace the current version of a file of this product.")
+			return h.ListDownloads(c)
+		}
+		order = previous.DisplayOrder
+	}
+
 	// Upload the file using the upload service
 	path, err := h.uploadSvc.UploadProductDownload(fileHeader)
 	if err != nil {
-		h.logger.Error("failed to upload download", "error", err)
+		h.logger.ErrorContext(ctx, "failed to upload download", "error", err)
 		return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload file: "+err.Error())
 	}
 
@@ -550,6 +629,11 @@ func (h *ProductDetailsHandler) AddDownload(c echo.Context) error {
 	desc := c.FormValue("description")
 	version := c.FormValue("version")
 	fileType := c.FormValue("file_type")
+	changelog := strings.TrimSpace(c.FormValue("changelog"))
+	gated := int64(0) // Checkbox sends "1" if checked
+	if c.FormValue("is_gated") == "1" {
+		gated = 1
+	}
 
 	// Auto-detect file type from extension if not manually specified
 	if fileType == "" {
@@ -566,14 +650,25 @@ func (h *ProductDetailsHandler) AddDownload(c echo.Context) error {
 		FileSize:     sql.NullInt64{Int64: fileHeader.Size, Valid: true},    // Store actual file size in bytes
 		Version:      sql.NullString{String: version, Valid: version != ""}, // Only store if provided
 		DisplayOrder: order,
+		Changelog:    changelog,
+		SupersedesID: sql.NullInt64{Int64: supersedesID, Valid: supersedesID != 0}, // NULL for a new file
+		IsGated:      gated,
 	})
 	if err != nil {
-		h.logger.Error("failed to create download", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create download", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if err := h.files.Sync(ctx, path); err != nil {
+		h.logger.ErrorContext(ctx, "failed to move download file", "error", err, "path", path)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log the activity for audit trail
-	logActivity(c, "updated", "product", id, "", "Added download to Product #%d", id)
+	if supersedesID != 0 {
+		logActivity(c, "updated", "product", id, "", "Added a new version of download #%d to Product #%d", supersedesID, id)
+	} else {
+		logActivity(c, "updated", "product", id, "", "Added download to Product #%d", id)
+	}
 
 	// Return the refreshed downloads list
 	return h.ListDownloads(c)
@@ -590,15 +685,31 @@ func (h *ProductDetailsHandler) AddDownload(c echo.Context) error {
 //
 // Note: This deletes a single download, unlike specs/features/certifications
 // which have bulk delete operations. Files may need manual cleanup from disk.
+// A file that only gated downloads still use leaves the public uploads.
+// Deleting one version keeps the others of the file chained together (see
+// migration 074).
 func (h *ProductDetailsHandler) DeleteDownload(c echo.Context) error {
+	ctx := c.Request().Context()
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	downloadID, _ := strconv.ParseInt(c.Param("download_id"), 10, 64)
 
+	download, err := h.queries.GetProductDownload(ctx, downloadID)
+	if err != nil && err != sql.ErrNoRows {
+		h.logger.ErrorContext(ctx, "failed to get download", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	// Delete the specific download record
-	if err := h.queries.DeleteProductDownload(c.Request().Context(), downloadID); err != nil {
-		h.logger.Error("failed to delete download", "error", err)
+	if err := h.queries.DeleteProductDownload(ctx, downloadID); err != nil {
+		h.logger.ErrorContext(ctx, "failed to delete download", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	// A copy's download may have kept a shared file public
+	if download.FilePath != "" {
+		if err := h.files.Sync(ctx, download.FilePath); err != nil {
+			h.logger.ErrorContext(ctx, "failed to move download file", "error", err, "path", download.FilePath)
+		}
+	}
 
 	// Log the deletion
 	logActivity(c, "updated", "product", id, "", "Deleted download from Product #%d", id)
@@ -618,28 +729,99 @@ func (h *ProductDetailsHandler) UpdateDownload(c echo.Context) error {
 	desc := c.FormValue("description")
 	version := c.FormValue("version")
 	fileType := c.FormValue("file_type")
+	gated := int64(0) // Checkbox sends "1" if checked
+	if c.FormValue("is_gated") == "1" {
+		gated = 1
+	}
 
 	if err := h.queries.UpdateProductDownload(ctx, sqlc.UpdateProductDownloadParams{
 		Title:        c.FormValue("title"),
 		Description:  sql.NullString{String: desc, Valid: desc != ""},
 		FileType:     fileType,
 		Version:      sql.NullString{String: version, Valid: version != ""},
+		Changelog:    strings.TrimSpace(c.FormValue("changelog")),
 		DisplayOrder: order,
+		IsGated:      gated,
 		ID:           downloadID,
 	}); err != nil {
-		h.logger.Error("failed to update download", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update download", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	// Gating or ungating moves the file in or out of the public uploads
+	if download, err := h.queries.GetProductDownload(ctx, downloadID); err == nil {
+		if err := h.files.Sync(ctx, download.FilePath); err != nil {
+			h.logger.ErrorContext(ctx, "failed to move download file", "error", err, "path", download.FilePath)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
 
 	logActivity(c, "updated", "product", id, "", "Updated download for Product #%d", id)
 	return h.ListDownloads(c)
 }
 
+// DownloadFile handles GET requests to /admin/products/:id/downloads/:download_id/file
+// Opens the file of a download for admins: ungated files redirect to their
+// public URL, gated files are streamed from GATED_DOWNLOAD_DIR. Downloads
+// are not counted.
+func (h *ProductDetailsHandler) DownloadFile(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	downloadID, _ := strconv.ParseInt(c.Param("download_id"), 10, 64)
+
+	download, err := h.queries.GetProductDownload(ctx, downloadID)
+	if err == sql.ErrNoRows || err == nil && download.ProductID != id {
+		return echo.NewHTTPError(http.StatusNotFound, "Download not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get download", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if download.IsGated != 1 {
+		return c.Redirect(http.StatusFound, download.FilePath)
+	}
+
+	f, err := h.files.Open(ctx, download.FilePath)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to open gated download file", "error", err, "id", downloadID)
+		return echo.NewHTTPError(http.StatusNotFound, "File not found")
+	}
+	defer f.Close()
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(download.FilePath)}))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	c.Response().Header().Set(echo.HeaderContentType, "application/octet-stream")
+	c.Response().WriteHeader(http.StatusOK)
+	_, err = io.Copy(c.Response(), f)
+	return err
+}
+
 // --- Product Images Section ---
-// Images are additional product photos for galleries, separate from primary_image
+// Images are additional product photos for galleries, separate from primary_image.
+// The gallery also holds videos (YouTube, Vimeo or an uploaded file) and 3D/CAD
+// files, told apart by media_type.
+
+// productMediaNouns names each gallery media type in messages.
+var productMediaNouns = map[string]string{
+	services.ProductMediaImage: "image",
+	services.ProductMediaVideo: "video",
+	services.ProductMediaModel: "3D/CAD file",
+}
+
+// mediaTitleRequired returns the error shown when gallery media is saved
+// without alt text, or "" when it has some. Videos and 3D/CAD files use the
+// alt text as their title: the player's accessible name and the download
+// link text.
+func mediaTitleRequired(mediaType, alt string) string {
+	if mediaType == services.ProductMediaVideo || mediaType == services.ProductMediaModel {
+		if strings.TrimSpace(alt) == "" {
+			return "Give the " + productMediaNouns[mediaType] + " a title; screen readers read it out in its place."
+		}
+		return ""
+	}
+	return altTextRequired(true, alt, "image")
+}
 
 // ListImages handles GET requests to /admin/products/:id/images
-// Returns the image gallery as an HTML fragment for HTMX swap.
+// Returns the media gallery as an HTML fragment for HTMX swap.
 //
 // URL Parameters:
 //   - id: Product ID
@@ -650,78 +832,137 @@ func (h *ProductDetailsHandler) ListImages(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	editingID, _ := strconv.ParseInt(c.QueryParam("edit"), 10, 64)
 
-	// Fetch all gallery images for this product
+	// Fetch all gallery images, videos and 3D/CAD files for this product
 	images, err := h.queries.ListProductImages(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("failed to list images", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list images", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Render the images partial template
 	return h.renderPartial(c, "product_images", map[string]interface{}{
-		"ProductID": id,
-		"Images":    images,
-		"EditingID": editingID,
+		"ProductID":       id,
+		"Images":          images,
+		"EditingID":       editingID,
+		"ModelExtensions": strings.Join(services.ProductModelExtensions, ","),
 	})
 }
 
 // AddImage handles POST requests to /admin/products/:id/images
-// Uploads an image and creates a new gallery image record, then returns the updated gallery.
+// Adds an image, a video or a 3D/CAD file to the gallery, then returns the updated gallery.
 //
 // URL Parameters:
 //   - id: Product ID
 //
 // Form Fields:
-//   - image: Required image file upload
-//   - alt_text: Optional alt text for accessibility
+//   - media_type: "image" (default), "video" or "model"
+//   - image: The image; for videos and 3D/CAD files an optional poster or preview image
+//   - video_url: YouTube or Vimeo link (videos, instead of a video file)
+//   - video: MP4 or WebM file (videos, instead of a link)
+//   - model: 3D/CAD file, e.g. STEP, IGES, STL or glTF (3D/CAD files)
+//   - alt_text: Required alt text for accessibility; the title of videos and 3D/CAD files
 //   - caption: Optional caption text
-//   - is_thumbnail: Checkbox (value "1" if checked) - marks as thumbnail image
+//   - is_thumbnail: Checkbox (value "1" if checked) - marks as thumbnail image (images only)
 //   - display_order: Sort order for display
 //
-// HTMX: Returns updated image gallery fragment after successful upload
+// HTMX: Returns updated image gallery fragment after successful upload. A missing
+// video link or file, an unrecognised video link or a missing title is shown as a
+// flash message with the gallery unchanged.
 //
 // Side Effects:
-//   - Uploads image to disk/storage via UploadService
+//   - Uploads files to disk/storage via UploadService
 //   - May resize or optimize image depending on upload service configuration
 func (h *ProductDetailsHandler) AddImage(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
-	isThumbnail := c.FormValue("is_thumbnail") == "1" // Checkbox sends "1" if checked
+	mediaType := c.FormValue("media_type")
+	if mediaType == "" {
+		mediaType = services.ProductMediaImage
+	}
+	isThumbnail := c.FormValue("is_thumbnail") == "1" && mediaType == services.ProductMediaImage // Checkbox sends "1" if checked
+	imageFile, _ := c.FormFile("image")                                                          // nil when no file was chosen
+
+	// Each type needs its source: an image file, a video link or file, or a 3D/CAD file
+	var mediaURL, msg string
+	videoFile, _ := c.FormFile("video")
+	modelFile, _ := c.FormFile("model")
+	switch mediaType {
+	case services.ProductMediaImage:
+		if imageFile == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Image file is required")
+		}
+	case services.ProductMediaVideo:
+		link := strings.TrimSpace(c.FormValue("video_url"))
+		if link != "" {
+			mediaURL = services.VideoEmbedURL(link)
+		}
+		if mediaURL == "" && (link != "" || videoFile == nil) {
+			msg = "Enter a YouTube or Vimeo video link, or upload an MP4 or WebM file."
+		}
+	case services.ProductMediaModel:
+		if modelFile == nil {
+			msg = "Choose the 3D/CAD file to upload."
+		}
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Unknown media type")
+	}
 
-	// Image file is required
-	fileHeader, err := c.FormFile("image")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Image file is required")
+	// So is alt text; without it the gallery is returned unchanged
+	altText := strings.TrimSpace(c.FormValue("alt_text"))
+	if msg == "" {
+		msg = mediaTitleRequired(mediaType, altText)
+	}
+	if msg != "" {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, msg)
+		return h.ListImages(c)
 	}
 
-	// Upload the image using the upload service
-	path, err := h.uploadSvc.UploadProductImage(fileHeader)
+	// Upload the video or 3D/CAD file first, so a file of the wrong type
+	// leaves no orphaned poster image behind
+	var err error
+	switch {
+	case mediaType == services.ProductMediaVideo && mediaURL == "":
+		mediaURL, err = h.uploadSvc.UploadProductVideo(videoFile)
+	case mediaType == services.ProductMediaModel:
+		mediaURL, err = h.uploadSvc.UploadProductModel(modelFile)
+	}
 	if err != nil {
-		h.logger.Error("failed to upload image", "error", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload image: "+err.Error())
+		h.logger.ErrorContext(ctx, "failed to upload media", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload file: "+err.Error())
+	}
+
+	// Upload the image (or poster) using the upload service
+	var path string
+	if imageFile != nil {
+		path, err = h.uploadSvc.UploadProductImage(imageFile)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to upload image", "error", err)
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload image: "+err.Error())
+		}
 	}
 
 	// Extract optional fields
-	altText := c.FormValue("alt_text")
 	caption := c.FormValue("caption")
 
-	// Create database record for the image
+	// Create database record for the media
 	_, err = h.queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{
 		ProductID:    id,
-		ImagePath:    path,                                                  // Stored path from upload service
-		AltText:      sql.NullString{String: altText, Valid: altText != ""}, // Only store if provided
+		ImagePath:    path,                                                  // Stored path from upload service, "" for a video or 3D file without poster
+		AltText:      sql.NullString{String: altText, Valid: true},          // Checked above
 		Caption:      sql.NullString{String: caption, Valid: caption != ""}, // Only store if provided
 		DisplayOrder: order,
 		IsThumbnail:  isThumbnail, // Boolean flag for thumbnail designation
+		MediaType:    mediaType,
+		MediaUrl:     mediaURL, // Player URL or uploaded file, "" for images
 	})
 	if err != nil {
-		h.logger.Error("failed to create image", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create image", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log the activity for audit trail
-	logActivity(c, "updated", "product", id, "", "Added image to Product #%d", id)
+	logActivity(c, "updated", "product", id, "", "Added %s to Product #%d", productMediaNouns[mediaType], id)
 
 	// Return the refreshed images gallery
 	return h.ListImages(c)
@@ -744,7 +985,7 @@ func (h *ProductDetailsHandler) DeleteImage(c echo.Context) error {
 
 	// Delete the specific image record
 	if err := h.queries.DeleteProductImage(c.Request().Context(), imageID); err != nil {
-		h.logger.Error("failed to delete image", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete image", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -757,23 +998,28 @@ func (h *ProductDetailsHandler) DeleteImage(c echo.Context) error {
 
 // UpdateImage handles POST requests to /admin/products/:id/images/:image_id
 // Updates an image's alt text, caption, and display order (NOT the file or thumbnail flag),
-// then returns the refreshed gallery.
+// then returns the refreshed gallery. The alt text cannot be cleared. The edit form
+// sends media_type so the message names what is missing (alt text or a title).
 func (h *ProductDetailsHandler) UpdateImage(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	imageID, _ := strconv.ParseInt(c.Param("image_id"), 10, 64)
 	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
 
-	altText := c.FormValue("alt_text")
+	altText := strings.TrimSpace(c.FormValue("alt_text"))
 	caption := c.FormValue("caption")
+	if msg := mediaTitleRequired(c.FormValue("media_type"), altText); msg != "" {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, msg)
+		return h.ListImages(c)
+	}
 
 	if err := h.queries.UpdateProductImage(ctx, sqlc.UpdateProductImageParams{
-		AltText:      sql.NullString{String: altText, Valid: altText != ""},
+		AltText:      sql.NullString{String: altText, Valid: true},
 		Caption:      sql.NullString{String: caption, Valid: caption != ""},
 		DisplayOrder: order,
 		ID:           imageID,
 	}); err != nil {
-		h.logger.Error("failed to update image", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update image", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -0,0 +1,79 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the bulk product export as CSV or Excel.
+package admin
+
+import (
+	// Standard library imports
+	"encoding/csv" // CSV output
+	"fmt"          // Download file name
+	"net/http"     // HTTP status codes
+	"time"         // Timestamp in the file name
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Product export and XLSX writer
+)
+
+// Export handles GET /admin/products/export
+// Downloads every product with its category, status, features and specs
+// (one column per spec). Rows are streamed as they are read, in batches, so
+// large catalogs do not build up in memory. The columns match the CSV import.
+//
+// Query parameters:
+//   - format: "csv" (default) or "xlsx"
+func (h *ProductsHandler) Export(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		return echo.NewHTTPError(http.StatusBadRequest, "format must be csv or xlsx")
+	}
+
+	filename := fmt.Sprintf("products-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	// Read from the reporting pool so a large export does not block saves
+	exporter := services.NewProductExporter(reporting(h.queries))
+	var n int
+	var err error
+	if format == "xlsx" {
+		res.Header().Set(echo.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		res.WriteHeader(http.StatusOK)
+		var x *services.XLSXWriter
+		if x, err = services.NewXLSXWriter(res, "Products"); err == nil {
+			n, err = exporter.Export(c.Request().Context(), x.Write, func() {
+				_ = x.Flush()
+				res.Flush()
+			})
+			if err == nil {
+				err = x.Close()
+			}
+		}
+	} else {
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.WriteHeader(http.StatusOK)
+		w := csv.NewWriter(res)
+		n, err = exporter.Export(c.Request().Context(), func(row []string) error {
+			for i := range row {
+				row[i] = csvSafe(row[i])
+			}
+			return w.Write(row)
+		}, func() {
+			w.Flush()
+			res.Flush()
+		})
+		w.Flush()
+	}
+	if err != nil {
+		// Headers are sent; the client receives a truncated file
+		h.logger.ErrorContext(c.Request().Context(), "product export interrupted", "format", format, "rows", n, "error", err)
+		return nil
+	}
+
+	logActivity(c, "exported", "product", 0, "", "Exported %d products as %s", n, format)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the bulk product import: upload a CSV, map its columns
+// to product fields, review the validation report (optionally as a dry run),
+// then create every product with its specs and features in one transaction.
+package admin
+
+import (
+	// Standard library imports
+	"bytes"    // Keeping the uploaded CSV to carry between steps
+	"io"       // Copying the upload while parsing it
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"strings"  // Reading the carried-over CSV
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Import parsing, validation and transaction
+)
+
+// maxImportFileSize limits uploaded CSV files to 5 MB.
+const maxImportFileSize = 5 << 20
+
+// ProductImportHandler serves the CSV import wizard under /admin/products/import.
+// The file is kept in a hidden field between steps rather than on disk, so an
+// abandoned import leaves nothing behind.
+type ProductImportHandler struct {
+	importer *services.ProductImporter // Validation and transactional import
+	logger   *slog.Logger              // Structured logger for error tracking
+	cache    *services.Cache           // Cleared after an import so new products appear
+}
+
+// NewProductImportHandler constructs a new ProductImportHandler with required dependencies.
+func NewProductImportHandler(importer *services.ProductImporter, logger *slog.Logger, cache *services.Cache) *ProductImportHandler {
+	return &ProductImportHandler{importer: importer, logger: logger, cache: cache}
+}
+
+// importColumn is one row of the mapping step.
+type importColumn struct {
+	Header  string   // Column header from the file
+	Field   string   // Selected services.ImportField* value
+	Samples []string // First values of the column, to help choose a field
+}
+
+// Show handles GET /admin/products/import
+// Renders the upload step.
+// Template: admin/pages/products_import.html (full page)
+func (h *ProductImportHandler) Show(c echo.Context) error {
+	return h.render(c, map[string]interface{}{})
+}
+
+// Upload handles POST /admin/products/import
+// Parses the uploaded file and renders the mapping step with suggested fields.
+// Unreadable files re-render the upload step with the parse error.
+//
+// Form fields:
+//   - file: CSV file (Excel: "Save as CSV UTF-8")
+func (h *ProductImportHandler) Upload(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return h.render(c, map[string]interface{}{"UploadError": "Choose a CSV file to import."})
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return h.render(c, map[string]interface{}{"UploadError": "The file is larger than 5 MB. Split it into smaller imports."})
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to open import file", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer f.Close()
+
+	var raw bytes.Buffer
+	file, err := services.ParseProductCSV(io.TeeReader(f, &raw))
+	if err != nil {
+		return h.render(c, map[string]interface{}{"UploadError": err.Error()})
+	}
+	return h.render(c, map[string]interface{}{
+		"CSV":      raw.String(),
+		"FileName": fileHeader.Filename,
+		"Rows":     len(file.Rows),
+		"Columns":  importColumns(file, services.SuggestImportMapping(file.Headers)),
+	})
+}
+
+// Run handles POST /admin/products/import/run
+// Validates the file with the chosen mapping and renders the report. With
+// action=import and no validation errors, the products are created in one
+// transaction; otherwise nothing is written.
+//
+// Form fields:
+//   - csv: File contents carried over from Upload
+//   - map: Field for each column, in column order
+//   - action: "dry_run" (validate only) or "import"
+func (h *ProductImportHandler) Run(c echo.Context) error {
+	raw := c.FormValue("csv")
+	file, err := services.ParseProductCSV(strings.NewReader(raw))
+	if err != nil {
+		return h.render(c, map[string]interface{}{"UploadError": err.Error()})
+	}
+	form, err := c.FormParams()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form")
+	}
+	mapping := form["map"]
+	if len(mapping) != len(file.Headers) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Column mapping does not match the file")
+	}
+
+	dryRun := c.FormValue("action") != "import"
+	report, err := h.importer.Import(c.Request().Context(), file, mapping, dryRun)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "product import failed", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if report.Imported > 0 {
+		h.cache.DeleteByPrefix("page:products")
+		logActivity(c, "created", "product", 0, "", "Imported %d products from %s", report.Imported, c.FormValue("file_name"))
+	}
+	return h.render(c, map[string]interface{}{
+		"CSV":      raw,
+		"FileName": c.FormValue("file_name"),
+		"Rows":     len(file.Rows),
+		"Columns":  importColumns(file, mapping),
+		"Report":   report,
+	})
+}
+
+// render renders the import page with the field list every step needs.
+func (h *ProductImportHandler) render(c echo.Context, data map[string]interface{}) error {
+	data["Title"] = "Import Products"
+	data["Fields"] = services.ImportFields
+	return c.Render(http.StatusOK, "admin/pages/products_import.html", data)
+}
+
+// importColumns pairs each header with its mapped field and up to three
+// sample values.
+func importColumns(file *services.ProductCSV, mapping []string) []importColumn {
+	cols := make([]importColumn, len(file.Headers))
+	for i, header := range file.Headers {
+		cols[i] = importColumn{Header: header, Field: mapping[i]}
+		for _, row := range file.Rows {
+			if len(cols[i].Samples) == 3 {
+				break
+			}
+			if v := row[i]; v != "" {
+				if r := []rune(v); len(r) > 40 {
+					v = string(r[:40]) + "…"
+				}
+				cols[i].Samples = append(cols[i].Samples, v)
+			}
+		}
+	}
+	return cols
+}
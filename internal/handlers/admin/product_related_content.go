@@ -0,0 +1,106 @@
+// Package admin provides HTTP handlers for the admin panel product management functionality.
+// This file contains the Related tab of the product editor: case studies,
+// blog posts and whitepapers pinned to the product. Pinned items are shown
+// first in the product page's related resources, ahead of the scored
+// recommendations from services.RelatedContentService.
+package admin
+
+import (
+	"net/http" // HTTP status codes
+	"strconv"  // String to integer conversion for URL params and form values
+	"strings"  // Splitting the "type:id" content value
+
+	"github.com/labstack/echo/v4"                           // Echo web framework for routing and context
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Related content types
+)
+
+// ListRelatedContent handles GET /admin/products/:id/related
+// Renders the product's pins and the form to add one.
+func (h *ProductDetailsHandler) ListRelatedContent(c echo.Context) error {
+	return h.renderRelatedContent(c, "")
+}
+
+// renderRelatedContent renders the related content partial with an optional form error.
+func (h *ProductDetailsHandler) renderRelatedContent(c echo.Context, formError string) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	pins, err := h.queries.ListRelatedContentPins(ctx, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list related content pins", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	// Options for the pin picker; drafts are listed too so a pin can be
+	// prepared before the content is published
+	caseStudies, err := h.queries.AdminListCaseStudies(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list case studies", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	posts, err := h.queries.ListAllBlogPosts(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list blog posts", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	whitepapers, err := h.queries.ListAllWhitepapers(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list whitepapers", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if formError != "" {
+		showFallbackForm(c)
+	}
+	return h.renderPartial(c, "product_related_content", map[string]interface{}{
+		"ProductID":   id,
+		"Pins":        pins,
+		"CaseStudies": caseStudies,
+		"BlogPosts":   posts,
+		"Whitepapers": whitepapers,
+		"Error":       formError,
+	})
+}
+
+// AddRelatedPin handles POST /admin/products/:id/related
+// Pins the item named by the content form field ("case_study:12",
+// "blog_post:4" or "whitepaper:7") at display_order.
+func (h *ProductDetailsHandler) AddRelatedPin(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+	contentType, rawID, _ := strings.Cut(c.FormValue("content"), ":")
+	contentID, _ := strconv.ParseInt(rawID, 10, 64)
+	if !services.IsRelatedContentType(contentType) || contentID <= 0 {
+		return h.renderRelatedContent(c, "Choose a case study, blog post or whitepaper to pin")
+	}
+
+	if err := h.queries.CreateRelatedContentPin(c.Request().Context(), sqlc.CreateRelatedContentPinParams{
+		ProductID:    id,
+		ContentType:  contentType,
+		ContentID:    contentID,
+		DisplayOrder: order,
+	}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to pin related content", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", id, "", "Pinned %s #%d to Product #%d", contentType, contentID, id)
+	return h.ListRelatedContent(c)
+}
+
+// DeleteRelatedPin handles DELETE /admin/products/:id/related/:pin_id
+func (h *ProductDetailsHandler) DeleteRelatedPin(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	pinID, _ := strconv.ParseInt(c.Param("pin_id"), 10, 64)
+
+	if err := h.queries.DeleteRelatedContentPin(c.Request().Context(), sqlc.DeleteRelatedContentPinParams{
+		ID:        pinID,
+		ProductID: id,
+	}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to unpin related content", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", id, "", "Removed a related content pin from Product #%d", id)
+	return h.ListRelatedContent(c)
+}
@@ -0,0 +1,264 @@
+// Package admin provides HTTP handlers for the admin panel product management functionality.
+// This file contains the Variants tab of the product editor: variants with
+// their own SKU, spec overrides, and gallery images. Like the other detail
+// tabs, every handler returns the refreshed partial for an HTMX swap.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows for variants outside the product
+	"net/http"     // HTTP status codes
+	"strconv"      // String to integer conversion for URL params and form values
+	"strings"      // Detecting SKU uniqueness violations
+
+	"github.com/labstack/echo/v4"                 // Echo web framework for routing and context
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database queries
+)
+
+// variantRow is one variant in the Variants tab with its overrides and images.
+type variantRow struct {
+	Variant sqlc.ProductVariant
+	Specs   []sqlc.ProductVariantSpec
+	Images  []sqlc.ProductVariantImage
+}
+
+// ListVariants handles GET /admin/products/:id/variants
+// Renders the variants partial. ?edit=ID opens a variant's inline edit form.
+func (h *ProductDetailsHandler) ListVariants(c echo.Context) error {
+	return h.renderVariants(c, "")
+}
+
+// renderVariants renders the variants partial with an optional form error.
+func (h *ProductDetailsHandler) renderVariants(c echo.Context, formError string) error {
+	ctx := c.Request().Context()
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	editingID, _ := strconv.ParseInt(c.QueryParam("edit"), 10, 64)
+
+	variants, err := h.queries.ListProductVariants(ctx, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list variants", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	rows := make([]variantRow, 0, len(variants))
+	for _, v := range variants {
+		specs, err := h.queries.ListProductVariantSpecs(ctx, v.ID)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to list variant specs", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		images, err := h.queries.ListProductVariantImages(ctx, v.ID)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to list variant images", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		rows = append(rows, variantRow{Variant: v, Specs: specs, Images: images})
+	}
+
+	if formError != "" {
+		showFallbackForm(c)
+	}
+	return h.renderPartial(c, "product_variants", map[string]interface{}{
+		"ProductID": id,
+		"Variants":  rows,
+		"EditingID": editingID,
+		"Error":     formError,
+	})
+}
+
+// variantSKUError returns the message shown when sku cannot be used for a
+// variant, or "" when it is free. Variant SKUs must not collide with a product
+// SKU; collisions with other variants are caught by the UNIQUE constraint.
+func (h *ProductDetailsHandler) variantSKUError(c echo.Context, sku string) string {
+	if sku == "" {
+		return "SKU is required"
+	}
+	if _, err := h.queries.GetProductBySKU(c.Request().Context(), sku); err == nil {
+		return "SKU " + sku + " is already used by a product"
+	}
+	return ""
+}
+
+// isUniqueViolation reports whether err is a SQLite UNIQUE constraint failure.
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// AddVariant handles POST /admin/products/:id/variants
+// Creates a variant from the sku, name and display_order form fields.
+func (h *ProductDetailsHandler) AddVariant(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+	sku := strings.TrimSpace(c.FormValue("sku"))
+	if msg := h.variantSKUError(c, sku); msg != "" {
+		return h.renderVariants(c, msg)
+	}
+
+	_, err := h.queries.CreateProductVariant(c.Request().Context(), sqlc.CreateProductVariantParams{
+		ProductID:    id,
+		Sku:          sku,
+		Name:         strings.TrimSpace(c.FormValue("name")),
+		DisplayOrder: order,
+	})
+	if isUniqueViolation(err) {
+		return h.renderVariants(c, "SKU "+sku+" is already used by another variant")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create variant", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", id, "", "Added variant %s to Product #%d", sku, id)
+	return h.ListVariants(c)
+}
+
+// UpdateVariant handles POST /admin/products/:id/variants/:variant_id
+func (h *ProductDetailsHandler) UpdateVariant(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	variantID, _ := strconv.ParseInt(c.Param("variant_id"), 10, 64)
+	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+	sku := strings.TrimSpace(c.FormValue("sku"))
+	if msg := h.variantSKUError(c, sku); msg != "" {
+		return h.renderVariants(c, msg)
+	}
+
+	n, err := h.queries.UpdateProductVariant(c.Request().Context(), sqlc.UpdateProductVariantParams{
+		Sku:          sku,
+		Name:         strings.TrimSpace(c.FormValue("name")),
+		DisplayOrder: order,
+		ID:           variantID,
+		ProductID:    id,
+	})
+	if isUniqueViolation(err) {
+		return h.renderVariants(c, "SKU "+sku+" is already used by another variant")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update variant", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Variant not found")
+	}
+
+	logActivity(c, "updated", "product", id, "", "Updated variant %s for Product #%d", sku, id)
+	return h.ListVariants(c)
+}
+
+// DeleteVariant handles DELETE /admin/products/:id/variants/:variant_id
+// Its spec overrides and images are removed by the foreign key cascade.
+func (h *ProductDetailsHandler) DeleteVariant(c echo.Context) error {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	variantID, _ := strconv.ParseInt(c.Param("variant_id"), 10, 64)
+
+	if err := h.queries.DeleteProductVariant(c.Request().Context(), sqlc.DeleteProductVariantParams{ID: variantID, ProductID: id}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete variant", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", id, "", "Deleted variant from Product #%d", id)
+	return h.ListVariants(c)
+}
+
+// variantOf loads the :variant_id variant, checking it belongs to the :id product.
+func (h *ProductDetailsHandler) variantOf(c echo.Context) (sqlc.ProductVariant, error) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	variantID, _ := strconv.ParseInt(c.Param("variant_id"), 10, 64)
+	v, err := h.queries.GetProductVariant(c.Request().Context(), sqlc.GetProductVariantParams{ID: variantID, ProductID: id})
+	if err == sql.ErrNoRows {
+		return v, echo.NewHTTPError(http.StatusNotFound, "Variant not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load variant", "error", err)
+		return v, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return v, nil
+}
+
+// AddVariantSpec handles POST /admin/products/:id/variants/:variant_id/specs
+// Sets the variant's value for a section + label; saving the same spec again
+// replaces the earlier override.
+func (h *ProductDetailsHandler) AddVariantSpec(c echo.Context) error {
+	v, err := h.variantOf(c)
+	if err != nil {
+		return err
+	}
+	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+
+	if _, err := h.queries.UpsertProductVariantSpec(c.Request().Context(), sqlc.UpsertProductVariantSpecParams{
+		VariantID:    v.ID,
+		SectionName:  c.FormValue("section_name"),
+		SpecKey:      c.FormValue("spec_key"),
+		SpecValue:    c.FormValue("spec_value"),
+		DisplayOrder: order,
+	}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to save variant spec", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", v.ProductID, "", "Set spec override for variant %s of Product #%d", v.Sku, v.ProductID)
+	return h.ListVariants(c)
+}
+
+// DeleteVariantSpec handles DELETE /admin/products/:id/variants/:variant_id/specs/:spec_id
+func (h *ProductDetailsHandler) DeleteVariantSpec(c echo.Context) error {
+	v, err := h.variantOf(c)
+	if err != nil {
+		return err
+	}
+	specID, _ := strconv.ParseInt(c.Param("spec_id"), 10, 64)
+
+	if err := h.queries.DeleteProductVariantSpec(c.Request().Context(), sqlc.DeleteProductVariantSpecParams{ID: specID, VariantID: v.ID}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete variant spec", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", v.ProductID, "", "Removed spec override from variant %s of Product #%d", v.Sku, v.ProductID)
+	return h.ListVariants(c)
+}
+
+// AddVariantImage handles POST /admin/products/:id/variants/:variant_id/images
+func (h *ProductDetailsHandler) AddVariantImage(c echo.Context) error {
+	v, err := h.variantOf(c)
+	if err != nil {
+		return err
+	}
+	order, _ := strconv.ParseInt(c.FormValue("display_order"), 10, 64)
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Image file is required")
+	}
+	path, err := h.uploadSvc.UploadProductImage(fileHeader)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to upload variant image", "error", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload image: "+err.Error())
+	}
+
+	if _, err := h.queries.CreateProductVariantImage(c.Request().Context(), sqlc.CreateProductVariantImageParams{
+		VariantID:    v.ID,
+		ImagePath:    path,
+		AltText:      c.FormValue("alt_text"),
+		DisplayOrder: order,
+	}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create variant image", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", v.ProductID, "", "Added image to variant %s of Product #%d", v.Sku, v.ProductID)
+	return h.ListVariants(c)
+}
+
+// DeleteVariantImage handles DELETE /admin/products/:id/variants/:variant_id/images/:image_id
+func (h *ProductDetailsHandler) DeleteVariantImage(c echo.Context) error {
+	v, err := h.variantOf(c)
+	if err != nil {
+		return err
+	}
+	imageID, _ := strconv.ParseInt(c.Param("image_id"), 10, 64)
+
+	if err := h.queries.DeleteProductVariantImage(c.Request().Context(), sqlc.DeleteProductVariantImageParams{ID: imageID, VariantID: v.ID}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete variant image", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "updated", "product", v.ProductID, "", "Deleted image from variant %s of Product #%d", v.Sku, v.ProductID)
+	return h.ListVariants(c)
+}
@@ -21,7 +21,7 @@ import (
 // It provides CRUD operations for products including listing with filters, creating,
 // editing, updating, and deleting products. It also handles image uploads and cache invalidation.
 type ProductsHandler struct {
-	queries   *sqlc.Queries           // Database queries generated by sqlc
+	queries   sqlc.Querier            // Database queries generated by sqlc
 	logger    *slog.Logger            // Structured logger for error reporting
 	uploadSvc *services.UploadService // Service for handling product image uploads
 	cache     *services.Cache         // Cache service for invalidating product-related cached pages
@@ -29,7 +29,7 @@ type ProductsHandler struct {
 
 // NewProductsHandler creates and returns a new ProductsHandler instance with the provided dependencies.
 // This constructor is typically called during application initialization when wiring up handlers.
-func NewProductsHandler(queries *sqlc.Queries, logger *slog.Logger, uploadSvc *services.UploadService, cache *services.Cache) *ProductsHandler {
+func NewProductsHandler(queries sqlc.Querier, logger *slog.Logger, uploadSvc *services.UploadService, cache *services.Cache) *ProductsHandler {
 	return &ProductsHandler{
 		queries:   queries,
 		logger:    logger,
@@ -88,7 +88,7 @@ func (h *ProductsHandler) List(c echo.Context) error {
 	// Fetch paginated product list with applied filters
 	products, err := h.queries.ListProductsAdminFiltered(ctx, filterParams)
 	if err != nil {
-		h.logger.Error("failed to list products", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list products", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -99,14 +99,14 @@ func (h *ProductsHandler) List(c echo.Context) error {
 		FilterSearch:   search,
 	})
 	if err != nil {
-		h.logger.Error("failed to count products", "error", err)
+		h.logger.ErrorContext(ctx, "failed to count products", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Fetch all categories for the filter dropdown
 	categories, err := h.queries.ListProductCategories(ctx)
 	if err != nil {
-		h.logger.Error("failed to list categories", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list categories", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -137,12 +137,15 @@ func (h *ProductsHandler) List(c echo.Context) error {
 	for _, cat := range categories {
 		categoryMap[cat.ID] = cat.Name
 	}
+	rows := make([]productRow, len(products))
+	for i, p := range products {
+		rows[i] = productRow{Product: p, Category: categoryMap[p.CategoryID]}
+	}
 
 	return c.Render(http.StatusOK, "admin/pages/products_list.html", map[string]interface{}{
 		"Title":       "Manage Products",
-		"Products":    products,
+		"Products":    rows,
 		"Categories":  categories,
-		"CategoryMap": categoryMap,
 		"Search":      search,
 		"Status":      status,
 		"CategoryID":  categoryID,
@@ -168,7 +171,7 @@ func (h *ProductsHandler) New(c echo.Context) error {
 	// Fetch all categories to populate the category dropdown
 	categories, err := h.queries.ListProductCategories(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list categories", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list categories", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	// Render the form template with no existing item (Item: nil indicates new product)
@@ -177,6 +180,7 @@ func (h *ProductsHandler) New(c echo.Context) error {
 		"FormAction": "/admin/products",       // POST to this endpoint for creation
 		"Item":       nil,                     // No existing product data
 		"Categories": categories,              // Available categories for dropdown
+		"CanPublish": canPublishFromForm(c, "product", 0, ""), // Publishing needs review for some roles
 	})
 }
 
@@ -200,7 +204,7 @@ func (h *ProductsHandler) New(c echo.Context) error {
 // Side Effects:
 //   - Uploads image to disk/storage if provided
 //   - Sets published_at timestamp if status is "published"
-//   - Invalidates product page cache
+//   - Invalidates the cached pages showing the product
 //   - Logs activity to audit trail
 func (h *ProductsHandler) Create(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -223,24 +227,31 @@ func (h *ProductsHandler) Create(c echo.Context) error {
 		// Image file was provided, upload it
 		path, err := h.uploadSvc.UploadProductImage(fileHeader)
 		if err != nil {
-			h.logger.Error("failed to upload image", "error", err)
+			h.logger.ErrorContext(ctx, "failed to upload image", "error", err)
 			return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload image: "+err.Error())
 		}
 		imagePath = sql.NullString{String: path, Valid: true}
 	}
 
 	// Set published_at timestamp if product is being published
-	status := c.FormValue("status")
+	// (the workflow may keep new products in draft until they are reviewed)
+	status := formStatus(c, "product", 0, "", c.FormValue("status"))
 	var publishedAt sql.NullTime
 	if status == "published" {
-		publishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		publishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugProducts, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate product slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Insert new product into database with all fields
-	// Note: Slug is auto-generated from name using makeSlug helper
-	_, err := h.queries.CreateProduct(ctx, sqlc.CreateProductParams{
+	// Note: Slug is auto-generated from name using uniqueSlug
+	product, err := h.queries.CreateProduct(ctx, sqlc.CreateProductParams{
 		Sku:             c.FormValue("sku"),
-		Slug:            makeSlug(c.FormValue("name")), // Generate URL-friendly slug from name
+		Slug:            slug,                          // Generate URL-friendly slug from name
 		Name:            c.FormValue("name"),
 		Tagline:         sql.NullString{String: tagline, Valid: tagline != ""},         // Only store if not empty
 		Description:     c.FormValue("description"),
@@ -256,15 +267,17 @@ func (h *ProductsHandler) Create(c echo.Context) error {
 		PublishedAt:     publishedAt,
 	})
 	if err != nil {
-		h.logger.Error("failed to create product", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create product", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	recordFormStatus(c, h.logger, "product", product.ID, product.Name, fmt.Sprintf("/admin/products/%d/edit", product.ID), "", status)
+	recordSlugChange(c, h.logger, "", productPath(ctx, h.queries, categoryID, slug))
 
-	// Invalidate cached product list pages in the frontend
-	h.cache.DeleteByPrefix("page:products")
+	// Invalidate the cached pages listing the new product's category
+	invalidateProductPages(h.cache, product.ID, categoryID)
 
 	// Log this action to the admin activity log for audit trail
-	logActivity(c, "created", "product", 0, c.FormValue("name"), "Created Product '%s'", c.FormValue("name"))
+	logActivity(c, "created", "product", product.ID, c.FormValue("name"), "Created Product '%s'", c.FormValue("name"))
 
 	// Redirect back to product list page after successful creation
 	return c.Redirect(http.StatusSeeOther, "/admin/products")
@@ -294,7 +307,7 @@ func (h *ProductsHandler) Edit(c echo.Context) error {
 	// Fetch all categories for the dropdown
 	categories, err := h.queries.ListProductCategories(ctx)
 	if err != nil {
-		h.logger.Error("failed to list categories", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list categories", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -304,6 +317,7 @@ func (h *ProductsHandler) Edit(c echo.Context) error {
 	if err == nil {
 		categorySlug = category.Slug
 	}
+	preview := previewURL("/products/"+categorySlug+"/"+product.Slug, services.PreviewProduct, product.ID)
 
 	// Render the form with existing product data
 	return c.Render(http.StatusOK, "admin/pages/products_form.html", map[string]interface{}{
@@ -311,7 +325,10 @@ func (h *ProductsHandler) Edit(c echo.Context) error {
 		"FormAction":   fmt.Sprintf("/admin/products/%d", id), // POST to this URL for update
 		"Item":         product,                               // Pre-fill form with existing data
 		"Categories":   categories,
-		"CategorySlug": categorySlug,                          // For "Preview" button link
+		"PreviewURL":   preview,                               // Signed link for the "Preview" button
+		"CanPublish":   canPublishFromForm(c, "product", id, product.Status),
+		"Workflow":     workflow != nil,                       // Show the review panel
+		"LanguageTabs": languageTabs(c, "product", id, ""),    // Links to the translation editors
 	})
 }
 
@@ -332,7 +349,7 @@ func (h *ProductsHandler) Edit(c echo.Context) error {
 //
 // Side Effects:
 //   - Uploads new image to disk/storage if provided
-//   - Invalidates product page cache
+//   - Invalidates the cached pages showing the product
 //   - Logs activity to audit trail
 func (h *ProductsHandler) Update(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -361,7 +378,7 @@ func (h *ProductsHandler) Update(c echo.Context) error {
 		// New image provided, upload and replace
 		path, err := h.uploadSvc.UploadProductImage(fileHeader)
 		if err != nil {
-			h.logger.Error("failed to upload image", "error", err)
+			h.logger.ErrorContext(ctx, "failed to upload image", "error", err)
 			return echo.NewHTTPError(http.StatusBadRequest, "Failed to upload image")
 		}
 		imagePath = sql.NullString{String: path, Valid: true}
@@ -369,16 +386,22 @@ func (h *ProductsHandler) Update(c echo.Context) error {
 
 	// Only set published_at if transitioning from draft to published
 	// This preserves the original publish date for already-published products
-	status := c.FormValue("status")
+	status := formStatus(c, "product", id, existing.Status, c.FormValue("status"))
 	publishedAt := existing.PublishedAt
 	if status == "published" && !existing.PublishedAt.Valid {
-		publishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		publishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+
+	slug, err := uniqueSlug(c, h.queries, services.SlugProducts, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to generate product slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Update the product record with new values
 	err = h.queries.UpdateProduct(ctx, sqlc.UpdateProductParams{
 		Sku:             c.FormValue("sku"),
-		Slug:            makeSlug(c.FormValue("name")), // Regenerate slug in case name changed
+		Slug:            slug,                          // Regenerate slug in case name changed
 		Name:            c.FormValue("name"),
 		Tagline:         sql.NullString{String: tagline, Valid: tagline != ""},
 		Description:     c.FormValue("description"),
@@ -395,12 +418,17 @@ func (h *ProductsHandler) Update(c echo.Context) error {
 		ID:              id,
 	})
 	if err != nil {
-		h.logger.Error("failed to update product", "error", err)
+		h.logger.ErrorContext(ctx, "failed to update product", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	recordFormStatus(c, h.logger, "product", id, c.FormValue("name"), fmt.Sprintf("/admin/products/%d/edit", id), existing.Status, status)
+
+	// Old links to the product follow it to its new slug or category
+	recordSlugChange(c, h.logger, productPath(ctx, h.queries, existing.CategoryID, existing.Slug), productPath(ctx, h.queries, categoryID, slug))
 
-	// Invalidate frontend product page cache
-	h.cache.DeleteByPrefix("page:products")
+	// Invalidate the product's cached pages, and the listings of both
+	// categories when it moved
+	invalidateProductPages(h.cache, id, existing.CategoryID, categoryID)
 
 	// Log update to audit trail
 	logActivity(c, "updated", "product", id, c.FormValue("name"), "Updated Product '%s'", c.FormValue("name"))
@@ -410,7 +438,7 @@ func (h *ProductsHandler) Update(c echo.Context) error {
 }
 
 // Delete handles DELETE requests to /admin/products/:id
-// Permanently deletes a product from the database.
+// Moves a product to the trash (soft delete).
 //
 // URL Parameters:
 //   - id: Product ID to delete
@@ -418,27 +446,31 @@ func (h *ProductsHandler) Update(c echo.Context) error {
 // HTMX: This endpoint returns an empty response (HTTP 204 No Content)
 // The frontend HTMX handles removing the row from the table on success.
 //
-// Note: This performs a hard delete. Related product_specs, product_features,
-// product_certifications, product_downloads, and product_images should be
-// cascade deleted via database foreign key constraints.
+// Note: The product and its specs, features, certifications, downloads, and
+// images are kept until the product is deleted permanently from /admin/trash
+// or purged by services.TrashPurge.
 //
 // Side Effects:
-//   - Invalidates product page cache
+//   - Invalidates the cached pages showing the product
 //   - Logs deletion to audit trail
 func (h *ProductsHandler) Delete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	product, err := h.queries.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
+	}
 
-	// Delete the product from database
-	if err := h.queries.DeleteProduct(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete product", "error", err)
+	// Move the product to the trash
+	if _, err := h.queries.TrashProduct(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete product", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Invalidate frontend cache
-	h.cache.DeleteByPrefix("page:products")
+	// Invalidate the product's cached pages and its category's listings
+	invalidateProductPages(h.cache, id, product.CategoryID)
 
 	// Log deletion to audit trail
-	logActivity(c, "deleted", "product", id, "", "Deleted Product #%d", id)
+	logActivity(c, "trashed", "product", id, "", "Moved Product #%d to trash", id)
 
 	// Return empty 204 response (HTMX will remove the row)
 	return c.NoContent(http.StatusOK)
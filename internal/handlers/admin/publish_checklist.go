@@ -0,0 +1,190 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the publish checklist shown on the solution, case study
+// and whitepaper edit forms, and the gate that keeps those forms from
+// publishing an item until it passes.
+package admin
+
+import (
+	// Standard library imports
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes
+	"strconv"  // Category and topic ID parsing
+	"strings"  // Override reason trimming and failed check lists
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Publish checker
+)
+
+// publishChecker runs the publish checklist. Like previewTokens it is set
+// once at startup so the edit handlers need no extra constructor argument;
+// when it is nil the checklist is hidden and items publish directly.
+var publishChecker *services.PublishChecker
+
+// SetPublishChecker sets the package-level publish checker.
+//
+// Example:
+//
+//	admin.SetPublishChecker(services.NewPublishChecker(queries, "public/uploads", []string{"admin"}))
+func SetPublishChecker(p *services.PublishChecker) {
+	publishChecker = p
+}
+
+// publishNotices explain on the edit page why the last save did not
+// publish, keyed by the ?publish= value the save redirected with.
+var publishNotices = map[string]string{
+	"blocked": "Saved, but not published: every check below has to pass first.",
+	"reason":  "Saved, but not published: give a reason for publishing without passing every check.",
+}
+
+// checklistForms read the checklist candidate from a submitted edit form,
+// keyed by the :type of POST /admin/publish-checklist/:type.
+var checklistForms = map[string]func(c echo.Context) services.PublishCandidate{
+	"solutions": func(c echo.Context) services.PublishCandidate {
+		return solutionCandidate(c.FormValue("meta_description"), c.FormValue("hero_image_url"),
+			c.FormValue("hero_description"), c.FormValue("overview_content"))
+	},
+	"case-studies": func(c echo.Context) services.PublishCandidate {
+		industryID, _ := strconv.ParseInt(c.FormValue("industry_id"), 10, 64)
+		return caseStudyCandidate(c.FormValue("meta_description"), c.FormValue("hero_image_url"), industryID,
+			c.FormValue("summary"), c.FormValue("challenge_content"), c.FormValue("solution_content"), c.FormValue("outcome_content"))
+	},
+	"whitepapers": func(c echo.Context) services.PublishCandidate {
+		topicID, _ := strconv.ParseInt(c.FormValue("topic_id"), 10, 64)
+		return whitepaperCandidate(c.FormValue("meta_description"), topicID, c.FormValue("description"))
+	},
+}
+
+// solutionCandidate is what the checklist inspects on a solution.
+func solutionCandidate(metaDescription, heroImage, heroDescription, overview string) services.PublishCandidate {
+	return services.PublishCandidate{
+		MetaDescription: metaDescription,
+		HasHeroImage:    true,
+		HeroImage:       heroImage,
+		HTML:            []string{heroDescription, overview},
+	}
+}
+
+// caseStudyCandidate is what the checklist inspects on a case study.
+func caseStudyCandidate(metaDescription, heroImage string, industryID int64, summary, challenge, solution, outcome string) services.PublishCandidate {
+	return services.PublishCandidate{
+		MetaDescription: metaDescription,
+		HasHeroImage:    true,
+		HeroImage:       heroImage,
+		CategoryLabel:   "Industry",
+		CategoryID:      industryID,
+		HTML:            []string{summary, challenge, solution, outcome},
+	}
+}
+
+// whitepaperCandidate is what the checklist inspects on a whitepaper.
+// Whitepapers have a generated cover, so there is no hero image check.
+func whitepaperCandidate(metaDescription string, topicID int64, description string) services.PublishCandidate {
+	return services.PublishCandidate{
+		MetaDescription: metaDescription,
+		CategoryLabel:   "Topic",
+		CategoryID:      topicID,
+		HTML:            []string{description},
+	}
+}
+
+// publishChecklistView is the data of partials/publish-checklist.html.
+type publishChecklistView struct {
+	Type        string // :type of the re-check URL, e.g. "case-studies"
+	Checklist   services.PublishChecklist
+	CanOverride bool   // The user's role may publish despite failed checks
+	Override    bool   // Override box as submitted
+	Reason      string // Override reason as submitted
+	Notice      string // Why the last save did not publish
+}
+
+// publishChecklist runs the checklist for an edit page. It returns nil, which
+// hides the checklist, when no checker is configured.
+func publishChecklist(c echo.Context, kind string, cand services.PublishCandidate) (*publishChecklistView, error) {
+	if publishChecker == nil {
+		return nil, nil
+	}
+	list, err := publishChecker.Check(c.Request().Context(), cand)
+	if err != nil {
+		return nil, err
+	}
+	return &publishChecklistView{
+		Type:        kind,
+		Checklist:   list,
+		CanOverride: publishChecker.CanOverride(getSessionRole(c)),
+		Override:    c.FormValue("publish_override") != "",
+		Reason:      c.FormValue("publish_override_reason"),
+		Notice:      publishNotices[c.QueryParam("publish")],
+	}, nil
+}
+
+// PublishChecklistHandler re-runs the publish checklist while an item is
+// being edited.
+type PublishChecklistHandler struct {
+	logger *slog.Logger // Structured logger for error tracking
+}
+
+// NewPublishChecklistHandler constructs a new PublishChecklistHandler with required dependencies.
+func NewPublishChecklistHandler(logger *slog.Logger) *PublishChecklistHandler {
+	return &PublishChecklistHandler{logger: logger}
+}
+
+// Check handles POST /admin/publish-checklist/:type
+// HTMX: Yes - posted with the edit form's current values, swaps the checklist
+// Template: admin/partials/publish_checklist.html
+func (h *PublishChecklistHandler) Check(c echo.Context) error {
+	form, ok := checklistForms[c.Param("type")]
+	if !ok || publishChecker == nil {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	view, err := publishChecklist(c, c.Param("type"), form(c))
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to run publish checklist", "type", c.Param("type"), "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to run the publish checklist")
+	}
+	return c.Render(http.StatusOK, "admin/partials/publish_checklist.html", view)
+}
+
+// publishGate is the outcome of checking a save that would publish an item.
+type publishGate struct {
+	notice string   // publishNotices key when the item has to stay unpublished
+	failed []string // Failed checks an override published past
+	reason string   // Override reason
+}
+
+// checkPublish runs the checklist for a save that would publish an item that
+// is not published yet. Items that fail stay unpublished unless the form
+// asks for an override with a reason and the user's role may override.
+func checkPublish(c echo.Context, cand services.PublishCandidate) (publishGate, error) {
+	if publishChecker == nil {
+		return publishGate{}, nil
+	}
+	list, err := publishChecker.Check(c.Request().Context(), cand)
+	if err != nil || list.Passed() {
+		return publishGate{}, err
+	}
+	if c.FormValue("publish_override") == "" || !publishChecker.CanOverride(getSessionRole(c)) {
+		return publishGate{notice: "blocked"}, nil
+	}
+	reason := strings.TrimSpace(c.FormValue("publish_override_reason"))
+	if reason == "" {
+		return publishGate{notice: "reason"}, nil
+	}
+	return publishGate{failed: list.Failed(), reason: reason}, nil
+}
+
+// allowed reports whether the save may publish.
+func (g publishGate) allowed() bool {
+	return g.notice == ""
+}
+
+// logOverride records a publish past failed checks in the activity log.
+func (g publishGate) logOverride(c echo.Context, resourceType string, id int64, title string) {
+	if len(g.failed) == 0 {
+		return
+	}
+	logActivity(c, "published", resourceType, id, title, "Published '%s' despite failed checks (%s). Reason: %s",
+		title, strings.Join(g.failed, "; "), g.reason)
+}
@@ -0,0 +1,204 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the redirect manager and the hook that records a
+// redirect whenever an editor changes a slug.
+package admin
+
+import (
+	"context"      // Category and product path lookups
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"net/url"      // Escaping error messages into the redirect URL
+	"strconv"      // Parsing IDs and status codes
+	"strings"      // Filtering rules
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Redirect rules
+)
+
+// redirects records a redirect from a page's old path when its slug
+// changes. Like previewTokens it is set once at startup so edit handlers
+// need no extra constructor argument; when it is nil nothing is recorded.
+var redirects *services.Redirects
+
+// SetRedirects sets the package-level redirect service.
+//
+// Example:
+//
+//	admin.SetRedirects(services.NewRedirects(queries, logger))
+func SetRedirects(svc *services.Redirects) {
+	redirects = svc
+}
+
+// recordSlugChange redirects oldPath to newPath after a save changed a
+// page's public path, and clears any rule for newPath so it is not
+// shadowed. Pass "" as oldPath for new content. Failures are logged and do
+// not fail the save.
+func recordSlugChange(c echo.Context, logger *slog.Logger, oldPath, newPath string) {
+	if redirects == nil || newPath == "" {
+		return
+	}
+	if err := redirects.SlugChanged(c.Request().Context(), oldPath, newPath); err != nil {
+		logger.ErrorContext(c.Request().Context(), "failed to record redirect", "error", err, "from", oldPath, "to", newPath)
+	}
+}
+
+// productPath returns the public path of a product with slug in the
+// category categoryID, or "" when the category cannot be loaded.
+func productPath(ctx context.Context, queries sqlc.Querier, categoryID int64, slug string) string {
+	cat, err := queries.GetProductCategory(ctx, categoryID)
+	if err != nil {
+		return ""
+	}
+	return "/products/" + cat.Slug + "/" + slug
+}
+
+// RedirectsHandler handles the redirect manager at /admin/redirects.
+type RedirectsHandler struct {
+	queries   sqlc.Querier        // Database queries generated by sqlc
+	logger    *slog.Logger        // Structured logger for error reporting
+	redirects *services.Redirects // In-memory rules reloaded after each change
+}
+
+// NewRedirectsHandler creates a new RedirectsHandler instance.
+func NewRedirectsHandler(queries sqlc.Querier, logger *slog.Logger, redirects *services.Redirects) *RedirectsHandler {
+	return &RedirectsHandler{queries: queries, logger: logger, redirects: redirects}
+}
+
+// List handles GET /admin/redirects
+// Lists every rule with its hits, with forms to add, edit and delete rules.
+// ?q= filters on the source and target paths; ?source= fills in the add
+// form (the "create redirect" shortcut on the 404 report).
+// Template: admin/pages/redirects.html (full page)
+func (h *RedirectsHandler) List(c echo.Context) error {
+	rules, err := h.queries.ListRedirects(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list redirects", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	search := strings.TrimSpace(c.QueryParam("q"))
+	if search != "" {
+		filtered := rules[:0]
+		for _, r := range rules {
+			if strings.Contains(r.SourcePath, search) || strings.Contains(r.TargetPath, search) {
+				filtered = append(filtered, r)
+			}
+		}
+		rules = filtered
+	}
+	return c.Render(http.StatusOK, "admin/pages/redirects.html", map[string]interface{}{
+		"Title":     "Redirects",
+		"Redirects": rules,
+		"Search":    search,
+		"Source":    c.QueryParam("source"),
+		"Error":     c.QueryParam("error"),
+	})
+}
+
+// Create handles POST /admin/redirects
+// Adds a manual rule from the source_path, target_path and status_code form
+// fields. Invalid rules and duplicate sources are reported on the list page.
+// The source's entries on the 404 report are dismissed, as it now redirects.
+func (h *RedirectsHandler) Create(c echo.Context) error {
+	source, target, status, err := redirectForm(c)
+	if err != nil {
+		return redirectsError(c, err.Error())
+	}
+	rule, err := h.queries.CreateRedirect(c.Request().Context(), sqlc.CreateRedirectParams{SourcePath: source, TargetPath: target, StatusCode: int64(status)})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return redirectsError(c, "A redirect for "+source+" already exists.")
+		}
+		h.logger.ErrorContext(c.Request().Context(), "failed to create redirect", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.reload(c)
+	if err := h.queries.DeleteNotFoundPath(c.Request().Context(), source); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to dismiss 404s for redirect", "error", err, "path", source)
+	}
+	logActivity(c, "created", "redirect", rule.ID, source, "Added redirect %s -> %s", source, target)
+	return c.Redirect(http.StatusSeeOther, "/admin/redirects")
+}
+
+// Update handles POST /admin/redirects/:id
+// Saves a rule's source, target and status. An edited automatic rule
+// becomes a manual one.
+func (h *RedirectsHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if _, err := h.queries.GetRedirect(c.Request().Context(), id); errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	source, target, status, err := redirectForm(c)
+	if err != nil {
+		return redirectsError(c, err.Error())
+	}
+	err = h.queries.UpdateRedirect(c.Request().Context(), sqlc.UpdateRedirectParams{SourcePath: source, TargetPath: target, StatusCode: int64(status), ID: id})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return redirectsError(c, "A redirect for "+source+" already exists.")
+		}
+		h.logger.ErrorContext(c.Request().Context(), "failed to update redirect", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.reload(c)
+	logActivity(c, "updated", "redirect", id, source, "Updated redirect %s -> %s", source, target)
+	return c.Redirect(http.StatusSeeOther, "/admin/redirects")
+}
+
+// Delete handles DELETE /admin/redirects/:id
+// Removes a rule; its source path serves whatever page lives there again.
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *RedirectsHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteRedirect(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete redirect", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.reload(c)
+	logActivity(c, "deleted", "redirect", id, "", "Deleted redirect #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// reload refreshes the rules served to visitors after a change.
+func (h *RedirectsHandler) reload(c echo.Context) {
+	if err := h.redirects.Reload(c.Request().Context()); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to reload redirects", "error", err)
+	}
+}
+
+// redirectForm reads and validates the rule fields of the add and edit forms.
+func redirectForm(c echo.Context) (source, target string, status int, err error) {
+	status, _ = strconv.Atoi(c.FormValue("status_code"))
+	if status == 0 {
+		status = http.StatusMovedPermanently
+	}
+	source, target, err = services.ValidateRedirect(c.FormValue("source_path"), c.FormValue("target_path"), status)
+	if err != nil {
+		return "", "", 0, errors.New(redirectErrorMessages[err])
+	}
+	return source, target, status, nil
+}
+
+// redirectErrorMessages explains rule validation errors on the list page.
+var redirectErrorMessages = map[error]string{
+	services.ErrRedirectSource:   "The source must be a path on this site, starting with / (e.g. /old-page).",
+	services.ErrRedirectTarget:   "The target must be a path starting with / or a full http(s):// URL.",
+	services.ErrRedirectWildcard: "Use * only at the end of the source, and at the end of the target only when the source ends in *.",
+	services.ErrRedirectReserved: "Admin pages and /public files cannot be redirected.",
+	services.ErrRedirectLoop:     "The target would redirect back to the source.",
+	services.ErrRedirectStatus:   "Choose 301 (permanent) or 302 (temporary).",
+}
+
+// redirectsError returns to the list page with msg shown above the rules.
+func redirectsError(c echo.Context, msg string) error {
+	return c.Redirect(http.StatusSeeOther, "/admin/redirects?error="+url.QueryEscape(msg))
+}
@@ -0,0 +1,31 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file routes reporting queries (exports) to the read-only pool.
+package admin
+
+import (
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database queries
+)
+
+// reportQueries runs long read-only scans such as exports. Like activityLog
+// it is set once at startup; when it is nil, reports use the handler's own
+// (primary) queries.
+var reportQueries sqlc.Querier
+
+// SetReportingQueries sets the queries used for exports, typically backed by
+// database.OpenReadOnly so they do not hold the primary's only connection.
+//
+// Example:
+//
+//	replica, _ := database.OpenReadOnly(database.Config{Path: dbPath})
+//	admin.SetReportingQueries(sqlc.New(replica))
+func SetReportingQueries(q sqlc.Querier) {
+	reportQueries = q
+}
+
+// reporting returns the reporting queries, or primary when none are set.
+func reporting(primary sqlc.Querier) sqlc.Querier {
+	if reportQueries == nil {
+		return primary
+	}
+	return reportQueries
+}
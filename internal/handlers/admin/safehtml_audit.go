@@ -0,0 +1,35 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file serves the safeHTML audit report, a development aid for
+// migrating template fields to sanitize-on-save.
+package admin
+
+import (
+	// Standard library imports
+	"net/http" // HTTP status codes
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/templates" // safeHTML audit results
+)
+
+// SafeHTMLAuditHandler serves the report of an enabled safeHTML audit.
+type SafeHTMLAuditHandler struct {
+	audit *templates.SafeHTMLAudit // Audit attached to the renderer at startup
+}
+
+// NewSafeHTMLAuditHandler constructs a new SafeHTMLAuditHandler.
+func NewSafeHTMLAuditHandler(audit *templates.SafeHTMLAudit) *SafeHTMLAuditHandler {
+	return &SafeHTMLAuditHandler{audit: audit}
+}
+
+// Report handles GET /admin/safehtml-audit
+// Writes the audit as plain text: one line per safeHTML call site with its
+// render and unsanitized counts since startup, fields to migrate first.
+func (h *SafeHTMLAuditHandler) Report(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().WriteHeader(http.StatusOK)
+	return h.audit.WriteReport(c.Response())
+}
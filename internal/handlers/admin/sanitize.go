@@ -0,0 +1,28 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the rich-text sanitization hook shared by the editors.
+package admin
+
+import (
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // HTMLSanitizer
+)
+
+// htmlSanitizer cleans WYSIWYG and Markdown HTML before it is stored. It
+// defaults to the built-in allowlist so content is never saved unsanitized,
+// and is replaced at startup by SetHTMLSanitizer with the configured policy.
+var htmlSanitizer = services.NewHTMLSanitizer(services.SanitizerConfig{})
+
+// SetHTMLSanitizer sets the package-level sanitizer used for blog post
+// bodies, solution overviews, case study sections, and their translations.
+//
+// Parameters:
+//   - s: Sanitizer built from the configured allowlist
+func SetHTMLSanitizer(s *services.HTMLSanitizer) {
+	htmlSanitizer = s
+}
+
+// sanitizeHTML removes scripts, event handlers, and anything else outside the
+// allowlist from rich-text HTML submitted by an editor.
+func sanitizeHTML(html string) string {
+	return htmlSanitizer.Sanitize(html)
+}
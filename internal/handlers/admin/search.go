@@ -0,0 +1,149 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the admin search behind the command palette (Ctrl+K),
+// which jumps to the edit page of any content item or settings screen.
+package admin
+
+import (
+	// Standard library imports
+	"fmt"      // Editor paths
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes
+	"strings"  // Query normalization and settings matching
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database queries
+)
+
+// AdminSearchHandler serves the results of the admin command palette.
+type AdminSearchHandler struct {
+	queries sqlc.Querier // Database query interface
+	logger  *slog.Logger // Structured logger for error reporting
+}
+
+// NewAdminSearchHandler creates a new AdminSearchHandler instance.
+func NewAdminSearchHandler(queries sqlc.Querier, logger *slog.Logger) *AdminSearchHandler {
+	return &AdminSearchHandler{queries: queries, logger: logger}
+}
+
+// adminSearchTypes labels the content types returned by SearchAdminContent
+// and links them to their editors.
+var adminSearchTypes = map[string]struct{ label, icon, editPath string }{
+	"product":      {"Product", "inventory_2", "/admin/products/%d/edit"},
+	"blog_post":    {"Blog post", "article", "/admin/blog/posts/%d/edit"},
+	"solution":     {"Solution", "lightbulb", "/admin/solutions/%d/edit"},
+	"case_study":   {"Case study", "science", "/admin/case-studies/%d/edit"},
+	"whitepaper":   {"Whitepaper", "description", "/admin/whitepapers/%d/edit"},
+	"landing_page": {"Landing page", "web", "/admin/landing-pages/%d/edit"},
+}
+
+// adminSearchSetting is a settings screen the palette can jump to. It
+// matches when every word of the query is in its title or keywords.
+type adminSearchSetting struct {
+	title, url, keywords string
+}
+
+// adminSearchSettings lists the settings screens, with the Global Settings
+// tabs linked directly.
+var adminSearchSettings = []adminSearchSetting{
+	{"Global Settings: General", "/admin/settings?tab=general", "site name tagline timezone maintenance branding logo favicon"},
+	{"Global Settings: Contact", "/admin/settings?tab=contact", "email phone address"},
+	{"Global Settings: Social Media", "/admin/settings?tab=social", "linkedin twitter facebook youtube instagram"},
+	{"Global Settings: SEO Defaults", "/admin/settings?tab=seo", "meta title description analytics robots"},
+	{"Global Settings: Integrations", "/admin/settings?tab=integrations", "machine translation api key"},
+	{"Global Settings: Performance", "/admin/settings?tab=performance", "page output minify cache"},
+	{"Global Settings: Theme", "/admin/settings?tab=theme", "colors fonts dark mode"},
+	{"Settings Import and Export", "/admin/settings/import", "backup json export"},
+	{"Homepage Settings", "/admin/homepage/settings", "hero stats testimonials cta sections"},
+	{"About Settings", "/admin/about/settings", "mission values team milestones"},
+	{"Products Settings", "/admin/products/settings", "catalog listing per page"},
+	{"Solutions Settings", "/admin/solutions/settings", "listing"},
+	{"Blog Settings", "/admin/blog/settings", "posts per page listing"},
+	{"Footer", "/admin/footer", "links columns copyright"},
+	{"Navigation Menus", "/admin/navigation", "menu header links mega"},
+	{"Redirects", "/admin/redirects", "301 302 urls"},
+	{"SEO Overrides", "/admin/seo", "meta title description open graph"},
+	{"Languages", "/admin/languages", "locales translations"},
+}
+
+// adminSearchResult is one entry of the palette's dropdown.
+type adminSearchResult struct {
+	Title     string // Item title or settings screen name
+	Detail    string // SKU or slug; empty for settings
+	TypeLabel string // e.g. "Product" or "Settings"
+	Icon      string // Material Symbols icon name
+	Status    string // Content status; empty for settings
+	URL       string // Edit page
+}
+
+const (
+	adminSearchContentLimit  = 15 // Content matches per query
+	adminSearchSettingsLimit = 5  // Settings matches per query
+)
+
+// Search handles GET /admin/search
+// Finds content by title, SKU or slug and settings screens by name, for the
+// command palette. An empty query returns the palette's hint.
+//
+// Query parameters:
+//   - q: Search term
+//
+// HTMX: returns admin/partials/search_results.html, swapped into the palette
+func (h *AdminSearchHandler) Search(c echo.Context) error {
+	ctx := c.Request().Context()
+	query := strings.Join(strings.Fields(c.QueryParam("q")), " ")
+
+	var results []adminSearchResult
+	if query != "" {
+		rows, err := h.queries.SearchAdminContent(ctx, sqlc.SearchAdminContentParams{
+			Query:       query,
+			ResultLimit: adminSearchContentLimit,
+		})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to search content", "error", err, "query", query)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		for _, r := range rows {
+			t := adminSearchTypes[r.ContentType]
+			results = append(results, adminSearchResult{
+				Title:     r.Title,
+				Detail:    r.Detail,
+				TypeLabel: t.label,
+				Icon:      t.icon,
+				Status:    r.Status,
+				URL:       fmt.Sprintf(t.editPath, r.ID),
+			})
+		}
+		results = append(results, matchSettings(query)...)
+	}
+
+	return c.Render(http.StatusOK, "admin/partials/search_results.html", map[string]interface{}{
+		"Query":   query,
+		"Results": results,
+	})
+}
+
+// matchSettings returns the settings screens matching every word of query.
+func matchSettings(query string) []adminSearchResult {
+	words := strings.Fields(strings.ToLower(query))
+	var results []adminSearchResult
+	for _, s := range adminSearchSettings {
+		text := strings.ToLower(s.title + " " + s.keywords)
+		matched := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			results = append(results, adminSearchResult{Title: s.title, TypeLabel: "Settings", Icon: "settings", URL: s.url})
+			if len(results) == adminSearchSettingsLimit {
+				break
+			}
+		}
+	}
+	return results
+}
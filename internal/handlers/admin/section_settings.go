@@ -11,21 +11,24 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context management
 
 	// Internal dependencies
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // sqlc-generated database queries and models
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/db/sqlc"          // sqlc-generated database queries and models
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page cache invalidation
 )
 
 // SectionSettingsHandler manages section-specific settings for different areas of the site.
 // Handles configuration for About, Products, Solutions, and Blog sections.
 // Each section has its own settings that control display options, pagination, and feature toggles.
 type SectionSettingsHandler struct {
-	queries *sqlc.Queries // Database query interface for section settings CRUD operations
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier    // Database query interface for section settings CRUD operations
+	logger  *slog.Logger    // Structured logger for error tracking
+	cache   *services.Cache // Public page cache, purged when listing settings change (optional)
 }
 
 // NewSectionSettingsHandler creates and initializes a new SectionSettingsHandler instance.
-// Dependencies are injected to support database access and logging.
-func NewSectionSettingsHandler(queries *sqlc.Queries, logger *slog.Logger) *SectionSettingsHandler {
-	return &SectionSettingsHandler{queries: queries, logger: logger}
+// Dependencies are injected to support database access, logging and cache invalidation.
+func NewSectionSettingsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *SectionSettingsHandler {
+	return &SectionSettingsHandler{queries: queries, logger: logger, cache: cache}
 }
 
 // ==================== ABOUT SETTINGS ====================
@@ -46,7 +49,6 @@ func NewSectionSettingsHandler(queries *sqlc.Queries, logger *slog.Logger) *Sect
 // - about_show_team: Display team members section
 //
 // Query Parameters:
-// - saved: Set to "1" after successful update to show success message
 //
 // Template Data:
 // - Title: Page title ("About Settings")
@@ -58,19 +60,15 @@ func (h *SectionSettingsHandler) AboutSettings(c echo.Context) error {
 	// Fetch current settings from database (single row table with all settings)
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Check for success flag from previous update operation
-	saved := c.QueryParam("saved") == "1"
-
 	// Render About settings form with current toggle states
 	// Template path: templates/admin/pages/about_settings.html
 	return c.Render(http.StatusOK, "admin/pages/about_settings.html", map[string]interface{}{
 		"Title":    "About Settings",
 		"Settings": settings,  // Contains about_show_* fields (int64: 0=off, 1=on)
-		"Saved":    saved,     // Triggers success message banner
 	})
 }
 
@@ -88,7 +86,7 @@ func (h *SectionSettingsHandler) AboutSettings(c echo.Context) error {
 //
 // Post-Update Behavior:
 // - Logs activity to activity_log table for audit trail
-// - Redirects back to settings form with saved=1 flag (shows success message)
+// - Redirects back to settings form with a success flash message
 //
 // Authentication: Requires valid session (enforced by middleware)
 func (h *SectionSettingsHandler) UpdateAboutSettings(c echo.Context) error {
@@ -110,15 +108,16 @@ func (h *SectionSettingsHandler) UpdateAboutSettings(c echo.Context) error {
 		AboutShowTeam:           boolToInt("about_show_team"),           // Toggle team members section
 	})
 	if err != nil {
-		h.logger.Error("failed to update about settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update about settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log settings update to activity_log for audit trail
 	logActivity(c, "updated", "about_settings", 0, "", "Updated About Settings")
 
-	// Redirect back to settings form with success flag
-	return c.Redirect(http.StatusSeeOther, "/admin/about/settings?saved=1")
+	// Redirect back to settings form with a success flash
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "About settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/about/settings")
 }
 
 // ==================== PRODUCTS SETTINGS ====================
@@ -139,7 +138,6 @@ func (h *SectionSettingsHandler) UpdateAboutSettings(c echo.Context) error {
 // - products_default_sort: Default sort order (dropdown: name, date, price, etc.)
 //
 // Query Parameters:
-// - saved: Set to "1" after successful update to show success message
 //
 // Template Data:
 // - Title: Page title ("Products Settings")
@@ -151,19 +149,15 @@ func (h *SectionSettingsHandler) ProductsSettings(c echo.Context) error {
 	// Fetch current settings from database
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Check for success flag from previous update operation
-	saved := c.QueryParam("saved") == "1"
-
 	// Render Products settings form
 	// Template path: templates/admin/pages/products_settings.html
 	return c.Render(http.StatusOK, "admin/pages/products_settings.html", map[string]interface{}{
 		"Title":    "Products Settings",
 		"Settings": settings,  // Contains products_* configuration fields
-		"Saved":    saved,     // Triggers success message banner
 	})
 }
 
@@ -186,7 +180,7 @@ func (h *SectionSettingsHandler) ProductsSettings(c echo.Context) error {
 //
 // Post-Update Behavior:
 // - Logs activity to activity_log table for audit trail
-// - Redirects back to settings form with saved=1 flag (shows success message)
+// - Redirects back to settings form with a success flash message
 //
 // Authentication: Requires valid session (enforced by middleware)
 func (h *SectionSettingsHandler) UpdateProductsSettings(c echo.Context) error {
@@ -219,15 +213,21 @@ func (h *SectionSettingsHandler) UpdateProductsSettings(c echo.Context) error {
 		ProductsDefaultSort:    c.FormValue("products_default_sort"),   // Default sort order string
 	})
 	if err != nil {
-		h.logger.Error("failed to update products settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update products settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	// Cached category and search pages are ordered by the default sort
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
+
 	// Log settings update to activity_log for audit trail
 	logActivity(c, "updated", "products_settings", 0, "", "Updated Products Settings")
 
-	// Redirect back to settings form with success flag
-	return c.Redirect(http.StatusSeeOther, "/admin/products/settings?saved=1")
+	// Redirect back to settings form with a success flash
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Products settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/products/settings")
 }
 
 // ==================== SOLUTIONS SETTINGS ====================
@@ -247,7 +247,6 @@ func (h *SectionSettingsHandler) UpdateProductsSettings(c echo.Context) error {
 // - solutions_show_search: Display search box (toggle)
 //
 // Query Parameters:
-// - saved: Set to "1" after successful update to show success message
 //
 // Template Data:
 // - Title: Page title ("Solutions Settings")
@@ -259,19 +258,15 @@ func (h *SectionSettingsHandler) SolutionsSettings(c echo.Context) error {
 	// Fetch current settings from database
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Check for success flag from previous update operation
-	saved := c.QueryParam("saved") == "1"
-
 	// Render Solutions settings form
 	// Template path: templates/admin/pages/solutions_settings.html
 	return c.Render(http.StatusOK, "admin/pages/solutions_settings.html", map[string]interface{}{
 		"Title":    "Solutions Settings",
 		"Settings": settings,  // Contains solutions_* configuration fields
-		"Saved":    saved,     // Triggers success message banner
 	})
 }
 
@@ -293,7 +288,7 @@ func (h *SectionSettingsHandler) SolutionsSettings(c echo.Context) error {
 //
 // Post-Update Behavior:
 // - Logs activity to activity_log table for audit trail
-// - Redirects back to settings form with saved=1 flag (shows success message)
+// - Redirects back to settings form with a success flash message
 //
 // Authentication: Requires valid session (enforced by middleware)
 func (h *SectionSettingsHandler) UpdateSolutionsSettings(c echo.Context) error {
@@ -325,15 +320,16 @@ func (h *SectionSettingsHandler) UpdateSolutionsSettings(c echo.Context) error {
 		SolutionsShowSearch:     boolToInt("solutions_show_search"),      // Toggle search box
 	})
 	if err != nil {
-		h.logger.Error("failed to update solutions settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update solutions settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log settings update to activity_log for audit trail
 	logActivity(c, "updated", "solutions_settings", 0, "", "Updated Solutions Settings")
 
-	// Redirect back to settings form with success flag
-	return c.Redirect(http.StatusSeeOther, "/admin/solutions/settings?saved=1")
+	// Redirect back to settings form with a success flash
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Solutions settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/solutions/settings")
 }
 
 // ==================== BLOG SETTINGS ====================
@@ -356,7 +352,6 @@ func (h *SectionSettingsHandler) UpdateSolutionsSettings(c echo.Context) error {
 // - blog_show_search: Display search box (toggle)
 //
 // Query Parameters:
-// - saved: Set to "1" after successful update to show success message
 //
 // Template Data:
 // - Title: Page title ("Blog Settings")
@@ -368,19 +363,15 @@ func (h *SectionSettingsHandler) BlogSettings(c echo.Context) error {
 	// Fetch current settings from database
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Check for success flag from previous update operation
-	saved := c.QueryParam("saved") == "1"
-
 	// Render Blog settings form
 	// Template path: templates/admin/pages/blog_settings.html
 	return c.Render(http.StatusOK, "admin/pages/blog_settings.html", map[string]interface{}{
 		"Title":    "Blog Settings",
 		"Settings": settings,  // Contains blog_* configuration fields
-		"Saved":    saved,     // Triggers success message banner
 	})
 }
 
@@ -406,7 +397,7 @@ func (h *SectionSettingsHandler) BlogSettings(c echo.Context) error {
 //
 // Post-Update Behavior:
 // - Logs activity to activity_log table for audit trail
-// - Redirects back to settings form with saved=1 flag (shows success message)
+// - Redirects back to settings form with a success flash message
 //
 // Authentication: Requires valid session (enforced by middleware)
 func (h *SectionSettingsHandler) UpdateBlogSettings(c echo.Context) error {
@@ -441,13 +432,14 @@ func (h *SectionSettingsHandler) UpdateBlogSettings(c echo.Context) error {
 		BlogShowSearch:     boolToInt("blog_show_search"),            // Toggle search box
 	})
 	if err != nil {
-		h.logger.Error("failed to update blog settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update blog settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
 	// Log settings update to activity_log for audit trail
 	logActivity(c, "updated", "blog_settings", 0, "", "Updated Blog Settings")
 
-	// Redirect back to settings form with success flag
-	return c.Redirect(http.StatusSeeOther, "/admin/blog/settings?saved=1")
+	// Redirect back to settings form with a success flash
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Blog settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/blog/settings")
 }
@@ -0,0 +1,257 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the SEO manager, which overrides the search and social
+// metadata of individual public pages.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing IDs
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // SEO overrides and page cache
+)
+
+// seoRow is one override on the list page with a readable target.
+type seoRow struct {
+	sqlc.SeoMetum
+	Target string // Path, or "Product: Name" for a content item
+}
+
+// SEOHandler handles the SEO manager at /admin/seo.
+type SEOHandler struct {
+	queries sqlc.Querier    // Database queries generated by sqlc
+	logger  *slog.Logger    // Structured logger for error reporting
+	seo     *services.SEO   // In-memory overrides reloaded after each change
+	cache   *services.Cache // Public page cache, cleared after each change
+}
+
+// NewSEOHandler creates a new SEOHandler instance.
+func NewSEOHandler(queries sqlc.Querier, logger *slog.Logger, seo *services.SEO, cache *services.Cache) *SEOHandler {
+	return &SEOHandler{queries: queries, logger: logger, seo: seo, cache: cache}
+}
+
+// List handles GET /admin/seo
+// Lists every override with the page or content item it applies to.
+// Template: admin/pages/seo.html (full page)
+func (h *SEOHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+	items, err := h.queries.ListSEOMeta(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list SEO overrides", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	rows := make([]seoRow, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, seoRow{SeoMetum: item, Target: h.target(c, item)})
+	}
+	return c.Render(http.StatusOK, "admin/pages/seo.html", map[string]interface{}{
+		"Title": "SEO",
+		"Items": rows,
+	})
+}
+
+// New handles GET /admin/seo/new
+// Shows an empty form. ?route= or ?entity_type=&entity_id= preselect the
+// page, for links from elsewhere in the admin.
+// Template: admin/pages/seo_form.html (full page)
+func (h *SEOHandler) New(c echo.Context) error {
+	entityID, _ := strconv.ParseInt(c.QueryParam("entity_id"), 10, 64)
+	return h.renderForm(c, http.StatusOK, sqlc.SeoMetum{
+		Route:      c.QueryParam("route"),
+		EntityType: c.QueryParam("entity_type"),
+		EntityID:   entityID,
+	}, "")
+}
+
+// Create handles POST /admin/seo
+// Adds an override from the form. Invalid input, unknown content and a
+// second override for the same page are reported on the form.
+func (h *SEOHandler) Create(c echo.Context) error {
+	params, msg := h.form(c)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, seoMetumFrom(params, 0), msg)
+	}
+	item, err := h.queries.CreateSEOMeta(c.Request().Context(), params)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return h.renderForm(c, http.StatusUnprocessableEntity, seoMetumFrom(params, 0), "This page already has an override; edit that one instead.")
+		}
+		h.logger.ErrorContext(c.Request().Context(), "failed to create SEO override", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	target := h.target(c, item)
+	logActivity(c, "created", "seo", item.ID, target, "Added SEO override for %s", target)
+	return c.Redirect(http.StatusSeeOther, "/admin/seo")
+}
+
+// Edit handles GET /admin/seo/:id/edit
+// Template: admin/pages/seo_form.html (full page)
+func (h *SEOHandler) Edit(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	item, err := h.queries.GetSEOMeta(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load SEO override", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.renderForm(c, http.StatusOK, item, "")
+}
+
+// Update handles POST /admin/seo/:id
+// Saves an override, including the page it applies to.
+func (h *SEOHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if _, err := h.queries.GetSEOMeta(c.Request().Context(), id); errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	params, msg := h.form(c)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, seoMetumFrom(params, id), msg)
+	}
+	err = h.queries.UpdateSEOMeta(c.Request().Context(), sqlc.UpdateSEOMetaParams{
+		Route:           params.Route,
+		EntityType:      params.EntityType,
+		EntityID:        params.EntityID,
+		MetaTitle:       params.MetaTitle,
+		MetaDescription: params.MetaDescription,
+		CanonicalUrl:    params.CanonicalUrl,
+		OgImage:         params.OgImage,
+		Noindex:         params.Noindex,
+		ID:              id,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return h.renderForm(c, http.StatusUnprocessableEntity, seoMetumFrom(params, id), "This page already has an override; edit that one instead.")
+		}
+		h.logger.ErrorContext(c.Request().Context(), "failed to update SEO override", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	target := h.target(c, seoMetumFrom(params, id))
+	logActivity(c, "updated", "seo", id, target, "Updated SEO override for %s", target)
+	return c.Redirect(http.StatusSeeOther, "/admin/seo")
+}
+
+// Delete handles DELETE /admin/seo/:id
+// Removes an override; the page shows its own metadata again.
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *SEOHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteSEOMeta(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete SEO override", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.changed(c)
+	logActivity(c, "deleted", "seo", id, "", "Deleted SEO override #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// changed reloads the overrides served to visitors and drops cached pages,
+// which were rendered with the old metadata.
+func (h *SEOHandler) changed(c echo.Context) {
+	if err := h.seo.Reload(c.Request().Context()); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to reload SEO overrides", "error", err)
+	}
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
+}
+
+// target describes the page an override applies to: its path, or the
+// content type and title of its item.
+func (h *SEOHandler) target(c echo.Context, item sqlc.SeoMetum) string {
+	if item.Route != "" {
+		return item.Route
+	}
+	label := services.SEOEntityLabel(item.EntityType)
+	title, err := h.seo.EntityTitle(c.Request().Context(), item.EntityType, item.EntityID)
+	if err != nil {
+		return label + " #" + strconv.FormatInt(item.EntityID, 10) + " (deleted)"
+	}
+	return label + ": " + title
+}
+
+// form reads and validates the override form. The page is the route field
+// when entity_type is empty, else the content item entity_id of that type,
+// which must exist. It returns a message for the form on invalid input.
+func (h *SEOHandler) form(c echo.Context) (sqlc.CreateSEOMetaParams, string) {
+	entityID, _ := strconv.ParseInt(c.FormValue("entity_id"), 10, 64)
+	params := sqlc.CreateSEOMetaParams{
+		EntityType:      c.FormValue("entity_type"),
+		EntityID:        entityID,
+		MetaTitle:       c.FormValue("meta_title"),
+		MetaDescription: c.FormValue("meta_description"),
+		CanonicalUrl:    c.FormValue("canonical_url"),
+		OgImage:         c.FormValue("og_image"),
+		Noindex:         c.FormValue("noindex") == "on",
+	}
+	if params.EntityType == "" {
+		params.Route = c.FormValue("route")
+	}
+	params, err := services.ValidateSEOMeta(params)
+	if err != nil {
+		return params, seoErrorMessages[err]
+	}
+	if params.EntityType != "" {
+		if _, err := h.seo.EntityTitle(c.Request().Context(), params.EntityType, params.EntityID); err != nil {
+			return params, "There is no " + services.SEOEntityLabel(params.EntityType) + " with ID " + strconv.FormatInt(params.EntityID, 10) + "."
+		}
+	}
+	return params, ""
+}
+
+// seoErrorMessages explains override validation errors on the form.
+var seoErrorMessages = map[error]string{
+	services.ErrSEOTarget:    "Enter a page path starting with / (e.g. /about), or choose a content type and its ID.",
+	services.ErrSEOReserved:  "Admin pages and /public files have no SEO metadata.",
+	services.ErrSEOCanonical: "The canonical URL must be a path on this site, starting with /.",
+	services.ErrSEOImage:     "The image must be a path starting with / or a full http(s):// URL.",
+	services.ErrSEOEmpty:     "Fill in at least one field; an empty override changes nothing.",
+}
+
+// renderForm shows the add or edit form for item (ID 0 for a new one).
+func (h *SEOHandler) renderForm(c echo.Context, status int, item sqlc.SeoMetum, errMsg string) error {
+	title, action := "New SEO Override", "/admin/seo"
+	if item.ID != 0 {
+		title, action = "Edit SEO Override", "/admin/seo/"+strconv.FormatInt(item.ID, 10)
+	}
+	return c.Render(status, "admin/pages/seo_form.html", map[string]interface{}{
+		"Title":       title,
+		"Item":        item,
+		"FormAction":  action,
+		"EntityTypes": services.SEOEntityTypes,
+		"Error":       errMsg,
+	})
+}
+
+// seoMetumFrom turns submitted form values back into a row for the form.
+func seoMetumFrom(p sqlc.CreateSEOMetaParams, id int64) sqlc.SeoMetum {
+	return sqlc.SeoMetum{
+		ID:              id,
+		Route:           p.Route,
+		EntityType:      p.EntityType,
+		EntityID:        p.EntityID,
+		MetaTitle:       p.MetaTitle,
+		MetaDescription: p.MetaDescription,
+		CanonicalUrl:    p.CanonicalUrl,
+		OgImage:         p.OgImage,
+		Noindex:         p.Noindex,
+	}
+}
@@ -0,0 +1,204 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains handlers for the current user's active sessions page,
+// including revoking a single session and logging out everywhere.
+package admin
+
+import (
+	// Standard library imports
+	"log/slog" // Structured logging for error tracking
+	"net/http" // HTTP status codes and error responses
+	"strconv"  // Session ID parsing from the URL
+	"strings"  // User-Agent matching
+	"time"     // Remaining session time for KeepAlive
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Session access and cookie handling
+)
+
+// SessionsHandler lists the signed-in user's sessions (browsers and devices)
+// and lets them end any of them remotely.
+type SessionsHandler struct {
+	queries sqlc.Querier // Database query interface for admin_sessions
+	logger  *slog.Logger // Structured logger for error tracking
+}
+
+// NewSessionsHandler constructs a new SessionsHandler with required dependencies.
+func NewSessionsHandler(queries sqlc.Querier, logger *slog.Logger) *SessionsHandler {
+	return &SessionsHandler{queries: queries, logger: logger}
+}
+
+// sessionView is one row of the Active Sessions page.
+type sessionView struct {
+	sqlc.AdminSession
+	Device  string // Browser and OS summary, e.g. "Chrome on macOS"
+	Current bool   // True for the session making this request
+}
+
+// List handles GET /admin/profile/sessions
+// Renders the current user's sessions, most recently active first.
+// Template: admin/pages/profile_sessions.html (full page)
+//
+// Query parameters:
+//   - revoked / others: Flash flags set by Revoke and RevokeOthers redirects
+func (h *SessionsHandler) List(c echo.Context) error {
+	sess := c.Get("session").(*customMiddleware.Session)
+
+	rows, err := h.queries.ListAdminSessionsByUser(c.Request().Context(), sess.UserID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list sessions", "user_id", sess.UserID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	views := make([]sessionView, 0, len(rows))
+	for _, row := range rows {
+		views = append(views, sessionView{
+			AdminSession: row,
+			Device:       describeUserAgent(row.UserAgent),
+			Current:      row.Token == sess.Token,
+		})
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/profile_sessions.html", map[string]interface{}{
+		"Title":       "Active Sessions",
+		"Sessions":    views,
+		"DisplayName": sess.DisplayName,
+		"Email":       sess.Email,
+		"Role":        sess.Role,
+		"Revoked":     c.QueryParam("revoked") == "1",
+		"Others":      c.QueryParam("others") == "1",
+	})
+}
+
+// Revoke handles POST /admin/profile/sessions/:id/revoke
+// Ends one of the current user's other sessions. That browser is sent to the
+// login page on its next request. Use Logout for the current session.
+func (h *SessionsHandler) Revoke(c echo.Context) error {
+	sess := c.Get("session").(*customMiddleware.Session)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid session ID")
+	}
+
+	if err := h.queries.DeleteAdminSession(c.Request().Context(), sqlc.DeleteAdminSessionParams{ID: id, UserID: sess.UserID}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to revoke session", "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	logActivity(c, "revoked", "session", id, sess.DisplayName, "Revoked session #%d", id)
+	return c.Redirect(http.StatusSeeOther, "/admin/profile/sessions?revoked=1")
+}
+
+// RevokeOthers handles POST /admin/profile/sessions/revoke-others
+// Ends every session of the current user except this one.
+func (h *SessionsHandler) RevokeOthers(c echo.Context) error {
+	sess := c.Get("session").(*customMiddleware.Session)
+	ctx := c.Request().Context()
+
+	rows, err := h.queries.ListAdminSessionsByUser(ctx, sess.UserID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list sessions", "user_id", sess.UserID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	revoked := 0
+	for _, row := range rows {
+		if row.Token == sess.Token {
+			continue
+		}
+		revoked++
+		if err := h.queries.DeleteAdminSession(ctx, sqlc.DeleteAdminSessionParams{ID: row.ID, UserID: sess.UserID}); err != nil {
+			h.logger.ErrorContext(ctx, "failed to revoke session", "id", row.ID, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+
+	logActivity(c, "revoked", "session", 0, sess.DisplayName, "Logged out %d other session(s)", revoked)
+	return c.Redirect(http.StatusSeeOther, "/admin/profile/sessions?others=1")
+}
+
+// RevokeAll handles POST /admin/profile/sessions/revoke-all
+// "Log out everywhere": ends every session of the current user, including
+// this one, and redirects to the login page.
+func (h *SessionsHandler) RevokeAll(c echo.Context) error {
+	sess := c.Get("session").(*customMiddleware.Session)
+
+	if err := h.queries.DeleteAdminSessionsByUser(c.Request().Context(), sess.UserID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to revoke sessions", "user_id", sess.UserID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "revoked", "session", 0, sess.DisplayName, "Logged out everywhere")
+
+	// Clear this browser's cookie as Logout does
+	sess.UserID = 0
+	sess.Email = ""
+	sess.DisplayName = ""
+	sess.Role = ""
+	sess.Token = ""
+	sess.Options.MaxAge = -1
+	if err := sess.Save(c.Request(), c.Response()); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to destroy session", "error", err)
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/login")
+}
+
+// KeepAlive handles POST /admin/session/keepalive
+// Called silently by session-timeout.js while an editor is active. Like any
+// admin request, it restarts the idle timeout in SessionTracker; the response
+// tells the page how long the session now has left, in seconds. Since this
+// request just restarted it, idle_remaining is also the full idle timeout.
+// A limit that is disabled is reported as 0.
+//
+// Response (JSON):
+//   - idle_remaining: Time until idle sign-out without further requests
+//   - absolute_remaining: Time until sign-out regardless of activity
+func (h *SessionsHandler) KeepAlive(c echo.Context) error {
+	expiry, _ := customMiddleware.SessionExpiryFrom(c)
+	now := time.Now()
+	remaining := func(t time.Time) int {
+		if t.IsZero() {
+			return 0
+		}
+		return int(t.Sub(now).Seconds())
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, map[string]int{
+		"idle_remaining":     remaining(expiry.Idle),
+		"absolute_remaining": remaining(expiry.Absolute),
+	})
+}
+
+// describeUserAgent summarizes a User-Agent header as "Browser on OS".
+// Order matters: Edge and Opera also claim Chrome, and Chrome claims Safari.
+func describeUserAgent(ua string) string {
+	if ua == "" {
+		return "Unknown device"
+	}
+	browser := "Unknown browser"
+	for _, b := range []struct{ token, name string }{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"}, {"Safari/", "Safari"}, {"curl/", "curl"},
+	} {
+		if strings.Contains(ua, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	os := ""
+	for _, o := range []struct{ token, name string }{
+		{"iPhone", "iOS"}, {"iPad", "iPadOS"}, {"Android", "Android"},
+		{"Windows", "Windows"}, {"Mac OS X", "macOS"}, {"CrOS", "ChromeOS"}, {"Linux", "Linux"},
+	} {
+		if strings.Contains(ua, o.token) {
+			os = o.name
+			break
+		}
+	}
+	if os == "" {
+		return browser
+	}
+	return browser + " on " + os
+}
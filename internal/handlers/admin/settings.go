@@ -4,11 +4,13 @@ import (
 	// Standard library imports
 	"log/slog"  // Structured logging for error tracking and debugging
 	"net/http"  // HTTP status codes and request/response handling
+	"strings"   // Normalizing theme colors
 
 	// Third-party framework
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context management
 
 	// Internal dependencies
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
 	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries and models
 	"github.com/narendhupati/bluejay-cms/internal/services" // Cache service for invalidating page-level caches
 )
@@ -17,14 +19,14 @@ import (
 // Manages site-wide configuration including contact info, SEO metadata,
 // analytics integration, and social media links.
 type SettingsHandler struct {
-	queries *sqlc.Queries   // Database query interface for settings CRUD operations
+	queries sqlc.Querier    // Database query interface for settings CRUD operations
 	logger  *slog.Logger    // Structured logger for error tracking
 	cache   *services.Cache // Cache service for invalidating page-level caches
 }
 
 // NewSettingsHandler creates and initializes a new SettingsHandler instance.
 // Dependencies are injected to support database access, logging, and cache invalidation.
-func NewSettingsHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *SettingsHandler {
+func NewSettingsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *SettingsHandler {
 	return &SettingsHandler{queries: queries, logger: logger, cache: cache}
 }
 
@@ -41,7 +43,6 @@ func NewSettingsHandler(queries *sqlc.Queries, logger *slog.Logger, cache *servi
 // - Social: Facebook, Twitter, LinkedIn, Instagram, YouTube links
 //
 // Query Parameters:
-// - saved: Set to "1" after successful update to show success message
 // - tab: Active tab identifier (general, seo, social) - defaults to "general"
 //
 // Template Data:
@@ -49,19 +50,19 @@ func NewSettingsHandler(queries *sqlc.Queries, logger *slog.Logger, cache *servi
 // - Settings: Current settings row from database (all fields)
 // - Saved: Boolean flag to display success banner
 // - ActiveTab: Which tab should be displayed/highlighted
+// - ThemeModes: Color inputs and previews of the Theme tab, one per mode
+// - Timezones: Suggested IANA zone names for the timezone field
+// - FormatLocales, Currencies: Suggestions for the number format fields
 //
 // Authentication: Requires valid session (enforced by middleware)
 func (h *SettingsHandler) Edit(c echo.Context) error {
 	// Fetch current settings from database (single row table)
 	settings, err := h.queries.GetSettings(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to load settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Check for success flag from previous update operation
-	saved := c.QueryParam("saved") == "1"
-
 	// Determine which tab should be active (preserves tab state after form submission)
 	activeTab := c.QueryParam("tab")
 	if activeTab == "" {
@@ -74,11 +75,48 @@ func (h *SettingsHandler) Edit(c echo.Context) error {
 	return c.Render(http.StatusOK, "admin/pages/settings_form.html", map[string]interface{}{
 		"Title":     "Global Settings",
 		"Settings":  settings,           // Current settings data from database
-		"Saved":     saved,               // Show success message if true
 		"ActiveTab": activeTab,           // Determines which tab is visible/active
+		"ThemeModes": themeFormModes(services.ThemeFromSettings(settings)),
+		"Timezones":  services.CommonTimezones, // Suggestions for the timezone field
+		"FormatLocales": services.CommonFormatLocales, // Suggestions for the formatting locale field
+		"Currencies":    services.CommonCurrencies,    // Suggestions for the currency field
 	})
 }
 
+// themeFormColor is one color input of the Theme tab.
+type themeFormColor struct {
+	Name  string // Form field, e.g. "theme_dark_surface"
+	Label string
+	Token string // CSS variable updated by the live preview
+	Value string
+}
+
+// themeFormMode groups the color inputs and preview of one mode.
+type themeFormMode struct {
+	Mode   string // "light" or "dark"; also the preview's data-theme
+	Label  string
+	Colors []themeFormColor
+}
+
+// themeFormModes lays out the Theme tab for the current theme.
+func themeFormModes(theme services.Theme) []themeFormMode {
+	mode := func(name, label string, c services.ThemeColors) themeFormMode {
+		field := "theme_" + name + "_"
+		return themeFormMode{Mode: name, Label: label, Colors: []themeFormColor{
+			{field + "primary", "Primary", "--color-primary", c.Primary},
+			{field + "primary_hover", "Primary Hover", "--color-primary-hover", c.PrimaryHover},
+			{field + "background", "Background", "--color-background", c.Background},
+			{field + "surface", "Surface", "--color-surface", c.Surface},
+			{field + "text", "Text", "--color-text", c.Text},
+			{field + "border", "Border", "--color-border", c.Border},
+		}}
+	}
+	return []themeFormMode{
+		mode(services.ThemeLight, "Light Mode", theme.Light),
+		mode(services.ThemeDark, "Dark Mode", theme.Dark),
+	}
+}
+
 // Update processes the global settings form submission and persists changes to database.
 //
 // HTTP Method: POST
@@ -98,6 +136,13 @@ func (h *SettingsHandler) Edit(c echo.Context) error {
 // - contact_phone: Primary contact phone number
 // - address: Physical business address
 // - business_hours: Operating hours text
+// - timezone: IANA zone timestamps are displayed in; blank or unknown names
+//   keep the saved zone
+// - format_locale, currency: BCP 47 tag and ISO 4217 code templates format
+//   numbers and prices with; saved only when both are valid
+// - maintenance_mode: Checkbox ("on" when checked) to show visitors the
+//   maintenance page (503) instead of the site
+// - maintenance_message: Text of the maintenance page; blank uses a default
 //
 // SEO Tab:
 // - meta_description: Default meta description for SEO
@@ -111,9 +156,22 @@ func (h *SettingsHandler) Edit(c echo.Context) error {
 // - social_instagram: Instagram profile URL
 // - social_youtube: YouTube channel URL
 //
+// Integrations Tab:
+// - mt_provider: Machine-translation provider ("deepl", "google", or "" to disable)
+// - mt_api_key: Provider API key; left blank to keep the saved key
+// - mt_api_key_clear: Set to "1" to remove the saved key
+//
+// Performance Tab:
+// - minify_html: Checkbox ("on" when checked) to minify public pages before caching
+//
+// Theme Tab:
+// - theme_mode: Default theme for visitors ("light", "dark", or "system")
+// - theme_light_*, theme_dark_*: #RRGGBB colors for each mode; a blank or
+//   malformed value keeps the saved color
+//
 // Post-Update Behavior:
 // - Logs activity to activity_log table for audit trail
-// - Redirects back to settings form with saved=1 flag (shows success message)
+// - Redirects back to settings form with a success flash message
 // - Preserves active tab state in redirect URL
 //
 // Authentication: Requires valid session (enforced by middleware)
@@ -124,33 +182,133 @@ func (h *SettingsHandler) Update(c echo.Context) error {
 		activeTab = "general" // Default to general tab if not specified
 	}
 
-	// Update all global settings fields in database (single UPDATE query)
-	// Settings table contains one row with all global configuration
-	err := h.queries.UpdateGlobalSettings(c.Request().Context(), sqlc.UpdateGlobalSettingsParams{
-		// General settings
-		SiteName:          c.FormValue("site_name"),
-		SiteTagline:       c.FormValue("site_tagline"),
-		ContactEmail:      c.FormValue("contact_email"),
-		ContactPhone:      c.FormValue("contact_phone"),
-		Address:           c.FormValue("address"),
-		BusinessHours:     c.FormValue("business_hours"),
-
-		// SEO settings
-		MetaDescription:   c.FormValue("meta_description"),
-		MetaKeywords:      c.FormValue("meta_keywords"),
-		GoogleAnalyticsID: c.FormValue("google_analytics_id"),
-
-		// Social media links
-		SocialFacebook:    c.FormValue("social_facebook"),
-		SocialTwitter:     c.FormValue("social_twitter"),
-		SocialLinkedin:    c.FormValue("social_linkedin"),
-		SocialInstagram:   c.FormValue("social_instagram"),
-		SocialYoutube:     c.FormValue("social_youtube"),
+	// The API key is never echoed back into the form, so a blank field keeps the saved key
+	current, err := h.queries.GetSettings(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	mtAPIKey := c.FormValue("mt_api_key")
+	if mtAPIKey == "" {
+		mtAPIKey = current.MtApiKey
+	}
+	if c.FormValue("mt_api_key_clear") == "1" {
+		mtAPIKey = ""
+	}
+
+	// Site timezone: applied to template date formatting once saved
+	tz := c.FormValue("timezone")
+	tzChanged := tz != current.Timezone && services.IsTimezone(tz)
+
+	// Number and price format: applied to the template functions once saved
+	formatLocale := strings.TrimSpace(c.FormValue("format_locale"))
+	currencyCode := strings.ToUpper(strings.TrimSpace(c.FormValue("currency")))
+	formatChanged := (formatLocale != current.FormatLocale || currencyCode != current.Currency) &&
+		services.IsFormatLocale(formatLocale) && services.IsCurrency(currencyCode)
+
+	// Maintenance mode: the public middleware reads it from the settings
+	// loaded on every request, so it applies to the next page view
+	maintenance := c.FormValue("maintenance_mode") == "on"
+	maintenanceMessage := strings.TrimSpace(c.FormValue("maintenance_message"))
+
+	// Theme tokens, served to both layouts from /theme-tokens.css
+	themeMode := current.ThemeMode
+	for _, m := range services.ThemeModes {
+		if c.FormValue("theme_mode") == m {
+			themeMode = m
+		}
+	}
+
+	// All settings tabs are saved in one transaction, so a failed write
+	// cannot leave the settings half updated
+	err = withTx(c.Request().Context(), h.queries, func(q sqlc.Querier) error {
+		// Update all global settings fields (single UPDATE query)
+		// Settings table contains one row with all global configuration
+		err := q.UpdateGlobalSettings(c.Request().Context(), sqlc.UpdateGlobalSettingsParams{
+			// General settings
+			SiteName:          c.FormValue("site_name"),
+			SiteTagline:       c.FormValue("site_tagline"),
+			ContactEmail:      c.FormValue("contact_email"),
+			ContactPhone:      c.FormValue("contact_phone"),
+			Address:           c.FormValue("address"),
+			BusinessHours:     c.FormValue("business_hours"),
+
+			// SEO settings
+			MetaDescription:   c.FormValue("meta_description"),
+			MetaKeywords:      c.FormValue("meta_keywords"),
+			GoogleAnalyticsID: c.FormValue("google_analytics_id"),
+
+			// Social media links
+			SocialFacebook:    c.FormValue("social_facebook"),
+			SocialTwitter:     c.FormValue("social_twitter"),
+			SocialLinkedin:    c.FormValue("social_linkedin"),
+			SocialInstagram:   c.FormValue("social_instagram"),
+			SocialYoutube:     c.FormValue("social_youtube"),
+
+			// Machine-translation assist
+			MtProvider:        c.FormValue("mt_provider"),
+			MtApiKey:          mtAPIKey,
+
+			// Performance
+			MinifyHtml: c.FormValue("minify_html") == "on",
+		})
+		if err != nil {
+			return err
+		}
+		if tzChanged {
+			if err := q.UpdateSiteTimezone(c.Request().Context(), tz); err != nil {
+				return err
+			}
+		}
+		if formatChanged {
+			if err := q.UpdateSiteFormat(c.Request().Context(), sqlc.UpdateSiteFormatParams{
+				FormatLocale: formatLocale,
+				Currency:     currencyCode,
+			}); err != nil {
+				return err
+			}
+		}
+		if maintenance != current.MaintenanceMode || maintenanceMessage != current.MaintenanceMessage {
+			if err := q.UpdateMaintenanceMode(c.Request().Context(), sqlc.UpdateMaintenanceModeParams{
+				MaintenanceMode:    maintenance,
+				MaintenanceMessage: maintenanceMessage,
+			}); err != nil {
+				return err
+			}
+		}
+		return q.UpdateThemeSettings(c.Request().Context(), sqlc.UpdateThemeSettingsParams{
+			ThemeMode:              themeMode,
+			ThemeLightPrimary:      themeColorValue(c, "theme_light_primary", current.ThemeLightPrimary),
+			ThemeLightPrimaryHover: themeColorValue(c, "theme_light_primary_hover", current.ThemeLightPrimaryHover),
+			ThemeLightBackground:   themeColorValue(c, "theme_light_background", current.ThemeLightBackground),
+			ThemeLightSurface:      themeColorValue(c, "theme_light_surface", current.ThemeLightSurface),
+			ThemeLightText:         themeColorValue(c, "theme_light_text", current.ThemeLightText),
+			ThemeLightBorder:       themeColorValue(c, "theme_light_border", current.ThemeLightBorder),
+			ThemeDarkPrimary:       themeColorValue(c, "theme_dark_primary", current.ThemeDarkPrimary),
+			ThemeDarkPrimaryHover:  themeColorValue(c, "theme_dark_primary_hover", current.ThemeDarkPrimaryHover),
+			ThemeDarkBackground:    themeColorValue(c, "theme_dark_background", current.ThemeDarkBackground),
+			ThemeDarkSurface:       themeColorValue(c, "theme_dark_surface", current.ThemeDarkSurface),
+			ThemeDarkText:          themeColorValue(c, "theme_dark_text", current.ThemeDarkText),
+			ThemeDarkBorder:        themeColorValue(c, "theme_dark_border", current.ThemeDarkBorder),
+		})
 	})
 	if err != nil {
-		h.logger.Error("failed to update settings", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update settings", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	if tzChanged {
+		services.SetSiteTimezone(tz)
+	}
+	if formatChanged {
+		services.SetSiteFormat(formatLocale, currencyCode)
+	}
+	if maintenance != current.MaintenanceMode {
+		if maintenance {
+			logActivity(c, "enabled", "maintenance", 0, "", "Enabled maintenance mode")
+		} else {
+			logActivity(c, "disabled", "maintenance", 0, "", "Disabled maintenance mode")
+		}
+	}
 
 	// Invalidate ALL cached public pages. Global settings (contact info, site name,
 	// etc.) render site-wide via the footer/header, so every "page:" cache entry is
@@ -163,8 +321,17 @@ func (h *SettingsHandler) Update(c echo.Context) error {
 	// entity_id=0 since settings is a singleton (no specific ID)
 	logActivity(c, "updated", "settings", 0, "", "Updated Global Settings")
 
-	// Redirect back to settings form with success flag and preserved tab state
-	// saved=1 triggers success message banner in template
+	// Redirect back to settings form with a success flash message and preserved tab state
 	// tab parameter ensures same tab is displayed after update
-	return c.Redirect(http.StatusSeeOther, "/admin/settings?saved=1&tab="+activeTab)
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Settings saved.")
+	return c.Redirect(http.StatusSeeOther, "/admin/settings?tab="+activeTab)
+}
+
+// themeColorValue returns the submitted #RRGGBB color for field, or current
+// when the field is blank or malformed.
+func themeColorValue(c echo.Context, field, current string) string {
+	if v := c.FormValue(field); services.IsThemeColor(v) {
+		return strings.ToUpper(v)
+	}
+	return current
 }
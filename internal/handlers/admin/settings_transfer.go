@@ -0,0 +1,161 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the JSON export and import of all site settings, used to
+// promote configuration between environments and to keep backups.
+package admin
+
+import (
+	// Standard library imports
+	"encoding/json" // Settings document output
+	"fmt"           // Download file name
+	"io"            // Reading the uploaded file
+	"net/http"      // HTTP status codes
+	"time"          // Export timestamp and preview fingerprint
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Settings document validation and apply
+)
+
+// maxSettingsFileSize limits uploaded settings files to 1 MB.
+const maxSettingsFileSize = 1 << 20
+
+// Export handles GET /admin/settings/export
+// Downloads every setting (except secrets such as API keys) as a JSON
+// document that ImportApply accepts on this or another installation.
+func (h *SettingsHandler) Export(c echo.Context) error {
+	settings, err := h.queries.GetSettings(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	now := time.Now()
+	body, err := json.MarshalIndent(services.ExportSettings(settings, now), "", "  ")
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to encode settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	filename := fmt.Sprintf("settings-%s.json", now.UTC().Format("20060102-150405"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	logActivity(c, "exported", "settings", 0, "", "Exported settings")
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, append(body, '\n'))
+}
+
+// Import handles GET /admin/settings/import
+// Renders the upload step.
+// Template: admin/pages/settings_import.html (full page)
+func (h *SettingsHandler) Import(c echo.Context) error {
+	return h.renderImport(c, map[string]interface{}{})
+}
+
+// ImportPreview handles POST /admin/settings/import
+// Validates the uploaded document against the current settings and renders
+// the changes it would make. Nothing is written.
+//
+// Form fields:
+//   - file: JSON file from Export
+func (h *SettingsHandler) ImportPreview(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return h.renderImport(c, map[string]interface{}{"UploadError": "Choose a settings file to import."})
+	}
+	if fileHeader.Size > maxSettingsFileSize {
+		return h.renderImport(c, map[string]interface{}{"UploadError": "The file is larger than 1 MB; it is not a settings export."})
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to open settings file", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to read settings file", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.preview(c, string(data), fileHeader.Filename, "")
+}
+
+// ImportApply handles POST /admin/settings/import/apply
+// Re-validates the previewed document and writes it. If the settings were
+// saved by someone else since the preview, the preview is shown again
+// instead, so nothing is applied that was not reviewed. Each changed setting
+// is recorded in the activity log with its old and new value.
+//
+// Form fields:
+//   - document: JSON carried over from ImportPreview
+//   - file_name: Name of the uploaded file, for the activity log
+//   - settings_version: updated_at of the settings the preview was built from
+func (h *SettingsHandler) ImportApply(c echo.Context) error {
+	ctx := c.Request().Context()
+	document, fileName := c.FormValue("document"), c.FormValue("file_name")
+	current, err := h.queries.GetSettings(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if c.FormValue("settings_version") != settingsVersion(current.UpdatedAt) {
+		return h.preview(c, document, fileName, "Settings were changed since this preview. Review the changes again before applying.")
+	}
+
+	result := services.ParseSettingsImport([]byte(document), current)
+	if !result.OK() || len(result.Changes) == 0 {
+		return h.preview(c, document, fileName, "")
+	}
+	if err := services.ApplySettingsImport(ctx, h.queries, result.Settings); err != nil {
+		h.logger.ErrorContext(ctx, "failed to import settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	// The timezone and format were validated by ParseSettingsImport
+	services.SetSiteTimezone(result.Settings.Timezone)
+	services.SetSiteFormat(result.Settings.FormatLocale, result.Settings.Currency)
+
+	// Settings render site-wide, so every cached page may be stale
+	if h.cache != nil {
+		h.cache.DeleteByPrefix("page:")
+	}
+	for _, ch := range result.Changes {
+		logActivity(c, "updated", "settings", 0, ch.Key, "Imported %s from %s: %q → %q", ch.Key, fileName, truncateSetting(ch.Old), truncateSetting(ch.New))
+	}
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Settings imported.")
+	return c.Redirect(http.StatusSeeOther, "/admin/settings")
+}
+
+// preview validates document against the current settings and renders the
+// diff step.
+func (h *SettingsHandler) preview(c echo.Context, document, fileName, notice string) error {
+	current, err := h.queries.GetSettings(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load settings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.renderImport(c, map[string]interface{}{
+		"Document":        document,
+		"FileName":        fileName,
+		"Result":          services.ParseSettingsImport([]byte(document), current),
+		"SettingsVersion": settingsVersion(current.UpdatedAt),
+		"Notice":          notice,
+	})
+}
+
+// renderImport renders the settings import page.
+func (h *SettingsHandler) renderImport(c echo.Context, data map[string]interface{}) error {
+	data["Title"] = "Import Settings"
+	return c.Render(http.StatusOK, "admin/pages/settings_import.html", data)
+}
+
+// settingsVersion identifies a saved state of the settings row.
+func settingsVersion(updatedAt time.Time) string {
+	return updatedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// truncateSetting shortens long values (e.g. about text) for the activity log.
+func truncateSetting(s string) string {
+	if r := []rune(s); len(r) > 80 {
+		return string(r[:80]) + "…"
+	}
+	return s
+}
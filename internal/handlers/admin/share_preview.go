@@ -0,0 +1,147 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the social share preview shown on the product, blog
+// post and whitepaper edit forms.
+package admin
+
+import (
+	"context"      // Loading the stored item
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"fmt"          // Product page titles
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing IDs
+	"strings"      // Trimming form values
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // SEO overrides and share previews
+)
+
+// sharePreviewForm reads the page metadata of an edit form as the public
+// handler of its type would set it. id is 0 for an item not saved yet;
+// otherwise the stored item supplies the fields the form does not edit,
+// such as the Open Graph image.
+type sharePreviewForm struct {
+	entityType string // services.Preview* type, for the SEO override
+	page       func(ctx context.Context, q sqlc.Querier, c echo.Context, id int64) (services.SharePage, error)
+}
+
+// sharePreviewForms are keyed by the :type of POST /admin/share-preview/:type.
+var sharePreviewForms = map[string]sharePreviewForm{
+	"products": {services.PreviewProduct, func(ctx context.Context, q sqlc.Querier, c echo.Context, id int64) (services.SharePage, error) {
+		page := services.SharePage{
+			Title:           fmt.Sprintf("%s | Products", strings.TrimSpace(c.FormValue("name"))),
+			MetaTitle:       strings.TrimSpace(c.FormValue("meta_title")),
+			MetaDescription: strings.TrimSpace(c.FormValue("meta_description")),
+		}
+		categoryID, _ := strconv.ParseInt(c.FormValue("category_id"), 10, 64)
+		cat, err := q.GetProductCategory(ctx, categoryID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return page, err
+		}
+		page.CanonicalURL = "/products/" + cat.Slug + "/" + strings.TrimSpace(c.FormValue("slug"))
+		if id != 0 {
+			p, err := q.GetProduct(ctx, id)
+			page.OGImage = p.OgImage
+			if errors.Is(err, sql.ErrNoRows) {
+				err = nil
+			}
+			return page, err
+		}
+		return page, nil
+	}},
+	"blog-posts": {services.PreviewBlogPost, func(ctx context.Context, q sqlc.Querier, c echo.Context, id int64) (services.SharePage, error) {
+		page := services.SharePage{
+			Title:           strings.TrimSpace(c.FormValue("title")),
+			MetaTitle:       strings.TrimSpace(c.FormValue("meta_title")),
+			MetaDescription: strings.TrimSpace(c.FormValue("meta_description")),
+			CanonicalURL:    "/blog/" + strings.TrimSpace(c.FormValue("slug")),
+		}
+		if id != 0 {
+			post, err := q.GetBlogPost(ctx, id)
+			page.OGImage = post.OgImage
+			if errors.Is(err, sql.ErrNoRows) {
+				err = nil
+			}
+			return page, err
+		}
+		return page, nil
+	}},
+	"whitepapers": {services.PreviewWhitepaper, func(ctx context.Context, q sqlc.Querier, c echo.Context, id int64) (services.SharePage, error) {
+		page := services.SharePage{
+			Title:           strings.TrimSpace(c.FormValue("title")),
+			MetaTitle:       strings.TrimSpace(c.FormValue("meta_title")),
+			MetaDescription: strings.TrimSpace(c.FormValue("meta_description")),
+			CanonicalURL:    "/whitepapers/" + strings.TrimSpace(c.FormValue("slug")),
+		}
+		if id != 0 {
+			// The image is only read by the slug queries of the public page
+			stored, err := q.GetWhitepaperByID(ctx, id)
+			if err == nil {
+				var wp sqlc.GetWhitepaperBySlugIncludeDraftsRow
+				wp, err = q.GetWhitepaperBySlugIncludeDrafts(ctx, stored.Slug)
+				page.OGImage = wp.OgImage
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				err = nil
+			}
+			return page, err
+		}
+		return page, nil
+	}},
+}
+
+// SharePreviewHandler renders how an item being edited will look on
+// Google, X and LinkedIn.
+type SharePreviewHandler struct {
+	queries sqlc.Querier  // Database queries generated by sqlc
+	logger  *slog.Logger  // Structured logger for error reporting
+	seo     *services.SEO // Admin > SEO overrides, applied like on the public page
+	baseURL string        // Public site URL
+}
+
+// NewSharePreviewHandler creates a new SharePreviewHandler instance.
+func NewSharePreviewHandler(queries sqlc.Querier, logger *slog.Logger, seo *services.SEO, baseURL string) *SharePreviewHandler {
+	return &SharePreviewHandler{queries: queries, logger: logger, seo: seo, baseURL: baseURL}
+}
+
+// Preview handles POST /admin/share-preview/:type
+// HTMX: Yes - posted with the edit form's current values, swaps the preview.
+// The title, description, image and URL are resolved as on the public page:
+// the item's fields, then the Admin > SEO override for its path or for the
+// item, then the layout's fallbacks (site name suffix, default description).
+// Template: admin/partials/share_preview.html
+//
+// Query Parameters:
+//   - id: ID of the item being edited; absent for a new item
+func (h *SharePreviewHandler) Preview(c echo.Context) error {
+	ctx := c.Request().Context()
+	form, ok := sharePreviewForms[c.Param("type")]
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	id, _ := strconv.ParseInt(c.QueryParam("id"), 10, 64)
+	page, err := form.page(ctx, h.queries, c, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load item for share preview", "type", c.Param("type"), "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if meta, found := h.seo.ForPath(page.CanonicalURL); found {
+		page = page.WithOverride(meta)
+	} else if meta, found := h.seo.ForEntity(form.entityType, id); found && id != 0 {
+		page = page.WithOverride(meta)
+	}
+
+	settings, err := h.queries.GetSettings(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.logger.ErrorContext(ctx, "failed to load settings for share preview", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/partials/share_preview.html", map[string]interface{}{
+		"Type":    c.Param("type"),
+		"ID":      id,
+		"Preview": services.BuildSharePreview(page, settings.MetaDescription, h.baseURL),
+	})
+}
@@ -0,0 +1,241 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the manager of the /go/ short links used in campaigns.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows detection and expiry dates
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing IDs
+	"strings"      // Trimming the base URL and form values
+	"time"         // Expiry dates
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Link validation, UTM tagging and site timezone
+)
+
+// ShortLinksHandler handles the short links at /admin/short-links.
+type ShortLinksHandler struct {
+	queries sqlc.Querier // Database queries generated by sqlc
+	logger  *slog.Logger // Structured logger for error reporting
+	baseURL string       // Public site URL, for the links to copy
+}
+
+// NewShortLinksHandler creates a new ShortLinksHandler instance.
+func NewShortLinksHandler(queries sqlc.Querier, logger *slog.Logger, baseURL string) *ShortLinksHandler {
+	return &ShortLinksHandler{queries: queries, logger: logger, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// shortLinkErrorMessages explains link validation errors on the form.
+var shortLinkErrorMessages = map[error]string{
+	services.ErrShortLinkCode:        "The code may use lowercase letters, digits, - and _ (up to 64), starting with a letter or digit.",
+	services.ErrShortLinkDestination: "The destination must be a path starting with / or a full http(s):// URL.",
+	services.ErrShortLinkLoop:        "The destination cannot be another short link.",
+	services.ErrShortLinkUTM:         "Enter a UTM source; analytics tools ignore the other UTM parameters without it.",
+}
+
+// List handles GET /admin/short-links
+// Lists every link, newest first, with its full URL, click count and
+// expiry.
+// Template: admin/pages/short_links_list.html (full page)
+func (h *ShortLinksHandler) List(c echo.Context) error {
+	links, err := h.queries.ListShortLinks(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list short links", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	targets := make(map[int64]string, len(links))
+	for _, link := range links {
+		targets[link.ID] = services.ShortLinkTarget(link)
+	}
+	return c.Render(http.StatusOK, "admin/pages/short_links_list.html", map[string]interface{}{
+		"Title":   "Short Links",
+		"Links":   links,
+		"Targets": targets,
+		"BaseURL": h.baseURL + strings.TrimSuffix(services.ShortLinkPrefix, "/"),
+		"Now":     time.Now(),
+	})
+}
+
+// New handles GET /admin/short-links/new
+// Template: admin/pages/short_links_form.html (full page)
+func (h *ShortLinksHandler) New(c echo.Context) error {
+	return h.renderForm(c, http.StatusOK, sqlc.ShortLink{}, "")
+}
+
+// Create handles POST /admin/short-links
+// Adds a link and returns to the list. A random code is generated when the
+// code is left empty. Invalid input is reported on the form.
+func (h *ShortLinksHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	link, msg := h.form(c, 0)
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, link, msg)
+	}
+	created, err := h.queries.CreateShortLink(ctx, sqlc.CreateShortLinkParams{
+		Code:        link.Code,
+		Destination: link.Destination,
+		UtmSource:   link.UtmSource,
+		UtmMedium:   link.UtmMedium,
+		UtmCampaign: link.UtmCampaign,
+		UtmTerm:     link.UtmTerm,
+		UtmContent:  link.UtmContent,
+		Note:        link.Note,
+		ExpiresAt:   link.ExpiresAt,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to create short link", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "short_link", created.ID, created.Code, "Created short link /go/%s", created.Code)
+	return c.Redirect(http.StatusSeeOther, "/admin/short-links")
+}
+
+// Edit handles GET /admin/short-links/:id/edit
+// Template: admin/pages/short_links_form.html (full page)
+func (h *ShortLinksHandler) Edit(c echo.Context) error {
+	link, err := h.link(c)
+	if err != nil {
+		return err
+	}
+	return h.renderForm(c, http.StatusOK, link, "")
+}
+
+// Update handles POST /admin/short-links/:id
+// Saves the link form. The click count is kept; a changed code stops the
+// old one from working.
+func (h *ShortLinksHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	existing, err := h.link(c)
+	if err != nil {
+		return err
+	}
+	link, msg := h.form(c, existing.ID)
+	link.ID, link.Clicks, link.LastClickAt = existing.ID, existing.Clicks, existing.LastClickAt
+	if msg != "" {
+		return h.renderForm(c, http.StatusUnprocessableEntity, link, msg)
+	}
+	err = h.queries.UpdateShortLink(ctx, sqlc.UpdateShortLinkParams{
+		Code:        link.Code,
+		Destination: link.Destination,
+		UtmSource:   link.UtmSource,
+		UtmMedium:   link.UtmMedium,
+		UtmCampaign: link.UtmCampaign,
+		UtmTerm:     link.UtmTerm,
+		UtmContent:  link.UtmContent,
+		Note:        link.Note,
+		ExpiresAt:   link.ExpiresAt,
+		ID:          existing.ID,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to update short link", "error", err, "id", existing.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "updated", "short_link", existing.ID, link.Code, "Updated short link /go/%s", link.Code)
+	return c.Redirect(http.StatusSeeOther, "/admin/short-links")
+}
+
+// Delete handles DELETE /admin/short-links/:id
+// Removes a link with its click count; /go/<code> then returns 404.
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *ShortLinksHandler) Delete(c echo.Context) error {
+	link, err := h.link(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.DeleteShortLink(c.Request().Context(), link.ID); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete short link", "error", err, "id", link.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "deleted", "short_link", link.ID, link.Code, "Deleted short link /go/%s", link.Code)
+	return c.NoContent(http.StatusOK)
+}
+
+// link loads the link named by the :id parameter.
+func (h *ShortLinksHandler) link(c echo.Context) (sqlc.ShortLink, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.ShortLink{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	link, err := h.queries.GetShortLink(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return link, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load short link", "error", err, "id", id)
+		return link, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return link, nil
+}
+
+// form reads and validates the link form for link id (0 for a new one).
+// The expiry date is entered in the site timezone and the link works until
+// the end of that day. It returns a message for the form on invalid input.
+func (h *ShortLinksHandler) form(c echo.Context, id int64) (sqlc.ShortLink, string) {
+	link := sqlc.ShortLink{
+		Code:        c.FormValue("code"),
+		Destination: c.FormValue("destination"),
+		UtmSource:   c.FormValue("utm_source"),
+		UtmMedium:   c.FormValue("utm_medium"),
+		UtmCampaign: c.FormValue("utm_campaign"),
+		UtmTerm:     c.FormValue("utm_term"),
+		UtmContent:  c.FormValue("utm_content"),
+		Note:        c.FormValue("note"),
+	}
+	var dateErr error
+	if v := strings.TrimSpace(c.FormValue("expires_at")); v != "" {
+		var day time.Time
+		day, dateErr = services.ParseSiteTime("2006-01-02", v)
+		link.ExpiresAt = sql.NullTime{Time: day.Add(24*time.Hour - time.Second).UTC(), Valid: dateErr == nil}
+	}
+
+	link, err := services.ValidateShortLink(link)
+	if err != nil {
+		return link, shortLinkErrorMessages[err]
+	}
+	if dateErr != nil {
+		return link, "Enter the expiry as a date."
+	}
+
+	ctx := c.Request().Context()
+	if link.Code == "" {
+		// Generated codes are 7 random characters; retry the rare collision
+		for i := 0; i < 5 && link.Code == ""; i++ {
+			code := services.NewShortLinkCode()
+			if _, err := h.queries.GetShortLinkByCode(ctx, code); errors.Is(err, sql.ErrNoRows) {
+				link.Code = code
+			}
+		}
+		if link.Code == "" {
+			return link, "Could not generate a free code; enter one."
+		}
+		return link, ""
+	}
+	taken, err := h.queries.GetShortLinkByCode(ctx, link.Code)
+	if err == nil && taken.ID != id {
+		return link, "Another link already uses /go/" + link.Code + "."
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.logger.ErrorContext(ctx, "failed to check short link code", "error", err)
+		return link, "Could not check the code; try again."
+	}
+	return link, ""
+}
+
+// renderForm shows the add or edit form for link (ID 0 for a new one).
+func (h *ShortLinksHandler) renderForm(c echo.Context, status int, link sqlc.ShortLink, errMsg string) error {
+	title, action := "New Short Link", "/admin/short-links"
+	if link.ID != 0 {
+		title, action = "Edit Short Link", "/admin/short-links/"+strconv.FormatInt(link.ID, 10)
+	}
+	return c.Render(status, "admin/pages/short_links_form.html", map[string]interface{}{
+		"Title":      title,
+		"Item":       link,
+		"FormAction": action,
+		"BaseURL":    h.baseURL + strings.TrimSuffix(services.ShortLinkPrefix, "/"),
+		"Error":      errMsg,
+	})
+}
@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"github.com/narendhupati/bluejay-cms/internal/services" // Sitemap store
+)
+
+// sitemap keeps /sitemap.xml between runs of the sitemap job. Like webhooks
+// it is set once at startup and notified through logActivity; when it is
+// nil every request builds the sitemap.
+var sitemap *services.Sitemap
+
+// SetSitemap sets the package-level sitemap store.
+//
+// Example:
+//
+//	admin.SetSitemap(services.NewSitemap(sitemapHandler.Build))
+func SetSitemap(s *services.Sitemap) {
+	sitemap = s
+}
+
+// refreshSitemap drops the kept sitemap after a change. Rebuilding is cheap
+// next to serving a stale sitemap, so every logged change counts, not only
+// the content types that send webhooks.
+func refreshSitemap() {
+	sitemap.Invalidate()
+}
@@ -5,6 +5,7 @@ package admin
 import (
 	// Standard library imports
 	"database/sql" // Used for nullable SQL types (NullString, NullInt64, NullBool)
+	"fmt"          // Editor path after a save that did not publish
 	"log/slog"     // Structured logging for error tracking and debugging
 	"math"         // Used for math.Ceil to calculate total pages from item count
 	"net/http"     // HTTP status codes for responses
@@ -24,7 +25,7 @@ const solutionsPerPage = 15
 // SolutionsHandler handles all HTTP requests for solutions management in the admin panel.
 // It manages CRUD operations for solutions and their related resources (stats, challenges, products, CTAs).
 type SolutionsHandler struct {
-	queries   *sqlc.Queries           // Database query interface generated by sqlc
+	queries   sqlc.Querier            // Database query interface generated by sqlc
 	logger    *slog.Logger            // Structured logger for error tracking
 	cache     *services.Cache         // Cache service for invalidating page cache after updates
 	uploadSvc *services.UploadService // Handles hero image file uploads to disk
@@ -38,7 +39,7 @@ type SolutionsHandler struct {
 //   - uploadSvc: service for persisting uploaded hero images to disk
 //
 // Returns a fully initialized SolutionsHandler ready to handle HTTP requests.
-func NewSolutionsHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache, uploadSvc *services.UploadService) *SolutionsHandler {
+func NewSolutionsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache, uploadSvc *services.UploadService) *SolutionsHandler {
 	return &SolutionsHandler{
 		queries:   queries,
 		logger:    logger,
@@ -86,7 +87,7 @@ func (h *SolutionsHandler) List(c echo.Context) error {
 		PageOffset:   offset,
 	})
 	if err != nil {
-		h.logger.Error("Failed to list solutions", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to list solutions", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load solutions")
 	}
 
@@ -96,7 +97,7 @@ func (h *SolutionsHandler) List(c echo.Context) error {
 		FilterSearch: search,
 	})
 	if err != nil {
-		h.logger.Error("Failed to count solutions", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to count solutions", "error", err)
 		total = 0 // Graceful degradation - pagination will show 1 page
 	}
 
@@ -173,17 +174,20 @@ func (h *SolutionsHandler) New(c echo.Context) error {
 //   - display_order: Sort order (integer)
 //
 // Business Logic:
-//   - Auto-generates slug from title if not provided using makeSlug()
+//   - Auto-generates a unique slug from title if not provided using uniqueSlug()
 //   - Converts checkbox/select values to appropriate SQL nullable types
-//   - Invalidates "page:solutions" cache entries after creation
+//   - Publishing runs the publish checklist; a solution that fails is saved as
+//     a draft and its edit page opens with the failed checks
+//   - Removes the cached pages listing solutions (see invalidateSolutionPages)
 //   - Logs activity for audit trail
 func (h *SolutionsHandler) Create(c echo.Context) error {
 	// Extract basic form values
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	// Auto-generate slug from title if not provided
-	if slug == "" {
-		slug = makeSlug(title)
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugSolutions, c.FormValue("slug"), title, 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to generate solution slug", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to create solution")
 	}
 
 	icon := c.FormValue("icon")
@@ -194,18 +198,30 @@ func (h *SolutionsHandler) Create(c echo.Context) error {
 	if fileHeader, err := c.FormFile("hero_image_file"); err == nil && fileHeader != nil {
 		path, uploadErr := h.uploadSvc.UploadSolutionImage(fileHeader)
 		if uploadErr != nil {
-			h.logger.Error("Failed to upload solution hero image", "error", uploadErr)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to upload solution hero image", "error", uploadErr)
 			return c.String(http.StatusBadRequest, "Failed to upload image: "+uploadErr.Error())
 		}
 		heroImageUrl = path
 	}
 	heroTitle := c.FormValue("hero_title")
 	heroDescription := c.FormValue("hero_description")
-	overviewContent := c.FormValue("overview_content")                                       // Rich text from Trix editor
+	overviewContent := sanitizeHTML(c.FormValue("overview_content"))                         // Rich text from Trix editor
 	metaDescription := c.FormValue("meta_description")                                       // SEO meta tag
 	referenceCode := c.FormValue("reference_code")                                           // Internal reference
 	isPublished := c.FormValue("is_published") == "1" || c.FormValue("is_published") == "on" // Checkbox or select
 
+	// Publishing needs the publish checklist to pass; otherwise save a draft
+	var gate publishGate
+	if isPublished {
+		var err error
+		gate, err = checkPublish(c, solutionCandidate(metaDescription, heroImageUrl, heroDescription, overviewContent))
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+			return c.String(http.StatusInternalServerError, "Failed to create solution")
+		}
+		isPublished = gate.allowed()
+	}
+
 	// Parse display order - defaults to 0 if not provided or invalid
 	displayOrder := int64(0)
 	if v := c.FormValue("display_order"); v != "" {
@@ -231,16 +247,22 @@ func (h *SolutionsHandler) Create(c echo.Context) error {
 	}
 
 	// Execute database insert
-	_, err := h.queries.CreateSolution(c.Request().Context(), params)
+	solution, err := h.queries.CreateSolution(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create solution", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create solution", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to create solution")
 	}
+	recordSlugChange(c, h.logger, "", "/solutions/"+slug)
 
 	// Invalidate cached solutions pages
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solution.ID, true)
 	// Log the creation for audit trail (uses helper function from common.go)
-	logActivity(c, "created", "solution", 0, c.FormValue("title"), "Created Solution '%s'", c.FormValue("title"))
+	logActivity(c, "created", "solution", solution.ID, c.FormValue("title"), "Created Solution '%s'", c.FormValue("title"))
+	gate.logOverride(c, "solution", solution.ID, title)
+	if !gate.allowed() {
+		// Show the failed checks on the new solution's edit page
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/solutions/%d/edit?publish=%s", solution.ID, gate.notice))
+	}
 	// Redirect to list view after successful creation
 	return c.Redirect(http.StatusSeeOther, "/admin/solutions")
 }
@@ -273,46 +295,55 @@ func (h *SolutionsHandler) Edit(c echo.Context) error {
 	// Load solution base data
 	solution, err := h.queries.GetSolutionByID(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load solution")
 	}
 
 	// Load related stats (graceful degradation on error - show empty array)
 	stats, err := h.queries.GetSolutionStats(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution stats", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution stats", "error", err)
 		stats = []sqlc.SolutionStat{}
 	}
 
 	// Load related challenges (graceful degradation on error)
 	challenges, err := h.queries.GetSolutionChallenges(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution challenges", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution challenges", "error", err)
 		challenges = []sqlc.SolutionChallenge{}
 	}
 
 	// Load linked products with join data (graceful degradation on error)
 	products, err := h.queries.GetSolutionProducts(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution products", "error", err)
 		products = []sqlc.GetSolutionProductsRow{}
 	}
 
 	// Load CTAs (call-to-action sections) - graceful degradation on error
 	ctas, err := h.queries.GetSolutionCTAs(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution CTAs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution CTAs", "error", err)
 		ctas = []sqlc.SolutionCta{}
 	}
 
+	checklist, err := publishChecklist(c, "solutions", solutionCandidate(solution.MetaDescription.String,
+		solution.HeroImageUrl.String, solution.HeroDescription.String, solution.OverviewContent.String))
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+	}
+
 	return c.Render(http.StatusOK, "admin/pages/solutions_form.html", map[string]interface{}{
-		"Title":      "Edit Solution",
-		"FormAction": "/admin/solutions/" + c.Param("id"),
-		"Item":       solution,
-		"Stats":      stats,
-		"Challenges": challenges,
-		"Products":   products,
-		"CTAs":       ctas,
+		"Title":        "Edit Solution",
+		"FormAction":   "/admin/solutions/" + c.Param("id"),
+		"Item":         solution,
+		"Stats":        stats,
+		"Challenges":   challenges,
+		"Products":     products,
+		"CTAs":         ctas,
+		"PreviewURL":   previewURL("/solutions/"+solution.Slug, services.PreviewSolution, solution.ID),
+		"Checklist":    checklist,
+		"LanguageTabs": languageTabs(c, "solution", id, ""),
 	})
 }
 
@@ -332,7 +363,8 @@ func (h *SolutionsHandler) Edit(c echo.Context) error {
 //   - Updates only the solution base record (not related resources)
 //   - Related resources (stats, challenges, products, CTAs) updated via separate endpoints
 //   - Auto-generates slug from title if not provided
-//   - Invalidates "page:solutions" cache entries
+//   - Publishing a draft runs the publish checklist, as in Create
+//   - Removes the cached solution page and the pages listing solutions
 //   - Logs activity for audit trail
 func (h *SolutionsHandler) Update(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -341,9 +373,11 @@ func (h *SolutionsHandler) Update(c echo.Context) error {
 	}
 
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title)
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugSolutions, c.FormValue("slug"), title, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to generate solution slug", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to update solution")
 	}
 
 	icon := c.FormValue("icon")
@@ -354,18 +388,35 @@ func (h *SolutionsHandler) Update(c echo.Context) error {
 	if fileHeader, err := c.FormFile("hero_image_file"); err == nil && fileHeader != nil {
 		path, uploadErr := h.uploadSvc.UploadSolutionImage(fileHeader)
 		if uploadErr != nil {
-			h.logger.Error("Failed to upload solution hero image", "error", uploadErr)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to upload solution hero image", "error", uploadErr)
 			return c.String(http.StatusBadRequest, "Failed to upload image: "+uploadErr.Error())
 		}
 		heroImageUrl = path
 	}
 	heroTitle := c.FormValue("hero_title")
 	heroDescription := c.FormValue("hero_description")
-	overviewContent := c.FormValue("overview_content")
+	overviewContent := sanitizeHTML(c.FormValue("overview_content"))
 	metaDescription := c.FormValue("meta_description")
 	referenceCode := c.FormValue("reference_code")
 	isPublished := c.FormValue("is_published") == "1" || c.FormValue("is_published") == "on"
 
+	existing, err := h.queries.GetSolutionByID(c.Request().Context(), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to update solution")
+	}
+
+	// Publishing a draft needs the publish checklist to pass; otherwise it stays a draft
+	var gate publishGate
+	if isPublished && !existing.IsPublished.Bool {
+		gate, err = checkPublish(c, solutionCandidate(metaDescription, heroImageUrl, heroDescription, overviewContent))
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+			return c.String(http.StatusInternalServerError, "Failed to update solution")
+		}
+		isPublished = gate.allowed()
+	}
+
 	displayOrder := int64(0)
 	if v := c.FormValue("display_order"); v != "" {
 		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
@@ -391,12 +442,17 @@ func (h *SolutionsHandler) Update(c echo.Context) error {
 
 	err = h.queries.UpdateSolution(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to update solution", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update solution", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update solution")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	recordSlugChange(c, h.logger, "/solutions/"+existing.Slug, "/solutions/"+slug)
+	invalidateSolutionPages(h.cache, id, true)
 	logActivity(c, "updated", "solution", id, c.FormValue("title"), "Updated Solution '%s'", c.FormValue("title"))
+	gate.logOverride(c, "solution", id, title)
+	if !gate.allowed() {
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/solutions/%d/edit?publish=%s", id, gate.notice))
+	}
 	return c.Redirect(http.StatusSeeOther, "/admin/solutions")
 }
 
@@ -411,8 +467,9 @@ func (h *SolutionsHandler) Update(c echo.Context) error {
 //   - id: Solution ID (int64)
 //
 // Business Logic:
-//   - Deletes solution and all related resources (cascade delete handled by DB)
-//   - Invalidates "page:solutions" cache entries
+//   - Moves the solution to the trash; related resources are kept until it
+//     is deleted permanently (cascade delete handled by DB)
+//   - Removes the cached solution page and the pages listing solutions
 //   - Logs activity for audit trail
 //   - Returns 204 No Content on success (HTMX removes element from DOM)
 func (h *SolutionsHandler) Delete(c echo.Context) error {
@@ -421,14 +478,14 @@ func (h *SolutionsHandler) Delete(c echo.Context) error {
 		return c.String(http.StatusBadRequest, "Invalid solution ID")
 	}
 
-	err = h.queries.DeleteSolution(c.Request().Context(), id)
+	_, err = h.queries.TrashSolution(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to delete solution", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete solution", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete solution")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
-	logActivity(c, "deleted", "solution", id, "", "Deleted Solution #%d", id)
+	invalidateSolutionPages(h.cache, id, true)
+	logActivity(c, "trashed", "solution", id, "", "Moved Solution #%d to trash", id)
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -449,7 +506,7 @@ func (h *SolutionsHandler) ChallengesTab(c echo.Context) error {
 
 	challenges, err := h.queries.GetSolutionChallenges(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution challenges", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution challenges", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load challenges")
 	}
 
@@ -478,7 +535,7 @@ func (h *SolutionsHandler) ProductsTab(c echo.Context) error {
 
 	products, err := h.queries.GetSolutionProducts(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution products", "error", err)
 		products = []sqlc.GetSolutionProductsRow{}
 	}
 
@@ -506,7 +563,7 @@ func (h *SolutionsHandler) StatsTab(c echo.Context) error {
 
 	stats, err := h.queries.GetSolutionStats(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution stats", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution stats", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load stats")
 	}
 
@@ -534,7 +591,7 @@ func (h *SolutionsHandler) CTAsTab(c echo.Context) error {
 
 	ctas, err := h.queries.GetSolutionCTAs(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get solution CTAs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution CTAs", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load CTAs")
 	}
 
@@ -590,15 +647,15 @@ func (h *SolutionsHandler) AddStat(c echo.Context) error {
 
 	_, err = h.queries.CreateSolutionStat(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create solution stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create solution stat", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to add stat")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	stats, err := h.queries.GetSolutionStats(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution stats", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution stats", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load stats")
 	}
 
@@ -631,11 +688,12 @@ func (h *SolutionsHandler) DeleteStat(c echo.Context) error {
 
 	err = h.queries.DeleteSolutionStat(c.Request().Context(), statID)
 	if err != nil {
-		h.logger.Error("Failed to delete solution stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete solution stat", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete stat")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	solutionID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	invalidateSolutionPages(h.cache, solutionID, false)
 	// Note: solutionID not available in DeleteStat, use statID as reference
 	logActivity(c, "updated", "solution", statID, "", "Updated Solution #%d sub-resources", statID)
 	return c.NoContent(http.StatusOK)
@@ -687,15 +745,15 @@ func (h *SolutionsHandler) AddChallenge(c echo.Context) error {
 
 	_, err = h.queries.CreateSolutionChallenge(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create solution challenge", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create solution challenge", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to add challenge")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	challenges, err := h.queries.GetSolutionChallenges(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution challenges", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution challenges", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load challenges")
 	}
 
@@ -728,11 +786,12 @@ func (h *SolutionsHandler) DeleteChallenge(c echo.Context) error {
 
 	err = h.queries.DeleteSolutionChallenge(c.Request().Context(), challengeID)
 	if err != nil {
-		h.logger.Error("Failed to delete solution challenge", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete solution challenge", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete challenge")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	solutionID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	invalidateSolutionPages(h.cache, solutionID, false)
 	// Note: solutionID not available in DeleteChallenge, use challengeID as reference
 	logActivity(c, "updated", "solution", challengeID, "", "Updated Solution #%d sub-resources", challengeID)
 	return c.NoContent(http.StatusOK)
@@ -789,15 +848,15 @@ func (h *SolutionsHandler) AddProduct(c echo.Context) error {
 
 	err = h.queries.AddProductToSolution(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to add product to solution", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to add product to solution", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to add product")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	products, err := h.queries.GetSolutionProducts(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution products", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load products")
 	}
 
@@ -845,11 +904,11 @@ func (h *SolutionsHandler) RemoveProduct(c echo.Context) error {
 
 	err = h.queries.RemoveProductFromSolution(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to remove product from solution", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to remove product from solution", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to remove product")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 	logActivity(c, "updated", "solution", solutionID, "", "Updated Solution #%d sub-resources", solutionID)
 	return c.NoContent(http.StatusOK)
 }
@@ -910,15 +969,15 @@ func (h *SolutionsHandler) AddCTA(c echo.Context) error {
 
 	_, err = h.queries.CreateSolutionCTA(c.Request().Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to create solution CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create solution CTA", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to add CTA")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	ctas, err := h.queries.GetSolutionCTAs(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution CTAs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution CTAs", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load CTAs")
 	}
 
@@ -951,11 +1010,12 @@ func (h *SolutionsHandler) DeleteCTA(c echo.Context) error {
 
 	err = h.queries.DeleteSolutionCTA(c.Request().Context(), ctaID)
 	if err != nil {
-		h.logger.Error("Failed to delete solution CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete solution CTA", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete CTA")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	solutionID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	invalidateSolutionPages(h.cache, solutionID, false)
 	// Note: solutionID not available in DeleteCTA, use ctaID as reference
 	logActivity(c, "updated", "solution", ctaID, "", "Updated Solution #%d sub-resources", ctaID)
 	return c.NoContent(http.StatusOK)
@@ -997,15 +1057,15 @@ func (h *SolutionsHandler) UpdateChallenge(c echo.Context) error {
 		ID:           challengeID,
 	})
 	if err != nil {
-		h.logger.Error("Failed to update solution challenge", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update solution challenge", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update challenge")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	challenges, err := h.queries.GetSolutionChallenges(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution challenges", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution challenges", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load challenges")
 	}
 
@@ -1049,15 +1109,15 @@ func (h *SolutionsHandler) UpdateStat(c echo.Context) error {
 		ID:           statID,
 	})
 	if err != nil {
-		h.logger.Error("Failed to update solution stat", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update solution stat", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update stat")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	stats, err := h.queries.GetSolutionStats(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution stats", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution stats", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load stats")
 	}
 
@@ -1107,15 +1167,15 @@ func (h *SolutionsHandler) UpdateCTA(c echo.Context) error {
 		ID:                  ctaID,
 	})
 	if err != nil {
-		h.logger.Error("Failed to update solution CTA", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update solution CTA", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update CTA")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	ctas, err := h.queries.GetSolutionCTAs(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution CTAs", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution CTAs", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load CTAs")
 	}
 
@@ -1162,15 +1222,15 @@ func (h *SolutionsHandler) UpdateProduct(c echo.Context) error {
 		ProductID:    productID,
 	})
 	if err != nil {
-		h.logger.Error("Failed to update solution product", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update solution product", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to update product")
 	}
 
-	h.cache.DeleteByPrefix("page:solutions")
+	invalidateSolutionPages(h.cache, solutionID, false)
 
 	products, err := h.queries.GetSolutionProducts(c.Request().Context(), solutionID)
 	if err != nil {
-		h.logger.Error("Failed to get solution products", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get solution products", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load products")
 	}
 
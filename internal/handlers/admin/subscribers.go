@@ -0,0 +1,128 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains handlers for the newsletter subscribers view.
+package admin
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging for error tracking
+	"math"         // Page count calculation
+	"net/http"     // HTTP status codes and error responses
+	"slices"       // Status filter validation
+	"strconv"      // Page and subscriber ID parsing
+	"strings"      // Trimming the search
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Newsletter statuses and provider unsubscribe
+)
+
+// subscribersPerPage is the page size of the Subscribers list.
+const subscribersPerPage = 50
+
+// SubscribersHandler lists the addresses signed up with the footer
+// newsletter form and removes them on request. The export button hands the
+// current filters to the background exports (see ExportsHandler).
+type SubscribersHandler struct {
+	queries    sqlc.Querier         // Database query interface for subscribers
+	newsletter *services.Newsletter // Deletes subscribers, unsubscribing them at the provider
+	logger     *slog.Logger         // Structured logger for error tracking
+}
+
+// NewSubscribersHandler constructs a new SubscribersHandler with required dependencies.
+func NewSubscribersHandler(queries sqlc.Querier, newsletter *services.Newsletter, logger *slog.Logger) *SubscribersHandler {
+	return &SubscribersHandler{queries: queries, newsletter: newsletter, logger: logger}
+}
+
+// List handles GET /admin/subscribers
+// Renders newsletter subscribers, newest first, 50 per page, with the
+// number of addresses in each status.
+// Template: admin/pages/subscribers_list.html (full page)
+//
+// Query parameters:
+//   - status: pending, confirmed or unsubscribed (default: all)
+//   - q: Part of the address
+//   - page: Page number (default 1)
+func (h *SubscribersHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+	status := c.QueryParam("status")
+	if !slices.Contains(services.NewsletterStatuses, status) {
+		status = ""
+	}
+	search := strings.TrimSpace(c.QueryParam("q"))
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	subscribers, err := h.queries.ListNewsletterSubscribers(ctx, sqlc.ListNewsletterSubscribersParams{
+		FilterStatus: status,
+		FilterSearch: search,
+		PageLimit:    subscribersPerPage,
+		PageOffset:   int64(page-1) * subscribersPerPage,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load newsletter subscribers", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	total, err := h.queries.CountNewsletterSubscribers(ctx, sqlc.CountNewsletterSubscribersParams{FilterStatus: status, FilterSearch: search})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count newsletter subscribers", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	byStatus, err := h.queries.CountNewsletterSubscribersByStatus(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count newsletter subscribers by status", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	counts := map[string]int64{}
+	for _, row := range byStatus {
+		counts[row.Status] = row.Count
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(subscribersPerPage)))
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	var pages []int
+	for i := 1; i <= totalPages; i++ {
+		pages = append(pages, i)
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/subscribers_list.html", map[string]interface{}{
+		"Title":       "Subscribers",
+		"Subscribers": subscribers,
+		"Counts":      counts,
+		"Statuses":    services.NewsletterStatuses,
+		"Status":      status,
+		"Search":      search,
+		"Total":       total,
+		"Page":        page,
+		"TotalPages":  totalPages,
+		"Pages":       pages,
+	})
+}
+
+// Delete handles DELETE /admin/subscribers/:id
+// Removes a subscriber entirely, e.g. on an erasure request. Confirmed
+// addresses are unsubscribed at the mailing list provider first.
+func (h *SubscribersHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid subscriber ID")
+	}
+	sub, err := h.newsletter.Delete(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.String(http.StatusNotFound, "Subscriber not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete newsletter subscriber", "error", err, "id", id)
+		return c.String(http.StatusInternalServerError, "Failed to delete subscriber")
+	}
+	logActivity(c, "deleted", "newsletter_subscriber", id, sub.Email, "Deleted Newsletter Subscriber #%d", id)
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,39 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file runs handlers that write several rows or tables in one
+// transaction.
+package admin
+
+import (
+	"context" // Transaction lifetime
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/database" // Transactor interface
+)
+
+// transactor opens the transactions of withTx. Like reportQueries it is set
+// once at startup; when it is nil (handler unit tests with a mock Querier)
+// the writes run one by one on the handler's queries, as before.
+var transactor database.Transactor
+
+// SetTransactor sets what handlers writing several tables use for their
+// transactions. It must open them on the connection behind the handlers'
+// queries.
+//
+// Example:
+//
+//	admin.SetTransactor(database.NewTransactor(db))
+func SetTransactor(t database.Transactor) {
+	transactor = t
+}
+
+// withTx runs fn with queries bound to one transaction, so that when one of
+// its writes fails none are kept: a whitepaper is not left without its
+// learning points, nor the footer without its links. fn must only use the
+// Querier it is given (see database.WithTx); activity logging, slug redirects
+// and the workflow write through their own queries and belong after it.
+func withTx(ctx context.Context, queries sqlc.Querier, fn func(sqlc.Querier) error) error {
+	if transactor == nil {
+		return fn(queries)
+	}
+	return transactor.WithTx(ctx, fn)
+}
@@ -0,0 +1,363 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains handlers for the content localization workflow.
+package admin
+
+import (
+	// Standard library imports
+	"context"      // Request context for settings lookups
+	"database/sql" // sql.ErrNoRows detection for unknown entities
+	"errors"       // Error inspection
+	"fmt"          // Edit form URLs for the language tabs
+	"log/slog"     // Structured logging for error tracking
+	"net/http"     // HTTP status codes and error responses
+	"net/url"      // Building dashboard redirect query strings
+	"strconv"      // String to integer conversions for entity IDs
+	"strings"      // Deriving the editor URL from the machine-translate path
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries via sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Translation service and cache invalidation
+)
+
+// translationCachePrefixes maps translatable entity types to the public page
+// cache prefix that must be invalidated when one of their translations changes.
+var translationCachePrefixes = map[string]string{
+	"product":   "page:products",
+	"blog_post": "page:blog",
+	"solution":  "page:solutions",
+	// Page sections appear on the home, product and solution pages alike
+	"page_section": "page:",
+}
+
+// translationSourceForms are the edit forms of each translatable type, which
+// the language tabs link back to as the source-language tab.
+var translationSourceForms = map[string]string{
+	"product":      "/admin/products/%d/edit",
+	"blog_post":    "/admin/blog/posts/%d/edit",
+	"solution":     "/admin/solutions/%d/edit",
+	"page_section": "/admin/page-sections/%d/edit",
+}
+
+// translationService backs the language tabs on edit forms. Like
+// publishChecker it is set once at startup so the edit handlers need no
+// extra constructor argument; when it is nil the tabs are hidden.
+var translationService *services.TranslationService
+
+// SetTranslationService sets the package-level translation service.
+//
+// Example:
+//
+//	admin.SetTranslationService(translationSvc)
+func SetTranslationService(svc *services.TranslationService) {
+	translationService = svc
+}
+
+// languageTab is one tab of partials/language-tabs.html.
+type languageTab struct {
+	Code   string // Locale code
+	Name   string // Language name
+	URL    string // Source edit form or translation editor
+	Status string // Translation status; empty on the source tab
+	Active bool   // The tab being edited
+}
+
+// languageTabs returns the language tabs of an entity's edit form: the
+// source-language form first, then the translation editor of every target
+// locale with its translation status. active is the locale being edited ("" for
+// the source form). It returns nil, hiding the tabs, when no target locale is
+// enabled or the statuses cannot be loaded.
+func languageTabs(c echo.Context, entityType string, entityID int64, active string) []languageTab {
+	if translationService == nil || len(translationService.Locales()) == 0 {
+		return nil
+	}
+	statuses, err := translationService.LocaleStatuses(c.Request().Context(), entityType, entityID)
+	if err != nil {
+		return nil
+	}
+	var tabs []languageTab
+	for _, l := range translationService.Languages() {
+		tab := languageTab{Code: l.Code, Name: l.Name, Active: l.Code == active || (l.Default && active == "")}
+		if l.Default {
+			tab.URL = fmt.Sprintf(translationSourceForms[entityType], entityID)
+		} else {
+			tab.URL = fmt.Sprintf("/admin/translations/%s/%d/%s", entityType, entityID, l.Code)
+			tab.Status = statuses[l.Code]
+		}
+		tabs = append(tabs, tab)
+	}
+	return tabs
+}
+
+// translationRichTextFields lists the translatable fields edited as HTML and
+// rendered with safeHTML, which are sanitized on save like their sources.
+var translationRichTextFields = map[string]bool{
+	"body":             true,
+	"overview_content": true,
+}
+
+// TranslationsHandler manages the translation dashboard and per-entity
+// translation editor, and the site locales. Translations overlay products, blog
+// posts, solutions, and page sections on public pages for each enabled target
+// locale.
+type TranslationsHandler struct {
+	queries      sqlc.Querier                 // Database query interface generated by sqlc
+	logger       *slog.Logger                 // Structured logger for error tracking
+	translations *services.TranslationService // Translation records, status, and coverage
+	cache        *services.Cache              // Cache service for invalidating translated pages
+}
+
+// NewTranslationsHandler constructs a new TranslationsHandler with required dependencies.
+func NewTranslationsHandler(queries sqlc.Querier, logger *slog.Logger, translations *services.TranslationService, cache *services.Cache) *TranslationsHandler {
+	return &TranslationsHandler{queries: queries, logger: logger, translations: translations, cache: cache}
+}
+
+// Dashboard handles GET /admin/translations
+// Renders coverage per locale and entity type, followed by the translation
+// status of every entity of the selected type in the selected locale.
+// Template: admin/pages/translations_dashboard.html (full page)
+//
+// Query parameters:
+//   - locale: Target locale to list (defaults to the first target locale)
+//   - type: Entity type to list (defaults to the first translatable type)
+func (h *TranslationsHandler) Dashboard(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	coverage, err := h.translations.Coverage(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to compute translation coverage", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	locales := h.translations.Locales()
+	locale := c.QueryParam("locale")
+	if !h.translations.IsTargetLocale(locale) && len(locales) > 0 {
+		locale = locales[0]
+	}
+	entityType, ok := services.LookupTranslatableType(c.QueryParam("type"))
+	if !ok {
+		entityType = services.TranslatableTypes[0]
+	}
+
+	// Per-entity listing is only available once at least one target locale is configured
+	var items []services.EntityTranslation
+	if locale != "" {
+		items, err = h.translations.Statuses(ctx, entityType.Key, locale)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to list translation statuses", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/translations_dashboard.html", map[string]interface{}{
+		"Title":         "Translations",
+		"DefaultLocale": h.translations.DefaultLocale(),
+		"Locales":       locales,
+		"Coverage":      coverage,
+		"Types":         services.TranslatableTypes,
+		"Locale":        locale,
+		"Type":          entityType,
+		"Items":         items,
+		"MachineAssist": h.machineTranslator(ctx) != nil, // Show "Machine Translate" actions
+	})
+}
+
+// machineTranslator returns the machine-translation provider configured in
+// Global Settings, or nil when the integration is disabled or settings cannot
+// be loaded.
+func (h *TranslationsHandler) machineTranslator(ctx context.Context) services.MachineTranslator {
+	settings, err := h.queries.GetSettings(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load settings", "error", err)
+		return nil
+	}
+	return services.NewMachineTranslator(settings.MtProvider, settings.MtApiKey)
+}
+
+// loadSource resolves the :type and :id route parameters to the entity's
+// current source content, returning a 404 HTTP error when either is unknown.
+func (h *TranslationsHandler) loadSource(c echo.Context) (*services.TranslationSource, error) {
+	if _, ok := services.LookupTranslatableType(c.Param("type")); !ok {
+		return nil, echo.NewHTTPError(http.StatusNotFound)
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if !h.translations.IsTargetLocale(c.Param("locale")) {
+		return nil, echo.NewHTTPError(http.StatusNotFound)
+	}
+
+	src, err := h.translations.Source(c.Request().Context(), c.Param("type"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load translation source", "error", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return src, nil
+}
+
+// Edit handles GET /admin/translations/:type/:id/:locale
+// Renders the translation editor with each source field shown next to its
+// translated counterpart.
+// Template: admin/pages/translations_form.html (full page)
+func (h *TranslationsHandler) Edit(c echo.Context) error {
+	src, err := h.loadSource(c)
+	if err != nil {
+		return err
+	}
+	locale := c.Param("locale")
+
+	tr, err := h.translations.Get(c.Request().Context(), src, locale)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load translation", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	t, _ := services.LookupTranslatableType(src.EntityType)
+	return c.Render(http.StatusOK, "admin/pages/translations_form.html", map[string]interface{}{
+		"Title":         "Translate " + src.Label,
+		"Type":          t,
+		"Source":        src,
+		"Translation":   tr,
+		"Locale":        locale,
+		"DefaultLocale": h.translations.DefaultLocale(),
+		"FormAction":    c.Request().URL.Path,
+		"MachineAssist": h.machineTranslator(c.Request().Context()) != nil,
+		"LanguageTabs":  languageTabs(c, src.EntityType, src.EntityID, locale),
+	})
+}
+
+// Save handles POST /admin/translations/:type/:id/:locale
+// Stores the submitted translated fields. The status becomes "complete" when
+// every non-empty source field is translated and "missing" otherwise; saving a
+// machine draft this way is how an editor approves it.
+// On success: redirects to the dashboard filtered to the entity's type and locale.
+func (h *TranslationsHandler) Save(c echo.Context) error {
+	src, err := h.loadSource(c)
+	if err != nil {
+		return err
+	}
+	locale := c.Param("locale")
+
+	t, _ := services.LookupTranslatableType(src.EntityType)
+	fields := make(map[string]string, len(t.Fields))
+	for _, f := range t.Fields {
+		fields[f] = c.FormValue(f)
+		if translationRichTextFields[f] {
+			fields[f] = sanitizeHTML(fields[f])
+		}
+	}
+
+	tr, err := h.translations.Save(c.Request().Context(), src, locale, fields)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to save translation", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// Translated pages are cached per locale under the entity's page prefix
+	h.cache.DeleteByPrefix(translationCachePrefixes[src.EntityType])
+
+	logActivity(c, "updated", "translation", src.EntityID, src.Label, "Updated %s translation of %s '%s' (%s)", locale, src.EntityType, src.Label, tr.Status)
+
+	q := url.Values{"type": {src.EntityType}, "locale": {locale}}
+	return c.Redirect(http.StatusSeeOther, "/admin/translations?"+q.Encode())
+}
+
+// MachineTranslate handles POST /admin/translations/:type/:id/:locale/machine
+// Pre-fills the untranslated fields of an entity using the machine-translation
+// provider configured in Global Settings. The draft is stored with status
+// "machine" and is not shown on public pages until an editor saves it.
+// On success: redirects to the translation editor for review.
+// Returns 400 when machine translation is not configured or there is nothing
+// left to translate, and 502 when the provider call fails.
+func (h *TranslationsHandler) MachineTranslate(c echo.Context) error {
+	src, err := h.loadSource(c)
+	if err != nil {
+		return err
+	}
+	locale := c.Param("locale")
+	ctx := c.Request().Context()
+
+	mt := h.machineTranslator(ctx)
+	if mt == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Machine translation is not configured")
+	}
+
+	if _, err := h.translations.MachineDraft(ctx, mt, src, locale); err != nil {
+		if errors.Is(err, services.ErrNothingToTranslate) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Every field is already translated")
+		}
+		h.logger.ErrorContext(ctx, "machine translation failed", "error", err, "entity", src.EntityType, "id", src.EntityID, "locale", locale)
+		return echo.NewHTTPError(http.StatusBadGateway, "Machine translation failed")
+	}
+
+	logActivity(c, "updated", "translation", src.EntityID, src.Label, "Machine-translated %s draft of %s '%s'", locale, src.EntityType, src.Label)
+	return c.Redirect(http.StatusSeeOther, strings.TrimSuffix(c.Request().URL.Path, "/machine"))
+}
+
+// Languages handles GET /admin/languages
+// Lists the site locales with forms to add, rename, reorder, enable and
+// disable them.
+// Template: admin/pages/languages.html (full page)
+func (h *TranslationsHandler) Languages(c echo.Context) error {
+	locales, err := h.translations.AllLocales(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list locales", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/languages.html", map[string]interface{}{
+		"Title":   "Languages",
+		"Locales": locales,
+		"Error":   c.QueryParam("error"),
+	})
+}
+
+// AddLanguage handles POST /admin/languages
+// Adds an enabled locale from the code and name form fields. Public pages are
+// served under /<code>/ and the locale is offered in the language switcher
+// straight away, falling back to source content until translations exist.
+// On success: redirects to the languages page.
+func (h *TranslationsHandler) AddLanguage(c echo.Context) error {
+	err := h.translations.AddLocale(c.Request().Context(), c.FormValue("code"), c.FormValue("name"))
+	if errors.Is(err, services.ErrInvalidLocale) {
+		return c.Redirect(http.StatusSeeOther, "/admin/languages?error="+url.QueryEscape("Use a two-letter language code, optionally with a region (e.g. de or pt-br)."))
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to add locale", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	// The switcher on cached pages lists the locales
+	h.cache.DeleteByPrefix("page:")
+	logActivity(c, "created", "locale", 0, c.FormValue("code"), "Added language '%s'", c.FormValue("code"))
+	return c.Redirect(http.StatusSeeOther, "/admin/languages")
+}
+
+// UpdateLanguage handles POST /admin/languages/:code
+// Saves a locale's name, sort order and enabled flag. Disabling a locale
+// hides it from visitors and translators but keeps its translations.
+// On success: redirects to the languages page.
+func (h *TranslationsHandler) UpdateLanguage(c echo.Context) error {
+	code := c.Param("code")
+	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
+	err := h.translations.UpdateLocale(c.Request().Context(), code, c.FormValue("name"), c.FormValue("is_enabled") == "1", sortOrder)
+	if errors.Is(err, services.ErrDefaultLocale) {
+		return c.Redirect(http.StatusSeeOther, "/admin/languages?error="+url.QueryEscape("The default language cannot be disabled."))
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update locale", "error", err, "code", code)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	h.cache.DeleteByPrefix("page:")
+	logActivity(c, "updated", "locale", 0, code, "Updated language '%s'", code)
+	return c.Redirect(http.StatusSeeOther, "/admin/languages")
+}
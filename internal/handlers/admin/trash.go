@@ -0,0 +1,190 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the trash page for soft-deleted content: listing
+// trashed items per type, restoring them, and deleting them permanently.
+package admin
+
+import (
+	// Standard library imports
+	"context"      // Query function signatures
+	"database/sql" // NullTime for deletion timestamps
+	"log/slog"     // Structured logging for error tracking
+	"net/http"     // HTTP status codes and error responses
+	"strconv"      // Item ID parsing from the URL
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page cache and trash purge settings
+)
+
+// TrashHandler manages soft-deleted products, blog posts, solutions, and
+// case studies. Deleting one of those in the admin only moves it here.
+type TrashHandler struct {
+	queries sqlc.Querier         // Database query interface for the content tables
+	logger  *slog.Logger         // Structured logger for error tracking
+	cache   *services.Cache      // Page cache, cleared when an item is restored
+	purge   *services.TrashPurge // Automatic purge job; nil when not configured
+}
+
+// NewTrashHandler constructs a new TrashHandler with required dependencies.
+func NewTrashHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache, purge *services.TrashPurge) *TrashHandler {
+	return &TrashHandler{queries: queries, logger: logger, cache: cache, purge: purge}
+}
+
+// trashItem is one row of the trash page.
+type trashItem struct {
+	ID        int64
+	Title     string
+	Slug      string
+	DeletedAt sql.NullTime
+}
+
+// trashSection groups the trashed items of one content type.
+type trashSection struct {
+	Type  string      // URL segment and activity resource type, e.g. "blog_post"
+	Label string      // Heading, e.g. "Blog Posts"
+	Items []trashItem // Most recently deleted first
+}
+
+// trashType describes how one content type is restored, deleted, and listed.
+type trashType struct {
+	label       string
+	cachePrefix string
+	restore     func(context.Context, int64) (int64, error)
+	destroy     func(context.Context, int64) (int64, error)
+	list        func(context.Context) ([]trashItem, error)
+}
+
+// types returns the supported content types keyed by URL segment, and their
+// display order on the trash page.
+func (h *TrashHandler) types() (map[string]trashType, []string) {
+	q := h.queries
+	return map[string]trashType{
+		"product": {"Products", "page:products", q.RestoreProduct, q.DeleteTrashedProduct, func(ctx context.Context) ([]trashItem, error) {
+			rows, err := q.ListTrashedProducts(ctx)
+			items := make([]trashItem, len(rows))
+			for i, r := range rows {
+				items[i] = trashItem{r.ID, r.Title, r.Slug, r.DeletedAt}
+			}
+			return items, err
+		}},
+		"blog_post": {"Blog Posts", "page:blog", q.RestoreBlogPost, q.DeleteTrashedBlogPost, func(ctx context.Context) ([]trashItem, error) {
+			rows, err := q.ListTrashedBlogPosts(ctx)
+			items := make([]trashItem, len(rows))
+			for i, r := range rows {
+				items[i] = trashItem{r.ID, r.Title, r.Slug, r.DeletedAt}
+			}
+			return items, err
+		}},
+		"solution": {"Solutions", "page:solutions", q.RestoreSolution, q.DeleteTrashedSolution, func(ctx context.Context) ([]trashItem, error) {
+			rows, err := q.ListTrashedSolutions(ctx)
+			items := make([]trashItem, len(rows))
+			for i, r := range rows {
+				items[i] = trashItem{r.ID, r.Title, r.Slug, r.DeletedAt}
+			}
+			return items, err
+		}},
+		"case_study": {"Case Studies", "page:case-studies", q.RestoreCaseStudy, q.DeleteTrashedCaseStudy, func(ctx context.Context) ([]trashItem, error) {
+			rows, err := q.ListTrashedCaseStudies(ctx)
+			items := make([]trashItem, len(rows))
+			for i, r := range rows {
+				items[i] = trashItem{r.ID, r.Title, r.Slug, r.DeletedAt}
+			}
+			return items, err
+		}},
+	}, []string{"product", "blog_post", "solution", "case_study"}
+}
+
+// List handles GET /admin/trash
+// Renders trashed items grouped by content type.
+// Template: admin/pages/trash.html (full page)
+//
+// Query parameters:
+//   - restored / deleted: Flash flags set by Restore and Delete redirects
+func (h *TrashHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+	types, order := h.types()
+
+	sections := make([]trashSection, 0, len(order))
+	total := 0
+	for _, key := range order {
+		items, err := types[key].list(ctx)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to list trash", "type", key, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		total += len(items)
+		sections = append(sections, trashSection{Type: key, Label: types[key].label, Items: items})
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/trash.html", map[string]interface{}{
+		"Title":         "Trash",
+		"Sections":      sections,
+		"Total":         total,
+		"RetentionDays": h.purge.Days(),
+		"Restored":      c.QueryParam("restored") == "1",
+		"Deleted":       c.QueryParam("deleted") == "1",
+	})
+}
+
+// Restore handles POST /admin/trash/:type/:id/restore
+// Takes an item out of the trash. It keeps its previous status, so a
+// published item is live again immediately.
+func (h *TrashHandler) Restore(c echo.Context) error {
+	kind, t, id, err := h.target(c)
+	if err != nil {
+		return err
+	}
+
+	n, err := t.restore(c.Request().Context(), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to restore item", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Item is not in the trash")
+	}
+
+	h.cache.DeleteByPrefix(t.cachePrefix)
+	logActivity(c, "restored", kind, id, "", "Restored %s #%d from trash", kind, id)
+	return c.Redirect(http.StatusSeeOther, "/admin/trash?restored=1")
+}
+
+// Delete handles POST /admin/trash/:type/:id/delete
+// Permanently deletes a trashed item and, through ON DELETE CASCADE, its
+// child rows. Items that are not in the trash return 404.
+func (h *TrashHandler) Delete(c echo.Context) error {
+	kind, t, id, err := h.target(c)
+	if err != nil {
+		return err
+	}
+
+	n, err := t.destroy(c.Request().Context(), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete item", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Item is not in the trash")
+	}
+
+	logActivity(c, "deleted", kind, id, "", "Permanently deleted %s #%d", kind, id)
+	return c.Redirect(http.StatusSeeOther, "/admin/trash?deleted=1")
+}
+
+// target resolves the :type and :id route parameters.
+func (h *TrashHandler) target(c echo.Context) (string, trashType, int64, error) {
+	types, _ := h.types()
+	kind := c.Param("type")
+	t, ok := types[kind]
+	if !ok {
+		return "", trashType{}, 0, echo.NewHTTPError(http.StatusNotFound, "Unknown content type")
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return "", trashType{}, 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	return kind, t, id, nil
+}
@@ -0,0 +1,194 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the webhook manager and the hook that sends content
+// events to the registered endpoints whenever public content changes.
+package admin
+
+import (
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing IDs
+	"strings"      // Joining the chosen events
+
+	"github.com/labstack/echo/v4" // Web framework
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Flash messages
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Webhook delivery
+)
+
+// webhooks sends content events to the endpoints under Admin > Webhooks.
+// Like redirects it is set once at startup so every handler that logs
+// activity notifies it through logActivity; when it is nil nothing is sent.
+var webhooks *services.Webhooks
+
+// SetWebhooks sets the package-level webhook service.
+//
+// Example:
+//
+//	admin.SetWebhooks(services.NewWebhooks(queries, logger))
+func SetWebhooks(svc *services.Webhooks) {
+	webhooks = svc
+}
+
+// publishChangeKey is the context key recordFormStatus sets to the status
+// ("published" or "draft") an edit form moved an item to, so the activity
+// logged after the save sends content.published rather than content.updated.
+const publishChangeKey = "webhook_publish_change"
+
+// notifyWebhooks queues the webhook event for an activity log entry, if it
+// is about public content (see services.ContentWebhookEvent). A save that
+// published or unpublished the item from its edit form is reported as such.
+func notifyWebhooks(c echo.Context, action, resourceType string, resourceID int64, resourceTitle string) {
+	if webhooks == nil {
+		return
+	}
+	if status, ok := c.Get(publishChangeKey).(string); ok && (action == "created" || action == "updated") {
+		action = "unpublished"
+		if status == services.WorkflowPublished {
+			action = "published"
+		}
+	}
+	event, ok := services.ContentWebhookEvent(action, resourceType)
+	if !ok {
+		return
+	}
+	webhooks.Emit(services.WebhookEvent{
+		Type:    event,
+		Action:  action,
+		Content: &services.WebhookContent{Type: resourceType, ID: resourceID, Title: resourceTitle},
+	})
+}
+
+// WebhooksHandler handles the webhook manager at /admin/webhooks.
+type WebhooksHandler struct {
+	queries  sqlc.Querier       // Database queries generated by sqlc
+	logger   *slog.Logger       // Structured logger for error reporting
+	webhooks *services.Webhooks // Sends the test ping
+}
+
+// NewWebhooksHandler creates a new WebhooksHandler instance.
+func NewWebhooksHandler(queries sqlc.Querier, logger *slog.Logger, webhooks *services.Webhooks) *WebhooksHandler {
+	return &WebhooksHandler{queries: queries, logger: logger, webhooks: webhooks}
+}
+
+// List handles GET /admin/webhooks
+// Lists the endpoints with their secrets and last delivery, with a form to
+// add one.
+// Template: admin/pages/webhooks.html (full page)
+func (h *WebhooksHandler) List(c echo.Context) error {
+	hooks, err := h.queries.ListWebhooks(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list webhooks", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Render(http.StatusOK, "admin/pages/webhooks.html", map[string]interface{}{
+		"Title":      "Webhooks",
+		"Webhooks":   hooks,
+		"EventTypes": services.WebhookEventTypes,
+	})
+}
+
+// Create handles POST /admin/webhooks
+// Adds an endpoint for the url form field, subscribed to the checked
+// events (none checked subscribes to all), with a new signing secret.
+func (h *WebhooksHandler) Create(c echo.Context) error {
+	target, err := services.ValidateWebhookURL(c.FormValue("url"))
+	if err != nil {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, "Enter a full http:// or https:// URL for the webhook.")
+		return c.Redirect(http.StatusSeeOther, "/admin/webhooks")
+	}
+	form, _ := c.FormParams()
+	var events []string
+	for _, e := range services.WebhookEventTypes {
+		for _, chosen := range form["events"] {
+			if chosen == e {
+				events = append(events, e)
+			}
+		}
+	}
+	if len(events) == len(services.WebhookEventTypes) {
+		events = nil
+	}
+
+	hook, err := h.queries.CreateWebhook(c.Request().Context(), sqlc.CreateWebhookParams{
+		Url:    target,
+		Secret: services.NewWebhookSecret(),
+		Events: strings.Join(events, ","),
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to create webhook", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "created", "webhook", hook.ID, target, "Added webhook %s", target)
+	customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Webhook added. Use its secret to verify the X-Bluejay-Signature header.")
+	return c.Redirect(http.StatusSeeOther, "/admin/webhooks")
+}
+
+// Toggle handles POST /admin/webhooks/:id/toggle
+// Pauses an active endpoint or resumes a paused one.
+func (h *WebhooksHandler) Toggle(c echo.Context) error {
+	hook, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	if err := h.queries.SetWebhookActive(c.Request().Context(), sqlc.SetWebhookActiveParams{IsActive: !hook.IsActive, ID: hook.ID}); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to update webhook", "error", err, "id", hook.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if hook.IsActive {
+		logActivity(c, "disabled", "webhook", hook.ID, hook.Url, "Paused webhook %s", hook.Url)
+	} else {
+		logActivity(c, "enabled", "webhook", hook.ID, hook.Url, "Resumed webhook %s", hook.Url)
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/webhooks")
+}
+
+// Test handles POST /admin/webhooks/:id/test
+// Sends a ping event to the endpoint right away and reports the outcome.
+func (h *WebhooksHandler) Test(c echo.Context) error {
+	hook, err := h.load(c)
+	if err != nil {
+		return err
+	}
+	if err := h.webhooks.Ping(c.Request().Context(), hook); err != nil {
+		customMiddleware.AddFlash(c, customMiddleware.FlashError, "Test delivery to "+hook.Url+" failed: "+err.Error())
+	} else {
+		customMiddleware.AddFlash(c, customMiddleware.FlashSuccess, "Test delivery to "+hook.Url+" succeeded.")
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/webhooks")
+}
+
+// Delete handles DELETE /admin/webhooks/:id
+// Removes an endpoint; events already being delivered to it still go out.
+// HTMX: returns an empty 200 response and the row is removed.
+func (h *WebhooksHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	if err := h.queries.DeleteWebhook(c.Request().Context(), id); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete webhook", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "deleted", "webhook", id, "", "Deleted webhook #%d", id)
+	return c.NoContent(http.StatusOK)
+}
+
+// load returns the endpoint named by :id.
+func (h *WebhooksHandler) load(c echo.Context) (sqlc.Webhook, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return sqlc.Webhook{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+	hook, err := h.queries.GetWebhook(c.Request().Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return hook, echo.NewHTTPError(http.StatusNotFound)
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load webhook", "error", err, "id", id)
+		return hook, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return hook, nil
+}
@@ -13,14 +13,15 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP routing and context handling
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated SQL queries from sqlc
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated SQL queries from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/services" // Unique slug generation
 )
 
 // WhitepaperTopicsHandler handles all HTTP requests for whitepaper topics management in the admin panel.
 // It manages CRUD operations for topics used to categorize and filter whitepapers.
 type WhitepaperTopicsHandler struct {
-	queries *sqlc.Queries // Database query interface generated by sqlc
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface generated by sqlc
+	logger  *slog.Logger // Structured logger for error tracking
 	// Note: No cache service - topic changes are less frequent and don't require cache invalidation
 }
 
@@ -30,7 +31,7 @@ type WhitepaperTopicsHandler struct {
 //   - logger: structured logger for error logging
 //
 // Returns a fully initialized WhitepaperTopicsHandler ready to handle HTTP requests.
-func NewWhitepaperTopicsHandler(queries *sqlc.Queries, logger *slog.Logger) *WhitepaperTopicsHandler {
+func NewWhitepaperTopicsHandler(queries sqlc.Querier, logger *slog.Logger) *WhitepaperTopicsHandler {
 	return &WhitepaperTopicsHandler{queries: queries, logger: logger}
 }
 
@@ -46,7 +47,7 @@ func NewWhitepaperTopicsHandler(queries *sqlc.Queries, logger *slog.Logger) *Whi
 func (h *WhitepaperTopicsHandler) List(c echo.Context) error {
 	items, err := h.queries.ListWhitepaperTopicsWithCount(c.Request().Context())
 	if err != nil {
-		h.logger.Error("failed to list whitepaper topics", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to list whitepaper topics", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	return c.Render(http.StatusOK, "admin/pages/whitepaper_topics_list.html", map[string]interface{}{
@@ -81,28 +82,34 @@ func (h *WhitepaperTopicsHandler) New(c echo.Context) error {
 //
 // Form Fields:
 //   - name: Topic name (required)
-//   - slug: URL slug (auto-generated from name using makeSlug)
+//   - slug: URL slug (auto-generated from name using uniqueSlug)
 //   - color_hex: Hex color code for topic badge/tag display (e.g., "#FF5733")
 //   - icon: Icon identifier or class name for topic visual
 //   - description: Optional description of the topic
 //   - sort_order: Display order for topic sorting (integer)
 //
 // Business Logic:
-//   - Auto-generates slug from name (using makeSlug helper from common.go)
+//   - Auto-generates slug from name (using uniqueSlug)
 //   - Logs activity for audit trail
 func (h *WhitepaperTopicsHandler) Create(c echo.Context) error {
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 	desc := c.FormValue("description")
-	_, err := h.queries.CreateWhitepaperTopic(c.Request().Context(), sqlc.CreateWhitepaperTopicParams{
+	slug, err := uniqueSlug(c, h.queries, services.SlugWhitepaperTopics, "", c.FormValue("name"), 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate whitepaper topic slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	_, err = h.queries.CreateWhitepaperTopic(c.Request().Context(), sqlc.CreateWhitepaperTopicParams{
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),
+		Slug:        slug,
 		ColorHex:    c.FormValue("color_hex"),
 		Icon:        c.FormValue("icon"),
 		Description: sql.NullString{String: desc, Valid: desc != ""},
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to create whitepaper topic", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to create whitepaper topic", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "created", "whitepaper_topic", 0, c.FormValue("name"), "Created Whitepaper Topic '%s'", c.FormValue("name"))
@@ -153,17 +160,23 @@ func (h *WhitepaperTopicsHandler) Update(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	sortOrder, _ := strconv.ParseInt(c.FormValue("sort_order"), 10, 64)
 	desc := c.FormValue("description")
-	_, err := h.queries.UpdateWhitepaperTopic(c.Request().Context(), sqlc.UpdateWhitepaperTopicParams{
+	slug, err := uniqueSlug(c, h.queries, services.SlugWhitepaperTopics, "", c.FormValue("name"), id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to generate whitepaper topic slug", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	_, err = h.queries.UpdateWhitepaperTopic(c.Request().Context(), sqlc.UpdateWhitepaperTopicParams{
 		ID:          id,
 		Name:        c.FormValue("name"),
-		Slug:        makeSlug(c.FormValue("name")),
+		Slug:        slug,
 		ColorHex:    c.FormValue("color_hex"),
 		Icon:        c.FormValue("icon"),
 		Description: sql.NullString{String: desc, Valid: desc != ""},
 		SortOrder:   sortOrder,
 	})
 	if err != nil {
-		h.logger.Error("failed to update whitepaper topic", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to update whitepaper topic", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "updated", "whitepaper_topic", id, c.FormValue("name"), "Updated Whitepaper Topic '%s'", c.FormValue("name"))
@@ -187,7 +200,7 @@ func (h *WhitepaperTopicsHandler) Update(c echo.Context) error {
 func (h *WhitepaperTopicsHandler) Delete(c echo.Context) error {
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err := h.queries.DeleteWhitepaperTopic(c.Request().Context(), id); err != nil {
-		h.logger.Error("failed to delete whitepaper topic", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to delete whitepaper topic", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 	logActivity(c, "deleted", "whitepaper_topic", id, "", "Deleted Whitepaper Topic #%d", id)
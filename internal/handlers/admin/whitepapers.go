@@ -13,6 +13,7 @@ import (
 	"os"           // File system operations (Create, MkdirAll, Remove)
 	"path/filepath" // Path manipulation for upload directory handling
 	"strconv"      // String to integer conversions for route params and form values
+	"strings"      // Mapping stored /uploads/ paths back to files
 	"time"         // Unix timestamp for unique filename generation
 
 	// Third-party imports
@@ -26,9 +27,10 @@ import (
 // WhitepapersHandler handles all HTTP requests for whitepapers management in the admin panel.
 // It manages CRUD operations for whitepapers, PDF file uploads, and download tracking.
 type WhitepapersHandler struct {
-	queries *sqlc.Queries   // Database query interface generated by sqlc
-	logger  *slog.Logger    // Structured logger for error tracking
-	cache   *services.Cache // Cache service for invalidating page cache after updates
+	queries   sqlc.Querier    // Database query interface generated by sqlc
+	logger    *slog.Logger    // Structured logger for error tracking
+	cache     *services.Cache // Cache service for invalidating page cache after updates
+	uploadDir string          // Directory served at /uploads; PDFs go in its whitepapers subdirectory
 }
 
 // NewWhitepapersHandler creates and initializes a new WhitepapersHandler with required dependencies.
@@ -36,16 +38,23 @@ type WhitepapersHandler struct {
 //   - queries: sqlc-generated database query interface
 //   - logger: structured logger for error logging
 //   - cache: cache service for cache invalidation
+//   - uploadDir: directory served at /uploads (e.g., "public/uploads")
 //
 // Returns a fully initialized WhitepapersHandler ready to handle HTTP requests.
-func NewWhitepapersHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *WhitepapersHandler {
+func NewWhitepapersHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache, uploadDir string) *WhitepapersHandler {
 	return &WhitepapersHandler{
-		queries: queries,
-		logger:  logger,
-		cache:   cache,
+		queries:   queries,
+		logger:    logger,
+		cache:     cache,
+		uploadDir: uploadDir,
 	}
 }
 
+// pdfPath returns the file behind a stored "/uploads/whitepapers/..." path.
+func (h *WhitepapersHandler) pdfPath(webPath string) string {
+	return filepath.Join(h.uploadDir, strings.TrimPrefix(webPath, "/uploads/"))
+}
+
 // whitepapersPerPage defines the number of whitepapers to display per page in the list view.
 const whitepapersPerPage = 15
 
@@ -93,7 +102,7 @@ func (h *WhitepapersHandler) List(c echo.Context) error {
 
 	whitepapers, err := h.queries.ListWhitepapersAdminFiltered(ctx, filterParams)
 	if err != nil {
-		h.logger.Error("Failed to list whitepapers", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to list whitepapers", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load whitepapers")
 	}
 
@@ -103,13 +112,13 @@ func (h *WhitepapersHandler) List(c echo.Context) error {
 		FilterStatus: status,
 	})
 	if err != nil {
-		h.logger.Error("Failed to count whitepapers", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to count whitepapers", "error", err)
 		total = 0
 	}
 
 	topics, err := h.queries.ListWhitepaperTopics(ctx)
 	if err != nil {
-		h.logger.Error("Failed to list whitepaper topics", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to list whitepaper topics", "error", err)
 	}
 
 	totalPages := int(math.Ceil(float64(total) / float64(whitepapersPerPage)))
@@ -160,7 +169,7 @@ func (h *WhitepapersHandler) List(c echo.Context) error {
 func (h *WhitepapersHandler) New(c echo.Context) error {
 	topics, err := h.queries.ListWhitepaperTopics(c.Request().Context())
 	if err != nil {
-		h.logger.Error("Failed to list whitepaper topics", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to list whitepaper topics", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load topics")
 	}
 
@@ -199,18 +208,22 @@ func (h *WhitepapersHandler) New(c echo.Context) error {
 //   - Uploads PDF file to public/uploads/whitepapers/ directory
 //   - Generates unique filename using Unix timestamp and slugified title
 //   - Stores file size in bytes for display purposes
-//   - Creates learning points as separate related records
+//   - Creates learning points as separate related records, in the same transaction
+//   - Publishing runs the publish checklist; a whitepaper that fails is saved
+//     unpublished and its edit page opens with the failed checks
 //   - Invalidates "page:whitepapers" cache entries after creation
 func (h *WhitepapersHandler) Create(c echo.Context) error {
 	if err := c.Request().ParseMultipartForm(50 << 20); err != nil {
-		h.logger.Error("Failed to parse multipart form", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to parse multipart form", "error", err)
 		return c.String(http.StatusBadRequest, "Failed to parse form")
 	}
 
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title)
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugWhitepapers, c.FormValue("slug"), title, 0)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to generate whitepaper slug", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to create whitepaper")
 	}
 
 	description := c.FormValue("description")
@@ -231,6 +244,20 @@ func (h *WhitepapersHandler) Create(c echo.Context) error {
 		isPublished = 1
 	}
 
+	// Publishing needs the publish checklist to pass; otherwise save it unpublished
+	var gate publishGate
+	if isPublished == 1 {
+		var err error
+		gate, err = checkPublish(c, whitepaperCandidate(metaDescription, topicID, description))
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+			return c.String(http.StatusInternalServerError, "Failed to create whitepaper")
+		}
+		if !gate.allowed() {
+			isPublished = 0
+		}
+	}
+
 	pageCount := sql.NullInt64{}
 	if v := c.FormValue("page_count"); v != "" {
 		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
@@ -246,27 +273,27 @@ func (h *WhitepapersHandler) Create(c echo.Context) error {
 		// Open the uploaded file for reading
 		src, err := file.Open()
 		if err != nil {
-			h.logger.Error("Failed to open uploaded file", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to open uploaded file", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to process upload")
 		}
 		defer src.Close()
 
 		// Ensure upload directory exists (create if needed)
-		uploadDir := "public/uploads/whitepapers"
+		uploadDir := filepath.Join(h.uploadDir, "whitepapers")
 		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			h.logger.Error("Failed to create upload directory", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to create upload directory", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to create upload directory")
 		}
 
 		// Generate unique filename: timestamp_slug.pdf
 		ext := filepath.Ext(file.Filename)
-		filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), makeSlug(title), ext)
+		filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), services.Slugify(title), ext)
 		dstPath := filepath.Join(uploadDir, filename)
 
 		// Create destination file
 		dst, err := os.Create(dstPath)
 		if err != nil {
-			h.logger.Error("Failed to create destination file", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to create destination file", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to save file")
 		}
 		defer dst.Close()
@@ -274,11 +301,11 @@ func (h *WhitepapersHandler) Create(c echo.Context) error {
 		// Copy uploaded file to destination and track bytes written
 		written, err := io.Copy(dst, src)
 		if err != nil {
-			h.logger.Error("Failed to copy file", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to copy file", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to save file")
 		}
 
-		// Store web-accessible path (without "public" prefix)
+		// Store web-accessible path (served from the upload directory)
 		pdfFilePath = "/uploads/whitepapers/" + filename
 		fileSizeBytes = written
 	}
@@ -298,33 +325,49 @@ func (h *WhitepapersHandler) Create(c echo.Context) error {
 		MetaDescription: sql.NullString{String: metaDescription, Valid: metaDescription != ""},
 	}
 
-	whitepaper, err := h.queries.CreateWhitepaper(c.Request().Context(), params)
+	// The whitepaper and its learning points are created together or not at all
+	var whitepaper sqlc.Whitepaper
+	err = withTx(c.Request().Context(), h.queries, func(q sqlc.Querier) error {
+		var err error
+		whitepaper, err = q.CreateWhitepaper(c.Request().Context(), params)
+		if err != nil {
+			return err
+		}
+		return createLearningPoints(c, q, whitepaper.ID)
+	})
 	if err != nil {
-		h.logger.Error("Failed to create whitepaper", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to create whitepaper", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to create whitepaper")
 	}
+	recordSlugChange(c, h.logger, "", "/whitepapers/"+slug)
 
-	// Handle learning points array from form (e.g., <input name="learning_points[]">)
-	// Creates separate database records for each learning point
-	learningPoints := c.Request().Form["learning_points[]"]
-	for i, point := range learningPoints {
+	h.cache.DeleteByPrefix("page:whitepapers")
+	logActivity(c, "created", "whitepaper", 0, c.FormValue("title"), "Created Whitepaper '%s'", c.FormValue("title"))
+	gate.logOverride(c, "whitepaper", whitepaper.ID, title)
+	if !gate.allowed() {
+		// Show the failed checks on the new whitepaper's edit page
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/whitepapers/%d/edit?publish=%s", whitepaper.ID, gate.notice))
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/whitepapers")
+}
+
+// createLearningPoints adds the form's learning points (learning_points[]
+// inputs) to a whitepaper, in form order and skipping empty entries.
+func createLearningPoints(c echo.Context, q sqlc.Querier, whitepaperID int64) error {
+	for i, point := range c.Request().Form["learning_points[]"] {
 		if point == "" {
 			continue // Skip empty entries
 		}
-		_, err := h.queries.CreateWhitepaperLearningPoint(c.Request().Context(), sqlc.CreateWhitepaperLearningPointParams{
-			WhitepaperID: whitepaper.ID,
+		_, err := q.CreateWhitepaperLearningPoint(c.Request().Context(), sqlc.CreateWhitepaperLearningPointParams{
+			WhitepaperID: whitepaperID,
 			PointText:    point,
 			DisplayOrder: int64(i + 1), // 1-indexed display order
 		})
 		if err != nil {
-			h.logger.Error("Failed to create learning point", "error", err)
-			// Continue processing other points even if one fails
+			return err
 		}
 	}
-
-	h.cache.DeleteByPrefix("page:whitepapers")
-	logActivity(c, "created", "whitepaper", 0, c.FormValue("title"), "Created Whitepaper '%s'", c.FormValue("title"))
-	return c.Redirect(http.StatusSeeOther, "/admin/whitepapers")
+	return nil
 }
 
 // Edit displays the form for editing a whitepaper.
@@ -351,19 +394,25 @@ func (h *WhitepapersHandler) Edit(c echo.Context) error {
 
 	whitepaper, err := h.queries.GetWhitepaperByID(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get whitepaper", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get whitepaper", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load whitepaper")
 	}
 
 	topics, err := h.queries.ListWhitepaperTopics(c.Request().Context())
 	if err != nil {
-		h.logger.Error("Failed to list whitepaper topics", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to list whitepaper topics", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load topics")
 	}
 
 	learningPoints, err := h.queries.GetWhitepaperLearningPoints(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get learning points", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get learning points", "error", err)
+	}
+
+	checklist, err := publishChecklist(c, "whitepapers", whitepaperCandidate(whitepaper.MetaDescription.String,
+		whitepaper.TopicID, whitepaper.Description))
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
 	}
 
 	return c.Render(http.StatusOK, "admin/pages/whitepapers_form.html", map[string]interface{}{
@@ -373,6 +422,8 @@ func (h *WhitepapersHandler) Edit(c echo.Context) error {
 		"Topics":         topics,
 		"LearningPoints": learningPoints,
 		"IsNew":          false,
+		"PreviewURL":     previewURL("/whitepapers/"+whitepaper.Slug, services.PreviewWhitepaper, whitepaper.ID),
+		"Checklist":      checklist,
 	})
 }
 
@@ -392,7 +443,8 @@ func (h *WhitepapersHandler) Edit(c echo.Context) error {
 //   - Updates whitepaper base record
 //   - If new PDF uploaded, removes old file and stores new one
 //   - Otherwise retains existing PDF file path and size
-//   - Replaces all learning points (delete old, insert new)
+//   - Replaces all learning points (delete old, insert new), in the same transaction
+//   - Publishing an unpublished whitepaper runs the publish checklist, as in Create
 //   - Invalidates "page:whitepapers" cache entries
 func (h *WhitepapersHandler) Update(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -401,14 +453,16 @@ func (h *WhitepapersHandler) Update(c echo.Context) error {
 	}
 
 	if err := c.Request().ParseMultipartForm(50 << 20); err != nil {
-		h.logger.Error("Failed to parse multipart form", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to parse multipart form", "error", err)
 		return c.String(http.StatusBadRequest, "Failed to parse form")
 	}
 
 	title := c.FormValue("title")
-	slug := c.FormValue("slug")
-	if slug == "" {
-		slug = makeSlug(title)
+	// Submitted slug, or one generated from the title, made unique
+	slug, err := uniqueSlug(c, h.queries, services.SlugWhitepapers, c.FormValue("slug"), title, id)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to generate whitepaper slug", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to update whitepaper")
 	}
 
 	description := c.FormValue("description")
@@ -439,10 +493,23 @@ func (h *WhitepapersHandler) Update(c echo.Context) error {
 	// Get existing whitepaper for current PDF path
 	existing, err := h.queries.GetWhitepaperByID(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get existing whitepaper", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get existing whitepaper", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load whitepaper")
 	}
 
+	// Publishing an unpublished whitepaper needs the publish checklist to pass
+	var gate publishGate
+	if isPublished == 1 && existing.IsPublished == 0 {
+		gate, err = checkPublish(c, whitepaperCandidate(metaDescription, topicID, description))
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "Failed to run publish checklist", "error", err)
+			return c.String(http.StatusInternalServerError, "Failed to update whitepaper")
+		}
+		if !gate.allowed() {
+			isPublished = 0
+		}
+	}
+
 	pdfFilePath := existing.PdfFilePath
 	fileSizeBytes := existing.FileSizeBytes
 
@@ -451,37 +518,37 @@ func (h *WhitepapersHandler) Update(c echo.Context) error {
 	if err == nil && file != nil {
 		src, err := file.Open()
 		if err != nil {
-			h.logger.Error("Failed to open uploaded file", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to open uploaded file", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to process upload")
 		}
 		defer src.Close()
 
-		uploadDir := "public/uploads/whitepapers"
+		uploadDir := filepath.Join(h.uploadDir, "whitepapers")
 		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			h.logger.Error("Failed to create upload directory", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to create upload directory", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to create upload directory")
 		}
 
 		// Remove old file if it exists
 		if existing.PdfFilePath != "" {
-			oldPath := filepath.Join("public", existing.PdfFilePath)
+			oldPath := h.pdfPath(existing.PdfFilePath)
 			os.Remove(oldPath)
 		}
 
 		ext := filepath.Ext(file.Filename)
-		filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), makeSlug(title), ext)
+		filename := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), services.Slugify(title), ext)
 		dstPath := filepath.Join(uploadDir, filename)
 
 		dst, err := os.Create(dstPath)
 		if err != nil {
-			h.logger.Error("Failed to create destination file", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to create destination file", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to save file")
 		}
 		defer dst.Close()
 
 		written, err := io.Copy(dst, src)
 		if err != nil {
-			h.logger.Error("Failed to copy file", "error", err)
+			h.logger.ErrorContext(c.Request().Context(), "Failed to copy file", "error", err)
 			return c.String(http.StatusInternalServerError, "Failed to save file")
 		}
 
@@ -505,38 +572,29 @@ func (h *WhitepapersHandler) Update(c echo.Context) error {
 		ID:              id,
 	}
 
-	err = h.queries.UpdateWhitepaper(c.Request().Context(), params)
-	if err != nil {
-		h.logger.Error("Failed to update whitepaper", "error", err)
-		return c.String(http.StatusInternalServerError, "Failed to update whitepaper")
-	}
-
-	// Replace learning points: delete all existing, then insert new ones
-	// This ensures clean state and prevents orphaned records
-	err = h.queries.DeleteWhitepaperLearningPoints(c.Request().Context(), id)
-	if err != nil {
-		h.logger.Error("Failed to delete old learning points", "error", err)
-	}
-
-	// Insert new learning points from form array
-	learningPoints := c.Request().Form["learning_points[]"]
-	for i, point := range learningPoints {
-		if point == "" {
-			continue // Skip empty entries
+	// The whitepaper and its learning points are saved together or not at all.
+	// Learning points are replaced: delete all existing, then insert the form's
+	err = withTx(c.Request().Context(), h.queries, func(q sqlc.Querier) error {
+		if err := q.UpdateWhitepaper(c.Request().Context(), params); err != nil {
+			return err
 		}
-		_, err := h.queries.CreateWhitepaperLearningPoint(c.Request().Context(), sqlc.CreateWhitepaperLearningPointParams{
-			WhitepaperID: id,
-			PointText:    point,
-			DisplayOrder: int64(i + 1), // 1-indexed display order
-		})
-		if err != nil {
-			h.logger.Error("Failed to create learning point", "error", err)
-			// Continue processing other points
+		if err := q.DeleteWhitepaperLearningPoints(c.Request().Context(), id); err != nil {
+			return err
 		}
+		return createLearningPoints(c, q, id)
+	})
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "Failed to update whitepaper", "error", err)
+		return c.String(http.StatusInternalServerError, "Failed to update whitepaper")
 	}
+	recordSlugChange(c, h.logger, "/whitepapers/"+existing.Slug, "/whitepapers/"+slug)
 
 	h.cache.DeleteByPrefix("page:whitepapers")
 	logActivity(c, "updated", "whitepaper", id, c.FormValue("title"), "Updated Whitepaper '%s'", c.FormValue("title"))
+	gate.logOverride(c, "whitepaper", id, title)
+	if !gate.allowed() {
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/whitepapers/%d/edit?publish=%s", id, gate.notice))
+	}
 	return c.Redirect(http.StatusSeeOther, "/admin/whitepapers")
 }
 
@@ -565,19 +623,19 @@ func (h *WhitepapersHandler) Delete(c echo.Context) error {
 	// Get whitepaper to find PDF path for cleanup
 	whitepaper, err := h.queries.GetWhitepaperByID(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get whitepaper for deletion", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to get whitepaper for deletion", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load whitepaper")
 	}
 
 	err = h.queries.DeleteWhitepaper(c.Request().Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to delete whitepaper", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "Failed to delete whitepaper", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to delete whitepaper")
 	}
 
 	// Remove PDF file
 	if whitepaper.PdfFilePath != "" {
-		oldPath := filepath.Join("public", whitepaper.PdfFilePath)
+		oldPath := h.pdfPath(whitepaper.PdfFilePath)
 		os.Remove(oldPath)
 	}
 
@@ -635,7 +693,7 @@ func (h *WhitepapersHandler) Downloads(c echo.Context) error {
 		PageOffset:       offset,
 	})
 	if err != nil {
-		h.logger.Error("Failed to list whitepaper downloads", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to list whitepaper downloads", "error", err)
 		return c.String(http.StatusInternalServerError, "Failed to load downloads")
 	}
 
@@ -645,7 +703,7 @@ func (h *WhitepapersHandler) Downloads(c echo.Context) error {
 		FilterDateTo:     dateTo,
 	})
 	if err != nil {
-		h.logger.Error("Failed to count whitepaper downloads", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to count whitepaper downloads", "error", err)
 		totalCount = 0
 	}
 
@@ -662,7 +720,7 @@ func (h *WhitepapersHandler) Downloads(c echo.Context) error {
 	// Get whitepapers list for filter dropdown
 	whitepapers, err := h.queries.ListAllWhitepapers(ctx)
 	if err != nil {
-		h.logger.Error("Failed to list whitepapers for filter", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to list whitepapers for filter", "error", err)
 	}
 
 	hasFilters := whitepaperStr != "" || dateFrom != "" || dateTo != ""
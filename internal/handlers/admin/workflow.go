@@ -0,0 +1,439 @@
+// Package admin provides HTTP handlers for the admin panel.
+// This file contains the publishing workflow: the review panel on blog post
+// and product edit pages, the review queue, and the checks that keep the
+// edit forms from publishing around the workflow.
+package admin
+
+import (
+	// Standard library imports
+	"context"      // Content loader signatures
+	"database/sql" // published_at for first publication
+	"errors"       // Trashed content marker
+	"fmt"          // Editor paths and panel messages
+	"log/slog"     // Structured logging for error tracking
+	"net/http"     // HTTP status codes and error responses
+	"strconv"      // ID parsing
+	"time"         // published_at for first publication
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Principal of the current user
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Workflow service and page cache
+)
+
+// workflow is the publishing workflow. Like previewTokens it is set once at
+// startup so the blog post and product handlers need no extra constructor
+// argument; when it is nil the edit forms publish directly, as before.
+var workflow *services.Workflow
+
+// SetWorkflow sets the package-level publishing workflow.
+//
+// Example:
+//
+//	admin.SetWorkflow(services.NewWorkflow(queries, logger, nil, mailer, siteBaseURL))
+func SetWorkflow(w *services.Workflow) {
+	workflow = w
+}
+
+// workflowType describes how the workflow reads and publishes one content type.
+type workflowType struct {
+	label       string                                                         // e.g. "Blog post"
+	cachePrefix string                                                         // Page cache entries to drop on publish
+	editPath    string                                                         // Editor path pattern with one %d
+	load        func(context.Context, int64) (title, status string, err error) // Title and status; an error when missing or trashed
+	setStatus   func(context.Context, int64, string) (int64, error)            // Publishes ("published") or unpublishes ("draft")
+}
+
+// workflowTypes returns the content types that go through the workflow,
+// keyed by the content_type stored in content_workflow.
+func workflowTypes(q sqlc.Querier) map[string]workflowType {
+	return map[string]workflowType{
+		"blog_post": {"Blog post", "page:blog", "/admin/blog/posts/%d/edit",
+			func(ctx context.Context, id int64) (string, string, error) {
+				p, err := q.GetBlogPost(ctx, id)
+				if err == nil && p.DeletedAt.Valid {
+					err = errNotInWorkflow
+				}
+				return p.Title, p.Status, err
+			},
+			func(ctx context.Context, id int64, status string) (int64, error) {
+				return q.SetBlogPostPublishStatus(ctx, sqlc.SetBlogPostPublishStatusParams{
+					Status: status, PublishedAt: nullTimeNow(), ID: id,
+				})
+			}},
+		"product": {"Product", "page:products", "/admin/products/%d/edit",
+			func(ctx context.Context, id int64) (string, string, error) {
+				p, err := q.GetProduct(ctx, id)
+				if err == nil && p.DeletedAt.Valid {
+					err = errNotInWorkflow
+				}
+				return p.Name, p.Status, err
+			},
+			func(ctx context.Context, id int64, status string) (int64, error) {
+				return q.SetProductPublishStatus(ctx, sqlc.SetProductPublishStatusParams{
+					Status: status, PublishedAt: nullTimeNow(), ID: id,
+				})
+			}},
+	}
+}
+
+// errNotInWorkflow marks trashed content, which has no review panel.
+var errNotInWorkflow = errors.New("content is in the trash")
+
+// workflowActor returns the current user as a workflow actor.
+func workflowActor(c echo.Context) services.WorkflowActor {
+	if p := customMiddleware.PrincipalFrom(c); p != nil {
+		return services.WorkflowActor{UserID: p.UserID, Role: p.Role, Name: p.DisplayName}
+	}
+	return services.WorkflowActor{UserID: getUserID(c)}
+}
+
+// publishedBoundary maps a status column value to the workflow state a form
+// save would move the item to: published, or draft for anything else.
+func publishedBoundary(status string) string {
+	if status == services.WorkflowPublished {
+		return services.WorkflowPublished
+	}
+	return services.WorkflowDraft
+}
+
+// canPublishFromForm reports whether the current user may change whether the
+// item is published from its edit form (id 0 for a new item). Without the
+// workflow everyone may.
+func canPublishFromForm(c echo.Context, contentType string, id int64, status string) bool {
+	if workflow == nil {
+		return true
+	}
+	item, err := workflow.State(c.Request().Context(), contentType, id, status)
+	if err != nil {
+		return false
+	}
+	target := services.WorkflowPublished
+	if item.State == services.WorkflowPublished {
+		target = services.WorkflowDraft
+	}
+	return workflow.Allowed(workflowActor(c), item, target)
+}
+
+// formStatus returns the status an edit form may save. Publishing or
+// unpublishing from the form is a workflow transition; users the workflow
+// does not allow to make it keep the current status (draft for new items)
+// and use the review panel instead.
+//
+// Parameters:
+//   - contentType: "blog_post" or "product"
+//   - id: Item ID, 0 when creating
+//   - current: The item's status before the save ("" when creating)
+//   - submitted: The status chosen on the form
+func formStatus(c echo.Context, contentType string, id int64, current, submitted string) string {
+	if current == "" {
+		current = services.WorkflowDraft
+	}
+	if publishedBoundary(current) == publishedBoundary(submitted) || canPublishFromForm(c, contentType, id, current) {
+		return submitted
+	}
+	return current
+}
+
+// recordFormStatus records a publish or unpublish made from an edit form in
+// the workflow, so the review panel, queue and history stay accurate.
+// formStatus has already checked the transition is allowed. The change is
+// also noted on the request, so the activity logged after the save sends a
+// content.published webhook (see notifyWebhooks).
+//
+// Parameters:
+//   - contentType, id, title: The saved item
+//   - link: Admin path of its editor, for notification emails
+//   - before, after: Its status before and after the save ("" when created)
+func recordFormStatus(c echo.Context, logger *slog.Logger, contentType string, id int64, title, link, before, after string) {
+	if publishedBoundary(before) == publishedBoundary(after) {
+		return
+	}
+	c.Set(publishChangeKey, publishedBoundary(after))
+	if workflow == nil {
+		return
+	}
+	ctx := c.Request().Context()
+	item, err := workflow.State(ctx, contentType, id, before)
+	if err == nil {
+		_, err = workflow.Transition(ctx, item, publishedBoundary(after), workflowActor(c), title, link, "Changed on the edit form")
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to record workflow state", "type", contentType, "id", id, "error", err)
+	}
+}
+
+// nullTimeNow returns the current time as a valid sql.NullTime.
+func nullTimeNow() sql.NullTime {
+	return sql.NullTime{Time: time.Now().UTC(), Valid: true}
+}
+
+// WorkflowHandler serves the review panel and the review queue.
+type WorkflowHandler struct {
+	queries sqlc.Querier    // Database query interface for content and users
+	logger  *slog.Logger    // Structured logger for error tracking
+	cache   *services.Cache // Page cache, cleared when content is published or unpublished
+}
+
+// NewWorkflowHandler constructs a new WorkflowHandler with required dependencies.
+func NewWorkflowHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *WorkflowHandler {
+	return &WorkflowHandler{queries: queries, logger: logger, cache: cache}
+}
+
+// workflowButton is one transition button on the review panel.
+type workflowButton struct {
+	To      string // Target state, submitted as "to"
+	Label   string // e.g. "Submit for review"
+	Primary bool   // Blue button for the forward transitions
+}
+
+// workflowButtonLabel names the transition from -> to for the panel.
+func workflowButtonLabel(from, to string, isSubmitter bool) string {
+	switch {
+	case to == services.WorkflowInReview:
+		return "Submit for review"
+	case to == services.WorkflowApproved:
+		return "Approve"
+	case to == services.WorkflowPublished && from == services.WorkflowDraft:
+		return "Publish without review"
+	case to == services.WorkflowPublished:
+		return "Publish"
+	case from == services.WorkflowInReview && isSubmitter:
+		return "Withdraw"
+	case from == services.WorkflowInReview:
+		return "Request changes"
+	case from == services.WorkflowPublished:
+		return "Unpublish"
+	default:
+		return "Reopen for changes"
+	}
+}
+
+// workflowActions are the activity log actions for each target state.
+var workflowActions = map[string]string{
+	services.WorkflowInReview:  "submitted",
+	services.WorkflowApproved:  "approved",
+	services.WorkflowPublished: "published",
+	services.WorkflowDraft:     "returned",
+}
+
+// target resolves :type and :id to a content item and its workflow row.
+func (h *WorkflowHandler) target(c echo.Context) (string, workflowType, int64, string, sqlc.ContentWorkflow, error) {
+	kind := c.Param("type")
+	t, ok := workflowTypes(h.queries)[kind]
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if !ok || err != nil {
+		return "", t, 0, "", sqlc.ContentWorkflow{}, echo.NewHTTPError(http.StatusNotFound)
+	}
+	ctx := c.Request().Context()
+	title, status, err := t.load(ctx, id)
+	if err != nil {
+		return "", t, 0, "", sqlc.ContentWorkflow{}, echo.NewHTTPError(http.StatusNotFound)
+	}
+	item, err := workflow.State(ctx, kind, id, status)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load workflow state", "type", kind, "id", id, "error", err)
+		return "", t, 0, "", sqlc.ContentWorkflow{}, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return kind, t, id, title, item, nil
+}
+
+// Panel handles GET /admin/workflow/:type/:id
+// Renders the review panel loaded into blog post and product edit pages.
+// Template: admin/partials/workflow_panel.html (HTMX fragment)
+func (h *WorkflowHandler) Panel(c echo.Context) error {
+	kind, _, id, title, item, err := h.target(c)
+	if err != nil {
+		return err
+	}
+	return h.renderPanel(c, kind, id, title, item, "")
+}
+
+// Transition handles POST /admin/workflow/:type/:id/transition
+// Moves the item to the state in the "to" field, with an optional "note" for
+// the history and notification email. Reaching or leaving published also
+// publishes or unpublishes the content. Transitions the user may not make
+// re-render the panel with a message.
+// Template: admin/partials/workflow_panel.html (HTMX fragment)
+func (h *WorkflowHandler) Transition(c echo.Context) error {
+	kind, t, id, title, item, err := h.target(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	actor := workflowActor(c)
+	from, to := item.State, c.FormValue("to")
+	if !workflow.Allowed(actor, item, to) {
+		showFallbackForm(c)
+		return h.renderPanel(c, kind, id, title, item, fmt.Sprintf("You cannot move this %s from %s to %s.",
+			t.label, services.WorkflowStateLabel(from), services.WorkflowStateLabel(to)))
+	}
+
+	if to == services.WorkflowPublished || from == services.WorkflowPublished {
+		if _, err := t.setStatus(ctx, id, publishedBoundary(to)); err != nil {
+			h.logger.ErrorContext(ctx, "failed to update content status", "type", kind, "id", id, "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		h.cache.DeleteByPrefix(t.cachePrefix)
+	}
+	item, err = workflow.Transition(ctx, item, to, actor, title, fmt.Sprintf(t.editPath, id), c.FormValue("note"))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to record workflow transition", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	action := workflowActions[to]
+	if from == services.WorkflowPublished {
+		action = "unpublished"
+	}
+	logActivity(c, action, kind, id, title, "Moved %s '%s' from %s to %s", kind, title,
+		services.WorkflowStateLabel(from), services.WorkflowStateLabel(to))
+	return h.renderPanel(c, kind, id, title, item, "")
+}
+
+// AssignReviewer handles POST /admin/workflow/:type/:id/reviewer
+// Sets the reviewer from the "reviewer_id" field (empty or 0 clears it).
+// Reviewers must be active users.
+// Template: admin/partials/workflow_panel.html (HTMX fragment)
+func (h *WorkflowHandler) AssignReviewer(c echo.Context) error {
+	kind, t, id, title, item, err := h.target(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	reviewerID, _ := strconv.ParseInt(c.FormValue("reviewer_id"), 10, 64)
+	if reviewerID > 0 && !h.isActiveUser(ctx, reviewerID) {
+		showFallbackForm(c)
+		return h.renderPanel(c, kind, id, title, item, "Choose an active user as reviewer.")
+	}
+	item, err = workflow.AssignReviewer(ctx, item, reviewerID, workflowActor(c), title, fmt.Sprintf(t.editPath, id))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to assign reviewer", "type", kind, "id", id, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	logActivity(c, "updated", kind, id, title, "Assigned a reviewer to %s '%s'", kind, title)
+	return h.renderPanel(c, kind, id, title, item, "")
+}
+
+// isActiveUser reports whether id is an active admin user.
+func (h *WorkflowHandler) isActiveUser(ctx context.Context, id int64) bool {
+	users, _ := h.queries.ListAdminUsers(ctx)
+	for _, u := range users {
+		if u.ID == id {
+			return u.IsActive == 1
+		}
+	}
+	return false
+}
+
+// workflowHistoryEntry is one transition in the panel's history list.
+type workflowHistoryEntry struct {
+	sqlc.ListContentWorkflowHistoryRow
+	ToLabel string // e.g. "Approved"
+}
+
+// renderPanel renders the review panel for one item.
+func (h *WorkflowHandler) renderPanel(c echo.Context, kind string, id int64, title string, item sqlc.ContentWorkflow, formError string) error {
+	ctx := c.Request().Context()
+	actor := workflowActor(c)
+	isSubmitter := item.SubmittedBy.Valid && item.SubmittedBy.Int64 == actor.UserID
+
+	var buttons []workflowButton
+	for _, to := range workflow.Next(actor, item) {
+		buttons = append(buttons, workflowButton{
+			To:      to,
+			Label:   workflowButtonLabel(item.State, to, isSubmitter),
+			Primary: to != services.WorkflowDraft,
+		})
+	}
+	users, _ := h.queries.ListAdminUsers(ctx)
+	reviewers := users[:0]
+	for _, u := range users {
+		if u.IsActive == 1 {
+			reviewers = append(reviewers, u)
+		}
+	}
+	rows, err := h.queries.ListContentWorkflowHistory(ctx, sqlc.ListContentWorkflowHistoryParams{ContentType: kind, ContentID: id})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load workflow history", "type", kind, "id", id, "error", err)
+	}
+	history := make([]workflowHistoryEntry, len(rows))
+	for i, r := range rows {
+		history[i] = workflowHistoryEntry{ListContentWorkflowHistoryRow: r, ToLabel: services.WorkflowStateLabel(r.ToState)}
+	}
+
+	return c.Render(http.StatusOK, "admin/partials/workflow_panel.html", map[string]interface{}{
+		"Type":       kind,
+		"ID":         id,
+		"ItemTitle":  title,
+		"State":      item.State,
+		"StateLabel": services.WorkflowStateLabel(item.State),
+		"ReviewerID": item.ReviewerID.Int64,
+		"Reviewers":  reviewers,
+		"Buttons":    buttons,
+		"History":    history,
+		"Error":      formError,
+	})
+}
+
+// reviewQueueItem is one row of the review queue.
+type reviewQueueItem struct {
+	sqlc.ListReviewQueueRow
+	TypeLabel  string // e.g. "Blog post"
+	StateLabel string // e.g. "In review"
+	EditURL    string // Editor path
+}
+
+// reviewQueueSection is one table on the review queue page.
+type reviewQueueSection struct {
+	Label string            // Section heading
+	Empty string            // Shown when Items is empty
+	Items []reviewQueueItem // Items in this state, oldest first
+}
+
+// ReviewQueue handles GET /admin/review-queue
+// Lists content waiting for review or for publishing, oldest first.
+// Template: admin/pages/review_queue.html (full page)
+//
+// Query parameters:
+//   - mine: "1" to show only items assigned to the current user
+func (h *WorkflowHandler) ReviewQueue(c echo.Context) error {
+	mine := c.QueryParam("mine") == "1"
+	var reviewerID int64
+	if mine {
+		reviewerID = workflowActor(c).UserID
+	}
+	rows, err := h.queries.ListReviewQueue(c.Request().Context(), reviewerID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list review queue", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	types := workflowTypes(h.queries)
+	var inReview, approved []reviewQueueItem
+	for _, r := range rows {
+		item := reviewQueueItem{
+			ListReviewQueueRow: r,
+			TypeLabel:          types[r.ContentType].label,
+			StateLabel:         services.WorkflowStateLabel(r.State),
+			EditURL:            fmt.Sprintf(types[r.ContentType].editPath, r.ContentID),
+		}
+		if r.State == services.WorkflowInReview {
+			inReview = append(inReview, item)
+		} else {
+			approved = append(approved, item)
+		}
+	}
+
+	return c.Render(http.StatusOK, "admin/pages/review_queue.html", map[string]interface{}{
+		"Title": "Review Queue",
+		"Sections": []reviewQueueSection{
+			{Label: "Waiting for review", Empty: "Nothing is waiting for review.", Items: inReview},
+			{Label: "Approved, waiting to be published", Empty: "Nothing is waiting to be published.", Items: approved},
+		},
+		"Mine": mine,
+	})
+}
@@ -21,14 +21,14 @@ import (
 // AboutHandler handles HTTP requests for the About Us page and related content.
 // It manages company overview, mission/vision/values, core values, milestones, and certifications.
 type AboutHandler struct {
-	queries *sqlc.Queries    // Database query interface for fetching about page data
-	logger  *slog.Logger     // Structured logger for debugging and error tracking
-	cache   *services.Cache  // In-memory cache for rendered HTML to improve response times
+	queries sqlc.Querier    // Database query interface for fetching about page data
+	logger  *slog.Logger    // Structured logger for debugging and error tracking
+	cache   *services.Cache // In-memory cache for rendered HTML to improve response times
 }
 
 // NewAboutHandler constructs a new AboutHandler with required dependencies.
 // This constructor is called during application initialization to wire up dependencies.
-func NewAboutHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *AboutHandler {
+func NewAboutHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *AboutHandler {
 	return &AboutHandler{
 		queries: queries,
 		logger:  logger,
@@ -70,13 +70,13 @@ func (h *AboutHandler) renderAndCache(c echo.Context, cacheKey string, ttlSecond
 	// This allows us to cache the rendered HTML before sending it
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Extract rendered HTML from buffer and store in cache for future requests
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 
 	// Return the rendered HTML to the client with specified status code
 	return c.HTML(statusCode, html)
@@ -100,7 +100,7 @@ func (h *AboutHandler) AboutPage(c echo.Context) error {
 
 	// Check if cached version exists and return it immediately to improve performance
 	// This avoids database queries and template rendering for repeated requests
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -111,21 +111,21 @@ func (h *AboutHandler) AboutPage(c echo.Context) error {
 	// This is optional content - if not configured in admin, we gracefully continue
 	overview, err := h.queries.GetCompanyOverview(ctx)
 	if err != nil {
-		h.logger.Debug("no company overview found", "error", err)
+		h.logger.DebugContext(ctx, "no company overview found", "error", err)
 	}
 
 	// Fetch mission, vision, and values statement (single row)
 	// Also optional - company may not have configured this yet
 	mvv, err := h.queries.GetMissionVisionValues(ctx)
 	if err != nil {
-		h.logger.Debug("no mission/vision/values found", "error", err)
+		h.logger.DebugContext(ctx, "no mission/vision/values found", "error", err)
 	}
 
 	// Fetch list of core values (e.g., "Integrity", "Innovation", "Customer Focus")
 	// Each core value has a title, description, and optional icon
 	coreValues, err := h.queries.ListCoreValues(ctx)
 	if err != nil {
-		h.logger.Error("failed to load core values", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load core values", "error", err)
 		coreValues = []sqlc.CoreValue{} // Default to empty slice to prevent template errors
 	}
 
@@ -133,7 +133,7 @@ func (h *AboutHandler) AboutPage(c echo.Context) error {
 	// Displayed in a timeline format on the about page
 	milestones, err := h.queries.ListMilestones(ctx)
 	if err != nil {
-		h.logger.Error("failed to load milestones", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load milestones", "error", err)
 		milestones = []sqlc.Milestone{} // Default to empty slice
 	}
 
@@ -141,7 +141,7 @@ func (h *AboutHandler) AboutPage(c echo.Context) error {
 	// Displayed as badges or cards to build trust with visitors
 	certs, err := h.queries.ListCertifications(ctx)
 	if err != nil {
-		h.logger.Error("failed to load certifications", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load certifications", "error", err)
 		certs = []sqlc.Certification{} // Default to empty slice
 	}
 
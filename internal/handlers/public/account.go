@@ -0,0 +1,374 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves customer accounts: sign-up with email confirmation,
+// sign-in, password resets and the /account page listing what a customer
+// downloaded.
+package public
+
+import (
+	// Standard library imports
+	"bytes"    // Rendering into a buffer
+	"errors"   // Account error inspection
+	"fmt"      // Download URLs
+	"log/slog" // Structured logging for errors
+	"net/http" // HTTP status codes and cookies
+	"net/url"  // Escaping tokens and the next parameter
+	"strings"  // Checking the next parameter
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // Library rows
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Customer cookie and signed-in account
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Customer accounts, signed download links
+)
+
+// AccountHandler serves the public customer account pages. Accounts let
+// returning visitors get whitepapers and gated product files without
+// filling in the lead form again, and find them later on /account.
+//
+// Account pages are personal, so they are never cached and not indexed.
+type AccountHandler struct {
+	accounts *services.CustomerAccounts // Registration, confirmation, sessions, resets and libraries
+	links    *services.DownloadLinks    // Signed links for gated files in the library
+	logger   *slog.Logger               // Structured logger for errors
+}
+
+// NewAccountHandler creates a new AccountHandler with the required dependencies.
+func NewAccountHandler(accounts *services.CustomerAccounts, links *services.DownloadLinks, logger *slog.Logger) *AccountHandler {
+	return &AccountHandler{accounts: accounts, links: links, logger: logger}
+}
+
+// accountFile is a product file in a customer's library with the link that
+// downloads it.
+type accountFile struct {
+	sqlc.ListCustomerLibraryProductDownloadsRow
+	URL string // /product-downloads/<id>, signed for gated files
+}
+
+// Account handles GET /account
+// Lists the whitepapers and product files the customer downloaded while
+// signed in. Gated files get a fresh signed link, so they download without
+// the lead form. Visitors who are not signed in are sent to sign in.
+//
+// Template: public/pages/account.html (full page)
+func (h *AccountHandler) Account(c echo.Context) error {
+	account, ok := customMiddleware.CustomerFrom(c)
+	if !ok {
+		return c.Redirect(http.StatusSeeOther, "/account/login?next=/account")
+	}
+	lib, err := h.accounts.Library(c.Request().Context(), account.ID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load customer library", "account", account.ID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	files := make([]accountFile, len(lib.Files))
+	for i, f := range lib.Files {
+		files[i] = accountFile{ListCustomerLibraryProductDownloadsRow: f, URL: fmt.Sprintf("/product-downloads/%d", f.ID)}
+		if f.IsGated == 1 {
+			files[i].URL += "?token=" + url.QueryEscape(h.links.Issue(f.ID))
+		}
+	}
+	return h.render(c, "public/pages/account.html", map[string]interface{}{
+		"Title":       "My Account",
+		"Account":     account,
+		"Whitepapers": lib.Whitepapers,
+		"Files":       files,
+	})
+}
+
+// LoginPage handles GET /account/login
+// Renders the sign-in form; signed-in customers go straight to ?next or
+// /account.
+//
+// Template: public/pages/account_login.html (full page)
+func (h *AccountHandler) LoginPage(c echo.Context) error {
+	next := safeNext(c.QueryParam("next"))
+	if _, ok := customMiddleware.CustomerFrom(c); ok {
+		return c.Redirect(http.StatusSeeOther, next)
+	}
+	return h.render(c, "public/pages/account_login.html", map[string]interface{}{
+		"Title": "Sign In",
+		"Next":  next,
+	})
+}
+
+// Login handles POST /account/login
+// Signs the customer in and redirects to the next page. A wrong email or
+// password shows the form again with one error for both.
+//
+// Form Fields: email, password, next
+func (h *AccountHandler) Login(c echo.Context) error {
+	ctx := c.Request().Context()
+	email := strings.TrimSpace(c.FormValue("email"))
+	next := safeNext(c.FormValue("next"))
+	account, err := h.accounts.Authenticate(ctx, email, c.FormValue("password"))
+	if errors.Is(err, services.ErrCustomerInvalidLogin) {
+		return h.render(c, "public/pages/account_login.html", map[string]interface{}{
+			"Title": "Sign In",
+			"Next":  next,
+			"Email": email,
+			"Error": err.Error(),
+		})
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to sign in customer", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.signIn(c, account.ID, next)
+}
+
+// RegisterPage handles GET /account/register
+// Renders the sign-up form.
+//
+// Template: public/pages/account_register.html (full page)
+func (h *AccountHandler) RegisterPage(c echo.Context) error {
+	next := safeNext(c.QueryParam("next"))
+	if _, ok := customMiddleware.CustomerFrom(c); ok {
+		return c.Redirect(http.StatusSeeOther, next)
+	}
+	return h.render(c, "public/pages/account_register.html", map[string]interface{}{
+		"Title": "Create an Account",
+		"Next":  next,
+	})
+}
+
+// Register handles POST /account/register
+// Emails a link that confirms the address and creates the account. The page
+// says the same whether or not the email already has an account, so it does
+// not reveal which emails are registered. Invalid details show the form
+// again with the error and the values entered.
+//
+// Form Fields: name, email, company, password (required), designation,
+// marketing_consent, next
+func (h *AccountHandler) Register(c echo.Context) error {
+	ctx := c.Request().Context()
+	next := safeNext(c.FormValue("next"))
+	r := services.CustomerRegistration{
+		Name:             c.FormValue("name"),
+		Email:            c.FormValue("email"),
+		Company:          c.FormValue("company"),
+		Designation:      c.FormValue("designation"),
+		Password:         c.FormValue("password"),
+		MarketingConsent: c.FormValue("marketing_consent") != "",
+	}
+	err := h.accounts.Register(ctx, r)
+	switch {
+	case errors.Is(err, services.ErrCustomerDetailsRequired), errors.Is(err, services.ErrCustomerInvalidEmail),
+		errors.Is(err, services.ErrCustomerPasswordTooShort), errors.Is(err, services.ErrCustomerPasswordTooLong):
+		r.Password = ""
+		return h.render(c, "public/pages/account_register.html", map[string]interface{}{
+			"Title": "Create an Account",
+			"Next":  next,
+			"Form":  r,
+			"Error": err.Error(),
+		})
+	case err != nil:
+		h.logger.ErrorContext(ctx, "failed to register customer", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.render(c, "public/pages/account_register.html", map[string]interface{}{
+		"Title": "Create an Account",
+		"Email": strings.TrimSpace(r.Email),
+		"Sent":  true,
+	})
+}
+
+// ConfirmPage handles GET /account/confirm?token=
+// Asks the visitor to confirm the sign-up of an emailed confirmation link,
+// or explains that the link no longer works. Confirming takes a POST, so
+// link scanners in mail clients do not create accounts or get their
+// session cookies by following the link.
+//
+// Template: public/pages/account_confirm.html (full page)
+func (h *AccountHandler) ConfirmPage(c echo.Context) error {
+	token := c.QueryParam("token")
+	return h.render(c, "public/pages/account_confirm.html", map[string]interface{}{
+		"Title": "Confirm Your Email",
+		"Token": token,
+		"Valid": token != "" && h.accounts.ValidConfirmToken(c.Request().Context(), token),
+	})
+}
+
+// Confirm handles POST /account/confirm
+// Creates the account of the confirmation link and signs it in.
+//
+// Form Fields: token
+func (h *AccountHandler) Confirm(c echo.Context) error {
+	ctx := c.Request().Context()
+	accountID, err := h.accounts.ConfirmRegistration(ctx, c.FormValue("token"))
+	if errors.Is(err, services.ErrCustomerConfirmInvalid) {
+		return h.render(c, "public/pages/account_confirm.html", map[string]interface{}{
+			"Title": "Confirm Your Email",
+		})
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to confirm customer registration", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.signIn(c, accountID, "/account")
+}
+
+// Logout handles POST /account/logout
+// Ends the session and clears the cookie.
+func (h *AccountHandler) Logout(c echo.Context) error {
+	if cookie, err := c.Cookie(customMiddleware.CustomerCookie); err == nil && cookie.Value != "" {
+		if err := h.accounts.EndSession(c.Request().Context(), cookie.Value); err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to end customer session", "error", err)
+		}
+	}
+	h.setCookie(c, "", -1)
+	return c.Redirect(http.StatusSeeOther, "/")
+}
+
+// ForgotPage handles GET /account/forgot
+// Renders the form asking for a password reset email.
+//
+// Template: public/pages/account_forgot.html (full page)
+func (h *AccountHandler) ForgotPage(c echo.Context) error {
+	return h.render(c, "public/pages/account_forgot.html", map[string]interface{}{
+		"Title": "Reset Your Password",
+	})
+}
+
+// Forgot handles POST /account/forgot
+// Emails a reset link if the address has an account. The page says the same
+// either way, so it does not reveal which emails are registered.
+//
+// Form Fields: email
+func (h *AccountHandler) Forgot(c echo.Context) error {
+	ctx := c.Request().Context()
+	email := strings.TrimSpace(c.FormValue("email"))
+	if email != "" {
+		if err := h.accounts.RequestPasswordReset(ctx, email); err != nil {
+			h.logger.ErrorContext(ctx, "failed to start customer password reset", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+	return h.render(c, "public/pages/account_forgot.html", map[string]interface{}{
+		"Title": "Reset Your Password",
+		"Email": email,
+		"Sent":  email != "",
+	})
+}
+
+// ResetPage handles GET /account/reset?token=
+// Renders the new password form of an emailed reset link, or explains that
+// the link no longer works.
+//
+// Template: public/pages/account_reset.html (full page)
+func (h *AccountHandler) ResetPage(c echo.Context) error {
+	token := c.QueryParam("token")
+	return h.render(c, "public/pages/account_reset.html", map[string]interface{}{
+		"Title": "Choose a New Password",
+		"Token": token,
+		"Valid": token != "" && h.accounts.ValidResetToken(c.Request().Context(), token),
+	})
+}
+
+// Reset handles POST /account/reset
+// Sets the new password, which signs the account out everywhere, then signs
+// this browser in.
+//
+// Form Fields: token, password
+func (h *AccountHandler) Reset(c echo.Context) error {
+	ctx := c.Request().Context()
+	token := c.FormValue("token")
+	accountID, err := h.accounts.ResetPassword(ctx, token, c.FormValue("password"))
+	if errors.Is(err, services.ErrCustomerResetInvalid) || errors.Is(err, services.ErrCustomerPasswordTooShort) ||
+		errors.Is(err, services.ErrCustomerPasswordTooLong) {
+		return h.render(c, "public/pages/account_reset.html", map[string]interface{}{
+			"Title": "Choose a New Password",
+			"Token": token,
+			"Valid": !errors.Is(err, services.ErrCustomerResetInvalid),
+			"Error": err.Error(),
+		})
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to reset customer password", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.signIn(c, accountID, "/account")
+}
+
+// WhitepaperShortcut handles GET /account/whitepapers/:slug
+// Returns the one-click download form a whitepaper page swaps in for its
+// lead form when the visitor is signed in. The whitepaper page is cached for
+// everyone, so it loads this with HTMX; anonymous visitors get 204 and keep
+// the lead form.
+//
+// Template: public/partials/account_whitepaper.html (HTMX fragment)
+func (h *AccountHandler) WhitepaperShortcut(c echo.Context) error {
+	account, ok := customMiddleware.CustomerFrom(c)
+	if !ok {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return h.render(c, "public/partials/account_whitepaper.html", map[string]interface{}{
+		"Account": account,
+		"Slug":    c.Param("slug"),
+	})
+}
+
+// signIn starts a session for the account, sets its cookie and redirects
+// to next.
+func (h *AccountHandler) signIn(c echo.Context, accountID int64, next string) error {
+	token, err := h.accounts.StartSession(c.Request().Context(), accountID)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to start customer session", "account", accountID, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	h.setCookie(c, token, int(services.CustomerSessionTTL.Seconds()))
+	return c.Redirect(http.StatusSeeOther, next)
+}
+
+// setCookie sets the customer session cookie; a negative maxAge deletes it.
+func (h *AccountHandler) setCookie(c echo.Context, token string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     customMiddleware.CustomerCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.IsTLS(),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// render renders an account page or fragment with the site settings and
+// footer data from the middleware. Nothing is cached.
+func (h *AccountHandler) render(c echo.Context, name string, data map[string]interface{}) error {
+	if settings := c.Get("settings"); settings != nil {
+		data["Settings"] = settings
+	}
+	if cats := c.Get("footer_categories"); cats != nil {
+		data["FooterCategories"] = cats
+	}
+	if sols := c.Get("footer_solutions"); sols != nil {
+		data["FooterSolutions"] = sols
+	}
+	if res := c.Get("footer_resources"); res != nil {
+		data["FooterResources"] = res
+	}
+	data["NoIndex"] = true
+	data["MinPasswordLength"] = services.MinCustomerPasswordLength
+	data["MaxPasswordLength"] = services.MaxCustomerPasswordLength
+	data["CurrentPage"] = "account"
+	setLang(c, data)
+
+	var buf bytes.Buffer
+	if err := c.Echo().Renderer.Render(&buf, name, data, c); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", name, "error", err)
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+	return c.HTML(http.StatusOK, minifyPage(c, buf.String()))
+}
+
+// safeNext returns next when it is a path on this site, and /account
+// otherwise, so sign-in cannot redirect visitors to another site.
+func safeNext(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/account"
+	}
+	return next
+}
@@ -0,0 +1,146 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file contains the read-only JSON API over the published catalog.
+package public
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for unknown categories
+	"errors"       // Matching sentinel errors
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing the limit parameter
+	"time"         // Timestamps in responses
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, JSON responses
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Cursor pagination
+)
+
+// APIHandler serves the public JSON API. Lists are paginated with opaque
+// cursors rather than page numbers, so clients walking a large catalog see
+// every product exactly once even while products are being added.
+type APIHandler struct {
+	queries    sqlc.Querier             // Database query interface for category lookups
+	logger     *slog.Logger             // Structured logger for errors
+	productSvc *services.ProductService // Keyset-paginated product listing
+}
+
+// NewAPIHandler creates a new APIHandler with the required dependencies.
+func NewAPIHandler(queries sqlc.Querier, logger *slog.Logger, productSvc *services.ProductService) *APIHandler {
+	return &APIHandler{queries: queries, logger: logger, productSvc: productSvc}
+}
+
+// apiProduct is a product as returned by the API.
+type apiProduct struct {
+	ID          int64       `json:"id"`
+	SKU         string      `json:"sku"`
+	Slug        string      `json:"slug"`
+	Name        string      `json:"name"`
+	Tagline     string      `json:"tagline,omitempty"`
+	Description string      `json:"description"`
+	Image       string      `json:"image,omitempty"`
+	Featured    bool        `json:"featured"`
+	Category    apiCategory `json:"category"`
+	URL         string      `json:"url"`
+	PublishedAt *time.Time  `json:"published_at,omitempty"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// apiCategory is the category summary embedded in each product.
+type apiCategory struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// apiProductList is the response of Products.
+type apiProductList struct {
+	Data       []apiProduct `json:"data"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	HasMore    bool         `json:"has_more"`
+}
+
+// Products handles GET /api/v1/products
+// Lists published products one page at a time. A response with has_more
+// carries next_cursor; pass it back as ?cursor= (with the same sort) for the
+// following page.
+//
+// Query parameters:
+//   - sort: "id" (default, oldest first) or "name"
+//   - category: Category slug to list (optional)
+//   - limit: Page size, 1-200 (default 50)
+//   - cursor: next_cursor of the previous page
+//
+// Errors are JSON {"error": "..."} with status 400 for bad parameters and
+// 404 for an unknown category.
+func (h *APIHandler) Products(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sort := c.QueryParam("sort")
+	if sort == "" {
+		sort = services.ProductSortID
+	}
+	if sort != services.ProductSortID && sort != services.ProductSortName {
+		return apiError(c, http.StatusBadRequest, `sort must be "id" or "name"`)
+	}
+	limit := services.DefaultProductPageSize
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > services.MaxProductPageSize {
+			return apiError(c, http.StatusBadRequest, "limit must be between 1 and 200")
+		}
+		limit = n
+	}
+	after, err := services.DecodeCursor(c.QueryParam("cursor"), sort)
+	if err != nil {
+		return apiError(c, http.StatusBadRequest, "cursor is invalid or was issued for another sort")
+	}
+
+	var categoryID int64
+	if slug := c.QueryParam("category"); slug != "" {
+		cat, err := h.queries.GetProductCategoryBySlug(ctx, slug)
+		if errors.Is(err, sql.ErrNoRows) {
+			return apiError(c, http.StatusNotFound, "category not found")
+		}
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to load category", "slug", slug, "error", err)
+			return apiError(c, http.StatusInternalServerError, "internal error")
+		}
+		categoryID = cat.ID
+	}
+
+	page, err := h.productSvc.ListProductPage(ctx, categoryID, after, limit)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list products", "error", err)
+		return apiError(c, http.StatusInternalServerError, "internal error")
+	}
+
+	resp := apiProductList{Data: make([]apiProduct, 0, len(page.Products)), NextCursor: page.NextCursor, HasMore: page.NextCursor != ""}
+	for _, p := range page.Products {
+		item := apiProduct{
+			ID:          p.ID,
+			SKU:         p.Sku,
+			Slug:        p.Slug,
+			Name:        p.Name,
+			Tagline:     p.Tagline.String,
+			Description: p.Description,
+			Image:       p.PrimaryImage.String,
+			Featured:    p.IsFeatured,
+			Category:    apiCategory{Slug: p.CategorySlug, Name: p.CategoryName},
+			URL:         "/products/" + p.CategorySlug + "/" + p.Slug,
+			UpdatedAt:   p.UpdatedAt,
+		}
+		if p.PublishedAt.Valid {
+			item.PublishedAt = &p.PublishedAt.Time
+		}
+		resp.Data = append(resp.Data, item)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// apiError writes a JSON error response.
+func apiError(c echo.Context, status int, message string) error {
+	return c.JSON(status, map[string]string{"error": message})
+}
@@ -0,0 +1,49 @@
+package public
+
+import (
+	"net/http" // HTTP status codes
+	"net/url"  // Unescaping the requested file name
+
+	"github.com/labstack/echo/v4"                           // Echo web framework
+	"github.com/narendhupati/bluejay-cms/internal/services" // Content-hashed asset names
+)
+
+// AssetsHandler serves the static files in public/ (CSS, JavaScript, images)
+// under the content-hashed names the templates link to (see the asset
+// template function), as well as under their plain names.
+type AssetsHandler struct {
+	assets *services.Assets // Maps hashed names to files
+}
+
+// NewAssetsHandler creates a new static asset handler.
+func NewAssetsHandler(assets *services.Assets) *AssetsHandler {
+	return &AssetsHandler{assets: assets}
+}
+
+// Serve sends a static file.
+//
+// HTTP Method: GET
+// Route: /public/* (e.g., /public/css/styles.3f9a1c2b0d.css)
+//
+// Caching:
+//   - A name whose hash matches the file's content is cached for a year and
+//     marked immutable; the next version of the file has another name
+//   - Plain names, and hashes of an older version (pages cached before a
+//     deploy), get Cache-Control: no-cache, so browsers revalidate them with
+//     If-Modified-Since
+//
+// Error Handling:
+//   - Returns 404 for files that do not exist
+func (h *AssetsHandler) Serve(c echo.Context) error {
+	name, err := url.PathUnescape(c.Param("*"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	file, current := h.assets.Resolve(name)
+	if current {
+		c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Response().Header().Set("Cache-Control", "no-cache")
+	}
+	return c.File(file)
+}
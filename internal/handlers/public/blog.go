@@ -6,6 +6,7 @@ package public
 import (
 	// Standard library imports
 	"bytes"       // Buffer for template rendering to enable HTML caching
+	"context"     // Request context for series navigation lookups
 	"database/sql" // SQL error handling (sql.ErrNoRows for 404 detection)
 	"fmt"         // String formatting for cache keys and template data
 	"log/slog"    // Structured logging for debugging and error tracking
@@ -17,24 +18,26 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc"          // sqlc-generated database queries
-	"github.com/narendhupati/bluejay-cms/internal/services" // Cache service for HTML caching
+	"github.com/narendhupati/bluejay-cms/db/sqlc"            // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // SEO overrides for the page's content
+	"github.com/narendhupati/bluejay-cms/internal/services"   // Cache service for HTML caching
 )
 
 // BlogHandler handles all blog-related public routes including
 // the main blog listing, category filtering, and individual post pages.
 // It implements caching for improved performance on frequently accessed pages.
 type BlogHandler struct {
-	queries *sqlc.Queries    // Database query interface for blog posts and categories
-	logger  *slog.Logger     // Structured logger for errors and debugging
-	cache   *services.Cache  // In-memory cache for rendered HTML pages
+	queries sqlc.Querier                  // Database query interface for blog posts and categories
+	logger  *slog.Logger                  // Structured logger for errors and debugging
+	cache   *services.Cache               // In-memory cache for rendered HTML pages
+	related *services.RelatedPostsService // Scored related posts by shared tags/category
 }
 
 // NewBlogHandler creates a new BlogHandler with the required dependencies.
 // The cache is used to store rendered HTML to reduce database queries and
 // template rendering overhead for frequently accessed blog pages.
-func NewBlogHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *BlogHandler {
-	return &BlogHandler{queries: queries, logger: logger, cache: cache}
+func NewBlogHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *BlogHandler {
+	return &BlogHandler{queries: queries, logger: logger, cache: cache, related: services.NewRelatedPostsService(queries, cache)}
 }
 
 // renderAndCache is a helper method that renders a template to HTML,
@@ -81,13 +84,13 @@ func (h *BlogHandler) renderAndCache(c echo.Context, cacheKey string, ttlSeconds
 	// Render template to buffer for caching
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Cache the rendered HTML and return to client
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 	return c.HTML(statusCode, html)
 }
 
@@ -97,7 +100,8 @@ func (h *BlogHandler) renderAndCache(c echo.Context, cacheKey string, ttlSeconds
 // Route: /blog
 // Query Parameters: ?page=N (optional), ?category=slug (optional)
 // Template: public/pages/blog_listing.html (full page, not HTMX fragment)
-// HTMX: Returns complete HTML page
+// HTMX: Returns complete HTML page; its category tabs and pagination links
+// refresh only the grid through BlogListingGrid
 // Cache TTL: 300 seconds (5 minutes)
 //
 // Purpose:
@@ -115,6 +119,7 @@ func (h *BlogHandler) renderAndCache(c echo.Context, cacheKey string, ttlSeconds
 //   - CatPosts: []sqlc.ListPublishedPostsByCategoryRow - Category-filtered posts
 //   - FeaturedPost: sqlc.BlogPost - Featured post to highlight at top
 //   - Categories: []sqlc.BlogCategory - All blog categories for navigation
+//   - Archives: []blogArchiveYear - Month/year archive sidebar widget
 //   - CurrentCategory: string - Currently selected category slug (or empty)
 //   - CurrentPage: "blog" - For navigation highlighting
 //   - Page: int - Current page number
@@ -142,6 +147,25 @@ func (h *BlogHandler) renderAndCache(c echo.Context, cacheKey string, ttlSeconds
 //   - Cache key includes page number and category for granular invalidation
 //   - Cache is invalidated when new posts are published or categories change
 func (h *BlogHandler) BlogListing(c echo.Context) error {
+	return h.blogListing(c, false)
+}
+
+// BlogListingGrid handles GET requests for the blog grid fragment.
+//
+// HTTP Method: GET
+// Route: /partials/blog
+// Query Parameters: same as BlogListing
+// Template: public/partials/blog_grid.html (HTMX fragment)
+// HTMX: Swapped into #blog-grid by the category tabs and pagination links;
+// HX-Push-Url records the matching /blog URL in browser history
+// Cache TTL: 300 seconds (5 minutes), separate from the full page
+func (h *BlogHandler) BlogListingGrid(c echo.Context) error {
+	pushGridURL(c, "/blog")
+	return h.blogListing(c, true)
+}
+
+// blogListing renders the blog listing as a full page, or only its grid.
+func (h *BlogHandler) blogListing(c echo.Context, grid bool) error {
 	// Extract query parameters
 	pageStr := c.QueryParam("page")
 	categorySlug := c.QueryParam("category")
@@ -154,7 +178,12 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 
 	// Check cache for this specific page/category combination
 	cacheKey := fmt.Sprintf("page:blog:page:%d:category:%s", page, categorySlug)
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	templateName := "public/pages/blog_listing.html"
+	if grid {
+		cacheKey += gridCacheSuffix
+		templateName = "public/partials/blog_grid.html"
+	}
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -189,12 +218,21 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 	}
 
 	if err != nil {
-		h.logger.Error("failed to list blog posts", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list blog posts", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	// Fetch featured post to display prominently (errors ignored for graceful degradation)
-	featuredPost, _ := h.queries.GetFeaturedPost(ctx)
+	// Featured post and archive widget sit outside the grid, so the
+	// fragment skips them
+	var featuredPost sqlc.GetFeaturedPostRow
+	var archives []blogArchiveYear
+	if !grid {
+		// Fetch featured post to display prominently (errors ignored for graceful degradation)
+		featuredPost, _ = h.queries.GetFeaturedPost(ctx)
+
+		// Month/year archive widget for the sidebar
+		archives = h.archiveWidget(ctx)
+	}
 
 	// Fetch all categories for navigation/filtering UI
 	categories, _ := h.queries.ListBlogCategories(ctx)
@@ -214,18 +252,19 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 		"Page":            page,            // Current page number
 		"TotalPages":      totalPages,      // Total pages
 		"TotalCount":      totalCount,      // Total posts count
+		"Archives":        archives,        // Month/year archive widget
 	}
 
 	// Render template and cache for 5 minutes
-	// Template: templates/public/pages/blog_listing.html
-	return h.renderAndCache(c, cacheKey, 300, http.StatusOK, "public/pages/blog_listing.html", data)
+	// Template: templates/public/pages/blog_listing.html or its grid partial
+	return h.renderAndCache(c, cacheKey, 300, http.StatusOK, templateName, data)
 }
 
 // BlogPost handles GET requests to view individual blog posts.
 //
 // HTTP Method: GET
 // Route: /blog/:slug (e.g., /blog/introducing-our-new-sensor-lineup)
-// Query Parameters: ?preview=1 (optional, for admin preview)
+// Query Parameters: ?preview_token= (optional, signed preview link from the admin editor)
 // Template: public/pages/blog_post.html (full page, not HTMX fragment)
 // HTMX: Returns complete HTML page
 // Cache TTL: 600 seconds (10 minutes) for published posts, no cache for previews
@@ -244,7 +283,7 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 //   - slug: Unique slug identifier for the post (e.g., "new-product-announcement")
 //
 // Query Parameters:
-//   - preview: If "1", shows draft/unpublished posts for admin preview
+//   - preview_token: Signed, expiring token for this item; shows it even as a draft
 //
 // Template Data:
 //   - Title: Post title - Browser tab title
@@ -255,6 +294,11 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 //   - Post: sqlc.BlogPost - Core post data (title, content, author, date, etc.)
 //   - Tags: []string - Topic tags for the post
 //   - RelatedProducts: []sqlc.Product - Products mentioned/related to this post
+//   - RelatedPosts: []services.RelatedPost - Up to 3 posts ranked by shared tags/category
+//   - Series: *sqlc.BlogSeries - Series this post belongs to (nil if none)
+//   - SeriesPosts: []sqlc.ListPublishedPostsInSeriesRow - Published parts of the series in order
+//   - SeriesPrev/SeriesNext: *sqlc.ListPublishedPostsInSeriesRow - Adjacent parts (nil at the ends)
+//   - SeriesPart: int - 1-based position of this post within SeriesPosts
 //   - CurrentPage: "blog" - For navigation highlighting
 //   - IsPreview: bool - True if viewing in preview mode (only in preview)
 //   - EditURL: string - Admin edit URL (only in preview mode)
@@ -267,7 +311,7 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 //   - Tags provide topical signals for search engines
 //
 // Preview Mode:
-//   - When ?preview=1 is set, shows unpublished/draft posts
+//   - With a valid ?preview_token= for this item, shows it even as a draft
 //   - Bypasses published status check for admin review
 //   - Adds edit link for admin convenience
 //   - Disables caching to show live changes immediately
@@ -288,12 +332,12 @@ func (h *BlogHandler) BlogListing(c echo.Context) error {
 //   - Cache is invalidated when post is updated in admin
 func (h *BlogHandler) BlogPost(c echo.Context) error {
 	slug := c.Param("slug")
-	preview := isPreviewRequest(c) // Check if this is an admin preview request
+	previewItemID, preview := previewID(c, services.PreviewBlogPost) // Signed preview link for this content type?
 
 	// Skip cache lookup for preview mode to show live changes
 	if !preview {
-		cacheKey := fmt.Sprintf("page:blog:post:%s", slug)
-		if cached, ok := h.cache.Get(cacheKey); ok {
+		cacheKey := localizedCacheKey(c, fmt.Sprintf("page:blog:post:%s", slug))
+		if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 			return c.HTML(http.StatusOK, cached.(string))
 		}
 	}
@@ -302,7 +346,7 @@ func (h *BlogHandler) BlogPost(c echo.Context) error {
 
 	// Variables to store post data (extracted from different query result types)
 	var post interface{}
-	var postID int64
+	var postID, postCategoryID int64
 	var postTitle, postSlug, postMetaTitle, metaDesc string
 	var postOgImage string
 	var postMetaDesc sql.NullString
@@ -311,16 +355,24 @@ func (h *BlogHandler) BlogPost(c echo.Context) error {
 	if preview {
 		// Preview mode: include draft/unpublished posts
 		p, err := h.queries.GetPostBySlugIncludeDrafts(ctx, slug)
-		if err == sql.ErrNoRows {
+		if err == sql.ErrNoRows || (err == nil && p.ID != previewItemID) {
+			// Missing, or the token was issued for another post
 			return echo.NewHTTPError(http.StatusNotFound, "Post not found")
 		}
 		if err != nil {
-			h.logger.Error("failed to load blog post", "error", err)
+			h.logger.ErrorContext(ctx, "failed to load blog post", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
+		// Overlay translated title/excerpt/body when a non-default locale is requested
+		if tr := translationFor(c, "blog_post", p.ID); tr != nil {
+			p.Title = tr.Field("title", p.Title)
+			p.Excerpt = tr.Field("excerpt", p.Excerpt)
+			p.Body = tr.Field("body", p.Body)
+		}
 		// Extract fields from preview query result
 		post = p
 		postID = p.ID
+		postCategoryID = p.CategoryID
 		postTitle = p.Title
 		postSlug = p.Slug
 		postMetaTitle = p.MetaTitle
@@ -334,12 +386,19 @@ func (h *BlogHandler) BlogPost(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusNotFound, "Post not found")
 		}
 		if err != nil {
-			h.logger.Error("failed to load blog post", "error", err)
+			h.logger.ErrorContext(ctx, "failed to load blog post", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
+		// Overlay translated title/excerpt/body when a non-default locale is requested
+		if tr := translationFor(c, "blog_post", p.ID); tr != nil {
+			p.Title = tr.Field("title", p.Title)
+			p.Excerpt = tr.Field("excerpt", p.Excerpt)
+			p.Body = tr.Field("body", p.Body)
+		}
 		// Extract fields from published query result
 		post = p
 		postID = p.ID
+		postCategoryID = p.CategoryID
 		postTitle = p.Title
 		postSlug = p.Slug
 		postMetaTitle = p.MetaTitle
@@ -352,6 +411,15 @@ func (h *BlogHandler) BlogPost(c echo.Context) error {
 	tags, _ := h.queries.GetPostTagsByPostID(ctx, postID)                   // Topic tags for this post
 	relatedProducts, _ := h.queries.GetPostProductsByPostID(ctx, postID)    // Products mentioned in this post
 
+	// Related posts ranked by shared tags and category (see services.RelatedPostsWeights)
+	relatedPosts, err := h.related.Related(ctx, postID, postCategoryID, 3)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load related posts", "error", err)
+	}
+
+	// Series navigation (only for posts that belong to a multi-part series)
+	series, seriesPosts, seriesPrev, seriesNext, seriesPart := h.seriesNavigation(ctx, postID)
+
 	// Extract meta description with null-safety
 	metaDesc = ""
 	if postMetaDesc.Valid && postMetaDesc.String != "" {
@@ -368,8 +436,18 @@ func (h *BlogHandler) BlogPost(c echo.Context) error {
 		"Post":            post,                                   // Full post data
 		"Tags":            tags,                                   // Topic tags
 		"RelatedProducts": relatedProducts,                        // Related products
+		"RelatedPosts":    relatedPosts,                           // Scored related articles
+		"Series":          series,                                 // Series this post belongs to (nil if none)
+		"SeriesPosts":     seriesPosts,                            // Published parts in order
+		"SeriesPrev":      seriesPrev,                             // Previous part (nil on first)
+		"SeriesNext":      seriesNext,                             // Next part (nil on last)
+		"SeriesPart":      seriesPart,                             // 1-based position of this post
 		"CurrentPage":     "blog",                                 // For nav highlighting
 	}
+	setLang(c, data)
+
+	// Apply the Admin > SEO override for this post, if any
+	middleware.SetSEOEntity(c, services.PreviewBlogPost, postID)
 
 	// Handle preview mode
 	if preview {
@@ -381,5 +459,52 @@ func (h *BlogHandler) BlogPost(c echo.Context) error {
 
 	// Render and cache for 10 minutes (600 seconds)
 	// Template: templates/public/pages/blog_post.html
-	return h.renderAndCache(c, fmt.Sprintf("page:blog:post:%s", slug), 600, http.StatusOK, "public/pages/blog_post.html", data)
+	return h.renderAndCache(c, localizedCacheKey(c, fmt.Sprintf("page:blog:post:%s", slug)), 600, http.StatusOK, "public/pages/blog_post.html", data)
+}
+
+// seriesNavigation loads the series a post belongs to together with its
+// published parts and the parts immediately before and after the post.
+//
+// Parameters:
+//   - ctx: request context
+//   - postID: the post being rendered
+//
+// Returns:
+//   - series: the post's series, or nil when it is not part of one
+//   - parts: published parts in series order
+//   - prev, next: adjacent parts, nil at either end of the series
+//   - part: 1-based position of the post within parts (0 if not listed,
+//     e.g. a draft viewed in preview mode)
+//
+// Errors are logged and treated as "no series" so a broken lookup never
+// prevents the post itself from rendering.
+func (h *BlogHandler) seriesNavigation(ctx context.Context, postID int64) (series *sqlc.BlogSeries, parts []sqlc.ListPublishedPostsInSeriesRow, prev, next *sqlc.ListPublishedPostsInSeriesRow, part int) {
+	s, err := h.queries.GetPublishedPostSeries(ctx, postID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			h.logger.ErrorContext(ctx, "failed to load post series", "error", err)
+		}
+		return nil, nil, nil, nil, 0
+	}
+
+	parts, err = h.queries.ListPublishedPostsInSeries(ctx, sql.NullInt64{Int64: s.ID, Valid: true})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load series posts", "error", err)
+		return nil, nil, nil, nil, 0
+	}
+
+	for i := range parts {
+		if parts[i].ID != postID {
+			continue
+		}
+		part = i + 1
+		if i > 0 {
+			prev = &parts[i-1]
+		}
+		if i < len(parts)-1 {
+			next = &parts[i+1]
+		}
+		break
+	}
+	return &s, parts, prev, next, part
 }
@@ -0,0 +1,178 @@
+// Package public provides HTTP handlers for public-facing website pages.
+// This file implements the month/year blog archive: the /blog/:year/:month
+// pages, the archive sidebar widget data, and archive entries for the sitemap.
+package public
+
+import (
+	// Standard library imports
+	"context"      // Request-scoped context for database queries
+	"database/sql" // Nullable publish dates
+	"fmt"          // Archive URLs and cache keys
+	"math"         // Ceiling division for pagination
+	"net/http"     // HTTP status codes
+	"regexp"       // Year/month route parameter validation
+	"strconv"      // Page and year parsing
+	"time"         // Month names and boundaries
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Site timezone for month boundaries
+)
+
+// Archive route parameters must be canonical: a four-digit year and a
+// two-digit month, so each archive bucket has exactly one URL.
+var (
+	archiveYearPattern  = regexp.MustCompile(`^\d{4}$`)
+	archiveMonthPattern = regexp.MustCompile(`^(0[1-9]|1[0-2])$`)
+)
+
+// blogArchiveMonth is one month with published posts.
+type blogArchiveMonth struct {
+	Year          int        // e.g. 2024
+	Month         time.Month // e.g. time.May
+	Count         int64      // Published posts in the month
+	LastPublished string     // Newest publish date in the month (YYYY-MM-DD)
+}
+
+// URL returns the archive page path, e.g. /blog/2024/05.
+func (m blogArchiveMonth) URL() string {
+	return fmt.Sprintf("/blog/%04d/%02d", m.Year, int(m.Month))
+}
+
+// Label returns the display name, e.g. "May 2024".
+func (m blogArchiveMonth) Label() string {
+	return fmt.Sprintf("%s %d", m.Month, m.Year)
+}
+
+// blogArchiveYear groups archive months under a year heading for the widget.
+type blogArchiveYear struct {
+	Year   int                // e.g. 2024
+	Count  int64              // Published posts in the year
+	Months []blogArchiveMonth // Newest month first
+}
+
+// archiveMonths buckets ListBlogArchiveDates rows (newest first) into months
+// of the site timezone, so a post is filed under the month its date shows.
+func archiveMonths(dates []sql.NullTime) []blogArchiveMonth {
+	var months []blogArchiveMonth
+	for _, d := range dates {
+		if !d.Valid {
+			continue
+		}
+		t := services.InSiteTimezone(d.Time)
+		if n := len(months); n == 0 || months[n-1].Year != t.Year() || months[n-1].Month != t.Month() {
+			months = append(months, blogArchiveMonth{Year: t.Year(), Month: t.Month(), LastPublished: t.Format("2006-01-02")})
+		}
+		months[len(months)-1].Count++
+	}
+	return months
+}
+
+// archiveYears groups months (newest first) by year for the sidebar widget.
+func archiveYears(months []blogArchiveMonth) []blogArchiveYear {
+	var years []blogArchiveYear
+	for _, m := range months {
+		if len(years) == 0 || years[len(years)-1].Year != m.Year {
+			years = append(years, blogArchiveYear{Year: m.Year})
+		}
+		y := &years[len(years)-1]
+		y.Count += m.Count
+		y.Months = append(y.Months, m)
+	}
+	return years
+}
+
+// archiveWidget loads the month/year widget data. Errors are logged and an
+// empty widget is returned, so the blog pages still render.
+func (h *BlogHandler) archiveWidget(ctx context.Context) []blogArchiveYear {
+	dates, err := h.queries.ListBlogArchiveDates(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list blog archive months", "error", err)
+		return nil
+	}
+	return archiveYears(archiveMonths(dates))
+}
+
+// BlogArchive handles GET requests to a month archive page.
+//
+// HTTP Method: GET
+// Route: /blog/:year/:month (e.g., /blog/2024/05)
+// Query Parameters: ?page=N (optional)
+// Template: public/pages/blog_archive.html (full page)
+// Cache TTL: 300 seconds (5 minutes), one entry per month and page
+//
+// Months run from midnight on the 1st in the site timezone. Non-canonical parameters
+// (e.g. /blog/2024/5) and months without published posts return 404.
+//
+// Template Data:
+//   - Title: "May 2024 Archive"
+//   - Archive: blogArchiveMonth - The month being shown
+//   - Archives: []blogArchiveYear - Sidebar widget data
+//   - Posts: []sqlc.ListPublishedPostsByMonthRow - Posts in the month, newest first
+//   - Page, TotalPages, TotalCount: Pagination
+func (h *BlogHandler) BlogArchive(c echo.Context) error {
+	yearStr, monthStr := c.Param("year"), c.Param("month")
+	if !archiveYearPattern.MatchString(yearStr) || !archiveMonthPattern.MatchString(monthStr) {
+		return echo.NewHTTPError(http.StatusNotFound, "Archive not found")
+	}
+	bucket := yearStr + "-" + monthStr
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	// Each month/page combination is cached separately; admin blog changes
+	// clear every "page:blog" key, archives included
+	cacheKey := fmt.Sprintf("page:blog:archive:%s:page:%d", bucket, page)
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+
+	year, _ := strconv.Atoi(yearStr)
+	month, _ := strconv.Atoi(monthStr)
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, services.SiteLocation())
+	bounds := sqlc.CountPublishedPostsByMonthParams{
+		MonthStart: start.UTC().Format(services.JobClockLayout),
+		MonthEnd:   start.AddDate(0, 1, 0).UTC().Format(services.JobClockLayout),
+	}
+
+	ctx := c.Request().Context()
+	totalCount, err := h.queries.CountPublishedPostsByMonth(ctx, bounds)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count archive posts", "bucket", bucket, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if totalCount == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Archive not found")
+	}
+
+	limit := int64(9) // Same 3x3 grid as the main listing
+	posts, err := h.queries.ListPublishedPostsByMonth(ctx, sqlc.ListPublishedPostsByMonthParams{
+		MonthStart: bounds.MonthStart,
+		MonthEnd:   bounds.MonthEnd,
+		Limit:      limit,
+		Offset:     int64(page-1) * limit,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list archive posts", "bucket", bucket, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	archive := blogArchiveMonth{Year: year, Month: time.Month(month), Count: totalCount}
+
+	data := map[string]interface{}{
+		"Title":       archive.Label() + " Archive",
+		"Archive":     archive,
+		"Archives":    h.archiveWidget(ctx),
+		"Posts":       posts,
+		"CurrentPage": "blog",
+		"Page":        page,
+		"TotalPages":  int(math.Ceil(float64(totalCount) / float64(limit))),
+		"TotalCount":  totalCount,
+	}
+	return h.renderAndCache(c, cacheKey, 300, http.StatusOK, "public/pages/blog_archive.html", data)
+}
@@ -0,0 +1,191 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the careers pages: the /careers listing of open
+// positions and job postings with their apply form.
+package public
+
+import (
+	// Standard library imports
+	"bytes"         // Rendering into a buffer
+	"database/sql"  // sql.ErrNoRows for 404 detection
+	"errors"        // Application error inspection
+	"html/template" // Structured data marked safe for the page head
+	"log/slog"      // Structured logging for errors
+	"net/http"      // HTTP status codes
+	"strings"       // Trimming form values and building absolute URLs
+	"time"          // Closing dates
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Open postings, applications and Google Jobs data
+)
+
+// careersPageTTL caches careers pages for five minutes: postings close by
+// the clock rather than by an edit.
+const careersPageTTL = 300
+
+// CareersHandler serves /careers and the pages and apply forms of published
+// job postings.
+type CareersHandler struct {
+	queries sqlc.Querier      // Database query interface for job postings
+	careers *services.Careers // Open postings and applications
+	logger  *slog.Logger      // Structured logger for errors
+	cache   *services.Cache   // In-memory cache for rendered pages
+	baseURL string            // Public site URL for the structured data
+}
+
+// NewCareersHandler creates a new CareersHandler with the required dependencies.
+func NewCareersHandler(queries sqlc.Querier, careers *services.Careers, logger *slog.Logger, cache *services.Cache, baseURL string) *CareersHandler {
+	return &CareersHandler{queries: queries, careers: careers, logger: logger, cache: cache, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// job loads the published posting named by the :slug parameter.
+func (h *CareersHandler) job(c echo.Context) (sqlc.JobPosting, error) {
+	job, err := h.queries.GetPublishedJobPostingBySlug(c.Request().Context(), c.Param("slug"))
+	if err == sql.ErrNoRows {
+		return job, echo.NewHTTPError(http.StatusNotFound, "Job not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load job posting", "error", err, "slug", c.Param("slug"))
+		return job, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return job, nil
+}
+
+// List handles GET /careers
+// Renders the open positions by department. Postings past their closing
+// date are left out.
+//
+// Template: public/pages/careers.html (full page)
+// Cache: 5 minutes under "page:careers"
+func (h *CareersHandler) List(c echo.Context) error {
+	if cached, ok := cachedPage(c, h.cache, "page:careers"); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	jobs, err := h.careers.Open(ctx, time.Now())
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list open job postings", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return renderCachedPage(c, h.cache, h.logger, "page:careers", careersPageTTL, "public/pages/careers.html", map[string]interface{}{
+		"Title":           "Careers",
+		"MetaDescription": "Open positions. Join the team.",
+		"CanonicalURL":    "/careers",
+		"CurrentPage":     "careers",
+		"Jobs":            jobs,
+		"TypeLabels":      services.JobEmploymentTypeLabels,
+	})
+}
+
+// Detail handles GET /careers/:slug
+// Renders a job posting with its apply form and the JobPosting structured
+// data Google Jobs reads. Once the posting closes the page says so, without
+// the form or the structured data.
+//
+// Template: public/pages/career_detail.html (full page)
+// Cache: 5 minutes under "page:careers:<slug>"
+func (h *CareersHandler) Detail(c echo.Context) error {
+	cacheKey := "page:careers:" + c.Param("slug")
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	job, err := h.job(c)
+	if err != nil {
+		return err
+	}
+
+	open := services.JobOpen(job, time.Now())
+	metaDesc := job.MetaDescription
+	if metaDesc == "" {
+		metaDesc = job.Summary
+	}
+	data := map[string]interface{}{
+		"Title":           job.Title,
+		"MetaDescription": metaDesc,
+		"CanonicalURL":    services.JobPath(job),
+		"CurrentPage":     "careers",
+		"Job":             job,
+		"Paragraphs":      services.JobParagraphs(job.Description),
+		"Open":            open,
+		"TypeLabels":      services.JobEmploymentTypeLabels,
+		"CVExtensions":    strings.Join(services.CVExtensions, ","),
+	}
+	if open {
+		orgName, logoURL := "", ""
+		if settings, ok := c.Get("settings").(sqlc.Setting); ok {
+			orgName = settings.SiteName
+			if settings.HeaderLogoPath != "" {
+				logoURL = h.baseURL + "/" + strings.TrimPrefix(settings.HeaderLogoPath, "/")
+			}
+		}
+		ld, err := services.JobPostingJSONLD(job, h.baseURL, orgName, logoURL)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to build job posting structured data", "error", err, "id", job.ID)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		data["StructuredData"] = template.JS(ld)
+	}
+	return renderCachedPage(c, h.cache, h.logger, cacheKey, careersPageTTL, "public/pages/career_detail.html", data)
+}
+
+// Apply handles POST /careers/:slug/apply
+// Records an application with its CV (HTMX, multipart) and replies with a
+// fragment that replaces the form. Errors go to the status line under the
+// form (HX-Retarget), leaving the fields for the applicant to correct.
+//
+// Form Fields: name, email, cv (required), phone, cover_letter
+//
+// Template: public/partials/job_applied.html (HTMX fragment)
+func (h *CareersHandler) Apply(c echo.Context) error {
+	ctx := c.Request().Context()
+	job, err := h.job(c)
+	if err != nil {
+		return err
+	}
+
+	in := services.JobApplicationInput{
+		Name:        strings.TrimSpace(c.FormValue("name")),
+		Email:       strings.TrimSpace(c.FormValue("email")),
+		Phone:       strings.TrimSpace(c.FormValue("phone")),
+		CoverLetter: strings.TrimSpace(c.FormValue("cover_letter")),
+		IPAddress:   c.RealIP(),
+	}
+	if in.Name == "" || in.Email == "" {
+		c.Response().Header().Set("HX-Retarget", "#job-application-status")
+		return c.HTML(http.StatusOK, `Name and email are required.`)
+	}
+	if cv, err := c.FormFile("cv"); err == nil {
+		in.CV = cv
+	}
+
+	_, err = h.careers.Apply(ctx, job, in)
+	switch {
+	case errors.Is(err, services.ErrJobApplicationInvalidEmail), errors.Is(err, services.ErrCVMissing),
+		errors.Is(err, services.ErrCVType), errors.Is(err, services.ErrCVTooLarge):
+		c.Response().Header().Set("HX-Retarget", "#job-application-status")
+		msg := err.Error()
+		return c.HTML(http.StatusOK, strings.ToUpper(msg[:1])+msg[1:]+`.`)
+	case errors.Is(err, services.ErrJobClosed):
+		c.Response().Header().Set("HX-Retarget", "#job-application-status")
+		return c.HTML(http.StatusOK, `Sorry, `+err.Error()+`.`)
+	case err != nil:
+		h.logger.ErrorContext(ctx, "failed to record job application", "error", err, "id", job.ID)
+		c.Response().Header().Set("HX-Retarget", "#job-application-status")
+		return c.HTML(http.StatusOK, `Something went wrong. Please try again later.`)
+	}
+
+	var buf bytes.Buffer
+	err = c.Echo().Renderer.Render(&buf, "public/partials/job_applied.html", map[string]interface{}{
+		"Job":   job,
+		"Email": in.Email,
+	}, c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "template render failed", "template", "public/partials/job_applied.html", "error", err)
+		return err
+	}
+	return c.HTML(http.StatusOK, buf.String())
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/labstack/echo/v4"
 	// sqlc provides type-safe database query interfaces generated from SQL files
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	// middleware provides SetSEOEntity, which names the page's content for SEO overrides
+	"github.com/narendhupati/bluejay-cms/internal/middleware"
 	// services provides business logic components like caching
 	"github.com/narendhupati/bluejay-cms/internal/services"
 )
@@ -30,14 +32,14 @@ import (
 // It manages both the case studies listing page (with optional industry filtering)
 // and individual case study detail pages (with preview mode for admins).
 type CaseStudiesHandler struct {
-	queries *sqlc.Queries    // Database query interface for fetching case studies, industries, products, and metrics
-	logger  *slog.Logger     // Structured logger for debugging and error tracking
-	cache   *services.Cache  // In-memory cache for rendered HTML to improve response times
+	queries sqlc.Querier    // Database query interface for fetching case studies, industries, products, and metrics
+	logger  *slog.Logger    // Structured logger for debugging and error tracking
+	cache   *services.Cache // In-memory cache for rendered HTML to improve response times
 }
 
 // NewCaseStudiesHandler constructs a new CaseStudiesHandler with required dependencies.
 // This constructor is called during application initialization to wire up dependencies.
-func NewCaseStudiesHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *CaseStudiesHandler {
+func NewCaseStudiesHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *CaseStudiesHandler {
 	return &CaseStudiesHandler{
 		queries: queries,
 		logger:  logger,
@@ -79,13 +81,13 @@ func (h *CaseStudiesHandler) renderAndCache(c echo.Context, cacheKey string, ttl
 	// This allows us to cache the rendered HTML before sending it
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Extract rendered HTML from buffer and store in cache for future requests
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 
 	// Return the rendered HTML to the client with specified status code
 	return c.HTML(statusCode, html)
@@ -99,8 +101,8 @@ func (h *CaseStudiesHandler) renderAndCache(c echo.Context, cacheKey string, ttl
 // Cache: 600 seconds (10 minutes) - case studies content is relatively static
 //
 // HTMX Behavior: This endpoint returns a full HTML page, not an HTMX fragment.
-// It is designed for direct browser navigation. However, the industry filter dropdown
-// may use HTMX to reload the page content when selection changes.
+// It is designed for direct browser navigation. The industry filter dropdown
+// refreshes only the grid through CaseStudiesListGrid.
 //
 // Query Parameters:
 //   - industry (optional): Integer ID of industry to filter by (e.g., ?industry=3)
@@ -110,6 +112,25 @@ func (h *CaseStudiesHandler) renderAndCache(c echo.Context, cacheKey string, ttl
 //
 // Returns: HTTP 200 with rendered case_studies.html template, or HTTP 400 if industry param is invalid
 func (h *CaseStudiesHandler) CaseStudiesList(c echo.Context) error {
+	return h.caseStudiesList(c, false)
+}
+
+// CaseStudiesListGrid handles GET requests to /partials/case-studies
+// Renders only the filter bar and grid of the /case-studies page.
+//
+// Route: GET /partials/case-studies (same ?industry= parameter as CaseStudiesList)
+// Template: public/partials/case_studies_grid.html (HTMX fragment)
+// Cache: 600 seconds (10 minutes), separate from the full page
+//
+// HTMX Behavior: The filter dropdown swaps the response into #case-studies-grid;
+// HX-Push-Url records the matching /case-studies URL in browser history.
+func (h *CaseStudiesHandler) CaseStudiesListGrid(c echo.Context) error {
+	pushGridURL(c, "/case-studies")
+	return h.caseStudiesList(c, true)
+}
+
+// caseStudiesList renders the listing as a full page, or only its filter bar and grid.
+func (h *CaseStudiesHandler) caseStudiesList(c echo.Context, grid bool) error {
 	// Extract request context for passing to database queries
 	ctx := c.Request().Context()
 
@@ -125,7 +146,7 @@ func (h *CaseStudiesHandler) CaseStudiesList(c echo.Context) error {
 		selectedIndustryID, err = strconv.ParseInt(industryParam, 10, 64)
 		if err != nil {
 			// Invalid industry ID format - return 400 error
-			h.logger.Error("invalid industry parameter", "error", err)
+			h.logger.ErrorContext(ctx, "invalid industry parameter", "error", err)
 			return echo.NewHTTPError(http.StatusBadRequest, "Invalid industry parameter")
 		}
 	}
@@ -137,8 +158,14 @@ func (h *CaseStudiesHandler) CaseStudiesList(c echo.Context) error {
 		cacheKey = fmt.Sprintf("page:case-studies:industry:%d", selectedIndustryID)
 	}
 
+	templateName := "public/pages/case_studies.html"
+	if grid {
+		cacheKey += gridCacheSuffix
+		templateName = "public/partials/case_studies_grid.html"
+	}
+
 	// Check if cached version exists and return it immediately
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -148,26 +175,26 @@ func (h *CaseStudiesHandler) CaseStudiesList(c echo.Context) error {
 		// Filtered view: only case studies for selected industry
 		caseStudies, err = h.queries.ListCaseStudiesByIndustry(ctx, selectedIndustryID)
 		if err != nil {
-			h.logger.Error("failed to list case studies by industry", "error", err)
+			h.logger.ErrorContext(ctx, "failed to list case studies by industry", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Get count for display (e.g., "Showing 5 case studies")
 		totalCount, err = h.queries.CountCaseStudiesByIndustry(ctx, selectedIndustryID)
 		if err != nil {
-			h.logger.Error("failed to count case studies by industry", "error", err)
+			h.logger.ErrorContext(ctx, "failed to count case studies by industry", "error", err)
 			totalCount = 0 // Graceful degradation - continue without count
 		}
 	} else {
 		// Unfiltered view: all published case studies
 		caseStudies, err = h.queries.ListCaseStudies(ctx)
 		if err != nil {
-			h.logger.Error("failed to list case studies", "error", err)
+			h.logger.ErrorContext(ctx, "failed to list case studies", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Get total count of all case studies
 		totalCount, err = h.queries.CountCaseStudies(ctx)
 		if err != nil {
-			h.logger.Error("failed to count case studies", "error", err)
+			h.logger.ErrorContext(ctx, "failed to count case studies", "error", err)
 			totalCount = 0 // Graceful degradation
 		}
 	}
@@ -176,7 +203,7 @@ func (h *CaseStudiesHandler) CaseStudiesList(c echo.Context) error {
 	// Dropdown allows users to filter case studies by industry
 	industries, err := h.queries.ListIndustries(ctx)
 	if err != nil {
-		h.logger.Error("failed to list industries", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list industries", "error", err)
 		industries = []sqlc.Industry{} // Default to empty slice
 	}
 
@@ -191,38 +218,61 @@ func (h *CaseStudiesHandler) CaseStudiesList(c echo.Context) error {
 	}
 
 	// Render template and cache for 10 minutes, return HTML to client
-	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, "public/pages/case_studies.html", data)
+	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, templateName, data)
 }
 
 // CaseStudyDetail handles GET requests to /case-studies/:slug
 // Renders an individual case study detail page with full content, products, and metrics.
 // Supports preview mode for admins to view draft case studies before publishing.
 //
-// Route: GET /case-studies/:slug (with optional ?preview=true query parameter for admins)
+// Route: GET /case-studies/:slug (with optional ?preview_token= from the admin editor)
 // Template: templates/public/pages/case_study_detail.html (full page, not HTMX fragment)
 // Cache: 1800 seconds (30 minutes) for published, 0 seconds for preview mode
 //
 // HTMX Behavior: This endpoint returns a full HTML page, not an HTMX fragment.
 // It is designed for direct browser navigation, not HTMX swaps.
 //
-// Preview Mode: When ?preview=true is in query string (checked by isPreviewRequest helper),
-// the handler fetches draft case studies and skips caching. This allows admins to preview
-// unpublished content before making it live. Preview mode adds edit link to the page.
+// Preview Mode: When ?preview_token= holds a valid token for this item (see previewID),
+// the handler fetches it even as a draft and skips caching. Tokens are signed, expire,
+// and are issued on the admin edit page, so editors can share the link with reviewers
+// before publishing. Preview mode adds edit link to the page.
 //
 // Related Content: Fetches associated products and success metrics to display alongside
 // the case study narrative. Challenge bullets are stored as JSON array and parsed here.
 //
 // Returns: HTTP 200 with rendered case_study_detail.html, or HTTP 404 if slug not found
 func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
+	return h.caseStudyDetail(c, false)
+}
+
+// CaseStudyPrint handles GET requests to /case-studies/:slug/print
+// Renders the case study for printing and PDF generation, without site
+// navigation, scripts or the Tailwind runtime.
+//
+// Template: templates/public/print/case_study_detail.html (print layout)
+// Cache: 1800 seconds, keyed apart from the case study page
+func (h *CaseStudiesHandler) CaseStudyPrint(c echo.Context) error {
+	preparePrint(c)
+	return h.caseStudyDetail(c, true)
+}
+
+// caseStudyDetail renders a case study page, or its print version.
+func (h *CaseStudiesHandler) caseStudyDetail(c echo.Context, forPrint bool) error {
 	// Extract slug from URL path parameter (e.g., /case-studies/acme-corp-success)
 	slug := c.Param("slug")
-	// Check if this is a preview request (admins viewing draft content)
-	preview := isPreviewRequest(c)
+	// Check for a signed preview link (drafts shared from the admin editor)
+	previewItemID, preview := previewID(c, services.PreviewCaseStudy)
+
+	cacheKey := fmt.Sprintf("page:case-studies:%s", slug)
+	templateName := "public/pages/case_study_detail.html"
+	if forPrint {
+		cacheKey += printCacheSuffix
+		templateName = "public/print/case_study_detail.html"
+	}
 
 	// Skip cache check for preview mode - always fetch fresh data for admins
 	if !preview {
-		cacheKey := fmt.Sprintf("page:case-studies:%s", slug)
-		if cached, ok := h.cache.Get(cacheKey); ok {
+		if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 			return c.HTML(http.StatusOK, cached.(string))
 		}
 	}
@@ -241,11 +291,12 @@ func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
 		// Preview mode: include draft case studies (not yet published)
 		// This allows admins to see how content will look before publishing
 		cs, err := h.queries.GetCaseStudyBySlugIncludeDrafts(ctx, slug)
-		if err == sql.ErrNoRows {
+		if err == sql.ErrNoRows || (err == nil && cs.ID != previewItemID) {
+			// Missing, or the token was issued for another case study
 			return echo.NewHTTPError(http.StatusNotFound, "Case study not found")
 		}
 		if err != nil {
-			h.logger.Error("failed to load case study", "error", err)
+			h.logger.ErrorContext(ctx, "failed to load case study", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Extract fields from query result
@@ -259,7 +310,7 @@ func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusNotFound, "Case study not found")
 		}
 		if err != nil {
-			h.logger.Error("failed to load case study", "error", err)
+			h.logger.ErrorContext(ctx, "failed to load case study", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Extract fields from query result
@@ -272,7 +323,7 @@ func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
 	// Shows which company products/services were used to achieve the results
 	products, err := h.queries.GetCaseStudyProducts(ctx, csID)
 	if err != nil {
-		h.logger.Error("failed to load case study products", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load case study products", "error", err)
 		products = []sqlc.GetCaseStudyProductsRow{} // Default to empty slice
 	}
 
@@ -280,7 +331,7 @@ func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
 	// Metrics show quantifiable results (e.g., "50% increase in sales", "2x faster processing")
 	metrics, err := h.queries.GetCaseStudyMetrics(ctx, csID)
 	if err != nil {
-		h.logger.Error("failed to load case study metrics", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load case study metrics", "error", err)
 		metrics = []sqlc.GetCaseStudyMetricsRow{} // Default to empty slice
 	}
 
@@ -289,7 +340,7 @@ func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
 	var challengeBullets []string
 	if csBullets.Valid {
 		if err := json.Unmarshal([]byte(csBullets.String), &challengeBullets); err != nil {
-			h.logger.Error("failed to parse challenge bullets", "error", err)
+			h.logger.ErrorContext(ctx, "failed to parse challenge bullets", "error", err)
 			challengeBullets = []string{} // Fallback to empty array on parse error
 		}
 	} else {
@@ -320,16 +371,20 @@ func (h *CaseStudiesHandler) CaseStudyDetail(c echo.Context) error {
 		"Metrics":          metrics,           // Array of success metric objects
 		"ChallengeBullets": challengeBullets,  // Array of challenge bullet strings
 		"CurrentPage":      "case-studies",    // Used by navigation to highlight active link
+		"PrintLabel":       "Case Study",      // Print layout header label
 	}
 
+	// Apply the Admin > SEO override for this case study, if any
+	middleware.SetSEOEntity(c, services.PreviewCaseStudy, csID)
+
 	// Handle preview mode differently - no caching and add admin edit link
 	if preview {
 		data["IsPreview"] = true // Shows preview banner in template
 		data["EditURL"] = fmt.Sprintf("/admin/case-studies/%d/edit", csID) // Link to admin editor
 		// No caching (ttl=0) for preview mode - always fresh content for admins
-		return h.renderAndCache(c, "preview:case-study:"+slug, 0, http.StatusOK, "public/pages/case_study_detail.html", data)
+		return h.renderAndCache(c, "preview:case-study:"+slug, 0, http.StatusOK, templateName, data)
 	}
 
 	// Normal mode: cache for 30 minutes since case study content rarely changes
-	return h.renderAndCache(c, fmt.Sprintf("page:case-studies:%s", slug), 1800, http.StatusOK, "public/pages/case_study_detail.html", data)
+	return h.renderAndCache(c, cacheKey, 1800, http.StatusOK, templateName, data)
 }
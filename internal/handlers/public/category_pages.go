@@ -0,0 +1,190 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file contains pagination helpers for product category pages shared by
+// the category handlers, the sitemap, and the cache warmer.
+package public
+
+import (
+	// Standard library imports
+	"context" // Request context for loading the category tree
+	"fmt"     // Page URLs and cache key suffixes
+	"net/url" // Filter query strings
+	"strconv" // Page query parameter parsing
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - query parameters
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Category hierarchy
+)
+
+// productsPerCategoryPage is the number of products on one category page.
+const productsPerCategoryPage = 12
+
+// MaxIndexedCategoryPages caps how many pages of each category are listed in
+// the sitemap and pre-rendered by the cache warmer. Deeper pages are still
+// served, just not advertised or warmed.
+const MaxIndexedCategoryPages = 10
+
+// requestPage returns the ?page= number, or 1 when it is missing or invalid.
+func requestPage(c echo.Context) int {
+	if page, err := strconv.Atoi(c.QueryParam("page")); err == nil && page > 0 {
+		return page
+	}
+	return 1
+}
+
+// pageCacheSuffix returns the cache key suffix for the requested page:
+// empty for page 1, so existing keys are unchanged, and ":page:N" otherwise.
+func pageCacheSuffix(c echo.Context) string {
+	if page := requestPage(c); page > 1 {
+		return fmt.Sprintf(":page:%d", page)
+	}
+	return ""
+}
+
+// categoryPageCount returns how many pages a category with total products has
+// (at least 1, so empty categories still have their first page).
+func categoryPageCount(total int64) int {
+	if total <= 0 {
+		return 1
+	}
+	return int((total + productsPerCategoryPage - 1) / productsPerCategoryPage)
+}
+
+// pageURL returns base for page 1 and base?page=N for later pages.
+func pageURL(base string, page int) string {
+	if page <= 1 {
+		return base
+	}
+	return fmt.Sprintf("%s?page=%d", base, page)
+}
+
+// filterCacheSuffix returns the cache key suffix for the requested filters:
+// empty without filters, and ":f:" plus the normalised filters otherwise, so
+// every filter and page combination is cached separately.
+func filterCacheSuffix(c echo.Context) string {
+	if filters := services.FacetFilters(c.QueryParams()); len(filters) > 0 {
+		return ":f:" + filters.Encode()
+	}
+	return ""
+}
+
+// sortParam returns the ?sort= value if it is a known sort order, else "".
+func sortParam(c echo.Context) string {
+	if sort := c.QueryParam("sort"); services.ValidProductSort(sort) {
+		return sort
+	}
+	return ""
+}
+
+// listingSort returns the product order for a listing: ?sort= when valid,
+// otherwise the products_default_sort setting.
+func listingSort(c echo.Context) string {
+	var siteDefault string
+	if settings, ok := c.Get("settings").(sqlc.Setting); ok {
+		siteDefault = settings.ProductsDefaultSort
+	}
+	return services.ResolveProductSort(c.QueryParam("sort"), siteDefault)
+}
+
+// sortCacheSuffix returns the cache key suffix for an explicit sort order:
+// empty without ?sort= (the site default, purged with the settings), and
+// ":s:" plus the order otherwise.
+func sortCacheSuffix(c echo.Context) string {
+	if sort := sortParam(c); sort != "" {
+		return ":s:" + sort
+	}
+	return ""
+}
+
+// filterPageURL returns the URL of a page of a filtered listing: base with
+// the filters, the explicit sort order (if any) and, after page 1, the page
+// number.
+func filterPageURL(base string, filters url.Values, sort string, page int) string {
+	q := url.Values{}
+	for param, values := range filters {
+		q[param] = values
+	}
+	if sort != "" {
+		q.Set("sort", sort)
+	}
+	if page > 1 {
+		q.Set("page", strconv.Itoa(page))
+	}
+	if len(q) == 0 {
+		return base
+	}
+	return base + "?" + q.Encode()
+}
+
+// loadCategoryTree loads the product category hierarchy. With counts, each
+// category's published product count is loaded in the same query so totals
+// roll up to parents.
+func loadCategoryTree(ctx context.Context, queries sqlc.Querier, withCounts bool) (*services.CategoryTree, error) {
+	if !withCounts {
+		categories, err := queries.ListProductCategories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return services.BuildCategoryTree(categories, nil), nil
+	}
+	rows, err := queries.ListProductCategoriesWithCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories := make([]sqlc.ProductCategory, len(rows))
+	counts := make(map[int64]int64, len(rows))
+	for i, row := range rows {
+		categories[i] = row.ProductCategory
+		counts[row.ProductCategory.ID] = row.PublishedCount
+	}
+	return services.BuildCategoryTree(categories, counts), nil
+}
+
+// CategoryPage is one page of a product category listing.
+type CategoryPage struct {
+	Node *services.CategoryNode // Category shown on the page
+	Page int                    // 1-based page number
+	Path string                 // Site-relative URL, e.g. /products/sensors?page=2
+}
+
+// CategoryPages returns every product category page up to
+// MaxIndexedCategoryPages per category, in category display order.
+//
+// Parameters:
+//   - ctx: Context for the database queries
+//   - queries: Database queries for categories and product counts
+//
+// Returns:
+//   - []CategoryPage: Pages of every category
+//   - error: Database error loading the categories
+func CategoryPages(ctx context.Context, queries sqlc.Querier) ([]CategoryPage, error) {
+	tree, err := loadCategoryTree(ctx, queries, true)
+	if err != nil {
+		return nil, err
+	}
+	var pages []CategoryPage
+	for _, node := range tree.Flatten() {
+		last := min(categoryPageCount(node.Total), MaxIndexedCategoryPages)
+		for page := 1; page <= last; page++ {
+			pages = append(pages, CategoryPage{Node: node, Page: page, Path: pageURL(node.URL(), page)})
+		}
+	}
+	return pages, nil
+}
+
+// CategoryPageURLs returns the paths of CategoryPages, for the cache warmer.
+func CategoryPageURLs(queries sqlc.Querier) func(ctx context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		pages, err := CategoryPages(ctx, queries)
+		if err != nil {
+			return nil, err
+		}
+		paths := make([]string, len(pages))
+		for i, p := range pages {
+			paths[i] = p.Path
+		}
+		return paths, nil
+	}
+}
@@ -25,14 +25,14 @@ import (
 // ContactHandler handles HTTP requests for the Contact Us page and form submissions.
 // It manages displaying office locations and processing contact form submissions with validation.
 type ContactHandler struct {
-	queries *sqlc.Queries    // Database query interface for fetching office locations and storing submissions
-	logger  *slog.Logger     // Structured logger for debugging and error tracking
-	cache   *services.Cache  // In-memory cache for rendered HTML to improve response times
+	queries sqlc.Querier    // Database query interface for fetching office locations and storing submissions
+	logger  *slog.Logger    // Structured logger for debugging and error tracking
+	cache   *services.Cache // In-memory cache for rendered HTML to improve response times
 }
 
 // NewContactHandler constructs a new ContactHandler with required dependencies.
 // This constructor is called during application initialization to wire up dependencies.
-func NewContactHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *ContactHandler {
+func NewContactHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *ContactHandler {
 	return &ContactHandler{
 		queries: queries,
 		logger:  logger,
@@ -74,13 +74,13 @@ func (h *ContactHandler) renderAndCache(c echo.Context, cacheKey string, ttlSeco
 	// This allows us to cache the rendered HTML before sending it
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Extract rendered HTML from buffer and store in cache for future requests
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 
 	// Return the rendered HTML to the client with specified status code
 	return c.HTML(statusCode, html)
@@ -103,7 +103,7 @@ func (h *ContactHandler) ShowContactPage(c echo.Context) error {
 
 	// Check if cached version exists and return it immediately to improve performance
 	// Contact page can be cached longer (1 hour) since office locations rarely change
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -114,7 +114,7 @@ func (h *ContactHandler) ShowContactPage(c echo.Context) error {
 	// Each office includes address, phone, email, hours, and optional map coordinates
 	offices, err := h.queries.GetActiveOfficeLocations(ctx)
 	if err != nil {
-		h.logger.Error("failed to load office locations", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load office locations", "error", err)
 		offices = []sqlc.GetActiveOfficeLocationsRow{} // Default to empty slice to prevent template errors
 	}
 
@@ -192,7 +192,7 @@ func (h *ContactHandler) SubmitContactForm(c echo.Context) error {
 		},
 	})
 	if err != nil {
-		h.logger.Error("failed to create contact submission", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create contact submission", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -0,0 +1,252 @@
+// Package public provides HTTP handlers for public-facing website pages.
+// This file implements the generated index pages used for internal linking:
+// the A–Z product index, the blog tag cloud with one page per tag, and the
+// whitepaper topic index.
+package public
+
+import (
+	// Standard library imports
+	"fmt"      // Cache keys and URLs
+	"math"     // Ceiling division for pagination
+	"net/http" // HTTP status codes
+	"strconv"  // Page parsing
+
+	// Third-party framework imports
+	"github.com/labstack/echo/v4" // Echo web framework for routing and context handling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // A–Z grouping and tag cloud weights
+)
+
+// indexPageTTL is the cache lifetime of the index pages, in seconds. They
+// change only when content is published, and those admin actions clear the
+// section's "page:" prefix, so a long TTL only delays taxonomy renames.
+const indexPageTTL = 6 * 60 * 60
+
+// blogTagCloudEntry is one tag of the /blog/tags cloud.
+type blogTagCloudEntry struct {
+	Name   string // Tag display name
+	Slug   string // Tag slug
+	Count  int64  // Published posts with the tag
+	Weight int    // Font size level, 1..services.TagCloudLevels
+}
+
+// URL returns the tag page path, e.g. /blog/tags/edge-computing.
+func (t blogTagCloudEntry) URL() string {
+	return "/blog/tags/" + t.Slug
+}
+
+// tagCloud converts ListBlogTagCloud rows and weights them by post count.
+func tagCloud(rows []sqlc.ListBlogTagCloudRow) []blogTagCloudEntry {
+	if len(rows) == 0 {
+		return nil
+	}
+	minCount, maxCount := rows[0].PostCount, rows[0].PostCount
+	for _, row := range rows {
+		minCount = min(minCount, row.PostCount)
+		maxCount = max(maxCount, row.PostCount)
+	}
+	tags := make([]blogTagCloudEntry, len(rows))
+	for i, row := range rows {
+		tags[i] = blogTagCloudEntry{
+			Name:   row.Name,
+			Slug:   row.Slug,
+			Count:  row.PostCount,
+			Weight: services.TagCloudWeight(row.PostCount, minCount, maxCount),
+		}
+	}
+	return tags
+}
+
+// whitepaperTopicSection is one topic of the /whitepapers/topics index.
+type whitepaperTopicSection struct {
+	Topic       sqlc.WhitepaperTopic
+	Whitepapers []sqlc.ListPublishedWhitepapersRow // Newest first
+}
+
+// ProductIndex handles GET requests to the A–Z product index.
+//
+// HTTP Method: GET
+// Route: /products/a-z
+// Template: public/pages/products_index.html (full page)
+// Cache TTL: indexPageTTL (6 hours); cleared with the other "page:products" keys
+//
+// Lists every published product under its first letter, with a letter bar
+// linking to each section. Names not starting with A–Z file under "#".
+//
+// Template Data:
+//   - Title: "Products A–Z"
+//   - Groups: []services.IndexGroup[sqlc.ListProductsForIndexRow] - Letter sections
+//   - Jumps: []services.IndexJump - Letter bar (# A … Z)
+//   - TotalCount: int - Number of products listed
+func (h *ProductsHandler) ProductIndex(c echo.Context) error {
+	cacheKey := "page:products:index"
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+
+	products, err := h.queries.ListProductsForIndex(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list products for index", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	groups := services.GroupByLetter(products, func(p sqlc.ListProductsForIndexRow) string { return p.Name })
+
+	data := map[string]interface{}{
+		"Title":      "Products A–Z",
+		"Groups":     groups,
+		"Jumps":      services.IndexJumps(groups),
+		"TotalCount": len(products),
+	}
+	dependsOn(c, services.ProductCatalogTag)
+	return h.renderAndCache(c, cacheKey, indexPageTTL, http.StatusOK, "public/pages/products_index.html", data)
+}
+
+// TagCloud handles GET requests to the blog tag cloud.
+//
+// HTTP Method: GET
+// Route: /blog/tags
+// Template: public/pages/blog_tags.html (full page)
+// Cache TTL: indexPageTTL (6 hours); cleared with the other "page:blog" keys
+//
+// Shows every tag used by a published post, sized by how many posts use it.
+//
+// Template Data:
+//   - Title: "Blog Tags"
+//   - Tags: []blogTagCloudEntry - Tags in name order with weights
+func (h *BlogHandler) TagCloud(c echo.Context) error {
+	cacheKey := "page:blog:tags"
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+
+	rows, err := h.queries.ListBlogTagCloud(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list blog tag cloud", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	data := map[string]interface{}{
+		"Title":       "Blog Tags",
+		"Tags":        tagCloud(rows),
+		"CurrentPage": "blog",
+	}
+	return h.renderAndCache(c, cacheKey, indexPageTTL, http.StatusOK, "public/pages/blog_tags.html", data)
+}
+
+// TagPosts handles GET requests to one tag's page.
+//
+// HTTP Method: GET
+// Route: /blog/tags/:slug (e.g., /blog/tags/edge-computing)
+// Query Parameters: ?page=N (optional)
+// Template: public/pages/blog_tag.html (full page)
+// Cache TTL: indexPageTTL (6 hours), one entry per tag and page
+//
+// Unknown tags and tags without published posts return 404.
+//
+// Template Data:
+//   - Title: "Posts tagged <name>"
+//   - Tag: sqlc.BlogTag - The tag being shown
+//   - Posts: []sqlc.ListPublishedPostsByTagRow - Tagged posts, newest first
+//   - Page, TotalPages, TotalCount: Pagination
+func (h *BlogHandler) TagPosts(c echo.Context) error {
+	slug := c.Param("slug")
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	cacheKey := fmt.Sprintf("page:blog:tag:%s:page:%d", slug, page)
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+
+	ctx := c.Request().Context()
+	tag, err := h.queries.GetBlogTagBySlug(ctx, slug)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+	}
+	totalCount, err := h.queries.CountPublishedPostsByTag(ctx, slug)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to count tagged posts", "tag", slug, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if totalCount == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+	}
+
+	limit := int64(9) // Same 3x3 grid as the main listing
+	posts, err := h.queries.ListPublishedPostsByTag(ctx, sqlc.ListPublishedPostsByTagParams{
+		Slug:   slug,
+		Limit:  limit,
+		Offset: int64(page-1) * limit,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list tagged posts", "tag", slug, "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	data := map[string]interface{}{
+		"Title":       "Posts tagged " + tag.Name,
+		"Tag":         tag,
+		"Posts":       posts,
+		"CurrentPage": "blog",
+		"Page":        page,
+		"TotalPages":  int(math.Ceil(float64(totalCount) / float64(limit))),
+		"TotalCount":  totalCount,
+	}
+	return h.renderAndCache(c, cacheKey, indexPageTTL, http.StatusOK, "public/pages/blog_tag.html", data)
+}
+
+// TopicIndex handles GET requests to the whitepaper topic index.
+//
+// HTTP Method: GET
+// Route: /whitepapers/topics
+// Template: public/pages/whitepaper_topics.html (full page)
+// Cache TTL: indexPageTTL (6 hours); cleared with the other "page:whitepapers" keys
+//
+// Lists each topic (in admin sort order) with all of its published
+// whitepapers. Topics without published whitepapers are left out.
+//
+// Template Data:
+//   - Title: "Whitepapers by Topic"
+//   - Topics: []whitepaperTopicSection - Topics with their whitepapers
+//   - TotalCount: int - Number of whitepapers listed
+func (h *WhitepapersHandler) TopicIndex(c echo.Context) error {
+	cacheKey := "page:whitepapers:topics"
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+
+	ctx := c.Request().Context()
+	topics, err := h.queries.ListWhitepaperTopics(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list whitepaper topics", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	whitepapers, err := h.queries.ListPublishedWhitepapers(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list whitepapers", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	byTopic := map[int64][]sqlc.ListPublishedWhitepapersRow{}
+	for _, wp := range whitepapers {
+		byTopic[wp.TopicID] = append(byTopic[wp.TopicID], wp)
+	}
+	var sections []whitepaperTopicSection
+	for _, topic := range topics {
+		if wps := byTopic[topic.ID]; len(wps) > 0 {
+			sections = append(sections, whitepaperTopicSection{Topic: topic, Whitepapers: wps})
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title":       "Whitepapers by Topic",
+		"Topics":      sections,
+		"TotalCount":  len(whitepapers),
+		"CurrentPage": "whitepapers",
+	}
+	return h.renderAndCache(c, cacheKey, indexPageTTL, http.StatusOK, "public/pages/whitepaper_topics.html", data)
+}
@@ -0,0 +1,181 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the product documentation under /docs: the index of
+// manuals and the pages of each doc set version with their sidebar tree.
+package public
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for 404 detection
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page trees and doc paths
+)
+
+// docsPageTTL caches documentation pages for 30 minutes; the admin clears
+// them on every change.
+const docsPageTTL = 1800
+
+// DocsHandler serves /docs and the pages of published doc sets.
+type DocsHandler struct {
+	queries sqlc.Querier    // Database query interface for doc sets and pages
+	logger  *slog.Logger    // Structured logger for errors
+	cache   *services.Cache // In-memory cache for rendered pages
+}
+
+// NewDocsHandler creates a new DocsHandler with the required dependencies.
+func NewDocsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *DocsHandler {
+	return &DocsHandler{queries: queries, logger: logger, cache: cache}
+}
+
+// set loads the published doc set named by the :set parameter.
+func (h *DocsHandler) set(c echo.Context) (sqlc.DocSet, error) {
+	set, err := h.queries.GetPublishedDocSetBySlug(c.Request().Context(), c.Param("set"))
+	if err == sql.ErrNoRows {
+		return set, echo.NewHTTPError(http.StatusNotFound, "Documentation not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load doc set", "error", err, "slug", c.Param("set"))
+		return set, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return set, nil
+}
+
+// Index handles GET /docs
+// Renders the published doc sets grouped by product.
+//
+// Template: public/pages/docs.html (full page)
+// Cache: 30 minutes under "page:docs"
+func (h *DocsHandler) Index(c echo.Context) error {
+	if cached, ok := cachedPage(c, h.cache, "page:docs"); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	sets, err := h.queries.ListPublishedDocSets(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list doc sets", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return renderCachedPage(c, h.cache, h.logger, "page:docs", docsPageTTL, "public/pages/docs.html", map[string]interface{}{
+		"Title":           "Documentation",
+		"MetaDescription": "Product manuals and technical documentation.",
+		"CanonicalURL":    "/docs",
+		"CurrentPage":     "docs",
+		"Sets":            sets,
+	})
+}
+
+// Set handles GET /docs/:set
+// Redirects (302) to the current version, so links to a manual follow new
+// releases.
+func (h *DocsHandler) Set(c echo.Context) error {
+	set, err := h.set(c)
+	if err != nil {
+		return err
+	}
+	if !set.CurrentVersionID.Valid {
+		return echo.NewHTTPError(http.StatusNotFound, "Documentation not found")
+	}
+	version, err := h.queries.GetDocVersion(c.Request().Context(), set.CurrentVersionID.Int64)
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load current doc version", "error", err, "id", set.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.Redirect(http.StatusFound, services.DocVersionPath(set.Slug, version.Version))
+}
+
+// Version handles GET /docs/:set/:version
+// Renders the contents of a doc set version: its description and the
+// tree of published pages.
+//
+// Template: public/pages/doc_page.html (full page, without .Node)
+// Cache: 30 minutes under "page:docs:<set>:<version>"
+func (h *DocsHandler) Version(c echo.Context) error {
+	return h.render(c, "")
+}
+
+// Page handles GET /docs/:set/:version/:page
+// Renders a published page with the sidebar tree of its version,
+// breadcrumbs and links to the previous and next page. Pages of older
+// versions say so and link to the current version.
+//
+// Template: public/pages/doc_page.html (full page)
+// Cache: 30 minutes under "page:docs:<set>:<version>:<page>"
+func (h *DocsHandler) Page(c echo.Context) error {
+	return h.render(c, c.Param("page"))
+}
+
+// render serves Version (pageSlug "") and Page.
+func (h *DocsHandler) render(c echo.Context, pageSlug string) error {
+	cacheKey := "page:docs:" + c.Param("set") + ":" + c.Param("version")
+	if pageSlug != "" {
+		cacheKey += ":" + pageSlug
+	}
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	set, err := h.set(c)
+	if err != nil {
+		return err
+	}
+	version, err := h.queries.GetDocVersionByLabel(ctx, sqlc.GetDocVersionByLabelParams{DocSetID: set.ID, Version: c.Param("version")})
+	if err == sql.ErrNoRows {
+		return echo.NewHTTPError(http.StatusNotFound, "Version not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load doc version", "error", err, "set", set.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	pages, err := h.queries.ListDocPages(ctx, version.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list doc pages", "error", err, "version", version.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	versions, err := h.queries.ListDocVersions(ctx, set.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list doc versions", "error", err, "set", set.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	tree := services.BuildDocTree(pages, services.DocVersionPath(set.Slug, version.Version), true)
+
+	var current string
+	for _, v := range versions {
+		if v.ID == set.CurrentVersionID.Int64 {
+			current = v.Version
+		}
+	}
+	data := map[string]interface{}{
+		"Title":           set.Name + " " + version.Version,
+		"MetaDescription": set.Description,
+		"CanonicalURL":    services.DocVersionPath(set.Slug, version.Version),
+		"CurrentPage":     "docs",
+		"Set":             set,
+		"Version":         version,
+		"Versions":        versions,
+		"CurrentVersion":  current,
+		"IsCurrent":       version.Version == current,
+		"BasePath":        services.DocVersionPath(set.Slug, version.Version),
+		"Tree":            tree,
+	}
+	if pageSlug != "" {
+		node := tree.BySlug(pageSlug)
+		if node == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "Page not found")
+		}
+		data["Title"] = node.Page.Title + " | " + set.Name + " " + version.Version
+		if node.Page.MetaDescription != "" {
+			data["MetaDescription"] = node.Page.MetaDescription
+		}
+		data["CanonicalURL"] = node.URL
+		data["Node"] = node
+		data["Breadcrumbs"] = node.Breadcrumbs()
+		data["Prev"] = tree.Prev(node)
+		data["Next"] = tree.Next(node)
+	}
+	return renderCachedPage(c, h.cache, h.logger, cacheKey, docsPageTTL, "public/pages/doc_page.html", data)
+}
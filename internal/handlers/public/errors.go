@@ -0,0 +1,126 @@
+package public
+
+import (
+	"bytes"    // Rendering to a buffer so a failed render can fall back to text
+	"errors"   // Unwrapping *echo.HTTPError
+	"log/slog" // Structured logging for unexpected errors
+	"net/http" // HTTP status codes and status text
+	"strings"  // Route prefix and Accept header checks
+
+	"github.com/labstack/echo/v4" // Echo web framework
+
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // HTMX request detection
+)
+
+// ErrorHandler writes every error response through the template engine:
+// branded 404 and error pages for browsers, a small fragment for HTMX swaps
+// and {"error": "..."} for API clients, instead of Echo's default JSON
+// message.
+type ErrorHandler struct {
+	logger *slog.Logger // Structured logger for unexpected errors
+}
+
+// NewErrorHandler creates a new error handler.
+func NewErrorHandler(logger *slog.Logger) *ErrorHandler {
+	return &ErrorHandler{logger: logger}
+}
+
+// Handle is an echo.HTTPErrorHandler.
+//
+// Usage: e.HTTPErrorHandler = publicHandlers.NewErrorHandler(logger).Handle
+//
+// Response by request:
+//   - /api/ paths and requests that accept JSON: {"error": message}
+//   - HTMX requests: public/partials/error.html
+//   - Everything else: public/pages/404.html for 404, public/pages/500.html
+//     for other statuses (with the status and its message)
+//
+// 4xx errors show the handler's message ("Invalid ID") unless it is just the
+// status text. 5xx errors show a generic message, and plain errors (not
+// *echo.HTTPError) are logged, since their text may include internal details.
+// If the template fails to render, the status text is written as plain text.
+func (h *ErrorHandler) Handle(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	code := http.StatusInternalServerError
+	message := ""
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		code = he.Code
+		if m, ok := he.Message.(string); ok {
+			message = m
+		}
+		if he.Internal != nil {
+			h.logger.ErrorContext(c.Request().Context(), "request failed", "error", he.Internal, "status", code, "path", c.Request().URL.Path)
+		}
+	} else {
+		h.logger.ErrorContext(c.Request().Context(), "request failed", "error", err, "path", c.Request().URL.Path)
+	}
+	if code >= http.StatusInternalServerError || message == "" || message == http.StatusText(code) {
+		message = errorMessages[code]
+		if message == "" {
+			message = http.StatusText(code)
+		}
+	}
+
+	req := c.Request()
+	if req.Method == http.MethodHead {
+		c.NoContent(code)
+		return
+	}
+	if strings.HasPrefix(req.URL.Path, "/api/") ||
+		strings.Contains(req.Header.Get(echo.HeaderAccept), echo.MIMEApplicationJSON) {
+		c.JSON(code, map[string]string{"error": message})
+		return
+	}
+
+	name := "public/pages/500.html"
+	if code == http.StatusNotFound {
+		name = "public/pages/404.html"
+	}
+	if middleware.IsHTMX(c) {
+		name = "public/partials/error.html"
+	}
+	data := map[string]interface{}{
+		"Title":      http.StatusText(code),
+		"StatusCode": code,
+		"Message":    message,
+		"AdminArea":  req.URL.Path == "/admin" || strings.HasPrefix(req.URL.Path, "/admin/"),
+	}
+	// Global data from SettingsLoader, present when the request reached the
+	// public group (including unmatched paths) and absent elsewhere
+	for key, ctxKey := range map[string]string{
+		"Settings":         "settings",
+		"FooterCategories": "footer_categories",
+		"FooterSolutions":  "footer_solutions",
+		"FooterResources":  "footer_resources",
+	} {
+		if v := c.Get(ctxKey); v != nil {
+			data[key] = v
+		}
+	}
+	if locale := requestLocale(c); locale != "" {
+		data["Lang"] = locale
+	}
+
+	var buf bytes.Buffer
+	if rerr := c.Echo().Renderer.Render(&buf, name, data, c); rerr != nil {
+		h.logger.ErrorContext(c.Request().Context(), "error page render failed", "template", name, "error", rerr)
+		c.String(code, http.StatusText(code))
+		return
+	}
+	c.HTMLBlob(code, buf.Bytes())
+}
+
+// errorMessages explains common statuses to visitors. 5xx details are never
+// shown, and 4xx errors without a handler message use these too.
+var errorMessages = map[int]string{
+	http.StatusNotFound:            "The page you are looking for does not exist or has moved.",
+	http.StatusForbidden:           "You do not have access to this page.",
+	http.StatusMethodNotAllowed:    "This page cannot be requested that way.",
+	http.StatusTooManyRequests:     "Too many requests. Please wait a moment and try again.",
+	http.StatusInternalServerError: "Something went wrong on our side. Please try again in a moment.",
+	http.StatusServiceUnavailable:  "The site is temporarily unavailable. Please try again in a few minutes.",
+}
@@ -0,0 +1,222 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves events and webinars: the /events listing, event pages
+// with their registration form and the calendar file of each event.
+package public
+
+import (
+	// Standard library imports
+	"bytes"        // Rendering into a buffer
+	"database/sql" // sql.ErrNoRows for 404 detection
+	"errors"       // Registration error inspection
+	"fmt"          // Cache keys and the calendar file name
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+	"strings"      // Trimming form values and splitting the description
+	"time"         // Upcoming/past split
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Event listing, registration and calendar files
+)
+
+// pastEventsShown is how many past events /events lists.
+const pastEventsShown = 12
+
+// eventPageTTL caches event pages for five minutes: upcoming events move to
+// the past, and registration closes, by the clock rather than by an edit.
+const eventPageTTL = 300
+
+// EventsHandler serves /events and the pages, registration forms and
+// calendar files of published events.
+type EventsHandler struct {
+	queries sqlc.Querier     // Database query interface for events
+	events  *services.Events // Listing, registration and calendar files
+	logger  *slog.Logger     // Structured logger for errors
+	cache   *services.Cache  // In-memory cache for rendered pages
+}
+
+// NewEventsHandler creates a new EventsHandler with the required dependencies.
+func NewEventsHandler(queries sqlc.Querier, events *services.Events, logger *slog.Logger, cache *services.Cache) *EventsHandler {
+	return &EventsHandler{queries: queries, events: events, logger: logger, cache: cache}
+}
+
+// event loads the published event named by the :slug parameter.
+func (h *EventsHandler) event(c echo.Context) (sqlc.Event, error) {
+	event, err := h.queries.GetPublishedEventBySlug(c.Request().Context(), c.Param("slug"))
+	if err == sql.ErrNoRows {
+		return event, echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load event", "error", err, "slug", c.Param("slug"))
+		return event, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return event, nil
+}
+
+// List handles GET /events
+// Renders the upcoming events, soonest first, then the most recent past
+// ones. An event is upcoming until it ends.
+//
+// Template: public/pages/events.html (full page)
+// Cache: 5 minutes under "page:events"
+func (h *EventsHandler) List(c echo.Context) error {
+	if cached, ok := cachedPage(c, h.cache, "page:events"); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	now := time.Now()
+	upcoming, err := h.events.Upcoming(ctx, now)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list upcoming events", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	past, err := h.events.Past(ctx, now, pastEventsShown)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list past events", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return renderCachedPage(c, h.cache, h.logger, "page:events", eventPageTTL, "public/pages/events.html", map[string]interface{}{
+		"Title":           "Events & Webinars",
+		"MetaDescription": "Upcoming events and webinars, and recordings of past ones.",
+		"CanonicalURL":    "/events",
+		"CurrentPage":     "events",
+		"Upcoming":        upcoming,
+		"Past":            past,
+	})
+}
+
+// Detail handles GET /events/:slug
+// Renders an event with its registration form, or why registration is not
+// possible: the event has ended, the admin closed registration or every
+// place is taken. Webinar join links are not on the page; registrants get
+// them after registering.
+//
+// Template: public/pages/event_detail.html (full page)
+// Cache: 5 minutes under "page:events:<slug>"
+func (h *EventsHandler) Detail(c echo.Context) error {
+	cacheKey := "page:events:" + c.Param("slug")
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	event, err := h.event(c)
+	if err != nil {
+		return err
+	}
+
+	state := "open"
+	switch {
+	case services.EventEnded(event, time.Now()):
+		state = "ended"
+	case event.RegistrationOpen != 1:
+		state = "closed"
+	case event.Capacity > 0:
+		count, err := h.queries.CountEventRegistrations(ctx, event.ID)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to count event registrations", "error", err, "id", event.ID)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		if count >= event.Capacity {
+			state = "full"
+		}
+	}
+
+	var paragraphs []string
+	for _, p := range strings.Split(strings.ReplaceAll(event.Description, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	metaDesc := event.MetaDescription
+	if metaDesc == "" {
+		metaDesc = event.Summary
+	}
+	return renderCachedPage(c, h.cache, h.logger, cacheKey, eventPageTTL, "public/pages/event_detail.html", map[string]interface{}{
+		"Title":           event.Title,
+		"MetaDescription": metaDesc,
+		"CanonicalURL":    services.EventPath(event),
+		"CurrentPage":     "events",
+		"Event":           event,
+		"Paragraphs":      paragraphs,
+		"State":           state,
+		"CalendarURL":     services.EventCalendarPath(event),
+	})
+}
+
+// Register handles POST /events/:slug/register
+// Records the registration form (HTMX) like the other lead forms and
+// replies with a fragment that replaces the form, carrying the webinar
+// join link. Errors go to the status line under the form (HX-Retarget),
+// leaving the fields for the visitor to correct. Registering an address
+// again shows the same confirmation.
+//
+// Form Fields: name, email, company (required), designation, marketing_consent
+//
+// Template: public/partials/event_registered.html (HTMX fragment)
+func (h *EventsHandler) Register(c echo.Context) error {
+	ctx := c.Request().Context()
+	event, err := h.event(c)
+	if err != nil {
+		return err
+	}
+
+	reg := sqlc.CreateEventRegistrationParams{
+		Name:        strings.TrimSpace(c.FormValue("name")),
+		Email:       strings.TrimSpace(c.FormValue("email")),
+		Company:     strings.TrimSpace(c.FormValue("company")),
+		Designation: strings.TrimSpace(c.FormValue("designation")),
+		IpAddress:   c.RealIP(),
+		UserAgent:   c.Request().UserAgent(),
+	}
+	if v := c.FormValue("marketing_consent"); v == "on" || v == "1" || v == "true" {
+		reg.MarketingConsent = 1
+	}
+	if reg.Name == "" || reg.Email == "" || reg.Company == "" {
+		c.Response().Header().Set("HX-Retarget", "#event-registration-status")
+		return c.HTML(http.StatusOK, `Name, email, and company are required.`)
+	}
+
+	err = h.events.Register(ctx, event, reg)
+	switch {
+	case errors.Is(err, services.ErrEventInvalidEmail):
+		c.Response().Header().Set("HX-Retarget", "#event-registration-status")
+		return c.HTML(http.StatusOK, `Enter a valid email address.`)
+	case errors.Is(err, services.ErrEventRegistrationClosed), errors.Is(err, services.ErrEventFull):
+		c.Response().Header().Set("HX-Retarget", "#event-registration-status")
+		return c.HTML(http.StatusOK, `Sorry, `+err.Error()+`.`)
+	case err != nil:
+		h.logger.ErrorContext(ctx, "failed to register for event", "error", err, "id", event.ID)
+		c.Response().Header().Set("HX-Retarget", "#event-registration-status")
+		return c.HTML(http.StatusOK, `Something went wrong. Please try again later.`)
+	}
+	if event.Capacity > 0 {
+		// The page may have to say the event is full now
+		h.cache.Delete("page:events:" + event.Slug)
+	}
+
+	var buf bytes.Buffer
+	err = c.Echo().Renderer.Render(&buf, "public/partials/event_registered.html", map[string]interface{}{
+		"Event":       event,
+		"Email":       reg.Email,
+		"CalendarURL": services.EventCalendarPath(event),
+	}, c)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "template render failed", "template", "public/partials/event_registered.html", "error", err)
+		return err
+	}
+	return c.HTML(http.StatusOK, buf.String())
+}
+
+// Calendar handles GET /events/:slug/calendar.ics
+// Serves the event as an iCalendar file to add to a calendar app.
+func (h *EventsHandler) Calendar(c echo.Context) error {
+	event, err := h.event(c)
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.ics"`, event.Slug))
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", h.events.Calendar(event))
+}
@@ -0,0 +1,103 @@
+// Package public provides HTTP handlers for public-facing website features.
+// This file serves the custom lead forms built under Admin > Forms.
+package public
+
+import (
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+
+	"github.com/labstack/echo/v4"                           // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/models"   // Form view model
+	"github.com/narendhupati/bluejay-cms/internal/services" // Form loading, checking and storage
+)
+
+// FormsHandler serves the active forms at /forms/:slug and takes their
+// submissions.
+type FormsHandler struct {
+	forms  *services.Forms // Form loading, submission storage and notifications
+	logger *slog.Logger    // Structured logger for error reporting
+	cache  *services.Cache // Rendered page cache
+}
+
+// NewFormsHandler creates a new FormsHandler instance.
+func NewFormsHandler(forms *services.Forms, logger *slog.Logger, cache *services.Cache) *FormsHandler {
+	return &FormsHandler{forms: forms, logger: logger, cache: cache}
+}
+
+// ShowForm handles GET /forms/:slug
+// Renders an active form on a page of its own; unknown and inactive forms
+// are not found.
+//
+// Template: public/pages/form.html (full page)
+// Cache: 600 seconds; the admin clears page:form: keys on every change
+func (h *FormsHandler) ShowForm(c echo.Context) error {
+	slug := c.Param("slug")
+	cacheKey := localizedCacheKey(c, "page:form:"+slug)
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	_, form, err := h.load(c, slug)
+	if err != nil {
+		return err
+	}
+	return renderCachedPage(c, h.cache, h.logger, cacheKey, 600, "public/pages/form.html", h.pageData(c, form))
+}
+
+// SubmitForm handles POST /forms/:slug/submit
+// Checks the answers against the form's rules and stores them as a
+// submission, which is emailed to the form's notification addresses.
+//
+// HTMX: returns public/partials/custom_form.html, the form with the answers
+// and their errors or the success message, for the form to swap itself
+// out. As HTMX only swaps successful responses, refused answers come back
+// with 200 too. Without HTMX the form's page is rendered the same way.
+func (h *FormsHandler) SubmitForm(c echo.Context) error {
+	ctx := c.Request().Context()
+	stored, form, err := h.load(c, c.Param("slug"))
+	if err != nil {
+		return err
+	}
+	checked, answers, ok := services.CheckFormSubmission(form, c.FormValue)
+	if ok {
+		if _, err := h.forms.Submit(ctx, stored, answers, c.RealIP(), c.Request().UserAgent()); err != nil {
+			h.logger.ErrorContext(ctx, "failed to store form submission", "error", err, "form", stored.ID)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		checked = models.Form{Slug: form.Slug, Name: form.Name, Success: stored.SuccessMessage}
+	}
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return c.Render(http.StatusOK, "public/partials/custom_form.html", checked)
+	}
+	// Not cached: the page shows this visitor's answers
+	return renderCachedPage(c, h.cache, h.logger, "", 0, "public/pages/form.html", h.pageData(c, checked))
+}
+
+// load returns the active form with slug, or a 404 error.
+func (h *FormsHandler) load(c echo.Context, slug string) (stored sqlc.Form, form models.Form, err error) {
+	stored, form, err = h.forms.Active(c.Request().Context(), slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return stored, form, echo.NewHTTPError(http.StatusNotFound, "Form not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load form", "error", err, "slug", slug)
+		return stored, form, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return stored, form, nil
+}
+
+// pageData returns the template data of the form's page, with the
+// rendered locale and language switcher.
+func (h *FormsHandler) pageData(c echo.Context, form models.Form) map[string]interface{} {
+	data := map[string]interface{}{
+		"Title":           form.Name,             // Browser tab title
+		"MetaDescription": form.Description,      // SEO description
+		"CanonicalURL":    "/forms/" + form.Slug, // SEO canonical URL
+		"Form":            form,                  // Fields, answers and errors
+		"CurrentPage":     "form",                // For nav highlighting
+	}
+	setLang(c, data)
+	return data
+}
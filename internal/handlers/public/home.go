@@ -20,14 +20,14 @@ import (
 // It aggregates data from multiple content tables (hero, stats, testimonials,
 // products, solutions, partners, blog posts) to compose the homepage view.
 type HomeHandler struct {
-	queries *sqlc.Queries // Database query interface for fetching homepage content
-	logger  *slog.Logger  // Structured logger for error tracking
+	queries sqlc.Querier // Database query interface for fetching homepage content
+	logger  *slog.Logger // Structured logger for error tracking
 }
 
 // NewHomeHandler creates a new HomeHandler instance with the provided dependencies.
 // This constructor is used during application initialization to wire up the handler
 // with the database queries and logger.
-func NewHomeHandler(queries *sqlc.Queries, logger *slog.Logger) *HomeHandler {
+func NewHomeHandler(queries sqlc.Querier, logger *slog.Logger) *HomeHandler {
 	return &HomeHandler{
 		queries: queries,
 		logger:  logger,
@@ -87,7 +87,7 @@ func (h *HomeHandler) ShowHomePage(c echo.Context) error {
 	// Fetch global site settings - this is critical and must succeed
 	settings, err := h.queries.GetSettings(ctx)
 	if err != nil {
-		h.logger.Error("failed to get settings", "error", err)
+		h.logger.ErrorContext(ctx, "failed to get settings", "error", err)
 		return c.String(http.StatusInternalServerError, "Internal server error")
 	}
 
@@ -119,12 +119,9 @@ func (h *HomeHandler) ShowHomePage(c echo.Context) error {
 
 	// Page sections for editable labels/headings
 	// Allows admin to customize section headings without code changes
+	// Keyed by section_key for easy template lookup, translated for the visitor's locale
 	sections, _ := h.queries.ListPageSections(ctx, "home")
-	sectionMap := make(map[string]sqlc.PageSection)
-	for _, s := range sections {
-		// Build a map keyed by section_key for easy template lookup
-		sectionMap[s.SectionKey] = s
-	}
+	sectionMap := localizedSectionMap(c, sections)
 
 	// Assemble template data with all homepage content
 	data := map[string]interface{}{
@@ -143,6 +140,8 @@ func (h *HomeHandler) ShowHomePage(c echo.Context) error {
 		"Sections":         sectionMap,        // Editable section content
 	}
 
+	setLang(c, data)
+
 	// Inject footer navigation data set by middleware
 	// These provide consistent site-wide navigation links in the footer
 	if cats := c.Get("footer_categories"); cats != nil {
@@ -0,0 +1,108 @@
+// Package public provides HTTP handlers for public-facing website features.
+// This file serves the campaign landing pages built under Admin > Landing Pages.
+package public
+
+import (
+	"database/sql" // sql.ErrNoRows detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging
+	"net/http"     // HTTP status codes
+	"strconv"      // Formatting the ID in the edit link
+	"strings"      // Trimming the requested path
+
+	"github.com/labstack/echo/v4"                           // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Page cache, preview types and section parsing
+)
+
+// LandingPagesHandler serves published landing pages at /<slug>.
+type LandingPagesHandler struct {
+	queries sqlc.Querier    // Database queries generated by sqlc
+	logger  *slog.Logger    // Structured logger for error reporting
+	forms   *services.Forms // Custom forms embedded in form sections
+	cache   *services.Cache // Rendered page cache
+}
+
+// NewLandingPagesHandler creates a new LandingPagesHandler instance.
+func NewLandingPagesHandler(queries sqlc.Querier, logger *slog.Logger, forms *services.Forms, cache *services.Cache) *LandingPagesHandler {
+	return &LandingPagesHandler{queries: queries, logger: logger, forms: forms, cache: cache}
+}
+
+// LandingPage handles GET /* for every path no other route serves.
+// It renders the published landing page whose slug is the path, or the 404
+// page. Drafts are shown with a valid preview token, without the page's
+// analytics snippets so reviews do not count as visits.
+//
+// Templates: public/pages/landing_page.html (site layout) or
+// public/pages/landing_page_minimal.html (own header and footer, without
+// navigation)
+// Cache: 600 seconds; the admin clears page:landing: keys on every change
+// to a landing page or a form
+func (h *LandingPagesHandler) LandingPage(c echo.Context) error {
+	slug := strings.Trim(c.Param("*"), "/")
+	if slug == "" {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	previewItemID, preview := previewID(c, services.PreviewLanding)
+	cacheKey := localizedCacheKey(c, "page:landing:"+slug)
+	if !preview {
+		if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+			return c.HTML(http.StatusOK, cached.(string))
+		}
+	}
+
+	ctx := c.Request().Context()
+	page, err := h.queries.GetLandingPageBySlug(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && (preview && page.ID != previewItemID || !preview && page.Status != services.LandingPublished) {
+		return echo.NewHTTPError(http.StatusNotFound, "Page not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load landing page", "error", err, "slug", slug)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	rows, err := h.queries.ListLandingPageSections(ctx, page.ID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load landing page sections", "error", err, "id", page.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	sections := services.LandingSections(rows)
+	for i := range sections {
+		if sections[i].FormSlug == "" || h.forms == nil {
+			continue
+		}
+		// A missing or inactive form falls back to the built-in lead form
+		_, form, err := h.forms.Active(ctx, sections[i].FormSlug)
+		if err == nil {
+			sections[i].Form = &form
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			h.logger.ErrorContext(ctx, "failed to load landing page form", "error", err, "form", sections[i].FormSlug)
+		}
+	}
+
+	data := map[string]interface{}{
+		"Title":           page.Title,             // Browser tab title and page heading
+		"MetaTitle":       page.MetaTitle,         // SEO title (empty: "<title> - site")
+		"MetaDescription": page.MetaDescription,   // SEO description
+		"OGImage":         page.OgImage,           // Social sharing image
+		"NoIndex":         page.Noindex,           // Robots noindex tag
+		"CanonicalURL":    "/" + page.Slug,        // SEO canonical URL
+		"Page":            page,                   // Page settings
+		"Sections":        sections,               // Sections in display order
+		"InquiryType":     "landing:" + page.Slug, // Tags lead form submissions with the campaign
+		"CurrentPage":     "landing",              // For nav highlighting
+	}
+	setLang(c, data)
+
+	tmpl := "public/pages/landing_page.html"
+	if page.Layout == services.LandingLayoutMinimal {
+		tmpl = "public/pages/landing_page_minimal.html"
+	}
+	if preview {
+		data["IsPreview"] = true                                                             // Show preview banner in template
+		data["EditURL"] = "/admin/landing-pages/" + strconv.FormatInt(page.ID, 10) + "/edit" // Link to admin editor
+		return renderCachedPage(c, h.cache, h.logger, "preview:landing:"+slug, 0, tmpl, data)
+	}
+	data["HeadSnippet"] = page.HeadSnippet // Analytics HTML for <head>
+	data["BodySnippet"] = page.BodySnippet // Analytics HTML before </body>
+	return renderCachedPage(c, h.cache, h.logger, cacheKey, 600, tmpl, data)
+}
@@ -0,0 +1,27 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file contains helpers shared by the grid-only /partials/* endpoints
+// that HTMX uses to refresh a listing without reloading the page.
+package public
+
+import (
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - response headers
+)
+
+// gridCacheSuffix is appended to a listing's page cache key for its grid-only
+// fragment, so the fragment and the full page never overwrite each other.
+// Both keys share the page prefix and are cleared together on admin edits.
+const gridCacheSuffix = ":grid"
+
+// pushGridURL prepares a grid fragment response. HX-Push-Url makes HTMX push
+// the full-page URL (path plus the request's query) into browser history
+// instead of the /partials/* URL, so back/forward, reload, and shared links
+// all render the complete page. Fragments are kept out of search indexes.
+func pushGridURL(c echo.Context, path string) {
+	if q := c.QueryString(); q != "" {
+		path += "?" + q
+	}
+	header := c.Response().Header()
+	header.Set("HX-Push-Url", path)
+	header.Set("X-Robots-Tag", "noindex")
+}
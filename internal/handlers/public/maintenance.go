@@ -0,0 +1,37 @@
+// Package public provides HTTP handlers for public-facing website features.
+// This file renders the page visitors see while maintenance mode is on.
+package public
+
+import (
+	"net/http" // HTTP status codes
+	"strings"  // Trimming the configured message
+
+	"github.com/labstack/echo/v4"                 // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Settings row loaded by the SettingsLoader middleware
+)
+
+// defaultMaintenanceMessage is shown when Global Settings has no message.
+const defaultMaintenanceMessage = "We are making some improvements and will be back shortly. Thank you for your patience."
+
+// MaintenancePage renders public/pages/maintenance.html with status 503 and
+// the maintenance message from Global Settings. It is the page passed to
+// middleware.Maintenance, which sets Retry-After before calling it.
+//
+// Template: public/pages/maintenance.html (base layout with its own header
+// and footer, without navigation)
+func MaintenancePage(c echo.Context) error {
+	data := map[string]interface{}{
+		"Title":   "Down for Maintenance",
+		"Message": defaultMaintenanceMessage,
+	}
+	if settings, ok := c.Get("settings").(sqlc.Setting); ok {
+		data["Settings"] = settings
+		if msg := strings.TrimSpace(settings.MaintenanceMessage); msg != "" {
+			data["Message"] = msg
+		}
+	}
+	if locale := requestLocale(c); locale != "" {
+		data["Lang"] = locale
+	}
+	return c.Render(http.StatusServiceUnavailable, "public/pages/maintenance.html", data)
+}
@@ -0,0 +1,19 @@
+// Package public provides HTTP handlers for public-facing website features.
+// This file applies the optional HTML minification setting to rendered pages.
+package public
+
+import (
+	"github.com/labstack/echo/v4"                           // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Settings row loaded by the SettingsLoader middleware
+	"github.com/narendhupati/bluejay-cms/internal/services" // HTML minifier
+)
+
+// minifyPage returns html minified when "Minify HTML output" is enabled in
+// Global Settings, or unchanged otherwise. It runs before a page is cached,
+// so each page is minified once per cache lifetime rather than per request.
+func minifyPage(c echo.Context, html string) string {
+	if settings, ok := c.Get("settings").(sqlc.Setting); ok && settings.MinifyHtml {
+		return services.MinifyHTML(html)
+	}
+	return html
+}
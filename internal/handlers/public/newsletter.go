@@ -0,0 +1,172 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the newsletter: the footer sign-up form, the
+// confirmation link of the double opt-in email and unsubscribe links.
+package public
+
+import (
+	// Standard library imports
+	"bytes"    // Rendering into a buffer
+	"errors"   // Newsletter error inspection
+	"html"     // Escaping the address in form replies
+	"log/slog" // Structured logging for errors
+	"net/http" // HTTP status codes
+	"net/url"  // Reading the page the form was sent from
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/internal/services" // Newsletter subscriptions
+)
+
+// NewsletterHandler serves newsletter sign-ups and the confirmation and
+// unsubscribe pages linked from emails.
+//
+// The pages are personal, so they are never cached and not indexed.
+type NewsletterHandler struct {
+	newsletter *services.Newsletter // Sign-ups, confirmations and unsubscribes
+	logger     *slog.Logger         // Structured logger for errors
+}
+
+// NewNewsletterHandler creates a new NewsletterHandler with the required dependencies.
+func NewNewsletterHandler(newsletter *services.Newsletter, logger *slog.Logger) *NewsletterHandler {
+	return &NewsletterHandler{newsletter: newsletter, logger: logger}
+}
+
+// Subscribe handles POST /newsletter/subscribe
+// Processes the footer sign-up form (HTMX) and replies with a fragment
+// that replaces the form; errors go to the status line under the form
+// (HX-Retarget), leaving the address for the visitor to correct. The
+// address is pending until the visitor follows the link in the
+// confirmation email. The reply is the same for addresses that are already
+// subscribed.
+//
+// Form fields:
+//   - email: Address to sign up
+//
+// The page the form was sent from (HX-Current-URL, or the Referer without
+// HTMX) is stored as the sign-up source.
+func (h *NewsletterHandler) Subscribe(c echo.Context) error {
+	email := c.FormValue("email")
+	source := c.Request().Header.Get("HX-Current-URL")
+	if source == "" {
+		source = c.Request().Referer()
+	}
+	if u, err := url.Parse(source); err == nil {
+		source = u.Path
+	} else {
+		source = ""
+	}
+
+	err := h.newsletter.Subscribe(c.Request().Context(), email, source, c.RealIP())
+	switch {
+	case errors.Is(err, services.ErrNewsletterInvalidEmail):
+		c.Response().Header().Set("HX-Retarget", "#newsletter-status")
+		return c.HTML(http.StatusOK, `Enter a valid email address.`)
+	case err != nil:
+		h.logger.ErrorContext(c.Request().Context(), "failed to sign up newsletter subscriber", "error", err)
+		c.Response().Header().Set("HX-Retarget", "#newsletter-status")
+		return c.HTML(http.StatusOK, `Something went wrong. Please try again later.`)
+	}
+	return c.HTML(http.StatusOK, `<p class="text-sm text-gray-300" role="status">Almost done: we sent a confirmation link to <span class="font-bold text-white">`+
+		html.EscapeString(email)+`</span>. Follow it to start receiving the newsletter.</p>`)
+}
+
+// Confirm handles GET /newsletter/confirm
+// Confirms the address of the ?token= link in the confirmation email and
+// hands it to the mailing list provider.
+//
+// Template: public/pages/newsletter.html (full page)
+func (h *NewsletterHandler) Confirm(c echo.Context) error {
+	sub, err := h.newsletter.Confirm(c.Request().Context(), c.QueryParam("token"))
+	if err != nil && !errors.Is(err, services.ErrNewsletterLinkInvalid) {
+		h.logger.ErrorContext(c.Request().Context(), "failed to confirm newsletter subscriber", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	state := "confirmed"
+	if err != nil {
+		state = "invalid"
+	}
+	return h.render(c, map[string]interface{}{
+		"Title": "Newsletter",
+		"State": state,
+		"Email": sub.Email,
+	})
+}
+
+// UnsubscribePage handles GET /newsletter/unsubscribe
+// Asks the visitor to confirm unsubscribing the address of the ?token=
+// link. Unsubscribing takes a POST, so link scanners in mail clients do not
+// unsubscribe anyone by following the link.
+//
+// Template: public/pages/newsletter.html (full page)
+func (h *NewsletterHandler) UnsubscribePage(c echo.Context) error {
+	token := c.QueryParam("token")
+	sub, err := h.newsletter.Subscriber(c.Request().Context(), token)
+	if err != nil && !errors.Is(err, services.ErrNewsletterLinkInvalid) {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load newsletter subscriber", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	state := "unsubscribe"
+	switch {
+	case err != nil:
+		state = "invalid"
+	case sub.Status == services.NewsletterUnsubscribed:
+		state = "unsubscribed"
+	}
+	return h.render(c, map[string]interface{}{
+		"Title": "Unsubscribe",
+		"State": state,
+		"Email": sub.Email,
+		"Token": token,
+	})
+}
+
+// Unsubscribe handles POST /newsletter/unsubscribe
+// Unsubscribes the address of the token (form field, or the query of a
+// one-click List-Unsubscribe POST from a mail client).
+//
+// Template: public/pages/newsletter.html (full page)
+func (h *NewsletterHandler) Unsubscribe(c echo.Context) error {
+	sub, err := h.newsletter.Unsubscribe(c.Request().Context(), c.FormValue("token"))
+	if err != nil && !errors.Is(err, services.ErrNewsletterLinkInvalid) {
+		h.logger.ErrorContext(c.Request().Context(), "failed to unsubscribe newsletter subscriber", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	state := "unsubscribed"
+	if err != nil {
+		state = "invalid"
+	}
+	return h.render(c, map[string]interface{}{
+		"Title": "Unsubscribe",
+		"State": state,
+		"Email": sub.Email,
+	})
+}
+
+// render renders the newsletter page with the footer data of the public
+// layout, uncached.
+func (h *NewsletterHandler) render(c echo.Context, data map[string]interface{}) error {
+	if settings := c.Get("settings"); settings != nil {
+		data["Settings"] = settings
+	}
+	if cats := c.Get("footer_categories"); cats != nil {
+		data["FooterCategories"] = cats
+	}
+	if sols := c.Get("footer_solutions"); sols != nil {
+		data["FooterSolutions"] = sols
+	}
+	if res := c.Get("footer_resources"); res != nil {
+		data["FooterResources"] = res
+	}
+	data["NoIndex"] = true
+	setLang(c, data)
+
+	var buf bytes.Buffer
+	if err := c.Echo().Renderer.Render(&buf, "public/pages/newsletter.html", data, c); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", "public/pages/newsletter.html", "error", err)
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+	return c.HTML(http.StatusOK, minifyPage(c, buf.String()))
+}
@@ -0,0 +1,96 @@
+// Package public provides HTTP handlers for public-facing website features.
+// This file wraps rendered-page cache access for the public handlers.
+package public
+
+import (
+	"bytes"    // Buffering rendered pages before caching
+	"log/slog" // Logging template errors
+	"net/http" // HTTP status codes
+
+	"github.com/labstack/echo/v4"                             // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // Template debug overlay
+	"github.com/narendhupati/bluejay-cms/internal/services"   // In-memory page cache
+)
+
+// cachedPage returns the cached HTML for cacheKey. Admin ?debug=templates
+// requests always miss so the debug overlay reflects a fresh render.
+func cachedPage(c echo.Context, cache *services.Cache, cacheKey string) (interface{}, bool) {
+	if middleware.TemplateDebugFrom(c) != nil {
+		return nil, false
+	}
+	return cache.Get(cacheKey)
+}
+
+// pageWarmer re-renders cached pages after an admin change invalidates them.
+// When nil (e.g., in tests), invalidated pages are rendered by the next visitor.
+var pageWarmer *services.CacheWarmer
+
+// SetCacheWarmer sets the warmer told which path renders each cached page.
+//
+// Parameters:
+//   - w: CacheWarmer whose Invalidated is the page cache's OnDelete hook
+func SetCacheWarmer(w *services.CacheWarmer) {
+	pageWarmer = w
+}
+
+// cacheTagsKey is the echo context key under which dependsOn collects the
+// cache tags of the page being rendered.
+const cacheTagsKey = "cache_tags"
+
+// dependsOn records that the page being rendered shows the content the tags
+// name (see services.ProductTag), so cachePage tags its entry and an admin
+// edit to that content removes it with Cache.DeleteTagged. Pages without
+// tags are only removed by prefix.
+func dependsOn(c echo.Context, tags ...string) {
+	existing, _ := c.Get(cacheTagsKey).([]string)
+	c.Set(cacheTagsKey, append(existing, tags...))
+}
+
+// cachePage stores rendered HTML under cacheKey, tagged with what the page
+// declared through dependsOn, and reports the key and TTL to the debug
+// overlay. The request path is remembered by pageWarmer, with the locale
+// prefix the visitor used, so the page can be re-rendered when cacheKey is
+// invalidated.
+func cachePage(c echo.Context, cache *services.Cache, cacheKey, html string, ttlSeconds int) {
+	middleware.TemplateDebugFrom(c).RecordCache(cacheKey, ttlSeconds)
+	cache.Set(cacheKey, html, ttlSeconds)
+	if tags, _ := c.Get(cacheTagsKey).([]string); len(tags) > 0 {
+		cache.Tag(cacheKey, tags...)
+	}
+	if pageWarmer != nil && ttlSeconds > 0 {
+		path := c.Request().URL.RequestURI()
+		if locale := middleware.LocalePrefixFrom(c); locale != "" {
+			path = "/" + locale + path
+		}
+		pageWarmer.Remember(cacheKey, path)
+	}
+}
+
+// renderCachedPage renders a full page with the global settings and footer
+// data, caches it under cacheKey for ttlSeconds and sends it with 200 OK.
+// With ttlSeconds 0 the page is only sent, for renders that must not be
+// served to others (previews, a visitor's submitted answers).
+func renderCachedPage(c echo.Context, cache *services.Cache, logger *slog.Logger, cacheKey string, ttlSeconds int, templateName string, data map[string]interface{}) error {
+	if settings := c.Get("settings"); settings != nil {
+		data["Settings"] = settings
+	}
+	if cats := c.Get("footer_categories"); cats != nil {
+		data["FooterCategories"] = cats
+	}
+	if sols := c.Get("footer_solutions"); sols != nil {
+		data["FooterSolutions"] = sols
+	}
+	if res := c.Get("footer_resources"); res != nil {
+		data["FooterResources"] = res
+	}
+	var buf bytes.Buffer
+	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
+		logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
+		return err
+	}
+	html := minifyPage(c, buf.String())
+	if ttlSeconds > 0 {
+		cachePage(c, cache, cacheKey, html, ttlSeconds)
+	}
+	return c.HTML(http.StatusOK, html)
+}
@@ -0,0 +1,260 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the partner portal: the tokenized form an invited
+// partner uses to send a new logo, description, website and tier evidence
+// for admin review.
+package public
+
+import (
+	// Standard library imports
+	"bytes"        // Rendering into a buffer
+	"context"      // Verifying link tokens
+	"database/sql" // sql.ErrNoRows when no submission is pending
+	"errors"       // Portal error inspection
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+	"strconv"      // Parsing the requested tier, formatting the invite ID
+	"strings"      // Capitalizing error messages
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Link authentication, keeping the token out of page views
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Partner portal invites and submissions
+)
+
+// PartnerPortalHandler serves /partner-portal/:token.
+//
+// The page belongs to one partner, so it is never cached and not indexed.
+type PartnerPortalHandler struct {
+	portal  *services.PartnerPortal // Invite lookup and submissions
+	queries sqlc.Querier            // Partner, tiers and the pending submission
+	logger  *slog.Logger            // Structured logger for errors
+}
+
+// NewPartnerPortalHandler creates a new PartnerPortalHandler with the required dependencies.
+func NewPartnerPortalHandler(portal *services.PartnerPortal, queries sqlc.Querier, logger *slog.Logger) *PartnerPortalHandler {
+	return &PartnerPortalHandler{portal: portal, queries: queries, logger: logger}
+}
+
+// partnerInviteResource is the Principal.Resource of requests admitted by a
+// portal link.
+const partnerInviteResource = "partner_invite"
+
+// Authenticator returns the authenticator of the portal routes: the link
+// token in the URL is checked like any other token credential, and unknown,
+// expired and revoked links get the explanation page.
+//
+// Example usage:
+//
+//	portalAuth := customMiddleware.Authenticate(partnerPortalHandler.Authenticator())
+//	publicGroup.GET("/partner-portal/:token", partnerPortalHandler.Page, portalAuth)
+func (h *PartnerPortalHandler) Authenticator() customMiddleware.Authenticator {
+	return customMiddleware.TokenAuthenticator{
+		Verifier: customMiddleware.TokenVerifierFunc(h.verifyLink),
+		Param:    "token",
+		LinkOnly: true,
+		Deny:     h.denyLink,
+	}
+}
+
+// verifyLink resolves a portal link token to a principal limited to its invite.
+func (h *PartnerPortalHandler) verifyLink(ctx context.Context, token string) (*customMiddleware.Principal, error) {
+	invite, err := h.portal.Open(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &customMiddleware.Principal{
+		Email:      invite.Email,
+		Method:     "partner_link",
+		Subject:    partnerInviteResource + "." + strconv.FormatInt(invite.ID, 10),
+		Resource:   partnerInviteResource,
+		ResourceID: invite.ID,
+	}, nil
+}
+
+// denyLink answers requests with an unknown, expired or revoked link.
+func (h *PartnerPortalHandler) denyLink(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrPartnerInviteInvalid) || errors.Is(err, customMiddleware.ErrNoCredentials) {
+		return h.render(c, http.StatusOK, map[string]interface{}{"State": "invalid"})
+	}
+	h.logger.ErrorContext(c.Request().Context(), "failed to open partner invite", "error", err)
+	return echo.NewHTTPError(http.StatusInternalServerError)
+}
+
+// invite returns the invite of the portal link admitted by Authenticator.
+func (h *PartnerPortalHandler) invite(c echo.Context) (sqlc.PartnerInvite, error) {
+	p := customMiddleware.PrincipalFrom(c)
+	if p == nil || p.Resource != partnerInviteResource {
+		return sqlc.PartnerInvite{}, services.ErrPartnerInviteInvalid
+	}
+	return h.queries.GetPartnerInvite(c.Request().Context(), p.ResourceID)
+}
+
+// partnerPortalForm holds the values shown in the portal form.
+type partnerPortalForm struct {
+	ContactName  string
+	Description  string
+	WebsiteURL   string
+	TierID       int64
+	TierEvidence string
+}
+
+// Page handles GET /partner-portal/:token
+// Shows the partner's current listing and the update form, filled in from
+// the submission awaiting review or else from the live listing. The route
+// is mounted behind Authenticator, so unknown, expired and revoked links
+// get an explanation instead.
+//
+// Template: public/pages/partner_portal.html (full page)
+func (h *PartnerPortalHandler) Page(c echo.Context) error {
+	ctx := c.Request().Context()
+	invite, err := h.invite(c)
+	if err != nil {
+		return h.denyLink(c, err)
+	}
+	partner, err := h.queries.GetPartner(ctx, invite.PartnerID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load partner", "error", err, "id", invite.PartnerID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	form := partnerPortalForm{
+		Description: partner.Description.String,
+		WebsiteURL:  partner.WebsiteUrl.String,
+		TierID:      partner.TierID,
+	}
+	pending, err := h.queries.GetPendingPartnerSubmission(ctx, partner.ID)
+	switch {
+	case err == nil:
+		form = partnerPortalForm{
+			ContactName:  pending.ContactName,
+			Description:  pending.Description,
+			WebsiteURL:   pending.WebsiteUrl,
+			TierID:       partner.TierID,
+			TierEvidence: pending.TierEvidence,
+		}
+		if pending.RequestedTierID.Valid {
+			form.TierID = pending.RequestedTierID.Int64
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		h.logger.ErrorContext(ctx, "failed to load pending partner submission", "error", err, "partner", partner.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.renderForm(c, http.StatusOK, partner, form, map[string]interface{}{
+		"Pending": err == nil,
+	})
+}
+
+// Submit handles POST /partner-portal/:token
+// Stores the form as the partner's pending submission (replacing one not
+// reviewed yet) and thanks the partner. Invalid values re-render the form
+// with the error and status 422.
+//
+// Form fields (multipart):
+//   - contact_name: Person sending the update (required)
+//   - description, website_url: New listing text and website
+//   - tier_id: Tier asked for; the current tier keeps it
+//   - tier_evidence: Proof for a tier change (required when changing)
+//   - logo: New logo (JPG, PNG or WebP up to 2 MB), optional
+//
+// Template: public/pages/partner_portal.html (full page)
+func (h *PartnerPortalHandler) Submit(c echo.Context) error {
+	ctx := c.Request().Context()
+	invite, err := h.invite(c)
+	if err != nil {
+		return h.denyLink(c, err)
+	}
+	partner, err := h.queries.GetPartner(ctx, invite.PartnerID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load partner", "error", err, "id", invite.PartnerID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	tierID, _ := strconv.ParseInt(c.FormValue("tier_id"), 10, 64)
+	form := partnerPortalForm{
+		ContactName:  c.FormValue("contact_name"),
+		Description:  c.FormValue("description"),
+		WebsiteURL:   c.FormValue("website_url"),
+		TierID:       tierID,
+		TierEvidence: c.FormValue("tier_evidence"),
+	}
+	in := services.PartnerSubmissionInput{
+		ContactName:     form.ContactName,
+		Description:     form.Description,
+		WebsiteURL:      form.WebsiteURL,
+		RequestedTierID: form.TierID,
+		TierEvidence:    form.TierEvidence,
+		IP:              c.RealIP(),
+	}
+	if logo, err := c.FormFile("logo"); err == nil {
+		in.Logo = logo
+	}
+
+	_, err = h.portal.Submit(ctx, invite, in)
+	switch {
+	case errors.Is(err, services.ErrPartnerContactRequired), errors.Is(err, services.ErrPartnerWebsiteInvalid),
+		errors.Is(err, services.ErrPartnerDescriptionTooLong), errors.Is(err, services.ErrPartnerTierInvalid),
+		errors.Is(err, services.ErrPartnerTierEvidenceRequired), errors.Is(err, services.ErrPartnerLogoInvalid):
+		msg := err.Error()
+		return h.renderForm(c, http.StatusUnprocessableEntity, partner, form, map[string]interface{}{
+			"Error": strings.ToUpper(msg[:1]) + msg[1:] + ".",
+		})
+	case err != nil:
+		h.logger.ErrorContext(ctx, "failed to store partner submission", "error", err, "partner", partner.ID)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return h.render(c, http.StatusOK, map[string]interface{}{
+		"State":   "sent",
+		"Partner": partner,
+	})
+}
+
+// renderForm renders the portal form for a partner with the tiers it can
+// ask for.
+func (h *PartnerPortalHandler) renderForm(c echo.Context, status int, partner sqlc.GetPartnerRow, form partnerPortalForm, data map[string]interface{}) error {
+	tiers, err := h.queries.ListPartnerTiers(c.Request().Context())
+	if err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to list partner tiers", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	data["State"] = "form"
+	data["Partner"] = partner
+	data["Tiers"] = tiers
+	data["Form"] = form
+	data["Token"] = c.Param("token")
+	data["MaxDescription"] = services.MaxPartnerDescriptionLength
+	return h.render(c, status, data)
+}
+
+// render renders the portal page with the footer data of the public
+// layout, uncached.
+func (h *PartnerPortalHandler) render(c echo.Context, status int, data map[string]interface{}) error {
+	if settings := c.Get("settings"); settings != nil {
+		data["Settings"] = settings
+	}
+	if cats := c.Get("footer_categories"); cats != nil {
+		data["FooterCategories"] = cats
+	}
+	if sols := c.Get("footer_solutions"); sols != nil {
+		data["FooterSolutions"] = sols
+	}
+	if res := c.Get("footer_resources"); res != nil {
+		data["FooterResources"] = res
+	}
+	data["Title"] = "Partner Portal"
+	data["NoIndex"] = true
+	setLang(c, data)
+
+	var buf bytes.Buffer
+	if err := c.Echo().Renderer.Render(&buf, "public/pages/partner_portal.html", data, c); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", "public/pages/partner_portal.html", "error", err)
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+	// The link token is in the URL; keep it out of Referer headers and analytics
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+	customMiddleware.SkipPageView(c)
+	return c.HTML(status, minifyPage(c, buf.String()))
+}
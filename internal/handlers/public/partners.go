@@ -22,14 +22,14 @@ import (
 // It manages displaying partner companies organized by tier (e.g., Platinum, Gold, Silver)
 // and partner testimonials to build credibility.
 type PartnersHandler struct {
-	queries *sqlc.Queries    // Database query interface for fetching partners, tiers, and testimonials
-	logger  *slog.Logger     // Structured logger for debugging and error tracking
-	cache   *services.Cache  // In-memory cache for rendered HTML to improve response times
+	queries sqlc.Querier    // Database query interface for fetching partners, tiers, and testimonials
+	logger  *slog.Logger    // Structured logger for debugging and error tracking
+	cache   *services.Cache // In-memory cache for rendered HTML to improve response times
 }
 
 // NewPartnersHandler constructs a new PartnersHandler with required dependencies.
 // This constructor is called during application initialization to wire up dependencies.
-func NewPartnersHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *PartnersHandler {
+func NewPartnersHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *PartnersHandler {
 	return &PartnersHandler{
 		queries: queries,
 		logger:  logger,
@@ -71,13 +71,13 @@ func (h *PartnersHandler) renderAndCache(c echo.Context, cacheKey string, ttlSec
 	// This allows us to cache the rendered HTML before sending it
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Extract rendered HTML from buffer and store in cache for future requests
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 
 	// Return the rendered HTML to the client with specified status code
 	return c.HTML(statusCode, html)
@@ -102,7 +102,7 @@ func (h *PartnersHandler) PartnersPage(c echo.Context) error {
 	cacheKey := "page:partners"
 
 	// Check if cached version exists and return it immediately to improve performance
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -113,7 +113,7 @@ func (h *PartnersHandler) PartnersPage(c echo.Context) error {
 	// Query returns partners with tier information joined, ordered by tier priority
 	allPartners, err := h.queries.ListPartnersByTier(ctx)
 	if err != nil {
-		h.logger.Error("failed to load partners", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load partners", "error", err)
 		allPartners = []sqlc.ListPartnersByTierRow{} // Default to empty slice to prevent template errors
 	}
 
@@ -121,7 +121,7 @@ func (h *PartnersHandler) PartnersPage(c echo.Context) error {
 	// Each tier has a name, description, display order, and optional color/icon
 	tiers, err := h.queries.ListPartnerTiers(ctx)
 	if err != nil {
-		h.logger.Error("failed to load partner tiers", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load partner tiers", "error", err)
 		tiers = []sqlc.PartnerTier{} // Default to empty slice
 	}
 
@@ -129,7 +129,7 @@ func (h *PartnersHandler) PartnersPage(c echo.Context) error {
 	// Testimonials from partner companies help build trust with potential partners
 	testimonials, err := h.queries.ListActiveTestimonials(ctx)
 	if err != nil {
-		h.logger.Error("failed to load testimonials", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load testimonials", "error", err)
 		testimonials = []sqlc.ListActiveTestimonialsRow{} // Default to empty slice
 	}
 
@@ -0,0 +1,126 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the press newsroom: the /press page with press
+// releases, "In the news" links and the media kit, and the release pages.
+package public
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for 404 detection
+	"errors"       // Error inspection
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+	"time"         // Embargo checks
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Press clock, paths, file types and Markdown
+)
+
+// pressPageTTL caches press pages for five minutes: embargoed releases go
+// out by the clock rather than by an edit.
+const pressPageTTL = 300
+
+// PressHandler serves /press and the pages of released press releases.
+type PressHandler struct {
+	queries sqlc.Querier    // Database query interface for press content
+	logger  *slog.Logger    // Structured logger for errors
+	cache   *services.Cache // In-memory cache for rendered pages
+}
+
+// NewPressHandler creates a new PressHandler with the required dependencies.
+func NewPressHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *PressHandler {
+	return &PressHandler{queries: queries, logger: logger, cache: cache}
+}
+
+// pressKitItem is a media kit file with the type label shown next to it.
+type pressKitItem struct {
+	sqlc.PressKitAsset
+	FileType string // e.g. "PDF", "SVG"
+}
+
+// List handles GET /press
+// Renders the released press releases, latest first, the newest "In the
+// news" links and the media kit. Embargoed and draft releases are left
+// out.
+//
+// Template: public/pages/press.html (full page)
+// Cache: 5 minutes under "page:press"
+func (h *PressHandler) List(c echo.Context) error {
+	if cached, ok := cachedPage(c, h.cache, "page:press"); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	releases, err := h.queries.ListReleasedPressReleases(ctx, time.Now().UTC().Format(services.PressClockLayout))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list press releases", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	mentions, err := h.queries.ListPublishedPressMentions(ctx, services.PressMentionsShown)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list press mentions", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	assets, err := h.queries.ListPressKitAssets(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to list press kit assets", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	kit := make([]pressKitItem, len(assets))
+	for i, a := range assets {
+		kit[i] = pressKitItem{PressKitAsset: a, FileType: services.PressKitFileType(a.FilePath)}
+	}
+	return renderCachedPage(c, h.cache, h.logger, "page:press", pressPageTTL, "public/pages/press.html", map[string]interface{}{
+		"Title":           "Press",
+		"MetaDescription": "Press releases, news coverage and the media kit.",
+		"CanonicalURL":    "/press",
+		"CurrentPage":     "press",
+		"Releases":        releases,
+		"Mentions":        mentions,
+		"Assets":          kit,
+	})
+}
+
+// Detail handles GET /press/:slug
+// Renders a released press release with its dateline and Markdown body.
+// Drafts and releases still under embargo are not found.
+//
+// Template: public/pages/press_release.html (full page)
+// Cache: 5 minutes under "page:press:<slug>"
+func (h *PressHandler) Detail(c echo.Context) error {
+	cacheKey := "page:press:" + c.Param("slug")
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
+	}
+	ctx := c.Request().Context()
+	release, err := h.queries.GetReleasedPressReleaseBySlug(ctx, sqlc.GetReleasedPressReleaseBySlugParams{
+		Slug: c.Param("slug"),
+		Now:  time.Now().UTC().Format(services.PressClockLayout),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound, "Press release not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load press release", "error", err, "slug", c.Param("slug"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	body, err := services.RenderMarkdown(release.Body)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to render press release", "error", err, "slug", release.Slug)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	metaDesc := release.MetaDescription
+	if metaDesc == "" {
+		metaDesc = release.Summary
+	}
+	return renderCachedPage(c, h.cache, h.logger, cacheKey, pressPageTTL, "public/pages/press_release.html", map[string]interface{}{
+		"Title":           release.Title,
+		"MetaDescription": metaDesc,
+		"CanonicalURL":    services.PressReleasePath(release),
+		"CurrentPage":     "press",
+		"Release":         release,
+		"Body":            body,
+	})
+}
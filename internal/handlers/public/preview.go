@@ -3,59 +3,45 @@
 package public
 
 import (
-	"github.com/labstack/echo/v4"                                // Echo web framework for HTTP request/response handling
-	"github.com/narendhupati/bluejay-cms/internal/middleware" // Session middleware for authentication checking
+	"github.com/labstack/echo/v4"                             // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // Preview grants verified by middleware.Preview
 )
 
-// isPreviewRequest checks if the request is a preview request with a valid admin session.
+// previewID reports whether the request carries a preview token for an item of
+// contentType, and which item it unlocks.
 //
 // Preview Mode:
-//   - Allows authenticated admin users to view draft/unpublished content
-//   - Triggered by ?preview=true query parameter
-//   - Requires active admin session (user must be logged in)
-//   - Used across all public content handlers (blog posts, products, case studies, etc.)
+//   - Lets editors and the reviewers they share links with see drafts
+//   - Triggered by ?preview_token=, a signed, expiring token issued on the
+//     admin edit pages (see services.PreviewTokens)
+//   - The token is verified by middleware.Preview; invalid or expired tokens
+//     never reach the handler (403)
+//   - Used across public content handlers (blog posts, products, solutions,
+//     case studies, whitepapers)
 //
 // Security:
-//   - Only authenticated users can preview content (prevents public access to drafts)
-//   - Session validation ensures preview parameter can't be exploited
-//   - UserID > 0 check ensures session contains valid user data
+//   - A token unlocks exactly one item: handlers load the draft by slug and
+//     must return 404 when its ID differs from the returned id
+//   - Tokens for other content types are ignored, so a blog post token does
+//     not open a product page in preview mode
 //
 // Usage Pattern:
-//   In public content handlers:
-//     if isPreviewRequest(c) {
-//         // Fetch content regardless of status (include drafts)
-//     } else {
-//         // Only fetch published content
-//     }
 //
-// Query Parameter:
-//   - preview=true: Enable preview mode
-//   - Any other value or omitted: Normal public mode
-//
-// Session Requirements:
-//   - Must have valid session object in Echo context
-//   - Session must contain UserID > 0 (authenticated admin user)
-//   - Session created by middleware.SessionMiddleware
+//	In public content handlers:
+//	  previewItemID, preview := previewID(c, services.PreviewBlogPost)
+//	  if preview {
+//	      // Fetch content regardless of status, 404 unless ID == previewItemID
+//	  } else {
+//	      // Only fetch published content
+//	  }
 //
 // Return Values:
-//   - true: Valid preview request (authenticated admin with preview param)
-//   - false: Normal request (no preview param OR not authenticated)
-func isPreviewRequest(c echo.Context) bool {
-	// Check for preview query parameter
-	// Must be exactly "true" (case-sensitive)
-	if c.QueryParam("preview") != "true" {
-		return false
-	}
-
-	// Validate admin session exists and contains authenticated user
-	// Session object is placed in context by middleware.SessionMiddleware
-	sess, ok := c.Get("session").(*middleware.Session)
-	if !ok || sess == nil || sess.UserID == 0 {
-		// No valid session - deny preview access
-		// This prevents unauthorized users from viewing drafts via ?preview=true
-		return false
+//   - id: ID of the item the token unlocks (0 when not previewing)
+//   - ok: true for a valid preview token of contentType
+func previewID(c echo.Context, contentType string) (id int64, ok bool) {
+	grant, ok := middleware.PreviewFrom(c)
+	if !ok || grant.Type != contentType {
+		return 0, false
 	}
-
-	// Valid preview request: authenticated admin with preview parameter
-	return true
+	return grant.ID, true
 }
@@ -0,0 +1,20 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file contains helpers shared by the /print routes, which render
+// product and case study pages in the print layout for printing and PDF
+// generation.
+package public
+
+import (
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - response headers
+)
+
+// printCacheSuffix is appended to a page's cache key for its print version.
+// Both keys share the page prefix and are cleared together on admin edits.
+const printCacheSuffix = ":print"
+
+// preparePrint keeps print pages out of search indexes; the print layout's
+// canonical link already points crawlers at the regular page.
+func preparePrint(c echo.Context) {
+	c.Response().Header().Set("X-Robots-Tag", "noindex")
+}
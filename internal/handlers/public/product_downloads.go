@@ -0,0 +1,250 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves product downloads, including gated ones.
+package public
+
+import (
+	// Standard library imports
+	"bytes"        // Buffer for rendering HTMX fragments
+	"database/sql" // Nullable lead fields and sql.ErrNoRows for 404 detection
+	"fmt"          // Product page and download URLs
+	"io"           // Streaming gated files
+	"log/slog"     // Structured logging for errors
+	"mime"         // Content type and file name of gated files
+	"net/http"     // HTTP status codes
+	"net/url"      // Escaping the download token
+	"path"         // File name and extension of gated files
+	"strconv"      // Download ID parsing
+	"strings"      // Trimming form values
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"                              // sqlc-generated database queries
+	customMiddleware "github.com/narendhupati/bluejay-cms/internal/middleware" // Signed-in customer
+	"github.com/narendhupati/bluejay-cms/internal/services"                    // Signed download links, bot detection
+)
+
+// ProductDownloadsHandler serves the files listed under a product's
+// downloads. Every version of every file is linked through it, so each
+// keeps its own download count. Gated files are only served with a signed
+// link, which visitors get by filling in the lead form, or straight away
+// when they are signed in to a customer account.
+type ProductDownloadsHandler struct {
+	queries sqlc.Querier            // Database query interface for downloads and leads
+	logger  *slog.Logger            // Structured logger for errors
+	links   *services.DownloadLinks // Signs and verifies gated download links
+	files   *services.DownloadFiles // Gated files, kept outside the public uploads
+}
+
+// NewProductDownloadsHandler creates a new ProductDownloadsHandler with the required dependencies.
+func NewProductDownloadsHandler(queries sqlc.Querier, logger *slog.Logger, links *services.DownloadLinks, files *services.DownloadFiles) *ProductDownloadsHandler {
+	return &ProductDownloadsHandler{queries: queries, logger: logger, links: links, files: files}
+}
+
+// download loads the published download named by the :id parameter.
+//
+// Returns:
+//   - id, download: The download and its product's URL slugs
+//   - err: 404 for unknown downloads and downloads of unpublished products,
+//     500 on database errors
+func (h *ProductDownloadsHandler) download(c echo.Context) (int64, sqlc.GetPublishedProductDownloadRow, error) {
+	ctx := c.Request().Context()
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, sqlc.GetPublishedProductDownloadRow{}, echo.NewHTTPError(http.StatusNotFound, "Download not found")
+	}
+	d, err := h.queries.GetPublishedProductDownload(ctx, id)
+	if err == sql.ErrNoRows {
+		return 0, d, echo.NewHTTPError(http.StatusNotFound, "Download not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get product download", "error", err, "id", id)
+		return 0, d, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return id, d, nil
+}
+
+// Download handles GET /product-downloads/:id
+// Counts a download of one version of a product file and redirects to the
+// file; crawlers and tools are redirected without being counted. Gated files
+// need ?token= from the lead form; without a valid one the visitor is sent
+// back to the product's downloads, where the form is. Gated files are not in
+// the public uploads, so they are streamed rather than redirected to.
+//
+// Error Handling:
+//   - Returns 404 for unknown downloads and downloads of unpublished products,
+//     and for gated files missing from GATED_DOWNLOAD_DIR
+//   - Returns 500 on database errors
+func (h *ProductDownloadsHandler) Download(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, d, err := h.download(c)
+	if err != nil {
+		return err
+	}
+	if d.IsGated == 1 {
+		if err := h.links.Verify(c.QueryParam("token"), id); err != nil {
+			return c.Redirect(http.StatusFound, fmt.Sprintf("/products/%s/%s#downloads", d.CategorySlug, d.ProductSlug))
+		}
+	}
+
+	if services.ClassifyUserAgent(c.Request().UserAgent()) != services.UABot {
+		if err := h.queries.IncrementDownloadCount(ctx, id); err != nil {
+			h.logger.ErrorContext(ctx, "failed to count product download", "error", err, "id", id)
+		}
+	}
+	if d.IsGated != 1 {
+		return c.Redirect(http.StatusFound, d.FilePath)
+	}
+
+	f, err := h.files.Open(ctx, d.FilePath)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to open gated product download", "error", err, "id", id)
+		return echo.NewHTTPError(http.StatusNotFound, "Download not found")
+	}
+	defer f.Close()
+	contentType := mime.TypeByExtension(path.Ext(d.FilePath))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(d.FilePath)}))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().WriteHeader(http.StatusOK)
+	_, err = io.Copy(c.Response(), f)
+	return err
+}
+
+// LeadForm handles GET /product-downloads/:id/form
+// Renders the lead form of a gated download; the product page loads it into
+// a modal with HTMX. Signed-in customers skip the form: the lead is recorded
+// from their account, the file is added to their library and the signed
+// link is returned straight away.
+//
+// Template: public/partials/download_lead_form.html (HTMX fragment), or
+// public/partials/download_lead_success.html for signed-in customers
+//
+// Error Handling:
+//   - Returns 404 for ungated downloads, which need no form
+//   - Returns 500 on database errors
+func (h *ProductDownloadsHandler) LeadForm(c echo.Context) error {
+	id, d, err := h.download(c)
+	if err != nil {
+		return err
+	}
+	if d.IsGated != 1 {
+		return echo.NewHTTPError(http.StatusNotFound, "Download not found")
+	}
+	data := map[string]interface{}{}
+	if account, ok := customMiddleware.CustomerFrom(c); ok {
+		data, err = h.grant(c, id, d, sqlc.CreateProductDownloadLeadParams{
+			Name:             account.Name,
+			Email:            account.Email,
+			Company:          account.Company,
+			Designation:      sql.NullString{String: account.Designation, Valid: account.Designation != ""},
+			MarketingConsent: account.MarketingConsent,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	data["ID"] = id
+	data["Download"] = d
+	return h.renderFragment(c, "public/partials/download_lead_form.html", data)
+}
+
+// Lead handles POST /product-downloads/:id/lead
+// Records the lead form of a gated download like a whitepaper download, then
+// returns the signed download link. The link counts the download when it is
+// followed, not here.
+//
+// Form Fields: name, email, company (required), designation, marketing_consent
+//
+// Template: public/partials/download_lead_success.html (HTMX fragment)
+//
+// Error Handling:
+//   - Returns 400 with an error fragment when a required field is missing
+//   - Returns 404 for ungated downloads
+//   - Returns 500 on database errors
+func (h *ProductDownloadsHandler) Lead(c echo.Context) error {
+	id, d, err := h.download(c)
+	if err != nil {
+		return err
+	}
+	if d.IsGated != 1 {
+		return echo.NewHTTPError(http.StatusNotFound, "Download not found")
+	}
+
+	name := strings.TrimSpace(c.FormValue("name"))
+	email := strings.TrimSpace(c.FormValue("email"))
+	company := strings.TrimSpace(c.FormValue("company"))
+	designation := strings.TrimSpace(c.FormValue("designation"))
+	if name == "" || email == "" || company == "" {
+		return c.HTML(http.StatusBadRequest, `<div class="alert alert-error">Name, email, and company are required.</div>`)
+	}
+	var consent int64
+	if v := c.FormValue("marketing_consent"); v == "on" || v == "1" || v == "true" {
+		consent = 1
+	}
+
+	data, err := h.grant(c, id, d, sqlc.CreateProductDownloadLeadParams{
+		Name:             name,
+		Email:            email,
+		Company:          company,
+		Designation:      sql.NullString{String: designation, Valid: designation != ""},
+		MarketingConsent: consent,
+	})
+	if err != nil {
+		return err
+	}
+	return h.renderFragment(c, "public/partials/download_lead_success.html", data)
+}
+
+// grant records the lead of a gated download and adds the file to the
+// library of a signed-in customer.
+//
+// Returns:
+//   - data: Template data of the success message with the signed link
+//   - err: 500 on database errors
+func (h *ProductDownloadsHandler) grant(c echo.Context, id int64, d sqlc.GetPublishedProductDownloadRow, lead sqlc.CreateProductDownloadLeadParams) (map[string]interface{}, error) {
+	ctx := c.Request().Context()
+	lead.ProductDownloadID = id
+	lead.IpAddress = sql.NullString{String: c.RealIP(), Valid: c.RealIP() != ""}
+	lead.UserAgent = sql.NullString{String: c.Request().UserAgent(), Valid: c.Request().UserAgent() != ""}
+	if _, err := h.queries.CreateProductDownloadLead(ctx, lead); err != nil {
+		h.logger.ErrorContext(ctx, "failed to record product download lead", "error", err, "id", id)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	account, signedIn := customMiddleware.CustomerFrom(c)
+	if signedIn {
+		err := h.queries.AddCustomerLibraryProductDownload(ctx, sqlc.AddCustomerLibraryProductDownloadParams{
+			AccountID:         account.ID,
+			ProductDownloadID: sql.NullInt64{Int64: id, Valid: true},
+		})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to add product download to customer library", "error", err, "id", id, "account", account.ID)
+		}
+	}
+
+	return map[string]interface{}{
+		"Title":       d.Title,
+		"Email":       lead.Email,
+		"Account":     signedIn,
+		"DownloadURL": fmt.Sprintf("/product-downloads/%d?token=%s", id, url.QueryEscape(h.links.Issue(id))),
+		"ValidHours":  int(h.links.TTL().Hours()),
+	}, nil
+}
+
+// renderFragment renders an HTMX fragment. Fragments depend on the visitor's
+// submission, so they are never cached.
+func (h *ProductDownloadsHandler) renderFragment(c echo.Context, name string, data map[string]interface{}) error {
+	if settings := c.Get("settings"); settings != nil {
+		data["Settings"] = settings
+	}
+	var buf bytes.Buffer
+	if err := c.Echo().Renderer.Render(&buf, name, data, c); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", name, "error", err)
+		return err
+	}
+	return c.HTML(http.StatusOK, buf.String())
+}
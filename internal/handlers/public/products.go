@@ -6,19 +6,22 @@ package public
 import (
 	// Standard library imports
 	"bytes"       // Buffer for template rendering to enable caching
+	"context"     // Request context for loading the category tree
 	"database/sql" // SQL error handling (sql.ErrNoRows for 404 detection)
 	"fmt"         // String formatting for error messages and template data
 	"log/slog"    // Structured logging for debugging and error tracking
 	"net/http"    // HTTP status codes and request/response handling
-	"strconv"     // String to integer conversion for pagination parameters
 	"strings"     // String manipulation for placeholder replacement in CTA text
+	"time"        // Current time for the "new" product filter
+	"unicode/utf8" // Minimum live search query length
 
 	// Third-party imports
 	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc"          // sqlc-generated database queries
-	"github.com/narendhupati/bluejay-cms/internal/services" // Business logic services (ProductService, Cache)
+	"github.com/narendhupati/bluejay-cms/db/sqlc"            // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // SEO overrides for the page's content
+	"github.com/narendhupati/bluejay-cms/internal/services"   // Business logic services (ProductService, Cache)
 )
 
 // ProductsHandler handles all product-related public routes including
@@ -26,21 +29,23 @@ import (
 // It implements caching for improved performance and uses the ProductService
 // for complex product data aggregation.
 type ProductsHandler struct {
-	queries    *sqlc.Queries              // Database query interface for product data
-	logger     *slog.Logger               // Structured logger for errors and debugging
-	productSvc *services.ProductService   // Business logic for product detail aggregation
-	cache      *services.Cache            // In-memory cache for rendered HTML pages
+	queries    sqlc.Querier                    // Database query interface for product data
+	logger     *slog.Logger                    // Structured logger for errors and debugging
+	productSvc *services.ProductService        // Business logic for product detail aggregation
+	cache      *services.Cache                 // In-memory cache for rendered HTML pages
+	related    *services.RelatedContentService // Case studies, posts and whitepapers for detail pages
 }
 
 // NewProductsHandler creates a new ProductsHandler with the required dependencies.
 // The cache is used to store rendered HTML to reduce database load and improve
 // response times for frequently accessed pages.
-func NewProductsHandler(queries *sqlc.Queries, logger *slog.Logger, productSvc *services.ProductService, cache *services.Cache) *ProductsHandler {
+func NewProductsHandler(queries sqlc.Querier, logger *slog.Logger, productSvc *services.ProductService, cache *services.Cache) *ProductsHandler {
 	return &ProductsHandler{
 		queries:    queries,
 		logger:     logger,
 		productSvc: productSvc,
 		cache:      cache,
+		related:    services.NewRelatedContentService(queries, cache),
 	}
 }
 
@@ -90,13 +95,13 @@ func (h *ProductsHandler) renderAndCache(c echo.Context, cacheKey string, ttlSec
 	// This allows us to cache the HTML before sending it
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Extract rendered HTML and store in cache for future requests
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 
 	// Return the rendered HTML to the client
 	return c.HTML(statusCode, html)
@@ -111,14 +116,15 @@ func (h *ProductsHandler) renderAndCache(c echo.Context, cacheKey string, ttlSec
 // Cache TTL: 600 seconds (10 minutes)
 //
 // Purpose:
-// Displays an overview of all product categories with product counts.
+// Displays an overview of the top-level product families with product counts.
 // This is the main product landing page that helps users browse categories
-// before drilling down into specific products.
+// before drilling down into series, types, and specific products.
 //
 // Template Data:
 //   - Title: "Products" - Browser tab title
-//   - Categories: []categoryWithCount - Product categories with count of products in each
-//   - TotalCount: int - Total number of categories
+//   - Categories: []categoryWithCount - Top-level families with the count of products
+//     in each, rolled up from their subcategories
+//   - TotalCount: int - Total number of families
 //   - PageHero: sqlc.PageSection - Hero section content (heading, description, image)
 //   - CategoriesSection: sqlc.PageSection - Categories section heading/description
 //   - PageCTA: sqlc.PageSection - Call-to-action section
@@ -133,17 +139,17 @@ func (h *ProductsHandler) renderAndCache(c echo.Context, cacheKey string, ttlSec
 //   - Cache is invalidated when categories or products are modified in admin
 func (h *ProductsHandler) ProductsList(c echo.Context) error {
 	// Check cache first for fast response on repeated requests
-	cacheKey := "page:products"
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	cacheKey := localizedCacheKey(c, "page:products")
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
 	ctx := c.Request().Context()
 
-	// Fetch all product categories from database
-	categories, err := h.queries.ListProductCategories(ctx)
+	// Fetch the category hierarchy with product counts rolled up to each family
+	tree, err := h.categoryTree(ctx, true)
 	if err != nil {
-		h.logger.Error("failed to list product categories", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list product categories", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -154,14 +160,13 @@ func (h *ProductsHandler) ProductsList(c echo.Context) error {
 		Count    int64
 	}
 
-	// Fetch product count for each category
-	// This helps users understand the size of each category before clicking
+	// Only families are listed here; series and types appear on their pages.
+	// Counts include products in subcategories so users see the family's size.
 	var categoriesWithCount []categoryWithCount
-	for _, cat := range categories {
-		count, _ := h.queries.CountProductsByCategory(ctx, cat.ID)
+	for _, root := range tree.Roots {
 		categoriesWithCount = append(categoriesWithCount, categoryWithCount{
-			Category: cat,
-			Count:    count,
+			Category: root.Category,
+			Count:    root.Total,
 		})
 	}
 
@@ -170,23 +175,32 @@ func (h *ProductsHandler) ProductsList(c echo.Context) error {
 	heroSection, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "products", SectionKey: "hero"})
 	categoriesSection, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "products", SectionKey: "categories_section"})
 	ctaSection, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "products", SectionKey: "cta"})
+	heroSection, categoriesSection, ctaSection = localizeSection(c, heroSection), localizeSection(c, categoriesSection), localizeSection(c, ctaSection)
 
 	// Assemble template data
 	data := map[string]interface{}{
 		"Title":             "Products",
 		"Categories":        categoriesWithCount, // Categories with product counts
-		"TotalCount":        len(categories),     // Total number of categories
+		"TotalCount":        len(tree.Roots),     // Total number of families
 		"PageHero":          heroSection,         // Hero section content
 		"CategoriesSection": categoriesSection,   // Categories section heading
 		"PageCTA":           ctaSection,          // CTA section
 	}
+	setLang(c, data)
+	dependsOn(c, services.ProductCatalogTag)
 
 	// Render template and cache for 10 minutes
 	// Template: templates/public/pages/products.html
 	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, "public/pages/products.html", data)
 }
 
-// ProductsByCategory handles GET requests to view products within a specific category.
+// categoryTree loads the product category hierarchy. With counts, each
+// category's published product count is fetched so totals roll up to parents.
+func (h *ProductsHandler) categoryTree(ctx context.Context, withCounts bool) (*services.CategoryTree, error) {
+	return loadCategoryTree(ctx, h.queries, withCounts)
+}
+
+// ProductsByCategory handles GET requests to view a top-level product family.
 //
 // HTTP Method: GET
 // Route: /products/:category (e.g., /products/electronics)
@@ -196,113 +210,238 @@ func (h *ProductsHandler) ProductsList(c echo.Context) error {
 // Cache TTL: 600 seconds (10 minutes)
 //
 // Purpose:
-// Displays a paginated list of products within a specific category.
-// Users can navigate through pages if the category has more than 12 products.
+// Displays the family's subcategories and a paginated list of products in the
+// family and all of its subcategories (see renderCategoryPage).
 //
 // URL Parameters:
 //   - category: URL slug of the product category (e.g., "industrial-sensors")
 //
 // Query Parameters:
 //   - page: Page number for pagination (default: 1, minimum: 1)
-//
-// Template Data:
-//   - Title: "{Category Name} | Products" - Browser tab title
-//   - Category: sqlc.ProductCategory - Category details (name, description, icon)
-//   - Products: []sqlc.Product - Products in this category (max 12 per page)
-//   - TotalCount: int64 - Total number of products in category
-//   - CurrentPage: int - Current page number
-//   - TotalPages: int - Total number of pages
-//   - CategoryHero: sqlc.PageSection - Hero section content
-//   - EmptyState: sqlc.PageSection - Content to show if category has no products
-//
-// SEO Considerations:
-//   - Page title includes category name for better search relevance
-//   - Pagination helps manage large product catalogs
-//   - Empty state section provides guidance when category has no products
+//   - sort: name_asc, name_desc, newest, oldest or sku (default: the
+//     products_default_sort setting)
 //
 // Error Handling:
 //   - Returns 404 if category slug doesn't exist
+//   - Redirects (301) subcategories to their nested /products/:parent/:child URL
 //   - Returns 500 on database errors
-//
-// Pagination:
-//   - 12 products per page (hard-coded limit)
-//   - Invalid page numbers default to page 1
-//   - Negative page numbers are rejected
 func (h *ProductsHandler) ProductsByCategory(c echo.Context) error {
 	ctx := c.Request().Context()
 	categorySlug := c.Param("category")
 
-	// Check cache for this specific category page
-	cacheKey := fmt.Sprintf("page:products:%s", categorySlug)
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	// Check cache for this specific category page (filters and page number)
+	cacheKey := localizedCacheKey(c, fmt.Sprintf("page:products:%s", categorySlug)+filterCacheSuffix(c)+sortCacheSuffix(c)+pageCacheSuffix(c))
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
-	// Fetch category by slug
-	category, err := h.queries.GetProductCategoryBySlug(ctx, categorySlug)
-	if err == sql.ErrNoRows {
+	// Fetch the hierarchy and find the category by slug
+	tree, err := h.categoryTree(ctx, true)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load category", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	node := tree.BySlug(categorySlug)
+	if node == nil {
 		// Category doesn't exist - return 404
 		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
 	}
+
+	// Subcategories live under their parent's URL; keep old links working
+	if node.Parent != nil {
+		target := node.URL()
+		if q := c.QueryString(); q != "" {
+			target += "?" + q
+		}
+		return c.Redirect(http.StatusMovedPermanently, target)
+	}
+
+	return h.renderCategoryPage(c, node, cacheKey, false)
+}
+
+// ProductsCategoryGrid handles GET requests for a category's product grid fragment.
+//
+// HTTP Method: GET
+// Route: /partials/products/:category (any level of the hierarchy, by slug)
+// Query Parameters: ?page=N (optional, defaults to 1), ?sort= and the facet filters
+// Template: public/partials/products_grid.html (HTMX fragment)
+// HTMX: Swapped into #product-grid by the pagination links and the filter
+// sidebar; HX-Push-Url
+// records the nested category URL in browser history
+// Cache TTL: 600 seconds (10 minutes), separate from the full page
+//
+// Error Handling:
+//   - Returns 404 if the category or page doesn't exist
+func (h *ProductsHandler) ProductsCategoryGrid(c echo.Context) error {
+	categorySlug := c.Param("category")
+
+	// The grid has no subcategory counts, so the plain hierarchy is enough
+	// to resolve the slug and its nested URL
+	tree, err := h.categoryTree(c.Request().Context(), false)
 	if err != nil {
-		h.logger.Error("failed to load category", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load category", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	node := tree.BySlug(categorySlug)
+	if node == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
+	}
+	pushGridURL(c, node.URL())
 
-	// Parse pagination parameter from query string
-	// Default to page 1 if not provided or invalid
-	page := 1
-	if p := c.QueryParam("page"); p != "" {
-		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
-			page = parsed
-		}
+	cacheKey := localizedCacheKey(c, fmt.Sprintf("page:products:%s", categorySlug)+gridCacheSuffix+filterCacheSuffix(c)+sortCacheSuffix(c)+pageCacheSuffix(c))
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
+		return c.HTML(http.StatusOK, cached.(string))
 	}
+	return h.renderCategoryPage(c, node, cacheKey, true)
+}
+
+// renderCategoryPage renders a category page for any level of the hierarchy,
+// or with grid set only its product grid (with the filter sidebar) and
+// pagination.
+//
+// Template: public/pages/products_category.html (public/partials/products_grid.html for grid)
+// Cache TTL: 600 seconds (10 minutes)
+//
+// Template Data:
+//   - Title: "{Category Name} | Products" - Browser tab title
+//   - Category: sqlc.ProductCategory - Category details (name, description, icon)
+//   - CategoryURL: string - Nested category URL without filters
+//   - Breadcrumbs: []services.Breadcrumb - Category trail from the family down
+//   - Subcategories: []*services.CategoryNode - Direct children with rolled-up counts
+//   - Products: []sqlc.ListAllProductsInCategoryTreeRow - Matching products in this
+//     category and its subcategories (max 12 per page), each with its own category slug
+//   - TotalCount: int64 - Number of matching products in the subtree
+//   - Facets: []services.Facet - Filter sidebar groups with per-value counts
+//   - FiltersActive: bool - At least one filter is selected
+//   - Sort: string - Product order in effect; SortParam: the explicit ?sort=
+//   - SortOptions: []services.SortOption - Sort dropdown entries
+//   - ClearURL / ClearGridURL: string - The listing without filters, keeping the sort
+//   - CurrentPage: int - Current page number
+//   - TotalPages: int - Total number of pages
+//   - PrevURL / NextURL: string - Adjacent pages for links and rel=prev/next (empty at the ends)
+//   - PrevGridURL / NextGridURL: string - The same pages as grid fragments, for HTMX
+//   - CanonicalURL: string - Nested category URL, with ?page=N after page 1;
+//     filtered pages point at the unfiltered category
+//   - CategoryHero: sqlc.PageSection - Hero section content
+//   - EmptyState: sqlc.PageSection - Content to show if category has no products
+//
+// Filters (see services.CategoryFacets):
+//   - status=featured|new, cert=<name>, spec_<section-label>=<value>
+//   - Values of one facet are alternatives; different facets must all match
+//   - Unknown facets or values redirect to the URL without them, so only real
+//     filter combinations are rendered and cached
+//
+// Sorting (see services.ProductSortOptions):
+//   - ?sort= picks the order; without it the products_default_sort setting
+//     applies. Unknown orders redirect to the URL without ?sort=
+//
+// Pagination:
+//   - 12 products per page (productsPerCategoryPage), after filtering
+//   - Invalid page numbers default to page 1
+//   - Negative page numbers are rejected
+//   - Pages past the last page return 404, so crawlers do not index empty pages
+func (h *ProductsHandler) renderCategoryPage(c echo.Context, node *services.CategoryNode, cacheKey string, grid bool) error {
+	ctx := c.Request().Context()
+	category := node.Category
 
-	// Set up pagination parameters
-	limit := int64(12) // Show 12 products per page
-	offset := int64((page - 1)) * limit
+	// Parse pagination parameter from query string
+	// Default to page 1 if not provided or invalid
+	page := requestPage(c)
 
-	// Fetch products for this category with pagination
-	products, err := h.queries.ListProductsByCategory(ctx, sqlc.ListProductsByCategoryParams{
-		CategoryID: category.ID,
-		Limit:      limit,
-		Offset:     offset,
-	})
+	// Load the subtree's products narrowed by the requested filters
+	filters := services.FacetFilters(c.QueryParams())
+	sort, explicitSort := listingSort(c), sortParam(c)
+	faceted, err := h.productSvc.CategoryFacets(ctx, category.ID, filters, sort, time.Now())
 	if err != nil {
-		h.logger.Error("failed to load products", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load products", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	if faceted.Filters.Encode() != filters.Encode() || c.QueryParam("sort") != explicitSort {
+		// Drop filters and sort orders that do not exist (stale links,
+		// hand-edited URLs)
+		return c.Redirect(http.StatusFound, filterPageURL(c.Request().URL.Path, faceted.Filters, explicitSort, 1))
+	}
 
 	// Calculate total pages for pagination controls
-	total, _ := h.queries.CountProductsByCategory(ctx, category.ID)
-	totalPages := int((total + limit - 1) / limit) // Ceiling division
+	total := int64(len(faceted.Products))
+	totalPages := categoryPageCount(total)
+	if page > totalPages {
+		return echo.NewHTTPError(http.StatusNotFound, "Page not found")
+	}
+	start := (page - 1) * productsPerCategoryPage
+	products := faceted.Products[start:min(start+productsPerCategoryPage, len(faceted.Products))]
+
+	// Adjacent page links (also emitted as rel=prev/next in the layout)
+	gridBase := "/partials/products/" + category.Slug
+	var prevURL, nextURL, prevGridURL, nextGridURL string
+	if page > 1 {
+		prevURL = filterPageURL(node.URL(), filters, explicitSort, page-1)
+		prevGridURL = filterPageURL(gridBase, filters, explicitSort, page-1)
+	}
+	if page < totalPages {
+		nextURL = filterPageURL(node.URL(), filters, explicitSort, page+1)
+		nextGridURL = filterPageURL(gridBase, filters, explicitSort, page+1)
+	}
+
+	clearURL := filterPageURL(node.URL(), nil, explicitSort, 1)
+	clearGridURL := filterPageURL(gridBase, nil, explicitSort, 1)
+
+	// Filter combinations and sort orders are not indexed separately
+	canonicalURL := pageURL(node.URL(), page)
+	if len(filters) > 0 || explicitSort != "" {
+		canonicalURL = node.URL()
+	}
 
 	// Fetch editable page sections
 	categoryHero, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "products_category", SectionKey: "hero"})
 	emptyState, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "products_category", SectionKey: "empty_state"})
+	categoryHero, emptyState = localizeSection(c, categoryHero), localizeSection(c, emptyState)
 
 	// Assemble template data
 	data := map[string]interface{}{
-		"Title":        fmt.Sprintf("%s | Products", category.Name), // SEO-friendly title
-		"Category":     category,                                    // Category details
-		"Products":     products,                                    // Products on current page
-		"TotalCount":   total,                                       // Total products in category
-		"CurrentPage":  page,                                        // Current page number
-		"TotalPages":   totalPages,                                  // Total pages for pagination
-		"CategoryHero": categoryHero,                                // Hero section content
-		"EmptyState":   emptyState,                                  // Empty state message
+		"Title":         fmt.Sprintf("%s | Products", category.Name), // SEO-friendly title
+		"Category":      category,                                    // Category details
+		"CategoryURL":   node.URL(),                                  // Unfiltered category URL (filter form action)
+		"Breadcrumbs":   node.Breadcrumbs(),                          // Family → series → type trail
+		"Subcategories": node.Children,                               // Child categories with rolled-up counts
+		"Products":      products,                                    // Products on current page
+		"TotalCount":    total,                                       // Matching products in the subtree
+		"Facets":        faceted.Facets,                              // Filter sidebar
+		"FiltersActive": len(filters) > 0,                            // Show "clear filters"
+		"Sort":          sort,                                        // Order in effect (dropdown selection)
+		"SortParam":     explicitSort,                                // Explicit ?sort=, carried by the filter form
+		"SortOptions":   services.ProductSortOptions,                 // Sort dropdown
+		"ClearURL":      clearURL,                                    // Unfiltered listing, same order
+		"ClearGridURL":  clearGridURL,                                // Unfiltered grid fragment
+		"CurrentPage":   page,                                        // Current page number
+		"TotalPages":    totalPages,                                  // Total pages for pagination
+		"PrevURL":       prevURL,                                     // Previous page, empty on page 1
+		"NextURL":       nextURL,                                     // Next page, empty on the last page
+		"PrevGridURL":   prevGridURL,                                 // Previous page fragment for HTMX
+		"NextGridURL":   nextGridURL,                                 // Next page fragment for HTMX
+		"CanonicalURL":  canonicalURL,                                // Nested category URL (self-canonical per page)
+		"CategoryHero":  categoryHero,                                // Hero section content
+		"EmptyState":    emptyState,                                  // Empty state message
 	}
+	setLang(c, data)
 
 	// Render template and cache for 10 minutes
-	// Template: templates/public/pages/products_category.html
-	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, "public/pages/products_category.html", data)
+	// Template: templates/public/pages/products_category.html or its grid partial
+	templateName := "public/pages/products_category.html"
+	if grid {
+		templateName = "public/partials/products_grid.html"
+	}
+	dependsOn(c, categoryPageTags(node)...)
+	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, templateName, data)
 }
 
-// ProductDetail handles GET requests to view a specific product's detail page.
+// ProductDetail handles GET requests to view a specific product's detail page,
+// or a subcategory page when :slug names a child category of :category.
 //
 // HTTP Method: GET
-// Route: /products/:category/:slug (e.g., /products/sensors/temperature-sensor-ts100)
-// Query Parameters: ?preview=1 (optional, for admin preview)
+// Route: /products/:category/:slug (e.g., /products/sensors/temperature-sensor-ts100
+// for a product, /products/sensors/temperature for a subcategory)
+// Query Parameters: ?preview_token= (optional, signed preview link from the admin editor)
 // Template: public/pages/product_detail.html (full page, not HTMX fragment)
 // HTMX: Returns complete HTML page
 // Cache TTL: 1800 seconds (30 minutes) for published products, no cache for previews
@@ -321,7 +460,8 @@ func (h *ProductsHandler) ProductsByCategory(c echo.Context) error {
 //   - slug: Product slug (unique identifier)
 //
 // Query Parameters:
-//   - preview: If "1", shows draft/unpublished products for admin preview
+//   - preview_token: Signed, expiring token for this item; shows it even as a draft
+//   - variant: Variant SKU; switches SKU, specs and gallery (404 if unknown)
 //
 // Template Data:
 //   - Title: "{Product Name} | Products" - Browser tab title
@@ -331,11 +471,15 @@ func (h *ProductsHandler) ProductsByCategory(c echo.Context) error {
 //   - CanonicalURL: Canonical URL for SEO
 //   - Product: sqlc.Product - Core product data (name, SKU, description, price)
 //   - Category: sqlc.ProductCategory - Parent category details
+//   - Breadcrumbs: []services.Breadcrumb - Category trail from the family down
 //   - Images: []sqlc.ProductImage - Product images for gallery
 //   - Features: []sqlc.ProductFeature - Features/benefits list
 //   - SpecSections: map[string][]sqlc.ProductSpec - Specs grouped by section
 //   - Certifications: []sqlc.ProductCertification - Certifications/compliance
-//   - Downloads: []sqlc.ProductDownload - Downloadable resources
+//   - Downloads: []services.DownloadVersions - Downloadable resources with their older versions
+//   - Variants: []sqlc.ProductVariant - Variant selector options
+//   - Variant: *sqlc.ProductVariant - Selected variant, nil for the base product
+//   - RelatedContent: services.RelatedContent - Pinned and recommended case studies, posts, whitepapers
 //   - DetailCTA: sqlc.PageSection - Call-to-action with placeholders replaced
 //   - Sections: map[string]sqlc.PageSection - Other editable sections
 //   - IsPreview: bool - True if viewing in preview mode
@@ -347,7 +491,7 @@ func (h *ProductsHandler) ProductsByCategory(c echo.Context) error {
 //   - Structured data could be added for rich snippets
 //
 // Preview Mode:
-//   - When ?preview=1 is set, shows unpublished/draft products
+//   - With a valid ?preview_token= for this item, shows it even as a draft
 //   - Adds edit link for admin convenience
 //   - Disables caching to show live changes
 //
@@ -356,9 +500,14 @@ func (h *ProductsHandler) ProductsByCategory(c echo.Context) error {
 //   - {product_sku} → Replaced with actual product SKU
 //   - This allows generic CTA templates to be personalized per product
 //
+// Subcategories:
+//   - A child category whose parent slug is :category takes precedence over a
+//     product with the same slug, and is rendered by renderCategoryPage
+//
 // Error Handling:
 //   - Returns 404 if product not found
 //   - Returns 404 if category slug doesn't match product's category
+//   - Returns 404 for unpublished products outside preview mode
 //   - Returns 500 on database errors
 //
 // Business Logic:
@@ -366,18 +515,57 @@ func (h *ProductsHandler) ProductsByCategory(c echo.Context) error {
 //   - Specifications are grouped by section for better organization
 //   - Preview mode bypasses published status checks
 func (h *ProductsHandler) ProductDetail(c echo.Context) error {
+	return h.productDetail(c, false)
+}
+
+// ProductPrint handles GET /products/:category/:slug/print
+// Renders the product as a printable spec sheet without site navigation,
+// scripts or the Tailwind runtime. Accepts ?variant= like ProductDetail.
+//
+// Template: public/print/product_detail.html (print layout)
+// Cache TTL: 1800 seconds, keyed apart from the product page
+//
+// Subcategory slugs are not printable and return 404.
+func (h *ProductsHandler) ProductPrint(c echo.Context) error {
+	preparePrint(c)
+	return h.productDetail(c, true)
+}
+
+// productDetail renders a product page, or its print version.
+func (h *ProductsHandler) productDetail(c echo.Context, forPrint bool) error {
 	categorySlug := c.Param("category")
 	productSlug := c.Param("slug")
-	preview := isPreviewRequest(c) // Check if this is an admin preview request
+	previewItemID, preview := previewID(c, services.PreviewProduct) // Signed preview link for this content type?
+	// The filter and page suffixes only matter when :slug is a subcategory; products ignore them
+	variantSKU := c.QueryParam("variant") // Optional variant selected in the variant selector
+	cacheKey := fmt.Sprintf("page:products:%s:%s", categorySlug, productSlug) + filterCacheSuffix(c) + sortCacheSuffix(c) + pageCacheSuffix(c)
+	if variantSKU != "" {
+		cacheKey += ":variant:" + variantSKU
+	}
+	if forPrint {
+		cacheKey += printCacheSuffix
+	}
+	cacheKey = localizedCacheKey(c, cacheKey)
 
 	// Skip cache lookup for preview mode to show live changes
 	if !preview {
-		cacheKey := fmt.Sprintf("page:products:%s:%s", categorySlug, productSlug)
-		if cached, ok := h.cache.Get(cacheKey); ok {
+		if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 			return c.HTML(http.StatusOK, cached.(string))
 		}
 	}
 
+	// /products/:parent/:child is a subcategory page when :slug names a child of :category
+	if sub, err := h.queries.GetProductCategoryBySlug(c.Request().Context(), productSlug); err == nil && sub.ParentID.Valid && !forPrint {
+		tree, err := h.categoryTree(c.Request().Context(), true)
+		if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to load category", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		if node := tree.ByID(sub.ID); node != nil && node.Parent != nil && node.Parent.Category.Slug == categorySlug {
+			return h.renderCategoryPage(c, node, cacheKey, false)
+		}
+	}
+
 	// Use ProductService to fetch aggregated product detail
 	// This retrieves product + category + images + features + specs + certifications + downloads
 	detail, err := h.productSvc.GetProductDetail(c.Request().Context(), productSlug)
@@ -386,7 +574,7 @@ func (h *ProductsHandler) ProductDetail(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
 	}
 	if err != nil {
-		h.logger.Error("failed to load product detail", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "failed to load product detail", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -396,14 +584,66 @@ func (h *ProductsHandler) ProductDetail(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Product not found in this category")
 	}
 
+	// Unpublished products are only shown through a preview link issued for them
+	if preview && detail.Product.ID != previewItemID || !preview && detail.Product.Status != "published" {
+		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
+	}
+
+	// Switch SKU, specs and gallery to the selected variant; unknown SKUs 404
+	// rather than rendering (and caching) the base product under their URL
+	if variantSKU != "" {
+		if err := h.productSvc.ApplyVariant(c.Request().Context(), detail, variantSKU); err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Variant not found")
+		} else if err != nil {
+			h.logger.ErrorContext(c.Request().Context(), "failed to load product variant", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+
+	// Overlay translated name/tagline/description when a non-default locale is requested
+	if tr := translationFor(c, "product", detail.Product.ID); tr != nil {
+		detail.Product.Name = tr.Field("name", detail.Product.Name)
+		detail.Product.Tagline = translateNullString(tr, "tagline", detail.Product.Tagline)
+		detail.Product.Description = tr.Field("description", detail.Product.Description)
+	}
+
 	// Group specifications by section name for organized display
 	// Example sections: "General", "Electrical", "Mechanical", "Environmental"
 	specSections := groupSpecsBySection(detail.Specs)
 
 	ctx := c.Request().Context()
 
+	// Breadcrumb trail through the product's family and series
+	var breadcrumbs []services.Breadcrumb
+	if tree, err := h.categoryTree(ctx, false); err == nil {
+		if node := tree.ByID(detail.Category.ID); node != nil {
+			breadcrumbs = node.Breadcrumbs()
+		}
+	}
+
+	// Related case studies, blog posts and whitepapers: admin pins first, then
+	// scored matches (see services.RelatedContentWeights). Print pages omit them.
+	var relatedContent services.RelatedContent
+	if !forPrint {
+		relatedContent, err = h.related.ForProduct(ctx, detail.Product.ID, detail.Category.ID, 3)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to load related content", "error", err)
+		}
+	}
+
+	// Published manuals of the product, linked to their current version.
+	// Print pages omit them.
+	var docSets []sqlc.ListProductDocSetsRow
+	if !forPrint {
+		docSets, err = h.queries.ListProductDocSets(ctx, sql.NullInt64{Int64: detail.Product.ID, Valid: true})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to load product doc sets", "error", err)
+		}
+	}
+
 	// Fetch CTA section and personalize it with product-specific placeholders
 	detailCTA, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "product_detail", SectionKey: "cta"})
+	detailCTA = localizeSection(c, detailCTA)
 
 	// Replace placeholders in CTA text with actual product data
 	// Example: "Request a quote for {product_name}" → "Request a quote for TS100"
@@ -415,10 +655,7 @@ func (h *ProductsHandler) ProductDetail(c echo.Context) error {
 
 	// Fetch other editable page sections for admin customization
 	sections, _ := h.queries.ListPageSections(ctx, "product_detail")
-	sectionMap := make(map[string]sqlc.PageSection)
-	for _, s := range sections {
-		sectionMap[s.SectionKey] = s
-	}
+	sectionMap := localizedSectionMap(c, sections)
 
 	// Extract SEO metadata, using fallbacks if not set
 	metaTitle := ""
@@ -439,27 +676,51 @@ func (h *ProductsHandler) ProductDetail(c echo.Context) error {
 		"CanonicalURL":    fmt.Sprintf("/products/%s/%s", detail.Category.Slug, detail.Product.Slug), // SEO canonical
 		"Product":         detail.Product,         // Core product data
 		"Category":        detail.Category,        // Parent category
+		"Breadcrumbs":     breadcrumbs,            // Category trail for navigation
 		"Images":          detail.Images,          // Product image gallery
 		"Features":        detail.Features,        // Features/benefits list
 		"SpecSections":    specSections,           // Specifications grouped by section
 		"Certifications":  detail.Certifications,  // Certifications/compliance
 		"Downloads":       detail.Downloads,       // Downloadable resources
+		"Variants":        detail.Variants,        // Variant selector options
+		"Variant":         detail.Variant,         // Selected variant, nil for the base product
+		"RelatedContent":  relatedContent,          // Pinned and recommended case studies, posts, whitepapers
+		"DocSets":         docSets,                // Manuals of the product
 		"DetailCTA":       detailCTA,              // Personalized CTA
 		"Sections":        sectionMap,             // Other editable sections
 	}
+	setLang(c, data)
+
+	templateName := "public/pages/product_detail.html"
+	if forPrint {
+		templateName = "public/print/product_detail.html"
+		data["PrintLabel"] = "Product Specification"
+	}
+
+	// Apply the Admin > SEO override for this product, if any
+	middleware.SetSEOEntity(c, services.PreviewProduct, detail.Product.ID)
 
 	// Handle preview mode (for admin to preview unpublished changes)
 	if preview {
 		data["IsPreview"] = true // Show preview banner in template
 		data["EditURL"] = fmt.Sprintf("/admin/products/%d/edit", detail.Product.ID) // Link to admin editor
 		// Don't cache preview pages (TTL=0)
-		return h.renderAndCache(c, "preview:product:"+productSlug, 0, http.StatusOK, "public/pages/product_detail.html", data)
+		return h.renderAndCache(c, "preview:product:"+productSlug, 0, http.StatusOK, templateName, data)
 	}
 
 	// Render and cache for 30 minutes (1800 seconds)
-	// Template: templates/public/pages/product_detail.html
-	cacheKey := fmt.Sprintf("page:products:%s:%s", categorySlug, productSlug)
-	return h.renderAndCache(c, cacheKey, 1800, http.StatusOK, "public/pages/product_detail.html", data)
+	dependsOn(c, services.ProductTag(detail.Product.ID))
+	return h.renderAndCache(c, cacheKey, 1800, http.StatusOK, templateName, data)
+}
+
+// categoryPageTags returns the cache tags of a category page: the category
+// and every category under it, since the page lists the whole subtree.
+func categoryPageTags(node *services.CategoryNode) []string {
+	tags := []string{services.ProductCategoryTag(node.Category.ID)}
+	for _, child := range node.Children {
+		tags = append(tags, categoryPageTags(child)...)
+	}
+	return tags
 }
 
 // ProductSearch handles GET requests to search for products by keyword.
@@ -498,46 +759,67 @@ func (h *ProductsHandler) ProductDetail(c echo.Context) error {
 // Search Implementation:
 //   - Uses SQL LIKE with wildcards for fuzzy matching
 //   - Searches across: product name, description, tagline
-//   - Case-insensitive search (handled by database)
+//   - Case-insensitive for ASCII letters (SQLite's LIKE); other letters
+//     match in the case typed
 //   - Limited to 24 results to prevent performance issues
 //   - Empty query returns empty results (not all products)
+//   - ?sort= orders results (name_asc, name_desc, newest, oldest, sku);
+//     the products_default_sort setting applies without it
 //
 // SEO Considerations:
 //   - Search results pages are not indexed (should have noindex meta tag)
 //   - Query parameter is included in page title for user clarity
 //
 // Performance:
-//   - Not cached since results vary by query
+//   - Results are cached for a minute under the normalized query (trimmed,
+//     ASCII lowercased, whitespace collapsed), shared by live and full-page
+//     search
+//   - Live (HTMX) queries under 2 characters return an empty fragment
+//     without searching, and live responses are cacheable by the browser
 //   - Limited to 24 results to keep response fast
 //   - Database indexes on name/description fields recommended
 func (h *ProductsHandler) ProductSearch(c echo.Context) error {
 	ctx := c.Request().Context()
 	q := c.QueryParam("q") // Extract search query from URL parameter
+	norm := normalizeSearchQuery(q)
+	live := c.Request().Header.Get("HX-Request") == "true"
 
-	var products []sqlc.SearchProductsRow
-	if q != "" {
-		// Add SQL wildcards for partial matching
-		wildcard := "%" + q + "%"
-		var err error
-		products, err = h.queries.SearchProducts(ctx, sqlc.SearchProductsParams{
-			Name:        wildcard, // Search in product name
-			Description: wildcard, // Search in product description
-			Tagline:     sql.NullString{String: wildcard, Valid: true}, // Search in tagline
-			Limit:       24, // Limit results to prevent overwhelming UI and database
-			Offset:      0,  // No pagination for search results
-		})
-		if err != nil {
-			h.logger.Error("failed to search products", "error", err)
-			return echo.NewHTTPError(http.StatusInternalServerError)
+	if live && utf8.RuneCountInString(norm) < minSuggestLength {
+		// Too short to narrow anything down; clear the results area
+		cacheSuggestions(c)
+		return c.HTML(http.StatusOK, "")
+	}
+
+	sort := listingSort(c)
+	var products []sqlc.SearchProductsSortedRow
+	if norm != "" {
+		key := searchCacheKey("products:"+sort, norm, 24)
+		if cached, ok := h.cache.Get(key); ok {
+			products = cached.([]sqlc.SearchProductsSortedRow)
+		} else {
+			// Add SQL wildcards for partial matching on name, description and tagline
+			var err error
+			products, err = h.queries.SearchProductsSorted(ctx, sqlc.SearchProductsSortedParams{
+				Pattern:   "%" + norm + "%",
+				Sort:      sort,
+				PageLimit: 24, // Limit results to prevent overwhelming UI and database
+			})
+			if err != nil {
+				h.logger.ErrorContext(ctx, "failed to search products", "error", err)
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+			h.cache.Set(key, products, searchCacheTTL)
 		}
 	}
 	// If query is empty, products remains empty slice (don't return all products)
 
 	// Assemble template data
 	data := map[string]interface{}{
-		"Title":    fmt.Sprintf("Search: %s | Products", q), // SEO-friendly title
-		"Products": products,                                 // Matching products
-		"Query":    q,                                        // Original query for display
+		"Title":       fmt.Sprintf("Search: %s | Products", q), // SEO-friendly title
+		"Products":    products,                                 // Matching products
+		"Query":       q,                                        // Original query for display
+		"Sort":        sort,                                     // Order in effect (dropdown selection)
+		"SortOptions": services.ProductSortOptions,              // Sort dropdown
 	}
 
 	// Add settings for non-HTMX requests (needed for full page layout)
@@ -546,10 +828,11 @@ func (h *ProductsHandler) ProductSearch(c echo.Context) error {
 	}
 
 	// Check if this is an HTMX request (has HX-Request header)
-	if c.Request().Header.Get("HX-Request") == "true" {
+	if live {
 		// Return partial HTML fragment for HTMX live search
 		// Template: templates/public/partials/product_search_results.html
 		// This fragment contains only the results grid, no layout/header/footer
+		cacheSuggestions(c)
 		return c.Render(http.StatusOK, "public/partials/product_search_results.html", data)
 	}
 
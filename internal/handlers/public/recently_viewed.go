@@ -0,0 +1,113 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file contains the "Recently viewed" products widget.
+package public
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for products no longer published
+	"errors"       // Matching sentinel errors
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes and cookies
+	"strconv"      // Parsing the current product ID
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // Signed cookie codec
+)
+
+// recentlyViewedCookie holds the visitor's recently viewed product IDs.
+const recentlyViewedCookie = "recently_viewed"
+
+// recentlyViewedShown is how many products the widget lists.
+const recentlyViewedShown = 6
+
+// RecentlyViewedHandler serves the recently viewed widget. The list lives in
+// a signed cookie rather than the database, so it needs no login and keeps
+// nothing about visitors server-side. Pages load the widget with HTMX after
+// rendering, which keeps the pages themselves cacheable for everyone.
+type RecentlyViewedHandler struct {
+	queries sqlc.Querier                  // Database query interface for product cards
+	logger  *slog.Logger                  // Structured logger for errors
+	codec   *services.RecentlyViewedCodec // Signs and verifies the cookie
+}
+
+// NewRecentlyViewedHandler creates a new RecentlyViewedHandler with the required dependencies.
+func NewRecentlyViewedHandler(queries sqlc.Querier, logger *slog.Logger, codec *services.RecentlyViewedCodec) *RecentlyViewedHandler {
+	return &RecentlyViewedHandler{queries: queries, logger: logger, codec: codec}
+}
+
+// recentlyViewedItem is one product card of the widget.
+type recentlyViewedItem struct {
+	Name    string
+	Tagline string
+	Image   string
+	URL     string
+}
+
+// RecentlyViewed handles GET /partials/recently-viewed
+// Renders the visitor's recently viewed products, most recent first. With
+// ?current=<product id> (product pages) the product is recorded first and
+// left out of the list, since the visitor is looking at it. Unpublished
+// products are neither recorded nor shown.
+//
+// Template: public/partials/recently_viewed.html (HTMX fragment, empty when
+// there is nothing to show)
+// Cache: private, never stored in the page cache
+func (h *RecentlyViewedHandler) RecentlyViewed(c echo.Context) error {
+	ctx := c.Request().Context()
+	var ids []int64
+	if cookie, err := c.Cookie(recentlyViewedCookie); err == nil {
+		ids = h.codec.Decode(cookie.Value)
+	}
+
+	current, _ := strconv.ParseInt(c.QueryParam("current"), 10, 64)
+	if current > 0 {
+		if _, err := h.queries.GetPublishedProductCard(ctx, current); err == nil {
+			ids = services.AddRecentlyViewed(ids, current)
+			c.SetCookie(&http.Cookie{
+				Name:     recentlyViewedCookie,
+				Value:    h.codec.Encode(ids),
+				Path:     "/",
+				MaxAge:   30 * 24 * 60 * 60,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			h.logger.ErrorContext(ctx, "failed to load viewed product", "id", current, "error", err)
+		}
+	}
+
+	var items []recentlyViewedItem
+	for _, id := range ids {
+		if id == current || len(items) == recentlyViewedShown {
+			continue
+		}
+		p, err := h.queries.GetPublishedProductCard(ctx, id)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				h.logger.ErrorContext(ctx, "failed to load recently viewed product", "id", id, "error", err)
+			}
+			continue
+		}
+		item := recentlyViewedItem{
+			Name:    p.Name,
+			Tagline: p.Tagline.String,
+			Image:   p.PrimaryImage.String,
+			URL:     "/products/" + p.CategorySlug + "/" + p.Slug,
+		}
+		if tr := translationFor(c, "product", p.ID); tr != nil {
+			item.Name = tr.Field("name", item.Name)
+			item.Tagline = tr.Field("tagline", item.Tagline)
+		}
+		items = append(items, item)
+	}
+
+	// The list differs per visitor
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return c.Render(http.StatusOK, "public/partials/recently_viewed.html", map[string]interface{}{
+		"Products": items,
+	})
+}
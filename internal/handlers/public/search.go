@@ -5,13 +5,61 @@ package public
 import (
 	"bytes"        // Used for buffering HTML fragments before sending to client
 	"database/sql" // Provides database/sql driver interfaces for SQLite database access
+	"fmt"          // Building cache keys and headers
 	"log/slog"     // Structured logging for search query tracking and error reporting
 	"net/http"     // HTTP status codes and constants
 	"strings"      // String manipulation for query sanitization and FTS5 token processing
+	"unicode/utf8" // Minimum suggestion query length in characters
 
 	"github.com/labstack/echo/v4" // Echo web framework for HTTP request/response handling
+
+	"github.com/narendhupati/bluejay-cms/internal/services" // Short-lived cache for search results
+)
+
+// Search result caching. Live search fires a request for every pause in
+// typing, and visitors repeat popular queries, so results are kept briefly
+// under a key built from the normalized query. Keys share the "page:" prefix
+// so admin saves that purge cached pages purge stale results too.
+const (
+	searchCacheTTL    = 60 // Seconds a result set is reused
+	searchCachePrefix = "page:search:"
+	minSuggestLength  = 2 // Shorter live queries match too much to be useful
 )
 
+// normalizeSearchQuery trims a query, collapses runs of whitespace and
+// lowercases ASCII letters, so "  Temp   Sensor" and "temp sensor" share a
+// cache entry. Only ASCII is folded because SQLite's LIKE, behind product
+// search, is case-insensitive for ASCII letters only: lowercasing "Élan"
+// would stop it from matching. Queries differing only in the case of other
+// letters are cached separately.
+func normalizeSearchQuery(q string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, strings.Join(strings.Fields(q), " "))
+}
+
+// searchCacheKey returns the cache key of a result set.
+//
+// Parameters:
+//   - scope: Which search, e.g. "site" or "products"
+//   - query: Normalized query
+//   - limit: Result limit; the same query is cached separately per limit
+func searchCacheKey(scope, query string, limit int) string {
+	return fmt.Sprintf("%s%s:%d:%s", searchCachePrefix, scope, limit, query)
+}
+
+// cacheSuggestions lets browsers and proxies reuse a live-search fragment
+// for the cache TTL. Vary keeps HTMX fragments apart from full pages served
+// at the same URL.
+func cacheSuggestions(c echo.Context) {
+	h := c.Response().Header()
+	h.Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", searchCacheTTL))
+	h.Add(echo.HeaderVary, "HX-Request")
+}
+
 // SearchResult represents a single search result from any content type.
 // Used to unify results from products, blog posts, and case studies into a consistent format.
 type SearchResult struct {
@@ -24,13 +72,15 @@ type SearchResult struct {
 // SearchHandler processes full-text search requests across multiple content types.
 // Uses SQLite FTS5 (Full-Text Search) indexes for fast query performance.
 type SearchHandler struct {
-	db     *sql.DB     // Database connection for executing FTS5 queries
-	logger *slog.Logger // Structured logger for tracking search queries and debugging errors
+	db     *sql.DB         // Database connection for executing FTS5 queries
+	logger *slog.Logger    // Structured logger for tracking search queries and debugging errors
+	cache  *services.Cache // Short-lived result cache (optional)
 }
 
-// NewSearchHandler creates a new search handler with database and logger dependencies.
-func NewSearchHandler(db *sql.DB, logger *slog.Logger) *SearchHandler {
-	return &SearchHandler{db: db, logger: logger}
+// NewSearchHandler creates a new search handler with database, logger and
+// cache dependencies. A nil cache disables result caching.
+func NewSearchHandler(db *sql.DB, logger *slog.Logger, cache *services.Cache) *SearchHandler {
+	return &SearchHandler{db: db, logger: logger, cache: cache}
 }
 
 // sanitizeQuery removes FTS5 special characters and adds prefix matching.
@@ -83,19 +133,21 @@ func sanitizeQuery(q string) string {
 	return strings.Join(words, " ")
 }
 
-// search executes full-text search queries across products and blog posts.
+// search executes full-text search queries across products, blog posts and
+// documentation pages.
 // Returns a unified list of results from all content types, limited by the limit parameter.
+// Results are cached for searchCacheTTL seconds under the normalized query.
 //
 // FTS5 Implementation:
-//   - Uses SQLite FTS5 virtual tables (products_fts, blog_posts_fts, case_studies_fts)
+//   - Uses SQLite FTS5 virtual tables (products_fts, blog_posts_fts, doc_pages_fts)
 //   - Joins FTS5 results back to main tables via rowid for full data access
 //   - MATCH clause uses sanitized query to prevent syntax errors
-//   - Only searches published content (status = 'published')
+//   - Only searches published content (status = 'published') that is not in the trash
 //
 // Query Processing:
 //   - Sanitizes query using sanitizeQuery to prevent FTS5 syntax errors
 //   - Returns nil if sanitized query is empty (invalid input)
-//   - Executes three separate queries sequentially (products → blog → case studies)
+//   - Executes three separate queries sequentially (products → blog → docs)
 //   - Errors are logged but don't stop subsequent searches (graceful degradation)
 //
 // Performance:
@@ -103,6 +155,22 @@ func sanitizeQuery(q string) string {
 //   - LIMIT parameter controls result count per content type
 //   - Results are appended to single slice (not sorted by relevance)
 func (h *SearchHandler) search(query string, limit int) []SearchResult {
+	query = normalizeSearchQuery(query)
+	key := searchCacheKey("site", query, limit)
+	if h.cache != nil {
+		if cached, ok := h.cache.Get(key); ok {
+			return cached.([]SearchResult)
+		}
+	}
+	results := h.searchDB(query, limit)
+	if h.cache != nil {
+		h.cache.Set(key, results, searchCacheTTL)
+	}
+	return results
+}
+
+// searchDB runs the FTS5 queries behind search, without caching.
+func (h *SearchHandler) searchDB(query string, limit int) []SearchResult {
 	ftsQuery := sanitizeQuery(query)
 	if ftsQuery == "" {
 		return nil
@@ -114,7 +182,7 @@ func (h *SearchHandler) search(query string, limit int) []SearchResult {
 	// FTS5 index: products_fts includes searchable product content
 	// URL format: /products/{category-slug}/{product-slug}
 	rows, err := h.db.Query(
-		`SELECT p.name, pc.slug, p.slug, COALESCE(p.tagline, '') FROM products_fts f JOIN products p ON f.rowid = p.id JOIN product_categories pc ON p.category_id = pc.id WHERE products_fts MATCH ? AND p.status = 'published' LIMIT ?`,
+		`SELECT p.name, pc.slug, p.slug, COALESCE(p.tagline, '') FROM products_fts f JOIN products p ON f.rowid = p.id JOIN product_categories pc ON p.category_id = pc.id WHERE products_fts MATCH ? AND p.status = 'published' AND p.deleted_at IS NULL LIMIT ?`,
 		ftsQuery, limit,
 	)
 	if err != nil {
@@ -139,7 +207,7 @@ func (h *SearchHandler) search(query string, limit int) []SearchResult {
 	// FTS5 index: blog_posts_fts includes title, excerpt, and full HTML content
 	// URL format: /blog/{post-slug}
 	rows2, err := h.db.Query(
-		`SELECT bp.title, bp.slug, bp.excerpt FROM blog_posts_fts f JOIN blog_posts bp ON f.rowid = bp.id WHERE blog_posts_fts MATCH ? AND bp.status = 'published' LIMIT ?`,
+		`SELECT bp.title, bp.slug, bp.excerpt FROM blog_posts_fts f JOIN blog_posts bp ON f.rowid = bp.id WHERE blog_posts_fts MATCH ? AND bp.status = 'published' AND bp.deleted_at IS NULL AND bp.published_at < datetime('now', '+1 second') LIMIT ?`,
 		ftsQuery, limit,
 	)
 	if err != nil {
@@ -160,6 +228,32 @@ func (h *SearchHandler) search(query string, limit int) []SearchResult {
 		}
 	}
 
+	// Search documentation across page titles and Markdown source
+	// FTS5 index: doc_pages_fts includes title and body_markdown
+	// Only published pages of the current version of published doc sets,
+	// leaving out pages below a draft as the public sidebar tree does
+	// URL format: /docs/{set-slug}/{version}/{page-slug}
+	rows3, err := h.db.Query(
+		`SELECT dp.title, ds.slug, dv.version, dp.slug, ds.name FROM doc_pages_fts f JOIN doc_pages dp ON f.rowid = dp.id JOIN doc_versions dv ON dp.doc_version_id = dv.id JOIN doc_sets ds ON ds.current_version_id = dv.id WHERE doc_pages_fts MATCH ? AND dp.is_published = 1 AND ds.is_published = 1 AND NOT EXISTS (WITH RECURSIVE up(id) AS (SELECT dp.parent_id UNION ALL SELECT a.parent_id FROM doc_pages a JOIN up ON a.id = up.id) SELECT 1 FROM up JOIN doc_pages a ON a.id = up.id WHERE a.is_published = 0) LIMIT ?`,
+		ftsQuery, limit,
+	)
+	if err != nil {
+		h.logger.Error("doc_pages fts query failed", "error", err)
+	} else {
+		defer rows3.Close()
+		for rows3.Next() {
+			var title, setSlug, version, slug, setName string
+			if err := rows3.Scan(&title, &setSlug, &version, &slug, &setName); err == nil {
+				results = append(results, SearchResult{
+					Type:    "Documentation",
+					Title:   title,
+					URL:     services.DocPagePath(setSlug, version, slug),
+					Excerpt: setName + " " + version, // Manual and version the page belongs to
+				})
+			}
+		}
+	}
+
 	return results
 }
 
@@ -246,18 +340,20 @@ func (h *SearchHandler) SearchPage(c echo.Context) error {
 //
 // UX Behavior:
 //   - Shows fewer results (5) than full search page (10) for faster rendering
-//   - Empty query returns empty results (no suggestions shown)
+//   - Queries under 2 characters return empty results without searching
 //   - Renders partial template directly into page via HTMX swap
 //
 // Performance:
 //   - Limits to 5 results total for fast response time
 //   - FTS5 prefix matching enables "type-ahead" behavior
+//   - Results are cached server-side, and the response is cacheable by the
+//     browser, so retyping or deleting characters repeats no queries
 //   - Buffer used to render template before returning (error handling)
 func (h *SearchHandler) SearchSuggest(c echo.Context) error {
 	query := c.QueryParam("q")
 
 	var results []SearchResult
-	if query != "" {
+	if utf8.RuneCountInString(normalizeSearchQuery(query)) >= minSuggestLength {
 		// Execute search with lower limit (5) for faster suggestion response
 		results = h.search(query, 5)
 	}
@@ -271,10 +367,11 @@ func (h *SearchHandler) SearchSuggest(c echo.Context) error {
 	// This prevents partial HTML being sent on template errors
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, "public/partials/search_suggestions.html", data, c); err != nil {
-		h.logger.Error("search suggestions render failed", "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "search suggestions render failed", "error", err)
 		return err
 	}
 
 	// Return HTML fragment for HTMX to inject into page
+	cacheSuggestions(c)
 	return c.HTML(http.StatusOK, buf.String())
 }
@@ -0,0 +1,65 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the /go/ short links used in campaigns.
+package public
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for 404 detection
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+	"strings"      // Case-insensitive codes
+	"time"         // Expiry
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, responses
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/services" // UTM tagging, expiry and bot detection
+)
+
+// ShortLinksHandler serves /go/:code, the short links managed under
+// Admin > Short Links.
+type ShortLinksHandler struct {
+	queries sqlc.Querier // Database query interface for links and click counts
+	logger  *slog.Logger // Structured logger for errors
+}
+
+// NewShortLinksHandler creates a new ShortLinksHandler with the required dependencies.
+func NewShortLinksHandler(queries sqlc.Querier, logger *slog.Logger) *ShortLinksHandler {
+	return &ShortLinksHandler{queries: queries, logger: logger}
+}
+
+// Follow handles GET /go/:code
+// Counts a visit and redirects (302) to the link's destination with its UTM
+// parameters. Crawlers and link previews are redirected without being
+// counted. Codes are matched case-insensitively, as they are often typed
+// from print.
+//
+// Error Handling:
+//   - Returns 404 for unknown codes
+//   - Returns 410 for expired links
+//   - Returns 500 on database errors
+func (h *ShortLinksHandler) Follow(c echo.Context) error {
+	ctx := c.Request().Context()
+	link, err := h.queries.GetShortLinkByCode(ctx, strings.ToLower(c.Param("code")))
+	if err == sql.ErrNoRows {
+		return echo.NewHTTPError(http.StatusNotFound, "Link not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to get short link", "error", err, "code", c.Param("code"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if services.ShortLinkExpired(link, time.Now()) {
+		return echo.NewHTTPError(http.StatusGone, "This link has expired.")
+	}
+
+	if services.ClassifyUserAgent(c.Request().UserAgent()) != services.UABot {
+		if err := h.queries.RecordShortLinkClick(ctx, link.ID); err != nil {
+			h.logger.ErrorContext(ctx, "failed to count short link click", "error", err, "id", link.ID)
+		}
+	}
+	// Every visit must reach the server to be counted
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.Redirect(http.StatusFound, services.ShortLinkTarget(link))
+}
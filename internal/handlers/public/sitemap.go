@@ -3,6 +3,7 @@
 package public
 
 import (
+	"context"      // Request-independent sitemap builds for the sitemap job
 	"encoding/xml" // XML marshaling for sitemap.xml standard format
 	"fmt"          // String formatting for constructing URLs
 	"log/slog"     // Structured logging for tracking sitemap generation errors
@@ -11,6 +12,7 @@ import (
 
 	"github.com/labstack/echo/v4"                      // Echo web framework for HTTP request/response handling
 	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated sqlc database queries for fetching published content
+	"github.com/narendhupati/bluejay-cms/internal/services" // Clock formats of the job postings and press release queries, sitemap store
 )
 
 // URLSet represents the root element of an XML sitemap following the sitemaps.org protocol.
@@ -33,25 +35,31 @@ type URL struct {
 // SitemapHandler generates XML sitemaps and robots.txt for search engine optimization.
 // Sitemaps help search engines discover and index all website content efficiently.
 type SitemapHandler struct {
-	queries *sqlc.Queries // Database queries for fetching published content
-	logger  *slog.Logger  // Structured logger for error tracking
-	baseURL string        // Base URL for the website (e.g., "https://example.com")
+	queries sqlc.Querier // Database queries for fetching published content
+	logger  *slog.Logger // Structured logger for error tracking
+	baseURL string       // Base URL for the website (e.g., "https://example.com")
+}
+
+// sitemapStore keeps the sitemap between runs of the sitemap job. When nil
+// (e.g., in tests), every request builds the sitemap.
+var sitemapStore *services.Sitemap
+
+// SetSitemap sets the store Sitemap serves from.
+//
+// Parameters:
+//   - s: Store regenerated by the sitemap job and invalidated by admin changes
+func SetSitemap(s *services.Sitemap) {
+	sitemapStore = s
 }
 
 // NewSitemapHandler creates a new sitemap handler with database queries, logger, and base URL.
 // The baseURL should be the production domain without trailing slash.
-func NewSitemapHandler(queries *sqlc.Queries, logger *slog.Logger, baseURL string) *SitemapHandler {
+func NewSitemapHandler(queries sqlc.Querier, logger *slog.Logger, baseURL string) *SitemapHandler {
 	return &SitemapHandler{queries: queries, logger: logger, baseURL: baseURL}
 }
 
-// Sitemap generates a complete XML sitemap for the website.
-//
-// HTTP Method: GET
-// Route: /sitemap.xml
-// Content-Type: application/xml
-//
-// Template: None - generates XML directly
-// HTMX: Not an HTMX endpoint - returns XML document
+// Build generates a complete XML sitemap for the website. It is served by
+// Sitemap and kept between runs of the sitemap job.
 //
 // SEO Purpose:
 //   - Helps search engines discover all website pages
@@ -61,16 +69,19 @@ func NewSitemapHandler(queries *sqlc.Queries, logger *slog.Logger, baseURL strin
 //
 // Sitemap Structure:
 //   1. Static pages (homepage, category indexes, about, contact)
-//   2. Dynamic content pages (solutions, blog posts, case studies, whitepapers)
-//   3. All URLs are absolute (include baseURL)
-//   4. Only published content is included
+//   2. Dynamic content pages (product categories with paginated pages, products,
+//      solutions, blog posts, case studies, whitepapers)
+//   3. Blog month archives (/blog/2024/05) and tag pages (/blog/tags/{slug})
+//   4. Published landing pages (/{slug}) not marked noindex
+//   5. All URLs are absolute (include baseURL)
+//   6. Only published content is included
 //
 // Priority Guidelines:
 //   - 1.0: Homepage (highest priority)
 //   - 0.9: Main category indexes (products, solutions)
 //   - 0.8: Blog index, case studies index
 //   - 0.7: Individual content pages, about page
-//   - 0.6: Contact page (lowest priority)
+//   - 0.6: Contact page, landing pages
 //
 // Change Frequency Guidelines:
 //   - daily: Blog index (new posts frequently)
@@ -80,8 +91,8 @@ func NewSitemapHandler(queries *sqlc.Queries, logger *slog.Logger, baseURL strin
 // Error Handling:
 //   - Database query errors are logged but don't fail sitemap generation
 //   - Graceful degradation: partial sitemap still generated if one query fails
-//   - XML marshaling errors return 500 error
-func (h *SitemapHandler) Sitemap(c echo.Context) error {
+//   - XML marshaling errors are returned
+func (h *SitemapHandler) Build(ctx context.Context) ([]byte, error) {
 	// Use current date as lastmod for static pages (updated during deploys)
 	now := time.Now().Format("2006-01-02")
 
@@ -99,11 +110,17 @@ func (h *SitemapHandler) Sitemap(c echo.Context) error {
 	}{
 		{"/", "weekly", "1.0"},              // Homepage: highest priority, updated weekly
 		{"/products", "weekly", "0.9"},      // Product catalog index
+		{"/products/a-z", "weekly", "0.6"},  // A–Z product index: internal links to every product
 		{"/solutions", "weekly", "0.9"},     // Solutions index
 		{"/blog", "daily", "0.8"},           // Blog index: updated daily with new posts
+		{"/blog/tags", "weekly", "0.5"},     // Blog tag cloud
 		{"/about", "monthly", "0.7"},        // About page: rarely changes
 		{"/contact", "monthly", "0.6"},      // Contact page: lowest priority
 		{"/partners", "monthly", "0.7"},     // Partners page
+		{"/events", "weekly", "0.6"},        // Events and webinars
+		{"/careers", "weekly", "0.6"},       // Open positions
+		{"/press", "weekly", "0.6"},         // Press releases and media kit
+		{"/docs", "weekly", "0.6"},          // Documentation index
 	}
 
 	// Add static pages to sitemap with current date as lastmod
@@ -116,12 +133,32 @@ func (h *SitemapHandler) Sitemap(c echo.Context) error {
 		})
 	}
 
+	// Product categories: every level of the hierarchy, including paginated
+	// listing pages up to MaxIndexedCategoryPages so deep products are reachable
+	// URL format: /products/{slug}, /products/{parent}/{slug}?page=N
+	categoryPages, err := CategoryPages(ctx, h.queries)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list product categories", "error", err)
+	} else {
+		for _, p := range categoryPages {
+			priority := "0.8" // First page of each category
+			if p.Page > 1 {
+				priority = "0.5" // Later pages mainly help crawlers discover products
+			}
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + p.Path,
+				ChangeFreq: "weekly",
+				Priority:   priority,
+			})
+		}
+	}
+
 	// Products: individual product detail pages
 	// URL format: /products/{category_slug}/{product_slug}
 	// Only includes published products with valid categories
-	products, err := h.queries.ListProductsForSitemap(c.Request().Context())
+	products, err := h.queries.ListProductsForSitemap(ctx)
 	if err != nil {
-		h.logger.Error("sitemap: failed to list products", "error", err)
+		h.logger.ErrorContext(ctx, "sitemap: failed to list products", "error", err)
 	} else {
 		for _, p := range products {
 			u := URL{
@@ -139,10 +176,10 @@ func (h *SitemapHandler) Sitemap(c echo.Context) error {
 	// Solutions: individual solution detail pages
 	// URL format: /solutions/{slug}
 	// Only includes published solutions (status filtering in query)
-	solutions, err := h.queries.ListPublishedSolutions(c.Request().Context())
+	solutions, err := h.queries.ListPublishedSolutions(ctx)
 	if err != nil {
 		// Log error but continue generating sitemap with remaining content
-		h.logger.Error("sitemap: failed to list solutions", "error", err)
+		h.logger.ErrorContext(ctx, "sitemap: failed to list solutions", "error", err)
 	} else {
 		for _, s := range solutions {
 			u := URL{
@@ -162,13 +199,13 @@ func (h *SitemapHandler) Sitemap(c echo.Context) error {
 	// URL format: /blog/{slug}
 	// Limit set to 1000 - should cover most blogs, adjust if needed
 	// Note: Very large blogs (>1000 posts) should implement sitemap index
-	posts, err := h.queries.ListPublishedPosts(c.Request().Context(), sqlc.ListPublishedPostsParams{
+	posts, err := h.queries.ListPublishedPosts(ctx, sqlc.ListPublishedPostsParams{
 		Limit:  1000, // Maximum posts to include in sitemap
 		Offset: 0,    // Start from newest posts
 	})
 	if err != nil {
 		// Log error but continue generating sitemap with remaining content
-		h.logger.Error("sitemap: failed to list blog posts", "error", err)
+		h.logger.ErrorContext(ctx, "sitemap: failed to list blog posts", "error", err)
 	} else {
 		for _, p := range posts {
 			urlset.URLs = append(urlset.URLs, URL{
@@ -179,23 +216,160 @@ func (h *SitemapHandler) Sitemap(c echo.Context) error {
 		}
 	}
 
+	// Blog archives: one page per month with published posts
+	// URL format: /blog/{year}/{month}
+	// lastmod is the newest publish date in the month
+	archives, err := h.queries.ListBlogArchiveDates(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list blog archive months", "error", err)
+	} else {
+		for _, m := range archiveMonths(archives) {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + m.URL(),
+				LastMod:    m.LastPublished,
+				ChangeFreq: "monthly", // Only changes when a post in the month is published or edited
+				Priority:   "0.5",     // Low priority - listing pages duplicate post content
+			})
+		}
+	}
+
+	// Blog tag pages: one per tag used by a published post
+	// URL format: /blog/tags/{slug}
+	tags, err := h.queries.ListBlogTagCloud(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list blog tags", "error", err)
+	} else {
+		for _, t := range tagCloud(tags) {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + t.URL(),
+				ChangeFreq: "weekly", // Changes whenever a tagged post is published
+				Priority:   "0.4",    // Lowest priority - tag pages duplicate post content
+			})
+		}
+	}
+
+	// Landing pages: published campaign pages search engines may index
+	// URL format: /{slug}
+	landingPages, err := h.queries.ListPublishedLandingPages(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list landing pages", "error", err)
+	} else {
+		for _, p := range landingPages {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + "/" + p.Slug,
+				LastMod:    p.UpdatedAt.Format("2006-01-02"),
+				ChangeFreq: "weekly", // Campaign pages change while the campaign runs
+				Priority:   "0.6",    // Medium priority - conversion pages with a limited lifetime
+			})
+		}
+	}
+
+	// Events: published events and webinars, past ones included
+	// URL format: /events/{slug}
+	events, err := h.queries.ListPublishedEventSlugs(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list events", "error", err)
+	} else {
+		for _, e := range events {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + "/events/" + e.Slug,
+				LastMod:    e.UpdatedAt.Format("2006-01-02"),
+				ChangeFreq: "weekly", // Details change in the run-up to the event
+				Priority:   "0.5",    // Medium-low priority - short-lived pages
+			})
+		}
+	}
+
+	// Careers: open job postings; closed ones drop out of the sitemap
+	// URL format: /careers/{slug}
+	jobs, err := h.queries.ListOpenJobPostingSlugs(ctx, time.Now().UTC().Format(services.JobClockLayout))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list job postings", "error", err)
+	} else {
+		for _, j := range jobs {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + "/careers/" + j.Slug,
+				LastMod:    j.UpdatedAt.Format("2006-01-02"),
+				ChangeFreq: "weekly", // Postings are edited until they close
+				Priority:   "0.5",    // Medium-low priority - short-lived pages
+			})
+		}
+	}
+
+	// Press: released press releases; embargoed ones are added once they are out
+	// URL format: /press/{slug}
+	releases, err := h.queries.ListReleasedPressReleaseSlugs(ctx, time.Now().UTC().Format(services.PressClockLayout))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list press releases", "error", err)
+	} else {
+		for _, r := range releases {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + "/press/" + r.Slug,
+				LastMod:    r.UpdatedAt.Format("2006-01-02"),
+				ChangeFreq: "yearly", // Releases rarely change once out
+				Priority:   "0.5",    // Medium-low priority
+			})
+		}
+	}
+
+	// Documentation: published pages of the current version of each manual
+	// URL format: /docs/{set-slug}/{version}/{page-slug}
+	docPages, err := h.queries.ListDocSitemapPages(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to list doc pages", "error", err)
+	} else {
+		for _, p := range docPages {
+			urlset.URLs = append(urlset.URLs, URL{
+				Loc:        h.baseURL + services.DocPagePath(p.SetSlug, p.Version, p.Slug),
+				LastMod:    p.UpdatedAt.Format("2006-01-02"),
+				ChangeFreq: "monthly", // Manuals change with product releases
+				Priority:   "0.5",     // Medium-low priority - reference pages
+			})
+		}
+	}
+
 	// Marshal URLSet to formatted XML with 2-space indentation
 	// Pretty-printed XML is easier for humans to read when debugging
 	xmlData, err := xml.MarshalIndent(urlset, "", "  ")
 	if err != nil {
-		h.logger.Error("sitemap: failed to marshal XML", "error", err)
-		return c.String(http.StatusInternalServerError, "failed to generate sitemap")
+		return nil, err
 	}
 
 	// Prepend XML declaration header (required by XML spec)
 	// Results in: <?xml version="1.0" encoding="UTF-8"?>
-	xmlData = append([]byte(xml.Header), xmlData...)
+	return append([]byte(xml.Header), xmlData...), nil
+}
+
+// Sitemap serves the sitemap built by Build, from the copy kept by the
+// sitemap job when there is one (see SetSitemap).
+//
+// HTTP Method: GET
+// Route: /sitemap.xml
+// Content-Type: application/xml
+//
+// Template: None - generates XML directly
+// HTMX: Not an HTMX endpoint - returns XML document
+//
+// Error Handling:
+//   - A failed build (XML marshaling) returns a 500 error
+func (h *SitemapHandler) Sitemap(c echo.Context) error {
+	ctx := c.Request().Context()
+	build := h.Build
+	if sitemapStore != nil {
+		build = sitemapStore.Get
+	}
+	xmlData, err := build(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "sitemap: failed to generate XML", "error", err)
+		return c.String(http.StatusInternalServerError, "failed to generate sitemap")
+	}
 
 	// Return XML with correct Content-Type header
 	// application/xml tells search engines this is a sitemap
 	return c.Blob(http.StatusOK, "application/xml", xmlData)
 }
 
+
 // RobotsTxt generates the robots.txt file for search engine crawlers.
 //
 // HTTP Method: GET
@@ -17,8 +17,9 @@ import (
 	"github.com/labstack/echo/v4" // Echo web framework - routing, context, rendering
 
 	// Internal imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc"          // sqlc-generated database queries
-	"github.com/narendhupati/bluejay-cms/internal/services" // Cache service for HTML caching
+	"github.com/narendhupati/bluejay-cms/db/sqlc"            // sqlc-generated database queries
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // SEO overrides for the page's content
+	"github.com/narendhupati/bluejay-cms/internal/services"   // Cache service for HTML caching
 )
 
 // SolutionsHandler handles all solution-related public routes including
@@ -27,16 +28,16 @@ import (
 // "Cold Chain Monitoring", "Energy Management"). It implements caching for
 // improved performance on these content-heavy pages.
 type SolutionsHandler struct {
-	queries *sqlc.Queries    // Database query interface for solutions and related data
-	logger  *slog.Logger     // Structured logger for errors and debugging
-	cache   *services.Cache  // In-memory cache for rendered HTML pages
+	queries sqlc.Querier    // Database query interface for solutions and related data
+	logger  *slog.Logger    // Structured logger for errors and debugging
+	cache   *services.Cache // In-memory cache for rendered HTML pages
 }
 
 // NewSolutionsHandler creates a new SolutionsHandler with the required dependencies.
 // The cache is used to store rendered HTML to reduce database queries and
 // template rendering overhead. Solutions pages are relatively stable and
 // benefit significantly from caching.
-func NewSolutionsHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *SolutionsHandler {
+func NewSolutionsHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *SolutionsHandler {
 	return &SolutionsHandler{
 		queries: queries,
 		logger:  logger,
@@ -88,13 +89,13 @@ func (h *SolutionsHandler) renderAndCache(c echo.Context, cacheKey string, ttlSe
 	// Render template to buffer for caching
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Cache the rendered HTML and return to client
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 	return c.HTML(statusCode, html)
 }
 
@@ -151,8 +152,8 @@ func (h *SolutionsHandler) renderAndCache(c echo.Context, cacheKey string, ttlSe
 //   - Cache invalidated when solutions are published/unpublished
 func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 	// Check cache for fast response on repeated requests
-	cacheKey := "page:solutions"
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	cacheKey := localizedCacheKey(c, "page:solutions")
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -161,7 +162,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 	// Fetch all published solutions (critical - must succeed)
 	solutions, err := h.queries.ListPublishedSolutions(ctx)
 	if err != nil {
-		h.logger.Error("failed to list published solutions", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list published solutions", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -169,7 +170,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 	// Non-critical - gracefully degrade to empty array on error
 	features, err := h.queries.ListSolutionPageFeatures(ctx)
 	if err != nil {
-		h.logger.Error("failed to list solution page features", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list solution page features", "error", err)
 		features = []sqlc.SolutionPageFeature{} // Default to empty array
 	}
 
@@ -178,7 +179,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 	cta, err := h.queries.GetActiveSolutionsListingCTA(ctx)
 	if err != nil && err != sql.ErrNoRows {
 		// Log error only if it's not just a "no rows" case
-		h.logger.Error("failed to get active solutions listing CTA", "error", err)
+		h.logger.ErrorContext(ctx, "failed to get active solutions listing CTA", "error", err)
 	}
 
 	// Fetch editable page sections for admin customization
@@ -186,6 +187,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 	heroSection, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "solutions", SectionKey: "hero"})
 	gridSection, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "solutions", SectionKey: "grid_section"})
 	featuresSection, _ := h.queries.GetPageSection(ctx, sqlc.GetPageSectionParams{PageKey: "solutions", SectionKey: "features_section"})
+	heroSection, gridSection, featuresSection = localizeSection(c, heroSection), localizeSection(c, gridSection), localizeSection(c, featuresSection)
 
 	// Assemble template data
 	data := map[string]interface{}{
@@ -198,6 +200,8 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 		"GridSection":     gridSection,      // Grid section heading
 		"FeaturesSection": featuresSection,  // Features section heading
 	}
+	setLang(c, data)
+	dependsOn(c, services.SolutionCatalogTag)
 
 	// Render template and cache for 10 minutes
 	// Template: templates/public/pages/solutions_list.html
@@ -208,7 +212,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 //
 // HTTP Method: GET
 // Route: /solutions/:slug (e.g., /solutions/smart-factory-automation)
-// Query Parameters: ?preview=1 (optional, for admin preview)
+// Query Parameters: ?preview_token= (optional, signed preview link from the admin editor)
 // Template: public/pages/solution_detail.html (full page, not HTMX fragment)
 // HTMX: Returns complete HTML page
 // Cache TTL: 1800 seconds (30 minutes) for published solutions, no cache for previews
@@ -227,7 +231,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 //   - slug: Unique slug identifier for the solution (e.g., "cold-chain-monitoring")
 //
 // Query Parameters:
-//   - preview: If "1", shows draft/unpublished solutions for admin preview
+//   - preview_token: Signed, expiring token for this item; shows it even as a draft
 //
 // Template Data:
 //   - Title: Solution title - Browser tab title
@@ -255,7 +259,7 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 //   - Cross-linking to other solutions and products improves internal linking
 //
 // Preview Mode:
-//   - When ?preview=1 is set, shows unpublished/draft solutions
+//   - With a valid ?preview_token= for this item, shows it even as a draft
 //   - Bypasses published status check for admin review
 //   - Adds edit link for admin convenience
 //   - Disables caching to show live changes immediately
@@ -285,12 +289,12 @@ func (h *SolutionsHandler) SolutionsList(c echo.Context) error {
 //   - Cache is invalidated when solution is updated in admin
 func (h *SolutionsHandler) SolutionDetail(c echo.Context) error {
 	slug := c.Param("slug")
-	preview := isPreviewRequest(c) // Check if this is an admin preview request
+	previewItemID, preview := previewID(c, services.PreviewSolution) // Signed preview link for this content type?
 
 	// Skip cache lookup for preview mode to show live changes
 	if !preview {
-		cacheKey := fmt.Sprintf("page:solutions:%s", slug)
-		if cached, ok := h.cache.Get(cacheKey); ok {
+		cacheKey := localizedCacheKey(c, fmt.Sprintf("page:solutions:%s", slug))
+		if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 			return c.HTML(http.StatusOK, cached.(string))
 		}
 	}
@@ -312,9 +316,20 @@ func (h *SolutionsHandler) SolutionDetail(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Solution not found")
 	}
 	if err != nil {
-		h.logger.Error("failed to load solution", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load solution", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
+	if preview && solution.ID != previewItemID {
+		// The token was issued for another solution
+		return echo.NewHTTPError(http.StatusNotFound, "Solution not found")
+	}
+
+	// Overlay translated title/descriptions when a non-default locale is requested
+	if tr := translationFor(c, "solution", solution.ID); tr != nil {
+		solution.Title = tr.Field("title", solution.Title)
+		solution.ShortDescription = tr.Field("short_description", solution.ShortDescription)
+		solution.OverviewContent = translateNullString(tr, "overview_content", solution.OverviewContent)
+	}
 
 	// Fetch associated data for this solution
 	// All are non-critical - gracefully degrade to empty arrays on error
@@ -322,35 +337,35 @@ func (h *SolutionsHandler) SolutionDetail(c echo.Context) error {
 	// Statistics/metrics (e.g., "30% cost reduction", "99.9% uptime")
 	stats, err := h.queries.GetSolutionStats(ctx, solution.ID)
 	if err != nil {
-		h.logger.Error("failed to load solution stats", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load solution stats", "error", err)
 		stats = []sqlc.SolutionStat{}
 	}
 
 	// Challenges addressed by this solution
 	challenges, err := h.queries.GetSolutionChallenges(ctx, solution.ID)
 	if err != nil {
-		h.logger.Error("failed to load solution challenges", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load solution challenges", "error", err)
 		challenges = []sqlc.SolutionChallenge{}
 	}
 
 	// Products included in this solution
 	products, err := h.queries.GetSolutionProducts(ctx, solution.ID)
 	if err != nil {
-		h.logger.Error("failed to load solution products", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load solution products", "error", err)
 		products = []sqlc.GetSolutionProductsRow{}
 	}
 
 	// Call-to-action sections (request demo, download whitepaper, etc.)
 	ctas, err := h.queries.GetSolutionCTAs(ctx, solution.ID)
 	if err != nil {
-		h.logger.Error("failed to load solution CTAs", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load solution CTAs", "error", err)
 		ctas = []sqlc.SolutionCta{}
 	}
 
 	// Fetch all published solutions for "Other Solutions" section
 	allSolutions, err := h.queries.ListPublishedSolutions(ctx)
 	if err != nil {
-		h.logger.Error("failed to list published solutions", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list published solutions", "error", err)
 		allSolutions = []sqlc.ListPublishedSolutionsRow{}
 	}
 
@@ -375,6 +390,7 @@ func (h *SolutionsHandler) SolutionDetail(c echo.Context) error {
 	sectionMap := make(map[string]sqlc.PageSection)
 	for _, s := range sections {
 		// Replace placeholders in section content with actual solution data
+		s = localizeSection(c, s)
 		s.Heading = replacer.Replace(s.Heading)
 		s.Description = replacer.Replace(s.Description)
 		sectionMap[s.SectionKey] = s
@@ -397,6 +413,10 @@ func (h *SolutionsHandler) SolutionDetail(c echo.Context) error {
 		"CurrentPage":     "solutions",                             // For nav highlighting
 		"Sections":        sectionMap,                              // Editable sections
 	}
+	setLang(c, data)
+
+	// Apply the Admin > SEO override for this solution, if any
+	middleware.SetSEOEntity(c, services.PreviewSolution, solution.ID)
 
 	// Handle preview mode
 	if preview {
@@ -406,7 +426,12 @@ func (h *SolutionsHandler) SolutionDetail(c echo.Context) error {
 		return h.renderAndCache(c, "preview:solution:"+slug, 0, http.StatusOK, "public/pages/solution_detail.html", data)
 	}
 
-	// Render and cache for 30 minutes (1800 seconds)
+	// Render and cache for 30 minutes (1800 seconds), removed when the
+	// solution, its linked products or the other solutions change
+	dependsOn(c, services.SolutionTag(solution.ID), services.SolutionCatalogTag)
+	for _, p := range products {
+		dependsOn(c, services.ProductTag(p.ProductID))
+	}
 	// Template: templates/public/pages/solution_detail.html
-	return h.renderAndCache(c, fmt.Sprintf("page:solutions:%s", slug), 1800, http.StatusOK, "public/pages/solution_detail.html", data)
+	return h.renderAndCache(c, localizedCacheKey(c, fmt.Sprintf("page:solutions:%s", slug)), 1800, http.StatusOK, "public/pages/solution_detail.html", data)
 }
@@ -29,6 +29,14 @@ type mockQuerier struct {
 	getSolutionCTAsFunc              func(ctx context.Context, solutionID int64) ([]sqlc.SolutionCta, error)
 }
 
+func (m *mockQuerier) GetPageSection(ctx context.Context, arg sqlc.GetPageSectionParams) (sqlc.PageSection, error) {
+	return sqlc.PageSection{}, sql.ErrNoRows
+}
+
+func (m *mockQuerier) ListPageSections(ctx context.Context, pageKey string) ([]sqlc.PageSection, error) {
+	return []sqlc.PageSection{}, nil
+}
+
 func (m *mockQuerier) ListPublishedSolutions(ctx context.Context) ([]sqlc.ListPublishedSolutionsRow, error) {
 	if m.listPublishedSolutionsFunc != nil {
 		return m.listPublishedSolutionsFunc(ctx)
@@ -100,11 +108,8 @@ func setupTestHandler() (*SolutionsHandler, *mockQuerier, *services.Cache) {
 	cache := services.NewCache()
 	mock := &mockQuerier{}
 
-	// Create a wrapper for sqlc.Queries that uses our mock
-	queries := &sqlc.Queries{}
-
 	handler := &SolutionsHandler{
-		queries: queries,
+		queries: mock,
 		logger:  logger,
 		cache:   cache,
 	}
@@ -179,22 +184,20 @@ func TestSolutionsList_Success(t *testing.T) {
 		return expectedCTA, nil
 	}
 
-	// Replace handler's queries with our mock by creating a new handler that uses mock directly
-	// Since we can't easily replace the queries field, we'll test the handler's behavior
-	// through integration with a custom Querier
-	handler.queries = &sqlc.Queries{}
-
 	c, rec := setupTestContext(http.MethodGet, "/solutions")
 
-	// Note: This test needs actual integration with sqlc.Queries
-	// For now, we'll verify the test structure compiles
-	_ = handler
-	_ = mock
-	_ = cache
-	_ = c
-	_ = rec
-
-	t.Log("Test structure validated - handler setup successful")
+	if err := handler.SolutionsList(c); err != nil {
+		t.Fatalf("SolutionsList: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "public/pages/solutions_list.html" {
+		t.Errorf("expected solutions_list.html to be rendered, got %q", rec.Body.String())
+	}
+	if _, ok := cache.Get("page:solutions"); !ok {
+		t.Error("expected the rendered page to be cached")
+	}
 }
 
 func TestSolutionsList_EmptyState(t *testing.T) {
@@ -211,16 +214,19 @@ func TestSolutionsList_EmptyState(t *testing.T) {
 }
 
 func TestSolutionsList_DatabaseError(t *testing.T) {
-	handler, _, _ := setupTestHandler()
+	handler, mock, _ := setupTestHandler()
 
-	c, rec := setupTestContext(http.MethodGet, "/solutions")
+	mock.listPublishedSolutionsFunc = func(ctx context.Context) ([]sqlc.ListPublishedSolutionsRow, error) {
+		return nil, errors.New("database connection error")
+	}
 
-	// Test database error handling
-	_ = handler
-	_ = c
-	_ = rec
+	c, _ := setupTestContext(http.MethodGet, "/solutions")
 
-	t.Log("Database error test structure validated")
+	err := handler.SolutionsList(c)
+	var he *echo.HTTPError
+	if !errors.As(err, &he) || he.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %v", err)
+	}
 }
 
 func TestSolutionDetail_Success(t *testing.T) {
@@ -277,11 +283,13 @@ func TestSolutionDetail_CacheHit(t *testing.T) {
 	c.SetParamNames("slug")
 	c.SetParamValues("enterprise-security")
 
-	// Test cache hit
-	_ = handler
-	_ = rec
-
-	t.Log("Cache hit test structure validated")
+	// The mock has no solutions, so anything but the cached page is a miss
+	if err := handler.SolutionDetail(c); err != nil {
+		t.Fatalf("SolutionDetail: %v", err)
+	}
+	if rec.Body.String() != cachedHTML {
+		t.Errorf("expected the cached page, got %q", rec.Body.String())
+	}
 }
 
 func TestSolutionsList_CacheHit(t *testing.T) {
@@ -294,12 +302,12 @@ func TestSolutionsList_CacheHit(t *testing.T) {
 
 	c, rec := setupTestContext(http.MethodGet, "/solutions")
 
-	// Test cache hit
-	_ = handler
-	_ = c
-	_ = rec
-
-	t.Log("Solutions list cache hit test structure validated")
+	if err := handler.SolutionsList(c); err != nil {
+		t.Fatalf("SolutionsList: %v", err)
+	}
+	if rec.Body.String() != cachedHTML {
+		t.Errorf("expected the cached page, got %q", rec.Body.String())
+	}
 }
 
 func TestSolutionDetail_PartialDataFailure(t *testing.T) {
@@ -480,13 +488,18 @@ func TestSolutionDetail_MultipleScenarios(t *testing.T) {
 			c.SetParamNames("slug")
 			c.SetParamValues(tt.slug)
 
-			// Verify test setup
-			_ = handler
-			_ = rec
-			_ = tt.expectedStatus
-			_ = tt.expectError
-
-			t.Logf("Scenario '%s' structure validated", tt.name)
+			err := handler.SolutionDetail(c)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			status := rec.Code
+			var he *echo.HTTPError
+			if errors.As(err, &he) {
+				status = he.Code
+			}
+			if status != tt.expectedStatus {
+				t.Errorf("expected %d, got %d", tt.expectedStatus, status)
+			}
 		})
 	}
 }
@@ -0,0 +1,97 @@
+// Package public provides HTTP handlers for the public-facing website.
+// This file serves the PDF datasheet of a product, generated from its
+// specifications so sales does not keep separate documents.
+package public
+
+import (
+	// Standard library imports
+	"database/sql" // sql.ErrNoRows for 404 detection
+	"fmt"          // Cache keys and file names
+	"log/slog"     // Structured logging for errors
+	"net/http"     // HTTP status codes
+	"net/url"      // Escaping the variant in the page URL
+	"strings"      // Trimming the base URL
+
+	// Third-party imports
+	"github.com/labstack/echo/v4" // Echo web framework - routing, context, responses
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Site settings for the branding
+	"github.com/narendhupati/bluejay-cms/internal/services" // Product detail, PDF rendering and page cache
+)
+
+// specSheetCacheTTL is how long a generated datasheet is kept, in seconds.
+// Product edits remove it earlier through the product's cache tag.
+const specSheetCacheTTL = 1800
+
+// SpecSheetHandler serves /products/:category/:slug/spec-sheet.pdf.
+type SpecSheetHandler struct {
+	logger     *slog.Logger             // Structured logger for error reporting
+	productSvc *services.ProductService // Product detail and variants
+	sheets     *services.SpecSheets     // PDF rendering
+	cache      *services.Cache          // Generated datasheets, with the product pages
+	baseURL    string                   // Public site URL printed on the sheet
+}
+
+// NewSpecSheetHandler creates a new SpecSheetHandler instance.
+func NewSpecSheetHandler(logger *slog.Logger, productSvc *services.ProductService, sheets *services.SpecSheets, cache *services.Cache, baseURL string) *SpecSheetHandler {
+	return &SpecSheetHandler{logger: logger, productSvc: productSvc, sheets: sheets, cache: cache, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// SpecSheet handles GET /products/:category/:slug/spec-sheet.pdf
+// Serves the product's datasheet: specs, features, certifications and main
+// image with the site's branding. The PDF is generated on the first request
+// and cached like the product page, under the same key prefix and product
+// tag, so editing the product or the site branding regenerates it.
+//
+// Query Parameters:
+//   - variant: SKU of a variant, for the variant's specs and images
+func (h *SpecSheetHandler) SpecSheet(c echo.Context) error {
+	ctx := c.Request().Context()
+	categorySlug, productSlug := c.Param("category"), c.Param("slug")
+	variantSKU := c.QueryParam("variant")
+	cacheKey := fmt.Sprintf("page:products:%s:%s", categorySlug, productSlug)
+	if variantSKU != "" {
+		cacheKey += ":variant:" + variantSKU
+	}
+	cacheKey = localizedCacheKey(c, cacheKey+":spec-sheet")
+
+	preparePrint(c)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s-spec-sheet.pdf"`, productSlug))
+	if cached, ok := h.cache.Get(cacheKey); ok {
+		return c.Blob(http.StatusOK, "application/pdf", cached.([]byte))
+	}
+
+	detail, err := h.productSvc.GetProductDetail(ctx, productSlug)
+	if err == sql.ErrNoRows {
+		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "failed to load product detail", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if detail.Category.Slug != categorySlug || detail.Product.Status != "published" {
+		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
+	}
+	pageURL := fmt.Sprintf("%s/products/%s/%s", h.baseURL, detail.Category.Slug, detail.Product.Slug)
+	if variantSKU != "" {
+		if err := h.productSvc.ApplyVariant(ctx, detail, variantSKU); err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Variant not found")
+		} else if err != nil {
+			h.logger.ErrorContext(ctx, "failed to load product variant", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		pageURL += "?variant=" + url.QueryEscape(variantSKU)
+	}
+	if tr := translationFor(c, "product", detail.Product.ID); tr != nil {
+		detail.Product.Name = tr.Field("name", detail.Product.Name)
+		detail.Product.Tagline = translateNullString(tr, "tagline", detail.Product.Tagline)
+		detail.Product.Description = tr.Field("description", detail.Product.Description)
+	}
+
+	settings, _ := c.Get("settings").(sqlc.Setting)
+	pdf := h.sheets.Render(detail, settings, pageURL)
+	h.cache.Set(cacheKey, pdf, specSheetCacheTTL)
+	h.cache.Tag(cacheKey, services.ProductTag(detail.Product.ID))
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
@@ -0,0 +1,51 @@
+package public
+
+import (
+	"log/slog" // Structured logging for settings lookup failures
+	"net/http" // HTTP status codes
+
+	"github.com/labstack/echo/v4"                           // Echo web framework
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Settings query
+	"github.com/narendhupati/bluejay-cms/internal/services" // Theme token rendering
+)
+
+// ThemeHandler serves the theme tokens configured under Global Settings as
+// a stylesheet shared by the public and admin layouts.
+type ThemeHandler struct {
+	queries sqlc.Querier // Settings lookups
+	logger  *slog.Logger // Structured logger for error tracking
+}
+
+// NewThemeHandler creates a new theme handler.
+func NewThemeHandler(queries sqlc.Querier, logger *slog.Logger) *ThemeHandler {
+	return &ThemeHandler{queries: queries, logger: logger}
+}
+
+// Tokens renders the theme stylesheet.
+//
+// HTTP Method: GET
+// Route: /theme-tokens.css
+// Content-Type: text/css
+//
+// The stylesheet defines the light and dark CSS variables and maps the
+// templates' utility classes onto them (see services.Theme.CSS). Browsers
+// revalidate it on every page load (Cache-Control: no-cache) and get a 304
+// while the ETag matches, so theme changes show up without a cache flush.
+// If the settings cannot be loaded the default theme is served, keeping
+// pages styled.
+func (h *ThemeHandler) Tokens(c echo.Context) error {
+	theme := services.DefaultTheme
+	if settings, err := h.queries.GetSettings(c.Request().Context()); err != nil {
+		h.logger.ErrorContext(c.Request().Context(), "failed to load theme settings", "error", err)
+	} else {
+		theme = services.ThemeFromSettings(settings)
+	}
+
+	etag := theme.ETag()
+	c.Response().Header().Set("ETag", etag)
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	if c.Request().Header.Get("If-None-Match") == etag {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, "text/css; charset=utf-8", []byte(theme.CSS()))
+}
@@ -0,0 +1,131 @@
+// Package public provides HTTP handlers for public-facing website features.
+// This file applies content translations to public pages.
+package public
+
+import (
+	"database/sql" // Nullable source columns that may be overlaid with translations
+
+	"github.com/labstack/echo/v4"                             // Echo web framework for HTTP request/response handling
+	"github.com/narendhupati/bluejay-cms/db/sqlc"             // Page sections overlaid with translations
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // Locale chosen by the Locale middleware
+	"github.com/narendhupati/bluejay-cms/internal/services"   // TranslationService and fallback rules
+)
+
+// translations is a package-level reference to the translation service.
+// Like the admin activity log service, it is injected once at startup so every
+// public handler can localize content without changing its constructor.
+// When nil (e.g., in tests), pages always render the source language.
+var translations *services.TranslationService
+
+// SetTranslationService sets the package-level translation service.
+//
+// Parameters:
+//   - svc: Initialized TranslationService
+func SetTranslationService(svc *services.TranslationService) {
+	translations = svc
+}
+
+// requestLocale returns the locale to render when it differs from the source
+// language, or "" when the page should render untranslated content.
+func requestLocale(c echo.Context) string {
+	if translations == nil {
+		return ""
+	}
+	locale := middleware.GetLocale(c)
+	if !translations.IsTargetLocale(locale) {
+		return ""
+	}
+	return locale
+}
+
+// localizedCacheKey scopes a page cache key to the request locale so each
+// language is cached separately. Source-language pages keep their original key.
+func localizedCacheKey(c echo.Context, key string) string {
+	if locale := requestLocale(c); locale != "" {
+		return key + ":" + locale
+	}
+	return key
+}
+
+// translationFor returns the translation to overlay on an entity for this
+// request, or nil to render the source (see TranslationService.Resolve).
+func translationFor(c echo.Context, entityType string, entityID int64) *services.Translation {
+	locale := requestLocale(c)
+	if locale == "" {
+		return nil
+	}
+	return translations.Resolve(c.Request().Context(), entityType, entityID, locale)
+}
+
+// translateNullString overlays a translation onto a nullable source column.
+func translateNullString(tr *services.Translation, field string, src sql.NullString) sql.NullString {
+	if v := tr.Field(field, src.String); v != src.String {
+		return sql.NullString{String: v, Valid: true}
+	}
+	return src
+}
+
+// localizeSection overlays the translation of a page section's text fields.
+// Button URLs are not translated; they point at locale-independent routes.
+func localizeSection(c echo.Context, section sqlc.PageSection) sqlc.PageSection {
+	tr := translationFor(c, "page_section", section.ID)
+	if tr == nil {
+		return section
+	}
+	section.Heading = tr.Field("heading", section.Heading)
+	section.Subheading = tr.Field("subheading", section.Subheading)
+	section.Description = tr.Field("description", section.Description)
+	section.Label = tr.Field("label", section.Label)
+	section.PrimaryButtonText = tr.Field("primary_button_text", section.PrimaryButtonText)
+	section.SecondaryButtonText = tr.Field("secondary_button_text", section.SecondaryButtonText)
+	return section
+}
+
+// localizedSectionMap keys a page's sections by section_key with their
+// translations applied, the shape the page templates look sections up in.
+func localizedSectionMap(c echo.Context, sections []sqlc.PageSection) map[string]sqlc.PageSection {
+	sectionMap := make(map[string]sqlc.PageSection, len(sections))
+	for _, s := range sections {
+		sectionMap[s.SectionKey] = localizeSection(c, s)
+	}
+	return sectionMap
+}
+
+// LanguageLink is one entry of the language switcher and of the page's
+// hreflang alternates.
+type LanguageLink struct {
+	Code    string // Locale code (e.g., "de")
+	Name    string // Language name (e.g., "Deutsch")
+	URL     string // This page under the locale prefix (e.g., "/de/products")
+	Current bool   // The language the page is rendered in
+}
+
+// languageLinks returns the switcher entries for the current page, or nil
+// when the site has a single language.
+func languageLinks(c echo.Context) []LanguageLink {
+	if translations == nil || len(translations.Locales()) == 0 {
+		return nil
+	}
+	current := requestLocale(c)
+	if current == "" {
+		current = translations.DefaultLocale()
+	}
+	path := c.Request().URL.Path
+	langs := translations.Languages()
+	links := make([]LanguageLink, len(langs))
+	for i, l := range langs {
+		links[i] = LanguageLink{Code: l.Code, Name: l.Name, URL: "/" + l.Code + path, Current: l.Code == current}
+	}
+	return links
+}
+
+// setLang records the rendered locale so the layout can emit <html lang="...">,
+// and the language switcher entries for the header and hreflang alternates.
+func setLang(c echo.Context, data map[string]interface{}) {
+	if locale := requestLocale(c); locale != "" {
+		data["Lang"] = locale
+	}
+	if links := languageLinks(c); links != nil {
+		data["Languages"] = links
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/labstack/echo/v4"
 	// sqlc provides type-safe database query interfaces generated from SQL files
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	// middleware provides SetSEOEntity, which names the page's content for SEO overrides
+	"github.com/narendhupati/bluejay-cms/internal/middleware"
 	// services provides business logic components like caching
 	"github.com/narendhupati/bluejay-cms/internal/services"
 )
@@ -30,14 +32,14 @@ import (
 // It manages the whitepapers listing page (with topic filtering), individual whitepaper
 // detail pages (with preview mode for admins), and the gated download form submission.
 type WhitepapersHandler struct {
-	queries *sqlc.Queries    // Database query interface for fetching whitepapers, topics, and recording downloads
-	logger  *slog.Logger     // Structured logger for debugging and error tracking
-	cache   *services.Cache  // In-memory cache for rendered HTML to improve response times
+	queries sqlc.Querier    // Database query interface for fetching whitepapers, topics, and recording downloads
+	logger  *slog.Logger    // Structured logger for debugging and error tracking
+	cache   *services.Cache // In-memory cache for rendered HTML to improve response times
 }
 
 // NewWhitepapersHandler constructs a new WhitepapersHandler with required dependencies.
 // This constructor is called during application initialization to wire up dependencies.
-func NewWhitepapersHandler(queries *sqlc.Queries, logger *slog.Logger, cache *services.Cache) *WhitepapersHandler {
+func NewWhitepapersHandler(queries sqlc.Querier, logger *slog.Logger, cache *services.Cache) *WhitepapersHandler {
 	return &WhitepapersHandler{
 		queries: queries,
 		logger:  logger,
@@ -79,13 +81,13 @@ func (h *WhitepapersHandler) renderAndCache(c echo.Context, cacheKey string, ttl
 	// This allows us to cache the rendered HTML before sending it
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, templateName, data, c); err != nil {
-		h.logger.Error("template render failed", "template", templateName, "error", err)
+		h.logger.ErrorContext(c.Request().Context(), "template render failed", "template", templateName, "error", err)
 		return err
 	}
 
 	// Extract rendered HTML from buffer and store in cache for future requests
-	html := buf.String()
-	h.cache.Set(cacheKey, html, ttlSeconds)
+	html := minifyPage(c, buf.String())
+	cachePage(c, h.cache, cacheKey, html, ttlSeconds)
 
 	// Return the rendered HTML to the client with specified status code
 	return c.HTML(statusCode, html)
@@ -99,8 +101,8 @@ func (h *WhitepapersHandler) renderAndCache(c echo.Context, cacheKey string, ttl
 // Cache: 600 seconds (10 minutes) - whitepaper listings are relatively static
 //
 // HTMX Behavior: This endpoint returns a full HTML page, not an HTMX fragment.
-// It is designed for direct browser navigation. However, the topic filter dropdown
-// may use HTMX to reload the page content when selection changes.
+// It is designed for direct browser navigation. The topic filter dropdown
+// refreshes only the grid through WhitepapersListGrid.
 //
 // Query Parameters:
 //   - topic (optional): Integer ID of topic to filter by (e.g., ?topic=2)
@@ -111,6 +113,25 @@ func (h *WhitepapersHandler) renderAndCache(c echo.Context, cacheKey string, ttl
 //
 // Returns: HTTP 200 with rendered whitepapers.html template, or HTTP 400 if topic param is invalid
 func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
+	return h.whitepapersList(c, false)
+}
+
+// WhitepapersListGrid handles GET requests to /partials/whitepapers
+// Renders only the filter bar and grid of the /whitepapers page.
+//
+// Route: GET /partials/whitepapers (same ?topic= parameter as WhitepapersList)
+// Template: public/partials/whitepapers_grid.html (HTMX fragment)
+// Cache: 600 seconds (10 minutes), separate from the full page
+//
+// HTMX Behavior: The filter dropdown swaps the response into #whitepapers-grid;
+// HX-Push-Url records the matching /whitepapers URL in browser history.
+func (h *WhitepapersHandler) WhitepapersListGrid(c echo.Context) error {
+	pushGridURL(c, "/whitepapers")
+	return h.whitepapersList(c, true)
+}
+
+// whitepapersList renders the listing as a full page, or only its filter bar and grid.
+func (h *WhitepapersHandler) whitepapersList(c echo.Context, grid bool) error {
 	// Extract request context for passing to database queries
 	ctx := c.Request().Context()
 
@@ -126,7 +147,7 @@ func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
 		selectedTopicID, err = strconv.ParseInt(topicParam, 10, 64)
 		if err != nil {
 			// Invalid topic ID format - return 400 error
-			h.logger.Error("invalid topic parameter", "error", err)
+			h.logger.ErrorContext(ctx, "invalid topic parameter", "error", err)
 			return echo.NewHTTPError(http.StatusBadRequest, "Invalid topic parameter")
 		}
 	}
@@ -138,8 +159,14 @@ func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
 		cacheKey = fmt.Sprintf("page:whitepapers:topic:%d", selectedTopicID)
 	}
 
+	templateName := "public/pages/whitepapers.html"
+	if grid {
+		cacheKey += gridCacheSuffix
+		templateName = "public/partials/whitepapers_grid.html"
+	}
+
 	// Check if cached version exists and return it immediately
-	if cached, ok := h.cache.Get(cacheKey); ok {
+	if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 		return c.HTML(http.StatusOK, cached.(string))
 	}
 
@@ -149,26 +176,26 @@ func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
 		// Filtered view: only published whitepapers for selected topic
 		whitepapers, err = h.queries.ListPublishedWhitepapersByTopic(ctx, selectedTopicID)
 		if err != nil {
-			h.logger.Error("failed to list whitepapers by topic", "error", err)
+			h.logger.ErrorContext(ctx, "failed to list whitepapers by topic", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Get count for display (e.g., "Showing 8 whitepapers")
 		totalCount, err = h.queries.CountPublishedWhitepapersByTopic(ctx, selectedTopicID)
 		if err != nil {
-			h.logger.Error("failed to count whitepapers by topic", "error", err)
+			h.logger.ErrorContext(ctx, "failed to count whitepapers by topic", "error", err)
 			totalCount = 0 // Graceful degradation - continue without count
 		}
 	} else {
 		// Unfiltered view: all published whitepapers
 		whitepapers, err = h.queries.ListPublishedWhitepapers(ctx)
 		if err != nil {
-			h.logger.Error("failed to list whitepapers", "error", err)
+			h.logger.ErrorContext(ctx, "failed to list whitepapers", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Get total count of all published whitepapers
 		totalCount, err = h.queries.CountPublishedWhitepapers(ctx)
 		if err != nil {
-			h.logger.Error("failed to count whitepapers", "error", err)
+			h.logger.ErrorContext(ctx, "failed to count whitepapers", "error", err)
 			totalCount = 0 // Graceful degradation
 		}
 	}
@@ -177,7 +204,7 @@ func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
 	// Topics categorize whitepapers (e.g., "Security", "Performance", "Best Practices")
 	topics, err := h.queries.ListWhitepaperTopics(ctx)
 	if err != nil {
-		h.logger.Error("failed to list whitepaper topics", "error", err)
+		h.logger.ErrorContext(ctx, "failed to list whitepaper topics", "error", err)
 		topics = []sqlc.WhitepaperTopic{} // Default to empty slice
 	}
 
@@ -192,23 +219,24 @@ func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
 	}
 
 	// Render template and cache for 10 minutes, return HTML to client
-	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, "public/pages/whitepapers.html", data)
+	return h.renderAndCache(c, cacheKey, 600, http.StatusOK, templateName, data)
 }
 
 // WhitepaperDetail handles GET requests to /whitepapers/:slug
 // Renders an individual whitepaper detail page with description, learning points, and download form.
 // Supports preview mode for admins to view draft whitepapers before publishing.
 //
-// Route: GET /whitepapers/:slug (with optional ?preview=true query parameter for admins)
+// Route: GET /whitepapers/:slug (with optional ?preview_token= from the admin editor)
 // Template: templates/public/pages/whitepaper_detail.html (full page, not HTMX fragment)
 // Cache: 900 seconds (15 minutes) for published, 0 seconds for preview mode
 //
 // HTMX Behavior: This endpoint returns a full HTML page, not an HTMX fragment.
 // The page includes a download form that uses HTMX to submit (see WhitepaperDownload handler).
 //
-// Preview Mode: When ?preview=true is in query string (checked by isPreviewRequest helper),
-// the handler fetches draft whitepapers and skips caching. This allows admins to preview
-// unpublished content before making it live. Preview mode adds edit link to the page.
+// Preview Mode: When ?preview_token= holds a valid token for this item (see previewID),
+// the handler fetches it even as a draft and skips caching. Tokens are signed, expire,
+// and are issued on the admin edit page, so editors can share the link with reviewers
+// before publishing. Preview mode adds edit link to the page.
 //
 // Related Content: Fetches learning points (key takeaways) and related whitepapers
 // from the same topic to encourage further engagement.
@@ -217,13 +245,13 @@ func (h *WhitepapersHandler) WhitepapersList(c echo.Context) error {
 func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 	// Extract slug from URL path parameter (e.g., /whitepapers/cloud-security-best-practices)
 	slug := c.Param("slug")
-	// Check if this is a preview request (admins viewing draft content)
-	preview := isPreviewRequest(c)
+	// Check for a signed preview link (drafts shared from the admin editor)
+	previewItemID, preview := previewID(c, services.PreviewWhitepaper)
 
 	// Skip cache check for preview mode - always fetch fresh data for admins
 	if !preview {
 		cacheKey := fmt.Sprintf("page:whitepapers:%s", slug)
-		if cached, ok := h.cache.Get(cacheKey); ok {
+		if cached, ok := cachedPage(c, h.cache, cacheKey); ok {
 			return c.HTML(http.StatusOK, cached.(string))
 		}
 	}
@@ -243,11 +271,12 @@ func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 		// Preview mode: include draft whitepapers (not yet published)
 		// This allows admins to see how content will look before publishing
 		wp, err := h.queries.GetWhitepaperBySlugIncludeDrafts(ctx, slug)
-		if err == sql.ErrNoRows {
+		if err == sql.ErrNoRows || (err == nil && wp.ID != previewItemID) {
+			// Missing, or the token was issued for another whitepaper
 			return echo.NewHTTPError(http.StatusNotFound, "Whitepaper not found")
 		}
 		if err != nil {
-			h.logger.Error("failed to load whitepaper", "error", err)
+			h.logger.ErrorContext(ctx, "failed to load whitepaper", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Extract fields from query result
@@ -262,7 +291,7 @@ func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusNotFound, "Whitepaper not found")
 		}
 		if err != nil {
-			h.logger.Error("failed to load whitepaper", "error", err)
+			h.logger.ErrorContext(ctx, "failed to load whitepaper", "error", err)
 			return echo.NewHTTPError(http.StatusInternalServerError)
 		}
 		// Extract fields from query result
@@ -276,7 +305,7 @@ func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 	// Learning points are bullet-style highlights of what reader will learn
 	learningPoints, err := h.queries.GetWhitepaperLearningPoints(ctx, wpID)
 	if err != nil {
-		h.logger.Error("failed to load whitepaper learning points", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load whitepaper learning points", "error", err)
 		learningPoints = []sqlc.GetWhitepaperLearningPointsRow{} // Default to empty slice
 	}
 
@@ -287,7 +316,7 @@ func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 		TopicID: wpTopicID, // Find whitepapers in same topic
 	})
 	if err != nil {
-		h.logger.Error("failed to load related whitepapers", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load related whitepapers", "error", err)
 		relatedPapers = []sqlc.GetRelatedWhitepapersRow{} // Default to empty slice
 	}
 
@@ -311,6 +340,9 @@ func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 		"CurrentPage":     "whitepapers",                              // Used by nav to highlight active link
 	}
 
+	// Apply the Admin > SEO override for this whitepaper, if any
+	middleware.SetSEOEntity(c, services.PreviewWhitepaper, wpID)
+
 	// Handle preview mode differently - no caching and add admin edit link
 	if preview {
 		data["IsPreview"] = true // Shows preview banner in template
@@ -345,6 +377,8 @@ func (h *WhitepapersHandler) WhitepaperDetail(c echo.Context) error {
 // Business Logic: This implements a "gated content" strategy where visitors must provide
 // contact information before accessing the whitepaper PDF. The information is stored for
 // lead generation and marketing purposes. Download count is incremented for analytics.
+// Signed-in customers may leave the fields out: their account fills them in, and the
+// whitepaper is added to their library on /account.
 //
 // Returns: HTTP 200 with success HTML fragment containing download link, or HTTP 400/404/500 on error
 func (h *WhitepapersHandler) WhitepaperDownload(c echo.Context) error {
@@ -359,7 +393,7 @@ func (h *WhitepapersHandler) WhitepaperDownload(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Whitepaper not found")
 	}
 	if err != nil {
-		h.logger.Error("failed to load whitepaper", "error", err)
+		h.logger.ErrorContext(ctx, "failed to load whitepaper", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
@@ -371,6 +405,27 @@ func (h *WhitepapersHandler) WhitepaperDownload(c echo.Context) error {
 	designation := strings.TrimSpace(c.FormValue("designation"))
 	marketingConsent := c.FormValue("marketing_consent")
 
+	// Signed-in customers download with one click: their account fills in
+	// the lead fields the shortcut form does not send
+	account, signedIn := middleware.CustomerFrom(c)
+	if signedIn {
+		if name == "" {
+			name = account.Name
+		}
+		if email == "" {
+			email = account.Email
+		}
+		if company == "" {
+			company = account.Company
+		}
+		if designation == "" {
+			designation = account.Designation
+		}
+		if marketingConsent == "" && account.MarketingConsent == 1 {
+			marketingConsent = "1"
+		}
+	}
+
 	// Validate required fields - reject submission if any are missing
 	// Returns an error HTML fragment that HTMX will swap into the page
 	if name == "" || email == "" || company == "" {
@@ -409,10 +464,21 @@ func (h *WhitepapersHandler) WhitepaperDownload(c echo.Context) error {
 		},
 	})
 	if err != nil {
-		h.logger.Error("failed to create whitepaper download", "error", err)
+		h.logger.ErrorContext(ctx, "failed to create whitepaper download", "error", err)
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	// Keep the whitepaper in the customer's library on /account
+	if signedIn {
+		err := h.queries.AddCustomerLibraryWhitepaper(ctx, sqlc.AddCustomerLibraryWhitepaperParams{
+			AccountID:    account.ID,
+			WhitepaperID: sql.NullInt64{Int64: whitepaper.ID, Valid: true},
+		})
+		if err != nil {
+			h.logger.ErrorContext(ctx, "failed to add whitepaper to customer library", "error", err, "account", account.ID)
+		}
+	}
+
 	// Increment download count asynchronously in background goroutine
 	// This updates the whitepaper's download_count field for analytics
 	// We don't wait for this to complete - fire and forget for performance
@@ -442,7 +508,7 @@ func (h *WhitepapersHandler) WhitepaperDownload(c echo.Context) error {
 	// This template shows thank you message and download link
 	var buf bytes.Buffer
 	if err := c.Echo().Renderer.Render(&buf, "public/pages/whitepaper_success.html", data, c); err != nil {
-		h.logger.Error("template render failed", "template", "public/pages/whitepaper_success.html", "error", err)
+		h.logger.ErrorContext(ctx, "template render failed", "template", "public/pages/whitepaper_success.html", "error", err)
 		return err
 	}
 
@@ -0,0 +1,332 @@
+// Package jobs runs the server's recurring work (cache warm-up, backups,
+// archiving, log and trash pruning, sitemap regeneration, scheduled
+// publishing) on cron-like schedules, and records each job's last run for
+// Admin > Jobs, where jobs can also be started by hand.
+// Long-lived workers with their own loops, such as the export queue, the
+// database health watchdog and the replication checkpointer, are not jobs.
+//
+// The last run of every job is kept in the job_runs table, so after a
+// restart interval jobs continue their schedule and runs that were missed
+// while the server was down are made up shortly after startup.
+package jobs
+
+import (
+	"context"  // Job cancellation
+	"errors"   // Sentinel errors for handlers
+	"fmt"      // Panic messages
+	"log/slog" // Structured logging of failed runs
+	"slices"   // Registration order
+	"sync"     // Guards job state shared with handlers
+	"time"     // Scheduling and run durations
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // job_runs queries
+)
+
+// Run triggers, stored in job_runs.last_trigger.
+const (
+	TriggerSchedule = "schedule" // Started by the scheduler
+	TriggerManual   = "manual"   // Started with "Run now"
+)
+
+// DefaultStartupDelay is how long after Start new and overdue jobs run.
+const DefaultStartupDelay = time.Minute
+
+// jobTimeLayout is how run times are written to job_runs (UTC).
+const jobTimeLayout = "2006-01-02 15:04:05"
+
+var (
+	// ErrUnknownJob is returned by RunNow for names that are not registered.
+	ErrUnknownJob = errors.New("jobs: unknown job")
+	// ErrJobRunning is returned by RunNow while the job is already running.
+	ErrJobRunning = errors.New("jobs: job is already running")
+)
+
+// Job is a unit of recurring work.
+type Job struct {
+	Name        string                          // Unique, URL-safe name, e.g. "trash-purge"
+	Description string                          // Shown on the jobs page
+	Schedule    Schedule                        // When it runs; nil runs it only by hand
+	Run         func(ctx context.Context) error // The work; an error marks the run failed
+}
+
+// Status is a job and its last run, as shown on the jobs page.
+type Status struct {
+	Name         string
+	Description  string
+	Schedule     string        // Schedule description, "" when the job only runs by hand
+	Running      bool          // A run is in progress
+	NextRun      time.Time     // Zero when not scheduled or running
+	LastStart    time.Time     // Zero when the job never ran
+	LastDuration time.Duration // Length of the last run
+	LastError    string        // Error of the last run, "" when it succeeded
+	LastTrigger  string        // TriggerSchedule or TriggerManual
+	LastSuccess  time.Time     // Start of the last successful run
+	Runs         int64         // Runs recorded
+	Failures     int64         // Failed runs recorded
+}
+
+// Config controls the scheduler. The zero value uses the defaults.
+type Config struct {
+	// StartupDelay is how long after Start jobs that never ran, or missed a
+	// run, are started. Defaults to DefaultStartupDelay.
+	StartupDelay time.Duration
+}
+
+// job is a registered Job with its state.
+type job struct {
+	Job
+	status Status
+}
+
+// Scheduler runs registered jobs on their schedules, one run of a job at a
+// time. A job that is still running when it is due again skips that run.
+type Scheduler struct {
+	queries *sqlc.Queries // Last runs (nil keeps them in memory only)
+	logger  *slog.Logger  // Failed runs and bookkeeping errors
+	config  Config        // Startup delay
+
+	mu   sync.Mutex
+	jobs []*job           // Registration order, for the jobs page
+	ctx  context.Context  // Set by Start; runs stop when it is cancelled
+	wake chan struct{}    // Signals the loop that a next run time changed
+	now  func() time.Time // Clock, replaced in tests
+}
+
+// New creates a scheduler. Add jobs before Start.
+//
+// Parameters:
+//   - queries: Database query interface for job_runs; nil keeps runs in memory
+//   - logger: Structured logger for failed runs
+//   - config: Startup delay
+//
+// Returns:
+//   - *Scheduler: Scheduler ready for jobs
+func New(queries *sqlc.Queries, logger *slog.Logger, config Config) *Scheduler {
+	if config.StartupDelay <= 0 {
+		config.StartupDelay = DefaultStartupDelay
+	}
+	return &Scheduler{
+		queries: queries,
+		logger:  logger,
+		config:  config,
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+// Add registers a job. It panics on a duplicate name or a missing Run,
+// which are programming errors.
+//
+// Parameters:
+//   - j: The job
+func (s *Scheduler) Add(j Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j.Run == nil || s.find(j.Name) != nil {
+		panic(fmt.Sprintf("jobs: invalid or duplicate job %q", j.Name))
+	}
+	jb := &job{Job: j, status: Status{Name: j.Name, Description: j.Description}}
+	if j.Schedule != nil {
+		jb.status.Schedule = j.Schedule.String()
+	}
+	s.jobs = append(s.jobs, jb)
+}
+
+// find returns the named job; s.mu must be held.
+func (s *Scheduler) find(name string) *job {
+	i := slices.IndexFunc(s.jobs, func(j *job) bool { return j.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return s.jobs[i]
+}
+
+// Start loads the last runs from job_runs, works out when each job runs
+// next and runs jobs in the background until ctx is cancelled.
+//
+// Parameters:
+//   - ctx: Controls the lifetime of the scheduler and of running jobs
+func (s *Scheduler) Start(ctx context.Context) {
+	var runs []sqlc.JobRun
+	if s.queries != nil {
+		var err error
+		if runs, err = s.queries.ListJobRuns(ctx); err != nil {
+			s.logger.Error("failed to load job runs", "error", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.ctx = ctx
+	now := s.now()
+	for _, r := range runs {
+		if jb := s.find(r.Name); jb != nil {
+			jb.status.LastStart = r.LastStartedAt
+			jb.status.LastDuration = time.Duration(r.LastDurationMs) * time.Millisecond
+			jb.status.LastError = r.LastError
+			jb.status.LastTrigger = r.LastTrigger
+			jb.status.LastSuccess = r.LastSuccessAt.Time
+			jb.status.Runs = r.Runs
+			jb.status.Failures = r.Failures
+		}
+	}
+	for _, jb := range s.jobs {
+		if jb.Schedule == nil {
+			continue
+		}
+		earliest := now.Add(s.config.StartupDelay)
+		jb.status.NextRun = earliest
+		if !jb.status.LastStart.IsZero() {
+			if next := jb.Schedule.Next(jb.status.LastStart.Add(jb.status.LastDuration).In(now.Location())); next.After(earliest) {
+				jb.status.NextRun = next
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	go s.loop(ctx)
+}
+
+// loop starts due jobs and sleeps until the next one is due.
+func (s *Scheduler) loop(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		now := s.now()
+		var next time.Time
+		for _, jb := range s.jobs {
+			if jb.status.Running || jb.status.NextRun.IsZero() {
+				continue
+			}
+			if !jb.status.NextRun.After(now) {
+				s.begin(jb, TriggerSchedule)
+				continue
+			}
+			if next.IsZero() || jb.status.NextRun.Before(next) {
+				next = jb.status.NextRun
+			}
+		}
+		s.mu.Unlock()
+
+		wait := time.Hour // Nothing scheduled: wait for a wake-up
+		if !next.IsZero() {
+			wait = next.Sub(now)
+		}
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-s.wake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		}
+	}
+}
+
+// RunNow starts a job in the background outside its schedule. For interval
+// schedules the next run is counted from the end of this one.
+//
+// Parameters:
+//   - name: Job name
+//
+// Returns:
+//   - error: ErrUnknownJob or ErrJobRunning
+func (s *Scheduler) RunNow(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jb := s.find(name)
+	if jb == nil {
+		return ErrUnknownJob
+	}
+	if jb.status.Running {
+		return ErrJobRunning
+	}
+	s.begin(jb, TriggerManual)
+	return nil
+}
+
+// begin marks jb running and runs it in a goroutine; s.mu must be held.
+func (s *Scheduler) begin(jb *job, trigger string) {
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	jb.status.Running = true
+	jb.status.NextRun = time.Time{}
+	start := s.now()
+	go func() {
+		err := s.run(ctx, jb)
+		s.finish(ctx, jb, trigger, start, err)
+	}()
+}
+
+// run calls the job, turning a panic into an error so one broken job does
+// not stop the server.
+func (s *Scheduler) run(ctx context.Context, jb *job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return jb.Run(ctx)
+}
+
+// finish records a run and schedules the job's next run.
+func (s *Scheduler) finish(ctx context.Context, jb *job, trigger string, start time.Time, err error) {
+	end := s.now()
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+		s.logger.Error("job failed", "job", jb.Name, "trigger", trigger, "error", err)
+	}
+
+	s.mu.Lock()
+	st := &jb.status
+	st.Running = false
+	st.LastStart = start
+	st.LastDuration = end.Sub(start)
+	st.LastError = errMsg
+	st.LastTrigger = trigger
+	st.Runs++
+	if err != nil {
+		st.Failures++
+	} else {
+		st.LastSuccess = start
+	}
+	if jb.Schedule != nil && ctx.Err() == nil {
+		st.NextRun = jb.Schedule.Next(end)
+	}
+	duration := st.LastDuration
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	if s.queries != nil {
+		// Recorded even when shutdown cancelled the run
+		if err := s.queries.RecordJobRun(context.WithoutCancel(ctx), sqlc.RecordJobRunParams{
+			Name:        jb.Name,
+			StartedAt:   start.UTC().Format(jobTimeLayout),
+			DurationMs:  duration.Milliseconds(),
+			Error:       errMsg,
+			TriggerKind: trigger,
+		}); err != nil {
+			s.logger.Error("failed to record job run", "job", jb.Name, "error", err)
+		}
+	}
+}
+
+// Jobs returns every registered job with its last run, in registration order.
+func (s *Scheduler) Jobs() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Status, len(s.jobs))
+	for i, jb := range s.jobs {
+		out[i] = jb.status
+	}
+	return out
+}
@@ -0,0 +1,164 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/jobs"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestCron_Next(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.ParseInLocation("2006-01-02 15:04", s, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	tests := []struct {
+		expr, from, want string
+	}{
+		{"30 2 * * *", "2026-03-10 01:00", "2026-03-10 02:30"},
+		{"30 2 * * *", "2026-03-10 02:30", "2026-03-11 02:30"},
+		{"*/15 * * * *", "2026-03-10 10:07", "2026-03-10 10:15"},
+		{"0 9-17/4 * * 1-5", "2026-03-13 18:00", "2026-03-16 09:00"}, // Friday evening to Monday
+		{"0 0 1,15 * *", "2026-03-02 00:00", "2026-03-15 00:00"},
+		{"0 0 * * 7", "2026-03-10 00:00", "2026-03-15 00:00"},  // 7 is Sunday
+		{"0 0 13 * 5", "2026-03-01 00:00", "2026-03-06 00:00"}, // Either day field matches
+		{"@monthly", "2026-12-31 23:59", "2027-01-01 00:00"},
+	}
+	for _, tt := range tests {
+		s, err := jobs.Cron(tt.expr)
+		if err != nil {
+			t.Fatalf("Cron(%q): %v", tt.expr, err)
+		}
+		if got := s.Next(at(tt.from)); !got.Equal(at(tt.want)) {
+			t.Errorf("%q after %s = %s, want %s", tt.expr, tt.from, got.Format("2006-01-02 15:04"), tt.want)
+		}
+	}
+
+	if s := jobs.MustCron("0 0 30 2 *"); !s.Next(at("2026-01-01 00:00")).IsZero() {
+		t.Error("February 30 matched")
+	}
+	for _, bad := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := jobs.Cron(bad); err == nil {
+			t.Errorf("Cron(%q) accepted", bad)
+		}
+	}
+}
+
+func TestEvery_String(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		time.Hour:        "every hour",
+		24 * time.Hour:   "every 24 hours",
+		5 * time.Minute:  "every 5 minutes",
+		90 * time.Second: "every 1m30s",
+	} {
+		if got := jobs.Every(d).String(); got != want {
+			t.Errorf("Every(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestScheduler_RunsAndRecords(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var scheduled, manual atomic.Int32
+	s := jobs.New(queries, discard, jobs.Config{StartupDelay: 10 * time.Millisecond})
+	s.Add(jobs.Job{Name: "tick", Schedule: jobs.Every(time.Hour), Run: func(context.Context) error {
+		scheduled.Add(1)
+		return nil
+	}})
+	s.Add(jobs.Job{Name: "by-hand", Run: func(context.Context) error {
+		manual.Add(1)
+		return errors.New("boom")
+	}})
+	if err := s.RunNow("missing"); !errors.Is(err, jobs.ErrUnknownJob) {
+		t.Errorf("RunNow(missing) = %v", err)
+	}
+	s.Start(ctx)
+	if err := s.RunNow("by-hand"); err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+
+	// The new interval job runs once after the startup delay, then waits an hour
+	waitFor(t, func() bool {
+		runs, _ := queries.ListJobRuns(ctx)
+		return len(runs) == 2
+	})
+	if scheduled.Load() != 1 || manual.Load() != 1 {
+		t.Errorf("runs = %d scheduled, %d manual", scheduled.Load(), manual.Load())
+	}
+	for _, st := range s.Jobs() {
+		switch st.Name {
+		case "tick":
+			if st.LastTrigger != jobs.TriggerSchedule || st.LastError != "" || st.NextRun.Before(time.Now().Add(50*time.Minute)) {
+				t.Errorf("tick status = %+v", st)
+			}
+		case "by-hand":
+			if st.LastTrigger != jobs.TriggerManual || st.LastError != "boom" || st.Failures != 1 || !st.NextRun.IsZero() {
+				t.Errorf("by-hand status = %+v", st)
+			}
+		}
+	}
+
+	// After a restart the recorded run is kept and the interval continues
+	cancel()
+	restarted := jobs.New(queries, discard, jobs.Config{StartupDelay: 10 * time.Millisecond})
+	restarted.Add(jobs.Job{Name: "tick", Schedule: jobs.Every(time.Hour), Run: func(context.Context) error {
+		scheduled.Add(1)
+		return nil
+	}})
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	restarted.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+	st := restarted.Jobs()[0]
+	if scheduled.Load() != 1 || st.Runs != 1 || st.NextRun.Before(time.Now().Add(50*time.Minute)) {
+		t.Errorf("after restart: %d runs, status %+v", scheduled.Load(), st)
+	}
+}
+
+func TestScheduler_RecoversPanicsAndRejectsOverlap(t *testing.T) {
+	release := make(chan struct{})
+	s := jobs.New(nil, discard, jobs.Config{})
+	s.Add(jobs.Job{Name: "slow", Run: func(context.Context) error {
+		<-release
+		panic("broken")
+	}})
+	s.Start(context.Background())
+
+	if err := s.RunNow("slow"); err != nil {
+		t.Fatalf("RunNow: %v", err)
+	}
+	if err := s.RunNow("slow"); !errors.Is(err, jobs.ErrJobRunning) {
+		t.Errorf("second RunNow = %v, want ErrJobRunning", err)
+	}
+	close(release)
+	waitFor(t, func() bool { return !s.Jobs()[0].Running })
+	if st := s.Jobs()[0]; st.LastError != "panic: broken" || st.Failures != 1 {
+		t.Errorf("status = %+v", st)
+	}
+}
+
+// waitFor polls cond for up to five seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatal("condition not met in time")
+}
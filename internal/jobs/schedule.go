@@ -0,0 +1,214 @@
+package jobs
+
+import (
+	"fmt"     // Parse errors and schedule descriptions
+	"strconv" // Parsing cron field numbers
+	"strings" // Splitting cron expressions
+	"time"    // Schedule arithmetic
+)
+
+// Schedule decides when a job runs next.
+type Schedule interface {
+	// Next returns the first run time after t, or the zero time if the
+	// schedule never fires again.
+	Next(t time.Time) time.Time
+	// String describes the schedule on the jobs page, e.g. "every 5 minutes".
+	String() string
+}
+
+// every is a fixed interval between the end of one run and the next.
+type every time.Duration
+
+// Every returns a schedule that runs a job d after its previous run
+// finished. d is rounded up to at least a second.
+func Every(d time.Duration) Schedule {
+	return every(max(d, time.Second))
+}
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+func (e every) String() string {
+	d := time.Duration(e)
+	switch {
+	case d == time.Hour:
+		return "every hour"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("every %d hours", d/time.Hour)
+	case d == time.Minute:
+		return "every minute"
+	case d%time.Minute == 0:
+		return fmt.Sprintf("every %d minutes", d/time.Minute)
+	}
+	return "every " + d.String()
+}
+
+// cron is a parsed five-field cron expression.
+type cron struct {
+	expr                     string
+	minute, hour, dom, month uint64 // Bit i set when value i matches
+	dow                      uint64 // Day of week, 0 = Sunday
+	domStar, dowStar         bool   // Field was "*", for the day-matching rule
+}
+
+// cronFields are the bounds of the five fields, in order.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronShortcuts are the @-descriptors accepted by Cron.
+var cronShortcuts = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+}
+
+// Cron parses a standard five-field cron expression ("minute hour
+// day-of-month month day-of-week"), evaluated in the time zone of the times
+// passed to Next (the server's local zone for the scheduler). Fields accept
+// "*", numbers, ranges ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10");
+// day of week is 0-7 with 0 and 7 both Sunday. As in cron, when both day
+// fields are restricted a day matching either one fires. The shortcuts
+// @hourly, @daily (@midnight), @weekly, @monthly and @yearly are accepted.
+//
+// Parameters:
+//   - expr: Cron expression, e.g. "30 2 * * *" for 02:30 every day
+//
+// Returns:
+//   - Schedule: The parsed schedule
+//   - error: Which field is invalid
+func Cron(expr string) (Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if s, ok := cronShortcuts[spec]; ok {
+		spec = s
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("jobs: cron expression %q: want 5 fields, got %d", expr, len(fields))
+	}
+	c := &cron{expr: strings.TrimSpace(expr)}
+	for i, f := range fields {
+		bits, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("jobs: cron expression %q: %s: %w", expr, cronFields[i].name, err)
+		}
+		switch i {
+		case 0:
+			c.minute = bits
+		case 1:
+			c.hour = bits
+		case 2:
+			c.dom, c.domStar = bits, f == "*"
+		case 3:
+			c.month = bits
+		case 4:
+			if bits&(1<<7) != 0 {
+				bits |= 1 // 7 is Sunday as well
+			}
+			c.dow, c.dowStar = bits, f == "*"
+		}
+	}
+	return c, nil
+}
+
+// MustCron is Cron for expressions known to be valid; it panics on errors.
+func MustCron(expr string) Schedule {
+	s, err := Cron(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// parseCronField returns the values matched by one field as a bit set.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(a)
+			hi, err2 = strconv.Atoi(b)
+			if err1 != nil || err2 != nil || lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = max // "5/15" means 5, 20, 35, 50
+			}
+		}
+		if lo < min || hi > max {
+			return 0, fmt.Errorf("%q is outside %d-%d", rangePart, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cron) String() string {
+	return c.expr
+}
+
+// Next returns the first matching minute after t. It searches up to five
+// years ahead, so an expression that can never match (e.g. February 30)
+// returns the zero time.
+func (c *cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: with one day field restricted only it
+// counts; with both restricted either may match.
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
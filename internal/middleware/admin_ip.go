@@ -0,0 +1,222 @@
+package middleware
+
+import (
+	// crypto/sha256 and crypto/subtle check the bypass token and its cookie
+	// without storing the token itself in the browser.
+	"crypto/sha256"
+	"crypto/subtle"
+
+	// encoding/hex formats the bypass cookie value.
+	"encoding/hex"
+
+	// fmt reports the rule that failed to parse.
+	"fmt"
+
+	// log/slog records every use of the bypass token.
+	"log/slog"
+
+	// net converts trusted proxy ranges for Echo's X-Forwarded-For extractor.
+	"net"
+
+	// net/http provides cookies, redirects and status codes.
+	"net/http"
+
+	// net/netip parses the client address and the CIDR rules.
+	"net/netip"
+
+	// strings splits the rule text into entries.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// adminBypassParam is the query parameter the emergency bypass token is
+	// given in, e.g. /admin/login?admin_bypass=<token>.
+	adminBypassParam = "admin_bypass"
+
+	// adminBypassCookie remembers a used bypass token for adminBypassMaxAge,
+	// so the rest of the session does not need the token in every URL.
+	adminBypassCookie = "admin_ip_bypass"
+	adminBypassMaxAge = 12 * 60 * 60
+
+	// adminBypassKey is the context key set when a request was let through by
+	// the bypass token rather than the network rules (see IPBypassed).
+	adminBypassKey = "admin_ip_bypassed"
+)
+
+// ParseIPRules parses admin network rules: IP addresses or CIDR ranges
+// (192.0.2.0/24, 2001:db8::/32), one per line or separated by commas. Blank
+// entries are skipped; a single address becomes a /32 (or /128) range. The
+// first entry that is neither is reported.
+//
+// Example:
+//
+//	allow, err := middleware.ParseIPRules("10.8.0.0/16\n203.0.113.7")
+func ParseIPRules(text string) ([]netip.Prefix, error) {
+	var rules []netip.Prefix
+	for _, entry := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' || r == ',' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an IP address or CIDR range", entry)
+			}
+			rules = append(rules, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil || addr.Zone() != "" {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR range", entry)
+		}
+		addr = addr.Unmap()
+		rules = append(rules, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return rules, nil
+}
+
+// ClientIPExtractor returns the echo.IPExtractor for c.RealIP(), which the
+// admin IP rules, rate limits and logs rely on. Without trusted proxies the
+// address of the connection is used and X-Forwarded-For is ignored, since any
+// client can send it. With them, X-Forwarded-For is read from the right and
+// the first address that is not a trusted proxy is the client; loopback and
+// private networks are only trusted when listed.
+//
+// Example usage:
+//
+//	proxies, _ := middleware.ParseIPRules("127.0.0.1")
+//	e.IPExtractor = middleware.ClientIPExtractor(proxies)
+func ClientIPExtractor(trusted []netip.Prefix) echo.IPExtractor {
+	if len(trusted) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, p := range trusted {
+		options = append(options, echo.TrustIPRange(&net.IPNet{
+			IP:   net.IP(p.Addr().AsSlice()),
+			Mask: net.CIDRMask(p.Bits(), p.Addr().BitLen()),
+		}))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// IPAllowed reports whether the client address ip may reach the admin panel
+// under the allow and deny rules: it must not match any deny rule, and must
+// match an allow rule when there are any. Addresses that cannot be parsed
+// are only allowed when there are no rules at all.
+func IPAllowed(ip string, allow, deny []netip.Prefix) bool {
+	if len(allow) == 0 && len(deny) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.WithZone("").Unmap()
+	for _, p := range deny {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	if len(allow) == 0 {
+		return true
+	}
+	for _, p := range allow {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPBypassed reports whether the request reached the admin panel with the
+// emergency bypass token rather than through the network rules.
+func IPBypassed(c echo.Context) bool {
+	bypassed, _ := c.Get(adminBypassKey).(bool)
+	return bypassed
+}
+
+// AdminIPFilter returns an Echo middleware for the /admin route groups (the
+// login page included) that refuses requests from addresses outside the
+// admin network rules of the settings (admin_ip_allow, admin_ip_deny) with
+// 403 Forbidden. With no rules saved every address is let through. The
+// client address is c.RealIP(), so the server's IPExtractor must come from
+// ClientIPExtractor: behind a reverse proxy, X-Forwarded-For is only
+// believed when the proxy is listed in TRUSTED_PROXIES.
+//
+// bypassToken, when not empty, lets an administrator in from anywhere after
+// a rule change locked them out: opening any /admin URL with
+// ?admin_bypass=<token> sets a cookie that admits that browser for 12 hours
+// and redirects to the URL without the token. Each use is logged.
+//
+// The rules are read from site, which the admin panel invalidates after
+// every save, so a change applies to the next request. When the settings
+// cannot be loaded the panel is refused with 503 rather than left open.
+//
+// Example usage:
+//
+//	adminIP := middleware.AdminIPFilter(siteData, cfg.AdminIPBypassToken)
+//	adminGroup := e.Group("/admin", adminIP, middleware.Authenticate(...))
+func AdminIPFilter(site SiteDataSource, bypassToken string) echo.MiddlewareFunc {
+	var cookieValue string
+	if bypassToken != "" {
+		sum := sha256.Sum256([]byte("admin-ip-bypass:" + bypassToken))
+		cookieValue = hex.EncodeToString(sum[:])
+	}
+	matches := func(got, want string) bool {
+		return want != "" && subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if token := c.QueryParam(adminBypassParam); token != "" && matches(token, bypassToken) {
+				slog.WarnContext(req.Context(), "admin IP rules bypassed with the emergency token", "ip", c.RealIP(), "path", req.URL.Path)
+				c.SetCookie(&http.Cookie{
+					Name:     adminBypassCookie,
+					Value:    cookieValue,
+					Path:     "/admin",
+					MaxAge:   adminBypassMaxAge,
+					HttpOnly: true,
+					Secure:   c.IsTLS(),
+					SameSite: http.SameSiteLaxMode,
+				})
+				query := req.URL.Query()
+				query.Del(adminBypassParam)
+				target := req.URL.Path
+				if len(query) > 0 {
+					target += "?" + query.Encode()
+				}
+				return c.Redirect(http.StatusSeeOther, target)
+			}
+			if cookie, err := c.Cookie(adminBypassCookie); err == nil && matches(cookie.Value, cookieValue) {
+				c.Set(adminBypassKey, true)
+				return next(c)
+			}
+
+			g, err := site.Get(req.Context())
+			if g.Settings == nil {
+				slog.ErrorContext(req.Context(), "admin IP filter: settings unavailable", "error", err)
+				return echo.NewHTTPError(http.StatusServiceUnavailable)
+			}
+			// Rules are validated when saved; entries that fail to parse
+			// here (edited in the database by hand) refuse everyone rather
+			// than silently dropping a deny rule
+			allow, errAllow := ParseIPRules(g.Settings.AdminIpAllow)
+			deny, errDeny := ParseIPRules(g.Settings.AdminIpDeny)
+			if errAllow != nil || errDeny != nil {
+				slog.ErrorContext(req.Context(), "admin IP filter: invalid rules", "allow_error", errAllow, "deny_error", errDeny)
+				return echo.NewHTTPError(http.StatusForbidden, "The admin panel is not available from this network")
+			}
+			if !IPAllowed(c.RealIP(), allow, deny) {
+				slog.WarnContext(req.Context(), "admin request refused by IP rules", "ip", c.RealIP(), "path", req.URL.Path)
+				return echo.NewHTTPError(http.StatusForbidden, "The admin panel is not available from this network")
+			}
+			return next(c)
+		}
+	}
+}
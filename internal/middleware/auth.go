@@ -37,26 +37,11 @@ import (
 //   - Relies on SessionMiddleware being executed first in the middleware chain
 //   - Uses 303 See Other redirect to ensure POST requests are converted to GET
 //   - Does not perform role-based authorization (see RequireRole for that)
+//   - Equivalent to Authenticate(SessionAuthenticator{LoginURL: "/admin/login"})
 func RequireAuth() echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			// Retrieve the session from the Echo context. The session is populated
-			// by SessionMiddleware earlier in the middleware chain.
-			sess, ok := c.Get("session").(*Session)
-
-			// Check if session retrieval was successful and if the user is authenticated.
-			// A UserID of 0 indicates an unauthenticated session (no user logged in).
-			if !ok || sess.UserID == 0 {
-				// Redirect unauthenticated users to the login page using HTTP 303 See Other.
-				// This status code ensures that the redirect is always performed with GET,
-				// even if the original request was POST, preventing form resubmission issues.
-				return c.Redirect(http.StatusSeeOther, "/admin/login")
-			}
-
-			// User is authenticated, proceed to the next handler in the chain
-			return next(c)
-		}
-	}
+	// Session-cookie authentication for the admin panel. Other route groups
+	// (API, previews, partner portal) pick their own Authenticator.
+	return Authenticate(SessionAuthenticator{LoginURL: "/admin/login"})
 }
 
 // RequireRole returns an Echo middleware that enforces role-based access control (RBAC)
@@ -87,24 +72,29 @@ func RequireAuth() echo.MiddlewareFunc {
 //   - Performs authentication check first (redirects to login if not authenticated)
 //   - Role comparison is case-sensitive
 //   - Returns 403 Forbidden (not 401 Unauthorized) for authenticated but unauthorized users
-//   - Relies on SessionMiddleware to populate the session with accurate role information
+//   - Reads the role from the request's Principal (see Authenticate), or from the
+//     session populated by SessionMiddleware when the route has no Authenticate
 func RequireRole(roles ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Retrieve the session from the Echo context
-			sess, ok := c.Get("session").(*Session)
+			// Use the principal resolved by Authenticate for the route group,
+			// falling back to the session for routes mounted without it.
+			p := PrincipalFrom(c)
+			if p == nil {
+				p, _ = SessionAuthenticator{}.Authenticate(c)
+			}
 
 			// First check if the user is authenticated at all. If not, redirect to login.
 			// This ensures that unauthenticated users don't receive a "Forbidden" error,
 			// which would be confusing (they should see the login page instead).
-			if !ok || sess.UserID == 0 {
+			if p == nil {
 				return c.Redirect(http.StatusSeeOther, "/admin/login")
 			}
 
 			// Check if the user's role matches any of the allowed roles.
 			// This is a whitelist approach: if any match is found, access is granted.
 			for _, role := range roles {
-				if sess.Role == role {
+				if p.Role == role {
 					// User has an authorized role, proceed to the next handler
 					return next(c)
 				}
@@ -0,0 +1,340 @@
+package middleware
+
+import (
+	// context is passed to token verifiers so lookups honour request cancellation.
+	"context"
+
+	// errors provides the ErrNoCredentials sentinel and error inspection.
+	"errors"
+
+	// net/http provides status codes for redirects and 401 challenges.
+	"net/http"
+
+	// strings is used to parse the Authorization header.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// Principal identifies the authenticated caller of a request, independent of
+// how it authenticated. Handlers read it with PrincipalFrom.
+type Principal struct {
+	UserID      int64    // admin_users.id for people; 0 for machine clients
+	Email       string   // User email address (empty for machine clients)
+	DisplayName string   // Name shown in the UI and audit log
+	Role        string   // Role for RequireRole (admin, editor, etc.)
+	Method      string   // Authenticator that accepted the request: "session", "token", "oidc", "preview", "partner_link"
+	Subject     string   // Stable identifier from the credential (token ID, OIDC sub)
+	Scopes      []string // Permissions granted to the credential (empty = unrestricted)
+	Resource    string   // Item a link credential is limited to, e.g. "blog_post" for a preview link
+	ResourceID  int64    // ID of that item
+}
+
+// HasScope reports whether the principal was granted scope. Principals without
+// any scopes (interactive sessions) are unrestricted.
+func (p *Principal) HasScope(scope string) bool {
+	if len(p.Scopes) == 0 {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// principalKey is the Echo context key holding the request's *Principal.
+const principalKey = "principal"
+
+// ErrNoCredentials is returned by an Authenticator when the request carries
+// none of the credentials it understands, so another authenticator may try.
+// Any other error means credentials were present but invalid.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator resolves the caller of a request. Each route group picks the
+// authenticator(s) it accepts with Authenticate, so the admin panel, API, and
+// preview links share the same plumbing while using different credentials.
+type Authenticator interface {
+	// Authenticate returns the caller, ErrNoCredentials when the request has no
+	// credentials of this kind, or another error when they are invalid.
+	Authenticate(c echo.Context) (*Principal, error)
+
+	// Challenge answers a request that failed authentication, e.g. by
+	// redirecting to a login page or returning 401 with WWW-Authenticate.
+	Challenge(c echo.Context, err error) error
+}
+
+// Authenticate returns an Echo middleware that admits requests accepted by a
+// and stores the resulting Principal in the context. Rejected requests are
+// answered with a.Challenge. Requests admitted by Optional without
+// credentials carry no principal.
+//
+// Parameters:
+//   - a: Authenticator for the route group (combine several with FirstOf)
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for a route group or individual route
+//
+// Example usage:
+//
+//	api := e.Group("/api", middleware.Authenticate(middleware.FirstOf(
+//		middleware.TokenAuthenticator{Verifier: apiKeys},
+//		middleware.SessionAuthenticator{LoginURL: "/admin/login"},
+//	)))
+func Authenticate(a Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p, err := a.Authenticate(c)
+			if err != nil {
+				return a.Challenge(c, err)
+			}
+			if p != nil {
+				c.Set(principalKey, p)
+			}
+			return next(c)
+		}
+	}
+}
+
+// PrincipalFrom returns the caller stored by Authenticate, or nil when the
+// route is not authenticated.
+func PrincipalFrom(c echo.Context) *Principal {
+	p, _ := c.Get(principalKey).(*Principal)
+	return p
+}
+
+// firstOf tries authenticators in order; see FirstOf.
+type firstOf []Authenticator
+
+// FirstOf combines authenticators: the first one that finds its credentials
+// decides the request. Invalid credentials are challenged by the authenticator
+// that rejected them; requests without any credentials are challenged by the
+// first authenticator.
+func FirstOf(auths ...Authenticator) Authenticator {
+	return firstOf(auths)
+}
+
+// Authenticate implements Authenticator.
+func (f firstOf) Authenticate(c echo.Context) (*Principal, error) {
+	for _, a := range f {
+		p, err := a.Authenticate(c)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return nil, &rejection{by: a, err: err}
+		}
+		return p, nil
+	}
+	return nil, ErrNoCredentials
+}
+
+// Challenge implements Authenticator.
+func (f firstOf) Challenge(c echo.Context, err error) error {
+	var r *rejection
+	if errors.As(err, &r) {
+		return r.by.Challenge(c, r.err)
+	}
+	if len(f) == 0 {
+		return echo.NewHTTPError(http.StatusUnauthorized)
+	}
+	return f[0].Challenge(c, err)
+}
+
+// optional admits anonymous requests; see Optional.
+type optional struct {
+	Authenticator
+}
+
+// Optional lets requests without any credentials of a through anonymously,
+// without a principal, while invalid credentials are still challenged by a.
+// Public pages use it for links that unlock more than the anonymous view,
+// such as preview links.
+func Optional(a Authenticator) Authenticator {
+	return optional{a}
+}
+
+// Authenticate implements Authenticator.
+func (o optional) Authenticate(c echo.Context) (*Principal, error) {
+	p, err := o.Authenticator.Authenticate(c)
+	if errors.Is(err, ErrNoCredentials) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// rejection remembers which authenticator refused the credentials.
+type rejection struct {
+	by  Authenticator
+	err error
+}
+
+func (r *rejection) Error() string { return r.err.Error() }
+func (r *rejection) Unwrap() error { return r.err }
+
+// SessionAuthenticator accepts the admin login session cookie. It relies on
+// SessionMiddleware running earlier in the chain.
+type SessionAuthenticator struct {
+	LoginURL string // Where unauthenticated browsers are redirected; defaults to /admin/login
+}
+
+// Authenticate implements Authenticator.
+func (s SessionAuthenticator) Authenticate(c echo.Context) (*Principal, error) {
+	sess, ok := c.Get("session").(*Session)
+	if !ok || sess.UserID == 0 {
+		return nil, ErrNoCredentials
+	}
+	return &Principal{
+		UserID:      sess.UserID,
+		Email:       sess.Email,
+		DisplayName: sess.DisplayName,
+		Role:        sess.Role,
+		Method:      "session",
+	}, nil
+}
+
+// Challenge implements Authenticator. It redirects with 303 See Other so
+// POST requests are converted to GET. When SessionTracker has just ended the
+// session for a timeout, the login page is told why. HTMX requests get an
+// HX-Redirect header instead, so the login page replaces the whole window
+// rather than being swapped into a fragment.
+func (s SessionAuthenticator) Challenge(c echo.Context, err error) error {
+	url := s.LoginURL
+	if url == "" {
+		url = "/admin/login"
+	}
+	if expired, _ := c.Get(sessionExpiredKey).(bool); expired {
+		url += "?error=session_expired"
+	}
+	if c.Request().Header.Get("HX-Request") == "true" {
+		c.Response().Header().Set("HX-Redirect", url)
+		return c.NoContent(http.StatusUnauthorized)
+	}
+	return c.Redirect(http.StatusSeeOther, url)
+}
+
+// TokenVerifier resolves an opaque credential (API key, signed preview token)
+// to its principal. It returns an error for unknown, expired, or revoked tokens.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*Principal, error)
+}
+
+// TokenVerifierFunc adapts a function to the TokenVerifier interface.
+type TokenVerifierFunc func(ctx context.Context, token string) (*Principal, error)
+
+// VerifyToken implements TokenVerifier.
+func (f TokenVerifierFunc) VerifyToken(ctx context.Context, token string) (*Principal, error) {
+	return f(ctx, token)
+}
+
+// bearerToken returns the token from an "Authorization: Bearer" header.
+func bearerToken(c echo.Context) string {
+	h := c.Request().Header.Get(echo.HeaderAuthorization)
+	if len(h) > 7 && strings.EqualFold(h[:7], "bearer ") {
+		return strings.TrimSpace(h[7:])
+	}
+	return ""
+}
+
+// TokenAuthenticator accepts machine credentials sent as "Authorization:
+// Bearer <token>", in Header, in the QueryParam query parameter or in the
+// Param route parameter (for shareable links such as previews). Rejected
+// requests receive 401 JSON unless Deny answers them.
+type TokenAuthenticator struct {
+	Verifier   TokenVerifier // Resolves tokens to principals
+	Header     string        // Optional extra header, e.g. "X-API-Key"
+	QueryParam string        // Optional query parameter, e.g. "token"
+	Param      string        // Optional route parameter, e.g. "token" for /partner-portal/:token
+	LinkOnly   bool          // Ignore the Authorization header: the token is part of a link on a public page
+	Realm      string        // WWW-Authenticate realm; defaults to "bluejay"
+
+	// Deny optionally answers rejected requests instead of the 401 JSON,
+	// e.g. with a page explaining that a link has expired.
+	Deny func(c echo.Context, err error) error
+}
+
+// Authenticate implements Authenticator.
+func (t TokenAuthenticator) Authenticate(c echo.Context) (*Principal, error) {
+	token := ""
+	if !t.LinkOnly {
+		token = bearerToken(c)
+	}
+	if token == "" && t.Header != "" {
+		token = c.Request().Header.Get(t.Header)
+	}
+	if token == "" && t.QueryParam != "" {
+		token = c.QueryParam(t.QueryParam)
+	}
+	if token == "" && t.Param != "" {
+		token = c.Param(t.Param)
+	}
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	p, err := t.Verifier.VerifyToken(c.Request().Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	if p.Method == "" {
+		p.Method = "token"
+	}
+	return p, nil
+}
+
+// Challenge implements Authenticator.
+func (t TokenAuthenticator) Challenge(c echo.Context, err error) error {
+	if t.Deny != nil {
+		return t.Deny(c, err)
+	}
+	realm := t.Realm
+	if realm == "" {
+		realm = "bluejay"
+	}
+	value := `Bearer realm="` + realm + `"`
+	if !errors.Is(err, ErrNoCredentials) {
+		value += `, error="invalid_token"`
+	}
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, value)
+	return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+}
+
+// OIDCAuthenticator accepts OpenID Connect ID tokens, sent as a bearer token
+// or in Cookie. Token signature, issuer, audience, and expiry checks are
+// delegated to Verifier (for example a go-oidc IDTokenVerifier adapter).
+// Browsers without a valid token are redirected to LoginURL to start the
+// provider's login flow; other clients receive 401.
+type OIDCAuthenticator struct {
+	Verifier TokenVerifier // Verifies ID tokens and maps claims to a principal
+	Cookie   string        // Cookie holding the ID token after login (optional)
+	LoginURL string        // Route that starts the OIDC authorization flow
+}
+
+// Authenticate implements Authenticator.
+func (o OIDCAuthenticator) Authenticate(c echo.Context) (*Principal, error) {
+	token := bearerToken(c)
+	if token == "" && o.Cookie != "" {
+		if ck, err := c.Cookie(o.Cookie); err == nil {
+			token = ck.Value
+		}
+	}
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	p, err := o.Verifier.VerifyToken(c.Request().Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	p.Method = "oidc"
+	return p, nil
+}
+
+// Challenge implements Authenticator.
+func (o OIDCAuthenticator) Challenge(c echo.Context, err error) error {
+	if o.LoginURL != "" && strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMETextHTML) {
+		return c.Redirect(http.StatusSeeOther, o.LoginURL)
+	}
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="oidc"`)
+	return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+}
@@ -0,0 +1,260 @@
+package middleware
+
+import (
+	// bufio and net are needed to pass Hijack through the wrapped writer.
+	"bufio"
+	"net"
+
+	// bytes buffers the start of a response until it is long enough to compress.
+	"bytes"
+
+	// compress/gzip encodes responses for clients without Brotli support.
+	"compress/gzip"
+
+	// io is used for the encoder interface shared by gzip and Brotli.
+	"io"
+
+	// mime parses Content-Type to decide whether a response compresses well.
+	"mime"
+
+	// net/http provides the ResponseWriter being wrapped and status codes.
+	"net/http"
+
+	// strconv parses q-values in Accept-Encoding.
+	"strconv"
+
+	// strings is used to parse Accept-Encoding and match content types.
+	"strings"
+
+	// sync pools encoders, which allocate large windows.
+	"sync"
+
+	// github.com/andybalholm/brotli encodes responses for clients sending br.
+	"github.com/andybalholm/brotli"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// compressMinLength is the smallest body worth compressing. Shorter responses
+// (redirects, HTMX toasts, empty JSON) are sent as they are, since the
+// encoding overhead would make them larger.
+const compressMinLength = 1024
+
+// compressibleTypes are the media types Compress encodes. Images other than
+// SVG, fonts, PDFs and archives are already compressed and are passed through.
+var compressibleTypes = map[string]bool{
+	"application/javascript":    true,
+	"application/json":          true,
+	"application/ld+json":       true,
+	"application/manifest+json": true,
+	"application/problem+json":  true,
+	"application/rss+xml":       true,
+	"application/atom+xml":      true,
+	"application/xml":           true,
+	"image/svg+xml":             true,
+}
+
+var (
+	gzipPool   = sync.Pool{New: func() any { w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression); return w }}
+	brotliPool = sync.Pool{New: func() any { return brotli.NewWriterLevel(io.Discard, 5) }}
+)
+
+// encoder is the part of gzip.Writer and brotli.Writer that Compress uses.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Compress returns middleware that encodes text responses (HTML, CSS,
+// JavaScript, JSON, XML, SVG) with Brotli or gzip, whichever the client
+// prefers in Accept-Encoding (Brotli when both are equally acceptable).
+// Responses shorter than compressMinLength, responses of other types,
+// partial content and responses a handler already encoded are sent as they
+// are. Vary: Accept-Encoding is set on every response it could have encoded,
+// so caches keep the encodings apart.
+//
+// It replaces Echo's Gzip middleware, which also encoded images and fonts.
+//
+// Example:
+//
+//	e.Use(middleware.Compress())
+func Compress() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Method == http.MethodHead {
+				return next(c)
+			}
+			res := c.Response()
+			res.Header().Add("Vary", "Accept-Encoding")
+			encoding := negotiateEncoding(c.Request().Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				return next(c)
+			}
+
+			w := &compressWriter{ResponseWriter: res.Writer, encoding: encoding}
+			res.Writer = w
+			defer func() {
+				w.finish()
+				res.Writer = w.ResponseWriter
+			}()
+			return next(c)
+		}
+	}
+}
+
+// negotiateEncoding picks "br" or "gzip" from an Accept-Encoding header, or
+// "" when the client accepts neither. A bare "*" counts as both.
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		switch name {
+		case "br", "*":
+			if q > bestQ || q == bestQ && best != "br" {
+				best, bestQ = "br", q
+			}
+		case "gzip", "x-gzip":
+			if q > bestQ {
+				best, bestQ = "gzip", q
+			}
+		}
+	}
+	return best
+}
+
+// compressWriter holds back the status and the first compressMinLength bytes
+// of a response, then either encodes the rest or passes it through.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int          // Status passed to WriteHeader, sent when decided
+	buf     bytes.Buffer // Body written before the decision
+	decided bool         // Whether the headers have been sent
+	enc     encoder      // Encoder when compressing, nil when passing through
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if !w.decided && w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+	w.buf.Write(b)
+	if w.buf.Len() >= compressMinLength {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends what has been written so far, so streamed exports reach the
+// client as they are produced.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(w.buf.Len() > 0)
+	}
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide sends the headers, encoding the body when long enough and of a
+// compressible type, and writes the buffered start of the body.
+func (w *compressWriter) decide(long bool) error {
+	w.decided = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	h := w.Header()
+	if long && w.shouldCompress(h) {
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		h.Del("Accept-Ranges")
+		if w.encoding == "br" {
+			w.enc = brotliPool.Get().(*brotli.Writer)
+		} else {
+			w.enc = gzipPool.Get().(*gzip.Writer)
+		}
+		w.enc.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// shouldCompress reports whether a response with headers h is encoded.
+func (w *compressWriter) shouldCompress(h http.Header) bool {
+	if w.status != http.StatusOK && w.status < http.StatusBadRequest || h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || compressibleTypes[mediaType]
+}
+
+// finish sends a response that never reached compressMinLength and returns
+// the encoder to its pool.
+func (w *compressWriter) finish() {
+	if !w.decided {
+		if w.status == 0 && w.buf.Len() == 0 {
+			// Nothing was written; Echo's error handler writes through the
+			// restored writer.
+			return
+		}
+		w.decide(false)
+	}
+	if w.enc == nil {
+		return
+	}
+	w.enc.Close()
+	w.enc.Reset(io.Discard)
+	if w.encoding == "br" {
+		brotliPool.Put(w.enc)
+	} else {
+		gzipPool.Put(w.enc)
+	}
+	w.enc = nil
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// github.com/narendhupati/bluejay-cms/internal/models provides the blocks
+	// rendered by the public layout.
+	"github.com/narendhupati/bluejay-cms/internal/models"
+)
+
+// contentBlocksKey is the context key holding the page's blocks.
+const contentBlocksKey = "content_blocks"
+
+// BlockSource provides the content blocks attached to a page path. It is
+// satisfied by services.ContentBlocks.
+type BlockSource interface {
+	ForPath(path string) []models.ContentBlock
+}
+
+// ContentBlocks returns an Echo middleware for the public route group that
+// makes the blocks attached to the requested path under Admin > Content
+// Blocks available to the renderer, which passes them to every public page
+// as .Blocks (read with BlocksFrom). The public layout renders them below
+// the page's own content.
+//
+// Parameters:
+//   - blocks: Block lookup, typically *services.ContentBlocks
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets c.Get("content_blocks")
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.ContentBlocks(blockSvc))
+func ContentBlocks(blocks BlockSource) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(contentBlocksKey, blocks.ForPath(c.Request().URL.Path))
+			return next(c)
+		}
+	}
+}
+
+// BlocksFrom returns the blocks stored by ContentBlocks, or nil outside it
+// (admin pages) and for pages without blocks.
+func BlocksFrom(c echo.Context) []models.ContentBlock {
+	if c == nil {
+		return nil
+	}
+	blocks, _ := c.Get(contentBlocksKey).([]models.ContentBlock)
+	return blocks
+}
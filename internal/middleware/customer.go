@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	// context carries the request context to the resolver.
+	"context"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// sqlc provides the customer account type.
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// CustomerCookie is the cookie holding a customer's session token. It is
+// separate from the admin session cookie, so signing in to the public site
+// never grants admin access.
+const CustomerCookie = "bluejay_account"
+
+// customerKey is the Echo context key holding the signed-in CustomerAccount.
+const customerKey = "customer"
+
+// CustomerResolver looks up the account of a session token. It is
+// implemented by services.CustomerAccounts.
+type CustomerResolver interface {
+	CustomerForSession(ctx context.Context, token string) (sqlc.CustomerAccount, error)
+}
+
+// Customer returns an Echo middleware for the public route group that
+// recognises signed-in customers. Requests with a bluejay_account cookie
+// naming a live session get the account stored in the context (read with
+// CustomerFrom); other requests, including those with ended or expired
+// sessions, pass through as anonymous visitors.
+//
+// Pages that cache their HTML must not vary it by customer; customer-specific
+// content is loaded with HTMX from uncached routes.
+//
+// Parameters:
+//   - r: Session resolver (services.CustomerAccounts)
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets c.Get("customer")
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.Customer(customerAccounts))
+func Customer(r CustomerResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			cookie, err := c.Cookie(CustomerCookie)
+			if err != nil || cookie.Value == "" {
+				return next(c)
+			}
+			if account, err := r.CustomerForSession(c.Request().Context(), cookie.Value); err == nil {
+				c.Set(customerKey, account)
+			}
+			return next(c)
+		}
+	}
+}
+
+// CustomerFrom returns the account stored by Customer, and false when the
+// visitor is not signed in.
+func CustomerFrom(c echo.Context) (sqlc.CustomerAccount, bool) {
+	a, ok := c.Get(customerKey).(sqlc.CustomerAccount)
+	return a, ok
+}
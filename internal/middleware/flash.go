@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	// encoding/gob registers Flash so it can be stored in the session cookie.
+	"encoding/gob"
+
+	// encoding/json builds the HX-Trigger header for HTMX toasts.
+	"encoding/json"
+
+	// github.com/labstack/echo/v4 provides the context types.
+	"github.com/labstack/echo/v4"
+)
+
+// Flash levels. The admin layout styles each one differently; errors and
+// warnings stay on screen until dismissed.
+const (
+	FlashSuccess = "success"
+	FlashError   = "error"
+	FlashWarning = "warning"
+)
+
+// Flash is a one-time notification for the admin panel, such as "Settings
+// saved." after a form is submitted.
+type Flash struct {
+	Level   string // FlashSuccess, FlashError or FlashWarning
+	Message string // Plain text shown to the user
+}
+
+// Context keys for flashes: flashesKey holds the flashes FlashesFrom took out
+// of the session, toastsKey the toasts queued on an HTMX response.
+const (
+	flashesKey = "flashes"
+	toastsKey  = "flash_toasts"
+)
+
+// ToastEvent is the HTMX event an HX-Trigger header raises to show toasts.
+// Its detail is {"messages": [{"level": ..., "message": ...}]}.
+const ToastEvent = "showToast"
+
+func init() {
+	gob.Register(Flash{})
+}
+
+// AddFlash queues a notification for the current user. After a normal form
+// post it is kept in the session and shown by the admin layout on the next
+// page, usually the one the handler redirects to. On an HTMX request there is
+// no next page, so it is sent back as a toast in the HX-Trigger header.
+//
+// Parameters:
+//   - c: Echo context of a request that went through SessionMiddleware
+//   - level: FlashSuccess, FlashError or FlashWarning
+//   - message: Plain text to show
+//
+// Example usage:
+//
+//	middleware.AddFlash(c, middleware.FlashSuccess, "Settings saved.")
+//	return c.Redirect(http.StatusSeeOther, "/admin/settings")
+func AddFlash(c echo.Context, level, message string) {
+	flash := Flash{Level: level, Message: message}
+	if IsHTMX(c) {
+		toasts, _ := c.Get(toastsKey).([]Flash)
+		toasts = append(toasts, flash)
+		c.Set(toastsKey, toasts)
+		c.Response().Header().Set("HX-Trigger", toastTrigger(toasts))
+		return
+	}
+	sess, ok := c.Get("session").(*Session)
+	if !ok {
+		return
+	}
+	sess.AddFlash(flash)
+	sess.Save(c.Request(), c.Response())
+}
+
+// toastTrigger encodes toasts as an HX-Trigger header value.
+func toastTrigger(toasts []Flash) string {
+	type toast struct {
+		Level   string `json:"level"`
+		Message string `json:"message"`
+	}
+	messages := make([]toast, len(toasts))
+	for i, t := range toasts {
+		messages[i] = toast{t.Level, t.Message}
+	}
+	b, _ := json.Marshal(map[string]interface{}{ToastEvent: map[string]interface{}{"messages": messages}})
+	return string(b)
+}
+
+// FlashesFrom takes the queued flashes out of the session, so each is shown
+// once, and returns them. The renderer calls it for pages with the admin
+// layout; later calls in the same request return the same flashes.
+func FlashesFrom(c echo.Context) []Flash {
+	if c == nil {
+		return nil
+	}
+	if flashes, ok := c.Get(flashesKey).([]Flash); ok {
+		return flashes
+	}
+	sess, ok := c.Get("session").(*Session)
+	if !ok {
+		return nil
+	}
+	var flashes []Flash
+	if values := sess.Flashes(); len(values) > 0 {
+		for _, v := range values {
+			if f, ok := v.(Flash); ok {
+				flashes = append(flashes, f)
+			}
+		}
+		sess.Save(c.Request(), c.Response())
+	}
+	c.Set(flashesKey, flashes)
+	return flashes
+}
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	// crypto/rand and encoding/hex generate the tokens rendered into forms.
+	"crypto/rand"
+	"encoding/hex"
+
+	// net/http provides the status codes for duplicate submissions.
+	"net/http"
+
+	// sync guards the map of used tokens.
+	"sync"
+
+	// time expires used tokens.
+	"time"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// FormTokenField is the hidden form field carrying a one-time form token.
+// Every POST form in the admin renders it with {{formToken}}, so forms carry
+// a token without JavaScript; /public/js/form-tokens.js replaces it after an
+// HTMX form is submitted successfully or a page is restored by the back
+// button, and adds it to forms that lack one.
+const FormTokenField = "form_token"
+
+// NewFormToken returns a new random form token. Templates call it as
+// {{formToken}} for the value of the FormTokenField input.
+func NewFormToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// maxFormTokenLength bounds the tokens kept in memory.
+const maxFormTokenLength = 128
+
+// formTokenWait is how long a repeat waits for the first submission to finish.
+const formTokenWait = 10 * time.Second
+
+// formTokenUse records a submitted token.
+type formTokenUse struct {
+	at       time.Time     // When the first submission arrived
+	done     chan struct{} // Closed when the first submission has finished
+	location string        // Where the first submission redirected, if it did; set before done is closed
+}
+
+// FormTokens rejects repeated submissions of the same form: a double-click,
+// a refresh that re-posts, or the back button followed by "Resubmit". Each
+// form carries a token, and a token is accepted once.
+//
+// This is protection against accidental duplicates, not a security control:
+// tokens are not checked against the ones rendered, and requests without a
+// token (API clients, scripts) are let through.
+//
+// A repeat of a submission that redirected (Post/Redirect/Get) is sent to the
+// same place, waiting for the first one to finish if needed, so the editor
+// lands where the first submission went instead of on an error. HTMX repeats
+// get 204 No Content at once, which leaves the page as the first request
+// updates it. Other repeats get 409 Conflict. A submission that fails
+// (4xx/5xx) releases its token so the form can be sent again.
+type FormTokens struct {
+	mu        sync.Mutex
+	used      map[string]*formTokenUse
+	ttl       time.Duration // How long a used token is remembered
+	lastPrune time.Time
+}
+
+// NewFormTokens creates a FormTokens that remembers used tokens for ttl.
+//
+// Parameters:
+//   - ttl: How long a token stays used; repeats after that are accepted again
+//
+// Returns:
+//   - *FormTokens: Token store; apply Middleware to the admin routes
+func NewFormTokens(ttl time.Duration) *FormTokens {
+	return &FormTokens{used: make(map[string]*formTokenUse), ttl: ttl}
+}
+
+// Middleware returns the Echo middleware that enforces one use per token on
+// POST requests.
+//
+// Example usage:
+//
+//	adminGroup.Use(middleware.NewFormTokens(time.Hour).Middleware())
+func (f *FormTokens) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Method != http.MethodPost {
+				return next(c)
+			}
+			token := c.FormValue(FormTokenField)
+			if token == "" || len(token) > maxFormTokenLength {
+				return next(c)
+			}
+
+			use, first := f.claim(token)
+			if !first {
+				if IsHTMX(c) {
+					return c.NoContent(http.StatusNoContent)
+				}
+				select {
+				case <-use.done:
+				case <-time.After(formTokenWait):
+				case <-c.Request().Context().Done():
+				}
+				if location := f.location(token); location != "" {
+					return c.Redirect(http.StatusSeeOther, location)
+				}
+				return echo.NewHTTPError(http.StatusConflict, "This form was already submitted.")
+			}
+
+			// Deferred so that a panic in next, recovered further up, still
+			// releases the token and the repeats waiting on it
+			status, location := http.StatusInternalServerError, ""
+			defer func() { f.finish(token, use, status, location) }()
+
+			err := next(c)
+			status = c.Response().Status
+			if he, ok := err.(*echo.HTTPError); ok {
+				status = he.Code
+			} else if err != nil {
+				status = http.StatusInternalServerError
+			}
+			location = c.Response().Header().Get(echo.HeaderLocation)
+			return err
+		}
+	}
+}
+
+// claim marks token as used and returns its record, with true for the first use.
+func (f *FormTokens) claim(token string) (*formTokenUse, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(f.lastPrune) > f.ttl {
+		for t, use := range f.used {
+			if now.Sub(use.at) > f.ttl {
+				delete(f.used, t)
+			}
+		}
+		f.lastPrune = now
+	}
+
+	if use, ok := f.used[token]; ok && now.Sub(use.at) <= f.ttl {
+		return use, false
+	}
+	use := &formTokenUse{at: now, done: make(chan struct{})}
+	f.used[token] = use
+	return use, true
+}
+
+// location returns where the first submission of token redirected, if it did.
+func (f *FormTokens) location(token string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if use, ok := f.used[token]; ok {
+		return use.location
+	}
+	return ""
+}
+
+// finish records how the first submission of token ended. Failed submissions
+// release the token.
+func (f *FormTokens) finish(token string, use *formTokenUse, status int, location string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if status >= http.StatusBadRequest {
+		if f.used[token] == use {
+			delete(f.used, token)
+		}
+	} else if status >= 300 && status < 400 {
+		use.location = location
+	}
+	close(use.done)
+}
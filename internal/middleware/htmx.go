@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	// net/http provides the method names accepted as overrides.
+	"net/http"
+
+	// strings restricts the override to admin paths.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// MethodOverrideField is the form field that lets a plain HTML form send a
+// DELETE or PUT. HTMX sends those methods itself; the field is what makes the
+// same admin endpoints usable with JavaScript disabled or blocked.
+const MethodOverrideField = "_method"
+
+// IsHTMX reports whether the request was made by HTMX (HX-Request: true).
+// Handlers and middleware use it to tell swaps from full-page navigation.
+func IsHTMX(c echo.Context) bool {
+	return c.Request().Header.Get("HX-Request") == "true"
+}
+
+// MethodOverride returns a pre-routing middleware that turns an admin POST
+// carrying _method=DELETE or _method=PUT into that method, so a no-script
+// form reaches the same route as the hx-delete or hx-put button beside it.
+// Other paths and methods pass through untouched, and public form bodies are
+// never parsed here.
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for e.Pre (it must run before routing)
+//
+// Example usage:
+//
+//	e.Pre(middleware.MethodOverride())
+func MethodOverride() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodPost || !strings.HasPrefix(req.URL.Path, "/admin/") {
+				return next(c)
+			}
+			switch m := strings.ToUpper(c.FormValue(MethodOverrideField)); m {
+			case http.MethodDelete, http.MethodPut:
+				req.Method = m
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	// net/http provides the cookie type used to remember a visitor's language choice.
+	"net/http"
+
+	// strings splits the locale prefix off the request path.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// localeCookieName is the cookie that remembers a visitor's chosen locale.
+const localeCookieName = "lang"
+
+// localePrefixKey is the context key under which LocalePrefix stores the
+// locale named by the URL prefix.
+const localePrefixKey = "locale_prefix"
+
+// LocaleSet is the set of locales visitors may choose from. It is satisfied
+// by services.TranslationService, whose locales can change at runtime.
+type LocaleSet interface {
+	DefaultLocale() string     // Locale used when the visitor has not chosen one
+	IsLocale(code string) bool // Whether visitors may select code
+}
+
+// LocalePrefix returns an Echo middleware that serves locale-prefixed URLs
+// such as /de/products/x. When the first path segment names a locale, it is
+// stripped from the request path before routing and the locale is recorded
+// for the Locale middleware, so every public route is reachable under every
+// locale prefix without being registered twice. Register it with e.Pre.
+//
+// Parameters:
+//   - locales: Locales that may appear as a prefix
+//
+// Returns:
+//   - echo.MiddlewareFunc: Pre-routing middleware that rewrites the path
+//
+// Example usage:
+//
+//	e.Pre(middleware.LocalePrefix(translationSvc))
+func LocalePrefix(locales LocaleSet) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			code, rest, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+			if code != "" && locales.IsLocale(code) {
+				req.URL.Path = "/" + rest
+				if req.URL.RawPath != "" {
+					req.URL.RawPath = "/" + strings.TrimPrefix(strings.TrimPrefix(req.URL.RawPath, "/"+code), "/")
+				}
+				c.Set(localePrefixKey, code)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Locale returns an Echo middleware that determines the locale for each public
+// request and stores it in the context under "locale". Resolution order:
+//  1. the URL prefix stripped by LocalePrefix (e.g., /de/products)
+//  2. ?lang= query parameter, if it names a supported locale
+//  3. the "lang" cookie, if it names a supported locale
+//  4. the default locale
+//
+// A locale chosen by prefix or ?lang= is also remembered in the cookie, so
+// unprefixed links keep the visitor's language.
+//
+// Parameters:
+//   - locales: Default and selectable locales
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets c.Get("locale")
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.Locale(translationSvc))
+func Locale(locales LocaleSet) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := locales.DefaultLocale()
+			chosen, _ := c.Get(localePrefixKey).(string)
+			if chosen == "" {
+				chosen = c.QueryParam("lang")
+			}
+			if chosen != "" && locales.IsLocale(chosen) {
+				locale = chosen
+				c.SetCookie(&http.Cookie{
+					Name:     localeCookieName,
+					Value:    chosen,
+					Path:     "/",
+					MaxAge:   365 * 24 * 60 * 60,
+					HttpOnly: true,
+					SameSite: http.SameSiteLaxMode,
+				})
+			} else if cookie, err := c.Cookie(localeCookieName); err == nil && locales.IsLocale(cookie.Value) {
+				locale = cookie.Value
+			}
+			c.Set("locale", locale)
+			return next(c)
+		}
+	}
+}
+
+// GetLocale returns the locale stored by the Locale middleware, or "" when the
+// middleware did not run for this request.
+func GetLocale(c echo.Context) string {
+	if l, ok := c.Get("locale").(string); ok {
+		return l
+	}
+	return ""
+}
+
+// LocalePrefixFrom returns the locale prefix LocalePrefix stripped from the
+// request path (e.g., "de" for /de/products), or "" when there was none.
+func LocalePrefixFrom(c echo.Context) string {
+	code, _ := c.Get(localePrefixKey).(string)
+	return code
+}
@@ -1,11 +1,17 @@
 package middleware
 
 import (
+	// errors unwraps the handler error to find its HTTP status.
+	"errors"
+
 	// log/slog is Go's structured logging package (introduced in Go 1.21). It provides
 	// high-performance structured logging with support for key-value pairs, log levels,
 	// and multiple output formats (JSON, text). Used here to log HTTP request metadata.
 	"log/slog"
 
+	// net/http provides status codes.
+	"net/http"
+
 	// time provides time and duration measurement functionality. Used to calculate
 	// the duration of each HTTP request by capturing timestamps before and after
 	// handler execution.
@@ -16,19 +22,27 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// Logging returns an Echo middleware that logs structured information about each HTTP request.
-// This middleware captures key request metadata including HTTP method, path, response status,
-// request duration, and client IP address. The logging occurs after the request is processed,
+// Logging returns an Echo middleware that writes a structured access log entry for
+// each HTTP request. This middleware captures key request metadata including HTTP
+// method, path, matched route, response status, request duration, client IP address
+// and the signed-in user. The logging occurs after the request is processed,
 // allowing the middleware to record the actual response status and timing information.
 //
 // The middleware uses structured logging (slog) which outputs logs in a consistent, parseable
-// format suitable for log aggregation systems like ELK, Splunk, or CloudWatch. Each log entry
-// includes the following fields:
+// format suitable for log aggregation systems like ELK, Splunk, Loki or CloudWatch. Each log
+// entry includes the following fields:
 //   - method: HTTP method (GET, POST, PUT, DELETE, etc.)
 //   - path: Request URL path (without query parameters)
-//   - status: HTTP response status code (200, 404, 500, etc.)
+//   - route: Matched route pattern (e.g., /products/:category/:slug), for grouping
+//   - status: HTTP response status code (200, 404, 500, etc.), including statuses
+//     written by the error handler after the chain returns
 //   - duration_ms: Request processing time in milliseconds
+//   - bytes: Response body size
 //   - ip: Client IP address (extracted from X-Real-IP, X-Forwarded-For, or remote addr)
+//   - user_agent: Client User-Agent header
+//   - user_id, user: Authenticated caller (admin user ID and email, or the API
+//     token subject), only on authenticated routes
+//   - request_id: Added by ContextLogHandler when RequestID runs before this middleware
 //
 // Parameters:
 //   - logger: A configured slog.Logger instance that will receive the log entries.
@@ -40,7 +54,8 @@ import (
 //
 // Example usage:
 //
-//	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+//	logger := slog.New(middleware.ContextLogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//	e.Use(middleware.RequestID())
 //	e.Use(middleware.Logging(logger))
 //
 // Performance considerations:
@@ -56,48 +71,60 @@ import (
 //	  "level": "INFO",
 //	  "msg": "request",
 //	  "method": "GET",
-//	  "path": "/admin/products",
+//	  "path": "/admin/products/12",
+//	  "route": "/admin/products/:id",
 //	  "status": 200,
 //	  "duration_ms": 45,
-//	  "ip": "192.168.1.100"
+//	  "bytes": 18342,
+//	  "ip": "192.168.1.100",
+//	  "user_agent": "Mozilla/5.0 ...",
+//	  "user_id": 1,
+//	  "user": "admin@example.com",
+//	  "request_id": "6f1c0e8b2a4d4f7e9a1b3c5d7e9f0a12"
 //	}
 func Logging(logger *slog.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Capture the request start time before executing the handler.
-			// This allows us to calculate the total request processing duration,
-			// including all subsequent middleware and the final handler execution.
 			start := time.Now()
 
-			// Execute the next handler in the middleware chain (or the final route handler).
-			// We capture the error to return it after logging, ensuring that error responses
-			// are still properly propagated even though we log first.
 			err := next(c)
 
-			// Log structured request information using slog's key-value format.
-			// Info level is appropriate for normal request logging (not warnings or errors).
-			//
-			// Fields logged:
-			// - method: HTTP verb (GET, POST, etc.) from the request
-			// - path: URL path component (excludes query string and fragment)
-			// - status: HTTP status code set by the handler (e.g., 200, 404, 500)
-			// - duration_ms: Time elapsed from request start to completion, in milliseconds.
-			//   This includes all middleware execution time and handler processing time.
-			// - ip: Client's IP address. Echo's RealIP() intelligently extracts the IP from
-			//   X-Real-IP, X-Forwarded-For headers (for proxied requests), or falls back
-			//   to the direct connection's remote address.
-			logger.Info("request",
-				"method", c.Request().Method,
-				"path", c.Request().URL.Path,
-				"status", c.Response().Status,
-				"duration_ms", time.Since(start).Milliseconds(),
-				"ip", c.RealIP(),
-			)
+			req := c.Request()
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.String("route", c.Path()),
+				slog.Int("status", responseStatus(c, err)),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+				slog.Int64("bytes", c.Response().Size),
+				slog.String("ip", c.RealIP()),
+				slog.String("user_agent", req.UserAgent()),
+			}
+			if p := PrincipalFrom(c); p != nil {
+				user := p.Email
+				if user == "" {
+					user = p.Subject
+				}
+				attrs = append(attrs, slog.Int64("user_id", p.UserID), slog.String("user", user))
+			}
+			logger.LogAttrs(req.Context(), slog.LevelInfo, "request", attrs...)
 
-			// Return the error from the handler execution. This ensures that if the handler
-			// or any subsequent middleware returned an error, it's properly propagated to
-			// Echo's error handling mechanism, even though we've logged the request.
 			return err
 		}
 	}
 }
+
+// responseStatus returns the status of the response to c. A handler error
+// is written by the error handler only after the middleware chain returns,
+// so its status comes from the error itself (500 unless it is an
+// *echo.HTTPError).
+func responseStatus(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	// net/http provides the 503 status and request methods.
+	"net/http"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// github.com/narendhupati/bluejay-cms/db/sqlc provides the settings row
+	// loaded by SettingsLoader, which holds the maintenance switch.
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// maintenanceRetryAfter is the Retry-After value (seconds) sent with
+// maintenance responses, telling crawlers to come back in ten minutes.
+const maintenanceRetryAfter = "600"
+
+// Maintenance returns an Echo middleware for the public route group that
+// closes the site while maintenance mode is on in Global Settings. Visitors
+// get a 503 Service Unavailable with Retry-After: page renders the branded
+// maintenance page for GET requests, and other requests (form posts, HTMX
+// swaps) get a 503 error for the central error handler. Requests from a
+// signed-in admin session pass through, so editors can check the site
+// before reopening it, and /health keeps reporting the server's state.
+//
+// It reads the settings stored by SettingsLoader, so it must be registered
+// after it; when the settings could not be loaded the site stays open.
+//
+// Parameters:
+//   - page: Renders the maintenance page, such as publicHandlers.MaintenancePage
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for the public route group
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.SettingsLoader(queries))
+//	publicGroup.Use(middleware.Maintenance(publicHandlers.MaintenancePage))
+func Maintenance(page echo.HandlerFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			settings, ok := c.Get("settings").(sqlc.Setting)
+			if !ok || !settings.MaintenanceMode || c.Request().URL.Path == "/health" {
+				return next(c)
+			}
+			if sess, ok := c.Get("session").(*Session); ok && sess.UserID != 0 {
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set("Retry-After", maintenanceRetryAfter)
+			h.Set("Cache-Control", "no-store")
+			switch m := c.Request().Method; {
+			case m == http.MethodHead:
+				return c.NoContent(http.StatusServiceUnavailable)
+			case m != http.MethodGet || IsHTMX(c):
+				return echo.NewHTTPError(http.StatusServiceUnavailable)
+			}
+			return page(c)
+		}
+	}
+}
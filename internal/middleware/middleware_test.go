@@ -1,12 +1,27 @@
 package middleware_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/andybalholm/brotli"
 	"github.com/labstack/echo/v4"
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
 	"github.com/narendhupati/bluejay-cms/internal/middleware"
+	"github.com/narendhupati/bluejay-cms/internal/models"
 )
 
 func init() {
@@ -160,3 +175,1252 @@ func TestRequireAuth_EmptySession(t *testing.T) {
 		t.Errorf("expected 303, got %d", rec.Code)
 	}
 }
+
+// fakeHealth is a DBHealthChecker whose state is set by the test; Verify
+// reports the database as broken when failVerify is set.
+type fakeHealth struct {
+	degraded   bool
+	failVerify bool
+}
+
+func (f *fakeHealth) Degraded() bool { return f.degraded }
+func (f *fakeHealth) Verify(ctx context.Context) bool {
+	if f.failVerify {
+		f.degraded = true
+		return false
+	}
+	return true
+}
+
+// mapStore is an in-memory PageSnapshotStore.
+type mapStore map[string]interface{}
+
+func (m mapStore) Get(key string) (interface{}, bool)   { v, ok := m[key]; return v, ok }
+func (m mapStore) Set(key string, v interface{}, _ int) { m[key] = v }
+
+func TestReadOnlyFallback_ServesSnapshotWithBanner(t *testing.T) {
+	health := &fakeHealth{}
+	store := mapStore{}
+	dbUp := true
+
+	e := echo.New()
+	g := e.Group("", middleware.ReadOnlyFallback(health, store))
+	g.GET("/page", func(c echo.Context) error {
+		if !dbUp {
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		return c.HTML(http.StatusOK, `<html><body class="x"><h1>Live page</h1></body></html>`)
+	})
+	g.GET("/other", func(c echo.Context) error { return echo.NewHTTPError(http.StatusInternalServerError) })
+	g.POST("/contact", func(c echo.Context) error { return c.String(http.StatusOK, "sent") })
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	if rec := get(http.MethodGet, "/page"); rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "read-only") {
+		t.Fatalf("expected live page while healthy, got %d", rec.Code)
+	}
+
+	// A 500 confirmed by the probe is replaced by the snapshot
+	dbUp = false
+	health.failVerify = true
+	rec := get(http.MethodGet, "/page")
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Read-Only-Mode") != "1" {
+		t.Fatalf("expected snapshot with read-only header, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `<body class="x"><div role="status"`) || !strings.Contains(body, "Live page") {
+		t.Errorf("expected banner injected after <body>, got %s", body)
+	}
+
+	if rec := get(http.MethodGet, "/other"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 for page without snapshot, got %d", rec.Code)
+	}
+	if rec := get(http.MethodPost, "/contact"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected writes to be refused in read-only mode, got %d", rec.Code)
+	}
+
+	// Recovery: handlers run again
+	health.degraded, health.failVerify, dbUp = false, false, true
+	if rec := get(http.MethodGet, "/page"); rec.Header().Get("X-Read-Only-Mode") != "" {
+		t.Error("expected normal handling after recovery")
+	}
+}
+
+func TestReadOnlyFallback_SkipsPrivatePages(t *testing.T) {
+	store := mapStore{}
+	e := echo.New()
+	g := e.Group("", middleware.ReadOnlyFallback(&fakeHealth{}, store))
+	g.GET("/account", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+		return c.HTML(http.StatusOK, `<html><body>Jane Doe</body></html>`)
+	})
+	g.GET("/page", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, `<html><body>Signed in as Jane</body></html>`)
+	})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/account", nil))
+	req := httptest.NewRequest(http.MethodGet, "/page", nil)
+	req.AddCookie(&http.Cookie{Name: middleware.CustomerCookie, Value: "session"})
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	if len(store) != 0 {
+		t.Errorf("expected no snapshots of private pages, got %v", store)
+	}
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/page", nil))
+	if len(store) != 1 {
+		t.Errorf("expected the anonymous page to be snapshotted, got %v", store)
+	}
+}
+
+func TestReadOnlyGuard_RefusesWritesWhenDegraded(t *testing.T) {
+	health := &fakeHealth{degraded: true}
+	e := echo.New()
+	g := e.Group("/admin", middleware.ReadOnlyGuard(health))
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	g.GET("/posts", ok)
+	g.POST("/posts", ok)
+
+	for method, want := range map[string]int{http.MethodGet: http.StatusOK, http.MethodPost: http.StatusServiceUnavailable} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, "/admin/posts", nil))
+		if rec.Code != want {
+			t.Errorf("%s: expected %d, got %d", method, want, rec.Code)
+		}
+	}
+}
+
+// staticTokens is a TokenVerifier backed by a map of valid tokens.
+func staticTokens(tokens map[string]*middleware.Principal) middleware.TokenVerifier {
+	return middleware.TokenVerifierFunc(func(ctx context.Context, token string) (*middleware.Principal, error) {
+		if p, ok := tokens[token]; ok {
+			cp := *p
+			return &cp, nil
+		}
+		return nil, errors.New("unknown token")
+	})
+}
+
+func TestAuthenticate_SessionSetsPrincipal(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil), rec)
+	c.Set("session", &middleware.Session{UserID: 7, Email: "ed@test.com", Role: "editor"})
+
+	var got *middleware.Principal
+	handler := middleware.Authenticate(middleware.SessionAuthenticator{})(func(c echo.Context) error {
+		got = middleware.PrincipalFrom(c)
+		return nil
+	})
+	if err := handler(c); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if got == nil || got.UserID != 7 || got.Role != "editor" || got.Method != "session" {
+		t.Errorf("unexpected principal %+v", got)
+	}
+}
+
+func TestAuthenticate_Token(t *testing.T) {
+	e := echo.New()
+	auth := middleware.TokenAuthenticator{
+		Verifier: staticTokens(map[string]*middleware.Principal{
+			"good": {Subject: "key-1", Role: "api", Scopes: []string{"products:read"}},
+		}),
+		Header:     "X-API-Key",
+		QueryParam: "token",
+	}
+	e.GET("/api/products", func(c echo.Context) error {
+		p := middleware.PrincipalFrom(c)
+		if !p.HasScope("products:read") || p.HasScope("products:write") {
+			t.Errorf("unexpected scopes %v", p.Scopes)
+		}
+		return c.String(http.StatusOK, p.Method+":"+p.Subject)
+	}, middleware.Authenticate(auth))
+
+	tests := []struct {
+		name   string
+		target string
+		header [2]string
+		code   int
+		body   string
+		wwwErr bool
+	}{
+		{"bearer", "/api/products", [2]string{"Authorization", "Bearer good"}, http.StatusOK, "token:key-1", false},
+		{"header", "/api/products", [2]string{"X-API-Key", "good"}, http.StatusOK, "token:key-1", false},
+		{"query", "/api/products?token=good", [2]string{}, http.StatusOK, "token:key-1", false},
+		{"missing", "/api/products", [2]string{}, http.StatusUnauthorized, "", false},
+		{"invalid", "/api/products", [2]string{"Authorization", "Bearer bad"}, http.StatusUnauthorized, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header[0] != "" {
+				req.Header.Set(tt.header[0], tt.header[1])
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d", tt.code, rec.Code)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("expected body %q, got %q", tt.body, rec.Body.String())
+			}
+			www := rec.Header().Get("WWW-Authenticate")
+			if tt.code == http.StatusUnauthorized && !strings.HasPrefix(www, "Bearer") {
+				t.Errorf("expected Bearer challenge, got %q", www)
+			}
+			if tt.wwwErr != strings.Contains(www, "invalid_token") {
+				t.Errorf("unexpected challenge %q", www)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_FirstOf(t *testing.T) {
+	e := echo.New()
+	auth := middleware.FirstOf(
+		middleware.TokenAuthenticator{Verifier: staticTokens(map[string]*middleware.Principal{"good": {Subject: "key-1"}})},
+		middleware.SessionAuthenticator{LoginURL: "/admin/login"},
+	)
+	handler := middleware.Authenticate(auth)(func(c echo.Context) error {
+		return c.String(http.StatusOK, middleware.PrincipalFrom(c).Method)
+	})
+
+	run := func(bearer string, sess *middleware.Session) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if sess != nil {
+			c.Set("session", sess)
+		}
+		if err := handler(c); err != nil {
+			t.Fatalf("handler error: %v", err)
+		}
+		return rec
+	}
+
+	if rec := run("good", nil); rec.Body.String() != "token" {
+		t.Errorf("expected token auth, got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := run("", &middleware.Session{UserID: 1, Role: "admin"}); rec.Body.String() != "session" {
+		t.Errorf("expected session fallback, got %d %q", rec.Code, rec.Body.String())
+	}
+	// Invalid credentials are challenged by the authenticator that rejected them
+	if rec := run("bad", &middleware.Session{UserID: 1}); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad token, got %d", rec.Code)
+	}
+	// No credentials at all: the first authenticator challenges
+	if rec := run("", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", rec.Code)
+	}
+}
+
+func TestAuthenticate_OptionalLink(t *testing.T) {
+	e := echo.New()
+	auth := middleware.Optional(middleware.TokenAuthenticator{
+		Verifier: staticTokens(map[string]*middleware.Principal{"good": {Subject: "link-1", Resource: "partner_invite", ResourceID: 3}}),
+		Param:    "token",
+		LinkOnly: true,
+		Deny: func(c echo.Context, err error) error {
+			return c.String(http.StatusOK, "invalid link")
+		},
+	})
+	e.GET("/portal/:token", func(c echo.Context) error {
+		p := middleware.PrincipalFrom(c)
+		if p == nil {
+			return c.String(http.StatusOK, "anonymous")
+		}
+		return c.String(http.StatusOK, fmt.Sprintf("%s %s:%d", p.Method, p.Resource, p.ResourceID))
+	}, middleware.Authenticate(auth))
+
+	for target, want := range map[string]string{
+		"/portal/good": "token partner_invite:3",
+		"/portal/bad":  "invalid link",
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer bad") // Ignored for links
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Body.String() != want {
+			t.Errorf("%s: expected %q, got %q", target, want, rec.Body.String())
+		}
+	}
+}
+
+// previewStub is a PreviewVerifier accepting the token "draft-7" for blog post 7.
+type previewStub struct{}
+
+func (previewStub) VerifyPreview(token string) (string, int64, error) {
+	if token == "draft-7" {
+		return "blog_post", 7, nil
+	}
+	return "", 0, errors.New("invalid")
+}
+
+func TestPreview(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.Preview(previewStub{}))
+	e.GET("/blog/post", func(c echo.Context) error {
+		if g, ok := middleware.PreviewFrom(c); ok {
+			return c.String(http.StatusOK, fmt.Sprintf("%s:%d", g.Type, g.ID))
+		}
+		return c.String(http.StatusOK, "published")
+	})
+
+	tests := []struct {
+		target  string
+		code    int
+		body    string
+		private bool
+	}{
+		{"/blog/post", http.StatusOK, "published", false},
+		{"/blog/post?preview_token=draft-7", http.StatusOK, "blog_post:7", true},
+		{"/blog/post?preview_token=stale", http.StatusForbidden, "", false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Code != tt.code || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s: got %d %q", tt.target, rec.Code, rec.Body.String())
+		}
+		if private := rec.Header().Get("Cache-Control") == "private, no-store"; private != tt.private {
+			t.Errorf("%s: Cache-Control = %q", tt.target, rec.Header().Get("Cache-Control"))
+		}
+	}
+}
+
+func TestAuthenticate_OIDC(t *testing.T) {
+	e := echo.New()
+	auth := middleware.OIDCAuthenticator{
+		Verifier: staticTokens(map[string]*middleware.Principal{"idtoken": {Subject: "sub-42", Email: "p@partner.com", Role: "partner"}}),
+		Cookie:   "id_token",
+		LoginURL: "/partners/login",
+	}
+	handler := middleware.Authenticate(auth)(middleware.RequireRole("partner")(func(c echo.Context) error {
+		return c.String(http.StatusOK, middleware.PrincipalFrom(c).Method)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/partners", nil)
+	req.AddCookie(&http.Cookie{Name: "id_token", Value: "idtoken"})
+	rec := httptest.NewRecorder()
+	if err := handler(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if rec.Body.String() != "oidc" {
+		t.Errorf("expected oidc principal with partner role, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/partners", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	rec = httptest.NewRecorder()
+	if err := handler(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/partners/login" {
+		t.Errorf("expected browser redirect to login, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+}
+
+func TestTemplateDebug_AdminOnly(t *testing.T) {
+	for _, tc := range []struct {
+		role    string
+		overlay bool
+	}{
+		{role: "admin", overlay: true},
+		{role: "editor", overlay: false},
+	} {
+		t.Run(tc.role, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/?debug=templates", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("session", &middleware.Session{UserID: 1, Role: tc.role})
+
+			handler := middleware.TemplateDebug()(func(c echo.Context) error {
+				info := middleware.TemplateDebugFrom(c)
+				if (info != nil) != tc.overlay {
+					t.Fatalf("TemplateDebugFrom() = %v, want collector: %v", info, tc.overlay)
+				}
+				// Recording on a nil collector must be a no-op
+				info.RecordRender("public/pages/home.html", map[string]interface{}{"Title": "Home", "Posts": []string{}})
+				info.RecordCache("page:home", 300)
+				return c.HTML(http.StatusOK, "<html><body><p>page</p></body></html>")
+			})
+			if err := handler(c); err != nil {
+				t.Fatalf("handler error: %v", err)
+			}
+
+			body := rec.Body.String()
+			if got := strings.Contains(body, `id="template-debug"`); got != tc.overlay {
+				t.Fatalf("overlay present = %v, want %v; body: %s", got, tc.overlay, body)
+			}
+			if tc.overlay {
+				for _, want := range []string{"public/pages/home.html", "Posts ([]string)", "Title (string)", "page:home (TTL 300s)"} {
+					if !strings.Contains(body, want) {
+						t.Errorf("expected overlay to contain %q", want)
+					}
+				}
+				if !strings.HasSuffix(body, "</body></html>") {
+					t.Error("expected overlay to be injected before </body>")
+				}
+			}
+		})
+	}
+}
+
+func TestSessionTracker_MissingTokenSignsOut(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Signed-in cookie from before session tracking: no token
+	c.Set("session", &middleware.Session{UserID: 1, Email: "admin@test.com", Role: "admin"})
+
+	handler := middleware.SessionTracker(nil, middleware.SessionTimeouts{})(middleware.RequireAuth()(func(c echo.Context) error {
+		t.Fatal("should not reach handler")
+		return nil
+	}))
+
+	if err := handler(c); err != nil {
+		t.Fatalf("handler error: %v", err)
+	}
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("expected redirect 303, got %d", rec.Code)
+	}
+}
+
+func TestSessionAuthenticator_HTMXChallenge(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/products/1/specs", nil)
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := (middleware.SessionAuthenticator{}).Challenge(c, middleware.ErrNoCredentials); err != nil {
+		t.Fatalf("challenge error: %v", err)
+	}
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for HTMX, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("HX-Redirect"); got != "/admin/login" {
+		t.Errorf("HX-Redirect = %q, want /admin/login", got)
+	}
+}
+
+func TestFormTokens(t *testing.T) {
+	e := echo.New()
+	created := 0
+	e.Use(middleware.NewFormTokens(time.Hour).Middleware())
+	e.POST("/admin/heroes", func(c echo.Context) error {
+		if c.FormValue("title") == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "title required")
+		}
+		created++
+		return c.Redirect(http.StatusSeeOther, "/admin/heroes?created=1")
+	})
+	e.POST("/admin/heroes/render", func(c echo.Context) error {
+		created++
+		return c.String(http.StatusOK, "saved")
+	})
+
+	post := func(path, token, title string, htmx bool) *httptest.ResponseRecorder {
+		form := url.Values{"title": {title}}
+		if token != "" {
+			form.Set(middleware.FormTokenField, token)
+		}
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A re-posted form follows the first submission's redirect without creating again
+	for i := 0; i < 2; i++ {
+		rec := post("/admin/heroes", "tok-1", "Hero", false)
+		if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/heroes?created=1" {
+			t.Errorf("submission %d: got %d %q", i+1, rec.Code, rec.Header().Get("Location"))
+		}
+	}
+	if created != 1 {
+		t.Fatalf("expected 1 record, got %d", created)
+	}
+
+	// HTMX repeats are ignored; other repeats without a redirect conflict
+	if rec := post("/admin/heroes", "tok-1", "Hero", true); rec.Code != http.StatusNoContent {
+		t.Errorf("HTMX repeat: expected 204, got %d", rec.Code)
+	}
+	post("/admin/heroes/render", "tok-2", "Hero", false)
+	if rec := post("/admin/heroes/render", "tok-2", "Hero", false); rec.Code != http.StatusConflict {
+		t.Errorf("repeat without redirect: expected 409, got %d", rec.Code)
+	}
+
+	// A failed submission releases its token for the corrected form
+	if rec := post("/admin/heroes", "tok-3", "", false); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid form: expected 400, got %d", rec.Code)
+	}
+	if rec := post("/admin/heroes", "tok-3", "Hero", false); rec.Code != http.StatusSeeOther {
+		t.Errorf("corrected form: expected 303, got %d", rec.Code)
+	}
+
+	// Forms without a token are not restricted
+	post("/admin/heroes", "", "Hero", false)
+	post("/admin/heroes", "", "Hero", false)
+	if created != 5 {
+		t.Errorf("expected 5 records, got %d", created)
+	}
+}
+
+func TestFormTokens_Panic(t *testing.T) {
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = echo.NewHTTPError(http.StatusInternalServerError)
+				}
+			}()
+			return next(c)
+		}
+	})
+	e.Use(middleware.NewFormTokens(time.Hour).Middleware())
+	panics := true
+	e.POST("/admin/heroes", func(c echo.Context) error {
+		if panics {
+			panics = false
+			panic("boom")
+		}
+		return c.Redirect(http.StatusSeeOther, "/admin/heroes")
+	})
+
+	post := func() *httptest.ResponseRecorder {
+		form := url.Values{middleware.FormTokenField: {"tok-1"}}
+		req := httptest.NewRequest(http.MethodPost, "/admin/heroes", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := post(); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("panicking submission: got %d", rec.Code)
+	}
+	// The token was released, so the retry is a first submission and does
+	// not wait for the one that panicked
+	start := time.Now()
+	if rec := post(); rec.Code != http.StatusSeeOther {
+		t.Errorf("retry after a panic: got %d", rec.Code)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("retry waited %v", waited)
+	}
+}
+
+func TestMethodOverride_AdminOnly(t *testing.T) {
+	e := echo.New()
+	e.Pre(middleware.MethodOverride())
+	method := func(c echo.Context) error { return c.String(http.StatusOK, c.Request().Method) }
+	e.Any("/admin/specs", method)
+	e.Any("/contact", method)
+
+	cases := []struct {
+		path, override, want string
+	}{
+		{"/admin/specs", "delete", http.MethodDelete},
+		{"/admin/specs", "PUT", http.MethodPut},
+		{"/admin/specs", "PATCH", http.MethodPost},
+		{"/admin/specs", "", http.MethodPost},
+		{"/contact", "DELETE", http.MethodPost},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(url.Values{middleware.MethodOverrideField: {tc.override}}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if got := rec.Body.String(); got != tc.want {
+			t.Errorf("%s with _method=%q: got %s, want %s", tc.path, tc.override, got, tc.want)
+		}
+	}
+}
+
+func TestVisitorTimezone(t *testing.T) {
+	e := echo.New()
+	guess := func(acceptLanguage string) string {
+		if acceptLanguage == "ja-JP" {
+			return "Asia/Tokyo"
+		}
+		return ""
+	}
+	for header, want := range map[string]string{"ja-JP": "Asia/Tokyo", "xx": ""} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", header)
+		c := e.NewContext(req, httptest.NewRecorder())
+		var got string
+		handler := middleware.VisitorTimezone(guess)(func(c echo.Context) error {
+			got = middleware.VisitorTimezoneFrom(c)
+			return nil
+		})
+		if err := handler(c); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%q: visitor timezone = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	e := echo.New()
+	page := func(c echo.Context) error { return c.String(http.StatusServiceUnavailable, "maintenance page") }
+	handler := middleware.Maintenance(page)(func(c echo.Context) error { return c.String(http.StatusOK, "site") })
+
+	tests := []struct {
+		name, method, path string
+		on, htmx           bool
+		userID             int64
+		wantCode           int
+		wantBody           string
+	}{
+		{"off", http.MethodGet, "/", false, false, 0, http.StatusOK, "site"},
+		{"visitor", http.MethodGet, "/products", true, false, 0, http.StatusServiceUnavailable, "maintenance page"},
+		{"signed-in admin", http.MethodGet, "/products", true, false, 7, http.StatusOK, "site"},
+		{"health", http.MethodGet, "/health", true, false, 0, http.StatusOK, "site"},
+		{"form post", http.MethodPost, "/contact/submit", true, false, 0, http.StatusServiceUnavailable, ""},
+		{"htmx", http.MethodGet, "/partials/blog", true, true, 0, http.StatusServiceUnavailable, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("settings", sqlc.Setting{MaintenanceMode: tt.on})
+		c.Set("session", &middleware.Session{UserID: tt.userID})
+
+		err := handler(c)
+		code := rec.Code
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			code = he.Code
+		} else if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if code != tt.wantCode || rec.Body.String() != tt.wantBody {
+			t.Errorf("%s: got %d %q, want %d %q", tt.name, code, rec.Body.String(), tt.wantCode, tt.wantBody)
+		}
+		if retry := rec.Header().Get("Retry-After"); (code == http.StatusServiceUnavailable) != (retry != "") {
+			t.Errorf("%s: Retry-After = %q", tt.name, retry)
+		}
+	}
+}
+
+// fakeRedirects resolves a fixed set of paths.
+type fakeRedirects map[string]string
+
+func (f fakeRedirects) Resolve(_ context.Context, path string) (string, int, bool) {
+	target, ok := f[path]
+	return target, http.StatusMovedPermanently, ok
+}
+
+// fakeLocales accepts "en" (default) and "de".
+type fakeLocales struct{}
+
+func (fakeLocales) DefaultLocale() string     { return "en" }
+func (fakeLocales) IsLocale(code string) bool { return code == "en" || code == "de" }
+
+func TestRedirects(t *testing.T) {
+	e := echo.New()
+	e.Pre(middleware.LocalePrefix(fakeLocales{}))
+	e.Use(middleware.Redirects(fakeRedirects{
+		"/old":      "/new",
+		"/query":    "/new?tab=specs",
+		"/external": "https://example.com/page",
+	}))
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "page") }
+	e.Any("/*", ok)
+
+	cases := []struct {
+		method, path string
+		code         int
+		location     string
+	}{
+		{http.MethodGet, "/old", http.StatusMovedPermanently, "/new"},
+		{http.MethodGet, "/old?utm=x", http.StatusMovedPermanently, "/new?utm=x"},
+		{http.MethodGet, "/de/old?utm=x", http.StatusMovedPermanently, "/de/new?utm=x"},
+		{http.MethodHead, "/old", http.StatusMovedPermanently, "/new"},
+		{http.MethodGet, "/query?utm=x", http.StatusMovedPermanently, "/new?tab=specs"},
+		{http.MethodGet, "/de/external?utm=x", http.StatusMovedPermanently, "https://example.com/page"},
+		{http.MethodPost, "/old", http.StatusOK, ""},
+		{http.MethodGet, "/other", http.StatusOK, ""},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		if rec.Code != tc.code || rec.Header().Get(echo.HeaderLocation) != tc.location {
+			t.Errorf("%s %s: %d %q, want %d %q", tc.method, tc.path, rec.Code, rec.Header().Get(echo.HeaderLocation), tc.code, tc.location)
+		}
+	}
+}
+
+// fakeNotFound collects recorded 404s.
+type fakeNotFound []sqlc.RecordNotFoundParams
+
+func (f *fakeNotFound) RecordNotFound(_ context.Context, arg sqlc.RecordNotFoundParams) error {
+	*f = append(*f, arg)
+	return nil
+}
+
+func TestNotFoundTracker(t *testing.T) {
+	var recorded fakeNotFound
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.NotFoundTracker(&recorded, slog.New(slog.NewTextHandler(io.Discard, nil)), e.DefaultHTTPErrorHandler)
+	e.GET("/broken", func(c echo.Context) error { return echo.NewHTTPError(http.StatusInternalServerError) })
+	e.GET("/gone", func(c echo.Context) error { return echo.NewHTTPError(http.StatusNotFound, "Product not found") })
+
+	long := "/" + strings.Repeat("x", 600)
+	for _, r := range []struct{ method, path, referrer string }{
+		{http.MethodGet, "/missing?utm=x", "https://example.com/page"},
+		{http.MethodHead, "/missing", ""},
+		{http.MethodGet, "/gone", ""},
+		{http.MethodGet, long, ""},
+		{http.MethodPost, "/missing", ""},
+		{http.MethodGet, "/admin/missing", ""},
+		{http.MethodGet, "/broken", ""},
+	} {
+		req := httptest.NewRequest(r.method, r.path, nil)
+		if r.referrer != "" {
+			req.Header.Set("Referer", r.referrer)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code == http.StatusOK {
+			t.Errorf("%s %s: error response not written", r.method, r.path)
+		}
+	}
+
+	want := []sqlc.RecordNotFoundParams{
+		{Path: "/missing", Referrer: "https://example.com/page"},
+		{Path: "/missing"},
+		{Path: "/gone"},
+		{Path: long[:512]},
+	}
+	if len(recorded) != len(want) {
+		t.Fatalf("recorded %d 404s, want %d: %+v", len(recorded), len(want), recorded)
+	}
+	for i := range want {
+		if recorded[i] != want[i] {
+			t.Errorf("404 %d = %+v, want %+v", i, recorded[i], want[i])
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	var got string
+	e.GET("/", func(c echo.Context) error {
+		got = middleware.RequestIDFrom(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, r := range []struct{ incoming, want string }{
+		{"lb-7f3a.1_x", "lb-7f3a.1_x"},
+		{"", ""},
+		{"bad id\nInjected: 1", ""},
+		{strings.Repeat("a", 65), ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if r.incoming != "" {
+			req.Header.Set(echo.HeaderXRequestID, r.incoming)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		header := rec.Header().Get(echo.HeaderXRequestID)
+		if header != got {
+			t.Errorf("incoming %q: header %q, context %q", r.incoming, header, got)
+		}
+		if r.want != "" && got != r.want {
+			t.Errorf("incoming %q: id %q, want it kept", r.incoming, got)
+		}
+		if r.want == "" && (len(got) != 32 || got == r.incoming) {
+			t.Errorf("incoming %q: id %q, want a generated one", r.incoming, got)
+		}
+	}
+}
+
+func TestLogging_AccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(middleware.ContextLogHandler(slog.NewJSONHandler(&buf, nil)))
+	e := echo.New()
+	e.Use(middleware.RequestID(), middleware.Logging(logger))
+	signedIn := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("session", &middleware.Session{UserID: 7, Email: "ed@test.com", Role: "editor"})
+			return next(c)
+		}
+	}
+	e.GET("/admin/products/:id", func(c echo.Context) error {
+		logger.InfoContext(c.Request().Context(), "handler")
+		return echo.NewHTTPError(http.StatusNotFound)
+	}, signedIn, middleware.Authenticate(middleware.SessionAuthenticator{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/products/12", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d log records, want handler + access log: %s", len(records), buf.String())
+	}
+	if records[0]["request_id"] != "req-1" {
+		t.Errorf("handler record request_id = %v", records[0]["request_id"])
+	}
+	access := records[1]
+	for key, want := range map[string]any{
+		"msg":        "request",
+		"request_id": "req-1",
+		"method":     "GET",
+		"path":       "/admin/products/12",
+		"route":      "/admin/products/:id",
+		"status":     float64(http.StatusNotFound),
+		"user_id":    float64(7),
+		"user":       "ed@test.com",
+	} {
+		if access[key] != want {
+			t.Errorf("access log %s = %v, want %v", key, access[key], want)
+		}
+	}
+	if _, ok := access["duration_ms"]; !ok {
+		t.Error("access log has no duration_ms")
+	}
+}
+
+// fakeSEO holds overrides keyed by path and by "type:id".
+type fakeSEO map[string]sqlc.SeoMetum
+
+func (f fakeSEO) ForPath(path string) (sqlc.SeoMetum, bool) {
+	m, ok := f[path]
+	return m, ok
+}
+
+func (f fakeSEO) ForEntity(entityType string, id int64) (sqlc.SeoMetum, bool) {
+	m, ok := f[entityType+":"+strconv.FormatInt(id, 10)]
+	return m, ok
+}
+
+func TestSEO(t *testing.T) {
+	e := echo.New()
+	e.Pre(middleware.LocalePrefix(fakeLocales{}))
+	e.Use(middleware.SEO(fakeSEO{
+		"/about":      {MetaTitle: "About"},
+		"/products/a": {MetaTitle: "By path"},
+		"product:1":   {MetaTitle: "By entity"},
+	}))
+	var path, title string
+	var ok bool
+	e.GET("/*", func(c echo.Context) error {
+		middleware.SetSEOEntity(c, "product", 1)
+		var meta sqlc.SeoMetum
+		path, meta, ok = middleware.PageSEOFrom(c)
+		title = meta.MetaTitle
+		return c.NoContent(http.StatusOK)
+	})
+
+	for req, want := range map[string][2]string{
+		"/de/about":   {"/about", "About"},
+		"/products/a": {"/products/a", "By path"}, // The path wins over the entity
+		"/products/b": {"/products/b", "By entity"},
+	} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, req, nil))
+		if !ok || path != want[0] || title != want[1] {
+			t.Errorf("%s: PageSEOFrom = %q, %q, %v; want %q, %q", req, path, title, ok, want[0], want[1])
+		}
+	}
+
+	if _, _, ok := middleware.PageSEOFrom(e.NewContext(httptest.NewRequest(http.MethodGet, "/admin", nil), httptest.NewRecorder())); ok {
+		t.Error("PageSEOFrom outside the middleware reported ok")
+	}
+}
+
+// fakePageViews records the paths of counted views.
+type fakePageViews []string
+
+func (f *fakePageViews) Record(r *http.Request, ip string) { *f = append(*f, r.URL.Path) }
+
+func TestPageViews(t *testing.T) {
+	var counted fakePageViews
+	e := echo.New()
+	e.Use(middleware.PageViews(&counted))
+	e.GET("/page", func(c echo.Context) error { return c.HTML(http.StatusOK, "<p>page</p>") })
+	e.GET("/feed.xml", func(c echo.Context) error { return c.XMLBlob(http.StatusOK, []byte("<rss/>")) })
+	e.GET("/gone", func(c echo.Context) error { return echo.NewHTTPError(http.StatusNotFound) })
+	e.GET("/moved", func(c echo.Context) error { return c.Redirect(http.StatusMovedPermanently, "/page") })
+	e.POST("/page", func(c echo.Context) error { return c.HTML(http.StatusOK, "<p>sent</p>") })
+	e.GET("/secret/:token", func(c echo.Context) error {
+		middleware.SkipPageView(c)
+		return c.HTML(http.StatusOK, "<p>secret</p>")
+	})
+
+	for _, r := range []struct {
+		method, path string
+		header       string
+	}{
+		{http.MethodGet, "/page", ""},
+		{http.MethodGet, "/page", "HX-Request: true"},
+		{http.MethodGet, "/page", "DNT: 1"},
+		{http.MethodGet, "/page", "Sec-GPC: 1"},
+		{http.MethodGet, "/page", "Sec-Purpose: prefetch"},
+		{http.MethodPost, "/page", ""},
+		{http.MethodGet, "/feed.xml", ""},
+		{http.MethodGet, "/gone", ""},
+		{http.MethodGet, "/moved", ""},
+		{http.MethodGet, "/secret/abc", ""},
+	} {
+		req := httptest.NewRequest(r.method, r.path, nil)
+		if name, value, ok := strings.Cut(r.header, ": "); ok {
+			req.Header.Set(name, value)
+		}
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if len(counted) != 1 || counted[0] != "/page" {
+		t.Errorf("counted %v, want only the plain GET of /page", counted)
+	}
+}
+
+func TestFlashes(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.SessionMiddleware())
+	e.POST("/save", func(c echo.Context) error {
+		middleware.AddFlash(c, middleware.FlashSuccess, "Settings saved.")
+		return c.Redirect(http.StatusSeeOther, "/page")
+	})
+	e.GET("/page", func(c echo.Context) error {
+		var msgs []string
+		for _, f := range middleware.FlashesFrom(c) {
+			msgs = append(msgs, f.Level+":"+f.Message)
+		}
+		return c.String(http.StatusOK, strings.Join(msgs, ","))
+	})
+	e.PATCH("/inline", func(c echo.Context) error {
+		middleware.AddFlash(c, middleware.FlashSuccess, "Saved.")
+		middleware.AddFlash(c, middleware.FlashWarning, "Order must be a number.")
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/save", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) == 0 {
+		t.Fatal("flash was not saved in the session cookie")
+	}
+	show := func() (string, []*http.Cookie) {
+		req := httptest.NewRequest(http.MethodGet, "/page", nil)
+		for _, ck := range cookies {
+			req.AddCookie(ck)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Body.String(), rec.Result().Cookies()
+	}
+	body, next := show()
+	if body != "success:Settings saved." {
+		t.Errorf("first page shows %q, want the flash", body)
+	}
+	cookies = next
+	if body, _ = show(); body != "" {
+		t.Errorf("flash shown twice: %q", body)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/inline", nil)
+	req.Header.Set("HX-Request", "true")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	var trigger map[string]struct {
+		Messages []struct{ Level, Message string }
+	}
+	if err := json.Unmarshal([]byte(rec.Header().Get("HX-Trigger")), &trigger); err != nil {
+		t.Fatalf("HX-Trigger %q: %v", rec.Header().Get("HX-Trigger"), err)
+	}
+	if msgs := trigger[middleware.ToastEvent].Messages; len(msgs) != 2 || msgs[1].Level != "warning" || msgs[1].Message != "Order must be a number." {
+		t.Errorf("toasts = %+v", msgs)
+	}
+	if len(rec.Result().Cookies()) != 0 {
+		t.Error("HTMX toast was also stored in the session")
+	}
+}
+
+// fakeSiteData serves fixed globals and counts invalidations.
+type fakeSiteData struct {
+	globals     models.SiteGlobals
+	invalidated int
+}
+
+func (f *fakeSiteData) Get(ctx context.Context) (models.SiteGlobals, error) {
+	return f.globals, errors.New("footer resources: no such table")
+}
+
+func (f *fakeSiteData) Invalidate() { f.invalidated++ }
+
+func TestSiteDataLoader(t *testing.T) {
+	site := &fakeSiteData{globals: models.SiteGlobals{
+		Settings:         &sqlc.Setting{SiteName: "Bluejay"},
+		FooterCategories: []sqlc.ProductCategory{{Slug: "sensors", ProductCount: 2}},
+	}}
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	err := middleware.SiteDataLoader(site)(func(c echo.Context) error {
+		if s, ok := c.Get("settings").(sqlc.Setting); !ok || s.SiteName != "Bluejay" {
+			t.Errorf("settings = %#v", c.Get("settings"))
+		}
+		if cats, ok := c.Get("footer_categories").([]sqlc.ProductCategory); !ok || len(cats) != 1 {
+			t.Errorf("footer_categories = %#v", c.Get("footer_categories"))
+		}
+		// Parts that failed to load are left unset
+		if c.Get("footer_resources") != nil {
+			t.Errorf("footer_resources = %#v, want unset", c.Get("footer_resources"))
+		}
+		return nil
+	})(c)
+	if err != nil {
+		t.Fatalf("a failed part must not fail the request: %v", err)
+	}
+}
+
+func TestInvalidateSiteData(t *testing.T) {
+	site := &fakeSiteData{}
+	e := echo.New()
+	e.Use(middleware.InvalidateSiteData(site))
+	e.GET("/admin/settings", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.POST("/admin/settings", func(c echo.Context) error { return c.Redirect(http.StatusSeeOther, "/admin/settings") })
+	e.POST("/admin/invalid", func(c echo.Context) error { return c.NoContent(http.StatusUnprocessableEntity) })
+	e.DELETE("/admin/broken", func(c echo.Context) error { return echo.NewHTTPError(http.StatusInternalServerError) })
+
+	for _, r := range []struct{ method, path string }{
+		{http.MethodGet, "/admin/settings"},
+		{http.MethodPost, "/admin/settings"},
+		{http.MethodPost, "/admin/invalid"},
+		{http.MethodDelete, "/admin/broken"},
+	} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(r.method, r.path, nil))
+	}
+	if site.invalidated != 1 {
+		t.Errorf("invalidated %d times, want once for the successful POST", site.invalidated)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	page := "<html>" + strings.Repeat("<p>Bluejay</p>", 200) + "</html>"
+	e := echo.New()
+	e.Use(middleware.Compress())
+	e.GET("/page", func(c echo.Context) error { return c.HTML(http.StatusOK, page) })
+	e.GET("/short", func(c echo.Context) error { return c.HTML(http.StatusOK, "<p>ok</p>") })
+	e.GET("/image", func(c echo.Context) error { return c.Blob(http.StatusOK, "image/png", []byte(page)) })
+	e.GET("/redirect", func(c echo.Context) error { return c.Redirect(http.StatusSeeOther, "/page") })
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", accept)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) string {
+		t.Helper()
+		var r io.Reader
+		switch rec.Header().Get("Content-Encoding") {
+		case "br":
+			r = brotli.NewReader(rec.Body)
+		case "gzip":
+			zr, err := gzip.NewReader(rec.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = zr
+		default:
+			r = rec.Body
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	tests := []struct {
+		path, accept, encoding string
+	}{
+		{"/page", "gzip, deflate, br", "br"},
+		{"/page", "gzip;q=1, br;q=0.5", "gzip"},
+		{"/page", "br;q=0, gzip", "gzip"},
+		{"/page", "*", "br"},
+		{"/page", "", ""},
+		{"/short", "br", ""},
+		{"/image", "br", ""},
+		{"/redirect", "br", ""},
+	}
+	for _, tt := range tests {
+		rec := get(tt.path, tt.accept)
+		if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+			t.Errorf("%s with %q: encoding %q, want %q", tt.path, tt.accept, got, tt.encoding)
+		}
+		if rec.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("%s with %q: Vary %q", tt.path, tt.accept, rec.Header().Get("Vary"))
+		}
+		if tt.path == "/page" && decode(t, rec) != page {
+			t.Errorf("%s with %q: body not preserved", tt.path, tt.accept)
+		}
+	}
+	if rec := get("/redirect", "br"); rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/page" {
+		t.Errorf("redirect: %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if rec := get("/short", "br"); rec.Body.String() != "<p>ok</p>" {
+		t.Errorf("short body: %q", rec.Body.String())
+	}
+}
+
+func TestParseIPRules(t *testing.T) {
+	rules, err := middleware.ParseIPRules("10.8.0.0/16\r\n203.0.113.7, 2001:db8::/32\n\n ::ffff:198.51.100.1 ")
+	if err != nil {
+		t.Fatalf("ParseIPRules: %v", err)
+	}
+	var got []string
+	for _, r := range rules {
+		got = append(got, r.String())
+	}
+	if want := "10.8.0.0/16 203.0.113.7/32 2001:db8::/32 198.51.100.1/32"; strings.Join(got, " ") != want {
+		t.Errorf("rules = %v, want %s", got, want)
+	}
+	for _, bad := range []string{"10.0.0.0/33", "intranet", "10.0.0"} {
+		if _, err := middleware.ParseIPRules(bad); err == nil || !strings.Contains(err.Error(), bad) {
+			t.Errorf("ParseIPRules(%q) = %v, want an error naming it", bad, err)
+		}
+	}
+}
+
+func TestAdminIPFilter(t *testing.T) {
+	const token = "emergency-token-at-least-32-characters"
+	site := &fakeSiteData{globals: models.SiteGlobals{Settings: &sqlc.Setting{
+		AdminIpAllow: "10.8.0.0/16\n2001:db8::/32",
+		AdminIpDeny:  "10.8.9.0/24",
+	}}}
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	admin := e.Group("/admin", middleware.AdminIPFilter(site, token))
+	admin.GET("/login", func(c echo.Context) error {
+		return c.String(http.StatusOK, strconv.FormatBool(middleware.IPBypassed(c)))
+	})
+	serve := func(remote, target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = remote
+		for _, ck := range cookies {
+			req.AddCookie(ck)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, tt := range []struct {
+		remote string
+		want   int
+	}{
+		{"10.8.1.2:5000", http.StatusOK},
+		{"[2001:db8::5]:5000", http.StatusOK},
+		{"10.8.9.9:5000", http.StatusForbidden}, // Denied inside an allowed range
+		{"198.51.100.1:5000", http.StatusForbidden},
+	} {
+		if rec := serve(tt.remote, "/admin/login"); rec.Code != tt.want {
+			t.Errorf("%s: %d, want %d", tt.remote, rec.Code, tt.want)
+		}
+	}
+
+	// A wrong token is ignored; the right one sets the cookie and drops
+	// itself from the URL
+	if rec := serve("198.51.100.1:5000", "/admin/login?admin_bypass=guess"); rec.Code != http.StatusForbidden {
+		t.Errorf("wrong token: %d, want 403", rec.Code)
+	}
+	rec := serve("198.51.100.1:5000", "/admin/login?next=%2Fadmin%2Fsettings&admin_bypass="+token)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/login?next=%2Fadmin%2Fsettings" {
+		t.Fatalf("bypass: %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || strings.Contains(cookies[0].Value, token) || !cookies[0].HttpOnly {
+		t.Fatalf("bypass cookie = %+v", cookies)
+	}
+	if rec := serve("198.51.100.1:5000", "/admin/login", cookies[0]); rec.Code != http.StatusOK || rec.Body.String() != "true" {
+		t.Errorf("with bypass cookie: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := serve("198.51.100.1:5000", "/admin/login", &http.Cookie{Name: cookies[0].Name, Value: "forged"}); rec.Code != http.StatusForbidden {
+		t.Errorf("forged cookie: %d, want 403", rec.Code)
+	}
+
+	// Without rules every address is allowed; without settings none is
+	site.globals.Settings = &sqlc.Setting{}
+	if rec := serve("198.51.100.1:5000", "/admin/login"); rec.Code != http.StatusOK || rec.Body.String() != "false" {
+		t.Errorf("no rules: %d %q", rec.Code, rec.Body.String())
+	}
+	site.globals.Settings = nil
+	if rec := serve("10.8.1.2:5000", "/admin/login"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("settings unavailable: %d, want 503", rec.Code)
+	}
+}
+
+func TestAdminIPFilter_ForwardedFor(t *testing.T) {
+	site := &fakeSiteData{globals: models.SiteGlobals{Settings: &sqlc.Setting{AdminIpAllow: "10.8.0.0/16"}}}
+	serve := func(trusted, remote, forwardedFor string) int {
+		proxies, err := middleware.ParseIPRules(trusted)
+		if err != nil {
+			t.Fatalf("ParseIPRules: %v", err)
+		}
+		e := echo.New()
+		e.IPExtractor = middleware.ClientIPExtractor(proxies)
+		e.GET("/admin/login", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, middleware.AdminIPFilter(site, ""))
+		req := httptest.NewRequest(http.MethodGet, "/admin/login", nil)
+		req.RemoteAddr = remote
+		if forwardedFor != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for _, tt := range []struct {
+		name                          string
+		trusted, remote, forwardedFor string
+		want                          int
+	}{
+		// Without trusted proxies the header is ignored, so a client cannot
+		// claim an allowed address
+		{"spoofed, no proxies", "", "198.51.100.1:5000", "10.8.1.2", http.StatusForbidden},
+		{"direct, no proxies", "", "10.8.1.2:5000", "", http.StatusOK},
+		{"spoofed, untrusted sender", "127.0.0.1", "198.51.100.1:5000", "10.8.1.2", http.StatusForbidden},
+		{"trusted proxy", "127.0.0.1", "127.0.0.1:5000", "10.8.1.2", http.StatusOK},
+		// A client's own header is passed on in front of the address the
+		// proxy appends, which is the one that counts
+		{"spoofed through proxy", "127.0.0.1", "127.0.0.1:5000", "10.8.1.2, 198.51.100.1", http.StatusForbidden},
+		// Private networks are only trusted when listed
+		{"unlisted private proxy", "127.0.0.1", "192.168.1.5:5000", "10.8.1.2", http.StatusForbidden},
+	} {
+		if got := serve(tt.trusted, tt.remote, tt.forwardedFor); got != tt.want {
+			t.Errorf("%s: %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// github.com/narendhupati/bluejay-cms/internal/models provides the menu
+	// items rendered by the header and footer templates.
+	"github.com/narendhupati/bluejay-cms/internal/models"
+)
+
+// navigationKey is the context key holding the active menus.
+const navigationKey = "navigation_menus"
+
+// MenuSource provides the active navigation menus keyed by location. It is
+// satisfied by services.Navigation.
+type MenuSource interface {
+	Menus() map[string][]models.MenuItem
+}
+
+// Navigation returns an Echo middleware for the public route group that
+// makes the active menus of Admin > Navigation available to the renderer,
+// which passes them to every public page as .Menus (read with MenusFrom).
+// The header and footer partials render the "header", "mobile", "footer"
+// and "footer_legal" menus in place of their built-in links.
+//
+// Parameters:
+//   - menus: Menu lookup, typically *services.Navigation
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets c.Get("navigation_menus")
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.Navigation(navSvc))
+func Navigation(menus MenuSource) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(navigationKey, menus.Menus())
+			return next(c)
+		}
+	}
+}
+
+// MenusFrom returns the active menus stored by Navigation, or an empty map
+// outside it (admin pages), so templates can always index .Menus.
+func MenusFrom(c echo.Context) map[string][]models.MenuItem {
+	if c != nil {
+		if menus, ok := c.Get(navigationKey).(map[string][]models.MenuItem); ok && menus != nil {
+			return menus
+		}
+	}
+	return map[string][]models.MenuItem{}
+}
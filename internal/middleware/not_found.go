@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	// context carries request cancellation into the recorder.
+	"context"
+
+	// errors unwraps the handler error to find its HTTP status.
+	"errors"
+
+	// log/slog reports recording failures without affecting the response.
+	"log/slog"
+
+	// net/http provides the status code and request methods that are tracked.
+	"net/http"
+
+	// strings excludes admin paths and trims over-long values.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the error handler and context types.
+	"github.com/labstack/echo/v4"
+
+	// github.com/narendhupati/bluejay-cms/db/sqlc provides the not_found_hits parameters.
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// notFoundMaxLen caps the stored path and referrer, so a scanner sending
+// huge URLs cannot bloat the table.
+const notFoundMaxLen = 512
+
+// linkCheckerUserAgent is services.LinkCheckerUserAgent. The link checker's
+// requests are not recorded: what it finds is listed under Admin > Link
+// Check, and counting them would flood the 404 report after every run.
+const linkCheckerUserAgent = "bluejay-link-checker"
+
+// NotFoundRecorder counts a public 404. It is satisfied by *sqlc.Queries.
+type NotFoundRecorder interface {
+	RecordNotFound(ctx context.Context, arg sqlc.RecordNotFoundParams) error
+}
+
+// NotFoundTracker returns an Echo error handler that records every public
+// GET or HEAD request ending in 404 Not Found (path and referrer) for the
+// broken link report under Admin > 404s, then hands the error to next to
+// write the response. Admin paths and requests from the link checker are not
+// recorded, and a recording failure is logged without changing the response.
+//
+// Parameters:
+//   - recorder: 404 counter, typically *sqlc.Queries
+//   - logger: Logger for recording failures
+//   - next: Handler that writes the error response, typically e.DefaultHTTPErrorHandler
+//
+// Returns:
+//   - echo.HTTPErrorHandler: Handler to assign to e.HTTPErrorHandler
+//
+// Example usage:
+//
+//	e.HTTPErrorHandler = middleware.NotFoundTracker(queries, logger, e.DefaultHTTPErrorHandler)
+func NotFoundTracker(recorder NotFoundRecorder, logger *slog.Logger, next echo.HTTPErrorHandler) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		var he *echo.HTTPError
+		req := c.Request()
+		if errors.As(err, &he) && he.Code == http.StatusNotFound && !c.Response().Committed &&
+			(req.Method == http.MethodGet || req.Method == http.MethodHead) &&
+			req.URL.Path != "/admin" && !strings.HasPrefix(req.URL.Path, "/admin/") &&
+			req.UserAgent() != linkCheckerUserAgent {
+			arg := sqlc.RecordNotFoundParams{
+				Path:     truncateNotFound(req.URL.Path),
+				Referrer: truncateNotFound(req.Referer()),
+			}
+			if rerr := recorder.RecordNotFound(req.Context(), arg); rerr != nil {
+				logger.ErrorContext(req.Context(), "failed to record 404", "error", rerr, "path", arg.Path)
+			}
+		}
+		next(err, c)
+	}
+}
+
+// truncateNotFound shortens s to notFoundMaxLen bytes without splitting a
+// UTF-8 sequence.
+func truncateNotFound(s string) string {
+	if len(s) <= notFoundMaxLen {
+		return s
+	}
+	return strings.ToValidUTF8(s[:notFoundMaxLen], "")
+}
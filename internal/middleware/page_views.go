@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	// net/http provides the request method and status that are counted.
+	"net/http"
+
+	// strings matches the response content type.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// PageViewRecorder counts a view of a public page. It is satisfied by
+// services.Analytics, which classifies the user agent, ignores bots and
+// looks up the visitor's country from ip.
+type PageViewRecorder interface {
+	Record(r *http.Request, ip string)
+}
+
+// PageViews returns an Echo middleware for the public route group that
+// records a page view for Admin > Analytics after each GET request answered
+// with a 200 HTML page. HTMX swaps, prefetches, draft previews and requests
+// from a signed-in admin session are not counted, nor are visitors who send
+// Do Not Track or Global Privacy Control.
+//
+// Parameters:
+//   - recorder: Page view counter, typically *services.Analytics
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that records views after the handler
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.PageViews(analyticsSvc))
+func PageViews(recorder PageViewRecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if err == nil && countPageView(c) {
+				recorder.Record(c.Request(), c.RealIP())
+			}
+			return err
+		}
+	}
+}
+
+// skipPageViewKey marks a request that must not be counted.
+const skipPageViewKey = "skip_page_view"
+
+// SkipPageView keeps the current request out of the page view counts. Handlers
+// call it for pages whose path carries a secret, such as the partner portal
+// token, so the secret is not stored with the view.
+func SkipPageView(c echo.Context) {
+	c.Set(skipPageViewKey, true)
+}
+
+// countPageView reports whether the finished request is a page view.
+func countPageView(c echo.Context) bool {
+	req, res := c.Request(), c.Response()
+	if req.Method != http.MethodGet || res.Status != http.StatusOK ||
+		!strings.HasPrefix(res.Header().Get(echo.HeaderContentType), echo.MIMETextHTML) {
+		return false
+	}
+	if IsHTMX(c) || req.Header.Get("DNT") == "1" || req.Header.Get("Sec-GPC") == "1" ||
+		req.Header.Get("Sec-Purpose") != "" || req.Header.Get("Purpose") == "prefetch" {
+		return false
+	}
+	if _, ok := PreviewFrom(c); ok {
+		return false
+	}
+	if skip, _ := c.Get(skipPageViewKey).(bool); skip {
+		return false
+	}
+	if sess, ok := c.Get("session").(*Session); ok && sess.UserID != 0 {
+		return false
+	}
+	return true
+}
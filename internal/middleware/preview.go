@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	// context is part of the TokenVerifier signature.
+	"context"
+
+	// net/http provides the 403 status for rejected preview links.
+	"net/http"
+
+	// strconv formats the item ID of a preview principal.
+	"strconv"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// PreviewTokenParam is the query parameter carrying a preview token.
+const PreviewTokenParam = "preview_token"
+
+// previewMethod is the Principal.Method of requests admitted by a preview link.
+const previewMethod = "preview"
+
+// PreviewGrant names the draft a verified preview token unlocks.
+type PreviewGrant struct {
+	Type string // Content type, e.g. services.PreviewBlogPost
+	ID   int64  // ID of the item in its table
+}
+
+// PreviewVerifier checks preview tokens. It is implemented by
+// services.PreviewTokens.
+type PreviewVerifier interface {
+	VerifyPreview(token string) (contentType string, id int64, err error)
+}
+
+// PreviewTokenVerifier adapts a PreviewVerifier to TokenVerifier. The
+// principal of a preview link is limited to the item it unlocks.
+func PreviewTokenVerifier(v PreviewVerifier) TokenVerifier {
+	return TokenVerifierFunc(func(ctx context.Context, token string) (*Principal, error) {
+		contentType, id, err := v.VerifyPreview(token)
+		if err != nil {
+			return nil, err
+		}
+		return &Principal{
+			DisplayName: "Preview link",
+			Method:      previewMethod,
+			Subject:     contentType + "." + strconv.FormatInt(id, 10),
+			Resource:    contentType,
+			ResourceID:  id,
+		}, nil
+	})
+}
+
+// Preview returns an Echo middleware for the public route group that admits
+// draft previews. It authenticates ?preview_token= with a TokenAuthenticator
+// through Authenticate: valid tokens store a preview principal in the context
+// (read with PreviewFrom) and mark the response private and not indexable;
+// invalid or expired tokens receive 403. Requests without the parameter pass
+// through untouched.
+//
+// The token alone grants access, without a login, so preview links can be
+// shared with reviewers until they expire.
+//
+// Parameters:
+//   - v: Token verifier (services.PreviewTokens)
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for the public route group
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.Preview(previewTokens))
+func Preview(v PreviewVerifier) echo.MiddlewareFunc {
+	authenticate := Authenticate(Optional(TokenAuthenticator{
+		Verifier:   PreviewTokenVerifier(v),
+		QueryParam: PreviewTokenParam,
+		LinkOnly:   true,
+		Deny: func(c echo.Context, err error) error {
+			return echo.NewHTTPError(http.StatusForbidden, "This preview link is invalid or has expired")
+		},
+	}))
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return authenticate(func(c echo.Context) error {
+			if _, ok := PreviewFrom(c); ok {
+				h := c.Response().Header()
+				h.Set(echo.HeaderCacheControl, "private, no-store")
+				h.Set("X-Robots-Tag", "noindex, nofollow")
+			}
+			return next(c)
+		})
+	}
+}
+
+// PreviewFrom returns the draft unlocked by the request's preview link, and
+// false when the request carries no valid preview token.
+func PreviewFrom(c echo.Context) (PreviewGrant, bool) {
+	p := PrincipalFrom(c)
+	if p == nil || p.Method != previewMethod {
+		return PreviewGrant{}, false
+	}
+	return PreviewGrant{Type: p.Resource, ID: p.ResourceID}, true
+}
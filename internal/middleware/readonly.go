@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	// bytes provides the buffer that captures rendered pages for snapshots.
+	"bytes"
+
+	// context is used by the DBHealthChecker interface for on-demand probes.
+	"context"
+
+	// net/http provides status codes and the ResponseWriter being wrapped.
+	"net/http"
+
+	// strings is used to locate the <body> tag when injecting the banner.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// DBHealthChecker reports whether the database is usable. It is implemented by
+// services.DBHealth.
+type DBHealthChecker interface {
+	// Degraded reports whether the app is in read-only recovery mode.
+	Degraded() bool
+	// Verify probes the database after a failed request, entering read-only
+	// mode when the probe fails. Returns true if the database is healthy.
+	Verify(ctx context.Context) bool
+}
+
+// PageSnapshotStore keeps the last good copy of each public page. It is
+// implemented by services.Cache.
+type PageSnapshotStore interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttlSeconds int)
+}
+
+const (
+	// snapshotTTL keeps page snapshots for a day, long enough to ride out an
+	// overnight outage without holding stale pages forever.
+	snapshotTTL = 24 * 60 * 60
+
+	// snapshotMaxBytes skips snapshots of unusually large responses.
+	snapshotMaxBytes = 1 << 20
+
+	// degradedBanner is injected right after <body> on pages served from
+	// snapshots. Inline styles keep it independent of the site stylesheet.
+	degradedBanner = `<div role="status" style="background:#FEF3C7;border-bottom:2px solid #000;color:#000;font:bold 13px/1.4 monospace;padding:10px 16px;text-align:center;">` +
+		`This site is temporarily in read-only mode. You are viewing a saved copy of this page; some content may be out of date.</div>`
+
+	// degradedPage is served when no snapshot exists for the requested page.
+	degradedPage = `<!DOCTYPE html><html><head><meta charset="utf-8"><title>Temporarily unavailable</title></head>` +
+		`<body style="font-family:monospace;max-width:640px;margin:80px auto;padding:0 16px;">` +
+		`<h1>Temporarily unavailable</h1><p>We are performing maintenance and this page is not available right now. Please try again in a few minutes.</p></body></html>`
+
+	// degradedFragment answers HTMX requests and form posts during read-only mode.
+	degradedFragment = `<div class="alert alert-error">The site is temporarily in read-only mode. Please try again in a few minutes.</div>`
+)
+
+// snapshotWriter passes the response through to the client while keeping a
+// copy of the body, up to snapshotMaxBytes, for the snapshot store.
+type snapshotWriter struct {
+	http.ResponseWriter
+	buf      bytes.Buffer
+	overflow bool
+}
+
+func (w *snapshotWriter) Write(b []byte) (int, error) {
+	if !w.overflow {
+		if w.buf.Len()+len(b) > snapshotMaxBytes {
+			w.overflow = true
+			w.buf.Reset()
+		} else {
+			w.buf.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// snapshotKey identifies a page snapshot by URL and the visitor's language
+// cookie, so localized pages are not served to visitors of another locale.
+func snapshotKey(c echo.Context) string {
+	lang := ""
+	if ck, err := c.Cookie(localeCookieName); err == nil {
+		lang = ck.Value
+	}
+	return "stale:" + lang + "|" + c.Request().URL.RequestURI()
+}
+
+// isPrivate reports whether a response is marked for the visitor only, like
+// the customer account pages, and must not be kept as a shared snapshot.
+func isPrivate(c echo.Context) bool {
+	cc := strings.ToLower(c.Response().Header().Get(echo.HeaderCacheControl))
+	return strings.Contains(cc, "private") || strings.Contains(cc, "no-store")
+}
+
+// hasCustomerSession reports whether the request carries a customer session
+// cookie; pages rendered for a signed-in customer may show their details.
+func hasCustomerSession(c echo.Context) bool {
+	ck, err := c.Cookie(CustomerCookie)
+	return err == nil && ck.Value != ""
+}
+
+// isServerError reports whether a handler result is a 5xx failure.
+func isServerError(c echo.Context, err error) bool {
+	if err == nil {
+		return c.Response().Status >= http.StatusInternalServerError
+	}
+	if he, ok := err.(*echo.HTTPError); ok {
+		return he.Code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// injectBanner inserts the read-only banner right after the opening <body> tag.
+func injectBanner(page string) string {
+	i := strings.Index(page, "<body")
+	if i < 0 {
+		return degradedBanner + page
+	}
+	j := strings.Index(page[i:], ">")
+	if j < 0 {
+		return degradedBanner + page
+	}
+	at := i + j + 1
+	return page[:at] + degradedBanner + page[at:]
+}
+
+// serveDegraded answers a request while the database is unavailable: saved
+// pages get the banner, anything else gets a 503.
+func serveDegraded(c echo.Context, store PageSnapshotStore) error {
+	h := c.Response().Header()
+	h.Set("X-Read-Only-Mode", "1")
+	h.Set("Cache-Control", "no-store")
+
+	req := c.Request()
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		if req.Header.Get("HX-Request") == "" {
+			if page, ok := store.Get(snapshotKey(c)); ok {
+				return c.HTML(http.StatusOK, injectBanner(page.(string)))
+			}
+		}
+	}
+
+	h.Set("Retry-After", "60")
+	if req.Header.Get("HX-Request") != "" || req.Method != http.MethodGet {
+		return c.HTML(http.StatusServiceUnavailable, degradedFragment)
+	}
+	return c.HTML(http.StatusServiceUnavailable, degradedPage)
+}
+
+// ReadOnlyFallback returns an Echo middleware that keeps public pages online
+// when SQLite is locked or corrupt.
+//
+// While the database is healthy it records a snapshot of every successful
+// full-page HTML GET response. When the database becomes unavailable, either
+// because the health watchdog tripped or because a request failed with a 5xx
+// and an on-demand probe confirmed the problem, requests are answered from
+// those snapshots with a read-only banner instead of a 500. Pages without a
+// snapshot, HTMX fragments, and form submissions receive a 503 with
+// Retry-After. Normal handling resumes as soon as the watchdog sees a healthy
+// database.
+//
+// Not snapshotted: HTMX requests (fragments), preview links (?preview_token=),
+// requests of signed-in customers, responses marked private or no-store (the
+// customer account pages), and /health, which always runs so monitors see
+// the real state.
+//
+// Parameters:
+//   - health: Database health state (services.DBHealth)
+//   - store: Snapshot storage (services.Cache); entries use the "stale:" prefix
+//     so page cache invalidation does not discard them
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for the public route group
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.ReadOnlyFallback(dbHealth, appCache))
+func ReadOnlyFallback(health DBHealthChecker, store PageSnapshotStore) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.URL.Path == "/health" {
+				return next(c)
+			}
+			if health.Degraded() {
+				return serveDegraded(c, store)
+			}
+
+			snapshot := req.Method == http.MethodGet &&
+				req.Header.Get("HX-Request") == "" &&
+				!req.URL.Query().Has(PreviewTokenParam) &&
+				!hasCustomerSession(c)
+
+			var w *snapshotWriter
+			if snapshot {
+				w = &snapshotWriter{ResponseWriter: c.Response().Writer}
+				c.Response().Writer = w
+				defer func() { c.Response().Writer = w.ResponseWriter }()
+			}
+
+			err := next(c)
+
+			if isServerError(c, err) {
+				// Only replace the error when nothing was written and the
+				// database really is the problem
+				if !health.Verify(req.Context()) && !c.Response().Committed {
+					return serveDegraded(c, store)
+				}
+				return err
+			}
+
+			if snapshot && err == nil && !w.overflow &&
+				c.Response().Status == http.StatusOK && !isPrivate(c) &&
+				strings.HasPrefix(c.Response().Header().Get(echo.HeaderContentType), echo.MIMETextHTML) {
+				store.Set(snapshotKey(c), w.buf.String(), snapshotTTL)
+			}
+			return err
+		}
+	}
+}
+
+// ReadOnlyGuard returns an Echo middleware for the admin panel that refuses
+// state-changing requests (anything but GET/HEAD) with a 503 while the
+// database is in read-only recovery mode, so editors get a clear message
+// instead of a failed save.
+//
+// Parameters:
+//   - health: Database health state (services.DBHealth)
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for the admin route group
+//
+// Example usage:
+//
+//	adminGroup.Use(middleware.ReadOnlyGuard(dbHealth))
+func ReadOnlyGuard(health DBHealthChecker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			m := c.Request().Method
+			if m != http.MethodGet && m != http.MethodHead && health.Degraded() {
+				c.Response().Header().Set("Retry-After", "60")
+				return echo.NewHTTPError(http.StatusServiceUnavailable, "The database is unavailable; the site is in read-only recovery mode. Changes cannot be saved right now.")
+			}
+			return next(c)
+		}
+	}
+}
@@ -108,7 +108,7 @@ func Recovery(logger *slog.Logger) echo.MiddlewareFunc {
 					//
 					// We use Error level (not Info or Warn) because panics are always serious issues
 					// that require investigation and fixing.
-					logger.Error("panic recovered",
+					logger.ErrorContext(c.Request().Context(), "panic recovered",
 						"error", err,
 						"stack", string(debug.Stack()),
 						"path", c.Request().URL.Path,
@@ -121,11 +121,11 @@ func Recovery(logger *slog.Logger) echo.MiddlewareFunc {
 					// - Clients don't need technical details; they just need to know something went wrong
 					//
 					// The detailed information is logged server-side where developers can access it.
-					// Note: We call c.JSON() directly rather than returning an error because we're
+					// Note: We call c.Error() directly rather than returning an error because we're
 					// in a deferred function that doesn't return a value to the normal control flow.
-					c.JSON(http.StatusInternalServerError, map[string]string{
-						"error": "Internal server error",
-					})
+					// c.Error() hands the 500 to the app's HTTPErrorHandler, which writes the
+					// branded error page (or JSON for API clients).
+					c.Error(echo.NewHTTPError(http.StatusInternalServerError))
 				}
 			}()
 
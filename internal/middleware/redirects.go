@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	// context carries request cancellation into rule lookups.
+	"context"
+
+	// net/http provides the request methods that are redirected.
+	"net/http"
+
+	// strings tells site paths from absolute URLs.
+	"strings"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// RedirectResolver looks up the redirect rule for a request path. It is
+// satisfied by services.Redirects.
+type RedirectResolver interface {
+	Resolve(ctx context.Context, path string) (target string, status int, ok bool)
+}
+
+// Redirects returns an Echo middleware that answers GET and HEAD requests
+// for a path with a redirect rule (a changed slug, or a rule added under
+// Admin > Redirects) with that rule's 301 or 302 before the page handler
+// runs. Site-path targets keep the visitor's locale prefix and, unless the
+// target has its own, the query string.
+//
+// Parameters:
+//   - redirects: Rule lookup, typically *services.Redirects
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that redirects matching requests
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.Redirects(redirectSvc))
+func Redirects(redirects RedirectResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+			target, status, ok := redirects.Resolve(req.Context(), req.URL.Path)
+			if !ok {
+				return next(c)
+			}
+			if strings.HasPrefix(target, "/") {
+				if code, _ := c.Get(localePrefixKey).(string); code != "" {
+					target = "/" + code + target
+				}
+				if req.URL.RawQuery != "" && !strings.Contains(target, "?") {
+					target += "?" + req.URL.RawQuery
+				}
+			}
+			return c.Redirect(status, target)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	// context carries the request ID to loggers and services.
+	"context"
+
+	// crypto/rand generates request IDs.
+	"crypto/rand"
+
+	// encoding/hex encodes generated IDs.
+	"encoding/hex"
+
+	// log/slog provides the handler that adds the ID to log records.
+	"log/slog"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// go.opentelemetry.io/otel/attribute labels the request span with the ID.
+	"go.opentelemetry.io/otel/attribute"
+
+	// go.opentelemetry.io/otel/trace finds the request span, if any.
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDMaxLen caps an incoming X-Request-ID; longer values are replaced.
+const requestIDMaxLen = 64
+
+// requestIDKey is the context key holding the request ID.
+type requestIDKey struct{}
+
+// RequestID returns an Echo middleware that gives every request an ID. An
+// X-Request-ID header set by a proxy or load balancer is kept when it is a
+// reasonable token (up to 64 letters, digits, '-', '_' or '.'); otherwise a
+// random ID is generated. The ID is echoed in the X-Request-ID response
+// header, labels the request's trace span, and is stored in the request
+// context, where ContextLogHandler adds it to every log record written with
+// a context method (logger.ErrorContext(c.Request().Context(), ...)).
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that assigns request IDs
+//
+// Example usage:
+//
+//	e.Use(middleware.RequestID())
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			trace.SpanFromContext(req.Context()).SetAttributes(attribute.String("request.id", id))
+			c.SetRequest(req.WithContext(context.WithValue(req.Context(), requestIDKey{}, id)))
+			return next(c)
+		}
+	}
+}
+
+// RequestIDFrom returns the ID RequestID assigned to the request ctx belongs
+// to, or "" outside a request.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID reports whether an incoming ID is safe to log and echo.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > requestIDMaxLen {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit hex ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// contextLogHandler adds the request ID from the record's context.
+type contextLogHandler struct {
+	slog.Handler
+}
+
+// ContextLogHandler wraps h so that records logged with a request context
+// (logger.InfoContext, ErrorContext, ...) carry a request_id attribute.
+// Records without one are passed through unchanged.
+//
+// Example usage:
+//
+//	logger := slog.New(middleware.ContextLogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+func ContextLogHandler(h slog.Handler) slog.Handler {
+	return contextLogHandler{Handler: h}
+}
+
+func (h contextLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFrom(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextLogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextLogHandler) WithGroup(name string) slog.Handler {
+	return contextLogHandler{Handler: h.Handler.WithGroup(name)}
+}
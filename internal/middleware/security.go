@@ -139,6 +139,10 @@ func SecurityHeaders() echo.MiddlewareFunc {
 			//   * https:: Any HTTPS source (allows loading images from external HTTPS URLs)
 			//     This is permissive but necessary for user-generated content and external images.
 			//
+			// - frame-src 'self' https://www.youtube-nocookie.com https://player.vimeo.com:
+			//   Allow iframes from the YouTube and Vimeo players, for product gallery videos
+			//   (services.VideoEmbedURL); uploaded videos fall under default-src 'self'.
+			//
 			// Production hardening recommendations:
 			// 1. Remove 'unsafe-inline' and 'unsafe-eval' by:
 			//    - Using a build process for Tailwind (no CDN)
@@ -147,7 +151,7 @@ func SecurityHeaders() echo.MiddlewareFunc {
 			// 2. Replace 'https:' in img-src with specific whitelisted domains
 			// 3. Add report-uri or report-to directives to monitor CSP violations
 			// 4. Consider adding frame-ancestors directive (redundant with X-Frame-Options but more flexible)
-			c.Response().Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline' 'unsafe-eval' cdn.tailwindcss.com cdn.jsdelivr.net fonts.googleapis.com; style-src 'self' 'unsafe-inline' fonts.googleapis.com cdn.tailwindcss.com; font-src 'self' fonts.gstatic.com; img-src 'self' data: https:;")
+			c.Response().Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline' 'unsafe-eval' cdn.tailwindcss.com cdn.jsdelivr.net fonts.googleapis.com; style-src 'self' 'unsafe-inline' fonts.googleapis.com cdn.tailwindcss.com; font-src 'self' fonts.gstatic.com; img-src 'self' data: https:; frame-src 'self' https://www.youtube-nocookie.com https://player.vimeo.com;")
 
 			// Proceed to the next handler in the middleware chain.
 			// The security headers are already set on the response and will be sent
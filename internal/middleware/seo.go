@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// github.com/narendhupati/bluejay-cms/db/sqlc provides the seo_meta row
+	// returned for a page.
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// seoKey is the context key holding the request's *seoPage.
+const seoKey = "seo"
+
+// SEOResolver looks up the metadata override for a page. It is satisfied
+// by services.SEO.
+type SEOResolver interface {
+	ForPath(path string) (sqlc.SeoMetum, bool)
+	ForEntity(entityType string, id int64) (sqlc.SeoMetum, bool)
+}
+
+// seoPage is what SEO and SetSEOEntity record about the page being served.
+type seoPage struct {
+	resolver   SEOResolver
+	path       string // Request path, without locale prefix
+	entityType string // Content type of a detail page, set by its handler
+	entityID   int64
+}
+
+// SEO returns an Echo middleware for the public route group that makes the
+// overrides set under Admin > SEO available to the renderer, which applies
+// them to every public page (read with PageSEOFrom). Detail page handlers
+// name the content item they show with SetSEOEntity, so overrides for a
+// product or post follow it when its slug changes.
+//
+// Parameters:
+//   - resolver: Override lookup, typically *services.SEO
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets c.Get("seo")
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.SEO(seoSvc))
+func SEO(resolver SEOResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(seoKey, &seoPage{resolver: resolver, path: c.Request().URL.Path})
+			return next(c)
+		}
+	}
+}
+
+// SetSEOEntity records that the page shows the content item id of
+// entityType (one of the services.Preview* types), so its override applies.
+// It does nothing outside the SEO middleware.
+func SetSEOEntity(c echo.Context, entityType string, id int64) {
+	if p, ok := c.Get(seoKey).(*seoPage); ok {
+		p.entityType, p.entityID = entityType, id
+	}
+}
+
+// PageSEOFrom returns the path of the page being served and its override:
+// the one for its path, else the one for the content item named with
+// SetSEOEntity, else an empty row. ok is false outside the SEO middleware
+// (admin pages).
+func PageSEOFrom(c echo.Context) (path string, meta sqlc.SeoMetum, ok bool) {
+	if c == nil {
+		return "", sqlc.SeoMetum{}, false
+	}
+	p, ok := c.Get(seoKey).(*seoPage)
+	if !ok {
+		return "", sqlc.SeoMetum{}, false
+	}
+	if meta, found := p.resolver.ForPath(p.path); found {
+		return p.path, meta, true
+	}
+	if p.entityType != "" {
+		meta, _ = p.resolver.ForEntity(p.entityType, p.entityID)
+	}
+	return p.path, meta, true
+}
@@ -39,6 +39,8 @@ import (
 //     the UI to personalize the experience (e.g., "Welcome, John").
 //   - Role: The user's role for authorization purposes (e.g., "admin", "editor", "viewer").
 //     Used by RequireRole() middleware and in templates to show/hide features.
+//   - Token: Random token identifying the server-side admin_sessions row created at login.
+//     SessionTracker signs the request out when the row no longer exists (revoked).
 //
 // The struct is stored in the Echo context under the key "session" and can be accessed
 // in handlers via: sess := c.Get("session").(*middleware.Session)
@@ -48,6 +50,7 @@ type Session struct {
 	Email             string     // User email address
 	DisplayName       string     // User display name
 	Role              string     // User role (admin, editor, etc.)
+	Token             string     // Server-side session token (see SessionTracker)
 }
 
 // SessionStore is a package-level variable holding the global CookieStore instance used
@@ -247,6 +250,12 @@ func SessionMiddleware() echo.MiddlewareFunc {
 				sess.Role = role
 			}
 
+			// Extract the server-side session token. SessionTracker checks it
+			// against admin_sessions so revoked sessions stop working.
+			if token, ok := session.Values["session_token"].(string); ok {
+				sess.Token = token
+			}
+
 			// Store the Session object in the Echo context so handlers and other middleware
 			// can access it via c.Get("session"). This is the primary way session data is
 			// accessed throughout the application.
@@ -312,6 +321,7 @@ func (s *Session) Save(r *http.Request, w http.ResponseWriter) error {
 	s.Values["email"] = s.Email
 	s.Values["display_name"] = s.DisplayName
 	s.Values["role"] = s.Role
+	s.Values["session_token"] = s.Token
 
 	// Call the gorilla Session.Save() method to persist the session data.
 	// This method:
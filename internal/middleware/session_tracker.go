@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	// crypto/rand generates unguessable session tokens.
+	"crypto/rand"
+
+	// encoding/hex encodes tokens for storage in the cookie and database.
+	"encoding/hex"
+
+	// time throttles last-seen updates and computes the stale-session cutoff.
+	"time"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// github.com/narendhupati/bluejay-cms/db/sqlc provides the admin_sessions queries.
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+)
+
+// sessionTouchInterval is how often a session's last_seen_at and IP address
+// are written back, so active admins do not cause a write on every request.
+const sessionTouchInterval = time.Minute
+
+// sessionExpiredKey marks a request whose session SessionTracker just ended
+// for exceeding a timeout, so the login redirect can say why.
+const sessionExpiredKey = "session_expired"
+
+// sessionExpiryKey is the Echo context key for the current SessionExpiry.
+const sessionExpiryKey = "session_expiry"
+
+// BackgroundRequestHeader marks a request the page sends on its own, such as
+// the edit-lock heartbeat or a dashboard card poll. SessionTracker does not
+// count these as activity, so an unattended tab still reaches the idle timeout.
+const BackgroundRequestHeader = "X-Background-Request"
+
+// IsBackgroundRequest reports whether the request carries BackgroundRequestHeader.
+func IsBackgroundRequest(c echo.Context) bool {
+	return c.Request().Header.Get(BackgroundRequestHeader) != ""
+}
+
+// SessionTimeouts bounds how long a signed-in admin session is accepted.
+// Both limits are enforced server-side by SessionTracker, independently of
+// the cookie lifetime. A zero duration disables that limit.
+type SessionTimeouts struct {
+	Idle     time.Duration // Sliding: sign out after this long without a request
+	Absolute time.Duration // Fixed: sign out this long after login, however active
+}
+
+// SessionExpiry is when the current session will be signed out, as computed
+// by SessionTracker for the request. Zero times mean the limit is disabled.
+type SessionExpiry struct {
+	Idle     time.Time // Deadline if no further request arrives
+	Absolute time.Time // Deadline regardless of activity
+}
+
+// SessionExpiryFrom returns the expiry SessionTracker stored for a signed-in
+// request, and false when there is none.
+func SessionExpiryFrom(c echo.Context) (SessionExpiry, bool) {
+	e, ok := c.Get(sessionExpiryKey).(SessionExpiry)
+	return e, ok
+}
+
+// NewSessionToken returns a random 256-bit token for a new admin_sessions row.
+func NewSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SessionMaxAge returns how long an idle session cookie stays valid, as
+// configured on SessionStore. Sessions idle longer than this can be pruned.
+func SessionMaxAge() time.Duration {
+	if SessionStore == nil || SessionStore.Options == nil {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(SessionStore.Options.MaxAge) * time.Second
+}
+
+// SessionTracker returns an Echo middleware that ties signed-in session
+// cookies to rows in admin_sessions. A cookie whose token has no row (the
+// session was revoked from the Active Sessions page, "log out everywhere" was
+// used, or the cookie predates session tracking) is treated as signed out for
+// the request, so RequireAuth sends the browser to the login page. For valid
+// sessions, last_seen_at and the client IP are refreshed at most once a minute.
+//
+// Sessions idle for longer than timeouts.Idle, or older than
+// timeouts.Absolute, are deleted and signed out the same way, and the login
+// redirect then carries ?error=session_expired. Because last_seen_at is
+// written at most once a minute, the idle limit is accurate to a minute.
+// Background requests (see BackgroundRequestHeader) are checked against both
+// limits but never refresh last_seen_at.
+//
+// Cookie sessions cannot be invalidated on their own (see InitSessionStore);
+// this lookup is what makes remote logout possible.
+//
+// It must run after SessionMiddleware.
+//
+// Parameters:
+//   - queries: Database queries for admin_sessions
+//   - timeouts: Idle and absolute session limits; zero values disable them
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for the whole application
+//
+// Example usage:
+//
+//	e.Use(middleware.SessionMiddleware())
+//	e.Use(middleware.SessionTracker(queries, middleware.SessionTimeouts{Idle: time.Hour}))
+func SessionTracker(queries *sqlc.Queries, timeouts SessionTimeouts) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			sess, ok := c.Get("session").(*Session)
+			if !ok || sess.UserID == 0 {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			if sess.Token == "" {
+				signOut(sess)
+				return next(c)
+			}
+			row, err := queries.GetAdminSessionByToken(ctx, sess.Token)
+			if err != nil || row.UserID != sess.UserID {
+				// Revoked, unknown, or unreadable: fail closed
+				signOut(sess)
+				return next(c)
+			}
+
+			now := time.Now()
+			expired := (timeouts.Idle > 0 && now.Sub(row.LastSeenAt) > timeouts.Idle) ||
+				(timeouts.Absolute > 0 && now.Sub(row.CreatedAt) > timeouts.Absolute)
+			if expired {
+				_ = queries.DeleteAdminSessionByToken(ctx, sess.Token)
+				signOut(sess)
+				c.Set(sessionExpiredKey, true)
+				return next(c)
+			}
+
+			// A background request is not activity: the session stays as
+			// idle as it was, and the deadline runs from the last real request
+			background := IsBackgroundRequest(c)
+			ip := c.RealIP()
+			if !background && (now.Sub(row.LastSeenAt) >= sessionTouchInterval || row.IpAddress != ip) {
+				_ = queries.TouchAdminSession(ctx, sqlc.TouchAdminSessionParams{IpAddress: ip, ID: row.ID})
+			}
+
+			var expiry SessionExpiry
+			if timeouts.Idle > 0 {
+				if background {
+					expiry.Idle = row.LastSeenAt.Add(timeouts.Idle)
+				} else {
+					// This request counts as activity, so the idle deadline restarts now
+					expiry.Idle = now.Add(timeouts.Idle)
+				}
+			}
+			if timeouts.Absolute > 0 {
+				expiry.Absolute = row.CreatedAt.Add(timeouts.Absolute)
+			}
+			c.Set(sessionExpiryKey, expiry)
+			return next(c)
+		}
+	}
+}
+
+// signOut clears the user fields for the current request only; the cookie is
+// left as is and is rejected again on the next request.
+func signOut(sess *Session) {
+	sess.UserID = 0
+	sess.Email = ""
+	sess.DisplayName = ""
+	sess.Role = ""
+	sess.Token = ""
+}
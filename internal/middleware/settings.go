@@ -1,12 +1,24 @@
 package middleware
 
 import (
+	// context carries request cancellation into the settings and footer queries.
+	"context"
+
+	// errors joins the errors of the separate queries into one.
+	"errors"
+
+	// fmt names the part that failed in each joined error.
+	"fmt"
+
 	// log/slog is Go's structured logging package, providing high-performance structured
 	// logging with support for key-value pairs. Used here to log non-fatal errors when
 	// loading settings or footer data fails, allowing the application to continue serving
 	// requests even if some template data is unavailable.
 	"log/slog"
 
+	// net/http provides the request methods and status codes of admin writes.
+	"net/http"
+
 	// github.com/labstack/echo/v4 is the Echo web framework, providing middleware
 	// interfaces, context objects for storing loaded data, and request context utilities.
 	"github.com/labstack/echo/v4"
@@ -15,6 +27,10 @@ import (
 	// methods generated by sqlc from SQL query files. The Queries object is used to
 	// fetch application settings and footer navigation data from the database.
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
+
+	// github.com/narendhupati/bluejay-cms/internal/models provides SiteGlobals,
+	// the settings and footer data shared by every public page.
+	"github.com/narendhupati/bluejay-cms/internal/models"
 )
 
 // SettingsLoader returns an Echo middleware that pre-loads application settings and footer
@@ -63,101 +79,168 @@ import (
 //	{{end}}
 //
 // Performance considerations:
-//   - Executes 4 database queries on every request (potential performance bottleneck)
-//   - For high-traffic sites, consider adding caching (in-memory or Redis)
-//   - Footer data rarely changes, so aggressive caching (5-60 minutes) is appropriate
-//   - Settings data might change more frequently (requires shorter cache TTL)
-//   - Consider adding a cache invalidation mechanism when settings/footer data is updated
+//   - Executes 4 database queries on every request
+//   - The server uses SiteDataLoader instead, which serves the same data from an
+//     in-memory snapshot (services.SiteData) that admin changes invalidate;
+//     SettingsLoader suits tests and tools that need every change at once
 //
 // Data loaded and context keys:
 //   - "settings": Application-wide settings (sqlc.Setting)
-//   - "footer_categories": Product categories for footer navigation ([]sqlc.ProductCategory)
-//   - "footer_solutions": Published solution pages for footer navigation ([]sqlc.Solution)
+//   - "footer_categories": Product categories for footer navigation ([]sqlc.ProductCategory,
+//     ProductCount set to the number of published products in each)
+//   - "footer_solutions": Published solution pages for footer navigation ([]sqlc.ListPublishedSolutionsRow)
 //   - "footer_resources": Custom footer resource links ([]sqlc.PageSection)
 func SettingsLoader(queries *sqlc.Queries) echo.MiddlewareFunc {
+	return SiteDataLoader(QuerySiteData(queries))
+}
+
+// SiteDataSource provides the settings and footer data of public pages. It is
+// satisfied by services.SiteData (cached) and QuerySiteData (per call).
+type SiteDataSource interface {
+	Get(ctx context.Context) (models.SiteGlobals, error)
+}
+
+// QuerySiteData returns a SiteDataSource that queries the database on every
+// Get: the settings row, the product categories with their published product
+// counts, the published solutions and the footer page sections, one query
+// each. Parts that fail to load are left nil and their errors are joined.
+//
+// Example usage:
+//
+//	siteData := services.NewSiteData(middleware.QuerySiteData(queries))
+func QuerySiteData(queries sqlc.Querier) SiteDataSource {
+	return querySiteData{queries: queries}
+}
+
+// querySiteData is the SiteDataSource of QuerySiteData.
+type querySiteData struct {
+	queries sqlc.Querier
+}
+
+// Get implements SiteDataSource.
+func (q querySiteData) Get(ctx context.Context) (models.SiteGlobals, error) {
+	var g models.SiteGlobals
+	var errs []error
+
+	// Load application-wide settings from the database.
+	// Settings typically include: site name, logo URL, contact email, social media
+	// links, analytics IDs, and other configuration that affects the entire site.
+	//
+	// GetSettings() executes a SELECT query (defined in db/queries/settings.sql)
+	// that retrieves a single row from the settings table. Most applications have
+	// only one settings row (global configuration).
+	if settings, err := q.queries.GetSettings(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("settings: %w", err))
+	} else {
+		g.Settings = &settings
+	}
+
+	// Load product categories for the footer navigation.
+	// Categories are typically organized hierarchically (e.g., Electronics > Laptops)
+	// and are displayed in the footer to help users discover products.
+	//
+	// ListProductCategoriesWithCounts() retrieves all product categories in
+	// display order together with their published product counts, in one
+	// grouped query rather than one count query per category.
+	if rows, err := q.queries.ListProductCategoriesWithCounts(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("footer categories: %w", err))
+	} else {
+		// ProductCount carries the live published count, so templates can
+		// show it or skip empty categories.
+		g.FooterCategories = make([]sqlc.ProductCategory, len(rows))
+		for i, row := range rows {
+			g.FooterCategories[i] = row.ProductCategory
+			g.FooterCategories[i].ProductCount = row.PublishedCount
+		}
+	}
+
+	// Load published solution pages for the footer navigation.
+	// ListPublishedSolutions() retrieves only solutions with published=true, ensuring
+	// that draft or unpublished solutions don't appear in the footer navigation.
+	if solutions, err := q.queries.ListPublishedSolutions(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("footer solutions: %w", err))
+	} else {
+		g.FooterSolutions = solutions
+	}
+
+	// Load custom footer resource links (e.g., "About Us", "Privacy Policy", "Help").
+	// The "footer" page section is a special section type used to store custom footer
+	// links that don't fit into categories or solutions (informational pages, legal pages).
+	if resources, err := q.queries.ListPageSections(ctx, "footer"); err != nil {
+		errs = append(errs, fmt.Errorf("footer resources: %w", err))
+	} else {
+		g.FooterResources = resources
+	}
+
+	return g, errors.Join(errs...)
+}
+
+// SiteDataLoader returns an Echo middleware that stores the settings and
+// footer data of site in the context under the keys listed on SettingsLoader.
+// Parts that failed to load are logged and left unset, so the page still
+// renders with the rest.
+//
+// Parameters:
+//   - site: Settings and footer data, typically *services.SiteData
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets "settings" and the footer keys
+//
+// Example usage:
+//
+//	publicGroup.Use(middleware.SiteDataLoader(siteData))
+func SiteDataLoader(site SiteDataSource) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Extract the request context, which carries deadlines, cancellation signals,
-			// and request-scoped values. This context is passed to database queries to
-			// enable request cancellation and timeout handling at the database level.
-			ctx := c.Request().Context()
-
-			// Load application-wide settings from the database.
-			// Settings typically include: site name, logo URL, contact email, social media
-			// links, analytics IDs, and other configuration that affects the entire site.
-			//
-			// GetSettings() executes a SELECT query (defined in db/queries/settings.sql)
-			// that retrieves a single row from the settings table. Most applications have
-			// only one settings row (global configuration).
-			settings, err := queries.GetSettings(ctx)
+			g, err := site.Get(c.Request().Context())
 			if err != nil {
-				// Log a warning if settings fail to load, but don't fail the request.
-				// This allows the site to remain operational even if the settings table
-				// is temporarily unavailable or misconfigured. Templates should check
-				// for the existence of settings data before using it.
-				//
-				// Uses structured logging with key-value pairs for better log aggregation
-				// and filtering in production log management systems.
-				slog.Warn("settings middleware: failed to load settings", "error", err)
-			} else {
-				// Store the loaded settings in the Echo context under the key "settings".
-				// Templates and handlers can access this via {{.settings}} or c.Get("settings").
-				c.Set("settings", settings)
+				// Log a warning but continue. Non-critical data loading should never
+				// break the request; templates handle missing data gracefully.
+				slog.Warn("settings middleware: failed to load site data", "error", err)
 			}
-
-			// Load product categories for the footer navigation.
-			// Categories are typically organized hierarchically (e.g., Electronics > Laptops)
-			// and are displayed in the footer to help users discover products.
-			//
-			// ListProductCategories() executes a SELECT query that retrieves all active
-			// product categories, typically ordered by display order or name.
-			categories, err := queries.ListProductCategories(ctx)
-			if err != nil {
-				// Log a warning but continue. The footer will render without categories
-				// if this fails. Templates should handle empty slices gracefully.
-				slog.Warn("settings middleware: failed to load footer categories", "error", err)
-			} else {
-				// Store the categories in the context for template access.
-				// Templates can iterate over this with {{range .footer_categories}}...{{end}}.
-				c.Set("footer_categories", categories)
+			// Templates and handlers can access these via {{.settings}} or c.Get("settings").
+			if g.Settings != nil {
+				c.Set("settings", *g.Settings)
 			}
-
-			// Load published solution pages for the footer navigation.
-			// Solutions are typically case studies, use cases, or solution-oriented content
-			// pages (e.g., "E-commerce Solutions", "Enterprise Solutions").
-			//
-			// ListPublishedSolutions() retrieves only solutions with published=true, ensuring
-			// that draft or unpublished solutions don't appear in the footer navigation.
-			solutions, err := queries.ListPublishedSolutions(ctx)
-			if err != nil {
-				// Log a warning but continue. The footer will render without solutions
-				// if this fails. Non-critical data loading should never break the request.
-				slog.Warn("settings middleware: failed to load footer solutions", "error", err)
-			} else {
-				// Store the solutions in the context for template access.
-				c.Set("footer_solutions", solutions)
+			if g.FooterCategories != nil {
+				c.Set("footer_categories", g.FooterCategories)
 			}
-
-			// Load custom footer resource links (e.g., "About Us", "Privacy Policy", "Help").
-			// The "footer" page section is a special section type used to store custom footer
-			// links that don't fit into categories or solutions (informational pages, legal pages).
-			//
-			// ListPageSections() executes a SELECT query filtered by section_type="footer",
-			// returning all page sections designated for footer display.
-			resources, err := queries.ListPageSections(ctx, "footer")
-			if err != nil {
-				// Log a warning but continue. The footer will render without custom resource
-				// links if this fails. Common footer links (categories, solutions) will still work.
-				slog.Warn("settings middleware: failed to load footer resources", "error", err)
-			} else {
-				// Store the footer resources in the context for template access.
-				c.Set("footer_resources", resources)
+			if g.FooterSolutions != nil {
+				c.Set("footer_solutions", g.FooterSolutions)
+			}
+			if g.FooterResources != nil {
+				c.Set("footer_resources", g.FooterResources)
 			}
-
-			// All data loading is complete (successfully or with warnings logged).
-			// Proceed to the next handler in the middleware chain. The loaded data is
-			// now available in the Echo context for use by handlers and templates.
 			return next(c)
 		}
 	}
 }
+
+// SiteDataInvalidator is told when the admin panel saves a change. It is
+// satisfied by services.SiteData.
+type SiteDataInvalidator interface {
+	Invalidate()
+}
+
+// InvalidateSiteData returns an Echo middleware for the admin route group
+// that invalidates site after every successful write (any method other than
+// GET and HEAD that does not fail), so the next public page loads the
+// settings and footer data again. Settings, categories, solutions and footer
+// sections are edited through many handlers; invalidating on any write keeps
+// them from having to know about the snapshot.
+//
+// Example usage:
+//
+//	adminGroup.Use(middleware.InvalidateSiteData(siteData))
+func InvalidateSiteData(site SiteDataInvalidator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			method := c.Request().Method
+			if err == nil && method != http.MethodGet && method != http.MethodHead && c.Response().Status < http.StatusBadRequest {
+				site.Invalidate()
+			}
+			return err
+		}
+	}
+}
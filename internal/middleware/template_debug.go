@@ -0,0 +1,264 @@
+package middleware
+
+import (
+	// bytes provides the buffer that holds the response until the overlay is added.
+	"bytes"
+
+	// fmt formats data key types and the overlay rows.
+	"fmt"
+
+	// html escapes template names and keys written into the overlay.
+	"html"
+
+	// net/http provides the ResponseWriter being wrapped and status codes.
+	"net/http"
+
+	// reflect lists the fields of struct template data.
+	"reflect"
+
+	// sort orders map keys so the overlay is stable between requests.
+	"sort"
+
+	// strings is used to locate </body> and build the overlay.
+	"strings"
+
+	// sync guards the render list, which concurrent renders may append to.
+	"sync"
+
+	// sync/atomic holds the per-request query counter.
+	"sync/atomic"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// database provides the per-request query counter.
+	"github.com/narendhupati/bluejay-cms/internal/database"
+)
+
+// templateDebugKey is the Echo context key holding the request's *TemplateDebugInfo.
+const templateDebugKey = "template_debug"
+
+// TemplateRender describes one template rendered during a request.
+type TemplateRender struct {
+	Name     string   // Template path, e.g. "public/pages/blog_post.html"
+	DataKeys []string // Top-level data keys with their Go types, e.g. "Post (sqlc.BlogPost)"
+}
+
+// TemplateDebugInfo collects what a request rendered for the ?debug=templates
+// overlay. Renderers and handlers record into it; all methods are no-ops on a
+// nil receiver so callers need not check whether debugging is active.
+type TemplateDebugInfo struct {
+	mu       sync.Mutex
+	renders  []TemplateRender
+	cacheKey string        // Page cache key the response would be stored under
+	cacheTTL int           // Page cache TTL in seconds
+	queries  *atomic.Int64 // SQL statements run with the request context
+}
+
+// TemplateDebugFrom returns the debug collector for the request, or nil when
+// the request is not a ?debug=templates request from an admin.
+func TemplateDebugFrom(c echo.Context) *TemplateDebugInfo {
+	if c == nil {
+		return nil
+	}
+	info, _ := c.Get(templateDebugKey).(*TemplateDebugInfo)
+	return info
+}
+
+// RecordRender notes a template execution and the top-level keys of its data.
+func (d *TemplateDebugInfo) RecordRender(name string, data interface{}) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.renders = append(d.renders, TemplateRender{Name: name, DataKeys: dataKeys(data)})
+}
+
+// RecordCache notes the page cache key and TTL used for the response.
+func (d *TemplateDebugInfo) RecordCache(key string, ttlSeconds int) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cacheKey, d.cacheTTL = key, ttlSeconds
+}
+
+// dataKeys lists the keys of map data or the exported fields of struct data,
+// each with its Go type.
+func dataKeys(data interface{}) []string {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	var keys []string
+	switch v.Kind() {
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			keys = append(keys, fmt.Sprintf("%v (%s)", iter.Key(), typeName(iter.Value())))
+		}
+		sort.Strings(keys)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				keys = append(keys, fmt.Sprintf("%s (%s)", t.Field(i).Name, t.Field(i).Type))
+			}
+		}
+	case reflect.Invalid:
+	default:
+		keys = append(keys, "(no keys: "+v.Type().String()+")")
+	}
+	return keys
+}
+
+// typeName returns the dynamic type of a map value ("nil" for nil interfaces).
+func typeName(v reflect.Value) string {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "nil"
+		}
+		v = v.Elem()
+	}
+	return v.Type().String()
+}
+
+// debugWriter holds the response body so the overlay can be added before it
+// is sent.
+type debugWriter struct {
+	http.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+func (w *debugWriter) WriteHeader(code int) { w.status = code }
+
+func (w *debugWriter) Write(b []byte) (int, error) { return w.buf.Write(b) }
+
+// TemplateDebug returns an Echo middleware that, for signed-in admins who add
+// ?debug=templates to a URL, appends a collapsible overlay listing the
+// templates rendered, their data keys, the page cache key and TTL, and the
+// number of SQL queries the request ran. HTMX requests get the same report as
+// an HTML comment so swapped fragments are not disturbed.
+//
+// Debug requests skip page cache reads (see the public handlers' cachedPage),
+// so the report always reflects a fresh render. The overlay itself is never
+// cached. Requests from anyone else pass through untouched.
+//
+// It must run after SessionMiddleware. Query counting requires the sqlc
+// queries to be built on database.CountingDB.
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware for the whole application
+//
+// Example usage:
+//
+//	e.Use(middleware.TemplateDebug())
+func TemplateDebug() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.QueryParam("debug") != "templates" {
+				return next(c)
+			}
+			sess, ok := c.Get("session").(*Session)
+			if !ok || sess.UserID == 0 || sess.Role != "admin" {
+				return next(c)
+			}
+
+			ctx, queries := database.WithQueryCounter(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+			info := &TemplateDebugInfo{queries: queries}
+			c.Set(templateDebugKey, info)
+
+			res := c.Response()
+			w := &debugWriter{ResponseWriter: res.Writer, status: http.StatusOK}
+			res.Writer = w
+			err := next(c)
+			res.Writer = w.ResponseWriter
+
+			// Errors are rendered by Echo's error handler after this returns;
+			// pass through whatever was written and let it respond.
+			if err != nil && w.buf.Len() == 0 {
+				return err
+			}
+
+			body := w.buf.String()
+			if strings.HasPrefix(res.Header().Get(echo.HeaderContentType), echo.MIMETextHTML) {
+				htmx := c.Request().Header.Get("HX-Request") != ""
+				body = injectDebugReport(body, info.report(htmx))
+			}
+			res.Header().Set("Cache-Control", "no-store")
+			res.Header().Del(echo.HeaderContentLength)
+			w.ResponseWriter.WriteHeader(w.status)
+			_, werr := w.ResponseWriter.Write([]byte(body))
+			if err != nil {
+				return err
+			}
+			return werr
+		}
+	}
+}
+
+// injectDebugReport places report before the closing </body> tag, or at the
+// end of fragments.
+func injectDebugReport(page, report string) string {
+	if i := strings.LastIndex(page, "</body>"); i >= 0 {
+		return page[:i] + report + page[i:]
+	}
+	return page + report
+}
+
+// report formats the collected information as an overlay, or as an HTML
+// comment block for HTMX fragments.
+func (d *TemplateDebugInfo) report(comment bool) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cache := "not cached"
+	if d.cacheKey != "" {
+		cache = fmt.Sprintf("%s (TTL %ds)", d.cacheKey, d.cacheTTL)
+	}
+	queries := d.queries.Load()
+
+	if comment {
+		var b strings.Builder
+		b.WriteString("\n<!-- debug=templates\n")
+		fmt.Fprintf(&b, "  cache: %s\n  queries: %d\n", commentSafe(cache), queries)
+		for _, r := range d.renders {
+			fmt.Fprintf(&b, "  template: %s\n", commentSafe(r.Name))
+			for _, k := range r.DataKeys {
+				fmt.Fprintf(&b, "    %s\n", commentSafe(k))
+			}
+		}
+		b.WriteString("-->\n")
+		return b.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(`<details id="template-debug" style="position:fixed;bottom:16px;right:16px;z-index:99999;max-width:480px;max-height:70vh;overflow:auto;background:#fff;color:#000;border:2px solid #000;box-shadow:4px 4px 0 #000;font:12px/1.5 monospace;">`)
+	fmt.Fprintf(&b, `<summary style="cursor:pointer;padding:8px 12px;font-weight:bold;text-transform:uppercase;background:#FEF3C7;">Template debug &middot; %d template(s) &middot; %d queries</summary>`, len(d.renders), queries)
+	b.WriteString(`<div style="padding:8px 12px;">`)
+	fmt.Fprintf(&b, `<p style="margin:0 0 8px;"><strong>Cache:</strong> %s</p>`, html.EscapeString(cache))
+	if len(d.renders) == 0 {
+		b.WriteString(`<p style="margin:0;">No templates rendered.</p>`)
+	}
+	for _, r := range d.renders {
+		fmt.Fprintf(&b, `<p style="margin:8px 0 4px;font-weight:bold;">%s</p><ul style="margin:0;padding-left:16px;">`, html.EscapeString(r.Name))
+		for _, k := range r.DataKeys {
+			fmt.Fprintf(&b, `<li>%s</li>`, html.EscapeString(k))
+		}
+		b.WriteString(`</ul>`)
+	}
+	b.WriteString(`</div></details>`)
+	return b.String()
+}
+
+// commentSafe keeps values from terminating the HTML comment early.
+func commentSafe(s string) string {
+	return strings.ReplaceAll(s, "--", "- -")
+}
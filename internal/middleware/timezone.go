@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+)
+
+// visitorTimezoneKey is the context key under which VisitorTimezone stores
+// the guessed zone name.
+const visitorTimezoneKey = "visitor_timezone"
+
+// VisitorTimezone returns an Echo middleware that guesses the visitor's time
+// zone from the Accept-Language header and stores the IANA name in the
+// context under "visitor_timezone" (nothing is stored when guess returns "").
+// The renderer passes it to templates as .VisitorTimezone for formatDateIn.
+//
+// Only use it where responses are not shared between visitors: public pages
+// are cached per URL, so they display the site timezone instead.
+//
+// Parameters:
+//   - guess: Maps an Accept-Language header to a zone name, such as
+//     services.TimezoneFromAcceptLanguage
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that sets c.Get("visitor_timezone")
+//
+// Example usage:
+//
+//	adminGroup.Use(middleware.VisitorTimezone(services.TimezoneFromAcceptLanguage))
+func VisitorTimezone(guess func(acceptLanguage string) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if zone := guess(c.Request().Header.Get("Accept-Language")); zone != "" {
+				c.Set(visitorTimezoneKey, zone)
+			}
+			return next(c)
+		}
+	}
+}
+
+// VisitorTimezoneFrom returns the zone stored by VisitorTimezone, or "".
+func VisitorTimezoneFrom(c echo.Context) string {
+	zone, _ := c.Get(visitorTimezoneKey).(string)
+	return zone
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	// net/http provides status codes.
+	"net/http"
+
+	// github.com/labstack/echo/v4 provides the middleware and context types.
+	"github.com/labstack/echo/v4"
+
+	// go.opentelemetry.io/otel provides the global trace context propagator.
+	"go.opentelemetry.io/otel"
+
+	// go.opentelemetry.io/otel/codes marks server errors on the span.
+	"go.opentelemetry.io/otel/codes"
+
+	// go.opentelemetry.io/otel/propagation reads traceparent from request headers.
+	"go.opentelemetry.io/otel/propagation"
+
+	// go.opentelemetry.io/otel/semconv/v1.34.0 provides the standard HTTP attribute keys.
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+
+	// go.opentelemetry.io/otel/trace provides span options.
+	"go.opentelemetry.io/otel/trace"
+
+	// github.com/narendhupati/bluejay-cms/internal/tracing provides the app tracer.
+	"github.com/narendhupati/bluejay-cms/internal/tracing"
+)
+
+// Tracing returns an Echo middleware that starts a server span for each
+// request, named after the matched route ("GET /products/:category/:slug")
+// and continuing the caller's trace when the request carries a traceparent
+// header. The span is stored in the request context, so spans started by
+// handlers, services, templates and database.TracingDB nest under it.
+// Responses with a 5xx status mark the span as failed.
+//
+// When tracing is off (see tracing.Setup) the global no-op provider makes
+// this middleware nearly free.
+//
+// Returns:
+//   - echo.MiddlewareFunc: Middleware that traces requests
+//
+// Example usage:
+//
+//	e.Use(middleware.Tracing())
+func Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			route := c.Path()
+			ctx, span := tracing.Tracer().Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			status := responseStatus(c, err)
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return err
+		}
+	}
+}
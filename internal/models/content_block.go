@@ -0,0 +1,40 @@
+package models
+
+// ContentBlock is a reusable block attached to a public page under
+// Admin > Content Blocks, as rendered below the page's own content (see
+// services.ContentBlocks). Only the fields of its type are set.
+type ContentBlock struct {
+	// Type is "rich_text", "cta", "stats" or "logo_wall".
+	Type string
+
+	// Heading is shown above the block, or empty.
+	Heading string
+
+	// Body is sanitized HTML for rich text and plain text for a call to
+	// action.
+	Body string
+
+	// ButtonText and ButtonURL are the button of a call to action; there is
+	// no button when either is empty.
+	ButtonText string
+	ButtonURL  string
+
+	// Stats are the figures of a stats strip, in display order.
+	Stats []BlockStat
+
+	// Logos are the logos of a logo wall, in display order.
+	Logos []BlockLogo
+}
+
+// BlockStat is one figure of a stats strip, such as "500+" "Customers".
+type BlockStat struct {
+	Value string
+	Label string
+}
+
+// BlockLogo is one logo of a logo wall.
+type BlockLogo struct {
+	Image string // Image path or URL
+	Alt   string // Alt text, usually the company name
+	URL   string // Link target, or empty for no link
+}
@@ -0,0 +1,51 @@
+package models
+
+// Form is a custom lead form built under Admin > Forms, as rendered by the
+// "custom-form" partial (see services.BuildForm). After a failed submission
+// its fields carry the visitor's answers and their errors.
+type Form struct {
+	// Slug names the form in /forms/<slug> and its submit endpoint.
+	Slug string
+
+	// Name is the form heading; Description the text above the fields, or
+	// empty.
+	Name        string
+	Description string
+
+	// SubmitLabel is the submit button label.
+	SubmitLabel string
+
+	// Fields are the form's inputs in display order.
+	Fields []FormField
+
+	// Success is the form's success message once it has been submitted;
+	// the partial then shows it instead of the fields.
+	Success string
+}
+
+// FormField is one input of a Form.
+type FormField struct {
+	Name        string   // Form value key, unique within the form
+	Label       string   // Label shown above the input
+	Type        string   // text, email, tel, number, textarea, select or checkbox
+	Placeholder string   // Hint inside the input, or empty
+	HelpText    string   // Hint below the input, or empty
+	Options     []string // Choices of a select
+	Required    bool     // Whether the field must be answered
+	MinLength   int64    // Fewest characters accepted, 0 for no minimum
+	MaxLength   int64    // Most characters accepted, 0 for no maximum
+
+	// Value is the submitted answer and Error why it was refused, both
+	// empty until the form is submitted.
+	Value string
+	Error string
+}
+
+// FormAnswer is one answer of a stored form submission. Submissions keep
+// the label and type the field had when the form was sent.
+type FormAnswer struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
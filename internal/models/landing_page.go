@@ -0,0 +1,41 @@
+package models
+
+// LandingSection is one section of a campaign landing page under
+// Admin > Landing Pages, as rendered by public/pages/landing_page.html (see
+// services.LandingSections). Only the fields of its type are set.
+type LandingSection struct {
+	// Type is "hero", "text", "form" or "testimonials".
+	Type string
+
+	// Heading is the hero headline or the section heading, or empty.
+	Heading string
+
+	// Body is sanitized HTML for text and plain text for the other types
+	// (the hero subheading, the text above a form).
+	Body string
+
+	// Image is the hero image path or URL, or empty.
+	Image string
+
+	// ButtonText is the hero button or the form's submit label; ButtonURL
+	// is the hero button target. A hero has no button when either is empty.
+	ButtonText string
+	ButtonURL  string
+
+	// FormSlug names the custom form (Admin > Forms) of a form section, or
+	// is empty for the built-in lead form; Form is that form once loaded,
+	// nil when it is missing or inactive.
+	FormSlug string
+	Form     *Form
+
+	// Testimonials are the quotes of a testimonials section, in display
+	// order.
+	Testimonials []LandingTestimonial
+}
+
+// LandingTestimonial is one quote of a testimonials section.
+type LandingTestimonial struct {
+	Quote string
+	Name  string // Who said it
+	Role  string // Job title and company, or empty
+}
@@ -0,0 +1,50 @@
+package models
+
+// MenuItem is one link of a navigation menu as rendered on the public site.
+// It is built from the navigation_items of an active menu (see
+// services.Navigation), with page links resolved to their paths and child
+// items nested under their dropdown or parent link.
+type MenuItem struct {
+	// Label is the link text.
+	Label string
+
+	// URL is the link target: a site path such as "/products" or an absolute
+	// URL. It is empty for dropdown items, which only open their children.
+	URL string
+
+	// NewTab opens the link in a new browser tab. External links always do.
+	NewTab bool
+
+	// External marks links to other sites (absolute http(s) URLs), which
+	// templates render with rel="noopener".
+	External bool
+
+	// Visibility limits the item to one layout: "all", "desktop" (the header
+	// bar and wide footers) or "mobile" (the mobile menu and narrow footers).
+	Visibility string
+
+	// Icon is a Material Symbols icon name shown before the label, or empty.
+	Icon string
+
+	// Mega opens the item's children as the columns of a wide panel instead
+	// of a dropdown list; each column lists its own children.
+	Mega bool
+
+	// Featured is the content card shown in the item's dropdown or mega
+	// panel, or nil. Unpublished and deleted content is left out.
+	Featured *FeaturedCard
+
+	// Children are the items of a dropdown, in display order. They may have
+	// children of their own.
+	Children []MenuItem
+}
+
+// FeaturedCard is a product, solution, blog post or case study promoted in a
+// navigation dropdown.
+type FeaturedCard struct {
+	Kind    string // Content type label, e.g. "Product"
+	Title   string
+	Summary string
+	Image   string // Image path or URL, or empty
+	URL     string // Public page of the content
+}
@@ -0,0 +1,22 @@
+package models
+
+import "github.com/narendhupati/bluejay-cms/db/sqlc"
+
+// SiteGlobals is the data every public page renders around its content:
+// the global settings and the footer links. It is loaded by
+// middleware.QuerySiteData, cached by services.SiteData and set on each
+// public request by the SiteDataLoader middleware.
+type SiteGlobals struct {
+	// Settings is the global settings row, or nil when it failed to load.
+	Settings *sqlc.Setting
+
+	// FooterCategories are the product categories in display order, with
+	// ProductCount set to the number of published products in each.
+	FooterCategories []sqlc.ProductCategory
+
+	// FooterSolutions are the published solutions.
+	FooterSolutions []sqlc.ListPublishedSolutionsRow
+
+	// FooterResources are the page sections of the "footer" page.
+	FooterResources []sqlc.PageSection
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Database calls
+	"fmt"     // Heading details
+	"strings" // Joining heading problems
+
+	// Third-party imports
+	"golang.org/x/net/html" // Rich-text parsing for images and headings
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// AccessibilityIssue is one line of the accessibility report.
+type AccessibilityIssue struct {
+	Label   string // Content type, e.g. "Blog post"
+	Title   string // Title of the item the problem is in
+	Field   string // Rich-text field, empty for standalone images
+	Image   string // Image URL, for missing alt text
+	Detail  string // What is wrong with the headings
+	EditURL string // Admin page that fixes the problem
+}
+
+// AccessibilityReport is the result of Accessibility.Audit.
+type AccessibilityReport struct {
+	MissingAlt []AccessibilityIssue // Images without alt text
+	Headings   []AccessibilityIssue // Rich-text fields with headings out of order
+}
+
+// Accessibility audits the site content for images without alt text and
+// rich text whose headings are out of order. Alt text is missing when it is
+// empty or blank, the same rule the publish checklist applies. Headings are
+// out of order when a field uses an h1 (the page title is already the h1) or
+// skips a level going down, e.g. an h2 followed by an h4.
+type Accessibility struct {
+	queries *sqlc.Queries // Images and rich-text fields
+}
+
+// NewAccessibility creates the accessibility audit.
+func NewAccessibility(queries *sqlc.Queries) *Accessibility {
+	return &Accessibility{queries: queries}
+}
+
+// Audit checks gallery, hero, testimonial and library images for alt text,
+// and every rich-text field for images without alt text and headings out of
+// order. Trashed content is skipped.
+//
+// Returns:
+//   - *AccessibilityReport: The problems found, grouped by kind
+//   - error: Database error
+func (a *Accessibility) Audit(ctx context.Context) (*AccessibilityReport, error) {
+	report := &AccessibilityReport{}
+
+	images, err := a.queries.ListImagesMissingAlt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range images {
+		report.MissingAlt = append(report.MissingAlt, AccessibilityIssue{
+			Label:   contentLabels[img.Source],
+			Title:   img.OwnerTitle,
+			Image:   img.ImagePath,
+			EditURL: contentEditURL(img.Source, img.OwnerID, 0, img.OwnerTitle),
+		})
+	}
+
+	fields, err := a.queries.ListRichContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		issue := AccessibilityIssue{
+			Label:   contentLabels[f.Source],
+			Title:   f.OwnerTitle,
+			Field:   f.Field,
+			EditURL: contentEditURL(f.Source, f.OwnerID, f.ParentID, f.OwnerTitle),
+		}
+		missingAlt, headings := auditRichText(f.Html)
+		for _, src := range missingAlt {
+			img := issue
+			img.Image = src
+			report.MissingAlt = append(report.MissingAlt, img)
+		}
+		if len(headings) > 0 {
+			issue.Detail = strings.Join(headings, "; ")
+			report.Headings = append(report.Headings, issue)
+		}
+	}
+	return report, nil
+}
+
+// auditRichText returns the sources of the images in fragment without alt
+// text, and a description of each heading that is out of order.
+func auditRichText(fragment string) ([]string, []string) {
+	var missingAlt, headings []string
+	prev := 0
+	z := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+		tok := z.Token()
+		if tok.Data == "img" {
+			if strings.TrimSpace(attr(tok, "alt")) == "" {
+				missingAlt = append(missingAlt, attr(tok, "src"))
+			}
+			continue
+		}
+		level := headingLevel(tok.Data)
+		switch {
+		case level == 0:
+			continue
+		case level == 1:
+			headings = append(headings, "h1 used; the page title is already the h1")
+		case prev > 0 && level > prev+1:
+			headings = append(headings, fmt.Sprintf("h%d follows h%d", level, prev))
+		case prev == 0 && level > 2:
+			headings = append(headings, fmt.Sprintf("starts at h%d instead of h2", level))
+		}
+		prev = level
+	}
+	return missingAlt, headings
+}
+
+// headingLevel returns 1-6 for the heading tags h1-h6, and 0 otherwise.
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
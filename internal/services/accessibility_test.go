@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestAccessibilityAudit seeds images with and without alt text and rich
+// text with good and bad headings, and checks what the report lists.
+func TestAccessibilityAudit(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Ada", Slug: "ada", Title: "Editor"})
+	post := func(title, body string) sqlc.BlogPost {
+		p, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: title, Slug: strings.ToLower(title), Excerpt: "e", Body: body,
+			CategoryID: cat.ID, AuthorID: author.ID, Status: "draft",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	post("Good", `<h2>Intro</h2><p>x</p><h3>Detail</h3><h2>Next</h2><img src="/a.png" alt="A chart">`)
+	bad := post("Bad", `<h1>Title again</h1><h2>Intro</h2><h4>Skipped</h4><img src="/b.png"><img src="/c.png" alt=" ">`)
+	trashed := post("Trashed", `<h1>Gone</h1><img src="/d.png">`)
+	db.Exec(`UPDATE blog_posts SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, trashed.ID)
+
+	pcat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cat", Slug: "cat", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "A-1", Slug: "a-1", Name: "Scanner", Description: "d", CategoryID: pcat.ID, Status: "draft"})
+	queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: prod.ID, ImagePath: "/uploads/products/front.jpg"})
+	queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: prod.ID, ImagePath: "/uploads/products/back.jpg", AltText: sql.NullString{String: "Back", Valid: true}})
+	queries.CreateHero(ctx, sqlc.CreateHeroParams{Headline: "Welcome", BackgroundImage: sql.NullString{String: "/hero.jpg", Valid: true}})
+	queries.CreateHero(ctx, sqlc.CreateHeroParams{Headline: "Plain"})
+	queries.CreateMediaFile(ctx, sqlc.CreateMediaFileParams{Filename: "1_logo.png", OriginalFilename: "logo.png", FilePath: "/uploads/media/1_logo.png", MimeType: "image/png", AltText: sql.NullString{Valid: true}})
+	queries.CreateMediaFile(ctx, sqlc.CreateMediaFileParams{Filename: "2_spec.pdf", OriginalFilename: "spec.pdf", FilePath: "/uploads/media/2_spec.pdf", MimeType: "application/pdf"})
+
+	report, err := services.NewAccessibility(queries).Audit(ctx)
+	if err != nil {
+		t.Fatalf("Audit: %v", err)
+	}
+
+	var alt []string
+	for _, i := range report.MissingAlt {
+		alt = append(alt, i.Label+" "+i.Title+" "+i.Image+" "+i.EditURL)
+	}
+	want := []string{
+		"Homepage hero Welcome /hero.jpg /admin/homepage/heroes/1/edit",
+		"Media library logo.png /uploads/media/1_logo.png /admin/media?search=logo.png",
+		"Product image Scanner /uploads/products/front.jpg /admin/products/1/images",
+		fmt.Sprintf("Blog post Bad /b.png /admin/blog/posts/%d/edit", bad.ID),
+		fmt.Sprintf("Blog post Bad /c.png /admin/blog/posts/%d/edit", bad.ID),
+	}
+	if strings.Join(alt, "\n") != strings.Join(want, "\n") {
+		t.Errorf("missing alt text:\n%s\nwant:\n%s", strings.Join(alt, "\n"), strings.Join(want, "\n"))
+	}
+
+	if len(report.Headings) != 1 {
+		t.Fatalf("headings = %+v, want only the bad post", report.Headings)
+	}
+	h := report.Headings[0]
+	if h.Title != "Bad" || h.Field != "Body" || h.Detail != "h1 used; the page title is already the h1; h4 follows h2" {
+		t.Errorf("heading issue = %+v", h)
+	}
+}
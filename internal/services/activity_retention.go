@@ -0,0 +1,86 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Job cancellation
+	"database/sql" // sql.ErrNoRows before the first archive
+	"errors"       // Error matching
+	"fmt"          // Error wrapping
+	"log/slog"     // Structured logging for job progress and failures
+	"time"         // Retention cutoff and job scheduling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// ActivityRetention prunes activity_log entries older than a fixed number of
+// days. Entries are only deleted once ArchiveService has exported their
+// month, so a window shorter than a month, or a failing archive job, holds
+// pruning back instead of dropping entries from the compliance archive.
+type ActivityRetention struct {
+	queries *sqlc.Queries // Database query interface for activity_log
+	logger  *slog.Logger  // Structured logger for job progress
+	days    int           // Entries older than this many days are deleted; 0 disables pruning
+}
+
+// NewActivityRetention creates the activity log pruning job.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - logger: Structured logger for job progress and failures
+//   - days: Retention window in days (0 = keep forever)
+//
+// Returns:
+//   - *ActivityRetention: Job ready to run or schedule
+func NewActivityRetention(queries *sqlc.Queries, logger *slog.Logger, days int) *ActivityRetention {
+	return &ActivityRetention{queries: queries, logger: logger, days: days}
+}
+
+// Days returns the retention window in days, 0 when pruning is disabled.
+func (r *ActivityRetention) Days() int {
+	if r == nil {
+		return 0
+	}
+	return r.days
+}
+
+// Prune deletes entries created more than the retention window before now,
+// but none after the last month ArchiveService archived.
+//
+// Parameters:
+//   - ctx: Context for the delete
+//   - now: Reference time for the cutoff
+//
+// Returns:
+//   - int64: Number of entries deleted (0 when pruning is disabled)
+//   - error: Database error, if any
+func (r *ActivityRetention) Prune(ctx context.Context, now time.Time) (int64, error) {
+	if r.days <= 0 {
+		return 0, nil
+	}
+	cutoff := now.UTC().AddDate(0, 0, -r.days)
+
+	latest, err := r.queries.GetLatestArchiveRun(ctx, ArchiveTableActivityLog)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil // Nothing archived yet
+	}
+	if err != nil {
+		return 0, fmt.Errorf("load latest archive: %w", err)
+	}
+	last, err := time.Parse(archivePeriodLayout, latest.Period)
+	if err != nil {
+		return 0, fmt.Errorf("parse archive period %q: %w", latest.Period, err)
+	}
+	if archived := last.AddDate(0, 1, 0); cutoff.After(archived) {
+		cutoff = archived
+	}
+
+	n, err := r.queries.DeleteActivityLogsBefore(ctx, cutoff.Format(archiveTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		r.logger.Info("pruned activity log", "rows", n, "retention_days", r.days, "before", cutoff.Format(archiveTimeLayout))
+	}
+	return n, nil
+}
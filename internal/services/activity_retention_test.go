@@ -0,0 +1,62 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestActivityRetention_Prune(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, ts := range []string{"2026-01-01 09:00:00", "2026-02-19 23:59:59", "2026-03-10 12:00:00"} {
+		if _, err := db.Exec(`INSERT INTO activity_log (action, resource_type, description, created_at) VALUES ('created', 'product', 'test', ?)`, ts); err != nil {
+			t.Fatalf("insert activity_log: %v", err)
+		}
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	now := time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC)
+
+	if n, err := services.NewActivityRetention(queries, logger, 0).Prune(ctx, now); err != nil || n != 0 {
+		t.Fatalf("disabled retention pruned %d rows (%v)", n, err)
+	}
+
+	// Nothing is pruned before its month is archived
+	retention := services.NewActivityRetention(queries, logger, 30)
+	if n, err := retention.Prune(ctx, now); err != nil || n != 0 {
+		t.Fatalf("pruned %d rows (%v) before any archive", n, err)
+	}
+	archive := func(period string) {
+		t.Helper()
+		if _, err := queries.CreateArchiveRun(ctx, sqlc.CreateArchiveRunParams{
+			SourceTable: services.ArchiveTableActivityLog, Period: period, StorageKey: "activity_log/" + period + ".jsonl.gz",
+		}); err != nil {
+			t.Fatalf("CreateArchiveRun %s: %v", period, err)
+		}
+	}
+	archive("2026-01")
+	if n, err := retention.Prune(ctx, now); err != nil || n != 1 {
+		t.Errorf("pruned %d rows (%v), want 1 (January, the only archived month)", n, err)
+	}
+
+	archive("2026-02")
+	n, err := retention.Prune(ctx, now)
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("pruned %d rows, want 1 (older than 2026-02-20 00:00)", n)
+	}
+	left, _ := queries.CountActivityLogs(ctx, sqlc.CountActivityLogsParams{})
+	if left != 1 {
+		t.Errorf("%d rows left, want 1", left)
+	}
+}
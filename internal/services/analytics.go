@@ -0,0 +1,471 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Job and request cancellation
+	"database/sql" // Transactions for batched inserts and rollups
+	"encoding/csv" // GeoIP range files
+	"errors"       // Wrapped load errors
+	"fmt"          // Error context
+	"io"           // GeoIP range readers
+	"log/slog"     // Logging dropped and failed page views
+	"net"          // Splitting the request's host and port
+	"net/http"     // Request headers
+	"net/netip"    // IP addresses and range lookups
+	"net/url"      // Referrer parsing
+	"os"           // Opening the GeoIP file
+	"sort"         // Range ordering and lookup
+	"strings"      // User agent matching
+	"sync"         // Guards the pending views
+	"time"         // Flush interval and report days
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// User agent classes, stored in page_views.ua_class. UABot is never stored.
+const (
+	UADesktop = "desktop"
+	UAMobile  = "mobile"
+	UATablet  = "tablet"
+	UABot     = "bot" // Crawlers, link previews and HTTP tools
+)
+
+// AnalyticsRanges are the report lengths offered by the dashboard, in days.
+var AnalyticsRanges = []int{7, 30, 90}
+
+const (
+	// analyticsFlushInterval is how often recorded views are written.
+	analyticsFlushInterval = 10 * time.Second
+
+	// analyticsMaxPending caps the views waiting to be written; more are
+	// dropped, so a flood of requests cannot grow memory without bound.
+	analyticsMaxPending = 10000
+
+	// analyticsMaxLen caps the stored path and referrer.
+	analyticsMaxLen = 512
+
+	// Rows listed per table of the report.
+	analyticsTopPages     = 20
+	analyticsTopReferrers = 10
+	analyticsTopCountries = 10
+
+	// analyticsDayLayout formats the UTC days of page_view_daily.
+	analyticsDayLayout = "2006-01-02"
+)
+
+// botMarkers identify crawlers, link previews and HTTP tools in a
+// lower-cased User-Agent.
+var botMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "preview", "facebookexternalhit", "embedly",
+	"headless", "lighthouse", "pingdom", "monitor", "curl", "wget", "python",
+	"go-http-client", "java/", "okhttp", "httpclient", "axios", "node-fetch",
+	"cache-warmer", // CacheWarmerUserAgent
+	"link-checker", // LinkCheckerUserAgent
+}
+
+// ClassifyUserAgent returns the class of a User-Agent header: UABot for
+// crawlers and tools (and an empty header), UATablet, UAMobile or
+// UADesktop.
+func ClassifyUserAgent(ua string) string {
+	ua = strings.ToLower(ua)
+	if strings.TrimSpace(ua) == "" {
+		return UABot
+	}
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return UABot
+		}
+	}
+	switch {
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"), strings.Contains(ua, "kindle"),
+		strings.Contains(ua, "silk/"), strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return UATablet
+	case strings.Contains(ua, "mobi"), strings.Contains(ua, "iphone"), strings.Contains(ua, "ipod"),
+		strings.Contains(ua, "android"), strings.Contains(ua, "windows phone"), strings.Contains(ua, "opera mini"):
+		return UAMobile
+	}
+	return UADesktop
+}
+
+// ReferrerHost returns the host of the site a Referer header names, without
+// "www." and the port, or "" for direct visits, links within the site
+// (host is the request's Host) and referrers that are not http(s) URLs.
+func ReferrerHost(referer, host string) string {
+	u, err := url.Parse(referer)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ""
+	}
+	ref := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	self := strings.ToLower(host)
+	if h, _, err := net.SplitHostPort(self); err == nil {
+		self = h
+	}
+	if ref == strings.TrimPrefix(self, "www.") {
+		return ""
+	}
+	return truncateAnalytics(ref)
+}
+
+// truncateAnalytics shortens s to analyticsMaxLen bytes without splitting
+// a UTF-8 sequence.
+func truncateAnalytics(s string) string {
+	if len(s) <= analyticsMaxLen {
+		return s
+	}
+	return strings.ToValidUTF8(s[:analyticsMaxLen], "")
+}
+
+// geoRange maps the addresses from start to end to a country.
+type geoRange struct {
+	start, end netip.Addr
+	country    string
+}
+
+// GeoIP maps IP addresses to countries with a table of address ranges, such
+// as the free DB-IP "IP to Country Lite" CSV. A nil *GeoIP knows no
+// countries.
+type GeoIP struct {
+	ranges []geoRange // Sorted by start
+}
+
+// LoadGeoIP reads CSV rows of first address, last address and ISO 3166-1
+// alpha-2 country code (further columns are ignored), IPv4 and IPv6 alike.
+// A header row is skipped.
+func LoadGeoIP(r io.Reader) (*GeoIP, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	g := &GeoIP{}
+	for line := 1; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 3 {
+			return nil, fmt.Errorf("geoip: line %d: want first address, last address and country", line)
+		}
+		start, err1 := netip.ParseAddr(strings.TrimSpace(rec[0]))
+		end, err2 := netip.ParseAddr(strings.TrimSpace(rec[1]))
+		if err1 != nil || err2 != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("geoip: line %d: invalid address range %q-%q", line, rec[0], rec[1])
+		}
+		country := strings.ToUpper(strings.TrimSpace(rec[2]))
+		if !validCountry(country) {
+			continue
+		}
+		g.ranges = append(g.ranges, geoRange{start: start.Unmap(), end: end.Unmap(), country: country})
+	}
+	sort.Slice(g.ranges, func(i, j int) bool { return g.ranges[i].start.Less(g.ranges[j].start) })
+	return g, nil
+}
+
+// OpenGeoIP loads the range file at path with LoadGeoIP.
+func OpenGeoIP(path string) (*GeoIP, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	g, err := LoadGeoIP(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return g, nil
+}
+
+// Len returns the number of address ranges loaded.
+func (g *GeoIP) Len() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.ranges)
+}
+
+// Country returns the country code of ip, or "" when it is not in a range
+// or does not parse.
+func (g *GeoIP) Country(ip string) string {
+	if g == nil {
+		return ""
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	i := sort.Search(len(g.ranges), func(i int) bool { return addr.Less(g.ranges[i].start) }) - 1
+	if i < 0 || g.ranges[i].end.Less(addr) {
+		return ""
+	}
+	return g.ranges[i].country
+}
+
+// validCountry reports whether code is an ISO 3166-1 alpha-2 code, leaving
+// out the XX and ZZ placeholders some CDNs and range files use.
+func validCountry(code string) bool {
+	if len(code) != 2 || code == "XX" || code == "ZZ" {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// AnalyticsConfig configures where page views' countries come from.
+type AnalyticsConfig struct {
+	GeoIP         *GeoIP // Address ranges, nil when none are configured
+	CountryHeader string // Request header a CDN sets to the visitor's country, e.g. CF-IPCountry; takes precedence over GeoIP
+}
+
+// AnalyticsDay is one day of the report's trend.
+type AnalyticsDay struct {
+	Day     string `json:"day"`   // YYYY-MM-DD (UTC)
+	Views   int64  `json:"views"` // Page views that day
+	Percent int    `json:"-"`     // Share of the busiest day, for the bar height
+}
+
+// AnalyticsCount is one row of the report's top lists.
+type AnalyticsCount struct {
+	Name    string // Path, referrer host, country code or UA class; "" for direct visits and unknown countries
+	Views   int64  // Page views in the range
+	Percent int    // Share of all views in the range
+}
+
+// AnalyticsReport is the dashboard under Admin > Analytics.
+type AnalyticsReport struct {
+	Days      int              // Length of the range, ending today (UTC)
+	Total     int64            // Views in the range
+	Today     int64            // Views today so far
+	Trend     []AnalyticsDay   // One entry per day of the range, oldest first
+	Pages     []AnalyticsCount // Most viewed paths
+	Referrers []AnalyticsCount // Referring sites
+	Countries []AnalyticsCount // Visitor countries
+	UAClasses []AnalyticsCount // Desktop, mobile and tablet
+}
+
+// Analytics records public page views and reports on them (first-party
+// analytics, without cookies or visitor identifiers). Views are kept in
+// memory and written in batches by Start, so recording never waits for the
+// database; the daily Rollup folds finished days into per-day counts.
+type Analytics struct {
+	db      *sql.DB         // Connection that opens the write transactions
+	queries *sqlc.Queries   // Queries bound to the transactions with WithTx
+	logger  *slog.Logger    // Failed writes and dropped views
+	config  AnalyticsConfig // Country sources
+
+	mu      sync.Mutex                  // Guards pending and dropped
+	pending []sqlc.InsertPageViewParams // Views waiting for the next Flush
+	dropped int                         // Views dropped since the last Flush (queue full)
+}
+
+// NewAnalytics creates the page view service. Call Start to write recorded
+// views in the background.
+//
+// Parameters:
+//   - db: Primary database, for the write transactions
+//   - queries: Database query interface from sqlc
+//   - logger: Structured logger for failed writes
+//   - config: Country sources (GeoIP ranges, CDN country header)
+//
+// Returns:
+//   - *Analytics: Service ready to record views and build reports
+func NewAnalytics(db *sql.DB, queries *sqlc.Queries, logger *slog.Logger, config AnalyticsConfig) *Analytics {
+	return &Analytics{db: db, queries: queries, logger: logger, config: config}
+}
+
+// Record queues a view of the page r requested from ip. Views from bots are
+// ignored, and views beyond analyticsMaxPending are dropped until the next
+// Flush.
+func (a *Analytics) Record(r *http.Request, ip string) {
+	class := ClassifyUserAgent(r.UserAgent())
+	if class == UABot {
+		return
+	}
+	view := sqlc.InsertPageViewParams{
+		Path:     truncateAnalytics(r.URL.Path),
+		Referrer: ReferrerHost(r.Referer(), r.Host),
+		UaClass:  class,
+		Country:  a.country(r, ip),
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.pending) >= analyticsMaxPending {
+		a.dropped++
+		return
+	}
+	a.pending = append(a.pending, view)
+}
+
+// country returns the visitor's country from the configured CDN header, or
+// else from the GeoIP ranges.
+func (a *Analytics) country(r *http.Request, ip string) string {
+	if a.config.CountryHeader != "" {
+		if code := strings.ToUpper(strings.TrimSpace(r.Header.Get(a.config.CountryHeader))); validCountry(code) {
+			return code
+		}
+	}
+	return a.config.GeoIP.Country(ip)
+}
+
+// Flush writes the queued views in one transaction. A batch that fails is
+// logged and discarded rather than retried.
+//
+// Returns:
+//   - int: Number of views written
+//   - error: Database error, if any
+func (a *Analytics) Flush(ctx context.Context) (int, error) {
+	a.mu.Lock()
+	views, dropped := a.pending, a.dropped
+	a.pending, a.dropped = nil, 0
+	a.mu.Unlock()
+	if dropped > 0 {
+		a.logger.Warn("dropped page views, write queue full", "views", dropped)
+	}
+	if len(views) == 0 {
+		return 0, nil
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	qtx := a.queries.WithTx(tx)
+	for _, view := range views {
+		if err := qtx.InsertPageView(ctx, view); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(views), nil
+}
+
+// Start writes the queued views every analyticsFlushInterval until ctx is
+// cancelled, then writes the last ones.
+func (a *Analytics) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(analyticsFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if _, err := a.Flush(context.Background()); err != nil {
+					a.logger.Error("failed to write page views", "error", err)
+				}
+				return
+			case <-ticker.C:
+				if _, err := a.Flush(ctx); err != nil {
+					a.logger.Error("failed to write page views", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Rollup adds the views of the days before now's (UTC) to page_view_daily
+// and deletes their rows, in one transaction. Run daily by the
+// "analytics-rollup" job; reports read both tables, so a late run only
+// leaves more rows in page_views.
+//
+// Returns:
+//   - int64: Number of views rolled up
+//   - error: Database error, if any
+func (a *Analytics) Rollup(ctx context.Context, now time.Time) (int64, error) {
+	cutoff := now.UTC().Format(analyticsDayLayout)
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	qtx := a.queries.WithTx(tx)
+	if _, err := qtx.RollupPageViews(ctx, cutoff); err != nil {
+		return 0, err
+	}
+	n, err := qtx.DeletePageViewsBefore(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		a.logger.Info("rolled up page views", "views", n, "before", cutoff)
+	}
+	return n, nil
+}
+
+// Report builds the dashboard for the days days ending with now's (UTC).
+// Views still queued for Flush are not included.
+func (a *Analytics) Report(ctx context.Context, days int, now time.Time) (AnalyticsReport, error) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	first := today.AddDate(0, 0, 1-days)
+	from := first.Format(analyticsDayLayout)
+	report := AnalyticsReport{Days: days}
+
+	trend, err := a.queries.PageViewTrend(ctx, from)
+	if err != nil {
+		return report, err
+	}
+	byDay := make(map[string]int64, len(trend))
+	var peak int64
+	for _, row := range trend {
+		byDay[row.Day] = row.Views
+		report.Total += row.Views
+		peak = max(peak, row.Views)
+	}
+	for d := first; !d.After(today); d = d.AddDate(0, 0, 1) {
+		day := AnalyticsDay{Day: d.Format(analyticsDayLayout), Views: byDay[d.Format(analyticsDayLayout)]}
+		day.Percent = percentOf(day.Views, peak)
+		report.Trend = append(report.Trend, day)
+	}
+	report.Today = report.Trend[len(report.Trend)-1].Views
+
+	pages, err := a.queries.TopPages(ctx, sqlc.TopPagesParams{Day: from, Limit: analyticsTopPages})
+	if err != nil {
+		return report, err
+	}
+	for _, row := range pages {
+		report.Pages = append(report.Pages, AnalyticsCount{Name: row.Path, Views: row.Views, Percent: percentOf(row.Views, report.Total)})
+	}
+	referrers, err := a.queries.TopReferrers(ctx, sqlc.TopReferrersParams{Day: from, Limit: analyticsTopReferrers})
+	if err != nil {
+		return report, err
+	}
+	for _, row := range referrers {
+		report.Referrers = append(report.Referrers, AnalyticsCount{Name: row.Referrer, Views: row.Views, Percent: percentOf(row.Views, report.Total)})
+	}
+	countries, err := a.queries.TopCountries(ctx, sqlc.TopCountriesParams{Day: from, Limit: analyticsTopCountries})
+	if err != nil {
+		return report, err
+	}
+	for _, row := range countries {
+		report.Countries = append(report.Countries, AnalyticsCount{Name: row.Country, Views: row.Views, Percent: percentOf(row.Views, report.Total)})
+	}
+	classes, err := a.queries.PageViewsByUAClass(ctx, from)
+	if err != nil {
+		return report, err
+	}
+	for _, row := range classes {
+		report.UAClasses = append(report.UAClasses, AnalyticsCount{Name: row.UaClass, Views: row.Views, Percent: percentOf(row.Views, report.Total)})
+	}
+	return report, nil
+}
+
+// percentOf returns n as a whole percentage of total, 0 when total is 0.
+func percentOf(n, total int64) int {
+	if total <= 0 {
+		return 0
+	}
+	return int(n * 100 / total)
+}
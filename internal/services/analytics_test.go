@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestClassifyUserAgent(t *testing.T) {
+	for ua, want := range map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36":         services.UADesktop,
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148":       services.UAMobile,
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/126.0 Mobile Safari/537.36":   services.UAMobile,
+		"Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148":                services.UATablet,
+		"Mozilla/5.0 (Linux; Android 13; SM-X710) AppleWebKit/537.36 Chrome/126.0 Safari/537.36":          services.UATablet,
+		"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)":                        services.UABot,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 HeadlessChrome/126.0 Safari/537.36": services.UABot,
+		"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)":                       services.UABot,
+		"curl/8.7.1":                  services.UABot,
+		services.CacheWarmerUserAgent: services.UABot,
+		"":                            services.UABot,
+	} {
+		if got := services.ClassifyUserAgent(ua); got != want {
+			t.Errorf("ClassifyUserAgent(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
+
+func TestReferrerHost(t *testing.T) {
+	for _, tc := range []struct{ referer, host, want string }{
+		{"https://www.google.com/search?q=bluejay", "example.com", "google.com"},
+		{"https://News.ycombinator.com/item?id=1", "example.com", "news.ycombinator.com"},
+		{"https://www.example.com/products", "example.com", ""},
+		{"http://example.com/about", "example.com:8080", ""},
+		{"android-app://com.slack", "example.com", ""},
+		{"", "example.com", ""},
+	} {
+		if got := services.ReferrerHost(tc.referer, tc.host); got != tc.want {
+			t.Errorf("ReferrerHost(%q, %q) = %q, want %q", tc.referer, tc.host, got, tc.want)
+		}
+	}
+}
+
+func TestGeoIP(t *testing.T) {
+	g, err := services.LoadGeoIP(strings.NewReader("ip_start,ip_end,country\n" +
+		"81.2.69.0,81.2.69.255,GB\n" +
+		"1.0.0.0,1.0.0.255,AU\n" +
+		"2001:db8::,2001:db8::ffff,de\n" +
+		"10.0.0.0,10.255.255.255,ZZ\n"))
+	if err != nil {
+		t.Fatalf("LoadGeoIP: %v", err)
+	}
+	if g.Len() != 3 {
+		t.Errorf("loaded %d ranges, want 3 (placeholder ZZ skipped)", g.Len())
+	}
+	for ip, want := range map[string]string{
+		"1.0.0.7":          "AU",
+		"81.2.69.255":      "GB",
+		"::ffff:81.2.69.1": "GB",
+		"2001:db8::1":      "DE",
+		"1.0.1.0":          "",
+		"10.1.2.3":         "",
+		"not an ip":        "",
+	} {
+		if got := g.Country(ip); got != want {
+			t.Errorf("Country(%q) = %q, want %q", ip, got, want)
+		}
+	}
+	var none *services.GeoIP
+	if none.Country("1.0.0.7") != "" {
+		t.Error("nil GeoIP found a country")
+	}
+	if _, err := services.LoadGeoIP(strings.NewReader("1.0.0.0,1.0.0.255,AU\nbad,range,US\n")); err == nil {
+		t.Error("LoadGeoIP accepted an invalid range")
+	}
+}
+
+func TestAnalytics_RecordRollupReport(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	geo, _ := services.LoadGeoIP(strings.NewReader("1.0.0.0,1.0.0.255,AU\n81.2.69.0,81.2.69.255,GB\n"))
+	analytics := services.NewAnalytics(db, queries, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		services.AnalyticsConfig{GeoIP: geo, CountryHeader: "CF-IPCountry"})
+	now := time.Now()
+
+	view := func(path, ua, referer, country, ip string) {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		req.Header.Set("User-Agent", ua)
+		req.Header.Set("Referer", referer)
+		req.Header.Set("CF-IPCountry", country)
+		analytics.Record(req, ip)
+	}
+	view("/pricing", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) Mobile/15E148", "https://www.google.com/search", "", "1.0.0.7")
+	view("/pricing", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/126.0", "https://example.com/", "us", "1.0.0.8")
+	view("/", "Mozilla/5.0 (compatible; Googlebot/2.1)", "", "", "1.0.0.9")
+	view("/", "Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X) Mobile/15E148", "", "XX", "::ffff:81.2.69.10")
+	if n, err := analytics.Flush(ctx); err != nil || n != 3 {
+		t.Fatalf("Flush = %d, %v; want 3 views (the bot is ignored)", n, err)
+	}
+	var countries string
+	db.QueryRow(`SELECT group_concat(country || ':' || ua_class || ':' || referrer, ' ') FROM (SELECT * FROM page_views ORDER BY id)`).Scan(&countries)
+	if countries != "AU:mobile:google.com US:desktop: GB:tablet:" {
+		t.Errorf("recorded views = %q", countries)
+	}
+
+	// Earlier days, recorded before the rollup
+	for _, v := range []struct {
+		path    string
+		daysAgo int
+	}{{"/pricing", 2}, {"/pricing", 2}, {"/blog", 10}} {
+		at := now.UTC().AddDate(0, 0, -v.daysAgo).Format("2006-01-02 15:04:05")
+		if _, err := db.Exec(`INSERT INTO page_views (path, viewed_at) VALUES (?, ?)`, v.path, at); err != nil {
+			t.Fatalf("insert page view: %v", err)
+		}
+	}
+	if n, err := analytics.Rollup(ctx, now); err != nil || n != 3 {
+		t.Fatalf("Rollup = %d, %v; want the 3 views of earlier days", n, err)
+	}
+	if n, _ := analytics.Rollup(ctx, now); n != 0 {
+		t.Errorf("second Rollup = %d, want 0", n)
+	}
+
+	week, err := analytics.Report(ctx, 7, now)
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if week.Total != 5 || week.Today != 3 || len(week.Trend) != 7 || week.Trend[4].Views != 2 || week.Trend[6].Percent != 100 {
+		t.Errorf("week: total %d, today %d, trend %+v", week.Total, week.Today, week.Trend)
+	}
+	if len(week.Pages) != 2 || week.Pages[0].Name != "/pricing" || week.Pages[0].Views != 4 || week.Pages[0].Percent != 80 {
+		t.Errorf("week pages = %+v", week.Pages)
+	}
+	if len(week.Referrers) != 2 || week.Referrers[0].Name != "" || week.Referrers[1].Name != "google.com" {
+		t.Errorf("week referrers = %+v", week.Referrers)
+	}
+	if len(week.UAClasses) != 3 || week.UAClasses[0].Name != services.UADesktop || week.UAClasses[0].Views != 3 {
+		t.Errorf("week UA classes = %+v", week.UAClasses)
+	}
+	if len(week.Countries) != 4 || week.Countries[0].Name != "" || week.Countries[0].Views != 2 {
+		t.Errorf("week countries = %+v", week.Countries)
+	}
+
+	month, _ := analytics.Report(ctx, 30, now)
+	if month.Total != 6 || len(month.Trend) != 30 || len(month.Pages) != 3 {
+		t.Errorf("month: total %d, %d days, pages %+v", month.Total, len(month.Trend), month.Pages)
+	}
+}
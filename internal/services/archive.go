@@ -0,0 +1,347 @@
+package services
+
+import (
+	// Standard library imports for compression, hashing, and serialization
+	"bytes"         // In-memory buffer for building compressed archive files
+	"compress/gzip" // Compresses each monthly export
+	"context"       // Request/job cancellation
+	"crypto/sha256" // Content hashes and the archive hash chain
+	"database/sql"  // sql.ErrNoRows detection
+	"encoding/hex"  // Hex encoding of hashes stored in the ledger
+	"encoding/json" // JSON Lines serialization of archived rows
+	"errors"        // Error inspection
+	"fmt"           // Error wrapping and storage key formatting
+	"io"            // Streaming stored archives back for verification
+	"log/slog"      // Structured logging for job progress and failures
+	"time"          // Month boundaries and job scheduling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Tables covered by the compliance archive.
+const (
+	ArchiveTableActivityLog         = "activity_log"
+	ArchiveTableContactSubmissions  = "contact_submissions"
+	ArchiveTableWhitepaperDownloads = "whitepaper_downloads"
+)
+
+// archiveTimeLayout matches the text format SQLite uses for CURRENT_TIMESTAMP,
+// so period bounds compare correctly against stored created_at values.
+const archiveTimeLayout = "2006-01-02 15:04:05"
+
+// archivePeriodLayout is the 'YYYY-MM' format used for archive_runs.period.
+const archivePeriodLayout = "2006-01"
+
+// ArchiveConfig controls the monthly archival job.
+type ArchiveConfig struct {
+	// RetentionMonths is how many complete months of rows stay in the live tables.
+	// Older rows are deleted after they have been archived. Zero disables pruning.
+	RetentionMonths int
+	// HashChain links every archive to the previous archive of the same table via
+	// sha256(prev_chain_hash || content_hash), making tampering with any earlier
+	// file or ledger row detectable.
+	HashChain bool
+}
+
+// ArchiveReport summarizes a single run of the archival job.
+type ArchiveReport struct {
+	Archived []sqlc.ArchiveRun // Ledger rows recorded during this run
+	Pruned   map[string]int64  // Rows deleted from each live table
+}
+
+// archiveSource describes how to read and prune one archived table.
+type archiveSource struct {
+	table  string
+	oldest func(ctx context.Context) (time.Time, error)
+	rows   func(ctx context.Context, start, end string) ([]interface{}, error)
+	prune  func(ctx context.Context, cutoff string) (int64, error)
+}
+
+// ArchiveService exports audit and lead tables to compressed, append-only files
+// in a Storage backend, records each export in the archive_runs ledger, and prunes
+// the live tables according to the retention policy.
+type ArchiveService struct {
+	queries *sqlc.Queries   // Database query interface for ledger and table access
+	storage Storage         // Write-once backend holding the archive files
+	logger  *slog.Logger    // Structured logger for job progress
+	config  ArchiveConfig   // Retention and signing options
+	sources []archiveSource // Tables included in each run, in processing order
+}
+
+// NewArchiveService creates an ArchiveService for activity_log, contact_submissions
+// and whitepaper_downloads.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - storage: Write-once backend where archive files are stored
+//   - logger: Structured logger for job progress and failures
+//   - config: Retention and hash chain options
+//
+// Returns:
+//   - *ArchiveService: Service ready to run or schedule the archival job
+func NewArchiveService(queries *sqlc.Queries, storage Storage, logger *slog.Logger, config ArchiveConfig) *ArchiveService {
+	s := &ArchiveService{queries: queries, storage: storage, logger: logger, config: config}
+	s.sources = []archiveSource{
+		{
+			table: ArchiveTableActivityLog,
+			oldest: func(ctx context.Context) (time.Time, error) {
+				t, err := queries.GetOldestActivityLogTime(ctx)
+				return t.Time, err
+			},
+			rows: func(ctx context.Context, start, end string) ([]interface{}, error) {
+				items, err := queries.ListActivityLogsForArchive(ctx, sqlc.ListActivityLogsForArchiveParams{PeriodStart: start, PeriodEnd: end})
+				return toInterfaces(items), err
+			},
+			prune: queries.DeleteActivityLogsBefore,
+		},
+		{
+			table:  ArchiveTableContactSubmissions,
+			oldest: queries.GetOldestContactSubmissionTime,
+			rows: func(ctx context.Context, start, end string) ([]interface{}, error) {
+				items, err := queries.ListContactSubmissionsForArchive(ctx, sqlc.ListContactSubmissionsForArchiveParams{PeriodStart: start, PeriodEnd: end})
+				return toInterfaces(items), err
+			},
+			prune: queries.DeleteContactSubmissionsBefore,
+		},
+		{
+			table:  ArchiveTableWhitepaperDownloads,
+			oldest: queries.GetOldestWhitepaperDownloadTime,
+			rows: func(ctx context.Context, start, end string) ([]interface{}, error) {
+				items, err := queries.ListWhitepaperDownloadsForArchive(ctx, sqlc.ListWhitepaperDownloadsForArchiveParams{PeriodStart: start, PeriodEnd: end})
+				return toInterfaces(items), err
+			},
+			prune: queries.DeleteWhitepaperDownloadsBefore,
+		},
+	}
+	return s
+}
+
+// toInterfaces converts a typed row slice for generic JSON encoding.
+func toInterfaces[T any](items []T) []interface{} {
+	out := make([]interface{}, len(items))
+	for i := range items {
+		out[i] = items[i]
+	}
+	return out
+}
+
+// monthStart truncates t to midnight UTC on the first day of its month.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Run archives every complete month before now that is not yet in the ledger,
+// then prunes live rows older than the retention window. A table is only pruned
+// when all of its months were archived successfully; failures on one table do not
+// stop the others.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - now: Reference time; the month containing now is never archived
+//
+// Returns:
+//   - *ArchiveReport: Archives written and rows pruned
+//   - error: The first error encountered, if any
+func (s *ArchiveService) Run(ctx context.Context, now time.Time) (*ArchiveReport, error) {
+	report := &ArchiveReport{Pruned: map[string]int64{}}
+	current := monthStart(now)
+	var firstErr error
+
+	for _, src := range s.sources {
+		runs, err := s.archiveSource(ctx, src, current)
+		report.Archived = append(report.Archived, runs...)
+		if err != nil {
+			s.logger.Error("failed to archive table", "table", src.table, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if s.config.RetentionMonths <= 0 {
+			continue
+		}
+		cutoff := current.AddDate(0, -s.config.RetentionMonths, 0)
+		n, err := src.prune(ctx, cutoff.Format(archiveTimeLayout))
+		if err != nil {
+			s.logger.Error("failed to prune table", "table", src.table, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		report.Pruned[src.table] = n
+		if n > 0 {
+			s.logger.Info("pruned archived rows", "table", src.table, "rows", n, "before", cutoff.Format(archivePeriodLayout))
+		}
+	}
+	return report, firstErr
+}
+
+// archiveSource exports each unarchived month of one table that ends before current.
+func (s *ArchiveService) archiveSource(ctx context.Context, src archiveSource, current time.Time) ([]sqlc.ArchiveRun, error) {
+	var start time.Time
+	prevChain := ""
+
+	latest, err := s.queries.GetLatestArchiveRun(ctx, src.table)
+	switch {
+	case err == nil:
+		last, err := time.Parse(archivePeriodLayout, latest.Period)
+		if err != nil {
+			return nil, fmt.Errorf("parse archive period %q: %w", latest.Period, err)
+		}
+		start = last.AddDate(0, 1, 0)
+		prevChain = latest.ChainHash
+	case errors.Is(err, sql.ErrNoRows):
+		oldest, err := src.oldest(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Empty table, nothing to archive
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find oldest row: %w", err)
+		}
+		start = monthStart(oldest)
+	default:
+		return nil, fmt.Errorf("load latest archive: %w", err)
+	}
+
+	var runs []sqlc.ArchiveRun
+	for m := start; m.Before(current); m = m.AddDate(0, 1, 0) {
+		next := m.AddDate(0, 1, 0)
+		rows, err := src.rows(ctx, m.Format(archiveTimeLayout), next.Format(archiveTimeLayout))
+		if err != nil {
+			return runs, fmt.Errorf("read %s: %w", m.Format(archivePeriodLayout), err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		data, err := encodeArchive(rows)
+		if err != nil {
+			return runs, fmt.Errorf("encode %s: %w", m.Format(archivePeriodLayout), err)
+		}
+		sum := sha256.Sum256(data)
+		contentHash := hex.EncodeToString(sum[:])
+
+		period := m.Format(archivePeriodLayout)
+		key := fmt.Sprintf("%s/%s.jsonl.gz", src.table, period)
+		if err := s.store(ctx, key, data, contentHash); err != nil {
+			return runs, fmt.Errorf("store %s: %w", key, err)
+		}
+
+		params := sqlc.CreateArchiveRunParams{
+			SourceTable: src.table,
+			Period:      period,
+			StorageKey:  key,
+			RowCount:    int64(len(rows)),
+			ContentHash: contentHash,
+		}
+		if s.config.HashChain {
+			params.PrevChainHash = prevChain
+			params.ChainHash = chainHash(prevChain, contentHash)
+			prevChain = params.ChainHash
+		}
+
+		run, err := s.queries.CreateArchiveRun(ctx, params)
+		if err != nil {
+			return runs, fmt.Errorf("record %s: %w", key, err)
+		}
+		s.logger.Info("archived table month", "table", src.table, "period", period, "rows", len(rows), "key", key)
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// store writes an archive file to the write-once storage. A file left behind
+// by an earlier run whose ledger insert failed is accepted when it holds the
+// same content, so the ledger row can still be written; a file with other
+// content is an error.
+func (s *ArchiveService) store(ctx context.Context, key string, data []byte, contentHash string) error {
+	err := s.storage.Put(ctx, key, bytes.NewReader(data))
+	if !errors.Is(err, ErrObjectExists) {
+		return err
+	}
+	rc, err := s.storage.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != contentHash {
+		return fmt.Errorf("%w with different content", ErrObjectExists)
+	}
+	s.logger.Info("archive file already stored, recording it", "key", key)
+	return nil
+}
+
+// encodeArchive serializes rows as gzip-compressed JSON Lines.
+func encodeArchive(rows []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// chainHash computes the next link of an archive hash chain.
+func chainHash(prev, content string) string {
+	sum := sha256.Sum256([]byte(prev + content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify re-reads every archive of a table from storage and checks its content
+// hash and, for hash-chained archives, that each link matches its predecessor.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - table: One of the ArchiveTable* constants
+//
+// Returns:
+//   - error: nil if the archive is intact, otherwise a description of the first mismatch
+func (s *ArchiveService) Verify(ctx context.Context, table string) error {
+	runs, err := s.queries.ListArchiveRunsByTable(ctx, table)
+	if err != nil {
+		return fmt.Errorf("list archives: %w", err)
+	}
+
+	prevChain := ""
+	for _, run := range runs {
+		rc, err := s.storage.Open(ctx, run.StorageKey)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", run.StorageKey, err)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("read %s: %w", run.StorageKey, err)
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != run.ContentHash {
+			return fmt.Errorf("%s: content hash mismatch", run.StorageKey)
+		}
+
+		if run.ChainHash == "" {
+			continue // Archived without signing
+		}
+		if run.PrevChainHash != prevChain {
+			return fmt.Errorf("%s: chain broken, previous hash does not match", run.StorageKey)
+		}
+		if chainHash(run.PrevChainHash, run.ContentHash) != run.ChainHash {
+			return fmt.Errorf("%s: chain hash mismatch", run.StorageKey)
+		}
+		prevChain = run.ChainHash
+	}
+	return nil
+}
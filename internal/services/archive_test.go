@@ -0,0 +1,162 @@
+package services_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestArchiveRun_ExportsCompleteMonthsAndPrunes(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, ts := range []string{"2026-01-05 10:00:00", "2026-01-20 12:00:00", "2026-02-11 09:30:00", "2026-03-02 08:00:00"} {
+		if _, err := db.Exec(`INSERT INTO activity_log (action, resource_type, description, created_at) VALUES ('created', 'product', 'test', ?)`, ts); err != nil {
+			t.Fatalf("insert activity_log: %v", err)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO contact_submissions (name, email, phone, company, message, created_at, updated_at)
+		VALUES ('A', 'a@example.com', '', '', 'hi', '2026-01-09 10:00:00', '2026-01-09 10:00:00')`); err != nil {
+		t.Fatalf("insert contact_submissions: %v", err)
+	}
+
+	storage := services.NewLocalStorage(t.TempDir())
+	svc := services.NewArchiveService(queries, storage, slog.New(slog.NewTextHandler(io.Discard, nil)), services.ArchiveConfig{
+		RetentionMonths: 1,
+		HashChain:       true,
+	})
+
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	report, err := svc.Run(ctx, now)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	// activity_log: Jan (2 rows) + Feb (1 row); contact_submissions: Jan (1 row). March is still open.
+	if len(report.Archived) != 3 {
+		t.Fatalf("expected 3 archives, got %d", len(report.Archived))
+	}
+	jan := report.Archived[0]
+	if jan.SourceTable != services.ArchiveTableActivityLog || jan.Period != "2026-01" || jan.RowCount != 2 {
+		t.Errorf("unexpected first archive: %+v", jan)
+	}
+	if feb := report.Archived[1]; feb.PrevChainHash != jan.ChainHash || feb.ChainHash == "" {
+		t.Errorf("expected February to extend January's chain")
+	}
+
+	// Rows before February (now - 1 month) are pruned once archived
+	if report.Pruned[services.ArchiveTableActivityLog] != 2 {
+		t.Errorf("expected 2 pruned activity rows, got %d", report.Pruned[services.ArchiveTableActivityLog])
+	}
+	var remaining int
+	db.QueryRow(`SELECT COUNT(*) FROM activity_log`).Scan(&remaining)
+	if remaining != 2 {
+		t.Errorf("expected 2 live activity rows, got %d", remaining)
+	}
+
+	// The stored file holds one JSON line per row
+	rc, err := storage.Open(ctx, jan.StorageKey)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer rc.Close()
+	zr, err := gzip.NewReader(rc)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if n := strings.Count(string(body), "\n"); n != 2 {
+		t.Errorf("expected 2 JSON lines, got %d", n)
+	}
+
+	if err := svc.Verify(ctx, services.ArchiveTableActivityLog); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+
+	// A second run in the same month has nothing new to archive
+	report, err = svc.Run(ctx, now)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if len(report.Archived) != 0 {
+		t.Errorf("expected no new archives, got %d", len(report.Archived))
+	}
+}
+
+func TestArchiveRun_RecoversFromFailedLedgerInsert(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if _, err := db.Exec(`INSERT INTO activity_log (action, resource_type, description, created_at) VALUES ('created', 'product', 'test', '2026-01-05 10:00:00')`); err != nil {
+		t.Fatalf("insert activity_log: %v", err)
+	}
+	storage := services.NewLocalStorage(t.TempDir())
+	svc := services.NewArchiveService(queries, storage, slog.New(slog.NewTextHandler(io.Discard, nil)), services.ArchiveConfig{RetentionMonths: 1})
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// The file is stored but the ledger insert fails
+	if _, err := db.Exec(`CREATE TRIGGER fail_archive_runs BEFORE INSERT ON archive_runs BEGIN SELECT RAISE(ABORT, 'ledger unavailable'); END`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	if _, err := svc.Run(ctx, now); err == nil {
+		t.Fatal("expected the run to fail")
+	}
+	var remaining int
+	db.QueryRow(`SELECT COUNT(*) FROM activity_log`).Scan(&remaining)
+	if remaining != 1 {
+		t.Fatalf("rows were pruned without a ledger entry")
+	}
+
+	// The next run records the stored file and prunes
+	db.Exec(`DROP TRIGGER fail_archive_runs`)
+	report, err := svc.Run(ctx, now)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if len(report.Archived) != 1 || report.Archived[0].Period != "2026-01" || report.Pruned[services.ArchiveTableActivityLog] != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if err := svc.Verify(ctx, services.ArchiveTableActivityLog); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestArchiveRuns_AppendOnly(t *testing.T) {
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+
+	if _, err := db.Exec(`INSERT INTO archive_runs (source_table, period, storage_key, content_hash) VALUES ('activity_log', '2026-01', 'k', 'h')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE archive_runs SET content_hash = 'x'`); err == nil {
+		t.Error("expected update to be rejected")
+	}
+	if _, err := db.Exec(`DELETE FROM archive_runs`); err == nil {
+		t.Error("expected delete to be rejected")
+	}
+}
+
+func TestLocalStorage_WriteOnce(t *testing.T) {
+	storage := services.NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+
+	if err := storage.Put(ctx, "a/b.txt", strings.NewReader("one")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := storage.Put(ctx, "a/b.txt", strings.NewReader("two")); !errors.Is(err, services.ErrObjectExists) {
+		t.Errorf("expected ErrObjectExists, got %v", err)
+	}
+	if err := storage.Put(ctx, "../escape.txt", strings.NewReader("x")); err == nil {
+		t.Error("expected path traversal key to be rejected")
+	}
+}
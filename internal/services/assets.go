@@ -0,0 +1,122 @@
+package services
+
+import (
+	// Standard library imports
+	"crypto/sha256" // Content hash in asset file names
+	"encoding/hex"  // Hash formatting
+	"os"            // Reading asset files and their modification times
+	"path"          // URL-style path handling for asset names
+	"path/filepath" // Mapping asset names to files on disk
+	"strings"       // Splitting hashed names
+	"sync"          // Guards the hash cache
+	"time"          // File modification times
+)
+
+// assetHashLength is how many hex digits of the SHA-256 content hash go into
+// an asset's file name: enough to tell versions apart, short enough to read.
+const assetHashLength = 10
+
+// Assets gives static files content-hashed URLs, such as
+// /public/css/styles.3f9a1c2b0d.css for css/styles.css, so they can be cached
+// by browsers and CDNs forever: a changed file gets a new URL. Hashes are
+// computed on first use and recomputed when a file's size or modification
+// time changes, so rebuilt CSS is picked up without a restart.
+type Assets struct {
+	dir       string // Directory the files are served from (e.g. "public")
+	urlPrefix string // URL path the directory is served under (e.g. "/public")
+
+	mu     sync.Mutex
+	hashes map[string]assetHash // Asset name -> hash of its current content
+}
+
+// assetHash is the content hash of an asset and the file state it was
+// computed from.
+type assetHash struct {
+	hash    string
+	size    int64
+	modTime time.Time
+}
+
+// NewAssets creates the asset resolver for files in dir served under
+// urlPrefix.
+//
+// Example:
+//
+//	assets := services.NewAssets("public", "/public")
+//	assets.URL("css/styles.css") // "/public/css/styles.3f9a1c2b0d.css"
+func NewAssets(dir, urlPrefix string) *Assets {
+	return &Assets{dir: dir, urlPrefix: strings.TrimSuffix(urlPrefix, "/"), hashes: make(map[string]assetHash)}
+}
+
+// URL returns the content-hashed URL of the asset name (a path relative to
+// the asset directory). Files that cannot be read, and files without an
+// extension, keep their plain URL.
+func (a *Assets) URL(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	hash, ok := a.hash(name)
+	ext := path.Ext(name)
+	if !ok || ext == "" {
+		return a.urlPrefix + "/" + name
+	}
+	return a.urlPrefix + "/" + strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// Resolve maps a requested asset name to the file on disk to serve. For a
+// hashed name it returns the unhashed file and whether the hash matches the
+// file's current content; only then may the response be cached forever.
+// Names without a hash map to their own file with current false.
+func (a *Assets) Resolve(name string) (file string, current bool) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	dot := strings.LastIndexByte(stem, '.')
+	if ext == "" || dot < 0 || !isAssetHash(stem[dot+1:]) {
+		return a.path(name), false
+	}
+	plain := stem[:dot] + ext
+	if _, err := os.Stat(a.path(plain)); err != nil {
+		// A file whose own name looks hashed (vendor builds)
+		return a.path(name), false
+	}
+	hash, ok := a.hash(plain)
+	return a.path(plain), ok && hash == stem[dot+1:]
+}
+
+// hash returns the content hash of the asset name, computing it when the
+// file is new or changed since last time.
+func (a *Assets) hash(name string) (string, bool) {
+	info, err := os.Stat(a.path(name))
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if h, ok := a.hashes[name]; ok && h.size == info.Size() && h.modTime.Equal(info.ModTime()) {
+		return h.hash, true
+	}
+	data, err := os.ReadFile(a.path(name))
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	h := assetHash{hash: hex.EncodeToString(sum[:])[:assetHashLength], size: info.Size(), modTime: info.ModTime()}
+	a.hashes[name] = h
+	return h.hash, true
+}
+
+// path returns the file of the asset name under the asset directory.
+func (a *Assets) path(name string) string {
+	return filepath.Join(a.dir, filepath.FromSlash(path.Clean("/"+name)))
+}
+
+// isAssetHash reports whether s has the form of a hash written by URL.
+func isAssetHash(s string) bool {
+	if len(s) != assetHashLength {
+		return false
+	}
+	for _, r := range s {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
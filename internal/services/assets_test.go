@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestAssets(t *testing.T) {
+	dir := t.TempDir()
+	css := filepath.Join(dir, "css", "styles.css")
+	os.MkdirAll(filepath.Dir(css), 0o755)
+	os.WriteFile(css, []byte("body{color:red}"), 0o644)
+	assets := services.NewAssets(dir, "/public/")
+
+	url := assets.URL("css/styles.css")
+	if !regexp.MustCompile(`^/public/css/styles\.[0-9a-f]{10}\.css$`).MatchString(url) {
+		t.Fatalf("URL = %q", url)
+	}
+	if got := assets.URL("/css/styles.css"); got != url {
+		t.Errorf("leading slash: %q", got)
+	}
+	if got := assets.URL("css/missing.css"); got != "/public/css/missing.css" {
+		t.Errorf("missing file: %q", got)
+	}
+
+	name := strings.TrimPrefix(url, "/public/")
+	if file, current := assets.Resolve(name); file != css || !current {
+		t.Errorf("Resolve(%q) = %q, %v", name, file, current)
+	}
+	if file, current := assets.Resolve("css/styles.css"); file != css || current {
+		t.Errorf("plain name: %q, %v", file, current)
+	}
+	if file, _ := assets.Resolve("../../etc/passwd"); !strings.HasPrefix(file, dir) {
+		t.Errorf("escaped the asset directory: %q", file)
+	}
+
+	// Editing the file changes its URL; the old URL still serves it, but no
+	// longer as current
+	os.WriteFile(css, []byte("body{color:blue}"), 0o644)
+	os.Chtimes(css, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if assets.URL("css/styles.css") == url {
+		t.Error("URL unchanged after edit")
+	}
+	if file, current := assets.Resolve(name); file != css || current {
+		t.Errorf("old hash: %q, %v", file, current)
+	}
+}
@@ -0,0 +1,765 @@
+package services
+
+import (
+	// Standard library imports
+	"archive/tar"   // Backup archive format
+	"compress/gzip" // Backup archive compression
+	"context"       // Job cancellation
+	"database/sql"  // Primary database handle being copied and restored
+	"encoding/json" // Backup manifest
+	"errors"        // Sentinel errors for handlers
+	"fmt"           // Keys and error wrapping
+	"io"            // Streaming archives to storage and downloads
+	"io/fs"         // Walking the uploads directory
+	"log/slog"      // Structured logging for backup jobs
+	"os"            // Temp files and upload files
+	"path"          // Archive entry names
+	"path/filepath" // Upload file paths
+	"regexp"        // Parsing backup names
+	"slices"        // Sorting the backup list
+	"strings"       // Archive entry names
+	"sync"          // One backup or restore at a time
+	"time"          // Backup timestamps and scheduling
+
+	// Third-party imports
+	"modernc.org/sqlite" // SQLite online backup API
+)
+
+// Backup kinds, part of each backup's name.
+const (
+	BackupManual     = "manual"      // Created from Admin > Backups
+	BackupScheduled  = "scheduled"   // Created by the scheduled job; only these are pruned
+	BackupPreRestore = "pre-restore" // Taken automatically before a restore
+	BackupUploaded   = "uploaded"    // Uploaded from another installation to restore
+)
+
+// DefaultBackupKeep is how many scheduled backups are kept when BackupConfig.Keep is unset.
+const DefaultBackupKeep = 7
+
+// Archive layout: the manifest first, then the database, then the uploads.
+const (
+	backupFormat       = 1
+	backupManifestName = "manifest.json"
+	backupDatabaseName = "database.sqlite"
+	backupUploadsDir   = "uploads/"
+)
+
+// backupName matches the names Backups gives its archives,
+// e.g. "backup-20260101-030000-scheduled.tar.gz".
+var backupName = regexp.MustCompile(`^backup-(\d{8}-\d{6})-(manual|scheduled|pre-restore|uploaded)\.tar\.gz$`)
+
+var (
+	// ErrBackupBusy is returned while another backup or restore is running.
+	ErrBackupBusy = errors.New("backups: another backup or restore is running")
+	// ErrBackupNotFound is returned for names that are not stored backups.
+	ErrBackupNotFound = errors.New("backups: no such backup")
+	// ErrBackupInvalid is returned for files that are not backup archives.
+	ErrBackupInvalid = errors.New("backups: not a backup archive")
+	// ErrBackupSchema is returned when restoring a backup made by a newer
+	// version, whose schema this build does not know.
+	ErrBackupSchema = errors.New("backups: backup is from a newer version")
+)
+
+// BackupStorage is where backup archives are kept: write-once objects that
+// can be listed and deleted.
+type BackupStorage interface {
+	ExportStorage
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+// BackupConfig controls scheduled backups and restores. The zero value
+// disables scheduled backups.
+type BackupConfig struct {
+	// Interval between scheduled backups; 0 disables them.
+	Interval time.Duration
+	// Keep is how many scheduled backups are kept. Defaults to DefaultBackupKeep.
+	// Manual, pre-restore and uploaded backups are kept until deleted.
+	Keep int
+	// SchemaVersion is the newest migration of this build. Backups with a
+	// newer schema are refused; older ones are restored and then migrated
+	// by AfterRestore.
+	SchemaVersion uint
+	// AfterRestore runs once the database and uploads have been restored,
+	// to migrate the restored schema and reload in-memory state.
+	AfterRestore func(ctx context.Context) error
+}
+
+// BackupManifest describes a backup archive. It is the first entry of the
+// archive, so it can be read without unpacking the rest.
+type BackupManifest struct {
+	Format        int       `json:"format"`
+	Kind          string    `json:"kind"`
+	CreatedAt     time.Time `json:"created_at"`
+	SchemaVersion uint      `json:"schema_version"`
+	DatabaseBytes int64     `json:"database_bytes"`
+	UploadFiles   int       `json:"upload_files"`
+	UploadBytes   int64     `json:"upload_bytes"`
+}
+
+// BackupInfo is a stored backup as listed on the backups page.
+type BackupInfo struct {
+	Name      string    // Object key, e.g. "backup-20260101-030000-scheduled.tar.gz"
+	Kind      string    // BackupManual, BackupScheduled, BackupPreRestore or BackupUploaded
+	CreatedAt time.Time // From the name (UTC)
+	Size      int64     // Archive size in bytes
+}
+
+// Backups snapshots the database and the uploads directory into a tar.gz
+// archive and restores such archives. The database is copied with SQLite's
+// online backup API, so a backup is consistent and the site keeps running
+// while it is taken. Archives are written to storage by Create and by the
+// scheduled job, or streamed straight to a download by WriteSnapshot.
+type Backups struct {
+	db        *sql.DB       // Primary database (single connection pool)
+	uploadDir string        // Uploads directory included in backups
+	storage   BackupStorage // Stored archives
+	logger    *slog.Logger  // Structured logger for backup jobs
+	config    BackupConfig  // Schedule, retention and restore hooks
+
+	mu sync.Mutex // Held by every backup and restore
+}
+
+// NewBackups creates the backup service.
+//
+// Parameters:
+//   - db: Primary database handle from database.InitDB
+//   - uploadDir: Uploads directory to include (UPLOAD_DIR)
+//   - storage: Where archives are stored
+//   - logger: Structured logger for backup jobs
+//   - config: Schedule, retention and restore hooks
+//
+// Returns:
+//   - *Backups: Service ready to use or schedule
+func NewBackups(db *sql.DB, uploadDir string, storage BackupStorage, logger *slog.Logger, config BackupConfig) *Backups {
+	if config.Keep <= 0 {
+		config.Keep = DefaultBackupKeep
+	}
+	return &Backups{db: db, uploadDir: uploadDir, storage: storage, logger: logger, config: config}
+}
+
+// Interval returns the time between scheduled backups, 0 when disabled.
+func (b *Backups) Interval() time.Duration {
+	return b.config.Interval
+}
+
+// Keep returns how many scheduled backups are kept.
+func (b *Backups) Keep() int {
+	return b.config.Keep
+}
+
+// Create takes a backup and stores it. Scheduled backups beyond Keep are
+// deleted afterwards, oldest first.
+//
+// Parameters:
+//   - ctx: Context for the backup
+//   - kind: BackupManual or BackupScheduled
+//
+// Returns:
+//   - BackupInfo: The stored backup
+//   - error: ErrBackupBusy, or any database or storage error
+func (b *Backups) Create(ctx context.Context, kind string) (BackupInfo, error) {
+	if !b.mu.TryLock() {
+		return BackupInfo{}, ErrBackupBusy
+	}
+	defer b.mu.Unlock()
+	info, err := b.create(ctx, kind)
+	if err != nil {
+		return info, err
+	}
+	if kind == BackupScheduled {
+		if err := b.prune(ctx); err != nil {
+			b.logger.Error("failed to prune scheduled backups", "error", err)
+		}
+	}
+	return info, nil
+}
+
+// create stores a new backup; b.mu must be held.
+func (b *Backups) create(ctx context.Context, kind string) (BackupInfo, error) {
+	start := time.Now()
+	info := BackupInfo{Name: b.newName(start, kind), Kind: kind, CreatedAt: start.UTC().Truncate(time.Second)}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := b.writeSnapshot(ctx, pw, kind)
+		pw.CloseWithError(err)
+	}()
+	err := b.storage.Put(ctx, info.Name, pr)
+	pr.CloseWithError(err) // Stops the writer if the storage gave up early
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("backups: store %s: %w", info.Name, err)
+	}
+
+	if objects, err := b.storage.List(ctx, info.Name); err == nil && len(objects) == 1 {
+		info.Size = objects[0].Size
+	}
+	b.logger.Info("backup created", "name", info.Name, "bytes", info.Size, "duration_ms", time.Since(start).Milliseconds())
+	return info, nil
+}
+
+// newName returns the object key for a backup taken at t.
+func (b *Backups) newName(t time.Time, kind string) string {
+	return fmt.Sprintf("backup-%s-%s.tar.gz", t.UTC().Format("20060102-150405"), kind)
+}
+
+// prune deletes the oldest scheduled backups beyond Keep.
+func (b *Backups) prune(ctx context.Context) error {
+	backups, err := b.List(ctx)
+	if err != nil {
+		return err
+	}
+	kept := 0
+	for _, backup := range backups {
+		if backup.Kind != BackupScheduled {
+			continue
+		}
+		if kept++; kept <= b.config.Keep {
+			continue
+		}
+		if err := b.storage.Delete(ctx, backup.Name); err != nil {
+			return err
+		}
+		b.logger.Info("pruned scheduled backup", "name", backup.Name)
+	}
+	return nil
+}
+
+// WriteSnapshot writes a new backup archive to w without storing it, for
+// direct downloads.
+//
+// Parameters:
+//   - ctx: Context for the backup
+//   - w: Destination of the tar.gz archive
+//
+// Returns:
+//   - BackupManifest: Description of the archive
+//   - error: ErrBackupBusy, or any database or write error
+func (b *Backups) WriteSnapshot(ctx context.Context, w io.Writer) (BackupManifest, error) {
+	if !b.mu.TryLock() {
+		return BackupManifest{}, ErrBackupBusy
+	}
+	defer b.mu.Unlock()
+	return b.writeSnapshot(ctx, w, BackupManual)
+}
+
+// writeSnapshot copies the database to a temp file with the online backup
+// API, then archives it with the uploads; b.mu must be held.
+func (b *Backups) writeSnapshot(ctx context.Context, w io.Writer, kind string) (BackupManifest, error) {
+	tmp, err := os.MkdirTemp("", "bluejay-backup-*")
+	if err != nil {
+		return BackupManifest{}, fmt.Errorf("backups: create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	manifest := BackupManifest{Format: backupFormat, Kind: kind, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	dbFile := filepath.Join(tmp, backupDatabaseName)
+	if manifest.SchemaVersion, err = b.copyDatabase(ctx, dbFile); err != nil {
+		return BackupManifest{}, err
+	}
+	dbInfo, err := os.Stat(dbFile)
+	if err != nil {
+		return BackupManifest{}, fmt.Errorf("backups: database copy: %w", err)
+	}
+	manifest.DatabaseBytes = dbInfo.Size()
+
+	uploads, err := b.uploadFiles()
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	for _, f := range uploads {
+		manifest.UploadFiles++
+		manifest.UploadBytes += f.size
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	if err := writeTarBytes(tw, backupManifestName, manifestJSON, manifest.CreatedAt); err != nil {
+		return BackupManifest{}, err
+	}
+	if err := writeTarFile(tw, backupDatabaseName, dbFile); err != nil {
+		return BackupManifest{}, err
+	}
+	for _, f := range uploads {
+		if err := ctx.Err(); err != nil {
+			return BackupManifest{}, err
+		}
+		if err := writeTarFile(tw, backupUploadsDir+f.name, filepath.Join(b.uploadDir, filepath.FromSlash(f.name))); err != nil {
+			return BackupManifest{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return BackupManifest{}, fmt.Errorf("backups: finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return BackupManifest{}, fmt.Errorf("backups: finish archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// sqliteBackuper is the online backup API of a modernc.org/sqlite connection.
+type sqliteBackuper interface {
+	NewBackup(dstURI string) (*sqlite.Backup, error)
+	NewRestore(srcURI string) (*sqlite.Backup, error)
+}
+
+// copyDatabase copies the live database to dst and returns its schema
+// version. The copy runs on the pool's single connection, so no write can
+// interleave with it.
+func (b *Backups) copyDatabase(ctx context.Context, dst string) (uint, error) {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("backups: database connection: %w", err)
+	}
+	defer conn.Close()
+
+	var version uint
+	if err := conn.QueryRowContext(ctx, "SELECT version FROM schema_migrations LIMIT 1").Scan(&version); err != nil {
+		return 0, fmt.Errorf("backups: read schema version: %w", err)
+	}
+	err = conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(sqliteBackuper)
+		if !ok {
+			return errors.New("backups: database driver has no online backup API")
+		}
+		bk, err := c.NewBackup(dst)
+		if err != nil {
+			return err
+		}
+		if _, err := bk.Step(-1); err != nil {
+			bk.Finish()
+			return err
+		}
+		return bk.Finish()
+	})
+	if err != nil {
+		return 0, fmt.Errorf("backups: copy database: %w", err)
+	}
+	return version, nil
+}
+
+// uploadFile is a regular file in the uploads directory.
+type uploadFile struct {
+	name string // Slash-separated path relative to the uploads directory
+	size int64
+}
+
+// uploadFiles lists the regular files in the uploads directory; symlinks and
+// dot files (e.g. .gitkeep, temp files) are skipped. A missing directory has
+// no files.
+func (b *Backups) uploadFiles() ([]uploadFile, error) {
+	var files []uploadFile
+	err := filepath.WalkDir(b.uploadDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == b.uploadDir && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && p != b.uploadDir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(b.uploadDir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, uploadFile{name: filepath.ToSlash(rel), size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backups: list uploads: %w", err)
+	}
+	return files, nil
+}
+
+// writeTarBytes adds a file with the given contents to the archive.
+func writeTarBytes(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}); err != nil {
+		return fmt.Errorf("backups: write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("backups: write %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeTarFile adds the file at src to the archive under name.
+func writeTarFile(tw *tar.Writer, name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("backups: open %s: %w", name, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("backups: stat %s: %w", name, err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: info.Size(), ModTime: info.ModTime(), Typeflag: tar.TypeReg}); err != nil {
+		return fmt.Errorf("backups: write %s: %w", name, err)
+	}
+	// A file that grew since the Stat is cut at the header size
+	if _, err := io.CopyN(tw, f, info.Size()); err != nil {
+		return fmt.Errorf("backups: write %s: %w", name, err)
+	}
+	return nil
+}
+
+// List returns the stored backups, newest first. Objects in the storage
+// that are not named like backups are ignored.
+//
+// Parameters:
+//   - ctx: Context for the storage listing
+//
+// Returns:
+//   - []BackupInfo: Stored backups
+//   - error: Storage error
+func (b *Backups) List(ctx context.Context) ([]BackupInfo, error) {
+	objects, err := b.storage.List(ctx, "backup-")
+	if err != nil {
+		return nil, err
+	}
+	var backups []BackupInfo
+	for _, o := range objects {
+		info, ok := parseBackupName(o.Key)
+		if !ok {
+			continue
+		}
+		info.Size = o.Size
+		backups = append(backups, info)
+	}
+	slices.SortFunc(backups, func(a, c BackupInfo) int {
+		if n := c.CreatedAt.Compare(a.CreatedAt); n != 0 {
+			return n
+		}
+		return strings.Compare(c.Name, a.Name)
+	})
+	return backups, nil
+}
+
+// parseBackupName reads the time and kind from a backup's name.
+func parseBackupName(name string) (BackupInfo, bool) {
+	m := backupName.FindStringSubmatch(name)
+	if m == nil {
+		return BackupInfo{}, false
+	}
+	created, err := time.Parse("20060102-150405", m[1])
+	if err != nil {
+		return BackupInfo{}, false
+	}
+	return BackupInfo{Name: name, Kind: m[2], CreatedAt: created}, true
+}
+
+// Open returns a stored backup for download. Callers must close it.
+//
+// Parameters:
+//   - ctx: Context for the storage read
+//   - name: Backup name from List
+//
+// Returns:
+//   - io.ReadCloser: The tar.gz archive
+//   - error: ErrBackupNotFound, or a storage error
+func (b *Backups) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	if _, ok := parseBackupName(name); !ok {
+		return nil, ErrBackupNotFound
+	}
+	r, err := b.storage.Open(ctx, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBackupNotFound
+	}
+	return r, err
+}
+
+// Delete removes a stored backup.
+//
+// Parameters:
+//   - ctx: Context for the storage delete
+//   - name: Backup name from List
+//
+// Returns:
+//   - error: ErrBackupNotFound for names that are not backups, or a storage error
+func (b *Backups) Delete(ctx context.Context, name string) error {
+	if _, ok := parseBackupName(name); !ok {
+		return ErrBackupNotFound
+	}
+	return b.storage.Delete(ctx, name)
+}
+
+// Inspect reads the manifest of a stored backup.
+//
+// Parameters:
+//   - ctx: Context for the storage read
+//   - name: Backup name from List
+//
+// Returns:
+//   - BackupManifest: What the backup contains
+//   - error: ErrBackupNotFound, ErrBackupInvalid, or a storage error
+func (b *Backups) Inspect(ctx context.Context, name string) (BackupManifest, error) {
+	r, err := b.Open(ctx, name)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	defer r.Close()
+	_, manifest, err := openBackupArchive(r)
+	return manifest, err
+}
+
+// openBackupArchive checks that r is a backup archive and returns a reader
+// positioned after its manifest.
+func openBackupArchive(r io.Reader) (*tar.Reader, BackupManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, BackupManifest{}, ErrBackupInvalid
+	}
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != backupManifestName {
+		return nil, BackupManifest{}, ErrBackupInvalid
+	}
+	var manifest BackupManifest
+	if err := json.NewDecoder(io.LimitReader(tr, 1<<20)).Decode(&manifest); err != nil || manifest.Format != backupFormat {
+		return nil, BackupManifest{}, ErrBackupInvalid
+	}
+	return tr, manifest, nil
+}
+
+// Import stores an uploaded archive, e.g. a download from another
+// installation, so it can be restored. Files that are not backup archives
+// are not kept.
+//
+// Parameters:
+//   - ctx: Context for the storage write
+//   - r: The uploaded tar.gz archive
+//
+// Returns:
+//   - BackupInfo: The stored backup
+//   - error: ErrBackupInvalid, or a storage error
+func (b *Backups) Import(ctx context.Context, r io.Reader) (BackupInfo, error) {
+	now := time.Now()
+	info := BackupInfo{Name: b.newName(now, BackupUploaded), Kind: BackupUploaded, CreatedAt: now.UTC().Truncate(time.Second)}
+	if err := b.storage.Put(ctx, info.Name, r); err != nil {
+		return BackupInfo{}, fmt.Errorf("backups: store %s: %w", info.Name, err)
+	}
+	if _, err := b.Inspect(ctx, info.Name); err != nil {
+		b.storage.Delete(ctx, info.Name)
+		return BackupInfo{}, err
+	}
+	if objects, err := b.storage.List(ctx, info.Name); err == nil && len(objects) == 1 {
+		info.Size = objects[0].Size
+	}
+	return info, nil
+}
+
+// Restore replaces the database with the one in a stored backup and writes
+// the backup's uploads into the uploads directory, then runs AfterRestore.
+// A pre-restore backup of the current state is stored first. Uploaded
+// files that are not in the backup are left in place.
+//
+// The database is restored with the online backup API on the live
+// connection, so the server keeps running; requests wait until it is done.
+//
+// Parameters:
+//   - ctx: Context for the restore
+//   - name: Backup name from List
+//
+// Returns:
+//   - BackupInfo: The pre-restore backup
+//   - error: ErrBackupBusy, ErrBackupNotFound, ErrBackupInvalid,
+//     ErrBackupSchema, or any database, storage or file error
+func (b *Backups) Restore(ctx context.Context, name string) (BackupInfo, error) {
+	if !b.mu.TryLock() {
+		return BackupInfo{}, ErrBackupBusy
+	}
+	defer b.mu.Unlock()
+
+	manifest, err := b.Inspect(ctx, name)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	if b.config.SchemaVersion > 0 && manifest.SchemaVersion > b.config.SchemaVersion {
+		return BackupInfo{}, ErrBackupSchema
+	}
+
+	tmp, err := os.MkdirTemp("", "bluejay-restore-*")
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("backups: create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+	dbFile := filepath.Join(tmp, backupDatabaseName)
+	if err := b.extract(ctx, name, dbFile, ""); err != nil {
+		return BackupInfo{}, err
+	}
+	if err := b.checkDatabase(ctx, dbFile); err != nil {
+		return BackupInfo{}, err
+	}
+
+	safety, err := b.create(ctx, BackupPreRestore)
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("backups: pre-restore backup: %w", err)
+	}
+	if err := b.restoreDatabase(ctx, dbFile); err != nil {
+		return safety, err
+	}
+	if err := b.extract(ctx, name, "", b.uploadDir); err != nil {
+		return safety, err
+	}
+	if b.config.AfterRestore != nil {
+		if err := b.config.AfterRestore(ctx); err != nil {
+			return safety, fmt.Errorf("backups: after restore: %w", err)
+		}
+	}
+	b.logger.Info("backup restored", "name", name, "schema_version", manifest.SchemaVersion, "upload_files", manifest.UploadFiles, "pre_restore_backup", safety.Name)
+	return safety, nil
+}
+
+// extract reads a stored backup and writes its database to dbFile and its
+// uploads into uploadDir; either may be "" to skip it. Entry names are
+// checked so an archive cannot write outside uploadDir.
+func (b *Backups) extract(ctx context.Context, name, dbFile, uploadDir string) error {
+	r, err := b.Open(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	tr, _, err := openBackupArchive(r)
+	if err != nil {
+		return err
+	}
+	foundDB := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ErrBackupInvalid
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch {
+		case hdr.Typeflag == tar.TypeDir:
+			continue
+		case hdr.Typeflag != tar.TypeReg:
+			return fmt.Errorf("%w: unexpected entry %q", ErrBackupInvalid, hdr.Name)
+		case hdr.Name == backupDatabaseName:
+			foundDB = true
+			if dbFile != "" {
+				if err := writeFileAtomic(dbFile, tr); err != nil {
+					return err
+				}
+			}
+		case strings.HasPrefix(hdr.Name, backupUploadsDir):
+			rel := path.Clean(strings.TrimPrefix(hdr.Name, backupUploadsDir))
+			if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+				return fmt.Errorf("%w: unsafe path %q", ErrBackupInvalid, hdr.Name)
+			}
+			if uploadDir != "" {
+				if err := writeFileAtomic(filepath.Join(uploadDir, filepath.FromSlash(rel)), tr); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("%w: unexpected entry %q", ErrBackupInvalid, hdr.Name)
+		}
+	}
+	if !foundDB {
+		return fmt.Errorf("%w: no database", ErrBackupInvalid)
+	}
+	return nil
+}
+
+// writeFileAtomic writes r to dst through a temp file in the same directory.
+func writeFileAtomic(dst string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("backups: create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".restore-*")
+	if err != nil {
+		return fmt.Errorf("backups: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("backups: write %s: %w", dst, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("backups: write %s: %w", dst, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("backups: write %s: %w", dst, err)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("backups: write %s: %w", dst, err)
+	}
+	return nil
+}
+
+// checkDatabase verifies that file is an intact SQLite database with a
+// migration history, by attaching it to the live connection.
+func (b *Backups) checkDatabase(ctx context.Context, file string) error {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("backups: database connection: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS restore_check", "file:"+file+"?mode=ro"); err != nil {
+		return fmt.Errorf("%w: %v", ErrBackupInvalid, err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "DETACH DATABASE restore_check")
+
+	var check string
+	if err := conn.QueryRowContext(ctx, "PRAGMA restore_check.quick_check").Scan(&check); err != nil || check != "ok" {
+		return fmt.Errorf("%w: database failed its integrity check", ErrBackupInvalid)
+	}
+	var dirty bool
+	if err := conn.QueryRowContext(ctx, "SELECT dirty FROM restore_check.schema_migrations LIMIT 1").Scan(&dirty); err != nil {
+		return fmt.Errorf("%w: database has no migration history", ErrBackupInvalid)
+	}
+	if dirty {
+		return fmt.Errorf("%w: database was backed up during a failed migration", ErrBackupInvalid)
+	}
+	return nil
+}
+
+// restoreDatabase copies file over the live database with the online backup
+// API. Other connections (such as the reporting pool) see the restored
+// contents on their next query.
+func (b *Backups) restoreDatabase(ctx context.Context, file string) error {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("backups: database connection: %w", err)
+	}
+	defer conn.Close()
+	err = conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(sqliteBackuper)
+		if !ok {
+			return errors.New("backups: database driver has no online backup API")
+		}
+		bk, err := c.NewRestore(file)
+		if err != nil {
+			return err
+		}
+		if _, err := bk.Step(-1); err != nil {
+			bk.Finish()
+			return err
+		}
+		return bk.Finish()
+	})
+	if err != nil {
+		return fmt.Errorf("backups: restore database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestBackups_CreateAndRestore(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	uploads := t.TempDir()
+	os.MkdirAll(filepath.Join(uploads, "products"), 0755)
+	os.WriteFile(filepath.Join(uploads, "products", "a.png"), []byte("png"), 0644)
+	os.WriteFile(filepath.Join(uploads, ".gitkeep"), nil, 0644)
+
+	if _, err := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Before", Slug: "before", Description: "d", Icon: "i"}); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+
+	restored := 0
+	backups := services.NewBackups(db, uploads, services.NewLocalStorage(t.TempDir()), slog.New(slog.NewTextHandler(io.Discard, nil)), services.BackupConfig{
+		AfterRestore: func(context.Context) error { restored++; return nil },
+	})
+	backup, err := backups.Create(ctx, services.BackupManual)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	manifest, err := backups.Inspect(ctx, backup.Name)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if manifest.Kind != services.BackupManual || manifest.SchemaVersion == 0 || manifest.UploadFiles != 1 || manifest.UploadBytes != 3 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	// Change the database and the uploads, then restore
+	if _, err := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "After", Slug: "after", Description: "d", Icon: "i"}); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	os.WriteFile(filepath.Join(uploads, "products", "a.png"), []byte("changed"), 0644)
+	os.WriteFile(filepath.Join(uploads, "products", "b.png"), []byte("new"), 0644)
+
+	safety, err := backups.Restore(ctx, backup.Name)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if safety.Kind != services.BackupPreRestore || restored != 1 {
+		t.Errorf("pre-restore backup = %+v, AfterRestore calls = %d", safety, restored)
+	}
+	var slugs []string
+	rows, err := db.Query(`SELECT slug FROM product_categories ORDER BY slug`)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	for rows.Next() {
+		var s string
+		rows.Scan(&s)
+		slugs = append(slugs, s)
+	}
+	rows.Close()
+	if len(slugs) != 1 || slugs[0] != "before" {
+		t.Errorf("categories after restore = %v, want [before]", slugs)
+	}
+	if data, _ := os.ReadFile(filepath.Join(uploads, "products", "a.png")); string(data) != "png" {
+		t.Errorf("a.png = %q, want the backed-up contents", data)
+	}
+	if _, err := os.Stat(filepath.Join(uploads, "products", "b.png")); err != nil {
+		t.Errorf("files not in the backup should be kept: %v", err)
+	}
+
+	list, err := backups.List(ctx)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("List = %v, %v; want the manual and the pre-restore backup", list, err)
+	}
+}
+
+func TestBackups_ScheduledPruning(t *testing.T) {
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	storage := services.NewLocalStorage(t.TempDir())
+	// Older scheduled backups and a manual one, which is never pruned
+	for _, name := range []string{
+		"backup-20260101-030000-scheduled.tar.gz",
+		"backup-20260102-030000-scheduled.tar.gz",
+		"backup-20260103-030000-manual.tar.gz",
+		"notes.txt",
+	} {
+		storage.Put(ctx, name, bytes.NewReader(nil))
+	}
+	backups := services.NewBackups(db, t.TempDir(), storage, slog.New(slog.NewTextHandler(io.Discard, nil)), services.BackupConfig{Keep: 2})
+	latest, err := backups.Create(ctx, services.BackupScheduled)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	list, err := backups.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var names []string
+	for _, b := range list {
+		names = append(names, b.Name)
+	}
+	want := []string{latest.Name, "backup-20260103-030000-manual.tar.gz", "backup-20260102-030000-scheduled.tar.gz"}
+	if len(names) != len(want) {
+		t.Fatalf("backups = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("backups = %v, want %v", names, want)
+			break
+		}
+	}
+	if !list[1].CreatedAt.Equal(time.Date(2026, 1, 3, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedAt = %v", list[1].CreatedAt)
+	}
+}
+
+func TestBackups_ImportRejectsUnsafeArchives(t *testing.T) {
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	uploads := t.TempDir()
+	backups := services.NewBackups(db, filepath.Join(uploads, "public"), services.NewLocalStorage(t.TempDir()), slog.New(slog.NewTextHandler(io.Discard, nil)), services.BackupConfig{})
+
+	if _, err := backups.Import(ctx, bytes.NewReader([]byte("not a backup"))); !errors.Is(err, services.ErrBackupInvalid) {
+		t.Errorf("Import(garbage) = %v, want ErrBackupInvalid", err)
+	}
+	if list, _ := backups.List(ctx); len(list) != 0 {
+		t.Errorf("invalid upload was kept: %v", list)
+	}
+
+	// A valid manifest followed by an entry that escapes the uploads directory
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct{ name, body string }{
+		{"manifest.json", `{"format":1,"kind":"manual","schema_version":1}`},
+		{"database.sqlite", "x"},
+		{"uploads/../../evil.txt", "x"},
+	} {
+		tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.body)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(f.body))
+	}
+	tw.Close()
+	gz.Close()
+
+	backup, err := backups.Import(ctx, &buf)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if _, err := backups.Restore(ctx, backup.Name); !errors.Is(err, services.ErrBackupInvalid) {
+		t.Errorf("Restore = %v, want ErrBackupInvalid", err)
+	}
+	if _, err := os.Stat(filepath.Join(uploads, "evil.txt")); err == nil {
+		t.Error("restore wrote outside the uploads directory")
+	}
+	if list, _ := backups.List(ctx); len(list) != 1 {
+		t.Errorf("a rejected restore should not take a pre-restore backup: %v", list)
+	}
+}
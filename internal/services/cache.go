@@ -12,6 +12,7 @@ import (
 type cacheItem struct {
 	value     interface{} // The cached data (can be any type)
 	expiresAt time.Time   // Absolute time when this cache entry becomes invalid
+	tags      []string    // Tags the entry was given with Tag, removed from the index with it
 }
 
 // Cache provides a thread-safe in-memory key-value store with automatic expiration.
@@ -22,8 +23,10 @@ type cacheItem struct {
 // The cache runs a background goroutine that periodically removes expired entries
 // to prevent unbounded memory growth.
 type Cache struct {
-	mu    sync.RWMutex           // Read-write mutex for thread-safe concurrent access
-	items map[string]cacheItem   // Internal storage mapping keys to cached items
+	mu       sync.RWMutex           // Read-write mutex for thread-safe concurrent access
+	items    map[string]cacheItem           // Internal storage mapping keys to cached items
+	tagged   map[string]map[string]struct{} // Tag -> keys given that tag (see Tag)
+	onDelete func(keys []string)            // Told which keys Delete, DeleteByPrefix and DeleteTagged removed (see OnDelete)
 }
 
 // NewCache creates and initializes a new Cache instance with automatic cleanup.
@@ -38,7 +41,8 @@ type Cache struct {
 func NewCache() *Cache {
 	// Initialize the cache with an empty items map
 	c := &Cache{
-		items: make(map[string]cacheItem),
+		items:  make(map[string]cacheItem),
+		tagged: make(map[string]map[string]struct{}),
 	}
 
 	// Start the background cleanup goroutine to remove expired entries periodically.
@@ -98,6 +102,10 @@ func (c *Cache) Set(key string, value interface{}, ttlSeconds int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// A replaced value drops the old value's tags; the caller tags the new
+	// value with what it was built from.
+	c.untag(key)
+
 	// Store the cache item with an absolute expiration timestamp.
 	// We use absolute time rather than duration to avoid recalculating
 	// expiration on every Get call.
@@ -119,10 +127,17 @@ func (c *Cache) Set(key string, value interface{}, ttlSeconds int) {
 func (c *Cache) Delete(key string) {
 	// Use write lock to ensure exclusive access during deletion
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	_, ok := c.items[key]
 
 	// Delete the cache entry (no-op if key doesn't exist)
+	c.untag(key)
 	delete(c.items, key)
+	onDelete := c.onDelete
+	c.mu.Unlock()
+
+	if ok && onDelete != nil {
+		onDelete([]string{key})
+	}
 }
 
 // DeleteByPrefix removes all cache entries whose keys start with the given prefix.
@@ -141,15 +156,103 @@ func (c *Cache) Delete(key string) {
 func (c *Cache) DeleteByPrefix(prefix string) {
 	// Use write lock to ensure exclusive access during bulk deletion
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
 	// Iterate through all cache entries and delete those matching the prefix.
 	// This approach is simple but may be slow for large caches with many keys.
+	var deleted []string
 	for k := range c.items {
 		if strings.HasPrefix(k, prefix) {
+			c.untag(k)
+			delete(c.items, k)
+			deleted = append(deleted, k)
+		}
+	}
+	onDelete := c.onDelete
+	c.mu.Unlock()
+
+	if len(deleted) > 0 && onDelete != nil {
+		onDelete(deleted)
+	}
+}
+
+// Tag records that the entry stored under key was built from the content
+// the tags name (such as ProductTag(12)), so DeleteTagged can remove exactly
+// the entries that show that content. Tags are dropped when the entry is
+// deleted, replaced by Set, or expires. Tagging a key that is not cached is a
+// no-op.
+//
+// Parameters:
+//   - key: The cache key of an entry already stored with Set
+//   - tags: The content the entry depends on
+func (c *Cache) Tag(key string, tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return
+	}
+	for _, tag := range tags {
+		keys := c.tagged[tag]
+		if keys == nil {
+			keys = make(map[string]struct{})
+			c.tagged[tag] = keys
+		}
+		if _, seen := keys[key]; seen {
+			continue
+		}
+		keys[key] = struct{}{}
+		item.tags = append(item.tags, tag)
+	}
+	c.items[key] = item
+}
+
+// DeleteTagged removes every cache entry given any of the tags, leaving
+// entries built from other content in place. Entries that were never tagged
+// are only removed by Delete and DeleteByPrefix.
+//
+// Parameters:
+//   - tags: The content that changed
+func (c *Cache) DeleteTagged(tags ...string) {
+	c.mu.Lock()
+
+	var deleted []string
+	for _, tag := range tags {
+		for k := range c.tagged[tag] {
+			c.untag(k)
 			delete(c.items, k)
+			deleted = append(deleted, k)
 		}
 	}
+	onDelete := c.onDelete
+	c.mu.Unlock()
+
+	if len(deleted) > 0 && onDelete != nil {
+		onDelete(deleted)
+	}
+}
+
+// untag removes key from the tag index. The caller must hold the write lock.
+func (c *Cache) untag(key string) {
+	for _, tag := range c.items[key].tags {
+		delete(c.tagged[tag], key)
+		if len(c.tagged[tag]) == 0 {
+			delete(c.tagged, tag)
+		}
+	}
+}
+
+// OnDelete sets fn to be called with the keys removed by each Delete,
+// DeleteByPrefix and DeleteTagged call, after the cache is unlocked, so
+// invalidated pages can be re-rendered (see CacheWarmer.Invalidated).
+// Entries that expire are not reported. fn must not block.
+//
+// Parameters:
+//   - fn: Receives the deleted keys; nil removes the hook
+func (c *Cache) OnDelete(fn func(keys []string)) {
+	c.mu.Lock()
+	c.onDelete = fn
+	c.mu.Unlock()
 }
 
 // cleanupLoop runs as a background goroutine that periodically removes expired
@@ -183,6 +286,7 @@ func (c *Cache) cleanupLoop() {
 		// the stored expiresAt timestamp.
 		for k, item := range c.items {
 			if now.After(item.expiresAt) {
+				c.untag(k)
 				delete(c.items, k)
 			}
 		}
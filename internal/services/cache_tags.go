@@ -0,0 +1,41 @@
+package services
+
+import (
+	// Standard library imports
+	"strconv" // Formats entity IDs into tags
+)
+
+// Cache tags name the content a cached public page was built from (see
+// Cache.Tag). Admin handlers delete by tag after an edit, so only the pages
+// that show the edited content are re-rendered instead of every page under
+// a prefix.
+const (
+	// ProductCatalogTag is given to the pages that list every published
+	// product: the products overview (with its per-family counts) and the
+	// A–Z index.
+	ProductCatalogTag = "products"
+
+	// SolutionCatalogTag is given to the pages that list every published
+	// solution: the solutions listing and, through their "other solutions"
+	// section, each solution page.
+	SolutionCatalogTag = "solutions"
+)
+
+// ProductTag is given to the pages that show the product with id outside
+// the category listings: its detail pages (variants, print version) and the
+// solution pages that link to it.
+func ProductTag(id int64) string {
+	return "product:" + strconv.FormatInt(id, 10)
+}
+
+// ProductCategoryTag is given to the category pages that list products
+// filed directly in the category with id. A page lists its whole subtree, so
+// it carries the tag of every category under it.
+func ProductCategoryTag(id int64) string {
+	return "product-category:" + strconv.FormatInt(id, 10)
+}
+
+// SolutionTag is given to the detail page of the solution with id.
+func SolutionTag(id int64) string {
+	return "solution:" + strconv.FormatInt(id, 10)
+}
@@ -1,6 +1,8 @@
 package services_test
 
 import (
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -108,3 +110,57 @@ func TestCache_DeleteNonexistent(t *testing.T) {
 	c.Delete("nonexistent")
 	c.DeleteByPrefix("nonexistent")
 }
+
+func TestCache_OnDelete(t *testing.T) {
+	c := services.NewCache()
+	var deleted []string
+	c.OnDelete(func(keys []string) { deleted = append(deleted, keys...) })
+
+	c.Set("page:products", "list", 60)
+	c.Set("page:products:detectors", "cat", 60)
+	c.Set("page:blog", "blog", 60)
+
+	c.Delete("page:blog")
+	c.Delete("page:blog") // Already gone: not reported again
+	c.DeleteByPrefix("page:products")
+	c.DeleteByPrefix("page:none")
+
+	sort.Strings(deleted)
+	if got := strings.Join(deleted, ","); got != "page:blog,page:products,page:products:detectors" {
+		t.Errorf("reported %q", got)
+	}
+}
+
+func TestCache_Tags(t *testing.T) {
+	c := services.NewCache()
+	var deleted []string
+	c.OnDelete(func(keys []string) { deleted = append(deleted, keys...) })
+
+	c.Set("page:products:sensors:a", "a", 60)
+	c.Set("page:products:sensors:b", "b", 60)
+	c.Set("page:products:sensors", "cat", 60)
+	c.Tag("page:products:sensors:a", services.ProductTag(1))
+	c.Tag("page:products:sensors:b", services.ProductTag(2))
+	c.Tag("page:products:sensors", services.ProductCategoryTag(7), services.ProductCategoryTag(7))
+	c.Tag("page:none", services.ProductTag(1)) // Not cached: ignored
+
+	c.DeleteTagged(services.ProductTag(1), services.ProductCategoryTag(7))
+	sort.Strings(deleted)
+	if got := strings.Join(deleted, ","); got != "page:products:sensors,page:products:sensors:a" {
+		t.Errorf("reported %q", got)
+	}
+	if _, ok := c.Get("page:products:sensors:b"); !ok {
+		t.Error("page of another product was removed")
+	}
+
+	// A replaced value loses its tags
+	c.Set("page:products:sensors:b", "b2", 60)
+	deleted = nil
+	c.DeleteTagged(services.ProductTag(2))
+	if len(deleted) != 0 {
+		t.Errorf("untagged replacement removed: %v", deleted)
+	}
+	if _, ok := c.Get("page:products:sensors:b"); !ok {
+		t.Error("replacement removed by an old tag")
+	}
+}
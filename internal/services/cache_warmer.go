@@ -0,0 +1,216 @@
+package services
+
+import (
+	// Standard library imports
+	"context"  // Job cancellation
+	"log/slog" // Structured logging for job progress and failures
+	"net/http" // In-process requests against the application handler
+	"sort"     // Stable order of re-rendered pages
+	"sync"     // Guards the remembered and queued pages
+	"time"     // Delay before re-rendering invalidated pages
+)
+
+// CacheWarmerUserAgent identifies requests made by CacheWarmer, so analytics
+// and request logs can tell them apart from visitors.
+const CacheWarmerUserAgent = "bluejay-cache-warmer"
+
+// Re-rendering of invalidated pages (see CacheWarmer.Start).
+const (
+	// rewarmDelay lets the invalidations of one admin save (and the commit
+	// before them) complete before the affected pages are re-rendered.
+	rewarmDelay = 2 * time.Second
+
+	// maxRememberedPages bounds how many cached pages are remembered for
+	// re-rendering; pages cached beyond it are left to the next visitor.
+	maxRememberedPages = 2000
+)
+
+// CacheWarmer pre-renders public pages by requesting them from the application
+// handler in-process. Pages whose HTML is already cached are served from the
+// cache, so a run only pays the render cost for pages that have expired or
+// been invalidated.
+//
+// It also re-renders pages right after an admin change invalidates them:
+// public handlers Remember the path of each page they cache, the page cache
+// reports deleted keys to Invalidated, and the loop started by Start requests
+// those paths again in the background, so the next visitor does not pay the
+// render cost.
+type CacheWarmer struct {
+	handler http.Handler                                // Application handler (the Echo instance)
+	logger  *slog.Logger                                // Structured logger for job progress
+	urls    func(ctx context.Context) ([]string, error) // Site-relative paths to warm
+
+	mu     sync.Mutex          // Guards pages and queued
+	pages  map[string]string   // Path that rendered each cached page, by cache key
+	queued map[string]struct{} // Paths of invalidated pages waiting to be re-rendered
+	wake   chan struct{}       // Signals the Start loop that paths were queued
+}
+
+// NewCacheWarmer creates a cache warmer.
+//
+// Parameters:
+//   - handler: Application handler that renders and caches the pages
+//   - logger: Structured logger for job progress and failures
+//   - urls: Returns the site-relative paths to warm on each run
+//
+// Returns:
+//   - *CacheWarmer: Warmer ready to run or schedule
+func NewCacheWarmer(handler http.Handler, logger *slog.Logger, urls func(ctx context.Context) ([]string, error)) *CacheWarmer {
+	return &CacheWarmer{
+		handler: handler,
+		logger:  logger,
+		urls:    urls,
+		pages:   map[string]string{},
+		queued:  map[string]struct{}{},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Remember records that the page cached under key is rendered by path, so
+// it is re-rendered when key is invalidated.
+//
+// Parameters:
+//   - key: Page cache key, e.g. "page:products:sensors"
+//   - path: Site-relative path and query that rendered it, with any locale prefix
+func (w *CacheWarmer) Remember(key, path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.pages[key]; ok || len(w.pages) < maxRememberedPages {
+		w.pages[key] = path
+	}
+}
+
+// Invalidated queues the remembered pages among keys for re-rendering. It is
+// the page cache's OnDelete hook and returns without waiting for them.
+//
+// Parameters:
+//   - keys: Cache keys that were deleted
+func (w *CacheWarmer) Invalidated(keys []string) {
+	w.mu.Lock()
+	queued := false
+	for _, key := range keys {
+		if path, ok := w.pages[key]; ok {
+			delete(w.pages, key)
+			w.queued[path] = struct{}{}
+			queued = true
+		}
+	}
+	w.mu.Unlock()
+	if queued {
+		select {
+		case w.wake <- struct{}{}:
+		default: // A wake-up is already pending
+		}
+	}
+}
+
+// Start runs the loop that re-renders invalidated pages until ctx is
+// cancelled. Each batch waits rewarmDelay after the first invalidation, then
+// requests its pages one at a time like Run.
+//
+// Parameters:
+//   - ctx: Stops the loop when cancelled
+func (w *CacheWarmer) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.wake:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(rewarmDelay):
+			}
+			if warmed, total := w.Rewarm(ctx); total > 0 {
+				w.logger.Debug("cache warmer: re-rendered invalidated pages", "warmed", warmed, "pages", total)
+			}
+		}
+	}()
+}
+
+// Rewarm requests the queued pages now. Start calls it after each batch of
+// invalidations; tests call it directly.
+//
+// Parameters:
+//   - ctx: Stops the run early when cancelled
+//
+// Returns:
+//   - int: Pages that rendered with 200 OK
+//   - int: Pages requested
+func (w *CacheWarmer) Rewarm(ctx context.Context) (int, int) {
+	w.mu.Lock()
+	paths := make([]string, 0, len(w.queued))
+	for path := range w.queued {
+		paths = append(paths, path)
+	}
+	w.queued = map[string]struct{}{}
+	w.mu.Unlock()
+	sort.Strings(paths)
+	return w.warm(ctx, paths), len(paths)
+}
+
+// Run requests every page once, sequentially so warming never competes with
+// visitors for the single SQLite connection more than one request at a time.
+//
+// Parameters:
+//   - ctx: Stops the run early when cancelled
+//
+// Returns:
+//   - int: Pages that rendered with 200 OK
+//   - error: Error listing the pages to warm
+func (w *CacheWarmer) Run(ctx context.Context) (int, error) {
+	paths, err := w.urls(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return w.warm(ctx, paths), nil
+}
+
+// warm requests each path in turn and returns how many rendered with 200 OK.
+func (w *CacheWarmer) warm(ctx context.Context, paths []string) int {
+	warmed := 0
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			break
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			w.logger.Warn("cache warmer: invalid path", "path", path, "error", err)
+			continue
+		}
+		req.RemoteAddr = "127.0.0.1:0"
+		req.Header.Set("User-Agent", CacheWarmerUserAgent)
+		rec := &discardResponse{header: http.Header{}}
+		w.handler.ServeHTTP(rec, req)
+		if rec.status == 0 || rec.status == http.StatusOK {
+			warmed++
+		} else {
+			w.logger.Warn("cache warmer: unexpected status", "path", path, "status", rec.status)
+		}
+	}
+	return warmed
+}
+
+// discardResponse is an http.ResponseWriter that keeps only the status code;
+// the rendered HTML is already stored in the page cache by the handler.
+type discardResponse struct {
+	header http.Header
+	status int
+}
+
+func (r *discardResponse) Header() http.Header { return r.header }
+
+func (r *discardResponse) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return len(b), nil
+}
+
+func (r *discardResponse) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestCacheWarmer_Run(t *testing.T) {
+	var seen []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.String())
+		if r.UserAgent() != services.CacheWarmerUserAgent {
+			t.Errorf("unexpected User-Agent %q", r.UserAgent())
+		}
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	urls := func(context.Context) ([]string, error) {
+		return []string{"/products/sensors", "/products/sensors?page=2", "/missing"}, nil
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	warmed, err := services.NewCacheWarmer(handler, logger, urls).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if warmed != 2 {
+		t.Errorf("warmed %d pages, want 2", warmed)
+	}
+	if len(seen) != 3 || seen[1] != "/products/sensors?page=2" {
+		t.Errorf("requested %v", seen)
+	}
+
+	failing := func(context.Context) ([]string, error) { return nil, errors.New("db down") }
+	if _, err := services.NewCacheWarmer(handler, logger, failing).Run(context.Background()); err == nil {
+		t.Error("expected the URL source error to be returned")
+	}
+}
+
+func TestCacheWarmer_Rewarm(t *testing.T) {
+	var seen []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.URL.RequestURI())
+		_, _ = w.Write([]byte("ok"))
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	w := services.NewCacheWarmer(handler, logger, nil)
+	cache := services.NewCache()
+	cache.OnDelete(w.Invalidated)
+
+	for key, path := range map[string]string{
+		"page:products:sensors":         "/products/sensors?page=2",
+		"page:products:sensors:thermal": "/de/products/sensors/thermal",
+		"page:blog":                     "/blog",
+	} {
+		cache.Set(key, "<html>", 60)
+		w.Remember(key, path)
+	}
+	cache.Set("page:products", "<html>", 60) // Cached but never remembered
+
+	cache.DeleteByPrefix("page:products")
+	warmed, total := w.Rewarm(context.Background())
+	if warmed != 2 || total != 2 {
+		t.Errorf("warmed %d of %d pages, want 2 of 2", warmed, total)
+	}
+	if len(seen) != 2 || seen[0] != "/de/products/sensors/thermal" || seen[1] != "/products/sensors?page=2" {
+		t.Errorf("requested %v", seen)
+	}
+
+	// Re-rendered pages are forgotten until they are cached again
+	cache.DeleteByPrefix("page:products")
+	if _, total := w.Rewarm(context.Background()); total != 0 {
+		t.Errorf("requested %d forgotten pages", total)
+	}
+}
+
+func TestCacheWarmer_Start(t *testing.T) {
+	done := make(chan string, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done <- r.URL.Path
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	w := services.NewCacheWarmer(handler, logger, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.Start(ctx)
+
+	w.Remember("page:about", "/about")
+	w.Invalidated([]string{"page:about"})
+	select {
+	case path := <-done:
+		if path != "/about" {
+			t.Errorf("re-rendered %q", path)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("invalidated page was not re-rendered")
+	}
+}
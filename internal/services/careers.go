@@ -0,0 +1,325 @@
+package services
+
+import (
+	// Standard library imports
+	"context"        // Request cancellation for queries and storage
+	"crypto/rand"    // Random CV storage keys
+	"encoding/json"  // Structured data for Google Jobs
+	"errors"         // Application errors
+	"fmt"            // CV storage keys and error wrapping
+	"html"           // Escaping the description for the structured data
+	"io"             // CV readers
+	"log/slog"       // Structured logging of orphaned CVs
+	"mime/multipart" // Uploaded CVs
+	"path/filepath"  // CV file names and extensions
+	"strings"        // Trimming form values and splitting the description
+	"time"           // Closing dates
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Employment types (job_postings.employment_type).
+const (
+	JobFullTime   = "full_time"
+	JobPartTime   = "part_time"
+	JobContract   = "contract"
+	JobTemporary  = "temporary"
+	JobInternship = "internship"
+)
+
+// JobEmploymentTypes lists the employment types in the order the admin form
+// offers them.
+var JobEmploymentTypes = []string{JobFullTime, JobPartTime, JobContract, JobTemporary, JobInternship}
+
+// JobEmploymentTypeLabels names the employment types on the site and in the
+// admin.
+var JobEmploymentTypeLabels = map[string]string{
+	JobFullTime:   "Full-time",
+	JobPartTime:   "Part-time",
+	JobContract:   "Contract",
+	JobTemporary:  "Temporary",
+	JobInternship: "Internship",
+}
+
+// googleEmploymentTypes maps employment types to the schema.org values Google
+// Jobs understands.
+var googleEmploymentTypes = map[string]string{
+	JobFullTime:   "FULL_TIME",
+	JobPartTime:   "PART_TIME",
+	JobContract:   "CONTRACTOR",
+	JobTemporary:  "TEMPORARY",
+	JobInternship: "INTERN",
+}
+
+// JobApplicationStatuses lists the steps of the hiring process
+// (job_applications.status) in order. New applications start as "new".
+var JobApplicationStatuses = []string{"new", "reviewing", "interview", "offer", "hired", "rejected"}
+
+// CVExtensions lists the file types accepted as CVs.
+var CVExtensions = []string{".pdf", ".doc", ".docx", ".odt", ".rtf"}
+
+// MaxCVSize is the largest CV accepted, in bytes.
+const MaxCVSize = 10 * 1024 * 1024
+
+// Errors returned by Careers.Apply. Their messages are shown to applicants.
+var (
+	ErrJobApplicationInvalidEmail = errors.New("enter a valid email address")
+	ErrJobClosed                  = errors.New("this position is no longer open")
+	ErrCVMissing                  = errors.New("attach your CV")
+	ErrCVType                     = errors.New("attach your CV as a PDF, Word, OpenDocument or RTF file")
+	ErrCVTooLarge                 = errors.New("your CV is larger than 10 MB")
+)
+
+// JobClockLayout formats "now" for the open postings queries, matching the
+// stored timestamps.
+const JobClockLayout = "2006-01-02 15:04:05"
+
+// JobApplicationInput is the apply form of a job posting.
+type JobApplicationInput struct {
+	Name        string                // Applicant name
+	Email       string                // Applicant address
+	Phone       string                // Optional phone number
+	CoverLetter string                // Optional cover letter
+	CV          *multipart.FileHeader // Uploaded CV
+	IPAddress   string                // Client IP
+}
+
+// Careers lists open job postings for /careers and records applications.
+// CVs are kept in storage outside the public uploads and are only served to
+// the admin.
+type Careers struct {
+	queries *sqlc.Queries // Posting and application rows
+	storage ExportStorage // CV files
+	logger  *slog.Logger  // CVs that could not be deleted
+}
+
+// NewCareers creates the careers service.
+//
+// Parameters:
+//   - queries: Database queries
+//   - storage: Where CVs are kept (APPLICATION_DIR)
+//   - logger: Structured logger
+func NewCareers(queries *sqlc.Queries, storage ExportStorage, logger *slog.Logger) *Careers {
+	return &Careers{queries: queries, storage: storage, logger: logger}
+}
+
+// Open returns the open job postings by department and title.
+func (cr *Careers) Open(ctx context.Context, now time.Time) ([]sqlc.JobPosting, error) {
+	return cr.queries.ListOpenJobPostings(ctx, now.UTC().Format(JobClockLayout))
+}
+
+// JobOpen reports whether a published posting still takes applications at
+// now.
+func JobOpen(job sqlc.JobPosting, now time.Time) bool {
+	return job.IsPublished == 1 && (!job.ValidThrough.Valid || !now.After(job.ValidThrough.Time))
+}
+
+// JobPath returns the public page of a job posting.
+func JobPath(job sqlc.JobPosting) string {
+	return "/careers/" + job.Slug
+}
+
+// Apply stores the CV and records an application for job.
+//
+// Parameters:
+//   - job: Published posting applied for
+//   - in: Apply form values; Name, Email and CV are required
+//
+// Returns:
+//   - sqlc.JobApplication: Recorded application
+//   - error: ErrJobApplicationInvalidEmail, ErrJobClosed, ErrCVMissing,
+//     ErrCVType, ErrCVTooLarge, or a storage or database error
+func (cr *Careers) Apply(ctx context.Context, job sqlc.JobPosting, in JobApplicationInput) (sqlc.JobApplication, error) {
+	in.Email = strings.TrimSpace(in.Email)
+	switch {
+	case !isEmailAddress(in.Email):
+		return sqlc.JobApplication{}, ErrJobApplicationInvalidEmail
+	case !JobOpen(job, time.Now()):
+		return sqlc.JobApplication{}, ErrJobClosed
+	case in.CV == nil || in.CV.Size == 0:
+		return sqlc.JobApplication{}, ErrCVMissing
+	case !hasExtension(in.CV.Filename, CVExtensions):
+		return sqlc.JobApplication{}, ErrCVType
+	case in.CV.Size > MaxCVSize:
+		return sqlc.JobApplication{}, ErrCVTooLarge
+	}
+
+	// The key is random so applicants cannot guess each other's files, and
+	// keeps only the extension of the uploaded name
+	key := fmt.Sprintf("cv/%s%s", strings.ToLower(rand.Text()), strings.ToLower(filepath.Ext(in.CV.Filename)))
+	src, err := in.CV.Open()
+	if err != nil {
+		return sqlc.JobApplication{}, fmt.Errorf("open CV: %w", err)
+	}
+	defer src.Close()
+	if err := cr.storage.Put(ctx, key, src); err != nil {
+		return sqlc.JobApplication{}, fmt.Errorf("store CV: %w", err)
+	}
+
+	app, err := cr.queries.CreateJobApplication(ctx, sqlc.CreateJobApplicationParams{
+		JobID:       job.ID,
+		Name:        in.Name,
+		Email:       in.Email,
+		Phone:       in.Phone,
+		CoverLetter: in.CoverLetter,
+		CvKey:       key,
+		CvFilename:  filepath.Base(in.CV.Filename),
+		CvSize:      in.CV.Size,
+		IpAddress:   in.IPAddress,
+	})
+	if err != nil {
+		cr.deleteCV(ctx, key)
+		return sqlc.JobApplication{}, err
+	}
+	return app, nil
+}
+
+// OpenCV returns the CV of an application. Callers must close it.
+func (cr *Careers) OpenCV(ctx context.Context, app sqlc.JobApplication) (io.ReadCloser, error) {
+	return cr.storage.Open(ctx, app.CvKey)
+}
+
+// DeleteApplication deletes an application and its CV.
+func (cr *Careers) DeleteApplication(ctx context.Context, app sqlc.JobApplication) error {
+	if err := cr.queries.DeleteJobApplication(ctx, app.ID); err != nil {
+		return err
+	}
+	cr.deleteCV(ctx, app.CvKey)
+	return nil
+}
+
+// DeleteJob deletes a job posting with its applications and their CVs.
+func (cr *Careers) DeleteJob(ctx context.Context, job sqlc.JobPosting) error {
+	keys, err := cr.queries.ListJobApplicationCVKeys(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	if err := cr.queries.DeleteJobPosting(ctx, job.ID); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		cr.deleteCV(ctx, key)
+	}
+	return nil
+}
+
+// deleteCV removes a CV file. The row is gone already, so a failure only
+// leaves an orphaned file and is logged.
+func (cr *Careers) deleteCV(ctx context.Context, key string) {
+	if err := cr.storage.Delete(ctx, key); err != nil {
+		cr.logger.ErrorContext(ctx, "failed to delete CV", "key", key, "error", err)
+	}
+}
+
+// JobParagraphs splits a posting description into paragraphs at blank
+// lines.
+func JobParagraphs(description string) []string {
+	var paragraphs []string
+	for _, p := range strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return paragraphs
+}
+
+// jobPostingLD is the schema.org JobPosting Google Jobs reads, in the order
+// of Google's documentation.
+type jobPostingLD struct {
+	Context                       string         `json:"@context"`
+	Type                          string         `json:"@type"`
+	Title                         string         `json:"title"`
+	Description                   string         `json:"description"`
+	Identifier                    ldIdentifier   `json:"identifier"`
+	DatePosted                    string         `json:"datePosted"`
+	ValidThrough                  string         `json:"validThrough,omitempty"`
+	EmploymentType                string         `json:"employmentType"`
+	HiringOrganization            ldOrganization `json:"hiringOrganization"`
+	JobLocation                   *ldPlace       `json:"jobLocation,omitempty"`
+	JobLocationType               string         `json:"jobLocationType,omitempty"`
+	ApplicantLocationRequirements *ldCountry     `json:"applicantLocationRequirements,omitempty"`
+	DirectApply                   bool           `json:"directApply"`
+	URL                           string         `json:"url"`
+}
+
+type ldIdentifier struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ldOrganization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs"`
+	Logo   string `json:"logo,omitempty"`
+}
+
+type ldPlace struct {
+	Type    string          `json:"@type"`
+	Address ldPostalAddress `json:"address"`
+}
+
+type ldPostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+type ldCountry struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// JobPostingJSONLD returns the JSON-LD structured data of an open posting
+// for Google Jobs. Remote postings are marked TELECOMMUTE and limited to
+// their country when one is set.
+//
+// Parameters:
+//   - job: Published posting
+//   - baseURL: Public site URL, without trailing slash
+//   - orgName: Name of the hiring organization (the site name)
+//   - logoURL: Absolute URL of the organization logo, or ""
+func JobPostingJSONLD(job sqlc.JobPosting, baseURL, orgName, logoURL string) ([]byte, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	var desc strings.Builder
+	if job.Summary != "" {
+		fmt.Fprintf(&desc, "<p>%s</p>", html.EscapeString(job.Summary))
+	}
+	for _, p := range JobParagraphs(job.Description) {
+		fmt.Fprintf(&desc, "<p>%s</p>", strings.ReplaceAll(html.EscapeString(p), "\n", "<br>"))
+	}
+	posted := job.CreatedAt
+	if job.PostedAt.Valid {
+		posted = job.PostedAt.Time
+	}
+
+	ld := jobPostingLD{
+		Context:            "https://schema.org/",
+		Type:               "JobPosting",
+		Title:              job.Title,
+		Description:        desc.String(),
+		Identifier:         ldIdentifier{Type: "PropertyValue", Name: orgName, Value: fmt.Sprintf("job-%d", job.ID)},
+		DatePosted:         posted.UTC().Format("2006-01-02"),
+		EmploymentType:     googleEmploymentTypes[job.EmploymentType],
+		HiringOrganization: ldOrganization{Type: "Organization", Name: orgName, SameAs: baseURL, Logo: logoURL},
+		DirectApply:        true,
+		URL:                baseURL + JobPath(job),
+	}
+	if job.ValidThrough.Valid {
+		ld.ValidThrough = job.ValidThrough.Time.UTC().Format(time.RFC3339)
+	}
+	if job.Remote == 1 {
+		ld.JobLocationType = "TELECOMMUTE"
+		if job.Country != "" {
+			ld.ApplicantLocationRequirements = &ldCountry{Type: "Country", Name: job.Country}
+		}
+	}
+	if job.Location != "" || (job.Remote != 1 && job.Country != "") {
+		ld.JobLocation = &ldPlace{Type: "Place", Address: ldPostalAddress{
+			Type: "PostalAddress", AddressLocality: job.Location, AddressCountry: job.Country,
+		}}
+	}
+	return json.Marshal(ld)
+}
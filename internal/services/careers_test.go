@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// cvFile returns an uploaded file named name holding content.
+func cvFile(t *testing.T, name, content string) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("cv", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(part, content)
+	w.Close()
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["cv"][0]
+}
+
+func TestJobPostingJSONLD(t *testing.T) {
+	job := sqlc.JobPosting{
+		ID:             3,
+		Title:          "Firmware Engineer",
+		Slug:           "firmware-engineer",
+		Location:       "Hyderabad",
+		Country:        "IN",
+		EmploymentType: services.JobContract,
+		Remote:         1,
+		Summary:        "Build <sensors>",
+		Description:    "First paragraph\n\nSecond & last",
+		ValidThrough:   sql.NullTime{Time: time.Date(2026, 5, 31, 18, 30, 0, 0, time.UTC), Valid: true},
+		PostedAt:       sql.NullTime{Time: time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC), Valid: true},
+		CreatedAt:      time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	data, err := services.JobPostingJSONLD(job, "https://example.com/", "Bluejay", "https://example.com/logo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ld map[string]interface{}
+	if err := json.Unmarshal(data, &ld); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]interface{}{
+		"@type":           "JobPosting",
+		"title":           "Firmware Engineer",
+		"description":     "<p>Build &lt;sensors&gt;</p><p>First paragraph</p><p>Second &amp; last</p>",
+		"datePosted":      "2026-04-01",
+		"validThrough":    "2026-05-31T18:30:00Z",
+		"employmentType":  "CONTRACTOR",
+		"jobLocationType": "TELECOMMUTE",
+		"directApply":     true,
+		"url":             "https://example.com/careers/firmware-engineer",
+	} {
+		if ld[key] != want {
+			t.Errorf("%s = %v, want %v", key, ld[key], want)
+		}
+	}
+	org := ld["hiringOrganization"].(map[string]interface{})
+	if org["name"] != "Bluejay" || org["logo"] != "https://example.com/logo.png" {
+		t.Errorf("hiringOrganization = %v", org)
+	}
+	if req := ld["applicantLocationRequirements"].(map[string]interface{}); req["name"] != "IN" {
+		t.Errorf("applicantLocationRequirements = %v", req)
+	}
+	addr := ld["jobLocation"].(map[string]interface{})["address"].(map[string]interface{})
+	if addr["addressLocality"] != "Hyderabad" || addr["addressCountry"] != "IN" {
+		t.Errorf("address = %v", addr)
+	}
+
+	// Without a closing date or remote work those fields are left out
+	job.ValidThrough, job.Remote = sql.NullTime{}, 0
+	data, _ = services.JobPostingJSONLD(job, "https://example.com", "Bluejay", "")
+	ld = nil
+	json.Unmarshal(data, &ld)
+	for _, key := range []string{"validThrough", "jobLocationType", "applicantLocationRequirements"} {
+		if _, ok := ld[key]; ok {
+			t.Errorf("%s set on an on-site posting without a closing date", key)
+		}
+	}
+}
+
+func TestCareers_Apply(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	dir := t.TempDir()
+	careers := services.NewCareers(queries, services.NewLocalStorage(dir), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	job, err := queries.CreateJobPosting(ctx, sqlc.CreateJobPostingParams{
+		Title: "Firmware Engineer", Slug: "firmware-engineer", EmploymentType: services.JobFullTime,
+		Location: "Hyderabad", IsPublished: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if open, _ := careers.Open(ctx, time.Now()); len(open) != 1 {
+		t.Fatalf("open postings = %d, want 1", len(open))
+	}
+
+	for name, tc := range map[string]struct {
+		email string
+		cv    *multipart.FileHeader
+		want  error
+	}{
+		"bad email":  {"jane", cvFile(t, "cv.pdf", "%PDF"), services.ErrJobApplicationInvalidEmail},
+		"no CV":      {"jane@example.com", nil, services.ErrCVMissing},
+		"empty CV":   {"jane@example.com", cvFile(t, "cv.pdf", ""), services.ErrCVMissing},
+		"wrong type": {"jane@example.com", cvFile(t, "cv.exe", "MZ"), services.ErrCVType},
+	} {
+		_, err := careers.Apply(ctx, job, services.JobApplicationInput{Name: "Jane", Email: tc.email, CV: tc.cv})
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	app, err := careers.Apply(ctx, job, services.JobApplicationInput{
+		Name: "Jane", Email: " jane@example.com ", CV: cvFile(t, "Jane Doe CV.PDF", "%PDF-1.7"), IPAddress: "203.0.113.9",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Email != "jane@example.com" || app.CvFilename != "Jane Doe CV.PDF" || app.Status != "new" || filepath.Ext(app.CvKey) != ".pdf" {
+		t.Errorf("application = %+v", app)
+	}
+	rc, err := careers.OpenCV(ctx, app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(content) != "%PDF-1.7" {
+		t.Errorf("stored CV = %q", content)
+	}
+
+	// A closed posting takes no more applications
+	job.ValidThrough = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+	if _, err := careers.Apply(ctx, job, services.JobApplicationInput{Name: "John", Email: "john@example.com", CV: cvFile(t, "cv.pdf", "%PDF")}); !errors.Is(err, services.ErrJobClosed) {
+		t.Errorf("closed posting: err = %v", err)
+	}
+
+	// Deleting the posting deletes the CVs of its applications
+	if err := careers.DeleteJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, app.CvKey)); !os.IsNotExist(err) {
+		t.Errorf("CV left after deleting the posting: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	// Standard library imports
+	"errors"  // Parent validation errors
+	"strings" // Indentation for nested select options
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// MaxCategoryDepth is the deepest a product category may be nested:
+// families (1) → series (2) → types (3).
+const MaxCategoryDepth = 3
+
+// Parent validation errors returned by CategoryTree.ValidateParent.
+var (
+	ErrCategoryCycle    = errors.New("categories: a category cannot be placed under itself or one of its subcategories")
+	ErrCategoryTooDeep  = errors.New("categories: nesting would exceed the maximum category depth")
+	ErrCategoryNotFound = errors.New("categories: parent category does not exist")
+)
+
+// CategoryNode is one product category with its place in the hierarchy.
+type CategoryNode struct {
+	Category sqlc.ProductCategory // The category row
+	Parent   *CategoryNode        // Parent category, nil for top-level families
+	Children []*CategoryNode      // Direct subcategories in display order
+	Depth    int                  // 1 for families, 2 for series, 3 for types
+	Count    int64                // Products directly in this category
+	Total    int64                // Products in this category and all descendants
+}
+
+// URL returns the public path of the category: /products/<slug> for
+// families and /products/<parent-slug>/<slug> for subcategories.
+func (n *CategoryNode) URL() string {
+	if n.Parent == nil {
+		return "/products/" + n.Category.Slug
+	}
+	return "/products/" + n.Parent.Category.Slug + "/" + n.Category.Slug
+}
+
+// Path returns the chain of categories from the top-level family down to n.
+func (n *CategoryNode) Path() []*CategoryNode {
+	var path []*CategoryNode
+	for cur := n; cur != nil; cur = cur.Parent {
+		path = append([]*CategoryNode{cur}, path...)
+	}
+	return path
+}
+
+// Height returns the number of levels in the subtree rooted at n (1 for a
+// category without subcategories).
+func (n *CategoryNode) Height() int {
+	h := 0
+	for _, child := range n.Children {
+		if ch := child.Height(); ch > h {
+			h = ch
+		}
+	}
+	return h + 1
+}
+
+// IsDescendantOf reports whether n is ancestor itself or sits below it.
+func (n *CategoryNode) IsDescendantOf(ancestor *CategoryNode) bool {
+	for cur := n; cur != nil; cur = cur.Parent {
+		if cur == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Breadcrumb is one link in a category breadcrumb trail.
+type Breadcrumb struct {
+	Name string // Link text
+	URL  string // Link target
+}
+
+// Breadcrumbs returns links for every category from the family down to n.
+func (n *CategoryNode) Breadcrumbs() []Breadcrumb {
+	var crumbs []Breadcrumb
+	for _, node := range n.Path() {
+		crumbs = append(crumbs, Breadcrumb{Name: node.Category.Name, URL: node.URL()})
+	}
+	return crumbs
+}
+
+// CategoryTree indexes product categories by ID and slug and links them into
+// a hierarchy. Build it with BuildCategoryTree.
+type CategoryTree struct {
+	Roots  []*CategoryNode          // Top-level families in display order
+	byID   map[int64]*CategoryNode  // Lookup by category ID
+	bySlug map[string]*CategoryNode // Lookup by slug
+}
+
+// BuildCategoryTree links categories into a tree. Input order (normally
+// ListProductCategories' sort_order, name) is kept among siblings. Categories
+// whose parent is missing, or which sit in a parent cycle left by direct
+// database edits, are treated as top-level families.
+//
+// Parameters:
+//   - categories: All product categories
+//   - counts: Products directly in each category, keyed by category ID (may be nil)
+//
+// Returns:
+//   - *CategoryTree: Linked hierarchy with rolled-up product totals
+func BuildCategoryTree(categories []sqlc.ProductCategory, counts map[int64]int64) *CategoryTree {
+	t := &CategoryTree{
+		byID:   make(map[int64]*CategoryNode, len(categories)),
+		bySlug: make(map[string]*CategoryNode, len(categories)),
+	}
+	nodes := make([]*CategoryNode, 0, len(categories))
+	for _, cat := range categories {
+		node := &CategoryNode{Category: cat, Count: counts[cat.ID]}
+		t.byID[cat.ID] = node
+		t.bySlug[cat.Slug] = node
+		nodes = append(nodes, node)
+	}
+
+	for _, node := range nodes {
+		if node.Category.ParentID.Valid {
+			if parent, ok := t.byID[node.Category.ParentID.Int64]; ok && !parent.IsDescendantOf(node) {
+				node.Parent = parent
+			}
+		}
+	}
+	for _, node := range nodes {
+		if node.Parent == nil {
+			t.Roots = append(t.Roots, node)
+		} else {
+			node.Parent.Children = append(node.Parent.Children, node)
+		}
+	}
+
+	var walk func(n *CategoryNode, depth int) int64
+	walk = func(n *CategoryNode, depth int) int64 {
+		n.Depth = depth
+		n.Total = n.Count
+		for _, child := range n.Children {
+			n.Total += walk(child, depth+1)
+		}
+		return n.Total
+	}
+	for _, root := range t.Roots {
+		walk(root, 1)
+	}
+	return t
+}
+
+// ByID returns the category with the given ID, or nil.
+func (t *CategoryTree) ByID(id int64) *CategoryNode {
+	return t.byID[id]
+}
+
+// BySlug returns the category with the given slug, or nil.
+func (t *CategoryTree) BySlug(slug string) *CategoryNode {
+	return t.bySlug[slug]
+}
+
+// Flatten returns every category in depth-first display order, so that each
+// category is followed by its subcategories.
+func (t *CategoryTree) Flatten() []*CategoryNode {
+	var out []*CategoryNode
+	var walk func(nodes []*CategoryNode)
+	walk = func(nodes []*CategoryNode) {
+		for _, n := range nodes {
+			out = append(out, n)
+			walk(n.Children)
+		}
+	}
+	walk(t.Roots)
+	return out
+}
+
+// CategoryOption is one entry of a parent/category select box.
+type CategoryOption struct {
+	ID    int64  // Category ID
+	Label string // Name indented by depth, e.g. "— — Rugged Tablets"
+	Depth int    // 1 for families
+}
+
+// Options returns select options for every category in display order.
+// Categories in the subtree of exclude (and exclude itself) are left out,
+// as are categories at maxDepth or deeper; pass 0 and MaxCategoryDepth+1 to
+// list everything.
+//
+// Parameters:
+//   - exclude: Category being edited (0 for none)
+//   - maxDepth: Only categories shallower than this are offered
+//
+// Returns:
+//   - []CategoryOption: Options for a <select>
+func (t *CategoryTree) Options(exclude int64, maxDepth int) []CategoryOption {
+	skip := t.byID[exclude]
+	var opts []CategoryOption
+	for _, n := range t.Flatten() {
+		if n.Depth >= maxDepth || (skip != nil && n.IsDescendantOf(skip)) {
+			continue
+		}
+		opts = append(opts, CategoryOption{
+			ID:    n.Category.ID,
+			Label: strings.Repeat("— ", n.Depth-1) + n.Category.Name,
+			Depth: n.Depth,
+		})
+	}
+	return opts
+}
+
+// ValidateParent checks that category id may be moved under parentID without
+// creating a cycle or nesting its subtree deeper than MaxCategoryDepth.
+//
+// Parameters:
+//   - id: Category being saved (0 for a new category)
+//   - parentID: Proposed parent (0 for a top-level family)
+//
+// Returns:
+//   - error: ErrCategoryNotFound, ErrCategoryCycle, ErrCategoryTooDeep, or nil
+func (t *CategoryTree) ValidateParent(id, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	parent := t.byID[parentID]
+	if parent == nil {
+		return ErrCategoryNotFound
+	}
+	height := 1
+	if node := t.byID[id]; node != nil {
+		if parent.IsDescendantOf(node) {
+			return ErrCategoryCycle
+		}
+		height = node.Height()
+	}
+	if parent.Depth+height > MaxCategoryDepth {
+		return ErrCategoryTooDeep
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func category(id int64, slug string, parent int64) sqlc.ProductCategory {
+	return sqlc.ProductCategory{ID: id, Name: slug, Slug: slug, ParentID: sql.NullInt64{Int64: parent, Valid: parent != 0}}
+}
+
+// testTree: computers (1) → laptops (2) → rugged (3); computers → desktops (4); sensors (5).
+func testTree() *services.CategoryTree {
+	return services.BuildCategoryTree([]sqlc.ProductCategory{
+		category(1, "computers", 0),
+		category(2, "laptops", 1),
+		category(3, "rugged", 2),
+		category(4, "desktops", 1),
+		category(5, "sensors", 0),
+	}, map[int64]int64{1: 1, 2: 2, 3: 4, 4: 8, 5: 16})
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	tree := testTree()
+
+	if len(tree.Roots) != 2 || tree.Roots[0].Category.Slug != "computers" || tree.Roots[1].Category.Slug != "sensors" {
+		t.Fatalf("unexpected roots: %+v", tree.Roots)
+	}
+	if got := tree.ByID(1).Total; got != 15 {
+		t.Errorf("computers total = %d, want 15 (rolled up)", got)
+	}
+	if got := tree.ByID(2).Total; got != 6 {
+		t.Errorf("laptops total = %d, want 6", got)
+	}
+
+	rugged := tree.BySlug("rugged")
+	if rugged.Depth != 3 {
+		t.Errorf("rugged depth = %d, want 3", rugged.Depth)
+	}
+	if got := rugged.URL(); got != "/products/laptops/rugged" {
+		t.Errorf("rugged URL = %q", got)
+	}
+	if got := tree.ByID(1).URL(); got != "/products/computers" {
+		t.Errorf("computers URL = %q", got)
+	}
+	crumbs := rugged.Breadcrumbs()
+	if len(crumbs) != 3 || crumbs[0].URL != "/products/computers" || crumbs[1].URL != "/products/computers/laptops" {
+		t.Errorf("unexpected breadcrumbs: %+v", crumbs)
+	}
+
+	var order []string
+	for _, n := range tree.Flatten() {
+		order = append(order, n.Category.Slug)
+	}
+	if want := "computers laptops rugged desktops sensors"; strings.Join(order, " ") != want {
+		t.Errorf("Flatten order = %q, want %q", strings.Join(order, " "), want)
+	}
+}
+
+func TestBuildCategoryTree_BrokenParents(t *testing.T) {
+	// A missing parent and a two-category cycle must not hang or drop categories.
+	tree := services.BuildCategoryTree([]sqlc.ProductCategory{
+		category(1, "orphan", 99),
+		category(2, "a", 3),
+		category(3, "b", 2),
+	}, nil)
+	if got := len(tree.Flatten()); got != 3 {
+		t.Fatalf("Flatten returned %d categories, want 3", got)
+	}
+	if tree.ByID(1).Parent != nil {
+		t.Error("category with a missing parent should be top-level")
+	}
+}
+
+func TestCategoryTree_ValidateParent(t *testing.T) {
+	tree := testTree()
+	tests := []struct {
+		name         string
+		id, parentID int64
+		want         error
+	}{
+		{"top level", 2, 0, nil},
+		{"new under series", 0, 2, nil},
+		{"new under type", 0, 3, services.ErrCategoryTooDeep},
+		{"self", 2, 2, services.ErrCategoryCycle},
+		{"under own child", 1, 3, services.ErrCategoryCycle},
+		{"subtree fits", 2, 5, nil},                             // laptops + rugged become levels 2 and 3
+		{"subtree too deep", 1, 5, services.ErrCategoryTooDeep}, // computers has two levels below it
+		{"leaf under series", 4, 2, nil},
+		{"missing parent", 4, 99, services.ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		if got := tree.ValidateParent(tt.id, tt.parentID); !errors.Is(got, tt.want) {
+			t.Errorf("%s: ValidateParent(%d, %d) = %v, want %v", tt.name, tt.id, tt.parentID, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryTree_Options(t *testing.T) {
+	opts := testTree().Options(2, services.MaxCategoryDepth)
+	var labels []string
+	for _, o := range opts {
+		labels = append(labels, o.Label)
+	}
+	// laptops and its subtree are excluded; types (depth 3) cannot take children
+	if want := "computers|— desktops|sensors"; strings.Join(labels, "|") != want {
+		t.Errorf("Options = %q, want %q", strings.Join(labels, "|"), want)
+	}
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request cancellation for block loading
+	"errors"  // Block validation errors
+	"strings" // Item parsing and path normalisation
+	"sync"    // Guards the block snapshot, which changes when blocks are edited
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"         // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/models" // ContentBlock view model rendered by the templates
+)
+
+// Content block types (content_blocks.block_type).
+const (
+	BlockRichText = "rich_text" // Formatted text from the editor
+	BlockCTA      = "cta"       // Heading, text and a button
+	BlockStats    = "stats"     // Strip of figures with labels
+	BlockLogoWall = "logo_wall" // Grid of customer or partner logos
+)
+
+// ContentBlockType is a kind of block the admin can create.
+type ContentBlockType struct {
+	Type  string // content_blocks.block_type
+	Label string // Name shown under Admin > Content Blocks
+}
+
+// ContentBlockTypes lists the block types in the order the admin form
+// offers them.
+var ContentBlockTypes = []ContentBlockType{
+	{BlockRichText, "Rich text"},
+	{BlockCTA, "Call to action"},
+	{BlockStats, "Stats strip"},
+	{BlockLogoWall, "Logo wall"},
+}
+
+// ContentBlockLabel returns the display name of a block type, or t itself
+// when it is unknown.
+func ContentBlockLabel(t string) string {
+	for _, bt := range ContentBlockTypes {
+		if bt.Type == t {
+			return bt.Label
+		}
+	}
+	return t
+}
+
+// Content block validation errors returned by ValidateContentBlock and
+// NormalizeBlockRoute.
+var (
+	ErrBlockName   = errors.New("blocks: the block needs a name")
+	ErrBlockType   = errors.New("blocks: unknown block type")
+	ErrBlockEmpty  = errors.New("blocks: the block has no content")
+	ErrBlockButton = errors.New("blocks: the button needs a label and a path starting with / or an http(s) URL")
+	ErrBlockItems  = errors.New("blocks: every line needs all of its fields")
+	ErrBlockRoute  = errors.New("blocks: choose a site path starting with /")
+)
+
+// ValidateContentBlock checks a block from the admin form and returns it
+// with its fields trimmed and its items normalised to one entry per line.
+// Rich text bodies must already be sanitized.
+//
+// Each type needs its own content: rich text a body, a call to action a
+// heading or text (its button is optional but complete), a stats strip
+// "value | label" lines and a logo wall "image | alt | link" lines, where
+// alt and link may be left out.
+func ValidateContentBlock(b sqlc.CreateContentBlockParams) (sqlc.CreateContentBlockParams, error) {
+	b.Name = strings.TrimSpace(b.Name)
+	b.Heading = strings.TrimSpace(b.Heading)
+	b.Body = strings.TrimSpace(b.Body)
+	b.ButtonText = strings.TrimSpace(b.ButtonText)
+	b.ButtonUrl = strings.TrimSpace(b.ButtonUrl)
+	if b.Name == "" {
+		return b, ErrBlockName
+	}
+	if ContentBlockLabel(b.BlockType) == b.BlockType {
+		return b, ErrBlockType
+	}
+
+	if b.BlockType != BlockCTA {
+		b.ButtonText, b.ButtonUrl = "", ""
+	}
+	if b.BlockType != BlockStats && b.BlockType != BlockLogoWall {
+		b.Items = ""
+	}
+	switch b.BlockType {
+	case BlockRichText:
+		if b.Body == "" {
+			return b, ErrBlockEmpty
+		}
+	case BlockCTA:
+		if b.Heading == "" && b.Body == "" {
+			return b, ErrBlockEmpty
+		}
+		if (b.ButtonText == "") != (b.ButtonUrl == "") || b.ButtonUrl != "" && !isLinkTarget(b.ButtonUrl) {
+			return b, ErrBlockButton
+		}
+	case BlockStats, BlockLogoWall:
+		b.Body = ""
+		fields := 2
+		if b.BlockType == BlockLogoWall {
+			fields = 1
+		}
+		lines := blockLines(b.Items)
+		if len(lines) == 0 {
+			return b, ErrBlockEmpty
+		}
+		for _, parts := range lines {
+			if len(parts) < fields || parts[0] == "" || fields == 2 && parts[1] == "" {
+				return b, ErrBlockItems
+			}
+			if b.BlockType == BlockLogoWall && (!isLinkTarget(parts[0]) || len(parts) > 2 && parts[2] != "" && !isLinkTarget(parts[2])) {
+				return b, ErrBlockItems
+			}
+		}
+		b.Items = joinBlockLines(lines)
+	}
+	return b, nil
+}
+
+// NormalizeBlockRoute checks the page path blocks are attached to and
+// returns it trimmed, without a trailing slash. Admin and static file paths
+// are refused, as they have no public layout.
+func NormalizeBlockRoute(route string) (string, error) {
+	route = strings.TrimSpace(route)
+	if !isSitePath(route) || strings.ContainsAny(route, "?#*") {
+		return route, ErrBlockRoute
+	}
+	if len(route) > 1 {
+		route = strings.TrimSuffix(route, "/")
+	}
+	for _, reserved := range reservedRedirectPrefixes {
+		if route == reserved || strings.HasPrefix(route, reserved+"/") {
+			return route, ErrBlockRoute
+		}
+	}
+	return route, nil
+}
+
+// isLinkTarget reports whether u is a site path or an absolute http(s) URL.
+func isLinkTarget(u string) bool {
+	return (isSitePath(u) || strings.HasPrefix(u, "https://") || strings.HasPrefix(u, "http://")) && !strings.Contains(u, " ")
+}
+
+// blockLines splits stored items into their non-empty lines and each line
+// into its trimmed "|"-separated fields.
+func blockLines(items string) [][]string {
+	var lines [][]string
+	for _, line := range strings.Split(items, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.Split(line, "|")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		lines = append(lines, parts)
+	}
+	return lines
+}
+
+// joinBlockLines stores parsed item lines, one per line with " | " between
+// fields.
+func joinBlockLines(lines [][]string) string {
+	out := make([]string, len(lines))
+	for i, parts := range lines {
+		out[i] = strings.Join(parts, " | ")
+	}
+	return strings.Join(out, "\n")
+}
+
+// ContentBlocks serves the blocks attached to public pages from memory. The
+// block editor calls Reload after each change, so rendering a page never
+// queries the database for its blocks.
+type ContentBlocks struct {
+	queries *sqlc.Queries // Block storage
+
+	mu    sync.RWMutex                     // Guards pages
+	pages map[string][]models.ContentBlock // Active blocks keyed by page path
+}
+
+// NewContentBlocks creates the content block service. Call Reload before
+// serving requests to load the attached blocks.
+func NewContentBlocks(queries *sqlc.Queries) *ContentBlocks {
+	return &ContentBlocks{queries: queries, pages: map[string][]models.ContentBlock{}}
+}
+
+// Reload replaces the in-memory blocks with the active blocks attached to
+// each page, in display order.
+func (b *ContentBlocks) Reload(ctx context.Context) error {
+	rows, err := b.queries.ListActivePageBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	pages := make(map[string][]models.ContentBlock)
+	for _, row := range rows {
+		pages[row.Route] = append(pages[row.Route], contentBlock(row))
+	}
+
+	b.mu.Lock()
+	b.pages = pages
+	b.mu.Unlock()
+	return nil
+}
+
+// ForPath returns the blocks of the page at path, or nil. A trailing slash
+// is ignored. The blocks are shared between requests and must not be
+// modified.
+func (b *ContentBlocks) ForPath(path string) []models.ContentBlock {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.pages[path]
+}
+
+// contentBlock converts a stored block into its rendered form, parsing the
+// entries of stats strips and logo walls.
+func contentBlock(row sqlc.ListActivePageBlocksRow) models.ContentBlock {
+	block := models.ContentBlock{
+		Type:       row.BlockType,
+		Heading:    row.Heading,
+		Body:       row.Body,
+		ButtonText: row.ButtonText,
+		ButtonURL:  row.ButtonUrl,
+	}
+	for _, parts := range blockLines(row.Items) {
+		switch row.BlockType {
+		case BlockStats:
+			if len(parts) >= 2 {
+				block.Stats = append(block.Stats, models.BlockStat{Value: parts[0], Label: parts[1]})
+			}
+		case BlockLogoWall:
+			logo := models.BlockLogo{Image: parts[0]}
+			if len(parts) > 1 {
+				logo.Alt = parts[1]
+			}
+			if len(parts) > 2 {
+				logo.URL = parts[2]
+			}
+			block.Logos = append(block.Logos, logo)
+		}
+	}
+	return block
+}
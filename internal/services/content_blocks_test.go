@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestContentBlocks_Reload(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(p sqlc.CreateContentBlockParams) sqlc.ContentBlock {
+		t.Helper()
+		b, err := queries.CreateContentBlock(ctx, p)
+		if err != nil {
+			t.Fatalf("create block: %v", err)
+		}
+		return b
+	}
+	stats := create(sqlc.CreateContentBlockParams{Name: "Numbers", BlockType: services.BlockStats, Items: "500+ | Customers\n12 | Countries", IsActive: true})
+	cta := create(sqlc.CreateContentBlockParams{Name: "Talk", BlockType: services.BlockCTA, Heading: "Talk to us", ButtonText: "Contact", ButtonUrl: "/contact", IsActive: true})
+	logos := create(sqlc.CreateContentBlockParams{Name: "Logos", BlockType: services.BlockLogoWall, Items: "/uploads/a.png | Acme | https://acme.example\n/uploads/b.png", IsActive: true})
+	hidden := create(sqlc.CreateContentBlockParams{Name: "Draft", BlockType: services.BlockRichText, Body: "<p>Soon</p>"})
+
+	for i, id := range []int64{cta.ID, stats.ID, hidden.ID, logos.ID} {
+		if _, err := queries.AttachPageBlock(ctx, sqlc.AttachPageBlockParams{Route: "/about", BlockID: id, SortOrder: int64(i)}); err != nil {
+			t.Fatalf("attach: %v", err)
+		}
+	}
+	attached, _ := queries.ListPageBlocks(ctx, "/about")
+	// Move the stats strip to the top
+	queries.UpdatePageBlockOrder(ctx, sqlc.UpdatePageBlockOrderParams{SortOrder: -1, ID: attached[1].ID, Route: "/about"})
+	// Another page's route leaves the attachment alone
+	queries.UpdatePageBlockOrder(ctx, sqlc.UpdatePageBlockOrderParams{SortOrder: 99, ID: attached[0].ID, Route: "/contact"})
+
+	blocks := services.NewContentBlocks(queries)
+	if err := blocks.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	page := blocks.ForPath("/about/")
+	if len(page) != 3 {
+		t.Fatalf("ForPath(/about/) = %+v, want 3 active blocks", page)
+	}
+	if page[0].Type != services.BlockStats || len(page[0].Stats) != 2 || page[0].Stats[1].Label != "Countries" {
+		t.Errorf("first block = %+v, want the stats strip", page[0])
+	}
+	if page[1].Type != services.BlockCTA || page[1].ButtonURL != "/contact" {
+		t.Errorf("second block = %+v, want the call to action", page[1])
+	}
+	if l := page[2].Logos; len(l) != 2 || l[0].URL != "https://acme.example" || l[1].Image != "/uploads/b.png" || l[1].Alt != "" {
+		t.Errorf("logos = %+v", l)
+	}
+	if got := blocks.ForPath("/contact"); got != nil {
+		t.Errorf("ForPath(/contact) = %+v, want none", got)
+	}
+
+	// Deleting a block detaches it from its pages
+	if err := queries.DeleteContentBlock(ctx, stats.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	blocks.Reload(ctx)
+	if page := blocks.ForPath("/about"); len(page) != 2 || page[0].Type != services.BlockCTA {
+		t.Errorf("after delete: %+v", page)
+	}
+}
+
+func TestValidateContentBlock(t *testing.T) {
+	ok := []sqlc.CreateContentBlockParams{
+		{Name: "Intro", BlockType: services.BlockRichText, Body: "<p>Hi</p>"},
+		{Name: "CTA", BlockType: services.BlockCTA, Heading: "Ready?"},
+		{Name: "CTA", BlockType: services.BlockCTA, Body: "Ask us", ButtonText: "Go", ButtonUrl: "https://example.com/x"},
+		{Name: "Stats", BlockType: services.BlockStats, Items: " 99% |Uptime \n\n 24/7| Support"},
+		{Name: "Logos", BlockType: services.BlockLogoWall, Items: "/uploads/a.png\nhttps://cdn.example.com/b.png | B | /partners"},
+	}
+	for _, b := range ok {
+		if _, err := services.ValidateContentBlock(b); err != nil {
+			t.Errorf("ValidateContentBlock(%+v) = %v", b, err)
+		}
+	}
+	got, _ := services.ValidateContentBlock(ok[3])
+	if got.Items != "99% | Uptime\n24/7 | Support" {
+		t.Errorf("stats items normalised to %q", got.Items)
+	}
+
+	bad := map[error]sqlc.CreateContentBlockParams{
+		services.ErrBlockName:   {Name: " ", BlockType: services.BlockRichText, Body: "x"},
+		services.ErrBlockType:   {Name: "X", BlockType: "carousel"},
+		services.ErrBlockEmpty:  {Name: "X", BlockType: services.BlockCTA, ButtonText: "Go", ButtonUrl: "/go"},
+		services.ErrBlockButton: {Name: "X", BlockType: services.BlockCTA, Heading: "H", ButtonUrl: "/go"},
+		services.ErrBlockItems:  {Name: "X", BlockType: services.BlockStats, Items: "500+"},
+	}
+	for want, b := range bad {
+		if _, err := services.ValidateContentBlock(b); !errors.Is(err, want) {
+			t.Errorf("ValidateContentBlock(%+v) = %v, want %v", b, err, want)
+		}
+	}
+	if _, err := services.ValidateContentBlock(sqlc.CreateContentBlockParams{Name: "X", BlockType: services.BlockLogoWall, Items: "javascript:alert(1) | x"}); !errors.Is(err, services.ErrBlockItems) {
+		t.Errorf("logo with a script URL: %v", err)
+	}
+
+	for route, want := range map[string]string{" /about/ ": "/about", "/": "/"} {
+		if got, err := services.NormalizeBlockRoute(route); err != nil || got != want {
+			t.Errorf("NormalizeBlockRoute(%q) = %q, %v", route, got, err)
+		}
+	}
+	for _, route := range []string{"about", "/admin/blocks", "/public/x.css", "/a?b=1", "//evil.example"} {
+		if _, err := services.NormalizeBlockRoute(route); !errors.Is(err, services.ErrBlockRoute) {
+			t.Errorf("NormalizeBlockRoute(%q) = %v, want ErrBlockRoute", route, err)
+		}
+	}
+}
@@ -0,0 +1,1411 @@
+package services
+
+import (
+	// Standard library imports
+	"archive/zip"   // Bundle file format
+	"bytes"         // Decoding the manifest
+	"context"       // Database calls
+	"crypto/sha256" // Media file hashes
+	"database/sql"  // Generic row access
+	"encoding/hex"  // Hash formatting
+	"encoding/json" // Manifest format
+	"errors"        // Sentinel errors
+	"fmt"           // Error messages and numbered names
+	"io"            // Copying media files
+	"os"            // Media files and staged bundles
+	"path"          // Media web paths
+	"path/filepath" // Media files on disk
+	"regexp"        // Finding /uploads/ references
+	"sort"          // Stable column order
+	"strings"       // Path and slug handling
+	"time"          // Export timestamp and stage expiry
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/database" // The import transaction
+)
+
+// Content bundles identify themselves so that an unrelated ZIP file, or one
+// from an incompatible release, is rejected before anything is written.
+const (
+	ContentBundleFormat  = "bluejay-content"
+	ContentBundleVersion = 1
+)
+
+// How an imported item whose slug is already used is resolved.
+const (
+	BundleSkip      = "skip"      // Keep the existing item
+	BundleOverwrite = "overwrite" // Replace the existing item with the bundle's
+	BundleCopy      = "copy"      // Import as a new draft with a "-copy" slug
+)
+
+const (
+	// contentBundleManifest is the bundle's JSON document inside the ZIP;
+	// media files are stored under contentBundleMediaDir by their path
+	// below /uploads/.
+	contentBundleManifest = "bundle.json"
+	contentBundleMediaDir = "media/"
+
+	// maxBundleManifest bounds the decoded manifest (64 MB).
+	maxBundleManifest = 64 << 20
+
+	// bundleStageTTL is how long an uploaded bundle waits for its import to
+	// be applied before it is removed.
+	bundleStageTTL = time.Hour
+)
+
+var (
+	// ErrBundleFormat is returned for files that are not content bundles of
+	// this release.
+	ErrBundleFormat = errors.New("not a content bundle exported by this version of the CMS")
+
+	// ErrBundleExpired is returned for staged bundles that were applied,
+	// discarded or removed after bundleStageTTL.
+	ErrBundleExpired = errors.New("the uploaded bundle has expired; upload it again")
+)
+
+// uploadRefPattern finds references to uploaded files in any text column:
+// image fields as well as <img src> in HTML bodies.
+var uploadRefPattern = regexp.MustCompile(`/uploads/[A-Za-z0-9._~%/-]+`)
+
+// bundleRef describes a column that holds the ID of other content. Bundles
+// carry the referenced item's slug instead, since IDs differ between
+// installations.
+type bundleRef struct {
+	kind    string // Referenced kind
+	include bool   // Export the referenced item too; otherwise only link to it when the target has it
+}
+
+// bundleChild is a table of sub-resources copied with their item.
+type bundleChild struct {
+	table  string               // e.g. "product_specs"
+	parent string               // Column holding the item's ID
+	refs   map[string]bundleRef // Columns referencing other content
+	order  string               // ORDER BY of the export
+	self   string               // Column referencing an earlier row of the same item, e.g. "supersedes_id"; exported as the row's position
+}
+
+// bundleKind describes how one content type is exported and imported.
+type bundleKind struct {
+	kind       string               // Content type, as in the activity log
+	label      string               // e.g. "Product"
+	plural     string               // Heading on the export page, e.g. "Products"
+	table      string               // Main table
+	title      string               // Title column
+	selectable bool                 // Offered on the export page; others are only exported as references
+	match      []string             // Columns that identify the same item in another installation, slug first
+	unique     []string             // Other unique columns that must not clash (e.g. sku)
+	refs       map[string]bundleRef // Columns of the main row referencing other content
+	children   []bundleChild        // Sub-resources
+	translated bool                 // Has rows in translations (entity_type = kind)
+	draft      BundleRow            // Column values that keep an imported copy unpublished
+}
+
+// bundleKinds lists the content types in import order: the lookups that
+// other content refers to come first.
+var bundleKinds = []bundleKind{
+	{kind: "product_category", label: "Product category", table: "product_categories", title: "name", match: []string{"slug", "name"},
+		refs: map[string]bundleRef{"parent_id": {kind: "product_category", include: true}}},
+	{kind: "blog_category", label: "Blog category", table: "blog_categories", title: "name", match: []string{"slug", "name"}},
+	{kind: "blog_author", label: "Blog author", table: "blog_authors", title: "name", match: []string{"slug"}},
+	{kind: "blog_series", label: "Blog series", table: "blog_series", title: "name", match: []string{"slug"}},
+	{kind: "blog_tag", label: "Blog tag", table: "blog_tags", title: "name", match: []string{"slug", "name"}},
+	{kind: "industry", label: "Industry", table: "industries", title: "name", match: []string{"slug", "name"}},
+	{kind: "whitepaper_topic", label: "Whitepaper topic", table: "whitepaper_topics", title: "name", match: []string{"slug", "name"}},
+
+	{kind: "product", label: "Product", table: "products", plural: "Products", title: "name", selectable: true, match: []string{"slug"}, unique: []string{"sku"},
+		refs: map[string]bundleRef{"category_id": {kind: "product_category", include: true}},
+		children: []bundleChild{
+			{table: "product_specs", parent: "product_id", order: "display_order, id"},
+			{table: "product_images", parent: "product_id", order: "display_order, id"},
+			{table: "product_features", parent: "product_id", order: "display_order, id"},
+			{table: "product_certifications", parent: "product_id", order: "display_order, id"},
+			{table: "product_downloads", parent: "product_id", order: "id", self: "supersedes_id"},
+		},
+		translated: true, draft: BundleRow{"status": "draft", "published_at": nil}},
+	{kind: "blog_post", label: "Blog post", table: "blog_posts", plural: "Blog posts", title: "title", selectable: true, match: []string{"slug"},
+		refs: map[string]bundleRef{
+			"category_id": {kind: "blog_category", include: true},
+			"author_id":   {kind: "blog_author", include: true},
+			"series_id":   {kind: "blog_series", include: true},
+		},
+		children: []bundleChild{
+			{table: "blog_post_tags", parent: "blog_post_id", order: "blog_tag_id",
+				refs: map[string]bundleRef{"blog_tag_id": {kind: "blog_tag", include: true}}},
+			{table: "blog_post_products", parent: "blog_post_id", order: "display_order, product_id",
+				refs: map[string]bundleRef{"product_id": {kind: "product"}}},
+		},
+		translated: true, draft: BundleRow{"status": "draft", "published_at": nil}},
+	{kind: "solution", label: "Solution", table: "solutions", plural: "Solutions", title: "title", selectable: true, match: []string{"slug"},
+		children: []bundleChild{
+			{table: "solution_stats", parent: "solution_id", order: "display_order, id"},
+			{table: "solution_challenges", parent: "solution_id", order: "display_order, id"},
+			{table: "solution_products", parent: "solution_id", order: "display_order, id",
+				refs: map[string]bundleRef{"product_id": {kind: "product"}}},
+			{table: "solution_ctas", parent: "solution_id", order: "id"},
+		},
+		translated: true, draft: BundleRow{"is_published": 0}},
+	{kind: "case_study", label: "Case study", table: "case_studies", plural: "Case studies", title: "title", selectable: true, match: []string{"slug"},
+		refs: map[string]bundleRef{"industry_id": {kind: "industry", include: true}},
+		children: []bundleChild{
+			{table: "case_study_products", parent: "case_study_id", order: "display_order, id",
+				refs: map[string]bundleRef{"product_id": {kind: "product"}}},
+			{table: "case_study_metrics", parent: "case_study_id", order: "display_order, id"},
+		},
+		draft: BundleRow{"is_published": 0}},
+	{kind: "whitepaper", label: "Whitepaper", table: "whitepapers", plural: "Whitepapers", title: "title", selectable: true, match: []string{"slug"},
+		refs: map[string]bundleRef{"topic_id": {kind: "whitepaper_topic", include: true}},
+		children: []bundleChild{
+			{table: "whitepaper_learning_points", parent: "whitepaper_id", order: "display_order, id"},
+		},
+		draft: BundleRow{"is_published": 0}},
+	{kind: "landing_page", label: "Landing page", table: "landing_pages", plural: "Landing pages", title: "title", selectable: true, match: []string{"slug"},
+		children: []bundleChild{
+			{table: "landing_page_sections", parent: "landing_page_id", order: "sort_order, id"},
+		},
+		draft: BundleRow{"status": "draft", "published_at": nil}},
+}
+
+// bundleOmitted are columns that are not carried between installations:
+// row identity, timestamps of the row itself, and counters.
+var bundleOmitted = map[string]bool{
+	"id": true, "created_at": true, "updated_at": true, "product_count": true, "download_count": true,
+}
+
+// lookupBundleKind returns the description of a content type.
+func lookupBundleKind(kind string) (bundleKind, bool) {
+	for _, k := range bundleKinds {
+		if k.kind == kind {
+			return k, true
+		}
+	}
+	return bundleKind{}, false
+}
+
+// BundleRow is one table row of a bundle, keyed by column name. Columns
+// that reference other content hold the referenced item's slug.
+type BundleRow map[string]interface{}
+
+// ContentBundle is the manifest of a content bundle (bundle.json).
+type ContentBundle struct {
+	Format     string        `json:"format"`
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Items      []BundleItem  `json:"items"` // In import order
+	Media      []BundleMedia `json:"media"`
+}
+
+// BundleItem is one exported item with its sub-resources.
+type BundleItem struct {
+	Kind         string                 `json:"kind"`
+	Slug         string                 `json:"slug"`
+	Title        string                 `json:"title"`
+	Selected     bool                   `json:"selected"` // Chosen on export, rather than exported because selected content refers to it
+	Row          BundleRow              `json:"row"`
+	Children     map[string][]BundleRow `json:"children,omitempty"` // By table
+	Translations []BundleRow            `json:"translations,omitempty"`
+}
+
+// BundleMedia is an uploaded file referenced by the bundle's content.
+type BundleMedia struct {
+	Path    string    `json:"path"`              // /uploads/... web path
+	SHA256  string    `json:"sha256"`            // Content hash, to recognise files the target already has
+	Library BundleRow `json:"library,omitempty"` // media_files row, when the file is in the media library
+}
+
+// BundleSelection names an item to export.
+type BundleSelection struct {
+	Kind string
+	ID   int64
+}
+
+// BundleCandidate is an item offered on the export page.
+type BundleCandidate struct {
+	ID    int64
+	Slug  string
+	Title string
+}
+
+// BundleCandidates lists the items of one content type on the export page.
+type BundleCandidates struct {
+	Kind  string
+	Label string // e.g. "Products"
+	Items []BundleCandidate
+}
+
+// ContentBundles exports selected content, with the lookups it refers to
+// and the uploaded files it links, as a ZIP bundle, and imports such a
+// bundle into another installation, so content written on staging can be
+// promoted to production without entering it again.
+//
+// Items are matched between installations by slug (lookups such as
+// categories also by name); IDs are never carried over. An imported item
+// whose slug is taken is skipped, overwrites the existing item, or is added
+// as a draft copy, as chosen per item. Product variants, related content
+// pins, workflow history and media variants are not part of a bundle.
+type ContentBundles struct {
+	db        *sql.DB // Source of exports and target of imports
+	uploadDir string  // Directory served at /uploads
+	stageDir  string  // Uploaded bundles waiting for their import to be applied
+}
+
+// NewContentBundles creates the content bundle service.
+//
+// Parameters:
+//   - db: Database connection, used directly since bundles copy whole rows
+//   - uploadDir: Directory served at /uploads (UPLOAD_DIR)
+//   - stageDir: Directory for uploaded bundles between preview and apply
+//
+// Returns:
+//   - *ContentBundles: Service ready for use
+func NewContentBundles(db *sql.DB, uploadDir, stageDir string) *ContentBundles {
+	return &ContentBundles{db: db, uploadDir: uploadDir, stageDir: stageDir}
+}
+
+// bundleQuerier is satisfied by *sql.DB and *sql.Tx.
+type bundleQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Candidates lists the content that can be selected for export, by type.
+// Trashed items are left out.
+func (b *ContentBundles) Candidates(ctx context.Context) ([]BundleCandidates, error) {
+	var out []BundleCandidates
+	for _, k := range bundleKinds {
+		if !k.selectable {
+			continue
+		}
+		cols, err := tableColumns(ctx, b.db, k.table)
+		if err != nil {
+			return nil, err
+		}
+		query := "SELECT id, slug, " + k.title + " FROM " + k.table
+		if cols["deleted_at"] {
+			query += " WHERE deleted_at IS NULL"
+		}
+		rows, err := b.db.QueryContext(ctx, query+" ORDER BY "+k.title+" COLLATE NOCASE")
+		if err != nil {
+			return nil, err
+		}
+		group := BundleCandidates{Kind: k.kind, Label: k.plural}
+		for rows.Next() {
+			var c BundleCandidate
+			if err := rows.Scan(&c.ID, &c.Slug, &c.Title); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			group.Items = append(group.Items, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		out = append(out, group)
+	}
+	return out, nil
+}
+
+// Export builds the manifest for the selected items, adding the lookups
+// they refer to and the uploaded files they link. Referenced items come
+// before the items referring to them.
+//
+// Returns:
+//   - *ContentBundle: Manifest; pass it to WriteBundle
+//   - error: sql.ErrNoRows when a selected item does not exist, or a
+//     database error
+func (b *ContentBundles) Export(ctx context.Context, selection []BundleSelection, now time.Time) (*ContentBundle, error) {
+	e := &bundleExporter{db: b.db, seen: map[string]int{}}
+	for _, sel := range selection {
+		k, ok := lookupBundleKind(sel.Kind)
+		if !ok || !k.selectable {
+			return nil, fmt.Errorf("cannot export content type %q", sel.Kind)
+		}
+		if _, err := e.add(ctx, k, sel.ID, true); err != nil {
+			return nil, err
+		}
+	}
+	bundle := &ContentBundle{Format: ContentBundleFormat, Version: ContentBundleVersion, ExportedAt: now.UTC(), Items: e.items}
+
+	for _, p := range bundleMediaPaths(bundle.Items) {
+		file := b.mediaFile(p)
+		sum, err := fileSHA256(file)
+		if err != nil {
+			continue // Missing files are links that are broken already
+		}
+		m := BundleMedia{Path: p, SHA256: sum}
+		rows, err := queryBundleRows(ctx, b.db, "SELECT * FROM media_files WHERE file_path = ? ORDER BY id LIMIT 1", p)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 1 {
+			m.Library = stripOmitted(rows[0])
+		}
+		bundle.Media = append(bundle.Media, m)
+	}
+	return bundle, nil
+}
+
+// WriteBundle writes bundle as a ZIP file: the manifest and the media files.
+func (b *ContentBundles) WriteBundle(w io.Writer, bundle *ContentBundle) error {
+	zw := zip.NewWriter(w)
+	manifest, err := zw.Create(contentBundleManifest)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(manifest)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bundle); err != nil {
+		return err
+	}
+	for _, m := range bundle.Media {
+		if err := b.writeMedia(zw, m.Path); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeMedia adds one uploaded file to the ZIP.
+func (b *ContentBundles) writeMedia(zw *zip.Writer, webPath string) error {
+	f, err := os.Open(b.mediaFile(webPath))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := zw.Create(contentBundleMediaDir + strings.TrimPrefix(webPath, "/uploads/"))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// mediaFile returns the file on disk of a /uploads/ web path.
+func (b *ContentBundles) mediaFile(webPath string) string {
+	return filepath.Join(b.uploadDir, filepath.FromSlash(strings.TrimPrefix(webPath, "/uploads/")))
+}
+
+// bundleExporter collects items in import order.
+type bundleExporter struct {
+	db    *sql.DB
+	seen  map[string]int // "kind:id" -> index in items
+	items []BundleItem
+}
+
+// add exports one item after the items its row refers to, and returns its
+// slug. An item already exported as a reference is marked selected.
+func (e *bundleExporter) add(ctx context.Context, k bundleKind, id int64, selected bool) (string, error) {
+	key := fmt.Sprintf("%s:%d", k.kind, id)
+	if i, ok := e.seen[key]; ok {
+		if selected {
+			e.items[i].Selected = true
+		}
+		return e.items[i].Slug, nil
+	}
+	rows, err := queryBundleRows(ctx, e.db, "SELECT * FROM "+k.table+" WHERE id = ?", id)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", sql.ErrNoRows
+	}
+	row := rows[0]
+	if err := e.replaceRefs(ctx, row, k.refs); err != nil {
+		return "", err
+	}
+
+	item := BundleItem{Kind: k.kind, Selected: selected, Row: stripOmitted(row)}
+	item.Slug, _ = row["slug"].(string)
+	item.Title, _ = row[k.title].(string)
+	for _, child := range k.children {
+		children, err := queryBundleRows(ctx, e.db, "SELECT * FROM "+child.table+" WHERE "+child.parent+" = ? ORDER BY "+child.order, id)
+		if err != nil {
+			return "", err
+		}
+		if child.self != "" {
+			selfPositions(children, child.self)
+		}
+		for _, c := range children {
+			delete(c, child.parent)
+			if err := e.replaceRefs(ctx, c, child.refs); err != nil {
+				return "", err
+			}
+			stripOmitted(c)
+		}
+		if len(children) > 0 {
+			if item.Children == nil {
+				item.Children = map[string][]BundleRow{}
+			}
+			item.Children[child.table] = children
+		}
+	}
+	if k.translated {
+		translations, err := queryBundleRows(ctx, e.db, "SELECT * FROM translations WHERE entity_type = ? AND entity_id = ? ORDER BY locale", k.kind, id)
+		if err != nil {
+			return "", err
+		}
+		for _, t := range translations {
+			delete(t, "entity_type")
+			delete(t, "entity_id")
+			stripOmitted(t)
+		}
+		item.Translations = translations
+	}
+
+	e.seen[key] = len(e.items)
+	e.items = append(e.items, item)
+	return item.Slug, nil
+}
+
+// replaceRefs replaces the IDs in the reference columns of row with the
+// referenced items' slugs, exporting included references first. Columns
+// are visited in name order so exports list lookups in a stable order.
+func (e *bundleExporter) replaceRefs(ctx context.Context, row BundleRow, refs map[string]bundleRef) error {
+	cols := make([]string, 0, len(refs))
+	for col := range refs {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	for _, col := range cols {
+		ref := refs[col]
+		id, ok := row[col].(int64)
+		if !ok {
+			row[col] = nil
+			continue
+		}
+		target, _ := lookupBundleKind(ref.kind)
+		var slug string
+		var err error
+		if ref.include {
+			slug, err = e.add(ctx, target, id, false)
+		} else {
+			err = e.db.QueryRowContext(ctx, "SELECT slug FROM "+target.table+" WHERE id = ?", id).Scan(&slug)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			row[col] = nil
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		row[col] = slug
+	}
+	return nil
+}
+
+// StagedBundle is an uploaded bundle waiting to be imported.
+type StagedBundle struct {
+	Token  string         // Names the upload between preview and apply
+	Bundle *ContentBundle // Decoded manifest
+	zip    *zip.ReadCloser
+}
+
+// Close releases the bundle's file; Discard removes it.
+func (s *StagedBundle) Close() error {
+	return s.zip.Close()
+}
+
+// Stage stores an uploaded bundle for the preview and apply steps, checks
+// it is a content bundle, and removes bundles abandoned for an hour.
+//
+// Returns:
+//   - *StagedBundle: The decoded bundle; Close it after use
+//   - error: ErrBundleFormat for other files, or a file system error
+func (b *ContentBundles) Stage(r io.Reader) (*StagedBundle, error) {
+	if err := os.MkdirAll(b.stageDir, 0o750); err != nil {
+		return nil, err
+	}
+	b.pruneStaged(time.Now())
+	token := randomHex(16)
+	f, err := os.OpenFile(b.stagedPath(token), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		b.Discard(token)
+		return nil, err
+	}
+	staged, err := b.Open(token)
+	if err != nil {
+		b.Discard(token)
+		return nil, err
+	}
+	return staged, nil
+}
+
+// Open reopens a staged bundle by its token.
+//
+// Returns:
+//   - *StagedBundle: The decoded bundle; Close it after use
+//   - error: ErrBundleExpired when there is no such bundle, ErrBundleFormat
+//     when it is not a valid bundle
+func (b *ContentBundles) Open(token string) (*StagedBundle, error) {
+	if len(token) != 32 || strings.Trim(token, "0123456789abcdef") != "" {
+		return nil, ErrBundleExpired
+	}
+	zr, err := zip.OpenReader(b.stagedPath(token))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBundleExpired
+	}
+	if err != nil {
+		return nil, ErrBundleFormat
+	}
+	bundle, err := readBundleManifest(&zr.Reader)
+	if err != nil {
+		zr.Close()
+		return nil, err
+	}
+	return &StagedBundle{Token: token, Bundle: bundle, zip: zr}, nil
+}
+
+// Discard removes a staged bundle.
+func (b *ContentBundles) Discard(token string) {
+	if len(token) == 32 && strings.Trim(token, "0123456789abcdef") == "" {
+		os.Remove(b.stagedPath(token))
+	}
+}
+
+// stagedPath returns the file of a staged bundle.
+func (b *ContentBundles) stagedPath(token string) string {
+	return filepath.Join(b.stageDir, token+".zip")
+}
+
+// pruneStaged removes staged bundles older than bundleStageTTL.
+func (b *ContentBundles) pruneStaged(now time.Time) {
+	entries, err := os.ReadDir(b.stageDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && strings.HasSuffix(entry.Name(), ".zip") && now.Sub(info.ModTime()) > bundleStageTTL {
+			os.Remove(filepath.Join(b.stageDir, entry.Name()))
+		}
+	}
+}
+
+// readBundleManifest decodes and checks the manifest of a bundle ZIP.
+func readBundleManifest(zr *zip.Reader) (*ContentBundle, error) {
+	f, err := zr.Open(contentBundleManifest)
+	if err != nil {
+		return nil, ErrBundleFormat
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxBundleManifest+1))
+	if err != nil || len(data) > maxBundleManifest {
+		return nil, ErrBundleFormat
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var bundle ContentBundle
+	if err := dec.Decode(&bundle); err != nil || bundle.Format != ContentBundleFormat || bundle.Version != ContentBundleVersion {
+		return nil, ErrBundleFormat
+	}
+	for i := range bundle.Items {
+		item := &bundle.Items[i]
+		if _, ok := lookupBundleKind(item.Kind); !ok || item.Slug == "" || item.Row == nil {
+			return nil, ErrBundleFormat
+		}
+		normalizeBundleRow(item.Row)
+		for _, rows := range item.Children {
+			for _, r := range rows {
+				normalizeBundleRow(r)
+			}
+		}
+		for _, r := range item.Translations {
+			normalizeBundleRow(r)
+		}
+	}
+	for _, m := range bundle.Media {
+		if !validBundleMediaPath(m.Path) {
+			return nil, ErrBundleFormat
+		}
+		normalizeBundleRow(m.Library)
+	}
+	return &bundle, nil
+}
+
+// validBundleMediaPath reports whether p is a clean path below /uploads/.
+func validBundleMediaPath(p string) bool {
+	return strings.HasPrefix(p, "/uploads/") && path.Clean(p) == p && !strings.Contains(p, "..")
+}
+
+// BundlePlanItem is one bundle item on the import preview.
+type BundlePlanItem struct {
+	Index      int    // Position in the bundle, names the resolution field
+	Kind       string // Content type
+	Label      string // e.g. "Product"
+	Slug       string
+	Title      string
+	Selected   bool   // Chosen on export; references are created or reused without a choice
+	ExistingID int64  // Item with the same slug (or name) in this installation, 0 when new
+	Conflict   string // Why the item cannot be created or overwritten as it is
+}
+
+// Choices returns the resolutions offered for the item; none when it is
+// simply created or reused.
+func (p BundlePlanItem) Choices() []string {
+	switch {
+	case !p.Selected:
+		return nil
+	case p.Conflict != "":
+		return []string{BundleSkip, BundleCopy}
+	case p.ExistingID != 0:
+		return []string{BundleSkip, BundleOverwrite, BundleCopy}
+	}
+	return nil
+}
+
+// BundlePlan is the import preview of a bundle.
+type BundlePlan struct {
+	Items      []BundlePlanItem // Selected items
+	References []BundlePlanItem // Lookups, created when missing
+	Media      int              // Uploaded files in the bundle
+	ExportedAt time.Time
+}
+
+// Conflicts counts the selected items that need a resolution.
+func (p *BundlePlan) Conflicts() int {
+	n := 0
+	for _, item := range p.Items {
+		if len(item.Choices()) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Plan compares a bundle with this installation: which items are new and
+// which slugs are already taken. Nothing is written.
+func (b *ContentBundles) Plan(ctx context.Context, bundle *ContentBundle) (*BundlePlan, error) {
+	plan := &BundlePlan{Media: len(bundle.Media), ExportedAt: bundle.ExportedAt}
+	for i, item := range bundle.Items {
+		k, _ := lookupBundleKind(item.Kind)
+		p := BundlePlanItem{Index: i, Kind: k.kind, Label: k.label, Slug: item.Slug, Title: item.Title, Selected: item.Selected}
+		var err error
+		if p.ExistingID, err = findBundleItem(ctx, b.db, k, item.Row); err != nil {
+			return nil, err
+		}
+		if p.Conflict, err = uniqueConflict(ctx, b.db, k, item.Row, p.ExistingID); err != nil {
+			return nil, err
+		}
+		if item.Selected {
+			plan.Items = append(plan.Items, p)
+		} else {
+			plan.References = append(plan.References, p)
+		}
+	}
+	return plan, nil
+}
+
+// findBundleItem returns the ID of the item of kind k in db that matches
+// row by slug (or, for lookups, by name), 0 when there is none.
+func findBundleItem(ctx context.Context, q bundleQuerier, k bundleKind, row BundleRow) (int64, error) {
+	for _, col := range k.match {
+		value, _ := row[col].(string)
+		if value == "" {
+			continue
+		}
+		id, err := queryID(ctx, q, "SELECT id FROM "+k.table+" WHERE "+col+" = ? LIMIT 1", value)
+		if id != 0 || err != nil {
+			return id, err
+		}
+	}
+	return 0, nil
+}
+
+// uniqueConflict reports a unique column of row (such as a product's SKU)
+// that another item than existingID already uses.
+func uniqueConflict(ctx context.Context, q bundleQuerier, k bundleKind, row BundleRow, existingID int64) (string, error) {
+	for _, col := range k.unique {
+		value, _ := row[col].(string)
+		if value == "" {
+			continue
+		}
+		id, err := queryID(ctx, q, "SELECT id FROM "+k.table+" WHERE "+col+" = ? LIMIT 1", value)
+		if err != nil {
+			return "", err
+		}
+		if id != 0 && id != existingID {
+			return fmt.Sprintf("%s %s is used by another %s", strings.ToUpper(col), value, strings.ToLower(k.label)), nil
+		}
+	}
+	return "", nil
+}
+
+// BundleReportItem is one item written or skipped by an import.
+type BundleReportItem struct {
+	Kind     string // Content type
+	Label    string // e.g. "Product"
+	ID       int64  // ID in this installation (0 when skipped without a match)
+	Title    string
+	Action   string // "created", "updated", "copied" or "skipped"
+	Selected bool   // False for lookups created because selected content refers to them
+}
+
+// BundleReport summarises an import.
+type BundleReport struct {
+	Items        []BundleReportItem
+	MediaWritten int      // Uploaded files added
+	MediaRenamed int      // Of those, files stored under a new name because the path held another file
+	Warnings     []string // Links to content the target does not have, which were left out
+}
+
+// Count returns how many items were given action.
+func (r *BundleReport) Count(action string) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Apply imports a staged bundle in one transaction. resolutions gives the
+// choice for selected items whose slug is taken, by BundlePlanItem.Index;
+// items without one are skipped. Referenced lookups are reused when this
+// installation has them and created otherwise. Uploaded files are written
+// before the transaction commits and removed again if it fails.
+func (b *ContentBundles) Apply(ctx context.Context, staged *StagedBundle, resolutions map[int]string) (*BundleReport, error) {
+	a := &bundleApplier{
+		columns: map[string]map[string]bool{},
+		ids:     map[string]map[string]int64{},
+		report:  &BundleReport{},
+	}
+	var written []string
+	err := database.WithDBTx(ctx, b.db, func(tx database.DBTX) error {
+		a.tx = tx
+		bundle := staged.Bundle
+		actions, err := a.decide(ctx, bundle, resolutions)
+		if err != nil {
+			return err
+		}
+		if written, err = b.importMedia(ctx, a, staged, actions); err != nil {
+			return err
+		}
+		return a.write(ctx, bundle, actions)
+	})
+	if err != nil {
+		removeFiles(written)
+		return nil, err
+	}
+	return a.report, nil
+}
+
+// bundleAction is what Apply does with one item.
+type bundleAction struct {
+	action     string // "created", "updated", "copied", "skipped" or "reused"
+	existingID int64
+}
+
+// bundleApplier holds the state of one import.
+type bundleApplier struct {
+	tx      bundleQuerier
+	columns map[string]map[string]bool  // Table -> column names
+	ids     map[string]map[string]int64 // Kind -> bundle slug -> ID in this installation
+	report  *BundleReport
+}
+
+// decide works out the action for every item from the plan and resolutions.
+func (a *bundleApplier) decide(ctx context.Context, bundle *ContentBundle, resolutions map[int]string) ([]bundleAction, error) {
+	actions := make([]bundleAction, len(bundle.Items))
+	for i, item := range bundle.Items {
+		k, _ := lookupBundleKind(item.Kind)
+		existing, err := findBundleItem(ctx, a.tx, k, item.Row)
+		if err != nil {
+			return nil, err
+		}
+		conflict, err := uniqueConflict(ctx, a.tx, k, item.Row, existing)
+		if err != nil {
+			return nil, err
+		}
+		act := bundleAction{existingID: existing}
+		switch {
+		case !item.Selected && existing != 0:
+			act.action = "reused"
+		case !item.Selected || (existing == 0 && conflict == ""):
+			act.action = "created"
+		case resolutions[i] == BundleCopy:
+			act.action = "copied"
+		case resolutions[i] == BundleOverwrite && conflict == "":
+			act.action = "updated"
+		default:
+			act.action = "skipped"
+		}
+		actions[i] = act
+	}
+	return actions, nil
+}
+
+// write inserts or updates the items' rows in import order, then their
+// sub-resources and translations, once every item in the bundle has an ID
+// that links can point at.
+func (a *bundleApplier) write(ctx context.Context, bundle *ContentBundle, actions []bundleAction) error {
+	newIDs := make([]int64, len(bundle.Items))
+	for i, item := range bundle.Items {
+		k, _ := lookupBundleKind(item.Kind)
+		act := actions[i]
+		if a.ids[k.kind] == nil {
+			a.ids[k.kind] = map[string]int64{}
+		}
+		row, err := a.resolveRefs(ctx, k.refs, copyBundleRow(item.Row), item.Title)
+		if err != nil {
+			return err
+		}
+		title := item.Title
+		var id int64
+		switch act.action {
+		case "reused":
+			a.ids[k.kind][item.Slug] = act.existingID
+			continue
+		case "skipped":
+			a.ids[k.kind][item.Slug] = act.existingID
+			a.report.Items = append(a.report.Items, BundleReportItem{Kind: k.kind, Label: k.label, ID: act.existingID, Title: title, Action: act.action, Selected: true})
+			continue
+		case "created":
+			id, err = a.insert(ctx, k.table, row)
+		case "updated":
+			id = act.existingID
+			err = a.overwrite(ctx, k, id, row)
+		case "copied":
+			if err = a.makeCopy(ctx, k, row); err == nil {
+				title, _ = row[k.title].(string)
+				id, err = a.insert(ctx, k.table, row)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", k.label, item.Title, err)
+		}
+		newIDs[i] = id
+		if act.action != "copied" {
+			a.ids[k.kind][item.Slug] = id
+		}
+		a.report.Items = append(a.report.Items, BundleReportItem{Kind: k.kind, Label: k.label, ID: id, Title: title, Action: act.action, Selected: item.Selected})
+	}
+
+	for i, item := range bundle.Items {
+		if newIDs[i] == 0 {
+			continue
+		}
+		k, _ := lookupBundleKind(item.Kind)
+		if err := a.writeChildren(ctx, k, item, newIDs[i]); err != nil {
+			return fmt.Errorf("%s %q: %w", k.label, item.Title, err)
+		}
+	}
+	return nil
+}
+
+// writeChildren inserts an item's sub-resources and translations.
+func (a *bundleApplier) writeChildren(ctx context.Context, k bundleKind, item BundleItem, id int64) error {
+	for _, child := range k.children {
+		var inserted []int64 // IDs of the rows written so far, by position
+		for _, c := range item.Children[child.table] {
+			row, err := a.resolveRefs(ctx, child.refs, copyBundleRow(c), item.Title)
+			if errors.Is(err, errBundleLinkMissing) {
+				inserted = append(inserted, 0)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			row[child.parent] = id
+			if child.self != "" {
+				row[child.self] = nil
+				if pos, ok := c[child.self].(int64); ok && pos >= 0 && pos < int64(len(inserted)) && inserted[pos] != 0 {
+					row[child.self] = inserted[pos]
+				}
+			}
+			newID, err := a.insert(ctx, child.table, row)
+			if err != nil {
+				return err
+			}
+			inserted = append(inserted, newID)
+		}
+	}
+	if k.translated {
+		for _, t := range item.Translations {
+			row := copyBundleRow(t)
+			row["entity_type"], row["entity_id"] = k.kind, id
+			if _, err := a.insert(ctx, "translations", row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// errBundleLinkMissing marks a sub-resource linking to content that this
+// installation does not have; it is left out with a warning.
+var errBundleLinkMissing = errors.New("linked content missing")
+
+// resolveRefs replaces the slugs in the reference columns of row with IDs
+// in this installation.
+func (a *bundleApplier) resolveRefs(ctx context.Context, refs map[string]bundleRef, row BundleRow, owner string) (BundleRow, error) {
+	for col, ref := range refs {
+		slug, ok := row[col].(string)
+		if !ok {
+			row[col] = nil
+			continue
+		}
+		id := a.ids[ref.kind][slug]
+		if id == 0 {
+			target, _ := lookupBundleKind(ref.kind)
+			var err error
+			if id, err = queryID(ctx, a.tx, "SELECT id FROM "+target.table+" WHERE slug = ? LIMIT 1", slug); err != nil {
+				return nil, err
+			}
+			if id == 0 {
+				if ref.include {
+					return nil, fmt.Errorf("refers to %s %q, which is not in the bundle", strings.ToLower(target.label), slug)
+				}
+				a.report.Warnings = append(a.report.Warnings, fmt.Sprintf("%q links to %s %q, which this site does not have; the link was left out.", owner, strings.ToLower(target.label), slug))
+				return nil, errBundleLinkMissing
+			}
+		}
+		row[col] = id
+	}
+	return row, nil
+}
+
+// overwrite replaces an existing item's row, sub-resources and
+// translations with the bundle's.
+func (a *bundleApplier) overwrite(ctx context.Context, k bundleKind, id int64, row BundleRow) error {
+	if err := a.update(ctx, k.table, id, row); err != nil {
+		return err
+	}
+	for _, child := range k.children {
+		if _, err := a.tx.ExecContext(ctx, "DELETE FROM "+child.table+" WHERE "+child.parent+" = ?", id); err != nil {
+			return err
+		}
+	}
+	if k.translated {
+		if _, err := a.tx.ExecContext(ctx, "DELETE FROM translations WHERE entity_type = ? AND entity_id = ?", k.kind, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// makeCopy turns row into an unpublished copy, like "Save as copy": the
+// title gets " (copy)" and the slug and unique columns the first free
+// "-copy" suffix.
+func (a *bundleApplier) makeCopy(ctx context.Context, k bundleKind, row BundleRow) error {
+	title, _ := row[k.title].(string)
+	row[k.title] = copyTitle(title)
+	for _, col := range append([]string{"slug"}, k.unique...) {
+		value, _ := row[col].(string)
+		suffix := "-copy"
+		if col != "slug" {
+			suffix = "-COPY"
+		}
+		free, err := a.freeValue(ctx, k.table, col, value, suffix)
+		if err != nil {
+			return err
+		}
+		row[col] = free
+	}
+	for col, v := range k.draft {
+		row[col] = v
+	}
+	return nil
+}
+
+// freeValue returns the first of "<value><suffix>", "<value><suffix>-2", ...
+// that no row of table uses in col.
+func (a *bundleApplier) freeValue(ctx context.Context, table, col, value, suffix string) (string, error) {
+	for n := 1; n <= maxCopySuffix; n++ {
+		candidate := value + suffix
+		if n > 1 {
+			candidate = fmt.Sprintf("%s%s-%d", value, suffix, n)
+		}
+		id, err := queryID(ctx, a.tx, "SELECT id FROM "+table+" WHERE "+col+" = ? LIMIT 1", candidate)
+		if err != nil {
+			return "", err
+		}
+		if id == 0 {
+			return candidate, nil
+		}
+	}
+	return "", errNoFreeCopyName
+}
+
+// insert adds row to table, using only the table's own columns.
+func (a *bundleApplier) insert(ctx context.Context, table string, row BundleRow) (int64, error) {
+	cols, args, err := a.bind(ctx, table, row)
+	if err != nil {
+		return 0, err
+	}
+	query := "INSERT INTO " + table + " (" + strings.Join(cols, ", ") + ") VALUES (" + strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + ")"
+	res, err := a.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// update writes row over the row id of table.
+func (a *bundleApplier) update(ctx context.Context, table string, id int64, row BundleRow) error {
+	cols, args, err := a.bind(ctx, table, row)
+	if err != nil {
+		return err
+	}
+	set := make([]string, len(cols))
+	for i, col := range cols {
+		set[i] = col + " = ?"
+	}
+	_, err = a.tx.ExecContext(ctx, "UPDATE "+table+" SET "+strings.Join(set, ", ")+" WHERE id = ?", append(args, id)...)
+	return err
+}
+
+// bind returns the columns of row that table has, in a stable order, and
+// their values. Column names in the bundle are never used in SQL unless
+// the table has them, and omitted columns keep their defaults.
+func (a *bundleApplier) bind(ctx context.Context, table string, row BundleRow) ([]string, []interface{}, error) {
+	known, ok := a.columns[table]
+	if !ok {
+		var err error
+		if known, err = tableColumns(ctx, a.tx, table); err != nil {
+			return nil, nil, err
+		}
+		a.columns[table] = known
+	}
+	var cols []string
+	for col := range row {
+		if known[col] && !bundleOmitted[col] {
+			cols = append(cols, col)
+		}
+	}
+	sort.Strings(cols)
+	args := make([]interface{}, len(cols))
+	for i, col := range cols {
+		args[i] = row[col]
+	}
+	return cols, args, nil
+}
+
+// importMedia writes the uploaded files referenced by the items being
+// written. A file the target already has with the same content is reused;
+// when the path holds a different file, the bundle's file gets a new name
+// and the items' references are rewritten. Media library rows are added for
+// files written.
+//
+// Returns:
+//   - []string: Files written, to remove if the import fails
+//   - error: File system or database error
+func (b *ContentBundles) importMedia(ctx context.Context, a *bundleApplier, staged *StagedBundle, actions []bundleAction) ([]string, error) {
+	bundle := staged.Bundle
+	var writing []BundleItem
+	var writingIdx []int
+	for i, item := range bundle.Items {
+		switch actions[i].action {
+		case "created", "updated", "copied":
+			writing = append(writing, item)
+			writingIdx = append(writingIdx, i)
+		}
+	}
+	needed := map[string]bool{}
+	for _, p := range bundleMediaPaths(writing) {
+		needed[p] = true
+	}
+
+	var written []string
+	renames := map[string]string{}
+	for _, m := range bundle.Media {
+		if !needed[m.Path] {
+			continue
+		}
+		target, present, err := b.mediaTarget(m)
+		if err != nil {
+			return written, err
+		}
+		if target != m.Path {
+			renames[m.Path] = target
+		}
+		if present {
+			continue // Same file already here
+		}
+		src, err := staged.zip.Open(contentBundleMediaDir + strings.TrimPrefix(m.Path, "/uploads/"))
+		if err != nil {
+			continue // Listed without its file; the link stays as it is
+		}
+		file := b.mediaFile(target)
+		err = writeNewFile(file, src)
+		src.Close()
+		if err != nil {
+			return written, err
+		}
+		written = append(written, file)
+		a.report.MediaWritten++
+		if target != m.Path {
+			a.report.MediaRenamed++
+		}
+		if m.Library != nil {
+			lib := copyBundleRow(m.Library)
+			lib["file_path"] = target
+			lib["filename"] = path.Base(target)
+			if _, err := a.insert(ctx, "media_files", lib); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	if len(renames) > 0 {
+		for _, i := range writingIdx {
+			rewriteBundleItem(&bundle.Items[i], renames)
+		}
+	}
+	return written, nil
+}
+
+// mediaTarget returns the web path of a bundle file in this installation:
+// its own path when that is free or holds the same file, otherwise the first
+// numbered name next to it that is free or holds the same file. present
+// reports whether the file is already there.
+func (b *ContentBundles) mediaTarget(m BundleMedia) (target string, present bool, err error) {
+	ext := path.Ext(m.Path)
+	stem := strings.TrimSuffix(m.Path, ext)
+	for n := 1; n <= maxCopySuffix; n++ {
+		candidate := m.Path
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d%s", stem, n, ext)
+		}
+		sum, err := fileSHA256(b.mediaFile(candidate))
+		if errors.Is(err, os.ErrNotExist) {
+			return candidate, false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if sum == m.SHA256 {
+			return candidate, true, nil
+		}
+	}
+	return "", false, errNoFreeCopyName
+}
+
+// writeNewFile creates file (and its directory) with the contents of r. An
+// existing file is never replaced.
+func writeNewFile(file string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(file)
+		return err
+	}
+	return f.Close()
+}
+
+// removeFiles deletes files written by a failed import.
+func removeFiles(files []string) {
+	for _, f := range files {
+		os.Remove(f)
+	}
+}
+
+// rewriteBundleItem replaces renamed media paths in every text value of an
+// item.
+func rewriteBundleItem(item *BundleItem, renames map[string]string) {
+	rewrite := func(row BundleRow) {
+		for col, v := range row {
+			if s, ok := v.(string); ok && strings.Contains(s, "/uploads/") {
+				row[col] = uploadRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+					if to, ok := renames[ref]; ok {
+						return to
+					}
+					return ref
+				})
+			}
+		}
+	}
+	rewrite(item.Row)
+	for _, rows := range item.Children {
+		for _, r := range rows {
+			rewrite(r)
+		}
+	}
+	for _, r := range item.Translations {
+		rewrite(r)
+	}
+}
+
+// bundleMediaPaths returns the /uploads/ paths referenced by items, sorted.
+func bundleMediaPaths(items []BundleItem) []string {
+	found := map[string]bool{}
+	collect := func(row BundleRow) {
+		for _, v := range row {
+			if s, ok := v.(string); ok {
+				for _, ref := range uploadRefPattern.FindAllString(s, -1) {
+					if validBundleMediaPath(ref) {
+						found[ref] = true
+					}
+				}
+			}
+		}
+	}
+	for _, item := range items {
+		collect(item.Row)
+		for _, rows := range item.Children {
+			for _, r := range rows {
+				collect(r)
+			}
+		}
+		for _, r := range item.Translations {
+			collect(r)
+		}
+	}
+	paths := make([]string, 0, len(found))
+	for p := range found {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// queryBundleRows runs query and returns every row as a BundleRow.
+func queryBundleRows(ctx context.Context, q bundleQuerier, query string, args ...interface{}) ([]BundleRow, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []BundleRow
+	for rows.Next() {
+		values := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(BundleRow, len(cols))
+		for i, col := range cols {
+			row[col] = bundleValue(values[i])
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// bundleValue converts a scanned column value to its JSON form. Times use
+// SQLite's CURRENT_TIMESTAMP format, so imported rows compare like native
+// ones.
+func bundleValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format("2006-01-02 15:04:05")
+	case []byte:
+		return string(v)
+	}
+	return v
+}
+
+// normalizeBundleRow converts the json.Numbers of a decoded row to int64
+// or float64.
+func normalizeBundleRow(row BundleRow) {
+	for col, v := range row {
+		if n, ok := v.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				row[col] = i
+			} else if f, err := n.Float64(); err == nil {
+				row[col] = f
+			} else {
+				row[col] = nil
+			}
+		}
+	}
+}
+
+// stripOmitted removes the columns that are not carried between
+// installations and returns row.
+func stripOmitted(row BundleRow) BundleRow {
+	for col := range bundleOmitted {
+		delete(row, col)
+	}
+	return row
+}
+
+// selfPositions replaces the row IDs in column col of rows with the
+// position of the referenced row in rows, or nil when it is not there, so
+// the reference survives the new IDs rows get on import. Referenced rows
+// come first (rows are exported in ID order).
+func selfPositions(rows []BundleRow, col string) {
+	positions := make(map[int64]int64, len(rows))
+	for i, row := range rows {
+		if id, ok := row["id"].(int64); ok {
+			positions[id] = int64(i)
+		}
+	}
+	for _, row := range rows {
+		id, ok := row[col].(int64)
+		pos, found := positions[id]
+		if ok && found {
+			row[col] = pos
+		} else {
+			row[col] = nil
+		}
+	}
+}
+
+// copyBundleRow returns a shallow copy of row.
+func copyBundleRow(row BundleRow) BundleRow {
+	out := make(BundleRow, len(row))
+	for k, v := range row {
+		out[k] = v
+	}
+	return out
+}
+
+// tableColumns returns the column names of table.
+func tableColumns(ctx context.Context, q bundleQuerier, table string) (map[string]bool, error) {
+	rows, err := queryBundleRows(ctx, q, "SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	cols := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		if name, ok := r["name"].(string); ok {
+			cols[name] = true
+		}
+	}
+	return cols, nil
+}
+
+// queryID runs a query selecting one ID and returns 0 when there is no row.
+func queryID(ctx context.Context, q bundleQuerier, query string, args ...interface{}) (int64, error) {
+	rows, err := queryBundleRows(ctx, q, query, args...)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	id, _ := rows[0]["id"].(int64)
+	return id, nil
+}
+
+// fileSHA256 returns the hex SHA-256 of a file's content.
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,260 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestContentBundles_ExportImport exports a blog post and the product it
+// links from one site and imports them into another that has the product
+// category already and a different file at the product image's path.
+func TestContentBundles_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	srcDB, src, cleanupSrc := testutil.SetupTestDB(t)
+	defer cleanupSrc()
+	dstDB, dst, cleanupDst := testutil.SetupTestDB(t)
+	defer cleanupDst()
+	srcUploads, dstUploads := t.TempDir(), t.TempDir()
+
+	// Source content
+	writeUpload(t, srcUploads, "products/gw.png", "gateway image")
+	cat, _ := src.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	product, err := src.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway", Description: "d", CategoryID: cat.ID, Status: "published"})
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	src.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: product.ID, SectionName: "General", SpecKey: "Ports", SpecValue: "4"})
+	src.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: product.ID, ImagePath: "/uploads/products/gw.png"})
+	src.UpsertTranslation(ctx, sqlc.UpsertTranslationParams{EntityType: "product", EntityID: product.ID, Locale: "de", Fields: `{"name":"Edge-Gateway"}`, Status: "published"})
+	srcDB.Exec(`INSERT INTO media_files (filename, original_filename, file_path, file_size, mime_type) VALUES ('gw.png', 'gw.png', '/uploads/products/gw.png', 13, 'image/png')`)
+
+	blogCat, _ := src.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000"})
+	author, _ := src.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Ada", Slug: "ada", Title: "Editor"})
+	tag, _ := src.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Launch", Slug: "launch"})
+	post, err := src.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Launch", Slug: "launch", Excerpt: "e", Body: `<img src="/uploads/products/gw.png">`,
+		CategoryID: blogCat.ID, AuthorID: author.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("create post: %v", err)
+	}
+	src.AddTagToPost(ctx, sqlc.AddTagToPostParams{BlogPostID: post.ID, BlogTagID: tag.ID})
+	srcDB.Exec(`INSERT INTO blog_post_products (blog_post_id, product_id, display_order) VALUES (?, ?, 0)`, post.ID, product.ID)
+
+	exporter := services.NewContentBundles(srcDB, srcUploads, t.TempDir())
+	bundle, err := exporter.Export(ctx, []services.BundleSelection{{Kind: "blog_post", ID: post.ID}, {Kind: "product", ID: product.ID}}, time.Now())
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	kinds := make([]string, len(bundle.Items))
+	for i, item := range bundle.Items {
+		kinds[i] = item.Kind
+	}
+	if got := strings.Join(kinds, ","); got != "blog_author,blog_category,blog_tag,blog_post,product_category,product" {
+		t.Errorf("items %s; lookups should precede the items referring to them", got)
+	}
+	if len(bundle.Media) != 1 || bundle.Media[0].Path != "/uploads/products/gw.png" || bundle.Media[0].Library == nil {
+		t.Fatalf("media %+v", bundle.Media)
+	}
+	var zipped bytes.Buffer
+	if err := exporter.WriteBundle(&zipped, bundle); err != nil {
+		t.Fatalf("WriteBundle: %v", err)
+	}
+
+	// Target site: the category exists, and another file uses the image path
+	existingCat, _ := dst.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	writeUpload(t, dstUploads, "products/gw.png", "another image")
+	importer := services.NewContentBundles(dstDB, dstUploads, t.TempDir())
+
+	if _, err := importer.Stage(strings.NewReader("not a zip")); !errors.Is(err, services.ErrBundleFormat) {
+		t.Errorf("Stage(garbage) = %v, want ErrBundleFormat", err)
+	}
+
+	apply := func(t *testing.T, choose map[string]string) *services.BundleReport {
+		t.Helper()
+		staged, err := importer.Stage(bytes.NewReader(zipped.Bytes()))
+		if err != nil {
+			t.Fatalf("Stage: %v", err)
+		}
+		defer staged.Close()
+		plan, err := importer.Plan(ctx, staged.Bundle)
+		if err != nil {
+			t.Fatalf("Plan: %v", err)
+		}
+		resolutions := map[int]string{}
+		for _, item := range plan.Items {
+			if r, ok := choose[item.Kind]; ok {
+				resolutions[item.Index] = r
+			}
+		}
+		report, err := importer.Apply(ctx, staged, resolutions)
+		if err != nil {
+			t.Fatalf("Apply: %v", err)
+		}
+		return report
+	}
+
+	t.Run("first import creates everything", func(t *testing.T) {
+		report := apply(t, nil)
+		if report.Count("created") != 5 || report.MediaWritten != 1 || report.MediaRenamed != 1 {
+			t.Errorf("report %+v: want 5 created (post, product and 3 blog lookups) and 1 renamed file", report)
+		}
+		got, err := dst.GetProductBySlug(ctx, "gw-1")
+		if err != nil {
+			t.Fatalf("imported product: %v", err)
+		}
+		if got.CategoryID != existingCat.ID || got.Sku != "GW-1" {
+			t.Errorf("product %+v should use the existing category %d", got, existingCat.ID)
+		}
+		images, _ := dst.ListProductImages(ctx, got.ID)
+		if len(images) != 1 || images[0].ImagePath != "/uploads/products/gw-2.png" {
+			t.Errorf("images %+v, want the renamed file", images)
+		}
+		if data, _ := os.ReadFile(filepath.Join(dstUploads, "products", "gw-2.png")); string(data) != "gateway image" {
+			t.Errorf("renamed file holds %q", data)
+		}
+		if data, _ := os.ReadFile(filepath.Join(dstUploads, "products", "gw.png")); string(data) != "another image" {
+			t.Error("existing file was overwritten")
+		}
+		if tr, err := dst.GetTranslation(ctx, sqlc.GetTranslationParams{EntityType: "product", EntityID: got.ID, Locale: "de"}); err != nil || tr.Fields != `{"name":"Edge-Gateway"}` {
+			t.Errorf("translation %+v, %v", tr, err)
+		}
+
+		var body string
+		var linked, tags, library int
+		dstDB.QueryRow(`SELECT body FROM blog_posts WHERE slug = 'launch'`).Scan(&body)
+		dstDB.QueryRow(`SELECT COUNT(*) FROM blog_post_products WHERE product_id = ?`, got.ID).Scan(&linked)
+		dstDB.QueryRow(`SELECT COUNT(*) FROM blog_post_tags`).Scan(&tags)
+		dstDB.QueryRow(`SELECT COUNT(*) FROM media_files WHERE file_path = '/uploads/products/gw-2.png'`).Scan(&library)
+		if body != `<img src="/uploads/products/gw-2.png">` || linked != 1 || tags != 1 || library != 1 {
+			t.Errorf("post body %q, %d product links, %d tags, %d library rows", body, linked, tags, library)
+		}
+	})
+
+	t.Run("conflicts overwrite, copy or skip by slug", func(t *testing.T) {
+		dstDB.Exec(`UPDATE products SET name = 'Changed' WHERE slug = 'gw-1'`)
+		report := apply(t, map[string]string{"product": services.BundleOverwrite, "blog_post": services.BundleCopy})
+		if report.Count("updated") != 1 || report.Count("copied") != 1 || report.MediaWritten != 0 {
+			t.Errorf("report %+v: want the product overwritten, the post copied and no files written", report)
+		}
+		product, _ := dst.GetProductBySlug(ctx, "gw-1")
+		specs, _ := dst.ListProductSpecs(ctx, product.ID)
+		if product.Name != "Edge Gateway" || len(specs) != 1 {
+			t.Errorf("overwritten product %q with %d specs", product.Name, len(specs))
+		}
+		var title, status, body string
+		if err := dstDB.QueryRow(`SELECT title, status, body FROM blog_posts WHERE slug = 'launch-copy'`).Scan(&title, &status, &body); err != nil {
+			t.Fatalf("copy: %v", err)
+		}
+		if title != "Launch (copy)" || status != "draft" || body != `<img src="/uploads/products/gw-2.png">` {
+			t.Errorf("copy %q %q %q", title, status, body)
+		}
+
+		report = apply(t, nil)
+		if report.Count("skipped") != 2 || len(report.Items) != 2 {
+			t.Errorf("report %+v: conflicts without a choice should be skipped", report)
+		}
+		var posts int
+		dstDB.QueryRow(`SELECT COUNT(*) FROM blog_posts`).Scan(&posts)
+		if posts != 2 {
+			t.Errorf("%d posts after skipping, want 2", posts)
+		}
+	})
+
+	t.Run("applied bundles expire", func(t *testing.T) {
+		staged, _ := importer.Stage(bytes.NewReader(zipped.Bytes()))
+		staged.Close()
+		importer.Discard(staged.Token)
+		if _, err := importer.Open(staged.Token); !errors.Is(err, services.ErrBundleExpired) {
+			t.Errorf("Open after Discard = %v, want ErrBundleExpired", err)
+		}
+		if _, err := importer.Open("../../etc/passwd"); !errors.Is(err, services.ErrBundleExpired) {
+			t.Errorf("Open(path) = %v, want ErrBundleExpired", err)
+		}
+	})
+
+	if _, err := exporter.Export(ctx, []services.BundleSelection{{Kind: "product", ID: 999}}, time.Now()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Export(missing) = %v, want sql.ErrNoRows", err)
+	}
+}
+
+// TestContentBundles_DownloadVersions checks that the version chains of a
+// product's downloads survive export and import with new row IDs.
+func TestContentBundles_DownloadVersions(t *testing.T) {
+	ctx := context.Background()
+	srcDB, src, cleanupSrc := testutil.SetupTestDB(t)
+	defer cleanupSrc()
+	dstDB, dst, cleanupDst := testutil.SetupTestDB(t)
+	defer cleanupDst()
+	srcUploads := t.TempDir()
+
+	cat, _ := src.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Gateways", Slug: "gateways", Description: "d", Icon: "i"})
+	product, _ := src.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "GW-1", Slug: "gw-1", Name: "Edge Gateway", Description: "d", CategoryID: cat.ID, Status: "published"})
+	var previous sql.NullInt64
+	for _, v := range []string{"1", "2", "3"} {
+		writeUpload(t, srcUploads, "downloads/gw-v"+v+".pdf", "datasheet "+v)
+		d, err := src.CreateProductDownload(ctx, sqlc.CreateProductDownloadParams{
+			ProductID: product.ID, Title: "Datasheet", FileType: "pdf", FilePath: "/uploads/downloads/gw-v" + v + ".pdf",
+			Version: sql.NullString{String: v, Valid: true}, SupersedesID: previous,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		previous = sql.NullInt64{Int64: d.ID, Valid: true}
+	}
+	// Rows in the target get other IDs than in the source
+	dstDB.Exec(`INSERT INTO sqlite_sequence (name, seq) VALUES ('product_downloads', 100)`)
+
+	exporter := services.NewContentBundles(srcDB, srcUploads, t.TempDir())
+	bundle, err := exporter.Export(ctx, []services.BundleSelection{{Kind: "product", ID: product.ID}}, time.Now())
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	var zipped bytes.Buffer
+	if err := exporter.WriteBundle(&zipped, bundle); err != nil {
+		t.Fatalf("WriteBundle: %v", err)
+	}
+	importer := services.NewContentBundles(dstDB, t.TempDir(), t.TempDir())
+	staged, err := importer.Stage(bytes.NewReader(zipped.Bytes()))
+	if err != nil {
+		t.Fatalf("Stage: %v", err)
+	}
+	defer staged.Close()
+	if _, err := importer.Apply(ctx, staged, nil); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	got, err := dst.GetProductBySlug(ctx, "gw-1")
+	if err != nil {
+		t.Fatalf("imported product: %v", err)
+	}
+	downloads, _ := dst.ListProductDownloads(ctx, got.ID)
+	groups := services.GroupDownloadVersions(downloads)
+	if len(groups) != 1 || groups[0].Latest.Version.String != "3" || len(groups[0].Older) != 2 ||
+		groups[0].Older[0].Version.String != "2" || groups[0].Older[1].Version.String != "1" || groups[0].Latest.ID < 100 {
+		t.Errorf("imported downloads %+v, want v3 superseding v2 superseding v1", groups)
+	}
+}
+
+// writeUpload writes a file below an upload directory.
+func writeUpload(t *testing.T, dir, name, content string) {
+	t.Helper()
+	file := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IndexOtherLetter heads A–Z index entries whose name does not start with
+// an ASCII letter (digits, symbols, accented letters).
+const IndexOtherLetter = "#"
+
+// IndexGroup is one letter section of an A–Z index page.
+type IndexGroup[T any] struct {
+	Letter string // "A"–"Z", or IndexOtherLetter
+	Items  []T    // Entries in their original order
+}
+
+// IndexJump is one link of the letter bar above an A–Z index. Letters
+// without entries are still listed so the bar always reads # A B … Z.
+type IndexJump struct {
+	Letter   string // "A"–"Z", or IndexOtherLetter
+	HasItems bool   // False renders the letter as plain text, not a link
+}
+
+// IndexLetter returns the index heading for name: its first letter
+// upper-cased if that is A–Z, otherwise IndexOtherLetter.
+func IndexLetter(name string) string {
+	r, _ := utf8.DecodeRuneInString(strings.TrimSpace(name))
+	r = unicode.ToUpper(r)
+	if r >= 'A' && r <= 'Z' {
+		return string(r)
+	}
+	return IndexOtherLetter
+}
+
+// GroupByLetter splits items into A–Z index sections keyed by name.
+// Sections come out in # A … Z order and skip empty letters; items keep
+// their input order within a section, so callers sort by name first.
+func GroupByLetter[T any](items []T, name func(T) string) []IndexGroup[T] {
+	byLetter := map[string][]T{}
+	for _, item := range items {
+		letter := IndexLetter(name(item))
+		byLetter[letter] = append(byLetter[letter], item)
+	}
+	var groups []IndexGroup[T]
+	for _, letter := range indexLetters() {
+		if entries, ok := byLetter[letter]; ok {
+			groups = append(groups, IndexGroup[T]{Letter: letter, Items: entries})
+		}
+	}
+	return groups
+}
+
+// IndexJumps builds the letter bar for groups returned by GroupByLetter.
+func IndexJumps[T any](groups []IndexGroup[T]) []IndexJump {
+	present := map[string]bool{}
+	for _, g := range groups {
+		present[g.Letter] = true
+	}
+	letters := indexLetters()
+	jumps := make([]IndexJump, len(letters))
+	for i, letter := range letters {
+		jumps[i] = IndexJump{Letter: letter, HasItems: present[letter]}
+	}
+	return jumps
+}
+
+// indexLetters returns the index headings in display order: # then A–Z.
+func indexLetters() []string {
+	letters := []string{IndexOtherLetter}
+	for r := 'A'; r <= 'Z'; r++ {
+		letters = append(letters, string(r))
+	}
+	return letters
+}
+
+// TagCloudLevels is the number of font sizes in the blog tag cloud.
+const TagCloudLevels = 5
+
+// TagCloudWeight maps a tag's post count onto 1..TagCloudLevels. The scale
+// is logarithmic between the least and most used tags, so one very popular
+// tag does not shrink every other tag to the smallest size. When all tags
+// have the same count every tag gets the middle size.
+func TagCloudWeight(count, minCount, maxCount int64) int {
+	if minCount < 1 {
+		minCount = 1
+	}
+	switch {
+	case maxCount <= minCount:
+		return (TagCloudLevels + 1) / 2
+	case count <= minCount:
+		return 1
+	case count >= maxCount:
+		return TagCloudLevels
+	}
+	ratio := (math.Log(float64(count)) - math.Log(float64(minCount))) /
+		(math.Log(float64(maxCount)) - math.Log(float64(minCount)))
+	return 1 + int(math.Round(ratio*float64(TagCloudLevels-1)))
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestIndexLetter(t *testing.T) {
+	cases := map[string]string{
+		"Alpha":      "A",
+		"zeta":       "Z",
+		"  beacon":   "B",
+		"9-Port Hub": "#",
+		"Émetteur":   "#",
+		"":           "#",
+	}
+	for name, want := range cases {
+		if got := services.IndexLetter(name); got != want {
+			t.Errorf("IndexLetter(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGroupByLetter_OrdersSectionsAndKeepsItemOrder(t *testing.T) {
+	names := []string{"3D Scanner", "alpha", "Apex", "Bridge", "Zulu"}
+	groups := services.GroupByLetter(names, func(s string) string { return s })
+
+	want := []struct {
+		letter string
+		items  []string
+	}{
+		{"#", []string{"3D Scanner"}},
+		{"A", []string{"alpha", "Apex"}},
+		{"B", []string{"Bridge"}},
+		{"Z", []string{"Zulu"}},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(groups), len(want), groups)
+	}
+	for i, w := range want {
+		if groups[i].Letter != w.letter {
+			t.Errorf("group %d letter = %q, want %q", i, groups[i].Letter, w.letter)
+		}
+		if len(groups[i].Items) != len(w.items) {
+			t.Fatalf("group %s items = %v, want %v", w.letter, groups[i].Items, w.items)
+		}
+		for j := range w.items {
+			if groups[i].Items[j] != w.items[j] {
+				t.Errorf("group %s item %d = %q, want %q", w.letter, j, groups[i].Items[j], w.items[j])
+			}
+		}
+	}
+
+	jumps := services.IndexJumps(groups)
+	if len(jumps) != 27 || jumps[0].Letter != "#" || jumps[26].Letter != "Z" {
+		t.Fatalf("unexpected letter bar: %+v", jumps)
+	}
+	if !jumps[1].HasItems || jumps[3].HasItems {
+		t.Errorf("A should link and C should not: %+v", jumps[:4])
+	}
+}
+
+func TestTagCloudWeight(t *testing.T) {
+	if got := services.TagCloudWeight(4, 4, 4); got != 3 {
+		t.Errorf("uniform counts weight = %d, want middle size 3", got)
+	}
+	if got := services.TagCloudWeight(1, 1, 100); got != 1 {
+		t.Errorf("least used weight = %d, want 1", got)
+	}
+	if got := services.TagCloudWeight(100, 1, 100); got != services.TagCloudLevels {
+		t.Errorf("most used weight = %d, want %d", got, services.TagCloudLevels)
+	}
+	// Log scale: 10 is halfway between 1 and 100
+	if got := services.TagCloudWeight(10, 1, 100); got != 3 {
+		t.Errorf("log midpoint weight = %d, want 3", got)
+	}
+}
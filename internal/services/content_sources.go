@@ -0,0 +1,69 @@
+package services
+
+import (
+	// Standard library imports
+	"fmt"     // Edit URLs
+	"net/url" // Escaping media search terms
+)
+
+// contentLabels labels the content types the accessibility report and the
+// link checker report on, by the source names their queries return.
+var contentLabels = map[string]string{
+	"product_image":   "Product image",
+	"hero":            "Homepage hero",
+	"homepage_cta":    "Homepage CTA",
+	"testimonial":     "Testimonial",
+	"media":           "Media library",
+	"blog_post":       "Blog post",
+	"product":         "Product",
+	"solution":        "Solution",
+	"solution_cta":    "Solution CTA",
+	"case_study":      "Case study",
+	"whitepaper":      "Whitepaper",
+	"page_section":    "Page section",
+	"header":          "Header",
+	"content_block":   "Content block",
+	"landing_page":    "Landing page",
+	"landing_section": "Landing page",
+}
+
+// contentEditURL returns the admin page where the item can be fixed.
+// parentID is the landing page of a landing page section; library files are
+// found by searching for their title (the original file name).
+func contentEditURL(source string, id, parentID int64, title string) string {
+	switch source {
+	case "product_image":
+		return fmt.Sprintf("/admin/products/%d/images", id)
+	case "hero":
+		return fmt.Sprintf("/admin/homepage/heroes/%d/edit", id)
+	case "homepage_cta":
+		return fmt.Sprintf("/admin/homepage/cta/%d/edit", id)
+	case "testimonial":
+		return fmt.Sprintf("/admin/homepage/testimonials/%d/edit", id)
+	case "media":
+		return "/admin/media?search=" + url.QueryEscape(title)
+	case "blog_post":
+		return fmt.Sprintf("/admin/blog/posts/%d/edit", id)
+	case "product":
+		return fmt.Sprintf("/admin/products/%d/edit", id)
+	case "solution":
+		return fmt.Sprintf("/admin/solutions/%d/edit", id)
+	case "solution_cta":
+		return fmt.Sprintf("/admin/solutions/%d/ctas-tab", id)
+	case "case_study":
+		return fmt.Sprintf("/admin/case-studies/%d/edit", id)
+	case "whitepaper":
+		return fmt.Sprintf("/admin/whitepapers/%d/edit", id)
+	case "page_section":
+		return fmt.Sprintf("/admin/page-sections/%d/edit", id)
+	case "header":
+		return "/admin/header"
+	case "content_block":
+		return fmt.Sprintf("/admin/blocks/%d/edit", id)
+	case "landing_page":
+		return fmt.Sprintf("/admin/landing-pages/%d/edit", id)
+	case "landing_section":
+		return fmt.Sprintf("/admin/landing-pages/%d/sections/%d/edit", parentID, id)
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	// Standard library imports
+	"context"         // Database calls
+	"encoding/base64" // URL-safe cursor encoding
+	"encoding/json"   // Cursor payload
+	"errors"          // Sentinel error for bad cursors
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Orderings of the product API. Each has its own keyset; the product id
+// breaks ties so the order is strict.
+const (
+	ProductSortID   = "id"   // Oldest first; new products always land on the last page
+	ProductSortName = "name" // Alphabetical (byte order), then id
+)
+
+// Limits on the number of products per API page.
+const (
+	DefaultProductPageSize = 50
+	MaxProductPageSize     = 200
+)
+
+// ErrInvalidCursor is returned for cursors that were not issued by
+// EncodeCursor, or were issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the keyset of the last row of a page: the next page starts
+// strictly after it. Clients receive it as an opaque string and must not
+// build or modify one.
+type Cursor struct {
+	Sort string `json:"s"`           // Ordering the cursor belongs to
+	Name string `json:"n,omitempty"` // Last name, for ProductSortName
+	ID   int64  `json:"i"`           // Last id; the tie-breaker of every ordering
+}
+
+// EncodeCursor returns the opaque form of c, safe to use in a query string.
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c) // A struct of strings and ints always marshals
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor from EncodeCursor and checks it belongs to
+// sort. An empty string is the start of the list.
+//
+// Returns:
+//   - Cursor: The keyset to continue after
+//   - error: ErrInvalidCursor if s is malformed or for another sort
+func DecodeCursor(s, sort string) (Cursor, error) {
+	if s == "" {
+		return Cursor{Sort: sort}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort || c.ID <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// ProductPage is one page of the product API.
+type ProductPage struct {
+	Products   []sqlc.ListPublishedProductsByIDAfterRow // Products in sort order
+	NextCursor string                                   // Cursor of the next page; empty on the last page
+}
+
+// ListProductPage returns the published products after cursor in the given
+// order, optionally limited to one category. One extra row is read to tell
+// whether another page follows, so the last page never returns a cursor
+// that leads to an empty page.
+//
+// Parameters:
+//   - ctx: Request context
+//   - categoryID: Category to list (0 for all)
+//   - after: Cursor from DecodeCursor; its Sort selects the ordering
+//   - limit: Page size, clamped to 1..MaxProductPageSize
+//
+// Returns:
+//   - *ProductPage: The products and the next cursor
+//   - error: ErrInvalidCursor for an unknown sort, or database errors
+func (s *ProductService) ListProductPage(ctx context.Context, categoryID int64, after Cursor, limit int) (*ProductPage, error) {
+	if limit < 1 {
+		limit = DefaultProductPageSize
+	}
+	if limit > MaxProductPageSize {
+		limit = MaxProductPageSize
+	}
+
+	var rows []sqlc.ListPublishedProductsByIDAfterRow
+	switch after.Sort {
+	case ProductSortID:
+		var err error
+		rows, err = s.queries.ListPublishedProductsByIDAfter(ctx, sqlc.ListPublishedProductsByIDAfterParams{
+			CategoryID: categoryID,
+			AfterID:    after.ID,
+			PageLimit:  int64(limit + 1),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case ProductSortName:
+		byName, err := s.queries.ListPublishedProductsByNameAfter(ctx, sqlc.ListPublishedProductsByNameAfterParams{
+			CategoryID: categoryID,
+			AfterName:  after.Name,
+			AfterID:    after.ID,
+			PageLimit:  int64(limit + 1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range byName {
+			rows = append(rows, sqlc.ListPublishedProductsByIDAfterRow(r))
+		}
+	default:
+		return nil, ErrInvalidCursor
+	}
+
+	page := &ProductPage{Products: rows}
+	if len(rows) > limit {
+		page.Products = rows[:limit]
+		last := page.Products[limit-1]
+		next := Cursor{Sort: after.Sort, ID: last.ID}
+		if after.Sort == ProductSortName {
+			next.Name = last.Name
+		}
+		page.NextCursor = EncodeCursor(next)
+	}
+	return page, nil
+}
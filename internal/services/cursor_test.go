@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	c := services.Cursor{Sort: services.ProductSortName, Name: "Sensor", ID: 7}
+	got, err := services.DecodeCursor(services.EncodeCursor(c), services.ProductSortName)
+	if err != nil || got != c {
+		t.Fatalf("round trip = %+v, %v", got, err)
+	}
+	if start, err := services.DecodeCursor("", services.ProductSortID); err != nil || start.ID != 0 {
+		t.Errorf("empty cursor = %+v, %v", start, err)
+	}
+	for _, bad := range []string{"!!", "bm90IGpzb24", services.EncodeCursor(services.Cursor{Sort: "id"})} {
+		if _, err := services.DecodeCursor(bad, services.ProductSortID); err != services.ErrInvalidCursor {
+			t.Errorf("DecodeCursor(%q) error = %v", bad, err)
+		}
+	}
+	if _, err := services.DecodeCursor(services.EncodeCursor(c), services.ProductSortID); err != services.ErrInvalidCursor {
+		t.Error("a cursor must not be accepted for another sort")
+	}
+}
+
+// TestListProductPage_StableAcrossInserts walks the catalog page by page
+// while products are added and removed, and checks no original product is
+// skipped or returned twice.
+func TestListProductPage_StableAcrossInserts(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	n := 0
+	create := func(name string) sqlc.Product {
+		n++
+		p, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("S-%d", n), Slug: fmt.Sprintf("s-%d", n), Name: name, Description: "d",
+			CategoryID: cat.ID, Status: "published",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	// Duplicate names exercise the id tie-breaker
+	names := []string{"Delta", "Alpha", "Echo", "Bravo", "Alpha", "Charlie", "Delta"}
+	for _, name := range names {
+		create(name)
+	}
+	svc := services.NewProductService(queries)
+
+	for _, sort := range []string{services.ProductSortID, services.ProductSortName} {
+		t.Run(sort, func(t *testing.T) {
+			seen := map[int64]bool{}
+			var order []string
+			cursor := ""
+			for pages := 0; ; pages++ {
+				after, err := services.DecodeCursor(cursor, sort)
+				if err != nil {
+					t.Fatal(err)
+				}
+				page, err := svc.ListProductPage(ctx, 0, after, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, p := range page.Products {
+					if seen[p.ID] {
+						t.Fatalf("product %d returned twice", p.ID)
+					}
+					seen[p.ID] = true
+					order = append(order, p.Name)
+				}
+				if pages == 0 {
+					// Rows sorting before the cursor must not shift later pages
+					create("Aardvark")
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				cursor = page.NextCursor
+			}
+			for i := 1; sort == services.ProductSortName && i < len(order); i++ {
+				if order[i] < order[i-1] {
+					t.Errorf("out of order: %q after %q", order[i], order[i-1])
+				}
+			}
+			if len(seen) < len(names) {
+				t.Errorf("saw %d products, want at least %d", len(seen), len(names))
+			}
+		})
+	}
+}
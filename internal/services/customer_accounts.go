@@ -0,0 +1,433 @@
+package services
+
+import (
+	// Standard library imports
+	"context"       // Request cancellation and background email timeouts
+	"crypto/rand"   // Session and reset tokens
+	"crypto/sha256" // Session, reset and confirmation tokens are stored hashed
+	"database/sql"  // sql.ErrNoRows and nullable library IDs
+	"encoding/hex"  // Token encoding
+	"errors"        // Account errors
+	"fmt"           // Password length message and reset email text
+	"log/slog"      // Structured logging of email failures
+	"strings"       // Trimming form values
+	"sync"          // Lazily computed dummy hash
+	"time"          // Session, reset and confirmation lifetimes
+
+	// Third-party imports
+	"golang.org/x/crypto/bcrypt" // Password hashing
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/database" // Password reset and confirmation transactions
+)
+
+const (
+	// CustomerSessionTTL is how long a customer stays signed in on a browser.
+	CustomerSessionTTL = 30 * 24 * time.Hour
+	// CustomerPasswordResetTTL is how long an emailed reset link works.
+	CustomerPasswordResetTTL = time.Hour
+	// CustomerConfirmTTL is how long an emailed sign-up confirmation link
+	// works.
+	CustomerConfirmTTL = 24 * time.Hour
+	// MinCustomerPasswordLength is the shortest password an account accepts.
+	MinCustomerPasswordLength = 8
+	// MaxCustomerPasswordLength is the longest password, in bytes, that
+	// bcrypt can hash.
+	MaxCustomerPasswordLength = 72
+)
+
+// Errors returned by CustomerAccounts. Their messages are shown to visitors.
+var (
+	ErrCustomerDetailsRequired  = errors.New("name, email, company and password are required")
+	ErrCustomerInvalidEmail     = errors.New("enter a valid email address")
+	ErrCustomerPasswordTooShort = fmt.Errorf("passwords must be at least %d characters", MinCustomerPasswordLength)
+	ErrCustomerPasswordTooLong  = fmt.Errorf("passwords can be at most %d characters (fewer with accented or non-Latin letters)", MaxCustomerPasswordLength)
+	ErrCustomerInvalidLogin     = errors.New("incorrect email or password")
+	ErrCustomerResetInvalid     = errors.New("this reset link is invalid or has expired")
+	ErrCustomerConfirmInvalid   = errors.New("this confirmation link is invalid or has expired")
+)
+
+// CustomerRegistration is the sign-up form of a customer account.
+type CustomerRegistration struct {
+	Name             string
+	Email            string
+	Company          string
+	Designation      string // Optional
+	Password         string
+	MarketingConsent bool
+}
+
+// CustomerLibrary is what a customer downloaded while signed in.
+type CustomerLibrary struct {
+	Whitepapers []sqlc.ListCustomerLibraryWhitepapersRow
+	Files       []sqlc.ListCustomerLibraryProductDownloadsRow
+}
+
+// CustomerAccounts manages public visitor accounts: registration with an
+// emailed confirmation link, sign-in sessions, password resets and each
+// customer's library of downloads.
+// Signed-in customers get whitepapers and gated product files without
+// filling in the lead form; their downloads are still recorded as leads.
+type CustomerAccounts struct {
+	db      *sql.DB       // Database handle for the reset and confirmation transactions
+	queries *sqlc.Queries // Database queries
+	logger  *slog.Logger  // Email failures
+	mailer  Mailer        // Confirmation and reset emails; nil disables them
+	baseURL string        // Site URL for links in emails, without trailing slash
+}
+
+// NewCustomerAccounts creates the customer account service.
+//
+// Parameters:
+//   - db: Database connection for the reset and confirmation transactions
+//   - queries: Database queries
+//   - logger: Structured logger
+//   - mailer: Confirmation and reset delivery; nil sends no email, so
+//     nobody can finish signing up
+//   - baseURL: Public site URL used in confirmation and reset links
+func NewCustomerAccounts(db *sql.DB, queries *sqlc.Queries, logger *slog.Logger, mailer Mailer, baseURL string) *CustomerAccounts {
+	return &CustomerAccounts{db: db, queries: queries, logger: logger, mailer: mailer, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Register handles the sign-up form. The account is created only when the
+// emailed confirmation link is followed (see ConfirmRegistration). An email
+// that already has an account is sent a note pointing to sign-in and
+// password reset instead. The result is the same either way, so the form
+// does not reveal which emails have accounts.
+//
+// Returns:
+//   - error: ErrCustomerDetailsRequired, ErrCustomerInvalidEmail,
+//     ErrCustomerPasswordTooShort, ErrCustomerPasswordTooLong or a
+//     database error
+func (a *CustomerAccounts) Register(ctx context.Context, r CustomerRegistration) error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Email = strings.TrimSpace(r.Email)
+	r.Company = strings.TrimSpace(r.Company)
+	r.Designation = strings.TrimSpace(r.Designation)
+	if r.Name == "" || r.Email == "" || r.Company == "" || r.Password == "" {
+		return ErrCustomerDetailsRequired
+	}
+	if !isEmailAddress(r.Email) {
+		return ErrCustomerInvalidEmail
+	}
+	if len(r.Password) < MinCustomerPasswordLength {
+		return ErrCustomerPasswordTooShort
+	}
+	if len(r.Password) > MaxCustomerPasswordLength {
+		return ErrCustomerPasswordTooLong
+	}
+	// Hashed for registered emails too, so both take as long
+	hash, err := bcrypt.GenerateFromPassword([]byte(r.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if err := a.queries.DeleteStaleCustomerRegistrations(ctx, now.Format("2006-01-02 15:04:05")); err != nil {
+		a.logger.ErrorContext(ctx, "failed to prune customer registrations", "error", err)
+	}
+
+	account, err := a.queries.GetCustomerAccountByEmail(ctx, r.Email)
+	if err == nil {
+		a.send(ctx, account.Email, "You already have an account", fmt.Sprintf(
+			"Hello %s,\n\nSomeone tried to create an account with this email address, which already has one. If it was you, sign in at:\n\n%s/account/login\n\nIf you forgot your password, choose a new one at:\n\n%s/account/forgot\n\nIf it was not you, ignore this email. Nothing has changed.",
+			account.Name, a.baseURL, a.baseURL))
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	token, err := newCustomerToken()
+	if err != nil {
+		return err
+	}
+	var consent int64
+	if r.MarketingConsent {
+		consent = 1
+	}
+	err = a.queries.CreateCustomerRegistration(ctx, sqlc.CreateCustomerRegistrationParams{
+		TokenHash:        hashCustomerToken(token),
+		Email:            r.Email,
+		Name:             r.Name,
+		Company:          r.Company,
+		Designation:      r.Designation,
+		PasswordHash:     string(hash),
+		MarketingConsent: consent,
+		ExpiresAt:        now.Add(CustomerConfirmTTL).Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return err
+	}
+	a.send(ctx, r.Email, "Confirm your email address", fmt.Sprintf(
+		"Hello %s,\n\nUse this link to confirm your email address and finish creating your account:\n\n%s/account/confirm?token=%s\n\nThe link works for %d hours. If you did not sign up, ignore this email and no account will be created.",
+		r.Name, a.baseURL, token, int(CustomerConfirmTTL.Hours())))
+	return nil
+}
+
+// ConfirmRegistration creates the account of an emailed confirmation token.
+// The account's other pending sign-ups stop working, in the same
+// transaction.
+//
+// Returns:
+//   - int64: ID of the new account, to sign it in
+//   - error: ErrCustomerConfirmInvalid, also when the email got an account
+//     through another link, or a database error
+func (a *CustomerAccounts) ConfirmRegistration(ctx context.Context, token string) (int64, error) {
+	reg, err := a.queries.GetCustomerRegistration(ctx, sqlc.GetCustomerRegistrationParams{
+		TokenHash: hashCustomerToken(token),
+		Now:       time.Now().UTC().Format("2006-01-02 15:04:05"),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrCustomerConfirmInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+	var account sqlc.CustomerAccount
+	err = database.WithTx(ctx, a.db, func(q *sqlc.Queries) error {
+		var err error
+		account, err = q.CreateCustomerAccount(ctx, sqlc.CreateCustomerAccountParams{
+			Email:            reg.Email,
+			Name:             reg.Name,
+			Company:          reg.Company,
+			Designation:      reg.Designation,
+			PasswordHash:     reg.PasswordHash,
+			MarketingConsent: reg.MarketingConsent,
+		})
+		if err != nil {
+			return err
+		}
+		return q.DeleteCustomerRegistrations(ctx, reg.Email)
+	})
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return 0, ErrCustomerConfirmInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+	return account.ID, nil
+}
+
+// ValidConfirmToken reports whether an emailed confirmation token still
+// works, so the confirmation page can say so before asking to confirm.
+func (a *CustomerAccounts) ValidConfirmToken(ctx context.Context, token string) bool {
+	_, err := a.queries.GetCustomerRegistration(ctx, sqlc.GetCustomerRegistrationParams{
+		TokenHash: hashCustomerToken(token),
+		Now:       time.Now().UTC().Format("2006-01-02 15:04:05"),
+	})
+	return err == nil
+}
+
+// dummyHash is compared against when an unknown email signs in, so the
+// response takes as long as for a known one.
+var dummyHash = sync.OnceValue(func() []byte {
+	h, _ := bcrypt.GenerateFromPassword([]byte("not-a-customer-password"), bcrypt.DefaultCost)
+	return h
+})
+
+// Authenticate checks a sign-in. Unknown emails and wrong passwords give the
+// same error, so the form does not reveal which emails have accounts.
+//
+// Returns:
+//   - sqlc.CustomerAccount: The account signed in to
+//   - error: ErrCustomerInvalidLogin or a database error
+func (a *CustomerAccounts) Authenticate(ctx context.Context, email, password string) (sqlc.CustomerAccount, error) {
+	account, err := a.queries.GetCustomerAccountByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return account, ErrCustomerInvalidLogin
+	}
+	if err != nil {
+		return account, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)) != nil {
+		return account, ErrCustomerInvalidLogin
+	}
+	return account, nil
+}
+
+// StartSession signs an account in and returns the token for its cookie.
+// Only the token's hash is stored. Expired sessions of all accounts are
+// pruned on the way.
+func (a *CustomerAccounts) StartSession(ctx context.Context, accountID int64) (string, error) {
+	token, err := newCustomerToken()
+	if err != nil {
+		return "", err
+	}
+	if _, err := a.queries.CreateCustomerSession(ctx, sqlc.CreateCustomerSessionParams{TokenHash: hashCustomerToken(token), AccountID: accountID}); err != nil {
+		return "", err
+	}
+	if err := a.queries.UpdateCustomerLastLogin(ctx, accountID); err != nil {
+		a.logger.ErrorContext(ctx, "failed to record customer sign-in", "account", accountID, "error", err)
+	}
+	if err := a.queries.DeleteStaleCustomerSessions(ctx, customerSessionCutoff()); err != nil {
+		a.logger.ErrorContext(ctx, "failed to prune customer sessions", "error", err)
+	}
+	return token, nil
+}
+
+// EndSession signs out the session of a cookie token.
+func (a *CustomerAccounts) EndSession(ctx context.Context, token string) error {
+	return a.queries.DeleteCustomerSession(ctx, hashCustomerToken(token))
+}
+
+// CustomerForSession returns the account signed in with a cookie token, or
+// sql.ErrNoRows when the session ended or expired. It implements
+// middleware.CustomerResolver.
+func (a *CustomerAccounts) CustomerForSession(ctx context.Context, token string) (sqlc.CustomerAccount, error) {
+	return a.queries.GetCustomerAccountBySession(ctx, sqlc.GetCustomerAccountBySessionParams{TokenHash: hashCustomerToken(token), Cutoff: customerSessionCutoff()})
+}
+
+// RequestPasswordReset emails a reset link to the account with the email,
+// if there is one. It succeeds either way, so the form does not reveal which
+// emails have accounts.
+func (a *CustomerAccounts) RequestPasswordReset(ctx context.Context, email string) error {
+	account, err := a.queries.GetCustomerAccountByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if a.mailer == nil {
+		a.logger.WarnContext(ctx, "customer password reset requested but email is not configured", "account", account.ID)
+		return nil
+	}
+	token, err := newCustomerToken()
+	if err != nil {
+		return err
+	}
+	err = a.queries.CreateCustomerPasswordReset(ctx, sqlc.CreateCustomerPasswordResetParams{
+		AccountID: account.ID,
+		TokenHash: hashCustomerToken(token),
+		ExpiresAt: time.Now().UTC().Add(CustomerPasswordResetTTL).Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return err
+	}
+
+	a.send(ctx, account.Email, "Reset your password", fmt.Sprintf(
+		"Hello %s,\n\nUse this link to choose a new password for your account:\n\n%s/account/reset?token=%s\n\nThe link works for %d minutes. If you did not ask for it, ignore this email.",
+		account.Name, a.baseURL, token, int(CustomerPasswordResetTTL.Minutes())))
+	return nil
+}
+
+// send emails a customer in the background, so the page does not wait for
+// the mail server. Without a mailer the email is dropped with a warning.
+func (a *CustomerAccounts) send(ctx context.Context, to, subject, body string) {
+	if a.mailer == nil {
+		a.logger.WarnContext(ctx, "customer email not sent: email is not configured", "subject", subject)
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := a.mailer.Send(ctx, []string{to}, subject, body); err != nil {
+			a.logger.Error("failed to send customer email", "subject", subject, "error", err)
+		}
+	}()
+}
+
+// ResetPassword sets a new password with an emailed reset token. All of the
+// account's reset links and sessions stop working, in the same transaction,
+// so a failure cannot leave the old sessions signed in with the new password.
+//
+// Returns:
+//   - int64: ID of the account reset, to sign it in
+//   - error: ErrCustomerResetInvalid, ErrCustomerPasswordTooShort,
+//     ErrCustomerPasswordTooLong or a database error
+func (a *CustomerAccounts) ResetPassword(ctx context.Context, token, password string) (int64, error) {
+	reset, err := a.queries.GetCustomerPasswordReset(ctx, sqlc.GetCustomerPasswordResetParams{
+		TokenHash: hashCustomerToken(token),
+		Now:       time.Now().UTC().Format("2006-01-02 15:04:05"),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrCustomerResetInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(password) < MinCustomerPasswordLength {
+		return 0, ErrCustomerPasswordTooShort
+	}
+	if len(password) > MaxCustomerPasswordLength {
+		return 0, ErrCustomerPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+	err = database.WithTx(ctx, a.db, func(q *sqlc.Queries) error {
+		if err := q.UpdateCustomerPassword(ctx, sqlc.UpdateCustomerPasswordParams{PasswordHash: string(hash), ID: reset.AccountID}); err != nil {
+			return err
+		}
+		if err := q.DeleteCustomerPasswordResets(ctx, reset.AccountID); err != nil {
+			return err
+		}
+		return q.DeleteCustomerSessionsByAccount(ctx, reset.AccountID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return reset.AccountID, nil
+}
+
+// ValidResetToken reports whether an emailed reset token still works, so
+// the reset page can say so before asking for a password.
+func (a *CustomerAccounts) ValidResetToken(ctx context.Context, token string) bool {
+	_, err := a.queries.GetCustomerPasswordReset(ctx, sqlc.GetCustomerPasswordResetParams{
+		TokenHash: hashCustomerToken(token),
+		Now:       time.Now().UTC().Format("2006-01-02 15:04:05"),
+	})
+	return err == nil
+}
+
+// AddWhitepaper records a whitepaper download in a customer's library.
+func (a *CustomerAccounts) AddWhitepaper(ctx context.Context, accountID, whitepaperID int64) error {
+	return a.queries.AddCustomerLibraryWhitepaper(ctx, sqlc.AddCustomerLibraryWhitepaperParams{
+		AccountID:    accountID,
+		WhitepaperID: sql.NullInt64{Int64: whitepaperID, Valid: true},
+	})
+}
+
+// AddProductDownload records a product file download in a customer's library.
+func (a *CustomerAccounts) AddProductDownload(ctx context.Context, accountID, downloadID int64) error {
+	return a.queries.AddCustomerLibraryProductDownload(ctx, sqlc.AddCustomerLibraryProductDownloadParams{
+		AccountID:         accountID,
+		ProductDownloadID: sql.NullInt64{Int64: downloadID, Valid: true},
+	})
+}
+
+// Library returns the published whitepapers and product files a customer
+// downloaded while signed in, most recent first.
+func (a *CustomerAccounts) Library(ctx context.Context, accountID int64) (CustomerLibrary, error) {
+	var lib CustomerLibrary
+	var err error
+	if lib.Whitepapers, err = a.queries.ListCustomerLibraryWhitepapers(ctx, accountID); err != nil {
+		return lib, err
+	}
+	lib.Files, err = a.queries.ListCustomerLibraryProductDownloads(ctx, accountID)
+	return lib, err
+}
+
+// customerSessionCutoff returns the creation time before which sessions expired.
+func customerSessionCutoff() string {
+	return time.Now().UTC().Add(-CustomerSessionTTL).Format("2006-01-02 15:04:05")
+}
+
+// newCustomerToken returns a random 256-bit hex token.
+func newCustomerToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashCustomerToken returns the hex SHA-256 of a session, reset or
+// confirmation token, as stored.
+func hashCustomerToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestCustomerAccounts(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	mailer := &recordingMailer{sent: make(chan sentMail, 2)}
+	accounts := services.NewCustomerAccounts(db, queries, slog.New(slog.NewTextHandler(io.Discard, nil)), mailer, "https://example.com/")
+
+	jane := services.CustomerRegistration{Name: "Jane Doe", Email: "Jane@Acme.com", Company: "Acme", Password: "correct-horse"}
+	for name, tc := range map[string]struct {
+		change func(r *services.CustomerRegistration)
+		want   error
+	}{
+		"no company":     {func(r *services.CustomerRegistration) { r.Company = " " }, services.ErrCustomerDetailsRequired},
+		"bad email":      {func(r *services.CustomerRegistration) { r.Email = "jane" }, services.ErrCustomerInvalidEmail},
+		"short password": {func(r *services.CustomerRegistration) { r.Password = "short" }, services.ErrCustomerPasswordTooShort},
+		"long password":  {func(r *services.CustomerRegistration) { r.Password = strings.Repeat("a", 73) }, services.ErrCustomerPasswordTooLong},
+	} {
+		r := jane
+		tc.change(&r)
+		if err := accounts.Register(ctx, r); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	// Signing up emails a confirmation link; the account is created when it
+	// is followed, and the other links of the email stop working
+	confirmLink := regexp.MustCompile(`https://example\.com/account/confirm\?token=([0-9a-f]+)`)
+	if err := accounts.Register(ctx, jane); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	msg := mailer.next(t)
+	first := confirmLink.FindStringSubmatch(msg.body)
+	if len(msg.to) != 1 || msg.to[0] != "Jane@Acme.com" || first == nil {
+		t.Fatalf("confirmation sent to %v: %s", msg.to, msg.body)
+	}
+	again := jane
+	again.Email = "jane@acme.com"
+	if err := accounts.Register(ctx, again); err != nil {
+		t.Fatalf("Register again before confirming: %v", err)
+	}
+	second := confirmLink.FindStringSubmatch(mailer.next(t).body)
+	if second == nil {
+		t.Fatal("the second sign-up has no confirmation link")
+	}
+	if _, err := accounts.Authenticate(ctx, "jane@acme.com", "correct-horse"); !errors.Is(err, services.ErrCustomerInvalidLogin) {
+		t.Errorf("unconfirmed sign-in: err = %v, want ErrCustomerInvalidLogin", err)
+	}
+	if _, err := accounts.ConfirmRegistration(ctx, "not-a-token"); !errors.Is(err, services.ErrCustomerConfirmInvalid) {
+		t.Errorf("unknown confirmation token: err = %v, want ErrCustomerConfirmInvalid", err)
+	}
+	if !accounts.ValidConfirmToken(ctx, first[1]) {
+		t.Error("the confirmation link does not work")
+	}
+	accountID, err := accounts.ConfirmRegistration(ctx, first[1])
+	if err != nil {
+		t.Fatalf("ConfirmRegistration: %v", err)
+	}
+	if _, err := accounts.ConfirmRegistration(ctx, second[1]); !errors.Is(err, services.ErrCustomerConfirmInvalid) {
+		t.Errorf("second link after confirming: err = %v, want ErrCustomerConfirmInvalid", err)
+	}
+
+	// Signing up with a registered email answers the same and emails the
+	// owner about signing in instead
+	if err := accounts.Register(ctx, again); err != nil {
+		t.Fatalf("Register of a registered email: %v", err)
+	}
+	msg = mailer.next(t)
+	if msg.to[0] != "Jane@Acme.com" || confirmLink.MatchString(msg.body) || !strings.Contains(msg.body, "https://example.com/account/forgot") {
+		t.Errorf("registered email got %v: %s", msg.to, msg.body)
+	}
+
+	// Sign-in ignores the email's case; wrong passwords and unknown emails
+	// give the same error
+	if _, err := accounts.Authenticate(ctx, " jane@ACME.com ", "correct-horse"); err != nil {
+		t.Errorf("Authenticate: %v", err)
+	}
+	for _, login := range [][2]string{{"jane@acme.com", "wrong-password"}, {"nobody@acme.com", "correct-horse"}} {
+		if _, err := accounts.Authenticate(ctx, login[0], login[1]); !errors.Is(err, services.ErrCustomerInvalidLogin) {
+			t.Errorf("Authenticate(%s, %s): err = %v, want ErrCustomerInvalidLogin", login[0], login[1], err)
+		}
+	}
+
+	token, err := accounts.StartSession(ctx, accountID)
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	if got, err := accounts.CustomerForSession(ctx, token); err != nil || got.ID != accountID {
+		t.Errorf("CustomerForSession = %d, %v", got.ID, err)
+	}
+	// Only the token's hash is stored, so the table cannot sign anyone in
+	var stored string
+	db.QueryRowContext(ctx, "SELECT token_hash FROM customer_sessions").Scan(&stored)
+	if stored == "" || stored == token {
+		t.Errorf("stored session = %q, want the token's hash", stored)
+	}
+
+	// Resets are only emailed to registered addresses
+	if err := accounts.RequestPasswordReset(ctx, "nobody@acme.com"); err != nil {
+		t.Fatalf("RequestPasswordReset of an unknown email: %v", err)
+	}
+	if err := accounts.RequestPasswordReset(ctx, "JANE@acme.com"); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	msg = mailer.next(t)
+	if len(msg.to) != 1 || msg.to[0] != "Jane@Acme.com" {
+		t.Errorf("reset sent to %v", msg.to)
+	}
+	m := regexp.MustCompile(`https://example\.com/account/reset\?token=([0-9a-f]+)`).FindStringSubmatch(msg.body)
+	if m == nil {
+		t.Fatalf("reset email has no link: %s", msg.body)
+	}
+	if _, err := accounts.ResetPassword(ctx, "not-a-token", "new-password"); !errors.Is(err, services.ErrCustomerResetInvalid) {
+		t.Errorf("unknown token: err = %v, want ErrCustomerResetInvalid", err)
+	}
+	if _, err := accounts.ResetPassword(ctx, m[1], "short"); !errors.Is(err, services.ErrCustomerPasswordTooShort) {
+		t.Errorf("short password: err = %v, want ErrCustomerPasswordTooShort", err)
+	}
+	if _, err := accounts.ResetPassword(ctx, m[1], strings.Repeat("é", 40)); !errors.Is(err, services.ErrCustomerPasswordTooLong) {
+		t.Errorf("80-byte password: err = %v, want ErrCustomerPasswordTooLong", err)
+	}
+	if id, err := accounts.ResetPassword(ctx, m[1], "new-password"); err != nil || id != accountID {
+		t.Fatalf("ResetPassword = %d, %v", id, err)
+	}
+
+	// The reset signs the account out everywhere and the link works once
+	if _, err := accounts.CustomerForSession(ctx, token); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("session after reset: err = %v, want sql.ErrNoRows", err)
+	}
+	if accounts.ValidResetToken(ctx, m[1]) {
+		t.Error("the reset link still works after use")
+	}
+	if _, err := accounts.Authenticate(ctx, "jane@acme.com", "correct-horse"); !errors.Is(err, services.ErrCustomerInvalidLogin) {
+		t.Error("the old password still works")
+	}
+	if _, err := accounts.Authenticate(ctx, "jane@acme.com", "new-password"); err != nil {
+		t.Errorf("new password: %v", err)
+	}
+}
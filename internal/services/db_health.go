@@ -0,0 +1,253 @@
+package services
+
+import (
+	// Standard library imports for probing, alerting, and state tracking
+	"bytes"         // Alert webhook request body
+	"context"       // Probe timeouts and watchdog cancellation
+	"database/sql"  // Database handle being probed
+	"encoding/json" // Alert webhook payload
+	"log/slog"      // Structured logging of mode changes (operator alerts)
+	"net/http"      // Alert webhook delivery
+	"strings"       // Classifying SQLite error messages
+	"sync"          // Guards the health state shared with request goroutines
+	"time"          // Probe interval and degraded-since timestamps
+)
+
+// DBHealthConfig controls the database health watchdog.
+type DBHealthConfig struct {
+	// Interval between background probes. Defaults to 15 seconds.
+	Interval time.Duration
+	// FailureThreshold is the number of consecutive failed background probes
+	// before the app enters read-only mode. Defaults to 2 so a single slow
+	// write does not flip the site.
+	FailureThreshold int
+	// ProbeTimeout bounds a single probe. Defaults to 10 seconds, which is
+	// longer than the 5 second SQLite busy timeout.
+	ProbeTimeout time.Duration
+	// AlertWebhook, when set, receives a JSON POST on every mode change
+	// ({"event": "db_degraded"|"db_recovered", "error": ..., "time": ...}).
+	AlertWebhook string
+}
+
+// DBHealthStatus is a snapshot of the watchdog state.
+type DBHealthStatus struct {
+	Degraded  bool      `json:"degraded"`             // True while serving cache-only read mode
+	Since     time.Time `json:"since,omitempty"`      // When the current degraded period started
+	LastError string    `json:"last_error,omitempty"` // Most recent probe failure
+}
+
+// DBHealth watches the SQLite database and flips the application into
+// read-only recovery mode when the database is locked or corrupt. While
+// degraded, public pages are served from cached snapshots (see the
+// ReadOnlyFallback middleware) and writes are refused. The watchdog keeps
+// probing and leaves read-only mode automatically once a probe succeeds.
+type DBHealth struct {
+	db     *sql.DB        // Database being probed
+	logger *slog.Logger   // Mode changes are logged at error/info level for operators
+	config DBHealthConfig // Probe interval, threshold, and alert target
+	client *http.Client   // Alert webhook client
+	alerts chan []byte    // Pending webhook payloads, delivered in order by one worker
+
+	mu       sync.RWMutex
+	degraded bool
+	since    time.Time
+	lastErr  string
+	failures int // Consecutive failed background probes
+}
+
+// NewDBHealth creates a DBHealth watchdog for db. Zero config values use the
+// documented defaults.
+//
+// Parameters:
+//   - db: Database handle to probe
+//   - logger: Structured logger for mode-change alerts
+//   - config: Probe interval, failure threshold, and optional alert webhook
+//
+// Returns:
+//   - *DBHealth: Watchdog in the healthy state; call Start to begin probing
+func NewDBHealth(db *sql.DB, logger *slog.Logger, config DBHealthConfig) *DBHealth {
+	if config.Interval <= 0 {
+		config.Interval = 15 * time.Second
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 2
+	}
+	if config.ProbeTimeout <= 0 {
+		config.ProbeTimeout = 10 * time.Second
+	}
+	h := &DBHealth{db: db, logger: logger, config: config, client: &http.Client{Timeout: 5 * time.Second}}
+	if config.AlertWebhook != "" {
+		h.alerts = make(chan []byte, 16)
+		go h.deliverAlerts()
+	}
+	return h
+}
+
+// Start launches the background watchdog, probing every Interval until ctx is
+// cancelled.
+func (h *DBHealth) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(h.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.Check(ctx)
+			}
+		}
+	}()
+}
+
+// probe verifies the database can be read and write-locked. BEGIN IMMEDIATE
+// acquires the write lock (waiting up to the busy timeout), so a database held
+// locked by another process fails here; reading sqlite_master fails for
+// corrupt or non-database files. The transaction is always rolled back.
+func (h *DBHealth) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.config.ProbeTimeout)
+	defer cancel()
+
+	conn, err := h.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "ROLLBACK")
+
+	var n int
+	return conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master").Scan(&n)
+}
+
+// Check runs one background probe and updates the state: FailureThreshold
+// consecutive failures enter read-only mode and any success leaves it.
+//
+// Returns:
+//   - bool: True if the probe succeeded
+func (h *DBHealth) Check(ctx context.Context) bool {
+	err := h.probe(ctx)
+
+	h.mu.Lock()
+	if err == nil {
+		h.failures = 0
+		h.mu.Unlock()
+		h.setDegraded(false, nil)
+		return true
+	}
+	h.failures++
+	failures := h.failures
+	h.mu.Unlock()
+
+	h.logger.Warn("database health probe failed", "error", err, "consecutive", failures)
+	if failures >= h.config.FailureThreshold {
+		h.setDegraded(true, err)
+	}
+	return false
+}
+
+// Verify probes the database on demand after a request failed. Because the
+// failing request is already evidence of a problem, a failed probe enters
+// read-only mode immediately instead of waiting for FailureThreshold.
+//
+// Returns:
+//   - bool: True if the database is healthy
+func (h *DBHealth) Verify(ctx context.Context) bool {
+	if err := h.probe(ctx); err != nil {
+		h.setDegraded(true, err)
+		return false
+	}
+	return true
+}
+
+// Degraded reports whether the application is in read-only recovery mode.
+func (h *DBHealth) Degraded() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.degraded
+}
+
+// Status returns a snapshot of the watchdog state for health endpoints.
+func (h *DBHealth) Status() DBHealthStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return DBHealthStatus{Degraded: h.degraded, Since: h.since, LastError: h.lastErr}
+}
+
+// setDegraded records a mode change and alerts operators when the mode flips.
+func (h *DBHealth) setDegraded(degraded bool, cause error) {
+	h.mu.Lock()
+	if h.degraded == degraded {
+		if cause != nil {
+			h.lastErr = cause.Error()
+		}
+		h.mu.Unlock()
+		return
+	}
+	h.degraded = degraded
+	since := h.since
+	if degraded {
+		h.since = time.Now()
+		h.lastErr = cause.Error()
+	} else {
+		h.since = time.Time{}
+		h.lastErr = ""
+		h.failures = 0
+	}
+	h.mu.Unlock()
+
+	if degraded {
+		h.logger.Error("database unavailable, entering read-only recovery mode", "error", cause, "kind", classifyDBError(cause))
+		h.alert("db_degraded", cause.Error())
+	} else {
+		h.logger.Info("database healthy again, leaving read-only recovery mode", "degraded_for", time.Since(since).Round(time.Second).String())
+		h.alert("db_recovered", "")
+	}
+}
+
+// alert queues a mode change for the configured webhook without blocking the
+// caller, which may be serving a request.
+func (h *DBHealth) alert(event, detail string) {
+	if h.alerts == nil {
+		return
+	}
+	body, _ := json.Marshal(map[string]string{
+		"event": event,
+		"error": detail,
+		"time":  time.Now().UTC().Format(time.RFC3339),
+	})
+	select {
+	case h.alerts <- body:
+	default:
+		h.logger.Error("database alert queue full, dropping alert", "event", event)
+	}
+}
+
+// deliverAlerts posts queued alerts in order. Delivery failures are logged;
+// they never affect the mode change itself.
+func (h *DBHealth) deliverAlerts() {
+	for body := range h.alerts {
+		resp, err := h.client.Post(h.config.AlertWebhook, "application/json", bytes.NewReader(body))
+		if err != nil {
+			h.logger.Error("failed to deliver database alert", "error", err)
+			continue
+		}
+		resp.Body.Close()
+	}
+}
+
+// classifyDBError labels a probe failure for logs: "locked", "corrupt", or
+// "unavailable" for anything else (I/O errors, missing file, timeouts).
+func classifyDBError(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "locked") || strings.Contains(msg, "busy"):
+		return "locked"
+	case strings.Contains(msg, "malformed") || strings.Contains(msg, "not a database") || strings.Contains(msg, "corrupt"):
+		return "corrupt"
+	}
+	return "unavailable"
+}
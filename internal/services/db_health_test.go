@@ -0,0 +1,97 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestDBHealth_LockedDatabaseDegradesAndRecovers(t *testing.T) {
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	events := make(chan string, 4)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		events <- body["event"]
+	}))
+	defer hook.Close()
+
+	health := services.NewDBHealth(db, slog.New(slog.NewTextHandler(io.Discard, nil)), services.DBHealthConfig{
+		FailureThreshold: 2,
+		ProbeTimeout:     time.Second,
+		AlertWebhook:     hook.URL,
+	})
+	if !health.Check(ctx) || health.Degraded() {
+		t.Fatal("expected a fresh database to be healthy")
+	}
+
+	// Hold the write lock from a second connection, as an external process would
+	var path string
+	db.QueryRow("SELECT file FROM pragma_database_list WHERE name = 'main'").Scan(&path)
+	other, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open second connection: %v", err)
+	}
+	defer other.Close()
+	lock, err := other.Conn(ctx)
+	if err != nil {
+		t.Fatalf("conn: %v", err)
+	}
+	if _, err := lock.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+
+	// One failed background probe is tolerated; the second trips read-only mode
+	if health.Check(ctx) || health.Degraded() {
+		t.Fatal("expected a single failed probe not to degrade")
+	}
+	if health.Check(ctx) || !health.Degraded() {
+		t.Fatal("expected read-only mode after consecutive failures")
+	}
+	if st := health.Status(); st.LastError == "" || st.Since.IsZero() {
+		t.Errorf("expected status to record the failure, got %+v", st)
+	}
+
+	lock.ExecContext(ctx, "ROLLBACK")
+	lock.Close()
+	if !health.Check(ctx) || health.Degraded() {
+		t.Fatal("expected automatic recovery once the lock is released")
+	}
+
+	for _, want := range []string{"db_degraded", "db_recovered"} {
+		select {
+		case got := <-events:
+			if got != want {
+				t.Errorf("expected alert %q, got %q", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("alert %q not delivered", want)
+		}
+	}
+}
+
+func TestDBHealth_VerifyDegradesImmediately(t *testing.T) {
+	db, _, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+
+	health := services.NewDBHealth(db, slog.New(slog.NewTextHandler(io.Discard, nil)), services.DBHealthConfig{FailureThreshold: 5})
+	if !health.Verify(context.Background()) {
+		t.Fatal("expected healthy database")
+	}
+	db.Close()
+	if health.Verify(context.Background()) || !health.Degraded() {
+		t.Error("expected a failed on-demand probe to enter read-only mode")
+	}
+}
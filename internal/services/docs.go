@@ -0,0 +1,368 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Request cancellation for slug checks and version copies
+	"database/sql" // Nullable parents and sql.ErrNoRows
+	"errors"       // Parent and version validation errors
+	"fmt"          // Numbered slug suffixes
+	"regexp"       // Version label validation
+	"strings"      // Indentation for nested select options
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/database" // Version copy transaction
+)
+
+// MaxDocDepth is the deepest a documentation page may be nested, e.g.
+// chapter (1) → section (2) → topic (3) → subtopic (4).
+const MaxDocDepth = 4
+
+// Page and version validation errors.
+var (
+	ErrDocPageCycle    = errors.New("docs: a page cannot be placed under itself or one of its subpages")
+	ErrDocPageTooDeep  = errors.New("docs: nesting would exceed the maximum page depth")
+	ErrDocPageNotFound = errors.New("docs: parent page does not exist in this version")
+	ErrDocVersionLabel = errors.New("docs: version labels are letters, digits, dots, hyphens and underscores")
+)
+
+// docVersionPattern matches version labels, which are also URL segments:
+// "2.1", "v3", "2024-10", "beta_1".
+var docVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,31}$`)
+
+// ValidDocVersion reports whether label can name a doc set version.
+func ValidDocVersion(label string) bool {
+	return docVersionPattern.MatchString(label)
+}
+
+// DocSetPath returns /docs/<set>, which redirects to the current version.
+func DocSetPath(setSlug string) string {
+	return "/docs/" + setSlug
+}
+
+// DocVersionPath returns the contents page of a doc set version.
+func DocVersionPath(setSlug, version string) string {
+	return "/docs/" + setSlug + "/" + version
+}
+
+// DocPagePath returns the public page of a documentation page.
+func DocPagePath(setSlug, version, pageSlug string) string {
+	return DocVersionPath(setSlug, version) + "/" + pageSlug
+}
+
+// DocNode is one documentation page with its place in the page tree.
+type DocNode struct {
+	Page     sqlc.DocPage // The page row
+	URL      string       // Public path of the page
+	Parent   *DocNode     // Parent page, nil for top-level pages
+	Children []*DocNode   // Direct subpages in display order
+	Depth    int          // 1 for top-level pages
+}
+
+// IsDescendantOf reports whether n is ancestor itself or sits below it.
+func (n *DocNode) IsDescendantOf(ancestor *DocNode) bool {
+	for cur := n; cur != nil; cur = cur.Parent {
+		if cur == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Height returns the number of levels in the subtree rooted at n (1 for a
+// page without subpages).
+func (n *DocNode) Height() int {
+	h := 0
+	for _, child := range n.Children {
+		if ch := child.Height(); ch > h {
+			h = ch
+		}
+	}
+	return h + 1
+}
+
+// Breadcrumbs returns links for every page from the top-level page down to
+// n.
+func (n *DocNode) Breadcrumbs() []Breadcrumb {
+	var crumbs []Breadcrumb
+	for cur := n; cur != nil; cur = cur.Parent {
+		crumbs = append([]Breadcrumb{{Name: cur.Page.Title, URL: cur.URL}}, crumbs...)
+	}
+	return crumbs
+}
+
+// DocTree indexes the pages of one doc set version by ID and slug and links
+// them into a hierarchy. Build it with BuildDocTree.
+type DocTree struct {
+	Roots  []*DocNode          // Top-level pages in display order
+	Pages  []*DocNode          // Every page in reading order, parents before their subpages
+	byID   map[int64]*DocNode  // Lookup by page ID
+	bySlug map[string]*DocNode // Lookup by slug
+}
+
+// BuildDocTree links the pages of a version into a tree. Input order
+// (ListDocPages' sort_order, title) is kept among siblings. Pages whose
+// parent is missing, or which sit in a parent cycle left by direct database
+// edits, are treated as top-level pages.
+//
+// Parameters:
+//   - pages: All pages of the version
+//   - basePath: DocVersionPath of the version, prefixed to page URLs
+//   - publishedOnly: Leave out unpublished pages and everything below them,
+//     as the public site does
+//
+// Returns:
+//   - *DocTree: Linked hierarchy
+func BuildDocTree(pages []sqlc.DocPage, basePath string, publishedOnly bool) *DocTree {
+	all := make(map[int64]*DocNode, len(pages))
+	nodes := make([]*DocNode, 0, len(pages))
+	for _, page := range pages {
+		node := &DocNode{Page: page, URL: basePath + "/" + page.Slug}
+		all[page.ID] = node
+		nodes = append(nodes, node)
+	}
+	for _, node := range nodes {
+		if node.Page.ParentID.Valid {
+			if parent, ok := all[node.Page.ParentID.Int64]; ok && !parent.IsDescendantOf(node) {
+				node.Parent = parent
+			}
+		}
+	}
+
+	t := &DocTree{byID: map[int64]*DocNode{}, bySlug: map[string]*DocNode{}}
+	for _, node := range nodes {
+		if node.Parent == nil {
+			t.Roots = append(t.Roots, node)
+		} else {
+			node.Parent.Children = append(node.Parent.Children, node)
+		}
+	}
+
+	var walk func(nodes []*DocNode, depth int) []*DocNode
+	walk = func(nodes []*DocNode, depth int) []*DocNode {
+		var kept []*DocNode
+		for _, n := range nodes {
+			if publishedOnly && n.Page.IsPublished != 1 {
+				continue
+			}
+			n.Depth = depth
+			t.byID[n.Page.ID] = n
+			t.bySlug[n.Page.Slug] = n
+			t.Pages = append(t.Pages, n)
+			n.Children = walk(n.Children, depth+1)
+			kept = append(kept, n)
+		}
+		return kept
+	}
+	t.Roots = walk(t.Roots, 1)
+	return t
+}
+
+// ByID returns the page with the given ID, or nil.
+func (t *DocTree) ByID(id int64) *DocNode {
+	return t.byID[id]
+}
+
+// BySlug returns the page with the given slug, or nil.
+func (t *DocTree) BySlug(slug string) *DocNode {
+	return t.bySlug[slug]
+}
+
+// Prev returns the page before n in reading order, or nil for the first.
+func (t *DocTree) Prev(n *DocNode) *DocNode {
+	for i, p := range t.Pages {
+		if p == n && i > 0 {
+			return t.Pages[i-1]
+		}
+	}
+	return nil
+}
+
+// Next returns the page after n in reading order, or nil for the last.
+func (t *DocTree) Next(n *DocNode) *DocNode {
+	for i, p := range t.Pages {
+		if p == n && i+1 < len(t.Pages) {
+			return t.Pages[i+1]
+		}
+	}
+	return nil
+}
+
+// DocPageOption is one entry of the parent page select box.
+type DocPageOption struct {
+	ID    int64  // Page ID
+	Label string // Title indented by depth, e.g. "— — Wiring"
+}
+
+// Options returns parent select options for every page in reading order.
+// The subtree of exclude (and exclude itself) is left out, as are pages at
+// MaxDocDepth, which cannot take subpages.
+func (t *DocTree) Options(exclude int64) []DocPageOption {
+	skip := t.byID[exclude]
+	var opts []DocPageOption
+	for _, n := range t.Pages {
+		if n.Depth >= MaxDocDepth || (skip != nil && n.IsDescendantOf(skip)) {
+			continue
+		}
+		opts = append(opts, DocPageOption{ID: n.Page.ID, Label: strings.Repeat("— ", n.Depth-1) + n.Page.Title})
+	}
+	return opts
+}
+
+// ValidateParent checks that page id may be moved under parentID without
+// creating a cycle or nesting its subtree deeper than MaxDocDepth.
+//
+// Parameters:
+//   - id: Page being saved (0 for a new page)
+//   - parentID: Proposed parent (0 for a top-level page)
+//
+// Returns:
+//   - error: ErrDocPageNotFound, ErrDocPageCycle, ErrDocPageTooDeep, or nil
+func (t *DocTree) ValidateParent(id, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	parent := t.byID[parentID]
+	if parent == nil {
+		return ErrDocPageNotFound
+	}
+	height := 1
+	if node := t.byID[id]; node != nil {
+		if parent.IsDescendantOf(node) {
+			return ErrDocPageCycle
+		}
+		height = node.Height()
+	}
+	if parent.Depth+height > MaxDocDepth {
+		return ErrDocPageTooDeep
+	}
+	return nil
+}
+
+// Docs manages doc set versions and page slugs. Sets and pages are
+// otherwise saved by the admin handlers directly.
+type Docs struct {
+	db      *sql.DB       // Connection that opens the version transaction
+	queries *sqlc.Queries // Queries bound to the transaction with WithTx
+}
+
+// NewDocs creates the docs service.
+//
+// Parameters:
+//   - db: Database connection, used to start the version transaction
+//   - queries: Database query interface from sqlc
+func NewDocs(db *sql.DB, queries *sqlc.Queries) *Docs {
+	return &Docs{db: db, queries: queries}
+}
+
+// UniquePageSlug returns Slugify(text), suffixed "-2", "-3", ... when
+// another page of the version already uses it.
+//
+// Parameters:
+//   - versionID: Version of the page
+//   - text: Submitted slug, or the title it is generated from
+//   - excludeID: Page being updated, whose own slug is free; 0 when creating
+func (d *Docs) UniquePageSlug(ctx context.Context, versionID int64, text string, excludeID int64) (string, error) {
+	base := Slugify(text)
+	if base == "" {
+		base = fallbackSlug
+	}
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+		taken, err := d.queries.DocPageSlugTaken(ctx, sqlc.DocPageSlugTakenParams{DocVersionID: versionID, Slug: candidate, ExcludeID: excludeID})
+		if err != nil {
+			return "", err
+		}
+		if taken == 0 {
+			return candidate, nil
+		}
+	}
+	return "", errNoFreeSlug
+}
+
+// AddVersion adds a version to a doc set in one transaction. It starts as
+// a copy of the pages of copyFrom, so the next release's manual is written
+// by editing the last one. The first version of a set becomes its current
+// version.
+//
+// Parameters:
+//   - set: Doc set to add the version to
+//   - label: Version label, e.g. "2.1"
+//   - copyFrom: Version of the same set whose pages are copied, or 0 to
+//     start empty
+//
+// Returns:
+//   - sqlc.DocVersion: The new version
+//   - error: ErrDocVersionLabel, sql.ErrNoRows when copyFrom is not a
+//     version of set, or a database error (a taken label fails the UNIQUE
+//     constraint); nothing is written on error
+func (d *Docs) AddVersion(ctx context.Context, set sqlc.DocSet, label string, copyFrom int64) (sqlc.DocVersion, error) {
+	if !ValidDocVersion(label) {
+		return sqlc.DocVersion{}, ErrDocVersionLabel
+	}
+	var version sqlc.DocVersion
+	err := database.WithTx(ctx, d.db, func(q *sqlc.Queries) error {
+		var pages []sqlc.DocPage
+		if copyFrom != 0 {
+			src, err := q.GetDocVersion(ctx, copyFrom)
+			if err != nil {
+				return err
+			}
+			if src.DocSetID != set.ID {
+				return sql.ErrNoRows
+			}
+			if pages, err = q.ListDocPages(ctx, src.ID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		version, err = q.CreateDocVersion(ctx, sqlc.CreateDocVersionParams{DocSetID: set.ID, Version: label})
+		if err != nil {
+			return err
+		}
+		// Reading order puts every parent before its subpages, so the new ID of
+		// a parent is known when its subpages are copied
+		newIDs := map[int64]int64{}
+		for _, n := range BuildDocTree(pages, "", false).Pages {
+			parent := sql.NullInt64{}
+			if n.Parent != nil {
+				parent = sql.NullInt64{Int64: newIDs[n.Parent.Page.ID], Valid: true}
+			}
+			p := n.Page
+			created, err := q.CreateDocPage(ctx, sqlc.CreateDocPageParams{
+				DocVersionID:    version.ID,
+				ParentID:        parent,
+				Title:           p.Title,
+				Slug:            p.Slug,
+				BodyMarkdown:    p.BodyMarkdown,
+				BodyHtml:        p.BodyHtml,
+				MetaDescription: p.MetaDescription,
+				SortOrder:       p.SortOrder,
+				IsPublished:     p.IsPublished,
+			})
+			if err != nil {
+				return err
+			}
+			newIDs[p.ID] = created.ID
+		}
+
+		if !set.CurrentVersionID.Valid {
+			err := q.SetDocSetCurrentVersion(ctx, sqlc.SetDocSetCurrentVersionParams{
+				CurrentVersionID: sql.NullInt64{Int64: version.ID, Valid: true},
+				ID:               set.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.DocVersion{}, err
+	}
+	return version, nil
+}
@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func docPage(id int64, slug string, parent int64, published bool) sqlc.DocPage {
+	p := sqlc.DocPage{ID: id, Title: slug, Slug: slug, ParentID: sql.NullInt64{Int64: parent, Valid: parent != 0}}
+	if published {
+		p.IsPublished = 1
+	}
+	return p
+}
+
+// docTree: intro (1); setup (2) → wiring (3) → pinout (4); setup → firmware (5, draft) → flashing (6).
+func docTree(publishedOnly bool) *services.DocTree {
+	return services.BuildDocTree([]sqlc.DocPage{
+		docPage(1, "intro", 0, true),
+		docPage(2, "setup", 0, true),
+		docPage(3, "wiring", 2, true),
+		docPage(4, "pinout", 3, true),
+		docPage(5, "firmware", 2, false),
+		docPage(6, "flashing", 5, true),
+	}, "/docs/sensor/2.0", publishedOnly)
+}
+
+func TestBuildDocTree(t *testing.T) {
+	tree := docTree(false)
+	var order []string
+	for _, n := range tree.Pages {
+		order = append(order, n.Page.Slug)
+	}
+	if got := fmt.Sprint(order); got != "[intro setup wiring pinout firmware flashing]" {
+		t.Errorf("reading order = %s", got)
+	}
+	if n := tree.BySlug("pinout"); n.Depth != 3 || n.URL != "/docs/sensor/2.0/pinout" || len(n.Breadcrumbs()) != 3 {
+		t.Errorf("pinout = depth %d, %s, %v", n.Depth, n.URL, n.Breadcrumbs())
+	}
+	if prev, next := tree.Prev(tree.BySlug("intro")), tree.Next(tree.BySlug("intro")); prev != nil || next.Page.Slug != "setup" {
+		t.Errorf("intro: prev %v, next %v", prev, next)
+	}
+
+	// The public tree drops drafts with everything below them
+	public := docTree(true)
+	if public.BySlug("firmware") != nil || public.BySlug("flashing") != nil {
+		t.Error("draft page or its subpage in the published tree")
+	}
+	if next := public.Next(public.BySlug("pinout")); next != nil {
+		t.Errorf("next after pinout = %s, want none", next.Page.Slug)
+	}
+	if len(public.ByID(2).Children) != 1 {
+		t.Errorf("setup has %d published subpages, want 1", len(public.ByID(2).Children))
+	}
+}
+
+func TestDocTree_ValidateParent(t *testing.T) {
+	tree := docTree(false)
+	for name, tc := range map[string]struct {
+		id, parent int64
+		want       error
+	}{
+		"top level":        {3, 0, nil},
+		"new under pinout": {0, 4, nil},
+		"under itself":     {2, 2, services.ErrDocPageCycle},
+		"under subpage":    {2, 4, services.ErrDocPageCycle},
+		"too deep":         {5, 4, services.ErrDocPageTooDeep},
+		"missing parent":   {3, 99, services.ErrDocPageNotFound},
+	} {
+		if err := tree.ValidateParent(tc.id, tc.parent); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+	for _, opt := range tree.Options(2) {
+		if opt.ID != 1 {
+			t.Errorf("option %+v offered as parent of setup", opt)
+		}
+	}
+}
+
+func TestDocs_AddVersion(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	docs := services.NewDocs(db, queries)
+
+	set, err := queries.CreateDocSet(ctx, sqlc.CreateDocSetParams{Name: "Sensor Manual", Slug: "sensor", IsPublished: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := docs.AddVersion(ctx, set, "2.0 beta", 0); !errors.Is(err, services.ErrDocVersionLabel) {
+		t.Errorf("label with a space: err = %v", err)
+	}
+	v1, err := docs.AddVersion(ctx, set, "1.0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set, _ = queries.GetDocSet(ctx, set.ID); set.CurrentVersionID.Int64 != v1.ID {
+		t.Errorf("current version = %v, want the first version", set.CurrentVersionID)
+	}
+
+	setup, _ := queries.CreateDocPage(ctx, sqlc.CreateDocPageParams{DocVersionID: v1.ID, Title: "Setup", Slug: "setup", IsPublished: 1})
+	queries.CreateDocPage(ctx, sqlc.CreateDocPageParams{DocVersionID: v1.ID, ParentID: sql.NullInt64{Int64: setup.ID, Valid: true}, Title: "Wiring", Slug: "wiring", BodyMarkdown: "Connect **VCC**", IsPublished: 1})
+	if slug, _ := docs.UniquePageSlug(ctx, v1.ID, "Setup", 0); slug != "setup-2" {
+		t.Errorf("slug for a second Setup page = %q", slug)
+	}
+
+	v2, err := docs.AddVersion(ctx, set, "2.0", v1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, _ := queries.ListDocPages(ctx, v2.ID)
+	tree := services.BuildDocTree(pages, "", false)
+	wiring := tree.BySlug("wiring")
+	if len(pages) != 2 || wiring == nil || wiring.Parent == nil || wiring.Parent.Page.Slug != "setup" || wiring.Page.BodyMarkdown != "Connect **VCC**" {
+		t.Fatalf("copied pages = %+v", pages)
+	}
+	if wiring.Parent.Page.ID == setup.ID {
+		t.Error("copied page still under the page of the old version")
+	}
+	if set, _ = queries.GetDocSet(ctx, set.ID); set.CurrentVersionID.Int64 != v1.ID {
+		t.Error("adding a version changed the current version")
+	}
+	if slug, _ := docs.UniquePageSlug(ctx, v2.ID, "Intro", 0); slug != "intro" {
+		t.Errorf("slug in the new version = %q", slug)
+	}
+
+	if _, err := docs.AddVersion(ctx, set, "1.0", 0); err == nil {
+		t.Error("added a version with a taken label")
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request cancellation for queries and file copies
+	"errors"  // Joining startup failures and matching ErrObjectExists
+	"fmt"     // Error wrapping
+	"io"      // Streaming files to the download route
+	"os"      // Matching missing files
+	"strings" // Mapping file paths to storage keys
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// DownloadFiles keeps product download files where their gating allows. A
+// file that only gated downloads use lives in GATED_DOWNLOAD_DIR, outside
+// the public uploads, and is streamed by the download route once its signed
+// link checks out; a plain /uploads URL for it is not found. Files that an
+// ungated download uses (product copies share files) stay in UPLOAD_DIR.
+//
+// File paths keep their /uploads/downloads/... form in both places, so
+// gating or ungating a download only moves its file.
+type DownloadFiles struct {
+	queries *sqlc.Queries // Which downloads use a file
+	public  *LocalStorage // UPLOAD_DIR, served at /uploads
+	gated   *LocalStorage // GATED_DOWNLOAD_DIR, never served directly
+}
+
+// NewDownloadFiles creates the product download file store.
+//
+// Parameters:
+//   - queries: Database queries
+//   - uploadDir: Public upload directory (UPLOAD_DIR)
+//   - gatedDir: Private directory for gated files (GATED_DOWNLOAD_DIR)
+func NewDownloadFiles(queries *sqlc.Queries, uploadDir, gatedDir string) *DownloadFiles {
+	return &DownloadFiles{queries: queries, public: NewLocalStorage(uploadDir), gated: NewLocalStorage(gatedDir)}
+}
+
+// downloadFileKey returns the storage key of an uploaded file path, e.g.
+// "downloads/123_manual.pdf" for "/uploads/downloads/123_manual.pdf".
+func downloadFileKey(filePath string) (string, bool) {
+	return strings.CutPrefix(filePath, "/uploads/")
+}
+
+// Sync moves a file to where the downloads using it allow: into the gated
+// directory while any gated download uses it, and out of the public uploads
+// unless an ungated one does. Call it after a download is added, changed or
+// deleted. Files outside /uploads are left alone.
+func (f *DownloadFiles) Sync(ctx context.Context, filePath string) error {
+	key, ok := downloadFileKey(filePath)
+	if !ok {
+		return nil
+	}
+	use, err := f.queries.GetProductDownloadFileUse(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	if use.Gated > 0 {
+		if err := copyObject(ctx, f.public, f.gated, key); err != nil {
+			return fmt.Errorf("protect %s: %w", filePath, err)
+		}
+	}
+	if use.Ungated > 0 {
+		if err := copyObject(ctx, f.gated, f.public, key); err != nil {
+			return fmt.Errorf("publish %s: %w", filePath, err)
+		}
+	}
+	switch {
+	case use.Gated > 0 && use.Ungated == 0:
+		return f.public.Delete(ctx, key)
+	case use.Ungated > 0 && use.Gated == 0:
+		return f.gated.Delete(ctx, key)
+	}
+	return nil
+}
+
+// SyncAll syncs the file of every gated download, moving gated files that
+// are still in the public uploads out of them. It runs at startup.
+func (f *DownloadFiles) SyncAll(ctx context.Context) error {
+	paths, err := f.queries.ListGatedProductDownloadFiles(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, p := range paths {
+		errs = append(errs, f.Sync(ctx, p))
+	}
+	return errors.Join(errs...)
+}
+
+// Open returns the file of a gated download. Callers must close it.
+func (f *DownloadFiles) Open(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	key, ok := downloadFileKey(filePath)
+	if !ok {
+		return nil, fmt.Errorf("download file %q is not an upload", filePath)
+	}
+	return f.gated.Open(ctx, key)
+}
+
+// copyObject copies the object under key from one storage to another,
+// unless the destination already has it. A file missing from both is left
+// for the download route to report.
+func copyObject(ctx context.Context, from, to *LocalStorage, key string) error {
+	if existing, err := to.Open(ctx, key); err == nil {
+		return existing.Close()
+	}
+	src, err := from.Open(ctx, key)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if err := to.Put(ctx, key, src); err != nil && !errors.Is(err, ErrObjectExists) {
+		return err
+	}
+	return nil
+}
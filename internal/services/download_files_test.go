@@ -0,0 +1,77 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestDownloadFiles_Sync(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	uploadDir, gatedDir := t.TempDir(), t.TempDir()
+	files := services.NewDownloadFiles(queries, uploadDir, gatedDir)
+
+	public := filepath.Join(uploadDir, "downloads", "1_manual.pdf")
+	gated := filepath.Join(gatedDir, "downloads", "1_manual.pdf")
+	os.MkdirAll(filepath.Dir(public), 0o755)
+	if err := os.WriteFile(public, []byte("pdf"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cat", Slug: "cat", Description: "d", Icon: "i"})
+	prod, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "A-1", Slug: "a-1", Name: "Sensor", Description: "d", CategoryID: cat.ID, Status: "published"})
+	add := func(isGated int64) sqlc.ProductDownload {
+		d, err := queries.CreateProductDownload(ctx, sqlc.CreateProductDownloadParams{
+			ProductID: prod.ID, Title: "Manual", FileType: "pdf", FilePath: "/uploads/downloads/1_manual.pdf", IsGated: isGated,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	// A product copy's ungated download keeps the shared file public
+	add(1)
+	open := add(0)
+	if err := files.SyncAll(ctx); err != nil {
+		t.Fatalf("SyncAll: %v", err)
+	}
+	if !exists(public) || !exists(gated) {
+		t.Errorf("shared file: public %v, gated %v, want both", exists(public), exists(gated))
+	}
+
+	// Once only gated downloads use it, it leaves the public uploads
+	queries.DeleteProductDownload(ctx, open.ID)
+	if err := files.Sync(ctx, open.FilePath); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if exists(public) || !exists(gated) {
+		t.Errorf("gated file: public %v, gated %v, want only gated", exists(public), exists(gated))
+	}
+	f, err := files.Open(ctx, open.FilePath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	body, _ := io.ReadAll(f)
+	f.Close()
+	if string(body) != "pdf" {
+		t.Errorf("Open = %q", body)
+	}
+
+	// Files outside the uploads are left alone
+	if err := files.Sync(ctx, "https://cdn.example.com/manual.pdf"); err != nil {
+		t.Errorf("Sync of an external file: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	// Standard library imports
+	"time" // Link lifetime
+)
+
+// TokenProductDownload is the token type of gated download links. Tokens are
+// bound to their type, so a download link cannot open a draft preview and a
+// preview link cannot unlock a download.
+const TokenProductDownload = "product_download"
+
+// DefaultDownloadLinkTTL is how long a gated download link stays valid after
+// the lead form: long enough to retry a failed download, short enough that
+// a forwarded link stops working. The files themselves are not in the public
+// uploads (see DownloadFiles), so the link is the only way to them.
+const DefaultDownloadLinkTTL = 24 * time.Hour
+
+// DownloadLinks issues and verifies the signed, expiring tokens that unlock
+// gated product downloads. A visitor gets a token after submitting the lead
+// form; the token unlocks exactly one download and needs no session. The
+// tokens are PreviewTokens of type TokenProductDownload.
+type DownloadLinks struct {
+	tokens *PreviewTokens // Signs and checks the tokens
+}
+
+// NewDownloadLinks creates a link issuer signing with a key derived from
+// secret.
+//
+// Parameters:
+//   - secret: Application secret
+//   - ttl: Lifetime of issued tokens (DefaultDownloadLinkTTL when zero or negative)
+//
+// Returns:
+//   - *DownloadLinks: Initialized issuer
+func NewDownloadLinks(secret string, ttl time.Duration) *DownloadLinks {
+	if ttl <= 0 {
+		ttl = DefaultDownloadLinkTTL
+	}
+	return &DownloadLinks{tokens: NewPreviewTokens(secret, ttl)}
+}
+
+// Issue returns a token unlocking the product download id.
+func (d *DownloadLinks) Issue(id int64) string {
+	return d.tokens.Issue(TokenProductDownload, id)
+}
+
+// TTL returns how long issued links stay valid.
+func (d *DownloadLinks) TTL() time.Duration {
+	return d.tokens.ttl
+}
+
+// Verify checks that token was issued by Issue for the download id.
+//
+// Returns:
+//   - error: nil, ErrTokenInvalid (also for tokens of another type or
+//     download) or ErrTokenExpired
+func (d *DownloadLinks) Verify(token string, id int64) error {
+	tokenType, tokenID, err := d.tokens.VerifyPreview(token)
+	if err != nil {
+		return err
+	}
+	if tokenType != TokenProductDownload || tokenID != id {
+		return ErrTokenInvalid
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestDownloadLinks(t *testing.T) {
+	links := services.NewDownloadLinks("secret", time.Hour)
+	token := links.Issue(42)
+	if err := links.Verify(token, 42); err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+
+	for name, check := range map[string]func() error{
+		"empty":        func() error { return links.Verify("", 42) },
+		"unsigned":     func() error { return links.Verify(token[:strings.LastIndexByte(token, '.')], 42) },
+		"other id":     func() error { return links.Verify(strings.Replace(token, "42.", "43.", 1), 43) },
+		"other file":   func() error { return links.Verify(token, 43) },
+		"other secret": func() error { return links.Verify(services.NewDownloadLinks("other", time.Hour).Issue(42), 42) },
+		"preview key": func() error {
+			return links.Verify(services.NewPreviewTokens("secret", time.Hour).Issue(services.PreviewProduct, 42), 42)
+		},
+	} {
+		if err := check(); !errors.Is(err, services.ErrTokenInvalid) {
+			t.Errorf("%s: err = %v, want ErrTokenInvalid", name, err)
+		}
+	}
+
+	// Lifetimes under a second expire at once, since expiry has second precision
+	shortLived := services.NewDownloadLinks("secret", time.Millisecond)
+	if err := shortLived.Verify(shortLived.Issue(1), 1); !errors.Is(err, services.ErrTokenExpired) {
+		t.Errorf("expired link: err = %v, want ErrTokenExpired", err)
+	}
+}
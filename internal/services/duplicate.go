@@ -0,0 +1,301 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Request cancellation for the copy transaction
+	"database/sql" // Copy transaction
+	"errors"       // Exhausted slug and SKU candidates
+	"fmt"          // Numbered slug and SKU suffixes
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Content types ContentDuplicator can copy, as used in routes and the
+// activity log.
+const (
+	DuplicateProduct   = "product"
+	DuplicateBlogPost  = "blog_post"
+	DuplicateSolution  = "solution"
+	DuplicateCaseStudy = "case_study"
+)
+
+// maxCopySuffix bounds the "-copy-N" candidates tried for one item.
+const maxCopySuffix = 100
+
+// errNoFreeCopyName is returned when every "-copy-N" candidate is taken.
+var errNoFreeCopyName = errors.New("no free slug or SKU for the copy")
+
+// ContentDuplicator implements "Save as copy": it copies a product, blog
+// post, solution or case study with all of its sub-resources in one
+// transaction, so editors can use an existing item as a template.
+//
+// Copies are unpublished drafts named "<title> (copy)" with a "-copy" slug
+// ("-copy-2", "-copy-3", ... when taken). Product and variant SKUs get a
+// "-COPY" suffix the same way. Uploaded files are shared with the original.
+type ContentDuplicator struct {
+	db      *sql.DB       // Connection that opens the copy transaction
+	queries *sqlc.Queries // Queries bound to the transaction with WithTx
+}
+
+// NewContentDuplicator creates a ContentDuplicator.
+//
+// Parameters:
+//   - db: Database connection, used to start the copy transaction
+//   - queries: Database query interface from sqlc
+//
+// Returns:
+//   - *ContentDuplicator: Duplicator ready for use
+func NewContentDuplicator(db *sql.DB, queries *sqlc.Queries) *ContentDuplicator {
+	return &ContentDuplicator{db: db, queries: queries}
+}
+
+// Duplicate copies one item and returns the copy's ID and title.
+//
+// Parameters:
+//   - ctx: Request context
+//   - contentType: DuplicateProduct, DuplicateBlogPost, DuplicateSolution or DuplicateCaseStudy
+//   - id: Item to copy
+//
+// Returns:
+//   - int64: ID of the copy
+//   - string: Title of the copy, e.g. "Sensor X (copy)"
+//   - error: sql.ErrNoRows when the item does not exist or is in the trash,
+//     or a database error; nothing is written on error
+func (d *ContentDuplicator) Duplicate(ctx context.Context, contentType string, id int64) (int64, string, error) {
+	copyFn := map[string]func(context.Context, *sqlc.Queries, int64) (int64, string, error){
+		DuplicateProduct:   d.copyProduct,
+		DuplicateBlogPost:  d.copyBlogPost,
+		DuplicateSolution:  d.copySolution,
+		DuplicateCaseStudy: d.copyCaseStudy,
+	}[contentType]
+	if copyFn == nil {
+		return 0, "", fmt.Errorf("cannot duplicate content type %q", contentType)
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tx.Rollback()
+	newID, title, err := copyFn(ctx, d.queries.WithTx(tx), id)
+	if err != nil {
+		return 0, "", err
+	}
+	return newID, title, tx.Commit()
+}
+
+// copyProduct copies a product with its specs, gallery, features,
+// certifications, downloads, variants, related content pins and translations.
+func (d *ContentDuplicator) copyProduct(ctx context.Context, q *sqlc.Queries, id int64) (int64, string, error) {
+	src, err := q.GetProduct(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	title := copyTitle(src.Name)
+	slug, err := copySlug(ctx, q, DuplicateProduct, src.Slug)
+	if err != nil {
+		return 0, "", err
+	}
+	sku, err := copySKU(ctx, q, src.Sku)
+	if err != nil {
+		return 0, "", err
+	}
+	newID, err := q.DuplicateProduct(ctx, sqlc.DuplicateProductParams{Sku: sku, Slug: slug, Name: title, SourceID: id})
+	if err != nil {
+		return 0, "", err
+	}
+
+	children := []func() error{
+		func() error {
+			return q.DuplicateProductSpecs(ctx, sqlc.DuplicateProductSpecsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateProductImages(ctx, sqlc.DuplicateProductImagesParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateProductFeatures(ctx, sqlc.DuplicateProductFeaturesParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateProductCertifications(ctx, sqlc.DuplicateProductCertificationsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			if err := q.DuplicateProductDownloads(ctx, sqlc.DuplicateProductDownloadsParams{NewID: newID, SourceID: id}); err != nil {
+				return err
+			}
+			return q.DuplicateProductDownloadVersions(ctx, sqlc.DuplicateProductDownloadVersionsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateProductRelatedContent(ctx, sqlc.DuplicateProductRelatedContentParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateTranslations(ctx, sqlc.DuplicateTranslationsParams{EntityType: DuplicateProduct, NewID: newID, SourceID: id})
+		},
+	}
+	if err := runAll(children); err != nil {
+		return 0, "", err
+	}
+
+	variants, err := q.ListProductVariants(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	for _, v := range variants {
+		vSKU, err := copySKU(ctx, q, v.Sku)
+		if err != nil {
+			return 0, "", err
+		}
+		newVariant, err := q.DuplicateProductVariant(ctx, sqlc.DuplicateProductVariantParams{NewProductID: newID, Sku: vSKU, SourceID: v.ID})
+		if err != nil {
+			return 0, "", err
+		}
+		if err := q.DuplicateProductVariantSpecs(ctx, sqlc.DuplicateProductVariantSpecsParams{NewID: newVariant, SourceID: v.ID}); err != nil {
+			return 0, "", err
+		}
+		if err := q.DuplicateProductVariantImages(ctx, sqlc.DuplicateProductVariantImagesParams{NewID: newVariant, SourceID: v.ID}); err != nil {
+			return 0, "", err
+		}
+	}
+	return newID, title, nil
+}
+
+// copyBlogPost copies a blog post with its tags, related products and
+// translations. The copy is not scheduled and not part of a series.
+func (d *ContentDuplicator) copyBlogPost(ctx context.Context, q *sqlc.Queries, id int64) (int64, string, error) {
+	src, err := q.GetBlogPost(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	title := copyTitle(src.Title)
+	slug, err := copySlug(ctx, q, DuplicateBlogPost, src.Slug)
+	if err != nil {
+		return 0, "", err
+	}
+	newID, err := q.DuplicateBlogPost(ctx, sqlc.DuplicateBlogPostParams{Title: title, Slug: slug, SourceID: id})
+	if err != nil {
+		return 0, "", err
+	}
+	return newID, title, runAll([]func() error{
+		func() error {
+			return q.DuplicateBlogPostTags(ctx, sqlc.DuplicateBlogPostTagsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateBlogPostProducts(ctx, sqlc.DuplicateBlogPostProductsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateTranslations(ctx, sqlc.DuplicateTranslationsParams{EntityType: DuplicateBlogPost, NewID: newID, SourceID: id})
+		},
+	})
+}
+
+// copySolution copies a solution with its stats, challenges, products, CTAs
+// and translations.
+func (d *ContentDuplicator) copySolution(ctx context.Context, q *sqlc.Queries, id int64) (int64, string, error) {
+	src, err := q.GetSolutionByID(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	title := copyTitle(src.Title)
+	slug, err := copySlug(ctx, q, DuplicateSolution, src.Slug)
+	if err != nil {
+		return 0, "", err
+	}
+	newID, err := q.DuplicateSolution(ctx, sqlc.DuplicateSolutionParams{Title: title, Slug: slug, SourceID: id})
+	if err != nil {
+		return 0, "", err
+	}
+	return newID, title, runAll([]func() error{
+		func() error {
+			return q.DuplicateSolutionStats(ctx, sqlc.DuplicateSolutionStatsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateSolutionChallenges(ctx, sqlc.DuplicateSolutionChallengesParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateSolutionProducts(ctx, sqlc.DuplicateSolutionProductsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateSolutionCTAs(ctx, sqlc.DuplicateSolutionCTAsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateTranslations(ctx, sqlc.DuplicateTranslationsParams{EntityType: DuplicateSolution, NewID: newID, SourceID: id})
+		},
+	})
+}
+
+// copyCaseStudy copies a case study with its products and metrics.
+func (d *ContentDuplicator) copyCaseStudy(ctx context.Context, q *sqlc.Queries, id int64) (int64, string, error) {
+	src, err := q.AdminGetCaseStudy(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	title := copyTitle(src.Title)
+	slug, err := copySlug(ctx, q, DuplicateCaseStudy, src.Slug)
+	if err != nil {
+		return 0, "", err
+	}
+	newID, err := q.DuplicateCaseStudy(ctx, sqlc.DuplicateCaseStudyParams{Slug: slug, Title: title, SourceID: id})
+	if err != nil {
+		return 0, "", err
+	}
+	return newID, title, runAll([]func() error{
+		func() error {
+			return q.DuplicateCaseStudyProducts(ctx, sqlc.DuplicateCaseStudyProductsParams{NewID: newID, SourceID: id})
+		},
+		func() error {
+			return q.DuplicateCaseStudyMetrics(ctx, sqlc.DuplicateCaseStudyMetricsParams{NewID: newID, SourceID: id})
+		},
+	})
+}
+
+// runAll runs steps in order and stops at the first error.
+func runAll(steps []func() error) error {
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTitle names a copy, e.g. "Sensor X" -> "Sensor X (copy)".
+func copyTitle(title string) string {
+	return title + " (copy)"
+}
+
+// copySlug returns the first free slug of "<slug>-copy", "<slug>-copy-2", ...
+func copySlug(ctx context.Context, q *sqlc.Queries, contentType, slug string) (string, error) {
+	for n := 1; n <= maxCopySuffix; n++ {
+		candidate := slug + "-copy"
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-copy-%d", slug, n)
+		}
+		taken, err := q.CopySlugTaken(ctx, sqlc.CopySlugTakenParams{ContentType: contentType, Slug: candidate})
+		if err != nil {
+			return "", err
+		}
+		if taken == 0 {
+			return candidate, nil
+		}
+	}
+	return "", errNoFreeCopyName
+}
+
+// copySKU returns the first SKU of "<sku>-COPY", "<sku>-COPY-2", ... that no
+// product or variant uses.
+func copySKU(ctx context.Context, q *sqlc.Queries, sku string) (string, error) {
+	for n := 1; n <= maxCopySuffix; n++ {
+		candidate := sku + "-COPY"
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-COPY-%d", sku, n)
+		}
+		taken, err := q.CopySKUTaken(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if taken == 0 {
+			return candidate, nil
+		}
+	}
+	return "", errNoFreeCopyName
+}
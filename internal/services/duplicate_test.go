@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestContentDuplicator_ProductDeepCopy(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	dup := services.NewContentDuplicator(db, queries)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	src, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "SX-1", Slug: "sensor-x", Name: "Sensor X", Description: "d", CategoryID: cat.ID, Status: "published"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: src.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V"})
+	queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: src.ID, ImagePath: "/uploads/x.jpg"})
+	v1, _ := queries.CreateProductDownload(ctx, sqlc.CreateProductDownloadParams{ProductID: src.ID, Title: "Datasheet", FileType: "pdf", FilePath: "/uploads/v1.pdf"})
+	queries.CreateProductDownload(ctx, sqlc.CreateProductDownloadParams{ProductID: src.ID, Title: "Manual", FileType: "pdf", FilePath: "/uploads/manual.pdf"})
+	queries.CreateProductDownload(ctx, sqlc.CreateProductDownloadParams{
+		ProductID: src.ID, Title: "Datasheet", FileType: "pdf", FilePath: "/uploads/v2.pdf", Changelog: "New pinout",
+		SupersedesID: sql.NullInt64{Int64: v1.ID, Valid: true},
+	})
+	variant, err := queries.CreateProductVariant(ctx, sqlc.CreateProductVariantParams{ProductID: src.ID, Sku: "SX-1-L", Name: "Long"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries.UpsertProductVariantSpec(ctx, sqlc.UpsertProductVariantSpecParams{VariantID: variant.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "48V"})
+	queries.UpsertTranslation(ctx, sqlc.UpsertTranslationParams{EntityType: "product", EntityID: src.ID, Locale: "de", Fields: `{"name":"Sensor X"}`, SourceHash: "h", Status: "published"})
+
+	id, title, err := dup.Duplicate(ctx, services.DuplicateProduct, src.ID)
+	if err != nil {
+		t.Fatalf("duplicate: %v", err)
+	}
+	if title != "Sensor X (copy)" {
+		t.Errorf("title = %q", title)
+	}
+	cp, err := queries.GetProduct(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Slug != "sensor-x-copy" || cp.Sku != "SX-1-COPY" || cp.Status != "draft" || cp.CategoryID != cat.ID {
+		t.Errorf("copy = slug %q sku %q status %q category %d", cp.Slug, cp.Sku, cp.Status, cp.CategoryID)
+	}
+	if specs, _ := queries.ListProductSpecs(ctx, id); len(specs) != 1 || specs[0].SpecValue != "24V" {
+		t.Errorf("specs = %+v", specs)
+	}
+	if images, _ := queries.ListProductImages(ctx, id); len(images) != 1 || images[0].ImagePath != "/uploads/x.jpg" {
+		t.Errorf("images = %+v", images)
+	}
+	// Downloads keep their version chains, pointing at the copied versions
+	downloads, _ := queries.ListProductDownloads(ctx, id)
+	groups := services.GroupDownloadVersions(downloads)
+	if len(groups) != 2 || groups[0].Latest.FilePath != "/uploads/manual.pdf" || len(groups[0].Older) != 0 ||
+		groups[1].Latest.FilePath != "/uploads/v2.pdf" || groups[1].Latest.Changelog != "New pinout" ||
+		len(groups[1].Older) != 1 || groups[1].Older[0].FilePath != "/uploads/v1.pdf" || groups[1].Older[0].ProductID != id {
+		t.Errorf("downloads = %+v", groups)
+	}
+
+	variants, _ := queries.ListProductVariants(ctx, id)
+	if len(variants) != 1 || variants[0].Sku != "SX-1-L-COPY" || variants[0].Name != "Long" {
+		t.Fatalf("variants = %+v", variants)
+	}
+	if specs, _ := queries.ListProductVariantSpecs(ctx, variants[0].ID); len(specs) != 1 || specs[0].SpecValue != "48V" {
+		t.Errorf("variant specs = %+v", specs)
+	}
+	if _, err := queries.GetTranslation(ctx, sqlc.GetTranslationParams{EntityType: "product", EntityID: id, Locale: "de"}); err != nil {
+		t.Errorf("translation not copied: %v", err)
+	}
+
+	// The original is untouched and a second copy takes the next suffix
+	if specs, _ := queries.ListProductSpecs(ctx, src.ID); len(specs) != 1 {
+		t.Errorf("original specs = %d", len(specs))
+	}
+	id2, _, err := dup.Duplicate(ctx, services.DuplicateProduct, src.ID)
+	if err != nil {
+		t.Fatalf("second duplicate: %v", err)
+	}
+	if cp2, _ := queries.GetProduct(ctx, id2); cp2.Slug != "sensor-x-copy-2" || cp2.Sku != "SX-1-COPY-2" {
+		t.Errorf("second copy = slug %q sku %q", cp2.Slug, cp2.Sku)
+	}
+}
+
+func TestContentDuplicator_TrashedOrMissing(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	dup := services.NewContentDuplicator(db, queries)
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	src, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "T-1", Slug: "trashed", Name: "Trashed", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	queries.TrashProduct(ctx, src.ID)
+
+	if _, _, err := dup.Duplicate(ctx, services.DuplicateProduct, src.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("trashed product: err = %v, want sql.ErrNoRows", err)
+	}
+	if _, _, err := dup.Duplicate(ctx, services.DuplicateBlogPost, 999); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("missing post: err = %v, want sql.ErrNoRows", err)
+	}
+	if _, _, err := dup.Duplicate(ctx, "whitepaper", 1); err == nil {
+		t.Error("unsupported content type should fail")
+	}
+}
+
+func TestContentDuplicator_BlogPostAndSolution(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	dup := services.NewContentDuplicator(db, queries)
+
+	blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "A", Slug: "a", Title: "Writer"})
+	tag, _ := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Edge", Slug: "edge"})
+	post, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Launch", Slug: "launch", Body: "body", Excerpt: "excerpt", CategoryID: blogCat.ID, AuthorID: author.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries.AddTagToPost(ctx, sqlc.AddTagToPostParams{BlogPostID: post.ID, BlogTagID: tag.ID})
+
+	postID, _, err := dup.Duplicate(ctx, services.DuplicateBlogPost, post.ID)
+	if err != nil {
+		t.Fatalf("duplicate post: %v", err)
+	}
+	cp, _ := queries.GetBlogPost(ctx, postID)
+	if cp.Slug != "launch-copy" || cp.Title != "Launch (copy)" || cp.Status != "draft" || cp.PublishedAt.Valid {
+		t.Errorf("post copy = slug %q title %q status %q published %v", cp.Slug, cp.Title, cp.Status, cp.PublishedAt.Valid)
+	}
+	if tags, _ := queries.GetPostTagsByPostID(ctx, postID); len(tags) != 1 {
+		t.Errorf("tags = %d, want 1", len(tags))
+	}
+
+	sol, err := queries.CreateSolution(ctx, sqlc.CreateSolutionParams{
+		Title: "Grid", Slug: "grid", Icon: "bolt", ShortDescription: "s", IsPublished: sql.NullBool{Bool: true, Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries.CreateSolutionStat(ctx, sqlc.CreateSolutionStatParams{SolutionID: sol.ID, Value: "99%", Label: "Uptime"})
+	queries.CreateSolutionCTA(ctx, sqlc.CreateSolutionCTAParams{SolutionID: sol.ID, Heading: "Talk to us"})
+
+	solID, _, err := dup.Duplicate(ctx, services.DuplicateSolution, sol.ID)
+	if err != nil {
+		t.Fatalf("duplicate solution: %v", err)
+	}
+	scp, _ := queries.GetSolutionByID(ctx, solID)
+	if scp.Slug != "grid-copy" || scp.IsPublished.Bool {
+		t.Errorf("solution copy = slug %q published %v", scp.Slug, scp.IsPublished.Bool)
+	}
+	if stats, _ := queries.GetSolutionStats(ctx, solID); len(stats) != 1 || stats[0].Value != "99%" {
+		t.Errorf("stats = %+v", stats)
+	}
+	if ctas, _ := queries.GetSolutionCTAs(ctx, solID); len(ctas) != 1 || ctas[0].Heading != "Talk to us" {
+		t.Errorf("ctas = %+v", ctas)
+	}
+}
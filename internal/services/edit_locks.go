@@ -0,0 +1,106 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Database calls
+	"database/sql" // sql.ErrNoRows for free items
+	"errors"       // Error matching
+	"time"         // Heartbeats and expiry
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// EditLockTTL is how long an edit lock lasts without a heartbeat. Open edit
+// forms renew their lock every 30 seconds, so a lock expires about two
+// minutes after its form is closed without releasing it (a crashed browser,
+// a lost connection).
+const EditLockTTL = 2 * time.Minute
+
+// EditLock is the holder of the lock on an item.
+type EditLock struct {
+	UserID   int64     // admin_users.id of the holder
+	UserName string    // Holder's display name
+	Since    time.Time // When the holder opened the edit form (UTC)
+}
+
+// EditLocks keeps soft "currently being edited by" locks, so an admin who
+// opens an item someone else is editing is warned before they overwrite each
+// other's changes. Locks never block saving.
+type EditLocks struct {
+	queries *sqlc.Queries // Database query interface for edit_locks
+	ttl     time.Duration // Locks without a heartbeat for this long have expired
+}
+
+// NewEditLocks creates the edit lock store.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - ttl: Expiry of a lock without heartbeat (0 = EditLockTTL)
+//
+// Returns:
+//   - *EditLocks: Lock store ready to use
+func NewEditLocks(queries *sqlc.Queries, ttl time.Duration) *EditLocks {
+	if ttl <= 0 {
+		ttl = EditLockTTL
+	}
+	return &EditLocks{queries: queries, ttl: ttl}
+}
+
+// Acquire takes the lock on an item for a user, or renews it when they
+// already hold it; the edit form calls it on load and on every heartbeat.
+// When someone else holds a live lock, it is left alone.
+//
+// Parameters:
+//   - ctx: Context for the database calls
+//   - contentType: Content type, e.g. "product" or "blog_post"
+//   - contentID: ID of the item
+//   - userID: Admin user editing the item
+//   - now: Current time
+//
+// Returns:
+//   - EditLock: The current holder, the user themselves when acquired
+//   - bool: Whether the user holds the lock
+//   - error: Database error
+func (l *EditLocks) Acquire(ctx context.Context, contentType string, contentID, userID int64, now time.Time) (EditLock, bool, error) {
+	// The stored times use SQLite's CURRENT_TIMESTAMP layout, in UTC
+	stamp := now.UTC().Format(archiveTimeLayout)
+	cutoff := now.UTC().Add(-l.ttl).Format(archiveTimeLayout)
+	// A lock that expires between the claim and the read is claimed again
+	for attempt := 0; attempt < 2; attempt++ {
+		n, err := l.queries.ClaimEditLock(ctx, sqlc.ClaimEditLockParams{
+			ContentType: contentType, ContentID: contentID, UserID: userID, Now: stamp, Cutoff: cutoff,
+		})
+		if err != nil {
+			return EditLock{}, false, err
+		}
+		row, err := l.queries.GetEditLock(ctx, sqlc.GetEditLockParams{ContentType: contentType, ContentID: contentID, Cutoff: cutoff})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return EditLock{}, false, err
+		}
+		lock := EditLock{UserID: row.UserID, UserName: row.UserName, Since: row.AcquiredAt.UTC()}
+		return lock, n > 0 && row.UserID == userID, nil
+	}
+	return EditLock{}, false, errors.New("edit lock changed hands while claiming it")
+}
+
+// Release drops a user's lock on an item, when they leave its edit form.
+// Locks held by other users are not touched.
+func (l *EditLocks) Release(ctx context.Context, contentType string, contentID, userID int64) error {
+	_, err := l.queries.ReleaseEditLock(ctx, sqlc.ReleaseEditLockParams{ContentType: contentType, ContentID: contentID, UserID: userID})
+	return err
+}
+
+// PurgeExpired deletes the locks whose heartbeat stopped more than the
+// expiry before now. Expired locks are already ignored; this only keeps the
+// table small.
+//
+// Returns:
+//   - int64: Number of locks deleted
+//   - error: Database error
+func (l *EditLocks) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return l.queries.DeleteExpiredEditLocks(ctx, now.UTC().Add(-l.ttl).Format(archiveTimeLayout))
+}
@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestEditLocks(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	alice, _ := queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{Email: "alice@test.com", PasswordHash: "x", DisplayName: "Alice", Role: "admin"})
+	bob, _ := queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{Email: "bob@test.com", PasswordHash: "x", DisplayName: "Bob", Role: "editor"})
+	locks := services.NewEditLocks(queries, 2*time.Minute)
+	start := time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)
+
+	acquire := func(userID int64, at time.Time) (services.EditLock, bool) {
+		t.Helper()
+		lock, held, err := locks.Acquire(ctx, "product", 7, userID, at)
+		if err != nil {
+			t.Fatalf("Acquire: %v", err)
+		}
+		return lock, held
+	}
+
+	if lock, held := acquire(alice.ID, start); !held || lock.UserID != alice.ID || !lock.Since.Equal(start) {
+		t.Fatalf("first open: held %v by %+v", held, lock)
+	}
+	// Bob opens the same product: Alice keeps it and Bob is told
+	lock, held := acquire(bob.ID, start.Add(30*time.Second))
+	if held || lock.UserID != alice.ID || lock.UserName != "Alice" || !lock.Since.Equal(start) {
+		t.Errorf("second admin: held %v by %+v, want Alice's lock", held, lock)
+	}
+	// Another item is free
+	if _, held, _ := locks.Acquire(ctx, "blog_post", 7, bob.ID, start); !held {
+		t.Error("lock on a different content type blocked")
+	}
+
+	// Alice's heartbeats keep the lock past the expiry, without moving Since
+	for _, s := range []int{60, 150, 240} {
+		if lock, held := acquire(alice.ID, start.Add(time.Duration(s)*time.Second)); !held || !lock.Since.Equal(start) {
+			t.Fatalf("heartbeat at +%ds: held %v, %+v", s, held, lock)
+		}
+	}
+	if _, held := acquire(bob.ID, start.Add(5*time.Minute)); held {
+		t.Error("Bob took a lock that is still renewed")
+	}
+
+	// Without heartbeats the lock expires and Bob gets it
+	later := start.Add(7 * time.Minute)
+	if lock, held := acquire(bob.ID, later); !held || lock.UserID != bob.ID || !lock.Since.Equal(later) {
+		t.Errorf("after expiry: held %v by %+v, want Bob's new lock", held, lock)
+	}
+
+	// Only the holder can release
+	if err := locks.Release(ctx, "product", 7, alice.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, held := acquire(alice.ID, later.Add(time.Second)); held {
+		t.Error("Alice released Bob's lock")
+	}
+	if err := locks.Release(ctx, "product", 7, bob.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, held := acquire(alice.ID, later.Add(2*time.Second)); !held {
+		t.Error("lock not free after its holder released it")
+	}
+
+	// Cleanup removes only expired locks
+	n, err := locks.PurgeExpired(ctx, later.Add(time.Minute))
+	if err != nil || n != 1 {
+		t.Errorf("PurgeExpired removed %d locks (%v), want the blog post lock", n, err)
+	}
+}
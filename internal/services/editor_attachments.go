@@ -0,0 +1,253 @@
+package services
+
+import (
+	// Standard library imports
+	"context"       // Database calls and the cleanup worker
+	"database/sql"  // Scanning the schema for references
+	"errors"        // Rejected uploads
+	"fmt"           // Error details
+	"io"            // Upload contents
+	"log/slog"      // Cleanup progress and failures
+	"os"            // Removing files
+	"path"          // Upload extension
+	"path/filepath" // Files under the upload directory
+	"strings"       // Column types and paths
+	"time"          // Grace period
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// editorAttachmentTypes are the extensions the editors may attach: raster
+// images that render inline. SVG and PDF stay in the media library picker.
+var editorAttachmentTypes = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true}
+
+// ErrEditorAttachment is returned by EditorAttachments.Store for files that
+// cannot be attached; the message says why.
+var ErrEditorAttachment = errors.New("attachment rejected")
+
+// editorAttachmentSkipTables are tables that never hold content referring to
+// an upload, or that are too large to scan (logs and analytics).
+var editorAttachmentSkipTables = map[string]bool{
+	"media_files": true, "media_variants": true, "editor_attachments": true, "schema_migrations": true,
+	"activity_log": true, "page_views": true, "page_view_daily": true, "page_view_counts": true,
+	"not_found_hits": true, "job_runs": true, "admin_sessions": true, "archive_runs": true,
+}
+
+// EditorAttachments stores images dropped or pasted into the rich text
+// editors in the media library, and removes them again once no content
+// refers to them, e.g. after the post they were in is deleted.
+//
+// An attachment is only removed after a grace period (a day by default), so
+// one uploaded into a form that has not been saved yet is kept. References
+// are searched in every text column of the content tables, so an attachment
+// that was picked from the media library elsewhere, or that a trashed item
+// still uses, stays.
+type EditorAttachments struct {
+	db        *sql.DB        // Searched for references to attachments
+	queries   *sqlc.Queries  // Attachment and media records
+	importer  *MediaImporter // Stores uploads like bulk imports do
+	uploadDir string         // Root of /uploads
+	logger    *slog.Logger   // Cleanup progress and failures
+	grace     time.Duration  // Age before an unreferenced attachment is removed
+	sweep     chan struct{}  // Requests a cleanup from the worker
+}
+
+// NewEditorAttachments creates the editor attachment service.
+//
+// Parameters:
+//   - db: Database connection, scanned for references to attachments
+//   - queries: Database query interface from sqlc
+//   - uploadDir: Directory served at /uploads (UPLOAD_DIR)
+//   - logger: Structured logger for the cleanup
+//
+// Returns:
+//   - *EditorAttachments: Service ready to store uploads
+func NewEditorAttachments(db *sql.DB, queries *sqlc.Queries, uploadDir string, logger *slog.Logger) *EditorAttachments {
+	return &EditorAttachments{
+		db:        db,
+		queries:   queries,
+		importer:  NewMediaImporter(queries, uploadDir),
+		uploadDir: uploadDir,
+		logger:    logger,
+		grace:     24 * time.Hour,
+		sweep:     make(chan struct{}, 1),
+	}
+}
+
+// Store adds an uploaded image to the media library and marks it as an
+// editor attachment. An image the library already has is returned instead
+// and left unmarked, so it is never removed as an attachment.
+//
+// Parameters:
+//   - ctx: Context for the database calls
+//   - name: Uploaded file name
+//   - size: Size reported by the upload
+//   - open: Opens the contents
+//
+// Returns:
+//   - sqlc.MediaFile: The stored (or existing) library file
+//   - error: ErrEditorAttachment (wrapped, with the reason) for files that
+//     are not a supported image or too large, or a storage error
+func (a *EditorAttachments) Store(ctx context.Context, name string, size int64, open func() (io.ReadCloser, error)) (sqlc.MediaFile, error) {
+	if !editorAttachmentTypes[strings.ToLower(path.Ext(name))] {
+		return sqlc.MediaFile{}, fmt.Errorf("%w: only JPEG, PNG, GIF and WebP images can be attached", ErrEditorAttachment)
+	}
+	item, err := a.importer.ImportFile(ctx, MediaImportFile{Path: path.Base(name), Size: size, Open: open})
+	if err != nil {
+		return sqlc.MediaFile{}, err
+	}
+	switch item.Status {
+	case MediaImportImported:
+		if err := a.queries.CreateEditorAttachment(ctx, item.MediaID); err != nil {
+			return sqlc.MediaFile{}, err
+		}
+	case MediaImportDuplicate:
+	default:
+		return sqlc.MediaFile{}, fmt.Errorf("%w: %s", ErrEditorAttachment, item.Detail)
+	}
+	return a.queries.GetMediaFile(ctx, item.MediaID)
+}
+
+// ContentDeleted asks the worker to look for attachments the deleted
+// content left behind. It never blocks; requests made while a cleanup is
+// pending are merged.
+func (a *EditorAttachments) ContentDeleted() {
+	select {
+	case a.sweep <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the cleanup requested by ContentDeleted in the background until
+// ctx is cancelled.
+func (a *EditorAttachments) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-a.sweep:
+				if _, err := a.PruneOrphans(ctx, time.Now()); err != nil {
+					a.logger.Error("editor attachment cleanup failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// PruneOrphans removes editor attachments older than the grace period that
+// no content refers to: the library record, its variants and the files.
+//
+// Parameters:
+//   - ctx: Context for the database calls
+//   - now: Reference time for the grace period
+//
+// Returns:
+//   - int: Attachments removed
+//   - error: First database error; attachments removed before it stay removed
+func (a *EditorAttachments) PruneOrphans(ctx context.Context, now time.Time) (int, error) {
+	files, err := a.queries.ListEditorAttachmentsBefore(ctx, now.UTC().Add(-a.grace).Format(archiveTimeLayout))
+	if err != nil || len(files) == 0 {
+		return 0, err
+	}
+	scans, err := a.referenceScans(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, f := range files {
+		variants, err := a.queries.ListMediaVariants(ctx, f.ID)
+		if err != nil {
+			return removed, err
+		}
+		paths := []string{f.FilePath}
+		for _, v := range variants {
+			paths = append(paths, v.FilePath)
+		}
+		used, err := referencedAnywhere(ctx, a.db, scans, paths)
+		if err != nil {
+			return removed, err
+		}
+		if used {
+			continue
+		}
+		if err := a.queries.DeleteMediaFile(ctx, f.ID); err != nil {
+			return removed, err
+		}
+		for _, p := range paths {
+			os.Remove(filepath.Join(a.uploadDir, filepath.FromSlash(strings.TrimPrefix(p, "/uploads/"))))
+		}
+		removed++
+	}
+	if removed > 0 {
+		a.logger.Info("removed orphaned editor attachments", "files", removed)
+	}
+	return removed, nil
+}
+
+// referenceScans returns one query per table that reports whether any text
+// column of the table contains ?1.
+func (a *EditorAttachments) referenceScans(ctx context.Context) ([]string, error) {
+	rows, err := a.db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name NOT LIKE '%_fts%' ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if !editorAttachmentSkipTables[name] {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var scans []string
+	for _, table := range tables {
+		cols, err := a.db.QueryContext(ctx, `SELECT name, type FROM pragma_table_info(?)`, table)
+		if err != nil {
+			return nil, err
+		}
+		var conds []string
+		for cols.Next() {
+			var name, typ string
+			if err := cols.Scan(&name, &typ); err != nil {
+				cols.Close()
+				return nil, err
+			}
+			if typ = strings.ToUpper(typ); typ == "" || strings.Contains(typ, "TEXT") || strings.Contains(typ, "CHAR") {
+				conds = append(conds, fmt.Sprintf(`instr("%s", ?1) > 0`, name))
+			}
+		}
+		cols.Close()
+		if len(conds) > 0 {
+			scans = append(scans, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM "%s" WHERE %s)`, table, strings.Join(conds, " OR ")))
+		}
+	}
+	return scans, nil
+}
+
+// referencedAnywhere reports whether any of paths appears in the columns
+// searched by scans.
+func referencedAnywhere(ctx context.Context, db *sql.DB, scans []string, paths []string) (bool, error) {
+	for _, p := range paths {
+		for _, scan := range scans {
+			var found bool
+			if err := db.QueryRowContext(ctx, scan, p).Scan(&found); err != nil {
+				return false, err
+			}
+			if found {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
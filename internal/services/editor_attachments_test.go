@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/color"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestEditorAttachments stores two editor uploads, uses one in a blog post
+// and checks that only the other is removed once the grace period is over.
+func TestEditorAttachments(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	uploadDir := t.TempDir()
+	attachments := services.NewEditorAttachments(db, queries, uploadDir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	store := func(name string, data []byte) (sqlc.MediaFile, error) {
+		return attachments.Store(ctx, name, int64(len(data)), func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		})
+	}
+	used, err := store("diagram.png", testPNG(t, 40, 20, color.RGBA{255, 0, 0, 255}))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	unused, err := store("screenshot.png", testPNG(t, 40, 20, color.RGBA{0, 0, 255, 255}))
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if used.Width.Int64 != 40 || used.FilePath == unused.FilePath {
+		t.Errorf("stored %+v and %+v", used, unused)
+	}
+	if again, err := store("copy.png", testPNG(t, 40, 20, color.RGBA{255, 0, 0, 255})); err != nil || again.ID != used.ID {
+		t.Errorf("same image again = %+v, %v; want the existing file", again, err)
+	}
+	if _, err := store("notes.pdf", []byte("%PDF-1.4")); !errors.Is(err, services.ErrEditorAttachment) {
+		t.Errorf("Store(pdf) = %v, want ErrEditorAttachment", err)
+	}
+	if _, err := store("fake.png", []byte("not an image")); !errors.Is(err, services.ErrEditorAttachment) {
+		t.Errorf("Store(fake png) = %v, want ErrEditorAttachment", err)
+	}
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Ada", Slug: "ada", Title: "Editor"})
+	if _, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Diagram", Slug: "diagram", Excerpt: "e", Body: `<figure><img src="` + used.FilePath + `"></figure>`,
+		CategoryID: cat.ID, AuthorID: author.ID, Status: "draft",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Within the grace period nothing is removed
+	if n, err := attachments.PruneOrphans(ctx, time.Now()); err != nil || n != 0 {
+		t.Errorf("PruneOrphans within a day = %d, %v; want 0", n, err)
+	}
+
+	n, err := attachments.PruneOrphans(ctx, time.Now().Add(25*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("PruneOrphans = %d, %v; want 1", n, err)
+	}
+	if _, err := queries.GetMediaFile(ctx, unused.ID); err == nil {
+		t.Error("unused attachment still in the library")
+	}
+	if _, err := os.Stat(filepath.Join(uploadDir, "media", unused.Filename)); !os.IsNotExist(err) {
+		t.Errorf("unused attachment still on disk: %v", err)
+	}
+	if _, err := queries.GetMediaFile(ctx, used.ID); err != nil {
+		t.Errorf("attachment used by the post was removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(uploadDir, "media", used.Filename)); err != nil {
+		t.Errorf("attachment used by the post missing from disk: %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	// Standard library imports
+	"context"  // Request cancellation and email timeouts
+	"errors"   // Registration errors
+	"fmt"      // Email text and calendar lines
+	"log/slog" // Structured logging of email failures
+	"strings"  // Calendar text escaping and line folding
+	"time"     // Event times and the current time
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Event types (events.event_type).
+const (
+	EventInPerson = "in_person" // Held at events.location
+	EventWebinar  = "webinar"   // Held online at events.webinar_url
+)
+
+// EventTypes lists the event types in the order the admin form offers them.
+var EventTypes = []string{EventInPerson, EventWebinar}
+
+// Errors returned by Events.Register. Their messages are shown to visitors.
+var (
+	ErrEventInvalidEmail       = errors.New("enter a valid email address")
+	ErrEventRegistrationClosed = errors.New("registration for this event is closed")
+	ErrEventFull               = errors.New("this event is fully booked")
+)
+
+// eventClockLayout formats "now" for the event listing queries, matching
+// the stored timestamps.
+const eventClockLayout = "2006-01-02 15:04:05"
+
+// Events lists events for /events and registers visitors for them.
+// Registrants are emailed a confirmation; for webinars it carries the join
+// link, which is not shown on the public page.
+type Events struct {
+	queries *sqlc.Queries // Event and registration rows
+	logger  *slog.Logger  // Email failures
+	mailer  Mailer        // Confirmation emails; nil disables them
+	baseURL string        // Site URL for links, without trailing slash
+}
+
+// NewEvents creates the events service.
+//
+// Parameters:
+//   - queries: Database queries
+//   - logger: Structured logger
+//   - mailer: Confirmation delivery; nil registers without emailing
+//   - baseURL: Public site URL used in emails and calendar files
+func NewEvents(queries *sqlc.Queries, logger *slog.Logger, mailer Mailer, baseURL string) *Events {
+	return &Events{queries: queries, logger: logger, mailer: mailer, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Upcoming returns the published events that have not ended, soonest first.
+func (ev *Events) Upcoming(ctx context.Context, now time.Time) ([]sqlc.Event, error) {
+	return ev.queries.ListUpcomingEvents(ctx, now.UTC().Format(eventClockLayout))
+}
+
+// Past returns up to limit published events that have ended, most recent
+// first.
+func (ev *Events) Past(ctx context.Context, now time.Time, limit int64) ([]sqlc.Event, error) {
+	return ev.queries.ListPastEvents(ctx, sqlc.ListPastEventsParams{Now: now.UTC().Format(eventClockLayout), PageLimit: limit})
+}
+
+// EventEnded reports whether an event is over at now.
+func EventEnded(event sqlc.Event, now time.Time) bool {
+	return !now.Before(event.EndsAt)
+}
+
+// Register records a registration and emails the registrant a
+// confirmation. Registration is refused once the event has ended, when the
+// admin has closed it or when the capacity is reached. Registering an
+// address twice is not an error and sends no second email.
+//
+// Parameters:
+//   - event: Published event to register for
+//   - reg: Form values; EventID is set from event
+//
+// Returns:
+//   - error: ErrEventInvalidEmail, ErrEventRegistrationClosed, ErrEventFull
+//     or a database error
+func (ev *Events) Register(ctx context.Context, event sqlc.Event, reg sqlc.CreateEventRegistrationParams) error {
+	reg.Email = strings.TrimSpace(reg.Email)
+	if !isEmailAddress(reg.Email) {
+		return ErrEventInvalidEmail
+	}
+	if event.RegistrationOpen != 1 || EventEnded(event, time.Now()) {
+		return ErrEventRegistrationClosed
+	}
+	if event.Capacity > 0 {
+		count, err := ev.queries.CountEventRegistrations(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+		if count >= event.Capacity {
+			return ErrEventFull
+		}
+	}
+
+	reg.EventID = event.ID
+	added, err := ev.queries.CreateEventRegistration(ctx, reg)
+	if err != nil || added == 0 {
+		return err
+	}
+
+	if ev.mailer == nil {
+		ev.logger.WarnContext(ctx, "event registration received but email is not configured", "event", event.ID)
+		return nil
+	}
+	body := ev.confirmation(event, reg.Name)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := ev.mailer.Send(ctx, []string{reg.Email}, "You're registered: "+event.Title, body); err != nil {
+			ev.logger.Error("failed to send event confirmation", "event", event.ID, "error", err)
+		}
+	}()
+	return nil
+}
+
+// confirmation returns the text of the registration email.
+func (ev *Events) confirmation(event sqlc.Event, name string) string {
+	loc := SiteLocation()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hello %s,\n\nYou are registered for %s.\n\n", name, event.Title)
+	fmt.Fprintf(&b, "When: %s - %s (%s)\n",
+		event.StartsAt.In(loc).Format("Mon, Jan 2, 2006 15:04"), event.EndsAt.In(loc).Format("Jan 2, 2006 15:04"), loc)
+	if event.EventType == EventWebinar && event.WebinarUrl != "" {
+		fmt.Fprintf(&b, "Join online: %s\n", event.WebinarUrl)
+	} else if event.Location != "" {
+		fmt.Fprintf(&b, "Where: %s\n", event.Location)
+	}
+	fmt.Fprintf(&b, "\nAdd it to your calendar:\n%s\n\nEvent details:\n%s\n", ev.baseURL+EventCalendarPath(event), ev.baseURL+EventPath(event))
+	return b.String()
+}
+
+// EventPath returns the public page of an event.
+func EventPath(event sqlc.Event) string {
+	return "/events/" + event.Slug
+}
+
+// EventCalendarPath returns the ICS file of an event.
+func EventCalendarPath(event sqlc.Event) string {
+	return EventPath(event) + "/calendar.ics"
+}
+
+// Calendar returns the ICS file of an event, stamped now.
+func (ev *Events) Calendar(event sqlc.Event) []byte {
+	return EventICS(event, ev.baseURL, time.Now())
+}
+
+// EventICS renders an event as an iCalendar (RFC 5545) file with one
+// VEVENT. Times are written in UTC. The event page is the URL; the webinar
+// link is left out, since the file is public and the link is for
+// registrants only.
+//
+// Parameters:
+//   - event: Event to export
+//   - baseURL: Public site URL, used for the URL and the UID domain
+//   - now: DTSTAMP of the file
+func EventICS(event sqlc.Event, baseURL string, now time.Time) []byte {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	host := baseURL
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if host == "" {
+		host = "bluejay-cms"
+	}
+	location := event.Location
+	if event.EventType == EventWebinar && location == "" {
+		location = "Online"
+	}
+
+	const stamp = "20060102T150405Z"
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Bluejay CMS//Events//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:event-%d@%s", event.ID, host),
+		"DTSTAMP:" + now.UTC().Format(stamp),
+		"DTSTART:" + event.StartsAt.UTC().Format(stamp),
+		"DTEND:" + event.EndsAt.UTC().Format(stamp),
+		"SUMMARY:" + icsText(event.Title),
+	}
+	if description := strings.TrimSpace(event.Summary); description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsText(description))
+	}
+	if location != "" {
+		lines = append(lines, "LOCATION:"+icsText(location))
+	}
+	lines = append(lines,
+		"URL:"+baseURL+EventPath(event),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(icsFold(line))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+// icsText escapes a TEXT property value: backslashes, semicolons, commas
+// and newlines.
+func icsText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// icsFold folds a content line into lines of at most 75 octets, continuing
+// with a space, without splitting a UTF-8 character.
+func icsFold(line string) string {
+	const max = 75
+	if len(line) <= max {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := len(string(r))
+		if width+n > max {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
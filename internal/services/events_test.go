@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestEventICS(t *testing.T) {
+	event := sqlc.Event{
+		ID:         7,
+		Title:      "Sensors, Live; Q&A",
+		Slug:       "sensors-live",
+		EventType:  services.EventWebinar,
+		Summary:    "Line one\nLine two with a very long tail that goes on and on so the folded line passes seventy-five octets",
+		StartsAt:   time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC),
+		EndsAt:     time.Date(2026, 3, 4, 16, 30, 0, 0, time.UTC),
+		WebinarUrl: "https://meet.example.com/secret",
+	}
+	ics := string(services.EventICS(event, "https://example.com/", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"UID:event-7@example.com\r\n",
+		"DTSTAMP:20260101T090000Z\r\n",
+		"DTSTART:20260304T150000Z\r\n",
+		"DTEND:20260304T163000Z\r\n",
+		`SUMMARY:Sensors\, Live\; Q&A` + "\r\n",
+		"LOCATION:Online\r\n",
+		"URL:https://example.com/events/sensors-live\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ICS lacks %q:\n%s", want, ics)
+		}
+	}
+	if strings.Contains(ics, "meet.example.com") {
+		t.Error("public ICS carries the webinar link")
+	}
+	if !strings.Contains(ics, `DESCRIPTION:Line one\nLine two`) {
+		t.Errorf("description not escaped:\n%s", ics)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
+
+func TestEvents_Register(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	mailer := &recordingMailer{sent: make(chan sentMail, 2)}
+	events := services.NewEvents(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), mailer, "https://example.com")
+
+	now := time.Now().UTC()
+	event, err := queries.CreateEvent(ctx, sqlc.CreateEventParams{
+		Title:            "Sensor Webinar",
+		Slug:             "sensor-webinar",
+		EventType:        services.EventWebinar,
+		StartsAt:         now.Add(24 * time.Hour),
+		EndsAt:           now.Add(25 * time.Hour),
+		WebinarUrl:       "https://meet.example.com/abc",
+		RegistrationOpen: 1,
+		Capacity:         1,
+		IsPublished:      1,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	reg := sqlc.CreateEventRegistrationParams{Name: "Jane", Email: "jane@acme.com", Company: "Acme"}
+
+	if err := events.Register(ctx, event, sqlc.CreateEventRegistrationParams{Name: "Jane", Email: "jane"}); !errors.Is(err, services.ErrEventInvalidEmail) {
+		t.Errorf("bad email: err = %v", err)
+	}
+	if err := events.Register(ctx, event, reg); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	mail := mailer.next(t)
+	if mail.to[0] != "jane@acme.com" || !strings.Contains(mail.body, "https://meet.example.com/abc") || !strings.Contains(mail.body, "https://example.com/events/sensor-webinar/calendar.ics") {
+		t.Errorf("confirmation = %+v", mail)
+	}
+
+	// Capacity is reached; a second address is turned away
+	other := reg
+	other.Email = "john@acme.com"
+	if err := events.Register(ctx, event, other); !errors.Is(err, services.ErrEventFull) {
+		t.Errorf("full event: err = %v", err)
+	}
+
+	event.Capacity = 0
+	event.RegistrationOpen = 0
+	if err := events.Register(ctx, event, other); !errors.Is(err, services.ErrEventRegistrationClosed) {
+		t.Errorf("closed event: err = %v", err)
+	}
+	event.RegistrationOpen = 1
+	event.EndsAt = now.Add(-time.Minute)
+	if err := events.Register(ctx, event, other); !errors.Is(err, services.ErrEventRegistrationClosed) {
+		t.Errorf("ended event: err = %v", err)
+	}
+
+	// The same address twice is one registration and one email
+	event.EndsAt = now.Add(25 * time.Hour)
+	if err := events.Register(ctx, event, reg); err != nil {
+		t.Fatalf("Register again: %v", err)
+	}
+	select {
+	case <-mailer.sent:
+		t.Error("second registration was emailed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if count, _ := queries.CountEventRegistrations(ctx, event.ID); count != 1 {
+		t.Errorf("registrations = %d, want 1", count)
+	}
+
+	upcoming, err := events.Upcoming(ctx, now)
+	if err != nil || len(upcoming) != 1 {
+		t.Errorf("Upcoming = %d, %v", len(upcoming), err)
+	}
+	if past, _ := events.Past(ctx, now.Add(26*time.Hour), 10); len(past) != 1 {
+		t.Errorf("Past after the end = %d events", len(past))
+	}
+}
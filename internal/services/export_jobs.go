@@ -0,0 +1,465 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Job cancellation
+	"database/sql" // sql.ErrNoRows from an empty queue
+	"encoding/csv" // CSV output
+	"errors"       // Sentinel errors for handlers
+	"fmt"          // File names, keys and notification text
+	"io"           // Streaming rows into storage
+	"log/slog"     // Structured logging for job progress and failures
+	"net/url"      // List filters stored with each job
+	"slices"       // Format checks
+	"strings"      // File name building
+	"time"         // Expiry and job scheduling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Export kinds, stored in export_jobs.kind. Each is registered with
+// ExportJobs.Register in main.go.
+const (
+	ExportProducts            = "products"             // Product catalog, as the CSV import reads it
+	ExportLeads               = "leads"                // Deduplicated leads
+	ExportWhitepaperDownloads = "whitepaper_downloads" // Gated whitepaper downloads (lead analytics)
+	ExportActivity            = "activity"             // Activity log entries
+	ExportSubscribers         = "subscribers"          // Newsletter subscribers
+)
+
+// Export formats, stored in export_jobs.format.
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+// Export job statuses, stored in export_jobs.status.
+const (
+	ExportQueued  = "queued"  // Waiting for the worker
+	ExportRunning = "running" // Rows are being written
+	ExportDone    = "done"    // File stored; downloadable until expires_at
+	ExportFailed  = "failed"  // Stopped with an error
+	ExportExpired = "expired" // File deleted after expires_at
+)
+
+// DefaultExportTTL is how long the download link of a finished export works.
+const DefaultExportTTL = 24 * time.Hour
+
+// exportProgressEvery is how many rows are written between progress updates.
+const exportProgressEvery = 500
+
+var (
+	// ErrUnknownExport is returned by Enqueue for kinds that are not registered.
+	ErrUnknownExport = errors.New("exports: unknown export")
+	// ErrExportFormat is returned by Enqueue for formats the kind does not offer.
+	ErrExportFormat = errors.New("exports: format not available for this export")
+	// ErrExportNotReady is returned by Open for jobs that have not finished.
+	ErrExportNotReady = errors.New("exports: export is not finished")
+	// ErrExportExpired is returned by Open once the download link has expired.
+	ErrExportExpired = errors.New("exports: download link has expired")
+)
+
+// ExportSource produces the rows of one kind of export.
+type ExportSource struct {
+	Label   string   // Shown on the exports page and in emails, e.g. "Products"
+	Formats []string // Formats offered, ExportCSV and/or ExportXLSX; the first is the default
+	// Count returns how many data rows Rows will write, for the progress bar.
+	// It may be nil when the total is not known up front.
+	Count func(ctx context.Context, params url.Values) (int64, error)
+	// Rows calls write with the header row and then once per data row. An
+	// error from write must stop it.
+	Rows func(ctx context.Context, params url.Values, write func([]string) error) error
+}
+
+// ExportStorage is where finished export files are kept. Unlike the archive,
+// files are deleted when their download link expires.
+type ExportStorage interface {
+	Storage
+	Delete(ctx context.Context, key string) error
+}
+
+// ExportJobs runs exports in the background, one at a time, so large exports
+// do not time out in the request. Jobs are queued in export_jobs, their file
+// is written to storage, and the requester is emailed a link to it. Download
+// links work for ttl; after that the file is deleted and the job expires.
+// Jobs interrupted by a restart are queued again on Start.
+type ExportJobs struct {
+	queries *sqlc.Queries           // Job queue and requester lookup
+	storage ExportStorage           // Finished files
+	logger  *slog.Logger            // Structured logger for job progress
+	mailer  Mailer                  // Ready notifications; nil disables email
+	baseURL string                  // Absolute links in emails
+	ttl     time.Duration           // Lifetime of download links
+	sources map[string]ExportSource // Registered kinds
+	wake    chan struct{}           // Signals the worker that a job was queued
+}
+
+// NewExportJobs creates the export queue. Register sources before Start.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc (the primary, since jobs are written)
+//   - storage: Where finished files are stored
+//   - logger: Structured logger for job progress and failures
+//   - mailer: Sends ready notifications; nil disables them
+//   - baseURL: Site URL for links in emails, e.g. "https://example.com"
+//   - ttl: Lifetime of download links (DefaultExportTTL when zero or negative)
+//
+// Returns:
+//   - *ExportJobs: Queue ready for sources
+func NewExportJobs(queries *sqlc.Queries, storage ExportStorage, logger *slog.Logger, mailer Mailer, baseURL string, ttl time.Duration) *ExportJobs {
+	if ttl <= 0 {
+		ttl = DefaultExportTTL
+	}
+	return &ExportJobs{
+		queries: queries,
+		storage: storage,
+		logger:  logger,
+		mailer:  mailer,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		ttl:     ttl,
+		sources: map[string]ExportSource{},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Register makes kind available to Enqueue.
+func (j *ExportJobs) Register(kind string, src ExportSource) {
+	j.sources[kind] = src
+}
+
+// Source returns the registered source of kind.
+func (j *ExportJobs) Source(kind string) (ExportSource, bool) {
+	src, ok := j.sources[kind]
+	return src, ok
+}
+
+// TTL returns how long download links work.
+func (j *ExportJobs) TTL() time.Duration {
+	return j.ttl
+}
+
+// Enqueue queues an export and wakes the worker.
+//
+// Parameters:
+//   - ctx: Request context
+//   - kind: Registered export kind, e.g. ExportProducts
+//   - format: ExportCSV or ExportXLSX; "" picks the source's default
+//   - params: List filters passed to the source, may be nil
+//   - userID: Requesting user, emailed when the export is ready (0 = none)
+//
+// Returns:
+//   - sqlc.ExportJob: The queued job
+//   - error: ErrUnknownExport, ErrExportFormat or a database error
+func (j *ExportJobs) Enqueue(ctx context.Context, kind, format string, params url.Values, userID int64) (sqlc.ExportJob, error) {
+	src, ok := j.sources[kind]
+	if !ok {
+		return sqlc.ExportJob{}, ErrUnknownExport
+	}
+	if format == "" {
+		format = src.Formats[0]
+	}
+	if !slices.Contains(src.Formats, format) {
+		return sqlc.ExportJob{}, ErrExportFormat
+	}
+	job, err := j.queries.CreateExportJob(ctx, sqlc.CreateExportJobParams{
+		Kind:   kind,
+		Format: format,
+		Params: params.Encode(),
+		UserID: sql.NullInt64{Int64: userID, Valid: userID > 0},
+	})
+	if err != nil {
+		return sqlc.ExportJob{}, err
+	}
+	select {
+	case j.wake <- struct{}{}:
+	default: // The worker is already due to look at the queue
+	}
+	return job, nil
+}
+
+// Start queues jobs left running by the previous process again, then runs
+// the worker in a background goroutine until ctx is cancelled. The worker
+// runs queued jobs as they arrive and deletes expired files every minute.
+//
+// Parameters:
+//   - ctx: Controls the lifetime of the worker
+func (j *ExportJobs) Start(ctx context.Context) {
+	if n, err := j.queries.RequeueRunningExportJobs(ctx); err != nil {
+		j.logger.Error("failed to requeue interrupted exports", "error", err)
+	} else if n > 0 {
+		j.logger.Info("requeued interrupted exports", "jobs", n)
+	}
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			for ctx.Err() == nil {
+				ran, err := j.RunNext(ctx)
+				if err != nil {
+					j.logger.Error("export queue failed", "error", err)
+				}
+				if !ran {
+					break
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-j.wake:
+			case <-ticker.C:
+				if _, err := j.PurgeExpired(ctx, time.Now()); err != nil {
+					j.logger.Error("export purge failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// RunNext runs the oldest queued job to completion. The outcome is recorded
+// on the job, so the error only reports queue failures.
+//
+// Returns:
+//   - bool: Whether a job was run (false when the queue is empty)
+//   - error: Database error claiming the job
+func (j *ExportJobs) RunNext(ctx context.Context) (bool, error) {
+	job, err := j.queries.ClaimNextExportJob(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	j.run(ctx, job)
+	return true, nil
+}
+
+// run writes the file of a claimed job to storage and records the outcome.
+// A job cut short by shutdown stays running and is queued again on restart.
+func (j *ExportJobs) run(ctx context.Context, job sqlc.ExportJob) {
+	src, ok := j.sources[job.Kind]
+	if !ok {
+		j.fail(ctx, job, ErrUnknownExport)
+		return
+	}
+	params, _ := url.ParseQuery(job.Params)
+	filename := fmt.Sprintf("%s-%s.%s", strings.ReplaceAll(job.Kind, "_", "-"), time.Now().UTC().Format("20060102-150405"), job.Format)
+	key := fmt.Sprintf("exports/%d/%s", job.ID, filename)
+
+	// Rows are written into a pipe that storage reads from, so the file never
+	// sits in memory
+	pr, pw := io.Pipe()
+	type result struct {
+		rows int64
+		err  error
+	}
+	written := make(chan result, 1)
+	go func() {
+		rows, err := j.write(ctx, job, src, params, pw)
+		pw.CloseWithError(err)
+		written <- result{rows, err}
+	}()
+	body := &countingReader{r: pr}
+	putErr := j.storage.Put(ctx, key, body)
+	pr.Close() // Unblocks the writer if storage stopped reading early
+	res := <-written
+
+	err := res.err
+	if err == nil {
+		err = putErr
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		j.fail(ctx, job, err)
+		return
+	}
+
+	expires := time.Now().Add(j.ttl).UTC()
+	if err := j.queries.CompleteExportJob(ctx, sqlc.CompleteExportJobParams{
+		RowsDone:   res.rows,
+		StorageKey: key,
+		Filename:   filename,
+		SizeBytes:  body.n,
+		ExpiresAt:  expires.Format(archiveTimeLayout),
+		ID:         job.ID,
+	}); err != nil {
+		j.logger.Error("failed to complete export", "job", job.ID, "error", err)
+		return
+	}
+	j.logger.Info("export finished", "job", job.ID, "kind", job.Kind, "rows", res.rows, "bytes", body.n)
+	job.Status, job.RowsDone, job.ExpiresAt = ExportDone, res.rows, sql.NullTime{Time: expires, Valid: true}
+	j.notify(ctx, job, src)
+}
+
+// write formats the rows of src into w and keeps the job's progress up to
+// date. It returns the number of data rows written.
+func (j *ExportJobs) write(ctx context.Context, job sqlc.ExportJob, src ExportSource, params url.Values, w io.Writer) (int64, error) {
+	var total int64
+	if src.Count != nil {
+		n, err := src.Count(ctx, params)
+		if err != nil {
+			j.logger.Warn("failed to count export rows", "job", job.ID, "error", err)
+		}
+		total = n
+	}
+	progress := func(done int64) {
+		if err := j.queries.UpdateExportJobProgress(ctx, sqlc.UpdateExportJobProgressParams{RowsDone: done, RowsTotal: total, ID: job.ID}); err != nil {
+			j.logger.Warn("failed to record export progress", "job", job.ID, "error", err)
+		}
+	}
+	progress(0)
+
+	var writeRow func([]string) error
+	var finish func() error
+	if job.Format == ExportXLSX {
+		x, err := NewXLSXWriter(w, src.Label)
+		if err != nil {
+			return 0, err
+		}
+		writeRow, finish = x.Write, x.Close
+	} else {
+		cw := csv.NewWriter(w)
+		writeRow = func(row []string) error {
+			for i := range row {
+				row[i] = CSVSafe(row[i])
+			}
+			return cw.Write(row)
+		}
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	}
+
+	var rows int64 = -1 // The first row is the header
+	err := src.Rows(ctx, params, func(row []string) error {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+		rows++
+		if rows > 0 && rows%exportProgressEvery == 0 {
+			progress(rows)
+		}
+		return nil
+	})
+	rows = max(rows, 0)
+	if err != nil {
+		return rows, err
+	}
+	return rows, finish()
+}
+
+// fail records why a job stopped and tells the requester.
+func (j *ExportJobs) fail(ctx context.Context, job sqlc.ExportJob, cause error) {
+	j.logger.Error("export failed", "job", job.ID, "kind", job.Kind, "error", cause)
+	if err := j.queries.FailExportJob(ctx, sqlc.FailExportJobParams{Error: cause.Error(), ID: job.ID}); err != nil {
+		j.logger.Error("failed to record export failure", "job", job.ID, "error", err)
+		return
+	}
+	job.Status = ExportFailed
+	j.notify(ctx, job, j.sources[job.Kind])
+}
+
+// notify emails the requester that a job finished. Delivery failures are
+// logged; the export page shows the outcome either way.
+func (j *ExportJobs) notify(ctx context.Context, job sqlc.ExportJob, src ExportSource) {
+	if j.mailer == nil || !job.UserID.Valid {
+		return
+	}
+	users, err := j.queries.ListAdminUsers(ctx)
+	if err != nil {
+		j.logger.Error("failed to look up export requester", "job", job.ID, "error", err)
+		return
+	}
+	var to string
+	for _, u := range users {
+		if u.ID == job.UserID.Int64 && u.IsActive == 1 {
+			to = u.Email
+		}
+	}
+	if to == "" {
+		return
+	}
+
+	label := src.Label
+	if label == "" {
+		label = job.Kind
+	}
+	subject := "Your " + label + " export is ready"
+	body := fmt.Sprintf("The %s export (%d rows) is ready. The download link works until %s.",
+		label, job.RowsDone, InSiteTimezone(job.ExpiresAt.Time).Format("Jan 2, 2006 15:04 MST"))
+	if job.Status == ExportFailed {
+		subject = "Your " + label + " export failed"
+		body = fmt.Sprintf("The %s export could not be completed. Try again from the admin, or contact your administrator if it keeps failing.", label)
+	}
+	body += fmt.Sprintf("\n\nOpen it in the admin: %s/admin/exports/%d", j.baseURL, job.ID)
+
+	sendCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := j.mailer.Send(sendCtx, []string{to}, subject, body); err != nil {
+		j.logger.Error("failed to send export notification", "job", job.ID, "error", err)
+	}
+}
+
+// Open returns the file of a finished job.
+//
+// Returns:
+//   - io.ReadCloser: File contents (caller must Close)
+//   - error: ErrExportNotReady, ErrExportExpired, or a storage error
+func (j *ExportJobs) Open(ctx context.Context, job sqlc.ExportJob, now time.Time) (io.ReadCloser, error) {
+	switch {
+	case job.Status == ExportExpired, job.Status == ExportDone && job.ExpiresAt.Valid && !now.Before(job.ExpiresAt.Time):
+		return nil, ErrExportExpired
+	case job.Status != ExportDone:
+		return nil, ErrExportNotReady
+	}
+	return j.storage.Open(ctx, job.StorageKey)
+}
+
+// PurgeExpired deletes the files of jobs whose download link expired before
+// now and marks the jobs expired.
+//
+// Returns:
+//   - int: Jobs expired
+//   - error: First storage or database error; earlier jobs stay expired
+func (j *ExportJobs) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	jobs, err := j.queries.ListExpiredExportJobs(ctx, now.UTC().Format(archiveTimeLayout))
+	if err != nil {
+		return 0, err
+	}
+	for i, job := range jobs {
+		if err := j.storage.Delete(ctx, job.StorageKey); err != nil {
+			return i, err
+		}
+		if err := j.queries.ExpireExportJob(ctx, job.ID); err != nil {
+			return i, err
+		}
+	}
+	if len(jobs) > 0 {
+		j.logger.Info("expired export downloads", "jobs", len(jobs))
+	}
+	return len(jobs), nil
+}
+
+// CSVSafe neutralizes spreadsheet formulas: cells starting with =, +, - or @
+// are prefixed with a quote so they open as text (CSV injection).
+func CSVSafe(s string) string {
+	if s != "" && (s[0] == '=' || s[0] == '+' || s[0] == '-' || s[0] == '@') {
+		return "'" + s
+	}
+	return s
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestExportJobs_RunsQueuedJobAndExpiresFile(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	jobs := services.NewExportJobs(queries, services.NewLocalStorage(t.TempDir()),
+		slog.New(slog.NewTextHandler(io.Discard, nil)), nil, "http://localhost", time.Hour)
+	jobs.Register("numbers", services.ExportSource{
+		Label:   "Numbers",
+		Formats: []string{services.ExportCSV},
+		Count:   func(context.Context, url.Values) (int64, error) { return 2, nil },
+		Rows: func(ctx context.Context, params url.Values, write func([]string) error) error {
+			for _, row := range [][]string{{"n", "label"}, {"1", params.Get("label")}, {"2", "=SUM(A1)"}} {
+				if err := write(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	if _, err := jobs.Enqueue(ctx, "missing", "", nil, 0); !errors.Is(err, services.ErrUnknownExport) {
+		t.Errorf("unknown kind: got %v", err)
+	}
+	if _, err := jobs.Enqueue(ctx, "numbers", services.ExportXLSX, nil, 0); !errors.Is(err, services.ErrExportFormat) {
+		t.Errorf("unsupported format: got %v", err)
+	}
+
+	job, err := jobs.Enqueue(ctx, "numbers", "", url.Values{"label": {"one"}}, 0)
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if job.Status != services.ExportQueued || job.Format != services.ExportCSV {
+		t.Fatalf("unexpected queued job: %+v", job)
+	}
+	if _, err := jobs.Open(ctx, job, time.Now()); !errors.Is(err, services.ErrExportNotReady) {
+		t.Errorf("queued job should not be ready, got %v", err)
+	}
+
+	if ran, err := jobs.RunNext(ctx); !ran || err != nil {
+		t.Fatalf("RunNext = %v, %v", ran, err)
+	}
+	if ran, _ := jobs.RunNext(ctx); ran {
+		t.Error("queue should be empty after the only job ran")
+	}
+
+	job, _ = queries.GetExportJob(ctx, job.ID)
+	if job.Status != services.ExportDone || job.RowsDone != 2 || job.RowsTotal != 2 || !job.ExpiresAt.Valid {
+		t.Fatalf("unexpected finished job: %+v", job)
+	}
+	if !strings.HasPrefix(job.Filename, "numbers-") || !strings.HasSuffix(job.Filename, ".csv") {
+		t.Errorf("unexpected filename %q", job.Filename)
+	}
+	f, err := jobs.Open(ctx, job, time.Now())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	f.Close()
+	if want := "n,label\n1,one\n2,'=SUM(A1)\n"; string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+	if job.SizeBytes != int64(len(data)) {
+		t.Errorf("size = %d, want %d", job.SizeBytes, len(data))
+	}
+
+	// After the link expires the file is gone and the job is marked expired
+	later := time.Now().Add(2 * time.Hour)
+	if _, err := jobs.Open(ctx, job, later); !errors.Is(err, services.ErrExportExpired) {
+		t.Errorf("expired link: got %v", err)
+	}
+	if n, err := jobs.PurgeExpired(ctx, later); n != 1 || err != nil {
+		t.Fatalf("PurgeExpired = %d, %v", n, err)
+	}
+	job, _ = queries.GetExportJob(ctx, job.ID)
+	if job.Status != services.ExportExpired {
+		t.Errorf("status after purge = %q", job.Status)
+	}
+}
+
+func TestExportJobs_RecordsSourceFailure(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	jobs := services.NewExportJobs(queries, services.NewLocalStorage(t.TempDir()),
+		slog.New(slog.NewTextHandler(io.Discard, nil)), nil, "http://localhost", time.Hour)
+	jobs.Register("broken", services.ExportSource{
+		Label:   "Broken",
+		Formats: []string{services.ExportCSV},
+		Rows: func(ctx context.Context, _ url.Values, write func([]string) error) error {
+			return errors.New("source unavailable")
+		},
+	})
+
+	job, _ := jobs.Enqueue(ctx, "broken", "", nil, 0)
+	jobs.RunNext(ctx)
+	job, _ = queries.GetExportJob(ctx, job.ID)
+	if job.Status != services.ExportFailed || job.Error != "source unavailable" {
+		t.Errorf("unexpected failed job: status=%q error=%q", job.Status, job.Error)
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Source cancellation between batches
+	"database/sql" // Optional timestamp cells
+	"net/url"      // List filters of each job
+	"strconv"      // Numeric cells and filter parsing
+	"strings"      // Joining multi-value cells
+	"time"         // Timestamp cells and date filters
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// exportSourceBatch is how many rows the export sources read per query.
+const exportSourceBatch = 500
+
+// exportDateLayout is the format of date filters (HTML date inputs).
+const exportDateLayout = "2006-01-02"
+
+// ProductExportSource exports every product with the columns of
+// ProductExporter, which the CSV import reads back. It has no filters.
+//
+// Parameters:
+//   - queries: Query interface to read from, typically the reporting pool
+func ProductExportSource(queries *sqlc.Queries) ExportSource {
+	return ExportSource{
+		Label:   "Products",
+		Formats: []string{ExportCSV, ExportXLSX},
+		Count: func(ctx context.Context, _ url.Values) (int64, error) {
+			return queries.CountProductsAdminFiltered(ctx, sqlc.CountProductsAdminFilteredParams{
+				FilterStatus: "", FilterCategory: int64(0), FilterSearch: "",
+			})
+		},
+		Rows: func(ctx context.Context, _ url.Values, write func([]string) error) error {
+			_, err := NewProductExporter(queries).Export(ctx, write, func() {})
+			return err
+		},
+	}
+}
+
+// LeadExportSource exports the deduplicated leads of the Leads view, most
+// recently active first, one row per person. It has no filters.
+//
+// Parameters:
+//   - leads: Lead service to read from
+func LeadExportSource(leads *LeadService) ExportSource {
+	return ExportSource{
+		Label:   "Leads",
+		Formats: []string{ExportCSV, ExportXLSX},
+		Rows: func(ctx context.Context, _ url.Values, write func([]string) error) error {
+			list, err := leads.Leads(ctx)
+			if err != nil {
+				return err
+			}
+			if err := write([]string{"email", "name", "company", "other_emails", "contacts", "rfqs", "whitepaper_downloads", "product_downloads", "event_registrations", "last_seen"}); err != nil {
+				return err
+			}
+			for _, l := range list {
+				var others []string
+				for _, e := range l.Emails {
+					if NormalizeEmail(e) != l.Key {
+						others = append(others, e)
+					}
+				}
+				if err := write([]string{
+					l.Key, l.Name, l.Company, strings.Join(others, " | "),
+					strconv.Itoa(l.Contacts), strconv.Itoa(l.RFQs), strconv.Itoa(l.Downloads), strconv.Itoa(l.ProductFiles), strconv.Itoa(l.Events),
+					l.LastSeen.UTC().Format(time.RFC3339),
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// WhitepaperDownloadExportSource exports gated whitepaper downloads, newest
+// first, with the filters of the downloads page.
+//
+// Parameters (job params):
+//   - whitepaper: Whitepaper ID (empty = all)
+//   - date_from, date_to: Inclusive days, YYYY-MM-DD (empty = unbounded)
+func WhitepaperDownloadExportSource(queries *sqlc.Queries) ExportSource {
+	filters := func(params url.Values) (whitepaper int64, from, to string) {
+		whitepaper, _ = strconv.ParseInt(params.Get("whitepaper"), 10, 64)
+		if _, err := time.Parse(exportDateLayout, params.Get("date_from")); err == nil {
+			from = params.Get("date_from")
+		}
+		if _, err := time.Parse(exportDateLayout, params.Get("date_to")); err == nil {
+			to = params.Get("date_to")
+		}
+		return whitepaper, from, to
+	}
+	return ExportSource{
+		Label:   "Whitepaper Downloads",
+		Formats: []string{ExportCSV, ExportXLSX},
+		Count: func(ctx context.Context, params url.Values) (int64, error) {
+			whitepaper, from, to := filters(params)
+			return queries.CountWhitepaperDownloadsFiltered(ctx, sqlc.CountWhitepaperDownloadsFilteredParams{
+				FilterWhitepaper: whitepaper, FilterDateFrom: from, FilterDateTo: to,
+			})
+		},
+		Rows: func(ctx context.Context, params url.Values, write func([]string) error) error {
+			whitepaper, from, to := filters(params)
+			if err := write([]string{"id", "timestamp", "whitepaper", "name", "email", "company", "designation", "marketing_consent"}); err != nil {
+				return err
+			}
+			for offset := int64(0); ; offset += exportSourceBatch {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				batch, err := queries.ListWhitepaperDownloadsFiltered(ctx, sqlc.ListWhitepaperDownloadsFilteredParams{
+					FilterWhitepaper: whitepaper, FilterDateFrom: from, FilterDateTo: to,
+					PageLimit: exportSourceBatch, PageOffset: offset,
+				})
+				if err != nil {
+					return err
+				}
+				for _, d := range batch {
+					consent := "no"
+					if d.MarketingConsent == 1 {
+						consent = "yes"
+					}
+					if err := write([]string{
+						strconv.FormatInt(d.ID, 10), d.CreatedAt.UTC().Format(time.RFC3339), d.WhitepaperTitle,
+						d.Name, d.Email, d.Company, d.Designation.String, consent,
+					}); err != nil {
+						return err
+					}
+				}
+				if len(batch) < exportSourceBatch {
+					return nil
+				}
+			}
+		},
+	}
+}
+
+// NewsletterSubscriberExportSource exports newsletter subscribers, newest
+// first, with their unsubscribe links for the mailing tool, using the
+// filters of the Subscribers page.
+//
+// Parameters (job params):
+//   - status: pending, confirmed or unsubscribed (empty = all)
+//   - q: Part of the address (empty = all)
+func NewsletterSubscriberExportSource(queries *sqlc.Queries, newsletter *Newsletter) ExportSource {
+	return ExportSource{
+		Label:   "Newsletter Subscribers",
+		Formats: []string{ExportCSV, ExportXLSX},
+		Count: func(ctx context.Context, params url.Values) (int64, error) {
+			return queries.CountNewsletterSubscribers(ctx, sqlc.CountNewsletterSubscribersParams{
+				FilterStatus: params.Get("status"), FilterSearch: params.Get("q"),
+			})
+		},
+		Rows: func(ctx context.Context, params url.Values, write func([]string) error) error {
+			if err := write([]string{"email", "status", "signed_up", "confirmed_at", "unsubscribed_at", "source", "unsubscribe_url"}); err != nil {
+				return err
+			}
+			optional := func(t sql.NullTime) string {
+				if !t.Valid {
+					return ""
+				}
+				return t.Time.UTC().Format(time.RFC3339)
+			}
+			for offset := int64(0); ; offset += exportSourceBatch {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				batch, err := queries.ListNewsletterSubscribers(ctx, sqlc.ListNewsletterSubscribersParams{
+					FilterStatus: params.Get("status"), FilterSearch: params.Get("q"),
+					PageLimit: exportSourceBatch, PageOffset: offset,
+				})
+				if err != nil {
+					return err
+				}
+				for _, s := range batch {
+					if err := write([]string{
+						s.Email, s.Status, s.CreatedAt.UTC().Format(time.RFC3339),
+						optional(s.ConfirmedAt), optional(s.UnsubscribedAt), s.Source, newsletter.UnsubscribeURL(s),
+					}); err != nil {
+						return err
+					}
+				}
+				if len(batch) < exportSourceBatch {
+					return nil
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,400 @@
+package services
+
+import (
+	// Standard library imports
+	"context"       // Request cancellation and background notification timeouts
+	"database/sql"  // sql.ErrNoRows for inactive forms
+	"encoding/json" // Submission answers stored as JSON
+	"errors"        // Form validation errors
+	"fmt"           // Notification text and length messages
+	"log/slog"      // Structured logging of notification failures
+	"net/mail"      // Email address validation
+	"strconv"       // Number answers
+	"strings"       // Trimming, option and address lists
+	"time"          // Notification timeout
+	"unicode/utf8"  // Answer lengths in characters
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"         // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/models" // Form view model rendered by the templates
+)
+
+// Form field types (form_fields.field_type).
+const (
+	FieldText     = "text"     // Single line of text
+	FieldEmail    = "email"    // Email address
+	FieldTel      = "tel"      // Phone number
+	FieldNumber   = "number"   // Whole or decimal number
+	FieldTextarea = "textarea" // Several lines of text
+	FieldSelect   = "select"   // One of a list of options
+	FieldCheckbox = "checkbox" // Yes or no, e.g. a consent box
+)
+
+// FormFieldType is a kind of field the admin can add to a form.
+type FormFieldType struct {
+	Type  string // form_fields.field_type
+	Label string // Name shown under Admin > Forms
+}
+
+// FormFieldTypes lists the field types in the order the admin form offers
+// them.
+var FormFieldTypes = []FormFieldType{
+	{FieldText, "Text"},
+	{FieldEmail, "Email"},
+	{FieldTel, "Phone"},
+	{FieldNumber, "Number"},
+	{FieldTextarea, "Paragraph"},
+	{FieldSelect, "Dropdown"},
+	{FieldCheckbox, "Checkbox"},
+}
+
+// FormFieldLabel returns the display name of a field type, or t itself when
+// it is unknown.
+func FormFieldLabel(t string) string {
+	for _, ft := range FormFieldTypes {
+		if ft.Type == t {
+			return ft.Label
+		}
+	}
+	return t
+}
+
+// Defaults for the form settings left empty in the admin.
+const (
+	defaultSubmitLabel    = "Submit"
+	defaultSuccessMessage = "Thank you. We will be in touch shortly."
+)
+
+// maxFormAnswer caps answers of fields without a maximum length, so a
+// single submission cannot fill the database.
+const maxFormAnswer = 5000
+
+// Form validation errors returned by ValidateForm and ValidateFormField.
+var (
+	ErrFormName     = errors.New("forms: the form needs a name")
+	ErrFormSlug     = errors.New("forms: the slug needs letters or digits")
+	ErrFormEmails   = errors.New("forms: notification addresses must be valid email addresses")
+	ErrFieldLabel   = errors.New("forms: the field needs a label")
+	ErrFieldName    = errors.New("forms: the field key needs letters or digits")
+	ErrFieldType    = errors.New("forms: unknown field type")
+	ErrFieldOptions = errors.New("forms: a dropdown needs at least one option")
+	ErrFieldLength  = errors.New("forms: the minimum length must not exceed the maximum")
+)
+
+// ValidateForm checks a form's settings from the admin and returns them
+// trimmed, with the slug normalised (an empty one is derived from the name),
+// the notification addresses normalised to a comma-separated list and empty
+// labels replaced by their defaults.
+func ValidateForm(f sqlc.CreateFormParams) (sqlc.CreateFormParams, error) {
+	f.Name = strings.TrimSpace(f.Name)
+	f.Description = strings.TrimSpace(f.Description)
+	f.SubmitLabel = strings.TrimSpace(f.SubmitLabel)
+	f.SuccessMessage = strings.TrimSpace(f.SuccessMessage)
+	if f.SubmitLabel == "" {
+		f.SubmitLabel = defaultSubmitLabel
+	}
+	if f.SuccessMessage == "" {
+		f.SuccessMessage = defaultSuccessMessage
+	}
+	if f.Name == "" {
+		return f, ErrFormName
+	}
+	if strings.TrimSpace(f.Slug) == "" {
+		f.Slug = f.Name
+	}
+	if f.Slug = Slugify(f.Slug); f.Slug == "" {
+		return f, ErrFormSlug
+	}
+	emails := FormNotifyEmails(f.NotifyEmails)
+	f.NotifyEmails = strings.Join(emails, ", ")
+	for _, e := range emails {
+		if !isEmailAddress(e) {
+			return f, ErrFormEmails
+		}
+	}
+	return f, nil
+}
+
+// FormNotifyEmails splits stored or entered notification addresses, which
+// may be separated by commas, semicolons, spaces or line breaks.
+func FormNotifyEmails(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	})
+}
+
+// ValidateFormField checks a field from the admin and returns it trimmed,
+// with its key normalised (an empty one is derived from the label), its
+// dropdown options reduced to one per line and the settings its type does
+// not use cleared. Lengths apply to text answers only.
+func ValidateFormField(f sqlc.CreateFormFieldParams) (sqlc.CreateFormFieldParams, error) {
+	f.Label = strings.TrimSpace(f.Label)
+	f.Placeholder = strings.TrimSpace(f.Placeholder)
+	f.HelpText = strings.TrimSpace(f.HelpText)
+	if f.Label == "" {
+		return f, ErrFieldLabel
+	}
+	if strings.TrimSpace(f.Name) == "" {
+		f.Name = f.Label
+	}
+	if f.Name = strings.ReplaceAll(Slugify(f.Name), "-", "_"); f.Name == "" {
+		return f, ErrFieldName
+	}
+	if FormFieldLabel(f.FieldType) == f.FieldType {
+		return f, ErrFieldType
+	}
+
+	var options []string
+	if f.FieldType == FieldSelect {
+		options = formOptions(f.Options)
+		if len(options) == 0 {
+			return f, ErrFieldOptions
+		}
+	}
+	f.Options = strings.Join(options, "\n")
+	switch f.FieldType {
+	case FieldSelect, FieldCheckbox, FieldNumber:
+		f.MinLength, f.MaxLength = 0, 0
+		if f.FieldType != FieldNumber {
+			f.Placeholder = ""
+		}
+	}
+	if f.MinLength < 0 || f.MaxLength < 0 || f.MaxLength > 0 && f.MinLength > f.MaxLength {
+		return f, ErrFieldLength
+	}
+	return f, nil
+}
+
+// formOptions splits stored dropdown options into their non-empty, trimmed
+// lines.
+func formOptions(s string) []string {
+	var options []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			options = append(options, line)
+		}
+	}
+	return options
+}
+
+// BuildForm converts a stored form and its fields into the form rendered by
+// the "custom-form" partial.
+func BuildForm(form sqlc.Form, fields []sqlc.FormField) models.Form {
+	out := models.Form{
+		Slug:        form.Slug,
+		Name:        form.Name,
+		Description: form.Description,
+		SubmitLabel: form.SubmitLabel,
+	}
+	for _, f := range fields {
+		out.Fields = append(out.Fields, models.FormField{
+			Name:        f.Name,
+			Label:       f.Label,
+			Type:        f.FieldType,
+			Placeholder: f.Placeholder,
+			HelpText:    f.HelpText,
+			Options:     formOptions(f.Options),
+			Required:    f.Required,
+			MinLength:   f.MinLength,
+			MaxLength:   f.MaxLength,
+		})
+	}
+	return out
+}
+
+// CheckFormSubmission reads the answer to each field of form with value and
+// checks it against the field's rules. It returns the form with the answers
+// and their errors filled in, for showing it again, and the answers to
+// store. ok is false when any answer was refused.
+//
+// A ticked checkbox is answered "Yes" and an unticked one "No"; a required
+// checkbox must be ticked.
+func CheckFormSubmission(form models.Form, value func(name string) string) (checked models.Form, answers []models.FormAnswer, ok bool) {
+	checked = form
+	checked.Fields = make([]models.FormField, len(form.Fields))
+	ok = true
+	for i, f := range form.Fields {
+		v := strings.TrimSpace(value(f.Name))
+		if f.Type == FieldCheckbox {
+			if v != "" {
+				v = "Yes"
+			}
+		} else if f.Type != FieldTextarea {
+			v = strings.Join(strings.Fields(v), " ")
+		}
+		f.Value = v
+		f.Error = formAnswerError(f, v)
+		if f.Error != "" {
+			ok = false
+		}
+		checked.Fields[i] = f
+
+		if f.Type == FieldCheckbox && v == "" {
+			v = "No"
+		}
+		answers = append(answers, models.FormAnswer{Name: f.Name, Label: f.Label, Type: f.Type, Value: v})
+	}
+	return checked, answers, ok
+}
+
+// formAnswerError explains why v is not a valid answer to f, or returns "".
+func formAnswerError(f models.FormField, v string) string {
+	if v == "" {
+		if f.Required {
+			return "This field is required."
+		}
+		return ""
+	}
+	n := int64(utf8.RuneCountInString(v))
+	switch {
+	case f.MinLength > 0 && n < f.MinLength:
+		return fmt.Sprintf("Enter at least %d characters.", f.MinLength)
+	case f.MaxLength > 0 && n > f.MaxLength:
+		return fmt.Sprintf("Enter at most %d characters.", f.MaxLength)
+	case n > maxFormAnswer:
+		return fmt.Sprintf("Enter at most %d characters.", maxFormAnswer)
+	}
+	switch f.Type {
+	case FieldEmail:
+		if !isEmailAddress(v) {
+			return "Enter a valid email address."
+		}
+	case FieldTel:
+		digits := 0
+		for _, r := range v {
+			switch {
+			case r >= '0' && r <= '9':
+				digits++
+			case !strings.ContainsRune("+-(). ", r):
+				return "Enter a valid phone number."
+			}
+		}
+		if digits < 6 {
+			return "Enter a valid phone number."
+		}
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "Enter a number."
+		}
+	case FieldSelect:
+		for _, o := range f.Options {
+			if o == v {
+				return ""
+			}
+		}
+		return "Choose one of the options."
+	}
+	return ""
+}
+
+// isEmailAddress reports whether s is a bare email address such as
+// ana@example.com.
+func isEmailAddress(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s && strings.Contains(s[strings.LastIndex(s, "@"):], ".")
+}
+
+// DecodeFormAnswers reads the answers of a stored submission; unreadable
+// data gives no answers.
+func DecodeFormAnswers(data string) []models.FormAnswer {
+	var answers []models.FormAnswer
+	if err := json.Unmarshal([]byte(data), &answers); err != nil {
+		return nil
+	}
+	return answers
+}
+
+// Forms loads the forms visitors can submit, stores their submissions and
+// notifies the form's addresses of each one.
+type Forms struct {
+	queries *sqlc.Queries // Form storage
+	logger  *slog.Logger  // Structured logger for notification failures
+	mailer  Mailer        // Notification delivery; nil disables email
+	baseURL string        // Site URL for admin links in notifications
+}
+
+// NewForms creates the form service.
+//
+// Parameters:
+//   - queries: Database queries generated by sqlc
+//   - logger: Structured logger for notification failures
+//   - mailer: Notification delivery; nil sends no email
+//   - baseURL: Site URL, e.g. "https://example.com", for admin links
+func NewForms(queries *sqlc.Queries, logger *slog.Logger, mailer Mailer, baseURL string) *Forms {
+	return &Forms{queries: queries, logger: logger, mailer: mailer, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Active returns the active form with slug and its fields ready to render.
+// An unknown or inactive form returns sql.ErrNoRows.
+func (f *Forms) Active(ctx context.Context, slug string) (sqlc.Form, models.Form, error) {
+	form, err := f.queries.GetFormBySlug(ctx, slug)
+	if err == nil && !form.IsActive {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		return form, models.Form{}, err
+	}
+	fields, err := f.queries.ListFormFields(ctx, form.ID)
+	if err != nil {
+		return form, models.Form{}, err
+	}
+	return form, BuildForm(form, fields), nil
+}
+
+// Submit stores answers as a submission of form and emails them to the
+// form's notification addresses in the background. Delivery failures are
+// logged; they never fail the submission.
+//
+// Parameters:
+//   - form: Form that was submitted
+//   - answers: Checked answers from CheckFormSubmission; the first email
+//     answer is kept apart for finding the submission
+//   - ip, userAgent: Visitor details kept with the submission
+func (f *Forms) Submit(ctx context.Context, form sqlc.Form, answers []models.FormAnswer, ip, userAgent string) (sqlc.FormSubmission, error) {
+	data, err := json.Marshal(answers)
+	if err != nil {
+		return sqlc.FormSubmission{}, err
+	}
+	email := ""
+	for _, a := range answers {
+		if a.Type == FieldEmail && a.Value != "" {
+			email = a.Value
+			break
+		}
+	}
+	sub, err := f.queries.CreateFormSubmission(ctx, sqlc.CreateFormSubmissionParams{
+		FormID:    form.ID,
+		Data:      string(data),
+		Email:     email,
+		IpAddress: ip,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return sub, err
+	}
+	f.notify(form, answers)
+	return sub, nil
+}
+
+// notify emails a submission to the form's notification addresses in the
+// background.
+func (f *Forms) notify(form sqlc.Form, answers []models.FormAnswer) {
+	to := FormNotifyEmails(form.NotifyEmails)
+	if f.mailer == nil || len(to) == 0 {
+		return
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "New submission of the form %q:\n", form.Name)
+	for _, a := range answers {
+		fmt.Fprintf(&body, "\n%s: %s", a.Label, a.Value)
+	}
+	fmt.Fprintf(&body, "\n\nSee all submissions in the admin: %s/admin/forms/%d/submissions", f.baseURL, form.ID)
+	subject := "New form submission: " + form.Name
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := f.mailer.Send(ctx, to, subject, body.String()); err != nil {
+			f.logger.Error("failed to send form notification", "form", form.ID, "error", err)
+		}
+	}()
+}
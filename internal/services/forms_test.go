@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestValidateForm(t *testing.T) {
+	got, err := services.ValidateForm(sqlc.CreateFormParams{Name: " Demo Request ", NotifyEmails: "sales@example.com;  ops@example.com\n"})
+	if err != nil || got.Slug != "demo-request" || got.NotifyEmails != "sales@example.com, ops@example.com" {
+		t.Errorf("ValidateForm = %+v, %v", got, err)
+	}
+	if got.SubmitLabel != "Submit" || got.SuccessMessage == "" {
+		t.Errorf("defaults not applied: %+v", got)
+	}
+
+	bad := map[error]sqlc.CreateFormParams{
+		services.ErrFormName:   {Name: " "},
+		services.ErrFormSlug:   {Name: "X", Slug: "!!"},
+		services.ErrFormEmails: {Name: "X", NotifyEmails: "sales@example.com, Sales <sales@example.com>"},
+	}
+	for want, p := range bad {
+		if _, err := services.ValidateForm(p); !errors.Is(err, want) {
+			t.Errorf("ValidateForm(%+v) = %v, want %v", p, err, want)
+		}
+	}
+}
+
+func TestValidateFormField(t *testing.T) {
+	got, err := services.ValidateFormField(sqlc.CreateFormFieldParams{Label: "Company Size", FieldType: services.FieldSelect, Options: " 1-10 \n\n11-50\n", Placeholder: "Pick", MaxLength: 20})
+	if err != nil || got.Name != "company_size" || got.Options != "1-10\n11-50" || got.Placeholder != "" || got.MaxLength != 0 {
+		t.Errorf("select field: %+v, %v", got, err)
+	}
+	got, err = services.ValidateFormField(sqlc.CreateFormFieldParams{Label: "Notes", FieldType: services.FieldTextarea, Options: "x", MinLength: 5, MaxLength: 500})
+	if err != nil || got.Options != "" || got.MinLength != 5 {
+		t.Errorf("textarea field: %+v, %v", got, err)
+	}
+
+	bad := map[error]sqlc.CreateFormFieldParams{
+		services.ErrFieldLabel:   {FieldType: services.FieldText},
+		services.ErrFieldName:    {Label: "X", Name: "!!", FieldType: services.FieldText},
+		services.ErrFieldType:    {Label: "X", FieldType: "date"},
+		services.ErrFieldOptions: {Label: "X", FieldType: services.FieldSelect, Options: "\n "},
+		services.ErrFieldLength:  {Label: "X", FieldType: services.FieldText, MinLength: 10, MaxLength: 5},
+	}
+	for want, p := range bad {
+		if _, err := services.ValidateFormField(p); !errors.Is(err, want) {
+			t.Errorf("ValidateFormField(%+v) = %v, want %v", p, err, want)
+		}
+	}
+}
+
+func TestCheckFormSubmission(t *testing.T) {
+	form := services.BuildForm(sqlc.Form{Slug: "demo"}, []sqlc.FormField{
+		{Name: "name", Label: "Name", FieldType: services.FieldText, Required: true, MinLength: 2, MaxLength: 10},
+		{Name: "email", Label: "Email", FieldType: services.FieldEmail, Required: true},
+		{Name: "phone", Label: "Phone", FieldType: services.FieldTel},
+		{Name: "seats", Label: "Seats", FieldType: services.FieldNumber},
+		{Name: "size", Label: "Size", FieldType: services.FieldSelect, Options: "Small\nLarge"},
+		{Name: "consent", Label: "I agree", FieldType: services.FieldCheckbox, Required: true},
+		{Name: "news", Label: "Newsletter", FieldType: services.FieldCheckbox},
+	})
+	answers := func(values map[string]string) func(string) string {
+		return func(name string) string { return values[name] }
+	}
+
+	checked, _, ok := services.CheckFormSubmission(form, answers(map[string]string{
+		"name": "A", "email": "not-an-email", "phone": "12ab", "seats": "many", "size": "Medium",
+	}))
+	if ok {
+		t.Fatal("invalid answers accepted")
+	}
+	want := []string{"at least 2", "valid email", "valid phone", "number", "one of the options", "required", ""}
+	for i, f := range checked.Fields {
+		if want[i] == "" && f.Error != "" || !strings.Contains(f.Error, want[i]) {
+			t.Errorf("%s: error %q, want %q", f.Name, f.Error, want[i])
+		}
+	}
+	if checked.Fields[0].Value != "A" || form.Fields[0].Value != "" {
+		t.Error("answers should fill the checked copy only")
+	}
+
+	_, got, ok := services.CheckFormSubmission(form, answers(map[string]string{
+		"name": " Ana   Ruiz ", "email": "ana@example.com", "phone": "+1 (555) 010-0000", "seats": "2.5", "size": "Large", "consent": "on",
+	}))
+	if !ok {
+		t.Fatal("valid answers refused")
+	}
+	if got[0].Value != "Ana Ruiz" || got[5].Value != "Yes" || got[6].Value != "No" || got[1].Type != services.FieldEmail {
+		t.Errorf("answers = %+v", got)
+	}
+}
+
+func TestForms_SubmitStoresAndNotifies(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	mailer := &recordingMailer{sent: make(chan sentMail, 1)}
+	forms := services.NewForms(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), mailer, "https://example.com/")
+
+	stored, err := queries.CreateForm(ctx, sqlc.CreateFormParams{Name: "Demo", Slug: "demo", SubmitLabel: "Send", SuccessMessage: "Thanks", NotifyEmails: "sales@example.com", IsActive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries.CreateFormField(ctx, sqlc.CreateFormFieldParams{FormID: stored.ID, Label: "Email", Name: "email", FieldType: services.FieldEmail, Required: true})
+
+	_, form, err := forms.Active(ctx, "demo")
+	if err != nil || len(form.Fields) != 1 || form.SubmitLabel != "Send" {
+		t.Fatalf("Active = %+v, %v", form, err)
+	}
+	_, answers, _ := services.CheckFormSubmission(form, func(string) string { return "ana@example.com" })
+	sub, err := forms.Submit(ctx, stored, answers, "203.0.113.9", "test")
+	if err != nil || sub.Email != "ana@example.com" {
+		t.Fatalf("Submit = %+v, %v", sub, err)
+	}
+	if got := services.DecodeFormAnswers(sub.Data); len(got) != 1 || got[0].Label != "Email" {
+		t.Errorf("stored answers = %+v", got)
+	}
+	msg := mailer.next(t)
+	if msg.to[0] != "sales@example.com" || !strings.Contains(msg.body, "Email: ana@example.com") || !strings.Contains(msg.body, "https://example.com/admin/forms/") {
+		t.Errorf("notification = %+v", msg)
+	}
+
+	queries.UpdateForm(ctx, sqlc.UpdateFormParams{Name: "Demo", Slug: "demo", IsActive: false, ID: stored.ID})
+	if _, _, err := forms.Active(ctx, "demo"); err == nil {
+		t.Error("inactive form should not be served")
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	// Standard library imports
+	"image"       // Source and destination images
+	"image/color" // Reading pixels of any colour model
+)
+
+// ResizeImage scales src down to maxWidth pixels wide, keeping its aspect
+// ratio, by averaging the source pixels under each destination pixel (a box
+// filter). Images already no wider than maxWidth are copied unchanged.
+//
+// The standard library has no resampling, and a box filter gives clean
+// thumbnails for the downscales the media library needs without pulling in
+// an imaging dependency.
+//
+// Parameters:
+//   - src: Decoded source image
+//   - maxWidth: Width of the result in pixels
+//
+// Returns:
+//   - *image.RGBA: The resized image, at least 1×1
+func ResizeImage(src image.Image, maxWidth int) *image.RGBA {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dw, dh := sw, sh
+	if sw > maxWidth {
+		dw = maxWidth
+		dh = int(float64(sh)*float64(maxWidth)/float64(sw) + 0.5)
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0 := sb.Min.Y + y*sh/dh
+		y1 := max(sb.Min.Y+(y+1)*sh/dh, y0+1)
+		for x := 0; x < dw; x++ {
+			x0 := sb.Min.X + x*sw/dw
+			x1 := max(sb.Min.X+(x+1)*sw/dw, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	// Standard library imports
+	"errors"  // Landing page validation errors
+	"strings" // Slug and item parsing
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"         // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/models" // LandingSection view model rendered by the templates
+)
+
+// Landing page layouts (landing_pages.layout).
+const (
+	LandingLayoutDefault = "default" // Site header, navigation and footer
+	LandingLayoutMinimal = "minimal" // Logo only, no navigation to leave by
+)
+
+// Landing page statuses (landing_pages.status).
+const (
+	LandingDraft     = "draft"
+	LandingPublished = "published"
+)
+
+// Landing page section types (landing_page_sections.section_type).
+const (
+	SectionHero         = "hero"         // Headline, subheading, image and button
+	SectionText         = "text"         // Formatted text from the editor
+	SectionForm         = "form"         // Lead form saved as a contact submission
+	SectionTestimonials = "testimonials" // Customer quotes
+)
+
+// LandingOption is a layout or section type the admin can choose.
+type LandingOption struct {
+	Value string // landing_pages.layout or landing_page_sections.section_type
+	Label string // Name shown under Admin > Landing Pages
+}
+
+// LandingLayouts lists the layouts in the order the admin form offers them.
+var LandingLayouts = []LandingOption{
+	{LandingLayoutDefault, "Site layout"},
+	{LandingLayoutMinimal, "Minimal (no navigation)"},
+}
+
+// LandingSectionTypes lists the section types in the order the admin form
+// offers them.
+var LandingSectionTypes = []LandingOption{
+	{SectionHero, "Hero"},
+	{SectionText, "Text"},
+	{SectionForm, "Lead form"},
+	{SectionTestimonials, "Testimonials"},
+}
+
+// LandingSectionLabel returns the display name of a section type, or t
+// itself when it is unknown.
+func LandingSectionLabel(t string) string {
+	return landingLabel(LandingSectionTypes, t)
+}
+
+// landingLabel returns the label of value in options, or value itself.
+func landingLabel(options []LandingOption, value string) string {
+	for _, o := range options {
+		if o.Value == value {
+			return o.Label
+		}
+	}
+	return value
+}
+
+// reservedLandingSegments are the first path segments of the site's own
+// routes. A landing page there would be shadowed by, or shadow, a built-in
+// page.
+var reservedLandingSegments = map[string]bool{
+	"about": true, "admin": true, "api": true, "blog": true, "case-studies": true,
+	"contact": true, "forms": true, "health": true, "partials": true, "partners": true,
+	"products": true, "public": true, "robots.txt": true, "search": true,
+	"sitemap.xml": true, "solutions": true, "theme-tokens.css": true,
+	"uploads": true, "whitepapers": true,
+}
+
+// Landing page validation errors returned by ValidateLandingPage and
+// ValidateLandingSection.
+var (
+	ErrLandingTitle    = errors.New("landing pages: the page needs a title")
+	ErrLandingSlug     = errors.New("landing pages: the slug needs letters or digits")
+	ErrLandingReserved = errors.New("landing pages: the slug is used by the site")
+	ErrLandingLayout   = errors.New("landing pages: unknown layout")
+	ErrLandingImage    = errors.New("landing pages: images must be a path starting with / or an http(s) URL")
+	ErrSectionType     = errors.New("landing pages: unknown section type")
+	ErrSectionEmpty    = errors.New("landing pages: the section has no content")
+	ErrSectionButton   = errors.New("landing pages: the button needs a label and a path starting with /, an #anchor or an http(s) URL")
+	ErrSectionItems    = errors.New("landing pages: every testimonial needs a quote and a name")
+)
+
+// NormalizeLandingSlug turns slug into the path of a landing page below the
+// site root: each "/"-separated segment is slugified and empty segments are
+// dropped, so "/Spring Offer/2026/" becomes "spring-offer/2026". The first
+// segment must not belong to one of the site's own routes.
+func NormalizeLandingSlug(slug string) (string, error) {
+	var segments []string
+	for _, s := range strings.Split(slug, "/") {
+		if s = Slugify(s); s != "" {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) == 0 {
+		return "", ErrLandingSlug
+	}
+	if reservedLandingSegments[segments[0]] {
+		return strings.Join(segments, "/"), ErrLandingReserved
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// ValidateLandingPage checks a landing page from the admin form and returns
+// it with its fields trimmed and its slug normalised; an empty slug is
+// derived from the title. The analytics snippets are stored as entered,
+// since they hold the scripts of analytics and ad tools.
+func ValidateLandingPage(p sqlc.CreateLandingPageParams) (sqlc.CreateLandingPageParams, error) {
+	p.Title = strings.TrimSpace(p.Title)
+	p.MetaTitle = strings.TrimSpace(p.MetaTitle)
+	p.MetaDescription = strings.TrimSpace(p.MetaDescription)
+	p.OgImage = strings.TrimSpace(p.OgImage)
+	p.HeadSnippet = strings.TrimSpace(p.HeadSnippet)
+	p.BodySnippet = strings.TrimSpace(p.BodySnippet)
+	if p.Title == "" {
+		return p, ErrLandingTitle
+	}
+	if strings.TrimSpace(p.Slug) == "" {
+		p.Slug = p.Title
+	}
+	slug, err := NormalizeLandingSlug(p.Slug)
+	p.Slug = slug
+	if err != nil {
+		return p, err
+	}
+	if landingLabel(LandingLayouts, p.Layout) == p.Layout {
+		return p, ErrLandingLayout
+	}
+	if p.OgImage != "" && !isLinkTarget(p.OgImage) {
+		return p, ErrLandingImage
+	}
+	return p, nil
+}
+
+// ValidateLandingSection checks a section from the admin form and returns it
+// with its fields trimmed, the fields its type does not use cleared and its
+// testimonials normalised to one "quote | name | role" entry per line. Text
+// bodies must already be sanitized.
+//
+// A hero needs a headline (its image and button are optional, the button
+// complete; it may point at an anchor on the page such as #form, the lead
+// form), a text section a body, a form nothing (its labels have
+// defaults; its items may name a form built under Admin > Forms by slug,
+// which replaces the built-in lead form) and a testimonials section at
+// least one quote with a name.
+func ValidateLandingSection(s sqlc.CreateLandingPageSectionParams) (sqlc.CreateLandingPageSectionParams, error) {
+	s.Heading = strings.TrimSpace(s.Heading)
+	s.Body = strings.TrimSpace(s.Body)
+	s.Image = strings.TrimSpace(s.Image)
+	s.ButtonText = strings.TrimSpace(s.ButtonText)
+	s.ButtonUrl = strings.TrimSpace(s.ButtonUrl)
+	if LandingSectionLabel(s.SectionType) == s.SectionType {
+		return s, ErrSectionType
+	}
+
+	if s.SectionType != SectionHero {
+		s.Image, s.ButtonUrl = "", ""
+		if s.SectionType != SectionForm {
+			s.ButtonText = ""
+		}
+	}
+	if s.SectionType != SectionTestimonials && s.SectionType != SectionForm {
+		s.Items = ""
+	}
+	switch s.SectionType {
+	case SectionHero:
+		if s.Heading == "" {
+			return s, ErrSectionEmpty
+		}
+		if s.Image != "" && !isLinkTarget(s.Image) {
+			return s, ErrLandingImage
+		}
+		anchor := strings.HasPrefix(s.ButtonUrl, "#") && !strings.Contains(s.ButtonUrl, " ")
+		if (s.ButtonText == "") != (s.ButtonUrl == "") || s.ButtonUrl != "" && !isLinkTarget(s.ButtonUrl) && !anchor {
+			return s, ErrSectionButton
+		}
+	case SectionText:
+		if s.Body == "" {
+			return s, ErrSectionEmpty
+		}
+	case SectionForm:
+		s.Items = strings.TrimSpace(s.Items)
+	case SectionTestimonials:
+		s.Body = ""
+		lines := blockLines(s.Items)
+		if len(lines) == 0 {
+			return s, ErrSectionEmpty
+		}
+		for _, parts := range lines {
+			if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+				return s, ErrSectionItems
+			}
+		}
+		s.Items = joinBlockLines(lines)
+	}
+	return s, nil
+}
+
+// LandingSections converts a page's stored sections into their rendered
+// form, parsing the quotes of testimonials sections. The custom forms of
+// form sections are left for the caller to load.
+func LandingSections(rows []sqlc.LandingPageSection) []models.LandingSection {
+	sections := make([]models.LandingSection, 0, len(rows))
+	for _, row := range rows {
+		section := models.LandingSection{
+			Type:       row.SectionType,
+			Heading:    row.Heading,
+			Body:       row.Body,
+			Image:      row.Image,
+			ButtonText: row.ButtonText,
+			ButtonURL:  row.ButtonUrl,
+		}
+		if row.SectionType == SectionForm {
+			section.FormSlug = row.Items
+		}
+		if row.SectionType == SectionTestimonials {
+			for _, parts := range blockLines(row.Items) {
+				if len(parts) < 2 {
+					continue
+				}
+				t := models.LandingTestimonial{Quote: parts[0], Name: parts[1]}
+				if len(parts) > 2 {
+					t.Role = parts[2]
+				}
+				section.Testimonials = append(section.Testimonials, t)
+			}
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestValidateLandingPage(t *testing.T) {
+	got, err := services.ValidateLandingPage(sqlc.CreateLandingPageParams{Title: " Spring Offer ", Layout: services.LandingLayoutMinimal})
+	if err != nil || got.Title != "Spring Offer" || got.Slug != "spring-offer" {
+		t.Errorf("slug from title: %+v, %v", got, err)
+	}
+	for slug, want := range map[string]string{"/Spring Offer/2026/": "spring-offer/2026", "a//b": "a/b", "blog-offer": "blog-offer"} {
+		if got, err := services.NormalizeLandingSlug(slug); err != nil || got != want {
+			t.Errorf("NormalizeLandingSlug(%q) = %q, %v", slug, got, err)
+		}
+	}
+	for _, slug := range []string{"products/offer", "/Admin", "blog"} {
+		if _, err := services.NormalizeLandingSlug(slug); !errors.Is(err, services.ErrLandingReserved) {
+			t.Errorf("NormalizeLandingSlug(%q) = %v, want ErrLandingReserved", slug, err)
+		}
+	}
+
+	bad := map[error]sqlc.CreateLandingPageParams{
+		services.ErrLandingTitle:  {Title: " ", Layout: services.LandingLayoutDefault},
+		services.ErrLandingSlug:   {Title: "X", Slug: "/ ! /", Layout: services.LandingLayoutDefault},
+		services.ErrLandingLayout: {Title: "X", Layout: "wide"},
+		services.ErrLandingImage:  {Title: "X", Layout: services.LandingLayoutDefault, OgImage: "javascript:alert(1)"},
+	}
+	for want, p := range bad {
+		if _, err := services.ValidateLandingPage(p); !errors.Is(err, want) {
+			t.Errorf("ValidateLandingPage(%+v) = %v, want %v", p, err, want)
+		}
+	}
+}
+
+func TestValidateLandingSection(t *testing.T) {
+	ok := []sqlc.CreateLandingPageSectionParams{
+		{SectionType: services.SectionHero, Heading: "Save 20%", Image: "/uploads/hero.jpg", ButtonText: "Get the offer", ButtonUrl: "#form"},
+		{SectionType: services.SectionText, Body: "<p>Details</p>"},
+		{SectionType: services.SectionForm},
+		{SectionType: services.SectionTestimonials, Items: " Great kit |Ana \n\n Fast | Bo | CTO, Acme"},
+	}
+	for _, s := range ok {
+		if _, err := services.ValidateLandingSection(s); err != nil {
+			t.Errorf("ValidateLandingSection(%+v) = %v", s, err)
+		}
+	}
+	if _, err := services.ValidateLandingSection(sqlc.CreateLandingPageSectionParams{SectionType: services.SectionHero, Heading: "H", ButtonText: "Go", ButtonUrl: "javascript:alert(1)"}); !errors.Is(err, services.ErrSectionButton) {
+		t.Errorf("hero button with a script URL: %v", err)
+	}
+	got, _ := services.ValidateLandingSection(ok[3])
+	if got.Items != "Great kit | Ana\nFast | Bo | CTO, Acme" {
+		t.Errorf("testimonials normalised to %q", got.Items)
+	}
+
+	bad := map[error]sqlc.CreateLandingPageSectionParams{
+		services.ErrSectionType:   {SectionType: "video"},
+		services.ErrSectionEmpty:  {SectionType: services.SectionHero, Body: "Sub"},
+		services.ErrSectionButton: {SectionType: services.SectionHero, Heading: "H", ButtonText: "Go"},
+		services.ErrSectionItems:  {SectionType: services.SectionTestimonials, Items: "Just a quote"},
+		services.ErrLandingImage:  {SectionType: services.SectionHero, Heading: "H", Image: "hero.jpg"},
+	}
+	for want, s := range bad {
+		if _, err := services.ValidateLandingSection(s); !errors.Is(err, want) {
+			t.Errorf("ValidateLandingSection(%+v) = %v, want %v", s, err, want)
+		}
+	}
+}
+
+func TestLandingSections(t *testing.T) {
+	sections := services.LandingSections([]sqlc.LandingPageSection{
+		{SectionType: services.SectionHero, Heading: "Save", ButtonText: "Go", ButtonUrl: "/contact"},
+		{SectionType: services.SectionTestimonials, Items: "Great kit | Ana\nFast | Bo | CTO, Acme"},
+	})
+	if len(sections) != 2 || sections[0].ButtonURL != "/contact" {
+		t.Fatalf("sections = %+v", sections)
+	}
+	quotes := sections[1].Testimonials
+	if len(quotes) != 2 || quotes[0].Name != "Ana" || quotes[0].Role != "" || quotes[1].Role != "CTO, Acme" {
+		t.Errorf("testimonials = %+v", quotes)
+	}
+}
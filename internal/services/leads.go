@@ -0,0 +1,333 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request-scoped cancellation for database calls
+	"errors"  // Merge validation errors
+	"fmt"     // Admin link formatting
+	"sort"    // Ordering leads and submissions
+	"strings" // Email and company normalization
+	"time"    // Submission timestamps
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Lead submission sources shown in the Leads view.
+const (
+	LeadSourceContact         = "contact"          // Contact form (any inquiry type except RFQ)
+	LeadSourceRFQ             = "rfq"              // Request for quote via the contact form
+	LeadSourceWhitepaper      = "whitepaper"       // Gated whitepaper download
+	LeadSourceProductDownload = "product_download" // Gated product download (manual, drawing, ...)
+	LeadSourceEvent           = "event"            // Event or webinar registration
+)
+
+// ErrInvalidLeadMerge is returned by LeadService.Merge when either address is
+// empty or both normalize to the same lead.
+var ErrInvalidLeadMerge = errors.New("leads: merge needs two different email addresses")
+
+// companySuffixes are legal-form words dropped from the end of company names,
+// so "Acme Pvt. Ltd." and "ACME" canonicalize to the same value.
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "lp": true,
+	"ltd": true, "limited": true, "corp": true, "corporation": true, "co": true,
+	"company": true, "plc": true, "pvt": true, "private": true, "gmbh": true,
+	"ag": true, "sa": true, "sas": true, "srl": true, "bv": true, "nv": true,
+	"pty": true, "kg": true, "oy": true, "ab": true, "as": true,
+}
+
+// NormalizeEmail canonicalizes an address for matching: surrounding space is
+// trimmed, the address is lowercased, and a "+tag" suffix on the local part is
+// removed ("Jane.Doe+rfq@Example.com" becomes "jane.doe@example.com").
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	if i := strings.IndexByte(local, '+'); i > 0 {
+		local = local[:i]
+	}
+	return local + "@" + domain
+}
+
+// NormalizeCompany canonicalizes a company name for matching: it is
+// lowercased, "&" becomes "and", punctuation is removed, a leading "the" and
+// trailing legal forms (Inc, Ltd, GmbH, Pvt Ltd, ...) are dropped, and spacing
+// is collapsed. "The Acme Co., Ltd." becomes "acme".
+func NormalizeCompany(company string) string {
+	company = strings.ReplaceAll(strings.ToLower(company), "&", " and ")
+	words := strings.FieldsFunc(company, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizeName lowercases a person's name and collapses its spacing.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// LeadSubmission is one contact form entry, RFQ, whitepaper download,
+// gated product download, or event registration.
+type LeadSubmission struct {
+	Source    string    // LeadSourceContact, LeadSourceRFQ, LeadSourceWhitepaper, LeadSourceProductDownload, or LeadSourceEvent
+	ID        int64     // Row ID in contact_submissions, whitepaper_downloads, product_download_leads, or event_registrations
+	Name      string    // Name as submitted
+	Email     string    // Email as submitted
+	Company   string    // Company as submitted
+	Detail    string    // Inquiry type, whitepaper title, product and file title, or event title
+	URL       string    // Admin page for the submission
+	CreatedAt time.Time // Submission time
+}
+
+// Lead groups every submission from one person, identified by normalized
+// email and any addresses merged into it.
+type Lead struct {
+	Key          string           // Primary normalized email
+	Name         string           // Name from the most recent submission
+	Company      string           // Company from the most recent submission
+	Emails       []string         // Distinct addresses as submitted
+	MergedEmails []string         // Normalized addresses merged into Key
+	Submissions  []LeadSubmission // Newest first
+	Contacts     int              // Contact form submissions
+	RFQs         int              // Requests for quote
+	Downloads    int              // Whitepaper downloads
+	ProductFiles int              // Gated product downloads
+	Events       int              // Event registrations
+	LastSeen     time.Time        // Most recent submission
+}
+
+// IsDuplicate reports whether the lead links several submissions or
+// addresses, i.e. whether deduplication found anything for it.
+func (l *Lead) IsDuplicate() bool {
+	return len(l.Submissions) > 1 || len(l.Emails) > 1 || len(l.MergedEmails) > 0
+}
+
+// LeadSuggestion proposes merging two leads that look like the same person.
+type LeadSuggestion struct {
+	Primary   *Lead  // Lead to keep (most recently active)
+	Duplicate *Lead  // Lead to merge into Primary
+	Reason    string // Why the leads match
+}
+
+// LeadService builds the deduplicated lead list from contact submissions,
+// whitepaper downloads, gated product downloads, and event registrations,
+// and records manual merges.
+type LeadService struct {
+	queries *sqlc.Queries // Database query interface for sources and merges
+}
+
+// NewLeadService creates a LeadService.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//
+// Returns:
+//   - *LeadService: Initialized service
+func NewLeadService(queries *sqlc.Queries) *LeadService {
+	return &LeadService{queries: queries}
+}
+
+// Leads returns every lead, most recently active first. Submissions are
+// grouped by normalized email, then merged addresses are folded into their
+// primary lead.
+//
+// Parameters:
+//   - ctx: Request context
+//
+// Returns:
+//   - []*Lead: Deduplicated leads
+//   - error: Database error
+func (s *LeadService) Leads(ctx context.Context) ([]*Lead, error) {
+	contacts, err := s.queries.ListLeadContactSubmissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	downloads, err := s.queries.ListLeadWhitepaperDownloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	productFiles, err := s.queries.ListLeadProductDownloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registrations, err := s.queries.ListLeadEventRegistrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	merges, err := s.queries.ListLeadMerges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	primaryOf := make(map[string]string, len(merges))
+	for _, m := range merges {
+		primaryOf[m.Email] = m.PrimaryEmail
+	}
+
+	var subs []LeadSubmission
+	for _, c := range contacts {
+		source := LeadSourceContact
+		if c.InquiryType.String == "rfq" {
+			source = LeadSourceRFQ
+		}
+		subs = append(subs, LeadSubmission{
+			Source: source, ID: c.ID, Name: c.Name, Email: c.Email, Company: c.Company,
+			Detail: c.InquiryType.String, URL: fmt.Sprintf("/admin/contact/submissions/%d", c.ID), CreatedAt: c.CreatedAt,
+		})
+	}
+	for _, d := range downloads {
+		subs = append(subs, LeadSubmission{
+			Source: LeadSourceWhitepaper, ID: d.ID, Name: d.Name, Email: d.Email, Company: d.Company,
+			Detail: d.WhitepaperTitle, URL: fmt.Sprintf("/admin/whitepapers/%d/downloads", d.WhitepaperID), CreatedAt: d.CreatedAt,
+		})
+	}
+	for _, f := range productFiles {
+		subs = append(subs, LeadSubmission{
+			Source: LeadSourceProductDownload, ID: f.ID, Name: f.Name, Email: f.Email, Company: f.Company,
+			Detail: f.ProductName + ": " + f.DownloadTitle, URL: fmt.Sprintf("/admin/products/%d/edit", f.ProductID), CreatedAt: f.CreatedAt,
+		})
+	}
+	for _, r := range registrations {
+		subs = append(subs, LeadSubmission{
+			Source: LeadSourceEvent, ID: r.ID, Name: r.Name, Email: r.Email, Company: r.Company,
+			Detail: r.EventTitle, URL: fmt.Sprintf("/admin/events/%d/registrations", r.EventID), CreatedAt: r.CreatedAt,
+		})
+	}
+	sort.SliceStable(subs, func(i, j int) bool { return subs[i].CreatedAt.After(subs[j].CreatedAt) })
+
+	byKey := make(map[string]*Lead)
+	var leads []*Lead
+	for _, sub := range subs {
+		email := NormalizeEmail(sub.Email)
+		key := email
+		if p, ok := primaryOf[email]; ok {
+			key = p
+		}
+		lead, ok := byKey[key]
+		if !ok {
+			// Submissions are newest first, so the first one names the lead
+			lead = &Lead{Key: key, Name: sub.Name, Company: sub.Company, LastSeen: sub.CreatedAt}
+			byKey[key] = lead
+			leads = append(leads, lead)
+		}
+		lead.Submissions = append(lead.Submissions, sub)
+		if !containsFold(lead.Emails, sub.Email) {
+			lead.Emails = append(lead.Emails, sub.Email)
+		}
+		if email != key && !containsFold(lead.MergedEmails, email) {
+			lead.MergedEmails = append(lead.MergedEmails, email)
+		}
+		switch sub.Source {
+		case LeadSourceContact:
+			lead.Contacts++
+		case LeadSourceRFQ:
+			lead.RFQs++
+		case LeadSourceWhitepaper:
+			lead.Downloads++
+		case LeadSourceProductDownload:
+			lead.ProductFiles++
+		case LeadSourceEvent:
+			lead.Events++
+		}
+	}
+	return leads, nil
+}
+
+// containsFold reports whether list holds s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggestions proposes merges for leads with different addresses but the
+// same person name at the same canonical company.
+//
+// Parameters:
+//   - leads: Output of Leads (most recently active first)
+//
+// Returns:
+//   - []LeadSuggestion: Candidate merges; Primary is the more recent lead
+func Suggestions(leads []*Lead) []LeadSuggestion {
+	type identity struct{ name, company string }
+	first := make(map[identity]*Lead)
+	var out []LeadSuggestion
+	for _, lead := range leads {
+		id := identity{normalizeName(lead.Name), NormalizeCompany(lead.Company)}
+		if id.name == "" || id.company == "" {
+			continue
+		}
+		if primary, ok := first[id]; ok {
+			out = append(out, LeadSuggestion{
+				Primary:   primary,
+				Duplicate: lead,
+				Reason:    fmt.Sprintf("Same name and company (%s)", id.company),
+			})
+			continue
+		}
+		first[id] = lead
+	}
+	return out
+}
+
+// Merge records that duplicateEmail belongs to the same person as
+// primaryEmail. Addresses already merged into duplicateEmail move with it,
+// and a primaryEmail that was itself merged resolves to its own primary.
+//
+// Parameters:
+//   - ctx: Request context
+//   - primaryEmail: Address of the lead to keep (any form; it is normalized)
+//   - duplicateEmail: Address of the lead to fold into it
+//
+// Returns:
+//   - error: ErrInvalidLeadMerge for empty or identical addresses, or a database error
+func (s *LeadService) Merge(ctx context.Context, primaryEmail, duplicateEmail string) error {
+	primary, duplicate := NormalizeEmail(primaryEmail), NormalizeEmail(duplicateEmail)
+	if primary == "" || duplicate == "" {
+		return ErrInvalidLeadMerge
+	}
+
+	merges, err := s.queries.ListLeadMerges(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range merges {
+		if m.Email == primary {
+			primary = m.PrimaryEmail
+		}
+		if m.Email == duplicate {
+			duplicate = m.PrimaryEmail
+		}
+	}
+	if primary == duplicate {
+		return ErrInvalidLeadMerge
+	}
+
+	if err := s.queries.RepointLeadMerges(ctx, sqlc.RepointLeadMergesParams{NewPrimary: primary, OldPrimary: duplicate}); err != nil {
+		return err
+	}
+	return s.queries.MergeLead(ctx, sqlc.MergeLeadParams{Email: duplicate, PrimaryEmail: primary})
+}
+
+// Unmerge splits a previously merged address back into its own lead.
+//
+// Parameters:
+//   - ctx: Request context
+//   - email: Merged address (any form; it is normalized)
+//
+// Returns:
+//   - error: Database error
+func (s *LeadService) Unmerge(ctx context.Context, email string) error {
+	return s.queries.UnmergeLead(ctx, NormalizeEmail(email))
+}
@@ -0,0 +1,56 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"Jane.Doe@Example.com":    "jane.doe@example.com",
+		"  jane+rfq@example.com ": "jane@example.com",
+		"jane+a+b@example.com":    "jane@example.com",
+		"+tag@example.com":        "+tag@example.com", // no local part left to keep
+		"not-an-email":            "not-an-email",
+		"":                        "",
+	}
+	for in, want := range tests {
+		if got := services.NormalizeEmail(in); got != want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeCompany(t *testing.T) {
+	tests := map[string]string{
+		"Acme":                   "acme",
+		"ACME Inc.":              "acme",
+		"The Acme Co., Ltd.":     "acme",
+		"Acme Pvt. Ltd":          "acme",
+		"Acme Widgets GmbH":      "acme widgets",
+		"Smith & Sons, LLC":      "smith and sons",
+		"Limited":                "limited", // a bare legal form is kept
+		"  Müller   Technik AG ": "müller technik",
+	}
+	for in, want := range tests {
+		if got := services.NormalizeCompany(in); got != want {
+			t.Errorf("NormalizeCompany(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	recent := &services.Lead{Key: "jane@acme.com", Name: "Jane Doe", Company: "Acme Inc."}
+	older := &services.Lead{Key: "jdoe@gmail.com", Name: "jane  doe", Company: "ACME"}
+	other := &services.Lead{Key: "john@acme.com", Name: "John Doe", Company: "Acme"}
+	noCompany := &services.Lead{Key: "jane@example.com", Name: "Jane Doe"}
+
+	got := services.Suggestions([]*services.Lead{recent, other, older, noCompany})
+	if len(got) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(got))
+	}
+	if got[0].Primary != recent || got[0].Duplicate != older {
+		t.Errorf("expected %s to merge into %s, got %s into %s", older.Key, recent.Key, got[0].Duplicate.Key, got[0].Primary.Key)
+	}
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Job cancellation and database calls
+	"database/sql" // Database handle
+	"fmt"          // Problem descriptions
+	"log/slog"     // Structured logging for run summaries
+	"net/http"     // In-process requests against the application handler
+	"net/url"      // Telling internal links from external ones
+	"strings"      // Path handling
+	"time"         // Time of the last run
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/database" // Transaction that replaces the previous results
+)
+
+// LinkCheckerUserAgent identifies requests made by LinkChecker, so analytics
+// and the 404 report can tell them apart from visitors.
+const LinkCheckerUserAgent = "bluejay-link-checker"
+
+// maxLinkRedirects is how many internal redirects a link may go through
+// before it is reported as a redirect loop.
+const maxLinkRedirects = 5
+
+// BrokenLinkIssue is one line of the link check report.
+type BrokenLinkIssue struct {
+	Label       string    // Content type the link is in, e.g. "Blog post"
+	Title       string    // Title of the item the link is in
+	Field       string    // Field the link is in
+	URL         string    // The link as written
+	Status      int       // HTTP status the link answered with
+	Problem     string    // What is wrong, e.g. "Page not found"
+	EditURL     string    // Admin page that edits the link
+	TargetURL   string    // Admin page of the unpublished target, or ""
+	RedirectURL string    // Form that adds a redirect for a missing page, or ""
+	CheckedAt   time.Time // When the link was checked
+}
+
+// LinkChecker finds internal links that lead nowhere: links in rich text
+// (blog posts, products, solutions, case studies, content blocks and landing
+// page sections) and call-to-action URLs (homepage heroes and CTAs, solution
+// CTAs, page sections, the header button, content block and landing page
+// buttons). Each link is requested from the application handler in-process,
+// following internal redirects, and is broken when the final answer is 400
+// or above. A 404 for a product, post, solution, case study, whitepaper or
+// landing page that exists but is not published is reported as unpublished,
+// so an editor can publish it instead of changing the link.
+//
+// Links are internal when they are site-relative ("/about") or absolute with
+// the host of SITE_BASE_URL. External links, anchors, mailto: and tel: links
+// are not checked. The results of the last run replace the previous ones in
+// the broken_links table.
+type LinkChecker struct {
+	db       *sql.DB       // Database handle for the results transaction
+	queries  *sqlc.Queries // Links and results
+	handler  http.Handler  // Application handler (the Echo instance)
+	siteHost string        // Host of SITE_BASE_URL, "" when it is not set
+	logger   *slog.Logger  // Structured logger for run summaries
+}
+
+// NewLinkChecker creates a link checker.
+//
+// Parameters:
+//   - db: Database handle for the results transaction
+//   - queries: Database query interface
+//   - handler: Application handler that serves the public pages
+//   - siteBaseURL: SITE_BASE_URL; absolute links to its host are internal
+//   - logger: Structured logger for run summaries
+//
+// Returns:
+//   - *LinkChecker: Checker ready to run or schedule
+func NewLinkChecker(db *sql.DB, queries *sqlc.Queries, handler http.Handler, siteBaseURL string, logger *slog.Logger) *LinkChecker {
+	host := ""
+	if u, err := url.Parse(siteBaseURL); err == nil {
+		host = u.Host
+	}
+	return &LinkChecker{db: db, queries: queries, handler: handler, siteHost: host, logger: logger}
+}
+
+// Run checks every internal link and replaces the stored results. Links are
+// requested one at a time so a run never competes with visitors for the
+// single SQLite connection more than one request at a time, and each
+// distinct path is requested only once.
+//
+// Parameters:
+//   - ctx: Stops the run early when cancelled; the previous results are kept
+//
+// Returns:
+//   - int: Broken links found
+//   - error: Database error or cancellation
+func (l *LinkChecker) Run(ctx context.Context) (int, error) {
+	var links []sqlc.CreateBrokenLinkParams
+	fields, err := l.queries.ListRichContent(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, f := range fields {
+		found, _ := scanRichText([]string{f.Html})
+		for _, link := range found {
+			links = append(links, sqlc.CreateBrokenLinkParams{
+				Source: f.Source, OwnerID: f.OwnerID, ParentID: f.ParentID,
+				OwnerTitle: f.OwnerTitle, Field: f.Field, Url: link,
+			})
+		}
+	}
+	ctas, err := l.queries.ListLinkSources(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range ctas {
+		links = append(links, sqlc.CreateBrokenLinkParams{
+			Source: s.Source, OwnerID: s.OwnerID, ParentID: s.ParentID,
+			OwnerTitle: s.OwnerTitle, Field: s.Field, Url: s.Url,
+		})
+	}
+
+	var broken []sqlc.CreateBrokenLinkParams
+	statuses := map[string]int{}
+	for _, link := range links {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		path, ok := l.internalPath(link.Url)
+		if !ok {
+			continue
+		}
+		status, seen := statuses[path]
+		if !seen {
+			status = l.status(ctx, path)
+			statuses[path] = status
+		}
+		if status < http.StatusBadRequest {
+			continue
+		}
+		link.Status = int64(status)
+		if status == http.StatusNotFound {
+			link.TargetType, link.TargetID, err = l.target(ctx, path)
+			if err != nil {
+				return 0, err
+			}
+		}
+		broken = append(broken, link)
+	}
+
+	if err := l.save(ctx, broken); err != nil {
+		return 0, err
+	}
+	l.logger.Info("link check finished", "links", len(links), "paths", len(statuses), "broken", len(broken))
+	return len(broken), nil
+}
+
+// save replaces the stored results with broken in one transaction, so the
+// report never shows a half-written run.
+func (l *LinkChecker) save(ctx context.Context, broken []sqlc.CreateBrokenLinkParams) error {
+	return database.WithTx(ctx, l.db, func(q *sqlc.Queries) error {
+		if err := q.DeleteBrokenLinks(ctx); err != nil {
+			return err
+		}
+		for _, link := range broken {
+			if err := q.CreateBrokenLink(ctx, link); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// internalPath returns the path and query to request for link, and false
+// when link is not an internal link.
+func (l *LinkChecker) internalPath(link string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", false
+	}
+	switch {
+	case u.Scheme == "" && u.Host == "":
+		if !strings.HasPrefix(u.Path, "/") {
+			return "", false
+		}
+	case u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https":
+		return "", false
+	case l.siteHost == "" || !strings.EqualFold(u.Host, l.siteHost):
+		return "", false
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u.RequestURI(), true
+}
+
+// status requests path from the application handler and returns the final
+// status code, following internal redirects.
+func (l *LinkChecker) status(ctx context.Context, path string) int {
+	for range maxLinkRedirects + 1 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return http.StatusBadRequest
+		}
+		req.RemoteAddr = "127.0.0.1:0"
+		req.Header.Set("User-Agent", LinkCheckerUserAgent)
+		rec := &discardResponse{header: http.Header{}}
+		l.handler.ServeHTTP(rec, req)
+		if rec.status == 0 {
+			return http.StatusOK
+		}
+		if rec.status < 300 || rec.status >= 400 {
+			return rec.status
+		}
+		next, err := req.URL.Parse(rec.header.Get("Location"))
+		if err != nil {
+			return http.StatusBadRequest
+		}
+		// Redirects off the site are not followed; the link itself works
+		if next.Host != "" && !strings.EqualFold(next.Host, l.siteHost) {
+			return rec.status
+		}
+		path = next.RequestURI()
+	}
+	return http.StatusLoopDetected
+}
+
+// target returns the item a missing path names when it exists but is not
+// published, e.g. ("blog_post", 12) for the draft post at /blog/launch, or
+// ("", 0).
+func (l *LinkChecker) target(ctx context.Context, path string) (string, int64, error) {
+	path, _, _ = strings.Cut(path, "?")
+	contentType, slug := linkTarget(path)
+	if contentType == "" {
+		contentType, slug = "landing_page", strings.Trim(path, "/")
+	}
+	id, err := l.queries.LinkTargetID(ctx, sqlc.LinkTargetIDParams{ContentType: contentType, Slug: slug})
+	if err != nil || id == 0 {
+		return "", 0, err
+	}
+	return contentType, id, nil
+}
+
+// Report returns the broken links found by the last run, each with the
+// admin pages that fix it.
+//
+// Returns:
+//   - []BrokenLinkIssue: Broken links by content type and item
+//   - error: Database error
+func (l *LinkChecker) Report(ctx context.Context) ([]BrokenLinkIssue, error) {
+	rows, err := l.queries.ListBrokenLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]BrokenLinkIssue, len(rows))
+	for i, r := range rows {
+		issue := BrokenLinkIssue{
+			Label:     contentLabels[r.Source],
+			Title:     r.OwnerTitle,
+			Field:     r.Field,
+			URL:       r.Url,
+			Status:    int(r.Status),
+			EditURL:   contentEditURL(r.Source, r.OwnerID, r.ParentID, r.OwnerTitle),
+			CheckedAt: r.CheckedAt,
+		}
+		switch {
+		case r.TargetID != 0:
+			issue.Problem = "Links to an unpublished " + strings.ToLower(contentLabels[r.TargetType])
+			issue.TargetURL = contentEditURL(r.TargetType, r.TargetID, 0, "")
+		case r.Status == http.StatusNotFound:
+			issue.Problem = "Page not found"
+			if path, ok := l.internalPath(r.Url); ok {
+				path, _, _ = strings.Cut(path, "?")
+				issue.RedirectURL = "/admin/redirects?source=" + url.QueryEscape(path)
+			}
+		case r.Status == http.StatusLoopDetected:
+			issue.Problem = "Redirect loop"
+		default:
+			issue.Problem = fmt.Sprintf("Error %d", r.Status)
+		}
+		issues[i] = issue
+	}
+	return issues, nil
+}
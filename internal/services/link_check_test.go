@@ -0,0 +1,110 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// TestLinkChecker seeds rich text and CTAs linking to working, missing,
+// redirecting, failing and unpublished pages, and checks what the report
+// lists and that each path is requested once.
+func TestLinkChecker(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	requests := map[string]int{}
+	site := http.NewServeMux()
+	site.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.RequestURI()]++
+		if r.UserAgent() != services.LinkCheckerUserAgent {
+			t.Errorf("User-Agent = %q", r.UserAgent())
+		}
+		switch r.URL.Path {
+		// The seeded page sections link to the listing pages
+		case "/ok", "/about", "/contact", "/products", "/blog", "/case-studies", "/whitepapers":
+			w.Write([]byte("ok"))
+		case "/old":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/external":
+			http.Redirect(w, r, "https://other.example.org/", http.StatusFound)
+		case "/boom":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "Ada", Slug: "ada", Title: "Editor"})
+	post, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+		Title: "Launch", Slug: "launch", Excerpt: "e", CategoryID: cat.ID, AuthorID: author.ID, Status: "draft",
+		Body: `<a href="/ok">a</a><a href="/missing">b</a><a href="/missing">c</a><a href="/old">d</a>` +
+			`<a href="/loop">e</a><a href="/external">f</a><a href="/boom">g</a>` +
+			`<a href="https://www.example.com/gone?x=1#y">h</a><a href="https://other.example.org/gone">i</a>` +
+			`<a href="#top">j</a><a href="mailto:sales@example.com">k</a><img src="/uploads/missing.png" alt="l">`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := queries.CreateLandingPage(ctx, sqlc.CreateLandingPageParams{Title: "Spring Sale", Slug: "spring-sale", Layout: "default"})
+	cta, _ := queries.CreateCTA(ctx, sqlc.CreateCTAParams{
+		Headline: "Spring", PrimaryCtaText: "Go", PrimaryCtaUrl: "/spring-sale",
+		SecondaryCtaUrl: sql.NullString{String: "/blog/launch", Valid: true}, IsActive: 1,
+	})
+	queries.CreateHero(ctx, sqlc.CreateHeroParams{Headline: "Welcome", PrimaryCtaText: "Go", PrimaryCtaUrl: "/old", IsActive: 1})
+
+	checker := services.NewLinkChecker(db, queries, site, "https://www.example.com", slog.New(slog.DiscardHandler))
+	broken, err := checker.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if broken != 8 {
+		t.Errorf("Run found %d broken links, want 8", broken)
+	}
+	if requests["/missing"] != 1 || requests["/ok"] != 2 {
+		t.Errorf("requests = %v, want each path once (and /ok again after /old)", requests)
+	}
+	if _, ok := requests["/gone"]; ok {
+		t.Error("an external link was requested")
+	}
+
+	// Running again replaces the results
+	if _, err := checker.Run(ctx); err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	issues, err := checker.Report(ctx)
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	var got []string
+	for _, i := range issues {
+		got = append(got, strings.Join([]string{i.Label, i.Field, i.URL, i.Problem, i.EditURL, i.TargetURL, i.RedirectURL}, " | "))
+	}
+	editPost := fmt.Sprintf("/admin/blog/posts/%d/edit", post.ID)
+	editCTA := fmt.Sprintf("/admin/homepage/cta/%d/edit", cta.ID)
+	want := []string{
+		"Blog post | Body | /boom | Error 500 | " + editPost + " |  | ",
+		"Blog post | Body | /loop | Redirect loop | " + editPost + " |  | ",
+		"Blog post | Body | /missing | Page not found | " + editPost + " |  | /admin/redirects?source=%2Fmissing",
+		"Blog post | Body | /missing | Page not found | " + editPost + " |  | /admin/redirects?source=%2Fmissing",
+		"Blog post | Body | /uploads/missing.png | Page not found | " + editPost + " |  | /admin/redirects?source=%2Fuploads%2Fmissing.png",
+		"Blog post | Body | https://www.example.com/gone?x=1#y | Page not found | " + editPost + " |  | /admin/redirects?source=%2Fgone",
+		"Homepage CTA | Primary button | /spring-sale | Links to an unpublished landing page | " + editCTA + " | " + fmt.Sprintf("/admin/landing-pages/%d/edit", page.ID) + " | ",
+		"Homepage CTA | Secondary button | /blog/launch | Links to an unpublished blog post | " + editCTA + " | " + editPost + " | ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("report:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
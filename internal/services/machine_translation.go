@@ -0,0 +1,178 @@
+package services
+
+import (
+	// Standard library imports for HTTP calls and JSON encoding
+	"bytes"         // Request body buffers
+	"context"       // Request-scoped cancellation for provider calls
+	"encoding/json" // Provider request and response payloads
+	"errors"        // Sentinel errors
+	"fmt"           // Error wrapping with provider context
+	"io"            // Reading provider error bodies
+	"net/http"      // Provider HTTP API calls
+	"net/url"       // API key query parameter for Google
+	"strings"       // Locale normalization and DeepL free-key detection
+	"time"          // Default HTTP client timeout
+)
+
+// Machine-translation providers selectable in Global Settings (settings.mt_provider).
+const (
+	MachineTranslationDeepL  = "deepl"
+	MachineTranslationGoogle = "google"
+)
+
+// ErrNothingToTranslate is returned when every non-empty source field already
+// has a translation, so a machine draft would not add anything.
+var ErrNothingToTranslate = errors.New("translation already has every field filled in")
+
+// MachineTranslator translates batches of text through an external provider.
+// Texts may contain HTML (blog bodies, solution overviews); implementations
+// must preserve markup.
+type MachineTranslator interface {
+	// Translate returns one translation per input text, in input order.
+	Translate(ctx context.Context, texts []string, sourceLocale, targetLocale string) ([]string, error)
+}
+
+// NewMachineTranslator returns the translator for a configured provider, or nil
+// when machine translation is disabled (no provider or no API key) or the
+// provider is unknown.
+//
+// Parameters:
+//   - provider: settings.mt_provider value ("deepl", "google", or "")
+//   - apiKey: settings.mt_api_key value
+//
+// Returns:
+//   - MachineTranslator: Provider client, or nil when not configured
+func NewMachineTranslator(provider, apiKey string) MachineTranslator {
+	if apiKey == "" {
+		return nil
+	}
+	switch provider {
+	case MachineTranslationDeepL:
+		return &DeepLTranslator{APIKey: apiKey}
+	case MachineTranslationGoogle:
+		return &GoogleTranslator{APIKey: apiKey}
+	}
+	return nil
+}
+
+// machineTranslationClient is shared by the provider clients when none is set.
+var machineTranslationClient = &http.Client{Timeout: 30 * time.Second}
+
+// postJSON sends payload to endpoint and decodes a JSON response into out.
+// Non-2xx responses are returned as errors including the response body, which
+// both providers use for error details.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, header http.Header, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = machineTranslationClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// DeepLTranslator calls the DeepL v2 translate API.
+type DeepLTranslator struct {
+	APIKey   string       // DeepL authentication key (free keys end in ":fx")
+	Endpoint string       // Overrides the API URL; derived from the key when empty
+	Client   *http.Client // HTTP client; a 30s-timeout default is used when nil
+}
+
+// endpoint returns the API URL, using the free-tier host for ":fx" keys.
+func (d *DeepLTranslator) endpoint() string {
+	if d.Endpoint != "" {
+		return d.Endpoint
+	}
+	if strings.HasSuffix(d.APIKey, ":fx") {
+		return "https://api-free.deepl.com/v2/translate"
+	}
+	return "https://api.deepl.com/v2/translate"
+}
+
+// Translate implements MachineTranslator.
+func (d *DeepLTranslator) Translate(ctx context.Context, texts []string, sourceLocale, targetLocale string) ([]string, error) {
+	payload := map[string]interface{}{
+		"text":         texts,
+		"source_lang":  strings.ToUpper(sourceLocale),
+		"target_lang":  strings.ToUpper(targetLocale),
+		"tag_handling": "html",
+	}
+	var out struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	header := http.Header{"Authorization": {"DeepL-Auth-Key " + d.APIKey}}
+	if err := postJSON(ctx, d.Client, d.endpoint(), header, payload, &out); err != nil {
+		return nil, fmt.Errorf("deepl: %w", err)
+	}
+	if len(out.Translations) != len(texts) {
+		return nil, fmt.Errorf("deepl: expected %d translations, got %d", len(texts), len(out.Translations))
+	}
+	result := make([]string, len(texts))
+	for i, t := range out.Translations {
+		result[i] = t.Text
+	}
+	return result, nil
+}
+
+// GoogleTranslator calls the Google Cloud Translation v2 (basic) API.
+type GoogleTranslator struct {
+	APIKey   string       // Google Cloud API key with the Translation API enabled
+	Endpoint string       // Overrides the API URL when non-empty
+	Client   *http.Client // HTTP client; a 30s-timeout default is used when nil
+}
+
+// Translate implements MachineTranslator.
+func (g *GoogleTranslator) Translate(ctx context.Context, texts []string, sourceLocale, targetLocale string) ([]string, error) {
+	endpoint := g.Endpoint
+	if endpoint == "" {
+		endpoint = "https://translation.googleapis.com/language/translate/v2"
+	}
+	endpoint += "?" + url.Values{"key": {g.APIKey}}.Encode()
+
+	payload := map[string]interface{}{
+		"q":      texts,
+		"source": sourceLocale,
+		"target": targetLocale,
+		"format": "html",
+	}
+	var out struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText string `json:"translatedText"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	if err := postJSON(ctx, g.Client, endpoint, nil, payload, &out); err != nil {
+		return nil, fmt.Errorf("google: %w", err)
+	}
+	if len(out.Data.Translations) != len(texts) {
+		return nil, fmt.Errorf("google: expected %d translations, got %d", len(texts), len(out.Data.Translations))
+	}
+	result := make([]string, len(texts))
+	for i, t := range out.Data.Translations {
+		result[i] = t.TranslatedText
+	}
+	return result, nil
+}
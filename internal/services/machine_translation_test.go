@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// fakeTranslator prefixes every text with the target locale and records calls.
+type fakeTranslator struct {
+	calls [][]string
+}
+
+func (f *fakeTranslator) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	f.calls = append(f.calls, texts)
+	out := make([]string, len(texts))
+	for i, t := range texts {
+		out[i] = "[" + target + "] " + t
+	}
+	return out, nil
+}
+
+func TestNewMachineTranslator(t *testing.T) {
+	if services.NewMachineTranslator("deepl", "") != nil {
+		t.Error("expected nil without an API key")
+	}
+	if services.NewMachineTranslator("", "key") != nil || services.NewMachineTranslator("bing", "key") != nil {
+		t.Error("expected nil for a disabled or unknown provider")
+	}
+	if _, ok := services.NewMachineTranslator("deepl", "key").(*services.DeepLTranslator); !ok {
+		t.Error("expected DeepL translator")
+	}
+	if _, ok := services.NewMachineTranslator("google", "key").(*services.GoogleTranslator); !ok {
+		t.Error("expected Google translator")
+	}
+}
+
+func TestDeepLTranslator_Translate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "DeepL-Auth-Key secret" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		var body struct {
+			Text       []string `json:"text"`
+			TargetLang string   `json:"target_lang"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.TargetLang != "DE" || len(body.Text) != 2 {
+			t.Errorf("unexpected request body %+v", body)
+		}
+		w.Write([]byte(`{"translations":[{"text":"Hallo"},{"text":"<p>Welt</p>"}]}`))
+	}))
+	defer srv.Close()
+
+	d := &services.DeepLTranslator{APIKey: "secret", Endpoint: srv.URL}
+	got, err := d.Translate(context.Background(), []string{"Hello", "<p>World</p>"}, "en", "de")
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if got[0] != "Hallo" || got[1] != "<p>Welt</p>" {
+		t.Errorf("unexpected translations %v", got)
+	}
+}
+
+func TestGoogleTranslator_TranslateError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "secret" {
+			t.Errorf("expected API key in query string")
+		}
+		http.Error(w, `{"error":{"message":"API key not valid"}}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	g := &services.GoogleTranslator{APIKey: "secret", Endpoint: srv.URL}
+	_, err := g.Translate(context.Background(), []string{"Hello"}, "en", "de")
+	if err == nil || !strings.Contains(err.Error(), "API key not valid") {
+		t.Errorf("expected provider error message, got %v", err)
+	}
+}
+
+func TestTranslationService_MachineDraft(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	res, err := db.Exec(`INSERT INTO solutions (title, slug, icon, short_description, is_published) VALUES ('Grid', 'grid', 'bolt', 'Grid monitoring', 1)`)
+	if err != nil {
+		t.Fatalf("insert solution: %v", err)
+	}
+	id, _ := res.LastInsertId()
+
+	svc := services.NewTranslationService(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), "en", []string{"fr"})
+	src, err := svc.Source(ctx, "solution", id)
+	if err != nil {
+		t.Fatalf("Source: %v", err)
+	}
+
+	// A human-translated title is kept; only the remaining field is sent to the provider
+	if _, err := svc.Save(ctx, src, "fr", map[string]string{"title": "Réseau"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	mt := &fakeTranslator{}
+	tr, err := svc.MachineDraft(ctx, mt, src, "fr")
+	if err != nil {
+		t.Fatalf("MachineDraft: %v", err)
+	}
+	if len(mt.calls) != 1 || len(mt.calls[0]) != 1 || mt.calls[0][0] != "Grid monitoring" {
+		t.Errorf("expected only the untranslated field to be sent, got %v", mt.calls)
+	}
+	if tr.Status != services.TranslationMachine || tr.Fields["title"] != "Réseau" || tr.Fields["short_description"] != "[fr] Grid monitoring" {
+		t.Errorf("unexpected draft %+v", tr)
+	}
+
+	// Machine drafts stay off public pages and are counted for review
+	if svc.Resolve(ctx, "solution", id, "fr") != nil {
+		t.Error("machine draft should not render on public pages")
+	}
+	coverage, err := svc.Coverage(ctx)
+	if err != nil {
+		t.Fatalf("Coverage: %v", err)
+	}
+	if coverage[0].Machine != 1 {
+		t.Errorf("expected 1 machine draft in coverage, got %+v", coverage[0].TypeCoverage)
+	}
+
+	// Nothing left to fill
+	if _, err := svc.MachineDraft(ctx, mt, src, "fr"); !errors.Is(err, services.ErrNothingToTranslate) {
+		t.Errorf("expected ErrNothingToTranslate, got %v", err)
+	}
+
+	// Saving the reviewed draft approves it
+	tr, err = svc.Save(ctx, src, "fr", tr.Fields)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if tr.Status != services.TranslationComplete || svc.Resolve(ctx, "solution", id, "fr") == nil {
+		t.Errorf("expected approved translation to be complete and public, got %q", tr.Status)
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	// Standard library imports
+	"context"  // Send signature (net/smtp itself does not take a context)
+	"fmt"      // Message headers
+	"net"      // Host:port joining
+	"net/smtp" // SMTP delivery
+	"strings"  // Header sanitising and message assembly
+	"time"     // Date header
+)
+
+// Mailer sends plain-text email. SMTPMailer is the production implementation;
+// tests substitute a recorder.
+type Mailer interface {
+	Send(ctx context.Context, to []string, subject, body string) error
+}
+
+// SMTPConfig is the outgoing mail server, read from SMTP_* environment
+// variables in main.go.
+type SMTPConfig struct {
+	Host     string // SMTP_HOST; mail is disabled when empty
+	Port     string // SMTP_PORT; defaults to 587
+	Username string // SMTP_USERNAME; PLAIN auth is used when set
+	Password string // SMTP_PASSWORD
+	From     string // SMTP_FROM, e.g. "Bluejay CMS <cms@example.com>"
+}
+
+// SMTPMailer delivers mail through an SMTP server with net/smtp, which
+// upgrades to STARTTLS when the server offers it.
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer creates a mailer for the configured server.
+//
+// Parameters:
+//   - config: Server address, credentials and sender
+//
+// Returns:
+//   - *SMTPMailer: Mailer ready to send
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	if config.Port == "" {
+		config.Port = "587"
+	}
+	return &SMTPMailer{config: config}
+}
+
+// Send delivers one message to all recipients.
+func (m *SMTPMailer) Send(ctx context.Context, to []string, subject, body string) error {
+	if len(to) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+	from := m.config.From
+	if from == "" {
+		from = m.config.Username
+	}
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	return smtp.SendMail(addr, auth, envelopeAddress(from), to, buildMessage(from, to, subject, body, time.Now()))
+}
+
+// buildMessage assembles an RFC 5322 plain-text message. Header values are
+// stripped of line breaks so content titles cannot inject headers.
+func buildMessage(from string, to []string, subject, body string, now time.Time) []byte {
+	clean := strings.NewReplacer("\r", " ", "\n", " ")
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", clean.Replace(from))
+	fmt.Fprintf(&b, "To: %s\r\n", clean.Replace(strings.Join(to, ", ")))
+	fmt.Fprintf(&b, "Subject: %s\r\n", clean.Replace(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// envelopeAddress returns the bare address of "Name <addr>".
+func envelopeAddress(from string) string {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+	return from
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes" // Render output buffer
+
+	// Third-party Markdown renderer
+	"github.com/yuin/goldmark"           // CommonMark-compliant Markdown parser and renderer
+	"github.com/yuin/goldmark/extension" // GitHub Flavored Markdown (tables, task lists, strikethrough, autolinks)
+	"github.com/yuin/goldmark/parser"    // Parser options (heading anchors)
+)
+
+// Blog post authoring modes (blog_posts.content_format).
+const (
+	ContentFormatHTML     = "html"     // Trix WYSIWYG editor; body is stored as submitted
+	ContentFormatMarkdown = "markdown" // Markdown source in body_markdown, rendered HTML in body
+)
+
+// markdown is the shared renderer. It runs with goldmark's safe defaults:
+// raw HTML blocks and inline tags in the source are dropped (rendered as an
+// HTML comment) and links or images with javascript:, vbscript:, file:, or
+// non-image data: URLs are emptied, so Markdown authors cannot inject script.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// RenderMarkdown converts Markdown source to sanitized HTML suitable for
+// storing in a post body and rendering with safeHTML.
+//
+// Parameters:
+//   - source: Markdown text (CommonMark plus GitHub Flavored Markdown tables,
+//     task lists, strikethrough, and autolinks)
+//
+// Returns:
+//   - string: Rendered HTML with raw HTML and dangerous URLs removed
+//   - error: Renderer failure (not expected for any input)
+func RenderMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
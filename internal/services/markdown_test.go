@@ -0,0 +1,35 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	html, err := services.RenderMarkdown("## Setup\n\nRun `make build`.\n\n| Port | Use |\n|---|---|\n| 28090 | HTTP |\n\n- [x] done\n")
+	if err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	for _, want := range []string{`<h2 id="setup">Setup</h2>`, "<code>make build</code>", "<table>", "<td>28090</td>", `type="checkbox"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in output:\n%s", want, html)
+		}
+	}
+}
+
+func TestRenderMarkdown_Sanitizes(t *testing.T) {
+	html, err := services.RenderMarkdown("<script>alert(1)</script>\n\nHi <img src=x onerror=alert(1)>\n\n[click](javascript:alert(1))\n")
+	if err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	for _, bad := range []string{"<script", "onerror", "javascript:"} {
+		if strings.Contains(html, bad) {
+			t.Errorf("unsafe %q survived rendering:\n%s", bad, html)
+		}
+	}
+	if !strings.Contains(html, "click") {
+		t.Errorf("link text should be kept:\n%s", html)
+	}
+}
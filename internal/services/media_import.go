@@ -0,0 +1,449 @@
+package services
+
+import (
+	// Standard library imports
+	"archive/zip"   // Reading uploaded archives
+	"bytes"         // Holding one file in memory while it is checked
+	"context"       // Cancelling a running import
+	"crypto/sha256" // Content hashes for de-duplication
+	"database/sql"  // sql.ErrNoRows and nullable dimensions
+	"encoding/hex"  // Hash encoding
+	"errors"        // Error matching
+	"fmt"           // Report details and file names
+	"image"         // Decoding images for metadata and variants
+	_ "image/gif"   // GIF decoder
+	"image/jpeg"    // JPEG decoder and variant encoder
+	"image/png"     // PNG decoder and variant encoder
+	"io"            // Reading sources
+	"io/fs"         // Walking server directories
+	"net/http"      // Content sniffing
+	"os"            // Writing files
+	"path"          // Slash-separated archive paths
+	"path/filepath" // Server paths
+	"regexp"        // File name cleaning
+	"sort"          // Stable directory order
+	"strings"       // Extension and path checks
+	"time"          // Unique file names and report timing
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// MaxMediaImportFileSize is the largest single file an import accepts, the
+// same limit as uploads through the media library.
+const MaxMediaImportFileSize = 10 << 20
+
+// maxVariantPixels stops variant generation for images whose decoded size
+// would not fit comfortably in memory (e.g. 8000×8000 scans).
+const maxVariantPixels = 40_000_000
+
+// MediaVariantSpec is one resized copy generated for raster images.
+type MediaVariantSpec struct {
+	Name     string // Stored in media_variants.name and the file name
+	MaxWidth int    // Images wider than this get the variant
+}
+
+// MediaVariantSpecs are the variants generated by imports, smallest first.
+var MediaVariantSpecs = []MediaVariantSpec{
+	{Name: "thumb", MaxWidth: 320},
+	{Name: "medium", MaxWidth: 1024},
+}
+
+// mediaImportTypes maps the importable extensions to their MIME type; the
+// same set the media library accepts for uploads.
+var mediaImportTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+	".pdf":  "application/pdf",
+}
+
+// Outcomes of one file in a MediaImportReport.
+const (
+	MediaImportImported  = "imported"  // Added to the library
+	MediaImportDuplicate = "duplicate" // Same contents as an existing file
+	MediaImportSkipped   = "skipped"   // Unsupported type or too large
+	MediaImportFailed    = "failed"    // Unreadable, corrupt, or not saved
+)
+
+// MediaImportFile is one file offered to an import.
+type MediaImportFile struct {
+	Path string                        // Slash-separated path within the source, shown in the report
+	Size int64                         // Size in bytes as reported by the source
+	Open func() (io.ReadCloser, error) // Opens the contents
+}
+
+// MediaImportItem is the outcome for one file.
+type MediaImportItem struct {
+	Path    string // Path within the source
+	Status  string // One of the MediaImport* outcomes
+	Detail  string // Reason, or what the file became
+	MediaID int64  // Library file: the new one, or the existing duplicate
+}
+
+// MediaImportReport summarises an import.
+type MediaImportReport struct {
+	Items      []MediaImportItem // One per file, in source order
+	Imported   int               // Files added
+	Duplicates int               // Files already in the library
+	Skipped    int               // Files of unsupported type or size
+	Failed     int               // Files that could not be imported
+	Variants   int               // Resized copies generated
+	Hashed     int               // Existing library files hashed for the first time
+	Started    time.Time
+	Finished   time.Time
+}
+
+// add records an item and updates the counters.
+func (r *MediaImportReport) add(item MediaImportItem) {
+	r.Items = append(r.Items, item)
+	switch item.Status {
+	case MediaImportImported:
+		r.Imported++
+	case MediaImportDuplicate:
+		r.Duplicates++
+	case MediaImportSkipped:
+		r.Skipped++
+	case MediaImportFailed:
+		r.Failed++
+	}
+}
+
+// MediaImporter adds files from archives or server directories to the media
+// library, storing them the way uploads are stored (under uploadDir/media,
+// served from /uploads/media).
+type MediaImporter struct {
+	queries   *sqlc.Queries // Media file and variant records
+	uploadDir string        // Root of /uploads, e.g. "public/uploads"
+}
+
+// NewMediaImporter creates a MediaImporter writing under uploadDir.
+func NewMediaImporter(queries *sqlc.Queries, uploadDir string) *MediaImporter {
+	return &MediaImporter{queries: queries, uploadDir: uploadDir}
+}
+
+// ZipMediaFiles lists the files of an archive. Folders, hidden files and the
+// "__MACOSX" metadata folder that macOS adds to archives are left out.
+func ZipMediaFiles(r *zip.Reader) []MediaImportFile {
+	var files []MediaImportFile
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || hiddenMediaPath(f.Name) {
+			continue
+		}
+		f := f
+		files = append(files, MediaImportFile{
+			Path: f.Name,
+			Size: int64(f.UncompressedSize64),
+			Open: func() (io.ReadCloser, error) { return f.Open() },
+		})
+	}
+	return files
+}
+
+// DirMediaFiles lists the files under a server directory, recursively and in
+// path order. Hidden files and folders are left out; symbolic links are not
+// followed, so an import cannot read outside root.
+func DirMediaFiles(root string) ([]MediaImportFile, error) {
+	var files []MediaImportFile
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		if rel != "." && hiddenMediaPath(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, MediaImportFile{
+			Path: rel,
+			Size: info.Size(),
+			Open: func() (io.ReadCloser, error) { return os.Open(p) },
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// hiddenMediaPath reports whether any element of a slash path is hidden
+// (".DS_Store", ".git/...") or archive metadata ("__MACOSX/...").
+func hiddenMediaPath(p string) bool {
+	for _, part := range strings.Split(p, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// Import adds files to the media library one at a time:
+//   - Files of other types than uploads accept, or over 10 MB, are skipped
+//   - Files whose SHA-256 matches a library file are reported as duplicates,
+//     including repeats within the same import
+//   - MIME type comes from the extension and must match the sniffed content;
+//     raster image dimensions are read from the file
+//   - JPEG, PNG and GIF images wider than a MediaVariantSpecs width get a
+//     resized copy (PNG for PNG and GIF, to keep transparency)
+//
+// Library files uploaded before content hashing are hashed first, so legacy
+// uploads are recognised as duplicates too. One file failing does not stop
+// the import; cancelling ctx does, and the report covers the files so far.
+//
+// Parameters:
+//   - ctx: Cancels the import between files
+//   - files: Files from ZipMediaFiles or DirMediaFiles
+//   - progress: Called after each file with the number done (may be nil)
+//
+// Returns:
+//   - *MediaImportReport: Outcome per file, also on error
+//   - error: Cancellation, or failing to prepare the library
+func (m *MediaImporter) Import(ctx context.Context, files []MediaImportFile, progress func(done int)) (*MediaImportReport, error) {
+	report := &MediaImportReport{Started: time.Now()}
+	defer func() { report.Finished = time.Now() }()
+
+	hashed, err := m.hashExisting(ctx)
+	report.Hashed = hashed
+	if err != nil {
+		return report, err
+	}
+	mediaDir := filepath.Join(m.uploadDir, "media")
+	if err := os.MkdirAll(filepath.Join(mediaDir, "variants"), 0755); err != nil {
+		return report, fmt.Errorf("create media directory: %w", err)
+	}
+
+	for i, f := range files {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		item, variants := m.importFile(ctx, f)
+		report.add(item)
+		report.Variants += variants
+		if progress != nil {
+			progress(i + 1)
+		}
+	}
+	return report, nil
+}
+
+// ImportFile adds a single file to the library the way Import does, for
+// uploads that are not part of a bulk import. A failed or skipped file is
+// reported in the item, not as an error.
+func (m *MediaImporter) ImportFile(ctx context.Context, f MediaImportFile) (MediaImportItem, error) {
+	if err := os.MkdirAll(filepath.Join(m.uploadDir, "media", "variants"), 0755); err != nil {
+		return MediaImportItem{}, fmt.Errorf("create media directory: %w", err)
+	}
+	item, _ := m.importFile(ctx, f)
+	return item, nil
+}
+
+// hashExisting fills in content_hash for library files that lack one. Files
+// missing from disk are left unhashed.
+func (m *MediaImporter) hashExisting(ctx context.Context) (int, error) {
+	legacy, err := m.queries.ListMediaFilesWithoutHash(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list unhashed media: %w", err)
+	}
+	n := 0
+	for _, mf := range legacy {
+		data, err := os.ReadFile(m.diskPath(mf.FilePath))
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if err := m.queries.SetMediaFileHash(ctx, sqlc.SetMediaFileHashParams{ContentHash: hex.EncodeToString(sum[:]), ID: mf.ID}); err != nil {
+			return n, fmt.Errorf("hash media file %d: %w", mf.ID, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// importFile imports one file and returns its outcome and the number of
+// variants generated.
+func (m *MediaImporter) importFile(ctx context.Context, f MediaImportFile) (MediaImportItem, int) {
+	item := MediaImportItem{Path: f.Path}
+	fail := func(status, format string, args ...interface{}) (MediaImportItem, int) {
+		item.Status, item.Detail = status, fmt.Sprintf(format, args...)
+		return item, 0
+	}
+
+	ext := strings.ToLower(path.Ext(f.Path))
+	mimeType, ok := mediaImportTypes[ext]
+	if !ok {
+		return fail(MediaImportSkipped, "unsupported file type %q", ext)
+	}
+	if f.Size > MaxMediaImportFileSize {
+		return fail(MediaImportSkipped, "larger than 10 MB")
+	}
+
+	// Read at most one byte over the limit: archive headers can understate
+	// the real size
+	rc, err := f.Open()
+	if err != nil {
+		return fail(MediaImportFailed, "cannot open: %v", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(rc, MaxMediaImportFileSize+1))
+	rc.Close()
+	if err != nil {
+		return fail(MediaImportFailed, "cannot read: %v", err)
+	}
+	if len(data) > MaxMediaImportFileSize {
+		return fail(MediaImportSkipped, "larger than 10 MB")
+	}
+	if !sniffMatches(mimeType, data) {
+		return fail(MediaImportFailed, "contents are not %s", mimeType)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	existing, err := m.queries.GetMediaFileByHash(ctx, hash)
+	if err == nil {
+		item.Status, item.MediaID = MediaImportDuplicate, existing.ID
+		item.Detail = fmt.Sprintf("same as %s (#%d)", existing.OriginalFilename, existing.ID)
+		return item, 0
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fail(MediaImportFailed, "database error: %v", err)
+	}
+
+	var width, height int
+	raster := mimeType == "image/jpeg" || mimeType == "image/png" || mimeType == "image/gif"
+	if raster {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return fail(MediaImportFailed, "not a readable image: %v", err)
+		}
+		width, height = cfg.Width, cfg.Height
+	}
+
+	filename := fmt.Sprintf("%d_%s", time.Now().UnixNano(), mediaImportFilename(path.Base(f.Path)))
+	diskPath := filepath.Join(m.uploadDir, "media", filename)
+	if err := os.WriteFile(diskPath, data, 0644); err != nil {
+		return fail(MediaImportFailed, "cannot save: %v", err)
+	}
+	mf, err := m.queries.CreateMediaFile(ctx, sqlc.CreateMediaFileParams{
+		Filename:         filename,
+		OriginalFilename: f.Path,
+		FilePath:         "/uploads/media/" + filename,
+		FileSize:         int64(len(data)),
+		MimeType:         mimeType,
+		Width:            sql.NullInt64{Int64: int64(width), Valid: width > 0},
+		Height:           sql.NullInt64{Int64: int64(height), Valid: height > 0},
+		AltText:          sql.NullString{String: "", Valid: true},
+		ContentHash:      hash,
+	})
+	if err != nil {
+		os.Remove(diskPath)
+		return fail(MediaImportFailed, "database error: %v", err)
+	}
+	item.Status, item.MediaID = MediaImportImported, mf.ID
+	item.Detail = mimeType
+	if width > 0 {
+		item.Detail = fmt.Sprintf("%s, %d×%d", mimeType, width, height)
+	}
+
+	variants := 0
+	if raster && width*height <= maxVariantPixels {
+		n, err := m.generateVariants(ctx, mf, data)
+		variants = n
+		if err != nil {
+			item.Detail += "; variants failed: " + err.Error()
+		}
+	} else if raster {
+		item.Detail += "; too large for variants"
+	}
+	return item, variants
+}
+
+// generateVariants writes and records the resized copies of an image.
+func (m *MediaImporter) generateVariants(ctx context.Context, mf sqlc.MediaFile, data []byte) (int, error) {
+	var src image.Image
+	n := 0
+	for _, spec := range MediaVariantSpecs {
+		if mf.Width.Int64 <= int64(spec.MaxWidth) {
+			continue
+		}
+		if src == nil {
+			var err error
+			if src, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+				return n, err
+			}
+		}
+		dst := ResizeImage(src, spec.MaxWidth)
+
+		ext, encode := ".png", func(w io.Writer) error { return png.Encode(w, dst) }
+		if mf.MimeType == "image/jpeg" {
+			ext, encode = ".jpg", func(w io.Writer) error { return jpeg.Encode(w, dst, &jpeg.Options{Quality: 85}) }
+		}
+		var buf bytes.Buffer
+		if err := encode(&buf); err != nil {
+			return n, err
+		}
+		stem := strings.TrimSuffix(mf.Filename, filepath.Ext(mf.Filename))
+		name := stem + "_" + spec.Name + ext
+		if err := os.WriteFile(filepath.Join(m.uploadDir, "media", "variants", name), buf.Bytes(), 0644); err != nil {
+			return n, err
+		}
+		b := dst.Bounds()
+		if _, err := m.queries.CreateMediaVariant(ctx, sqlc.CreateMediaVariantParams{
+			MediaFileID: mf.ID,
+			Name:        spec.Name,
+			FilePath:    "/uploads/media/variants/" + name,
+			Width:       int64(b.Dx()),
+			Height:      int64(b.Dy()),
+			FileSize:    int64(buf.Len()),
+		}); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// diskPath converts a /uploads/... web path to its file under uploadDir.
+func (m *MediaImporter) diskPath(webPath string) string {
+	return filepath.Join(m.uploadDir, filepath.FromSlash(strings.TrimPrefix(webPath, "/uploads/")))
+}
+
+// sniffMatches reports whether data looks like mimeType. SVG and WebP are
+// checked by their markers, since http.DetectContentType reports SVG as
+// text and does not know every WebP variant.
+func sniffMatches(mimeType string, data []byte) bool {
+	switch mimeType {
+	case "image/svg+xml":
+		head := data[:min(len(data), 1024)]
+		return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+	case "image/webp":
+		return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+	}
+	return http.DetectContentType(data) == mimeType
+}
+
+// mediaImportFilenameRegexp matches runs of characters not kept in stored names.
+var mediaImportFilenameRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// mediaImportFilename makes a file name safe for disk and URLs, keeping
+// letters, digits, dots, dashes and underscores.
+func mediaImportFilename(name string) string {
+	name = strings.Trim(mediaImportFilenameRegexp.ReplaceAllString(name, "_"), "_")
+	if name == "" || strings.HasPrefix(name, ".") {
+		name = "file" + name
+	}
+	return name
+}
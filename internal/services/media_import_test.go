@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// testPNG returns a PNG of the given size filled with c.
+func testPNG(t *testing.T, w, h int, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestMediaImporter_Zip(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	uploadDir := t.TempDir()
+
+	wide := testPNG(t, 1200, 600, color.RGBA{255, 0, 0, 255})
+	small := testPNG(t, 100, 50, color.RGBA{0, 0, 255, 255})
+
+	// A library file uploaded before hashing, with the same contents as small
+	os.MkdirAll(filepath.Join(uploadDir, "media"), 0755)
+	os.WriteFile(filepath.Join(uploadDir, "media", "old.png"), small, 0644)
+	legacy, err := queries.CreateMediaFile(ctx, sqlc.CreateMediaFileParams{
+		Filename: "old.png", OriginalFilename: "old.png", FilePath: "/uploads/media/old.png",
+		FileSize: int64(len(small)), MimeType: "image/png", AltText: sql.NullString{Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range map[string][]byte{
+		"photos/wide.png":         wide,
+		"photos/copy of wide.png": wide,
+		"small.png":               small,
+		"notes.txt":               []byte("hello"),
+		"fake.png":                []byte("not an image at all"),
+		"__MACOSX/._wide.png":     []byte("metadata"),
+	} {
+		w, _ := zw.Create(name)
+		w.Write(data)
+	}
+	zw.Close()
+	zr, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+	files := services.ZipMediaFiles(zr)
+	if len(files) != 5 {
+		t.Fatalf("files = %d, want 5 (archive metadata left out)", len(files))
+	}
+	var progress int
+	report, err := services.NewMediaImporter(queries, uploadDir).Import(ctx, files, func(done int) { progress = done })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Imported != 1 || report.Duplicates != 2 || report.Skipped != 1 || report.Failed != 1 || report.Hashed != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if progress != 5 {
+		t.Errorf("progress = %d, want 5", progress)
+	}
+
+	statuses := map[string]services.MediaImportItem{}
+	for _, item := range report.Items {
+		statuses[item.Path] = item
+	}
+	if item := statuses["small.png"]; item.Status != services.MediaImportDuplicate || item.MediaID != legacy.ID {
+		t.Errorf("small.png = %+v, want duplicate of the legacy upload", item)
+	}
+	imported := statuses["photos/copy of wide.png"]
+	if imported.Status != services.MediaImportImported {
+		// Zip order decides which copy is imported
+		imported = statuses["photos/wide.png"]
+	}
+	mf, err := queries.GetMediaFile(ctx, imported.MediaID)
+	if err != nil || mf.Width.Int64 != 1200 || mf.ContentHash == "" {
+		t.Fatalf("imported file = %+v, %v", mf, err)
+	}
+	if _, err := os.Stat(filepath.Join(uploadDir, "media", mf.Filename)); err != nil {
+		t.Errorf("imported file not on disk: %v", err)
+	}
+
+	// 1200px wide: both variants, each on disk at its width
+	variants, _ := queries.ListMediaVariants(ctx, mf.ID)
+	if len(variants) != 2 || report.Variants != 2 {
+		t.Fatalf("variants = %+v", variants)
+	}
+	for i, want := range []int64{320, 1024} {
+		v := variants[i]
+		if v.Width != want || v.Height != want/2 {
+			t.Errorf("variant %s = %dx%d", v.Name, v.Width, v.Height)
+		}
+		data, err := os.ReadFile(filepath.Join(uploadDir, "media", "variants", filepath.Base(v.FilePath)))
+		if err != nil {
+			t.Fatalf("variant %s not on disk: %v", v.Name, err)
+		}
+		cfg, err := png.DecodeConfig(bytes.NewReader(data))
+		if err != nil || int64(cfg.Width) != want {
+			t.Errorf("variant %s file: %v %v", v.Name, cfg, err)
+		}
+	}
+}
+
+func TestMediaImporter_Dir(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "a", "b"), 0755)
+	os.MkdirAll(filepath.Join(root, ".git"), 0755)
+	os.WriteFile(filepath.Join(root, "a", "b", "logo.svg"), []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), 0644)
+	os.WriteFile(filepath.Join(root, "a", "dot.png"), testPNG(t, 10, 10, color.Black), 0644)
+	os.WriteFile(filepath.Join(root, ".git", "x.png"), testPNG(t, 10, 10, color.White), 0644)
+	os.WriteFile(filepath.Join(root, ".DS_Store"), []byte("x"), 0644)
+
+	files, err := services.DirMediaFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Path != "a/b/logo.svg" || files[1].Path != "a/dot.png" {
+		t.Fatalf("files = %+v", files)
+	}
+
+	importer := services.NewMediaImporter(queries, t.TempDir())
+	report, err := importer.Import(context.Background(), files, nil)
+	if err != nil || report.Imported != 2 || report.Variants != 0 {
+		t.Fatalf("report = %+v, %v", report, err)
+	}
+	// Importing the same folder again stores nothing
+	report, _ = importer.Import(context.Background(), files, nil)
+	if report.Imported != 0 || report.Duplicates != 2 {
+		t.Errorf("second import = %+v", report)
+	}
+}
+
+func TestResizeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	// Left half black, right half white
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x >= 2 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+	dst := services.ResizeImage(src, 2)
+	if b := dst.Bounds(); b.Dx() != 2 || b.Dy() != 1 {
+		t.Fatalf("size = %v", b)
+	}
+	if r, _, _, _ := dst.At(0, 0).RGBA(); r != 0 {
+		t.Errorf("left pixel r = %d, want black", r)
+	}
+	if r, _, _, _ := dst.At(1, 0).RGBA(); r != 0xffff {
+		t.Errorf("right pixel r = %d, want white", r)
+	}
+	if b := services.ResizeImage(src, 10).Bounds(); b.Dx() != 4 {
+		t.Errorf("narrow image should keep its size, got %v", b)
+	}
+}
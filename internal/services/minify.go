@@ -0,0 +1,177 @@
+package services
+
+import (
+	// Standard library imports
+	"strings" // Case-insensitive tag matching and output building
+)
+
+// minifyRawTags are elements whose content is copied verbatim: whitespace is
+// significant in preformatted text and form fields, and collapsing it inside
+// scripts or stylesheets could change their meaning (e.g. // line comments).
+var minifyRawTags = map[string]bool{
+	"pre": true, "code": true, "textarea": true, "script": true, "style": true,
+}
+
+// minifyBlockTags are elements next to which whitespace-only text never
+// renders, so it can be dropped instead of collapsed to a single space.
+var minifyBlockTags = map[string]bool{
+	"!doctype": true, "html": true, "head": true, "body": true, "meta": true, "link": true,
+	"title": true, "base": true, "script": true, "style": true, "noscript": true,
+	"div": true, "p": true, "section": true, "article": true, "aside": true, "main": true,
+	"header": true, "footer": true, "nav": true, "figure": true, "figcaption": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "hr": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "thead": true, "tbody": true, "tfoot": true, "tr": true, "th": true, "td": true,
+	"form": true, "fieldset": true, "option": true, "blockquote": true, "svg": true, "path": true,
+}
+
+// MinifyHTML strips comments and redundant whitespace from a rendered page to
+// reduce its size before it is cached and served.
+//
+// Rules:
+//   - HTML comments are removed, except IE conditional comments (<!--[if ...])
+//   - Runs of whitespace in text collapse to a single space
+//   - Whitespace-only text next to block-level elements is removed
+//   - Tags (including attribute values) are copied unchanged
+//   - <pre>, <code>, <textarea>, <script>, and <style> content is copied unchanged
+//
+// Parameters:
+//   - src: Complete HTML document or fragment
+//
+// Returns:
+//   - string: Minified HTML that renders identically to src
+func MinifyHTML(src string) string {
+	out := make([]byte, 0, len(src))
+	prevTag := "" // Name of the tag before the current text ("" at the start)
+	i := 0
+	for i < len(src) {
+		if !isTagStart(src, i) {
+			end := i + 1
+			for end < len(src) && !isTagStart(src, end) {
+				end++
+			}
+			out = appendText(out, src[i:end], prevTag, nextTagName(src, end))
+			i = end
+			continue
+		}
+
+		if strings.HasPrefix(src[i:], "<!--") {
+			end := strings.Index(src[i+4:], "-->")
+			if end < 0 {
+				return string(append(out, src[i:]...))
+			}
+			end += i + 4 + len("-->")
+			if strings.HasPrefix(src[i:], "<!--[if") || strings.HasPrefix(src[i:], "<!--<![endif") {
+				out = append(out, src[i:end]...)
+			}
+			i = end
+			continue
+		}
+
+		name := nextTagName(src, i)
+		end := tagEnd(src, i)
+		out = append(out, src[i:end]...)
+		i = end
+		prevTag = name
+
+		if minifyRawTags[name] {
+			closeStart := findClosingTag(src, i, name)
+			if closeStart < 0 {
+				return string(append(out, src[i:]...))
+			}
+			closeEnd := tagEnd(src, closeStart)
+			out = append(out, src[i:closeEnd]...)
+			i = closeEnd
+		}
+	}
+	return string(out)
+}
+
+// isTagStart reports whether src[i] opens a tag, closing tag, comment, or
+// doctype rather than being a literal "<" in text.
+func isTagStart(src string, i int) bool {
+	if src[i] != '<' || i+1 >= len(src) {
+		return false
+	}
+	c := src[i+1]
+	return c == '/' || c == '!' || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
+
+// nextTagName returns the lower-cased name of the tag starting at i, without
+// a leading "/" for closing tags, or "" when there is no tag at i.
+func nextTagName(src string, i int) string {
+	if i >= len(src) || !isTagStart(src, i) {
+		return ""
+	}
+	start := i + 1
+	if src[start] == '/' {
+		start++
+	}
+	end := start
+	for end < len(src) && !strings.ContainsRune(" \t\r\n/>", rune(src[end])) {
+		end++
+	}
+	return strings.ToLower(src[start:end])
+}
+
+// tagEnd returns the index just past the ">" closing the tag at i, skipping
+// any ">" inside quoted attribute values.
+func tagEnd(src string, i int) int {
+	var quote byte
+	for j := i + 1; j < len(src); j++ {
+		switch c := src[j]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return j + 1
+		}
+	}
+	return len(src)
+}
+
+// findClosingTag returns the index of the "</name" that closes a raw element
+// whose content starts at i, or -1 when it is missing.
+func findClosingTag(src string, i int, name string) int {
+	for {
+		j := strings.Index(src[i:], "</")
+		if j < 0 {
+			return -1
+		}
+		j += i
+		if nextTagName(src, j) == name {
+			return j
+		}
+		i = j + 2
+	}
+}
+
+// appendText appends text with whitespace runs collapsed. Whitespace-only text
+// between a block-level tag and its neighbour, or at the document edges, is dropped.
+func appendText(out []byte, text, prevTag, nextTag string) []byte {
+	if strings.TrimSpace(text) == "" &&
+		(prevTag == "" || nextTag == "" || minifyBlockTags[prevTag] || minifyBlockTags[nextTag]) {
+		return out
+	}
+	inSpace := len(out) > 0 && isSpace(out[len(out)-1])
+	for k := 0; k < len(text); k++ {
+		if isSpace(text[k]) {
+			if !inSpace {
+				out = append(out, ' ')
+				inSpace = true
+			}
+			continue
+		}
+		out = append(out, text[k])
+		inSpace = false
+	}
+	return out
+}
+
+// isSpace reports whether c is HTML inter-element whitespace.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
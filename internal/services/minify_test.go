@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestMinifyHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "block whitespace removed",
+			in:   "<!DOCTYPE html>\n<html>\n  <body>\n    <div>\n      <p>Hello</p>\n    </div>\n  </body>\n</html>\n",
+			want: "<!DOCTYPE html><html><body><div><p>Hello</p></div></body></html>",
+		},
+		{
+			name: "inline whitespace collapsed to one space",
+			in:   "<p>Hello,\n    <strong>world</strong>   <em>again</em>\t!</p>",
+			want: "<p>Hello, <strong>world</strong> <em>again</em> !</p>",
+		},
+		{
+			name: "comments stripped without doubling spaces",
+			in:   "<p>one <!-- note --> two</p>",
+			want: "<p>one two</p>",
+		},
+		{
+			name: "conditional comments kept",
+			in:   "<head>\n<!--[if lt IE 9]><script src=\"x.js\"></script><![endif]-->\n</head>",
+			want: "<head><!--[if lt IE 9]><script src=\"x.js\"></script><![endif]--></head>",
+		},
+		{
+			name: "pre and code preserved",
+			in:   "<div>\n  <pre><code>func main() {\n    fmt.Println(\"hi\")\n}</code></pre>\n  <p>see <code>a   b</code></p>\n</div>",
+			want: "<div><pre><code>func main() {\n    fmt.Println(\"hi\")\n}</code></pre><p>see <code>a   b</code></p></div>",
+		},
+		{
+			name: "script, style, and textarea preserved",
+			in:   "<script>\n  // keep <b> as-is\n  var a = 1;\n</script>\n<style>\n  p { color: red; }\n</style>\n<form>\n  <textarea name=\"m\">line 1\n\n  line 2</textarea>\n</form>",
+			want: "<script>\n  // keep <b> as-is\n  var a = 1;\n</script><style>\n  p { color: red; }\n</style><form><textarea name=\"m\">line 1\n\n  line 2</textarea></form>",
+		},
+		{
+			name: "attributes unchanged",
+			in:   "<a title=\"a  >  b\"   href=\"/x\">link</a>",
+			want: "<a title=\"a  >  b\"   href=\"/x\">link</a>",
+		},
+		{
+			name: "literal less-than in text",
+			in:   "<p>1 <  2</p>",
+			want: "<p>1 < 2</p>",
+		},
+		{
+			name: "uppercase raw tags",
+			in:   "<PRE>  a\n  b</PRE>",
+			want: "<PRE>  a\n  b</PRE>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.MinifyHTML(tt.in); got != tt.want {
+				t.Errorf("MinifyHTML()\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	// Standard library imports
+	"context"     // Request cancellation for menu loading
+	"regexp"      // Icon name validation
+	"strings"     // URL inspection
+	"sync"        // Guards the menu snapshot, which changes when menus are edited
+	"sync/atomic" // Single background refresh at a time
+	"time"        // Menu refresh interval
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"         // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/models" // MenuItem view model rendered by the templates
+)
+
+// Navigation menu locations rendered by the public templates.
+const (
+	NavHeader      = "header"       // Header bar, replacing the links of Global Settings
+	NavFooter      = "footer"       // Footer link columns, replacing products and solutions
+	NavFooterLegal = "footer_legal" // Links beside the copyright notice
+	NavMobile      = "mobile"       // Mobile menu; the header menu is used when there is none
+)
+
+// Navigation item visibility values (navigation_items.visibility).
+const (
+	NavVisibleAll     = "all"
+	NavVisibleDesktop = "desktop"
+	NavVisibleMobile  = "mobile"
+)
+
+// NavigationVisibilities lists the visibility values the menu editor offers.
+var NavigationVisibilities = []string{NavVisibleAll, NavVisibleDesktop, NavVisibleMobile}
+
+// NormalizeNavVisibility returns v when it is a known visibility value and
+// NavVisibleAll otherwise.
+func NormalizeNavVisibility(v string) string {
+	for _, known := range NavigationVisibilities {
+		if v == known {
+			return v
+		}
+	}
+	return NavVisibleAll
+}
+
+// Navigation dropdown layouts (navigation_items.layout).
+const (
+	NavLayoutList = "list" // Children in a dropdown list; deeper levels fly out
+	NavLayoutMega = "mega" // Children as the columns of a wide panel
+)
+
+// NavFeaturedTypes lists the content types a dropdown can feature as a card,
+// in the order the menu editor offers them. They reuse the preview content
+// types, as SEO overrides do.
+var NavFeaturedTypes = []SEOEntityType{
+	{PreviewProduct, "Product"},
+	{PreviewSolution, "Solution"},
+	{PreviewBlogPost, "Blog post"},
+	{PreviewCaseStudy, "Case study"},
+}
+
+// IsNavFeaturedType reports whether a dropdown can feature content of type t.
+func IsNavFeaturedType(t string) bool {
+	for _, ft := range NavFeaturedTypes {
+		if ft.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// navIconPattern matches Material Symbols icon names such as "memory".
+var navIconPattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// NormalizeNavIcon returns the trimmed icon name when it is a valid Material
+// Symbols name and "" otherwise.
+func NormalizeNavIcon(icon string) string {
+	icon = strings.TrimSpace(icon)
+	if !navIconPattern.MatchString(icon) {
+		return ""
+	}
+	return icon
+}
+
+// navigationRefresh is how long the menus are served before Reload runs
+// again in the background, so featured cards follow changes to the content
+// they show (titles, images, unpublishing) without a menu edit.
+const navigationRefresh = 5 * time.Minute
+
+// Navigation serves the active navigation menus to the public site from
+// memory. The menu editor calls Reload after each change, so rendering a
+// page never queries the database for its menus.
+type Navigation struct {
+	queries *sqlc.Queries // Menu storage and featured content
+
+	mu         sync.RWMutex                 // Guards menus and loaded
+	menus      map[string][]models.MenuItem // Top-level items keyed by location
+	loaded     time.Time                    // When menus were last loaded
+	refreshing atomic.Bool                  // A background refresh is running
+}
+
+// NewNavigation creates the navigation service. Call Reload before serving
+// requests to load the active menus.
+func NewNavigation(queries *sqlc.Queries) *Navigation {
+	return &Navigation{queries: queries, menus: map[string][]models.MenuItem{}, loaded: time.Now()}
+}
+
+// Reload replaces the in-memory menus with the visible items of the active
+// menus. Children are nested under their parent in display order; children
+// of hidden items are left out along with their parent. Featured content is
+// looked up here, so rendering never queries it.
+func (n *Navigation) Reload(ctx context.Context) error {
+	rows, err := n.queries.ListActiveNavigationItems(ctx)
+	if err != nil {
+		return err
+	}
+	featured := make(map[int64]*models.FeaturedCard)
+	for _, row := range rows {
+		if row.FeaturedType != "" {
+			featured[row.ID] = n.featuredCard(ctx, row.FeaturedType, row.FeaturedID)
+		}
+	}
+	menus := buildMenus(rows, featured)
+
+	n.mu.Lock()
+	n.menus, n.loaded = menus, time.Now()
+	n.mu.Unlock()
+	return nil
+}
+
+// Menus returns the active menus keyed by location. The map and its items
+// are shared between requests and must not be modified. Menus older than
+// navigationRefresh are returned as they are while a background Reload
+// fetches fresh ones; a failed refresh keeps the current menus.
+func (n *Navigation) Menus() map[string][]models.MenuItem {
+	n.mu.RLock()
+	menus, loaded := n.menus, n.loaded
+	n.mu.RUnlock()
+	if time.Since(loaded) > navigationRefresh && n.refreshing.CompareAndSwap(false, true) {
+		go func() {
+			defer n.refreshing.Store(false)
+			_ = n.Reload(context.Background())
+		}()
+	}
+	return menus
+}
+
+// featuredCard looks up the content featured by a dropdown. It returns nil
+// for unknown types and for content that is missing, unpublished or deleted.
+func (n *Navigation) featuredCard(ctx context.Context, contentType string, id int64) *models.FeaturedCard {
+	switch contentType {
+	case PreviewProduct:
+		p, err := n.queries.GetProduct(ctx, id)
+		if err != nil || p.Status != "published" || p.DeletedAt.Valid {
+			return nil
+		}
+		cat, err := n.queries.GetProductCategory(ctx, p.CategoryID)
+		if err != nil {
+			return nil
+		}
+		return &models.FeaturedCard{Kind: "Product", Title: p.Name, Summary: p.Tagline.String, Image: p.PrimaryImage.String, URL: "/products/" + cat.Slug + "/" + p.Slug}
+	case PreviewSolution:
+		sol, err := n.queries.GetSolutionByID(ctx, id)
+		if err != nil || !sol.IsPublished.Bool || sol.DeletedAt.Valid {
+			return nil
+		}
+		return &models.FeaturedCard{Kind: "Solution", Title: sol.Title, Summary: sol.ShortDescription, Image: sol.HeroImageUrl.String, URL: "/solutions/" + sol.Slug}
+	case PreviewBlogPost:
+		post, err := n.queries.GetBlogPost(ctx, id)
+		if err != nil || post.Status != "published" || post.DeletedAt.Valid {
+			return nil
+		}
+		return &models.FeaturedCard{Kind: "Blog post", Title: post.Title, Summary: post.Excerpt, Image: post.FeaturedImageUrl.String, URL: "/blog/" + post.Slug}
+	case PreviewCaseStudy:
+		cs, err := n.queries.AdminGetCaseStudy(ctx, id)
+		if err != nil || cs.IsPublished != 1 || cs.DeletedAt.Valid {
+			return nil
+		}
+		return &models.FeaturedCard{Kind: "Case study", Title: cs.Title, Summary: cs.Summary, Image: cs.HeroImageUrl.String, URL: "/case-studies/" + cs.Slug}
+	}
+	return nil
+}
+
+// buildMenus nests the active menu items, which arrive in display order, into
+// one tree per location. Items whose parent is hidden are left out.
+func buildMenus(rows []sqlc.ListActiveNavigationItemsRow, featured map[int64]*models.FeaturedCard) map[string][]models.MenuItem {
+	children := map[int64][]sqlc.ListActiveNavigationItemsRow{}
+	for _, row := range rows {
+		if row.ParentID.Valid {
+			children[row.ParentID.Int64] = append(children[row.ParentID.Int64], row)
+		}
+	}
+
+	visited := map[int64]bool{} // Guards against parent cycles
+	var build func(row sqlc.ListActiveNavigationItemsRow) models.MenuItem
+	build = func(row sqlc.ListActiveNavigationItemsRow) models.MenuItem {
+		visited[row.ID] = true
+		item := menuItem(row)
+		item.Featured = featured[row.ID]
+		for _, child := range children[row.ID] {
+			if visited[child.ID] {
+				continue
+			}
+			if c := build(child); c.URL != "" || len(c.Children) > 0 {
+				item.Children = append(item.Children, c)
+			}
+		}
+		item.Mega = item.Mega && len(item.Children) > 0
+		return item
+	}
+
+	menus := map[string][]models.MenuItem{}
+	for _, row := range rows {
+		if row.ParentID.Valid {
+			continue
+		}
+		item := build(row)
+		if item.URL == "" && len(item.Children) == 0 && item.Featured == nil {
+			continue // A dropdown without visible items leads nowhere
+		}
+		menus[row.Location] = append(menus[row.Location], item)
+	}
+	return menus
+}
+
+// menuItem converts a stored item into its rendered form. Page links without
+// a stored URL point at the page's slug, as the menu editor sets when they
+// are added.
+func menuItem(row sqlc.ListActiveNavigationItemsRow) models.MenuItem {
+	url := strings.TrimSpace(row.Url.String)
+	if url == "" && row.LinkType == "page" && row.PageIdentifier.String != "" {
+		url = "/" + Slugify(row.PageIdentifier.String)
+	}
+	if row.LinkType == "dropdown" {
+		url = ""
+	}
+	external := strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "//")
+	return models.MenuItem{
+		Label:      row.Label,
+		URL:        url,
+		NewTab:     row.OpenNewTab.Int64 == 1 || external,
+		External:   external,
+		Visibility: NormalizeNavVisibility(row.Visibility),
+		Icon:       NormalizeNavIcon(row.Icon),
+		Mega:       row.Layout == NavLayoutMega && !row.ParentID.Valid,
+	}
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestNavigation_Reload(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	menu := func(location string, active bool) int64 {
+		t.Helper()
+		m, err := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: location, Location: location})
+		if err != nil {
+			t.Fatalf("create menu: %v", err)
+		}
+		if err := queries.SetNavigationMenuActive(ctx, sqlc.SetNavigationMenuActiveParams{IsActive: active, ID: m.ID}); err != nil {
+			t.Fatalf("activate menu: %v", err)
+		}
+		return m.ID
+	}
+	item := func(menuID int64, parent int64, label, linkType, url, page string, sort, active int64) int64 {
+		t.Helper()
+		it, err := queries.CreateNavigationItem(ctx, sqlc.CreateNavigationItemParams{
+			MenuID:         menuID,
+			ParentID:       sql.NullInt64{Int64: parent, Valid: parent != 0},
+			Label:          label,
+			LinkType:       linkType,
+			Url:            sql.NullString{String: url, Valid: url != ""},
+			PageIdentifier: sql.NullString{String: page, Valid: page != ""},
+			OpenNewTab:     sql.NullInt64{Int64: 0, Valid: true},
+			IsActive:       sql.NullInt64{Int64: active, Valid: true},
+			SortOrder:      sql.NullInt64{Int64: sort, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+		return it.ID
+	}
+
+	header := menu("header", true)
+	item(header, 0, "Blog", "custom", "/blog", "", 2, 1)
+	about := item(header, 0, "About", "page", "", "Case Studies", 0, 1)
+	more := item(header, 0, "More", "dropdown", "", "", 1, 1)
+	item(header, more, "Docs", "custom", "https://docs.example.com", "", 0, 1)
+	item(header, about, "Team", "custom", "/x", "", 0, 1)
+	hidden := item(header, 0, "Hidden", "custom", "/hidden", "", 3, 0)
+	item(header, hidden, "Orphan", "custom", "/orphan", "", 0, 1)
+	item(header, 0, "Empty dropdown", "dropdown", "", "", 4, 1)
+	item(menu("footer", false), 0, "Inactive menu", "custom", "/nope", "", 0, 1)
+
+	nav := services.NewNavigation(queries)
+	if err := nav.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	menus := nav.Menus()
+	if _, ok := menus["footer"]; ok {
+		t.Error("inactive footer menu was loaded")
+	}
+	got := menus["header"]
+	if len(got) != 3 || got[0].Label != "About" || got[1].Label != "More" || got[2].Label != "Blog" {
+		t.Fatalf("header = %+v, want About, More, Blog", got)
+	}
+	if got[0].URL != "/case-studies" {
+		t.Errorf("page link URL = %q, want /case-studies", got[0].URL)
+	}
+	if len(got[0].Children) != 1 {
+		t.Errorf("About has %d children, want 1", len(got[0].Children))
+	}
+	if got[1].URL != "" || len(got[1].Children) != 1 {
+		t.Fatalf("dropdown = %+v", got[1])
+	}
+	if docs := got[1].Children[0]; !docs.External || !docs.NewTab || docs.Visibility != services.NavVisibleAll {
+		t.Errorf("external child = %+v", docs)
+	}
+	if got[2].External || got[2].NewTab {
+		t.Errorf("site link = %+v, want same-tab internal link", got[2])
+	}
+}
+
+func TestDeactivateOtherNavigationMenus(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	var ids []int64
+	for _, location := range []string{"header", "header", "footer"} {
+		m, err := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: location, Location: location})
+		if err != nil {
+			t.Fatalf("create menu: %v", err)
+		}
+		if err := queries.SetNavigationMenuActive(ctx, sqlc.SetNavigationMenuActiveParams{IsActive: true, ID: m.ID}); err != nil {
+			t.Fatalf("activate menu: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	if err := queries.DeactivateOtherNavigationMenus(ctx, sqlc.DeactivateOtherNavigationMenusParams{Location: "header", ID: ids[1]}); err != nil {
+		t.Fatalf("DeactivateOtherNavigationMenus: %v", err)
+	}
+	for i, want := range []bool{false, true, true} {
+		m, err := queries.GetNavigationMenu(ctx, ids[i])
+		if err != nil {
+			t.Fatalf("get menu: %v", err)
+		}
+		if m.IsActive != want {
+			t.Errorf("menu %d (%s) active = %v, want %v", i, m.Location, m.IsActive, want)
+		}
+	}
+}
+
+func TestNavigation_MegaMenu(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i", SortOrder: 1})
+	live, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "S1", Slug: "s1", Name: "Sensor One", Tagline: sql.NullString{String: "Fast", Valid: true}, Description: "d", CategoryID: cat.ID, Status: "published"})
+	draft, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "S2", Slug: "s2", Name: "Sensor Two", Description: "d", CategoryID: cat.ID, Status: "draft"})
+
+	m, _ := queries.CreateNavigationMenu(ctx, sqlc.CreateNavigationMenuParams{Name: "Main", Location: "header"})
+	queries.SetNavigationMenuActive(ctx, sqlc.SetNavigationMenuActiveParams{IsActive: true, ID: m.ID})
+	add := func(parent int64, label, url string, sort int64) sqlc.NavigationItem {
+		t.Helper()
+		it, err := queries.CreateNavigationItem(ctx, sqlc.CreateNavigationItemParams{
+			MenuID: m.ID, ParentID: sql.NullInt64{Int64: parent, Valid: parent != 0}, Label: label, LinkType: "custom",
+			Url: sql.NullString{String: url, Valid: url != ""}, IsActive: sql.NullInt64{Int64: 1, Valid: true}, SortOrder: sql.NullInt64{Int64: sort, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+		return it
+	}
+	set := func(it sqlc.NavigationItem, icon, layout, featuredType string, featuredID int64) {
+		t.Helper()
+		if err := queries.UpdateNavigationItem(ctx, sqlc.UpdateNavigationItemParams{
+			Label: it.Label, LinkType: it.LinkType, Url: it.Url, IsActive: it.IsActive, ParentID: it.ParentID, SortOrder: it.SortOrder,
+			Visibility: "all", Icon: icon, Layout: layout, FeaturedType: featuredType, FeaturedID: featuredID, ID: it.ID,
+		}); err != nil {
+			t.Fatalf("update item: %v", err)
+		}
+	}
+
+	products := add(0, "Products", "", 0)
+	set(products, "", services.NavLayoutMega, services.PreviewProduct, live.ID)
+	column := add(products.ID, "Sensors", "/products/sensors", 0)
+	set(column, "sensors", "", "", 0)
+	sub := add(column.ID, "Industrial", "/products/sensors/industrial", 0)
+	add(sub.ID, "Rugged", "/products/sensors/rugged", 0)
+	resources := add(0, "Resources", "", 1)
+	set(resources, "bad icon!", services.NavLayoutMega, services.PreviewProduct, draft.ID)
+	add(resources.ID, "Docs", "/docs", 0)
+
+	nav := services.NewNavigation(queries)
+	if err := nav.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	header := nav.Menus()["header"]
+	if len(header) != 2 {
+		t.Fatalf("header = %+v", header)
+	}
+	p := header[0]
+	if !p.Mega || p.Featured == nil || p.Featured.Title != "Sensor One" || p.Featured.Summary != "Fast" || p.Featured.URL != "/products/sensors/s1" {
+		t.Errorf("products item = %+v, featured %+v", p, p.Featured)
+	}
+	if len(p.Children) != 1 || p.Children[0].Icon != "sensors" || len(p.Children[0].Children) != 1 || len(p.Children[0].Children[0].Children) != 1 {
+		t.Errorf("products children not nested three levels deep: %+v", p.Children)
+	}
+	if r := header[1]; r.Featured != nil || r.Icon != "" {
+		t.Errorf("resources item = %+v, want no card for a draft product and no invalid icon", r)
+	}
+}
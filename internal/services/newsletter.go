@@ -0,0 +1,355 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes"         // Provider request bodies
+	"context"       // Request cancellation and provider timeouts
+	"crypto/md5"    // Mailchimp member IDs
+	"database/sql"  // sql.ErrNoRows
+	"encoding/hex"  // Member IDs
+	"encoding/json" // Provider request payloads
+	"errors"        // Newsletter errors
+	"fmt"           // Confirmation email text and provider errors
+	"io"            // Reading provider error bodies
+	"log/slog"      // Structured logging of email and provider failures
+	"net/http"      // Provider API calls
+	"net/url"       // Escaping the list ID
+	"strings"       // Trimming addresses and reading the data center
+	"time"          // Confirmation lifetime and timeouts
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Newsletter subscriber statuses (newsletter_subscribers.status).
+const (
+	NewsletterPending      = "pending"      // Signed up, confirmation email sent
+	NewsletterConfirmed    = "confirmed"    // Followed the confirmation link
+	NewsletterUnsubscribed = "unsubscribed" // Followed an unsubscribe link
+)
+
+// NewsletterStatuses lists the subscriber statuses in lifecycle order.
+var NewsletterStatuses = []string{NewsletterPending, NewsletterConfirmed, NewsletterUnsubscribed}
+
+// NewsletterConfirmTTL is how long an emailed confirmation link works.
+const NewsletterConfirmTTL = 7 * 24 * time.Hour
+
+// NewsletterMailchimp is the NEWSLETTER_PROVIDER value for Mailchimp.
+const NewsletterMailchimp = "mailchimp"
+
+// Errors returned by Newsletter. Their messages are shown to visitors.
+var (
+	ErrNewsletterInvalidEmail = errors.New("enter a valid email address")
+	ErrNewsletterLinkInvalid  = errors.New("this link is invalid or has expired")
+)
+
+// NewsletterProvider is a mailing list service that sends the actual
+// newsletters. Confirmed subscribers are added to its list and unsubscribes
+// are passed on, so the list only ever holds double opt-in addresses.
+type NewsletterProvider interface {
+	// Subscribe adds or re-subscribes an address.
+	Subscribe(ctx context.Context, email string) error
+	// Unsubscribe marks an address as unsubscribed. Addresses the provider
+	// does not know are not an error.
+	Unsubscribe(ctx context.Context, email string) error
+}
+
+// NewNewsletterProvider returns the provider client for a configured
+// provider, or nil when none is configured or the provider is unknown.
+//
+// Parameters:
+//   - provider: NEWSLETTER_PROVIDER value ("mailchimp" or "")
+//   - apiKey: NEWSLETTER_API_KEY value
+//   - listID: NEWSLETTER_LIST_ID value
+//
+// Returns:
+//   - NewsletterProvider: Provider client, or nil when not configured
+func NewNewsletterProvider(provider, apiKey, listID string) NewsletterProvider {
+	if apiKey == "" || listID == "" {
+		return nil
+	}
+	switch provider {
+	case NewsletterMailchimp:
+		return &MailchimpProvider{APIKey: apiKey, ListID: listID}
+	}
+	return nil
+}
+
+// MailchimpProvider keeps a Mailchimp audience in step through the
+// Marketing API v3 list members endpoint.
+type MailchimpProvider struct {
+	APIKey   string       // API key, ending in the account's data center (e.g. -us6)
+	ListID   string       // Audience ID
+	Endpoint string       // Overrides the API root; derived from the key when empty
+	Client   *http.Client // HTTP client; a 10s-timeout default is used when nil
+}
+
+// mailchimpClient is used by MailchimpProvider when no client is set.
+var mailchimpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Subscribe implements NewsletterProvider. The member is created or
+// updated as subscribed; Mailchimp does not send its own confirmation,
+// since the address was confirmed here.
+func (m *MailchimpProvider) Subscribe(ctx context.Context, email string) error {
+	_, err := m.member(ctx, http.MethodPut, email, map[string]string{
+		"email_address": email,
+		"status_if_new": "subscribed",
+		"status":        "subscribed",
+	})
+	return err
+}
+
+// Unsubscribe implements NewsletterProvider.
+func (m *MailchimpProvider) Unsubscribe(ctx context.Context, email string) error {
+	status, err := m.member(ctx, http.MethodPatch, email, map[string]string{"status": "unsubscribed"})
+	if status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// member sends payload to the list member of email, which Mailchimp
+// identifies by the MD5 of the lower-case address.
+//
+// Returns:
+//   - int: HTTP status of the response, 0 when none came
+//   - error: Transport error, or the status and Mailchimp's error detail
+//     when it is not 2xx
+func (m *MailchimpProvider) member(ctx context.Context, method, email string, payload interface{}) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+	sum := md5.Sum([]byte(strings.ToLower(email)))
+	endpoint := fmt.Sprintf("%s/lists/%s/members/%s", m.endpoint(), url.PathEscape(m.ListID), hex.EncodeToString(sum[:]))
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth("bluejay", m.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := m.Client
+	if client == nil {
+		client = mailchimpClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("mailchimp: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("mailchimp: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// endpoint returns the API root of the key's data center.
+func (m *MailchimpProvider) endpoint() string {
+	if m.Endpoint != "" {
+		return strings.TrimSuffix(m.Endpoint, "/")
+	}
+	dc := m.APIKey[strings.LastIndex(m.APIKey, "-")+1:]
+	return "https://" + dc + ".api.mailchimp.com/3.0"
+}
+
+// Newsletter manages newsletter subscriptions with double opt-in: the
+// footer form adds an address as pending and emails a confirmation link;
+// only confirmed addresses are handed to the provider. Every subscriber has
+// a permanent unsubscribe link.
+type Newsletter struct {
+	queries  *sqlc.Queries      // Subscriber rows
+	logger   *slog.Logger       // Email and provider failures
+	mailer   Mailer             // Confirmation emails; nil disables them
+	provider NewsletterProvider // Mailing list sync; nil keeps subscribers here only
+	baseURL  string             // Site URL for links in emails, without trailing slash
+}
+
+// NewNewsletter creates the newsletter service.
+//
+// Parameters:
+//   - queries: Database queries
+//   - logger: Structured logger
+//   - mailer: Confirmation delivery; nil sends no email, so nobody confirms
+//   - provider: Mailing list provider; nil keeps subscribers in the database only
+//   - baseURL: Public site URL used in confirmation and unsubscribe links
+func NewNewsletter(queries *sqlc.Queries, logger *slog.Logger, mailer Mailer, provider NewsletterProvider, baseURL string) *Newsletter {
+	return &Newsletter{queries: queries, logger: logger, mailer: mailer, provider: provider, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Subscribe signs an address up and emails it a confirmation link. New,
+// pending and unsubscribed addresses get a fresh link (earlier links stop
+// working); confirmed addresses are left alone. The result is the same
+// either way, so the form does not reveal who is subscribed.
+//
+// Parameters:
+//   - email: Address from the form
+//   - source: Page the form was submitted from
+//   - ip: Client IP of the sign-up
+//
+// Returns:
+//   - error: ErrNewsletterInvalidEmail or a database error
+func (n *Newsletter) Subscribe(ctx context.Context, email, source, ip string) error {
+	email = strings.TrimSpace(email)
+	if !isEmailAddress(email) {
+		return ErrNewsletterInvalidEmail
+	}
+	token, err := newCustomerToken()
+	if err != nil {
+		return err
+	}
+
+	sub, err := n.queries.GetNewsletterSubscriberByEmail(ctx, email)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		unsubscribe, err := newCustomerToken()
+		if err != nil {
+			return err
+		}
+		sub, err = n.queries.CreateNewsletterSubscriber(ctx, sqlc.CreateNewsletterSubscriberParams{
+			Email:            email,
+			ConfirmTokenHash: hashCustomerToken(token),
+			UnsubscribeToken: unsubscribe,
+			Source:           source,
+			IpAddress:        ip,
+		})
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case sub.Status == NewsletterConfirmed:
+		return nil
+	default:
+		err = n.queries.RestartNewsletterConfirmation(ctx, sqlc.RestartNewsletterConfirmationParams{
+			ConfirmTokenHash: hashCustomerToken(token),
+			Source:           source,
+			ID:               sub.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.mailer == nil {
+		n.logger.WarnContext(ctx, "newsletter sign-up received but email is not configured", "subscriber", sub.ID)
+		return nil
+	}
+	body := fmt.Sprintf("Hello,\n\nPlease confirm that you want to receive our newsletter at %s:\n\n%s/newsletter/confirm?token=%s\n\nThe link works for %d days. If you did not sign up, ignore this email and you will not be added.\n\nTo stop receiving emails later, use:\n%s",
+		sub.Email, n.baseURL, token, int(NewsletterConfirmTTL.Hours()/24), n.UnsubscribeURL(sub))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := n.mailer.Send(ctx, []string{sub.Email}, "Confirm your newsletter subscription", body); err != nil {
+			n.logger.Error("failed to send newsletter confirmation", "subscriber", sub.ID, "error", err)
+		}
+	}()
+	return nil
+}
+
+// Confirm confirms the address of an emailed confirmation token and hands
+// it to the provider. Provider failures are logged and recorded on the
+// subscriber; the confirmation stands.
+//
+// Returns:
+//   - sqlc.NewsletterSubscriber: The confirmed subscriber
+//   - error: ErrNewsletterLinkInvalid or a database error
+func (n *Newsletter) Confirm(ctx context.Context, token string) (sqlc.NewsletterSubscriber, error) {
+	sub, err := n.queries.ConfirmNewsletterSubscriber(ctx, sqlc.ConfirmNewsletterSubscriberParams{
+		TokenHash: hashCustomerToken(token),
+		Cutoff:    time.Now().UTC().Add(-NewsletterConfirmTTL).Format("2006-01-02 15:04:05"),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.NewsletterSubscriber{}, ErrNewsletterLinkInvalid
+	}
+	if err != nil {
+		return sqlc.NewsletterSubscriber{}, err
+	}
+	n.sync(ctx, sub, true)
+	return sub, nil
+}
+
+// Subscriber returns the subscriber of an unsubscribe token, so the
+// unsubscribe page can name the address before the visitor confirms.
+//
+// Returns:
+//   - error: ErrNewsletterLinkInvalid or a database error
+func (n *Newsletter) Subscriber(ctx context.Context, unsubscribeToken string) (sqlc.NewsletterSubscriber, error) {
+	if unsubscribeToken == "" {
+		return sqlc.NewsletterSubscriber{}, ErrNewsletterLinkInvalid
+	}
+	sub, err := n.queries.GetNewsletterSubscriberByUnsubscribeToken(ctx, unsubscribeToken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.NewsletterSubscriber{}, ErrNewsletterLinkInvalid
+	}
+	return sub, err
+}
+
+// Unsubscribe unsubscribes the address of an unsubscribe token and passes
+// it on to the provider when it had been confirmed. Unsubscribing twice is
+// not an error.
+//
+// Returns:
+//   - sqlc.NewsletterSubscriber: The subscriber, as it was before
+//   - error: ErrNewsletterLinkInvalid or a database error
+func (n *Newsletter) Unsubscribe(ctx context.Context, unsubscribeToken string) (sqlc.NewsletterSubscriber, error) {
+	sub, err := n.Subscriber(ctx, unsubscribeToken)
+	if err != nil || sub.Status == NewsletterUnsubscribed {
+		return sub, err
+	}
+	if err := n.queries.UnsubscribeNewsletterSubscriber(ctx, sub.ID); err != nil {
+		return sub, err
+	}
+	if sub.Status == NewsletterConfirmed {
+		n.sync(ctx, sub, false)
+	}
+	return sub, nil
+}
+
+// Delete removes a subscriber entirely, e.g. on an erasure request,
+// unsubscribing it at the provider first when it had been confirmed.
+func (n *Newsletter) Delete(ctx context.Context, id int64) (sqlc.NewsletterSubscriber, error) {
+	sub, err := n.queries.GetNewsletterSubscriber(ctx, id)
+	if err != nil {
+		return sub, err
+	}
+	if sub.Status == NewsletterConfirmed && n.provider != nil {
+		if err := n.provider.Unsubscribe(ctx, sub.Email); err != nil {
+			n.logger.WarnContext(ctx, "newsletter provider unsubscribe failed", "subscriber", sub.ID, "error", err)
+		}
+	}
+	return sub, n.queries.DeleteNewsletterSubscriber(ctx, id)
+}
+
+// UnsubscribeURL returns the permanent unsubscribe link of a subscriber,
+// for newsletter footers and the List-Unsubscribe header.
+func (n *Newsletter) UnsubscribeURL(sub sqlc.NewsletterSubscriber) string {
+	return n.baseURL + "/newsletter/unsubscribe?token=" + sub.UnsubscribeToken
+}
+
+// sync hands a subscribe or unsubscribe to the provider, if one is
+// configured, and records the outcome. It finishes even when the visitor
+// leaves the page.
+func (n *Newsletter) sync(ctx context.Context, sub sqlc.NewsletterSubscriber, subscribe bool) {
+	if n.provider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 15*time.Second)
+	defer cancel()
+	var err error
+	if subscribe {
+		err = n.provider.Subscribe(ctx, sub.Email)
+	} else {
+		err = n.provider.Unsubscribe(ctx, sub.Email)
+	}
+	var msg string
+	if err != nil {
+		msg = err.Error()
+		n.logger.WarnContext(ctx, "newsletter provider sync failed", "subscriber", sub.ID, "subscribe", subscribe, "error", err)
+	}
+	if rerr := n.queries.RecordNewsletterProviderSync(ctx, sqlc.RecordNewsletterProviderSyncParams{ProviderError: msg, ID: sub.ID}); rerr != nil {
+		n.logger.ErrorContext(ctx, "failed to record newsletter provider sync", "subscriber", sub.ID, "error", rerr)
+	}
+}
@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// recordingProvider records the addresses handed to the mailing list.
+type recordingProvider struct {
+	mu    sync.Mutex
+	calls []string
+	err   error
+}
+
+func (p *recordingProvider) Subscribe(ctx context.Context, email string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, "subscribe "+email)
+	return p.err
+}
+
+func (p *recordingProvider) Unsubscribe(ctx context.Context, email string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, "unsubscribe "+email)
+	return p.err
+}
+
+func TestNewsletter_DoubleOptIn(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	mailer := &recordingMailer{sent: make(chan sentMail, 1)}
+	provider := &recordingProvider{}
+	newsletter := services.NewNewsletter(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), mailer, provider, "https://example.com/")
+
+	if err := newsletter.Subscribe(ctx, "jane", "/", ""); !errors.Is(err, services.ErrNewsletterInvalidEmail) {
+		t.Errorf("bad email: err = %v", err)
+	}
+	if err := newsletter.Subscribe(ctx, " Jane@Acme.com ", "/blog", "10.0.0.1"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	first := mailer.next(t)
+	if first.to[0] != "Jane@Acme.com" || !strings.Contains(first.body, "https://example.com/newsletter/unsubscribe?token=") {
+		t.Errorf("confirmation = %+v", first)
+	}
+	link := regexp.MustCompile(`/newsletter/confirm\?token=([0-9a-f]+)`)
+	stale := link.FindStringSubmatch(first.body)[1]
+
+	// Signing up again replaces the link; nothing reaches the provider yet
+	if err := newsletter.Subscribe(ctx, "jane@acme.com", "/about", ""); err != nil {
+		t.Fatalf("Subscribe again: %v", err)
+	}
+	token := link.FindStringSubmatch(mailer.next(t).body)[1]
+	if _, err := newsletter.Confirm(ctx, stale); !errors.Is(err, services.ErrNewsletterLinkInvalid) {
+		t.Errorf("replaced link: err = %v", err)
+	}
+	if len(provider.calls) != 0 {
+		t.Errorf("provider calls before confirmation: %v", provider.calls)
+	}
+
+	sub, err := newsletter.Confirm(ctx, token)
+	if err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	if sub.Status != services.NewsletterConfirmed || !sub.ConfirmedAt.Valid || sub.Source != "/about" {
+		t.Errorf("confirmed subscriber = %+v", sub)
+	}
+	if _, err := newsletter.Confirm(ctx, token); !errors.Is(err, services.ErrNewsletterLinkInvalid) {
+		t.Errorf("link used twice: err = %v", err)
+	}
+
+	// Confirmed addresses are not emailed again
+	if err := newsletter.Subscribe(ctx, "jane@acme.com", "/", ""); err != nil {
+		t.Fatalf("Subscribe confirmed: %v", err)
+	}
+	select {
+	case msg := <-mailer.sent:
+		t.Errorf("confirmed address emailed again: %+v", msg)
+	default:
+	}
+
+	if _, err := newsletter.Unsubscribe(ctx, "nope"); !errors.Is(err, services.ErrNewsletterLinkInvalid) {
+		t.Errorf("unknown unsubscribe token: err = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := newsletter.Unsubscribe(ctx, sub.UnsubscribeToken); err != nil {
+			t.Fatalf("Unsubscribe: %v", err)
+		}
+	}
+	got, _ := queries.GetNewsletterSubscriber(ctx, sub.ID)
+	if got.Status != services.NewsletterUnsubscribed || !got.ProviderSyncedAt.Valid || got.ProviderError != "" {
+		t.Errorf("unsubscribed subscriber = %+v", got)
+	}
+	want := []string{"subscribe Jane@Acme.com", "unsubscribe Jane@Acme.com"}
+	if strings.Join(provider.calls, ",") != strings.Join(want, ",") {
+		t.Errorf("provider calls = %v, want %v", provider.calls, want)
+	}
+
+	// Provider failures are recorded, the confirmation stands
+	provider.err = errors.New("list is full")
+	newsletter.Subscribe(ctx, "jane@acme.com", "/", "")
+	sub, err = newsletter.Confirm(ctx, link.FindStringSubmatch(mailer.next(t).body)[1])
+	if err != nil {
+		t.Fatalf("Confirm with failing provider: %v", err)
+	}
+	if got, _ := queries.GetNewsletterSubscriber(ctx, sub.ID); got.Status != services.NewsletterConfirmed || got.ProviderError != "list is full" {
+		t.Errorf("subscriber after failed sync = %+v", got)
+	}
+}
+
+func TestMailchimpProvider(t *testing.T) {
+	type call struct {
+		method, path, user, key string
+		body                    map[string]string
+	}
+	var calls []call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{method: r.Method, path: r.URL.Path}
+		c.user, c.key, _ = r.BasicAuth()
+		json.NewDecoder(r.Body).Decode(&c.body)
+		calls = append(calls, c)
+		if strings.HasSuffix(r.URL.Path, "/members/0c37520834a304cb679534135354b504") {
+			http.Error(w, `{"title":"Resource Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPut && c.body["email_address"] == "bad@acme.com" {
+			http.Error(w, `{"title":"Invalid Resource"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	if p := services.NewNewsletterProvider("mailchimp", "", "list"); p != nil {
+		t.Errorf("provider without a key = %T", p)
+	}
+	p := &services.MailchimpProvider{APIKey: "secret-us6", ListID: "a1b2", Endpoint: srv.URL + "/3.0/"}
+	if err := p.Subscribe(context.Background(), "Jane@Acme.com"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	// Mailchimp's member ID is the MD5 of the lower-case address
+	want := call{method: http.MethodPut, path: "/3.0/lists/a1b2/members/a6b55dc639dd4151e97efbc42ee1a28b", user: "bluejay", key: "secret-us6"}
+	if got := calls[0]; got.method != want.method || got.path != want.path || got.user != want.user || got.key != want.key ||
+		got.body["status"] != "subscribed" || got.body["email_address"] != "Jane@Acme.com" {
+		t.Errorf("subscribe call = %+v, want %+v", got, want)
+	}
+	if err := p.Subscribe(context.Background(), "bad@acme.com"); err == nil || !strings.Contains(err.Error(), "Invalid Resource") {
+		t.Errorf("rejected subscribe: err = %v", err)
+	}
+	if err := p.Unsubscribe(context.Background(), "jane@acme.com"); err != nil || calls[2].method != http.MethodPatch || calls[2].body["status"] != "unsubscribed" {
+		t.Errorf("Unsubscribe: %v, %+v", err, calls[2])
+	}
+	if err := p.Unsubscribe(context.Background(), "ghost@acme.com"); err != nil {
+		t.Errorf("unsubscribing an address Mailchimp does not know: %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	// Standard library imports
+	"context"        // Request cancellation and background email timeouts
+	"database/sql"   // sql.ErrNoRows and nullable IDs
+	"errors"         // Portal errors
+	"fmt"            // Invite email text and error wrapping
+	"log/slog"       // Structured logging of email failures
+	"mime/multipart" // Uploaded logos
+	"net/url"        // Website validation
+	"strings"        // Trimming form values
+	"time"           // Invite lifetime and timeouts
+	"unicode/utf8"   // Description length
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"           // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/database" // Transactions
+)
+
+// Partner submission statuses (partner_submissions.status).
+const (
+	PartnerSubmissionPending    = "pending"    // Waiting in the admin queue
+	PartnerSubmissionApproved   = "approved"   // Copied onto the partner
+	PartnerSubmissionRejected   = "rejected"   // Declined; the partner is unchanged
+	PartnerSubmissionSuperseded = "superseded" // Replaced by a newer submission before review
+)
+
+// PartnerSubmissionStatuses lists the submission statuses for the admin
+// queue filter.
+var PartnerSubmissionStatuses = []string{PartnerSubmissionPending, PartnerSubmissionApproved, PartnerSubmissionRejected, PartnerSubmissionSuperseded}
+
+// PartnerInviteTTL is how long a partner portal link works.
+const PartnerInviteTTL = 30 * 24 * time.Hour
+
+// MaxPartnerDescriptionLength is the longest description, in characters, a
+// partner can send.
+const MaxPartnerDescriptionLength = 2000
+
+// Errors returned by PartnerPortal. Their messages are shown to partners
+// and admins.
+var (
+	ErrPartnerInviteInvalid        = errors.New("this link is invalid, has expired or was revoked")
+	ErrPartnerInviteEmail          = errors.New("enter a valid email address or leave it empty")
+	ErrPartnerContactRequired      = errors.New("enter your name")
+	ErrPartnerWebsiteInvalid       = errors.New("enter a website address starting with http:// or https://")
+	ErrPartnerDescriptionTooLong   = fmt.Errorf("descriptions are limited to %d characters", MaxPartnerDescriptionLength)
+	ErrPartnerTierInvalid          = errors.New("choose one of the listed partner tiers")
+	ErrPartnerTierEvidenceRequired = errors.New("describe why your organization qualifies for the requested tier")
+	ErrPartnerLogoInvalid          = errors.New("upload your logo as a JPG, PNG or WebP image of at most 2 MB")
+	ErrPartnerSubmissionReviewed   = errors.New("this submission has already been reviewed")
+)
+
+// PartnerSubmissionInput is the partner portal form.
+type PartnerSubmissionInput struct {
+	ContactName     string                // Person sending the update
+	Description     string                // New partner description
+	WebsiteURL      string                // New website, empty for none
+	RequestedTierID int64                 // Tier asked for, 0 to keep the current one
+	TierEvidence    string                // Proof for a tier change
+	Logo            *multipart.FileHeader // New logo, nil to keep the current one
+	IP              string                // Client IP, kept with the submission
+}
+
+// PartnerPortal lets partner organizations update their own listing. The
+// admin invites a partner with a tokenized link to /partner-portal/<token>;
+// what the partner sends there waits in an approval queue and only reaches
+// the public partners page once an admin approves it.
+type PartnerPortal struct {
+	db      *sql.DB        // Connection that opens the submit and review transactions
+	queries *sqlc.Queries  // Queries bound to the transactions with WithTx
+	uploads *UploadService // Stores submitted logos
+	logger  *slog.Logger   // Email failures
+	mailer  Mailer         // Invite emails; nil disables them
+	baseURL string         // Site URL for invite links, without trailing slash
+}
+
+// NewPartnerPortal creates the partner portal service.
+//
+// Parameters:
+//   - db: Database connection for transactions
+//   - queries: Database queries
+//   - uploads: Storage for submitted logos
+//   - logger: Structured logger
+//   - mailer: Invite delivery; nil sends no email
+//   - baseURL: Public site URL used in invite links
+func NewPartnerPortal(db *sql.DB, queries *sqlc.Queries, uploads *UploadService, logger *slog.Logger, mailer Mailer, baseURL string) *PartnerPortal {
+	return &PartnerPortal{db: db, queries: queries, uploads: uploads, logger: logger, mailer: mailer, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Invite creates a portal link for a partner and emails it to email when
+// one is given and email is configured. The link is only returned here;
+// the database keeps its hash.
+//
+// Returns:
+//   - string: The portal link
+//   - error: ErrPartnerInviteEmail or a database error
+func (p *PartnerPortal) Invite(ctx context.Context, partner sqlc.GetPartnerRow, email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email != "" && !isEmailAddress(email) {
+		return "", ErrPartnerInviteEmail
+	}
+	token, err := newCustomerToken()
+	if err != nil {
+		return "", err
+	}
+	invite, err := p.queries.CreatePartnerInvite(ctx, sqlc.CreatePartnerInviteParams{
+		PartnerID: partner.ID,
+		Email:     email,
+		TokenHash: hashCustomerToken(token),
+		ExpiresAt: time.Now().UTC().Add(PartnerInviteTTL).Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return "", err
+	}
+	link := p.baseURL + "/partner-portal/" + token
+
+	if email == "" {
+		return link, nil
+	}
+	if p.mailer == nil {
+		p.logger.WarnContext(ctx, "partner invite created but email is not configured", "invite", invite.ID)
+		return link, nil
+	}
+	body := fmt.Sprintf("Hello,\n\nYou can update the listing of %s on our partners page here:\n\n%s\n\nUpload your logo, edit your description and website, or request a different partner tier. Changes go live once our team has reviewed them.\n\nThe link works for %d days. Please do not share it.",
+		partner.Name, link, int(PartnerInviteTTL.Hours()/24))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := p.mailer.Send(ctx, []string{email}, "Update your partner listing", body); err != nil {
+			p.logger.Error("failed to send partner invite", "invite", invite.ID, "error", err)
+		}
+	}()
+	return link, nil
+}
+
+// Open returns the invite of a portal link token.
+//
+// Returns:
+//   - sqlc.PartnerInvite: The invite
+//   - error: ErrPartnerInviteInvalid for unknown, expired or revoked links,
+//     or a database error
+func (p *PartnerPortal) Open(ctx context.Context, token string) (sqlc.PartnerInvite, error) {
+	if token == "" {
+		return sqlc.PartnerInvite{}, ErrPartnerInviteInvalid
+	}
+	invite, err := p.queries.GetActivePartnerInvite(ctx, sqlc.GetActivePartnerInviteParams{
+		TokenHash: hashCustomerToken(token),
+		Now:       time.Now().UTC().Format("2006-01-02 15:04:05"),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return invite, ErrPartnerInviteInvalid
+	}
+	return invite, err
+}
+
+// Submit stores the portal form of an invite as the partner's pending
+// submission, replacing an earlier one that was not reviewed yet. Asking
+// for the partner's current tier is the same as keeping it.
+//
+// Returns:
+//   - sqlc.PartnerSubmission: The pending submission
+//   - error: ErrPartnerContactRequired, ErrPartnerWebsiteInvalid,
+//     ErrPartnerDescriptionTooLong, ErrPartnerTierInvalid,
+//     ErrPartnerTierEvidenceRequired, ErrPartnerLogoInvalid or a database
+//     error
+func (p *PartnerPortal) Submit(ctx context.Context, invite sqlc.PartnerInvite, in PartnerSubmissionInput) (sqlc.PartnerSubmission, error) {
+	in.ContactName = strings.TrimSpace(in.ContactName)
+	in.Description = strings.TrimSpace(in.Description)
+	in.WebsiteURL = strings.TrimSpace(in.WebsiteURL)
+	in.TierEvidence = strings.TrimSpace(in.TierEvidence)
+	if in.ContactName == "" {
+		return sqlc.PartnerSubmission{}, ErrPartnerContactRequired
+	}
+	if in.WebsiteURL != "" && !isWebURL(in.WebsiteURL) {
+		return sqlc.PartnerSubmission{}, ErrPartnerWebsiteInvalid
+	}
+	if utf8.RuneCountInString(in.Description) > MaxPartnerDescriptionLength {
+		return sqlc.PartnerSubmission{}, ErrPartnerDescriptionTooLong
+	}
+	partner, err := p.queries.GetPartner(ctx, invite.PartnerID)
+	if err != nil {
+		return sqlc.PartnerSubmission{}, err
+	}
+	var tier sql.NullInt64
+	if in.RequestedTierID != 0 && in.RequestedTierID != partner.TierID {
+		if _, err := p.queries.GetPartnerTier(ctx, in.RequestedTierID); errors.Is(err, sql.ErrNoRows) {
+			return sqlc.PartnerSubmission{}, ErrPartnerTierInvalid
+		} else if err != nil {
+			return sqlc.PartnerSubmission{}, err
+		}
+		if in.TierEvidence == "" {
+			return sqlc.PartnerSubmission{}, ErrPartnerTierEvidenceRequired
+		}
+		tier = sql.NullInt64{Int64: in.RequestedTierID, Valid: true}
+	}
+
+	var logo string
+	if in.Logo != nil {
+		if logo, err = p.uploads.UploadPartnerLogo(in.Logo); err != nil {
+			p.logger.WarnContext(ctx, "partner logo refused", "partner", partner.ID, "error", err)
+			return sqlc.PartnerSubmission{}, ErrPartnerLogoInvalid
+		}
+	}
+
+	var sub sqlc.PartnerSubmission
+	err = database.WithTx(ctx, p.db, func(q *sqlc.Queries) error {
+		if err := q.SupersedePendingPartnerSubmissions(ctx, partner.ID); err != nil {
+			return err
+		}
+		var err error
+		sub, err = q.CreatePartnerSubmission(ctx, sqlc.CreatePartnerSubmissionParams{
+			PartnerID:       partner.ID,
+			InviteID:        sql.NullInt64{Int64: invite.ID, Valid: true},
+			LogoUrl:         logo,
+			Description:     in.Description,
+			WebsiteUrl:      in.WebsiteURL,
+			RequestedTierID: tier,
+			TierEvidence:    in.TierEvidence,
+			ContactName:     in.ContactName,
+			IpAddress:       in.IP,
+		})
+		if err != nil {
+			return err
+		}
+		return q.TouchPartnerInvite(ctx, invite.ID)
+	})
+	return sub, err
+}
+
+// Approve copies a pending submission onto its partner. A submission
+// without a logo keeps the current logo.
+//
+// Returns:
+//   - sqlc.PartnerSubmission: The submission as it was before review
+//   - error: ErrPartnerSubmissionReviewed, sql.ErrNoRows or a database error
+func (p *PartnerPortal) Approve(ctx context.Context, id int64, notes string) (sqlc.PartnerSubmission, error) {
+	return p.review(ctx, id, PartnerSubmissionApproved, notes)
+}
+
+// Reject declines a pending submission; the partner is left unchanged.
+//
+// Returns:
+//   - sqlc.PartnerSubmission: The submission as it was before review
+//   - error: ErrPartnerSubmissionReviewed, sql.ErrNoRows or a database error
+func (p *PartnerPortal) Reject(ctx context.Context, id int64, notes string) (sqlc.PartnerSubmission, error) {
+	return p.review(ctx, id, PartnerSubmissionRejected, notes)
+}
+
+// review records the decision on a pending submission and applies it when
+// approved, in one transaction.
+func (p *PartnerPortal) review(ctx context.Context, id int64, status, notes string) (sqlc.PartnerSubmission, error) {
+	var sub sqlc.PartnerSubmission
+	err := database.WithTx(ctx, p.db, func(q *sqlc.Queries) error {
+		var err error
+		sub, err = q.GetPartnerSubmission(ctx, id)
+		if err != nil {
+			return err
+		}
+		if sub.Status != PartnerSubmissionPending {
+			return ErrPartnerSubmissionReviewed
+		}
+		if status == PartnerSubmissionApproved {
+			err = q.ApplyPartnerSubmission(ctx, sqlc.ApplyPartnerSubmissionParams{
+				LogoUrl:         sub.LogoUrl,
+				Description:     sub.Description,
+				WebsiteUrl:      sub.WebsiteUrl,
+				RequestedTierID: sub.RequestedTierID,
+				ID:              sub.PartnerID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return q.ReviewPartnerSubmission(ctx, sqlc.ReviewPartnerSubmissionParams{
+			Status:      status,
+			ReviewNotes: strings.TrimSpace(notes),
+			ID:          sub.ID,
+		})
+	})
+	return sub, err
+}
+
+// isWebURL reports whether s is an absolute http or https URL with a host.
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
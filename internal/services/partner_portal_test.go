@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestPartnerPortal(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	uploadDir := t.TempDir()
+	mailer := &recordingMailer{sent: make(chan sentMail, 1)}
+	portal := services.NewPartnerPortal(db, queries, services.NewUploadService(uploadDir), slog.New(slog.NewTextHandler(io.Discard, nil)), mailer, "https://example.com/")
+
+	silver, _ := queries.CreatePartnerTier(ctx, sqlc.CreatePartnerTierParams{Name: "Silver", Slug: "silver", SortOrder: 2})
+	gold, _ := queries.CreatePartnerTier(ctx, sqlc.CreatePartnerTierParams{Name: "Gold", Slug: "gold", SortOrder: 1})
+	created, err := queries.CreatePartner(ctx, sqlc.CreatePartnerParams{
+		Name:    "Acme Sensors",
+		TierID:  silver.ID,
+		LogoUrl: sql.NullString{String: "/uploads/branding/acme.svg", Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	partner, _ := queries.GetPartner(ctx, created.ID)
+
+	// Invites
+	if _, err := portal.Invite(ctx, partner, "not an address"); !errors.Is(err, services.ErrPartnerInviteEmail) {
+		t.Errorf("bad invite email: err = %v", err)
+	}
+	link, err := portal.Invite(ctx, partner, "web@acme.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := mailer.next(t)
+	token := regexp.MustCompile(`/partner-portal/([0-9a-f]{64})`).FindStringSubmatch(msg.body)
+	if token == nil || link != "https://example.com/partner-portal/"+token[1] || msg.to[0] != "web@acme.example" {
+		t.Fatalf("invite link %q, email %+v", link, msg)
+	}
+	if _, err := portal.Open(ctx, "deadbeef"); !errors.Is(err, services.ErrPartnerInviteInvalid) {
+		t.Errorf("unknown token: err = %v", err)
+	}
+	invite, err := portal.Open(ctx, token[1])
+	if err != nil || invite.PartnerID != partner.ID {
+		t.Fatalf("open = %+v, %v", invite, err)
+	}
+
+	// Validation
+	for name, tc := range map[string]struct {
+		in   services.PartnerSubmissionInput
+		want error
+	}{
+		"no name":          {services.PartnerSubmissionInput{Description: "x"}, services.ErrPartnerContactRequired},
+		"bad website":      {services.PartnerSubmissionInput{ContactName: "Ann", WebsiteURL: "javascript:alert(1)"}, services.ErrPartnerWebsiteInvalid},
+		"unknown tier":     {services.PartnerSubmissionInput{ContactName: "Ann", RequestedTierID: 999, TierEvidence: "x"}, services.ErrPartnerTierInvalid},
+		"no evidence":      {services.PartnerSubmissionInput{ContactName: "Ann", RequestedTierID: gold.ID}, services.ErrPartnerTierEvidenceRequired},
+		"svg logo":         {services.PartnerSubmissionInput{ContactName: "Ann", Logo: createMultipartFileHeader(t, "logo.svg", []byte("<svg></svg>"), "image/svg+xml")}, services.ErrPartnerLogoInvalid},
+		"disguised script": {services.PartnerSubmissionInput{ContactName: "Ann", Logo: createMultipartFileHeader(t, "logo.png", []byte("<script>alert(1)</script>"), "image/png")}, services.ErrPartnerLogoInvalid},
+	} {
+		if _, err := portal.Submit(ctx, invite, tc.in); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	// A newer submission supersedes the pending one
+	first, err := portal.Submit(ctx, invite, services.PartnerSubmissionInput{ContactName: "Ann", Description: "First draft", RequestedTierID: silver.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.RequestedTierID.Valid {
+		t.Error("asking for the current tier stored a tier change")
+	}
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+	second, err := portal.Submit(ctx, invite, services.PartnerSubmissionInput{
+		ContactName:     "Ann",
+		Description:     "Industrial sensors since 1999.",
+		WebsiteURL:      "https://acme.example",
+		RequestedTierID: gold.ID,
+		TierEvidence:    "ISO 9001, 40 joint deployments",
+		Logo:            createMultipartFileHeader(t, "acme logo.png", png, "image/png"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(uploadDir, filepath.FromSlash(second.LogoUrl[len("/uploads/"):]))); err != nil {
+		t.Errorf("logo %q not stored: %v", second.LogoUrl, err)
+	}
+	if first, _ = queries.GetPartnerSubmission(ctx, first.ID); first.Status != services.PartnerSubmissionSuperseded {
+		t.Errorf("first submission status = %s", first.Status)
+	}
+	if pending, _ := queries.GetPendingPartnerSubmission(ctx, partner.ID); pending.ID != second.ID {
+		t.Errorf("pending submission = %d, want %d", pending.ID, second.ID)
+	}
+	if p, _ := queries.GetPartner(ctx, partner.ID); p.TierID != silver.ID || p.Description.Valid {
+		t.Error("partner changed before approval")
+	}
+
+	// Approval applies the submission once
+	if _, err := portal.Approve(ctx, first.ID, ""); !errors.Is(err, services.ErrPartnerSubmissionReviewed) {
+		t.Errorf("approve superseded: err = %v", err)
+	}
+	if _, err := portal.Approve(ctx, second.ID, "Evidence checked"); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := queries.GetPartner(ctx, partner.ID)
+	if p.TierID != gold.ID || p.Description.String != "Industrial sensors since 1999." || p.WebsiteUrl.String != "https://acme.example" || p.LogoUrl.String != second.LogoUrl {
+		t.Errorf("approved partner = %+v", p)
+	}
+	if _, err := portal.Reject(ctx, second.ID, ""); !errors.Is(err, services.ErrPartnerSubmissionReviewed) {
+		t.Errorf("reject approved: err = %v", err)
+	}
+
+	// Rejection leaves the partner alone; no logo keeps the current one
+	third, err := portal.Submit(ctx, invite, services.PartnerSubmissionInput{ContactName: "Ann", Description: "Spam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := portal.Reject(ctx, third.ID, "Not accurate"); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := queries.GetPartner(ctx, partner.ID); p.Description.String != "Industrial sensors since 1999." {
+		t.Error("rejected submission changed the partner")
+	}
+	fourth, _ := portal.Submit(ctx, invite, services.PartnerSubmissionInput{ContactName: "Ann", Description: "Updated"})
+	portal.Approve(ctx, fourth.ID, "")
+	if p, _ := queries.GetPartner(ctx, partner.ID); p.LogoUrl.String != second.LogoUrl || p.WebsiteUrl.Valid {
+		t.Errorf("approval without a logo: logo %q, website %v", p.LogoUrl.String, p.WebsiteUrl)
+	}
+
+	// Revoked links stop working
+	queries.RevokePartnerInvite(ctx, invite.ID)
+	if _, err := portal.Open(ctx, token[1]); !errors.Is(err, services.ErrPartnerInviteInvalid) {
+		t.Errorf("revoked link: err = %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes"   // Assembling the file
+	"fmt"     // Writing PDF operators and objects
+	"strings" // Escaping text strings
+
+	// Third-party imports
+	"golang.org/x/text/encoding"         // Replacing characters WinAnsi cannot show
+	"golang.org/x/text/encoding/charmap" // Windows-1252, the WinAnsiEncoding of the standard fonts
+)
+
+// A4 page size in PDF points (1/72 inch).
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+)
+
+// pdfRGB is a fill or stroke color with components from 0 to 1.
+type pdfRGB struct{ R, G, B float64 }
+
+// pdfHexColor parses a #RRGGBB color, returning def for anything else.
+func pdfHexColor(hex string, def pdfRGB) pdfRGB {
+	if !IsThemeColor(hex) {
+		return def
+	}
+	var r, g, b int
+	fmt.Sscanf(hex[1:], "%02x%02x%02x", &r, &g, &b)
+	return pdfRGB{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+}
+
+// pdfImage is a JPEG embedded once and drawn by name.
+type pdfImage struct {
+	data          []byte
+	width, height int
+}
+
+// pdfWriter builds a small PDF 1.4 document: text in the standard
+// Helvetica fonts, filled rectangles, lines and JPEG images on A4 pages.
+// The standard fonts need no embedding, which keeps generated files small;
+// text outside Windows-1252 is shown as "?".
+//
+// Coordinates are in points from the bottom-left corner of the page, as
+// in PDF itself.
+type pdfWriter struct {
+	pages   []*bytes.Buffer // Content stream of each page
+	current int             // Page drawn on
+	images  []pdfImage
+	title   string // Document title in the file's metadata
+}
+
+// newPDFWriter returns an empty document with the given title.
+func newPDFWriter(title string) *pdfWriter {
+	return &pdfWriter{title: title}
+}
+
+// addPage starts a new page and draws on it.
+func (w *pdfWriter) addPage() {
+	w.pages = append(w.pages, new(bytes.Buffer))
+	w.current = len(w.pages) - 1
+}
+
+// selectPage draws on page i (from 0) again, e.g. to add page numbers once
+// the page count is known.
+func (w *pdfWriter) selectPage(i int) {
+	w.current = i
+}
+
+// page returns the content stream of the current page.
+func (w *pdfWriter) page() *bytes.Buffer {
+	return w.pages[w.current]
+}
+
+// addJPEG registers a JPEG image and returns its index for drawImage.
+func (w *pdfWriter) addJPEG(data []byte, width, height int) int {
+	w.images = append(w.images, pdfImage{data: data, width: width, height: height})
+	return len(w.images) - 1
+}
+
+// text draws s with its baseline starting at (x, y).
+func (w *pdfWriter) text(x, y, size float64, bold bool, color pdfRGB, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(w.page(), "BT /%s %.2f Tf %.3f %.3f %.3f rg %.2f %.2f Td (%s) Tj ET\n",
+		font, size, color.R, color.G, color.B, x, y, pdfString(s))
+}
+
+// rect fills a rectangle whose bottom-left corner is at (x, y).
+func (w *pdfWriter) rect(x, y, width, height float64, color pdfRGB) {
+	fmt.Fprintf(w.page(), "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f\n",
+		color.R, color.G, color.B, x, y, width, height)
+}
+
+// line strokes a straight line.
+func (w *pdfWriter) line(x1, y1, x2, y2, width float64, color pdfRGB) {
+	fmt.Fprintf(w.page(), "%.3f %.3f %.3f RG %.2f w %.2f %.2f m %.2f %.2f l S\n",
+		color.R, color.G, color.B, width, x1, y1, x2, y2)
+}
+
+// drawImage draws a registered image into the box with its bottom-left
+// corner at (x, y).
+func (w *pdfWriter) drawImage(index int, x, y, width, height float64) {
+	fmt.Fprintf(w.page(), "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, x, y, index)
+}
+
+// bytes returns the finished document.
+func (w *pdfWriter) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	stream := func(dict string, data []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dict, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	// Objects 1-4 are the catalog, the page tree, the two fonts and the
+	// document info; images follow, then each page with its content.
+	const firstImage = 6
+	firstPage := firstImage + len(w.images)
+	var kids, xobjects strings.Builder
+	for i := range w.pages {
+		fmt.Fprintf(&kids, "%d 0 R ", firstPage+2*i)
+	}
+	for i := range w.images {
+		fmt.Fprintf(&xobjects, "/Im%d %d 0 R ", i, firstImage+i)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(w.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) >>", pdfString(w.title)))
+	for _, img := range w.images {
+		stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode",
+			img.width, img.height), img.data)
+	}
+	for i, content := range w.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> /XObject << %s>> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, xobjects.String(), firstPage+2*i+1))
+		stream("", content.Bytes())
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfEncoder maps text to Windows-1252, the WinAnsiEncoding of the standard
+// fonts, replacing what it cannot show.
+var pdfEncoder = encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder())
+
+// pdfString encodes s for a PDF literal string: Windows-1252 bytes with
+// parentheses and backslashes escaped and control characters dropped.
+func pdfString(s string) string {
+	encoded, err := pdfEncoder.String(s)
+	if err != nil {
+		encoded = s
+	}
+	var b strings.Builder
+	for i := 0; i < len(encoded); i++ {
+		switch c := encoded[i]; {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20:
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Glyph widths of the printable ASCII characters (32-126) in thousandths of
+// the font size, from the Adobe font metrics of the standard fonts. Other
+// characters are measured as a digit.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfTextWidth returns the width of s in points.
+func pdfTextWidth(s string, size float64, bold bool) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfWrap breaks s into lines no wider than maxWidth, at spaces where it
+// can and inside words that are too long on their own. Newlines in s start
+// a new line.
+func pdfWrap(s string, size float64, bold bool, maxWidth float64) []string {
+	var lines []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if pdfTextWidth(candidate, size, bold) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Split a word wider than the line
+			line = ""
+			for _, r := range word {
+				if line != "" && pdfTextWidth(line+string(r), size, bold) > maxWidth {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	// Standard library imports
+	"path/filepath" // Media kit file extensions
+	"strings"       // Upper-casing file types
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// PressKitExtensions lists the file types accepted in the media kit: logos,
+// photos, fact sheets and archives of them. SVG logos are allowed because
+// only admins upload media kit files.
+var PressKitExtensions = []string{".jpg", ".jpeg", ".png", ".webp", ".svg", ".eps", ".pdf", ".zip"}
+
+// PressClockLayout formats "now" for the press release queries, matching
+// the stored timestamps.
+const PressClockLayout = "2006-01-02 15:04:05"
+
+// PressMentionsShown is how many "In the news" links /press lists.
+const PressMentionsShown = 20
+
+// PressReleasePath returns the public path of a press release.
+func PressReleasePath(release sqlc.PressRelease) string {
+	return "/press/" + release.Slug
+}
+
+// PressKitFileType returns the label shown next to a media kit download,
+// e.g. "PDF" or "PNG".
+func PressKitFileType(path string) string {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if ext == "jpeg" {
+		ext = "jpg"
+	}
+	return strings.ToUpper(ext)
+}
@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestPressReleases_Embargo(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for _, r := range []sqlc.CreatePressReleaseParams{
+		{Title: "Out", Slug: "out", ReleasedAt: now.Add(-time.Hour), IsPublished: 1},
+		{Title: "Embargoed", Slug: "embargoed", ReleasedAt: now.Add(time.Hour), IsPublished: 1},
+		{Title: "Draft", Slug: "draft", ReleasedAt: now.Add(-time.Hour)},
+	} {
+		if _, err := queries.CreatePressRelease(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock := now.Format(services.PressClockLayout)
+	released, err := queries.ListReleasedPressReleases(ctx, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(released) != 1 || released[0].Slug != "out" {
+		t.Fatalf("released = %+v, want only the published release that is out", released)
+	}
+
+	_, err = queries.GetReleasedPressReleaseBySlug(ctx, sqlc.GetReleasedPressReleaseBySlugParams{Slug: "embargoed", Now: clock})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("embargoed release served: %v", err)
+	}
+	// Once its time has come it is served
+	later := now.Add(2 * time.Hour).Format(services.PressClockLayout)
+	if _, err := queries.GetReleasedPressReleaseBySlug(ctx, sqlc.GetReleasedPressReleaseBySlugParams{Slug: "embargoed", Now: later}); err != nil {
+		t.Errorf("release not served after its embargo: %v", err)
+	}
+}
+
+func TestPressKitFileType(t *testing.T) {
+	cases := map[string]string{
+		"/uploads/press/1_logo.SVG":       "SVG",
+		"/uploads/press/1_photo.jpeg":     "JPG",
+		"/uploads/press/1_fact_sheet.pdf": "PDF",
+		"/uploads/press/1_kit":            "",
+	}
+	for path, want := range cases {
+		if got := services.PressKitFileType(path); got != want {
+			t.Errorf("PressKitFileType(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestPreviewTokens(t *testing.T) {
+	tokens := services.NewPreviewTokens("secret", time.Hour)
+	token := tokens.Issue(services.PreviewBlogPost, 42)
+	contentType, id, err := tokens.VerifyPreview(token)
+	if err != nil || contentType != services.PreviewBlogPost || id != 42 {
+		t.Fatalf("round trip = %q, %d, %v", contentType, id, err)
+	}
+
+	for name, bad := range map[string]string{
+		"empty":        "",
+		"unsigned":     token[:strings.LastIndexByte(token, '.')],
+		"other id":     strings.Replace(token, ".42.", ".43.", 1),
+		"other type":   strings.Replace(token, "blog_post.", "product.", 1),
+		"other secret": services.NewPreviewTokens("other", time.Hour).Issue(services.PreviewBlogPost, 42),
+	} {
+		if _, _, err := tokens.VerifyPreview(bad); !errors.Is(err, services.ErrTokenInvalid) {
+			t.Errorf("%s: err = %v, want ErrTokenInvalid", name, err)
+		}
+	}
+
+	// Lifetimes under a second expire at once, since expiry has second precision
+	shortLived := services.NewPreviewTokens("secret", time.Millisecond)
+	if _, _, err := shortLived.VerifyPreview(shortLived.Issue(services.PreviewProduct, 1)); !errors.Is(err, services.ErrTokenExpired) {
+		t.Errorf("expired token: err = %v, want ErrTokenExpired", err)
+	}
+}
@@ -2,10 +2,15 @@ package services
 
 import (
 	// Standard library imports for context handling
-	"context" // Provides context for request cancellation and timeout handling
+	"context"      // Provides context for request cancellation and timeout handling
+	"database/sql" // Nullable alt text for variant gallery images
+
+	// Third-party imports
+	"go.opentelemetry.io/otel/attribute" // Span attributes
 
 	// Internal application imports
-	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/db/sqlc"          // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/tracing" // Service spans under the request span
 )
 
 // ProductService provides business logic for product-related operations.
@@ -43,7 +48,9 @@ type ProductDetail struct {
 	Images         []sqlc.ProductImage          // Product images for gallery display
 	Features       []sqlc.ProductFeature        // Key product features and selling points
 	Certifications []sqlc.ProductCertification  // Industry certifications and compliance information
-	Downloads      []sqlc.ProductDownload       // Downloadable resources (datasheets, manuals, CAD files)
+	Downloads      []DownloadVersions           // Downloadable resources (datasheets, manuals, CAD files) with their older versions
+	Variants       []sqlc.ProductVariant        // Variants offered in the page's variant selector
+	Variant        *sqlc.ProductVariant         // Variant applied by ApplyVariant, nil for the base product
 }
 
 // GetProductDetail retrieves complete product information by slug, aggregating data
@@ -68,6 +75,9 @@ type ProductDetail struct {
 //   - *ProductDetail: Complete product information with all related data
 //   - error: Non-nil if the product or category cannot be found, nil on success
 func (s *ProductService) GetProductDetail(ctx context.Context, slug string) (*ProductDetail, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductDetail", attribute.String("product.slug", slug))
+	defer span.End()
+
 	// Retrieve the core product record by its URL slug.
 	// This is a required operation - if it fails, we cannot proceed.
 	product, err := s.queries.GetProductBySlug(ctx, slug)
@@ -119,6 +129,13 @@ func (s *ProductService) GetProductDetail(ctx context.Context, slug string) (*Pr
 		downloads = []sqlc.ProductDownload{}
 	}
 
+	// Retrieve variants for the selector.
+	// Empty slice default renders the page without a selector.
+	variants, err := s.queries.ListProductVariants(ctx, product.ID)
+	if err != nil {
+		variants = []sqlc.ProductVariant{}
+	}
+
 	// Assemble all retrieved data into a comprehensive ProductDetail structure
 	return &ProductDetail{
 		Product:        product,
@@ -127,6 +144,94 @@ func (s *ProductService) GetProductDetail(ctx context.Context, slug string) (*Pr
 		Images:         images,
 		Features:       features,
 		Certifications: certifications,
-		Downloads:      downloads,
+		Downloads:      GroupDownloadVersions(downloads),
+		Variants:       variants,
 	}, nil
 }
+
+// ApplyVariant switches a product detail to one of its variants. The variant's
+// SKU replaces the product SKU, each spec override replaces the product spec
+// with the same section and label (or is added to its section when the
+// product has no such spec), and the variant's images replace the gallery
+// and main image when it has any.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - detail: Product detail from GetProductDetail, modified in place
+//   - sku: Variant SKU, e.g. from ?variant= on the product page
+//
+// Returns:
+//   - error: sql.ErrNoRows if the product has no variant with this SKU
+func (s *ProductService) ApplyVariant(ctx context.Context, detail *ProductDetail, sku string) error {
+	ctx, span := tracing.Start(ctx, "ProductService.ApplyVariant", attribute.String("product.variant_sku", sku))
+	defer span.End()
+
+	variant, err := s.queries.GetProductVariantBySKU(ctx, sqlc.GetProductVariantBySKUParams{
+		ProductID: detail.Product.ID,
+		Sku:       sku,
+	})
+	if err != nil {
+		return err
+	}
+	overrides, err := s.queries.ListProductVariantSpecs(ctx, variant.ID)
+	if err != nil {
+		return err
+	}
+	images, err := s.queries.ListProductVariantImages(ctx, variant.ID)
+	if err != nil {
+		return err
+	}
+
+	detail.Variant = &variant
+	detail.Product.Sku = variant.Sku
+	detail.Specs = mergeVariantSpecs(detail.Specs, overrides)
+	if len(images) > 0 {
+		gallery := make([]sqlc.ProductImage, 0, len(images))
+		for _, img := range images {
+			gallery = append(gallery, sqlc.ProductImage{
+				ID:           img.ID,
+				ProductID:    detail.Product.ID,
+				ImagePath:    img.ImagePath,
+				AltText:      sql.NullString{String: img.AltText, Valid: img.AltText != ""},
+				DisplayOrder: img.DisplayOrder,
+				MediaType:    ProductMediaImage,
+			})
+		}
+		// The variant's images replace the product's; its videos and 3D/CAD
+		// files still apply
+		for _, media := range detail.Images {
+			if media.MediaType != ProductMediaImage {
+				gallery = append(gallery, media)
+			}
+		}
+		detail.Images = gallery
+		detail.Product.PrimaryImage = sql.NullString{String: images[0].ImagePath, Valid: true}
+	}
+	return nil
+}
+
+// mergeVariantSpecs returns the product specs with a variant's overrides
+// applied. Overridden specs keep their position; new ones follow the
+// product specs in override order.
+func mergeVariantSpecs(specs []sqlc.ProductSpec, overrides []sqlc.ProductVariantSpec) []sqlc.ProductSpec {
+	type specKey struct{ section, key string }
+	index := make(map[specKey]int, len(specs))
+	merged := make([]sqlc.ProductSpec, len(specs), len(specs)+len(overrides))
+	copy(merged, specs)
+	for i, spec := range merged {
+		index[specKey{spec.SectionName, spec.SpecKey}] = i
+	}
+	for _, o := range overrides {
+		if i, ok := index[specKey{o.SectionName, o.SpecKey}]; ok {
+			merged[i].SpecValue = o.SpecValue
+			continue
+		}
+		merged = append(merged, sqlc.ProductSpec{
+			SectionName:  o.SectionName,
+			SpecKey:      o.SpecKey,
+			SpecValue:    o.SpecValue,
+			DisplayOrder: o.DisplayOrder,
+		})
+	}
+	return merged
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// DownloadVersions is a product download with the versions it superseded.
+// The public page links to Latest and lists Older under an "Older versions"
+// expander; each version keeps its own download count.
+type DownloadVersions struct {
+	Latest sqlc.ProductDownload   // Current version
+	Older  []sqlc.ProductDownload // Earlier versions, newest first
+}
+
+// TotalDownloads returns the downloads of all versions together.
+func (v DownloadVersions) TotalDownloads() int64 {
+	total := v.Latest.DownloadCount
+	for _, d := range v.Older {
+		total += d.DownloadCount
+	}
+	return total
+}
+
+// GroupDownloadVersions groups a product's downloads into version chains
+// by supersedes_id. Groups keep the order of their latest versions in
+// downloads (display order); a download that nothing supersedes is the
+// latest of its chain.
+//
+// Parameters:
+//   - downloads: All downloads of one product, in display order
+//
+// Returns:
+//   - []DownloadVersions: One group per current file
+func GroupDownloadVersions(downloads []sqlc.ProductDownload) []DownloadVersions {
+	byID := make(map[int64]sqlc.ProductDownload, len(downloads))
+	superseded := map[int64]bool{}
+	for _, d := range downloads {
+		byID[d.ID] = d
+		if d.SupersedesID.Valid {
+			superseded[d.SupersedesID.Int64] = true
+		}
+	}
+
+	groups := []DownloadVersions{}
+	seen := map[int64]bool{}
+	for _, d := range downloads {
+		if superseded[d.ID] {
+			continue
+		}
+		group := DownloadVersions{Latest: d}
+		seen[d.ID] = true
+		for prev := d.SupersedesID; prev.Valid; {
+			older, ok := byID[prev.Int64]
+			if !ok || seen[older.ID] {
+				break
+			}
+			seen[older.ID] = true
+			group.Older = append(group.Older, older)
+			prev = older.SupersedesID
+		}
+		groups = append(groups, group)
+	}
+	// Versions left over (a chain that loops back on itself) are listed on
+	// their own rather than hidden
+	for _, d := range downloads {
+		if !seen[d.ID] {
+			groups = append(groups, DownloadVersions{Latest: d})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestGroupDownloadVersions(t *testing.T) {
+	supersedes := func(id int64) sql.NullInt64 { return sql.NullInt64{Int64: id, Valid: true} }
+	downloads := []sqlc.ProductDownload{
+		{ID: 1, Title: "Datasheet v1", DownloadCount: 40},
+		{ID: 5, Title: "Manual"},
+		{ID: 2, Title: "Datasheet v2", SupersedesID: supersedes(1), DownloadCount: 10},
+		{ID: 3, Title: "Datasheet v3", SupersedesID: supersedes(2), DownloadCount: 1},
+		{ID: 7, Title: "Loop A", SupersedesID: supersedes(8)},
+		{ID: 8, Title: "Loop B", SupersedesID: supersedes(7)},
+		{ID: 9, Title: "Orphan", SupersedesID: supersedes(99)},
+	}
+
+	groups := services.GroupDownloadVersions(downloads)
+	var got []string
+	for _, g := range groups {
+		line := g.Latest.Title
+		for _, d := range g.Older {
+			line += " < " + d.Title
+		}
+		got = append(got, line)
+	}
+	want := []string{"Manual", "Datasheet v3 < Datasheet v2 < Datasheet v1", "Orphan", "Loop A", "Loop B"}
+	if len(got) != len(want) {
+		t.Fatalf("groups = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("group %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if total := groups[1].TotalDownloads(); total != 51 {
+		t.Errorf("TotalDownloads = %d, want 51", total)
+	}
+	if groups := services.GroupDownloadVersions(nil); len(groups) != 0 {
+		t.Errorf("no downloads: %+v", groups)
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request cancellation between batches
+	"strings" // Joining features
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// productExportBatch is how many products are loaded per query during export.
+const productExportBatch = 500
+
+// ProductExporter writes every product, with its category, status, features
+// and specs, as rows of a spreadsheet. Products are read in batches, so
+// memory use stays flat with thousands of products.
+//
+// The columns match the CSV import (see SuggestImportMapping): one column per
+// distinct spec, headed "Section: Label", and features joined with "|". An
+// exported file can be edited and imported into another installation as is.
+type ProductExporter struct {
+	queries sqlc.Querier // Database query interface for products and their specs
+}
+
+// NewProductExporter creates a ProductExporter.
+func NewProductExporter(queries sqlc.Querier) *ProductExporter {
+	return &ProductExporter{queries: queries}
+}
+
+// Export calls write with the header row, then once per product in ID order.
+// Trashed products are left out.
+//
+// Parameters:
+//   - ctx: Request context; the export stops between batches when it is done
+//   - write: Receives each row; an error from it stops the export
+//   - flush: Called after each batch so rows reach the client as they are ready
+//
+// Returns:
+//   - int: Products written
+//   - error: Database, context, or write errors
+func (e *ProductExporter) Export(ctx context.Context, write func([]string) error, flush func()) (int, error) {
+	specCols, err := e.queries.ListProductSpecColumnsForExport(ctx)
+	if err != nil {
+		return 0, err
+	}
+	header := []string{"sku", "name", "slug", "category", "description", "tagline", "overview", "status", "features"}
+	fixed := len(header)
+	colIndex := make(map[[2]string]int, len(specCols))
+	for i, sc := range specCols {
+		header = append(header, sc.SectionName+": "+sc.SpecKey)
+		colIndex[[2]string{sc.SectionName, sc.SpecKey}] = fixed + i
+	}
+	if err := write(header); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	var afterID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		products, err := e.queries.ListProductsForExport(ctx, sqlc.ListProductsForExportParams{AfterID: afterID, BatchSize: productExportBatch})
+		if err != nil {
+			return written, err
+		}
+		if len(products) == 0 {
+			return written, nil
+		}
+		first, last := products[0].ID, products[len(products)-1].ID
+		specs, err := e.queries.ListProductSpecsForExport(ctx, sqlc.ListProductSpecsForExportParams{FirstID: first, LastID: last})
+		if err != nil {
+			return written, err
+		}
+		features, err := e.queries.ListProductFeaturesForExport(ctx, sqlc.ListProductFeaturesForExportParams{FirstID: first, LastID: last})
+		if err != nil {
+			return written, err
+		}
+
+		specsByProduct := make(map[int64][]sqlc.ListProductSpecsForExportRow)
+		for _, s := range specs {
+			specsByProduct[s.ProductID] = append(specsByProduct[s.ProductID], s)
+		}
+		featuresByProduct := make(map[int64][]string)
+		for _, f := range features {
+			featuresByProduct[f.ProductID] = append(featuresByProduct[f.ProductID], f.FeatureText)
+		}
+
+		for _, p := range products {
+			row := make([]string, len(header))
+			copy(row, []string{
+				p.Sku, p.Name, p.Slug, p.CategorySlug, p.Description,
+				p.Tagline.String, p.Overview.String, p.Status,
+				strings.Join(featuresByProduct[p.ID], " | "),
+			})
+			for _, s := range specsByProduct[p.ID] {
+				// A spec added after the header was written has no column
+				if col, ok := colIndex[[2]string{s.SectionName, s.SpecKey}]; ok {
+					row[col] = s.SpecValue
+				}
+			}
+			if err := write(row); err != nil {
+				return written, err
+			}
+			written++
+		}
+		flush()
+		afterID = last
+		if len(products) < productExportBatch {
+			return written, nil
+		}
+	}
+}
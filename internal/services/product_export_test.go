@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestProductExporter_Export(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	// More than one batch, so paging by ID is exercised
+	for i := 1; i <= 501; i++ {
+		p, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("S-%03d", i), Slug: fmt.Sprintf("s-%03d", i), Name: fmt.Sprintf("Sensor %d", i), Description: "d", CategoryID: cat.ID, Status: "published",
+		})
+		if err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+		if i == 1 || i == 501 {
+			queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V"})
+			queries.CreateProductFeature(ctx, sqlc.CreateProductFeatureParams{ProductID: p.ID, FeatureText: "Rugged"})
+			queries.CreateProductFeature(ctx, sqlc.CreateProductFeatureParams{ProductID: p.ID, FeatureText: "Compact", DisplayOrder: 1})
+		}
+		if i == 2 {
+			queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "Environment", SpecKey: "Range", SpecValue: "-40 to 85 C"})
+		}
+	}
+
+	var rows [][]string
+	flushes := 0
+	n, err := services.NewProductExporter(queries).Export(ctx, func(r []string) error {
+		rows = append(rows, append([]string(nil), r...))
+		return nil
+	}, func() { flushes++ })
+	if err != nil || n != 501 {
+		t.Fatalf("export: n=%d err=%v", n, err)
+	}
+	if flushes != 2 {
+		t.Errorf("expected a flush per batch, got %d", flushes)
+	}
+	wantHeader := "sku,name,slug,category,description,tagline,overview,status,features,Electrical: Voltage,Environment: Range"
+	if got := strings.Join(rows[0], ","); got != wantHeader {
+		t.Errorf("header = %q", got)
+	}
+	if got := strings.Join(rows[1], ","); got != "S-001,Sensor 1,s-001,sensors,d,,,published,Rugged | Compact,24V," {
+		t.Errorf("first row = %q", got)
+	}
+	if last := rows[len(rows)-1]; last[0] != "S-501" || last[9] != "24V" {
+		t.Errorf("last row = %q", last)
+	}
+}
+
+// TestProductExport_ImportRoundTrip checks that an exported CSV maps onto
+// the import without manual changes.
+func TestProductExport_ImportRoundTrip(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	p, _ := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: "A-1", Slug: "a-1", Name: "Alpha", Description: "d", CategoryID: cat.ID, Status: "draft"})
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "Environment", SpecKey: "Range", SpecValue: "-40 to 85 C"})
+	queries.CreateProductFeature(ctx, sqlc.CreateProductFeatureParams{ProductID: p.ID, FeatureText: "Rugged"})
+
+	// Export through the formula guard used by the admin handler
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	services.NewProductExporter(queries).Export(ctx, func(r []string) error {
+		for i := range r {
+			if strings.HasPrefix(r[i], "-") {
+				r[i] = "'" + r[i]
+			}
+		}
+		return w.Write(r)
+	}, func() {})
+	w.Flush()
+
+	// Re-import under new SKUs and slugs
+	exported := strings.NewReplacer("A-1", "B-1", "a-1", "b-1").Replace(buf.String())
+	file, err := services.ParseProductCSV(strings.NewReader(exported))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	mapping := services.SuggestImportMapping(file.Headers)
+	report, err := services.NewProductImporter(db, queries).Import(ctx, file, mapping, false)
+	if err != nil || report.Imported != 1 {
+		t.Fatalf("import: %+v, %v", report, err)
+	}
+	b, _ := queries.GetProductBySKU(ctx, "B-1")
+	specs, _ := queries.ListProductSpecs(ctx, b.ID)
+	if len(specs) != 1 || specs[0].SectionName != "Environment" || specs[0].SpecValue != "-40 to 85 C" {
+		t.Errorf("round-tripped specs = %+v", specs)
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	x, err := services.NewXLSXWriter(&buf, "Products")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	x.Write([]string{"sku", "name"})
+	x.Write([]string{"A<1>", "", "R&D \"x\""})
+	if err := x.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("not a zip: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(b)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{`<c r="A1" t="inlineStr">`, `<c r="B1" t="inlineStr">`, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">A&lt;1&gt;</t>`, `<c r="C2"`, "R&amp;D &#34;x&#34;", "</sheetData></worksheet>"} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet: expected %q", want)
+		}
+	}
+	if strings.Contains(sheet, `r="B2"`) {
+		t.Error("empty cells should be omitted")
+	}
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Database calls
+	"net/url" // Filter selections come from the query string
+	"sort"    // Ordering facet values and selections
+	"strconv" // Numeric comparison of spec values
+	"strings" // Parameter prefixes and value normalisation
+	"time"    // "New" status window
+	"unicode" // Finding the numeric prefix of spec values
+
+	// Third-party imports
+	"go.opentelemetry.io/otel/attribute" // Span attributes
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc"          // Generated database query code from sqlc
+	"github.com/narendhupati/bluejay-cms/internal/tracing" // Service spans under the request span
+)
+
+// Query parameters of the category page filters. Spec facets use
+// SpecFacetPrefix followed by a slug of the spec's section and label, e.g.
+// ?spec_electrical-voltage=24V.
+const (
+	FacetStatus        = "status"
+	FacetCertification = "cert"
+	SpecFacetPrefix    = "spec_"
+)
+
+// Values of the status facet.
+const (
+	StatusFacetFeatured = "featured" // Marked as featured by an editor
+	StatusFacetNew      = "new"      // Published within NewProductWindow
+)
+
+// NewProductWindow is how long after publishing a product counts as new.
+const NewProductWindow = 90 * 24 * time.Hour
+
+// maxSpecFacetValues caps the distinct values a spec may have to become a
+// facet. Specs with more (serial numbers, exact dimensions) would make
+// unusable filter lists.
+const maxSpecFacetValues = 12
+
+// FacetValue is one checkbox in the filter sidebar.
+type FacetValue struct {
+	Value    string // Query parameter value
+	Label    string // Display text
+	Count    int    // Matching products if this value were added to the other filters
+	Selected bool   // Value is part of the current filters
+}
+
+// Facet is one group of values in the filter sidebar. Values of a facet are
+// alternatives (any may match); different facets must all match.
+type Facet struct {
+	Param  string       // Query parameter name
+	Label  string       // Heading, e.g. "Voltage"
+	Values []FacetValue // Values in display order
+}
+
+// FacetedProducts is a category's product list narrowed by filters, with
+// the facets to narrow it further.
+type FacetedProducts struct {
+	Facets   []Facet                                 // Facets offered for the category
+	Products []sqlc.ListAllProductsInCategoryTreeRow // Matching products in the requested sort order
+	Filters  url.Values                              // The requested filters that exist, values sorted
+}
+
+// FacetFilters returns the filter parameters of a query string, with values
+// trimmed, de-duplicated and sorted so equal selections compare (and cache)
+// the same. Other parameters, such as page, are left out.
+func FacetFilters(query url.Values) url.Values {
+	filters := url.Values{}
+	for param, values := range query {
+		if param != FacetStatus && param != FacetCertification && !strings.HasPrefix(param, SpecFacetPrefix) {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" && !seen[v] {
+				seen[v] = true
+				filters[param] = append(filters[param], v)
+			}
+		}
+		sort.Strings(filters[param])
+	}
+	return filters
+}
+
+// CategoryFacets lists the published products of a category subtree that
+// match filters, and builds the facets from the products' specs,
+// certifications and status:
+//   - Status: featured, and new (published within NewProductWindow)
+//   - Certifications: every certification held by a product
+//   - One facet per spec with 2 to 12 distinct values across the products,
+//     at least one of them shared by two products
+//
+// Each value's count is the number of products that would match if it were
+// selected as well, so counts never lead to an empty page. Filters naming
+// facets or values that do not exist are dropped from the returned Filters.
+//
+// Filtering happens in memory: a category holds at most a few hundred
+// products, and the rendered pages are cached.
+//
+// Parameters:
+//   - ctx: Request context
+//   - categoryID: Root of the category subtree
+//   - filters: Selections from FacetFilters
+//   - order: Product order, one of ProductSortOptions
+//   - now: Current time, for the "new" status
+//
+// Returns:
+//   - *FacetedProducts: Matching products, facets and the valid filters
+//   - error: Database errors
+func (s *ProductService) CategoryFacets(ctx context.Context, categoryID int64, filters url.Values, order string, now time.Time) (*FacetedProducts, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.CategoryFacets", attribute.Int64("product.category_id", categoryID))
+	defer span.End()
+
+	products, err := s.queries.ListAllProductsInCategoryTree(ctx, sqlc.ListAllProductsInCategoryTreeParams{CategoryID: categoryID, Sort: order})
+	if err != nil {
+		return nil, err
+	}
+	specs, err := s.queries.ListCategoryTreeSpecFacets(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := s.queries.ListCategoryTreeCertificationFacets(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// has[param][value] is the set of product IDs with that value
+	has := map[string]map[string]map[int64]bool{}
+	add := func(param, value string, productID int64) {
+		if has[param] == nil {
+			has[param] = map[string]map[int64]bool{}
+		}
+		if has[param][value] == nil {
+			has[param][value] = map[int64]bool{}
+		}
+		has[param][value][productID] = true
+	}
+
+	var facets []Facet
+	statusFacet := Facet{Param: FacetStatus, Label: "Status"}
+	for _, p := range products {
+		if p.IsFeatured {
+			add(FacetStatus, StatusFacetFeatured, p.ID)
+		}
+		if p.PublishedAt.Valid && now.Sub(p.PublishedAt.Time) < NewProductWindow {
+			add(FacetStatus, StatusFacetNew, p.ID)
+		}
+	}
+	for _, v := range []struct{ value, label string }{{StatusFacetFeatured, "Featured"}, {StatusFacetNew, "New"}} {
+		if len(has[FacetStatus][v.value]) > 0 {
+			statusFacet.Values = append(statusFacet.Values, FacetValue{Value: v.value, Label: v.label})
+		}
+	}
+	if len(statusFacet.Values) > 0 {
+		facets = append(facets, statusFacet)
+	}
+
+	certFacet := Facet{Param: FacetCertification, Label: "Certifications"}
+	for _, c := range certs {
+		name := strings.TrimSpace(c.CertificationName)
+		if name == "" {
+			continue
+		}
+		if len(has[FacetCertification][name]) == 0 {
+			certFacet.Values = append(certFacet.Values, FacetValue{Value: name, Label: name})
+		}
+		add(FacetCertification, name, c.ProductID)
+	}
+	if len(certFacet.Values) > 0 {
+		facets = append(facets, certFacet)
+	}
+
+	// Spec facets, in the order specs first appear
+	var specParams []string
+	specLabels := map[string]string{}
+	for _, sp := range specs {
+		value := strings.TrimSpace(sp.SpecValue)
+		param := SpecFacetPrefix + Slugify(sp.SectionName+" "+sp.SpecKey)
+		if value == "" || param == SpecFacetPrefix {
+			continue
+		}
+		if _, ok := specLabels[param]; !ok {
+			specParams = append(specParams, param)
+			specLabels[param] = sp.SpecKey
+		}
+		add(param, value, sp.ProductID)
+	}
+	for _, param := range specParams {
+		if n := len(has[param]); n < 2 || n > maxSpecFacetValues || !sharedValue(has[param]) {
+			continue
+		}
+		facet := Facet{Param: param, Label: specLabels[param]}
+		for value := range has[param] {
+			facet.Values = append(facet.Values, FacetValue{Value: value, Label: value})
+		}
+		sort.Slice(facet.Values, func(i, j int) bool { return naturalLess(facet.Values[i].Value, facet.Values[j].Value) })
+		facets = append(facets, facet)
+	}
+
+	// Keep only selections of offered values
+	valid := url.Values{}
+	for _, f := range facets {
+		for _, v := range filters[f.Param] {
+			if offered(f, v) {
+				valid[f.Param] = append(valid[f.Param], v)
+			}
+		}
+	}
+
+	// matches reports whether a product satisfies every filter except the
+	// one on skip
+	matches := func(id int64, skip string) bool {
+		for param, values := range valid {
+			if param == skip {
+				continue
+			}
+			found := false
+			for _, v := range values {
+				if has[param][v][id] {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	result := &FacetedProducts{Filters: valid}
+	for _, p := range products {
+		if matches(p.ID, "") {
+			result.Products = append(result.Products, p)
+		}
+	}
+	for i := range facets {
+		f := &facets[i]
+		for j := range f.Values {
+			v := &f.Values[j]
+			for id := range has[f.Param][v.Value] {
+				if matches(id, f.Param) {
+					v.Count++
+				}
+			}
+			for _, sel := range valid[f.Param] {
+				v.Selected = v.Selected || sel == v.Value
+			}
+		}
+	}
+	result.Facets = facets
+	return result, nil
+}
+
+// sharedValue reports whether any value is held by more than one product.
+// Specs where every product has its own value (model numbers, exact sizes)
+// cannot group products, so they are not offered as facets.
+func sharedValue(values map[string]map[int64]bool) bool {
+	for _, products := range values {
+		if len(products) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// offered reports whether value is one of the facet's values.
+func offered(f Facet, value string) bool {
+	for _, v := range f.Values {
+		if v.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// naturalLess orders spec values so numbers sort by magnitude ("5 V" before
+// "12 V") and text case-insensitively.
+func naturalLess(a, b string) bool {
+	na, okA := leadingNumber(a)
+	nb, okB := leadingNumber(b)
+	if okA && okB && na != nb {
+		return na < nb
+	}
+	if okA != okB {
+		return okA
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// leadingNumber parses the number at the start of s, e.g. -40 in "-40 °C".
+func leadingNumber(s string) (float64, bool) {
+	end := 0
+	for i, r := range s {
+		if unicode.IsDigit(r) || r == '.' || (i == 0 && r == '-') {
+			end = i + 1
+			continue
+		}
+		break
+	}
+	n, err := strconv.ParseFloat(s[:end], 64)
+	return n, err == nil
+}
@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestFacetFilters(t *testing.T) {
+	got := services.FacetFilters(url.Values{
+		"page":                   {"2"},
+		"cert":                   {"UL", " CE ", "UL", ""},
+		"spec_electrical-supply": {"24 V"},
+		"q":                      {"ignored"},
+	})
+	if want := "cert=CE&cert=UL&spec_electrical-supply=24+V"; got.Encode() != want {
+		t.Errorf("FacetFilters = %q, want %q", got.Encode(), want)
+	}
+}
+
+func TestCategoryFacets(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Now()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	other, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Other", Slug: "other", Description: "d", Icon: "i"})
+	type spec struct{ supply, serial string }
+	seed := []struct {
+		cat      int64
+		status   string
+		featured bool
+		age      time.Duration
+		spec     spec
+		certs    []string
+	}{
+		{cat.ID, "published", true, time.Hour, spec{"24 V", "A1"}, []string{"CE", "UL"}},
+		{cat.ID, "published", false, 200 * 24 * time.Hour, spec{"5 V", "A2"}, []string{"CE"}},
+		{cat.ID, "published", false, 200 * 24 * time.Hour, spec{"12 V", "A3"}, nil},
+		{cat.ID, "published", false, 200 * 24 * time.Hour, spec{"24 V", "A4"}, nil},
+		{cat.ID, "draft", false, time.Hour, spec{"48 V", "A5"}, []string{"ATEX"}},
+		{other.ID, "published", false, time.Hour, spec{"48 V", "A6"}, []string{"ATEX"}},
+	}
+	ids := make([]int64, len(seed))
+	for i, s := range seed {
+		p, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("S-%d", i), Slug: fmt.Sprintf("s-%d", i), Name: fmt.Sprintf("Sensor %d", i), Description: "d",
+			CategoryID: s.cat, Status: s.status, IsFeatured: s.featured, FeaturedOrder: sql.NullInt64{Int64: 1, Valid: s.featured},
+			PublishedAt: sql.NullTime{Time: now.Add(-s.age), Valid: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = p.ID
+		queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "Electrical", SpecKey: "Supply", SpecValue: s.spec.supply})
+		// A different value on every product: not useful as a filter
+		if i < 2 {
+			queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: p.ID, SectionName: "General", SpecKey: "Serial", SpecValue: s.spec.serial})
+		}
+		for _, c := range s.certs {
+			queries.CreateProductCertification(ctx, sqlc.CreateProductCertificationParams{ProductID: p.ID, CertificationName: c})
+		}
+	}
+	svc := services.NewProductService(queries)
+
+	// summary renders a facet as "param: value(count) ..." with * for selected
+	summary := func(f services.Facet) string {
+		s := f.Param + ":"
+		for _, v := range f.Values {
+			sel := ""
+			if v.Selected {
+				sel = "*"
+			}
+			s += fmt.Sprintf(" %s%s(%d)", sel, v.Value, v.Count)
+		}
+		return s
+	}
+
+	res, err := svc.CategoryFacets(ctx, cat.ID, url.Values{}, services.SortNewest, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Products) != 4 {
+		t.Fatalf("expected the 4 published products, got %d", len(res.Products))
+	}
+	want := []string{
+		"status: featured(1) new(1)",
+		"cert: CE(2) UL(1)",
+		"spec_electrical-supply: 5 V(1) 12 V(1) 24 V(2)",
+	}
+	if len(res.Facets) != len(want) {
+		t.Fatalf("facets: got %d, want %d", len(res.Facets), len(want))
+	}
+	for i, f := range res.Facets {
+		if got := summary(f); got != want[i] {
+			t.Errorf("facet %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	// Values of one facet are alternatives; facets combine with AND, and each
+	// facet's counts ignore its own selection
+	res, _ = svc.CategoryFacets(ctx, cat.ID, url.Values{"cert": {"CE"}, "spec_electrical-supply": {"24 V", "5 V"}}, services.SortNewest, now)
+	if len(res.Products) != 2 || res.Products[0].ID != ids[0] || res.Products[1].ID != ids[1] {
+		t.Errorf("filtered products = %v", res.Products)
+	}
+	for i, want := range []string{
+		"status: featured(1) new(1)",
+		"cert: *CE(2) UL(1)",
+		"spec_electrical-supply: *5 V(1) 12 V(0) *24 V(1)",
+	} {
+		if got := summary(res.Facets[i]); got != want {
+			t.Errorf("filtered facet %d = %q, want %q", i, got, want)
+		}
+	}
+
+	// Filters that are not offered are dropped
+	res, _ = svc.CategoryFacets(ctx, cat.ID, url.Values{"cert": {"ATEX", "CE"}, "spec_general-serial": {"A1"}}, services.SortNewest, now)
+	if res.Filters.Encode() != "cert=CE" {
+		t.Errorf("valid filters = %q", res.Filters.Encode())
+	}
+}
@@ -0,0 +1,423 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes"        // Sniffing the delimiter and stripping the Excel BOM
+	"context"      // Request cancellation for lookups and the import transaction
+	"database/sql" // Import transaction and nullable product columns
+	"encoding/csv" // Parsing the uploaded spreadsheet
+	"errors"       // Input validation errors
+	"fmt"          // Row error messages
+	"io"           // Reading the upload
+	"strings"      // Header normalization and list splitting
+	"time"         // published_at for rows imported as published
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Fields a CSV column can be mapped to in the product import. A column mapped
+// to ImportFieldSpec becomes one spec per row, named after the column header.
+const (
+	ImportFieldIgnore      = ""
+	ImportFieldSKU         = "sku"
+	ImportFieldName        = "name"
+	ImportFieldSlug        = "slug"
+	ImportFieldCategory    = "category"
+	ImportFieldDescription = "description"
+	ImportFieldTagline     = "tagline"
+	ImportFieldOverview    = "overview"
+	ImportFieldStatus      = "status"
+	ImportFieldFeatures    = "features"
+	ImportFieldSpec        = "spec"
+)
+
+// ImportFields lists the mappable fields in the order the mapping step shows them.
+var ImportFields = []string{
+	ImportFieldSKU, ImportFieldName, ImportFieldSlug, ImportFieldCategory,
+	ImportFieldDescription, ImportFieldTagline, ImportFieldOverview,
+	ImportFieldStatus, ImportFieldFeatures, ImportFieldSpec,
+}
+
+// MaxImportRows caps the data rows in one import so a single request stays short.
+const MaxImportRows = 5000
+
+// defaultSpecSection is the spec section for spec columns whose header has
+// no "Section: " prefix.
+const defaultSpecSection = "Specifications"
+
+// ProductCSV is a parsed product spreadsheet: the header row and the data rows.
+type ProductCSV struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ParseProductCSV reads a CSV export, e.g. from Excel's "Save as CSV". The
+// first row is the header. A UTF-8 byte order mark is dropped, and files whose
+// header uses more semicolons than commas (Excel in many European locales)
+// are read with ";" as the delimiter. The quote that CSV exports put before
+// values starting with =, +, - or @ (to stop spreadsheet formulas) is removed.
+//
+// Parameters:
+//   - r: The uploaded file
+//
+// Returns:
+//   - *ProductCSV: Headers and rows, with surrounding whitespace trimmed
+//   - error: Unreadable CSV, no data rows, or more than MaxImportRows rows
+func ParseProductCSV(r io.Reader) (*ProductCSV, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1 // Short rows are padded below
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("the file needs a header row and at least one product")
+	}
+	if len(records)-1 > MaxImportRows {
+		return nil, fmt.Errorf("the file has %d products; import at most %d at a time", len(records)-1, MaxImportRows)
+	}
+
+	headers := records[0]
+	for i := range headers {
+		headers[i] = strings.TrimSpace(headers[i])
+	}
+	rows := make([][]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make([]string, len(headers))
+		for i := range row {
+			if i < len(rec) {
+				row[i] = unquoteFormula(strings.TrimSpace(rec[i]))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return &ProductCSV{Headers: headers, Rows: rows}, nil
+}
+
+// SuggestImportMapping guesses a field for each header from its name, so
+// files using the export's column names need no manual mapping. Headers of
+// the form "Section: Label" are taken as specs; other unknown headers are
+// left unmapped.
+func SuggestImportMapping(headers []string) []string {
+	aliases := map[string]string{
+		"sku":          ImportFieldSKU,
+		"productcode":  ImportFieldSKU,
+		"name":         ImportFieldName,
+		"productname":  ImportFieldName,
+		"slug":         ImportFieldSlug,
+		"category":     ImportFieldCategory,
+		"categoryslug": ImportFieldCategory,
+		"categoryname": ImportFieldCategory,
+		"description":  ImportFieldDescription,
+		"tagline":      ImportFieldTagline,
+		"overview":     ImportFieldOverview,
+		"status":       ImportFieldStatus,
+		"features":     ImportFieldFeatures,
+	}
+	mapping := make([]string, len(headers))
+	for i, h := range headers {
+		key := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(h))
+		mapping[i] = aliases[key]
+		if mapping[i] == "" && strings.Contains(h, ":") {
+			mapping[i] = ImportFieldSpec
+		}
+	}
+	return mapping
+}
+
+// ImportRowResult is one line of the validation report.
+type ImportRowResult struct {
+	Line   int      // Line in the file, counting the header as line 1
+	SKU    string   // SKU from the row, for display
+	Name   string   // Product name from the row, for display
+	Specs  int      // Specs the row would create
+	Errors []string // Problems that block the import; empty when the row is valid
+}
+
+// ImportReport summarizes a validation or import run.
+type ImportReport struct {
+	Rows     []ImportRowResult
+	Errors   []string // Problems with the mapping itself, e.g. no SKU column
+	Invalid  int      // Rows with at least one error
+	DryRun   bool     // True when nothing was written
+	Imported int      // Products created; 0 for dry runs and failed imports
+}
+
+// OK reports whether the file can be imported as mapped.
+func (r *ImportReport) OK() bool {
+	return len(r.Errors) == 0 && r.Invalid == 0
+}
+
+// importSpec is one spec a row creates.
+type importSpec struct {
+	section, key, value string
+}
+
+// importRow is a validated row ready to insert.
+type importRow struct {
+	params   sqlc.CreateProductParams
+	specs    []importSpec
+	features []string
+}
+
+// ProductImporter validates product spreadsheets and imports them in one
+// transaction: either every row is created, with its specs and features, or
+// none is.
+type ProductImporter struct {
+	db      *sql.DB       // Connection that opens the import transaction
+	queries *sqlc.Queries // Lookups for categories and existing SKUs/slugs
+}
+
+// NewProductImporter creates a ProductImporter.
+//
+// Parameters:
+//   - db: Database connection, used to start the import transaction
+//   - queries: Database query interface from sqlc
+//
+// Returns:
+//   - *ProductImporter: Importer ready for use
+func NewProductImporter(db *sql.DB, queries *sqlc.Queries) *ProductImporter {
+	return &ProductImporter{db: db, queries: queries}
+}
+
+// Import validates every row of file under mapping and, unless dryRun is set
+// or any row is invalid, creates the products in a single transaction.
+//
+// Validation reports, per row: missing required fields (SKU, name, category,
+// description), unknown categories (matched by slug or name), invalid
+// statuses, and SKUs or slugs that repeat within the file or already exist.
+//
+// Parameters:
+//   - ctx: Request context
+//   - file: Parsed spreadsheet
+//   - mapping: Field for each column (ImportField* values), same length as file.Headers
+//   - dryRun: Validate only
+//
+// Returns:
+//   - *ImportReport: Per-row results; Imported is set when products were created
+//   - error: Database failures other than a row being rejected
+func (p *ProductImporter) Import(ctx context.Context, file *ProductCSV, mapping []string, dryRun bool) (*ImportReport, error) {
+	rows, report, err := p.validate(ctx, file, mapping)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = dryRun
+	if dryRun || !report.OK() {
+		return report, nil
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := p.queries.WithTx(tx)
+
+	for i, row := range rows {
+		product, err := qtx.CreateProduct(ctx, row.params)
+		if err != nil {
+			// e.g. a slug held by a product in the trash; nothing is kept
+			report.Rows[i].Errors = append(report.Rows[i].Errors, "could not be created: "+err.Error())
+			report.Invalid++
+			return report, nil
+		}
+		for order, spec := range row.specs {
+			if _, err := qtx.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{
+				ProductID:    product.ID,
+				SectionName:  spec.section,
+				SpecKey:      spec.key,
+				SpecValue:    spec.value,
+				DisplayOrder: int64(order),
+			}); err != nil {
+				return nil, err
+			}
+		}
+		for order, feature := range row.features {
+			if _, err := qtx.CreateProductFeature(ctx, sqlc.CreateProductFeatureParams{
+				ProductID:    product.ID,
+				FeatureText:  feature,
+				DisplayOrder: int64(order),
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	report.Imported = len(rows)
+	return report, nil
+}
+
+// validate builds the insert parameters for each row and the report.
+func (p *ProductImporter) validate(ctx context.Context, file *ProductCSV, mapping []string) ([]importRow, *ImportReport, error) {
+	report := &ImportReport{}
+	if len(mapping) != len(file.Headers) {
+		return nil, nil, errors.New("mapping does not match the file's columns")
+	}
+	mapped := map[string]int{}
+	for col, field := range mapping {
+		if field == ImportFieldIgnore || field == ImportFieldSpec {
+			continue
+		}
+		if _, dup := mapped[field]; dup {
+			report.Errors = append(report.Errors, fmt.Sprintf("%q is mapped to more than one column", field))
+		}
+		mapped[field] = col
+	}
+	for _, field := range []string{ImportFieldSKU, ImportFieldName, ImportFieldCategory, ImportFieldDescription} {
+		if _, ok := mapped[field]; !ok {
+			report.Errors = append(report.Errors, fmt.Sprintf("no column is mapped to %q", field))
+		}
+	}
+	if len(report.Errors) > 0 {
+		return nil, report, nil
+	}
+
+	categories, err := p.queries.ListProductCategories(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	categoryIDs := make(map[string]int64, 2*len(categories))
+	for _, cat := range categories {
+		categoryIDs[strings.ToLower(cat.Slug)] = cat.ID
+		categoryIDs[strings.ToLower(cat.Name)] = cat.ID
+	}
+
+	value := func(row []string, field string) string {
+		if col, ok := mapped[field]; ok {
+			return row[col]
+		}
+		return ""
+	}
+	seenSKU := map[string]int{}
+	seenSlug := map[string]int{}
+	rows := make([]importRow, 0, len(file.Rows))
+	for i, rec := range file.Rows {
+		line := i + 2
+		res := ImportRowResult{Line: line, SKU: value(rec, ImportFieldSKU), Name: value(rec, ImportFieldName)}
+		fail := func(format string, args ...interface{}) {
+			res.Errors = append(res.Errors, fmt.Sprintf(format, args...))
+		}
+
+		for _, field := range []string{ImportFieldSKU, ImportFieldName, ImportFieldCategory, ImportFieldDescription} {
+			if value(rec, field) == "" {
+				fail("%s is required", field)
+			}
+		}
+
+		if sku := res.SKU; sku != "" {
+			if first, dup := seenSKU[strings.ToLower(sku)]; dup {
+				fail("duplicate SKU %s (also on line %d)", sku, first)
+			} else if _, err := p.queries.GetProductBySKU(ctx, sku); err == nil {
+				fail("SKU %s already exists", sku)
+			} else {
+				seenSKU[strings.ToLower(sku)] = line
+			}
+		}
+
+		slug := value(rec, ImportFieldSlug)
+		if slug == "" {
+			slug = Slugify(res.Name)
+		}
+		if slug != "" {
+			if first, dup := seenSlug[slug]; dup {
+				fail("duplicate slug %s (also on line %d)", slug, first)
+			} else if _, err := p.queries.GetProductBySlug(ctx, slug); err == nil {
+				fail("slug %s already exists", slug)
+			} else {
+				seenSlug[slug] = line
+			}
+		}
+
+		var categoryID int64
+		if cat := value(rec, ImportFieldCategory); cat != "" {
+			id, ok := categoryIDs[strings.ToLower(cat)]
+			if !ok {
+				fail("category %q not found", cat)
+			}
+			categoryID = id
+		}
+
+		status := strings.ToLower(value(rec, ImportFieldStatus))
+		if status == "" {
+			status = "draft"
+		}
+		if status != "draft" && status != "published" && status != "archived" {
+			fail("status %q must be draft, published or archived", status)
+		}
+		var publishedAt sql.NullTime
+		if status == "published" {
+			publishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		}
+
+		row := importRow{
+			params: sqlc.CreateProductParams{
+				Sku:         res.SKU,
+				Slug:        slug,
+				Name:        res.Name,
+				Tagline:     nullIfEmpty(value(rec, ImportFieldTagline)),
+				Description: value(rec, ImportFieldDescription),
+				Overview:    nullIfEmpty(value(rec, ImportFieldOverview)),
+				CategoryID:  categoryID,
+				Status:      status,
+				PublishedAt: publishedAt,
+			},
+			features: splitFeatures(value(rec, ImportFieldFeatures)),
+		}
+		for col, field := range mapping {
+			if field != ImportFieldSpec || rec[col] == "" {
+				continue
+			}
+			section, key := defaultSpecSection, file.Headers[col]
+			if s, k, ok := strings.Cut(key, ":"); ok {
+				section, key = strings.TrimSpace(s), strings.TrimSpace(k)
+			}
+			row.specs = append(row.specs, importSpec{section: section, key: key, value: rec[col]})
+		}
+		res.Specs = len(row.specs)
+
+		if len(res.Errors) > 0 {
+			report.Invalid++
+		}
+		report.Rows = append(report.Rows, res)
+		rows = append(rows, row)
+	}
+	return rows, report, nil
+}
+
+// unquoteFormula undoes the CSV injection guard of exports: "'-40 °C" is read
+// as "-40 °C".
+func unquoteFormula(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune("=+-@", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
+
+// splitFeatures splits a features cell on "|" into bullet points.
+func splitFeatures(cell string) []string {
+	var features []string
+	for _, f := range strings.Split(cell, "|") {
+		if f = strings.TrimSpace(f); f != "" {
+			features = append(features, f)
+		}
+	}
+	return features
+}
+
+// nullIfEmpty stores empty optional text as NULL.
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
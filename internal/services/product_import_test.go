@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestParseProductCSV(t *testing.T) {
+	// Excel-style: BOM, semicolons, a short row
+	file, err := services.ParseProductCSV(strings.NewReader("\xef\xbb\xbfSKU;Name;Electrical: Voltage\nA-1;Alpha;24V\nB-2;Beta\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := strings.Join(file.Headers, "|"); got != "SKU|Name|Electrical: Voltage" {
+		t.Errorf("headers = %q", got)
+	}
+	if len(file.Rows) != 2 || file.Rows[1][2] != "" {
+		t.Errorf("rows = %q, want the short row padded", file.Rows)
+	}
+
+	if _, err := services.ParseProductCSV(strings.NewReader("sku,name\n")); err == nil {
+		t.Error("expected an error for a file without products")
+	}
+	if got := services.SuggestImportMapping([]string{"SKU", "Product Name", "category_slug", "Weight"}); strings.Join(got, ",") != "sku,name,category," {
+		t.Errorf("suggested mapping = %q", got)
+	}
+}
+
+func TestProductImporter_Import(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	if _, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "OLD-1", Slug: "old-1", Name: "Old", Description: "d", CategoryID: cat.ID, Status: "draft",
+	}); err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	importer := services.NewProductImporter(db, queries)
+	mapping := []string{"sku", "name", "category", "description", "status", "features", "spec"}
+	header := "sku,name,category,description,status,features,Electrical: Voltage\n"
+
+	// Invalid rows: nothing is written, each problem is reported on its line
+	file, _ := services.ParseProductCSV(strings.NewReader(header +
+		"NEW-1,New One,Sensors,d,published,Fast|Small,24V\n" +
+		"NEW-1,New Two,sensors,d,,,\n" +
+		"OLD-1,Old Again,sensors,d,,,\n" +
+		"NEW-3,New Three,Pumps,d,live,,\n"))
+	report, err := importer.Import(ctx, file, mapping, false)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if report.OK() || report.Invalid != 3 || report.Imported != 0 {
+		t.Fatalf("expected 3 invalid rows and no import, got %+v", report)
+	}
+	wantErrors := map[int]string{3: "duplicate SKU NEW-1 (also on line 2)", 4: "SKU OLD-1 already exists", 5: `category "Pumps" not found`}
+	for _, row := range report.Rows {
+		if want, ok := wantErrors[row.Line]; ok && (len(row.Errors) == 0 || row.Errors[0] != want) {
+			t.Errorf("line %d errors = %q, want %q first", row.Line, row.Errors, want)
+		}
+	}
+	if _, err := queries.GetProductBySKU(ctx, "NEW-1"); err == nil {
+		t.Error("a failed import must not create any product")
+	}
+
+	// Dry run of a valid file writes nothing
+	file, _ = services.ParseProductCSV(strings.NewReader(header +
+		"NEW-1,New One,Sensors,d,published,Fast|Small,24V\n" +
+		"NEW-2,New Two,sensors,d,,,\n"))
+	if report, err = importer.Import(ctx, file, mapping, true); err != nil || !report.OK() || report.Imported != 0 {
+		t.Fatalf("dry run: %+v, %v", report, err)
+	}
+	if _, err := queries.GetProductBySKU(ctx, "NEW-1"); err == nil {
+		t.Error("a dry run must not create any product")
+	}
+
+	if report, err = importer.Import(ctx, file, mapping, false); err != nil || report.Imported != 2 {
+		t.Fatalf("import: %+v, %v", report, err)
+	}
+	p, err := queries.GetProductBySKU(ctx, "NEW-1")
+	if err != nil {
+		t.Fatalf("imported product: %v", err)
+	}
+	if p.Slug != "new-one" || p.Status != "published" || !p.PublishedAt.Valid || p.CategoryID != cat.ID {
+		t.Errorf("imported product = %+v", p)
+	}
+	specs, _ := queries.ListProductSpecs(ctx, p.ID)
+	if len(specs) != 1 || specs[0].SectionName != "Electrical" || specs[0].SpecKey != "Voltage" || specs[0].SpecValue != "24V" {
+		t.Errorf("specs = %+v", specs)
+	}
+	features, _ := queries.ListProductFeatures(ctx, p.ID)
+	if len(features) != 2 || features[1].FeatureText != "Small" {
+		t.Errorf("features = %+v", features)
+	}
+	if p2, _ := queries.GetProductBySKU(ctx, "NEW-2"); p2.Status != "draft" {
+		t.Errorf("default status = %q, want draft", p2.Status)
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	// Standard library imports
+	"net/url"       // Parsing video page links
+	"path/filepath" // File extensions of 3D/CAD files
+	"regexp"        // Video ID formats
+	"strings"       // Host and path matching
+)
+
+// Product gallery media types (product_images.media_type).
+const (
+	ProductMediaImage = "image" // image_path is the image
+	ProductMediaVideo = "video" // media_url is a player URL or an uploaded MP4/WebM file
+	ProductMediaModel = "model" // media_url is an uploaded 3D/CAD file
+)
+
+// ProductModelExtensions are the 3D/CAD file types product galleries accept:
+// STEP, IGES, STL, OBJ, glTF, 3MF, DWG and DXF.
+var ProductModelExtensions = []string{".step", ".stp", ".iges", ".igs", ".stl", ".obj", ".glb", ".gltf", ".3mf", ".dwg", ".dxf"}
+
+// ProductVideoExtensions are the video file types product galleries accept;
+// browsers play both without plugins.
+var ProductVideoExtensions = []string{".mp4", ".webm"}
+
+var (
+	youTubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// VideoEmbedURL turns a YouTube or Vimeo link into the URL of its embeddable
+// player, e.g. "https://youtu.be/dQw4w9WgXcQ" into
+// "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ". YouTube videos use
+// the privacy-enhanced youtube-nocookie.com player, which sets no cookies
+// until the visitor plays the video.
+//
+// Accepted links: youtube.com/watch?v=ID, youtu.be/ID, youtube.com/embed/ID,
+// youtube.com/shorts/ID, vimeo.com/ID and player.vimeo.com/video/ID.
+//
+// Returns:
+//   - string: Player URL, or "" when link is not a YouTube or Vimeo video
+func VideoEmbedURL(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var id string
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		switch {
+		case len(segments) == 1 && segments[0] == "watch":
+			id = u.Query().Get("v")
+		case len(segments) == 2 && (segments[0] == "embed" || segments[0] == "shorts"):
+			id = segments[1]
+		}
+		if youTubeID.MatchString(id) {
+			return "https://www.youtube-nocookie.com/embed/" + id
+		}
+	case "youtu.be":
+		if len(segments) == 1 && youTubeID.MatchString(segments[0]) {
+			return "https://www.youtube-nocookie.com/embed/" + segments[0]
+		}
+	case "vimeo.com":
+		id = segments[0]
+		if len(segments) == 1 && vimeoID.MatchString(id) {
+			return "https://player.vimeo.com/video/" + id
+		}
+	case "player.vimeo.com":
+		if len(segments) == 2 && segments[0] == "video" && vimeoID.MatchString(segments[1]) {
+			return "https://player.vimeo.com/video/" + segments[1]
+		}
+	}
+	return ""
+}
+
+// hasExtension reports whether the file name ends in one of exts, ignoring case.
+func hasExtension(name string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestVideoEmbedURL(t *testing.T) {
+	tests := []struct {
+		link, want string
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{" https://m.youtube.com/shorts/dQw4w9WgXcQ ", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"https://www.youtube.com/embed/dQw4w9WgXcQ", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"https://vimeo.com/76979871", "https://player.vimeo.com/video/76979871"},
+		{"https://player.vimeo.com/video/76979871", "https://player.vimeo.com/video/76979871"},
+		{"https://www.youtube.com/watch?v=short", ""},
+		{"https://www.youtube.com/channel/UC123", ""},
+		{"https://vimeo.com/channels/staffpicks", ""},
+		{"https://example.com/video.mp4", ""},
+		{"javascript:alert(1)", ""},
+		{"youtu.be/dQw4w9WgXcQ", ""},
+	}
+	for _, tt := range tests {
+		if got := services.VideoEmbedURL(tt.link); got != tt.want {
+			t.Errorf("VideoEmbedURL(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestUploadProductVideoAndModel(t *testing.T) {
+	svc := services.NewUploadService(t.TempDir())
+
+	path, err := svc.UploadProductVideo(createMultipartFileHeader(t, "demo.MP4", []byte("video"), "video/mp4"))
+	if err != nil || !strings.HasPrefix(path, "/uploads/products/") || !strings.HasSuffix(path, "_demo.MP4") {
+		t.Errorf("UploadProductVideo = %q, %v", path, err)
+	}
+	if _, err := svc.UploadProductVideo(createMultipartFileHeader(t, "demo.avi", []byte("video"), "video/x-msvideo")); err == nil {
+		t.Error("UploadProductVideo accepted an AVI file")
+	}
+
+	path, err = svc.UploadProductModel(createMultipartFileHeader(t, "housing v2.step", []byte("ISO-10303-21;"), "application/octet-stream"))
+	if err != nil || !strings.HasPrefix(path, "/uploads/models/") || !strings.HasSuffix(path, "_housing_v2.step") {
+		t.Errorf("UploadProductModel = %q, %v", path, err)
+	}
+	if _, err := svc.UploadProductModel(createMultipartFileHeader(t, "setup.exe", []byte("MZ"), "application/octet-stream")); err == nil {
+		t.Error("UploadProductModel accepted an executable")
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+// Sort orders of the public product listings (category pages and product
+// search), selected with ?sort= or the products_default_sort setting.
+const (
+	SortNameAsc  = "name_asc"  // Name A-Z
+	SortNameDesc = "name_desc" // Name Z-A
+	SortNewest   = "newest"    // Most recently published first
+	SortOldest   = "oldest"    // Earliest published first
+	SortSKU      = "sku"       // SKU A-Z
+)
+
+// SortOption is one entry of a sort dropdown.
+type SortOption struct {
+	Value string // ?sort= value
+	Label string // Display text
+}
+
+// ProductSortOptions lists the listing sort orders in dropdown order.
+var ProductSortOptions = []SortOption{
+	{SortNameAsc, "Name (A-Z)"},
+	{SortNameDesc, "Name (Z-A)"},
+	{SortNewest, "Newest First"},
+	{SortOldest, "Oldest First"},
+	{SortSKU, "SKU"},
+}
+
+// ValidProductSort reports whether sort is one of ProductSortOptions.
+func ValidProductSort(sort string) bool {
+	for _, o := range ProductSortOptions {
+		if o.Value == sort {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveProductSort returns the sort order to use: requested if valid,
+// else the site default, else SortNameAsc (the setting's own default).
+func ResolveProductSort(requested, siteDefault string) string {
+	if ValidProductSort(requested) {
+		return requested
+	}
+	if ValidProductSort(siteDefault) {
+		return siteDefault
+	}
+	return SortNameAsc
+}
@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 
 	"github.com/narendhupati/bluejay-cms/db/sqlc"
@@ -144,3 +145,59 @@ func TestGetProductDetail_EmptyRelated(t *testing.T) {
 
 // Ensure sql import is used (for nullable fields in CreateProductParams)
 var _ = sql.NullString{}
+
+func TestApplyVariant(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{
+		Name: "Drives", Slug: "drives", Description: "desc", Icon: "icon", SortOrder: 1,
+	})
+	prod, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+		Sku: "DRV-1", Slug: "drive-one", Name: "Drive One", Description: "d", CategoryID: cat.ID, Status: "published",
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: prod.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V", DisplayOrder: 1})
+	queries.CreateProductSpec(ctx, sqlc.CreateProductSpecParams{ProductID: prod.ID, SectionName: "Physical", SpecKey: "Weight", SpecValue: "2kg", DisplayOrder: 2})
+	queries.CreateProductImage(ctx, sqlc.CreateProductImageParams{ProductID: prod.ID, ImagePath: "/img/base.jpg"})
+	v, err := queries.CreateProductVariant(ctx, sqlc.CreateProductVariantParams{ProductID: prod.ID, Sku: "DRV-1-HV", Name: "High voltage"})
+	if err != nil {
+		t.Fatalf("CreateProductVariant: %v", err)
+	}
+	queries.UpsertProductVariantSpec(ctx, sqlc.UpsertProductVariantSpecParams{VariantID: v.ID, SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "230V"})
+	queries.UpsertProductVariantSpec(ctx, sqlc.UpsertProductVariantSpecParams{VariantID: v.ID, SectionName: "Electrical", SpecKey: "Phase", SpecValue: "Single"})
+
+	svc := services.NewProductService(queries)
+	detail, err := svc.GetProductDetail(ctx, "drive-one")
+	if err != nil {
+		t.Fatalf("GetProductDetail: %v", err)
+	}
+	if len(detail.Variants) != 1 {
+		t.Fatalf("expected 1 variant, got %d", len(detail.Variants))
+	}
+	if err := svc.ApplyVariant(ctx, detail, "DRV-1-HV"); err != nil {
+		t.Fatalf("ApplyVariant: %v", err)
+	}
+
+	if detail.Product.Sku != "DRV-1-HV" || detail.Variant == nil || detail.Variant.ID != v.ID {
+		t.Errorf("expected variant SKU and Variant set, got %q %+v", detail.Product.Sku, detail.Variant)
+	}
+	got := make([]string, 0, len(detail.Specs))
+	for _, s := range detail.Specs {
+		got = append(got, s.SpecKey+"="+s.SpecValue)
+	}
+	if want := "Voltage=230V Weight=2kg Phase=Single"; fmt.Sprint(got) != "["+want+"]" {
+		t.Errorf("specs = %v, want [%s]", got, want)
+	}
+	// Without variant images the product gallery is kept
+	if len(detail.Images) != 1 || detail.Images[0].ImagePath != "/img/base.jpg" {
+		t.Errorf("expected the product gallery, got %+v", detail.Images)
+	}
+
+	if err := svc.ApplyVariant(ctx, detail, "MISSING"); err != sql.ErrNoRows {
+		t.Errorf("unknown variant: expected sql.ErrNoRows, got %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	// Standard library imports
+	"context"       // Request cancellation for link lookups
+	"fmt"           // Failure details
+	"net/url"       // Link parsing
+	"os"            // Uploaded file lookups
+	"path/filepath" // Uploads directory paths
+	"strings"       // Path splitting and trimming
+
+	// Third-party imports
+	"golang.org/x/net/html" // Rich-text parsing for links and images
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Publish checklist item keys, in display order.
+const (
+	CheckMetaDescription = "meta_description"
+	CheckHeroImage       = "hero_image"
+	CheckCategory        = "category"
+	CheckLinks           = "links"
+	CheckAltText         = "alt_text"
+)
+
+// maxBrokenLinksShown bounds the links listed in a failed links check.
+const maxBrokenLinksShown = 5
+
+// PublishCandidate is what the publish checklist inspects: the values an
+// editor is about to save, not the stored row.
+type PublishCandidate struct {
+	MetaDescription string
+	HasHeroImage    bool   // False for content types without a hero image; the check is skipped
+	HeroImage       string // Hero image URL or upload path
+	CategoryLabel   string // e.g. "Industry"; empty for content types without a category
+	CategoryID      int64
+	HTML            []string // Rich-text fields, checked for broken links and missing alt text
+}
+
+// ChecklistItem is one line of the publish checklist.
+type ChecklistItem struct {
+	Key    string // One of the Check* constants
+	Label  string // e.g. "Meta description present"
+	OK     bool
+	Detail string // Why the check failed, e.g. the broken links
+}
+
+// PublishChecklist is the result of PublishChecker.Check.
+type PublishChecklist struct {
+	Items []ChecklistItem
+}
+
+// Passed reports whether every check passed.
+func (l PublishChecklist) Passed() bool {
+	return len(l.Failed()) == 0
+}
+
+// Failed returns the labels of the checks that failed.
+func (l PublishChecklist) Failed() []string {
+	var failed []string
+	for _, item := range l.Items {
+		if !item.OK {
+			failed = append(failed, item.Label)
+		}
+	}
+	return failed
+}
+
+// PublishChecker runs the checklist that solutions, case studies and
+// whitepapers must pass before they are published, and says which roles
+// may publish anyway.
+//
+// Links are checked without network access: links to product, blog,
+// solution, case study and whitepaper pages must point at published items,
+// and links to /uploads/ must point at files that exist. Links to other
+// sites and to other pages of this site are not checked.
+type PublishChecker struct {
+	queries       *sqlc.Queries   // Link target lookups
+	uploadsDir    string          // Directory served at /uploads
+	overrideRoles map[string]bool // Roles that may publish when checks fail
+}
+
+// NewPublishChecker creates a PublishChecker.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - uploadsDir: Directory served at /uploads (e.g., "public/uploads")
+//   - overrideRoles: Roles that may publish despite failed checks, with a reason
+//
+// Returns:
+//   - *PublishChecker: Checker ready for use
+func NewPublishChecker(queries *sqlc.Queries, uploadsDir string, overrideRoles []string) *PublishChecker {
+	roles := map[string]bool{}
+	for _, role := range overrideRoles {
+		if role = strings.TrimSpace(role); role != "" {
+			roles[role] = true
+		}
+	}
+	return &PublishChecker{queries: queries, uploadsDir: uploadsDir, overrideRoles: roles}
+}
+
+// CanOverride reports whether role may publish despite failed checks.
+func (p *PublishChecker) CanOverride(role string) bool {
+	return p.overrideRoles[role]
+}
+
+// Check runs the checklist against cand. Checks that do not apply to the
+// content type are left out.
+func (p *PublishChecker) Check(ctx context.Context, cand PublishCandidate) (PublishChecklist, error) {
+	var list PublishChecklist
+	add := func(key, label string, ok bool, detail string) {
+		if ok {
+			detail = ""
+		}
+		list.Items = append(list.Items, ChecklistItem{Key: key, Label: label, OK: ok, Detail: detail})
+	}
+
+	add(CheckMetaDescription, "Meta description present", strings.TrimSpace(cand.MetaDescription) != "",
+		"Add a meta description for search results and link previews.")
+	if cand.HasHeroImage {
+		add(CheckHeroImage, "Hero image set", strings.TrimSpace(cand.HeroImage) != "",
+			"Upload or link a hero image.")
+	}
+	if cand.CategoryLabel != "" {
+		add(CheckCategory, cand.CategoryLabel+" selected", cand.CategoryID > 0,
+			fmt.Sprintf("Choose a %s.", strings.ToLower(cand.CategoryLabel)))
+	}
+
+	links, missingAlt := scanRichText(cand.HTML)
+	if strings.TrimSpace(cand.HeroImage) != "" {
+		links = append(links, strings.TrimSpace(cand.HeroImage))
+	}
+	broken, err := p.brokenLinks(ctx, links)
+	if err != nil {
+		return PublishChecklist{}, err
+	}
+	add(CheckLinks, "No broken links", len(broken) == 0, brokenLinksDetail(broken))
+	add(CheckAltText, "Alt text on every image", missingAlt == 0,
+		fmt.Sprintf("%d image(s) in the content have no alt text.", missingAlt))
+	return list, nil
+}
+
+// scanRichText returns the link and image URLs in fragments and the number
+// of images without alt text.
+func scanRichText(fragments []string) ([]string, int) {
+	var links []string
+	missingAlt := 0
+	for _, fragment := range fragments {
+		if strings.TrimSpace(fragment) == "" {
+			continue
+		}
+		z := html.NewTokenizer(strings.NewReader(fragment))
+		for {
+			tt := z.Next()
+			if tt == html.ErrorToken {
+				break
+			}
+			if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+				continue
+			}
+			tok := z.Token()
+			switch tok.Data {
+			case "a":
+				if href := attr(tok, "href"); href != "" {
+					links = append(links, href)
+				}
+			case "img":
+				if src := attr(tok, "src"); src != "" {
+					links = append(links, src)
+				}
+				if strings.TrimSpace(attr(tok, "alt")) == "" {
+					missingAlt++
+				}
+			}
+		}
+	}
+	return links, missingAlt
+}
+
+// attr returns the value of the named attribute of tok, or "".
+func attr(tok html.Token, name string) string {
+	for _, a := range tok.Attr {
+		if a.Key == name {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// brokenLinks returns the links, without duplicates, that point at a missing
+// upload or at an item that is not published.
+func (p *PublishChecker) brokenLinks(ctx context.Context, links []string) ([]string, error) {
+	seen := map[string]bool{}
+	var broken []string
+	for _, link := range links {
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		ok, err := p.linkWorks(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			broken = append(broken, link)
+		}
+	}
+	return broken, nil
+}
+
+// linkWorks checks one link. Links it cannot check are reported as working.
+func (p *PublishChecker) linkWorks(ctx context.Context, link string) (bool, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false, nil
+	}
+	if u.Scheme != "" || u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+		return true, nil
+	}
+
+	if rel, ok := strings.CutPrefix(u.Path, "/uploads/"); ok {
+		clean := filepath.Clean(filepath.FromSlash(rel))
+		if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return false, nil
+		}
+		info, err := os.Stat(filepath.Join(p.uploadsDir, clean))
+		return err == nil && !info.IsDir(), nil
+	}
+
+	contentType, slug := linkTarget(u.Path)
+	if contentType == "" {
+		return true, nil
+	}
+	published, err := p.queries.LinkTargetPublished(ctx, sqlc.LinkTargetPublishedParams{ContentType: contentType, Slug: slug})
+	return published == 1, err
+}
+
+// linkTarget maps a public detail page path to its content type and slug,
+// e.g. "/solutions/smart-grid" to ("solution", "smart-grid"). Other paths
+// return "".
+func linkTarget(path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case segments[0] == "products" && (len(segments) == 3 || len(segments) == 4 && segments[3] == "print"):
+		return "product", segments[2]
+	case len(segments) != 2:
+		return "", ""
+	case segments[0] == "blog" && segments[1] != "tags":
+		return "blog_post", segments[1]
+	case segments[0] == "solutions":
+		return "solution", segments[1]
+	case segments[0] == "case-studies":
+		return "case_study", segments[1]
+	case segments[0] == "whitepapers" && segments[1] != "topics":
+		return "whitepaper", segments[1]
+	}
+	return "", ""
+}
+
+// brokenLinksDetail lists the first broken links.
+func brokenLinksDetail(broken []string) string {
+	if len(broken) <= maxBrokenLinksShown {
+		return "Fix or remove: " + strings.Join(broken, ", ")
+	}
+	return fmt.Sprintf("Fix or remove: %s and %d more", strings.Join(broken[:maxBrokenLinksShown], ", "), len(broken)-maxBrokenLinksShown)
+}
@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestPublishChecker_Check(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	uploads := t.TempDir()
+	os.MkdirAll(filepath.Join(uploads, "images"), 0o755)
+	os.WriteFile(filepath.Join(uploads, "images", "hero.jpg"), []byte("jpg"), 0o644)
+	queries.CreateSolution(ctx, sqlc.CreateSolutionParams{Title: "Live", Slug: "live", Icon: "i", ShortDescription: "s", IsPublished: sql.NullBool{Bool: true, Valid: true}})
+	queries.CreateSolution(ctx, sqlc.CreateSolutionParams{Title: "Draft", Slug: "draft", Icon: "i", ShortDescription: "s", IsPublished: sql.NullBool{Valid: true}})
+	checker := services.NewPublishChecker(queries, uploads, []string{"admin"})
+
+	list, err := checker.Check(ctx, services.PublishCandidate{
+		HasHeroImage:  true,
+		CategoryLabel: "Industry",
+		HTML: []string{`<p><a href="/solutions/live">ok</a> <a href="/solutions/draft">draft</a>
+			<a href="https://example.com/anything">external</a> <a href="/contact">contact</a></p>
+			<img src="/uploads/images/hero.jpg"><img src="/uploads/images/gone.jpg" alt="Gone">`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]services.ChecklistItem{}
+	for _, item := range list.Items {
+		got[item.Key] = item
+	}
+	for _, key := range []string{services.CheckMetaDescription, services.CheckHeroImage, services.CheckCategory, services.CheckLinks, services.CheckAltText} {
+		if item, ok := got[key]; !ok || item.OK {
+			t.Errorf("%s: got %+v, want a failed check", key, item)
+		}
+	}
+	if got[services.CheckCategory].Label != "Industry selected" {
+		t.Errorf("category label = %q", got[services.CheckCategory].Label)
+	}
+	links := got[services.CheckLinks].Detail
+	if !strings.Contains(links, "/solutions/draft") || !strings.Contains(links, "/uploads/images/gone.jpg") {
+		t.Errorf("links detail = %q, want the draft solution and the missing upload", links)
+	}
+	if strings.Contains(links, "/solutions/live") || strings.Contains(links, "hero.jpg") || strings.Contains(links, "example.com") {
+		t.Errorf("links detail = %q lists working or unchecked links", links)
+	}
+	if !strings.HasPrefix(got[services.CheckAltText].Detail, "1 image") {
+		t.Errorf("alt text detail = %q", got[services.CheckAltText].Detail)
+	}
+	if list.Passed() || len(list.Failed()) != 5 {
+		t.Errorf("failed = %v", list.Failed())
+	}
+
+	// A complete candidate passes; types without a hero image or category skip those checks
+	list, err = checker.Check(ctx, services.PublishCandidate{
+		MetaDescription: "Grid monitoring",
+		HasHeroImage:    true,
+		HeroImage:       "/uploads/images/hero.jpg",
+		CategoryLabel:   "Industry",
+		CategoryID:      1,
+		HTML:            []string{`<a href="/solutions/live">ok</a><img src="/uploads/images/hero.jpg" alt="Hero">`},
+	})
+	if err != nil || !list.Passed() {
+		t.Errorf("complete candidate: err %v, failed %v", err, list.Failed())
+	}
+	list, _ = checker.Check(ctx, services.PublishCandidate{MetaDescription: "m"})
+	if len(list.Items) != 3 || !list.Passed() {
+		t.Errorf("items = %+v, want meta description, links and alt text only", list.Items)
+	}
+}
+
+func TestPublishChecker_CanOverride(t *testing.T) {
+	checker := services.NewPublishChecker(nil, "", []string{" admin", "editor ", ""})
+	if !checker.CanOverride("admin") || !checker.CanOverride("editor") {
+		t.Error("listed roles should override")
+	}
+	if checker.CanOverride("viewer") || checker.CanOverride("") {
+		t.Error("unlisted roles should not override")
+	}
+}
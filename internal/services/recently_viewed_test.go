@@ -0,0 +1,46 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestRecentlyViewedCodec(t *testing.T) {
+	codec := services.NewRecentlyViewedCodec("secret")
+	value := codec.Encode([]int64{12, 7, 3})
+	if got := fmt.Sprint(codec.Decode(value)); got != "[12 7 3]" {
+		t.Errorf("round trip = %s", got)
+	}
+
+	for name, bad := range map[string]string{
+		"empty":          "",
+		"unsigned":       "12-7-3",
+		"tampered":       "12-7-4" + value[len("12-7-3"):],
+		"other secret":   services.NewRecentlyViewedCodec("other").Encode([]int64{1}),
+		"not numbers":    "a-b." + value[len("12-7-3."):],
+		"signature only": value[len("12-7-3"):],
+	} {
+		if ids := codec.Decode(bad); ids != nil {
+			t.Errorf("%s: decoded %v", name, ids)
+		}
+	}
+}
+
+func TestAddRecentlyViewed(t *testing.T) {
+	ids := []int64{5, 4, 3}
+	if got := fmt.Sprint(services.AddRecentlyViewed(ids, 3)); got != "[3 5 4]" {
+		t.Errorf("revisit = %s", got)
+	}
+	if fmt.Sprint(ids) != "[5 4 3]" {
+		t.Errorf("input modified: %v", ids)
+	}
+	var many []int64
+	for i := int64(1); i <= 20; i++ {
+		many = services.AddRecentlyViewed(many, i)
+	}
+	if len(many) != services.MaxRecentlyViewed || many[0] != 20 {
+		t.Errorf("capped list = %v", many)
+	}
+}
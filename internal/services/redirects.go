@@ -0,0 +1,215 @@
+package services
+
+import (
+	// Standard library imports
+	"context"  // Request cancellation for rule changes and hit counts
+	"errors"   // Rule validation errors
+	"log/slog" // Logging hit counts that could not be saved
+	"net/http" // Redirect status codes
+	"sort"     // Longest wildcard prefix first
+	"strings"  // Path prefix matching
+	"sync"     // Guards the rule snapshot, which changes when rules are edited
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Redirect rule validation errors returned by ValidateRedirect.
+var (
+	ErrRedirectSource   = errors.New("redirects: the source must be a site path starting with /")
+	ErrRedirectTarget   = errors.New("redirects: the target must be a path starting with / or an http(s) URL")
+	ErrRedirectWildcard = errors.New("redirects: * may only end the source, and the target only when the source ends in *")
+	ErrRedirectReserved = errors.New("redirects: admin and static file paths cannot be redirected")
+	ErrRedirectLoop     = errors.New("redirects: the target would redirect back to the source")
+	ErrRedirectStatus   = errors.New("redirects: the status must be 301 or 302")
+)
+
+// maxRedirectHops bounds how many chained rules Resolve follows.
+const maxRedirectHops = 5
+
+// reservedRedirectPrefixes are paths no rule may take over.
+var reservedRedirectPrefixes = []string{"/admin", "/public"}
+
+// redirectRule is one rule of the in-memory snapshot.
+type redirectRule struct {
+	id     int64  // redirects.id, for hit counts
+	source string // Exact path, or the prefix of a wildcard without its "*"
+	target string // Path or URL; a trailing "*" takes the matched rest
+	status int    // 301 or 302
+}
+
+// Redirects serves the rules of the redirects table from memory and records
+// automatic rules when slugs change. Every change goes through Reload, so
+// public requests never query the database to look for a rule.
+type Redirects struct {
+	queries *sqlc.Queries // Rule storage and hit counts
+	logger  *slog.Logger  // Failed hit counts
+
+	mu        sync.RWMutex            // Guards exact and wildcards
+	exact     map[string]redirectRule // Rules keyed by source path
+	wildcards []redirectRule          // Wildcard rules, longest prefix first
+}
+
+// NewRedirects creates the redirect service. Call Reload before serving
+// requests to load the stored rules.
+func NewRedirects(queries *sqlc.Queries, logger *slog.Logger) *Redirects {
+	return &Redirects{queries: queries, logger: logger, exact: map[string]redirectRule{}}
+}
+
+// Reload replaces the in-memory rules with the rows of the redirects table.
+func (r *Redirects) Reload(ctx context.Context) error {
+	rows, err := r.queries.ListRedirects(ctx)
+	if err != nil {
+		return err
+	}
+	exact := make(map[string]redirectRule, len(rows))
+	var wildcards []redirectRule
+	for _, row := range rows {
+		rule := redirectRule{id: row.ID, source: row.SourcePath, target: row.TargetPath, status: int(row.StatusCode)}
+		if prefix, ok := strings.CutSuffix(row.SourcePath, "*"); ok {
+			rule.source = prefix
+			wildcards = append(wildcards, rule)
+		} else {
+			exact[rule.source] = rule
+		}
+	}
+	sort.SliceStable(wildcards, func(i, j int) bool { return len(wildcards[i].source) > len(wildcards[j].source) })
+
+	r.mu.Lock()
+	r.exact, r.wildcards = exact, wildcards
+	r.mu.Unlock()
+	return nil
+}
+
+// Resolve returns where a request for path should be redirected. Exact
+// rules win over wildcards, and longer wildcard prefixes over shorter ones.
+// A trailing slash is ignored. When the target is itself redirected (a
+// renamed product in a since renamed category) the chain is followed, so
+// visitors get a single redirect. Each use is counted on the first rule.
+//
+// Parameters:
+//   - path: Request path, without locale prefix or query string
+//
+// Returns:
+//   - target: Path or absolute URL to redirect to
+//   - status: 301 or 302
+//   - ok: Whether a rule matched
+func (r *Redirects) Resolve(ctx context.Context, path string) (target string, status int, ok bool) {
+	r.mu.RLock()
+	rule, target, ok := r.match(path)
+	for hops := 1; ok && hops < maxRedirectHops; hops++ {
+		_, next, found := r.match(target)
+		if !found || next == path {
+			break
+		}
+		target = next
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return "", 0, false
+	}
+
+	if err := r.queries.RecordRedirectHit(ctx, rule.id); err != nil {
+		r.logger.Warn("failed to count redirect hit", "error", err, "id", rule.id)
+	}
+	return target, rule.status, true
+}
+
+// match finds the rule for path and the target it gives. The caller holds
+// r.mu.
+func (r *Redirects) match(path string) (redirectRule, string, bool) {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if rule, ok := r.exact[path]; ok {
+		return rule, rule.target, rule.target != path
+	}
+	for _, w := range r.wildcards {
+		rest, found := strings.CutPrefix(path, w.source)
+		if !found {
+			continue
+		}
+		target := w.target
+		if base, wild := strings.CutSuffix(w.target, "*"); wild {
+			// Browsers read "//host" and "/\host" as links to another site,
+			// so separators leading the rest collapse into one
+			if trimmed := strings.TrimLeft(rest, "/\\"); trimmed != rest {
+				rest = trimmed
+				if !strings.HasSuffix(base, "/") {
+					rest = "/" + rest
+				}
+			}
+			target = base + rest
+		}
+		return w, target, target != path
+	}
+	return redirectRule{}, "", false
+}
+
+// SlugChanged records that the page at oldPath moved to newPath: it adds a
+// permanent redirect from oldPath, points rules that led to oldPath at
+// newPath, and removes any rule for newPath, which is live again. Pass ""
+// as oldPath for new content so no older rule shadows it. Paths ending in
+// "*" move a whole section, e.g. the products of a renamed category.
+func (r *Redirects) SlugChanged(ctx context.Context, oldPath, newPath string) error {
+	if oldPath == newPath {
+		return nil
+	}
+	if err := r.queries.DeleteRedirectBySource(ctx, newPath); err != nil {
+		return err
+	}
+	if oldPath != "" {
+		if err := r.queries.RetargetRedirects(ctx, sqlc.RetargetRedirectsParams{NewTarget: newPath, OldTarget: oldPath}); err != nil {
+			return err
+		}
+		if err := r.queries.SaveAutomaticRedirect(ctx, sqlc.SaveAutomaticRedirectParams{SourcePath: oldPath, TargetPath: newPath}); err != nil {
+			return err
+		}
+	}
+	return r.Reload(ctx)
+}
+
+// ValidateRedirect checks a manual rule and returns its source and target
+// trimmed, with the trailing slash removed from the source.
+//
+// Sources are site paths (/old-page) or wildcards (/old-section/*). Targets
+// are site paths or http(s) URLs; a target ending in "*" receives the part
+// of the path matched by a wildcard source (/old/* -> /new/* sends /old/a
+// to /new/a).
+func ValidateRedirect(source, target string, status int) (string, string, error) {
+	source, target = strings.TrimSpace(source), strings.TrimSpace(target)
+	if !strings.HasPrefix(source, "/") || strings.HasPrefix(source, "//") || strings.ContainsAny(source, "?# ") {
+		return "", "", ErrRedirectSource
+	}
+	if len(source) > 1 {
+		source = strings.TrimSuffix(source, "/")
+	}
+	// Browsers read "//host" and "/\host" as links to another site
+	local := strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") && !strings.HasPrefix(target, "/\\")
+	if !local && !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "http://") || strings.ContainsAny(target, " ") {
+		return "", "", ErrRedirectTarget
+	}
+	prefix, wildcard := strings.CutSuffix(source, "*")
+	targetBase, targetWildcard := strings.CutSuffix(target, "*")
+	if strings.Contains(prefix, "*") || strings.Contains(targetBase, "*") || targetWildcard && !wildcard {
+		return "", "", ErrRedirectWildcard
+	}
+	for _, reserved := range reservedRedirectPrefixes {
+		if prefix == reserved || strings.HasPrefix(prefix, reserved+"/") {
+			return "", "", ErrRedirectReserved
+		}
+	}
+	if status != http.StatusMovedPermanently && status != http.StatusFound {
+		return "", "", ErrRedirectStatus
+	}
+	// Compare paths as Resolve matches them: without query or trailing slash
+	targetPath, _, _ := strings.Cut(targetBase, "?")
+	targetPath, _, _ = strings.Cut(targetPath, "#")
+	if len(targetPath) > 1 {
+		targetPath = strings.TrimSuffix(targetPath, "/")
+	}
+	if local && (targetPath == source || wildcard && strings.HasPrefix(targetBase, prefix)) {
+		return "", "", ErrRedirectLoop
+	}
+	return source, target, nil
+}
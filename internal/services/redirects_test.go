@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestRedirects_Resolve(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, r := range []sqlc.CreateRedirectParams{
+		{SourcePath: "/about-us", TargetPath: "/about", StatusCode: 301},
+		{SourcePath: "/docs/*", TargetPath: "/resources/*", StatusCode: 302},
+		{SourcePath: "/docs/legacy/*", TargetPath: "/archive", StatusCode: 301},
+		{SourcePath: "/docs/intro", TargetPath: "https://example.com/intro", StatusCode: 301},
+		{SourcePath: "/old/*", TargetPath: "/*", StatusCode: 301},
+	} {
+		if _, err := queries.CreateRedirect(ctx, r); err != nil {
+			t.Fatalf("create %s: %v", r.SourcePath, err)
+		}
+	}
+	redirects := services.NewRedirects(queries, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := redirects.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	cases := []struct {
+		path, target string
+		status       int
+	}{
+		{"/about-us", "/about", http.StatusMovedPermanently},
+		{"/about-us/", "/about", http.StatusMovedPermanently},
+		{"/docs/setup/wifi", "/resources/setup/wifi", http.StatusFound},
+		{"/docs/legacy/v1", "/archive", http.StatusMovedPermanently},
+		{"/docs/intro", "https://example.com/intro", http.StatusMovedPermanently},
+		{"/old/page", "/page", http.StatusMovedPermanently},
+		{"/old//evil.com", "/evil.com", http.StatusMovedPermanently},
+		{"/old/\\evil.com", "/evil.com", http.StatusMovedPermanently},
+		{"/old/\\/evil.com", "/evil.com", http.StatusMovedPermanently},
+		{"/docs", "", 0},
+		{"/about", "", 0},
+	}
+	for _, tc := range cases {
+		target, status, ok := redirects.Resolve(ctx, tc.path)
+		if target != tc.target || status != tc.status || ok != (tc.target != "") {
+			t.Errorf("Resolve(%q) = %q, %d, %v; want %q, %d", tc.path, target, status, ok, tc.target, tc.status)
+		}
+	}
+
+	rules, _ := queries.ListRedirects(ctx)
+	for _, r := range rules {
+		if r.SourcePath == "/about-us" && (r.Hits != 2 || !r.LastHitAt.Valid) {
+			t.Errorf("/about-us hits = %d, last hit valid = %v", r.Hits, r.LastHitAt.Valid)
+		}
+	}
+}
+
+func TestRedirects_SlugChanged(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	redirects := services.NewRedirects(queries, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	resolve := func(path string) string {
+		t.Helper()
+		target, _, _ := redirects.Resolve(ctx, path)
+		return target
+	}
+
+	// a -> b -> c leaves no chain behind
+	if err := redirects.SlugChanged(ctx, "/blog/a", "/blog/b"); err != nil {
+		t.Fatalf("SlugChanged: %v", err)
+	}
+	redirects.SlugChanged(ctx, "/blog/b", "/blog/c")
+	if got := resolve("/blog/a"); got != "/blog/c" {
+		t.Errorf("/blog/a -> %q, want /blog/c", got)
+	}
+	if got := resolve("/blog/b"); got != "/blog/c" {
+		t.Errorf("/blog/b -> %q, want /blog/c", got)
+	}
+
+	// Renaming back to an old slug makes that path live again
+	redirects.SlugChanged(ctx, "/blog/c", "/blog/a")
+	if got := resolve("/blog/a"); got != "" {
+		t.Errorf("/blog/a is live again but redirects to %q", got)
+	}
+	if got := resolve("/blog/c"); got != "/blog/a" {
+		t.Errorf("/blog/c -> %q, want /blog/a", got)
+	}
+
+	// New content clears a rule for its path
+	redirects.SlugChanged(ctx, "", "/blog/b")
+	if got := resolve("/blog/b"); got != "" {
+		t.Errorf("/blog/b belongs to new content but redirects to %q", got)
+	}
+
+	rules, _ := queries.ListRedirects(ctx)
+	for _, r := range rules {
+		if !r.IsAutomatic || r.StatusCode != 301 {
+			t.Errorf("rule %s should be an automatic 301", r.SourcePath)
+		}
+	}
+}
+
+func TestValidateRedirect(t *testing.T) {
+	cases := []struct {
+		source, target string
+		status         int
+		want           error
+		wantSource     string
+	}{
+		{" /old/ ", "/new", 301, nil, "/old"},
+		{"/old/*", "/new/*", 302, nil, "/old/*"},
+		{"/old", "https://example.com/x", 301, nil, "/old"},
+		{"/old/*", "/*", 301, nil, "/old/*"},
+		{"old", "/new", 301, services.ErrRedirectSource, ""},
+		{"//evil.com", "/new", 301, services.ErrRedirectSource, ""},
+		{"/old?x=1", "/new", 301, services.ErrRedirectSource, ""},
+		{"/old", "new", 301, services.ErrRedirectTarget, ""},
+		{"/old", "//evil.com", 301, services.ErrRedirectTarget, ""},
+		{"/old", "/\\evil.com", 301, services.ErrRedirectTarget, ""},
+		{"/old", "javascript:alert(1)", 301, services.ErrRedirectTarget, ""},
+		{"/old", "/new/*", 301, services.ErrRedirectWildcard, ""},
+		{"/o*ld", "/new", 301, services.ErrRedirectWildcard, ""},
+		{"/admin/products", "/new", 301, services.ErrRedirectReserved, ""},
+		{"/public/*", "/new", 301, services.ErrRedirectReserved, ""},
+		{"/old", "/new", 307, services.ErrRedirectStatus, ""},
+		{"/old", "/old", 301, services.ErrRedirectLoop, ""},
+		{"/old", "/old/?page=2", 301, services.ErrRedirectLoop, ""},
+		{"/old/*", "/old/new/*", 301, services.ErrRedirectLoop, ""},
+	}
+	for _, tc := range cases {
+		source, _, err := services.ValidateRedirect(tc.source, tc.target, tc.status)
+		if !errors.Is(err, tc.want) || source != tc.wantSource {
+			t.Errorf("ValidateRedirect(%q, %q, %d) = %q, %v; want %q, %v", tc.source, tc.target, tc.status, source, err, tc.wantSource, tc.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request-scoped cancellation for database calls
+	"fmt"     // Cache key formatting
+	"sort"    // Ranking candidates by pin order and score
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// relatedContentCachePrefix namespaces cached related content. It lives under
+// "page:products" so that saving a product, which already invalidates
+// "page:products*", also refreshes its recommendations and pins.
+const relatedContentCachePrefix = "page:products:related:"
+
+// relatedContentCandidates is how many candidates of each type are fetched
+// for scoring. Candidates arrive pins first, then by number of signals.
+const relatedContentCandidates = 30
+
+// Related content types, as stored in related_content_pins.content_type.
+const (
+	RelatedCaseStudy  = "case_study"
+	RelatedBlogPost   = "blog_post"
+	RelatedWhitepaper = "whitepaper"
+)
+
+// IsRelatedContentType reports whether t is one of the related content types.
+func IsRelatedContentType(t string) bool {
+	return t == RelatedCaseStudy || t == RelatedBlogPost || t == RelatedWhitepaper
+}
+
+// RelatedContentWeights controls how related content is scored for a product:
+//
+//	case study: Direct*featuresProduct + Category*sharesCategory + Industry*sharesIndustry
+//	blog post:  Direct*mentionsProduct + Category*sharesCategory + Tag*sharedTags
+//	whitepaper: Category*topicMatchesCategory + Tag*topicMatchesTag
+//
+// Every signal is 0 or 1 except sharedTags, the number of tags a post shares
+// with the posts that mention the product. Whitepapers are not linked to
+// products, so they only match through their topic.
+type RelatedContentWeights struct {
+	Direct   float64 // The item links the product itself
+	Industry float64 // Case study in the industry of one that features the product
+	Category float64 // The item links, or its topic is named like, the product's category
+	Tag      float64 // Per tag shared with posts about the product
+}
+
+// DefaultRelatedContentWeights puts content that names the product well ahead
+// of content that is only close to it by category, industry or tags.
+var DefaultRelatedContentWeights = RelatedContentWeights{Direct: 5, Industry: 2, Category: 2, Tag: 1}
+
+// RelatedContentItem is one recommended case study, blog post or whitepaper.
+type RelatedContentItem struct {
+	Type    string  // RelatedCaseStudy, RelatedBlogPost or RelatedWhitepaper
+	ID      int64   // ID in the content type's table
+	Title   string  // Display title
+	URL     string  // Public page path
+	Summary string  // Summary, excerpt or description
+	Label   string  // Industry, blog category or whitepaper topic
+	Image   string  // Hero or featured image, empty when there is none
+	Pinned  bool    // Chosen by an admin rather than by score
+	Score   float64 // Relevance score (pinned items keep theirs, often 0)
+}
+
+// RelatedContent is the related content of one product, by type.
+type RelatedContent struct {
+	CaseStudies []RelatedContentItem
+	BlogPosts   []RelatedContentItem
+	Whitepapers []RelatedContentItem
+}
+
+// Empty reports whether there is nothing to show.
+func (r RelatedContent) Empty() bool {
+	return len(r.CaseStudies) == 0 && len(r.BlogPosts) == 0 && len(r.Whitepapers) == 0
+}
+
+// RelatedContentService recommends case studies, blog posts and whitepapers
+// for product pages and caches the result per product.
+type RelatedContentService struct {
+	queries sqlc.Querier          // Database query interface for candidate lookup
+	cache   *Cache                // Cache for ranked results
+	weights RelatedContentWeights // Scoring formula weights
+	ttl     int                   // Cache TTL in seconds
+}
+
+// NewRelatedContentService creates a RelatedContentService using DefaultRelatedContentWeights.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - cache: Shared in-memory cache
+//
+// Returns:
+//   - *RelatedContentService: Initialized service
+func NewRelatedContentService(queries sqlc.Querier, cache *Cache) *RelatedContentService {
+	return &RelatedContentService{queries: queries, cache: cache, weights: DefaultRelatedContentWeights, ttl: 600}
+}
+
+// ScoreCaseStudy applies the weight formula to a case study candidate.
+func (w RelatedContentWeights) ScoreCaseStudy(c sqlc.ListRelatedCaseStudyCandidatesRow) float64 {
+	return w.Direct*float64(c.FeaturesProduct) + w.Category*float64(c.SharesCategory) + w.Industry*float64(c.SharesIndustry)
+}
+
+// ScoreBlogPost applies the weight formula to a blog post candidate.
+func (w RelatedContentWeights) ScoreBlogPost(c sqlc.ListRelatedBlogPostCandidatesRow) float64 {
+	return w.Direct*float64(c.MentionsProduct) + w.Category*float64(c.SharesCategory) + w.Tag*float64(c.SharedTags)
+}
+
+// ScoreWhitepaper applies the weight formula to a whitepaper candidate.
+func (w RelatedContentWeights) ScoreWhitepaper(c sqlc.ListRelatedWhitepaperCandidatesRow) float64 {
+	return w.Category*float64(c.TopicMatchesCategory) + w.Tag*float64(c.TopicMatchesTag)
+}
+
+// rankedContent is a candidate waiting to be ranked.
+type rankedContent struct {
+	item     RelatedContentItem
+	pinOrder int64
+}
+
+// rankRelatedContent orders candidates pins first (by pin order), then by
+// score, keeping the query order for ties, and keeps the first limit.
+func rankRelatedContent(candidates []rankedContent, limit int) []RelatedContentItem {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.item.Pinned != b.item.Pinned {
+			return a.item.Pinned
+		}
+		if a.item.Pinned {
+			return a.pinOrder < b.pinOrder
+		}
+		return a.item.Score > b.item.Score
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	items := make([]RelatedContentItem, len(candidates))
+	for i, c := range candidates {
+		items[i] = c.item
+	}
+	return items
+}
+
+// ForProduct returns up to limit case studies, blog posts and whitepapers
+// related to a product. Admin pins come first in pin order; the remaining
+// slots go to the highest scored published content. Results are cached for
+// ten minutes.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - productID: Product being viewed
+//   - categoryID: The product's category
+//   - limit: Maximum number of items of each type
+//
+// Returns:
+//   - RelatedContent: Ranked items by type (empty when nothing matches)
+//   - error: Database error
+func (s *RelatedContentService) ForProduct(ctx context.Context, productID, categoryID int64, limit int) (RelatedContent, error) {
+	cacheKey := fmt.Sprintf("%s%d:%d", relatedContentCachePrefix, productID, limit)
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		return cached.(RelatedContent), nil
+	}
+
+	var related RelatedContent
+
+	caseStudies, err := s.queries.ListRelatedCaseStudyCandidates(ctx, sqlc.ListRelatedCaseStudyCandidatesParams{
+		ProductID: productID, CategoryID: categoryID, CandidateLimit: relatedContentCandidates,
+	})
+	if err != nil {
+		return RelatedContent{}, err
+	}
+	candidates := make([]rankedContent, len(caseStudies))
+	for i, cs := range caseStudies {
+		candidates[i] = rankedContent{pinOrder: cs.PinOrder, item: RelatedContentItem{
+			Type: RelatedCaseStudy, ID: cs.ID, Title: cs.Title, URL: "/case-studies/" + cs.Slug,
+			Summary: cs.Summary, Label: cs.IndustryName, Image: cs.HeroImageUrl.String,
+			Pinned: cs.Pinned == 1, Score: s.weights.ScoreCaseStudy(cs),
+		}}
+	}
+	related.CaseStudies = rankRelatedContent(candidates, limit)
+
+	posts, err := s.queries.ListRelatedBlogPostCandidates(ctx, sqlc.ListRelatedBlogPostCandidatesParams{
+		ProductID: productID, CategoryID: categoryID, CandidateLimit: relatedContentCandidates,
+	})
+	if err != nil {
+		return RelatedContent{}, err
+	}
+	candidates = make([]rankedContent, len(posts))
+	for i, p := range posts {
+		candidates[i] = rankedContent{pinOrder: p.PinOrder, item: RelatedContentItem{
+			Type: RelatedBlogPost, ID: p.ID, Title: p.Title, URL: "/blog/" + p.Slug,
+			Summary: p.Excerpt, Label: p.CategoryName, Image: p.FeaturedImageUrl.String,
+			Pinned: p.Pinned == 1, Score: s.weights.ScoreBlogPost(p),
+		}}
+	}
+	related.BlogPosts = rankRelatedContent(candidates, limit)
+
+	whitepapers, err := s.queries.ListRelatedWhitepaperCandidates(ctx, sqlc.ListRelatedWhitepaperCandidatesParams{
+		ProductID: productID, CategoryID: categoryID, CandidateLimit: relatedContentCandidates,
+	})
+	if err != nil {
+		return RelatedContent{}, err
+	}
+	candidates = make([]rankedContent, len(whitepapers))
+	for i, wp := range whitepapers {
+		candidates[i] = rankedContent{pinOrder: wp.PinOrder, item: RelatedContentItem{
+			Type: RelatedWhitepaper, ID: wp.ID, Title: wp.Title, URL: "/whitepapers/" + wp.Slug,
+			Summary: wp.Description, Label: wp.TopicName,
+			Pinned: wp.Pinned == 1, Score: s.weights.ScoreWhitepaper(wp),
+		}}
+	}
+	related.Whitepapers = rankRelatedContent(candidates, limit)
+
+	s.cache.Set(cacheKey, related, s.ttl)
+	return related, nil
+}
@@ -0,0 +1,155 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestRelatedContent_PinsFirstThenScored(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	sensors, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Sensors", Slug: "sensors", Description: "d", Icon: "i"})
+	meters, _ := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Meters", Slug: "meters", Description: "d", Icon: "i"})
+	product := func(slug string, cat sqlc.ProductCategory) int64 {
+		p, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{Sku: slug, Slug: slug, Name: slug, Description: "d", CategoryID: cat.ID, Status: "published"})
+		if err != nil {
+			t.Fatalf("create product %s: %v", slug, err)
+		}
+		return p.ID
+	}
+	current := product("current", sensors)
+	sibling := product("sibling", sensors)
+	other := product("other", meters)
+
+	// Case studies
+	energy, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Energy", Slug: "energy", Icon: "bolt", Description: "d"})
+	mining, _ := queries.CreateIndustry(ctx, sqlc.CreateIndustryParams{Name: "Mining", Slug: "mining", Icon: "terrain", Description: "d"})
+	caseStudy := func(slug string, industry sqlc.Industry, published int64, products ...int64) int64 {
+		cs, err := queries.AdminCreateCaseStudy(ctx, sqlc.AdminCreateCaseStudyParams{
+			Slug: slug, Title: slug, ClientName: "Client", IndustryID: industry.ID, Summary: "s",
+			ChallengeContent: "c", SolutionContent: "s", OutcomeContent: "o", IsPublished: published,
+		})
+		if err != nil {
+			t.Fatalf("create case study %s: %v", slug, err)
+		}
+		for _, id := range products {
+			queries.AdminAddCaseStudyProduct(ctx, sqlc.AdminAddCaseStudyProductParams{CaseStudyID: cs.ID, ProductID: id})
+		}
+		return cs.ID
+	}
+	csDirect := caseStudy("direct", energy, 1, current)     // 5 + industry 2
+	csCategory := caseStudy("category", energy, 1, sibling) // category 2 + industry 2
+	csIndustry := caseStudy("industry", energy, 1, other)   // industry 2
+	csPinned := caseStudy("pinned", mining, 1, other)       // no signal, pinned
+	caseStudy("unrelated", mining, 1, other)
+	caseStudy("draft", energy, 0, current)
+
+	// Blog posts
+	blogCat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "A", Slug: "a", Title: "Writer"})
+	edge, _ := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Edge", Slug: "edge"})
+	post := func(slug string, tagged bool, products ...int64) int64 {
+		p, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: slug, Slug: slug, Body: "body", Excerpt: "excerpt", CategoryID: blogCat.ID, AuthorID: author.ID,
+			Status: "published", PublishedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+		if tagged {
+			queries.AddTagToPost(ctx, sqlc.AddTagToPostParams{BlogPostID: p.ID, BlogTagID: edge.ID})
+		}
+		for _, id := range products {
+			queries.AddProductToPost(ctx, sqlc.AddProductToPostParams{BlogPostID: p.ID, ProductID: id})
+		}
+		return p.ID
+	}
+	postDirect := post("mentions-product", true, current)    // 5
+	postCategory := post("mentions-sibling", false, sibling) // 2
+	postTag := post("shares-tag", true)                      // 1
+	post("unrelated-post", false, other)
+
+	// Whitepapers match through their topic slug
+	topic := func(slug string) sqlc.WhitepaperTopic {
+		tp, _ := queries.CreateWhitepaperTopic(ctx, sqlc.CreateWhitepaperTopicParams{Name: slug, Slug: slug, ColorHex: "#000000", Icon: "i"})
+		return tp
+	}
+	whitepaper := func(slug string, tp sqlc.WhitepaperTopic) int64 {
+		wp, err := queries.CreateWhitepaper(ctx, sqlc.CreateWhitepaperParams{
+			Title: slug, Slug: slug, Description: "d", TopicID: tp.ID, PdfFilePath: "/f.pdf",
+			FileSizeBytes: 1, PublishedDate: "2026-01-01", IsPublished: 1, CoverColorFrom: "#111111", CoverColorTo: "#222222",
+		})
+		if err != nil {
+			t.Fatalf("create whitepaper %s: %v", slug, err)
+		}
+		return wp.ID
+	}
+	wpTag := whitepaper("edge-paper", topic("edge"))           // 1
+	wpCategory := whitepaper("sensor-paper", topic("sensors")) // 2
+	whitepaper("other-paper", topic("other"))
+
+	if err := queries.CreateRelatedContentPin(ctx, sqlc.CreateRelatedContentPinParams{
+		ProductID: current, ContentType: services.RelatedCaseStudy, ContentID: csPinned,
+	}); err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+
+	cache := services.NewCache()
+	svc := services.NewRelatedContentService(queries, cache)
+	related, err := svc.ForProduct(ctx, current, sensors.ID, 10)
+	if err != nil {
+		t.Fatalf("ForProduct: %v", err)
+	}
+
+	assertOrder := func(kind string, got []services.RelatedContentItem, want ...int64) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %d items, got %+v", kind, len(want), got)
+		}
+		for i, id := range want {
+			if got[i].ID != id {
+				t.Errorf("%s position %d: expected %d, got %d (%s, score %.1f)", kind, i, id, got[i].ID, got[i].Title, got[i].Score)
+			}
+		}
+	}
+	assertOrder("case studies", related.CaseStudies, csPinned, csDirect, csCategory, csIndustry)
+	assertOrder("blog posts", related.BlogPosts, postDirect, postCategory, postTag)
+	assertOrder("whitepapers", related.Whitepapers, wpCategory, wpTag)
+	if !related.CaseStudies[0].Pinned || related.CaseStudies[1].Pinned {
+		t.Error("only the pinned case study should be marked as pinned")
+	}
+	if related.CaseStudies[1].URL != "/case-studies/direct" || related.BlogPosts[0].URL != "/blog/mentions-product" {
+		t.Errorf("unexpected URLs: %q, %q", related.CaseStudies[1].URL, related.BlogPosts[0].URL)
+	}
+
+	// The limit applies per type, after the pins
+	limited, _ := svc.ForProduct(ctx, current, sensors.ID, 2)
+	assertOrder("limited case studies", limited.CaseStudies, csPinned, csDirect)
+
+	// Results are cached until the "page:products" prefix is cleared
+	queries.CreateRelatedContentPin(ctx, sqlc.CreateRelatedContentPinParams{ProductID: current, ContentType: services.RelatedWhitepaper, ContentID: wpTag})
+	related, _ = svc.ForProduct(ctx, current, sensors.ID, 10)
+	if related.Whitepapers[0].ID != wpCategory {
+		t.Error("expected the cached result to be reused")
+	}
+	cache.DeleteByPrefix("page:products")
+	related, _ = svc.ForProduct(ctx, current, sensors.ID, 10)
+	assertOrder("whitepapers after pin", related.Whitepapers, wpTag, wpCategory)
+
+	// Permanently deleting pinned content removes its pin
+	if err := queries.AdminDeleteCaseStudy(ctx, csPinned); err != nil {
+		t.Fatalf("delete case study: %v", err)
+	}
+	pins, _ := queries.ListRelatedContentPins(ctx, current)
+	if len(pins) != 1 || pins[0].ContentType != services.RelatedWhitepaper {
+		t.Errorf("expected only the whitepaper pin to remain, got %+v", pins)
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request-scoped cancellation for database calls
+	"fmt"     // Cache key formatting
+	"math"    // Exponential recency decay
+	"sort"    // Ranking candidates by score
+	"time"    // Post age for the recency term
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// relatedPostsCachePrefix namespaces cached related-post lists. It lives under
+// "page:blog" so that every admin blog change, which already invalidates
+// "page:blog*", also refreshes related posts.
+const relatedPostsCachePrefix = "page:blog:related:"
+
+// relatedPostsCandidates is how many candidates are fetched for scoring.
+// Candidates arrive ordered by shared tags, so the strongest matches are kept.
+const relatedPostsCandidates = 50
+
+// RelatedPostsWeights controls the related posts scoring formula:
+//
+//	score = Tag*sharedTags + Category*sameCategory + Recency*0.5^(ageDays/HalfLifeDays)
+//
+// sameCategory is 1 when the candidate is in the current post's category and 0
+// otherwise. The recency term lets newer posts win ties without overriding
+// topical relevance.
+type RelatedPostsWeights struct {
+	Tag          float64 // Weight per tag shared with the current post
+	Category     float64 // Weight for sharing the current post's category
+	Recency      float64 // Maximum bonus for a post published today
+	HalfLifeDays float64 // Age in days at which the recency bonus halves
+}
+
+// DefaultRelatedPostsWeights favours shared tags over category, so two posts
+// tagged alike across categories outrank a same-category post with no tags in common.
+var DefaultRelatedPostsWeights = RelatedPostsWeights{Tag: 3, Category: 2, Recency: 1, HalfLifeDays: 180}
+
+// RelatedPost is a candidate post with its computed relevance score.
+type RelatedPost struct {
+	sqlc.ListRelatedPostCandidatesRow
+	Score float64
+}
+
+// RelatedPostsService computes "related posts" for blog articles by shared tags
+// and category, and caches the ranked result per post.
+type RelatedPostsService struct {
+	queries sqlc.Querier        // Database query interface for candidate lookup
+	cache   *Cache              // Cache for ranked results
+	weights RelatedPostsWeights // Scoring formula weights
+	ttl     int                 // Cache TTL in seconds
+}
+
+// NewRelatedPostsService creates a RelatedPostsService using DefaultRelatedPostsWeights.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - cache: Shared in-memory cache
+//
+// Returns:
+//   - *RelatedPostsService: Initialized service
+func NewRelatedPostsService(queries sqlc.Querier, cache *Cache) *RelatedPostsService {
+	return &RelatedPostsService{queries: queries, cache: cache, weights: DefaultRelatedPostsWeights, ttl: 600}
+}
+
+// Score applies the weight formula to a candidate.
+//
+// Parameters:
+//   - candidate: Candidate row with match signals
+//   - categoryID: Category of the current post
+//   - now: Reference time for the recency term
+//
+// Returns:
+//   - float64: Relevance score (higher is more related)
+func (w RelatedPostsWeights) Score(candidate sqlc.ListRelatedPostCandidatesRow, categoryID int64, now time.Time) float64 {
+	score := w.Tag * float64(candidate.SharedTags)
+	if candidate.CategoryID == categoryID {
+		score += w.Category
+	}
+	if candidate.PublishedAt.Valid && w.HalfLifeDays > 0 {
+		ageDays := math.Max(0, now.Sub(candidate.PublishedAt.Time).Hours()/24)
+		score += w.Recency * math.Pow(0.5, ageDays/w.HalfLifeDays)
+	}
+	return score
+}
+
+// Related returns up to limit published posts most related to a post, ranked
+// by score and then by publish date. Results are cached for ten minutes.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - postID: Current post (never included in the results)
+//   - categoryID: Current post's category
+//   - limit: Maximum number of posts to return
+//
+// Returns:
+//   - []RelatedPost: Ranked related posts (empty when nothing matches)
+//   - error: Database error
+func (s *RelatedPostsService) Related(ctx context.Context, postID, categoryID int64, limit int) ([]RelatedPost, error) {
+	cacheKey := fmt.Sprintf("%s%d:%d", relatedPostsCachePrefix, postID, limit)
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		return cached.([]RelatedPost), nil
+	}
+
+	candidates, err := s.queries.ListRelatedPostCandidates(ctx, sqlc.ListRelatedPostCandidatesParams{
+		PostID:         postID,
+		CategoryID:     categoryID,
+		CandidateLimit: relatedPostsCandidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ranked := make([]RelatedPost, len(candidates))
+	for i, c := range candidates {
+		ranked[i] = RelatedPost{ListRelatedPostCandidatesRow: c, Score: s.weights.Score(c, categoryID, now)}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].PublishedAt.Time.After(ranked[j].PublishedAt.Time)
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	s.cache.Set(cacheKey, ranked, s.ttl)
+	return ranked, nil
+}
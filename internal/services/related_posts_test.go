@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestRelatedPosts_RanksBySharedTagsThenCategory(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	news, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000"})
+	guides, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "Guides", Slug: "guides", ColorHex: "#000000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "A", Slug: "a", Title: "Writer"})
+
+	now := time.Now()
+	post := func(slug string, cat sqlc.BlogCategory, age time.Duration, status string) int64 {
+		p, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: slug, Slug: slug, Body: "body", Excerpt: "excerpt",
+			CategoryID: cat.ID, AuthorID: author.ID, Status: status,
+			PublishedAt: sql.NullTime{Time: now.Add(-age), Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+		return p.ID
+	}
+	tag := func(slug string, postIDs ...int64) {
+		tg, err := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: slug, Slug: slug})
+		if err != nil {
+			t.Fatalf("create tag: %v", err)
+		}
+		for _, id := range postIDs {
+			queries.AddTagToPost(ctx, sqlc.AddTagToPostParams{BlogPostID: id, BlogTagID: tg.ID})
+		}
+	}
+
+	current := post("current", news, 0, "published")
+	twoTags := post("two-tags-other-category", guides, 300*24*time.Hour, "published")
+	oneTagSameCat := post("one-tag-same-category", news, 48*time.Hour, "published")
+	sameCatOld := post("same-category-old", news, 400*24*time.Hour, "published")
+	sameCatNew := post("same-category-new", news, time.Hour, "published")
+	unrelated := post("unrelated", guides, time.Hour, "published")
+	draft := post("draft", news, time.Hour, "draft")
+	tag("sensors", current, twoTags, oneTagSameCat, draft)
+	tag("radiation", current, twoTags)
+	tag("misc", unrelated)
+
+	svc := services.NewRelatedPostsService(queries, services.NewCache())
+	related, err := svc.Related(ctx, current, news.ID, 10)
+	if err != nil {
+		t.Fatalf("Related: %v", err)
+	}
+
+	// Two shared tags (6) beat one tag + category (3+2), which beats category alone (2);
+	// among same-category posts the recency term puts the newer one first
+	want := []int64{twoTags, oneTagSameCat, sameCatNew, sameCatOld}
+	if len(related) != len(want) {
+		t.Fatalf("expected %d related posts, got %d", len(want), len(related))
+	}
+	for i, id := range want {
+		if related[i].ID != id {
+			t.Errorf("position %d: expected post %d, got %d (%s, score %.2f)", i, id, related[i].ID, related[i].Slug, related[i].Score)
+		}
+	}
+	for _, r := range related {
+		if r.ID == current || r.ID == unrelated || r.ID == draft {
+			t.Errorf("unexpected post %q in related posts", r.Slug)
+		}
+	}
+
+	// Results are cached: a new shared tag is not visible until invalidation
+	tag("extra", current, sameCatOld)
+	related, _ = svc.Related(ctx, current, news.ID, 10)
+	if related[3].ID != sameCatOld {
+		t.Errorf("expected cached ranking to be reused")
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Job cancellation and hook timeouts
+	"database/sql" // Primary database handle being checkpointed
+	"fmt"          // Hook environment and error formatting
+	"log/slog"     // Structured logging of checkpoints and hook failures
+	"os"           // Hook environment
+	"os/exec"      // Running the exec-on-change hook
+	"strings"      // Checkpoint mode validation
+	"sync"         // Serializes Sync between the ticker and manual calls
+	"time"         // Sync interval and hook timeout
+)
+
+// ReplicationConfig controls the replication hooks. The zero value checks for
+// writes every 10 seconds and runs PASSIVE checkpoints without a hook.
+type ReplicationConfig struct {
+	// Interval between checks for committed writes. Defaults to 10 seconds.
+	Interval time.Duration
+	// CheckpointMode is the wal_checkpoint mode: PASSIVE (default), FULL,
+	// RESTART or TRUNCATE. PASSIVE never waits for readers; TRUNCATE also
+	// resets the WAL file to zero bytes, which suits file-level snapshots.
+	CheckpointMode string
+	// Command, when set, runs through "sh -c" after every checkpoint that
+	// followed writes, e.g. to push a snapshot or poke a replicator. It
+	// receives BLUEJAY_DB_PATH, BLUEJAY_CHECKPOINT_MODE, BLUEJAY_WAL_FRAMES
+	// and BLUEJAY_CHECKPOINTED_FRAMES in its environment.
+	Command string
+	// CommandTimeout bounds one run of Command. Defaults to one minute.
+	CommandTimeout time.Duration
+}
+
+// CheckpointResult is the outcome of PRAGMA wal_checkpoint.
+type CheckpointResult struct {
+	Busy         bool  // The checkpoint could not finish because of readers or writers
+	WALFrames    int64 // Frames in the WAL file
+	Checkpointed int64 // Frames copied back into the database file
+}
+
+// Replication checkpoints the WAL when the application has written since the
+// last check, and then runs an optional exec-on-change hook. It supports
+// continuous replication setups: Litestream (with
+// database.Config.DisableAutoCheckpoint the checkpoints happen here, on a
+// predictable schedule, rather than inside whichever request crosses the
+// autocheckpoint threshold) and simpler snapshot scripts that ship the
+// database file after each change.
+//
+// Writes are detected with total_changes(), which counts the row changes of
+// the primary's single connection (see database.InitDB), and schema_version,
+// which moves on DDL such as migrations. Checks on an idle site cost one
+// query and do not run the hook.
+type Replication struct {
+	db     *sql.DB           // Primary database (single connection pool)
+	path   string            // Database file, passed to the hook
+	logger *slog.Logger      // Checkpoint and hook logging
+	config ReplicationConfig // Interval, checkpoint mode, and hook
+
+	mu   sync.Mutex
+	last writeMarker // Write counters at the last Sync
+}
+
+// writeMarker identifies the database state reached by the primary's writes.
+type writeMarker struct {
+	changes int64 // total_changes() of the primary connection
+	schema  int64 // PRAGMA schema_version
+}
+
+// writeMarkerQuery reads a writeMarker.
+const writeMarkerQuery = "SELECT total_changes(), (SELECT schema_version FROM pragma_schema_version)"
+
+// NewReplication creates the replication job. Zero config values use the
+// documented defaults; an unknown CheckpointMode falls back to PASSIVE.
+//
+// Parameters:
+//   - db: Primary database handle from database.InitDB
+//   - path: Database file path, passed to the hook as BLUEJAY_DB_PATH
+//   - logger: Structured logger
+//   - config: Interval, checkpoint mode, and exec-on-change hook
+//
+// Returns:
+//   - *Replication: Job ready to run or schedule
+func NewReplication(db *sql.DB, path string, logger *slog.Logger, config ReplicationConfig) *Replication {
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+	switch mode := strings.ToUpper(config.CheckpointMode); mode {
+	case "PASSIVE", "FULL", "RESTART", "TRUNCATE":
+		config.CheckpointMode = mode
+	default:
+		config.CheckpointMode = "PASSIVE"
+	}
+	if config.CommandTimeout <= 0 {
+		config.CommandTimeout = time.Minute
+	}
+	return &Replication{db: db, path: path, logger: logger, config: config}
+}
+
+// Start runs Sync every Interval in a background goroutine until ctx is
+// cancelled.
+//
+// Parameters:
+//   - ctx: Controls the lifetime of the background job
+func (r *Replication) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, _, err := r.Sync(ctx); err != nil {
+					r.logger.Error("replication sync failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Sync checkpoints the WAL and runs the hook if anything was written since
+// the previous Sync. A busy checkpoint still runs the hook, since the WAL
+// holds the committed changes either way.
+//
+// Parameters:
+//   - ctx: Context for the checkpoint and hook
+//
+// Returns:
+//   - bool: Whether there were writes (and so a checkpoint) since the last Sync
+//   - CheckpointResult: Checkpoint outcome (zero when there were no writes)
+//   - error: Database or hook error
+func (r *Replication) Sync(ctx context.Context) (bool, CheckpointResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var marker writeMarker
+	if err := r.db.QueryRowContext(ctx, writeMarkerQuery).Scan(&marker.changes, &marker.schema); err != nil {
+		return false, CheckpointResult{}, err
+	}
+	// A different count, not only a higher one: a reopened connection restarts at 0
+	if marker == r.last {
+		return false, CheckpointResult{}, nil
+	}
+
+	var res CheckpointResult
+	var busy int64
+	if err := r.db.QueryRowContext(ctx, "PRAGMA wal_checkpoint("+r.config.CheckpointMode+")").Scan(&busy, &res.WALFrames, &res.Checkpointed); err != nil {
+		return true, CheckpointResult{}, err
+	}
+	res.Busy = busy != 0
+	r.last = marker
+
+	if r.config.Command != "" {
+		if err := r.runHook(ctx, res); err != nil {
+			return true, res, err
+		}
+	}
+	return true, res, nil
+}
+
+// runHook runs the exec-on-change command with the checkpoint in its environment.
+func (r *Replication) runHook(ctx context.Context, res CheckpointResult) error {
+	ctx, cancel := context.WithTimeout(ctx, r.config.CommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", r.config.Command)
+	cmd.Env = append(os.Environ(),
+		"BLUEJAY_DB_PATH="+r.path,
+		"BLUEJAY_CHECKPOINT_MODE="+r.config.CheckpointMode,
+		fmt.Sprintf("BLUEJAY_WAL_FRAMES=%d", res.WALFrames),
+		fmt.Sprintf("BLUEJAY_CHECKPOINTED_FRAMES=%d", res.Checkpointed),
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("replication hook: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	r.logger.Info("replication hook ran", "wal_frames", res.WALFrames, "checkpointed", res.Checkpointed)
+	return nil
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/database"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestReplication_SyncRunsHookAfterWrites(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "primary.db")
+	db, err := database.InitDB(database.Config{Path: dbPath, DisableAutoCheckpoint: true})
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	defer database.Close(db)
+	ctx := context.Background()
+
+	out := filepath.Join(t.TempDir(), "hook.log")
+	repl := services.NewReplication(db, dbPath, slog.New(slog.NewTextHandler(io.Discard, nil)), services.ReplicationConfig{
+		CheckpointMode: "truncate",
+		Command:        `echo "$BLUEJAY_DB_PATH $BLUEJAY_CHECKPOINT_MODE" >> ` + out,
+	})
+
+	if _, err := db.Exec("CREATE TABLE t (id INTEGER); INSERT INTO t (id) VALUES (1)"); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	changed, res, err := repl.Sync(ctx)
+	if err != nil || !changed {
+		t.Fatalf("first sync: changed=%v err=%v", changed, err)
+	}
+	if res.Busy || res.WALFrames != 0 {
+		t.Errorf("TRUNCATE checkpoint should empty the WAL, got %+v", res)
+	}
+
+	// Nothing written since: no checkpoint and no hook
+	if changed, _, err := repl.Sync(ctx); err != nil || changed {
+		t.Errorf("idle sync: changed=%v err=%v", changed, err)
+	}
+
+	db.Exec("INSERT INTO t (id) VALUES (2)")
+	if changed, _, err := repl.Sync(ctx); err != nil || !changed {
+		t.Errorf("sync after write: changed=%v err=%v", changed, err)
+	}
+
+	log, _ := os.ReadFile(out)
+	lines := strings.Split(strings.TrimSpace(string(log)), "\n")
+	if len(lines) != 2 || lines[0] != dbPath+" TRUNCATE" {
+		t.Errorf("hook runs = %q, want two runs with the database path and mode", lines)
+	}
+}
+
+func TestReplication_HookFailure(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "primary.db")
+	db, err := database.InitDB(database.Config{Path: dbPath})
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	defer database.Close(db)
+
+	repl := services.NewReplication(db, dbPath, slog.New(slog.NewTextHandler(io.Discard, nil)), services.ReplicationConfig{
+		Command: "echo upload failed >&2; exit 3",
+	})
+	db.Exec("CREATE TABLE t (id INTEGER)")
+	if _, _, err := repl.Sync(context.Background()); err == nil || !strings.Contains(err.Error(), "upload failed") {
+		t.Errorf("expected the hook's output in the error, got %v", err)
+	}
+}
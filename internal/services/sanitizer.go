@@ -0,0 +1,99 @@
+package services
+
+import (
+	// Standard library imports
+	"regexp"  // Iframe source allowlist and element:attribute parsing
+	"strings" // Normalizing allowlist entries
+
+	// Third-party HTML sanitizer
+	"github.com/microcosm-cc/bluemonday" // Allowlist-based HTML sanitization
+)
+
+// SanitizerConfig extends the built-in rich-text allowlist. The zero value
+// allows exactly what the Trix editor and the Markdown renderer produce.
+type SanitizerConfig struct {
+	// AllowElements lists extra elements to keep (e.g. "mark", "abbr").
+	AllowElements []string
+	// AllowAttributes lists extra attributes as "element:attribute" pairs,
+	// or "*:attribute" for every element (e.g. "abbr:title", "*:style").
+	AllowAttributes []string
+	// IframeHosts lists hosts whose https embeds are kept as <iframe>
+	// (e.g. "www.youtube.com", "player.vimeo.com"). Iframes are removed when empty.
+	IframeHosts []string
+}
+
+// HTMLSanitizer cleans rich-text HTML submitted from the admin editors before
+// it is stored. Stored content is rendered with safeHTML on public pages, so
+// this is the defence against stored XSS: scripts, event handlers, style
+// attributes, forms, and javascript: URLs are removed while formatting,
+// links, images, tables, and Trix attachments are kept.
+type HTMLSanitizer struct {
+	policy *bluemonday.Policy // Compiled allowlist; safe for concurrent use
+}
+
+// NewHTMLSanitizer builds a sanitizer from the default rich-text allowlist
+// plus any extra elements, attributes, and iframe hosts in config.
+//
+// Parameters:
+//   - config: Allowlist extensions (zero value for the defaults)
+//
+// Returns:
+//   - *HTMLSanitizer: Sanitizer ready for use by handlers
+func NewHTMLSanitizer(config SanitizerConfig) *HTMLSanitizer {
+	// UGCPolicy covers user-generated formatting: headings, lists, links
+	// (with rel="nofollow"), images, tables, code, and quotes
+	p := bluemonday.UGCPolicy()
+
+	// Trix markup: attachment figures carry their metadata in data-trix-*
+	// attributes and layout in class names
+	p.AllowDataAttributes()
+	p.AllowAttrs("class").OnElements("figure", "figcaption", "span", "div", "pre", "code", "img", "a")
+	p.AllowElements("figure", "figcaption")
+
+	// Markdown task lists render as disabled checkboxes
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+
+	for _, el := range config.AllowElements {
+		if el = strings.ToLower(strings.TrimSpace(el)); el != "" {
+			p.AllowElements(el)
+		}
+	}
+	for _, pair := range config.AllowAttributes {
+		el, attr, ok := strings.Cut(strings.ToLower(strings.TrimSpace(pair)), ":")
+		if !ok || el == "" || attr == "" {
+			continue
+		}
+		if el == "*" {
+			p.AllowAttrs(attr).Globally()
+		} else {
+			p.AllowAttrs(attr).OnElements(el)
+		}
+	}
+	if hosts := iframeHostPattern(config.IframeHosts); hosts != nil {
+		p.AllowAttrs("src").Matching(hosts).OnElements("iframe")
+		p.AllowAttrs("width", "height", "title", "allow", "allowfullscreen", "frameborder", "loading").OnElements("iframe")
+	}
+
+	return &HTMLSanitizer{policy: p}
+}
+
+// iframeHostPattern matches https URLs on one of hosts, or returns nil when
+// no hosts are configured.
+func iframeHostPattern(hosts []string) *regexp.Regexp {
+	var quoted []string
+	for _, h := range hosts {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			quoted = append(quoted, regexp.QuoteMeta(h))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`^https://(` + strings.Join(quoted, "|") + `)(/|$)`)
+}
+
+// Sanitize returns html with everything outside the allowlist removed.
+func (s *HTMLSanitizer) Sanitize(html string) string {
+	return s.policy.Sanitize(html)
+}
@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestHTMLSanitizer_RemovesScript(t *testing.T) {
+	s := services.NewHTMLSanitizer(services.SanitizerConfig{})
+
+	tests := []struct {
+		name string
+		in   string
+		bad  string
+	}{
+		{"script tag", `<div>Hi<script>alert(1)</script></div>`, "<script"},
+		{"event handler", `<img src="/uploads/a.png" onerror="alert(1)">`, "onerror"},
+		{"javascript url", `<a href="javascript:alert(1)">x</a>`, "javascript:"},
+		{"style attribute", `<p style="position:fixed">x</p>`, "style="},
+		{"iframe", `<iframe src="https://evil.example.com"></iframe>`, "<iframe"},
+		{"form", `<form action="/admin/logout"><button>go</button></form>`, "<form"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if out := s.Sanitize(tt.in); strings.Contains(out, tt.bad) {
+				t.Errorf("%q survived: %s", tt.bad, out)
+			}
+		})
+	}
+}
+
+func TestHTMLSanitizer_KeepsEditorMarkup(t *testing.T) {
+	s := services.NewHTMLSanitizer(services.SanitizerConfig{})
+
+	// Trix attachment and formatting output
+	trix := `<div><strong>Bold</strong> <em>it</em> <del>old</del><br><a href="https://example.com">link</a></div>` +
+		`<figure data-trix-attachment="{&quot;contentType&quot;:&quot;image/png&quot;}" class="attachment attachment--preview">` +
+		`<img src="/uploads/a.png" width="400"><figcaption class="attachment__caption">Caption</figcaption></figure>`
+	out := s.Sanitize(trix)
+	for _, want := range []string{"<strong>Bold</strong>", "<del>old</del>", `href="https://example.com"`, "data-trix-attachment=", `class="attachment attachment--preview"`, `src="/uploads/a.png"`, "<figcaption"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q to be kept in %s", want, out)
+		}
+	}
+
+	// Markdown renderer output: heading anchors, tables, task lists
+	md, _ := services.RenderMarkdown("## Setup\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n- [x] done\n")
+	out = s.Sanitize(md)
+	for _, want := range []string{`<h2 id="setup">`, "<table>", `type="checkbox"`, "checked"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q to be kept in %s", want, out)
+		}
+	}
+}
+
+func TestHTMLSanitizer_ConfigurableAllowlist(t *testing.T) {
+	s := services.NewHTMLSanitizer(services.SanitizerConfig{
+		AllowElements:   []string{"mark"},
+		AllowAttributes: []string{"abbr:title", "bogus"},
+		IframeHosts:     []string{"www.youtube.com"},
+	})
+
+	out := s.Sanitize(`<mark>hi</mark><abbr title="HyperText">HTML</abbr>`)
+	if !strings.Contains(out, "<mark>hi</mark>") || !strings.Contains(out, `title="HyperText"`) {
+		t.Errorf("configured element/attribute removed: %s", out)
+	}
+
+	out = s.Sanitize(`<iframe src="https://www.youtube.com/embed/abc" allowfullscreen onload="x()"></iframe>`)
+	if !strings.Contains(out, `src="https://www.youtube.com/embed/abc"`) || strings.Contains(out, "onload") {
+		t.Errorf("allowed iframe not cleaned as expected: %s", out)
+	}
+
+	for _, in := range []string{
+		`<iframe src="https://www.youtube.com.evil.com/embed"></iframe>`,
+		`<iframe src="http://www.youtube.com/embed/abc"></iframe>`,
+	} {
+		if out := s.Sanitize(in); strings.Contains(out, "src=") {
+			t.Errorf("iframe from disallowed origin kept: %s", out)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	// Standard library imports
+	"context"  // Job cancellation
+	"log/slog" // Structured logging of posts going live
+	"sync"     // Guards the end of the previous run
+	"time"     // Run windows
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// ScheduledPublishing announces blog posts whose future publish date has
+// arrived. The public blog queries already hide a post until its
+// published_at, but pages cached before then still leave it out, and the
+// save that scheduled it announced it too early. Each run clears the cached
+// blog pages and the kept sitemap and sends content.published for the posts
+// that went live since the previous run.
+//
+// The end of the previous run is kept in memory, starting when the service
+// is created; posts that went live while the server was down are visible
+// after the restart (the cache starts empty) but are not announced.
+type ScheduledPublishing struct {
+	queries  *sqlc.Queries // Database query interface for blog_posts
+	cache    *Cache        // Cached public pages
+	webhooks *Webhooks     // content.published events; nil sends none
+	sitemap  *Sitemap      // Kept sitemap; nil when not kept
+	logger   *slog.Logger  // Structured logger for posts going live
+
+	mu    sync.Mutex
+	since time.Time // End of the previous run
+}
+
+// NewScheduledPublishing creates the scheduled publishing job.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - cache: Page cache whose "page:blog" entries are cleared
+//   - webhooks: Webhook service for content.published; may be nil
+//   - sitemap: Kept sitemap to invalidate; may be nil
+//   - logger: Structured logger for posts going live
+//
+// Returns:
+//   - *ScheduledPublishing: Job ready to run or schedule
+func NewScheduledPublishing(queries *sqlc.Queries, cache *Cache, webhooks *Webhooks, sitemap *Sitemap, logger *slog.Logger) *ScheduledPublishing {
+	return &ScheduledPublishing{
+		queries:  queries,
+		cache:    cache,
+		webhooks: webhooks,
+		sitemap:  sitemap,
+		logger:   logger,
+		since:    time.Now(),
+	}
+}
+
+// Run announces the posts that went live between the previous run and now.
+//
+// Parameters:
+//   - ctx: Context for the query
+//   - now: End of this run's window
+//
+// Returns:
+//   - int: Number of posts that went live
+//   - error: Database error, if any; the window is then retried next run
+func (p *ScheduledPublishing) Run(ctx context.Context, now time.Time) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	posts, err := p.queries.ListBlogPostsGoingLive(ctx, sqlc.ListBlogPostsGoingLiveParams{
+		After: p.since.UTC().Format(JobClockLayout),
+		Until: now.UTC().Format(JobClockLayout),
+	})
+	if err != nil {
+		return 0, err
+	}
+	p.since = now
+	if len(posts) == 0 {
+		return 0, nil
+	}
+
+	p.cache.DeleteByPrefix("page:blog")
+	p.sitemap.Invalidate()
+	for _, post := range posts {
+		p.logger.Info("scheduled blog post went live", "id", post.ID, "slug", post.Slug)
+		if p.webhooks != nil {
+			p.webhooks.Emit(WebhookEvent{
+				Type:    WebhookContentPublished,
+				Action:  "published",
+				Content: &WebhookContent{Type: "blog_post", ID: post.ID, Title: post.Title},
+			})
+		}
+	}
+	return len(posts), nil
+}
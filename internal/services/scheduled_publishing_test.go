@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestScheduledPublishing_Run(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, _ := queries.CreateBlogCategory(ctx, sqlc.CreateBlogCategoryParams{Name: "News", Slug: "news", ColorHex: "#000000"})
+	author, _ := queries.CreateBlogAuthor(ctx, sqlc.CreateBlogAuthorParams{Name: "A", Slug: "a", Title: "Writer"})
+	now := time.Now().UTC()
+	for slug, publishAt := range map[string]time.Time{"now": now, "later": now.Add(30 * time.Minute)} {
+		if _, err := queries.CreateBlogPost(ctx, sqlc.CreateBlogPostParams{
+			Title: slug, Slug: slug, Body: "body", Excerpt: "excerpt",
+			CategoryID: cat.ID, AuthorID: author.ID, Status: "published",
+			PublishedAt: sql.NullTime{Time: publishAt, Valid: true},
+		}); err != nil {
+			t.Fatalf("create post %s: %v", slug, err)
+		}
+	}
+
+	// The scheduled post is hidden until its publish date
+	posts, err := queries.ListPublishedPosts(ctx, sqlc.ListPublishedPostsParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListPublishedPosts: %v", err)
+	}
+	if len(posts) != 1 || posts[0].Slug != "now" {
+		t.Errorf("public posts = %v, want only the one published now", posts)
+	}
+
+	cache := services.NewCache()
+	builds := 0
+	sitemap := services.NewSitemap(func(context.Context) ([]byte, error) {
+		builds++
+		return []byte("<urlset/>"), nil
+	})
+	if _, err := sitemap.Regenerate(ctx); err != nil {
+		t.Fatalf("Regenerate: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	job := services.NewScheduledPublishing(queries, cache, nil, sitemap, logger)
+
+	// Before the publish date nothing goes live; the post published on save
+	// was announced by the save
+	cache.Set("page:blog:page:1:category:", "cached", 600)
+	if n, err := job.Run(ctx, now.Add(time.Minute)); err != nil || n != 0 {
+		t.Fatalf("first run: %d posts went live (%v), want 0", n, err)
+	}
+	if _, ok := cache.Get("page:blog:page:1:category:"); !ok {
+		t.Error("blog pages were cleared although nothing went live")
+	}
+
+	if n, err := job.Run(ctx, now.Add(31*time.Minute)); err != nil || n != 1 {
+		t.Fatalf("second run: %d posts went live (%v), want 1", n, err)
+	}
+	if _, ok := cache.Get("page:blog:page:1:category:"); ok {
+		t.Error("cached blog pages should be cleared when a post goes live")
+	}
+	if _, err := sitemap.Get(ctx); err != nil || builds != 2 {
+		t.Errorf("sitemap built %d times (%v), want the kept copy dropped and built again", builds, err)
+	}
+
+	// Each post is announced once
+	if n, err := job.Run(ctx, now.Add(32*time.Minute)); err != nil || n != 0 {
+		t.Errorf("third run: %d posts went live (%v), want 0", n, err)
+	}
+}
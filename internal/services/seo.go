@@ -0,0 +1,190 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Request cancellation for override loading and title lookups
+	"database/sql" // sql.ErrNoRows for unknown content types
+	"errors"       // Override validation errors
+	"strings"      // Path normalisation
+	"sync"         // Guards the override snapshot, which changes when overrides are edited
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// SEO override validation errors returned by ValidateSEOMeta.
+var (
+	ErrSEOTarget    = errors.New("seo: choose a site path starting with / or a content item")
+	ErrSEOReserved  = errors.New("seo: admin and static file paths have no metadata")
+	ErrSEOCanonical = errors.New("seo: the canonical URL must be a site path starting with /")
+	ErrSEOImage     = errors.New("seo: the image must be a path starting with / or an http(s) URL")
+	ErrSEOEmpty     = errors.New("seo: the override changes nothing")
+)
+
+// SEOEntityType is a content type whose pages can carry an SEO override.
+type SEOEntityType struct {
+	Type  string // seo_meta.entity_type, one of the Preview* content types
+	Label string // Name shown under Admin > SEO
+}
+
+// SEOEntityTypes lists the content types an override can target, in the
+// order the admin form offers them. They reuse the preview content types,
+// which name the same detail pages.
+var SEOEntityTypes = []SEOEntityType{
+	{PreviewProduct, "Product"},
+	{PreviewSolution, "Solution"},
+	{PreviewBlogPost, "Blog post"},
+	{PreviewCaseStudy, "Case study"},
+	{PreviewWhitepaper, "Whitepaper"},
+}
+
+// SEOEntityLabel returns the display name of an override's content type,
+// or entityType itself when it is unknown.
+func SEOEntityLabel(entityType string) string {
+	for _, t := range SEOEntityTypes {
+		if t.Type == entityType {
+			return t.Label
+		}
+	}
+	return entityType
+}
+
+// seoEntityKey identifies a content item in the override snapshot.
+type seoEntityKey struct {
+	entityType string
+	id         int64
+}
+
+// SEO serves the per-page metadata overrides of the seo_meta table from
+// memory. Every change goes through Reload, so public requests never query
+// the database to look for an override.
+type SEO struct {
+	queries *sqlc.Queries // Override storage and content titles
+
+	mu       sync.RWMutex                   // Guards routes and entities
+	routes   map[string]sqlc.SeoMetum       // Overrides keyed by path
+	entities map[seoEntityKey]sqlc.SeoMetum // Overrides keyed by content item
+}
+
+// NewSEO creates the SEO metadata service. Call Reload before serving
+// requests to load the stored overrides.
+func NewSEO(queries *sqlc.Queries) *SEO {
+	return &SEO{queries: queries, routes: map[string]sqlc.SeoMetum{}, entities: map[seoEntityKey]sqlc.SeoMetum{}}
+}
+
+// Reload replaces the in-memory overrides with the rows of the seo_meta table.
+func (s *SEO) Reload(ctx context.Context) error {
+	rows, err := s.queries.ListSEOMeta(ctx)
+	if err != nil {
+		return err
+	}
+	routes := make(map[string]sqlc.SeoMetum, len(rows))
+	entities := make(map[seoEntityKey]sqlc.SeoMetum, len(rows))
+	for _, row := range rows {
+		if row.Route != "" {
+			routes[row.Route] = row
+		} else {
+			entities[seoEntityKey{row.EntityType, row.EntityID}] = row
+		}
+	}
+
+	s.mu.Lock()
+	s.routes, s.entities = routes, entities
+	s.mu.Unlock()
+	return nil
+}
+
+// ForPath returns the override for the page at path. A trailing slash is
+// ignored.
+func (s *SEO) ForPath(path string) (sqlc.SeoMetum, bool) {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	meta, ok := s.routes[path]
+	return meta, ok
+}
+
+// ForEntity returns the override for the detail page of a content item.
+func (s *SEO) ForEntity(entityType string, id int64) (sqlc.SeoMetum, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	meta, ok := s.entities[seoEntityKey{entityType, id}]
+	return meta, ok
+}
+
+// EntityTitle returns the title of the content item an override targets,
+// for the admin list and to check the item exists. It returns
+// sql.ErrNoRows for a missing item or unknown content type.
+func (s *SEO) EntityTitle(ctx context.Context, entityType string, id int64) (string, error) {
+	switch entityType {
+	case PreviewProduct:
+		p, err := s.queries.GetProduct(ctx, id)
+		return p.Name, err
+	case PreviewSolution:
+		sol, err := s.queries.GetSolutionByID(ctx, id)
+		return sol.Title, err
+	case PreviewBlogPost:
+		post, err := s.queries.GetBlogPost(ctx, id)
+		return post.Title, err
+	case PreviewCaseStudy:
+		cs, err := s.queries.AdminGetCaseStudy(ctx, id)
+		return cs.Title, err
+	case PreviewWhitepaper:
+		wp, err := s.queries.GetWhitepaperByID(ctx, id)
+		return wp.Title, err
+	}
+	return "", sql.ErrNoRows
+}
+
+// ValidateSEOMeta checks an override from the admin form and returns it
+// with its fields trimmed and the trailing slash removed from the route.
+//
+// An override targets either a site path (route) or a content item
+// (entity_type and entity_id), never both; whether the item exists is
+// checked separately with EntityTitle. The canonical URL is a site path,
+// as the layout prefixes the site's address; the image may also be an
+// absolute http(s) URL.
+func ValidateSEOMeta(m sqlc.CreateSEOMetaParams) (sqlc.CreateSEOMetaParams, error) {
+	m.Route = strings.TrimSpace(m.Route)
+	m.MetaTitle = strings.TrimSpace(m.MetaTitle)
+	m.MetaDescription = strings.TrimSpace(m.MetaDescription)
+	m.CanonicalUrl = strings.TrimSpace(m.CanonicalUrl)
+	m.OgImage = strings.TrimSpace(m.OgImage)
+
+	if m.Route != "" {
+		m.EntityType, m.EntityID = "", 0
+		if !isSitePath(m.Route) || strings.ContainsAny(m.Route, "?#*") {
+			return m, ErrSEOTarget
+		}
+		if len(m.Route) > 1 {
+			m.Route = strings.TrimSuffix(m.Route, "/")
+		}
+		for _, reserved := range reservedRedirectPrefixes {
+			if m.Route == reserved || strings.HasPrefix(m.Route, reserved+"/") {
+				return m, ErrSEOReserved
+			}
+		}
+	} else if SEOEntityLabel(m.EntityType) == m.EntityType || m.EntityID <= 0 {
+		return m, ErrSEOTarget
+	}
+
+	if m.CanonicalUrl != "" && !isSitePath(m.CanonicalUrl) {
+		return m, ErrSEOCanonical
+	}
+	if m.OgImage != "" && !isSitePath(m.OgImage) && !strings.HasPrefix(m.OgImage, "https://") && !strings.HasPrefix(m.OgImage, "http://") ||
+		strings.ContainsAny(m.OgImage, " ") {
+		return m, ErrSEOImage
+	}
+	if m.MetaTitle == "" && m.MetaDescription == "" && m.CanonicalUrl == "" && m.OgImage == "" && !m.Noindex {
+		return m, ErrSEOEmpty
+	}
+	return m, nil
+}
+
+// isSitePath reports whether p is a path on this site: it starts with a
+// single "/" and contains no spaces.
+func isSitePath(p string) bool {
+	return strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "//") && !strings.Contains(p, " ")
+}
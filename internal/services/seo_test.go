@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestSEO_Lookup(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, m := range []sqlc.CreateSEOMetaParams{
+		{Route: "/about", MetaTitle: "About BlueJay"},
+		{EntityType: services.PreviewProduct, EntityID: 7, Noindex: true},
+	} {
+		if _, err := queries.CreateSEOMeta(ctx, m); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	if _, err := queries.CreateSEOMeta(ctx, sqlc.CreateSEOMetaParams{Route: "/about", MetaTitle: "Again"}); err == nil {
+		t.Error("second override for /about accepted")
+	}
+	if _, err := queries.CreateSEOMeta(ctx, sqlc.CreateSEOMetaParams{Route: "/x", EntityType: services.PreviewProduct, EntityID: 8}); err == nil {
+		t.Error("override with both a route and an entity accepted")
+	}
+
+	seo := services.NewSEO(queries)
+	if err := seo.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if m, ok := seo.ForPath("/about/"); !ok || m.MetaTitle != "About BlueJay" {
+		t.Errorf("ForPath(/about/) = %+v, %v", m, ok)
+	}
+	if _, ok := seo.ForPath("/contact"); ok {
+		t.Error("ForPath(/contact) found an override")
+	}
+	if m, ok := seo.ForEntity(services.PreviewProduct, 7); !ok || !m.Noindex {
+		t.Errorf("ForEntity(product, 7) = %+v, %v", m, ok)
+	}
+	if _, ok := seo.ForEntity(services.PreviewSolution, 7); ok {
+		t.Error("ForEntity(solution, 7) found the product's override")
+	}
+}
+
+func TestValidateSEOMeta(t *testing.T) {
+	ok := []sqlc.CreateSEOMetaParams{
+		{Route: " /about/ ", MetaTitle: "About"},
+		{Route: "/", OgImage: "https://cdn.example.com/og.png"},
+		{EntityType: services.PreviewBlogPost, EntityID: 3, CanonicalUrl: "/blog/other"},
+		{Route: "/contact", EntityType: services.PreviewBlogPost, EntityID: 3, Noindex: true}, // The route wins
+	}
+	for _, m := range ok {
+		if _, err := services.ValidateSEOMeta(m); err != nil {
+			t.Errorf("ValidateSEOMeta(%+v) = %v", m, err)
+		}
+	}
+	if m, _ := services.ValidateSEOMeta(ok[0]); m.Route != "/about" {
+		t.Errorf("route = %q, want /about", m.Route)
+	}
+	if m, _ := services.ValidateSEOMeta(ok[3]); m.EntityType != "" || m.EntityID != 0 {
+		t.Errorf("route override kept entity %s/%d", m.EntityType, m.EntityID)
+	}
+
+	bad := map[error]sqlc.CreateSEOMetaParams{
+		services.ErrSEOTarget:    {Route: "about", MetaTitle: "x"},
+		services.ErrSEOReserved:  {Route: "/admin/settings", MetaTitle: "x"},
+		services.ErrSEOCanonical: {Route: "/a", CanonicalUrl: "https://other.example.com/a"},
+		services.ErrSEOImage:     {Route: "/a", OgImage: "javascript:alert(1)"},
+		services.ErrSEOEmpty:     {Route: "/a"},
+	}
+	for want, m := range bad {
+		if _, err := services.ValidateSEOMeta(m); !errors.Is(err, want) {
+			t.Errorf("ValidateSEOMeta(%+v) = %v, want %v", m, err, want)
+		}
+	}
+	if _, err := services.ValidateSEOMeta(sqlc.CreateSEOMetaParams{EntityType: "page", EntityID: 1, MetaTitle: "x"}); !errors.Is(err, services.ErrSEOTarget) {
+		t.Errorf("unknown content type: %v", err)
+	}
+}
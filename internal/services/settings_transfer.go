@@ -0,0 +1,321 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes"         // Decoding the uploaded document
+	"context"       // Database calls
+	"encoding/json" // Settings document format
+	"fmt"           // Validation messages
+	"reflect"       // Mapping JSON keys onto the settings row
+	"sort"          // Stable order of changes and errors
+	"strconv"       // Formatting values for the diff
+	"time"          // Export timestamp
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Settings documents identify themselves so that an unrelated JSON file, or
+// one from an incompatible release, is rejected instead of half-applied.
+const (
+	SettingsExportFormat  = "bluejay-settings"
+	SettingsExportVersion = 1
+)
+
+// settingsNotExported are settings columns that are not part of an export:
+// the row identity and timestamps, secrets, which stay in the installation
+// they were entered in, maintenance mode, so importing a copy of a staging
+// site's settings cannot take the public site offline, and the admin network
+// rules and HTTPS host names, which differ between installations and could
+// lock everyone out.
+var settingsNotExported = map[string]bool{
+	"id":                  true,
+	"created_at":          true,
+	"updated_at":          true,
+	"mt_api_key":          true,
+	"maintenance_mode":    true,
+	"maintenance_message": true,
+	"admin_ip_allow":      true,
+	"admin_ip_deny":       true,
+	"tls_domains":         true,
+}
+
+// settingRule constrains a setting beyond its JSON type. Integer settings
+// without a rule are on/off flags stored as 0 or 1.
+type settingRule struct {
+	min, max int64             // Inclusive range for integer settings
+	oneOf    []string          // Allowed values for string settings
+	check    func(string) bool // Required format for string settings
+	format   string            // Describes check in error messages
+}
+
+// settingsRules mirror the choices offered by the settings forms.
+var settingsRules = map[string]settingRule{
+	"footer_columns":            {min: 2, max: 4},
+	"homepage_max_heroes":       {min: 1, max: 20},
+	"homepage_max_stats":        {min: 1, max: 12},
+	"homepage_max_testimonials": {min: 1, max: 12},
+	"homepage_hero_interval":    {min: 2, max: 30},
+	"products_per_page":         {min: 4, max: 48},
+	"solutions_per_page":        {min: 4, max: 48},
+	"blog_posts_per_page":       {min: 3, max: 50},
+	"products_default_sort":     {oneOf: []string{SortNameAsc, SortNameDesc, SortNewest, SortOldest, SortSKU}},
+	"header_cta_style":          {oneOf: []string{"primary", "secondary"}},
+	"header_social_style":       {oneOf: []string{"icons", "icons_labels"}},
+	"footer_social_style":       {oneOf: []string{"icons", "icons_labels"}},
+	"footer_bg_style":           {oneOf: []string{"dark", "light", "primary"}},
+	"mt_provider":               {oneOf: []string{"", "deepl", "google"}},
+	"theme_mode":                {oneOf: ThemeModes},
+	"theme_light_primary":       themeColorRule,
+	"theme_light_primary_hover": themeColorRule,
+	"theme_light_background":    themeColorRule,
+	"theme_light_surface":       themeColorRule,
+	"theme_light_text":          themeColorRule,
+	"theme_light_border":        themeColorRule,
+	"theme_dark_primary":        themeColorRule,
+	"theme_dark_primary_hover":  themeColorRule,
+	"theme_dark_background":     themeColorRule,
+	"theme_dark_surface":        themeColorRule,
+	"theme_dark_text":           themeColorRule,
+	"theme_dark_border":         themeColorRule,
+	"timezone":                  {check: IsTimezone, format: "a time zone such as Europe/Berlin"},
+	"format_locale":             {check: IsFormatLocale, format: "a locale such as de-DE"},
+	"currency":                  {check: IsCurrency, format: "a currency code such as EUR"},
+}
+
+var themeColorRule = settingRule{check: IsThemeColor, format: "a #RRGGBB color"}
+
+// settingField is an exportable column of sqlc.Setting.
+type settingField struct {
+	key   string       // JSON key, same as the column name
+	index int          // Field index in sqlc.Setting
+	kind  reflect.Kind // reflect.String, reflect.Bool or reflect.Int64
+}
+
+// settingsFields are the exportable settings in column order, read from the
+// JSON tags of sqlc.Setting so new columns are included automatically.
+var settingsFields = func() []settingField {
+	t := reflect.TypeOf(sqlc.Setting{})
+	var fields []settingField
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("json")
+		if settingsNotExported[key] {
+			continue
+		}
+		fields = append(fields, settingField{key: key, index: i, kind: t.Field(i).Type.Kind()})
+	}
+	return fields
+}()
+
+// SettingsDocument is the JSON form of all settings.
+type SettingsDocument struct {
+	Format     string                 `json:"format"`
+	Version    int                    `json:"version"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Settings   map[string]interface{} `json:"settings"`
+}
+
+// ExportSettings converts the settings row into a document for download.
+// Secrets (the machine-translation API key) are left out.
+func ExportSettings(s sqlc.Setting, now time.Time) SettingsDocument {
+	v := reflect.ValueOf(s)
+	values := make(map[string]interface{}, len(settingsFields))
+	for _, f := range settingsFields {
+		values[f.key] = v.Field(f.index).Interface()
+	}
+	return SettingsDocument{
+		Format:     SettingsExportFormat,
+		Version:    SettingsExportVersion,
+		ExportedAt: now.UTC(),
+		Settings:   values,
+	}
+}
+
+// SettingChange is one setting an import would change.
+type SettingChange struct {
+	Key string // Column name, e.g. "site_name"
+	Old string // Current value, formatted for display
+	New string // Value from the file
+}
+
+// SettingsImport is the result of validating a settings document against
+// the current settings.
+type SettingsImport struct {
+	Settings sqlc.Setting    // Current settings with the document's values applied
+	Changes  []SettingChange // Settings whose value differs, in column order
+	Errors   []string        // Validation errors; nothing may be applied if any
+	Omitted  int             // Exportable settings missing from the document (kept as they are)
+}
+
+// OK reports whether the document passed validation.
+func (si *SettingsImport) OK() bool {
+	return len(si.Errors) == 0
+}
+
+// ParseSettingsImport validates a settings document and works out which
+// settings it changes. Settings missing from the document keep their
+// current values. Nothing is written.
+//
+// Validation covers the format marker and version, unknown or non-importable
+// keys, JSON types (string, boolean, integer), and the allowed values and
+// ranges of the settings forms. All errors are collected, so one pass
+// reports every problem in the file.
+//
+// Parameters:
+//   - data: The uploaded JSON document
+//   - current: The settings row as it is now
+//
+// Returns:
+//   - *SettingsImport: Merged settings, changes and validation errors
+func ParseSettingsImport(data []byte, current sqlc.Setting) *SettingsImport {
+	result := &SettingsImport{Settings: current}
+
+	var doc struct {
+		Format     string                     `json:"format"`
+		Version    int                        `json:"version"`
+		ExportedAt *time.Time                 `json:"exported_at"`
+		Settings   map[string]json.RawMessage `json:"settings"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		result.Errors = append(result.Errors, "not a valid settings file: "+err.Error())
+		return result
+	}
+	if doc.Format != SettingsExportFormat {
+		result.Errors = append(result.Errors, fmt.Sprintf("format must be %q", SettingsExportFormat))
+		return result
+	}
+	if doc.Version != SettingsExportVersion {
+		result.Errors = append(result.Errors, fmt.Sprintf("unsupported version %d (this site reads version %d)", doc.Version, SettingsExportVersion))
+		return result
+	}
+	if doc.Settings == nil {
+		result.Errors = append(result.Errors, `"settings" is missing`)
+		return result
+	}
+
+	fieldsByKey := make(map[string]settingField, len(settingsFields))
+	for _, f := range settingsFields {
+		fieldsByKey[f.key] = f
+	}
+	var unknown []string
+	for key := range doc.Settings {
+		if _, ok := fieldsByKey[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		if settingsNotExported[key] {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: cannot be imported", key))
+		} else {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: unknown setting", key))
+		}
+	}
+
+	merged := reflect.ValueOf(&result.Settings).Elem()
+	old := reflect.ValueOf(current)
+	for _, f := range settingsFields {
+		raw, ok := doc.Settings[f.key]
+		if !ok {
+			result.Omitted++
+			continue
+		}
+		value, msg := decodeSetting(f, raw)
+		if msg != "" {
+			result.Errors = append(result.Errors, f.key+": "+msg)
+			continue
+		}
+		merged.Field(f.index).Set(value)
+		if value.Interface() != old.Field(f.index).Interface() {
+			result.Changes = append(result.Changes, SettingChange{
+				Key: f.key,
+				Old: formatSetting(old.Field(f.index)),
+				New: formatSetting(value),
+			})
+		}
+	}
+	return result
+}
+
+// decodeSetting decodes and checks one value. It returns a message
+// describing the problem when the value is not acceptable.
+func decodeSetting(f settingField, raw json.RawMessage) (reflect.Value, string) {
+	// json.Unmarshal accepts null for any type, leaving the zero value
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return reflect.Value{}, "must not be null"
+	}
+	switch f.kind {
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return reflect.Value{}, "must be a string"
+		}
+		if rule, ok := settingsRules[f.key]; ok && len(rule.oneOf) > 0 {
+			allowed := false
+			for _, v := range rule.oneOf {
+				allowed = allowed || v == s
+			}
+			if !allowed {
+				return reflect.Value{}, fmt.Sprintf("%q is not one of %q", s, rule.oneOf)
+			}
+		}
+		if rule, ok := settingsRules[f.key]; ok && rule.check != nil && !rule.check(s) {
+			return reflect.Value{}, fmt.Sprintf("%q is not %s", s, rule.format)
+		}
+		return reflect.ValueOf(s), ""
+	case reflect.Bool:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return reflect.Value{}, "must be true or false"
+		}
+		return reflect.ValueOf(b), ""
+	case reflect.Int64:
+		var n int64
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return reflect.Value{}, "must be a whole number"
+		}
+		rule, ok := settingsRules[f.key]
+		if !ok {
+			rule = settingRule{min: 0, max: 1}
+		}
+		if n < rule.min || n > rule.max {
+			return reflect.Value{}, fmt.Sprintf("must be between %d and %d", rule.min, rule.max)
+		}
+		return reflect.ValueOf(n), ""
+	}
+	return reflect.Value{}, "unsupported setting type"
+}
+
+// formatSetting formats a value for the diff preview and activity log.
+func formatSetting(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	}
+	return v.String()
+}
+
+// ApplySettingsImport writes every importable setting from s in a single
+// update. Use the Settings of a SettingsImport that passed validation.
+//
+// Returns:
+//   - error: A settings column missing from the ImportSettings query, or
+//     database errors
+func ApplySettingsImport(ctx context.Context, queries sqlc.Querier, s sqlc.Setting) error {
+	var params sqlc.ImportSettingsParams
+	src := reflect.ValueOf(s)
+	dst := reflect.ValueOf(&params).Elem()
+	for _, f := range settingsFields {
+		name := src.Type().Field(f.index).Name
+		field := dst.FieldByName(name)
+		if !field.IsValid() {
+			return fmt.Errorf("settings import: column %s is not written by ImportSettings", f.key)
+		}
+		field.Set(src.Field(f.index))
+	}
+	return queries.ImportSettings(ctx, params)
+}
@@ -0,0 +1,192 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// settingsDoc builds a version 1 document around the given settings.
+func settingsDoc(t *testing.T, settings map[string]interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"format": "bluejay-settings", "version": 1, "settings": settings})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSettingsExport_RoundTrip(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	current, err := queries.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current.MtApiKey = "secret"
+
+	doc := services.ExportSettings(current, time.Now())
+	if _, ok := doc.Settings["mt_api_key"]; ok {
+		t.Error("API key must not be exported")
+	}
+	if _, ok := doc.Settings["id"]; ok {
+		t.Error("id must not be exported")
+	}
+	data, _ := json.Marshal(doc)
+	result := services.ParseSettingsImport(data, current)
+	if !result.OK() || len(result.Changes) != 0 || result.Omitted != 0 {
+		t.Fatalf("re-import of an export: errors=%v changes=%v omitted=%d", result.Errors, result.Changes, result.Omitted)
+	}
+}
+
+// TestSettingsImport_AppliesEveryColumn changes every exported setting and
+// checks each one is written, so a column missing from ImportSettings is
+// caught.
+func TestSettingsImport_AppliesEveryColumn(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	current, _ := queries.GetSettings(ctx)
+
+	choices := map[string][2]interface{}{
+		"products_default_sort":     {"name_asc", "oldest"},
+		"header_cta_style":          {"primary", "secondary"},
+		"header_social_style":       {"icons", "icons_labels"},
+		"footer_social_style":       {"icons", "icons_labels"},
+		"footer_bg_style":           {"dark", "light"},
+		"mt_provider":               {"", "deepl"},
+		"footer_columns":            {2.0, 3.0},
+		"homepage_max_heroes":       {3.0, 4.0},
+		"homepage_max_stats":        {3.0, 4.0},
+		"homepage_max_testimonials": {3.0, 4.0},
+		"homepage_hero_interval":    {3.0, 4.0},
+		"products_per_page":         {8.0, 9.0},
+		"solutions_per_page":        {8.0, 9.0},
+		"blog_posts_per_page":       {8.0, 9.0},
+		"theme_mode":                {"light", "system"},
+		"timezone":                  {"UTC", "Asia/Tokyo"},
+		"format_locale":             {"en-US", "de-DE"},
+		"currency":                  {"USD", "EUR"},
+	}
+	for key := range services.ExportSettings(current, time.Now()).Settings {
+		if strings.HasPrefix(key, "theme_light_") || strings.HasPrefix(key, "theme_dark_") {
+			choices[key] = [2]interface{}{"#123456", "#654321"}
+		}
+	}
+	settings := map[string]interface{}{}
+	for key, v := range services.ExportSettings(current, time.Now()).Settings {
+		raw, _ := json.Marshal(v)
+		var old interface{}
+		json.Unmarshal(raw, &old)
+		if pair, ok := choices[key]; ok {
+			settings[key] = pair[0]
+			if old == pair[0] {
+				settings[key] = pair[1]
+			}
+			continue
+		}
+		switch o := old.(type) {
+		case string:
+			settings[key] = o + "-imported"
+		case bool:
+			settings[key] = !o
+		case float64:
+			settings[key] = 1 - o
+		}
+	}
+
+	result := services.ParseSettingsImport(settingsDoc(t, settings), current)
+	if !result.OK() {
+		t.Fatalf("errors: %v", result.Errors)
+	}
+	if len(result.Changes) != len(settings) {
+		t.Fatalf("expected %d changes, got %d", len(settings), len(result.Changes))
+	}
+	if err := services.ApplySettingsImport(ctx, queries, result.Settings); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	saved, _ := queries.GetSettings(ctx)
+	got := services.ExportSettings(saved, time.Now()).Settings
+	want := services.ExportSettings(result.Settings, time.Now()).Settings
+	for key := range want {
+		if got[key] != want[key] {
+			t.Errorf("%s: saved %v, want %v", key, got[key], want[key])
+		}
+	}
+	if saved.MtApiKey != current.MtApiKey {
+		t.Error("API key must be left unchanged")
+	}
+}
+
+func TestSettingsImport_Partial(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	current, _ := queries.GetSettings(context.Background())
+
+	result := services.ParseSettingsImport(settingsDoc(t, map[string]interface{}{
+		"site_name":      "Staging Copy",
+		"footer_columns": current.FooterColumns,
+	}), current)
+	if !result.OK() || len(result.Changes) != 1 {
+		t.Fatalf("errors=%v changes=%v", result.Errors, result.Changes)
+	}
+	if ch := result.Changes[0]; ch.Key != "site_name" || ch.Old != current.SiteName || ch.New != "Staging Copy" {
+		t.Errorf("change = %+v", ch)
+	}
+	if result.Omitted == 0 || result.Settings.SiteTagline != current.SiteTagline {
+		t.Error("settings missing from the file should keep their values")
+	}
+}
+
+func TestSettingsImport_Validation(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	current, _ := queries.GetSettings(context.Background())
+
+	cases := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not json", `{"format":`, "not a valid settings file"},
+		{"unknown top-level field", `{"format":"bluejay-settings","version":1,"settings":{},"extra":1}`, "not a valid settings file"},
+		{"wrong format", `{"format":"other","version":1,"settings":{}}`, `format must be "bluejay-settings"`},
+		{"newer version", `{"format":"bluejay-settings","version":2,"settings":{}}`, "unsupported version 2"},
+		{"no settings", `{"format":"bluejay-settings","version":1}`, `"settings" is missing`},
+		{"unknown key", `{"format":"bluejay-settings","version":1,"settings":{"site_nme":"x"}}`, "site_nme: unknown setting"},
+		{"secret", `{"format":"bluejay-settings","version":1,"settings":{"mt_api_key":"k"}}`, "mt_api_key: cannot be imported"},
+		{"string type", `{"format":"bluejay-settings","version":1,"settings":{"site_name":5}}`, "site_name: must be a string"},
+		{"bool type", `{"format":"bluejay-settings","version":1,"settings":{"minify_html":"yes"}}`, "minify_html: must be true or false"},
+		{"int type", `{"format":"bluejay-settings","version":1,"settings":{"products_per_page":12.5}}`, "products_per_page: must be a whole number"},
+		{"range", `{"format":"bluejay-settings","version":1,"settings":{"footer_columns":9}}`, "footer_columns: must be between 2 and 4"},
+		{"flag", `{"format":"bluejay-settings","version":1,"settings":{"blog_show_tags":2}}`, "blog_show_tags: must be between 0 and 1"},
+		{"enum", `{"format":"bluejay-settings","version":1,"settings":{"footer_bg_style":"neon"}}`, `footer_bg_style: "neon" is not one of`},
+		{"color", `{"format":"bluejay-settings","version":1,"settings":{"theme_dark_text":"white"}}`, `theme_dark_text: "white" is not a #RRGGBB color`},
+		{"timezone", `{"format":"bluejay-settings","version":1,"settings":{"timezone":"Mars/Olympus"}}`, `timezone: "Mars/Olympus" is not a time zone`},
+		{"null", `{"format":"bluejay-settings","version":1,"settings":{"site_name":null}}`, "site_name: must not be null"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := services.ParseSettingsImport([]byte(tc.data), current)
+			if result.OK() {
+				t.Fatal("expected a validation error")
+			}
+			if !strings.Contains(strings.Join(result.Errors, "\n"), tc.want) {
+				t.Errorf("errors %q, want %q", result.Errors, tc.want)
+			}
+		})
+	}
+
+	// Every problem is reported at once
+	result := services.ParseSettingsImport(settingsDoc(t, map[string]interface{}{"footer_columns": 9, "site_name": 1, "bogus": true}), current)
+	if len(result.Errors) != 3 {
+		t.Errorf("expected 3 errors, got %q", result.Errors)
+	}
+}
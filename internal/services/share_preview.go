@@ -0,0 +1,151 @@
+package services
+
+import (
+	// Standard library imports
+	"fmt"          // Warning texts
+	"net/url"      // Host shown in the previews
+	"strings"      // Path and URL handling
+	"unicode/utf8" // Character counts
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // SEO override rows
+)
+
+// SiteTitleSuffix is appended by public/layouts/base.html to the title of
+// a page without a meta title of its own.
+const SiteTitleSuffix = " - BlueJay Innovative Labs"
+
+// SharePage is the head metadata a public handler sets for a page, before
+// the layout's fallbacks and the Admin > SEO override apply.
+type SharePage struct {
+	Title           string // Page title, shown with SiteTitleSuffix without a meta title
+	MetaTitle       string
+	MetaDescription string
+	OGImage         string // Site path or absolute URL
+	CanonicalURL    string // Site path
+}
+
+// WithOverride applies an Admin > SEO override the way the renderer does:
+// its non-empty fields replace the page's own.
+func (p SharePage) WithOverride(meta sqlc.SeoMetum) SharePage {
+	for _, f := range []struct {
+		dst *string
+		val string
+	}{
+		{&p.MetaTitle, meta.MetaTitle},
+		{&p.MetaDescription, meta.MetaDescription},
+		{&p.CanonicalURL, meta.CanonicalUrl},
+		{&p.OGImage, meta.OgImage},
+	} {
+		if f.val != "" {
+			*f.dst = f.val
+		}
+	}
+	return p
+}
+
+// ShareCard is how one platform shows a shared page or search result.
+type ShareCard struct {
+	Platform       string // "Google", "X" or "LinkedIn"
+	Title          string // Title as the platform shows it, cut to TitleMax
+	Description    string // Description as the platform shows it, cut to DescriptionMax
+	TitleLen       int    // Characters in the full title
+	TitleMax       int
+	DescriptionLen int // Characters in the full description
+	DescriptionMax int
+	ShowsImage     bool     // The platform shows the Open Graph image
+	Warnings       []string // What the editor may want to fix
+}
+
+// TitleOver reports whether the title is cut.
+func (c ShareCard) TitleOver() bool { return c.TitleLen > c.TitleMax }
+
+// DescriptionOver reports whether the description is cut.
+func (c ShareCard) DescriptionOver() bool { return c.DescriptionLen > c.DescriptionMax }
+
+// SharePreview is a page as search engines and social networks will show
+// it, built from the same metadata public/layouts/base.html renders.
+type SharePreview struct {
+	Title              string // <title>, og:title and twitter:title
+	Description        string // Meta, og: and twitter: description
+	DefaultDescription bool   // Description is the site-wide one from Settings
+	Image              string // Absolute og:image URL, empty for none
+	URL                string // Absolute canonical URL
+	Host               string // Domain the platforms display
+	Cards              []ShareCard
+}
+
+// sharePlatforms are the previewed platforms with the approximate number
+// of characters each shows of a title and a description before cutting.
+var sharePlatforms = []struct {
+	name           string
+	titleMax       int
+	descriptionMax int
+	showsImage     bool
+}{
+	{"Google", 60, 160, false},
+	{"X", 70, 200, true},
+	{"LinkedIn", 70, 100, true},
+}
+
+// BuildSharePreview returns how page appears on Google, X and LinkedIn.
+// siteDescription is the default description of Settings, used by the
+// layout when the page has none; baseURL is the public site URL that
+// site paths are resolved against.
+func BuildSharePreview(page SharePage, siteDescription, baseURL string) SharePreview {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	p := SharePreview{Title: page.MetaTitle, Description: page.MetaDescription}
+	if p.Title == "" {
+		p.Title = page.Title + SiteTitleSuffix
+	}
+	if p.Description == "" {
+		p.Description, p.DefaultDescription = siteDescription, true
+	}
+	p.URL = baseURL + page.CanonicalURL
+	if isSitePath(page.OGImage) {
+		p.Image = baseURL + page.OGImage
+	} else {
+		p.Image = page.OGImage
+	}
+	if u, err := url.Parse(baseURL); err == nil {
+		p.Host = strings.TrimPrefix(u.Host, "www.")
+	}
+
+	titleLen, descLen := utf8.RuneCountInString(p.Title), utf8.RuneCountInString(p.Description)
+	for _, platform := range sharePlatforms {
+		card := ShareCard{
+			Platform:       platform.name,
+			Title:          cutText(p.Title, platform.titleMax),
+			Description:    cutText(p.Description, platform.descriptionMax),
+			TitleLen:       titleLen,
+			TitleMax:       platform.titleMax,
+			DescriptionLen: descLen,
+			DescriptionMax: platform.descriptionMax,
+			ShowsImage:     platform.showsImage,
+		}
+		if card.TitleOver() {
+			card.Warnings = append(card.Warnings, fmt.Sprintf("The title is %d characters; %s shows about %d.", titleLen, platform.name, platform.titleMax))
+		}
+		if card.DescriptionOver() {
+			card.Warnings = append(card.Warnings, fmt.Sprintf("The description is %d characters; %s shows about %d.", descLen, platform.name, platform.descriptionMax))
+		}
+		if platform.showsImage && p.Image == "" {
+			card.Warnings = append(card.Warnings, "No image: "+platform.name+" shows a plain link.")
+		}
+		p.Cards = append(p.Cards, card)
+	}
+	if p.DefaultDescription {
+		p.Cards[0].Warnings = append(p.Cards[0].Warnings, "No meta description: the site's default is used, and Google may pick text from the page instead.")
+	}
+	return p
+}
+
+// cutText shortens s to at most max characters, ending it with "…" when
+// it is cut.
+func cutText(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	r := []rune(s)
+	return strings.TrimSpace(string(r[:max-1])) + "…"
+}
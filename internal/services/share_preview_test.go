@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestBuildSharePreview(t *testing.T) {
+	page := services.SharePage{Title: "TS 100 | Products", CanonicalURL: "/products/sensors/ts-100", OGImage: "/uploads/ts-100.jpg"}
+
+	// The layout's fallbacks: site name suffix and default description
+	p := services.BuildSharePreview(page, "Industrial sensors", "https://www.example.com/")
+	if p.Title != "TS 100 | Products - BlueJay Innovative Labs" || p.Description != "Industrial sensors" || !p.DefaultDescription {
+		t.Errorf("fallbacks: %+v", p)
+	}
+	if p.URL != "https://www.example.com/products/sensors/ts-100" || p.Image != "https://www.example.com/uploads/ts-100.jpg" || p.Host != "example.com" {
+		t.Errorf("URL %q, image %q, host %q", p.URL, p.Image, p.Host)
+	}
+	if len(p.Cards) != 3 || p.Cards[0].Platform != "Google" || len(p.Cards[0].Warnings) != 1 {
+		t.Fatalf("cards: %+v", p.Cards)
+	}
+
+	// An override replaces the page's own fields; long texts are cut with warnings
+	page.MetaTitle = "Own title"
+	page = page.WithOverride(sqlc.SeoMetum{MetaTitle: strings.Repeat("é", 65), MetaDescription: strings.Repeat("word ", 30), OgImage: ""})
+	page.OGImage = ""
+	p = services.BuildSharePreview(page, "Industrial sensors", "https://example.com")
+	google, x, linkedIn := p.Cards[0], p.Cards[1], p.Cards[2]
+	if google.TitleLen != 65 || !google.TitleOver() || x.TitleOver() {
+		t.Errorf("title counted in bytes or limits wrong: %+v", google)
+	}
+	if n := len([]rune(google.Title)); n != 60 || !strings.HasSuffix(google.Title, "…") {
+		t.Errorf("Google title %q (%d characters)", google.Title, n)
+	}
+	if google.DescriptionOver() || !linkedIn.DescriptionOver() {
+		t.Error("description limits wrong")
+	}
+	if len(google.Warnings) != 1 || len(x.Warnings) != 1 || len(linkedIn.Warnings) != 2 {
+		t.Errorf("warnings: %q / %q / %q", google.Warnings, x.Warnings, linkedIn.Warnings)
+	}
+	if !strings.Contains(x.Warnings[0], "No image") {
+		t.Errorf("missing image not reported: %q", x.Warnings)
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	// Standard library imports
+	"crypto/rand" // Generated codes
+	"errors"      // Link validation errors
+	"net/url"     // Adding UTM parameters to the destination
+	"regexp"      // Code format
+	"strings"     // Trimming and prefix checks
+	"time"        // Expiry
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// ShortLinkPrefix is the path short links are served under.
+const ShortLinkPrefix = "/go/"
+
+// Short link validation errors returned by ValidateShortLink.
+var (
+	ErrShortLinkCode        = errors.New("short links: the code must be 1-64 lowercase letters, digits, - or _")
+	ErrShortLinkDestination = errors.New("short links: the destination must be a path starting with / or an http(s) URL")
+	ErrShortLinkLoop        = errors.New("short links: the destination cannot be another short link")
+	ErrShortLinkUTM         = errors.New("short links: UTM parameters need a source")
+)
+
+// shortLinkCodePattern is the format of a code: what follows /go/.
+var shortLinkCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// shortLinkCodeLength is the length of generated codes.
+const shortLinkCodeLength = 7
+
+// NewShortLinkCode returns a random code for a link saved without one, such
+// as "k3xq7ma". The caller checks it is not taken.
+func NewShortLinkCode() string {
+	return strings.ToLower(rand.Text()[:shortLinkCodeLength])
+}
+
+// ValidateShortLink checks a link from the admin form and returns it with
+// its fields trimmed and its code lowercased. The code may be empty, for
+// the caller to generate one.
+//
+// Destinations are site paths (/products/sensors?ref=1) or http(s) URLs.
+// utm_source is required when any other UTM parameter is set, since
+// analytics tools ignore campaign data without it.
+func ValidateShortLink(link sqlc.ShortLink) (sqlc.ShortLink, error) {
+	link.Code = strings.ToLower(strings.TrimSpace(link.Code))
+	link.Destination = strings.TrimSpace(link.Destination)
+	for _, v := range []*string{&link.UtmSource, &link.UtmMedium, &link.UtmCampaign, &link.UtmTerm, &link.UtmContent, &link.Note} {
+		*v = strings.TrimSpace(*v)
+	}
+
+	if link.Code != "" && !shortLinkCodePattern.MatchString(link.Code) {
+		return link, ErrShortLinkCode
+	}
+	// Browsers read "//host" and "/\host" as links to another site
+	local := strings.HasPrefix(link.Destination, "/") &&
+		!strings.HasPrefix(link.Destination, "//") && !strings.HasPrefix(link.Destination, "/\\")
+	if !local && !strings.HasPrefix(link.Destination, "https://") && !strings.HasPrefix(link.Destination, "http://") ||
+		strings.ContainsAny(link.Destination, " \t\r\n") {
+		return link, ErrShortLinkDestination
+	}
+	if u, err := url.Parse(link.Destination); err != nil || !local && u.Host == "" {
+		return link, ErrShortLinkDestination
+	}
+	if local && strings.HasPrefix(link.Destination, ShortLinkPrefix) {
+		return link, ErrShortLinkLoop
+	}
+	if link.UtmSource == "" && link.UtmMedium+link.UtmCampaign+link.UtmTerm+link.UtmContent != "" {
+		return link, ErrShortLinkUTM
+	}
+	return link, nil
+}
+
+// ShortLinkTarget returns the URL a link redirects to: its destination with
+// the link's UTM parameters added, replacing any the destination already
+// has. The rest of the query and the fragment are kept.
+func ShortLinkTarget(link sqlc.ShortLink) string {
+	u, err := url.Parse(link.Destination)
+	if err != nil {
+		return link.Destination
+	}
+	q := u.Query()
+	for _, p := range []struct{ name, value string }{
+		{"utm_source", link.UtmSource},
+		{"utm_medium", link.UtmMedium},
+		{"utm_campaign", link.UtmCampaign},
+		{"utm_term", link.UtmTerm},
+		{"utm_content", link.UtmContent},
+	} {
+		if p.value != "" {
+			q.Set(p.name, p.value)
+		}
+	}
+	if len(q) > 0 {
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// ShortLinkExpired reports whether link no longer redirects at now.
+func ShortLinkExpired(link sqlc.ShortLink, now time.Time) bool {
+	return link.ExpiresAt.Valid && !now.Before(link.ExpiresAt.Time)
+}
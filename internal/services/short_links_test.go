@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestValidateShortLink(t *testing.T) {
+	cases := []struct {
+		name string
+		link sqlc.ShortLink
+		err  error
+	}{
+		{"site path", sqlc.ShortLink{Code: " Spring-26 ", Destination: "/products/sensors"}, nil},
+		{"url with utm", sqlc.ShortLink{Code: "fair", Destination: "https://example.com/a?b=1", UtmSource: "expo", UtmMedium: "print"}, nil},
+		{"generated code", sqlc.ShortLink{Destination: "/"}, nil},
+		{"bad code", sqlc.ShortLink{Code: "spring/26", Destination: "/"}, services.ErrShortLinkCode},
+		{"leading dash", sqlc.ShortLink{Code: "-a", Destination: "/"}, services.ErrShortLinkCode},
+		{"relative", sqlc.ShortLink{Code: "a", Destination: "products"}, services.ErrShortLinkDestination},
+		{"protocol relative", sqlc.ShortLink{Code: "a", Destination: "//evil.example"}, services.ErrShortLinkDestination},
+		{"backslash host", sqlc.ShortLink{Code: "a", Destination: "/\\evil.example"}, services.ErrShortLinkDestination},
+		{"javascript", sqlc.ShortLink{Code: "a", Destination: "javascript:alert(1)"}, services.ErrShortLinkDestination},
+		{"no host", sqlc.ShortLink{Code: "a", Destination: "https://"}, services.ErrShortLinkDestination},
+		{"chain", sqlc.ShortLink{Code: "a", Destination: "/go/b"}, services.ErrShortLinkLoop},
+		{"campaign without source", sqlc.ShortLink{Code: "a", Destination: "/", UtmCampaign: "spring"}, services.ErrShortLinkUTM},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			link, err := services.ValidateShortLink(tc.link)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err = %v, want %v", err, tc.err)
+			}
+			if err == nil && tc.name == "site path" && link.Code != "spring-26" {
+				t.Errorf("code = %q, want it trimmed and lowercased", link.Code)
+			}
+		})
+	}
+
+	if code := services.NewShortLinkCode(); len(code) != 7 {
+		t.Errorf("generated code %q", code)
+	} else if _, err := services.ValidateShortLink(sqlc.ShortLink{Code: code, Destination: "/"}); err != nil {
+		t.Errorf("generated code %q is invalid: %v", code, err)
+	}
+}
+
+func TestShortLinkTarget(t *testing.T) {
+	cases := []struct {
+		link sqlc.ShortLink
+		want string
+	}{
+		{sqlc.ShortLink{Destination: "/products"}, "/products"},
+		{sqlc.ShortLink{Destination: "/products?b=2#specs", UtmSource: "expo", UtmCampaign: "spring 26"},
+			"/products?b=2&utm_campaign=spring+26&utm_source=expo#specs"},
+		{sqlc.ShortLink{Destination: "https://example.com/?utm_source=old", UtmSource: "new", UtmContent: "banner"},
+			"https://example.com/?utm_content=banner&utm_source=new"},
+	}
+	for _, tc := range cases {
+		if got := services.ShortLinkTarget(tc.link); got != tc.want {
+			t.Errorf("ShortLinkTarget(%q) = %q, want %q", tc.link.Destination, got, tc.want)
+		}
+	}
+}
+
+func TestShortLinkExpired(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	if services.ShortLinkExpired(sqlc.ShortLink{}, now) {
+		t.Error("link without expiry expired")
+	}
+	link := sqlc.ShortLink{ExpiresAt: sql.NullTime{Time: now, Valid: true}}
+	if !services.ShortLinkExpired(link, now) || services.ShortLinkExpired(link, now.Add(-time.Second)) {
+		t.Error("expiry not applied at its time")
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	// Standard library imports
+	"crypto/hmac"     // Signing payloads
+	"crypto/sha256"   // HMAC hash and key derivation
+	"encoding/base64" // Signature encoding
+	"strings"         // Splitting off the signature
+)
+
+// signer signs short payloads such as token fields and cookie values with
+// HMAC-SHA256. Its key is derived from the application secret and a purpose,
+// so the session secret can be shared without reusing its key and values
+// signed for one purpose do not verify for another.
+type signer struct {
+	key []byte // HMAC key derived from the secret and purpose
+}
+
+// newSigner creates a signer for purpose, e.g. "preview".
+func newSigner(purpose, secret string) signer {
+	key := sha256.Sum256([]byte(purpose + ":" + secret))
+	return signer{key: key[:]}
+}
+
+// seal returns payload followed by "." and its base64url signature.
+func (s signer) seal(payload string) string {
+	return payload + "." + s.sign(payload)
+}
+
+// open returns the payload of a value from seal, and false when the value is
+// malformed or its signature does not match.
+func (s signer) open(value string) (string, bool) {
+	i := strings.LastIndexByte(value, '.')
+	if i < 0 {
+		return "", false
+	}
+	payload, sig := value[:i], value[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.sign(payload))) {
+		return "", false
+	}
+	return payload, true
+}
+
+// sign returns the base64url HMAC-SHA256 of payload.
+func (s signer) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
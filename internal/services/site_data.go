@@ -0,0 +1,119 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request cancellation for loading
+	"sync"    // Guards the snapshot, which changes when content is edited
+	"time"    // Snapshot refresh interval
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/internal/models" // SiteGlobals served to the public pages
+)
+
+// siteDataRefresh is how long a snapshot is served before the next request
+// reloads it, so changes not made through the admin panel (scheduled
+// publishing, product counts) show up without an edit.
+const siteDataRefresh = time.Minute
+
+// siteDataRetry is how long the previous snapshot is served after a failed
+// reload before another request tries again, so a locked or unavailable
+// database is not queried by every page view.
+const siteDataRetry = 10 * time.Second
+
+// SiteGlobalsSource loads SiteGlobals from the database on each call. It is
+// satisfied by middleware.QuerySiteData. Parts that fail to load are left
+// nil and reported in the error.
+type SiteGlobalsSource interface {
+	Get(ctx context.Context) (models.SiteGlobals, error)
+}
+
+// SiteData serves SiteGlobals to the public site from memory, so rendering
+// a page does not query the database for its settings and footer. The admin
+// panel calls Invalidate after each change, and the next request reloads.
+type SiteData struct {
+	source SiteGlobalsSource // Loads the settings and footer
+
+	mu        sync.Mutex         // Guards the fields below; not held while reloading
+	globals   models.SiteGlobals // Current snapshot
+	loaded    time.Time          // When globals were last loaded
+	failed    time.Time          // When the last reload failed; zero after a successful one
+	stale     bool               // Invalidate was called, or the last reload failed
+	reloading chan struct{}      // Closed when the reload in flight ends; nil when none is
+}
+
+// NewSiteData creates the site data service over source. The first Get
+// loads the snapshot.
+func NewSiteData(source SiteGlobalsSource) *SiteData {
+	return &SiteData{source: source, stale: true}
+}
+
+// Invalidate makes the next Get reload the snapshot, even while a failed
+// reload is waiting out siteDataRetry. Admin handlers call it (through
+// middleware.InvalidateSiteData) after saving a change.
+func (s *SiteData) Invalidate() {
+	s.mu.Lock()
+	s.stale = true
+	s.failed = time.Time{}
+	s.mu.Unlock()
+}
+
+// Get returns the current snapshot, reloading it first when it was
+// invalidated or is older than siteDataRefresh. One request reloads while
+// the others are served the previous snapshot; only before the first load
+// do they wait for it. When a reload fails, the parts that failed keep their
+// previous values, the error is returned to the request that reloaded, and
+// the snapshot is served as it is until siteDataRetry has passed. The
+// slices are shared between requests and must not be modified.
+func (s *SiteData) Get(ctx context.Context) (models.SiteGlobals, error) {
+	s.mu.Lock()
+	if !s.stale && time.Since(s.loaded) < siteDataRefresh || time.Since(s.failed) < siteDataRetry {
+		defer s.mu.Unlock()
+		return s.globals, nil
+	}
+	if done := s.reloading; done != nil {
+		if !s.loaded.IsZero() || !s.failed.IsZero() {
+			defer s.mu.Unlock()
+			return s.globals, nil
+		}
+		s.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return models.SiteGlobals{}, ctx.Err()
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.globals, nil
+	}
+	done := make(chan struct{})
+	s.reloading, s.stale = done, false
+	s.mu.Unlock()
+
+	// Other requests depend on this reload, so it is not cut short when the
+	// visitor who started it goes away
+	g, err := s.source.Get(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g.Settings == nil {
+		g.Settings = s.globals.Settings
+	}
+	if g.FooterCategories == nil {
+		g.FooterCategories = s.globals.FooterCategories
+	}
+	if g.FooterSolutions == nil {
+		g.FooterSolutions = s.globals.FooterSolutions
+	}
+	if g.FooterResources == nil {
+		g.FooterResources = s.globals.FooterResources
+	}
+	s.globals = g
+	if err == nil {
+		s.loaded, s.failed = time.Now(), time.Time{}
+	} else {
+		s.failed, s.stale = time.Now(), true
+	}
+	s.reloading = nil
+	close(done)
+	return g, err
+}
@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/models"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+// fakeSiteSource returns its globals and err, counting the loads.
+type fakeSiteSource struct {
+	globals models.SiteGlobals
+	err     error
+	loads   int
+}
+
+func (f *fakeSiteSource) Get(ctx context.Context) (models.SiteGlobals, error) {
+	f.loads++
+	return f.globals, f.err
+}
+
+func TestSiteData(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeSiteSource{globals: models.SiteGlobals{
+		Settings:         &sqlc.Setting{SiteName: "Bluejay"},
+		FooterCategories: []sqlc.ProductCategory{{Slug: "sensors"}},
+	}}
+	site := services.NewSiteData(src)
+
+	for i := 0; i < 3; i++ {
+		g, err := site.Get(ctx)
+		if err != nil || g.Settings == nil || g.Settings.SiteName != "Bluejay" {
+			t.Fatalf("Get = %+v, %v", g, err)
+		}
+	}
+	if src.loads != 1 {
+		t.Errorf("expected one load for three requests, got %d", src.loads)
+	}
+
+	// After an invalidation the next request loads again
+	src.globals.Settings = &sqlc.Setting{SiteName: "Renamed"}
+	site.Invalidate()
+	if g, _ := site.Get(ctx); g.Settings.SiteName != "Renamed" || src.loads != 2 {
+		t.Errorf("after Invalidate got %q with %d loads", g.Settings.SiteName, src.loads)
+	}
+
+	// A failed part keeps its previous value
+	failed := errors.New("database is locked")
+	src.globals, src.err = models.SiteGlobals{FooterCategories: []sqlc.ProductCategory{}}, failed
+	site.Invalidate()
+	g, err := site.Get(ctx)
+	if !errors.Is(err, failed) {
+		t.Errorf("expected the load error, got %v", err)
+	}
+	if g.Settings == nil || g.Settings.SiteName != "Renamed" {
+		t.Errorf("expected the previous settings to be kept, got %+v", g.Settings)
+	}
+	if len(g.FooterCategories) != 0 {
+		t.Errorf("expected the reloaded (empty) categories, got %v", g.FooterCategories)
+	}
+
+	// Until siteDataRetry passes the snapshot is served without retrying,
+	// unless an admin change invalidates it
+	src.err = nil
+	if _, err := site.Get(ctx); err != nil || src.loads != 3 {
+		t.Errorf("expected the snapshot without a retry, got %v with %d loads", err, src.loads)
+	}
+	site.Invalidate()
+	site.Get(ctx)
+	if src.loads != 4 {
+		t.Errorf("expected a reload after Invalidate, got %d loads", src.loads)
+	}
+}
+
+// blockingSiteSource returns its globals once release is closed.
+type blockingSiteSource struct {
+	globals models.SiteGlobals
+	started chan struct{}
+	release chan struct{}
+	loads   atomic.Int32
+}
+
+func (b *blockingSiteSource) Get(ctx context.Context) (models.SiteGlobals, error) {
+	b.loads.Add(1)
+	b.started <- struct{}{}
+	<-b.release
+	return b.globals, nil
+}
+
+func TestSiteData_ReloadOutsideLock(t *testing.T) {
+	ctx := context.Background()
+	src := &blockingSiteSource{
+		globals: models.SiteGlobals{Settings: &sqlc.Setting{SiteName: "Bluejay"}},
+		started: make(chan struct{}, 2),
+		release: make(chan struct{}),
+	}
+	site := services.NewSiteData(src)
+	close(src.release)
+	site.Get(ctx)
+	<-src.started
+
+	// While one request reloads, the others get the previous snapshot
+	src.release = make(chan struct{})
+	src.globals = models.SiteGlobals{Settings: &sqlc.Setting{SiteName: "Renamed"}}
+	site.Invalidate()
+	reloaded := make(chan models.SiteGlobals)
+	go func() {
+		g, _ := site.Get(ctx)
+		reloaded <- g
+	}()
+	<-src.started
+	for i := 0; i < 3; i++ {
+		if g, err := site.Get(ctx); err != nil || g.Settings.SiteName != "Bluejay" {
+			t.Fatalf("Get during a reload = %+v, %v, want the previous snapshot", g.Settings, err)
+		}
+	}
+	close(src.release)
+	if g := <-reloaded; g.Settings.SiteName != "Renamed" {
+		t.Errorf("reload returned %q", g.Settings.SiteName)
+	}
+	if g, _ := site.Get(ctx); g.Settings.SiteName != "Renamed" || src.loads.Load() != 2 {
+		t.Errorf("after the reload got %q with %d loads", g.Settings.SiteName, src.loads.Load())
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	// Standard library imports
+	"errors"      // Validation errors
+	"strings"     // Input trimming
+	"sync/atomic" // Site format shared with the template functions
+
+	// Unicode CLDR data
+	"golang.org/x/text/currency" // ISO 4217 codes
+	"golang.org/x/text/language" // BCP 47 tags
+)
+
+// Defaults until a formatting locale and currency are configured under
+// Global Settings.
+const (
+	DefaultFormatLocale = "en-US"
+	DefaultCurrency     = "USD"
+)
+
+var (
+	// ErrInvalidFormatLocale is returned for tags that are not well-formed BCP 47.
+	ErrInvalidFormatLocale = errors.New("unknown formatting locale")
+	// ErrInvalidCurrency is returned for codes that are not ISO 4217.
+	ErrInvalidCurrency = errors.New("unknown currency")
+)
+
+// SiteFormatConfig is how templates format numbers and prices. It is set
+// once for the site; the translation locales only change the text.
+type SiteFormatConfig struct {
+	Locale   language.Tag  // Decimal and grouping separators
+	Currency currency.Unit // Currency of prices without an explicit one
+}
+
+// siteFormat is read on every template render and replaced when the
+// setting changes.
+var siteFormat atomic.Pointer[SiteFormatConfig]
+
+// LoadFormatLocale parses a BCP 47 tag such as "de-DE". A blank tag is
+// rejected rather than read as the undetermined language.
+func LoadFormatLocale(tag string) (language.Tag, error) {
+	if strings.TrimSpace(tag) == "" {
+		return language.Und, ErrInvalidFormatLocale
+	}
+	t, err := language.Parse(tag)
+	if err != nil {
+		return language.Und, ErrInvalidFormatLocale
+	}
+	return t, nil
+}
+
+// IsFormatLocale reports whether tag is a well-formed BCP 47 tag.
+func IsFormatLocale(tag string) bool {
+	_, err := LoadFormatLocale(tag)
+	return err == nil
+}
+
+// LoadCurrency parses an ISO 4217 code such as "EUR", in any case.
+func LoadCurrency(code string) (currency.Unit, error) {
+	u, err := currency.ParseISO(strings.TrimSpace(code))
+	if err != nil {
+		return currency.Unit{}, ErrInvalidCurrency
+	}
+	return u, nil
+}
+
+// IsCurrency reports whether code is an ISO 4217 currency code.
+func IsCurrency(code string) bool {
+	_, err := LoadCurrency(code)
+	return err == nil
+}
+
+// SetSiteFormat makes tag and code the formatting locale and default
+// currency of the templates. Call it at startup and whenever the setting
+// changes. Invalid values leave the current format in place.
+func SetSiteFormat(tag, code string) error {
+	t, err := LoadFormatLocale(tag)
+	if err != nil {
+		return err
+	}
+	u, err := LoadCurrency(code)
+	if err != nil {
+		return err
+	}
+	siteFormat.Store(&SiteFormatConfig{Locale: t, Currency: u})
+	return nil
+}
+
+// SiteFormat returns the site's formatting locale and currency (en-US and
+// USD until set).
+func SiteFormat() SiteFormatConfig {
+	if f := siteFormat.Load(); f != nil {
+		return *f
+	}
+	return SiteFormatConfig{Locale: language.AmericanEnglish, Currency: currency.USD}
+}
+
+// CommonFormatLocales are suggested by the formatting locale field of the
+// settings form; any BCP 47 tag is accepted.
+var CommonFormatLocales = []string{
+	"en-US", "en-GB", "en-IN", "en-AU", "de-DE", "de-CH", "fr-FR", "es-ES",
+	"es-MX", "it-IT", "nl-NL", "pt-BR", "pl-PL", "sv-SE", "ja-JP", "zh-CN",
+}
+
+// CommonCurrencies are suggested by the currency field of the settings form.
+var CommonCurrencies = []string{
+	"USD", "EUR", "GBP", "INR", "JPY", "CNY", "AUD", "CAD", "CHF", "SEK", "BRL", "MXN",
+}
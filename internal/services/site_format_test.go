@@ -0,0 +1,29 @@
+package services_test
+
+import (
+	"testing"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestSiteFormat(t *testing.T) {
+	t.Cleanup(func() { services.SetSiteFormat(services.DefaultFormatLocale, services.DefaultCurrency) })
+
+	if f := services.SiteFormat(); f.Locale != language.AmericanEnglish || f.Currency != currency.USD {
+		t.Fatalf("default format = %v %v", f.Locale, f.Currency)
+	}
+	for _, c := range [][2]string{{"", "EUR"}, {"not a locale", "EUR"}, {"de-DE", ""}, {"de-DE", "XYZ"}} {
+		if err := services.SetSiteFormat(c[0], c[1]); err == nil {
+			t.Errorf("%q / %q should be rejected", c[0], c[1])
+		}
+	}
+	if err := services.SetSiteFormat("de-DE", "eur"); err != nil {
+		t.Fatal(err)
+	}
+	if f := services.SiteFormat(); f.Locale.String() != "de-DE" || f.Currency != currency.EUR {
+		t.Errorf("format = %v %v, want de-DE EUR", f.Locale, f.Currency)
+	}
+}
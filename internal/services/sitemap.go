@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context" // Build cancellation
+	"sync"    // Guards the kept copy
+)
+
+// Sitemap keeps the last /sitemap.xml built by Regenerate, so crawlers are
+// not served a dozen queries per request. Any change to public content
+// drops the copy (see Invalidate), and requests build the sitemap afresh
+// until the next Regenerate. Regenerating on a schedule also picks up what
+// changes with time alone: scheduled blog posts, closing job postings and
+// press releases coming out of embargo.
+type Sitemap struct {
+	build func(ctx context.Context) ([]byte, error) // Renders the sitemap XML
+
+	mu   sync.Mutex
+	body []byte // Kept copy; nil when none is kept
+	gen  int    // Bumped by Invalidate, so a build it overlapped is not kept
+}
+
+// NewSitemap creates the sitemap store.
+//
+// Parameters:
+//   - build: Renders the sitemap XML (see public.SitemapHandler.Build)
+//
+// Returns:
+//   - *Sitemap: Store without a kept copy; Get builds until Regenerate runs
+func NewSitemap(build func(ctx context.Context) ([]byte, error)) *Sitemap {
+	return &Sitemap{build: build}
+}
+
+// Get returns the kept sitemap, or builds one when none is kept.
+func (s *Sitemap) Get(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	body := s.body
+	s.mu.Unlock()
+	if body != nil {
+		return body, nil
+	}
+	return s.build(ctx)
+}
+
+// Regenerate builds the sitemap and keeps it for later requests.
+//
+// Returns:
+//   - int: Size of the sitemap in bytes
+//   - error: Build error; the previous copy is kept
+func (s *Sitemap) Regenerate(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	gen := s.gen
+	s.mu.Unlock()
+
+	body, err := s.build(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	if s.gen == gen {
+		s.body = body
+	}
+	s.mu.Unlock()
+	return len(body), nil
+}
+
+// Invalidate drops the kept copy after a change to public content. A nil
+// Sitemap is ignored.
+func (s *Sitemap) Invalidate() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.body = nil
+	s.gen++
+	s.mu.Unlock()
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Request cancellation for uniqueness checks
+	"errors"  // Exhausted slug candidates
+	"fmt"     // Numbered slug suffixes
+	"strings" // Building slugs
+	"unicode" // Letter, digit and accent classes
+
+	// Third-party imports
+	"golang.org/x/text/unicode/norm" // Splitting accented letters into base letter + accent
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Tables with a UNIQUE slug column, as accepted by SlugService.Unique.
+const (
+	SlugProducts          = "products"
+	SlugProductCategories = "product_categories"
+	SlugSolutions         = "solutions"
+	SlugIndustries        = "industries"
+	SlugPartnerTiers      = "partner_tiers"
+	SlugBlogPosts         = "blog_posts"
+	SlugBlogCategories    = "blog_categories"
+	SlugBlogTags          = "blog_tags"
+	SlugBlogAuthors       = "blog_authors"
+	SlugBlogSeries        = "blog_series"
+	SlugCaseStudies       = "case_studies"
+	SlugWhitepapers       = "whitepapers"
+	SlugWhitepaperTopics  = "whitepaper_topics"
+	SlugEvents            = "events"
+	SlugJobPostings       = "job_postings"
+	SlugPressReleases     = "press_releases"
+	SlugDocSets           = "doc_sets"
+)
+
+// maxSlugLength caps generated slugs; longer ones are cut at a hyphen.
+const maxSlugLength = 80
+
+// maxSlugSuffix bounds the "-N" candidates tried for one slug.
+const maxSlugSuffix = 1000
+
+// fallbackSlug is used when nothing of the text survives Slugify, e.g. a
+// title made only of punctuation or of letters that have no ASCII form.
+const fallbackSlug = "untitled"
+
+// errNoFreeSlug is returned when every "-N" candidate is taken.
+var errNoFreeSlug = errors.New("no free slug")
+
+// slugTransliterations spells out letters that Unicode does not decompose
+// into an ASCII letter plus accents (é does, ß and ø do not).
+var slugTransliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th",
+	'ł': "l", 'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "n", 'ĸ': "k",
+}
+
+// Slugify converts text into a URL slug: lowercase ASCII letters and digits
+// separated by single hyphens. Accents are removed ("Café Crème" ->
+// "cafe-creme"), letters such as ß and æ are transliterated, apostrophes
+// are dropped ("Don't" -> "dont") and any other run of punctuation, spaces
+// or symbols becomes one hyphen. Leading and trailing hyphens are trimmed
+// and the result is cut to 80 characters at a word boundary.
+//
+// Slugify can return "" (e.g. for "!!!"); SlugService.Unique falls back to
+// "untitled" then.
+func Slugify(s string) string {
+	var b strings.Builder
+	separate := false
+	emit := func(part string) {
+		if separate && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		separate = false
+		b.WriteString(part)
+	}
+	for _, r := range norm.NFKD.String(s) {
+		r = unicode.ToLower(r)
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accent split off by NFKD: keep the base letter only
+		case r == '\'' || r == '’':
+			// Apostrophes join the word they are in
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			emit(string(r))
+		case slugTransliterations[r] != "":
+			emit(slugTransliterations[r])
+		default:
+			separate = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return slug
+}
+
+// SlugService picks slugs that no other row of the same table uses, so
+// creates and renames never fail on the UNIQUE constraint.
+type SlugService struct {
+	queries sqlc.Querier // Slug lookups
+}
+
+// NewSlugService creates a slug service.
+func NewSlugService(queries sqlc.Querier) *SlugService {
+	return &SlugService{queries: queries}
+}
+
+// Unique returns Slugify(text), suffixed "-2", "-3", ... when another row
+// of table already uses it. Trashed rows count, as they keep their slug.
+//
+// Parameters:
+//   - table: One of the Slug* table constants
+//   - text: Submitted slug, or the name or title it is generated from
+//   - excludeID: Row being updated, whose own slug is free; 0 when creating
+//
+// Returns:
+//   - string: Free slug
+//   - error: Database error, or errNoFreeSlug after 1000 candidates
+func (s *SlugService) Unique(ctx context.Context, table, text string, excludeID int64) (string, error) {
+	base := Slugify(text)
+	if base == "" {
+		base = fallbackSlug
+	}
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+		taken, err := s.queries.SlugTaken(ctx, sqlc.SlugTakenParams{TableName: table, Slug: candidate, ExcludeID: excludeID})
+		if err != nil {
+			return "", err
+		}
+		if taken == 0 {
+			return candidate, nil
+		}
+	}
+	return "", errNoFreeSlug
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Product Category Name":      "product-category-name",
+		"  Café Crème  ":             "cafe-creme",
+		"Straße & Smørrebrød":        "strasse-smorrebrod",
+		"Don't Stop -- Believing!":   "dont-stop-believing",
+		"Ångström_Meter (v2.0)":      "angstrom-meter-v2-0",
+		"already-a-slug":             "already-a-slug",
+		"Łódź — Poland’s ﬁnest":      "lodz-polands-finest",
+		"!!!":                        "",
+		"日本語":                        "",
+		"IP67 Sensor, 24 V / 4–20mA": "ip67-sensor-24-v-4-20ma",
+	}
+	for in, want := range tests {
+		if got := services.Slugify(in); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	long := services.Slugify(strings.Repeat("word ", 40))
+	if len(long) > 80 || strings.HasSuffix(long, "-") || !strings.HasPrefix(long, "word-word") {
+		t.Errorf("long slug not cut at a word boundary: %q", long)
+	}
+}
+
+func TestSlugService_Unique(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	slugs := services.NewSlugService(queries)
+
+	unique := func(text string, id int64) string {
+		t.Helper()
+		slug, err := slugs.Unique(ctx, services.SlugBlogTags, text, id)
+		if err != nil {
+			t.Fatalf("Unique(%q): %v", text, err)
+		}
+		return slug
+	}
+
+	first, _ := queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Café", Slug: unique("Café", 0)})
+	if first.Slug != "cafe" {
+		t.Fatalf("first slug = %q", first.Slug)
+	}
+	queries.CreateBlogTag(ctx, sqlc.CreateBlogTagParams{Name: "Cafe", Slug: unique("Cafe", 0)})
+	if got := unique("CAFE!", 0); got != "cafe-3" {
+		t.Errorf("third slug = %q, want cafe-3", got)
+	}
+
+	// A row keeps its own slug when updated
+	if got := unique("Café", first.ID); got != "cafe" {
+		t.Errorf("update of the first tag = %q, want cafe", got)
+	}
+
+	// Slugs are unique per table, and empty ones fall back to a placeholder
+	if got, _ := slugs.Unique(ctx, services.SlugProducts, "Cafe", 0); got != "cafe" {
+		t.Errorf("product slug = %q, want cafe", got)
+	}
+	if got := unique("???", 0); got != "untitled" {
+		t.Errorf("empty slug = %q, want untitled", got)
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes"         // Re-encoding images
+	"fmt"           // Page numbers
+	"image"         // Decoding product images and the logo
+	"image/color"   // White background behind transparent images
+	"image/draw"    // Flattening transparency
+	"image/jpeg"    // Images are embedded as JPEG
+	_ "image/png"   // PNG decoder
+	"os"            // Reading images from the upload directory
+	"path/filepath" // Mapping /uploads URLs to files
+	"strings"       // Upload path checks and labels
+
+	// Internal imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Settings for the branding
+)
+
+// specSheetImageWidth is the pixel width images are scaled down to before
+// embedding; enough for print at the sizes drawn, small enough to keep the
+// file light.
+const specSheetImageWidth = 800
+
+// Layout of the datasheet, in points.
+const (
+	specSheetMargin = 48.0
+	specSheetBottom = 64.0 // Lowest baseline above the footer
+)
+
+var (
+	specSheetText  = pdfRGB{0.10, 0.12, 0.18}
+	specSheetMuted = pdfRGB{0.42, 0.45, 0.50}
+	specSheetRule  = pdfRGB{0.82, 0.84, 0.87}
+	specSheetShade = pdfRGB{0.96, 0.96, 0.97}
+)
+
+// SpecSheets renders the printable PDF datasheet of a product served at
+// /products/:category/:slug/spec-sheet.pdf: the product's name, overview,
+// main image, features, specifications by section and certifications,
+// branded with the site's logo, primary theme color and contact details.
+// Images are read from the upload directory; images elsewhere and formats
+// the standard library cannot decode (such as WebP) are left out.
+type SpecSheets struct {
+	uploadDir string // Directory served at /uploads
+}
+
+// NewSpecSheets creates a SpecSheets that reads images from uploadDir.
+func NewSpecSheets(uploadDir string) *SpecSheets {
+	return &SpecSheets{uploadDir: uploadDir}
+}
+
+// Render returns the datasheet of a product as a PDF.
+//
+// Parameters:
+//   - detail: Product with its images, features, specs and certifications
+//     (with ApplyVariant already applied for a variant's sheet)
+//   - settings: Site settings for the name, logo, colors and contact details
+//   - pageURL: Absolute URL of the product page, printed in the footer
+func (s *SpecSheets) Render(detail *ProductDetail, settings sqlc.Setting, pageURL string) []byte {
+	w := newPDFWriter(detail.Product.Name + " - " + settings.SiteName)
+	l := &specSheetLayout{
+		w:     w,
+		brand: pdfHexColor(ThemeFromSettings(settings).Light.Primary, pdfRGB{0, 0.4, 0.8}),
+		width: pdfPageWidth - 2*specSheetMargin,
+	}
+
+	// Header: logo or site name, and the SKU
+	l.newPage()
+	if logo, ok := s.loadImage(w, settings.HeaderLogoPath); ok {
+		height := 28.0
+		width := height * float64(logo.width) / float64(logo.height)
+		if width > 180 {
+			width, height = 180, 180*float64(logo.height)/float64(logo.width)
+		}
+		w.drawImage(logo.index, specSheetMargin, l.y-height, width, height)
+	} else {
+		w.text(specSheetMargin, l.y-18, 16, true, specSheetText, settings.SiteName)
+	}
+	l.rightText(l.y-10, 8, true, specSheetMuted, "PRODUCT DATASHEET")
+	sku := detail.Product.Sku
+	if detail.Variant != nil {
+		sku = detail.Variant.Sku
+	}
+	l.rightText(l.y-24, 10, true, specSheetText, "SKU "+sku)
+	l.y -= 40
+	w.line(specSheetMargin, l.y, pdfPageWidth-specSheetMargin, l.y, 0.75, specSheetRule)
+	l.y -= 28
+
+	// Title
+	w.text(specSheetMargin, l.y, 9, true, l.brand, strings.ToUpper(detail.Category.Name))
+	l.y -= 26
+	for _, line := range pdfWrap(detail.Product.Name, 24, true, l.width) {
+		w.text(specSheetMargin, l.y, 24, true, specSheetText, line)
+		l.y -= 28
+	}
+	if detail.Product.Tagline.Valid && detail.Product.Tagline.String != "" {
+		l.paragraph(detail.Product.Tagline.String, 12, true, specSheetMuted, specSheetMargin, l.width, 16)
+	}
+	l.y -= 10
+
+	// Overview next to the main image
+	overview := detail.Product.Description
+	if detail.Product.Overview.Valid && detail.Product.Overview.String != "" {
+		overview = detail.Product.Overview.String
+	}
+	top, textWidth := l.y, l.width
+	imageBottom := l.y
+	if img, ok := s.loadImage(w, specSheetImagePath(detail)); ok {
+		size := 190.0
+		width, height := size, size*float64(img.height)/float64(img.width)
+		if height > size {
+			width, height = size*float64(img.width)/float64(img.height), size
+		}
+		x := pdfPageWidth - specSheetMargin - width
+		w.drawImage(img.index, x, top-height, width, height)
+		imageBottom = top - height
+		textWidth = l.width - size - 20
+	}
+	l.y = top - 2
+	firstPage := len(w.pages)
+	l.paragraph(overview, 10, false, specSheetText, specSheetMargin, textWidth, 14)
+	if len(w.pages) == firstPage {
+		l.y = min(l.y, imageBottom)
+	}
+	l.y -= 20
+
+	// Features
+	if len(detail.Features) > 0 {
+		l.heading("Key Features")
+		for _, f := range detail.Features {
+			l.bullet(f.FeatureText)
+		}
+		l.y -= 12
+	}
+
+	// Specifications, grouped by section in display order
+	if len(detail.Specs) > 0 {
+		l.heading("Specifications")
+		var sections []string
+		bySection := map[string][]sqlc.ProductSpec{}
+		for _, spec := range detail.Specs {
+			if _, ok := bySection[spec.SectionName]; !ok {
+				sections = append(sections, spec.SectionName)
+			}
+			bySection[spec.SectionName] = append(bySection[spec.SectionName], spec)
+		}
+		for _, section := range sections {
+			if section != "" {
+				l.ensure(40)
+				w.text(specSheetMargin, l.y, 10, true, l.brand, strings.ToUpper(section))
+				l.y -= 8
+			}
+			for i, spec := range bySection[section] {
+				l.specRow(spec.SpecKey, spec.SpecValue, i%2 == 0)
+			}
+			l.y -= 14
+		}
+	}
+
+	// Certifications
+	if len(detail.Certifications) > 0 {
+		l.heading("Certifications")
+		for _, cert := range detail.Certifications {
+			label := cert.CertificationName
+			if cert.CertificationCode.Valid && cert.CertificationCode.String != "" {
+				label += " (" + cert.CertificationCode.String + ")"
+			}
+			l.bullet(label)
+		}
+	}
+
+	// Footer on every page, now that the page count is known
+	contact := settings.SiteName
+	for _, part := range []string{settings.ContactEmail, settings.ContactPhone} {
+		if part != "" {
+			contact += "  |  " + part
+		}
+	}
+	revision := "Rev. " + detail.Product.UpdatedAt.Format("2006-01-02")
+	for i := range w.pages {
+		w.selectPage(i)
+		w.line(specSheetMargin, 46, pdfPageWidth-specSheetMargin, 46, 0.75, specSheetRule)
+		w.text(specSheetMargin, 34, 8, true, specSheetText, contact)
+		w.text(specSheetMargin, 23, 7.5, false, specSheetMuted, pageURL)
+		l.rightText(23, 7.5, false, specSheetMuted, fmt.Sprintf("%s  |  Page %d of %d", revision, i+1, len(w.pages)))
+	}
+	return w.bytes()
+}
+
+// specSheetImagePath returns the image shown on the datasheet: the gallery
+// thumbnail, else the first gallery image, else the product's primary image.
+func specSheetImagePath(detail *ProductDetail) string {
+	first := ""
+	for _, img := range detail.Images {
+		if img.MediaType != ProductMediaImage {
+			continue
+		}
+		if img.IsThumbnail {
+			return img.ImagePath
+		}
+		if first == "" {
+			first = img.ImagePath
+		}
+	}
+	if first == "" && detail.Product.PrimaryImage.Valid {
+		first = detail.Product.PrimaryImage.String
+	}
+	return first
+}
+
+// specSheetImage is an image added to the document.
+type specSheetImage struct {
+	index         int // For pdfWriter.drawImage
+	width, height int // Pixel size, for the aspect ratio
+}
+
+// loadImage reads an image under /uploads, flattens it onto white, scales
+// it down and adds it to the document as a JPEG. It reports false for empty
+// paths, paths outside the upload directory and images it cannot decode.
+func (s *SpecSheets) loadImage(w *pdfWriter, urlPath string) (specSheetImage, bool) {
+	rel, ok := strings.CutPrefix(urlPath, "/uploads/")
+	if !ok || s.uploadDir == "" {
+		return specSheetImage{}, false
+	}
+	rel = filepath.Clean(filepath.FromSlash(rel))
+	if !filepath.IsLocal(rel) {
+		return specSheetImage{}, false
+	}
+	f, err := os.Open(filepath.Join(s.uploadDir, rel))
+	if err != nil {
+		return specSheetImage{}, false
+	}
+	defer f.Close()
+	src, _, err := image.Decode(f)
+	if err != nil || src.Bounds().Dx() == 0 || src.Bounds().Dy() == 0 {
+		return specSheetImage{}, false
+	}
+
+	resized := ResizeImage(src, specSheetImageWidth)
+	flat := image.NewRGBA(resized.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), resized, resized.Bounds().Min, draw.Over)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: 85}); err != nil {
+		return specSheetImage{}, false
+	}
+	b := flat.Bounds()
+	return specSheetImage{index: w.addJPEG(buf.Bytes(), b.Dx(), b.Dy()), width: b.Dx(), height: b.Dy()}, true
+}
+
+// specSheetLayout tracks the drawing position while the datasheet flows
+// over its pages.
+type specSheetLayout struct {
+	w     *pdfWriter
+	brand pdfRGB  // Primary theme color
+	width float64 // Width between the margins
+	y     float64 // Baseline of the next line
+}
+
+// newPage starts a page with the brand stripe along its top edge.
+func (l *specSheetLayout) newPage() {
+	l.w.addPage()
+	l.w.rect(0, pdfPageHeight-8, pdfPageWidth, 8, l.brand)
+	l.y = pdfPageHeight - 40
+}
+
+// ensure starts a new page unless height points fit above the footer.
+func (l *specSheetLayout) ensure(height float64) {
+	if l.y-height < specSheetBottom {
+		l.newPage()
+	}
+}
+
+// rightText draws s right-aligned at the right margin.
+func (l *specSheetLayout) rightText(y, size float64, bold bool, color pdfRGB, s string) {
+	l.w.text(pdfPageWidth-specSheetMargin-pdfTextWidth(s, size, bold), y, size, bold, color, s)
+}
+
+// heading draws a section heading with a rule in the brand color.
+func (l *specSheetLayout) heading(title string) {
+	l.ensure(60)
+	l.w.text(specSheetMargin, l.y, 11, true, specSheetText, strings.ToUpper(title))
+	l.y -= 6
+	l.w.line(specSheetMargin, l.y, specSheetMargin+l.width, l.y, 1.5, l.brand)
+	l.y -= 18
+}
+
+// paragraph draws wrapped text, breaking pages as needed.
+func (l *specSheetLayout) paragraph(text string, size float64, bold bool, color pdfRGB, x, width, leading float64) {
+	for _, line := range pdfWrap(text, size, bold, width) {
+		l.ensure(leading)
+		l.w.text(x, l.y, size, bold, color, line)
+		l.y -= leading
+	}
+}
+
+// bullet draws a list item with a square marker in the brand color.
+func (l *specSheetLayout) bullet(text string) {
+	l.ensure(14)
+	l.w.rect(specSheetMargin+2, l.y+2.5, 4, 4, l.brand)
+	l.paragraph(text, 10, false, specSheetText, specSheetMargin+14, l.width-14, 14)
+	l.y -= 2
+}
+
+// specRow draws one specification as a key/value table row.
+func (l *specSheetLayout) specRow(key, value string, shaded bool) {
+	keyWidth := l.width * 0.38
+	keys := pdfWrap(key, 9, true, keyWidth-16)
+	values := pdfWrap(value, 9, false, l.width-keyWidth-8)
+	rows := max(len(keys), len(values))
+	height := float64(rows)*12 + 8
+	l.ensure(height)
+	top := l.y
+	if shaded {
+		l.w.rect(specSheetMargin, top-height, l.width, height, specSheetShade)
+	}
+	for i := 0; i < rows; i++ {
+		baseline := top - 14 - float64(i)*12
+		if i < len(keys) {
+			l.w.text(specSheetMargin+8, baseline, 9, true, specSheetMuted, keys[i])
+		}
+		if i < len(values) {
+			l.w.text(specSheetMargin+keyWidth, baseline, 9, false, specSheetText, values[i])
+		}
+	}
+	l.y = top - height
+}
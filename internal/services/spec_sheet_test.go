@@ -0,0 +1,111 @@
+package services_test
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+// checkPDFStructure checks that every cross-reference entry points at its
+// object and startxref points at the table.
+func checkPDFStructure(t *testing.T, pdf []byte) {
+	t.Helper()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, pdf[off:off+10])
+		}
+	}
+}
+
+func TestSpecSheets_Render(t *testing.T) {
+	uploadDir := t.TempDir()
+	os.MkdirAll(filepath.Join(uploadDir, "products"), 0o755)
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		img.Set(x, 5, color.NRGBA{R: 255, A: 128})
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	os.WriteFile(filepath.Join(uploadDir, "products", "ts-100.png"), buf.Bytes(), 0o644)
+
+	detail := &services.ProductDetail{
+		Product: sqlc.Product{
+			Sku: "TS-100", Name: "TS 100 (Pro)", Description: "Thermal sensor for –40 to 125 °C.",
+			Tagline: sql.NullString{String: "Rugged and exact", Valid: true}, UpdatedAt: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		Category: sqlc.ProductCategory{Name: "Sensors"},
+		Images:   []sqlc.ProductImage{{ImagePath: "/uploads/products/ts-100.png", MediaType: services.ProductMediaImage}},
+		Features: []sqlc.ProductFeature{{FeatureText: "IP67 housing"}},
+		Specs: []sqlc.ProductSpec{
+			{SectionName: "Electrical", SpecKey: "Voltage", SpecValue: "24V DC"},
+			{SectionName: "Mechanical", SpecKey: "Weight", SpecValue: "120 g"},
+			{SectionName: "Electrical", SpecKey: "Current", SpecValue: "20 mA"},
+		},
+		Certifications: []sqlc.ProductCertification{{CertificationName: "CE", CertificationCode: sql.NullString{String: "EN 61326", Valid: true}}},
+	}
+	settings := sqlc.Setting{SiteName: "Bluejay Labs", ContactEmail: "sales@example.com", ThemeLightPrimary: "#FF0000"}
+	sheets := services.NewSpecSheets(uploadDir)
+
+	pdf := sheets.Render(detail, settings, "https://example.com/products/sensors/ts-100")
+	checkPDFStructure(t, pdf)
+	for _, want := range []string{
+		"(TS 100 \\(Pro\\)) Tj", "(SKU TS-100) Tj", "(24V DC) Tj", "(IP67 housing) Tj", "(CE \\(EN 61326\\)) Tj",
+		"(ELECTRICAL) Tj", "(Bluejay Labs  |  sales@example.com) Tj", "(Rev. 2026-03-04  |  Page 1 of 1) Tj",
+		"/Filter /DCTDecode", "1.000 0.000 0.000 rg", // brand color from the theme
+		"\x96\x34\x30 to 125 \xb0C", // Windows-1252
+	} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("datasheet lacks %q", want)
+		}
+	}
+	// Sections keep their first appearance order, with all their specs
+	electrical, mechanical, current := bytes.Index(pdf, []byte("(ELECTRICAL)")), bytes.Index(pdf, []byte("(MECHANICAL)")), bytes.Index(pdf, []byte("(20 mA)"))
+	if !(electrical < current && current < mechanical) {
+		t.Error("specs not grouped by section in order")
+	}
+
+	// Long spec lists flow onto more pages, each with a footer
+	for i := 0; i < 80; i++ {
+		detail.Specs = append(detail.Specs, sqlc.ProductSpec{SectionName: "Registers", SpecKey: fmt.Sprintf("Register %d", i), SpecValue: strings.Repeat("word ", 30)})
+	}
+	pdf = sheets.Render(detail, settings, "https://example.com/products/sensors/ts-100")
+	checkPDFStructure(t, pdf)
+	pages := bytes.Count(pdf, []byte("/Type /Page "))
+	if pages < 3 || !bytes.Contains(pdf, []byte(fmt.Sprintf("Page %d of %d) Tj", pages, pages))) {
+		t.Errorf("long datasheet has %d pages", pages)
+	}
+
+	// Images outside the upload directory are never read
+	for _, path := range []string{"/uploads/../spec_sheet_test.go", "https://example.com/a.png", "/uploads/products/missing.png"} {
+		detail.Images[0].ImagePath = path
+		if bytes.Contains(sheets.Render(detail, settings, ""), []byte("DCTDecode")) {
+			t.Errorf("image %q embedded", path)
+		}
+	}
+}
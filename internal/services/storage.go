@@ -0,0 +1,213 @@
+package services
+
+import (
+	// Standard library imports for file persistence and error handling
+	"context"       // Allows callers to cancel long-running storage operations
+	"errors"        // Sentinel errors for storage conflicts
+	"fmt"           // Error wrapping with operation context
+	"io"            // Reader/writer interfaces for streaming object contents
+	"os"            // File system access for the local storage backend
+	"path/filepath" // Safe, cross-platform path construction
+	"strings"       // Key validation (rejecting path traversal)
+	"time"          // Object modification times
+)
+
+// ErrObjectExists is returned by Storage.Put when an object with the same key has
+// already been written. Storage is write-once so archived data cannot be replaced.
+var ErrObjectExists = errors.New("storage: object already exists")
+
+// Storage is the backend used for durable, write-once objects such as compliance
+// archives. Keys are slash-separated relative paths (e.g. "activity_log/2025-01.jsonl.gz").
+// Implementations must refuse to overwrite an existing key.
+type Storage interface {
+	// Put stores the contents of r under key, returning ErrObjectExists if the key
+	// is already present.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open returns a reader for the object stored under key. Callers must close it.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ObjectInfo describes a stored object, as returned by LocalStorage.List.
+type ObjectInfo struct {
+	Key     string    // Relative object key
+	Size    int64     // Size in bytes
+	ModTime time.Time // When the object was written
+}
+
+// LocalStorage is a Storage backed by a directory on the local file system.
+// Objects are written atomically (temp file + rename) and made read-only once
+// stored, so the directory behaves as an append-only archive.
+type LocalStorage struct {
+	root string // Base directory under which all object keys are resolved
+}
+
+// NewLocalStorage creates a LocalStorage rooted at the given directory.
+// The directory is created lazily on the first Put.
+//
+// Parameters:
+//   - root: Base directory for stored objects (e.g., "data/archives")
+//
+// Returns:
+//   - *LocalStorage: Storage backend ready for use
+func NewLocalStorage(root string) *LocalStorage {
+	return &LocalStorage{root: root}
+}
+
+// path resolves a storage key to a file path inside the root directory,
+// rejecting absolute keys and keys that would escape the root.
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(s.root, clean), nil
+}
+
+// Put writes the object to a temporary file next to its final location and then
+// links it into place, so readers never observe a partially written object and an
+// existing object is never replaced.
+//
+// Parameters:
+//   - ctx: Context checked before the write begins
+//   - key: Relative object key
+//   - r: Object contents
+//
+// Returns:
+//   - error: ErrObjectExists if the key is taken, or any I/O error
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	dst, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return ErrObjectExists
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("storage: create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".put-*")
+	if err != nil {
+		return fmt.Errorf("storage: create temp file: %w", err)
+	}
+	// Always remove the temp name; after a successful link the object lives on under dst
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("storage: write object: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("storage: sync object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("storage: close object: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0444); err != nil {
+		return fmt.Errorf("storage: make object read-only: %w", err)
+	}
+
+	// os.Link fails if dst exists, which closes the race between the Stat above and now
+	if err := os.Link(tmp.Name(), dst); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrObjectExists
+		}
+		return fmt.Errorf("storage: link object: %w", err)
+	}
+	return nil
+}
+
+// Open returns a reader for a stored object.
+//
+// Parameters:
+//   - ctx: Context checked before opening
+//   - key: Relative object key
+//
+// Returns:
+//   - io.ReadCloser: Object contents (caller must Close)
+//   - error: os.ErrNotExist-wrapped error if the object is missing
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+// Delete removes a stored object; a missing object is not an error. The
+// archive never deletes, but short-lived objects such as export files do
+// once their download link expires (see ExportStorage).
+//
+// Parameters:
+//   - ctx: Context checked before deleting
+//   - key: Relative object key
+//
+// Returns:
+//   - error: Invalid key or I/O error
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("storage: delete object: %w", err)
+	}
+	return nil
+}
+
+// List returns the objects whose keys start with prefix, sorted by key. Temp
+// files of writes in progress are skipped. A root that does not exist yet
+// (nothing was ever stored) lists as empty.
+//
+// Parameters:
+//   - ctx: Context checked before listing
+//   - prefix: Key prefix to match ("" for every object)
+//
+// Returns:
+//   - []ObjectInfo: Matching objects
+//   - error: I/O error while walking the root
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var objects []ObjectInfo
+	err := filepath.WalkDir(s.root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			if p == s.root && errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".put-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: list objects: %w", err)
+	}
+	return objects, nil
+}
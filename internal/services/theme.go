@@ -0,0 +1,211 @@
+package services
+
+import (
+	// Standard library imports
+	"crypto/sha256" // Stylesheet ETag
+	"encoding/hex"  // ETag encoding
+	"fmt"           // Stylesheet output
+	"regexp"        // Color validation
+	"strings"       // Stylesheet building
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Theme modes stored in settings.theme_mode. The mode decides what visitors
+// see until they pick a theme with the header toggle.
+const (
+	ThemeLight  = "light"
+	ThemeDark   = "dark"
+	ThemeSystem = "system" // Follow the browser's prefers-color-scheme
+)
+
+// ThemeModes lists the accepted values of settings.theme_mode.
+var ThemeModes = []string{ThemeLight, ThemeDark, ThemeSystem}
+
+// themeColorPattern matches the #RRGGBB colors produced by <input type="color">.
+var themeColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// IsThemeColor reports whether s is a #RRGGBB hex color.
+func IsThemeColor(s string) bool {
+	return themeColorPattern.MatchString(s)
+}
+
+// ThemeColors holds the tokens of one mode.
+type ThemeColors struct {
+	Primary      string // Buttons, links and brand accents
+	PrimaryHover string // Hover state of primary buttons
+	Background   string // Page background
+	Surface      string // Cards, panels and inputs
+	Text         string // Body text
+	Border       string // Borders and the hard drop shadows
+}
+
+// Theme is the token set served to the public and admin layouts as CSS
+// variables (see Theme.CSS).
+type Theme struct {
+	Mode  string
+	Light ThemeColors
+	Dark  ThemeColors
+}
+
+// DefaultTheme matches the column defaults of migration 053. The light
+// colors are the ones the templates were designed with.
+var DefaultTheme = Theme{
+	Mode: ThemeLight,
+	Light: ThemeColors{
+		Primary:      "#0066CC",
+		PrimaryHover: "#004499",
+		Background:   "#FFFFFF",
+		Surface:      "#FFFFFF",
+		Text:         "#000000",
+		Border:       "#000000",
+	},
+	Dark: ThemeColors{
+		Primary:      "#4D9FFF",
+		PrimaryHover: "#80BAFF",
+		Background:   "#0F1115",
+		Surface:      "#1A1D23",
+		Text:         "#E6E8EB",
+		Border:       "#4A505A",
+	},
+}
+
+// ThemeFromSettings builds the theme configured under Global Settings.
+// Blank or invalid values fall back to DefaultTheme, so a bad value can
+// never break the generated stylesheet.
+func ThemeFromSettings(s sqlc.Setting) Theme {
+	t := DefaultTheme
+	for _, m := range ThemeModes {
+		if s.ThemeMode == m {
+			t.Mode = m
+		}
+	}
+	t.Light = themeColors(t.Light, s.ThemeLightPrimary, s.ThemeLightPrimaryHover, s.ThemeLightBackground,
+		s.ThemeLightSurface, s.ThemeLightText, s.ThemeLightBorder)
+	t.Dark = themeColors(t.Dark, s.ThemeDarkPrimary, s.ThemeDarkPrimaryHover, s.ThemeDarkBackground,
+		s.ThemeDarkSurface, s.ThemeDarkText, s.ThemeDarkBorder)
+	return t
+}
+
+// themeColors overrides the tokens of def with every valid color given, in
+// ThemeColors field order.
+func themeColors(def ThemeColors, colors ...string) ThemeColors {
+	fields := []*string{&def.Primary, &def.PrimaryHover, &def.Background, &def.Surface, &def.Text, &def.Border}
+	for i, c := range colors {
+		if IsThemeColor(c) {
+			*fields[i] = strings.ToUpper(c)
+		}
+	}
+	return def
+}
+
+// themeRule is one rule of the generated stylesheet. Selectors are relative
+// to a theme root and are prefixed with it when written.
+type themeRule struct {
+	selectors []string
+	decls     string
+}
+
+// brandRules recolor the brand utility classes hard-coded across the
+// templates (Tailwind's bg-[#0066CC] and friends) with the primary tokens,
+// so a custom primary color applies in both modes. They are written under
+// :root to outrank the utilities Tailwind injects after the stylesheet.
+var brandRules = []themeRule{
+	{[]string{`.text-\[\#0066CC\]`, `.text-primary`, `.hover\:text-\[\#0066CC\]:hover`, `.group:hover .group-hover\:text-\[\#0066CC\]`},
+		"color: var(--color-primary);"},
+	{[]string{`.hover\:text-\[\#004499\]:hover`},
+		"color: var(--color-primary-hover);"},
+	{[]string{`.bg-\[\#0066CC\]`, `.bg-primary`, `.hover\:bg-\[\#0066CC\]:hover`, `.group:hover .group-hover\:bg-\[\#0066CC\]`},
+		"background-color: var(--color-primary);"},
+	{[]string{`.bg-primary\/10`},
+		"background-color: color-mix(in srgb, var(--color-primary) 10%, transparent);"},
+	{[]string{`.bg-\[\#004499\]`, `.hover\:bg-\[\#004499\]:hover`},
+		"background-color: var(--color-primary-hover);"},
+	{[]string{`.border-\[\#0066CC\]`, `.border-primary`, `.focus\:border-\[\#0066CC\]:focus`},
+		"border-color: var(--color-primary);"},
+}
+
+// darkRules remap the light utility classes the templates are built from
+// (white surfaces, black and gray text, black borders) to the dark tokens.
+var darkRules = []themeRule{
+	{[]string{"body", "body.bg-white", "body.bg-gray-50"},
+		"background-color: var(--color-background); color: var(--color-text);"},
+	{[]string{".bg-white", ".bg-gray-50", ".bg-gray-100", `.bg-\[\#F8F9FA\]`, `.bg-\[\#F5F5F0\]`, ".bg-background-light"},
+		"background-color: var(--color-surface);"},
+	{[]string{".hover\\:bg-gray-50:hover", ".hover\\:bg-gray-100:hover"},
+		"background-color: color-mix(in srgb, var(--color-text) 8%, var(--color-surface));"},
+	{[]string{".text-black", ".text-gray-900", ".text-gray-800", ".text-gray-700", ".text-text-primary", `.text-\[\#1a1f2e\]`, ".hover\\:text-black:hover"},
+		"color: var(--color-text);"},
+	{[]string{".text-gray-600", ".text-gray-500", ".text-gray-400", ".text-text-secondary"},
+		"color: var(--color-text-muted);"},
+	{[]string{".border-black", ".border-gray-100", ".border-gray-200", ".border-gray-300"},
+		"border-color: var(--color-border);"},
+	{[]string{"input", "select", "textarea"},
+		"background-color: var(--color-surface); color: var(--color-text); border-color: var(--color-border);"},
+}
+
+// CSS renders the theme as a stylesheet: the tokens of each mode as CSS
+// variables, plus the rules mapping the templates' utility classes onto them.
+//
+// An explicit choice is applied by setting data-theme="light" or "dark" on
+// <html> (public/js/theme.js does this from the "theme" cookie). Without
+// one, the configured mode applies: light, dark, or the browser preference
+// for system. Any element with data-theme gets that mode's variables, which
+// is how the admin settings page previews both modes side by side.
+func (t Theme) CSS() string {
+	var b strings.Builder
+	b.WriteString("/* Theme tokens - generated from Global Settings > Theme */\n")
+
+	darkRoots := []string{`[data-theme="dark"]`}
+	if t.Mode == ThemeDark {
+		darkRoots = append(darkRoots, `:root:not([data-theme])`)
+	}
+	writeThemeTokens(&b, []string{":root", `[data-theme="light"]`}, "light", t.Light)
+	writeThemeTokens(&b, darkRoots, "dark", t.Dark)
+	writeThemeRules(&b, []string{":root"}, brandRules)
+	writeThemeRules(&b, darkRoots, darkRules)
+
+	if t.Mode == ThemeSystem {
+		b.WriteString("@media (prefers-color-scheme: dark) {\n")
+		writeThemeTokens(&b, []string{`:root:not([data-theme])`}, "dark", t.Dark)
+		writeThemeRules(&b, []string{`:root:not([data-theme])`}, darkRules)
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// ETag returns a strong validator for the rendered stylesheet.
+func (t Theme) ETag() string {
+	sum := sha256.Sum256([]byte(t.CSS()))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func writeThemeTokens(b *strings.Builder, roots []string, scheme string, c ThemeColors) {
+	fmt.Fprintf(b, "%s {\n", strings.Join(roots, ",\n"))
+	fmt.Fprintf(b, "    color-scheme: %s;\n", scheme)
+	fmt.Fprintf(b, "    --color-primary: %s;\n", c.Primary)
+	fmt.Fprintf(b, "    --color-primary-hover: %s;\n", c.PrimaryHover)
+	fmt.Fprintf(b, "    --color-background: %s;\n", c.Background)
+	fmt.Fprintf(b, "    --color-surface: %s;\n", c.Surface)
+	fmt.Fprintf(b, "    --color-text: %s;\n", c.Text)
+	b.WriteString("    --color-text-muted: color-mix(in srgb, var(--color-text) 65%, var(--color-surface));\n")
+	fmt.Fprintf(b, "    --color-border: %s;\n", c.Border)
+	// Variables used by styles.css and admin-styles.css
+	b.WriteString("    --primary: var(--color-primary);\n")
+	b.WriteString("    --primary-dark: var(--color-primary-hover);\n")
+	b.WriteString("    --black: var(--color-border);\n")
+	b.WriteString("}\n")
+}
+
+func writeThemeRules(b *strings.Builder, roots []string, rules []themeRule) {
+	for _, r := range rules {
+		var sels []string
+		for _, root := range roots {
+			for _, s := range r.selectors {
+				sels = append(sels, root+" "+s)
+			}
+		}
+		fmt.Fprintf(b, "%s {\n    %s\n}\n", strings.Join(sels, ",\n"), r.decls)
+	}
+}
@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestThemeFromSettings(t *testing.T) {
+	theme := services.ThemeFromSettings(sqlc.Setting{
+		ThemeMode:         "system",
+		ThemeLightPrimary: "#ff6600",
+		ThemeLightText:    "red",
+		ThemeDarkSurface:  "#222222",
+		ThemeDarkBorder:   "#12345",
+	})
+	if theme.Mode != services.ThemeSystem {
+		t.Errorf("mode = %q", theme.Mode)
+	}
+	if theme.Light.Primary != "#FF6600" || theme.Dark.Surface != "#222222" {
+		t.Errorf("valid colors not applied: %+v", theme)
+	}
+	if theme.Light.Text != services.DefaultTheme.Light.Text || theme.Dark.Border != services.DefaultTheme.Dark.Border {
+		t.Errorf("invalid colors should fall back to the defaults: %+v", theme)
+	}
+	if theme.Light.Background != services.DefaultTheme.Light.Background {
+		t.Error("blank colors should fall back to the defaults")
+	}
+
+	if got := services.ThemeFromSettings(sqlc.Setting{ThemeMode: "neon"}).Mode; got != services.ThemeLight {
+		t.Errorf("unknown mode = %q, want light", got)
+	}
+}
+
+func TestThemeCSS_Modes(t *testing.T) {
+	theme := services.DefaultTheme
+	theme.Light.Primary = "#FF6600"
+
+	css := theme.CSS()
+	for _, want := range []string{
+		"--color-primary: #FF6600;",
+		"--color-primary: " + theme.Dark.Primary + ";",
+		`[data-theme="dark"] .bg-white`,
+		`:root .bg-\[\#0066CC\]`,
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("light mode CSS missing %q", want)
+		}
+	}
+	if strings.Contains(css, ":root:not([data-theme])") || strings.Contains(css, "@media") {
+		t.Error("light mode should not apply dark tokens without an explicit choice")
+	}
+
+	theme.Mode = services.ThemeDark
+	if css := theme.CSS(); !strings.Contains(css, ":root:not([data-theme]) .bg-white") || strings.Contains(css, "@media") {
+		t.Error("dark mode should apply dark tokens without an explicit choice")
+	}
+
+	theme.Mode = services.ThemeSystem
+	css = theme.CSS()
+	media := strings.Index(css, "@media (prefers-color-scheme: dark)")
+	if media < 0 || !strings.Contains(css[media:], ":root:not([data-theme]) .bg-white") {
+		t.Error("system mode should follow prefers-color-scheme")
+	}
+
+	if theme.ETag() == services.DefaultTheme.ETag() {
+		t.Error("ETag should change with the theme")
+	}
+}
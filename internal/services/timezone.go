@@ -0,0 +1,170 @@
+package services
+
+import (
+	// Standard library imports
+	"errors"      // Timezone validation errors
+	"sort"        // Accept-Language quality ordering
+	"strconv"     // Accept-Language quality values
+	"strings"     // Header and tag parsing
+	"sync/atomic" // Site timezone shared with the template functions
+	"time"        // Zone loading and conversion
+)
+
+// DefaultTimezone is the site timezone until one is configured under
+// Global Settings. Timestamps are always stored in UTC.
+const DefaultTimezone = "UTC"
+
+// ErrInvalidTimezone is returned for names that are not IANA time zones.
+var ErrInvalidTimezone = errors.New("unknown time zone")
+
+// siteLocation is the zone timestamps are displayed in. It is read on every
+// template render and replaced when the setting changes.
+var siteLocation atomic.Pointer[time.Location]
+
+// LoadTimezone resolves an IANA zone name such as "Europe/Berlin". Unlike
+// time.LoadLocation it rejects "" and "Local", which would silently mean UTC
+// or whatever zone the server happens to run in.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// IsTimezone reports whether name is an IANA time zone.
+func IsTimezone(name string) bool {
+	_, err := LoadTimezone(name)
+	return err == nil
+}
+
+// SetSiteTimezone makes name the zone used to display timestamps and to read
+// admin date inputs. Call it at startup and whenever the setting changes.
+// An unknown name leaves the current zone in place.
+func SetSiteTimezone(name string) error {
+	loc, err := LoadTimezone(name)
+	if err != nil {
+		return err
+	}
+	siteLocation.Store(loc)
+	return nil
+}
+
+// SiteLocation returns the site timezone (UTC until one is set).
+func SiteLocation() *time.Location {
+	if loc := siteLocation.Load(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// InSiteTimezone converts t for display in the site timezone.
+func InSiteTimezone(t time.Time) time.Time {
+	return t.In(SiteLocation())
+}
+
+// ParseSiteTime parses a zone-less form value (e.g. from
+// <input type="datetime-local">) as a site-timezone wall clock and returns
+// it in UTC for storage.
+func ParseSiteTime(layout, value string) (time.Time, error) {
+	t, err := time.ParseInLocation(layout, value, SiteLocation())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// CommonTimezones are suggested by the timezone field of the settings form;
+// any IANA name is accepted.
+var CommonTimezones = []string{
+	"UTC",
+	"America/Los_Angeles", "America/Denver", "America/Chicago", "America/New_York",
+	"America/Sao_Paulo", "America/Mexico_City", "America/Toronto",
+	"Europe/London", "Europe/Paris", "Europe/Berlin", "Europe/Madrid", "Europe/Rome",
+	"Europe/Amsterdam", "Europe/Istanbul", "Europe/Moscow",
+	"Africa/Johannesburg", "Africa/Lagos", "Asia/Dubai", "Asia/Kolkata", "Asia/Singapore",
+	"Asia/Shanghai", "Asia/Hong_Kong", "Asia/Tokyo", "Asia/Seoul",
+	"Australia/Sydney", "Pacific/Auckland",
+}
+
+// regionTimezones maps Accept-Language regions (ISO 3166 country codes) to
+// the zone most of the country's population lives in.
+var regionTimezones = map[string]string{
+	"us": "America/New_York", "ca": "America/Toronto", "mx": "America/Mexico_City",
+	"br": "America/Sao_Paulo", "ar": "America/Argentina/Buenos_Aires", "cl": "America/Santiago",
+	"co": "America/Bogota", "gb": "Europe/London", "ie": "Europe/Dublin", "pt": "Europe/Lisbon",
+	"es": "Europe/Madrid", "fr": "Europe/Paris", "be": "Europe/Brussels", "nl": "Europe/Amsterdam",
+	"de": "Europe/Berlin", "at": "Europe/Vienna", "ch": "Europe/Zurich", "it": "Europe/Rome",
+	"dk": "Europe/Copenhagen", "se": "Europe/Stockholm", "no": "Europe/Oslo", "fi": "Europe/Helsinki",
+	"pl": "Europe/Warsaw", "cz": "Europe/Prague", "gr": "Europe/Athens", "tr": "Europe/Istanbul",
+	"ru": "Europe/Moscow", "ua": "Europe/Kyiv", "il": "Asia/Jerusalem", "ae": "Asia/Dubai",
+	"sa": "Asia/Riyadh", "eg": "Africa/Cairo", "za": "Africa/Johannesburg", "ng": "Africa/Lagos",
+	"ke": "Africa/Nairobi", "in": "Asia/Kolkata", "pk": "Asia/Karachi", "bd": "Asia/Dhaka",
+	"th": "Asia/Bangkok", "vn": "Asia/Ho_Chi_Minh", "id": "Asia/Jakarta", "my": "Asia/Kuala_Lumpur",
+	"sg": "Asia/Singapore", "ph": "Asia/Manila", "cn": "Asia/Shanghai", "hk": "Asia/Hong_Kong",
+	"tw": "Asia/Taipei", "kr": "Asia/Seoul", "jp": "Asia/Tokyo", "au": "Australia/Sydney",
+	"nz": "Pacific/Auckland",
+}
+
+// languageTimezones is the fallback for tags without a region, for languages
+// spoken mainly in one zone. Widely spread languages (en, es, ar, ...) are
+// left out rather than guessed.
+var languageTimezones = map[string]string{
+	"de": "Europe/Berlin", "fr": "Europe/Paris", "it": "Europe/Rome", "nl": "Europe/Amsterdam",
+	"pl": "Europe/Warsaw", "cs": "Europe/Prague", "da": "Europe/Copenhagen", "sv": "Europe/Stockholm",
+	"nb": "Europe/Oslo", "fi": "Europe/Helsinki", "el": "Europe/Athens", "tr": "Europe/Istanbul",
+	"uk": "Europe/Kyiv", "he": "Asia/Jerusalem", "hi": "Asia/Kolkata", "th": "Asia/Bangkok",
+	"vi": "Asia/Ho_Chi_Minh", "id": "Asia/Jakarta", "ja": "Asia/Tokyo", "ko": "Asia/Seoul",
+	"zh": "Asia/Shanghai",
+}
+
+// TimezoneFromAcceptLanguage guesses a visitor's time zone from their
+// Accept-Language header: the region of the most preferred tag that has one
+// we know ("en-GB" -> Europe/London), else a single-zone language ("ja" ->
+// Asia/Tokyo). It returns "" when nothing matches. Browsers do not send the
+// zone itself, so this is only a heuristic for display hints.
+func TimezoneFromAcceptLanguage(header string) string {
+	type tag struct {
+		lang, region string
+		q            float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		subtags := strings.FieldsFunc(strings.ToLower(fields[0]), func(r rune) bool { return r == '-' || r == '_' })
+		if len(subtags) == 0 {
+			continue
+		}
+		t := tag{lang: subtags[0], q: 1}
+		for _, s := range subtags[1:] {
+			if len(s) == 2 { // Skip script subtags such as "Hant"
+				t.region = s
+				break
+			}
+		}
+		for _, f := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(f), "q="); ok {
+				if q, err := strconv.ParseFloat(v, 64); err == nil {
+					t.q = q
+				}
+			}
+		}
+		if t.q > 0 {
+			tags = append(tags, t)
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	for _, t := range tags {
+		if zone, ok := regionTimezones[t.region]; ok {
+			return zone
+		}
+		if zone, ok := languageTimezones[t.lang]; ok {
+			return zone
+		}
+	}
+	return ""
+}
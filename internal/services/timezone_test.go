@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestSiteTimezone(t *testing.T) {
+	t.Cleanup(func() { services.SetSiteTimezone(services.DefaultTimezone) })
+
+	if services.SiteLocation() != time.UTC {
+		t.Fatalf("default zone = %v, want UTC", services.SiteLocation())
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus"} {
+		if err := services.SetSiteTimezone(name); err == nil {
+			t.Errorf("%q should be rejected", name)
+		}
+	}
+	if err := services.SetSiteTimezone("Asia/Kolkata"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Form values are site-zone wall clocks, stored as UTC
+	stored, err := services.ParseSiteTime("2006-01-02T15:04", "2026-03-01T09:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 3, 1, 4, 0, 0, 0, time.UTC); !stored.Equal(want) || stored.Location() != time.UTC {
+		t.Errorf("stored = %v, want %v", stored, want)
+	}
+	if got := services.InSiteTimezone(stored).Format("15:04"); got != "09:30" {
+		t.Errorf("displayed = %s, want 09:30", got)
+	}
+}
+
+func TestTimezoneFromAcceptLanguage(t *testing.T) {
+	cases := map[string]string{
+		"en-GB,en;q=0.9":         "Europe/London",
+		"de":                     "Europe/Berlin",
+		"zh-Hant-TW":             "Asia/Taipei",
+		"en;q=0.5, ja-JP":        "Asia/Tokyo", // Highest quality wins
+		"es-419, en":             "",           // No known region or single-zone language
+		"fr-CA,fr;q=0.9":         "America/Toronto",
+		"xx-ZZ, pt-BR;q=0.8":     "America/Sao_Paulo",
+		"de-DE;q=0, en-US;q=0.1": "America/New_York", // q=0 means "not acceptable"
+		"":                       "",
+	}
+	for header, want := range cases {
+		if got := services.TimezoneFromAcceptLanguage(header); got != want {
+			t.Errorf("%q: got %q, want %q", header, got, want)
+		}
+		if want != "" && !services.IsTimezone(want) {
+			t.Errorf("%q maps to unknown zone %q", header, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	// Standard library imports
+	"context" // Settings lookup per handshake
+	"fmt"     // Validation and policy errors
+	"strings" // Splitting and normalizing host names
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// ParseHostNames parses the host names of the built-in HTTPS server: one per
+// line or separated by commas, such as example.com. Blank entries are
+// skipped and names are lowercased. The first entry that is not a host name
+// (with a port, a scheme, a path or no dot) is reported.
+//
+// Example:
+//
+//	hosts, err := services.ParseHostNames("example.com\nwww.example.com")
+func ParseHostNames(text string) ([]string, error) {
+	var hosts []string
+	for _, entry := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' || r == ',' }) {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if strings.ContainsAny(entry, ":/ \t") || !strings.Contains(entry, ".") ||
+			strings.HasPrefix(entry, ".") || strings.HasSuffix(entry, ".") {
+			return nil, fmt.Errorf("%q is not a host name such as example.com", entry)
+		}
+		hosts = append(hosts, entry)
+	}
+	return hosts, nil
+}
+
+// TLSHostPolicy returns the autocert host policy of the built-in HTTPS
+// server. Certificates are obtained only for the host names saved on the
+// HTTPS Domains admin page, or for fallback (TLS_DOMAINS) while none are
+// saved. The settings are read on each check, so a saved change applies to
+// the next new host without a restart; autocert only asks for hosts it has
+// no certificate for yet. When the settings cannot be read, every host is
+// refused rather than falling back.
+func TLSHostPolicy(queries sqlc.Querier, fallback []string) func(ctx context.Context, host string) error {
+	return func(ctx context.Context, host string) error {
+		settings, err := queries.GetSettings(ctx)
+		if err != nil {
+			return fmt.Errorf("read HTTPS host names: %w", err)
+		}
+		hosts, err := ParseHostNames(settings.TlsDomains)
+		if err != nil {
+			return fmt.Errorf("HTTPS host names: %w", err)
+		}
+		if len(hosts) == 0 {
+			hosts = fallback
+		}
+		for _, h := range hosts {
+			if strings.EqualFold(h, host) {
+				return nil
+			}
+		}
+		return fmt.Errorf("host %q is not an HTTPS domain of this site", host)
+	}
+}
@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestParseHostNames(t *testing.T) {
+	hosts, err := services.ParseHostNames(" Example.com, www.example.com \r\n\nshop.example.com")
+	if err != nil || len(hosts) != 3 || hosts[0] != "example.com" || hosts[2] != "shop.example.com" {
+		t.Errorf("ParseHostNames = %v, %v", hosts, err)
+	}
+	for _, bad := range []string{"example.com:443", "https://example.com", "localhost", "example.com/docs", ".example.com"} {
+		if _, err := services.ParseHostNames(bad); err == nil {
+			t.Errorf("ParseHostNames(%q) accepted", bad)
+		}
+	}
+}
+
+func TestTLSHostPolicy(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	policy := services.TLSHostPolicy(queries, []string{"env.example.com"})
+
+	// TLS_DOMAINS applies while no host names are saved
+	if err := policy(ctx, "env.example.com"); err != nil {
+		t.Errorf("fallback host refused: %v", err)
+	}
+	if err := policy(ctx, "other.example.com"); err == nil {
+		t.Error("unlisted host allowed")
+	}
+
+	// Saved host names replace it, without a restart
+	if err := queries.UpdateTLSDomains(ctx, "example.com\nwww.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy(ctx, "www.example.com"); err != nil {
+		t.Errorf("saved host refused: %v", err)
+	}
+	if err := policy(ctx, "env.example.com"); err == nil {
+		t.Error("TLS_DOMAINS host allowed although host names are saved")
+	}
+}
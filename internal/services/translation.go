@@ -0,0 +1,751 @@
+package services
+
+import (
+	// Standard library imports for hashing, JSON encoding, and error handling
+	"context"       // Request-scoped cancellation for database calls
+	"crypto/sha256" // Fingerprints source content to detect outdated translations
+	"database/sql"  // sql.ErrNoRows detection and nullable source columns
+	"encoding/hex"  // Hex encoding of source fingerprints
+	"encoding/json" // Translated fields are stored as a JSON object
+	"errors"        // Error inspection
+	"fmt"           // Error messages for unknown entity types
+	"log/slog"      // Structured logging for lookup failures on public pages
+	"regexp"        // Locale code validation
+	"strings"       // Joining fields for fingerprinting
+	"sync"          // Guards the locale list, which changes when admins edit languages
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Translation status values stored in translations.status.
+const (
+	TranslationMissing  = "missing"  // Not every source field has been translated
+	TranslationOutdated = "outdated" // Source content changed after the translation was completed
+	TranslationComplete = "complete" // Every non-empty source field is translated
+	TranslationMachine  = "machine"  // Machine-translated draft awaiting human review
+)
+
+// TranslatableType describes an entity type that can be translated and the
+// source columns that make up its translatable content.
+type TranslatableType struct {
+	Key    string   // Value stored in translations.entity_type
+	Label  string   // Human-readable name for the admin dashboard
+	Fields []string // Translatable source columns, in editor order
+}
+
+// TranslatableTypes lists every entity type supported by the localization
+// workflow, in the order shown on the translation dashboard.
+var TranslatableTypes = []TranslatableType{
+	{Key: "product", Label: "Products", Fields: []string{"name", "tagline", "description"}},
+	{Key: "blog_post", Label: "Blog Posts", Fields: []string{"title", "excerpt", "body"}},
+	{Key: "solution", Label: "Solutions", Fields: []string{"title", "short_description", "overview_content"}},
+	{Key: "page_section", Label: "Page Sections", Fields: []string{"heading", "subheading", "description", "label", "primary_button_text", "secondary_button_text"}},
+}
+
+// LookupTranslatableType returns the TranslatableType registered under key.
+func LookupTranslatableType(key string) (TranslatableType, bool) {
+	for _, t := range TranslatableTypes {
+		if t.Key == key {
+			return t, true
+		}
+	}
+	return TranslatableType{}, false
+}
+
+// TranslationSource is the source-language content of one translatable entity.
+type TranslationSource struct {
+	EntityType string            // TranslatableType key
+	EntityID   int64             // Primary key of the source row
+	Label      string            // Display name (product name, post title, ...)
+	Fields     map[string]string // Source text keyed by column name
+	Hash       string            // Fingerprint of Fields
+}
+
+// Translation is the translated content of one entity for one locale.
+type Translation struct {
+	Locale string            // Target locale (e.g., "de")
+	Status string            // One of the Translation* status constants
+	Fields map[string]string // Translated text keyed by source column name
+}
+
+// Field returns the translated value of a field, falling back to the source
+// text when there is no translation or the translated value is empty. It is
+// safe to call on a nil *Translation, which always returns the fallback.
+//
+// Parameters:
+//   - name: Source column name (e.g., "title")
+//   - fallback: Source-language value to use when no translation exists
+//
+// Returns:
+//   - string: Translated value or fallback
+func (t *Translation) Field(name, fallback string) string {
+	if t == nil {
+		return fallback
+	}
+	if v := strings.TrimSpace(t.Fields[name]); v != "" {
+		return t.Fields[name]
+	}
+	return fallback
+}
+
+// TypeCoverage is the translation coverage of one entity type in one locale.
+type TypeCoverage struct {
+	EntityType string
+	Label      string
+	Total      int
+	Complete   int
+	Outdated   int
+	Machine    int // Unreviewed machine drafts (not yet shown on public pages)
+	Missing    int
+}
+
+// Percent returns the share of entities with a complete translation (0-100).
+func (c TypeCoverage) Percent() int {
+	if c.Total == 0 {
+		return 100
+	}
+	return c.Complete * 100 / c.Total
+}
+
+// LocaleCoverage aggregates translation coverage for one locale.
+type LocaleCoverage struct {
+	Locale string
+	Types  []TypeCoverage
+	TypeCoverage
+}
+
+// ErrInvalidLocale is returned when a locale code is not a lowercase ISO 639-1
+// language code with an optional region (e.g., "de" or "pt-br").
+var ErrInvalidLocale = errors.New("invalid locale code")
+
+// ErrDefaultLocale is returned when an admin tries to disable the default
+// locale, which the source content is written in.
+var ErrDefaultLocale = errors.New("the default locale cannot be disabled")
+
+// localeCodePattern matches the locale codes used in /<code>/ URL prefixes.
+// Codes are kept to two letters (plus region) so they never collide with
+// top-level routes such as /api or /admin.
+var localeCodePattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]{2})?$`)
+
+// localeNames are the switcher names of common languages, used when a locale
+// is seeded from SITE_LOCALES or added without a name.
+var localeNames = map[string]string{
+	"de": "Deutsch",
+	"en": "English",
+	"es": "Español",
+	"fr": "Français",
+	"it": "Italiano",
+	"ja": "日本語",
+	"nl": "Nederlands",
+	"pl": "Polski",
+	"pt": "Português",
+	"zh": "中文",
+}
+
+// LocaleName returns the native name of a language, or the upper-cased code
+// when the language is not known.
+func LocaleName(code string) string {
+	if name, ok := localeNames[code]; ok {
+		return name
+	}
+	return strings.ToUpper(code)
+}
+
+// LocaleInfo describes one site language.
+type LocaleInfo struct {
+	Code      string // Locale code and URL prefix (e.g., "de")
+	Name      string // Name shown in the language switcher (e.g., "Deutsch")
+	Default   bool   // Source-content locale
+	Enabled   bool   // Offered to visitors and translators
+	SortOrder int64  // Position in the switcher
+}
+
+// TranslationService manages per-entity translations: tracking their status
+// against the source content, computing coverage per locale, and resolving
+// which translated fields public pages should render.
+type TranslationService struct {
+	queries       *sqlc.Queries // Database query interface for translations and sources
+	logger        *slog.Logger  // Structured logger for lookup failures
+	defaultLocale string        // Source language of all content (e.g., "en")
+
+	mu      sync.RWMutex      // Guards locales and names
+	locales []string          // Translation target locales, excluding the default
+	names   map[string]string // Switcher names keyed by locale code
+}
+
+// NewTranslationService creates a TranslationService.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - logger: Structured logger
+//   - defaultLocale: Locale the source content is written in (e.g., "en")
+//   - locales: Additional locales content can be translated into
+//
+// Returns:
+//   - *TranslationService: Initialized service
+//
+// The locales are used as given until SyncLocales moves them into the
+// locales table, after which the table is authoritative.
+func NewTranslationService(queries *sqlc.Queries, logger *slog.Logger, defaultLocale string, locales []string) *TranslationService {
+	targets := make([]string, 0, len(locales))
+	for _, l := range locales {
+		if l != "" && l != defaultLocale {
+			targets = append(targets, l)
+		}
+	}
+	return &TranslationService{queries: queries, logger: logger, defaultLocale: defaultLocale, locales: targets, names: map[string]string{}}
+}
+
+// DefaultLocale returns the source-content locale.
+func (s *TranslationService) DefaultLocale() string {
+	return s.defaultLocale
+}
+
+// Locales returns the translation target locales (the default locale excluded).
+func (s *TranslationService) Locales() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.locales...)
+}
+
+// IsTargetLocale reports whether content can be translated into locale.
+func (s *TranslationService) IsTargetLocale(locale string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, l := range s.locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLocale reports whether visitors may choose locale: the default locale or
+// an enabled target.
+func (s *TranslationService) IsLocale(locale string) bool {
+	return locale == s.defaultLocale || s.IsTargetLocale(locale)
+}
+
+// Languages returns the default locale followed by the enabled targets, in
+// switcher order.
+func (s *TranslationService) Languages() []LocaleInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	langs := make([]LocaleInfo, 0, len(s.locales)+1)
+	for i, code := range append([]string{s.defaultLocale}, s.locales...) {
+		name := s.names[code]
+		if name == "" {
+			name = LocaleName(code)
+		}
+		langs = append(langs, LocaleInfo{Code: code, Name: name, Default: i == 0, Enabled: true, SortOrder: int64(i)})
+	}
+	return langs
+}
+
+// SyncLocales adds the default and target locales the service was created
+// with to the locales table (existing rows, including disabled ones, are
+// left alone), marks the default, and loads the enabled targets from the
+// table. Call it once at startup.
+func (s *TranslationService) SyncLocales(ctx context.Context) error {
+	for i, code := range append([]string{s.defaultLocale}, s.Locales()...) {
+		if err := s.queries.CreateLocale(ctx, sqlc.CreateLocaleParams{
+			Code: code, Name: LocaleName(code), IsDefault: i == 0, SortOrder: int64(i),
+		}); err != nil {
+			return err
+		}
+	}
+	if err := s.queries.SetDefaultLocale(ctx, s.defaultLocale); err != nil {
+		return err
+	}
+	return s.loadLocales(ctx)
+}
+
+// loadLocales replaces the in-memory locale list with the enabled locales
+// of the locales table.
+func (s *TranslationService) loadLocales(ctx context.Context) error {
+	rows, err := s.queries.ListLocales(ctx)
+	if err != nil {
+		return err
+	}
+	targets := make([]string, 0, len(rows))
+	names := make(map[string]string, len(rows))
+	for _, r := range rows {
+		names[r.Code] = r.Name
+		if r.IsEnabled && r.Code != s.defaultLocale {
+			targets = append(targets, r.Code)
+		}
+	}
+	s.mu.Lock()
+	s.locales, s.names = targets, names
+	s.mu.Unlock()
+	return nil
+}
+
+// AllLocales lists every row of the locales table, disabled ones included.
+func (s *TranslationService) AllLocales(ctx context.Context) ([]LocaleInfo, error) {
+	rows, err := s.queries.ListLocales(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locales := make([]LocaleInfo, len(rows))
+	for i, r := range rows {
+		locales[i] = LocaleInfo{Code: r.Code, Name: r.Name, Default: r.Code == s.defaultLocale, Enabled: r.IsEnabled, SortOrder: r.SortOrder}
+	}
+	return locales, nil
+}
+
+// AddLocale adds an enabled translation target. An empty name uses the
+// language's native name; adding an existing code changes nothing.
+//
+// Returns:
+//   - error: ErrInvalidLocale for a malformed code, or a database error
+func (s *TranslationService) AddLocale(ctx context.Context, code, name string) error {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !localeCodePattern.MatchString(code) {
+		return ErrInvalidLocale
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = LocaleName(code)
+	}
+	existing, err := s.queries.ListLocales(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.queries.CreateLocale(ctx, sqlc.CreateLocaleParams{Code: code, Name: name, SortOrder: int64(len(existing))}); err != nil {
+		return err
+	}
+	return s.loadLocales(ctx)
+}
+
+// UpdateLocale renames, reorders, enables or disables a locale. Translations
+// of a disabled locale are kept and reappear when it is enabled again.
+//
+// Returns:
+//   - error: ErrDefaultLocale when disabling the default locale,
+//     sql.ErrNoRows for an unknown code, or a database error
+func (s *TranslationService) UpdateLocale(ctx context.Context, code, name string, enabled bool, sortOrder int64) error {
+	if code == s.defaultLocale && !enabled {
+		return ErrDefaultLocale
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = LocaleName(code)
+	}
+	n, err := s.queries.UpdateLocale(ctx, sqlc.UpdateLocaleParams{Name: name, IsEnabled: enabled, SortOrder: sortOrder, Code: code})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return s.loadLocales(ctx)
+}
+
+// sourceHash fingerprints the translatable fields of an entity in declared order.
+func sourceHash(t TranslatableType, fields map[string]string) string {
+	parts := make([]string, len(t.Fields))
+	for i, f := range t.Fields {
+		parts[i] = fields[f]
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sources loads the source content of every entity of a translatable type.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - entityType: TranslatableType key
+//
+// Returns:
+//   - []TranslationSource: Source content with fingerprints
+//   - error: Unknown entity type or database error
+func (s *TranslationService) Sources(ctx context.Context, entityType string) ([]TranslationSource, error) {
+	t, ok := LookupTranslatableType(entityType)
+	if !ok {
+		return nil, fmt.Errorf("unknown translatable type %q", entityType)
+	}
+
+	var sources []TranslationSource
+	add := func(id int64, label string, values ...string) {
+		fields := make(map[string]string, len(t.Fields))
+		for i, f := range t.Fields {
+			fields[f] = values[i]
+		}
+		sources = append(sources, TranslationSource{EntityType: t.Key, EntityID: id, Label: label, Fields: fields, Hash: sourceHash(t, fields)})
+	}
+
+	switch t.Key {
+	case "product":
+		rows, err := s.queries.ListProductTranslationSources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			add(r.ID, r.Name, r.Name, r.Tagline.String, r.Description)
+		}
+	case "blog_post":
+		rows, err := s.queries.ListBlogPostTranslationSources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			add(r.ID, r.Title, r.Title, r.Excerpt, r.Body)
+		}
+	case "solution":
+		rows, err := s.queries.ListSolutionTranslationSources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			add(r.ID, r.Title, r.Title, r.ShortDescription, r.OverviewContent.String)
+		}
+	case "page_section":
+		rows, err := s.queries.ListPageSectionTranslationSources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			add(r.ID, r.PageKey+" / "+r.SectionKey, r.Heading, r.Subheading, r.Description, r.Label, r.PrimaryButtonText, r.SecondaryButtonText)
+		}
+	}
+	return sources, nil
+}
+
+// Source loads the source content of a single entity.
+//
+// Returns:
+//   - *TranslationSource: Source content
+//   - error: sql.ErrNoRows if the entity does not exist
+func (s *TranslationService) Source(ctx context.Context, entityType string, entityID int64) (*TranslationSource, error) {
+	sources, err := s.Sources(ctx, entityType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sources {
+		if sources[i].EntityID == entityID {
+			return &sources[i], nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// decodeTranslation converts a stored record, downgrading a complete
+// translation to outdated when the current source hash no longer matches.
+func decodeTranslation(row sqlc.Translation, currentHash string) *Translation {
+	tr := &Translation{Locale: row.Locale, Status: row.Status, Fields: map[string]string{}}
+	if err := json.Unmarshal([]byte(row.Fields), &tr.Fields); err != nil {
+		tr.Fields = map[string]string{}
+	}
+	if tr.Status == TranslationComplete && currentHash != "" && row.SourceHash != currentHash {
+		tr.Status = TranslationOutdated
+	}
+	return tr
+}
+
+// Get returns the translation of an entity for a locale with its status checked
+// against the current source. An untranslated entity yields an empty Translation
+// with status "missing".
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - src: Current source content of the entity
+//   - locale: Target locale
+//
+// Returns:
+//   - *Translation: Stored or empty translation
+//   - error: Database error
+func (s *TranslationService) Get(ctx context.Context, src *TranslationSource, locale string) (*Translation, error) {
+	row, err := s.queries.GetTranslation(ctx, sqlc.GetTranslationParams{EntityType: src.EntityType, EntityID: src.EntityID, Locale: locale})
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Translation{Locale: locale, Status: TranslationMissing, Fields: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeTranslation(row, src.Hash), nil
+}
+
+// Save stores translated fields for an entity and locale. The status is
+// "complete" when every non-empty source field has a translation and "missing"
+// otherwise; the current source fingerprint is recorded so later source edits
+// mark the translation outdated.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - src: Current source content of the entity
+//   - locale: Target locale
+//   - fields: Translated values keyed by source column (unknown keys are ignored)
+//
+// Returns:
+//   - *Translation: The saved translation
+//   - error: Unsupported locale or database error
+func (s *TranslationService) Save(ctx context.Context, src *TranslationSource, locale string, fields map[string]string) (*Translation, error) {
+	if !s.IsTargetLocale(locale) {
+		return nil, fmt.Errorf("unsupported locale %q", locale)
+	}
+	t, _ := LookupTranslatableType(src.EntityType)
+
+	clean := make(map[string]string, len(t.Fields))
+	status := TranslationComplete
+	for _, f := range t.Fields {
+		v := strings.TrimSpace(fields[f])
+		if v != "" {
+			clean[f] = v
+		} else if strings.TrimSpace(src.Fields[f]) != "" {
+			status = TranslationMissing
+		}
+	}
+	encoded, err := json.Marshal(clean)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpsertTranslation(ctx, sqlc.UpsertTranslationParams{
+		EntityType: src.EntityType,
+		EntityID:   src.EntityID,
+		Locale:     locale,
+		Fields:     string(encoded),
+		SourceHash: src.Hash,
+		Status:     status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeTranslation(row, src.Hash), nil
+}
+
+// MachineDraft pre-fills a translation with machine-translated text. Only
+// source fields without an existing translation are sent to the provider, so
+// human work is never overwritten. The result is stored with status "machine"
+// and stays hidden from public pages until an editor saves it via Save.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - mt: Configured provider (see NewMachineTranslator)
+//   - src: Current source content of the entity
+//   - locale: Target locale
+//
+// Returns:
+//   - *Translation: The saved draft
+//   - error: ErrNothingToTranslate, unsupported locale, provider or database error
+func (s *TranslationService) MachineDraft(ctx context.Context, mt MachineTranslator, src *TranslationSource, locale string) (*Translation, error) {
+	if !s.IsTargetLocale(locale) {
+		return nil, fmt.Errorf("unsupported locale %q", locale)
+	}
+	current, err := s.Get(ctx, src, locale)
+	if err != nil {
+		return nil, err
+	}
+	t, _ := LookupTranslatableType(src.EntityType)
+
+	var names, texts []string
+	for _, f := range t.Fields {
+		if strings.TrimSpace(src.Fields[f]) != "" && strings.TrimSpace(current.Fields[f]) == "" {
+			names = append(names, f)
+			texts = append(texts, src.Fields[f])
+		}
+	}
+	if len(texts) == 0 {
+		return nil, ErrNothingToTranslate
+	}
+
+	translated, err := mt.Translate(ctx, texts, s.defaultLocale, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string, len(t.Fields))
+	for k, v := range current.Fields {
+		fields[k] = v
+	}
+	for i, f := range names {
+		if v := strings.TrimSpace(translated[i]); v != "" {
+			fields[f] = v
+		}
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpsertTranslation(ctx, sqlc.UpsertTranslationParams{
+		EntityType: src.EntityType,
+		EntityID:   src.EntityID,
+		Locale:     locale,
+		Fields:     string(encoded),
+		SourceHash: src.Hash,
+		Status:     TranslationMachine,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeTranslation(row, src.Hash), nil
+}
+
+// Coverage computes, for every target locale, how many entities of each type
+// have complete, outdated, or missing translations. Translations found to be
+// outdated are flagged in the database so the stored status stays current.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - []LocaleCoverage: One entry per target locale
+//   - error: Database error
+func (s *TranslationService) Coverage(ctx context.Context) ([]LocaleCoverage, error) {
+	sources := make(map[string][]TranslationSource, len(TranslatableTypes))
+	for _, t := range TranslatableTypes {
+		list, err := s.Sources(ctx, t.Key)
+		if err != nil {
+			return nil, err
+		}
+		sources[t.Key] = list
+	}
+
+	var result []LocaleCoverage
+	for _, locale := range s.Locales() {
+		rows, err := s.queries.ListTranslationsByLocale(ctx, locale)
+		if err != nil {
+			return nil, err
+		}
+		byEntity := make(map[string]sqlc.Translation, len(rows))
+		for _, r := range rows {
+			byEntity[fmt.Sprintf("%s:%d", r.EntityType, r.EntityID)] = r
+		}
+
+		lc := LocaleCoverage{Locale: locale}
+		for _, t := range TranslatableTypes {
+			tc := TypeCoverage{EntityType: t.Key, Label: t.Label}
+			for _, src := range sources[t.Key] {
+				tc.Total++
+				row, ok := byEntity[fmt.Sprintf("%s:%d", t.Key, src.EntityID)]
+				if !ok {
+					tc.Missing++
+					continue
+				}
+				tr := decodeTranslation(row, src.Hash)
+				if tr.Status == TranslationOutdated && row.Status == TranslationComplete {
+					s.queries.MarkTranslationsOutdated(ctx, sqlc.MarkTranslationsOutdatedParams{
+						EntityType: t.Key, EntityID: src.EntityID, SourceHash: src.Hash,
+					})
+				}
+				switch tr.Status {
+				case TranslationComplete:
+					tc.Complete++
+				case TranslationOutdated:
+					tc.Outdated++
+				case TranslationMachine:
+					tc.Machine++
+				default:
+					tc.Missing++
+				}
+			}
+			lc.Types = append(lc.Types, tc)
+			lc.Total += tc.Total
+			lc.Complete += tc.Complete
+			lc.Outdated += tc.Outdated
+			lc.Machine += tc.Machine
+			lc.Missing += tc.Missing
+		}
+		result = append(result, lc)
+	}
+	return result, nil
+}
+
+// EntityTranslation pairs an entity's source content with its translation
+// status in one locale, for the dashboard's per-entity listing.
+type EntityTranslation struct {
+	Source TranslationSource
+	Status string
+}
+
+// Statuses lists every entity of a type with its translation status in locale.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - entityType: TranslatableType key
+//   - locale: Target locale
+//
+// Returns:
+//   - []EntityTranslation: One entry per source entity
+//   - error: Unknown entity type or database error
+func (s *TranslationService) Statuses(ctx context.Context, entityType, locale string) ([]EntityTranslation, error) {
+	sources, err := s.Sources(ctx, entityType)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListTranslationsByLocale(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]sqlc.Translation, len(rows))
+	for _, r := range rows {
+		if r.EntityType == entityType {
+			byID[r.EntityID] = r
+		}
+	}
+
+	result := make([]EntityTranslation, 0, len(sources))
+	for _, src := range sources {
+		status := TranslationMissing
+		if row, ok := byID[src.EntityID]; ok {
+			status = decodeTranslation(row, src.Hash).Status
+		}
+		result = append(result, EntityTranslation{Source: src, Status: status})
+	}
+	return result, nil
+}
+
+// LocaleStatuses returns the translation status of one entity in every
+// target locale, keyed by locale, for the language tabs of edit forms.
+//
+// Returns:
+//   - map[string]string: Translation* status per target locale
+//   - error: sql.ErrNoRows if the entity does not exist, or a database error
+func (s *TranslationService) LocaleStatuses(ctx context.Context, entityType string, entityID int64) (map[string]string, error) {
+	src, err := s.Source(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]string)
+	for _, locale := range s.Locales() {
+		tr, err := s.Get(ctx, src, locale)
+		if err != nil {
+			return nil, err
+		}
+		statuses[locale] = tr.Status
+	}
+	return statuses, nil
+}
+
+// Resolve returns the translation public pages should render for an entity,
+// applying the fallback rules:
+//   - the default locale, or a locale that is not a target, renders the source
+//   - missing (incomplete) translations are ignored so pages never mix languages
+//     mid-sentence; the source is rendered instead
+//   - machine drafts are ignored until an editor has reviewed and saved them
+//   - complete and outdated translations are rendered, with any empty field
+//     falling back to the source text (see Translation.Field)
+//
+// Lookup errors are logged and treated as "no translation".
+//
+// Returns:
+//   - *Translation: Translation to overlay, or nil to render the source
+func (s *TranslationService) Resolve(ctx context.Context, entityType string, entityID int64, locale string) *Translation {
+	if !s.IsTargetLocale(locale) {
+		return nil
+	}
+	row, err := s.queries.GetTranslation(ctx, sqlc.GetTranslationParams{EntityType: entityType, EntityID: entityID, Locale: locale})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Error("failed to load translation", "error", err, "entity", entityType, "id", entityID, "locale", locale)
+		}
+		return nil
+	}
+	tr := decodeTranslation(row, "")
+	if tr.Status == TranslationMissing || tr.Status == TranslationMachine {
+		return nil
+	}
+	return tr
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestTranslationService_StatusCoverageAndFallback(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	res, err := db.Exec(`INSERT INTO solutions (title, slug, icon, short_description, is_published) VALUES ('Grid', 'grid', 'bolt', 'Grid monitoring', 1)`)
+	if err != nil {
+		t.Fatalf("insert solution: %v", err)
+	}
+	id, _ := res.LastInsertId()
+
+	svc := services.NewTranslationService(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), "en", []string{"en", "fr"})
+	if got := svc.Locales(); len(got) != 1 || got[0] != "fr" {
+		t.Fatalf("expected target locales [fr], got %v", got)
+	}
+
+	src, err := svc.Source(ctx, "solution", id)
+	if err != nil {
+		t.Fatalf("Source: %v", err)
+	}
+
+	// Partial translation is stored as missing and ignored on public pages
+	tr, err := svc.Save(ctx, src, "fr", map[string]string{"title": "Réseau"})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if tr.Status != services.TranslationMissing {
+		t.Errorf("expected missing, got %q", tr.Status)
+	}
+	if svc.Resolve(ctx, "solution", id, "fr") != nil {
+		t.Error("expected incomplete translation to fall back to the source")
+	}
+
+	// Every non-empty source field translated: complete (empty overview_content is not required)
+	tr, err = svc.Save(ctx, src, "fr", map[string]string{"title": "Réseau", "short_description": "Surveillance du réseau"})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if tr.Status != services.TranslationComplete {
+		t.Errorf("expected complete, got %q", tr.Status)
+	}
+	resolved := svc.Resolve(ctx, "solution", id, "fr")
+	if resolved.Field("title", "Grid") != "Réseau" || resolved.Field("overview_content", "src") != "src" {
+		t.Errorf("unexpected resolved fields: %+v", resolved.Fields)
+	}
+	if svc.Resolve(ctx, "solution", id, "en") != nil {
+		t.Error("default locale should render the source")
+	}
+
+	// Editing the source makes the translation outdated, but it still renders
+	if _, err := db.Exec(`UPDATE solutions SET short_description = 'Grid monitoring and control' WHERE id = ?`, id); err != nil {
+		t.Fatalf("update solution: %v", err)
+	}
+	coverage, err := svc.Coverage(ctx)
+	if err != nil {
+		t.Fatalf("Coverage: %v", err)
+	}
+	if len(coverage) != 1 || coverage[0].Locale != "fr" {
+		t.Fatalf("expected coverage for fr only, got %+v", coverage)
+	}
+	if coverage[0].Outdated != 1 || coverage[0].Complete != 0 {
+		t.Errorf("expected 1 outdated translation, got %+v", coverage[0].TypeCoverage)
+	}
+	if tr := svc.Resolve(ctx, "solution", id, "fr"); tr == nil || tr.Status != services.TranslationOutdated {
+		t.Errorf("expected outdated translation to keep rendering, got %+v", tr)
+	}
+}
+
+func TestTranslationService_Locales(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	svc := services.NewTranslationService(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), "en", []string{"de"})
+	if err := svc.SyncLocales(ctx); err != nil {
+		t.Fatalf("SyncLocales: %v", err)
+	}
+	if langs := svc.Languages(); len(langs) != 2 || langs[0].Code != "en" || !langs[0].Default || langs[1].Name != "Deutsch" {
+		t.Fatalf("languages = %+v, want en (default) and Deutsch", langs)
+	}
+
+	if err := svc.AddLocale(ctx, "Pt-BR", ""); err != nil {
+		t.Fatalf("AddLocale: %v", err)
+	}
+	if !svc.IsTargetLocale("pt-br") {
+		t.Error("added locale should be a target")
+	}
+	for _, code := range []string{"api", "english", "d", ""} {
+		if err := svc.AddLocale(ctx, code, ""); err != services.ErrInvalidLocale {
+			t.Errorf("AddLocale(%q) = %v, want ErrInvalidLocale", code, err)
+		}
+	}
+
+	// Disabled locales are kept but no longer offered; the default stays enabled
+	if err := svc.UpdateLocale(ctx, "de", "German", false, 1); err != nil {
+		t.Fatalf("UpdateLocale: %v", err)
+	}
+	if svc.IsLocale("de") || !svc.IsLocale("en") {
+		t.Errorf("locales after disabling de: %v", svc.Locales())
+	}
+	if err := svc.UpdateLocale(ctx, "en", "English", false, 0); err != services.ErrDefaultLocale {
+		t.Errorf("disabling the default: got %v", err)
+	}
+	if err := svc.UpdateLocale(ctx, "xx", "", true, 0); err == nil {
+		t.Error("updating an unknown locale should fail")
+	}
+
+	// Restarting with the same SITE_LOCALES does not re-enable de
+	restarted := services.NewTranslationService(queries, slog.New(slog.NewTextHandler(io.Discard, nil)), "en", []string{"de"})
+	if err := restarted.SyncLocales(ctx); err != nil {
+		t.Fatalf("SyncLocales: %v", err)
+	}
+	if got := restarted.Locales(); len(got) != 1 || got[0] != "pt-br" {
+		t.Errorf("locales after restart = %v, want [pt-br]", got)
+	}
+	all, _ := restarted.AllLocales(ctx)
+	if len(all) != 3 || all[0].Code != "en" {
+		t.Errorf("all locales = %+v", all)
+	}
+}
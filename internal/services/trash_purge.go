@@ -0,0 +1,76 @@
+package services
+
+import (
+	// Standard library imports
+	"context"  // Job cancellation
+	"log/slog" // Structured logging for job progress and failures
+	"time"     // Purge cutoff and job scheduling
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// TrashPurge permanently deletes content that has been in the admin trash for
+// longer than a fixed number of days. Child rows (specs, tags, metrics, ...)
+// are removed by the ON DELETE CASCADE constraints of each content table.
+type TrashPurge struct {
+	queries *sqlc.Queries // Database query interface for the content tables
+	logger  *slog.Logger  // Structured logger for job progress
+	days    int           // Trashed items older than this many days are purged; 0 keeps them forever
+}
+
+// NewTrashPurge creates the trash purge job.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - logger: Structured logger for job progress and failures
+//   - days: Days an item stays in the trash (0 = keep until deleted by hand)
+//
+// Returns:
+//   - *TrashPurge: Job ready to run or schedule
+func NewTrashPurge(queries *sqlc.Queries, logger *slog.Logger, days int) *TrashPurge {
+	return &TrashPurge{queries: queries, logger: logger, days: days}
+}
+
+// Days returns how long items stay in the trash, 0 when purging is disabled.
+func (p *TrashPurge) Days() int {
+	if p == nil {
+		return 0
+	}
+	return p.days
+}
+
+// Purge permanently deletes items trashed more than the retention window
+// before now.
+//
+// Parameters:
+//   - ctx: Context for the deletes
+//   - now: Reference time for the cutoff
+//
+// Returns:
+//   - int64: Number of items deleted across all content types (0 when disabled)
+//   - error: First database error; earlier content types stay purged
+func (p *TrashPurge) Purge(ctx context.Context, now time.Time) (int64, error) {
+	if p.days <= 0 {
+		return 0, nil
+	}
+	// deleted_at is set with CURRENT_TIMESTAMP, which is UTC in this layout
+	cutoff := now.UTC().AddDate(0, 0, -p.days).Format(archiveTimeLayout)
+	var total int64
+	for _, purge := range []func(context.Context, string) (int64, error){
+		p.queries.PurgeTrashedProducts,
+		p.queries.PurgeTrashedBlogPosts,
+		p.queries.PurgeTrashedSolutions,
+		p.queries.PurgeTrashedCaseStudies,
+	} {
+		n, err := purge(ctx, cutoff)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	if total > 0 {
+		p.logger.Info("purged trash", "items", total, "retention_days", p.days)
+	}
+	return total, nil
+}
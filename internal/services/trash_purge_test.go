@@ -0,0 +1,60 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestTrashPurge_Purge(t *testing.T) {
+	db, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	cat, err := queries.CreateProductCategory(ctx, sqlc.CreateProductCategoryParams{Name: "Cat", Slug: "cat", Description: "d", Icon: "i"})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	// Live product, recently trashed product, long-trashed product
+	deletedAt := []interface{}{nil, "2026-03-01 12:00:00", "2026-01-15 08:00:00"}
+	for i, ts := range deletedAt {
+		p, err := queries.CreateProduct(ctx, sqlc.CreateProductParams{
+			Sku: fmt.Sprintf("SKU-%d", i), Slug: fmt.Sprintf("p-%d", i), Name: "P", Description: "d", CategoryID: cat.ID, Status: "published",
+		})
+		if err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+		if _, err := db.Exec(`UPDATE products SET deleted_at = ? WHERE id = ?`, ts, p.ID); err != nil {
+			t.Fatalf("set deleted_at: %v", err)
+		}
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	now := time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC)
+
+	if n, err := services.NewTrashPurge(queries, logger, 0).Purge(ctx, now); err != nil || n != 0 {
+		t.Fatalf("disabled purge deleted %d items (%v)", n, err)
+	}
+
+	n, err := services.NewTrashPurge(queries, logger, 30).Purge(ctx, now)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("purged %d items, want 1 (trashed before 2026-02-20)", n)
+	}
+	if _, err := queries.GetProductBySKU(ctx, "SKU-2"); err == nil {
+		t.Error("long-trashed product should be purged")
+	}
+	for _, sku := range []string{"SKU-0", "SKU-1"} {
+		if _, err := queries.GetProductBySKU(ctx, sku); err != nil {
+			t.Errorf("%s should be kept: %v", sku, err)
+		}
+	}
+}
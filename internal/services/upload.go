@@ -290,6 +290,142 @@ func (s *UploadService) UploadProductDownload(file *multipart.FileHeader) (strin
 	return "/uploads/downloads/" + filename, nil
 }
 
+// UploadProductVideo stores a video for a product gallery in the "products"
+// subdirectory, next to the product images.
+//
+// Allowed types: mp4, webm (ProductVideoExtensions). Max size: 100MB.
+// File naming convention: {unix_timestamp}_{sanitized_original_filename}
+//
+// Parameters:
+//   - file: Multipart file header from HTTP form upload
+//
+// Returns:
+//   - string: Public URL path to the uploaded video (e.g., "/uploads/products/1234567890_demo.mp4")
+//   - error: Non-nil if validation fails or file cannot be saved
+func (s *UploadService) UploadProductVideo(file *multipart.FileHeader) (string, error) {
+	if !hasExtension(file.Filename, ProductVideoExtensions) {
+		return "", fmt.Errorf("invalid file type: %s (use MP4 or WebM)", strings.ToLower(filepath.Ext(file.Filename)))
+	}
+	if file.Size > 100*1024*1024 {
+		return "", fmt.Errorf("file too large (max 100MB)")
+	}
+	return s.save(file, "products")
+}
+
+// UploadProductModel stores a 3D/CAD file for a product gallery in the
+// "models" subdirectory. Visitors download these files, so unlike downloads
+// the types are limited to the CAD and 3D formats in ProductModelExtensions.
+//
+// Allowed types: step, stp, iges, igs, stl, obj, glb, gltf, 3mf, dwg, dxf. Max size: 50MB.
+// File naming convention: {unix_timestamp}_{sanitized_original_filename}
+//
+// Parameters:
+//   - file: Multipart file header from HTTP form upload
+//
+// Returns:
+//   - string: Public URL path to the uploaded file (e.g., "/uploads/models/1234567890_housing.step")
+//   - error: Non-nil if validation fails or file cannot be saved
+func (s *UploadService) UploadProductModel(file *multipart.FileHeader) (string, error) {
+	if !hasExtension(file.Filename, ProductModelExtensions) {
+		return "", fmt.Errorf("invalid file type: %s", strings.ToLower(filepath.Ext(file.Filename)))
+	}
+	if file.Size > 50*1024*1024 {
+		return "", fmt.Errorf("file too large (max 50MB)")
+	}
+	return s.save(file, "models")
+}
+
+// UploadPressKitAsset stores a media kit file for /press in the "press"
+// subdirectory. Journalists download these files, so the types are limited
+// to the images, documents and archives in PressKitExtensions.
+//
+// Allowed types: jpg, jpeg, png, webp, svg, eps, pdf, zip. Max size: 50MB.
+// File naming convention: {unix_timestamp}_{sanitized_original_filename}
+//
+// Parameters:
+//   - file: Multipart file header from HTTP form upload
+//
+// Returns:
+//   - string: Public URL path to the uploaded file (e.g., "/uploads/press/1234567890_logo.svg")
+//   - error: Non-nil if validation fails or file cannot be saved
+func (s *UploadService) UploadPressKitAsset(file *multipart.FileHeader) (string, error) {
+	if !hasExtension(file.Filename, PressKitExtensions) {
+		return "", fmt.Errorf("invalid file type: %s", strings.ToLower(filepath.Ext(file.Filename)))
+	}
+	if file.Size > 50*1024*1024 {
+		return "", fmt.Errorf("file too large (max 50MB)")
+	}
+	return s.save(file, "press")
+}
+
+// partnerLogoTypes maps the logo extensions accepted from the partner portal
+// to the content they must contain.
+var partnerLogoTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+}
+
+// UploadPartnerLogo stores a logo sent through the partner portal in the
+// "partners" subdirectory. The file comes from outside the admin, so SVG
+// (which can carry scripts) is refused and the content must match the
+// extension.
+//
+// Allowed types: jpg, jpeg, png, webp. Max size: 2MB.
+// File naming convention: {unix_timestamp}_{sanitized_original_filename}
+//
+// Parameters:
+//   - file: Multipart file header from HTTP form upload
+//
+// Returns:
+//   - string: Public URL path to the uploaded logo (e.g., "/uploads/partners/1234567890_logo.png")
+//   - error: Non-nil if validation fails or file cannot be saved
+func (s *UploadService) UploadPartnerLogo(file *multipart.FileHeader) (string, error) {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	mimeType, ok := partnerLogoTypes[ext]
+	if !ok {
+		return "", fmt.Errorf("invalid file type: %s (use JPG, PNG or WebP)", ext)
+	}
+	if file.Size > 2*1024*1024 {
+		return "", fmt.Errorf("file too large (max 2MB)")
+	}
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(src, head)
+	src.Close()
+	if !sniffMatches(mimeType, head[:n]) {
+		return "", fmt.Errorf("file content does not match its %s extension", ext)
+	}
+	return s.save(file, "partners")
+}
+
+// save copies file into the subdirectory dir of the upload root under a
+// timestamped name and returns its public URL path.
+func (s *UploadService) save(file *multipart.FileHeader, dir string) (string, error) {
+	filename := fmt.Sprintf("%d_%s", time.Now().Unix(), sanitizeFilename(file.Filename))
+	if err := os.MkdirAll(filepath.Join(s.uploadDir, dir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+	dst, err := os.Create(filepath.Join(s.uploadDir, dir, filename))
+	if err != nil {
+		return "", fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer dst.Close()
+	if _, err = io.Copy(dst, src); err != nil {
+		return "", fmt.Errorf("failed to copy file: %w", err)
+	}
+	return "/uploads/" + dir + "/" + filename, nil
+}
+
 // sanitizeFilename cleans uploaded filenames to ensure they are safe for filesystem
 // storage and URL paths. This function prevents issues with special characters that
 // could cause problems in file paths or URLs.
@@ -0,0 +1,322 @@
+package services
+
+import (
+	// Standard library imports
+	"bytes"         // Request bodies
+	"context"       // Delivery cancellation
+	"crypto/hmac"   // Payload signatures
+	"crypto/rand"   // Event IDs and endpoint secrets
+	"crypto/sha256" // Payload signatures
+	"encoding/hex"  // Signature, ID and secret formatting
+	"encoding/json" // Event payloads
+	"errors"        // URL validation errors
+	"fmt"           // Delivery error messages
+	"io"            // Draining response bodies
+	"log/slog"      // Dropped and failed deliveries
+	"net/http"      // Delivery requests
+	"net/url"       // URL validation
+	"strconv"       // Timestamp header
+	"strings"       // Event lists
+	"time"          // Timestamps and retry delays
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Webhook endpoints
+)
+
+// Webhook event types. Receivers tell them apart by the X-Bluejay-Event
+// header or the "type" field of the payload.
+const (
+	WebhookContentPublished = "content.published" // Content became public
+	WebhookContentUpdated   = "content.updated"   // Public content or page settings changed
+	WebhookContentDeleted   = "content.deleted"   // Content was trashed or deleted
+	WebhookPing             = "ping"              // Test send from Admin > Webhooks
+)
+
+// WebhookEventTypes lists the event types an endpoint can subscribe to.
+var WebhookEventTypes = []string{WebhookContentPublished, WebhookContentUpdated, WebhookContentDeleted}
+
+const (
+	// webhookQueueSize is how many events wait for delivery before new ones
+	// are dropped (and logged).
+	webhookQueueSize = 256
+
+	// webhookAttempts is how often a delivery is tried before it is given up;
+	// the wait doubles after each failure, starting at Webhooks.retryDelay.
+	webhookAttempts = 3
+)
+
+// ErrWebhookURL is returned by ValidateWebhookURL for anything but an
+// absolute http or https URL.
+var ErrWebhookURL = errors.New("webhook URL must be an absolute http(s) URL")
+
+// WebhookEvent is the JSON body POSTed to webhook endpoints.
+type WebhookEvent struct {
+	ID         string          `json:"id"`                // Unique per event, the same on every retry
+	Type       string          `json:"type"`              // e.g. "content.published"
+	OccurredAt time.Time       `json:"occurred_at"`       // When the change was saved (UTC)
+	Action     string          `json:"action,omitempty"`  // Activity log action, e.g. "trashed"
+	Content    *WebhookContent `json:"content,omitempty"` // Changed item; nil for ping
+}
+
+// WebhookContent identifies the item a webhook event is about.
+type WebhookContent struct {
+	Type  string `json:"type"`            // Content type, e.g. "blog_post"
+	ID    int64  `json:"id"`              // Item ID; 0 for single pages such as "about"
+	Title string `json:"title,omitempty"` // Title, when known
+}
+
+// webhookContentTypes are the activity log resource types that appear on
+// the public site. Changes to anything else (users, leads, backups,
+// settings of the admin panel) send no webhook.
+var webhookContentTypes = map[string]bool{
+	"about": true, "about_settings": true, "blog_author": true, "blog_category": true,
+	"blog_post": true, "blog_series": true, "blog_settings": true, "blog_tag": true,
+	"case_study": true, "certification": true, "content_block": true, "core_value": true,
+	"cta": true, "footer": true, "header": true, "hero": true, "homepage_settings": true,
+	"industry": true, "landing_page": true, "milestone": true, "navigation": true,
+	"office": true, "page": true, "partner": true, "partner_testimonial": true,
+	"partner_tier": true, "product": true, "product_category": true, "products_settings": true,
+	"redirect": true, "seo": true, "solution": true, "solutions_settings": true,
+	"stat": true, "testimonial": true, "translation": true, "whitepaper": true,
+	"whitepaper_topic": true,
+}
+
+// ContentWebhookEvent maps an activity log entry to the webhook event it
+// sends: "published" to content.published; "created", "updated",
+// "restored" and "unpublished" to content.updated; "trashed" and "deleted"
+// to content.deleted. It reports false for other actions and for resource
+// types that are not public content.
+func ContentWebhookEvent(action, resourceType string) (string, bool) {
+	if !webhookContentTypes[resourceType] {
+		return "", false
+	}
+	switch action {
+	case "published":
+		return WebhookContentPublished, true
+	case "created", "updated", "restored", "unpublished":
+		return WebhookContentUpdated, true
+	case "trashed", "deleted":
+		return WebhookContentDeleted, true
+	}
+	return "", false
+}
+
+// WebhookSubscribed reports whether an endpoint with the comma-separated
+// events list receives eventType. A blank list receives every event, and
+// every endpoint receives ping.
+func WebhookSubscribed(events, eventType string) bool {
+	if strings.TrimSpace(events) == "" || eventType == WebhookPing {
+		return true
+	}
+	for _, e := range strings.Split(events, ",") {
+		if strings.TrimSpace(e) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhookURL trims raw and checks it is an absolute http or https
+// URL with a host.
+func ValidateWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrWebhookURL
+	}
+	return raw, nil
+}
+
+// NewWebhookSecret returns a random signing secret for a new endpoint.
+func NewWebhookSecret() string {
+	return "whsec_" + randomHex(24)
+}
+
+// SignWebhook returns the X-Bluejay-Signature header for body sent at the
+// Unix time timestamp: "sha256=" and the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the endpoint's secret. Receivers compute
+// the same value and compare it in constant time, and should reject
+// timestamps more than a few minutes old to stop replays.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Webhooks delivers content events to the endpoints under Admin > Webhooks.
+// Emit queues an event without blocking the request that made the change;
+// a single worker started by Start POSTs each event, signed, to every
+// active endpoint subscribed to it, retrying failures, and records the last
+// outcome on the endpoint.
+type Webhooks struct {
+	queries    *sqlc.Queries     // Endpoints and delivery results
+	logger     *slog.Logger      // Dropped events and failed deliveries
+	client     *http.Client      // Delivery client with a short timeout
+	events     chan WebhookEvent // Events waiting for the worker
+	retryDelay time.Duration     // Wait before the first retry
+}
+
+// NewWebhooks creates the webhook service. Events emitted before Start are
+// kept (up to the queue size) and delivered once it runs.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - logger: Structured logger for dropped events and failed deliveries
+//
+// Returns:
+//   - *Webhooks: Service ready to queue events
+func NewWebhooks(queries *sqlc.Queries, logger *slog.Logger) *Webhooks {
+	return &Webhooks{
+		queries:    queries,
+		logger:     logger,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		events:     make(chan WebhookEvent, webhookQueueSize),
+		retryDelay: 2 * time.Second,
+	}
+}
+
+// SetRetryDelay changes the wait before the first retry (2 seconds by
+// default), for tests.
+func (w *Webhooks) SetRetryDelay(d time.Duration) {
+	w.retryDelay = d
+}
+
+// Emit queues an event for delivery, filling in its ID and time. When the
+// queue is full the event is dropped and logged.
+func (w *Webhooks) Emit(event WebhookEvent) {
+	event.ID = "evt_" + randomHex(12)
+	event.OccurredAt = time.Now().UTC()
+	select {
+	case w.events <- event:
+	default:
+		w.logger.Error("webhook queue full, dropping event", "type", event.Type)
+	}
+}
+
+// Start delivers queued events in the background until ctx is cancelled.
+func (w *Webhooks) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-w.events:
+				w.dispatch(ctx, event)
+			}
+		}
+	}()
+}
+
+// dispatch delivers event to every active endpoint subscribed to it.
+func (w *Webhooks) dispatch(ctx context.Context, event WebhookEvent) {
+	hooks, err := w.queries.ListActiveWebhooks(ctx)
+	if err != nil {
+		w.logger.Error("failed to load webhooks", "error", err)
+		return
+	}
+	for _, hook := range hooks {
+		if WebhookSubscribed(hook.Events, event.Type) {
+			w.Deliver(ctx, hook, event)
+		}
+	}
+}
+
+// Deliver POSTs event to hook, trying up to three times when the endpoint
+// cannot be reached or answers 429 or 5xx, and records the outcome on the
+// endpoint. Other responses are final.
+//
+// Returns:
+//   - error: Why the last attempt failed, nil on a 2xx response
+func (w *Webhooks) Deliver(ctx context.Context, hook sqlc.Webhook, event WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	delay := w.retryDelay
+	var status int
+	for attempt := 1; ; attempt++ {
+		status, err = w.send(ctx, hook, event, body)
+		retry := err != nil && (status == 0 || status == http.StatusTooManyRequests || status >= 500)
+		if !retry || attempt == webhookAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	if err != nil {
+		w.logger.Warn("webhook delivery failed", "webhook", hook.ID, "type", event.Type, "error", err)
+	}
+	w.record(ctx, hook.ID, status, err)
+	return err
+}
+
+// Ping sends a single ping event to hook, without retries, and records the
+// outcome. Used by the test button under Admin > Webhooks.
+func (w *Webhooks) Ping(ctx context.Context, hook sqlc.Webhook) error {
+	event := WebhookEvent{ID: "evt_" + randomHex(12), Type: WebhookPing, OccurredAt: time.Now().UTC()}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	status, err := w.send(ctx, hook, event, body)
+	w.record(ctx, hook.ID, status, err)
+	return err
+}
+
+// record stores the outcome of a delivery on the endpoint. It runs even
+// when ctx was cancelled mid-delivery, so the failure shows in the admin.
+func (w *Webhooks) record(ctx context.Context, id int64, status int, err error) {
+	var lastError string
+	if err != nil {
+		lastError = err.Error()
+	}
+	if rerr := w.queries.RecordWebhookDelivery(context.WithoutCancel(ctx), sqlc.RecordWebhookDeliveryParams{
+		LastStatus: int64(status),
+		LastError:  lastError,
+		ID:         id,
+	}); rerr != nil {
+		w.logger.Error("failed to record webhook delivery", "webhook", id, "error", rerr)
+	}
+}
+
+// send makes one delivery attempt.
+//
+// Returns:
+//   - int: HTTP status of the response, 0 when none came
+//   - error: Transport error, or the status when it is not 2xx
+func (w *Webhooks) send(ctx context.Context, hook sqlc.Webhook, event WebhookEvent, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Bluejay-Webhooks/1")
+	req.Header.Set("X-Bluejay-Event", event.Type)
+	req.Header.Set("X-Bluejay-Delivery", event.ID)
+	req.Header.Set("X-Bluejay-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Bluejay-Signature", SignWebhook(hook.Secret, timestamp, body))
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// randomHex returns n random bytes as hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+func TestContentWebhookEvent(t *testing.T) {
+	tests := []struct {
+		action, resourceType string
+		want                 string
+		ok                   bool
+	}{
+		{"published", "blog_post", services.WebhookContentPublished, true},
+		{"updated", "product", services.WebhookContentUpdated, true},
+		{"unpublished", "blog_post", services.WebhookContentUpdated, true},
+		{"restored", "case_study", services.WebhookContentUpdated, true},
+		{"trashed", "solution", services.WebhookContentDeleted, true},
+		{"deleted", "landing_page", services.WebhookContentDeleted, true},
+		{"submitted", "blog_post", "", false},
+		{"updated", "admin_user", "", false},
+		{"deleted", "backup", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := services.ContentWebhookEvent(tt.action, tt.resourceType)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ContentWebhookEvent(%q, %q) = %q, %v; want %q, %v", tt.action, tt.resourceType, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWebhookSubscribed(t *testing.T) {
+	if !services.WebhookSubscribed("", services.WebhookContentDeleted) {
+		t.Error("blank events list should receive everything")
+	}
+	if !services.WebhookSubscribed("content.published, content.deleted", services.WebhookContentDeleted) {
+		t.Error("listed event not received")
+	}
+	if services.WebhookSubscribed("content.published", services.WebhookContentUpdated) {
+		t.Error("unlisted event received")
+	}
+	if !services.WebhookSubscribed("content.published", services.WebhookPing) {
+		t.Error("ping should reach every endpoint")
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, raw := range []string{"https://example.com/hook", " http://10.0.0.5:8080/rebuild "} {
+		if _, err := services.ValidateWebhookURL(raw); err != nil {
+			t.Errorf("ValidateWebhookURL(%q): %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"", "example.com/hook", "ftp://example.com", "https://", "javascript:alert(1)"} {
+		if _, err := services.ValidateWebhookURL(raw); err == nil {
+			t.Errorf("ValidateWebhookURL(%q) accepted", raw)
+		}
+	}
+}
+
+func TestWebhooks_DeliverRetriesAndRecords(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	var signed atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get("X-Bluejay-Timestamp"), 10, 64)
+		signed.Store(r.Header.Get("X-Bluejay-Signature") == services.SignWebhook("secret", ts, body))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	hook, err := queries.CreateWebhook(ctx, sqlc.CreateWebhookParams{Url: srv.URL, Secret: "secret"})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	w := services.NewWebhooks(queries, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.SetRetryDelay(time.Millisecond)
+
+	event := services.WebhookEvent{ID: "evt_1", Type: services.WebhookContentUpdated, Content: &services.WebhookContent{Type: "product", ID: 7}}
+	if err := w.Deliver(ctx, hook, event); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	if calls.Load() != 3 || !signed.Load() {
+		t.Errorf("calls %d, signed %v; want 3 signed attempts", calls.Load(), signed.Load())
+	}
+	got, _ := queries.GetWebhook(ctx, hook.ID)
+	if got.LastStatus != http.StatusOK || got.LastError != "" || !got.LastDeliveredAt.Valid {
+		t.Errorf("recorded %d %q %v, want 200 without error", got.LastStatus, got.LastError, got.LastDeliveredAt)
+	}
+
+	// A 4xx answer is final and recorded as a failure
+	calls.Store(0)
+	gone := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer gone.Close()
+	hook.Url = gone.URL
+	if err := w.Deliver(ctx, hook, event); err == nil {
+		t.Fatal("Deliver to a 410 endpoint succeeded")
+	}
+	got, _ = queries.GetWebhook(ctx, hook.ID)
+	if calls.Load() != 1 || got.LastStatus != http.StatusGone || got.LastError == "" {
+		t.Errorf("calls %d, recorded %d %q; want one attempt recorded as 410", calls.Load(), got.LastStatus, got.LastError)
+	}
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	// Standard library imports
+	"context"      // Database calls and mail delivery
+	"database/sql" // Nullable user IDs and sql.ErrNoRows
+	"errors"       // Sentinel errors
+	"fmt"          // Policy parse errors and mail text
+	"log/slog"     // Logging of mail failures
+	"strings"      // Policy parsing and mail text
+	"time"         // Mail delivery timeout
+
+	// Internal application imports
+	"github.com/narendhupati/bluejay-cms/db/sqlc" // Generated database query code from sqlc
+)
+
+// Workflow states. Content moves draft -> in_review -> approved -> published;
+// reviewers can send it back to draft, and published content can be taken
+// down to draft again.
+const (
+	WorkflowDraft     = "draft"
+	WorkflowInReview  = "in_review"
+	WorkflowApproved  = "approved"
+	WorkflowPublished = "published"
+)
+
+// workflowStates lists the states in order with their display labels.
+var workflowStates = []struct{ state, label string }{
+	{WorkflowDraft, "Draft"},
+	{WorkflowInReview, "In review"},
+	{WorkflowApproved, "Approved"},
+	{WorkflowPublished, "Published"},
+}
+
+// WorkflowStateLabel returns the display label of a state, e.g. "In review".
+func WorkflowStateLabel(state string) string {
+	for _, s := range workflowStates {
+		if s.state == state {
+			return s.label
+		}
+	}
+	return state
+}
+
+// WorkflowTransition is one edge of the workflow, e.g. draft -> in_review.
+type WorkflowTransition struct {
+	From string
+	To   string
+}
+
+// workflowTransitions are the edges of the workflow, in the order their
+// buttons are shown. A policy can only grant these.
+var workflowTransitions = []WorkflowTransition{
+	{WorkflowDraft, WorkflowInReview},     // Submit for review
+	{WorkflowInReview, WorkflowApproved},  // Approve
+	{WorkflowApproved, WorkflowPublished}, // Publish
+	{WorkflowDraft, WorkflowPublished},    // Publish without review
+	{WorkflowInReview, WorkflowDraft},     // Request changes (or withdraw)
+	{WorkflowApproved, WorkflowDraft},     // Reopen for changes
+	{WorkflowPublished, WorkflowDraft},    // Unpublish
+}
+
+// reviewDecisions are the transitions an item's assigned reviewer may make
+// whatever their role.
+var reviewDecisions = []WorkflowTransition{
+	{WorkflowInReview, WorkflowApproved},
+	{WorkflowInReview, WorkflowDraft},
+}
+
+// WorkflowPolicy lists the transitions each role may make. Roles that are
+// not listed may not change workflow states at all.
+type WorkflowPolicy map[string][]WorkflowTransition
+
+// DefaultWorkflowPolicy lets editors submit their work and withdraw it, and
+// admins make every transition, including publishing without review.
+func DefaultWorkflowPolicy() WorkflowPolicy {
+	return WorkflowPolicy{
+		"editor": {
+			{WorkflowDraft, WorkflowInReview},
+			{WorkflowInReview, WorkflowDraft},
+		},
+		"admin": append([]WorkflowTransition(nil), workflowTransitions...),
+	}
+}
+
+// ParseWorkflowPolicy reads a policy in the WORKFLOW_PERMISSIONS format:
+// semicolon-separated roles, each with a comma-separated list of from>to
+// transitions or "*" for all of them.
+//
+// Example:
+//
+//	editor=draft>in_review,in_review>draft,in_review>approved;admin=*
+func ParseWorkflowPolicy(s string) (WorkflowPolicy, error) {
+	policy := WorkflowPolicy{}
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		role, list, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("workflow policy: %q is not role=transitions", entry)
+		}
+		if strings.TrimSpace(list) == "*" {
+			policy[role] = append([]WorkflowTransition(nil), workflowTransitions...)
+			continue
+		}
+		for _, edge := range strings.Split(list, ",") {
+			from, to, _ := strings.Cut(strings.TrimSpace(edge), ">")
+			t := WorkflowTransition{strings.TrimSpace(from), strings.TrimSpace(to)}
+			if !containsTransition(workflowTransitions, t) {
+				return nil, fmt.Errorf("workflow policy: unknown transition %q for role %s", edge, role)
+			}
+			policy[role] = append(policy[role], t)
+		}
+	}
+	return policy, nil
+}
+
+// ErrTransitionNotAllowed is returned by Transition when the policy does not
+// let the actor make the transition from the item's current state.
+var ErrTransitionNotAllowed = errors.New("workflow transition not allowed")
+
+// WorkflowActor is the user changing a workflow state.
+type WorkflowActor struct {
+	UserID int64  // admin_users.id
+	Role   string // Role looked up in the policy
+	Name   string // Shown in notification emails
+}
+
+// Workflow manages the review states of blog posts and products: which
+// transitions a user may make, the transition history, reviewer assignment,
+// and email notifications. Publishing the content itself (its status column)
+// is left to the caller, which knows the content tables.
+type Workflow struct {
+	queries *sqlc.Queries  // Workflow tables and admin users
+	logger  *slog.Logger   // Mail failures
+	policy  WorkflowPolicy // Transitions per role
+	mailer  Mailer         // Notification delivery; nil disables email
+	baseURL string         // Prefix for links in emails, e.g. "https://cms.example.com"
+}
+
+// NewWorkflow creates the workflow service.
+//
+// Parameters:
+//   - queries: Database query interface from sqlc
+//   - logger: Structured logger for notification failures
+//   - policy: Transitions per role; nil uses DefaultWorkflowPolicy
+//   - mailer: Notification delivery; nil sends no email
+//   - baseURL: Site URL that admin links in emails are relative to
+//
+// Returns:
+//   - *Workflow: Service ready to use
+func NewWorkflow(queries *sqlc.Queries, logger *slog.Logger, policy WorkflowPolicy, mailer Mailer, baseURL string) *Workflow {
+	if policy == nil {
+		policy = DefaultWorkflowPolicy()
+	}
+	return &Workflow{queries: queries, logger: logger, policy: policy, mailer: mailer, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// State returns an item's workflow row. Items that never entered the
+// workflow are reported in the state matching their status column:
+// published content is published, anything else is a draft.
+//
+// Parameters:
+//   - ctx: Context for the query
+//   - contentType: "blog_post" or "product"
+//   - id: Content ID
+//   - status: The item's status column
+func (w *Workflow) State(ctx context.Context, contentType string, id int64, status string) (sqlc.ContentWorkflow, error) {
+	item, err := w.queries.GetContentWorkflow(ctx, sqlc.GetContentWorkflowParams{ContentType: contentType, ContentID: id})
+	if errors.Is(err, sql.ErrNoRows) {
+		item = sqlc.ContentWorkflow{ContentType: contentType, ContentID: id, State: WorkflowDraft}
+		if status == WorkflowPublished {
+			item.State = WorkflowPublished
+		}
+		return item, nil
+	}
+	return item, err
+}
+
+// Allowed reports whether actor may move item to the state to.
+func (w *Workflow) Allowed(actor WorkflowActor, item sqlc.ContentWorkflow, to string) bool {
+	t := WorkflowTransition{item.State, to}
+	if containsTransition(w.policy[actor.Role], t) {
+		return true
+	}
+	return item.ReviewerID.Valid && item.ReviewerID.Int64 == actor.UserID && containsTransition(reviewDecisions, t)
+}
+
+// Next returns the states actor may move item to, in button order.
+func (w *Workflow) Next(actor WorkflowActor, item sqlc.ContentWorkflow) []string {
+	var next []string
+	for _, t := range workflowTransitions {
+		if t.From == item.State && w.Allowed(actor, item, t.To) {
+			next = append(next, t.To)
+		}
+	}
+	return next
+}
+
+// Transition moves item to the state to and records it in the history.
+// Submitting for review makes actor the item's submitter, who is told about
+// the review outcome. Notifications are sent in the background.
+//
+// Parameters:
+//   - ctx: Context for the database writes
+//   - item: Current workflow row (from State)
+//   - to: Target state
+//   - actor: User making the transition
+//   - title: Content title for notifications
+//   - link: Admin path of the item's editor, e.g. "/admin/blog/posts/3/edit"
+//   - note: Optional comment, e.g. the changes a reviewer asks for
+//
+// Returns:
+//   - sqlc.ContentWorkflow: The updated row
+//   - error: ErrTransitionNotAllowed or a database error
+func (w *Workflow) Transition(ctx context.Context, item sqlc.ContentWorkflow, to string, actor WorkflowActor, title, link, note string) (sqlc.ContentWorkflow, error) {
+	if !w.Allowed(actor, item, to) {
+		return item, ErrTransitionNotAllowed
+	}
+	from := item.State
+	item.State = to
+	if to == WorkflowInReview {
+		item.SubmittedBy = sql.NullInt64{Int64: actor.UserID, Valid: actor.UserID > 0}
+	}
+	if err := w.save(ctx, item); err != nil {
+		return item, err
+	}
+	if err := w.queries.CreateContentWorkflowHistory(ctx, sqlc.CreateContentWorkflowHistoryParams{
+		ContentType: item.ContentType,
+		ContentID:   item.ContentID,
+		FromState:   from,
+		ToState:     to,
+		UserID:      sql.NullInt64{Int64: actor.UserID, Valid: actor.UserID > 0},
+		Note:        strings.TrimSpace(note),
+	}); err != nil {
+		return item, err
+	}
+	w.notifyTransition(ctx, item, from, actor, title, link, note)
+	return item, nil
+}
+
+// AssignReviewer sets or clears (reviewerID 0) an item's reviewer. A
+// reviewer assigned while the item is in review is notified.
+//
+// Parameters:
+//   - ctx: Context for the database write
+//   - item: Current workflow row (from State)
+//   - reviewerID: admin_users.id of the reviewer, 0 for none
+//   - actor: User making the assignment
+//   - title, link: Content title and editor path for the notification
+//
+// Returns:
+//   - sqlc.ContentWorkflow: The updated row
+//   - error: Database error
+func (w *Workflow) AssignReviewer(ctx context.Context, item sqlc.ContentWorkflow, reviewerID int64, actor WorkflowActor, title, link string) (sqlc.ContentWorkflow, error) {
+	changed := item.ReviewerID.Int64 != reviewerID
+	item.ReviewerID = sql.NullInt64{Int64: reviewerID, Valid: reviewerID > 0}
+	if err := w.save(ctx, item); err != nil {
+		return item, err
+	}
+	if changed && reviewerID > 0 && reviewerID != actor.UserID && item.State == WorkflowInReview {
+		w.notify(ctx, []int64{reviewerID}, fmt.Sprintf("Review requested: %s", title),
+			fmt.Sprintf("%s asked you to review %q.", actor.Name, title), link)
+	}
+	return item, nil
+}
+
+// save writes the workflow row.
+func (w *Workflow) save(ctx context.Context, item sqlc.ContentWorkflow) error {
+	return w.queries.SaveContentWorkflow(ctx, sqlc.SaveContentWorkflowParams{
+		ContentType: item.ContentType,
+		ContentID:   item.ContentID,
+		State:       item.State,
+		ReviewerID:  item.ReviewerID,
+		SubmittedBy: item.SubmittedBy,
+	})
+}
+
+// notifyTransition emails the people a state change concerns: reviewers
+// when work is submitted (the assigned reviewer, else everyone who may
+// approve), and the submitter and reviewer about decisions and publishing.
+// The actor is never emailed about their own change.
+func (w *Workflow) notifyTransition(ctx context.Context, item sqlc.ContentWorkflow, from string, actor WorkflowActor, title, link, note string) {
+	var recipients []int64
+	var subject string
+	switch {
+	case item.State == WorkflowInReview:
+		subject = "Review requested: " + title
+		if item.ReviewerID.Valid {
+			recipients = []int64{item.ReviewerID.Int64}
+		} else {
+			recipients = w.usersAllowed(ctx, WorkflowTransition{WorkflowInReview, WorkflowApproved})
+		}
+	case item.State == WorkflowApproved:
+		subject = "Approved: " + title
+		recipients = append([]int64{item.SubmittedBy.Int64}, w.usersAllowed(ctx, WorkflowTransition{WorkflowApproved, WorkflowPublished})...)
+	case item.State == WorkflowPublished:
+		subject = "Published: " + title
+		recipients = []int64{item.SubmittedBy.Int64, item.ReviewerID.Int64}
+	case from == WorkflowInReview:
+		subject = "Changes requested: " + title
+		recipients = []int64{item.SubmittedBy.Int64}
+	default:
+		subject = "Moved back to draft: " + title
+		recipients = []int64{item.SubmittedBy.Int64, item.ReviewerID.Int64}
+	}
+
+	body := fmt.Sprintf("%s moved %q from %s to %s.", actor.Name, title, WorkflowStateLabel(from), WorkflowStateLabel(item.State))
+	if note = strings.TrimSpace(note); note != "" {
+		body += "\n\nNote:\n" + note
+	}
+	ids := recipients[:0]
+	for _, id := range recipients {
+		if id > 0 && id != actor.UserID {
+			ids = append(ids, id)
+		}
+	}
+	w.notify(ctx, ids, subject, body, link)
+}
+
+// usersAllowed returns the active users whose role may make transition t.
+func (w *Workflow) usersAllowed(ctx context.Context, t WorkflowTransition) []int64 {
+	users, err := w.queries.ListAdminUsers(ctx)
+	if err != nil {
+		w.logger.Error("failed to list workflow recipients", "error", err)
+		return nil
+	}
+	var ids []int64
+	for _, u := range users {
+		if u.IsActive == 1 && containsTransition(w.policy[u.Role], t) {
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids
+}
+
+// notify emails the given users in the background. Delivery failures are
+// logged; they never fail the transition.
+func (w *Workflow) notify(ctx context.Context, userIDs []int64, subject, body, link string) {
+	if w.mailer == nil || len(userIDs) == 0 {
+		return
+	}
+	users, err := w.queries.ListAdminUsers(ctx)
+	if err != nil {
+		w.logger.Error("failed to look up workflow recipients", "error", err)
+		return
+	}
+	var to []string
+	for _, u := range users {
+		if u.IsActive == 1 && containsID(userIDs, u.ID) && !containsString(to, u.Email) {
+			to = append(to, u.Email)
+		}
+	}
+	if len(to) == 0 {
+		return
+	}
+	if link != "" {
+		body += "\n\nOpen it in the admin: " + w.baseURL + link
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := w.mailer.Send(ctx, to, subject, body); err != nil {
+			w.logger.Error("failed to send workflow notification", "subject", subject, "error", err)
+		}
+	}()
+}
+
+func containsTransition(list []WorkflowTransition, t WorkflowTransition) bool {
+	for _, x := range list {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(list []int64, id int64) bool {
+	for _, x := range list {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"
+	"github.com/narendhupati/bluejay-cms/internal/services"
+	"github.com/narendhupati/bluejay-cms/internal/testutil"
+)
+
+// sentMail is one message handed to recordingMailer.
+type sentMail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+// recordingMailer delivers messages to a channel instead of a server.
+type recordingMailer struct {
+	sent chan sentMail
+}
+
+func (m *recordingMailer) Send(ctx context.Context, to []string, subject, body string) error {
+	m.sent <- sentMail{to: to, subject: subject, body: body}
+	return nil
+}
+
+// next waits for the next message sent in the background.
+func (m *recordingMailer) next(t *testing.T) sentMail {
+	t.Helper()
+	select {
+	case msg := <-m.sent:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification sent")
+		return sentMail{}
+	}
+}
+
+func TestWorkflow_DefaultPolicy(t *testing.T) {
+	w := services.NewWorkflow(nil, nil, nil, nil, "")
+	editor := services.WorkflowActor{UserID: 2, Role: "editor"}
+	admin := services.WorkflowActor{UserID: 1, Role: "admin"}
+	draft := sqlc.ContentWorkflow{State: services.WorkflowDraft}
+	inReview := sqlc.ContentWorkflow{State: services.WorkflowInReview}
+
+	if got := strings.Join(w.Next(editor, draft), ","); got != "in_review" {
+		t.Errorf("editor on draft: next = %q, want in_review", got)
+	}
+	if w.Allowed(editor, draft, services.WorkflowPublished) {
+		t.Error("editor should not publish without review")
+	}
+	if w.Allowed(editor, inReview, services.WorkflowApproved) {
+		t.Error("editor should not approve")
+	}
+	if got := strings.Join(w.Next(admin, draft), ","); got != "in_review,published" {
+		t.Errorf("admin on draft: next = %q, want in_review,published", got)
+	}
+	if w.Allowed(services.WorkflowActor{Role: "viewer"}, draft, services.WorkflowInReview) {
+		t.Error("unknown role should make no transitions")
+	}
+
+	// The assigned reviewer may decide whatever their role, but not publish
+	assigned := sqlc.ContentWorkflow{State: services.WorkflowInReview, ReviewerID: sql.NullInt64{Int64: 2, Valid: true}}
+	if got := strings.Join(w.Next(editor, assigned), ","); got != "approved,draft" {
+		t.Errorf("assigned reviewer: next = %q, want approved,draft", got)
+	}
+	assigned.State = services.WorkflowApproved
+	if w.Allowed(editor, assigned, services.WorkflowPublished) {
+		t.Error("assigned editor should not publish")
+	}
+}
+
+func TestParseWorkflowPolicy(t *testing.T) {
+	policy, err := services.ParseWorkflowPolicy("editor = draft>in_review, in_review>approved ; admin=*")
+	if err != nil {
+		t.Fatalf("ParseWorkflowPolicy: %v", err)
+	}
+	w := services.NewWorkflow(nil, nil, policy, nil, "")
+	editor := services.WorkflowActor{Role: "editor"}
+	if !w.Allowed(editor, sqlc.ContentWorkflow{State: services.WorkflowInReview}, services.WorkflowApproved) {
+		t.Error("policy should let editors approve")
+	}
+	if w.Allowed(editor, sqlc.ContentWorkflow{State: services.WorkflowInReview}, services.WorkflowDraft) {
+		t.Error("policy should not let editors withdraw")
+	}
+	if !w.Allowed(services.WorkflowActor{Role: "admin"}, sqlc.ContentWorkflow{State: services.WorkflowPublished}, services.WorkflowDraft) {
+		t.Error("* should allow every transition")
+	}
+
+	for _, bad := range []string{"editor", "=draft>in_review", "editor=draft>approved", "editor=draft"} {
+		if _, err := services.ParseWorkflowPolicy(bad); err == nil {
+			t.Errorf("ParseWorkflowPolicy(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWorkflow_TransitionsAndNotifications(t *testing.T) {
+	_, queries, cleanup := testutil.SetupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newUser := func(email, name, role string) services.WorkflowActor {
+		u, err := queries.CreateAdminUser(ctx, sqlc.CreateAdminUserParams{Email: email, PasswordHash: "x", DisplayName: name, Role: role})
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		return services.WorkflowActor{UserID: u.ID, Role: role, Name: name}
+	}
+	admin := newUser("admin@example.com", "Ada", "admin")
+	editor := newUser("editor@example.com", "Eve", "editor")
+
+	mailer := &recordingMailer{sent: make(chan sentMail, 4)}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	w := services.NewWorkflow(queries, logger, nil, mailer, "https://cms.example.com/")
+	link := "/admin/blog/posts/7/edit"
+
+	item, err := w.State(ctx, "blog_post", 7, "draft")
+	if err != nil || item.State != services.WorkflowDraft {
+		t.Fatalf("State = %q, %v; want draft", item.State, err)
+	}
+	if published, _ := w.State(ctx, "blog_post", 8, "published"); published.State != services.WorkflowPublished {
+		t.Errorf("published content without a workflow row should be published, got %q", published.State)
+	}
+
+	if _, err := w.Transition(ctx, item, services.WorkflowPublished, editor, "Launch", link, ""); !errors.Is(err, services.ErrTransitionNotAllowed) {
+		t.Fatalf("editor publish: err = %v, want ErrTransitionNotAllowed", err)
+	}
+
+	// Submitting emails everyone who may approve
+	item, err = w.Transition(ctx, item, services.WorkflowInReview, editor, "Launch", link, "")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	msg := mailer.next(t)
+	if strings.Join(msg.to, ",") != "admin@example.com" || msg.subject != "Review requested: Launch" {
+		t.Errorf("submit mail = %v %q", msg.to, msg.subject)
+	}
+	if !strings.Contains(msg.body, "https://cms.example.com/admin/blog/posts/7/edit") {
+		t.Errorf("mail body has no link: %q", msg.body)
+	}
+
+	// Requesting changes emails the submitter with the note
+	item, err = w.Transition(ctx, item, services.WorkflowDraft, admin, "Launch", link, "Fix the intro")
+	if err != nil {
+		t.Fatalf("request changes: %v", err)
+	}
+	msg = mailer.next(t)
+	if strings.Join(msg.to, ",") != "editor@example.com" || !strings.HasPrefix(msg.subject, "Changes requested") || !strings.Contains(msg.body, "Fix the intro") {
+		t.Errorf("changes mail = %v %q %q", msg.to, msg.subject, msg.body)
+	}
+
+	// Assigning a reviewer to a submitted item emails them only
+	item, _ = w.Transition(ctx, item, services.WorkflowInReview, editor, "Launch", link, "")
+	mailer.next(t)
+	if item, err = w.AssignReviewer(ctx, item, admin.UserID, editor, "Launch", link); err != nil {
+		t.Fatalf("AssignReviewer: %v", err)
+	}
+	if msg = mailer.next(t); strings.Join(msg.to, ",") != "admin@example.com" {
+		t.Errorf("reviewer mail to %v", msg.to)
+	}
+
+	item, _ = w.Transition(ctx, item, services.WorkflowApproved, admin, "Launch", link, "")
+	if msg = mailer.next(t); strings.Join(msg.to, ",") != "editor@example.com" || msg.subject != "Approved: Launch" {
+		t.Errorf("approve mail = %v %q", msg.to, msg.subject)
+	}
+	if _, err := w.Transition(ctx, item, services.WorkflowPublished, admin, "Launch", link, ""); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if msg = mailer.next(t); strings.Join(msg.to, ",") != "editor@example.com" || msg.subject != "Published: Launch" {
+		t.Errorf("publish mail = %v %q", msg.to, msg.subject)
+	}
+
+	saved, err := w.State(ctx, "blog_post", 7, "draft")
+	if err != nil || saved.State != services.WorkflowPublished || saved.ReviewerID.Int64 != admin.UserID || saved.SubmittedBy.Int64 != editor.UserID {
+		t.Errorf("saved row = %+v, %v", saved, err)
+	}
+	history, err := queries.ListContentWorkflowHistory(ctx, sqlc.ListContentWorkflowHistoryParams{ContentType: "blog_post", ContentID: 7})
+	if err != nil || len(history) != 5 {
+		t.Fatalf("history = %d rows, %v; want 5", len(history), err)
+	}
+	if history[0].ToState != services.WorkflowPublished || history[0].UserName != "Ada" {
+		t.Errorf("latest history entry = %+v", history[0])
+	}
+
+	queue, err := queries.ListReviewQueue(ctx, 0)
+	if err != nil || len(queue) != 0 {
+		t.Errorf("review queue = %d rows, %v; published content should not be queued", len(queue), err)
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	// Standard library imports
+	"archive/zip"  // XLSX files are zip packages
+	"encoding/xml" // Escaping cell text
+	"io"           // Destination of the workbook
+	"strconv"      // Row numbers in cell references
+	"strings"      // Building escaped cell text
+)
+
+// xlsxStaticParts are the fixed package parts of a one-sheet workbook; the
+// workbook (sheet name) and sheet are written by NewXLSXWriter. Cells are
+// inline strings, so no shared string table (which would have to be built in
+// memory) is needed.
+var xlsxStaticParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// XLSXWriter streams rows into a single-sheet Excel workbook. Rows are
+// compressed and written as they arrive, so memory use does not grow with
+// the number of rows. Every cell is text.
+type XLSXWriter struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	row   int
+	err   error
+}
+
+// NewXLSXWriter starts a workbook with one sheet named sheetName.
+//
+// Parameters:
+//   - w: Destination, e.g. the HTTP response
+//   - sheetName: Sheet tab name (at most 31 characters in Excel)
+//
+// Returns:
+//   - *XLSXWriter: Writer for the sheet's rows; Close must be called
+//   - error: Write failures
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+	workbook := struct{ name, body string }{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="` + xmlEscape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`}
+	for _, part := range append([]struct{ name, body string }{workbook}, xlsxStaticParts...) {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+	return &XLSXWriter{zw: zw, sheet: sheet}, nil
+}
+
+// Write appends one row. After an error, further writes are ignored and the
+// error is returned again.
+func (x *XLSXWriter) Write(record []string) error {
+	if x.err != nil {
+		return x.err
+	}
+	x.row++
+	n := strconv.Itoa(x.row)
+	buf := make([]byte, 0, 64*len(record))
+	buf = append(buf, `<row r="`+n+`">`...)
+	for i, v := range record {
+		if v == "" {
+			continue
+		}
+		buf = append(buf, `<c r="`+xlsxColumn(i)+n+`" t="inlineStr"><is><t xml:space="preserve">`...)
+		buf = append(buf, xmlEscape(v)...)
+		buf = append(buf, `</t></is></c>`...)
+	}
+	buf = append(buf, `</row>`...)
+	_, x.err = x.sheet.Write(buf)
+	return x.err
+}
+
+// Flush sends the rows written so far to the destination.
+func (x *XLSXWriter) Flush() error {
+	if x.err != nil {
+		return x.err
+	}
+	return x.zw.Flush()
+}
+
+// Close ends the sheet and the workbook. It does not close the destination.
+func (x *XLSXWriter) Close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
+
+// xlsxColumn returns the column letters for a zero-based index (0 = A, 26 = AA).
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xmlEscape escapes text for XML, replacing characters XML cannot contain.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,27 @@
+package templates
+
+import (
+	"html/template" // Function map of the compiled templates
+	"strings"       // Trimming asset names
+
+	"github.com/narendhupati/bluejay-cms/internal/services" // Content-hashed asset URLs
+)
+
+// assetURL is the asset template function until UseAssets is called: the
+// plain /public URL of the file, as used by tests.
+func assetURL(name string) string {
+	return "/public/" + strings.TrimPrefix(name, "/")
+}
+
+// UseAssets makes the asset template function ({{asset "css/styles.css"}})
+// return content-hashed URLs from assets, which the static file handler
+// serves with a far-future Cache-Control. It must be called before the first
+// render.
+//
+// Parameters:
+//   - assets: Resolver of the files served under /public
+func (r *Renderer) UseAssets(assets *services.Assets) {
+	for _, tmpl := range r.templates {
+		tmpl.Funcs(template.FuncMap{"asset": assets.URL})
+	}
+}
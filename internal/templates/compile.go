@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"errors"        // Aggregating parse errors from every template set
+	"fmt"           // Prefixing errors with the template set name
+	"html/template" // Template set parsing
+	"runtime"       // Worker pool size
+	"sync"          // Worker pool coordination
+	"time"          // Startup timing
+)
+
+// templateJob is one template set queued by loadTemplates.
+type templateJob struct {
+	name   string   // Renderer key, e.g. "admin/pages/dashboard.html"
+	source string   // Inline body of "base" parsed before files; empty for file-only sets
+	files  []string // Files parsed into the set
+}
+
+// templateJobs collects the template sets loadTemplates registers.
+type templateJobs struct {
+	list []templateJob
+}
+
+// add queues a set parsed from files; one of them defines "base".
+func (j *templateJobs) add(name string, files ...string) {
+	j.list = append(j.list, templateJob{name: name, files: files})
+}
+
+// addSource queues a set whose "base" is source, for fragments that render a
+// named template from files on its own.
+func (j *templateJobs) addSource(name, source string, files ...string) {
+	j.list = append(j.list, templateJob{name: name, source: source, files: files})
+}
+
+// LoadStats describes how long startup template compilation took.
+type LoadStats struct {
+	Sets     int           // Template sets compiled
+	Workers  int           // Parallel parsers used
+	Duration time.Duration // Wall-clock time for all sets
+}
+
+// LoadStats returns the startup compilation timing, for logging.
+func (r *Renderer) LoadStats() LoadStats {
+	return r.stats
+}
+
+// compile parses the queued template sets with a pool of GOMAXPROCS workers.
+// A failing set does not stop the others, so every broken template is
+// reported in one joined error, in registration order.
+//
+// Parameters:
+//   - jobs: Template sets queued by loadTemplates
+//   - funcMap: Functions available to every template
+//
+// Returns:
+//   - error: Parse errors of all failing sets, nil when every set parsed
+func (r *Renderer) compile(jobs *templateJobs, funcMap template.FuncMap) error {
+	start := time.Now()
+	workers := min(runtime.GOMAXPROCS(0), len(jobs.list))
+
+	parsed := make([]*template.Template, len(jobs.list))
+	errs := make([]error, len(jobs.list))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				parsed[i], errs[i] = parseJob(jobs.list[i], funcMap)
+			}
+		}()
+	}
+	for i := range jobs.list {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for i, job := range jobs.list {
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s: %w", job.name, errs[i])
+			continue
+		}
+		r.templates[job.name] = parsed[i]
+	}
+	r.stats = LoadStats{Sets: len(jobs.list), Workers: workers, Duration: time.Since(start)}
+	return errors.Join(errs...)
+}
+
+// parseJob parses one template set named "base".
+func parseJob(job templateJob, funcMap template.FuncMap) (*template.Template, error) {
+	t := template.New("base").Funcs(funcMap)
+	if job.source != "" {
+		if _, err := t.Parse(job.source); err != nil {
+			return nil, err
+		}
+	}
+	return t.ParseFiles(job.files...)
+}
@@ -0,0 +1,243 @@
+package templates
+
+import (
+	"errors"        // dict argument errors
+	"fmt"           // Error messages
+	"html/template" // template.HTML for rendered Markdown
+	"math"          // Rounding in humanize
+	"reflect"       // Numbers of any Go type
+	"time"          // timeago
+	"unicode"       // Symbol placement
+
+	"golang.org/x/text/currency" // ISO 4217 symbols and minor units
+	"golang.org/x/text/language" // Formatting locale
+	"golang.org/x/text/message"  // Locale-aware number printing
+	"golang.org/x/text/number"   // Decimal formatting options
+
+	"github.com/narendhupati/bluejay-cms/internal/services" // Site format and Markdown renderer
+)
+
+// suffixSymbolLanguages write the currency symbol after the amount
+// ("1.234,50 €"); other languages put it first ("$1,234.50"). x/text has
+// the symbols and separators of each locale but not this position.
+var suffixSymbolLanguages = map[string]bool{
+	"de": true, "fr": true, "es": true, "it": true, "pt": true, "pl": true,
+	"cs": true, "sk": true, "sv": true, "da": true, "fi": true, "nb": true,
+	"no": true, "ru": true, "uk": true, "el": true, "hu": true, "ro": true,
+}
+
+// money formats an amount as a price in the site's formatting locale
+// (Global Settings), with the currency's usual number of decimals.
+//
+// Parameters:
+//   - amount: Any Go number
+//   - code: Optional ISO 4217 code; the site currency when absent or unknown
+//
+// Returns:
+//   - string: e.g. "$1,234.50" (en-US), "1.234,50 €" (de-DE), "¥1,235" (JPY)
+//
+// Usage in templates: {{money .Price}} or {{money .Price "EUR"}}
+func money(amount interface{}, code ...string) string {
+	f := services.SiteFormat()
+	unit := f.Currency
+	if len(code) > 0 {
+		if u, err := services.LoadCurrency(code[0]); err == nil {
+			unit = u
+		}
+	}
+	return formatMoney(toFloat(amount), unit, f.Locale)
+}
+
+// formatMoney is money for a given currency and locale.
+func formatMoney(amount float64, unit currency.Unit, tag language.Tag) string {
+	p := message.NewPrinter(tag)
+	scale, _ := currency.Standard.Rounding(unit)
+	// Prices round half away from zero; x/text would round half to even
+	pow := math.Pow10(scale)
+	digits := p.Sprint(number.Decimal(math.Round(math.Abs(amount)*pow)/pow, number.Scale(scale)))
+	symbol := p.Sprint(currency.Symbol(unit))
+	sign := ""
+	if amount < 0 && digits != p.Sprint(number.Decimal(0, number.Scale(scale))) {
+		sign = "-"
+	}
+
+	if base, _ := tag.Base(); suffixSymbolLanguages[base.String()] {
+		return sign + digits + "\u00a0" + symbol // No-break space, as in CLDR
+	}
+	// Letter symbols such as CHF need a space: "CHF 12.50" but "$12.50"
+	if r := []rune(symbol); unicode.IsLetter(r[len(r)-1]) {
+		symbol += "\u00a0"
+	}
+	return sign + symbol + digits
+}
+
+// pluralize picks the singular or plural form of a word for a count.
+//
+// Parameters:
+//   - count: Any Go number
+//   - singular: Word for a count of 1 (e.g. "post")
+//   - plural: Optional plural form; singular + "s" when absent
+//
+// Returns:
+//   - string: The word only, so templates control how the count is shown
+//
+// Usage in templates: {{.Total}} {{pluralize .Total "entry" "entries"}}
+func pluralize(count interface{}, singular string, plural ...string) string {
+	if n := toFloat(count); n == 1 || n == -1 {
+		return singular
+	}
+	if len(plural) > 0 {
+		return plural[0]
+	}
+	return singular + "s"
+}
+
+// humanizeUnits are the suffixes of humanize, one per factor of 1000.
+var humanizeUnits = []string{"", "k", "M", "B", "T"}
+
+// humanize shortens a large number to at most one decimal and a unit
+// suffix, in the site's formatting locale. Numbers below 1000 are shown
+// whole.
+//
+// Parameters:
+//   - n: Any Go number
+//
+// Returns:
+//   - string: e.g. "950", "1.2k", "3M", "1,2k" (de-DE)
+//
+// Usage in templates: {{humanize .Views}} views
+func humanize(n interface{}) string {
+	return humanizeIn(toFloat(n), services.SiteFormat().Locale)
+}
+
+// humanizeIn is humanize for a given locale.
+func humanizeIn(v float64, tag language.Tag) string {
+	p := message.NewPrinter(tag)
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	if v < 1000 {
+		return sign + p.Sprint(number.Decimal(math.Round(v)))
+	}
+	unit := 0
+	for unit < len(humanizeUnits)-1 && v >= 1000 {
+		v /= 1000
+		unit++
+	}
+	// 999,950 rounds to 1000k; show it as 1M
+	if v = math.Round(v*10) / 10; v >= 1000 && unit < len(humanizeUnits)-1 {
+		v /= 1000
+		unit++
+	}
+	return sign + p.Sprint(number.Decimal(v, number.MaxFractionDigits(1))) + humanizeUnits[unit]
+}
+
+// markdown renders Markdown source with the same safe renderer as
+// Markdown blog posts (see services.RenderMarkdown): raw HTML and script
+// URLs in the source are dropped, so the result needs no safeHTML.
+//
+// Usage in templates: {{.Summary | markdown}}
+func markdown(source string) template.HTML {
+	html, err := services.RenderMarkdown(source)
+	if err != nil {
+		return ""
+	}
+	return template.HTML(html)
+}
+
+// dict builds a map from key/value pairs, for passing several values into
+// a partial.
+//
+// Returns an error, which stops the render, for an odd number of arguments
+// or a key that is not a string.
+//
+// Usage in templates: {{template "card" dict "Product" . "Compact" true}}
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict: expected key/value pairs")
+	}
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// list builds a slice from its arguments, for range loops and partials
+// (the built-in slice only slices an existing value).
+//
+// Usage in templates: {{range list "a" "b"}} or {{template "tags" list .Tag1 .Tag2}}
+func list(items ...interface{}) []interface{} {
+	return items
+}
+
+// timeago describes how long ago (or how far ahead) t is.
+//
+// Returns:
+//   - string: "just now", "5 minutes ago", "in 3 hours", "yesterday",
+//     "12 days ago"; times a month or more away are shown as a date in
+//     the site timezone, and a zero time as ""
+//
+// Usage in templates: {{timeago .CreatedAt}}
+func timeago(t time.Time) string {
+	return timeagoFrom(t, time.Now())
+}
+
+// timeagoFrom is timeago relative to now.
+func timeagoFrom(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	ago := func(n int, word string) string {
+		s := fmt.Sprintf("%d %s", n, pluralize(n, word))
+		if future {
+			return "in " + s
+		}
+		return s + " ago"
+	}
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return ago(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		return ago(int(d/time.Hour), "hour")
+	case d < 48*time.Hour:
+		if future {
+			return "tomorrow"
+		}
+		return "yesterday"
+	case d < 30*24*time.Hour:
+		return ago(int(d/(24*time.Hour)), "day")
+	}
+	return formatDate(t, "Jan 2, 2006")
+}
+
+// toFloat converts any Go number (and pointers to one) to float64, so the
+// number functions accept int, int64 and float64 fields alike. Other values
+// count as 0.
+func toFloat(v interface{}) float64 {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	return 0
+}
@@ -0,0 +1,198 @@
+package templates
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+
+	"github.com/narendhupati/bluejay-cms/internal/services"
+)
+
+func TestMoney(t *testing.T) {
+	cases := []struct {
+		amount float64
+		unit   currency.Unit
+		locale string
+		want   string
+	}{
+		{1234.5, currency.USD, "en-US", "$1,234.50"},
+		{-12.5, currency.USD, "en-US", "-$12.50"},
+		{1234.5, currency.EUR, "de-DE", "1.234,50\u00a0€"},
+		{1234.5, currency.EUR, "fr-FR", "1\u00a0234,50\u00a0€"},
+		{1234.5, currency.JPY, "en-US", "¥1,235"},
+		{1234.5, currency.CHF, "en-GB", "CHF\u00a01,234.50"},
+		{1234567, currency.INR, "en-IN", "₹12,34,567.00"},
+		{-0.001, currency.USD, "en-US", "$0.00"},
+	}
+	for _, c := range cases {
+		if got := formatMoney(c.amount, c.unit, language.MustParse(c.locale)); got != c.want {
+			t.Errorf("formatMoney(%v, %v, %s) = %q, want %q", c.amount, c.unit, c.locale, got, c.want)
+		}
+	}
+
+	// The template function uses the site format and accepts any number
+	t.Cleanup(func() { services.SetSiteFormat(services.DefaultFormatLocale, services.DefaultCurrency) })
+	services.SetSiteFormat("de-DE", "EUR")
+	if got := money(int64(20)); got != "20,00\u00a0€" {
+		t.Errorf("money(20) = %q", got)
+	}
+	if got := money(20, "usd"); got != "20,00\u00a0$" {
+		t.Errorf("money(20, usd) = %q", got)
+	}
+	if got := money(20, "nope"); got != "20,00\u00a0€" {
+		t.Errorf("unknown currency should fall back to the site's: %q", got)
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	cases := []struct {
+		count interface{}
+		words []string
+		want  string
+	}{
+		{1, []string{"post"}, "post"},
+		{int64(0), []string{"post"}, "posts"},
+		{2, []string{"post"}, "posts"},
+		{1.0, []string{"entry", "entries"}, "entry"},
+		{3, []string{"entry", "entries"}, "entries"},
+	}
+	for _, c := range cases {
+		if got := pluralize(c.count, c.words[0], c.words[1:]...); got != c.want {
+			t.Errorf("pluralize(%v, %q) = %q, want %q", c.count, c.words, got, c.want)
+		}
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	en, de := language.AmericanEnglish, language.German
+	cases := []struct {
+		n    float64
+		tag  language.Tag
+		want string
+	}{
+		{0, en, "0"},
+		{950, en, "950"},
+		{999.6, en, "1,000"},
+		{1000, en, "1k"},
+		{1234, en, "1.2k"},
+		{-1234, en, "-1.2k"},
+		{999950, en, "1M"},
+		{3_000_000, en, "3M"},
+		{4_560_000_000, en, "4.6B"},
+		{1234, de, "1,2k"},
+	}
+	for _, c := range cases {
+		if got := humanizeIn(c.n, c.tag); got != c.want {
+			t.Errorf("humanizeIn(%v, %v) = %q, want %q", c.n, c.tag, got, c.want)
+		}
+	}
+	if got := humanize(int64(12345)); got != "12.3k" {
+		t.Errorf("humanize(12345) = %q", got)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	got := string(markdown("**Rugged** sensor <script>alert(1)</script> [x](javascript:alert(1))"))
+	if !strings.Contains(got, "<strong>Rugged</strong>") {
+		t.Errorf("not rendered: %s", got)
+	}
+	if strings.Contains(got, "<script>") || strings.Contains(got, "javascript:") {
+		t.Errorf("unsafe output: %s", got)
+	}
+}
+
+func TestDictAndList(t *testing.T) {
+	if _, err := dict("a"); err == nil {
+		t.Error("odd number of arguments accepted")
+	}
+	if _, err := dict(1, "a"); err == nil {
+		t.Error("non-string key accepted")
+	}
+
+	// Passing several values into a partial
+	tmpl := template.Must(template.New("page").Funcs(template.FuncMap{"dict": dict, "list": list}).Parse(
+		`{{define "card"}}{{.Name}}{{if .Compact}} (compact){{end}}:{{range .Tags}} {{.}}{{end}}{{end}}` +
+			`{{template "card" dict "Name" .Name "Compact" true "Tags" (list "a" 2)}}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]string{"Name": "TS 100"}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "TS 100 (compact): a 2" {
+		t.Errorf("rendered %q", b.String())
+	}
+}
+
+func TestTimeago(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Time{}, ""},
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-5 * time.Minute), "5 minutes ago"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.Add(3 * time.Hour), "in 3 hours"},
+		{now.Add(-30 * time.Hour), "yesterday"},
+		{now.Add(30 * time.Hour), "tomorrow"},
+		{now.Add(-12 * 24 * time.Hour), "12 days ago"},
+		{now.Add(-45 * 24 * time.Hour), "Jan 29, 2026"},
+	}
+	for _, c := range cases {
+		if got := timeagoFrom(c.t, now); got != c.want {
+			t.Errorf("timeagoFrom(%v) = %q, want %q", c.t, got, c.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		s      string
+		length int
+		want   string
+	}{
+		{"Sensor", 10, "Sensor"},
+		{"Sensor", 6, "Sensor"},
+		{"Thermal sensor", 8, "Thermal..."},
+		{"Crème brûlée", 4, "Crèm..."},
+		{"Prix: 12 €", 9, "Prix: 12..."},
+		{"日本語のテキスト", 3, "日本語..."},
+		{"", 0, ""},
+	}
+	for _, c := range cases {
+		if got := truncate(c.s, c.length); got != c.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", c.s, c.length, got, c.want)
+		}
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	cases := []struct {
+		s      string
+		length int
+		want   string
+	}{
+		{"Rugged thermal sensor", 30, "Rugged thermal sensor"},
+		{"Rugged thermal sensor", 10, "Rugged..."},
+		{"Rugged thermal sensor", 14, "Rugged thermal..."},
+		{"Rugged thermal sensor", 15, "Rugged thermal..."},
+		{"Rugged, thermal and sealed", 12, "Rugged..."},
+		{"Crème brûlée à la carte", 13, "Crème brûlée..."},
+		{"Überlänge", 4, "Über..."},
+		{"Sensor", 0, "..."},
+	}
+	for _, c := range cases {
+		if got := truncateWords(c.s, c.length); got != c.want {
+			t.Errorf("truncateWords(%q, %d) = %q, want %q", c.s, c.length, got, c.want)
+		}
+		if !utf8.ValidString(truncateWords(c.s, c.length)) {
+			t.Errorf("truncateWords(%q, %d) split a character", c.s, c.length)
+		}
+	}
+}
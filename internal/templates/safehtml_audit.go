@@ -0,0 +1,218 @@
+package templates
+
+import (
+	"fmt"                 // Report formatting
+	"html/template"       // template.HTML return type of the audited safeHTML
+	"io"                  // Report output destination
+	"log/slog"            // Warnings when a field first renders unsanitized HTML
+	"sort"                // Stable report ordering
+	"strconv"             // Quoting the field label inserted into the parse tree
+	"sync"                // Guards the per-field counters across concurrent renders
+	"text/template/parse" // Parse tree walking to find safeHTML calls
+)
+
+// SafeHTMLAudit records, per template and field, what flows through safeHTML
+// and whether it would survive the HTML sanitizer unchanged. It is a
+// development aid for moving fields onto sanitize-on-save one at a time: a
+// field with no unsanitized renders is already safe to trust, while the rest
+// still hold HTML the sanitizer would rewrite.
+//
+// Enable it with Renderer.AuditSafeHTML before the first render.
+type SafeHTMLAudit struct {
+	sanitize func(string) string // Sanitizer to compare rendered values against
+	logger   *slog.Logger        // Structured logger for first-offence warnings
+
+	mu     sync.Mutex
+	fields map[safeHTMLKey]*SafeHTMLField
+}
+
+// safeHTMLKey identifies one safeHTML call site.
+type safeHTMLKey struct {
+	template string // Renderer template key, e.g. "public/pages/blog_post.html"
+	field    string // Argument expression, e.g. ".Post.Body"
+}
+
+// SafeHTMLField is the audit result for one safeHTML call site.
+type SafeHTMLField struct {
+	Template    string // Renderer template key, e.g. "public/pages/blog_post.html"
+	Field       string // Argument expression, e.g. ".Post.Body"
+	Renders     int64  // Values rendered since startup
+	Unsanitized int64  // Values the sanitizer would have changed
+}
+
+// NewSafeHTMLAudit creates an audit that compares every value passed to
+// safeHTML with sanitize(value).
+//
+// Parameters:
+//   - sanitize: HTML sanitizer, normally services.HTMLSanitizer.Sanitize
+//   - logger: Structured logger for the first unsanitized render of each field
+//
+// Returns:
+//   - *SafeHTMLAudit: Audit ready to pass to Renderer.AuditSafeHTML
+func NewSafeHTMLAudit(sanitize func(string) string, logger *slog.Logger) *SafeHTMLAudit {
+	return &SafeHTMLAudit{sanitize: sanitize, logger: logger, fields: make(map[safeHTMLKey]*SafeHTMLField)}
+}
+
+// field returns the counters for a call site, creating them on first use.
+// The caller must hold a.mu.
+func (a *SafeHTMLAudit) field(key safeHTMLKey) *SafeHTMLField {
+	f, ok := a.fields[key]
+	if !ok {
+		f = &SafeHTMLField{Template: key.template, Field: key.field}
+		a.fields[key] = f
+	}
+	return f
+}
+
+// record counts one render of a call site.
+func (a *SafeHTMLAudit) record(key safeHTMLKey, s string) {
+	clean := a.sanitize(s) == s
+	a.mu.Lock()
+	f := a.field(key)
+	f.Renders++
+	first := false
+	if !clean {
+		f.Unsanitized++
+		first = f.Unsanitized == 1
+	}
+	a.mu.Unlock()
+	if first {
+		a.logger.Warn("safeHTML rendered unsanitized HTML", "template", key.template, "field", key.field)
+	}
+}
+
+// Fields returns every safeHTML call site found in the templates, including
+// ones not rendered yet, with the fields needing migration first.
+func (a *SafeHTMLAudit) Fields() []SafeHTMLField {
+	a.mu.Lock()
+	fields := make([]SafeHTMLField, 0, len(a.fields))
+	for _, f := range a.fields {
+		fields = append(fields, *f)
+	}
+	a.mu.Unlock()
+	sort.Slice(fields, func(i, j int) bool {
+		if (fields[i].Unsanitized > 0) != (fields[j].Unsanitized > 0) {
+			return fields[i].Unsanitized > 0
+		}
+		if fields[i].Template != fields[j].Template {
+			return fields[i].Template < fields[j].Template
+		}
+		return fields[i].Field < fields[j].Field
+	})
+	return fields
+}
+
+// WriteReport writes Fields as a plain-text table. Status is MIGRATE for
+// fields that rendered unsanitized HTML, UNSEEN for fields not rendered yet,
+// and OK otherwise.
+//
+// Parameters:
+//   - w: Report destination
+//
+// Returns:
+//   - error: Write error, if any
+func (a *SafeHTMLAudit) WriteReport(w io.Writer) error {
+	fields := a.Fields()
+	if _, err := fmt.Fprintf(w, "safeHTML audit: %d call site(s)\n\n%-8s %8s %11s  %s\n", len(fields), "STATUS", "RENDERS", "UNSANITIZED", "TEMPLATE FIELD"); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		status := "OK"
+		switch {
+		case f.Unsanitized > 0:
+			status = "MIGRATE"
+		case f.Renders == 0:
+			status = "UNSEEN"
+		}
+		if _, err := fmt.Fprintf(w, "%-8s %8d %11d  %s %s\n", status, f.Renders, f.Unsanitized, f.Template, f.Field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AuditSafeHTML routes every safeHTML call through audit. Each call site is
+// rewritten to pass its argument expression as a label, so the audit can
+// attribute values to fields, and is registered so unrendered fields still
+// appear in the report. It must be called before the first render.
+//
+// Parameters:
+//   - audit: Audit that receives the rendered values
+func (r *Renderer) AuditSafeHTML(audit *SafeHTMLAudit) {
+	for name, tmpl := range r.templates {
+		// A tree can be shared by several names; label each one once
+		seen := make(map[*parse.Tree]bool)
+		for _, t := range tmpl.Templates() {
+			if t.Tree == nil || seen[t.Tree] {
+				continue
+			}
+			seen[t.Tree] = true
+			labelSafeHTML(t.Tree.Root, func(field string) {
+				audit.mu.Lock()
+				audit.field(safeHTMLKey{name, field})
+				audit.mu.Unlock()
+			})
+		}
+		tmpl.Funcs(template.FuncMap{
+			"safeHTML": func(field, s string) template.HTML {
+				audit.record(safeHTMLKey{name, field}, s)
+				return safeHTML(s)
+			},
+		})
+	}
+}
+
+// labelSafeHTML walks a parse tree and inserts the argument expression as a
+// leading string argument of every safeHTML call, reporting each one to found.
+// Both forms are handled: {{safeHTML .X}} becomes {{safeHTML ".X" .X}} and
+// {{.X | safeHTML}} becomes {{.X | safeHTML ".X"}}.
+func labelSafeHTML(node parse.Node, found func(field string)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			labelSafeHTML(child, found)
+		}
+	case *parse.ActionNode:
+		labelSafeHTML(n.Pipe, found)
+	case *parse.IfNode:
+		labelSafeHTML(&n.BranchNode, found)
+	case *parse.RangeNode:
+		labelSafeHTML(&n.BranchNode, found)
+	case *parse.WithNode:
+		labelSafeHTML(&n.BranchNode, found)
+	case *parse.BranchNode:
+		labelSafeHTML(n.Pipe, found)
+		labelSafeHTML(n.List, found)
+		labelSafeHTML(n.ElseList, found)
+	case *parse.TemplateNode:
+		labelSafeHTML(n.Pipe, found)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for i, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				labelSafeHTML(arg, found)
+			}
+			ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+			if !ok || ident.Ident != "safeHTML" {
+				continue
+			}
+			var field string
+			switch {
+			case len(cmd.Args) > 1:
+				field = cmd.Args[1].String()
+			case i > 0:
+				field = n.Cmds[i-1].String()
+			default:
+				continue
+			}
+			label := &parse.StringNode{NodeType: parse.NodeString, Pos: ident.Pos, Quoted: strconv.Quote(field), Text: field}
+			cmd.Args = append([]parse.Node{ident, label}, cmd.Args[1:]...)
+			found(field)
+		}
+	}
+}
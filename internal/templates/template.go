@@ -15,10 +15,19 @@ import (
 	"html/template" // Go's HTML templating engine with auto-escaping for XSS protection
 	"io"            // For writing rendered templates to HTTP response writers
 	"path/filepath" // For cross-platform file path construction
+	"slices"        // Finding the admin layout in a template set
 	"strings"       // For string manipulation in template functions (ToUpper, ReplaceAll)
 	"time"          // For date formatting in template functions
+	"unicode"       // Word boundaries in truncateWords
+	"unicode/utf8"  // Character counts in truncate
 
-	"github.com/labstack/echo/v4" // Echo web framework - provides HTTP context for rendering
+	"github.com/labstack/echo/v4"        // Echo web framework - provides HTTP context for rendering
+	"go.opentelemetry.io/otel/attribute" // Template name on render spans
+
+	"github.com/narendhupati/bluejay-cms/db/sqlc"             // SEO overrides applied to public pages
+	"github.com/narendhupati/bluejay-cms/internal/middleware" // ?debug=templates render tracking, visitor timezone, SEO overrides, form tokens
+	"github.com/narendhupati/bluejay-cms/internal/services"   // Site timezone for date formatting and slugs
+	"github.com/narendhupati/bluejay-cms/internal/tracing"    // Render spans
 )
 
 // Renderer implements Echo's echo.Renderer interface to integrate Go templates with Echo.
@@ -39,6 +48,7 @@ import (
 type Renderer struct {
 	templates map[string]*template.Template // Map of template names to compiled template trees
 	basePath  string                        // Root directory for template files (typically "templates/")
+	stats     LoadStats                     // Startup compilation timing
 }
 
 // NewRenderer creates and initializes a new template renderer.
@@ -52,17 +62,38 @@ type Renderer struct {
 //
 // The function will panic if template files are missing or contain syntax errors,
 // ensuring that template problems are caught at startup rather than at request time.
+// The panic message lists every broken template; use LoadRenderer to get the
+// error instead.
 //
 // Example usage:
 //   renderer := NewRenderer("templates/")
 //   e.Renderer = renderer
 func NewRenderer(basePath string) *Renderer {
+	r, err := LoadRenderer(basePath)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LoadRenderer creates a renderer like NewRenderer but returns template
+// errors instead of panicking.
+//
+// Parameters:
+//   - basePath: Root directory containing template files (e.g., "templates/")
+//
+// Returns:
+//   - *Renderer: Fully initialized renderer, nil on error
+//   - error: Every template set that failed to parse, joined (see errors.Join)
+func LoadRenderer(basePath string) (*Renderer, error) {
 	r := &Renderer{
 		templates: make(map[string]*template.Template),
 		basePath:  basePath,
 	}
-	r.loadTemplates()
-	return r
+	if err := r.loadTemplates(); err != nil {
+		return nil, err
+	}
+	return r, nil
 }
 
 // Render implements the echo.Renderer interface to render templates for HTTP responses.
@@ -72,7 +103,7 @@ func NewRenderer(basePath string) *Renderer {
 //   - w: HTTP response writer where rendered HTML will be written
 //   - name: Template identifier (e.g., "admin/pages/dashboard.html")
 //   - data: Data passed to template for variable substitution (typically a struct or map)
-//   - c: Echo context containing request/response info (currently unused but required by interface)
+//   - c: Echo context containing request/response info (used for ?debug=templates tracking; may be nil)
 //
 // Returns:
 //   - error: Non-nil if template not found or execution fails
@@ -85,17 +116,82 @@ func NewRenderer(basePath string) *Renderer {
 // Error handling:
 // - Missing template returns error before rendering (fail-fast)
 // - Template execution errors (missing data, type mismatches) return during rendering
+//
+// Map data also receives VisitorTimezone (for formatDateIn) unless the
+// handler set it: the zone guessed by the VisitorTimezone middleware, or ""
+// for the site timezone, and Menus, the active navigation menus stored by
+// the Navigation middleware (empty outside it), and Blocks, the content
+// blocks the ContentBlocks middleware found for the path. On public pages
+// (behind the SEO middleware) it also receives the page's metadata override,
+// see applySEO.
 func (r *Renderer) Render(w io.Writer, name string, data interface{}, c echo.Context) error {
 	tmpl, ok := r.templates[name]
 	if !ok {
 		return fmt.Errorf("template not found: %s", name)
 	}
+	if m, ok := data.(map[string]interface{}); ok {
+		if _, set := m["VisitorTimezone"]; !set {
+			zone := ""
+			if c != nil {
+				zone = middleware.VisitorTimezoneFrom(c)
+			}
+			m["VisitorTimezone"] = zone
+		}
+		if _, set := m["Menus"]; !set {
+			m["Menus"] = middleware.MenusFrom(c)
+		}
+		if _, set := m["Blocks"]; !set {
+			m["Blocks"] = middleware.BlocksFrom(c)
+		}
+		if path, meta, ok := middleware.PageSEOFrom(c); ok {
+			applySEO(m, path, meta)
+		}
+		// Pages with the admin layout show the session's flash messages
+		if _, set := m["Flashes"]; !set && tmpl.Lookup("admin-flashes") != nil {
+			m["Flashes"] = middleware.FlashesFrom(c)
+		}
+	}
+	// Record the render for the admin debug overlay (no-op unless ?debug=templates)
+	middleware.TemplateDebugFrom(c).RecordRender(name, data)
+	// Trace the render under the request span (no-op when tracing is off)
+	if c != nil {
+		_, span := tracing.Start(c.Request().Context(), "render "+name, attribute.String("template.name", name))
+		defer span.End()
+		err := tmpl.ExecuteTemplate(w, "base", data)
+		tracing.RecordError(span, err)
+		return err
+	}
 	return tmpl.ExecuteTemplate(w, "base", data)
 }
 
+// applySEO sets the head metadata of a public page from its Admin > SEO
+// override: non-empty MetaTitle, MetaDescription, CanonicalURL and OGImage
+// replace the handler's values, and NoIndex adds a robots noindex tag. A
+// page without a canonical URL of its own gets its path, so the layout no
+// longer points it at the homepage.
+func applySEO(m map[string]interface{}, path string, meta sqlc.SeoMetum) {
+	if canonical, _ := m["CanonicalURL"].(string); canonical == "" {
+		m["CanonicalURL"] = path
+	}
+	for key, value := range map[string]string{
+		"MetaTitle":       meta.MetaTitle,
+		"MetaDescription": meta.MetaDescription,
+		"CanonicalURL":    meta.CanonicalUrl,
+		"OGImage":         meta.OgImage,
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	if meta.Noindex {
+		m["NoIndex"] = true
+	}
+}
+
 // loadTemplates discovers and compiles all templates from the filesystem.
-// This method is called once during initialization and will panic on any template errors,
-// ensuring all templates are valid before the application starts serving requests.
+// This method is called once during initialization. Every template set is
+// parsed, even after a failure, so the returned error lists all broken
+// templates at once; the application must not serve requests if it fails.
 //
 // Template organization:
 // - Full pages reference layouts (admin/layouts/base.html or public/layouts/base.html)
@@ -106,19 +202,25 @@ func (r *Renderer) Render(w io.Writer, name string, data interface{}, c echo.Con
 // Template function map:
 // All templates have access to custom functions for data formatting and manipulation.
 // These functions are registered before template parsing and available in all templates.
-func (r *Renderer) loadTemplates() {
+func (r *Renderer) loadTemplates() error {
 	// funcMap registers custom functions available to all templates.
 	// Functions provide data formatting, math operations, and string manipulation.
 	// These extend Go's built-in template functions (len, printf, etc.)
 	funcMap := template.FuncMap{
 		"safeHTML":   safeHTML,   // Renders HTML without escaping (use carefully!)
-		"formatDate": formatDate, // Formats time.Time to human-readable string
+		"formatDate": formatDate, // Formats time.Time to human-readable string (site timezone)
+		"formatDateIn": formatDateIn,            // Same, in a given zone such as .VisitorTimezone
+		"localTime":    services.InSiteTimezone, // Converts time.Time to the site timezone for .Format
+		"siteTimezone": func() string { return services.SiteLocation().String() }, // Site timezone name, for input hints
 		"truncate":   truncate,   // Shortens strings with ellipsis
+		"truncateWords": truncateWords, // Same, at a word boundary (card excerpts)
 		"slugify":    slugify,    // Converts strings to URL-safe slugs
 		"now":        time.Now,   // Returns current timestamp
 		"add":        func(a, b int) int { return a + b }, // Integer addition for templates
 		"sub":        func(a, b int) int { return a - b }, // Integer subtraction for templates
 		"upper":      strings.ToUpper,                     // Converts string to uppercase
+		"asset":      assetURL,                            // Static file URL, content-hashed after UseAssets
+		"formToken":  middleware.NewFormToken,             // One-time token for admin POST forms (middleware.FormTokens)
 		// seq generates integer sequence for range loops ({{range seq 5}} generates 0,1,2,3,4)
 		"seq": func(n int64) []int {
 			s := make([]int, n)
@@ -128,6 +230,13 @@ func (r *Renderer) loadTemplates() {
 			return s
 		},
 		"int64": func(i int) int64 { return int64(i) },     // Type conversion for int to int64
+		"list":       list,       // Builds a slice for range loops and partials ({{range list "a" "b"}})
+		"dict":       dict,       // Builds a map for partials ({{template "card" dict "Item" . "Compact" true}})
+		"money":      money,      // Formats a price in the site locale and currency ({{money .Price "EUR"}})
+		"humanize":   humanize,   // Shortens large numbers (1.2k, 3M) in the site locale
+		"pluralize":  pluralize,  // Singular or plural word for a count ({{pluralize .Total "entry" "entries"}})
+		"markdown":   markdown,   // Renders Markdown with raw HTML removed
+		"timeago":    timeago,    // Relative time ("5 minutes ago"), a date after a month
 		// formatFileSize converts bytes to human-readable format (B, KB, MB)
 		"formatFileSize": func(size int64) string {
 			if size < 1024 {
@@ -140,35 +249,47 @@ func (r *Renderer) loadTemplates() {
 		},
 	}
 
+	// Template sets are only queued here; compile parses them in parallel
+	jobs := &templateJobs{}
+
 	// Public homepage template
 	// Uses: public/layouts/base.html (defines <html>, <head>, <body> structure)
 	// Includes: partials/header.html (site navigation), partials/footer.html (site footer)
 	// Content: public/pages/home.html defines {{block "content"}} for hero, stats, testimonials
-	r.templates["public/pages/home.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("public/pages/home.html",
 		filepath.Join(r.basePath, "public/layouts/base.html"),
 		filepath.Join(r.basePath, "public/pages/home.html"),
 		filepath.Join(r.basePath, "partials/header.html"),
+		filepath.Join(r.basePath, "partials/header-nav.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "partials/language-switcher.html"),
 		filepath.Join(r.basePath, "partials/footer.html"),
-	))
+	)
 
 	// Admin login page template
 	// Uses: admin/layouts/base.html (minimal layout, no sidebar)
 	// Content: admin/pages/login.html defines login form with username/password fields
 	// Note: No sidebar included - login page is accessed before authentication
-	r.templates["admin/pages/login.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("admin/pages/login.html",
 		filepath.Join(r.basePath, "admin/layouts/base.html"),
 		filepath.Join(r.basePath, "admin/pages/login.html"),
-	))
+	)
 
 	// Admin dashboard template
 	// Uses: admin/layouts/base.html (admin panel structure)
 	// Includes: partials/admin-sidebar.html (admin navigation menu)
-	// Content: admin/pages/dashboard.html shows statistics and recent activity
-	r.templates["admin/pages/dashboard.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	// Includes: admin/partials/dashboard_card.html (the HTMX-refreshable metric cards)
+	// Content: admin/pages/dashboard.html shows the cards and quick actions
+	jobs.add("admin/pages/dashboard.html",
 		filepath.Join(r.basePath, "admin/layouts/base.html"),
 		filepath.Join(r.basePath, "admin/pages/dashboard.html"),
 		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-	))
+		filepath.Join(r.basePath, "admin/partials/dashboard_card.html"),
+	)
+	// One card on its own, for GET /admin/dashboard/cards/:card
+	jobs.addSource("admin/partials/dashboard_card.html", `{{template "dashboard-card" .}}`,
+		filepath.Join(r.basePath, "admin/partials/dashboard_card.html"),
+	)
 
 	// Phase 3: Public product pages
 	// Uses: public/layouts/base.html for consistent site structure
@@ -177,16 +298,24 @@ func (r *Renderer) loadTemplates() {
 	//   - products.html: Product catalog grid with filters and search
 	//   - products_category.html: Category-filtered product listing
 	//   - product_detail.html: Individual product page with specs, media, related products
+	//   - products_index.html: A–Z index of every published product (/products/a-z)
+	// Partials: public/partials/products_grid.html (category grid, also served alone),
+	// public/partials/related_content.html (related resources on product_detail)
 	publicProductPages := []string{
-		"products", "products_category", "product_detail",
+		"products", "products_category", "product_detail", "products_index",
 	}
 	for _, page := range publicProductPages {
-		r.templates["public/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("public/pages/"+page+".html",
 			filepath.Join(r.basePath, "public/layouts/base.html"),
 			filepath.Join(r.basePath, "public/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
 			filepath.Join(r.basePath, "partials/footer.html"),
-		))
+			filepath.Join(r.basePath, "public/partials/products_grid.html"),
+			filepath.Join(r.basePath, "public/partials/related_content.html"),
+		)
 	}
 
 	// Phase 2: Master table admin pages
@@ -201,6 +330,7 @@ func (r *Renderer) loadTemplates() {
 	//   - Products: Full product CRUD with media, specs, categories
 	//   - Settings: Global site settings (name, SEO, social links)
 	//   - Page sections: Reusable content blocks for pages
+	// Partials: partials/language-tabs.html (translation tabs on products_form and page_sections_form)
 	//   - Header/Footer: Site-wide navigation and footer content management
 	masterPages := []string{
 		"product_categories_list", "product_categories_form",
@@ -209,19 +339,25 @@ func (r *Renderer) loadTemplates() {
 		"industries_list", "industries_form",
 		"partner_tiers_list", "partner_tiers_form",
 		"whitepaper_topics_list", "whitepaper_topics_form",
-		"products_list", "products_form",
-		"settings_form",
+		"products_list", "products_form", "products_import",
+		"settings_form", "settings_import",
 		"page_sections_list", "page_sections_form",
 		"header_form",
 		"footer_form",
 	}
 	for _, page := range masterPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+			filepath.Join(r.basePath, "partials/language-tabs.html"),
+			filepath.Join(r.basePath, "admin/partials/product_row.html"),
+		)
 	}
+	// One product row on its own, returned by its inline edit endpoint
+	jobs.addSource("admin/partials/product_row.html", `{{template "product-row" .}}`,
+		filepath.Join(r.basePath, "admin/partials/product_row.html"),
+	)
 
 	// Phase 4: Public solution pages
 	// Uses: public/layouts/base.html for consistent public site structure
@@ -233,17 +369,21 @@ func (r *Renderer) loadTemplates() {
 		"solutions_list", "solution_detail",
 	}
 	for _, page := range publicSolutionPages {
-		r.templates["public/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("public/pages/"+page+".html",
 			filepath.Join(r.basePath, "public/layouts/base.html"),
 			filepath.Join(r.basePath, "public/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
 			filepath.Join(r.basePath, "partials/footer.html"),
-		))
+		)
 	}
 
 	// Phase 4: Admin solution pages
 	// Uses: admin/layouts/base.html (admin panel structure)
-	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Includes: partials/admin-sidebar.html (admin navigation), partials/publish-checklist.html (publish gate),
+	// partials/language-tabs.html (translation tabs)
 	// Templates:
 	//   - solutions_list.html: Table of all solutions with edit/delete actions
 	//   - solutions_form.html: Create/edit form with Trix editor and related content management
@@ -251,11 +391,13 @@ func (r *Renderer) loadTemplates() {
 		"solutions_list", "solutions_form",
 	}
 	for _, page := range solutionAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+			filepath.Join(r.basePath, "partials/publish-checklist.html"),
+			filepath.Join(r.basePath, "partials/language-tabs.html"),
+		)
 	}
 
 	// Phase 4: Admin solution partials (HTMX fragments - standalone, no layout)
@@ -270,9 +412,9 @@ func (r *Renderer) loadTemplates() {
 		"solution_stats", "solution_challenges", "solution_products", "solution_ctas",
 	}
 	for _, partial := range solutionPartials {
-		r.templates["admin/partials/"+partial+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/partials/"+partial+".html",
 			filepath.Join(r.basePath, "admin/partials/"+partial+".html"),
-		))
+		)
 	}
 
 	// Phase 6: Public case study pages
@@ -281,21 +423,26 @@ func (r *Renderer) loadTemplates() {
 	// Templates:
 	//   - case_studies.html: Grid of case studies with industry/product filtering
 	//   - case_study_detail.html: Full case study with challenge, solution, results, metrics
+	// Partials: public/partials/case_studies_grid.html (filter bar and grid, also served alone)
 	publicCaseStudyPages := []string{
 		"case_studies", "case_study_detail",
 	}
 	for _, page := range publicCaseStudyPages {
-		r.templates["public/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("public/pages/"+page+".html",
 			filepath.Join(r.basePath, "public/layouts/base.html"),
 			filepath.Join(r.basePath, "public/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
 			filepath.Join(r.basePath, "partials/footer.html"),
-		))
+			filepath.Join(r.basePath, "public/partials/case_studies_grid.html"),
+		)
 	}
 
 	// Phase 6: Admin case study pages
 	// Uses: admin/layouts/base.html (admin panel structure)
-	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Includes: partials/admin-sidebar.html (admin navigation), partials/publish-checklist.html (publish gate)
 	// Templates:
 	//   - case_studies_list.html: Table of case studies with status, industry, featured flag
 	//   - case_studies_form.html: Create/edit form with client info, challenge, solution, results
@@ -303,12 +450,18 @@ func (r *Renderer) loadTemplates() {
 		"case_studies_list", "case_studies_form",
 	}
 	for _, page := range caseStudyAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+			filepath.Join(r.basePath, "partials/publish-checklist.html"),
+			filepath.Join(r.basePath, "admin/partials/case_study_row.html"),
+		)
 	}
+	// One case study row on its own, returned by its inline edit endpoint
+	jobs.addSource("admin/partials/case_study_row.html", `{{template "case-study-row" .}}`,
+		filepath.Join(r.basePath, "admin/partials/case_study_row.html"),
+	)
 
 	// Phase 6: Admin case study partials (HTMX fragments - standalone, no layout)
 	// HTMX target fragments for dynamic updates within case_studies_form.html.
@@ -319,9 +472,9 @@ func (r *Renderer) loadTemplates() {
 		"case_study_products", "case_study_metrics",
 	}
 	for _, partial := range caseStudyPartials {
-		r.templates["admin/partials/"+partial+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/partials/"+partial+".html",
 			filepath.Join(r.basePath, "admin/partials/"+partial+".html"),
-		))
+		)
 	}
 
 	// Phase 5: Blog tag partials (HTMX fragments - standalone, no layout)
@@ -332,17 +485,52 @@ func (r *Renderer) loadTemplates() {
 	//   - product_suggestions.html: Related product autocomplete (hx-get on input)
 	blogPartials := []string{"tag_suggestions", "tag_chip", "product_suggestions"}
 	for _, partial := range blogPartials {
-		r.templates["admin/partials/"+partial+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/partials/"+partial+".html",
 			filepath.Join(r.basePath, "admin/partials/"+partial+".html"),
-		))
+		)
 	}
 
 	// Product search partial (HTMX fragment - standalone, no layout)
 	// Used on public products page for live search results (hx-get on search input).
 	// Returns filtered product grid without page reload.
-	r.templates["public/partials/product_search_results.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("public/partials/product_search_results.html",
 		filepath.Join(r.basePath, "public/partials/product_search_results.html"),
-	))
+	)
+
+	// Recently viewed products partial (HTMX fragment - standalone, no layout)
+	// Loaded after product and category pages render, since it differs per visitor.
+	jobs.add("public/partials/recently_viewed.html",
+		filepath.Join(r.basePath, "public/partials/recently_viewed.html"),
+	)
+
+	// Listing grid partials (HTMX fragments - standalone, no layout)
+	// The listing pages include these grids; the /partials/* endpoints render
+	// them alone so filter and pagination links swap just the grid.
+	listingGrids := []string{
+		"products_grid", "blog_grid", "case_studies_grid", "whitepapers_grid",
+	}
+	for _, grid := range listingGrids {
+		name := strings.ReplaceAll(grid, "_", "-")
+		jobs.addSource("public/partials/"+grid+".html", `{{template "`+name+`" .}}`,
+			filepath.Join(r.basePath, "public/partials/"+grid+".html"),
+		)
+	}
+
+	// Print pages (/print routes and PDF generation)
+	// Uses: public/layouts/print.html (no header, footer, scripts or Tailwind;
+	// styled by public/css/print.css)
+	// Templates:
+	//   - product_detail.html: Product spec sheet with features, specs, certifications
+	//   - case_study_detail.html: Case study narrative with metrics and products
+	printPages := []string{
+		"product_detail", "case_study_detail",
+	}
+	for _, page := range printPages {
+		jobs.add("public/print/"+page+".html",
+			filepath.Join(r.basePath, "public/layouts/print.html"),
+			filepath.Join(r.basePath, "public/print/"+page+".html"),
+		)
+	}
 
 	// Phase 5: Public blog pages
 	// Uses: public/layouts/base.html for public site structure
@@ -350,74 +538,210 @@ func (r *Renderer) loadTemplates() {
 	// Templates:
 	//   - blog_listing.html: Blog archive with category/tag/author filtering, pagination
 	//   - blog_post.html: Full blog post with author bio, tags, related posts, comments
+	//   - blog_archive.html: Posts published in one month (/blog/2024/05)
+	//   - blog_tags.html: Tag cloud of every tag on a published post (/blog/tags)
+	//   - blog_tag.html: Posts with one tag (/blog/tags/edge-computing)
+	// Partials: partials/blog-archive-widget.html (month/year sidebar widget),
+	// public/partials/blog_grid.html (category tabs, grid, pagination; also served alone)
 	publicBlogPages := []string{
-		"blog_listing", "blog_post",
+		"blog_listing", "blog_post", "blog_archive", "blog_tags", "blog_tag",
 	}
 	for _, page := range publicBlogPages {
-		r.templates["public/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("public/pages/"+page+".html",
 			filepath.Join(r.basePath, "public/layouts/base.html"),
 			filepath.Join(r.basePath, "public/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
 			filepath.Join(r.basePath, "partials/footer.html"),
-		))
+			filepath.Join(r.basePath, "partials/blog-archive-widget.html"),
+			filepath.Join(r.basePath, "public/partials/blog_grid.html"),
+		)
 	}
 
 	// Phase 5: Admin blog pages
 	// Uses: admin/layouts/base.html (admin panel structure)
-	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Includes: partials/admin-sidebar.html (admin navigation), partials/language-tabs.html (translation tabs)
 	// Templates:
 	//   - blog_posts_list.html: Table of posts with status, category, author, publish date
 	//   - blog_post_form.html: Create/edit form with Trix editor, tags, SEO, scheduling
 	//   - blog_tags_list.html: Tag management with usage count, merge/delete actions
+	//   - blog_series_list.html: Series with post counts
+	//   - blog_series_form.html: Series details plus ordered post assignment
 	blogAdminPages := []string{
 		"blog_posts_list", "blog_post_form", "blog_tags_list",
+		"blog_series_list", "blog_series_form",
 	}
 	for _, page := range blogAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+			filepath.Join(r.basePath, "partials/language-tabs.html"),
+			filepath.Join(r.basePath, "admin/partials/blog_post_row.html"),
+		)
 	}
+	// One blog post row on its own, returned by its inline edit endpoint
+	jobs.addSource("admin/partials/blog_post_row.html", `{{template "blog-post-row" .}}`,
+		filepath.Join(r.basePath, "admin/partials/blog_post_row.html"),
+	)
 	// Phase 8: Public whitepaper pages
 	// Uses: public/layouts/base.html for public site structure
 	// Includes: partials/header.html (navigation), partials/footer.html (footer)
 	// Templates:
 	//   - whitepapers.html: Grid of whitepapers with topic filtering
 	//   - whitepaper_detail.html: Whitepaper overview with gated download form
+	//   - whitepaper_topics.html: Topic index listing every published whitepaper
+	// Partials: public/partials/whitepapers_grid.html (filter bar and grid, also served alone),
+	// public/partials/lead_fields.html (download form fields on whitepaper_detail)
 	publicWhitepaperPages := []string{
-		"whitepapers", "whitepaper_detail",
+		"whitepapers", "whitepaper_detail", "whitepaper_topics",
 	}
 	for _, page := range publicWhitepaperPages {
-		r.templates["public/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("public/pages/"+page+".html",
 			filepath.Join(r.basePath, "public/layouts/base.html"),
 			filepath.Join(r.basePath, "public/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
 			filepath.Join(r.basePath, "partials/footer.html"),
-		))
+			filepath.Join(r.basePath, "public/partials/whitepapers_grid.html"),
+			filepath.Join(r.basePath, "public/partials/lead_fields.html"),
+		)
 	}
 
 	// Phase 8: Whitepaper success partial (HTMX fragment - standalone, no layout)
 	// Shown after whitepaper download form submission via HTMX.
 	// Displays success message and download link without page reload.
-	r.templates["public/pages/whitepaper_success.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("public/pages/whitepaper_success.html",
 		filepath.Join(r.basePath, "public/pages/whitepaper_success.html"),
-	))
+	)
+
+	// Gated product download partials (HTMX fragments - standalone, no layout)
+	// The product page loads the lead form into a modal; submitting it swaps in
+	// the success message with the signed download link. Signed-in customers
+	// get the modal with the success message straight away.
+	jobs.add("public/partials/download_lead_form.html",
+		filepath.Join(r.basePath, "public/partials/download_lead_form.html"),
+		filepath.Join(r.basePath, "public/partials/lead_fields.html"),
+		filepath.Join(r.basePath, "public/partials/download_lead_success.html"),
+	)
+	jobs.addSource("public/partials/download_lead_success.html", `{{template "download-lead-success" .}}`,
+		filepath.Join(r.basePath, "public/partials/download_lead_success.html"),
+	)
+
+	// Events and webinars
+	// Uses: public/layouts/base.html for public site structure
+	// Templates:
+	//   - events.html: Upcoming events, then past ones
+	//   - event_detail.html: Event page with the registration form (lead_fields)
+	// Partial: public/partials/event_registered.html (HTMX fragment swapped in
+	// for the form after registering, also served alone)
+	for _, page := range []string{"events", "event_detail"} {
+		jobs.add("public/pages/"+page+".html",
+			filepath.Join(r.basePath, "public/layouts/base.html"),
+			filepath.Join(r.basePath, "public/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
+			filepath.Join(r.basePath, "partials/footer.html"),
+			filepath.Join(r.basePath, "public/partials/lead_fields.html"),
+		)
+	}
+	jobs.add("public/partials/event_registered.html",
+		filepath.Join(r.basePath, "public/partials/event_registered.html"),
+	)
+
+	// Careers
+	// Uses: public/layouts/base.html for public site structure
+	// Templates:
+	//   - careers.html: Open positions by department
+	//   - career_detail.html: Job posting with its apply form and Google Jobs
+	//     structured data
+	// Partial: public/partials/job_applied.html (HTMX fragment swapped in for
+	// the apply form after applying, also served alone)
+	for _, page := range []string{"careers", "career_detail"} {
+		jobs.add("public/pages/"+page+".html",
+			filepath.Join(r.basePath, "public/layouts/base.html"),
+			filepath.Join(r.basePath, "public/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
+			filepath.Join(r.basePath, "partials/footer.html"),
+		)
+	}
+	jobs.add("public/partials/job_applied.html",
+		filepath.Join(r.basePath, "public/partials/job_applied.html"),
+	)
+
+	// Press newsroom
+	// Uses: public/layouts/base.html for public site structure
+	// Templates:
+	//   - press.html: Press releases, "In the news" links and the media kit
+	//   - press_release.html: One press release with its dateline
+	for _, page := range []string{"press", "press_release"} {
+		jobs.add("public/pages/"+page+".html",
+			filepath.Join(r.basePath, "public/layouts/base.html"),
+			filepath.Join(r.basePath, "public/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
+			filepath.Join(r.basePath, "partials/footer.html"),
+		)
+	}
+
+	// Documentation
+	// Uses: public/layouts/base.html for public site structure
+	// Templates:
+	//   - docs.html: Published doc sets grouped by product
+	//   - doc_page.html: A doc set version's contents, or one of its pages,
+	//     with the sidebar tree, version switcher and prev/next links
+	for _, page := range []string{"docs", "doc_page"} {
+		jobs.add("public/pages/"+page+".html",
+			filepath.Join(r.basePath, "public/layouts/base.html"),
+			filepath.Join(r.basePath, "public/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
+			filepath.Join(r.basePath, "partials/footer.html"),
+		)
+	}
+
+	// Partner portal: the tokenized form invited partners use to send logo,
+	// description and tier updates for review
+	jobs.add("public/pages/partner_portal.html",
+		filepath.Join(r.basePath, "public/layouts/base.html"),
+		filepath.Join(r.basePath, "public/pages/partner_portal.html"),
+		filepath.Join(r.basePath, "partials/header.html"),
+		filepath.Join(r.basePath, "partials/header-nav.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "partials/language-switcher.html"),
+		filepath.Join(r.basePath, "partials/footer.html"),
+	)
 
 	// Phase 8: Public contact page
 	// Uses: public/layouts/base.html for public site structure
 	// Includes: partials/header.html (navigation), partials/footer.html (footer)
 	// Content: Contact form with office locations map
-	r.templates["public/pages/contact.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("public/pages/contact.html",
 		filepath.Join(r.basePath, "public/layouts/base.html"),
 		filepath.Join(r.basePath, "public/pages/contact.html"),
 		filepath.Join(r.basePath, "partials/header.html"),
+		filepath.Join(r.basePath, "partials/header-nav.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "partials/language-switcher.html"),
 		filepath.Join(r.basePath, "partials/footer.html"),
-	))
+	)
 
 	// Phase 8: Admin whitepaper pages
 	// Uses: admin/layouts/base.html (admin panel structure)
-	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Includes: partials/admin-sidebar.html (admin navigation), partials/publish-checklist.html (publish gate)
 	// Templates:
 	//   - whitepapers_list.html: Table of whitepapers with status, topic, downloads count
 	//   - whitepapers_form.html: Create/edit form with file upload, gating options
@@ -426,11 +750,105 @@ func (r *Renderer) loadTemplates() {
 		"whitepapers_list", "whitepapers_form", "whitepapers_downloads",
 	}
 	for _, page := range whitepaperAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+			filepath.Join(r.basePath, "partials/publish-checklist.html"),
+		)
+	}
+
+	// Admin event pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - events_list.html: Events with dates, status and registration counts
+	//   - events_form.html: Create/edit form for an event or webinar
+	//   - event_registrations.html: Registrations of one event
+	for _, page := range []string{"events_list", "events_form", "event_registrations"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Admin careers pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - careers_list.html: Job postings with status and application counts
+	//   - careers_form.html: Create/edit form for a job posting
+	//   - job_applications_list.html: Application inbox with status filters
+	//   - job_application_detail.html: One application with its status and notes
+	for _, page := range []string{"careers_list", "careers_form", "job_applications_list", "job_application_detail"} {
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+		)
+	}
+
+	// Admin press pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - press_list.html: Press releases with release date and status
+	//   - press_form.html: Create/edit form for a press release
+	//   - press_mentions_list.html: "In the news" links
+	//   - press_mention_form.html: Create/edit form for an "In the news" link
+	//   - press_kit.html: Media kit files with the upload form
+	for _, page := range []string{"press_list", "press_form", "press_mentions_list", "press_mention_form", "press_kit"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Admin documentation pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - docs_list.html: Doc sets with product and current version
+	//   - docs_form.html: Create/edit form for a doc set with its versions
+	//   - doc_pages_list.html: Page tree of one version
+	//   - doc_page_form.html: Create/edit form for a Markdown page
+	for _, page := range []string{"docs_list", "docs_form", "doc_pages_list", "doc_page_form"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Admin partner portal pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - partner_portal.html: Invite links and submission history of a partner
+	//   - partner_submissions_list.html: Approval queue with status filter
+	//   - partner_submission_detail.html: Current listing next to the proposed changes
+	for _, page := range []string{"partner_portal", "partner_submissions_list", "partner_submission_detail"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Admin short link pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - short_links_list.html: Links with their URL, destination, clicks and expiry
+	//   - short_links_form.html: Create/edit form for a link with its UTM parameters
+	for _, page := range []string{"short_links_list", "short_links_form"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
 	}
 
 	// Phase 8: Admin contact pages
@@ -441,16 +859,20 @@ func (r *Renderer) loadTemplates() {
 	//   - contact_submission_detail.html: Full submission view with user info, message, actions
 	//   - office_locations_list.html: Table of office locations with address, contact info
 	//   - office_locations_form.html: Create/edit form for office location details
+	//   - leads_list.html: Submissions grouped by person across contact, RFQ, and whitepapers
+	//   - customers_list.html: Registered customer accounts of the public site
+	//   - subscribers_list.html: Newsletter subscribers with status filter and CSV export
 	contactAdminPages := []string{
 		"contact_submissions_list", "contact_submission_detail",
 		"office_locations_list", "office_locations_form",
+		"leads_list", "customers_list", "subscribers_list",
 	}
 	for _, page := range contactAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+		)
 	}
 
 	// Phase 7: Public about and partners pages
@@ -461,12 +883,15 @@ func (r *Renderer) loadTemplates() {
 	//   - partners.html: Partner ecosystem with tier-based filtering, logos, testimonials
 	publicAboutPages := []string{"about", "partners"}
 	for _, page := range publicAboutPages {
-		r.templates["public/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("public/pages/"+page+".html",
 			filepath.Join(r.basePath, "public/layouts/base.html"),
 			filepath.Join(r.basePath, "public/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
 			filepath.Join(r.basePath, "partials/footer.html"),
-		))
+		)
 	}
 
 	// Phase 9: Admin homepage pages
@@ -492,30 +917,120 @@ func (r *Renderer) loadTemplates() {
 		"blog_settings",
 	}
 	for _, page := range homepageAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+		)
 	}
 
 	// Phase 9: Search page
 	// Uses: public/layouts/base.html for public site structure
 	// Includes: partials/header.html (navigation), partials/footer.html (footer)
 	// Content: Global site search with results across products, blog, solutions, case studies
-	r.templates["public/pages/search.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("public/pages/search.html",
 		filepath.Join(r.basePath, "public/layouts/base.html"),
 		filepath.Join(r.basePath, "public/pages/search.html"),
 		filepath.Join(r.basePath, "partials/header.html"),
+		filepath.Join(r.basePath, "partials/header-nav.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "partials/language-switcher.html"),
+		filepath.Join(r.basePath, "partials/footer.html"),
+	)
+
+	// Error pages rendered by the central error handler (public.ErrorHandler):
+	// 404 and every other status for full pages, and a fragment for HTMX swaps
+	for _, page := range []string{"public/pages/404.html", "public/pages/500.html"} {
+		jobs.add(page,
+			filepath.Join(r.basePath, "public/layouts/base.html"),
+			filepath.Join(r.basePath, page),
+			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
+			filepath.Join(r.basePath, "partials/footer.html"),
+		)
+	}
+	jobs.add("public/partials/error.html",
+		filepath.Join(r.basePath, "public/partials/error.html"),
+	)
+
+	// Maintenance page served by middleware.Maintenance; defines its own
+	// header and footer without navigation, since every other page is closed
+	jobs.add("public/pages/maintenance.html",
+		filepath.Join(r.basePath, "public/layouts/base.html"),
+		filepath.Join(r.basePath, "public/pages/maintenance.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+	)
+
+	// Campaign landing pages (Admin > Landing Pages) in the site layout and in
+	// the minimal layout, whose file defines a header and footer without
+	// navigation
+	jobs.add("public/pages/landing_page.html",
+		filepath.Join(r.basePath, "public/layouts/base.html"),
+		filepath.Join(r.basePath, "public/pages/landing_page.html"),
+		filepath.Join(r.basePath, "partials/header.html"),
+		filepath.Join(r.basePath, "partials/header-nav.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "partials/language-switcher.html"),
+		filepath.Join(r.basePath, "partials/footer.html"),
+		filepath.Join(r.basePath, "public/partials/custom_form.html"),
+	)
+	jobs.add("public/pages/landing_page_minimal.html",
+		filepath.Join(r.basePath, "public/layouts/base.html"),
+		filepath.Join(r.basePath, "public/pages/landing_page.html"),
+		filepath.Join(r.basePath, "public/pages/landing_page_minimal.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "public/partials/custom_form.html"),
+	)
+
+	// Forms built under Admin > Forms: the stand-alone page at /forms/<slug>
+	// and the form alone, which the submit endpoint sends back for HTMX to
+	// swap in (public/partials/custom_form.html, also embedded in landing
+	// pages)
+	jobs.add("public/pages/form.html",
+		filepath.Join(r.basePath, "public/layouts/base.html"),
+		filepath.Join(r.basePath, "public/pages/form.html"),
+		filepath.Join(r.basePath, "partials/header.html"),
+		filepath.Join(r.basePath, "partials/header-nav.html"),
+		filepath.Join(r.basePath, "partials/content-blocks.html"),
+		filepath.Join(r.basePath, "partials/language-switcher.html"),
 		filepath.Join(r.basePath, "partials/footer.html"),
-	))
+		filepath.Join(r.basePath, "public/partials/custom_form.html"),
+	)
+	jobs.addSource("public/partials/custom_form.html", `{{template "custom-form" .}}`,
+		filepath.Join(r.basePath, "public/partials/custom_form.html"),
+	)
+
+	// Customer account pages (sign-in, sign-up and its confirmation,
+	// password reset and the /account library) and the newsletter
+	// confirmation/unsubscribe page, plus the one-click whitepaper download
+	// a signed-in customer's whitepaper page loads in place of the lead form
+	publicAccountPages := []string{
+		"account", "account_login", "account_register", "account_confirm", "account_forgot", "account_reset",
+		"newsletter",
+	}
+	for _, page := range publicAccountPages {
+		jobs.add("public/pages/"+page+".html",
+			filepath.Join(r.basePath, "public/layouts/base.html"),
+			filepath.Join(r.basePath, "public/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/header.html"),
+			filepath.Join(r.basePath, "partials/header-nav.html"),
+			filepath.Join(r.basePath, "partials/content-blocks.html"),
+			filepath.Join(r.basePath, "partials/language-switcher.html"),
+			filepath.Join(r.basePath, "partials/footer.html"),
+		)
+	}
+	jobs.add("public/partials/account_whitepaper.html",
+		filepath.Join(r.basePath, "public/partials/account_whitepaper.html"),
+	)
 
 	// Phase 9: Search suggestions partial (HTMX fragment - standalone, no layout)
 	// Autocomplete suggestions shown while user types in search box (hx-get on input).
 	// Returns filtered results without page reload for instant search experience.
-	r.templates["public/partials/search_suggestions.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("public/partials/search_suggestions.html",
 		filepath.Join(r.basePath, "public/partials/search_suggestions.html"),
-	))
+	)
 
 	// Phase 7: Admin about and partners pages
 	// Uses: admin/layouts/base.html (admin panel structure)
@@ -539,29 +1054,174 @@ func (r *Renderer) loadTemplates() {
 		"about_settings",
 	}
 	for _, page := range aboutAdminPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+		)
 	}
 
 	// Phase 18: Media library page
 	// Uses: admin/layouts/base.html (admin panel structure)
 	// Includes: partials/admin-sidebar.html (admin navigation)
 	// Content: Centralized media management with upload, organize, search, embed
-	r.templates["admin/pages/media_library.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("admin/pages/media_library.html",
 		filepath.Join(r.basePath, "admin/layouts/base.html"),
 		filepath.Join(r.basePath, "admin/pages/media_library.html"),
 		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-	))
+	)
+
+	// Bulk media import: ZIP or server folder form, then a self-polling status
+	// and report page for the background import
+	jobs.add("admin/pages/media_import.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/media_import.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Background exports: recent exports, then a self-polling status page
+	// with the download link once the export is ready
+	jobs.add("admin/pages/exports.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/exports.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Backups: snapshot download, stored backups, and the restore
+	// confirmation step
+	jobs.add("admin/pages/backups.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/backups.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Scheduled jobs: schedules, last runs and "run now"; polls itself while
+	// a job is running
+	jobs.add("admin/pages/jobs.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/jobs.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Redirect manager: automatic rules from changed slugs, manual rules and
+	// wildcard patterns with hit counts
+	jobs.add("admin/pages/redirects.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/redirects.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Admin access: networks the admin panel may be reached from
+	jobs.add("admin/pages/admin_access.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/admin_access.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// HTTPS domains: host names of the built-in HTTPS server
+	jobs.add("admin/pages/https_domains.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/https_domains.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Webhooks: endpoints notified when public content changes
+	jobs.add("admin/pages/webhooks.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/webhooks.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Accessibility: images without alt text and headings out of order
+	jobs.add("admin/pages/accessibility.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/accessibility.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Link check: broken internal links found by the nightly run
+	jobs.add("admin/pages/link_check.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/link_check.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Content bundles: export selection, then the upload and conflict
+	// review steps of the import
+	jobs.add("admin/pages/content_export.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/content_export.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+	jobs.add("admin/pages/content_import.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/content_import.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// SEO manager: per-page metadata overrides and their form
+	jobs.add("admin/pages/seo.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/seo.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+	jobs.add("admin/pages/seo_form.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/seo_form.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Content blocks: the block library, the block form and the ordered
+	// blocks of one page
+	for _, page := range []string{"content_blocks", "content_block_form", "content_block_page"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Landing pages: the page list, the page form with its sections and the
+	// section form
+	for _, page := range []string{"landing_pages", "landing_page_form", "landing_section_form"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Form builder: the form list, the form settings with its fields, the
+	// field form and a form's submissions
+	for _, page := range []string{"forms", "form_form", "form_field_form", "form_submissions"} {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// 404 report: missing public paths with their referrers and shortcuts
+	// to the redirect manager
+	jobs.add("admin/pages/not_found.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/not_found.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// First-party analytics: page views, trend and top lists
+	jobs.add("admin/pages/analytics.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/analytics.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
 
 	// Phase 18: Media picker partial (HTMX fragment - standalone, no layout)
 	// Modal overlay for selecting media from library in forms (hx-get on media button click).
 	// Allows browsing, searching, and selecting images/files without page navigation.
-	r.templates["admin/partials/media_picker.html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+	jobs.add("admin/partials/media_picker.html",
 		filepath.Join(r.basePath, "admin/partials/media_picker.html"),
-	))
+	)
 
 	// Phase 19: Navigation editor pages
 	// Uses: admin/layouts/base.html (admin panel structure)
@@ -573,12 +1233,134 @@ func (r *Renderer) loadTemplates() {
 		"navigation_list", "navigation_editor",
 	}
 	for _, page := range navigationPages {
-		r.templates["admin/pages/"+page+".html"] = template.Must(template.New("base").Funcs(funcMap).ParseFiles(
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Phase 20: Activity log page
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Content: Audit trail with user/type/action/date filters and CSV export
+	jobs.add("admin/pages/activity_log.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/activity_log.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Trash page for soft-deleted content
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Content: Trashed products, posts, solutions and case studies with restore/delete actions
+	jobs.add("admin/pages/trash.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/trash.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Review queue for the publishing workflow
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Content: Blog posts and products waiting for review or publishing
+	jobs.add("admin/pages/review_queue.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/review_queue.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Review panel (HTMX fragment - standalone, no layout)
+	// Loaded into the blog post and product edit pages; state, reviewer,
+	// transition buttons and history.
+	jobs.add("admin/partials/workflow_panel.html",
+		filepath.Join(r.basePath, "admin/partials/workflow_panel.html"),
+	)
+
+	// Edit lock notice (HTMX fragment - standalone, no layout)
+	// Returned by the edit form heartbeat; says who else has the item open,
+	// empty while the current user holds the lock.
+	jobs.add("admin/partials/edit_lock.html",
+		filepath.Join(r.basePath, "admin/partials/edit_lock.html"),
+	)
+
+	// Command palette results (HTMX fragment - standalone, no layout)
+	// Content and settings matches with links to their edit pages, swapped
+	// into the Ctrl+K palette of the admin sidebar.
+	jobs.add("admin/partials/search_results.html",
+		filepath.Join(r.basePath, "admin/partials/search_results.html"),
+	)
+
+	// Publish checklist (HTMX fragment - standalone, no layout)
+	// Re-run from the solution, case study and whitepaper edit forms.
+	jobs.add("admin/partials/publish_checklist.html",
+		filepath.Join(r.basePath, "admin/partials/publish_checklist.html"),
+		filepath.Join(r.basePath, "partials/publish-checklist.html"),
+	)
+
+	// Share preview (HTMX fragment - standalone, no layout)
+	// Google, X and LinkedIn previews on the product, blog post and
+	// whitepaper edit forms, re-rendered as the SEO fields change.
+	jobs.add("admin/partials/share_preview.html",
+		filepath.Join(r.basePath, "admin/partials/share_preview.html"),
+	)
+
+	// Translation workflow pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - translations_dashboard.html: Coverage per locale and per-entity translation status
+	//   - translations_form.html: Side-by-side source and translated fields editor, with language tabs
+	//   - languages.html: Site locales (add, rename, reorder, enable, disable)
+	translationPages := []string{
+		"translations_dashboard", "translations_form", "languages",
+	}
+	for _, page := range translationPages {
+		jobs.add("admin/pages/"+page+".html",
 			filepath.Join(r.basePath, "admin/layouts/base.html"),
 			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
 			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
-		))
+			filepath.Join(r.basePath, "partials/language-tabs.html"),
+		)
 	}
+
+	// No-script fallback page for HTMX sub-resource editors
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Content: A tab fragment (specs, variants, solution stats...) rendered by
+	// admin.HTMXFallback for browsers without JavaScript
+	jobs.add("admin/pages/htmx_fallback.html",
+		filepath.Join(r.basePath, "admin/layouts/base.html"),
+		filepath.Join(r.basePath, "admin/pages/htmx_fallback.html"),
+		filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+	)
+
+	// Profile pages
+	// Uses: admin/layouts/base.html (admin panel structure)
+	// Includes: partials/admin-sidebar.html (admin navigation)
+	// Templates:
+	//   - profile_sessions.html: Current user's signed-in devices with revoke / log out everywhere
+	profilePages := []string{
+		"profile_sessions",
+	}
+	for _, page := range profilePages {
+		jobs.add("admin/pages/"+page+".html",
+			filepath.Join(r.basePath, "admin/layouts/base.html"),
+			filepath.Join(r.basePath, "admin/pages/"+page+".html"),
+			filepath.Join(r.basePath, "partials/admin-sidebar.html"),
+		)
+	}
+
+	// Every page with the admin layout gets the flash message partial the
+	// layout renders, so the page lists above do not repeat it.
+	adminLayout := filepath.Join(r.basePath, "admin/layouts/base.html")
+	for i, job := range jobs.list {
+		if slices.Contains(job.files, adminLayout) {
+			jobs.list[i].files = append(job.files, filepath.Join(r.basePath, "admin/partials/flash.html"))
+		}
+	}
+
+	return r.compile(jobs, funcMap)
 }
 
 // safeHTML marks a string as safe HTML content, bypassing Go's auto-escaping.
@@ -592,14 +1374,18 @@ func (r *Renderer) loadTemplates() {
 //   - template.HTML: Marked-safe HTML that won't be escaped in templates
 //
 // SECURITY WARNING: Only use with trusted content. User input passed through
-// this function without sanitization creates XSS vulnerabilities.
+// this function without sanitization creates XSS vulnerabilities. Run with
+// SAFEHTML_AUDIT=true to see which fields still hold unsanitized HTML
+// (see SafeHTMLAudit).
 //
 // Usage in templates: {{.Content | safeHTML}}
 func safeHTML(s string) template.HTML {
 	return template.HTML(s)
 }
 
-// formatDate converts a time.Time value to a human-readable date string.
+// formatDate converts a time.Time value to a human-readable date string in
+// the site timezone (Global Settings). Timestamps are stored in UTC, so
+// formatting them directly would show UTC wall clocks.
 // Supports custom format strings using Go's time formatting syntax.
 //
 // Parameters:
@@ -622,11 +1408,27 @@ func formatDate(t time.Time, format string) string {
 	if format == "" {
 		format = "January 2, 2006"
 	}
-	return t.Format(format)
+	return services.InSiteTimezone(t).Format(format)
+}
+
+// formatDateIn is formatDate in the named IANA zone, falling back to the
+// site timezone when zone is blank or unknown. Pass .VisitorTimezone to show
+// a time in the zone guessed from the visitor's Accept-Language header.
+//
+// Usage in templates: {{formatDateIn .CreatedAt "Jan 2, 2006 15:04 MST" .VisitorTimezone}}
+func formatDateIn(t time.Time, format, zone string) string {
+	loc, err := services.LoadTimezone(zone)
+	if err != nil {
+		return formatDate(t, format)
+	}
+	if format == "" {
+		format = "January 2, 2006"
+	}
+	return t.In(loc).Format(format)
 }
 
 // truncate shortens a string to a maximum length, appending "..." if truncated.
-// Used for excerpt generation, preview text, and card descriptions.
+// Used for preview text and table cells; card excerpts use truncateWords.
 //
 // Parameters:
 //   - s: String to potentially truncate
@@ -635,39 +1437,62 @@ func formatDate(t time.Time, format string) string {
 // Returns:
 //   - string: Original string if <= length, otherwise truncated with "..." suffix
 //
-// Note: Truncates at exact character count, not word boundaries.
-// For word-boundary truncation, implement custom logic in handlers.
+// Note: Length counts characters (runes), not bytes, so multi-byte
+// characters such as "é" or "€" are never split. The cut may fall inside
+// a word; see truncateWords.
 //
-// Usage in templates: {{.Description | truncate 100}}
+// Usage in templates: {{truncate .Description 100}}
 func truncate(s string, length int) string {
-	if len(s) <= length {
+	if utf8.RuneCountInString(s) <= length {
 		return s
 	}
-	return s[:length] + "..."
+	r := []rune(s)
+	return strings.TrimRightFunc(string(r[:max(length, 0)]), unicode.IsSpace) + "..."
 }
 
-// slugify converts a string to a URL-safe slug format.
-// Converts to lowercase and replaces spaces with hyphens.
+// truncateWords is truncate at a word boundary: the text is cut after the
+// last whole word that fits, and trailing punctuation such as a comma is
+// dropped before the "...". A first word longer than length is cut inside
+// the word rather than leaving nothing.
 //
 // Parameters:
-//   - s: String to convert (e.g., "Product Name")
+//   - s: String to potentially truncate
+//   - length: Maximum length in characters (excluding ellipsis)
 //
-// Returns:
-//   - string: URL-safe slug (e.g., "product-name")
+// Usage in templates: {{truncateWords .Excerpt 150}}
+func truncateWords(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	if length <= 0 {
+		return "..."
+	}
+	r := []rune(s)
+	cut := length
+	// Back off to a word boundary unless the cut already falls on one
+	if !unicode.IsSpace(r[cut]) {
+		for i := cut; i > 0; i-- {
+			if unicode.IsSpace(r[i-1]) {
+				cut = i - 1
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(r[:cut]), func(c rune) bool {
+		return unicode.IsSpace(c) || strings.ContainsRune(",;:-–—", c)
+	}) + "..."
+}
+
+// slugify converts a string to a URL-safe slug format, the same way slugs
+// are generated when content is saved (see services.Slugify).
 //
-// Current limitations:
-//   - Only handles spaces, not other special characters
-//   - No Unicode normalization (accented characters not simplified)
-//   - No duplicate hyphen removal
+// Parameters:
+//   - s: String to convert (e.g., "Café Crème")
 //
-// For production, consider using a dedicated slugify library that handles:
-//   - Special characters (punctuation, symbols)
-//   - Unicode transliteration (é -> e, ñ -> n)
-//   - Duplicate separator removal
+// Returns:
+//   - string: URL-safe slug (e.g., "cafe-creme")
 //
 // Usage in templates: {{.Title | slugify}}
 func slugify(s string) string {
-	s = strings.ToLower(s)
-	s = strings.ReplaceAll(s, " ", "-")
-	return s
+	return services.Slugify(s)
 }
@@ -0,0 +1,131 @@
+// Package tracing sets up OpenTelemetry tracing for the server. Spans cover
+// the request path end to end: the Tracing middleware starts a server span
+// per request, ProductService and the template renderer add child spans, and
+// database.TracingDB records one span per sqlc query. Tracing is off unless
+// an exporter is configured through the standard OTEL_* environment
+// variables, in which case the global no-op provider keeps every span free.
+package tracing
+
+import (
+	"context" // Exporter setup and span contexts
+	"fmt"     // Error wrapping
+	"os"      // Environment lookups and console exporter output
+
+	"go.opentelemetry.io/otel"                                        // Global tracer provider and propagator
+	"go.opentelemetry.io/otel/attribute"                              // Span attributes
+	"go.opentelemetry.io/otel/codes"                                  // Span error status
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp" // OTLP/HTTP exporter
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"           // Console exporter for local debugging
+	"go.opentelemetry.io/otel/propagation"                            // W3C traceparent and baggage headers
+	"go.opentelemetry.io/otel/sdk/resource"                           // service.name and SDK attributes
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"                     // Tracer provider and batching
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"                // Standard attribute keys
+	"go.opentelemetry.io/otel/trace"                                  // Span and tracer types
+)
+
+// scope is the instrumentation scope name of the app's spans.
+const scope = "github.com/narendhupati/bluejay-cms"
+
+// defaultServiceName is reported as service.name unless OTEL_SERVICE_NAME
+// or OTEL_RESOURCE_ATTRIBUTES sets another.
+const defaultServiceName = "bluejay-cms"
+
+// Exporter names accepted in OTEL_TRACES_EXPORTER.
+const (
+	ExporterNone    = "none"    // Tracing off (the default without an OTLP endpoint)
+	ExporterOTLP    = "otlp"    // OTLP over HTTP, configured by OTEL_EXPORTER_OTLP_*
+	ExporterConsole = "console" // Spans printed as JSON to stderr
+)
+
+// Config selects the span exporter.
+type Config struct {
+	Exporter string // ExporterNone, ExporterOTLP or ExporterConsole
+}
+
+// ConfigFromEnv reads OTEL_TRACES_EXPORTER. When it is unset, spans are sent
+// over OTLP if OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set, and tracing is off otherwise.
+// Endpoint, headers, timeout, sampler (OTEL_TRACES_SAMPLER and
+// OTEL_TRACES_SAMPLER_ARG) and resource attributes are read by the
+// OpenTelemetry SDK itself.
+func ConfigFromEnv() Config {
+	if v := os.Getenv("OTEL_TRACES_EXPORTER"); v != "" {
+		return Config{Exporter: v}
+	}
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		return Config{Exporter: ExporterOTLP}
+	}
+	return Config{Exporter: ExporterNone}
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator for cfg. The returned function flushes buffered spans and stops
+// the exporter; call it on shutdown. With ExporterNone nothing is installed
+// and shutdown is a no-op.
+//
+// Example usage:
+//
+//	shutdown, err := tracing.Setup(ctx, tracing.ConfigFromEnv())
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer shutdown(context.Background())
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExporterConsole:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q (use %s, %s or %s)", cfg.Exporter, ExporterOTLP, ExporterConsole, ExporterNone)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	// Later options win, so the environment overrides the default name
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(defaultServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the app's tracer from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(scope)
+}
+
+// Start starts a child span of the span in ctx.
+//
+// Example usage:
+//
+//	ctx, span := tracing.Start(ctx, "ProductService.GetProductDetail", attribute.String("product.slug", slug))
+//	defer span.End()
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordError marks span as failed with err. A nil err is ignored.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,43 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/narendhupati/bluejay-cms/internal/tracing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	cases := []struct {
+		exporter, endpoint, tracesEndpoint string
+		want                               string
+	}{
+		{"", "", "", tracing.ExporterNone},
+		{"", "http://collector:4318", "", tracing.ExporterOTLP},
+		{"", "", "http://collector:4318/v1/traces", tracing.ExporterOTLP},
+		{"console", "http://collector:4318", "", tracing.ExporterConsole},
+		{"none", "http://collector:4318", "", tracing.ExporterNone},
+	}
+	for _, tc := range cases {
+		t.Setenv("OTEL_TRACES_EXPORTER", tc.exporter)
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tc.endpoint)
+		t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", tc.tracesEndpoint)
+		if got := tracing.ConfigFromEnv().Exporter; got != tc.want {
+			t.Errorf("exporter=%q endpoint=%q traces endpoint=%q: got %q, want %q", tc.exporter, tc.endpoint, tc.tracesEndpoint, got, tc.want)
+		}
+	}
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone})
+	if err != nil {
+		t.Fatalf("Setup(none): %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+
+	if _, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"}); err == nil {
+		t.Error("Setup accepted an unknown exporter")
+	}
+}
@@ -0,0 +1,17 @@
+/* ============================================
+   Bluejay CMS — Admin Edit Lock JS
+   ============================================
+   #edit-lock on an edit form claims the item with hx-post on load and every
+   30 seconds. When the page is left (saved, navigated away or closed) the
+   lock is released right away with a beacon, so the next admin is not told
+   the item is being edited until the lock expires. */
+
+(function() {
+    'use strict';
+
+    window.addEventListener('pagehide', function() {
+        var el = document.getElementById('edit-lock');
+        var url = el && el.getAttribute('data-release');
+        if (url && navigator.sendBeacon) navigator.sendBeacon(url);
+    });
+})();
@@ -0,0 +1,98 @@
+/* ============================================
+   Bluejay CMS — Admin Flash Messages JS
+   ============================================
+   Flash messages queued by a form post are rendered into #admin-toasts by
+   the admin layout. HTMX responses raise the same toasts with an HX-Trigger
+   header: {"showToast": {"messages": [{"level": ..., "message": ...}]}}.
+   Success toasts fade after a few seconds; errors and warnings stay until
+   dismissed with their close button or Esc. Toasts never take focus. */
+
+(function() {
+    'use strict';
+
+    var SUCCESS_TIMEOUT = 6000;
+    var ICONS = { success: 'check_circle', warning: 'warning', error: 'error' };
+    var COLORS = {
+        success: 'bg-green-100 text-green-900',
+        warning: 'bg-yellow-100 text-yellow-900',
+        error: 'bg-red-100 text-red-900'
+    };
+
+    function container() { return document.getElementById('admin-toasts'); }
+
+    function toasts() {
+        var c = container();
+        return c ? Array.prototype.slice.call(c.querySelectorAll('.admin-toast')) : [];
+    }
+
+    function dismiss(toast) {
+        if (!toast || !toast.parentNode) return;
+        // Keep keyboard users in the page when the focused close button goes away
+        if (toast.contains(document.activeElement)) document.activeElement.blur();
+        toast.parentNode.removeChild(toast);
+    }
+
+    function autoDismiss(toast) {
+        if (toast.getAttribute('data-level') === 'success') {
+            setTimeout(function() { dismiss(toast); }, SUCCESS_TIMEOUT);
+        }
+    }
+
+    // Build a toast like the admin-toast template, with the message as text
+    function show(level, message) {
+        var c = container();
+        if (!c || !message) return;
+        if (!ICONS[level]) level = 'success';
+        var toast = document.createElement('div');
+        toast.className = 'admin-toast admin-toast-' + level + ' flex items-start gap-3 border-2 border-black px-4 py-3 text-sm font-bold ' + COLORS[level];
+        toast.style.boxShadow = '4px 4px 0px #000';
+        toast.setAttribute('data-level', level);
+        toast.setAttribute('role', level === 'error' ? 'alert' : 'status');
+
+        var icon = document.createElement('span');
+        icon.className = 'material-symbols-outlined text-lg';
+        icon.setAttribute('aria-hidden', 'true');
+        icon.textContent = ICONS[level];
+
+        var text = document.createElement('p');
+        text.className = 'flex-1';
+        text.textContent = message;
+
+        var close = document.createElement('button');
+        close.type = 'button';
+        close.className = 'admin-toast-close text-current hover:opacity-70 focus:outline-none focus:ring-2 focus:ring-black';
+        close.setAttribute('aria-label', 'Dismiss notification');
+        close.title = 'Dismiss (Esc)';
+        close.innerHTML = '<span class="material-symbols-outlined text-lg" aria-hidden="true">close</span>';
+
+        toast.appendChild(icon);
+        toast.appendChild(text);
+        toast.appendChild(close);
+        c.appendChild(toast);
+        autoDismiss(toast);
+    }
+
+    window.showAdminToast = show;
+
+    document.addEventListener('click', function(e) {
+        var close = e.target.closest && e.target.closest('.admin-toast-close');
+        if (close) dismiss(close.closest('.admin-toast'));
+    });
+
+    // Esc dismisses the newest toast, unless a dialog such as the command
+    // palette is open and uses Esc itself
+    document.addEventListener('keydown', function(e) {
+        if (e.key !== 'Escape') return;
+        var palette = document.getElementById('command-palette');
+        if (palette && !palette.classList.contains('hidden')) return;
+        var list = toasts();
+        if (list.length) dismiss(list[list.length - 1]);
+    });
+
+    document.body.addEventListener('showToast', function(e) {
+        var messages = (e.detail && e.detail.messages) || [];
+        messages.forEach(function(m) { show(m.level, m.message); });
+    });
+
+    toasts().forEach(autoDismiss);
+})();
@@ -0,0 +1,75 @@
+/* ============================================
+   Bluejay CMS — Admin Command Palette JS
+   ============================================
+   Ctrl+K (Cmd+K on macOS) opens a search box over any admin page. Typing
+   asks GET /admin/search for matching content and settings screens (via
+   HTMX); the arrow keys move between the matches and Enter opens one. */
+
+(function() {
+    'use strict';
+
+    function palette() { return document.getElementById('command-palette'); }
+    function input() { return document.getElementById('command-palette-input'); }
+    function results() {
+        return Array.prototype.slice.call(document.querySelectorAll('#command-palette-results .admin-search-result'));
+    }
+
+    window.openCommandPalette = function() {
+        var p = palette();
+        if (!p) return;
+        p.classList.remove('hidden');
+        p.classList.add('flex');
+        var box = input();
+        box.select();
+        box.focus();
+        // Show the hint, or refresh stale matches, when reopening
+        if (window.htmx) htmx.trigger(box, 'search');
+    };
+
+    window.closeCommandPalette = function() {
+        var p = palette();
+        if (!p) return;
+        p.classList.add('hidden');
+        p.classList.remove('flex');
+    };
+
+    function isOpen() {
+        var p = palette();
+        return p && !p.classList.contains('hidden');
+    }
+
+    // Move the highlighted match by step (1 down, -1 up)
+    function move(step) {
+        var items = results();
+        if (!items.length) return;
+        var current = items.findIndex(function(a) { return a.classList.contains('active'); });
+        var next = (current + step + items.length) % items.length;
+        items.forEach(function(a) { a.classList.remove('active', 'bg-gray-100'); });
+        items[next].classList.add('active', 'bg-gray-100');
+        items[next].scrollIntoView({ block: 'nearest' });
+    }
+
+    document.addEventListener('keydown', function(e) {
+        if ((e.ctrlKey || e.metaKey) && (e.key === 'k' || e.key === 'K')) {
+            e.preventDefault();
+            if (isOpen()) {
+                window.closeCommandPalette();
+            } else {
+                window.openCommandPalette();
+            }
+            return;
+        }
+        if (!isOpen()) return;
+        if (e.key === 'Escape') {
+            e.preventDefault();
+            window.closeCommandPalette();
+        } else if (e.key === 'ArrowDown' || e.key === 'ArrowUp') {
+            e.preventDefault();
+            move(e.key === 'ArrowDown' ? 1 : -1);
+        } else if (e.key === 'Enter' && e.target === input()) {
+            e.preventDefault();
+            var active = document.querySelector('#command-palette-results .admin-search-result.active');
+            if (active) window.location.href = active.getAttribute('href');
+        }
+    });
+})();
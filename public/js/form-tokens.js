@@ -0,0 +1,108 @@
+/* ============================================
+   Bluejay CMS — Admin Form Tokens JS
+   ============================================
+   Keeps the one-time form_token of POST forms fresh. Forms are rendered
+   with a token ({{formToken}}) and the server accepts each token once
+   (middleware.FormTokens), so a double-click, a refresh that re-posts, or
+   "Resubmit" after going back cannot create a record twice. This script
+   adds a token to forms rendered without one.
+   - Full-page forms also disable their submit buttons while submitting.
+   - HTMX forms get a fresh token after each successful request, so a form
+     that stays on the page can be used again. */
+
+(function() {
+    'use strict';
+
+    var FIELD = 'form_token';
+
+    function newToken() {
+        var bytes = new Uint8Array(16);
+        window.crypto.getRandomValues(bytes);
+        return Array.prototype.map.call(bytes, function(b) {
+            return ('0' + b.toString(16)).slice(-2);
+        }).join('');
+    }
+
+    function isPostForm(form) {
+        return form.hasAttribute('hx-post') || (form.getAttribute('method') || '').toLowerCase() === 'post';
+    }
+
+    // Set a token on form, replacing any earlier one when renew is true
+    function stampForm(form, renew) {
+        if (!isPostForm(form)) return;
+        var input = form.querySelector('input[name="' + FIELD + '"]');
+        if (!input) {
+            input = document.createElement('input');
+            input.type = 'hidden';
+            input.name = FIELD;
+            form.appendChild(input);
+        }
+        if (renew || !input.value) {
+            input.value = newToken();
+        }
+    }
+
+    function stampAll(root, renew) {
+        if (root.tagName === 'FORM') stampForm(root, renew);
+        var forms = root.querySelectorAll ? root.querySelectorAll('form') : [];
+        for (var i = 0; i < forms.length; i++) {
+            stampForm(forms[i], renew);
+        }
+    }
+
+    function init() {
+        stampAll(document, false);
+
+        // Forms added by HTMX swaps
+        document.body.addEventListener('htmx:load', function(evt) {
+            stampAll(evt.detail.elt, false);
+        });
+
+        // A form still on the page after a successful request may be sent again
+        document.body.addEventListener('htmx:afterRequest', function(evt) {
+            var form = evt.detail.elt.closest && evt.detail.elt.closest('form');
+            if (evt.detail.successful && form && document.body.contains(form)) {
+                stampForm(form, true);
+            }
+        });
+
+        // Full-page forms: one click only. Disable after the browser has
+        // collected the form data, so the clicked button's value is sent.
+        document.addEventListener('submit', function(evt) {
+            var form = evt.target;
+            if (evt.defaultPrevented || form.hasAttribute('hx-post') || !isPostForm(form)) return;
+            setTimeout(function() {
+                var buttons = form.querySelectorAll('button[type="submit"], button:not([type]), input[type="submit"]');
+                for (var i = 0; i < buttons.length; i++) {
+                    if (buttons[i].disabled) continue;
+                    buttons[i].disabled = true;
+                    buttons[i].setAttribute('data-submitting', '');
+                }
+            }, 0);
+            // Still here (e.g. the response was a download): allow a new submission
+            setTimeout(function() { release(form); }, 8000);
+        });
+    }
+
+    // Re-enable root's submit buttons with new tokens, as a new submission
+    function release(root) {
+        stampAll(root, true);
+        var buttons = root.querySelectorAll('[data-submitting]');
+        for (var i = 0; i < buttons.length; i++) {
+            buttons[i].disabled = false;
+            buttons[i].removeAttribute('data-submitting');
+        }
+    }
+
+    // Restored from the back/forward cache: the page is live again, so new
+    // edits must not be mistaken for the earlier submission
+    window.addEventListener('pageshow', function(evt) {
+        if (evt.persisted) release(document);
+    });
+
+    if (document.readyState === 'loading') {
+        document.addEventListener('DOMContentLoaded', init);
+    } else {
+        init();
+    }
+})();
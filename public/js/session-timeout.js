@@ -0,0 +1,132 @@
+/* ============================================
+   Bluejay CMS — Admin Session Timeout JS
+   ============================================
+   The server signs out sessions after an idle timeout and after an
+   absolute lifetime (SESSION_IDLE_TIMEOUT_MINUTES, SESSION_MAX_LIFETIME_HOURS).
+   This script mirrors those deadlines in the page:
+   - editors who are typing get silent keep-alives, so a long edit
+     with no saves does not time out;
+   - a banner warns shortly before either deadline;
+   - at the deadline the browser goes to the login page. */
+
+(function() {
+    'use strict';
+
+    var KEEPALIVE_URL = '/admin/session/keepalive';
+    var LOGIN_URL = '/admin/login?error=session_expired';
+    var IDLE_WARNING_MS = 2 * 60 * 1000;     // Warn 2 minutes before idle sign-out
+    var ABSOLUTE_WARNING_MS = 10 * 60 * 1000; // Warn 10 minutes before the lifetime ends
+
+    var idleMs = 0;           // Idle timeout; 0 when disabled
+    var idleDeadline = 0;     // Timestamps in ms; 0 when disabled
+    var absoluteDeadline = 0;
+    var lastActivity = 0;     // Last keystroke, click, or scroll
+    var lastRequest = 0;      // Last request that restarted the idle timeout
+    var banner = null;
+
+    // Ask the server for the deadlines; the request itself restarts the idle timeout
+    function keepAlive() {
+        lastRequest = Date.now();
+        return fetch(KEEPALIVE_URL, {
+            method: 'POST',
+            credentials: 'same-origin',
+            headers: { 'Accept': 'application/json' }
+        }).then(function(res) {
+            // Signed out sessions are redirected to the login page
+            if (!res.ok || res.redirected) {
+                expire();
+                return;
+            }
+            return res.json().then(function(data) {
+                var now = Date.now();
+                idleMs = data.idle_remaining * 1000;
+                idleDeadline = idleMs > 0 ? now + idleMs : 0;
+                absoluteDeadline = data.absolute_remaining > 0 ? now + data.absolute_remaining * 1000 : 0;
+                update();
+            });
+        }).catch(function() {});
+    }
+
+    function expire() {
+        window.location.href = LOGIN_URL;
+    }
+
+    function formatRemaining(ms) {
+        var s = Math.max(0, Math.ceil(ms / 1000));
+        var m = Math.floor(s / 60);
+        s = s % 60;
+        return m + ':' + (s < 10 ? '0' : '') + s;
+    }
+
+    function showBanner(message, canExtend) {
+        if (!banner) {
+            banner = document.createElement('div');
+            banner.setAttribute('role', 'alert');
+            banner.className = 'fixed bottom-4 right-4 z-50 max-w-sm bg-yellow-300 border-2 border-black p-4 font-mono text-sm';
+            banner.style.boxShadow = '4px 4px 0px #000';
+            banner.innerHTML = '<p class="font-bold" data-session-message></p>' +
+                '<button type="button" data-session-extend class="mt-3 bg-black text-white px-4 py-2 text-xs font-bold uppercase tracking-wider border-2 border-black hover:bg-white hover:text-black">Stay signed in</button>';
+            banner.querySelector('[data-session-extend]').addEventListener('click', keepAlive);
+            document.body.appendChild(banner);
+        }
+        banner.querySelector('[data-session-message]').textContent = message;
+        banner.querySelector('[data-session-extend]').style.display = canExtend ? '' : 'none';
+    }
+
+    function hideBanner() {
+        if (banner) {
+            banner.parentNode.removeChild(banner);
+            banner = null;
+        }
+    }
+
+    // Runs every second: keep active editors signed in, then warn or expire
+    function update() {
+        var now = Date.now();
+
+        // Silent keep-alive once an active editor is past half the idle timeout
+        if (idleDeadline && lastActivity > lastRequest && idleDeadline - now < idleMs / 2) {
+            keepAlive();
+            return;
+        }
+
+        var idleLeft = idleDeadline ? idleDeadline - now : Infinity;
+        var absoluteLeft = absoluteDeadline ? absoluteDeadline - now : Infinity;
+        if (idleLeft <= 0 || absoluteLeft <= 0) {
+            expire();
+        } else if (absoluteLeft <= ABSOLUTE_WARNING_MS && absoluteLeft <= idleLeft) {
+            showBanner('Your session ends in ' + formatRemaining(absoluteLeft) + '. Save your work; you will need to sign in again.', false);
+        } else if (idleLeft <= IDLE_WARNING_MS) {
+            showBanner('You will be signed out in ' + formatRemaining(idleLeft) + ' due to inactivity.', true);
+        } else {
+            hideBanner();
+        }
+    }
+
+    function markActive() {
+        lastActivity = Date.now();
+    }
+
+    function init() {
+        ['keydown', 'input', 'mousedown', 'scroll', 'touchstart'].forEach(function(type) {
+            document.addEventListener(type, markActive, { passive: true, capture: true });
+        });
+        // Every successful HTMX request restarts the idle timeout on the
+        // server, except background polls marked with X-Background-Request
+        document.body.addEventListener('htmx:afterRequest', function(evt) {
+            var headers = (evt.detail.requestConfig && evt.detail.requestConfig.headers) || {};
+            if (evt.detail.successful && idleMs && !headers['X-Background-Request']) {
+                lastRequest = Date.now();
+                idleDeadline = lastRequest + idleMs;
+            }
+        });
+        keepAlive();
+        setInterval(update, 1000);
+    }
+
+    if (document.readyState === 'loading') {
+        document.addEventListener('DOMContentLoaded', init);
+    } else {
+        init();
+    }
+})();
@@ -0,0 +1,64 @@
+/* ============================================
+   Bluejay CMS — Theme JS
+   ============================================
+   Loaded (without defer) in the <head> of the public and admin layouts,
+   after /theme-tokens.css.
+   - Applies the visitor's light/dark choice from the "theme" cookie as
+     data-theme on <html> before the page paints. Without a choice the
+     default mode from Global Settings applies through the stylesheet.
+   - ?theme=light or ?theme=dark previews a mode for one page view without
+     changing the saved choice (used by the admin settings page).
+   - Buttons with [data-theme-toggle] switch modes and save the choice for
+     a year. They start hidden and are shown once this script runs. */
+
+(function() {
+    'use strict';
+
+    var COOKIE = 'theme';
+    var root = document.documentElement;
+
+    function valid(mode) {
+        return mode === 'light' || mode === 'dark';
+    }
+
+    function saved() {
+        var match = document.cookie.match(/(?:^|;\s*)theme=(light|dark)/);
+        return match ? match[1] : '';
+    }
+
+    var preview = new URLSearchParams(window.location.search).get('theme');
+    var mode = valid(preview) ? preview : saved();
+    if (mode) {
+        root.setAttribute('data-theme', mode);
+    }
+
+    // The mode in effect, including the default from settings
+    function current() {
+        return window.getComputedStyle(root).colorScheme === 'dark' ? 'dark' : 'light';
+    }
+
+    function toggle() {
+        var next = current() === 'dark' ? 'light' : 'dark';
+        root.setAttribute('data-theme', next);
+        document.cookie = COOKIE + '=' + next + '; path=/; max-age=31536000; samesite=lax';
+        updateButtons();
+    }
+
+    function updateButtons() {
+        var dark = current() === 'dark';
+        document.querySelectorAll('[data-theme-toggle]').forEach(function(btn) {
+            btn.setAttribute('aria-pressed', dark ? 'true' : 'false');
+            btn.setAttribute('title', dark ? 'Switch to light mode' : 'Switch to dark mode');
+            var icon = btn.querySelector('.material-symbols-outlined');
+            if (icon) icon.textContent = dark ? 'light_mode' : 'dark_mode';
+        });
+    }
+
+    document.addEventListener('DOMContentLoaded', function() {
+        document.querySelectorAll('[data-theme-toggle]').forEach(function(btn) {
+            btn.hidden = false;
+            btn.addEventListener('click', toggle);
+        });
+        updateButtons();
+    });
+})();
@@ -0,0 +1,60 @@
+/* ============================================
+   Bluejay CMS — Trix Editor Attachments JS
+   ============================================
+   Images dropped or pasted into a Trix editor are uploaded to
+   POST /admin/editor/attachments, which adds them to the media library and
+   answers with {"url": ..., "href": ...}. Progress is shown on the
+   attachment while it uploads; a failed upload removes the attachment and
+   raises an error toast. Other files are refused before uploading. */
+
+(function() {
+    'use strict';
+
+    var ENDPOINT = '/admin/editor/attachments';
+    var MAX_SIZE = 10 * 1024 * 1024;
+    var TYPES = ['image/jpeg', 'image/png', 'image/gif', 'image/webp'];
+
+    function error(message) {
+        if (window.showAdminToast) window.showAdminToast('error', message);
+    }
+
+    document.addEventListener('trix-file-accept', function(e) {
+        if (TYPES.indexOf(e.file.type) === -1) {
+            e.preventDefault();
+            error('Only JPEG, PNG, GIF and WebP images can be attached.');
+        } else if (e.file.size > MAX_SIZE) {
+            e.preventDefault();
+            error('Images larger than 10 MB cannot be attached.');
+        }
+    });
+
+    document.addEventListener('trix-attachment-add', function(e) {
+        var attachment = e.attachment;
+        if (!attachment.file) return;
+
+        var data = new FormData();
+        data.append('file', attachment.file);
+
+        var xhr = new XMLHttpRequest();
+        xhr.open('POST', ENDPOINT, true);
+        xhr.setRequestHeader('Accept', 'application/json');
+        xhr.upload.addEventListener('progress', function(p) {
+            if (p.lengthComputable) attachment.setUploadProgress(p.loaded / p.total * 100);
+        });
+        xhr.addEventListener('load', function() {
+            var body = {};
+            try { body = JSON.parse(xhr.responseText); } catch (_) {}
+            if (xhr.status === 200 && body.url) {
+                attachment.setAttributes({ url: body.url, href: body.href });
+                return;
+            }
+            attachment.remove();
+            error(body.error || 'The image could not be uploaded.');
+        });
+        xhr.addEventListener('error', function() {
+            attachment.remove();
+            error('The image could not be uploaded.');
+        });
+        xhr.send(data);
+    });
+})();
@@ -8,7 +8,8 @@ cd "$(dirname "$0")"
 go build -o server ./cmd/server
 if [ $? -eq 0 ]; then
     echo "Starting server on port 28090..."
-    ./server
+    # Development-only default; production sets SESSION_SECRET itself
+    SESSION_SECRET="${SESSION_SECRET:-dev-only-session-secret-not-for-production}" ./server
 else
     echo "Build failed!"
     exit 1